@@ -0,0 +1,89 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/evidence"
+	"github.com/google/uuid"
+)
+
+// Access-control actions recorded in the access control log
+const (
+	AccessActionDenyIP         = "DENY_IP"
+	AccessActionRemoveDeniedIP = "REMOVE_DENIED_IP"
+)
+
+// AccessControlEvent is an entry of the access control log
+type AccessControlEvent struct {
+	ID        string    `json:"id"`
+	Action    string    `json:"action"`
+	Subject   string    `json:"subject"`
+	ActorID   string    `json:"actor_id"`
+	Details   string    `json:"details,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RecordAccessControlEvent appends an event to the access control log
+func (r *repository) RecordAccessControlEvent(ctx context.Context, event *AccessControlEvent) error {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO access_control_events (id, action, subject, actor_id, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.Action,
+		event.Subject,
+		event.ActorID,
+		event.Details,
+		event.CreatedAt,
+	)
+	return err
+}
+
+// ListAccessControlEvents retrieves the events recorded in [from, to), oldest first
+func (r *repository) ListAccessControlEvents(ctx context.Context, from, to time.Time) ([]*AccessControlEvent, error) {
+	query := `
+		SELECT id, action, subject, actor_id, COALESCE(details, ''), created_at
+		FROM access_control_events
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*AccessControlEvent{}
+	for rows.Next() {
+		event := &AccessControlEvent{}
+		if err := rows.Scan(&event.ID, &event.Action, &event.Subject, &event.ActorID, &event.Details, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// EvidenceSources returns the compliance evidence of the account service: the
+// access-control changes of the period
+func EvidenceSources(repo Repository) []evidence.Source {
+	return []evidence.Source{
+		evidence.SourceFunc{
+			SourceName: "access_control_changes",
+			Fn: func(ctx context.Context, from, to time.Time) (interface{}, error) {
+				return repo.ListAccessControlEvents(ctx, from, to)
+			},
+		},
+	}
+}
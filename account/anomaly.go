@@ -0,0 +1,341 @@
+package account
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Auth event kinds watched by the anomaly detector
+const (
+	EventLoginFailure = "login_failure"
+	EventTokenRefresh = "token_refresh"
+	EventRegistration = "registration"
+)
+
+// SecurityEventAuthAnomaly is emitted when an IP or ASN exceeds an anomaly threshold
+const SecurityEventAuthAnomaly = "auth_anomaly"
+
+// ErrRateLimited is returned when a client exceeded the rate limit of an auth endpoint
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// AnomalyStore keeps sliding-window counters and expiring flags. It is implemented
+// by cache.Counters.
+type AnomalyStore interface {
+	Hit(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error)
+	Count(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error)
+	Flag(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	IsFlagged(ctx context.Context, key string) (bool, error)
+}
+
+// AnomalyRule configures detection and rate limiting of one event kind
+type AnomalyRule struct {
+	Window time.Duration
+	// Limit is the number of events per window an IP may cause before its requests
+	// are rejected; zero disables the limit
+	Limit int64
+	// IPThreshold and ASNThreshold are the event counts per window that are
+	// considered an anomaly for a single IP or a whole ASN
+	IPThreshold  int64
+	ASNThreshold int64
+	// TightenedLimit replaces Limit while the IP or its ASN is flagged
+	TightenedLimit int64
+	// Penalty is how long a flag stays in place
+	Penalty time.Duration
+}
+
+// DefaultAnomalyRules are the rules used when none are configured
+var DefaultAnomalyRules = map[string]AnomalyRule{
+	EventLoginFailure: {
+		Window:         10 * time.Minute,
+		Limit:          30,
+		IPThreshold:    15,
+		ASNThreshold:   100,
+		TightenedLimit: 5,
+		Penalty:        30 * time.Minute,
+	},
+	EventTokenRefresh: {
+		Window:         time.Minute,
+		Limit:          60,
+		IPThreshold:    30,
+		ASNThreshold:   300,
+		TightenedLimit: 10,
+		Penalty:        15 * time.Minute,
+	},
+	EventRegistration: {
+		Window:         time.Hour,
+		Limit:          20,
+		IPThreshold:    10,
+		ASNThreshold:   50,
+		TightenedLimit: 2,
+		Penalty:        time.Hour,
+	},
+}
+
+// SecurityEvent describes a detected anomaly
+type SecurityEvent struct {
+	Type      string
+	Kind      string
+	Dimension string // "ip" or "asn"
+	Subject   string
+	Count     int64
+	Threshold int64
+	Window    time.Duration
+	At        time.Time
+}
+
+// SecurityEventSink receives security events
+type SecurityEventSink interface {
+	Emit(ctx context.Context, event SecurityEvent)
+}
+
+// logEventSink writes security events to the structured log and metrics
+type logEventSink struct {
+	log *logger.Logger
+}
+
+// NewLogEventSink creates a sink that logs security events and counts them in metrics
+func NewLogEventSink(log *logger.Logger) SecurityEventSink {
+	return &logEventSink{log: log}
+}
+
+// Emit logs the event as a warning
+func (s *logEventSink) Emit(ctx context.Context, event SecurityEvent) {
+	metrics.SecurityEventsTotal.WithLabelValues("account-service", event.Type, event.Kind).Inc()
+	s.log.Warn(ctx, "Security event", map[string]interface{}{
+		"type":      event.Type,
+		"kind":      event.Kind,
+		"dimension": event.Dimension,
+		"subject":   event.Subject,
+		"count":     event.Count,
+		"threshold": event.Threshold,
+		"window":    event.Window.String(),
+		"at":        event.At.UTC().Format(time.RFC3339),
+	})
+}
+
+// ASNResolver maps an IP address to its autonomous system number
+type ASNResolver interface {
+	ASN(ip string) string
+}
+
+// cidrASN is one entry of a CIDR table
+type cidrASN struct {
+	prefix netip.Prefix
+	asn    string
+}
+
+// CIDRASNResolver resolves ASNs from a static table of network prefixes
+type CIDRASNResolver struct {
+	entries []cidrASN
+}
+
+// ParseCIDRASNTable reads lines of the form "203.0.113.0/24 AS64500". Blank lines
+// and lines starting with # are ignored.
+func ParseCIDRASNTable(r io.Reader) (*CIDRASNResolver, error) {
+	resolver := &CIDRASNResolver{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected \"<cidr> <asn>\"", line)
+		}
+		prefix, err := netip.ParsePrefix(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		resolver.entries = append(resolver.entries, cidrASN{prefix: prefix.Masked(), asn: fields[1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return resolver, nil
+}
+
+// ASN returns the ASN of the most specific prefix containing ip, or ""
+func (r *CIDRASNResolver) ASN(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	best, bestBits := "", -1
+	for _, e := range r.entries {
+		if e.prefix.Bits() > bestBits && e.prefix.Contains(addr) {
+			best, bestBits = e.asn, e.prefix.Bits()
+		}
+	}
+	return best
+}
+
+// AnomalyDetector counts auth events per IP and ASN in sliding windows. Spikes
+// above a rule's thresholds flag the IP or ASN, which tightens its rate limit for
+// the penalty period and emits a security event. Store failures never block
+// requests.
+type AnomalyDetector struct {
+	store AnomalyStore
+	rules map[string]AnomalyRule
+	sink  SecurityEventSink
+	asn   ASNResolver
+	log   *logger.Logger
+	now   func() time.Time
+}
+
+// NewAnomalyDetector creates a detector. Kinds without a rule are not tracked.
+func NewAnomalyDetector(store AnomalyStore, rules map[string]AnomalyRule, sink SecurityEventSink, log *logger.Logger) *AnomalyDetector {
+	return &AnomalyDetector{
+		store: store,
+		rules: rules,
+		sink:  sink,
+		log:   log,
+		now:   time.Now,
+	}
+}
+
+// WithASNResolver enables per-ASN counting
+func (d *AnomalyDetector) WithASNResolver(resolver ASNResolver) *AnomalyDetector {
+	d.asn = resolver
+	return d
+}
+
+// Allow returns ErrRateLimited if ip exceeded the current limit for kind
+func (d *AnomalyDetector) Allow(ctx context.Context, kind, ip string) error {
+	rule, ok := d.rules[kind]
+	if !ok || ip == "" {
+		return nil
+	}
+
+	limit := rule.Limit
+	if d.flagged(ctx, kind, ip) {
+		limit = rule.TightenedLimit
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	count, err := d.store.Count(ctx, counterKey(kind, "ip", ip), rule.Window, d.now())
+	if err != nil {
+		d.log.Warn(ctx, "Anomaly store unavailable, allowing request", map[string]interface{}{"error": err.Error(), "kind": kind})
+		return nil
+	}
+
+	if count >= limit {
+		metrics.RateLimitedTotal.WithLabelValues("account-service", kind).Inc()
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// Record counts an event of kind from ip and flags the IP or its ASN when the
+// count crosses the anomaly threshold
+func (d *AnomalyDetector) Record(ctx context.Context, kind, ip string) {
+	rule, ok := d.rules[kind]
+	if !ok || ip == "" {
+		return
+	}
+
+	d.hit(ctx, kind, rule, "ip", ip, rule.IPThreshold)
+	if d.asn != nil {
+		if asn := d.asn.ASN(ip); asn != "" {
+			d.hit(ctx, kind, rule, "asn", asn, rule.ASNThreshold)
+		}
+	}
+}
+
+// hit increments one counter and flags its subject above threshold
+func (d *AnomalyDetector) hit(ctx context.Context, kind string, rule AnomalyRule, dimension, subject string, threshold int64) {
+	now := d.now()
+	key := counterKey(kind, dimension, subject)
+
+	count, err := d.store.Hit(ctx, key, rule.Window, now)
+	if err != nil {
+		d.log.Warn(ctx, "Failed to record auth event", map[string]interface{}{"error": err.Error(), "kind": kind})
+		return
+	}
+
+	if threshold <= 0 || count <= threshold {
+		return
+	}
+
+	newlyFlagged, err := d.store.Flag(ctx, key, rule.Penalty)
+	if err != nil {
+		d.log.Warn(ctx, "Failed to flag anomaly", map[string]interface{}{"error": err.Error(), "kind": kind})
+		return
+	}
+
+	// Emit once per penalty period rather than on every event
+	if newlyFlagged && d.sink != nil {
+		d.sink.Emit(ctx, SecurityEvent{
+			Type:      SecurityEventAuthAnomaly,
+			Kind:      kind,
+			Dimension: dimension,
+			Subject:   subject,
+			Count:     count,
+			Threshold: threshold,
+			Window:    rule.Window,
+			At:        now,
+		})
+	}
+}
+
+// flagged reports whether ip or its ASN is flagged for kind
+func (d *AnomalyDetector) flagged(ctx context.Context, kind, ip string) bool {
+	if flagged, err := d.store.IsFlagged(ctx, counterKey(kind, "ip", ip)); err == nil && flagged {
+		return true
+	}
+	if d.asn == nil {
+		return false
+	}
+	asn := d.asn.ASN(ip)
+	if asn == "" {
+		return false
+	}
+	flagged, err := d.store.IsFlagged(ctx, counterKey(kind, "asn", asn))
+	return err == nil && flagged
+}
+
+// counterKey builds the store key of a counter
+func counterKey(kind, dimension, subject string) string {
+	return kind + ":" + dimension + ":" + subject
+}
+
+// clientIP returns the caller's IP, preferring the address forwarded by the gateway
+func clientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("x-forwarded-for"); len(v) > 0 {
+			if ip := strings.TrimSpace(strings.Split(v[0], ",")[0]); ip != "" {
+				return ip
+			}
+		}
+		if v := md.Get("x-real-ip"); len(v) > 0 && strings.TrimSpace(v[0]) != "" {
+			return strings.TrimSpace(v[0])
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
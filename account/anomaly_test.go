@@ -0,0 +1,195 @@
+package account
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/cache"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// recordingSink collects emitted security events
+type recordingSink struct {
+	events []SecurityEvent
+}
+
+func (s *recordingSink) Emit(ctx context.Context, event SecurityEvent) {
+	s.events = append(s.events, event)
+}
+
+var testRules = map[string]AnomalyRule{
+	EventLoginFailure: {
+		Window:         time.Minute,
+		Limit:          10,
+		IPThreshold:    3,
+		ASNThreshold:   5,
+		TightenedLimit: 4,
+		Penalty:        time.Hour,
+	},
+}
+
+func newTestDetector(t *testing.T) (*AnomalyDetector, *recordingSink, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	sink := &recordingSink{}
+	detector := NewAnomalyDetector(cache.NewCounters(client, "test:"), testRules, sink, logger.New("test"))
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	detector.now = func() time.Time { return now }
+	return detector, sink, server
+}
+
+func TestAnomalyDetector_FlagsSpikeOnce(t *testing.T) {
+	detector, sink, _ := newTestDetector(t)
+	ctx := context.Background()
+
+	for i := 0; i < 6; i++ {
+		detector.Record(ctx, EventLoginFailure, "198.51.100.7")
+	}
+
+	if len(sink.events) != 1 {
+		t.Fatalf("expected 1 security event, got %d", len(sink.events))
+	}
+	event := sink.events[0]
+	if event.Type != SecurityEventAuthAnomaly || event.Dimension != "ip" || event.Subject != "198.51.100.7" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if event.Count != 4 {
+		t.Errorf("expected event at count 4, got %d", event.Count)
+	}
+}
+
+func TestAnomalyDetector_TightensLimitWhenFlagged(t *testing.T) {
+	detector, _, _ := newTestDetector(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		detector.Record(ctx, EventLoginFailure, "198.51.100.7")
+	}
+	if err := detector.Allow(ctx, EventLoginFailure, "198.51.100.7"); err != nil {
+		t.Fatalf("expected request to be allowed below threshold, got %v", err)
+	}
+
+	// Crossing the threshold flags the IP and the tightened limit of 4 applies
+	detector.Record(ctx, EventLoginFailure, "198.51.100.7")
+	if err := detector.Allow(ctx, EventLoginFailure, "198.51.100.7"); err != ErrRateLimited {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	if err := detector.Allow(ctx, EventLoginFailure, "203.0.113.1"); err != nil {
+		t.Errorf("expected other IPs to be allowed, got %v", err)
+	}
+}
+
+func TestAnomalyDetector_ASNSpike(t *testing.T) {
+	detector, sink, _ := newTestDetector(t)
+	ctx := context.Background()
+
+	resolver, err := ParseCIDRASNTable(strings.NewReader("# test table\n203.0.113.0/24 AS64500\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	detector.WithASNResolver(resolver)
+
+	// Spread across IPs so no single IP crosses its threshold
+	for i := 1; i <= 6; i++ {
+		detector.Record(ctx, EventLoginFailure, "203.0.113."+strconv.Itoa(i))
+	}
+
+	if len(sink.events) != 1 || sink.events[0].Dimension != "asn" || sink.events[0].Subject != "AS64500" {
+		t.Fatalf("expected one ASN event, got %+v", sink.events)
+	}
+
+	// Every IP in the flagged ASN gets the tightened limit
+	for i := 0; i < 4; i++ {
+		detector.Record(ctx, EventLoginFailure, "203.0.113.50")
+	}
+	if err := detector.Allow(ctx, EventLoginFailure, "203.0.113.50"); err != ErrRateLimited {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestAnomalyDetector_FailsOpen(t *testing.T) {
+	detector, _, server := newTestDetector(t)
+	server.Close()
+
+	if err := detector.Allow(context.Background(), EventLoginFailure, "198.51.100.7"); err != nil {
+		t.Errorf("expected request to be allowed when store is down, got %v", err)
+	}
+	detector.Record(context.Background(), EventLoginFailure, "198.51.100.7")
+}
+
+func TestParseCIDRASNTable_MostSpecificPrefix(t *testing.T) {
+	resolver, err := ParseCIDRASNTable(strings.NewReader("10.0.0.0/8 AS1\n10.1.0.0/16 AS2\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := map[string]string{
+		"10.1.2.3":        "AS2",
+		"10.2.0.1":        "AS1",
+		"192.0.2.1":       "",
+		"not-an-ip":       "",
+		"::ffff:10.1.0.1": "AS2",
+	}
+	for ip, want := range tests {
+		if got := resolver.ASN(ip); got != want {
+			t.Errorf("ASN(%q) = %q, want %q", ip, got, want)
+		}
+	}
+
+	if _, err := ParseCIDRASNTable(strings.NewReader("10.0.0.0/8\n")); err == nil {
+		t.Error("expected error for malformed line, got nil")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.7, 10.0.0.1"))
+	if ip := clientIP(ctx); ip != "198.51.100.7" {
+		t.Errorf("expected forwarded IP, got %q", ip)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-real-ip", "203.0.113.9"))
+	if ip := clientIP(ctx); ip != "203.0.113.9" {
+		t.Errorf("expected real IP, got %q", ip)
+	}
+
+	if ip := clientIP(context.Background()); ip != "" {
+		t.Errorf("expected empty IP, got %q", ip)
+	}
+}
+
+func TestService_Login_RateLimited(t *testing.T) {
+	detector, _, _ := newTestDetector(t)
+	repo := &mockRepository{
+		verifyPasswordFunc: func(ctx context.Context, email, password string) (*Account, error) {
+			return nil, ErrInvalidCredentials
+		},
+	}
+	service := NewService(repo, "test-secret").WithAnomalyDetector(detector)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.7"))
+	req := &pb.LoginRequest{Email: "test@example.com", Password: "wrong"}
+
+	for i := 0; i < 4; i++ {
+		_, err := service.Login(ctx, req)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("attempt %d: expected Unauthenticated, got %v", i+1, err)
+		}
+	}
+
+	_, err := service.Login(ctx, req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", err)
+	}
+}
@@ -8,14 +8,25 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/Ujjwaljain16/E-commerce-Backend/account"
 	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/cache"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/evidence"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/outbox"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
 	_ "github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -56,11 +67,178 @@ func main() {
 
 	// Create repository and service
 	repo := account.NewRepository(db)
-	service := account.NewService(repo, jwtSecret)
+	service := account.NewService(repo, jwtSecret).
+		WithIssuer(os.Getenv("JWT_ISSUER"), os.Getenv("JWT_AUDIENCE")).
+		WithLeeway(getEnvDuration("JWT_LEEWAY", 0))
 
-	// Create gRPC server with metrics interceptor
+	// Tokens are signed with the private keys in JWT_PRIVATE_KEY_FILE when
+	// set, and verified elsewhere with the public keys served as a JWKS. It
+	// lists key files separated by commas, each optionally followed by
+	// @<RFC 3339 time> it signs from; the key activated last signs.
+	if keyFiles := os.Getenv("JWT_PRIVATE_KEY_FILE"); keyFiles != "" {
+		for _, entry := range strings.Split(keyFiles, ",") {
+			keyFile, activeFrom, err := parseKeyEntry(strings.TrimSpace(entry))
+			if err == nil {
+				err = useSigningKey(service, keyFile, activeFrom)
+			}
+			if err != nil {
+				log.Error(ctx, "Failed to load JWT signing key", map[string]interface{}{
+					"error": err.Error(),
+					"file":  entry,
+				})
+				os.Exit(1)
+			}
+			log.Info(ctx, "Loaded JWT signing key", map[string]interface{}{
+				"file":        keyFile,
+				"active_from": activeFrom,
+			})
+		}
+	}
+
+	// Redis backs anomaly detection, the token revocation list and the
+	// shared IP deny list
+	var redisClient *redis.Client
+	if redisAddr := os.Getenv("REDIS_ADDR"); redisAddr != "" {
+		redisClient, err = cache.NewRedisClient(ctx, cache.Config{
+			Addr:     redisAddr,
+			Password: os.Getenv("REDIS_PASSWORD"),
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to connect to Redis", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer redisClient.Close()
+
+		detector, err := newAnomalyDetector(redisClient, log)
+		if err != nil {
+			log.Error(ctx, "Failed to set up anomaly detection", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		service.WithAnomalyDetector(detector)
+		log.Info(ctx, "Auth anomaly detection enabled", map[string]interface{}{
+			"redis_addr": redisAddr,
+		})
+
+		service.WithRevocationStore(auth.NewRedisRevocationStore(redisClient, auth.DefaultRevocationPrefix))
+	}
+
+	// TOKEN_FORMAT=opaque issues random tokens whose claims stay in Redis,
+	// resolved by other services through the token store or Introspect, and
+	// TOKEN_FORMAT=paseto PASETO v4 local tokens encrypted with PASETO_KEY,
+	// which the services validating tokens share
+	switch tokenFormat := os.Getenv("TOKEN_FORMAT"); tokenFormat {
+	case "", "jwt":
+	case "opaque":
+		if redisClient == nil {
+			log.Error(ctx, "TOKEN_FORMAT=opaque requires REDIS_ADDR", nil)
+			os.Exit(1)
+		}
+		service.WithOpaqueTokens(auth.NewRedisTokenStore(redisClient, auth.DefaultTokenStorePrefix))
+		log.Info(ctx, "Issuing opaque tokens", nil)
+	case "paseto":
+		key, err := auth.ParsePASETOKey(os.Getenv("PASETO_KEY"))
+		if err == nil {
+			_, err = service.WithPASETO(key)
+		}
+		if err != nil {
+			log.Error(ctx, "TOKEN_FORMAT=paseto requires PASETO_KEY, 32 hex-encoded bytes", nil)
+			os.Exit(1)
+		}
+		log.Info(ctx, "Issuing PASETO tokens", nil)
+	default:
+		log.Error(ctx, "Invalid TOKEN_FORMAT", map[string]interface{}{
+			"token_format": tokenFormat,
+		})
+		os.Exit(1)
+	}
+
+	// SERVICE_ACCOUNTS_FILE lists the machine clients that obtain scoped
+	// tokens from /oauth2/token with their client ID and secret
+	var serviceAccounts []auth.ServiceAccount
+	if path := os.Getenv("SERVICE_ACCOUNTS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			serviceAccounts, err = auth.ParseServiceAccounts(data)
+		}
+		if err != nil {
+			log.Error(ctx, "Failed to load service accounts", map[string]interface{}{
+				"error": err.Error(),
+				"path":  path,
+			})
+			os.Exit(1)
+		}
+		log.Info(ctx, "Client credentials enabled", map[string]interface{}{
+			"service_accounts": len(serviceAccounts),
+		})
+	}
+
+	// IP filtering: admin network allowlist and runtime deny list
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnvWithRedis(filterCtx, log, account.AdminMethods, redisClient)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+	service.WithIPFilter(ipFilter)
+
+	// Publish account events from the outbox to Kafka
+	relayCtx, stopRelay := context.WithCancel(ctx)
+	defer stopRelay()
+	if brokers := kafka.ParseBrokers(os.Getenv("KAFKA_BROKERS")); len(brokers) > 0 {
+		producer, err := kafka.NewProducer(kafka.Config{Brokers: brokers}, "account-service")
+		if err != nil {
+			log.Error(ctx, "Failed to configure Kafka producer", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer producer.Close()
+		interval := getEnvDuration("OUTBOX_RELAY_INTERVAL", time.Second)
+		go outbox.NewRelay(db, account.OutboxTable, producer, log).Run(relayCtx, interval)
+		log.Info(ctx, "Outbox relay enabled", map[string]interface{}{
+			"topic":    account.EventsTopic,
+			"interval": interval.String(),
+		})
+	} else {
+		log.Warn(ctx, "Account events are held in the outbox (KAFKA_BROKERS not set)", nil)
+	}
+
+	// Export signed compliance evidence bundles when an evidence bucket is configured
+	exportCtx, stopExport := context.WithCancel(ctx)
+	defer stopExport()
+	if bucket := os.Getenv("EVIDENCE_BUCKET"); bucket != "" {
+		exporter, err := newEvidenceExporter(bucket, account.EvidenceSources(repo), log)
+		if err != nil {
+			log.Error(ctx, "Failed to configure evidence export", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		interval := getEnvDuration("EVIDENCE_EXPORT_INTERVAL", 24*time.Hour)
+		go exporter.Run(exportCtx, interval)
+		log.Info(ctx, "Evidence export enabled", map[string]interface{}{
+			"bucket":   bucket,
+			"interval": interval.String(),
+		})
+	}
+
+	// Create gRPC server with metrics, IP filter, auth and tenant
+	// interceptors. The tenant is taken from the validated token, so tokens
+	// are validated first.
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(metrics.UnaryServerInterceptor("account-service")),
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("account-service"),
+			ipFilter.UnaryServerInterceptor(),
+			service.AuthInterceptor().UnaryServerInterceptor(),
+			tenant.UnaryServerInterceptor(auth.TenantFromContext),
+		),
 	)
 	pb.RegisterAccountServiceServer(grpcServer, service)
 
@@ -73,9 +251,15 @@ func main() {
 	// Enable reflection for grpcurl/grpcui
 	reflection.Register(grpcServer)
 
-	// Start Prometheus metrics HTTP server
+	// Start Prometheus metrics HTTP server, which also serves the JWKS, token
+	// introspection and the client credentials token endpoint
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
+		http.Handle("/.well-known/jwks.json", service.JWKSHandler())
+		http.Handle("/oauth2/introspect", service.IntrospectionHandler())
+		if len(serviceAccounts) > 0 {
+			http.Handle("/oauth2/token", service.ClientCredentialsHandler(serviceAccounts, getEnvDuration("CLIENT_TOKEN_DURATION", auth.DefaultClientTokenDuration)))
+		}
 		metricsAddr := fmt.Sprintf(":%s", metricsPort)
 		log.Info(ctx, "Metrics server listening", map[string]interface{}{
 			"port": metricsPort,
@@ -109,6 +293,9 @@ func main() {
 		<-sigChan
 
 		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		stopExport()
+		stopRelay()
 		grpcServer.GracefulStop()
 		repo.Close()
 	}()
@@ -122,9 +309,98 @@ func main() {
 	}
 }
 
+// newAnomalyDetector builds the auth anomaly detector on Redis
+func newAnomalyDetector(client *redis.Client, log *logger.Logger) (*account.AnomalyDetector, error) {
+	counters := cache.NewCounters(client, "account:auth:")
+	detector := account.NewAnomalyDetector(counters, account.DefaultAnomalyRules, account.NewLogEventSink(log), log)
+
+	if path := os.Getenv("ASN_TABLE_PATH"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ASN table: %w", err)
+		}
+		defer f.Close()
+
+		resolver, err := account.ParseCIDRASNTable(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ASN table: %w", err)
+		}
+		detector.WithASNResolver(resolver)
+	}
+
+	return detector, nil
+}
+
+// parseKeyEntry splits a JWT_PRIVATE_KEY_FILE entry into the key file and
+// the time it signs from, zero when the entry has none
+func parseKeyEntry(entry string) (string, time.Time, error) {
+	file, at, ok := strings.Cut(entry, "@")
+	if !ok {
+		return file, time.Time{}, nil
+	}
+	activeFrom, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid activation time %q: %w", at, err)
+	}
+	return file, activeFrom, nil
+}
+
+// useSigningKey signs the service's tokens with the PEM private key in file
+// from activeFrom on
+func useSigningKey(service *account.Service, file string, activeFrom time.Time) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	key, err := auth.ParsePrivateKeyPEM(data)
+	if err != nil {
+		return err
+	}
+	return service.AddSigningKey(key, activeFrom)
+}
+
+// evidenceConfigKeys are the settings recorded in each evidence bundle; secrets are fingerprinted
+var evidenceConfigKeys = []string{
+	"PORT", "METRICS_PORT", "DATABASE_URL", "JWT_SECRET", "JWT_PRIVATE_KEY_FILE", "JWT_ISSUER", "JWT_AUDIENCE", "JWT_LEEWAY", "TOKEN_FORMAT", "PASETO_KEY", "SERVICE_ACCOUNTS_FILE", "CLIENT_TOKEN_DURATION", "REDIS_ADDR", "KAFKA_BROKERS",
+	"ADMIN_ALLOWED_IPS", "TRUSTED_PROXIES", "DENY_LIST_SYNC_INTERVAL", "ASN_TABLE_PATH",
+}
+
+// newEvidenceExporter builds the compliance evidence exporter. Bundles are
+// written to EVIDENCE_BUCKET with the S3_* connection settings, signed with
+// EVIDENCE_SIGNING_KEY, and include the backup verification report at
+// BACKUP_REPORT_PATH when set.
+func newEvidenceExporter(bucket string, sources []evidence.Source, log *logger.Logger) (*evidence.Exporter, error) {
+	store, err := storage.NewS3Client(storage.Config{
+		Endpoint:  getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		Region:    getEnv("S3_REGION", "us-east-1"),
+		Bucket:    bucket,
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PathStyle: getEnv("S3_PATH_STYLE", "true") == "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sources = append(sources, evidence.ConfigSnapshot(evidenceConfigKeys))
+	if path := os.Getenv("BACKUP_REPORT_PATH"); path != "" {
+		sources = append(sources, evidence.FileSource("backup_verification", path))
+	}
+
+	key := []byte(os.Getenv("EVIDENCE_SIGNING_KEY"))
+	return evidence.NewExporter("account-service", store, key, log, sources...), nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,166 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminMethods are the admin-scoped RPCs of the account service
+var AdminMethods = []string{
+	pb.AccountService_DenyIP_FullMethodName,
+	pb.AccountService_RemoveDeniedIP_FullMethodName,
+	pb.AccountService_ListDeniedIPs_FullMethodName,
+	pb.AccountService_ListAccounts_FullMethodName,
+}
+
+// WithIPFilter enables runtime management of the IP deny list
+func (s *Service) WithIPFilter(filter *ipfilter.Filter) *Service {
+	s.ipFilter = filter
+	return s
+}
+
+// requireAdmin checks that the call, authenticated by AuthInterceptor,
+// carries an admin's token of the call's tenant and returns its claims
+func (s *Service) requireAdmin(ctx context.Context) (*auth.Claims, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	if tenant.OrDefault(claims.TenantID) != tenant.FromContext(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "token belongs to another tenant")
+	}
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// recordAccessChange appends a deny list change to the access control log. The
+// change has already been applied, so a failure is reported to the caller to
+// retry rather than silently losing the record.
+func (s *Service) recordAccessChange(ctx context.Context, action, subject, actorID, details string) error {
+	err := s.repo.RecordAccessControlEvent(ctx, &AccessControlEvent{
+		Action:  action,
+		Subject: subject,
+		ActorID: actorID,
+		Details: details,
+	})
+	if err != nil {
+		return status.Error(codes.Internal, "failed to record access control change")
+	}
+	return nil
+}
+
+// DenyIP adds an IP or CIDR to the deny list
+func (s *Service) DenyIP(ctx context.Context, req *pb.DenyIPRequest) (*pb.DenyIPResponse, error) {
+	claims, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.ipFilter == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ip filtering is not enabled")
+	}
+	if req.Cidr == "" {
+		return nil, status.Error(codes.InvalidArgument, "cidr is required")
+	}
+	if req.TtlSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_seconds must not be negative")
+	}
+
+	prefix, err := ipfilter.ParsePrefix(req.Cidr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cidr must be an IP address or CIDR")
+	}
+
+	entry := ipfilter.DenyEntry{
+		Prefix:    prefix,
+		Reason:    req.Reason,
+		CreatedAt: time.Now(),
+	}
+	if req.TtlSeconds > 0 {
+		entry.ExpiresAt = entry.CreatedAt.Add(time.Duration(req.TtlSeconds) * time.Second)
+	}
+
+	if err := s.ipFilter.Deny(ctx, entry); err != nil {
+		return nil, status.Error(codes.Internal, "failed to update deny list")
+	}
+	if err := s.recordAccessChange(ctx, AccessActionDenyIP, prefix.String(), claims.UserID, req.Reason); err != nil {
+		return nil, err
+	}
+
+	return &pb.DenyIPResponse{Entry: toProtoDeniedIP(entry)}, nil
+}
+
+// RemoveDeniedIP removes an entry from the deny list
+func (s *Service) RemoveDeniedIP(ctx context.Context, req *pb.RemoveDeniedIPRequest) (*pb.RemoveDeniedIPResponse, error) {
+	claims, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.ipFilter == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ip filtering is not enabled")
+	}
+	if req.Cidr == "" {
+		return nil, status.Error(codes.InvalidArgument, "cidr is required")
+	}
+
+	prefix, err := ipfilter.ParsePrefix(req.Cidr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cidr must be an IP address or CIDR")
+	}
+
+	existed, err := s.ipFilter.Remove(ctx, prefix)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update deny list")
+	}
+	if !existed {
+		return nil, status.Error(codes.NotFound, "deny list entry not found")
+	}
+	if err := s.recordAccessChange(ctx, AccessActionRemoveDeniedIP, prefix.String(), claims.UserID, ""); err != nil {
+		return nil, err
+	}
+
+	return &pb.RemoveDeniedIPResponse{
+		Success: true,
+		Message: "deny list entry removed",
+	}, nil
+}
+
+// ListDeniedIPs returns the active deny list
+func (s *Service) ListDeniedIPs(ctx context.Context, req *pb.ListDeniedIPsRequest) (*pb.ListDeniedIPsResponse, error) {
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if s.ipFilter == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ip filtering is not enabled")
+	}
+
+	entries := s.ipFilter.DenyList()
+	protoEntries := make([]*pb.DeniedIP, len(entries))
+	for i, e := range entries {
+		protoEntries[i] = toProtoDeniedIP(e)
+	}
+
+	return &pb.ListDeniedIPsResponse{Entries: protoEntries}, nil
+}
+
+// toProtoDeniedIP converts a deny entry to protobuf
+func toProtoDeniedIP(e ipfilter.DenyEntry) *pb.DeniedIP {
+	denied := &pb.DeniedIP{
+		Cidr:      e.Prefix.String(),
+		Reason:    e.Reason,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if !e.ExpiresAt.IsZero() {
+		denied.ExpiresAt = timestamppb.New(e.ExpiresAt)
+	}
+	return denied
+}
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// contextWithRole returns the context of a call AuthInterceptor
+// authenticated for a caller with role
+func contextWithRole(role string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Email: "admin@example.com", Role: role})
+}
+
+func TestService_DenyIP_Lifecycle(t *testing.T) {
+	var events []*AccessControlEvent
+	mockRepo := &mockRepository{
+		recordAccessControlEventFunc: func(ctx context.Context, event *AccessControlEvent) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+	filter := ipfilter.New(ipfilter.Config{}, nil)
+	service := NewService(mockRepo, "test-secret").WithIPFilter(filter)
+	ctx := contextWithRole("ADMIN")
+
+	resp, err := service.DenyIP(ctx, &pb.DenyIPRequest{Cidr: "203.0.113.7/24", Reason: "credential stuffing", TtlSeconds: 3600})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.Entry.Cidr != "203.0.113.0/24" || resp.Entry.ExpiresAt == nil {
+		t.Errorf("unexpected entry %+v", resp.Entry)
+	}
+
+	list, err := service.ListDeniedIPs(ctx, &pb.ListDeniedIPsRequest{})
+	if err != nil || len(list.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %v, %v", list, err)
+	}
+
+	if _, err := service.RemoveDeniedIP(ctx, &pb.RemoveDeniedIPRequest{Cidr: "203.0.113.0/24"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_, err = service.RemoveDeniedIP(ctx, &pb.RemoveDeniedIPRequest{Cidr: "203.0.113.0/24"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 access control events, got %d", len(events))
+	}
+	if e := events[0]; e.Action != AccessActionDenyIP || e.Subject != "203.0.113.0/24" || e.ActorID != "user-1" || e.Details != "credential stuffing" {
+		t.Errorf("unexpected deny event %+v", e)
+	}
+	if e := events[1]; e.Action != AccessActionRemoveDeniedIP || e.Subject != "203.0.113.0/24" {
+		t.Errorf("unexpected remove event %+v", e)
+	}
+}
+
+func TestService_DenyIP_RecordFailure(t *testing.T) {
+	mockRepo := &mockRepository{
+		recordAccessControlEventFunc: func(ctx context.Context, event *AccessControlEvent) error {
+			return errors.New("database unavailable")
+		},
+	}
+	service := NewService(mockRepo, "test-secret").WithIPFilter(ipfilter.New(ipfilter.Config{}, nil))
+
+	_, err := service.DenyIP(contextWithRole("ADMIN"), &pb.DenyIPRequest{Cidr: "203.0.113.7"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal, got %v", err)
+	}
+}
+
+func TestService_DenyIP_RequiresAdmin(t *testing.T) {
+	service := NewService(&mockRepository{}, "test-secret").WithIPFilter(ipfilter.New(ipfilter.Config{}, nil))
+	req := &pb.DenyIPRequest{Cidr: "203.0.113.7"}
+
+	if _, err := service.DenyIP(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", err)
+	}
+	if _, err := service.DenyIP(contextWithRole("USER"), req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestService_DenyIP_InvalidCIDR(t *testing.T) {
+	service := NewService(&mockRepository{}, "test-secret").WithIPFilter(ipfilter.New(ipfilter.Config{}, nil))
+
+	_, err := service.DenyIP(contextWithRole("ADMIN"), &pb.DenyIPRequest{Cidr: "not-an-ip"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
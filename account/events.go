@@ -0,0 +1,53 @@
+package account
+
+import (
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/outbox"
+)
+
+const (
+	// EventsTopic is the Kafka topic account events are published to
+	EventsTopic = "account-events"
+	// OutboxTable holds account events until they are published
+	OutboxTable = "account_outbox"
+)
+
+// Account event types
+const (
+	EventAccountCreated  = "account.created"
+	EventAccountUpdated  = "account.updated"
+	EventPasswordChanged = "account.password_changed"
+	EventAccountDeleted  = "account.deleted"
+)
+
+// events is the outbox account mutations add their events to
+var events = outbox.New(OutboxTable, EventsTopic)
+
+// accountEventData is the data of an account event. The password hash is
+// never published.
+type accountEventData struct {
+	AccountID string `json:"account_id"`
+	TenantID  string `json:"tenant_id"`
+	Email     string `json:"email,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+// accountEvent describes a mutation of account a at at
+func accountEvent(eventType string, a *Account, at time.Time) outbox.Event {
+	return outbox.Event{
+		Type:       eventType,
+		Key:        a.ID,
+		OccurredAt: at,
+		Data: accountEventData{
+			AccountID: a.ID,
+			TenantID:  a.TenantID,
+			Email:     a.Email,
+			Name:      a.Name,
+			Phone:     a.Phone,
+			Role:      a.Role,
+		},
+	}
+}
@@ -79,7 +79,8 @@ func runMigrations(db *sql.DB) error {
 	createTableSQL := `
 		CREATE TABLE IF NOT EXISTS accounts (
 			id UUID PRIMARY KEY,
-			email VARCHAR(255) UNIQUE NOT NULL,
+			tenant_id VARCHAR(63) NOT NULL DEFAULT 'default',
+			email VARCHAR(255) NOT NULL,
 			password_hash VARCHAR(255) NOT NULL,
 			name VARCHAR(255) NOT NULL,
 			phone VARCHAR(20),
@@ -89,7 +90,8 @@ func runMigrations(db *sql.DB) error {
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			deleted_at TIMESTAMP,
-			CONSTRAINT accounts_role_check CHECK (role IN ('USER', 'ADMIN'))
+			CONSTRAINT accounts_role_check CHECK (role IN ('USER', 'ADMIN')),
+			CONSTRAINT accounts_tenant_email_key UNIQUE (tenant_id, email)
 		);
 	`
 	if _, err := db.Exec(createTableSQL); err != nil {
@@ -102,6 +104,23 @@ func runMigrations(db *sql.DB) error {
 		return fmt.Errorf("failed to create role index: %w", err)
 	}
 
+	// Create outbox table
+	createOutboxSQL := `
+		CREATE TABLE IF NOT EXISTS account_outbox (
+			id BIGSERIAL PRIMARY KEY,
+			event_id UUID NOT NULL UNIQUE,
+			topic VARCHAR(255) NOT NULL,
+			event_key VARCHAR(255) NOT NULL,
+			event_type VARCHAR(100) NOT NULL,
+			payload TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			published_at TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createOutboxSQL); err != nil {
+		return fmt.Errorf("failed to create outbox table: %w", err)
+	}
+
 	return nil
 }
 
@@ -268,7 +287,7 @@ func TestIntegration_GetProfile(t *testing.T) {
 		UserId: registerResp.User.Id,
 	}
 
-	profileResp, err := service.GetProfile(ctx, profileReq)
+	profileResp, err := service.GetProfile(userContext(registerResp.User.Id), profileReq)
 	if err != nil {
 		t.Fatalf("GetProfile failed: %v", err)
 	}
@@ -307,7 +326,7 @@ func TestIntegration_UpdateProfile(t *testing.T) {
 		Phone:  "6666666666",
 	}
 
-	updateResp, err := service.UpdateProfile(ctx, updateReq)
+	updateResp, err := service.UpdateProfile(userContext(registerResp.User.Id), updateReq)
 	if err != nil {
 		t.Fatalf("UpdateProfile failed: %v", err)
 	}
@@ -348,7 +367,7 @@ func TestIntegration_ChangePassword(t *testing.T) {
 		NewPassword: newPassword,
 	}
 
-	_, err = service.ChangePassword(ctx, changeReq)
+	_, err = service.ChangePassword(userContext(registerResp.User.Id), changeReq)
 	if err != nil {
 		t.Fatalf("ChangePassword failed: %v", err)
 	}
@@ -481,7 +500,7 @@ func TestIntegration_DeleteAccount(t *testing.T) {
 		UserId: registerResp.User.Id,
 	}
 
-	_, err = service.DeleteAccount(ctx, deleteReq)
+	_, err = service.DeleteAccount(userContext(registerResp.User.Id), deleteReq)
 	if err != nil {
 		t.Fatalf("DeleteAccount failed: %v", err)
 	}
@@ -502,7 +521,7 @@ func TestIntegration_DeleteAccount(t *testing.T) {
 		UserId: registerResp.User.Id,
 	}
 
-	_, err = service.GetProfile(ctx, profileReq)
+	_, err = service.GetProfile(userContext(registerResp.User.Id), profileReq)
 	if err == nil {
 		t.Fatal("Expected error when getting profile of deleted account")
 	}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_access_control_events_created_at;
+DROP TABLE IF EXISTS access_control_events;
@@ -0,0 +1,11 @@
+-- Append-only log of access-control changes, exported as compliance evidence
+CREATE TABLE IF NOT EXISTS access_control_events (
+    id VARCHAR(36) PRIMARY KEY,
+    action VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    actor_id VARCHAR(36) NOT NULL,
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_access_control_events_created_at ON access_control_events(created_at);
@@ -0,0 +1,2 @@
+-- Accounts registered in a period, newest first
+CREATE INDEX idx_accounts_created_at ON accounts(created_at DESC);
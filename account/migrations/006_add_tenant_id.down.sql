@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_accounts_tenant_created_at;
+CREATE INDEX idx_accounts_created_at ON accounts(created_at DESC);
+CREATE INDEX idx_accounts_email ON accounts(email);
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_tenant_email_key;
+ALTER TABLE accounts ADD CONSTRAINT accounts_email_key UNIQUE (email);
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS tenant_id;
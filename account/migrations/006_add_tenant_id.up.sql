@@ -0,0 +1,10 @@
+-- Accounts belong to a tenant (store). Existing accounts belong to the
+-- default tenant, and an email may be registered once per tenant.
+ALTER TABLE accounts ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_email_key;
+ALTER TABLE accounts ADD CONSTRAINT accounts_tenant_email_key UNIQUE (tenant_id, email);
+
+DROP INDEX IF EXISTS idx_accounts_email;
+DROP INDEX IF EXISTS idx_accounts_created_at;
+CREATE INDEX idx_accounts_tenant_created_at ON accounts(tenant_id, created_at DESC);
@@ -27,6 +27,12 @@ const (
 	AccountService_DeleteAccount_FullMethodName  = "/account.AccountService/DeleteAccount"
 	AccountService_VerifyToken_FullMethodName    = "/account.AccountService/VerifyToken"
 	AccountService_RefreshToken_FullMethodName   = "/account.AccountService/RefreshToken"
+	AccountService_Logout_FullMethodName         = "/account.AccountService/Logout"
+	AccountService_Introspect_FullMethodName     = "/account.AccountService/Introspect"
+	AccountService_DenyIP_FullMethodName         = "/account.AccountService/DenyIP"
+	AccountService_RemoveDeniedIP_FullMethodName = "/account.AccountService/RemoveDeniedIP"
+	AccountService_ListDeniedIPs_FullMethodName  = "/account.AccountService/ListDeniedIPs"
+	AccountService_ListAccounts_FullMethodName   = "/account.AccountService/ListAccounts"
 )
 
 // AccountServiceClient is the client API for AccountService service.
@@ -51,6 +57,18 @@ type AccountServiceClient interface {
 	VerifyToken(ctx context.Context, in *VerifyTokenRequest, opts ...grpc.CallOption) (*VerifyTokenResponse, error)
 	// RefreshToken generates a new JWT token from a refresh token
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	// Logout revokes an access token and its refresh token on every service
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	// Introspect returns the state and claims of a JWT or opaque token (service or admin only)
+	Introspect(ctx context.Context, in *IntrospectRequest, opts ...grpc.CallOption) (*IntrospectResponse, error)
+	// DenyIP adds an IP or CIDR to the deny list (admin only)
+	DenyIP(ctx context.Context, in *DenyIPRequest, opts ...grpc.CallOption) (*DenyIPResponse, error)
+	// RemoveDeniedIP removes an entry from the deny list (admin only)
+	RemoveDeniedIP(ctx context.Context, in *RemoveDeniedIPRequest, opts ...grpc.CallOption) (*RemoveDeniedIPResponse, error)
+	// ListDeniedIPs returns the active deny list (admin only)
+	ListDeniedIPs(ctx context.Context, in *ListDeniedIPsRequest, opts ...grpc.CallOption) (*ListDeniedIPsResponse, error)
+	// ListAccounts lists the accounts registered in a period (admin only)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
 }
 
 type accountServiceClient struct {
@@ -141,6 +159,66 @@ func (c *accountServiceClient) RefreshToken(ctx context.Context, in *RefreshToke
 	return out, nil
 }
 
+func (c *accountServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, AccountService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) Introspect(ctx context.Context, in *IntrospectRequest, opts ...grpc.CallOption) (*IntrospectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IntrospectResponse)
+	err := c.cc.Invoke(ctx, AccountService_Introspect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) DenyIP(ctx context.Context, in *DenyIPRequest, opts ...grpc.CallOption) (*DenyIPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DenyIPResponse)
+	err := c.cc.Invoke(ctx, AccountService_DenyIP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) RemoveDeniedIP(ctx context.Context, in *RemoveDeniedIPRequest, opts ...grpc.CallOption) (*RemoveDeniedIPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDeniedIPResponse)
+	err := c.cc.Invoke(ctx, AccountService_RemoveDeniedIP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ListDeniedIPs(ctx context.Context, in *ListDeniedIPsRequest, opts ...grpc.CallOption) (*ListDeniedIPsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeniedIPsResponse)
+	err := c.cc.Invoke(ctx, AccountService_ListDeniedIPs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, AccountService_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountServiceServer is the server API for AccountService service.
 // All implementations must embed UnimplementedAccountServiceServer
 // for forward compatibility.
@@ -163,6 +241,18 @@ type AccountServiceServer interface {
 	VerifyToken(context.Context, *VerifyTokenRequest) (*VerifyTokenResponse, error)
 	// RefreshToken generates a new JWT token from a refresh token
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// Logout revokes an access token and its refresh token on every service
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	// Introspect returns the state and claims of a JWT or opaque token (service or admin only)
+	Introspect(context.Context, *IntrospectRequest) (*IntrospectResponse, error)
+	// DenyIP adds an IP or CIDR to the deny list (admin only)
+	DenyIP(context.Context, *DenyIPRequest) (*DenyIPResponse, error)
+	// RemoveDeniedIP removes an entry from the deny list (admin only)
+	RemoveDeniedIP(context.Context, *RemoveDeniedIPRequest) (*RemoveDeniedIPResponse, error)
+	// ListDeniedIPs returns the active deny list (admin only)
+	ListDeniedIPs(context.Context, *ListDeniedIPsRequest) (*ListDeniedIPsResponse, error)
+	// ListAccounts lists the accounts registered in a period (admin only)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
 	mustEmbedUnimplementedAccountServiceServer()
 }
 
@@ -197,6 +287,24 @@ func (UnimplementedAccountServiceServer) VerifyToken(context.Context, *VerifyTok
 func (UnimplementedAccountServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RefreshToken not implemented")
 }
+func (UnimplementedAccountServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedAccountServiceServer) Introspect(context.Context, *IntrospectRequest) (*IntrospectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Introspect not implemented")
+}
+func (UnimplementedAccountServiceServer) DenyIP(context.Context, *DenyIPRequest) (*DenyIPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DenyIP not implemented")
+}
+func (UnimplementedAccountServiceServer) RemoveDeniedIP(context.Context, *RemoveDeniedIPRequest) (*RemoveDeniedIPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveDeniedIP not implemented")
+}
+func (UnimplementedAccountServiceServer) ListDeniedIPs(context.Context, *ListDeniedIPsRequest) (*ListDeniedIPsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeniedIPs not implemented")
+}
+func (UnimplementedAccountServiceServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAccounts not implemented")
+}
 func (UnimplementedAccountServiceServer) mustEmbedUnimplementedAccountServiceServer() {}
 func (UnimplementedAccountServiceServer) testEmbeddedByValue()                        {}
 
@@ -362,6 +470,114 @@ func _AccountService_RefreshToken_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_Introspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).Introspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_Introspect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).Introspect(ctx, req.(*IntrospectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_DenyIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyIPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).DenyIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_DenyIP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).DenyIP(ctx, req.(*DenyIPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_RemoveDeniedIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeniedIPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).RemoveDeniedIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_RemoveDeniedIP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).RemoveDeniedIP(ctx, req.(*RemoveDeniedIPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ListDeniedIPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeniedIPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ListDeniedIPs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ListDeniedIPs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ListDeniedIPs(ctx, req.(*ListDeniedIPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AccountService_ServiceDesc is the grpc.ServiceDesc for AccountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -401,6 +617,30 @@ var AccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshToken",
 			Handler:    _AccountService_RefreshToken_Handler,
 		},
+		{
+			MethodName: "Logout",
+			Handler:    _AccountService_Logout_Handler,
+		},
+		{
+			MethodName: "Introspect",
+			Handler:    _AccountService_Introspect_Handler,
+		},
+		{
+			MethodName: "DenyIP",
+			Handler:    _AccountService_DenyIP_Handler,
+		},
+		{
+			MethodName: "RemoveDeniedIP",
+			Handler:    _AccountService_RemoveDeniedIP_Handler,
+		},
+		{
+			MethodName: "ListDeniedIPs",
+			Handler:    _AccountService_ListDeniedIPs_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _AccountService_ListAccounts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "account/account.proto",
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -22,6 +23,7 @@ var (
 // Account represents a user account in the system
 type Account struct {
 	ID           string
+	TenantID     string
 	Email        string
 	PasswordHash string
 	Name         string
@@ -33,7 +35,18 @@ type Account struct {
 	UpdatedAt    time.Time
 }
 
-// Repository defines the interface for account data operations
+// AccountFilter narrows the accounts listed. Zero values match every account,
+// except that deleted accounts are only listed with IncludeInactive.
+type AccountFilter struct {
+	CreatedFrom     time.Time
+	CreatedTo       time.Time
+	Role            string
+	IncludeInactive bool
+}
+
+// Repository defines the interface for account data operations. Accounts
+// are scoped to the tenant of the request context: an account of another
+// tenant is not found, and an email may be registered once per tenant.
 type Repository interface {
 	Create(ctx context.Context, email, password, name, phone, role string) (*Account, error)
 	GetByID(ctx context.Context, id string) (*Account, error)
@@ -42,6 +55,9 @@ type Repository interface {
 	UpdatePassword(ctx context.Context, id, newPasswordHash string) error
 	Delete(ctx context.Context, id string) error
 	VerifyPassword(ctx context.Context, email, password string) (*Account, error)
+	List(ctx context.Context, filter AccountFilter, page, pageSize int32) ([]*Account, int32, error)
+	RecordAccessControlEvent(ctx context.Context, event *AccessControlEvent) error
+	ListAccessControlEvents(ctx context.Context, from, to time.Time) ([]*AccessControlEvent, error)
 	Close() error
 }
 
@@ -69,6 +85,7 @@ func (r *repository) Create(ctx context.Context, email, password, name, phone, r
 
 	account := &Account{
 		ID:           uuid.New().String(),
+		TenantID:     tenant.FromContext(ctx),
 		Email:        email,
 		PasswordHash: string(hashedPassword),
 		Name:         name,
@@ -80,12 +97,18 @@ func (r *repository) Create(ctx context.Context, email, password, name, phone, r
 		UpdatedAt:    time.Now(),
 	}
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO accounts (id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO accounts (id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		account.ID,
 		account.Email,
 		account.PasswordHash,
@@ -96,11 +119,18 @@ func (r *repository) Create(ctx context.Context, email, password, name, phone, r
 		account.IsActive,
 		account.CreatedAt,
 		account.UpdatedAt,
+		account.TenantID,
 	)
+	if err == nil {
+		err = events.Add(ctx, tx, accountEvent(EventAccountCreated, account, account.CreatedAt))
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 
 	if err != nil {
 		// Check for unique constraint violation
-		if err.Error() == "pq: duplicate key value violates unique constraint \"accounts_email_key\"" {
+		if err.Error() == "pq: duplicate key value violates unique constraint \"accounts_tenant_email_key\"" {
 			return nil, ErrEmailAlreadyExists
 		}
 		return nil, err
@@ -114,12 +144,12 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Account, error) {
 	account := &Account{}
 
 	query := `
-		SELECT id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at
+		SELECT id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at, tenant_id
 		FROM accounts
-		WHERE id = $1 AND is_active = TRUE
+		WHERE id = $1 AND tenant_id = $2 AND is_active = TRUE
 	`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, tenant.FromContext(ctx)).Scan(
 		&account.ID,
 		&account.Email,
 		&account.PasswordHash,
@@ -130,6 +160,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Account, error) {
 		&account.IsActive,
 		&account.CreatedAt,
 		&account.UpdatedAt,
+		&account.TenantID,
 	)
 
 	if err == sql.ErrNoRows {
@@ -147,12 +178,12 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*Account, er
 	account := &Account{}
 
 	query := `
-		SELECT id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at
+		SELECT id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at, tenant_id
 		FROM accounts
-		WHERE email = $1 AND is_active = TRUE
+		WHERE email = $1 AND tenant_id = $2 AND is_active = TRUE
 	`
 
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := r.db.QueryRowContext(ctx, query, email, tenant.FromContext(ctx)).Scan(
 		&account.ID,
 		&account.Email,
 		&account.PasswordHash,
@@ -163,6 +194,7 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*Account, er
 		&account.IsActive,
 		&account.CreatedAt,
 		&account.UpdatedAt,
+		&account.TenantID,
 	)
 
 	if err == sql.ErrNoRows {
@@ -177,15 +209,21 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*Account, er
 
 // Update updates account profile information
 func (r *repository) Update(ctx context.Context, id, name, phone string) (*Account, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE accounts
 		SET name = $2, phone = $3, updated_at = $4
-		WHERE id = $1 AND is_active = TRUE
-		RETURNING id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at
+		WHERE id = $1 AND tenant_id = $5 AND is_active = TRUE
+		RETURNING id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at, tenant_id
 	`
 
 	account := &Account{}
-	err := r.db.QueryRowContext(ctx, query, id, name, phone, time.Now()).Scan(
+	err = tx.QueryRowContext(ctx, query, id, name, phone, time.Now(), tenant.FromContext(ctx)).Scan(
 		&account.ID,
 		&account.Email,
 		&account.PasswordHash,
@@ -196,11 +234,18 @@ func (r *repository) Update(ctx context.Context, id, name, phone string) (*Accou
 		&account.IsActive,
 		&account.CreatedAt,
 		&account.UpdatedAt,
+		&account.TenantID,
 	)
 
 	if err == sql.ErrNoRows {
 		return nil, ErrAccountNotFound
 	}
+	if err == nil {
+		err = events.Add(ctx, tx, accountEvent(EventAccountUpdated, account, account.UpdatedAt))
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -213,24 +258,10 @@ func (r *repository) UpdatePassword(ctx context.Context, id, newPasswordHash str
 	query := `
 		UPDATE accounts
 		SET password_hash = $2, updated_at = $3
-		WHERE id = $1 AND is_active = TRUE
+		WHERE id = $1 AND tenant_id = $4 AND is_active = TRUE
 	`
 
-	result, err := r.db.ExecContext(ctx, query, id, newPasswordHash, time.Now())
-	if err != nil {
-		return err
-	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rows == 0 {
-		return ErrAccountNotFound
-	}
-
-	return nil
+	return r.execWithEvent(ctx, EventPasswordChanged, id, query, newPasswordHash)
 }
 
 // Delete soft-deletes an account by setting is_active to false
@@ -238,10 +269,24 @@ func (r *repository) Delete(ctx context.Context, id string) error {
 	query := `
 		UPDATE accounts
 		SET is_active = FALSE, updated_at = $2
-		WHERE id = $1
+		WHERE id = $1 AND tenant_id = $3
 	`
 
-	result, err := r.db.ExecContext(ctx, query, id, time.Now())
+	return r.execWithEvent(ctx, EventAccountDeleted, id, query)
+}
+
+// execWithEvent runs an update of account id of the context tenant, whose
+// first argument is the ID and last two the update time and the tenant, and
+// adds an event of eventType in the same transaction
+func (r *repository) execWithEvent(ctx context.Context, eventType, id, query string, args ...interface{}) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, query, append(append([]interface{}{id}, args...), now, tenant.FromContext(ctx))...)
 	if err != nil {
 		return err
 	}
@@ -255,7 +300,10 @@ func (r *repository) Delete(ctx context.Context, id string) error {
 		return ErrAccountNotFound
 	}
 
-	return nil
+	if err := events.Add(ctx, tx, accountEvent(eventType, &Account{ID: id, TenantID: tenant.FromContext(ctx)}, now)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // VerifyPassword verifies email and password combination
@@ -273,6 +321,79 @@ func (r *repository) VerifyPassword(ctx context.Context, email, password string)
 	return account, nil
 }
 
+// List retrieves the accounts matching filter, newest first, with the total
+// number that match
+func (r *repository) List(ctx context.Context, filter AccountFilter, page, pageSize int32) ([]*Account, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	offset := (page - 1) * pageSize
+
+	var from, to interface{}
+	if !filter.CreatedFrom.IsZero() {
+		from = filter.CreatedFrom
+	}
+	if !filter.CreatedTo.IsZero() {
+		to = filter.CreatedTo
+	}
+	tenantID := tenant.FromContext(ctx)
+	where := `
+		WHERE tenant_id = $5
+		AND ($1::timestamptz IS NULL OR created_at >= $1)
+		AND ($2::timestamptz IS NULL OR created_at < $2)
+		AND ($3 = '' OR role = $3)
+		AND ($4 OR is_active = TRUE)
+	`
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM accounts"+where, from, to, filter.Role, filter.IncludeInactive, tenantID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, email, password_hash, name, phone, role, is_verified, is_active, created_at, updated_at, tenant_id
+		FROM accounts` + where + `
+		ORDER BY created_at DESC, id
+		LIMIT $6 OFFSET $7
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, filter.Role, filter.IncludeInactive, tenantID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	accounts := []*Account{}
+	for rows.Next() {
+		account := &Account{}
+		err := rows.Scan(
+			&account.ID,
+			&account.Email,
+			&account.PasswordHash,
+			&account.Name,
+			&account.Phone,
+			&account.Role,
+			&account.IsVerified,
+			&account.IsActive,
+			&account.CreatedAt,
+			&account.UpdatedAt,
+			&account.TenantID,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, total, rows.Err()
+}
+
 // Close closes the database connection
 func (r *repository) Close() error {
 	return r.db.Close()
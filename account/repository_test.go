@@ -3,8 +3,11 @@ package account
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
 	_ "github.com/lib/pq"
 )
 
@@ -30,6 +33,7 @@ func setupTestDB(t *testing.T) (*sql.DB, func()) {
 	// Cleanup function
 	cleanup := func() {
 		_, _ = db.Exec("TRUNCATE TABLE accounts CASCADE")
+		_, _ = db.Exec("TRUNCATE TABLE account_outbox")
 		db.Close()
 	}
 
@@ -214,3 +218,155 @@ func TestRepository_Delete(t *testing.T) {
 		t.Errorf("Expected ErrAccountNotFound for deleted account, got %v", err)
 	}
 }
+
+func TestRepository_OutboxEvents(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, "outbox@example.com", "password123", "Outbox User", "9999999999", "USER")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := repo.Update(ctx, created.ID, "Renamed", "9999999999"); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// A failed mutation adds no event
+	if _, err := repo.Create(ctx, "outbox@example.com", "password123", "Duplicate", "", "USER"); err != ErrEmailAlreadyExists {
+		t.Fatalf("Expected ErrEmailAlreadyExists, got %v", err)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT event_type FROM account_outbox WHERE event_key = $1 ORDER BY id", created.ID)
+	if err != nil {
+		t.Fatalf("Failed to read outbox: %v", err)
+	}
+	defer rows.Close()
+
+	var types []string
+	for rows.Next() {
+		var eventType string
+		if err := rows.Scan(&eventType); err != nil {
+			t.Fatalf("Failed to scan outbox: %v", err)
+		}
+		types = append(types, eventType)
+	}
+
+	want := []string{EventAccountCreated, EventAccountUpdated, EventAccountDeleted}
+	if strings.Join(types, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected events %v, got %v", want, types)
+	}
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM account_outbox").Scan(&total); err != nil {
+		t.Fatalf("Failed to count outbox: %v", err)
+	}
+	if total != len(want) {
+		t.Errorf("Expected %d events in outbox, got %d", len(want), total)
+	}
+}
+
+func TestRepository_TenantIsolation(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	acme := tenant.NewContext(context.Background(), "acme")
+	globex := tenant.NewContext(context.Background(), "globex")
+
+	created, err := repo.Create(acme, "shared@example.com", "password123", "Acme User", "", "USER")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if created.TenantID != "acme" {
+		t.Errorf("Expected tenant acme, got %s", created.TenantID)
+	}
+
+	// The same email may register with another store
+	if _, err := repo.Create(globex, "shared@example.com", "password456", "Globex User", "", "USER"); err != nil {
+		t.Fatalf("Create in another tenant failed: %v", err)
+	}
+
+	if _, err := repo.GetByID(globex, created.ID); err != ErrAccountNotFound {
+		t.Errorf("Expected ErrAccountNotFound across tenants, got %v", err)
+	}
+	if _, err := repo.VerifyPassword(globex, "shared@example.com", "password123"); err != ErrInvalidCredentials {
+		t.Errorf("Expected ErrInvalidCredentials across tenants, got %v", err)
+	}
+	if err := repo.Delete(globex, created.ID); err != ErrAccountNotFound {
+		t.Errorf("Expected ErrAccountNotFound deleting across tenants, got %v", err)
+	}
+
+	accounts, total, err := repo.List(acme, AccountFilter{}, 1, 10)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if total != 1 || len(accounts) != 1 || accounts[0].ID != created.ID {
+		t.Errorf("Expected only the acme account, got %+v (total %d)", accounts, total)
+	}
+}
+
+func TestRepository_AccessControlEvents(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	defer db.Exec("TRUNCATE TABLE access_control_events")
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	from := time.Now().Add(-time.Minute)
+
+	err := repo.RecordAccessControlEvent(ctx, &AccessControlEvent{
+		Action:  AccessActionDenyIP,
+		Subject: "203.0.113.0/24",
+		ActorID: "admin-1",
+		Details: "credential stuffing",
+	})
+	if err != nil {
+		t.Fatalf("RecordAccessControlEvent failed: %v", err)
+	}
+
+	events, err := repo.ListAccessControlEvents(ctx, from, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ListAccessControlEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Action != AccessActionDenyIP || events[0].ActorID != "admin-1" {
+		t.Errorf("Unexpected events %+v", events)
+	}
+}
+
+func TestRepository_List(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	from := time.Now().Add(-time.Minute)
+
+	if _, err := repo.Create(ctx, "user@example.com", "password123", "User", "", "USER"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := repo.Create(ctx, "admin@example.com", "password123", "Admin", "", "ADMIN"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	accounts, total, err := repo.List(ctx, AccountFilter{CreatedFrom: from, Role: "USER"}, 1, 10)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if total != 1 || len(accounts) != 1 || accounts[0].Email != "user@example.com" {
+		t.Errorf("Unexpected accounts %+v (total %d)", accounts, total)
+	}
+
+	_, total, err = repo.List(ctx, AccountFilter{CreatedTo: from}, 1, 10)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if total != 0 {
+		t.Errorf("Expected no accounts before the period, got %d", total)
+	}
+}
@@ -2,22 +2,37 @@ package account
 
 import (
 	"context"
+	"crypto"
 	"errors"
+	"net/http"
 	"time"
 
 	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// UserMethods are the RPCs acting on one account, which need an access
+// token; the account is the token's unless its role may act on others
+var UserMethods = []string{
+	pb.AccountService_GetProfile_FullMethodName,
+	pb.AccountService_UpdateProfile_FullMethodName,
+	pb.AccountService_ChangePassword_FullMethodName,
+	pb.AccountService_DeleteAccount_FullMethodName,
+}
+
 // Service implements the AccountService gRPC interface
 type Service struct {
 	pb.UnimplementedAccountServiceServer
 	repo         Repository
 	tokenService *auth.TokenService
+	detector     *AnomalyDetector
+	ipFilter     *ipfilter.Filter
 }
 
 // NewService creates a new account service
@@ -28,6 +43,118 @@ func NewService(repo Repository, jwtSecret string) *Service {
 	}
 }
 
+// WithAnomalyDetector enables anomaly detection and rate limiting on auth endpoints
+func (s *Service) WithAnomalyDetector(detector *AnomalyDetector) *Service {
+	s.detector = detector
+	return s
+}
+
+// WithSigningKey signs tokens with an RSA or ECDSA key instead of the JWT
+// secret, so other services verify them with the public key served by
+// JWKSHandler. Tokens signed with the secret are still accepted.
+func (s *Service) WithSigningKey(key crypto.Signer) (*Service, error) {
+	if _, err := s.tokenService.WithSigningKey(key); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddSigningKey publishes another signing key and signs tokens with it from
+// activeFrom on, so keys are rotated without rejecting tokens signed with the
+// previous one
+func (s *Service) AddSigningKey(key crypto.Signer, activeFrom time.Time) error {
+	return s.tokenService.AddSigningKey(key, activeFrom)
+}
+
+// WithIssuer sets the iss and aud claims of issued tokens and refuses tokens
+// with other values, so tokens of one environment are not accepted by another
+func (s *Service) WithIssuer(issuer, audience string) *Service {
+	s.tokenService.WithIssuer(issuer).WithAudience(audience)
+	return s
+}
+
+// WithLeeway accepts tokens up to leeway past their expiry, for clocks of
+// the services issuing and presenting them that are slightly apart
+func (s *Service) WithLeeway(leeway time.Duration) *Service {
+	s.tokenService.WithLeeway(leeway)
+	return s
+}
+
+// WithRevocationStore enables Logout and makes revoked tokens invalid
+func (s *Service) WithRevocationStore(store auth.RevocationStore) *Service {
+	s.tokenService.WithRevocationStore(store)
+	return s
+}
+
+// WithOpaqueTokens issues opaque tokens recorded in store in place of JWTs.
+// Other services validate them by sharing the store or calling Introspect.
+func (s *Service) WithOpaqueTokens(store auth.TokenStore) *Service {
+	s.tokenService.WithOpaqueTokens(store)
+	return s
+}
+
+// WithPASETO issues PASETO v4 local tokens encrypted with key in place of
+// JWTs. Other services validate them with the same key.
+func (s *Service) WithPASETO(key []byte) (*Service, error) {
+	if _, err := s.tokenService.WithPASETO(key); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// IntrospectionHandler serves token introspection (RFC 7662) over HTTP
+func (s *Service) IntrospectionHandler() http.Handler {
+	return s.tokenService.IntrospectionHandler()
+}
+
+// ClientCredentialsHandler serves the token endpoint of the client
+// credentials grant, issuing accounts short-lived SERVICE tokens valid for
+// duration
+func (s *Service) ClientCredentialsHandler(accounts []auth.ServiceAccount, duration time.Duration) http.Handler {
+	return auth.NewClientCredentials(s.tokenService, accounts, duration).TokenHandler()
+}
+
+// AuthInterceptor authenticates calls of UserMethods and AdminMethods with
+// the service's own tokens and puts their claims in the call context;
+// AdminMethods need an ADMIN token. Other RPCs check their tokens
+// themselves, if they take any.
+func (s *Service) AuthInterceptor() *auth.Interceptor {
+	roles := make(map[string][]string, len(UserMethods)+len(AdminMethods))
+	for _, m := range UserMethods {
+		roles[m] = []string{auth.RoleUser, auth.RoleAdmin, auth.RoleService}
+	}
+	for _, m := range AdminMethods {
+		roles[m] = []string{auth.RoleAdmin}
+	}
+	return auth.NewInterceptor(s.tokenService, auth.InterceptorConfig{
+		ExemptMethods: []string{"/"},
+		RequiredRoles: roles,
+	})
+}
+
+// JWKSHandler serves the public keys tokens are signed with
+func (s *Service) JWKSHandler() http.Handler {
+	return s.tokenService.JWKSHandler()
+}
+
+// checkRateLimit rejects the request if the caller is over the limit for kind
+func (s *Service) checkRateLimit(ctx context.Context, kind string) error {
+	if s.detector == nil {
+		return nil
+	}
+	if err := s.detector.Allow(ctx, kind, clientIP(ctx)); err != nil {
+		return status.Error(codes.ResourceExhausted, "too many requests, try again later")
+	}
+	return nil
+}
+
+// recordAuthEvent counts an auth event from the caller
+func (s *Service) recordAuthEvent(ctx context.Context, kind string) {
+	if s.detector != nil {
+		s.detector.Record(ctx, kind, clientIP(ctx))
+	}
+}
+
 // Register creates a new user account
 func (s *Service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	// Validate input
@@ -35,6 +162,11 @@ func (s *Service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Re
 		return nil, status.Error(codes.InvalidArgument, "email, password, and name are required")
 	}
 
+	if err := s.checkRateLimit(ctx, EventRegistration); err != nil {
+		return nil, err
+	}
+	s.recordAuthEvent(ctx, EventRegistration)
+
 	// Create account with default USER role
 	account, err := s.repo.Create(ctx, req.Email, req.Password, req.Name, req.Phone, "USER")
 	if err != nil {
@@ -45,7 +177,7 @@ func (s *Service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Re
 	}
 
 	// Generate tokens using auth package with account role
-	accessToken, refreshToken, err := s.tokenService.GenerateTokenPair(account.ID, account.Email, account.Role)
+	accessToken, refreshToken, err := s.tokenService.GenerateTenantTokenPair(account.TenantID, account.ID, account.Email, account.Role)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate tokens")
 	}
@@ -61,6 +193,7 @@ func (s *Service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Re
 			UpdatedAt:  timestamppb.New(account.UpdatedAt),
 			IsVerified: account.IsVerified,
 			IsActive:   account.IsActive,
+			TenantId:   account.TenantID,
 		},
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -73,17 +206,22 @@ func (s *Service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRes
 		return nil, status.Error(codes.InvalidArgument, "email and password are required")
 	}
 
+	if err := s.checkRateLimit(ctx, EventLoginFailure); err != nil {
+		return nil, err
+	}
+
 	// Verify credentials
 	account, err := s.repo.VerifyPassword(ctx, req.Email, req.Password)
 	if err != nil {
 		if errors.Is(err, ErrInvalidCredentials) {
+			s.recordAuthEvent(ctx, EventLoginFailure)
 			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 		}
 		return nil, status.Error(codes.Internal, "failed to verify credentials")
 	}
 
 	// Generate tokens using auth package with account role
-	accessToken, refreshToken, err := s.tokenService.GenerateTokenPair(account.ID, account.Email, account.Role)
+	accessToken, refreshToken, err := s.tokenService.GenerateTenantTokenPair(account.TenantID, account.ID, account.Email, account.Role)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate tokens")
 	}
@@ -99,17 +237,44 @@ func (s *Service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRes
 			UpdatedAt:  timestamppb.New(account.UpdatedAt),
 			IsVerified: account.IsVerified,
 			IsActive:   account.IsActive,
+			TenantId:   account.TenantID,
 		},
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
 }
 
-// GetProfile retrieves user profile
+// authorizeUser checks that the caller's validated token, put in ctx by the
+// auth interceptor, belongs to userID or carries one of roles, which may act
+// on any account of the tenant
+func (s *Service) authorizeUser(ctx context.Context, userID string, roles ...string) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	if claims.Role != auth.RoleService && tenant.OrDefault(claims.TenantID) != tenant.FromContext(ctx) {
+		return status.Error(codes.PermissionDenied, "token belongs to another tenant")
+	}
+	if claims.UserID == userID {
+		return nil
+	}
+	for _, role := range roles {
+		if claims.Role == role {
+			return nil
+		}
+	}
+	return status.Error(codes.PermissionDenied, "cannot access another user's account")
+}
+
+// GetProfile retrieves user profile. Users read their own profile; admins
+// and services may read any.
 func (s *Service) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
 	if req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
+	if err := s.authorizeUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService); err != nil {
+		return nil, err
+	}
 
 	account, err := s.repo.GetByID(ctx, req.UserId)
 	if err != nil {
@@ -130,15 +295,20 @@ func (s *Service) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*p
 			UpdatedAt:  timestamppb.New(account.UpdatedAt),
 			IsVerified: account.IsVerified,
 			IsActive:   account.IsActive,
+			TenantId:   account.TenantID,
 		},
 	}, nil
 }
 
-// UpdateProfile updates user profile information
+// UpdateProfile updates user profile information of the caller, or of any
+// user for admins
 func (s *Service) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
 	if req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
+	if err := s.authorizeUser(ctx, req.UserId, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
 
 	account, err := s.repo.Update(ctx, req.UserId, req.Name, req.Phone)
 	if err != nil {
@@ -159,15 +329,19 @@ func (s *Service) UpdateProfile(ctx context.Context, req *pb.UpdateProfileReques
 			UpdatedAt:  timestamppb.New(account.UpdatedAt),
 			IsVerified: account.IsVerified,
 			IsActive:   account.IsActive,
+			TenantId:   account.TenantID,
 		},
 	}, nil
 }
 
-// ChangePassword changes user password
+// ChangePassword changes the caller's password
 func (s *Service) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
 	if req.UserId == "" || req.OldPassword == "" || req.NewPassword == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id, old_password, and new_password are required")
 	}
+	if err := s.authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
 
 	// Get account
 	account, err := s.repo.GetByID(ctx, req.UserId)
@@ -202,11 +376,14 @@ func (s *Service) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequ
 	}, nil
 }
 
-// DeleteAccount soft-deletes a user account
+// DeleteAccount soft-deletes the caller's account, or any account for admins
 func (s *Service) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
 	if req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
+	if err := s.authorizeUser(ctx, req.UserId, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
 
 	err := s.repo.Delete(ctx, req.UserId)
 	if err != nil {
@@ -228,17 +405,30 @@ func (s *Service) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (
 		return nil, status.Error(codes.InvalidArgument, "token is required")
 	}
 
+	// A token of another tenant is not valid for this one
 	claims, err := s.tokenService.ValidateToken(req.Token)
-	if err != nil {
+	if errors.Is(err, auth.ErrRevocationUnavailable) {
+		return nil, status.Error(codes.Unavailable, "failed to check token revocation")
+	}
+	if errors.Is(err, auth.ErrTokenStoreUnavailable) {
+		return nil, status.Error(codes.Unavailable, "failed to look up token")
+	}
+	if err != nil || tenant.OrDefault(claims.TenantID) != tenant.FromContext(ctx) {
 		return &pb.VerifyTokenResponse{
 			Valid: false,
 		}, nil
 	}
 
 	return &pb.VerifyTokenResponse{
-		Valid:     true,
-		UserId:    claims.UserID,
-		ExpiresAt: timestamppb.New(claims.ExpiresAt.Time),
+		Valid:       true,
+		UserId:      claims.UserID,
+		ExpiresAt:   timestamppb.New(claims.ExpiresAt.Time),
+		TenantId:    tenant.OrDefault(claims.TenantID),
+		Email:       claims.Email,
+		Role:        claims.Role,
+		TokenId:     claims.ID,
+		Scope:       claims.Scope,
+		Permissions: claims.Permissions,
 	}, nil
 }
 
@@ -248,16 +438,31 @@ func (s *Service) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest)
 		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
 	}
 
+	if err := s.checkRateLimit(ctx, EventTokenRefresh); err != nil {
+		return nil, err
+	}
+	s.recordAuthEvent(ctx, EventTokenRefresh)
+
 	claims, err := s.tokenService.ValidateToken(req.RefreshToken)
 	if err != nil {
 		if errors.Is(err, auth.ErrTokenExpired) {
 			return nil, status.Error(codes.Unauthenticated, "refresh token expired")
 		}
+		if errors.Is(err, auth.ErrRevocationUnavailable) {
+			return nil, status.Error(codes.Unavailable, "failed to check token revocation")
+		}
+		if errors.Is(err, auth.ErrTokenStoreUnavailable) {
+			return nil, status.Error(codes.Unavailable, "failed to look up token")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	tenantID := tenant.OrDefault(claims.TenantID)
+	if tenantID != tenant.FromContext(ctx) {
 		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
 	}
 
 	// Generate new tokens using auth package
-	accessToken, refreshToken, err := s.tokenService.GenerateTokenPair(claims.UserID, claims.Email, claims.Role)
+	accessToken, refreshToken, err := s.tokenService.GenerateTenantTokenPair(tenantID, claims.UserID, claims.Email, claims.Role)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate tokens")
 	}
@@ -267,3 +472,131 @@ func (s *Service) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest)
 		RefreshToken: refreshToken,
 	}, nil
 }
+
+// Logout revokes the given access and refresh tokens until they expire, so
+// every service sharing the revocation list refuses them. Tokens that have
+// already expired or been revoked need no revocation.
+func (s *Service) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	if req.AccessToken == "" && req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "access_token or refresh_token is required")
+	}
+
+	for _, token := range []string{req.AccessToken, req.RefreshToken} {
+		if token == "" {
+			continue
+		}
+		claims, err := s.tokenService.GetClaimsFromToken(token)
+		if err != nil || tenant.OrDefault(claims.TenantID) != tenant.FromContext(ctx) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		err = s.tokenService.Revoke(ctx, token)
+		switch {
+		case errors.Is(err, auth.ErrRevocationNotConfigured):
+			return nil, status.Error(codes.FailedPrecondition, "token revocation is not enabled")
+		case errors.Is(err, auth.ErrNoTokenID):
+			return nil, status.Error(codes.FailedPrecondition, "token was issued before tokens could be revoked")
+		case err != nil:
+			return nil, status.Error(codes.Internal, "failed to revoke token")
+		}
+	}
+
+	return &pb.LogoutResponse{Success: true}, nil
+}
+
+// Introspect returns the state and claims of a token, opaque or JWT, for
+// services that cannot validate tokens themselves. The caller's bearer token
+// must carry the SERVICE or ADMIN role.
+func (s *Service) Introspect(ctx context.Context, req *pb.IntrospectRequest) (*pb.IntrospectResponse, error) {
+	caller, err := s.tokenService.ValidateToken(auth.BearerToken(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid caller token")
+	}
+	if caller.Role != auth.RoleService && caller.Role != auth.RoleAdmin {
+		return nil, status.Error(codes.PermissionDenied, "service or admin role required")
+	}
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	in, err := s.tokenService.Introspect(req.Token)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "failed to introspect token")
+	}
+	// A token of another tenant is not active for this one
+	if !in.Active || tenant.OrDefault(in.TenantID) != tenant.FromContext(ctx) {
+		return &pb.IntrospectResponse{Active: false}, nil
+	}
+
+	return &pb.IntrospectResponse{
+		Active:    true,
+		UserId:    in.Subject,
+		Email:     in.Email,
+		Role:      in.Role,
+		TenantId:  tenant.OrDefault(in.TenantID),
+		TokenId:   in.TokenID,
+		IssuedAt:  timestamppb.New(time.Unix(in.IssuedAt, 0)),
+		ExpiresAt: timestamppb.New(time.Unix(in.Expiry, 0)),
+	}, nil
+}
+
+// ListAccounts lists the accounts registered in a period, newest first
+func (s *Service) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Role != "" && req.Role != "USER" && req.Role != "ADMIN" {
+		return nil, status.Error(codes.InvalidArgument, "role must be USER or ADMIN")
+	}
+
+	filter := AccountFilter{Role: req.Role, IncludeInactive: req.IncludeInactive}
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = req.CreatedTo.AsTime()
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return nil, status.Error(codes.InvalidArgument, "created_from must be before created_to")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	accounts, total, err := s.repo.List(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list accounts")
+	}
+
+	users := make([]*pb.User, len(accounts))
+	for i, account := range accounts {
+		users[i] = &pb.User{
+			Id:         account.ID,
+			Email:      account.Email,
+			Name:       account.Name,
+			Phone:      account.Phone,
+			Role:       account.Role,
+			CreatedAt:  timestamppb.New(account.CreatedAt),
+			UpdatedAt:  timestamppb.New(account.UpdatedAt),
+			IsVerified: account.IsVerified,
+			IsActive:   account.IsActive,
+			TenantId:   account.TenantID,
+		}
+	}
+
+	return &pb.ListAccountsResponse{
+		Users:    users,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
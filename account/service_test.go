@@ -7,7 +7,11 @@ import (
 	"time"
 
 	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -21,7 +25,11 @@ type mockRepository struct {
 	updatePasswordFunc func(ctx context.Context, id, newPasswordHash string) error
 	deleteFunc         func(ctx context.Context, id string) error
 	verifyPasswordFunc func(ctx context.Context, email, password string) (*Account, error)
+	listFunc           func(ctx context.Context, filter AccountFilter, page, pageSize int32) ([]*Account, int32, error)
 	closeFunc          func() error
+
+	recordAccessControlEventFunc func(ctx context.Context, event *AccessControlEvent) error
+	listAccessControlEventsFunc  func(ctx context.Context, from, to time.Time) ([]*AccessControlEvent, error)
 }
 
 func (m *mockRepository) Create(ctx context.Context, email, password, name, phone, role string) (*Account, error) {
@@ -73,6 +81,27 @@ func (m *mockRepository) VerifyPassword(ctx context.Context, email, password str
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockRepository) List(ctx context.Context, filter AccountFilter, page, pageSize int32) ([]*Account, int32, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, filter, page, pageSize)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *mockRepository) RecordAccessControlEvent(ctx context.Context, event *AccessControlEvent) error {
+	if m.recordAccessControlEventFunc != nil {
+		return m.recordAccessControlEventFunc(ctx, event)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockRepository) ListAccessControlEvents(ctx context.Context, from, to time.Time) ([]*AccessControlEvent, error) {
+	if m.listAccessControlEventsFunc != nil {
+		return m.listAccessControlEventsFunc(ctx, from, to)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockRepository) Close() error {
 	if m.closeFunc != nil {
 		return m.closeFunc()
@@ -239,6 +268,11 @@ func TestService_Login_InvalidCredentials(t *testing.T) {
 	}
 }
 
+// userContext returns a context carrying the claims of a USER token of userID
+func userContext(userID string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: userID, Role: auth.RoleUser})
+}
+
 func TestService_GetProfile_Success(t *testing.T) {
 	mockRepo := &mockRepository{
 		getByIDFunc: func(ctx context.Context, id string) (*Account, error) {
@@ -257,7 +291,7 @@ func TestService_GetProfile_Success(t *testing.T) {
 	}
 
 	service := NewService(mockRepo, "test-secret")
-	ctx := context.Background()
+	ctx := userContext("test-id-123")
 
 	req := &pb.GetProfileRequest{
 		UserId: "test-id-123",
@@ -281,7 +315,7 @@ func TestService_GetProfile_NotFound(t *testing.T) {
 	}
 
 	service := NewService(mockRepo, "test-secret")
-	ctx := context.Background()
+	ctx := userContext("nonexistent-id")
 
 	req := &pb.GetProfileRequest{
 		UserId: "nonexistent-id",
@@ -316,7 +350,7 @@ func TestService_UpdateProfile_Success(t *testing.T) {
 	}
 
 	service := NewService(mockRepo, "test-secret")
-	ctx := context.Background()
+	ctx := userContext("test-id-123")
 
 	req := &pb.UpdateProfileRequest{
 		UserId: "test-id-123",
@@ -356,7 +390,7 @@ func TestService_ChangePassword_Success(t *testing.T) {
 	}
 
 	service := NewService(mockRepo, "test-secret")
-	ctx := context.Background()
+	ctx := userContext("test-id-123")
 
 	req := &pb.ChangePasswordRequest{
 		UserId:      "test-id-123",
@@ -385,7 +419,7 @@ func TestService_ChangePassword_WrongOldPassword(t *testing.T) {
 	}
 
 	service := NewService(mockRepo, "test-secret")
-	ctx := context.Background()
+	ctx := userContext("test-id-123")
 
 	req := &pb.ChangePasswordRequest{
 		UserId:      "test-id-123",
@@ -412,7 +446,7 @@ func TestService_DeleteAccount_Success(t *testing.T) {
 	}
 
 	service := NewService(mockRepo, "test-secret")
-	ctx := context.Background()
+	ctx := userContext("test-id-123")
 
 	req := &pb.DeleteAccountRequest{
 		UserId: "test-id-123",
@@ -424,6 +458,94 @@ func TestService_DeleteAccount_Success(t *testing.T) {
 	}
 }
 
+func TestService_AuthorizeUser(t *testing.T) {
+	mockRepo := &mockRepository{
+		getByIDFunc: func(ctx context.Context, id string) (*Account, error) {
+			return &Account{ID: id, Email: "test@example.com", Role: "USER", IsActive: true}, nil
+		},
+		deleteFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+	}
+	service := NewService(mockRepo, "test-secret")
+	withClaims := func(claims *auth.Claims) context.Context {
+		return auth.ContextWithClaims(context.Background(), claims)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		call     func(ctx context.Context) error
+		expected codes.Code
+	}{
+		{"no token", context.Background(), func(ctx context.Context) error {
+			_, err := service.GetProfile(ctx, &pb.GetProfileRequest{UserId: "user-1"})
+			return err
+		}, codes.Unauthenticated},
+		{"other user", userContext("user-2"), func(ctx context.Context) error {
+			_, err := service.GetProfile(ctx, &pb.GetProfileRequest{UserId: "user-1"})
+			return err
+		}, codes.PermissionDenied},
+		{"other tenant", withClaims(&auth.Claims{UserID: "user-1", Role: auth.RoleUser, TenantID: "acme"}), func(ctx context.Context) error {
+			_, err := service.GetProfile(ctx, &pb.GetProfileRequest{UserId: "user-1"})
+			return err
+		}, codes.PermissionDenied},
+		{"admin reads any profile", withClaims(&auth.Claims{UserID: "admin-1", Role: auth.RoleAdmin}), func(ctx context.Context) error {
+			_, err := service.GetProfile(ctx, &pb.GetProfileRequest{UserId: "user-1"})
+			return err
+		}, codes.OK},
+		{"service reads any profile", withClaims(&auth.Claims{UserID: "notification-service", Role: auth.RoleService}), func(ctx context.Context) error {
+			_, err := service.GetProfile(ctx, &pb.GetProfileRequest{UserId: "user-1"})
+			return err
+		}, codes.OK},
+		{"service cannot delete accounts", withClaims(&auth.Claims{UserID: "notification-service", Role: auth.RoleService}), func(ctx context.Context) error {
+			_, err := service.DeleteAccount(ctx, &pb.DeleteAccountRequest{UserId: "user-1"})
+			return err
+		}, codes.PermissionDenied},
+		{"other user cannot update", userContext("user-2"), func(ctx context.Context) error {
+			_, err := service.UpdateProfile(ctx, &pb.UpdateProfileRequest{UserId: "user-1", Name: "Mallory"})
+			return err
+		}, codes.PermissionDenied},
+		{"admin cannot change passwords", withClaims(&auth.Claims{UserID: "admin-1", Role: auth.RoleAdmin}), func(ctx context.Context) error {
+			_, err := service.ChangePassword(ctx, &pb.ChangePasswordRequest{UserId: "user-1", OldPassword: "x", NewPassword: "y"})
+			return err
+		}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(tt.ctx); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestService_AuthInterceptor(t *testing.T) {
+	service := NewService(&mockRepository{}, "test-secret")
+	unary := service.AuthInterceptor().UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id, _ := auth.UserIDFromContext(ctx)
+		return id, nil
+	}
+
+	if _, err := unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: pb.AccountService_GetProfile_FullMethodName}, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: pb.AccountService_Login_FullMethodName}, handler); err != nil {
+		t.Errorf("Expected Login to pass without a token, got %v", err)
+	}
+
+	token, _ := service.tokenService.GenerateAccessToken("user-123", "test@example.com", "USER")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	resp, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.AccountService_GetProfile_FullMethodName}, handler)
+	if err != nil || resp != "user-123" {
+		t.Errorf("Expected the token's user ID in the context, got %v, %v", resp, err)
+	}
+	if _, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.AccountService_DenyIP_FullMethodName}, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a customer calling DenyIP, got %v", err)
+	}
+}
+
 func TestService_VerifyToken_ValidToken(t *testing.T) {
 	mockRepo := &mockRepository{}
 	service := NewService(mockRepo, "test-secret")
@@ -450,6 +572,9 @@ func TestService_VerifyToken_ValidToken(t *testing.T) {
 	if resp.UserId != "user-123" {
 		t.Errorf("Expected user ID user-123, got %s", resp.UserId)
 	}
+	if resp.Role != "USER" || resp.Email != "test@example.com" || resp.TokenId == "" {
+		t.Errorf("Expected the token's claims, got %+v", resp)
+	}
 }
 
 func TestService_VerifyToken_InvalidToken(t *testing.T) {
@@ -471,6 +596,33 @@ func TestService_VerifyToken_InvalidToken(t *testing.T) {
 	}
 }
 
+func TestService_VerifyToken_OtherTenant(t *testing.T) {
+	mockRepo := &mockRepository{}
+	service := NewService(mockRepo, "test-secret")
+
+	token, _, err := service.tokenService.GenerateTenantTokenPair("acme", "user-123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	resp, err := service.VerifyToken(tenant.NewContext(context.Background(), "acme"), &pb.VerifyTokenRequest{Token: token})
+	if err != nil {
+		t.Fatalf("VerifyToken failed: %v", err)
+	}
+	if !resp.Valid || resp.TenantId != "acme" {
+		t.Errorf("Expected token valid for acme, got %+v", resp)
+	}
+
+	// The token of an acme user is not valid on another store
+	resp, err = service.VerifyToken(context.Background(), &pb.VerifyTokenRequest{Token: token})
+	if err != nil {
+		t.Fatalf("VerifyToken returned error: %v", err)
+	}
+	if resp.Valid {
+		t.Error("Expected token of another tenant to be invalid")
+	}
+}
+
 func TestService_RefreshToken_Success(t *testing.T) {
 	mockRepo := &mockRepository{}
 	service := NewService(mockRepo, "test-secret")
@@ -519,6 +671,183 @@ func TestService_RefreshToken_InvalidToken(t *testing.T) {
 	}
 }
 
+// memoryRevocations is an in-memory auth.RevocationStore
+type memoryRevocations struct {
+	revoked map[string]time.Time
+	err     error
+}
+
+func (m *memoryRevocations) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.revoked[jti] = expiresAt
+	return nil
+}
+
+func (m *memoryRevocations) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	_, ok := m.revoked[jti]
+	return ok, nil
+}
+
+func TestService_Logout(t *testing.T) {
+	revocations := &memoryRevocations{revoked: map[string]time.Time{}}
+	service := NewService(&mockRepository{}, "test-secret").WithRevocationStore(revocations)
+	ctx := context.Background()
+
+	accessToken, refreshToken, err := service.tokenService.GenerateTokenPair("user-123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	resp, err := service.Logout(ctx, &pb.LogoutRequest{AccessToken: accessToken, RefreshToken: refreshToken})
+	if err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	if !resp.Success || len(revocations.revoked) != 2 {
+		t.Errorf("Expected both tokens to be revoked, got %v", revocations.revoked)
+	}
+
+	verify, err := service.VerifyToken(ctx, &pb.VerifyTokenRequest{Token: accessToken})
+	if err != nil || verify.Valid {
+		t.Errorf("Expected revoked access token to be invalid, got %v, %v", verify, err)
+	}
+	if _, err := service.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshToken}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for a revoked refresh token, got %v", err)
+	}
+
+	// Logging out again is harmless
+	if _, err := service.Logout(ctx, &pb.LogoutRequest{RefreshToken: refreshToken}); err != nil {
+		t.Errorf("Expected second logout to succeed, got %v", err)
+	}
+
+	// Without the revocation list tokens cannot be checked and are refused
+	revocations.err = errors.New("connection refused")
+	_, fresh, _ := service.tokenService.GenerateTokenPair("user-123", "test@example.com", "USER")
+	if _, err := service.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: fresh}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+	if _, err := service.Logout(ctx, &pb.LogoutRequest{RefreshToken: fresh}); status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+}
+
+func TestService_Logout_Rejected(t *testing.T) {
+	service := NewService(&mockRepository{}, "test-secret")
+	accessToken, _, _ := service.tokenService.GenerateTenantTokenPair("acme", "user-123", "test@example.com", "USER")
+
+	if _, err := service.Logout(tenant.NewContext(context.Background(), "acme"), &pb.LogoutRequest{AccessToken: accessToken}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without a revocation store, got %v", err)
+	}
+
+	service.WithRevocationStore(&memoryRevocations{revoked: map[string]time.Time{}})
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.LogoutRequest
+		expected codes.Code
+	}{
+		{"no tokens", tenant.NewContext(context.Background(), "acme"), &pb.LogoutRequest{}, codes.InvalidArgument},
+		{"invalid token", tenant.NewContext(context.Background(), "acme"), &pb.LogoutRequest{AccessToken: "invalid.token"}, codes.Unauthenticated},
+		{"other tenant", tenant.NewContext(context.Background(), "globex"), &pb.LogoutRequest{AccessToken: accessToken}, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Logout(tt.ctx, tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// memoryTokens is an in-memory auth.TokenStore
+type memoryTokens struct {
+	records map[string]*auth.Claims
+}
+
+func (m *memoryTokens) Save(ctx context.Context, hash string, claims *auth.Claims) error {
+	m.records[hash] = claims
+	return nil
+}
+
+func (m *memoryTokens) Get(ctx context.Context, hash string) (*auth.Claims, error) {
+	return m.records[hash], nil
+}
+
+func (m *memoryTokens) Delete(ctx context.Context, hash string) error {
+	delete(m.records, hash)
+	return nil
+}
+
+func TestService_Introspect(t *testing.T) {
+	service := NewService(&mockRepository{}, "test-secret").WithOpaqueTokens(&memoryTokens{records: map[string]*auth.Claims{}})
+	accessToken, refreshToken, err := service.tokenService.GenerateTokenPair("user-123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if !auth.IsOpaque(accessToken) {
+		t.Fatalf("Expected an opaque token, got %s", accessToken)
+	}
+	caller, _ := auth.NewServiceTokens(service.tokenService, "order-service").Token()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+caller))
+
+	resp, err := service.Introspect(ctx, &pb.IntrospectRequest{Token: accessToken})
+	if err != nil {
+		t.Fatalf("Introspect failed: %v", err)
+	}
+	if !resp.Active || resp.UserId != "user-123" || resp.Role != "USER" || resp.TenantId != "default" || resp.TokenId == "" {
+		t.Errorf("Unexpected introspection %v", resp)
+	}
+
+	// Logout deletes the records without a revocation list
+	if _, err := service.Logout(context.Background(), &pb.LogoutRequest{AccessToken: accessToken, RefreshToken: refreshToken}); err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	resp, err = service.Introspect(ctx, &pb.IntrospectRequest{Token: accessToken})
+	if err != nil || resp.Active {
+		t.Errorf("Expected logged out token to be inactive, got %v, %v", resp, err)
+	}
+	if _, err := service.RefreshToken(context.Background(), &pb.RefreshTokenRequest{RefreshToken: refreshToken}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for a logged out refresh token, got %v", err)
+	}
+}
+
+func TestService_Introspect_Rejected(t *testing.T) {
+	service := NewService(&mockRepository{}, "test-secret")
+	user, _ := service.tokenService.GenerateAccessToken("user-123", "test@example.com", "USER")
+	withCaller := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.IntrospectRequest
+		expected codes.Code
+	}{
+		{"no caller token", context.Background(), &pb.IntrospectRequest{Token: user}, codes.Unauthenticated},
+		{"user caller", withCaller(user), &pb.IntrospectRequest{Token: user}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Introspect(tt.ctx, tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// A token of another tenant is inactive
+	admin, _ := service.tokenService.GenerateAccessToken("admin-1", "admin@example.com", "ADMIN")
+	other, _, _ := service.tokenService.GenerateTenantTokenPair("acme", "user-123", "test@example.com", "USER")
+	resp, err := service.Introspect(withCaller(admin), &pb.IntrospectRequest{Token: other})
+	if err != nil || resp.Active {
+		t.Errorf("Expected token of another tenant to be inactive, got %v, %v", resp, err)
+	}
+}
+
 func TestService_AllEndpoints_Coverage(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -650,3 +979,86 @@ func testVerifyTokenEmpty(t *testing.T, service *Service) {
 func mustTimestamp(t time.Time) *timestamppb.Timestamp {
 	return timestamppb.New(t)
 }
+
+func TestService_ListAccounts(t *testing.T) {
+	var gotFilter AccountFilter
+	mockRepo := &mockRepository{
+		listFunc: func(ctx context.Context, filter AccountFilter, page, pageSize int32) ([]*Account, int32, error) {
+			gotFilter = filter
+			if page != 1 || pageSize != 10 {
+				t.Errorf("expected page 1 of 10, got %d of %d", page, pageSize)
+			}
+			return []*Account{{ID: "user-2", Email: "new@example.com", Role: "USER", IsActive: true, CreatedAt: time.Now()}}, 1, nil
+		},
+	}
+	service := NewService(mockRepo, "test-secret")
+
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	resp, err := service.ListAccounts(contextWithRole("ADMIN"), &pb.ListAccountsRequest{
+		CreatedFrom: timestamppb.New(from),
+		CreatedTo:   timestamppb.New(to),
+		Role:        "USER",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.Total != 1 || len(resp.Users) != 1 || resp.Users[0].Email != "new@example.com" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if !gotFilter.CreatedFrom.Equal(from) || !gotFilter.CreatedTo.Equal(to) || gotFilter.Role != "USER" || gotFilter.IncludeInactive {
+		t.Errorf("unexpected filter %+v", gotFilter)
+	}
+}
+
+func TestService_ListAccounts_Rejected(t *testing.T) {
+	service := NewService(&mockRepository{}, "test-secret")
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.ListAccountsRequest
+		expected codes.Code
+	}{
+		{"no token", context.Background(), &pb.ListAccountsRequest{}, codes.Unauthenticated},
+		{"not admin", contextWithRole("USER"), &pb.ListAccountsRequest{}, codes.PermissionDenied},
+		{"unknown role", contextWithRole("ADMIN"), &pb.ListAccountsRequest{Role: "OWNER"}, codes.InvalidArgument},
+		{"empty period", contextWithRole("ADMIN"), &pb.ListAccountsRequest{CreatedFrom: timestamppb.New(from), CreatedTo: timestamppb.New(from)}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ListAccounts(tt.ctx, tt.req)
+			if status.Code(err) != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestService_RefreshToken_OtherTenant(t *testing.T) {
+	mockRepo := &mockRepository{}
+	service := NewService(mockRepo, "test-secret")
+
+	_, refreshToken, err := service.tokenService.GenerateTenantTokenPair("acme", "user-123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	_, err = service.RefreshToken(tenant.NewContext(context.Background(), "globex"), &pb.RefreshTokenRequest{RefreshToken: refreshToken})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated error, got %v", err)
+	}
+
+	resp, err := service.RefreshToken(tenant.NewContext(context.Background(), "acme"), &pb.RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	claims, err := service.tokenService.ValidateToken(resp.AccessToken)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+	if claims.TenantID != "acme" {
+		t.Errorf("Expected refreshed token for acme, got %q", claims.TenantID)
+	}
+}
@@ -0,0 +1,39 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RemoteVerifier verifies tokens with the VerifyToken RPC of the account
+// service, for the auth.Verifier of the services that embed one
+func RemoteVerifier(client pb.AccountServiceClient) auth.RemoteVerifier {
+	return func(ctx context.Context, tokenString string) (*auth.Claims, error) {
+		resp, err := client.VerifyToken(ctx, &pb.VerifyTokenRequest{Token: tokenString})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", auth.ErrVerifierUnavailable, err)
+		}
+		if !resp.Valid {
+			return nil, auth.ErrInvalidToken
+		}
+		claims := &auth.Claims{
+			UserID:      resp.UserId,
+			Email:       resp.Email,
+			Role:        resp.Role,
+			TenantID:    resp.TenantId,
+			Scope:       resp.Scope,
+			Permissions: resp.Permissions,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID: resp.TokenId,
+			},
+		}
+		if resp.ExpiresAt != nil {
+			claims.ExpiresAt = jwt.NewNumericDate(resp.ExpiresAt.AsTime())
+		}
+		return claims, nil
+	}
+}
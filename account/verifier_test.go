@@ -0,0 +1,61 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// verifyClient answers VerifyToken with resp or err
+type verifyClient struct {
+	pb.AccountServiceClient
+	resp *pb.VerifyTokenResponse
+	err  error
+}
+
+func (c *verifyClient) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest, opts ...grpc.CallOption) (*pb.VerifyTokenResponse, error) {
+	return c.resp, c.err
+}
+
+func TestRemoteVerifier(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	client := &verifyClient{resp: &pb.VerifyTokenResponse{
+		Valid:       true,
+		UserId:      "user-1",
+		Email:       "user@example.com",
+		Role:        auth.RoleAdmin,
+		TenantId:    "acme",
+		TokenId:     "token-1",
+		Scope:       "catalog:read",
+		Permissions: []string{"catalog:product:write"},
+		ExpiresAt:   timestamppb.New(expiresAt),
+	}}
+	verify := RemoteVerifier(client)
+
+	claims, err := verify(context.Background(), "opaque_token")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if claims.UserID != "user-1" || claims.Role != auth.RoleAdmin || claims.TenantID != "acme" || claims.ID != "token-1" ||
+		claims.Scope != "catalog:read" || len(claims.Permissions) != 1 || !claims.ExpiresAt.Time.Equal(expiresAt) {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+
+	client.resp = &pb.VerifyTokenResponse{Valid: false}
+	if _, err := verify(context.Background(), "opaque_token"); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+
+	client.err = status.Error(codes.Unavailable, "connection refused")
+	if _, err := verify(context.Background(), "opaque_token"); !errors.Is(err, auth.ErrVerifierUnavailable) {
+		t.Errorf("Expected ErrVerifierUnavailable, got %v", err)
+	}
+}
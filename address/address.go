@@ -0,0 +1,80 @@
+package address
+
+import (
+	"strings"
+)
+
+// Issue codes
+const (
+	IssueMissing        = "MISSING"
+	IssueTooLong        = "TOO_LONG"
+	IssueInvalidFormat  = "INVALID_FORMAT"
+	IssueUnknownCountry = "UNKNOWN_COUNTRY"
+	IssueUnknownState   = "UNKNOWN_STATE"
+	IssueUndeliverable  = "UNDELIVERABLE"
+	IssueCorrected      = "CORRECTED"
+)
+
+// SourceRules is the source of results from the offline rules
+const SourceRules = "rules"
+
+// Address is a postal address
+type Address struct {
+	Name       string
+	Street1    string
+	Street2    string
+	City       string
+	State      string
+	PostalCode string
+	// Country is an ISO 3166 alpha-2 code
+	Country string
+}
+
+// Issue is a problem found with an address. Errors make the address invalid;
+// other issues are standardizations or doubts.
+type Issue struct {
+	// Field is the proto name of the field, or empty for the whole address
+	Field   string
+	Code    string
+	Message string
+	Error   bool
+}
+
+// Result is the outcome of validating an address
+type Result struct {
+	Valid bool
+	// Address is the standardized address
+	Address Address
+	// Confidence that the address is deliverable, from 0 to 1
+	Confidence float64
+	Issues     []Issue
+	Source     string
+}
+
+// hasErrors reports whether any issue is an error
+func hasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Error {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize trims every field and collapses runs of whitespace, and
+// upper-cases the country and postal code
+func normalize(a Address) Address {
+	return Address{
+		Name:       collapse(a.Name),
+		Street1:    collapse(a.Street1),
+		Street2:    collapse(a.Street2),
+		City:       collapse(a.City),
+		State:      collapse(a.State),
+		PostalCode: strings.ToUpper(collapse(a.PostalCode)),
+		Country:    strings.ToUpper(collapse(a.Country)),
+	}
+}
+
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
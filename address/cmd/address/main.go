@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/address"
+	"github.com/Ujjwaljain16/E-commerce-Backend/address/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("address-service")
+	log.Info(ctx, "Starting Address Service", nil)
+
+	// Get configuration from environment
+	port := getEnv("PORT", "50075")
+	metricsPort := getEnv("METRICS_PORT", "9114")
+
+	// Configure the provider addresses are verified with, if any
+	provider, err := newProvider()
+	if err != nil {
+		log.Error(ctx, "Failed to configure address provider", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+	providerName := address.SourceRules
+	if provider != nil {
+		providerName = provider.Name()
+	}
+
+	service := address.NewService(provider, log)
+
+	// IP filtering: the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, nil)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Create gRPC server with metrics and IP filter interceptors
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("address-service"),
+			ipFilter.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterAddressServiceServer(grpcServer, service)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("address.AddressService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Enable reflection for grpcurl/grpcui
+	reflection.Register(grpcServer)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start gRPC server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Error(ctx, "Failed to listen", map[string]interface{}{
+			"error": err.Error(),
+			"port":  port,
+		})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "Address Service listening", map[string]interface{}{
+		"port":         port,
+		"metrics_port": metricsPort,
+		"provider":     providerName,
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		grpcServer.GracefulStop()
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+// newProvider builds the address provider from the environment:
+// ADDRESS_PROVIDER=easypost verifies addresses through EasyPost with
+// EASYPOST_API_KEY; without ADDRESS_PROVIDER only the offline rules are used
+func newProvider() (address.Provider, error) {
+	switch os.Getenv("ADDRESS_PROVIDER") {
+	case "":
+		return nil, nil
+	case "easypost":
+		return address.NewEasyPostProvider(getEnv("EASYPOST_URL", address.EasyPostURL), os.Getenv("EASYPOST_API_KEY"))
+	}
+	return nil, errors.New("ADDRESS_PROVIDER must be easypost or empty")
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
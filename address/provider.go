@@ -0,0 +1,190 @@
+package address
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EasyPostURL is EasyPost's API
+const EasyPostURL = "https://api.easypost.com"
+
+const (
+	// maxResponseBytes caps the size of a provider response
+	maxResponseBytes = 1 << 20
+	// verifiedConfidence is the confidence in an address the provider
+	// verified as deliverable
+	verifiedConfidence = 0.95
+)
+
+// Provider checks addresses against an address verification service
+type Provider interface {
+	// Name identifies the provider in results
+	Name() string
+	// Validate checks an address the offline rules passed. It returns an
+	// error only when the provider could not give an answer.
+	Validate(ctx context.Context, a Address) (*Result, error)
+}
+
+type easyPostProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewEasyPostProvider creates a provider verifying addresses for delivery
+// through EasyPost's API at baseURL, normally EasyPostURL
+func NewEasyPostProvider(baseURL, apiKey string) (Provider, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid address provider URL %q", baseURL)
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("an EasyPost API key is required")
+	}
+	return &easyPostProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (p *easyPostProvider) Name() string {
+	return "easypost"
+}
+
+type easyPostAddress struct {
+	Name    string `json:"name,omitempty"`
+	Street1 string `json:"street1"`
+	Street2 string `json:"street2,omitempty"`
+	City    string `json:"city"`
+	State   string `json:"state,omitempty"`
+	Zip     string `json:"zip,omitempty"`
+	Country string `json:"country"`
+}
+
+type easyPostRequest struct {
+	Address easyPostAddress `json:"address"`
+	Verify  []string        `json:"verify"`
+}
+
+type easyPostResponse struct {
+	easyPostAddress
+	Verifications struct {
+		Delivery struct {
+			Success bool `json:"success"`
+			Errors  []struct {
+				Code    string `json:"code"`
+				Field   string `json:"field"`
+				Message string `json:"message"`
+			} `json:"errors"`
+		} `json:"delivery"`
+	} `json:"verifications"`
+}
+
+func (p *easyPostProvider) Validate(ctx context.Context, a Address) (*Result, error) {
+	body, err := json.Marshal(easyPostRequest{
+		Address: easyPostAddress{
+			Name:    a.Name,
+			Street1: a.Street1,
+			Street2: a.Street2,
+			City:    a.City,
+			State:   a.State,
+			Zip:     a.PostalCode,
+			Country: a.Country,
+		},
+		Verify: []string{"delivery"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v2/addresses", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(p.apiKey, "")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify address: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("address provider responded %s", resp.Status)
+	}
+
+	var verified easyPostResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&verified); err != nil {
+		return nil, fmt.Errorf("failed to decode address: %w", err)
+	}
+
+	delivery := verified.Verifications.Delivery
+	if !delivery.Success {
+		result := &Result{Address: a, Source: p.Name()}
+		for _, e := range delivery.Errors {
+			result.Issues = append(result.Issues, Issue{
+				Field:   easyPostField(e.Field),
+				Code:    IssueUndeliverable,
+				Message: e.Message,
+				Error:   true,
+			})
+		}
+		if len(result.Issues) == 0 {
+			result.Issues = []Issue{{Code: IssueUndeliverable, Message: "address is not deliverable", Error: true}}
+		}
+		return result, nil
+	}
+
+	standard := normalize(Address{
+		Name:       a.Name,
+		Street1:    verified.Street1,
+		Street2:    verified.Street2,
+		City:       verified.City,
+		State:      verified.State,
+		PostalCode: verified.Zip,
+		Country:    verified.Country,
+	})
+	if standard.Street1 == "" || standard.City == "" || standard.Country == "" {
+		return nil, fmt.Errorf("address provider returned an incomplete address")
+	}
+	return &Result{
+		Valid:      true,
+		Address:    standard,
+		Confidence: verifiedConfidence,
+		Issues:     corrections(a, standard),
+		Source:     p.Name(),
+	}, nil
+}
+
+// easyPostField maps EasyPost's field names to the proto's
+func easyPostField(field string) string {
+	if field == "zip" {
+		return "postal_code"
+	}
+	return field
+}
+
+// corrections lists the fields the provider standardized
+func corrections(before, after Address) []Issue {
+	var issues []Issue
+	for _, f := range []struct{ name, before, after string }{
+		{"street1", before.Street1, after.Street1},
+		{"street2", before.Street2, after.Street2},
+		{"city", before.City, after.City},
+		{"state", before.State, after.State},
+		{"postal_code", before.PostalCode, after.PostalCode},
+		{"country", before.Country, after.Country},
+	} {
+		if f.before != f.after {
+			issues = append(issues, Issue{Field: f.name, Code: IssueCorrected, Message: f.name + " standardized to " + f.after})
+		}
+	}
+	return issues
+}
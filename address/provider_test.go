@@ -0,0 +1,87 @@
+package address
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveEasyPost(t *testing.T, status int, body string, got *easyPostRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, ok := r.BasicAuth(); !ok || user != "test-key" || r.URL.Path != "/v2/addresses" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if got != nil {
+			json.NewDecoder(r.Body).Decode(got)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+var testAddress = Address{Name: "Ada Lovelace", Street1: "1 market st", City: "san francisco", State: "CA", PostalCode: "94107", Country: "US"}
+
+func TestEasyPostProvider_Verified(t *testing.T) {
+	var req easyPostRequest
+	server := serveEasyPost(t, http.StatusCreated, `{
+		"street1": "1 MARKET ST", "city": "SAN FRANCISCO", "state": "CA", "zip": "94105-1420", "country": "US",
+		"verifications": {"delivery": {"success": true, "errors": []}}
+	}`, &req)
+	provider, err := NewEasyPostProvider(server.URL, "test-key")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	result, err := provider.Validate(context.Background(), testAddress)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if req.Address.Zip != "94107" || len(req.Verify) != 1 || req.Verify[0] != "delivery" {
+		t.Errorf("Unexpected request %+v", req)
+	}
+	if !result.Valid || result.Confidence != verifiedConfidence || result.Source != "easypost" {
+		t.Fatalf("Expected a verified result, got %+v", result)
+	}
+	if result.Address.PostalCode != "94105-1420" || result.Address.Name != "Ada Lovelace" {
+		t.Errorf("Unexpected address %+v", result.Address)
+	}
+	if codes := issueCodes(result.Issues); codes["postal_code"] != IssueCorrected || codes["state"] != "" {
+		t.Errorf("Unexpected issues %v", result.Issues)
+	}
+}
+
+func TestEasyPostProvider_Undeliverable(t *testing.T) {
+	server := serveEasyPost(t, http.StatusCreated, `{
+		"street1": "1 MARKET ST", "city": "SAN FRANCISCO", "state": "CA", "zip": "94107", "country": "US",
+		"verifications": {"delivery": {"success": false, "errors": [
+			{"code": "E.ADDRESS.NOT_FOUND", "field": "address", "message": "Address not found"}
+		]}}
+	}`, nil)
+	provider, _ := NewEasyPostProvider(server.URL, "test-key")
+
+	result, err := provider.Validate(context.Background(), testAddress)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Valid || len(result.Issues) != 1 || result.Issues[0].Code != IssueUndeliverable || !result.Issues[0].Error {
+		t.Errorf("Expected an undeliverable result, got %+v", result)
+	}
+}
+
+func TestEasyPostProvider_Failure(t *testing.T) {
+	server := serveEasyPost(t, http.StatusInternalServerError, `{}`, nil)
+	provider, _ := NewEasyPostProvider(server.URL, "test-key")
+
+	if _, err := provider.Validate(context.Background(), testAddress); err == nil {
+		t.Error("Expected an error when the provider fails")
+	}
+	if _, err := NewEasyPostProvider(server.URL, ""); err == nil {
+		t.Error("Expected an error without an API key")
+	}
+}
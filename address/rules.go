@@ -0,0 +1,229 @@
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxFieldLength bounds the text fields of an address
+	maxFieldLength = 255
+	// maxPostalCodeLength bounds postal codes
+	maxPostalCodeLength = 20
+	// rulesConfidence is the confidence in an address that passes the rules:
+	// it is well formed, but nothing says it exists
+	rulesConfidence = 0.6
+)
+
+// countryRule is how a country's addresses are checked offline
+type countryRule struct {
+	// postalCode matches the postal code with spaces and dashes removed
+	postalCode *regexp.Regexp
+	// postalOptional is set where addresses often have no postal code
+	postalOptional bool
+	// split is where sep goes in the standard form of a postal code,
+	// counted from the end when negative; 0 for no separator
+	split int
+	sep   string
+	// states maps upper-case state codes and names to codes; nil when the
+	// country's addresses have no checked states
+	states map[string]string
+}
+
+// countryRules are the countries whose postal codes and states are checked.
+// Other countries only need a plausible postal code, when one is given.
+var countryRules = map[string]countryRule{
+	"US": {postalCode: regexp.MustCompile(`^\d{5}(\d{4})?$`), split: 5, sep: "-", states: stateTable(
+		"AL:Alabama", "AK:Alaska", "AZ:Arizona", "AR:Arkansas", "CA:California", "CO:Colorado", "CT:Connecticut",
+		"DE:Delaware", "DC:District of Columbia", "FL:Florida", "GA:Georgia", "HI:Hawaii", "ID:Idaho", "IL:Illinois",
+		"IN:Indiana", "IA:Iowa", "KS:Kansas", "KY:Kentucky", "LA:Louisiana", "ME:Maine", "MD:Maryland",
+		"MA:Massachusetts", "MI:Michigan", "MN:Minnesota", "MS:Mississippi", "MO:Missouri", "MT:Montana",
+		"NE:Nebraska", "NV:Nevada", "NH:New Hampshire", "NJ:New Jersey", "NM:New Mexico", "NY:New York",
+		"NC:North Carolina", "ND:North Dakota", "OH:Ohio", "OK:Oklahoma", "OR:Oregon", "PA:Pennsylvania",
+		"RI:Rhode Island", "SC:South Carolina", "SD:South Dakota", "TN:Tennessee", "TX:Texas", "UT:Utah",
+		"VT:Vermont", "VA:Virginia", "WA:Washington", "WV:West Virginia", "WI:Wisconsin", "WY:Wyoming",
+		"AS:American Samoa", "GU:Guam", "MP:Northern Mariana Islands", "PR:Puerto Rico", "VI:U.S. Virgin Islands",
+		"AA:Armed Forces Americas", "AE:Armed Forces Europe", "AP:Armed Forces Pacific",
+	)},
+	"CA": {postalCode: regexp.MustCompile(`^[A-Z]\d[A-Z]\d[A-Z]\d$`), split: 3, sep: " ", states: stateTable(
+		"AB:Alberta", "BC:British Columbia", "MB:Manitoba", "NB:New Brunswick", "NL:Newfoundland and Labrador",
+		"NS:Nova Scotia", "NT:Northwest Territories", "NU:Nunavut", "ON:Ontario", "PE:Prince Edward Island",
+		"QC:Quebec", "SK:Saskatchewan", "YT:Yukon",
+	)},
+	"AU": {postalCode: regexp.MustCompile(`^\d{4}$`), states: stateTable(
+		"ACT:Australian Capital Territory", "NSW:New South Wales", "NT:Northern Territory", "QLD:Queensland",
+		"SA:South Australia", "TAS:Tasmania", "VIC:Victoria", "WA:Western Australia",
+	)},
+	"GB": {postalCode: regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]?\d[A-Z]{2}$`), split: -3, sep: " "},
+	"IE": {postalCode: regexp.MustCompile(`^[A-Z]\d[\dW][A-Z\d]{4}$`), postalOptional: true, split: 3, sep: " "},
+	"NL": {postalCode: regexp.MustCompile(`^\d{4}[A-Z]{2}$`), split: 4, sep: " "},
+	"SE": {postalCode: regexp.MustCompile(`^\d{5}$`), split: 3, sep: " "},
+	"PL": {postalCode: regexp.MustCompile(`^\d{5}$`), split: 2, sep: "-"},
+	"JP": {postalCode: regexp.MustCompile(`^\d{7}$`), split: 3, sep: "-"},
+	"BR": {postalCode: regexp.MustCompile(`^\d{8}$`), split: 5, sep: "-"},
+	"DE": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"FR": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"IT": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"ES": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"AT": {postalCode: regexp.MustCompile(`^\d{4}$`)},
+	"BE": {postalCode: regexp.MustCompile(`^\d{4}$`)},
+	"CH": {postalCode: regexp.MustCompile(`^\d{4}$`)},
+	"DK": {postalCode: regexp.MustCompile(`^\d{4}$`)},
+	"NO": {postalCode: regexp.MustCompile(`^\d{4}$`)},
+	"IN": {postalCode: regexp.MustCompile(`^\d{6}$`)},
+	"CN": {postalCode: regexp.MustCompile(`^\d{6}$`)},
+	"SG": {postalCode: regexp.MustCompile(`^\d{6}$`)},
+	"MX": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+}
+
+// anyPostalCode is a plausible postal code of a country without a rule
+var anyPostalCode = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,9}$`)
+
+// countryAliases are common country names that are not ISO codes
+var countryAliases = map[string]string{
+	"UK":  "GB",
+	"USA": "US",
+}
+
+// countryCodes are the ISO 3166 alpha-2 codes
+var countryCodes = toSet(strings.Fields(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+	CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO
+	FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE
+	JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO
+	MP MQ MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW
+	PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM
+	TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW
+`))
+
+// CheckRules validates an address against the offline country, postal code
+// and state rules and returns it standardized. The rules cannot tell whether
+// an address exists, so a valid result is never fully confident.
+func CheckRules(in Address) *Result {
+	a := normalize(in)
+	var issues []Issue
+	fail := func(field, code, msg string) {
+		issues = append(issues, Issue{Field: field, Code: code, Message: msg, Error: true})
+	}
+	correct := func(field, msg string) {
+		issues = append(issues, Issue{Field: field, Code: IssueCorrected, Message: msg})
+	}
+
+	for _, f := range []struct{ name, value string }{
+		{"name", a.Name}, {"street1", a.Street1}, {"street2", a.Street2}, {"city", a.City}, {"state", a.State},
+	} {
+		if len(f.value) > maxFieldLength {
+			fail(f.name, IssueTooLong, f.name+" is too long")
+		}
+	}
+	if a.Street1 == "" {
+		fail("street1", IssueMissing, "street1 is required")
+	}
+	if a.City == "" {
+		fail("city", IssueMissing, "city is required")
+	}
+
+	if code, ok := countryAliases[a.Country]; ok {
+		correct("country", "country standardized to "+code)
+		a.Country = code
+	}
+	switch {
+	case a.Country == "":
+		fail("country", IssueMissing, "country is required")
+	case !countryCodes[a.Country]:
+		fail("country", IssueUnknownCountry, fmt.Sprintf("country %s is not an ISO 3166 alpha-2 code", a.Country))
+	default:
+		rule, ok := countryRules[a.Country]
+		if ok {
+			checkState(&a, rule, fail, correct)
+		}
+		checkPostalCode(&a, rule, ok, fail, correct)
+	}
+
+	result := &Result{Address: a, Issues: issues, Source: SourceRules}
+	if !hasErrors(issues) {
+		result.Valid = true
+		result.Confidence = rulesConfidence
+	}
+	return result
+}
+
+// checkPostalCode checks the postal code against the country's rule, when it
+// has one, and standardizes it
+func checkPostalCode(a *Address, rule countryRule, hasRule bool, fail func(field, code, msg string), correct func(field, msg string)) {
+	switch {
+	case len(a.PostalCode) > maxPostalCodeLength:
+		fail("postal_code", IssueTooLong, "postal_code is too long")
+		return
+	case a.PostalCode == "":
+		if hasRule && !rule.postalOptional {
+			fail("postal_code", IssueMissing, "postal_code is required in "+a.Country)
+		}
+		return
+	case !hasRule:
+		if !anyPostalCode.MatchString(a.PostalCode) {
+			fail("postal_code", IssueInvalidFormat, fmt.Sprintf("postal_code %s is not a valid postal code", a.PostalCode))
+		}
+		return
+	}
+
+	compact := strings.NewReplacer(" ", "", "-", "").Replace(a.PostalCode)
+	if !rule.postalCode.MatchString(compact) {
+		fail("postal_code", IssueInvalidFormat, fmt.Sprintf("postal_code %s is not a valid %s postal code", a.PostalCode, a.Country))
+		return
+	}
+	standard := compact
+	if split := rule.split; split != 0 {
+		if split < 0 {
+			split += len(compact)
+		}
+		if split > 0 && split < len(compact) {
+			standard = compact[:split] + rule.sep + compact[split:]
+		}
+	}
+	if standard != a.PostalCode {
+		correct("postal_code", "postal_code standardized to "+standard)
+		a.PostalCode = standard
+	}
+}
+
+// checkState checks the state against the country's states, when it has
+// them, and standardizes a name or lower-case code to its code
+func checkState(a *Address, rule countryRule, fail func(field, code, msg string), correct func(field, msg string)) {
+	if rule.states == nil {
+		return
+	}
+	if a.State == "" {
+		fail("state", IssueMissing, "state is required in "+a.Country)
+		return
+	}
+	code, ok := rule.states[strings.ToUpper(a.State)]
+	if !ok {
+		fail("state", IssueUnknownState, fmt.Sprintf("state %s is not a state of %s", a.State, a.Country))
+		return
+	}
+	if code != a.State {
+		correct("state", "state standardized to "+code)
+		a.State = code
+	}
+}
+
+// stateTable builds a states map from "CODE:Name" pairs
+func stateTable(pairs ...string) map[string]string {
+	states := make(map[string]string, 2*len(pairs))
+	for _, pair := range pairs {
+		code, name, _ := strings.Cut(pair, ":")
+		states[code] = code
+		states[strings.ToUpper(name)] = code
+	}
+	return states
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
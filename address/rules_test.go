@@ -0,0 +1,113 @@
+package address
+
+import (
+	"testing"
+)
+
+func issueCodes(issues []Issue) map[string]string {
+	codes := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		codes[issue.Field] = issue.Code
+	}
+	return codes
+}
+
+func TestCheckRules_Standardizes(t *testing.T) {
+	result := CheckRules(Address{
+		Name:       "  Ada   Lovelace ",
+		Street1:    "1 Market  St",
+		City:       "San Francisco",
+		State:      "california",
+		PostalCode: "941071234",
+		Country:    "usa",
+	})
+
+	if !result.Valid || result.Confidence != rulesConfidence || result.Source != SourceRules {
+		t.Fatalf("Expected a valid rules result, got %+v", result)
+	}
+	want := Address{
+		Name:       "Ada Lovelace",
+		Street1:    "1 Market St",
+		City:       "San Francisco",
+		State:      "CA",
+		PostalCode: "94107-1234",
+		Country:    "US",
+	}
+	if result.Address != want {
+		t.Errorf("Expected %+v, got %+v", want, result.Address)
+	}
+	codes := issueCodes(result.Issues)
+	for _, field := range []string{"state", "postal_code", "country"} {
+		if codes[field] != IssueCorrected {
+			t.Errorf("Expected %s to be corrected, got %v", field, result.Issues)
+		}
+	}
+}
+
+func TestCheckRules_PostalCodes(t *testing.T) {
+	tests := []struct {
+		country, postalCode, want string
+		valid                     bool
+	}{
+		{"GB", "sw1a1aa", "SW1A 1AA", true},
+		{"GB", "EC1A 1BB", "EC1A 1BB", true},
+		{"CA", "k1a0b1", "K1A 0B1", true},
+		{"NL", "1012 AB", "1012 AB", true},
+		{"JP", "1000001", "100-0001", true},
+		{"DE", "10115", "10115", true},
+		{"DE", "1011", "", false},
+		{"US", "9410", "", false},
+		{"IE", "", "", true},
+		{"FR", "", "", false},
+		{"KE", "00100", "00100", true},
+		{"KE", "", "", true},
+		{"KE", "#1", "", false},
+	}
+
+	for _, tt := range tests {
+		a := Address{Street1: "1 Main St", City: "Town", PostalCode: tt.postalCode, Country: tt.country}
+		switch tt.country {
+		case "US":
+			a.State = "NY"
+		case "CA":
+			a.State = "ON"
+		}
+		result := CheckRules(a)
+		if result.Valid != tt.valid {
+			t.Errorf("%s %q: expected valid %v, got %+v", tt.country, tt.postalCode, tt.valid, result.Issues)
+			continue
+		}
+		if tt.valid && result.Address.PostalCode != tt.want {
+			t.Errorf("%s %q: expected %q, got %q", tt.country, tt.postalCode, tt.want, result.Address.PostalCode)
+		}
+	}
+}
+
+func TestCheckRules_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		address Address
+		field   string
+		code    string
+	}{
+		{"missing street", Address{City: "Paris", PostalCode: "75001", Country: "FR"}, "street1", IssueMissing},
+		{"missing city", Address{Street1: "1 Rue", PostalCode: "75001", Country: "FR"}, "city", IssueMissing},
+		{"missing country", Address{Street1: "1 Rue", City: "Paris"}, "country", IssueMissing},
+		{"unknown country", Address{Street1: "1 Rue", City: "Paris", Country: "XX"}, "country", IssueUnknownCountry},
+		{"missing state", Address{Street1: "1 Main St", City: "Austin", PostalCode: "73301", Country: "US"}, "state", IssueMissing},
+		{"unknown state", Address{Street1: "1 Main St", City: "Austin", State: "Texass", PostalCode: "73301", Country: "US"}, "state", IssueUnknownState},
+		{"invalid postal code", Address{Street1: "1 Main St", City: "Ottawa", State: "ON", PostalCode: "12345", Country: "CA"}, "postal_code", IssueInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CheckRules(tt.address)
+			if result.Valid || result.Confidence != 0 {
+				t.Fatalf("Expected an invalid result, got %+v", result)
+			}
+			if code := issueCodes(result.Issues)[tt.field]; code != tt.code {
+				t.Errorf("Expected %s on %s, got %v", tt.code, tt.field, result.Issues)
+			}
+		})
+	}
+}
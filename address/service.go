@@ -0,0 +1,96 @@
+package address
+
+import (
+	"context"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/address/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service implements the AddressService gRPC interface
+type Service struct {
+	pb.UnimplementedAddressServiceServer
+	provider Provider
+	log      *logger.Logger
+}
+
+// NewService creates a new address service. provider may be nil, in which
+// case addresses are checked against the offline rules alone.
+func NewService(provider Provider, log *logger.Logger) *Service {
+	return &Service{
+		provider: provider,
+		log:      log,
+	}
+}
+
+// ValidateAddress checks an address and returns it standardized
+func (s *Service) ValidateAddress(ctx context.Context, req *pb.ValidateAddressRequest) (*pb.ValidateAddressResponse, error) {
+	if req.Address == nil {
+		s.log.Warn(ctx, "Validate address failed: address is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+	return toProtoResult(s.Validate(ctx, fromProtoAddress(req.Address))), nil
+}
+
+// Validate checks an address against the offline rules, then against the
+// provider when the rules pass. When the provider cannot be reached the
+// rules' result stands.
+func (s *Service) Validate(ctx context.Context, a Address) *Result {
+	result := CheckRules(a)
+	if !result.Valid || s.provider == nil {
+		return result
+	}
+
+	verified, err := s.provider.Validate(ctx, result.Address)
+	if err != nil {
+		s.log.Warn(ctx, "Address provider failed; using rules", map[string]interface{}{
+			"error":    err.Error(),
+			"provider": s.provider.Name(),
+		})
+		return result
+	}
+	// The rules' corrections come first: the provider saw their output
+	verified.Issues = append(result.Issues, verified.Issues...)
+	return verified
+}
+
+func fromProtoAddress(a *pb.Address) Address {
+	return Address{
+		Name:       a.Name,
+		Street1:    a.Street1,
+		Street2:    a.Street2,
+		City:       a.City,
+		State:      a.State,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func toProtoResult(r *Result) *pb.ValidateAddressResponse {
+	resp := &pb.ValidateAddressResponse{
+		Valid: r.Valid,
+		Address: &pb.Address{
+			Name:       r.Address.Name,
+			Street1:    r.Address.Street1,
+			Street2:    r.Address.Street2,
+			City:       r.Address.City,
+			State:      r.Address.State,
+			PostalCode: r.Address.PostalCode,
+			Country:    r.Address.Country,
+		},
+		Confidence: r.Confidence,
+		Issues:     make([]*pb.Issue, len(r.Issues)),
+		Source:     r.Source,
+	}
+	for i, issue := range r.Issues {
+		resp.Issues[i] = &pb.Issue{
+			Field:   issue.Field,
+			Code:    issue.Code,
+			Message: issue.Message,
+			Error:   issue.Error,
+		}
+	}
+	return resp
+}
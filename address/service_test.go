@@ -0,0 +1,106 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/address/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeProvider returns the result or error it is given
+type fakeProvider struct {
+	result *Result
+	err    error
+	calls  int
+}
+
+func (f *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (f *fakeProvider) Validate(ctx context.Context, a Address) (*Result, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	result := *f.result
+	return &result, nil
+}
+
+var validRequest = &pb.ValidateAddressRequest{Address: &pb.Address{
+	Street1: "10 Downing St", City: "London", PostalCode: "sw1a2aa", Country: "UK",
+}}
+
+func TestValidateAddress_RulesOnly(t *testing.T) {
+	service := NewService(nil, logger.New("test"))
+
+	resp, err := service.ValidateAddress(context.Background(), validRequest)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Valid || resp.Source != SourceRules || resp.Address.PostalCode != "SW1A 2AA" || resp.Address.Country != "GB" {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+	if len(resp.Issues) != 2 {
+		t.Errorf("Expected 2 corrections, got %v", resp.Issues)
+	}
+}
+
+func TestValidateAddress_Provider(t *testing.T) {
+	provider := &fakeProvider{result: &Result{
+		Valid:      true,
+		Address:    Address{Street1: "10 DOWNING ST", City: "LONDON", PostalCode: "SW1A 2AA", Country: "GB"},
+		Confidence: verifiedConfidence,
+		Issues:     []Issue{{Field: "street1", Code: IssueCorrected, Message: "street1 standardized to 10 DOWNING ST"}},
+		Source:     "fake",
+	}}
+	service := NewService(provider, logger.New("test"))
+
+	resp, err := service.ValidateAddress(context.Background(), validRequest)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Valid || resp.Source != "fake" || resp.Confidence != verifiedConfidence || resp.Address.Street1 != "10 DOWNING ST" {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+	if len(resp.Issues) != 3 {
+		t.Errorf("Expected the rules' and provider's corrections, got %v", resp.Issues)
+	}
+}
+
+func TestValidateAddress_ProviderDown(t *testing.T) {
+	provider := &fakeProvider{err: errors.New("connection refused")}
+	service := NewService(provider, logger.New("test"))
+
+	resp, err := service.ValidateAddress(context.Background(), validRequest)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Valid || resp.Source != SourceRules || resp.Confidence != rulesConfidence {
+		t.Errorf("Expected the rules' result, got %+v", resp)
+	}
+}
+
+func TestValidateAddress_InvalidSkipsProvider(t *testing.T) {
+	provider := &fakeProvider{err: errors.New("unexpected call")}
+	service := NewService(provider, logger.New("test"))
+
+	resp, err := service.ValidateAddress(context.Background(), &pb.ValidateAddressRequest{Address: &pb.Address{
+		Street1: "1 Main St", City: "Springfield", Country: "ZZ",
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Valid || provider.calls != 0 {
+		t.Errorf("Expected an invalid result without calling the provider, got %+v", resp)
+	}
+
+	_, err = service.ValidateAddress(context.Background(), &pb.ValidateAddressRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
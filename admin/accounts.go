@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// accountsPageSize is the page size accounts are read with
+const accountsPageSize = 100
+
+// maxPeriodCustomers caps the customers read for one dashboard view
+const maxPeriodCustomers = 10000
+
+// ErrTooManyCustomers is returned when a period holds more new customers
+// than a dashboard view reads
+var ErrTooManyCustomers = errors.New("too many customers in period")
+
+// Customer is a customer account as the dashboards see it
+type Customer struct {
+	ID        string
+	Email     string
+	Name      string
+	CreatedAt time.Time
+}
+
+// Accounts reads customer accounts from the account service
+type Accounts interface {
+	// RegisteredBetween returns the customers registered in [from, to),
+	// newest first
+	RegisteredBetween(ctx context.Context, from, to time.Time) ([]*Customer, error)
+}
+
+type grpcAccounts struct {
+	client accountpb.AccountServiceClient
+}
+
+// NewGRPCAccounts creates Accounts backed by the account service
+func NewGRPCAccounts(client accountpb.AccountServiceClient) Accounts {
+	return &grpcAccounts{client: client}
+}
+
+// RegisteredBetween pages through the active USER accounts of the period.
+// The account service only lists accounts to admins, so the caller's bearer
+// token is passed on.
+func (a *grpcAccounts) RegisteredBetween(ctx context.Context, from, to time.Time) ([]*Customer, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+		}
+	}
+
+	var customers []*Customer
+	for page := int32(1); ; page++ {
+		resp, err := a.client.ListAccounts(ctx, &accountpb.ListAccountsRequest{
+			CreatedFrom: timestamppb.New(from),
+			CreatedTo:   timestamppb.New(to),
+			Role:        "USER",
+			Page:        page,
+			PageSize:    accountsPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+		if resp.Total > maxPeriodCustomers {
+			return nil, ErrTooManyCustomers
+		}
+
+		for _, u := range resp.Users {
+			customers = append(customers, &Customer{ID: u.Id, Email: u.Email, Name: u.Name, CreatedAt: u.CreatedAt.AsTime()})
+		}
+		if len(resp.Users) < accountsPageSize || page*accountsPageSize >= resp.Total {
+			return customers, nil
+		}
+	}
+}
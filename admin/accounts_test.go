@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeAccountClient serves ListAccounts from a list of users
+type fakeAccountClient struct {
+	accountpb.AccountServiceClient
+	users         []*accountpb.User
+	total         int32
+	requests      []*accountpb.ListAccountsRequest
+	authorization []string
+}
+
+func (c *fakeAccountClient) ListAccounts(ctx context.Context, req *accountpb.ListAccountsRequest, opts ...grpc.CallOption) (*accountpb.ListAccountsResponse, error) {
+	c.requests = append(c.requests, req)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.authorization = md.Get("authorization")
+	return &accountpb.ListAccountsResponse{Users: c.users, Total: c.total}, nil
+}
+
+func TestGRPCAccounts_RegisteredBetween(t *testing.T) {
+	client := &fakeAccountClient{
+		users: []*accountpb.User{{Id: "c1", Email: "c1@example.com", Name: "Ada"}},
+		total: 1,
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer admin-token"))
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	customers, err := NewGRPCAccounts(client).RegisteredBetween(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(customers) != 1 || customers[0].ID != "c1" {
+		t.Errorf("Unexpected customers %v", customers)
+	}
+	if len(client.authorization) != 1 || client.authorization[0] != "Bearer admin-token" {
+		t.Errorf("Expected the caller's token to be passed on, got %v", client.authorization)
+	}
+
+	req := client.requests[0]
+	if req.Role != "USER" || !req.CreatedFrom.AsTime().Equal(from) || !req.CreatedTo.AsTime().Equal(to) {
+		t.Errorf("Unexpected request %v", req)
+	}
+
+	client.total = maxPeriodCustomers + 1
+	if _, err := NewGRPCAccounts(client).RegisteredBetween(ctx, from, to); !errors.Is(err, ErrTooManyCustomers) {
+		t.Errorf("Expected ErrTooManyCustomers, got %v", err)
+	}
+}
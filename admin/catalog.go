@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+)
+
+// BestSeller is a product with its sales over a window
+type BestSeller struct {
+	ProductID  string
+	SKU        string
+	Name       string
+	Category   string
+	Price      float64
+	UnitsSold  int64
+	OrderCount int64
+	ViewCount  int64
+}
+
+// Catalog reads product rankings from the catalog service
+type Catalog interface {
+	// BestSellers returns the products with the most units sold in window,
+	// most first
+	BestSellers(ctx context.Context, window, category string, limit int32) ([]*BestSeller, error)
+}
+
+type grpcCatalog struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCCatalog creates a Catalog backed by the catalog service
+func NewGRPCCatalog(client catalogpb.CatalogServiceClient) Catalog {
+	return &grpcCatalog{client: client}
+}
+
+// BestSellers lists the catalog's best sellers
+func (c *grpcCatalog) BestSellers(ctx context.Context, window, category string, limit int32) ([]*BestSeller, error) {
+	resp, err := c.client.ListBestSellers(ctx, &catalogpb.ListBestSellersRequest{Window: window, Category: category, Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list best sellers: %w", err)
+	}
+
+	sellers := make([]*BestSeller, 0, len(resp.BestSellers))
+	for _, bs := range resp.BestSellers {
+		if bs.Product == nil {
+			continue
+		}
+		sellers = append(sellers, &BestSeller{
+			ProductID:  bs.Product.Id,
+			SKU:        bs.Product.Sku,
+			Name:       bs.Product.Name,
+			Category:   bs.Product.Category,
+			Price:      bs.Product.EffectivePrice,
+			UnitsSold:  bs.UnitsSold,
+			OrderCount: bs.OrderCount,
+			ViewCount:  bs.ViewCount,
+		})
+	}
+	return sellers, nil
+}
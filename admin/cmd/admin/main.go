@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/account"
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/admin"
+	"github.com/Ujjwaljain16/E-commerce-Backend/admin/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("admin-service")
+	log.Info(ctx, "Starting Admin Service", nil)
+
+	// Get configuration from environment
+	port := getEnv("PORT", "50068")
+	metricsPort := getEnv("METRICS_PORT", "9107")
+	httpPort := getEnv("HTTP_PORT", "8087")
+	accountAddr := getEnv("ACCOUNT_ADDR", "localhost:50051")
+	catalogAddr := getEnv("CATALOG_ADDR", "localhost:50052")
+	orderAddr := getEnv("ORDER_ADDR", "localhost:50053")
+	paymentAddr := getEnv("PAYMENT_ADDR", "localhost:50055")
+
+	// Dashboard callers present access tokens issued by the account service
+	tokens := auth.TokenServiceFromEnv()
+
+	// PASETO tokens the account service issues with TOKEN_FORMAT=paseto are
+	// decrypted with the shared PASETO_KEY
+	if pasetoKey := os.Getenv("PASETO_KEY"); pasetoKey != "" {
+		key, err := auth.ParsePASETOKey(pasetoKey)
+		if err == nil {
+			_, err = tokens.WithPASETOKey(key)
+		}
+		if err != nil {
+			log.Error(ctx, "Invalid PASETO_KEY", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+	}
+
+	// The dashboards are composed from the account, catalog, order and
+	// payment services. Orders are listed with a SERVICE token.
+	serviceTokens := auth.ServiceTokensFromEnv("admin-service")
+	conns := make(map[string]*grpc.ClientConn)
+	for name, addr := range map[string]string{"account": accountAddr, "catalog": catalogAddr, "order": orderAddr, "payment": paymentAddr} {
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		if name == "order" {
+			opts = append(opts, grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()))
+		}
+		conn, err := grpc.NewClient(addr, opts...)
+		if err != nil {
+			log.Error(ctx, "Failed to create "+name+" service client", map[string]interface{}{
+				"error": err.Error(),
+				"addr":  addr,
+			})
+			os.Exit(1)
+		}
+		defer conn.Close()
+		conns[name] = conn
+	}
+
+	// Tokens the admin service cannot validate with JWT_SECRET, PASETO_KEY or
+	// the keys at JWKS_URL, such as opaque tokens, are verified with the
+	// account service, whose answers are cached for TOKEN_CACHE_TTL
+	accounts := accountpb.NewAccountServiceClient(conns["account"])
+	verifier := auth.NewVerifier(tokens, account.RemoteVerifier(accounts), getEnvDuration("TOKEN_CACHE_TTL", auth.DefaultVerifierCacheTTL))
+	if jwksURL := os.Getenv("JWKS_URL"); jwksURL != "" {
+		verifier.WithJWKS(http.DefaultClient, jwksURL)
+		if err := verifier.LoadJWKS(ctx); err != nil {
+			log.Warn(ctx, "Failed to load JWKS, keys are fetched when tokens need them", map[string]interface{}{
+				"error": err.Error(),
+				"url":   jwksURL,
+			})
+		}
+	}
+
+	// Every RPC needs an ADMIN token; the HTTP dashboards check theirs with
+	// the same interceptor
+	authenticator := auth.NewInterceptor(verifier, auth.InterceptorConfig{
+		ExemptMethods: []string{"/"},
+		RequiredRoles: admin.MethodRoles(),
+	})
+
+	service := admin.NewService(
+		admin.NewGRPCOrders(orderpb.NewOrderServiceClient(conns["order"])),
+		admin.NewGRPCPayments(paymentpb.NewPaymentServiceClient(conns["payment"])),
+		admin.NewGRPCCatalog(catalogpb.NewCatalogServiceClient(conns["catalog"])),
+		admin.NewGRPCAccounts(accounts),
+		authenticator,
+		log,
+	)
+
+	// IP filtering: admin network allowlist and the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, admin.AdminMethods)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Create gRPC server with metrics and IP filter interceptors
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("admin-service"),
+			ipFilter.UnaryServerInterceptor(),
+			authenticator.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterAdminServiceServer(grpcServer, service)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("admin.AdminService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Enable reflection for grpcurl/grpcui
+	reflection.Register(grpcServer)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start the dashboard HTTP server for the backoffice UI
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", httpPort),
+		Handler:           ipFilter.Middleware(service.HTTPHandler()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Info(ctx, "Dashboard server listening", map[string]interface{}{
+			"port": httpPort,
+		})
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(ctx, "Dashboard server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start gRPC server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Error(ctx, "Failed to listen", map[string]interface{}{
+			"error": err.Error(),
+			"port":  port,
+		})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "Admin Service listening", map[string]interface{}{
+		"port":         port,
+		"metrics_port": metricsPort,
+		"http_port":    httpPort,
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+		grpcServer.GracefulStop()
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
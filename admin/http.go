@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/admin/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// jsonOptions writes responses with every field, so dashboards see zeros
+var jsonOptions = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// HTTPHandler serves the dashboard views as JSON for the backoffice UI:
+//
+//	GET /admin/daily-sales?from=2026-06-01&to=2026-06-07
+//	GET /admin/top-products?window=WEEK&category=mugs&limit=10
+//	GET /admin/new-customers?from=2026-06-01&to=2026-06-07&limit=10
+//
+// The Authorization header carries the same bearer token as the gRPC API.
+func (s *Service) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/daily-sales", func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := s.authenticate(r, pb.AdminService_GetDailySales_FullMethodName)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		q := r.URL.Query()
+		resp, err := s.GetDailySales(ctx, &pb.GetDailySalesRequest{From: q.Get("from"), To: q.Get("to")})
+		writeJSON(w, resp, err)
+	})
+	mux.HandleFunc("GET /admin/top-products", func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := s.authenticate(r, pb.AdminService_GetTopProducts_FullMethodName)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		q := r.URL.Query()
+		n, ok := limitParam(w, r)
+		if !ok {
+			return
+		}
+		resp, err := s.GetTopProducts(ctx, &pb.GetTopProductsRequest{Window: q.Get("window"), Category: q.Get("category"), Limit: n})
+		writeJSON(w, resp, err)
+	})
+	mux.HandleFunc("GET /admin/new-customers", func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := s.authenticate(r, pb.AdminService_GetNewCustomers_FullMethodName)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		q := r.URL.Query()
+		n, ok := limitParam(w, r)
+		if !ok {
+			return
+		}
+		resp, err := s.GetNewCustomers(ctx, &pb.GetNewCustomersRequest{From: q.Get("from"), To: q.Get("to"), Limit: n})
+		writeJSON(w, resp, err)
+	})
+	return mux
+}
+
+// authenticate checks the bearer token of a request's Authorization header
+// as the auth interceptor checks that of method on the gRPC API. The header
+// stays in the context as gRPC metadata, so it is passed on to the account
+// service.
+func (s *Service) authenticate(r *http.Request, method string) (context.Context, error) {
+	md := metadata.MD{}
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		md.Set("authorization", authorization)
+	}
+	return s.authenticator.Authenticate(metadata.NewIncomingContext(r.Context(), md), method)
+}
+
+// limitParam reads the optional limit query parameter, answering 400 when it
+// is not a number
+func limitParam(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return 0, true
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		http.Error(w, "limit must be a number", http.StatusBadRequest)
+		return 0, false
+	}
+	return int32(n), true
+}
+
+// writeJSON writes a handler's response, or its error with the matching HTTP
+// status
+func writeJSON(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		st := status.Convert(err)
+		http.Error(w, st.Message(), httpStatus(st.Code()))
+		return
+	}
+
+	body, err := jsonOptions.Marshal(resp)
+	if err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// httpStatus maps the gRPC codes the handlers return to HTTP statuses
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestService_HTTPHandler(t *testing.T) {
+	orders := &fakeOrders{orders: []*Order{
+		{ID: "o1", Status: "PAID", Total: 25, CreatedAt: time.Date(2026, 6, 7, 9, 0, 0, 0, time.UTC)},
+	}}
+	payments := &fakePayments{payments: map[string][]*Payment{"o1": {{Status: "CAPTURED", Captured: 25}}}}
+	service := newTestService(orders, payments, &fakeCatalog{}, &fakeAccounts{})
+	handler := service.HTTPHandler()
+
+	token, err := testTokens.GenerateAccessToken("user-1", "admin@example.com", "ADMIN")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/daily-sales?from=2026-06-07&to=2026-06-07", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Days []struct {
+			Date       string  `json:"date"`
+			GrossSales float64 `json:"grossSales"`
+			Refunded   float64 `json:"refunded"`
+		} `json:"days"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected JSON, got %v", err)
+	}
+	if len(body.Days) != 1 || body.Days[0].Date != "2026-06-07" || body.Days[0].GrossSales != 25 {
+		t.Errorf("Unexpected body %s", rec.Body.String())
+	}
+	if !containsKey(rec.Body.Bytes(), "refunded") {
+		t.Errorf("Expected zero amounts to be written, got %s", rec.Body.String())
+	}
+}
+
+func TestService_HTTPHandler_Errors(t *testing.T) {
+	service := newTestService(&fakeOrders{err: errors.New("connection refused")}, &fakePayments{}, &fakeCatalog{}, &fakeAccounts{})
+	handler := service.HTTPHandler()
+
+	admin, err := testTokens.GenerateAccessToken("user-1", "admin@example.com", "ADMIN")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	customer, err := testTokens.GenerateAccessToken("user-2", "user@example.com", "USER")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		code  int
+	}{
+		{"no token", "/admin/top-products", "", http.StatusUnauthorized},
+		{"customer", "/admin/top-products", customer, http.StatusForbidden},
+		{"bad limit", "/admin/new-customers?limit=ten", admin, http.StatusBadRequest},
+		{"bad window", "/admin/top-products?window=YEAR", admin, http.StatusBadRequest},
+		{"order service down", "/admin/daily-sales", admin, http.StatusServiceUnavailable},
+		{"unknown view", "/admin/returns", admin, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Errorf("Expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+// containsKey reports whether a JSON body's first day has the given key
+func containsKey(body []byte, key string) bool {
+	var parsed struct {
+		Days []map[string]any `json:"days"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil || len(parsed.Days) == 0 {
+		return false
+	}
+	_, ok := parsed.Days[0][key]
+	return ok
+}
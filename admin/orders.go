@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+)
+
+// ordersPageSize is the page size orders are read with
+const ordersPageSize = 100
+
+// maxPeriodOrders caps the orders read for one dashboard view
+const maxPeriodOrders = 10000
+
+// ErrTooManyOrders is returned when a period holds more orders than a
+// dashboard view reads
+var ErrTooManyOrders = errors.New("too many orders in period")
+
+// Order is an order as the dashboards see it
+type Order struct {
+	ID        string
+	Status    string
+	Total     float64
+	CreatedAt time.Time
+}
+
+// Orders reads orders from the order service
+type Orders interface {
+	// PlacedBetween returns the orders placed in [from, to), newest first
+	PlacedBetween(ctx context.Context, from, to time.Time) ([]*Order, error)
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+// PlacedBetween pages through the order service's orders, which are listed
+// newest first, until it passes from. An order placed while paging shifts the
+// pages by one, so orders already seen are skipped.
+func (o *grpcOrders) PlacedBetween(ctx context.Context, from, to time.Time) ([]*Order, error) {
+	var orders []*Order
+	seen := make(map[string]bool)
+	for page := int32(1); ; page++ {
+		resp, err := o.client.ListOrders(ctx, &orderpb.ListOrdersRequest{Page: page, PageSize: ordersPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders: %w", err)
+		}
+
+		for _, po := range resp.Orders {
+			createdAt := po.CreatedAt.AsTime()
+			if createdAt.Before(from) {
+				return orders, nil
+			}
+			if !createdAt.Before(to) || seen[po.Id] {
+				continue
+			}
+			if len(orders) == maxPeriodOrders {
+				return nil, ErrTooManyOrders
+			}
+			seen[po.Id] = true
+			orders = append(orders, &Order{ID: po.Id, Status: po.Status, Total: po.TotalAmount, CreatedAt: createdAt})
+		}
+
+		if len(resp.Orders) < ordersPageSize || page*ordersPageSize >= resp.Total {
+			return orders, nil
+		}
+	}
+}
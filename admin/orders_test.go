@@ -0,0 +1,101 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeOrderClient serves ListOrders from a list of orders, newest first
+type fakeOrderClient struct {
+	orderpb.OrderServiceClient
+	orders []*orderpb.Order
+	pages  []int32
+	err    error
+}
+
+func (c *fakeOrderClient) ListOrders(ctx context.Context, req *orderpb.ListOrdersRequest, opts ...grpc.CallOption) (*orderpb.ListOrdersResponse, error) {
+	c.pages = append(c.pages, req.Page)
+	if c.err != nil {
+		return nil, c.err
+	}
+	start := min(int((req.Page-1)*req.PageSize), len(c.orders))
+	end := min(start+int(req.PageSize), len(c.orders))
+	return &orderpb.ListOrdersResponse{Orders: c.orders[start:end], Total: int32(len(c.orders))}, nil
+}
+
+// hourlyOrders returns n orders placed an hour apart, newest first, the
+// newest at latest
+func hourlyOrders(n int, latest time.Time) []*orderpb.Order {
+	orders := make([]*orderpb.Order, n)
+	for i := range orders {
+		orders[i] = &orderpb.Order{
+			Id:          fmt.Sprintf("order-%d", i),
+			Status:      "PAID",
+			TotalAmount: 10,
+			CreatedAt:   timestamppb.New(latest.Add(-time.Duration(i) * time.Hour)),
+		}
+	}
+	return orders
+}
+
+func TestGRPCOrders_PlacedBetween(t *testing.T) {
+	latest := time.Date(2026, 6, 20, 12, 0, 0, 0, time.UTC)
+	client := &fakeOrderClient{orders: hourlyOrders(500, latest)}
+	orders := NewGRPCOrders(client)
+
+	// Ten days ending two days before the latest order
+	to := latest.Add(-48 * time.Hour)
+	from := to.Add(-240 * time.Hour)
+	got, err := orders.PlacedBetween(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got) != 240 {
+		t.Errorf("Expected 240 orders, got %d", len(got))
+	}
+	for _, o := range got {
+		if o.CreatedAt.Before(from) || !o.CreatedAt.Before(to) {
+			t.Errorf("Order %s placed at %v is outside the period", o.ID, o.CreatedAt)
+		}
+	}
+	if len(client.pages) != 3 {
+		t.Errorf("Expected paging to stop once past from, read pages %v", client.pages)
+	}
+}
+
+func TestGRPCOrders_PlacedBetween_SkipsShiftedOrders(t *testing.T) {
+	latest := time.Date(2026, 6, 20, 12, 0, 0, 0, time.UTC)
+	all := hourlyOrders(150, latest)
+	// The second page repeats the last order of the first, as it does when
+	// an order is placed between the two reads
+	client := &fakeOrderClient{orders: append(append(all[:100:100], all[99]), all[100:]...)}
+
+	got, err := NewGRPCOrders(client).PlacedBetween(context.Background(), latest.Add(-1000*time.Hour), latest.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got) != 150 {
+		t.Errorf("Expected 150 distinct orders, got %d", len(got))
+	}
+}
+
+func TestGRPCOrders_PlacedBetween_Errors(t *testing.T) {
+	latest := time.Date(2026, 6, 20, 12, 0, 0, 0, time.UTC)
+
+	client := &fakeOrderClient{orders: hourlyOrders(maxPeriodOrders+1, latest)}
+	if _, err := NewGRPCOrders(client).PlacedBetween(context.Background(), time.Time{}, latest.Add(time.Hour)); !errors.Is(err, ErrTooManyOrders) {
+		t.Errorf("Expected ErrTooManyOrders, got %v", err)
+	}
+
+	client = &fakeOrderClient{err: errors.New("connection refused")}
+	if _, err := NewGRPCOrders(client).PlacedBetween(context.Background(), time.Time{}, latest); err == nil {
+		t.Error("Expected an error")
+	}
+}
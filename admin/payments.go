@@ -0,0 +1,44 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+)
+
+// Payment is a payment as the dashboards see it
+type Payment struct {
+	Status   string
+	Captured float64
+	Refunded float64
+}
+
+// Payments reads payments from the payment service
+type Payments interface {
+	// ForOrder returns the payments of an order
+	ForOrder(ctx context.Context, orderID string) ([]*Payment, error)
+}
+
+type grpcPayments struct {
+	client paymentpb.PaymentServiceClient
+}
+
+// NewGRPCPayments creates Payments backed by the payment service
+func NewGRPCPayments(client paymentpb.PaymentServiceClient) Payments {
+	return &grpcPayments{client: client}
+}
+
+// ForOrder lists an order's payments
+func (p *grpcPayments) ForOrder(ctx context.Context, orderID string) ([]*Payment, error) {
+	resp, err := p.client.ListPayments(ctx, &paymentpb.ListPaymentsRequest{OrderId: orderID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	payments := make([]*Payment, len(resp.Payments))
+	for i, pp := range resp.Payments {
+		payments[i] = &Payment{Status: pp.Status, Captured: pp.CapturedAmount, Refunded: pp.RefundedAmount}
+	}
+	return payments, nil
+}
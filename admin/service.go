@@ -0,0 +1,303 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/admin/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// dateLayout is the layout of the days in requests and responses
+	dateLayout = "2006-01-02"
+	// defaultPeriodDays is the length of the period when no from day is given
+	defaultPeriodDays = 7
+	// maxPeriodDays caps the length of a period
+	maxPeriodDays = 92
+)
+
+// paidStatuses are the order statuses of orders that were paid
+var paidStatuses = map[string]bool{
+	"PAID":      true,
+	"FULFILLED": true,
+	"DELIVERED": true,
+}
+
+// validWindows are the best-seller windows of the catalog
+var validWindows = map[string]bool{
+	"":         true,
+	"DAY":      true,
+	"WEEK":     true,
+	"MONTH":    true,
+	"ALL_TIME": true,
+}
+
+// AdminMethods are the admin-scoped endpoints of the admin service: every
+// RPC and every dashboard path
+var AdminMethods = []string{
+	"/" + pb.AdminService_ServiceDesc.ServiceName + "/",
+	"/admin/",
+}
+
+// MethodRoles maps every RPC of the admin service to the roles allowed to
+// call it: ADMIN only
+func MethodRoles() map[string][]string {
+	methods := pb.AdminService_ServiceDesc.Methods
+	roles := make(map[string][]string, len(methods))
+	for _, m := range methods {
+		roles["/"+pb.AdminService_ServiceDesc.ServiceName+"/"+m.MethodName] = []string{auth.RoleAdmin}
+	}
+	return roles
+}
+
+// Service implements the AdminService gRPC interface
+type Service struct {
+	pb.UnimplementedAdminServiceServer
+	orders        Orders
+	payments      Payments
+	catalog       Catalog
+	accounts      Accounts
+	authenticator *auth.Interceptor
+	log           *logger.Logger
+	now           func() time.Time
+}
+
+// NewService creates a new admin service. authenticator is the auth
+// interceptor of the gRPC server, requiring MethodRoles; the HTTP handler
+// checks the bearer tokens of dashboard requests with it too.
+func NewService(orders Orders, payments Payments, catalog Catalog, accounts Accounts, authenticator *auth.Interceptor, log *logger.Logger) *Service {
+	return &Service{
+		orders:        orders,
+		payments:      payments,
+		catalog:       catalog,
+		accounts:      accounts,
+		authenticator: authenticator,
+		log:           log,
+		now:           time.Now,
+	}
+}
+
+// GetDailySales adds up the orders placed on each day of a period and the
+// payments taken on them
+func (s *Service) GetDailySales(ctx context.Context, req *pb.GetDailySalesRequest) (*pb.GetDailySalesResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	from, to, msg := s.period(req.From, req.To)
+	if msg != "" {
+		s.log.Warn(ctx, "Get daily sales failed: "+msg, map[string]interface{}{"from": req.From, "to": req.To})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	orders, err := s.orders.PlacedBetween(ctx, from, to)
+	if errors.Is(err, ErrTooManyOrders) {
+		return nil, status.Error(codes.FailedPrecondition, "period has too many orders; choose a shorter period")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to read orders", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	days := make(map[string]*pb.DailySales)
+	total := &pb.DailySales{}
+	for _, o := range orders {
+		date := o.CreatedAt.UTC().Format(dateLayout)
+		if days[date] == nil {
+			days[date] = &pb.DailySales{}
+		}
+
+		paid := paidStatuses[o.Status]
+		var captured, refunded float64
+		if paid {
+			payments, err := s.payments.ForOrder(ctx, o.ID)
+			if err != nil {
+				s.log.Error(ctx, "Failed to read payments", map[string]interface{}{"error": err.Error(), "order_id": o.ID})
+				return nil, status.Error(codes.Unavailable, "payment service unavailable")
+			}
+			for _, p := range payments {
+				captured += p.Captured
+				refunded += p.Refunded
+			}
+		}
+
+		for _, d := range []*pb.DailySales{days[date], total} {
+			d.Orders++
+			if o.Status == "CANCELLED" {
+				d.CancelledOrders++
+			}
+			if paid {
+				d.PaidOrders++
+				d.GrossSales += o.Total
+				d.Captured += captured
+				d.Refunded += refunded
+			}
+		}
+	}
+
+	resp := &pb.GetDailySalesResponse{Total: finishSales(total)}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		sales := days[date]
+		if sales == nil {
+			sales = &pb.DailySales{}
+		}
+		sales.Date = date
+		resp.Days = append(resp.Days, finishSales(sales))
+	}
+	return resp, nil
+}
+
+// finishSales rounds a day's amounts to the cent and derives its net sales
+// and average order value
+func finishSales(d *pb.DailySales) *pb.DailySales {
+	d.GrossSales = roundCents(d.GrossSales)
+	d.Captured = roundCents(d.Captured)
+	d.Refunded = roundCents(d.Refunded)
+	d.NetSales = roundCents(d.Captured - d.Refunded)
+	if d.PaidOrders > 0 {
+		d.AverageOrderValue = roundCents(d.GrossSales / float64(d.PaidOrders))
+	}
+	return d
+}
+
+// GetTopProducts returns the catalog's best sellers with their conversion
+// rate
+func (s *Service) GetTopProducts(ctx context.Context, req *pb.GetTopProductsRequest) (*pb.GetTopProductsResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	window := strings.ToUpper(req.Window)
+	if !validWindows[window] {
+		s.log.Warn(ctx, "Get top products failed: invalid window", map[string]interface{}{"window": req.Window})
+		return nil, status.Error(codes.InvalidArgument, "window must be DAY, WEEK, MONTH or ALL_TIME")
+	}
+
+	sellers, err := s.catalog.BestSellers(ctx, window, req.Category, limit(req.Limit))
+	if err != nil {
+		s.log.Error(ctx, "Failed to read best sellers", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Unavailable, "catalog service unavailable")
+	}
+
+	resp := &pb.GetTopProductsResponse{Products: make([]*pb.TopProduct, len(sellers))}
+	for i, bs := range sellers {
+		product := &pb.TopProduct{
+			ProductId:  bs.ProductID,
+			Sku:        bs.SKU,
+			Name:       bs.Name,
+			Category:   bs.Category,
+			Price:      bs.Price,
+			UnitsSold:  bs.UnitsSold,
+			OrderCount: bs.OrderCount,
+			ViewCount:  bs.ViewCount,
+		}
+		if bs.ViewCount > 0 {
+			product.ConversionRate = math.Round(float64(bs.OrderCount)/float64(bs.ViewCount)*10000) / 10000
+		}
+		resp.Products[i] = product
+	}
+	return resp, nil
+}
+
+// GetNewCustomers counts the customers registered on each day of a period
+// and lists the newest
+func (s *Service) GetNewCustomers(ctx context.Context, req *pb.GetNewCustomersRequest) (*pb.GetNewCustomersResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	from, to, msg := s.period(req.From, req.To)
+	if msg != "" {
+		s.log.Warn(ctx, "Get new customers failed: "+msg, map[string]interface{}{"from": req.From, "to": req.To})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	customers, err := s.accounts.RegisteredBetween(ctx, from, to)
+	if errors.Is(err, ErrTooManyCustomers) {
+		return nil, status.Error(codes.FailedPrecondition, "period has too many customers; choose a shorter period")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to read customers", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Unavailable, "account service unavailable")
+	}
+
+	counts := make(map[string]int32)
+	for _, c := range customers {
+		counts[c.CreatedAt.UTC().Format(dateLayout)]++
+	}
+
+	resp := &pb.GetNewCustomersResponse{Total: int32(len(customers))}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		resp.Days = append(resp.Days, &pb.DailyCount{Date: date, Count: counts[date]})
+	}
+	for _, c := range customers[:min(len(customers), int(limit(req.Limit)))] {
+		resp.Latest = append(resp.Latest, &pb.Customer{
+			Id:        c.ID,
+			Email:     c.Email,
+			Name:      c.Name,
+			CreatedAt: timestamppb.New(c.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// period parses a request's first and last day into the [from, to) range
+// they cover, in UTC. It returns a message describing the first problem, or
+// "".
+func (s *Service) period(fromDay, toDay string) (time.Time, time.Time, string) {
+	last := s.now().UTC().Truncate(24 * time.Hour)
+	if toDay != "" {
+		day, err := time.Parse(dateLayout, toDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, "to must be a date as YYYY-MM-DD"
+		}
+		last = day
+	}
+	first := last.AddDate(0, 0, 1-defaultPeriodDays)
+	if fromDay != "" {
+		day, err := time.Parse(dateLayout, fromDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, "from must be a date as YYYY-MM-DD"
+		}
+		first = day
+	}
+
+	switch {
+	case first.After(last):
+		return time.Time{}, time.Time{}, "from cannot be after to"
+	case last.Sub(first) >= maxPeriodDays*24*time.Hour:
+		return time.Time{}, time.Time{}, "period cannot be longer than 92 days"
+	}
+	return first, last.AddDate(0, 0, 1), ""
+}
+
+// requireAdmin checks that the call, authenticated by the auth interceptor,
+// carries an admin's token
+func (s *Service) requireAdmin(ctx context.Context) error {
+	err := auth.RequireRole(ctx, auth.RoleAdmin)
+	if status.Code(err) == codes.PermissionDenied {
+		userID, _ := auth.UserIDFromContext(ctx)
+		s.log.Warn(ctx, "Admin role required", map[string]interface{}{"user_id": userID})
+	}
+	return err
+}
+
+// limit applies the default and maximum to a requested list length
+func limit(requested int32) int32 {
+	if requested < 1 {
+		return 10
+	}
+	return min(requested, 100)
+}
+
+// roundCents rounds an amount to the cent
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
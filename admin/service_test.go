@@ -0,0 +1,259 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/admin/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeOrders struct {
+	orders   []*Order
+	err      error
+	from, to time.Time
+}
+
+func (f *fakeOrders) PlacedBetween(ctx context.Context, from, to time.Time) ([]*Order, error) {
+	f.from, f.to = from, to
+	return f.orders, f.err
+}
+
+type fakePayments struct {
+	payments map[string][]*Payment
+	err      error
+	calls    []string
+}
+
+func (f *fakePayments) ForOrder(ctx context.Context, orderID string) ([]*Payment, error) {
+	f.calls = append(f.calls, orderID)
+	return f.payments[orderID], f.err
+}
+
+type fakeCatalog struct {
+	sellers  []*BestSeller
+	err      error
+	window   string
+	category string
+	limit    int32
+}
+
+func (f *fakeCatalog) BestSellers(ctx context.Context, window, category string, limit int32) ([]*BestSeller, error) {
+	f.window, f.category, f.limit = window, category, limit
+	return f.sellers, f.err
+}
+
+type fakeAccounts struct {
+	customers []*Customer
+	err       error
+}
+
+func (f *fakeAccounts) RegisteredBetween(ctx context.Context, from, to time.Time) ([]*Customer, error) {
+	return f.customers, f.err
+}
+
+// testTokens signs the tokens of test callers
+var testTokens = auth.NewTokenService("test-secret", time.Minute, time.Hour)
+
+// contextWithRole returns the context of a call the auth interceptor
+// authenticated for a caller with role
+func contextWithRole(role string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Email: "admin@example.com", Role: role})
+}
+
+func newTestService(orders *fakeOrders, payments *fakePayments, catalog *fakeCatalog, accounts *fakeAccounts) *Service {
+	authenticator := auth.NewInterceptor(testTokens, auth.InterceptorConfig{ExemptMethods: []string{"/"}, RequiredRoles: MethodRoles()})
+	s := NewService(orders, payments, catalog, accounts, authenticator, logger.New("admin-test"))
+	s.now = func() time.Time { return time.Date(2026, 6, 7, 15, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func TestService_GetDailySales(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, 6, d, hour, 0, 0, 0, time.UTC) }
+	orders := &fakeOrders{orders: []*Order{
+		{ID: "o4", Status: "CANCELLED", Total: 15, CreatedAt: day(7, 9)},
+		{ID: "o3", Status: "DELIVERED", Total: 20.5, CreatedAt: day(6, 18)},
+		{ID: "o2", Status: "PENDING", Total: 99, CreatedAt: day(6, 10)},
+		{ID: "o1", Status: "PAID", Total: 40, CreatedAt: day(5, 8)},
+	}}
+	payments := &fakePayments{payments: map[string][]*Payment{
+		"o1": {{Status: "PARTIALLY_REFUNDED", Captured: 40, Refunded: 10}},
+		"o3": {{Status: "CAPTURED", Captured: 20.5}},
+	}}
+	service := newTestService(orders, payments, &fakeCatalog{}, &fakeAccounts{})
+
+	resp, err := service.GetDailySales(contextWithRole("ADMIN"), &pb.GetDailySalesRequest{From: "2026-06-05", To: "2026-06-07"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !orders.from.Equal(day(5, 0)) || !orders.to.Equal(day(8, 0)) {
+		t.Errorf("Expected orders read from %v to %v, got %v to %v", day(5, 0), day(8, 0), orders.from, orders.to)
+	}
+	if len(payments.calls) != 2 {
+		t.Errorf("Expected payments read for the 2 paid orders, got %v", payments.calls)
+	}
+
+	if len(resp.Days) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(resp.Days))
+	}
+	if d := resp.Days[0]; d.Date != "2026-06-05" || d.Orders != 1 || d.PaidOrders != 1 || d.GrossSales != 40 || d.NetSales != 30 || d.AverageOrderValue != 40 {
+		t.Errorf("Unexpected first day %v", d)
+	}
+	if d := resp.Days[1]; d.Date != "2026-06-06" || d.Orders != 2 || d.PaidOrders != 1 || d.GrossSales != 20.5 || d.Captured != 20.5 {
+		t.Errorf("Unexpected second day %v", d)
+	}
+	if d := resp.Days[2]; d.Date != "2026-06-07" || d.Orders != 1 || d.CancelledOrders != 1 || d.PaidOrders != 0 || d.GrossSales != 0 {
+		t.Errorf("Unexpected third day %v", d)
+	}
+
+	total := resp.Total
+	if total.Orders != 4 || total.PaidOrders != 2 || total.CancelledOrders != 1 || total.GrossSales != 60.5 ||
+		total.Captured != 60.5 || total.Refunded != 10 || total.NetSales != 50.5 || total.AverageOrderValue != 30.25 {
+		t.Errorf("Unexpected total %v", total)
+	}
+}
+
+func TestService_GetDailySales_DefaultPeriod(t *testing.T) {
+	orders := &fakeOrders{}
+	service := newTestService(orders, &fakePayments{}, &fakeCatalog{}, &fakeAccounts{})
+
+	resp, err := service.GetDailySales(contextWithRole("ADMIN"), &pb.GetDailySalesRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Days) != 7 || resp.Days[0].Date != "2026-06-01" || resp.Days[6].Date != "2026-06-07" {
+		t.Errorf("Expected the 7 days up to today, got %v", resp.Days)
+	}
+}
+
+func TestService_GetDailySales_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *pb.GetDailySalesRequest
+		orders   *fakeOrders
+		payments *fakePayments
+		code     codes.Code
+	}{
+		{"bad to", &pb.GetDailySalesRequest{To: "06/07/2026"}, &fakeOrders{}, &fakePayments{}, codes.InvalidArgument},
+		{"bad from", &pb.GetDailySalesRequest{From: "yesterday"}, &fakeOrders{}, &fakePayments{}, codes.InvalidArgument},
+		{"from after to", &pb.GetDailySalesRequest{From: "2026-06-08", To: "2026-06-07"}, &fakeOrders{}, &fakePayments{}, codes.InvalidArgument},
+		{"period too long", &pb.GetDailySalesRequest{From: "2026-01-01", To: "2026-06-07"}, &fakeOrders{}, &fakePayments{}, codes.InvalidArgument},
+		{"too many orders", &pb.GetDailySalesRequest{}, &fakeOrders{err: ErrTooManyOrders}, &fakePayments{}, codes.FailedPrecondition},
+		{"order service down", &pb.GetDailySalesRequest{}, &fakeOrders{err: errors.New("connection refused")}, &fakePayments{}, codes.Unavailable},
+		{
+			"payment service down",
+			&pb.GetDailySalesRequest{},
+			&fakeOrders{orders: []*Order{{ID: "o1", Status: "PAID", Total: 10, CreatedAt: time.Date(2026, 6, 7, 9, 0, 0, 0, time.UTC)}}},
+			&fakePayments{err: errors.New("connection refused")},
+			codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(tt.orders, tt.payments, &fakeCatalog{}, &fakeAccounts{})
+			_, err := service.GetDailySales(contextWithRole("ADMIN"), tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestService_RequireAdmin(t *testing.T) {
+	service := newTestService(&fakeOrders{}, &fakePayments{}, &fakeCatalog{}, &fakeAccounts{})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"no token", context.Background(), codes.Unauthenticated},
+		{"customer", contextWithRole("USER"), codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.GetDailySales(tt.ctx, &pb.GetDailySalesRequest{}); status.Code(err) != tt.code {
+				t.Errorf("GetDailySales: expected %v, got %v", tt.code, err)
+			}
+			if _, err := service.GetTopProducts(tt.ctx, &pb.GetTopProductsRequest{}); status.Code(err) != tt.code {
+				t.Errorf("GetTopProducts: expected %v, got %v", tt.code, err)
+			}
+			if _, err := service.GetNewCustomers(tt.ctx, &pb.GetNewCustomersRequest{}); status.Code(err) != tt.code {
+				t.Errorf("GetNewCustomers: expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestService_GetTopProducts(t *testing.T) {
+	catalog := &fakeCatalog{sellers: []*BestSeller{
+		{ProductID: "p1", SKU: "MUG-1", Name: "Mug", Category: "mugs", Price: 12, UnitsSold: 30, OrderCount: 20, ViewCount: 300},
+		{ProductID: "p2", SKU: "MUG-2", Name: "Big Mug", Category: "mugs", Price: 15, UnitsSold: 4, OrderCount: 4},
+	}}
+	service := newTestService(&fakeOrders{}, &fakePayments{}, catalog, &fakeAccounts{})
+
+	resp, err := service.GetTopProducts(contextWithRole("ADMIN"), &pb.GetTopProductsRequest{Window: "week", Category: "mugs", Limit: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if catalog.window != "WEEK" || catalog.category != "mugs" || catalog.limit != 100 {
+		t.Errorf("Unexpected catalog request %q %q %d", catalog.window, catalog.category, catalog.limit)
+	}
+	if len(resp.Products) != 2 {
+		t.Fatalf("Expected 2 products, got %d", len(resp.Products))
+	}
+	if p := resp.Products[0]; p.Sku != "MUG-1" || p.UnitsSold != 30 || p.ConversionRate != 0.0667 {
+		t.Errorf("Unexpected first product %v", p)
+	}
+	if p := resp.Products[1]; p.ConversionRate != 0 {
+		t.Errorf("Expected no conversion rate without views, got %v", p.ConversionRate)
+	}
+
+	if _, err := service.GetTopProducts(contextWithRole("ADMIN"), &pb.GetTopProductsRequest{Window: "YEAR"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown window, got %v", err)
+	}
+
+	catalog.err = errors.New("connection refused")
+	if _, err := service.GetTopProducts(contextWithRole("ADMIN"), &pb.GetTopProductsRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
+
+func TestService_GetNewCustomers(t *testing.T) {
+	accounts := &fakeAccounts{customers: []*Customer{
+		{ID: "c3", Email: "c3@example.com", Name: "Cleo", CreatedAt: time.Date(2026, 6, 7, 11, 0, 0, 0, time.UTC)},
+		{ID: "c2", Email: "c2@example.com", Name: "Bram", CreatedAt: time.Date(2026, 6, 7, 8, 0, 0, 0, time.UTC)},
+		{ID: "c1", Email: "c1@example.com", Name: "Ada", CreatedAt: time.Date(2026, 6, 5, 20, 0, 0, 0, time.UTC)},
+	}}
+	service := newTestService(&fakeOrders{}, &fakePayments{}, &fakeCatalog{}, accounts)
+
+	resp, err := service.GetNewCustomers(contextWithRole("ADMIN"), &pb.GetNewCustomersRequest{From: "2026-06-05", To: "2026-06-07", Limit: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 3 {
+		t.Errorf("Expected 3 customers, got %d", resp.Total)
+	}
+	counts := map[string]int32{}
+	for _, d := range resp.Days {
+		counts[d.Date] = d.Count
+	}
+	if len(resp.Days) != 3 || counts["2026-06-05"] != 1 || counts["2026-06-06"] != 0 || counts["2026-06-07"] != 2 {
+		t.Errorf("Unexpected days %v", resp.Days)
+	}
+	if len(resp.Latest) != 2 || resp.Latest[0].Id != "c3" || resp.Latest[1].Id != "c2" {
+		t.Errorf("Expected the 2 newest customers, got %v", resp.Latest)
+	}
+
+	accounts.err = ErrTooManyCustomers
+	if _, err := service.GetNewCustomers(contextWithRole("ADMIN"), &pb.GetNewCustomersRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package cart
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// abandonBatchSize is how many idle carts are marked abandoned per transaction
+	abandonBatchSize = 500
+	// DefaultAbandonAfter is how long a cart stays unchanged before it is
+	// abandoned
+	DefaultAbandonAfter = 24 * time.Hour
+)
+
+// AbandonedCartDetector periodically marks carts abandoned once their
+// customer has left them unchanged for longer than the abandonment threshold.
+// Each abandoned cart gets a cart.abandoned event; changing the cart makes it
+// active again, to be abandoned anew should it go idle again.
+type AbandonedCartDetector struct {
+	repo  Repository
+	after time.Duration
+	log   *logger.Logger
+	now   func() time.Time
+}
+
+// NewAbandonedCartDetector creates a detector abandoning carts idle for
+// longer than after
+func NewAbandonedCartDetector(repo Repository, after time.Duration, log *logger.Logger) *AbandonedCartDetector {
+	return &AbandonedCartDetector{repo: repo, after: after, log: log, now: time.Now}
+}
+
+// Run detects abandoned carts every interval until ctx is cancelled
+func (d *AbandonedCartDetector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DetectAbandoned(ctx); err != nil {
+				d.log.Error(ctx, "Failed to detect abandoned carts", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
+
+// DetectAbandoned marks every idle cart abandoned, a batch at a time
+func (d *AbandonedCartDetector) DetectAbandoned(ctx context.Context) error {
+	at := d.now()
+	idleBefore := at.Add(-d.after)
+	for {
+		abandoned, err := d.repo.MarkAbandoned(ctx, idleBefore, at, abandonBatchSize)
+		if err != nil {
+			return err
+		}
+		if abandoned < abandonBatchSize {
+			return nil
+		}
+	}
+}
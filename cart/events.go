@@ -0,0 +1,57 @@
+package cart
+
+import (
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/outbox"
+)
+
+const (
+	// EventsTopic is the Kafka topic cart events are published to
+	EventsTopic = "cart-events"
+	// OutboxTable holds cart events until they are published
+	OutboxTable = "cart_outbox"
+)
+
+// EventCartAbandoned is published when a cart is marked abandoned
+const EventCartAbandoned = "cart.abandoned"
+
+// events is the outbox cart changes add their events to
+var events = outbox.New(OutboxTable, EventsTopic)
+
+// abandonedEventData is the data of a cart.abandoned event; it carries what a
+// remarketing campaign needs to remind the customer of their cart
+type abandonedEventData struct {
+	CartID         string          `json:"cart_id"`
+	TenantID       string          `json:"tenant_id"`
+	UserID         string          `json:"user_id"`
+	Items          []eventCartItem `json:"items"`
+	LastActivityAt time.Time       `json:"last_activity_at"`
+	AbandonedAt    time.Time       `json:"abandoned_at"`
+}
+
+type eventCartItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// abandonedEvent describes cart c being marked abandoned
+func abandonedEvent(c *Cart) outbox.Event {
+	data := abandonedEventData{
+		CartID:         c.ID,
+		TenantID:       c.TenantID,
+		UserID:         c.UserID,
+		Items:          make([]eventCartItem, len(c.Items)),
+		LastActivityAt: c.UpdatedAt.UTC(),
+		AbandonedAt:    c.AbandonedAt.UTC(),
+	}
+	for i, item := range c.Items {
+		data.Items[i] = eventCartItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	return outbox.Event{
+		Type:       EventCartAbandoned,
+		Key:        c.ID,
+		OccurredAt: *c.AbandonedAt,
+		Data:       data,
+	}
+}
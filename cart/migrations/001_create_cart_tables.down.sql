@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS cart_items;
+DROP INDEX IF EXISTS idx_carts_abandoned;
+DROP INDEX IF EXISTS idx_carts_idle;
+DROP TABLE IF EXISTS carts;
@@ -0,0 +1,25 @@
+-- Shopping carts, one per customer. updated_at is the customer's last change
+-- to the cart; an active cart with items left unchanged for longer than the
+-- abandonment threshold is marked ABANDONED, and becomes ACTIVE again when
+-- the customer changes it.
+CREATE TABLE IF NOT EXISTS carts (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'ABANDONED')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    abandoned_at TIMESTAMP,
+    CHECK ((status = 'ABANDONED') = (abandoned_at IS NOT NULL))
+);
+
+-- Active carts by idle time, and abandoned carts, most recent first
+CREATE INDEX idx_carts_idle ON carts(updated_at) WHERE status = 'ACTIVE';
+CREATE INDEX idx_carts_abandoned ON carts(abandoned_at DESC) WHERE status = 'ABANDONED';
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    cart_id UUID NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    product_id VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cart_id, product_id)
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_carts_tenant_abandoned;
+CREATE INDEX idx_carts_abandoned ON carts(abandoned_at DESC) WHERE status = 'ABANDONED';
+
+ALTER TABLE carts DROP CONSTRAINT IF EXISTS carts_tenant_user_key;
+ALTER TABLE carts ADD CONSTRAINT carts_user_id_key UNIQUE (user_id);
+
+ALTER TABLE carts DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,9 @@
+-- Carts belong to a tenant (store); a customer has one cart in each store.
+-- Existing carts belong to the default tenant.
+ALTER TABLE carts ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+ALTER TABLE carts DROP CONSTRAINT IF EXISTS carts_user_id_key;
+ALTER TABLE carts ADD CONSTRAINT carts_tenant_user_key UNIQUE (tenant_id, user_id);
+
+DROP INDEX IF EXISTS idx_carts_abandoned;
+CREATE INDEX idx_carts_tenant_abandoned ON carts(tenant_id, abandoned_at DESC) WHERE status = 'ABANDONED';
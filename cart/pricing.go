@@ -0,0 +1,96 @@
+package cart
+
+import (
+	"context"
+	"errors"
+
+	pricingpb "github.com/Ujjwaljain16/E-commerce-Backend/pricing/pb"
+)
+
+// ErrPricingDisabled is returned when a cart is priced in a deployment
+// without a pricing service
+var ErrPricingDisabled = errors.New("cart pricing is not available")
+
+// PriceTerms are who a cart is priced for, where and in which currency
+type PriceTerms struct {
+	CustomerSegment string
+	Channel         string
+	Currency        string
+}
+
+// PricedItem is a cart line at its resolved price
+type PricedItem struct {
+	ProductID string
+	Quantity  int32
+	// BaseUnitPrice is the price before price rules
+	BaseUnitPrice float64
+	UnitPrice     float64
+	LineTotal     float64
+}
+
+// PricedCart is a cart at its resolved prices
+type PricedCart struct {
+	Items    []*PricedItem
+	Subtotal float64
+	Currency string
+}
+
+// Pricer resolves the prices of cart lines
+type Pricer interface {
+	// Price prices the items of a cart for the terms. Errors from the pricing
+	// service are returned as gRPC status errors.
+	Price(ctx context.Context, items []*Item, terms PriceTerms) (*PricedCart, error)
+}
+
+type noPricer struct{}
+
+// NewNoPricer creates a Pricer that refuses to price, for deployments
+// without a pricing service
+func NewNoPricer() Pricer {
+	return noPricer{}
+}
+
+func (noPricer) Price(ctx context.Context, items []*Item, terms PriceTerms) (*PricedCart, error) {
+	return nil, ErrPricingDisabled
+}
+
+type grpcPricer struct {
+	client pricingpb.PricingServiceClient
+}
+
+// NewGRPCPricer creates a Pricer backed by the pricing service
+func NewGRPCPricer(client pricingpb.PricingServiceClient) Pricer {
+	return &grpcPricer{client: client}
+}
+
+func (p *grpcPricer) Price(ctx context.Context, items []*Item, terms PriceTerms) (*PricedCart, error) {
+	req := &pricingpb.ResolvePricesRequest{
+		Lines:           make([]*pricingpb.PriceLine, len(items)),
+		CustomerSegment: terms.CustomerSegment,
+		Channel:         terms.Channel,
+		Currency:        terms.Currency,
+	}
+	for i, item := range items {
+		req.Lines[i] = &pricingpb.PriceLine{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+
+	resp, err := p.client.ResolvePrices(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	priced := &PricedCart{
+		Items:    make([]*PricedItem, len(resp.Prices)),
+		Subtotal: resp.Subtotal,
+		Currency: resp.Currency,
+	}
+	for i, price := range resp.Prices {
+		priced.Items[i] = &PricedItem{
+			ProductID:     price.ProductId,
+			Quantity:      price.Quantity,
+			BaseUnitPrice: price.BaseUnitPrice,
+			UnitPrice:     price.UnitPrice,
+			LineTotal:     price.LineTotal,
+		}
+	}
+	return priced, nil
+}
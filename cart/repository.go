@@ -0,0 +1,441 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Cart statuses
+const (
+	StatusActive    = "ACTIVE"
+	StatusAbandoned = "ABANDONED"
+)
+
+const (
+	// MaxItems is how many different products a cart holds
+	MaxItems = 100
+	// MaxQuantity is the most of one product a cart holds
+	MaxQuantity = 999
+)
+
+var (
+	// ErrCartNotFound is returned when a customer has no cart
+	ErrCartNotFound = errors.New("cart not found")
+	// ErrCartFull is returned when an item would take a cart over MaxItems
+	ErrCartFull = errors.New("cart is full")
+	// ErrQuantityLimit is returned when an item would take a product's
+	// quantity over MaxQuantity
+	ErrQuantityLimit = errors.New("quantity limit exceeded")
+)
+
+// Cart is a customer's shopping cart
+type Cart struct {
+	ID        string
+	TenantID  string
+	UserID    string
+	Status    string
+	Items     []*Item
+	CreatedAt time.Time
+	// UpdatedAt is the customer's last change to the cart
+	UpdatedAt   time.Time
+	AbandonedAt *time.Time
+}
+
+// Item is a product and quantity in a cart
+type Item struct {
+	ProductID string
+	Quantity  int32
+	AddedAt   time.Time
+}
+
+// Repository handles cart data persistence. Carts are scoped to the tenant
+// of the request context, except by the sweeps MarkAbandoned and DeleteIdle,
+// which cover every tenant.
+type Repository interface {
+	// Get returns the customer's cart, or ErrCartNotFound
+	Get(ctx context.Context, userID string) (*Cart, error)
+	// AddItem adds quantity of a product to the customer's cart, creating the
+	// cart if need be
+	AddItem(ctx context.Context, userID, productID string, quantity int32, at time.Time) (*Cart, error)
+	// SetItem sets the quantity of a product in the customer's cart; 0
+	// removes it
+	SetItem(ctx context.Context, userID, productID string, quantity int32, at time.Time) (*Cart, error)
+	// Clear empties the customer's cart
+	Clear(ctx context.Context, userID string, at time.Time) (*Cart, error)
+	// MarkAbandoned marks up to limit active carts with items left unchanged
+	// since idleBefore abandoned at at, adding a cart.abandoned event for
+	// each, and returns how many it marked
+	MarkAbandoned(ctx context.Context, idleBefore, at time.Time, limit int) (int, error)
+	// ListAbandoned lists abandoned carts of the tenant, most recently
+	// abandoned first,
+	// optionally only those abandoned after after and before before
+	ListAbandoned(ctx context.Context, after, before *time.Time, page, pageSize int32) ([]*Cart, int32, error)
+	// DeleteIdle deletes up to limit carts left unchanged since idleBefore,
+	// with their items, and returns how many it deleted
+	DeleteIdle(ctx context.Context, idleBefore time.Time, limit int) (int, error)
+	// Delete deletes the customer's cart with its items and reports whether
+	// there was one
+	Delete(ctx context.Context, userID string) (bool, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL cart repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const cartColumns = `id, tenant_id, user_id, status, created_at, updated_at, abandoned_at`
+
+// querier is implemented by *sql.DB and *sql.Tx
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Get retrieves a customer's cart with its items
+func (r *postgresRepository) Get(ctx context.Context, userID string) (*Cart, error) {
+	return r.get(ctx, r.db, userID)
+}
+
+func (r *postgresRepository) get(ctx context.Context, q querier, userID string) (*Cart, error) {
+	c, err := scanCart(q.QueryRowContext(ctx, "SELECT "+cartColumns+" FROM carts WHERE tenant_id = $1 AND user_id = $2", tenant.FromContext(ctx), userID))
+	if err == sql.ErrNoRows {
+		return nil, ErrCartNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get cart", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+	if err := loadItems(ctx, q, []*Cart{c}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddItem adds to the product's quantity in the cart
+func (r *postgresRepository) AddItem(ctx context.Context, userID, productID string, quantity int32, at time.Time) (*Cart, error) {
+	return r.change(ctx, userID, at, func(tx *sql.Tx, cartID string) error {
+		var total int32
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO cart_items (cart_id, product_id, quantity, added_at) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity
+			RETURNING quantity
+		`, cartID, productID, quantity, at).Scan(&total)
+		if err != nil {
+			return fmt.Errorf("failed to add item: %w", err)
+		}
+		if total > MaxQuantity {
+			return ErrQuantityLimit
+		}
+		return checkItemCount(ctx, tx, cartID)
+	})
+}
+
+// SetItem sets the product's quantity in the cart, removing it at 0
+func (r *postgresRepository) SetItem(ctx context.Context, userID, productID string, quantity int32, at time.Time) (*Cart, error) {
+	return r.change(ctx, userID, at, func(tx *sql.Tx, cartID string) error {
+		if quantity == 0 {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2", cartID, productID); err != nil {
+				return fmt.Errorf("failed to remove item: %w", err)
+			}
+			return nil
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO cart_items (cart_id, product_id, quantity, added_at) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = EXCLUDED.quantity
+		`, cartID, productID, quantity, at)
+		if err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+		return checkItemCount(ctx, tx, cartID)
+	})
+}
+
+// Clear deletes the cart's items
+func (r *postgresRepository) Clear(ctx context.Context, userID string, at time.Time) (*Cart, error) {
+	return r.change(ctx, userID, at, func(tx *sql.Tx, cartID string) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM cart_items WHERE cart_id = $1", cartID); err != nil {
+			return fmt.Errorf("failed to clear cart: %w", err)
+		}
+		return nil
+	})
+}
+
+// change applies a customer's change to their cart in one transaction. The
+// cart is created if need be, or locked and made active again, then fn
+// changes its items and the changed cart is returned.
+func (r *postgresRepository) change(ctx context.Context, userID string, at time.Time, fn func(tx *sql.Tx, cartID string) error) (*Cart, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var cartID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO carts (id, tenant_id, user_id, status, created_at, updated_at) VALUES ($1, $2, $3, 'ACTIVE', $4, $4)
+		ON CONFLICT (tenant_id, user_id) DO UPDATE SET status = 'ACTIVE', abandoned_at = NULL, updated_at = EXCLUDED.updated_at
+		RETURNING id
+	`, uuid.New().String(), tenant.FromContext(ctx), userID, at).Scan(&cartID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to update cart", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, fmt.Errorf("failed to update cart: %w", err)
+	}
+
+	if err := fn(tx, cartID); err != nil {
+		return nil, err
+	}
+	c, err := r.get(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return c, nil
+}
+
+// checkItemCount returns ErrCartFull when the cart holds more than MaxItems
+// products
+func checkItemCount(ctx context.Context, tx *sql.Tx, cartID string) error {
+	var count int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM cart_items WHERE cart_id = $1", cartID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count items: %w", err)
+	}
+	if count > MaxItems {
+		return ErrCartFull
+	}
+	return nil
+}
+
+// MarkAbandoned marks idle carts abandoned and adds their events in one
+// transaction. Carts being changed by their customer are left for the next
+// sweep.
+func (r *postgresRepository) MarkAbandoned(ctx context.Context, idleBefore, at time.Time, limit int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "SELECT "+cartColumns+` FROM carts c
+		WHERE status = 'ACTIVE' AND updated_at < $1
+			AND EXISTS (SELECT 1 FROM cart_items i WHERE i.cart_id = c.id)
+		ORDER BY updated_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, idleBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock idle carts: %w", err)
+	}
+	idle, err := scanCarts(rows)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan idle carts: %w", err)
+	}
+	if len(idle) == 0 {
+		return 0, nil
+	}
+	if err := loadItems(ctx, tx, idle); err != nil {
+		return 0, err
+	}
+
+	ids := make([]string, len(idle))
+	for i, c := range idle {
+		ids[i] = c.ID
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE carts SET status = 'ABANDONED', abandoned_at = $2 WHERE id = ANY($1)", pq.Array(ids), at); err != nil {
+		r.log.Error(ctx, "Failed to mark carts abandoned", map[string]interface{}{"error": err.Error()})
+		return 0, fmt.Errorf("failed to mark carts abandoned: %w", err)
+	}
+	for _, c := range idle {
+		c.Status = StatusAbandoned
+		c.AbandonedAt = &at
+		if err := events.Add(ctx, tx, abandonedEvent(c)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	r.log.Info(ctx, "Carts abandoned", map[string]interface{}{"carts": len(idle)})
+	return len(idle), nil
+}
+
+// ListAbandoned retrieves abandoned carts with pagination
+func (r *postgresRepository) ListAbandoned(ctx context.Context, after, before *time.Time, page, pageSize int32) ([]*Cart, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	where := "WHERE tenant_id = $1 AND status = 'ABANDONED'"
+	args := []interface{}{tenant.FromContext(ctx)}
+	if after != nil {
+		args = append(args, *after)
+		where += fmt.Sprintf(" AND abandoned_at > $%d", len(args))
+	}
+	if before != nil {
+		args = append(args, *before)
+		where += fmt.Sprintf(" AND abandoned_at < $%d", len(args))
+	}
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM carts "+where, args...).Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count abandoned carts", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count abandoned carts: %w", err)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM carts %s ORDER BY abandoned_at DESC, id LIMIT $%d OFFSET $%d",
+		cartColumns, where, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, query, append(args, pageSize, offset)...)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list abandoned carts", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list abandoned carts: %w", err)
+	}
+	carts, err := scanCarts(rows)
+	if err != nil {
+		r.log.Error(ctx, "Failed to scan abandoned carts", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to scan abandoned carts: %w", err)
+	}
+	if err := loadItems(ctx, r.db, carts); err != nil {
+		return nil, 0, err
+	}
+
+	return carts, total, nil
+}
+
+// DeleteIdle deletes a batch of idle carts. Carts being changed by their
+// customer are left for the next batch.
+func (r *postgresRepository) DeleteIdle(ctx context.Context, idleBefore time.Time, limit int) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM carts WHERE id IN (
+			SELECT id FROM carts WHERE updated_at < $1
+			ORDER BY updated_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, idleBefore, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to delete idle carts", map[string]interface{}{"error": err.Error()})
+		return 0, fmt.Errorf("failed to delete idle carts: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted carts: %w", err)
+	}
+	return int(deleted), nil
+}
+
+// Delete deletes a customer's cart; its items are deleted with it
+func (r *postgresRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM carts WHERE tenant_id = $1 AND user_id = $2", tenant.FromContext(ctx), userID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to delete cart", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return false, fmt.Errorf("failed to delete cart: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to count deleted carts: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// loadItems fills in the items of carts, oldest first
+func loadItems(ctx context.Context, q querier, carts []*Cart) error {
+	if len(carts) == 0 {
+		return nil
+	}
+	byID := make(map[string]*Cart, len(carts))
+	ids := make([]string, len(carts))
+	for i, c := range carts {
+		c.Items = []*Item{}
+		byID[c.ID] = c
+		ids[i] = c.ID
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT cart_id, product_id, quantity, added_at FROM cart_items
+		WHERE cart_id = ANY($1)
+		ORDER BY added_at, product_id
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get cart items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cartID string
+		item := &Item{}
+		if err := rows.Scan(&cartID, &item.ProductID, &item.Quantity, &item.AddedAt); err != nil {
+			return fmt.Errorf("failed to scan cart item: %w", err)
+		}
+		if c := byID[cartID]; c != nil {
+			c.Items = append(c.Items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating cart items: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCart scans a cart selected with cartColumns
+func scanCart(row rowScanner) (*Cart, error) {
+	c := &Cart{}
+	var abandonedAt sql.NullTime
+	if err := row.Scan(&c.ID, &c.TenantID, &c.UserID, &c.Status, &c.CreatedAt, &c.UpdatedAt, &abandonedAt); err != nil {
+		return nil, err
+	}
+	if abandonedAt.Valid {
+		c.AbandonedAt = &abandonedAt.Time
+	}
+	return c, nil
+}
+
+// scanCarts scans and closes rows of carts selected with cartColumns
+func scanCarts(rows *sql.Rows) ([]*Cart, error) {
+	defer rows.Close()
+
+	var carts []*Cart
+	for rows.Next() {
+		c, err := scanCart(rows)
+		if err != nil {
+			return nil, err
+		}
+		carts = append(carts, c)
+	}
+	return carts, rows.Err()
+}
@@ -0,0 +1,222 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("cart-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var cartColumnNames = []string{"id", "tenant_id", "user_id", "status", "created_at", "updated_at", "abandoned_at"}
+
+func TestGet_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM carts WHERE tenant_id = \$1 AND user_id = \$2`).
+		WithArgs(tenant.Default, "user-1").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.Get(context.Background(), "user-1"); err != ErrCartNotFound {
+		t.Errorf("Expected ErrCartNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddItem(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO carts (.+) ON CONFLICT \(tenant_id, user_id\) DO UPDATE SET status = 'ACTIVE', abandoned_at = NULL`).
+		WithArgs(sqlmock.AnyArg(), tenant.Default, "user-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("cart-1"))
+	mock.ExpectQuery(`INSERT INTO cart_items (.+) DO UPDATE SET quantity = cart_items.quantity \+ EXCLUDED.quantity`).
+		WithArgs("cart-1", "product-1", int32(2), now).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(3))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM cart_items WHERE cart_id = \$1`).
+		WithArgs("cart-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT (.+) FROM carts WHERE tenant_id = \$1 AND user_id = \$2`).
+		WithArgs(tenant.Default, "user-1").
+		WillReturnRows(sqlmock.NewRows(cartColumnNames).AddRow("cart-1", tenant.Default, "user-1", StatusActive, now, now, nil))
+	mock.ExpectQuery(`SELECT cart_id, product_id, quantity, added_at FROM cart_items`).
+		WithArgs(pq.Array([]string{"cart-1"})).
+		WillReturnRows(sqlmock.NewRows([]string{"cart_id", "product_id", "quantity", "added_at"}).AddRow("cart-1", "product-1", 3, now))
+	mock.ExpectCommit()
+
+	c, err := repo.AddItem(context.Background(), "user-1", "product-1", 2, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(c.Items) != 1 || c.Items[0].Quantity != 3 {
+		t.Errorf("Expected 3 of product-1, got %v", c.Items)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddItem_QuantityLimit(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO carts`).
+		WithArgs(sqlmock.AnyArg(), tenant.Default, "user-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("cart-1"))
+	mock.ExpectQuery(`INSERT INTO cart_items`).
+		WithArgs("cart-1", "product-1", int32(500), now).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(1000))
+	mock.ExpectRollback()
+
+	if _, err := repo.AddItem(context.Background(), "user-1", "product-1", 500, now); err != ErrQuantityLimit {
+		t.Errorf("Expected ErrQuantityLimit, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkAbandoned(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	idleBefore := now.Add(-DefaultAbandonAfter)
+	lastChange := idleBefore.Add(-time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM carts c\s+WHERE status = 'ACTIVE' AND updated_at < \$1\s+AND EXISTS (.+)\s+ORDER BY updated_at, id\s+LIMIT \$2\s+FOR UPDATE SKIP LOCKED`).
+		WithArgs(idleBefore, 500).
+		WillReturnRows(sqlmock.NewRows(cartColumnNames).AddRow("cart-1", tenant.Default, "user-1", StatusActive, lastChange, lastChange, nil))
+	mock.ExpectQuery(`SELECT cart_id, product_id, quantity, added_at FROM cart_items`).
+		WithArgs(pq.Array([]string{"cart-1"})).
+		WillReturnRows(sqlmock.NewRows([]string{"cart_id", "product_id", "quantity", "added_at"}).AddRow("cart-1", "product-1", 2, lastChange))
+	mock.ExpectExec(`UPDATE carts SET status = 'ABANDONED', abandoned_at = \$2 WHERE id = ANY\(\$1\)`).
+		WithArgs(pq.Array([]string{"cart-1"}), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO cart_outbox`).
+		WithArgs(sqlmock.AnyArg(), EventsTopic, "cart-1", EventCartAbandoned, sqlmock.AnyArg(), now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	abandoned, err := repo.MarkAbandoned(context.Background(), idleBefore, now, 500)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if abandoned != 1 {
+		t.Errorf("Expected 1 cart abandoned, got %d", abandoned)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListAbandoned(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	after := now.Add(-48 * time.Hour)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM carts WHERE tenant_id = \$1 AND status = 'ABANDONED' AND abandoned_at > \$2`).
+		WithArgs("acme", after).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT (.+) FROM carts WHERE tenant_id = \$1 AND status = 'ABANDONED' AND abandoned_at > \$2 ORDER BY abandoned_at DESC, id LIMIT \$3 OFFSET \$4`).
+		WithArgs("acme", after, int32(10), int32(0)).
+		WillReturnRows(sqlmock.NewRows(cartColumnNames).AddRow("cart-1", "acme", "user-1", StatusAbandoned, now, now, now))
+	mock.ExpectQuery(`SELECT cart_id, product_id, quantity, added_at FROM cart_items`).
+		WithArgs(pq.Array([]string{"cart-1"})).
+		WillReturnRows(sqlmock.NewRows([]string{"cart_id", "product_id", "quantity", "added_at"}).AddRow("cart-1", "product-1", 2, now))
+
+	ctx := tenant.NewContext(context.Background(), "acme")
+	carts, total, err := repo.ListAbandoned(ctx, &after, nil, 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 1 || len(carts) != 1 || carts[0].AbandonedAt == nil || len(carts[0].Items) != 1 {
+		t.Errorf("Unexpected carts %v", carts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteIdle(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	idleBefore := time.Now().AddDate(0, 0, -30)
+	mock.ExpectExec(`DELETE FROM carts WHERE id IN \(\s+SELECT id FROM carts WHERE updated_at < \$1\s+ORDER BY updated_at, id\s+LIMIT \$2\s+FOR UPDATE SKIP LOCKED`).
+		WithArgs(idleBefore, 500).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := repo.DeleteIdle(context.Background(), idleBefore, 500)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("Expected 3 carts deleted, got %d", deleted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`DELETE FROM carts WHERE tenant_id = \$1 AND user_id = \$2`).
+		WithArgs(tenant.Default, "user-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	deleted, err := repo.Delete(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !deleted {
+		t.Error("Expected the cart to be deleted")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGet_OtherTenant(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// The customer has a cart, but in another store
+	mock.ExpectQuery(`SELECT (.+) FROM carts WHERE tenant_id = \$1 AND user_id = \$2`).
+		WithArgs("acme", "user-1").
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := tenant.NewContext(context.Background(), "acme")
+	if _, err := repo.Get(ctx, "user-1"); err != ErrCartNotFound {
+		t.Errorf("Expected ErrCartNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
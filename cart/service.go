@@ -0,0 +1,364 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxIDLength matches the user_id and product_id columns
+const maxIDLength = 255
+
+// AdminMethods are the admin-scoped RPCs of the cart service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.CartService_ListAbandonedCarts_FullMethodName,
+	pb.CartService_ExpireCarts_FullMethodName,
+	pb.CartService_EraseUserData_FullMethodName,
+}
+
+// MethodRoles maps the RPCs needing a token to the roles allowed to call
+// them: EraseUserData is called by the privacy service, with a SERVICE token
+func MethodRoles() map[string][]string {
+	return map[string][]string{
+		pb.CartService_EraseUserData_FullMethodName: {auth.RoleService},
+	}
+}
+
+const (
+	// defaultExpireAfterDays is how long a cart stays unchanged before
+	// ExpireCarts deletes it, unless the request says otherwise
+	defaultExpireAfterDays = 30
+	// maxExpireAfterDays bounds the idle days of ExpireCarts
+	maxExpireAfterDays = 3650
+	// expireBatchSize is how many idle carts are deleted per statement
+	expireBatchSize = 500
+)
+
+// Service implements the CartService gRPC interface
+type Service struct {
+	pb.UnimplementedCartServiceServer
+	repo   Repository
+	pricer Pricer
+	log    *logger.Logger
+	now    func() time.Time
+}
+
+// NewService creates a new cart service
+func NewService(repo Repository, pricer Pricer, log *logger.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		pricer: pricer,
+		log:    log,
+		now:    time.Now,
+	}
+}
+
+// GetCart returns the customer's cart, empty when they have none yet
+func (s *Service) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.GetCartResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Get cart failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	c, err := s.repo.Get(ctx, req.UserId)
+	if errors.Is(err, ErrCartNotFound) {
+		return &pb.GetCartResponse{Cart: &pb.Cart{UserId: req.UserId, Status: StatusActive}}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get cart")
+	}
+
+	return &pb.GetCartResponse{Cart: toProtoCart(c)}, nil
+}
+
+// AddItem adds a product to the customer's cart
+func (s *Service) AddItem(ctx context.Context, req *pb.AddItemRequest) (*pb.AddItemResponse, error) {
+	msg := itemProblem(req.UserId, req.ProductId)
+	if msg == "" && (req.Quantity < 1 || req.Quantity > MaxQuantity) {
+		msg = "quantity must be between 1 and 999"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Add item failed: "+msg, map[string]interface{}{"user_id": req.UserId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	c, err := s.repo.AddItem(ctx, req.UserId, req.ProductId, req.Quantity, s.now())
+	if err != nil {
+		return nil, s.changeError(ctx, "add item", req.UserId, err)
+	}
+	return &pb.AddItemResponse{Cart: toProtoCart(c)}, nil
+}
+
+// UpdateItem sets the quantity of a product in the customer's cart
+func (s *Service) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+	msg := itemProblem(req.UserId, req.ProductId)
+	if msg == "" && (req.Quantity < 0 || req.Quantity > MaxQuantity) {
+		msg = "quantity must be between 0 and 999"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Update item failed: "+msg, map[string]interface{}{"user_id": req.UserId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	c, err := s.repo.SetItem(ctx, req.UserId, req.ProductId, req.Quantity, s.now())
+	if err != nil {
+		return nil, s.changeError(ctx, "update item", req.UserId, err)
+	}
+	return &pb.UpdateItemResponse{Cart: toProtoCart(c)}, nil
+}
+
+// RemoveItem takes a product out of the customer's cart
+func (s *Service) RemoveItem(ctx context.Context, req *pb.RemoveItemRequest) (*pb.RemoveItemResponse, error) {
+	if msg := itemProblem(req.UserId, req.ProductId); msg != "" {
+		s.log.Warn(ctx, "Remove item failed: "+msg, map[string]interface{}{"user_id": req.UserId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	c, err := s.repo.SetItem(ctx, req.UserId, req.ProductId, 0, s.now())
+	if err != nil {
+		return nil, s.changeError(ctx, "remove item", req.UserId, err)
+	}
+	return &pb.RemoveItemResponse{Cart: toProtoCart(c)}, nil
+}
+
+// ClearCart empties the customer's cart
+func (s *Service) ClearCart(ctx context.Context, req *pb.ClearCartRequest) (*pb.ClearCartResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Clear cart failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	c, err := s.repo.Clear(ctx, req.UserId, s.now())
+	if err != nil {
+		return nil, s.changeError(ctx, "clear cart", req.UserId, err)
+	}
+	return &pb.ClearCartResponse{Cart: toProtoCart(c)}, nil
+}
+
+// ListAbandonedCarts lists abandoned carts for remarketing campaigns
+func (s *Service) ListAbandonedCarts(ctx context.Context, req *pb.ListAbandonedCartsRequest) (*pb.ListAbandonedCartsResponse, error) {
+	var after, before *time.Time
+	if req.AbandonedAfter != nil {
+		t := req.AbandonedAfter.AsTime()
+		after = &t
+	}
+	if req.AbandonedBefore != nil {
+		t := req.AbandonedBefore.AsTime()
+		before = &t
+	}
+	if after != nil && before != nil && !after.Before(*before) {
+		s.log.Warn(ctx, "List abandoned carts failed: abandoned_after must be before abandoned_before", nil)
+		return nil, status.Error(codes.InvalidArgument, "abandoned_after must be before abandoned_before")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	carts, total, err := s.repo.ListAbandoned(ctx, after, before, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list abandoned carts")
+	}
+
+	protoCarts := make([]*pb.Cart, len(carts))
+	for i, c := range carts {
+		protoCarts[i] = toProtoCart(c)
+	}
+
+	return &pb.ListAbandonedCartsResponse{
+		Carts:    protoCarts,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// ExpireCarts deletes carts left unchanged for longer than the idle days, a
+// batch at a time
+func (s *Service) ExpireCarts(ctx context.Context, req *pb.ExpireCartsRequest) (*pb.ExpireCartsResponse, error) {
+	idleDays := req.IdleDays
+	if idleDays == 0 {
+		idleDays = defaultExpireAfterDays
+	}
+	if idleDays < 0 || idleDays > maxExpireAfterDays {
+		s.log.Warn(ctx, "Expire carts failed: idle days out of range", map[string]interface{}{"idle_days": req.IdleDays})
+		return nil, status.Errorf(codes.InvalidArgument, "idle_days must be between 1 and %d", maxExpireAfterDays)
+	}
+
+	idleBefore := s.now().AddDate(0, 0, -int(idleDays))
+	var expired int32
+	for {
+		deleted, err := s.repo.DeleteIdle(ctx, idleBefore, expireBatchSize)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to expire carts")
+		}
+		expired += int32(deleted)
+		if deleted < expireBatchSize {
+			break
+		}
+	}
+
+	s.log.Info(ctx, "Carts expired", map[string]interface{}{"carts": expired, "idle_days": idleDays})
+	return &pb.ExpireCartsResponse{Expired: expired}, nil
+}
+
+// EraseUserData deletes a customer's cart for the privacy service when their
+// account is deleted. Erasing a customer without a cart deletes nothing.
+func (s *Service) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Erase user data failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	deleted, err := s.repo.Delete(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to erase user data")
+	}
+
+	var records int32
+	if deleted {
+		records = 1
+	}
+	s.log.Info(ctx, "Cart erased", map[string]interface{}{"user_id": req.UserId, "carts": records})
+	return &pb.EraseUserDataResponse{Records: records}, nil
+}
+
+// PriceCart prices the customer's cart with the pricing service. An empty
+// cart is priced at 0 without asking it.
+func (s *Service) PriceCart(ctx context.Context, req *pb.PriceCartRequest) (*pb.PriceCartResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Price cart failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	c, err := s.repo.Get(ctx, req.UserId)
+	if errors.Is(err, ErrCartNotFound) {
+		return &pb.PriceCartResponse{Items: []*pb.PricedItem{}, Currency: req.Currency}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get cart")
+	}
+	if len(c.Items) == 0 {
+		return &pb.PriceCartResponse{Items: []*pb.PricedItem{}, Currency: req.Currency}, nil
+	}
+
+	priced, err := s.pricer.Price(ctx, c.Items, PriceTerms{
+		CustomerSegment: req.CustomerSegment,
+		Channel:         req.Channel,
+		Currency:        req.Currency,
+	})
+	if err != nil {
+		return nil, s.priceError(ctx, req.UserId, err)
+	}
+
+	resp := &pb.PriceCartResponse{
+		Items:    make([]*pb.PricedItem, len(priced.Items)),
+		Subtotal: priced.Subtotal,
+		Currency: priced.Currency,
+	}
+	for i, item := range priced.Items {
+		resp.Items[i] = &pb.PricedItem{
+			ProductId:     item.ProductID,
+			Quantity:      item.Quantity,
+			BaseUnitPrice: item.BaseUnitPrice,
+			UnitPrice:     item.UnitPrice,
+			LineTotal:     item.LineTotal,
+		}
+	}
+	return resp, nil
+}
+
+// priceError maps an error pricing a cart to a gRPC status. A line the
+// pricing service refuses to price, such as a product no longer sold or
+// without a price in the currency, fails the precondition that the cart can
+// be priced.
+func (s *Service) priceError(ctx context.Context, userID string, err error) error {
+	if errors.Is(err, ErrPricingDisabled) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	switch st := status.Convert(err); st.Code() {
+	case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+		s.log.Warn(ctx, "Price cart failed: "+st.Message(), map[string]interface{}{"user_id": userID})
+		return status.Error(codes.FailedPrecondition, st.Message())
+	}
+	s.log.Error(ctx, "Failed to price cart", map[string]interface{}{"error": err.Error(), "user_id": userID})
+	return status.Error(codes.Unavailable, "failed to price cart")
+}
+
+// changeError maps an error changing a cart to a gRPC status
+func (s *Service) changeError(ctx context.Context, op, userID string, err error) error {
+	switch {
+	case errors.Is(err, ErrCartFull):
+		return status.Error(codes.FailedPrecondition, "cart cannot hold more than 100 products")
+	case errors.Is(err, ErrQuantityLimit):
+		return status.Error(codes.FailedPrecondition, "cart cannot hold more than 999 of a product")
+	}
+	s.log.Error(ctx, "Failed to "+op, map[string]interface{}{"error": err.Error(), "user_id": userID})
+	return status.Error(codes.Internal, "failed to "+op)
+}
+
+// userProblem validates a customer ID and returns a message describing the
+// problem, or ""
+func userProblem(userID string) string {
+	switch {
+	case userID == "":
+		return "user_id is required"
+	case len(userID) > maxIDLength:
+		return "user_id is too long"
+	}
+	return ""
+}
+
+// itemProblem validates the customer and product of an item change and
+// returns a message describing the first problem, or ""
+func itemProblem(userID, productID string) string {
+	if msg := userProblem(userID); msg != "" {
+		return msg
+	}
+	switch {
+	case productID == "":
+		return "product_id is required"
+	case len(productID) > maxIDLength:
+		return "product_id is too long"
+	}
+	return ""
+}
+
+// toProtoCart converts a cart to protobuf
+func toProtoCart(c *Cart) *pb.Cart {
+	cart := &pb.Cart{
+		Id:        c.ID,
+		UserId:    c.UserID,
+		Status:    c.Status,
+		Items:     make([]*pb.CartItem, len(c.Items)),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+	}
+	for i, item := range c.Items {
+		cart.Items[i] = &pb.CartItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+			AddedAt:   timestamppb.New(item.AddedAt),
+		}
+	}
+	if c.AbandonedAt != nil {
+		cart.AbandonedAt = timestamppb.New(*c.AbandonedAt)
+	}
+	return cart
+}
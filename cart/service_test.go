@@ -0,0 +1,550 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps carts by user ID and applies each change to a copy,
+// so a change over the item or quantity limits leaves the cart as it was
+type memoryRepository struct {
+	mu    sync.Mutex
+	carts map[string]*Cart // by user ID
+	// abandoned records the carts a cart.abandoned event was added for
+	abandoned []string
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{carts: map[string]*Cart{}}
+}
+
+// copyCart returns a copy of c that later changes to c do not show through
+func copyCart(c *Cart) *Cart {
+	cp := *c
+	cp.Items = make([]*Item, len(c.Items))
+	for i, item := range c.Items {
+		it := *item
+		cp.Items[i] = &it
+	}
+	return &cp
+}
+
+func (m *memoryRepository) Get(ctx context.Context, userID string) (*Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.carts[userID]
+	if !ok {
+		return nil, ErrCartNotFound
+	}
+	return copyCart(c), nil
+}
+
+// change applies fn to a copy of the customer's cart and keeps the copy
+// unless fn fails
+func (m *memoryRepository) change(userID string, at time.Time, fn func(c *Cart) error) (*Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.carts[userID]
+	if !ok {
+		c = &Cart{ID: uuid.New().String(), UserID: userID, Items: []*Item{}, CreatedAt: at}
+	}
+	c = copyCart(c)
+	c.Status = StatusActive
+	c.AbandonedAt = nil
+	c.UpdatedAt = at
+	if err := fn(c); err != nil {
+		return nil, err
+	}
+	m.carts[userID] = c
+	return copyCart(c), nil
+}
+
+func (m *memoryRepository) AddItem(ctx context.Context, userID, productID string, quantity int32, at time.Time) (*Cart, error) {
+	return m.change(userID, at, func(c *Cart) error {
+		for _, item := range c.Items {
+			if item.ProductID == productID {
+				if item.Quantity+quantity > MaxQuantity {
+					return ErrQuantityLimit
+				}
+				item.Quantity += quantity
+				return nil
+			}
+		}
+		if len(c.Items) >= MaxItems {
+			return ErrCartFull
+		}
+		c.Items = append(c.Items, &Item{ProductID: productID, Quantity: quantity, AddedAt: at})
+		return nil
+	})
+}
+
+func (m *memoryRepository) SetItem(ctx context.Context, userID, productID string, quantity int32, at time.Time) (*Cart, error) {
+	return m.change(userID, at, func(c *Cart) error {
+		for i, item := range c.Items {
+			if item.ProductID == productID {
+				if quantity == 0 {
+					c.Items = append(c.Items[:i], c.Items[i+1:]...)
+				} else {
+					item.Quantity = quantity
+				}
+				return nil
+			}
+		}
+		if quantity == 0 {
+			return nil
+		}
+		if len(c.Items) >= MaxItems {
+			return ErrCartFull
+		}
+		c.Items = append(c.Items, &Item{ProductID: productID, Quantity: quantity, AddedAt: at})
+		return nil
+	})
+}
+
+func (m *memoryRepository) Clear(ctx context.Context, userID string, at time.Time) (*Cart, error) {
+	return m.change(userID, at, func(c *Cart) error {
+		c.Items = []*Item{}
+		return nil
+	})
+}
+
+func (m *memoryRepository) MarkAbandoned(ctx context.Context, idleBefore, at time.Time, limit int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var marked int
+	for _, c := range m.carts {
+		if marked == limit {
+			break
+		}
+		if c.Status != StatusActive || len(c.Items) == 0 || !c.UpdatedAt.Before(idleBefore) {
+			continue
+		}
+		c.Status = StatusAbandoned
+		c.AbandonedAt = &at
+		m.abandoned = append(m.abandoned, c.ID)
+		marked++
+	}
+	return marked, nil
+}
+
+func (m *memoryRepository) ListAbandoned(ctx context.Context, after, before *time.Time, page, pageSize int32) ([]*Cart, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []*Cart
+	for _, c := range m.carts {
+		if c.Status != StatusAbandoned {
+			continue
+		}
+		if (after != nil && !c.AbandonedAt.After(*after)) || (before != nil && !c.AbandonedAt.Before(*before)) {
+			continue
+		}
+		matched = append(matched, copyCart(c))
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].AbandonedAt.After(*matched[j].AbandonedAt) })
+
+	start := min(int((page-1)*pageSize), len(matched))
+	end := min(start+int(pageSize), len(matched))
+	return matched[start:end], int32(len(matched)), nil
+}
+
+func (m *memoryRepository) DeleteIdle(ctx context.Context, idleBefore time.Time, limit int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int
+	for userID, c := range m.carts {
+		if deleted < limit && c.UpdatedAt.Before(idleBefore) {
+			delete(m.carts, userID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *memoryRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.carts[userID]
+	delete(m.carts, userID)
+	return ok, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakePricer prices every unit at 2.5 less 10% for the vip segment
+type fakePricer struct {
+	terms PriceTerms
+	err   error
+}
+
+func (p *fakePricer) Price(ctx context.Context, items []*Item, terms PriceTerms) (*PricedCart, error) {
+	p.terms = terms
+	if p.err != nil {
+		return nil, p.err
+	}
+	priced := &PricedCart{Currency: "USD"}
+	if terms.Currency != "" {
+		priced.Currency = terms.Currency
+	}
+	for _, item := range items {
+		unit := 2.5
+		if terms.CustomerSegment == "vip" {
+			unit = 2.25
+		}
+		line := &PricedItem{ProductID: item.ProductID, Quantity: item.Quantity, BaseUnitPrice: 2.5, UnitPrice: unit, LineTotal: unit * float64(item.Quantity)}
+		priced.Items = append(priced.Items, line)
+		priced.Subtotal += line.LineTotal
+	}
+	return priced, nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	pricer  *fakePricer
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo:   newMemoryRepository(),
+		pricer: &fakePricer{},
+		now:    time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(f.repo, f.pricer, logger.New("cart-test"))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *serviceFixture) add(t *testing.T, userID, productID string, quantity int32) *pb.Cart {
+	t.Helper()
+	resp, err := f.service.AddItem(context.Background(), &pb.AddItemRequest{UserId: userID, ProductId: productID, Quantity: quantity})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Cart
+}
+
+// detect runs the abandoned cart detector at the fixture's time
+func (f *serviceFixture) detect(t *testing.T) {
+	t.Helper()
+	detector := NewAbandonedCartDetector(f.repo, DefaultAbandonAfter, logger.New("cart-test"))
+	detector.now = func() time.Time { return f.now }
+	if err := detector.DetectAbandoned(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestGetCart_NoCart(t *testing.T) {
+	f := setupService()
+
+	resp, err := f.service.GetCart(context.Background(), &pb.GetCartRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Cart.UserId != "user-1" || resp.Cart.Status != StatusActive || len(resp.Cart.Items) != 0 {
+		t.Errorf("Expected an empty cart, got %v", resp.Cart)
+	}
+}
+
+func TestCartItems(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+
+	f.add(t, "user-1", "product-1", 2)
+	c := f.add(t, "user-1", "product-1", 3)
+	if len(c.Items) != 1 || c.Items[0].Quantity != 5 {
+		t.Fatalf("Expected 5 of product-1, got %v", c.Items)
+	}
+
+	f.add(t, "user-1", "product-2", 1)
+	updated, err := f.service.UpdateItem(ctx, &pb.UpdateItemRequest{UserId: "user-1", ProductId: "product-1", Quantity: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(updated.Cart.Items) != 2 || updated.Cart.Items[0].Quantity != 1 {
+		t.Errorf("Expected 1 of product-1, got %v", updated.Cart.Items)
+	}
+
+	removed, err := f.service.RemoveItem(ctx, &pb.RemoveItemRequest{UserId: "user-1", ProductId: "product-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(removed.Cart.Items) != 1 || removed.Cart.Items[0].ProductId != "product-2" {
+		t.Errorf("Expected only product-2, got %v", removed.Cart.Items)
+	}
+
+	cleared, err := f.service.ClearCart(ctx, &pb.ClearCartRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(cleared.Cart.Items) != 0 {
+		t.Errorf("Expected an empty cart, got %v", cleared.Cart.Items)
+	}
+}
+
+func TestCartItems_Rejected(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+	f.add(t, "user-1", "product-1", 990)
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"missing user", func() error {
+			_, err := f.service.AddItem(ctx, &pb.AddItemRequest{ProductId: "product-1", Quantity: 1})
+			return err
+		}, codes.InvalidArgument},
+		{"missing product", func() error {
+			_, err := f.service.AddItem(ctx, &pb.AddItemRequest{UserId: "user-1", Quantity: 1})
+			return err
+		}, codes.InvalidArgument},
+		{"zero quantity added", func() error {
+			_, err := f.service.AddItem(ctx, &pb.AddItemRequest{UserId: "user-1", ProductId: "product-1"})
+			return err
+		}, codes.InvalidArgument},
+		{"negative quantity", func() error {
+			_, err := f.service.UpdateItem(ctx, &pb.UpdateItemRequest{UserId: "user-1", ProductId: "product-1", Quantity: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"over the quantity limit", func() error {
+			_, err := f.service.AddItem(ctx, &pb.AddItemRequest{UserId: "user-1", ProductId: "product-1", Quantity: 10})
+			return err
+		}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, code)
+			}
+		})
+	}
+}
+
+func TestAbandonedCartDetector(t *testing.T) {
+	f := setupService()
+	f.add(t, "user-1", "product-1", 1)
+	f.now = f.now.Add(12 * time.Hour)
+	f.add(t, "user-2", "product-2", 1)
+	// An empty cart is never abandoned
+	f.service.ClearCart(context.Background(), &pb.ClearCartRequest{UserId: "user-3"})
+
+	f.now = f.now.Add(13 * time.Hour)
+	f.detect(t)
+
+	if len(f.repo.abandoned) != 1 {
+		t.Fatalf("Expected 1 cart abandoned, got %d", len(f.repo.abandoned))
+	}
+	resp, err := f.service.GetCart(context.Background(), &pb.GetCartRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Cart.Status != StatusAbandoned || resp.Cart.AbandonedAt == nil || f.repo.abandoned[0] != resp.Cart.Id {
+		t.Errorf("Expected user-1's cart abandoned, got %v", resp.Cart)
+	}
+
+	// Detecting again abandons nothing new
+	f.detect(t)
+	if len(f.repo.abandoned) != 1 {
+		t.Errorf("Expected an abandoned cart to be abandoned once, got %d events", len(f.repo.abandoned))
+	}
+
+	// Changing the cart makes it active again
+	c := f.add(t, "user-1", "product-3", 1)
+	if c.Status != StatusActive || c.AbandonedAt != nil {
+		t.Errorf("Expected the cart active again, got %v", c)
+	}
+}
+
+func TestExpireCarts(t *testing.T) {
+	f := setupService()
+	f.add(t, "user-1", "product-1", 1)
+	f.now = f.now.AddDate(0, 0, 20)
+	f.add(t, "user-2", "product-2", 1)
+	f.now = f.now.AddDate(0, 0, 11)
+
+	resp, err := f.service.ExpireCarts(context.Background(), &pb.ExpireCartsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Expired != 1 {
+		t.Errorf("Expected 1 cart expired, got %d", resp.Expired)
+	}
+	if _, err := f.repo.Get(context.Background(), "user-1"); err != ErrCartNotFound {
+		t.Errorf("Expected user-1's cart deleted, got %v", err)
+	}
+	if _, err := f.repo.Get(context.Background(), "user-2"); err != nil {
+		t.Errorf("Expected user-2's cart kept, got %v", err)
+	}
+
+	_, err = f.service.ExpireCarts(context.Background(), &pb.ExpireCartsRequest{IdleDays: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestEraseUserData(t *testing.T) {
+	f := setupService()
+	f.add(t, "user-1", "product-1", 1)
+	f.add(t, "user-2", "product-2", 1)
+
+	resp, err := f.service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Records != 1 {
+		t.Errorf("Expected 1 cart erased, got %d", resp.Records)
+	}
+	if _, err := f.repo.Get(context.Background(), "user-1"); err != ErrCartNotFound {
+		t.Errorf("Expected user-1's cart deleted, got %v", err)
+	}
+	if _, err := f.repo.Get(context.Background(), "user-2"); err != nil {
+		t.Errorf("Expected user-2's cart kept, got %v", err)
+	}
+
+	// Erasing again finds no cart
+	resp, err = f.service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{UserId: "user-1"})
+	if err != nil || resp.Records != 0 {
+		t.Errorf("Expected nothing erased again, got %v, %v", resp, err)
+	}
+}
+
+func TestListAbandonedCarts(t *testing.T) {
+	f := setupService()
+	start := f.now
+	f.add(t, "user-1", "product-1", 1)
+	f.add(t, "user-2", "product-1", 1)
+	f.now = f.now.Add(25 * time.Hour)
+	f.detect(t)
+	f.add(t, "user-3", "product-2", 4)
+	f.now = f.now.Add(25 * time.Hour)
+	f.detect(t)
+
+	ctx := context.Background()
+	resp, err := f.service.ListAbandonedCarts(ctx, &pb.ListAbandonedCartsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 3 || resp.PageSize != 10 || resp.Carts[0].UserId != "user-3" {
+		t.Errorf("Expected 3 carts, most recently abandoned first, got %v", resp)
+	}
+
+	recent, err := f.service.ListAbandonedCarts(ctx, &pb.ListAbandonedCartsRequest{
+		AbandonedAfter: timestamppb.New(start.Add(30 * time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recent.Total != 1 || recent.Carts[0].Items[0].Quantity != 4 {
+		t.Errorf("Expected user-3's cart, got %v", recent.Carts)
+	}
+
+	_, err = f.service.ListAbandonedCarts(ctx, &pb.ListAbandonedCartsRequest{
+		AbandonedAfter:  timestamppb.New(f.now),
+		AbandonedBefore: timestamppb.New(start),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestPriceCart(t *testing.T) {
+	f := setupService()
+	f.add(t, "user-1", "prod-1", 2)
+	f.add(t, "user-1", "prod-2", 1)
+
+	resp, err := f.service.PriceCart(context.Background(), &pb.PriceCartRequest{UserId: "user-1", CustomerSegment: "vip", Channel: "app", Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Items) != 2 || resp.Items[0].UnitPrice != 2.25 || resp.Items[0].LineTotal != 4.5 || resp.Subtotal != 6.75 || resp.Currency != "EUR" {
+		t.Errorf("Unexpected priced cart %v", resp)
+	}
+	if f.pricer.terms != (PriceTerms{CustomerSegment: "vip", Channel: "app", Currency: "EUR"}) {
+		t.Errorf("Expected the price terms passed to the pricer, got %+v", f.pricer.terms)
+	}
+}
+
+func TestPriceCart_EmptyCart(t *testing.T) {
+	f := setupService()
+	f.pricer.err = errors.New("pricer should not be called")
+
+	resp, err := f.service.PriceCart(context.Background(), &pb.PriceCartRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Items) != 0 || resp.Subtotal != 0 {
+		t.Errorf("Expected an empty priced cart, got %v", resp)
+	}
+}
+
+func TestPriceCart_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"pricing disabled", ErrPricingDisabled, codes.FailedPrecondition},
+		{"no price in currency", status.Error(codes.FailedPrecondition, "product prod-1 has no price in JPY"), codes.FailedPrecondition},
+		{"product gone", status.Error(codes.NotFound, "product prod-1 not found"), codes.FailedPrecondition},
+		{"pricing down", status.Error(codes.Unavailable, "connection refused"), codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService()
+			f.add(t, "user-1", "prod-1", 1)
+			f.pricer.err = tt.err
+
+			_, err := f.service.PriceCart(context.Background(), &pb.PriceCartRequest{UserId: "user-1"})
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestMethodRoles(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", 15*time.Minute, time.Hour)
+	interceptor := auth.NewInterceptor(tokens, auth.InterceptorConfig{ExemptMethods: []string{"/"}, RequiredRoles: MethodRoles()})
+	withToken := func(role string) context.Context {
+		token, _ := tokens.GenerateAccessToken("caller-1", "", role)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected codes.Code
+	}{
+		{"no token", context.Background(), codes.Unauthenticated},
+		{"customer", withToken(auth.RoleUser), codes.PermissionDenied},
+		{"admin", withToken(auth.RoleAdmin), codes.PermissionDenied},
+		{"service", withToken(auth.RoleService), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor.Authenticate(tt.ctx, pb.CartService_EraseUserData_FullMethodName)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
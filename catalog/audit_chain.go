@@ -0,0 +1,186 @@
+package catalog
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// genesisHash is the previous hash of the first history entry
+var genesisHash = strings.Repeat("0", 64)
+
+// verifyBatchSize is the number of entries read per query during verification
+const verifyBatchSize = 1000
+
+// hashPriceChange returns the chain hash of an entry. Migration 009 computes the
+// same hash in SQL for entries recorded before chaining, so the input format
+// must not change.
+func hashPriceChange(c *PriceChange) string {
+	old := ""
+	if c.OldPrice != nil {
+		old = strconv.FormatFloat(*c.OldPrice, 'f', 2, 64)
+	}
+
+	input := strings.Join([]string{
+		strconv.FormatInt(c.Seq, 10),
+		c.PrevHash,
+		c.ID,
+		c.ProductID,
+		old,
+		strconv.FormatFloat(c.NewPrice, 'f', 2, 64),
+		c.ChangedBy,
+		strconv.FormatInt(c.ChangedAt.UnixMicro(), 10),
+	}, "|")
+
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// signAnchor returns the HMAC of an anchor, or "" without a key
+func signAnchor(key []byte, seq int64, hash string) string {
+	if len(key) == 0 {
+		return ""
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strconv.FormatInt(seq, 10) + "|" + hash))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ChainReport is the result of verifying the price history chain
+type ChainReport struct {
+	Valid          bool
+	EntriesChecked int64
+	AnchorsChecked int32
+	HeadSeq        int64
+	HeadHash       string
+	// FirstInvalidSeq and Problem describe the first inconsistency found
+	FirstInvalidSeq int64
+	Problem         string
+}
+
+// fail marks the report invalid at seq
+func (r *ChainReport) fail(seq int64, problem string) *ChainReport {
+	r.Valid = false
+	r.FirstInvalidSeq = seq
+	r.Problem = problem
+	return r
+}
+
+// verifyPriceHistoryChain recomputes every entry hash, checks that the chain has
+// no gaps and that it matches every anchor. With a key, anchor signatures are
+// checked too, so rewriting history requires the key.
+func verifyPriceHistoryChain(ctx context.Context, repo Repository, key []byte) (*ChainReport, error) {
+	anchors, err := repo.ListAuditAnchors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	anchorsAt := make(map[int64][]*AuditAnchor, len(anchors))
+	for _, a := range anchors {
+		if len(key) > 0 && !hmac.Equal([]byte(a.Signature), []byte(signAnchor(key, a.Seq, a.Hash))) {
+			return (&ChainReport{}).fail(a.Seq, "anchor signature is invalid"), nil
+		}
+		anchorsAt[a.Seq] = append(anchorsAt[a.Seq], a)
+	}
+
+	report := &ChainReport{Valid: true, HeadHash: genesisHash}
+	for {
+		batch, err := repo.ListPriceHistoryChain(ctx, report.HeadSeq, verifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, c := range batch {
+			if c.Seq != report.HeadSeq+1 {
+				return report.fail(report.HeadSeq+1, "entry is missing"), nil
+			}
+			if c.PrevHash != report.HeadHash {
+				return report.fail(c.Seq, "previous hash does not match"), nil
+			}
+			if hashPriceChange(c) != c.Hash {
+				return report.fail(c.Seq, "entry hash does not match its contents"), nil
+			}
+			for _, a := range anchorsAt[c.Seq] {
+				if a.Hash != c.Hash {
+					return report.fail(c.Seq, "entry does not match anchor"), nil
+				}
+				report.AnchorsChecked++
+			}
+
+			report.HeadSeq = c.Seq
+			report.HeadHash = c.Hash
+			report.EntriesChecked++
+		}
+	}
+
+	// An anchor beyond the head means entries were removed from the end
+	if n := len(anchors); n > 0 && anchors[n-1].Seq > report.HeadSeq {
+		return report.fail(report.HeadSeq+1, fmt.Sprintf("history ends before anchor at %d", anchors[n-1].Seq)), nil
+	}
+
+	return report, nil
+}
+
+// AuditAnchorer periodically records the head of the price history chain
+type AuditAnchorer struct {
+	repo Repository
+	key  []byte
+	log  *logger.Logger
+	last int64
+}
+
+// NewAuditAnchorer creates an anchorer. Anchors are signed when key is non-empty.
+func NewAuditAnchorer(repo Repository, key []byte, log *logger.Logger) *AuditAnchorer {
+	return &AuditAnchorer{repo: repo, key: key, log: log}
+}
+
+// Anchor records the current chain head. It returns nil when the head has
+// not moved since the last anchor.
+func (a *AuditAnchorer) Anchor(ctx context.Context) (*AuditAnchor, error) {
+	head, err := a.repo.GetPriceHistoryHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil || head.Seq == a.last {
+		return nil, nil
+	}
+
+	anchor, err := a.repo.SaveAuditAnchor(ctx, &AuditAnchor{
+		Seq:       head.Seq,
+		Hash:      head.Hash,
+		Signature: signAnchor(a.key, head.Seq, head.Hash),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.last = anchor.Seq
+	return anchor, nil
+}
+
+// Run anchors the chain every interval until ctx is cancelled
+func (a *AuditAnchorer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.Anchor(ctx); err != nil {
+				a.log.Error(ctx, "Failed to anchor price history", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
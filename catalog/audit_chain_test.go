@@ -0,0 +1,191 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// buildChain returns n correctly chained price history entries
+func buildChain(n int) []*PriceChange {
+	chain := make([]*PriceChange, n)
+	prev := genesisHash
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := range chain {
+		c := &PriceChange{
+			ID:        fmt.Sprintf("h%d", i+1),
+			ProductID: "p1",
+			NewPrice:  100 - float64(i),
+			ChangedBy: "admin-1",
+			ChangedAt: start.Add(time.Duration(i) * time.Minute),
+			Seq:       int64(i + 1),
+			PrevHash:  prev,
+		}
+		if i > 0 {
+			old := chain[i-1].NewPrice
+			c.OldPrice = &old
+		}
+		c.Hash = hashPriceChange(c)
+		prev = c.Hash
+		chain[i] = c
+	}
+	return chain
+}
+
+// chainRepo serves chain and anchors through the mock repository
+func chainRepo(chain []*PriceChange, anchors []*AuditAnchor) *MockRepository {
+	return &MockRepository{
+		ListPriceHistoryChainFunc: func(ctx context.Context, afterSeq int64, limit int) ([]*PriceChange, error) {
+			batch := []*PriceChange{}
+			for _, c := range chain {
+				if c.Seq > afterSeq && len(batch) < limit {
+					batch = append(batch, c)
+				}
+			}
+			return batch, nil
+		},
+		ListAuditAnchorsFunc: func(ctx context.Context) ([]*AuditAnchor, error) {
+			return anchors, nil
+		},
+	}
+}
+
+func TestVerifyAuditChain_Valid(t *testing.T) {
+	key := []byte("anchor-key")
+	chain := buildChain(5)
+	anchors := []*AuditAnchor{{Seq: 3, Hash: chain[2].Hash, Signature: signAnchor(key, 3, chain[2].Hash)}}
+
+	service := setupService(chainRepo(chain, anchors)).WithAuditKey(key)
+	resp, err := service.VerifyAuditChain(context.Background(), &pb.VerifyAuditChainRequest{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Valid || resp.EntriesChecked != 5 || resp.AnchorsChecked != 1 || resp.HeadSeq != 5 || resp.HeadHash != chain[4].Hash {
+		t.Errorf("Unexpected report %v", resp)
+	}
+}
+
+func TestVerifyAuditChain_DetectsTampering(t *testing.T) {
+	key := []byte("anchor-key")
+
+	tests := []struct {
+		name    string
+		tamper  func(chain []*PriceChange, anchors []*AuditAnchor) ([]*PriceChange, []*AuditAnchor)
+		wantSeq int64
+	}{
+		{
+			name: "edited price",
+			tamper: func(chain []*PriceChange, anchors []*AuditAnchor) ([]*PriceChange, []*AuditAnchor) {
+				chain[2].NewPrice = 1
+				return chain, anchors
+			},
+			wantSeq: 3,
+		},
+		{
+			name: "deleted entry",
+			tamper: func(chain []*PriceChange, anchors []*AuditAnchor) ([]*PriceChange, []*AuditAnchor) {
+				return append(chain[:1], chain[2:]...), anchors
+			},
+			wantSeq: 2,
+		},
+		{
+			name: "rewritten tail",
+			tamper: func(chain []*PriceChange, anchors []*AuditAnchor) ([]*PriceChange, []*AuditAnchor) {
+				// Rehashing keeps the chain consistent but no longer matches the anchor
+				chain[3].NewPrice = 1
+				chain[3].Hash = hashPriceChange(chain[3])
+				chain[4].PrevHash = chain[3].Hash
+				chain[4].Hash = hashPriceChange(chain[4])
+				return chain, anchors
+			},
+			wantSeq: 4,
+		},
+		{
+			name: "truncated after anchor",
+			tamper: func(chain []*PriceChange, anchors []*AuditAnchor) ([]*PriceChange, []*AuditAnchor) {
+				return chain[:2], anchors
+			},
+			wantSeq: 3,
+		},
+		{
+			name: "forged anchor",
+			tamper: func(chain []*PriceChange, anchors []*AuditAnchor) ([]*PriceChange, []*AuditAnchor) {
+				anchors[0].Signature = signAnchor([]byte("other-key"), anchors[0].Seq, anchors[0].Hash)
+				return chain, anchors
+			},
+			wantSeq: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := buildChain(5)
+			anchors := []*AuditAnchor{{Seq: 4, Hash: chain[3].Hash, Signature: signAnchor(key, 4, chain[3].Hash)}}
+			chain, anchors = tt.tamper(chain, anchors)
+
+			report, err := verifyPriceHistoryChain(context.Background(), chainRepo(chain, anchors), key)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if report.Valid {
+				t.Fatal("Expected tampering to be detected")
+			}
+			if report.FirstInvalidSeq != tt.wantSeq {
+				t.Errorf("Expected first invalid seq %d, got %d (%s)", tt.wantSeq, report.FirstInvalidSeq, report.Problem)
+			}
+		})
+	}
+}
+
+func TestVerifyAuditChain_Batches(t *testing.T) {
+	chain := buildChain(verifyBatchSize + 5)
+
+	report, err := verifyPriceHistoryChain(context.Background(), chainRepo(chain, nil), nil)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !report.Valid || report.EntriesChecked != int64(len(chain)) {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestAuditAnchorer_SkipsUnchangedHead(t *testing.T) {
+	chain := buildChain(3)
+	head := chain[2]
+	var saved []*AuditAnchor
+
+	mockRepo := &MockRepository{
+		GetPriceHistoryHeadFunc: func(ctx context.Context) (*PriceChange, error) {
+			return head, nil
+		},
+		SaveAuditAnchorFunc: func(ctx context.Context, anchor *AuditAnchor) (*AuditAnchor, error) {
+			saved = append(saved, anchor)
+			return anchor, nil
+		},
+	}
+
+	key := []byte("anchor-key")
+	anchorer := NewAuditAnchorer(mockRepo, key, logger.New("catalog-test"))
+	ctx := context.Background()
+
+	anchor, err := anchorer.Anchor(ctx)
+	if err != nil || anchor == nil {
+		t.Fatalf("Expected anchor, got %v, %v", anchor, err)
+	}
+	if anchor.Seq != 3 || anchor.Hash != head.Hash || anchor.Signature != signAnchor(key, 3, head.Hash) {
+		t.Errorf("Unexpected anchor %+v", anchor)
+	}
+
+	if anchor, _ := anchorer.Anchor(ctx); anchor != nil {
+		t.Errorf("Expected no anchor for unchanged head, got %+v", anchor)
+	}
+	if len(saved) != 1 {
+		t.Errorf("Expected 1 saved anchor, got %d", len(saved))
+	}
+}
@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Kinds of autocomplete suggestions
+const (
+	suggestionProduct  = "PRODUCT"
+	suggestionCategory = "CATEGORY"
+)
+
+// likeEscaper escapes the LIKE wildcards of user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ProductSuggestion is a product whose name starts with an autocomplete prefix
+type ProductSuggestion struct {
+	ID   string
+	Name string
+}
+
+// SuggestProducts retrieves up to limit products and up to limit categories
+// whose names start with prefix, ignoring case, in one round trip. Both are
+// ordered by name.
+func (r *postgresRepository) SuggestProducts(ctx context.Context, prefix string, limit int, filter ProductFilter) ([]*ProductSuggestion, []string, error) {
+	conditions, args := filter.conditions(
+		[]string{"LOWER(name) LIKE $1"},
+		[]interface{}{likeEscaper.Replace(strings.ToLower(prefix)) + "%", limit},
+	)
+	categoryConditions := append([]string{"LOWER(category) LIKE $1"}, conditions[1:]...)
+
+	query := `
+		(SELECT '` + suggestionProduct + `', id::text, name
+		FROM products
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY LOWER(name), id
+		LIMIT $2)
+		UNION ALL
+		(SELECT DISTINCT '` + suggestionCategory + `', '', category
+		FROM products
+		WHERE ` + strings.Join(categoryConditions, " AND ") + `
+		ORDER BY category
+		LIMIT $2)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.Error(ctx, "Failed to suggest products", map[string]interface{}{"error": err.Error(), "prefix": prefix})
+		return nil, nil, fmt.Errorf("failed to suggest products: %w", err)
+	}
+	defer rows.Close()
+
+	products := []*ProductSuggestion{}
+	categories := []string{}
+	for rows.Next() {
+		var kind, id, name string
+		if err := rows.Scan(&kind, &id, &name); err != nil {
+			r.log.Error(ctx, "Failed to scan suggestion", map[string]interface{}{"error": err.Error()})
+			return nil, nil, fmt.Errorf("failed to scan suggestion: %w", err)
+		}
+		if kind == suggestionCategory {
+			categories = append(categories, name)
+		} else {
+			products = append(products, &ProductSuggestion{ID: id, Name: name})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating suggestions", map[string]interface{}{"error": err.Error()})
+		return nil, nil, fmt.Errorf("error iterating suggestions: %w", err)
+	}
+
+	return products, categories, nil
+}
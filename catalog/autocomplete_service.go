@@ -0,0 +1,57 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPrefixLength caps autocomplete prefixes; longer input is a search, not
+// something to complete
+const maxPrefixLength = 100
+
+// SuggestProducts returns the product names and categories starting with a
+// prefix, for search-as-you-type. Names are not localized.
+func (s *Service) SuggestProducts(ctx context.Context, req *pb.SuggestProductsRequest) (*pb.SuggestProductsResponse, error) {
+	prefix := strings.TrimLeft(req.Prefix, " \t")
+	if prefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "prefix is required")
+	}
+	if utf8.RuneCountInString(prefix) > maxPrefixLength {
+		return nil, status.Errorf(codes.InvalidArgument, "prefix cannot exceed %d characters", maxPrefixLength)
+	}
+
+	limit := int(req.Limit)
+	if limit < 1 {
+		limit = 5
+	}
+	if limit > 10 {
+		limit = 10
+	}
+
+	var filter ProductFilter
+	var msg string
+	filter.Channel, filter.CustomerGroup, msg = audienceFromRequest(req.Channel, req.CustomerGroup)
+	if msg != "" {
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	products, categories, err := s.repo.SuggestProducts(ctx, prefix, limit, filter)
+	if err != nil {
+		s.log.Error(ctx, "Failed to suggest products", map[string]interface{}{"error": err.Error(), "prefix": prefix})
+		return nil, status.Error(codes.Internal, "failed to suggest products")
+	}
+
+	resp := &pb.SuggestProductsResponse{
+		Products:   make([]*pb.ProductSuggestion, len(products)),
+		Categories: categories,
+	}
+	for i, p := range products {
+		resp.Products[i] = &pb.ProductSuggestion{Id: p.ID, Name: p.Name}
+	}
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSuggestProducts_Success(t *testing.T) {
+	var gotPrefix string
+	var gotLimit int
+	var gotFilter ProductFilter
+	mockRepo := &MockRepository{
+		SuggestProductsFunc: func(ctx context.Context, prefix string, limit int, filter ProductFilter) ([]*ProductSuggestion, []string, error) {
+			gotPrefix, gotLimit, gotFilter = prefix, limit, filter
+			return []*ProductSuggestion{{ID: "prod-1", Name: "Desk Lamp"}}, []string{"Desks"}, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.SuggestProducts(context.Background(), &pb.SuggestProductsRequest{Prefix: "  des", Channel: ChannelMobile})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotPrefix != "des" || gotLimit != 5 || gotFilter != (ProductFilter{Channel: ChannelMobile}) {
+		t.Errorf("Unexpected repository call %q, %d, %+v", gotPrefix, gotLimit, gotFilter)
+	}
+	if len(resp.Products) != 1 || resp.Products[0].Name != "Desk Lamp" || len(resp.Categories) != 1 || resp.Categories[0] != "Desks" {
+		t.Errorf("Unexpected suggestions %v", resp)
+	}
+
+	// Trailing spaces are kept so "desk " does not complete to "desks"
+	if _, err := service.SuggestProducts(context.Background(), &pb.SuggestProductsRequest{Prefix: "desk ", Limit: 50}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotPrefix != "desk " || gotLimit != 10 {
+		t.Errorf("Expected prefix %q and limit 10, got %q and %d", "desk ", gotPrefix, gotLimit)
+	}
+}
+
+func TestSuggestProducts_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		SuggestProductsFunc: func(ctx context.Context, prefix string, limit int, filter ProductFilter) ([]*ProductSuggestion, []string, error) {
+			return nil, nil, errors.New("db down")
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		name     string
+		req      *pb.SuggestProductsRequest
+		expected codes.Code
+	}{
+		{"empty prefix", &pb.SuggestProductsRequest{Prefix: "  "}, codes.InvalidArgument},
+		{"long prefix", &pb.SuggestProductsRequest{Prefix: strings.Repeat("a", maxPrefixLength+1)}, codes.InvalidArgument},
+		{"unknown channel", &pb.SuggestProductsRequest{Prefix: "la", Channel: "KIOSK"}, codes.InvalidArgument},
+		{"repository error", &pb.SuggestProductsRequest{Prefix: "la"}, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SuggestProducts(context.Background(), tt.req)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	notificationpb "github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EventBackInStock is the notification service event for a restocked product
+const EventBackInStock = "product.back_in_stock"
+
+const (
+	// backInStockBatchSize is how many subscriptions are claimed at a time
+	backInStockBatchSize = 100
+	// backInStockLease is how long a claimed subscription is held before a
+	// failed notification is tried again
+	backInStockLease = 5 * time.Minute
+)
+
+// BackInStockSender delivers back-in-stock notifications
+type BackInStockSender interface {
+	// SendBackInStock tells the subscriber that the product is in stock again
+	SendBackInStock(ctx context.Context, sub *BackInStockSubscription) error
+}
+
+type logBackInStockSender struct {
+	log *logger.Logger
+}
+
+// NewLogBackInStockSender creates a BackInStockSender that only logs the
+// notifications it would send, for deployments without a notification service
+func NewLogBackInStockSender(log *logger.Logger) BackInStockSender {
+	return &logBackInStockSender{log: log}
+}
+
+func (s *logBackInStockSender) SendBackInStock(ctx context.Context, sub *BackInStockSubscription) error {
+	s.log.Info(ctx, "Back-in-stock notification", map[string]interface{}{
+		"subscription_id": sub.ID,
+		"product_id":      sub.ProductID,
+		"user_id":         sub.UserID,
+	})
+	return nil
+}
+
+type grpcBackInStockSender struct {
+	notifications notificationpb.NotificationServiceClient
+	now           func() time.Time
+}
+
+// NewGRPCBackInStockSender creates a BackInStockSender that publishes
+// product.back_in_stock events to the notification service
+func NewGRPCBackInStockSender(notifications notificationpb.NotificationServiceClient) BackInStockSender {
+	return &grpcBackInStockSender{notifications: notifications, now: time.Now}
+}
+
+func (s *grpcBackInStockSender) SendBackInStock(ctx context.Context, sub *BackInStockSubscription) error {
+	// A subscription is notified once, so its ID identifies the event and a
+	// notification resent after a lost acknowledgement is deduplicated
+	_, err := s.notifications.PublishEvent(ctx, &notificationpb.PublishEventRequest{
+		EventId: EventBackInStock + ":" + sub.ID,
+		Type:    EventBackInStock,
+		UserId:  sub.UserID,
+		Data: map[string]string{
+			"product_id":   sub.ProductID,
+			"product_name": sub.ProductName,
+		},
+		OccurredAt: timestamppb.New(s.now()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", EventBackInStock, err)
+	}
+	return nil
+}
+
+// BackInStockNotifier notifies back-in-stock subscribers when their product
+// is restocked and then deletes their subscriptions. Restocks are picked up
+// from product changes as they happen and by a periodic sweep, which also
+// retries failed notifications.
+type BackInStockNotifier struct {
+	repo    Repository
+	sender  BackInStockSender
+	changes *ProductChangeHub
+	log     *logger.Logger
+}
+
+// NewBackInStockNotifier creates a back-in-stock notifier. Without a hub,
+// restocks are only found by the sweep.
+func NewBackInStockNotifier(repo Repository, sender BackInStockSender, changes *ProductChangeHub, log *logger.Logger) *BackInStockNotifier {
+	return &BackInStockNotifier{repo: repo, sender: sender, changes: changes, log: log}
+}
+
+// Run notifies the subscribers of restocked products as product changes
+// arrive and every sweepInterval until ctx is cancelled
+func (n *BackInStockNotifier) Run(ctx context.Context, sweepInterval time.Duration) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	var sub *ProductSubscription
+	var changes <-chan ProductChange
+	if n.changes != nil {
+		sub = n.changes.Subscribe()
+		changes = sub.Changes()
+	}
+	defer func() {
+		if sub != nil {
+			n.changes.Unsubscribe(sub)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.notify(ctx, "")
+		case change, ok := <-changes:
+			if !ok {
+				err := sub.Err()
+				if err == nil || errors.Is(err, ErrHubClosed) {
+					sub, changes = nil, nil
+					continue
+				}
+				// Restocks may have been missed; the sweep finds them
+				n.log.Warn(ctx, "Back-in-stock notifier resubscribing to product changes", map[string]interface{}{"error": err.Error()})
+				sub = n.changes.Subscribe()
+				changes = sub.Changes()
+				n.notify(ctx, "")
+				continue
+			}
+			if isRestocked(change) {
+				n.notify(ctx, change.ProductID)
+			}
+		}
+	}
+}
+
+// isRestocked reports whether a change leaves an active physical product in stock
+func isRestocked(change ProductChange) bool {
+	p := change.Product
+	return change.Type == ProductChangeUpdated && p != nil &&
+		p.TracksStock() && p.Stock > 0 && p.Status == ProductStatusActive
+}
+
+// notify sends the notifications of the subscriptions to productID, or to
+// every restocked product when productID is "", and deletes the
+// subscriptions notified. Subscriptions whose notification failed stay
+// claimed until their lease expires and are retried by a later sweep.
+func (n *BackInStockNotifier) notify(ctx context.Context, productID string) {
+	for {
+		subs, err := n.repo.ClaimBackInStock(ctx, productID, backInStockBatchSize, backInStockLease)
+		if err != nil {
+			n.log.Error(ctx, "Failed to claim back-in-stock subscriptions", map[string]interface{}{"error": err.Error()})
+			return
+		}
+
+		for _, sub := range subs {
+			if err := n.sender.SendBackInStock(ctx, sub); err != nil {
+				n.log.Warn(ctx, "Failed to send back-in-stock notification", map[string]interface{}{
+					"error":           err.Error(),
+					"subscription_id": sub.ID,
+					"product_id":      sub.ProductID,
+				})
+				continue
+			}
+			// A subscription that cannot be deleted is notified again after
+			// its lease; the notification service drops the repeated event
+			n.repo.DeleteBackInStockSubscription(ctx, sub.ID)
+		}
+
+		if len(subs) < backInStockBatchSize {
+			return
+		}
+	}
+}
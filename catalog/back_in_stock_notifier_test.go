@@ -0,0 +1,137 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// mockBackInStockSender records notifications and fails those of failFor
+type mockBackInStockSender struct {
+	mu      sync.Mutex
+	sent    []string
+	failFor string
+	notify  chan string
+}
+
+func (s *mockBackInStockSender) SendBackInStock(ctx context.Context, sub *BackInStockSubscription) error {
+	if sub.UserID == s.failFor {
+		return errors.New("notification service unavailable")
+	}
+	s.mu.Lock()
+	s.sent = append(s.sent, sub.UserID)
+	s.mu.Unlock()
+	if s.notify != nil {
+		s.notify <- sub.UserID
+	}
+	return nil
+}
+
+// backInStockRepo returns a repository whose subscriptions are claimed once
+// and records the deleted ones
+func backInStockRepo(subs []*BackInStockSubscription, deleted *[]string) *MockRepository {
+	var mu sync.Mutex
+	claimed := false
+	return &MockRepository{
+		ClaimBackInStockFunc: func(ctx context.Context, productID string, limit int, lease time.Duration) ([]*BackInStockSubscription, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if claimed {
+				return nil, nil
+			}
+			claimed = true
+			var matched []*BackInStockSubscription
+			for _, sub := range subs {
+				if productID == "" || sub.ProductID == productID {
+					matched = append(matched, sub)
+				}
+			}
+			return matched, nil
+		},
+		DeleteBackInStockSubscriptionFunc: func(ctx context.Context, id string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			*deleted = append(*deleted, id)
+			return nil
+		},
+	}
+}
+
+func TestBackInStockNotifier_DeletesNotified(t *testing.T) {
+	var deleted []string
+	repo := backInStockRepo([]*BackInStockSubscription{
+		{ID: "sub-1", ProductID: "prod-1", UserID: "user-1"},
+		{ID: "sub-2", ProductID: "prod-1", UserID: "user-2"},
+	}, &deleted)
+	sender := &mockBackInStockSender{failFor: "user-2"}
+
+	NewBackInStockNotifier(repo, sender, nil, logger.New("catalog-test")).notify(context.Background(), "")
+
+	if !reflect.DeepEqual(sender.sent, []string{"user-1"}) {
+		t.Errorf("Expected user-1 to be notified, got %v", sender.sent)
+	}
+	// The failed subscription is kept for the next sweep
+	if !reflect.DeepEqual(deleted, []string{"sub-1"}) {
+		t.Errorf("Expected only sub-1 to be deleted, got %v", deleted)
+	}
+}
+
+func TestBackInStockNotifier_NotifiesOnRestock(t *testing.T) {
+	var deleted []string
+	repo := backInStockRepo([]*BackInStockSubscription{{ID: "sub-1", ProductID: "prod-1", UserID: "user-1"}}, &deleted)
+	sender := &mockBackInStockSender{notify: make(chan string, 1)}
+	hub := NewProductChangeHub(8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		NewBackInStockNotifier(repo, sender, hub, logger.New("catalog-test")).Run(ctx, time.Hour)
+		close(done)
+	}()
+
+	waitForSubscribers(t, hub, 1)
+	hub.Publish(ProductChange{Type: ProductChangeUpdated, ProductID: "prod-2", Product: &Product{ID: "prod-2", Stock: 0, Status: ProductStatusActive}})
+	hub.Publish(ProductChange{Type: ProductChangeUpdated, ProductID: "prod-1", Product: &Product{ID: "prod-1", Stock: 5, Status: ProductStatusActive}})
+
+	select {
+	case user := <-sender.notify:
+		if user != "user-1" {
+			t.Errorf("Expected user-1 to be notified, got %s", user)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a notification for the restocked product")
+	}
+
+	cancel()
+	<-done
+	waitForSubscribers(t, hub, 0)
+}
+
+func TestIsRestocked(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   ProductChange
+		expected bool
+	}{
+		{"in stock", ProductChange{Type: ProductChangeUpdated, Product: &Product{Stock: 1, Status: ProductStatusActive}}, true},
+		{"out of stock", ProductChange{Type: ProductChangeUpdated, Product: &Product{Status: ProductStatusActive}}, false},
+		{"draft", ProductChange{Type: ProductChangeUpdated, Product: &Product{Stock: 1, Status: ProductStatusDraft}}, false},
+		{"digital", ProductChange{Type: ProductChangeUpdated, Product: &Product{Stock: 1, Status: ProductStatusActive, ProductType: ProductTypeDigital}}, false},
+		{"created", ProductChange{Type: ProductChangeCreated, Product: &Product{Stock: 1, Status: ProductStatusActive}}, false},
+		{"deleted", ProductChange{Type: ProductChangeDeleted}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRestocked(tt.change); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
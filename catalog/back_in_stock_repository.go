@@ -0,0 +1,111 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+)
+
+// BackInStockSubscription is a customer waiting for an out-of-stock product
+type BackInStockSubscription struct {
+	ID        string
+	ProductID string
+	UserID    string
+	CreatedAt time.Time
+	// ProductName is filled in by ClaimBackInStock
+	ProductName string
+}
+
+const backInStockColumns = "id, product_id, user_id, created_at"
+
+// SubscribeBackInStock inserts a subscription, or returns the customer's
+// existing subscription to the product
+func (r *postgresRepository) SubscribeBackInStock(ctx context.Context, sub *BackInStockSubscription) (*BackInStockSubscription, error) {
+	query := `
+		INSERT INTO back_in_stock_subscriptions (product_id, user_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (product_id, user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING ` + backInStockColumns
+
+	saved := &BackInStockSubscription{}
+	err := r.db.QueryRowContext(ctx, query, sub.ProductID, sub.UserID, sub.CreatedAt).
+		Scan(&saved.ID, &saved.ProductID, &saved.UserID, &saved.CreatedAt)
+	if pgerr.IsForeignKeyViolation(err) || pgerr.IsMalformedID(err) {
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to subscribe to back-in-stock notification", map[string]interface{}{"error": err.Error(), "product_id": sub.ProductID})
+		return nil, fmt.Errorf("failed to subscribe to back-in-stock notification: %w", err)
+	}
+
+	r.log.Info(ctx, "Back-in-stock subscription saved", map[string]interface{}{"subscription_id": saved.ID, "product_id": saved.ProductID})
+	return saved, nil
+}
+
+// UnsubscribeBackInStock deletes the customer's subscription to a product, if any
+func (r *postgresRepository) UnsubscribeBackInStock(ctx context.Context, productID, userID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM back_in_stock_subscriptions WHERE product_id = $1 AND user_id = $2", productID, userID)
+	if pgerr.IsMalformedID(err) {
+		return nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to unsubscribe from back-in-stock notification", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to unsubscribe from back-in-stock notification: %w", err)
+	}
+	return nil
+}
+
+// ClaimBackInStock leases up to limit subscriptions to active physical
+// products that are in stock again, oldest first, so that concurrent
+// notifiers do not notify the same subscription. With a productID only that
+// product's subscriptions are claimed. Subscriptions whose lease expired are
+// claimed again.
+func (r *postgresRepository) ClaimBackInStock(ctx context.Context, productID string, limit int, lease time.Duration) ([]*BackInStockSubscription, error) {
+	now := time.Now()
+	query := `
+		UPDATE back_in_stock_subscriptions s
+		SET claimed_until = $1
+		FROM products p
+		WHERE p.id = s.product_id AND s.id IN (
+			SELECT b.id FROM back_in_stock_subscriptions b
+			JOIN products bp ON bp.id = b.product_id
+			WHERE ($3 = '' OR b.product_id::text = $3)
+				AND bp.stock > 0 AND bp.product_type = 'PHYSICAL' AND bp.status = 'ACTIVE'
+				AND (b.claimed_until IS NULL OR b.claimed_until < $2)
+			ORDER BY b.created_at
+			LIMIT $4
+			FOR UPDATE OF b SKIP LOCKED
+		)
+		RETURNING s.id, s.product_id, s.user_id, s.created_at, p.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now.Add(lease), now, productID, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to claim back-in-stock subscriptions", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to claim back-in-stock subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*BackInStockSubscription
+	for rows.Next() {
+		sub := &BackInStockSubscription{}
+		if err := rows.Scan(&sub.ID, &sub.ProductID, &sub.UserID, &sub.CreatedAt, &sub.ProductName); err != nil {
+			r.log.Error(ctx, "Failed to scan back-in-stock subscription", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan back-in-stock subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+// DeleteBackInStockSubscription deletes a subscription once it was notified
+func (r *postgresRepository) DeleteBackInStockSubscription(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM back_in_stock_subscriptions WHERE id = $1", id); err != nil {
+		r.log.Error(ctx, "Failed to delete back-in-stock subscription", map[string]interface{}{"error": err.Error(), "subscription_id": id})
+		return fmt.Errorf("failed to delete back-in-stock subscription: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SubscribeBackInStock subscribes a customer to one notification when an
+// out-of-stock product is restocked. The subscription is removed once the
+// notification is sent; subscribing again returns the pending subscription.
+// Customers subscribe themselves; ADMIN and SERVICE tokens name the customer
+// in user_id.
+func (s *Service) SubscribeBackInStock(ctx context.Context, req *pb.SubscribeBackInStockRequest) (*pb.SubscribeBackInStockResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "Subscribe back in stock failed: "+status.Convert(err).Message(), map[string]interface{}{"product_id": req.ProductId})
+		return nil, err
+	}
+	if msg := backInStockRequestError(req.ProductId, userID); msg != "" {
+		s.log.Warn(ctx, "Subscribe back in stock failed: "+msg, map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if !product.TracksStock() {
+		s.log.Warn(ctx, "Subscribe back in stock failed: product does not track stock", map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.FailedPrecondition, "product does not track stock")
+	}
+	if product.Stock > 0 {
+		s.log.Warn(ctx, "Subscribe back in stock failed: product is in stock", map[string]interface{}{"product_id": req.ProductId, "stock": product.Stock})
+		return nil, status.Error(codes.FailedPrecondition, "product is in stock")
+	}
+
+	sub, err := s.repo.SubscribeBackInStock(ctx, &BackInStockSubscription{
+		ProductID: req.ProductId,
+		UserID:    userID,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		if errors.Is(err, ErrProductNotFound) {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to subscribe")
+	}
+
+	return &pb.SubscribeBackInStockResponse{
+		Subscription: toProtoBackInStockSubscription(sub),
+	}, nil
+}
+
+// UnsubscribeBackInStock removes a customer's subscription to a product; it
+// succeeds when there is none
+func (s *Service) UnsubscribeBackInStock(ctx context.Context, req *pb.UnsubscribeBackInStockRequest) (*pb.UnsubscribeBackInStockResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "Unsubscribe back in stock failed: "+status.Convert(err).Message(), map[string]interface{}{"product_id": req.ProductId})
+		return nil, err
+	}
+	if msg := backInStockRequestError(req.ProductId, userID); msg != "" {
+		s.log.Warn(ctx, "Unsubscribe back in stock failed: "+msg, map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	if err := s.repo.UnsubscribeBackInStock(ctx, req.ProductId, userID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to unsubscribe")
+	}
+	return &pb.UnsubscribeBackInStockResponse{}, nil
+}
+
+// backInStockRequestError validates the product and customer of a
+// subscription request, returning "" when they are valid
+func backInStockRequestError(productID, userID string) string {
+	switch {
+	case productID == "":
+		return "product_id is required"
+	case userID == "":
+		return "user_id is required"
+	case len(userID) > maxUserIDLength:
+		return "user_id is too long"
+	}
+	return ""
+}
+
+// toProtoBackInStockSubscription converts a subscription to protobuf
+func toProtoBackInStockSubscription(sub *BackInStockSubscription) *pb.BackInStockSubscription {
+	return &pb.BackInStockSubscription{
+		Id:        sub.ID,
+		ProductId: sub.ProductID,
+		UserId:    sub.UserID,
+		CreatedAt: timestamppb.New(sub.CreatedAt),
+	}
+}
@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubscribeBackInStock_Success(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var got *BackInStockSubscription
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, ProductType: ProductTypePhysical}, nil
+		},
+		SubscribeBackInStockFunc: func(ctx context.Context, sub *BackInStockSubscription) (*BackInStockSubscription, error) {
+			got = sub
+			saved := *sub
+			saved.ID = "sub-1"
+			return &saved, nil
+		},
+	}
+	service := setupService(mockRepo)
+	service.now = func() time.Time { return now }
+
+	resp, err := service.SubscribeBackInStock(callerContext("user-1", auth.RoleUser), &pb.SubscribeBackInStockRequest{ProductId: "prod-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.ProductID != "prod-1" || got.UserID != "user-1" || !got.CreatedAt.Equal(now) {
+		t.Errorf("Unexpected subscription %+v", got)
+	}
+	if sub := resp.Subscription; sub.Id != "sub-1" || sub.ProductId != "prod-1" || !sub.CreatedAt.AsTime().Equal(now) {
+		t.Errorf("Unexpected response %v", sub)
+	}
+}
+
+func TestSubscribeBackInStock_Errors(t *testing.T) {
+	products := map[string]*Product{
+		"in-stock": {ID: "in-stock", ProductType: ProductTypePhysical, Stock: 3},
+		"digital":  {ID: "digital", ProductType: ProductTypeDigital},
+	}
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if p, ok := products[id]; ok {
+				return p, nil
+			}
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	notifier := callerContext("notifier", auth.RoleService)
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.SubscribeBackInStockRequest
+		expected codes.Code
+	}{
+		{"missing product", notifier, &pb.SubscribeBackInStockRequest{UserId: "user-1"}, codes.InvalidArgument},
+		{"missing user", notifier, &pb.SubscribeBackInStockRequest{ProductId: "in-stock"}, codes.InvalidArgument},
+		{"user too long", notifier, &pb.SubscribeBackInStockRequest{ProductId: "in-stock", UserId: strings.Repeat("u", maxUserIDLength+1)}, codes.InvalidArgument},
+		{"unknown product", notifier, &pb.SubscribeBackInStockRequest{ProductId: "missing", UserId: "user-1"}, codes.NotFound},
+		{"in stock", notifier, &pb.SubscribeBackInStockRequest{ProductId: "in-stock", UserId: "user-1"}, codes.FailedPrecondition},
+		{"digital", notifier, &pb.SubscribeBackInStockRequest{ProductId: "digital", UserId: "user-1"}, codes.FailedPrecondition},
+		{"no token", context.Background(), &pb.SubscribeBackInStockRequest{ProductId: "missing", UserId: "user-1"}, codes.Unauthenticated},
+		{"another customer", callerContext("user-2", auth.RoleUser), &pb.SubscribeBackInStockRequest{ProductId: "missing", UserId: "user-1"}, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SubscribeBackInStock(tt.ctx, tt.req)
+			if st, _ := status.FromError(err); st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestUnsubscribeBackInStock(t *testing.T) {
+	var product, user string
+	mockRepo := &MockRepository{
+		UnsubscribeBackInStockFunc: func(ctx context.Context, productID, userID string) error {
+			product, user = productID, userID
+			return nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	if _, err := service.UnsubscribeBackInStock(callerContext("user-1", auth.RoleUser), &pb.UnsubscribeBackInStockRequest{ProductId: "prod-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if product != "prod-1" || user != "user-1" {
+		t.Errorf("Expected prod-1 and user-1 to be unsubscribed, got %s and %s", product, user)
+	}
+
+	_, err := service.UnsubscribeBackInStock(callerContext("admin-1", auth.RoleAdmin), &pb.UnsubscribeBackInStockRequest{ProductId: "prod-1"})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
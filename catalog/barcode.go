@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"strings"
+)
+
+// productBarcodes holds the normalized barcodes of a request; "" when not set
+type productBarcodes struct {
+	ean, upc, isbn string
+}
+
+// all returns the barcodes that are set
+func (b productBarcodes) all() []string {
+	var codes []string
+	for _, c := range []string{b.ean, b.upc, b.isbn} {
+		if c != "" {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}
+
+// barcodesFromRequest validates and normalizes the barcode fields of a create
+// or update request and returns a message describing the first problem, or "".
+func barcodesFromRequest(ean, upc, isbn string) (productBarcodes, string) {
+	var b productBarcodes
+
+	if ean = cleanBarcode(ean); ean != "" {
+		if (len(ean) != 8 && len(ean) != 13) || !validGTIN(ean) {
+			return productBarcodes{}, "ean must be a valid EAN-8 or EAN-13"
+		}
+		b.ean = ean
+	}
+
+	if upc = cleanBarcode(upc); upc != "" {
+		if len(upc) != 12 || !validGTIN(upc) {
+			return productBarcodes{}, "upc must be a valid 12-digit UPC-A"
+		}
+		b.upc = upc
+	}
+
+	if isbn = cleanBarcode(isbn); isbn != "" {
+		normalized, ok := normalizeISBN(isbn)
+		if !ok {
+			return productBarcodes{}, "isbn must be a valid ISBN-10 or ISBN-13"
+		}
+		b.isbn = normalized
+	}
+	return b, ""
+}
+
+// barcodeCandidates returns the stored forms a scanned barcode may match. A
+// UPC-A is also an EAN-13 with a leading zero, and an ISBN-10 is stored as ISBN-13.
+func barcodeCandidates(code string) []string {
+	code = cleanBarcode(code)
+	candidates := []string{code}
+	switch {
+	case len(code) == 10:
+		if isbn, ok := normalizeISBN(code); ok {
+			candidates = append(candidates, isbn)
+		}
+	case len(code) == 12:
+		candidates = append(candidates, "0"+code)
+	case len(code) == 13 && code[0] == '0':
+		candidates = append(candidates, code[1:])
+	}
+	return candidates
+}
+
+// cleanBarcode removes the spaces and hyphens barcodes are often printed with
+func cleanBarcode(code string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
+
+// validGTIN reports whether code is all digits with a correct GTIN check
+// digit, as used by EAN, UPC and ISBN-13
+func validGTIN(code string) bool {
+	return len(code) >= 8 && isDigits(code) && gtinCheckDigit(code[:len(code)-1]) == code[len(code)-1:]
+}
+
+// normalizeISBN returns the ISBN-13 form of a cleaned ISBN-10 or ISBN-13
+func normalizeISBN(code string) (string, bool) {
+	switch len(code) {
+	case 13:
+		if (strings.HasPrefix(code, "978") || strings.HasPrefix(code, "979")) && validGTIN(code) {
+			return code, true
+		}
+	case 10:
+		if !isDigits(code[:9]) {
+			return "", false
+		}
+		sum := 0
+		for i := 0; i < 9; i++ {
+			sum += int(code[i]-'0') * (10 - i)
+		}
+		check := code[9]
+		switch {
+		case check == 'X':
+			sum += 10
+		case check >= '0' && check <= '9':
+			sum += int(check - '0')
+		default:
+			return "", false
+		}
+		if sum%11 != 0 {
+			return "", false
+		}
+		isbn := "978" + code[:9]
+		return isbn + gtinCheckDigit(isbn), true
+	}
+	return "", false
+}
+
+// gtinCheckDigit returns the check digit completing a GTIN body. Weights
+// alternate 3 and 1 starting from the rightmost digit.
+func gtinCheckDigit(body string) string {
+	sum := 0
+	for i := len(body) - 1; i >= 0; i-- {
+		d := int(body[i] - '0')
+		if (len(body)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return string(rune('0' + (10-sum%10)%10))
+}
+
+// isDigits reports whether s is non-empty and all ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
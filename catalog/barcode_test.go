@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBarcodesFromRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		ean, upc, isbn string
+		expected       productBarcodes
+		wantMsg        bool
+	}{
+		{"none", "", "", "", productBarcodes{}, false},
+		{"ean-13", "4006381333931", "", "", productBarcodes{ean: "4006381333931"}, false},
+		{"ean-8 with spaces", "9638 5074", "", "", productBarcodes{ean: "96385074"}, false},
+		{"upc-a", "", "036000291452", "", productBarcodes{upc: "036000291452"}, false},
+		{"isbn-13 with hyphens", "", "", "978-0-306-40615-7", productBarcodes{isbn: "9780306406157"}, false},
+		{"isbn-10 converted", "", "", "0-306-40615-2", productBarcodes{isbn: "9780306406157"}, false},
+		{"isbn-10 with X", "", "", "080442957X", productBarcodes{isbn: "9780804429573"}, false},
+		{"bad ean check digit", "4006381333932", "", "", productBarcodes{}, true},
+		{"ean wrong length", "40063813339", "", "", productBarcodes{}, true},
+		{"upc letters", "", "03600029145A", "", productBarcodes{}, true},
+		{"isbn-13 without bookland prefix", "", "", "4006381333931", productBarcodes{}, true},
+		{"bad isbn-10 check digit", "", "", "0306406153", productBarcodes{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := barcodesFromRequest(tt.ean, tt.upc, tt.isbn)
+			if (msg != "") != tt.wantMsg {
+				t.Fatalf("Expected message %v, got %q", tt.wantMsg, msg)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBarcodeCandidates(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected []string
+	}{
+		{"036000291452", []string{"036000291452", "0036000291452"}},
+		{"0036000291452", []string{"0036000291452", "036000291452"}},
+		{"0-306-40615-2", []string{"0306406152", "9780306406157"}},
+		{"4006381333931", []string{"4006381333931"}},
+	}
+
+	for _, tt := range tests {
+		if got := barcodeCandidates(tt.code); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.code, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Booking statuses
+const (
+	BookingHeld      = "HELD"
+	BookingConfirmed = "CONFIRMED"
+	BookingCancelled = "CANCELLED"
+)
+
+var (
+	// ErrNotBookable is returned when a product has no enabled booking configuration
+	ErrNotBookable = errors.New("product is not bookable")
+	// ErrBookingUnavailable is returned when the requested period exceeds remaining capacity
+	ErrBookingUnavailable = errors.New("requested period is not available")
+	// ErrBookingNotFound is returned when a booking does not exist
+	ErrBookingNotFound = errors.New("booking not found")
+	// ErrBookingStateChanged is returned when a booking is no longer in the expected status
+	ErrBookingStateChanged = errors.New("booking status has changed")
+)
+
+// BookingConfig holds the booking settings of a bookable product
+type BookingConfig struct {
+	ProductID string
+	Enabled   bool
+	Capacity  int32
+}
+
+// Booking is a date-range reservation of a bookable product
+type Booking struct {
+	ID        string
+	ProductID string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Quantity  int32
+	Status    string
+	Reference string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+const bookingColumns = "id, product_id, starts_at, ends_at, quantity, status, reference, expires_at, created_at"
+
+// SetBookingConfig creates or replaces the booking configuration of a product
+func (r *postgresRepository) SetBookingConfig(ctx context.Context, cfg *BookingConfig) (*BookingConfig, error) {
+	query := `
+		INSERT INTO product_booking_configs (product_id, enabled, capacity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (product_id) DO UPDATE SET enabled = EXCLUDED.enabled, capacity = EXCLUDED.capacity
+		RETURNING product_id, enabled, capacity
+	`
+
+	saved := &BookingConfig{}
+	err := r.db.QueryRowContext(ctx, query, cfg.ProductID, cfg.Enabled, cfg.Capacity).
+		Scan(&saved.ProductID, &saved.Enabled, &saved.Capacity)
+	if err != nil {
+		r.log.Error(ctx, "Failed to set booking config", map[string]interface{}{"error": err.Error(), "product_id": cfg.ProductID})
+		return nil, fmt.Errorf("failed to set booking config: %w", err)
+	}
+
+	r.log.Info(ctx, "Booking config updated successfully", map[string]interface{}{"product_id": saved.ProductID, "enabled": saved.Enabled, "capacity": saved.Capacity})
+	return saved, nil
+}
+
+// GetBookingConfig retrieves the booking configuration of a product
+func (r *postgresRepository) GetBookingConfig(ctx context.Context, productID string) (*BookingConfig, error) {
+	query := "SELECT product_id, enabled, capacity FROM product_booking_configs WHERE product_id = $1"
+
+	cfg := &BookingConfig{}
+	err := r.db.QueryRowContext(ctx, query, productID).Scan(&cfg.ProductID, &cfg.Enabled, &cfg.Capacity)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotBookable
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get booking config", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get booking config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// ListActiveBookings retrieves held and confirmed bookings overlapping [from, to).
+// Holds that expired before now are ignored.
+func (r *postgresRepository) ListActiveBookings(ctx context.Context, productID string, from, to, now time.Time) ([]*Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM product_bookings
+		WHERE product_id = $1
+			AND status <> 'CANCELLED'
+			AND NOT (status = 'HELD' AND expires_at <= $4)
+			AND starts_at < $3 AND ends_at > $2
+		ORDER BY starts_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, from, to, now)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list bookings", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to list bookings: %w", err)
+	}
+	defer rows.Close()
+
+	bookings := []*Booking{}
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan booking", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan booking: %w", err)
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating bookings", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// CreateBooking inserts a booking if the product still has capacity for the period.
+// The booking config row is locked so concurrent reservations cannot overbook.
+func (r *postgresRepository) CreateBooking(ctx context.Context, booking *Booking, now time.Time) (*Booking, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var enabled bool
+	var capacity int32
+	err = tx.QueryRowContext(ctx,
+		"SELECT enabled, capacity FROM product_booking_configs WHERE product_id = $1 FOR UPDATE",
+		booking.ProductID,
+	).Scan(&enabled, &capacity)
+	if err == sql.ErrNoRows || (err == nil && !enabled) {
+		return nil, ErrNotBookable
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to lock booking config", map[string]interface{}{"error": err.Error(), "product_id": booking.ProductID})
+		return nil, fmt.Errorf("failed to lock booking config: %w", err)
+	}
+
+	overlapQuery := `
+		SELECT COALESCE(SUM(quantity), 0)
+		FROM product_bookings
+		WHERE product_id = $1
+			AND status <> 'CANCELLED'
+			AND NOT (status = 'HELD' AND expires_at <= $4)
+			AND starts_at < $3 AND ends_at > $2
+	`
+	var booked int32
+	err = tx.QueryRowContext(ctx, overlapQuery, booking.ProductID, booking.StartsAt, booking.EndsAt, now).Scan(&booked)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count overlapping bookings", map[string]interface{}{"error": err.Error(), "product_id": booking.ProductID})
+		return nil, fmt.Errorf("failed to count overlapping bookings: %w", err)
+	}
+
+	if booked+booking.Quantity > capacity {
+		r.log.Warn(ctx, "Booking conflicts with existing bookings", map[string]interface{}{"product_id": booking.ProductID, "booked": booked, "capacity": capacity})
+		return nil, ErrBookingUnavailable
+	}
+
+	insertQuery := `
+		INSERT INTO product_bookings (product_id, starts_at, ends_at, quantity, status, reference, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + bookingColumns
+
+	created, err := scanBooking(tx.QueryRowContext(ctx, insertQuery,
+		booking.ProductID,
+		booking.StartsAt,
+		booking.EndsAt,
+		booking.Quantity,
+		booking.Status,
+		booking.Reference,
+		booking.ExpiresAt,
+	))
+	if err != nil {
+		r.log.Error(ctx, "Failed to create booking", map[string]interface{}{"error": err.Error(), "product_id": booking.ProductID})
+		return nil, fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit booking", map[string]interface{}{"error": err.Error(), "product_id": booking.ProductID})
+		return nil, fmt.Errorf("failed to commit booking: %w", err)
+	}
+
+	r.log.Info(ctx, "Booking created successfully", map[string]interface{}{"booking_id": created.ID, "product_id": created.ProductID})
+	return created, nil
+}
+
+// GetBooking retrieves a booking by ID
+func (r *postgresRepository) GetBooking(ctx context.Context, id string) (*Booking, error) {
+	query := "SELECT " + bookingColumns + " FROM product_bookings WHERE id = $1"
+
+	booking, err := scanBooking(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrBookingNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get booking", map[string]interface{}{"error": err.Error(), "booking_id": id})
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+// UpdateBookingStatus moves a booking from one status to another, clearing the hold expiry.
+// It returns ErrBookingStateChanged if the booking is no longer in fromStatus.
+func (r *postgresRepository) UpdateBookingStatus(ctx context.Context, id, fromStatus, toStatus string) (*Booking, error) {
+	query := `
+		UPDATE product_bookings
+		SET status = $3, expires_at = NULL
+		WHERE id = $1 AND status = $2
+		RETURNING ` + bookingColumns
+
+	booking, err := scanBooking(r.db.QueryRowContext(ctx, query, id, fromStatus, toStatus))
+	if err == sql.ErrNoRows {
+		return nil, ErrBookingStateChanged
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update booking status", map[string]interface{}{"error": err.Error(), "booking_id": id})
+		return nil, fmt.Errorf("failed to update booking status: %w", err)
+	}
+
+	r.log.Info(ctx, "Booking status updated", map[string]interface{}{"booking_id": id, "status": toStatus})
+	return booking, nil
+}
+
+// scanBooking scans a booking selected with bookingColumns
+func scanBooking(row rowScanner) (*Booking, error) {
+	booking := &Booking{}
+	var reference sql.NullString
+	var expiresAt sql.NullTime
+
+	err := row.Scan(
+		&booking.ID,
+		&booking.ProductID,
+		&booking.StartsAt,
+		&booking.EndsAt,
+		&booking.Quantity,
+		&booking.Status,
+		&reference,
+		&expiresAt,
+		&booking.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	booking.Reference = reference.String
+	if expiresAt.Valid {
+		booking.ExpiresAt = &expiresAt.Time
+	}
+	return booking, nil
+}
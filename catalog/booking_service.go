@@ -0,0 +1,297 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultBookingHold is how long a reservation is held before checkout must confirm it
+	defaultBookingHold = 15 * time.Minute
+	// maxBookingHold caps client-requested hold durations
+	maxBookingHold = time.Hour
+	// maxAvailabilityDays caps the size of an availability calendar request
+	maxAvailabilityDays = 92
+)
+
+// SetBookingConfig enables or disables booking mode for a product
+func (s *Service) SetBookingConfig(ctx context.Context, req *pb.SetBookingConfigRequest) (*pb.SetBookingConfigResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Set booking config failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.Capacity < 0 {
+		s.log.Warn(ctx, "Set booking config failed: capacity cannot be negative", nil)
+		return nil, status.Error(codes.InvalidArgument, "capacity cannot be negative")
+	}
+
+	capacity := req.Capacity
+	if capacity == 0 {
+		capacity = 1
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	cfg, err := s.repo.SetBookingConfig(ctx, &BookingConfig{
+		ProductID: req.ProductId,
+		Enabled:   req.Enabled,
+		Capacity:  capacity,
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to set booking config", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to set booking config")
+	}
+
+	return &pb.SetBookingConfigResponse{
+		Config: toProtoBookingConfig(cfg),
+	}, nil
+}
+
+// GetAvailability returns the per-day availability calendar of a bookable product
+func (s *Service) GetAvailability(ctx context.Context, req *pb.GetAvailabilityRequest) (*pb.GetAvailabilityResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get availability failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.From == nil || req.To == nil {
+		s.log.Warn(ctx, "Get availability failed: date range is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+
+	from := truncateToDay(req.From.AsTime())
+	to := req.To.AsTime()
+	if !to.After(from) {
+		return nil, status.Error(codes.InvalidArgument, "to must be after from")
+	}
+	if to.Sub(from) > maxAvailabilityDays*24*time.Hour {
+		return nil, status.Errorf(codes.InvalidArgument, "date range cannot exceed %d days", maxAvailabilityDays)
+	}
+
+	cfg, err := s.repo.GetBookingConfig(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.bookingError(ctx, err, req.ProductId)
+	}
+
+	bookings, err := s.repo.ListActiveBookings(ctx, req.ProductId, from, to, s.now())
+	if err != nil {
+		s.log.Error(ctx, "Failed to list bookings", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get availability")
+	}
+
+	days := []*pb.AvailabilityDay{}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		available := int32(0)
+		if cfg.Enabled {
+			available = cfg.Capacity - bookedQuantity(bookings, day, day.AddDate(0, 0, 1))
+			if available < 0 {
+				available = 0
+			}
+		}
+		days = append(days, &pb.AvailabilityDay{
+			Date:      timestamppb.New(day),
+			Available: available,
+		})
+	}
+
+	return &pb.GetAvailabilityResponse{
+		Config: toProtoBookingConfig(cfg),
+		Days:   days,
+	}, nil
+}
+
+// ReserveBooking holds a date range of a bookable product for checkout
+func (s *Service) ReserveBooking(ctx context.Context, req *pb.ReserveBookingRequest) (*pb.ReserveBookingResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Reserve booking failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.StartsAt == nil || req.EndsAt == nil {
+		s.log.Warn(ctx, "Reserve booking failed: period is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "starts_at and ends_at are required")
+	}
+
+	now := s.now()
+	startsAt := req.StartsAt.AsTime()
+	endsAt := req.EndsAt.AsTime()
+	if !endsAt.After(startsAt) {
+		return nil, status.Error(codes.InvalidArgument, "ends_at must be after starts_at")
+	}
+	if startsAt.Before(now) {
+		return nil, status.Error(codes.InvalidArgument, "starts_at cannot be in the past")
+	}
+	if req.Quantity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity cannot be negative")
+	}
+	if req.HoldSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "hold_seconds cannot be negative")
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	hold := defaultBookingHold
+	if req.HoldSeconds > 0 {
+		hold = time.Duration(req.HoldSeconds) * time.Second
+	}
+	if hold > maxBookingHold {
+		hold = maxBookingHold
+	}
+	expiresAt := now.Add(hold)
+
+	booking, err := s.repo.CreateBooking(ctx, &Booking{
+		ProductID: req.ProductId,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		Quantity:  quantity,
+		Status:    BookingHeld,
+		Reference: req.Reference,
+		ExpiresAt: &expiresAt,
+	}, now)
+	if err != nil {
+		return nil, s.bookingError(ctx, err, req.ProductId)
+	}
+
+	s.log.Info(ctx, "Booking reserved successfully", map[string]interface{}{"booking_id": booking.ID, "product_id": booking.ProductID})
+
+	return &pb.ReserveBookingResponse{
+		Booking: toProtoBooking(booking),
+	}, nil
+}
+
+// ConfirmBooking confirms a held booking before its hold expires
+func (s *Service) ConfirmBooking(ctx context.Context, req *pb.ConfirmBookingRequest) (*pb.ConfirmBookingResponse, error) {
+	if req.BookingId == "" {
+		s.log.Warn(ctx, "Confirm booking failed: booking ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "booking_id is required")
+	}
+
+	booking, err := s.repo.GetBooking(ctx, req.BookingId)
+	if err != nil {
+		return nil, s.bookingError(ctx, err, "")
+	}
+
+	if booking.Status == BookingConfirmed {
+		return &pb.ConfirmBookingResponse{Booking: toProtoBooking(booking)}, nil
+	}
+	if booking.Status != BookingHeld {
+		return nil, status.Error(codes.FailedPrecondition, "booking is not held")
+	}
+	if booking.ExpiresAt != nil && !booking.ExpiresAt.After(s.now()) {
+		return nil, status.Error(codes.FailedPrecondition, "booking hold has expired")
+	}
+
+	confirmed, err := s.repo.UpdateBookingStatus(ctx, booking.ID, BookingHeld, BookingConfirmed)
+	if err != nil {
+		return nil, s.bookingError(ctx, err, booking.ProductID)
+	}
+
+	s.log.Info(ctx, "Booking confirmed successfully", map[string]interface{}{"booking_id": confirmed.ID})
+
+	return &pb.ConfirmBookingResponse{
+		Booking: toProtoBooking(confirmed),
+	}, nil
+}
+
+// CancelBooking releases a held or confirmed booking
+func (s *Service) CancelBooking(ctx context.Context, req *pb.CancelBookingRequest) (*pb.CancelBookingResponse, error) {
+	if req.BookingId == "" {
+		s.log.Warn(ctx, "Cancel booking failed: booking ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "booking_id is required")
+	}
+
+	booking, err := s.repo.GetBooking(ctx, req.BookingId)
+	if err != nil {
+		return nil, s.bookingError(ctx, err, "")
+	}
+
+	if booking.Status == BookingCancelled {
+		return &pb.CancelBookingResponse{Booking: toProtoBooking(booking)}, nil
+	}
+
+	cancelled, err := s.repo.UpdateBookingStatus(ctx, booking.ID, booking.Status, BookingCancelled)
+	if err != nil {
+		return nil, s.bookingError(ctx, err, booking.ProductID)
+	}
+
+	s.log.Info(ctx, "Booking cancelled successfully", map[string]interface{}{"booking_id": cancelled.ID})
+
+	return &pb.CancelBookingResponse{
+		Booking: toProtoBooking(cancelled),
+	}, nil
+}
+
+// bookingError maps booking repository errors to gRPC status errors
+func (s *Service) bookingError(ctx context.Context, err error, productID string) error {
+	switch {
+	case errors.Is(err, ErrNotBookable):
+		s.log.Warn(ctx, "Product is not bookable", map[string]interface{}{"product_id": productID})
+		return status.Error(codes.FailedPrecondition, "product is not bookable")
+	case errors.Is(err, ErrBookingUnavailable):
+		return status.Error(codes.AlreadyExists, "requested period is already booked")
+	case errors.Is(err, ErrBookingNotFound):
+		return status.Error(codes.NotFound, "booking not found")
+	case errors.Is(err, ErrBookingStateChanged):
+		return status.Error(codes.FailedPrecondition, "booking status has changed")
+	default:
+		s.log.Error(ctx, "Booking operation failed", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return status.Error(codes.Internal, "booking operation failed")
+	}
+}
+
+// bookedQuantity sums the quantity of bookings overlapping [from, to)
+func bookedQuantity(bookings []*Booking, from, to time.Time) int32 {
+	var total int32
+	for _, b := range bookings {
+		if b.StartsAt.Before(to) && b.EndsAt.After(from) {
+			total += b.Quantity
+		}
+	}
+	return total
+}
+
+// truncateToDay returns midnight UTC of the given time
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// toProtoBookingConfig converts a domain BookingConfig to protobuf
+func toProtoBookingConfig(cfg *BookingConfig) *pb.BookingConfig {
+	return &pb.BookingConfig{
+		ProductId: cfg.ProductID,
+		Enabled:   cfg.Enabled,
+		Capacity:  cfg.Capacity,
+	}
+}
+
+// toProtoBooking converts a domain Booking to protobuf
+func toProtoBooking(b *Booking) *pb.Booking {
+	booking := &pb.Booking{
+		Id:        b.ID,
+		ProductId: b.ProductID,
+		StartsAt:  timestamppb.New(b.StartsAt),
+		EndsAt:    timestamppb.New(b.EndsAt),
+		Quantity:  b.Quantity,
+		Status:    b.Status,
+		Reference: b.Reference,
+		CreatedAt: timestamppb.New(b.CreatedAt),
+	}
+	if b.ExpiresAt != nil {
+		booking.ExpiresAt = timestamppb.New(*b.ExpiresAt)
+	}
+	return booking
+}
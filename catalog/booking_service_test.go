@@ -0,0 +1,228 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var bookingNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func setupBookingService(repo Repository) *Service {
+	service := setupService(repo)
+	service.now = func() time.Time { return bookingNow }
+	return service
+}
+
+func TestGetAvailability_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingConfigFunc: func(ctx context.Context, productID string) (*BookingConfig, error) {
+			return &BookingConfig{ProductID: productID, Enabled: true, Capacity: 2}, nil
+		},
+		ListActiveBookingsFunc: func(ctx context.Context, productID string, from, to, now time.Time) ([]*Booking, error) {
+			return []*Booking{
+				{StartsAt: time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, 6, 3, 10, 0, 0, 0, time.UTC), Quantity: 1},
+				{StartsAt: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), Quantity: 1},
+			}, nil
+		},
+	}
+
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.GetAvailabilityRequest{
+		ProductId: "bike-1",
+		From:      timestamppb.New(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)),
+		To:        timestamppb.New(time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)),
+	}
+
+	resp, err := service.GetAvailability(ctx, req)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int32{1, 0, 2}
+	if len(resp.Days) != len(expected) {
+		t.Fatalf("Expected %d days, got %d", len(expected), len(resp.Days))
+	}
+
+	for i, day := range resp.Days {
+		if day.Available != expected[i] {
+			t.Errorf("Day %d: expected %d available, got %d", i, expected[i], day.Available)
+		}
+	}
+}
+
+func TestGetAvailability_NotBookable(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingConfigFunc: func(ctx context.Context, productID string) (*BookingConfig, error) {
+			return nil, ErrNotBookable
+		},
+	}
+
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.GetAvailabilityRequest{
+		ProductId: "book-1",
+		From:      timestamppb.New(bookingNow),
+		To:        timestamppb.New(bookingNow.AddDate(0, 0, 7)),
+	}
+
+	_, err := service.GetAvailability(ctx, req)
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition error, got %v", err)
+	}
+}
+
+func TestReserveBooking_Success(t *testing.T) {
+	var created *Booking
+	mockRepo := &MockRepository{
+		CreateBookingFunc: func(ctx context.Context, booking *Booking, now time.Time) (*Booking, error) {
+			created = booking
+			booking.ID = "booking-1"
+			booking.CreatedAt = now
+			return booking, nil
+		},
+	}
+
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.ReserveBookingRequest{
+		ProductId: "bike-1",
+		StartsAt:  timestamppb.New(bookingNow.Add(24 * time.Hour)),
+		EndsAt:    timestamppb.New(bookingNow.Add(48 * time.Hour)),
+		Reference: "checkout-42",
+	}
+
+	resp, err := service.ReserveBooking(ctx, req)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created.Quantity != 1 || created.Status != BookingHeld {
+		t.Errorf("Expected held booking of quantity 1, got %d %s", created.Quantity, created.Status)
+	}
+
+	if !resp.Booking.ExpiresAt.AsTime().Equal(bookingNow.Add(defaultBookingHold)) {
+		t.Errorf("Expected hold to expire at %v, got %v", bookingNow.Add(defaultBookingHold), resp.Booking.ExpiresAt.AsTime())
+	}
+}
+
+func TestReserveBooking_Conflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateBookingFunc: func(ctx context.Context, booking *Booking, now time.Time) (*Booking, error) {
+			return nil, ErrBookingUnavailable
+		},
+	}
+
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.ReserveBookingRequest{
+		ProductId: "bike-1",
+		StartsAt:  timestamppb.New(bookingNow.Add(24 * time.Hour)),
+		EndsAt:    timestamppb.New(bookingNow.Add(48 * time.Hour)),
+	}
+
+	_, err := service.ReserveBooking(ctx, req)
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists error, got %v", err)
+	}
+}
+
+func TestReserveBooking_InvalidPeriod(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.ReserveBookingRequest{
+		ProductId: "bike-1",
+		StartsAt:  timestamppb.New(bookingNow.Add(48 * time.Hour)),
+		EndsAt:    timestamppb.New(bookingNow.Add(24 * time.Hour)),
+	}
+
+	_, err := service.ReserveBooking(ctx, req)
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestConfirmBooking_ExpiredHold(t *testing.T) {
+	expired := bookingNow.Add(-time.Minute)
+	mockRepo := &MockRepository{
+		GetBookingFunc: func(ctx context.Context, id string) (*Booking, error) {
+			return &Booking{ID: id, Status: BookingHeld, ExpiresAt: &expired}, nil
+		},
+	}
+
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.ConfirmBooking(ctx, &pb.ConfirmBookingRequest{BookingId: "booking-1"})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition error, got %v", err)
+	}
+}
+
+func TestConfirmBooking_Success(t *testing.T) {
+	expires := bookingNow.Add(time.Minute)
+	mockRepo := &MockRepository{
+		GetBookingFunc: func(ctx context.Context, id string) (*Booking, error) {
+			return &Booking{ID: id, Status: BookingHeld, ExpiresAt: &expires}, nil
+		},
+		UpdateBookingStatusFunc: func(ctx context.Context, id, fromStatus, toStatus string) (*Booking, error) {
+			if fromStatus != BookingHeld || toStatus != BookingConfirmed {
+				t.Errorf("Unexpected transition %s -> %s", fromStatus, toStatus)
+			}
+			return &Booking{ID: id, Status: toStatus}, nil
+		},
+	}
+
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.ConfirmBooking(ctx, &pb.ConfirmBookingRequest{BookingId: "booking-1"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Booking.Status != BookingConfirmed {
+		t.Errorf("Expected status %s, got %s", BookingConfirmed, resp.Booking.Status)
+	}
+}
+
+func TestCancelBooking_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingFunc: func(ctx context.Context, id string) (*Booking, error) {
+			return nil, ErrBookingNotFound
+		},
+	}
+
+	service := setupBookingService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.CancelBooking(ctx, &pb.CancelBookingRequest{BookingId: "missing"})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrNotBundle is returned when a product has no bundle definition
+var ErrNotBundle = errors.New("product is not a bundle")
+
+// Bundle is a product sold as a kit of component products
+type Bundle struct {
+	ProductID string
+	// DiscountPercent is taken off the sum of the component prices
+	DiscountPercent float64
+	Components      []*BundleComponent
+}
+
+// BundleComponent is a component product and the quantity of it in one bundle
+type BundleComponent struct {
+	ProductID string
+	Quantity  int32
+	// Product is populated when the bundle is read
+	Product *Product
+}
+
+// ListPrice returns the sum of the component list prices
+func (b *Bundle) ListPrice() float64 {
+	var total float64
+	for _, c := range b.Components {
+		total += c.Product.Price * float64(c.Quantity)
+	}
+	return roundCents(total)
+}
+
+// Price returns the bundle price at now: the sum of the component effective
+// prices less the bundle discount
+func (b *Bundle) Price(now time.Time) float64 {
+	var total float64
+	for _, c := range b.Components {
+		total += c.Product.EffectivePrice(now) * float64(c.Quantity)
+	}
+	return roundCents(total * (1 - b.DiscountPercent/100))
+}
+
+// Stock returns how many complete bundles the component stock allows.
+// Digital components never limit it.
+func (b *Bundle) Stock() int32 {
+	if len(b.Components) == 0 {
+		return 0
+	}
+	stock := int32(math.MaxInt32)
+	for _, c := range b.Components {
+		if !c.Product.TracksStock() {
+			continue
+		}
+		if available := c.Product.Stock / c.Quantity; available < stock {
+			stock = available
+		}
+	}
+	return stock
+}
+
+// SetBundle creates or replaces the bundle definition of a product. A bundle
+// without components is removed, turning the product back into a simple one.
+func (r *postgresRepository) SetBundle(ctx context.Context, bundle *Bundle) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if len(bundle.Components) == 0 {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM product_bundles WHERE product_id = $1", bundle.ProductID); err != nil {
+			r.log.Error(ctx, "Failed to remove bundle", map[string]interface{}{"error": err.Error(), "product_id": bundle.ProductID})
+			return fmt.Errorf("failed to remove bundle: %w", err)
+		}
+	} else {
+		upsertQuery := `
+			INSERT INTO product_bundles (product_id, discount_percent)
+			VALUES ($1, $2)
+			ON CONFLICT (product_id) DO UPDATE SET discount_percent = EXCLUDED.discount_percent
+		`
+		if _, err := tx.ExecContext(ctx, upsertQuery, bundle.ProductID, bundle.DiscountPercent); err != nil {
+			r.log.Error(ctx, "Failed to save bundle", map[string]interface{}{"error": err.Error(), "product_id": bundle.ProductID})
+			return fmt.Errorf("failed to save bundle: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM product_bundle_components WHERE bundle_id = $1", bundle.ProductID); err != nil {
+			r.log.Error(ctx, "Failed to clear bundle components", map[string]interface{}{"error": err.Error(), "product_id": bundle.ProductID})
+			return fmt.Errorf("failed to clear bundle components: %w", err)
+		}
+
+		insertQuery := `
+			INSERT INTO product_bundle_components (bundle_id, component_id, quantity, position)
+			VALUES ($1, $2, $3, $4)
+		`
+		for i, c := range bundle.Components {
+			if _, err := tx.ExecContext(ctx, insertQuery, bundle.ProductID, c.ProductID, c.Quantity, i); err != nil {
+				r.log.Error(ctx, "Failed to insert bundle component", map[string]interface{}{"error": err.Error(), "product_id": bundle.ProductID, "component_id": c.ProductID})
+				return fmt.Errorf("failed to insert bundle component: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit bundle", map[string]interface{}{"error": err.Error(), "product_id": bundle.ProductID})
+		return fmt.Errorf("failed to commit bundle: %w", err)
+	}
+
+	r.log.Info(ctx, "Bundle updated successfully", map[string]interface{}{"product_id": bundle.ProductID, "components": len(bundle.Components)})
+	return nil
+}
+
+// GetBundle retrieves the bundle definition of a product with its component
+// products in position order. It returns ErrNotBundle for simple products.
+func (r *postgresRepository) GetBundle(ctx context.Context, productID string) (*Bundle, error) {
+	bundle := &Bundle{ProductID: productID}
+	err := r.db.QueryRowContext(ctx, "SELECT discount_percent FROM product_bundles WHERE product_id = $1", productID).
+		Scan(&bundle.DiscountPercent)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotBundle
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get bundle", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get bundle: %w", err)
+	}
+
+	query := `
+		SELECT bc.quantity, ` + productColumnsWithAlias("p") + `
+		FROM product_bundle_components bc
+		JOIN products p ON p.id = bc.component_id
+		WHERE bc.bundle_id = $1
+		ORDER BY bc.position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get bundle components", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		component := &BundleComponent{}
+		product, err := scanProduct(rows, &component.Quantity)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan bundle component", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan bundle component: %w", err)
+		}
+		component.ProductID = product.ID
+		component.Product = product
+		bundle.Components = append(bundle.Components, component)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating bundle components", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating bundle components: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// IsBundleComponent reports whether a product is a component of any bundle
+func (r *postgresRepository) IsBundleComponent(ctx context.Context, productID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM product_bundle_components WHERE component_id = $1)", productID).Scan(&exists)
+	if err != nil {
+		r.log.Error(ctx, "Failed to check bundle membership", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return false, fmt.Errorf("failed to check bundle membership: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,147 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBundleComponents caps the number of component products per bundle
+const maxBundleComponents = 20
+
+// SetBundle makes a product a bundle of component products, or removes the
+// bundle when no components are given. Bundles cannot be nested.
+func (s *Service) SetBundle(ctx context.Context, req *pb.SetBundleRequest) (*pb.SetBundleResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Set bundle failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if len(req.Components) > maxBundleComponents {
+		s.log.Warn(ctx, "Set bundle failed: too many components", map[string]interface{}{"count": len(req.Components)})
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d bundle components are allowed", maxBundleComponents)
+	}
+	if req.DiscountPercent < 0 || req.DiscountPercent >= 100 {
+		return nil, status.Error(codes.InvalidArgument, "discount_percent must be at least 0 and below 100")
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{ProductID: req.ProductId, DiscountPercent: req.DiscountPercent}
+	if len(req.Components) > 0 {
+		isComponent, err := s.repo.IsBundleComponent(ctx, req.ProductId)
+		if err != nil {
+			s.log.Error(ctx, "Failed to check bundle membership", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+			return nil, status.Error(codes.Internal, "failed to set bundle")
+		}
+		if isComponent {
+			s.log.Warn(ctx, "Set bundle failed: product is a bundle component", map[string]interface{}{"product_id": req.ProductId})
+			return nil, status.Error(codes.FailedPrecondition, "product is a component of another bundle")
+		}
+	}
+
+	seen := make(map[string]bool, len(req.Components))
+	for _, c := range req.Components {
+		switch {
+		case c.ProductId == "":
+			return nil, status.Error(codes.InvalidArgument, "component product_id cannot be empty")
+		case c.ProductId == req.ProductId:
+			s.log.Warn(ctx, "Set bundle failed: self reference", map[string]interface{}{"product_id": req.ProductId})
+			return nil, status.Error(codes.InvalidArgument, "bundle cannot contain itself")
+		case c.Quantity <= 0:
+			return nil, status.Error(codes.InvalidArgument, "component quantity must be positive")
+		case seen[c.ProductId]:
+			return nil, status.Errorf(codes.InvalidArgument, "component %s is listed more than once", c.ProductId)
+		}
+		seen[c.ProductId] = true
+
+		if _, err := s.repo.GetByID(ctx, c.ProductId); err != nil {
+			if !errors.Is(err, ErrProductNotFound) {
+				return nil, s.productLookupError(ctx, err, c.ProductId)
+			}
+			s.log.Warn(ctx, "Bundle component not found", map[string]interface{}{"component_id": c.ProductId})
+			return nil, status.Errorf(codes.NotFound, "component product %s not found", c.ProductId)
+		}
+		if _, err := s.repo.GetBundle(ctx, c.ProductId); err == nil {
+			s.log.Warn(ctx, "Set bundle failed: nested bundle", map[string]interface{}{"product_id": req.ProductId, "component_id": c.ProductId})
+			return nil, status.Errorf(codes.InvalidArgument, "component %s is itself a bundle", c.ProductId)
+		} else if !errors.Is(err, ErrNotBundle) {
+			s.log.Error(ctx, "Failed to get component bundle", map[string]interface{}{"error": err.Error(), "component_id": c.ProductId})
+			return nil, status.Error(codes.Internal, "failed to set bundle")
+		}
+
+		bundle.Components = append(bundle.Components, &BundleComponent{ProductID: c.ProductId, Quantity: c.Quantity})
+	}
+
+	if err := s.repo.SetBundle(ctx, bundle); err != nil {
+		s.log.Error(ctx, "Failed to set bundle", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to set bundle")
+	}
+
+	if len(bundle.Components) == 0 {
+		s.log.Info(ctx, "Bundle removed successfully", map[string]interface{}{"product_id": req.ProductId})
+		return &pb.SetBundleResponse{}, nil
+	}
+
+	saved, err := s.repo.GetBundle(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get bundle", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get bundle")
+	}
+
+	s.log.Info(ctx, "Bundle set successfully", map[string]interface{}{"product_id": req.ProductId, "components": len(saved.Components)})
+
+	return &pb.SetBundleResponse{
+		Bundle: toProtoBundle(saved, s.now()),
+	}, nil
+}
+
+// GetBundle retrieves a bundle with its computed price and stock
+func (s *Service) GetBundle(ctx context.Context, req *pb.GetBundleRequest) (*pb.GetBundleResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get bundle failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	bundle, err := s.repo.GetBundle(ctx, req.ProductId)
+	if errors.Is(err, ErrNotBundle) {
+		return nil, status.Error(codes.NotFound, "product is not a bundle")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get bundle", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get bundle")
+	}
+
+	return &pb.GetBundleResponse{
+		Bundle: toProtoBundle(bundle, s.now()),
+	}, nil
+}
+
+// toProtoBundle converts a bundle to protobuf, pricing it at now
+func toProtoBundle(b *Bundle, now time.Time) *pb.Bundle {
+	components := make([]*pb.BundleComponent, len(b.Components))
+	for i, c := range b.Components {
+		components[i] = &pb.BundleComponent{
+			ProductId: c.ProductID,
+			Quantity:  c.Quantity,
+			Product:   toProtoProduct(c.Product, now),
+		}
+	}
+	return &pb.Bundle{
+		ProductId:       b.ProductID,
+		DiscountPercent: b.DiscountPercent,
+		Components:      components,
+		ListPrice:       b.ListPrice(),
+		Price:           b.Price(now),
+		Stock:           b.Stock(),
+	}
+}
@@ -0,0 +1,177 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bundleRepo serves the products kit, cable and charger, where kit may be
+// made a bundle of the others and any product in bundles is a bundle
+func bundleRepo(bundles map[string]*Bundle) *MockRepository {
+	products := map[string]*Product{
+		"kit":     {ID: "kit", Name: "Starter Kit", Price: 50},
+		"cable":   {ID: "cable", Name: "Cable", Price: 10, Stock: 7},
+		"charger": {ID: "charger", Name: "Charger", Price: 25, Stock: 4},
+	}
+	return &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if p, ok := products[id]; ok {
+				return p, nil
+			}
+			return nil, ErrProductNotFound
+		},
+		IsBundleComponentFunc: func(ctx context.Context, productID string) (bool, error) {
+			for _, b := range bundles {
+				for _, c := range b.Components {
+					if c.ProductID == productID {
+						return true, nil
+					}
+				}
+			}
+			return false, nil
+		},
+		SetBundleFunc: func(ctx context.Context, bundle *Bundle) error {
+			if len(bundle.Components) == 0 {
+				delete(bundles, bundle.ProductID)
+				return nil
+			}
+			for _, c := range bundle.Components {
+				c.Product = products[c.ProductID]
+			}
+			bundles[bundle.ProductID] = bundle
+			return nil
+		},
+		GetBundleFunc: func(ctx context.Context, productID string) (*Bundle, error) {
+			if b, ok := bundles[productID]; ok {
+				return b, nil
+			}
+			return nil, ErrNotBundle
+		},
+	}
+}
+
+func TestSetBundle_ComputesPriceAndStock(t *testing.T) {
+	bundles := map[string]*Bundle{}
+	service := setupService(bundleRepo(bundles))
+	ctx := context.Background()
+
+	resp, err := service.SetBundle(ctx, &pb.SetBundleRequest{
+		ProductId: "kit",
+		Components: []*pb.BundleComponent{
+			{ProductId: "cable", Quantity: 2},
+			{ProductId: "charger", Quantity: 1},
+		},
+		DiscountPercent: 10,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	b := resp.Bundle
+	if b.ListPrice != 45 || b.Price != 40.5 {
+		t.Errorf("Expected list price 45 and price 40.5, got %v and %v", b.ListPrice, b.Price)
+	}
+	// 7 cables make 3 kits, 4 chargers make 4
+	if b.Stock != 3 {
+		t.Errorf("Expected stock 3, got %d", b.Stock)
+	}
+	if len(b.Components) != 2 || b.Components[0].Product.Name != "Cable" {
+		t.Errorf("Unexpected components %v", b.Components)
+	}
+
+	got, err := service.GetBundle(ctx, &pb.GetBundleRequest{ProductId: "kit"})
+	if err != nil || got.Bundle.Price != 40.5 {
+		t.Errorf("Expected bundle from GetBundle, got %v, %v", got, err)
+	}
+}
+
+func TestSetBundle_EmptyRemovesBundle(t *testing.T) {
+	bundles := map[string]*Bundle{
+		"kit": {ProductID: "kit", Components: []*BundleComponent{{ProductID: "cable", Quantity: 1}}},
+	}
+	service := setupService(bundleRepo(bundles))
+	ctx := context.Background()
+
+	resp, err := service.SetBundle(ctx, &pb.SetBundleRequest{ProductId: "kit"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Bundle != nil {
+		t.Errorf("Expected no bundle, got %v", resp.Bundle)
+	}
+
+	_, err = service.GetBundle(ctx, &pb.GetBundleRequest{ProductId: "kit"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestSetBundle_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		bundles map[string]*Bundle
+		req     *pb.SetBundleRequest
+		code    codes.Code
+	}{
+		{"missing product", nil, &pb.SetBundleRequest{}, codes.InvalidArgument},
+		{"unknown product", nil, &pb.SetBundleRequest{ProductId: "nope"}, codes.NotFound},
+		{"discount too high", nil, &pb.SetBundleRequest{ProductId: "kit", DiscountPercent: 100}, codes.InvalidArgument},
+		{"self reference", nil, &pb.SetBundleRequest{ProductId: "kit", Components: []*pb.BundleComponent{{ProductId: "kit", Quantity: 1}}}, codes.InvalidArgument},
+		{"zero quantity", nil, &pb.SetBundleRequest{ProductId: "kit", Components: []*pb.BundleComponent{{ProductId: "cable"}}}, codes.InvalidArgument},
+		{"duplicate component", nil, &pb.SetBundleRequest{ProductId: "kit", Components: []*pb.BundleComponent{
+			{ProductId: "cable", Quantity: 1}, {ProductId: "cable", Quantity: 2},
+		}}, codes.InvalidArgument},
+		{"unknown component", nil, &pb.SetBundleRequest{ProductId: "kit", Components: []*pb.BundleComponent{{ProductId: "nope", Quantity: 1}}}, codes.NotFound},
+		{"nested bundle", map[string]*Bundle{
+			"charger": {ProductID: "charger", Components: []*BundleComponent{{ProductID: "cable", Quantity: 1}}},
+		}, &pb.SetBundleRequest{ProductId: "kit", Components: []*pb.BundleComponent{{ProductId: "charger", Quantity: 1}}}, codes.InvalidArgument},
+		{"product is a component", map[string]*Bundle{
+			"charger": {ProductID: "charger", Components: []*BundleComponent{{ProductID: "kit", Quantity: 1}}},
+		}, &pb.SetBundleRequest{ProductId: "kit", Components: []*pb.BundleComponent{{ProductId: "cable", Quantity: 1}}}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundles := tt.bundles
+			if bundles == nil {
+				bundles = map[string]*Bundle{}
+			}
+			service := setupService(bundleRepo(bundles))
+
+			_, err := service.SetBundle(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestBundle_PriceUsesActiveSales(t *testing.T) {
+	now := time.Now()
+	salePrice := 8.0
+	bundle := &Bundle{
+		DiscountPercent: 50,
+		Components: []*BundleComponent{
+			{Quantity: 3, Product: &Product{Price: 10, SalePrice: &salePrice, Stock: 2}},
+		},
+	}
+
+	if price := bundle.Price(now); price != 12 {
+		t.Errorf("Expected price 12, got %v", price)
+	}
+	if list := bundle.ListPrice(); list != 30 {
+		t.Errorf("Expected list price 30, got %v", list)
+	}
+	// 2 in stock cannot fill one bundle of 3
+	if stock := bundle.Stock(); stock != 0 {
+		t.Errorf("Expected stock 0, got %d", stock)
+	}
+	if stock := (&Bundle{}).Stock(); stock != 0 {
+		t.Errorf("Expected empty bundle stock 0, got %d", stock)
+	}
+}
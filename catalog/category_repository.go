@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+)
+
+// CategoryStats summarizes the products of one category. Products without a
+// category are reported under "".
+type CategoryStats struct {
+	Category     string
+	ProductCount int32
+	MinPrice     float64
+	MaxPrice     float64
+	TotalStock   int64
+}
+
+// GetCategoryStats computes the statistics of every category in one query,
+// ordered by category. Drafts are left out unless includeDrafts is set.
+func (r *postgresRepository) GetCategoryStats(ctx context.Context, includeDrafts bool) ([]*CategoryStats, error) {
+	query := `
+		SELECT COALESCE(category, ''), COUNT(*), MIN(price), MAX(price), COALESCE(SUM(stock), 0)
+		FROM products
+		WHERE $1 OR status = '` + ProductStatusActive + `'
+		GROUP BY COALESCE(category, '')
+		ORDER BY COALESCE(category, '')
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, includeDrafts)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get category stats", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get category stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*CategoryStats{}
+	for rows.Next() {
+		s := &CategoryStats{}
+		if err := rows.Scan(&s.Category, &s.ProductCount, &s.MinPrice, &s.MaxPrice, &s.TotalStock); err != nil {
+			r.log.Error(ctx, "Failed to scan category stats", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan category stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating category stats", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating category stats: %w", err)
+	}
+
+	return stats, nil
+}
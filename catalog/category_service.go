@@ -0,0 +1,31 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetCategoryStats returns the product count, price range and total stock of
+// every category, for navigation menus and admin dashboards
+func (s *Service) GetCategoryStats(ctx context.Context, req *pb.GetCategoryStatsRequest) (*pb.GetCategoryStatsResponse, error) {
+	stats, err := s.repo.GetCategoryStats(ctx, req.IncludeDrafts)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get category stats", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to get category stats")
+	}
+
+	resp := &pb.GetCategoryStatsResponse{Categories: make([]*pb.CategoryStats, len(stats))}
+	for i, c := range stats {
+		resp.Categories[i] = &pb.CategoryStats{
+			Category:     c.Category,
+			ProductCount: c.ProductCount,
+			MinPrice:     c.MinPrice,
+			MaxPrice:     c.MaxPrice,
+			TotalStock:   c.TotalStock,
+		}
+	}
+	return resp, nil
+}
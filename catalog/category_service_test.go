@@ -0,0 +1,53 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetCategoryStats_Success(t *testing.T) {
+	var gotIncludeDrafts bool
+	mockRepo := &MockRepository{
+		GetCategoryStatsFunc: func(ctx context.Context, includeDrafts bool) ([]*CategoryStats, error) {
+			gotIncludeDrafts = includeDrafts
+			return []*CategoryStats{
+				{Category: "", ProductCount: 1, MinPrice: 5, MaxPrice: 5, TotalStock: 3},
+				{Category: "Home", ProductCount: 4, MinPrice: 9.99, MaxPrice: 120, TotalStock: 57},
+			}, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.GetCategoryStats(context.Background(), &pb.GetCategoryStatsRequest{IncludeDrafts: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !gotIncludeDrafts {
+		t.Error("Expected include_drafts to be passed to the repository")
+	}
+	if len(resp.Categories) != 2 {
+		t.Fatalf("Expected 2 categories, got %d", len(resp.Categories))
+	}
+	if c := resp.Categories[1]; c.Category != "Home" || c.ProductCount != 4 || c.MinPrice != 9.99 || c.MaxPrice != 120 || c.TotalStock != 57 {
+		t.Errorf("Unexpected category stats %v", c)
+	}
+}
+
+func TestGetCategoryStats_Error(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetCategoryStatsFunc: func(ctx context.Context, includeDrafts bool) ([]*CategoryStats, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.GetCategoryStats(context.Background(), &pb.GetCategoryStatsRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Clone copies a product into a new DRAFT product with the given SKU, together
+// with its images, price tiers and translations, and records the creation in
+// the audit log. An empty name keeps the source name. Stock starts at zero
+// and barcodes and ratings are not copied.
+func (r *postgresRepository) Clone(ctx context.Context, sourceID, sku, name, actor string) (*Product, error) {
+	id := uuid.New().String()
+	now := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO products (id, name, description, price, sku, stock, category, created_at, updated_at, description_blocks,
+			sale_price, sale_starts_at, sale_ends_at, low_stock_threshold, product_type,
+			weight_kg, length_cm, width_cm, height_cm, shipping_class, min_order_qty, max_order_qty, qty_increment, vendor_id, status)
+		SELECT $1, COALESCE(NULLIF($2, ''), name), description, price, $3, 0, category, $4, $4, description_blocks,
+			sale_price, sale_starts_at, sale_ends_at, low_stock_threshold, product_type,
+			weight_kg, length_cm, width_cm, height_cm, shipping_class, min_order_qty, max_order_qty, qty_increment, vendor_id, $5
+		FROM products
+		WHERE id = $6
+	`
+
+	result, err := tx.ExecContext(ctx, query, id, name, sku, now, ProductStatusDraft, sourceID)
+	if err != nil {
+		if pgerr.IsUniqueViolation(err, skuConstraint) {
+			return nil, ErrDuplicateSKU
+		}
+		r.log.Error(ctx, "Failed to clone product", map[string]interface{}{"error": err.Error(), "source_id": sourceID})
+		return nil, fmt.Errorf("failed to clone product: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil, ErrProductNotFound
+	}
+
+	copies := []struct{ what, query string }{
+		{"images", `
+			INSERT INTO product_images (product_id, url, alt_text, position, is_primary, width, height,
+				validation_status, validation_errors, alt_text_generated, needs_review, processed_at)
+			SELECT $1, url, alt_text, position, is_primary, width, height,
+				validation_status, validation_errors, alt_text_generated, needs_review, processed_at
+			FROM product_images
+			WHERE product_id = $2
+		`},
+		{"price tiers", `
+			INSERT INTO product_price_tiers (product_id, min_quantity, unit_price)
+			SELECT $1, min_quantity, unit_price
+			FROM product_price_tiers
+			WHERE product_id = $2
+		`},
+		{"translations", `
+			INSERT INTO product_translations (product_id, locale, name, description)
+			SELECT $1, locale, name, description
+			FROM product_translations
+			WHERE product_id = $2
+		`},
+	}
+	for _, c := range copies {
+		if _, err := tx.ExecContext(ctx, c.query, id, sourceID); err != nil {
+			r.log.Error(ctx, "Failed to copy product "+c.what, map[string]interface{}{"error": err.Error(), "source_id": sourceID})
+			return nil, fmt.Errorf("failed to copy product %s: %w", c.what, err)
+		}
+	}
+
+	err = recordPriceChange(ctx, tx, id, nil, actor, now)
+
+	var clone *Product
+	if err == nil {
+		clone, err = scanProduct(tx.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE id = $1", id))
+	}
+	if err == nil {
+		changes := append([]FieldChange{{Field: "cloned_from", New: sourceID}}, diffProducts(nil, clone)...)
+		err = recordProductAudit(ctx, tx, id, AuditActionCreate, actor, changes, now)
+		if err == nil {
+			err = recordProductEvent(ctx, tx, id, AuditActionCreate, actor, changes, now)
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to clone product", map[string]interface{}{"error": err.Error(), "source_id": sourceID})
+		return nil, fmt.Errorf("failed to clone product: %w", err)
+	}
+
+	r.log.Info(ctx, "Product cloned", map[string]interface{}{"product_id": id, "source_id": sourceID, "sku": sku, "actor": actor})
+	return clone, nil
+}
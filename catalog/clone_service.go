@@ -0,0 +1,61 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CloneProduct creates a DRAFT copy of a product under a new SKU, so similar
+// products can be created by editing the copy and making it ACTIVE
+func (s *Service) CloneProduct(ctx context.Context, req *pb.CloneProductRequest) (*pb.CloneProductResponse, error) {
+	if req.SourceId == "" {
+		s.log.Warn(ctx, "Clone product failed: source ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "source_id is required")
+	}
+	sku := strings.TrimSpace(req.Sku)
+	if sku == "" {
+		s.log.Warn(ctx, "Clone product failed: SKU is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+	if len(sku) > maxSKULength {
+		return nil, status.Errorf(codes.InvalidArgument, "sku cannot exceed %d characters", maxSKULength)
+	}
+	name := strings.TrimSpace(req.Name)
+
+	if err := s.authorizeVendorByID(ctx, req.SourceId); err != nil {
+		return nil, err
+	}
+
+	// Former SKUs count as taken too
+	_, err := s.repo.GetBySKU(ctx, sku)
+	if err == nil {
+		s.log.Warn(ctx, "Clone product failed: SKU already used", map[string]interface{}{"sku": sku})
+		return nil, status.Error(codes.AlreadyExists, "sku is already used by another product")
+	}
+	if !errors.Is(err, ErrProductNotFound) {
+		s.log.Error(ctx, "Failed to check SKU", map[string]interface{}{"error": err.Error(), "sku": sku})
+		return nil, status.Error(codes.Internal, "failed to clone product")
+	}
+
+	clone, err := s.repo.Clone(ctx, req.SourceId, sku, name, actorFromContext(ctx))
+	switch {
+	case errors.Is(err, ErrProductNotFound):
+		return nil, status.Error(codes.NotFound, "product not found")
+	case errors.Is(err, ErrDuplicateSKU):
+		return nil, status.Error(codes.AlreadyExists, "sku is already used by another product")
+	case err != nil:
+		s.log.Error(ctx, "Failed to clone product", map[string]interface{}{"error": err.Error(), "source_id": req.SourceId})
+		return nil, status.Error(codes.Internal, "failed to clone product")
+	}
+
+	s.log.Info(ctx, "Product cloned successfully", map[string]interface{}{"product_id": clone.ID, "source_id": req.SourceId, "sku": sku})
+
+	return &pb.CloneProductResponse{
+		Product: toProtoProduct(clone, s.now()),
+	}, nil
+}
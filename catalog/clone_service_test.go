@@ -0,0 +1,105 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCloneProduct_Success(t *testing.T) {
+	var gotSource, gotSKU, gotName, gotActor string
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CloneFunc: func(ctx context.Context, sourceID, sku, name, actor string) (*Product, error) {
+			gotSource, gotSKU, gotName, gotActor = sourceID, sku, name, actor
+			return &Product{ID: "prod-2", Name: "Lamp, blue", SKU: sku, Price: 40, Status: ProductStatusDraft}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "admin-1", Role: auth.RoleAdmin})
+
+	resp, err := service.CloneProduct(ctx, &pb.CloneProductRequest{SourceId: "prod-1", Sku: " LAMP-002 ", Name: "Lamp, blue"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Product.Status != ProductStatusDraft || resp.Product.Sku != "LAMP-002" {
+		t.Errorf("Unexpected product %v", resp.Product)
+	}
+	if gotSource != "prod-1" || gotSKU != "LAMP-002" || gotName != "Lamp, blue" || gotActor != "admin-1" {
+		t.Errorf("Unexpected clone arguments %s %s %s %s", gotSource, gotSKU, gotName, gotActor)
+	}
+}
+
+func TestCloneProduct_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			if sku == "LAMP-001" {
+				return &Product{ID: "prod-1", SKU: sku}, nil
+			}
+			return nil, ErrProductNotFound
+		},
+		CloneFunc: func(ctx context.Context, sourceID, sku, name, actor string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		name     string
+		req      *pb.CloneProductRequest
+		expected codes.Code
+	}{
+		{"missing source ID", &pb.CloneProductRequest{Sku: "LAMP-002"}, codes.InvalidArgument},
+		{"missing SKU", &pb.CloneProductRequest{SourceId: "prod-1", Sku: " "}, codes.InvalidArgument},
+		{"SKU taken", &pb.CloneProductRequest{SourceId: "prod-1", Sku: "LAMP-001"}, codes.AlreadyExists},
+		{"source not found", &pb.CloneProductRequest{SourceId: "missing", Sku: "LAMP-002"}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.CloneProduct(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestUpdateProduct_Status(t *testing.T) {
+	var saved *Product
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, SKU: "LAMP-002", ProductType: ProductTypePhysical, Status: ProductStatusDraft, Version: 1}, nil
+		},
+		UpdateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			saved = product
+			return product, nil
+		},
+	}
+	service := setupService(mockRepo)
+	req := &pb.UpdateProductRequest{Id: "prod-2", Name: "Lamp, blue", Price: 40, Version: 1}
+
+	if _, err := service.UpdateProduct(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Status != ProductStatusDraft {
+		t.Errorf("Expected empty status to keep DRAFT, got %s", saved.Status)
+	}
+
+	req.Status = ProductStatusActive
+	if _, err := service.UpdateProduct(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Status != ProductStatusActive {
+		t.Errorf("Expected ACTIVE, got %s", saved.Status)
+	}
+
+	req.Status = "ARCHIVED"
+	if _, err := service.UpdateProduct(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
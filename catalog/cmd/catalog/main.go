@@ -8,15 +8,31 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
+	"github.com/Ujjwaljain16/E-commerce-Backend/account"
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
 	"github.com/Ujjwaljain16/E-commerce-Backend/catalog"
 	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	notificationpb "github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/cache"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/captioning"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/evidence"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/imagecheck"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
-	_ "github.com/lib/pq"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/outbox"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	vendorspb "github.com/Ujjwaljain16/E-commerce-Backend/vendors/pb"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -35,7 +51,7 @@ func main() {
 	metricsPort := getEnv("METRICS_PORT", "9091")
 
 	// Connect to database
-	db, err := sql.Open("postgres", dbURL)
+	db, err := sql.Open("pgx", dbURL)
 	if err != nil {
 		log.Error(ctx, "Failed to connect to database", map[string]interface{}{
 			"error": err.Error(),
@@ -55,11 +71,264 @@ func main() {
 
 	// Create repository and service
 	repo := catalog.NewPostgresRepository(db, log)
-	service := catalog.NewService(repo, log)
+	service := catalog.NewService(repo, log).
+		WithDefaultLocale(getEnv("DEFAULT_LOCALE", catalog.DefaultLocale)).
+		WithSearchSimilarity(getEnvFloat("SEARCH_SIMILARITY_THRESHOLD", catalog.DefaultSearchSimilarity))
 
-	// Create gRPC server with metrics interceptor
+	// Enable presigned image uploads when object storage is configured
+	if bucket := os.Getenv("S3_BUCKET"); bucket != "" {
+		store, err := storage.NewS3Client(storage.Config{
+			Endpoint:  getEnv("S3_ENDPOINT", "http://localhost:9000"),
+			Region:    getEnv("S3_REGION", "us-east-1"),
+			Bucket:    bucket,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: getEnv("S3_PATH_STYLE", "true") == "true",
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to configure image storage", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		service.WithImageStore(store)
+		log.Info(ctx, "Image uploads enabled", map[string]interface{}{
+			"bucket": bucket,
+		})
+	}
+
+	// Admin RPCs need access tokens issued by the account service, with the
+	// roles of catalog.MethodRoles; other services, checkout for its stock
+	// reservations among them, call with SERVICE tokens
+	tokens := auth.TokenServiceFromEnv()
+
+	// PASETO tokens the account service issues with TOKEN_FORMAT=paseto are
+	// decrypted with the shared PASETO_KEY
+	if pasetoKey := os.Getenv("PASETO_KEY"); pasetoKey != "" {
+		key, err := auth.ParsePASETOKey(pasetoKey)
+		if err == nil {
+			_, err = tokens.WithPASETOKey(key)
+		}
+		if err != nil {
+			log.Error(ctx, "Invalid PASETO_KEY", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+	}
+
+	// Tokens revoked through the account service are refused, and the opaque
+	// tokens it issues with TOKEN_FORMAT=opaque are looked up
+	if redisAddr := os.Getenv("REDIS_ADDR"); redisAddr != "" {
+		revocationClient, err := cache.NewRedisClient(ctx, cache.Config{
+			Addr:     redisAddr,
+			Password: os.Getenv("REDIS_PASSWORD"),
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to connect to Redis", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer revocationClient.Close()
+		tokens.WithRevocationStore(auth.NewRedisRevocationStore(revocationClient, auth.DefaultRevocationPrefix))
+		tokens.WithTokenStore(auth.NewRedisTokenStore(revocationClient, auth.DefaultTokenStorePrefix))
+	}
+
+	// Tokens the account service signs with an RSA or ECDSA key are
+	// verified with the keys at JWKS_URL, fetched again when a token names a
+	// key they do not contain, so catalog needs no copy of the signing secret.
+	// With ACCOUNT_ADDR set, tokens none of the local keys validate, such as
+	// opaque tokens without REDIS_ADDR, are verified with the account service,
+	// whose answers are cached for TOKEN_CACHE_TTL.
+	var remote auth.RemoteVerifier
+	if accountAddr := os.Getenv("ACCOUNT_ADDR"); accountAddr != "" {
+		accountConn, err := grpc.NewClient(accountAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Error(ctx, "Failed to create account service client", map[string]interface{}{
+				"error": err.Error(),
+				"addr":  accountAddr,
+			})
+			os.Exit(1)
+		}
+		defer accountConn.Close()
+		remote = account.RemoteVerifier(accountpb.NewAccountServiceClient(accountConn))
+	}
+	verifier := auth.NewVerifier(tokens, remote, getEnvDuration("TOKEN_CACHE_TTL", auth.DefaultVerifierCacheTTL))
+	if jwksURL := os.Getenv("JWKS_URL"); jwksURL != "" {
+		verifier.WithJWKS(http.DefaultClient, jwksURL)
+		if err := verifier.LoadJWKS(ctx); err != nil {
+			log.Warn(ctx, "Failed to load JWKS, keys are fetched when tokens need them", map[string]interface{}{
+				"error": err.Error(),
+				"url":   jwksURL,
+			})
+		}
+	}
+
+	authenticator := auth.NewInterceptor(verifier, auth.InterceptorConfig{
+		ExemptMethods:  []string{"/"},
+		RequiredRoles:  catalog.MethodRoles(),
+		RequiredScopes: catalog.MethodScopes(),
+	})
+
+	// Enable digital product files and downloads when their private bucket is configured
+	if bucket := os.Getenv("DIGITAL_ASSETS_BUCKET"); bucket != "" {
+		store, err := storage.NewS3Client(storage.Config{
+			Endpoint:  getEnv("S3_ENDPOINT", "http://localhost:9000"),
+			Region:    getEnv("S3_REGION", "us-east-1"),
+			Bucket:    bucket,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: getEnv("S3_PATH_STYLE", "true") == "true",
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to configure digital asset storage", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		// Download callers present access tokens issued by the account service
+		service.WithDigitalAssets(store)
+		log.Info(ctx, "Digital downloads enabled", map[string]interface{}{
+			"bucket": bucket,
+		})
+	}
+
+	// Start the image validation and alt-text pipeline
+	pipelineCtx, stopPipeline := context.WithCancel(ctx)
+	defer stopPipeline()
+	if getEnv("IMAGE_PIPELINE_ENABLED", "false") == "true" {
+		processor, err := newImageProcessor(repo, log)
+		if err != nil {
+			log.Error(ctx, "Failed to configure image pipeline", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		interval := getEnvDuration("IMAGE_PIPELINE_INTERVAL", 30*time.Second)
+		go processor.Run(pipelineCtx, interval)
+		log.Info(ctx, "Image pipeline enabled", map[string]interface{}{
+			"interval": interval.String(),
+		})
+	}
+
+	// Anchor the price history chain periodically; anchors are signed when a key is set
+	auditKey := []byte(os.Getenv("AUDIT_ANCHOR_KEY"))
+	service.WithAuditKey(auditKey)
+	anchorInterval := getEnvDuration("AUDIT_ANCHOR_INTERVAL", time.Hour)
+	go catalog.NewAuditAnchorer(repo, auditKey, log).Run(pipelineCtx, anchorInterval)
+
+	// Return the stock of checkout reservations whose hold has expired
+	expiryInterval := getEnvDuration("RESERVATION_EXPIRY_INTERVAL", time.Minute)
+	go catalog.NewReservationExpirer(repo, log).Run(pipelineCtx, expiryInterval)
+
+	// Push product changes from the database to WatchProducts subscribers
+	changes := catalog.NewProductChangeHub(getEnvInt("WATCH_BUFFER_SIZE", 256))
+	service.WithProductChanges(changes)
+	go func() {
+		if err := catalog.NewProductChangeNotifier(dbURL, repo, changes, log).Run(pipelineCtx); err != nil {
+			changes.Close()
+		}
+	}()
+
+	// Notify back-in-stock subscribers through the notification service when
+	// it is configured; otherwise notifications are only logged
+	backInStock := catalog.NewLogBackInStockSender(log)
+	if notificationAddr := os.Getenv("NOTIFICATION_ADDR"); notificationAddr != "" {
+		notificationConn, err := grpc.NewClient(notificationAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Error(ctx, "Failed to create notification service client", map[string]interface{}{
+				"error": err.Error(),
+				"addr":  notificationAddr,
+			})
+			os.Exit(1)
+		}
+		defer notificationConn.Close()
+		backInStock = catalog.NewGRPCBackInStockSender(notificationpb.NewNotificationServiceClient(notificationConn))
+	}
+	backInStockInterval := getEnvDuration("BACK_IN_STOCK_SWEEP_INTERVAL", 5*time.Minute)
+	go catalog.NewBackInStockNotifier(repo, backInStock, changes, log).Run(pipelineCtx, backInStockInterval)
+
+	// Publish product events from the outbox to Kafka
+	if brokers := kafka.ParseBrokers(os.Getenv("KAFKA_BROKERS")); len(brokers) > 0 {
+		producer, err := kafka.NewProducer(kafka.Config{Brokers: brokers}, "catalog-service")
+		if err != nil {
+			log.Error(ctx, "Failed to configure Kafka producer", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer producer.Close()
+		interval := getEnvDuration("OUTBOX_RELAY_INTERVAL", time.Second)
+		go outbox.NewRelay(db, catalog.OutboxTable, producer, log).Run(pipelineCtx, interval)
+		log.Info(ctx, "Outbox relay enabled", map[string]interface{}{
+			"topic":    catalog.EventsTopic,
+			"interval": interval.String(),
+		})
+	} else {
+		log.Warn(ctx, "Product events are held in the outbox (KAFKA_BROKERS not set)", nil)
+	}
+
+	// Export signed compliance evidence bundles when an evidence bucket is configured
+	if bucket := os.Getenv("EVIDENCE_BUCKET"); bucket != "" {
+		exporter, err := newEvidenceExporter(bucket, catalog.EvidenceSources(repo, auditKey), log)
+		if err != nil {
+			log.Error(ctx, "Failed to configure evidence export", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		interval := getEnvDuration("EVIDENCE_EXPORT_INTERVAL", 24*time.Hour)
+		go exporter.Run(pipelineCtx, interval)
+		log.Info(ctx, "Evidence export enabled", map[string]interface{}{
+			"bucket":   bucket,
+			"interval": interval.String(),
+		})
+	}
+
+	// IP filtering: admin network allowlist and the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, catalog.AdminMethods)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Marketplace vendors may change their own products once the vendor
+	// service approved them; without it, vendor calls are refused
+	var vendors catalog.Vendors
+	if vendorAddr := os.Getenv("VENDOR_ADDR"); vendorAddr != "" {
+		vendorConn, err := grpc.NewClient(vendorAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Error(ctx, "Failed to create vendor service client", map[string]interface{}{
+				"error": err.Error(),
+				"addr":  vendorAddr,
+			})
+			os.Exit(1)
+		}
+		defer vendorConn.Close()
+		vendors = catalog.NewGRPCVendors(vendorspb.NewVendorServiceClient(vendorConn))
+	}
+	vendorGuard := catalog.NewVendorGuard(vendors, log)
+
+	// Create gRPC server with metrics, IP filter, auth and vendor interceptors
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(metrics.UnaryServerInterceptor("catalog-service")),
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("catalog-service"),
+			ipFilter.UnaryServerInterceptor(),
+			authenticator.UnaryServerInterceptor(),
+			vendorGuard.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			metrics.StreamServerInterceptor("catalog-service"),
+			ipFilter.StreamServerInterceptor(),
+			authenticator.StreamServerInterceptor(),
+			vendorGuard.StreamServerInterceptor(),
+		),
 	)
 	pb.RegisterCatalogServiceServer(grpcServer, service)
 
@@ -108,6 +377,10 @@ func main() {
 		<-sigChan
 
 		log.Info(ctx, "Shutting down gracefully", nil)
+		stopPipeline()
+		stopFilter()
+		// Watch streams never end on their own
+		changes.Close()
 		grpcServer.GracefulStop()
 		repo.Close()
 	}()
@@ -121,9 +394,94 @@ func main() {
 	}
 }
 
+// newImageProcessor builds the image pipeline from the environment
+func newImageProcessor(repo catalog.Repository, log *logger.Logger) (*catalog.ImageProcessor, error) {
+	cfg := catalog.DefaultImageProcessorConfig
+	cfg.Policy.MinWidth = getEnvInt("IMAGE_MIN_WIDTH", cfg.Policy.MinWidth)
+	cfg.Policy.MinHeight = getEnvInt("IMAGE_MIN_HEIGHT", cfg.Policy.MinHeight)
+	cfg.Policy.Background = getEnv("IMAGE_BACKGROUND_POLICY", imagecheck.BackgroundAny)
+	if err := cfg.Policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Alt text is only generated when a captioning provider is configured.
+	// Sandbox mode replaces it with a deterministic fake.
+	var captioner captioning.Provider
+	if getEnv("SANDBOX_MODE", "false") == "true" {
+		log.Info(context.Background(), "Sandbox mode: using fake captioning provider", nil)
+		captioner = captioning.NewFakeProvider()
+	} else if endpoint := os.Getenv("CAPTION_API_URL"); endpoint != "" {
+		provider, err := captioning.NewHTTPProvider(captioning.Config{
+			Endpoint: endpoint,
+			APIKey:   os.Getenv("CAPTION_API_KEY"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		captioner = provider
+	}
+
+	return catalog.NewImageProcessor(repo, catalog.NewHTTPImageFetcher(30*time.Second), captioner, cfg, log), nil
+}
+
+// evidenceConfigKeys are the settings recorded in each evidence bundle; secrets are fingerprinted
+var evidenceConfigKeys = []string{
+	"PORT", "METRICS_PORT", "DATABASE_URL", "REDIS_ADDR", "KAFKA_BROKERS",
+	"ADMIN_ALLOWED_IPS", "TRUSTED_PROXIES", "DENY_LIST_SYNC_INTERVAL", "VENDOR_ADDR", "NOTIFICATION_ADDR",
+	"S3_BUCKET", "S3_ENDPOINT", "S3_ACCESS_KEY", "S3_SECRET_KEY", "DIGITAL_ASSETS_BUCKET", "JWT_SECRET", "PASETO_KEY", "DEFAULT_LOCALE",
+	"IMAGE_PIPELINE_ENABLED", "AUDIT_ANCHOR_KEY", "AUDIT_ANCHOR_INTERVAL",
+}
+
+// newEvidenceExporter builds the compliance evidence exporter. Bundles are
+// written to EVIDENCE_BUCKET with the S3_* connection settings, signed with
+// EVIDENCE_SIGNING_KEY, and include the backup verification report at
+// BACKUP_REPORT_PATH when set.
+func newEvidenceExporter(bucket string, sources []evidence.Source, log *logger.Logger) (*evidence.Exporter, error) {
+	store, err := storage.NewS3Client(storage.Config{
+		Endpoint:  getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		Region:    getEnv("S3_REGION", "us-east-1"),
+		Bucket:    bucket,
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PathStyle: getEnv("S3_PATH_STYLE", "true") == "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sources = append(sources, evidence.ConfigSnapshot(evidenceConfigKeys))
+	if path := os.Getenv("BACKUP_REPORT_PATH"); path != "" {
+		sources = append(sources, evidence.FileSource("backup_verification", path))
+	}
+
+	key := []byte(os.Getenv("EVIDENCE_SIGNING_KEY"))
+	return evidence.NewExporter("catalog-service", store, key, log, sources...), nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,183 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrAssetNotFound is returned when a digital asset does not exist
+	ErrAssetNotFound = errors.New("digital asset not found")
+	// ErrEntitlementNotFound is returned when revoking an entitlement that is not active
+	ErrEntitlementNotFound = errors.New("entitlement not found")
+)
+
+// DigitalAsset is a file delivered with a digital product
+type DigitalAsset struct {
+	ID          string
+	ProductID   string
+	ObjectKey   string
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	CreatedAt   time.Time
+}
+
+// Entitlement allows a user to download a digital product
+type Entitlement struct {
+	UserID    string
+	ProductID string
+	Reference string
+	GrantedAt time.Time
+	RevokedAt *time.Time
+}
+
+const digitalAssetColumns = "id, product_id, object_key, file_name, content_type, size_bytes, created_at"
+
+const entitlementColumns = "user_id, product_id, reference, granted_at, revoked_at"
+
+// AddDigitalAsset records an uploaded file of a digital product
+func (r *postgresRepository) AddDigitalAsset(ctx context.Context, asset *DigitalAsset) (*DigitalAsset, error) {
+	query := `
+		INSERT INTO product_digital_assets (product_id, object_key, file_name, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + digitalAssetColumns
+
+	created, err := scanDigitalAsset(r.db.QueryRowContext(ctx, query,
+		asset.ProductID,
+		asset.ObjectKey,
+		asset.FileName,
+		asset.ContentType,
+		asset.SizeBytes,
+	))
+	if err != nil {
+		r.log.Error(ctx, "Failed to add digital asset", map[string]interface{}{"error": err.Error(), "product_id": asset.ProductID})
+		return nil, fmt.Errorf("failed to add digital asset: %w", err)
+	}
+
+	r.log.Info(ctx, "Digital asset added", map[string]interface{}{"asset_id": created.ID, "product_id": created.ProductID})
+	return created, nil
+}
+
+// GetDigitalAsset retrieves one file of a digital product
+func (r *postgresRepository) GetDigitalAsset(ctx context.Context, productID, assetID string) (*DigitalAsset, error) {
+	query := "SELECT " + digitalAssetColumns + " FROM product_digital_assets WHERE id = $1 AND product_id = $2"
+
+	asset, err := scanDigitalAsset(r.db.QueryRowContext(ctx, query, assetID, productID))
+	if err == sql.ErrNoRows {
+		return nil, ErrAssetNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get digital asset", map[string]interface{}{"error": err.Error(), "asset_id": assetID})
+		return nil, fmt.Errorf("failed to get digital asset: %w", err)
+	}
+	return asset, nil
+}
+
+// ListDigitalAssets retrieves the files of a digital product, oldest first
+func (r *postgresRepository) ListDigitalAssets(ctx context.Context, productID string) ([]*DigitalAsset, error) {
+	query := "SELECT " + digitalAssetColumns + " FROM product_digital_assets WHERE product_id = $1 ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list digital assets", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to list digital assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []*DigitalAsset{}
+	for rows.Next() {
+		asset, err := scanDigitalAsset(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan digital asset", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan digital asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating digital assets", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating digital assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// GrantEntitlement allows a user to download a digital product. Granting an
+// existing entitlement refreshes it and lifts any revocation.
+func (r *postgresRepository) GrantEntitlement(ctx context.Context, ent *Entitlement) (*Entitlement, error) {
+	query := `
+		INSERT INTO digital_entitlements (user_id, product_id, reference, granted_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, product_id) DO UPDATE
+		SET reference = EXCLUDED.reference, granted_at = EXCLUDED.granted_at, revoked_at = NULL
+		RETURNING ` + entitlementColumns
+
+	granted, err := scanEntitlement(r.db.QueryRowContext(ctx, query, ent.UserID, ent.ProductID, ent.Reference, ent.GrantedAt))
+	if err != nil {
+		r.log.Error(ctx, "Failed to grant entitlement", map[string]interface{}{"error": err.Error(), "user_id": ent.UserID, "product_id": ent.ProductID})
+		return nil, fmt.Errorf("failed to grant entitlement: %w", err)
+	}
+
+	r.log.Info(ctx, "Entitlement granted", map[string]interface{}{"user_id": granted.UserID, "product_id": granted.ProductID, "reference": granted.Reference})
+	return granted, nil
+}
+
+// RevokeEntitlement withdraws an active entitlement, for example after a refund
+func (r *postgresRepository) RevokeEntitlement(ctx context.Context, userID, productID string, now time.Time) (*Entitlement, error) {
+	query := `
+		UPDATE digital_entitlements SET revoked_at = $3
+		WHERE user_id = $1 AND product_id = $2 AND revoked_at IS NULL
+		RETURNING ` + entitlementColumns
+
+	revoked, err := scanEntitlement(r.db.QueryRowContext(ctx, query, userID, productID, now))
+	if err == sql.ErrNoRows {
+		return nil, ErrEntitlementNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to revoke entitlement", map[string]interface{}{"error": err.Error(), "user_id": userID, "product_id": productID})
+		return nil, fmt.Errorf("failed to revoke entitlement: %w", err)
+	}
+
+	r.log.Info(ctx, "Entitlement revoked", map[string]interface{}{"user_id": userID, "product_id": productID})
+	return revoked, nil
+}
+
+// HasEntitlement reports whether a user may download a digital product
+func (r *postgresRepository) HasEntitlement(ctx context.Context, userID, productID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM digital_entitlements WHERE user_id = $1 AND product_id = $2 AND revoked_at IS NULL)",
+		userID, productID,
+	).Scan(&exists)
+	if err != nil {
+		r.log.Error(ctx, "Failed to check entitlement", map[string]interface{}{"error": err.Error(), "user_id": userID, "product_id": productID})
+		return false, fmt.Errorf("failed to check entitlement: %w", err)
+	}
+	return exists, nil
+}
+
+// scanDigitalAsset reads a digital asset in digitalAssetColumns order
+func scanDigitalAsset(row rowScanner) (*DigitalAsset, error) {
+	a := &DigitalAsset{}
+	if err := row.Scan(&a.ID, &a.ProductID, &a.ObjectKey, &a.FileName, &a.ContentType, &a.SizeBytes, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// scanEntitlement reads an entitlement in entitlementColumns order
+func scanEntitlement(row rowScanner) (*Entitlement, error) {
+	e := &Entitlement{}
+	var revokedAt sql.NullTime
+	if err := row.Scan(&e.UserID, &e.ProductID, &e.Reference, &e.GrantedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		e.RevokedAt = &revokedAt.Time
+	}
+	return e, nil
+}
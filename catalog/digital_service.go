@@ -0,0 +1,313 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxDigitalAssetSize is the largest digital product file accepted, in bytes
+	maxDigitalAssetSize = 2 << 30
+	// assetUploadExpiry is how long a presigned asset upload URL stays valid
+	assetUploadExpiry = 30 * time.Minute
+	// downloadURLExpiry is how long a download link stays valid; links are
+	// issued per request, so a shared link stops working quickly
+	downloadURLExpiry = 5 * time.Minute
+	// maxFileNameLength is the longest asset file name accepted
+	maxFileNameLength = 255
+)
+
+// DigitalAssetStore is the private object storage holding digital product files
+type DigitalAssetStore interface {
+	PresignPut(key, contentType string, contentLength int64, expires time.Duration) (string, error)
+	PresignGet(key string, expires time.Duration) (string, error)
+	Stat(ctx context.Context, key string) (*storage.ObjectInfo, error)
+}
+
+// WithDigitalAssets enables digital product files and their downloads. Callers
+// of GenerateDownloadURL need an access token, as one of UserMethods.
+func (s *Service) WithDigitalAssets(store DigitalAssetStore) *Service {
+	s.assets = store
+	return s
+}
+
+// GetDigitalAssetUploadURL returns a presigned URL the admin uploads a digital product file to
+func (s *Service) GetDigitalAssetUploadURL(ctx context.Context, req *pb.GetDigitalAssetUploadURLRequest) (*pb.GetDigitalAssetUploadURLResponse, error) {
+	if s.assets == nil {
+		return nil, status.Error(codes.Unimplemented, "digital assets are not configured")
+	}
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get digital asset upload URL failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.ContentType == "" {
+		return nil, status.Error(codes.InvalidArgument, "content_type is required")
+	}
+	if req.ContentLength <= 0 || req.ContentLength > maxDigitalAssetSize {
+		s.log.Warn(ctx, "Get digital asset upload URL failed: invalid content length", map[string]interface{}{"content_length": req.ContentLength})
+		return nil, status.Errorf(codes.InvalidArgument, "content_length must be between 1 and %d bytes", int64(maxDigitalAssetSize))
+	}
+
+	product, err := s.digitalProduct(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	key := assetKeyPrefix(req.ProductId) + uuid.New().String()
+	uploadURL, err := s.assets.PresignPut(key, req.ContentType, req.ContentLength, assetUploadExpiry)
+	if err != nil {
+		s.log.Error(ctx, "Failed to presign digital asset upload", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to create upload URL")
+	}
+
+	s.log.Info(ctx, "Digital asset upload URL issued", map[string]interface{}{"product_id": req.ProductId, "object_key": key})
+
+	return &pb.GetDigitalAssetUploadURLResponse{
+		UploadUrl: uploadURL,
+		ObjectKey: key,
+		Headers: map[string]string{
+			"Content-Type":   req.ContentType,
+			"Content-Length": strconv.FormatInt(req.ContentLength, 10),
+		},
+		ExpiresAt: timestamppb.New(s.now().Add(assetUploadExpiry)),
+	}, nil
+}
+
+// AttachDigitalAsset verifies an uploaded file and adds it to a digital product
+func (s *Service) AttachDigitalAsset(ctx context.Context, req *pb.AttachDigitalAssetRequest) (*pb.AttachDigitalAssetResponse, error) {
+	if s.assets == nil {
+		return nil, status.Error(codes.Unimplemented, "digital assets are not configured")
+	}
+	if req.ProductId == "" || req.ObjectKey == "" {
+		s.log.Warn(ctx, "Attach digital asset failed: product ID and object key are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id and object_key are required")
+	}
+	if !strings.HasPrefix(req.ObjectKey, assetKeyPrefix(req.ProductId)) {
+		s.log.Warn(ctx, "Attach digital asset failed: object key belongs to another product", map[string]interface{}{"product_id": req.ProductId, "object_key": req.ObjectKey})
+		return nil, status.Error(codes.InvalidArgument, "object_key does not belong to this product")
+	}
+
+	fileName := strings.TrimSpace(req.FileName)
+	if fileName == "" || len(fileName) > maxFileNameLength || strings.ContainsAny(fileName, `/\`) {
+		return nil, status.Errorf(codes.InvalidArgument, "file_name must be 1 to %d characters without path separators", maxFileNameLength)
+	}
+
+	product, err := s.digitalProduct(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	info, err := s.assets.Stat(ctx, req.ObjectKey)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, status.Error(codes.FailedPrecondition, "file has not been uploaded")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to stat uploaded digital asset", map[string]interface{}{"error": err.Error(), "object_key": req.ObjectKey})
+		return nil, status.Error(codes.Internal, "failed to verify file")
+	}
+	if info.Size <= 0 || info.Size > maxDigitalAssetSize {
+		s.log.Warn(ctx, "Attach digital asset failed: invalid size", map[string]interface{}{"size": info.Size})
+		return nil, status.Error(codes.InvalidArgument, "uploaded file exceeds the size limit")
+	}
+
+	asset, err := s.repo.AddDigitalAsset(ctx, &DigitalAsset{
+		ProductID:   req.ProductId,
+		ObjectKey:   req.ObjectKey,
+		FileName:    fileName,
+		ContentType: info.ContentType,
+		SizeBytes:   info.Size,
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to attach digital asset", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to attach digital asset")
+	}
+
+	return &pb.AttachDigitalAssetResponse{
+		Asset: toProtoDigitalAsset(asset),
+	}, nil
+}
+
+// ListDigitalAssets lists the files of a digital product
+func (s *Service) ListDigitalAssets(ctx context.Context, req *pb.ListDigitalAssetsRequest) (*pb.ListDigitalAssetsResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "List digital assets failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	assets, err := s.repo.ListDigitalAssets(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list digital assets", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to list digital assets")
+	}
+
+	protoAssets := make([]*pb.DigitalAsset, len(assets))
+	for i, a := range assets {
+		protoAssets[i] = toProtoDigitalAsset(a)
+	}
+	return &pb.ListDigitalAssetsResponse{Assets: protoAssets}, nil
+}
+
+// GrantEntitlement allows a user to download a digital product
+func (s *Service) GrantEntitlement(ctx context.Context, req *pb.GrantEntitlementRequest) (*pb.GrantEntitlementResponse, error) {
+	if req.UserId == "" || req.ProductId == "" {
+		s.log.Warn(ctx, "Grant entitlement failed: user ID and product ID are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id and product_id are required")
+	}
+
+	if _, err := s.digitalProduct(ctx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	ent, err := s.repo.GrantEntitlement(ctx, &Entitlement{
+		UserID:    req.UserId,
+		ProductID: req.ProductId,
+		Reference: req.Reference,
+		GrantedAt: s.now(),
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to grant entitlement", map[string]interface{}{"error": err.Error(), "user_id": req.UserId, "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to grant entitlement")
+	}
+
+	return &pb.GrantEntitlementResponse{
+		Entitlement: toProtoEntitlement(ent),
+	}, nil
+}
+
+// RevokeEntitlement withdraws a user's access to a digital product
+func (s *Service) RevokeEntitlement(ctx context.Context, req *pb.RevokeEntitlementRequest) (*pb.RevokeEntitlementResponse, error) {
+	if req.UserId == "" || req.ProductId == "" {
+		s.log.Warn(ctx, "Revoke entitlement failed: user ID and product ID are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id and product_id are required")
+	}
+
+	ent, err := s.repo.RevokeEntitlement(ctx, req.UserId, req.ProductId, s.now())
+	if errors.Is(err, ErrEntitlementNotFound) {
+		return nil, status.Error(codes.NotFound, "entitlement not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to revoke entitlement", map[string]interface{}{"error": err.Error(), "user_id": req.UserId, "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to revoke entitlement")
+	}
+
+	return &pb.RevokeEntitlementResponse{
+		Entitlement: toProtoEntitlement(ent),
+	}, nil
+}
+
+// GenerateDownloadURL returns a short-lived download link for a file of a
+// digital product. The caller must hold an active entitlement; admins may
+// download any file.
+func (s *Service) GenerateDownloadURL(ctx context.Context, req *pb.GenerateDownloadURLRequest) (*pb.GenerateDownloadURLResponse, error) {
+	if s.assets == nil {
+		return nil, status.Error(codes.Unimplemented, "digital downloads are not configured")
+	}
+	if req.ProductId == "" || req.AssetId == "" {
+		s.log.Warn(ctx, "Generate download URL failed: product ID and asset ID are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id and asset_id are required")
+	}
+	if _, err := uuid.Parse(req.AssetId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid asset_id")
+	}
+
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+
+	asset, err := s.repo.GetDigitalAsset(ctx, req.ProductId, req.AssetId)
+	if errors.Is(err, ErrAssetNotFound) {
+		return nil, status.Error(codes.NotFound, "digital asset not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get digital asset", map[string]interface{}{"error": err.Error(), "asset_id": req.AssetId})
+		return nil, status.Error(codes.Internal, "failed to get digital asset")
+	}
+
+	if !auth.PolicyFromContext(ctx).Allows(claims, PermissionAssetDownload) {
+		entitled, err := s.repo.HasEntitlement(ctx, claims.UserID, req.ProductId)
+		if err != nil {
+			s.log.Error(ctx, "Failed to check entitlement", map[string]interface{}{"error": err.Error(), "user_id": claims.UserID, "product_id": req.ProductId})
+			return nil, status.Error(codes.Internal, "failed to check entitlement")
+		}
+		if !entitled {
+			s.log.Warn(ctx, "Download denied: no entitlement", map[string]interface{}{"user_id": claims.UserID, "product_id": req.ProductId})
+			return nil, status.Error(codes.PermissionDenied, "no entitlement to this product")
+		}
+	}
+
+	downloadURL, err := s.assets.PresignGet(asset.ObjectKey, downloadURLExpiry)
+	if err != nil {
+		s.log.Error(ctx, "Failed to presign download", map[string]interface{}{"error": err.Error(), "asset_id": asset.ID})
+		return nil, status.Error(codes.Internal, "failed to create download URL")
+	}
+
+	s.log.Info(ctx, "Download URL issued", map[string]interface{}{"user_id": claims.UserID, "product_id": req.ProductId, "asset_id": asset.ID})
+
+	return &pb.GenerateDownloadURLResponse{
+		DownloadUrl: downloadURL,
+		FileName:    asset.FileName,
+		ExpiresAt:   timestamppb.New(s.now().Add(downloadURLExpiry)),
+	}, nil
+}
+
+// digitalProduct loads a product and checks that it is digital
+func (s *Service) digitalProduct(ctx context.Context, productID string) (*Product, error) {
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, productID)
+	}
+	if product.ProductType != ProductTypeDigital {
+		return nil, status.Error(codes.FailedPrecondition, "product is not digital")
+	}
+	return product, nil
+}
+
+// assetKeyPrefix returns the object key prefix of a product's digital files
+func assetKeyPrefix(productID string) string {
+	return "digital/" + productID + "/"
+}
+
+// toProtoDigitalAsset converts a digital asset to protobuf
+func toProtoDigitalAsset(a *DigitalAsset) *pb.DigitalAsset {
+	return &pb.DigitalAsset{
+		Id:          a.ID,
+		ProductId:   a.ProductID,
+		ObjectKey:   a.ObjectKey,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		SizeBytes:   a.SizeBytes,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+	}
+}
+
+// toProtoEntitlement converts an entitlement to protobuf
+func toProtoEntitlement(e *Entitlement) *pb.Entitlement {
+	ent := &pb.Entitlement{
+		UserId:    e.UserID,
+		ProductId: e.ProductID,
+		Reference: e.Reference,
+		GrantedAt: timestamppb.New(e.GrantedAt),
+	}
+	if e.RevokedAt != nil {
+		ent.RevokedAt = timestamppb.New(*e.RevokedAt)
+	}
+	return ent
+}
@@ -0,0 +1,235 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testAssetID = "7b0e5f4a-9c1d-4e2b-8f3a-1d2c3b4a5e6f"
+
+// mockAssetStore is an in-memory DigitalAssetStore for testing
+type mockAssetStore struct {
+	objects map[string]*storage.ObjectInfo
+}
+
+func (m *mockAssetStore) PresignPut(key, contentType string, contentLength int64, expires time.Duration) (string, error) {
+	return "https://assets.test/" + key + "?upload", nil
+}
+
+func (m *mockAssetStore) PresignGet(key string, expires time.Duration) (string, error) {
+	return "https://assets.test/" + key + "?download", nil
+}
+
+func (m *mockAssetStore) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	if info, ok := m.objects[key]; ok {
+		return info, nil
+	}
+	return nil, storage.ErrObjectNotFound
+}
+
+// digitalRepo serves the digital product ebook, the physical product mug and
+// one ebook file; entitled lists the users holding an ebook entitlement
+func digitalRepo(entitled ...string) *MockRepository {
+	products := map[string]*Product{
+		"ebook": {ID: "ebook", Name: "Go Handbook", Price: 20, ProductType: ProductTypeDigital},
+		"mug":   {ID: "mug", Name: "Mug", Price: 8, Stock: 3, ProductType: ProductTypePhysical},
+	}
+	return &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if p, ok := products[id]; ok {
+				return p, nil
+			}
+			return nil, ErrProductNotFound
+		},
+		AddDigitalAssetFunc: func(ctx context.Context, asset *DigitalAsset) (*DigitalAsset, error) {
+			asset.ID = testAssetID
+			return asset, nil
+		},
+		GetDigitalAssetFunc: func(ctx context.Context, productID, assetID string) (*DigitalAsset, error) {
+			if productID != "ebook" || assetID != testAssetID {
+				return nil, ErrAssetNotFound
+			}
+			return &DigitalAsset{ID: testAssetID, ProductID: productID, ObjectKey: "digital/ebook/file", FileName: "handbook.pdf"}, nil
+		},
+		HasEntitlementFunc: func(ctx context.Context, userID, productID string) (bool, error) {
+			for _, u := range entitled {
+				if u == userID && productID == "ebook" {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+func setupDigitalService(repo Repository, store *mockAssetStore) *Service {
+	return setupService(repo).WithDigitalAssets(store)
+}
+
+// callerContext returns the context of a call the auth interceptor
+// authenticated for the user
+func callerContext(userID, role string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: userID, Role: role})
+}
+
+func TestGenerateDownloadURL_Entitlement(t *testing.T) {
+	service := setupDigitalService(digitalRepo("buyer"), &mockAssetStore{})
+	req := &pb.GenerateDownloadURLRequest{ProductId: "ebook", AssetId: testAssetID}
+
+	resp, err := service.GenerateDownloadURL(callerContext("buyer", auth.RoleUser), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasSuffix(resp.DownloadUrl, "digital/ebook/file?download") || resp.FileName != "handbook.pdf" || resp.ExpiresAt == nil {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *pb.GenerateDownloadURLRequest
+		code codes.Code
+	}{
+		{"admin without entitlement", callerContext("admin", auth.RoleAdmin), req, codes.OK},
+		{"not entitled", callerContext("browser", auth.RoleUser), req, codes.PermissionDenied},
+		{"no token", context.Background(), req, codes.Unauthenticated},
+		{"unknown asset", callerContext("buyer", auth.RoleUser), &pb.GenerateDownloadURLRequest{ProductId: "mug", AssetId: testAssetID}, codes.NotFound},
+		{"invalid asset id", callerContext("buyer", auth.RoleUser), &pb.GenerateDownloadURLRequest{ProductId: "ebook", AssetId: "file"}, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GenerateDownloadURL(tt.ctx, tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestGenerateDownloadURL_NotConfigured(t *testing.T) {
+	service := setupService(digitalRepo("buyer"))
+
+	_, err := service.GenerateDownloadURL(callerContext("buyer", auth.RoleUser), &pb.GenerateDownloadURLRequest{ProductId: "ebook", AssetId: testAssetID})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented, got %v", err)
+	}
+}
+
+func TestAttachDigitalAsset(t *testing.T) {
+	store := &mockAssetStore{objects: map[string]*storage.ObjectInfo{
+		"digital/ebook/upload": {ContentType: "application/pdf", Size: 4096},
+		"digital/mug/upload":   {ContentType: "application/pdf", Size: 4096},
+	}}
+	service := setupDigitalService(digitalRepo(), store)
+	ctx := context.Background()
+
+	resp, err := service.AttachDigitalAsset(ctx, &pb.AttachDigitalAssetRequest{ProductId: "ebook", ObjectKey: "digital/ebook/upload", FileName: " handbook.pdf "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if a := resp.Asset; a.FileName != "handbook.pdf" || a.ContentType != "application/pdf" || a.SizeBytes != 4096 {
+		t.Errorf("Unexpected asset %v", a)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.AttachDigitalAssetRequest
+		code codes.Code
+	}{
+		{"physical product", &pb.AttachDigitalAssetRequest{ProductId: "mug", ObjectKey: "digital/mug/upload", FileName: "a.pdf"}, codes.FailedPrecondition},
+		{"other product's key", &pb.AttachDigitalAssetRequest{ProductId: "ebook", ObjectKey: "digital/mug/upload", FileName: "a.pdf"}, codes.InvalidArgument},
+		{"path in file name", &pb.AttachDigitalAssetRequest{ProductId: "ebook", ObjectKey: "digital/ebook/upload", FileName: "../a.pdf"}, codes.InvalidArgument},
+		{"not uploaded", &pb.AttachDigitalAssetRequest{ProductId: "ebook", ObjectKey: "digital/ebook/missing", FileName: "a.pdf"}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AttachDigitalAsset(ctx, tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestGrantEntitlement_RequiresDigitalProduct(t *testing.T) {
+	mockRepo := digitalRepo()
+	mockRepo.GrantEntitlementFunc = func(ctx context.Context, ent *Entitlement) (*Entitlement, error) {
+		return ent, nil
+	}
+	service := setupDigitalService(mockRepo, &mockAssetStore{})
+	ctx := context.Background()
+
+	resp, err := service.GrantEntitlement(ctx, &pb.GrantEntitlementRequest{UserId: "buyer", ProductId: "ebook", Reference: "order-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Entitlement.UserId != "buyer" || resp.Entitlement.Reference != "order-1" || resp.Entitlement.GrantedAt == nil {
+		t.Errorf("Unexpected entitlement %v", resp.Entitlement)
+	}
+
+	_, err = service.GrantEntitlement(ctx, &pb.GrantEntitlementRequest{UserId: "buyer", ProductId: "mug"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestCreateProduct_DigitalHasNoStock(t *testing.T) {
+	var created *Product
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			created = product
+			return product, nil
+		},
+	}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Go Handbook", Sku: "EBOOK-1", Price: 20, Stock: 5, ProductType: ProductTypeDigital})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for digital stock, got %v", err)
+	}
+
+	_, err = service.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Go Handbook", Sku: "EBOOK-1", Price: 20, ProductType: "SERVICE"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unknown type, got %v", err)
+	}
+
+	resp, err := service.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Go Handbook", Sku: "EBOOK-1", Price: 20, ProductType: ProductTypeDigital})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ProductType != ProductTypeDigital || resp.Product.ProductType != ProductTypeDigital {
+		t.Errorf("Expected digital product, got %v", resp.Product)
+	}
+
+	if _, err := service.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Mug", Sku: "MUG-1", Price: 8}); err != nil || created.ProductType != ProductTypePhysical {
+		t.Errorf("Expected physical default, got %v, %v", created, err)
+	}
+}
+
+func TestDecrementStock_DigitalProduct(t *testing.T) {
+	mockRepo := &MockRepository{
+		AdjustStockFunc: func(ctx context.Context, productID string, delta int32) (*StockLevel, error) {
+			return nil, ErrStockNotTracked
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.DecrementStock(context.Background(), &pb.DecrementStockRequest{ProductId: "ebook", Quantity: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
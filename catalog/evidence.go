@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/evidence"
+)
+
+// priceChangeRecord is a price change as exported in evidence bundles
+type priceChangeRecord struct {
+	Seq       int64     `json:"seq"`
+	ProductID string    `json:"product_id"`
+	OldPrice  *float64  `json:"old_price"`
+	NewPrice  float64   `json:"new_price"`
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+	Hash      string    `json:"hash"`
+}
+
+// chainReportRecord is a price history verification as exported in evidence bundles
+type chainReportRecord struct {
+	Valid           bool   `json:"valid"`
+	EntriesChecked  int64  `json:"entries_checked"`
+	AnchorsChecked  int32  `json:"anchors_checked"`
+	HeadSeq         int64  `json:"head_seq"`
+	HeadHash        string `json:"head_hash"`
+	FirstInvalidSeq int64  `json:"first_invalid_seq,omitempty"`
+	Problem         string `json:"problem,omitempty"`
+}
+
+// productMutationRecord is a product audit entry as exported in evidence bundles
+type productMutationRecord struct {
+	ID        int64         `json:"id"`
+	ProductID string        `json:"product_id"`
+	Action    string        `json:"action"`
+	Actor     string        `json:"actor"`
+	Changes   []FieldChange `json:"changes"`
+	At        time.Time     `json:"at"`
+}
+
+// EvidenceSources returns the compliance evidence of the catalog: the admin
+// price changes of the period, a verification of the price history chain
+// they are recorded in and the product mutations of the period
+func EvidenceSources(repo Repository, auditKey []byte) []evidence.Source {
+	return []evidence.Source{
+		evidence.SourceFunc{
+			SourceName: "admin_price_changes",
+			Fn: func(ctx context.Context, from, to time.Time) (interface{}, error) {
+				changes, err := repo.ListPriceChangesBetween(ctx, from, to)
+				if err != nil {
+					return nil, err
+				}
+				records := make([]priceChangeRecord, len(changes))
+				for i, c := range changes {
+					records[i] = priceChangeRecord{
+						Seq:       c.Seq,
+						ProductID: c.ProductID,
+						OldPrice:  c.OldPrice,
+						NewPrice:  c.NewPrice,
+						ChangedBy: c.ChangedBy,
+						ChangedAt: c.ChangedAt,
+						Hash:      c.Hash,
+					}
+				}
+				return records, nil
+			},
+		},
+		evidence.SourceFunc{
+			SourceName: "price_history_chain",
+			Fn: func(ctx context.Context, from, to time.Time) (interface{}, error) {
+				report, err := verifyPriceHistoryChain(ctx, repo, auditKey)
+				if err != nil {
+					return nil, err
+				}
+				return chainReportRecord(*report), nil
+			},
+		},
+		evidence.SourceFunc{
+			SourceName: "product_mutations",
+			Fn: func(ctx context.Context, from, to time.Time) (interface{}, error) {
+				entries, err := repo.ListProductAuditBetween(ctx, from, to)
+				if err != nil {
+					return nil, err
+				}
+				records := make([]productMutationRecord, len(entries))
+				for i, e := range entries {
+					records[i] = productMutationRecord{
+						ID:        e.ID,
+						ProductID: e.ProductID,
+						Action:    e.Action,
+						Actor:     e.Actor,
+						Changes:   e.Changes,
+						At:        e.CreatedAt,
+					}
+				}
+				return records, nil
+			},
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEvidenceSources(t *testing.T) {
+	key := []byte("anchor-key")
+	chain := buildChain(3)
+	mockRepo := chainRepo(chain, nil)
+	mockRepo.ListPriceChangesBetweenFunc = func(ctx context.Context, from, to time.Time) ([]*PriceChange, error) {
+		return chain[1:], nil
+	}
+	mockRepo.ListProductAuditBetweenFunc = func(ctx context.Context, from, to time.Time) ([]*ProductAuditEntry, error) {
+		return []*ProductAuditEntry{
+			{ID: 7, ProductID: "prod-1", Action: AuditActionUpdate, Actor: "admin-1", Changes: []FieldChange{{Field: "name", Old: "Lamp", New: "Desk Lamp"}}},
+		}, nil
+	}
+
+	sources := EvidenceSources(mockRepo, key)
+	if len(sources) != 3 {
+		t.Fatalf("Expected 3 sources, got %d", len(sources))
+	}
+
+	ctx := context.Background()
+	from, to := time.Now().Add(-time.Hour), time.Now()
+
+	records, err := sources[0].Collect(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	changes := records.([]priceChangeRecord)
+	if len(changes) != 2 || changes[0].Seq != 2 || changes[0].ChangedBy != "admin-1" || changes[0].Hash != chain[1].Hash {
+		t.Errorf("Unexpected price change records %+v", changes)
+	}
+
+	records, err = sources[1].Collect(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report := records.(chainReportRecord); !report.Valid || report.HeadSeq != 3 {
+		t.Errorf("Unexpected chain report %+v", report)
+	}
+
+	records, err = sources[2].Collect(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	mutations := records.([]productMutationRecord)
+	if len(mutations) != 1 || mutations[0].Actor != "admin-1" || mutations[0].Changes[0].New != "Desk Lamp" {
+		t.Errorf("Unexpected product mutation records %+v", mutations)
+	}
+}
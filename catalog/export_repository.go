@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProductCursor is a position in the (updated_at, id) order of products. A
+// cursor without an ID starts at UpdatedAt, inclusive.
+type ProductCursor struct {
+	UpdatedAt time.Time
+	ID        string
+}
+
+// ListUpdatedSince retrieves up to limit products after the cursor in
+// (updated_at, id) order, drafts included
+func (r *postgresRepository) ListUpdatedSince(ctx context.Context, cursor ProductCursor, limit int) ([]*Product, error) {
+	where := "updated_at >= $1"
+	args := []interface{}{cursor.UpdatedAt}
+	if cursor.ID != "" {
+		where = "(updated_at, id) > ($1, $2)"
+		args = append(args, cursor.ID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM products
+		WHERE %s
+		ORDER BY updated_at, id
+		LIMIT $%d
+	`, productColumns, where, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list updated products", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list updated products: %w", err)
+	}
+	defer rows.Close()
+
+	products := []*Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan product", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating updated products", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating updated products: %w", err)
+	}
+
+	return products, nil
+}
@@ -0,0 +1,57 @@
+package catalog
+
+import (
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamBatchSize is the number of products read from the database at a time
+// while streaming
+const streamBatchSize = 500
+
+// StreamProducts streams every product updated at or after updated_since in
+// (updated_at, id) order. A product updated while the stream runs may be sent
+// twice; consumers upsert by ID. Deleted products are not reported.
+func (s *Service) StreamProducts(req *pb.StreamProductsRequest, stream grpc.ServerStreamingServer[pb.Product]) error {
+	ctx := stream.Context()
+
+	var cursor ProductCursor
+	if req.UpdatedSince != nil {
+		if err := req.UpdatedSince.CheckValid(); err != nil {
+			return status.Error(codes.InvalidArgument, "invalid updated_since")
+		}
+		cursor.UpdatedAt = req.UpdatedSince.AsTime()
+	}
+
+	sent := 0
+	for {
+		products, err := s.repo.ListUpdatedSince(ctx, cursor, streamBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			s.log.Error(ctx, "Failed to stream products", map[string]interface{}{"error": err.Error(), "sent": sent})
+			return status.Error(codes.Internal, "failed to stream products")
+		}
+
+		now := s.now()
+		for _, p := range products {
+			if err := stream.Send(toProtoProduct(p, now)); err != nil {
+				s.log.Warn(ctx, "Product stream interrupted", map[string]interface{}{"error": err.Error(), "sent": sent})
+				return err
+			}
+			sent++
+		}
+
+		if len(products) < streamBatchSize {
+			break
+		}
+		last := products[len(products)-1]
+		cursor = ProductCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+	}
+
+	s.log.Info(ctx, "Products streamed", map[string]interface{}{"count": sent})
+	return nil
+}
@@ -0,0 +1,92 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// productStream records the products sent on a StreamProducts stream
+type productStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    []*pb.Product
+	sendErr error
+}
+
+func (s *productStream) Context() context.Context { return s.ctx }
+
+func (s *productStream) Send(p *pb.Product) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, p)
+	return nil
+}
+
+func TestStreamProducts_Batches(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	total := streamBatchSize + 3
+	var cursors []ProductCursor
+	mockRepo := &MockRepository{
+		ListUpdatedSinceFunc: func(ctx context.Context, cursor ProductCursor, limit int) ([]*Product, error) {
+			cursors = append(cursors, cursor)
+			start := 0
+			if cursor.ID != "" {
+				fmt.Sscanf(cursor.ID, "prod-%d", &start)
+				start++
+			}
+			var products []*Product
+			for i := start; i < total && len(products) < limit; i++ {
+				products = append(products, &Product{ID: fmt.Sprintf("prod-%d", i), UpdatedAt: since.Add(time.Duration(i) * time.Second)})
+			}
+			return products, nil
+		},
+	}
+	service := setupService(mockRepo)
+	stream := &productStream{ctx: context.Background()}
+
+	if err := service.StreamProducts(&pb.StreamProductsRequest{UpdatedSince: timestamppb.New(since)}, stream); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(stream.sent) != total {
+		t.Fatalf("Expected %d products, got %d", total, len(stream.sent))
+	}
+	if len(cursors) != 2 || !cursors[0].UpdatedAt.Equal(since) || cursors[0].ID != "" {
+		t.Fatalf("Unexpected cursors %v", cursors)
+	}
+	if last := fmt.Sprintf("prod-%d", streamBatchSize-1); cursors[1].ID != last {
+		t.Errorf("Expected second batch after %s, got %v", last, cursors[1])
+	}
+}
+
+func TestStreamProducts_Errors(t *testing.T) {
+	service := setupService(&MockRepository{
+		ListUpdatedSinceFunc: func(ctx context.Context, cursor ProductCursor, limit int) ([]*Product, error) {
+			return nil, errors.New("database unavailable")
+		},
+	})
+	err := service.StreamProducts(&pb.StreamProductsRequest{}, &productStream{ctx: context.Background()})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+
+	invalid := &pb.StreamProductsRequest{UpdatedSince: &timestamppb.Timestamp{Nanos: -1}}
+	if err := service.StreamProducts(invalid, &productStream{ctx: context.Background()}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := service.StreamProducts(&pb.StreamProductsRequest{}, &productStream{ctx: ctx}); status.Code(err) != codes.Canceled {
+		t.Errorf("Expected Canceled, got %v", err)
+	}
+}
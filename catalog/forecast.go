@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// SalesVelocity is an active physical product's stock and its units sold
+// over a sales window
+type SalesVelocity struct {
+	ProductID         string
+	SKU               string
+	Name              string
+	Stock             int32
+	LowStockThreshold int32
+	UnitsSold         int64
+}
+
+// ReorderPolicy controls how stock is forecast and reorders are sized
+type ReorderPolicy struct {
+	// WindowDays is the number of days units sold are averaged over
+	WindowDays int
+	// LeadTimeDays is how long a reorder takes to arrive
+	LeadTimeDays int
+	// CoverDays is how many days of sales a reorder should last once it arrives
+	CoverDays int
+}
+
+// ReorderSuggestion is the stock forecast of a product. A product needs a
+// reorder when ReorderQuantity is positive.
+type ReorderSuggestion struct {
+	SalesVelocity
+	// DailyVelocity is the average units sold per day
+	DailyVelocity float64
+	// DaysOfStock is how many days the stock lasts at DailyVelocity, or -1
+	// when nothing was sold
+	DaysOfStock float64
+	// StockoutAt and ReorderBy are nil when nothing was sold
+	StockoutAt *time.Time
+	ReorderBy  *time.Time
+	// ReorderPoint is the stock at or below which a reorder is needed: the
+	// sales expected during the lead time plus the low-stock threshold as
+	// safety stock
+	ReorderPoint    int32
+	ReorderQuantity int32
+}
+
+// Forecast projects a product's days of stock from its sales velocity and
+// suggests a reorder once stock is at the reorder point. The reorder brings
+// stock up to the sales of the lead time and cover days plus the safety
+// stock. Products that sold nothing are only reordered below their low-stock
+// threshold.
+func Forecast(v *SalesVelocity, policy ReorderPolicy, now time.Time) *ReorderSuggestion {
+	s := &ReorderSuggestion{SalesVelocity: *v, DaysOfStock: -1}
+	if policy.WindowDays > 0 {
+		s.DailyVelocity = float64(v.UnitsSold) / float64(policy.WindowDays)
+	}
+
+	if s.DailyVelocity > 0 {
+		s.DaysOfStock = float64(v.Stock) / s.DailyVelocity
+		stockoutAt := now.Add(time.Duration(s.DaysOfStock * float64(24*time.Hour)))
+		reorderBy := stockoutAt.AddDate(0, 0, -policy.LeadTimeDays)
+		s.StockoutAt, s.ReorderBy = &stockoutAt, &reorderBy
+	}
+
+	s.ReorderPoint = clampInt32(math.Ceil(s.DailyVelocity*float64(policy.LeadTimeDays)) + float64(v.LowStockThreshold))
+	if v.Stock <= s.ReorderPoint {
+		target := math.Ceil(s.DailyVelocity*float64(policy.LeadTimeDays+policy.CoverDays)) + float64(v.LowStockThreshold)
+		s.ReorderQuantity = clampInt32(target - float64(v.Stock))
+	}
+	return s
+}
+
+// ForecastAll forecasts every product and orders the suggestions by days of
+// stock, fewest first, with products that sold nothing last. Unless all is
+// set, only products that need a reorder are returned.
+func ForecastAll(velocities []*SalesVelocity, policy ReorderPolicy, now time.Time, all bool) []*ReorderSuggestion {
+	suggestions := make([]*ReorderSuggestion, 0, len(velocities))
+	for _, v := range velocities {
+		s := Forecast(v, policy, now)
+		if all || s.ReorderQuantity > 0 {
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if (a.DaysOfStock < 0) != (b.DaysOfStock < 0) {
+			return b.DaysOfStock < 0
+		}
+		if a.DaysOfStock != b.DaysOfStock {
+			return a.DaysOfStock < b.DaysOfStock
+		}
+		return a.ProductID < b.ProductID
+	})
+	return suggestions
+}
+
+// clampInt32 converts a non-negative quantity to int32, saturating at the
+// int32 range
+func clampInt32(f float64) int32 {
+	switch {
+	case f <= 0:
+		return 0
+	case f >= math.MaxInt32:
+		return math.MaxInt32
+	}
+	return int32(f)
+}
@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ListSalesVelocity retrieves the stock of every active physical product with
+// its units sold on the days from since up to, but not including, until
+func (r *postgresRepository) ListSalesVelocity(ctx context.Context, since, until time.Time) ([]*SalesVelocity, error) {
+	query := `
+		SELECT p.id, p.sku, p.name, p.stock, p.low_stock_threshold, COALESCE(a.units, 0)
+		FROM products p
+		LEFT JOIN (
+			SELECT product_id, SUM(units) AS units
+			FROM product_activity_daily
+			WHERE day >= $1 AND day < $2
+			GROUP BY product_id
+		) a ON a.product_id = p.id
+		WHERE p.product_type = '` + ProductTypePhysical + `' AND p.status = '` + ProductStatusActive + `'
+		ORDER BY p.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since, until)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list sales velocity", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list sales velocity: %w", err)
+	}
+	defer rows.Close()
+
+	velocities := []*SalesVelocity{}
+	for rows.Next() {
+		v := &SalesVelocity{}
+		if err := rows.Scan(&v.ProductID, &v.SKU, &v.Name, &v.Stock, &v.LowStockThreshold, &v.UnitsSold); err != nil {
+			r.log.Error(ctx, "Failed to scan sales velocity", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan sales velocity: %w", err)
+		}
+		velocities = append(velocities, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating sales velocity", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating sales velocity: %w", err)
+	}
+
+	return velocities, nil
+}
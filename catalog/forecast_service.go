@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Reorder report defaults and limits, in days
+const (
+	defaultSalesWindowDays  = 28
+	defaultLeadTimeDays     = 7
+	defaultReorderCoverDays = 30
+	maxForecastDays         = 365
+)
+
+// GetReorderReport forecasts the days of stock of active physical products
+// from their units sold over the last complete days and suggests what to
+// reorder. Products that need no reorder are only listed with include_all.
+func (s *Service) GetReorderReport(ctx context.Context, req *pb.GetReorderReportRequest) (*pb.GetReorderReportResponse, error) {
+	policy, msg := reorderPolicyFromRequest(req)
+	if msg != "" {
+		s.log.Warn(ctx, "Get reorder report failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	// Today is still selling, so the window ends with yesterday
+	now := s.now()
+	today := now.UTC().Truncate(24 * time.Hour)
+	velocities, err := s.repo.ListSalesVelocity(ctx, today.AddDate(0, 0, -policy.WindowDays), today)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list sales velocity", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to get reorder report")
+	}
+	suggestions := ForecastAll(velocities, policy, now, req.IncludeAll)
+
+	total := int32(len(suggestions))
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+
+	resp := &pb.GetReorderReportResponse{
+		Suggestions: make([]*pb.ReorderSuggestion, 0, end-start),
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		GeneratedAt: timestamppb.New(now),
+	}
+	for _, sg := range suggestions[start:end] {
+		resp.Suggestions = append(resp.Suggestions, toProtoReorderSuggestion(sg))
+	}
+	return resp, nil
+}
+
+// reorderPolicyFromRequest applies the defaults to the days of a reorder
+// report request, or returns a message describing the first invalid one
+func reorderPolicyFromRequest(req *pb.GetReorderReportRequest) (ReorderPolicy, string) {
+	policy := ReorderPolicy{
+		WindowDays:   int(req.WindowDays),
+		LeadTimeDays: int(req.LeadTimeDays),
+		CoverDays:    int(req.CoverDays),
+	}
+	switch {
+	case policy.WindowDays < 0 || policy.WindowDays > maxForecastDays:
+		return policy, "window_days must be between 1 and 365"
+	case policy.LeadTimeDays < 0 || policy.LeadTimeDays > maxForecastDays:
+		return policy, "lead_time_days must be between 1 and 365"
+	case policy.CoverDays < 0 || policy.CoverDays > maxForecastDays:
+		return policy, "cover_days must be between 1 and 365"
+	}
+	if policy.WindowDays == 0 {
+		policy.WindowDays = defaultSalesWindowDays
+	}
+	if policy.LeadTimeDays == 0 {
+		policy.LeadTimeDays = defaultLeadTimeDays
+	}
+	if policy.CoverDays == 0 {
+		policy.CoverDays = defaultReorderCoverDays
+	}
+	return policy, ""
+}
+
+// toProtoReorderSuggestion converts a reorder suggestion to protobuf
+func toProtoReorderSuggestion(s *ReorderSuggestion) *pb.ReorderSuggestion {
+	suggestion := &pb.ReorderSuggestion{
+		ProductId:         s.ProductID,
+		Sku:               s.SKU,
+		Name:              s.Name,
+		Stock:             s.Stock,
+		LowStockThreshold: s.LowStockThreshold,
+		UnitsSold:         s.UnitsSold,
+		DailyVelocity:     s.DailyVelocity,
+		DaysOfStock:       s.DaysOfStock,
+		ReorderPoint:      s.ReorderPoint,
+		ReorderQuantity:   s.ReorderQuantity,
+	}
+	if s.StockoutAt != nil {
+		suggestion.StockoutAt = timestamppb.New(*s.StockoutAt)
+		suggestion.ReorderBy = timestamppb.New(*s.ReorderBy)
+	}
+	return suggestion
+}
@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetReorderReport_Success(t *testing.T) {
+	now := time.Date(2026, 5, 29, 15, 0, 0, 0, time.UTC)
+	var gotSince, gotUntil time.Time
+	mockRepo := &MockRepository{
+		ListSalesVelocityFunc: func(ctx context.Context, since, until time.Time) ([]*SalesVelocity, error) {
+			gotSince, gotUntil = since, until
+			return []*SalesVelocity{
+				{ProductID: "prod-1", SKU: "KET-1", Name: "Kettle", Stock: 12, LowStockThreshold: 5, UnitsSold: 56},
+				{ProductID: "prod-2", SKU: "MUG-1", Name: "Mug", Stock: 400, UnitsSold: 56},
+				{ProductID: "prod-3", SKU: "TEA-1", Name: "Tea", Stock: 1, UnitsSold: 28},
+			}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	service.now = func() time.Time { return now }
+
+	resp, err := service.GetReorderReport(context.Background(), &pb.GetReorderReportRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The default window is the 28 days before today
+	today := time.Date(2026, 5, 29, 0, 0, 0, 0, time.UTC)
+	if !gotUntil.Equal(today) || !gotSince.Equal(today.AddDate(0, 0, -28)) {
+		t.Errorf("Unexpected sales window %v to %v", gotSince, gotUntil)
+	}
+	if resp.Total != 2 || resp.Page != 1 || resp.PageSize != 1 || !resp.GeneratedAt.AsTime().Equal(now) {
+		t.Errorf("Unexpected report %v", resp)
+	}
+	if len(resp.Suggestions) != 1 {
+		t.Fatalf("Expected 1 suggestion, got %d", len(resp.Suggestions))
+	}
+	if sg := resp.Suggestions[0]; sg.ProductId != "prod-3" || sg.DaysOfStock != 1 || sg.ReorderQuantity != 36 ||
+		!sg.StockoutAt.AsTime().Equal(now.AddDate(0, 0, 1)) || !sg.ReorderBy.AsTime().Equal(now.AddDate(0, 0, -6)) {
+		t.Errorf("Unexpected suggestion %v", sg)
+	}
+}
+
+func TestGetReorderReport_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		ListSalesVelocityFunc: func(ctx context.Context, since, until time.Time) ([]*SalesVelocity, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		name     string
+		req      *pb.GetReorderReportRequest
+		expected codes.Code
+	}{
+		{"negative window", &pb.GetReorderReportRequest{WindowDays: -1}, codes.InvalidArgument},
+		{"long lead time", &pb.GetReorderReportRequest{LeadTimeDays: maxForecastDays + 1}, codes.InvalidArgument},
+		{"long cover", &pb.GetReorderReportRequest{CoverDays: maxForecastDays + 1}, codes.InvalidArgument},
+		{"database down", &pb.GetReorderReportRequest{}, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetReorderReport(context.Background(), tt.req)
+			if st, _ := status.FromError(err); st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForecast(t *testing.T) {
+	now := time.Date(2026, 5, 29, 12, 0, 0, 0, time.UTC)
+	policy := ReorderPolicy{WindowDays: 28, LeadTimeDays: 7, CoverDays: 30}
+
+	// 56 units in 28 days sell 2 a day, so 12 units last 6 days
+	s := Forecast(&SalesVelocity{ProductID: "prod-1", Stock: 12, LowStockThreshold: 5, UnitsSold: 56}, policy, now)
+	if s.DailyVelocity != 2 || s.DaysOfStock != 6 {
+		t.Errorf("Expected 2 a day for 6 days, got %v a day for %v days", s.DailyVelocity, s.DaysOfStock)
+	}
+	if !s.StockoutAt.Equal(now.AddDate(0, 0, 6)) || !s.ReorderBy.Equal(now.AddDate(0, 0, -1)) {
+		t.Errorf("Unexpected stockout %v and reorder date %v", s.StockoutAt, s.ReorderBy)
+	}
+	// 14 units during the lead time plus 5 of safety stock
+	if s.ReorderPoint != 19 {
+		t.Errorf("Expected reorder point 19, got %d", s.ReorderPoint)
+	}
+	// 74 units for the lead time and cover days plus 5 of safety stock
+	if s.ReorderQuantity != 67 {
+		t.Errorf("Expected reorder quantity 67, got %d", s.ReorderQuantity)
+	}
+
+	if s := Forecast(&SalesVelocity{Stock: 100, UnitsSold: 56}, policy, now); s.ReorderQuantity != 0 {
+		t.Errorf("Expected no reorder above the reorder point, got %d", s.ReorderQuantity)
+	}
+}
+
+func TestForecast_NoSales(t *testing.T) {
+	policy := ReorderPolicy{WindowDays: 28, LeadTimeDays: 7, CoverDays: 30}
+
+	s := Forecast(&SalesVelocity{Stock: 2, LowStockThreshold: 5}, policy, time.Now())
+	if s.DaysOfStock != -1 || s.StockoutAt != nil || s.ReorderBy != nil {
+		t.Errorf("Expected no stockout without sales, got %+v", s)
+	}
+	if s.ReorderQuantity != 3 {
+		t.Errorf("Expected a reorder up to the low-stock threshold, got %d", s.ReorderQuantity)
+	}
+
+	if s := Forecast(&SalesVelocity{Stock: 0}, policy, time.Now()); s.ReorderQuantity != 0 {
+		t.Errorf("Expected no reorder without sales or a threshold, got %d", s.ReorderQuantity)
+	}
+}
+
+func TestForecastAll(t *testing.T) {
+	policy := ReorderPolicy{WindowDays: 10, LeadTimeDays: 7, CoverDays: 30}
+	velocities := []*SalesVelocity{
+		{ProductID: "idle", Stock: 1, LowStockThreshold: 5},
+		{ProductID: "slow", Stock: 5, UnitsSold: 10},
+		{ProductID: "fast", Stock: 9, UnitsSold: 30},
+		{ProductID: "stocked", Stock: 500, UnitsSold: 10},
+	}
+
+	var ids []string
+	for _, s := range ForecastAll(velocities, policy, time.Now(), false) {
+		ids = append(ids, s.ProductID)
+	}
+	if len(ids) != 3 || ids[0] != "fast" || ids[1] != "slow" || ids[2] != "idle" {
+		t.Errorf("Expected fast, slow and idle, got %v", ids)
+	}
+
+	if all := ForecastAll(velocities, policy, time.Now(), true); len(all) != 4 || all[2].ProductID != "stocked" {
+		t.Errorf("Expected every product with stocked before idle, got %d", len(all))
+	}
+}
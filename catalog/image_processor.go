@@ -0,0 +1,233 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/captioning"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/imagecheck"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// ImageFetcher downloads an image for validation
+type ImageFetcher interface {
+	Fetch(ctx context.Context, imageURL string) ([]byte, error)
+}
+
+// httpImageFetcher downloads images over HTTP(S)
+type httpImageFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPImageFetcher creates an ImageFetcher that downloads images over HTTP(S)
+func NewHTTPImageFetcher(timeout time.Duration) ImageFetcher {
+	return &httpImageFetcher{client: &http.Client{Timeout: timeout}}
+}
+
+// Fetch downloads an image, refusing non-HTTP URLs and images above maxImageSize
+func (f *httpImageFetcher) Fetch(ctx context.Context, imageURL string) ([]byte, error) {
+	u, err := url.Parse(imageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("unsupported image URL %q", imageURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download image: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	if len(data) > maxImageSize {
+		return nil, errors.New("image exceeds the size limit")
+	}
+	return data, nil
+}
+
+// ImageProcessorConfig tunes the image validation pipeline
+type ImageProcessorConfig struct {
+	Policy imagecheck.Policy
+	// BatchSize is the number of images claimed per run
+	BatchSize int
+	// Lease is how long a claimed image is hidden from other workers
+	Lease time.Duration
+	// MaxAttempts is how often an image download is tried before the image fails
+	MaxAttempts int32
+}
+
+// DefaultImageProcessorConfig is the pipeline configuration used when none is given
+var DefaultImageProcessorConfig = ImageProcessorConfig{
+	Policy:      imagecheck.DefaultPolicy,
+	BatchSize:   20,
+	Lease:       5 * time.Minute,
+	MaxAttempts: 3,
+}
+
+// ImageProcessor validates newly attached images and generates missing alt text.
+// Results are written back to the images; anything a person should look at is
+// flagged with needs_review.
+type ImageProcessor struct {
+	repo      Repository
+	fetcher   ImageFetcher
+	captioner captioning.Provider
+	cfg       ImageProcessorConfig
+	log       *logger.Logger
+}
+
+// NewImageProcessor creates an image pipeline. captioner may be nil, in which case
+// images without alt text are flagged for review instead.
+func NewImageProcessor(repo Repository, fetcher ImageFetcher, captioner captioning.Provider, cfg ImageProcessorConfig, log *logger.Logger) *ImageProcessor {
+	return &ImageProcessor{
+		repo:      repo,
+		fetcher:   fetcher,
+		captioner: captioner,
+		cfg:       cfg,
+		log:       log,
+	}
+}
+
+// Run processes pending images every interval until ctx is cancelled
+func (p *ImageProcessor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.ProcessPending(ctx); err != nil && ctx.Err() == nil {
+			p.log.Error(ctx, "Image pipeline run failed", map[string]interface{}{"error": err.Error()})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessPending claims one batch of pending images and processes it. It returns
+// the number of images whose results were saved.
+func (p *ImageProcessor) ProcessPending(ctx context.Context) (int, error) {
+	jobs, err := p.repo.ClaimPendingImages(ctx, p.cfg.BatchSize, p.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		if p.process(ctx, job) {
+			done++
+		}
+	}
+
+	if len(jobs) > 0 {
+		p.log.Info(ctx, "Image pipeline run completed", map[string]interface{}{"claimed": len(jobs), "processed": done})
+	}
+	return done, nil
+}
+
+// process validates a single image and saves the result. It returns false when the
+// image is left pending for a retry or the result could not be saved.
+func (p *ImageProcessor) process(ctx context.Context, job *ImageJob) bool {
+	fields := map[string]interface{}{"image_id": job.ImageID, "product_id": job.ProductID, "attempt": job.Attempts}
+
+	data, err := p.fetcher.Fetch(ctx, job.URL)
+	if err != nil {
+		fields["error"] = err.Error()
+		if job.Attempts < p.cfg.MaxAttempts {
+			// The lease expires and the image is claimed again later
+			p.log.Warn(ctx, "Image download failed, will retry", fields)
+			return false
+		}
+		p.log.Warn(ctx, "Image download failed, giving up", fields)
+		return p.save(ctx, &ImageValidation{
+			ImageID:     job.ImageID,
+			Status:      ImageValidationFailed,
+			Errors:      []string{"image could not be downloaded"},
+			NeedsReview: true,
+		})
+	}
+
+	v := validateImage(job.ImageID, data, p.cfg.Policy)
+
+	if job.AltText == "" {
+		// Missing alt text is always reviewed, generated or not
+		v.NeedsReview = true
+		if p.captioner != nil {
+			caption, err := p.captioner.Caption(ctx, job.URL, job.ProductName)
+			if err != nil {
+				p.log.Warn(ctx, "Alt text generation failed", map[string]interface{}{"image_id": job.ImageID, "error": err.Error()})
+			} else {
+				v.GeneratedAltText = truncateText(caption, maxAltTextLength)
+			}
+		}
+	}
+
+	return p.save(ctx, v)
+}
+
+// save writes a validation result, logging failures
+func (p *ImageProcessor) save(ctx context.Context, v *ImageValidation) bool {
+	if err := p.repo.SaveImageValidation(ctx, v); err != nil {
+		if !errors.Is(err, ErrImageNotFound) {
+			p.log.Error(ctx, "Failed to save image validation", map[string]interface{}{"image_id": v.ImageID, "error": err.Error()})
+		}
+		return false
+	}
+	return true
+}
+
+// validateImage checks image data against the policy
+func validateImage(imageID string, data []byte, policy imagecheck.Policy) *ImageValidation {
+	v := &ImageValidation{ImageID: imageID}
+
+	result, err := imagecheck.Check(data, policy)
+	if err != nil {
+		v.Status = ImageValidationFailed
+		v.Errors = []string{"image format could not be validated"}
+		v.NeedsReview = true
+		return v
+	}
+
+	v.Width = int32(result.Width)
+	v.Height = int32(result.Height)
+	v.Status = ImageValidationPassed
+	if !result.Passed() {
+		v.Status = ImageValidationFailed
+		v.Errors = result.Problems
+		v.NeedsReview = true
+	}
+	return v
+}
+
+// truncateText shortens s to at most max bytes without splitting a UTF-8 sequence
+func truncateText(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[:max]
+	for !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,176 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/captioning"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/imagecheck"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// mockImageFetcher serves images from memory
+type mockImageFetcher struct {
+	images map[string][]byte
+}
+
+func (m *mockImageFetcher) Fetch(ctx context.Context, imageURL string) ([]byte, error) {
+	if data, ok := m.images[imageURL]; ok {
+		return data, nil
+	}
+	return nil, errors.New("download failed")
+}
+
+// mockCaptioner returns a fixed caption
+type mockCaptioner struct {
+	caption string
+	err     error
+}
+
+func (m *mockCaptioner) Caption(ctx context.Context, imageURL string, hint string) (string, error) {
+	return m.caption, m.err
+}
+
+// whitePNG renders a plain white PNG of the given size
+func whitePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// setupProcessor creates a processor that saves results into saved
+func setupProcessor(jobs []*ImageJob, fetcher ImageFetcher, captioner captioning.Provider, saved *[]*ImageValidation) *ImageProcessor {
+	repo := &MockRepository{
+		ClaimPendingImagesFunc: func(ctx context.Context, limit int, lease time.Duration) ([]*ImageJob, error) {
+			return jobs, nil
+		},
+		SaveImageValidationFunc: func(ctx context.Context, v *ImageValidation) error {
+			*saved = append(*saved, v)
+			return nil
+		},
+	}
+
+	cfg := DefaultImageProcessorConfig
+	cfg.Policy = imagecheck.Policy{MinWidth: 100, MinHeight: 100, MaxAspectRatio: 2, Background: imagecheck.BackgroundWhite}
+
+	return NewImageProcessor(repo, fetcher, captioner, cfg, logger.New("catalog-test"))
+}
+
+func TestProcessPending_PassesAndGeneratesAltText(t *testing.T) {
+	jobs := []*ImageJob{{ImageID: "img-1", ProductID: "p1", ProductName: "Mug", URL: "https://cdn.test/a.png", Attempts: 1}}
+	fetcher := &mockImageFetcher{images: map[string][]byte{"https://cdn.test/a.png": whitePNG(t, 200, 200)}}
+
+	var saved []*ImageValidation
+	processor := setupProcessor(jobs, fetcher, &mockCaptioner{caption: "A white mug"}, &saved)
+
+	done, err := processor.ProcessPending(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if done != 1 || len(saved) != 1 {
+		t.Fatalf("Expected 1 saved result, got %d", len(saved))
+	}
+
+	v := saved[0]
+	if v.Status != ImageValidationPassed || v.Width != 200 || v.Height != 200 {
+		t.Errorf("Unexpected validation %+v", v)
+	}
+
+	if v.GeneratedAltText != "A white mug" || !v.NeedsReview {
+		t.Errorf("Expected generated alt text flagged for review, got %+v", v)
+	}
+}
+
+func TestProcessPending_KeepsExistingAltText(t *testing.T) {
+	jobs := []*ImageJob{{ImageID: "img-1", URL: "https://cdn.test/a.png", AltText: "Front view", Attempts: 1}}
+	fetcher := &mockImageFetcher{images: map[string][]byte{"https://cdn.test/a.png": whitePNG(t, 200, 200)}}
+
+	var saved []*ImageValidation
+	processor := setupProcessor(jobs, fetcher, &mockCaptioner{caption: "unused"}, &saved)
+
+	processor.ProcessPending(context.Background())
+
+	if len(saved) != 1 || saved[0].GeneratedAltText != "" || saved[0].NeedsReview {
+		t.Errorf("Expected passing image with alt text not to need review, got %+v", saved)
+	}
+}
+
+func TestProcessPending_PolicyViolation(t *testing.T) {
+	jobs := []*ImageJob{{ImageID: "img-1", URL: "https://cdn.test/a.png", AltText: "Banner", Attempts: 1}}
+	fetcher := &mockImageFetcher{images: map[string][]byte{"https://cdn.test/a.png": whitePNG(t, 500, 100)}}
+
+	var saved []*ImageValidation
+	processor := setupProcessor(jobs, fetcher, nil, &saved)
+
+	processor.ProcessPending(context.Background())
+
+	if len(saved) != 1 {
+		t.Fatalf("Expected 1 saved result, got %d", len(saved))
+	}
+
+	if saved[0].Status != ImageValidationFailed || len(saved[0].Errors) == 0 || !saved[0].NeedsReview {
+		t.Errorf("Expected failed validation flagged for review, got %+v", saved[0])
+	}
+}
+
+func TestProcessPending_RetriesDownloadFailures(t *testing.T) {
+	jobs := []*ImageJob{
+		{ImageID: "img-1", URL: "https://cdn.test/missing.png", Attempts: 1},
+		{ImageID: "img-2", URL: "https://cdn.test/missing.png", Attempts: DefaultImageProcessorConfig.MaxAttempts},
+	}
+
+	var saved []*ImageValidation
+	processor := setupProcessor(jobs, &mockImageFetcher{}, nil, &saved)
+
+	done, _ := processor.ProcessPending(context.Background())
+
+	if done != 1 || len(saved) != 1 || saved[0].ImageID != "img-2" {
+		t.Fatalf("Expected only the exhausted image to be saved, got %+v", saved)
+	}
+
+	if saved[0].Status != ImageValidationFailed || !saved[0].NeedsReview {
+		t.Errorf("Expected exhausted image to fail, got %+v", saved[0])
+	}
+}
+
+func TestProcessPending_CaptionFailure(t *testing.T) {
+	jobs := []*ImageJob{{ImageID: "img-1", URL: "https://cdn.test/a.png", Attempts: 1}}
+	fetcher := &mockImageFetcher{images: map[string][]byte{"https://cdn.test/a.png": whitePNG(t, 200, 200)}}
+
+	var saved []*ImageValidation
+	processor := setupProcessor(jobs, fetcher, &mockCaptioner{err: errors.New("provider down")}, &saved)
+
+	processor.ProcessPending(context.Background())
+
+	if len(saved) != 1 || saved[0].GeneratedAltText != "" || !saved[0].NeedsReview {
+		t.Errorf("Expected missing alt text to be flagged for review, got %+v", saved)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	if got := truncateText("héllo", 2); got != "h" {
+		t.Errorf("Expected truncation on a rune boundary, got %q", got)
+	}
+
+	if got := truncateText(strings.Repeat("a", 10), 20); len(got) != 10 {
+		t.Errorf("Expected short text unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,442 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Image validation statuses
+const (
+	ImageValidationPending = "PENDING"
+	ImageValidationPassed  = "PASSED"
+	ImageValidationFailed  = "FAILED"
+)
+
+var (
+	// ErrImageNotFound is returned when an image does not belong to the product
+	ErrImageNotFound = errors.New("image not found")
+	// ErrImageOrderMismatch is returned when a reorder does not list every image of the product exactly once
+	ErrImageOrderMismatch = errors.New("image order must list every image of the product")
+)
+
+// ProductImage is a product image with its display metadata.
+// The JSON tags match the objects built by productImagesColumn.
+type ProductImage struct {
+	ID        string `json:"id"`
+	URL       string `json:"url"`
+	AltText   string `json:"alt_text"`
+	Position  int32  `json:"position"`
+	IsPrimary bool   `json:"is_primary"`
+
+	Width            int32    `json:"width"`
+	Height           int32    `json:"height"`
+	ValidationStatus string   `json:"validation_status"`
+	ValidationErrors []string `json:"validation_errors"`
+	AltTextGenerated bool     `json:"alt_text_generated"`
+	NeedsReview      bool     `json:"needs_review"`
+
+	Renditions []ImageRendition `json:"renditions"`
+}
+
+// ImageRendition is a resized copy of an image made by the image service
+type ImageRendition struct {
+	Name      string `json:"name"`
+	Format    string `json:"format"`
+	URL       string `json:"url"`
+	Width     int32  `json:"width"`
+	Height    int32  `json:"height"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// ImageJob is a pending image claimed by the validation pipeline
+type ImageJob struct {
+	ImageID     string
+	ProductID   string
+	ProductName string
+	URL         string
+	AltText     string
+	Attempts    int32
+}
+
+// ImageValidation is the pipeline result written back to an image
+type ImageValidation struct {
+	ImageID string
+	Width   int32
+	Height  int32
+	Status  string
+	Errors  []string
+	// GeneratedAltText is stored only if the image has no alt text yet
+	GeneratedAltText string
+	NeedsReview      bool
+}
+
+// productImagesColumn aggregates the images of the product row aliased as alias into a JSON array
+func productImagesColumn(alias string) string {
+	return `COALESCE((
+		SELECT json_agg(json_build_object(
+			'id', pi.id, 'url', pi.url, 'alt_text', pi.alt_text, 'position', pi.position, 'is_primary', pi.is_primary,
+			'width', COALESCE(pi.width, 0), 'height', COALESCE(pi.height, 0),
+			'validation_status', pi.validation_status, 'validation_errors', pi.validation_errors,
+			'alt_text_generated', pi.alt_text_generated, 'needs_review', pi.needs_review,
+			'renditions', pi.renditions
+		) ORDER BY pi.position)
+		FROM product_images pi
+		WHERE pi.product_id = ` + alias + `.id
+	), '[]') AS images`
+}
+
+// AppendImage adds an uploaded image to the end of a product's images. The
+// first image of a product becomes its primary image. Re-attaching a URL
+// updates its alt text. The product.image_uploaded event is added to the outbox in the
+// same transaction, so the image service makes its renditions.
+func (r *postgresRepository) AppendImage(ctx context.Context, id, objectKey, imageURL, altText string) (*Product, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO product_images (product_id, url, alt_text, position, is_primary)
+		SELECT p.id, $2, $3,
+			COALESCE((SELECT MAX(position) + 1 FROM product_images WHERE product_id = p.id), 0),
+			NOT EXISTS (SELECT 1 FROM product_images WHERE product_id = p.id AND is_primary)
+		FROM products p
+		WHERE p.id = $1
+		ON CONFLICT (product_id, url) DO UPDATE SET alt_text = EXCLUDED.alt_text, alt_text_generated = FALSE
+		RETURNING id
+	`
+
+	var imageID string
+	err = tx.QueryRowContext(ctx, query, id, imageURL, altText).Scan(&imageID)
+	if err == sql.ErrNoRows {
+		r.log.Warn(ctx, "Product not found for image", map[string]interface{}{"product_id": id})
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to append product image", map[string]interface{}{"error": err.Error(), "product_id": id})
+		return nil, fmt.Errorf("failed to append product image: %w", err)
+	}
+
+	if err := recordImageUploaded(ctx, tx, id, imageID, objectKey, imageURL, time.Now()); err != nil {
+		r.log.Error(ctx, "Failed to record image event", map[string]interface{}{"error": err.Error(), "product_id": id})
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit product image", map[string]interface{}{"error": err.Error(), "product_id": id})
+		return nil, fmt.Errorf("failed to commit product image: %w", err)
+	}
+
+	r.log.Info(ctx, "Product image attached successfully", map[string]interface{}{"product_id": id, "image_id": imageID})
+	return r.GetByID(ctx, id)
+}
+
+// ReorderImages sets the display order of a product's images. imageIDs must
+// contain every image of the product exactly once.
+func (r *postgresRepository) ReorderImages(ctx context.Context, productID string, imageIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM (SELECT id FROM product_images WHERE product_id = $1 FOR UPDATE) locked",
+		productID,
+	).Scan(&count)
+	if err != nil {
+		r.log.Error(ctx, "Failed to lock product images", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to lock product images: %w", err)
+	}
+
+	if count != len(imageIDs) {
+		return ErrImageOrderMismatch
+	}
+
+	query := `
+		UPDATE product_images pi
+		SET position = o.ord - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+		WHERE pi.id = o.id AND pi.product_id = $1
+	`
+	result, err := tx.ExecContext(ctx, query, productID, pq.Array(imageIDs))
+	if err != nil {
+		r.log.Error(ctx, "Failed to reorder product images", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to reorder product images: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rows affected", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if int(rows) != count {
+		return ErrImageOrderMismatch
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit image order", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to commit image order: %w", err)
+	}
+
+	r.log.Info(ctx, "Product images reordered successfully", map[string]interface{}{"product_id": productID, "count": count})
+	return nil
+}
+
+// SetPrimaryImage marks an image as the primary image of its product, unmarking the previous one
+func (r *postgresRepository) SetPrimaryImage(ctx context.Context, productID, imageID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE product_images SET is_primary = FALSE WHERE product_id = $1 AND is_primary AND id <> $2",
+		productID, imageID,
+	)
+	if err != nil {
+		r.log.Error(ctx, "Failed to clear primary image", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to clear primary image: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE product_images SET is_primary = TRUE WHERE product_id = $1 AND id = $2",
+		productID, imageID,
+	)
+	if err != nil {
+		r.log.Error(ctx, "Failed to set primary image", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to set primary image: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rows affected", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrImageNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit primary image", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to commit primary image: %w", err)
+	}
+
+	r.log.Info(ctx, "Primary image updated successfully", map[string]interface{}{"product_id": productID, "image_id": imageID})
+	return nil
+}
+
+// ClaimPendingImages leases up to limit images awaiting validation so that concurrent
+// workers do not process the same image. Images whose lease expired are claimed again.
+func (r *postgresRepository) ClaimPendingImages(ctx context.Context, limit int, lease time.Duration) ([]*ImageJob, error) {
+	now := time.Now()
+	query := `
+		UPDATE product_images pi
+		SET claimed_until = $1, processing_attempts = pi.processing_attempts + 1
+		FROM products p
+		WHERE p.id = pi.product_id AND pi.id IN (
+			SELECT id FROM product_images
+			WHERE validation_status = 'PENDING' AND (claimed_until IS NULL OR claimed_until < $2)
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING pi.id, pi.product_id, p.name, pi.url, pi.alt_text, pi.processing_attempts
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now.Add(lease), now, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to claim pending images", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to claim pending images: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*ImageJob
+	for rows.Next() {
+		job := &ImageJob{}
+		if err := rows.Scan(&job.ImageID, &job.ProductID, &job.ProductName, &job.URL, &job.AltText, &job.Attempts); err != nil {
+			r.log.Error(ctx, "Failed to scan image job", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan image job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// SaveImageValidation stores the pipeline result of an image and releases its lease
+func (r *postgresRepository) SaveImageValidation(ctx context.Context, v *ImageValidation) error {
+	query := `
+		UPDATE product_images
+		SET width = $2, height = $3, validation_status = $4, validation_errors = $5,
+			alt_text = CASE WHEN alt_text = '' THEN $6 ELSE alt_text END,
+			alt_text_generated = CASE WHEN alt_text = '' AND $6 <> '' THEN TRUE ELSE alt_text_generated END,
+			needs_review = $7, claimed_until = NULL, processed_at = $8
+		WHERE id = $1
+	`
+
+	errs := v.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		v.ImageID, v.Width, v.Height, v.Status, pq.Array(errs), v.GeneratedAltText, v.NeedsReview, time.Now())
+	if err != nil {
+		r.log.Error(ctx, "Failed to save image validation", map[string]interface{}{"error": err.Error(), "image_id": v.ImageID})
+		return fmt.Errorf("failed to save image validation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rows affected", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrImageNotFound
+	}
+
+	r.log.Info(ctx, "Image validation saved", map[string]interface{}{"image_id": v.ImageID, "status": v.Status, "needs_review": v.NeedsReview})
+	return nil
+}
+
+// ReviewImage clears the review flag of an image. A non-empty altText replaces
+// the current alt text and marks it as written by a person.
+func (r *postgresRepository) ReviewImage(ctx context.Context, productID, imageID, altText string) error {
+	query := `
+		UPDATE product_images
+		SET needs_review = FALSE,
+			alt_text = CASE WHEN $3 = '' THEN alt_text ELSE $3 END,
+			alt_text_generated = CASE WHEN $3 = '' THEN alt_text_generated ELSE FALSE END
+		WHERE product_id = $1 AND id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, productID, imageID, altText)
+	if err != nil {
+		r.log.Error(ctx, "Failed to review image", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to review image: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rows affected", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrImageNotFound
+	}
+
+	r.log.Info(ctx, "Image reviewed", map[string]interface{}{"product_id": productID, "image_id": imageID})
+	return nil
+}
+
+// SetImageRenditions replaces the renditions of an image
+func (r *postgresRepository) SetImageRenditions(ctx context.Context, productID, imageID string, renditions []ImageRendition) error {
+	if renditions == nil {
+		renditions = []ImageRendition{}
+	}
+	data, err := json.Marshal(renditions)
+	if err != nil {
+		return fmt.Errorf("failed to encode image renditions: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE product_images SET renditions = $3 WHERE product_id = $1 AND id = $2",
+		productID, imageID, data,
+	)
+	if err != nil {
+		r.log.Error(ctx, "Failed to set image renditions", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to set image renditions: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rows affected", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrImageNotFound
+	}
+
+	r.log.Info(ctx, "Image renditions saved", map[string]interface{}{"product_id": productID, "image_id": imageID, "renditions": len(renditions)})
+	return nil
+}
+
+// syncImages makes a product's images match urls in order. Metadata of URLs that
+// are kept is preserved, and the first image becomes primary if none is set.
+func (r *postgresRepository) syncImages(ctx context.Context, tx *sql.Tx, productID string, urls []string) error {
+	if urls == nil {
+		urls = []string{}
+	}
+
+	_, err := tx.ExecContext(ctx,
+		"DELETE FROM product_images WHERE product_id = $1 AND NOT (url = ANY($2))",
+		productID, pq.Array(urls),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove product images: %w", err)
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+
+	upsertQuery := `
+		INSERT INTO product_images (product_id, url, position)
+		SELECT $1, u.url, u.ord - 1
+		FROM unnest($2::text[]) WITH ORDINALITY AS u(url, ord)
+		ON CONFLICT (product_id, url) DO UPDATE SET position = EXCLUDED.position
+	`
+	if _, err := tx.ExecContext(ctx, upsertQuery, productID, pq.Array(urls)); err != nil {
+		return fmt.Errorf("failed to save product images: %w", err)
+	}
+
+	primaryQuery := `
+		UPDATE product_images SET is_primary = TRUE
+		WHERE id = (SELECT id FROM product_images WHERE product_id = $1 ORDER BY position LIMIT 1)
+			AND NOT EXISTS (SELECT 1 FROM product_images WHERE product_id = $1 AND is_primary)
+	`
+	if _, err := tx.ExecContext(ctx, primaryQuery, productID); err != nil {
+		return fmt.Errorf("failed to set primary image: %w", err)
+	}
+
+	return nil
+}
+
+// imageURLs returns the URLs of images in order
+func imageURLs(images []*ProductImage) []string {
+	urls := make([]string, len(images))
+	for i, img := range images {
+		urls[i] = img.URL
+	}
+	return urls
+}
+
+// decodeImages decodes the JSON array built by productImagesColumn
+func decodeImages(data []byte) ([]*ProductImage, error) {
+	images := []*ProductImage{}
+	if len(data) == 0 {
+		return images, nil
+	}
+	if err := json.Unmarshal(data, &images); err != nil {
+		return nil, fmt.Errorf("failed to decode product images: %w", err)
+	}
+	return images, nil
+}
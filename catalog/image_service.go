@@ -0,0 +1,375 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxImageSize is the largest image upload accepted, in bytes
+	maxImageSize = 10 << 20
+	// imageUploadExpiry is how long a presigned upload URL stays valid
+	imageUploadExpiry = 15 * time.Minute
+	// maxAltTextLength is the longest image alt text accepted
+	maxAltTextLength = 500
+)
+
+// imageExtensions maps allowed image content types to object key extensions
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// ImageStore is the object storage used for product images
+type ImageStore interface {
+	PresignPut(key, contentType string, contentLength int64, expires time.Duration) (string, error)
+	Stat(ctx context.Context, key string) (*storage.ObjectInfo, error)
+	URL(key string) string
+}
+
+// WithImageStore enables presigned image uploads backed by the given store
+func (s *Service) WithImageStore(store ImageStore) *Service {
+	s.images = store
+	return s
+}
+
+// GetImageUploadURL returns a presigned URL the client uploads a product image to
+func (s *Service) GetImageUploadURL(ctx context.Context, req *pb.GetImageUploadURLRequest) (*pb.GetImageUploadURLResponse, error) {
+	if s.images == nil {
+		return nil, status.Error(codes.Unimplemented, "image uploads are not configured")
+	}
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get image upload URL failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	ext, ok := imageExtensions[req.ContentType]
+	if !ok {
+		s.log.Warn(ctx, "Get image upload URL failed: unsupported content type", map[string]interface{}{"content_type": req.ContentType})
+		return nil, status.Error(codes.InvalidArgument, "content_type must be image/jpeg, image/png, image/webp or image/gif")
+	}
+	if req.ContentLength <= 0 || req.ContentLength > maxImageSize {
+		s.log.Warn(ctx, "Get image upload URL failed: invalid content length", map[string]interface{}{"content_length": req.ContentLength})
+		return nil, status.Errorf(codes.InvalidArgument, "content_length must be between 1 and %d bytes", maxImageSize)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	key := imageKeyPrefix(req.ProductId) + uuid.New().String() + ext
+	uploadURL, err := s.images.PresignPut(key, req.ContentType, req.ContentLength, imageUploadExpiry)
+	if err != nil {
+		s.log.Error(ctx, "Failed to presign image upload", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to create upload URL")
+	}
+
+	s.log.Info(ctx, "Image upload URL issued", map[string]interface{}{"product_id": req.ProductId, "object_key": key})
+
+	return &pb.GetImageUploadURLResponse{
+		UploadUrl: uploadURL,
+		ObjectKey: key,
+		Headers: map[string]string{
+			"Content-Type":   req.ContentType,
+			"Content-Length": strconv.FormatInt(req.ContentLength, 10),
+		},
+		ExpiresAt: timestamppb.New(s.now().Add(imageUploadExpiry)),
+	}, nil
+}
+
+// AttachImage verifies an uploaded image and appends it to the product images
+func (s *Service) AttachImage(ctx context.Context, req *pb.AttachImageRequest) (*pb.AttachImageResponse, error) {
+	if s.images == nil {
+		return nil, status.Error(codes.Unimplemented, "image uploads are not configured")
+	}
+	if req.ProductId == "" || req.ObjectKey == "" {
+		s.log.Warn(ctx, "Attach image failed: product ID and object key are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id and object_key are required")
+	}
+	if !strings.HasPrefix(req.ObjectKey, imageKeyPrefix(req.ProductId)) {
+		s.log.Warn(ctx, "Attach image failed: object key belongs to another product", map[string]interface{}{"product_id": req.ProductId, "object_key": req.ObjectKey})
+		return nil, status.Error(codes.InvalidArgument, "object_key does not belong to this product")
+	}
+
+	altText := strings.TrimSpace(req.AltText)
+	if len(altText) > maxAltTextLength {
+		s.log.Warn(ctx, "Attach image failed: alt text too long", map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Errorf(codes.InvalidArgument, "alt_text must be at most %d characters", maxAltTextLength)
+	}
+
+	if err := s.authorizeVendorByID(ctx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	info, err := s.images.Stat(ctx, req.ObjectKey)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, status.Error(codes.FailedPrecondition, "image has not been uploaded")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to stat uploaded image", map[string]interface{}{"error": err.Error(), "object_key": req.ObjectKey})
+		return nil, status.Error(codes.Internal, "failed to verify image")
+	}
+	if _, ok := imageExtensions[info.ContentType]; !ok {
+		s.log.Warn(ctx, "Attach image failed: unsupported content type", map[string]interface{}{"content_type": info.ContentType})
+		return nil, status.Error(codes.InvalidArgument, "uploaded object is not a supported image")
+	}
+	if info.Size <= 0 || info.Size > maxImageSize {
+		s.log.Warn(ctx, "Attach image failed: invalid size", map[string]interface{}{"size": info.Size})
+		return nil, status.Error(codes.InvalidArgument, "uploaded image exceeds the size limit")
+	}
+
+	product, err := s.repo.AppendImage(ctx, req.ProductId, req.ObjectKey, s.images.URL(req.ObjectKey), altText)
+	if errors.Is(err, ErrProductNotFound) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to attach image", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to attach image")
+	}
+
+	return &pb.AttachImageResponse{
+		Product: toProtoProduct(product, s.now()),
+	}, nil
+}
+
+// ReorderImages sets the display order of a product's images
+func (s *Service) ReorderImages(ctx context.Context, req *pb.ReorderImagesRequest) (*pb.ReorderImagesResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Reorder images failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	seen := make(map[string]bool, len(req.ImageIds))
+	for _, id := range req.ImageIds {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid image id %q", id)
+		}
+		if seen[id] {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate image id %q", id)
+		}
+		seen[id] = true
+	}
+
+	if err := s.authorizeVendorByID(ctx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReorderImages(ctx, req.ProductId, req.ImageIds); err != nil {
+		return nil, s.imageError(ctx, err, req.ProductId)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+
+	return &pb.ReorderImagesResponse{
+		Product: toProtoProduct(product, s.now()),
+	}, nil
+}
+
+// SetPrimaryImage marks one of a product's images as its primary image
+func (s *Service) SetPrimaryImage(ctx context.Context, req *pb.SetPrimaryImageRequest) (*pb.SetPrimaryImageResponse, error) {
+	if req.ProductId == "" || req.ImageId == "" {
+		s.log.Warn(ctx, "Set primary image failed: product ID and image ID are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id and image_id are required")
+	}
+	if _, err := uuid.Parse(req.ImageId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid image_id")
+	}
+
+	if err := s.authorizeVendorByID(ctx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetPrimaryImage(ctx, req.ProductId, req.ImageId); err != nil {
+		return nil, s.imageError(ctx, err, req.ProductId)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+
+	return &pb.SetPrimaryImageResponse{
+		Product: toProtoProduct(product, s.now()),
+	}, nil
+}
+
+// ReviewImage clears the review flag set by the image pipeline, optionally correcting the alt text
+func (s *Service) ReviewImage(ctx context.Context, req *pb.ReviewImageRequest) (*pb.ReviewImageResponse, error) {
+	if req.ProductId == "" || req.ImageId == "" {
+		s.log.Warn(ctx, "Review image failed: product ID and image ID are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id and image_id are required")
+	}
+	if _, err := uuid.Parse(req.ImageId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid image_id")
+	}
+
+	altText := strings.TrimSpace(req.AltText)
+	if len(altText) > maxAltTextLength {
+		s.log.Warn(ctx, "Review image failed: alt text too long", map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Errorf(codes.InvalidArgument, "alt_text must be at most %d characters", maxAltTextLength)
+	}
+
+	if err := s.repo.ReviewImage(ctx, req.ProductId, req.ImageId, altText); err != nil {
+		return nil, s.imageError(ctx, err, req.ProductId)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+
+	return &pb.ReviewImageResponse{
+		Product: toProtoProduct(product, s.now()),
+	}, nil
+}
+
+// maxRenditions caps the renditions stored for one image
+const maxRenditions = 20
+
+// SetImageRenditions stores the renditions the image service made of an
+// image, replacing any made before
+func (s *Service) SetImageRenditions(ctx context.Context, req *pb.SetImageRenditionsRequest) (*pb.SetImageRenditionsResponse, error) {
+	if req.ProductId == "" || req.ImageId == "" {
+		s.log.Warn(ctx, "Set image renditions failed: product ID and image ID are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id and image_id are required")
+	}
+	if _, err := uuid.Parse(req.ImageId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid image_id")
+	}
+	if len(req.Renditions) > maxRenditions {
+		s.log.Warn(ctx, "Set image renditions failed: too many renditions", map[string]interface{}{"product_id": req.ProductId, "renditions": len(req.Renditions)})
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d renditions are allowed", maxRenditions)
+	}
+
+	renditions := make([]ImageRendition, 0, len(req.Renditions))
+	seen := make(map[string]bool, len(req.Renditions))
+	for _, r := range req.Renditions {
+		if r.Name == "" || r.Format == "" || r.Url == "" {
+			return nil, status.Error(codes.InvalidArgument, "renditions need a name, format and url")
+		}
+		if r.Width <= 0 || r.Height <= 0 || r.SizeBytes < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid dimensions for rendition %q", r.Name)
+		}
+		key := r.Name + "/" + r.Format
+		if seen[key] {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate rendition %q in %s", r.Name, r.Format)
+		}
+		seen[key] = true
+		renditions = append(renditions, ImageRendition{
+			Name:      r.Name,
+			Format:    r.Format,
+			URL:       r.Url,
+			Width:     r.Width,
+			Height:    r.Height,
+			SizeBytes: r.SizeBytes,
+		})
+	}
+
+	if err := s.repo.SetImageRenditions(ctx, req.ProductId, req.ImageId, renditions); err != nil {
+		return nil, s.imageError(ctx, err, req.ProductId)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+
+	return &pb.SetImageRenditionsResponse{
+		Product: toProtoProduct(product, s.now()),
+	}, nil
+}
+
+// imageError maps image repository errors to gRPC status errors
+func (s *Service) imageError(ctx context.Context, err error, productID string) error {
+	switch {
+	case errors.Is(err, ErrImageNotFound):
+		return status.Error(codes.NotFound, "image not found")
+	case errors.Is(err, ErrImageOrderMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.log.Error(ctx, "Image operation failed", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return status.Error(codes.Internal, "failed to update images")
+	}
+}
+
+// imagesFromURLs builds product images from URLs in display order, skipping blanks and duplicates
+func imagesFromURLs(urls []string) []*ProductImage {
+	images := make([]*ProductImage, 0, len(urls))
+	seen := make(map[string]bool, len(urls))
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		images = append(images, &ProductImage{URL: u, Position: int32(len(images))})
+	}
+	return images
+}
+
+// toProtoImages converts domain images to protobuf images
+func toProtoImages(images []*ProductImage) []*pb.ProductImage {
+	converted := make([]*pb.ProductImage, len(images))
+	for i, img := range images {
+		converted[i] = &pb.ProductImage{
+			Id:        img.ID,
+			Url:       img.URL,
+			AltText:   img.AltText,
+			Position:  img.Position,
+			IsPrimary: img.IsPrimary,
+
+			Width:            img.Width,
+			Height:           img.Height,
+			ValidationStatus: img.ValidationStatus,
+			ValidationErrors: img.ValidationErrors,
+			AltTextGenerated: img.AltTextGenerated,
+			NeedsReview:      img.NeedsReview,
+
+			Renditions: toProtoRenditions(img.Renditions),
+		}
+	}
+	return converted
+}
+
+// toProtoRenditions converts domain renditions to protobuf renditions
+func toProtoRenditions(renditions []ImageRendition) []*pb.ImageRendition {
+	if len(renditions) == 0 {
+		return nil
+	}
+	converted := make([]*pb.ImageRendition, len(renditions))
+	for i, r := range renditions {
+		converted[i] = &pb.ImageRendition{
+			Name:      r.Name,
+			Format:    r.Format,
+			Url:       r.URL,
+			Width:     r.Width,
+			Height:    r.Height,
+			SizeBytes: r.SizeBytes,
+		}
+	}
+	return converted
+}
+
+// imageKeyPrefix is the object key prefix of a product's images
+func imageKeyPrefix(productID string) string {
+	return "products/" + productID + "/"
+}
@@ -0,0 +1,480 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockImageStore is an in-memory ImageStore for testing
+type mockImageStore struct {
+	objects map[string]*storage.ObjectInfo
+}
+
+func (m *mockImageStore) PresignPut(key, contentType string, contentLength int64, expires time.Duration) (string, error) {
+	return "https://storage.test/" + key + "?signed", nil
+}
+
+func (m *mockImageStore) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	if info, ok := m.objects[key]; ok {
+		return info, nil
+	}
+	return nil, storage.ErrObjectNotFound
+}
+
+func (m *mockImageStore) URL(key string) string {
+	return "https://cdn.test/" + key
+}
+
+func TestGetImageUploadURL_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id}, nil
+		},
+	}
+
+	service := setupService(mockRepo).WithImageStore(&mockImageStore{})
+	ctx := context.Background()
+
+	req := &pb.GetImageUploadURLRequest{
+		ProductId:     "p1",
+		ContentType:   "image/png",
+		ContentLength: 2048,
+	}
+
+	resp, err := service.GetImageUploadURL(ctx, req)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(resp.ObjectKey, "products/p1/") || !strings.HasSuffix(resp.ObjectKey, ".png") {
+		t.Errorf("Unexpected object key %s", resp.ObjectKey)
+	}
+
+	if resp.Headers["Content-Type"] != "image/png" || resp.Headers["Content-Length"] != "2048" {
+		t.Errorf("Unexpected upload headers %v", resp.Headers)
+	}
+}
+
+func TestGetImageUploadURL_InvalidContentType(t *testing.T) {
+	service := setupService(&MockRepository{}).WithImageStore(&mockImageStore{})
+	ctx := context.Background()
+
+	req := &pb.GetImageUploadURLRequest{
+		ProductId:     "p1",
+		ContentType:   "application/pdf",
+		ContentLength: 2048,
+	}
+
+	_, err := service.GetImageUploadURL(ctx, req)
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestGetImageUploadURL_TooLarge(t *testing.T) {
+	service := setupService(&MockRepository{}).WithImageStore(&mockImageStore{})
+	ctx := context.Background()
+
+	req := &pb.GetImageUploadURLRequest{
+		ProductId:     "p1",
+		ContentType:   "image/jpeg",
+		ContentLength: maxImageSize + 1,
+	}
+
+	_, err := service.GetImageUploadURL(ctx, req)
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestGetImageUploadURL_NotConfigured(t *testing.T) {
+	service := setupService(&MockRepository{})
+	ctx := context.Background()
+
+	_, err := service.GetImageUploadURL(ctx, &pb.GetImageUploadURLRequest{ProductId: "p1"})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented error, got %v", err)
+	}
+}
+
+func TestAttachImage_Success(t *testing.T) {
+	key := "products/p1/image.png"
+	store := &mockImageStore{objects: map[string]*storage.ObjectInfo{
+		key: {Key: key, ContentType: "image/png", Size: 2048},
+	}}
+
+	var attached, attachedKey string
+	mockRepo := &MockRepository{
+		AppendImageFunc: func(ctx context.Context, id, objectKey, imageURL, altText string) (*Product, error) {
+			attached, attachedKey = imageURL, objectKey
+			return &Product{ID: id, Images: []*ProductImage{{ID: "img-1", URL: imageURL, AltText: altText, IsPrimary: true}}}, nil
+		},
+	}
+
+	service := setupService(mockRepo).WithImageStore(store)
+	ctx := context.Background()
+
+	resp, err := service.AttachImage(ctx, &pb.AttachImageRequest{ProductId: "p1", ObjectKey: key, AltText: "  Front view "})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if attached != "https://cdn.test/"+key {
+		t.Errorf("Expected public URL to be attached, got %s", attached)
+	}
+
+	if attachedKey != key {
+		t.Errorf("Expected object key %s for the image service, got %s", key, attachedKey)
+	}
+
+	if len(resp.Product.Images) != 1 {
+		t.Errorf("Expected 1 image, got %d", len(resp.Product.Images))
+	}
+
+	if len(resp.Product.ImageDetails) != 1 || resp.Product.ImageDetails[0].AltText != "Front view" {
+		t.Errorf("Expected trimmed alt text in image details, got %v", resp.Product.ImageDetails)
+	}
+}
+
+func TestAttachImage_NotUploaded(t *testing.T) {
+	service := setupService(&MockRepository{}).WithImageStore(&mockImageStore{})
+	ctx := context.Background()
+
+	_, err := service.AttachImage(ctx, &pb.AttachImageRequest{ProductId: "p1", ObjectKey: "products/p1/missing.png"})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition error, got %v", err)
+	}
+}
+
+func TestAttachImage_ForeignKey(t *testing.T) {
+	service := setupService(&MockRepository{}).WithImageStore(&mockImageStore{})
+	ctx := context.Background()
+
+	_, err := service.AttachImage(ctx, &pb.AttachImageRequest{ProductId: "p1", ObjectKey: "products/p2/image.png"})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestAttachImage_WrongContentType(t *testing.T) {
+	key := "products/p1/file.png"
+	store := &mockImageStore{objects: map[string]*storage.ObjectInfo{
+		key: {Key: key, ContentType: "text/html", Size: 10},
+	}}
+
+	service := setupService(&MockRepository{}).WithImageStore(store)
+	ctx := context.Background()
+
+	_, err := service.AttachImage(ctx, &pb.AttachImageRequest{ProductId: "p1", ObjectKey: key})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestAttachImage_AltTextTooLong(t *testing.T) {
+	key := "products/p1/image.png"
+	store := &mockImageStore{objects: map[string]*storage.ObjectInfo{
+		key: {Key: key, ContentType: "image/png", Size: 2048},
+	}}
+
+	service := setupService(&MockRepository{}).WithImageStore(store)
+	ctx := context.Background()
+
+	_, err := service.AttachImage(ctx, &pb.AttachImageRequest{
+		ProductId: "p1",
+		ObjectKey: key,
+		AltText:   strings.Repeat("a", maxAltTextLength+1),
+	})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestReorderImages_Success(t *testing.T) {
+	first := "11111111-1111-1111-1111-111111111111"
+	second := "22222222-2222-2222-2222-222222222222"
+
+	var order []string
+	mockRepo := &MockRepository{
+		ReorderImagesFunc: func(ctx context.Context, productID string, imageIDs []string) error {
+			order = imageIDs
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, Images: []*ProductImage{
+				{ID: second, URL: "b.jpg", Position: 0},
+				{ID: first, URL: "a.jpg", Position: 1, IsPrimary: true},
+			}}, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.ReorderImages(ctx, &pb.ReorderImagesRequest{ProductId: "p1", ImageIds: []string{second, first}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(order) != 2 || order[0] != second {
+		t.Errorf("Expected new order to be passed to repository, got %v", order)
+	}
+
+	if resp.Product.Images[0] != "b.jpg" || resp.Product.ImageDetails[1].IsPrimary != true {
+		t.Errorf("Unexpected images %v", resp.Product.ImageDetails)
+	}
+}
+
+func TestReorderImages_DuplicateID(t *testing.T) {
+	service := setupService(&MockRepository{})
+	ctx := context.Background()
+
+	id := "11111111-1111-1111-1111-111111111111"
+	_, err := service.ReorderImages(ctx, &pb.ReorderImagesRequest{ProductId: "p1", ImageIds: []string{id, id}})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestReorderImages_Mismatch(t *testing.T) {
+	mockRepo := &MockRepository{
+		ReorderImagesFunc: func(ctx context.Context, productID string, imageIDs []string) error {
+			return ErrImageOrderMismatch
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.ReorderImages(ctx, &pb.ReorderImagesRequest{
+		ProductId: "p1",
+		ImageIds:  []string{"11111111-1111-1111-1111-111111111111"},
+	})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestSetPrimaryImage_Success(t *testing.T) {
+	imageID := "11111111-1111-1111-1111-111111111111"
+
+	var primary string
+	mockRepo := &MockRepository{
+		SetPrimaryImageFunc: func(ctx context.Context, productID, id string) error {
+			primary = id
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, Images: []*ProductImage{{ID: imageID, URL: "a.jpg", IsPrimary: true}}}, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.SetPrimaryImage(ctx, &pb.SetPrimaryImageRequest{ProductId: "p1", ImageId: imageID})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if primary != imageID {
+		t.Errorf("Expected image %s to be set primary, got %s", imageID, primary)
+	}
+
+	if !resp.Product.ImageDetails[0].IsPrimary {
+		t.Error("Expected image to be primary")
+	}
+}
+
+func TestSetPrimaryImage_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		SetPrimaryImageFunc: func(ctx context.Context, productID, id string) error {
+			return ErrImageNotFound
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.SetPrimaryImage(ctx, &pb.SetPrimaryImageRequest{
+		ProductId: "p1",
+		ImageId:   "11111111-1111-1111-1111-111111111111",
+	})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestReviewImage_ReplacesAltText(t *testing.T) {
+	imageID := "11111111-1111-1111-1111-111111111111"
+
+	var reviewedAlt string
+	mockRepo := &MockRepository{
+		ReviewImageFunc: func(ctx context.Context, productID, id, altText string) error {
+			reviewedAlt = altText
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, Images: []*ProductImage{{ID: imageID, URL: "a.jpg", AltText: "Red mug"}}}, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.ReviewImage(ctx, &pb.ReviewImageRequest{ProductId: "p1", ImageId: imageID, AltText: " Red mug "})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if reviewedAlt != "Red mug" {
+		t.Errorf("Expected trimmed alt text, got %q", reviewedAlt)
+	}
+
+	if resp.Product.ImageDetails[0].NeedsReview {
+		t.Error("Expected review flag to be cleared")
+	}
+}
+
+func TestReviewImage_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		ReviewImageFunc: func(ctx context.Context, productID, id, altText string) error {
+			return ErrImageNotFound
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.ReviewImage(ctx, &pb.ReviewImageRequest{
+		ProductId: "p1",
+		ImageId:   "11111111-1111-1111-1111-111111111111",
+	})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestSetImageRenditions_Success(t *testing.T) {
+	imageID := "11111111-1111-1111-1111-111111111111"
+
+	var saved []ImageRendition
+	mockRepo := &MockRepository{
+		SetImageRenditionsFunc: func(ctx context.Context, productID, id string, renditions []ImageRendition) error {
+			saved = renditions
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, Images: []*ProductImage{{ID: imageID, URL: "a.jpg", Renditions: saved}}}, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.SetImageRenditions(ctx, &pb.SetImageRenditionsRequest{
+		ProductId: "p1",
+		ImageId:   imageID,
+		Renditions: []*pb.ImageRendition{
+			{Name: "thumbnail", Format: "webp", Url: "https://cdn.test/t.webp", Width: 160, Height: 120, SizeBytes: 2048},
+			{Name: "thumbnail", Format: "jpeg", Url: "https://cdn.test/t.jpg", Width: 160, Height: 120, SizeBytes: 4096},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(saved) != 2 || saved[0].Format != "webp" || saved[1].URL != "https://cdn.test/t.jpg" {
+		t.Errorf("Expected renditions saved in order, got %+v", saved)
+	}
+
+	renditions := resp.Product.ImageDetails[0].Renditions
+	if len(renditions) != 2 || renditions[0].Width != 160 || renditions[0].SizeBytes != 2048 {
+		t.Errorf("Expected renditions in image details, got %v", renditions)
+	}
+}
+
+func TestSetImageRenditions_Invalid(t *testing.T) {
+	imageID := "11111111-1111-1111-1111-111111111111"
+	service := setupService(&MockRepository{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		renditions []*pb.ImageRendition
+	}{
+		{"missing url", []*pb.ImageRendition{{Name: "small", Format: "jpeg", Width: 480, Height: 360}}},
+		{"no dimensions", []*pb.ImageRendition{{Name: "small", Format: "jpeg", Url: "s.jpg"}}},
+		{"duplicate", []*pb.ImageRendition{
+			{Name: "small", Format: "jpeg", Url: "s.jpg", Width: 480, Height: 360},
+			{Name: "small", Format: "jpeg", Url: "s2.jpg", Width: 480, Height: 360},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SetImageRenditions(ctx, &pb.SetImageRenditionsRequest{ProductId: "p1", ImageId: imageID, Renditions: tt.renditions})
+
+			st, ok := status.FromError(err)
+			if !ok || st.Code() != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetImageRenditions_ImageDeleted(t *testing.T) {
+	mockRepo := &MockRepository{
+		SetImageRenditionsFunc: func(ctx context.Context, productID, id string, renditions []ImageRendition) error {
+			return ErrImageNotFound
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.SetImageRenditions(ctx, &pb.SetImageRenditionsRequest{
+		ProductId: "p1",
+		ImageId:   "11111111-1111-1111-1111-111111111111",
+	})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
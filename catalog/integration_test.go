@@ -9,7 +9,7 @@ import (
 
 	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
-	_ "github.com/lib/pq"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -44,7 +44,7 @@ func setupIntegrationTest(t *testing.T) (*Service, func()) {
 	}
 
 	// Connect to database
-	db, err := sql.Open("postgres", connStr)
+	db, err := sql.Open("pgx", connStr)
 	if err != nil {
 		t.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -81,21 +81,211 @@ func runMigrations(db *sql.DB) error {
 			price DECIMAL(10, 2) NOT NULL CHECK (price >= 0),
 			sku VARCHAR(100) UNIQUE NOT NULL,
 			stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
-			images TEXT[],
 			category VARCHAR(100),
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			description_blocks JSONB NOT NULL DEFAULT '[]',
+			sale_price DECIMAL(10, 2) CHECK (sale_price > 0),
+			sale_starts_at TIMESTAMP,
+			sale_ends_at TIMESTAMP,
+			low_stock_threshold INTEGER NOT NULL DEFAULT 0 CHECK (low_stock_threshold >= 0),
+			product_type VARCHAR(20) NOT NULL DEFAULT 'PHYSICAL' CHECK (product_type IN ('PHYSICAL', 'DIGITAL')),
+			weight_kg DECIMAL(10, 3) NOT NULL DEFAULT 0 CHECK (weight_kg >= 0),
+			length_cm DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (length_cm >= 0),
+			width_cm DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (width_cm >= 0),
+			height_cm DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (height_cm >= 0),
+			shipping_class VARCHAR(20) NOT NULL DEFAULT 'STANDARD',
+			ean VARCHAR(13) UNIQUE,
+			upc VARCHAR(12) UNIQUE,
+			isbn VARCHAR(13) UNIQUE,
+			average_rating DECIMAL(3, 2) NOT NULL DEFAULT 0 CHECK (average_rating >= 0 AND average_rating <= 5),
+			review_count INTEGER NOT NULL DEFAULT 0 CHECK (review_count >= 0),
+			rating_updated_at TIMESTAMP,
+			status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'DRAFT')),
+			version BIGINT NOT NULL DEFAULT 1,
+			min_order_qty INTEGER NOT NULL DEFAULT 0 CHECK (min_order_qty >= 0),
+			max_order_qty INTEGER NOT NULL DEFAULT 0 CHECK (max_order_qty >= 0),
+			qty_increment INTEGER NOT NULL DEFAULT 0 CHECK (qty_increment >= 0),
+			vendor_id VARCHAR(255) NOT NULL DEFAULT '',
+			CONSTRAINT products_order_qty_range CHECK (max_order_qty = 0 OR max_order_qty >= min_order_qty),
+			CHECK (product_type = 'PHYSICAL' OR (stock = 0 AND low_stock_threshold = 0)),
+			CHECK (sale_price < price),
+			CHECK (sale_ends_at > sale_starts_at)
 		);
 	`
 	if _, err := db.Exec(createTableSQL); err != nil {
 		return fmt.Errorf("failed to create products table: %w", err)
 	}
 
+	// Create SKU aliases table; product lookups by SKU resolve former SKUs
+	createSKUAliasesSQL := `
+		CREATE TABLE IF NOT EXISTS product_sku_aliases (
+			sku VARCHAR(100) PRIMARY KEY,
+			product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+			changed_by VARCHAR(255) NOT NULL,
+			changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createSKUAliasesSQL); err != nil {
+		return fmt.Errorf("failed to create sku aliases table: %w", err)
+	}
+
+	// Create product images table
+	createImagesSQL := `
+		CREATE TABLE IF NOT EXISTS product_images (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+			url TEXT NOT NULL,
+			alt_text VARCHAR(500) NOT NULL DEFAULT '',
+			position INTEGER NOT NULL DEFAULT 0,
+			is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+			width INTEGER,
+			height INTEGER,
+			validation_status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+			validation_errors TEXT[] NOT NULL DEFAULT '{}',
+			alt_text_generated BOOLEAN NOT NULL DEFAULT FALSE,
+			needs_review BOOLEAN NOT NULL DEFAULT FALSE,
+			renditions JSONB NOT NULL DEFAULT '[]',
+			processing_attempts INTEGER NOT NULL DEFAULT 0,
+			claimed_until TIMESTAMP,
+			processed_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (product_id, url)
+		);
+	`
+	if _, err := db.Exec(createImagesSQL); err != nil {
+		return fmt.Errorf("failed to create product images table: %w", err)
+	}
+
+	// Create price history table
+	createPriceHistorySQL := `
+		CREATE TABLE IF NOT EXISTS price_history (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID NOT NULL,
+			old_price DECIMAL(10, 2),
+			new_price DECIMAL(10, 2) NOT NULL,
+			changed_by VARCHAR(255) NOT NULL,
+			changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			seq BIGINT NOT NULL UNIQUE,
+			prev_hash CHAR(64) NOT NULL,
+			hash CHAR(64) NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS price_history_anchors (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			seq BIGINT NOT NULL,
+			hash CHAR(64) NOT NULL,
+			signature VARCHAR(64) NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createPriceHistorySQL); err != nil {
+		return fmt.Errorf("failed to create price history table: %w", err)
+	}
+
+	// Create product audit log table
+	createAuditLogSQL := `
+		CREATE TABLE IF NOT EXISTS product_audit_log (
+			id BIGSERIAL PRIMARY KEY,
+			product_id UUID NOT NULL,
+			action VARCHAR(10) NOT NULL,
+			actor VARCHAR(255) NOT NULL,
+			changes JSONB NOT NULL DEFAULT '[]',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createAuditLogSQL); err != nil {
+		return fmt.Errorf("failed to create product audit log table: %w", err)
+	}
+
+	// Create outbox table
+	createOutboxSQL := `
+		CREATE TABLE IF NOT EXISTS catalog_outbox (
+			id BIGSERIAL PRIMARY KEY,
+			event_id UUID NOT NULL UNIQUE,
+			topic VARCHAR(255) NOT NULL,
+			event_key VARCHAR(255) NOT NULL,
+			event_type VARCHAR(100) NOT NULL,
+			payload TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			published_at TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createOutboxSQL); err != nil {
+		return fmt.Errorf("failed to create outbox table: %w", err)
+	}
+
+	// Create product activity tables
+	createActivitySQL := `
+		CREATE TABLE IF NOT EXISTS product_activity_daily (
+			product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+			day DATE NOT NULL,
+			views BIGINT NOT NULL DEFAULT 0,
+			orders BIGINT NOT NULL DEFAULT 0,
+			units BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (product_id, day)
+		);
+		CREATE TABLE IF NOT EXISTS product_activity_events (
+			event_id VARCHAR(255) PRIMARY KEY,
+			recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createActivitySQL); err != nil {
+		return fmt.Errorf("failed to create product activity tables: %w", err)
+	}
+
+	// Create product visibility table
+	createVisibilitySQL := `
+		CREATE TABLE IF NOT EXISTS product_visibility (
+			product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+			channels TEXT[] NOT NULL DEFAULT '{}',
+			customer_groups TEXT[] NOT NULL DEFAULT '{}',
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createVisibilitySQL); err != nil {
+		return fmt.Errorf("failed to create product visibility table: %w", err)
+	}
+
+	// Create product question and answer tables
+	createQuestionsSQL := `
+		CREATE TABLE IF NOT EXISTS product_questions (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+			user_id VARCHAR(255) NOT NULL,
+			body TEXT NOT NULL,
+			status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+			moderated_by VARCHAR(255),
+			moderated_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS product_answers (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			question_id UUID NOT NULL REFERENCES product_questions(id) ON DELETE CASCADE,
+			user_id VARCHAR(255) NOT NULL,
+			body TEXT NOT NULL,
+			status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+			moderated_by VARCHAR(255),
+			moderated_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	if _, err := db.Exec(createQuestionsSQL); err != nil {
+		return fmt.Errorf("failed to create product question tables: %w", err)
+	}
+
 	// Create indexes
 	indexes := []string{
 		"CREATE INDEX IF NOT EXISTS idx_products_sku ON products(sku);",
 		"CREATE INDEX IF NOT EXISTS idx_products_category ON products(category);",
 		"CREATE INDEX IF NOT EXISTS idx_products_name ON products(name);",
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm;",
+		"CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (LOWER(name) gin_trgm_ops);",
+		"CREATE INDEX IF NOT EXISTS idx_products_name_prefix ON products (LOWER(name) text_pattern_ops);",
+		"CREATE INDEX IF NOT EXISTS idx_products_category_prefix ON products (LOWER(category) text_pattern_ops);",
+		"CREATE INDEX IF NOT EXISTS idx_product_questions_product ON product_questions(product_id, status, created_at DESC);",
+		"CREATE INDEX IF NOT EXISTS idx_product_answers_question ON product_answers(question_id, created_at);",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_product_images_primary ON product_images(product_id) WHERE is_primary;",
 	}
 
 	for _, indexSQL := range indexes {
@@ -382,6 +572,7 @@ func TestIntegration_UpdateProduct(t *testing.T) {
 		Stock:       20,
 		Images:      []string{"new-image.jpg"},
 		Category:    "Books",
+		Version:     createResp.Product.Version,
 	}
 
 	updateResp, err := service.UpdateProduct(ctx, updateReq)
@@ -406,6 +597,15 @@ func TestIntegration_UpdateProduct(t *testing.T) {
 	if updateResp.Product.Sku != createReq.Sku {
 		t.Errorf("SKU should not change, expected %s, got %s", createReq.Sku, updateResp.Product.Sku)
 	}
+
+	if updateResp.Product.Version != updateReq.Version+1 {
+		t.Errorf("Expected version %d, got %d", updateReq.Version+1, updateResp.Product.Version)
+	}
+
+	// An update based on the previous version is rejected
+	if _, err := service.UpdateProduct(ctx, updateReq); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a stale version, got %v", err)
+	}
 }
 
 func TestIntegration_DeleteProduct(t *testing.T) {
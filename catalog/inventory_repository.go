@@ -0,0 +1,159 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Inventory update outcomes
+const (
+	InventoryUpdated   = "UPDATED"
+	InventoryUnchanged = "UNCHANGED"
+	InventoryNotFound  = "NOT_FOUND"
+	InventoryRejected  = "REJECTED"
+)
+
+// InventoryUpdate sets the stock and list price of the product with a SKU
+type InventoryUpdate struct {
+	SKU string
+	// SetStock is false to leave the stock unchanged
+	SetStock bool
+	Stock    int32
+	// Price is 0 to leave the price unchanged
+	Price float64
+}
+
+// InventoryUpdateResult is the outcome of an inventory update
+type InventoryUpdateResult struct {
+	SKU       string
+	ProductID string
+	Status    string
+	// Message explains a REJECTED update
+	Message           string
+	OldStock          int32
+	NewStock          int32
+	OldPrice          float64
+	NewPrice          float64
+	LowStockThreshold int32
+}
+
+// ApplyInventoryUpdates applies a batch of inventory updates in one
+// transaction, locking products in SKU order so concurrent batches cannot
+// deadlock. Each changed product gets a new version, a price history entry
+// when its price changed, an audit entry and a product event. Unknown SKUs
+// and updates the product cannot take are reported in the results, which are
+// in the order of updates.
+func (r *postgresRepository) ApplyInventoryUpdates(ctx context.Context, updates []*InventoryUpdate, actor string) ([]*InventoryUpdateResult, error) {
+	order := make([]int, len(updates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return updates[order[a]].SKU < updates[order[b]].SKU })
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	results := make([]*InventoryUpdateResult, len(updates))
+	var updated int
+	for _, i := range order {
+		result, err := applyInventoryUpdate(ctx, tx, updates[i], actor, now)
+		if err != nil {
+			r.log.Error(ctx, "Failed to apply inventory update", map[string]interface{}{"error": err.Error(), "sku": updates[i].SKU})
+			return nil, err
+		}
+		if result.Status == InventoryUpdated {
+			updated++
+		}
+		results[i] = result
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit inventory updates", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to commit inventory updates: %w", err)
+	}
+
+	r.log.Info(ctx, "Inventory updates applied", map[string]interface{}{"updates": len(updates), "updated": updated, "actor": actor})
+	return results, nil
+}
+
+// applyInventoryUpdate locks the product with the update's current or former
+// SKU and applies the update to it
+func applyInventoryUpdate(ctx context.Context, tx *sql.Tx, u *InventoryUpdate, actor string, at time.Time) (*InventoryUpdateResult, error) {
+	result := &InventoryUpdateResult{SKU: u.SKU}
+
+	var productType string
+	var salePrice sql.NullFloat64
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, stock, price, sale_price, low_stock_threshold, product_type FROM products
+		WHERE sku = $1 OR id = (SELECT product_id FROM product_sku_aliases WHERE sku = $1)
+		ORDER BY sku = $1 DESC
+		LIMIT 1
+		FOR UPDATE
+	`, u.SKU).Scan(&result.ProductID, &result.OldStock, &result.OldPrice, &salePrice, &result.LowStockThreshold, &productType)
+	if err == sql.ErrNoRows {
+		result.Status = InventoryNotFound
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	result.NewStock, result.NewPrice = result.OldStock, result.OldPrice
+	if u.SetStock {
+		result.NewStock = u.Stock
+	}
+	if u.Price > 0 {
+		result.NewPrice = u.Price
+	}
+
+	switch {
+	case u.SetStock && productType == ProductTypeDigital && u.Stock != result.OldStock:
+		result.Status, result.Message = InventoryRejected, "product does not track stock"
+		return result, nil
+	case salePrice.Valid && salePrice.Float64 >= result.NewPrice:
+		result.Status, result.Message = InventoryRejected, "price must be above the sale price"
+		return result, nil
+	}
+
+	var changes []FieldChange
+	if result.NewPrice != result.OldPrice {
+		changes = append(changes, FieldChange{Field: "price", Old: auditFloat(result.OldPrice), New: auditFloat(result.NewPrice)})
+	}
+	if result.NewStock != result.OldStock {
+		changes = append(changes, FieldChange{Field: "stock", Old: strconv.Itoa(int(result.OldStock)), New: strconv.Itoa(int(result.NewStock))})
+	}
+	if len(changes) == 0 {
+		result.Status = InventoryUnchanged
+		return result, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE products SET stock = $2, price = $3, updated_at = $4, version = version + 1 WHERE id = $1
+	`, result.ProductID, result.NewStock, result.NewPrice, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update inventory: %w", err)
+	}
+	if result.NewPrice != result.OldPrice {
+		if err := recordPriceChange(ctx, tx, result.ProductID, &result.OldPrice, actor, at); err != nil {
+			return nil, err
+		}
+	}
+	if err := recordProductAudit(ctx, tx, result.ProductID, AuditActionUpdate, actor, changes, at); err != nil {
+		return nil, err
+	}
+	if err := recordProductEvent(ctx, tx, result.ProductID, AuditActionUpdate, actor, changes, at); err != nil {
+		return nil, err
+	}
+
+	result.Status = InventoryUpdated
+	return result, nil
+}
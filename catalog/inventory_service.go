@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxInventoryUpdates caps the updates applied by one ApplyInventoryUpdates call
+	maxInventoryUpdates = 500
+	// maxPrice fits the DECIMAL(10, 2) price column
+	maxPrice = 99999999.99
+)
+
+// ApplyInventoryUpdates sets the stock and list price of a batch of products
+// by SKU. The whole batch is applied or, on a database failure, none of it;
+// unknown SKUs and updates a product cannot take are reported per update.
+// Stock falling to the low-stock threshold emits a low-stock event.
+func (s *Service) ApplyInventoryUpdates(ctx context.Context, req *pb.ApplyInventoryUpdatesRequest) (*pb.ApplyInventoryUpdatesResponse, error) {
+	updates, msg := inventoryUpdatesFromRequest(req)
+	if msg != "" {
+		s.log.Warn(ctx, "Apply inventory updates failed: "+msg, map[string]interface{}{"count": len(req.Updates)})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	results, err := s.repo.ApplyInventoryUpdates(ctx, updates, actorFromContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to apply inventory updates")
+	}
+
+	resp := &pb.ApplyInventoryUpdatesResponse{Results: make([]*pb.InventoryUpdateResult, len(results))}
+	for i, r := range results {
+		if r.Status == InventoryUpdated {
+			s.emitLowStock(ctx, r.ProductID, isLowStock(r.OldStock, r.LowStockThreshold), &StockLevel{
+				ProductID:         r.ProductID,
+				Stock:             r.NewStock,
+				LowStockThreshold: r.LowStockThreshold,
+			})
+		}
+		resp.Results[i] = &pb.InventoryUpdateResult{
+			Sku:       r.SKU,
+			ProductId: r.ProductID,
+			Status:    r.Status,
+			Message:   r.Message,
+			OldStock:  r.OldStock,
+			NewStock:  r.NewStock,
+			OldPrice:  r.OldPrice,
+			NewPrice:  r.NewPrice,
+		}
+	}
+	return resp, nil
+}
+
+// inventoryUpdatesFromRequest validates the updates of a request and returns
+// them with prices rounded to cents, or a message describing the first problem
+func inventoryUpdatesFromRequest(req *pb.ApplyInventoryUpdatesRequest) ([]*InventoryUpdate, string) {
+	switch {
+	case len(req.Updates) == 0:
+		return nil, "updates are required"
+	case len(req.Updates) > maxInventoryUpdates:
+		return nil, fmt.Sprintf("at most %d updates can be applied at once", maxInventoryUpdates)
+	}
+
+	updates := make([]*InventoryUpdate, len(req.Updates))
+	seen := make(map[string]bool, len(req.Updates))
+	for i, u := range req.Updates {
+		sku := strings.TrimSpace(u.Sku)
+		switch {
+		case sku == "":
+			return nil, "sku is required"
+		case len(sku) > maxSKULength:
+			return nil, fmt.Sprintf("skus cannot exceed %d characters", maxSKULength)
+		case seen[sku]:
+			return nil, fmt.Sprintf("sku %s is updated more than once", sku)
+		case u.SetStock && u.Stock < 0:
+			return nil, fmt.Sprintf("stock of sku %s cannot be negative", sku)
+		case u.Price < 0 || u.Price > maxPrice || math.IsNaN(u.Price):
+			return nil, fmt.Sprintf("price of sku %s must be between 0 and 99999999.99", sku)
+		case !u.SetStock && u.Price == 0:
+			return nil, fmt.Sprintf("sku %s sets neither stock nor price", sku)
+		}
+		seen[sku] = true
+		updates[i] = &InventoryUpdate{
+			SKU:      sku,
+			SetStock: u.SetStock,
+			Stock:    u.Stock,
+			Price:    math.Round(u.Price*100) / 100,
+		}
+	}
+	return updates, ""
+}
@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestApplyInventoryUpdates_Success(t *testing.T) {
+	var applied []*InventoryUpdate
+	repo := &MockRepository{
+		ApplyInventoryUpdatesFunc: func(ctx context.Context, updates []*InventoryUpdate, actor string) ([]*InventoryUpdateResult, error) {
+			applied = updates
+			return []*InventoryUpdateResult{
+				{SKU: "SKU-1", ProductID: "p1", Status: InventoryUpdated, OldStock: 10, NewStock: 2, OldPrice: 5, NewPrice: 5, LowStockThreshold: 3},
+				{SKU: "SKU-2", Status: InventoryNotFound},
+			}, nil
+		},
+	}
+	sink := &recordingStockSink{}
+	service := setupService(repo).WithStockEventSink(sink)
+
+	resp, err := service.ApplyInventoryUpdates(context.Background(), &pb.ApplyInventoryUpdatesRequest{
+		Updates: []*pb.InventoryUpdate{
+			{Sku: " SKU-1 ", SetStock: true, Stock: 2},
+			{Sku: "SKU-2", Price: 12.345},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if applied[0].SKU != "SKU-1" || applied[1].Price != 12.35 {
+		t.Errorf("Expected trimmed SKUs and prices rounded to cents, got %+v %+v", applied[0], applied[1])
+	}
+	if len(resp.Results) != 2 || resp.Results[0].NewStock != 2 || resp.Results[1].Status != InventoryNotFound {
+		t.Errorf("Unexpected results %v", resp.Results)
+	}
+	if len(sink.events) != 1 || sink.events[0].ProductID != "p1" {
+		t.Errorf("Expected a low-stock event for p1, got %v", sink.events)
+	}
+}
+
+func TestApplyInventoryUpdates_InvalidRequest(t *testing.T) {
+	service := setupService(&MockRepository{})
+
+	tests := []struct {
+		name    string
+		updates []*pb.InventoryUpdate
+	}{
+		{"no updates", nil},
+		{"missing sku", []*pb.InventoryUpdate{{SetStock: true, Stock: 1}}},
+		{"duplicate sku", []*pb.InventoryUpdate{{Sku: "SKU-1", Price: 1}, {Sku: "SKU-1", Price: 2}}},
+		{"negative stock", []*pb.InventoryUpdate{{Sku: "SKU-1", SetStock: true, Stock: -1}}},
+		{"negative price", []*pb.InventoryUpdate{{Sku: "SKU-1", Price: -1}}},
+		{"nothing to set", []*pb.InventoryUpdate{{Sku: "SKU-1"}}},
+		{"too many updates", make([]*pb.InventoryUpdate, maxInventoryUpdates+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ApplyInventoryUpdates(context.Background(), &pb.ApplyInventoryUpdatesRequest{Updates: tt.updates})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
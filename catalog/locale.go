@@ -0,0 +1,116 @@
+package catalog
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the locale of the name and description stored on products
+// unless the service is configured otherwise
+const DefaultLocale = "en"
+
+// maxAcceptedLocales caps the locales taken from one Accept-Language value
+const maxAcceptedLocales = 10
+
+// normalizeLocale returns the canonical form of a BCP 47 language tag: a
+// lowercase language, a titlecase script and an uppercase region joined by
+// hyphens, so "zh_hant_tw" becomes "zh-Hant-TW"
+func normalizeLocale(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || len(tag) > 35 {
+		return "", false
+	}
+
+	parts := strings.Split(strings.ReplaceAll(tag, "_", "-"), "-")
+	if len(parts[0]) < 2 || len(parts[0]) > 3 || !isLetters(parts[0]) {
+		return "", false
+	}
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		p := parts[i]
+		switch {
+		case len(p) == 4 && isLetters(p):
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		case len(p) == 2 && isLetters(p), len(p) == 3 && isDigits(p):
+			parts[i] = strings.ToUpper(p)
+		case len(p) >= 1 && len(p) <= 8 && isAlphanumeric(p):
+			parts[i] = strings.ToLower(p)
+		default:
+			return "", false
+		}
+	}
+	return strings.Join(parts, "-"), true
+}
+
+// parseAcceptLanguage returns the locales of an Accept-Language value such as
+// "fr-CA, fr;q=0.9, en;q=0.5" in preference order. Wildcards, invalid tags
+// and entries with q=0 are skipped.
+func parseAcceptLanguage(value string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var entries []weighted
+	for _, entry := range strings.Split(value, ",") {
+		tag, params, _ := strings.Cut(entry, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		locale, ok := normalizeLocale(tag)
+		if !ok || q <= 0 {
+			continue
+		}
+		entries = append(entries, weighted{locale, q})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+
+	var locales []string
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if seen[e.locale] {
+			continue
+		}
+		seen[e.locale] = true
+		locales = append(locales, e.locale)
+		if len(locales) == maxAcceptedLocales {
+			break
+		}
+	}
+	return locales
+}
+
+// localeFallbacks returns a locale followed by its less specific forms, so
+// "zh-Hant-TW" gives "zh-Hant-TW", "zh-Hant" and "zh"
+func localeFallbacks(locale string) []string {
+	fallbacks := []string{locale}
+	for i := strings.LastIndex(locale, "-"); i > 0; i = strings.LastIndex(locale, "-") {
+		locale = locale[:i]
+		fallbacks = append(fallbacks, locale)
+	}
+	return fallbacks
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && !isLetters(string(r)) {
+			return false
+		}
+	}
+	return true
+}
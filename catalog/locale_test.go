@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+		valid    bool
+	}{
+		{"fr", "fr", true},
+		{"pt_br", "pt-BR", true},
+		{"ZH-hant-tw", "zh-Hant-TW", true},
+		{"es-419", "es-419", true},
+		{" de-CH ", "de-CH", true},
+		{"", "", false},
+		{"f", "", false},
+		{"english", "", false},
+		{"fr-", "", false},
+		{"fr-ca!", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := normalizeLocale(tt.tag)
+		if ok != tt.valid || got != tt.expected {
+			t.Errorf("normalizeLocale(%q) = %q, %v; expected %q, %v", tt.tag, got, ok, tt.expected, tt.valid)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected []string
+	}{
+		{"", nil},
+		{"fr-CA", []string{"fr-CA"}},
+		{"en;q=0.5, fr-ca, de;q=0.8", []string{"fr-CA", "de", "en"}},
+		{"*, es;q=0, it;q=0.3, it", []string{"it"}},
+		{"nl;q=abc, pl", []string{"pl"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseAcceptLanguage(tt.value); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("parseAcceptLanguage(%q) = %v; expected %v", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	expected := []string{"zh-Hant-TW", "zh-Hant", "zh"}
+	if got := localeFallbacks("zh-Hant-TW"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
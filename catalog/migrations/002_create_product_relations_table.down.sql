@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_product_relations_related;
+DROP TABLE IF EXISTS product_relations;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS product_relations (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    related_product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    relation_type VARCHAR(20) NOT NULL CHECK (relation_type IN ('RELATED', 'UPSELL', 'CROSS_SELL')),
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, relation_type, related_product_id),
+    CHECK (product_id <> related_product_id)
+);
+
+-- Reverse lookups (which products link to this one)
+CREATE INDEX idx_product_relations_related ON product_relations(related_product_id);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trigger_update_product_bookings_updated_at ON product_bookings;
+DROP TRIGGER IF EXISTS trigger_update_product_booking_configs_updated_at ON product_booking_configs;
+DROP INDEX IF EXISTS idx_product_bookings_active;
+DROP TABLE IF EXISTS product_bookings;
+DROP TABLE IF EXISTS product_booking_configs;
@@ -0,0 +1,36 @@
+-- Products that can be booked for a date range (rentals, appointments)
+CREATE TABLE IF NOT EXISTS product_booking_configs (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    capacity INTEGER NOT NULL DEFAULT 1 CHECK (capacity > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS product_bookings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'HELD' CHECK (status IN ('HELD', 'CONFIRMED', 'CANCELLED')),
+    reference VARCHAR(255),
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+-- Overlap lookups only consider active bookings
+CREATE INDEX idx_product_bookings_active ON product_bookings(product_id, starts_at, ends_at)
+    WHERE status <> 'CANCELLED';
+
+CREATE TRIGGER trigger_update_product_booking_configs_updated_at
+    BEFORE UPDATE ON product_booking_configs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_products_updated_at();
+
+CREATE TRIGGER trigger_update_product_bookings_updated_at
+    BEFORE UPDATE ON product_bookings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_products_updated_at();
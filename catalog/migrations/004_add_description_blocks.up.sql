@@ -0,0 +1,2 @@
+-- Structured rich content blocks; description keeps a plain-text rendering for search
+ALTER TABLE products ADD COLUMN description_blocks JSONB NOT NULL DEFAULT '[]';
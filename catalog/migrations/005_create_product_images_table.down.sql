@@ -0,0 +1,13 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS images TEXT[];
+
+UPDATE products p
+SET images = (
+    SELECT array_agg(i.url ORDER BY i.position)
+    FROM product_images i
+    WHERE i.product_id = p.id
+);
+
+DROP TRIGGER IF EXISTS trigger_update_product_images_updated_at ON product_images;
+DROP INDEX IF EXISTS idx_product_images_primary;
+DROP INDEX IF EXISTS idx_product_images_product;
+DROP TABLE IF EXISTS product_images;
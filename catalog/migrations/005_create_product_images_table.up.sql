@@ -0,0 +1,34 @@
+-- Product images with display metadata, replacing the products.images URL array
+CREATE TABLE IF NOT EXISTS product_images (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    alt_text VARCHAR(500) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, url)
+);
+
+CREATE INDEX idx_product_images_product ON product_images(product_id, position);
+
+-- At most one primary image per product
+CREATE UNIQUE INDEX idx_product_images_primary ON product_images(product_id) WHERE is_primary;
+
+CREATE TRIGGER trigger_update_product_images_updated_at
+    BEFORE UPDATE ON product_images
+    FOR EACH ROW
+    EXECUTE FUNCTION update_products_updated_at();
+
+-- Move existing URLs over, keeping their order; the first image becomes primary
+INSERT INTO product_images (product_id, url, position, is_primary)
+SELECT p.id, img.url, img.ord - 1, img.ord = 1
+FROM products p
+CROSS JOIN LATERAL (
+    SELECT DISTINCT ON (u.url) u.url, u.ord
+    FROM unnest(p.images) WITH ORDINALITY AS u(url, ord)
+    ORDER BY u.url, u.ord
+) img;
+
+ALTER TABLE products DROP COLUMN IF EXISTS images;
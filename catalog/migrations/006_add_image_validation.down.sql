@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_product_images_review;
+DROP INDEX IF EXISTS idx_product_images_pending;
+
+ALTER TABLE product_images
+    DROP COLUMN IF EXISTS processed_at,
+    DROP COLUMN IF EXISTS claimed_until,
+    DROP COLUMN IF EXISTS processing_attempts,
+    DROP COLUMN IF EXISTS needs_review,
+    DROP COLUMN IF EXISTS alt_text_generated,
+    DROP COLUMN IF EXISTS validation_errors,
+    DROP COLUMN IF EXISTS validation_status,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width;
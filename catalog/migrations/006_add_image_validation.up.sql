@@ -0,0 +1,18 @@
+-- Results of the asynchronous image validation and alt-text pipeline
+ALTER TABLE product_images
+    ADD COLUMN IF NOT EXISTS width INTEGER,
+    ADD COLUMN IF NOT EXISTS height INTEGER,
+    ADD COLUMN IF NOT EXISTS validation_status VARCHAR(20) NOT NULL DEFAULT 'PENDING'
+        CHECK (validation_status IN ('PENDING', 'PASSED', 'FAILED')),
+    ADD COLUMN IF NOT EXISTS validation_errors TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS alt_text_generated BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS needs_review BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS processing_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS processed_at TIMESTAMP;
+
+-- Pending images are picked up by the pipeline in upload order
+CREATE INDEX IF NOT EXISTS idx_product_images_pending ON product_images(created_at) WHERE validation_status = 'PENDING';
+
+-- Images flagged for admin review
+CREATE INDEX IF NOT EXISTS idx_product_images_review ON product_images(product_id) WHERE needs_review;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_price_history_product;
+DROP TABLE IF EXISTS price_history;
@@ -0,0 +1,17 @@
+-- Every price a product has had, for auditing and "was/now" pricing.
+-- No foreign key: history is kept after a product is deleted.
+CREATE TABLE IF NOT EXISTS price_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL,
+    old_price DECIMAL(10, 2), -- NULL for a product's initial price
+    new_price DECIMAL(10, 2) NOT NULL CHECK (new_price >= 0),
+    changed_by VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_price_history_product ON price_history(product_id, changed_at DESC);
+
+-- Current prices of existing products become their initial entries
+INSERT INTO price_history (product_id, old_price, new_price, changed_by, changed_at)
+SELECT id, NULL, price, 'system', created_at
+FROM products;
@@ -0,0 +1,9 @@
+-- Quantity-break prices: a tier's unit price applies from min_quantity up to
+-- the next tier. Quantities below the first tier pay the product price.
+CREATE TABLE IF NOT EXISTS product_price_tiers (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    min_quantity INTEGER NOT NULL CHECK (min_quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, min_quantity)
+);
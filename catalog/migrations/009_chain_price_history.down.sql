@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_price_history_anchors_seq;
+DROP TABLE IF EXISTS price_history_anchors;
+
+ALTER TABLE price_history
+    DROP CONSTRAINT IF EXISTS price_history_seq_unique,
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS seq;
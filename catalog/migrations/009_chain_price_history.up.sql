@@ -0,0 +1,50 @@
+-- Hash-chain the price history so edits and deletions are detectable: every
+-- entry stores the hash of the previous one, and anchors periodically record
+-- the chain head.
+ALTER TABLE price_history
+    ADD COLUMN seq BIGINT,
+    ADD COLUMN prev_hash CHAR(64),
+    ADD COLUMN hash CHAR(64);
+
+-- Chain existing entries in insertion order. The hash input must match
+-- hashPriceChange in catalog/audit_chain.go.
+DO $$
+DECLARE
+    entry RECORD;
+    n BIGINT := 0;
+    prev CHAR(64) := repeat('0', 64);
+    h CHAR(64);
+BEGIN
+    FOR entry IN SELECT * FROM price_history ORDER BY changed_at, id LOOP
+        n := n + 1;
+        h := encode(sha256(convert_to(concat_ws('|',
+            n::text,
+            prev,
+            entry.id::text,
+            entry.product_id::text,
+            COALESCE(entry.old_price::text, ''),
+            entry.new_price::text,
+            entry.changed_by,
+            (EXTRACT(EPOCH FROM entry.changed_at) * 1000000)::bigint::text
+        ), 'UTF8')), 'hex');
+        UPDATE price_history SET seq = n, prev_hash = prev, hash = h WHERE id = entry.id;
+        prev := h;
+    END LOOP;
+END $$;
+
+ALTER TABLE price_history
+    ALTER COLUMN seq SET NOT NULL,
+    ALTER COLUMN prev_hash SET NOT NULL,
+    ALTER COLUMN hash SET NOT NULL,
+    ADD CONSTRAINT price_history_seq_unique UNIQUE (seq);
+
+-- Checkpoints of the chain head, signed with AUDIT_ANCHOR_KEY when configured
+CREATE TABLE IF NOT EXISTS price_history_anchors (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    seq BIGINT NOT NULL,
+    hash CHAR(64) NOT NULL,
+    signature VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_price_history_anchors_seq ON price_history_anchors(seq);
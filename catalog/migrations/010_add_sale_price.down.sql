@@ -0,0 +1,6 @@
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_sale_window,
+    DROP CONSTRAINT IF EXISTS products_sale_below_price,
+    DROP COLUMN IF EXISTS sale_ends_at,
+    DROP COLUMN IF EXISTS sale_starts_at,
+    DROP COLUMN IF EXISTS sale_price;
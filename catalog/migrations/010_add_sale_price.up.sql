@@ -0,0 +1,9 @@
+-- Time-bound sale price; a NULL bound leaves that side of the window open
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS sale_price DECIMAL(10, 2) CHECK (sale_price > 0),
+    ADD COLUMN IF NOT EXISTS sale_starts_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS sale_ends_at TIMESTAMP;
+
+ALTER TABLE products
+    ADD CONSTRAINT products_sale_below_price CHECK (sale_price < price),
+    ADD CONSTRAINT products_sale_window CHECK (sale_ends_at > sale_starts_at);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trigger_update_stock_reservations_updated_at ON stock_reservations;
+DROP INDEX IF EXISTS idx_stock_reservations_held;
+DROP TABLE IF EXISTS stock_reservations;
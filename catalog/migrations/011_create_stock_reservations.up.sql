@@ -0,0 +1,21 @@
+-- Stock held for a checkout. Reserving deducts products.stock immediately;
+-- releasing or expiring a hold returns it, committing keeps it deducted.
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'HELD' CHECK (status IN ('HELD', 'COMMITTED', 'RELEASED', 'EXPIRED')),
+    reference VARCHAR(255),
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The expiry sweep only scans held reservations
+CREATE INDEX idx_stock_reservations_held ON stock_reservations(expires_at)
+    WHERE status = 'HELD';
+
+CREATE TRIGGER trigger_update_stock_reservations_updated_at
+    BEFORE UPDATE ON stock_reservations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_products_updated_at();
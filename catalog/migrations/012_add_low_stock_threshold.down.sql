@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_products_low_stock;
+ALTER TABLE products DROP COLUMN IF EXISTS low_stock_threshold;
@@ -0,0 +1,7 @@
+-- Per-product low-stock threshold; 0 disables low-stock alerts
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS low_stock_threshold INTEGER NOT NULL DEFAULT 0 CHECK (low_stock_threshold >= 0);
+
+-- ListLowStockProducts only scans products at or below their threshold
+CREATE INDEX idx_products_low_stock ON products(stock)
+    WHERE low_stock_threshold > 0 AND stock <= low_stock_threshold;
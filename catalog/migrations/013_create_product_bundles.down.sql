@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_update_product_bundles_updated_at ON product_bundles;
+DROP INDEX IF EXISTS idx_product_bundle_components_component;
+DROP TABLE IF EXISTS product_bundle_components;
+DROP TABLE IF EXISTS product_bundles;
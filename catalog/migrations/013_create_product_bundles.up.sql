@@ -0,0 +1,25 @@
+-- A bundle is a product sold as a kit of component products. Its price and
+-- stock are computed from the components.
+CREATE TABLE IF NOT EXISTS product_bundles (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    discount_percent DECIMAL(5, 2) NOT NULL DEFAULT 0 CHECK (discount_percent >= 0 AND discount_percent < 100),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS product_bundle_components (
+    bundle_id UUID NOT NULL REFERENCES product_bundles(product_id) ON DELETE CASCADE,
+    component_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (bundle_id, component_id),
+    CHECK (bundle_id <> component_id)
+);
+
+-- Finds the bundles a product is part of
+CREATE INDEX idx_product_bundle_components_component ON product_bundle_components(component_id);
+
+CREATE TRIGGER trigger_update_product_bundles_updated_at
+    BEFORE UPDATE ON product_bundles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_products_updated_at();
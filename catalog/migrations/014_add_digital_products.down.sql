@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS digital_entitlements;
+DROP INDEX IF EXISTS idx_product_digital_assets_product;
+DROP TABLE IF EXISTS product_digital_assets;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_digital_no_stock_check;
+ALTER TABLE products DROP COLUMN IF EXISTS product_type;
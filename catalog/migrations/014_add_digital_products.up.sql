@@ -0,0 +1,29 @@
+-- Digital products are delivered as downloadable files and do not track stock
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS product_type VARCHAR(20) NOT NULL DEFAULT 'PHYSICAL'
+        CHECK (product_type IN ('PHYSICAL', 'DIGITAL')),
+    ADD CONSTRAINT products_digital_no_stock_check
+        CHECK (product_type = 'PHYSICAL' OR (stock = 0 AND low_stock_threshold = 0));
+
+-- Files delivered with a digital product, stored in the private assets bucket
+CREATE TABLE IF NOT EXISTS product_digital_assets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    object_key TEXT NOT NULL UNIQUE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_digital_assets_product ON product_digital_assets(product_id, created_at);
+
+-- Users allowed to download a digital product, granted when an order is paid
+CREATE TABLE IF NOT EXISTS digital_entitlements (
+    user_id VARCHAR(64) NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    granted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP,
+    PRIMARY KEY (user_id, product_id)
+);
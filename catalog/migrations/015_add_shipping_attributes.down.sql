@@ -0,0 +1,6 @@
+ALTER TABLE products
+    DROP COLUMN IF EXISTS shipping_class,
+    DROP COLUMN IF EXISTS height_cm,
+    DROP COLUMN IF EXISTS width_cm,
+    DROP COLUMN IF EXISTS length_cm,
+    DROP COLUMN IF EXISTS weight_kg;
@@ -0,0 +1,9 @@
+-- Package weight, dimensions and handling class used by shipping rate calculation.
+-- 0 means not set; the dimensions are set together.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS weight_kg DECIMAL(10, 3) NOT NULL DEFAULT 0 CHECK (weight_kg >= 0),
+    ADD COLUMN IF NOT EXISTS length_cm DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (length_cm >= 0),
+    ADD COLUMN IF NOT EXISTS width_cm DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (width_cm >= 0),
+    ADD COLUMN IF NOT EXISTS height_cm DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (height_cm >= 0),
+    ADD COLUMN IF NOT EXISTS shipping_class VARCHAR(20) NOT NULL DEFAULT 'STANDARD'
+        CHECK (shipping_class IN ('STANDARD', 'OVERSIZED', 'FRAGILE', 'HAZARDOUS', 'FREIGHT'));
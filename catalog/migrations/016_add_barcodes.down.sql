@@ -0,0 +1,4 @@
+ALTER TABLE products
+    DROP COLUMN IF EXISTS isbn,
+    DROP COLUMN IF EXISTS upc,
+    DROP COLUMN IF EXISTS ean;
@@ -0,0 +1,6 @@
+-- Barcodes for POS and warehouse scanning, stored normalized (digits only,
+-- ISBN-10 converted to ISBN-13). NULL when not set.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS ean VARCHAR(13) UNIQUE,
+    ADD COLUMN IF NOT EXISTS upc VARCHAR(12) UNIQUE,
+    ADD COLUMN IF NOT EXISTS isbn VARCHAR(13) UNIQUE;
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trigger_update_product_translations_updated_at ON product_translations;
+DROP TABLE IF EXISTS product_translations;
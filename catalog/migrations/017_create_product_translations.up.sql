@@ -0,0 +1,16 @@
+-- Localized product content. The name and description on products are in the
+-- default locale; a translation replaces them for one locale.
+CREATE TABLE IF NOT EXISTS product_translations (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, locale)
+);
+
+CREATE TRIGGER trigger_update_product_translations_updated_at
+    BEFORE UPDATE ON product_translations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_products_updated_at();
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_rating;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS rating_updated_at,
+    DROP COLUMN IF EXISTS review_count,
+    DROP COLUMN IF EXISTS average_rating;
@@ -0,0 +1,10 @@
+-- Review aggregates pushed by the review service. rating_updated_at is the
+-- time the last applied aggregate was computed; older updates are ignored.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS average_rating DECIMAL(3, 2) NOT NULL DEFAULT 0
+        CHECK (average_rating >= 0 AND average_rating <= 5),
+    ADD COLUMN IF NOT EXISTS review_count INTEGER NOT NULL DEFAULT 0 CHECK (review_count >= 0),
+    ADD COLUMN IF NOT EXISTS rating_updated_at TIMESTAMP;
+
+-- Supports sorting and filtering by rating
+CREATE INDEX idx_products_rating ON products(average_rating DESC, review_count DESC);
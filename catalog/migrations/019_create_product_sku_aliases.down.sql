@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_product_sku_aliases_product;
+DROP TABLE IF EXISTS product_sku_aliases;
@@ -0,0 +1,10 @@
+-- Former SKUs of products. A changed SKU is kept here so references to it
+-- still resolve; it cannot be reused by another product.
+CREATE TABLE IF NOT EXISTS product_sku_aliases (
+    sku VARCHAR(100) PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    changed_by VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_sku_aliases_product ON product_sku_aliases(product_id);
@@ -0,0 +1,5 @@
+-- DRAFT products, such as clones still being edited, are hidden from
+-- listings and search until they are made ACTIVE
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE'
+        CHECK (status IN ('ACTIVE', 'DRAFT'));
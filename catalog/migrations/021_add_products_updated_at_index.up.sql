@@ -0,0 +1,2 @@
+-- Supports StreamProducts, which pages through products by (updated_at, id)
+CREATE INDEX IF NOT EXISTS idx_products_updated_at ON products(updated_at, id);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trigger_notify_product_change ON products;
+DROP FUNCTION IF EXISTS notify_product_change();
@@ -0,0 +1,21 @@
+-- Publish product changes on the product_changes channel for WatchProducts.
+-- Notifications are delivered when the transaction commits.
+CREATE OR REPLACE FUNCTION notify_product_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    product_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        product_id = OLD.id;
+    ELSE
+        product_id = NEW.id;
+    END IF;
+    PERFORM pg_notify('product_changes', json_build_object('op', TG_OP, 'id', product_id, 'at', now())::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_notify_product_change
+    AFTER INSERT OR UPDATE OR DELETE ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_product_change();
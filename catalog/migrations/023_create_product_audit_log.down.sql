@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_product_audit_log_created;
+DROP INDEX IF EXISTS idx_product_audit_log_product;
+DROP TABLE IF EXISTS product_audit_log;
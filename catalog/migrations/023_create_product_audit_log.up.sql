@@ -0,0 +1,13 @@
+-- Who changed what on products. Entries have no foreign key so they outlive
+-- deleted products.
+CREATE TABLE IF NOT EXISTS product_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    product_id UUID NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('CREATE', 'UPDATE', 'DELETE')),
+    actor VARCHAR(255) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_audit_log_product ON product_audit_log(product_id, created_at DESC);
+CREATE INDEX idx_product_audit_log_created ON product_audit_log(created_at);
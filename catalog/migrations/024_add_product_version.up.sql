@@ -0,0 +1,4 @@
+-- Version for optimistic concurrency: UpdateProduct must send the version it
+-- read, and every edit increments it
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_activity_events;
+DROP INDEX IF EXISTS idx_product_activity_daily_day;
+DROP TABLE IF EXISTS product_activity_daily;
@@ -0,0 +1,19 @@
+-- Daily view and order counts per product, for best-seller rankings and the
+-- POPULARITY sort. Kept out of products so activity does not touch updated_at
+-- or publish product changes.
+CREATE TABLE IF NOT EXISTS product_activity_daily (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0 CHECK (views >= 0),
+    orders BIGINT NOT NULL DEFAULT 0 CHECK (orders >= 0),
+    units BIGINT NOT NULL DEFAULT 0 CHECK (units >= 0),
+    PRIMARY KEY (product_id, day)
+);
+
+CREATE INDEX idx_product_activity_daily_day ON product_activity_daily(day);
+
+-- IDs of recorded activity events, so redelivered events are counted once
+CREATE TABLE IF NOT EXISTS product_activity_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,8 @@
+-- Storefront visibility rules. A product without a row, or with an empty list,
+-- is visible in every channel and to every customer group.
+CREATE TABLE IF NOT EXISTS product_visibility (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    channels TEXT[] NOT NULL DEFAULT '{}',
+    customer_groups TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,5 @@
+-- Trigram matching for typo-tolerant search. The index also serves the
+-- substring matches of the regular search.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (LOWER(name) gin_trgm_ops);
@@ -0,0 +1,4 @@
+-- Prefix indexes for search-as-you-type. text_pattern_ops lets LIKE 'prefix%'
+-- use the index whatever the database collation.
+CREATE INDEX IF NOT EXISTS idx_products_name_prefix ON products (LOWER(name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_products_category_prefix ON products (LOWER(category) text_pattern_ops);
@@ -0,0 +1,5 @@
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_order_qty_range,
+    DROP COLUMN IF EXISTS qty_increment,
+    DROP COLUMN IF EXISTS max_order_qty,
+    DROP COLUMN IF EXISTS min_order_qty;
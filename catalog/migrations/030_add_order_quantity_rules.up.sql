@@ -0,0 +1,8 @@
+-- Order quantity rules enforced when pricing and reserving quantities; 0
+-- places no rule. Allowed quantities are multiples of qty_increment between
+-- min_order_qty and max_order_qty.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS min_order_qty INTEGER NOT NULL DEFAULT 0 CHECK (min_order_qty >= 0),
+    ADD COLUMN IF NOT EXISTS max_order_qty INTEGER NOT NULL DEFAULT 0 CHECK (max_order_qty >= 0),
+    ADD COLUMN IF NOT EXISTS qty_increment INTEGER NOT NULL DEFAULT 0 CHECK (qty_increment >= 0),
+    ADD CONSTRAINT products_order_qty_range CHECK (max_order_qty = 0 OR max_order_qty >= min_order_qty);
@@ -0,0 +1,7 @@
+-- Marketplace vendor selling the product; '' for products the operator sells.
+-- Ownership is set when a product is created and cannot change.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS vendor_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- A vendor's products
+CREATE INDEX IF NOT EXISTS idx_products_vendor ON products(vendor_id, created_at DESC) WHERE vendor_id <> '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_catalog_outbox_published_at;
+DROP INDEX IF EXISTS idx_catalog_outbox_unpublished;
+DROP TABLE IF EXISTS catalog_outbox;
@@ -0,0 +1,15 @@
+-- Product events awaiting publication to Kafka, added in the transaction of
+-- the change they describe
+CREATE TABLE IF NOT EXISTS catalog_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    topic VARCHAR(255) NOT NULL,
+    event_key VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_catalog_outbox_unpublished ON catalog_outbox(id) WHERE published_at IS NULL;
+CREATE INDEX idx_catalog_outbox_published_at ON catalog_outbox(published_at);
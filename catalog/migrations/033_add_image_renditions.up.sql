@@ -0,0 +1,3 @@
+-- Resized copies of each image made by the image service, as
+-- [{name, format, url, width, height, size_bytes}]
+ALTER TABLE product_images ADD COLUMN IF NOT EXISTS renditions JSONB NOT NULL DEFAULT '[]';
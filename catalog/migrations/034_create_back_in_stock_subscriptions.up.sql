@@ -0,0 +1,11 @@
+-- Customers waiting for an out-of-stock product. A subscription is notified
+-- once when the product is back in stock and then deleted; claimed_until
+-- leases it to the catalog instance sending the notification.
+CREATE TABLE IF NOT EXISTS back_in_stock_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    claimed_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, user_id)
+);
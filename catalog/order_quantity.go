@@ -0,0 +1,39 @@
+package catalog
+
+import "fmt"
+
+// orderQuantity holds validated order quantity rules of a product
+type orderQuantity struct {
+	min, max, increment int32
+}
+
+// orderQuantityFromRequest validates the order quantity rules of a create or
+// update request and returns a message describing the first problem, or "".
+// Bounds must be multiples of the increment so that both can be ordered.
+func orderQuantityFromRequest(min, max, increment int32) (orderQuantity, string) {
+	switch {
+	case min < 0 || max < 0 || increment < 0:
+		return orderQuantity{}, "order quantity rules cannot be negative"
+	case max > 0 && max < min:
+		return orderQuantity{}, "max_order_qty cannot be below min_order_qty"
+	case increment > 0 && min%increment != 0:
+		return orderQuantity{}, "min_order_qty must be a multiple of qty_increment"
+	case increment > 0 && max%increment != 0:
+		return orderQuantity{}, "max_order_qty must be a multiple of qty_increment"
+	}
+	return orderQuantity{min: min, max: max, increment: increment}, ""
+}
+
+// orderQuantityProblem checks a positive quantity against the order quantity
+// rules of a product and returns a message describing the violated rule, or ""
+func orderQuantityProblem(p *Product, quantity int32) string {
+	switch {
+	case p.MinOrderQty > 0 && quantity < p.MinOrderQty:
+		return fmt.Sprintf("quantity must be at least %d", p.MinOrderQty)
+	case p.MaxOrderQty > 0 && quantity > p.MaxOrderQty:
+		return fmt.Sprintf("quantity cannot exceed %d", p.MaxOrderQty)
+	case p.QtyIncrement > 0 && quantity%p.QtyIncrement != 0:
+		return fmt.Sprintf("quantity must be a multiple of %d", p.QtyIncrement)
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package catalog
+
+import "testing"
+
+func TestOrderQuantityFromRequest(t *testing.T) {
+	tests := []struct {
+		name                string
+		min, max, increment int32
+		expected            orderQuantity
+		wantMsg             bool
+	}{
+		{"no rules", 0, 0, 0, orderQuantity{}, false},
+		{"minimum only", 3, 0, 0, orderQuantity{min: 3}, false},
+		{"case packs", 6, 48, 6, orderQuantity{min: 6, max: 48, increment: 6}, false},
+		{"increment without bounds", 0, 0, 4, orderQuantity{increment: 4}, false},
+		{"negative", 0, -1, 0, orderQuantity{}, true},
+		{"max below min", 10, 5, 0, orderQuantity{}, true},
+		{"min off increment", 5, 0, 6, orderQuantity{}, true},
+		{"max off increment", 6, 50, 6, orderQuantity{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := orderQuantityFromRequest(tt.min, tt.max, tt.increment)
+			if (msg != "") != tt.wantMsg {
+				t.Fatalf("Expected message %v, got %q", tt.wantMsg, msg)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOrderQuantityProblem(t *testing.T) {
+	product := &Product{MinOrderQty: 6, MaxOrderQty: 24, QtyIncrement: 6}
+
+	tests := []struct {
+		quantity int32
+		expected string
+	}{
+		{6, ""},
+		{18, ""},
+		{24, ""},
+		{3, "quantity must be at least 6"},
+		{30, "quantity cannot exceed 24"},
+		{10, "quantity must be a multiple of 6"},
+	}
+
+	for _, tt := range tests {
+		if got := orderQuantityProblem(product, tt.quantity); got != tt.expected {
+			t.Errorf("Quantity %d: expected %q, got %q", tt.quantity, tt.expected, got)
+		}
+	}
+
+	if got := orderQuantityProblem(&Product{}, 7); got != "" {
+		t.Errorf("Expected no rules to allow any quantity, got %q", got)
+	}
+}
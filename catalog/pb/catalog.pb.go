@@ -24,19 +24,45 @@ const (
 
 // Product represents a product in the catalog
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Sku           string                 `protobuf:"bytes,5,opt,name=sku,proto3" json:"sku,omitempty"`
-	Stock         int32                  `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`
-	Images        []string               `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
-	Category      string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price             float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Sku               string                 `protobuf:"bytes,5,opt,name=sku,proto3" json:"sku,omitempty"`
+	Stock             int32                  `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`
+	Images            []string               `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"` // image URLs in display order
+	Category          string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DescriptionBlocks []*ContentBlock        `protobuf:"bytes,11,rep,name=description_blocks,json=descriptionBlocks,proto3" json:"description_blocks,omitempty"`
+	ImageDetails      []*ProductImage        `protobuf:"bytes,12,rep,name=image_details,json=imageDetails,proto3" json:"image_details,omitempty"`
+	SalePrice         float64                `protobuf:"fixed64,13,opt,name=sale_price,json=salePrice,proto3" json:"sale_price,omitempty"` // 0 when the product has no sale price
+	SaleStartsAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=sale_starts_at,json=saleStartsAt,proto3" json:"sale_starts_at,omitempty"`
+	SaleEndsAt        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=sale_ends_at,json=saleEndsAt,proto3" json:"sale_ends_at,omitempty"`
+	EffectivePrice    float64                `protobuf:"fixed64,16,opt,name=effective_price,json=effectivePrice,proto3" json:"effective_price,omitempty"` // sale_price while the sale is active, otherwise price
+	OnSale            bool                   `protobuf:"varint,17,opt,name=on_sale,json=onSale,proto3" json:"on_sale,omitempty"`
+	LowStockThreshold int32                  `protobuf:"varint,18,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"` // 0 when low-stock alerts are disabled
+	ProductType       string                 `protobuf:"bytes,19,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"`                      // PHYSICAL or DIGITAL
+	WeightKg          float64                `protobuf:"fixed64,20,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`                             // package weight; 0 when not set
+	LengthCm          float64                `protobuf:"fixed64,21,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`                             // package dimensions; 0 when not set
+	WidthCm           float64                `protobuf:"fixed64,22,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm          float64                `protobuf:"fixed64,23,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	ShippingClass     string                 `protobuf:"bytes,24,opt,name=shipping_class,json=shippingClass,proto3" json:"shipping_class,omitempty"`   // STANDARD, OVERSIZED, FRAGILE, HAZARDOUS or FREIGHT
+	Ean               string                 `protobuf:"bytes,25,opt,name=ean,proto3" json:"ean,omitempty"`                                            // EAN-8 or EAN-13; empty when not set
+	Upc               string                 `protobuf:"bytes,26,opt,name=upc,proto3" json:"upc,omitempty"`                                            // UPC-A
+	Isbn              string                 `protobuf:"bytes,27,opt,name=isbn,proto3" json:"isbn,omitempty"`                                          // ISBN-13
+	Locale            string                 `protobuf:"bytes,28,opt,name=locale,proto3" json:"locale,omitempty"`                                      // locale of name and description on read RPCs
+	AverageRating     float64                `protobuf:"fixed64,29,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"` // 0 when the product has no reviews
+	ReviewCount       int32                  `protobuf:"varint,30,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	Status            string                 `protobuf:"bytes,31,opt,name=status,proto3" json:"status,omitempty"`                                  // ACTIVE or DRAFT; drafts are hidden from listings and search
+	Version           int64                  `protobuf:"varint,32,opt,name=version,proto3" json:"version,omitempty"`                               // incremented by every edit; send it back in UpdateProductRequest
+	MinOrderQty       int32                  `protobuf:"varint,33,opt,name=min_order_qty,json=minOrderQty,proto3" json:"min_order_qty,omitempty"`  // smallest quantity per order line; 0 when not set
+	MaxOrderQty       int32                  `protobuf:"varint,34,opt,name=max_order_qty,json=maxOrderQty,proto3" json:"max_order_qty,omitempty"`  // largest quantity per order line; 0 when not set
+	QtyIncrement      int32                  `protobuf:"varint,35,opt,name=qty_increment,json=qtyIncrement,proto3" json:"qty_increment,omitempty"` // quantities must be multiples of this; 0 when not set
+	VendorId          string                 `protobuf:"bytes,36,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`              // marketplace vendor selling the product; empty when the operator sells it
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Product) Reset() {
@@ -139,166 +165,222 @@ func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// CreateProduct
-type CreateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
-	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
-	Images        []string               `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
-	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Product) GetDescriptionBlocks() []*ContentBlock {
+	if x != nil {
+		return x.DescriptionBlocks
+	}
+	return nil
 }
 
-func (x *CreateProductRequest) Reset() {
-	*x = CreateProductRequest{}
-	mi := &file_catalog_catalog_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Product) GetImageDetails() []*ProductImage {
+	if x != nil {
+		return x.ImageDetails
+	}
+	return nil
 }
 
-func (x *CreateProductRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Product) GetSalePrice() float64 {
+	if x != nil {
+		return x.SalePrice
+	}
+	return 0
 }
 
-func (*CreateProductRequest) ProtoMessage() {}
+func (x *Product) GetSaleStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SaleStartsAt
+	}
+	return nil
+}
 
-func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[1]
+func (x *Product) GetSaleEndsAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SaleEndsAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{1}
+func (x *Product) GetEffectivePrice() float64 {
+	if x != nil {
+		return x.EffectivePrice
+	}
+	return 0
 }
 
-func (x *CreateProductRequest) GetName() string {
+func (x *Product) GetOnSale() bool {
 	if x != nil {
-		return x.Name
+		return x.OnSale
 	}
-	return ""
+	return false
 }
 
-func (x *CreateProductRequest) GetDescription() string {
+func (x *Product) GetLowStockThreshold() int32 {
 	if x != nil {
-		return x.Description
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
+func (x *Product) GetProductType() string {
+	if x != nil {
+		return x.ProductType
 	}
 	return ""
 }
 
-func (x *CreateProductRequest) GetPrice() float64 {
+func (x *Product) GetWeightKg() float64 {
 	if x != nil {
-		return x.Price
+		return x.WeightKg
 	}
 	return 0
 }
 
-func (x *CreateProductRequest) GetSku() string {
+func (x *Product) GetLengthCm() float64 {
 	if x != nil {
-		return x.Sku
+		return x.LengthCm
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateProductRequest) GetStock() int32 {
+func (x *Product) GetWidthCm() float64 {
 	if x != nil {
-		return x.Stock
+		return x.WidthCm
 	}
 	return 0
 }
 
-func (x *CreateProductRequest) GetImages() []string {
+func (x *Product) GetHeightCm() float64 {
 	if x != nil {
-		return x.Images
+		return x.HeightCm
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateProductRequest) GetCategory() string {
+func (x *Product) GetShippingClass() string {
 	if x != nil {
-		return x.Category
+		return x.ShippingClass
 	}
 	return ""
 }
 
-type CreateProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Product) GetEan() string {
+	if x != nil {
+		return x.Ean
+	}
+	return ""
 }
 
-func (x *CreateProductResponse) Reset() {
-	*x = CreateProductResponse{}
-	mi := &file_catalog_catalog_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Product) GetUpc() string {
+	if x != nil {
+		return x.Upc
+	}
+	return ""
 }
 
-func (x *CreateProductResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Product) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
 }
 
-func (*CreateProductResponse) ProtoMessage() {}
+func (x *Product) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
 
-func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[2]
+func (x *Product) GetAverageRating() float64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AverageRating
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
-func (*CreateProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{2}
+func (x *Product) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
 }
 
-func (x *CreateProductResponse) GetProduct() *Product {
+func (x *Product) GetStatus() string {
 	if x != nil {
-		return x.Product
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-// GetProduct
-type GetProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Product) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
-	mi := &file_catalog_catalog_proto_msgTypes[3]
+func (x *Product) GetMinOrderQty() int32 {
+	if x != nil {
+		return x.MinOrderQty
+	}
+	return 0
+}
+
+func (x *Product) GetMaxOrderQty() int32 {
+	if x != nil {
+		return x.MaxOrderQty
+	}
+	return 0
+}
+
+func (x *Product) GetQtyIncrement() int32 {
+	if x != nil {
+		return x.QtyIncrement
+	}
+	return 0
+}
+
+func (x *Product) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+// ProductImage is a product image with its display metadata
+type ProductImage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url              string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	AltText          string                 `protobuf:"bytes,3,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Position         int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	IsPrimary        bool                   `protobuf:"varint,5,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	Width            int32                  `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height           int32                  `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	ValidationStatus string                 `protobuf:"bytes,8,opt,name=validation_status,json=validationStatus,proto3" json:"validation_status,omitempty"` // PENDING, PASSED or FAILED
+	ValidationErrors []string               `protobuf:"bytes,9,rep,name=validation_errors,json=validationErrors,proto3" json:"validation_errors,omitempty"`
+	AltTextGenerated bool                   `protobuf:"varint,10,opt,name=alt_text_generated,json=altTextGenerated,proto3" json:"alt_text_generated,omitempty"` // alt text was written by the captioning provider
+	NeedsReview      bool                   `protobuf:"varint,11,opt,name=needs_review,json=needsReview,proto3" json:"needs_review,omitempty"`
+	Renditions       []*ImageRendition      `protobuf:"bytes,12,rep,name=renditions,proto3" json:"renditions,omitempty"` // resized copies made by the image service; empty until they are ready
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProductImage) Reset() {
+	*x = ProductImage{}
+	mi := &file_catalog_catalog_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *ProductImage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*ProductImage) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[3]
+func (x *ProductImage) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,87 +391,123 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
+func (*ProductImage) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetProductRequest) GetId() string {
+func (x *ProductImage) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ProductImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
 }
 
-func (x *GetProductResponse) Reset() {
-	*x = GetProductResponse{}
-	mi := &file_catalog_catalog_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ProductImage) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
 }
 
-func (x *GetProductResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ProductImage) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
 }
 
-func (*GetProductResponse) ProtoMessage() {}
+func (x *ProductImage) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
 
-func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[4]
+func (x *ProductImage) GetWidth() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Width
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
-func (*GetProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{4}
+func (x *ProductImage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
 }
 
-func (x *GetProductResponse) GetProduct() *Product {
+func (x *ProductImage) GetValidationStatus() string {
 	if x != nil {
-		return x.Product
+		return x.ValidationStatus
+	}
+	return ""
+}
+
+func (x *ProductImage) GetValidationErrors() []string {
+	if x != nil {
+		return x.ValidationErrors
 	}
 	return nil
 }
 
-// ListProducts
-type ListProductsRequest struct {
+func (x *ProductImage) GetAltTextGenerated() bool {
+	if x != nil {
+		return x.AltTextGenerated
+	}
+	return false
+}
+
+func (x *ProductImage) GetNeedsReview() bool {
+	if x != nil {
+		return x.NeedsReview
+	}
+	return false
+}
+
+func (x *ProductImage) GetRenditions() []*ImageRendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+// ImageRendition is a resized copy of a product image
+type ImageRendition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // thumbnail, small or large
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // jpeg, png or webp
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
-	mi := &file_catalog_catalog_proto_msgTypes[5]
+func (x *ImageRendition) Reset() {
+	*x = ImageRendition{}
+	mi := &file_catalog_catalog_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsRequest) String() string {
+func (x *ImageRendition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
+func (*ImageRendition) ProtoMessage() {}
 
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[5]
+func (x *ImageRendition) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,57 +518,82 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ImageRendition.ProtoReflect.Descriptor instead.
+func (*ImageRendition) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ListProductsRequest) GetPage() int32 {
+func (x *ImageRendition) GetName() string {
 	if x != nil {
-		return x.Page
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsRequest) GetPageSize() int32 {
+func (x *ImageRendition) GetFormat() string {
 	if x != nil {
-		return x.PageSize
+		return x.Format
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsRequest) GetCategory() string {
+func (x *ImageRendition) GetUrl() string {
 	if x != nil {
-		return x.Category
+		return x.Url
 	}
 	return ""
 }
 
-type ListProductsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+func (x *ImageRendition) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageRendition) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ImageRendition) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+// ContentBlock is a structured rich content block of a product description.
+// Text may use inline markdown marks: **bold**, _italic_ and [label](https://link).
+type ContentBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // paragraph, heading, list, ordered_list, quote or image
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"` // heading level 1-6
+	Items         []string               `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`  // list items
+	Url           string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`      // image source
+	Alt           string                 `protobuf:"bytes,6,opt,name=alt,proto3" json:"alt,omitempty"`      // image alt text
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_catalog_catalog_proto_msgTypes[6]
+func (x *ContentBlock) Reset() {
+	*x = ContentBlock{}
+	mi := &file_catalog_catalog_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *ContentBlock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*ContentBlock) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[6]
+func (x *ContentBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,68 +604,99 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ContentBlock.ProtoReflect.Descriptor instead.
+func (*ContentBlock) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *ContentBlock) GetType() string {
 	if x != nil {
-		return x.Products
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-func (x *ListProductsResponse) GetTotal() int32 {
+func (x *ContentBlock) GetText() string {
 	if x != nil {
-		return x.Total
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsResponse) GetPage() int32 {
+func (x *ContentBlock) GetLevel() int32 {
 	if x != nil {
-		return x.Page
+		return x.Level
 	}
 	return 0
 }
 
-func (x *ListProductsResponse) GetPageSize() int32 {
+func (x *ContentBlock) GetItems() []string {
 	if x != nil {
-		return x.PageSize
+		return x.Items
 	}
-	return 0
+	return nil
 }
 
-// UpdateProduct
-type UpdateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
-	Images        []string               `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
-	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ContentBlock) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
-	mi := &file_catalog_catalog_proto_msgTypes[7]
+func (x *ContentBlock) GetAlt() string {
+	if x != nil {
+		return x.Alt
+	}
+	return ""
+}
+
+// CreateProduct
+type CreateProductRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Price             float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Sku               string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	Stock             int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	Images            []string               `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	Category          string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	DescriptionBlocks []*ContentBlock        `protobuf:"bytes,8,rep,name=description_blocks,json=descriptionBlocks,proto3" json:"description_blocks,omitempty"`     // when set and description is empty, description is derived as plain text
+	SalePrice         float64                `protobuf:"fixed64,9,opt,name=sale_price,json=salePrice,proto3" json:"sale_price,omitempty"`                           // 0 for no sale; must be below price
+	SaleStartsAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=sale_starts_at,json=saleStartsAt,proto3" json:"sale_starts_at,omitempty"`                 // unset starts the sale immediately
+	SaleEndsAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=sale_ends_at,json=saleEndsAt,proto3" json:"sale_ends_at,omitempty"`                       // unset keeps the sale running
+	LowStockThreshold int32                  `protobuf:"varint,12,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"` // 0 disables low-stock alerts
+	ProductType       string                 `protobuf:"bytes,13,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"`                      // PHYSICAL (default) or DIGITAL; digital products have no stock
+	WeightKg          float64                `protobuf:"fixed64,14,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`                             // 0 when unknown; must be 0 for digital products
+	LengthCm          float64                `protobuf:"fixed64,15,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`                             // length, width and height are set together
+	WidthCm           float64                `protobuf:"fixed64,16,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm          float64                `protobuf:"fixed64,17,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	ShippingClass     string                 `protobuf:"bytes,18,opt,name=shipping_class,json=shippingClass,proto3" json:"shipping_class,omitempty"` // defaults to STANDARD
+	Ean               string                 `protobuf:"bytes,19,opt,name=ean,proto3" json:"ean,omitempty"`                                          // barcodes are optional and unique across products
+	Upc               string                 `protobuf:"bytes,20,opt,name=upc,proto3" json:"upc,omitempty"`
+	Isbn              string                 `protobuf:"bytes,21,opt,name=isbn,proto3" json:"isbn,omitempty"`                                      // ISBN-10 is converted to ISBN-13
+	MinOrderQty       int32                  `protobuf:"varint,22,opt,name=min_order_qty,json=minOrderQty,proto3" json:"min_order_qty,omitempty"`  // 0 for no minimum
+	MaxOrderQty       int32                  `protobuf:"varint,23,opt,name=max_order_qty,json=maxOrderQty,proto3" json:"max_order_qty,omitempty"`  // 0 for no maximum; at least min_order_qty
+	QtyIncrement      int32                  `protobuf:"varint,24,opt,name=qty_increment,json=qtyIncrement,proto3" json:"qty_increment,omitempty"` // 0 for any step; min and max must be multiples of it
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *CreateProductRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*CreateProductRequest) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[7]
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,172 +707,201 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *UpdateProductRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UpdateProductRequest) GetName() string {
+func (x *CreateProductRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetDescription() string {
+func (x *CreateProductRequest) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetPrice() float64 {
+func (x *CreateProductRequest) GetPrice() float64 {
 	if x != nil {
 		return x.Price
 	}
 	return 0
 }
 
-func (x *UpdateProductRequest) GetStock() int32 {
+func (x *CreateProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetStock() int32 {
 	if x != nil {
 		return x.Stock
 	}
 	return 0
 }
 
-func (x *UpdateProductRequest) GetImages() []string {
+func (x *CreateProductRequest) GetImages() []string {
 	if x != nil {
 		return x.Images
 	}
 	return nil
 }
 
-func (x *UpdateProductRequest) GetCategory() string {
+func (x *CreateProductRequest) GetCategory() string {
 	if x != nil {
 		return x.Category
 	}
 	return ""
 }
 
-type UpdateProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateProductRequest) GetDescriptionBlocks() []*ContentBlock {
+	if x != nil {
+		return x.DescriptionBlocks
+	}
+	return nil
 }
 
-func (x *UpdateProductResponse) Reset() {
-	*x = UpdateProductResponse{}
-	mi := &file_catalog_catalog_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreateProductRequest) GetSalePrice() float64 {
+	if x != nil {
+		return x.SalePrice
+	}
+	return 0
 }
 
-func (x *UpdateProductResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreateProductRequest) GetSaleStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SaleStartsAt
+	}
+	return nil
 }
 
-func (*UpdateProductResponse) ProtoMessage() {}
+func (x *CreateProductRequest) GetSaleEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SaleEndsAt
+	}
+	return nil
+}
 
-func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[8]
+func (x *CreateProductRequest) GetLowStockThreshold() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LowStockThreshold
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{8}
+func (x *CreateProductRequest) GetProductType() string {
+	if x != nil {
+		return x.ProductType
+	}
+	return ""
 }
 
-func (x *UpdateProductResponse) GetProduct() *Product {
+func (x *CreateProductRequest) GetWeightKg() float64 {
 	if x != nil {
-		return x.Product
+		return x.WeightKg
 	}
-	return nil
+	return 0
 }
 
-// DeleteProduct
-type DeleteProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateProductRequest) GetLengthCm() float64 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
-	mi := &file_catalog_catalog_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreateProductRequest) GetWidthCm() float64 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
 }
 
-func (x *DeleteProductRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreateProductRequest) GetHeightCm() float64 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (x *CreateProductRequest) GetShippingClass() string {
+	if x != nil {
+		return x.ShippingClass
+	}
+	return ""
+}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[9]
+func (x *CreateProductRequest) GetEan() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Ean
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{9}
+func (x *CreateProductRequest) GetUpc() string {
+	if x != nil {
+		return x.Upc
+	}
+	return ""
 }
 
-func (x *DeleteProductRequest) GetId() string {
+func (x *CreateProductRequest) GetIsbn() string {
 	if x != nil {
-		return x.Id
+		return x.Isbn
 	}
 	return ""
 }
 
-type DeleteProductResponse struct {
+func (x *CreateProductRequest) GetMinOrderQty() int32 {
+	if x != nil {
+		return x.MinOrderQty
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetMaxOrderQty() int32 {
+	if x != nil {
+		return x.MaxOrderQty
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetQtyIncrement() int32 {
+	if x != nil {
+		return x.QtyIncrement
+	}
+	return 0
+}
+
+type CreateProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductResponse) Reset() {
-	*x = DeleteProductResponse{}
-	mi := &file_catalog_catalog_proto_msgTypes[10]
+func (x *CreateProductResponse) Reset() {
+	*x = CreateProductResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductResponse) String() string {
+func (x *CreateProductResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductResponse) ProtoMessage() {}
+func (*CreateProductResponse) ProtoMessage() {}
 
-func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[10]
+func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,50 +912,9201 @@ func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DeleteProductResponse) GetSuccess() bool {
+func (x *CreateProductResponse) GetProduct() *Product {
 	if x != nil {
-		return x.Success
+		return x.Product
 	}
-	return false
+	return nil
 }
 
-func (x *DeleteProductResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// GetProduct
+type GetProductRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeRelated bool                   `protobuf:"varint,2,opt,name=include_related,json=includeRelated,proto3" json:"include_related,omitempty"` // when set, related_products is populated
+	Locale         string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`                                        // Accept-Language value such as "fr-CA, fr;q=0.8"; defaults to the accept-language metadata
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-// SearchProducts
-type SearchProductsRequest struct {
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetIncludeRelated() bool {
+	if x != nil {
+		return x.IncludeRelated
+	}
+	return false
+}
+
+func (x *GetProductRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetProductResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Product         *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	RelatedProducts []*RelatedProduct      `protobuf:"bytes,2,rep,name=related_products,json=relatedProducts,proto3" json:"related_products,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductResponse) ProtoMessage() {}
+
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *GetProductResponse) GetRelatedProducts() []*RelatedProduct {
+	if x != nil {
+		return x.RelatedProducts
+	}
+	return nil
+}
+
+// ListProducts
+type ListProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`                                     // Accept-Language value such as "fr-CA, fr;q=0.8"; defaults to the accept-language metadata
+	SortBy        string                 `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                       // NEWEST (default), RATING, REVIEW_COUNT or POPULARITY (units sold, then views, over 30 days)
+	MinRating     float64                `protobuf:"fixed64,6,opt,name=min_rating,json=minRating,proto3" json:"min_rating,omitempty"`            // keep products rated at least this; 0 keeps all
+	IncludeDrafts bool                   `protobuf:"varint,7,opt,name=include_drafts,json=includeDrafts,proto3" json:"include_drafts,omitempty"` // also return DRAFT products
+	Channel       string                 `protobuf:"bytes,8,opt,name=channel,proto3" json:"channel,omitempty"`                                   // WEB, MOBILE or B2B; applies visibility rules for that storefront, empty applies none
+	CustomerGroup string                 `protobuf:"bytes,9,opt,name=customer_group,json=customerGroup,proto3" json:"customer_group,omitempty"`  // group of the customer browsing; empty for guests. Requires channel
+	VendorId      string                 `protobuf:"bytes,10,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`                // keep the products of one marketplace vendor; empty keeps all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetMinRating() float64 {
+	if x != nil {
+		return x.MinRating
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetIncludeDrafts() bool {
+	if x != nil {
+		return x.IncludeDrafts
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCustomerGroup() string {
+	if x != nil {
+		return x.CustomerGroup
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// UpdateProduct
+type UpdateProductRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price             float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Stock             int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	Images            []string               `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	Category          string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	DescriptionBlocks []*ContentBlock        `protobuf:"bytes,8,rep,name=description_blocks,json=descriptionBlocks,proto3" json:"description_blocks,omitempty"`
+	SalePrice         float64                `protobuf:"fixed64,9,opt,name=sale_price,json=salePrice,proto3" json:"sale_price,omitempty"` // 0 removes the sale price
+	SaleStartsAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=sale_starts_at,json=saleStartsAt,proto3" json:"sale_starts_at,omitempty"`
+	SaleEndsAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=sale_ends_at,json=saleEndsAt,proto3" json:"sale_ends_at,omitempty"`
+	LowStockThreshold int32                  `protobuf:"varint,12,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"`
+	WeightKg          float64                `protobuf:"fixed64,13,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	LengthCm          float64                `protobuf:"fixed64,14,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm           float64                `protobuf:"fixed64,15,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm          float64                `protobuf:"fixed64,16,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	ShippingClass     string                 `protobuf:"bytes,17,opt,name=shipping_class,json=shippingClass,proto3" json:"shipping_class,omitempty"` // defaults to STANDARD
+	Ean               string                 `protobuf:"bytes,18,opt,name=ean,proto3" json:"ean,omitempty"`                                          // empty removes the barcode
+	Upc               string                 `protobuf:"bytes,19,opt,name=upc,proto3" json:"upc,omitempty"`
+	Isbn              string                 `protobuf:"bytes,20,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Status            string                 `protobuf:"bytes,21,opt,name=status,proto3" json:"status,omitempty"`    // ACTIVE or DRAFT; empty keeps the current status
+	Version           int64                  `protobuf:"varint,22,opt,name=version,proto3" json:"version,omitempty"` // required; the product version the update is based on
+	MinOrderQty       int32                  `protobuf:"varint,23,opt,name=min_order_qty,json=minOrderQty,proto3" json:"min_order_qty,omitempty"`
+	MaxOrderQty       int32                  `protobuf:"varint,24,opt,name=max_order_qty,json=maxOrderQty,proto3" json:"max_order_qty,omitempty"`
+	QtyIncrement      int32                  `protobuf:"varint,25,opt,name=qty_increment,json=qtyIncrement,proto3" json:"qty_increment,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescriptionBlocks() []*ContentBlock {
+	if x != nil {
+		return x.DescriptionBlocks
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetSalePrice() float64 {
+	if x != nil {
+		return x.SalePrice
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetSaleStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SaleStartsAt
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetSaleEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SaleEndsAt
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetLowStockThreshold() int32 {
+	if x != nil {
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetLengthCm() float64 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetWidthCm() float64 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetHeightCm() float64 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetShippingClass() string {
+	if x != nil {
+		return x.ShippingClass
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetEan() string {
+	if x != nil {
+		return x.Ean
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetUpc() string {
+	if x != nil {
+		return x.Upc
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetMinOrderQty() int32 {
+	if x != nil {
+		return x.MinOrderQty
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetMaxOrderQty() int32 {
+	if x != nil {
+		return x.MaxOrderQty
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetQtyIncrement() int32 {
+	if x != nil {
+		return x.QtyIncrement
+	}
+	return 0
+}
+
+type UpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductResponse) Reset() {
+	*x = UpdateProductResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductResponse) ProtoMessage() {}
+
+func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// DeleteProduct
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteProductResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteProductResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetProductByBarcode looks up a product by a scanned EAN, UPC or ISBN
+type GetProductByBarcodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Barcode       string                 `protobuf:"bytes,1,opt,name=barcode,proto3" json:"barcode,omitempty"` // spaces and hyphens are ignored
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`   // Accept-Language value such as "fr-CA, fr;q=0.8"; defaults to the accept-language metadata
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByBarcodeRequest) Reset() {
+	*x = GetProductByBarcodeRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByBarcodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByBarcodeRequest) ProtoMessage() {}
+
+func (x *GetProductByBarcodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByBarcodeRequest.ProtoReflect.Descriptor instead.
+func (*GetProductByBarcodeRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetProductByBarcodeRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *GetProductByBarcodeRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetProductByBarcodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByBarcodeResponse) Reset() {
+	*x = GetProductByBarcodeResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByBarcodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByBarcodeResponse) ProtoMessage() {}
+
+func (x *GetProductByBarcodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByBarcodeResponse.ProtoReflect.Descriptor instead.
+func (*GetProductByBarcodeResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetProductByBarcodeResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// SearchProducts
+type SearchProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`                                     // Accept-Language value such as "fr-CA, fr;q=0.8"; defaults to the accept-language metadata
+	SortBy        string                 `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                       // NEWEST (default), RATING, REVIEW_COUNT or POPULARITY (units sold, then views, over 30 days)
+	MinRating     float64                `protobuf:"fixed64,6,opt,name=min_rating,json=minRating,proto3" json:"min_rating,omitempty"`            // keep products rated at least this; 0 keeps all
+	IncludeDrafts bool                   `protobuf:"varint,7,opt,name=include_drafts,json=includeDrafts,proto3" json:"include_drafts,omitempty"` // also return DRAFT products
+	Channel       string                 `protobuf:"bytes,8,opt,name=channel,proto3" json:"channel,omitempty"`                                   // WEB, MOBILE or B2B; applies visibility rules for that storefront, empty applies none
+	CustomerGroup string                 `protobuf:"bytes,9,opt,name=customer_group,json=customerGroup,proto3" json:"customer_group,omitempty"`  // group of the customer browsing; empty for guests. Requires channel
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetMinRating() float64 {
+	if x != nil {
+		return x.MinRating
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetIncludeDrafts() bool {
+	if x != nil {
+		return x.IncludeDrafts
+	}
+	return false
+}
+
+func (x *SearchProductsRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetCustomerGroup() string {
+	if x != nil {
+		return x.CustomerGroup
+	}
+	return ""
+}
+
+type SearchProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Fuzzy         bool                   `protobuf:"varint,3,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`          // no product matched the query exactly; products have similar names instead
+	Suggestion    string                 `protobuf:"bytes,4,opt,name=suggestion,proto3" json:"suggestion,omitempty"` // "did you mean" query built from similar product name words; empty if none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsResponse) ProtoMessage() {}
+
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchProductsResponse) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *SearchProductsResponse) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+// RelatedProduct links a product to another product with a typed relation
+type RelatedProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RelationType  string                 `protobuf:"bytes,1,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"` // RELATED, UPSELL or CROSS_SELL
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	Product       *Product               `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedProduct) Reset() {
+	*x = RelatedProduct{}
+	mi := &file_catalog_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedProduct) ProtoMessage() {}
+
+func (x *RelatedProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedProduct.ProtoReflect.Descriptor instead.
+func (*RelatedProduct) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RelatedProduct) GetRelationType() string {
+	if x != nil {
+		return x.RelationType
+	}
+	return ""
+}
+
+func (x *RelatedProduct) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *RelatedProduct) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// SetRelatedProducts replaces all links of one relation type for a product
+type SetRelatedProductsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProductId         string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	RelationType      string                 `protobuf:"bytes,2,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
+	RelatedProductIds []string               `protobuf:"bytes,3,rep,name=related_product_ids,json=relatedProductIds,proto3" json:"related_product_ids,omitempty"` // ordered; empty clears the relation
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetRelatedProductsRequest) Reset() {
+	*x = SetRelatedProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRelatedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRelatedProductsRequest) ProtoMessage() {}
+
+func (x *SetRelatedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRelatedProductsRequest.ProtoReflect.Descriptor instead.
+func (*SetRelatedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetRelatedProductsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetRelatedProductsRequest) GetRelationType() string {
+	if x != nil {
+		return x.RelationType
+	}
+	return ""
+}
+
+func (x *SetRelatedProductsRequest) GetRelatedProductIds() []string {
+	if x != nil {
+		return x.RelatedProductIds
+	}
+	return nil
+}
+
+type SetRelatedProductsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RelatedProducts []*RelatedProduct      `protobuf:"bytes,1,rep,name=related_products,json=relatedProducts,proto3" json:"related_products,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetRelatedProductsResponse) Reset() {
+	*x = SetRelatedProductsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRelatedProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRelatedProductsResponse) ProtoMessage() {}
+
+func (x *SetRelatedProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRelatedProductsResponse.ProtoReflect.Descriptor instead.
+func (*SetRelatedProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetRelatedProductsResponse) GetRelatedProducts() []*RelatedProduct {
+	if x != nil {
+		return x.RelatedProducts
+	}
+	return nil
+}
+
+// GetRelatedProducts
+type GetRelatedProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	RelationType  string                 `protobuf:"bytes,2,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"` // optional; empty returns all relation types
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedProductsRequest) Reset() {
+	*x = GetRelatedProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedProductsRequest) ProtoMessage() {}
+
+func (x *GetRelatedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetRelatedProductsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetRelatedProductsRequest) GetRelationType() string {
+	if x != nil {
+		return x.RelationType
+	}
+	return ""
+}
+
+type GetRelatedProductsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RelatedProducts []*RelatedProduct      `protobuf:"bytes,1,rep,name=related_products,json=relatedProducts,proto3" json:"related_products,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRelatedProductsResponse) Reset() {
+	*x = GetRelatedProductsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedProductsResponse) ProtoMessage() {}
+
+func (x *GetRelatedProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetRelatedProductsResponse) GetRelatedProducts() []*RelatedProduct {
+	if x != nil {
+		return x.RelatedProducts
+	}
+	return nil
+}
+
+// BookingConfig enables rental/appointment style booking for a product
+type BookingConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Capacity      int32                  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"` // units that can be booked for the same period
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingConfig) Reset() {
+	*x = BookingConfig{}
+	mi := &file_catalog_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingConfig) ProtoMessage() {}
+
+func (x *BookingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingConfig.ProtoReflect.Descriptor instead.
+func (*BookingConfig) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BookingConfig) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BookingConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *BookingConfig) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+// Booking is a date-range reservation of a bookable product
+type Booking struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                        // HELD, CONFIRMED or CANCELLED
+	Reference     string                 `protobuf:"bytes,7,opt,name=reference,proto3" json:"reference,omitempty"`                  // checkout or order reference
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // set while HELD
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Booking) Reset() {
+	*x = Booking{}
+	mi := &file_catalog_catalog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Booking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Booking) ProtoMessage() {}
+
+func (x *Booking) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Booking.ProtoReflect.Descriptor instead.
+func (*Booking) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Booking) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Booking) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Booking) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Booking) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Booking) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Booking) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Booking) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Booking) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Booking) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// AvailabilityDay is one day of a product's availability calendar
+type AvailabilityDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Available     int32                  `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilityDay) Reset() {
+	*x = AvailabilityDay{}
+	mi := &file_catalog_catalog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityDay) ProtoMessage() {}
+
+func (x *AvailabilityDay) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityDay.ProtoReflect.Descriptor instead.
+func (*AvailabilityDay) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AvailabilityDay) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *AvailabilityDay) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+// SetBookingConfig
+type SetBookingConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Capacity      int32                  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBookingConfigRequest) Reset() {
+	*x = SetBookingConfigRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBookingConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBookingConfigRequest) ProtoMessage() {}
+
+func (x *SetBookingConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBookingConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetBookingConfigRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetBookingConfigRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetBookingConfigRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetBookingConfigRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type SetBookingConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *BookingConfig         `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBookingConfigResponse) Reset() {
+	*x = SetBookingConfigResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBookingConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBookingConfigResponse) ProtoMessage() {}
+
+func (x *SetBookingConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBookingConfigResponse.ProtoReflect.Descriptor instead.
+func (*SetBookingConfigResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetBookingConfigResponse) GetConfig() *BookingConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// GetAvailability
+type GetAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailabilityRequest) Reset() {
+	*x = GetAvailabilityRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetAvailabilityRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetAvailabilityRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetAvailabilityRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *BookingConfig         `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Days          []*AvailabilityDay     `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailabilityResponse) Reset() {
+	*x = GetAvailabilityResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailabilityResponse) ProtoMessage() {}
+
+func (x *GetAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetAvailabilityResponse) GetConfig() *BookingConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *GetAvailabilityResponse) GetDays() []*AvailabilityDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+// ReserveBooking places a temporary hold on a date range during checkout
+type ReserveBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reference     string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	HoldSeconds   int32                  `protobuf:"varint,6,opt,name=hold_seconds,json=holdSeconds,proto3" json:"hold_seconds,omitempty"` // optional; defaults to 15 minutes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveBookingRequest) Reset() {
+	*x = ReserveBookingRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveBookingRequest) ProtoMessage() {}
+
+func (x *ReserveBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveBookingRequest.ProtoReflect.Descriptor instead.
+func (*ReserveBookingRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReserveBookingRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReserveBookingRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *ReserveBookingRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *ReserveBookingRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveBookingRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReserveBookingRequest) GetHoldSeconds() int32 {
+	if x != nil {
+		return x.HoldSeconds
+	}
+	return 0
+}
+
+type ReserveBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Booking       *Booking               `protobuf:"bytes,1,opt,name=booking,proto3" json:"booking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveBookingResponse) Reset() {
+	*x = ReserveBookingResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveBookingResponse) ProtoMessage() {}
+
+func (x *ReserveBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveBookingResponse.ProtoReflect.Descriptor instead.
+func (*ReserveBookingResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReserveBookingResponse) GetBooking() *Booking {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+// ConfirmBooking turns a held booking into a confirmed one
+type ConfirmBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     string                 `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmBookingRequest) Reset() {
+	*x = ConfirmBookingRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmBookingRequest) ProtoMessage() {}
+
+func (x *ConfirmBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmBookingRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmBookingRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ConfirmBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+type ConfirmBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Booking       *Booking               `protobuf:"bytes,1,opt,name=booking,proto3" json:"booking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmBookingResponse) Reset() {
+	*x = ConfirmBookingResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmBookingResponse) ProtoMessage() {}
+
+func (x *ConfirmBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmBookingResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmBookingResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ConfirmBookingResponse) GetBooking() *Booking {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+// CancelBooking releases a held or confirmed booking
+type CancelBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     string                 `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBookingRequest) Reset() {
+	*x = CancelBookingRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingRequest) ProtoMessage() {}
+
+func (x *CancelBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingRequest.ProtoReflect.Descriptor instead.
+func (*CancelBookingRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CancelBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+type CancelBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Booking       *Booking               `protobuf:"bytes,1,opt,name=booking,proto3" json:"booking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBookingResponse) Reset() {
+	*x = CancelBookingResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingResponse) ProtoMessage() {}
+
+func (x *CancelBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingResponse.ProtoReflect.Descriptor instead.
+func (*CancelBookingResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CancelBookingResponse) GetBooking() *Booking {
+	if x != nil {
+		return x.Booking
+	}
+	return nil
+}
+
+// GetImageUploadURL returns a presigned URL for uploading a product image
+type GetImageUploadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`        // image/jpeg, image/png, image/webp or image/gif
+	ContentLength int64                  `protobuf:"varint,3,opt,name=content_length,json=contentLength,proto3" json:"content_length,omitempty"` // size in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageUploadURLRequest) Reset() {
+	*x = GetImageUploadURLRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageUploadURLRequest) ProtoMessage() {}
+
+func (x *GetImageUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*GetImageUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetImageUploadURLRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetImageUploadURLRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetImageUploadURLRequest) GetContentLength() int64 {
+	if x != nil {
+		return x.ContentLength
+	}
+	return 0
+}
+
+type GetImageUploadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadUrl     string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`                                                      // HTTP PUT target
+	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`                                                      // pass to AttachImage after the upload
+	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // headers the upload must send unchanged
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageUploadURLResponse) Reset() {
+	*x = GetImageUploadURLResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageUploadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageUploadURLResponse) ProtoMessage() {}
+
+func (x *GetImageUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*GetImageUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetImageUploadURLResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *GetImageUploadURLResponse) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *GetImageUploadURLResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *GetImageUploadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// AttachImage verifies an uploaded object and adds it to the product images
+type AttachImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	AltText       string                 `protobuf:"bytes,3,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachImageRequest) Reset() {
+	*x = AttachImageRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachImageRequest) ProtoMessage() {}
+
+func (x *AttachImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachImageRequest.ProtoReflect.Descriptor instead.
+func (*AttachImageRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AttachImageRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AttachImageRequest) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *AttachImageRequest) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+type AttachImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachImageResponse) Reset() {
+	*x = AttachImageResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachImageResponse) ProtoMessage() {}
+
+func (x *AttachImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachImageResponse.ProtoReflect.Descriptor instead.
+func (*AttachImageResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AttachImageResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// ReorderImages sets the display order of all images of a product
+type ReorderImagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ImageIds      []string               `protobuf:"bytes,2,rep,name=image_ids,json=imageIds,proto3" json:"image_ids,omitempty"` // every image of the product, in the new order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderImagesRequest) Reset() {
+	*x = ReorderImagesRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderImagesRequest) ProtoMessage() {}
+
+func (x *ReorderImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderImagesRequest.ProtoReflect.Descriptor instead.
+func (*ReorderImagesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReorderImagesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReorderImagesRequest) GetImageIds() []string {
+	if x != nil {
+		return x.ImageIds
+	}
+	return nil
+}
+
+type ReorderImagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderImagesResponse) Reset() {
+	*x = ReorderImagesResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderImagesResponse) ProtoMessage() {}
+
+func (x *ReorderImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderImagesResponse.ProtoReflect.Descriptor instead.
+func (*ReorderImagesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReorderImagesResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// SetPrimaryImage marks one image as the product's primary image
+type SetPrimaryImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ImageId       string                 `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPrimaryImageRequest) Reset() {
+	*x = SetPrimaryImageRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPrimaryImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPrimaryImageRequest) ProtoMessage() {}
+
+func (x *SetPrimaryImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPrimaryImageRequest.ProtoReflect.Descriptor instead.
+func (*SetPrimaryImageRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetPrimaryImageRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetPrimaryImageRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+type SetPrimaryImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPrimaryImageResponse) Reset() {
+	*x = SetPrimaryImageResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPrimaryImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPrimaryImageResponse) ProtoMessage() {}
+
+func (x *SetPrimaryImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPrimaryImageResponse.ProtoReflect.Descriptor instead.
+func (*SetPrimaryImageResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetPrimaryImageResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// ReviewImage clears an image's review flag, optionally replacing its alt text
+type ReviewImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ImageId       string                 `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	AltText       string                 `protobuf:"bytes,3,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"` // keeps the current alt text when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewImageRequest) Reset() {
+	*x = ReviewImageRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewImageRequest) ProtoMessage() {}
+
+func (x *ReviewImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewImageRequest.ProtoReflect.Descriptor instead.
+func (*ReviewImageRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReviewImageRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReviewImageRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *ReviewImageRequest) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+type ReviewImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewImageResponse) Reset() {
+	*x = ReviewImageResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewImageResponse) ProtoMessage() {}
+
+func (x *ReviewImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewImageResponse.ProtoReflect.Descriptor instead.
+func (*ReviewImageResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReviewImageResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// SetImageRenditions replaces the renditions of an image. The image service
+// calls it once it has generated them.
+type SetImageRenditionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ImageId       string                 `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Renditions    []*ImageRendition      `protobuf:"bytes,3,rep,name=renditions,proto3" json:"renditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetImageRenditionsRequest) Reset() {
+	*x = SetImageRenditionsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetImageRenditionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetImageRenditionsRequest) ProtoMessage() {}
+
+func (x *SetImageRenditionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetImageRenditionsRequest.ProtoReflect.Descriptor instead.
+func (*SetImageRenditionsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SetImageRenditionsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetImageRenditionsRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *SetImageRenditionsRequest) GetRenditions() []*ImageRendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+type SetImageRenditionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetImageRenditionsResponse) Reset() {
+	*x = SetImageRenditionsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetImageRenditionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetImageRenditionsResponse) ProtoMessage() {}
+
+func (x *SetImageRenditionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetImageRenditionsResponse.ProtoReflect.Descriptor instead.
+func (*SetImageRenditionsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetImageRenditionsResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// PriceChange is one entry of a product's price history
+type PriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OldPrice      float64                `protobuf:"fixed64,3,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"` // 0 for the product's initial price
+	NewPrice      float64                `protobuf:"fixed64,4,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,5,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Seq           int64                  `protobuf:"varint,7,opt,name=seq,proto3" json:"seq,omitempty"`  // position in the audit chain
+	Hash          string                 `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"` // chain hash of this entry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_catalog_catalog_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PriceChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetOldPrice() float64 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *PriceChange) GetNewPrice() float64 {
+	if x != nil {
+		return x.NewPrice
+	}
+	return 0
+}
+
+func (x *PriceChange) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *PriceChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *PriceChange) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PriceChange) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// GetPriceHistory lists a product's price changes, newest first
+type GetPriceHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetPriceHistoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*PriceChange         `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetPriceHistoryResponse) GetChanges() []*PriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// PriceTier is a quantity-break price: unit_price applies from min_quantity
+// up to the next tier
+type PriceTier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinQuantity   int32                  `protobuf:"varint,1,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,2,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceTier) Reset() {
+	*x = PriceTier{}
+	mi := &file_catalog_catalog_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceTier) ProtoMessage() {}
+
+func (x *PriceTier) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceTier.ProtoReflect.Descriptor instead.
+func (*PriceTier) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *PriceTier) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *PriceTier) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+// SetPriceTiers replaces a product's quantity-break prices; an empty list removes them
+type SetPriceTiersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Tiers         []*PriceTier           `protobuf:"bytes,2,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceTiersRequest) Reset() {
+	*x = SetPriceTiersRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceTiersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceTiersRequest) ProtoMessage() {}
+
+func (x *SetPriceTiersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceTiersRequest.ProtoReflect.Descriptor instead.
+func (*SetPriceTiersRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SetPriceTiersRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetPriceTiersRequest) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type SetPriceTiersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tiers         []*PriceTier           `protobuf:"bytes,1,rep,name=tiers,proto3" json:"tiers,omitempty"` // ordered by min_quantity
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceTiersResponse) Reset() {
+	*x = SetPriceTiersResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceTiersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceTiersResponse) ProtoMessage() {}
+
+func (x *SetPriceTiersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceTiersResponse.ProtoReflect.Descriptor instead.
+func (*SetPriceTiersResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SetPriceTiersResponse) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// GetPriceForQuantity prices a quantity of a product for cart and checkout
+type GetPriceForQuantityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceForQuantityRequest) Reset() {
+	*x = GetPriceForQuantityRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceForQuantityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceForQuantityRequest) ProtoMessage() {}
+
+func (x *GetPriceForQuantityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceForQuantityRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceForQuantityRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetPriceForQuantityRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetPriceForQuantityRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type GetPriceForQuantityResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity        int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice       float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	TotalPrice      float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	BasePrice       float64                `protobuf:"fixed64,5,opt,name=base_price,json=basePrice,proto3" json:"base_price,omitempty"`                    // the product's list price
+	TierMinQuantity int32                  `protobuf:"varint,6,opt,name=tier_min_quantity,json=tierMinQuantity,proto3" json:"tier_min_quantity,omitempty"` // min_quantity of the applied tier; 0 when the base or sale price applies
+	Tiers           []*PriceTier           `protobuf:"bytes,7,rep,name=tiers,proto3" json:"tiers,omitempty"`                                               // all tiers, for showing the next price break
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetPriceForQuantityResponse) Reset() {
+	*x = GetPriceForQuantityResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceForQuantityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceForQuantityResponse) ProtoMessage() {}
+
+func (x *GetPriceForQuantityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceForQuantityResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceForQuantityResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetPriceForQuantityResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetPriceForQuantityResponse) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *GetPriceForQuantityResponse) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *GetPriceForQuantityResponse) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *GetPriceForQuantityResponse) GetBasePrice() float64 {
+	if x != nil {
+		return x.BasePrice
+	}
+	return 0
+}
+
+func (x *GetPriceForQuantityResponse) GetTierMinQuantity() int32 {
+	if x != nil {
+		return x.TierMinQuantity
+	}
+	return 0
+}
+
+func (x *GetPriceForQuantityResponse) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// VerifyAuditChain recomputes the price history hash chain and checks it
+// against the stored anchors
+type VerifyAuditChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAuditChainRequest) Reset() {
+	*x = VerifyAuditChainRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditChainRequest) ProtoMessage() {}
+
+func (x *VerifyAuditChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditChainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAuditChainRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{56}
+}
+
+type VerifyAuditChainResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Valid           bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	EntriesChecked  int64                  `protobuf:"varint,2,opt,name=entries_checked,json=entriesChecked,proto3" json:"entries_checked,omitempty"`
+	AnchorsChecked  int32                  `protobuf:"varint,3,opt,name=anchors_checked,json=anchorsChecked,proto3" json:"anchors_checked,omitempty"`
+	HeadSeq         int64                  `protobuf:"varint,4,opt,name=head_seq,json=headSeq,proto3" json:"head_seq,omitempty"`
+	HeadHash        string                 `protobuf:"bytes,5,opt,name=head_hash,json=headHash,proto3" json:"head_hash,omitempty"`
+	FirstInvalidSeq int64                  `protobuf:"varint,6,opt,name=first_invalid_seq,json=firstInvalidSeq,proto3" json:"first_invalid_seq,omitempty"` // 0 when valid
+	Problem         string                 `protobuf:"bytes,7,opt,name=problem,proto3" json:"problem,omitempty"`                                           // empty when valid
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyAuditChainResponse) Reset() {
+	*x = VerifyAuditChainResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditChainResponse) ProtoMessage() {}
+
+func (x *VerifyAuditChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditChainResponse.ProtoReflect.Descriptor instead.
+func (*VerifyAuditChainResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *VerifyAuditChainResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyAuditChainResponse) GetEntriesChecked() int64 {
+	if x != nil {
+		return x.EntriesChecked
+	}
+	return 0
+}
+
+func (x *VerifyAuditChainResponse) GetAnchorsChecked() int32 {
+	if x != nil {
+		return x.AnchorsChecked
+	}
+	return 0
+}
+
+func (x *VerifyAuditChainResponse) GetHeadSeq() int64 {
+	if x != nil {
+		return x.HeadSeq
+	}
+	return 0
+}
+
+func (x *VerifyAuditChainResponse) GetHeadHash() string {
+	if x != nil {
+		return x.HeadHash
+	}
+	return ""
+}
+
+func (x *VerifyAuditChainResponse) GetFirstInvalidSeq() int64 {
+	if x != nil {
+		return x.FirstInvalidSeq
+	}
+	return 0
+}
+
+func (x *VerifyAuditChainResponse) GetProblem() string {
+	if x != nil {
+		return x.Problem
+	}
+	return ""
+}
+
+// IncrementStock atomically adds quantity to a product's stock
+type IncrementStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // must be positive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementStockRequest) Reset() {
+	*x = IncrementStockRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementStockRequest) ProtoMessage() {}
+
+func (x *IncrementStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementStockRequest.ProtoReflect.Descriptor instead.
+func (*IncrementStockRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *IncrementStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *IncrementStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type IncrementStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Stock         int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"` // stock level after the adjustment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementStockResponse) Reset() {
+	*x = IncrementStockResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementStockResponse) ProtoMessage() {}
+
+func (x *IncrementStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementStockResponse.ProtoReflect.Descriptor instead.
+func (*IncrementStockResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *IncrementStockResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *IncrementStockResponse) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+// DecrementStock atomically removes quantity from a product's stock, failing
+// without a change when stock is insufficient
+type DecrementStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // must be positive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecrementStockRequest) Reset() {
+	*x = DecrementStockRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecrementStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecrementStockRequest) ProtoMessage() {}
+
+func (x *DecrementStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecrementStockRequest.ProtoReflect.Descriptor instead.
+func (*DecrementStockRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DecrementStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DecrementStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type DecrementStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Stock         int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"` // stock level after the adjustment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecrementStockResponse) Reset() {
+	*x = DecrementStockResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecrementStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecrementStockResponse) ProtoMessage() {}
+
+func (x *DecrementStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecrementStockResponse.ProtoReflect.Descriptor instead.
+func (*DecrementStockResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DecrementStockResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DecrementStockResponse) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+// ListLowStockProducts lists products at or below their low-stock threshold
+type ListLowStockProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowStockProductsRequest) Reset() {
+	*x = ListLowStockProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowStockProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowStockProductsRequest) ProtoMessage() {}
+
+func (x *ListLowStockProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowStockProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListLowStockProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListLowStockProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLowStockProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListLowStockProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"` // lowest stock first
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowStockProductsResponse) Reset() {
+	*x = ListLowStockProductsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowStockProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowStockProductsResponse) ProtoMessage() {}
+
+func (x *ListLowStockProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowStockProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListLowStockProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListLowStockProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListLowStockProductsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListLowStockProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLowStockProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// StockReservation is stock held for a checkout; the quantity is deducted
+// from stock while HELD and returned when released or expired
+type StockReservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                        // HELD, COMMITTED, RELEASED or EXPIRED
+	Reference     string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`                  // checkout or order reference
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // set while HELD
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservation) Reset() {
+	*x = StockReservation{}
+	mi := &file_catalog_catalog_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservation) ProtoMessage() {}
+
+func (x *StockReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservation.ProtoReflect.Descriptor instead.
+func (*StockReservation) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *StockReservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockReservation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockReservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockReservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StockReservation) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *StockReservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StockReservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ReserveStock holds stock for a checkout until it is committed or expires
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // must be positive
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	HoldSeconds   int32                  `protobuf:"varint,4,opt,name=hold_seconds,json=holdSeconds,proto3" json:"hold_seconds,omitempty"` // optional; defaults to 15 minutes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ReserveStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetHoldSeconds() int32 {
+	if x != nil {
+		return x.HoldSeconds
+	}
+	return 0
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ReserveStockResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// ReleaseReservation returns held stock when a checkout is abandoned
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ReleaseReservationResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// CommitReservation makes a held reservation permanent when the order is placed
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CommitReservationResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// UncommitReservation returns the stock of a committed reservation when the
+// checkout it was committed for fails afterwards
+type UncommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UncommitReservationRequest) Reset() {
+	*x = UncommitReservationRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UncommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncommitReservationRequest) ProtoMessage() {}
+
+func (x *UncommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*UncommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *UncommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type UncommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UncommitReservationResponse) Reset() {
+	*x = UncommitReservationResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UncommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncommitReservationResponse) ProtoMessage() {}
+
+func (x *UncommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*UncommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *UncommitReservationResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// BundleComponent is a component product and its quantity in one bundle
+type BundleComponent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Product       *Product               `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"` // populated in responses
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundleComponent) Reset() {
+	*x = BundleComponent{}
+	mi := &file_catalog_catalog_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundleComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleComponent) ProtoMessage() {}
+
+func (x *BundleComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleComponent.ProtoReflect.Descriptor instead.
+func (*BundleComponent) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *BundleComponent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BundleComponent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *BundleComponent) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Bundle is a product sold as a kit of component products. Its price and
+// stock are computed from the components.
+type Bundle struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	DiscountPercent float64                `protobuf:"fixed64,2,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty"` // taken off the sum of the component prices
+	Components      []*BundleComponent     `protobuf:"bytes,3,rep,name=components,proto3" json:"components,omitempty"`                                    // in the given order
+	ListPrice       float64                `protobuf:"fixed64,4,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"`                   // sum of the component list prices
+	Price           float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`                                            // sum of the component effective prices less the discount
+	Stock           int32                  `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`                                             // complete bundles the component stock allows
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Bundle) Reset() {
+	*x = Bundle{}
+	mi := &file_catalog_catalog_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bundle) ProtoMessage() {}
+
+func (x *Bundle) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bundle.ProtoReflect.Descriptor instead.
+func (*Bundle) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *Bundle) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Bundle) GetDiscountPercent() float64 {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return 0
+}
+
+func (x *Bundle) GetComponents() []*BundleComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Bundle) GetListPrice() float64 {
+	if x != nil {
+		return x.ListPrice
+	}
+	return 0
+}
+
+func (x *Bundle) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Bundle) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+// SetBundle makes a product a bundle of the given components; an empty list
+// turns it back into a simple product
+type SetBundleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Components      []*BundleComponent     `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"` // product_id and quantity only
+	DiscountPercent float64                `protobuf:"fixed64,3,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetBundleRequest) Reset() {
+	*x = SetBundleRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBundleRequest) ProtoMessage() {}
+
+func (x *SetBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBundleRequest.ProtoReflect.Descriptor instead.
+func (*SetBundleRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SetBundleRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetBundleRequest) GetComponents() []*BundleComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *SetBundleRequest) GetDiscountPercent() float64 {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return 0
+}
+
+type SetBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *Bundle                `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"` // unset when the bundle was removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBundleResponse) Reset() {
+	*x = SetBundleResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBundleResponse) ProtoMessage() {}
+
+func (x *SetBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBundleResponse.ProtoReflect.Descriptor instead.
+func (*SetBundleResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SetBundleResponse) GetBundle() *Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type GetBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBundleRequest) Reset() {
+	*x = GetBundleRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBundleRequest) ProtoMessage() {}
+
+func (x *GetBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBundleRequest.ProtoReflect.Descriptor instead.
+func (*GetBundleRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetBundleRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type GetBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *Bundle                `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBundleResponse) Reset() {
+	*x = GetBundleResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBundleResponse) ProtoMessage() {}
+
+func (x *GetBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBundleResponse.ProtoReflect.Descriptor instead.
+func (*GetBundleResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetBundleResponse) GetBundle() *Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+// DigitalAsset is a file delivered with a digital product
+type DigitalAsset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,3,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	FileName      string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DigitalAsset) Reset() {
+	*x = DigitalAsset{}
+	mi := &file_catalog_catalog_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DigitalAsset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DigitalAsset) ProtoMessage() {}
+
+func (x *DigitalAsset) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DigitalAsset.ProtoReflect.Descriptor instead.
+func (*DigitalAsset) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DigitalAsset) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DigitalAsset) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DigitalAsset) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *DigitalAsset) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DigitalAsset) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DigitalAsset) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *DigitalAsset) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Entitlement allows a user to download a digital product
+type Entitlement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"` // order reference
+	GrantedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=granted_at,json=grantedAt,proto3" json:"granted_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"` // set once revoked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entitlement) Reset() {
+	*x = Entitlement{}
+	mi := &file_catalog_catalog_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entitlement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entitlement) ProtoMessage() {}
+
+func (x *Entitlement) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entitlement.ProtoReflect.Descriptor instead.
+func (*Entitlement) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *Entitlement) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Entitlement) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Entitlement) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Entitlement) GetGrantedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GrantedAt
+	}
+	return nil
+}
+
+func (x *Entitlement) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+// GetDigitalAssetUploadURL returns a presigned URL for uploading a file of a digital product
+type GetDigitalAssetUploadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	ContentLength int64                  `protobuf:"varint,3,opt,name=content_length,json=contentLength,proto3" json:"content_length,omitempty"` // size in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigitalAssetUploadURLRequest) Reset() {
+	*x = GetDigitalAssetUploadURLRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigitalAssetUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigitalAssetUploadURLRequest) ProtoMessage() {}
+
+func (x *GetDigitalAssetUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigitalAssetUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*GetDigitalAssetUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetDigitalAssetUploadURLRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetDigitalAssetUploadURLRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetDigitalAssetUploadURLRequest) GetContentLength() int64 {
+	if x != nil {
+		return x.ContentLength
+	}
+	return 0
+}
+
+type GetDigitalAssetUploadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadUrl     string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`                                                      // HTTP PUT target
+	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`                                                      // pass to AttachDigitalAsset after the upload
+	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // headers the upload must send unchanged
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigitalAssetUploadURLResponse) Reset() {
+	*x = GetDigitalAssetUploadURLResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigitalAssetUploadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigitalAssetUploadURLResponse) ProtoMessage() {}
+
+func (x *GetDigitalAssetUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigitalAssetUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*GetDigitalAssetUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetDigitalAssetUploadURLResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *GetDigitalAssetUploadURLResponse) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *GetDigitalAssetUploadURLResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *GetDigitalAssetUploadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// AttachDigitalAsset verifies an uploaded object and adds it to the product's files
+type AttachDigitalAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"` // name shown to customers
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachDigitalAssetRequest) Reset() {
+	*x = AttachDigitalAssetRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachDigitalAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachDigitalAssetRequest) ProtoMessage() {}
+
+func (x *AttachDigitalAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachDigitalAssetRequest.ProtoReflect.Descriptor instead.
+func (*AttachDigitalAssetRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *AttachDigitalAssetRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AttachDigitalAssetRequest) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *AttachDigitalAssetRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+type AttachDigitalAssetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *DigitalAsset          `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachDigitalAssetResponse) Reset() {
+	*x = AttachDigitalAssetResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachDigitalAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachDigitalAssetResponse) ProtoMessage() {}
+
+func (x *AttachDigitalAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachDigitalAssetResponse.ProtoReflect.Descriptor instead.
+func (*AttachDigitalAssetResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *AttachDigitalAssetResponse) GetAsset() *DigitalAsset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type ListDigitalAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDigitalAssetsRequest) Reset() {
+	*x = ListDigitalAssetsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDigitalAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDigitalAssetsRequest) ProtoMessage() {}
+
+func (x *ListDigitalAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDigitalAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ListDigitalAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ListDigitalAssetsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListDigitalAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*DigitalAsset        `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDigitalAssetsResponse) Reset() {
+	*x = ListDigitalAssetsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDigitalAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDigitalAssetsResponse) ProtoMessage() {}
+
+func (x *ListDigitalAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDigitalAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ListDigitalAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ListDigitalAssetsResponse) GetAssets() []*DigitalAsset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+// GrantEntitlement allows a user to download a digital product, typically once
+// their order is paid
+type GrantEntitlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantEntitlementRequest) Reset() {
+	*x = GrantEntitlementRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantEntitlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantEntitlementRequest) ProtoMessage() {}
+
+func (x *GrantEntitlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantEntitlementRequest.ProtoReflect.Descriptor instead.
+func (*GrantEntitlementRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GrantEntitlementRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GrantEntitlementRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GrantEntitlementRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type GrantEntitlementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entitlement   *Entitlement           `protobuf:"bytes,1,opt,name=entitlement,proto3" json:"entitlement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantEntitlementResponse) Reset() {
+	*x = GrantEntitlementResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantEntitlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantEntitlementResponse) ProtoMessage() {}
+
+func (x *GrantEntitlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantEntitlementResponse.ProtoReflect.Descriptor instead.
+func (*GrantEntitlementResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GrantEntitlementResponse) GetEntitlement() *Entitlement {
+	if x != nil {
+		return x.Entitlement
+	}
+	return nil
+}
+
+// RevokeEntitlement withdraws a user's access, for example after a refund
+type RevokeEntitlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeEntitlementRequest) Reset() {
+	*x = RevokeEntitlementRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeEntitlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeEntitlementRequest) ProtoMessage() {}
+
+func (x *RevokeEntitlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeEntitlementRequest.ProtoReflect.Descriptor instead.
+func (*RevokeEntitlementRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *RevokeEntitlementRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeEntitlementRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type RevokeEntitlementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entitlement   *Entitlement           `protobuf:"bytes,1,opt,name=entitlement,proto3" json:"entitlement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeEntitlementResponse) Reset() {
+	*x = RevokeEntitlementResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeEntitlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeEntitlementResponse) ProtoMessage() {}
+
+func (x *RevokeEntitlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeEntitlementResponse.ProtoReflect.Descriptor instead.
+func (*RevokeEntitlementResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *RevokeEntitlementResponse) GetEntitlement() *Entitlement {
+	if x != nil {
+		return x.Entitlement
+	}
+	return nil
+}
+
+// GenerateDownloadURL returns a short-lived download link for a file of a
+// digital product. The caller is identified by the bearer token in the
+// authorization metadata and must hold an entitlement to the product.
+type GenerateDownloadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	AssetId       string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateDownloadURLRequest) Reset() {
+	*x = GenerateDownloadURLRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateDownloadURLRequest) ProtoMessage() {}
+
+func (x *GenerateDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GenerateDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GenerateDownloadURLRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GenerateDownloadURLRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+type GenerateDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateDownloadURLResponse) Reset() {
+	*x = GenerateDownloadURLResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateDownloadURLResponse) ProtoMessage() {}
+
+func (x *GenerateDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*GenerateDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GenerateDownloadURLResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *GenerateDownloadURLResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *GenerateDownloadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ProductTranslation is the name and description of a product in one locale
+type ProductTranslation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"` // empty when the default locale description applies
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductTranslation) Reset() {
+	*x = ProductTranslation{}
+	mi := &file_catalog_catalog_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductTranslation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductTranslation) ProtoMessage() {}
+
+func (x *ProductTranslation) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductTranslation.ProtoReflect.Descriptor instead.
+func (*ProductTranslation) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ProductTranslation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// SetProductTranslation creates or replaces a product translation
+type SetProductTranslationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"` // BCP 47 tag such as "fr" or "pt-BR"; not the default locale
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductTranslationRequest) Reset() {
+	*x = SetProductTranslationRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductTranslationRequest) ProtoMessage() {}
+
+func (x *SetProductTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductTranslationRequest.ProtoReflect.Descriptor instead.
+func (*SetProductTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *SetProductTranslationRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetProductTranslationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SetProductTranslationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetProductTranslationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type SetProductTranslationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Translation   *ProductTranslation    `protobuf:"bytes,1,opt,name=translation,proto3" json:"translation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductTranslationResponse) Reset() {
+	*x = SetProductTranslationResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductTranslationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductTranslationResponse) ProtoMessage() {}
+
+func (x *SetProductTranslationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductTranslationResponse.ProtoReflect.Descriptor instead.
+func (*SetProductTranslationResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *SetProductTranslationResponse) GetTranslation() *ProductTranslation {
+	if x != nil {
+		return x.Translation
+	}
+	return nil
+}
+
+type DeleteProductTranslationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductTranslationRequest) Reset() {
+	*x = DeleteProductTranslationRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductTranslationRequest) ProtoMessage() {}
+
+func (x *DeleteProductTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductTranslationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *DeleteProductTranslationRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeleteProductTranslationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type DeleteProductTranslationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductTranslationResponse) Reset() {
+	*x = DeleteProductTranslationResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductTranslationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductTranslationResponse) ProtoMessage() {}
+
+func (x *DeleteProductTranslationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductTranslationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductTranslationResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{97}
+}
+
+type ListProductTranslationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductTranslationsRequest) Reset() {
+	*x = ListProductTranslationsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductTranslationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductTranslationsRequest) ProtoMessage() {}
+
+func (x *ListProductTranslationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductTranslationsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductTranslationsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ListProductTranslationsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListProductTranslationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Translations  []*ProductTranslation  `protobuf:"bytes,1,rep,name=translations,proto3" json:"translations,omitempty"`                        // ordered by locale
+	DefaultLocale string                 `protobuf:"bytes,2,opt,name=default_locale,json=defaultLocale,proto3" json:"default_locale,omitempty"` // locale of the product's own name and description
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductTranslationsResponse) Reset() {
+	*x = ListProductTranslationsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductTranslationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductTranslationsResponse) ProtoMessage() {}
+
+func (x *ListProductTranslationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductTranslationsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductTranslationsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ListProductTranslationsResponse) GetTranslations() []*ProductTranslation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *ListProductTranslationsResponse) GetDefaultLocale() string {
+	if x != nil {
+		return x.DefaultLocale
+	}
+	return ""
+}
+
+// UpdateRatingAggregate stores the review summary of a product computed by the
+// review service. Aggregates older than the stored one are ignored, so
+// redelivered or reordered updates are safe.
+type UpdateRatingAggregateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	AverageRating float64                `protobuf:"fixed64,2,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"` // 1 to 5, or 0 without reviews
+	ReviewCount   int32                  `protobuf:"varint,3,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"` // when the aggregate was computed; defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRatingAggregateRequest) Reset() {
+	*x = UpdateRatingAggregateRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRatingAggregateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRatingAggregateRequest) ProtoMessage() {}
+
+func (x *UpdateRatingAggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRatingAggregateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRatingAggregateRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *UpdateRatingAggregateRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateRatingAggregateRequest) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *UpdateRatingAggregateRequest) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *UpdateRatingAggregateRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type UpdateRatingAggregateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Applied       bool                   `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"` // false when a newer aggregate was already stored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRatingAggregateResponse) Reset() {
+	*x = UpdateRatingAggregateResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRatingAggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRatingAggregateResponse) ProtoMessage() {}
+
+func (x *UpdateRatingAggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRatingAggregateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRatingAggregateResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *UpdateRatingAggregateResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpdateRatingAggregateResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+// ProductActivityEvent is a view or an order of a product, consumed from the
+// storefront and order events
+type ProductActivityEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // unique per event, e.g. order ID and line number; redelivered events are counted once
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                               // VIEW or ORDER
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`                      // units ordered, or views; defaults to 1
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // defaults to now; selects the day the event counts for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductActivityEvent) Reset() {
+	*x = ProductActivityEvent{}
+	mi := &file_catalog_catalog_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductActivityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductActivityEvent) ProtoMessage() {}
+
+func (x *ProductActivityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductActivityEvent.ProtoReflect.Descriptor instead.
+func (*ProductActivityEvent) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *ProductActivityEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ProductActivityEvent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductActivityEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductActivityEvent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ProductActivityEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// RecordProductActivity adds events to the daily activity counts behind
+// ListBestSellers and the POPULARITY sort
+type RecordProductActivityRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Events        []*ProductActivityEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // at most 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordProductActivityRequest) Reset() {
+	*x = RecordProductActivityRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordProductActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordProductActivityRequest) ProtoMessage() {}
+
+func (x *RecordProductActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordProductActivityRequest.ProtoReflect.Descriptor instead.
+func (*RecordProductActivityRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *RecordProductActivityRequest) GetEvents() []*ProductActivityEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type RecordProductActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recorded      int32                  `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	Ignored       int32                  `protobuf:"varint,2,opt,name=ignored,proto3" json:"ignored,omitempty"` // already recorded, or of unknown products
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordProductActivityResponse) Reset() {
+	*x = RecordProductActivityResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordProductActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordProductActivityResponse) ProtoMessage() {}
+
+func (x *RecordProductActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordProductActivityResponse.ProtoReflect.Descriptor instead.
+func (*RecordProductActivityResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *RecordProductActivityResponse) GetRecorded() int32 {
+	if x != nil {
+		return x.Recorded
+	}
+	return 0
+}
+
+func (x *RecordProductActivityResponse) GetIgnored() int32 {
+	if x != nil {
+		return x.Ignored
+	}
+	return 0
+}
+
+// ListBestSellers ranks active products by units sold over a time window
+type ListBestSellersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        string                 `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`     // DAY (today, UTC), WEEK (default; last 7 days), MONTH (last 30 days) or ALL_TIME
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"` // empty ranks every category
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`      // default 10, max 100
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`     // Accept-Language value; defaults to the accept-language metadata
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBestSellersRequest) Reset() {
+	*x = ListBestSellersRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBestSellersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBestSellersRequest) ProtoMessage() {}
+
+func (x *ListBestSellersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBestSellersRequest.ProtoReflect.Descriptor instead.
+func (*ListBestSellersRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *ListBestSellersRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *ListBestSellersRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListBestSellersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListBestSellersRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// BestSeller is a product with its activity over the requested window
+type BestSeller struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	UnitsSold     int64                  `protobuf:"varint,2,opt,name=units_sold,json=unitsSold,proto3" json:"units_sold,omitempty"`
+	OrderCount    int64                  `protobuf:"varint,3,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	ViewCount     int64                  `protobuf:"varint,4,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BestSeller) Reset() {
+	*x = BestSeller{}
+	mi := &file_catalog_catalog_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BestSeller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BestSeller) ProtoMessage() {}
+
+func (x *BestSeller) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BestSeller.ProtoReflect.Descriptor instead.
+func (*BestSeller) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *BestSeller) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *BestSeller) GetUnitsSold() int64 {
+	if x != nil {
+		return x.UnitsSold
+	}
+	return 0
+}
+
+func (x *BestSeller) GetOrderCount() int64 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+func (x *BestSeller) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+type ListBestSellersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BestSellers   []*BestSeller          `protobuf:"bytes,1,rep,name=best_sellers,json=bestSellers,proto3" json:"best_sellers,omitempty"` // most units sold first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBestSellersResponse) Reset() {
+	*x = ListBestSellersResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBestSellersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBestSellersResponse) ProtoMessage() {}
+
+func (x *ListBestSellersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBestSellersResponse.ProtoReflect.Descriptor instead.
+func (*ListBestSellersResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ListBestSellersResponse) GetBestSellers() []*BestSeller {
+	if x != nil {
+		return x.BestSellers
+	}
+	return nil
+}
+
+// SuggestProducts completes a search-as-you-type prefix with product names and
+// categories
+type SuggestProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`   // matched case-insensitively against the start of names
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`    // products and categories each; default 5, max 10
+	Channel       string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"` // as in ListProductsRequest
+	CustomerGroup string                 `protobuf:"bytes,4,opt,name=customer_group,json=customerGroup,proto3" json:"customer_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestProductsRequest) Reset() {
+	*x = SuggestProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestProductsRequest) ProtoMessage() {}
+
+func (x *SuggestProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestProductsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *SuggestProductsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SuggestProductsRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *SuggestProductsRequest) GetCustomerGroup() string {
+	if x != nil {
+		return x.CustomerGroup
+	}
+	return ""
+}
+
+type ProductSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSuggestion) Reset() {
+	*x = ProductSuggestion{}
+	mi := &file_catalog_catalog_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSuggestion) ProtoMessage() {}
+
+func (x *ProductSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSuggestion.ProtoReflect.Descriptor instead.
+func (*ProductSuggestion) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *ProductSuggestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductSuggestion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SuggestProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductSuggestion   `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`     // ordered by name
+	Categories    []string               `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"` // ordered by name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestProductsResponse) Reset() {
+	*x = SuggestProductsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestProductsResponse) ProtoMessage() {}
+
+func (x *SuggestProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestProductsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *SuggestProductsResponse) GetProducts() []*ProductSuggestion {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *SuggestProductsResponse) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// ProductVisibility restricts where a product is listed and searchable. An
+// empty list places no restriction.
+type ProductVisibility struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Channels       []string               `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"` // WEB, MOBILE and/or B2B
+	CustomerGroups []string               `protobuf:"bytes,3,rep,name=customer_groups,json=customerGroups,proto3" json:"customer_groups,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProductVisibility) Reset() {
+	*x = ProductVisibility{}
+	mi := &file_catalog_catalog_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductVisibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductVisibility) ProtoMessage() {}
+
+func (x *ProductVisibility) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductVisibility.ProtoReflect.Descriptor instead.
+func (*ProductVisibility) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *ProductVisibility) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductVisibility) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *ProductVisibility) GetCustomerGroups() []string {
+	if x != nil {
+		return x.CustomerGroups
+	}
+	return nil
+}
+
+func (x *ProductVisibility) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// SetProductVisibility replaces the visibility rules of a product
+type SetProductVisibilityRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Channels       []string               `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+	CustomerGroups []string               `protobuf:"bytes,3,rep,name=customer_groups,json=customerGroups,proto3" json:"customer_groups,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetProductVisibilityRequest) Reset() {
+	*x = SetProductVisibilityRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductVisibilityRequest) ProtoMessage() {}
+
+func (x *SetProductVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*SetProductVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *SetProductVisibilityRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetProductVisibilityRequest) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *SetProductVisibilityRequest) GetCustomerGroups() []string {
+	if x != nil {
+		return x.CustomerGroups
+	}
+	return nil
+}
+
+type SetProductVisibilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Visibility    *ProductVisibility     `protobuf:"bytes,1,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductVisibilityResponse) Reset() {
+	*x = SetProductVisibilityResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductVisibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductVisibilityResponse) ProtoMessage() {}
+
+func (x *SetProductVisibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductVisibilityResponse.ProtoReflect.Descriptor instead.
+func (*SetProductVisibilityResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *SetProductVisibilityResponse) GetVisibility() *ProductVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return nil
+}
+
+type GetProductVisibilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductVisibilityRequest) Reset() {
+	*x = GetProductVisibilityRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductVisibilityRequest) ProtoMessage() {}
+
+func (x *GetProductVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*GetProductVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *GetProductVisibilityRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type GetProductVisibilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Visibility    *ProductVisibility     `protobuf:"bytes,1,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductVisibilityResponse) Reset() {
+	*x = GetProductVisibilityResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductVisibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductVisibilityResponse) ProtoMessage() {}
+
+func (x *GetProductVisibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductVisibilityResponse.ProtoReflect.Descriptor instead.
+func (*GetProductVisibilityResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *GetProductVisibilityResponse) GetVisibility() *ProductVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return nil
+}
+
+// ChangeSKU replaces the SKU of a product. The old SKU is kept as an alias so
+// references to it still resolve, and cannot be used by another product.
+type ChangeSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	NewSku        string                 `protobuf:"bytes,2,opt,name=new_sku,json=newSku,proto3" json:"new_sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeSKURequest) Reset() {
+	*x = ChangeSKURequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeSKURequest) ProtoMessage() {}
+
+func (x *ChangeSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeSKURequest.ProtoReflect.Descriptor instead.
+func (*ChangeSKURequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ChangeSKURequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ChangeSKURequest) GetNewSku() string {
+	if x != nil {
+		return x.NewSku
+	}
+	return ""
+}
+
+type ChangeSKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	PreviousSku   string                 `protobuf:"bytes,2,opt,name=previous_sku,json=previousSku,proto3" json:"previous_sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeSKUResponse) Reset() {
+	*x = ChangeSKUResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeSKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeSKUResponse) ProtoMessage() {}
+
+func (x *ChangeSKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeSKUResponse.ProtoReflect.Descriptor instead.
+func (*ChangeSKUResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ChangeSKUResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ChangeSKUResponse) GetPreviousSku() string {
+	if x != nil {
+		return x.PreviousSku
+	}
+	return ""
+}
+
+// GetProductBySKU resolves current and former SKUs
+type GetProductBySKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"` // Accept-Language value such as "fr-CA, fr;q=0.8"; defaults to the accept-language metadata
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductBySKURequest) Reset() {
+	*x = GetProductBySKURequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductBySKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductBySKURequest) ProtoMessage() {}
+
+func (x *GetProductBySKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductBySKURequest.ProtoReflect.Descriptor instead.
+func (*GetProductBySKURequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *GetProductBySKURequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *GetProductBySKURequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetProductBySKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	FormerSku     bool                   `protobuf:"varint,2,opt,name=former_sku,json=formerSku,proto3" json:"former_sku,omitempty"` // the requested SKU is an alias; product.sku is the current one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductBySKUResponse) Reset() {
+	*x = GetProductBySKUResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductBySKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductBySKUResponse) ProtoMessage() {}
+
+func (x *GetProductBySKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductBySKUResponse.ProtoReflect.Descriptor instead.
+func (*GetProductBySKUResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *GetProductBySKUResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *GetProductBySKUResponse) GetFormerSku() bool {
+	if x != nil {
+		return x.FormerSku
+	}
+	return false
+}
+
+// GetProductsBySKUs resolves up to 100 current or former SKUs in one call, for
+// imports and order ingestion that identify items by SKU
+type GetProductsBySKUsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skus          []string               `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"` // Accept-Language value; defaults to the accept-language metadata
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsBySKUsRequest) Reset() {
+	*x = GetProductsBySKUsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsBySKUsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsBySKUsRequest) ProtoMessage() {}
+
+func (x *GetProductsBySKUsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsBySKUsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsBySKUsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *GetProductsBySKUsRequest) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *GetProductsBySKUsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// SKUMatch is the product a requested SKU resolves to
+type SKUMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"` // the requested SKU
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	FormerSku     bool                   `protobuf:"varint,3,opt,name=former_sku,json=formerSku,proto3" json:"former_sku,omitempty"` // the requested SKU is an alias; product.sku is the current one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SKUMatch) Reset() {
+	*x = SKUMatch{}
+	mi := &file_catalog_catalog_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SKUMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SKUMatch) ProtoMessage() {}
+
+func (x *SKUMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SKUMatch.ProtoReflect.Descriptor instead.
+func (*SKUMatch) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *SKUMatch) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SKUMatch) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *SKUMatch) GetFormerSku() bool {
+	if x != nil {
+		return x.FormerSku
+	}
+	return false
+}
+
+type GetProductsBySKUsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*SKUMatch            `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`                   // in request order
+	NotFound      []string               `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"` // requested SKUs matching no product, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsBySKUsResponse) Reset() {
+	*x = GetProductsBySKUsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsBySKUsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsBySKUsResponse) ProtoMessage() {}
+
+func (x *GetProductsBySKUsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsBySKUsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsBySKUsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *GetProductsBySKUsResponse) GetMatches() []*SKUMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *GetProductsBySKUsResponse) GetNotFound() []string {
+	if x != nil {
+		return x.NotFound
+	}
+	return nil
+}
+
+// GetCategoryStats summarizes every category, for navigation menus and admin
+// dashboards
+type GetCategoryStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncludeDrafts bool                   `protobuf:"varint,1,opt,name=include_drafts,json=includeDrafts,proto3" json:"include_drafts,omitempty"` // count DRAFT products too
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryStatsRequest) Reset() {
+	*x = GetCategoryStatsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryStatsRequest) ProtoMessage() {}
+
+func (x *GetCategoryStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryStatsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *GetCategoryStatsRequest) GetIncludeDrafts() bool {
+	if x != nil {
+		return x.IncludeDrafts
+	}
+	return false
+}
+
+// CategoryStats summarizes the products of one category
+type CategoryStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"` // empty for products without a category
+	ProductCount  int32                  `protobuf:"varint,2,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"`
+	MinPrice      float64                `protobuf:"fixed64,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"` // list prices; sale prices are not considered
+	MaxPrice      float64                `protobuf:"fixed64,4,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	TotalStock    int64                  `protobuf:"varint,5,opt,name=total_stock,json=totalStock,proto3" json:"total_stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryStats) Reset() {
+	*x = CategoryStats{}
+	mi := &file_catalog_catalog_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryStats) ProtoMessage() {}
+
+func (x *CategoryStats) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryStats.ProtoReflect.Descriptor instead.
+func (*CategoryStats) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *CategoryStats) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryStats) GetProductCount() int32 {
+	if x != nil {
+		return x.ProductCount
+	}
+	return 0
+}
+
+func (x *CategoryStats) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *CategoryStats) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *CategoryStats) GetTotalStock() int64 {
+	if x != nil {
+		return x.TotalStock
+	}
+	return 0
+}
+
+type GetCategoryStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategoryStats       `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"` // ordered by category
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryStatsResponse) Reset() {
+	*x = GetCategoryStatsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryStatsResponse) ProtoMessage() {}
+
+func (x *GetCategoryStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryStatsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *GetCategoryStatsResponse) GetCategories() []*CategoryStats {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// SKUAlias is a former SKU of a product
+type SKUAlias struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,2,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SKUAlias) Reset() {
+	*x = SKUAlias{}
+	mi := &file_catalog_catalog_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SKUAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SKUAlias) ProtoMessage() {}
+
+func (x *SKUAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SKUAlias.ProtoReflect.Descriptor instead.
+func (*SKUAlias) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *SKUAlias) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SKUAlias) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *SKUAlias) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type ListSKUAliasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSKUAliasesRequest) Reset() {
+	*x = ListSKUAliasesRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSKUAliasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSKUAliasesRequest) ProtoMessage() {}
+
+func (x *ListSKUAliasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSKUAliasesRequest.ProtoReflect.Descriptor instead.
+func (*ListSKUAliasesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *ListSKUAliasesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListSKUAliasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aliases       []*SKUAlias            `protobuf:"bytes,1,rep,name=aliases,proto3" json:"aliases,omitempty"` // most recent first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSKUAliasesResponse) Reset() {
+	*x = ListSKUAliasesResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSKUAliasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSKUAliasesResponse) ProtoMessage() {}
+
+func (x *ListSKUAliasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSKUAliasesResponse.ProtoReflect.Descriptor instead.
+func (*ListSKUAliasesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *ListSKUAliasesResponse) GetAliases() []*SKUAlias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+// CloneProduct creates a DRAFT copy of a product with its images, price tiers
+// and translations. Stock, barcodes, ratings and digital assets are not copied.
+type CloneProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceId      string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`   // required; must not be used by another product
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // defaults to the source name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneProductRequest) Reset() {
+	*x = CloneProductRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneProductRequest) ProtoMessage() {}
+
+func (x *CloneProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneProductRequest.ProtoReflect.Descriptor instead.
+func (*CloneProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *CloneProductRequest) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *CloneProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CloneProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CloneProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneProductResponse) Reset() {
+	*x = CloneProductResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneProductResponse) ProtoMessage() {}
+
+func (x *CloneProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneProductResponse.ProtoReflect.Descriptor instead.
+func (*CloneProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *CloneProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// StreamProducts streams products ordered by updated_at, for initial loads and
+// incremental syncs of downstream systems. Drafts are included; their status
+// tells consumers to hide them.
+type StreamProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedSince  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"` // inclusive; unset streams the whole catalog
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamProductsRequest) Reset() {
+	*x = StreamProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProductsRequest) ProtoMessage() {}
+
+func (x *StreamProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProductsRequest.ProtoReflect.Descriptor instead.
+func (*StreamProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *StreamProductsRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+// WatchProducts pushes product changes as they are committed. Changes made
+// while a subscriber is not connected are not replayed; use StreamProducts to
+// catch up. Response headers are sent once the subscription is live, so a
+// subscriber that waits for them before catching up misses no change.
+type WatchProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"` // empty watches every product
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchProductsRequest) Reset() {
+	*x = WatchProductsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchProductsRequest) ProtoMessage() {}
+
+func (x *WatchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchProductsRequest.ProtoReflect.Descriptor instead.
+func (*WatchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *WatchProductsRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+// ProductChangeEvent is a committed change to a product
+type ProductChangeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // CREATED, UPDATED or DELETED
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Product       *Product               `protobuf:"bytes,4,opt,name=product,proto3" json:"product,omitempty"` // the product after the change; unset for DELETED or when it could not be loaded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductChangeEvent) Reset() {
+	*x = ProductChangeEvent{}
+	mi := &file_catalog_catalog_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductChangeEvent) ProtoMessage() {}
+
+func (x *ProductChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductChangeEvent.ProtoReflect.Descriptor instead.
+func (*ProductChangeEvent) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *ProductChangeEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductChangeEvent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductChangeEvent) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *ProductChangeEvent) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// FieldChange is the old and new value of one product field, formatted as text.
+// An empty value means the field was not set.
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_catalog_catalog_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// ProductAuditEntry records who created, updated or deleted a product and
+// which fields changed
+type ProductAuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"` // CREATE, UPDATE or DELETE
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductAuditEntry) Reset() {
+	*x = ProductAuditEntry{}
+	mi := &file_catalog_catalog_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAuditEntry) ProtoMessage() {}
+
+func (x *ProductAuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAuditEntry.ProtoReflect.Descriptor instead.
+func (*ProductAuditEntry) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *ProductAuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductAuditEntry) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductAuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ProductAuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ProductAuditEntry) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ProductAuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// GetProductAuditLog lists a product's mutations, newest first. Entries are
+// kept after the product is deleted.
+type GetProductAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductAuditLogRequest) Reset() {
+	*x = GetProductAuditLogRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductAuditLogRequest) ProtoMessage() {}
+
+func (x *GetProductAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*GetProductAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *GetProductAuditLogRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetProductAuditLogRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetProductAuditLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetProductAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*ProductAuditEntry   `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductAuditLogResponse) Reset() {
+	*x = GetProductAuditLogResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductAuditLogResponse) ProtoMessage() {}
+
+func (x *GetProductAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*GetProductAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *GetProductAuditLogResponse) GetEntries() []*ProductAuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetProductAuditLogResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetProductAuditLogResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetProductAuditLogResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ProductAnswer is a customer's answer to a product question
+type ProductAnswer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	QuestionId    string                 `protobuf:"bytes,2,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // PENDING, APPROVED or REJECTED
+	ModeratedBy   string                 `protobuf:"bytes,6,opt,name=moderated_by,json=moderatedBy,proto3" json:"moderated_by,omitempty"`
+	ModeratedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=moderated_at,json=moderatedAt,proto3" json:"moderated_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductAnswer) Reset() {
+	*x = ProductAnswer{}
+	mi := &file_catalog_catalog_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAnswer) ProtoMessage() {}
+
+func (x *ProductAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAnswer.ProtoReflect.Descriptor instead.
+func (*ProductAnswer) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *ProductAnswer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductAnswer) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *ProductAnswer) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProductAnswer) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *ProductAnswer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProductAnswer) GetModeratedBy() string {
+	if x != nil {
+		return x.ModeratedBy
+	}
+	return ""
+}
+
+func (x *ProductAnswer) GetModeratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModeratedAt
+	}
+	return nil
+}
+
+func (x *ProductAnswer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ProductQuestion is a customer's question about a product
+type ProductQuestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // PENDING, APPROVED or REJECTED
+	ModeratedBy   string                 `protobuf:"bytes,6,opt,name=moderated_by,json=moderatedBy,proto3" json:"moderated_by,omitempty"`
+	ModeratedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=moderated_at,json=moderatedAt,proto3" json:"moderated_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Answers       []*ProductAnswer       `protobuf:"bytes,9,rep,name=answers,proto3" json:"answers,omitempty"` // oldest first; set by ListQuestions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductQuestion) Reset() {
+	*x = ProductQuestion{}
+	mi := &file_catalog_catalog_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductQuestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductQuestion) ProtoMessage() {}
+
+func (x *ProductQuestion) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductQuestion.ProtoReflect.Descriptor instead.
+func (*ProductQuestion) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *ProductQuestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductQuestion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductQuestion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProductQuestion) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *ProductQuestion) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProductQuestion) GetModeratedBy() string {
+	if x != nil {
+		return x.ModeratedBy
+	}
+	return ""
+}
+
+func (x *ProductQuestion) GetModeratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModeratedAt
+	}
+	return nil
+}
+
+func (x *ProductQuestion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProductQuestion) GetAnswers() []*ProductAnswer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+// AskQuestion submits a question about a product. It is PENDING, and not
+// listed, until a moderator approves it.
+type AskQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"` // at most 1000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AskQuestionRequest) Reset() {
+	*x = AskQuestionRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AskQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AskQuestionRequest) ProtoMessage() {}
+
+func (x *AskQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AskQuestionRequest.ProtoReflect.Descriptor instead.
+func (*AskQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *AskQuestionRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AskQuestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AskQuestionRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type AskQuestionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Question      *ProductQuestion       `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AskQuestionResponse) Reset() {
+	*x = AskQuestionResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AskQuestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AskQuestionResponse) ProtoMessage() {}
+
+func (x *AskQuestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AskQuestionResponse.ProtoReflect.Descriptor instead.
+func (*AskQuestionResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *AskQuestionResponse) GetQuestion() *ProductQuestion {
+	if x != nil {
+		return x.Question
+	}
+	return nil
+}
+
+// AnswerQuestion submits an answer to an approved question. It is PENDING
+// until a moderator approves it.
+type AnswerQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"` // at most 2000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerQuestionRequest) Reset() {
+	*x = AnswerQuestionRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerQuestionRequest) ProtoMessage() {}
+
+func (x *AnswerQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerQuestionRequest.ProtoReflect.Descriptor instead.
+func (*AnswerQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *AnswerQuestionRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *AnswerQuestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AnswerQuestionRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type AnswerQuestionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Answer        *ProductAnswer         `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerQuestionResponse) Reset() {
+	*x = AnswerQuestionResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerQuestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerQuestionResponse) ProtoMessage() {}
+
+func (x *AnswerQuestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerQuestionResponse.ProtoReflect.Descriptor instead.
+func (*AnswerQuestionResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *AnswerQuestionResponse) GetAnswer() *ProductAnswer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+// ModerateQuestion approves or rejects a question
+type ModerateQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // APPROVED or REJECTED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateQuestionRequest) Reset() {
+	*x = ModerateQuestionRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateQuestionRequest) ProtoMessage() {}
+
+func (x *ModerateQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateQuestionRequest.ProtoReflect.Descriptor instead.
+func (*ModerateQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *ModerateQuestionRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *ModerateQuestionRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ModerateQuestionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Question      *ProductQuestion       `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateQuestionResponse) Reset() {
+	*x = ModerateQuestionResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateQuestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateQuestionResponse) ProtoMessage() {}
+
+func (x *ModerateQuestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateQuestionResponse.ProtoReflect.Descriptor instead.
+func (*ModerateQuestionResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *ModerateQuestionResponse) GetQuestion() *ProductQuestion {
+	if x != nil {
+		return x.Question
+	}
+	return nil
+}
+
+// ModerateAnswer approves or rejects an answer
+type ModerateAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerId      string                 `protobuf:"bytes,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // APPROVED or REJECTED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateAnswerRequest) Reset() {
+	*x = ModerateAnswerRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateAnswerRequest) ProtoMessage() {}
+
+func (x *ModerateAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateAnswerRequest.ProtoReflect.Descriptor instead.
+func (*ModerateAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *ModerateAnswerRequest) GetAnswerId() string {
+	if x != nil {
+		return x.AnswerId
+	}
+	return ""
+}
+
+func (x *ModerateAnswerRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ModerateAnswerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Answer        *ProductAnswer         `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateAnswerResponse) Reset() {
+	*x = ModerateAnswerResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateAnswerResponse) ProtoMessage() {}
+
+func (x *ModerateAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateAnswerResponse.ProtoReflect.Descriptor instead.
+func (*ModerateAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *ModerateAnswerResponse) GetAnswer() *ProductAnswer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+// ListQuestions lists a product's questions, newest first
+type ListQuestionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Page      int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize  int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 10, max 100
+	// Empty lists APPROVED questions with their APPROVED answers, for the
+	// product page. Moderation queues set a status and get answers in every status.
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuestionsRequest) Reset() {
+	*x = ListQuestionsRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[148]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuestionsRequest) ProtoMessage() {}
+
+func (x *ListQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[148]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*ListQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{148}
+}
+
+func (x *ListQuestionsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListQuestionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListQuestionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListQuestionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questions     []*ProductQuestion     `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuestionsResponse) Reset() {
+	*x = ListQuestionsResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[149]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuestionsResponse) ProtoMessage() {}
+
+func (x *ListQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[149]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*ListQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{149}
+}
+
+func (x *ListQuestionsResponse) GetQuestions() []*ProductQuestion {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *ListQuestionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListQuestionsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListQuestionsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ApplyInventoryUpdates sets the stock and list price of products by SKU, as
+// reported by an external system of record such as an ERP. The batch is
+// applied in one transaction; an update that cannot be applied is reported
+// in its result and does not hold up the others.
+type InventoryUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                            // current or former SKU
+	SetStock      bool                   `protobuf:"varint,2,opt,name=set_stock,json=setStock,proto3" json:"set_stock,omitempty"` // false leaves the stock unchanged
+	Stock         int32                  `protobuf:"varint,3,opt,name=stock,proto3" json:"stock,omitempty"`                       // on-hand stock to set; at least 0
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`                      // list price to set; 0 leaves the price unchanged
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryUpdate) Reset() {
+	*x = InventoryUpdate{}
+	mi := &file_catalog_catalog_proto_msgTypes[150]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryUpdate) ProtoMessage() {}
+
+func (x *InventoryUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[150]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryUpdate.ProtoReflect.Descriptor instead.
+func (*InventoryUpdate) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{150}
+}
+
+func (x *InventoryUpdate) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *InventoryUpdate) GetSetStock() bool {
+	if x != nil {
+		return x.SetStock
+	}
+	return false
+}
+
+func (x *InventoryUpdate) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *InventoryUpdate) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+// InventoryUpdateResult is the outcome of one InventoryUpdate
+type InventoryUpdateResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`                              // the requested SKU
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // empty when NOT_FOUND
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                        // UPDATED, UNCHANGED, NOT_FOUND or REJECTED
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                      // why the update was REJECTED
+	OldStock      int32                  `protobuf:"varint,5,opt,name=old_stock,json=oldStock,proto3" json:"old_stock,omitempty"`
+	NewStock      int32                  `protobuf:"varint,6,opt,name=new_stock,json=newStock,proto3" json:"new_stock,omitempty"`
+	OldPrice      float64                `protobuf:"fixed64,7,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice      float64                `protobuf:"fixed64,8,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryUpdateResult) Reset() {
+	*x = InventoryUpdateResult{}
+	mi := &file_catalog_catalog_proto_msgTypes[151]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryUpdateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryUpdateResult) ProtoMessage() {}
+
+func (x *InventoryUpdateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[151]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryUpdateResult.ProtoReflect.Descriptor instead.
+func (*InventoryUpdateResult) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{151}
+}
+
+func (x *InventoryUpdateResult) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *InventoryUpdateResult) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InventoryUpdateResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *InventoryUpdateResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InventoryUpdateResult) GetOldStock() int32 {
+	if x != nil {
+		return x.OldStock
+	}
+	return 0
+}
+
+func (x *InventoryUpdateResult) GetNewStock() int32 {
+	if x != nil {
+		return x.NewStock
+	}
+	return 0
+}
+
+func (x *InventoryUpdateResult) GetOldPrice() float64 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *InventoryUpdateResult) GetNewPrice() float64 {
+	if x != nil {
+		return x.NewPrice
+	}
+	return 0
+}
+
+type ApplyInventoryUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updates       []*InventoryUpdate     `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"` // at most 500, each SKU once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyInventoryUpdatesRequest) Reset() {
+	*x = ApplyInventoryUpdatesRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[152]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyInventoryUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyInventoryUpdatesRequest) ProtoMessage() {}
+
+func (x *ApplyInventoryUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[152]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyInventoryUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*ApplyInventoryUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{152}
+}
+
+func (x *ApplyInventoryUpdatesRequest) GetUpdates() []*InventoryUpdate {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type ApplyInventoryUpdatesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Results       []*InventoryUpdateResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyInventoryUpdatesResponse) Reset() {
+	*x = ApplyInventoryUpdatesResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[153]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyInventoryUpdatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyInventoryUpdatesResponse) ProtoMessage() {}
+
+func (x *ApplyInventoryUpdatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[153]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyInventoryUpdatesResponse.ProtoReflect.Descriptor instead.
+func (*ApplyInventoryUpdatesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{153}
+}
+
+func (x *ApplyInventoryUpdatesResponse) GetResults() []*InventoryUpdateResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// BackInStockSubscription asks for one notification when an out-of-stock
+// product is restocked; it is removed once the notification is sent
+type BackInStockSubscription struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackInStockSubscription) Reset() {
+	*x = BackInStockSubscription{}
+	mi := &file_catalog_catalog_proto_msgTypes[154]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackInStockSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackInStockSubscription) ProtoMessage() {}
+
+func (x *BackInStockSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[154]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackInStockSubscription.ProtoReflect.Descriptor instead.
+func (*BackInStockSubscription) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{154}
+}
+
+func (x *BackInStockSubscription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BackInStockSubscription) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BackInStockSubscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BackInStockSubscription) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// SubscribeBackInStock subscribes a customer to an out-of-stock physical
+// product. Subscribing again returns the existing subscription.
+type SubscribeBackInStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeBackInStockRequest) Reset() {
+	*x = SubscribeBackInStockRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[155]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeBackInStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBackInStockRequest) ProtoMessage() {}
+
+func (x *SubscribeBackInStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[155]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBackInStockRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBackInStockRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{155}
+}
+
+func (x *SubscribeBackInStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SubscribeBackInStockRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SubscribeBackInStockResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Subscription  *BackInStockSubscription `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeBackInStockResponse) Reset() {
+	*x = SubscribeBackInStockResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[156]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeBackInStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBackInStockResponse) ProtoMessage() {}
+
+func (x *SubscribeBackInStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[156]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBackInStockResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeBackInStockResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{156}
+}
+
+func (x *SubscribeBackInStockResponse) GetSubscription() *BackInStockSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type UnsubscribeBackInStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeBackInStockRequest) Reset() {
+	*x = UnsubscribeBackInStockRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[157]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeBackInStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeBackInStockRequest) ProtoMessage() {}
+
+func (x *UnsubscribeBackInStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[157]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeBackInStockRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeBackInStockRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{157}
+}
+
+func (x *UnsubscribeBackInStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UnsubscribeBackInStockRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnsubscribeBackInStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeBackInStockResponse) Reset() {
+	*x = UnsubscribeBackInStockResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[158]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeBackInStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeBackInStockResponse) ProtoMessage() {}
+
+func (x *UnsubscribeBackInStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[158]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeBackInStockResponse.ProtoReflect.Descriptor instead.
+func (*UnsubscribeBackInStockResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{158}
+}
+
+// ReorderSuggestion projects how long an active physical product's stock
+// lasts at its recent sales velocity and how much to reorder
+type ReorderSuggestion struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProductId         string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku               string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Stock             int32                  `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	LowStockThreshold int32                  `protobuf:"varint,5,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"` // kept as safety stock
+	UnitsSold         int64                  `protobuf:"varint,6,opt,name=units_sold,json=unitsSold,proto3" json:"units_sold,omitempty"`                           // over the sales window
+	DailyVelocity     float64                `protobuf:"fixed64,7,opt,name=daily_velocity,json=dailyVelocity,proto3" json:"daily_velocity,omitempty"`              // units sold per day
+	DaysOfStock       float64                `protobuf:"fixed64,8,opt,name=days_of_stock,json=daysOfStock,proto3" json:"days_of_stock,omitempty"`                  // stock / daily_velocity; -1 when nothing sold
+	StockoutAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=stockout_at,json=stockoutAt,proto3" json:"stockout_at,omitempty"`                         // unset when nothing sold
+	ReorderBy         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=reorder_by,json=reorderBy,proto3" json:"reorder_by,omitempty"`                           // latest order date for stock to arrive before stockout_at; unset when nothing sold
+	ReorderPoint      int32                  `protobuf:"varint,11,opt,name=reorder_point,json=reorderPoint,proto3" json:"reorder_point,omitempty"`                 // stock at or below which to reorder
+	ReorderQuantity   int32                  `protobuf:"varint,12,opt,name=reorder_quantity,json=reorderQuantity,proto3" json:"reorder_quantity,omitempty"`        // 0 when no reorder is needed
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReorderSuggestion) Reset() {
+	*x = ReorderSuggestion{}
+	mi := &file_catalog_catalog_proto_msgTypes[159]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderSuggestion) ProtoMessage() {}
+
+func (x *ReorderSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[159]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderSuggestion.ProtoReflect.Descriptor instead.
+func (*ReorderSuggestion) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{159}
+}
+
+func (x *ReorderSuggestion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetLowStockThreshold() int32 {
+	if x != nil {
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetUnitsSold() int64 {
+	if x != nil {
+		return x.UnitsSold
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDailyVelocity() float64 {
+	if x != nil {
+		return x.DailyVelocity
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDaysOfStock() float64 {
+	if x != nil {
+		return x.DaysOfStock
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetStockoutAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StockoutAt
+	}
+	return nil
+}
+
+func (x *ReorderSuggestion) GetReorderBy() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReorderBy
+	}
+	return nil
+}
+
+func (x *ReorderSuggestion) GetReorderPoint() int32 {
+	if x != nil {
+		return x.ReorderPoint
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetReorderQuantity() int32 {
+	if x != nil {
+		return x.ReorderQuantity
+	}
+	return 0
+}
+
+// GetReorderReport forecasts the stock of active physical products from their
+// units sold over the last window_days complete days
+type GetReorderReportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	WindowDays    int32                  `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`         // default 28, max 365
+	LeadTimeDays  int32                  `protobuf:"varint,2,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"` // days a reorder takes to arrive; default 7, max 365
+	CoverDays     int32                  `protobuf:"varint,3,opt,name=cover_days,json=coverDays,proto3" json:"cover_days,omitempty"`            // days of sales a reorder should cover; default 30, max 365
+	IncludeAll    bool                   `protobuf:"varint,4,opt,name=include_all,json=includeAll,proto3" json:"include_all,omitempty"`         // also list products that need no reorder
+	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 10, max 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchProductsRequest) Reset() {
-	*x = SearchProductsRequest{}
-	mi := &file_catalog_catalog_proto_msgTypes[11]
+func (x *GetReorderReportRequest) Reset() {
+	*x = GetReorderReportRequest{}
+	mi := &file_catalog_catalog_proto_msgTypes[160]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchProductsRequest) String() string {
+func (x *GetReorderReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchProductsRequest) ProtoMessage() {}
+func (*GetReorderReportRequest) ProtoMessage() {}
 
-func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[11]
+func (x *GetReorderReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[160]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,55 +10117,79 @@ func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
-func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetReorderReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReorderReportRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{160}
 }
 
-func (x *SearchProductsRequest) GetQuery() string {
+func (x *GetReorderReportRequest) GetWindowDays() int32 {
 	if x != nil {
-		return x.Query
+		return x.WindowDays
 	}
-	return ""
+	return 0
 }
 
-func (x *SearchProductsRequest) GetPage() int32 {
+func (x *GetReorderReportRequest) GetLeadTimeDays() int32 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *GetReorderReportRequest) GetCoverDays() int32 {
+	if x != nil {
+		return x.CoverDays
+	}
+	return 0
+}
+
+func (x *GetReorderReportRequest) GetIncludeAll() bool {
+	if x != nil {
+		return x.IncludeAll
+	}
+	return false
+}
+
+func (x *GetReorderReportRequest) GetPage() int32 {
 	if x != nil {
 		return x.Page
 	}
 	return 0
 }
 
-func (x *SearchProductsRequest) GetPageSize() int32 {
+func (x *GetReorderReportRequest) GetPageSize() int32 {
 	if x != nil {
 		return x.PageSize
 	}
 	return 0
 }
 
-type SearchProductsResponse struct {
+type GetReorderReportResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Suggestions   []*ReorderSuggestion   `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"` // fewest days of stock first, products without sales last
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchProductsResponse) Reset() {
-	*x = SearchProductsResponse{}
-	mi := &file_catalog_catalog_proto_msgTypes[12]
+func (x *GetReorderReportResponse) Reset() {
+	*x = GetReorderReportResponse{}
+	mi := &file_catalog_catalog_proto_msgTypes[161]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchProductsResponse) String() string {
+func (x *GetReorderReportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchProductsResponse) ProtoMessage() {}
+func (*GetReorderReportResponse) ProtoMessage() {}
 
-func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_catalog_proto_msgTypes[12]
+func (x *GetReorderReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_catalog_proto_msgTypes[161]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -822,30 +10200,51 @@ func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
-func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_catalog_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetReorderReportResponse.ProtoReflect.Descriptor instead.
+func (*GetReorderReportResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_catalog_proto_rawDescGZIP(), []int{161}
 }
 
-func (x *SearchProductsResponse) GetProducts() []*Product {
+func (x *GetReorderReportResponse) GetSuggestions() []*ReorderSuggestion {
 	if x != nil {
-		return x.Products
+		return x.Suggestions
 	}
 	return nil
 }
 
-func (x *SearchProductsResponse) GetTotal() int32 {
+func (x *GetReorderReportResponse) GetTotal() int32 {
 	if x != nil {
 		return x.Total
 	}
 	return 0
 }
 
+func (x *GetReorderReportResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetReorderReportResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetReorderReportResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
 var File_catalog_catalog_proto protoreflect.FileDescriptor
 
 const file_catalog_catalog_proto_rawDesc = "" +
 	"\n" +
-	"\x15catalog/catalog.proto\x12\acatalog\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb7\x02\n" +
+	"\x15catalog/catalog.proto\x12\acatalog\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdc\t\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -859,7 +10258,67 @@ const file_catalog_catalog_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbe\x01\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12D\n" +
+	"\x12description_blocks\x18\v \x03(\v2\x15.catalog.ContentBlockR\x11descriptionBlocks\x12:\n" +
+	"\rimage_details\x18\f \x03(\v2\x15.catalog.ProductImageR\fimageDetails\x12\x1d\n" +
+	"\n" +
+	"sale_price\x18\r \x01(\x01R\tsalePrice\x12@\n" +
+	"\x0esale_starts_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\fsaleStartsAt\x12<\n" +
+	"\fsale_ends_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"saleEndsAt\x12'\n" +
+	"\x0feffective_price\x18\x10 \x01(\x01R\x0eeffectivePrice\x12\x17\n" +
+	"\aon_sale\x18\x11 \x01(\bR\x06onSale\x12.\n" +
+	"\x13low_stock_threshold\x18\x12 \x01(\x05R\x11lowStockThreshold\x12!\n" +
+	"\fproduct_type\x18\x13 \x01(\tR\vproductType\x12\x1b\n" +
+	"\tweight_kg\x18\x14 \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x15 \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x16 \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x17 \x01(\x01R\bheightCm\x12%\n" +
+	"\x0eshipping_class\x18\x18 \x01(\tR\rshippingClass\x12\x10\n" +
+	"\x03ean\x18\x19 \x01(\tR\x03ean\x12\x10\n" +
+	"\x03upc\x18\x1a \x01(\tR\x03upc\x12\x12\n" +
+	"\x04isbn\x18\x1b \x01(\tR\x04isbn\x12\x16\n" +
+	"\x06locale\x18\x1c \x01(\tR\x06locale\x12%\n" +
+	"\x0eaverage_rating\x18\x1d \x01(\x01R\raverageRating\x12!\n" +
+	"\freview_count\x18\x1e \x01(\x05R\vreviewCount\x12\x16\n" +
+	"\x06status\x18\x1f \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18  \x01(\x03R\aversion\x12\"\n" +
+	"\rmin_order_qty\x18! \x01(\x05R\vminOrderQty\x12\"\n" +
+	"\rmax_order_qty\x18\" \x01(\x05R\vmaxOrderQty\x12#\n" +
+	"\rqty_increment\x18# \x01(\x05R\fqtyIncrement\x12\x1b\n" +
+	"\tvendor_id\x18$ \x01(\tR\bvendorId\"\x98\x03\n" +
+	"\fProductImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x19\n" +
+	"\balt_text\x18\x03 \x01(\tR\aaltText\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x12\x1d\n" +
+	"\n" +
+	"is_primary\x18\x05 \x01(\bR\tisPrimary\x12\x14\n" +
+	"\x05width\x18\x06 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\a \x01(\x05R\x06height\x12+\n" +
+	"\x11validation_status\x18\b \x01(\tR\x10validationStatus\x12+\n" +
+	"\x11validation_errors\x18\t \x03(\tR\x10validationErrors\x12,\n" +
+	"\x12alt_text_generated\x18\n" +
+	" \x01(\bR\x10altTextGenerated\x12!\n" +
+	"\fneeds_review\x18\v \x01(\bR\vneedsReview\x127\n" +
+	"\n" +
+	"renditions\x18\f \x03(\v2\x17.catalog.ImageRenditionR\n" +
+	"renditions\"\x9b\x01\n" +
+	"\x0eImageRendition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03R\tsizeBytes\"\x86\x01\n" +
+	"\fContentBlock\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\x05R\x05level\x12\x14\n" +
+	"\x05items\x18\x04 \x03(\tR\x05items\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x10\n" +
+	"\x03alt\x18\x06 \x01(\tR\x03alt\"\xb4\x06\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
@@ -867,22 +10326,54 @@ const file_catalog_catalog_proto_rawDesc = "" +
 	"\x03sku\x18\x04 \x01(\tR\x03sku\x12\x14\n" +
 	"\x05stock\x18\x05 \x01(\x05R\x05stock\x12\x16\n" +
 	"\x06images\x18\x06 \x03(\tR\x06images\x12\x1a\n" +
-	"\bcategory\x18\a \x01(\tR\bcategory\"C\n" +
+	"\bcategory\x18\a \x01(\tR\bcategory\x12D\n" +
+	"\x12description_blocks\x18\b \x03(\v2\x15.catalog.ContentBlockR\x11descriptionBlocks\x12\x1d\n" +
+	"\n" +
+	"sale_price\x18\t \x01(\x01R\tsalePrice\x12@\n" +
+	"\x0esale_starts_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\fsaleStartsAt\x12<\n" +
+	"\fsale_ends_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"saleEndsAt\x12.\n" +
+	"\x13low_stock_threshold\x18\f \x01(\x05R\x11lowStockThreshold\x12!\n" +
+	"\fproduct_type\x18\r \x01(\tR\vproductType\x12\x1b\n" +
+	"\tweight_kg\x18\x0e \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x0f \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x10 \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x11 \x01(\x01R\bheightCm\x12%\n" +
+	"\x0eshipping_class\x18\x12 \x01(\tR\rshippingClass\x12\x10\n" +
+	"\x03ean\x18\x13 \x01(\tR\x03ean\x12\x10\n" +
+	"\x03upc\x18\x14 \x01(\tR\x03upc\x12\x12\n" +
+	"\x04isbn\x18\x15 \x01(\tR\x04isbn\x12\"\n" +
+	"\rmin_order_qty\x18\x16 \x01(\x05R\vminOrderQty\x12\"\n" +
+	"\rmax_order_qty\x18\x17 \x01(\x05R\vmaxOrderQty\x12#\n" +
+	"\rqty_increment\x18\x18 \x01(\x05R\fqtyIncrement\"C\n" +
 	"\x15CreateProductResponse\x12*\n" +
-	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"#\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"d\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0finclude_related\x18\x02 \x01(\bR\x0eincludeRelated\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"\x84\x01\n" +
 	"\x12GetProductResponse\x12*\n" +
-	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"b\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\x12B\n" +
+	"\x10related_products\x18\x02 \x03(\v2\x17.catalog.RelatedProductR\x0frelatedProducts\"\xb7\x02\n" +
 	"\x13ListProductsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1a\n" +
-	"\bcategory\x18\x03 \x01(\tR\bcategory\"\x8b\x01\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x17\n" +
+	"\asort_by\x18\x05 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"min_rating\x18\x06 \x01(\x01R\tminRating\x12%\n" +
+	"\x0einclude_drafts\x18\a \x01(\bR\rincludeDrafts\x12\x18\n" +
+	"\achannel\x18\b \x01(\tR\achannel\x12%\n" +
+	"\x0ecustomer_group\x18\t \x01(\tR\rcustomerGroup\x12\x1b\n" +
+	"\tvendor_id\x18\n" +
+	" \x01(\tR\bvendorId\"\x8b\x01\n" +
 	"\x14ListProductsResponse\x12,\n" +
 	"\bproducts\x18\x01 \x03(\v2\x10.catalog.ProductR\bproducts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xbc\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xc1\x06\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -890,21 +10381,718 @@ const file_catalog_catalog_proto_rawDesc = "" +
 	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x14\n" +
 	"\x05stock\x18\x05 \x01(\x05R\x05stock\x12\x16\n" +
 	"\x06images\x18\x06 \x03(\tR\x06images\x12\x1a\n" +
-	"\bcategory\x18\a \x01(\tR\bcategory\"C\n" +
+	"\bcategory\x18\a \x01(\tR\bcategory\x12D\n" +
+	"\x12description_blocks\x18\b \x03(\v2\x15.catalog.ContentBlockR\x11descriptionBlocks\x12\x1d\n" +
+	"\n" +
+	"sale_price\x18\t \x01(\x01R\tsalePrice\x12@\n" +
+	"\x0esale_starts_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\fsaleStartsAt\x12<\n" +
+	"\fsale_ends_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"saleEndsAt\x12.\n" +
+	"\x13low_stock_threshold\x18\f \x01(\x05R\x11lowStockThreshold\x12\x1b\n" +
+	"\tweight_kg\x18\r \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x0e \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x0f \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x10 \x01(\x01R\bheightCm\x12%\n" +
+	"\x0eshipping_class\x18\x11 \x01(\tR\rshippingClass\x12\x10\n" +
+	"\x03ean\x18\x12 \x01(\tR\x03ean\x12\x10\n" +
+	"\x03upc\x18\x13 \x01(\tR\x03upc\x12\x12\n" +
+	"\x04isbn\x18\x14 \x01(\tR\x04isbn\x12\x16\n" +
+	"\x06status\x18\x15 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x16 \x01(\x03R\aversion\x12\"\n" +
+	"\rmin_order_qty\x18\x17 \x01(\x05R\vminOrderQty\x12\"\n" +
+	"\rmax_order_qty\x18\x18 \x01(\x05R\vmaxOrderQty\x12#\n" +
+	"\rqty_increment\x18\x19 \x01(\x05R\fqtyIncrement\"C\n" +
 	"\x15UpdateProductResponse\x12*\n" +
 	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"&\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
 	"\x15DeleteProductResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"^\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"N\n" +
+	"\x1aGetProductByBarcodeRequest\x12\x18\n" +
+	"\abarcode\x18\x01 \x01(\tR\abarcode\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"I\n" +
+	"\x1bGetProductByBarcodeResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"\x96\x02\n" +
 	"\x15SearchProductsRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\\\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x17\n" +
+	"\asort_by\x18\x05 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"min_rating\x18\x06 \x01(\x01R\tminRating\x12%\n" +
+	"\x0einclude_drafts\x18\a \x01(\bR\rincludeDrafts\x12\x18\n" +
+	"\achannel\x18\b \x01(\tR\achannel\x12%\n" +
+	"\x0ecustomer_group\x18\t \x01(\tR\rcustomerGroup\"\x92\x01\n" +
 	"\x16SearchProductsResponse\x12,\n" +
 	"\bproducts\x18\x01 \x03(\v2\x10.catalog.ProductR\bproducts\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xe7\x03\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05fuzzy\x18\x03 \x01(\bR\x05fuzzy\x12\x1e\n" +
+	"\n" +
+	"suggestion\x18\x04 \x01(\tR\n" +
+	"suggestion\"}\n" +
+	"\x0eRelatedProduct\x12#\n" +
+	"\rrelation_type\x18\x01 \x01(\tR\frelationType\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12*\n" +
+	"\aproduct\x18\x03 \x01(\v2\x10.catalog.ProductR\aproduct\"\x8f\x01\n" +
+	"\x19SetRelatedProductsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12#\n" +
+	"\rrelation_type\x18\x02 \x01(\tR\frelationType\x12.\n" +
+	"\x13related_product_ids\x18\x03 \x03(\tR\x11relatedProductIds\"`\n" +
+	"\x1aSetRelatedProductsResponse\x12B\n" +
+	"\x10related_products\x18\x01 \x03(\v2\x17.catalog.RelatedProductR\x0frelatedProducts\"_\n" +
+	"\x19GetRelatedProductsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12#\n" +
+	"\rrelation_type\x18\x02 \x01(\tR\frelationType\"`\n" +
+	"\x1aGetRelatedProductsResponse\x12B\n" +
+	"\x10related_products\x18\x01 \x03(\v2\x17.catalog.RelatedProductR\x0frelatedProducts\"d\n" +
+	"\rBookingConfig\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x05R\bcapacity\"\xee\x02\n" +
+	"\aBooking\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x127\n" +
+	"\tstarts_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1c\n" +
+	"\treference\x18\a \x01(\tR\treference\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"_\n" +
+	"\x0fAvailabilityDay\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\x05R\tavailable\"n\n" +
+	"\x17SetBookingConfigRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x05R\bcapacity\"J\n" +
+	"\x18SetBookingConfigResponse\x12.\n" +
+	"\x06config\x18\x01 \x01(\v2\x16.catalog.BookingConfigR\x06config\"\x93\x01\n" +
+	"\x16GetAvailabilityRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"w\n" +
+	"\x17GetAvailabilityResponse\x12.\n" +
+	"\x06config\x18\x01 \x01(\v2\x16.catalog.BookingConfigR\x06config\x12,\n" +
+	"\x04days\x18\x02 \x03(\v2\x18.catalog.AvailabilityDayR\x04days\"\x81\x02\n" +
+	"\x15ReserveBookingRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x127\n" +
+	"\tstarts_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12!\n" +
+	"\fhold_seconds\x18\x06 \x01(\x05R\vholdSeconds\"D\n" +
+	"\x16ReserveBookingResponse\x12*\n" +
+	"\abooking\x18\x01 \x01(\v2\x10.catalog.BookingR\abooking\"6\n" +
+	"\x15ConfirmBookingRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\tR\tbookingId\"D\n" +
+	"\x16ConfirmBookingResponse\x12*\n" +
+	"\abooking\x18\x01 \x01(\v2\x10.catalog.BookingR\abooking\"5\n" +
+	"\x14CancelBookingRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\tR\tbookingId\"C\n" +
+	"\x15CancelBookingResponse\x12*\n" +
+	"\abooking\x18\x01 \x01(\v2\x10.catalog.BookingR\abooking\"\x83\x01\n" +
+	"\x18GetImageUploadURLRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12%\n" +
+	"\x0econtent_length\x18\x03 \x01(\x03R\rcontentLength\"\x9b\x02\n" +
+	"\x19GetImageUploadURLResponse\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12I\n" +
+	"\aheaders\x18\x03 \x03(\v2/.catalog.GetImageUploadURLResponse.HeadersEntryR\aheaders\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"m\n" +
+	"\x12AttachImageRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12\x19\n" +
+	"\balt_text\x18\x03 \x01(\tR\aaltText\"A\n" +
+	"\x13AttachImageResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"R\n" +
+	"\x14ReorderImagesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
+	"\timage_ids\x18\x02 \x03(\tR\bimageIds\"C\n" +
+	"\x15ReorderImagesResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"R\n" +
+	"\x16SetPrimaryImageRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\tR\aimageId\"E\n" +
+	"\x17SetPrimaryImageResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"i\n" +
+	"\x12ReviewImageRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\tR\aimageId\x12\x19\n" +
+	"\balt_text\x18\x03 \x01(\tR\aaltText\"A\n" +
+	"\x13ReviewImageResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"\x8e\x01\n" +
+	"\x19SetImageRenditionsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\tR\aimageId\x127\n" +
+	"\n" +
+	"renditions\x18\x03 \x03(\v2\x17.catalog.ImageRenditionR\n" +
+	"renditions\"H\n" +
+	"\x1aSetImageRenditionsResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"\xf6\x01\n" +
+	"\vPriceChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1b\n" +
+	"\told_price\x18\x03 \x01(\x01R\boldPrice\x12\x1b\n" +
+	"\tnew_price\x18\x04 \x01(\x01R\bnewPrice\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x05 \x01(\tR\tchangedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x12\x10\n" +
+	"\x03seq\x18\a \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04hash\x18\b \x01(\tR\x04hash\"h\n" +
+	"\x16GetPriceHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x90\x01\n" +
+	"\x17GetPriceHistoryResponse\x12.\n" +
+	"\achanges\x18\x01 \x03(\v2\x14.catalog.PriceChangeR\achanges\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"M\n" +
+	"\tPriceTier\x12!\n" +
+	"\fmin_quantity\x18\x01 \x01(\x05R\vminQuantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x02 \x01(\x01R\tunitPrice\"_\n" +
+	"\x14SetPriceTiersRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12(\n" +
+	"\x05tiers\x18\x02 \x03(\v2\x12.catalog.PriceTierR\x05tiers\"A\n" +
+	"\x15SetPriceTiersResponse\x12(\n" +
+	"\x05tiers\x18\x01 \x03(\v2\x12.catalog.PriceTierR\x05tiers\"W\n" +
+	"\x1aGetPriceForQuantityRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x8d\x02\n" +
+	"\x1bGetPriceForQuantityResponse\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\x12\x1f\n" +
+	"\vtotal_price\x18\x04 \x01(\x01R\n" +
+	"totalPrice\x12\x1d\n" +
+	"\n" +
+	"base_price\x18\x05 \x01(\x01R\tbasePrice\x12*\n" +
+	"\x11tier_min_quantity\x18\x06 \x01(\x05R\x0ftierMinQuantity\x12(\n" +
+	"\x05tiers\x18\a \x03(\v2\x12.catalog.PriceTierR\x05tiers\"\x19\n" +
+	"\x17VerifyAuditChainRequest\"\x80\x02\n" +
+	"\x18VerifyAuditChainResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12'\n" +
+	"\x0fentries_checked\x18\x02 \x01(\x03R\x0eentriesChecked\x12'\n" +
+	"\x0fanchors_checked\x18\x03 \x01(\x05R\x0eanchorsChecked\x12\x19\n" +
+	"\bhead_seq\x18\x04 \x01(\x03R\aheadSeq\x12\x1b\n" +
+	"\thead_hash\x18\x05 \x01(\tR\bheadHash\x12*\n" +
+	"\x11first_invalid_seq\x18\x06 \x01(\x03R\x0ffirstInvalidSeq\x12\x18\n" +
+	"\aproblem\x18\a \x01(\tR\aproblem\"R\n" +
+	"\x15IncrementStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"M\n" +
+	"\x16IncrementStockResponse\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05stock\x18\x02 \x01(\x05R\x05stock\"R\n" +
+	"\x15DecrementStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"M\n" +
+	"\x16DecrementStockResponse\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05stock\x18\x02 \x01(\x05R\x05stock\"N\n" +
+	"\x1bListLowStockProductsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x93\x01\n" +
+	"\x1cListLowStockProductsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.catalog.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x89\x02\n" +
+	"\x10StockReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x91\x01\n" +
+	"\x13ReserveStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\x12!\n" +
+	"\fhold_seconds\x18\x04 \x01(\x05R\vholdSeconds\"S\n" +
+	"\x14ReserveStockResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.catalog.StockReservationR\vreservation\"B\n" +
+	"\x19ReleaseReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"Y\n" +
+	"\x1aReleaseReservationResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.catalog.StockReservationR\vreservation\"A\n" +
+	"\x18CommitReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"X\n" +
+	"\x19CommitReservationResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.catalog.StockReservationR\vreservation\"C\n" +
+	"\x1aUncommitReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"Z\n" +
+	"\x1bUncommitReservationResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.catalog.StockReservationR\vreservation\"x\n" +
+	"\x0fBundleComponent\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12*\n" +
+	"\aproduct\x18\x03 \x01(\v2\x10.catalog.ProductR\aproduct\"\xd7\x01\n" +
+	"\x06Bundle\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12)\n" +
+	"\x10discount_percent\x18\x02 \x01(\x01R\x0fdiscountPercent\x128\n" +
+	"\n" +
+	"components\x18\x03 \x03(\v2\x18.catalog.BundleComponentR\n" +
+	"components\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\x04 \x01(\x01R\tlistPrice\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x14\n" +
+	"\x05stock\x18\x06 \x01(\x05R\x05stock\"\x96\x01\n" +
+	"\x10SetBundleRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x128\n" +
+	"\n" +
+	"components\x18\x02 \x03(\v2\x18.catalog.BundleComponentR\n" +
+	"components\x12)\n" +
+	"\x10discount_percent\x18\x03 \x01(\x01R\x0fdiscountPercent\"<\n" +
+	"\x11SetBundleResponse\x12'\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x0f.catalog.BundleR\x06bundle\"1\n" +
+	"\x10GetBundleRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"<\n" +
+	"\x11GetBundleResponse\x12'\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x0f.catalog.BundleR\x06bundle\"\xf6\x01\n" +
+	"\fDigitalAsset\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x03 \x01(\tR\tobjectKey\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03R\tsizeBytes\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd9\x01\n" +
+	"\vEntitlement\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\x129\n" +
+	"\n" +
+	"granted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tgrantedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"\x8a\x01\n" +
+	"\x1fGetDigitalAssetUploadURLRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12%\n" +
+	"\x0econtent_length\x18\x03 \x01(\x03R\rcontentLength\"\xa9\x02\n" +
+	" GetDigitalAssetUploadURLResponse\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12P\n" +
+	"\aheaders\x18\x03 \x03(\v26.catalog.GetDigitalAssetUploadURLResponse.HeadersEntryR\aheaders\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"v\n" +
+	"\x19AttachDigitalAssetRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\"I\n" +
+	"\x1aAttachDigitalAssetResponse\x12+\n" +
+	"\x05asset\x18\x01 \x01(\v2\x15.catalog.DigitalAssetR\x05asset\"9\n" +
+	"\x18ListDigitalAssetsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"J\n" +
+	"\x19ListDigitalAssetsResponse\x12-\n" +
+	"\x06assets\x18\x01 \x03(\v2\x15.catalog.DigitalAssetR\x06assets\"o\n" +
+	"\x17GrantEntitlementRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\"R\n" +
+	"\x18GrantEntitlementResponse\x126\n" +
+	"\ventitlement\x18\x01 \x01(\v2\x14.catalog.EntitlementR\ventitlement\"R\n" +
+	"\x18RevokeEntitlementRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"S\n" +
+	"\x19RevokeEntitlementResponse\x126\n" +
+	"\ventitlement\x18\x01 \x01(\v2\x14.catalog.EntitlementR\ventitlement\"V\n" +
+	"\x1aGenerateDownloadURLRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\"\x98\x01\n" +
+	"\x1bGenerateDownloadURLResponse\x12!\n" +
+	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xbc\x01\n" +
+	"\x12ProductTranslation\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8b\x01\n" +
+	"\x1cSetProductTranslationRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"^\n" +
+	"\x1dSetProductTranslationResponse\x12=\n" +
+	"\vtranslation\x18\x01 \x01(\v2\x1b.catalog.ProductTranslationR\vtranslation\"X\n" +
+	"\x1fDeleteProductTranslationRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"\"\n" +
+	" DeleteProductTranslationResponse\"?\n" +
+	"\x1eListProductTranslationsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"\x89\x01\n" +
+	"\x1fListProductTranslationsResponse\x12?\n" +
+	"\ftranslations\x18\x01 \x03(\v2\x1b.catalog.ProductTranslationR\ftranslations\x12%\n" +
+	"\x0edefault_locale\x18\x02 \x01(\tR\rdefaultLocale\"\xb8\x01\n" +
+	"\x1cUpdateRatingAggregateRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12%\n" +
+	"\x0eaverage_rating\x18\x02 \x01(\x01R\raverageRating\x12!\n" +
+	"\freview_count\x18\x03 \x01(\x05R\vreviewCount\x12/\n" +
+	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"e\n" +
+	"\x1dUpdateRatingAggregateResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\bR\aapplied\"\xbd\x01\n" +
+	"\x14ProductActivityEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"U\n" +
+	"\x1cRecordProductActivityRequest\x125\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.catalog.ProductActivityEventR\x06events\"U\n" +
+	"\x1dRecordProductActivityResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\x05R\brecorded\x12\x18\n" +
+	"\aignored\x18\x02 \x01(\x05R\aignored\"z\n" +
+	"\x16ListBestSellersRequest\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"\x97\x01\n" +
+	"\n" +
+	"BestSeller\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\x12\x1d\n" +
+	"\n" +
+	"units_sold\x18\x02 \x01(\x03R\tunitsSold\x12\x1f\n" +
+	"\vorder_count\x18\x03 \x01(\x03R\n" +
+	"orderCount\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x04 \x01(\x03R\tviewCount\"Q\n" +
+	"\x17ListBestSellersResponse\x126\n" +
+	"\fbest_sellers\x18\x01 \x03(\v2\x13.catalog.BestSellerR\vbestSellers\"\x87\x01\n" +
+	"\x16SuggestProductsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x12%\n" +
+	"\x0ecustomer_group\x18\x04 \x01(\tR\rcustomerGroup\"7\n" +
+	"\x11ProductSuggestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"q\n" +
+	"\x17SuggestProductsResponse\x126\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1a.catalog.ProductSuggestionR\bproducts\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\tR\n" +
+	"categories\"\xb2\x01\n" +
+	"\x11ProductVisibility\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bchannels\x18\x02 \x03(\tR\bchannels\x12'\n" +
+	"\x0fcustomer_groups\x18\x03 \x03(\tR\x0ecustomerGroups\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x81\x01\n" +
+	"\x1bSetProductVisibilityRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bchannels\x18\x02 \x03(\tR\bchannels\x12'\n" +
+	"\x0fcustomer_groups\x18\x03 \x03(\tR\x0ecustomerGroups\"Z\n" +
+	"\x1cSetProductVisibilityResponse\x12:\n" +
+	"\n" +
+	"visibility\x18\x01 \x01(\v2\x1a.catalog.ProductVisibilityR\n" +
+	"visibility\"<\n" +
+	"\x1bGetProductVisibilityRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"Z\n" +
+	"\x1cGetProductVisibilityResponse\x12:\n" +
+	"\n" +
+	"visibility\x18\x01 \x01(\v2\x1a.catalog.ProductVisibilityR\n" +
+	"visibility\"J\n" +
+	"\x10ChangeSKURequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x17\n" +
+	"\anew_sku\x18\x02 \x01(\tR\x06newSku\"b\n" +
+	"\x11ChangeSKUResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\x12!\n" +
+	"\fprevious_sku\x18\x02 \x01(\tR\vpreviousSku\"B\n" +
+	"\x16GetProductBySKURequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"d\n" +
+	"\x17GetProductBySKUResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\x12\x1d\n" +
+	"\n" +
+	"former_sku\x18\x02 \x01(\bR\tformerSku\"F\n" +
+	"\x18GetProductsBySKUsRequest\x12\x12\n" +
+	"\x04skus\x18\x01 \x03(\tR\x04skus\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"g\n" +
+	"\bSKUMatch\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12*\n" +
+	"\aproduct\x18\x02 \x01(\v2\x10.catalog.ProductR\aproduct\x12\x1d\n" +
+	"\n" +
+	"former_sku\x18\x03 \x01(\bR\tformerSku\"e\n" +
+	"\x19GetProductsBySKUsResponse\x12+\n" +
+	"\amatches\x18\x01 \x03(\v2\x11.catalog.SKUMatchR\amatches\x12\x1b\n" +
+	"\tnot_found\x18\x02 \x03(\tR\bnotFound\"@\n" +
+	"\x17GetCategoryStatsRequest\x12%\n" +
+	"\x0einclude_drafts\x18\x01 \x01(\bR\rincludeDrafts\"\xab\x01\n" +
+	"\rCategoryStats\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12#\n" +
+	"\rproduct_count\x18\x02 \x01(\x05R\fproductCount\x12\x1b\n" +
+	"\tmin_price\x18\x03 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x04 \x01(\x01R\bmaxPrice\x12\x1f\n" +
+	"\vtotal_stock\x18\x05 \x01(\x03R\n" +
+	"totalStock\"R\n" +
+	"\x18GetCategoryStatsResponse\x126\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x16.catalog.CategoryStatsR\n" +
+	"categories\"v\n" +
+	"\bSKUAlias\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x02 \x01(\tR\tchangedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"6\n" +
+	"\x15ListSKUAliasesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"E\n" +
+	"\x16ListSKUAliasesResponse\x12+\n" +
+	"\aaliases\x18\x01 \x03(\v2\x11.catalog.SKUAliasR\aaliases\"X\n" +
+	"\x13CloneProductRequest\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"B\n" +
+	"\x14CloneProductResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.catalog.ProductR\aproduct\"X\n" +
+	"\x15StreamProductsRequest\x12?\n" +
+	"\rupdated_since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\"7\n" +
+	"\x14WatchProductsRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\"\xae\x01\n" +
+	"\x12ProductChangeEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x129\n" +
+	"\n" +
+	"changed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x12*\n" +
+	"\aproduct\x18\x04 \x01(\v2\x10.catalog.ProductR\aproduct\"]\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"\xdb\x01\n" +
+	"\x11ProductAuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12.\n" +
+	"\achanges\x18\x05 \x03(\v2\x14.catalog.FieldChangeR\achanges\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"k\n" +
+	"\x19GetProductAuditLogRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x99\x01\n" +
+	"\x1aGetProductAuditLogResponse\x124\n" +
+	"\aentries\x18\x01 \x03(\v2\x1a.catalog.ProductAuditEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xa2\x02\n" +
+	"\rProductAnswer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vquestion_id\x18\x02 \x01(\tR\n" +
+	"questionId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\fmoderated_by\x18\x06 \x01(\tR\vmoderatedBy\x12=\n" +
+	"\fmoderated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vmoderatedAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd4\x02\n" +
+	"\x0fProductQuestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\fmoderated_by\x18\x06 \x01(\tR\vmoderatedBy\x12=\n" +
+	"\fmoderated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vmoderatedAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x120\n" +
+	"\aanswers\x18\t \x03(\v2\x16.catalog.ProductAnswerR\aanswers\"`\n" +
+	"\x12AskQuestionRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"K\n" +
+	"\x13AskQuestionResponse\x124\n" +
+	"\bquestion\x18\x01 \x01(\v2\x18.catalog.ProductQuestionR\bquestion\"e\n" +
+	"\x15AnswerQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"H\n" +
+	"\x16AnswerQuestionResponse\x12.\n" +
+	"\x06answer\x18\x01 \x01(\v2\x16.catalog.ProductAnswerR\x06answer\"R\n" +
+	"\x17ModerateQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"P\n" +
+	"\x18ModerateQuestionResponse\x124\n" +
+	"\bquestion\x18\x01 \x01(\v2\x18.catalog.ProductQuestionR\bquestion\"L\n" +
+	"\x15ModerateAnswerRequest\x12\x1b\n" +
+	"\tanswer_id\x18\x01 \x01(\tR\banswerId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"H\n" +
+	"\x16ModerateAnswerResponse\x12.\n" +
+	"\x06answer\x18\x01 \x01(\v2\x16.catalog.ProductAnswerR\x06answer\"~\n" +
+	"\x14ListQuestionsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\x96\x01\n" +
+	"\x15ListQuestionsResponse\x126\n" +
+	"\tquestions\x18\x01 \x03(\v2\x18.catalog.ProductQuestionR\tquestions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"l\n" +
+	"\x0fInventoryUpdate\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1b\n" +
+	"\tset_stock\x18\x02 \x01(\bR\bsetStock\x12\x14\n" +
+	"\x05stock\x18\x03 \x01(\x05R\x05stock\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\"\xee\x01\n" +
+	"\x15InventoryUpdateResult\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1b\n" +
+	"\told_stock\x18\x05 \x01(\x05R\boldStock\x12\x1b\n" +
+	"\tnew_stock\x18\x06 \x01(\x05R\bnewStock\x12\x1b\n" +
+	"\told_price\x18\a \x01(\x01R\boldPrice\x12\x1b\n" +
+	"\tnew_price\x18\b \x01(\x01R\bnewPrice\"R\n" +
+	"\x1cApplyInventoryUpdatesRequest\x122\n" +
+	"\aupdates\x18\x01 \x03(\v2\x18.catalog.InventoryUpdateR\aupdates\"Y\n" +
+	"\x1dApplyInventoryUpdatesResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.catalog.InventoryUpdateResultR\aresults\"\x9c\x01\n" +
+	"\x17BackInStockSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"U\n" +
+	"\x1bSubscribeBackInStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"d\n" +
+	"\x1cSubscribeBackInStockResponse\x12D\n" +
+	"\fsubscription\x18\x01 \x01(\v2 .catalog.BackInStockSubscriptionR\fsubscription\"W\n" +
+	"\x1dUnsubscribeBackInStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\" \n" +
+	"\x1eUnsubscribeBackInStockResponse\"\xd0\x03\n" +
+	"\x11ReorderSuggestion\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05stock\x18\x04 \x01(\x05R\x05stock\x12.\n" +
+	"\x13low_stock_threshold\x18\x05 \x01(\x05R\x11lowStockThreshold\x12\x1d\n" +
+	"\n" +
+	"units_sold\x18\x06 \x01(\x03R\tunitsSold\x12%\n" +
+	"\x0edaily_velocity\x18\a \x01(\x01R\rdailyVelocity\x12\"\n" +
+	"\rdays_of_stock\x18\b \x01(\x01R\vdaysOfStock\x12;\n" +
+	"\vstockout_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"stockoutAt\x129\n" +
+	"\n" +
+	"reorder_by\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\treorderBy\x12#\n" +
+	"\rreorder_point\x18\v \x01(\x05R\freorderPoint\x12)\n" +
+	"\x10reorder_quantity\x18\f \x01(\x05R\x0freorderQuantity\"\xd1\x01\n" +
+	"\x17GetReorderReportRequest\x12\x1f\n" +
+	"\vwindow_days\x18\x01 \x01(\x05R\n" +
+	"windowDays\x12$\n" +
+	"\x0elead_time_days\x18\x02 \x01(\x05R\fleadTimeDays\x12\x1d\n" +
+	"\n" +
+	"cover_days\x18\x03 \x01(\x05R\tcoverDays\x12\x1f\n" +
+	"\vinclude_all\x18\x04 \x01(\bR\n" +
+	"includeAll\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\xde\x01\n" +
+	"\x18GetReorderReportResponse\x12<\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1a.catalog.ReorderSuggestionR\vsuggestions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12=\n" +
+	"\fgenerated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt2\xdc-\n" +
 	"\x0eCatalogService\x12N\n" +
 	"\rCreateProduct\x12\x1d.catalog.CreateProductRequest\x1a\x1e.catalog.CreateProductResponse\x12E\n" +
 	"\n" +
@@ -912,7 +11100,67 @@ const file_catalog_catalog_proto_rawDesc = "" +
 	"\fListProducts\x12\x1c.catalog.ListProductsRequest\x1a\x1d.catalog.ListProductsResponse\x12N\n" +
 	"\rUpdateProduct\x12\x1d.catalog.UpdateProductRequest\x1a\x1e.catalog.UpdateProductResponse\x12N\n" +
 	"\rDeleteProduct\x12\x1d.catalog.DeleteProductRequest\x1a\x1e.catalog.DeleteProductResponse\x12Q\n" +
-	"\x0eSearchProducts\x12\x1e.catalog.SearchProductsRequest\x1a\x1f.catalog.SearchProductsResponseB7Z5github.com/Ujjwaljain16/E-commerce-Backend/catalog/pbb\x06proto3"
+	"\x0eSearchProducts\x12\x1e.catalog.SearchProductsRequest\x1a\x1f.catalog.SearchProductsResponse\x12`\n" +
+	"\x13GetProductByBarcode\x12#.catalog.GetProductByBarcodeRequest\x1a$.catalog.GetProductByBarcodeResponse\x12]\n" +
+	"\x12SetRelatedProducts\x12\".catalog.SetRelatedProductsRequest\x1a#.catalog.SetRelatedProductsResponse\x12]\n" +
+	"\x12GetRelatedProducts\x12\".catalog.GetRelatedProductsRequest\x1a#.catalog.GetRelatedProductsResponse\x12W\n" +
+	"\x10SetBookingConfig\x12 .catalog.SetBookingConfigRequest\x1a!.catalog.SetBookingConfigResponse\x12T\n" +
+	"\x0fGetAvailability\x12\x1f.catalog.GetAvailabilityRequest\x1a .catalog.GetAvailabilityResponse\x12Q\n" +
+	"\x0eReserveBooking\x12\x1e.catalog.ReserveBookingRequest\x1a\x1f.catalog.ReserveBookingResponse\x12Q\n" +
+	"\x0eConfirmBooking\x12\x1e.catalog.ConfirmBookingRequest\x1a\x1f.catalog.ConfirmBookingResponse\x12N\n" +
+	"\rCancelBooking\x12\x1d.catalog.CancelBookingRequest\x1a\x1e.catalog.CancelBookingResponse\x12Z\n" +
+	"\x11GetImageUploadURL\x12!.catalog.GetImageUploadURLRequest\x1a\".catalog.GetImageUploadURLResponse\x12H\n" +
+	"\vAttachImage\x12\x1b.catalog.AttachImageRequest\x1a\x1c.catalog.AttachImageResponse\x12N\n" +
+	"\rReorderImages\x12\x1d.catalog.ReorderImagesRequest\x1a\x1e.catalog.ReorderImagesResponse\x12T\n" +
+	"\x0fSetPrimaryImage\x12\x1f.catalog.SetPrimaryImageRequest\x1a .catalog.SetPrimaryImageResponse\x12H\n" +
+	"\vReviewImage\x12\x1b.catalog.ReviewImageRequest\x1a\x1c.catalog.ReviewImageResponse\x12]\n" +
+	"\x12SetImageRenditions\x12\".catalog.SetImageRenditionsRequest\x1a#.catalog.SetImageRenditionsResponse\x12T\n" +
+	"\x0fGetPriceHistory\x12\x1f.catalog.GetPriceHistoryRequest\x1a .catalog.GetPriceHistoryResponse\x12N\n" +
+	"\rSetPriceTiers\x12\x1d.catalog.SetPriceTiersRequest\x1a\x1e.catalog.SetPriceTiersResponse\x12`\n" +
+	"\x13GetPriceForQuantity\x12#.catalog.GetPriceForQuantityRequest\x1a$.catalog.GetPriceForQuantityResponse\x12W\n" +
+	"\x10VerifyAuditChain\x12 .catalog.VerifyAuditChainRequest\x1a!.catalog.VerifyAuditChainResponse\x12Q\n" +
+	"\x0eIncrementStock\x12\x1e.catalog.IncrementStockRequest\x1a\x1f.catalog.IncrementStockResponse\x12Q\n" +
+	"\x0eDecrementStock\x12\x1e.catalog.DecrementStockRequest\x1a\x1f.catalog.DecrementStockResponse\x12c\n" +
+	"\x14ListLowStockProducts\x12$.catalog.ListLowStockProductsRequest\x1a%.catalog.ListLowStockProductsResponse\x12K\n" +
+	"\fReserveStock\x12\x1c.catalog.ReserveStockRequest\x1a\x1d.catalog.ReserveStockResponse\x12]\n" +
+	"\x12ReleaseReservation\x12\".catalog.ReleaseReservationRequest\x1a#.catalog.ReleaseReservationResponse\x12Z\n" +
+	"\x11CommitReservation\x12!.catalog.CommitReservationRequest\x1a\".catalog.CommitReservationResponse\x12`\n" +
+	"\x13UncommitReservation\x12#.catalog.UncommitReservationRequest\x1a$.catalog.UncommitReservationResponse\x12B\n" +
+	"\tSetBundle\x12\x19.catalog.SetBundleRequest\x1a\x1a.catalog.SetBundleResponse\x12B\n" +
+	"\tGetBundle\x12\x19.catalog.GetBundleRequest\x1a\x1a.catalog.GetBundleResponse\x12o\n" +
+	"\x18GetDigitalAssetUploadURL\x12(.catalog.GetDigitalAssetUploadURLRequest\x1a).catalog.GetDigitalAssetUploadURLResponse\x12]\n" +
+	"\x12AttachDigitalAsset\x12\".catalog.AttachDigitalAssetRequest\x1a#.catalog.AttachDigitalAssetResponse\x12Z\n" +
+	"\x11ListDigitalAssets\x12!.catalog.ListDigitalAssetsRequest\x1a\".catalog.ListDigitalAssetsResponse\x12W\n" +
+	"\x10GrantEntitlement\x12 .catalog.GrantEntitlementRequest\x1a!.catalog.GrantEntitlementResponse\x12Z\n" +
+	"\x11RevokeEntitlement\x12!.catalog.RevokeEntitlementRequest\x1a\".catalog.RevokeEntitlementResponse\x12`\n" +
+	"\x13GenerateDownloadURL\x12#.catalog.GenerateDownloadURLRequest\x1a$.catalog.GenerateDownloadURLResponse\x12f\n" +
+	"\x15SetProductTranslation\x12%.catalog.SetProductTranslationRequest\x1a&.catalog.SetProductTranslationResponse\x12o\n" +
+	"\x18DeleteProductTranslation\x12(.catalog.DeleteProductTranslationRequest\x1a).catalog.DeleteProductTranslationResponse\x12l\n" +
+	"\x17ListProductTranslations\x12'.catalog.ListProductTranslationsRequest\x1a(.catalog.ListProductTranslationsResponse\x12f\n" +
+	"\x15UpdateRatingAggregate\x12%.catalog.UpdateRatingAggregateRequest\x1a&.catalog.UpdateRatingAggregateResponse\x12B\n" +
+	"\tChangeSKU\x12\x19.catalog.ChangeSKURequest\x1a\x1a.catalog.ChangeSKUResponse\x12T\n" +
+	"\x0fGetProductBySKU\x12\x1f.catalog.GetProductBySKURequest\x1a .catalog.GetProductBySKUResponse\x12Q\n" +
+	"\x0eListSKUAliases\x12\x1e.catalog.ListSKUAliasesRequest\x1a\x1f.catalog.ListSKUAliasesResponse\x12Z\n" +
+	"\x11GetProductsBySKUs\x12!.catalog.GetProductsBySKUsRequest\x1a\".catalog.GetProductsBySKUsResponse\x12W\n" +
+	"\x10GetCategoryStats\x12 .catalog.GetCategoryStatsRequest\x1a!.catalog.GetCategoryStatsResponse\x12f\n" +
+	"\x15RecordProductActivity\x12%.catalog.RecordProductActivityRequest\x1a&.catalog.RecordProductActivityResponse\x12T\n" +
+	"\x0fListBestSellers\x12\x1f.catalog.ListBestSellersRequest\x1a .catalog.ListBestSellersResponse\x12T\n" +
+	"\x0fSuggestProducts\x12\x1f.catalog.SuggestProductsRequest\x1a .catalog.SuggestProductsResponse\x12c\n" +
+	"\x14SetProductVisibility\x12$.catalog.SetProductVisibilityRequest\x1a%.catalog.SetProductVisibilityResponse\x12c\n" +
+	"\x14GetProductVisibility\x12$.catalog.GetProductVisibilityRequest\x1a%.catalog.GetProductVisibilityResponse\x12K\n" +
+	"\fCloneProduct\x12\x1c.catalog.CloneProductRequest\x1a\x1d.catalog.CloneProductResponse\x12D\n" +
+	"\x0eStreamProducts\x12\x1e.catalog.StreamProductsRequest\x1a\x10.catalog.Product0\x01\x12M\n" +
+	"\rWatchProducts\x12\x1d.catalog.WatchProductsRequest\x1a\x1b.catalog.ProductChangeEvent0\x01\x12]\n" +
+	"\x12GetProductAuditLog\x12\".catalog.GetProductAuditLogRequest\x1a#.catalog.GetProductAuditLogResponse\x12H\n" +
+	"\vAskQuestion\x12\x1b.catalog.AskQuestionRequest\x1a\x1c.catalog.AskQuestionResponse\x12Q\n" +
+	"\x0eAnswerQuestion\x12\x1e.catalog.AnswerQuestionRequest\x1a\x1f.catalog.AnswerQuestionResponse\x12W\n" +
+	"\x10ModerateQuestion\x12 .catalog.ModerateQuestionRequest\x1a!.catalog.ModerateQuestionResponse\x12Q\n" +
+	"\x0eModerateAnswer\x12\x1e.catalog.ModerateAnswerRequest\x1a\x1f.catalog.ModerateAnswerResponse\x12N\n" +
+	"\rListQuestions\x12\x1d.catalog.ListQuestionsRequest\x1a\x1e.catalog.ListQuestionsResponse\x12f\n" +
+	"\x15ApplyInventoryUpdates\x12%.catalog.ApplyInventoryUpdatesRequest\x1a&.catalog.ApplyInventoryUpdatesResponse\x12c\n" +
+	"\x14SubscribeBackInStock\x12$.catalog.SubscribeBackInStockRequest\x1a%.catalog.SubscribeBackInStockResponse\x12i\n" +
+	"\x16UnsubscribeBackInStock\x12&.catalog.UnsubscribeBackInStockRequest\x1a'.catalog.UnsubscribeBackInStockResponse\x12W\n" +
+	"\x10GetReorderReport\x12 .catalog.GetReorderReportRequest\x1a!.catalog.GetReorderReportResponseB7Z5github.com/Ujjwaljain16/E-commerce-Backend/catalog/pbb\x06proto3"
 
 var (
 	file_catalog_catalog_proto_rawDescOnce sync.Once
@@ -926,48 +11174,430 @@ func file_catalog_catalog_proto_rawDescGZIP() []byte {
 	return file_catalog_catalog_proto_rawDescData
 }
 
-var file_catalog_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_catalog_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 164)
 var file_catalog_catalog_proto_goTypes = []any{
-	(*Product)(nil),                // 0: catalog.Product
-	(*CreateProductRequest)(nil),   // 1: catalog.CreateProductRequest
-	(*CreateProductResponse)(nil),  // 2: catalog.CreateProductResponse
-	(*GetProductRequest)(nil),      // 3: catalog.GetProductRequest
-	(*GetProductResponse)(nil),     // 4: catalog.GetProductResponse
-	(*ListProductsRequest)(nil),    // 5: catalog.ListProductsRequest
-	(*ListProductsResponse)(nil),   // 6: catalog.ListProductsResponse
-	(*UpdateProductRequest)(nil),   // 7: catalog.UpdateProductRequest
-	(*UpdateProductResponse)(nil),  // 8: catalog.UpdateProductResponse
-	(*DeleteProductRequest)(nil),   // 9: catalog.DeleteProductRequest
-	(*DeleteProductResponse)(nil),  // 10: catalog.DeleteProductResponse
-	(*SearchProductsRequest)(nil),  // 11: catalog.SearchProductsRequest
-	(*SearchProductsResponse)(nil), // 12: catalog.SearchProductsResponse
-	(*timestamppb.Timestamp)(nil),  // 13: google.protobuf.Timestamp
+	(*Product)(nil),                          // 0: catalog.Product
+	(*ProductImage)(nil),                     // 1: catalog.ProductImage
+	(*ImageRendition)(nil),                   // 2: catalog.ImageRendition
+	(*ContentBlock)(nil),                     // 3: catalog.ContentBlock
+	(*CreateProductRequest)(nil),             // 4: catalog.CreateProductRequest
+	(*CreateProductResponse)(nil),            // 5: catalog.CreateProductResponse
+	(*GetProductRequest)(nil),                // 6: catalog.GetProductRequest
+	(*GetProductResponse)(nil),               // 7: catalog.GetProductResponse
+	(*ListProductsRequest)(nil),              // 8: catalog.ListProductsRequest
+	(*ListProductsResponse)(nil),             // 9: catalog.ListProductsResponse
+	(*UpdateProductRequest)(nil),             // 10: catalog.UpdateProductRequest
+	(*UpdateProductResponse)(nil),            // 11: catalog.UpdateProductResponse
+	(*DeleteProductRequest)(nil),             // 12: catalog.DeleteProductRequest
+	(*DeleteProductResponse)(nil),            // 13: catalog.DeleteProductResponse
+	(*GetProductByBarcodeRequest)(nil),       // 14: catalog.GetProductByBarcodeRequest
+	(*GetProductByBarcodeResponse)(nil),      // 15: catalog.GetProductByBarcodeResponse
+	(*SearchProductsRequest)(nil),            // 16: catalog.SearchProductsRequest
+	(*SearchProductsResponse)(nil),           // 17: catalog.SearchProductsResponse
+	(*RelatedProduct)(nil),                   // 18: catalog.RelatedProduct
+	(*SetRelatedProductsRequest)(nil),        // 19: catalog.SetRelatedProductsRequest
+	(*SetRelatedProductsResponse)(nil),       // 20: catalog.SetRelatedProductsResponse
+	(*GetRelatedProductsRequest)(nil),        // 21: catalog.GetRelatedProductsRequest
+	(*GetRelatedProductsResponse)(nil),       // 22: catalog.GetRelatedProductsResponse
+	(*BookingConfig)(nil),                    // 23: catalog.BookingConfig
+	(*Booking)(nil),                          // 24: catalog.Booking
+	(*AvailabilityDay)(nil),                  // 25: catalog.AvailabilityDay
+	(*SetBookingConfigRequest)(nil),          // 26: catalog.SetBookingConfigRequest
+	(*SetBookingConfigResponse)(nil),         // 27: catalog.SetBookingConfigResponse
+	(*GetAvailabilityRequest)(nil),           // 28: catalog.GetAvailabilityRequest
+	(*GetAvailabilityResponse)(nil),          // 29: catalog.GetAvailabilityResponse
+	(*ReserveBookingRequest)(nil),            // 30: catalog.ReserveBookingRequest
+	(*ReserveBookingResponse)(nil),           // 31: catalog.ReserveBookingResponse
+	(*ConfirmBookingRequest)(nil),            // 32: catalog.ConfirmBookingRequest
+	(*ConfirmBookingResponse)(nil),           // 33: catalog.ConfirmBookingResponse
+	(*CancelBookingRequest)(nil),             // 34: catalog.CancelBookingRequest
+	(*CancelBookingResponse)(nil),            // 35: catalog.CancelBookingResponse
+	(*GetImageUploadURLRequest)(nil),         // 36: catalog.GetImageUploadURLRequest
+	(*GetImageUploadURLResponse)(nil),        // 37: catalog.GetImageUploadURLResponse
+	(*AttachImageRequest)(nil),               // 38: catalog.AttachImageRequest
+	(*AttachImageResponse)(nil),              // 39: catalog.AttachImageResponse
+	(*ReorderImagesRequest)(nil),             // 40: catalog.ReorderImagesRequest
+	(*ReorderImagesResponse)(nil),            // 41: catalog.ReorderImagesResponse
+	(*SetPrimaryImageRequest)(nil),           // 42: catalog.SetPrimaryImageRequest
+	(*SetPrimaryImageResponse)(nil),          // 43: catalog.SetPrimaryImageResponse
+	(*ReviewImageRequest)(nil),               // 44: catalog.ReviewImageRequest
+	(*ReviewImageResponse)(nil),              // 45: catalog.ReviewImageResponse
+	(*SetImageRenditionsRequest)(nil),        // 46: catalog.SetImageRenditionsRequest
+	(*SetImageRenditionsResponse)(nil),       // 47: catalog.SetImageRenditionsResponse
+	(*PriceChange)(nil),                      // 48: catalog.PriceChange
+	(*GetPriceHistoryRequest)(nil),           // 49: catalog.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil),          // 50: catalog.GetPriceHistoryResponse
+	(*PriceTier)(nil),                        // 51: catalog.PriceTier
+	(*SetPriceTiersRequest)(nil),             // 52: catalog.SetPriceTiersRequest
+	(*SetPriceTiersResponse)(nil),            // 53: catalog.SetPriceTiersResponse
+	(*GetPriceForQuantityRequest)(nil),       // 54: catalog.GetPriceForQuantityRequest
+	(*GetPriceForQuantityResponse)(nil),      // 55: catalog.GetPriceForQuantityResponse
+	(*VerifyAuditChainRequest)(nil),          // 56: catalog.VerifyAuditChainRequest
+	(*VerifyAuditChainResponse)(nil),         // 57: catalog.VerifyAuditChainResponse
+	(*IncrementStockRequest)(nil),            // 58: catalog.IncrementStockRequest
+	(*IncrementStockResponse)(nil),           // 59: catalog.IncrementStockResponse
+	(*DecrementStockRequest)(nil),            // 60: catalog.DecrementStockRequest
+	(*DecrementStockResponse)(nil),           // 61: catalog.DecrementStockResponse
+	(*ListLowStockProductsRequest)(nil),      // 62: catalog.ListLowStockProductsRequest
+	(*ListLowStockProductsResponse)(nil),     // 63: catalog.ListLowStockProductsResponse
+	(*StockReservation)(nil),                 // 64: catalog.StockReservation
+	(*ReserveStockRequest)(nil),              // 65: catalog.ReserveStockRequest
+	(*ReserveStockResponse)(nil),             // 66: catalog.ReserveStockResponse
+	(*ReleaseReservationRequest)(nil),        // 67: catalog.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),       // 68: catalog.ReleaseReservationResponse
+	(*CommitReservationRequest)(nil),         // 69: catalog.CommitReservationRequest
+	(*CommitReservationResponse)(nil),        // 70: catalog.CommitReservationResponse
+	(*UncommitReservationRequest)(nil),       // 71: catalog.UncommitReservationRequest
+	(*UncommitReservationResponse)(nil),      // 72: catalog.UncommitReservationResponse
+	(*BundleComponent)(nil),                  // 73: catalog.BundleComponent
+	(*Bundle)(nil),                           // 74: catalog.Bundle
+	(*SetBundleRequest)(nil),                 // 75: catalog.SetBundleRequest
+	(*SetBundleResponse)(nil),                // 76: catalog.SetBundleResponse
+	(*GetBundleRequest)(nil),                 // 77: catalog.GetBundleRequest
+	(*GetBundleResponse)(nil),                // 78: catalog.GetBundleResponse
+	(*DigitalAsset)(nil),                     // 79: catalog.DigitalAsset
+	(*Entitlement)(nil),                      // 80: catalog.Entitlement
+	(*GetDigitalAssetUploadURLRequest)(nil),  // 81: catalog.GetDigitalAssetUploadURLRequest
+	(*GetDigitalAssetUploadURLResponse)(nil), // 82: catalog.GetDigitalAssetUploadURLResponse
+	(*AttachDigitalAssetRequest)(nil),        // 83: catalog.AttachDigitalAssetRequest
+	(*AttachDigitalAssetResponse)(nil),       // 84: catalog.AttachDigitalAssetResponse
+	(*ListDigitalAssetsRequest)(nil),         // 85: catalog.ListDigitalAssetsRequest
+	(*ListDigitalAssetsResponse)(nil),        // 86: catalog.ListDigitalAssetsResponse
+	(*GrantEntitlementRequest)(nil),          // 87: catalog.GrantEntitlementRequest
+	(*GrantEntitlementResponse)(nil),         // 88: catalog.GrantEntitlementResponse
+	(*RevokeEntitlementRequest)(nil),         // 89: catalog.RevokeEntitlementRequest
+	(*RevokeEntitlementResponse)(nil),        // 90: catalog.RevokeEntitlementResponse
+	(*GenerateDownloadURLRequest)(nil),       // 91: catalog.GenerateDownloadURLRequest
+	(*GenerateDownloadURLResponse)(nil),      // 92: catalog.GenerateDownloadURLResponse
+	(*ProductTranslation)(nil),               // 93: catalog.ProductTranslation
+	(*SetProductTranslationRequest)(nil),     // 94: catalog.SetProductTranslationRequest
+	(*SetProductTranslationResponse)(nil),    // 95: catalog.SetProductTranslationResponse
+	(*DeleteProductTranslationRequest)(nil),  // 96: catalog.DeleteProductTranslationRequest
+	(*DeleteProductTranslationResponse)(nil), // 97: catalog.DeleteProductTranslationResponse
+	(*ListProductTranslationsRequest)(nil),   // 98: catalog.ListProductTranslationsRequest
+	(*ListProductTranslationsResponse)(nil),  // 99: catalog.ListProductTranslationsResponse
+	(*UpdateRatingAggregateRequest)(nil),     // 100: catalog.UpdateRatingAggregateRequest
+	(*UpdateRatingAggregateResponse)(nil),    // 101: catalog.UpdateRatingAggregateResponse
+	(*ProductActivityEvent)(nil),             // 102: catalog.ProductActivityEvent
+	(*RecordProductActivityRequest)(nil),     // 103: catalog.RecordProductActivityRequest
+	(*RecordProductActivityResponse)(nil),    // 104: catalog.RecordProductActivityResponse
+	(*ListBestSellersRequest)(nil),           // 105: catalog.ListBestSellersRequest
+	(*BestSeller)(nil),                       // 106: catalog.BestSeller
+	(*ListBestSellersResponse)(nil),          // 107: catalog.ListBestSellersResponse
+	(*SuggestProductsRequest)(nil),           // 108: catalog.SuggestProductsRequest
+	(*ProductSuggestion)(nil),                // 109: catalog.ProductSuggestion
+	(*SuggestProductsResponse)(nil),          // 110: catalog.SuggestProductsResponse
+	(*ProductVisibility)(nil),                // 111: catalog.ProductVisibility
+	(*SetProductVisibilityRequest)(nil),      // 112: catalog.SetProductVisibilityRequest
+	(*SetProductVisibilityResponse)(nil),     // 113: catalog.SetProductVisibilityResponse
+	(*GetProductVisibilityRequest)(nil),      // 114: catalog.GetProductVisibilityRequest
+	(*GetProductVisibilityResponse)(nil),     // 115: catalog.GetProductVisibilityResponse
+	(*ChangeSKURequest)(nil),                 // 116: catalog.ChangeSKURequest
+	(*ChangeSKUResponse)(nil),                // 117: catalog.ChangeSKUResponse
+	(*GetProductBySKURequest)(nil),           // 118: catalog.GetProductBySKURequest
+	(*GetProductBySKUResponse)(nil),          // 119: catalog.GetProductBySKUResponse
+	(*GetProductsBySKUsRequest)(nil),         // 120: catalog.GetProductsBySKUsRequest
+	(*SKUMatch)(nil),                         // 121: catalog.SKUMatch
+	(*GetProductsBySKUsResponse)(nil),        // 122: catalog.GetProductsBySKUsResponse
+	(*GetCategoryStatsRequest)(nil),          // 123: catalog.GetCategoryStatsRequest
+	(*CategoryStats)(nil),                    // 124: catalog.CategoryStats
+	(*GetCategoryStatsResponse)(nil),         // 125: catalog.GetCategoryStatsResponse
+	(*SKUAlias)(nil),                         // 126: catalog.SKUAlias
+	(*ListSKUAliasesRequest)(nil),            // 127: catalog.ListSKUAliasesRequest
+	(*ListSKUAliasesResponse)(nil),           // 128: catalog.ListSKUAliasesResponse
+	(*CloneProductRequest)(nil),              // 129: catalog.CloneProductRequest
+	(*CloneProductResponse)(nil),             // 130: catalog.CloneProductResponse
+	(*StreamProductsRequest)(nil),            // 131: catalog.StreamProductsRequest
+	(*WatchProductsRequest)(nil),             // 132: catalog.WatchProductsRequest
+	(*ProductChangeEvent)(nil),               // 133: catalog.ProductChangeEvent
+	(*FieldChange)(nil),                      // 134: catalog.FieldChange
+	(*ProductAuditEntry)(nil),                // 135: catalog.ProductAuditEntry
+	(*GetProductAuditLogRequest)(nil),        // 136: catalog.GetProductAuditLogRequest
+	(*GetProductAuditLogResponse)(nil),       // 137: catalog.GetProductAuditLogResponse
+	(*ProductAnswer)(nil),                    // 138: catalog.ProductAnswer
+	(*ProductQuestion)(nil),                  // 139: catalog.ProductQuestion
+	(*AskQuestionRequest)(nil),               // 140: catalog.AskQuestionRequest
+	(*AskQuestionResponse)(nil),              // 141: catalog.AskQuestionResponse
+	(*AnswerQuestionRequest)(nil),            // 142: catalog.AnswerQuestionRequest
+	(*AnswerQuestionResponse)(nil),           // 143: catalog.AnswerQuestionResponse
+	(*ModerateQuestionRequest)(nil),          // 144: catalog.ModerateQuestionRequest
+	(*ModerateQuestionResponse)(nil),         // 145: catalog.ModerateQuestionResponse
+	(*ModerateAnswerRequest)(nil),            // 146: catalog.ModerateAnswerRequest
+	(*ModerateAnswerResponse)(nil),           // 147: catalog.ModerateAnswerResponse
+	(*ListQuestionsRequest)(nil),             // 148: catalog.ListQuestionsRequest
+	(*ListQuestionsResponse)(nil),            // 149: catalog.ListQuestionsResponse
+	(*InventoryUpdate)(nil),                  // 150: catalog.InventoryUpdate
+	(*InventoryUpdateResult)(nil),            // 151: catalog.InventoryUpdateResult
+	(*ApplyInventoryUpdatesRequest)(nil),     // 152: catalog.ApplyInventoryUpdatesRequest
+	(*ApplyInventoryUpdatesResponse)(nil),    // 153: catalog.ApplyInventoryUpdatesResponse
+	(*BackInStockSubscription)(nil),          // 154: catalog.BackInStockSubscription
+	(*SubscribeBackInStockRequest)(nil),      // 155: catalog.SubscribeBackInStockRequest
+	(*SubscribeBackInStockResponse)(nil),     // 156: catalog.SubscribeBackInStockResponse
+	(*UnsubscribeBackInStockRequest)(nil),    // 157: catalog.UnsubscribeBackInStockRequest
+	(*UnsubscribeBackInStockResponse)(nil),   // 158: catalog.UnsubscribeBackInStockResponse
+	(*ReorderSuggestion)(nil),                // 159: catalog.ReorderSuggestion
+	(*GetReorderReportRequest)(nil),          // 160: catalog.GetReorderReportRequest
+	(*GetReorderReportResponse)(nil),         // 161: catalog.GetReorderReportResponse
+	nil,                                      // 162: catalog.GetImageUploadURLResponse.HeadersEntry
+	nil,                                      // 163: catalog.GetDigitalAssetUploadURLResponse.HeadersEntry
+	(*timestamppb.Timestamp)(nil),            // 164: google.protobuf.Timestamp
 }
 var file_catalog_catalog_proto_depIdxs = []int32{
-	13, // 0: catalog.Product.created_at:type_name -> google.protobuf.Timestamp
-	13, // 1: catalog.Product.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: catalog.CreateProductResponse.product:type_name -> catalog.Product
-	0,  // 3: catalog.GetProductResponse.product:type_name -> catalog.Product
-	0,  // 4: catalog.ListProductsResponse.products:type_name -> catalog.Product
-	0,  // 5: catalog.UpdateProductResponse.product:type_name -> catalog.Product
-	0,  // 6: catalog.SearchProductsResponse.products:type_name -> catalog.Product
-	1,  // 7: catalog.CatalogService.CreateProduct:input_type -> catalog.CreateProductRequest
-	3,  // 8: catalog.CatalogService.GetProduct:input_type -> catalog.GetProductRequest
-	5,  // 9: catalog.CatalogService.ListProducts:input_type -> catalog.ListProductsRequest
-	7,  // 10: catalog.CatalogService.UpdateProduct:input_type -> catalog.UpdateProductRequest
-	9,  // 11: catalog.CatalogService.DeleteProduct:input_type -> catalog.DeleteProductRequest
-	11, // 12: catalog.CatalogService.SearchProducts:input_type -> catalog.SearchProductsRequest
-	2,  // 13: catalog.CatalogService.CreateProduct:output_type -> catalog.CreateProductResponse
-	4,  // 14: catalog.CatalogService.GetProduct:output_type -> catalog.GetProductResponse
-	6,  // 15: catalog.CatalogService.ListProducts:output_type -> catalog.ListProductsResponse
-	8,  // 16: catalog.CatalogService.UpdateProduct:output_type -> catalog.UpdateProductResponse
-	10, // 17: catalog.CatalogService.DeleteProduct:output_type -> catalog.DeleteProductResponse
-	12, // 18: catalog.CatalogService.SearchProducts:output_type -> catalog.SearchProductsResponse
-	13, // [13:19] is the sub-list for method output_type
-	7,  // [7:13] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	164, // 0: catalog.Product.created_at:type_name -> google.protobuf.Timestamp
+	164, // 1: catalog.Product.updated_at:type_name -> google.protobuf.Timestamp
+	3,   // 2: catalog.Product.description_blocks:type_name -> catalog.ContentBlock
+	1,   // 3: catalog.Product.image_details:type_name -> catalog.ProductImage
+	164, // 4: catalog.Product.sale_starts_at:type_name -> google.protobuf.Timestamp
+	164, // 5: catalog.Product.sale_ends_at:type_name -> google.protobuf.Timestamp
+	2,   // 6: catalog.ProductImage.renditions:type_name -> catalog.ImageRendition
+	3,   // 7: catalog.CreateProductRequest.description_blocks:type_name -> catalog.ContentBlock
+	164, // 8: catalog.CreateProductRequest.sale_starts_at:type_name -> google.protobuf.Timestamp
+	164, // 9: catalog.CreateProductRequest.sale_ends_at:type_name -> google.protobuf.Timestamp
+	0,   // 10: catalog.CreateProductResponse.product:type_name -> catalog.Product
+	0,   // 11: catalog.GetProductResponse.product:type_name -> catalog.Product
+	18,  // 12: catalog.GetProductResponse.related_products:type_name -> catalog.RelatedProduct
+	0,   // 13: catalog.ListProductsResponse.products:type_name -> catalog.Product
+	3,   // 14: catalog.UpdateProductRequest.description_blocks:type_name -> catalog.ContentBlock
+	164, // 15: catalog.UpdateProductRequest.sale_starts_at:type_name -> google.protobuf.Timestamp
+	164, // 16: catalog.UpdateProductRequest.sale_ends_at:type_name -> google.protobuf.Timestamp
+	0,   // 17: catalog.UpdateProductResponse.product:type_name -> catalog.Product
+	0,   // 18: catalog.GetProductByBarcodeResponse.product:type_name -> catalog.Product
+	0,   // 19: catalog.SearchProductsResponse.products:type_name -> catalog.Product
+	0,   // 20: catalog.RelatedProduct.product:type_name -> catalog.Product
+	18,  // 21: catalog.SetRelatedProductsResponse.related_products:type_name -> catalog.RelatedProduct
+	18,  // 22: catalog.GetRelatedProductsResponse.related_products:type_name -> catalog.RelatedProduct
+	164, // 23: catalog.Booking.starts_at:type_name -> google.protobuf.Timestamp
+	164, // 24: catalog.Booking.ends_at:type_name -> google.protobuf.Timestamp
+	164, // 25: catalog.Booking.expires_at:type_name -> google.protobuf.Timestamp
+	164, // 26: catalog.Booking.created_at:type_name -> google.protobuf.Timestamp
+	164, // 27: catalog.AvailabilityDay.date:type_name -> google.protobuf.Timestamp
+	23,  // 28: catalog.SetBookingConfigResponse.config:type_name -> catalog.BookingConfig
+	164, // 29: catalog.GetAvailabilityRequest.from:type_name -> google.protobuf.Timestamp
+	164, // 30: catalog.GetAvailabilityRequest.to:type_name -> google.protobuf.Timestamp
+	23,  // 31: catalog.GetAvailabilityResponse.config:type_name -> catalog.BookingConfig
+	25,  // 32: catalog.GetAvailabilityResponse.days:type_name -> catalog.AvailabilityDay
+	164, // 33: catalog.ReserveBookingRequest.starts_at:type_name -> google.protobuf.Timestamp
+	164, // 34: catalog.ReserveBookingRequest.ends_at:type_name -> google.protobuf.Timestamp
+	24,  // 35: catalog.ReserveBookingResponse.booking:type_name -> catalog.Booking
+	24,  // 36: catalog.ConfirmBookingResponse.booking:type_name -> catalog.Booking
+	24,  // 37: catalog.CancelBookingResponse.booking:type_name -> catalog.Booking
+	162, // 38: catalog.GetImageUploadURLResponse.headers:type_name -> catalog.GetImageUploadURLResponse.HeadersEntry
+	164, // 39: catalog.GetImageUploadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,   // 40: catalog.AttachImageResponse.product:type_name -> catalog.Product
+	0,   // 41: catalog.ReorderImagesResponse.product:type_name -> catalog.Product
+	0,   // 42: catalog.SetPrimaryImageResponse.product:type_name -> catalog.Product
+	0,   // 43: catalog.ReviewImageResponse.product:type_name -> catalog.Product
+	2,   // 44: catalog.SetImageRenditionsRequest.renditions:type_name -> catalog.ImageRendition
+	0,   // 45: catalog.SetImageRenditionsResponse.product:type_name -> catalog.Product
+	164, // 46: catalog.PriceChange.changed_at:type_name -> google.protobuf.Timestamp
+	48,  // 47: catalog.GetPriceHistoryResponse.changes:type_name -> catalog.PriceChange
+	51,  // 48: catalog.SetPriceTiersRequest.tiers:type_name -> catalog.PriceTier
+	51,  // 49: catalog.SetPriceTiersResponse.tiers:type_name -> catalog.PriceTier
+	51,  // 50: catalog.GetPriceForQuantityResponse.tiers:type_name -> catalog.PriceTier
+	0,   // 51: catalog.ListLowStockProductsResponse.products:type_name -> catalog.Product
+	164, // 52: catalog.StockReservation.expires_at:type_name -> google.protobuf.Timestamp
+	164, // 53: catalog.StockReservation.created_at:type_name -> google.protobuf.Timestamp
+	64,  // 54: catalog.ReserveStockResponse.reservation:type_name -> catalog.StockReservation
+	64,  // 55: catalog.ReleaseReservationResponse.reservation:type_name -> catalog.StockReservation
+	64,  // 56: catalog.CommitReservationResponse.reservation:type_name -> catalog.StockReservation
+	64,  // 57: catalog.UncommitReservationResponse.reservation:type_name -> catalog.StockReservation
+	0,   // 58: catalog.BundleComponent.product:type_name -> catalog.Product
+	73,  // 59: catalog.Bundle.components:type_name -> catalog.BundleComponent
+	73,  // 60: catalog.SetBundleRequest.components:type_name -> catalog.BundleComponent
+	74,  // 61: catalog.SetBundleResponse.bundle:type_name -> catalog.Bundle
+	74,  // 62: catalog.GetBundleResponse.bundle:type_name -> catalog.Bundle
+	164, // 63: catalog.DigitalAsset.created_at:type_name -> google.protobuf.Timestamp
+	164, // 64: catalog.Entitlement.granted_at:type_name -> google.protobuf.Timestamp
+	164, // 65: catalog.Entitlement.revoked_at:type_name -> google.protobuf.Timestamp
+	163, // 66: catalog.GetDigitalAssetUploadURLResponse.headers:type_name -> catalog.GetDigitalAssetUploadURLResponse.HeadersEntry
+	164, // 67: catalog.GetDigitalAssetUploadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	79,  // 68: catalog.AttachDigitalAssetResponse.asset:type_name -> catalog.DigitalAsset
+	79,  // 69: catalog.ListDigitalAssetsResponse.assets:type_name -> catalog.DigitalAsset
+	80,  // 70: catalog.GrantEntitlementResponse.entitlement:type_name -> catalog.Entitlement
+	80,  // 71: catalog.RevokeEntitlementResponse.entitlement:type_name -> catalog.Entitlement
+	164, // 72: catalog.GenerateDownloadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	164, // 73: catalog.ProductTranslation.updated_at:type_name -> google.protobuf.Timestamp
+	93,  // 74: catalog.SetProductTranslationResponse.translation:type_name -> catalog.ProductTranslation
+	93,  // 75: catalog.ListProductTranslationsResponse.translations:type_name -> catalog.ProductTranslation
+	164, // 76: catalog.UpdateRatingAggregateRequest.as_of:type_name -> google.protobuf.Timestamp
+	0,   // 77: catalog.UpdateRatingAggregateResponse.product:type_name -> catalog.Product
+	164, // 78: catalog.ProductActivityEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	102, // 79: catalog.RecordProductActivityRequest.events:type_name -> catalog.ProductActivityEvent
+	0,   // 80: catalog.BestSeller.product:type_name -> catalog.Product
+	106, // 81: catalog.ListBestSellersResponse.best_sellers:type_name -> catalog.BestSeller
+	109, // 82: catalog.SuggestProductsResponse.products:type_name -> catalog.ProductSuggestion
+	164, // 83: catalog.ProductVisibility.updated_at:type_name -> google.protobuf.Timestamp
+	111, // 84: catalog.SetProductVisibilityResponse.visibility:type_name -> catalog.ProductVisibility
+	111, // 85: catalog.GetProductVisibilityResponse.visibility:type_name -> catalog.ProductVisibility
+	0,   // 86: catalog.ChangeSKUResponse.product:type_name -> catalog.Product
+	0,   // 87: catalog.GetProductBySKUResponse.product:type_name -> catalog.Product
+	0,   // 88: catalog.SKUMatch.product:type_name -> catalog.Product
+	121, // 89: catalog.GetProductsBySKUsResponse.matches:type_name -> catalog.SKUMatch
+	124, // 90: catalog.GetCategoryStatsResponse.categories:type_name -> catalog.CategoryStats
+	164, // 91: catalog.SKUAlias.changed_at:type_name -> google.protobuf.Timestamp
+	126, // 92: catalog.ListSKUAliasesResponse.aliases:type_name -> catalog.SKUAlias
+	0,   // 93: catalog.CloneProductResponse.product:type_name -> catalog.Product
+	164, // 94: catalog.StreamProductsRequest.updated_since:type_name -> google.protobuf.Timestamp
+	164, // 95: catalog.ProductChangeEvent.changed_at:type_name -> google.protobuf.Timestamp
+	0,   // 96: catalog.ProductChangeEvent.product:type_name -> catalog.Product
+	134, // 97: catalog.ProductAuditEntry.changes:type_name -> catalog.FieldChange
+	164, // 98: catalog.ProductAuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	135, // 99: catalog.GetProductAuditLogResponse.entries:type_name -> catalog.ProductAuditEntry
+	164, // 100: catalog.ProductAnswer.moderated_at:type_name -> google.protobuf.Timestamp
+	164, // 101: catalog.ProductAnswer.created_at:type_name -> google.protobuf.Timestamp
+	164, // 102: catalog.ProductQuestion.moderated_at:type_name -> google.protobuf.Timestamp
+	164, // 103: catalog.ProductQuestion.created_at:type_name -> google.protobuf.Timestamp
+	138, // 104: catalog.ProductQuestion.answers:type_name -> catalog.ProductAnswer
+	139, // 105: catalog.AskQuestionResponse.question:type_name -> catalog.ProductQuestion
+	138, // 106: catalog.AnswerQuestionResponse.answer:type_name -> catalog.ProductAnswer
+	139, // 107: catalog.ModerateQuestionResponse.question:type_name -> catalog.ProductQuestion
+	138, // 108: catalog.ModerateAnswerResponse.answer:type_name -> catalog.ProductAnswer
+	139, // 109: catalog.ListQuestionsResponse.questions:type_name -> catalog.ProductQuestion
+	150, // 110: catalog.ApplyInventoryUpdatesRequest.updates:type_name -> catalog.InventoryUpdate
+	151, // 111: catalog.ApplyInventoryUpdatesResponse.results:type_name -> catalog.InventoryUpdateResult
+	164, // 112: catalog.BackInStockSubscription.created_at:type_name -> google.protobuf.Timestamp
+	154, // 113: catalog.SubscribeBackInStockResponse.subscription:type_name -> catalog.BackInStockSubscription
+	164, // 114: catalog.ReorderSuggestion.stockout_at:type_name -> google.protobuf.Timestamp
+	164, // 115: catalog.ReorderSuggestion.reorder_by:type_name -> google.protobuf.Timestamp
+	159, // 116: catalog.GetReorderReportResponse.suggestions:type_name -> catalog.ReorderSuggestion
+	164, // 117: catalog.GetReorderReportResponse.generated_at:type_name -> google.protobuf.Timestamp
+	4,   // 118: catalog.CatalogService.CreateProduct:input_type -> catalog.CreateProductRequest
+	6,   // 119: catalog.CatalogService.GetProduct:input_type -> catalog.GetProductRequest
+	8,   // 120: catalog.CatalogService.ListProducts:input_type -> catalog.ListProductsRequest
+	10,  // 121: catalog.CatalogService.UpdateProduct:input_type -> catalog.UpdateProductRequest
+	12,  // 122: catalog.CatalogService.DeleteProduct:input_type -> catalog.DeleteProductRequest
+	16,  // 123: catalog.CatalogService.SearchProducts:input_type -> catalog.SearchProductsRequest
+	14,  // 124: catalog.CatalogService.GetProductByBarcode:input_type -> catalog.GetProductByBarcodeRequest
+	19,  // 125: catalog.CatalogService.SetRelatedProducts:input_type -> catalog.SetRelatedProductsRequest
+	21,  // 126: catalog.CatalogService.GetRelatedProducts:input_type -> catalog.GetRelatedProductsRequest
+	26,  // 127: catalog.CatalogService.SetBookingConfig:input_type -> catalog.SetBookingConfigRequest
+	28,  // 128: catalog.CatalogService.GetAvailability:input_type -> catalog.GetAvailabilityRequest
+	30,  // 129: catalog.CatalogService.ReserveBooking:input_type -> catalog.ReserveBookingRequest
+	32,  // 130: catalog.CatalogService.ConfirmBooking:input_type -> catalog.ConfirmBookingRequest
+	34,  // 131: catalog.CatalogService.CancelBooking:input_type -> catalog.CancelBookingRequest
+	36,  // 132: catalog.CatalogService.GetImageUploadURL:input_type -> catalog.GetImageUploadURLRequest
+	38,  // 133: catalog.CatalogService.AttachImage:input_type -> catalog.AttachImageRequest
+	40,  // 134: catalog.CatalogService.ReorderImages:input_type -> catalog.ReorderImagesRequest
+	42,  // 135: catalog.CatalogService.SetPrimaryImage:input_type -> catalog.SetPrimaryImageRequest
+	44,  // 136: catalog.CatalogService.ReviewImage:input_type -> catalog.ReviewImageRequest
+	46,  // 137: catalog.CatalogService.SetImageRenditions:input_type -> catalog.SetImageRenditionsRequest
+	49,  // 138: catalog.CatalogService.GetPriceHistory:input_type -> catalog.GetPriceHistoryRequest
+	52,  // 139: catalog.CatalogService.SetPriceTiers:input_type -> catalog.SetPriceTiersRequest
+	54,  // 140: catalog.CatalogService.GetPriceForQuantity:input_type -> catalog.GetPriceForQuantityRequest
+	56,  // 141: catalog.CatalogService.VerifyAuditChain:input_type -> catalog.VerifyAuditChainRequest
+	58,  // 142: catalog.CatalogService.IncrementStock:input_type -> catalog.IncrementStockRequest
+	60,  // 143: catalog.CatalogService.DecrementStock:input_type -> catalog.DecrementStockRequest
+	62,  // 144: catalog.CatalogService.ListLowStockProducts:input_type -> catalog.ListLowStockProductsRequest
+	65,  // 145: catalog.CatalogService.ReserveStock:input_type -> catalog.ReserveStockRequest
+	67,  // 146: catalog.CatalogService.ReleaseReservation:input_type -> catalog.ReleaseReservationRequest
+	69,  // 147: catalog.CatalogService.CommitReservation:input_type -> catalog.CommitReservationRequest
+	71,  // 148: catalog.CatalogService.UncommitReservation:input_type -> catalog.UncommitReservationRequest
+	75,  // 149: catalog.CatalogService.SetBundle:input_type -> catalog.SetBundleRequest
+	77,  // 150: catalog.CatalogService.GetBundle:input_type -> catalog.GetBundleRequest
+	81,  // 151: catalog.CatalogService.GetDigitalAssetUploadURL:input_type -> catalog.GetDigitalAssetUploadURLRequest
+	83,  // 152: catalog.CatalogService.AttachDigitalAsset:input_type -> catalog.AttachDigitalAssetRequest
+	85,  // 153: catalog.CatalogService.ListDigitalAssets:input_type -> catalog.ListDigitalAssetsRequest
+	87,  // 154: catalog.CatalogService.GrantEntitlement:input_type -> catalog.GrantEntitlementRequest
+	89,  // 155: catalog.CatalogService.RevokeEntitlement:input_type -> catalog.RevokeEntitlementRequest
+	91,  // 156: catalog.CatalogService.GenerateDownloadURL:input_type -> catalog.GenerateDownloadURLRequest
+	94,  // 157: catalog.CatalogService.SetProductTranslation:input_type -> catalog.SetProductTranslationRequest
+	96,  // 158: catalog.CatalogService.DeleteProductTranslation:input_type -> catalog.DeleteProductTranslationRequest
+	98,  // 159: catalog.CatalogService.ListProductTranslations:input_type -> catalog.ListProductTranslationsRequest
+	100, // 160: catalog.CatalogService.UpdateRatingAggregate:input_type -> catalog.UpdateRatingAggregateRequest
+	116, // 161: catalog.CatalogService.ChangeSKU:input_type -> catalog.ChangeSKURequest
+	118, // 162: catalog.CatalogService.GetProductBySKU:input_type -> catalog.GetProductBySKURequest
+	127, // 163: catalog.CatalogService.ListSKUAliases:input_type -> catalog.ListSKUAliasesRequest
+	120, // 164: catalog.CatalogService.GetProductsBySKUs:input_type -> catalog.GetProductsBySKUsRequest
+	123, // 165: catalog.CatalogService.GetCategoryStats:input_type -> catalog.GetCategoryStatsRequest
+	103, // 166: catalog.CatalogService.RecordProductActivity:input_type -> catalog.RecordProductActivityRequest
+	105, // 167: catalog.CatalogService.ListBestSellers:input_type -> catalog.ListBestSellersRequest
+	108, // 168: catalog.CatalogService.SuggestProducts:input_type -> catalog.SuggestProductsRequest
+	112, // 169: catalog.CatalogService.SetProductVisibility:input_type -> catalog.SetProductVisibilityRequest
+	114, // 170: catalog.CatalogService.GetProductVisibility:input_type -> catalog.GetProductVisibilityRequest
+	129, // 171: catalog.CatalogService.CloneProduct:input_type -> catalog.CloneProductRequest
+	131, // 172: catalog.CatalogService.StreamProducts:input_type -> catalog.StreamProductsRequest
+	132, // 173: catalog.CatalogService.WatchProducts:input_type -> catalog.WatchProductsRequest
+	136, // 174: catalog.CatalogService.GetProductAuditLog:input_type -> catalog.GetProductAuditLogRequest
+	140, // 175: catalog.CatalogService.AskQuestion:input_type -> catalog.AskQuestionRequest
+	142, // 176: catalog.CatalogService.AnswerQuestion:input_type -> catalog.AnswerQuestionRequest
+	144, // 177: catalog.CatalogService.ModerateQuestion:input_type -> catalog.ModerateQuestionRequest
+	146, // 178: catalog.CatalogService.ModerateAnswer:input_type -> catalog.ModerateAnswerRequest
+	148, // 179: catalog.CatalogService.ListQuestions:input_type -> catalog.ListQuestionsRequest
+	152, // 180: catalog.CatalogService.ApplyInventoryUpdates:input_type -> catalog.ApplyInventoryUpdatesRequest
+	155, // 181: catalog.CatalogService.SubscribeBackInStock:input_type -> catalog.SubscribeBackInStockRequest
+	157, // 182: catalog.CatalogService.UnsubscribeBackInStock:input_type -> catalog.UnsubscribeBackInStockRequest
+	160, // 183: catalog.CatalogService.GetReorderReport:input_type -> catalog.GetReorderReportRequest
+	5,   // 184: catalog.CatalogService.CreateProduct:output_type -> catalog.CreateProductResponse
+	7,   // 185: catalog.CatalogService.GetProduct:output_type -> catalog.GetProductResponse
+	9,   // 186: catalog.CatalogService.ListProducts:output_type -> catalog.ListProductsResponse
+	11,  // 187: catalog.CatalogService.UpdateProduct:output_type -> catalog.UpdateProductResponse
+	13,  // 188: catalog.CatalogService.DeleteProduct:output_type -> catalog.DeleteProductResponse
+	17,  // 189: catalog.CatalogService.SearchProducts:output_type -> catalog.SearchProductsResponse
+	15,  // 190: catalog.CatalogService.GetProductByBarcode:output_type -> catalog.GetProductByBarcodeResponse
+	20,  // 191: catalog.CatalogService.SetRelatedProducts:output_type -> catalog.SetRelatedProductsResponse
+	22,  // 192: catalog.CatalogService.GetRelatedProducts:output_type -> catalog.GetRelatedProductsResponse
+	27,  // 193: catalog.CatalogService.SetBookingConfig:output_type -> catalog.SetBookingConfigResponse
+	29,  // 194: catalog.CatalogService.GetAvailability:output_type -> catalog.GetAvailabilityResponse
+	31,  // 195: catalog.CatalogService.ReserveBooking:output_type -> catalog.ReserveBookingResponse
+	33,  // 196: catalog.CatalogService.ConfirmBooking:output_type -> catalog.ConfirmBookingResponse
+	35,  // 197: catalog.CatalogService.CancelBooking:output_type -> catalog.CancelBookingResponse
+	37,  // 198: catalog.CatalogService.GetImageUploadURL:output_type -> catalog.GetImageUploadURLResponse
+	39,  // 199: catalog.CatalogService.AttachImage:output_type -> catalog.AttachImageResponse
+	41,  // 200: catalog.CatalogService.ReorderImages:output_type -> catalog.ReorderImagesResponse
+	43,  // 201: catalog.CatalogService.SetPrimaryImage:output_type -> catalog.SetPrimaryImageResponse
+	45,  // 202: catalog.CatalogService.ReviewImage:output_type -> catalog.ReviewImageResponse
+	47,  // 203: catalog.CatalogService.SetImageRenditions:output_type -> catalog.SetImageRenditionsResponse
+	50,  // 204: catalog.CatalogService.GetPriceHistory:output_type -> catalog.GetPriceHistoryResponse
+	53,  // 205: catalog.CatalogService.SetPriceTiers:output_type -> catalog.SetPriceTiersResponse
+	55,  // 206: catalog.CatalogService.GetPriceForQuantity:output_type -> catalog.GetPriceForQuantityResponse
+	57,  // 207: catalog.CatalogService.VerifyAuditChain:output_type -> catalog.VerifyAuditChainResponse
+	59,  // 208: catalog.CatalogService.IncrementStock:output_type -> catalog.IncrementStockResponse
+	61,  // 209: catalog.CatalogService.DecrementStock:output_type -> catalog.DecrementStockResponse
+	63,  // 210: catalog.CatalogService.ListLowStockProducts:output_type -> catalog.ListLowStockProductsResponse
+	66,  // 211: catalog.CatalogService.ReserveStock:output_type -> catalog.ReserveStockResponse
+	68,  // 212: catalog.CatalogService.ReleaseReservation:output_type -> catalog.ReleaseReservationResponse
+	70,  // 213: catalog.CatalogService.CommitReservation:output_type -> catalog.CommitReservationResponse
+	72,  // 214: catalog.CatalogService.UncommitReservation:output_type -> catalog.UncommitReservationResponse
+	76,  // 215: catalog.CatalogService.SetBundle:output_type -> catalog.SetBundleResponse
+	78,  // 216: catalog.CatalogService.GetBundle:output_type -> catalog.GetBundleResponse
+	82,  // 217: catalog.CatalogService.GetDigitalAssetUploadURL:output_type -> catalog.GetDigitalAssetUploadURLResponse
+	84,  // 218: catalog.CatalogService.AttachDigitalAsset:output_type -> catalog.AttachDigitalAssetResponse
+	86,  // 219: catalog.CatalogService.ListDigitalAssets:output_type -> catalog.ListDigitalAssetsResponse
+	88,  // 220: catalog.CatalogService.GrantEntitlement:output_type -> catalog.GrantEntitlementResponse
+	90,  // 221: catalog.CatalogService.RevokeEntitlement:output_type -> catalog.RevokeEntitlementResponse
+	92,  // 222: catalog.CatalogService.GenerateDownloadURL:output_type -> catalog.GenerateDownloadURLResponse
+	95,  // 223: catalog.CatalogService.SetProductTranslation:output_type -> catalog.SetProductTranslationResponse
+	97,  // 224: catalog.CatalogService.DeleteProductTranslation:output_type -> catalog.DeleteProductTranslationResponse
+	99,  // 225: catalog.CatalogService.ListProductTranslations:output_type -> catalog.ListProductTranslationsResponse
+	101, // 226: catalog.CatalogService.UpdateRatingAggregate:output_type -> catalog.UpdateRatingAggregateResponse
+	117, // 227: catalog.CatalogService.ChangeSKU:output_type -> catalog.ChangeSKUResponse
+	119, // 228: catalog.CatalogService.GetProductBySKU:output_type -> catalog.GetProductBySKUResponse
+	128, // 229: catalog.CatalogService.ListSKUAliases:output_type -> catalog.ListSKUAliasesResponse
+	122, // 230: catalog.CatalogService.GetProductsBySKUs:output_type -> catalog.GetProductsBySKUsResponse
+	125, // 231: catalog.CatalogService.GetCategoryStats:output_type -> catalog.GetCategoryStatsResponse
+	104, // 232: catalog.CatalogService.RecordProductActivity:output_type -> catalog.RecordProductActivityResponse
+	107, // 233: catalog.CatalogService.ListBestSellers:output_type -> catalog.ListBestSellersResponse
+	110, // 234: catalog.CatalogService.SuggestProducts:output_type -> catalog.SuggestProductsResponse
+	113, // 235: catalog.CatalogService.SetProductVisibility:output_type -> catalog.SetProductVisibilityResponse
+	115, // 236: catalog.CatalogService.GetProductVisibility:output_type -> catalog.GetProductVisibilityResponse
+	130, // 237: catalog.CatalogService.CloneProduct:output_type -> catalog.CloneProductResponse
+	0,   // 238: catalog.CatalogService.StreamProducts:output_type -> catalog.Product
+	133, // 239: catalog.CatalogService.WatchProducts:output_type -> catalog.ProductChangeEvent
+	137, // 240: catalog.CatalogService.GetProductAuditLog:output_type -> catalog.GetProductAuditLogResponse
+	141, // 241: catalog.CatalogService.AskQuestion:output_type -> catalog.AskQuestionResponse
+	143, // 242: catalog.CatalogService.AnswerQuestion:output_type -> catalog.AnswerQuestionResponse
+	145, // 243: catalog.CatalogService.ModerateQuestion:output_type -> catalog.ModerateQuestionResponse
+	147, // 244: catalog.CatalogService.ModerateAnswer:output_type -> catalog.ModerateAnswerResponse
+	149, // 245: catalog.CatalogService.ListQuestions:output_type -> catalog.ListQuestionsResponse
+	153, // 246: catalog.CatalogService.ApplyInventoryUpdates:output_type -> catalog.ApplyInventoryUpdatesResponse
+	156, // 247: catalog.CatalogService.SubscribeBackInStock:output_type -> catalog.SubscribeBackInStockResponse
+	158, // 248: catalog.CatalogService.UnsubscribeBackInStock:output_type -> catalog.UnsubscribeBackInStockResponse
+	161, // 249: catalog.CatalogService.GetReorderReport:output_type -> catalog.GetReorderReportResponse
+	184, // [184:250] is the sub-list for method output_type
+	118, // [118:184] is the sub-list for method input_type
+	118, // [118:118] is the sub-list for extension type_name
+	118, // [118:118] is the sub-list for extension extendee
+	0,   // [0:118] is the sub-list for field type_name
 }
 
 func init() { file_catalog_catalog_proto_init() }
@@ -981,7 +11611,7 @@ func file_catalog_catalog_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_catalog_proto_rawDesc), len(file_catalog_catalog_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   164,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,72 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CatalogService_CreateProduct_FullMethodName  = "/catalog.CatalogService/CreateProduct"
-	CatalogService_GetProduct_FullMethodName     = "/catalog.CatalogService/GetProduct"
-	CatalogService_ListProducts_FullMethodName   = "/catalog.CatalogService/ListProducts"
-	CatalogService_UpdateProduct_FullMethodName  = "/catalog.CatalogService/UpdateProduct"
-	CatalogService_DeleteProduct_FullMethodName  = "/catalog.CatalogService/DeleteProduct"
-	CatalogService_SearchProducts_FullMethodName = "/catalog.CatalogService/SearchProducts"
+	CatalogService_CreateProduct_FullMethodName            = "/catalog.CatalogService/CreateProduct"
+	CatalogService_GetProduct_FullMethodName               = "/catalog.CatalogService/GetProduct"
+	CatalogService_ListProducts_FullMethodName             = "/catalog.CatalogService/ListProducts"
+	CatalogService_UpdateProduct_FullMethodName            = "/catalog.CatalogService/UpdateProduct"
+	CatalogService_DeleteProduct_FullMethodName            = "/catalog.CatalogService/DeleteProduct"
+	CatalogService_SearchProducts_FullMethodName           = "/catalog.CatalogService/SearchProducts"
+	CatalogService_GetProductByBarcode_FullMethodName      = "/catalog.CatalogService/GetProductByBarcode"
+	CatalogService_SetRelatedProducts_FullMethodName       = "/catalog.CatalogService/SetRelatedProducts"
+	CatalogService_GetRelatedProducts_FullMethodName       = "/catalog.CatalogService/GetRelatedProducts"
+	CatalogService_SetBookingConfig_FullMethodName         = "/catalog.CatalogService/SetBookingConfig"
+	CatalogService_GetAvailability_FullMethodName          = "/catalog.CatalogService/GetAvailability"
+	CatalogService_ReserveBooking_FullMethodName           = "/catalog.CatalogService/ReserveBooking"
+	CatalogService_ConfirmBooking_FullMethodName           = "/catalog.CatalogService/ConfirmBooking"
+	CatalogService_CancelBooking_FullMethodName            = "/catalog.CatalogService/CancelBooking"
+	CatalogService_GetImageUploadURL_FullMethodName        = "/catalog.CatalogService/GetImageUploadURL"
+	CatalogService_AttachImage_FullMethodName              = "/catalog.CatalogService/AttachImage"
+	CatalogService_ReorderImages_FullMethodName            = "/catalog.CatalogService/ReorderImages"
+	CatalogService_SetPrimaryImage_FullMethodName          = "/catalog.CatalogService/SetPrimaryImage"
+	CatalogService_ReviewImage_FullMethodName              = "/catalog.CatalogService/ReviewImage"
+	CatalogService_SetImageRenditions_FullMethodName       = "/catalog.CatalogService/SetImageRenditions"
+	CatalogService_GetPriceHistory_FullMethodName          = "/catalog.CatalogService/GetPriceHistory"
+	CatalogService_SetPriceTiers_FullMethodName            = "/catalog.CatalogService/SetPriceTiers"
+	CatalogService_GetPriceForQuantity_FullMethodName      = "/catalog.CatalogService/GetPriceForQuantity"
+	CatalogService_VerifyAuditChain_FullMethodName         = "/catalog.CatalogService/VerifyAuditChain"
+	CatalogService_IncrementStock_FullMethodName           = "/catalog.CatalogService/IncrementStock"
+	CatalogService_DecrementStock_FullMethodName           = "/catalog.CatalogService/DecrementStock"
+	CatalogService_ListLowStockProducts_FullMethodName     = "/catalog.CatalogService/ListLowStockProducts"
+	CatalogService_ReserveStock_FullMethodName             = "/catalog.CatalogService/ReserveStock"
+	CatalogService_ReleaseReservation_FullMethodName       = "/catalog.CatalogService/ReleaseReservation"
+	CatalogService_CommitReservation_FullMethodName        = "/catalog.CatalogService/CommitReservation"
+	CatalogService_UncommitReservation_FullMethodName      = "/catalog.CatalogService/UncommitReservation"
+	CatalogService_SetBundle_FullMethodName                = "/catalog.CatalogService/SetBundle"
+	CatalogService_GetBundle_FullMethodName                = "/catalog.CatalogService/GetBundle"
+	CatalogService_GetDigitalAssetUploadURL_FullMethodName = "/catalog.CatalogService/GetDigitalAssetUploadURL"
+	CatalogService_AttachDigitalAsset_FullMethodName       = "/catalog.CatalogService/AttachDigitalAsset"
+	CatalogService_ListDigitalAssets_FullMethodName        = "/catalog.CatalogService/ListDigitalAssets"
+	CatalogService_GrantEntitlement_FullMethodName         = "/catalog.CatalogService/GrantEntitlement"
+	CatalogService_RevokeEntitlement_FullMethodName        = "/catalog.CatalogService/RevokeEntitlement"
+	CatalogService_GenerateDownloadURL_FullMethodName      = "/catalog.CatalogService/GenerateDownloadURL"
+	CatalogService_SetProductTranslation_FullMethodName    = "/catalog.CatalogService/SetProductTranslation"
+	CatalogService_DeleteProductTranslation_FullMethodName = "/catalog.CatalogService/DeleteProductTranslation"
+	CatalogService_ListProductTranslations_FullMethodName  = "/catalog.CatalogService/ListProductTranslations"
+	CatalogService_UpdateRatingAggregate_FullMethodName    = "/catalog.CatalogService/UpdateRatingAggregate"
+	CatalogService_ChangeSKU_FullMethodName                = "/catalog.CatalogService/ChangeSKU"
+	CatalogService_GetProductBySKU_FullMethodName          = "/catalog.CatalogService/GetProductBySKU"
+	CatalogService_ListSKUAliases_FullMethodName           = "/catalog.CatalogService/ListSKUAliases"
+	CatalogService_GetProductsBySKUs_FullMethodName        = "/catalog.CatalogService/GetProductsBySKUs"
+	CatalogService_GetCategoryStats_FullMethodName         = "/catalog.CatalogService/GetCategoryStats"
+	CatalogService_RecordProductActivity_FullMethodName    = "/catalog.CatalogService/RecordProductActivity"
+	CatalogService_ListBestSellers_FullMethodName          = "/catalog.CatalogService/ListBestSellers"
+	CatalogService_SuggestProducts_FullMethodName          = "/catalog.CatalogService/SuggestProducts"
+	CatalogService_SetProductVisibility_FullMethodName     = "/catalog.CatalogService/SetProductVisibility"
+	CatalogService_GetProductVisibility_FullMethodName     = "/catalog.CatalogService/GetProductVisibility"
+	CatalogService_CloneProduct_FullMethodName             = "/catalog.CatalogService/CloneProduct"
+	CatalogService_StreamProducts_FullMethodName           = "/catalog.CatalogService/StreamProducts"
+	CatalogService_WatchProducts_FullMethodName            = "/catalog.CatalogService/WatchProducts"
+	CatalogService_GetProductAuditLog_FullMethodName       = "/catalog.CatalogService/GetProductAuditLog"
+	CatalogService_AskQuestion_FullMethodName              = "/catalog.CatalogService/AskQuestion"
+	CatalogService_AnswerQuestion_FullMethodName           = "/catalog.CatalogService/AnswerQuestion"
+	CatalogService_ModerateQuestion_FullMethodName         = "/catalog.CatalogService/ModerateQuestion"
+	CatalogService_ModerateAnswer_FullMethodName           = "/catalog.CatalogService/ModerateAnswer"
+	CatalogService_ListQuestions_FullMethodName            = "/catalog.CatalogService/ListQuestions"
+	CatalogService_ApplyInventoryUpdates_FullMethodName    = "/catalog.CatalogService/ApplyInventoryUpdates"
+	CatalogService_SubscribeBackInStock_FullMethodName     = "/catalog.CatalogService/SubscribeBackInStock"
+	CatalogService_UnsubscribeBackInStock_FullMethodName   = "/catalog.CatalogService/UnsubscribeBackInStock"
+	CatalogService_GetReorderReport_FullMethodName         = "/catalog.CatalogService/GetReorderReport"
 )
 
 // CatalogServiceClient is the client API for CatalogService service.
@@ -37,6 +97,66 @@ type CatalogServiceClient interface {
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*UpdateProductResponse, error)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error)
 	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+	GetProductByBarcode(ctx context.Context, in *GetProductByBarcodeRequest, opts ...grpc.CallOption) (*GetProductByBarcodeResponse, error)
+	SetRelatedProducts(ctx context.Context, in *SetRelatedProductsRequest, opts ...grpc.CallOption) (*SetRelatedProductsResponse, error)
+	GetRelatedProducts(ctx context.Context, in *GetRelatedProductsRequest, opts ...grpc.CallOption) (*GetRelatedProductsResponse, error)
+	SetBookingConfig(ctx context.Context, in *SetBookingConfigRequest, opts ...grpc.CallOption) (*SetBookingConfigResponse, error)
+	GetAvailability(ctx context.Context, in *GetAvailabilityRequest, opts ...grpc.CallOption) (*GetAvailabilityResponse, error)
+	ReserveBooking(ctx context.Context, in *ReserveBookingRequest, opts ...grpc.CallOption) (*ReserveBookingResponse, error)
+	ConfirmBooking(ctx context.Context, in *ConfirmBookingRequest, opts ...grpc.CallOption) (*ConfirmBookingResponse, error)
+	CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error)
+	GetImageUploadURL(ctx context.Context, in *GetImageUploadURLRequest, opts ...grpc.CallOption) (*GetImageUploadURLResponse, error)
+	AttachImage(ctx context.Context, in *AttachImageRequest, opts ...grpc.CallOption) (*AttachImageResponse, error)
+	ReorderImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*ReorderImagesResponse, error)
+	SetPrimaryImage(ctx context.Context, in *SetPrimaryImageRequest, opts ...grpc.CallOption) (*SetPrimaryImageResponse, error)
+	ReviewImage(ctx context.Context, in *ReviewImageRequest, opts ...grpc.CallOption) (*ReviewImageResponse, error)
+	SetImageRenditions(ctx context.Context, in *SetImageRenditionsRequest, opts ...grpc.CallOption) (*SetImageRenditionsResponse, error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	SetPriceTiers(ctx context.Context, in *SetPriceTiersRequest, opts ...grpc.CallOption) (*SetPriceTiersResponse, error)
+	GetPriceForQuantity(ctx context.Context, in *GetPriceForQuantityRequest, opts ...grpc.CallOption) (*GetPriceForQuantityResponse, error)
+	VerifyAuditChain(ctx context.Context, in *VerifyAuditChainRequest, opts ...grpc.CallOption) (*VerifyAuditChainResponse, error)
+	IncrementStock(ctx context.Context, in *IncrementStockRequest, opts ...grpc.CallOption) (*IncrementStockResponse, error)
+	DecrementStock(ctx context.Context, in *DecrementStockRequest, opts ...grpc.CallOption) (*DecrementStockResponse, error)
+	ListLowStockProducts(ctx context.Context, in *ListLowStockProductsRequest, opts ...grpc.CallOption) (*ListLowStockProductsResponse, error)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error)
+	UncommitReservation(ctx context.Context, in *UncommitReservationRequest, opts ...grpc.CallOption) (*UncommitReservationResponse, error)
+	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
+	GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*GetBundleResponse, error)
+	GetDigitalAssetUploadURL(ctx context.Context, in *GetDigitalAssetUploadURLRequest, opts ...grpc.CallOption) (*GetDigitalAssetUploadURLResponse, error)
+	AttachDigitalAsset(ctx context.Context, in *AttachDigitalAssetRequest, opts ...grpc.CallOption) (*AttachDigitalAssetResponse, error)
+	ListDigitalAssets(ctx context.Context, in *ListDigitalAssetsRequest, opts ...grpc.CallOption) (*ListDigitalAssetsResponse, error)
+	GrantEntitlement(ctx context.Context, in *GrantEntitlementRequest, opts ...grpc.CallOption) (*GrantEntitlementResponse, error)
+	RevokeEntitlement(ctx context.Context, in *RevokeEntitlementRequest, opts ...grpc.CallOption) (*RevokeEntitlementResponse, error)
+	GenerateDownloadURL(ctx context.Context, in *GenerateDownloadURLRequest, opts ...grpc.CallOption) (*GenerateDownloadURLResponse, error)
+	SetProductTranslation(ctx context.Context, in *SetProductTranslationRequest, opts ...grpc.CallOption) (*SetProductTranslationResponse, error)
+	DeleteProductTranslation(ctx context.Context, in *DeleteProductTranslationRequest, opts ...grpc.CallOption) (*DeleteProductTranslationResponse, error)
+	ListProductTranslations(ctx context.Context, in *ListProductTranslationsRequest, opts ...grpc.CallOption) (*ListProductTranslationsResponse, error)
+	UpdateRatingAggregate(ctx context.Context, in *UpdateRatingAggregateRequest, opts ...grpc.CallOption) (*UpdateRatingAggregateResponse, error)
+	ChangeSKU(ctx context.Context, in *ChangeSKURequest, opts ...grpc.CallOption) (*ChangeSKUResponse, error)
+	GetProductBySKU(ctx context.Context, in *GetProductBySKURequest, opts ...grpc.CallOption) (*GetProductBySKUResponse, error)
+	ListSKUAliases(ctx context.Context, in *ListSKUAliasesRequest, opts ...grpc.CallOption) (*ListSKUAliasesResponse, error)
+	GetProductsBySKUs(ctx context.Context, in *GetProductsBySKUsRequest, opts ...grpc.CallOption) (*GetProductsBySKUsResponse, error)
+	GetCategoryStats(ctx context.Context, in *GetCategoryStatsRequest, opts ...grpc.CallOption) (*GetCategoryStatsResponse, error)
+	RecordProductActivity(ctx context.Context, in *RecordProductActivityRequest, opts ...grpc.CallOption) (*RecordProductActivityResponse, error)
+	ListBestSellers(ctx context.Context, in *ListBestSellersRequest, opts ...grpc.CallOption) (*ListBestSellersResponse, error)
+	SuggestProducts(ctx context.Context, in *SuggestProductsRequest, opts ...grpc.CallOption) (*SuggestProductsResponse, error)
+	SetProductVisibility(ctx context.Context, in *SetProductVisibilityRequest, opts ...grpc.CallOption) (*SetProductVisibilityResponse, error)
+	GetProductVisibility(ctx context.Context, in *GetProductVisibilityRequest, opts ...grpc.CallOption) (*GetProductVisibilityResponse, error)
+	CloneProduct(ctx context.Context, in *CloneProductRequest, opts ...grpc.CallOption) (*CloneProductResponse, error)
+	StreamProducts(ctx context.Context, in *StreamProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Product], error)
+	WatchProducts(ctx context.Context, in *WatchProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProductChangeEvent], error)
+	GetProductAuditLog(ctx context.Context, in *GetProductAuditLogRequest, opts ...grpc.CallOption) (*GetProductAuditLogResponse, error)
+	AskQuestion(ctx context.Context, in *AskQuestionRequest, opts ...grpc.CallOption) (*AskQuestionResponse, error)
+	AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*AnswerQuestionResponse, error)
+	ModerateQuestion(ctx context.Context, in *ModerateQuestionRequest, opts ...grpc.CallOption) (*ModerateQuestionResponse, error)
+	ModerateAnswer(ctx context.Context, in *ModerateAnswerRequest, opts ...grpc.CallOption) (*ModerateAnswerResponse, error)
+	ListQuestions(ctx context.Context, in *ListQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error)
+	ApplyInventoryUpdates(ctx context.Context, in *ApplyInventoryUpdatesRequest, opts ...grpc.CallOption) (*ApplyInventoryUpdatesResponse, error)
+	SubscribeBackInStock(ctx context.Context, in *SubscribeBackInStockRequest, opts ...grpc.CallOption) (*SubscribeBackInStockResponse, error)
+	UnsubscribeBackInStock(ctx context.Context, in *UnsubscribeBackInStockRequest, opts ...grpc.CallOption) (*UnsubscribeBackInStockResponse, error)
+	GetReorderReport(ctx context.Context, in *GetReorderReportRequest, opts ...grpc.CallOption) (*GetReorderReportResponse, error)
 }
 
 type catalogServiceClient struct {
@@ -107,6 +227,624 @@ func (c *catalogServiceClient) SearchProducts(ctx context.Context, in *SearchPro
 	return out, nil
 }
 
+func (c *catalogServiceClient) GetProductByBarcode(ctx context.Context, in *GetProductByBarcodeRequest, opts ...grpc.CallOption) (*GetProductByBarcodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductByBarcodeResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetProductByBarcode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetRelatedProducts(ctx context.Context, in *SetRelatedProductsRequest, opts ...grpc.CallOption) (*SetRelatedProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRelatedProductsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetRelatedProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetRelatedProducts(ctx context.Context, in *GetRelatedProductsRequest, opts ...grpc.CallOption) (*GetRelatedProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedProductsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetRelatedProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetBookingConfig(ctx context.Context, in *SetBookingConfigRequest, opts ...grpc.CallOption) (*SetBookingConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBookingConfigResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetBookingConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetAvailability(ctx context.Context, in *GetAvailabilityRequest, opts ...grpc.CallOption) (*GetAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAvailabilityResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReserveBooking(ctx context.Context, in *ReserveBookingRequest, opts ...grpc.CallOption) (*ReserveBookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveBookingResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReserveBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ConfirmBooking(ctx context.Context, in *ConfirmBookingRequest, opts ...grpc.CallOption) (*ConfirmBookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmBookingResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ConfirmBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBookingResponse)
+	err := c.cc.Invoke(ctx, CatalogService_CancelBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetImageUploadURL(ctx context.Context, in *GetImageUploadURLRequest, opts ...grpc.CallOption) (*GetImageUploadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImageUploadURLResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetImageUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) AttachImage(ctx context.Context, in *AttachImageRequest, opts ...grpc.CallOption) (*AttachImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachImageResponse)
+	err := c.cc.Invoke(ctx, CatalogService_AttachImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReorderImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*ReorderImagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderImagesResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReorderImages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetPrimaryImage(ctx context.Context, in *SetPrimaryImageRequest, opts ...grpc.CallOption) (*SetPrimaryImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPrimaryImageResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetPrimaryImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReviewImage(ctx context.Context, in *ReviewImageRequest, opts ...grpc.CallOption) (*ReviewImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewImageResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReviewImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetImageRenditions(ctx context.Context, in *SetImageRenditionsRequest, opts ...grpc.CallOption) (*SetImageRenditionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetImageRenditionsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetImageRenditions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetPriceTiers(ctx context.Context, in *SetPriceTiersRequest, opts ...grpc.CallOption) (*SetPriceTiersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPriceTiersResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetPriceTiers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetPriceForQuantity(ctx context.Context, in *GetPriceForQuantityRequest, opts ...grpc.CallOption) (*GetPriceForQuantityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceForQuantityResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetPriceForQuantity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) VerifyAuditChain(ctx context.Context, in *VerifyAuditChainRequest, opts ...grpc.CallOption) (*VerifyAuditChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyAuditChainResponse)
+	err := c.cc.Invoke(ctx, CatalogService_VerifyAuditChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) IncrementStock(ctx context.Context, in *IncrementStockRequest, opts ...grpc.CallOption) (*IncrementStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementStockResponse)
+	err := c.cc.Invoke(ctx, CatalogService_IncrementStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DecrementStock(ctx context.Context, in *DecrementStockRequest, opts ...grpc.CallOption) (*DecrementStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecrementStockResponse)
+	err := c.cc.Invoke(ctx, CatalogService_DecrementStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListLowStockProducts(ctx context.Context, in *ListLowStockProductsRequest, opts ...grpc.CallOption) (*ListLowStockProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLowStockProductsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListLowStockProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveStockResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitReservationResponse)
+	err := c.cc.Invoke(ctx, CatalogService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UncommitReservation(ctx context.Context, in *UncommitReservationRequest, opts ...grpc.CallOption) (*UncommitReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UncommitReservationResponse)
+	err := c.cc.Invoke(ctx, CatalogService_UncommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBundleResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*GetBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBundleResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetDigitalAssetUploadURL(ctx context.Context, in *GetDigitalAssetUploadURLRequest, opts ...grpc.CallOption) (*GetDigitalAssetUploadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDigitalAssetUploadURLResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetDigitalAssetUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) AttachDigitalAsset(ctx context.Context, in *AttachDigitalAssetRequest, opts ...grpc.CallOption) (*AttachDigitalAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachDigitalAssetResponse)
+	err := c.cc.Invoke(ctx, CatalogService_AttachDigitalAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListDigitalAssets(ctx context.Context, in *ListDigitalAssetsRequest, opts ...grpc.CallOption) (*ListDigitalAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDigitalAssetsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListDigitalAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GrantEntitlement(ctx context.Context, in *GrantEntitlementRequest, opts ...grpc.CallOption) (*GrantEntitlementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantEntitlementResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GrantEntitlement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) RevokeEntitlement(ctx context.Context, in *RevokeEntitlementRequest, opts ...grpc.CallOption) (*RevokeEntitlementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeEntitlementResponse)
+	err := c.cc.Invoke(ctx, CatalogService_RevokeEntitlement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GenerateDownloadURL(ctx context.Context, in *GenerateDownloadURLRequest, opts ...grpc.CallOption) (*GenerateDownloadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateDownloadURLResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GenerateDownloadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetProductTranslation(ctx context.Context, in *SetProductTranslationRequest, opts ...grpc.CallOption) (*SetProductTranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetProductTranslationResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetProductTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeleteProductTranslation(ctx context.Context, in *DeleteProductTranslationRequest, opts ...grpc.CallOption) (*DeleteProductTranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProductTranslationResponse)
+	err := c.cc.Invoke(ctx, CatalogService_DeleteProductTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListProductTranslations(ctx context.Context, in *ListProductTranslationsRequest, opts ...grpc.CallOption) (*ListProductTranslationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductTranslationsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListProductTranslations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateRatingAggregate(ctx context.Context, in *UpdateRatingAggregateRequest, opts ...grpc.CallOption) (*UpdateRatingAggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRatingAggregateResponse)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateRatingAggregate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ChangeSKU(ctx context.Context, in *ChangeSKURequest, opts ...grpc.CallOption) (*ChangeSKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeSKUResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ChangeSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetProductBySKU(ctx context.Context, in *GetProductBySKURequest, opts ...grpc.CallOption) (*GetProductBySKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductBySKUResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetProductBySKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListSKUAliases(ctx context.Context, in *ListSKUAliasesRequest, opts ...grpc.CallOption) (*ListSKUAliasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSKUAliasesResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListSKUAliases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetProductsBySKUs(ctx context.Context, in *GetProductsBySKUsRequest, opts ...grpc.CallOption) (*GetProductsBySKUsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductsBySKUsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetProductsBySKUs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetCategoryStats(ctx context.Context, in *GetCategoryStatsRequest, opts ...grpc.CallOption) (*GetCategoryStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryStatsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetCategoryStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) RecordProductActivity(ctx context.Context, in *RecordProductActivityRequest, opts ...grpc.CallOption) (*RecordProductActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordProductActivityResponse)
+	err := c.cc.Invoke(ctx, CatalogService_RecordProductActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListBestSellers(ctx context.Context, in *ListBestSellersRequest, opts ...grpc.CallOption) (*ListBestSellersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBestSellersResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListBestSellers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SuggestProducts(ctx context.Context, in *SuggestProductsRequest, opts ...grpc.CallOption) (*SuggestProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestProductsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SuggestProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetProductVisibility(ctx context.Context, in *SetProductVisibilityRequest, opts ...grpc.CallOption) (*SetProductVisibilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetProductVisibilityResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetProductVisibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetProductVisibility(ctx context.Context, in *GetProductVisibilityRequest, opts ...grpc.CallOption) (*GetProductVisibilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductVisibilityResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetProductVisibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CloneProduct(ctx context.Context, in *CloneProductRequest, opts ...grpc.CallOption) (*CloneProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneProductResponse)
+	err := c.cc.Invoke(ctx, CatalogService_CloneProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) StreamProducts(ctx context.Context, in *StreamProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Product], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[0], CatalogService_StreamProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamProductsRequest, Product]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_StreamProductsClient = grpc.ServerStreamingClient[Product]
+
+func (c *catalogServiceClient) WatchProducts(ctx context.Context, in *WatchProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProductChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[1], CatalogService_WatchProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchProductsRequest, ProductChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_WatchProductsClient = grpc.ServerStreamingClient[ProductChangeEvent]
+
+func (c *catalogServiceClient) GetProductAuditLog(ctx context.Context, in *GetProductAuditLogRequest, opts ...grpc.CallOption) (*GetProductAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductAuditLogResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetProductAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) AskQuestion(ctx context.Context, in *AskQuestionRequest, opts ...grpc.CallOption) (*AskQuestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AskQuestionResponse)
+	err := c.cc.Invoke(ctx, CatalogService_AskQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*AnswerQuestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnswerQuestionResponse)
+	err := c.cc.Invoke(ctx, CatalogService_AnswerQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ModerateQuestion(ctx context.Context, in *ModerateQuestionRequest, opts ...grpc.CallOption) (*ModerateQuestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModerateQuestionResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ModerateQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ModerateAnswer(ctx context.Context, in *ModerateAnswerRequest, opts ...grpc.CallOption) (*ModerateAnswerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModerateAnswerResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ModerateAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListQuestions(ctx context.Context, in *ListQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuestionsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ApplyInventoryUpdates(ctx context.Context, in *ApplyInventoryUpdatesRequest, opts ...grpc.CallOption) (*ApplyInventoryUpdatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyInventoryUpdatesResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ApplyInventoryUpdates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SubscribeBackInStock(ctx context.Context, in *SubscribeBackInStockRequest, opts ...grpc.CallOption) (*SubscribeBackInStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubscribeBackInStockResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SubscribeBackInStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UnsubscribeBackInStock(ctx context.Context, in *UnsubscribeBackInStockRequest, opts ...grpc.CallOption) (*UnsubscribeBackInStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnsubscribeBackInStockResponse)
+	err := c.cc.Invoke(ctx, CatalogService_UnsubscribeBackInStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetReorderReport(ctx context.Context, in *GetReorderReportRequest, opts ...grpc.CallOption) (*GetReorderReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReorderReportResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetReorderReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogServiceServer is the server API for CatalogService service.
 // All implementations must embed UnimplementedCatalogServiceServer
 // for forward compatibility.
@@ -117,159 +855,1465 @@ type CatalogServiceServer interface {
 	UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error)
 	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
 	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+	GetProductByBarcode(context.Context, *GetProductByBarcodeRequest) (*GetProductByBarcodeResponse, error)
+	SetRelatedProducts(context.Context, *SetRelatedProductsRequest) (*SetRelatedProductsResponse, error)
+	GetRelatedProducts(context.Context, *GetRelatedProductsRequest) (*GetRelatedProductsResponse, error)
+	SetBookingConfig(context.Context, *SetBookingConfigRequest) (*SetBookingConfigResponse, error)
+	GetAvailability(context.Context, *GetAvailabilityRequest) (*GetAvailabilityResponse, error)
+	ReserveBooking(context.Context, *ReserveBookingRequest) (*ReserveBookingResponse, error)
+	ConfirmBooking(context.Context, *ConfirmBookingRequest) (*ConfirmBookingResponse, error)
+	CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error)
+	GetImageUploadURL(context.Context, *GetImageUploadURLRequest) (*GetImageUploadURLResponse, error)
+	AttachImage(context.Context, *AttachImageRequest) (*AttachImageResponse, error)
+	ReorderImages(context.Context, *ReorderImagesRequest) (*ReorderImagesResponse, error)
+	SetPrimaryImage(context.Context, *SetPrimaryImageRequest) (*SetPrimaryImageResponse, error)
+	ReviewImage(context.Context, *ReviewImageRequest) (*ReviewImageResponse, error)
+	SetImageRenditions(context.Context, *SetImageRenditionsRequest) (*SetImageRenditionsResponse, error)
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	SetPriceTiers(context.Context, *SetPriceTiersRequest) (*SetPriceTiersResponse, error)
+	GetPriceForQuantity(context.Context, *GetPriceForQuantityRequest) (*GetPriceForQuantityResponse, error)
+	VerifyAuditChain(context.Context, *VerifyAuditChainRequest) (*VerifyAuditChainResponse, error)
+	IncrementStock(context.Context, *IncrementStockRequest) (*IncrementStockResponse, error)
+	DecrementStock(context.Context, *DecrementStockRequest) (*DecrementStockResponse, error)
+	ListLowStockProducts(context.Context, *ListLowStockProductsRequest) (*ListLowStockProductsResponse, error)
+	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error)
+	UncommitReservation(context.Context, *UncommitReservationRequest) (*UncommitReservationResponse, error)
+	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
+	GetBundle(context.Context, *GetBundleRequest) (*GetBundleResponse, error)
+	GetDigitalAssetUploadURL(context.Context, *GetDigitalAssetUploadURLRequest) (*GetDigitalAssetUploadURLResponse, error)
+	AttachDigitalAsset(context.Context, *AttachDigitalAssetRequest) (*AttachDigitalAssetResponse, error)
+	ListDigitalAssets(context.Context, *ListDigitalAssetsRequest) (*ListDigitalAssetsResponse, error)
+	GrantEntitlement(context.Context, *GrantEntitlementRequest) (*GrantEntitlementResponse, error)
+	RevokeEntitlement(context.Context, *RevokeEntitlementRequest) (*RevokeEntitlementResponse, error)
+	GenerateDownloadURL(context.Context, *GenerateDownloadURLRequest) (*GenerateDownloadURLResponse, error)
+	SetProductTranslation(context.Context, *SetProductTranslationRequest) (*SetProductTranslationResponse, error)
+	DeleteProductTranslation(context.Context, *DeleteProductTranslationRequest) (*DeleteProductTranslationResponse, error)
+	ListProductTranslations(context.Context, *ListProductTranslationsRequest) (*ListProductTranslationsResponse, error)
+	UpdateRatingAggregate(context.Context, *UpdateRatingAggregateRequest) (*UpdateRatingAggregateResponse, error)
+	ChangeSKU(context.Context, *ChangeSKURequest) (*ChangeSKUResponse, error)
+	GetProductBySKU(context.Context, *GetProductBySKURequest) (*GetProductBySKUResponse, error)
+	ListSKUAliases(context.Context, *ListSKUAliasesRequest) (*ListSKUAliasesResponse, error)
+	GetProductsBySKUs(context.Context, *GetProductsBySKUsRequest) (*GetProductsBySKUsResponse, error)
+	GetCategoryStats(context.Context, *GetCategoryStatsRequest) (*GetCategoryStatsResponse, error)
+	RecordProductActivity(context.Context, *RecordProductActivityRequest) (*RecordProductActivityResponse, error)
+	ListBestSellers(context.Context, *ListBestSellersRequest) (*ListBestSellersResponse, error)
+	SuggestProducts(context.Context, *SuggestProductsRequest) (*SuggestProductsResponse, error)
+	SetProductVisibility(context.Context, *SetProductVisibilityRequest) (*SetProductVisibilityResponse, error)
+	GetProductVisibility(context.Context, *GetProductVisibilityRequest) (*GetProductVisibilityResponse, error)
+	CloneProduct(context.Context, *CloneProductRequest) (*CloneProductResponse, error)
+	StreamProducts(*StreamProductsRequest, grpc.ServerStreamingServer[Product]) error
+	WatchProducts(*WatchProductsRequest, grpc.ServerStreamingServer[ProductChangeEvent]) error
+	GetProductAuditLog(context.Context, *GetProductAuditLogRequest) (*GetProductAuditLogResponse, error)
+	AskQuestion(context.Context, *AskQuestionRequest) (*AskQuestionResponse, error)
+	AnswerQuestion(context.Context, *AnswerQuestionRequest) (*AnswerQuestionResponse, error)
+	ModerateQuestion(context.Context, *ModerateQuestionRequest) (*ModerateQuestionResponse, error)
+	ModerateAnswer(context.Context, *ModerateAnswerRequest) (*ModerateAnswerResponse, error)
+	ListQuestions(context.Context, *ListQuestionsRequest) (*ListQuestionsResponse, error)
+	ApplyInventoryUpdates(context.Context, *ApplyInventoryUpdatesRequest) (*ApplyInventoryUpdatesResponse, error)
+	SubscribeBackInStock(context.Context, *SubscribeBackInStockRequest) (*SubscribeBackInStockResponse, error)
+	UnsubscribeBackInStock(context.Context, *UnsubscribeBackInStockRequest) (*UnsubscribeBackInStockResponse, error)
+	GetReorderReport(context.Context, *GetReorderReportRequest) (*GetReorderReportResponse, error)
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+// UnimplementedCatalogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogServiceServer struct{}
+
+func (UnimplementedCatalogServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateProduct not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateProduct not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedCatalogServiceServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetProductByBarcode(context.Context, *GetProductByBarcodeRequest) (*GetProductByBarcodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductByBarcode not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetRelatedProducts(context.Context, *SetRelatedProductsRequest) (*SetRelatedProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRelatedProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetRelatedProducts(context.Context, *GetRelatedProductsRequest) (*GetRelatedProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelatedProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetBookingConfig(context.Context, *SetBookingConfigRequest) (*SetBookingConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetBookingConfig not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetAvailability(context.Context, *GetAvailabilityRequest) (*GetAvailabilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAvailability not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReserveBooking(context.Context, *ReserveBookingRequest) (*ReserveBookingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveBooking not implemented")
+}
+func (UnimplementedCatalogServiceServer) ConfirmBooking(context.Context, *ConfirmBookingRequest) (*ConfirmBookingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmBooking not implemented")
+}
+func (UnimplementedCatalogServiceServer) CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelBooking not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetImageUploadURL(context.Context, *GetImageUploadURLRequest) (*GetImageUploadURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetImageUploadURL not implemented")
+}
+func (UnimplementedCatalogServiceServer) AttachImage(context.Context, *AttachImageRequest) (*AttachImageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachImage not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReorderImages(context.Context, *ReorderImagesRequest) (*ReorderImagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReorderImages not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetPrimaryImage(context.Context, *SetPrimaryImageRequest) (*SetPrimaryImageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPrimaryImage not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReviewImage(context.Context, *ReviewImageRequest) (*ReviewImageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReviewImage not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetImageRenditions(context.Context, *SetImageRenditionsRequest) (*SetImageRenditionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetImageRenditions not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetPriceTiers(context.Context, *SetPriceTiersRequest) (*SetPriceTiersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPriceTiers not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetPriceForQuantity(context.Context, *GetPriceForQuantityRequest) (*GetPriceForQuantityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPriceForQuantity not implemented")
+}
+func (UnimplementedCatalogServiceServer) VerifyAuditChain(context.Context, *VerifyAuditChainRequest) (*VerifyAuditChainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyAuditChain not implemented")
+}
+func (UnimplementedCatalogServiceServer) IncrementStock(context.Context, *IncrementStockRequest) (*IncrementStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IncrementStock not implemented")
+}
+func (UnimplementedCatalogServiceServer) DecrementStock(context.Context, *DecrementStockRequest) (*DecrementStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DecrementStock not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListLowStockProducts(context.Context, *ListLowStockProductsRequest) (*ListLowStockProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLowStockProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedCatalogServiceServer) CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedCatalogServiceServer) UncommitReservation(context.Context, *UncommitReservationRequest) (*UncommitReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UncommitReservation not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetBundle not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetBundle(context.Context, *GetBundleRequest) (*GetBundleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBundle not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetDigitalAssetUploadURL(context.Context, *GetDigitalAssetUploadURLRequest) (*GetDigitalAssetUploadURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDigitalAssetUploadURL not implemented")
+}
+func (UnimplementedCatalogServiceServer) AttachDigitalAsset(context.Context, *AttachDigitalAssetRequest) (*AttachDigitalAssetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachDigitalAsset not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListDigitalAssets(context.Context, *ListDigitalAssetsRequest) (*ListDigitalAssetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDigitalAssets not implemented")
+}
+func (UnimplementedCatalogServiceServer) GrantEntitlement(context.Context, *GrantEntitlementRequest) (*GrantEntitlementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GrantEntitlement not implemented")
+}
+func (UnimplementedCatalogServiceServer) RevokeEntitlement(context.Context, *RevokeEntitlementRequest) (*RevokeEntitlementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeEntitlement not implemented")
+}
+func (UnimplementedCatalogServiceServer) GenerateDownloadURL(context.Context, *GenerateDownloadURLRequest) (*GenerateDownloadURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateDownloadURL not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetProductTranslation(context.Context, *SetProductTranslationRequest) (*SetProductTranslationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetProductTranslation not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeleteProductTranslation(context.Context, *DeleteProductTranslationRequest) (*DeleteProductTranslationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteProductTranslation not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListProductTranslations(context.Context, *ListProductTranslationsRequest) (*ListProductTranslationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListProductTranslations not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateRatingAggregate(context.Context, *UpdateRatingAggregateRequest) (*UpdateRatingAggregateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateRatingAggregate not implemented")
+}
+func (UnimplementedCatalogServiceServer) ChangeSKU(context.Context, *ChangeSKURequest) (*ChangeSKUResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ChangeSKU not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetProductBySKU(context.Context, *GetProductBySKURequest) (*GetProductBySKUResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductBySKU not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListSKUAliases(context.Context, *ListSKUAliasesRequest) (*ListSKUAliasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSKUAliases not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetProductsBySKUs(context.Context, *GetProductsBySKUsRequest) (*GetProductsBySKUsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductsBySKUs not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetCategoryStats(context.Context, *GetCategoryStatsRequest) (*GetCategoryStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCategoryStats not implemented")
+}
+func (UnimplementedCatalogServiceServer) RecordProductActivity(context.Context, *RecordProductActivityRequest) (*RecordProductActivityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordProductActivity not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListBestSellers(context.Context, *ListBestSellersRequest) (*ListBestSellersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBestSellers not implemented")
+}
+func (UnimplementedCatalogServiceServer) SuggestProducts(context.Context, *SuggestProductsRequest) (*SuggestProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuggestProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetProductVisibility(context.Context, *SetProductVisibilityRequest) (*SetProductVisibilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetProductVisibility not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetProductVisibility(context.Context, *GetProductVisibilityRequest) (*GetProductVisibilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductVisibility not implemented")
+}
+func (UnimplementedCatalogServiceServer) CloneProduct(context.Context, *CloneProductRequest) (*CloneProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloneProduct not implemented")
+}
+func (UnimplementedCatalogServiceServer) StreamProducts(*StreamProductsRequest, grpc.ServerStreamingServer[Product]) error {
+	return status.Error(codes.Unimplemented, "method StreamProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) WatchProducts(*WatchProductsRequest, grpc.ServerStreamingServer[ProductChangeEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetProductAuditLog(context.Context, *GetProductAuditLogRequest) (*GetProductAuditLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductAuditLog not implemented")
+}
+func (UnimplementedCatalogServiceServer) AskQuestion(context.Context, *AskQuestionRequest) (*AskQuestionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AskQuestion not implemented")
+}
+func (UnimplementedCatalogServiceServer) AnswerQuestion(context.Context, *AnswerQuestionRequest) (*AnswerQuestionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AnswerQuestion not implemented")
+}
+func (UnimplementedCatalogServiceServer) ModerateQuestion(context.Context, *ModerateQuestionRequest) (*ModerateQuestionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ModerateQuestion not implemented")
+}
+func (UnimplementedCatalogServiceServer) ModerateAnswer(context.Context, *ModerateAnswerRequest) (*ModerateAnswerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ModerateAnswer not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListQuestions(context.Context, *ListQuestionsRequest) (*ListQuestionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListQuestions not implemented")
+}
+func (UnimplementedCatalogServiceServer) ApplyInventoryUpdates(context.Context, *ApplyInventoryUpdatesRequest) (*ApplyInventoryUpdatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApplyInventoryUpdates not implemented")
+}
+func (UnimplementedCatalogServiceServer) SubscribeBackInStock(context.Context, *SubscribeBackInStockRequest) (*SubscribeBackInStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubscribeBackInStock not implemented")
+}
+func (UnimplementedCatalogServiceServer) UnsubscribeBackInStock(context.Context, *UnsubscribeBackInStockRequest) (*UnsubscribeBackInStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnsubscribeBackInStock not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetReorderReport(context.Context, *GetReorderReportRequest) (*GetReorderReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReorderReport not implemented")
+}
+func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
+func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServiceServer will
+// result in compilation errors.
+type UnsafeCatalogServiceServer interface {
 	mustEmbedUnimplementedCatalogServiceServer()
 }
 
-// UnimplementedCatalogServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedCatalogServiceServer struct{}
+func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
+	// If the following call panics, it indicates UnimplementedCatalogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatalogService_ServiceDesc, srv)
+}
+
+func _CatalogService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateProduct(ctx, req.(*CreateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SearchProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetProductByBarcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductByBarcodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProductByBarcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProductByBarcode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProductByBarcode(ctx, req.(*GetProductByBarcodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetRelatedProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRelatedProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetRelatedProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetRelatedProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetRelatedProducts(ctx, req.(*SetRelatedProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetRelatedProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetRelatedProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetRelatedProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetRelatedProducts(ctx, req.(*GetRelatedProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetBookingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBookingConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetBookingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetBookingConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetBookingConfig(ctx, req.(*SetBookingConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetAvailability(ctx, req.(*GetAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReserveBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReserveBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReserveBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReserveBooking(ctx, req.(*ReserveBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ConfirmBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ConfirmBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ConfirmBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ConfirmBooking(ctx, req.(*ConfirmBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CancelBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CancelBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CancelBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CancelBooking(ctx, req.(*CancelBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetImageUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImageUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetImageUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetImageUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetImageUploadURL(ctx, req.(*GetImageUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_AttachImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).AttachImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_AttachImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).AttachImage(ctx, req.(*AttachImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReorderImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReorderImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReorderImages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReorderImages(ctx, req.(*ReorderImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetPrimaryImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPrimaryImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetPrimaryImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetPrimaryImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetPrimaryImage(ctx, req.(*SetPrimaryImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReviewImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReviewImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReviewImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReviewImage(ctx, req.(*ReviewImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetImageRenditions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetImageRenditionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetImageRenditions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetImageRenditions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetImageRenditions(ctx, req.(*SetImageRenditionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetPriceTiers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceTiersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetPriceTiers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetPriceTiers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetPriceTiers(ctx, req.(*SetPriceTiersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetPriceForQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceForQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetPriceForQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetPriceForQuantity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetPriceForQuantity(ctx, req.(*GetPriceForQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_VerifyAuditChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAuditChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).VerifyAuditChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_VerifyAuditChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).VerifyAuditChain(ctx, req.(*VerifyAuditChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_IncrementStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).IncrementStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_IncrementStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).IncrementStock(ctx, req.(*IncrementStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DecrementStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecrementStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DecrementStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DecrementStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DecrementStock(ctx, req.(*DecrementStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListLowStockProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowStockProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListLowStockProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListLowStockProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListLowStockProducts(ctx, req.(*ListLowStockProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UncommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UncommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UncommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UncommitReservation(ctx, req.(*UncommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetBundle(ctx, req.(*SetBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetBundle(ctx, req.(*GetBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetDigitalAssetUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDigitalAssetUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetDigitalAssetUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetDigitalAssetUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetDigitalAssetUploadURL(ctx, req.(*GetDigitalAssetUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_AttachDigitalAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachDigitalAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).AttachDigitalAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_AttachDigitalAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).AttachDigitalAsset(ctx, req.(*AttachDigitalAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListDigitalAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDigitalAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListDigitalAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListDigitalAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListDigitalAssets(ctx, req.(*ListDigitalAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GrantEntitlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantEntitlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GrantEntitlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GrantEntitlement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GrantEntitlement(ctx, req.(*GrantEntitlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_RevokeEntitlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeEntitlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RevokeEntitlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RevokeEntitlement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RevokeEntitlement(ctx, req.(*RevokeEntitlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GenerateDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GenerateDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GenerateDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GenerateDownloadURL(ctx, req.(*GenerateDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetProductTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProductTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetProductTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetProductTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetProductTranslation(ctx, req.(*SetProductTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteProductTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteProductTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteProductTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteProductTranslation(ctx, req.(*DeleteProductTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListProductTranslations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductTranslationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListProductTranslations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListProductTranslations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListProductTranslations(ctx, req.(*ListProductTranslationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateRatingAggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRatingAggregateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateRatingAggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateRatingAggregate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateRatingAggregate(ctx, req.(*UpdateRatingAggregateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ChangeSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ChangeSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ChangeSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ChangeSKU(ctx, req.(*ChangeSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetProductBySKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductBySKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProductBySKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProductBySKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProductBySKU(ctx, req.(*GetProductBySKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListSKUAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSKUAliasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListSKUAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListSKUAliases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListSKUAliases(ctx, req.(*ListSKUAliasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetProductsBySKUs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductsBySKUsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProductsBySKUs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProductsBySKUs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProductsBySKUs(ctx, req.(*GetProductsBySKUsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetCategoryStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetCategoryStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetCategoryStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetCategoryStats(ctx, req.(*GetCategoryStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_RecordProductActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordProductActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RecordProductActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RecordProductActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RecordProductActivity(ctx, req.(*RecordProductActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListBestSellers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBestSellersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListBestSellers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListBestSellers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListBestSellers(ctx, req.(*ListBestSellersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SuggestProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SuggestProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SuggestProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SuggestProducts(ctx, req.(*SuggestProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetProductVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProductVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetProductVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetProductVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetProductVisibility(ctx, req.(*SetProductVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedCatalogServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method CreateProduct not implemented")
+func _CatalogService_GetProductVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProductVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProductVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProductVisibility(ctx, req.(*GetProductVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedCatalogServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetProduct not implemented")
+
+func _CatalogService_CloneProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CloneProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CloneProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CloneProduct(ctx, req.(*CloneProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedCatalogServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListProducts not implemented")
+
+func _CatalogService_StreamProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).StreamProducts(m, &grpc.GenericServerStream[StreamProductsRequest, Product]{ServerStream: stream})
 }
-func (UnimplementedCatalogServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdateProduct not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_StreamProductsServer = grpc.ServerStreamingServer[Product]
+
+func _CatalogService_WatchProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).WatchProducts(m, &grpc.GenericServerStream[WatchProductsRequest, ProductChangeEvent]{ServerStream: stream})
 }
-func (UnimplementedCatalogServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteProduct not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_WatchProductsServer = grpc.ServerStreamingServer[ProductChangeEvent]
+
+func _CatalogService_GetProductAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProductAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProductAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProductAuditLog(ctx, req.(*GetProductAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedCatalogServiceServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SearchProducts not implemented")
+
+func _CatalogService_AskQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AskQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).AskQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_AskQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).AskQuestion(ctx, req.(*AskQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
-func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
 
-// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to CatalogServiceServer will
-// result in compilation errors.
-type UnsafeCatalogServiceServer interface {
-	mustEmbedUnimplementedCatalogServiceServer()
+func _CatalogService_AnswerQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).AnswerQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_AnswerQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).AnswerQuestion(ctx, req.(*AnswerQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
-	// If the following call panics, it indicates UnimplementedCatalogServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _CatalogService_ModerateQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&CatalogService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ModerateQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ModerateQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ModerateQuestion(ctx, req.(*ModerateQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _CatalogService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateProductRequest)
+func _CatalogService_ModerateAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateAnswerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceServer).CreateProduct(ctx, in)
+		return srv.(CatalogServiceServer).ModerateAnswer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogService_CreateProduct_FullMethodName,
+		FullMethod: CatalogService_ModerateAnswer_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceServer).CreateProduct(ctx, req.(*CreateProductRequest))
+		return srv.(CatalogServiceServer).ModerateAnswer(ctx, req.(*ModerateAnswerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CatalogService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProductRequest)
+func _CatalogService_ListQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuestionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceServer).GetProduct(ctx, in)
+		return srv.(CatalogServiceServer).ListQuestions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogService_GetProduct_FullMethodName,
+		FullMethod: CatalogService_ListQuestions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+		return srv.(CatalogServiceServer).ListQuestions(ctx, req.(*ListQuestionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CatalogService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListProductsRequest)
+func _CatalogService_ApplyInventoryUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyInventoryUpdatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceServer).ListProducts(ctx, in)
+		return srv.(CatalogServiceServer).ApplyInventoryUpdates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogService_ListProducts_FullMethodName,
+		FullMethod: CatalogService_ApplyInventoryUpdates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+		return srv.(CatalogServiceServer).ApplyInventoryUpdates(ctx, req.(*ApplyInventoryUpdatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CatalogService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateProductRequest)
+func _CatalogService_SubscribeBackInStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeBackInStockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceServer).UpdateProduct(ctx, in)
+		return srv.(CatalogServiceServer).SubscribeBackInStock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogService_UpdateProduct_FullMethodName,
+		FullMethod: CatalogService_SubscribeBackInStock_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+		return srv.(CatalogServiceServer).SubscribeBackInStock(ctx, req.(*SubscribeBackInStockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CatalogService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteProductRequest)
+func _CatalogService_UnsubscribeBackInStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeBackInStockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceServer).DeleteProduct(ctx, in)
+		return srv.(CatalogServiceServer).UnsubscribeBackInStock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogService_DeleteProduct_FullMethodName,
+		FullMethod: CatalogService_UnsubscribeBackInStock_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+		return srv.(CatalogServiceServer).UnsubscribeBackInStock(ctx, req.(*UnsubscribeBackInStockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CatalogService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchProductsRequest)
+func _CatalogService_GetReorderReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReorderReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceServer).SearchProducts(ctx, in)
+		return srv.(CatalogServiceServer).GetReorderReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogService_SearchProducts_FullMethodName,
+		FullMethod: CatalogService_GetReorderReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+		return srv.(CatalogServiceServer).GetReorderReport(ctx, req.(*GetReorderReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -305,7 +2349,250 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchProducts",
 			Handler:    _CatalogService_SearchProducts_Handler,
 		},
+		{
+			MethodName: "GetProductByBarcode",
+			Handler:    _CatalogService_GetProductByBarcode_Handler,
+		},
+		{
+			MethodName: "SetRelatedProducts",
+			Handler:    _CatalogService_SetRelatedProducts_Handler,
+		},
+		{
+			MethodName: "GetRelatedProducts",
+			Handler:    _CatalogService_GetRelatedProducts_Handler,
+		},
+		{
+			MethodName: "SetBookingConfig",
+			Handler:    _CatalogService_SetBookingConfig_Handler,
+		},
+		{
+			MethodName: "GetAvailability",
+			Handler:    _CatalogService_GetAvailability_Handler,
+		},
+		{
+			MethodName: "ReserveBooking",
+			Handler:    _CatalogService_ReserveBooking_Handler,
+		},
+		{
+			MethodName: "ConfirmBooking",
+			Handler:    _CatalogService_ConfirmBooking_Handler,
+		},
+		{
+			MethodName: "CancelBooking",
+			Handler:    _CatalogService_CancelBooking_Handler,
+		},
+		{
+			MethodName: "GetImageUploadURL",
+			Handler:    _CatalogService_GetImageUploadURL_Handler,
+		},
+		{
+			MethodName: "AttachImage",
+			Handler:    _CatalogService_AttachImage_Handler,
+		},
+		{
+			MethodName: "ReorderImages",
+			Handler:    _CatalogService_ReorderImages_Handler,
+		},
+		{
+			MethodName: "SetPrimaryImage",
+			Handler:    _CatalogService_SetPrimaryImage_Handler,
+		},
+		{
+			MethodName: "ReviewImage",
+			Handler:    _CatalogService_ReviewImage_Handler,
+		},
+		{
+			MethodName: "SetImageRenditions",
+			Handler:    _CatalogService_SetImageRenditions_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _CatalogService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "SetPriceTiers",
+			Handler:    _CatalogService_SetPriceTiers_Handler,
+		},
+		{
+			MethodName: "GetPriceForQuantity",
+			Handler:    _CatalogService_GetPriceForQuantity_Handler,
+		},
+		{
+			MethodName: "VerifyAuditChain",
+			Handler:    _CatalogService_VerifyAuditChain_Handler,
+		},
+		{
+			MethodName: "IncrementStock",
+			Handler:    _CatalogService_IncrementStock_Handler,
+		},
+		{
+			MethodName: "DecrementStock",
+			Handler:    _CatalogService_DecrementStock_Handler,
+		},
+		{
+			MethodName: "ListLowStockProducts",
+			Handler:    _CatalogService_ListLowStockProducts_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _CatalogService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _CatalogService_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _CatalogService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "UncommitReservation",
+			Handler:    _CatalogService_UncommitReservation_Handler,
+		},
+		{
+			MethodName: "SetBundle",
+			Handler:    _CatalogService_SetBundle_Handler,
+		},
+		{
+			MethodName: "GetBundle",
+			Handler:    _CatalogService_GetBundle_Handler,
+		},
+		{
+			MethodName: "GetDigitalAssetUploadURL",
+			Handler:    _CatalogService_GetDigitalAssetUploadURL_Handler,
+		},
+		{
+			MethodName: "AttachDigitalAsset",
+			Handler:    _CatalogService_AttachDigitalAsset_Handler,
+		},
+		{
+			MethodName: "ListDigitalAssets",
+			Handler:    _CatalogService_ListDigitalAssets_Handler,
+		},
+		{
+			MethodName: "GrantEntitlement",
+			Handler:    _CatalogService_GrantEntitlement_Handler,
+		},
+		{
+			MethodName: "RevokeEntitlement",
+			Handler:    _CatalogService_RevokeEntitlement_Handler,
+		},
+		{
+			MethodName: "GenerateDownloadURL",
+			Handler:    _CatalogService_GenerateDownloadURL_Handler,
+		},
+		{
+			MethodName: "SetProductTranslation",
+			Handler:    _CatalogService_SetProductTranslation_Handler,
+		},
+		{
+			MethodName: "DeleteProductTranslation",
+			Handler:    _CatalogService_DeleteProductTranslation_Handler,
+		},
+		{
+			MethodName: "ListProductTranslations",
+			Handler:    _CatalogService_ListProductTranslations_Handler,
+		},
+		{
+			MethodName: "UpdateRatingAggregate",
+			Handler:    _CatalogService_UpdateRatingAggregate_Handler,
+		},
+		{
+			MethodName: "ChangeSKU",
+			Handler:    _CatalogService_ChangeSKU_Handler,
+		},
+		{
+			MethodName: "GetProductBySKU",
+			Handler:    _CatalogService_GetProductBySKU_Handler,
+		},
+		{
+			MethodName: "ListSKUAliases",
+			Handler:    _CatalogService_ListSKUAliases_Handler,
+		},
+		{
+			MethodName: "GetProductsBySKUs",
+			Handler:    _CatalogService_GetProductsBySKUs_Handler,
+		},
+		{
+			MethodName: "GetCategoryStats",
+			Handler:    _CatalogService_GetCategoryStats_Handler,
+		},
+		{
+			MethodName: "RecordProductActivity",
+			Handler:    _CatalogService_RecordProductActivity_Handler,
+		},
+		{
+			MethodName: "ListBestSellers",
+			Handler:    _CatalogService_ListBestSellers_Handler,
+		},
+		{
+			MethodName: "SuggestProducts",
+			Handler:    _CatalogService_SuggestProducts_Handler,
+		},
+		{
+			MethodName: "SetProductVisibility",
+			Handler:    _CatalogService_SetProductVisibility_Handler,
+		},
+		{
+			MethodName: "GetProductVisibility",
+			Handler:    _CatalogService_GetProductVisibility_Handler,
+		},
+		{
+			MethodName: "CloneProduct",
+			Handler:    _CatalogService_CloneProduct_Handler,
+		},
+		{
+			MethodName: "GetProductAuditLog",
+			Handler:    _CatalogService_GetProductAuditLog_Handler,
+		},
+		{
+			MethodName: "AskQuestion",
+			Handler:    _CatalogService_AskQuestion_Handler,
+		},
+		{
+			MethodName: "AnswerQuestion",
+			Handler:    _CatalogService_AnswerQuestion_Handler,
+		},
+		{
+			MethodName: "ModerateQuestion",
+			Handler:    _CatalogService_ModerateQuestion_Handler,
+		},
+		{
+			MethodName: "ModerateAnswer",
+			Handler:    _CatalogService_ModerateAnswer_Handler,
+		},
+		{
+			MethodName: "ListQuestions",
+			Handler:    _CatalogService_ListQuestions_Handler,
+		},
+		{
+			MethodName: "ApplyInventoryUpdates",
+			Handler:    _CatalogService_ApplyInventoryUpdates_Handler,
+		},
+		{
+			MethodName: "SubscribeBackInStock",
+			Handler:    _CatalogService_SubscribeBackInStock_Handler,
+		},
+		{
+			MethodName: "UnsubscribeBackInStock",
+			Handler:    _CatalogService_UnsubscribeBackInStock_Handler,
+		},
+		{
+			MethodName: "GetReorderReport",
+			Handler:    _CatalogService_GetReorderReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamProducts",
+			Handler:       _CatalogService_StreamProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchProducts",
+			Handler:       _CatalogService_WatchProducts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "catalog/catalog.proto",
 }
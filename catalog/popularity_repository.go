@@ -0,0 +1,153 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Product activity types
+const (
+	ActivityView  = "VIEW"
+	ActivityOrder = "ORDER"
+)
+
+// popularityWindowDays is the number of days of activity the POPULARITY sort considers
+const popularityWindowDays = 30
+
+// popularityOrder ranks products by units sold, then views, over the last
+// popularityWindowDays days. It is used in queries selecting FROM products.
+var popularityOrder = fmt.Sprintf(`
+	(SELECT COALESCE(SUM(d.units), 0) FROM product_activity_daily d
+		WHERE d.product_id = products.id AND d.day > CURRENT_DATE - %[1]d) DESC,
+	(SELECT COALESCE(SUM(d.views), 0) FROM product_activity_daily d
+		WHERE d.product_id = products.id AND d.day > CURRENT_DATE - %[1]d) DESC`, popularityWindowDays)
+
+// ProductActivity is a view or an order of a product. EventID identifies the
+// event so redeliveries are counted once.
+type ProductActivity struct {
+	EventID    string
+	ProductID  string
+	Type       string
+	Quantity   int32 // units ordered, or views
+	OccurredAt time.Time
+}
+
+// BestSeller is a product with its activity over a period
+type BestSeller struct {
+	Product    *Product
+	UnitsSold  int64
+	OrderCount int64
+	ViewCount  int64
+}
+
+// RecordActivity adds activity events to the daily counts of their products
+// and returns how many were recorded. Events already recorded and events of
+// unknown products are skipped.
+func (r *postgresRepository) RecordActivity(ctx context.Context, events []*ProductActivity) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	upsert := `
+		INSERT INTO product_activity_daily (product_id, day, views, orders, units)
+		SELECT $1, $2, $3, $4, $5
+		WHERE EXISTS (SELECT 1 FROM products WHERE id = $1)
+		ON CONFLICT (product_id, day) DO UPDATE
+		SET views = product_activity_daily.views + EXCLUDED.views,
+			orders = product_activity_daily.orders + EXCLUDED.orders,
+			units = product_activity_daily.units + EXCLUDED.units
+	`
+
+	recorded := 0
+	for _, e := range events {
+		result, err := tx.ExecContext(ctx, "INSERT INTO product_activity_events (event_id) VALUES ($1) ON CONFLICT DO NOTHING", e.EventID)
+		if err != nil {
+			r.log.Error(ctx, "Failed to record activity event", map[string]interface{}{"error": err.Error(), "event_id": e.EventID})
+			return 0, fmt.Errorf("failed to record activity event: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			// Already recorded
+			continue
+		}
+
+		var views, orders, units int32
+		if e.Type == ActivityOrder {
+			orders, units = 1, e.Quantity
+		} else {
+			views = e.Quantity
+		}
+		day := e.OccurredAt.UTC().Truncate(24 * time.Hour)
+
+		result, err = tx.ExecContext(ctx, upsert, e.ProductID, day, views, orders, units)
+		if err != nil {
+			r.log.Error(ctx, "Failed to record product activity", map[string]interface{}{"error": err.Error(), "product_id": e.ProductID})
+			return 0, fmt.Errorf("failed to record product activity: %w", err)
+		}
+		rows, err = result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		// No row means the product does not exist
+		if rows > 0 {
+			recorded++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit product activity", map[string]interface{}{"error": err.Error()})
+		return 0, fmt.Errorf("failed to commit product activity: %w", err)
+	}
+	return recorded, nil
+}
+
+// ListBestSellers retrieves the active products with the most units sold
+// since the given day, optionally within a category
+func (r *postgresRepository) ListBestSellers(ctx context.Context, since time.Time, category string, limit int) ([]*BestSeller, error) {
+	query := `
+		SELECT a.units, a.orders, a.views, ` + productColumnsWithAlias("p") + `
+		FROM (
+			SELECT product_id, SUM(units) AS units, SUM(orders) AS orders, SUM(views) AS views
+			FROM product_activity_daily
+			WHERE day >= $1
+			GROUP BY product_id
+		) a
+		JOIN products p ON p.id = a.product_id
+		WHERE a.units > 0 AND p.status = '` + ProductStatusActive + `' AND ($2 = '' OR p.category = $2)
+		ORDER BY a.units DESC, a.orders DESC, a.views DESC, p.id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since, category, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list best sellers", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list best sellers: %w", err)
+	}
+	defer rows.Close()
+
+	sellers := []*BestSeller{}
+	for rows.Next() {
+		b := &BestSeller{}
+		product, err := scanProduct(rows, &b.UnitsSold, &b.OrderCount, &b.ViewCount)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan best seller", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan best seller: %w", err)
+		}
+		b.Product = product
+		sellers = append(sellers, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating best sellers", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating best sellers: %w", err)
+	}
+
+	return sellers, nil
+}
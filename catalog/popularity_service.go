@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxActivityEvents caps the events recorded by one RecordProductActivity call
+const maxActivityEvents = 500
+
+// maxEventIDLength matches the product_activity_events event_id column
+const maxEventIDLength = 255
+
+// Best-seller windows
+const (
+	WindowDay     = "DAY"
+	WindowWeek    = "WEEK"
+	WindowMonth   = "MONTH"
+	WindowAllTime = "ALL_TIME"
+)
+
+// bestSellerWindowDays is the number of days, including today, each window covers
+var bestSellerWindowDays = map[string]int{
+	WindowDay:   1,
+	WindowWeek:  7,
+	WindowMonth: 30,
+}
+
+// RecordProductActivity adds views and orders to the activity counts of
+// products. It is called with the storefront's views and the order service's
+// order events; redelivered events are ignored.
+func (s *Service) RecordProductActivity(ctx context.Context, req *pb.RecordProductActivityRequest) (*pb.RecordProductActivityResponse, error) {
+	if len(req.Events) == 0 {
+		s.log.Warn(ctx, "Record product activity failed: events are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "events are required")
+	}
+	if len(req.Events) > maxActivityEvents {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d events can be recorded at once", maxActivityEvents)
+	}
+
+	now := s.now()
+	events := make([]*ProductActivity, len(req.Events))
+	for i, e := range req.Events {
+		event, msg := activityFromRequest(e, now)
+		if msg != "" {
+			s.log.Warn(ctx, "Record product activity failed: "+msg, map[string]interface{}{"event_id": e.EventId})
+			return nil, status.Errorf(codes.InvalidArgument, "events[%d]: %s", i, msg)
+		}
+		events[i] = event
+	}
+
+	recorded, err := s.repo.RecordActivity(ctx, events)
+	if err != nil {
+		s.log.Error(ctx, "Failed to record product activity", map[string]interface{}{"error": err.Error(), "count": len(events)})
+		return nil, status.Error(codes.Internal, "failed to record product activity")
+	}
+
+	return &pb.RecordProductActivityResponse{
+		Recorded: int32(recorded),
+		Ignored:  int32(len(events) - recorded),
+	}, nil
+}
+
+// activityFromRequest validates an activity event, defaulting its quantity to
+// 1 and its time to now
+func activityFromRequest(e *pb.ProductActivityEvent, now time.Time) (*ProductActivity, string) {
+	if e.EventId == "" {
+		return nil, "event_id is required"
+	}
+	if len(e.EventId) > maxEventIDLength {
+		return nil, "event_id is too long"
+	}
+	if e.ProductId == "" {
+		return nil, "product_id is required"
+	}
+	if e.Type != ActivityView && e.Type != ActivityOrder {
+		return nil, "type must be VIEW or ORDER"
+	}
+	if e.Quantity < 0 {
+		return nil, "quantity cannot be negative"
+	}
+
+	event := &ProductActivity{
+		EventID:    e.EventId,
+		ProductID:  e.ProductId,
+		Type:       e.Type,
+		Quantity:   e.Quantity,
+		OccurredAt: now,
+	}
+	if event.Quantity == 0 {
+		event.Quantity = 1
+	}
+	if e.OccurredAt != nil {
+		event.OccurredAt = e.OccurredAt.AsTime()
+	}
+	return event, ""
+}
+
+// ListBestSellers ranks active products by units sold over a time window
+func (s *Service) ListBestSellers(ctx context.Context, req *pb.ListBestSellersRequest) (*pb.ListBestSellersResponse, error) {
+	window := req.Window
+	if window == "" {
+		window = WindowWeek
+	}
+	var since time.Time
+	if window != WindowAllTime {
+		days, ok := bestSellerWindowDays[window]
+		if !ok {
+			s.log.Warn(ctx, "List best sellers failed: invalid window", map[string]interface{}{"window": req.Window})
+			return nil, status.Error(codes.InvalidArgument, "window must be DAY, WEEK, MONTH or ALL_TIME")
+		}
+		today := s.now().UTC().Truncate(24 * time.Hour)
+		since = today.AddDate(0, 0, 1-days)
+	}
+
+	limit := int(req.Limit)
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	sellers, err := s.repo.ListBestSellers(ctx, since, req.Category, limit)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list best sellers", map[string]interface{}{"error": err.Error(), "window": window})
+		return nil, status.Error(codes.Internal, "failed to list best sellers")
+	}
+
+	products := make([]*Product, len(sellers))
+	for i, b := range sellers {
+		products[i] = b.Product
+	}
+	if err := s.localize(ctx, req.Locale, products...); err != nil {
+		s.log.Error(ctx, "Failed to localize products", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list best sellers")
+	}
+
+	now := s.now()
+	resp := &pb.ListBestSellersResponse{BestSellers: make([]*pb.BestSeller, len(sellers))}
+	for i, b := range sellers {
+		resp.BestSellers[i] = &pb.BestSeller{
+			Product:    toProtoProduct(b.Product, now),
+			UnitsSold:  b.UnitsSold,
+			OrderCount: b.OrderCount,
+			ViewCount:  b.ViewCount,
+		}
+	}
+	return resp, nil
+}
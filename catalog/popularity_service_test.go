@@ -0,0 +1,123 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var popularityNow = time.Date(2026, 5, 20, 15, 30, 0, 0, time.UTC)
+
+func TestRecordProductActivity_Success(t *testing.T) {
+	var got []*ProductActivity
+	mockRepo := &MockRepository{
+		RecordActivityFunc: func(ctx context.Context, events []*ProductActivity) (int, error) {
+			got = events
+			return 1, nil
+		},
+	}
+	service := setupService(mockRepo)
+	service.now = func() time.Time { return popularityNow }
+
+	orderedAt := popularityNow.Add(-26 * time.Hour)
+	resp, err := service.RecordProductActivity(context.Background(), &pb.RecordProductActivityRequest{
+		Events: []*pb.ProductActivityEvent{
+			{EventId: "order-1:1", ProductId: "prod-1", Type: ActivityOrder, Quantity: 3, OccurredAt: timestamppb.New(orderedAt)},
+			{EventId: "view-9", ProductId: "prod-2", Type: ActivityView},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Recorded != 1 || resp.Ignored != 1 {
+		t.Errorf("Expected 1 recorded and 1 ignored, got %v", resp)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(got))
+	}
+	if got[0].Quantity != 3 || !got[0].OccurredAt.Equal(orderedAt) {
+		t.Errorf("Unexpected order event %+v", got[0])
+	}
+	if got[1].Quantity != 1 || !got[1].OccurredAt.Equal(popularityNow) {
+		t.Errorf("Expected view to default to 1 view now, got %+v", got[1])
+	}
+}
+
+func TestRecordProductActivity_Invalid(t *testing.T) {
+	service := setupService(&MockRepository{})
+
+	tests := []struct {
+		name  string
+		event *pb.ProductActivityEvent
+	}{
+		{"missing event ID", &pb.ProductActivityEvent{ProductId: "prod-1", Type: ActivityView}},
+		{"missing product ID", &pb.ProductActivityEvent{EventId: "e1", Type: ActivityView}},
+		{"unknown type", &pb.ProductActivityEvent{EventId: "e1", ProductId: "prod-1", Type: "CLICK"}},
+		{"negative quantity", &pb.ProductActivityEvent{EventId: "e1", ProductId: "prod-1", Type: ActivityOrder, Quantity: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.RecordProductActivityRequest{Events: []*pb.ProductActivityEvent{tt.event}}
+			if _, err := service.RecordProductActivity(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+
+	if _, err := service.RecordProductActivity(context.Background(), &pb.RecordProductActivityRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without events, got %v", err)
+	}
+}
+
+func TestListBestSellers_Success(t *testing.T) {
+	var gotSince time.Time
+	var gotCategory string
+	var gotLimit int
+	mockRepo := &MockRepository{
+		ListBestSellersFunc: func(ctx context.Context, since time.Time, category string, limit int) ([]*BestSeller, error) {
+			gotSince, gotCategory, gotLimit = since, category, limit
+			return []*BestSeller{
+				{Product: &Product{ID: "prod-1", Name: "Lamp"}, UnitsSold: 12, OrderCount: 5, ViewCount: 80},
+			}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	service.now = func() time.Time { return popularityNow }
+
+	resp, err := service.ListBestSellers(context.Background(), &pb.ListBestSellersRequest{Category: "Home"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := time.Date(2026, 5, 14, 0, 0, 0, 0, time.UTC); !gotSince.Equal(want) {
+		t.Errorf("Expected the default WEEK window to start %v, got %v", want, gotSince)
+	}
+	if gotCategory != "Home" || gotLimit != 10 {
+		t.Errorf("Expected category Home and limit 10, got %q and %d", gotCategory, gotLimit)
+	}
+	if len(resp.BestSellers) != 1 || resp.BestSellers[0].UnitsSold != 12 || resp.BestSellers[0].Product.Id != "prod-1" {
+		t.Errorf("Unexpected best sellers %v", resp.BestSellers)
+	}
+
+	windows := map[string]time.Time{
+		WindowDay:     time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC),
+		WindowMonth:   time.Date(2026, 4, 21, 0, 0, 0, 0, time.UTC),
+		WindowAllTime: {},
+	}
+	for window, want := range windows {
+		if _, err := service.ListBestSellers(context.Background(), &pb.ListBestSellersRequest{Window: window, Limit: 500}); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", window, err)
+		}
+		if !gotSince.Equal(want) || gotLimit != 100 {
+			t.Errorf("%s: expected since %v and limit 100, got %v and %d", window, want, gotSince, gotLimit)
+		}
+	}
+
+	if _, err := service.ListBestSellers(context.Background(), &pb.ListBestSellersRequest{Window: "YEAR"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown window, got %v", err)
+	}
+}
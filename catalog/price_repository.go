@@ -0,0 +1,281 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceChange is one entry of a product's price history. Entries form a hash
+// chain in Seq order (see audit_chain.go).
+type PriceChange struct {
+	ID        string
+	ProductID string
+	// OldPrice is nil for the product's initial price
+	OldPrice  *float64
+	NewPrice  float64
+	ChangedBy string
+	ChangedAt time.Time
+	Seq       int64
+	PrevHash  string
+	Hash      string
+}
+
+// AuditAnchor is a checkpoint of the price history chain head
+type AuditAnchor struct {
+	ID        string
+	Seq       int64
+	Hash      string
+	Signature string
+	CreatedAt time.Time
+}
+
+const priceChangeColumns = "id, product_id, old_price, new_price, changed_by, changed_at, seq, prev_hash, hash"
+
+// recordPriceChange appends the product's current price to the history chain.
+// With a non-nil oldPrice nothing is recorded unless the price actually changed.
+func recordPriceChange(ctx context.Context, tx *sql.Tx, productID string, oldPrice *float64, actor string, at time.Time) error {
+	var price float64
+	if err := tx.QueryRowContext(ctx, "SELECT price FROM products WHERE id = $1", productID).Scan(&price); err != nil {
+		return fmt.Errorf("failed to read price: %w", err)
+	}
+	if oldPrice != nil && *oldPrice == price {
+		return nil
+	}
+
+	// Entries are chained in order, so concurrent writers take turns
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('price_history'))"); err != nil {
+		return fmt.Errorf("failed to lock price history: %w", err)
+	}
+
+	change := &PriceChange{
+		ID:        uuid.New().String(),
+		ProductID: productID,
+		OldPrice:  oldPrice,
+		NewPrice:  price,
+		ChangedBy: actor,
+		ChangedAt: at.UTC().Truncate(time.Microsecond),
+		PrevHash:  genesisHash,
+	}
+
+	err := tx.QueryRowContext(ctx, "SELECT seq, hash FROM price_history ORDER BY seq DESC LIMIT 1").Scan(&change.Seq, &change.PrevHash)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to read price history head: %w", err)
+	}
+	change.Seq++
+	change.Hash = hashPriceChange(change)
+
+	var old sql.NullFloat64
+	if oldPrice != nil {
+		old = sql.NullFloat64{Float64: *oldPrice, Valid: true}
+	}
+
+	query := `
+		INSERT INTO price_history (` + priceChangeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = tx.ExecContext(ctx, query, change.ID, change.ProductID, old, change.NewPrice, change.ChangedBy, change.ChangedAt, change.Seq, change.PrevHash, change.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to record price change: %w", err)
+	}
+	return nil
+}
+
+// scanPriceChange scans a row selected with priceChangeColumns
+func scanPriceChange(row rowScanner) (*PriceChange, error) {
+	change := &PriceChange{}
+	var oldPrice sql.NullFloat64
+	err := row.Scan(&change.ID, &change.ProductID, &oldPrice, &change.NewPrice, &change.ChangedBy, &change.ChangedAt, &change.Seq, &change.PrevHash, &change.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if oldPrice.Valid {
+		change.OldPrice = &oldPrice.Float64
+	}
+	return change, nil
+}
+
+// GetPriceHistory retrieves a product's price changes, newest first
+func (r *postgresRepository) GetPriceHistory(ctx context.Context, productID string, page, pageSize int32) ([]*PriceChange, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM price_history WHERE product_id = $1", productID).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count price history", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, 0, fmt.Errorf("failed to count price history: %w", err)
+	}
+
+	query := `
+		SELECT ` + priceChangeColumns + `
+		FROM price_history
+		WHERE product_id = $1
+		ORDER BY seq DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get price history", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, 0, fmt.Errorf("failed to get price history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*PriceChange{}
+	for rows.Next() {
+		change, err := scanPriceChange(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan price change", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan price change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate price history: %w", err)
+	}
+
+	return changes, total, nil
+}
+
+// ListPriceHistoryChain retrieves up to limit history entries after afterSeq, in chain order
+func (r *postgresRepository) ListPriceHistoryChain(ctx context.Context, afterSeq int64, limit int) ([]*PriceChange, error) {
+	query := `
+		SELECT ` + priceChangeColumns + `
+		FROM price_history
+		WHERE seq > $1
+		ORDER BY seq
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterSeq, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list price history chain", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list price history chain: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*PriceChange{}
+	for rows.Next() {
+		change, err := scanPriceChange(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan price change", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan price change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price history chain: %w", err)
+	}
+
+	return changes, nil
+}
+
+// ListPriceChangesBetween retrieves the price changes recorded in [from, to) in chain order
+func (r *postgresRepository) ListPriceChangesBetween(ctx context.Context, from, to time.Time) ([]*PriceChange, error) {
+	query := `
+		SELECT ` + priceChangeColumns + `
+		FROM price_history
+		WHERE changed_at >= $1 AND changed_at < $2
+		ORDER BY seq
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list price changes", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list price changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*PriceChange{}
+	for rows.Next() {
+		change, err := scanPriceChange(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan price change", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan price change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// GetPriceHistoryHead retrieves the last entry of the price history chain, or nil if it is empty
+func (r *postgresRepository) GetPriceHistoryHead(ctx context.Context) (*PriceChange, error) {
+	query := "SELECT " + priceChangeColumns + " FROM price_history ORDER BY seq DESC LIMIT 1"
+
+	change, err := scanPriceChange(r.db.QueryRowContext(ctx, query))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get price history head", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get price history head: %w", err)
+	}
+
+	return change, nil
+}
+
+// SaveAuditAnchor stores a checkpoint of the chain head
+func (r *postgresRepository) SaveAuditAnchor(ctx context.Context, anchor *AuditAnchor) (*AuditAnchor, error) {
+	query := `
+		INSERT INTO price_history_anchors (seq, hash, signature)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	saved := *anchor
+	if err := r.db.QueryRowContext(ctx, query, anchor.Seq, anchor.Hash, anchor.Signature).Scan(&saved.ID, &saved.CreatedAt); err != nil {
+		r.log.Error(ctx, "Failed to save audit anchor", map[string]interface{}{"error": err.Error(), "seq": anchor.Seq})
+		return nil, fmt.Errorf("failed to save audit anchor: %w", err)
+	}
+
+	r.log.Info(ctx, "Audit anchor saved", map[string]interface{}{"seq": saved.Seq, "hash": saved.Hash})
+	return &saved, nil
+}
+
+// ListAuditAnchors retrieves all checkpoints ordered by sequence
+func (r *postgresRepository) ListAuditAnchors(ctx context.Context) ([]*AuditAnchor, error) {
+	query := "SELECT id, seq, hash, signature, created_at FROM price_history_anchors ORDER BY seq, created_at"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list audit anchors", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list audit anchors: %w", err)
+	}
+	defer rows.Close()
+
+	anchors := []*AuditAnchor{}
+	for rows.Next() {
+		anchor := &AuditAnchor{}
+		if err := rows.Scan(&anchor.ID, &anchor.Seq, &anchor.Hash, &anchor.Signature, &anchor.CreatedAt); err != nil {
+			r.log.Error(ctx, "Failed to scan audit anchor", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan audit anchor: %w", err)
+		}
+		anchors = append(anchors, anchor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate audit anchors: %w", err)
+	}
+
+	return anchors, nil
+}
@@ -0,0 +1,115 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// systemActor is recorded when a change is made without a calling user
+const systemActor = "system"
+
+// actorFromContext returns the user ID of the caller's validated token, or
+// systemActor
+func actorFromContext(ctx context.Context) string {
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		return userID
+	}
+	return systemActor
+}
+
+// WithAuditKey sets the key price history anchors are signed with
+func (s *Service) WithAuditKey(key []byte) *Service {
+	s.auditKey = key
+	return s
+}
+
+// GetPriceHistory returns a product's price changes, newest first
+func (s *Service) GetPriceHistory(ctx context.Context, req *pb.GetPriceHistoryRequest) (*pb.GetPriceHistoryResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get price history failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	changes, total, err := s.repo.GetPriceHistory(ctx, req.ProductId, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get price history", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get price history")
+	}
+
+	// Every product has at least its initial price, so no history means no product
+	if total == 0 {
+		if _, err := s.repo.GetByID(ctx, req.ProductId); err != nil {
+			return nil, s.productLookupError(ctx, err, req.ProductId)
+		}
+	}
+
+	protoChanges := make([]*pb.PriceChange, len(changes))
+	for i, c := range changes {
+		protoChanges[i] = toProtoPriceChange(c)
+	}
+
+	return &pb.GetPriceHistoryResponse{
+		Changes:  protoChanges,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// VerifyAuditChain checks that the price history has not been tampered with
+func (s *Service) VerifyAuditChain(ctx context.Context, req *pb.VerifyAuditChainRequest) (*pb.VerifyAuditChainResponse, error) {
+	report, err := verifyPriceHistoryChain(ctx, s.repo, s.auditKey)
+	if err != nil {
+		s.log.Error(ctx, "Failed to verify audit chain", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to verify audit chain")
+	}
+
+	if !report.Valid {
+		s.log.Error(ctx, "Audit chain verification failed", map[string]interface{}{"seq": report.FirstInvalidSeq, "problem": report.Problem})
+	}
+
+	return &pb.VerifyAuditChainResponse{
+		Valid:           report.Valid,
+		EntriesChecked:  report.EntriesChecked,
+		AnchorsChecked:  report.AnchorsChecked,
+		HeadSeq:         report.HeadSeq,
+		HeadHash:        report.HeadHash,
+		FirstInvalidSeq: report.FirstInvalidSeq,
+		Problem:         report.Problem,
+	}, nil
+}
+
+// toProtoPriceChange converts a price change to protobuf
+func toProtoPriceChange(c *PriceChange) *pb.PriceChange {
+	change := &pb.PriceChange{
+		Id:        c.ID,
+		ProductId: c.ProductID,
+		NewPrice:  c.NewPrice,
+		ChangedBy: c.ChangedBy,
+		ChangedAt: timestamppb.New(c.ChangedAt),
+		Seq:       c.Seq,
+		Hash:      c.Hash,
+	}
+	if c.OldPrice != nil {
+		change.OldPrice = *c.OldPrice
+	}
+	return change
+}
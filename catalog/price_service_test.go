@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetPriceHistory_Success(t *testing.T) {
+	oldPrice := 99.99
+	mockRepo := &MockRepository{
+		GetPriceHistoryFunc: func(ctx context.Context, productID string, page, pageSize int32) ([]*PriceChange, int32, error) {
+			return []*PriceChange{
+				{ID: "h2", ProductID: productID, OldPrice: &oldPrice, NewPrice: 79.99, ChangedBy: "admin-1", ChangedAt: time.Now()},
+				{ID: "h1", ProductID: productID, NewPrice: 99.99, ChangedBy: "system", ChangedAt: time.Now()},
+			}, 2, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.GetPriceHistory(ctx, &pb.GetPriceHistoryRequest{ProductId: "p1"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Total != 2 || len(resp.Changes) != 2 || resp.PageSize != 10 {
+		t.Fatalf("Unexpected response %v", resp)
+	}
+
+	if resp.Changes[0].OldPrice != 99.99 || resp.Changes[0].NewPrice != 79.99 {
+		t.Errorf("Unexpected latest change %v", resp.Changes[0])
+	}
+
+	if resp.Changes[1].OldPrice != 0 {
+		t.Errorf("Expected initial price to have no old price, got %v", resp.Changes[1].OldPrice)
+	}
+}
+
+func TestGetPriceHistory_ProductNotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetPriceHistoryFunc: func(ctx context.Context, productID string, page, pageSize int32) ([]*PriceChange, int32, error) {
+			return []*PriceChange{}, 0, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.GetPriceHistory(ctx, &pb.GetPriceHistoryRequest{ProductId: "missing"})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestUpdateProduct_RecordsActor(t *testing.T) {
+	var actor string
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, SKU: "SKU-001", Price: 10, Version: 1}, nil
+		},
+		UpdateFunc: func(ctx context.Context, product *Product, a string) (*Product, error) {
+			actor = a
+			return product, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "admin-7", Role: auth.RoleAdmin})
+
+	_, err := service.UpdateProduct(ctx, &pb.UpdateProductRequest{Id: "p1", Name: "Mug", Price: 12, Version: 1})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if actor != "admin-7" {
+		t.Errorf("Expected actor admin-7, got %q", actor)
+	}
+
+	if got := actorFromContext(context.Background()); got != systemActor {
+		t.Errorf("Expected %s without a token, got %q", systemActor, got)
+	}
+
+	// A user ID the caller puts in metadata is not trusted
+	forged := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "admin-7"))
+	if got := actorFromContext(forged); got != systemActor {
+		t.Errorf("Expected %s for a forwarded user ID, got %q", systemActor, got)
+	}
+}
@@ -0,0 +1,94 @@
+package catalog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldChange is the old and new value of one product field, formatted as
+// text. An empty value means the field was not set.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// auditField is a product field recorded in the audit log
+type auditField struct {
+	name  string
+	value func(p *Product) string
+}
+
+// auditFields lists the audited product fields in the order changes are
+// reported. Timestamps and the review aggregates are not audited.
+var auditFields = []auditField{
+	{"name", func(p *Product) string { return p.Name }},
+	{"description", func(p *Product) string { return p.Description }},
+	{"description_blocks", func(p *Product) string {
+		if len(p.DescriptionBlocks) == 0 {
+			return ""
+		}
+		data, _ := json.Marshal(p.DescriptionBlocks)
+		return string(data)
+	}},
+	{"sku", func(p *Product) string { return p.SKU }},
+	{"status", func(p *Product) string { return p.Status }},
+	{"product_type", func(p *Product) string { return p.ProductType }},
+	{"category", func(p *Product) string { return p.Category }},
+	{"price", func(p *Product) string { return auditFloat(p.Price) }},
+	{"sale_price", func(p *Product) string {
+		if p.SalePrice == nil {
+			return ""
+		}
+		return auditFloat(*p.SalePrice)
+	}},
+	{"sale_starts_at", func(p *Product) string { return auditTime(p.SaleStartsAt) }},
+	{"sale_ends_at", func(p *Product) string { return auditTime(p.SaleEndsAt) }},
+	{"stock", func(p *Product) string { return strconv.Itoa(int(p.Stock)) }},
+	{"low_stock_threshold", func(p *Product) string { return strconv.Itoa(int(p.LowStockThreshold)) }},
+	{"min_order_qty", func(p *Product) string { return strconv.Itoa(int(p.MinOrderQty)) }},
+	{"max_order_qty", func(p *Product) string { return strconv.Itoa(int(p.MaxOrderQty)) }},
+	{"qty_increment", func(p *Product) string { return strconv.Itoa(int(p.QtyIncrement)) }},
+	{"images", func(p *Product) string { return strings.Join(imageURLs(p.Images), "\n") }},
+	{"weight_kg", func(p *Product) string { return auditFloat(p.WeightKg) }},
+	{"length_cm", func(p *Product) string { return auditFloat(p.LengthCm) }},
+	{"width_cm", func(p *Product) string { return auditFloat(p.WidthCm) }},
+	{"height_cm", func(p *Product) string { return auditFloat(p.HeightCm) }},
+	{"shipping_class", func(p *Product) string { return p.ShippingClass }},
+	{"ean", func(p *Product) string { return p.EAN }},
+	{"upc", func(p *Product) string { return p.UPC }},
+	{"isbn", func(p *Product) string { return p.ISBN }},
+}
+
+// diffProducts returns the audited fields whose values differ between before
+// and after. A nil before reports every field set on a created product; a nil
+// after reports every field of a deleted one.
+func diffProducts(before, after *Product) []FieldChange {
+	changes := []FieldChange{}
+	for _, f := range auditFields {
+		var old, new string
+		if before != nil {
+			old = f.value(before)
+		}
+		if after != nil {
+			new = f.value(after)
+		}
+		if old != new {
+			changes = append(changes, FieldChange{Field: f.name, Old: old, New: new})
+		}
+	}
+	return changes
+}
+
+func auditFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func auditTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
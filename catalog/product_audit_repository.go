@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Product audit actions
+const (
+	AuditActionCreate = "CREATE"
+	AuditActionUpdate = "UPDATE"
+	AuditActionDelete = "DELETE"
+)
+
+// ProductAuditEntry records one mutation of a product
+type ProductAuditEntry struct {
+	ID        int64
+	ProductID string
+	Action    string
+	Actor     string
+	Changes   []FieldChange
+	CreatedAt time.Time
+}
+
+const productAuditColumns = "id, product_id, action, actor, changes, created_at"
+
+// recordProductAudit adds an audit entry within the transaction of the mutation
+func recordProductAudit(ctx context.Context, tx *sql.Tx, productID, action, actor string, changes []FieldChange, at time.Time) error {
+	if changes == nil {
+		changes = []FieldChange{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+
+	query := "INSERT INTO product_audit_log (product_id, action, actor, changes, created_at) VALUES ($1, $2, $3, $4, $5)"
+	if _, err := tx.ExecContext(ctx, query, productID, action, actor, data, at); err != nil {
+		return fmt.Errorf("failed to record product audit entry: %w", err)
+	}
+	return nil
+}
+
+// GetProductAuditLog retrieves a product's audit entries with pagination, newest first
+func (r *postgresRepository) GetProductAuditLog(ctx context.Context, productID string, page, pageSize int32) ([]*ProductAuditEntry, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM product_audit_log WHERE product_id = $1", productID).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count product audit log", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, 0, fmt.Errorf("failed to count product audit log: %w", err)
+	}
+
+	query := `
+		SELECT ` + productAuditColumns + `
+		FROM product_audit_log
+		WHERE product_id = $1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	entries, err := r.queryProductAudit(ctx, query, productID, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get product audit log", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// ListProductAuditBetween retrieves the audit entries recorded in [from, to), oldest first
+func (r *postgresRepository) ListProductAuditBetween(ctx context.Context, from, to time.Time) ([]*ProductAuditEntry, error) {
+	query := `
+		SELECT ` + productAuditColumns + `
+		FROM product_audit_log
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY id
+	`
+
+	entries, err := r.queryProductAudit(ctx, query, from, to)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list product audit entries", map[string]interface{}{"error": err.Error()})
+		return nil, err
+	}
+	return entries, nil
+}
+
+// queryProductAudit runs a query selecting productAuditColumns
+func (r *postgresRepository) queryProductAudit(ctx context.Context, query string, args ...interface{}) ([]*ProductAuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*ProductAuditEntry{}
+	for rows.Next() {
+		e := &ProductAuditEntry{}
+		var changes []byte
+		if err := rows.Scan(&e.ID, &e.ProductID, &e.Action, &e.Actor, &changes, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan product audit entry: %w", err)
+		}
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode audit changes: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product audit log: %w", err)
+	}
+	return entries, nil
+}
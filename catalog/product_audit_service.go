@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetProductAuditLog returns who created, updated or deleted a product and
+// which fields changed, newest first
+func (s *Service) GetProductAuditLog(ctx context.Context, req *pb.GetProductAuditLogRequest) (*pb.GetProductAuditLogResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get product audit log failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	entries, total, err := s.repo.GetProductAuditLog(ctx, req.ProductId, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get product audit log", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get product audit log")
+	}
+
+	// Entries outlive the product, so no entries and no product means an
+	// unknown ID; products created before auditing have an empty log
+	if total == 0 {
+		if _, err := s.repo.GetByID(ctx, req.ProductId); err != nil {
+			return nil, s.productLookupError(ctx, err, req.ProductId)
+		}
+	}
+
+	protoEntries := make([]*pb.ProductAuditEntry, len(entries))
+	for i, e := range entries {
+		protoEntries[i] = toProtoProductAuditEntry(e)
+	}
+
+	return &pb.GetProductAuditLogResponse{
+		Entries:  protoEntries,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// toProtoProductAuditEntry converts an audit entry to protobuf
+func toProtoProductAuditEntry(e *ProductAuditEntry) *pb.ProductAuditEntry {
+	changes := make([]*pb.FieldChange, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = &pb.FieldChange{
+			Field:    c.Field,
+			OldValue: c.Old,
+			NewValue: c.New,
+		}
+	}
+
+	return &pb.ProductAuditEntry{
+		Id:        e.ID,
+		ProductId: e.ProductID,
+		Action:    e.Action,
+		Actor:     e.Actor,
+		Changes:   changes,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+}
@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetProductAuditLog_Success(t *testing.T) {
+	var gotPage, gotPageSize int32
+	mockRepo := &MockRepository{
+		GetProductAuditLogFunc: func(ctx context.Context, productID string, page, pageSize int32) ([]*ProductAuditEntry, int32, error) {
+			gotPage, gotPageSize = page, pageSize
+			return []*ProductAuditEntry{
+				{ID: 2, ProductID: productID, Action: AuditActionUpdate, Actor: "admin-1", CreatedAt: time.Now(),
+					Changes: []FieldChange{{Field: "price", Old: "40", New: "35"}}},
+				{ID: 1, ProductID: productID, Action: AuditActionCreate, Actor: "admin-1", CreatedAt: time.Now(),
+					Changes: []FieldChange{{Field: "name", New: "Lamp"}}},
+			}, 2, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.GetProductAuditLog(context.Background(), &pb.GetProductAuditLogRequest{ProductId: "prod-1", PageSize: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotPage != 1 || gotPageSize != 100 {
+		t.Errorf("Expected page 1 of size 100, got %d of size %d", gotPage, gotPageSize)
+	}
+	if resp.Total != 2 || len(resp.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d of %d", len(resp.Entries), resp.Total)
+	}
+	entry := resp.Entries[0]
+	if entry.Action != AuditActionUpdate || entry.Actor != "admin-1" || len(entry.Changes) != 1 {
+		t.Fatalf("Unexpected entry %v", entry)
+	}
+	if c := entry.Changes[0]; c.Field != "price" || c.OldValue != "40" || c.NewValue != "35" {
+		t.Errorf("Unexpected change %v", c)
+	}
+}
+
+func TestGetProductAuditLog_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetProductAuditLogFunc: func(ctx context.Context, productID string, page, pageSize int32) ([]*ProductAuditEntry, int32, error) {
+			if productID == "broken" {
+				return nil, 0, errors.New("db down")
+			}
+			return []*ProductAuditEntry{}, 0, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if id == "prod-1" {
+				return &Product{ID: id}, nil
+			}
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		name      string
+		productID string
+		expected  codes.Code
+	}{
+		{"missing product ID", "", codes.InvalidArgument},
+		{"unknown product", "missing", codes.NotFound},
+		{"repository error", "broken", codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetProductAuditLog(context.Background(), &pb.GetProductAuditLogRequest{ProductId: tt.productID})
+			if st, _ := status.FromError(err); st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// A product created before auditing has an empty log
+	resp, err := service.GetProductAuditLog(context.Background(), &pb.GetProductAuditLogRequest{ProductId: "prod-1"})
+	if err != nil || len(resp.Entries) != 0 {
+		t.Errorf("Expected an empty log, got %v, %v", resp, err)
+	}
+}
@@ -0,0 +1,54 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffProducts(t *testing.T) {
+	saleEnds := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	salePrice := 35.0
+	before := &Product{Name: "Lamp", SKU: "LAMP-001", Price: 40, Stock: 5, Status: ProductStatusDraft, Images: imagesFromURLs([]string{"a.jpg"})}
+	after := &Product{Name: "Desk Lamp", SKU: "LAMP-001", Price: 40, Stock: 5, Status: ProductStatusActive, Images: imagesFromURLs([]string{"a.jpg", "b.jpg"}),
+		SalePrice: &salePrice, SaleEndsAt: &saleEnds}
+
+	changes := diffProducts(before, after)
+	expected := []FieldChange{
+		{Field: "name", Old: "Lamp", New: "Desk Lamp"},
+		{Field: "status", Old: ProductStatusDraft, New: ProductStatusActive},
+		{Field: "sale_price", Old: "", New: "35"},
+		{Field: "sale_ends_at", Old: "", New: "2026-03-01T00:00:00Z"},
+		{Field: "images", Old: "a.jpg", New: "a.jpg\nb.jpg"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %+v", len(expected), changes)
+	}
+	for i, c := range changes {
+		if c != expected[i] {
+			t.Errorf("Change %d: expected %+v, got %+v", i, expected[i], c)
+		}
+	}
+
+	if changes := diffProducts(before, before); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+func TestDiffProducts_CreateAndDelete(t *testing.T) {
+	product := &Product{Name: "Lamp", SKU: "LAMP-001", Price: 40}
+
+	created := diffProducts(nil, product)
+	if len(created) == 0 || created[0] != (FieldChange{Field: "name", New: "Lamp"}) {
+		t.Errorf("Unexpected create changes %+v", created)
+	}
+	for _, c := range created {
+		if c.Old != "" {
+			t.Errorf("Expected no old values on create, got %+v", c)
+		}
+	}
+
+	deleted := diffProducts(product, nil)
+	if len(deleted) != len(created) || deleted[0] != (FieldChange{Field: "name", Old: "Lamp"}) {
+		t.Errorf("Unexpected delete changes %+v", deleted)
+	}
+}
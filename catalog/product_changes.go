@@ -0,0 +1,245 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+// ProductChangesChannel is the PostgreSQL notification channel product
+// changes are published on
+const ProductChangesChannel = "product_changes"
+
+// Product change types
+const (
+	ProductChangeCreated = "CREATED"
+	ProductChangeUpdated = "UPDATED"
+	ProductChangeDeleted = "DELETED"
+)
+
+var (
+	// ErrSubscriberTooSlow ends a subscription whose buffer is full
+	ErrSubscriberTooSlow = errors.New("subscriber fell behind the product change stream")
+	// ErrChangesLost ends subscriptions when notifications may have been missed
+	ErrChangesLost = errors.New("product change notifications may have been lost")
+	// ErrHubClosed ends subscriptions when the hub shuts down
+	ErrHubClosed = errors.New("product change stream closed")
+)
+
+// ProductChange is a committed change to a product. Product is the product
+// after the change and is nil for deletions.
+type ProductChange struct {
+	Type      string
+	ProductID string
+	Product   *Product
+	At        time.Time
+}
+
+// ProductSubscription receives product changes until it ends
+type ProductSubscription struct {
+	ch  chan ProductChange
+	err error
+}
+
+// Changes returns the channel of changes; it is closed when the subscription ends
+func (s *ProductSubscription) Changes() <-chan ProductChange {
+	return s.ch
+}
+
+// Err returns why the subscription ended, or nil when it was unsubscribed.
+// It is valid once Changes is closed.
+func (s *ProductSubscription) Err() error {
+	return s.err
+}
+
+// ProductChangeHub fans product changes out to subscribers. A subscriber that
+// does not keep up is dropped rather than slowing down the others.
+type ProductChangeHub struct {
+	mu     sync.Mutex
+	subs   map[*ProductSubscription]struct{}
+	buffer int
+	closed bool
+}
+
+// NewProductChangeHub creates a hub buffering up to buffer changes per subscriber
+func NewProductChangeHub(buffer int) *ProductChangeHub {
+	if buffer < 1 {
+		buffer = 1
+	}
+	return &ProductChangeHub{
+		subs:   make(map[*ProductSubscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe starts a subscription to changes published from now on
+func (h *ProductChangeHub) Subscribe() *ProductSubscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &ProductSubscription{ch: make(chan ProductChange, h.buffer)}
+	if h.closed {
+		sub.err = ErrHubClosed
+		close(sub.ch)
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe ends a subscription
+func (h *ProductChangeHub) Unsubscribe(sub *ProductSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.end(sub, nil)
+}
+
+// Publish delivers a change to every subscriber
+func (h *ProductChangeHub) Publish(change ProductChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		select {
+		case sub.ch <- change:
+		default:
+			h.end(sub, ErrSubscriberTooSlow)
+		}
+	}
+}
+
+// Interrupt ends every current subscription with err, so subscribers know to
+// resynchronize
+func (h *ProductChangeHub) Interrupt(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		h.end(sub, err)
+	}
+}
+
+// Close ends every subscription and rejects new ones
+func (h *ProductChangeHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		h.end(sub, ErrHubClosed)
+	}
+}
+
+// end removes a subscription and closes its channel; h.mu must be held
+func (h *ProductChangeHub) end(sub *ProductSubscription, err error) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	sub.err = err
+	close(sub.ch)
+}
+
+// productChangeNotification is the payload of notify_product_change
+type productChangeNotification struct {
+	Op string    `json:"op"`
+	ID string    `json:"id"`
+	At time.Time `json:"at"`
+}
+
+// productChangeTypes maps trigger operations to change types
+var productChangeTypes = map[string]string{
+	"INSERT": ProductChangeCreated,
+	"UPDATE": ProductChangeUpdated,
+	"DELETE": ProductChangeDeleted,
+}
+
+// ProductChangeNotifier listens for product change notifications from
+// PostgreSQL and publishes them, with the changed product, to a hub
+type ProductChangeNotifier struct {
+	dbURL string
+	repo  Repository
+	hub   *ProductChangeHub
+	log   *logger.Logger
+}
+
+// NewProductChangeNotifier creates a notifier listening on the database at dbURL
+func NewProductChangeNotifier(dbURL string, repo Repository, hub *ProductChangeHub, log *logger.Logger) *ProductChangeNotifier {
+	return &ProductChangeNotifier{
+		dbURL: dbURL,
+		repo:  repo,
+		hub:   hub,
+		log:   log,
+	}
+}
+
+// Run relays notifications until ctx is cancelled. The listener reconnects on
+// its own; notifications sent while it was disconnected are lost, so
+// subscriptions are interrupted after each reconnect.
+func (n *ProductChangeNotifier) Run(ctx context.Context) error {
+	listener := pq.NewListener(n.dbURL, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			n.log.Warn(ctx, "Product change listener error", map[string]interface{}{"event": event, "error": err.Error()})
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(ProductChangesChannel); err != nil {
+		n.log.Error(ctx, "Failed to listen for product changes", map[string]interface{}{"error": err.Error()})
+		return err
+	}
+
+	n.relay(ctx, listener.Notify)
+	return nil
+}
+
+// relay publishes notifications until ctx is cancelled or notifications is closed
+func (n *ProductChangeNotifier) relay(ctx context.Context, notifications <-chan *pq.Notification) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+			// A nil notification follows a reconnect
+			if notification == nil {
+				n.log.Warn(ctx, "Product change listener reconnected; interrupting watchers", nil)
+				n.hub.Interrupt(ErrChangesLost)
+				continue
+			}
+			n.handle(ctx, notification.Extra)
+		}
+	}
+}
+
+// handle publishes the change described by a notification payload
+func (n *ProductChangeNotifier) handle(ctx context.Context, payload string) {
+	var msg productChangeNotification
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		n.log.Warn(ctx, "Invalid product change notification", map[string]interface{}{"error": err.Error(), "payload": payload})
+		return
+	}
+	changeType, ok := productChangeTypes[msg.Op]
+	if !ok {
+		n.log.Warn(ctx, "Unknown product change operation", map[string]interface{}{"op": msg.Op})
+		return
+	}
+
+	change := ProductChange{Type: changeType, ProductID: msg.ID, At: msg.At}
+	if changeType != ProductChangeDeleted {
+		product, err := n.repo.GetByID(ctx, msg.ID)
+		if err != nil {
+			// The product may have been deleted since; its deletion follows
+			n.log.Warn(ctx, "Failed to load changed product", map[string]interface{}{"error": err.Error(), "product_id": msg.ID})
+		}
+		change.Product = product
+	}
+	n.hub.Publish(change)
+}
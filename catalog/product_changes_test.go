@@ -0,0 +1,102 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func TestProductChangeHub_FanOut(t *testing.T) {
+	hub := NewProductChangeHub(2)
+	a, b := hub.Subscribe(), hub.Subscribe()
+
+	hub.Publish(ProductChange{Type: ProductChangeUpdated, ProductID: "prod-1"})
+	for _, sub := range []*ProductSubscription{a, b} {
+		if change := <-sub.Changes(); change.ProductID != "prod-1" {
+			t.Errorf("Expected prod-1, got %+v", change)
+		}
+	}
+
+	hub.Unsubscribe(a)
+	if _, ok := <-a.Changes(); ok || a.Err() != nil {
+		t.Errorf("Expected unsubscribed channel to close without error, got %v", a.Err())
+	}
+	hub.Publish(ProductChange{Type: ProductChangeDeleted, ProductID: "prod-1"})
+	if change := <-b.Changes(); change.Type != ProductChangeDeleted {
+		t.Errorf("Expected deletion, got %+v", change)
+	}
+}
+
+func TestProductChangeHub_DropsSlowSubscriber(t *testing.T) {
+	hub := NewProductChangeHub(1)
+	slow := hub.Subscribe()
+
+	hub.Publish(ProductChange{ProductID: "prod-1"})
+	hub.Publish(ProductChange{ProductID: "prod-2"})
+
+	if change := <-slow.Changes(); change.ProductID != "prod-1" {
+		t.Errorf("Expected buffered change, got %+v", change)
+	}
+	if _, ok := <-slow.Changes(); ok {
+		t.Fatal("Expected subscription to end")
+	}
+	if !errors.Is(slow.Err(), ErrSubscriberTooSlow) {
+		t.Errorf("Expected ErrSubscriberTooSlow, got %v", slow.Err())
+	}
+}
+
+func TestProductChangeHub_Close(t *testing.T) {
+	hub := NewProductChangeHub(1)
+	sub := hub.Subscribe()
+	hub.Close()
+
+	if _, ok := <-sub.Changes(); ok || !errors.Is(sub.Err(), ErrHubClosed) {
+		t.Errorf("Expected ErrHubClosed, got %v", sub.Err())
+	}
+	late := hub.Subscribe()
+	if _, ok := <-late.Changes(); ok || !errors.Is(late.Err(), ErrHubClosed) {
+		t.Errorf("Expected new subscriptions to be rejected, got %v", late.Err())
+	}
+	hub.Unsubscribe(sub)
+}
+
+func TestProductChangeNotifier_Relay(t *testing.T) {
+	var loaded []string
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			loaded = append(loaded, id)
+			return &Product{ID: id, Name: "Lamp"}, nil
+		},
+	}
+	hub := NewProductChangeHub(10)
+	sub := hub.Subscribe()
+	notifier := NewProductChangeNotifier("", mockRepo, hub, logger.New("catalog-test"))
+
+	notifications := make(chan *pq.Notification, 4)
+	notifications <- &pq.Notification{Extra: `{"op":"INSERT","id":"prod-1","at":"2024-05-01T10:00:00.5+00:00"}`}
+	notifications <- &pq.Notification{Extra: `not json`}
+	notifications <- &pq.Notification{Extra: `{"op":"DELETE","id":"prod-2","at":"2024-05-01T10:00:01+00:00"}`}
+	notifications <- nil
+	close(notifications)
+	notifier.relay(context.Background(), notifications)
+
+	created := <-sub.Changes()
+	if created.Type != ProductChangeCreated || created.Product == nil || created.Product.Name != "Lamp" || created.At.IsZero() {
+		t.Errorf("Unexpected created change %+v", created)
+	}
+	deleted := <-sub.Changes()
+	if deleted.Type != ProductChangeDeleted || deleted.ProductID != "prod-2" || deleted.Product != nil {
+		t.Errorf("Unexpected deleted change %+v", deleted)
+	}
+	if len(loaded) != 1 {
+		t.Errorf("Expected only the created product to be loaded, got %v", loaded)
+	}
+
+	// The reconnect interrupted the subscription
+	if _, ok := <-sub.Changes(); ok || !errors.Is(sub.Err(), ErrChangesLost) {
+		t.Errorf("Expected ErrChangesLost, got %v", sub.Err())
+	}
+}
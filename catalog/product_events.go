@@ -0,0 +1,78 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/outbox"
+)
+
+const (
+	// EventsTopic is the Kafka topic product events are published to
+	EventsTopic = "catalog-events"
+	// OutboxTable holds product events until they are published
+	OutboxTable = "catalog_outbox"
+)
+
+// Product event types
+const (
+	EventProductCreated = "product.created"
+	EventProductUpdated = "product.updated"
+	EventProductDeleted = "product.deleted"
+)
+
+// EventImageUploaded is published when an uploaded image is attached to a
+// product; the image service makes its renditions
+const EventImageUploaded = "product.image_uploaded"
+
+// productEventTypes maps audit actions to the event published for them
+var productEventTypes = map[string]string{
+	AuditActionCreate: EventProductCreated,
+	AuditActionUpdate: EventProductUpdated,
+	AuditActionDelete: EventProductDeleted,
+}
+
+// events is the outbox product mutations add their events to
+var events = outbox.New(OutboxTable, EventsTopic)
+
+// productEventData is the data of a product event: the changed fields, as in
+// the audit log
+type productEventData struct {
+	ProductID string        `json:"product_id"`
+	Actor     string        `json:"actor"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// recordProductEvent adds the event of a product mutation within its
+// transaction, so that the event is published if and only if the mutation
+// commits
+func recordProductEvent(ctx context.Context, tx outbox.Execer, productID, action, actor string, changes []FieldChange, at time.Time) error {
+	if changes == nil {
+		changes = []FieldChange{}
+	}
+	return events.Add(ctx, tx, outbox.Event{
+		Type:       productEventTypes[action],
+		Key:        productID,
+		OccurredAt: at,
+		Data:       productEventData{ProductID: productID, Actor: actor, Changes: changes},
+	})
+}
+
+// imageUploadedEventData is the data of a product.image_uploaded event
+type imageUploadedEventData struct {
+	ProductID string `json:"product_id"`
+	ImageID   string `json:"image_id"`
+	ObjectKey string `json:"object_key"`
+	URL       string `json:"url"`
+}
+
+// recordImageUploaded adds the event of an attached upload within its
+// transaction
+func recordImageUploaded(ctx context.Context, tx outbox.Execer, productID, imageID, objectKey, imageURL string, at time.Time) error {
+	return events.Add(ctx, tx, outbox.Event{
+		Type:       EventImageUploaded,
+		Key:        productID,
+		OccurredAt: at,
+		Data:       imageUploadedEventData{ProductID: productID, ImageID: imageID, ObjectKey: objectKey, URL: imageURL},
+	})
+}
@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// metricsService labels the catalog's database metrics
+const metricsService = "catalog-service"
+
+// errNotPgx is returned inside queryProductPage when the connection does not
+// belong to the pgx driver, so the queries cannot be batched
+var errNotPgx = errors.New("connection is not a pgx connection")
+
+// productRows is implemented by *sql.Rows and pgx.Rows
+type productRows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+// queryRow runs a single-row query and records its duration under queryType.
+// The pgx driver prepares the query on first use and reuses the statement
+// for later calls on the same connection.
+func (r *postgresRepository) queryRow(ctx context.Context, queryType, query string, args ...interface{}) *sql.Row {
+	defer observeQuery(queryType, time.Now())
+	return r.db.QueryRowContext(ctx, query, args...)
+}
+
+// queryProductPage runs the count query and the page query of a product
+// listing and records their duration under queryType. On a pgx connection
+// both are sent as one batch, so a page costs a single round trip; other
+// drivers, such as the test mocks, run them one after the other.
+func (r *postgresRepository) queryProductPage(ctx context.Context, queryType, countQuery string, countArgs []interface{}, pageQuery string, pageArgs []interface{}, pageSize int32) ([]*Product, int32, error) {
+	defer observeQuery(queryType, time.Now())
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	var products []*Product
+	var total int32
+	err = conn.Raw(func(driverConn interface{}) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errNotPgx
+		}
+
+		batch := &pgx.Batch{}
+		batch.Queue(countQuery, countArgs...)
+		batch.Queue(pageQuery, pageArgs...)
+		results := pgxConn.Conn().SendBatch(ctx, batch)
+		defer results.Close()
+
+		if err := results.QueryRow().Scan(&total); err != nil {
+			return fmt.Errorf("failed to count products: %w", err)
+		}
+		rows, err := results.Query()
+		if err != nil {
+			return fmt.Errorf("failed to query products: %w", err)
+		}
+		defer rows.Close()
+		products, err = scanProducts(rows, pageSize)
+		return err
+	})
+	if !errors.Is(err, errNotPgx) {
+		return products, total, err
+	}
+
+	if err := conn.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count products: %w", err)
+	}
+	rows, err := conn.QueryContext(ctx, pageQuery, pageArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query products: %w", err)
+	}
+	defer rows.Close()
+	products, err = scanProducts(rows, pageSize)
+	return products, total, err
+}
+
+// scanProducts scans every row of a page of products
+func scanProducts(rows productRows, pageSize int32) ([]*Product, error) {
+	products := make([]*Product, 0, pageSize)
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating products: %w", err)
+	}
+	return products, nil
+}
+
+// observeQuery records the duration of a query started at start
+func observeQuery(queryType string, start time.Time) {
+	metrics.DBQueryDuration.WithLabelValues(metricsService, queryType).Observe(time.Since(start).Seconds())
+}
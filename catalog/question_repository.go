@@ -0,0 +1,288 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/lib/pq"
+)
+
+// Moderation statuses of questions and answers
+const (
+	ModerationPending  = "PENDING"
+	ModerationApproved = "APPROVED"
+	ModerationRejected = "REJECTED"
+)
+
+var (
+	// ErrQuestionNotFound is returned when a question does not exist
+	ErrQuestionNotFound = errors.New("question not found")
+	// ErrAnswerNotFound is returned when an answer does not exist
+	ErrAnswerNotFound = errors.New("answer not found")
+)
+
+// ProductQuestion is a customer question about a product
+type ProductQuestion struct {
+	ID          string
+	ProductID   string
+	UserID      string
+	Body        string
+	Status      string
+	ModeratedBy string
+	ModeratedAt *time.Time
+	CreatedAt   time.Time
+	// Answers are filled in by ListQuestions, oldest first
+	Answers []*ProductAnswer
+}
+
+// ProductAnswer is a customer answer to a product question
+type ProductAnswer struct {
+	ID          string
+	QuestionID  string
+	UserID      string
+	Body        string
+	Status      string
+	ModeratedBy string
+	ModeratedAt *time.Time
+	CreatedAt   time.Time
+}
+
+const questionColumns = "id, product_id, user_id, body, status, moderated_by, moderated_at, created_at"
+
+const answerColumns = "id, question_id, user_id, body, status, moderated_by, moderated_at, created_at"
+
+// CreateQuestion inserts a question
+func (r *postgresRepository) CreateQuestion(ctx context.Context, q *ProductQuestion) (*ProductQuestion, error) {
+	query := `
+		INSERT INTO product_questions (product_id, user_id, body, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + questionColumns
+
+	created, err := scanQuestion(r.db.QueryRowContext(ctx, query, q.ProductID, q.UserID, q.Body, q.Status, q.CreatedAt))
+	if err != nil {
+		r.log.Error(ctx, "Failed to create question", map[string]interface{}{"error": err.Error(), "product_id": q.ProductID})
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+
+	r.log.Info(ctx, "Question created", map[string]interface{}{"question_id": created.ID, "product_id": created.ProductID})
+	return created, nil
+}
+
+// GetQuestion retrieves a question by ID, without its answers
+func (r *postgresRepository) GetQuestion(ctx context.Context, id string) (*ProductQuestion, error) {
+	query := "SELECT " + questionColumns + " FROM product_questions WHERE id = $1"
+
+	q, err := scanQuestion(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrQuestionNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get question", map[string]interface{}{"error": err.Error(), "question_id": id})
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	return q, nil
+}
+
+// CreateAnswer inserts an answer to a question
+func (r *postgresRepository) CreateAnswer(ctx context.Context, a *ProductAnswer) (*ProductAnswer, error) {
+	query := `
+		INSERT INTO product_answers (question_id, user_id, body, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + answerColumns
+
+	created, err := scanAnswer(r.db.QueryRowContext(ctx, query, a.QuestionID, a.UserID, a.Body, a.Status, a.CreatedAt))
+	if err != nil {
+		r.log.Error(ctx, "Failed to create answer", map[string]interface{}{"error": err.Error(), "question_id": a.QuestionID})
+		return nil, fmt.Errorf("failed to create answer: %w", err)
+	}
+
+	r.log.Info(ctx, "Answer created", map[string]interface{}{"answer_id": created.ID, "question_id": created.QuestionID})
+	return created, nil
+}
+
+// ModerateQuestion sets the moderation status of a question
+func (r *postgresRepository) ModerateQuestion(ctx context.Context, id, status, actor string, at time.Time) (*ProductQuestion, error) {
+	query := `
+		UPDATE product_questions
+		SET status = $2, moderated_by = $3, moderated_at = $4
+		WHERE id = $1
+		RETURNING ` + questionColumns
+
+	q, err := scanQuestion(r.db.QueryRowContext(ctx, query, id, status, actor, at))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrQuestionNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to moderate question", map[string]interface{}{"error": err.Error(), "question_id": id})
+		return nil, fmt.Errorf("failed to moderate question: %w", err)
+	}
+
+	r.log.Info(ctx, "Question moderated", map[string]interface{}{"question_id": id, "status": status, "actor": actor})
+	return q, nil
+}
+
+// ModerateAnswer sets the moderation status of an answer
+func (r *postgresRepository) ModerateAnswer(ctx context.Context, id, status, actor string, at time.Time) (*ProductAnswer, error) {
+	query := `
+		UPDATE product_answers
+		SET status = $2, moderated_by = $3, moderated_at = $4
+		WHERE id = $1
+		RETURNING ` + answerColumns
+
+	a, err := scanAnswer(r.db.QueryRowContext(ctx, query, id, status, actor, at))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrAnswerNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to moderate answer", map[string]interface{}{"error": err.Error(), "answer_id": id})
+		return nil, fmt.Errorf("failed to moderate answer: %w", err)
+	}
+
+	r.log.Info(ctx, "Answer moderated", map[string]interface{}{"answer_id": id, "status": status, "actor": actor})
+	return a, nil
+}
+
+// ListQuestions retrieves a product's questions in a status with pagination,
+// newest first, together with their answers in answerStatus, oldest first.
+// An empty answerStatus includes answers in every status.
+func (r *postgresRepository) ListQuestions(ctx context.Context, productID, status, answerStatus string, page, pageSize int32) ([]*ProductQuestion, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM product_questions WHERE product_id = $1 AND status = $2", productID, status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count questions", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, 0, fmt.Errorf("failed to count questions: %w", err)
+	}
+
+	query := `
+		SELECT ` + questionColumns + `
+		FROM product_questions
+		WHERE product_id = $1 AND status = $2
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list questions", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, 0, fmt.Errorf("failed to list questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := make([]*ProductQuestion, 0, pageSize)
+	byID := map[string]*ProductQuestion{}
+	ids := []string{}
+	for rows.Next() {
+		q, err := scanQuestion(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan question", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan question: %w", err)
+		}
+		q.Answers = []*ProductAnswer{}
+		questions = append(questions, q)
+		byID[q.ID] = q
+		ids = append(ids, q.ID)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating questions", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating questions: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return questions, total, nil
+	}
+
+	answers, err := r.queryAnswers(ctx, ids, answerStatus)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list answers", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, 0, err
+	}
+	for _, a := range answers {
+		if q := byID[a.QuestionID]; q != nil {
+			q.Answers = append(q.Answers, a)
+		}
+	}
+
+	return questions, total, nil
+}
+
+// queryAnswers retrieves the answers to the given questions, oldest first,
+// restricted to status unless it is empty
+func (r *postgresRepository) queryAnswers(ctx context.Context, questionIDs []string, status string) ([]*ProductAnswer, error) {
+	query := `
+		SELECT ` + answerColumns + `
+		FROM product_answers
+		WHERE question_id = ANY($1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(questionIDs), status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query answers: %w", err)
+	}
+	defer rows.Close()
+
+	answers := []*ProductAnswer{}
+	for rows.Next() {
+		a, err := scanAnswer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan answer: %w", err)
+		}
+		answers = append(answers, a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating answers: %w", err)
+	}
+	return answers, nil
+}
+
+// scanQuestion scans a question selected with questionColumns
+func scanQuestion(row rowScanner) (*ProductQuestion, error) {
+	q := &ProductQuestion{}
+	var moderatedBy sql.NullString
+	var moderatedAt sql.NullTime
+
+	err := row.Scan(&q.ID, &q.ProductID, &q.UserID, &q.Body, &q.Status, &moderatedBy, &moderatedAt, &q.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	q.ModeratedBy = moderatedBy.String
+	if moderatedAt.Valid {
+		q.ModeratedAt = &moderatedAt.Time
+	}
+	return q, nil
+}
+
+// scanAnswer scans an answer selected with answerColumns
+func scanAnswer(row rowScanner) (*ProductAnswer, error) {
+	a := &ProductAnswer{}
+	var moderatedBy sql.NullString
+	var moderatedAt sql.NullTime
+
+	err := row.Scan(&a.ID, &a.QuestionID, &a.UserID, &a.Body, &a.Status, &moderatedBy, &moderatedAt, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	a.ModeratedBy = moderatedBy.String
+	if moderatedAt.Valid {
+		a.ModeratedAt = &moderatedAt.Time
+	}
+	return a, nil
+}
@@ -0,0 +1,280 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Length limits of questions and answers, in characters
+const (
+	maxQuestionLength = 1000
+	maxAnswerLength   = 2000
+)
+
+// maxUserIDLength matches the user_id columns of questions, answers and
+// back-in-stock subscriptions
+const maxUserIDLength = 255
+
+// AskQuestion submits a customer question about a product. The question is
+// PENDING until a moderator approves it. Customers ask as the user of their
+// token; ADMIN and SERVICE tokens name the asker in user_id.
+func (s *Service) AskQuestion(ctx context.Context, req *pb.AskQuestionRequest) (*pb.AskQuestionResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Ask question failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	userID, err := auth.ActingUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "Ask question failed: "+status.Convert(err).Message(), map[string]interface{}{"product_id": req.ProductId})
+		return nil, err
+	}
+	body, msg := postFromRequest(userID, req.Body, maxQuestionLength)
+	if msg != "" {
+		s.log.Warn(ctx, "Ask question failed: "+msg, map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	if _, err := s.repo.GetByID(ctx, req.ProductId); err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+
+	question, err := s.repo.CreateQuestion(ctx, &ProductQuestion{
+		ProductID: req.ProductId,
+		UserID:    userID,
+		Body:      body,
+		Status:    ModerationPending,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to create question", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to create question")
+	}
+
+	return &pb.AskQuestionResponse{
+		Question: toProtoQuestion(question),
+	}, nil
+}
+
+// AnswerQuestion submits a customer answer to an approved question. The
+// answer is PENDING until a moderator approves it. Customers answer as the
+// user of their token, like AskQuestion.
+func (s *Service) AnswerQuestion(ctx context.Context, req *pb.AnswerQuestionRequest) (*pb.AnswerQuestionResponse, error) {
+	if req.QuestionId == "" {
+		s.log.Warn(ctx, "Answer question failed: question ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "question_id is required")
+	}
+	userID, err := auth.ActingUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "Answer question failed: "+status.Convert(err).Message(), map[string]interface{}{"question_id": req.QuestionId})
+		return nil, err
+	}
+	body, msg := postFromRequest(userID, req.Body, maxAnswerLength)
+	if msg != "" {
+		s.log.Warn(ctx, "Answer question failed: "+msg, map[string]interface{}{"question_id": req.QuestionId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	question, err := s.repo.GetQuestion(ctx, req.QuestionId)
+	if err != nil {
+		return nil, s.questionError(ctx, err, req.QuestionId)
+	}
+	if question.Status != ModerationApproved {
+		s.log.Warn(ctx, "Answer question failed: question is not approved", map[string]interface{}{"question_id": req.QuestionId, "status": question.Status})
+		return nil, status.Error(codes.FailedPrecondition, "question is not approved")
+	}
+
+	answer, err := s.repo.CreateAnswer(ctx, &ProductAnswer{
+		QuestionID: question.ID,
+		UserID:     userID,
+		Body:       body,
+		Status:     ModerationPending,
+		CreatedAt:  s.now(),
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to create answer", map[string]interface{}{"error": err.Error(), "question_id": req.QuestionId})
+		return nil, status.Error(codes.Internal, "failed to create answer")
+	}
+
+	return &pb.AnswerQuestionResponse{
+		Answer: toProtoAnswer(answer),
+	}, nil
+}
+
+// ModerateQuestion approves or rejects a question
+func (s *Service) ModerateQuestion(ctx context.Context, req *pb.ModerateQuestionRequest) (*pb.ModerateQuestionResponse, error) {
+	if req.QuestionId == "" {
+		s.log.Warn(ctx, "Moderate question failed: question ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "question_id is required")
+	}
+	if !isModerationDecision(req.Status) {
+		return nil, status.Error(codes.InvalidArgument, "status must be APPROVED or REJECTED")
+	}
+
+	question, err := s.repo.ModerateQuestion(ctx, req.QuestionId, req.Status, actorFromContext(ctx), s.now())
+	if err != nil {
+		return nil, s.questionError(ctx, err, req.QuestionId)
+	}
+
+	return &pb.ModerateQuestionResponse{
+		Question: toProtoQuestion(question),
+	}, nil
+}
+
+// ModerateAnswer approves or rejects an answer
+func (s *Service) ModerateAnswer(ctx context.Context, req *pb.ModerateAnswerRequest) (*pb.ModerateAnswerResponse, error) {
+	if req.AnswerId == "" {
+		s.log.Warn(ctx, "Moderate answer failed: answer ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "answer_id is required")
+	}
+	if !isModerationDecision(req.Status) {
+		return nil, status.Error(codes.InvalidArgument, "status must be APPROVED or REJECTED")
+	}
+
+	answer, err := s.repo.ModerateAnswer(ctx, req.AnswerId, req.Status, actorFromContext(ctx), s.now())
+	if errors.Is(err, ErrAnswerNotFound) {
+		s.log.Warn(ctx, "Answer not found", map[string]interface{}{"answer_id": req.AnswerId})
+		return nil, status.Error(codes.NotFound, "answer not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to moderate answer", map[string]interface{}{"error": err.Error(), "answer_id": req.AnswerId})
+		return nil, status.Error(codes.Internal, "failed to moderate answer")
+	}
+
+	return &pb.ModerateAnswerResponse{
+		Answer: toProtoAnswer(answer),
+	}, nil
+}
+
+// ListQuestions lists a product's questions, newest first. Without a status
+// it lists approved questions with their approved answers; with one it is a
+// moderation queue and includes answers in every status.
+func (s *Service) ListQuestions(ctx context.Context, req *pb.ListQuestionsRequest) (*pb.ListQuestionsResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "List questions failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	questionStatus, answerStatus := ModerationApproved, ModerationApproved
+	if req.Status != "" {
+		if req.Status != ModerationPending && !isModerationDecision(req.Status) {
+			return nil, status.Error(codes.InvalidArgument, "status must be PENDING, APPROVED or REJECTED")
+		}
+		questionStatus, answerStatus = req.Status, ""
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	if _, err := s.repo.GetByID(ctx, req.ProductId); err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+
+	questions, total, err := s.repo.ListQuestions(ctx, req.ProductId, questionStatus, answerStatus, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list questions", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to list questions")
+	}
+
+	protoQuestions := make([]*pb.ProductQuestion, len(questions))
+	for i, q := range questions {
+		protoQuestions[i] = toProtoQuestion(q)
+	}
+
+	return &pb.ListQuestionsResponse{
+		Questions: protoQuestions,
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+	}, nil
+}
+
+// postFromRequest validates the author and body of a question or answer,
+// returning the trimmed body
+func postFromRequest(userID, body string, maxLength int) (string, string) {
+	if userID == "" {
+		return "", "user_id is required"
+	}
+	if len(userID) > maxUserIDLength {
+		return "", "user_id is too long"
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", "body is required"
+	}
+	if utf8.RuneCountInString(body) > maxLength {
+		return "", fmt.Sprintf("body cannot exceed %d characters", maxLength)
+	}
+	return body, ""
+}
+
+// isModerationDecision reports whether status is one a moderator can set
+func isModerationDecision(status string) bool {
+	return status == ModerationApproved || status == ModerationRejected
+}
+
+// questionError maps a question lookup or moderation failure to a gRPC error
+func (s *Service) questionError(ctx context.Context, err error, questionID string) error {
+	if errors.Is(err, ErrQuestionNotFound) {
+		s.log.Warn(ctx, "Question not found", map[string]interface{}{"question_id": questionID})
+		return status.Error(codes.NotFound, "question not found")
+	}
+	s.log.Error(ctx, "Failed to get question", map[string]interface{}{"error": err.Error(), "question_id": questionID})
+	return status.Error(codes.Internal, "failed to get question")
+}
+
+// toProtoQuestion converts a question and any loaded answers to protobuf
+func toProtoQuestion(q *ProductQuestion) *pb.ProductQuestion {
+	question := &pb.ProductQuestion{
+		Id:          q.ID,
+		ProductId:   q.ProductID,
+		UserId:      q.UserID,
+		Body:        q.Body,
+		Status:      q.Status,
+		ModeratedBy: q.ModeratedBy,
+		CreatedAt:   timestamppb.New(q.CreatedAt),
+		Answers:     make([]*pb.ProductAnswer, len(q.Answers)),
+	}
+	if q.ModeratedAt != nil {
+		question.ModeratedAt = timestamppb.New(*q.ModeratedAt)
+	}
+	for i, a := range q.Answers {
+		question.Answers[i] = toProtoAnswer(a)
+	}
+	return question
+}
+
+// toProtoAnswer converts an answer to protobuf
+func toProtoAnswer(a *ProductAnswer) *pb.ProductAnswer {
+	answer := &pb.ProductAnswer{
+		Id:          a.ID,
+		QuestionId:  a.QuestionID,
+		UserId:      a.UserID,
+		Body:        a.Body,
+		Status:      a.Status,
+		ModeratedBy: a.ModeratedBy,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+	}
+	if a.ModeratedAt != nil {
+		answer.ModeratedAt = timestamppb.New(*a.ModeratedAt)
+	}
+	return answer
+}
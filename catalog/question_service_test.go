@@ -0,0 +1,296 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAskQuestion_Success(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var got *ProductQuestion
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id}, nil
+		},
+		CreateQuestionFunc: func(ctx context.Context, q *ProductQuestion) (*ProductQuestion, error) {
+			got = q
+			saved := *q
+			saved.ID = "q-1"
+			return &saved, nil
+		},
+	}
+	service := setupService(mockRepo)
+	service.now = func() time.Time { return now }
+
+	resp, err := service.AskQuestion(callerContext("user-1", auth.RoleUser), &pb.AskQuestionRequest{
+		ProductId: "prod-1",
+		Body:      "  Does it fit a 15-inch laptop?  ",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.UserID != "user-1" || got.Body != "Does it fit a 15-inch laptop?" || got.Status != ModerationPending || !got.CreatedAt.Equal(now) {
+		t.Errorf("Unexpected question %+v", got)
+	}
+	if q := resp.Question; q.Id != "q-1" || q.Status != ModerationPending || q.ModeratedAt != nil || len(q.Answers) != 0 {
+		t.Errorf("Unexpected response %v", q)
+	}
+}
+
+func TestAskQuestion_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	admin := callerContext("admin-1", auth.RoleAdmin)
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.AskQuestionRequest
+		expected codes.Code
+	}{
+		{"missing product", admin, &pb.AskQuestionRequest{UserId: "user-1", Body: "Is it waterproof?"}, codes.InvalidArgument},
+		{"missing user", admin, &pb.AskQuestionRequest{ProductId: "prod-1", Body: "Is it waterproof?"}, codes.InvalidArgument},
+		{"blank body", admin, &pb.AskQuestionRequest{ProductId: "prod-1", UserId: "user-1", Body: "   "}, codes.InvalidArgument},
+		{"body too long", admin, &pb.AskQuestionRequest{ProductId: "prod-1", UserId: "user-1", Body: strings.Repeat("a", maxQuestionLength+1)}, codes.InvalidArgument},
+		{"unknown product", admin, &pb.AskQuestionRequest{ProductId: "prod-1", UserId: "user-1", Body: "Is it waterproof?"}, codes.NotFound},
+		{"no token", context.Background(), &pb.AskQuestionRequest{ProductId: "prod-1", UserId: "user-1", Body: "Is it waterproof?"}, codes.Unauthenticated},
+		{"another customer", callerContext("user-2", auth.RoleUser), &pb.AskQuestionRequest{ProductId: "prod-1", UserId: "user-1", Body: "Is it waterproof?"}, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AskQuestion(tt.ctx, tt.req)
+			if st, _ := status.FromError(err); st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestAnswerQuestion_Success(t *testing.T) {
+	var got *ProductAnswer
+	mockRepo := &MockRepository{
+		GetQuestionFunc: func(ctx context.Context, id string) (*ProductQuestion, error) {
+			return &ProductQuestion{ID: id, ProductID: "prod-1", Status: ModerationApproved}, nil
+		},
+		CreateAnswerFunc: func(ctx context.Context, a *ProductAnswer) (*ProductAnswer, error) {
+			got = a
+			saved := *a
+			saved.ID = "a-1"
+			return &saved, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.AnswerQuestion(callerContext("user-2", auth.RoleUser), &pb.AnswerQuestionRequest{
+		QuestionId: "q-1",
+		Body:       "Yes, mine fits easily.",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.QuestionID != "q-1" || got.UserID != "user-2" || got.Status != ModerationPending {
+		t.Errorf("Unexpected answer %+v", got)
+	}
+	if resp.Answer.Id != "a-1" || resp.Answer.QuestionId != "q-1" {
+		t.Errorf("Unexpected response %v", resp.Answer)
+	}
+}
+
+func TestAnswerQuestion_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetQuestionFunc: func(ctx context.Context, id string) (*ProductQuestion, error) {
+			switch id {
+			case "pending":
+				return &ProductQuestion{ID: id, Status: ModerationPending}, nil
+			case "broken":
+				return nil, errors.New("db down")
+			}
+			return nil, ErrQuestionNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	customer := callerContext("user-1", auth.RoleUser)
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.AnswerQuestionRequest
+		expected codes.Code
+	}{
+		{"missing question", customer, &pb.AnswerQuestionRequest{Body: "Yes"}, codes.InvalidArgument},
+		{"missing body", customer, &pb.AnswerQuestionRequest{QuestionId: "q-1"}, codes.InvalidArgument},
+		{"body too long", customer, &pb.AnswerQuestionRequest{QuestionId: "q-1", Body: strings.Repeat("a", maxAnswerLength+1)}, codes.InvalidArgument},
+		{"unknown question", customer, &pb.AnswerQuestionRequest{QuestionId: "missing", Body: "Yes"}, codes.NotFound},
+		{"unapproved question", customer, &pb.AnswerQuestionRequest{QuestionId: "pending", Body: "Yes"}, codes.FailedPrecondition},
+		{"repository error", customer, &pb.AnswerQuestionRequest{QuestionId: "broken", Body: "Yes"}, codes.Internal},
+		{"no token", context.Background(), &pb.AnswerQuestionRequest{QuestionId: "q-1", UserId: "user-1", Body: "Yes"}, codes.Unauthenticated},
+		{"another customer", customer, &pb.AnswerQuestionRequest{QuestionId: "q-1", UserId: "user-2", Body: "Yes"}, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AnswerQuestion(tt.ctx, tt.req)
+			if st, _ := status.FromError(err); st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestModerateQuestion_Success(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var gotActor string
+	mockRepo := &MockRepository{
+		ModerateQuestionFunc: func(ctx context.Context, id, status, actor string, at time.Time) (*ProductQuestion, error) {
+			gotActor = actor
+			return &ProductQuestion{ID: id, Status: status, ModeratedBy: actor, ModeratedAt: &at}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	service.now = func() time.Time { return now }
+
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "moderator-1", Role: auth.RoleAdmin})
+	resp, err := service.ModerateQuestion(ctx, &pb.ModerateQuestionRequest{QuestionId: "q-1", Status: ModerationApproved})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotActor != "moderator-1" {
+		t.Errorf("Expected actor moderator-1, got %q", gotActor)
+	}
+	if q := resp.Question; q.Status != ModerationApproved || q.ModeratedBy != "moderator-1" || !q.ModeratedAt.AsTime().Equal(now) {
+		t.Errorf("Unexpected question %v", q)
+	}
+}
+
+func TestModerate_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		ModerateQuestionFunc: func(ctx context.Context, id, status, actor string, at time.Time) (*ProductQuestion, error) {
+			return nil, ErrQuestionNotFound
+		},
+		ModerateAnswerFunc: func(ctx context.Context, id, status, actor string, at time.Time) (*ProductAnswer, error) {
+			return nil, ErrAnswerNotFound
+		},
+	}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func() error
+		expected codes.Code
+	}{
+		{"question without ID", func() error {
+			_, err := service.ModerateQuestion(ctx, &pb.ModerateQuestionRequest{Status: ModerationApproved})
+			return err
+		}, codes.InvalidArgument},
+		{"question back to pending", func() error {
+			_, err := service.ModerateQuestion(ctx, &pb.ModerateQuestionRequest{QuestionId: "q-1", Status: ModerationPending})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown question", func() error {
+			_, err := service.ModerateQuestion(ctx, &pb.ModerateQuestionRequest{QuestionId: "q-1", Status: ModerationRejected})
+			return err
+		}, codes.NotFound},
+		{"answer without status", func() error {
+			_, err := service.ModerateAnswer(ctx, &pb.ModerateAnswerRequest{AnswerId: "a-1"})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown answer", func() error {
+			_, err := service.ModerateAnswer(ctx, &pb.ModerateAnswerRequest{AnswerId: "a-1", Status: ModerationApproved})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if st, _ := status.FromError(tt.call()); st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, st)
+			}
+		})
+	}
+}
+
+func TestListQuestions_Success(t *testing.T) {
+	var gotStatus, gotAnswerStatus string
+	var gotPageSize int32
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id}, nil
+		},
+		ListQuestionsFunc: func(ctx context.Context, productID, status, answerStatus string, page, pageSize int32) ([]*ProductQuestion, int32, error) {
+			gotStatus, gotAnswerStatus, gotPageSize = status, answerStatus, pageSize
+			return []*ProductQuestion{{
+				ID: "q-1", ProductID: productID, Status: status, CreatedAt: time.Now(),
+				Answers: []*ProductAnswer{{ID: "a-1", QuestionID: "q-1", Status: ModerationApproved, CreatedAt: time.Now()}},
+			}}, 1, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.ListQuestions(context.Background(), &pb.ListQuestionsRequest{ProductId: "prod-1", PageSize: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotStatus != ModerationApproved || gotAnswerStatus != ModerationApproved || gotPageSize != 100 {
+		t.Errorf("Expected approved questions and answers in pages of 100, got %q, %q, %d", gotStatus, gotAnswerStatus, gotPageSize)
+	}
+	if resp.Total != 1 || len(resp.Questions) != 1 || len(resp.Questions[0].Answers) != 1 {
+		t.Fatalf("Unexpected response %v", resp)
+	}
+
+	// A moderation queue gets answers in every status
+	if _, err := service.ListQuestions(context.Background(), &pb.ListQuestionsRequest{ProductId: "prod-1", Status: ModerationPending}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotStatus != ModerationPending || gotAnswerStatus != "" {
+		t.Errorf("Expected pending questions with all answers, got %q, %q", gotStatus, gotAnswerStatus)
+	}
+}
+
+func TestListQuestions_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if id == "missing" {
+				return nil, ErrProductNotFound
+			}
+			return &Product{ID: id}, nil
+		},
+		ListQuestionsFunc: func(ctx context.Context, productID, status, answerStatus string, page, pageSize int32) ([]*ProductQuestion, int32, error) {
+			return nil, 0, errors.New("db down")
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		name     string
+		req      *pb.ListQuestionsRequest
+		expected codes.Code
+	}{
+		{"missing product ID", &pb.ListQuestionsRequest{}, codes.InvalidArgument},
+		{"invalid status", &pb.ListQuestionsRequest{ProductId: "prod-1", Status: "SPAM"}, codes.InvalidArgument},
+		{"unknown product", &pb.ListQuestionsRequest{ProductId: "missing"}, codes.NotFound},
+		{"repository error", &pb.ListQuestionsRequest{ProductId: "prod-1"}, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ListQuestions(context.Background(), tt.req)
+			if st, _ := status.FromError(err); st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RatingAggregate is the review summary of a product computed by the review service
+type RatingAggregate struct {
+	AverageRating float64
+	ReviewCount   int32
+	// AsOf is when the aggregate was computed
+	AsOf time.Time
+}
+
+// UpdateRatingAggregate stores the review summary of a product unless a newer
+// one is already stored, and reports whether it was applied
+func (r *postgresRepository) UpdateRatingAggregate(ctx context.Context, productID string, agg RatingAggregate) (bool, error) {
+	query := `
+		UPDATE products
+		SET average_rating = $2, review_count = $3, rating_updated_at = $4
+		WHERE id = $1 AND (rating_updated_at IS NULL OR rating_updated_at <= $4)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, productID, agg.AverageRating, agg.ReviewCount, agg.AsOf)
+	if err != nil {
+		r.log.Error(ctx, "Failed to update rating aggregate", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return false, fmt.Errorf("failed to update rating aggregate: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return true, nil
+	}
+
+	// Either the product does not exist or a newer aggregate is stored
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM products WHERE id = $1)", productID).Scan(&exists); err != nil {
+		r.log.Error(ctx, "Failed to check product", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return false, fmt.Errorf("failed to check product: %w", err)
+	}
+	if !exists {
+		return false, ErrProductNotFound
+	}
+	return false, nil
+}
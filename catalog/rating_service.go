@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Bounds of a product's average rating while it has reviews
+const (
+	minRating = 1.0
+	maxRating = 5.0
+)
+
+// UpdateRatingAggregate stores the review summary of a product. It is called
+// by the review service whenever a product's reviews change; stale aggregates
+// are acknowledged but not applied.
+func (s *Service) UpdateRatingAggregate(ctx context.Context, req *pb.UpdateRatingAggregateRequest) (*pb.UpdateRatingAggregateResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Update rating aggregate failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.ReviewCount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "review_count cannot be negative")
+	}
+	if req.ReviewCount == 0 && req.AverageRating != 0 {
+		return nil, status.Error(codes.InvalidArgument, "average_rating must be 0 without reviews")
+	}
+	if req.ReviewCount > 0 && (req.AverageRating < minRating || req.AverageRating > maxRating) {
+		return nil, status.Errorf(codes.InvalidArgument, "average_rating must be between %g and %g", minRating, maxRating)
+	}
+
+	agg := RatingAggregate{
+		AverageRating: math.Round(req.AverageRating*100) / 100,
+		ReviewCount:   req.ReviewCount,
+		AsOf:          s.now(),
+	}
+	if req.AsOf != nil {
+		agg.AsOf = req.AsOf.AsTime()
+	}
+
+	applied, err := s.repo.UpdateRatingAggregate(ctx, req.ProductId, agg)
+	if errors.Is(err, ErrProductNotFound) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to update rating aggregate", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to update rating aggregate")
+	}
+	if !applied {
+		s.log.Info(ctx, "Stale rating aggregate ignored", map[string]interface{}{"product_id": req.ProductId, "as_of": agg.AsOf})
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get product", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get product")
+	}
+
+	return &pb.UpdateRatingAggregateResponse{
+		Product: toProtoProduct(product, s.now()),
+		Applied: applied,
+	}, nil
+}
+
+// filterFromRequest validates the listing options of a request
+func filterFromRequest(category, sortBy string, minRating float64) (ProductFilter, string) {
+	switch sortBy {
+	case "", SortNewest, SortRating, SortReviewCount, SortPopularity:
+	default:
+		return ProductFilter{}, "sort_by must be NEWEST, RATING, REVIEW_COUNT or POPULARITY"
+	}
+	if minRating < 0 || minRating > maxRating {
+		return ProductFilter{}, "min_rating must be between 0 and 5"
+	}
+	return ProductFilter{Category: category, SortBy: sortBy, MinRating: minRating}, ""
+}
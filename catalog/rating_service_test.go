@@ -0,0 +1,121 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUpdateRatingAggregate(t *testing.T) {
+	var stored RatingAggregate
+	mockRepo := &MockRepository{
+		UpdateRatingFunc: func(ctx context.Context, productID string, agg RatingAggregate) (bool, error) {
+			if agg.AsOf.Before(stored.AsOf) {
+				return false, nil
+			}
+			stored = agg
+			return true, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, AverageRating: stored.AverageRating, ReviewCount: stored.ReviewCount}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	asOf := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	resp, err := service.UpdateRatingAggregate(context.Background(), &pb.UpdateRatingAggregateRequest{
+		ProductId:     "prod-1",
+		AverageRating: 4.666,
+		ReviewCount:   3,
+		AsOf:          timestamppb.New(asOf),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Applied || resp.Product.AverageRating != 4.67 || resp.Product.ReviewCount != 3 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	// A reordered, older aggregate is acknowledged but not applied
+	resp, err = service.UpdateRatingAggregate(context.Background(), &pb.UpdateRatingAggregateRequest{
+		ProductId:     "prod-1",
+		AverageRating: 4,
+		ReviewCount:   2,
+		AsOf:          timestamppb.New(asOf.Add(-time.Minute)),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Applied || resp.Product.ReviewCount != 3 {
+		t.Errorf("Expected stale aggregate to be ignored, got %v", resp)
+	}
+}
+
+func TestUpdateRatingAggregate_Invalid(t *testing.T) {
+	service := setupService(&MockRepository{})
+
+	tests := []struct {
+		name string
+		req  *pb.UpdateRatingAggregateRequest
+	}{
+		{"missing product", &pb.UpdateRatingAggregateRequest{AverageRating: 4, ReviewCount: 1}},
+		{"negative count", &pb.UpdateRatingAggregateRequest{ProductId: "prod-1", ReviewCount: -1}},
+		{"rating without reviews", &pb.UpdateRatingAggregateRequest{ProductId: "prod-1", AverageRating: 3}},
+		{"rating above 5", &pb.UpdateRatingAggregateRequest{ProductId: "prod-1", AverageRating: 5.5, ReviewCount: 2}},
+		{"rating below 1", &pb.UpdateRatingAggregateRequest{ProductId: "prod-1", AverageRating: 0.5, ReviewCount: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.UpdateRatingAggregate(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateRatingAggregate_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		UpdateRatingFunc: func(ctx context.Context, productID string, agg RatingAggregate) (bool, error) {
+			return false, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.UpdateRatingAggregate(context.Background(), &pb.UpdateRatingAggregateRequest{ProductId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListProducts_SortByRating(t *testing.T) {
+	var got ProductFilter
+	mockRepo := &MockRepository{
+		ListFunc: func(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			got = filter
+			return []*Product{{ID: "prod-1", AverageRating: 4.5, ReviewCount: 10}}, 1, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.ListProducts(context.Background(), &pb.ListProductsRequest{Category: "Books", SortBy: SortRating, MinRating: 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != (ProductFilter{Category: "Books", SortBy: SortRating, MinRating: 4}) {
+		t.Errorf("Unexpected filter %+v", got)
+	}
+	if resp.Products[0].AverageRating != 4.5 || resp.Products[0].ReviewCount != 10 {
+		t.Errorf("Expected rating aggregate in response, got %v", resp.Products[0])
+	}
+
+	for _, req := range []*pb.ListProductsRequest{{SortBy: "PRICE"}, {MinRating: 6}, {MinRating: -1}} {
+		if _, err := service.ListProducts(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
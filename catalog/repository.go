@@ -3,11 +3,15 @@ package catalog
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/richtext"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
@@ -20,30 +24,323 @@ type Product struct {
 	Price       float64
 	SKU         string
 	Stock       int32
-	Images      []string
+	Images      []*ProductImage
 	Category    string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	DescriptionBlocks []richtext.Block
+
+	// SalePrice replaces Price between SaleStartsAt and SaleEndsAt. A nil
+	// bound leaves that side of the sale window open.
+	SalePrice    *float64
+	SaleStartsAt *time.Time
+	SaleEndsAt   *time.Time
+
+	// LowStockThreshold is the stock level at or below which the product is
+	// low on stock; 0 disables low-stock alerts
+	LowStockThreshold int32
+
+	// ProductType is ProductTypePhysical or ProductTypeDigital and cannot
+	// change after creation
+	ProductType string
+
+	// Package weight and dimensions for shipping rates; 0 when not set
+	WeightKg      float64
+	LengthCm      float64
+	WidthCm       float64
+	HeightCm      float64
+	ShippingClass string
+
+	// Barcodes, normalized to digits; "" when not set. ISBN is stored as ISBN-13.
+	EAN  string
+	UPC  string
+	ISBN string
+
+	// Review aggregates maintained by the review service
+	AverageRating float64
+	ReviewCount   int32
+
+	// Status is ProductStatusActive or ProductStatusDraft
+	Status string
+
+	// Version is incremented by every edit; Update requires the version the
+	// edit was based on
+	Version int64
+
+	// Order quantity rules: quantities must be multiples of QtyIncrement
+	// between MinOrderQty and MaxOrderQty. 0 places no rule.
+	MinOrderQty  int32
+	MaxOrderQty  int32
+	QtyIncrement int32
+
+	// VendorID is the marketplace vendor selling the product; "" for products
+	// the operator sells. It is set on create and never changes.
+	VendorID string
+
+	// Locale is the locale of Name and Description; it is set when the
+	// product is localized for a read and "" otherwise
+	Locale string
+}
+
+// Product types
+const (
+	ProductTypePhysical = "PHYSICAL"
+	// ProductTypeDigital products are delivered as downloadable files and do not track stock
+	ProductTypeDigital = "DIGITAL"
+)
+
+// Product statuses
+const (
+	ProductStatusActive = "ACTIVE"
+	// ProductStatusDraft products are hidden from listings and search
+	ProductStatusDraft = "DRAFT"
+)
+
+// ErrVersionConflict is returned when an update is based on an outdated product version
+var ErrVersionConflict = errors.New("product was modified concurrently")
+
+// Shipping classes tell the shipping service how a package is handled
+const (
+	ShippingClassStandard  = "STANDARD"
+	ShippingClassOversized = "OVERSIZED"
+	ShippingClassFragile   = "FRAGILE"
+	ShippingClassHazardous = "HAZARDOUS"
+	ShippingClassFreight   = "FREIGHT"
+)
+
+// TracksStock reports whether stock adjustments and reservations apply to the product
+func (p *Product) TracksStock() bool {
+	return p.ProductType != ProductTypeDigital
+}
+
+// OnSale reports whether the sale price applies at now
+func (p *Product) OnSale(now time.Time) bool {
+	if p.SalePrice == nil {
+		return false
+	}
+	if p.SaleStartsAt != nil && now.Before(*p.SaleStartsAt) {
+		return false
+	}
+	if p.SaleEndsAt != nil && !now.Before(*p.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// EffectivePrice returns the price a customer pays at now
+func (p *Product) EffectivePrice(now time.Time) float64 {
+	if p.OnSale(now) {
+		return *p.SalePrice
+	}
+	return p.Price
+}
+
+// productColumnNames lists the products columns in the order scanProduct reads them
+var productColumnNames = []string{
+	"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at",
+	"description_blocks", "sale_price", "sale_starts_at", "sale_ends_at", "low_stock_threshold",
+	"product_type", "weight_kg", "length_cm", "width_cm", "height_cm", "shipping_class",
+	"ean", "upc", "isbn", "average_rating", "review_count", "status", "version",
+	"min_order_qty", "max_order_qty", "qty_increment", "vendor_id",
+}
+
+// productColumns is the select list for products
+var productColumns = productColumnsWithAlias("products")
+
+// productColumnsWithAlias returns the select list for products qualified with a table alias.
+// Images are aggregated from product_images.
+func productColumnsWithAlias(alias string) string {
+	qualified := make([]string, len(productColumnNames))
+	for i, c := range productColumnNames {
+		if c == "images" {
+			qualified[i] = productImagesColumn(alias)
+			continue
+		}
+		qualified[i] = alias + "." + c
+	}
+	return strings.Join(qualified, ", ")
+}
+
+// Relation types supported for product links
+const (
+	RelationRelated   = "RELATED"
+	RelationUpsell    = "UPSELL"
+	RelationCrossSell = "CROSS_SELL"
+)
+
+// Product listing orders
+const (
+	SortNewest      = "NEWEST"
+	SortRating      = "RATING"
+	SortReviewCount = "REVIEW_COUNT"
+	SortPopularity  = "POPULARITY"
+)
+
+// ProductFilter narrows and orders product listings
+type ProductFilter struct {
+	// Category keeps products of one category; "" keeps all
+	Category string
+	// MinRating keeps products with an average rating of at least MinRating;
+	// 0 keeps all, including unrated products
+	MinRating float64
+	// SortBy is one of the Sort* orders; "" sorts newest first
+	SortBy string
+	// IncludeDrafts keeps DRAFT products, which are otherwise left out
+	IncludeDrafts bool
+	// Channel applies the visibility rules of one storefront channel together
+	// with CustomerGroup; "" applies no visibility rules
+	Channel       string
+	CustomerGroup string
+	// VendorID keeps the products of one marketplace vendor; "" keeps all
+	VendorID string
+}
+
+// conditions appends the filter's WHERE conditions and their arguments to
+// those of the caller, numbering placeholders after the existing arguments
+func (f ProductFilter) conditions(conditions []string, args []interface{}) ([]string, []interface{}) {
+	if f.Category != "" {
+		args = append(args, f.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if f.MinRating > 0 {
+		args = append(args, f.MinRating)
+		conditions = append(conditions, fmt.Sprintf("average_rating >= $%d", len(args)))
+	}
+	if !f.IncludeDrafts {
+		conditions = append(conditions, "status = '"+ProductStatusActive+"'")
+	}
+	if f.Channel != "" {
+		args = append(args, f.Channel, f.CustomerGroup)
+		conditions = append(conditions, fmt.Sprintf(visibleCondition, len(args)-1, len(args)))
+	}
+	if f.VendorID != "" {
+		args = append(args, f.VendorID)
+		conditions = append(conditions, fmt.Sprintf("vendor_id = $%d", len(args)))
+	}
+	return conditions, args
+}
+
+// orderBy returns the ORDER BY clause of the filter's sort order. Ties are
+// broken by creation time, newest first.
+func (f ProductFilter) orderBy() string {
+	switch f.SortBy {
+	case SortRating:
+		return "average_rating DESC, review_count DESC, created_at DESC"
+	case SortReviewCount:
+		return "review_count DESC, average_rating DESC, created_at DESC"
+	case SortPopularity:
+		return popularityOrder + ", created_at DESC"
+	default:
+		return "created_at DESC"
+	}
+}
+
+// RelatedProduct is a product linked to another product by a typed relation
+type RelatedProduct struct {
+	RelationType string
+	Position     int32
+	Product      *Product
 }
 
 // Repository handles product data persistence
 type Repository interface {
-	Create(ctx context.Context, product *Product) (*Product, error)
+	Create(ctx context.Context, product *Product, actor string) (*Product, error)
 	GetByID(ctx context.Context, id string) (*Product, error)
 	GetBySKU(ctx context.Context, sku string) (*Product, error)
-	List(ctx context.Context, page, pageSize int32, category string) ([]*Product, int32, error)
-	Update(ctx context.Context, product *Product) (*Product, error)
-	Delete(ctx context.Context, id string) error
-	Search(ctx context.Context, query string, page, pageSize int32) ([]*Product, int32, error)
+	GetByBarcode(ctx context.Context, barcodes []string) (*Product, error)
+	List(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error)
+	Update(ctx context.Context, product *Product, actor string) (*Product, error)
+	GetPriceHistory(ctx context.Context, productID string, page, pageSize int32) ([]*PriceChange, int32, error)
+	ListPriceHistoryChain(ctx context.Context, afterSeq int64, limit int) ([]*PriceChange, error)
+	ListPriceChangesBetween(ctx context.Context, from, to time.Time) ([]*PriceChange, error)
+	GetPriceHistoryHead(ctx context.Context) (*PriceChange, error)
+	SaveAuditAnchor(ctx context.Context, anchor *AuditAnchor) (*AuditAnchor, error)
+	ListAuditAnchors(ctx context.Context) ([]*AuditAnchor, error)
+	SetPriceTiers(ctx context.Context, productID string, tiers []*PriceTier) error
+	GetPriceTiers(ctx context.Context, productID string) ([]*PriceTier, error)
+	AdjustStock(ctx context.Context, productID string, delta int32) (*StockLevel, error)
+	ListLowStock(ctx context.Context, page, pageSize int32) ([]*Product, int32, error)
+	ApplyInventoryUpdates(ctx context.Context, updates []*InventoryUpdate, actor string) ([]*InventoryUpdateResult, error)
+	CreateStockReservation(ctx context.Context, res *StockReservation) (*StockReservation, *StockLevel, error)
+	GetStockReservation(ctx context.Context, id string) (*StockReservation, error)
+	CommitStockReservation(ctx context.Context, id string, now time.Time) (*StockReservation, error)
+	ReleaseStockReservation(ctx context.Context, id string) (*StockReservation, error)
+	UncommitStockReservation(ctx context.Context, id string) (*StockReservation, error)
+	ExpireStockReservations(ctx context.Context, now time.Time) (int64, error)
+	Delete(ctx context.Context, id, actor string) error
+	AppendImage(ctx context.Context, id, objectKey, imageURL, altText string) (*Product, error)
+	ReorderImages(ctx context.Context, productID string, imageIDs []string) error
+	SetPrimaryImage(ctx context.Context, productID, imageID string) error
+	ClaimPendingImages(ctx context.Context, limit int, lease time.Duration) ([]*ImageJob, error)
+	SaveImageValidation(ctx context.Context, v *ImageValidation) error
+	ReviewImage(ctx context.Context, productID, imageID, altText string) error
+	SetImageRenditions(ctx context.Context, productID, imageID string, renditions []ImageRendition) error
+	Search(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error)
+	SetRelations(ctx context.Context, productID, relationType string, relatedIDs []string) error
+	GetRelations(ctx context.Context, productID, relationType string) ([]*RelatedProduct, error)
+	SetBookingConfig(ctx context.Context, cfg *BookingConfig) (*BookingConfig, error)
+	GetBookingConfig(ctx context.Context, productID string) (*BookingConfig, error)
+	ListActiveBookings(ctx context.Context, productID string, from, to, now time.Time) ([]*Booking, error)
+	CreateBooking(ctx context.Context, booking *Booking, now time.Time) (*Booking, error)
+	GetBooking(ctx context.Context, id string) (*Booking, error)
+	UpdateBookingStatus(ctx context.Context, id, fromStatus, toStatus string) (*Booking, error)
+	SetBundle(ctx context.Context, bundle *Bundle) error
+	GetBundle(ctx context.Context, productID string) (*Bundle, error)
+	IsBundleComponent(ctx context.Context, productID string) (bool, error)
+	AddDigitalAsset(ctx context.Context, asset *DigitalAsset) (*DigitalAsset, error)
+	GetDigitalAsset(ctx context.Context, productID, assetID string) (*DigitalAsset, error)
+	ListDigitalAssets(ctx context.Context, productID string) ([]*DigitalAsset, error)
+	GrantEntitlement(ctx context.Context, ent *Entitlement) (*Entitlement, error)
+	RevokeEntitlement(ctx context.Context, userID, productID string, now time.Time) (*Entitlement, error)
+	HasEntitlement(ctx context.Context, userID, productID string) (bool, error)
+	SetTranslation(ctx context.Context, t *ProductTranslation) (*ProductTranslation, error)
+	DeleteTranslation(ctx context.Context, productID, locale string) error
+	ListTranslations(ctx context.Context, productID string) ([]*ProductTranslation, error)
+	GetTranslations(ctx context.Context, productIDs, locales []string) ([]*ProductTranslation, error)
+	UpdateRatingAggregate(ctx context.Context, productID string, agg RatingAggregate) (bool, error)
+	ChangeSKU(ctx context.Context, productID, newSKU, actor string) (*Product, error)
+	ListSKUAliases(ctx context.Context, productID string) ([]*SKUAlias, error)
+	GetBySKUs(ctx context.Context, skus []string) (map[string]*Product, error)
+	GetCategoryStats(ctx context.Context, includeDrafts bool) ([]*CategoryStats, error)
+	RecordActivity(ctx context.Context, events []*ProductActivity) (int, error)
+	ListBestSellers(ctx context.Context, since time.Time, category string, limit int) ([]*BestSeller, error)
+	FuzzySearch(ctx context.Context, query string, threshold float64, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error)
+	SuggestQuery(ctx context.Context, query string, threshold float64, filter ProductFilter) (string, error)
+	SuggestProducts(ctx context.Context, prefix string, limit int, filter ProductFilter) ([]*ProductSuggestion, []string, error)
+	SetVisibility(ctx context.Context, v *ProductVisibility) (*ProductVisibility, error)
+	GetVisibility(ctx context.Context, productID string) (*ProductVisibility, error)
+	CreateQuestion(ctx context.Context, q *ProductQuestion) (*ProductQuestion, error)
+	GetQuestion(ctx context.Context, id string) (*ProductQuestion, error)
+	CreateAnswer(ctx context.Context, a *ProductAnswer) (*ProductAnswer, error)
+	ModerateQuestion(ctx context.Context, id, status, actor string, at time.Time) (*ProductQuestion, error)
+	ModerateAnswer(ctx context.Context, id, status, actor string, at time.Time) (*ProductAnswer, error)
+	ListQuestions(ctx context.Context, productID, status, answerStatus string, page, pageSize int32) ([]*ProductQuestion, int32, error)
+	Clone(ctx context.Context, sourceID, sku, name, actor string) (*Product, error)
+	ListUpdatedSince(ctx context.Context, cursor ProductCursor, limit int) ([]*Product, error)
+	GetProductAuditLog(ctx context.Context, productID string, page, pageSize int32) ([]*ProductAuditEntry, int32, error)
+	ListProductAuditBetween(ctx context.Context, from, to time.Time) ([]*ProductAuditEntry, error)
+	SubscribeBackInStock(ctx context.Context, sub *BackInStockSubscription) (*BackInStockSubscription, error)
+	UnsubscribeBackInStock(ctx context.Context, productID, userID string) error
+	ClaimBackInStock(ctx context.Context, productID string, limit int, lease time.Duration) ([]*BackInStockSubscription, error)
+	DeleteBackInStockSubscription(ctx context.Context, id string) error
+	ListSalesVelocity(ctx context.Context, since, until time.Time) ([]*SalesVelocity, error)
 	Close() error
 }
 
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type postgresRepository struct {
 	db  *sql.DB
 	log *logger.Logger
 }
 
-// NewPostgresRepository creates a new PostgreSQL repository
+// NewPostgresRepository creates a new PostgreSQL repository. db should be
+// opened with the pgx driver, which caches prepared statements per connection
+// and lets List and Search batch their queries.
 func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
 	return &postgresRepository{
 		db:  db,
@@ -51,20 +348,34 @@ func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
 	}
 }
 
-// Create creates a new product
-func (r *postgresRepository) Create(ctx context.Context, product *Product) (*Product, error) {
+// Create creates a new product together with its images and records its
+// initial price and an audit entry
+func (r *postgresRepository) Create(ctx context.Context, product *Product, actor string) (*Product, error) {
 	product.ID = uuid.New().String()
 	product.CreatedAt = time.Now()
 	product.UpdatedAt = time.Now()
 
+	blocks, err := marshalBlocks(product.DescriptionBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO products (id, name, description, price, sku, stock, images, category, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, name, description, price, sku, stock, images, category, created_at, updated_at
+		INSERT INTO products (id, name, description, price, sku, stock, category, created_at, updated_at, description_blocks,
+			sale_price, sale_starts_at, sale_ends_at, low_stock_threshold, product_type,
+			weight_kg, length_cm, width_cm, height_cm, shipping_class, ean, upc, isbn,
+			min_order_qty, max_order_qty, qty_increment, vendor_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)
 	`
 
-	var images pq.StringArray
-	err := r.db.QueryRowContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		product.ID,
@@ -73,60 +384,76 @@ func (r *postgresRepository) Create(ctx context.Context, product *Product) (*Pro
 		product.Price,
 		product.SKU,
 		product.Stock,
-		pq.Array(product.Images),
 		product.Category,
 		product.CreatedAt,
 		product.UpdatedAt,
-	).Scan(
-		&product.ID,
-		&product.Name,
-		&product.Description,
-		&product.Price,
-		&product.SKU,
-		&product.Stock,
-		&images,
-		&product.Category,
-		&product.CreatedAt,
-		&product.UpdatedAt,
+		blocks,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
+		product.LowStockThreshold,
+		product.ProductType,
+		product.WeightKg,
+		product.LengthCm,
+		product.WidthCm,
+		product.HeightCm,
+		product.ShippingClass,
+		nullString(product.EAN),
+		nullString(product.UPC),
+		nullString(product.ISBN),
+		product.MinOrderQty,
+		product.MaxOrderQty,
+		product.QtyIncrement,
+		product.VendorID,
 	)
+	if err == nil {
+		err = r.syncImages(ctx, tx, product.ID, imageURLs(product.Images))
+	}
+	if err == nil {
+		err = recordPriceChange(ctx, tx, product.ID, nil, actor, product.CreatedAt)
+	}
+
+	var created *Product
+	if err == nil {
+		created, err = scanProduct(tx.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE id = $1", product.ID))
+	}
+	if err == nil {
+		changes := diffProducts(nil, created)
+		err = recordProductAudit(ctx, tx, created.ID, AuditActionCreate, actor, changes, product.CreatedAt)
+		if err == nil {
+			err = recordProductEvent(ctx, tx, created.ID, AuditActionCreate, actor, changes, product.CreatedAt)
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 
+	if pgerr.IsUniqueViolation(err, skuConstraint) {
+		r.log.Warn(ctx, "Product SKU already exists", map[string]interface{}{"sku": product.SKU})
+		return nil, ErrDuplicateSKU
+	}
 	if err != nil {
 		r.log.Error(ctx, "Failed to create product", map[string]interface{}{"error": err.Error()})
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
-	product.Images = images
-	r.log.Info(ctx, "Product created successfully", map[string]interface{}{"product_id": product.ID, "sku": product.SKU})
-	return product, nil
+	r.log.Info(ctx, "Product created successfully", map[string]interface{}{"product_id": created.ID, "sku": created.SKU})
+	return created, nil
 }
 
 // GetByID retrieves a product by ID
 func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Product, error) {
 	query := `
-		SELECT id, name, description, price, sku, stock, images, category, created_at, updated_at
+		SELECT ` + productColumns + `
 		FROM products
 		WHERE id = $1
 	`
 
-	product := &Product{}
-	var images pq.StringArray
+	product, err := scanProduct(r.queryRow(ctx, "get_product", query, id))
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&product.ID,
-		&product.Name,
-		&product.Description,
-		&product.Price,
-		&product.SKU,
-		&product.Stock,
-		&images,
-		&product.Category,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
 		r.log.Warn(ctx, "Product not found", map[string]interface{}{"product_id": id})
-		return nil, fmt.Errorf("product not found")
+		return nil, ErrProductNotFound
 	}
 
 	if err != nil {
@@ -134,37 +461,24 @@ func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Product,
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
-	product.Images = images
 	return product, nil
 }
 
-// GetBySKU retrieves a product by SKU
+// GetBySKU retrieves a product by its current SKU or a former one
 func (r *postgresRepository) GetBySKU(ctx context.Context, sku string) (*Product, error) {
 	query := `
-		SELECT id, name, description, price, sku, stock, images, category, created_at, updated_at
+		SELECT ` + productColumns + `
 		FROM products
-		WHERE sku = $1
+		WHERE sku = $1 OR id = (SELECT product_id FROM product_sku_aliases WHERE sku = $1)
+		ORDER BY sku = $1 DESC
+		LIMIT 1
 	`
 
-	product := &Product{}
-	var images pq.StringArray
-
-	err := r.db.QueryRowContext(ctx, query, sku).Scan(
-		&product.ID,
-		&product.Name,
-		&product.Description,
-		&product.Price,
-		&product.SKU,
-		&product.Stock,
-		&images,
-		&product.Category,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-	)
+	product, err := scanProduct(r.queryRow(ctx, "get_product_by_sku", query, sku))
 
 	if err == sql.ErrNoRows {
 		r.log.Warn(ctx, "Product not found", map[string]interface{}{"sku": sku})
-		return nil, fmt.Errorf("product not found")
+		return nil, ErrProductNotFound
 	}
 
 	if err != nil {
@@ -172,12 +486,33 @@ func (r *postgresRepository) GetBySKU(ctx context.Context, sku string) (*Product
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
-	product.Images = images
 	return product, nil
 }
 
-// List retrieves products with pagination and optional category filter
-func (r *postgresRepository) List(ctx context.Context, page, pageSize int32, category string) ([]*Product, int32, error) {
+// GetByBarcode retrieves the product whose EAN, UPC or ISBN matches any of
+// the given barcodes. It returns ErrProductNotFound when none does.
+func (r *postgresRepository) GetByBarcode(ctx context.Context, barcodes []string) (*Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products
+		WHERE ean = ANY($1) OR upc = ANY($1) OR isbn = ANY($1)
+		LIMIT 1
+	`
+
+	product, err := scanProduct(r.db.QueryRowContext(ctx, query, pq.Array(barcodes)))
+	if err == sql.ErrNoRows {
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get product by barcode", map[string]interface{}{"error": err.Error(), "barcodes": barcodes})
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// List retrieves products with pagination, filtered and ordered by filter
+func (r *postgresRepository) List(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -190,126 +525,145 @@ func (r *postgresRepository) List(ctx context.Context, page, pageSize int32, cat
 
 	offset := (page - 1) * pageSize
 
-	// Build query with optional category filter
-	var query string
-	var countQuery string
-	var args []interface{}
-
-	if category != "" {
-		query = `
-			SELECT id, name, description, price, sku, stock, images, category, created_at, updated_at
-			FROM products
-			WHERE category = $1
-			ORDER BY created_at DESC
-			LIMIT $2 OFFSET $3
-		`
-		countQuery = "SELECT COUNT(*) FROM products WHERE category = $1"
-		args = []interface{}{category, pageSize, offset}
-	} else {
-		query = `
-			SELECT id, name, description, price, sku, stock, images, category, created_at, updated_at
-			FROM products
-			ORDER BY created_at DESC
-			LIMIT $1 OFFSET $2
-		`
-		countQuery = "SELECT COUNT(*) FROM products"
-		args = []interface{}{pageSize, offset}
-	}
-
-	// Get total count
-	var total int32
-	var countArgs []interface{}
-	if category != "" {
-		countArgs = []interface{}{category}
-	}
-	err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
-	if err != nil {
-		r.log.Error(ctx, "Failed to count products", map[string]interface{}{"error": err.Error()})
-		return nil, 0, fmt.Errorf("failed to count products: %w", err)
+	conditions, countArgs := filter.conditions(nil, nil)
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	// Get products
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	countQuery := "SELECT COUNT(*) FROM products " + where
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM products
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, productColumns, where, filter.orderBy(), len(countArgs)+1, len(countArgs)+2)
+	args := append(append([]interface{}{}, countArgs...), pageSize, offset)
+
+	products, total, err := r.queryProductPage(ctx, "list_products", countQuery, countArgs, query, args, pageSize)
 	if err != nil {
 		r.log.Error(ctx, "Failed to list products", map[string]interface{}{"error": err.Error()})
 		return nil, 0, fmt.Errorf("failed to list products: %w", err)
 	}
-	defer rows.Close()
 
-	products := []*Product{}
-	for rows.Next() {
-		product := &Product{}
-		var images pq.StringArray
-
-		err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Description,
-			&product.Price,
-			&product.SKU,
-			&product.Stock,
-			&images,
-			&product.Category,
-			&product.CreatedAt,
-			&product.UpdatedAt,
-		)
-		if err != nil {
-			r.log.Error(ctx, "Failed to scan product", map[string]interface{}{"error": err.Error()})
-			return nil, 0, fmt.Errorf("failed to scan product: %w", err)
-		}
+	r.log.Info(ctx, "Products listed successfully", map[string]interface{}{"count": len(products), "total": total})
+	return products, total, nil
+}
 
-		product.Images = images
-		products = append(products, product)
+// Update updates an existing product, replaces its images and records a price
+// change and an audit entry of the changed fields. product.Version must be the
+// current version, which the update increments.
+func (r *postgresRepository) Update(ctx context.Context, product *Product, actor string) (*Product, error) {
+	blocks, err := marshalBlocks(product.DescriptionBlocks)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = rows.Err(); err != nil {
-		r.log.Error(ctx, "Error iterating products", map[string]interface{}{"error": err.Error()})
-		return nil, 0, fmt.Errorf("error iterating products: %w", err)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	r.log.Info(ctx, "Products listed successfully", map[string]interface{}{"count": len(products), "total": total})
-	return products, total, nil
-}
+	// Lock the row so concurrent updates record consecutive price changes
+	var oldPrice float64
+	err = tx.QueryRowContext(ctx, "SELECT price FROM products WHERE id = $1 FOR UPDATE", product.ID).Scan(&oldPrice)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		r.log.Warn(ctx, "Product not found for update", map[string]interface{}{"product_id": product.ID})
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to lock product", map[string]interface{}{"error": err.Error(), "product_id": product.ID})
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	before, err := scanProduct(tx.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE id = $1", product.ID))
+	if err != nil {
+		r.log.Error(ctx, "Failed to read product", map[string]interface{}{"error": err.Error(), "product_id": product.ID})
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	if before.Version != product.Version {
+		r.log.Warn(ctx, "Product version conflict", map[string]interface{}{"product_id": product.ID, "version": product.Version, "current_version": before.Version})
+		return nil, ErrVersionConflict
+	}
 
-// Update updates an existing product
-func (r *postgresRepository) Update(ctx context.Context, product *Product) (*Product, error) {
 	query := `
 		UPDATE products
-		SET name = $1, description = $2, price = $3, stock = $4, images = $5, category = $6, updated_at = $7
-		WHERE id = $8
-		RETURNING id, name, description, price, sku, stock, images, category, created_at, updated_at
+		SET name = $1, description = $2, price = $3, stock = $4, category = $5, updated_at = $6, description_blocks = $7,
+			sale_price = $8, sale_starts_at = $9, sale_ends_at = $10, low_stock_threshold = $11,
+			weight_kg = $12, length_cm = $13, width_cm = $14, height_cm = $15, shipping_class = $16,
+			ean = $17, upc = $18, isbn = $19, status = $20,
+			min_order_qty = $21, max_order_qty = $22, qty_increment = $23, version = version + 1
+		WHERE id = $24
 	`
 
 	product.UpdatedAt = time.Now()
-	var images pq.StringArray
 
-	err := r.db.QueryRowContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		product.Name,
 		product.Description,
 		product.Price,
 		product.Stock,
-		pq.Array(product.Images),
 		product.Category,
 		product.UpdatedAt,
+		blocks,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
+		product.LowStockThreshold,
+		product.WeightKg,
+		product.LengthCm,
+		product.WidthCm,
+		product.HeightCm,
+		product.ShippingClass,
+		nullString(product.EAN),
+		nullString(product.UPC),
+		nullString(product.ISBN),
+		product.Status,
+		product.MinOrderQty,
+		product.MaxOrderQty,
+		product.QtyIncrement,
 		product.ID,
-	).Scan(
-		&product.ID,
-		&product.Name,
-		&product.Description,
-		&product.Price,
-		&product.SKU,
-		&product.Stock,
-		&images,
-		&product.Category,
-		&product.CreatedAt,
-		&product.UpdatedAt,
 	)
+	if err != nil {
+		r.log.Error(ctx, "Failed to update product", map[string]interface{}{"error": err.Error(), "product_id": product.ID})
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
 
-	if err == sql.ErrNoRows {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rows affected", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
 		r.log.Warn(ctx, "Product not found for update", map[string]interface{}{"product_id": product.ID})
-		return nil, fmt.Errorf("product not found")
+		return nil, ErrProductNotFound
+	}
+
+	err = r.syncImages(ctx, tx, product.ID, imageURLs(product.Images))
+	if err == nil {
+		err = recordPriceChange(ctx, tx, product.ID, &oldPrice, actor, product.UpdatedAt)
+	}
+
+	var updated *Product
+	if err == nil {
+		updated, err = scanProduct(tx.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE id = $1", product.ID))
+	}
+	if err == nil {
+		if changes := diffProducts(before, updated); len(changes) > 0 {
+			err = recordProductAudit(ctx, tx, product.ID, AuditActionUpdate, actor, changes, product.UpdatedAt)
+			if err == nil {
+				err = recordProductEvent(ctx, tx, product.ID, AuditActionUpdate, actor, changes, product.UpdatedAt)
+			}
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
 	}
 
 	if err != nil {
@@ -317,16 +671,30 @@ func (r *postgresRepository) Update(ctx context.Context, product *Product) (*Pro
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
-	product.Images = images
-	r.log.Info(ctx, "Product updated successfully", map[string]interface{}{"product_id": product.ID})
-	return product, nil
+	r.log.Info(ctx, "Product updated successfully", map[string]interface{}{"product_id": updated.ID})
+	return updated, nil
 }
 
-// Delete deletes a product
-func (r *postgresRepository) Delete(ctx context.Context, id string) error {
-	query := "DELETE FROM products WHERE id = $1"
+// Delete deletes a product and records an audit entry with its last values
+func (r *postgresRepository) Delete(ctx context.Context, id, actor string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	before, err := scanProduct(tx.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE id = $1", id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		r.log.Warn(ctx, "Product not found for deletion", map[string]interface{}{"product_id": id})
+		return ErrProductNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to read product", map[string]interface{}{"error": err.Error(), "product_id": id})
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM products WHERE id = $1", id)
 	if err != nil {
 		r.log.Error(ctx, "Failed to delete product", map[string]interface{}{"error": err.Error(), "product_id": id})
 		return fmt.Errorf("failed to delete product: %w", err)
@@ -340,15 +708,28 @@ func (r *postgresRepository) Delete(ctx context.Context, id string) error {
 
 	if rows == 0 {
 		r.log.Warn(ctx, "Product not found for deletion", map[string]interface{}{"product_id": id})
-		return fmt.Errorf("product not found")
+		return ErrProductNotFound
+	}
+
+	changes, now := diffProducts(before, nil), time.Now()
+	err = recordProductAudit(ctx, tx, id, AuditActionDelete, actor, changes, now)
+	if err == nil {
+		err = recordProductEvent(ctx, tx, id, AuditActionDelete, actor, changes, now)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to delete product", map[string]interface{}{"error": err.Error(), "product_id": id})
+		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
-	r.log.Info(ctx, "Product deleted successfully", map[string]interface{}{"product_id": id})
+	r.log.Info(ctx, "Product deleted successfully", map[string]interface{}{"product_id": id, "actor": actor})
 	return nil
 }
 
 // Search searches for products by name or description
-func (r *postgresRepository) Search(ctx context.Context, query string, page, pageSize int32) ([]*Product, int32, error) {
+func (r *postgresRepository) Search(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -361,70 +742,197 @@ func (r *postgresRepository) Search(ctx context.Context, query string, page, pag
 
 	offset := (page - 1) * pageSize
 	searchPattern := "%" + strings.ToLower(query) + "%"
+	conditions, countArgs := filter.conditions(
+		[]string{"(LOWER(name) LIKE $1 OR LOWER(description) LIKE $1)"},
+		[]interface{}{searchPattern},
+	)
+	where := "WHERE " + strings.Join(conditions, " AND ")
 
-	// Count total matching products
-	countQuery := `
-		SELECT COUNT(*)
+	countQuery := "SELECT COUNT(*) FROM products " + where
+	searchQuery := fmt.Sprintf(`
+		SELECT %s
 		FROM products
-		WHERE LOWER(name) LIKE $1 OR LOWER(description) LIKE $1
-	`
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, productColumns, where, filter.orderBy(), len(countArgs)+1, len(countArgs)+2)
+	args := append(append([]interface{}{}, countArgs...), pageSize, offset)
 
-	var total int32
-	err := r.db.QueryRowContext(ctx, countQuery, searchPattern).Scan(&total)
+	products, total, err := r.queryProductPage(ctx, "search_products", countQuery, countArgs, searchQuery, args, pageSize)
 	if err != nil {
-		r.log.Error(ctx, "Failed to count search results", map[string]interface{}{"error": err.Error()})
-		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+		r.log.Error(ctx, "Failed to search products", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to search products: %w", err)
 	}
 
-	// Search products
-	searchQuery := `
-		SELECT id, name, description, price, sku, stock, images, category, created_at, updated_at
-		FROM products
-		WHERE LOWER(name) LIKE $1 OR LOWER(description) LIKE $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+	r.log.Info(ctx, "Products searched successfully", map[string]interface{}{"query": query, "count": len(products), "total": total})
+	return products, total, nil
+}
+
+// SetRelations replaces the links of one relation type for a product, preserving the given order
+func (r *postgresRepository) SetRelations(ctx context.Context, productID, relationType string, relatedIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM product_relations WHERE product_id = $1 AND relation_type = $2", productID, relationType)
+	if err != nil {
+		r.log.Error(ctx, "Failed to clear product relations", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to clear product relations: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO product_relations (product_id, related_product_id, relation_type, position)
+		VALUES ($1, $2, $3, $4)
 	`
+	for i, relatedID := range relatedIDs {
+		if _, err := tx.ExecContext(ctx, insertQuery, productID, relatedID, relationType, i); err != nil {
+			r.log.Error(ctx, "Failed to insert product relation", map[string]interface{}{"error": err.Error(), "product_id": productID, "related_product_id": relatedID})
+			return fmt.Errorf("failed to insert product relation: %w", err)
+		}
+	}
 
-	rows, err := r.db.QueryContext(ctx, searchQuery, searchPattern, pageSize, offset)
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit product relations", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to commit product relations: %w", err)
+	}
+
+	r.log.Info(ctx, "Product relations updated successfully", map[string]interface{}{"product_id": productID, "relation_type": relationType, "count": len(relatedIDs)})
+	return nil
+}
+
+// GetRelations retrieves linked products, optionally filtered by relation type
+func (r *postgresRepository) GetRelations(ctx context.Context, productID, relationType string) ([]*RelatedProduct, error) {
+	query := `
+		SELECT pr.relation_type, pr.position, ` + productColumnsWithAlias("p") + `
+		FROM product_relations pr
+		JOIN products p ON p.id = pr.related_product_id
+		WHERE pr.product_id = $1 AND ($2 = '' OR pr.relation_type = $2)
+		ORDER BY pr.relation_type, pr.position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, relationType)
 	if err != nil {
-		r.log.Error(ctx, "Failed to search products", map[string]interface{}{"error": err.Error()})
-		return nil, 0, fmt.Errorf("failed to search products: %w", err)
+		r.log.Error(ctx, "Failed to get product relations", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get product relations: %w", err)
 	}
 	defer rows.Close()
 
-	products := []*Product{}
+	related := []*RelatedProduct{}
 	for rows.Next() {
-		product := &Product{}
-		var images pq.StringArray
-
-		err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Description,
-			&product.Price,
-			&product.SKU,
-			&product.Stock,
-			&images,
-			&product.Category,
-			&product.CreatedAt,
-			&product.UpdatedAt,
-		)
+		rel := &RelatedProduct{}
+		product, err := scanProduct(rows, &rel.RelationType, &rel.Position)
 		if err != nil {
-			r.log.Error(ctx, "Failed to scan search result", map[string]interface{}{"error": err.Error()})
-			return nil, 0, fmt.Errorf("failed to scan search result: %w", err)
+			r.log.Error(ctx, "Failed to scan product relation", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan product relation: %w", err)
 		}
 
-		product.Images = images
-		products = append(products, product)
+		rel.Product = product
+		related = append(related, rel)
 	}
 
 	if err = rows.Err(); err != nil {
-		r.log.Error(ctx, "Error iterating search results", map[string]interface{}{"error": err.Error()})
-		return nil, 0, fmt.Errorf("error iterating search results: %w", err)
+		r.log.Error(ctx, "Error iterating product relations", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating product relations: %w", err)
 	}
 
-	r.log.Info(ctx, "Products searched successfully", map[string]interface{}{"query": query, "count": len(products), "total": total})
-	return products, total, nil
+	return related, nil
+}
+
+// nullString stores an empty string as NULL, for optional unique columns
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// scanProduct scans a product selected with productColumns. Extra destinations
+// for columns selected before the product columns are scanned first.
+func scanProduct(row rowScanner, leading ...interface{}) (*Product, error) {
+	product := &Product{}
+	var images []byte
+	var description sql.NullString
+	var category sql.NullString
+	var blocks []byte
+	var salePrice sql.NullFloat64
+	var saleStartsAt, saleEndsAt sql.NullTime
+	var ean, upc, isbn sql.NullString
+
+	dest := append(leading,
+		&product.ID,
+		&product.Name,
+		&description,
+		&product.Price,
+		&product.SKU,
+		&product.Stock,
+		&images,
+		&category,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&blocks,
+		&salePrice,
+		&saleStartsAt,
+		&saleEndsAt,
+		&product.LowStockThreshold,
+		&product.ProductType,
+		&product.WeightKg,
+		&product.LengthCm,
+		&product.WidthCm,
+		&product.HeightCm,
+		&product.ShippingClass,
+		&ean,
+		&upc,
+		&isbn,
+		&product.AverageRating,
+		&product.ReviewCount,
+		&product.Status,
+		&product.Version,
+		&product.MinOrderQty,
+		&product.MaxOrderQty,
+		&product.QtyIncrement,
+		&product.VendorID,
+	)
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Description = description.String
+	product.Category = category.String
+	product.EAN = ean.String
+	product.UPC = upc.String
+	product.ISBN = isbn.String
+	if salePrice.Valid {
+		product.SalePrice = &salePrice.Float64
+	}
+	if saleStartsAt.Valid {
+		product.SaleStartsAt = &saleStartsAt.Time
+	}
+	if saleEndsAt.Valid {
+		product.SaleEndsAt = &saleEndsAt.Time
+	}
+	product.Images, err = decodeImages(images)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) > 0 {
+		if err := json.Unmarshal(blocks, &product.DescriptionBlocks); err != nil {
+			return nil, fmt.Errorf("failed to decode description blocks: %w", err)
+		}
+	}
+	return product, nil
+}
+
+// marshalBlocks encodes description blocks for the JSONB column
+func marshalBlocks(blocks []richtext.Block) ([]byte, error) {
+	if blocks == nil {
+		blocks = []richtext.Block{}
+	}
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode description blocks: %w", err)
+	}
+	return data, nil
 }
 
 // Close closes the database connection
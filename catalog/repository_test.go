@@ -3,6 +3,11 @@ package catalog
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +28,73 @@ func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
 	return db, mock, repo
 }
 
+// imagesJSON builds the aggregated images column for the given URLs
+func imagesJSON(urls ...string) []byte {
+	images := imagesFromURLs(urls)
+	for i, img := range images {
+		img.ID = fmt.Sprintf("img-%d", i+1)
+		img.IsPrimary = i == 0
+	}
+	data, _ := json.Marshal(images)
+	return data
+}
+
+// productRow completes a products row with defaults for the columns after updated_at
+func productRow(values ...driver.Value) []driver.Value {
+	return append(values, []byte("[]"), nil, nil, nil, 0, ProductTypePhysical, 0.0, 0.0, 0.0, 0.0, ShippingClassStandard, nil, nil, nil, 0.0, 0, ProductStatusActive, 1, 0, 0, 0, "")
+}
+
+// productColumnIndex returns the position of a column in a products row
+func productColumnIndex(name string) int {
+	for i, c := range productColumnNames {
+		if c == name {
+			return i
+		}
+	}
+	panic("unknown products column " + name)
+}
+
+// expectRecordPriceChange expects a new price history entry chained after entry 4
+func expectRecordPriceChange(mock sqlmock.Sqlmock, productID driver.Value, oldPrice driver.Value, price float64, actor string) {
+	mock.ExpectQuery(`SELECT price FROM products WHERE id`).
+		WithArgs(productID).
+		WillReturnRows(sqlmock.NewRows([]string{"price"}).AddRow(price))
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT seq, hash FROM price_history ORDER BY seq DESC LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"seq", "hash"}).AddRow(4, strings.Repeat("a", 64)))
+	mock.ExpectExec(`INSERT INTO price_history`).
+		WithArgs(sqlmock.AnyArg(), productID, oldPrice, price, actor, sqlmock.AnyArg(), int64(5), strings.Repeat("a", 64), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expectSyncImages expects the statements that replace a product's images with urls
+func expectSyncImages(mock sqlmock.Sqlmock, urls []string) {
+	mock.ExpectExec(`DELETE FROM product_images`).
+		WithArgs(sqlmock.AnyArg(), pq.Array(urls)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO product_images`).
+		WithArgs(sqlmock.AnyArg(), pq.Array(urls)).
+		WillReturnResult(sqlmock.NewResult(0, int64(len(urls))))
+	mock.ExpectExec(`UPDATE product_images SET is_primary`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expectProductAudit expects an audit entry for a product mutation
+func expectProductAudit(mock sqlmock.Sqlmock, productID driver.Value, action, actor string) {
+	mock.ExpectExec(`INSERT INTO product_audit_log`).
+		WithArgs(productID, action, actor, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectProductEvent expects the outbox event of a product mutation
+func expectProductEvent(mock sqlmock.Sqlmock, productID driver.Value, eventType string) {
+	mock.ExpectExec(`INSERT INTO catalog_outbox`).
+		WithArgs(sqlmock.AnyArg(), EventsTopic, productID, eventType, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
 func TestCreate(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -34,18 +106,26 @@ func TestCreate(t *testing.T) {
 		Price:       99.99,
 		SKU:         "TEST-001",
 		Stock:       10,
-		Images:      []string{"image1.jpg", "image2.jpg"},
+		Images:      imagesFromURLs([]string{"image1.jpg", "image2.jpg"}),
 		Category:    "Electronics",
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at"}).
-		AddRow("test-id", product.Name, product.Description, product.Price, product.SKU, product.Stock, pq.Array(product.Images), product.Category, time.Now(), time.Now())
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("test-id", product.Name, product.Description, product.Price, product.SKU, product.Stock, imagesJSON("image1.jpg", "image2.jpg"), product.Category, time.Now(), time.Now())...)
 
-	mock.ExpectQuery(`INSERT INTO products`).
-		WithArgs(sqlmock.AnyArg(), product.Name, product.Description, product.Price, product.SKU, product.Stock, pq.Array(product.Images), product.Category, sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO products`).
+		WithArgs(sqlmock.AnyArg(), product.Name, product.Description, product.Price, product.SKU, product.Stock, product.Category, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil, nil, int32(0), product.ProductType, 0.0, 0.0, 0.0, 0.0, product.ShippingClass, nullString(""), nullString(""), nullString(""), int32(0), int32(0), int32(0), "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectSyncImages(mock, []string{"image1.jpg", "image2.jpg"})
+	expectRecordPriceChange(mock, sqlmock.AnyArg(), nil, product.Price, "admin-1")
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
 		WillReturnRows(rows)
+	expectProductAudit(mock, "test-id", AuditActionCreate, "admin-1")
+	expectProductEvent(mock, "test-id", EventProductCreated)
+	mock.ExpectCommit()
 
-	result, err := repo.Create(ctx, product)
+	result, err := repo.Create(ctx, product, "admin-1")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -59,6 +139,10 @@ func TestCreate(t *testing.T) {
 		t.Errorf("Expected name %s, got %s", product.Name, result.Name)
 	}
 
+	if len(result.Images) != 2 || result.Images[1].URL != "image2.jpg" || !result.Images[0].IsPrimary {
+		t.Errorf("Unexpected images %v", result.Images)
+	}
+
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("Unfulfilled expectations: %v", err)
 	}
@@ -75,15 +159,17 @@ func TestCreate_Error(t *testing.T) {
 		Price:       99.99,
 		SKU:         "TEST-001",
 		Stock:       10,
-		Images:      []string{"image1.jpg"},
+		Images:      imagesFromURLs([]string{"image1.jpg"}),
 		Category:    "Electronics",
 	}
 
-	mock.ExpectQuery(`INSERT INTO products`).
-		WithArgs(sqlmock.AnyArg(), product.Name, product.Description, product.Price, product.SKU, product.Stock, pq.Array(product.Images), product.Category, sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO products`).
+		WithArgs(sqlmock.AnyArg(), product.Name, product.Description, product.Price, product.SKU, product.Stock, product.Category, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil, nil, int32(0), product.ProductType, 0.0, 0.0, 0.0, 0.0, product.ShippingClass, nullString(""), nullString(""), nullString(""), int32(0), int32(0), int32(0), "").
 		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
 
-	result, err := repo.Create(ctx, product)
+	result, err := repo.Create(ctx, product, "admin-1")
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -105,8 +191,8 @@ func TestGetByID(t *testing.T) {
 	ctx := context.Background()
 	productID := "test-id"
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at"}).
-		AddRow(productID, "Test Product", "Test Description", 99.99, "TEST-001", 10, pq.Array([]string{"image1.jpg"}), "Electronics", time.Now(), time.Now())
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow(productID, "Test Product", "Test Description", 99.99, "TEST-001", 10, imagesJSON("image1.jpg"), "Electronics", time.Now(), time.Now())...)
 
 	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
 		WithArgs(productID).
@@ -131,6 +217,25 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
+func TestCreate_DuplicateSKU(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO products`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: skuConstraint})
+	mock.ExpectRollback()
+
+	_, err := repo.Create(context.Background(), &Product{Name: "Lamp", SKU: "LAMP-001", Price: 40}, "admin-1")
+	if !errors.Is(err, ErrDuplicateSKU) {
+		t.Errorf("Expected ErrDuplicateSKU, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
 func TestGetByID_NotFound(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -144,8 +249,8 @@ func TestGetByID_NotFound(t *testing.T) {
 
 	result, err := repo.GetByID(ctx, productID)
 
-	if err == nil {
-		t.Error("Expected error, got nil")
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
 	}
 
 	if result != nil {
@@ -157,6 +262,23 @@ func TestGetByID_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetByID_MalformedID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02", Message: "invalid input syntax for type uuid"})
+
+	if _, err := repo.GetByID(context.Background(), "not-a-uuid"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
 func TestGetBySKU(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -164,8 +286,8 @@ func TestGetBySKU(t *testing.T) {
 	ctx := context.Background()
 	sku := "TEST-001"
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at"}).
-		AddRow("test-id", "Test Product", "Test Description", 99.99, sku, 10, pq.Array([]string{"image1.jpg"}), "Electronics", time.Now(), time.Now())
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("test-id", "Test Product", "Test Description", 99.99, sku, 10, imagesJSON("image1.jpg"), "Electronics", time.Now(), time.Now())...)
 
 	mock.ExpectQuery(`SELECT (.+) FROM products WHERE sku`).
 		WithArgs(sku).
@@ -190,6 +312,41 @@ func TestGetBySKU(t *testing.T) {
 	}
 }
 
+func TestGetByBarcode(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	barcodes := []string{"036000291452", "0036000291452"}
+
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("test-id", "Soda", "", 1.99, "SODA-001", 10, imagesJSON(), "Drinks", time.Now(), time.Now())...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE ean = ANY\(\$1\) OR upc = ANY\(\$1\) OR isbn = ANY\(\$1\)`).
+		WithArgs(pq.Array(barcodes)).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByBarcode(ctx, barcodes)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.ID != "test-id" {
+		t.Errorf("Expected product test-id, got %s", result.ID)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE ean`).
+		WithArgs(pq.Array(barcodes)).
+		WillReturnRows(sqlmock.NewRows(productColumnNames))
+
+	if _, err := repo.GetByBarcode(ctx, barcodes); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
 func TestList(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -203,15 +360,15 @@ func TestList(t *testing.T) {
 	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM products`).
 		WillReturnRows(countRows)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at"}).
-		AddRow("id1", "Product 1", "Description 1", 99.99, "SKU-001", 10, pq.Array([]string{"image1.jpg"}), "Electronics", time.Now(), time.Now()).
-		AddRow("id2", "Product 2", "Description 2", 149.99, "SKU-002", 20, pq.Array([]string{"image2.jpg"}), "Books", time.Now(), time.Now())
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("id1", "Product 1", "Description 1", 99.99, "SKU-001", 10, imagesJSON("image1.jpg"), "Electronics", time.Now(), time.Now())...).
+		AddRow(productRow("id2", "Product 2", "Description 2", 149.99, "SKU-002", 20, imagesJSON("image2.jpg"), "Books", time.Now(), time.Now())...)
 
-	mock.ExpectQuery(`SELECT (.+) FROM products ORDER BY created_at DESC LIMIT`).
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE status = 'ACTIVE' ORDER BY created_at DESC LIMIT`).
 		WithArgs(pageSize, int32(0)).
 		WillReturnRows(rows)
 
-	result, total, err := repo.List(ctx, page, pageSize, category)
+	result, total, err := repo.List(ctx, page, pageSize, ProductFilter{Category: category})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -244,14 +401,14 @@ func TestList_WithCategory(t *testing.T) {
 		WithArgs(category).
 		WillReturnRows(countRows)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at"}).
-		AddRow("id1", "Product 1", "Description 1", 99.99, "SKU-001", 10, pq.Array([]string{"image1.jpg"}), "Electronics", time.Now(), time.Now())
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("id1", "Product 1", "Description 1", 99.99, "SKU-001", 10, imagesJSON("image1.jpg"), "Electronics", time.Now(), time.Now())...)
 
 	mock.ExpectQuery(`SELECT (.+) FROM products WHERE category`).
 		WithArgs(category, pageSize, int32(0)).
 		WillReturnRows(rows)
 
-	result, total, err := repo.List(ctx, page, pageSize, category)
+	result, total, err := repo.List(ctx, page, pageSize, ProductFilter{Category: category})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -282,18 +439,37 @@ func TestUpdate(t *testing.T) {
 		Price:       199.99,
 		SKU:         "TEST-001",
 		Stock:       20,
-		Images:      []string{"new-image.jpg"},
+		Images:      imagesFromURLs([]string{"new-image.jpg"}),
 		Category:    "Electronics",
+		Status:      ProductStatusDraft,
+		Version:     1,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at"}).
-		AddRow(product.ID, product.Name, product.Description, product.Price, product.SKU, product.Stock, pq.Array(product.Images), product.Category, time.Now(), time.Now())
+	before := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow(product.ID, "Old Product", product.Description, 149.99, product.SKU, product.Stock, imagesJSON("new-image.jpg"), product.Category, time.Now(), time.Now())...)
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow(product.ID, product.Name, product.Description, product.Price, product.SKU, product.Stock, imagesJSON("new-image.jpg"), product.Category, time.Now(), time.Now())...)
 
-	mock.ExpectQuery(`UPDATE products SET`).
-		WithArgs(product.Name, product.Description, product.Price, product.Stock, pq.Array(product.Images), product.Category, sqlmock.AnyArg(), product.ID).
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT price FROM products WHERE id = \$1 FOR UPDATE`).
+		WithArgs(product.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"price"}).AddRow(149.99))
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs(product.ID).
+		WillReturnRows(before)
+	mock.ExpectExec(`UPDATE products SET`).
+		WithArgs(product.Name, product.Description, product.Price, product.Stock, product.Category, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil, nil, int32(0), 0.0, 0.0, 0.0, 0.0, product.ShippingClass, nullString(""), nullString(""), nullString(""), product.Status, int32(0), int32(0), int32(0), product.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectSyncImages(mock, []string{"new-image.jpg"})
+	expectRecordPriceChange(mock, product.ID, 149.99, product.Price, "admin-1")
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs(product.ID).
 		WillReturnRows(rows)
+	expectProductAudit(mock, product.ID, AuditActionUpdate, "admin-1")
+	expectProductEvent(mock, product.ID, EventProductUpdated)
+	mock.ExpectCommit()
 
-	result, err := repo.Update(ctx, product)
+	result, err := repo.Update(ctx, product, "admin-1")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -324,15 +500,17 @@ func TestUpdate_NotFound(t *testing.T) {
 		Price:       199.99,
 		SKU:         "TEST-001",
 		Stock:       20,
-		Images:      []string{"new-image.jpg"},
+		Images:      imagesFromURLs([]string{"new-image.jpg"}),
 		Category:    "Electronics",
 	}
 
-	mock.ExpectQuery(`UPDATE products SET`).
-		WithArgs(product.Name, product.Description, product.Price, product.Stock, pq.Array(product.Images), product.Category, sqlmock.AnyArg(), product.ID).
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT price FROM products WHERE id = \$1 FOR UPDATE`).
+		WithArgs(product.ID).
 		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 
-	result, err := repo.Update(ctx, product)
+	result, err := repo.Update(ctx, product, "admin-1")
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -347,6 +525,35 @@ func TestUpdate_NotFound(t *testing.T) {
 	}
 }
 
+func TestUpdate_VersionConflict(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	product := &Product{ID: "test-id", Name: "Updated Product", Price: 199.99, SKU: "TEST-001", Version: 1}
+
+	row := productRow(product.ID, "Old Product", "", 149.99, product.SKU, 20, imagesJSON(), "Electronics", time.Now(), time.Now())
+	row[productColumnIndex("version")] = 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT price FROM products WHERE id = \$1 FOR UPDATE`).
+		WithArgs(product.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"price"}).AddRow(149.99))
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs(product.ID).
+		WillReturnRows(sqlmock.NewRows(productColumnNames).AddRow(row...))
+	mock.ExpectRollback()
+
+	_, err := repo.Update(ctx, product, "admin-1")
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -354,11 +561,19 @@ func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	productID := "test-id"
 
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs(productID).
+		WillReturnRows(sqlmock.NewRows(productColumnNames).
+			AddRow(productRow(productID, "Test Product", "", 99.99, "TEST-001", 10, imagesJSON(), "Electronics", time.Now(), time.Now())...))
 	mock.ExpectExec(`DELETE FROM products WHERE id`).
 		WithArgs(productID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectProductAudit(mock, productID, AuditActionDelete, "admin-1")
+	expectProductEvent(mock, productID, EventProductDeleted)
+	mock.ExpectCommit()
 
-	err := repo.Delete(ctx, productID)
+	err := repo.Delete(ctx, productID, "admin-1")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -376,11 +591,13 @@ func TestDelete_NotFound(t *testing.T) {
 	ctx := context.Background()
 	productID := "non-existent"
 
-	mock.ExpectExec(`DELETE FROM products WHERE id`).
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
 		WithArgs(productID).
-		WillReturnResult(sqlmock.NewResult(0, 0))
+		WillReturnRows(sqlmock.NewRows(productColumnNames))
+	mock.ExpectRollback()
 
-	err := repo.Delete(ctx, productID)
+	err := repo.Delete(ctx, productID, "admin-1")
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -406,14 +623,14 @@ func TestSearch(t *testing.T) {
 		WithArgs(searchPattern).
 		WillReturnRows(countRows)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "sku", "stock", "images", "category", "created_at", "updated_at"}).
-		AddRow("id1", "Test Product", "Test Description", 99.99, "SKU-001", 10, pq.Array([]string{"image1.jpg"}), "Electronics", time.Now(), time.Now())
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("id1", "Test Product", "Test Description", 99.99, "SKU-001", 10, imagesJSON("image1.jpg"), "Electronics", time.Now(), time.Now())...)
 
 	mock.ExpectQuery(`SELECT (.+) FROM products WHERE`).
 		WithArgs(searchPattern, pageSize, int32(0)).
 		WillReturnRows(rows)
 
-	result, total, err := repo.Search(ctx, query, page, pageSize)
+	result, total, err := repo.Search(ctx, query, page, pageSize, ProductFilter{})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -431,3 +648,1489 @@ func TestSearch(t *testing.T) {
 		t.Errorf("Unfulfilled expectations: %v", err)
 	}
 }
+
+func TestSetRelations(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM product_relations WHERE product_id`).
+		WithArgs("p1", RelationCrossSell).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_relations`).
+		WithArgs("p1", "p2", RelationCrossSell, 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_relations`).
+		WithArgs("p1", "p3", RelationCrossSell, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SetRelations(ctx, "p1", RelationCrossSell, []string{"p2", "p3"})
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRelations(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows(append([]string{"relation_type", "position"}, productColumnNames...)).
+		AddRow(append([]driver.Value{RelationUpsell, 0}, productRow("p2", "Pro Model", "Better", 199.99, "SKU-002", 5, imagesJSON(), "Electronics", time.Now(), time.Now())...)...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM product_relations pr JOIN products p`).
+		WithArgs("p1", "").
+		WillReturnRows(rows)
+
+	result, err := repo.GetRelations(ctx, "p1", "")
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if len(result) != 1 || result[0].Product.ID != "p2" || result[0].RelationType != RelationUpsell {
+		t.Errorf("Unexpected relations %v", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetBundle(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO product_bundles`).
+		WithArgs("kit", 10.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM product_bundle_components WHERE bundle_id`).
+		WithArgs("kit").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_bundle_components`).
+		WithArgs("kit", "cable", int32(2), 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SetBundle(ctx, &Bundle{
+		ProductID:       "kit",
+		DiscountPercent: 10,
+		Components:      []*BundleComponent{{ProductID: "cable", Quantity: 2}},
+	})
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetBundle(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT discount_percent FROM product_bundles`).
+		WithArgs("kit").
+		WillReturnRows(sqlmock.NewRows([]string{"discount_percent"}).AddRow(10.0))
+
+	rows := sqlmock.NewRows(append([]string{"quantity"}, productColumnNames...)).
+		AddRow(append([]driver.Value{2}, productRow("cable", "Cable", "USB-C", 10, "SKU-010", 7, imagesJSON(), "Electronics", time.Now(), time.Now())...)...)
+	mock.ExpectQuery(`SELECT (.+) FROM product_bundle_components bc JOIN products p`).
+		WithArgs("kit").
+		WillReturnRows(rows)
+
+	bundle, err := repo.GetBundle(ctx, "kit")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(bundle.Components) != 1 || bundle.Components[0].Quantity != 2 || bundle.Components[0].Product.Name != "Cable" {
+		t.Errorf("Unexpected components %v", bundle.Components)
+	}
+	if bundle.Stock() != 3 {
+		t.Errorf("Expected stock 3, got %d", bundle.Stock())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetBundle_NotBundle(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT discount_percent FROM product_bundles`).
+		WithArgs("cable").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetBundle(context.Background(), "cable")
+
+	if !errors.Is(err, ErrNotBundle) {
+		t.Errorf("Expected ErrNotBundle, got %v", err)
+	}
+}
+
+func TestCreateBooking_Conflict(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	booking := &Booking{
+		ProductID: "p1",
+		StartsAt:  now.Add(24 * time.Hour),
+		EndsAt:    now.Add(48 * time.Hour),
+		Quantity:  1,
+		Status:    BookingHeld,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT enabled, capacity FROM product_booking_configs WHERE product_id = \$1 FOR UPDATE`).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"enabled", "capacity"}).AddRow(true, 1))
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(quantity\), 0\) FROM product_bookings`).
+		WithArgs("p1", booking.StartsAt, booking.EndsAt, now).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateBooking(ctx, booking, now)
+
+	if err != ErrBookingUnavailable {
+		t.Errorf("Expected ErrBookingUnavailable, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID_SalePrice(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	productID := "test-id"
+	endsAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	values := productRow(productID, "Test Product", "Test Description", 99.99, "TEST-001", 10, imagesJSON(), "Electronics", time.Now(), time.Now())
+	values[productColumnIndex("sale_price")] = 79.99
+	values[productColumnIndex("sale_ends_at")] = endsAt
+	rows := sqlmock.NewRows(productColumnNames).AddRow(values...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs(productID).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByID(ctx, productID)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.SalePrice == nil || *result.SalePrice != 79.99 {
+		t.Errorf("Expected sale price 79.99, got %v", result.SalePrice)
+	}
+	if result.SaleStartsAt != nil || result.SaleEndsAt == nil || !result.SaleEndsAt.Equal(endsAt) {
+		t.Errorf("Unexpected sale window %v - %v", result.SaleStartsAt, result.SaleEndsAt)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID_DescriptionBlocks(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	productID := "test-id"
+
+	values := productRow(productID, "Test Product", "Title", 99.99, "TEST-001", 10, imagesJSON(), "Electronics", time.Now(), time.Now())
+	values[productColumnIndex("description_blocks")] = []byte(`[{"type":"heading","text":"Title","level":1}]`)
+	rows := sqlmock.NewRows(productColumnNames).AddRow(values...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs(productID).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByID(ctx, productID)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.DescriptionBlocks) != 1 || result.DescriptionBlocks[0].Level != 1 {
+		t.Errorf("Expected decoded heading block, got %v", result.DescriptionBlocks)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetPrimaryImage(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE product_images SET is_primary = FALSE`).
+		WithArgs("p1", "img-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE product_images SET is_primary = TRUE`).
+		WithArgs("p1", "img-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SetPrimaryImage(ctx, "p1", "img-2"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetPrimaryImage_UnknownImage(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE product_images SET is_primary = FALSE`).
+		WithArgs("p1", "missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE product_images SET is_primary = TRUE`).
+		WithArgs("p1", "missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.SetPrimaryImage(ctx, "p1", "missing")
+
+	if !errors.Is(err, ErrImageNotFound) {
+		t.Errorf("Expected ErrImageNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAppendImage_ProductNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO product_images`).
+		WithArgs("missing", "https://cdn.test/a.png", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, err := repo.AppendImage(ctx, "missing", "products/missing/a.png", "https://cdn.test/a.png", "")
+
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAppendImage_RecordsUploadEvent(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO product_images`).
+		WithArgs("p1", "https://cdn.test/a.png", "Front").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("img-1"))
+	expectProductEvent(mock, "p1", EventImageUploaded)
+	mock.ExpectCommit().WillReturnError(errors.New("connection reset"))
+
+	if _, err := repo.AppendImage(ctx, "p1", "products/p1/a.png", "https://cdn.test/a.png", "Front"); err == nil {
+		t.Error("Expected commit error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetImageRenditions_UnknownImage(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectExec(`UPDATE product_images SET renditions`).
+		WithArgs("p1", "missing", []byte(`[]`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.SetImageRenditions(ctx, "p1", "missing", nil)
+
+	if !errors.Is(err, ErrImageNotFound) {
+		t.Errorf("Expected ErrImageNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestReorderImages_CountMismatch(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM \(SELECT id FROM product_images`).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectRollback()
+
+	err := repo.ReorderImages(ctx, "p1", []string{"img-1", "img-2"})
+
+	if !errors.Is(err, ErrImageOrderMismatch) {
+		t.Errorf("Expected ErrImageOrderMismatch, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPriceHistory(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	changedAt := time.Now()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM price_history WHERE product_id`).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	rows := sqlmock.NewRows([]string{"id", "product_id", "old_price", "new_price", "changed_by", "changed_at", "seq", "prev_hash", "hash"}).
+		AddRow("h2", "p1", 99.99, 79.99, "admin-1", changedAt, 2, "hash-1", "hash-2").
+		AddRow("h1", "p1", nil, 99.99, "system", changedAt.Add(-time.Hour), 1, genesisHash, "hash-1")
+
+	mock.ExpectQuery(`SELECT (.+) FROM price_history WHERE product_id`).
+		WithArgs("p1", int32(10), int32(0)).
+		WillReturnRows(rows)
+
+	changes, total, err := repo.GetPriceHistory(ctx, "p1", 1, 10)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if total != 2 || len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d (total %d)", len(changes), total)
+	}
+
+	if changes[0].OldPrice == nil || *changes[0].OldPrice != 99.99 || changes[0].NewPrice != 79.99 {
+		t.Errorf("Unexpected latest change %+v", changes[0])
+	}
+
+	if changes[1].OldPrice != nil {
+		t.Errorf("Expected initial price without old price, got %v", *changes[1].OldPrice)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListPriceChangesBetween(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	rows := sqlmock.NewRows([]string{"id", "product_id", "old_price", "new_price", "changed_by", "changed_at", "seq", "prev_hash", "hash"}).
+		AddRow("h2", "p1", 99.99, 79.99, "admin-1", from.Add(time.Hour), 2, "hash-1", "hash-2")
+
+	mock.ExpectQuery(`SELECT (.+) FROM price_history WHERE changed_at >= \$1 AND changed_at < \$2 ORDER BY seq`).
+		WithArgs(from, to).
+		WillReturnRows(rows)
+
+	changes, err := repo.ListPriceChangesBetween(ctx, from, to)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].ChangedBy != "admin-1" || changes[0].Seq != 2 {
+		t.Errorf("Unexpected changes %+v", changes)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetPriceTiers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM product_price_tiers WHERE product_id`).
+		WithArgs("p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_price_tiers`).
+		WithArgs("p1", int32(10), 8.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_price_tiers`).
+		WithArgs("p1", int32(50), 7.5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SetPriceTiers(ctx, "p1", []*PriceTier{{MinQuantity: 10, UnitPrice: 8}, {MinQuantity: 50, UnitPrice: 7.5}})
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPriceTiers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"min_quantity", "unit_price"}).
+		AddRow(10, 8.0).
+		AddRow(50, 7.5)
+
+	mock.ExpectQuery(`SELECT min_quantity, unit_price FROM product_price_tiers WHERE product_id`).
+		WithArgs("p1").
+		WillReturnRows(rows)
+
+	tiers, err := repo.GetPriceTiers(ctx, "p1")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(tiers) != 2 || tiers[0].MinQuantity != 10 || tiers[1].UnitPrice != 7.5 {
+		t.Errorf("Unexpected tiers %v", tiers)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAdjustStock(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery(`UPDATE products SET stock = stock \+ \$2, updated_at = \$3 WHERE id = \$1 AND stock \+ \$2 >= 0 AND product_type = 'PHYSICAL' RETURNING stock, low_stock_threshold`).
+		WithArgs("p1", int32(-3), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"stock", "low_stock_threshold"}).AddRow(7, 10))
+
+	level, err := repo.AdjustStock(ctx, "p1", -3)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if level.Stock != 7 || level.LowStockThreshold != 10 || !level.LowStock() {
+		t.Errorf("Unexpected stock level %+v", level)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAdjustStock_NoMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{"insufficient stock", sqlmock.NewRows([]string{"product_type"}).AddRow(ProductTypePhysical), ErrInsufficientStock},
+		{"digital product", sqlmock.NewRows([]string{"product_type"}).AddRow(ProductTypeDigital), ErrStockNotTracked},
+		{"missing product", sqlmock.NewRows([]string{"product_type"}), ErrProductNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+
+			mock.ExpectQuery(`UPDATE products SET stock`).
+				WithArgs("p1", int32(-3), sqlmock.AnyArg()).
+				WillReturnError(sql.ErrNoRows)
+			mock.ExpectQuery(`SELECT product_type FROM products`).
+				WithArgs("p1").
+				WillReturnRows(tt.rows)
+
+			_, err := repo.AdjustStock(context.Background(), "p1", -3)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateStockReservation(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	expiresAt := time.Date(2025, 6, 1, 12, 15, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE products SET stock = stock - \$2, updated_at = \$3 WHERE id = \$1 AND stock >= \$2 AND product_type = 'PHYSICAL' RETURNING stock, low_stock_threshold`).
+		WithArgs("p1", int32(2), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"stock", "low_stock_threshold"}).AddRow(3, 0))
+	mock.ExpectQuery(`INSERT INTO stock_reservations`).
+		WithArgs("p1", int32(2), ReservationHeld, "cart-1", &expiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "quantity", "status", "reference", "expires_at", "created_at"}).
+			AddRow("r1", "p1", 2, ReservationHeld, "cart-1", expiresAt, createdAt))
+	mock.ExpectCommit()
+
+	res, level, err := repo.CreateStockReservation(context.Background(), &StockReservation{
+		ProductID: "p1",
+		Quantity:  2,
+		Status:    ReservationHeld,
+		Reference: "cart-1",
+		ExpiresAt: &expiresAt,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if res.ID != "r1" || res.ExpiresAt == nil || !res.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Unexpected reservation %+v", res)
+	}
+
+	if level.Stock != 3 {
+		t.Errorf("Expected remaining stock 3, got %d", level.Stock)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateStockReservation_InsufficientStock(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE products SET stock`).
+		WithArgs("p1", int32(9), sqlmock.AnyArg()).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT product_type FROM products`).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"product_type"}).AddRow(ProductTypePhysical))
+	mock.ExpectRollback()
+
+	_, _, err := repo.CreateStockReservation(context.Background(), &StockReservation{ProductID: "p1", Quantity: 9, Status: ReservationHeld})
+
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("Expected ErrInsufficientStock, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestExpireStockReservations(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WITH expired AS \(\s*UPDATE stock_reservations SET status = 'EXPIRED'`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.ExpireStockReservations(context.Background(), now)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("Expected 2 expired reservations, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListLowStock(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM products WHERE low_stock_threshold > 0 AND stock <= low_stock_threshold`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	row := productRow("id1", "Product 1", "Description 1", 99.99, "SKU-001", 2, imagesJSON(), "Electronics", time.Now(), time.Now())
+	row[productColumnIndex("low_stock_threshold")] = 5
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE low_stock_threshold > 0 AND stock <= low_stock_threshold ORDER BY stock, name LIMIT`).
+		WithArgs(int32(10), int32(10)).
+		WillReturnRows(sqlmock.NewRows(productColumnNames).AddRow(row...))
+
+	products, total, err := repo.ListLowStock(context.Background(), 2, 10)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if total != 1 || len(products) != 1 || products[0].LowStockThreshold != 5 {
+		t.Errorf("Unexpected result %v (total %d)", products, total)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRevokeEntitlement_NotActive(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`UPDATE digital_entitlements SET revoked_at`).
+		WithArgs("buyer", "ebook", sqlmock.AnyArg()).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.RevokeEntitlement(context.Background(), "buyer", "ebook", time.Now())
+
+	if !errors.Is(err, ErrEntitlementNotFound) {
+		t.Errorf("Expected ErrEntitlementNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetTranslations(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"product_id", "locale", "name", "description", "created_at", "updated_at"}).
+		AddRow("prod-1", "fr", "Lampe de bureau", "", now, now)
+
+	mock.ExpectQuery(`SELECT (.+) FROM product_translations WHERE product_id = ANY\(\$1\) AND locale = ANY\(\$2\)`).
+		WithArgs(pq.Array([]string{"prod-1", "prod-2"}), pq.Array([]string{"fr-CA", "fr"})).
+		WillReturnRows(rows)
+
+	translations, err := repo.GetTranslations(context.Background(), []string{"prod-1", "prod-2"}, []string{"fr-CA", "fr"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(translations) != 1 || translations[0].Locale != "fr" || translations[0].Name != "Lampe de bureau" {
+		t.Errorf("Unexpected translations %+v", translations)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteTranslation_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`DELETE FROM product_translations`).
+		WithArgs("prod-1", "fr").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteTranslation(context.Background(), "prod-1", "fr")
+	if !errors.Is(err, ErrTranslationNotFound) {
+		t.Errorf("Expected ErrTranslationNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestList_ByRating(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	filter := ProductFilter{Category: "Books", MinRating: 4, SortBy: SortRating}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM products WHERE category = \$1 AND average_rating >= \$2 AND status = 'ACTIVE'`).
+		WithArgs("Books", 4.0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("id1", "Product 1", "Description 1", 9.99, "SKU-001", 10, imagesJSON(), "Books", time.Now(), time.Now())...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE category = \$1 AND average_rating >= \$2 AND status = 'ACTIVE' ORDER BY average_rating DESC, review_count DESC, created_at DESC LIMIT \$3 OFFSET \$4`).
+		WithArgs("Books", 4.0, int32(10), int32(10)).
+		WillReturnRows(rows)
+
+	result, total, err := repo.List(context.Background(), 2, 10, filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 1 || total != 1 {
+		t.Errorf("Expected 1 product of 1, got %d of %d", len(result), total)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestList_ByPopularity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	filter := ProductFilter{SortBy: SortPopularity}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM products WHERE status = 'ACTIVE'`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("id1", "Product 1", "Description 1", 9.99, "SKU-001", 10, imagesJSON(), "Books", time.Now(), time.Now())...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE status = 'ACTIVE' ORDER BY \(SELECT COALESCE\(SUM\(d.units\), 0\) FROM product_activity_daily d (.+), created_at DESC LIMIT \$1 OFFSET \$2`).
+		WithArgs(int32(10), int32(0)).
+		WillReturnRows(rows)
+
+	result, _, err := repo.List(context.Background(), 1, 10, filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("Expected 1 product, got %d", len(result))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateRatingAggregate_Stale(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	agg := RatingAggregate{AverageRating: 4.5, ReviewCount: 2, AsOf: time.Now()}
+
+	mock.ExpectExec(`UPDATE products SET average_rating = \$2, review_count = \$3, rating_updated_at = \$4 WHERE id = \$1 AND \(rating_updated_at IS NULL OR rating_updated_at <= \$4\)`).
+		WithArgs("prod-1", 4.5, int32(2), agg.AsOf).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs("prod-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	applied, err := repo.UpdateRatingAggregate(context.Background(), "prod-1", agg)
+	if err != nil || applied {
+		t.Errorf("Expected stale aggregate to be skipped, got %v, %v", applied, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestChangeSKU(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT sku FROM products WHERE id = \$1 FOR UPDATE`).
+		WithArgs("prod-1").
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("LAMP-002"))
+	// LAMP-001 is a former SKU of the same product and is reclaimed
+	mock.ExpectQuery(`SELECT product_id FROM product_sku_aliases WHERE sku`).
+		WithArgs("LAMP-001").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow("prod-1"))
+	mock.ExpectExec(`DELETE FROM product_sku_aliases WHERE sku`).
+		WithArgs("LAMP-001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE products SET sku`).
+		WithArgs("prod-1", "LAMP-001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_sku_aliases`).
+		WithArgs("LAMP-002", "prod-1", "admin-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectProductAudit(mock, "prod-1", AuditActionUpdate, "admin-1")
+	expectProductEvent(mock, "prod-1", EventProductUpdated)
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WithArgs("prod-1").
+		WillReturnRows(sqlmock.NewRows(productColumnNames).
+			AddRow(productRow("prod-1", "Lamp", "", 40.0, "LAMP-001", 5, imagesJSON(), "Home", time.Now(), time.Now())...))
+
+	product, err := repo.ChangeSKU(ctx, "prod-1", "LAMP-001", "admin-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if product.SKU != "LAMP-001" {
+		t.Errorf("Expected SKU LAMP-001, got %s", product.SKU)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestChangeSKU_AliasOfAnotherProduct(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT sku FROM products WHERE id = \$1 FOR UPDATE`).
+		WithArgs("prod-1").
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("LAMP-002"))
+	mock.ExpectQuery(`SELECT product_id FROM product_sku_aliases WHERE sku`).
+		WithArgs("MUG-001").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow("prod-2"))
+	mock.ExpectRollback()
+
+	_, err := repo.ChangeSKU(context.Background(), "prod-1", "MUG-001", "admin-1")
+	if !errors.Is(err, ErrDuplicateSKU) {
+		t.Errorf("Expected ErrDuplicateSKU, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetBySKUs(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	columns := append([]string{"sku"}, productColumnNames...)
+	mock.ExpectQuery(`SELECT DISTINCT ON \(s.sku\) s.sku, (.+) FROM unnest\(\$1::text\[\]\) AS s\(sku\) JOIN products p`).
+		WithArgs(pq.Array([]string{"LAMP-001", "MUG-001", "BOWL-001"})).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(append([]driver.Value{"LAMP-001"}, productRow("prod-1", "Lamp", "", 40.0, "LAMP-002", 5, imagesJSON(), "Home", time.Now(), time.Now())...)...).
+			AddRow(append([]driver.Value{"MUG-001"}, productRow("prod-2", "Mug", "", 9.99, "MUG-001", 12, imagesJSON(), "Kitchen", time.Now(), time.Now())...)...))
+
+	products, err := repo.GetBySKUs(context.Background(), []string{"LAMP-001", "MUG-001", "BOWL-001"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("Expected 2 products, got %d", len(products))
+	}
+	if p := products["LAMP-001"]; p == nil || p.ID != "prod-1" || p.SKU != "LAMP-002" {
+		t.Errorf("Expected former SKU LAMP-001 to resolve to prod-1, got %+v", p)
+	}
+	if _, ok := products["BOWL-001"]; ok {
+		t.Error("Expected BOWL-001 to be missing")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	row := productRow("prod-2", "Lamp", "", 40.0, "LAMP-002", 0, imagesJSON("lamp.jpg"), "Home", time.Now(), time.Now())
+	row[productColumnIndex("status")] = ProductStatusDraft
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO products (.+) SELECT (.+) FROM products WHERE id = \$6`).
+		WithArgs(sqlmock.AnyArg(), "", "LAMP-002", sqlmock.AnyArg(), ProductStatusDraft, "prod-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_images (.+) SELECT (.+) FROM product_images WHERE product_id = \$2`).
+		WithArgs(sqlmock.AnyArg(), "prod-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_price_tiers (.+) SELECT (.+) FROM product_price_tiers WHERE product_id = \$2`).
+		WithArgs(sqlmock.AnyArg(), "prod-1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT INTO product_translations (.+) SELECT (.+) FROM product_translations WHERE product_id = \$2`).
+		WithArgs(sqlmock.AnyArg(), "prod-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectRecordPriceChange(mock, sqlmock.AnyArg(), nil, 40.0, "admin-1")
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE id`).
+		WillReturnRows(sqlmock.NewRows(productColumnNames).AddRow(row...))
+	expectProductAudit(mock, sqlmock.AnyArg(), AuditActionCreate, "admin-1")
+	expectProductEvent(mock, sqlmock.AnyArg(), EventProductCreated)
+	mock.ExpectCommit()
+
+	clone, err := repo.Clone(ctx, "prod-1", "LAMP-002", "", "admin-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if clone.Status != ProductStatusDraft || clone.SKU != "LAMP-002" || len(clone.Images) != 1 {
+		t.Errorf("Unexpected clone %+v", clone)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClone_SourceNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO products`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.Clone(context.Background(), "missing", "LAMP-002", "", "admin-1")
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestList_IncludeDrafts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM products$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`SELECT (.+) FROM products ORDER BY created_at DESC LIMIT`).
+		WithArgs(int32(10), int32(0)).
+		WillReturnRows(sqlmock.NewRows(productColumnNames))
+
+	if _, _, err := repo.List(context.Background(), 1, 10, ProductFilter{IncludeDrafts: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListUpdatedSince(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE updated_at >= \$1 ORDER BY updated_at, id LIMIT \$2`).
+		WithArgs(since, 2).
+		WillReturnRows(sqlmock.NewRows(productColumnNames).
+			AddRow(productRow("prod-1", "Lamp", "", 40.0, "LAMP-001", 5, imagesJSON(), "Home", since, since)...).
+			AddRow(productRow("prod-2", "Mug", "", 10.0, "MUG-001", 5, imagesJSON(), "Home", since, since)...))
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE \(updated_at, id\) > \(\$1, \$2\) ORDER BY updated_at, id LIMIT \$3`).
+		WithArgs(since, "prod-2", 2).
+		WillReturnRows(sqlmock.NewRows(productColumnNames))
+
+	products, err := repo.ListUpdatedSince(ctx, ProductCursor{UpdatedAt: since}, 2)
+	if err != nil || len(products) != 2 {
+		t.Fatalf("Expected 2 products, got %v, %v", products, err)
+	}
+	products, err = repo.ListUpdatedSince(ctx, ProductCursor{UpdatedAt: since, ID: "prod-2"}, 2)
+	if err != nil || len(products) != 0 {
+		t.Fatalf("Expected no products, got %v, %v", products, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetProductAuditLog(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM product_audit_log WHERE product_id`).
+		WithArgs("prod-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT (.+) FROM product_audit_log WHERE product_id = \$1 ORDER BY id DESC`).
+		WithArgs("prod-1", int32(10), int32(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "action", "actor", "changes", "created_at"}).
+			AddRow(2, "prod-1", AuditActionDelete, "admin-2", []byte(`[{"field":"name","old":"Lamp","new":""}]`), now).
+			AddRow(1, "prod-1", AuditActionCreate, "admin-1", []byte(`[{"field":"name","old":"","new":"Lamp"}]`), now.Add(-time.Hour)))
+
+	entries, total, err := repo.GetProductAuditLog(ctx, "prod-1", 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 2 || len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d (total %d)", len(entries), total)
+	}
+	if entries[0].Action != AuditActionDelete || entries[0].Actor != "admin-2" {
+		t.Errorf("Unexpected latest entry %+v", entries[0])
+	}
+	if len(entries[1].Changes) != 1 || entries[1].Changes[0] != (FieldChange{Field: "name", New: "Lamp"}) {
+		t.Errorf("Unexpected changes %+v", entries[1].Changes)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetCategoryStats(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COALESCE\(category, ''\), COUNT\(\*\), MIN\(price\), MAX\(price\), COALESCE\(SUM\(stock\), 0\) FROM products WHERE \$1 OR status = 'ACTIVE' GROUP BY`).
+		WithArgs(false).
+		WillReturnRows(sqlmock.NewRows([]string{"category", "count", "min", "max", "sum"}).
+			AddRow("", 1, 5.0, 5.0, 3).
+			AddRow("Home", 4, 9.99, 120.0, 57))
+
+	stats, err := repo.GetCategoryStats(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 categories, got %d", len(stats))
+	}
+	if s := stats[1]; s.Category != "Home" || s.ProductCount != 4 || s.MinPrice != 9.99 || s.MaxPrice != 120 || s.TotalStock != 57 {
+		t.Errorf("Unexpected category stats %+v", s)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecordActivity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	day := time.Date(2026, 5, 19, 0, 0, 0, 0, time.UTC)
+	events := []*ProductActivity{
+		{EventID: "order-1:1", ProductID: "prod-1", Type: ActivityOrder, Quantity: 3, OccurredAt: day.Add(13 * time.Hour)},
+		{EventID: "order-1:1", ProductID: "prod-1", Type: ActivityOrder, Quantity: 3, OccurredAt: day.Add(13 * time.Hour)},
+		{EventID: "view-1", ProductID: "missing", Type: ActivityView, Quantity: 1, OccurredAt: day},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO product_activity_events`).
+		WithArgs("order-1:1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_activity_daily (.+) WHERE EXISTS (.+) ON CONFLICT \(product_id, day\) DO UPDATE`).
+		WithArgs("prod-1", day, int32(0), int32(1), int32(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Redelivered event
+	mock.ExpectExec(`INSERT INTO product_activity_events`).
+		WithArgs("order-1:1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// Unknown product
+	mock.ExpectExec(`INSERT INTO product_activity_events`).
+		WithArgs("view-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO product_activity_daily`).
+		WithArgs("missing", day, int32(1), int32(0), int32(0)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	recorded, err := repo.RecordActivity(context.Background(), events)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recorded != 1 {
+		t.Errorf("Expected 1 recorded event, got %d", recorded)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListBestSellers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	since := time.Date(2026, 5, 14, 0, 0, 0, 0, time.UTC)
+	columns := append([]string{"units", "orders", "views"}, productColumnNames...)
+
+	mock.ExpectQuery(`SELECT a.units, a.orders, a.views, (.+) FROM \( SELECT product_id, SUM\(units\)(.+) JOIN products p (.+) ORDER BY a.units DESC`).
+		WithArgs(since, "Home", 10).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(append([]driver.Value{12, 5, 80}, productRow("prod-1", "Lamp", "", 40.0, "LAMP-001", 5, imagesJSON(), "Home", time.Now(), time.Now())...)...))
+
+	sellers, err := repo.ListBestSellers(context.Background(), since, "Home", 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sellers) != 1 || sellers[0].UnitsSold != 12 || sellers[0].OrderCount != 5 || sellers[0].ViewCount != 80 || sellers[0].Product.ID != "prod-1" {
+		t.Errorf("Unexpected best sellers %+v", sellers)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestList_ByChannel(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	filter := ProductFilter{Category: "Tools", Channel: ChannelB2B, CustomerGroup: "wholesale"}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM products WHERE category = \$1 AND status = 'ACTIVE' AND NOT EXISTS \( SELECT 1 FROM product_visibility v WHERE v.product_id = products.id AND (.+) \$2 = ANY\(v.channels\)(.+) \$3 = ANY\(v.customer_groups\)\)\)`).
+		WithArgs("Tools", ChannelB2B, "wholesale").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("id1", "Drill", "", 99.0, "DRILL-001", 10, imagesJSON(), "Tools", time.Now(), time.Now())...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE (.+) AND NOT EXISTS (.+) ORDER BY created_at DESC LIMIT \$4 OFFSET \$5`).
+		WithArgs("Tools", ChannelB2B, "wholesale", int32(10), int32(0)).
+		WillReturnRows(rows)
+
+	result, total, err := repo.List(context.Background(), 1, 10, filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 1 || total != 1 {
+		t.Errorf("Expected 1 product of 1, got %d of %d", len(result), total)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetVisibility(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	updatedAt := time.Now()
+	mock.ExpectQuery(`INSERT INTO product_visibility (.+) ON CONFLICT \(product_id\) DO UPDATE (.+) RETURNING updated_at`).
+		WithArgs("prod-1", pq.Array([]string{ChannelB2B}), pq.Array([]string{"wholesale"})).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+	saved, err := repo.SetVisibility(context.Background(), &ProductVisibility{
+		ProductID:      "prod-1",
+		Channels:       []string{ChannelB2B},
+		CustomerGroups: []string{"wholesale"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !saved.UpdatedAt.Equal(updatedAt) || saved.Channels[0] != ChannelB2B {
+		t.Errorf("Unexpected visibility %+v", saved)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetVisibility_NoRules(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT channels, customer_groups, updated_at FROM product_visibility WHERE product_id = \$1`).
+		WithArgs("prod-1").
+		WillReturnError(sql.ErrNoRows)
+
+	v, err := repo.GetVisibility(context.Background(), "prod-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if v.ProductID != "prod-1" || len(v.Channels) != 0 || len(v.CustomerGroups) != 0 || !v.UpdatedAt.IsZero() {
+		t.Errorf("Expected no rules, got %+v", v)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFuzzySearch(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM products WHERE word_similarity\(\$1, LOWER\(name\)\) >= \$2 AND status = 'ACTIVE'`).
+		WithArgs("lmap", 0.3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	rows := sqlmock.NewRows(productColumnNames).
+		AddRow(productRow("id1", "Desk Lamp", "", 40.0, "LAMP-001", 5, imagesJSON(), "Home", time.Now(), time.Now())...)
+
+	mock.ExpectQuery(`SELECT (.+) FROM products WHERE word_similarity(.+) ORDER BY word_similarity\(\$1, LOWER\(name\)\) DESC, created_at DESC LIMIT \$3 OFFSET \$4`).
+		WithArgs("lmap", 0.3, int32(10), int32(0)).
+		WillReturnRows(rows)
+
+	products, total, err := repo.FuzzySearch(context.Background(), "Lmap", 0.3, 1, 10, ProductFilter{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(products) != 1 || total != 1 || products[0].Name != "Desk Lamp" {
+		t.Errorf("Unexpected results %v (%d)", products, total)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSuggestQuery(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COALESCE\(\( SELECT w FROM products, regexp_split_to_table(.+) WHERE similarity\(w, q.word\) >= \$2 AND category = \$3 AND status = 'ACTIVE' (.+) FROM unnest\(\$1::text\[\]\) WITH ORDINALITY`).
+		WithArgs(pq.Array([]string{"desk", "lmap"}), 0.3, "Home").
+		WillReturnRows(sqlmock.NewRows([]string{"word"}).AddRow("desk").AddRow("lamp"))
+
+	suggestion, err := repo.SuggestQuery(context.Background(), " Desk  Lmap", 0.3, ProductFilter{Category: "Home"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if suggestion != "desk lamp" {
+		t.Errorf("Expected %q, got %q", "desk lamp", suggestion)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSuggestProducts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`\(SELECT 'PRODUCT', id::text, name FROM products WHERE LOWER\(name\) LIKE \$1 AND status = 'ACTIVE' ORDER BY LOWER\(name\), id LIMIT \$2\) UNION ALL \(SELECT DISTINCT 'CATEGORY', '', category FROM products WHERE LOWER\(category\) LIKE \$1 AND status = 'ACTIVE' ORDER BY category LIMIT \$2\)`).
+		WithArgs(`50\%\_off%`, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "id", "name"}).
+			AddRow("PRODUCT", "prod-1", "50%_off Lamp").
+			AddRow("CATEGORY", "", "50%_off Deals"))
+
+	products, categories, err := repo.SuggestProducts(context.Background(), "50%_Off", 5, ProductFilter{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(products) != 1 || products[0].ID != "prod-1" || len(categories) != 1 || categories[0] != "50%_off Deals" {
+		t.Errorf("Unexpected suggestions %+v, %v", products, categories)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListQuestions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	columns := []string{"id", "product_id", "user_id", "body", "status", "moderated_by", "moderated_at", "created_at"}
+	answerColumns := []string{"id", "question_id", "user_id", "body", "status", "moderated_by", "moderated_at", "created_at"}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM product_questions WHERE product_id = \$1 AND status = \$2`).
+		WithArgs("prod-1", ModerationApproved).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT (.+) FROM product_questions WHERE product_id = \$1 AND status = \$2 ORDER BY created_at DESC`).
+		WithArgs("prod-1", ModerationApproved, int32(10), int32(0)).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("q-2", "prod-1", "user-2", "Is it waterproof?", ModerationApproved, "mod-1", now, now).
+			AddRow("q-1", "prod-1", "user-1", "Does it fold?", ModerationApproved, nil, nil, now.Add(-time.Hour)))
+	mock.ExpectQuery(`SELECT (.+) FROM product_answers WHERE question_id = ANY\(\$1\) AND \(\$2 = '' OR status = \$2\) ORDER BY created_at`).
+		WithArgs(pq.Array([]string{"q-2", "q-1"}), ModerationApproved).
+		WillReturnRows(sqlmock.NewRows(answerColumns).
+			AddRow("a-1", "q-1", "user-3", "Yes, in half.", ModerationApproved, "mod-1", now, now))
+
+	questions, total, err := repo.ListQuestions(context.Background(), "prod-1", ModerationApproved, ModerationApproved, 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 2 || len(questions) != 2 {
+		t.Fatalf("Expected 2 questions, got %d (total %d)", len(questions), total)
+	}
+	if questions[0].ModeratedBy != "mod-1" || questions[0].ModeratedAt == nil || len(questions[0].Answers) != 0 {
+		t.Errorf("Unexpected first question %+v", questions[0])
+	}
+	if questions[1].ModeratedAt != nil || len(questions[1].Answers) != 1 || questions[1].Answers[0].ID != "a-1" {
+		t.Errorf("Expected the answer on the second question, got %+v", questions[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestModerateQuestion_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`UPDATE product_questions SET status = \$2, moderated_by = \$3, moderated_at = \$4 WHERE id = \$1 RETURNING`).
+		WithArgs("q-1", ModerationRejected, "mod-1", sqlmock.AnyArg()).
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.ModerateQuestion(context.Background(), "q-1", ModerationRejected, "mod-1", time.Now()); !errors.Is(err, ErrQuestionNotFound) {
+		t.Errorf("Expected ErrQuestionNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestApplyInventoryUpdates(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	lockColumns := []string{"id", "stock", "price", "sale_price", "low_stock_threshold", "product_type"}
+	mock.ExpectBegin()
+	// Products are locked in SKU order
+	mock.ExpectQuery(`SELECT id, stock, price, sale_price, low_stock_threshold, product_type FROM products\s+WHERE sku = \$1 OR id = \(SELECT product_id FROM product_sku_aliases WHERE sku = \$1\)(.+)FOR UPDATE`).
+		WithArgs("SKU-A").
+		WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("p1", 10, 20.0, nil, 0, ProductTypePhysical))
+	mock.ExpectExec(`UPDATE products SET stock = \$2, price = \$3, updated_at = \$4, version = version \+ 1 WHERE id = \$1`).
+		WithArgs("p1", int32(4), 25.0, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRecordPriceChange(mock, "p1", 20.0, 25.0, "erp-sync")
+	expectProductAudit(mock, "p1", AuditActionUpdate, "erp-sync")
+	expectProductEvent(mock, "p1", EventProductUpdated)
+	mock.ExpectQuery(`SELECT (.+) FROM products`).
+		WithArgs("SKU-B").
+		WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("p2", 3, 9.5, nil, 0, ProductTypePhysical))
+	mock.ExpectQuery(`SELECT (.+) FROM products`).
+		WithArgs("SKU-C").
+		WillReturnRows(sqlmock.NewRows(lockColumns))
+	mock.ExpectQuery(`SELECT (.+) FROM products`).
+		WithArgs("SKU-D").
+		WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("p4", 0, 30.0, 28.0, 0, ProductTypePhysical))
+	mock.ExpectCommit()
+
+	results, err := repo.ApplyInventoryUpdates(context.Background(), []*InventoryUpdate{
+		{SKU: "SKU-D", Price: 27},
+		{SKU: "SKU-C", SetStock: true, Stock: 1},
+		{SKU: "SKU-B", SetStock: true, Stock: 3, Price: 9.5},
+		{SKU: "SKU-A", SetStock: true, Stock: 4, Price: 25},
+	}, "erp-sync")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []string{InventoryRejected, InventoryNotFound, InventoryUnchanged, InventoryUpdated}
+	for i, r := range results {
+		if r.Status != want[i] {
+			t.Errorf("Expected %s %s, got %s", r.SKU, want[i], r.Status)
+		}
+	}
+	if a := results[3]; a.OldStock != 10 || a.NewStock != 4 || a.OldPrice != 20 || a.NewPrice != 25 {
+		t.Errorf("Unexpected result %+v", a)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSubscribeBackInStock_UnknownProduct(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`INSERT INTO back_in_stock_subscriptions (.+) ON CONFLICT \(product_id, user_id\) DO UPDATE`).
+		WithArgs("prod-1", "user-1", sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	_, err := repo.SubscribeBackInStock(context.Background(), &BackInStockSubscription{ProductID: "prod-1", UserID: "user-1", CreatedAt: time.Now()})
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClaimBackInStock(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE back_in_stock_subscriptions s SET claimed_until = \$1 FROM products p (.+)bp.stock > 0 AND bp.product_type = 'PHYSICAL' AND bp.status = 'ACTIVE'(.+)FOR UPDATE OF b SKIP LOCKED`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "prod-1", 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "user_id", "created_at", "name"}).
+			AddRow("sub-1", "prod-1", "user-1", now, "Kettle"))
+
+	subs, err := repo.ClaimBackInStock(context.Background(), "prod-1", 100, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(subs) != 1 || subs[0].ID != "sub-1" || subs[0].ProductName != "Kettle" {
+		t.Errorf("Unexpected subscriptions %+v", subs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListSalesVelocity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	until := time.Date(2026, 5, 29, 0, 0, 0, 0, time.UTC)
+	since := until.AddDate(0, 0, -28)
+	mock.ExpectQuery(`SELECT p.id, p.sku, p.name, p.stock, p.low_stock_threshold, COALESCE\(a.units, 0\) FROM products p LEFT JOIN (.+)WHERE day >= \$1 AND day < \$2(.+)p.product_type = 'PHYSICAL' AND p.status = 'ACTIVE'`).
+		WithArgs(since, until).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku", "name", "stock", "low_stock_threshold", "units"}).
+			AddRow("prod-1", "KET-1", "Kettle", 12, 5, 56).
+			AddRow("prod-2", "MUG-1", "Mug", 40, 0, 0))
+
+	velocities, err := repo.ListSalesVelocity(context.Background(), since, until)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(velocities) != 2 || velocities[0].UnitsSold != 56 || velocities[0].LowStockThreshold != 5 || velocities[1].UnitsSold != 0 {
+		t.Errorf("Unexpected sales velocity %+v", velocities)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Stock reservation statuses
+const (
+	ReservationHeld      = "HELD"
+	ReservationCommitted = "COMMITTED"
+	ReservationReleased  = "RELEASED"
+	ReservationExpired   = "EXPIRED"
+)
+
+var (
+	// ErrReservationNotFound is returned when a stock reservation does not exist
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrReservationStateChanged is returned when a reservation is no longer held
+	ErrReservationStateChanged = errors.New("reservation status has changed")
+)
+
+// StockReservation is stock held for a checkout until it is committed,
+// released or expires
+type StockReservation struct {
+	ID        string
+	ProductID string
+	Quantity  int32
+	Status    string
+	Reference string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+const reservationColumns = "id, product_id, quantity, status, reference, expires_at, created_at"
+
+// CreateStockReservation deducts the reserved quantity from the product's
+// stock and records the hold in one transaction, so concurrent checkouts
+// cannot oversell. It returns the reservation with the remaining stock, or
+// ErrInsufficientStock without changing stock when not enough is available.
+func (r *postgresRepository) CreateStockReservation(ctx context.Context, res *StockReservation) (*StockReservation, *StockLevel, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	level := &StockLevel{ProductID: res.ProductID}
+	err = tx.QueryRowContext(ctx,
+		"UPDATE products SET stock = stock - $2, updated_at = $3 WHERE id = $1 AND stock >= $2 AND product_type = 'PHYSICAL' RETURNING stock, low_stock_threshold",
+		res.ProductID, res.Quantity, time.Now(),
+	).Scan(&level.Stock, &level.LowStockThreshold)
+	if err == sql.ErrNoRows {
+		return nil, nil, r.stockGuardError(ctx, tx, res.ProductID, "reserve stock")
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to deduct reserved stock", map[string]interface{}{"error": err.Error(), "product_id": res.ProductID})
+		return nil, nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO stock_reservations (product_id, quantity, status, reference, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + reservationColumns
+
+	created, err := scanReservation(tx.QueryRowContext(ctx, insertQuery,
+		res.ProductID,
+		res.Quantity,
+		res.Status,
+		res.Reference,
+		res.ExpiresAt,
+	))
+	if err != nil {
+		r.log.Error(ctx, "Failed to create reservation", map[string]interface{}{"error": err.Error(), "product_id": res.ProductID})
+		return nil, nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit reservation", map[string]interface{}{"error": err.Error(), "product_id": res.ProductID})
+		return nil, nil, fmt.Errorf("failed to commit reservation: %w", err)
+	}
+
+	r.log.Info(ctx, "Stock reserved", map[string]interface{}{"reservation_id": created.ID, "product_id": created.ProductID, "quantity": created.Quantity, "stock": level.Stock})
+	return created, level, nil
+}
+
+// GetStockReservation retrieves a stock reservation by ID
+func (r *postgresRepository) GetStockReservation(ctx context.Context, id string) (*StockReservation, error) {
+	query := "SELECT " + reservationColumns + " FROM stock_reservations WHERE id = $1"
+
+	res, err := scanReservation(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get reservation", map[string]interface{}{"error": err.Error(), "reservation_id": id})
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	return res, nil
+}
+
+// CommitStockReservation turns a hold that has not expired by now into a
+// committed reservation; the stock stays deducted. It returns
+// ErrReservationStateChanged if the reservation is no longer held.
+func (r *postgresRepository) CommitStockReservation(ctx context.Context, id string, now time.Time) (*StockReservation, error) {
+	query := `
+		UPDATE stock_reservations
+		SET status = 'COMMITTED', expires_at = NULL
+		WHERE id = $1 AND status = 'HELD' AND expires_at > $2
+		RETURNING ` + reservationColumns
+
+	res, err := scanReservation(r.db.QueryRowContext(ctx, query, id, now))
+	if err == sql.ErrNoRows {
+		return nil, ErrReservationStateChanged
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to commit reservation", map[string]interface{}{"error": err.Error(), "reservation_id": id})
+		return nil, fmt.Errorf("failed to commit reservation: %w", err)
+	}
+
+	r.log.Info(ctx, "Reservation committed", map[string]interface{}{"reservation_id": id})
+	return res, nil
+}
+
+// ReleaseStockReservation releases a held reservation and returns its
+// quantity to the product's stock. It returns ErrReservationStateChanged if
+// the reservation is no longer held.
+func (r *postgresRepository) ReleaseStockReservation(ctx context.Context, id string) (*StockReservation, error) {
+	return r.releaseReservation(ctx, id, ReservationHeld)
+}
+
+// UncommitStockReservation releases a committed reservation and returns its
+// quantity to the product's stock. It returns ErrReservationStateChanged if
+// the reservation is no longer committed.
+func (r *postgresRepository) UncommitStockReservation(ctx context.Context, id string) (*StockReservation, error) {
+	return r.releaseReservation(ctx, id, ReservationCommitted)
+}
+
+// releaseReservation marks a reservation in status from as RELEASED and
+// returns its quantity to the product's stock in one transaction
+func (r *postgresRepository) releaseReservation(ctx context.Context, id, from string) (*StockReservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE stock_reservations
+		SET status = 'RELEASED', expires_at = NULL
+		WHERE id = $1 AND status = $2
+		RETURNING ` + reservationColumns
+
+	res, err := scanReservation(tx.QueryRowContext(ctx, query, id, from))
+	if err == sql.ErrNoRows {
+		return nil, ErrReservationStateChanged
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to release reservation", map[string]interface{}{"error": err.Error(), "reservation_id": id})
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE products SET stock = stock + $2, updated_at = $3 WHERE id = $1",
+		res.ProductID, res.Quantity, time.Now(),
+	)
+	if err != nil {
+		r.log.Error(ctx, "Failed to restore reserved stock", map[string]interface{}{"error": err.Error(), "reservation_id": id})
+		return nil, fmt.Errorf("failed to restore reserved stock: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit release", map[string]interface{}{"error": err.Error(), "reservation_id": id})
+		return nil, fmt.Errorf("failed to commit release: %w", err)
+	}
+
+	r.log.Info(ctx, "Reservation released", map[string]interface{}{"reservation_id": id, "product_id": res.ProductID, "quantity": res.Quantity})
+	return res, nil
+}
+
+// ExpireStockReservations marks holds that expired by now as EXPIRED and
+// returns their stock, in a single statement. It returns the number of
+// reservations expired.
+func (r *postgresRepository) ExpireStockReservations(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		WITH expired AS (
+			UPDATE stock_reservations
+			SET status = 'EXPIRED', expires_at = NULL
+			WHERE status = 'HELD' AND expires_at <= $1
+			RETURNING product_id, quantity
+		), restored AS (
+			UPDATE products p
+			SET stock = p.stock + e.quantity, updated_at = $1
+			FROM (SELECT product_id, SUM(quantity) AS quantity FROM expired GROUP BY product_id) e
+			WHERE p.id = e.product_id
+		)
+		SELECT COUNT(*) FROM expired
+	`
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, now).Scan(&count); err != nil {
+		r.log.Error(ctx, "Failed to expire reservations", map[string]interface{}{"error": err.Error()})
+		return 0, fmt.Errorf("failed to expire reservations: %w", err)
+	}
+
+	if count > 0 {
+		r.log.Info(ctx, "Expired stock reservations", map[string]interface{}{"count": count})
+	}
+	return count, nil
+}
+
+// scanReservation scans a reservation selected with reservationColumns
+func scanReservation(row rowScanner) (*StockReservation, error) {
+	res := &StockReservation{}
+	var reference sql.NullString
+	var expiresAt sql.NullTime
+
+	err := row.Scan(
+		&res.ID,
+		&res.ProductID,
+		&res.Quantity,
+		&res.Status,
+		&reference,
+		&expiresAt,
+		&res.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Reference = reference.String
+	if expiresAt.Valid {
+		res.ExpiresAt = &expiresAt.Time
+	}
+	return res, nil
+}
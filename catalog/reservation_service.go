@@ -0,0 +1,220 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultReservationHold is how long stock is held before checkout must commit it
+	defaultReservationHold = 15 * time.Minute
+	// maxReservationHold caps client-requested hold durations
+	maxReservationHold = time.Hour
+)
+
+// ReserveStock holds stock for a checkout. The quantity must satisfy the
+// product's order quantity rules; it is deducted from stock immediately and
+// returned if the hold is released or expires.
+func (s *Service) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	if err := validateStockAdjustment(req.ProductId, req.Quantity); err != nil {
+		s.log.Warn(ctx, "Reserve stock failed: invalid request", map[string]interface{}{"product_id": req.ProductId, "quantity": req.Quantity})
+		return nil, err
+	}
+	if req.HoldSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "hold_seconds cannot be negative")
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if msg := orderQuantityProblem(product, req.Quantity); msg != "" {
+		s.log.Warn(ctx, "Reserve stock failed: "+msg, map[string]interface{}{"product_id": req.ProductId, "quantity": req.Quantity})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	hold := defaultReservationHold
+	if req.HoldSeconds > 0 {
+		hold = time.Duration(req.HoldSeconds) * time.Second
+	}
+	if hold > maxReservationHold {
+		hold = maxReservationHold
+	}
+	expiresAt := s.now().Add(hold)
+
+	res, level, err := s.repo.CreateStockReservation(ctx, &StockReservation{
+		ProductID: req.ProductId,
+		Quantity:  req.Quantity,
+		Status:    ReservationHeld,
+		Reference: req.Reference,
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		return nil, s.reservationError(ctx, err, req.ProductId)
+	}
+	s.emitLowStock(ctx, req.ProductId, isLowStock(level.Stock+req.Quantity, level.LowStockThreshold), level)
+
+	return &pb.ReserveStockResponse{
+		Reservation: toProtoReservation(res),
+	}, nil
+}
+
+// CommitReservation makes a held reservation permanent before its hold expires
+func (s *Service) CommitReservation(ctx context.Context, req *pb.CommitReservationRequest) (*pb.CommitReservationResponse, error) {
+	if req.ReservationId == "" {
+		s.log.Warn(ctx, "Commit reservation failed: reservation ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	res, err := s.repo.GetStockReservation(ctx, req.ReservationId)
+	if err != nil {
+		return nil, s.reservationError(ctx, err, "")
+	}
+
+	if res.Status == ReservationCommitted {
+		return &pb.CommitReservationResponse{Reservation: toProtoReservation(res)}, nil
+	}
+	if res.Status != ReservationHeld {
+		return nil, status.Error(codes.FailedPrecondition, "reservation is not held")
+	}
+	now := s.now()
+	if res.ExpiresAt != nil && !res.ExpiresAt.After(now) {
+		return nil, status.Error(codes.FailedPrecondition, "reservation hold has expired")
+	}
+
+	committed, err := s.repo.CommitStockReservation(ctx, res.ID, now)
+	if err != nil {
+		return nil, s.reservationError(ctx, err, res.ProductID)
+	}
+
+	return &pb.CommitReservationResponse{
+		Reservation: toProtoReservation(committed),
+	}, nil
+}
+
+// ReleaseReservation returns the stock of a held reservation. Releasing a
+// reservation that was already released or expired is a no-op.
+func (s *Service) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	if req.ReservationId == "" {
+		s.log.Warn(ctx, "Release reservation failed: reservation ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	res, err := s.repo.GetStockReservation(ctx, req.ReservationId)
+	if err != nil {
+		return nil, s.reservationError(ctx, err, "")
+	}
+
+	switch res.Status {
+	case ReservationReleased, ReservationExpired:
+		return &pb.ReleaseReservationResponse{Reservation: toProtoReservation(res)}, nil
+	case ReservationCommitted:
+		return nil, status.Error(codes.FailedPrecondition, "reservation is already committed")
+	}
+
+	released, err := s.repo.ReleaseStockReservation(ctx, res.ID)
+	if err != nil {
+		return nil, s.reservationError(ctx, err, res.ProductID)
+	}
+
+	return &pb.ReleaseReservationResponse{
+		Reservation: toProtoReservation(released),
+	}, nil
+}
+
+// UncommitReservation returns the stock of a committed reservation, for a
+// checkout that fails after committing it. Uncommitting a reservation that
+// was already released is a no-op.
+func (s *Service) UncommitReservation(ctx context.Context, req *pb.UncommitReservationRequest) (*pb.UncommitReservationResponse, error) {
+	if req.ReservationId == "" {
+		s.log.Warn(ctx, "Uncommit reservation failed: reservation ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	res, err := s.repo.GetStockReservation(ctx, req.ReservationId)
+	if err != nil {
+		return nil, s.reservationError(ctx, err, "")
+	}
+
+	switch res.Status {
+	case ReservationReleased:
+		return &pb.UncommitReservationResponse{Reservation: toProtoReservation(res)}, nil
+	case ReservationHeld, ReservationExpired:
+		return nil, status.Error(codes.FailedPrecondition, "reservation is not committed")
+	}
+
+	released, err := s.repo.UncommitStockReservation(ctx, res.ID)
+	if err != nil {
+		return nil, s.reservationError(ctx, err, res.ProductID)
+	}
+
+	return &pb.UncommitReservationResponse{
+		Reservation: toProtoReservation(released),
+	}, nil
+}
+
+// reservationError maps reservation repository errors to gRPC status errors
+func (s *Service) reservationError(ctx context.Context, err error, productID string) error {
+	switch {
+	case errors.Is(err, ErrReservationNotFound):
+		return status.Error(codes.NotFound, "reservation not found")
+	case errors.Is(err, ErrReservationStateChanged):
+		return status.Error(codes.FailedPrecondition, "reservation status has changed")
+	case errors.Is(err, ErrProductNotFound), errors.Is(err, ErrInsufficientStock), errors.Is(err, ErrStockNotTracked):
+		return s.stockError(ctx, err, productID)
+	default:
+		s.log.Error(ctx, "Reservation operation failed", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return status.Error(codes.Internal, "reservation operation failed")
+	}
+}
+
+// toProtoReservation converts a domain StockReservation to protobuf
+func toProtoReservation(r *StockReservation) *pb.StockReservation {
+	res := &pb.StockReservation{
+		Id:        r.ID,
+		ProductId: r.ProductID,
+		Quantity:  r.Quantity,
+		Status:    r.Status,
+		Reference: r.Reference,
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+	if r.ExpiresAt != nil {
+		res.ExpiresAt = timestamppb.New(*r.ExpiresAt)
+	}
+	return res
+}
+
+// ReservationExpirer periodically returns the stock of expired holds
+type ReservationExpirer struct {
+	repo Repository
+	log  *logger.Logger
+}
+
+// NewReservationExpirer creates a reservation expirer
+func NewReservationExpirer(repo Repository, log *logger.Logger) *ReservationExpirer {
+	return &ReservationExpirer{repo: repo, log: log}
+}
+
+// Run expires held reservations every interval until ctx is cancelled
+func (e *ReservationExpirer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := e.repo.ExpireStockReservations(ctx, time.Now()); err != nil {
+				e.log.Error(ctx, "Failed to expire stock reservations", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var reservationNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// reservationRepo keeps stock and reservations in memory the way the
+// postgres repository does
+func reservationRepo(stock int32) (*MockRepository, *int32) {
+	reservations := map[string]*StockReservation{}
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if id != "p1" {
+				return nil, ErrProductNotFound
+			}
+			return &Product{ID: id, ProductType: ProductTypePhysical}, nil
+		},
+		CreateStockReservationFunc: func(ctx context.Context, res *StockReservation) (*StockReservation, *StockLevel, error) {
+			if res.ProductID != "p1" {
+				return nil, nil, ErrProductNotFound
+			}
+			if stock < res.Quantity {
+				return nil, nil, ErrInsufficientStock
+			}
+			stock -= res.Quantity
+			created := *res
+			created.ID = "r1"
+			created.CreatedAt = reservationNow
+			reservations[created.ID] = &created
+			return &created, &StockLevel{ProductID: res.ProductID, Stock: stock}, nil
+		},
+		GetStockReservationFunc: func(ctx context.Context, id string) (*StockReservation, error) {
+			res, ok := reservations[id]
+			if !ok {
+				return nil, ErrReservationNotFound
+			}
+			copied := *res
+			return &copied, nil
+		},
+		CommitStockReservationFunc: func(ctx context.Context, id string, now time.Time) (*StockReservation, error) {
+			res := reservations[id]
+			if res.Status != ReservationHeld || !res.ExpiresAt.After(now) {
+				return nil, ErrReservationStateChanged
+			}
+			res.Status, res.ExpiresAt = ReservationCommitted, nil
+			return res, nil
+		},
+		ReleaseStockReservationFunc: func(ctx context.Context, id string) (*StockReservation, error) {
+			res := reservations[id]
+			if res.Status != ReservationHeld {
+				return nil, ErrReservationStateChanged
+			}
+			stock += res.Quantity
+			res.Status, res.ExpiresAt = ReservationReleased, nil
+			return res, nil
+		},
+		UncommitStockReservationFunc: func(ctx context.Context, id string) (*StockReservation, error) {
+			res := reservations[id]
+			if res.Status != ReservationCommitted {
+				return nil, ErrReservationStateChanged
+			}
+			stock += res.Quantity
+			res.Status = ReservationReleased
+			return res, nil
+		},
+	}
+	return mockRepo, &stock
+}
+
+func setupReservationService(repo Repository) *Service {
+	service := setupService(repo)
+	service.now = func() time.Time { return reservationNow }
+	return service
+}
+
+func TestReserveStock_CommitKeepsStockDeducted(t *testing.T) {
+	mockRepo, stock := reservationRepo(5)
+	service := setupReservationService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: 3, Reference: "cart-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	res := resp.Reservation
+	if res.Status != ReservationHeld || res.Reference != "cart-1" || *stock != 2 {
+		t.Errorf("Unexpected reservation %v with stock %d", res, *stock)
+	}
+	if !res.ExpiresAt.AsTime().Equal(reservationNow.Add(defaultReservationHold)) {
+		t.Errorf("Expected default hold, got expiry %v", res.ExpiresAt.AsTime())
+	}
+
+	committed, err := service.CommitReservation(ctx, &pb.CommitReservationRequest{ReservationId: res.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if committed.Reservation.Status != ReservationCommitted || *stock != 2 {
+		t.Errorf("Expected committed reservation with stock 2, got %v with stock %d", committed.Reservation, *stock)
+	}
+
+	// Committing again is idempotent; releasing a committed reservation is not allowed
+	if _, err := service.CommitReservation(ctx, &pb.CommitReservationRequest{ReservationId: res.Id}); err != nil {
+		t.Errorf("Expected repeated commit to succeed, got %v", err)
+	}
+	_, err = service.ReleaseReservation(ctx, &pb.ReleaseReservationRequest{ReservationId: res.Id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestReleaseReservation_ReturnsStock(t *testing.T) {
+	mockRepo, stock := reservationRepo(5)
+	service := setupReservationService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition while stock is held, got %v", err)
+	}
+
+	released, err := service.ReleaseReservation(ctx, &pb.ReleaseReservationRequest{ReservationId: resp.Reservation.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if released.Reservation.Status != ReservationReleased || *stock != 5 {
+		t.Errorf("Expected released reservation with stock 5, got %v with stock %d", released.Reservation, *stock)
+	}
+
+	// Releasing again is a no-op, and a released hold cannot be committed
+	if _, err := service.ReleaseReservation(ctx, &pb.ReleaseReservationRequest{ReservationId: resp.Reservation.Id}); err != nil || *stock != 5 {
+		t.Errorf("Expected repeated release to be a no-op, got %v with stock %d", err, *stock)
+	}
+	_, err = service.CommitReservation(ctx, &pb.CommitReservationRequest{ReservationId: resp.Reservation.Id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestUncommitReservation_ReturnsStock(t *testing.T) {
+	mockRepo, stock := reservationRepo(5)
+	service := setupReservationService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	id := resp.Reservation.Id
+
+	// A hold is released, not uncommitted
+	_, err = service.UncommitReservation(ctx, &pb.UncommitReservationRequest{ReservationId: id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a held reservation, got %v", err)
+	}
+
+	if _, err := service.CommitReservation(ctx, &pb.CommitReservationRequest{ReservationId: id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	uncommitted, err := service.UncommitReservation(ctx, &pb.UncommitReservationRequest{ReservationId: id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if uncommitted.Reservation.Status != ReservationReleased || *stock != 5 {
+		t.Errorf("Expected released reservation with stock 5, got %v with stock %d", uncommitted.Reservation, *stock)
+	}
+
+	// Uncommitting again, or releasing, is a no-op
+	if _, err := service.UncommitReservation(ctx, &pb.UncommitReservationRequest{ReservationId: id}); err != nil || *stock != 5 {
+		t.Errorf("Expected repeated uncommit to be a no-op, got %v with stock %d", err, *stock)
+	}
+	if _, err := service.ReleaseReservation(ctx, &pb.ReleaseReservationRequest{ReservationId: id}); err != nil || *stock != 5 {
+		t.Errorf("Expected release to be a no-op, got %v with stock %d", err, *stock)
+	}
+}
+
+func TestCommitReservation_Expired(t *testing.T) {
+	mockRepo, _ := reservationRepo(5)
+	service := setupReservationService(mockRepo)
+	ctx := context.Background()
+
+	resp, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: 1, HoldSeconds: 60})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	service.now = func() time.Time { return reservationNow.Add(time.Minute) }
+	_, err = service.CommitReservation(ctx, &pb.CommitReservationRequest{ReservationId: resp.Reservation.Id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestReserveStock_HoldIsCapped(t *testing.T) {
+	mockRepo, _ := reservationRepo(5)
+	service := setupReservationService(mockRepo)
+
+	resp, err := service.ReserveStock(context.Background(), &pb.ReserveStockRequest{ProductId: "p1", Quantity: 1, HoldSeconds: 86400})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Reservation.ExpiresAt.AsTime().Equal(reservationNow.Add(maxReservationHold)) {
+		t.Errorf("Expected hold capped at %v, got expiry %v", maxReservationHold, resp.Reservation.ExpiresAt.AsTime())
+	}
+}
+
+func TestReserveStock_OrderQuantityRules(t *testing.T) {
+	mockRepo, stock := reservationRepo(50)
+	mockRepo.GetByIDFunc = func(ctx context.Context, id string) (*Product, error) {
+		return &Product{ID: id, MinOrderQty: 6, MaxOrderQty: 24, QtyIncrement: 6}, nil
+	}
+	service := setupReservationService(mockRepo)
+	ctx := context.Background()
+
+	for _, quantity := range []int32{3, 10, 30} {
+		_, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: quantity})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Quantity %d: expected InvalidArgument, got %v", quantity, err)
+		}
+	}
+	if *stock != 50 {
+		t.Errorf("Expected no stock reserved, got %d left", *stock)
+	}
+
+	if _, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: 12}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *stock != 38 {
+		t.Errorf("Expected 38 left, got %d", *stock)
+	}
+}
+
+func TestReservation_InvalidRequest(t *testing.T) {
+	mockRepo, _ := reservationRepo(5)
+	service := setupReservationService(mockRepo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"zero quantity", func() error {
+			_, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1"})
+			return err
+		}, codes.InvalidArgument},
+		{"negative hold", func() error {
+			_, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "p1", Quantity: 1, HoldSeconds: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown product", func() error {
+			_, err := service.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: "missing", Quantity: 1})
+			return err
+		}, codes.NotFound},
+		{"missing reservation id", func() error {
+			_, err := service.CommitReservation(ctx, &pb.CommitReservationRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown reservation", func() error {
+			_, err := service.ReleaseReservation(ctx, &pb.ReleaseReservationRequest{ReservationId: "missing"})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		if code := status.Code(tt.call()); code != tt.code {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.code, code)
+		}
+	}
+}
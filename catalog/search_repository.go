@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// FuzzySearch searches for products whose name contains a word similar to
+// the query, most similar first. threshold is the minimum trigram word
+// similarity, between 0 and 1.
+func (r *postgresRepository) FuzzySearch(ctx context.Context, query string, threshold float64, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+	conditions, countArgs := filter.conditions(
+		[]string{"word_similarity($1, LOWER(name)) >= $2"},
+		[]interface{}{strings.ToLower(query), threshold},
+	)
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM products "+where, countArgs...).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count fuzzy search results", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count fuzzy search results: %w", err)
+	}
+
+	searchQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM products
+		%s
+		ORDER BY word_similarity($1, LOWER(name)) DESC, %s
+		LIMIT $%d OFFSET $%d
+	`, productColumns, where, filter.orderBy(), len(countArgs)+1, len(countArgs)+2)
+	args := append(append([]interface{}{}, countArgs...), pageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, searchQuery, args...)
+	if err != nil {
+		r.log.Error(ctx, "Failed to fuzzy search products", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to fuzzy search products: %w", err)
+	}
+	defer rows.Close()
+
+	products := []*Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan fuzzy search result", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan fuzzy search result: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating fuzzy search results", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating fuzzy search results: %w", err)
+	}
+
+	return products, total, nil
+}
+
+// SuggestQuery corrects each word of a query to the most similar word of the
+// names of the products the filter keeps. Words without a word at least as
+// similar as threshold are kept as they are.
+func (r *postgresRepository) SuggestQuery(ctx context.Context, query string, threshold float64, filter ProductFilter) (string, error) {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	conditions, args := filter.conditions(
+		[]string{"similarity(w, q.word) >= $2"},
+		[]interface{}{pq.Array(words), threshold},
+	)
+	suggestQuery := `
+		SELECT COALESCE((
+			SELECT w
+			FROM products, regexp_split_to_table(LOWER(name), '[^[:alnum:]]+') AS w
+			WHERE ` + strings.Join(conditions, " AND ") + `
+			ORDER BY similarity(w, q.word) DESC, w
+			LIMIT 1
+		), q.word)
+		FROM unnest($1::text[]) WITH ORDINALITY AS q(word, n)
+		ORDER BY q.n
+	`
+
+	rows, err := r.db.QueryContext(ctx, suggestQuery, args...)
+	if err != nil {
+		r.log.Error(ctx, "Failed to suggest query", map[string]interface{}{"error": err.Error()})
+		return "", fmt.Errorf("failed to suggest query: %w", err)
+	}
+	defer rows.Close()
+
+	corrected := make([]string, 0, len(words))
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return "", fmt.Errorf("failed to scan suggestion: %w", err)
+		}
+		corrected = append(corrected, word)
+	}
+	if err = rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating suggestions: %w", err)
+	}
+
+	return strings.Join(corrected, " "), nil
+}
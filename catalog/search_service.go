@@ -0,0 +1,49 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultSearchSimilarity is the trigram similarity a product name word must
+// reach to match a query word in the fuzzy search fallback
+const DefaultSearchSimilarity = 0.3
+
+// minFuzzyQueryLength is the shortest query searched fuzzily; shorter queries
+// have too few trigrams to tell typos from other words
+const minFuzzyQueryLength = 3
+
+// WithSearchSimilarity sets the similarity threshold of the fuzzy search
+// fallback. Values outside (0, 1) keep DefaultSearchSimilarity.
+func (s *Service) WithSearchSimilarity(threshold float64) *Service {
+	if threshold > 0 && threshold < 1 {
+		s.searchSimilarity = threshold
+	}
+	return s
+}
+
+// fuzzySearch searches for products with names similar to a query that had no
+// exact matches, and corrects the query to the words of similar product names.
+// The suggestion is "" when no word was corrected.
+func (s *Service) fuzzySearch(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, string, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < minFuzzyQueryLength {
+		return []*Product{}, 0, "", nil
+	}
+
+	products, total, err := s.repo.FuzzySearch(ctx, query, s.searchSimilarity, page, pageSize, filter)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	suggestion, err := s.repo.SuggestQuery(ctx, query, s.searchSimilarity, filter)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	if suggestion == strings.Join(strings.Fields(strings.ToLower(query)), " ") {
+		suggestion = ""
+	}
+
+	return products, total, suggestion, nil
+}
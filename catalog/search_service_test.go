@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSearchProducts_FuzzyFallback(t *testing.T) {
+	var gotThreshold float64
+	var gotFilter ProductFilter
+	mockRepo := &MockRepository{
+		SearchFunc: func(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			return []*Product{}, 0, nil
+		},
+		FuzzySearchFunc: func(ctx context.Context, query string, threshold float64, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			gotThreshold, gotFilter = threshold, filter
+			return []*Product{{ID: "prod-1", Name: "Desk Lamp"}}, 1, nil
+		},
+		SuggestQueryFunc: func(ctx context.Context, query string, threshold float64, filter ProductFilter) (string, error) {
+			return "desk lamp", nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "Desk Lmap", Channel: ChannelWeb})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Fuzzy || resp.Total != 1 || resp.Products[0].Id != "prod-1" {
+		t.Errorf("Expected fuzzy results, got %v", resp)
+	}
+	if resp.Suggestion != "desk lamp" {
+		t.Errorf("Expected suggestion %q, got %q", "desk lamp", resp.Suggestion)
+	}
+	if gotThreshold != DefaultSearchSimilarity || gotFilter.Channel != ChannelWeb {
+		t.Errorf("Expected the default threshold and request filter, got %v and %+v", gotThreshold, gotFilter)
+	}
+
+	service.WithSearchSimilarity(0.5)
+	if _, err := service.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "lmap"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotThreshold != 0.5 {
+		t.Errorf("Expected threshold 0.5, got %v", gotThreshold)
+	}
+}
+
+func TestSearchProducts_NoFuzzyFallback(t *testing.T) {
+	fuzzyCalls := 0
+	mockRepo := &MockRepository{
+		SearchFunc: func(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			if query == "lamp" {
+				return []*Product{{ID: "prod-1"}}, 1, nil
+			}
+			return []*Product{}, 0, nil
+		},
+		FuzzySearchFunc: func(ctx context.Context, query string, threshold float64, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			fuzzyCalls++
+			return []*Product{}, 0, nil
+		},
+		SuggestQueryFunc: func(ctx context.Context, query string, threshold float64, filter ProductFilter) (string, error) {
+			// Nothing to correct
+			return query, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	// Exact matches are not searched fuzzily
+	resp, err := service.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "lamp"})
+	if err != nil || resp.Fuzzy || resp.Suggestion != "" {
+		t.Errorf("Expected exact results, got %v, %v", resp, err)
+	}
+
+	// Queries too short for trigrams are not either
+	resp, err = service.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "zq"})
+	if err != nil || resp.Total != 0 {
+		t.Errorf("Expected no results, got %v, %v", resp, err)
+	}
+	if fuzzyCalls != 0 {
+		t.Errorf("Expected no fuzzy searches, got %d", fuzzyCalls)
+	}
+
+	// A query that is already made of product name words has no suggestion
+	resp, err = service.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "xylophone"})
+	if err != nil || resp.Fuzzy || resp.Suggestion != "" {
+		t.Errorf("Expected no fuzzy results or suggestion, got %v, %v", resp, err)
+	}
+}
+
+func TestSearchProducts_FuzzyError(t *testing.T) {
+	mockRepo := &MockRepository{
+		SearchFunc: func(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			return []*Product{}, 0, nil
+		},
+		FuzzySearchFunc: func(ctx context.Context, query string, threshold float64, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			return nil, 0, errors.New("extension missing")
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "lmap"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+}
@@ -2,26 +2,191 @@ package catalog
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/richtext"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxRelatedProducts caps the number of links per relation type
+const maxRelatedProducts = 50
+
+// errVersionConflictMessage tells the caller to reload a product before editing it again
+const errVersionConflictMessage = "product was modified by another request; reload it and retry with the current version"
+
+// productLookupError maps an error looking up a product to a gRPC status:
+// NotFound when the product does not exist, and Internal for any other
+// failure, so database outages are not reported as missing products
+func (s *Service) productLookupError(ctx context.Context, err error, productID string) error {
+	if errors.Is(err, ErrProductNotFound) {
+		s.log.Warn(ctx, "Product not found", map[string]interface{}{"product_id": productID})
+		return status.Error(codes.NotFound, "product not found")
+	}
+	s.log.Error(ctx, "Failed to get product", map[string]interface{}{"error": err.Error(), "product_id": productID})
+	return status.Error(codes.Internal, "failed to get product")
+}
+
+// AdminMethods are the admin-scoped RPCs of the catalog service; they are
+// restricted to the admin network allowlist and to the roles of MethodRoles
+var AdminMethods = []string{
+	pb.CatalogService_CreateProduct_FullMethodName,
+	pb.CatalogService_UpdateProduct_FullMethodName,
+	pb.CatalogService_DeleteProduct_FullMethodName,
+	pb.CatalogService_SetRelatedProducts_FullMethodName,
+	pb.CatalogService_SetBookingConfig_FullMethodName,
+	pb.CatalogService_GetImageUploadURL_FullMethodName,
+	pb.CatalogService_AttachImage_FullMethodName,
+	pb.CatalogService_ReorderImages_FullMethodName,
+	pb.CatalogService_SetPrimaryImage_FullMethodName,
+	pb.CatalogService_ReviewImage_FullMethodName,
+	pb.CatalogService_SetImageRenditions_FullMethodName,
+	pb.CatalogService_SetPriceTiers_FullMethodName,
+	pb.CatalogService_VerifyAuditChain_FullMethodName,
+	pb.CatalogService_IncrementStock_FullMethodName,
+	pb.CatalogService_DecrementStock_FullMethodName,
+	pb.CatalogService_ListLowStockProducts_FullMethodName,
+	pb.CatalogService_ApplyInventoryUpdates_FullMethodName,
+	pb.CatalogService_GetReorderReport_FullMethodName,
+	pb.CatalogService_SetBundle_FullMethodName,
+	pb.CatalogService_GetDigitalAssetUploadURL_FullMethodName,
+	pb.CatalogService_AttachDigitalAsset_FullMethodName,
+	pb.CatalogService_GrantEntitlement_FullMethodName,
+	pb.CatalogService_RevokeEntitlement_FullMethodName,
+	pb.CatalogService_SetProductTranslation_FullMethodName,
+	pb.CatalogService_DeleteProductTranslation_FullMethodName,
+	pb.CatalogService_UpdateRatingAggregate_FullMethodName,
+	pb.CatalogService_ChangeSKU_FullMethodName,
+	pb.CatalogService_CloneProduct_FullMethodName,
+	pb.CatalogService_StreamProducts_FullMethodName,
+	pb.CatalogService_WatchProducts_FullMethodName,
+	pb.CatalogService_GetProductAuditLog_FullMethodName,
+	pb.CatalogService_RecordProductActivity_FullMethodName,
+	pb.CatalogService_SetProductVisibility_FullMethodName,
+	pb.CatalogService_ModerateQuestion_FullMethodName,
+	pb.CatalogService_ModerateAnswer_FullMethodName,
+}
+
+// ServiceMethods are the RPCs other services call, with a SERVICE token. The
+// stock reservation and booking RPCs checkout calls are not admin RPCs, so
+// they are not restricted to the admin network, but still need a token.
+var ServiceMethods = []string{
+	pb.CatalogService_IncrementStock_FullMethodName,
+	pb.CatalogService_DecrementStock_FullMethodName,
+	pb.CatalogService_ApplyInventoryUpdates_FullMethodName,
+	pb.CatalogService_ReserveStock_FullMethodName,
+	pb.CatalogService_ReleaseReservation_FullMethodName,
+	pb.CatalogService_CommitReservation_FullMethodName,
+	pb.CatalogService_UncommitReservation_FullMethodName,
+	pb.CatalogService_ReserveBooking_FullMethodName,
+	pb.CatalogService_ConfirmBooking_FullMethodName,
+	pb.CatalogService_CancelBooking_FullMethodName,
+	pb.CatalogService_UpdateRatingAggregate_FullMethodName,
+	pb.CatalogService_SetImageRenditions_FullMethodName,
+	pb.CatalogService_StreamProducts_FullMethodName,
+	pb.CatalogService_WatchProducts_FullMethodName,
+}
+
+// UserMethods are the RPCs customers call for themselves, with an access
+// token. Questions, answers and back-in-stock subscriptions are the token's
+// user's; ADMIN and SERVICE tokens act for the customer named by user_id.
+var UserMethods = []string{
+	pb.CatalogService_AskQuestion_FullMethodName,
+	pb.CatalogService_AnswerQuestion_FullMethodName,
+	pb.CatalogService_SubscribeBackInStock_FullMethodName,
+	pb.CatalogService_UnsubscribeBackInStock_FullMethodName,
+	pb.CatalogService_GenerateDownloadURL_FullMethodName,
+}
+
+// MethodRoles maps each admin, service and user RPC to the roles allowed to
+// call it: ADMIN tokens may call every one, SERVICE tokens those of
+// ServiceMethods, UserMethods and VendorMethods, the latter for the vendor
+// portal, and customers those of UserMethods only
+func MethodRoles() map[string][]string {
+	roles := make(map[string][]string, len(AdminMethods)+len(UserMethods))
+	for _, m := range AdminMethods {
+		roles[m] = []string{auth.RoleAdmin}
+	}
+	for _, m := range append(ServiceMethods, VendorMethods...) {
+		roles[m] = []string{auth.RoleAdmin, auth.RoleService}
+	}
+	for _, m := range UserMethods {
+		roles[m] = []string{auth.RoleUser, auth.RoleAdmin, auth.RoleService}
+	}
+	return roles
+}
+
+// Scopes of the client-credentials tokens of background services, each
+// allowing the ServiceMethods of one job
+const (
+	ScopeInventory = "catalog:inventory"
+	ScopeRatings   = "catalog:ratings"
+	ScopeImages    = "catalog:images"
+	ScopeRead      = "catalog:read"
+)
+
+// PermissionAssetDownload lets a caller download the digital assets of any
+// product without an entitlement; ADMIN tokens hold it through
+// auth.DefaultPolicy
+const PermissionAssetDownload = "catalog:asset:download"
+
+// MethodScopes maps each of ServiceMethods to the scope a client-credentials
+// token needs to call it
+func MethodScopes() map[string][]string {
+	return map[string][]string{
+		pb.CatalogService_IncrementStock_FullMethodName:        {ScopeInventory},
+		pb.CatalogService_DecrementStock_FullMethodName:        {ScopeInventory},
+		pb.CatalogService_ApplyInventoryUpdates_FullMethodName: {ScopeInventory},
+		pb.CatalogService_ReserveStock_FullMethodName:          {ScopeInventory},
+		pb.CatalogService_ReleaseReservation_FullMethodName:    {ScopeInventory},
+		pb.CatalogService_CommitReservation_FullMethodName:     {ScopeInventory},
+		pb.CatalogService_UncommitReservation_FullMethodName:   {ScopeInventory},
+		pb.CatalogService_ReserveBooking_FullMethodName:        {ScopeInventory},
+		pb.CatalogService_ConfirmBooking_FullMethodName:        {ScopeInventory},
+		pb.CatalogService_CancelBooking_FullMethodName:         {ScopeInventory},
+		pb.CatalogService_UpdateRatingAggregate_FullMethodName: {ScopeRatings},
+		pb.CatalogService_SetImageRenditions_FullMethodName:    {ScopeImages},
+		pb.CatalogService_StreamProducts_FullMethodName:        {ScopeRead},
+		pb.CatalogService_WatchProducts_FullMethodName:         {ScopeRead},
+	}
+}
+
 // Service implements the CatalogService gRPC interface
 type Service struct {
 	pb.UnimplementedCatalogServiceServer
-	repo Repository
-	log  *logger.Logger
+	repo   Repository
+	log    *logger.Logger
+	now    func() time.Time
+	images ImageStore
+	// auditKey signs and verifies price history anchors
+	auditKey []byte
+	// stockEvents receives low-stock events
+	stockEvents StockEventSink
+	// assets stores digital product files
+	assets DigitalAssetStore
+	// defaultLocale is the locale of the name and description stored on products
+	defaultLocale string
+	// changes delivers product changes to WatchProducts subscribers
+	changes *ProductChangeHub
+	// searchSimilarity is the fuzzy search threshold
+	searchSimilarity float64
 }
 
 // NewService creates a new catalog service
 func NewService(repo Repository, log *logger.Logger) *Service {
 	return &Service{
-		repo: repo,
-		log:  log,
+		repo:             repo,
+		log:              log,
+		now:              time.Now,
+		stockEvents:      NewLogStockEventSink(log),
+		defaultLocale:    DefaultLocale,
+		searchSimilarity: DefaultSearchSimilarity,
 	}
 }
 
@@ -44,26 +209,107 @@ func (s *Service) CreateProduct(ctx context.Context, req *pb.CreateProductReques
 		s.log.Warn(ctx, "Create product failed: stock cannot be negative", nil)
 		return nil, status.Error(codes.InvalidArgument, "stock cannot be negative")
 	}
+	if req.LowStockThreshold < 0 {
+		s.log.Warn(ctx, "Create product failed: low stock threshold cannot be negative", nil)
+		return nil, status.Error(codes.InvalidArgument, "low_stock_threshold cannot be negative")
+	}
+
+	productType := req.ProductType
+	if productType == "" {
+		productType = ProductTypePhysical
+	}
+	if productType != ProductTypePhysical && productType != ProductTypeDigital {
+		s.log.Warn(ctx, "Create product failed: invalid product type", map[string]interface{}{"product_type": req.ProductType})
+		return nil, status.Error(codes.InvalidArgument, "product_type must be PHYSICAL or DIGITAL")
+	}
+	if productType == ProductTypeDigital && (req.Stock != 0 || req.LowStockThreshold != 0) {
+		s.log.Warn(ctx, "Create product failed: digital product with stock", nil)
+		return nil, status.Error(codes.InvalidArgument, "digital products do not track stock")
+	}
+
+	sale, msg := saleFromRequest(req.Price, req.SalePrice, req.SaleStartsAt, req.SaleEndsAt)
+	if msg != "" {
+		s.log.Warn(ctx, "Create product failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	shipping, msg := shippingFromRequest(productType, req.WeightKg, req.LengthCm, req.WidthCm, req.HeightCm, req.ShippingClass)
+	if msg != "" {
+		s.log.Warn(ctx, "Create product failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	barcodes, msg := barcodesFromRequest(req.Ean, req.Upc, req.Isbn)
+	if msg != "" {
+		s.log.Warn(ctx, "Create product failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	orderQty, msg := orderQuantityFromRequest(req.MinOrderQty, req.MaxOrderQty, req.QtyIncrement)
+	if msg != "" {
+		s.log.Warn(ctx, "Create product failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	blocks, err := sanitizeBlocks(req.DescriptionBlocks)
+	if err != nil {
+		s.log.Warn(ctx, "Create product failed: invalid description blocks", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	description := req.Description
+	if description == "" && len(blocks) > 0 {
+		description = richtext.RenderPlainText(blocks)
+	}
 
 	// Check if SKU already exists
-	existing, err := s.repo.GetBySKU(ctx, req.Sku)
-	if err == nil && existing != nil {
+	_, err = s.repo.GetBySKU(ctx, req.Sku)
+	if err == nil {
 		s.log.Warn(ctx, "Create product failed: SKU already exists", map[string]interface{}{"sku": req.Sku})
 		return nil, status.Error(codes.AlreadyExists, "product with this SKU already exists")
 	}
+	if !errors.Is(err, ErrProductNotFound) {
+		s.log.Error(ctx, "Failed to check SKU", map[string]interface{}{"error": err.Error(), "sku": req.Sku})
+		return nil, status.Error(codes.Internal, "failed to create product")
+	}
+	if err := s.checkBarcodesUnique(ctx, "", barcodes); err != nil {
+		return nil, err
+	}
 
 	// Create product
 	product := &Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		SKU:         req.Sku,
-		Stock:       req.Stock,
-		Images:      req.Images,
-		Category:    req.Category,
+		Name:              req.Name,
+		Description:       description,
+		Price:             req.Price,
+		SKU:               req.Sku,
+		Stock:             req.Stock,
+		Images:            imagesFromURLs(req.Images),
+		Category:          req.Category,
+		DescriptionBlocks: blocks,
+		SalePrice:         sale.price,
+		SaleStartsAt:      sale.startsAt,
+		SaleEndsAt:        sale.endsAt,
+		LowStockThreshold: req.LowStockThreshold,
+		ProductType:       productType,
+		WeightKg:          shipping.weightKg,
+		LengthCm:          shipping.lengthCm,
+		WidthCm:           shipping.widthCm,
+		HeightCm:          shipping.heightCm,
+		ShippingClass:     shipping.class,
+		EAN:               barcodes.ean,
+		UPC:               barcodes.upc,
+		ISBN:              barcodes.isbn,
+		MinOrderQty:       orderQty.min,
+		MaxOrderQty:       orderQty.max,
+		QtyIncrement:      orderQty.increment,
+		VendorID:          vendorFromContext(ctx),
+	}
+
+	created, err := s.repo.Create(ctx, product, actorFromContext(ctx))
+	if errors.Is(err, ErrDuplicateSKU) {
+		// Created concurrently since the check above
+		return nil, status.Error(codes.AlreadyExists, "product with this SKU already exists")
 	}
-
-	created, err := s.repo.Create(ctx, product)
 	if err != nil {
 		s.log.Error(ctx, "Failed to create product", map[string]interface{}{"error": err.Error()})
 		return nil, status.Error(codes.Internal, "failed to create product")
@@ -72,7 +318,7 @@ func (s *Service) CreateProduct(ctx context.Context, req *pb.CreateProductReques
 	s.log.Info(ctx, "Product created successfully", map[string]interface{}{"product_id": created.ID, "sku": created.SKU})
 
 	return &pb.CreateProductResponse{
-		Product: toProtoProduct(created),
+		Product: toProtoProduct(created, s.now()),
 	}, nil
 }
 
@@ -85,15 +331,87 @@ func (s *Service) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*p
 
 	product, err := s.repo.GetByID(ctx, req.Id)
 	if err != nil {
-		s.log.Warn(ctx, "Product not found", map[string]interface{}{"product_id": req.Id})
+		return nil, s.productLookupError(ctx, err, req.Id)
+	}
+
+	var related []*RelatedProduct
+	if req.IncludeRelated {
+		related, err = s.repo.GetRelations(ctx, req.Id, "")
+		if err != nil {
+			s.log.Error(ctx, "Failed to get related products", map[string]interface{}{"error": err.Error(), "product_id": req.Id})
+			return nil, status.Error(codes.Internal, "failed to get related products")
+		}
+	}
+
+	products := []*Product{product}
+	for _, r := range related {
+		products = append(products, r.Product)
+	}
+	if err := s.localize(ctx, req.Locale, products...); err != nil {
+		s.log.Error(ctx, "Failed to localize product", map[string]interface{}{"error": err.Error(), "product_id": req.Id})
+		return nil, status.Error(codes.Internal, "failed to get product")
+	}
+
+	resp := &pb.GetProductResponse{
+		Product: toProtoProduct(product, s.now()),
+	}
+	if req.IncludeRelated {
+		resp.RelatedProducts = toProtoRelatedProducts(related, s.now())
+	}
+
+	return resp, nil
+}
+
+// GetProductByBarcode retrieves a product by a scanned EAN, UPC or ISBN
+func (s *Service) GetProductByBarcode(ctx context.Context, req *pb.GetProductByBarcodeRequest) (*pb.GetProductByBarcodeResponse, error) {
+	barcode := cleanBarcode(req.Barcode)
+	if barcode == "" {
+		s.log.Warn(ctx, "Get product by barcode failed: barcode is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "barcode is required")
+	}
+	// ISBN-10 may end in X; anything else must be digits
+	if !isDigits(strings.TrimSuffix(barcode, "X")) {
+		return nil, status.Error(codes.InvalidArgument, "barcode must contain only digits")
+	}
+
+	product, err := s.repo.GetByBarcode(ctx, barcodeCandidates(barcode))
+	if errors.Is(err, ErrProductNotFound) {
 		return nil, status.Error(codes.NotFound, "product not found")
 	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get product by barcode", map[string]interface{}{"error": err.Error(), "barcode": barcode})
+		return nil, status.Error(codes.Internal, "failed to get product")
+	}
+	if err := s.localize(ctx, req.Locale, product); err != nil {
+		s.log.Error(ctx, "Failed to localize product", map[string]interface{}{"error": err.Error(), "product_id": product.ID})
+		return nil, status.Error(codes.Internal, "failed to get product")
+	}
 
-	return &pb.GetProductResponse{
-		Product: toProtoProduct(product),
+	return &pb.GetProductByBarcodeResponse{
+		Product: toProtoProduct(product, s.now()),
 	}, nil
 }
 
+// checkBarcodesUnique returns an AlreadyExists error when another product than
+// productID already uses one of the barcodes, in any barcode field
+func (s *Service) checkBarcodesUnique(ctx context.Context, productID string, barcodes productBarcodes) error {
+	for _, code := range barcodes.all() {
+		existing, err := s.repo.GetByBarcode(ctx, barcodeCandidates(code))
+		if errors.Is(err, ErrProductNotFound) {
+			continue
+		}
+		if err != nil {
+			s.log.Error(ctx, "Failed to check barcode", map[string]interface{}{"error": err.Error(), "barcode": code})
+			return status.Error(codes.Internal, "failed to check barcode")
+		}
+		if existing.ID != productID {
+			s.log.Warn(ctx, "Barcode already in use", map[string]interface{}{"barcode": code, "product_id": existing.ID})
+			return status.Errorf(codes.AlreadyExists, "barcode %s is already used by another product", code)
+		}
+	}
+	return nil
+}
+
 // ListProducts retrieves a paginated list of products
 func (s *Service) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
 	page := req.Page
@@ -109,15 +427,32 @@ func (s *Service) ListProducts(ctx context.Context, req *pb.ListProductsRequest)
 		pageSize = 100
 	}
 
-	products, total, err := s.repo.List(ctx, page, pageSize, req.Category)
+	filter, msg := filterFromRequest(req.Category, req.SortBy, req.MinRating)
+	if msg != "" {
+		s.log.Warn(ctx, "List products failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	filter.IncludeDrafts = req.IncludeDrafts
+	filter.VendorID = req.VendorId
+	filter.Channel, filter.CustomerGroup, msg = audienceFromRequest(req.Channel, req.CustomerGroup)
+	if msg != "" {
+		s.log.Warn(ctx, "List products failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	products, total, err := s.repo.List(ctx, page, pageSize, filter)
 	if err != nil {
 		s.log.Error(ctx, "Failed to list products", map[string]interface{}{"error": err.Error()})
 		return nil, status.Error(codes.Internal, "failed to list products")
 	}
+	if err := s.localize(ctx, req.Locale, products...); err != nil {
+		s.log.Error(ctx, "Failed to localize products", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list products")
+	}
 
 	protoProducts := make([]*pb.Product, len(products))
 	for i, p := range products {
-		protoProducts[i] = toProtoProduct(p)
+		protoProducts[i] = toProtoProduct(p, s.now())
 	}
 
 	s.log.Info(ctx, "Products listed successfully", map[string]interface{}{"count": len(products), "total": total})
@@ -150,27 +485,119 @@ func (s *Service) UpdateProduct(ctx context.Context, req *pb.UpdateProductReques
 		s.log.Warn(ctx, "Update product failed: stock cannot be negative", nil)
 		return nil, status.Error(codes.InvalidArgument, "stock cannot be negative")
 	}
+	if req.LowStockThreshold < 0 {
+		s.log.Warn(ctx, "Update product failed: low stock threshold cannot be negative", nil)
+		return nil, status.Error(codes.InvalidArgument, "low_stock_threshold cannot be negative")
+	}
+	if req.Status != "" && req.Status != ProductStatusActive && req.Status != ProductStatusDraft {
+		s.log.Warn(ctx, "Update product failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be ACTIVE or DRAFT")
+	}
+	if req.Version < 1 {
+		s.log.Warn(ctx, "Update product failed: version is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+
+	sale, msg := saleFromRequest(req.Price, req.SalePrice, req.SaleStartsAt, req.SaleEndsAt)
+	if msg != "" {
+		s.log.Warn(ctx, "Update product failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	blocks, err := sanitizeBlocks(req.DescriptionBlocks)
+	if err != nil {
+		s.log.Warn(ctx, "Update product failed: invalid description blocks", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	description := req.Description
+	if description == "" && len(blocks) > 0 {
+		description = richtext.RenderPlainText(blocks)
+	}
 
 	// Check if product exists
 	existing, err := s.repo.GetByID(ctx, req.Id)
 	if err != nil {
-		s.log.Warn(ctx, "Product not found for update", map[string]interface{}{"product_id": req.Id})
-		return nil, status.Error(codes.NotFound, "product not found")
+		return nil, s.productLookupError(ctx, err, req.Id)
+	}
+	if err := s.authorizeVendor(ctx, existing); err != nil {
+		return nil, err
+	}
+	if existing.Version != req.Version {
+		s.log.Warn(ctx, "Update product failed: version conflict", map[string]interface{}{"product_id": req.Id, "version": req.Version, "current_version": existing.Version})
+		return nil, status.Error(codes.FailedPrecondition, errVersionConflictMessage)
+	}
+	if !existing.TracksStock() && (req.Stock != 0 || req.LowStockThreshold != 0) {
+		s.log.Warn(ctx, "Update product failed: digital product with stock", map[string]interface{}{"product_id": req.Id})
+		return nil, status.Error(codes.InvalidArgument, "digital products do not track stock")
+	}
+
+	shipping, msg := shippingFromRequest(existing.ProductType, req.WeightKg, req.LengthCm, req.WidthCm, req.HeightCm, req.ShippingClass)
+	if msg != "" {
+		s.log.Warn(ctx, "Update product failed: "+msg, map[string]interface{}{"product_id": req.Id})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	barcodes, msg := barcodesFromRequest(req.Ean, req.Upc, req.Isbn)
+	if msg != "" {
+		s.log.Warn(ctx, "Update product failed: "+msg, map[string]interface{}{"product_id": req.Id})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	orderQty, msg := orderQuantityFromRequest(req.MinOrderQty, req.MaxOrderQty, req.QtyIncrement)
+	if msg != "" {
+		s.log.Warn(ctx, "Update product failed: "+msg, map[string]interface{}{"product_id": req.Id})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	if err := s.checkBarcodesUnique(ctx, existing.ID, barcodes); err != nil {
+		return nil, err
+	}
+
+	productStatus := existing.Status
+	if req.Status != "" {
+		productStatus = req.Status
 	}
 
 	// Update product
 	product := &Product{
-		ID:          existing.ID,
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		SKU:         existing.SKU, // SKU cannot be updated
-		Stock:       req.Stock,
-		Images:      req.Images,
-		Category:    req.Category,
+		ID:                existing.ID,
+		Name:              req.Name,
+		Description:       description,
+		Price:             req.Price,
+		SKU:               existing.SKU, // SKU cannot be updated
+		Stock:             req.Stock,
+		Images:            imagesFromURLs(req.Images),
+		Category:          req.Category,
+		DescriptionBlocks: blocks,
+		SalePrice:         sale.price,
+		SaleStartsAt:      sale.startsAt,
+		SaleEndsAt:        sale.endsAt,
+		LowStockThreshold: req.LowStockThreshold,
+		ProductType:       existing.ProductType, // product type cannot be updated
+		WeightKg:          shipping.weightKg,
+		LengthCm:          shipping.lengthCm,
+		WidthCm:           shipping.widthCm,
+		HeightCm:          shipping.heightCm,
+		ShippingClass:     shipping.class,
+		EAN:               barcodes.ean,
+		UPC:               barcodes.upc,
+		ISBN:              barcodes.isbn,
+		Status:            productStatus,
+		Version:           req.Version,
+		MinOrderQty:       orderQty.min,
+		MaxOrderQty:       orderQty.max,
+		QtyIncrement:      orderQty.increment,
+		VendorID:          existing.VendorID, // ownership cannot be changed
+	}
+
+	updated, err := s.repo.Update(ctx, product, actorFromContext(ctx))
+	if errors.Is(err, ErrVersionConflict) {
+		return nil, status.Error(codes.FailedPrecondition, errVersionConflictMessage)
+	}
+	if errors.Is(err, ErrProductNotFound) {
+		// Deleted since it was read
+		return nil, status.Error(codes.NotFound, "product not found")
 	}
-
-	updated, err := s.repo.Update(ctx, product)
 	if err != nil {
 		s.log.Error(ctx, "Failed to update product", map[string]interface{}{"error": err.Error(), "product_id": req.Id})
 		return nil, status.Error(codes.Internal, "failed to update product")
@@ -178,8 +605,14 @@ func (s *Service) UpdateProduct(ctx context.Context, req *pb.UpdateProductReques
 
 	s.log.Info(ctx, "Product updated successfully", map[string]interface{}{"product_id": updated.ID})
 
+	s.emitLowStock(ctx, updated.ID, isLowStock(existing.Stock, existing.LowStockThreshold), &StockLevel{
+		ProductID:         updated.ID,
+		Stock:             updated.Stock,
+		LowStockThreshold: updated.LowStockThreshold,
+	})
+
 	return &pb.UpdateProductResponse{
-		Product: toProtoProduct(updated),
+		Product: toProtoProduct(updated, s.now()),
 	}, nil
 }
 
@@ -189,12 +622,19 @@ func (s *Service) DeleteProduct(ctx context.Context, req *pb.DeleteProductReques
 		s.log.Warn(ctx, "Delete product failed: ID is required", nil)
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
+	if err := s.authorizeVendorByID(ctx, req.Id); err != nil {
+		return nil, err
+	}
 
-	err := s.repo.Delete(ctx, req.Id)
-	if err != nil {
-		s.log.Warn(ctx, "Failed to delete product", map[string]interface{}{"error": err.Error(), "product_id": req.Id})
+	err := s.repo.Delete(ctx, req.Id, actorFromContext(ctx))
+	if errors.Is(err, ErrProductNotFound) {
+		s.log.Warn(ctx, "Delete product failed: product not found", map[string]interface{}{"product_id": req.Id})
 		return nil, status.Error(codes.NotFound, "product not found")
 	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to delete product", map[string]interface{}{"error": err.Error(), "product_id": req.Id})
+		return nil, status.Error(codes.Internal, "failed to delete product")
+	}
 
 	s.log.Info(ctx, "Product deleted successfully", map[string]interface{}{"product_id": req.Id})
 
@@ -224,41 +664,340 @@ func (s *Service) SearchProducts(ctx context.Context, req *pb.SearchProductsRequ
 		pageSize = 100
 	}
 
-	products, total, err := s.repo.Search(ctx, req.Query, page, pageSize)
+	filter, msg := filterFromRequest("", req.SortBy, req.MinRating)
+	if msg != "" {
+		s.log.Warn(ctx, "Search products failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	filter.IncludeDrafts = req.IncludeDrafts
+	filter.Channel, filter.CustomerGroup, msg = audienceFromRequest(req.Channel, req.CustomerGroup)
+	if msg != "" {
+		s.log.Warn(ctx, "Search products failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	products, total, err := s.repo.Search(ctx, req.Query, page, pageSize, filter)
 	if err != nil {
 		s.log.Error(ctx, "Failed to search products", map[string]interface{}{"error": err.Error(), "query": req.Query})
 		return nil, status.Error(codes.Internal, "failed to search products")
 	}
 
+	// Fall back to similar names when nothing matches, to tolerate typos
+	fuzzy := false
+	suggestion := ""
+	if total == 0 {
+		products, total, suggestion, err = s.fuzzySearch(ctx, req.Query, page, pageSize, filter)
+		if err != nil {
+			s.log.Error(ctx, "Failed to fuzzy search products", map[string]interface{}{"error": err.Error(), "query": req.Query})
+			return nil, status.Error(codes.Internal, "failed to search products")
+		}
+		fuzzy = total > 0
+	}
+	if err := s.localize(ctx, req.Locale, products...); err != nil {
+		s.log.Error(ctx, "Failed to localize products", map[string]interface{}{"error": err.Error(), "query": req.Query})
+		return nil, status.Error(codes.Internal, "failed to search products")
+	}
+
 	protoProducts := make([]*pb.Product, len(products))
 	for i, p := range products {
-		protoProducts[i] = toProtoProduct(p)
+		protoProducts[i] = toProtoProduct(p, s.now())
 	}
 
-	s.log.Info(ctx, "Products searched successfully", map[string]interface{}{"query": req.Query, "count": len(products), "total": total})
+	s.log.Info(ctx, "Products searched successfully", map[string]interface{}{"query": req.Query, "count": len(products), "total": total, "fuzzy": fuzzy})
 
 	return &pb.SearchProductsResponse{
-		Products: protoProducts,
-		Total:    total,
+		Products:   protoProducts,
+		Total:      total,
+		Fuzzy:      fuzzy,
+		Suggestion: suggestion,
+	}, nil
+}
+
+// SetRelatedProducts replaces the related products of one relation type
+func (s *Service) SetRelatedProducts(ctx context.Context, req *pb.SetRelatedProductsRequest) (*pb.SetRelatedProductsResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Set related products failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if !isValidRelationType(req.RelationType) {
+		s.log.Warn(ctx, "Set related products failed: invalid relation type", map[string]interface{}{"relation_type": req.RelationType})
+		return nil, status.Error(codes.InvalidArgument, "relation_type must be RELATED, UPSELL or CROSS_SELL")
+	}
+	if len(req.RelatedProductIds) > maxRelatedProducts {
+		s.log.Warn(ctx, "Set related products failed: too many related products", map[string]interface{}{"count": len(req.RelatedProductIds)})
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d related products are allowed", maxRelatedProducts)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.RelatedProductIds))
+	relatedIDs := make([]string, 0, len(req.RelatedProductIds))
+	for _, id := range req.RelatedProductIds {
+		if id == "" {
+			return nil, status.Error(codes.InvalidArgument, "related product id cannot be empty")
+		}
+		if id == req.ProductId {
+			s.log.Warn(ctx, "Set related products failed: self reference", map[string]interface{}{"product_id": req.ProductId})
+			return nil, status.Error(codes.InvalidArgument, "product cannot be related to itself")
+		}
+		if seen[id] {
+			continue
+		}
+		if _, err := s.repo.GetByID(ctx, id); err != nil {
+			if !errors.Is(err, ErrProductNotFound) {
+				return nil, s.productLookupError(ctx, err, id)
+			}
+			s.log.Warn(ctx, "Related product not found", map[string]interface{}{"related_product_id": id})
+			return nil, status.Errorf(codes.NotFound, "related product %s not found", id)
+		}
+		seen[id] = true
+		relatedIDs = append(relatedIDs, id)
+	}
+
+	if err := s.repo.SetRelations(ctx, req.ProductId, req.RelationType, relatedIDs); err != nil {
+		s.log.Error(ctx, "Failed to set related products", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to set related products")
+	}
+
+	related, err := s.repo.GetRelations(ctx, req.ProductId, req.RelationType)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get related products", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get related products")
+	}
+
+	s.log.Info(ctx, "Related products set successfully", map[string]interface{}{"product_id": req.ProductId, "relation_type": req.RelationType, "count": len(related)})
+
+	return &pb.SetRelatedProductsResponse{
+		RelatedProducts: toProtoRelatedProducts(related, s.now()),
+	}, nil
+}
+
+// GetRelatedProducts retrieves the products linked to a product
+func (s *Service) GetRelatedProducts(ctx context.Context, req *pb.GetRelatedProductsRequest) (*pb.GetRelatedProductsResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get related products failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.RelationType != "" && !isValidRelationType(req.RelationType) {
+		s.log.Warn(ctx, "Get related products failed: invalid relation type", map[string]interface{}{"relation_type": req.RelationType})
+		return nil, status.Error(codes.InvalidArgument, "relation_type must be RELATED, UPSELL or CROSS_SELL")
+	}
+
+	related, err := s.repo.GetRelations(ctx, req.ProductId, req.RelationType)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get related products", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get related products")
+	}
+
+	return &pb.GetRelatedProductsResponse{
+		RelatedProducts: toProtoRelatedProducts(related, s.now()),
 	}, nil
 }
 
-// toProtoProduct converts a domain Product to a protobuf Product
-func toProtoProduct(p *Product) *pb.Product {
+// isValidRelationType reports whether t is a supported relation type
+func isValidRelationType(t string) bool {
+	switch t {
+	case RelationRelated, RelationUpsell, RelationCrossSell:
+		return true
+	}
+	return false
+}
+
+// toProtoRelatedProducts converts domain related products to protobuf
+func toProtoRelatedProducts(related []*RelatedProduct, now time.Time) []*pb.RelatedProduct {
+	protoRelated := make([]*pb.RelatedProduct, len(related))
+	for i, r := range related {
+		protoRelated[i] = &pb.RelatedProduct{
+			RelationType: r.RelationType,
+			Position:     r.Position,
+			Product:      toProtoProduct(r.Product, now),
+		}
+	}
+	return protoRelated
+}
+
+// toProtoProduct converts a domain Product to a protobuf Product priced at now
+func toProtoProduct(p *Product, now time.Time) *pb.Product {
 	if p == nil {
 		return nil
 	}
 
-	return &pb.Product{
+	product := &pb.Product{
 		Id:          p.ID,
 		Name:        p.Name,
 		Description: p.Description,
 		Price:       p.Price,
 		Sku:         p.SKU,
 		Stock:       p.Stock,
-		Images:      p.Images,
+		Images:      imageURLs(p.Images),
 		Category:    p.Category,
 		CreatedAt:   timestamppb.New(p.CreatedAt),
 		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+
+		DescriptionBlocks: toProtoBlocks(p.DescriptionBlocks),
+		ImageDetails:      toProtoImages(p.Images),
+
+		EffectivePrice: p.EffectivePrice(now),
+		OnSale:         p.OnSale(now),
+
+		LowStockThreshold: p.LowStockThreshold,
+		ProductType:       p.ProductType,
+
+		WeightKg:      p.WeightKg,
+		LengthCm:      p.LengthCm,
+		WidthCm:       p.WidthCm,
+		HeightCm:      p.HeightCm,
+		ShippingClass: p.ShippingClass,
+
+		Ean:  p.EAN,
+		Upc:  p.UPC,
+		Isbn: p.ISBN,
+
+		Locale: p.Locale,
+
+		AverageRating: p.AverageRating,
+		ReviewCount:   p.ReviewCount,
+
+		Status:  p.Status,
+		Version: p.Version,
+
+		MinOrderQty:  p.MinOrderQty,
+		MaxOrderQty:  p.MaxOrderQty,
+		QtyIncrement: p.QtyIncrement,
+
+		VendorId: p.VendorID,
+	}
+	if p.SalePrice != nil {
+		product.SalePrice = *p.SalePrice
+	}
+	if p.SaleStartsAt != nil {
+		product.SaleStartsAt = timestamppb.New(*p.SaleStartsAt)
+	}
+	if p.SaleEndsAt != nil {
+		product.SaleEndsAt = timestamppb.New(*p.SaleEndsAt)
+	}
+	return product
+}
+
+// productSale is a validated sale price and window
+type productSale struct {
+	price    *float64
+	startsAt *time.Time
+	endsAt   *time.Time
+}
+
+// saleFromRequest validates the sale fields of a create or update request and
+// returns a message describing the first problem, or "". A zero sale price
+// means no sale.
+func saleFromRequest(price, salePrice float64, startsAt, endsAt *timestamppb.Timestamp) (productSale, string) {
+	var sale productSale
+	if salePrice < 0 {
+		return sale, "sale_price cannot be negative"
+	}
+	if salePrice == 0 {
+		if startsAt != nil || endsAt != nil {
+			return sale, "sale_price is required with a sale window"
+		}
+		return sale, ""
+	}
+	if salePrice >= price {
+		return sale, "sale_price must be below price"
+	}
+
+	sale.price = &salePrice
+	if startsAt != nil {
+		t := startsAt.AsTime()
+		sale.startsAt = &t
+	}
+	if endsAt != nil {
+		t := endsAt.AsTime()
+		sale.endsAt = &t
+	}
+	if sale.startsAt != nil && sale.endsAt != nil && !sale.endsAt.After(*sale.startsAt) {
+		return productSale{}, "sale_ends_at must be after sale_starts_at"
+	}
+	return sale, ""
+}
+
+// productShipping holds the validated shipping fields of a request
+type productShipping struct {
+	weightKg, lengthCm, widthCm, heightCm float64
+	class                                 string
+}
+
+// shippingFromRequest validates the shipping fields of a create or update
+// request and returns a message describing the first problem, or "". An empty
+// shipping class means STANDARD.
+func shippingFromRequest(productType string, weightKg, lengthCm, widthCm, heightCm float64, class string) (productShipping, string) {
+	shipping := productShipping{weightKg: weightKg, lengthCm: lengthCm, widthCm: widthCm, heightCm: heightCm, class: class}
+	if shipping.class == "" {
+		shipping.class = ShippingClassStandard
+	}
+
+	switch {
+	case weightKg < 0 || lengthCm < 0 || widthCm < 0 || heightCm < 0:
+		return productShipping{}, "weight and dimensions cannot be negative"
+	case (lengthCm > 0 || widthCm > 0 || heightCm > 0) && (lengthCm == 0 || widthCm == 0 || heightCm == 0):
+		return productShipping{}, "length_cm, width_cm and height_cm must be set together"
+	case !isValidShippingClass(shipping.class):
+		return productShipping{}, "shipping_class must be STANDARD, OVERSIZED, FRAGILE, HAZARDOUS or FREIGHT"
+	case productType == ProductTypeDigital && (weightKg > 0 || lengthCm > 0):
+		return productShipping{}, "digital products are not shipped"
+	}
+	return shipping, ""
+}
+
+// isValidShippingClass reports whether class is a known shipping class
+func isValidShippingClass(class string) bool {
+	switch class {
+	case ShippingClassStandard, ShippingClassOversized, ShippingClassFragile, ShippingClassHazardous, ShippingClassFreight:
+		return true
+	}
+	return false
+}
+
+// sanitizeBlocks converts protobuf content blocks and sanitizes them for storage
+func sanitizeBlocks(blocks []*pb.ContentBlock) ([]richtext.Block, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	converted := make([]richtext.Block, len(blocks))
+	for i, b := range blocks {
+		converted[i] = richtext.Block{
+			Type:  b.Type,
+			Text:  b.Text,
+			Level: b.Level,
+			Items: b.Items,
+			URL:   b.Url,
+			Alt:   b.Alt,
+		}
+	}
+	return richtext.Sanitize(converted)
+}
+
+// toProtoBlocks converts content blocks to protobuf
+func toProtoBlocks(blocks []richtext.Block) []*pb.ContentBlock {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	protoBlocks := make([]*pb.ContentBlock, len(blocks))
+	for i, b := range blocks {
+		protoBlocks[i] = &pb.ContentBlock{
+			Type:  b.Type,
+			Text:  b.Text,
+			Level: b.Level,
+			Items: b.Items,
+			Url:   b.URL,
+			Alt:   b.Alt,
+		}
 	}
+	return protoBlocks
 }
@@ -7,26 +7,115 @@ import (
 	"time"
 
 	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
 	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MockRepository is a mock implementation of Repository for testing
 type MockRepository struct {
-	CreateFunc   func(ctx context.Context, product *Product) (*Product, error)
+	CreateFunc   func(ctx context.Context, product *Product, actor string) (*Product, error)
 	GetByIDFunc  func(ctx context.Context, id string) (*Product, error)
 	GetBySKUFunc func(ctx context.Context, sku string) (*Product, error)
-	ListFunc     func(ctx context.Context, page, pageSize int32, category string) ([]*Product, int32, error)
-	UpdateFunc   func(ctx context.Context, product *Product) (*Product, error)
-	DeleteFunc   func(ctx context.Context, id string) error
-	SearchFunc   func(ctx context.Context, query string, page, pageSize int32) ([]*Product, int32, error)
+	ListFunc     func(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error)
+	UpdateFunc   func(ctx context.Context, product *Product, actor string) (*Product, error)
+	DeleteFunc   func(ctx context.Context, id, actor string) error
+	SearchFunc   func(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error)
 	CloseFunc    func() error
+
+	SetRelationsFunc func(ctx context.Context, productID, relationType string, relatedIDs []string) error
+	GetRelationsFunc func(ctx context.Context, productID, relationType string) ([]*RelatedProduct, error)
+
+	SetBookingConfigFunc    func(ctx context.Context, cfg *BookingConfig) (*BookingConfig, error)
+	GetBookingConfigFunc    func(ctx context.Context, productID string) (*BookingConfig, error)
+	ListActiveBookingsFunc  func(ctx context.Context, productID string, from, to, now time.Time) ([]*Booking, error)
+	CreateBookingFunc       func(ctx context.Context, booking *Booking, now time.Time) (*Booking, error)
+	GetBookingFunc          func(ctx context.Context, id string) (*Booking, error)
+	UpdateBookingStatusFunc func(ctx context.Context, id, fromStatus, toStatus string) (*Booking, error)
+
+	AppendImageFunc func(ctx context.Context, id, objectKey, imageURL, altText string) (*Product, error)
+
+	ReorderImagesFunc   func(ctx context.Context, productID string, imageIDs []string) error
+	SetPrimaryImageFunc func(ctx context.Context, productID, imageID string) error
+
+	ClaimPendingImagesFunc  func(ctx context.Context, limit int, lease time.Duration) ([]*ImageJob, error)
+	SaveImageValidationFunc func(ctx context.Context, v *ImageValidation) error
+	ReviewImageFunc         func(ctx context.Context, productID, imageID, altText string) error
+	SetImageRenditionsFunc  func(ctx context.Context, productID, imageID string, renditions []ImageRendition) error
+
+	GetPriceHistoryFunc func(ctx context.Context, productID string, page, pageSize int32) ([]*PriceChange, int32, error)
+
+	ListPriceHistoryChainFunc   func(ctx context.Context, afterSeq int64, limit int) ([]*PriceChange, error)
+	ListPriceChangesBetweenFunc func(ctx context.Context, from, to time.Time) ([]*PriceChange, error)
+	GetPriceHistoryHeadFunc     func(ctx context.Context) (*PriceChange, error)
+	SaveAuditAnchorFunc         func(ctx context.Context, anchor *AuditAnchor) (*AuditAnchor, error)
+	ListAuditAnchorsFunc        func(ctx context.Context) ([]*AuditAnchor, error)
+
+	SetPriceTiersFunc func(ctx context.Context, productID string, tiers []*PriceTier) error
+	GetPriceTiersFunc func(ctx context.Context, productID string) ([]*PriceTier, error)
+
+	AdjustStockFunc           func(ctx context.Context, productID string, delta int32) (*StockLevel, error)
+	ListLowStockFunc          func(ctx context.Context, page, pageSize int32) ([]*Product, int32, error)
+	ApplyInventoryUpdatesFunc func(ctx context.Context, updates []*InventoryUpdate, actor string) ([]*InventoryUpdateResult, error)
+
+	CreateStockReservationFunc   func(ctx context.Context, res *StockReservation) (*StockReservation, *StockLevel, error)
+	GetStockReservationFunc      func(ctx context.Context, id string) (*StockReservation, error)
+	CommitStockReservationFunc   func(ctx context.Context, id string, now time.Time) (*StockReservation, error)
+	ReleaseStockReservationFunc  func(ctx context.Context, id string) (*StockReservation, error)
+	UncommitStockReservationFunc func(ctx context.Context, id string) (*StockReservation, error)
+	ExpireStockReservationsFunc  func(ctx context.Context, now time.Time) (int64, error)
+
+	SetBundleFunc         func(ctx context.Context, bundle *Bundle) error
+	GetBundleFunc         func(ctx context.Context, productID string) (*Bundle, error)
+	IsBundleComponentFunc func(ctx context.Context, productID string) (bool, error)
+
+	GetByBarcodeFunc func(ctx context.Context, barcodes []string) (*Product, error)
+
+	AddDigitalAssetFunc         func(ctx context.Context, asset *DigitalAsset) (*DigitalAsset, error)
+	GetDigitalAssetFunc         func(ctx context.Context, productID, assetID string) (*DigitalAsset, error)
+	ListDigitalAssetsFunc       func(ctx context.Context, productID string) ([]*DigitalAsset, error)
+	GrantEntitlementFunc        func(ctx context.Context, ent *Entitlement) (*Entitlement, error)
+	RevokeEntitlementFunc       func(ctx context.Context, userID, productID string, now time.Time) (*Entitlement, error)
+	HasEntitlementFunc          func(ctx context.Context, userID, productID string) (bool, error)
+	SetTranslationFunc          func(ctx context.Context, t *ProductTranslation) (*ProductTranslation, error)
+	DeleteTranslationFunc       func(ctx context.Context, productID, locale string) error
+	ListTranslationsFunc        func(ctx context.Context, productID string) ([]*ProductTranslation, error)
+	GetTranslationsFunc         func(ctx context.Context, productIDs, locales []string) ([]*ProductTranslation, error)
+	UpdateRatingFunc            func(ctx context.Context, productID string, agg RatingAggregate) (bool, error)
+	ChangeSKUFunc               func(ctx context.Context, productID, newSKU, actor string) (*Product, error)
+	ListSKUAliasesFunc          func(ctx context.Context, productID string) ([]*SKUAlias, error)
+	CloneFunc                   func(ctx context.Context, sourceID, sku, name, actor string) (*Product, error)
+	ListUpdatedSinceFunc        func(ctx context.Context, cursor ProductCursor, limit int) ([]*Product, error)
+	GetBySKUsFunc               func(ctx context.Context, skus []string) (map[string]*Product, error)
+	GetCategoryStatsFunc        func(ctx context.Context, includeDrafts bool) ([]*CategoryStats, error)
+	RecordActivityFunc          func(ctx context.Context, events []*ProductActivity) (int, error)
+	ListBestSellersFunc         func(ctx context.Context, since time.Time, category string, limit int) ([]*BestSeller, error)
+	FuzzySearchFunc             func(ctx context.Context, query string, threshold float64, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error)
+	SuggestQueryFunc            func(ctx context.Context, query string, threshold float64, filter ProductFilter) (string, error)
+	SuggestProductsFunc         func(ctx context.Context, prefix string, limit int, filter ProductFilter) ([]*ProductSuggestion, []string, error)
+	SetVisibilityFunc           func(ctx context.Context, v *ProductVisibility) (*ProductVisibility, error)
+	GetVisibilityFunc           func(ctx context.Context, productID string) (*ProductVisibility, error)
+	CreateQuestionFunc          func(ctx context.Context, q *ProductQuestion) (*ProductQuestion, error)
+	GetQuestionFunc             func(ctx context.Context, id string) (*ProductQuestion, error)
+	CreateAnswerFunc            func(ctx context.Context, a *ProductAnswer) (*ProductAnswer, error)
+	ModerateQuestionFunc        func(ctx context.Context, id, status, actor string, at time.Time) (*ProductQuestion, error)
+	ModerateAnswerFunc          func(ctx context.Context, id, status, actor string, at time.Time) (*ProductAnswer, error)
+	ListQuestionsFunc           func(ctx context.Context, productID, status, answerStatus string, page, pageSize int32) ([]*ProductQuestion, int32, error)
+	GetProductAuditLogFunc      func(ctx context.Context, productID string, page, pageSize int32) ([]*ProductAuditEntry, int32, error)
+	ListProductAuditBetweenFunc func(ctx context.Context, from, to time.Time) ([]*ProductAuditEntry, error)
+
+	SubscribeBackInStockFunc          func(ctx context.Context, sub *BackInStockSubscription) (*BackInStockSubscription, error)
+	UnsubscribeBackInStockFunc        func(ctx context.Context, productID, userID string) error
+	ClaimBackInStockFunc              func(ctx context.Context, productID string, limit int, lease time.Duration) ([]*BackInStockSubscription, error)
+	DeleteBackInStockSubscriptionFunc func(ctx context.Context, id string) error
+	ListSalesVelocityFunc             func(ctx context.Context, since, until time.Time) ([]*SalesVelocity, error)
 }
 
-func (m *MockRepository) Create(ctx context.Context, product *Product) (*Product, error) {
+func (m *MockRepository) Create(ctx context.Context, product *Product, actor string) (*Product, error) {
 	if m.CreateFunc != nil {
-		return m.CreateFunc(ctx, product)
+		return m.CreateFunc(ctx, product, actor)
 	}
 	return nil, errors.New("not implemented")
 }
@@ -45,73 +134,902 @@ func (m *MockRepository) GetBySKU(ctx context.Context, sku string) (*Product, er
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockRepository) List(ctx context.Context, page, pageSize int32, category string) ([]*Product, int32, error) {
+func (m *MockRepository) List(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
 	if m.ListFunc != nil {
-		return m.ListFunc(ctx, page, pageSize, category)
+		return m.ListFunc(ctx, page, pageSize, filter)
 	}
 	return nil, 0, errors.New("not implemented")
 }
 
-func (m *MockRepository) Update(ctx context.Context, product *Product) (*Product, error) {
+func (m *MockRepository) Update(ctx context.Context, product *Product, actor string) (*Product, error) {
 	if m.UpdateFunc != nil {
-		return m.UpdateFunc(ctx, product)
+		return m.UpdateFunc(ctx, product, actor)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockRepository) Delete(ctx context.Context, id string) error {
+func (m *MockRepository) Delete(ctx context.Context, id, actor string) error {
 	if m.DeleteFunc != nil {
-		return m.DeleteFunc(ctx, id)
+		return m.DeleteFunc(ctx, id, actor)
 	}
 	return errors.New("not implemented")
 }
 
-func (m *MockRepository) Search(ctx context.Context, query string, page, pageSize int32) ([]*Product, int32, error) {
+func (m *MockRepository) Search(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
 	if m.SearchFunc != nil {
-		return m.SearchFunc(ctx, query, page, pageSize)
+		return m.SearchFunc(ctx, query, page, pageSize, filter)
 	}
 	return nil, 0, errors.New("not implemented")
 }
 
-func (m *MockRepository) Close() error {
-	if m.CloseFunc != nil {
-		return m.CloseFunc()
+func (m *MockRepository) SetRelations(ctx context.Context, productID, relationType string, relatedIDs []string) error {
+	if m.SetRelationsFunc != nil {
+		return m.SetRelationsFunc(ctx, productID, relationType, relatedIDs)
 	}
-	return nil
+	return errors.New("not implemented")
 }
 
-func setupService(repo Repository) *Service {
-	log := logger.New("catalog-test")
-	return NewService(repo, log)
+func (m *MockRepository) GetRelations(ctx context.Context, productID, relationType string) ([]*RelatedProduct, error) {
+	if m.GetRelationsFunc != nil {
+		return m.GetRelationsFunc(ctx, productID, relationType)
+	}
+	return nil, errors.New("not implemented")
 }
 
-func TestCreateProduct_Success(t *testing.T) {
-	mockRepo := &MockRepository{
-		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
-			return nil, errors.New("not found")
-		},
-		CreateFunc: func(ctx context.Context, product *Product) (*Product, error) {
-			product.ID = "test-id"
-			product.CreatedAt = time.Now()
-			product.UpdatedAt = time.Now()
-			return product, nil
+func (m *MockRepository) SetBookingConfig(ctx context.Context, cfg *BookingConfig) (*BookingConfig, error) {
+	if m.SetBookingConfigFunc != nil {
+		return m.SetBookingConfigFunc(ctx, cfg)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetBookingConfig(ctx context.Context, productID string) (*BookingConfig, error) {
+	if m.GetBookingConfigFunc != nil {
+		return m.GetBookingConfigFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListActiveBookings(ctx context.Context, productID string, from, to, now time.Time) ([]*Booking, error) {
+	if m.ListActiveBookingsFunc != nil {
+		return m.ListActiveBookingsFunc(ctx, productID, from, to, now)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) CreateBooking(ctx context.Context, booking *Booking, now time.Time) (*Booking, error) {
+	if m.CreateBookingFunc != nil {
+		return m.CreateBookingFunc(ctx, booking, now)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetBooking(ctx context.Context, id string) (*Booking, error) {
+	if m.GetBookingFunc != nil {
+		return m.GetBookingFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) UpdateBookingStatus(ctx context.Context, id, fromStatus, toStatus string) (*Booking, error) {
+	if m.UpdateBookingStatusFunc != nil {
+		return m.UpdateBookingStatusFunc(ctx, id, fromStatus, toStatus)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) AppendImage(ctx context.Context, id, objectKey, imageURL, altText string) (*Product, error) {
+	if m.AppendImageFunc != nil {
+		return m.AppendImageFunc(ctx, id, objectKey, imageURL, altText)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ReorderImages(ctx context.Context, productID string, imageIDs []string) error {
+	if m.ReorderImagesFunc != nil {
+		return m.ReorderImagesFunc(ctx, productID, imageIDs)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) SetPrimaryImage(ctx context.Context, productID, imageID string) error {
+	if m.SetPrimaryImageFunc != nil {
+		return m.SetPrimaryImageFunc(ctx, productID, imageID)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) ClaimPendingImages(ctx context.Context, limit int, lease time.Duration) ([]*ImageJob, error) {
+	if m.ClaimPendingImagesFunc != nil {
+		return m.ClaimPendingImagesFunc(ctx, limit, lease)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) SaveImageValidation(ctx context.Context, v *ImageValidation) error {
+	if m.SaveImageValidationFunc != nil {
+		return m.SaveImageValidationFunc(ctx, v)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) ReviewImage(ctx context.Context, productID, imageID, altText string) error {
+	if m.ReviewImageFunc != nil {
+		return m.ReviewImageFunc(ctx, productID, imageID, altText)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) SetImageRenditions(ctx context.Context, productID, imageID string, renditions []ImageRendition) error {
+	if m.SetImageRenditionsFunc != nil {
+		return m.SetImageRenditionsFunc(ctx, productID, imageID, renditions)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) GetPriceHistory(ctx context.Context, productID string, page, pageSize int32) ([]*PriceChange, int32, error) {
+	if m.GetPriceHistoryFunc != nil {
+		return m.GetPriceHistoryFunc(ctx, productID, page, pageSize)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListPriceHistoryChain(ctx context.Context, afterSeq int64, limit int) ([]*PriceChange, error) {
+	if m.ListPriceHistoryChainFunc != nil {
+		return m.ListPriceHistoryChainFunc(ctx, afterSeq, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListPriceChangesBetween(ctx context.Context, from, to time.Time) ([]*PriceChange, error) {
+	if m.ListPriceChangesBetweenFunc != nil {
+		return m.ListPriceChangesBetweenFunc(ctx, from, to)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetPriceHistoryHead(ctx context.Context) (*PriceChange, error) {
+	if m.GetPriceHistoryHeadFunc != nil {
+		return m.GetPriceHistoryHeadFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) SaveAuditAnchor(ctx context.Context, anchor *AuditAnchor) (*AuditAnchor, error) {
+	if m.SaveAuditAnchorFunc != nil {
+		return m.SaveAuditAnchorFunc(ctx, anchor)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListAuditAnchors(ctx context.Context) ([]*AuditAnchor, error) {
+	if m.ListAuditAnchorsFunc != nil {
+		return m.ListAuditAnchorsFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) SetPriceTiers(ctx context.Context, productID string, tiers []*PriceTier) error {
+	if m.SetPriceTiersFunc != nil {
+		return m.SetPriceTiersFunc(ctx, productID, tiers)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) GetPriceTiers(ctx context.Context, productID string) ([]*PriceTier, error) {
+	if m.GetPriceTiersFunc != nil {
+		return m.GetPriceTiersFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) AdjustStock(ctx context.Context, productID string, delta int32) (*StockLevel, error) {
+	if m.AdjustStockFunc != nil {
+		return m.AdjustStockFunc(ctx, productID, delta)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListLowStock(ctx context.Context, page, pageSize int32) ([]*Product, int32, error) {
+	if m.ListLowStockFunc != nil {
+		return m.ListLowStockFunc(ctx, page, pageSize)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) ApplyInventoryUpdates(ctx context.Context, updates []*InventoryUpdate, actor string) ([]*InventoryUpdateResult, error) {
+	if m.ApplyInventoryUpdatesFunc != nil {
+		return m.ApplyInventoryUpdatesFunc(ctx, updates, actor)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) CreateStockReservation(ctx context.Context, res *StockReservation) (*StockReservation, *StockLevel, error) {
+	if m.CreateStockReservationFunc != nil {
+		return m.CreateStockReservationFunc(ctx, res)
+	}
+	return nil, nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetStockReservation(ctx context.Context, id string) (*StockReservation, error) {
+	if m.GetStockReservationFunc != nil {
+		return m.GetStockReservationFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) CommitStockReservation(ctx context.Context, id string, now time.Time) (*StockReservation, error) {
+	if m.CommitStockReservationFunc != nil {
+		return m.CommitStockReservationFunc(ctx, id, now)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ReleaseStockReservation(ctx context.Context, id string) (*StockReservation, error) {
+	if m.ReleaseStockReservationFunc != nil {
+		return m.ReleaseStockReservationFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) UncommitStockReservation(ctx context.Context, id string) (*StockReservation, error) {
+	if m.UncommitStockReservationFunc != nil {
+		return m.UncommitStockReservationFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ExpireStockReservations(ctx context.Context, now time.Time) (int64, error) {
+	if m.ExpireStockReservationsFunc != nil {
+		return m.ExpireStockReservationsFunc(ctx, now)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) SetBundle(ctx context.Context, bundle *Bundle) error {
+	if m.SetBundleFunc != nil {
+		return m.SetBundleFunc(ctx, bundle)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) GetBundle(ctx context.Context, productID string) (*Bundle, error) {
+	if m.GetBundleFunc != nil {
+		return m.GetBundleFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) IsBundleComponent(ctx context.Context, productID string) (bool, error) {
+	if m.IsBundleComponentFunc != nil {
+		return m.IsBundleComponentFunc(ctx, productID)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetByBarcode(ctx context.Context, barcodes []string) (*Product, error) {
+	if m.GetByBarcodeFunc != nil {
+		return m.GetByBarcodeFunc(ctx, barcodes)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) AddDigitalAsset(ctx context.Context, asset *DigitalAsset) (*DigitalAsset, error) {
+	if m.AddDigitalAssetFunc != nil {
+		return m.AddDigitalAssetFunc(ctx, asset)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetDigitalAsset(ctx context.Context, productID, assetID string) (*DigitalAsset, error) {
+	if m.GetDigitalAssetFunc != nil {
+		return m.GetDigitalAssetFunc(ctx, productID, assetID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListDigitalAssets(ctx context.Context, productID string) ([]*DigitalAsset, error) {
+	if m.ListDigitalAssetsFunc != nil {
+		return m.ListDigitalAssetsFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GrantEntitlement(ctx context.Context, ent *Entitlement) (*Entitlement, error) {
+	if m.GrantEntitlementFunc != nil {
+		return m.GrantEntitlementFunc(ctx, ent)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) RevokeEntitlement(ctx context.Context, userID, productID string, now time.Time) (*Entitlement, error) {
+	if m.RevokeEntitlementFunc != nil {
+		return m.RevokeEntitlementFunc(ctx, userID, productID, now)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) HasEntitlement(ctx context.Context, userID, productID string) (bool, error) {
+	if m.HasEntitlementFunc != nil {
+		return m.HasEntitlementFunc(ctx, userID, productID)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *MockRepository) SetTranslation(ctx context.Context, t *ProductTranslation) (*ProductTranslation, error) {
+	if m.SetTranslationFunc != nil {
+		return m.SetTranslationFunc(ctx, t)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) DeleteTranslation(ctx context.Context, productID, locale string) error {
+	if m.DeleteTranslationFunc != nil {
+		return m.DeleteTranslationFunc(ctx, productID, locale)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) ListTranslations(ctx context.Context, productID string) ([]*ProductTranslation, error) {
+	if m.ListTranslationsFunc != nil {
+		return m.ListTranslationsFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetTranslations(ctx context.Context, productIDs, locales []string) ([]*ProductTranslation, error) {
+	if m.GetTranslationsFunc != nil {
+		return m.GetTranslationsFunc(ctx, productIDs, locales)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) UpdateRatingAggregate(ctx context.Context, productID string, agg RatingAggregate) (bool, error) {
+	if m.UpdateRatingFunc != nil {
+		return m.UpdateRatingFunc(ctx, productID, agg)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *MockRepository) ChangeSKU(ctx context.Context, productID, newSKU, actor string) (*Product, error) {
+	if m.ChangeSKUFunc != nil {
+		return m.ChangeSKUFunc(ctx, productID, newSKU, actor)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListSKUAliases(ctx context.Context, productID string) ([]*SKUAlias, error) {
+	if m.ListSKUAliasesFunc != nil {
+		return m.ListSKUAliasesFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) Clone(ctx context.Context, sourceID, sku, name, actor string) (*Product, error) {
+	if m.CloneFunc != nil {
+		return m.CloneFunc(ctx, sourceID, sku, name, actor)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListUpdatedSince(ctx context.Context, cursor ProductCursor, limit int) ([]*Product, error) {
+	if m.ListUpdatedSinceFunc != nil {
+		return m.ListUpdatedSinceFunc(ctx, cursor, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetBySKUs(ctx context.Context, skus []string) (map[string]*Product, error) {
+	if m.GetBySKUsFunc != nil {
+		return m.GetBySKUsFunc(ctx, skus)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetCategoryStats(ctx context.Context, includeDrafts bool) ([]*CategoryStats, error) {
+	if m.GetCategoryStatsFunc != nil {
+		return m.GetCategoryStatsFunc(ctx, includeDrafts)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) RecordActivity(ctx context.Context, events []*ProductActivity) (int, error) {
+	if m.RecordActivityFunc != nil {
+		return m.RecordActivityFunc(ctx, events)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListBestSellers(ctx context.Context, since time.Time, category string, limit int) ([]*BestSeller, error) {
+	if m.ListBestSellersFunc != nil {
+		return m.ListBestSellersFunc(ctx, since, category, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) FuzzySearch(ctx context.Context, query string, threshold float64, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+	if m.FuzzySearchFunc != nil {
+		return m.FuzzySearchFunc(ctx, query, threshold, page, pageSize, filter)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) SuggestQuery(ctx context.Context, query string, threshold float64, filter ProductFilter) (string, error) {
+	if m.SuggestQueryFunc != nil {
+		return m.SuggestQueryFunc(ctx, query, threshold, filter)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (m *MockRepository) SuggestProducts(ctx context.Context, prefix string, limit int, filter ProductFilter) ([]*ProductSuggestion, []string, error) {
+	if m.SuggestProductsFunc != nil {
+		return m.SuggestProductsFunc(ctx, prefix, limit, filter)
+	}
+	return nil, nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) SetVisibility(ctx context.Context, v *ProductVisibility) (*ProductVisibility, error) {
+	if m.SetVisibilityFunc != nil {
+		return m.SetVisibilityFunc(ctx, v)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetVisibility(ctx context.Context, productID string) (*ProductVisibility, error) {
+	if m.GetVisibilityFunc != nil {
+		return m.GetVisibilityFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) CreateQuestion(ctx context.Context, q *ProductQuestion) (*ProductQuestion, error) {
+	if m.CreateQuestionFunc != nil {
+		return m.CreateQuestionFunc(ctx, q)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetQuestion(ctx context.Context, id string) (*ProductQuestion, error) {
+	if m.GetQuestionFunc != nil {
+		return m.GetQuestionFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) CreateAnswer(ctx context.Context, a *ProductAnswer) (*ProductAnswer, error) {
+	if m.CreateAnswerFunc != nil {
+		return m.CreateAnswerFunc(ctx, a)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ModerateQuestion(ctx context.Context, id, status, actor string, at time.Time) (*ProductQuestion, error) {
+	if m.ModerateQuestionFunc != nil {
+		return m.ModerateQuestionFunc(ctx, id, status, actor, at)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ModerateAnswer(ctx context.Context, id, status, actor string, at time.Time) (*ProductAnswer, error) {
+	if m.ModerateAnswerFunc != nil {
+		return m.ModerateAnswerFunc(ctx, id, status, actor, at)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListQuestions(ctx context.Context, productID, status, answerStatus string, page, pageSize int32) ([]*ProductQuestion, int32, error) {
+	if m.ListQuestionsFunc != nil {
+		return m.ListQuestionsFunc(ctx, productID, status, answerStatus, page, pageSize)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetProductAuditLog(ctx context.Context, productID string, page, pageSize int32) ([]*ProductAuditEntry, int32, error) {
+	if m.GetProductAuditLogFunc != nil {
+		return m.GetProductAuditLogFunc(ctx, productID, page, pageSize)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) ListProductAuditBetween(ctx context.Context, from, to time.Time) ([]*ProductAuditEntry, error) {
+	if m.ListProductAuditBetweenFunc != nil {
+		return m.ListProductAuditBetweenFunc(ctx, from, to)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) SubscribeBackInStock(ctx context.Context, sub *BackInStockSubscription) (*BackInStockSubscription, error) {
+	if m.SubscribeBackInStockFunc != nil {
+		return m.SubscribeBackInStockFunc(ctx, sub)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) UnsubscribeBackInStock(ctx context.Context, productID, userID string) error {
+	if m.UnsubscribeBackInStockFunc != nil {
+		return m.UnsubscribeBackInStockFunc(ctx, productID, userID)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) ClaimBackInStock(ctx context.Context, productID string, limit int, lease time.Duration) ([]*BackInStockSubscription, error) {
+	if m.ClaimBackInStockFunc != nil {
+		return m.ClaimBackInStockFunc(ctx, productID, limit, lease)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) DeleteBackInStockSubscription(ctx context.Context, id string) error {
+	if m.DeleteBackInStockSubscriptionFunc != nil {
+		return m.DeleteBackInStockSubscriptionFunc(ctx, id)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) ListSalesVelocity(ctx context.Context, since, until time.Time) ([]*SalesVelocity, error) {
+	if m.ListSalesVelocityFunc != nil {
+		return m.ListSalesVelocityFunc(ctx, since, until)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) Close() error {
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+func setupService(repo Repository) *Service {
+	log := logger.New("catalog-test")
+	return NewService(repo, log)
+}
+
+func TestCreateProduct_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			product.ID = "test-id"
+			product.CreatedAt = time.Now()
+			product.UpdatedAt = time.Now()
+			return product, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.CreateProductRequest{
+		Name:        "Test Product",
+		Description: "Test Description",
+		Price:       99.99,
+		Sku:         "TEST-001",
+		Stock:       10,
+		Images:      []string{"image1.jpg"},
+		Category:    "Electronics",
+	}
+
+	resp, err := service.CreateProduct(ctx, req)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if resp == nil {
+		t.Fatal("Expected response, got nil")
+	}
+
+	if resp.Product.Name != req.Name {
+		t.Errorf("Expected name %s, got %s", req.Name, resp.Product.Name)
+	}
+
+	if resp.Product.Sku != req.Sku {
+		t.Errorf("Expected SKU %s, got %s", req.Sku, resp.Product.Sku)
+	}
+}
+
+func TestCreateProduct_MissingName(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.CreateProductRequest{
+		Name:  "",
+		Price: 99.99,
+		Sku:   "TEST-001",
+		Stock: 10,
+	}
+
+	resp, err := service.CreateProduct(ctx, req)
+
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	if resp != nil {
+		t.Errorf("Expected nil response, got %v", resp)
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestCreateProduct_MissingSKU(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.CreateProductRequest{
+		Name:  "Test Product",
+		Price: 99.99,
+		Sku:   "",
+		Stock: 10,
+	}
+
+	resp, err := service.CreateProduct(ctx, req)
+
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	if resp != nil {
+		t.Errorf("Expected nil response, got %v", resp)
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestCreateProduct_InvalidPrice(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.CreateProductRequest{
+		Name:  "Test Product",
+		Price: -10.0,
+		Sku:   "TEST-001",
+		Stock: 10,
+	}
+
+	_, err := service.CreateProduct(ctx, req)
+
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestCreateProduct_NegativeStock(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.CreateProductRequest{
+		Name:  "Test Product",
+		Price: 99.99,
+		Sku:   "TEST-001",
+		Stock: -5,
+	}
+
+	_, err := service.CreateProduct(ctx, req)
+
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestCreateProduct_DuplicateSKU(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return &Product{ID: "existing-id", SKU: sku}, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.CreateProductRequest{
+		Name:  "Test Product",
+		Price: 99.99,
+		Sku:   "TEST-001",
+		Stock: 10,
+	}
+
+	_, err := service.CreateProduct(ctx, req)
+
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists error, got %v", err)
+	}
+}
+
+func TestGetProduct_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{
+				ID:          id,
+				Name:        "Test Product",
+				Description: "Test Description",
+				Price:       99.99,
+				SKU:         "TEST-001",
+				Stock:       10,
+				Images:      []*ProductImage{{ID: "img-1", URL: "image1.jpg", IsPrimary: true}},
+				Category:    "Electronics",
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			}, nil
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.GetProductRequest{Id: "test-id"}
+	resp, err := service.GetProduct(ctx, req)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if resp == nil {
+		t.Fatal("Expected response, got nil")
+	}
+
+	if resp.Product.Id != "test-id" {
+		t.Errorf("Expected ID test-id, got %s", resp.Product.Id)
+	}
+}
+
+func TestGetProduct_MissingID(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.GetProductRequest{Id: ""}
+	_, err := service.GetProduct(ctx, req)
+
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestGetProduct_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
+
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.GetProductRequest{Id: "non-existent"}
+	_, err := service.GetProduct(ctx, req)
+
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestGetProduct_DatabaseError(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return nil, errors.New("connection refused")
+		},
+		DeleteFunc: func(ctx context.Context, id, actor string) error {
+			return errors.New("connection refused")
+		},
+	}
+	service := setupService(mockRepo)
+
+	// An outage must not look like a missing product
+	_, err := service.GetProduct(context.Background(), &pb.GetProductRequest{Id: "prod-1"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+
+	_, err = service.DeleteProduct(context.Background(), &pb.DeleteProductRequest{Id: "prod-1"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal from delete, got %v", err)
+	}
+}
+
+func TestCreateProduct_SKUErrors(t *testing.T) {
+	lookupErr := errors.New("connection refused")
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			if sku == "BROKEN" {
+				return nil, lookupErr
+			}
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			// Another request created the SKU after the check
+			return nil, ErrDuplicateSKU
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		sku      string
+		expected codes.Code
+	}{
+		{"BROKEN", codes.Internal},
+		{"LAMP-001", codes.AlreadyExists},
+	}
+	for _, tt := range tests {
+		_, err := service.CreateProduct(context.Background(), &pb.CreateProductRequest{Name: "Lamp", Sku: tt.sku, Price: 40})
+		if status.Code(err) != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.sku, tt.expected, err)
+		}
+	}
+}
+
+func TestListProducts_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		ListFunc: func(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			return []*Product{
+				{
+					ID:        "id1",
+					Name:      "Product 1",
+					Price:     99.99,
+					SKU:       "SKU-001",
+					Stock:     10,
+					CreatedAt: time.Now(),
+					UpdatedAt: time.Now(),
+				},
+				{
+					ID:        "id2",
+					Name:      "Product 2",
+					Price:     149.99,
+					SKU:       "SKU-002",
+					Stock:     20,
+					CreatedAt: time.Now(),
+					UpdatedAt: time.Now(),
+				},
+			}, 2, nil
 		},
 	}
 
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.CreateProductRequest{
-		Name:        "Test Product",
-		Description: "Test Description",
-		Price:       99.99,
-		Sku:         "TEST-001",
-		Stock:       10,
-		Images:      []string{"image1.jpg"},
-		Category:    "Electronics",
+	req := &pb.ListProductsRequest{
+		Page:     1,
+		PageSize: 10,
 	}
 
-	resp, err := service.CreateProduct(ctx, req)
+	resp, err := service.ListProducts(ctx, req)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -121,108 +1039,134 @@ func TestCreateProduct_Success(t *testing.T) {
 		t.Fatal("Expected response, got nil")
 	}
 
-	if resp.Product.Name != req.Name {
-		t.Errorf("Expected name %s, got %s", req.Name, resp.Product.Name)
+	if len(resp.Products) != 2 {
+		t.Errorf("Expected 2 products, got %d", len(resp.Products))
 	}
 
-	if resp.Product.Sku != req.Sku {
-		t.Errorf("Expected SKU %s, got %s", req.Sku, resp.Product.Sku)
+	if resp.Total != 2 {
+		t.Errorf("Expected total 2, got %d", resp.Total)
 	}
 }
 
-func TestCreateProduct_MissingName(t *testing.T) {
-	mockRepo := &MockRepository{}
+func TestListProducts_WithCategory(t *testing.T) {
+	mockRepo := &MockRepository{
+		ListFunc: func(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			if filter.Category != "Electronics" {
+				t.Errorf("Expected category Electronics, got %s", filter.Category)
+			}
+			return []*Product{}, 0, nil
+		},
+	}
+
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.CreateProductRequest{
-		Name:  "",
-		Price: 99.99,
-		Sku:   "TEST-001",
-		Stock: 10,
+	req := &pb.ListProductsRequest{
+		Page:     1,
+		PageSize: 10,
+		Category: "Electronics",
 	}
 
-	resp, err := service.CreateProduct(ctx, req)
-
-	if err == nil {
-		t.Error("Expected error, got nil")
-	}
+	_, err := service.ListProducts(ctx, req)
 
-	if resp != nil {
-		t.Errorf("Expected nil response, got %v", resp)
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
 	}
+}
 
-	st, ok := status.FromError(err)
-	if !ok || st.Code() != codes.InvalidArgument {
-		t.Errorf("Expected InvalidArgument error, got %v", err)
+func TestUpdateProduct_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{
+				ID:        id,
+				SKU:       "TEST-001",
+				CreatedAt: time.Now(),
+				Version:   1,
+			}, nil
+		},
+		UpdateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			product.UpdatedAt = time.Now()
+			return product, nil
+		},
 	}
-}
 
-func TestCreateProduct_MissingSKU(t *testing.T) {
-	mockRepo := &MockRepository{}
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.CreateProductRequest{
-		Name:  "Test Product",
-		Price: 99.99,
-		Sku:   "",
-		Stock: 10,
+	req := &pb.UpdateProductRequest{
+		Id:          "test-id",
+		Name:        "Updated Product",
+		Description: "Updated Description",
+		Price:       199.99,
+		Stock:       20,
+		Images:      []string{"new-image.jpg"},
+		Category:    "Electronics",
+		Version:     1,
 	}
 
-	resp, err := service.CreateProduct(ctx, req)
+	resp, err := service.UpdateProduct(ctx, req)
 
-	if err == nil {
-		t.Error("Expected error, got nil")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if resp != nil {
-		t.Errorf("Expected nil response, got %v", resp)
+	if resp == nil {
+		t.Fatal("Expected response, got nil")
 	}
 
-	st, ok := status.FromError(err)
-	if !ok || st.Code() != codes.InvalidArgument {
-		t.Errorf("Expected InvalidArgument error, got %v", err)
+	if resp.Product.Name != req.Name {
+		t.Errorf("Expected name %s, got %s", req.Name, resp.Product.Name)
 	}
 }
 
-func TestCreateProduct_InvalidPrice(t *testing.T) {
-	mockRepo := &MockRepository{}
-	service := setupService(mockRepo)
-	ctx := context.Background()
-
-	req := &pb.CreateProductRequest{
-		Name:  "Test Product",
-		Price: -10.0,
-		Sku:   "TEST-001",
-		Stock: 10,
+func TestUpdateProduct_Version(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, SKU: "TEST-001", Version: 3}, nil
+		},
+		UpdateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			// Another update committed after GetByID
+			return nil, ErrVersionConflict
+		},
 	}
 
-	_, err := service.CreateProduct(ctx, req)
-
-	if err == nil {
-		t.Error("Expected error, got nil")
-	}
+	service := setupService(mockRepo)
+	ctx := context.Background()
 
-	st, ok := status.FromError(err)
-	if !ok || st.Code() != codes.InvalidArgument {
-		t.Errorf("Expected InvalidArgument error, got %v", err)
+	tests := []struct {
+		name     string
+		version  int64
+		expected codes.Code
+	}{
+		{"missing version", 0, codes.InvalidArgument},
+		{"stale version", 2, codes.FailedPrecondition},
+		{"concurrent update", 3, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.UpdateProductRequest{Id: "test-id", Name: "Updated Product", Price: 199.99, Version: tt.version}
+			if _, err := service.UpdateProduct(ctx, req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
 	}
 }
 
-func TestCreateProduct_NegativeStock(t *testing.T) {
+func TestUpdateProduct_MissingID(t *testing.T) {
 	mockRepo := &MockRepository{}
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.CreateProductRequest{
-		Name:  "Test Product",
-		Price: 99.99,
-		Sku:   "TEST-001",
-		Stock: -5,
+	req := &pb.UpdateProductRequest{
+		Id:      "",
+		Name:    "Updated Product",
+		Price:   199.99,
+		Stock:   20,
+		Version: 1,
 	}
 
-	_, err := service.CreateProduct(ctx, req)
+	_, err := service.UpdateProduct(ctx, req)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -234,58 +1178,50 @@ func TestCreateProduct_NegativeStock(t *testing.T) {
 	}
 }
 
-func TestCreateProduct_DuplicateSKU(t *testing.T) {
+func TestUpdateProduct_NotFound(t *testing.T) {
 	mockRepo := &MockRepository{
-		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
-			return &Product{ID: "existing-id", SKU: sku}, nil
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return nil, ErrProductNotFound
 		},
 	}
 
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.CreateProductRequest{
-		Name:  "Test Product",
-		Price: 99.99,
-		Sku:   "TEST-001",
-		Stock: 10,
+	req := &pb.UpdateProductRequest{
+		Id:      "non-existent",
+		Name:    "Updated Product",
+		Price:   199.99,
+		Stock:   20,
+		Version: 1,
 	}
 
-	_, err := service.CreateProduct(ctx, req)
+	_, err := service.UpdateProduct(ctx, req)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
 
 	st, ok := status.FromError(err)
-	if !ok || st.Code() != codes.AlreadyExists {
-		t.Errorf("Expected AlreadyExists error, got %v", err)
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
 	}
 }
 
-func TestGetProduct_Success(t *testing.T) {
+func TestDeleteProduct_Success(t *testing.T) {
+	var gotActor string
 	mockRepo := &MockRepository{
-		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
-			return &Product{
-				ID:          id,
-				Name:        "Test Product",
-				Description: "Test Description",
-				Price:       99.99,
-				SKU:         "TEST-001",
-				Stock:       10,
-				Images:      []string{"image1.jpg"},
-				Category:    "Electronics",
-				CreatedAt:   time.Now(),
-				UpdatedAt:   time.Now(),
-			}, nil
+		DeleteFunc: func(ctx context.Context, id, actor string) error {
+			gotActor = actor
+			return nil
 		},
 	}
 
 	service := setupService(mockRepo)
-	ctx := context.Background()
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "admin-1", Role: auth.RoleAdmin})
 
-	req := &pb.GetProductRequest{Id: "test-id"}
-	resp, err := service.GetProduct(ctx, req)
+	req := &pb.DeleteProductRequest{Id: "test-id"}
+	resp, err := service.DeleteProduct(ctx, req)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -295,18 +1231,21 @@ func TestGetProduct_Success(t *testing.T) {
 		t.Fatal("Expected response, got nil")
 	}
 
-	if resp.Product.Id != "test-id" {
-		t.Errorf("Expected ID test-id, got %s", resp.Product.Id)
+	if !resp.Success {
+		t.Error("Expected success to be true")
+	}
+	if gotActor != "admin-1" {
+		t.Errorf("Expected actor admin-1, got %q", gotActor)
 	}
 }
 
-func TestGetProduct_MissingID(t *testing.T) {
+func TestDeleteProduct_MissingID(t *testing.T) {
 	mockRepo := &MockRepository{}
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.GetProductRequest{Id: ""}
-	_, err := service.GetProduct(ctx, req)
+	req := &pb.DeleteProductRequest{Id: ""}
+	_, err := service.DeleteProduct(ctx, req)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -318,18 +1257,18 @@ func TestGetProduct_MissingID(t *testing.T) {
 	}
 }
 
-func TestGetProduct_NotFound(t *testing.T) {
+func TestDeleteProduct_NotFound(t *testing.T) {
 	mockRepo := &MockRepository{
-		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
-			return nil, errors.New("not found")
+		DeleteFunc: func(ctx context.Context, id, actor string) error {
+			return ErrProductNotFound
 		},
 	}
 
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.GetProductRequest{Id: "non-existent"}
-	_, err := service.GetProduct(ctx, req)
+	req := &pb.DeleteProductRequest{Id: "non-existent"}
+	_, err := service.DeleteProduct(ctx, req)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -341,174 +1280,205 @@ func TestGetProduct_NotFound(t *testing.T) {
 	}
 }
 
-func TestListProducts_Success(t *testing.T) {
+func TestSearchProducts_Success(t *testing.T) {
 	mockRepo := &MockRepository{
-		ListFunc: func(ctx context.Context, page, pageSize int32, category string) ([]*Product, int32, error) {
+		SearchFunc: func(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
 			return []*Product{
 				{
 					ID:        "id1",
-					Name:      "Product 1",
+					Name:      "Test Product",
 					Price:     99.99,
 					SKU:       "SKU-001",
 					Stock:     10,
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				},
-				{
-					ID:        "id2",
-					Name:      "Product 2",
-					Price:     149.99,
-					SKU:       "SKU-002",
-					Stock:     20,
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
-				},
-			}, 2, nil
+			}, 1, nil
 		},
 	}
 
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.ListProductsRequest{
+	req := &pb.SearchProductsRequest{
+		Query:    "test",
+		Page:     1,
+		PageSize: 10,
+	}
+
+	resp, err := service.SearchProducts(ctx, req)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if resp == nil {
+		t.Fatal("Expected response, got nil")
+	}
+
+	if len(resp.Products) != 1 {
+		t.Errorf("Expected 1 product, got %d", len(resp.Products))
+	}
+
+	if resp.Total != 1 {
+		t.Errorf("Expected total 1, got %d", resp.Total)
+	}
+}
+
+func TestSearchProducts_MissingQuery(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	req := &pb.SearchProductsRequest{
+		Query:    "",
 		Page:     1,
 		PageSize: 10,
 	}
 
-	resp, err := service.ListProducts(ctx, req)
-
-	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
-	}
-
-	if resp == nil {
-		t.Fatal("Expected response, got nil")
-	}
+	_, err := service.SearchProducts(ctx, req)
 
-	if len(resp.Products) != 2 {
-		t.Errorf("Expected 2 products, got %d", len(resp.Products))
+	if err == nil {
+		t.Error("Expected error, got nil")
 	}
 
-	if resp.Total != 2 {
-		t.Errorf("Expected total 2, got %d", resp.Total)
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
 	}
 }
 
-func TestListProducts_WithCategory(t *testing.T) {
+func TestGetProduct_IncludeRelated(t *testing.T) {
 	mockRepo := &MockRepository{
-		ListFunc: func(ctx context.Context, page, pageSize int32, category string) ([]*Product, int32, error) {
-			if category != "Electronics" {
-				t.Errorf("Expected category Electronics, got %s", category)
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, Name: "Phone", SKU: "PHONE-1", Price: 499}, nil
+		},
+		GetRelationsFunc: func(ctx context.Context, productID, relationType string) ([]*RelatedProduct, error) {
+			if relationType != "" {
+				t.Errorf("Expected all relation types, got %s", relationType)
 			}
-			return []*Product{}, 0, nil
+			return []*RelatedProduct{
+				{RelationType: RelationCrossSell, Position: 0, Product: &Product{ID: "case-id", Name: "Case"}},
+			}, nil
 		},
 	}
 
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.ListProductsRequest{
-		Page:     1,
-		PageSize: 10,
-		Category: "Electronics",
+	resp, err := service.GetProduct(ctx, &pb.GetProductRequest{Id: "phone-id", IncludeRelated: true})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	_, err := service.ListProducts(ctx, req)
+	if len(resp.RelatedProducts) != 1 {
+		t.Fatalf("Expected 1 related product, got %d", len(resp.RelatedProducts))
+	}
 
-	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
+	if resp.RelatedProducts[0].RelationType != RelationCrossSell || resp.RelatedProducts[0].Product.Id != "case-id" {
+		t.Errorf("Unexpected related product %v", resp.RelatedProducts[0])
 	}
 }
 
-func TestUpdateProduct_Success(t *testing.T) {
+func TestSetRelatedProducts_Success(t *testing.T) {
+	var stored []string
 	mockRepo := &MockRepository{
 		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
-			return &Product{
-				ID:        id,
-				SKU:       "TEST-001",
-				CreatedAt: time.Now(),
-			}, nil
+			return &Product{ID: id}, nil
 		},
-		UpdateFunc: func(ctx context.Context, product *Product) (*Product, error) {
-			product.UpdatedAt = time.Now()
-			return product, nil
+		SetRelationsFunc: func(ctx context.Context, productID, relationType string, relatedIDs []string) error {
+			stored = relatedIDs
+			return nil
+		},
+		GetRelationsFunc: func(ctx context.Context, productID, relationType string) ([]*RelatedProduct, error) {
+			related := make([]*RelatedProduct, len(stored))
+			for i, id := range stored {
+				related[i] = &RelatedProduct{RelationType: relationType, Position: int32(i), Product: &Product{ID: id}}
+			}
+			return related, nil
 		},
 	}
 
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.UpdateProductRequest{
-		Id:          "test-id",
-		Name:        "Updated Product",
-		Description: "Updated Description",
-		Price:       199.99,
-		Stock:       20,
-		Images:      []string{"new-image.jpg"},
-		Category:    "Electronics",
+	req := &pb.SetRelatedProductsRequest{
+		ProductId:         "p1",
+		RelationType:      RelationUpsell,
+		RelatedProductIds: []string{"p2", "p3", "p2"},
 	}
 
-	resp, err := service.UpdateProduct(ctx, req)
+	resp, err := service.SetRelatedProducts(ctx, req)
 
 	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if resp == nil {
-		t.Fatal("Expected response, got nil")
+	if len(stored) != 2 || stored[0] != "p2" || stored[1] != "p3" {
+		t.Errorf("Expected deduplicated [p2 p3], got %v", stored)
 	}
 
-	if resp.Product.Name != req.Name {
-		t.Errorf("Expected name %s, got %s", req.Name, resp.Product.Name)
+	if len(resp.RelatedProducts) != 2 {
+		t.Errorf("Expected 2 related products, got %d", len(resp.RelatedProducts))
 	}
 }
 
-func TestUpdateProduct_MissingID(t *testing.T) {
+func TestSetRelatedProducts_InvalidRelationType(t *testing.T) {
 	mockRepo := &MockRepository{}
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.UpdateProductRequest{
-		Id:    "",
-		Name:  "Updated Product",
-		Price: 199.99,
-		Stock: 20,
+	_, err := service.SetRelatedProducts(ctx, &pb.SetRelatedProductsRequest{ProductId: "p1", RelationType: "SIMILAR"})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
 	}
+}
 
-	_, err := service.UpdateProduct(ctx, req)
+func TestSetRelatedProducts_SelfReference(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	ctx := context.Background()
 
-	if err == nil {
-		t.Error("Expected error, got nil")
+	req := &pb.SetRelatedProductsRequest{
+		ProductId:         "p1",
+		RelationType:      RelationRelated,
+		RelatedProductIds: []string{"p1"},
 	}
 
+	_, err := service.SetRelatedProducts(ctx, req)
+
 	st, ok := status.FromError(err)
 	if !ok || st.Code() != codes.InvalidArgument {
 		t.Errorf("Expected InvalidArgument error, got %v", err)
 	}
 }
 
-func TestUpdateProduct_NotFound(t *testing.T) {
+func TestSetRelatedProducts_RelatedNotFound(t *testing.T) {
 	mockRepo := &MockRepository{
 		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
-			return nil, errors.New("not found")
+			if id == "missing" {
+				return nil, ErrProductNotFound
+			}
+			return &Product{ID: id}, nil
 		},
 	}
-
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.UpdateProductRequest{
-		Id:    "non-existent",
-		Name:  "Updated Product",
-		Price: 199.99,
-		Stock: 20,
+	req := &pb.SetRelatedProductsRequest{
+		ProductId:         "p1",
+		RelationType:      RelationRelated,
+		RelatedProductIds: []string{"missing"},
 	}
 
-	_, err := service.UpdateProduct(ctx, req)
-
-	if err == nil {
-		t.Error("Expected error, got nil")
-	}
+	_, err := service.SetRelatedProducts(ctx, req)
 
 	st, ok := status.FromError(err)
 	if !ok || st.Code() != codes.NotFound {
@@ -516,137 +1486,425 @@ func TestUpdateProduct_NotFound(t *testing.T) {
 	}
 }
 
-func TestDeleteProduct_Success(t *testing.T) {
+func TestGetRelatedProducts_MissingProductID(t *testing.T) {
+	mockRepo := &MockRepository{}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.GetRelatedProducts(ctx, &pb.GetRelatedProductsRequest{})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestCreateProduct_DescriptionBlocks(t *testing.T) {
+	var saved *Product
 	mockRepo := &MockRepository{
-		DeleteFunc: func(ctx context.Context, id string) error {
-			return nil
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			saved = product
+			product.ID = "test-id"
+			return product, nil
 		},
 	}
 
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.DeleteProductRequest{Id: "test-id"}
-	resp, err := service.DeleteProduct(ctx, req)
+	req := &pb.CreateProductRequest{
+		Name:  "Desk Lamp",
+		Price: 39.99,
+		Sku:   "LAMP-001",
+		DescriptionBlocks: []*pb.ContentBlock{
+			{Type: "heading", Level: 2, Text: "Bright <i>and</i> warm"},
+			{Type: "list", Items: []string{"**Dimmable**", "USB-C"}},
+		},
+	}
+
+	resp, err := service.CreateProduct(ctx, req)
 
 	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
+		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if resp == nil {
-		t.Fatal("Expected response, got nil")
+	if saved.DescriptionBlocks[0].Text != "Bright and warm" {
+		t.Errorf("Expected sanitized heading, got %q", saved.DescriptionBlocks[0].Text)
 	}
 
-	if !resp.Success {
-		t.Error("Expected success to be true")
+	if saved.Description != "Bright and warm\n\n- Dimmable\n- USB-C" {
+		t.Errorf("Expected plain-text description derived from blocks, got %q", saved.Description)
+	}
+
+	if len(resp.Product.DescriptionBlocks) != 2 {
+		t.Errorf("Expected 2 description blocks, got %d", len(resp.Product.DescriptionBlocks))
 	}
 }
 
-func TestDeleteProduct_MissingID(t *testing.T) {
+func TestCreateProduct_InvalidDescriptionBlocks(t *testing.T) {
 	mockRepo := &MockRepository{}
 	service := setupService(mockRepo)
 	ctx := context.Background()
 
-	req := &pb.DeleteProductRequest{Id: ""}
-	_, err := service.DeleteProduct(ctx, req)
-
-	if err == nil {
-		t.Error("Expected error, got nil")
+	req := &pb.CreateProductRequest{
+		Name:              "Desk Lamp",
+		Price:             39.99,
+		Sku:               "LAMP-001",
+		DescriptionBlocks: []*pb.ContentBlock{{Type: "image", Url: "javascript:alert(1)"}},
 	}
 
+	_, err := service.CreateProduct(ctx, req)
+
 	st, ok := status.FromError(err)
 	if !ok || st.Code() != codes.InvalidArgument {
 		t.Errorf("Expected InvalidArgument error, got %v", err)
 	}
 }
 
-func TestDeleteProduct_NotFound(t *testing.T) {
+func TestCreateProduct_SalePrice(t *testing.T) {
+	var created *Product
 	mockRepo := &MockRepository{
-		DeleteFunc: func(ctx context.Context, id string) error {
-			return errors.New("not found")
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			created = product
+			return product, nil
 		},
 	}
 
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
 	service := setupService(mockRepo)
-	ctx := context.Background()
+	service.now = func() time.Time { return now }
+
+	resp, err := service.CreateProduct(context.Background(), &pb.CreateProductRequest{
+		Name:         "Test Product",
+		Price:        100,
+		Sku:          "TEST-001",
+		SalePrice:    80,
+		SaleStartsAt: timestamppb.New(now.Add(-time.Hour)),
+		SaleEndsAt:   timestamppb.New(now.Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
-	req := &pb.DeleteProductRequest{Id: "non-existent"}
-	_, err := service.DeleteProduct(ctx, req)
+	if created.SalePrice == nil || *created.SalePrice != 80 || created.SaleStartsAt == nil || created.SaleEndsAt == nil {
+		t.Errorf("Expected sale to be saved, got %+v", created)
+	}
+	if !resp.Product.OnSale || resp.Product.EffectivePrice != 80 || resp.Product.SalePrice != 80 || resp.Product.Price != 100 {
+		t.Errorf("Expected active sale in response, got %v", resp.Product)
+	}
+}
 
-	if err == nil {
-		t.Error("Expected error, got nil")
+func TestCreateProduct_InvalidSalePrice(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  *pb.CreateProductRequest
+	}{
+		{"negative", &pb.CreateProductRequest{SalePrice: -1}},
+		{"equal to price", &pb.CreateProductRequest{SalePrice: 100}},
+		{"above price", &pb.CreateProductRequest{SalePrice: 120}},
+		{"window without price", &pb.CreateProductRequest{SaleEndsAt: timestamppb.New(start)}},
+		{"ends before start", &pb.CreateProductRequest{SalePrice: 80, SaleStartsAt: timestamppb.New(start), SaleEndsAt: timestamppb.New(start)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Name, tt.req.Sku, tt.req.Price = "Test Product", "TEST-001", 100
+			_, err := service.CreateProduct(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
 	}
+}
 
-	st, ok := status.FromError(err)
-	if !ok || st.Code() != codes.NotFound {
-		t.Errorf("Expected NotFound error, got %v", err)
+func TestUpdateProduct_SaleBelowNewPrice(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, Name: "Test Product", Price: 100}, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: "p1", Name: "Test Product", Price: 50, SalePrice: 60, Version: 1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
 	}
 }
 
-func TestSearchProducts_Success(t *testing.T) {
+func TestCreateProduct_Shipping(t *testing.T) {
+	var created *Product
 	mockRepo := &MockRepository{
-		SearchFunc: func(ctx context.Context, query string, page, pageSize int32) ([]*Product, int32, error) {
-			return []*Product{
-				{
-					ID:        "id1",
-					Name:      "Test Product",
-					Price:     99.99,
-					SKU:       "SKU-001",
-					Stock:     10,
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
-				},
-			}, 1, nil
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			created = product
+			return product, nil
 		},
 	}
+	service := setupService(mockRepo)
+
+	resp, err := service.CreateProduct(context.Background(), &pb.CreateProductRequest{
+		Name:          "Desk Lamp",
+		Price:         40,
+		Sku:           "LAMP-002",
+		WeightKg:      1.25,
+		LengthCm:      30,
+		WidthCm:       20,
+		HeightCm:      45,
+		ShippingClass: ShippingClassFragile,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created.WeightKg != 1.25 || created.HeightCm != 45 || created.ShippingClass != ShippingClassFragile {
+		t.Errorf("Expected shipping fields to be saved, got %+v", created)
+	}
+	if resp.Product.WeightKg != 1.25 || resp.Product.LengthCm != 30 || resp.Product.ShippingClass != ShippingClassFragile {
+		t.Errorf("Expected shipping fields in response, got %v", resp.Product)
+	}
 
+	if _, err := service.CreateProduct(context.Background(), &pb.CreateProductRequest{Name: "Mug", Price: 8, Sku: "MUG-002"}); err != nil || created.ShippingClass != ShippingClassStandard {
+		t.Errorf("Expected STANDARD shipping class by default, got %+v, %v", created, err)
+	}
+}
+
+func TestCreateProduct_InvalidShipping(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
 	service := setupService(mockRepo)
-	ctx := context.Background()
 
-	req := &pb.SearchProductsRequest{
-		Query:    "test",
-		Page:     1,
-		PageSize: 10,
+	tests := []struct {
+		name string
+		req  *pb.CreateProductRequest
+	}{
+		{"negative weight", &pb.CreateProductRequest{WeightKg: -1}},
+		{"partial dimensions", &pb.CreateProductRequest{LengthCm: 10, WidthCm: 10}},
+		{"unknown class", &pb.CreateProductRequest{ShippingClass: "EXPRESS"}},
+		{"digital with weight", &pb.CreateProductRequest{ProductType: ProductTypeDigital, WeightKg: 0.1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Name, tt.req.Sku, tt.req.Price = "Test Product", "TEST-001", 100
+			_, err := service.CreateProduct(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
 	}
+}
 
-	resp, err := service.SearchProducts(ctx, req)
+func TestCreateProduct_OrderQuantityRules(t *testing.T) {
+	var created *Product
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			created = product
+			return product, nil
+		},
+	}
+	service := setupService(mockRepo)
 
+	resp, err := service.CreateProduct(context.Background(), &pb.CreateProductRequest{
+		Name:         "Screws",
+		Price:        4,
+		Sku:          "SCREW-100",
+		MinOrderQty:  10,
+		MaxOrderQty:  500,
+		QtyIncrement: 10,
+	})
 	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.MinOrderQty != 10 || created.MaxOrderQty != 500 || created.QtyIncrement != 10 {
+		t.Errorf("Expected order quantity rules to be saved, got %+v", created)
+	}
+	if resp.Product.MinOrderQty != 10 || resp.Product.MaxOrderQty != 500 || resp.Product.QtyIncrement != 10 {
+		t.Errorf("Expected order quantity rules in response, got %v", resp.Product)
 	}
 
-	if resp == nil {
-		t.Fatal("Expected response, got nil")
+	_, err = service.CreateProduct(context.Background(), &pb.CreateProductRequest{
+		Name: "Screws", Price: 4, Sku: "SCREW-101", MinOrderQty: 10, MaxOrderQty: 5,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for max below min, got %v", err)
 	}
+}
 
-	if len(resp.Products) != 1 {
-		t.Errorf("Expected 1 product, got %d", len(resp.Products))
+func TestCreateProduct_DuplicateBarcode(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		GetByBarcodeFunc: func(ctx context.Context, barcodes []string) (*Product, error) {
+			for _, code := range barcodes {
+				if code == "0036000291452" {
+					return &Product{ID: "existing-id"}, nil
+				}
+			}
+			return nil, ErrProductNotFound
+		},
 	}
+	service := setupService(mockRepo)
 
-	if resp.Total != 1 {
-		t.Errorf("Expected total 1, got %d", resp.Total)
+	// The UPC is stored as EAN-13 on the other product
+	_, err := service.CreateProduct(context.Background(), &pb.CreateProductRequest{
+		Name:  "Soda",
+		Price: 2,
+		Sku:   "SODA-001",
+		Upc:   "036000291452",
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists, got %v", err)
+	}
+
+	_, err = service.CreateProduct(context.Background(), &pb.CreateProductRequest{
+		Name:  "Soda",
+		Price: 2,
+		Sku:   "SODA-001",
+		Upc:   "036000291453",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for bad check digit, got %v", err)
 	}
 }
 
-func TestSearchProducts_MissingQuery(t *testing.T) {
-	mockRepo := &MockRepository{}
+func TestGetProductByBarcode(t *testing.T) {
+	var lookedUp []string
+	mockRepo := &MockRepository{
+		GetByBarcodeFunc: func(ctx context.Context, barcodes []string) (*Product, error) {
+			lookedUp = barcodes
+			if barcodes[0] == "9780306406157" {
+				return &Product{ID: "book-id", Name: "Book", ISBN: "9780306406157"}, nil
+			}
+			return nil, ErrProductNotFound
+		},
+	}
 	service := setupService(mockRepo)
-	ctx := context.Background()
 
-	req := &pb.SearchProductsRequest{
-		Query:    "",
-		Page:     1,
-		PageSize: 10,
+	resp, err := service.GetProductByBarcode(context.Background(), &pb.GetProductByBarcodeRequest{Barcode: "978-0-306-40615-7"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Product.Id != "book-id" || resp.Product.Isbn != "9780306406157" {
+		t.Errorf("Unexpected product %v", resp.Product)
 	}
 
-	_, err := service.SearchProducts(ctx, req)
+	_, err = service.GetProductByBarcode(context.Background(), &pb.GetProductByBarcodeRequest{Barcode: "036000291452"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if len(lookedUp) != 2 || lookedUp[1] != "0036000291452" {
+		t.Errorf("Expected UPC lookup to include the EAN-13 form, got %v", lookedUp)
+	}
 
-	if err == nil {
-		t.Error("Expected error, got nil")
+	for _, barcode := range []string{"", "ABC123"} {
+		_, err := service.GetProductByBarcode(context.Background(), &pb.GetProductByBarcodeRequest{Barcode: barcode})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %q, got %v", barcode, err)
+		}
 	}
+}
 
-	st, ok := status.FromError(err)
-	if !ok || st.Code() != codes.InvalidArgument {
-		t.Errorf("Expected InvalidArgument error, got %v", err)
+func TestProduct_EffectivePrice(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+	sale := 80.0
+
+	tests := []struct {
+		name     string
+		product  *Product
+		expected float64
+	}{
+		{"no sale", &Product{Price: 100}, 100},
+		{"open window", &Product{Price: 100, SalePrice: &sale}, 80},
+		{"active window", &Product{Price: 100, SalePrice: &sale, SaleStartsAt: &before, SaleEndsAt: &after}, 80},
+		{"not started", &Product{Price: 100, SalePrice: &sale, SaleStartsAt: &after}, 100},
+		{"ended", &Product{Price: 100, SalePrice: &sale, SaleEndsAt: &before}, 100},
+		{"ends now", &Product{Price: 100, SalePrice: &sale, SaleEndsAt: &now}, 100},
+	}
+
+	for _, tt := range tests {
+		if got := tt.product.EffectivePrice(now); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+		if onSale := tt.product.OnSale(now); onSale != (tt.expected == sale) {
+			t.Errorf("%s: unexpected on sale %v", tt.name, onSale)
+		}
+	}
+}
+
+func TestMethodRoles(t *testing.T) {
+	roles := MethodRoles()
+	restricted := map[string]bool{}
+	for _, m := range append(append(AdminMethods, ServiceMethods...), VendorMethods...) {
+		restricted[m] = true
+	}
+	if len(roles) != len(restricted)+len(UserMethods) {
+		t.Errorf("Expected roles for the %d admin, service and user methods, got %d", len(restricted)+len(UserMethods), len(roles))
+	}
+	for m := range restricted {
+		if len(roles[m]) == 0 || roles[m][0] != auth.RoleAdmin {
+			t.Errorf("Expected %s to be open to admins, got %v", m, roles[m])
+		}
+	}
+	for _, m := range append(ServiceMethods, VendorMethods...) {
+		if len(roles[m]) != 2 || roles[m][1] != auth.RoleService {
+			t.Errorf("Expected %s to be open to services, got %v", m, roles[m])
+		}
+	}
+	if r := roles[pb.CatalogService_ReviewImage_FullMethodName]; len(r) != 1 {
+		t.Errorf("Expected ReviewImage to be open to admins only, got %v", r)
+	}
+	// Stock reservations are not admin RPCs, but are not public either
+	if r := roles[pb.CatalogService_ReserveStock_FullMethodName]; len(r) != 2 || r[1] != auth.RoleService {
+		t.Errorf("Expected ReserveStock to be open to services, got %v", r)
+	}
+	for _, m := range UserMethods {
+		if r := roles[m]; len(r) != 3 || r[0] != auth.RoleUser {
+			t.Errorf("Expected %s to be open to customers, got %v", m, r)
+		}
+	}
+	if _, ok := roles[pb.CatalogService_GetProduct_FullMethodName]; ok {
+		t.Error("Expected GetProduct to stay public")
+	}
+}
+
+func TestMethodScopes(t *testing.T) {
+	scopes := MethodScopes()
+	if len(scopes) != len(ServiceMethods) {
+		t.Errorf("Expected scopes for the %d service methods, got %d", len(ServiceMethods), len(scopes))
+	}
+	for _, m := range ServiceMethods {
+		if len(scopes[m]) == 0 {
+			t.Errorf("Expected a scope for %s", m)
+		}
+	}
+	if s := scopes[pb.CatalogService_CommitReservation_FullMethodName]; len(s) != 1 || s[0] != ScopeInventory {
+		t.Errorf("Expected CommitReservation to need %s, got %v", ScopeInventory, s)
+	}
+	if s := scopes[pb.CatalogService_StreamProducts_FullMethodName]; len(s) != 1 || s[0] != ScopeRead {
+		t.Errorf("Expected StreamProducts to need %s, got %v", ScopeRead, s)
 	}
 }
@@ -0,0 +1,166 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateSKU is returned when a SKU is the current or a former SKU of another product
+var ErrDuplicateSKU = errors.New("sku is already used by another product")
+
+// skuConstraint is the unique constraint on products.sku
+const skuConstraint = "products_sku_key"
+
+// SKUAlias is a former SKU of a product
+type SKUAlias struct {
+	SKU       string
+	ProductID string
+	ChangedBy string
+	ChangedAt time.Time
+}
+
+// ChangeSKU replaces the SKU of a product, keeps the old one as an alias and
+// records an audit entry. Changing back to a former SKU of the same product
+// reclaims it.
+func (r *postgresRepository) ChangeSKU(ctx context.Context, productID, newSKU, actor string) (*Product, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldSKU string
+	err = tx.QueryRowContext(ctx, "SELECT sku FROM products WHERE id = $1 FOR UPDATE", productID).Scan(&oldSKU)
+	if err == sql.ErrNoRows {
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to lock product", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	var aliasOwner string
+	err = tx.QueryRowContext(ctx, "SELECT product_id FROM product_sku_aliases WHERE sku = $1", newSKU).Scan(&aliasOwner)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		r.log.Error(ctx, "Failed to check SKU aliases", map[string]interface{}{"error": err.Error(), "sku": newSKU})
+		return nil, fmt.Errorf("failed to check sku aliases: %w", err)
+	case aliasOwner != productID:
+		return nil, ErrDuplicateSKU
+	default:
+		if _, err := tx.ExecContext(ctx, "DELETE FROM product_sku_aliases WHERE sku = $1", newSKU); err != nil {
+			r.log.Error(ctx, "Failed to reclaim SKU alias", map[string]interface{}{"error": err.Error(), "sku": newSKU})
+			return nil, fmt.Errorf("failed to reclaim sku alias: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE products SET sku = $2, version = version + 1 WHERE id = $1", productID, newSKU); err != nil {
+		if pgerr.IsUniqueViolation(err, skuConstraint) {
+			return nil, ErrDuplicateSKU
+		}
+		r.log.Error(ctx, "Failed to change SKU", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to change sku: %w", err)
+	}
+
+	insertAlias := "INSERT INTO product_sku_aliases (sku, product_id, changed_by) VALUES ($1, $2, $3)"
+	if _, err := tx.ExecContext(ctx, insertAlias, oldSKU, productID, actor); err != nil {
+		r.log.Error(ctx, "Failed to record SKU alias", map[string]interface{}{"error": err.Error(), "product_id": productID, "sku": oldSKU})
+		return nil, fmt.Errorf("failed to record sku alias: %w", err)
+	}
+
+	changes, now := []FieldChange{{Field: "sku", Old: oldSKU, New: newSKU}}, time.Now()
+	if err := recordProductAudit(ctx, tx, productID, AuditActionUpdate, actor, changes, now); err != nil {
+		r.log.Error(ctx, "Failed to record SKU change audit", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, err
+	}
+	if err := recordProductEvent(ctx, tx, productID, AuditActionUpdate, actor, changes, now); err != nil {
+		r.log.Error(ctx, "Failed to record SKU change event", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit SKU change", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to commit sku change: %w", err)
+	}
+
+	r.log.Info(ctx, "SKU changed", map[string]interface{}{"product_id": productID, "old_sku": oldSKU, "new_sku": newSKU, "actor": actor})
+	return r.GetByID(ctx, productID)
+}
+
+// ListSKUAliases retrieves the former SKUs of a product, most recent first
+func (r *postgresRepository) ListSKUAliases(ctx context.Context, productID string) ([]*SKUAlias, error) {
+	query := `
+		SELECT sku, product_id, changed_by, changed_at
+		FROM product_sku_aliases
+		WHERE product_id = $1
+		ORDER BY changed_at DESC, sku
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list SKU aliases", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to list sku aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []*SKUAlias{}
+	for rows.Next() {
+		a := &SKUAlias{}
+		if err := rows.Scan(&a.SKU, &a.ProductID, &a.ChangedBy, &a.ChangedAt); err != nil {
+			r.log.Error(ctx, "Failed to scan SKU alias", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan sku alias: %w", err)
+		}
+		aliases = append(aliases, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating SKU aliases", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating sku aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// GetBySKUs retrieves the products with the given current or former SKUs,
+// keyed by the requested SKU. SKUs that match no product are left out.
+func (r *postgresRepository) GetBySKUs(ctx context.Context, skus []string) (map[string]*Product, error) {
+	query := `
+		SELECT DISTINCT ON (s.sku) s.sku, ` + productColumnsWithAlias("p") + `
+		FROM unnest($1::text[]) AS s(sku)
+		JOIN products p ON p.sku = s.sku OR p.id = (SELECT product_id FROM product_sku_aliases a WHERE a.sku = s.sku)
+		ORDER BY s.sku, p.sku = s.sku DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(skus))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get products by SKU", map[string]interface{}{"error": err.Error(), "count": len(skus)})
+		return nil, fmt.Errorf("failed to get products by sku: %w", err)
+	}
+	defer rows.Close()
+
+	products := make(map[string]*Product, len(skus))
+	for rows.Next() {
+		var sku string
+		product, err := scanProduct(rows, &sku)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan product", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products[sku] = product
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating products", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating products: %w", err)
+	}
+
+	return products, nil
+}
@@ -0,0 +1,184 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxSKULength matches the products sku column
+const maxSKULength = 100
+
+// maxBatchSKUs caps the SKUs resolved by one GetProductsBySKUs call
+const maxBatchSKUs = 100
+
+// ChangeSKU replaces the SKU of a product. SKUs are otherwise immutable; the
+// old SKU is kept as an alias so existing references still resolve.
+func (s *Service) ChangeSKU(ctx context.Context, req *pb.ChangeSKURequest) (*pb.ChangeSKUResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Change SKU failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	newSKU := strings.TrimSpace(req.NewSku)
+	if newSKU == "" {
+		s.log.Warn(ctx, "Change SKU failed: new SKU is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "new_sku is required")
+	}
+	if len(newSKU) > maxSKULength {
+		return nil, status.Errorf(codes.InvalidArgument, "new_sku cannot exceed %d characters", maxSKULength)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+	if product.SKU == newSKU {
+		return nil, status.Error(codes.InvalidArgument, "new_sku is already the product's SKU")
+	}
+
+	updated, err := s.repo.ChangeSKU(ctx, req.ProductId, newSKU, actorFromContext(ctx))
+	switch {
+	case errors.Is(err, ErrDuplicateSKU):
+		s.log.Warn(ctx, "Change SKU failed: SKU already used", map[string]interface{}{"product_id": req.ProductId, "sku": newSKU})
+		return nil, status.Error(codes.AlreadyExists, "sku is already used by another product")
+	case errors.Is(err, ErrProductNotFound):
+		return nil, status.Error(codes.NotFound, "product not found")
+	case err != nil:
+		s.log.Error(ctx, "Failed to change SKU", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to change sku")
+	}
+
+	s.log.Info(ctx, "Product SKU changed", map[string]interface{}{"product_id": req.ProductId, "old_sku": product.SKU, "new_sku": newSKU})
+
+	return &pb.ChangeSKUResponse{
+		Product:     toProtoProduct(updated, s.now()),
+		PreviousSku: product.SKU,
+	}, nil
+}
+
+// GetProductBySKU retrieves a product by its current SKU or a former one
+func (s *Service) GetProductBySKU(ctx context.Context, req *pb.GetProductBySKURequest) (*pb.GetProductBySKUResponse, error) {
+	sku := strings.TrimSpace(req.Sku)
+	if sku == "" {
+		s.log.Warn(ctx, "Get product by SKU failed: SKU is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if errors.Is(err, ErrProductNotFound) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get product by SKU", map[string]interface{}{"error": err.Error(), "sku": sku})
+		return nil, status.Error(codes.Internal, "failed to get product")
+	}
+	formerSKU := product.SKU != sku
+
+	if err := s.localize(ctx, req.Locale, product); err != nil {
+		s.log.Error(ctx, "Failed to localize product", map[string]interface{}{"error": err.Error(), "product_id": product.ID})
+		return nil, status.Error(codes.Internal, "failed to get product")
+	}
+
+	return &pb.GetProductBySKUResponse{
+		Product:   toProtoProduct(product, s.now()),
+		FormerSku: formerSKU,
+	}, nil
+}
+
+// GetProductsBySKUs resolves a batch of current or former SKUs to products.
+// SKUs are trimmed and duplicates resolved once; unknown SKUs are reported in
+// not_found rather than failing the call.
+func (s *Service) GetProductsBySKUs(ctx context.Context, req *pb.GetProductsBySKUsRequest) (*pb.GetProductsBySKUsResponse, error) {
+	if len(req.Skus) == 0 {
+		s.log.Warn(ctx, "Get products by SKUs failed: SKUs are required", nil)
+		return nil, status.Error(codes.InvalidArgument, "skus are required")
+	}
+
+	skus := make([]string, 0, len(req.Skus))
+	seen := make(map[string]bool, len(req.Skus))
+	for _, raw := range req.Skus {
+		sku := strings.TrimSpace(raw)
+		if sku == "" {
+			return nil, status.Error(codes.InvalidArgument, "skus cannot be empty")
+		}
+		if len(sku) > maxSKULength {
+			return nil, status.Errorf(codes.InvalidArgument, "skus cannot exceed %d characters", maxSKULength)
+		}
+		if !seen[sku] {
+			seen[sku] = true
+			skus = append(skus, sku)
+		}
+	}
+	if len(skus) > maxBatchSKUs {
+		s.log.Warn(ctx, "Get products by SKUs failed: too many SKUs", map[string]interface{}{"count": len(skus)})
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d skus can be requested at once", maxBatchSKUs)
+	}
+
+	products, err := s.repo.GetBySKUs(ctx, skus)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get products by SKUs", map[string]interface{}{"error": err.Error(), "count": len(skus)})
+		return nil, status.Error(codes.Internal, "failed to get products")
+	}
+
+	found := make([]*Product, 0, len(products))
+	for _, sku := range skus {
+		if p, ok := products[sku]; ok {
+			found = append(found, p)
+		}
+	}
+	if err := s.localize(ctx, req.Locale, found...); err != nil {
+		s.log.Error(ctx, "Failed to localize products", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to get products")
+	}
+
+	now := s.now()
+	resp := &pb.GetProductsBySKUsResponse{
+		Matches:  make([]*pb.SKUMatch, 0, len(found)),
+		NotFound: []string{},
+	}
+	for _, sku := range skus {
+		p, ok := products[sku]
+		if !ok {
+			resp.NotFound = append(resp.NotFound, sku)
+			continue
+		}
+		resp.Matches = append(resp.Matches, &pb.SKUMatch{
+			Sku:       sku,
+			Product:   toProtoProduct(p, now),
+			FormerSku: p.SKU != sku,
+		})
+	}
+	return resp, nil
+}
+
+// ListSKUAliases lists the former SKUs of a product
+func (s *Service) ListSKUAliases(ctx context.Context, req *pb.ListSKUAliasesRequest) (*pb.ListSKUAliasesResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "List SKU aliases failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	aliases, err := s.repo.ListSKUAliases(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list SKU aliases", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to list sku aliases")
+	}
+
+	resp := &pb.ListSKUAliasesResponse{Aliases: make([]*pb.SKUAlias, len(aliases))}
+	for i, a := range aliases {
+		resp.Aliases[i] = &pb.SKUAlias{
+			Sku:       a.SKU,
+			ChangedBy: a.ChangedBy,
+			ChangedAt: timestamppb.New(a.ChangedAt),
+		}
+	}
+	return resp, nil
+}
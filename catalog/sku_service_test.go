@@ -0,0 +1,187 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestChangeSKU_Success(t *testing.T) {
+	var actor string
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, SKU: "LAMP-001"}, nil
+		},
+		ChangeSKUFunc: func(ctx context.Context, productID, newSKU, a string) (*Product, error) {
+			actor = a
+			return &Product{ID: productID, SKU: newSKU}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "admin-1", Role: auth.RoleAdmin})
+
+	resp, err := service.ChangeSKU(ctx, &pb.ChangeSKURequest{ProductId: "prod-1", NewSku: " LAMP-002 "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Product.Sku != "LAMP-002" || resp.PreviousSku != "LAMP-001" {
+		t.Errorf("Unexpected response %v", resp)
+	}
+	if actor != "admin-1" {
+		t.Errorf("Expected actor admin-1, got %s", actor)
+	}
+}
+
+func TestChangeSKU_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if id == "missing" {
+				return nil, ErrProductNotFound
+			}
+			return &Product{ID: id, SKU: "LAMP-001"}, nil
+		},
+		ChangeSKUFunc: func(ctx context.Context, productID, newSKU, actor string) (*Product, error) {
+			return nil, ErrDuplicateSKU
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		name     string
+		req      *pb.ChangeSKURequest
+		expected codes.Code
+	}{
+		{"missing product ID", &pb.ChangeSKURequest{NewSku: "LAMP-002"}, codes.InvalidArgument},
+		{"missing SKU", &pb.ChangeSKURequest{ProductId: "prod-1", NewSku: "  "}, codes.InvalidArgument},
+		{"same SKU", &pb.ChangeSKURequest{ProductId: "prod-1", NewSku: "LAMP-001"}, codes.InvalidArgument},
+		{"product not found", &pb.ChangeSKURequest{ProductId: "missing", NewSku: "LAMP-002"}, codes.NotFound},
+		{"SKU taken", &pb.ChangeSKURequest{ProductId: "prod-1", NewSku: "MUG-001"}, codes.AlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.ChangeSKU(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetProductBySKU_FormerSKU(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			if sku == "LAMP-001" || sku == "LAMP-002" {
+				return &Product{ID: "prod-1", SKU: "LAMP-002"}, nil
+			}
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.GetProductBySKU(context.Background(), &pb.GetProductBySKURequest{Sku: "LAMP-001"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.FormerSku || resp.Product.Sku != "LAMP-002" {
+		t.Errorf("Expected former SKU to resolve to LAMP-002, got %v", resp)
+	}
+
+	if resp, err := service.GetProductBySKU(context.Background(), &pb.GetProductBySKURequest{Sku: "LAMP-002"}); err != nil || resp.FormerSku {
+		t.Errorf("Expected current SKU match, got %v, %v", resp, err)
+	}
+
+	if _, err := service.GetProductBySKU(context.Background(), &pb.GetProductBySKURequest{Sku: "MUG-001"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListSKUAliases(t *testing.T) {
+	mockRepo := &MockRepository{
+		ListSKUAliasesFunc: func(ctx context.Context, productID string) ([]*SKUAlias, error) {
+			return []*SKUAlias{{SKU: "LAMP-001", ProductID: productID, ChangedBy: "admin-1", ChangedAt: time.Now()}}, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.ListSKUAliases(context.Background(), &pb.ListSKUAliasesRequest{ProductId: "prod-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Aliases) != 1 || resp.Aliases[0].Sku != "LAMP-001" || resp.Aliases[0].ChangedBy != "admin-1" {
+		t.Errorf("Unexpected aliases %v", resp.Aliases)
+	}
+}
+
+func TestGetProductsBySKUs(t *testing.T) {
+	var requested []string
+	mockRepo := &MockRepository{
+		GetBySKUsFunc: func(ctx context.Context, skus []string) (map[string]*Product, error) {
+			requested = skus
+			return map[string]*Product{
+				"LAMP-001": {ID: "prod-1", SKU: "LAMP-002"},
+				"MUG-001":  {ID: "prod-2", SKU: "MUG-001"},
+			}, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.GetProductsBySKUs(context.Background(), &pb.GetProductsBySKUsRequest{
+		Skus: []string{"MUG-001", " LAMP-001 ", "BOWL-001", "MUG-001"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(requested) != 3 {
+		t.Errorf("Expected 3 distinct SKUs to be looked up, got %v", requested)
+	}
+	if len(resp.Matches) != 2 {
+		t.Fatalf("Expected 2 matches, got %v", resp.Matches)
+	}
+	if m := resp.Matches[0]; m.Sku != "MUG-001" || m.Product.Id != "prod-2" || m.FormerSku {
+		t.Errorf("Unexpected first match %v", m)
+	}
+	if m := resp.Matches[1]; m.Sku != "LAMP-001" || m.Product.Sku != "LAMP-002" || !m.FormerSku {
+		t.Errorf("Expected LAMP-001 to resolve as a former SKU, got %v", m)
+	}
+	if len(resp.NotFound) != 1 || resp.NotFound[0] != "BOWL-001" {
+		t.Errorf("Expected BOWL-001 not found, got %v", resp.NotFound)
+	}
+}
+
+func TestGetProductsBySKUs_Errors(t *testing.T) {
+	tooMany := make([]string, maxBatchSKUs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("SKU-%03d", i)
+	}
+	mockRepo := &MockRepository{
+		GetBySKUsFunc: func(ctx context.Context, skus []string) (map[string]*Product, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		name     string
+		skus     []string
+		expected codes.Code
+	}{
+		{"no SKUs", nil, codes.InvalidArgument},
+		{"empty SKU", []string{"LAMP-001", " "}, codes.InvalidArgument},
+		{"too many SKUs", tooMany, codes.InvalidArgument},
+		{"repository error", []string{"LAMP-001"}, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetProductsBySKUs(context.Background(), &pb.GetProductsBySKUsRequest{Skus: tt.skus})
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
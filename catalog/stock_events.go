@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+)
+
+// StockEventLowStock is emitted when a product's stock falls to or below its
+// low-stock threshold
+const StockEventLowStock = "low_stock"
+
+// StockEvent describes a change in a product's inventory
+type StockEvent struct {
+	Type      string
+	ProductID string
+	Stock     int32
+	Threshold int32
+	At        time.Time
+}
+
+// StockEventSink receives stock events
+type StockEventSink interface {
+	Emit(ctx context.Context, event StockEvent)
+}
+
+// logStockEventSink writes stock events to the structured log and metrics
+type logStockEventSink struct {
+	log *logger.Logger
+}
+
+// NewLogStockEventSink creates a sink that logs stock events and counts them in metrics
+func NewLogStockEventSink(log *logger.Logger) StockEventSink {
+	return &logStockEventSink{log: log}
+}
+
+// Emit logs the event as a warning
+func (s *logStockEventSink) Emit(ctx context.Context, event StockEvent) {
+	metrics.StockEventsTotal.WithLabelValues("catalog-service", event.Type).Inc()
+	s.log.Warn(ctx, "Stock event", map[string]interface{}{
+		"type":       event.Type,
+		"product_id": event.ProductID,
+		"stock":      event.Stock,
+		"threshold":  event.Threshold,
+		"at":         event.At.UTC().Format(time.RFC3339),
+	})
+}
+
+// WithStockEventSink replaces the sink stock events are emitted to
+func (s *Service) WithStockEventSink(sink StockEventSink) *Service {
+	s.stockEvents = sink
+	return s
+}
+
+// emitLowStock emits a low-stock event when stock moved from above the
+// threshold to at or below it. Products already low on stock do not emit
+// again until they are restocked above the threshold.
+func (s *Service) emitLowStock(ctx context.Context, productID string, wasLow bool, level *StockLevel) {
+	if wasLow || !level.LowStock() {
+		return
+	}
+	s.stockEvents.Emit(ctx, StockEvent{
+		Type:      StockEventLowStock,
+		ProductID: productID,
+		Stock:     level.Stock,
+		Threshold: level.LowStockThreshold,
+		At:        s.now(),
+	})
+}
@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrProductNotFound is returned when a stock adjustment targets an unknown product
+	ErrProductNotFound = errors.New("product not found")
+	// ErrInsufficientStock is returned when an adjustment would take stock below zero
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrStockNotTracked is returned when adjusting or reserving stock of a digital product
+	ErrStockNotTracked = errors.New("product does not track stock")
+)
+
+// queryRower is implemented by *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// stockGuardError explains why a guarded stock update matched no row: the
+// product is missing, digital or short of stock
+func (r *postgresRepository) stockGuardError(ctx context.Context, q queryRower, productID, action string) error {
+	var productType string
+	err := q.QueryRowContext(ctx, "SELECT product_type FROM products WHERE id = $1", productID).Scan(&productType)
+	switch {
+	case err == sql.ErrNoRows:
+		return ErrProductNotFound
+	case err != nil:
+		r.log.Error(ctx, "Failed to check product", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to %s: %w", action, err)
+	case productType == ProductTypeDigital:
+		return ErrStockNotTracked
+	default:
+		return ErrInsufficientStock
+	}
+}
+
+// StockLevel is a product's stock after an adjustment, with the threshold
+// needed to tell whether the adjustment crossed into low stock
+type StockLevel struct {
+	ProductID         string
+	Stock             int32
+	LowStockThreshold int32
+}
+
+// LowStock reports whether stock is at or below its low-stock threshold
+func (l *StockLevel) LowStock() bool {
+	return isLowStock(l.Stock, l.LowStockThreshold)
+}
+
+// isLowStock reports whether stock is at or below an enabled threshold
+func isLowStock(stock, threshold int32) bool {
+	return threshold > 0 && stock <= threshold
+}
+
+// AdjustStock adds delta to a product's stock in a single statement and
+// returns the new level. A negative delta that would take stock below zero
+// leaves it unchanged and returns ErrInsufficientStock. Digital products
+// return ErrStockNotTracked.
+func (r *postgresRepository) AdjustStock(ctx context.Context, productID string, delta int32) (*StockLevel, error) {
+	query := `
+		UPDATE products
+		SET stock = stock + $2, updated_at = $3
+		WHERE id = $1 AND stock + $2 >= 0 AND product_type = 'PHYSICAL'
+		RETURNING stock, low_stock_threshold
+	`
+
+	level := &StockLevel{ProductID: productID}
+	err := r.db.QueryRowContext(ctx, query, productID, delta, time.Now()).Scan(&level.Stock, &level.LowStockThreshold)
+	if err == sql.ErrNoRows {
+		return nil, r.stockGuardError(ctx, r.db, productID, "adjust stock")
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to adjust stock", map[string]interface{}{"error": err.Error(), "product_id": productID, "delta": delta})
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+
+	r.log.Info(ctx, "Stock adjusted", map[string]interface{}{"product_id": productID, "delta": delta, "stock": level.Stock})
+	return level, nil
+}
+
+// ListLowStock retrieves products at or below their low-stock threshold,
+// lowest stock first
+func (r *postgresRepository) ListLowStock(ctx context.Context, page, pageSize int32) ([]*Product, int32, error) {
+	const lowStock = "low_stock_threshold > 0 AND stock <= low_stock_threshold"
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM products WHERE "+lowStock).Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count low-stock products", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count low-stock products: %w", err)
+	}
+
+	query := `
+		SELECT ` + productColumns + `
+		FROM products
+		WHERE ` + lowStock + `
+		ORDER BY stock, name
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list low-stock products", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list low-stock products: %w", err)
+	}
+	defer rows.Close()
+
+	products := []*Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan product", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating low-stock products", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating low-stock products: %w", err)
+	}
+
+	return products, total, nil
+}
@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IncrementStock atomically adds to a product's stock
+func (s *Service) IncrementStock(ctx context.Context, req *pb.IncrementStockRequest) (*pb.IncrementStockResponse, error) {
+	if err := validateStockAdjustment(req.ProductId, req.Quantity); err != nil {
+		s.log.Warn(ctx, "Increment stock failed: invalid request", map[string]interface{}{"product_id": req.ProductId, "quantity": req.Quantity})
+		return nil, err
+	}
+
+	if err := s.authorizeVendorByID(ctx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	level, err := s.repo.AdjustStock(ctx, req.ProductId, req.Quantity)
+	if err != nil {
+		return nil, s.stockError(ctx, err, req.ProductId)
+	}
+
+	return &pb.IncrementStockResponse{
+		ProductId: req.ProductId,
+		Stock:     level.Stock,
+	}, nil
+}
+
+// DecrementStock atomically removes from a product's stock. Stock never goes
+// below zero; an insufficient level fails without changing it. Falling to the
+// low-stock threshold emits a low-stock event.
+func (s *Service) DecrementStock(ctx context.Context, req *pb.DecrementStockRequest) (*pb.DecrementStockResponse, error) {
+	if err := validateStockAdjustment(req.ProductId, req.Quantity); err != nil {
+		s.log.Warn(ctx, "Decrement stock failed: invalid request", map[string]interface{}{"product_id": req.ProductId, "quantity": req.Quantity})
+		return nil, err
+	}
+
+	if err := s.authorizeVendorByID(ctx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	level, err := s.repo.AdjustStock(ctx, req.ProductId, -req.Quantity)
+	if err != nil {
+		return nil, s.stockError(ctx, err, req.ProductId)
+	}
+	s.emitLowStock(ctx, req.ProductId, isLowStock(level.Stock+req.Quantity, level.LowStockThreshold), level)
+
+	return &pb.DecrementStockResponse{
+		ProductId: req.ProductId,
+		Stock:     level.Stock,
+	}, nil
+}
+
+// ListLowStockProducts lists products at or below their low-stock threshold
+func (s *Service) ListLowStockProducts(ctx context.Context, req *pb.ListLowStockProductsRequest) (*pb.ListLowStockProductsResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	products, total, err := s.repo.ListLowStock(ctx, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list low-stock products", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list low-stock products")
+	}
+
+	protoProducts := make([]*pb.Product, len(products))
+	for i, p := range products {
+		protoProducts[i] = toProtoProduct(p, s.now())
+	}
+
+	return &pb.ListLowStockProductsResponse{
+		Products: protoProducts,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// validateStockAdjustment checks the fields shared by stock adjustment requests
+func validateStockAdjustment(productID string, quantity int32) error {
+	if productID == "" {
+		return status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if quantity <= 0 {
+		return status.Error(codes.InvalidArgument, "quantity must be positive")
+	}
+	return nil
+}
+
+// stockError maps stock repository errors to gRPC status errors
+func (s *Service) stockError(ctx context.Context, err error, productID string) error {
+	switch {
+	case errors.Is(err, ErrProductNotFound):
+		return status.Error(codes.NotFound, "product not found")
+	case errors.Is(err, ErrInsufficientStock):
+		s.log.Warn(ctx, "Insufficient stock", map[string]interface{}{"product_id": productID})
+		return status.Error(codes.FailedPrecondition, "insufficient stock")
+	case errors.Is(err, ErrStockNotTracked):
+		return status.Error(codes.FailedPrecondition, "digital products do not track stock")
+	default:
+		s.log.Error(ctx, "Stock adjustment failed", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return status.Error(codes.Internal, "failed to adjust stock")
+	}
+}
@@ -0,0 +1,199 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stockRepo adjusts an in-memory stock level the way AdjustStock does
+func stockRepo(stock int32) *MockRepository {
+	return lowStockRepo(stock, 0)
+}
+
+// lowStockRepo is stockRepo for a product with a low-stock threshold
+func lowStockRepo(stock, threshold int32) *MockRepository {
+	return &MockRepository{
+		AdjustStockFunc: func(ctx context.Context, productID string, delta int32) (*StockLevel, error) {
+			if productID != "p1" {
+				return nil, ErrProductNotFound
+			}
+			if stock+delta < 0 {
+				return nil, ErrInsufficientStock
+			}
+			stock += delta
+			return &StockLevel{ProductID: productID, Stock: stock, LowStockThreshold: threshold}, nil
+		},
+	}
+}
+
+// recordingStockSink collects emitted stock events
+type recordingStockSink struct {
+	events []StockEvent
+}
+
+func (r *recordingStockSink) Emit(ctx context.Context, event StockEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestIncrementStock_Success(t *testing.T) {
+	service := setupService(stockRepo(5))
+
+	resp, err := service.IncrementStock(context.Background(), &pb.IncrementStockRequest{ProductId: "p1", Quantity: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Stock != 8 || resp.ProductId != "p1" {
+		t.Errorf("Unexpected response %v", resp)
+	}
+}
+
+func TestDecrementStock_Success(t *testing.T) {
+	service := setupService(stockRepo(5))
+	ctx := context.Background()
+
+	resp, err := service.DecrementStock(ctx, &pb.DecrementStockRequest{ProductId: "p1", Quantity: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Stock != 0 {
+		t.Errorf("Expected stock 0, got %d", resp.Stock)
+	}
+
+	_, err = service.DecrementStock(ctx, &pb.DecrementStockRequest{ProductId: "p1", Quantity: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestAdjustStock_InvalidRequest(t *testing.T) {
+	service := setupService(stockRepo(5))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"missing product", func() error {
+			_, err := service.IncrementStock(ctx, &pb.IncrementStockRequest{Quantity: 1})
+			return err
+		}, codes.InvalidArgument},
+		{"zero quantity", func() error {
+			_, err := service.IncrementStock(ctx, &pb.IncrementStockRequest{ProductId: "p1"})
+			return err
+		}, codes.InvalidArgument},
+		{"negative quantity", func() error {
+			_, err := service.DecrementStock(ctx, &pb.DecrementStockRequest{ProductId: "p1", Quantity: -2})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown product", func() error {
+			_, err := service.DecrementStock(ctx, &pb.DecrementStockRequest{ProductId: "missing", Quantity: 1})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		if code := status.Code(tt.call()); code != tt.code {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.code, code)
+		}
+	}
+}
+
+func TestDecrementStock_EmitsLowStockOnce(t *testing.T) {
+	sink := &recordingStockSink{}
+	service := setupService(lowStockRepo(10, 5)).WithStockEventSink(sink)
+	ctx := context.Background()
+
+	for _, quantity := range []int32{4, 2, 1} {
+		if _, err := service.DecrementStock(ctx, &pb.DecrementStockRequest{ProductId: "p1", Quantity: quantity}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if len(sink.events) != 1 {
+		t.Fatalf("Expected 1 low-stock event, got %d", len(sink.events))
+	}
+	if e := sink.events[0]; e.Type != StockEventLowStock || e.ProductID != "p1" || e.Stock != 4 || e.Threshold != 5 {
+		t.Errorf("Unexpected event %+v", e)
+	}
+
+	// Restocking above the threshold re-arms the alert
+	if _, err := service.IncrementStock(ctx, &pb.IncrementStockRequest{ProductId: "p1", Quantity: 10}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.DecrementStock(ctx, &pb.DecrementStockRequest{ProductId: "p1", Quantity: 9}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sink.events) != 2 {
+		t.Errorf("Expected a second low-stock event after restocking, got %d", len(sink.events))
+	}
+}
+
+func TestDecrementStock_ThresholdDisabled(t *testing.T) {
+	sink := &recordingStockSink{}
+	service := setupService(stockRepo(3)).WithStockEventSink(sink)
+
+	if _, err := service.DecrementStock(context.Background(), &pb.DecrementStockRequest{ProductId: "p1", Quantity: 3}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sink.events) != 0 {
+		t.Errorf("Expected no events without a threshold, got %v", sink.events)
+	}
+}
+
+func TestListLowStockProducts(t *testing.T) {
+	mockRepo := &MockRepository{
+		ListLowStockFunc: func(ctx context.Context, page, pageSize int32) ([]*Product, int32, error) {
+			if page != 1 || pageSize != 100 {
+				t.Errorf("Expected page 1 of 100, got page %d of %d", page, pageSize)
+			}
+			return []*Product{{ID: "p1", Stock: 2, LowStockThreshold: 5}}, 1, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.ListLowStockProducts(context.Background(), &pb.ListLowStockProductsRequest{PageSize: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 1 || len(resp.Products) != 1 || resp.Products[0].LowStockThreshold != 5 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+}
+
+func TestUpdateProduct_EmitsLowStock(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, SKU: "TEST-001", Stock: 20, LowStockThreshold: 5, Version: 1}, nil
+		},
+		UpdateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			return product, nil
+		},
+	}
+	sink := &recordingStockSink{}
+	service := setupService(mockRepo).WithStockEventSink(sink)
+
+	_, err := service.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+		Id:                "p1",
+		Name:              "Mug",
+		Price:             9.99,
+		Stock:             3,
+		LowStockThreshold: 5,
+		Version:           1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sink.events) != 1 || sink.events[0].Stock != 3 {
+		t.Errorf("Expected one low-stock event at stock 3, got %+v", sink.events)
+	}
+
+	_, err = service.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: "p1", Name: "Mug", Price: 9.99, LowStockThreshold: -1, Version: 1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
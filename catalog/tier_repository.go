@@ -0,0 +1,80 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+)
+
+// PriceTier is a quantity-break price. UnitPrice applies from MinQuantity up to
+// the next tier's MinQuantity.
+type PriceTier struct {
+	MinQuantity int32
+	UnitPrice   float64
+}
+
+// SetPriceTiers replaces the quantity-break prices of a product
+func (r *postgresRepository) SetPriceTiers(ctx context.Context, productID string, tiers []*PriceTier) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM product_price_tiers WHERE product_id = $1", productID); err != nil {
+		r.log.Error(ctx, "Failed to clear price tiers", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to clear price tiers: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO product_price_tiers (product_id, min_quantity, unit_price)
+		VALUES ($1, $2, $3)
+	`
+	for _, tier := range tiers {
+		if _, err := tx.ExecContext(ctx, insertQuery, productID, tier.MinQuantity, tier.UnitPrice); err != nil {
+			r.log.Error(ctx, "Failed to insert price tier", map[string]interface{}{"error": err.Error(), "product_id": productID, "min_quantity": tier.MinQuantity})
+			return fmt.Errorf("failed to insert price tier: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit price tiers", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return fmt.Errorf("failed to commit price tiers: %w", err)
+	}
+
+	r.log.Info(ctx, "Price tiers updated successfully", map[string]interface{}{"product_id": productID, "count": len(tiers)})
+	return nil
+}
+
+// GetPriceTiers retrieves the quantity-break prices of a product, ordered by quantity
+func (r *postgresRepository) GetPriceTiers(ctx context.Context, productID string) ([]*PriceTier, error) {
+	query := `
+		SELECT min_quantity, unit_price
+		FROM product_price_tiers
+		WHERE product_id = $1
+		ORDER BY min_quantity
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get price tiers", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get price tiers: %w", err)
+	}
+	defer rows.Close()
+
+	tiers := []*PriceTier{}
+	for rows.Next() {
+		tier := &PriceTier{}
+		if err := rows.Scan(&tier.MinQuantity, &tier.UnitPrice); err != nil {
+			r.log.Error(ctx, "Failed to scan price tier", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan price tier: %w", err)
+		}
+		tiers = append(tiers, tier)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price tiers: %w", err)
+	}
+
+	return tiers, nil
+}
@@ -0,0 +1,150 @@
+package catalog
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPriceTiers caps the number of quantity breaks per product
+const maxPriceTiers = 20
+
+// SetPriceTiers replaces a product's quantity-break prices
+func (s *Service) SetPriceTiers(ctx context.Context, req *pb.SetPriceTiersRequest) (*pb.SetPriceTiersResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Set price tiers failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if len(req.Tiers) > maxPriceTiers {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d price tiers are allowed", maxPriceTiers)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	tiers := make([]*PriceTier, len(req.Tiers))
+	for i, t := range req.Tiers {
+		tiers[i] = &PriceTier{MinQuantity: t.MinQuantity, UnitPrice: t.UnitPrice}
+	}
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinQuantity < tiers[j].MinQuantity })
+
+	if msg := validatePriceTiers(tiers, product.Price); msg != "" {
+		s.log.Warn(ctx, "Set price tiers failed: "+msg, map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	if err := s.repo.SetPriceTiers(ctx, req.ProductId, tiers); err != nil {
+		s.log.Error(ctx, "Failed to set price tiers", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to set price tiers")
+	}
+
+	return &pb.SetPriceTiersResponse{
+		Tiers: toProtoPriceTiers(tiers),
+	}, nil
+}
+
+// GetPriceForQuantity returns the unit and total price of a quantity of a product
+func (s *Service) GetPriceForQuantity(ctx context.Context, req *pb.GetPriceForQuantityRequest) (*pb.GetPriceForQuantityResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get price for quantity failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.Quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be positive")
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if msg := orderQuantityProblem(product, req.Quantity); msg != "" {
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	tiers, err := s.repo.GetPriceTiers(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get price tiers", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get price tiers")
+	}
+
+	unitPrice, applied := priceForQuantity(product.Price, tiers, req.Quantity)
+	// An active sale price wins over any tier that is not cheaper
+	if sale := product.EffectivePrice(s.now()); sale < unitPrice {
+		unitPrice, applied = sale, nil
+	}
+
+	resp := &pb.GetPriceForQuantityResponse{
+		ProductId:  product.ID,
+		Quantity:   req.Quantity,
+		UnitPrice:  unitPrice,
+		TotalPrice: roundCents(unitPrice * float64(req.Quantity)),
+		BasePrice:  product.Price,
+		Tiers:      toProtoPriceTiers(tiers),
+	}
+	if applied != nil {
+		resp.TierMinQuantity = applied.MinQuantity
+	}
+	return resp, nil
+}
+
+// validatePriceTiers checks tiers sorted by quantity and returns a message
+// describing the first problem, or "". Each break must lower the unit price.
+func validatePriceTiers(tiers []*PriceTier, basePrice float64) string {
+	previous := basePrice
+	for i, t := range tiers {
+		if t.MinQuantity < 1 {
+			return "min_quantity must be at least 1"
+		}
+		if t.UnitPrice <= 0 {
+			return "unit_price must be positive"
+		}
+		if i > 0 && t.MinQuantity == tiers[i-1].MinQuantity {
+			return "min_quantity must be unique"
+		}
+		// A tier starting at 1 replaces the base price, so it is not compared to it
+		if (i > 0 || t.MinQuantity > 1) && t.UnitPrice >= previous {
+			return "unit_price must decrease as min_quantity increases"
+		}
+		previous = t.UnitPrice
+	}
+	return ""
+}
+
+// priceForQuantity returns the unit price of quantity and the tier that set
+// it, or nil when the base price applies. tiers must be sorted by quantity.
+func priceForQuantity(basePrice float64, tiers []*PriceTier, quantity int32) (float64, *PriceTier) {
+	var applied *PriceTier
+	for _, t := range tiers {
+		if t.MinQuantity > quantity {
+			break
+		}
+		applied = t
+	}
+	if applied == nil {
+		return basePrice, nil
+	}
+	return applied.UnitPrice, applied
+}
+
+// roundCents rounds an amount to two decimals
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// toProtoPriceTiers converts price tiers to protobuf
+func toProtoPriceTiers(tiers []*PriceTier) []*pb.PriceTier {
+	protoTiers := make([]*pb.PriceTier, len(tiers))
+	for i, t := range tiers {
+		protoTiers[i] = &pb.PriceTier{MinQuantity: t.MinQuantity, UnitPrice: t.UnitPrice}
+	}
+	return protoTiers
+}
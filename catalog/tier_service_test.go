@@ -0,0 +1,181 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func tieredProductRepo(tiers []*PriceTier) *MockRepository {
+	return &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if id != "p1" {
+				return nil, ErrProductNotFound
+			}
+			return &Product{ID: id, Name: "Widget", Price: 10}, nil
+		},
+		GetPriceTiersFunc: func(ctx context.Context, productID string) ([]*PriceTier, error) {
+			return tiers, nil
+		},
+	}
+}
+
+func TestGetPriceForQuantity_AppliesTier(t *testing.T) {
+	service := setupService(tieredProductRepo([]*PriceTier{
+		{MinQuantity: 10, UnitPrice: 8},
+		{MinQuantity: 50, UnitPrice: 7.5},
+	}))
+	ctx := context.Background()
+
+	tests := []struct {
+		quantity  int32
+		unitPrice float64
+		total     float64
+		tierMin   int32
+	}{
+		{1, 10, 10, 0},
+		{9, 10, 90, 0},
+		{10, 8, 80, 10},
+		{49, 8, 392, 10},
+		{50, 7.5, 375, 50},
+	}
+
+	for _, tt := range tests {
+		resp, err := service.GetPriceForQuantity(ctx, &pb.GetPriceForQuantityRequest{ProductId: "p1", Quantity: tt.quantity})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.UnitPrice != tt.unitPrice || resp.TotalPrice != tt.total || resp.TierMinQuantity != tt.tierMin {
+			t.Errorf("Quantity %d: got unit %v total %v tier %d", tt.quantity, resp.UnitPrice, resp.TotalPrice, resp.TierMinQuantity)
+		}
+		if resp.BasePrice != 10 || len(resp.Tiers) != 2 {
+			t.Errorf("Quantity %d: unexpected base price or tiers %v", tt.quantity, resp)
+		}
+	}
+}
+
+func TestGetPriceForQuantity_InvalidRequest(t *testing.T) {
+	service := setupService(tieredProductRepo(nil))
+	ctx := context.Background()
+
+	_, err := service.GetPriceForQuantity(ctx, &pb.GetPriceForQuantityRequest{ProductId: "p1", Quantity: 0})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+
+	_, err = service.GetPriceForQuantity(ctx, &pb.GetPriceForQuantityRequest{ProductId: "missing", Quantity: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestGetPriceForQuantity_OrderQuantityRules(t *testing.T) {
+	mockRepo := tieredProductRepo(nil)
+	mockRepo.GetByIDFunc = func(ctx context.Context, id string) (*Product, error) {
+		return &Product{ID: id, Price: 10, MinOrderQty: 10, QtyIncrement: 5}, nil
+	}
+	service := setupService(mockRepo)
+	ctx := context.Background()
+
+	_, err := service.GetPriceForQuantity(ctx, &pb.GetPriceForQuantityRequest{ProductId: "p1", Quantity: 5})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument || st.Message() != "quantity must be at least 10" {
+		t.Errorf("Expected the minimum to be enforced, got %v", err)
+	}
+
+	_, err = service.GetPriceForQuantity(ctx, &pb.GetPriceForQuantityRequest{ProductId: "p1", Quantity: 12})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument || st.Message() != "quantity must be a multiple of 5" {
+		t.Errorf("Expected the increment to be enforced, got %v", err)
+	}
+
+	resp, err := service.GetPriceForQuantity(ctx, &pb.GetPriceForQuantityRequest{ProductId: "p1", Quantity: 15})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.TotalPrice != 150 {
+		t.Errorf("Expected total 150, got %v", resp.TotalPrice)
+	}
+}
+
+func TestSetPriceTiers_SortsAndSaves(t *testing.T) {
+	var saved []*PriceTier
+	mockRepo := tieredProductRepo(nil)
+	mockRepo.SetPriceTiersFunc = func(ctx context.Context, productID string, tiers []*PriceTier) error {
+		saved = tiers
+		return nil
+	}
+
+	service := setupService(mockRepo)
+	resp, err := service.SetPriceTiers(context.Background(), &pb.SetPriceTiersRequest{
+		ProductId: "p1",
+		Tiers: []*pb.PriceTier{
+			{MinQuantity: 50, UnitPrice: 7},
+			{MinQuantity: 10, UnitPrice: 8},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(saved) != 2 || saved[0].MinQuantity != 10 || saved[1].MinQuantity != 50 {
+		t.Errorf("Expected tiers to be saved in quantity order, got %v", saved)
+	}
+	if len(resp.Tiers) != 2 || resp.Tiers[0].MinQuantity != 10 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+}
+
+func TestSetPriceTiers_Validation(t *testing.T) {
+	service := setupService(tieredProductRepo(nil))
+	ctx := context.Background()
+
+	invalid := [][]*pb.PriceTier{
+		{{MinQuantity: 0, UnitPrice: 5}},
+		{{MinQuantity: 10, UnitPrice: 0}},
+		{{MinQuantity: 10, UnitPrice: 8}, {MinQuantity: 10, UnitPrice: 7}},
+		{{MinQuantity: 10, UnitPrice: 12}},                                 // not below the base price
+		{{MinQuantity: 10, UnitPrice: 8}, {MinQuantity: 20, UnitPrice: 9}}, // price goes up
+	}
+
+	for _, tiers := range invalid {
+		_, err := service.SetPriceTiers(ctx, &pb.SetPriceTiersRequest{ProductId: "p1", Tiers: tiers})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Tiers %v: expected InvalidArgument, got %v", tiers, err)
+		}
+	}
+
+	_, err := service.SetPriceTiers(ctx, &pb.SetPriceTiersRequest{ProductId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestGetPriceForQuantity_SalePrice(t *testing.T) {
+	sale := 9.0
+	mockRepo := tieredProductRepo([]*PriceTier{{MinQuantity: 10, UnitPrice: 8}})
+	mockRepo.GetByIDFunc = func(ctx context.Context, id string) (*Product, error) {
+		return &Product{ID: id, Name: "Widget", Price: 10, SalePrice: &sale}, nil
+	}
+	service := setupService(mockRepo)
+
+	tests := []struct {
+		quantity  int32
+		unitPrice float64
+		tierMin   int32
+	}{
+		{1, 9, 0},
+		{10, 8, 10},
+	}
+
+	for _, tt := range tests {
+		resp, err := service.GetPriceForQuantity(context.Background(), &pb.GetPriceForQuantityRequest{ProductId: "p1", Quantity: tt.quantity})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.UnitPrice != tt.unitPrice || resp.TierMinQuantity != tt.tierMin || resp.BasePrice != 10 {
+			t.Errorf("Quantity %d: got unit %v tier %d base %v", tt.quantity, resp.UnitPrice, resp.TierMinQuantity, resp.BasePrice)
+		}
+	}
+}
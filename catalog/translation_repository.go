@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrTranslationNotFound is returned when a product has no translation for a locale
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// ProductTranslation is the name and description of a product in one locale
+type ProductTranslation struct {
+	ProductID string
+	Locale    string
+	Name      string
+	// Description is "" when the default locale description applies
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+const translationColumns = "product_id, locale, name, description, created_at, updated_at"
+
+// SetTranslation creates or replaces the translation of a product for its locale
+func (r *postgresRepository) SetTranslation(ctx context.Context, t *ProductTranslation) (*ProductTranslation, error) {
+	query := `
+		INSERT INTO product_translations (product_id, locale, name, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (product_id, locale) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description
+		RETURNING ` + translationColumns
+
+	saved, err := scanTranslation(r.db.QueryRowContext(ctx, query, t.ProductID, t.Locale, t.Name, t.Description))
+	if err != nil {
+		r.log.Error(ctx, "Failed to save translation", map[string]interface{}{"error": err.Error(), "product_id": t.ProductID, "locale": t.Locale})
+		return nil, fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	r.log.Info(ctx, "Translation saved", map[string]interface{}{"product_id": saved.ProductID, "locale": saved.Locale})
+	return saved, nil
+}
+
+// DeleteTranslation removes the translation of a product for a locale
+func (r *postgresRepository) DeleteTranslation(ctx context.Context, productID, locale string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM product_translations WHERE product_id = $1 AND locale = $2", productID, locale)
+	if err != nil {
+		r.log.Error(ctx, "Failed to delete translation", map[string]interface{}{"error": err.Error(), "product_id": productID, "locale": locale})
+		return fmt.Errorf("failed to delete translation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrTranslationNotFound
+	}
+
+	r.log.Info(ctx, "Translation deleted", map[string]interface{}{"product_id": productID, "locale": locale})
+	return nil
+}
+
+// ListTranslations retrieves all translations of a product ordered by locale
+func (r *postgresRepository) ListTranslations(ctx context.Context, productID string) ([]*ProductTranslation, error) {
+	query := "SELECT " + translationColumns + " FROM product_translations WHERE product_id = $1 ORDER BY locale"
+	return r.queryTranslations(ctx, query, productID)
+}
+
+// GetTranslations retrieves the translations of the given products in any of
+// the given locales
+func (r *postgresRepository) GetTranslations(ctx context.Context, productIDs, locales []string) ([]*ProductTranslation, error) {
+	query := "SELECT " + translationColumns + " FROM product_translations WHERE product_id = ANY($1) AND locale = ANY($2)"
+	return r.queryTranslations(ctx, query, pq.Array(productIDs), pq.Array(locales))
+}
+
+func (r *postgresRepository) queryTranslations(ctx context.Context, query string, args ...interface{}) ([]*ProductTranslation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get translations", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []*ProductTranslation{}
+	for rows.Next() {
+		t, err := scanTranslation(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan translation", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		translations = append(translations, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating translations", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+func scanTranslation(row rowScanner) (*ProductTranslation, error) {
+	t := &ProductTranslation{}
+	if err := row.Scan(&t.ProductID, &t.Locale, &t.Name, &t.Description, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
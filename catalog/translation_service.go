@@ -0,0 +1,208 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxTranslatedNameLength matches the products name column
+const maxTranslatedNameLength = 255
+
+// WithDefaultLocale sets the locale of the name and description stored on
+// products. An invalid tag keeps DefaultLocale.
+func (s *Service) WithDefaultLocale(locale string) *Service {
+	if normalized, ok := normalizeLocale(locale); ok {
+		s.defaultLocale = normalized
+	}
+	return s
+}
+
+// SetProductTranslation creates or replaces the name and description of a
+// product in one locale
+func (s *Service) SetProductTranslation(ctx context.Context, req *pb.SetProductTranslationRequest) (*pb.SetProductTranslationResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Set translation failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	locale, ok := normalizeLocale(req.Locale)
+	if !ok {
+		s.log.Warn(ctx, "Set translation failed: invalid locale", map[string]interface{}{"locale": req.Locale})
+		return nil, status.Error(codes.InvalidArgument, "locale must be a language tag such as fr or pt-BR")
+	}
+	if locale == s.defaultLocale {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is the default locale; update the product instead", locale)
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxTranslatedNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "name cannot exceed %d characters", maxTranslatedNameLength)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.repo.SetTranslation(ctx, &ProductTranslation{
+		ProductID:   req.ProductId,
+		Locale:      locale,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to set translation", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId, "locale": locale})
+		return nil, status.Error(codes.Internal, "failed to set translation")
+	}
+
+	return &pb.SetProductTranslationResponse{
+		Translation: toProtoTranslation(translation),
+	}, nil
+}
+
+// DeleteProductTranslation removes the translation of a product for a locale;
+// reads in that locale fall back to the next preferred locale
+func (s *Service) DeleteProductTranslation(ctx context.Context, req *pb.DeleteProductTranslationRequest) (*pb.DeleteProductTranslationResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Delete translation failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	locale, ok := normalizeLocale(req.Locale)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "locale must be a language tag such as fr or pt-BR")
+	}
+
+	if err := s.authorizeVendorByID(ctx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.DeleteTranslation(ctx, req.ProductId, locale)
+	if errors.Is(err, ErrTranslationNotFound) {
+		return nil, status.Error(codes.NotFound, "translation not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to delete translation", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId, "locale": locale})
+		return nil, status.Error(codes.Internal, "failed to delete translation")
+	}
+
+	return &pb.DeleteProductTranslationResponse{}, nil
+}
+
+// ListProductTranslations lists the translations of a product
+func (s *Service) ListProductTranslations(ctx context.Context, req *pb.ListProductTranslationsRequest) (*pb.ListProductTranslationsResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "List translations failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	translations, err := s.repo.ListTranslations(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list translations", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to list translations")
+	}
+
+	resp := &pb.ListProductTranslationsResponse{
+		Translations:  make([]*pb.ProductTranslation, len(translations)),
+		DefaultLocale: s.defaultLocale,
+	}
+	for i, t := range translations {
+		resp.Translations[i] = toProtoTranslation(t)
+	}
+	return resp, nil
+}
+
+// localize replaces the name and description of products with their best
+// translation for the requested Accept-Language value. Products without a
+// matching translation keep their default locale content.
+func (s *Service) localize(ctx context.Context, acceptLanguage string, products ...*Product) error {
+	for _, p := range products {
+		p.Locale = s.defaultLocale
+	}
+
+	locales := s.preferredLocales(ctx, acceptLanguage)
+	if len(locales) == 0 || len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	translations, err := s.repo.GetTranslations(ctx, ids, locales)
+	if err != nil {
+		return err
+	}
+
+	rank := make(map[string]int, len(locales))
+	for i, l := range locales {
+		rank[l] = i
+	}
+	best := make(map[string]*ProductTranslation, len(translations))
+	for _, t := range translations {
+		if current, ok := best[t.ProductID]; !ok || rank[t.Locale] < rank[current.Locale] {
+			best[t.ProductID] = t
+		}
+	}
+
+	for _, p := range products {
+		t, ok := best[p.ID]
+		if !ok {
+			continue
+		}
+		p.Name = t.Name
+		if t.Description != "" {
+			p.Description = t.Description
+		}
+		p.Locale = t.Locale
+	}
+	return nil
+}
+
+// preferredLocales returns the locales to look translations up in, most
+// preferred first. acceptLanguage defaults to the accept-language metadata of
+// the call. Each locale is followed by its less specific forms, and the list
+// stops at the default locale since its content is always available.
+func (s *Service) preferredLocales(ctx context.Context, acceptLanguage string) []string {
+	if acceptLanguage == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			acceptLanguage = strings.Join(md.Get("accept-language"), ",")
+		}
+	}
+
+	var locales []string
+	seen := make(map[string]bool)
+	for _, requested := range parseAcceptLanguage(acceptLanguage) {
+		for _, locale := range localeFallbacks(requested) {
+			if locale == s.defaultLocale {
+				return locales
+			}
+			if !seen[locale] {
+				seen[locale] = true
+				locales = append(locales, locale)
+			}
+		}
+	}
+	return locales
+}
+
+func toProtoTranslation(t *ProductTranslation) *pb.ProductTranslation {
+	return &pb.ProductTranslation{
+		ProductId:   t.ProductID,
+		Locale:      t.Locale,
+		Name:        t.Name,
+		Description: t.Description,
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+	}
+}
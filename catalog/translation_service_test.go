@@ -0,0 +1,192 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// translationRepo serves two products with French and Canadian French translations
+func translationRepo(lookups *[][]string) *MockRepository {
+	translations := []*ProductTranslation{
+		{ProductID: "prod-1", Locale: "fr", Name: "Lampe de bureau", Description: "Une lampe"},
+		{ProductID: "prod-1", Locale: "fr-CA", Name: "Lampe de bureau (CA)"},
+		{ProductID: "prod-2", Locale: "fr", Name: "Tasse"},
+	}
+	return &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id, Name: "Desk Lamp", Description: "A lamp"}, nil
+		},
+		GetRelationsFunc: func(ctx context.Context, productID, relationType string) ([]*RelatedProduct, error) {
+			return []*RelatedProduct{{RelationType: RelationCrossSell, Product: &Product{ID: "prod-2", Name: "Mug"}}}, nil
+		},
+		GetTranslationsFunc: func(ctx context.Context, productIDs, locales []string) ([]*ProductTranslation, error) {
+			if lookups != nil {
+				*lookups = append(*lookups, locales)
+			}
+			var found []*ProductTranslation
+			for _, t := range translations {
+				for _, l := range locales {
+					if t.Locale == l {
+						found = append(found, t)
+					}
+				}
+			}
+			return found, nil
+		},
+	}
+}
+
+func TestGetProduct_Localized(t *testing.T) {
+	var lookups [][]string
+	service := setupService(translationRepo(&lookups))
+
+	resp, err := service.GetProduct(context.Background(), &pb.GetProductRequest{Id: "prod-1", IncludeRelated: true, Locale: "fr-CA, en;q=0.8"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The description is not translated for fr-CA, so the default one is kept
+	if p := resp.Product; p.Name != "Lampe de bureau (CA)" || p.Description != "A lamp" || p.Locale != "fr-CA" {
+		t.Errorf("Unexpected localized product %v", p)
+	}
+	if p := resp.RelatedProducts[0].Product; p.Name != "Tasse" || p.Locale != "fr" {
+		t.Errorf("Expected related product to fall back to fr, got %v", p)
+	}
+	if expected := [][]string{{"fr-CA", "fr"}}; !reflect.DeepEqual(lookups, expected) {
+		t.Errorf("Expected lookups %v, got %v", expected, lookups)
+	}
+}
+
+func TestGetProduct_LocaleFromMetadata(t *testing.T) {
+	service := setupService(translationRepo(nil))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "fr"))
+
+	resp, err := service.GetProduct(ctx, &pb.GetProductRequest{Id: "prod-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Product.Name != "Lampe de bureau" || resp.Product.Description != "Une lampe" {
+		t.Errorf("Expected French content, got %v", resp.Product)
+	}
+}
+
+func TestGetProduct_DefaultLocale(t *testing.T) {
+	var lookups [][]string
+	service := setupService(translationRepo(&lookups))
+
+	for _, locale := range []string{"", "en-US, fr;q=0.5", "de"} {
+		resp, err := service.GetProduct(context.Background(), &pb.GetProductRequest{Id: "prod-1", Locale: locale})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Product.Name != "Desk Lamp" || resp.Product.Locale != DefaultLocale {
+			t.Errorf("%q: expected default locale content, got %v", locale, resp.Product)
+		}
+	}
+
+	// en-US falls back to the default locale before fr is considered
+	if expected := [][]string{{"en-US"}, {"de"}}; !reflect.DeepEqual(lookups, expected) {
+		t.Errorf("Expected lookups %v, got %v", expected, lookups)
+	}
+}
+
+func TestSetProductTranslation(t *testing.T) {
+	var saved *ProductTranslation
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id}, nil
+		},
+		SetTranslationFunc: func(ctx context.Context, tr *ProductTranslation) (*ProductTranslation, error) {
+			saved = tr
+			tr.UpdatedAt = time.Now()
+			return tr, nil
+		},
+	}
+	service := setupService(mockRepo).WithDefaultLocale("en-us")
+
+	resp, err := service.SetProductTranslation(context.Background(), &pb.SetProductTranslationRequest{
+		ProductId:   "prod-1",
+		Locale:      "pt_br",
+		Name:        " Luminária ",
+		Description: "Uma luminária",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Locale != "pt-BR" || saved.Name != "Luminária" || resp.Translation.Locale != "pt-BR" {
+		t.Errorf("Unexpected translation %+v", saved)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.SetProductTranslationRequest
+	}{
+		{"missing product", &pb.SetProductTranslationRequest{Locale: "fr", Name: "Lampe"}},
+		{"invalid locale", &pb.SetProductTranslationRequest{ProductId: "prod-1", Locale: "french", Name: "Lampe"}},
+		{"default locale", &pb.SetProductTranslationRequest{ProductId: "prod-1", Locale: "EN-US", Name: "Lamp"}},
+		{"missing name", &pb.SetProductTranslationRequest{ProductId: "prod-1", Locale: "fr", Name: " "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.SetProductTranslation(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetProductTranslation_ProductNotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.SetProductTranslation(context.Background(), &pb.SetProductTranslationRequest{ProductId: "missing", Locale: "fr", Name: "Lampe"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestDeleteProductTranslation_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		DeleteTranslationFunc: func(ctx context.Context, productID, locale string) error {
+			if locale != "fr-CA" {
+				t.Errorf("Expected normalized locale, got %s", locale)
+			}
+			return ErrTranslationNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.DeleteProductTranslation(context.Background(), &pb.DeleteProductTranslationRequest{ProductId: "prod-1", Locale: "fr_ca"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListProducts_LocalizeFailure(t *testing.T) {
+	mockRepo := &MockRepository{
+		ListFunc: func(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			return []*Product{{ID: "prod-1", Name: "Desk Lamp"}}, 1, nil
+		},
+		GetTranslationsFunc: func(ctx context.Context, productIDs, locales []string) ([]*ProductTranslation, error) {
+			return nil, errors.New("database unavailable")
+		},
+	}
+	service := setupService(mockRepo)
+
+	_, err := service.ListProducts(context.Background(), &pb.ListProductsRequest{Locale: "fr"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+}
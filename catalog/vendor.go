@@ -0,0 +1,194 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	vendorspb "github.com/Ujjwaljain16/E-commerce-Backend/vendors/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// vendorMetadataKey is the gRPC metadata key carrying the marketplace
+	// vendor a call is made for. The vendor portal sets it; operator tools
+	// leave it unset.
+	vendorMetadataKey = "x-vendor-id"
+	// vendorStatusActive is the vendor service status of vendors that may sell
+	vendorStatusActive = "ACTIVE"
+)
+
+// VendorMethods are the admin RPCs a marketplace vendor may call, each only
+// on its own products. Vendors are refused every other admin RPC.
+var VendorMethods = []string{
+	pb.CatalogService_CreateProduct_FullMethodName,
+	pb.CatalogService_UpdateProduct_FullMethodName,
+	pb.CatalogService_DeleteProduct_FullMethodName,
+	pb.CatalogService_CloneProduct_FullMethodName,
+	pb.CatalogService_ChangeSKU_FullMethodName,
+	pb.CatalogService_SetRelatedProducts_FullMethodName,
+	pb.CatalogService_SetBookingConfig_FullMethodName,
+	pb.CatalogService_GetImageUploadURL_FullMethodName,
+	pb.CatalogService_AttachImage_FullMethodName,
+	pb.CatalogService_ReorderImages_FullMethodName,
+	pb.CatalogService_SetPrimaryImage_FullMethodName,
+	pb.CatalogService_SetPriceTiers_FullMethodName,
+	pb.CatalogService_IncrementStock_FullMethodName,
+	pb.CatalogService_DecrementStock_FullMethodName,
+	pb.CatalogService_SetBundle_FullMethodName,
+	pb.CatalogService_GetDigitalAssetUploadURL_FullMethodName,
+	pb.CatalogService_AttachDigitalAsset_FullMethodName,
+	pb.CatalogService_SetProductTranslation_FullMethodName,
+	pb.CatalogService_DeleteProductTranslation_FullMethodName,
+	pb.CatalogService_SetProductVisibility_FullMethodName,
+}
+
+// vendorFromContext returns the vendor ID forwarded by the vendor portal, or
+// "" for calls made by the operator
+func vendorFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, v := range md.Get(vendorMetadataKey) {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// authorizeVendor returns a PermissionDenied error when the call is made for
+// a vendor that does not sell the product. The operator may change any
+// product.
+func (s *Service) authorizeVendor(ctx context.Context, product *Product) error {
+	vendorID := vendorFromContext(ctx)
+	if vendorID == "" || vendorID == product.VendorID {
+		return nil
+	}
+	s.log.Warn(ctx, "Vendor write refused: product belongs to another seller", map[string]interface{}{"product_id": product.ID, "vendor_id": vendorID})
+	return status.Error(codes.PermissionDenied, "product belongs to another seller")
+}
+
+// authorizeVendorByID is authorizeVendor for a product that has not been
+// read. Operator calls do not read it.
+func (s *Service) authorizeVendorByID(ctx context.Context, productID string) error {
+	if vendorFromContext(ctx) == "" {
+		return nil
+	}
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return s.productLookupError(ctx, err, productID)
+	}
+	return s.authorizeVendor(ctx, product)
+}
+
+// Vendors looks up marketplace vendors
+type Vendors interface {
+	// CanSell reports whether a vendor exists and is ACTIVE
+	CanSell(ctx context.Context, vendorID string) (bool, error)
+}
+
+type grpcVendors struct {
+	client vendorspb.VendorServiceClient
+}
+
+// NewGRPCVendors creates Vendors backed by the vendor service
+func NewGRPCVendors(client vendorspb.VendorServiceClient) Vendors {
+	return &grpcVendors{client: client}
+}
+
+// CanSell reads the vendor's status
+func (v *grpcVendors) CanSell(ctx context.Context, vendorID string) (bool, error) {
+	resp, err := v.client.GetVendor(ctx, &vendorspb.GetVendorRequest{VendorId: vendorID})
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get vendor: %w", err)
+	}
+	return resp.Vendor.Status == vendorStatusActive, nil
+}
+
+// VendorGuard refuses admin calls made for a marketplace vendor unless the
+// method is one of VendorMethods and the vendor is ACTIVE. Calls without a
+// vendor, and public RPCs, pass through; which products a vendor may change
+// is checked by the service.
+type VendorGuard struct {
+	vendors Vendors
+	admin   map[string]bool
+	allowed map[string]bool
+	log     *logger.Logger
+}
+
+// NewVendorGuard creates a vendor guard. With nil vendors the marketplace is
+// disabled and every admin call made for a vendor is refused.
+func NewVendorGuard(vendors Vendors, log *logger.Logger) *VendorGuard {
+	g := &VendorGuard{
+		vendors: vendors,
+		admin:   make(map[string]bool, len(AdminMethods)),
+		allowed: make(map[string]bool, len(VendorMethods)),
+		log:     log,
+	}
+	for _, m := range AdminMethods {
+		g.admin[m] = true
+	}
+	for _, m := range VendorMethods {
+		g.allowed[m] = true
+	}
+	return g
+}
+
+// UnaryServerInterceptor returns a unary interceptor enforcing the guard
+func (g *VendorGuard) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := g.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream interceptor enforcing the guard
+func (g *VendorGuard) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := g.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// check returns the error refusing a call, or nil
+func (g *VendorGuard) check(ctx context.Context, method string) error {
+	vendorID := vendorFromContext(ctx)
+	if vendorID == "" || !g.admin[method] {
+		return nil
+	}
+	fields := map[string]interface{}{"vendor_id": vendorID, "method": method}
+	if !g.allowed[method] {
+		g.log.Warn(ctx, "Vendor call refused: method not open to vendors", fields)
+		return status.Error(codes.PermissionDenied, "method is not open to vendors")
+	}
+	if g.vendors == nil {
+		g.log.Warn(ctx, "Vendor call refused: marketplace vendors are not enabled", fields)
+		return status.Error(codes.FailedPrecondition, "marketplace vendors are not enabled")
+	}
+
+	ok, err := g.vendors.CanSell(ctx, vendorID)
+	if err != nil {
+		fields["error"] = err.Error()
+		g.log.Error(ctx, "Failed to check vendor", fields)
+		return status.Error(codes.Unavailable, "failed to check vendor")
+	}
+	if !ok {
+		g.log.Warn(ctx, "Vendor call refused: vendor is not active", fields)
+		return status.Error(codes.PermissionDenied, "vendor is not active")
+	}
+	return nil
+}
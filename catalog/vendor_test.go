@@ -0,0 +1,207 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	vendorspb "github.com/Ujjwaljain16/E-commerce-Backend/vendors/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// vendorContext returns a context for a call made for a vendor
+func vendorContext(vendorID string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(vendorMetadataKey, vendorID))
+}
+
+// fakeVendors reports the vendors in active as able to sell
+type fakeVendors struct {
+	active map[string]bool
+	err    error
+	calls  int
+}
+
+func (f *fakeVendors) CanSell(ctx context.Context, vendorID string) (bool, error) {
+	f.calls++
+	return f.active[vendorID], f.err
+}
+
+func TestCreateProduct_Vendor(t *testing.T) {
+	var created *Product
+	mockRepo := &MockRepository{
+		GetBySKUFunc: func(ctx context.Context, sku string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+		CreateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			created = product
+			product.ID = "prod-1"
+			return product, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.CreateProduct(vendorContext("vendor-1"), &pb.CreateProductRequest{Name: "Mug", Price: 12, Sku: "MUG-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.VendorID != "vendor-1" || resp.Product.VendorId != "vendor-1" {
+		t.Errorf("Expected the product owned by vendor-1, got %q", created.VendorID)
+	}
+}
+
+func TestVendorWrites_Ownership(t *testing.T) {
+	products := map[string]*Product{
+		"own":      {ID: "own", Name: "Mug", Price: 12, SKU: "MUG-1", Version: 1, Status: ProductStatusActive, VendorID: "vendor-1"},
+		"other":    {ID: "other", Name: "Cup", Price: 9, SKU: "CUP-1", Version: 1, Status: ProductStatusActive, VendorID: "vendor-2"},
+		"operator": {ID: "operator", Name: "Lamp", Price: 40, SKU: "LAMP-1", Version: 1, Status: ProductStatusActive},
+	}
+	var deleted []string
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			if p, ok := products[id]; ok {
+				copied := *p
+				return &copied, nil
+			}
+			return nil, ErrProductNotFound
+		},
+		UpdateFunc: func(ctx context.Context, product *Product, actor string) (*Product, error) {
+			return product, nil
+		},
+		DeleteFunc: func(ctx context.Context, id, actor string) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+		AdjustStockFunc: func(ctx context.Context, productID string, delta int32) (*StockLevel, error) {
+			return &StockLevel{ProductID: productID, Stock: 10 + delta}, nil
+		},
+	}
+	service := setupService(mockRepo)
+	ctx := vendorContext("vendor-1")
+
+	update := func(id string) error {
+		_, err := service.UpdateProduct(ctx, &pb.UpdateProductRequest{Id: id, Name: "Renamed", Price: 15, Version: 1})
+		return err
+	}
+	resp, err := service.UpdateProduct(ctx, &pb.UpdateProductRequest{Id: "own", Name: "Renamed", Price: 15, Version: 1})
+	if err != nil {
+		t.Fatalf("Expected the vendor to update its own product, got %v", err)
+	}
+	if resp.Product.VendorId != "vendor-1" {
+		t.Errorf("Expected the product to stay with vendor-1, got %q", resp.Product.VendorId)
+	}
+	for _, id := range []string{"other", "operator"} {
+		if err := update(id); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected PermissionDenied updating %s, got %v", id, err)
+		}
+		if _, err := service.DeleteProduct(ctx, &pb.DeleteProductRequest{Id: id}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected PermissionDenied deleting %s, got %v", id, err)
+		}
+		if _, err := service.IncrementStock(ctx, &pb.IncrementStockRequest{ProductId: id, Quantity: 1}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected PermissionDenied restocking %s, got %v", id, err)
+		}
+	}
+	if _, err := service.DeleteProduct(ctx, &pb.DeleteProductRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing product, got %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected nothing deleted, got %v", deleted)
+	}
+
+	// The operator may change any product
+	if _, err := service.DeleteProduct(context.Background(), &pb.DeleteProductRequest{Id: "other"}); err != nil {
+		t.Errorf("Expected the operator to delete a vendor's product, got %v", err)
+	}
+}
+
+func TestListProducts_VendorFilter(t *testing.T) {
+	var gotFilter ProductFilter
+	mockRepo := &MockRepository{
+		ListFunc: func(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			gotFilter = filter
+			return nil, 0, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	if _, err := service.ListProducts(context.Background(), &pb.ListProductsRequest{VendorId: "vendor-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotFilter.VendorID != "vendor-1" {
+		t.Errorf("Expected the vendor filter, got %+v", gotFilter)
+	}
+}
+
+func TestVendorGuard(t *testing.T) {
+	vendors := &fakeVendors{active: map[string]bool{"vendor-1": true}}
+	guard := NewVendorGuard(vendors, logger.New("catalog-test"))
+	interceptor := guard.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		expected codes.Code
+	}{
+		{"operator", context.Background(), pb.CatalogService_ReviewImage_FullMethodName, codes.OK},
+		{"active vendor", vendorContext("vendor-1"), pb.CatalogService_UpdateProduct_FullMethodName, codes.OK},
+		{"public read", vendorContext("vendor-9"), pb.CatalogService_GetProduct_FullMethodName, codes.OK},
+		{"inactive vendor", vendorContext("vendor-9"), pb.CatalogService_UpdateProduct_FullMethodName, codes.PermissionDenied},
+		{"operator-only method", vendorContext("vendor-1"), pb.CatalogService_ReviewImage_FullMethodName, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := call(tt.ctx, tt.method); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	vendors.err = errors.New("unavailable")
+	if err := call(vendorContext("vendor-1"), pb.CatalogService_UpdateProduct_FullMethodName); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable when the vendor service fails, got %v", err)
+	}
+
+	disabled := NewVendorGuard(nil, logger.New("catalog-test")).UnaryServerInterceptor()
+	_, err := disabled(vendorContext("vendor-1"), nil, &grpc.UnaryServerInfo{FullMethod: pb.CatalogService_UpdateProduct_FullMethodName}, handler)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without a vendor service, got %v", err)
+	}
+}
+
+// fakeVendorClient serves GetVendor from the vendor service client interface
+type fakeVendorClient struct {
+	vendorspb.VendorServiceClient
+	vendors map[string]string
+}
+
+func (c *fakeVendorClient) GetVendor(ctx context.Context, req *vendorspb.GetVendorRequest, opts ...grpc.CallOption) (*vendorspb.GetVendorResponse, error) {
+	s, ok := c.vendors[req.VendorId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "vendor not found")
+	}
+	return &vendorspb.GetVendorResponse{Vendor: &vendorspb.Vendor{Id: req.VendorId, Status: s}}, nil
+}
+
+func TestGRPCVendors_CanSell(t *testing.T) {
+	vendors := NewGRPCVendors(&fakeVendorClient{vendors: map[string]string{"vendor-1": "ACTIVE", "vendor-2": "SUSPENDED"}})
+
+	for id, expected := range map[string]bool{"vendor-1": true, "vendor-2": false, "missing": false} {
+		ok, err := vendors.CanSell(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got %v", id, err)
+		}
+		if ok != expected {
+			t.Errorf("Expected CanSell %v for %s, got %v", expected, id, ok)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Storefront channels
+const (
+	ChannelWeb    = "WEB"
+	ChannelMobile = "MOBILE"
+	ChannelB2B    = "B2B"
+)
+
+// visibleCondition keeps products whose visibility rules admit the channel
+// and customer group in the given placeholders. An empty rule list admits
+// everyone, and products without rules are visible everywhere.
+const visibleCondition = `NOT EXISTS (
+	SELECT 1 FROM product_visibility v
+	WHERE v.product_id = products.id
+		AND ((cardinality(v.channels) > 0 AND NOT $%[1]d = ANY(v.channels))
+			OR (cardinality(v.customer_groups) > 0 AND NOT $%[2]d = ANY(v.customer_groups))))`
+
+// ProductVisibility restricts the channels and customer groups a product is
+// listed and searchable for. An empty list places no restriction.
+type ProductVisibility struct {
+	ProductID      string
+	Channels       []string
+	CustomerGroups []string
+	// UpdatedAt is zero for a product that never had rules
+	UpdatedAt time.Time
+}
+
+// SetVisibility creates or replaces the visibility rules of a product
+func (r *postgresRepository) SetVisibility(ctx context.Context, v *ProductVisibility) (*ProductVisibility, error) {
+	query := `
+		INSERT INTO product_visibility (product_id, channels, customer_groups, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (product_id) DO UPDATE
+		SET channels = EXCLUDED.channels, customer_groups = EXCLUDED.customer_groups, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+
+	saved := &ProductVisibility{ProductID: v.ProductID, Channels: v.Channels, CustomerGroups: v.CustomerGroups}
+	err := r.db.QueryRowContext(ctx, query, v.ProductID, pq.Array(v.Channels), pq.Array(v.CustomerGroups)).Scan(&saved.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to save product visibility", map[string]interface{}{"error": err.Error(), "product_id": v.ProductID})
+		return nil, fmt.Errorf("failed to save product visibility: %w", err)
+	}
+
+	r.log.Info(ctx, "Product visibility saved", map[string]interface{}{"product_id": v.ProductID, "channels": v.Channels, "customer_groups": v.CustomerGroups})
+	return saved, nil
+}
+
+// GetVisibility retrieves the visibility rules of a product; a product without
+// rules gets empty lists
+func (r *postgresRepository) GetVisibility(ctx context.Context, productID string) (*ProductVisibility, error) {
+	query := "SELECT channels, customer_groups, updated_at FROM product_visibility WHERE product_id = $1"
+
+	v := &ProductVisibility{ProductID: productID}
+	err := r.db.QueryRowContext(ctx, query, productID).Scan(pq.Array(&v.Channels), pq.Array(&v.CustomerGroups), &v.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &ProductVisibility{ProductID: productID, Channels: []string{}, CustomerGroups: []string{}}, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get product visibility", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get product visibility: %w", err)
+	}
+	return v, nil
+}
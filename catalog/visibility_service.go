@@ -0,0 +1,141 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCustomerGroups caps the customer groups a product can be restricted to
+const maxCustomerGroups = 20
+
+// maxCustomerGroupLength caps the length of a customer group name
+const maxCustomerGroupLength = 50
+
+// isValidChannel reports whether channel is one of the storefront channels
+func isValidChannel(channel string) bool {
+	switch channel {
+	case ChannelWeb, ChannelMobile, ChannelB2B:
+		return true
+	}
+	return false
+}
+
+// audienceFromRequest validates the channel and customer group a listing is
+// made for
+func audienceFromRequest(channel, customerGroup string) (string, string, string) {
+	customerGroup = strings.TrimSpace(customerGroup)
+	if channel == "" {
+		if customerGroup != "" {
+			return "", "", "channel is required with customer_group"
+		}
+		return "", "", ""
+	}
+	if !isValidChannel(channel) {
+		return "", "", "channel must be WEB, MOBILE or B2B"
+	}
+	return channel, customerGroup, ""
+}
+
+// SetProductVisibility replaces the channels and customer groups a product
+// is listed and searchable for. Empty lists make it visible everywhere.
+func (s *Service) SetProductVisibility(ctx context.Context, req *pb.SetProductVisibilityRequest) (*pb.SetProductVisibilityResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Set product visibility failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	channels := []string{}
+	seen := map[string]bool{}
+	for _, c := range req.Channels {
+		if !isValidChannel(c) {
+			s.log.Warn(ctx, "Set product visibility failed: invalid channel", map[string]interface{}{"channel": c})
+			return nil, status.Error(codes.InvalidArgument, "channels must be WEB, MOBILE or B2B")
+		}
+		if !seen[c] {
+			seen[c] = true
+			channels = append(channels, c)
+		}
+	}
+
+	groups := []string{}
+	seen = map[string]bool{}
+	for _, g := range req.CustomerGroups {
+		g = strings.TrimSpace(g)
+		if g == "" {
+			return nil, status.Error(codes.InvalidArgument, "customer_groups cannot contain empty names")
+		}
+		if utf8.RuneCountInString(g) > maxCustomerGroupLength {
+			return nil, status.Errorf(codes.InvalidArgument, "customer group names cannot exceed %d characters", maxCustomerGroupLength)
+		}
+		if !seen[g] {
+			seen[g] = true
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) > maxCustomerGroups {
+		return nil, status.Errorf(codes.InvalidArgument, "a product can be restricted to at most %d customer groups", maxCustomerGroups)
+	}
+
+	product, err := s.repo.GetByID(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+	if err := s.authorizeVendor(ctx, product); err != nil {
+		return nil, err
+	}
+
+	visibility, err := s.repo.SetVisibility(ctx, &ProductVisibility{
+		ProductID:      req.ProductId,
+		Channels:       channels,
+		CustomerGroups: groups,
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to set product visibility", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to set product visibility")
+	}
+
+	return &pb.SetProductVisibilityResponse{
+		Visibility: toProtoVisibility(visibility),
+	}, nil
+}
+
+// GetProductVisibility returns the visibility rules of a product
+func (s *Service) GetProductVisibility(ctx context.Context, req *pb.GetProductVisibilityRequest) (*pb.GetProductVisibilityResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "Get product visibility failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	if _, err := s.repo.GetByID(ctx, req.ProductId); err != nil {
+		return nil, s.productLookupError(ctx, err, req.ProductId)
+	}
+
+	visibility, err := s.repo.GetVisibility(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get product visibility", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get product visibility")
+	}
+
+	return &pb.GetProductVisibilityResponse{
+		Visibility: toProtoVisibility(visibility),
+	}, nil
+}
+
+// toProtoVisibility converts visibility rules to protobuf
+func toProtoVisibility(v *ProductVisibility) *pb.ProductVisibility {
+	visibility := &pb.ProductVisibility{
+		ProductId:      v.ProductID,
+		Channels:       v.Channels,
+		CustomerGroups: v.CustomerGroups,
+	}
+	if !v.UpdatedAt.IsZero() {
+		visibility.UpdatedAt = timestamppb.New(v.UpdatedAt)
+	}
+	return visibility
+}
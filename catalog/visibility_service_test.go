@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetProductVisibility_Success(t *testing.T) {
+	var got *ProductVisibility
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id}, nil
+		},
+		SetVisibilityFunc: func(ctx context.Context, v *ProductVisibility) (*ProductVisibility, error) {
+			got = v
+			saved := *v
+			saved.UpdatedAt = time.Now()
+			return &saved, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.SetProductVisibility(context.Background(), &pb.SetProductVisibilityRequest{
+		ProductId:      "prod-1",
+		Channels:       []string{ChannelB2B, ChannelWeb, ChannelB2B},
+		CustomerGroups: []string{" wholesale ", "wholesale", "vip"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got.Channels) != 2 || got.Channels[0] != ChannelB2B || got.Channels[1] != ChannelWeb {
+		t.Errorf("Expected deduplicated channels, got %v", got.Channels)
+	}
+	if len(got.CustomerGroups) != 2 || got.CustomerGroups[0] != "wholesale" || got.CustomerGroups[1] != "vip" {
+		t.Errorf("Expected trimmed, deduplicated groups, got %v", got.CustomerGroups)
+	}
+	if resp.Visibility.ProductId != "prod-1" || resp.Visibility.UpdatedAt == nil {
+		t.Errorf("Unexpected visibility %v", resp.Visibility)
+	}
+
+	// Empty lists lift every restriction
+	if _, err := service.SetProductVisibility(context.Background(), &pb.SetProductVisibilityRequest{ProductId: "prod-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got.Channels) != 0 || len(got.CustomerGroups) != 0 {
+		t.Errorf("Expected no restrictions, got %+v", got)
+	}
+}
+
+func TestSetProductVisibility_Errors(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return nil, ErrProductNotFound
+		},
+	}
+	service := setupService(mockRepo)
+
+	manyGroups := make([]string, maxCustomerGroups+1)
+	for i := range manyGroups {
+		manyGroups[i] = string(rune('a' + i))
+	}
+
+	tests := []struct {
+		name     string
+		req      *pb.SetProductVisibilityRequest
+		expected codes.Code
+	}{
+		{"missing product ID", &pb.SetProductVisibilityRequest{Channels: []string{ChannelWeb}}, codes.InvalidArgument},
+		{"unknown channel", &pb.SetProductVisibilityRequest{ProductId: "prod-1", Channels: []string{"KIOSK"}}, codes.InvalidArgument},
+		{"empty group", &pb.SetProductVisibilityRequest{ProductId: "prod-1", CustomerGroups: []string{" "}}, codes.InvalidArgument},
+		{"too many groups", &pb.SetProductVisibilityRequest{ProductId: "prod-1", CustomerGroups: manyGroups}, codes.InvalidArgument},
+		{"unknown product", &pb.SetProductVisibilityRequest{ProductId: "missing", Channels: []string{ChannelWeb}}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SetProductVisibility(context.Background(), tt.req)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetProductVisibility_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Product, error) {
+			return &Product{ID: id}, nil
+		},
+		GetVisibilityFunc: func(ctx context.Context, productID string) (*ProductVisibility, error) {
+			return &ProductVisibility{ProductID: productID, Channels: []string{}, CustomerGroups: []string{}}, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	resp, err := service.GetProductVisibility(context.Background(), &pb.GetProductVisibilityRequest{ProductId: "prod-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Visibility.Channels) != 0 || resp.Visibility.UpdatedAt != nil {
+		t.Errorf("Expected no rules, got %v", resp.Visibility)
+	}
+}
+
+func TestListProducts_Visibility(t *testing.T) {
+	var got ProductFilter
+	mockRepo := &MockRepository{
+		ListFunc: func(ctx context.Context, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			got = filter
+			return []*Product{}, 0, nil
+		},
+		SearchFunc: func(ctx context.Context, query string, page, pageSize int32, filter ProductFilter) ([]*Product, int32, error) {
+			got = filter
+			return []*Product{{ID: "prod-1"}}, 1, nil
+		},
+	}
+	service := setupService(mockRepo)
+
+	if _, err := service.ListProducts(context.Background(), &pb.ListProductsRequest{Channel: ChannelB2B, CustomerGroup: "wholesale"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Channel != ChannelB2B || got.CustomerGroup != "wholesale" {
+		t.Errorf("Unexpected filter %+v", got)
+	}
+
+	if _, err := service.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "lamp", Channel: ChannelMobile}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Channel != ChannelMobile || got.CustomerGroup != "" {
+		t.Errorf("Unexpected filter %+v", got)
+	}
+
+	for _, req := range []*pb.ListProductsRequest{{Channel: "KIOSK"}, {CustomerGroup: "wholesale"}} {
+		if _, err := service.ListProducts(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package catalog
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WithProductChanges enables WatchProducts with the hub product changes are published to
+func (s *Service) WithProductChanges(hub *ProductChangeHub) *Service {
+	s.changes = hub
+	return s
+}
+
+// WatchProducts pushes product changes to the caller until it disconnects.
+// The stream ends with UNAVAILABLE when changes may have been missed, so the
+// caller can resync with StreamProducts and watch again. Headers are sent as
+// soon as the caller is subscribed.
+func (s *Service) WatchProducts(req *pb.WatchProductsRequest, stream grpc.ServerStreamingServer[pb.ProductChangeEvent]) error {
+	ctx := stream.Context()
+	if s.changes == nil {
+		return status.Error(codes.Unimplemented, "product change stream is not enabled")
+	}
+
+	var watched map[string]bool
+	if len(req.ProductIds) > 0 {
+		watched = make(map[string]bool, len(req.ProductIds))
+		for _, id := range req.ProductIds {
+			watched[id] = true
+		}
+	}
+
+	sub := s.changes.Subscribe()
+	defer s.changes.Unsubscribe(sub)
+	s.log.Info(ctx, "Product watcher subscribed", map[string]interface{}{"product_ids": len(req.ProductIds)})
+
+	// Headers tell the caller the subscription is live, so it can catch up
+	// with StreamProducts without missing a change
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case change, ok := <-sub.Changes():
+			if !ok {
+				err := sub.Err()
+				if err == nil {
+					return nil
+				}
+				s.log.Warn(ctx, "Product watcher ended", map[string]interface{}{"error": err.Error()})
+				if errors.Is(err, ErrHubClosed) {
+					return status.Error(codes.Unavailable, "catalog service is shutting down")
+				}
+				return status.Error(codes.Unavailable, err.Error()+"; resync with StreamProducts")
+			}
+			if watched != nil && !watched[change.ProductID] {
+				continue
+			}
+			if err := stream.Send(toProtoProductChange(change, s.now())); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toProtoProductChange converts a product change to protobuf
+func toProtoProductChange(c ProductChange, now time.Time) *pb.ProductChangeEvent {
+	return &pb.ProductChangeEvent{
+		Type:      c.Type,
+		ProductId: c.ProductID,
+		ChangedAt: timestamppb.New(c.At),
+		Product:   toProtoProduct(c.Product, now),
+	}
+}
@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// changeStream records the events sent on a WatchProducts stream
+type changeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.ProductChangeEvent
+}
+
+func (s *changeStream) Context() context.Context { return s.ctx }
+
+func (s *changeStream) SendHeader(metadata.MD) error { return nil }
+
+func (s *changeStream) Send(e *pb.ProductChangeEvent) error {
+	s.sent <- e
+	return nil
+}
+
+// waitForSubscribers waits until the hub has n subscribers
+func waitForSubscribers(t *testing.T, hub *ProductChangeHub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		hub.mu.Lock()
+		count := len(hub.subs)
+		hub.mu.Unlock()
+		if count == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Expected %d subscribers", n)
+}
+
+func TestWatchProducts_FiltersAndInterrupts(t *testing.T) {
+	hub := NewProductChangeHub(10)
+	service := setupService(&MockRepository{}).WithProductChanges(hub)
+	stream := &changeStream{ctx: context.Background(), sent: make(chan *pb.ProductChangeEvent, 10)}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- service.WatchProducts(&pb.WatchProductsRequest{ProductIds: []string{"prod-1"}}, stream)
+	}()
+	waitForSubscribers(t, hub, 1)
+
+	hub.Publish(ProductChange{Type: ProductChangeUpdated, ProductID: "prod-2", Product: &Product{ID: "prod-2"}})
+	hub.Publish(ProductChange{Type: ProductChangeUpdated, ProductID: "prod-1", Product: &Product{ID: "prod-1", Name: "Lamp"}})
+	hub.Publish(ProductChange{Type: ProductChangeDeleted, ProductID: "prod-1"})
+	hub.Interrupt(ErrChangesLost)
+
+	if err := <-done; status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+	close(stream.sent)
+
+	var events []*pb.ProductChangeEvent
+	for e := range stream.sent {
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events for prod-1, got %v", events)
+	}
+	if events[0].Type != ProductChangeUpdated || events[0].Product.GetName() != "Lamp" {
+		t.Errorf("Unexpected update event %v", events[0])
+	}
+	if events[1].Type != ProductChangeDeleted || events[1].Product != nil {
+		t.Errorf("Unexpected delete event %v", events[1])
+	}
+}
+
+func TestWatchProducts_ClientCancel(t *testing.T) {
+	hub := NewProductChangeHub(10)
+	service := setupService(&MockRepository{}).WithProductChanges(hub)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &changeStream{ctx: ctx, sent: make(chan *pb.ProductChangeEvent, 10)}
+
+	done := make(chan error, 1)
+	go func() { done <- service.WatchProducts(&pb.WatchProductsRequest{}, stream) }()
+	waitForSubscribers(t, hub, 1)
+	cancel()
+
+	if err := <-done; status.Code(err) != codes.Canceled {
+		t.Errorf("Expected Canceled, got %v", err)
+	}
+	waitForSubscribers(t, hub, 0)
+}
+
+func TestWatchProducts_NotEnabled(t *testing.T) {
+	service := setupService(&MockRepository{})
+	stream := &changeStream{ctx: context.Background()}
+
+	if err := service.WatchProducts(&pb.WatchProductsRequest{}, stream); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented, got %v", err)
+	}
+}
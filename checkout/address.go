@@ -0,0 +1,76 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	addresspb "github.com/Ujjwaljain16/E-commerce-Backend/address/pb"
+)
+
+// ErrInvalidAddress is returned when the address service rejects a shipping
+// address
+var ErrInvalidAddress = errors.New("invalid shipping address")
+
+// AddressValidator checks shipping addresses before orders are accepted
+type AddressValidator interface {
+	// Validate returns the address standardized. It returns an error
+	// wrapping ErrInvalidAddress when the address is invalid.
+	Validate(ctx context.Context, addr *Address) (*Address, error)
+}
+
+type noAddressValidator struct{}
+
+// NewNoAddressValidator creates an AddressValidator that accepts every
+// address as given, for deployments without an address service
+func NewNoAddressValidator() AddressValidator {
+	return noAddressValidator{}
+}
+
+func (noAddressValidator) Validate(ctx context.Context, addr *Address) (*Address, error) {
+	return addr, nil
+}
+
+type grpcAddressValidator struct {
+	client addresspb.AddressServiceClient
+}
+
+// NewGRPCAddressValidator creates an AddressValidator backed by the address
+// service
+func NewGRPCAddressValidator(client addresspb.AddressServiceClient) AddressValidator {
+	return &grpcAddressValidator{client: client}
+}
+
+func (v *grpcAddressValidator) Validate(ctx context.Context, addr *Address) (*Address, error) {
+	resp, err := v.client.ValidateAddress(ctx, &addresspb.ValidateAddressRequest{
+		Address: &addresspb.Address{
+			Name:       addr.Name,
+			Street1:    addr.Street1,
+			Street2:    addr.Street2,
+			City:       addr.City,
+			State:      addr.State,
+			PostalCode: addr.PostalCode,
+			Country:    addr.Country,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Valid {
+		for _, issue := range resp.Issues {
+			if issue.Error {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidAddress, issue.Message)
+			}
+		}
+		return nil, ErrInvalidAddress
+	}
+	return &Address{
+		Name:       resp.Address.Name,
+		Street1:    resp.Address.Street1,
+		Street2:    resp.Address.Street2,
+		City:       resp.Address.City,
+		State:      resp.Address.State,
+		PostalCode: resp.Address.PostalCode,
+		Country:    resp.Address.Country,
+	}, nil
+}
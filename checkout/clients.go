@@ -0,0 +1,132 @@
+package checkout
+
+import (
+	"context"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+)
+
+// Order statuses set by checkout
+const (
+	orderStatusPaid      = "PAID"
+	orderStatusCancelled = "CANCELLED"
+)
+
+// Item is a cart line to check out
+type Item struct {
+	ProductID string
+	Quantity  int32
+}
+
+// PlacedOrder is an order created by checkout
+type PlacedOrder struct {
+	ID          string
+	Status      string
+	TotalAmount float64
+}
+
+// Inventory holds stock while a checkout is in progress
+type Inventory interface {
+	// Reserve holds stock for a checkout and returns the reservation ID
+	Reserve(ctx context.Context, item Item, reference string) (string, error)
+	Commit(ctx context.Context, reservationID string) error
+	Release(ctx context.Context, reservationID string) error
+	// Uncommit returns the stock of a committed reservation
+	Uncommit(ctx context.Context, reservationID string) error
+}
+
+// PriceTerms are who an order is priced for, where and in which currency
+type PriceTerms struct {
+	CustomerSegment string
+	Channel         string
+	Currency        string
+}
+
+// Orders creates orders and moves them through checkout
+type Orders interface {
+	Create(ctx context.Context, userID string, items []Item, terms PriceTerms) (*PlacedOrder, error)
+	MarkPaid(ctx context.Context, orderID string) error
+	Cancel(ctx context.Context, orderID string) error
+}
+
+type grpcInventory struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCInventory creates an Inventory backed by catalog stock reservations
+func NewGRPCInventory(client catalogpb.CatalogServiceClient) Inventory {
+	return &grpcInventory{client: client}
+}
+
+func (i *grpcInventory) Reserve(ctx context.Context, item Item, reference string) (string, error) {
+	resp, err := i.client.ReserveStock(ctx, &catalogpb.ReserveStockRequest{
+		ProductId: item.ProductID,
+		Quantity:  item.Quantity,
+		Reference: reference,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Reservation.Id, nil
+}
+
+func (i *grpcInventory) Commit(ctx context.Context, reservationID string) error {
+	_, err := i.client.CommitReservation(ctx, &catalogpb.CommitReservationRequest{ReservationId: reservationID})
+	return err
+}
+
+func (i *grpcInventory) Release(ctx context.Context, reservationID string) error {
+	_, err := i.client.ReleaseReservation(ctx, &catalogpb.ReleaseReservationRequest{ReservationId: reservationID})
+	return err
+}
+
+func (i *grpcInventory) Uncommit(ctx context.Context, reservationID string) error {
+	_, err := i.client.UncommitReservation(ctx, &catalogpb.UncommitReservationRequest{ReservationId: reservationID})
+	return err
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+func (o *grpcOrders) Create(ctx context.Context, userID string, items []Item, terms PriceTerms) (*PlacedOrder, error) {
+	req := &orderpb.CreateOrderRequest{
+		UserId:          userID,
+		Items:           make([]*orderpb.CartItem, len(items)),
+		CustomerSegment: terms.CustomerSegment,
+		Channel:         terms.Channel,
+		Currency:        terms.Currency,
+	}
+	for i, item := range items {
+		req.Items[i] = &orderpb.CartItem{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+
+	resp, err := o.client.CreateOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &PlacedOrder{
+		ID:          resp.Order.Id,
+		Status:      resp.Order.Status,
+		TotalAmount: resp.Order.TotalAmount,
+	}, nil
+}
+
+func (o *grpcOrders) MarkPaid(ctx context.Context, orderID string) error {
+	return o.setStatus(ctx, orderID, orderStatusPaid)
+}
+
+func (o *grpcOrders) Cancel(ctx context.Context, orderID string) error {
+	return o.setStatus(ctx, orderID, orderStatusCancelled)
+}
+
+func (o *grpcOrders) setStatus(ctx context.Context, orderID, status string) error {
+	_, err := o.client.UpdateOrderStatus(ctx, &orderpb.UpdateOrderStatusRequest{OrderId: orderID, Status: status})
+	return err
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	addresspb "github.com/Ujjwaljain16/E-commerce-Backend/address/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/checkout"
+	"github.com/Ujjwaljain16/E-commerce-Backend/checkout/pb"
+	fraudpb "github.com/Ujjwaljain16/E-commerce-Backend/fraud/pb"
+	loyaltypb "github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pb"
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/saga"
+	walletpb "github.com/Ujjwaljain16/E-commerce-Backend/wallet/pb"
+	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("checkout-service")
+	log.Info(ctx, "Starting Checkout Service", nil)
+
+	// Get configuration from environment
+	catalogAddr := getEnv("CATALOG_ADDR", "localhost:50052")
+	orderAddr := getEnv("ORDER_ADDR", "localhost:50053")
+	port := getEnv("PORT", "50054")
+	metricsPort := getEnv("METRICS_PORT", "9093")
+
+	paymentAddr := getEnv("PAYMENT_ADDR", "localhost:50055")
+	fraudAddr := os.Getenv("FRAUD_ADDR")
+	loyaltyAddr := os.Getenv("LOYALTY_ADDR")
+	walletAddr := os.Getenv("WALLET_ADDR")
+	addressAddr := os.Getenv("ADDRESS_ADDR")
+
+	// Stock is reserved through the catalog service and orders are created
+	// through the order service, whose RPCs need a SERVICE token
+	tokens := auth.TokenServiceFromEnv()
+	serviceTokens := auth.NewServiceTokens(tokens, "checkout-service")
+	catalogConn := dial(ctx, log, catalogAddr, grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()))
+	defer catalogConn.Close()
+	orderConn := dial(ctx, log, orderAddr, grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()))
+	defer orderConn.Close()
+
+	// Payments go through the payment service, or a local sandbox gateway in
+	// sandbox mode
+	var payments checkout.Payments
+	if getEnv("SANDBOX_MODE", "false") == "true" {
+		log.Info(ctx, "Sandbox mode: using sandbox payment gateway", nil)
+		payments = checkout.NewSandboxPayments()
+	} else {
+		paymentConn := dial(ctx, log, paymentAddr)
+		defer paymentConn.Close()
+		payments = checkout.NewGRPCPayments(paymentpb.NewPaymentServiceClient(paymentConn))
+	}
+
+	// Checkouts are screened by the fraud service when one is configured
+	fraud := checkout.NewNoFraudScreen()
+	if fraudAddr != "" {
+		fraudConn := dial(ctx, log, fraudAddr)
+		defer fraudConn.Close()
+		fraud = checkout.NewGRPCFraudScreen(fraudpb.NewFraudServiceClient(fraudConn))
+	}
+
+	// Loyalty points can be redeemed when a loyalty service is configured,
+	// with a SERVICE token
+	loyalty := checkout.NewNoLoyalty()
+	if loyaltyAddr != "" {
+		loyaltyConn := dial(ctx, log, loyaltyAddr, grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()))
+		defer loyaltyConn.Close()
+		loyalty = checkout.NewGRPCLoyalty(loyaltypb.NewLoyaltyServiceClient(loyaltyConn))
+	}
+
+	// Store credit can be spent when a wallet service is configured, with a
+	// SERVICE token
+	wallet := checkout.NewNoWallet()
+	if walletAddr != "" {
+		walletConn := dial(ctx, log, walletAddr, grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()))
+		defer walletConn.Close()
+		wallet = checkout.NewGRPCWallet(walletpb.NewWalletServiceClient(walletConn))
+	}
+
+	// Shipping addresses are validated when an address service is configured
+	addresses := checkout.NewNoAddressValidator()
+	if addressAddr != "" {
+		addressConn := dial(ctx, log, addressAddr)
+		defer addressConn.Close()
+		addresses = checkout.NewGRPCAddressValidator(addresspb.NewAddressServiceClient(addressConn))
+	}
+
+	coordinator, err := checkout.NewCoordinator(
+		checkout.NewGRPCInventory(catalogpb.NewCatalogServiceClient(catalogConn)),
+		checkout.NewGRPCOrders(orderpb.NewOrderServiceClient(orderConn)),
+		fraud,
+		loyalty,
+		wallet,
+		payments,
+		log,
+	)
+	if err != nil {
+		log.Error(ctx, "Failed to create checkout saga", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+	service := checkout.NewService(coordinator, addresses, log)
+
+	// With a database, checkout sagas are saved as they run and those left
+	// unfinished by a stopped instance are compensated
+	recoveryCtx, stopRecovery := context.WithCancel(ctx)
+	defer stopRecovery()
+	if dbURL := os.Getenv("DATABASE_URL"); dbURL != "" {
+		db, err := sql.Open("postgres", dbURL)
+		if err != nil {
+			log.Error(ctx, "Failed to connect to database", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			log.Error(ctx, "Failed to ping database", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+
+		coordinator.WithSagaStore(saga.NewPostgresStore(db, "checkout_sagas"))
+		interval := getEnvDuration("SAGA_RECOVERY_INTERVAL", time.Minute)
+		staleAfter := getEnvDuration("SAGA_STALE_AFTER", 10*time.Minute)
+		go coordinator.RunRecovery(recoveryCtx, interval, staleAfter)
+	}
+
+	// IP filtering: the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, nil)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Orders are placed for the customer of the caller's access token
+	authenticator := auth.NewInterceptor(tokens, auth.InterceptorConfig{
+		ExemptMethods: []string{"/"},
+		RequiredRoles: checkout.MethodRoles(),
+	})
+
+	// Create gRPC server with metrics, IP filter and auth interceptors
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("checkout-service"),
+			ipFilter.UnaryServerInterceptor(),
+			authenticator.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterCheckoutServiceServer(grpcServer, service)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("checkout.CheckoutService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Enable reflection for grpcurl/grpcui
+	reflection.Register(grpcServer)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start gRPC server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Error(ctx, "Failed to listen", map[string]interface{}{
+			"error": err.Error(),
+			"port":  port,
+		})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "Checkout Service listening", map[string]interface{}{
+		"port":         port,
+		"metrics_port": metricsPort,
+		"catalog_addr": catalogAddr,
+		"order_addr":   orderAddr,
+		"sandbox_mode": getEnv("SANDBOX_MODE", "false") == "true",
+		"fraud_screen": fraudAddr != "",
+		"loyalty":      loyaltyAddr != "",
+		"wallet":       walletAddr != "",
+		"address":      addressAddr != "",
+		"saga_store":   os.Getenv("DATABASE_URL") != "",
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		stopRecovery()
+		grpcServer.GracefulStop()
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+// dial creates a gRPC client connection to a service
+func dial(ctx context.Context, log *logger.Logger, addr string, opts ...grpc.DialOption) *grpc.ClientConn {
+	conn, err := grpc.NewClient(addr, append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)...)
+	if err != nil {
+		log.Error(ctx, "Failed to create client", map[string]interface{}{
+			"error": err.Error(),
+			"addr":  addr,
+		})
+		os.Exit(1)
+	}
+	return conn
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,112 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+
+	fraudpb "github.com/Ujjwaljain16/E-commerce-Backend/fraud/pb"
+)
+
+// ErrFraudDeclined is returned when fraud screening rejects a checkout
+var ErrFraudDeclined = errors.New("checkout declined by fraud screening")
+
+// fraudStatusRejected is the assessment status of a rejected checkout
+const fraudStatusRejected = "REJECTED"
+
+// Address is a billing or shipping address of a checkout
+type Address struct {
+	Name       string
+	Street1    string
+	Street2    string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
+// CustomerDetails is what a checkout is screened for fraud with. Everything
+// is optional unless a fraud service is configured, which requires Email.
+// Segment and Channel are not screened; they are passed on to price the
+// order.
+type CustomerDetails struct {
+	Email           string
+	IPAddress       string
+	BillingAddress  *Address
+	ShippingAddress *Address
+	Segment         string
+	Channel         string
+}
+
+// Screening asks the fraud service to score a checkout
+type Screening struct {
+	OrderID  string
+	UserID   string
+	Amount   float64
+	Currency string
+	CustomerDetails
+}
+
+// FraudScreen scores checkouts before their payment is authorized
+type FraudScreen interface {
+	// Screen scores a checkout. It returns ErrFraudDeclined when the checkout
+	// is rejected. A checkout held for manual review goes ahead; the payment
+	// service does not capture its payment until it is approved.
+	Screen(ctx context.Context, screening Screening) error
+}
+
+type noFraudScreen struct{}
+
+// NewNoFraudScreen creates a FraudScreen that passes every checkout, for
+// deployments without a fraud service
+func NewNoFraudScreen() FraudScreen {
+	return noFraudScreen{}
+}
+
+func (noFraudScreen) Screen(ctx context.Context, screening Screening) error {
+	return nil
+}
+
+type grpcFraudScreen struct {
+	client fraudpb.FraudServiceClient
+}
+
+// NewGRPCFraudScreen creates a FraudScreen backed by the fraud service
+func NewGRPCFraudScreen(client fraudpb.FraudServiceClient) FraudScreen {
+	return &grpcFraudScreen{client: client}
+}
+
+func (f *grpcFraudScreen) Screen(ctx context.Context, screening Screening) error {
+	resp, err := f.client.ScoreCheckout(ctx, &fraudpb.ScoreCheckoutRequest{
+		OrderId:         screening.OrderID,
+		UserId:          screening.UserID,
+		Email:           screening.Email,
+		IpAddress:       screening.IPAddress,
+		Amount:          screening.Amount,
+		Currency:        screening.Currency,
+		BillingAddress:  toFraudAddress(screening.BillingAddress),
+		ShippingAddress: toFraudAddress(screening.ShippingAddress),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Assessment.Status == fraudStatusRejected {
+		return ErrFraudDeclined
+	}
+	return nil
+}
+
+// toFraudAddress converts an optional address to the fraud service's protobuf
+func toFraudAddress(addr *Address) *fraudpb.Address {
+	if addr == nil {
+		return nil
+	}
+	return &fraudpb.Address{
+		Name:       addr.Name,
+		Street1:    addr.Street1,
+		Street2:    addr.Street2,
+		City:       addr.City,
+		State:      addr.State,
+		PostalCode: addr.PostalCode,
+		Country:    addr.Country,
+	}
+}
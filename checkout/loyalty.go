@@ -0,0 +1,76 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+
+	loyaltypb "github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pb"
+)
+
+// ErrLoyaltyDisabled is returned when points are redeemed in a deployment
+// without a loyalty service
+var ErrLoyaltyDisabled = errors.New("loyalty points cannot be redeemed")
+
+// PointsRedemption is loyalty points spent on an order
+type PointsRedemption struct {
+	ID     string
+	Points int64
+	// Value is what the points took off the order total
+	Value float64
+}
+
+// Loyalty redeems loyalty points towards checkouts
+type Loyalty interface {
+	// Redeem spends a customer's points on an order. The points must be worth
+	// less than orderTotal, so a payment is still authorized for the rest.
+	Redeem(ctx context.Context, userID, orderID string, points int64, orderTotal float64) (*PointsRedemption, error)
+	// Release gives back the points of a redemption whose checkout was undone
+	Release(ctx context.Context, redemptionID string) error
+}
+
+type noLoyalty struct{}
+
+// NewNoLoyalty creates a Loyalty that refuses every redemption, for
+// deployments without a loyalty service
+func NewNoLoyalty() Loyalty {
+	return noLoyalty{}
+}
+
+func (noLoyalty) Redeem(ctx context.Context, userID, orderID string, points int64, orderTotal float64) (*PointsRedemption, error) {
+	return nil, ErrLoyaltyDisabled
+}
+
+func (noLoyalty) Release(ctx context.Context, redemptionID string) error {
+	return nil
+}
+
+type grpcLoyalty struct {
+	client loyaltypb.LoyaltyServiceClient
+}
+
+// NewGRPCLoyalty creates a Loyalty backed by the loyalty service
+func NewGRPCLoyalty(client loyaltypb.LoyaltyServiceClient) Loyalty {
+	return &grpcLoyalty{client: client}
+}
+
+func (l *grpcLoyalty) Redeem(ctx context.Context, userID, orderID string, points int64, orderTotal float64) (*PointsRedemption, error) {
+	resp, err := l.client.RedeemPoints(ctx, &loyaltypb.RedeemPointsRequest{
+		UserId:     userID,
+		Points:     points,
+		OrderId:    orderID,
+		OrderTotal: orderTotal,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PointsRedemption{
+		ID:     resp.Redemption.Id,
+		Points: -resp.Redemption.Points,
+		Value:  resp.Redemption.Value,
+	}, nil
+}
+
+func (l *grpcLoyalty) Release(ctx context.Context, redemptionID string) error {
+	_, err := l.client.ReleaseRedemption(ctx, &loyaltypb.ReleaseRedemptionRequest{RedemptionId: redemptionID})
+	return err
+}
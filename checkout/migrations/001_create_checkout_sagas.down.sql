@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_checkout_sagas_active;
+DROP TABLE IF EXISTS checkout_sagas;
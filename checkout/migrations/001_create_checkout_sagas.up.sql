@@ -0,0 +1,16 @@
+-- State of checkout sagas, saved by pkg/saga as each step completes so that
+-- checkouts left unfinished by a stopped instance can be compensated
+CREATE TABLE IF NOT EXISTS checkout_sagas (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    steps JSONB NOT NULL DEFAULT '[]',
+    failed_step VARCHAR(100) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Recovery only scans sagas still in progress
+CREATE INDEX idx_checkout_sagas_active ON checkout_sagas(name, updated_at)
+    WHERE status IN ('RUNNING', 'COMPENSATING');
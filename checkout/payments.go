@@ -0,0 +1,127 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrPaymentDeclined is returned when the payment gateway declines an authorization
+	ErrPaymentDeclined = errors.New("payment declined")
+	// ErrPaymentMethodRequired is returned when points and store credit leave
+	// part of the order to pay and no payment method was given
+	ErrPaymentMethodRequired = errors.New("payment_method is required to pay what points and store credit do not cover")
+)
+
+// PaymentDetails is how the customer pays for a checkout
+type PaymentDetails struct {
+	// Method may be empty when points and store credit pay for the whole order
+	Method string
+	// Provider, Region and Currency are optional; the payment service picks
+	// the provider by region and has a default currency
+	Provider string
+	Region   string
+	Currency string
+	// Points are loyalty points to pay part of the order with; the payment
+	// is authorized for the rest
+	Points int64
+	// StoreCredit is the most store credit to pay with; what is left after
+	// points is paid with it first
+	StoreCredit float64
+}
+
+// Authorization asks the payment gateway to hold an amount for an order
+type Authorization struct {
+	OrderID string
+	UserID  string
+	Amount  float64
+	PaymentDetails
+}
+
+// Payments authorizes and voids payments for checkout
+type Payments interface {
+	// Authorize holds the amount on the payment method and returns the
+	// authorization ID. It returns ErrPaymentDeclined when the gateway
+	// refuses the payment.
+	Authorize(ctx context.Context, auth Authorization) (string, error)
+	// Void cancels an authorization that will not be captured
+	Void(ctx context.Context, authorizationID string) error
+}
+
+type grpcPayments struct {
+	client paymentpb.PaymentServiceClient
+}
+
+// NewGRPCPayments creates Payments backed by the payment service
+func NewGRPCPayments(client paymentpb.PaymentServiceClient) Payments {
+	return &grpcPayments{client: client}
+}
+
+func (p *grpcPayments) Authorize(ctx context.Context, auth Authorization) (string, error) {
+	resp, err := p.client.AuthorizePayment(ctx, &paymentpb.AuthorizePaymentRequest{
+		OrderId:       auth.OrderID,
+		UserId:        auth.UserID,
+		Amount:        auth.Amount,
+		Currency:      auth.Currency,
+		PaymentMethod: auth.Method,
+		Provider:      auth.Provider,
+		Region:        auth.Region,
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return "", ErrPaymentDeclined
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp.Payment.Id, nil
+}
+
+func (p *grpcPayments) Void(ctx context.Context, authorizationID string) error {
+	_, err := p.client.VoidPayment(ctx, &paymentpb.VoidPaymentRequest{PaymentId: authorizationID})
+	return err
+}
+
+// SandboxDeclinedPaymentMethod is the payment method the sandbox gateway declines
+const SandboxDeclinedPaymentMethod = "tok_declined"
+
+// SandboxPayments is a payment gateway for sandbox mode. It approves every
+// payment method except SandboxDeclinedPaymentMethod, makes no network calls
+// and records voided authorizations so tests can inspect them.
+type SandboxPayments struct {
+	mu     sync.Mutex
+	voided []string
+}
+
+// NewSandboxPayments creates a sandbox payment gateway
+func NewSandboxPayments() *SandboxPayments {
+	return &SandboxPayments{}
+}
+
+// Authorize approves the payment unless the payment method is the declined test token
+func (p *SandboxPayments) Authorize(ctx context.Context, auth Authorization) (string, error) {
+	if auth.Method == SandboxDeclinedPaymentMethod {
+		return "", ErrPaymentDeclined
+	}
+	return "auth_" + uuid.New().String(), nil
+}
+
+// Void records the voided authorization
+func (p *SandboxPayments) Void(ctx context.Context, authorizationID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.voided = append(p.voided, authorizationID)
+	return nil
+}
+
+// Voided returns the authorizations voided so far
+func (p *SandboxPayments) Voided() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.voided...)
+}
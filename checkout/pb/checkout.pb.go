@@ -0,0 +1,557 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: checkout/checkout.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CartItem is a product and quantity in the customer's cart
+type CartItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItem) Reset() {
+	*x = CartItem{}
+	mi := &file_checkout_checkout_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItem) ProtoMessage() {}
+
+func (x *CartItem) ProtoReflect() protoreflect.Message {
+	mi := &file_checkout_checkout_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
+func (*CartItem) Descriptor() ([]byte, []int) {
+	return file_checkout_checkout_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CartItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CartItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Address is a billing or shipping address
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Street1       string                 `protobuf:"bytes,2,opt,name=street1,proto3" json:"street1,omitempty"`
+	Street2       string                 `protobuf:"bytes,3,opt,name=street2,proto3" json:"street2,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	State         string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,7,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166 alpha-2 code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_checkout_checkout_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_checkout_checkout_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_checkout_checkout_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Address) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Address) GetStreet1() string {
+	if x != nil {
+		return x.Street1
+	}
+	return ""
+}
+
+func (x *Address) GetStreet2() string {
+	if x != nil {
+		return x.Street2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// PlaceOrder checks out a cart: it reserves stock, creates the order,
+// screens it for fraud, redeems loyalty points, authorizes payment for the
+// rest and marks the order paid. When a
+// step fails, the completed steps are undone and no order is placed.
+type PlaceOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	PaymentMethod   string                 `protobuf:"bytes,3,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`       // payment method token from the client-side payment form; optional when store credit pays for the order
+	PaymentProvider string                 `protobuf:"bytes,4,opt,name=payment_provider,json=paymentProvider,proto3" json:"payment_provider,omitempty"` // optional; stripe, razorpay or paypal
+	Region          string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`                                          // optional ISO 3166 country code used to route the payment
+	Currency        string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`                                      // optional ISO 4217 code; the payment service default when empty
+	// Fraud screening details. email is required when fraud screening is
+	// enabled; the rest are optional.
+	Email          string   `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	IpAddress      string   `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"` // the customer's IP address
+	BillingAddress *Address `protobuf:"bytes,9,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address,omitempty"`
+	// Validated and standardized by the address service, when one is
+	// configured, before the order is placed
+	ShippingAddress *Address `protobuf:"bytes,10,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	// Loyalty points to pay part of the order with; optional. They must be
+	// worth less than the order total.
+	RedeemPoints int64 `protobuf:"varint,11,opt,name=redeem_points,json=redeemPoints,proto3" json:"redeem_points,omitempty"`
+	// Most store credit to pay with; optional. It pays for what it can of the
+	// total left after points, and payment_method may be left empty when it
+	// covers all of it.
+	StoreCredit float64 `protobuf:"fixed64,12,opt,name=store_credit,json=storeCredit,proto3" json:"store_credit,omitempty"`
+	// Price terms passed on to the order service, which prices the order
+	// with them when it uses the pricing service; optional. The order is
+	// priced in currency.
+	CustomerSegment string `protobuf:"bytes,13,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"`
+	Channel         string `protobuf:"bytes,14,opt,name=channel,proto3" json:"channel,omitempty"` // such as web, app or pos
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_checkout_checkout_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_checkout_checkout_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_checkout_checkout_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlaceOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetPaymentProvider() string {
+	if x != nil {
+		return x.PaymentProvider
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetBillingAddress() *Address {
+	if x != nil {
+		return x.BillingAddress
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetRedeemPoints() int64 {
+	if x != nil {
+		return x.RedeemPoints
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetStoreCredit() float64 {
+	if x != nil {
+		return x.StoreCredit
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type PlaceOrderResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId       string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"` // reference recorded on the stock reservations
+	OrderId          string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status           string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // order status; PAID on success
+	TotalAmount      float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	AuthorizationId  string                 `protobuf:"bytes,5,opt,name=authorization_id,json=authorizationId,proto3" json:"authorization_id,omitempty"` // payment authorization to capture on fulfilment; empty when nothing was left to authorize
+	PointsRedeemed   int64                  `protobuf:"varint,6,opt,name=points_redeemed,json=pointsRedeemed,proto3" json:"points_redeemed,omitempty"`
+	PointsValue      float64                `protobuf:"fixed64,7,opt,name=points_value,json=pointsValue,proto3" json:"points_value,omitempty"`                // what the redeemed points took off the total
+	AmountAuthorized float64                `protobuf:"fixed64,8,opt,name=amount_authorized,json=amountAuthorized,proto3" json:"amount_authorized,omitempty"` // total_amount less points_value and store_credit_used
+	StoreCreditUsed  float64                `protobuf:"fixed64,9,opt,name=store_credit_used,json=storeCreditUsed,proto3" json:"store_credit_used,omitempty"`
+	ShippingAddress  *Address               `protobuf:"bytes,10,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"` // standardized by the address service; empty when none was given
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	mi := &file_checkout_checkout_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderResponse) ProtoMessage() {}
+
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_checkout_checkout_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_checkout_checkout_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlaceOrderResponse) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+func (x *PlaceOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PlaceOrderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PlaceOrderResponse) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *PlaceOrderResponse) GetAuthorizationId() string {
+	if x != nil {
+		return x.AuthorizationId
+	}
+	return ""
+}
+
+func (x *PlaceOrderResponse) GetPointsRedeemed() int64 {
+	if x != nil {
+		return x.PointsRedeemed
+	}
+	return 0
+}
+
+func (x *PlaceOrderResponse) GetPointsValue() float64 {
+	if x != nil {
+		return x.PointsValue
+	}
+	return 0
+}
+
+func (x *PlaceOrderResponse) GetAmountAuthorized() float64 {
+	if x != nil {
+		return x.AmountAuthorized
+	}
+	return 0
+}
+
+func (x *PlaceOrderResponse) GetStoreCreditUsed() float64 {
+	if x != nil {
+		return x.StoreCreditUsed
+	}
+	return 0
+}
+
+func (x *PlaceOrderResponse) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+var File_checkout_checkout_proto protoreflect.FileDescriptor
+
+const file_checkout_checkout_proto_rawDesc = "" +
+	"\n" +
+	"\x17checkout/checkout.proto\x12\bcheckout\"E\n" +
+	"\bCartItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xb6\x01\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\astreet1\x18\x02 \x01(\tR\astreet1\x12\x18\n" +
+	"\astreet2\x18\x03 \x01(\tR\astreet2\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\a \x01(\tR\acountry\"\x98\x04\n" +
+	"\x11PlaceOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12(\n" +
+	"\x05items\x18\x02 \x03(\v2\x12.checkout.CartItemR\x05items\x12%\n" +
+	"\x0epayment_method\x18\x03 \x01(\tR\rpaymentMethod\x12)\n" +
+	"\x10payment_provider\x18\x04 \x01(\tR\x0fpaymentProvider\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05email\x18\a \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\b \x01(\tR\tipAddress\x12:\n" +
+	"\x0fbilling_address\x18\t \x01(\v2\x11.checkout.AddressR\x0ebillingAddress\x12<\n" +
+	"\x10shipping_address\x18\n" +
+	" \x01(\v2\x11.checkout.AddressR\x0fshippingAddress\x12#\n" +
+	"\rredeem_points\x18\v \x01(\x03R\fredeemPoints\x12!\n" +
+	"\fstore_credit\x18\f \x01(\x01R\vstoreCredit\x12)\n" +
+	"\x10customer_segment\x18\r \x01(\tR\x0fcustomerSegment\x12\x18\n" +
+	"\achannel\x18\x0e \x01(\tR\achannel\"\x99\x03\n" +
+	"\x12PlaceOrderResponse\x12\x1f\n" +
+	"\vcheckout_id\x18\x01 \x01(\tR\n" +
+	"checkoutId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x01R\vtotalAmount\x12)\n" +
+	"\x10authorization_id\x18\x05 \x01(\tR\x0fauthorizationId\x12'\n" +
+	"\x0fpoints_redeemed\x18\x06 \x01(\x03R\x0epointsRedeemed\x12!\n" +
+	"\fpoints_value\x18\a \x01(\x01R\vpointsValue\x12+\n" +
+	"\x11amount_authorized\x18\b \x01(\x01R\x10amountAuthorized\x12*\n" +
+	"\x11store_credit_used\x18\t \x01(\x01R\x0fstoreCreditUsed\x12<\n" +
+	"\x10shipping_address\x18\n" +
+	" \x01(\v2\x11.checkout.AddressR\x0fshippingAddress2Z\n" +
+	"\x0fCheckoutService\x12G\n" +
+	"\n" +
+	"PlaceOrder\x12\x1b.checkout.PlaceOrderRequest\x1a\x1c.checkout.PlaceOrderResponseB8Z6github.com/Ujjwaljain16/E-commerce-Backend/checkout/pbb\x06proto3"
+
+var (
+	file_checkout_checkout_proto_rawDescOnce sync.Once
+	file_checkout_checkout_proto_rawDescData []byte
+)
+
+func file_checkout_checkout_proto_rawDescGZIP() []byte {
+	file_checkout_checkout_proto_rawDescOnce.Do(func() {
+		file_checkout_checkout_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_checkout_checkout_proto_rawDesc), len(file_checkout_checkout_proto_rawDesc)))
+	})
+	return file_checkout_checkout_proto_rawDescData
+}
+
+var file_checkout_checkout_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_checkout_checkout_proto_goTypes = []any{
+	(*CartItem)(nil),           // 0: checkout.CartItem
+	(*Address)(nil),            // 1: checkout.Address
+	(*PlaceOrderRequest)(nil),  // 2: checkout.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil), // 3: checkout.PlaceOrderResponse
+}
+var file_checkout_checkout_proto_depIdxs = []int32{
+	0, // 0: checkout.PlaceOrderRequest.items:type_name -> checkout.CartItem
+	1, // 1: checkout.PlaceOrderRequest.billing_address:type_name -> checkout.Address
+	1, // 2: checkout.PlaceOrderRequest.shipping_address:type_name -> checkout.Address
+	1, // 3: checkout.PlaceOrderResponse.shipping_address:type_name -> checkout.Address
+	2, // 4: checkout.CheckoutService.PlaceOrder:input_type -> checkout.PlaceOrderRequest
+	3, // 5: checkout.CheckoutService.PlaceOrder:output_type -> checkout.PlaceOrderResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_checkout_checkout_proto_init() }
+func file_checkout_checkout_proto_init() {
+	if File_checkout_checkout_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_checkout_checkout_proto_rawDesc), len(file_checkout_checkout_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_checkout_checkout_proto_goTypes,
+		DependencyIndexes: file_checkout_checkout_proto_depIdxs,
+		MessageInfos:      file_checkout_checkout_proto_msgTypes,
+	}.Build()
+	File_checkout_checkout_proto = out.File
+	file_checkout_checkout_proto_goTypes = nil
+	file_checkout_checkout_proto_depIdxs = nil
+}
@@ -0,0 +1,246 @@
+package checkout
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/saga"
+)
+
+// Checkout saga steps, in the order they run
+const (
+	StepReserveStock     = "reserve_stock"
+	StepCreateOrder      = "create_order"
+	StepScreenFraud      = "screen_fraud"
+	StepRedeemPoints     = "redeem_points"
+	StepDebitWallet      = "debit_wallet"
+	StepAuthorizePayment = "authorize_payment"
+	StepCommitStock      = "commit_stock"
+	StepMarkPaid         = "mark_paid"
+)
+
+// StepError reports the checkout step that failed. The steps before it have
+// been compensated by the time it is returned.
+type StepError = saga.StepError
+
+// Result is the outcome of a successful checkout
+type Result struct {
+	Order           *PlacedOrder
+	AuthorizationID string
+	// PointsRedeemed and PointsValue are the loyalty points spent on the
+	// order and what they took off it, StoreCreditUsed is the store credit
+	// spent on it and AmountAuthorized is the rest. AuthorizationID is empty
+	// when points and store credit paid for the whole order.
+	PointsRedeemed   int64
+	PointsValue      float64
+	StoreCreditUsed  float64
+	AmountAuthorized float64
+}
+
+// SagaName names checkout sagas in the saga store
+const SagaName = "checkout"
+
+// stepTimeout bounds each checkout step and compensation
+const stepTimeout = 30 * time.Second
+
+// Coordinator runs the checkout saga: reserve stock for every line, create
+// the order, screen it for fraud, redeem loyalty points, spend store credit,
+// authorize payment for the rest, commit the reservations and mark the order
+// paid. When a step fails the completed steps are undone in reverse order.
+type Coordinator struct {
+	inventory Inventory
+	orders    Orders
+	fraud     FraudScreen
+	loyalty   Loyalty
+	wallet    Wallet
+	payments  Payments
+	log       *logger.Logger
+	saga      *saga.Orchestrator
+}
+
+// NewCoordinator creates a checkout saga coordinator. Saga state is kept in
+// memory until WithSagaStore is called.
+func NewCoordinator(inventory Inventory, orders Orders, fraud FraudScreen, loyalty Loyalty, wallet Wallet, payments Payments, log *logger.Logger) (*Coordinator, error) {
+	c := &Coordinator{
+		inventory: inventory,
+		orders:    orders,
+		fraud:     fraud,
+		loyalty:   loyalty,
+		wallet:    wallet,
+		payments:  payments,
+		log:       log,
+	}
+	orchestrator, err := saga.NewOrchestrator(saga.Definition{
+		Name: SagaName,
+		Steps: []string{
+			StepReserveStock, StepCreateOrder, StepScreenFraud, StepRedeemPoints,
+			StepDebitWallet, StepAuthorizePayment, StepCommitStock, StepMarkPaid,
+		},
+		Compensations: map[string]saga.Compensation{
+			StepReserveStock: c.inventory.Release,
+			StepCreateOrder:  c.orders.Cancel,
+			// A rejected checkout's order is cancelled by the compensation
+			// of create_order
+			StepScreenFraud:      saga.NoCompensation,
+			StepRedeemPoints:     c.loyalty.Release,
+			StepDebitWallet:      c.wallet.Release,
+			StepAuthorizePayment: c.payments.Void,
+			StepCommitStock:      c.inventory.Uncommit,
+			// Nothing runs after the order is marked paid
+			StepMarkPaid: saga.NoCompensation,
+		},
+		StepTimeout: stepTimeout,
+	}, saga.NewMemoryStore(), log)
+	if err != nil {
+		return nil, err
+	}
+	c.saga = orchestrator
+	return c, nil
+}
+
+// WithSagaStore saves the state of checkout sagas in store, so that checkouts
+// left unfinished by a stopped instance are compensated by RunRecovery
+func (c *Coordinator) WithSagaStore(store saga.Store) *Coordinator {
+	c.saga = c.saga.WithStore(store)
+	return c
+}
+
+// RunRecovery compensates checkouts not updated for staleAfter every
+// interval until ctx is cancelled
+func (c *Coordinator) RunRecovery(ctx context.Context, interval, staleAfter time.Duration) {
+	c.saga.Run(ctx, interval, staleAfter)
+}
+
+// PlaceOrder checks out items for a user. checkoutID identifies the saga and
+// is recorded on the stock reservations so they can be traced back to the
+// checkout, and customer is what the checkout is screened for fraud with.
+func (c *Coordinator) PlaceOrder(ctx context.Context, checkoutID, userID string, items []Item, payment PaymentDetails, customer CustomerDetails) (*Result, error) {
+	var (
+		reservations = make([]string, len(items))
+		order        *PlacedOrder
+		redemption   *PointsRedemption
+		debit        *WalletDebit
+		authID       string
+		// amount is what is left to pay
+		amount float64
+	)
+
+	steps := make([]saga.Step, 0, 2*len(items)+6)
+	for i, item := range items {
+		steps = append(steps, saga.Step{Name: StepReserveStock, Action: func(ctx context.Context) (string, error) {
+			id, err := c.inventory.Reserve(ctx, item, checkoutID)
+			if err != nil {
+				return "", err
+			}
+			reservations[i] = id
+			return id, nil
+		}})
+	}
+
+	steps = append(steps, saga.Step{Name: StepCreateOrder, Action: func(ctx context.Context) (string, error) {
+		var err error
+		order, err = c.orders.Create(ctx, userID, items, PriceTerms{
+			CustomerSegment: customer.Segment,
+			Channel:         customer.Channel,
+			Currency:        payment.Currency,
+		})
+		if err != nil {
+			return "", err
+		}
+		amount = order.TotalAmount
+		return order.ID, nil
+	}})
+
+	steps = append(steps, saga.Step{Name: StepScreenFraud, Action: func(ctx context.Context) (string, error) {
+		return "", c.fraud.Screen(ctx, Screening{
+			OrderID:         order.ID,
+			UserID:          userID,
+			Amount:          order.TotalAmount,
+			Currency:        payment.Currency,
+			CustomerDetails: customer,
+		})
+	}})
+
+	if payment.Points > 0 {
+		steps = append(steps, saga.Step{Name: StepRedeemPoints, Action: func(ctx context.Context) (string, error) {
+			var err error
+			redemption, err = c.loyalty.Redeem(ctx, userID, order.ID, payment.Points, order.TotalAmount)
+			if err != nil {
+				return "", err
+			}
+			amount = roundCents(order.TotalAmount - redemption.Value)
+			return redemption.ID, nil
+		}})
+	}
+
+	// Store credit pays for what it can of the rest, up to what was asked
+	steps = append(steps, saga.Step{Name: StepDebitWallet, Action: func(ctx context.Context) (string, error) {
+		credit := roundCents(math.Min(payment.StoreCredit, amount))
+		if credit <= 0 {
+			return "", nil
+		}
+		var err error
+		debit, err = c.wallet.Debit(ctx, userID, order.ID, credit)
+		if err != nil {
+			return "", err
+		}
+		amount = roundCents(amount - debit.Amount)
+		return debit.ID, nil
+	}})
+
+	// Nothing is authorized when points and store credit paid for the order
+	steps = append(steps, saga.Step{Name: StepAuthorizePayment, Action: func(ctx context.Context) (string, error) {
+		if amount <= 0 {
+			return "", nil
+		}
+		if payment.Method == "" {
+			return "", ErrPaymentMethodRequired
+		}
+		var err error
+		authID, err = c.payments.Authorize(ctx, Authorization{
+			OrderID:        order.ID,
+			UserID:         userID,
+			Amount:         amount,
+			PaymentDetails: payment,
+		})
+		return authID, err
+	}})
+
+	// A committed reservation is no longer released by the compensation of
+	// reserve_stock, so its own compensation returns the stock
+	for i := range items {
+		steps = append(steps, saga.Step{Name: StepCommitStock, Action: func(ctx context.Context) (string, error) {
+			if err := c.inventory.Commit(ctx, reservations[i]); err != nil {
+				return "", err
+			}
+			return reservations[i], nil
+		}})
+	}
+
+	steps = append(steps, saga.Step{Name: StepMarkPaid, Action: func(ctx context.Context) (string, error) {
+		return "", c.orders.MarkPaid(ctx, order.ID)
+	}})
+
+	if err := c.saga.Execute(ctx, checkoutID, steps); err != nil {
+		return nil, err
+	}
+	order.Status = orderStatusPaid
+
+	c.log.Info(ctx, "Checkout completed", map[string]interface{}{"checkout_id": checkoutID, "order_id": order.ID, "user_id": userID})
+	result := &Result{Order: order, AuthorizationID: authID, AmountAuthorized: amount}
+	if redemption != nil {
+		result.PointsRedeemed = redemption.Points
+		result.PointsValue = redemption.Value
+	}
+	if debit != nil {
+		result.StoreCreditUsed = debit.Amount
+	}
+	return result, nil
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
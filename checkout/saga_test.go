@@ -0,0 +1,531 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// recorder collects the calls made to the fake dependencies in order
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) record(format string, args ...interface{}) {
+	r.calls = append(r.calls, fmt.Sprintf(format, args...))
+}
+
+// fakeInventory reserves stock as res-<product>, failing for products in fail
+// and commits of reservations in commitFail
+type fakeInventory struct {
+	rec        *recorder
+	fail       map[string]error
+	commitFail map[string]error
+	releaseErr error
+}
+
+func (f *fakeInventory) Reserve(ctx context.Context, item Item, reference string) (string, error) {
+	if err := f.fail[item.ProductID]; err != nil {
+		return "", err
+	}
+	f.rec.record("reserve %s x%d", item.ProductID, item.Quantity)
+	return "res-" + item.ProductID, nil
+}
+
+func (f *fakeInventory) Commit(ctx context.Context, reservationID string) error {
+	if err := f.commitFail[reservationID]; err != nil {
+		return err
+	}
+	f.rec.record("commit %s", reservationID)
+	return nil
+}
+
+func (f *fakeInventory) Release(ctx context.Context, reservationID string) error {
+	f.rec.record("release %s", reservationID)
+	return f.releaseErr
+}
+
+func (f *fakeInventory) Uncommit(ctx context.Context, reservationID string) error {
+	f.rec.record("uncommit %s", reservationID)
+	return nil
+}
+
+// fakeOrders creates order-1 priced at 10 per unit
+type fakeOrders struct {
+	rec       *recorder
+	createErr error
+	paidErr   error
+	terms     PriceTerms
+}
+
+func (f *fakeOrders) Create(ctx context.Context, userID string, items []Item, terms PriceTerms) (*PlacedOrder, error) {
+	f.terms = terms
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.rec.record("create order for %s", userID)
+	var total float64
+	for _, item := range items {
+		total += 10 * float64(item.Quantity)
+	}
+	return &PlacedOrder{ID: "order-1", Status: "PENDING", TotalAmount: total}, nil
+}
+
+func (f *fakeOrders) MarkPaid(ctx context.Context, orderID string) error {
+	if f.paidErr != nil {
+		return f.paidErr
+	}
+	f.rec.record("mark paid %s", orderID)
+	return nil
+}
+
+func (f *fakeOrders) Cancel(ctx context.Context, orderID string) error {
+	f.rec.record("cancel %s", orderID)
+	return nil
+}
+
+// fakePayments authorizes as auth-1 unless err is set
+type fakePayments struct {
+	rec *recorder
+	err error
+}
+
+func (f *fakePayments) Authorize(ctx context.Context, auth Authorization) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.rec.record("authorize %s %.2f", auth.OrderID, auth.Amount)
+	return "auth-1", nil
+}
+
+func (f *fakePayments) Void(ctx context.Context, authorizationID string) error {
+	f.rec.record("void %s", authorizationID)
+	return nil
+}
+
+// fakeFraud screens every checkout, declining them with err when it is set
+type fakeFraud struct {
+	rec  *recorder
+	err  error
+	last Screening
+}
+
+func (f *fakeFraud) Screen(ctx context.Context, screening Screening) error {
+	f.rec.record("screen %s %.2f", screening.OrderID, screening.Amount)
+	f.last = screening
+	return f.err
+}
+
+// fakeLoyalty redeems points at a cent each as red-1, failing with err when
+// it is set
+type fakeLoyalty struct {
+	rec *recorder
+	err error
+}
+
+func (f *fakeLoyalty) Redeem(ctx context.Context, userID, orderID string, points int64, orderTotal float64) (*PointsRedemption, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.rec.record("redeem %d points on %s", points, orderID)
+	return &PointsRedemption{ID: "red-1", Points: points, Value: float64(points) / 100}, nil
+}
+
+func (f *fakeLoyalty) Release(ctx context.Context, redemptionID string) error {
+	f.rec.record("release points %s", redemptionID)
+	return nil
+}
+
+// fakeWallet debits store credit as debit-1, failing with err when it is set
+type fakeWallet struct {
+	rec *recorder
+	err error
+}
+
+func (f *fakeWallet) Debit(ctx context.Context, userID, orderID string, amount float64) (*WalletDebit, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.rec.record("debit %.2f store credit on %s", amount, orderID)
+	return &WalletDebit{ID: "debit-1", Amount: amount}, nil
+}
+
+func (f *fakeWallet) Release(ctx context.Context, debitID string) error {
+	f.rec.record("release store credit %s", debitID)
+	return nil
+}
+
+// sagaFixture wires a coordinator to fakes sharing one recorder
+type sagaFixture struct {
+	rec       *recorder
+	inventory *fakeInventory
+	orders    *fakeOrders
+	fraud     *fakeFraud
+	loyalty   *fakeLoyalty
+	wallet    *fakeWallet
+	payments  *fakePayments
+}
+
+func newSagaFixture() *sagaFixture {
+	rec := &recorder{}
+	return &sagaFixture{
+		rec:       rec,
+		inventory: &fakeInventory{rec: rec, fail: map[string]error{}, commitFail: map[string]error{}},
+		orders:    &fakeOrders{rec: rec},
+		fraud:     &fakeFraud{rec: rec},
+		loyalty:   &fakeLoyalty{rec: rec},
+		wallet:    &fakeWallet{rec: rec},
+		payments:  &fakePayments{rec: rec},
+	}
+}
+
+func (f *sagaFixture) coordinator(t *testing.T) *Coordinator {
+	t.Helper()
+	c, err := NewCoordinator(f.inventory, f.orders, f.fraud, f.loyalty, f.wallet, f.payments, logger.New("checkout-test"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return c
+}
+
+var twoItems = []Item{{ProductID: "p1", Quantity: 2}, {ProductID: "p2", Quantity: 1}}
+
+var visa = PaymentDetails{Method: "tok_visa"}
+
+var shopper = CustomerDetails{Email: "ada@example.com"}
+
+func TestPlaceOrder_Success(t *testing.T) {
+	f := newSagaFixture()
+
+	result, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, visa, shopper)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Order.ID != "order-1" || result.Order.Status != orderStatusPaid || result.Order.TotalAmount != 30 || result.AuthorizationID != "auth-1" {
+		t.Errorf("Unexpected result %+v %+v", result, result.Order)
+	}
+
+	want := []string{
+		"reserve p1 x2",
+		"reserve p2 x1",
+		"create order for user-1",
+		"screen order-1 30.00",
+		"authorize order-1 30.00",
+		"commit res-p1",
+		"commit res-p2",
+		"mark paid order-1",
+	}
+	if !reflect.DeepEqual(f.rec.calls, want) {
+		t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, want)
+	}
+}
+
+func TestPlaceOrder_PassesPriceTerms(t *testing.T) {
+	f := newSagaFixture()
+
+	customer := CustomerDetails{Email: "ada@example.com", Segment: "wholesale", Channel: "app"}
+	_, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, PaymentDetails{Method: "tok_visa", Currency: "EUR"}, customer)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := (PriceTerms{CustomerSegment: "wholesale", Channel: "app", Currency: "EUR"}); f.orders.terms != want {
+		t.Errorf("Expected the order priced with %+v, got %+v", want, f.orders.terms)
+	}
+}
+
+func TestPlaceOrder_RedeemsPoints(t *testing.T) {
+	f := newSagaFixture()
+	payment := visa
+	payment.Points = 550
+
+	result, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, payment, shopper)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.PointsRedeemed != 550 || result.PointsValue != 5.5 || result.AmountAuthorized != 24.5 || result.Order.TotalAmount != 30 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	want := []string{
+		"reserve p1 x2",
+		"reserve p2 x1",
+		"create order for user-1",
+		"screen order-1 30.00",
+		"redeem 550 points on order-1",
+		"authorize order-1 24.50",
+		"commit res-p1",
+		"commit res-p2",
+		"mark paid order-1",
+	}
+	if !reflect.DeepEqual(f.rec.calls, want) {
+		t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, want)
+	}
+}
+
+func TestPlaceOrder_ReleasesPointsOnFailure(t *testing.T) {
+	f := newSagaFixture()
+	f.payments.err = ErrPaymentDeclined
+	payment := visa
+	payment.Points = 550
+
+	_, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, payment, shopper)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Step != StepAuthorizePayment {
+		t.Fatalf("Expected failure at %s, got %v", StepAuthorizePayment, err)
+	}
+
+	want := []string{
+		"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+		"redeem 550 points on order-1",
+		"release points red-1", "cancel order-1", "release res-p2", "release res-p1",
+	}
+	if !reflect.DeepEqual(f.rec.calls, want) {
+		t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, want)
+	}
+}
+
+func TestPlaceOrder_PaysWithStoreCredit(t *testing.T) {
+	f := newSagaFixture()
+	payment := visa
+	payment.Points = 550
+	payment.StoreCredit = 10
+
+	result, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, payment, shopper)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.StoreCreditUsed != 10 || result.AmountAuthorized != 14.5 || result.AuthorizationID != "auth-1" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	want := []string{
+		"reserve p1 x2",
+		"reserve p2 x1",
+		"create order for user-1",
+		"screen order-1 30.00",
+		"redeem 550 points on order-1",
+		"debit 10.00 store credit on order-1",
+		"authorize order-1 14.50",
+		"commit res-p1",
+		"commit res-p2",
+		"mark paid order-1",
+	}
+	if !reflect.DeepEqual(f.rec.calls, want) {
+		t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, want)
+	}
+}
+
+func TestPlaceOrder_PaysFullyWithStoreCredit(t *testing.T) {
+	f := newSagaFixture()
+
+	// Store credit beyond the total is not spent, and nothing is left to
+	// authorize, so no payment method is needed
+	result, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, PaymentDetails{StoreCredit: 100}, shopper)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.StoreCreditUsed != 30 || result.AmountAuthorized != 0 || result.AuthorizationID != "" || result.Order.Status != orderStatusPaid {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	want := []string{
+		"reserve p1 x2",
+		"reserve p2 x1",
+		"create order for user-1",
+		"screen order-1 30.00",
+		"debit 30.00 store credit on order-1",
+		"commit res-p1",
+		"commit res-p2",
+		"mark paid order-1",
+	}
+	if !reflect.DeepEqual(f.rec.calls, want) {
+		t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, want)
+	}
+}
+
+func TestPlaceOrder_StoreCreditShortWithoutPaymentMethod(t *testing.T) {
+	f := newSagaFixture()
+
+	_, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, PaymentDetails{StoreCredit: 20}, shopper)
+	if !errors.Is(err, ErrPaymentMethodRequired) {
+		t.Fatalf("Expected ErrPaymentMethodRequired, got %v", err)
+	}
+
+	want := []string{
+		"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+		"debit 20.00 store credit on order-1",
+		"release store credit debit-1", "cancel order-1", "release res-p2", "release res-p1",
+	}
+	if !reflect.DeepEqual(f.rec.calls, want) {
+		t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, want)
+	}
+}
+
+func TestPlaceOrder_Compensation(t *testing.T) {
+	failure := errors.New("boom")
+	tests := []struct {
+		name  string
+		setup func(f *sagaFixture)
+		step  string
+		want  []string
+	}{
+		{
+			name:  "second reservation fails",
+			setup: func(f *sagaFixture) { f.inventory.fail["p2"] = failure },
+			step:  StepReserveStock,
+			want:  []string{"reserve p1 x2", "release res-p1"},
+		},
+		{
+			name:  "order creation fails",
+			setup: func(f *sagaFixture) { f.orders.createErr = failure },
+			step:  StepCreateOrder,
+			want:  []string{"reserve p1 x2", "reserve p2 x1", "release res-p2", "release res-p1"},
+		},
+		{
+			name:  "fraud screening declines",
+			setup: func(f *sagaFixture) { f.fraud.err = ErrFraudDeclined },
+			step:  StepScreenFraud,
+			want: []string{
+				"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+				"cancel order-1", "release res-p2", "release res-p1",
+			},
+		},
+		{
+			name:  "points redemption fails",
+			setup: func(f *sagaFixture) { f.loyalty.err = failure },
+			step:  StepRedeemPoints,
+			want: []string{
+				"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+				"cancel order-1", "release res-p2", "release res-p1",
+			},
+		},
+		{
+			name:  "store credit debit fails",
+			setup: func(f *sagaFixture) { f.wallet.err = failure },
+			step:  StepDebitWallet,
+			want: []string{
+				"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+				"cancel order-1", "release res-p2", "release res-p1",
+			},
+		},
+		{
+			name:  "payment declined",
+			setup: func(f *sagaFixture) { f.payments.err = ErrPaymentDeclined },
+			step:  StepAuthorizePayment,
+			want: []string{
+				"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+				"cancel order-1", "release res-p2", "release res-p1",
+			},
+		},
+		{
+			name:  "first commit fails",
+			setup: func(f *sagaFixture) { f.inventory.commitFail["res-p1"] = failure },
+			step:  StepCommitStock,
+			want: []string{
+				"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+				"authorize order-1 30.00",
+				"void auth-1", "cancel order-1", "release res-p2", "release res-p1",
+			},
+		},
+		{
+			name:  "second commit fails",
+			setup: func(f *sagaFixture) { f.inventory.commitFail["res-p2"] = failure },
+			step:  StepCommitStock,
+			want: []string{
+				"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+				"authorize order-1 30.00",
+				"commit res-p1",
+				"uncommit res-p1", "void auth-1", "cancel order-1", "release res-p2", "release res-p1",
+			},
+		},
+		{
+			name:  "marking paid fails",
+			setup: func(f *sagaFixture) { f.orders.paidErr = failure },
+			step:  StepMarkPaid,
+			want: []string{
+				"reserve p1 x2", "reserve p2 x1", "create order for user-1", "screen order-1 30.00",
+				"authorize order-1 30.00",
+				"commit res-p1", "commit res-p2",
+				"uncommit res-p2", "uncommit res-p1", "void auth-1", "cancel order-1", "release res-p2", "release res-p1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSagaFixture()
+			tt.setup(f)
+
+			// Points are redeemed and store credit spent only when their
+			// steps are under test
+			payment := visa
+			switch tt.step {
+			case StepRedeemPoints:
+				payment.Points = 100
+			case StepDebitWallet:
+				payment.StoreCredit = 5
+			}
+			_, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, payment, shopper)
+			var stepErr *StepError
+			if !errors.As(err, &stepErr) || stepErr.Step != tt.step {
+				t.Fatalf("Expected failure at %s, got %v", tt.step, err)
+			}
+			if !reflect.DeepEqual(f.rec.calls, tt.want) {
+				t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceOrder_CompensationContinuesPastFailures(t *testing.T) {
+	f := newSagaFixture()
+	f.orders.createErr = errors.New("order service down")
+	f.inventory.releaseErr = errors.New("catalog down")
+
+	_, err := f.coordinator(t).PlaceOrder(context.Background(), "co-1", "user-1", twoItems, visa, shopper)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	want := []string{"reserve p1 x2", "reserve p2 x1", "release res-p2", "release res-p1"}
+	if !reflect.DeepEqual(f.rec.calls, want) {
+		t.Errorf("Unexpected calls\n got: %v\nwant: %v", f.rec.calls, want)
+	}
+}
+
+func TestPlaceOrder_CompensatesAfterCancellation(t *testing.T) {
+	f := newSagaFixture()
+	ctx, cancel := context.WithCancel(context.Background())
+	f.orders.createErr = context.Canceled
+	cancel()
+
+	var released []error
+	inventory := &cancelCheckingInventory{fakeInventory: f.inventory, released: &released}
+	coordinator, err := NewCoordinator(inventory, f.orders, f.fraud, f.loyalty, f.wallet, f.payments, logger.New("checkout-test"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := coordinator.PlaceOrder(ctx, "co-1", "user-1", twoItems, visa, shopper); err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(released) == 0 || released[0] != nil {
+		t.Errorf("Expected compensation to run with a live context, got %v", released)
+	}
+}
+
+// cancelCheckingInventory captures the state of the context reservations are
+// released with
+type cancelCheckingInventory struct {
+	*fakeInventory
+	released *[]error
+}
+
+func (i *cancelCheckingInventory) Release(ctx context.Context, reservationID string) error {
+	*i.released = append(*i.released, ctx.Err())
+	return i.fakeInventory.Release(ctx, reservationID)
+}
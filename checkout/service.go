@@ -0,0 +1,222 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/checkout/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxCartItems matches the order service's limit on lines per order
+const maxCartItems = 100
+
+// UserMethods are the RPCs acting for one customer, which need an access
+// token; the customer is the token's unless its role is ADMIN or SERVICE
+var UserMethods = []string{
+	pb.CheckoutService_PlaceOrder_FullMethodName,
+}
+
+// MethodRoles maps each of UserMethods to the roles allowed to call it
+func MethodRoles() map[string][]string {
+	roles := make(map[string][]string, len(UserMethods))
+	for _, m := range UserMethods {
+		roles[m] = []string{auth.RoleUser, auth.RoleAdmin, auth.RoleService}
+	}
+	return roles
+}
+
+// Service implements the CheckoutService gRPC interface
+type Service struct {
+	pb.UnimplementedCheckoutServiceServer
+	coordinator *Coordinator
+	addresses   AddressValidator
+	log         *logger.Logger
+}
+
+// NewService creates a new checkout service
+func NewService(coordinator *Coordinator, addresses AddressValidator, log *logger.Logger) *Service {
+	return &Service{
+		coordinator: coordinator,
+		addresses:   addresses,
+		log:         log,
+	}
+}
+
+// PlaceOrder checks out a cart. Either the order is placed and paid, or every
+// completed step is undone and an error describes the step that failed.
+func (s *Service) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "Place order failed: "+status.Convert(err).Message(), map[string]interface{}{"user_id": req.UserId})
+		return nil, err
+	}
+	if userID == "" {
+		s.log.Warn(ctx, "Place order failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.PaymentMethod == "" && req.StoreCredit == 0 {
+		s.log.Warn(ctx, "Place order failed: payment method is required", map[string]interface{}{"user_id": userID})
+		return nil, status.Error(codes.InvalidArgument, "payment_method is required")
+	}
+	if req.RedeemPoints < 0 {
+		s.log.Warn(ctx, "Place order failed: redeem points cannot be negative", map[string]interface{}{"user_id": userID})
+		return nil, status.Error(codes.InvalidArgument, "redeem_points cannot be negative")
+	}
+	if req.StoreCredit < 0 {
+		s.log.Warn(ctx, "Place order failed: store credit cannot be negative", map[string]interface{}{"user_id": userID})
+		return nil, status.Error(codes.InvalidArgument, "store_credit cannot be negative")
+	}
+	items, msg := itemsFromCart(req.Items)
+	if msg != "" {
+		s.log.Warn(ctx, "Place order failed: "+msg, map[string]interface{}{"user_id": userID})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	// The shipping address is standardized before the order is screened
+	shippingAddress := fromProtoAddress(req.ShippingAddress)
+	if shippingAddress != nil {
+		standard, err := s.addresses.Validate(ctx, shippingAddress)
+		if errors.Is(err, ErrInvalidAddress) {
+			s.log.Warn(ctx, "Place order failed: "+err.Error(), map[string]interface{}{"user_id": userID})
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err != nil {
+			s.log.Error(ctx, "Failed to validate shipping address", map[string]interface{}{"error": err.Error(), "user_id": userID})
+			return nil, status.Error(codes.Unavailable, "failed to validate shipping address")
+		}
+		shippingAddress = standard
+	}
+
+	checkoutID := uuid.New().String()
+	result, err := s.coordinator.PlaceOrder(ctx, checkoutID, userID, items, PaymentDetails{
+		Method:      req.PaymentMethod,
+		Provider:    req.PaymentProvider,
+		Region:      req.Region,
+		Currency:    req.Currency,
+		Points:      req.RedeemPoints,
+		StoreCredit: req.StoreCredit,
+	}, CustomerDetails{
+		Email:           req.Email,
+		IPAddress:       req.IpAddress,
+		BillingAddress:  fromProtoAddress(req.BillingAddress),
+		ShippingAddress: shippingAddress,
+		Segment:         req.CustomerSegment,
+		Channel:         req.Channel,
+	})
+	if err != nil {
+		return nil, s.checkoutError(ctx, err, checkoutID)
+	}
+
+	return &pb.PlaceOrderResponse{
+		CheckoutId:       checkoutID,
+		OrderId:          result.Order.ID,
+		Status:           result.Order.Status,
+		TotalAmount:      result.Order.TotalAmount,
+		AuthorizationId:  result.AuthorizationID,
+		PointsRedeemed:   result.PointsRedeemed,
+		PointsValue:      result.PointsValue,
+		AmountAuthorized: result.AmountAuthorized,
+		StoreCreditUsed:  result.StoreCreditUsed,
+		ShippingAddress:  toProtoAddress(shippingAddress),
+	}, nil
+}
+
+// itemsFromCart validates the lines of a cart and returns a message
+// describing the first problem, or ""
+func itemsFromCart(cart []*pb.CartItem) ([]Item, string) {
+	if len(cart) == 0 {
+		return nil, "items are required"
+	}
+	if len(cart) > maxCartItems {
+		return nil, fmt.Sprintf("a cart cannot have more than %d items", maxCartItems)
+	}
+	items := make([]Item, len(cart))
+	seen := make(map[string]bool, len(cart))
+	for i, line := range cart {
+		switch {
+		case line.ProductId == "":
+			return nil, "product_id is required"
+		case line.Quantity <= 0:
+			return nil, "quantity must be positive"
+		case seen[line.ProductId]:
+			return nil, "product " + line.ProductId + " appears more than once"
+		}
+		seen[line.ProductId] = true
+		items[i] = Item{ProductID: line.ProductId, Quantity: line.Quantity}
+	}
+	return items, ""
+}
+
+// fromProtoAddress converts an optional address from protobuf
+func fromProtoAddress(addr *pb.Address) *Address {
+	if addr == nil {
+		return nil
+	}
+	return &Address{
+		Name:       addr.Name,
+		Street1:    addr.Street1,
+		Street2:    addr.Street2,
+		City:       addr.City,
+		State:      addr.State,
+		PostalCode: addr.PostalCode,
+		Country:    addr.Country,
+	}
+}
+
+// toProtoAddress converts an optional address to protobuf
+func toProtoAddress(addr *Address) *pb.Address {
+	if addr == nil {
+		return nil
+	}
+	return &pb.Address{
+		Name:       addr.Name,
+		Street1:    addr.Street1,
+		Street2:    addr.Street2,
+		City:       addr.City,
+		State:      addr.State,
+		PostalCode: addr.PostalCode,
+		Country:    addr.Country,
+	}
+}
+
+// checkoutError maps a failed checkout to a gRPC status. Problems with the
+// cart reported by the catalog or order service are passed on to the caller;
+// outages are reported as Unavailable so the checkout can be retried.
+func (s *Service) checkoutError(ctx context.Context, err error, checkoutID string) error {
+	if errors.Is(err, ErrPaymentDeclined) {
+		return status.Error(codes.FailedPrecondition, "payment declined")
+	}
+	if errors.Is(err, ErrFraudDeclined) {
+		return status.Error(codes.FailedPrecondition, "checkout declined")
+	}
+	if errors.Is(err, ErrLoyaltyDisabled) {
+		return status.Error(codes.FailedPrecondition, ErrLoyaltyDisabled.Error())
+	}
+	if errors.Is(err, ErrWalletDisabled) {
+		return status.Error(codes.FailedPrecondition, ErrWalletDisabled.Error())
+	}
+	if errors.Is(err, ErrPaymentMethodRequired) {
+		return status.Error(codes.InvalidArgument, ErrPaymentMethodRequired.Error())
+	}
+
+	step := ""
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		step = stepErr.Step
+		if st, ok := status.FromError(stepErr.Err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument, codes.FailedPrecondition, codes.NotFound:
+				return status.Error(st.Code(), st.Message())
+			}
+		}
+	}
+
+	s.log.Error(ctx, "Checkout failed", map[string]interface{}{"error": err.Error(), "checkout_id": checkoutID, "step": step})
+	return status.Error(codes.Unavailable, "checkout failed; no order was placed, try again")
+}
@@ -0,0 +1,528 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	addresspb "github.com/Ujjwaljain16/E-commerce-Backend/address/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/checkout/pb"
+	fraudpb "github.com/Ujjwaljain16/E-commerce-Backend/fraud/pb"
+	loyaltypb "github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pb"
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	walletpb "github.com/Ujjwaljain16/E-commerce-Backend/wallet/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func setupService(t *testing.T, f *sagaFixture) *Service {
+	t.Helper()
+	return NewService(f.coordinator(t), NewNoAddressValidator(), logger.New("checkout-test"))
+}
+
+// callerContext returns a context carrying the claims of a token of userID
+// in role, as the auth interceptor leaves them
+func callerContext(userID, role string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: userID, Role: role})
+}
+
+func TestPlaceOrderRPC_Success(t *testing.T) {
+	f := newSagaFixture()
+	service := setupService(t, f)
+
+	resp, err := service.PlaceOrder(callerContext("user-1", auth.RoleUser), &pb.PlaceOrderRequest{
+		UserId:          "user-1",
+		Items:           []*pb.CartItem{{ProductId: "p1", Quantity: 3}},
+		PaymentMethod:   "tok_visa",
+		Email:           "ada@example.com",
+		IpAddress:       "203.0.113.7",
+		ShippingAddress: &pb.Address{City: "London", PostalCode: "SW1A 1AA", Country: "GB"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.CheckoutId == "" || resp.OrderId != "order-1" || resp.Status != orderStatusPaid || resp.TotalAmount != 30 || resp.AuthorizationId != "auth-1" {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	screened := f.fraud.last
+	if screened.UserID != "user-1" || screened.Email != "ada@example.com" || screened.IPAddress != "203.0.113.7" {
+		t.Errorf("Unexpected screening %+v", screened)
+	}
+	if screened.BillingAddress != nil || screened.ShippingAddress == nil || screened.ShippingAddress.Country != "GB" {
+		t.Errorf("Unexpected addresses %+v %+v", screened.BillingAddress, screened.ShippingAddress)
+	}
+}
+
+func TestPlaceOrderRPC_RedeemsPoints(t *testing.T) {
+	f := newSagaFixture()
+
+	resp, err := setupService(t, f).PlaceOrder(callerContext("user-1", auth.RoleUser), &pb.PlaceOrderRequest{
+		UserId:        "user-1",
+		Items:         []*pb.CartItem{{ProductId: "p1", Quantity: 3}},
+		PaymentMethod: "tok_visa",
+		RedeemPoints:  1000,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.TotalAmount != 30 || resp.PointsRedeemed != 1000 || resp.PointsValue != 10 || resp.AmountAuthorized != 20 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+}
+
+func TestPlaceOrderRPC_PaysWithStoreCredit(t *testing.T) {
+	f := newSagaFixture()
+
+	// No payment method is needed when store credit covers the order
+	resp, err := setupService(t, f).PlaceOrder(callerContext("user-1", auth.RoleUser), &pb.PlaceOrderRequest{
+		UserId:      "user-1",
+		Items:       []*pb.CartItem{{ProductId: "p1", Quantity: 3}},
+		StoreCredit: 50,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.TotalAmount != 30 || resp.StoreCreditUsed != 30 || resp.AmountAuthorized != 0 || resp.AuthorizationId != "" {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	// Otherwise the rest still needs one
+	_, err = setupService(t, newSagaFixture()).PlaceOrder(callerContext("user-1", auth.RoleUser), &pb.PlaceOrderRequest{
+		UserId:      "user-1",
+		Items:       []*pb.CartItem{{ProductId: "p1", Quantity: 3}},
+		StoreCredit: 10,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestPlaceOrderRPC_ActingUser(t *testing.T) {
+	item := []*pb.CartItem{{ProductId: "p1", Quantity: 1}}
+
+	f := newSagaFixture()
+	if _, err := setupService(t, f).PlaceOrder(callerContext("user-1", auth.RoleUser), &pb.PlaceOrderRequest{Items: item, PaymentMethod: "tok_visa"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(strings.Join(f.rec.calls, "; "), "create order for user-1") {
+		t.Errorf("Expected the order of the token's user, got %v", f.rec.calls)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		userID   string
+		expected codes.Code
+	}{
+		{"no token", context.Background(), "user-1", codes.Unauthenticated},
+		{"another user", callerContext("user-1", auth.RoleUser), "user-2", codes.PermissionDenied},
+		{"service naming no user", callerContext("storefront", auth.RoleService), "", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSagaFixture()
+			_, err := setupService(t, f).PlaceOrder(tt.ctx, &pb.PlaceOrderRequest{UserId: tt.userID, Items: item, PaymentMethod: "tok_visa"})
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if len(f.rec.calls) != 0 {
+				t.Errorf("Expected no saga steps, got %v", f.rec.calls)
+			}
+		})
+	}
+}
+
+func TestPlaceOrderRPC_InvalidRequest(t *testing.T) {
+	tooMany := make([]*pb.CartItem, maxCartItems+1)
+	for i := range tooMany {
+		tooMany[i] = &pb.CartItem{ProductId: fmt.Sprintf("p%d", i), Quantity: 1}
+	}
+	item := []*pb.CartItem{{ProductId: "p1", Quantity: 1}}
+
+	tests := []struct {
+		name string
+		req  *pb.PlaceOrderRequest
+	}{
+		{"missing payment method", &pb.PlaceOrderRequest{UserId: "user-1", Items: item}},
+		{"empty cart", &pb.PlaceOrderRequest{UserId: "user-1", PaymentMethod: "tok_visa"}},
+		{"too many items", &pb.PlaceOrderRequest{UserId: "user-1", Items: tooMany, PaymentMethod: "tok_visa"}},
+		{"missing product", &pb.PlaceOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{Quantity: 1}}, PaymentMethod: "tok_visa"}},
+		{"zero quantity", &pb.PlaceOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{ProductId: "p1"}}, PaymentMethod: "tok_visa"}},
+		{"duplicate product", &pb.PlaceOrderRequest{UserId: "user-1", Items: append(item, item[0]), PaymentMethod: "tok_visa"}},
+		{"negative points", &pb.PlaceOrderRequest{UserId: "user-1", Items: item, PaymentMethod: "tok_visa", RedeemPoints: -1}},
+		{"negative store credit", &pb.PlaceOrderRequest{UserId: "user-1", Items: item, PaymentMethod: "tok_visa", StoreCredit: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSagaFixture()
+			_, err := setupService(t, f).PlaceOrder(callerContext("user-1", auth.RoleUser), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+			if len(f.rec.calls) != 0 {
+				t.Errorf("Expected no saga steps, got %v", f.rec.calls)
+			}
+		})
+	}
+}
+
+func TestPlaceOrderRPC_StepFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(f *sagaFixture)
+		expected codes.Code
+		message  string
+	}{
+		{
+			name: "insufficient stock",
+			setup: func(f *sagaFixture) {
+				f.inventory.fail["p1"] = status.Error(codes.FailedPrecondition, "insufficient stock")
+			},
+			expected: codes.FailedPrecondition,
+			message:  "insufficient stock",
+		},
+		{
+			name: "quantity rule",
+			setup: func(f *sagaFixture) {
+				f.inventory.fail["p1"] = status.Error(codes.InvalidArgument, "quantity must be at least 5")
+			},
+			expected: codes.InvalidArgument,
+			message:  "quantity must be at least 5",
+		},
+		{
+			name:     "payment declined",
+			setup:    func(f *sagaFixture) { f.payments.err = ErrPaymentDeclined },
+			expected: codes.FailedPrecondition,
+			message:  "payment declined",
+		},
+		{
+			name:     "fraud screening declines",
+			setup:    func(f *sagaFixture) { f.fraud.err = ErrFraudDeclined },
+			expected: codes.FailedPrecondition,
+			message:  "checkout declined",
+		},
+		{
+			name:     "fraud screening needs an email",
+			setup:    func(f *sagaFixture) { f.fraud.err = status.Error(codes.InvalidArgument, "email is required") },
+			expected: codes.InvalidArgument,
+			message:  "email is required",
+		},
+		{
+			name:     "loyalty disabled",
+			setup:    func(f *sagaFixture) { f.loyalty.err = ErrLoyaltyDisabled },
+			expected: codes.FailedPrecondition,
+			message:  "loyalty points cannot be redeemed",
+		},
+		{
+			name:     "insufficient points",
+			setup:    func(f *sagaFixture) { f.loyalty.err = status.Error(codes.FailedPrecondition, "insufficient points") },
+			expected: codes.FailedPrecondition,
+			message:  "insufficient points",
+		},
+		{
+			name:     "wallet disabled",
+			setup:    func(f *sagaFixture) { f.wallet.err = ErrWalletDisabled },
+			expected: codes.FailedPrecondition,
+			message:  "store credit cannot be used",
+		},
+		{
+			name: "insufficient store credit",
+			setup: func(f *sagaFixture) {
+				f.wallet.err = status.Error(codes.FailedPrecondition, "insufficient store credit")
+			},
+			expected: codes.FailedPrecondition,
+			message:  "insufficient store credit",
+		},
+		{
+			name:     "order service down",
+			setup:    func(f *sagaFixture) { f.orders.createErr = status.Error(codes.Unavailable, "connection refused") },
+			expected: codes.Unavailable,
+		},
+		{
+			name:     "payment gateway error",
+			setup:    func(f *sagaFixture) { f.payments.err = errors.New("gateway timeout") },
+			expected: codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSagaFixture()
+			tt.setup(f)
+
+			_, err := setupService(t, f).PlaceOrder(callerContext("user-1", auth.RoleUser), &pb.PlaceOrderRequest{
+				UserId:        "user-1",
+				Items:         []*pb.CartItem{{ProductId: "p1", Quantity: 1}},
+				PaymentMethod: "tok_visa",
+				RedeemPoints:  100,
+				StoreCredit:   2,
+			})
+			st := status.Convert(err)
+			if st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if tt.message != "" && st.Message() != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, st.Message())
+			}
+		})
+	}
+}
+
+func TestSandboxPayments(t *testing.T) {
+	payments := NewSandboxPayments()
+
+	authID, err := payments.Authorize(context.Background(), Authorization{OrderID: "order-1", Amount: 10, PaymentDetails: visa})
+	if err != nil || authID == "" {
+		t.Fatalf("Expected an authorization, got %q, %v", authID, err)
+	}
+	if _, err := payments.Authorize(context.Background(), Authorization{PaymentDetails: PaymentDetails{Method: SandboxDeclinedPaymentMethod}}); !errors.Is(err, ErrPaymentDeclined) {
+		t.Errorf("Expected ErrPaymentDeclined, got %v", err)
+	}
+
+	if err := payments.Void(context.Background(), authID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if voided := payments.Voided(); len(voided) != 1 || voided[0] != authID {
+		t.Errorf("Unexpected voided authorizations %v", voided)
+	}
+}
+
+// fakePaymentClient answers AuthorizePayment with err, or with payment pay-1
+type fakePaymentClient struct {
+	paymentpb.PaymentServiceClient
+	err  error
+	last *paymentpb.AuthorizePaymentRequest
+}
+
+func (c *fakePaymentClient) AuthorizePayment(ctx context.Context, req *paymentpb.AuthorizePaymentRequest, opts ...grpc.CallOption) (*paymentpb.AuthorizePaymentResponse, error) {
+	c.last = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &paymentpb.AuthorizePaymentResponse{Payment: &paymentpb.Payment{Id: "pay-1"}}, nil
+}
+
+func TestGRPCPayments_Authorize(t *testing.T) {
+	client := &fakePaymentClient{}
+	payments := NewGRPCPayments(client)
+
+	id, err := payments.Authorize(context.Background(), Authorization{
+		OrderID:        "order-1",
+		Amount:         30,
+		PaymentDetails: PaymentDetails{Method: "pay_1", Provider: "razorpay", Region: "IN", Currency: "INR"},
+	})
+	if err != nil || id != "pay-1" {
+		t.Fatalf("Expected pay-1, got %q, %v", id, err)
+	}
+	if client.last.PaymentMethod != "pay_1" || client.last.Provider != "razorpay" || client.last.Region != "IN" || client.last.Currency != "INR" {
+		t.Errorf("Unexpected request %v", client.last)
+	}
+
+	client.err = status.Error(codes.FailedPrecondition, "payment declined")
+	if _, err := payments.Authorize(context.Background(), Authorization{}); !errors.Is(err, ErrPaymentDeclined) {
+		t.Errorf("Expected ErrPaymentDeclined, got %v", err)
+	}
+	client.err = status.Error(codes.Unavailable, "payment provider unavailable")
+	if _, err := payments.Authorize(context.Background(), Authorization{}); err == nil || errors.Is(err, ErrPaymentDeclined) {
+		t.Errorf("Expected a non-decline error, got %v", err)
+	}
+}
+
+// fakeFraudClient answers ScoreCheckout with an assessment in status
+type fakeFraudClient struct {
+	fraudpb.FraudServiceClient
+	status string
+	last   *fraudpb.ScoreCheckoutRequest
+}
+
+func (c *fakeFraudClient) ScoreCheckout(ctx context.Context, req *fraudpb.ScoreCheckoutRequest, opts ...grpc.CallOption) (*fraudpb.ScoreCheckoutResponse, error) {
+	c.last = req
+	return &fraudpb.ScoreCheckoutResponse{Assessment: &fraudpb.Assessment{OrderId: req.OrderId, Status: c.status}}, nil
+}
+
+func TestGRPCFraudScreen(t *testing.T) {
+	client := &fakeFraudClient{status: "APPROVED"}
+	screen := NewGRPCFraudScreen(client)
+	screening := Screening{
+		OrderID:  "order-1",
+		UserID:   "user-1",
+		Amount:   30,
+		Currency: "EUR",
+		CustomerDetails: CustomerDetails{
+			Email:          "ada@example.com",
+			BillingAddress: &Address{PostalCode: "75001", Country: "FR"},
+		},
+	}
+
+	if err := screen.Screen(context.Background(), screening); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.last.Email != "ada@example.com" || client.last.Currency != "EUR" || client.last.BillingAddress.Country != "FR" || client.last.ShippingAddress != nil {
+		t.Errorf("Unexpected request %v", client.last)
+	}
+
+	// A checkout held for review goes ahead
+	client.status = "PENDING_REVIEW"
+	if err := screen.Screen(context.Background(), screening); err != nil {
+		t.Errorf("Expected a held checkout to go ahead, got %v", err)
+	}
+	client.status = "REJECTED"
+	if err := screen.Screen(context.Background(), screening); !errors.Is(err, ErrFraudDeclined) {
+		t.Errorf("Expected ErrFraudDeclined, got %v", err)
+	}
+}
+
+// fakeLoyaltyClient redeems points at a cent each
+type fakeLoyaltyClient struct {
+	loyaltypb.LoyaltyServiceClient
+	redeemed *loyaltypb.RedeemPointsRequest
+	released string
+}
+
+func (c *fakeLoyaltyClient) RedeemPoints(ctx context.Context, req *loyaltypb.RedeemPointsRequest, opts ...grpc.CallOption) (*loyaltypb.RedeemPointsResponse, error) {
+	c.redeemed = req
+	return &loyaltypb.RedeemPointsResponse{Redemption: &loyaltypb.LedgerEntry{
+		Id:     "red-1",
+		Type:   "REDEEM",
+		Points: -req.Points,
+		Value:  float64(req.Points) / 100,
+	}}, nil
+}
+
+func (c *fakeLoyaltyClient) ReleaseRedemption(ctx context.Context, req *loyaltypb.ReleaseRedemptionRequest, opts ...grpc.CallOption) (*loyaltypb.ReleaseRedemptionResponse, error) {
+	c.released = req.RedemptionId
+	return &loyaltypb.ReleaseRedemptionResponse{}, nil
+}
+
+func TestGRPCLoyalty(t *testing.T) {
+	client := &fakeLoyaltyClient{}
+	loyalty := NewGRPCLoyalty(client)
+
+	redemption, err := loyalty.Redeem(context.Background(), "user-1", "order-1", 250, 30)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if redemption.ID != "red-1" || redemption.Points != 250 || redemption.Value != 2.5 {
+		t.Errorf("Unexpected redemption %+v", redemption)
+	}
+	if client.redeemed.UserId != "user-1" || client.redeemed.OrderId != "order-1" || client.redeemed.OrderTotal != 30 {
+		t.Errorf("Unexpected request %v", client.redeemed)
+	}
+
+	if err := loyalty.Release(context.Background(), "red-1"); err != nil || client.released != "red-1" {
+		t.Errorf("Expected red-1 to be released, got %q (%v)", client.released, err)
+	}
+}
+
+func TestNoLoyalty(t *testing.T) {
+	if _, err := NewNoLoyalty().Redeem(context.Background(), "user-1", "order-1", 100, 30); !errors.Is(err, ErrLoyaltyDisabled) {
+		t.Errorf("Expected ErrLoyaltyDisabled, got %v", err)
+	}
+}
+
+// fakeWalletClient debits store credit as debit-1
+type fakeWalletClient struct {
+	walletpb.WalletServiceClient
+	debited  *walletpb.DebitWalletRequest
+	released string
+}
+
+func (c *fakeWalletClient) DebitWallet(ctx context.Context, req *walletpb.DebitWalletRequest, opts ...grpc.CallOption) (*walletpb.DebitWalletResponse, error) {
+	c.debited = req
+	return &walletpb.DebitWalletResponse{Debit: &walletpb.Entry{
+		Id:     "debit-1",
+		Type:   "DEBIT",
+		Amount: -req.Amount,
+	}}, nil
+}
+
+func (c *fakeWalletClient) ReleaseDebit(ctx context.Context, req *walletpb.ReleaseDebitRequest, opts ...grpc.CallOption) (*walletpb.ReleaseDebitResponse, error) {
+	c.released = req.DebitId
+	return &walletpb.ReleaseDebitResponse{}, nil
+}
+
+func TestGRPCWallet(t *testing.T) {
+	client := &fakeWalletClient{}
+	wallet := NewGRPCWallet(client)
+
+	debit, err := wallet.Debit(context.Background(), "user-1", "order-1", 12.5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if debit.ID != "debit-1" || debit.Amount != 12.5 {
+		t.Errorf("Unexpected debit %+v", debit)
+	}
+	if client.debited.UserId != "user-1" || client.debited.OrderId != "order-1" || client.debited.Amount != 12.5 {
+		t.Errorf("Unexpected request %v", client.debited)
+	}
+
+	if err := wallet.Release(context.Background(), "debit-1"); err != nil || client.released != "debit-1" {
+		t.Errorf("Expected debit-1 to be released, got %q (%v)", client.released, err)
+	}
+}
+
+func TestNoWallet(t *testing.T) {
+	if _, err := NewNoWallet().Debit(context.Background(), "user-1", "order-1", 10); !errors.Is(err, ErrWalletDisabled) {
+		t.Errorf("Expected ErrWalletDisabled, got %v", err)
+	}
+}
+
+// fakeAddressClient is an address service client that accepts addresses in
+// GB, upper-casing their postal codes, and rejects the rest
+type fakeAddressClient struct {
+	addresspb.AddressServiceClient
+	err error
+}
+
+func (c *fakeAddressClient) ValidateAddress(ctx context.Context, req *addresspb.ValidateAddressRequest, opts ...grpc.CallOption) (*addresspb.ValidateAddressResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	addr := proto.Clone(req.Address).(*addresspb.Address)
+	if addr.Country != "GB" {
+		return &addresspb.ValidateAddressResponse{Address: addr, Issues: []*addresspb.Issue{
+			{Field: "country", Code: "UNKNOWN_COUNTRY", Message: "country " + addr.Country + " is not an ISO 3166 alpha-2 code", Error: true},
+		}}, nil
+	}
+	addr.PostalCode = strings.ToUpper(addr.PostalCode)
+	return &addresspb.ValidateAddressResponse{Valid: true, Address: addr, Confidence: 0.6}, nil
+}
+
+func TestPlaceOrderRPC_ValidatesShippingAddress(t *testing.T) {
+	f := newSagaFixture()
+	service := NewService(f.coordinator(t), NewGRPCAddressValidator(&fakeAddressClient{}), logger.New("checkout-test"))
+	req := &pb.PlaceOrderRequest{
+		UserId:          "user-1",
+		Items:           []*pb.CartItem{{ProductId: "p1", Quantity: 1}},
+		PaymentMethod:   "tok_visa",
+		ShippingAddress: &pb.Address{Street1: "10 Downing St", City: "London", PostalCode: "sw1a 2aa", Country: "GB"},
+	}
+
+	resp, err := service.PlaceOrder(callerContext("user-1", auth.RoleUser), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.ShippingAddress.GetPostalCode() != "SW1A 2AA" || f.fraud.last.ShippingAddress.PostalCode != "SW1A 2AA" {
+		t.Errorf("Expected the standardized address, got %v and %+v", resp.ShippingAddress, f.fraud.last.ShippingAddress)
+	}
+
+	req.ShippingAddress.Country = "XX"
+	_, err = service.PlaceOrder(callerContext("user-1", auth.RoleUser), req)
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "invalid shipping address") {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+
+	service = NewService(f.coordinator(t), NewGRPCAddressValidator(&fakeAddressClient{err: errors.New("connection refused")}), logger.New("checkout-test"))
+	_, err = service.PlaceOrder(callerContext("user-1", auth.RoleUser), req)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+
+	walletpb "github.com/Ujjwaljain16/E-commerce-Backend/wallet/pb"
+)
+
+// ErrWalletDisabled is returned when store credit is spent in a deployment
+// without a wallet service
+var ErrWalletDisabled = errors.New("store credit cannot be used")
+
+// WalletDebit is store credit spent on an order
+type WalletDebit struct {
+	ID     string
+	Amount float64
+}
+
+// Wallet spends customers' store credit at checkout
+type Wallet interface {
+	// Debit spends amount of a customer's store credit on an order
+	Debit(ctx context.Context, userID, orderID string, amount float64) (*WalletDebit, error)
+	// Release gives back the store credit of a debit whose checkout was undone
+	Release(ctx context.Context, debitID string) error
+}
+
+type noWallet struct{}
+
+// NewNoWallet creates a Wallet that refuses every debit, for deployments
+// without a wallet service
+func NewNoWallet() Wallet {
+	return noWallet{}
+}
+
+func (noWallet) Debit(ctx context.Context, userID, orderID string, amount float64) (*WalletDebit, error) {
+	return nil, ErrWalletDisabled
+}
+
+func (noWallet) Release(ctx context.Context, debitID string) error {
+	return nil
+}
+
+type grpcWallet struct {
+	client walletpb.WalletServiceClient
+}
+
+// NewGRPCWallet creates a Wallet backed by the wallet service
+func NewGRPCWallet(client walletpb.WalletServiceClient) Wallet {
+	return &grpcWallet{client: client}
+}
+
+func (w *grpcWallet) Debit(ctx context.Context, userID, orderID string, amount float64) (*WalletDebit, error) {
+	resp, err := w.client.DebitWallet(ctx, &walletpb.DebitWalletRequest{
+		UserId:  userID,
+		OrderId: orderID,
+		Amount:  amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WalletDebit{
+		ID:     resp.Debit.Id,
+		Amount: -resp.Debit.Amount,
+	}, nil
+}
+
+func (w *grpcWallet) Release(ctx context.Context, debitID string) error {
+	_, err := w.client.ReleaseDebit(ctx, &walletpb.ReleaseDebitRequest{DebitId: debitID})
+	return err
+}
@@ -0,0 +1,103 @@
+package dlq
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// DefaultTopics are the dead-letter topics of the services' event topics
+var DefaultTopics = []string{
+	kafka.DeadLetterTopic("account-events"),
+	kafka.DeadLetterTopic("catalog-events"),
+	kafka.DeadLetterTopic("order-events"),
+	kafka.DeadLetterTopic("cart-events"),
+}
+
+// maxSaveBackoff caps the wait between attempts to record a dead letter
+const maxSaveBackoff = 30 * time.Second
+
+// errNotDeadLetter is returned for a message on a dead-letter topic that was
+// not published as a dead letter
+var errNotDeadLetter = errors.New("message has no dead-letter headers")
+
+// FromMessage reads the dead letter published by kafka.DeadLetter from a
+// message consumed off a dead-letter topic
+func FromMessage(msg kafka.Message, receivedAt time.Time) (*DeadLetter, error) {
+	topic, group := msg.Headers[kafka.HeaderDeadLetterTopic], msg.Headers[kafka.HeaderDeadLetterGroup]
+	if topic == "" || group == "" {
+		return nil, errNotDeadLetter
+	}
+
+	d := &DeadLetter{
+		DLQTopic:      msg.Topic,
+		DLQPartition:  int32(msg.Partition),
+		DLQOffset:     msg.Offset,
+		Topic:         topic,
+		Partition:     -1,
+		Offset:        -1,
+		ConsumerGroup: group,
+		Key:           msg.Key,
+		Value:         msg.Value,
+		Headers:       make(map[string]string, len(msg.Headers)),
+		Error:         msg.Headers[kafka.HeaderDeadLetterError],
+		Status:        StatusNew,
+		FailedAt:      receivedAt,
+		ReceivedAt:    receivedAt,
+	}
+	if p, err := strconv.ParseInt(msg.Headers[kafka.HeaderDeadLetterPartition], 10, 32); err == nil {
+		d.Partition = int32(p)
+	}
+	if o, err := strconv.ParseInt(msg.Headers[kafka.HeaderDeadLetterOffset], 10, 64); err == nil {
+		d.Offset = o
+	}
+	if t, err := time.Parse(time.RFC3339Nano, msg.Headers[kafka.HeaderDeadLetterFailedAt]); err == nil {
+		d.FailedAt = t
+	}
+	for k, v := range msg.Headers {
+		if !strings.HasPrefix(k, "dlq-") {
+			d.Headers[k] = v
+		}
+	}
+	return d, nil
+}
+
+// EventHandler records the dead letters consumed from the dead-letter
+// topics. A dead letter that cannot be recorded is tried again, backing off
+// from backoff up to 30 seconds, until it is or ctx is cancelled, so no dead
+// letter is lost to a database outage.
+func EventHandler(repo Repository, backoff time.Duration, log *logger.Logger) kafka.Handler {
+	return func(ctx context.Context, msg kafka.Message) error {
+		d, err := FromMessage(msg, time.Now())
+		if err != nil {
+			return err
+		}
+
+		wait := backoff
+		for {
+			err := repo.Save(ctx, d)
+			if err == nil {
+				return nil
+			}
+			log.Warn(ctx, "Failed to record dead letter, retrying", map[string]interface{}{
+				"error": err.Error(),
+				"topic": d.Topic,
+				"retry": wait.String(),
+			})
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			if wait *= 2; wait > maxSaveBackoff {
+				wait = maxSaveBackoff
+			}
+		}
+	}
+}
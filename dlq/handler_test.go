@@ -0,0 +1,73 @@
+package dlq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// deadLetterMessage is a failed catalog event as kafka.DeadLetter publishes it
+func deadLetterMessage(failedAt time.Time) kafka.Message {
+	consumed := kafka.Message{
+		Topic: "catalog-events", Key: "prod-1", Value: []byte(`{}`), Partition: 2, Offset: 41,
+		Headers: map[string]string{"event-id": "evt-1"},
+	}
+	msg := kafka.DeadLetter(consumed, "image-service", errors.New("catalog unavailable"), failedAt)
+	msg.Partition, msg.Offset = 0, 7
+	return msg
+}
+
+func TestFromMessage(t *testing.T) {
+	failedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	received := failedAt.Add(time.Minute)
+
+	d, err := FromMessage(deadLetterMessage(failedAt), received)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if d.DLQTopic != "catalog-events.dlq" || d.DLQOffset != 7 || d.Topic != "catalog-events" || d.Partition != 2 || d.Offset != 41 ||
+		d.ConsumerGroup != "image-service" || d.Error != "catalog unavailable" || !d.FailedAt.Equal(failedAt) || d.Status != StatusNew {
+		t.Errorf("Unexpected dead letter %+v", d)
+	}
+	if len(d.Headers) != 1 || d.Headers["event-id"] != "evt-1" {
+		t.Errorf("Expected only the message's own headers, got %v", d.Headers)
+	}
+
+	if _, err := FromMessage(kafka.Message{Topic: "catalog-events.dlq"}, received); err == nil {
+		t.Error("Expected a message without dead-letter headers to be refused")
+	}
+}
+
+func TestEventHandler_RetriesUntilSaved(t *testing.T) {
+	repo := newMemoryRepository()
+	repo.saveErrs = []error{errors.New("connection refused"), errors.New("connection refused")}
+	handle := EventHandler(repo, time.Millisecond, logger.New("dlq-test"))
+
+	msg := deadLetterMessage(time.Now())
+	if err := handle(context.Background(), msg); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Read again after a restart
+	if err := handle(context.Background(), msg); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(repo.deadLetters) != 1 {
+		t.Errorf("Expected the dead letter to be recorded once, got %d", len(repo.deadLetters))
+	}
+}
+
+func TestEventHandler_StopsWhenCancelled(t *testing.T) {
+	repo := newMemoryRepository()
+	repo.saveErrs = []error{errors.New("connection refused")}
+	handle := EventHandler(repo, time.Hour, logger.New("dlq-test"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := handle(ctx, deadLetterMessage(time.Now())); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
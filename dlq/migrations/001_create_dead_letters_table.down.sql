@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_dead_letters_topic_group;
+DROP INDEX IF EXISTS idx_dead_letters_failed_at;
+DROP TABLE IF EXISTS dead_letters;
@@ -0,0 +1,26 @@
+-- Messages consumers failed to handle, read from the dead-letter topics. A
+-- dead letter is recorded once per position on its dead-letter topic, so
+-- reading a dead-letter topic again does not duplicate it.
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id UUID PRIMARY KEY,
+    dlq_topic VARCHAR(255) NOT NULL,
+    dlq_partition INTEGER NOT NULL,
+    dlq_offset BIGINT NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    partition INTEGER NOT NULL,
+    "offset" BIGINT NOT NULL,
+    consumer_group VARCHAR(255) NOT NULL,
+    key TEXT NOT NULL DEFAULT '',
+    value BYTEA,
+    headers JSONB NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'NEW' CHECK (status IN ('NEW', 'REQUEUED')),
+    requeue_count INTEGER NOT NULL DEFAULT 0,
+    failed_at TIMESTAMP NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    requeued_at TIMESTAMP,
+    UNIQUE (dlq_topic, dlq_partition, dlq_offset)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letters_failed_at ON dead_letters(failed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_dead_letters_topic_group ON dead_letters(topic, consumer_group);
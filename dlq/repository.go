@@ -0,0 +1,238 @@
+// Package dlq keeps the messages consumers failed to handle, read from the
+// dead-letter topics, for operators to inspect and requeue.
+package dlq
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Statuses of dead letters
+const (
+	StatusNew = "NEW"
+	// StatusRequeued is a dead letter published back to its topic
+	StatusRequeued = "REQUEUED"
+)
+
+// ErrDeadLetterNotFound is returned when a dead letter does not exist
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a message a consumer group failed to handle
+type DeadLetter struct {
+	ID string
+	// DLQTopic, DLQPartition and DLQOffset are where the dead letter was
+	// read from; a dead letter is recorded once
+	DLQTopic     string
+	DLQPartition int32
+	DLQOffset    int64
+	// Topic, Partition and Offset are where the message was consumed from
+	Topic         string
+	Partition     int32
+	Offset        int64
+	ConsumerGroup string
+	Key           string
+	Value         []byte
+	// Headers are the message's headers, without the dead-letter headers
+	Headers      map[string]string
+	Error        string
+	Status       string
+	RequeueCount int32
+	FailedAt     time.Time
+	ReceivedAt   time.Time
+	RequeuedAt   *time.Time
+}
+
+// Filter narrows a listing of dead letters; empty fields match everything
+type Filter struct {
+	Topic         string
+	ConsumerGroup string
+	Status        string
+}
+
+// Repository handles dead letter persistence
+type Repository interface {
+	// Save records a dead letter, unless the one at the same position of the
+	// dead-letter topic has been recorded before
+	Save(ctx context.Context, d *DeadLetter) error
+	GetByID(ctx context.Context, id string) (*DeadLetter, error)
+	// List lists dead letters, most recently failed first
+	List(ctx context.Context, filter Filter, page, pageSize int32) ([]*DeadLetter, int32, error)
+	// MarkRequeued marks a dead letter REQUEUED at at and counts the requeue
+	MarkRequeued(ctx context.Context, id string, at time.Time) (*DeadLetter, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL dead letter repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const deadLetterColumns = `id, dlq_topic, dlq_partition, dlq_offset, topic, partition, "offset", consumer_group, key, value,
+	headers, error, status, requeue_count, failed_at, received_at, requeued_at`
+
+// Save inserts a dead letter; a dead letter read again after a consumer
+// restart is ignored
+func (r *postgresRepository) Save(ctx context.Context, d *DeadLetter) error {
+	headers, err := json.Marshal(d.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter headers: %w", err)
+	}
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO dead_letters (id, dlq_topic, dlq_partition, dlq_offset, topic, partition, "offset", consumer_group,
+			key, value, headers, error, status, requeue_count, failed_at, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (dlq_topic, dlq_partition, dlq_offset) DO NOTHING
+	`, d.ID, d.DLQTopic, d.DLQPartition, d.DLQOffset, d.Topic, d.Partition, d.Offset, d.ConsumerGroup,
+		d.Key, d.Value, headers, d.Error, d.Status, d.RequeueCount, d.FailedAt, d.ReceivedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to save dead letter", map[string]interface{}{"error": err.Error(), "topic": d.Topic, "group": d.ConsumerGroup})
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n > 0 {
+		r.log.Info(ctx, "Dead letter recorded", map[string]interface{}{"dead_letter_id": d.ID, "topic": d.Topic, "group": d.ConsumerGroup})
+	}
+	return nil
+}
+
+// GetByID retrieves a dead letter
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*DeadLetter, error) {
+	query := "SELECT " + deadLetterColumns + " FROM dead_letters WHERE id = $1"
+
+	d, err := scanDeadLetter(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get dead letter", map[string]interface{}{"error": err.Error(), "dead_letter_id": id})
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return d, nil
+}
+
+// List retrieves dead letters with pagination
+func (r *postgresRepository) List(ctx context.Context, filter Filter, page, pageSize int32) ([]*DeadLetter, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+	where := `($1 = '' OR topic = $1) AND ($2 = '' OR consumer_group = $2) AND ($3 = '' OR status = $3)`
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dead_letters WHERE "+where,
+		filter.Topic, filter.ConsumerGroup, filter.Status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count dead letters", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	query := `
+		SELECT ` + deadLetterColumns + `
+		FROM dead_letters
+		WHERE ` + where + `
+		ORDER BY failed_at DESC, id
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.Topic, filter.ConsumerGroup, filter.Status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list dead letters", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	deadLetters, err := scanDeadLetters(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deadLetters, total, nil
+}
+
+// MarkRequeued records that a dead letter was published back to its topic
+func (r *postgresRepository) MarkRequeued(ctx context.Context, id string, at time.Time) (*DeadLetter, error) {
+	query := `
+		UPDATE dead_letters
+		SET status = 'REQUEUED', requeue_count = requeue_count + 1, requeued_at = $2
+		WHERE id = $1
+		RETURNING ` + deadLetterColumns
+
+	d, err := scanDeadLetter(r.db.QueryRowContext(ctx, query, id, at))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to mark dead letter requeued", map[string]interface{}{"error": err.Error(), "dead_letter_id": id})
+		return nil, fmt.Errorf("failed to mark dead letter requeued: %w", err)
+	}
+	r.log.Info(ctx, "Dead letter requeued", map[string]interface{}{"dead_letter_id": id, "topic": d.Topic, "group": d.ConsumerGroup})
+	return d, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanDeadLetter scans a dead letter selected with deadLetterColumns
+func scanDeadLetter(row rowScanner) (*DeadLetter, error) {
+	d := &DeadLetter{}
+	var headers []byte
+	var requeuedAt sql.NullTime
+
+	err := row.Scan(&d.ID, &d.DLQTopic, &d.DLQPartition, &d.DLQOffset, &d.Topic, &d.Partition, &d.Offset, &d.ConsumerGroup,
+		&d.Key, &d.Value, &headers, &d.Error, &d.Status, &d.RequeueCount, &d.FailedAt, &d.ReceivedAt, &requeuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(headers, &d.Headers); err != nil {
+		return nil, fmt.Errorf("failed to decode dead letter headers: %w", err)
+	}
+	if requeuedAt.Valid {
+		d.RequeuedAt = &requeuedAt.Time
+	}
+	return d, nil
+}
+
+// scanDeadLetters scans and closes rows of dead letters
+func scanDeadLetters(rows *sql.Rows) ([]*DeadLetter, error) {
+	defer rows.Close()
+
+	var deadLetters []*DeadLetter
+	for rows.Next() {
+		d, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		deadLetters = append(deadLetters, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dead letters: %w", err)
+	}
+	return deadLetters, nil
+}
@@ -0,0 +1,124 @@
+package dlq
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("dlq-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var deadLetterColumnNames = []string{
+	"id", "dlq_topic", "dlq_partition", "dlq_offset", "topic", "partition", "offset", "consumer_group", "key", "value",
+	"headers", "error", "status", "requeue_count", "failed_at", "received_at", "requeued_at",
+}
+
+func TestSave_IgnoresDuplicates(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	d := &DeadLetter{DLQTopic: "catalog-events.dlq", DLQPartition: 0, DLQOffset: 7, Topic: "catalog-events", Partition: 2, Offset: 41,
+		ConsumerGroup: "image-service", Key: "prod-1", Value: []byte(`{}`), Headers: map[string]string{"type": "product.image_uploaded"},
+		Error: "boom", Status: StatusNew, FailedAt: now, ReceivedAt: now}
+
+	mock.ExpectExec(`INSERT INTO dead_letters .* ON CONFLICT \(dlq_topic, dlq_partition, dlq_offset\) DO NOTHING`).
+		WithArgs(sqlmock.AnyArg(), "catalog-events.dlq", int32(0), int64(7), "catalog-events", int32(2), int64(41), "image-service",
+			"prod-1", []byte(`{}`), []byte(`{"type":"product.image_uploaded"}`), "boom", StatusNew, int32(0), now, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.Save(context.Background(), d); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if d.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM dead_letters WHERE id = \$1`).
+		WithArgs("dl-1").
+		WillReturnRows(sqlmock.NewRows(deadLetterColumnNames).AddRow(
+			"dl-1", "catalog-events.dlq", 0, 7, "catalog-events", 2, 41, "image-service", "prod-1", []byte(`{}`),
+			[]byte(`{"type":"product.image_uploaded"}`), "boom", StatusRequeued, 1, now, now, now))
+	mock.ExpectQuery(`SELECT .* FROM dead_letters WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	d, err := repo.GetByID(context.Background(), "dl-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if d.Headers["type"] != "product.image_uploaded" || d.RequeuedAt == nil || d.RequeueCount != 1 || d.Offset != 41 {
+		t.Errorf("Unexpected dead letter %+v", d)
+	}
+
+	if _, err := repo.GetByID(context.Background(), "not-a-uuid"); err != ErrDeadLetterNotFound {
+		t.Errorf("Expected ErrDeadLetterNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestList_Filtered(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM dead_letters WHERE`).
+		WithArgs("catalog-events", "", StatusNew).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`SELECT .* FROM dead_letters .* ORDER BY failed_at DESC, id LIMIT \$4 OFFSET \$5`).
+		WithArgs("catalog-events", "", StatusNew, int32(100), int32(100)).
+		WillReturnRows(sqlmock.NewRows(deadLetterColumnNames))
+
+	deadLetters, total, err := repo.List(context.Background(), Filter{Topic: "catalog-events", Status: StatusNew}, 2, 500)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 0 || len(deadLetters) != 0 {
+		t.Errorf("Expected no dead letters, got %d of %d", len(deadLetters), total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkRequeued_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE dead_letters SET status = 'REQUEUED', requeue_count = requeue_count \+ 1, requeued_at = \$2 WHERE id = \$1`).
+		WithArgs("dl-1", now).
+		WillReturnRows(sqlmock.NewRows(deadLetterColumnNames))
+
+	if _, err := repo.MarkRequeued(context.Background(), "dl-1", now); err != ErrDeadLetterNotFound {
+		t.Errorf("Expected ErrDeadLetterNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package dlq
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/dlq/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminMethods are the admin-scoped RPCs of the dead letter service; they are
+// restricted to the admin network allowlist. Every RPC is for operators.
+var AdminMethods = []string{
+	pb.DeadLetterService_ListDeadLetters_FullMethodName,
+	pb.DeadLetterService_GetDeadLetter_FullMethodName,
+	pb.DeadLetterService_RequeueDeadLetter_FullMethodName,
+}
+
+// Service implements the DeadLetterService gRPC interface
+type Service struct {
+	pb.UnimplementedDeadLetterServiceServer
+	repo      Repository
+	publisher kafka.Publisher
+	log       *logger.Logger
+	now       func() time.Time
+}
+
+// NewService creates a new dead letter service; requeued messages are
+// published with publisher
+func NewService(repo Repository, publisher kafka.Publisher, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		publisher: publisher,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// ListDeadLetters lists dead letters without their values and headers
+func (s *Service) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	if req.Status != "" && req.Status != StatusNew && req.Status != StatusRequeued {
+		return nil, status.Error(codes.InvalidArgument, "status must be NEW or REQUEUED")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	filter := Filter{Topic: req.Topic, ConsumerGroup: req.ConsumerGroup, Status: req.Status}
+	deadLetters, total, err := s.repo.List(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list dead letters")
+	}
+
+	protoDeadLetters := make([]*pb.DeadLetter, len(deadLetters))
+	for i, d := range deadLetters {
+		protoDeadLetters[i] = toProtoDeadLetter(d, false)
+	}
+	return &pb.ListDeadLettersResponse{
+		DeadLetters: protoDeadLetters,
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+	}, nil
+}
+
+// GetDeadLetter returns a dead letter with its value and headers
+func (s *Service) GetDeadLetter(ctx context.Context, req *pb.GetDeadLetterRequest) (*pb.GetDeadLetterResponse, error) {
+	if req.DeadLetterId == "" {
+		return nil, status.Error(codes.InvalidArgument, "dead_letter_id is required")
+	}
+
+	d, err := s.repo.GetByID(ctx, req.DeadLetterId)
+	if err != nil {
+		return nil, s.getError(err)
+	}
+	return &pb.GetDeadLetterResponse{DeadLetter: toProtoDeadLetter(d, true)}, nil
+}
+
+// RequeueDeadLetter publishes a dead letter back to its topic, addressed to
+// the consumer group that failed on it so that the topic's other groups do
+// not handle it twice, and marks it REQUEUED. A dead letter can be requeued
+// more than once; one that fails again comes back as a new dead letter.
+func (s *Service) RequeueDeadLetter(ctx context.Context, req *pb.RequeueDeadLetterRequest) (*pb.RequeueDeadLetterResponse, error) {
+	if req.DeadLetterId == "" {
+		s.log.Warn(ctx, "Requeue dead letter failed: dead letter ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "dead_letter_id is required")
+	}
+
+	d, err := s.repo.GetByID(ctx, req.DeadLetterId)
+	if err != nil {
+		return nil, s.getError(err)
+	}
+
+	msg := kafka.Requeue(d.Topic, d.Key, d.Value, d.Headers, d.ConsumerGroup)
+	if err := s.publisher.Publish(ctx, msg); err != nil {
+		s.log.Error(ctx, "Failed to requeue dead letter", map[string]interface{}{
+			"error":          err.Error(),
+			"dead_letter_id": d.ID,
+			"topic":          d.Topic,
+		})
+		return nil, status.Error(codes.Unavailable, "failed to publish the dead letter")
+	}
+
+	d, err = s.repo.MarkRequeued(ctx, d.ID, s.now())
+	if err != nil {
+		return nil, s.getError(err)
+	}
+	return &pb.RequeueDeadLetterResponse{DeadLetter: toProtoDeadLetter(d, true)}, nil
+}
+
+// getError maps a repository error to a gRPC status
+func (s *Service) getError(err error) error {
+	if err == ErrDeadLetterNotFound {
+		return status.Error(codes.NotFound, "dead letter not found")
+	}
+	return status.Error(codes.Internal, "failed to get dead letter")
+}
+
+// toProtoDeadLetter converts a dead letter to protobuf, with its value and
+// headers when full
+func toProtoDeadLetter(d *DeadLetter, full bool) *pb.DeadLetter {
+	deadLetter := &pb.DeadLetter{
+		Id:            d.ID,
+		Topic:         d.Topic,
+		Partition:     d.Partition,
+		Offset:        d.Offset,
+		ConsumerGroup: d.ConsumerGroup,
+		Key:           d.Key,
+		Error:         d.Error,
+		Status:        d.Status,
+		RequeueCount:  d.RequeueCount,
+		FailedAt:      timestamppb.New(d.FailedAt),
+		ReceivedAt:    timestamppb.New(d.ReceivedAt),
+	}
+	if full {
+		deadLetter.Value = d.Value
+		deadLetter.Headers = d.Headers
+	}
+	if d.RequeuedAt != nil {
+		deadLetter.RequeuedAt = timestamppb.New(*d.RequeuedAt)
+	}
+	return deadLetter
+}
@@ -0,0 +1,230 @@
+package dlq
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/dlq/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps dead letters by ID and fails the next saves with
+// saveErrs
+type memoryRepository struct {
+	mu          sync.Mutex
+	deadLetters map[string]DeadLetter
+	// saveErrs fail the next saves, one error each
+	saveErrs []error
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{deadLetters: make(map[string]DeadLetter)}
+}
+
+func (r *memoryRepository) Save(ctx context.Context, d *DeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.saveErrs) > 0 {
+		err := r.saveErrs[0]
+		r.saveErrs = r.saveErrs[1:]
+		return err
+	}
+	for _, existing := range r.deadLetters {
+		if existing.DLQTopic == d.DLQTopic && existing.DLQPartition == d.DLQPartition && existing.DLQOffset == d.DLQOffset {
+			return nil
+		}
+	}
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	r.deadLetters[d.ID] = *d
+	return nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id string) (*DeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.deadLetters[id]
+	if !ok {
+		return nil, ErrDeadLetterNotFound
+	}
+	return &d, nil
+}
+
+func (r *memoryRepository) List(ctx context.Context, filter Filter, page, pageSize int32) ([]*DeadLetter, int32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []*DeadLetter
+	for _, d := range r.deadLetters {
+		if (filter.Topic == "" || d.Topic == filter.Topic) && (filter.ConsumerGroup == "" || d.ConsumerGroup == filter.ConsumerGroup) &&
+			(filter.Status == "" || d.Status == filter.Status) {
+			d := d
+			matched = append(matched, &d)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].FailedAt.After(matched[j].FailedAt) })
+
+	total := int32(len(matched))
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return matched[start:end], total, nil
+}
+
+func (r *memoryRepository) MarkRequeued(ctx context.Context, id string, at time.Time) (*DeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.deadLetters[id]
+	if !ok {
+		return nil, ErrDeadLetterNotFound
+	}
+	d.Status = StatusRequeued
+	d.RequeueCount++
+	d.RequeuedAt = &at
+	r.deadLetters[id] = d
+	return &d, nil
+}
+
+func (r *memoryRepository) Close() error {
+	return nil
+}
+
+// fakePublisher records published messages, or fails with err
+type fakePublisher struct {
+	published []kafka.Message
+	err       error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, msgs ...kafka.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, msgs...)
+	return nil
+}
+
+func setupService(t *testing.T) (*Service, *memoryRepository, *fakePublisher) {
+	repo := newMemoryRepository()
+	publisher := &fakePublisher{}
+	return NewService(repo, publisher, logger.New("dlq-test")), repo, publisher
+}
+
+// saveDeadLetter records a NEW dead letter of group that failed at failedAt
+func saveDeadLetter(t *testing.T, repo *memoryRepository, group string, offset int64, failedAt time.Time) *DeadLetter {
+	d := &DeadLetter{
+		DLQTopic: "catalog-events.dlq", DLQOffset: offset, Topic: "catalog-events", Partition: 1, Offset: offset * 10,
+		ConsumerGroup: group, Key: "prod-1", Value: []byte(`{"type":"product.image_uploaded"}`),
+		Headers: map[string]string{"event-id": "evt-1"}, Error: "catalog unavailable", Status: StatusNew,
+		FailedAt: failedAt, ReceivedAt: failedAt,
+	}
+	if err := repo.Save(context.Background(), d); err != nil {
+		t.Fatalf("Failed to save dead letter: %v", err)
+	}
+	return d
+}
+
+func TestListDeadLetters(t *testing.T) {
+	service, repo, _ := setupService(t)
+	now := time.Now()
+	saveDeadLetter(t, repo, "image-service", 1, now.Add(-time.Hour))
+	latest := saveDeadLetter(t, repo, "image-service", 2, now)
+	saveDeadLetter(t, repo, "search-indexer", 3, now)
+
+	resp, err := service.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{ConsumerGroup: "image-service"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || len(resp.DeadLetters) != 2 || resp.DeadLetters[0].Id != latest.ID || resp.PageSize != 10 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+	if resp.DeadLetters[0].Value != nil || resp.DeadLetters[0].Headers != nil {
+		t.Error("Expected listings to leave out values and headers")
+	}
+
+	_, err = service.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{Status: "FAILED"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetDeadLetter(t *testing.T) {
+	service, repo, _ := setupService(t)
+	d := saveDeadLetter(t, repo, "image-service", 1, time.Now())
+
+	resp, err := service.GetDeadLetter(context.Background(), &pb.GetDeadLetterRequest{DeadLetterId: d.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(resp.DeadLetter.Value) != string(d.Value) || resp.DeadLetter.Headers["event-id"] != "evt-1" || resp.DeadLetter.Error != d.Error {
+		t.Errorf("Unexpected dead letter %v", resp.DeadLetter)
+	}
+
+	_, err = service.GetDeadLetter(context.Background(), &pb.GetDeadLetterRequest{DeadLetterId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestRequeueDeadLetter(t *testing.T) {
+	service, repo, publisher := setupService(t)
+	d := saveDeadLetter(t, repo, "image-service", 1, time.Now())
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	resp, err := service.RequeueDeadLetter(context.Background(), &pb.RequeueDeadLetterRequest{DeadLetterId: d.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.DeadLetter.Status != StatusRequeued || resp.DeadLetter.RequeueCount != 1 || !resp.DeadLetter.RequeuedAt.AsTime().Equal(now) {
+		t.Errorf("Unexpected dead letter %v", resp.DeadLetter)
+	}
+
+	if len(publisher.published) != 1 {
+		t.Fatalf("Expected one message published, got %d", len(publisher.published))
+	}
+	msg := publisher.published[0]
+	if msg.Topic != "catalog-events" || msg.Key != "prod-1" || string(msg.Value) != string(d.Value) ||
+		msg.Headers[kafka.HeaderRequeuedFor] != "image-service" || msg.Headers["event-id"] != "evt-1" {
+		t.Errorf("Unexpected requeued message %+v", msg)
+	}
+}
+
+func TestRequeueDeadLetter_Refused(t *testing.T) {
+	service, repo, publisher := setupService(t)
+	d := saveDeadLetter(t, repo, "image-service", 1, time.Now())
+
+	_, err := service.RequeueDeadLetter(context.Background(), &pb.RequeueDeadLetterRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+	_, err = service.RequeueDeadLetter(context.Background(), &pb.RequeueDeadLetterRequest{DeadLetterId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	publisher.err = errors.New("kafka unavailable")
+	_, err = service.RequeueDeadLetter(context.Background(), &pb.RequeueDeadLetterRequest{DeadLetterId: d.ID})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+	if stored, _ := repo.GetByID(context.Background(), d.ID); stored.Status != StatusNew {
+		t.Errorf("Expected the dead letter to stay NEW, got %s", stored.Status)
+	}
+}
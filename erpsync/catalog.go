@@ -0,0 +1,79 @@
+package erpsync
+
+import (
+	"context"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+)
+
+const (
+	// lookupBatchSize is the most SKUs GetProductsBySKUs resolves at once
+	lookupBatchSize = 100
+	// applyBatchSize is the most updates ApplyInventoryUpdates takes at once
+	applyBatchSize = 500
+)
+
+// Product is the catalog's current stock and price for a SKU
+type Product struct {
+	ID    string
+	Stock int32
+	Price float64
+}
+
+// Catalog reads and updates catalog stock and prices by SKU
+type Catalog interface {
+	// Lookup resolves up to lookupBatchSize current or former SKUs; SKUs
+	// matching no product are missing from the result
+	Lookup(ctx context.Context, skus []string) (map[string]*Product, error)
+	// Apply sets the stock and price of up to applyBatchSize records and
+	// returns the outcome of each, in the order of records. The batch is
+	// applied or, on error, none of it.
+	Apply(ctx context.Context, records []*Record) ([]*Item, error)
+}
+
+type grpcCatalog struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCCatalog creates a Catalog backed by the catalog service
+func NewGRPCCatalog(client catalogpb.CatalogServiceClient) Catalog {
+	return &grpcCatalog{client: client}
+}
+
+func (c *grpcCatalog) Lookup(ctx context.Context, skus []string) (map[string]*Product, error) {
+	resp, err := c.client.GetProductsBySKUs(ctx, &catalogpb.GetProductsBySKUsRequest{Skus: skus})
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[string]*Product, len(resp.Matches))
+	for _, m := range resp.Matches {
+		products[m.Sku] = &Product{ID: m.Product.Id, Stock: m.Product.Stock, Price: m.Product.Price}
+	}
+	return products, nil
+}
+
+func (c *grpcCatalog) Apply(ctx context.Context, records []*Record) ([]*Item, error) {
+	req := &catalogpb.ApplyInventoryUpdatesRequest{Updates: make([]*catalogpb.InventoryUpdate, len(records))}
+	for i, r := range records {
+		req.Updates[i] = &catalogpb.InventoryUpdate{Sku: r.SKU, SetStock: r.SetStock, Stock: r.Stock, Price: r.Price}
+	}
+
+	resp, err := c.client.ApplyInventoryUpdates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*Item, len(resp.Results))
+	for i, r := range resp.Results {
+		items[i] = &Item{
+			SKU:       r.Sku,
+			ProductID: r.ProductId,
+			Status:    r.Status,
+			Message:   r.Message,
+			OldStock:  r.OldStock,
+			NewStock:  r.NewStock,
+			OldPrice:  r.OldPrice,
+			NewPrice:  r.NewPrice,
+		}
+	}
+	return items, nil
+}
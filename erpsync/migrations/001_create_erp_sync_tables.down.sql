@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_erp_sync_run_items_run;
+DROP TABLE IF EXISTS erp_sync_run_items;
+DROP INDEX IF EXISTS idx_erp_sync_runs_started_at;
+DROP INDEX IF EXISTS idx_erp_sync_runs_running;
+DROP TABLE IF EXISTS erp_sync_runs;
@@ -0,0 +1,43 @@
+-- ERP sync runs and their counts. At most one run is RUNNING at a time; a
+-- run left RUNNING by a process that died is marked FAILED by the next run.
+CREATE TABLE IF NOT EXISTS erp_sync_runs (
+    id UUID PRIMARY KEY,
+    source VARCHAR(20) NOT NULL,
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('SCHEDULED', 'MANUAL')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    records INT NOT NULL DEFAULT 0,
+    updated INT NOT NULL DEFAULT 0,
+    unchanged INT NOT NULL DEFAULT 0,
+    not_found INT NOT NULL DEFAULT 0,
+    rejected INT NOT NULL DEFAULT 0,
+    invalid INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    CHECK ((status = 'RUNNING') = (finished_at IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_erp_sync_runs_running ON erp_sync_runs((status)) WHERE status = 'RUNNING';
+CREATE INDEX idx_erp_sync_runs_started_at ON erp_sync_runs(started_at DESC);
+
+-- The reconciliation report of a run: every ERP record that changed the
+-- catalog or could not be applied. Records already matching the catalog are
+-- only counted on the run.
+CREATE TABLE IF NOT EXISTS erp_sync_run_items (
+    id BIGSERIAL PRIMARY KEY,
+    run_id UUID NOT NULL REFERENCES erp_sync_runs(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    product_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('UPDATED', 'NOT_FOUND', 'REJECTED', 'INVALID', 'FAILED')),
+    message TEXT NOT NULL DEFAULT '',
+    erp_has_stock BOOLEAN NOT NULL,
+    erp_stock INT NOT NULL,
+    erp_price DECIMAL(10, 2) NOT NULL,
+    old_stock INT NOT NULL,
+    new_stock INT NOT NULL,
+    old_price DECIMAL(10, 2) NOT NULL,
+    new_price DECIMAL(10, 2) NOT NULL
+);
+
+CREATE INDEX idx_erp_sync_run_items_run ON erp_sync_run_items(run_id, sku);
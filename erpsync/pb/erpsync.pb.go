@@ -0,0 +1,870 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: erpsync/erpsync.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SyncRun is one pull of stock and prices from the ERP, with the counts of
+// its reconciliation report
+type SyncRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`    // sftp or rest
+	Trigger       string                 `protobuf:"bytes,3,opt,name=trigger,proto3" json:"trigger,omitempty"`  // SCHEDULED or MANUAL
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`    // RUNNING, COMPLETED or FAILED
+	Records       int32                  `protobuf:"varint,5,opt,name=records,proto3" json:"records,omitempty"` // records read from the ERP
+	Updated       int32                  `protobuf:"varint,6,opt,name=updated,proto3" json:"updated,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,7,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	NotFound      int32                  `protobuf:"varint,8,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	Rejected      int32                  `protobuf:"varint,9,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Invalid       int32                  `protobuf:"varint,10,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	Failed        int32                  `protobuf:"varint,11,opt,name=failed,proto3" json:"failed,omitempty"`
+	Error         string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"` // why a FAILED run failed
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // unset while RUNNING
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncRun) Reset() {
+	*x = SyncRun{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRun) ProtoMessage() {}
+
+func (x *SyncRun) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRun.ProtoReflect.Descriptor instead.
+func (*SyncRun) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SyncRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SyncRun) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SyncRun) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *SyncRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SyncRun) GetRecords() int32 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *SyncRun) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *SyncRun) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *SyncRun) GetNotFound() int32 {
+	if x != nil {
+		return x.NotFound
+	}
+	return 0
+}
+
+func (x *SyncRun) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *SyncRun) GetInvalid() int32 {
+	if x != nil {
+		return x.Invalid
+	}
+	return 0
+}
+
+func (x *SyncRun) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *SyncRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SyncRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *SyncRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// SyncRunItem is a line of a run's reconciliation report: an ERP record that
+// changed the catalog or could not be applied. Records already matching the
+// catalog are only counted.
+type SyncRunItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`          // unset for NOT_FOUND and INVALID
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                 // UPDATED, NOT_FOUND, REJECTED, INVALID or FAILED
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                               // why the record was not applied
+	ErpHasStock   bool                   `protobuf:"varint,5,opt,name=erp_has_stock,json=erpHasStock,proto3" json:"erp_has_stock,omitempty"` // false when the ERP left the stock unreported
+	ErpStock      int32                  `protobuf:"varint,6,opt,name=erp_stock,json=erpStock,proto3" json:"erp_stock,omitempty"`
+	ErpPrice      float64                `protobuf:"fixed64,7,opt,name=erp_price,json=erpPrice,proto3" json:"erp_price,omitempty"` // 0 when the ERP left the price unreported
+	OldStock      int32                  `protobuf:"varint,8,opt,name=old_stock,json=oldStock,proto3" json:"old_stock,omitempty"`
+	NewStock      int32                  `protobuf:"varint,9,opt,name=new_stock,json=newStock,proto3" json:"new_stock,omitempty"`
+	OldPrice      float64                `protobuf:"fixed64,10,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice      float64                `protobuf:"fixed64,11,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncRunItem) Reset() {
+	*x = SyncRunItem{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncRunItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRunItem) ProtoMessage() {}
+
+func (x *SyncRunItem) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRunItem.ProtoReflect.Descriptor instead.
+func (*SyncRunItem) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SyncRunItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SyncRunItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SyncRunItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SyncRunItem) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SyncRunItem) GetErpHasStock() bool {
+	if x != nil {
+		return x.ErpHasStock
+	}
+	return false
+}
+
+func (x *SyncRunItem) GetErpStock() int32 {
+	if x != nil {
+		return x.ErpStock
+	}
+	return 0
+}
+
+func (x *SyncRunItem) GetErpPrice() float64 {
+	if x != nil {
+		return x.ErpPrice
+	}
+	return 0
+}
+
+func (x *SyncRunItem) GetOldStock() int32 {
+	if x != nil {
+		return x.OldStock
+	}
+	return 0
+}
+
+func (x *SyncRunItem) GetNewStock() int32 {
+	if x != nil {
+		return x.NewStock
+	}
+	return 0
+}
+
+func (x *SyncRunItem) GetOldPrice() float64 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *SyncRunItem) GetNewPrice() float64 {
+	if x != nil {
+		return x.NewPrice
+	}
+	return 0
+}
+
+// RunSync starts a sync now instead of waiting for the schedule. The run is
+// returned RUNNING; poll GetSyncRun for its report.
+type RunSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSyncRequest) Reset() {
+	*x = RunSyncRequest{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSyncRequest) ProtoMessage() {}
+
+func (x *RunSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSyncRequest.ProtoReflect.Descriptor instead.
+func (*RunSyncRequest) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{2}
+}
+
+type RunSyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Run           *SyncRun               `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSyncResponse) Reset() {
+	*x = RunSyncResponse{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSyncResponse) ProtoMessage() {}
+
+func (x *RunSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSyncResponse.ProtoReflect.Descriptor instead.
+func (*RunSyncResponse) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RunSyncResponse) GetRun() *SyncRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+// GetSyncRun returns a run and its counts
+type GetSyncRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncRunRequest) Reset() {
+	*x = GetSyncRunRequest{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncRunRequest) ProtoMessage() {}
+
+func (x *GetSyncRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncRunRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncRunRequest) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSyncRunRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetSyncRunResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Run           *SyncRun               `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncRunResponse) Reset() {
+	*x = GetSyncRunResponse{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncRunResponse) ProtoMessage() {}
+
+func (x *GetSyncRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncRunResponse.ProtoReflect.Descriptor instead.
+func (*GetSyncRunResponse) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSyncRunResponse) GetRun() *SyncRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+// ListSyncRuns lists runs, most recent first
+type ListSyncRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSyncRunsRequest) Reset() {
+	*x = ListSyncRunsRequest{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSyncRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSyncRunsRequest) ProtoMessage() {}
+
+func (x *ListSyncRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSyncRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListSyncRunsRequest) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSyncRunsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSyncRunsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListSyncRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*SyncRun             `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSyncRunsResponse) Reset() {
+	*x = ListSyncRunsResponse{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSyncRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSyncRunsResponse) ProtoMessage() {}
+
+func (x *ListSyncRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSyncRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListSyncRunsResponse) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSyncRunsResponse) GetRuns() []*SyncRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *ListSyncRunsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSyncRunsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSyncRunsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListSyncRunItems pages through a run's reconciliation report, in SKU order
+type ListSyncRunItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // only items with this status; empty for all
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSyncRunItemsRequest) Reset() {
+	*x = ListSyncRunItemsRequest{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSyncRunItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSyncRunItemsRequest) ProtoMessage() {}
+
+func (x *ListSyncRunItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSyncRunItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListSyncRunItemsRequest) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSyncRunItemsRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ListSyncRunItemsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListSyncRunItemsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSyncRunItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListSyncRunItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SyncRunItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSyncRunItemsResponse) Reset() {
+	*x = ListSyncRunItemsResponse{}
+	mi := &file_erpsync_erpsync_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSyncRunItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSyncRunItemsResponse) ProtoMessage() {}
+
+func (x *ListSyncRunItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_erpsync_erpsync_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSyncRunItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListSyncRunItemsResponse) Descriptor() ([]byte, []int) {
+	return file_erpsync_erpsync_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSyncRunItemsResponse) GetItems() []*SyncRunItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListSyncRunItemsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSyncRunItemsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSyncRunItemsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+var File_erpsync_erpsync_proto protoreflect.FileDescriptor
+
+const file_erpsync_erpsync_proto_rawDesc = "" +
+	"\n" +
+	"\x15erpsync/erpsync.proto\x12\aerpsync\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x03\n" +
+	"\aSyncRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x18\n" +
+	"\atrigger\x18\x03 \x01(\tR\atrigger\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\arecords\x18\x05 \x01(\x05R\arecords\x12\x18\n" +
+	"\aupdated\x18\x06 \x01(\x05R\aupdated\x12\x1c\n" +
+	"\tunchanged\x18\a \x01(\x05R\tunchanged\x12\x1b\n" +
+	"\tnot_found\x18\b \x01(\x05R\bnotFound\x12\x1a\n" +
+	"\brejected\x18\t \x01(\x05R\brejected\x12\x18\n" +
+	"\ainvalid\x18\n" +
+	" \x01(\x05R\ainvalid\x12\x16\n" +
+	"\x06failed\x18\v \x01(\x05R\x06failed\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\xc2\x02\n" +
+	"\vSyncRunItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\"\n" +
+	"\rerp_has_stock\x18\x05 \x01(\bR\verpHasStock\x12\x1b\n" +
+	"\terp_stock\x18\x06 \x01(\x05R\berpStock\x12\x1b\n" +
+	"\terp_price\x18\a \x01(\x01R\berpPrice\x12\x1b\n" +
+	"\told_stock\x18\b \x01(\x05R\boldStock\x12\x1b\n" +
+	"\tnew_stock\x18\t \x01(\x05R\bnewStock\x12\x1b\n" +
+	"\told_price\x18\n" +
+	" \x01(\x01R\boldPrice\x12\x1b\n" +
+	"\tnew_price\x18\v \x01(\x01R\bnewPrice\"\x10\n" +
+	"\x0eRunSyncRequest\"5\n" +
+	"\x0fRunSyncResponse\x12\"\n" +
+	"\x03run\x18\x01 \x01(\v2\x10.erpsync.SyncRunR\x03run\"#\n" +
+	"\x11GetSyncRunRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x12GetSyncRunResponse\x12\"\n" +
+	"\x03run\x18\x01 \x01(\v2\x10.erpsync.SyncRunR\x03run\"F\n" +
+	"\x13ListSyncRunsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x83\x01\n" +
+	"\x14ListSyncRunsResponse\x12$\n" +
+	"\x04runs\x18\x01 \x03(\v2\x10.erpsync.SyncRunR\x04runs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"y\n" +
+	"\x17ListSyncRunItemsRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x8d\x01\n" +
+	"\x18ListSyncRunItemsResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.erpsync.SyncRunItemR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xbb\x02\n" +
+	"\x0eERPSyncService\x12<\n" +
+	"\aRunSync\x12\x17.erpsync.RunSyncRequest\x1a\x18.erpsync.RunSyncResponse\x12E\n" +
+	"\n" +
+	"GetSyncRun\x12\x1a.erpsync.GetSyncRunRequest\x1a\x1b.erpsync.GetSyncRunResponse\x12K\n" +
+	"\fListSyncRuns\x12\x1c.erpsync.ListSyncRunsRequest\x1a\x1d.erpsync.ListSyncRunsResponse\x12W\n" +
+	"\x10ListSyncRunItems\x12 .erpsync.ListSyncRunItemsRequest\x1a!.erpsync.ListSyncRunItemsResponseB7Z5github.com/Ujjwaljain16/E-commerce-Backend/erpsync/pbb\x06proto3"
+
+var (
+	file_erpsync_erpsync_proto_rawDescOnce sync.Once
+	file_erpsync_erpsync_proto_rawDescData []byte
+)
+
+func file_erpsync_erpsync_proto_rawDescGZIP() []byte {
+	file_erpsync_erpsync_proto_rawDescOnce.Do(func() {
+		file_erpsync_erpsync_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_erpsync_erpsync_proto_rawDesc), len(file_erpsync_erpsync_proto_rawDesc)))
+	})
+	return file_erpsync_erpsync_proto_rawDescData
+}
+
+var file_erpsync_erpsync_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_erpsync_erpsync_proto_goTypes = []any{
+	(*SyncRun)(nil),                  // 0: erpsync.SyncRun
+	(*SyncRunItem)(nil),              // 1: erpsync.SyncRunItem
+	(*RunSyncRequest)(nil),           // 2: erpsync.RunSyncRequest
+	(*RunSyncResponse)(nil),          // 3: erpsync.RunSyncResponse
+	(*GetSyncRunRequest)(nil),        // 4: erpsync.GetSyncRunRequest
+	(*GetSyncRunResponse)(nil),       // 5: erpsync.GetSyncRunResponse
+	(*ListSyncRunsRequest)(nil),      // 6: erpsync.ListSyncRunsRequest
+	(*ListSyncRunsResponse)(nil),     // 7: erpsync.ListSyncRunsResponse
+	(*ListSyncRunItemsRequest)(nil),  // 8: erpsync.ListSyncRunItemsRequest
+	(*ListSyncRunItemsResponse)(nil), // 9: erpsync.ListSyncRunItemsResponse
+	(*timestamppb.Timestamp)(nil),    // 10: google.protobuf.Timestamp
+}
+var file_erpsync_erpsync_proto_depIdxs = []int32{
+	10, // 0: erpsync.SyncRun.started_at:type_name -> google.protobuf.Timestamp
+	10, // 1: erpsync.SyncRun.finished_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: erpsync.RunSyncResponse.run:type_name -> erpsync.SyncRun
+	0,  // 3: erpsync.GetSyncRunResponse.run:type_name -> erpsync.SyncRun
+	0,  // 4: erpsync.ListSyncRunsResponse.runs:type_name -> erpsync.SyncRun
+	1,  // 5: erpsync.ListSyncRunItemsResponse.items:type_name -> erpsync.SyncRunItem
+	2,  // 6: erpsync.ERPSyncService.RunSync:input_type -> erpsync.RunSyncRequest
+	4,  // 7: erpsync.ERPSyncService.GetSyncRun:input_type -> erpsync.GetSyncRunRequest
+	6,  // 8: erpsync.ERPSyncService.ListSyncRuns:input_type -> erpsync.ListSyncRunsRequest
+	8,  // 9: erpsync.ERPSyncService.ListSyncRunItems:input_type -> erpsync.ListSyncRunItemsRequest
+	3,  // 10: erpsync.ERPSyncService.RunSync:output_type -> erpsync.RunSyncResponse
+	5,  // 11: erpsync.ERPSyncService.GetSyncRun:output_type -> erpsync.GetSyncRunResponse
+	7,  // 12: erpsync.ERPSyncService.ListSyncRuns:output_type -> erpsync.ListSyncRunsResponse
+	9,  // 13: erpsync.ERPSyncService.ListSyncRunItems:output_type -> erpsync.ListSyncRunItemsResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_erpsync_erpsync_proto_init() }
+func file_erpsync_erpsync_proto_init() {
+	if File_erpsync_erpsync_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_erpsync_erpsync_proto_rawDesc), len(file_erpsync_erpsync_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_erpsync_erpsync_proto_goTypes,
+		DependencyIndexes: file_erpsync_erpsync_proto_depIdxs,
+		MessageInfos:      file_erpsync_erpsync_proto_msgTypes,
+	}.Build()
+	File_erpsync_erpsync_proto = out.File
+	file_erpsync_erpsync_proto_goTypes = nil
+	file_erpsync_erpsync_proto_depIdxs = nil
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: erpsync/erpsync.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ERPSyncService_RunSync_FullMethodName          = "/erpsync.ERPSyncService/RunSync"
+	ERPSyncService_GetSyncRun_FullMethodName       = "/erpsync.ERPSyncService/GetSyncRun"
+	ERPSyncService_ListSyncRuns_FullMethodName     = "/erpsync.ERPSyncService/ListSyncRuns"
+	ERPSyncService_ListSyncRunItems_FullMethodName = "/erpsync.ERPSyncService/ListSyncRunItems"
+)
+
+// ERPSyncServiceClient is the client API for ERPSyncService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ERPSyncService keeps catalog stock and prices in line with the ERP. Every
+// RPC is admin-scoped.
+type ERPSyncServiceClient interface {
+	RunSync(ctx context.Context, in *RunSyncRequest, opts ...grpc.CallOption) (*RunSyncResponse, error)
+	GetSyncRun(ctx context.Context, in *GetSyncRunRequest, opts ...grpc.CallOption) (*GetSyncRunResponse, error)
+	ListSyncRuns(ctx context.Context, in *ListSyncRunsRequest, opts ...grpc.CallOption) (*ListSyncRunsResponse, error)
+	ListSyncRunItems(ctx context.Context, in *ListSyncRunItemsRequest, opts ...grpc.CallOption) (*ListSyncRunItemsResponse, error)
+}
+
+type eRPSyncServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewERPSyncServiceClient(cc grpc.ClientConnInterface) ERPSyncServiceClient {
+	return &eRPSyncServiceClient{cc}
+}
+
+func (c *eRPSyncServiceClient) RunSync(ctx context.Context, in *RunSyncRequest, opts ...grpc.CallOption) (*RunSyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunSyncResponse)
+	err := c.cc.Invoke(ctx, ERPSyncService_RunSync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eRPSyncServiceClient) GetSyncRun(ctx context.Context, in *GetSyncRunRequest, opts ...grpc.CallOption) (*GetSyncRunResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSyncRunResponse)
+	err := c.cc.Invoke(ctx, ERPSyncService_GetSyncRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eRPSyncServiceClient) ListSyncRuns(ctx context.Context, in *ListSyncRunsRequest, opts ...grpc.CallOption) (*ListSyncRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSyncRunsResponse)
+	err := c.cc.Invoke(ctx, ERPSyncService_ListSyncRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eRPSyncServiceClient) ListSyncRunItems(ctx context.Context, in *ListSyncRunItemsRequest, opts ...grpc.CallOption) (*ListSyncRunItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSyncRunItemsResponse)
+	err := c.cc.Invoke(ctx, ERPSyncService_ListSyncRunItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ERPSyncServiceServer is the server API for ERPSyncService service.
+// All implementations must embed UnimplementedERPSyncServiceServer
+// for forward compatibility.
+//
+// ERPSyncService keeps catalog stock and prices in line with the ERP. Every
+// RPC is admin-scoped.
+type ERPSyncServiceServer interface {
+	RunSync(context.Context, *RunSyncRequest) (*RunSyncResponse, error)
+	GetSyncRun(context.Context, *GetSyncRunRequest) (*GetSyncRunResponse, error)
+	ListSyncRuns(context.Context, *ListSyncRunsRequest) (*ListSyncRunsResponse, error)
+	ListSyncRunItems(context.Context, *ListSyncRunItemsRequest) (*ListSyncRunItemsResponse, error)
+	mustEmbedUnimplementedERPSyncServiceServer()
+}
+
+// UnimplementedERPSyncServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedERPSyncServiceServer struct{}
+
+func (UnimplementedERPSyncServiceServer) RunSync(context.Context, *RunSyncRequest) (*RunSyncResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunSync not implemented")
+}
+func (UnimplementedERPSyncServiceServer) GetSyncRun(context.Context, *GetSyncRunRequest) (*GetSyncRunResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSyncRun not implemented")
+}
+func (UnimplementedERPSyncServiceServer) ListSyncRuns(context.Context, *ListSyncRunsRequest) (*ListSyncRunsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSyncRuns not implemented")
+}
+func (UnimplementedERPSyncServiceServer) ListSyncRunItems(context.Context, *ListSyncRunItemsRequest) (*ListSyncRunItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSyncRunItems not implemented")
+}
+func (UnimplementedERPSyncServiceServer) mustEmbedUnimplementedERPSyncServiceServer() {}
+func (UnimplementedERPSyncServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeERPSyncServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ERPSyncServiceServer will
+// result in compilation errors.
+type UnsafeERPSyncServiceServer interface {
+	mustEmbedUnimplementedERPSyncServiceServer()
+}
+
+func RegisterERPSyncServiceServer(s grpc.ServiceRegistrar, srv ERPSyncServiceServer) {
+	// If the following call panics, it indicates UnimplementedERPSyncServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ERPSyncService_ServiceDesc, srv)
+}
+
+func _ERPSyncService_RunSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunSyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ERPSyncServiceServer).RunSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ERPSyncService_RunSync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ERPSyncServiceServer).RunSync(ctx, req.(*RunSyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ERPSyncService_GetSyncRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ERPSyncServiceServer).GetSyncRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ERPSyncService_GetSyncRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ERPSyncServiceServer).GetSyncRun(ctx, req.(*GetSyncRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ERPSyncService_ListSyncRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSyncRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ERPSyncServiceServer).ListSyncRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ERPSyncService_ListSyncRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ERPSyncServiceServer).ListSyncRuns(ctx, req.(*ListSyncRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ERPSyncService_ListSyncRunItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSyncRunItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ERPSyncServiceServer).ListSyncRunItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ERPSyncService_ListSyncRunItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ERPSyncServiceServer).ListSyncRunItems(ctx, req.(*ListSyncRunItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ERPSyncService_ServiceDesc is the grpc.ServiceDesc for ERPSyncService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ERPSyncService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "erpsync.ERPSyncService",
+	HandlerType: (*ERPSyncServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RunSync",
+			Handler:    _ERPSyncService_RunSync_Handler,
+		},
+		{
+			MethodName: "GetSyncRun",
+			Handler:    _ERPSyncService_GetSyncRun_Handler,
+		},
+		{
+			MethodName: "ListSyncRuns",
+			Handler:    _ERPSyncService_ListSyncRuns_Handler,
+		},
+		{
+			MethodName: "ListSyncRunItems",
+			Handler:    _ERPSyncService_ListSyncRunItems_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "erpsync/erpsync.proto",
+}
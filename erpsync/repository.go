@@ -0,0 +1,342 @@
+package erpsync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Run statuses
+const (
+	RunRunning   = "RUNNING"
+	RunCompleted = "COMPLETED"
+	RunFailed    = "FAILED"
+)
+
+// Run triggers
+const (
+	TriggerScheduled = "SCHEDULED"
+	TriggerManual    = "MANUAL"
+)
+
+// runningConstraint is the partial unique index allowing one RUNNING run
+const runningConstraint = "idx_erp_sync_runs_running"
+
+// Item statuses. UPDATED, UNCHANGED, NOT_FOUND and REJECTED are the catalog's
+// outcomes for an update.
+const (
+	ItemUpdated   = "UPDATED"
+	ItemUnchanged = "UNCHANGED"
+	ItemNotFound  = "NOT_FOUND"
+	ItemRejected  = "REJECTED"
+	// ItemInvalid is a record that could not be read from the ERP
+	ItemInvalid = "INVALID"
+	// ItemFailed is a record whose batch the catalog failed to apply
+	ItemFailed = "FAILED"
+)
+
+var (
+	// ErrRunNotFound is returned when a sync run does not exist
+	ErrRunNotFound = errors.New("sync run not found")
+	// ErrRunInProgress is returned when a run is started while another is
+	// running
+	ErrRunInProgress = errors.New("sync run in progress")
+)
+
+// Run is one pull of stock and prices from the ERP
+type Run struct {
+	ID      string
+	Source  string
+	Trigger string
+	Status  string
+	// Records is how many records were read from the ERP; the other counts
+	// break them down by item status
+	Records   int32
+	Updated   int32
+	Unchanged int32
+	NotFound  int32
+	Rejected  int32
+	Invalid   int32
+	Failed    int32
+	// Error is why a FAILED run failed
+	Error      string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// Item is the reconciliation of one ERP record against the catalog
+type Item struct {
+	SKU       string
+	ProductID string
+	Status    string
+	// Message is why the record was not applied
+	Message     string
+	ERPHasStock bool
+	ERPStock    int32
+	// ERPPrice is 0 when the ERP did not report the price
+	ERPPrice float64
+	OldStock int32
+	NewStock int32
+	OldPrice float64
+	NewPrice float64
+}
+
+// count adds an item with status to the run's counts
+func (r *Run) count(status string) {
+	switch status {
+	case ItemUpdated:
+		r.Updated++
+	case ItemUnchanged:
+		r.Unchanged++
+	case ItemNotFound:
+		r.NotFound++
+	case ItemRejected:
+		r.Rejected++
+	case ItemInvalid:
+		r.Invalid++
+	case ItemFailed:
+		r.Failed++
+	}
+}
+
+// Repository handles sync run persistence
+type Repository interface {
+	// StartRun records run as RUNNING, assigning its ID. A run still
+	// RUNNING since before staleBefore is taken to have died and is marked
+	// FAILED; any other running run makes it return ErrRunInProgress.
+	StartRun(ctx context.Context, run *Run, staleBefore time.Time) error
+	// FinishRun records the outcome and counts of run with its report items
+	FinishRun(ctx context.Context, run *Run, items []*Item) error
+	// GetRun returns a run, or ErrRunNotFound
+	GetRun(ctx context.Context, id string) (*Run, error)
+	// ListRuns lists runs, most recent first
+	ListRuns(ctx context.Context, page, pageSize int32) ([]*Run, int32, error)
+	// ListItems lists a run's report items in SKU order, optionally only
+	// those with status
+	ListItems(ctx context.Context, runID, status string, page, pageSize int32) ([]*Item, int32, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL sync run repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const runColumns = `id, source, trigger, status, records, updated, unchanged, not_found, rejected, invalid, failed, error, started_at, finished_at`
+
+// StartRun inserts the run; the unique index on running runs keeps a second
+// one from starting
+func (r *postgresRepository) StartRun(ctx context.Context, run *Run, staleBefore time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE erp_sync_runs SET status = 'FAILED', error = 'run was interrupted', finished_at = $2
+		WHERE status = 'RUNNING' AND started_at < $1
+	`, staleBefore, run.StartedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to fail stale sync runs", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to fail stale sync runs: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		r.log.Warn(ctx, "Stale sync run marked failed", nil)
+	}
+
+	run.ID = uuid.New().String()
+	run.Status = RunRunning
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO erp_sync_runs (id, source, trigger, status, started_at) VALUES ($1, $2, $3, $4, $5)
+	`, run.ID, run.Source, run.Trigger, run.Status, run.StartedAt)
+	if pgerr.IsUniqueViolation(err, runningConstraint) {
+		return ErrRunInProgress
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to start sync run", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to start sync run: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit sync run", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to commit sync run: %w", err)
+	}
+	return nil
+}
+
+// FinishRun inserts the items in one statement and updates the run
+func (r *postgresRepository) FinishRun(ctx context.Context, run *Run, items []*Item) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if len(items) > 0 {
+		n := len(items)
+		skus, productIDs, statuses, messages := make([]string, n), make([]string, n), make([]string, n), make([]string, n)
+		hasStock, erpStock, oldStock, newStock := make([]bool, n), make([]int32, n), make([]int32, n), make([]int32, n)
+		erpPrice, oldPrice, newPrice := make([]float64, n), make([]float64, n), make([]float64, n)
+		for i, item := range items {
+			skus[i], productIDs[i], statuses[i], messages[i] = item.SKU, item.ProductID, item.Status, item.Message
+			hasStock[i], erpStock[i], oldStock[i], newStock[i] = item.ERPHasStock, item.ERPStock, item.OldStock, item.NewStock
+			erpPrice[i], oldPrice[i], newPrice[i] = item.ERPPrice, item.OldPrice, item.NewPrice
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO erp_sync_run_items (
+				run_id, sku, product_id, status, message, erp_has_stock, erp_stock, erp_price,
+				old_stock, new_stock, old_price, new_price
+			)
+			SELECT $1, sku, NULLIF(product_id, ''), status, message, erp_has_stock, erp_stock, erp_price,
+				old_stock, new_stock, old_price, new_price
+			FROM unnest($2::text[], $3::text[], $4::text[], $5::text[], $6::boolean[], $7::int[], $8::numeric[],
+				$9::int[], $10::int[], $11::numeric[], $12::numeric[])
+				AS t(sku, product_id, status, message, erp_has_stock, erp_stock, erp_price, old_stock, new_stock, old_price, new_price)
+		`, run.ID, pq.Array(skus), pq.Array(productIDs), pq.Array(statuses), pq.Array(messages), pq.Array(hasStock),
+			pq.Array(erpStock), pq.Array(erpPrice), pq.Array(oldStock), pq.Array(newStock), pq.Array(oldPrice), pq.Array(newPrice))
+		if err != nil {
+			r.log.Error(ctx, "Failed to save sync run items", map[string]interface{}{"error": err.Error(), "run_id": run.ID})
+			return fmt.Errorf("failed to save sync run items: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE erp_sync_runs SET status = $2, records = $3, updated = $4, unchanged = $5, not_found = $6,
+			rejected = $7, invalid = $8, failed = $9, error = $10, finished_at = $11
+		WHERE id = $1
+	`, run.ID, run.Status, run.Records, run.Updated, run.Unchanged, run.NotFound,
+		run.Rejected, run.Invalid, run.Failed, run.Error, run.FinishedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to finish sync run", map[string]interface{}{"error": err.Error(), "run_id": run.ID})
+		return fmt.Errorf("failed to finish sync run: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit sync run", map[string]interface{}{"error": err.Error(), "run_id": run.ID})
+		return fmt.Errorf("failed to commit sync run: %w", err)
+	}
+	return nil
+}
+
+// GetRun retrieves a run by ID
+func (r *postgresRepository) GetRun(ctx context.Context, id string) (*Run, error) {
+	run, err := scanRun(r.db.QueryRowContext(ctx, "SELECT "+runColumns+" FROM erp_sync_runs WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrRunNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get sync run", map[string]interface{}{"error": err.Error(), "run_id": id})
+		return nil, fmt.Errorf("failed to get sync run: %w", err)
+	}
+	return run, nil
+}
+
+// ListRuns lists runs by start time, newest first
+func (r *postgresRepository) ListRuns(ctx context.Context, page, pageSize int32) ([]*Run, int32, error) {
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM erp_sync_runs").Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count sync runs", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count sync runs: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+runColumns+" FROM erp_sync_runs ORDER BY started_at DESC, id LIMIT $1 OFFSET $2",
+		pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list sync runs", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list sync runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*Run
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan sync run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list sync runs: %w", err)
+	}
+	return runs, total, nil
+}
+
+// ListItems lists a run's items by SKU
+func (r *postgresRepository) ListItems(ctx context.Context, runID, status string, page, pageSize int32) ([]*Item, int32, error) {
+	where := " FROM erp_sync_run_items WHERE run_id = $1 AND ($2 = '' OR status = $2)"
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+where, runID, status).Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count sync run items", map[string]interface{}{"error": err.Error(), "run_id": runID})
+		return nil, 0, fmt.Errorf("failed to count sync run items: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT sku, product_id, status, message, erp_has_stock, erp_stock, erp_price,
+			old_stock, new_stock, old_price, new_price`+where+`
+		ORDER BY sku, id LIMIT $3 OFFSET $4
+	`, runID, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list sync run items", map[string]interface{}{"error": err.Error(), "run_id": runID})
+		return nil, 0, fmt.Errorf("failed to list sync run items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Item
+	for rows.Next() {
+		item := &Item{}
+		var productID sql.NullString
+		err := rows.Scan(&item.SKU, &productID, &item.Status, &item.Message, &item.ERPHasStock, &item.ERPStock,
+			&item.ERPPrice, &item.OldStock, &item.NewStock, &item.OldPrice, &item.NewPrice)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan sync run item: %w", err)
+		}
+		item.ProductID = productID.String
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list sync run items: %w", err)
+	}
+	return items, total, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRun scans a run selected with runColumns
+func scanRun(row rowScanner) (*Run, error) {
+	run := &Run{}
+	var finishedAt sql.NullTime
+	err := row.Scan(&run.ID, &run.Source, &run.Trigger, &run.Status, &run.Records, &run.Updated, &run.Unchanged,
+		&run.NotFound, &run.Rejected, &run.Invalid, &run.Failed, &run.Error, &run.StartedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+	return run, nil
+}
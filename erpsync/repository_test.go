@@ -0,0 +1,168 @@
+package erpsync
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("erpsync-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var runColumnNames = []string{"id", "source", "trigger", "status", "records", "updated", "unchanged", "not_found", "rejected", "invalid", "failed", "error", "started_at", "finished_at"}
+
+func TestStartRun(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	staleBefore := now.Add(-staleRunAfter)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE erp_sync_runs SET status = 'FAILED', error = 'run was interrupted', finished_at = \$2\s+WHERE status = 'RUNNING' AND started_at < \$1`).
+		WithArgs(staleBefore, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO erp_sync_runs \(id, source, trigger, status, started_at\)`).
+		WithArgs(sqlmock.AnyArg(), "sftp", TriggerScheduled, RunRunning, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	run := &Run{Source: "sftp", Trigger: TriggerScheduled, StartedAt: now}
+	if err := repo.StartRun(context.Background(), run, staleBefore); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if run.ID == "" || run.Status != RunRunning {
+		t.Errorf("Expected a running run with an ID, got %+v", run)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStartRun_InProgress(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE erp_sync_runs`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO erp_sync_runs`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_erp_sync_runs_running"})
+	mock.ExpectRollback()
+
+	run := &Run{Source: "sftp", Trigger: TriggerManual, StartedAt: now}
+	if err := repo.StartRun(context.Background(), run, now.Add(-staleRunAfter)); err != ErrRunInProgress {
+		t.Errorf("Expected ErrRunInProgress, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFinishRun(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	run := &Run{ID: "run-1", Status: RunCompleted, Records: 2, Updated: 1, NotFound: 1, FinishedAt: &now}
+	items := []*Item{
+		{SKU: "SKU-1", ProductID: "product-1", Status: ItemUpdated, ERPHasStock: true, ERPStock: 7, OldStock: 5, NewStock: 7, OldPrice: 9.99, NewPrice: 9.99},
+		{SKU: "GONE", Status: ItemNotFound, ERPHasStock: true, ERPStock: 1},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO erp_sync_run_items (.+) FROM unnest`).
+		WithArgs("run-1", pq.Array([]string{"SKU-1", "GONE"}), pq.Array([]string{"product-1", ""}),
+			pq.Array([]string{ItemUpdated, ItemNotFound}), pq.Array([]string{"", ""}), pq.Array([]bool{true, true}),
+			pq.Array([]int32{7, 1}), pq.Array([]float64{0, 0}), pq.Array([]int32{5, 0}), pq.Array([]int32{7, 0}),
+			pq.Array([]float64{9.99, 0}), pq.Array([]float64{9.99, 0})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE erp_sync_runs SET status = \$2`).
+		WithArgs("run-1", RunCompleted, int32(2), int32(1), int32(0), int32(1), int32(0), int32(0), int32(0), "", &now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.FinishRun(context.Background(), run, items); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRun_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM erp_sync_runs WHERE id = \$1`).
+		WithArgs("run-404").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.GetRun(context.Background(), "run-404"); err != ErrRunNotFound {
+		t.Errorf("Expected ErrRunNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListItems(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM erp_sync_run_items WHERE run_id = \$1 AND \(\$2 = '' OR status = \$2\)`).
+		WithArgs("run-1", ItemNotFound).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT sku, product_id, (.+) FROM erp_sync_run_items (.+) ORDER BY sku, id LIMIT \$3 OFFSET \$4`).
+		WithArgs("run-1", ItemNotFound, int32(10), int32(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"sku", "product_id", "status", "message", "erp_has_stock", "erp_stock", "erp_price", "old_stock", "new_stock", "old_price", "new_price"}).
+			AddRow("GONE", nil, ItemNotFound, "", true, 1, 0, 0, 0, 0, 0))
+
+	items, total, err := repo.ListItems(context.Background(), "run-1", ItemNotFound, 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 1 || len(items) != 1 || items[0].SKU != "GONE" || items[0].ProductID != "" {
+		t.Errorf("Unexpected items %v", items)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListRuns(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM erp_sync_runs`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT (.+) FROM erp_sync_runs ORDER BY started_at DESC, id LIMIT \$1 OFFSET \$2`).
+		WithArgs(int32(10), int32(0)).
+		WillReturnRows(sqlmock.NewRows(runColumnNames).AddRow("run-1", "rest", TriggerScheduled, RunRunning, 0, 0, 0, 0, 0, 0, 0, "", now, nil))
+
+	runs, total, err := repo.ListRuns(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 1 || len(runs) != 1 || runs[0].FinishedAt != nil {
+		t.Errorf("Unexpected runs %v", runs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package erpsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRESTPages caps the pages followed in one fetch, in case the ERP's
+	// next links loop
+	maxRESTPages = 10000
+	// maxRESTPageBytes caps the size of one page of the export
+	maxRESTPageBytes = 32 << 20
+)
+
+// RESTSource pulls the export from an ERP HTTP API. Each page is a JSON
+// object:
+//
+//	{"items": [{"sku": "A-1", "stock": 5, "price": 19.99}], "next": "/stock?page=2"}
+//
+// stock and price may be null or left out to leave them unreported; next is
+// empty on the last page and may be relative to the page's URL.
+type RESTSource struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewRESTSource creates a source reading the export from rawURL, sending
+// token as a bearer token when it is set
+func NewRESTSource(rawURL, token string) (*RESTSource, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid ERP URL %q", rawURL)
+	}
+	return &RESTSource{
+		url:    rawURL,
+		token:  token,
+		client: &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// Name identifies the source in run reports
+func (s *RESTSource) Name() string {
+	return "rest"
+}
+
+type restPage struct {
+	Items []struct {
+		SKU   string   `json:"sku"`
+		Stock *int64   `json:"stock"`
+		Price *float64 `json:"price"`
+	} `json:"items"`
+	Next string `json:"next"`
+}
+
+// Fetch reads every page of the export. next links are only followed on the
+// export's own host, so the token is never sent elsewhere.
+func (s *RESTSource) Fetch(ctx context.Context) ([]*Record, error) {
+	first, err := url.Parse(s.url)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	next := first
+	for pages := 0; ; pages++ {
+		if pages == maxRESTPages {
+			return nil, fmt.Errorf("export has more than %d pages", maxRESTPages)
+		}
+		page, err := s.fetchPage(ctx, next.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			records = append(records, restRecord(len(records)+1, item.SKU, item.Stock, item.Price))
+		}
+
+		if page.Next == "" {
+			return records, nil
+		}
+		ref, err := next.Parse(page.Next)
+		if err != nil || ref.Scheme != first.Scheme || ref.Host != first.Host {
+			return nil, fmt.Errorf("next page %q is not on the export's host", page.Next)
+		}
+		next = ref
+	}
+}
+
+func (s *RESTSource) fetchPage(ctx context.Context, pageURL string) (*restPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch export: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ERP responded %s", resp.Status)
+	}
+
+	var page restPage
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRESTPageBytes)).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode export: %w", err)
+	}
+	return &page, nil
+}
+
+// restRecord converts the n-th item of the export to a record, setting its
+// Problem when a value cannot be used
+func restRecord(n int, sku string, stock *int64, price *float64) *Record {
+	record := &Record{SKU: strings.TrimSpace(sku)}
+	switch {
+	case record.SKU == "":
+		record.Problem = fmt.Sprintf("item %d: sku is required", n)
+		return record
+	case stock != nil && (*stock < 0 || *stock > 1<<31-1):
+		record.Problem = fmt.Sprintf("item %d: stock %d is out of range", n, *stock)
+		return record
+	case price != nil && math.Round(*price*100) < 1:
+		record.Problem = fmt.Sprintf("item %d: price must be 0.01 or more", n)
+		return record
+	}
+	if stock != nil {
+		record.SetStock, record.Stock = true, int32(*stock)
+	}
+	if price != nil {
+		record.Price = *price
+	}
+	if msg := valueProblem(record); msg != "" {
+		record.Problem = fmt.Sprintf("item %d: %s", n, msg)
+	}
+	return record
+}
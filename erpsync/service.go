@@ -0,0 +1,191 @@
+package erpsync
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/erpsync/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminMethods are the admin-scoped RPCs of the ERP sync service; they are
+// restricted to the admin network allowlist. The service only serves the
+// backoffice, so that is every RPC.
+var AdminMethods = []string{
+	"/" + pb.ERPSyncService_ServiceDesc.ServiceName + "/",
+}
+
+// itemStatuses are the statuses ListSyncRunItems filters by
+var itemStatuses = map[string]bool{
+	ItemUpdated:  true,
+	ItemNotFound: true,
+	ItemRejected: true,
+	ItemInvalid:  true,
+	ItemFailed:   true,
+}
+
+// Service implements the ERPSyncService gRPC interface
+type Service struct {
+	pb.UnimplementedERPSyncServiceServer
+	repo   Repository
+	syncer *Syncer
+	log    *logger.Logger
+}
+
+// NewService creates a new ERP sync service starting manual runs on syncer
+func NewService(repo Repository, syncer *Syncer, log *logger.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		syncer: syncer,
+		log:    log,
+	}
+}
+
+// RunSync starts a sync now
+func (s *Service) RunSync(ctx context.Context, req *pb.RunSyncRequest) (*pb.RunSyncResponse, error) {
+	run, err := s.syncer.Trigger(ctx)
+	if errors.Is(err, ErrRunInProgress) {
+		return nil, status.Error(codes.FailedPrecondition, "a sync is already in progress")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to start sync")
+	}
+	return &pb.RunSyncResponse{Run: toProtoRun(run)}, nil
+}
+
+// GetSyncRun returns a run and its counts
+func (s *Service) GetSyncRun(ctx context.Context, req *pb.GetSyncRunRequest) (*pb.GetSyncRunResponse, error) {
+	if req.Id == "" {
+		s.log.Warn(ctx, "Get sync run failed: id is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	run, err := s.repo.GetRun(ctx, req.Id)
+	if errors.Is(err, ErrRunNotFound) {
+		return nil, status.Error(codes.NotFound, "sync run not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get sync run")
+	}
+	return &pb.GetSyncRunResponse{Run: toProtoRun(run)}, nil
+}
+
+// ListSyncRuns lists runs, most recent first
+func (s *Service) ListSyncRuns(ctx context.Context, req *pb.ListSyncRunsRequest) (*pb.ListSyncRunsResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	runs, total, err := s.repo.ListRuns(ctx, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list sync runs")
+	}
+
+	protoRuns := make([]*pb.SyncRun, len(runs))
+	for i, run := range runs {
+		protoRuns[i] = toProtoRun(run)
+	}
+
+	return &pb.ListSyncRunsResponse{
+		Runs:     protoRuns,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// ListSyncRunItems pages through a run's reconciliation report
+func (s *Service) ListSyncRunItems(ctx context.Context, req *pb.ListSyncRunItemsRequest) (*pb.ListSyncRunItemsResponse, error) {
+	var msg string
+	switch {
+	case req.RunId == "":
+		msg = "run_id is required"
+	case req.Status != "" && !itemStatuses[req.Status]:
+		msg = "status must be UPDATED, NOT_FOUND, REJECTED, INVALID or FAILED"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "List sync run items failed: "+msg, map[string]interface{}{"run_id": req.RunId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	if _, err := s.repo.GetRun(ctx, req.RunId); errors.Is(err, ErrRunNotFound) {
+		return nil, status.Error(codes.NotFound, "sync run not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list sync run items")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	items, total, err := s.repo.ListItems(ctx, req.RunId, req.Status, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list sync run items")
+	}
+
+	protoItems := make([]*pb.SyncRunItem, len(items))
+	for i, item := range items {
+		protoItems[i] = &pb.SyncRunItem{
+			Sku:         item.SKU,
+			ProductId:   item.ProductID,
+			Status:      item.Status,
+			Message:     item.Message,
+			ErpHasStock: item.ERPHasStock,
+			ErpStock:    item.ERPStock,
+			ErpPrice:    item.ERPPrice,
+			OldStock:    item.OldStock,
+			NewStock:    item.NewStock,
+			OldPrice:    item.OldPrice,
+			NewPrice:    item.NewPrice,
+		}
+	}
+
+	return &pb.ListSyncRunItemsResponse{
+		Items:    protoItems,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// toProtoRun converts a run to protobuf
+func toProtoRun(run *Run) *pb.SyncRun {
+	r := &pb.SyncRun{
+		Id:        run.ID,
+		Source:    run.Source,
+		Trigger:   run.Trigger,
+		Status:    run.Status,
+		Records:   run.Records,
+		Updated:   run.Updated,
+		Unchanged: run.Unchanged,
+		NotFound:  run.NotFound,
+		Rejected:  run.Rejected,
+		Invalid:   run.Invalid,
+		Failed:    run.Failed,
+		Error:     run.Error,
+		StartedAt: timestamppb.New(run.StartedAt),
+	}
+	if run.FinishedAt != nil {
+		r.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+	return r
+}
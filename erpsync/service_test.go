@@ -0,0 +1,370 @@
+package erpsync
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/erpsync/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps sync runs and their items, refusing a run while
+// another has been running since staleBefore
+type memoryRepository struct {
+	mu    sync.Mutex
+	runs  []*Run
+	items map[string][]*Item // by run ID
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{items: map[string][]*Item{}}
+}
+
+func (m *memoryRepository) StartRun(ctx context.Context, run *Run, staleBefore time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.runs {
+		if r.Status != RunRunning {
+			continue
+		}
+		if !r.StartedAt.Before(staleBefore) {
+			return ErrRunInProgress
+		}
+		r.Status, r.Error, r.FinishedAt = RunFailed, "run was interrupted", &run.StartedAt
+	}
+	run.ID = uuid.New().String()
+	run.Status = RunRunning
+	cp := *run
+	m.runs = append(m.runs, &cp)
+	return nil
+}
+
+func (m *memoryRepository) FinishRun(ctx context.Context, run *Run, items []*Item) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, r := range m.runs {
+		if r.ID == run.ID {
+			cp := *run
+			m.runs[i] = &cp
+			m.items[run.ID] = items
+			return nil
+		}
+	}
+	return ErrRunNotFound
+}
+
+func (m *memoryRepository) GetRun(ctx context.Context, id string) (*Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.runs {
+		if r.ID == id {
+			cp := *r
+			return &cp, nil
+		}
+	}
+	return nil, ErrRunNotFound
+}
+
+func (m *memoryRepository) ListRuns(ctx context.Context, page, pageSize int32) ([]*Run, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var runs []*Run
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		cp := *m.runs[i]
+		runs = append(runs, &cp)
+	}
+	return paginate(runs, page, pageSize), int32(len(runs)), nil
+}
+
+func (m *memoryRepository) ListItems(ctx context.Context, runID, status string, page, pageSize int32) ([]*Item, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var items []*Item
+	for _, item := range m.items[runID] {
+		if status == "" || item.Status == status {
+			cp := *item
+			items = append(items, &cp)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].SKU < items[j].SKU })
+	return paginate(items, page, pageSize), int32(len(items)), nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+func paginate[T any](all []T, page, pageSize int32) []T {
+	start := int((page - 1) * pageSize)
+	if start >= len(all) {
+		return nil
+	}
+	return all[start:min(start+int(pageSize), len(all))]
+}
+
+// fakeSource returns fixed records, or err
+type fakeSource struct {
+	records []*Record
+	err     error
+}
+
+func (f *fakeSource) Name() string {
+	return "fake"
+}
+
+func (f *fakeSource) Fetch(ctx context.Context) ([]*Record, error) {
+	return f.records, f.err
+}
+
+// fakeCatalog applies updates to in-memory products by SKU, rejecting stock
+// changes to SKUs in digital
+type fakeCatalog struct {
+	products map[string]*Product
+	digital  map[string]bool
+	// applyErr fails every Apply call
+	applyErr error
+	applied  int
+}
+
+func (f *fakeCatalog) Lookup(ctx context.Context, skus []string) (map[string]*Product, error) {
+	if len(skus) > lookupBatchSize {
+		return nil, errors.New("too many skus")
+	}
+	found := map[string]*Product{}
+	for _, sku := range skus {
+		if p, ok := f.products[sku]; ok {
+			cp := *p
+			found[sku] = &cp
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeCatalog) Apply(ctx context.Context, records []*Record) ([]*Item, error) {
+	if f.applyErr != nil {
+		return nil, f.applyErr
+	}
+	if len(records) > applyBatchSize {
+		return nil, errors.New("too many updates")
+	}
+	items := make([]*Item, len(records))
+	for i, r := range records {
+		p := f.products[r.SKU]
+		item := &Item{SKU: r.SKU, ProductID: p.ID, OldStock: p.Stock, NewStock: p.Stock, OldPrice: p.Price, NewPrice: p.Price}
+		if r.SetStock {
+			item.NewStock = r.Stock
+		}
+		if r.Price > 0 {
+			item.NewPrice = r.Price
+		}
+		if f.digital[r.SKU] && item.NewStock != item.OldStock {
+			item.Status, item.Message = ItemRejected, "product does not track stock"
+		} else {
+			item.Status = ItemUpdated
+			p.Stock, p.Price = item.NewStock, item.NewPrice
+			f.applied++
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	source  *fakeSource
+	catalog *fakeCatalog
+	syncer  *Syncer
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo:   newMemoryRepository(),
+		source: &fakeSource{},
+		catalog: &fakeCatalog{
+			products: map[string]*Product{
+				"SKU-1": {ID: "product-1", Stock: 10, Price: 19.99},
+				"SKU-2": {ID: "product-2", Stock: 5, Price: 9.99},
+				"SKU-3": {ID: "product-3", Stock: 0, Price: 4.5},
+				"EBOOK": {ID: "product-4", Stock: 0, Price: 12},
+			},
+			digital: map[string]bool{"EBOOK": true},
+		},
+		now: time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	log := logger.New("erpsync-test")
+	f.syncer = NewSyncer(f.source, f.catalog, f.repo, log)
+	f.syncer.now = func() time.Time { return f.now }
+	f.service = NewService(f.repo, f.syncer, log)
+	return f
+}
+
+func (f *serviceFixture) sync(t *testing.T) *Run {
+	t.Helper()
+	run, err := f.syncer.Sync(context.Background(), TriggerScheduled)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return run
+}
+
+func (f *serviceFixture) items(t *testing.T, runID, status string) []*pb.SyncRunItem {
+	t.Helper()
+	resp, err := f.service.ListSyncRunItems(context.Background(), &pb.ListSyncRunItemsRequest{RunId: runID, Status: status, PageSize: 100})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Items
+}
+
+func TestSync(t *testing.T) {
+	f := setupService()
+	f.source.records = []*Record{
+		{SKU: "SKU-1", SetStock: true, Stock: 10, Price: 19.99}, // matches the catalog
+		{SKU: "SKU-2", SetStock: true, Stock: 7},                // stock only
+		{SKU: "SKU-3", Price: 5.25},                             // price only
+		{SKU: "EBOOK", SetStock: true, Stock: 3},
+		{SKU: "GONE", SetStock: true, Stock: 1},
+		{SKU: "DUP", SetStock: true, Stock: 1},
+		{SKU: "DUP", SetStock: true, Stock: 2},
+		{Problem: "line 9: sku is required"},
+	}
+
+	run := f.sync(t)
+	if run.Status != RunCompleted || run.FinishedAt == nil {
+		t.Fatalf("Expected a completed run, got %+v", run)
+	}
+	if run.Records != 8 || run.Updated != 2 || run.Unchanged != 1 || run.NotFound != 1 || run.Rejected != 1 || run.Invalid != 3 || run.Failed != 0 {
+		t.Errorf("Unexpected counts %+v", run)
+	}
+	if p := f.catalog.products["SKU-2"]; p.Stock != 7 || p.Price != 9.99 {
+		t.Errorf("Expected SKU-2's stock set and price kept, got %+v", p)
+	}
+	if p := f.catalog.products["SKU-3"]; p.Stock != 0 || p.Price != 5.25 {
+		t.Errorf("Expected SKU-3's price set and stock kept, got %+v", p)
+	}
+
+	updated := f.items(t, run.ID, ItemUpdated)
+	if len(updated) != 2 || updated[0].Sku != "SKU-2" || updated[0].OldStock != 5 || updated[0].NewStock != 7 || !updated[0].ErpHasStock {
+		t.Errorf("Unexpected updated items %v", updated)
+	}
+	if all := f.items(t, run.ID, ""); len(all) != 7 {
+		t.Errorf("Expected every record but the unchanged one reported, got %d items", len(all))
+	}
+	rejected := f.items(t, run.ID, ItemRejected)
+	if len(rejected) != 1 || rejected[0].Sku != "EBOOK" || rejected[0].Message == "" {
+		t.Errorf("Unexpected rejected items %v", rejected)
+	}
+
+	// Syncing the same export again changes nothing
+	again := f.sync(t)
+	if again.Updated != 0 || again.Unchanged != 3 {
+		t.Errorf("Expected the second run to find the catalog in sync, got %+v", again)
+	}
+}
+
+func TestSync_Batches(t *testing.T) {
+	f := setupService()
+	for i := 0; i < 1200; i++ {
+		sku := "BULK-" + uuid.New().String()
+		f.catalog.products[sku] = &Product{ID: sku, Stock: 1, Price: 1}
+		f.source.records = append(f.source.records, &Record{SKU: sku, SetStock: true, Stock: 2})
+	}
+
+	run := f.sync(t)
+	if run.Updated != 1200 || f.catalog.applied != 1200 {
+		t.Errorf("Expected 1200 products updated, got %+v", run)
+	}
+}
+
+func TestSync_Failures(t *testing.T) {
+	t.Run("fetch fails", func(t *testing.T) {
+		f := setupService()
+		f.source.err = errors.New("connection refused")
+
+		run := f.sync(t)
+		if run.Status != RunFailed || run.Error == "" {
+			t.Errorf("Expected a failed run, got %+v", run)
+		}
+	})
+
+	t.Run("apply fails", func(t *testing.T) {
+		f := setupService()
+		f.source.records = []*Record{{SKU: "SKU-1", SetStock: true, Stock: 1}}
+		f.catalog.applyErr = errors.New("unavailable")
+
+		run := f.sync(t)
+		if run.Status != RunCompleted || run.Failed != 1 {
+			t.Errorf("Expected the record reported failed, got %+v", run)
+		}
+	})
+}
+
+func TestRunSync(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+
+	resp, err := f.service.RunSync(ctx, &pb.RunSyncRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Run.Status != RunRunning || resp.Run.Trigger != TriggerManual {
+		t.Errorf("Expected a running manual run, got %v", resp.Run)
+	}
+
+	if _, err := f.service.RunSync(ctx, &pb.RunSyncRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition while a run is in progress, got %v", err)
+	}
+
+	// A run left running past the stale threshold no longer blocks a sync
+	<-f.syncer.manual
+	f.now = f.now.Add(staleRunAfter + time.Minute)
+	f.sync(t)
+	got, err := f.service.GetSyncRun(ctx, &pb.GetSyncRunRequest{Id: resp.Run.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Run.Status != RunFailed {
+		t.Errorf("Expected the stale run failed, got %v", got.Run)
+	}
+
+	list, err := f.service.ListSyncRuns(ctx, &pb.ListSyncRunsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list.Total != 2 || list.Runs[0].Trigger != TriggerScheduled {
+		t.Errorf("Expected the scheduled run listed first, got %v", list.Runs)
+	}
+}
+
+func TestListSyncRunItems_InvalidRequest(t *testing.T) {
+	f := setupService()
+	run := f.sync(t)
+
+	tests := []struct {
+		name string
+		req  *pb.ListSyncRunItemsRequest
+		code codes.Code
+	}{
+		{"missing run", &pb.ListSyncRunItemsRequest{}, codes.InvalidArgument},
+		{"unknown status", &pb.ListSyncRunItemsRequest{RunId: run.ID, Status: ItemUnchanged}, codes.InvalidArgument},
+		{"unknown run", &pb.ListSyncRunItemsRequest{RunId: "run-404"}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.ListSyncRunItems(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package erpsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTPConfig locates a CSV export on the ERP's SFTP server
+type SFTPConfig struct {
+	// Addr is the server's host:port
+	Addr string
+	User string
+	// Password authenticates the user when PrivateKey is not set
+	Password string
+	// PrivateKey is a PEM private key authenticating the user
+	PrivateKey []byte
+	// HostKey is the server's public key in authorized_keys format; the
+	// connection is refused when the server presents another
+	HostKey string
+	// Path is the export's path on the server
+	Path string
+}
+
+// SFTPSource reads a CSV export (see ParseCSV) from an SFTP server
+type SFTPSource struct {
+	addr   string
+	path   string
+	config *ssh.ClientConfig
+}
+
+// NewSFTPSource creates a source reading the export described by cfg
+func NewSFTPSource(cfg SFTPConfig) (*SFTPSource, error) {
+	switch {
+	case cfg.Addr == "":
+		return nil, errors.New("SFTP address is required")
+	case cfg.User == "":
+		return nil, errors.New("SFTP user is required")
+	case cfg.Path == "":
+		return nil, errors.New("SFTP path is required")
+	case cfg.HostKey == "":
+		return nil, errors.New("SFTP host key is required")
+	}
+
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.HostKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SFTP host key: %w", err)
+	}
+
+	var auth ssh.AuthMethod
+	switch {
+	case len(cfg.PrivateKey) > 0:
+		signer, err := ssh.ParsePrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SFTP private key: %w", err)
+		}
+		auth = ssh.PublicKeys(signer)
+	case cfg.Password != "":
+		auth = ssh.Password(cfg.Password)
+	default:
+		return nil, errors.New("SFTP password or private key is required")
+	}
+
+	return &SFTPSource{
+		addr: cfg.Addr,
+		path: cfg.Path,
+		config: &ssh.ClientConfig{
+			User:            cfg.User,
+			Auth:            []ssh.AuthMethod{auth},
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+			Timeout:         30 * time.Second,
+		},
+	}, nil
+}
+
+// Name identifies the source in run reports
+func (s *SFTPSource) Name() string {
+	return "sftp"
+}
+
+// Fetch downloads and parses the export. The connection is closed when ctx
+// is cancelled.
+func (s *SFTPSource) Fetch(ctx context.Context) ([]*Record, error) {
+	conn, err := ssh.Dial("tcp", s.addr, s.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SFTP server: %w", err)
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start SFTP session: %w", err)
+	}
+	defer client.Close()
+
+	f, err := client.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", s.path, err)
+	}
+	defer f.Close()
+
+	records, err := ParseCSV(f)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+	return records, nil
+}
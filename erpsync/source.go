@@ -0,0 +1,143 @@
+package erpsync
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxSKULength is the longest SKU the catalog accepts
+	maxSKULength = 100
+	// maxPrice fits the catalog's DECIMAL(10, 2) price column
+	maxPrice = 99999999.99
+)
+
+// Record is the stock and list price the ERP holds for a SKU
+type Record struct {
+	SKU string
+	// SetStock is false when the ERP does not report the stock
+	SetStock bool
+	Stock    int32
+	// Price is 0 when the ERP does not report the price
+	Price float64
+	// Problem is why the record could not be read; such records are reported
+	// INVALID and not applied
+	Problem string
+}
+
+// Source pulls the current stock and prices from the ERP
+type Source interface {
+	// Name identifies the source in run reports
+	Name() string
+	// Fetch reads every record the ERP exports. Unreadable records are
+	// returned with a Problem; an error means the export as a whole could not
+	// be read.
+	Fetch(ctx context.Context) ([]*Record, error)
+}
+
+// ParseCSV reads an ERP stock export. The first row is a header naming a sku
+// column and a stock column, a price column or both, in any order and case;
+// other columns are ignored. An empty stock or price cell leaves that value
+// unreported.
+func ParseCSV(r io.Reader) ([]*Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("export is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	skuCol, stockCol, priceCol := -1, -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "sku":
+			skuCol = i
+		case "stock":
+			stockCol = i
+		case "price":
+			priceCol = i
+		}
+	}
+	if skuCol < 0 {
+		return nil, errors.New("header has no sku column")
+	}
+	if stockCol < 0 && priceCol < 0 {
+		return nil, errors.New("header has neither a stock nor a price column")
+	}
+
+	var records []*Record
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read export: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, parseRow(row, line, skuCol, stockCol, priceCol))
+	}
+}
+
+// parseRow reads the record on a CSV line, setting its Problem when a cell
+// cannot be read
+func parseRow(row []string, line, skuCol, stockCol, priceCol int) *Record {
+	cell := func(col int) string {
+		if col < 0 || col >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[col])
+	}
+
+	record := &Record{SKU: cell(skuCol)}
+	if record.SKU == "" {
+		record.Problem = fmt.Sprintf("line %d: sku is required", line)
+		return record
+	}
+	if s := cell(stockCol); s != "" {
+		stock, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || stock < 0 {
+			record.Problem = fmt.Sprintf("line %d: stock %q is not a whole number of 0 or more", line, s)
+			return record
+		}
+		record.SetStock, record.Stock = true, int32(stock)
+	}
+	if s := cell(priceCol); s != "" {
+		price, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.Round(price*100) < 1 {
+			record.Problem = fmt.Sprintf("line %d: price %q is not a number of 0.01 or more", line, s)
+			return record
+		}
+		record.Price = price
+	}
+	record.Problem = valueProblem(record)
+	if record.Problem != "" {
+		record.Problem = fmt.Sprintf("line %d: %s", line, record.Problem)
+	}
+	return record
+}
+
+// valueProblem checks the values of a record and returns a message describing
+// the first problem, or "". Prices are rounded to cents.
+func valueProblem(record *Record) string {
+	record.Price = math.Round(record.Price*100) / 100
+	switch {
+	case len(record.SKU) > maxSKULength:
+		return "sku cannot exceed 100 characters"
+	case math.IsNaN(record.Price) || record.Price < 0 || record.Price > maxPrice:
+		return "price must be between 0.01 and 99999999.99"
+	case !record.SetStock && record.Price == 0:
+		return "reports neither stock nor price"
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package erpsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	export := "\ufeffPrice,SKU,Description,Stock\n" +
+		"19.99,SKU-1,Widget,10\n" +
+		",SKU-2,Gadget,7\n" +
+		"5.255,SKU-3,,\n" +
+		"1.00,,No SKU,1\n" +
+		"2.00,SKU-5,Broken,-1\n" +
+		"abc,SKU-6,Broken,1\n" +
+		",SKU-7,Nothing,\n" +
+		"0,SKU-8,Free,1\n"
+
+	records, err := ParseCSV(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 8 {
+		t.Fatalf("Expected 8 records, got %d", len(records))
+	}
+
+	want := []Record{
+		{SKU: "SKU-1", SetStock: true, Stock: 10, Price: 19.99},
+		{SKU: "SKU-2", SetStock: true, Stock: 7},
+		{SKU: "SKU-3", Price: 5.26},
+	}
+	for i, w := range want {
+		if *records[i] != w {
+			t.Errorf("Record %d: expected %+v, got %+v", i, w, *records[i])
+		}
+	}
+	for i, r := range records[3:] {
+		if r.Problem == "" {
+			t.Errorf("Expected a problem with %+v", r)
+		}
+		if prefix := fmt.Sprintf("line %d:", i+5); !strings.HasPrefix(r.Problem, prefix) {
+			t.Errorf("Expected the problem on %s, got %q", prefix, r.Problem)
+		}
+	}
+}
+
+func TestParseCSV_BadHeader(t *testing.T) {
+	for _, export := range []string{"", "stock,price\n1,2\n", "sku,name\nA,B\n"} {
+		if _, err := ParseCSV(strings.NewReader(export)); err == nil {
+			t.Errorf("Expected an error for %q", export)
+		}
+	}
+}
+
+func TestRESTSource(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprint(w, `{"items": [{"sku": "SKU-1", "stock": 10, "price": 19.99}, {"sku": "SKU-2", "stock": null}], "next": "?page=2"}`)
+		case "2":
+			fmt.Fprint(w, `{"items": [{"sku": "SKU-3", "price": 5}, {"sku": "", "stock": 1}, {"sku": "SKU-5", "stock": -1}]}`)
+		}
+	}))
+	defer server.Close()
+
+	source, err := NewRESTSource(server.URL+"/stock", "secret")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	records, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(auth) != 2 || auth[0] != "Bearer secret" {
+		t.Errorf("Expected both pages fetched with the token, got %v", auth)
+	}
+	if len(records) != 5 {
+		t.Fatalf("Expected 5 records, got %d", len(records))
+	}
+	if r := records[0]; !r.SetStock || r.Stock != 10 || r.Price != 19.99 || r.Problem != "" {
+		t.Errorf("Unexpected first record %+v", r)
+	}
+	if r := records[1]; r.Problem != "item 2: reports neither stock nor price" {
+		t.Errorf("Expected an item reporting nothing invalid, got %+v", r)
+	}
+	if r := records[2]; r.SetStock || r.Price != 5 || r.Problem != "" {
+		t.Errorf("Unexpected third record %+v", r)
+	}
+	if records[3].Problem == "" || records[4].Problem == "" {
+		t.Errorf("Expected the last two records invalid, got %+v and %+v", records[3], records[4])
+	}
+}
+
+func TestRESTSource_ForeignNextPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": [], "next": "https://attacker.example/steal"}`)
+	}))
+	defer server.Close()
+
+	source, err := NewRESTSource(server.URL, "secret")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := source.Fetch(context.Background()); err == nil {
+		t.Error("Expected a next page on another host to be refused")
+	}
+}
+
+func TestNewSFTPSource_InvalidConfig(t *testing.T) {
+	const hostKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGgfJbNZp3pEWWvIcmM5qO6o6XXEhaEyQTvBOM5oWZyO"
+	valid := SFTPConfig{Addr: "erp:22", User: "shop", Password: "secret", HostKey: hostKey, Path: "/exports/stock.csv"}
+	if _, err := NewSFTPSource(valid); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(c *SFTPConfig)
+	}{
+		{"missing address", func(c *SFTPConfig) { c.Addr = "" }},
+		{"missing path", func(c *SFTPConfig) { c.Path = "" }},
+		{"missing host key", func(c *SFTPConfig) { c.HostKey = "" }},
+		{"invalid host key", func(c *SFTPConfig) { c.HostKey = "not a key" }},
+		{"no credentials", func(c *SFTPConfig) { c.Password = "" }},
+		{"invalid private key", func(c *SFTPConfig) { c.PrivateKey = []byte("not a key") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.change(&cfg)
+			if _, err := NewSFTPSource(cfg); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
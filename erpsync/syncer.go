@@ -0,0 +1,248 @@
+package erpsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// DefaultSyncInterval is how often the ERP is synced
+	DefaultSyncInterval = time.Hour
+	// staleRunAfter is how long a run can stay RUNNING before it is taken to
+	// have died with its process
+	staleRunAfter = 2 * time.Hour
+)
+
+// Syncer pulls stock and prices from the ERP and applies those that differ
+// from the catalog, recording a reconciliation report of every record that
+// changed the catalog or could not be applied. Only one run is in progress
+// at a time across replicas.
+type Syncer struct {
+	source  Source
+	catalog Catalog
+	repo    Repository
+	log     *logger.Logger
+	now     func() time.Time
+	// manual holds runs started by Trigger for the Run loop
+	manual chan *Run
+}
+
+// NewSyncer creates a syncer applying records from source to catalog
+func NewSyncer(source Source, catalog Catalog, repo Repository, log *logger.Logger) *Syncer {
+	return &Syncer{
+		source:  source,
+		catalog: catalog,
+		repo:    repo,
+		log:     log,
+		now:     time.Now,
+		manual:  make(chan *Run, 1),
+	}
+}
+
+// Run syncs every interval, and whenever Trigger starts a run, until ctx is
+// cancelled
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case run := <-s.manual:
+			if err := s.reconcile(ctx, run); err != nil {
+				s.log.Error(ctx, "Failed to finish ERP sync", map[string]interface{}{"error": err.Error(), "run_id": run.ID})
+			}
+		case <-ticker.C:
+			_, err := s.Sync(ctx, TriggerScheduled)
+			if errors.Is(err, ErrRunInProgress) {
+				s.log.Info(ctx, "ERP sync skipped: another run is in progress", nil)
+			} else if err != nil {
+				s.log.Error(ctx, "Failed to sync ERP", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
+
+// Trigger starts a run to be carried out by the Run loop and returns it
+// RUNNING, or ErrRunInProgress
+func (s *Syncer) Trigger(ctx context.Context) (*Run, error) {
+	run, err := s.start(ctx, TriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	s.manual <- run
+	return run, nil
+}
+
+// Sync carries out a run and returns it finished. A run that could not read
+// the ERP or the catalog is returned FAILED; an error means the run could
+// not be recorded.
+func (s *Syncer) Sync(ctx context.Context, trigger string) (*Run, error) {
+	run, err := s.start(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reconcile(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *Syncer) start(ctx context.Context, trigger string) (*Run, error) {
+	run := &Run{Source: s.source.Name(), Trigger: trigger, StartedAt: s.now()}
+	if err := s.repo.StartRun(ctx, run, run.StartedAt.Add(-staleRunAfter)); err != nil {
+		return nil, err
+	}
+	s.log.Info(ctx, "ERP sync started", map[string]interface{}{"run_id": run.ID, "trigger": trigger})
+	return run, nil
+}
+
+// reconcile carries out a started run and records its outcome. The outcome
+// is recorded even when ctx is cancelled, so a run interrupted by shutdown is
+// reported FAILED.
+func (s *Syncer) reconcile(ctx context.Context, run *Run) error {
+	items, err := s.apply(ctx, run)
+	run.Status = RunCompleted
+	if err != nil {
+		run.Status, run.Error = RunFailed, err.Error()
+	}
+	finishedAt := s.now()
+	run.FinishedAt = &finishedAt
+
+	if err := s.repo.FinishRun(context.WithoutCancel(ctx), run, items); err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"run_id":    run.ID,
+		"records":   run.Records,
+		"updated":   run.Updated,
+		"unchanged": run.Unchanged,
+		"not_found": run.NotFound,
+		"rejected":  run.Rejected,
+		"invalid":   run.Invalid,
+		"failed":    run.Failed,
+	}
+	if run.Status == RunFailed {
+		fields["error"] = run.Error
+		s.log.Error(ctx, "ERP sync failed", fields)
+	} else {
+		s.log.Info(ctx, "ERP sync completed", fields)
+	}
+	return nil
+}
+
+// apply fetches the ERP records, diffs them against the catalog and applies
+// those that differ in batches. It returns the report items, counting every
+// record into the run, and an error when the run cannot go on.
+func (s *Syncer) apply(ctx context.Context, run *Run) ([]*Item, error) {
+	records, err := s.source.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ERP export: %w", err)
+	}
+	run.Records = int32(len(records))
+
+	var items []*Item
+	report := func(item *Item) {
+		run.count(item.Status)
+		if item.Status != ItemUnchanged {
+			items = append(items, item)
+		}
+	}
+
+	// A SKU exported twice is ambiguous, so neither record is applied
+	occurrences := make(map[string]int, len(records))
+	for _, r := range records {
+		if r.Problem == "" {
+			occurrences[r.SKU]++
+		}
+	}
+	var valid []*Record
+	for _, r := range records {
+		switch {
+		case r.Problem != "":
+			report(recordItem(r, ItemInvalid, r.Problem))
+		case occurrences[r.SKU] > 1:
+			report(recordItem(r, ItemInvalid, "sku appears more than once in the export"))
+		default:
+			valid = append(valid, r)
+		}
+	}
+
+	var changed []*Record
+	for start := 0; start < len(valid); start += lookupBatchSize {
+		batch := valid[start:min(start+lookupBatchSize, len(valid))]
+		skus := make([]string, len(batch))
+		for i, r := range batch {
+			skus[i] = r.SKU
+		}
+		products, err := s.catalog.Lookup(ctx, skus)
+		if err != nil {
+			return items, fmt.Errorf("failed to look up catalog products: %w", err)
+		}
+		for _, r := range batch {
+			p, ok := products[r.SKU]
+			switch {
+			case !ok:
+				report(recordItem(r, ItemNotFound, ""))
+			case differs(r, p):
+				changed = append(changed, r)
+			default:
+				report(recordItem(r, ItemUnchanged, ""))
+			}
+		}
+	}
+
+	for start := 0; start < len(changed); start += applyBatchSize {
+		batch := changed[start:min(start+applyBatchSize, len(changed))]
+		results, err := s.catalog.Apply(ctx, batch)
+		if err == nil && len(results) != len(batch) {
+			err = fmt.Errorf("catalog returned %d results for %d updates", len(results), len(batch))
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return items, ctx.Err()
+			}
+			s.log.Error(ctx, "Failed to apply ERP batch", map[string]interface{}{"error": err.Error(), "run_id": run.ID, "size": len(batch)})
+			for _, r := range batch {
+				report(recordItem(r, ItemFailed, "catalog failed to apply the batch"))
+			}
+			continue
+		}
+		for i, item := range results {
+			item.ERPHasStock, item.ERPStock, item.ERPPrice = batch[i].SetStock, batch[i].Stock, batch[i].Price
+			report(item)
+		}
+	}
+	return items, nil
+}
+
+// differs reports whether a record would change the product's stock or price
+func differs(r *Record, p *Product) bool {
+	if r.SetStock && r.Stock != p.Stock {
+		return true
+	}
+	return r.Price > 0 && math.Round(r.Price*100) != math.Round(p.Price*100)
+}
+
+// recordItem reports a record that was not applied. The values of an
+// INVALID record are left out, as they may not be representable.
+func recordItem(r *Record, status, message string) *Item {
+	if status == ItemInvalid {
+		return &Item{SKU: r.SKU, Status: status, Message: message}
+	}
+	return &Item{
+		SKU:         r.SKU,
+		Status:      status,
+		Message:     message,
+		ERPHasStock: r.SetStock,
+		ERPStock:    r.Stock,
+		ERPPrice:    r.Price,
+	}
+}
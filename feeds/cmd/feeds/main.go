@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/feeds"
+	"github.com/Ujjwaljain16/E-commerce-Backend/feeds/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("feed-service")
+	log.Info(ctx, "Starting Feed Service", nil)
+
+	// Get configuration from environment
+	port := getEnv("PORT", "50080")
+	metricsPort := getEnv("METRICS_PORT", "9120")
+	catalogAddr := getEnv("CATALOG_ADDR", "localhost:50052")
+	cfg := feeds.Config{
+		StorefrontURL: getEnv("STOREFRONT_URL", "http://localhost:3000"),
+		Title:         getEnv("STORE_NAME", "E-commerce Store"),
+		Currency:      getEnv("FEED_CURRENCY", "USD"),
+		Brand:         os.Getenv("FEED_BRAND"),
+		CacheMaxAge:   getEnvDuration("FEED_CACHE_MAX_AGE", feeds.DefaultCacheMaxAge),
+	}
+
+	// Feeds are served to merchant platforms and crawlers from a public bucket
+	store, err := storage.NewS3Client(storage.Config{
+		Endpoint:  getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		Region:    getEnv("S3_REGION", "us-east-1"),
+		Bucket:    getEnv("FEEDS_BUCKET", "feeds"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PathStyle: getEnv("S3_PATH_STYLE", "true") == "true",
+		PublicURL: os.Getenv("FEEDS_PUBLIC_URL"),
+	})
+	if err != nil {
+		log.Error(ctx, "Failed to configure feed storage", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	catalogConn, err := grpc.NewClient(catalogAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Error(ctx, "Failed to create catalog service client", map[string]interface{}{
+			"error": err.Error(),
+			"addr":  catalogAddr,
+		})
+		os.Exit(1)
+	}
+	defer catalogConn.Close()
+
+	// Feeds are generated when asked for; the jobs service asks on a schedule
+	generator := feeds.NewGenerator(catalogpb.NewCatalogServiceClient(catalogConn), store, cfg, log)
+	service := feeds.NewService(generator, log)
+
+	// IP filtering: admin network allowlist and the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, feeds.AdminMethods)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Create gRPC server with metrics and IP filter interceptors
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("feed-service"),
+			ipFilter.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterFeedServiceServer(grpcServer, service)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("feeds.FeedService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Enable reflection for grpcurl/grpcui
+	reflection.Register(grpcServer)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start gRPC server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Error(ctx, "Failed to listen", map[string]interface{}{
+			"error": err.Error(),
+			"port":  port,
+		})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "Feed Service listening", map[string]interface{}{
+		"port":           port,
+		"metrics_port":   metricsPort,
+		"catalog_addr":   catalogAddr,
+		"storefront_url": cfg.StorefrontURL,
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		grpcServer.GracefulStop()
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,266 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+)
+
+// Feed formats
+const (
+	FormatGoogle   = "GOOGLE_SHOPPING"
+	FormatFacebook = "FACEBOOK"
+)
+
+// googleNamespace is the namespace of the g: product attributes, which both
+// Google Merchant Center and Facebook catalogs read
+const googleNamespace = "http://base.google.com/ns/1.0"
+
+// maxAdditionalImages is the most additional images the feeds accept
+const maxAdditionalImages = 10
+
+// limits are the title and description lengths a format accepts
+var limits = map[string]struct{ title, description int }{
+	FormatGoogle:   {title: 150, description: 5000},
+	FormatFacebook: {title: 200, description: 9999},
+}
+
+// Item is a product as it is listed in the feeds
+type Item struct {
+	ID               string
+	Title            string
+	Description      string
+	Link             string
+	ImageLink        string
+	AdditionalImages []string
+	Price            float64
+	SalePrice        float64
+	SaleStartsAt     time.Time
+	SaleEndsAt       time.Time
+	InStock          bool
+	Category         string
+	GTIN             string
+	MPN              string
+	ShippingWeightKg float64
+	UpdatedAt        time.Time
+}
+
+// ProductLink is the storefront page of a product
+func ProductLink(storefrontURL, productID string) string {
+	return strings.TrimRight(storefrontURL, "/") + "/products/" + productID
+}
+
+// itemFromProduct builds the feed item of a product. Products without a
+// price or an image are left out, since the feeds reject them.
+func itemFromProduct(p *catalogpb.Product, storefrontURL string, now time.Time) (Item, bool) {
+	if p.Price <= 0 {
+		return Item{}, false
+	}
+
+	var images []string
+	for _, img := range p.ImageDetails {
+		if img.ValidationStatus == "FAILED" {
+			continue
+		}
+		if img.IsPrimary {
+			images = append([]string{img.Url}, images...)
+		} else {
+			images = append(images, img.Url)
+		}
+	}
+	if len(p.ImageDetails) == 0 {
+		images = p.Images
+	}
+	if len(images) == 0 {
+		return Item{}, false
+	}
+
+	item := Item{
+		ID:               p.Id,
+		Title:            p.Name,
+		Description:      p.Description,
+		Link:             ProductLink(storefrontURL, p.Id),
+		ImageLink:        images[0],
+		AdditionalImages: images[1:min(len(images), maxAdditionalImages+1)],
+		Price:            p.Price,
+		InStock:          p.ProductType == "DIGITAL" || p.Stock > 0,
+		Category:         p.Category,
+		GTIN:             firstNonEmpty(p.Ean, p.Upc, p.Isbn),
+		MPN:              p.Sku,
+		ShippingWeightKg: p.WeightKg,
+	}
+	if item.Description == "" {
+		item.Description = p.Name
+	}
+	if p.UpdatedAt != nil {
+		item.UpdatedAt = p.UpdatedAt.AsTime()
+	}
+	if p.SalePrice > 0 && p.SalePrice < p.Price {
+		item.SalePrice = p.SalePrice
+		if p.SaleStartsAt != nil {
+			item.SaleStartsAt = p.SaleStartsAt.AsTime()
+		}
+		if p.SaleEndsAt != nil {
+			item.SaleEndsAt = p.SaleEndsAt.AsTime()
+		}
+		// A sale that has ended is not listed, and one without a start has
+		// started
+		if !item.SaleEndsAt.IsZero() && !item.SaleEndsAt.After(now) {
+			item.SalePrice = 0
+		}
+		if item.SaleStartsAt.IsZero() && !item.SaleEndsAt.IsZero() {
+			item.SaleStartsAt = now
+		}
+	}
+	return item, true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Channel describes a feed as a whole
+type Channel struct {
+	Title    string
+	Link     string
+	Currency string
+	// Brand is the brand of every product; products have no brand of their own
+	Brand string
+}
+
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	Namespace string     `xml:"xmlns:g,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	ID                     string   `xml:"g:id"`
+	Title                  string   `xml:"g:title"`
+	Description            string   `xml:"g:description"`
+	Link                   string   `xml:"g:link"`
+	ImageLink              string   `xml:"g:image_link"`
+	AdditionalImageLinks   []string `xml:"g:additional_image_link"`
+	Availability           string   `xml:"g:availability"`
+	Price                  string   `xml:"g:price"`
+	SalePrice              string   `xml:"g:sale_price,omitempty"`
+	SalePriceEffectiveDate string   `xml:"g:sale_price_effective_date,omitempty"`
+	Condition              string   `xml:"g:condition"`
+	Brand                  string   `xml:"g:brand,omitempty"`
+	GTIN                   string   `xml:"g:gtin,omitempty"`
+	MPN                    string   `xml:"g:mpn,omitempty"`
+	IdentifierExists       string   `xml:"g:identifier_exists,omitempty"`
+	ProductType            string   `xml:"g:product_type,omitempty"`
+	ShippingWeight         string   `xml:"g:shipping_weight,omitempty"`
+}
+
+// WriteProductFeed encodes items as an RSS 2.0 product feed in format
+func WriteProductFeed(format string, channel Channel, items []Item) ([]byte, error) {
+	limit, ok := limits[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown feed format %q", format)
+	}
+
+	feed := rss{
+		Version:   "2.0",
+		Namespace: googleNamespace,
+		Channel: rssChannel{
+			Title:       channel.Title,
+			Link:        channel.Link,
+			Description: channel.Title + " products",
+			Items:       make([]rssItem, len(items)),
+		},
+	}
+	for i, item := range items {
+		entry := rssItem{
+			ID:                   item.ID,
+			Title:                truncate(item.Title, limit.title),
+			Description:          truncate(item.Description, limit.description),
+			Link:                 item.Link,
+			ImageLink:            item.ImageLink,
+			AdditionalImageLinks: item.AdditionalImages,
+			Availability:         availability(format, item.InStock),
+			Price:                formatPrice(item.Price, channel.Currency),
+			Condition:            "new",
+			Brand:                channel.Brand,
+			GTIN:                 item.GTIN,
+			MPN:                  item.MPN,
+			ProductType:          item.Category,
+		}
+		if item.SalePrice > 0 {
+			entry.SalePrice = formatPrice(item.SalePrice, channel.Currency)
+			entry.SalePriceEffectiveDate = effectiveDate(item.SaleStartsAt, item.SaleEndsAt)
+		}
+		// Products need two of GTIN, brand and MPN to be identified
+		if format == FormatGoogle && item.GTIN == "" && channel.Brand == "" {
+			entry.IdentifierExists = "no"
+		}
+		if item.ShippingWeightKg > 0 {
+			entry.ShippingWeight = strconv.FormatFloat(item.ShippingWeightKg, 'f', -1, 64) + " kg"
+		}
+		feed.Channel.Items[i] = entry
+	}
+
+	data, err := encodeXML(feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s feed: %w", format, err)
+	}
+	return data, nil
+}
+
+// availability is the stock value of a format: Google's spelling uses
+// underscores, Facebook's spaces
+func availability(format string, inStock bool) string {
+	value := "out_of_stock"
+	if inStock {
+		value = "in_stock"
+	}
+	if format == FormatFacebook {
+		value = strings.ReplaceAll(value, "_", " ")
+	}
+	return value
+}
+
+func formatPrice(amount float64, currency string) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64) + " " + currency
+}
+
+// effectiveDate is the ISO 8601 interval of a sale. The feeds need both
+// ends, so a sale without an end is given one a year after it starts.
+func effectiveDate(starts, ends time.Time) string {
+	if starts.IsZero() {
+		return ""
+	}
+	if ends.IsZero() {
+		ends = starts.AddDate(1, 0, 0)
+	}
+	const layout = "2006-01-02T15:04Z07:00"
+	return starts.UTC().Format(layout) + "/" + ends.UTC().Format(layout)
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:n]))
+}
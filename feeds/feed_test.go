@@ -0,0 +1,127 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestItemFromProduct(t *testing.T) {
+	p := &catalogpb.Product{
+		Id:          "p1",
+		Name:        "Red Mug",
+		Price:       12.5,
+		Sku:         "MUG-RED",
+		Stock:       3,
+		Category:    "Kitchen",
+		Ean:         "4006381333931",
+		WeightKg:    0.4,
+		SalePrice:   9.99,
+		SaleEndsAt:  timestamppb.New(testNow.Add(48 * time.Hour)),
+		UpdatedAt:   timestamppb.New(testNow),
+		ProductType: "PHYSICAL",
+		ImageDetails: []*catalogpb.ProductImage{
+			{Url: "https://cdn.test/side.jpg"},
+			{Url: "https://cdn.test/broken.jpg", ValidationStatus: "FAILED"},
+			{Url: "https://cdn.test/front.jpg", IsPrimary: true},
+		},
+	}
+
+	item, ok := itemFromProduct(p, "https://shop.test/", testNow)
+
+	if !ok {
+		t.Fatal("Expected the product listed")
+	}
+
+	if item.Link != "https://shop.test/products/p1" || item.Description != "Red Mug" {
+		t.Errorf("Expected storefront link and name as description, got %q %q", item.Link, item.Description)
+	}
+
+	if item.ImageLink != "https://cdn.test/front.jpg" || len(item.AdditionalImages) != 1 || item.AdditionalImages[0] != "https://cdn.test/side.jpg" {
+		t.Errorf("Expected primary image first and failed images left out, got %q %v", item.ImageLink, item.AdditionalImages)
+	}
+
+	if item.SalePrice != 9.99 || !item.SaleStartsAt.Equal(testNow) || !item.InStock || item.GTIN != "4006381333931" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+}
+
+func TestItemFromProduct_LeftOut(t *testing.T) {
+	if _, ok := itemFromProduct(&catalogpb.Product{Id: "p1", Images: []string{"a.jpg"}}, "https://shop.test", testNow); ok {
+		t.Error("Expected a product without a price left out")
+	}
+	if _, ok := itemFromProduct(&catalogpb.Product{Id: "p2", Price: 5}, "https://shop.test", testNow); ok {
+		t.Error("Expected a product without images left out")
+	}
+
+	ended := &catalogpb.Product{Id: "p3", Price: 5, SalePrice: 4, SaleEndsAt: timestamppb.New(testNow.Add(-time.Hour)), Images: []string{"a.jpg"}, ProductType: "DIGITAL"}
+	item, ok := itemFromProduct(ended, "https://shop.test", testNow)
+	if !ok || item.SalePrice != 0 || !item.InStock {
+		t.Errorf("Expected an ended sale dropped and digital products in stock, got %+v", item)
+	}
+}
+
+func TestWriteProductFeed(t *testing.T) {
+	items := []Item{{
+		ID:           "p1",
+		Title:        "Red Mug & Saucer",
+		Description:  strings.Repeat("x", 6000),
+		Link:         "https://shop.test/products/p1",
+		ImageLink:    "https://cdn.test/front.jpg",
+		Price:        12.5,
+		SalePrice:    9.99,
+		SaleStartsAt: testNow,
+		SaleEndsAt:   testNow.Add(48 * time.Hour),
+		MPN:          "MUG-RED",
+	}}
+	channel := Channel{Title: "Test Shop", Link: "https://shop.test", Currency: "EUR"}
+
+	google, err := WriteProductFeed(FormatGoogle, channel, items)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, want := range []string{
+		`<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">`,
+		`<g:title>Red Mug &amp; Saucer</g:title>`,
+		`<g:availability>out_of_stock</g:availability>`,
+		`<g:price>12.50 EUR</g:price>`,
+		`<g:sale_price>9.99 EUR</g:sale_price>`,
+		`<g:sale_price_effective_date>2026-06-01T12:00Z/2026-06-03T12:00Z</g:sale_price_effective_date>`,
+		`<g:identifier_exists>no</g:identifier_exists>`,
+	} {
+		if !strings.Contains(string(google), want) {
+			t.Errorf("Expected Google feed to contain %s", want)
+		}
+	}
+	if strings.Contains(string(google), strings.Repeat("x", 5001)) {
+		t.Error("Expected description truncated to 5000 characters")
+	}
+
+	facebook, err := WriteProductFeed(FormatFacebook, channel, items)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(facebook), `<g:availability>out of stock</g:availability>`) {
+		t.Error("Expected Facebook availability spelling")
+	}
+
+	var parsed struct {
+		Items []struct {
+			ID string `xml:"id"`
+		} `xml:"channel>item"`
+	}
+	if err := xml.Unmarshal(facebook, &parsed); err != nil || len(parsed.Items) != 1 || parsed.Items[0].ID != "p1" {
+		t.Errorf("Expected well-formed XML, got %+v, %v", parsed, err)
+	}
+
+	if _, err := WriteProductFeed("PINTEREST", channel, items); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,223 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"google.golang.org/grpc"
+)
+
+// Feed names
+const (
+	FeedSitemapIndex = "SITEMAP_INDEX"
+	FeedSitemap      = "SITEMAP"
+)
+
+// Object keys of the feeds
+const (
+	GoogleFeedKey   = "google-shopping.xml"
+	FacebookFeedKey = "facebook.xml"
+	SitemapIndexKey = "sitemap.xml"
+)
+
+// catalogPageSize is the page size products are listed with
+const catalogPageSize = 100
+
+// DefaultCacheMaxAge is how long clients and CDNs may cache the feeds
+const DefaultCacheMaxAge = time.Hour
+
+// feedChannel is the storefront channel whose visibility rules the feeds
+// apply, as a guest browsing the web store
+const feedChannel = "WEB"
+
+// ErrGenerationRunning is returned when feeds are asked for while they are
+// being generated
+var ErrGenerationRunning = errors.New("feed generation already running")
+
+// Store is the object storage the feeds are served from
+type Store interface {
+	PutObject(ctx context.Context, key string, body []byte, opts storage.PutOptions) error
+	URL(key string) string
+}
+
+// Catalog is the part of the catalog service the feeds are read from
+type Catalog interface {
+	ListProducts(ctx context.Context, in *catalogpb.ListProductsRequest, opts ...grpc.CallOption) (*catalogpb.ListProductsResponse, error)
+}
+
+// Config holds the storefront details written into the feeds
+type Config struct {
+	// StorefrontURL is the base URL product pages are linked under, as
+	// <StorefrontURL>/products/<id>
+	StorefrontURL string
+	Title         string
+	Currency      string
+	Brand         string
+	CacheMaxAge   time.Duration
+}
+
+// File is a stored feed
+type File struct {
+	Name      string
+	URL       string
+	Items     int
+	SizeBytes int64
+}
+
+// Result is the outcome of a generation
+type Result struct {
+	Files       []File
+	GeneratedAt time.Time
+}
+
+// Generator builds the product feeds and sitemaps from the catalog and
+// stores them
+type Generator struct {
+	catalog Catalog
+	store   Store
+	cfg     Config
+	log     *logger.Logger
+	running sync.Mutex
+	now     func() time.Time
+}
+
+// NewGenerator creates a new generator
+func NewGenerator(catalog Catalog, store Store, cfg Config, log *logger.Logger) *Generator {
+	if cfg.CacheMaxAge <= 0 {
+		cfg.CacheMaxAge = DefaultCacheMaxAge
+	}
+	return &Generator{
+		catalog: catalog,
+		store:   store,
+		cfg:     cfg,
+		log:     log,
+		now:     time.Now,
+	}
+}
+
+// Generate builds every feed from the current catalog and replaces the
+// stored files. Only one generation runs at a time.
+func (g *Generator) Generate(ctx context.Context) (*Result, error) {
+	if !g.running.TryLock() {
+		return nil, ErrGenerationRunning
+	}
+	defer g.running.Unlock()
+
+	now := g.now()
+	products, err := g.products(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(products))
+	urls := make([]SitemapURL, 0, len(products))
+	for _, p := range products {
+		item, ok := itemFromProduct(p, g.cfg.StorefrontURL, now)
+		if ok {
+			items = append(items, item)
+		}
+		// Products the shopping feeds leave out still have a page
+		var updated time.Time
+		if p.UpdatedAt != nil {
+			updated = p.UpdatedAt.AsTime()
+		}
+		urls = append(urls, SitemapURL{Loc: ProductLink(g.cfg.StorefrontURL, p.Id), LastMod: updated})
+	}
+
+	result := &Result{GeneratedAt: now}
+	channel := Channel{Title: g.cfg.Title, Link: g.cfg.StorefrontURL, Currency: g.cfg.Currency, Brand: g.cfg.Brand}
+	for _, feed := range []struct{ format, key string }{
+		{FormatGoogle, GoogleFeedKey},
+		{FormatFacebook, FacebookFeedKey},
+	} {
+		data, err := WriteProductFeed(feed.format, channel, items)
+		if err != nil {
+			return nil, err
+		}
+		file, err := g.put(ctx, feed.format, feed.key, data, len(items))
+		if err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	// Sitemaps are stored before the index that lists them, so the index
+	// never points at a sitemap that is not there
+	var sitemaps []SitemapURL
+	for i, chunk := range chunkURLs(urls, MaxSitemapURLs) {
+		data, err := WriteSitemap(chunk)
+		if err != nil {
+			return nil, err
+		}
+		file, err := g.put(ctx, FeedSitemap, "sitemap-products-"+strconv.Itoa(i+1)+".xml", data, len(chunk))
+		if err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, file)
+		sitemaps = append(sitemaps, SitemapURL{Loc: file.URL, LastMod: now})
+	}
+	data, err := WriteSitemapIndex(sitemaps)
+	if err != nil {
+		return nil, err
+	}
+	file, err := g.put(ctx, FeedSitemapIndex, SitemapIndexKey, data, len(sitemaps))
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, file)
+
+	g.log.Info(ctx, "Feeds generated", map[string]interface{}{
+		"products": len(products),
+		"items":    len(items),
+		"sitemaps": len(sitemaps),
+	})
+	return result, nil
+}
+
+// products lists the products a guest sees on the web store. A product
+// created while paging shifts the pages by one, so products already seen
+// are skipped.
+func (g *Generator) products(ctx context.Context) ([]*catalogpb.Product, error) {
+	var products []*catalogpb.Product
+	seen := make(map[string]bool)
+	for page := int32(1); ; page++ {
+		resp, err := g.catalog.ListProducts(ctx, &catalogpb.ListProductsRequest{
+			Page:     page,
+			PageSize: catalogPageSize,
+			Channel:  feedChannel,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list products: %w", err)
+		}
+
+		for _, p := range resp.Products {
+			if !seen[p.Id] {
+				seen[p.Id] = true
+				products = append(products, p)
+			}
+		}
+
+		if len(resp.Products) < catalogPageSize || page*catalogPageSize >= resp.Total {
+			return products, nil
+		}
+	}
+}
+
+// put stores a feed with its cache headers
+func (g *Generator) put(ctx context.Context, name, key string, data []byte, items int) (File, error) {
+	opts := storage.PutOptions{
+		ContentType:  "application/xml",
+		CacheControl: fmt.Sprintf("public, max-age=%d", int(g.cfg.CacheMaxAge.Seconds())),
+	}
+	if err := g.store.PutObject(ctx, key, data, opts); err != nil {
+		return File{}, fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return File{Name: name, URL: g.store.URL(key), Items: items, SizeBytes: int64(len(data))}, nil
+}
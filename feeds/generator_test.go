@@ -0,0 +1,136 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"google.golang.org/grpc"
+)
+
+// mockStore keeps feeds in memory, in the order they were stored
+type mockStore struct {
+	mu     sync.Mutex
+	keys   []string
+	bodies map[string][]byte
+	opts   map[string]storage.PutOptions
+	// entered is sent to and block waited on by each PutObject, when set
+	entered chan struct{}
+	block   chan struct{}
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{bodies: map[string][]byte{}, opts: map[string]storage.PutOptions{}}
+}
+
+func (m *mockStore) PutObject(ctx context.Context, key string, body []byte, opts storage.PutOptions) error {
+	if m.block != nil {
+		m.entered <- struct{}{}
+		<-m.block
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys = append(m.keys, key)
+	m.bodies[key] = body
+	m.opts[key] = opts
+	return nil
+}
+
+func (m *mockStore) URL(key string) string {
+	return "https://feeds.test/" + key
+}
+
+// mockCatalog serves products in pages
+type mockCatalog struct {
+	products []*catalogpb.Product
+	requests []*catalogpb.ListProductsRequest
+}
+
+func (m *mockCatalog) ListProducts(ctx context.Context, in *catalogpb.ListProductsRequest, opts ...grpc.CallOption) (*catalogpb.ListProductsResponse, error) {
+	m.requests = append(m.requests, in)
+	start := int(in.Page-1) * int(in.PageSize)
+	end := min(start+int(in.PageSize), len(m.products))
+	if start > end {
+		start = end
+	}
+	return &catalogpb.ListProductsResponse{Products: m.products[start:end], Total: int32(len(m.products))}, nil
+}
+
+func testProducts(n int) []*catalogpb.Product {
+	products := make([]*catalogpb.Product, n)
+	for i := range products {
+		products[i] = &catalogpb.Product{Id: fmt.Sprintf("p%d", i), Name: "Product", Price: 10, Stock: 1, Images: []string{"https://cdn.test/a.jpg"}}
+	}
+	// One product without images has a page but no feed entry
+	products[0].Images = nil
+	return products
+}
+
+func TestGenerate(t *testing.T) {
+	store := newMockStore()
+	catalog := &mockCatalog{products: testProducts(150)}
+	generator := NewGenerator(catalog, store, Config{StorefrontURL: "https://shop.test", Title: "Test Shop", Currency: "USD"}, logger.New("feed-test"))
+	generator.now = func() time.Time { return testNow }
+
+	result, err := generator.Generate(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(catalog.requests) != 2 || catalog.requests[0].Channel != "WEB" || catalog.requests[0].IncludeDrafts {
+		t.Errorf("Expected 2 pages of web store products, got %d requests", len(catalog.requests))
+	}
+
+	if len(result.Files) != 4 || result.Files[0].Name != FormatGoogle || result.Files[0].Items != 149 {
+		t.Fatalf("Unexpected files %+v", result.Files)
+	}
+
+	index := result.Files[3]
+	if index.Name != FeedSitemapIndex || index.URL != "https://feeds.test/sitemap.xml" || store.keys[len(store.keys)-1] != SitemapIndexKey {
+		t.Errorf("Expected the sitemap index stored last, got %+v after %v", index, store.keys)
+	}
+
+	if !strings.Contains(string(store.bodies["sitemap-products-1.xml"]), "https://shop.test/products/p0") {
+		t.Error("Expected products left out of the feeds listed in the sitemap")
+	}
+
+	if opts := store.opts[GoogleFeedKey]; opts.CacheControl != "public, max-age=3600" || opts.ContentType != "application/xml" {
+		t.Errorf("Expected cache headers, got %+v", opts)
+	}
+}
+
+func TestGenerate_OneAtATime(t *testing.T) {
+	store := newMockStore()
+	store.entered = make(chan struct{})
+	store.block = make(chan struct{})
+	generator := NewGenerator(&mockCatalog{products: testProducts(2)}, store, Config{StorefrontURL: "https://shop.test"}, logger.New("feed-test"))
+
+	done := make(chan error)
+	go func() {
+		_, err := generator.Generate(context.Background())
+		done <- err
+	}()
+
+	// The first generation is storing its first feed
+	<-store.entered
+	if _, err := generator.Generate(context.Background()); err != ErrGenerationRunning {
+		t.Errorf("Expected ErrGenerationRunning, got %v", err)
+	}
+
+	close(store.block)
+	go func() {
+		for range store.entered {
+		}
+	}()
+	if err := <-done; err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	close(store.entered)
+}
@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: feeds/feeds.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Feed is a generated file served from object storage
+type Feed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // GOOGLE_SHOPPING, FACEBOOK, SITEMAP_INDEX or SITEMAP
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Items         int32                  `protobuf:"varint,3,opt,name=items,proto3" json:"items,omitempty"` // products, or for SITEMAP_INDEX sitemaps
+	SizeBytes     int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed) Reset() {
+	*x = Feed{}
+	mi := &file_feeds_feeds_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed) ProtoMessage() {}
+
+func (x *Feed) ProtoReflect() protoreflect.Message {
+	mi := &file_feeds_feeds_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed.ProtoReflect.Descriptor instead.
+func (*Feed) Descriptor() ([]byte, []int) {
+	return file_feeds_feeds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Feed) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Feed) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Feed) GetItems() int32 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+func (x *Feed) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+// GenerateFeeds builds every feed from the current catalog and replaces the
+// stored files. The jobs service calls it on a schedule.
+type GenerateFeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateFeedsRequest) Reset() {
+	*x = GenerateFeedsRequest{}
+	mi := &file_feeds_feeds_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateFeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateFeedsRequest) ProtoMessage() {}
+
+func (x *GenerateFeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_feeds_feeds_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateFeedsRequest.ProtoReflect.Descriptor instead.
+func (*GenerateFeedsRequest) Descriptor() ([]byte, []int) {
+	return file_feeds_feeds_proto_rawDescGZIP(), []int{1}
+}
+
+type GenerateFeedsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feeds         []*Feed                `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateFeedsResponse) Reset() {
+	*x = GenerateFeedsResponse{}
+	mi := &file_feeds_feeds_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateFeedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateFeedsResponse) ProtoMessage() {}
+
+func (x *GenerateFeedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_feeds_feeds_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateFeedsResponse.ProtoReflect.Descriptor instead.
+func (*GenerateFeedsResponse) Descriptor() ([]byte, []int) {
+	return file_feeds_feeds_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateFeedsResponse) GetFeeds() []*Feed {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+func (x *GenerateFeedsResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+var File_feeds_feeds_proto protoreflect.FileDescriptor
+
+const file_feeds_feeds_proto_rawDesc = "" +
+	"\n" +
+	"\x11feeds/feeds.proto\x12\x05feeds\x1a\x1fgoogle/protobuf/timestamp.proto\"a\n" +
+	"\x04Feed\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x14\n" +
+	"\x05items\x18\x03 \x01(\x05R\x05items\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\"\x16\n" +
+	"\x14GenerateFeedsRequest\"y\n" +
+	"\x15GenerateFeedsResponse\x12!\n" +
+	"\x05feeds\x18\x01 \x03(\v2\v.feeds.FeedR\x05feeds\x12=\n" +
+	"\fgenerated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt2Y\n" +
+	"\vFeedService\x12J\n" +
+	"\rGenerateFeeds\x12\x1b.feeds.GenerateFeedsRequest\x1a\x1c.feeds.GenerateFeedsResponseB5Z3github.com/Ujjwaljain16/E-commerce-Backend/feeds/pbb\x06proto3"
+
+var (
+	file_feeds_feeds_proto_rawDescOnce sync.Once
+	file_feeds_feeds_proto_rawDescData []byte
+)
+
+func file_feeds_feeds_proto_rawDescGZIP() []byte {
+	file_feeds_feeds_proto_rawDescOnce.Do(func() {
+		file_feeds_feeds_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_feeds_feeds_proto_rawDesc), len(file_feeds_feeds_proto_rawDesc)))
+	})
+	return file_feeds_feeds_proto_rawDescData
+}
+
+var file_feeds_feeds_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_feeds_feeds_proto_goTypes = []any{
+	(*Feed)(nil),                  // 0: feeds.Feed
+	(*GenerateFeedsRequest)(nil),  // 1: feeds.GenerateFeedsRequest
+	(*GenerateFeedsResponse)(nil), // 2: feeds.GenerateFeedsResponse
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_feeds_feeds_proto_depIdxs = []int32{
+	0, // 0: feeds.GenerateFeedsResponse.feeds:type_name -> feeds.Feed
+	3, // 1: feeds.GenerateFeedsResponse.generated_at:type_name -> google.protobuf.Timestamp
+	1, // 2: feeds.FeedService.GenerateFeeds:input_type -> feeds.GenerateFeedsRequest
+	2, // 3: feeds.FeedService.GenerateFeeds:output_type -> feeds.GenerateFeedsResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_feeds_feeds_proto_init() }
+func file_feeds_feeds_proto_init() {
+	if File_feeds_feeds_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_feeds_feeds_proto_rawDesc), len(file_feeds_feeds_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_feeds_feeds_proto_goTypes,
+		DependencyIndexes: file_feeds_feeds_proto_depIdxs,
+		MessageInfos:      file_feeds_feeds_proto_msgTypes,
+	}.Build()
+	File_feeds_feeds_proto = out.File
+	file_feeds_feeds_proto_goTypes = nil
+	file_feeds_feeds_proto_depIdxs = nil
+}
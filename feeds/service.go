@@ -0,0 +1,60 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/feeds/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminMethods are the admin-scoped RPCs of the feed service; they are
+// restricted to the admin network allowlist. The feeds themselves are served
+// from object storage, so that is every RPC.
+var AdminMethods = []string{
+	"/" + pb.FeedService_ServiceDesc.ServiceName + "/",
+}
+
+// Service implements the FeedService gRPC interface
+type Service struct {
+	pb.UnimplementedFeedServiceServer
+	generator *Generator
+	log       *logger.Logger
+}
+
+// NewService creates a new feed service
+func NewService(generator *Generator, log *logger.Logger) *Service {
+	return &Service{
+		generator: generator,
+		log:       log,
+	}
+}
+
+// GenerateFeeds builds and stores every feed
+func (s *Service) GenerateFeeds(ctx context.Context, req *pb.GenerateFeedsRequest) (*pb.GenerateFeedsResponse, error) {
+	result, err := s.generator.Generate(ctx)
+	if errors.Is(err, ErrGenerationRunning) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to generate feeds", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to generate feeds")
+	}
+
+	resp := &pb.GenerateFeedsResponse{
+		Feeds:       make([]*pb.Feed, len(result.Files)),
+		GeneratedAt: timestamppb.New(result.GeneratedAt),
+	}
+	for i, f := range result.Files {
+		resp.Feeds[i] = &pb.Feed{
+			Name:      f.Name,
+			Url:       f.URL,
+			Items:     int32(f.Items),
+			SizeBytes: f.SizeBytes,
+		}
+	}
+	return resp, nil
+}
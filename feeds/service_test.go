@@ -0,0 +1,48 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/feeds/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingCatalog fails every listing
+type failingCatalog struct{}
+
+func (failingCatalog) ListProducts(ctx context.Context, in *catalogpb.ListProductsRequest, opts ...grpc.CallOption) (*catalogpb.ListProductsResponse, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestGenerateFeeds(t *testing.T) {
+	generator := NewGenerator(&mockCatalog{products: testProducts(3)}, newMockStore(), Config{StorefrontURL: "https://shop.test"}, logger.New("feed-test"))
+	service := NewService(generator, logger.New("feed-test"))
+
+	resp, err := service.GenerateFeeds(context.Background(), &pb.GenerateFeedsRequest{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Feeds) != 4 || resp.Feeds[0].Items != 2 || resp.Feeds[0].SizeBytes == 0 || resp.GeneratedAt == nil {
+		t.Errorf("Unexpected response %v", resp)
+	}
+}
+
+func TestGenerateFeeds_CatalogUnavailable(t *testing.T) {
+	generator := NewGenerator(failingCatalog{}, newMockStore(), Config{}, logger.New("feed-test"))
+	service := NewService(generator, logger.New("feed-test"))
+
+	_, err := service.GenerateFeeds(context.Background(), &pb.GenerateFeedsRequest{})
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Internal {
+		t.Errorf("Expected Internal error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// sitemapNamespace is the namespace of the sitemaps protocol
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// MaxSitemapURLs is the most URLs the sitemaps protocol allows in a sitemap
+const MaxSitemapURLs = 50000
+
+// SitemapURL is a page listed in a sitemap
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlset struct {
+	XMLName   xml.Name     `xml:"urlset"`
+	Namespace string       `xml:"xmlns,attr"`
+	URLs      []sitemapLoc `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName   xml.Name     `xml:"sitemapindex"`
+	Namespace string       `xml:"xmlns,attr"`
+	Sitemaps  []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// WriteSitemap encodes up to MaxSitemapURLs pages as a sitemap
+func WriteSitemap(urls []SitemapURL) ([]byte, error) {
+	if len(urls) > MaxSitemapURLs {
+		return nil, fmt.Errorf("a sitemap holds at most %d URLs, got %d", MaxSitemapURLs, len(urls))
+	}
+	set := urlset{Namespace: sitemapNamespace, URLs: make([]sitemapLoc, len(urls))}
+	for i, u := range urls {
+		set.URLs[i] = sitemapLoc{Loc: u.Loc, LastMod: formatLastMod(u.LastMod)}
+	}
+	data, err := encodeXML(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sitemap: %w", err)
+	}
+	return data, nil
+}
+
+// WriteSitemapIndex encodes an index of sitemaps
+func WriteSitemapIndex(sitemaps []SitemapURL) ([]byte, error) {
+	index := sitemapIndex{Namespace: sitemapNamespace, Sitemaps: make([]sitemapLoc, len(sitemaps))}
+	for i, s := range sitemaps {
+		index.Sitemaps[i] = sitemapLoc{Loc: s.Loc, LastMod: formatLastMod(s.LastMod)}
+	}
+	data, err := encodeXML(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sitemap index: %w", err)
+	}
+	return data, nil
+}
+
+// chunkURLs splits urls into sitemaps of at most size URLs
+func chunkURLs(urls []SitemapURL, size int) [][]SitemapURL {
+	var chunks [][]SitemapURL
+	for len(urls) > size {
+		chunks = append(chunks, urls[:size])
+		urls = urls[size:]
+	}
+	return append(chunks, urls)
+}
+
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// encodeXML encodes v as an indented XML document
+func encodeXML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
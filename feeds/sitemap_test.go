@@ -0,0 +1,55 @@
+package feeds
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteSitemap(t *testing.T) {
+	data, err := WriteSitemap([]SitemapURL{
+		{Loc: "https://shop.test/products/p1", LastMod: testNow},
+		{Loc: "https://shop.test/products/p2?a=1&b=2"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, want := range []string{
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+		`<loc>https://shop.test/products/p1</loc>`,
+		`<lastmod>2026-06-01T12:00:00Z</lastmod>`,
+		`<loc>https://shop.test/products/p2?a=1&amp;b=2</loc>`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected sitemap to contain %s", want)
+		}
+	}
+
+	if _, err := WriteSitemap(make([]SitemapURL, MaxSitemapURLs+1)); err == nil {
+		t.Error("Expected an error for too many URLs")
+	}
+}
+
+func TestWriteSitemapIndex(t *testing.T) {
+	data, err := WriteSitemapIndex([]SitemapURL{{Loc: "https://feeds.test/sitemap-products-1.xml", LastMod: testNow}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(string(data), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`) ||
+		!strings.Contains(string(data), `<sitemap>`) {
+		t.Errorf("Unexpected sitemap index %s", data)
+	}
+}
+
+func TestChunkURLs(t *testing.T) {
+	chunks := chunkURLs(make([]SitemapURL, 5), 2)
+	if len(chunks) != 3 || len(chunks[0]) != 2 || len(chunks[2]) != 1 {
+		t.Errorf("Expected chunks of 2, 2 and 1, got %d chunks", len(chunks))
+	}
+
+	// An empty catalog still gets a sitemap, so the index is valid
+	if chunks := chunkURLs(nil, 2); len(chunks) != 1 || len(chunks[0]) != 0 {
+		t.Errorf("Expected one empty chunk, got %v", chunks)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/flags"
+	"github.com/Ujjwaljain16/E-commerce-Backend/flags/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/cache"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/featureflag"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("flag-service")
+	log.Info(ctx, "Starting Flag Service", nil)
+
+	// Get configuration from environment
+	port := getEnv("PORT", "50076")
+	metricsPort := getEnv("METRICS_PORT", "9115")
+	redisAddr := os.Getenv("REDIS_ADDR")
+
+	// Flags and the IP deny list are shared with the other services through
+	// Redis; without it flags are kept in memory and lost on restart
+	var redisClient *redis.Client
+	if redisAddr != "" {
+		client, err := cache.NewRedisClient(ctx, cache.Config{
+			Addr:     redisAddr,
+			Password: os.Getenv("REDIS_PASSWORD"),
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to connect to Redis", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer client.Close()
+		redisClient = client
+	} else {
+		log.Warn(ctx, "REDIS_ADDR is not set; flags are kept in memory and not shared", nil)
+	}
+
+	// Load the flags and keep them in sync with changes made through other
+	// replicas
+	var store featureflag.Store
+	if redisClient != nil {
+		store = featureflag.NewRedisStore(redisClient, getEnv("FLAGS_REDIS_KEY", featureflag.DefaultRedisKey))
+	}
+	flagClient := featureflag.New(store)
+	if err := flagClient.Sync(ctx); err != nil {
+		log.Error(ctx, "Failed to load flags", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+	go flagClient.Run(workerCtx, getEnvDuration("FLAG_SYNC_INTERVAL", 10*time.Second), func(err error) {
+		log.Warn(ctx, "Failed to sync flags", map[string]interface{}{"error": err.Error()})
+	})
+
+	service := flags.NewService(flagClient, log)
+
+	// IP filtering: admin network allowlist and the deny list shared through Redis
+	ipFilter, err := ipfilter.FromEnvWithRedis(workerCtx, log, flags.AdminMethods, redisClient)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Create gRPC server with metrics and IP filter interceptors
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("flag-service"),
+			ipFilter.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterFlagServiceServer(grpcServer, service)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("flags.FlagService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Enable reflection for grpcurl/grpcui
+	reflection.Register(grpcServer)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start gRPC server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Error(ctx, "Failed to listen", map[string]interface{}{
+			"error": err.Error(),
+			"port":  port,
+		})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "Flag Service listening", map[string]interface{}{
+		"port":         port,
+		"metrics_port": metricsPort,
+		"flags":        len(flagClient.Flags()),
+		"shared":       redisClient != nil,
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopWorkers()
+		grpcServer.GracefulStop()
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,248 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/flags/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/featureflag"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxDescriptionLength bounds flag descriptions
+	maxDescriptionLength = 500
+	// maxUsers bounds the users a flag targets; larger audiences are
+	// reached with the percentage
+	maxUsers = 1000
+	// maxUserIDLength bounds targeted user IDs
+	maxUserIDLength = 100
+	// maxKeys bounds the flags evaluated in one call
+	maxKeys = 100
+)
+
+// keyPattern is the format of flag keys
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,99}$`)
+
+// AdminMethods are the admin-scoped RPCs of the flag service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.FlagService_CreateFlag_FullMethodName,
+	pb.FlagService_UpdateFlag_FullMethodName,
+	pb.FlagService_DeleteFlag_FullMethodName,
+	pb.FlagService_GetFlag_FullMethodName,
+	pb.FlagService_ListFlags_FullMethodName,
+}
+
+// Service implements the FlagService gRPC interface
+type Service struct {
+	pb.UnimplementedFlagServiceServer
+	flags *featureflag.Client
+	log   *logger.Logger
+}
+
+// NewService creates a new flag service managing the flags of client
+func NewService(flags *featureflag.Client, log *logger.Logger) *Service {
+	return &Service{
+		flags: flags,
+		log:   log,
+	}
+}
+
+// CreateFlag adds a flag
+func (s *Service) CreateFlag(ctx context.Context, req *pb.CreateFlagRequest) (*pb.CreateFlagResponse, error) {
+	flag, err := s.validFlag(ctx, "Create flag", req.Flag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sync(ctx); err != nil {
+		return nil, err
+	}
+	if _, exists := s.flags.Flag(flag.Key); exists {
+		return nil, status.Error(codes.AlreadyExists, "flag "+flag.Key+" already exists")
+	}
+
+	if err := s.flags.Set(ctx, flag); err != nil {
+		s.log.Error(ctx, "Failed to create flag", map[string]interface{}{"error": err.Error(), "key": flag.Key})
+		return nil, status.Error(codes.Internal, "failed to create flag")
+	}
+	saved, _ := s.flags.Flag(flag.Key)
+	s.log.Info(ctx, "Flag created", flagFields(saved))
+	return &pb.CreateFlagResponse{Flag: toProtoFlag(saved)}, nil
+}
+
+// UpdateFlag replaces a flag's description, switch, percentage and users
+func (s *Service) UpdateFlag(ctx context.Context, req *pb.UpdateFlagRequest) (*pb.UpdateFlagResponse, error) {
+	flag, err := s.validFlag(ctx, "Update flag", req.Flag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sync(ctx); err != nil {
+		return nil, err
+	}
+	if _, exists := s.flags.Flag(flag.Key); !exists {
+		return nil, status.Error(codes.NotFound, "flag not found")
+	}
+
+	if err := s.flags.Set(ctx, flag); err != nil {
+		s.log.Error(ctx, "Failed to update flag", map[string]interface{}{"error": err.Error(), "key": flag.Key})
+		return nil, status.Error(codes.Internal, "failed to update flag")
+	}
+	saved, _ := s.flags.Flag(flag.Key)
+	s.log.Info(ctx, "Flag updated", flagFields(saved))
+	return &pb.UpdateFlagResponse{Flag: toProtoFlag(saved)}, nil
+}
+
+// DeleteFlag removes a flag
+func (s *Service) DeleteFlag(ctx context.Context, req *pb.DeleteFlagRequest) (*pb.DeleteFlagResponse, error) {
+	if req.Key == "" {
+		s.log.Warn(ctx, "Delete flag failed: key is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+	if err := s.sync(ctx); err != nil {
+		return nil, err
+	}
+
+	existed, err := s.flags.Delete(ctx, req.Key)
+	if err != nil {
+		s.log.Error(ctx, "Failed to delete flag", map[string]interface{}{"error": err.Error(), "key": req.Key})
+		return nil, status.Error(codes.Internal, "failed to delete flag")
+	}
+	if !existed {
+		return nil, status.Error(codes.NotFound, "flag not found")
+	}
+	s.log.Info(ctx, "Flag deleted", map[string]interface{}{"key": req.Key})
+	return &pb.DeleteFlagResponse{}, nil
+}
+
+// GetFlag returns a flag
+func (s *Service) GetFlag(ctx context.Context, req *pb.GetFlagRequest) (*pb.GetFlagResponse, error) {
+	if req.Key == "" {
+		s.log.Warn(ctx, "Get flag failed: key is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+	flag, ok := s.flags.Flag(req.Key)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "flag not found")
+	}
+	return &pb.GetFlagResponse{Flag: toProtoFlag(flag)}, nil
+}
+
+// ListFlags returns every flag
+func (s *Service) ListFlags(ctx context.Context, req *pb.ListFlagsRequest) (*pb.ListFlagsResponse, error) {
+	flags := s.flags.Flags()
+	resp := &pb.ListFlagsResponse{Flags: make([]*pb.Flag, len(flags))}
+	for i, flag := range flags {
+		resp.Flags[i] = toProtoFlag(flag)
+	}
+	return resp, nil
+}
+
+// EvaluateFlags returns the value of flags for a user
+func (s *Service) EvaluateFlags(ctx context.Context, req *pb.EvaluateFlagsRequest) (*pb.EvaluateFlagsResponse, error) {
+	if len(req.Keys) > maxKeys {
+		s.log.Warn(ctx, "Evaluate flags failed: too many keys", map[string]interface{}{"count": len(req.Keys)})
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d keys can be evaluated at once", maxKeys)
+	}
+
+	keys := req.Keys
+	if len(keys) == 0 {
+		for _, flag := range s.flags.Flags() {
+			keys = append(keys, flag.Key)
+		}
+	}
+	resp := &pb.EvaluateFlagsResponse{Evaluations: make([]*pb.Evaluation, len(keys))}
+	for i, key := range keys {
+		evaluation := s.flags.Evaluate(key, req.UserId)
+		resp.Evaluations[i] = &pb.Evaluation{
+			Key:     key,
+			Enabled: evaluation.Enabled,
+			Reason:  evaluation.Reason,
+		}
+	}
+	return resp, nil
+}
+
+// sync refreshes the flags from the store before a change, so that changes
+// made through other replicas are seen
+func (s *Service) sync(ctx context.Context) error {
+	if err := s.flags.Sync(ctx); err != nil {
+		s.log.Error(ctx, "Failed to sync flags", map[string]interface{}{"error": err.Error()})
+		return status.Error(codes.Unavailable, "failed to load flags")
+	}
+	return nil
+}
+
+// validFlag converts a flag from protobuf, returning a gRPC status error when
+// it is invalid
+func (s *Service) validFlag(ctx context.Context, op string, flag *pb.Flag) (featureflag.Flag, error) {
+	if flag == nil {
+		s.log.Warn(ctx, op+" failed: flag is required", nil)
+		return featureflag.Flag{}, status.Error(codes.InvalidArgument, "flag is required")
+	}
+	if msg := flagProblem(flag); msg != "" {
+		s.log.Warn(ctx, op+" failed: "+msg, map[string]interface{}{"key": flag.Key})
+		return featureflag.Flag{}, status.Error(codes.InvalidArgument, msg)
+	}
+	return featureflag.Flag{
+		Key:         flag.Key,
+		Description: flag.Description,
+		Enabled:     flag.Enabled,
+		Percentage:  int(flag.Percentage),
+		Users:       flag.Users,
+	}, nil
+}
+
+// flagProblem validates a flag and returns a message describing the first
+// problem, or ""
+func flagProblem(flag *pb.Flag) string {
+	switch {
+	case flag.Key == "":
+		return "key is required"
+	case !keyPattern.MatchString(flag.Key):
+		return "key must be lower-case letters, digits, dots, dashes and underscores, starting with a letter or digit"
+	case len(flag.Description) > maxDescriptionLength:
+		return fmt.Sprintf("description cannot be longer than %d characters", maxDescriptionLength)
+	case flag.Percentage < 0 || flag.Percentage > 100:
+		return "percentage must be between 0 and 100"
+	case len(flag.Users) > maxUsers:
+		return fmt.Sprintf("a flag cannot target more than %d users", maxUsers)
+	}
+	seen := make(map[string]bool, len(flag.Users))
+	for _, user := range flag.Users {
+		switch {
+		case user == "":
+			return "users cannot be empty"
+		case len(user) > maxUserIDLength:
+			return fmt.Sprintf("user IDs cannot be longer than %d characters", maxUserIDLength)
+		case seen[user]:
+			return "user " + user + " appears more than once"
+		}
+		seen[user] = true
+	}
+	return ""
+}
+
+func flagFields(flag featureflag.Flag) map[string]interface{} {
+	return map[string]interface{}{
+		"key":        flag.Key,
+		"enabled":    flag.Enabled,
+		"percentage": flag.Percentage,
+		"users":      len(flag.Users),
+	}
+}
+
+func toProtoFlag(flag featureflag.Flag) *pb.Flag {
+	return &pb.Flag{
+		Key:         flag.Key,
+		Description: flag.Description,
+		Enabled:     flag.Enabled,
+		Percentage:  int32(flag.Percentage),
+		Users:       flag.Users,
+		UpdatedAt:   timestamppb.New(flag.UpdatedAt),
+	}
+}
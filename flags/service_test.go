@@ -0,0 +1,161 @@
+package flags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/flags/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/featureflag"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupService() *Service {
+	return NewService(featureflag.New(nil), logger.New("flags-test"))
+}
+
+func TestCreateFlag(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	resp, err := service.CreateFlag(ctx, &pb.CreateFlagRequest{Flag: &pb.Flag{
+		Key:         "search-elasticsearch",
+		Description: "Serve search from Elasticsearch",
+		Enabled:     true,
+		Percentage:  10,
+		Users:       []string{"user-1"},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Flag.Key != "search-elasticsearch" || resp.Flag.Percentage != 10 || !resp.Flag.UpdatedAt.IsValid() {
+		t.Errorf("Unexpected flag %v", resp.Flag)
+	}
+
+	_, err = service.CreateFlag(ctx, &pb.CreateFlagRequest{Flag: &pb.Flag{Key: "search-elasticsearch"}})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestCreateFlag_Invalid(t *testing.T) {
+	service := setupService()
+
+	tests := []*pb.Flag{
+		nil,
+		{},
+		{Key: "Search Backend"},
+		{Key: "f", Percentage: 101},
+		{Key: "f", Percentage: -1},
+		{Key: "f", Users: []string{""}},
+		{Key: "f", Users: []string{"u1", "u1"}},
+	}
+	for _, flag := range tests {
+		_, err := service.CreateFlag(context.Background(), &pb.CreateFlagRequest{Flag: flag})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", flag, err)
+		}
+	}
+}
+
+func TestUpdateAndDeleteFlag(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	_, err := service.UpdateFlag(ctx, &pb.UpdateFlagRequest{Flag: &pb.Flag{Key: "new-checkout", Enabled: true}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	if _, err := service.CreateFlag(ctx, &pb.CreateFlagRequest{Flag: &pb.Flag{Key: "new-checkout"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.UpdateFlag(ctx, &pb.UpdateFlagRequest{Flag: &pb.Flag{Key: "new-checkout", Enabled: true, Percentage: 100}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, err := service.GetFlag(ctx, &pb.GetFlagRequest{Key: "new-checkout"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !got.Flag.Enabled || got.Flag.Percentage != 100 {
+		t.Errorf("Expected the updated flag, got %v", got.Flag)
+	}
+
+	if _, err := service.DeleteFlag(ctx, &pb.DeleteFlagRequest{Key: "new-checkout"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.DeleteFlag(ctx, &pb.DeleteFlagRequest{Key: "new-checkout"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := service.GetFlag(ctx, &pb.GetFlagRequest{Key: "new-checkout"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestEvaluateFlags(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+	for _, flag := range []*pb.Flag{
+		{Key: "beta", Enabled: true, Users: []string{"user-1"}},
+		{Key: "dark-mode", Enabled: true, Percentage: 100},
+		{Key: "off"},
+	} {
+		if _, err := service.CreateFlag(ctx, &pb.CreateFlagRequest{Flag: flag}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	resp, err := service.EvaluateFlags(ctx, &pb.EvaluateFlagsRequest{UserId: "user-1", Keys: []string{"off", "beta", "missing"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := []struct {
+		key     string
+		enabled bool
+		reason  string
+	}{
+		{"off", false, featureflag.ReasonDisabled},
+		{"beta", true, featureflag.ReasonTargeted},
+		{"missing", false, featureflag.ReasonUnknown},
+	}
+	if len(resp.Evaluations) != len(want) {
+		t.Fatalf("Expected %d evaluations, got %v", len(want), resp.Evaluations)
+	}
+	for i, w := range want {
+		got := resp.Evaluations[i]
+		if got.Key != w.key || got.Enabled != w.enabled || got.Reason != w.reason {
+			t.Errorf("Expected %+v, got %v", w, got)
+		}
+	}
+
+	all, err := service.EvaluateFlags(ctx, &pb.EvaluateFlagsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(all.Evaluations) != 3 || all.Evaluations[0].Key != "beta" || all.Evaluations[0].Enabled || !all.Evaluations[1].Enabled {
+		t.Errorf("Unexpected evaluations for an anonymous visitor %v", all.Evaluations)
+	}
+}
+
+func TestCreateFlag_SeesOtherReplicas(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	first := NewService(featureflag.New(featureflag.NewRedisStore(client, "flags")), logger.New("flags-test"))
+	second := NewService(featureflag.New(featureflag.NewRedisStore(client, "flags")), logger.New("flags-test"))
+
+	if _, err := first.CreateFlag(ctx, &pb.CreateFlagRequest{Flag: &pb.Flag{Key: "beta"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := second.CreateFlag(ctx, &pb.CreateFlagRequest{Flag: &pb.Flag{Key: "beta"}}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists, got %v", err)
+	}
+	if _, err := second.UpdateFlag(ctx, &pb.UpdateFlagRequest{Flag: &pb.Flag{Key: "beta", Enabled: true}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_fraud_assessments_review;
+DROP INDEX IF EXISTS idx_fraud_assessments_ip;
+DROP INDEX IF EXISTS idx_fraud_assessments_user;
+DROP TABLE IF EXISTS fraud_assessments;
@@ -0,0 +1,27 @@
+-- Fraud assessments of checkouts, one per order. status starts as APPROVED,
+-- PENDING_REVIEW or REJECTED by score; a reviewer moves PENDING_REVIEW to
+-- APPROVED or REJECTED. Addresses are stored as the address JSON scored.
+CREATE TABLE IF NOT EXISTS fraud_assessments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL UNIQUE,
+    user_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL,
+    billing_address JSONB,
+    shipping_address JSONB,
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 100),
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL CHECK (status IN ('APPROVED', 'PENDING_REVIEW', 'REJECTED')),
+    reviewer VARCHAR(255) NOT NULL DEFAULT '',
+    review_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP
+);
+
+-- Velocity rules count a customer's and an IP address's recent checkouts
+CREATE INDEX idx_fraud_assessments_user ON fraud_assessments(user_id, created_at DESC);
+CREATE INDEX idx_fraud_assessments_ip ON fraud_assessments(ip_address, created_at DESC) WHERE ip_address <> '';
+-- The review queue
+CREATE INDEX idx_fraud_assessments_review ON fraud_assessments(created_at) WHERE status = 'PENDING_REVIEW';
@@ -0,0 +1,59 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Order and payment statuses used when a rejected order is unwound
+const (
+	orderStatusCancelled    = "CANCELLED"
+	paymentStatusAuthorized = "AUTHORIZED"
+)
+
+// Orders unwinds orders rejected in review
+type Orders interface {
+	// Reject voids the order's authorized payments and cancels the order. An
+	// order that can no longer be cancelled is left as it is.
+	Reject(ctx context.Context, orderID string) error
+}
+
+type grpcOrders struct {
+	orders   orderpb.OrderServiceClient
+	payments paymentpb.PaymentServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order and payment services
+func NewGRPCOrders(orders orderpb.OrderServiceClient, payments paymentpb.PaymentServiceClient) Orders {
+	return &grpcOrders{orders: orders, payments: payments}
+}
+
+// Reject releases the customer's funds before cancelling, so a failure in
+// between leaves a cancellable order rather than held funds on a cancelled one
+func (o *grpcOrders) Reject(ctx context.Context, orderID string) error {
+	resp, err := o.payments.ListPayments(ctx, &paymentpb.ListPaymentsRequest{OrderId: orderID})
+	if err != nil {
+		return fmt.Errorf("failed to list payments: %w", err)
+	}
+	for _, payment := range resp.Payments {
+		if payment.Status != paymentStatusAuthorized {
+			continue
+		}
+		if _, err := o.payments.VoidPayment(ctx, &paymentpb.VoidPaymentRequest{PaymentId: payment.Id}); err != nil {
+			return fmt.Errorf("failed to void payment %s: %w", payment.Id, err)
+		}
+	}
+
+	// The order service refuses transitions the order state machine does not
+	// allow with FAILED_PRECONDITION, which means the order has moved on
+	_, err = o.orders.UpdateOrderStatus(ctx, &orderpb.UpdateOrderStatusRequest{OrderId: orderID, Status: orderStatusCancelled})
+	if err != nil && status.Code(err) != codes.FailedPrecondition {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+	return nil
+}
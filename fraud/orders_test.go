@@ -0,0 +1,73 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePaymentClient serves an order's payments and records voids
+type fakePaymentClient struct {
+	paymentpb.PaymentServiceClient
+	payments []*paymentpb.Payment
+	voided   []string
+}
+
+func (c *fakePaymentClient) ListPayments(ctx context.Context, req *paymentpb.ListPaymentsRequest, opts ...grpc.CallOption) (*paymentpb.ListPaymentsResponse, error) {
+	return &paymentpb.ListPaymentsResponse{Payments: c.payments}, nil
+}
+
+func (c *fakePaymentClient) VoidPayment(ctx context.Context, req *paymentpb.VoidPaymentRequest, opts ...grpc.CallOption) (*paymentpb.VoidPaymentResponse, error) {
+	c.voided = append(c.voided, req.PaymentId)
+	return &paymentpb.VoidPaymentResponse{}, nil
+}
+
+// fakeOrderClient records status updates, failing them with err when it is set
+type fakeOrderClient struct {
+	orderpb.OrderServiceClient
+	updates []*orderpb.UpdateOrderStatusRequest
+	err     error
+}
+
+func (c *fakeOrderClient) UpdateOrderStatus(ctx context.Context, req *orderpb.UpdateOrderStatusRequest, opts ...grpc.CallOption) (*orderpb.UpdateOrderStatusResponse, error) {
+	c.updates = append(c.updates, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &orderpb.UpdateOrderStatusResponse{}, nil
+}
+
+func TestGRPCOrdersReject(t *testing.T) {
+	payments := &fakePaymentClient{payments: []*paymentpb.Payment{
+		{Id: "payment-1", Status: "FAILED"},
+		{Id: "payment-2", Status: paymentStatusAuthorized},
+	}}
+	orders := &fakeOrderClient{}
+
+	if err := NewGRPCOrders(orders, payments).Reject(context.Background(), "order-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(payments.voided) != 1 || payments.voided[0] != "payment-2" {
+		t.Errorf("Expected only the authorized payment to be voided, got %v", payments.voided)
+	}
+	if len(orders.updates) != 1 || orders.updates[0].OrderId != "order-1" || orders.updates[0].Status != orderStatusCancelled {
+		t.Errorf("Expected the order to be cancelled, got %v", orders.updates)
+	}
+}
+
+func TestGRPCOrdersReject_OrderMovedOn(t *testing.T) {
+	orders := &fakeOrderClient{err: status.Error(codes.FailedPrecondition, "invalid status transition")}
+	if err := NewGRPCOrders(orders, &fakePaymentClient{}).Reject(context.Background(), "order-1"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	orders.err = status.Error(codes.Unavailable, "connection refused")
+	if err := NewGRPCOrders(orders, &fakePaymentClient{}).Reject(context.Background(), "order-1"); err == nil {
+		t.Error("Expected an error")
+	}
+}
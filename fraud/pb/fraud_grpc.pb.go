@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: fraud/fraud.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FraudService_ScoreCheckout_FullMethodName    = "/fraud.FraudService/ScoreCheckout"
+	FraudService_GetAssessment_FullMethodName    = "/fraud.FraudService/GetAssessment"
+	FraudService_ListReviewQueue_FullMethodName  = "/fraud.FraudService/ListReviewQueue"
+	FraudService_ReviewAssessment_FullMethodName = "/fraud.FraudService/ReviewAssessment"
+)
+
+// FraudServiceClient is the client API for FraudService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FraudService scores checkouts and holds risky ones for manual review
+type FraudServiceClient interface {
+	ScoreCheckout(ctx context.Context, in *ScoreCheckoutRequest, opts ...grpc.CallOption) (*ScoreCheckoutResponse, error)
+	GetAssessment(ctx context.Context, in *GetAssessmentRequest, opts ...grpc.CallOption) (*GetAssessmentResponse, error)
+	ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error)
+	ReviewAssessment(ctx context.Context, in *ReviewAssessmentRequest, opts ...grpc.CallOption) (*ReviewAssessmentResponse, error)
+}
+
+type fraudServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFraudServiceClient(cc grpc.ClientConnInterface) FraudServiceClient {
+	return &fraudServiceClient{cc}
+}
+
+func (c *fraudServiceClient) ScoreCheckout(ctx context.Context, in *ScoreCheckoutRequest, opts ...grpc.CallOption) (*ScoreCheckoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScoreCheckoutResponse)
+	err := c.cc.Invoke(ctx, FraudService_ScoreCheckout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fraudServiceClient) GetAssessment(ctx context.Context, in *GetAssessmentRequest, opts ...grpc.CallOption) (*GetAssessmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAssessmentResponse)
+	err := c.cc.Invoke(ctx, FraudService_GetAssessment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fraudServiceClient) ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewQueueResponse)
+	err := c.cc.Invoke(ctx, FraudService_ListReviewQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fraudServiceClient) ReviewAssessment(ctx context.Context, in *ReviewAssessmentRequest, opts ...grpc.CallOption) (*ReviewAssessmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewAssessmentResponse)
+	err := c.cc.Invoke(ctx, FraudService_ReviewAssessment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FraudServiceServer is the server API for FraudService service.
+// All implementations must embed UnimplementedFraudServiceServer
+// for forward compatibility.
+//
+// FraudService scores checkouts and holds risky ones for manual review
+type FraudServiceServer interface {
+	ScoreCheckout(context.Context, *ScoreCheckoutRequest) (*ScoreCheckoutResponse, error)
+	GetAssessment(context.Context, *GetAssessmentRequest) (*GetAssessmentResponse, error)
+	ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error)
+	ReviewAssessment(context.Context, *ReviewAssessmentRequest) (*ReviewAssessmentResponse, error)
+	mustEmbedUnimplementedFraudServiceServer()
+}
+
+// UnimplementedFraudServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFraudServiceServer struct{}
+
+func (UnimplementedFraudServiceServer) ScoreCheckout(context.Context, *ScoreCheckoutRequest) (*ScoreCheckoutResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScoreCheckout not implemented")
+}
+func (UnimplementedFraudServiceServer) GetAssessment(context.Context, *GetAssessmentRequest) (*GetAssessmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAssessment not implemented")
+}
+func (UnimplementedFraudServiceServer) ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReviewQueue not implemented")
+}
+func (UnimplementedFraudServiceServer) ReviewAssessment(context.Context, *ReviewAssessmentRequest) (*ReviewAssessmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReviewAssessment not implemented")
+}
+func (UnimplementedFraudServiceServer) mustEmbedUnimplementedFraudServiceServer() {}
+func (UnimplementedFraudServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeFraudServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FraudServiceServer will
+// result in compilation errors.
+type UnsafeFraudServiceServer interface {
+	mustEmbedUnimplementedFraudServiceServer()
+}
+
+func RegisterFraudServiceServer(s grpc.ServiceRegistrar, srv FraudServiceServer) {
+	// If the following call panics, it indicates UnimplementedFraudServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FraudService_ServiceDesc, srv)
+}
+
+func _FraudService_ScoreCheckout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScoreCheckoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FraudServiceServer).ScoreCheckout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FraudService_ScoreCheckout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FraudServiceServer).ScoreCheckout(ctx, req.(*ScoreCheckoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FraudService_GetAssessment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssessmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FraudServiceServer).GetAssessment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FraudService_GetAssessment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FraudServiceServer).GetAssessment(ctx, req.(*GetAssessmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FraudService_ListReviewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FraudServiceServer).ListReviewQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FraudService_ListReviewQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FraudServiceServer).ListReviewQueue(ctx, req.(*ListReviewQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FraudService_ReviewAssessment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewAssessmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FraudServiceServer).ReviewAssessment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FraudService_ReviewAssessment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FraudServiceServer).ReviewAssessment(ctx, req.(*ReviewAssessmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FraudService_ServiceDesc is the grpc.ServiceDesc for FraudService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FraudService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fraud.FraudService",
+	HandlerType: (*FraudServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ScoreCheckout",
+			Handler:    _FraudService_ScoreCheckout_Handler,
+		},
+		{
+			MethodName: "GetAssessment",
+			Handler:    _FraudService_GetAssessment_Handler,
+		},
+		{
+			MethodName: "ListReviewQueue",
+			Handler:    _FraudService_ListReviewQueue_Handler,
+		},
+		{
+			MethodName: "ReviewAssessment",
+			Handler:    _FraudService_ReviewAssessment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "fraud/fraud.proto",
+}
@@ -0,0 +1,293 @@
+package fraud
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Assessment statuses
+const (
+	StatusApproved      = "APPROVED"
+	StatusPendingReview = "PENDING_REVIEW"
+	StatusRejected      = "REJECTED"
+)
+
+// orderConstraint is the unique constraint allowing one assessment per order
+const orderConstraint = "fraud_assessments_order_id_key"
+
+var (
+	// ErrAssessmentNotFound is returned when an order has no assessment
+	ErrAssessmentNotFound = errors.New("assessment not found")
+	// ErrAssessmentExists is returned when an order has been scored already
+	ErrAssessmentExists = errors.New("order has been scored already")
+	// ErrStatusConflict is returned when an assessment changed since it was read
+	ErrStatusConflict = errors.New("assessment status changed")
+)
+
+// Address is a billing or shipping address of a checkout
+type Address struct {
+	Name       string `json:"name"`
+	Street1    string `json:"street1"`
+	Street2    string `json:"street2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// Assessment is the fraud score of an order's checkout
+type Assessment struct {
+	ID              string
+	OrderID         string
+	UserID          string
+	Email           string
+	IPAddress       string
+	Amount          float64
+	Currency        string
+	BillingAddress  *Address
+	ShippingAddress *Address
+	Score           int32
+	Reasons         []string
+	Status          string
+	Reviewer        string
+	ReviewNote      string
+	CreatedAt       time.Time
+	ReviewedAt      *time.Time
+}
+
+// Repository handles assessment data persistence
+type Repository interface {
+	// Create inserts an assessment. It returns ErrAssessmentExists when the
+	// order has one already.
+	Create(ctx context.Context, a *Assessment) (*Assessment, error)
+	GetByOrder(ctx context.Context, orderID string) (*Assessment, error)
+	// Velocity counts the checkouts of userID and ipAddress since
+	// velocitySince, and adds up the spend of userID in currency since spendSince
+	Velocity(ctx context.Context, userID, ipAddress, currency string, velocitySince, spendSince time.Time) (Velocity, error)
+	// ListPendingReview lists the assessments held for review, oldest first
+	ListPendingReview(ctx context.Context, page, pageSize int32) ([]*Assessment, int32, error)
+	// Review records a reviewer's decision on a PENDING_REVIEW assessment. It
+	// returns ErrStatusConflict when the assessment is no longer PENDING_REVIEW.
+	Review(ctx context.Context, orderID, status, reviewer, note string, at time.Time) (*Assessment, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL assessment repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const assessmentColumns = `id, order_id, user_id, email, ip_address, amount, currency, billing_address, shipping_address,
+	score, reasons, status, reviewer, review_note, created_at, reviewed_at`
+
+// Create inserts an assessment
+func (r *postgresRepository) Create(ctx context.Context, a *Assessment) (*Assessment, error) {
+	billing, err := marshalAddress(a.BillingAddress)
+	if err != nil {
+		return nil, err
+	}
+	shipping, err := marshalAddress(a.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	a.ID = uuid.New().String()
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO fraud_assessments (id, order_id, user_id, email, ip_address, amount, currency, billing_address,
+			shipping_address, score, reasons, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, a.ID, a.OrderID, a.UserID, a.Email, a.IPAddress, a.Amount, a.Currency, billing, shipping,
+		a.Score, pq.Array(a.Reasons), a.Status, a.CreatedAt)
+	if pgerr.IsUniqueViolation(err, orderConstraint) {
+		return nil, ErrAssessmentExists
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to create assessment", map[string]interface{}{"error": err.Error(), "order_id": a.OrderID})
+		return nil, fmt.Errorf("failed to create assessment: %w", err)
+	}
+
+	r.log.Info(ctx, "Checkout scored", map[string]interface{}{"order_id": a.OrderID, "score": a.Score, "status": a.Status})
+	return a, nil
+}
+
+// GetByOrder retrieves the assessment of an order
+func (r *postgresRepository) GetByOrder(ctx context.Context, orderID string) (*Assessment, error) {
+	query := "SELECT " + assessmentColumns + " FROM fraud_assessments WHERE order_id = $1"
+
+	a, err := scanAssessment(r.db.QueryRowContext(ctx, query, orderID))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrAssessmentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get assessment", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	return a, nil
+}
+
+// Velocity counts recent checkouts in one query. Rejected checkouts count
+// too: a customer trying card after card is what the velocity rules catch.
+func (r *postgresRepository) Velocity(ctx context.Context, userID, ipAddress, currency string, velocitySince, spendSince time.Time) (Velocity, error) {
+	var v Velocity
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE user_id = $1 AND created_at >= $4),
+			COUNT(*) FILTER (WHERE $2 <> '' AND ip_address = $2 AND created_at >= $4),
+			COALESCE(SUM(amount) FILTER (WHERE user_id = $1 AND currency = $3 AND created_at >= $5), 0)
+		FROM fraud_assessments
+		WHERE (user_id = $1 OR ($2 <> '' AND ip_address = $2)) AND created_at >= LEAST($4, $5)
+	`, userID, ipAddress, currency, velocitySince, spendSince).Scan(&v.UserCheckouts, &v.IPCheckouts, &v.UserSpend)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count checkouts", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return Velocity{}, fmt.Errorf("failed to count checkouts: %w", err)
+	}
+	return v, nil
+}
+
+// ListPendingReview retrieves the review queue with pagination
+func (r *postgresRepository) ListPendingReview(ctx context.Context, page, pageSize int32) ([]*Assessment, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM fraud_assessments WHERE status = 'PENDING_REVIEW'").Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count review queue", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count review queue: %w", err)
+	}
+
+	query := "SELECT " + assessmentColumns + ` FROM fraud_assessments
+		WHERE status = 'PENDING_REVIEW'
+		ORDER BY created_at, id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list review queue", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list review queue: %w", err)
+	}
+	defer rows.Close()
+
+	assessments := make([]*Assessment, 0, pageSize)
+	for rows.Next() {
+		a, err := scanAssessment(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan assessment", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan assessment: %w", err)
+		}
+		assessments = append(assessments, a)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating review queue", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating review queue: %w", err)
+	}
+
+	return assessments, total, nil
+}
+
+// Review records the approval or rejection of a PENDING_REVIEW assessment
+func (r *postgresRepository) Review(ctx context.Context, orderID, status, reviewer, note string, at time.Time) (*Assessment, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE fraud_assessments SET status = $2, reviewer = $3, review_note = $4, reviewed_at = $5
+		WHERE order_id = $1 AND status = 'PENDING_REVIEW'
+	`, orderID, status, reviewer, note, at)
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrAssessmentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to review assessment", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+		return nil, fmt.Errorf("failed to review assessment: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to review assessment: %w", err)
+	}
+	if rows == 0 {
+		if _, err := r.GetByOrder(ctx, orderID); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	}
+
+	r.log.Info(ctx, "Assessment reviewed", map[string]interface{}{"order_id": orderID, "status": status, "reviewer": reviewer})
+	return r.GetByOrder(ctx, orderID)
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// marshalAddress encodes an address for a JSONB column; nil is stored as NULL
+func marshalAddress(addr *Address) (sql.NullString, error) {
+	if addr == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(addr)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode address: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// unmarshalAddress decodes an address from a JSONB column
+func unmarshalAddress(data []byte) (*Address, error) {
+	if data == nil {
+		return nil, nil
+	}
+	addr := &Address{}
+	if err := json.Unmarshal(data, addr); err != nil {
+		return nil, fmt.Errorf("failed to decode address: %w", err)
+	}
+	return addr, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAssessment(row rowScanner) (*Assessment, error) {
+	a := &Assessment{}
+	var billing, shipping []byte
+	var reviewedAt sql.NullTime
+	err := row.Scan(&a.ID, &a.OrderID, &a.UserID, &a.Email, &a.IPAddress, &a.Amount, &a.Currency, &billing, &shipping,
+		&a.Score, pq.Array(&a.Reasons), &a.Status, &a.Reviewer, &a.ReviewNote, &a.CreatedAt, &reviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	if a.BillingAddress, err = unmarshalAddress(billing); err != nil {
+		return nil, err
+	}
+	if a.ShippingAddress, err = unmarshalAddress(shipping); err != nil {
+		return nil, err
+	}
+	if reviewedAt.Valid {
+		a.ReviewedAt = &reviewedAt.Time
+	}
+	return a, nil
+}
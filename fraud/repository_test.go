@@ -0,0 +1,158 @@
+package fraud
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("fraud-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var assessmentColumnNames = []string{
+	"id", "order_id", "user_id", "email", "ip_address", "amount", "currency", "billing_address", "shipping_address",
+	"score", "reasons", "status", "reviewer", "review_note", "created_at", "reviewed_at",
+}
+
+func testAssessment() *Assessment {
+	return &Assessment{
+		OrderID:         "order-1",
+		UserID:          "user-1",
+		Email:           "ada@mailinator.com",
+		IPAddress:       "203.0.113.7",
+		Amount:          1200,
+		Currency:        "USD",
+		ShippingAddress: &Address{Name: "Ada", Street1: "1 Main St", City: "Springfield", PostalCode: "12345", Country: "US"},
+		Score:           55,
+		Reasons:         []string{ReasonDisposableEmail, ReasonHighAmount},
+		Status:          StatusPendingReview,
+		CreatedAt:       time.Now(),
+	}
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	a := testAssessment()
+	mock.ExpectExec(`INSERT INTO fraud_assessments`).
+		WithArgs(sqlmock.AnyArg(), "order-1", "user-1", "ada@mailinator.com", "203.0.113.7", 1200.0, "USD",
+			sql.NullString{},
+			sql.NullString{String: `{"name":"Ada","street1":"1 Main St","city":"Springfield","postal_code":"12345","country":"US"}`, Valid: true},
+			int32(55), sqlmock.AnyArg(), StatusPendingReview, a.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	created, err := repo.Create(context.Background(), a)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_Exists(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO fraud_assessments`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: orderConstraint})
+
+	if _, err := repo.Create(context.Background(), testAssessment()); err != ErrAssessmentExists {
+		t.Errorf("Expected ErrAssessmentExists, got %v", err)
+	}
+}
+
+func TestGetByOrder(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM fraud_assessments WHERE order_id = \$1`).
+		WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows(assessmentColumnNames).AddRow(
+			"a-1", "order-1", "user-1", "ada@mailinator.com", "203.0.113.7", 1200.0, "USD", nil,
+			[]byte(`{"name":"Ada","street1":"1 Main St","city":"Springfield","postal_code":"12345","country":"US"}`),
+			55, "{DISPOSABLE_EMAIL,HIGH_AMOUNT}", StatusRejected, "sam", "Card reported stolen", now, now))
+
+	a, err := repo.GetByOrder(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if a.BillingAddress != nil || a.ShippingAddress == nil || a.ShippingAddress.PostalCode != "12345" {
+		t.Errorf("Unexpected addresses %v and %v", a.BillingAddress, a.ShippingAddress)
+	}
+	if len(a.Reasons) != 2 || a.Reasons[1] != ReasonHighAmount || a.ReviewedAt == nil || a.Reviewer != "sam" {
+		t.Errorf("Unexpected assessment %+v", a)
+	}
+}
+
+func TestGetByOrder_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM fraud_assessments WHERE order_id = \$1`).
+		WithArgs("order-9").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.GetByOrder(context.Background(), "order-9"); err != ErrAssessmentNotFound {
+		t.Errorf("Expected ErrAssessmentNotFound, got %v", err)
+	}
+}
+
+func TestVelocity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT\s+COUNT\(\*\) FILTER (.+) FROM fraud_assessments`).
+		WithArgs("user-1", "203.0.113.7", "USD", now.Add(-VelocityWindow), now.Add(-SpendWindow)).
+		WillReturnRows(sqlmock.NewRows([]string{"user", "ip", "spend"}).AddRow(3, 7, 420.5))
+
+	v, err := repo.Velocity(context.Background(), "user-1", "203.0.113.7", "USD", now.Add(-VelocityWindow), now.Add(-SpendWindow))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if v.UserCheckouts != 3 || v.IPCheckouts != 7 || v.UserSpend != 420.5 {
+		t.Errorf("Unexpected velocity %+v", v)
+	}
+}
+
+func TestReview_Conflict(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE fraud_assessments SET status = \$2, reviewer = \$3, review_note = \$4, reviewed_at = \$5\s+WHERE order_id = \$1 AND status = 'PENDING_REVIEW'`).
+		WithArgs("order-1", StatusApproved, "sam", "", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT (.+) FROM fraud_assessments WHERE order_id = \$1`).
+		WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows(assessmentColumnNames).AddRow(
+			"a-1", "order-1", "user-1", "ada@example.com", "", 40.0, "USD", nil, nil,
+			0, "{}", StatusApproved, "", "", now, nil))
+
+	if _, err := repo.Review(context.Background(), "order-1", StatusApproved, "sam", "", now); err != ErrStatusConflict {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package fraud
+
+import (
+	"strings"
+	"time"
+)
+
+// Rules that add to a checkout's score, reported as the assessment's reasons
+const (
+	ReasonUserVelocity    = "USER_VELOCITY"
+	ReasonIPVelocity      = "IP_VELOCITY"
+	ReasonSpendVelocity   = "SPEND_VELOCITY"
+	ReasonCountryMismatch = "ADDRESS_COUNTRY_MISMATCH"
+	ReasonPostalMismatch  = "ADDRESS_POSTAL_MISMATCH"
+	ReasonDisposableEmail = "DISPOSABLE_EMAIL"
+	ReasonNumericEmail    = "NUMERIC_EMAIL"
+	ReasonHighAmount      = "HIGH_AMOUNT"
+)
+
+// Velocity windows and limits. A checkout counts towards the limits of the
+// checkouts after it, so the limits are on checkouts before the one scored.
+const (
+	// VelocityWindow is how far back checkouts are counted per customer and IP address
+	VelocityWindow = time.Hour
+	// SpendWindow is how far back a customer's spend is added up
+	SpendWindow = 24 * time.Hour
+
+	maxUserCheckouts = 5
+	maxIPCheckouts   = 10
+	maxSpend         = 5000
+	highAmount       = 1000
+)
+
+// ruleWeights is how much each rule adds to a score
+var ruleWeights = map[string]int32{
+	ReasonUserVelocity:    35,
+	ReasonIPVelocity:      30,
+	ReasonSpendVelocity:   25,
+	ReasonCountryMismatch: 30,
+	ReasonPostalMismatch:  10,
+	ReasonDisposableEmail: 40,
+	ReasonNumericEmail:    15,
+	ReasonHighAmount:      15,
+}
+
+// disposableDomains are throwaway email providers
+var disposableDomains = map[string]bool{
+	"10minutemail.com":  true,
+	"discard.email":     true,
+	"guerrillamail.com": true,
+	"mailinator.com":    true,
+	"maildrop.cc":       true,
+	"sharklasers.com":   true,
+	"temp-mail.org":     true,
+	"tempmail.com":      true,
+	"throwawaymail.com": true,
+	"trashmail.com":     true,
+	"yopmail.com":       true,
+}
+
+// Thresholds turn a score into a decision: checkouts scoring at least Review
+// are held for manual review, and those scoring at least Decline are rejected
+type Thresholds struct {
+	Review  int32
+	Decline int32
+}
+
+// DefaultThresholds hold a checkout for review at 50 and reject it at 80
+var DefaultThresholds = Thresholds{Review: 50, Decline: 80}
+
+// Status returns the assessment status for a score
+func (t Thresholds) Status(score int32) string {
+	switch {
+	case score >= t.Decline:
+		return StatusRejected
+	case score >= t.Review:
+		return StatusPendingReview
+	}
+	return StatusApproved
+}
+
+// Velocity is the recent activity of a checkout's customer and IP address,
+// not counting the checkout itself
+type Velocity struct {
+	// UserCheckouts and IPCheckouts are checkouts within VelocityWindow
+	UserCheckouts int32
+	IPCheckouts   int32
+	// UserSpend is the customer's spend in the checkout's currency within SpendWindow
+	UserSpend float64
+}
+
+// Score applies the rules to a checkout and returns its score, capped at 100,
+// and the rules that added to it
+func Score(a *Assessment, v Velocity) (int32, []string) {
+	reasons := []string{}
+	if v.UserCheckouts >= maxUserCheckouts {
+		reasons = append(reasons, ReasonUserVelocity)
+	}
+	if a.IPAddress != "" && v.IPCheckouts >= maxIPCheckouts {
+		reasons = append(reasons, ReasonIPVelocity)
+	}
+	if v.UserSpend+a.Amount > maxSpend {
+		reasons = append(reasons, ReasonSpendVelocity)
+	}
+	if reason := addressReason(a.BillingAddress, a.ShippingAddress); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	reasons = append(reasons, emailReasons(a.Email)...)
+	if a.Amount >= highAmount {
+		reasons = append(reasons, ReasonHighAmount)
+	}
+
+	var score int32
+	for _, reason := range reasons {
+		score += ruleWeights[reason]
+	}
+	if score > 100 {
+		score = 100
+	}
+	return score, reasons
+}
+
+// addressReason compares the billing and shipping addresses. Nothing is
+// compared unless both are given.
+func addressReason(billing, shipping *Address) string {
+	if billing == nil || shipping == nil {
+		return ""
+	}
+	if !strings.EqualFold(billing.Country, shipping.Country) {
+		return ReasonCountryMismatch
+	}
+	if normalizePostalCode(billing.PostalCode) != normalizePostalCode(shipping.PostalCode) {
+		return ReasonPostalMismatch
+	}
+	return ""
+}
+
+// normalizePostalCode drops case and spacing, so "SW1A 1AA" matches "sw1a1aa"
+func normalizePostalCode(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
+
+// emailReasons flags throwaway providers and generated-looking addresses,
+// whose local part is mostly digits
+func emailReasons(email string) []string {
+	local, domain, ok := strings.Cut(strings.ToLower(email), "@")
+	if !ok {
+		return nil
+	}
+
+	var reasons []string
+	if disposableDomains[domain] {
+		reasons = append(reasons, ReasonDisposableEmail)
+	}
+	digits := 0
+	for _, r := range local {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	if len(local) >= 6 && digits*2 > len(local) {
+		reasons = append(reasons, ReasonNumericEmail)
+	}
+	return reasons
+}
@@ -0,0 +1,99 @@
+package fraud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	london := &Address{City: "London", PostalCode: "SW1A 1AA", Country: "GB"}
+
+	tests := []struct {
+		name       string
+		assessment Assessment
+		velocity   Velocity
+		score      int32
+		reasons    []string
+	}{
+		{
+			name:       "clean checkout",
+			assessment: Assessment{Email: "ada@example.com", IPAddress: "203.0.113.7", Amount: 40, BillingAddress: london, ShippingAddress: &Address{PostalCode: "sw1a1aa", Country: "gb"}},
+			velocity:   Velocity{UserCheckouts: 4, IPCheckouts: 9, UserSpend: 100},
+			score:      0,
+			reasons:    []string{},
+		},
+		{
+			name:       "velocity",
+			assessment: Assessment{Email: "ada@example.com", IPAddress: "203.0.113.7", Amount: 40},
+			velocity:   Velocity{UserCheckouts: 5, IPCheckouts: 10, UserSpend: 4980},
+			score:      90,
+			reasons:    []string{ReasonUserVelocity, ReasonIPVelocity, ReasonSpendVelocity},
+		},
+		{
+			name:       "IP velocity without an IP address",
+			assessment: Assessment{Email: "ada@example.com", Amount: 40},
+			velocity:   Velocity{IPCheckouts: 50},
+			score:      0,
+			reasons:    []string{},
+		},
+		{
+			name:       "addresses in different countries",
+			assessment: Assessment{Email: "ada@example.com", Amount: 40, BillingAddress: london, ShippingAddress: &Address{PostalCode: "75001", Country: "FR"}},
+			score:      30,
+			reasons:    []string{ReasonCountryMismatch},
+		},
+		{
+			name:       "addresses with different postal codes",
+			assessment: Assessment{Email: "ada@example.com", Amount: 40, BillingAddress: london, ShippingAddress: &Address{PostalCode: "M1 1AE", Country: "GB"}},
+			score:      10,
+			reasons:    []string{ReasonPostalMismatch},
+		},
+		{
+			name:       "one address only",
+			assessment: Assessment{Email: "ada@example.com", Amount: 40, ShippingAddress: london},
+			score:      0,
+			reasons:    []string{},
+		},
+		{
+			name:       "risky email",
+			assessment: Assessment{Email: "x83920174@Mailinator.com", Amount: 40},
+			score:      55,
+			reasons:    []string{ReasonDisposableEmail, ReasonNumericEmail},
+		},
+		{
+			name:       "capped at 100",
+			assessment: Assessment{Email: "x83920174@yopmail.com", Amount: 6000, BillingAddress: london, ShippingAddress: &Address{Country: "US"}},
+			velocity:   Velocity{UserCheckouts: 9},
+			score:      100,
+			reasons:    []string{ReasonUserVelocity, ReasonSpendVelocity, ReasonCountryMismatch, ReasonDisposableEmail, ReasonNumericEmail, ReasonHighAmount},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := Score(&tt.assessment, tt.velocity)
+			if score != tt.score || !reflect.DeepEqual(reasons, tt.reasons) {
+				t.Errorf("Expected %d %v, got %d %v", tt.score, tt.reasons, score, reasons)
+			}
+		})
+	}
+}
+
+func TestThresholdsStatus(t *testing.T) {
+	tests := []struct {
+		score    int32
+		expected string
+	}{
+		{0, StatusApproved},
+		{49, StatusApproved},
+		{50, StatusPendingReview},
+		{79, StatusPendingReview},
+		{80, StatusRejected},
+		{100, StatusRejected},
+	}
+	for _, tt := range tests {
+		if got := DefaultThresholds.Status(tt.score); got != tt.expected {
+			t.Errorf("Score %d: expected %s, got %s", tt.score, tt.expected, got)
+		}
+	}
+}
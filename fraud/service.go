@@ -0,0 +1,387 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/netip"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/fraud/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultCurrency is used when a checkout names no currency, as in the payment service
+const DefaultCurrency = "USD"
+
+// Review decisions
+const (
+	DecisionApprove = "APPROVE"
+	DecisionReject  = "REJECT"
+)
+
+const (
+	// maxIDLength matches the user_id and reviewer columns of assessments
+	maxIDLength = 255
+	// maxEmailLength matches the email column of assessments
+	maxEmailLength = 255
+	// maxNoteLength caps a review note
+	maxNoteLength = 1000
+	// maxAddressFieldLength caps each field of an address
+	maxAddressFieldLength = 255
+)
+
+// AdminMethods are the admin-scoped RPCs of the fraud service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.FraudService_GetAssessment_FullMethodName,
+	pb.FraudService_ListReviewQueue_FullMethodName,
+	pb.FraudService_ReviewAssessment_FullMethodName,
+}
+
+// Service implements the FraudService gRPC interface
+type Service struct {
+	pb.UnimplementedFraudServiceServer
+	repo       Repository
+	orders     Orders
+	thresholds Thresholds
+	log        *logger.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new fraud service. orders unwinds the orders rejected
+// in review.
+func NewService(repo Repository, orders Orders, thresholds Thresholds, log *logger.Logger) *Service {
+	return &Service{
+		repo:       repo,
+		orders:     orders,
+		thresholds: thresholds,
+		log:        log,
+		now:        time.Now,
+	}
+}
+
+// ScoreCheckout scores an order's checkout against the velocity, address and
+// email rules. Checkouts scoring at the review threshold are held for manual
+// review, and those at the decline threshold are rejected outright.
+func (s *Service) ScoreCheckout(ctx context.Context, req *pb.ScoreCheckoutRequest) (*pb.ScoreCheckoutResponse, error) {
+	currency := strings.ToUpper(req.Currency)
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if msg := scoreProblem(req, currency); msg != "" {
+		s.log.Warn(ctx, "Score checkout failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	// A retried checkout keeps the decision it was given
+	existing, err := s.repo.GetByOrder(ctx, req.OrderId)
+	if err == nil {
+		return &pb.ScoreCheckoutResponse{Assessment: toProtoAssessment(existing)}, nil
+	}
+	if !errors.Is(err, ErrAssessmentNotFound) {
+		s.log.Error(ctx, "Failed to get assessment", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Internal, "failed to score checkout")
+	}
+
+	now := s.now()
+	a := &Assessment{
+		OrderID:         req.OrderId,
+		UserID:          req.UserId,
+		Email:           strings.TrimSpace(req.Email),
+		IPAddress:       normalizeIP(req.IpAddress),
+		Amount:          math.Round(req.Amount*100) / 100,
+		Currency:        currency,
+		BillingAddress:  fromProtoAddress(req.BillingAddress),
+		ShippingAddress: fromProtoAddress(req.ShippingAddress),
+		CreatedAt:       now,
+	}
+
+	velocity, err := s.repo.Velocity(ctx, a.UserID, a.IPAddress, a.Currency, now.Add(-VelocityWindow), now.Add(-SpendWindow))
+	if err != nil {
+		s.log.Error(ctx, "Failed to count checkouts", map[string]interface{}{"error": err.Error(), "order_id": a.OrderID})
+		return nil, status.Error(codes.Internal, "failed to score checkout")
+	}
+	a.Score, a.Reasons = Score(a, velocity)
+	a.Status = s.thresholds.Status(a.Score)
+
+	created, err := s.repo.Create(ctx, a)
+	if errors.Is(err, ErrAssessmentExists) {
+		// Scored by a concurrent retry
+		created, err = s.repo.GetByOrder(ctx, a.OrderID)
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to record assessment", map[string]interface{}{"error": err.Error(), "order_id": a.OrderID})
+		return nil, status.Error(codes.Internal, "failed to score checkout")
+	}
+
+	if created.Status != StatusApproved {
+		s.log.Warn(ctx, "Checkout flagged", map[string]interface{}{"order_id": created.OrderID, "user_id": created.UserID, "score": created.Score, "status": created.Status, "reasons": strings.Join(created.Reasons, ",")})
+	}
+	return &pb.ScoreCheckoutResponse{
+		Assessment: toProtoAssessment(created),
+	}, nil
+}
+
+// scoreProblem validates a checkout to score and returns a message
+// describing the first problem, or ""
+func scoreProblem(req *pb.ScoreCheckoutRequest, currency string) string {
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return "user_id is too long"
+	case strings.TrimSpace(req.Email) == "":
+		return "email is required"
+	case len(req.Email) > maxEmailLength:
+		return "email is too long"
+	case !validEmail(strings.TrimSpace(req.Email)):
+		return "email is not a valid address"
+	case req.IpAddress != "" && normalizeIP(req.IpAddress) == "":
+		return "ip_address is not a valid IP address"
+	case len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "":
+		return "currency must be a three-letter ISO 4217 code"
+	case req.Amount <= 0 || math.IsInf(req.Amount, 0) || math.IsNaN(req.Amount):
+		return "amount must be positive"
+	}
+	if msg := addressProblem("billing_address", req.BillingAddress); msg != "" {
+		return msg
+	}
+	return addressProblem("shipping_address", req.ShippingAddress)
+}
+
+// addressProblem validates an optional address
+func addressProblem(field string, addr *pb.Address) string {
+	if addr == nil {
+		return ""
+	}
+	if len(addr.Country) != 2 {
+		return field + ".country must be a two-letter ISO 3166 code"
+	}
+	for _, v := range []string{addr.Name, addr.Street1, addr.Street2, addr.City, addr.State, addr.PostalCode} {
+		if len(v) > maxAddressFieldLength {
+			return fmt.Sprintf("%s fields cannot exceed %d characters", field, maxAddressFieldLength)
+		}
+	}
+	return ""
+}
+
+// validEmail reports whether email is a bare address, without a display name
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// normalizeIP returns the canonical form of an IP address, or "" when it is
+// not one
+func normalizeIP(ip string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return ""
+	}
+	return addr.Unmap().String()
+}
+
+// GetAssessment retrieves the assessment of an order
+func (s *Service) GetAssessment(ctx context.Context, req *pb.GetAssessmentRequest) (*pb.GetAssessmentResponse, error) {
+	a, err := s.load(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetAssessmentResponse{
+		Assessment: toProtoAssessment(a),
+	}, nil
+}
+
+// ListReviewQueue lists the assessments held for manual review, oldest first
+func (s *Service) ListReviewQueue(ctx context.Context, req *pb.ListReviewQueueRequest) (*pb.ListReviewQueueResponse, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	assessments, total, err := s.repo.ListPendingReview(ctx, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list review queue", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list review queue")
+	}
+
+	protoAssessments := make([]*pb.Assessment, len(assessments))
+	for i, a := range assessments {
+		protoAssessments[i] = toProtoAssessment(a)
+	}
+
+	return &pb.ListReviewQueueResponse{
+		Assessments: protoAssessments,
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+	}, nil
+}
+
+// ReviewAssessment records a reviewer's decision on an assessment held for
+// review. A rejected order's authorized payments are voided and the order is
+// cancelled; when that fails, rejecting the assessment again retries it.
+func (s *Service) ReviewAssessment(ctx context.Context, req *pb.ReviewAssessmentRequest) (*pb.ReviewAssessmentResponse, error) {
+	note := strings.TrimSpace(req.Note)
+	if msg := reviewProblem(req, note); msg != "" {
+		s.log.Warn(ctx, "Review assessment failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	a, err := s.load(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	to := StatusApproved
+	if req.Decision == DecisionReject {
+		to = StatusRejected
+	}
+	switch {
+	case a.Status == StatusPendingReview:
+		a, err = s.repo.Review(ctx, a.OrderID, to, strings.TrimSpace(req.Reviewer), note, s.now())
+		if err != nil {
+			return nil, s.reviewError(ctx, err, req.OrderId)
+		}
+	case a.Status == StatusRejected && to == StatusRejected && a.Reviewer != "":
+		s.log.Info(ctx, "Retrying rejected order", map[string]interface{}{"order_id": a.OrderID})
+	default:
+		return nil, status.Error(codes.FailedPrecondition, "assessment is "+a.Status+", not PENDING_REVIEW")
+	}
+
+	if a.Status == StatusRejected {
+		if err := s.orders.Reject(ctx, a.OrderID); err != nil {
+			s.log.Error(ctx, "Failed to unwind rejected order", map[string]interface{}{"error": err.Error(), "order_id": a.OrderID})
+			return nil, status.Error(codes.Unavailable, "assessment rejected, but the order could not be cancelled; reject it again to retry")
+		}
+	}
+
+	s.log.Info(ctx, "Assessment reviewed", map[string]interface{}{"order_id": a.OrderID, "status": a.Status, "reviewer": a.Reviewer})
+	return &pb.ReviewAssessmentResponse{
+		Assessment: toProtoAssessment(a),
+	}, nil
+}
+
+// reviewProblem validates a review and returns a message describing the
+// first problem, or ""
+func reviewProblem(req *pb.ReviewAssessmentRequest, note string) string {
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case req.Decision != DecisionApprove && req.Decision != DecisionReject:
+		return "decision must be APPROVE or REJECT"
+	case strings.TrimSpace(req.Reviewer) == "":
+		return "reviewer is required"
+	case len(req.Reviewer) > maxIDLength:
+		return "reviewer is too long"
+	case utf8.RuneCountInString(note) > maxNoteLength:
+		return fmt.Sprintf("note cannot exceed %d characters", maxNoteLength)
+	}
+	return ""
+}
+
+// load reads the assessment of an order
+func (s *Service) load(ctx context.Context, orderID string) (*Assessment, error) {
+	if orderID == "" {
+		s.log.Warn(ctx, "Assessment request failed: order ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	a, err := s.repo.GetByOrder(ctx, orderID)
+	if errors.Is(err, ErrAssessmentNotFound) {
+		s.log.Warn(ctx, "Assessment not found", map[string]interface{}{"order_id": orderID})
+		return nil, status.Error(codes.NotFound, "assessment not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get assessment", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+		return nil, status.Error(codes.Internal, "failed to get assessment")
+	}
+	return a, nil
+}
+
+// reviewError maps an error recording a review to a gRPC status
+func (s *Service) reviewError(ctx context.Context, err error, orderID string) error {
+	switch {
+	case errors.Is(err, ErrAssessmentNotFound):
+		return status.Error(codes.NotFound, "assessment not found")
+	case errors.Is(err, ErrStatusConflict):
+		s.log.Warn(ctx, "Assessment reviewed by another request", map[string]interface{}{"order_id": orderID})
+		return status.Error(codes.Aborted, "assessment was reviewed by another request")
+	}
+	s.log.Error(ctx, "Failed to review assessment", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+	return status.Error(codes.Internal, "failed to review assessment")
+}
+
+// fromProtoAddress converts an optional address from protobuf
+func fromProtoAddress(addr *pb.Address) *Address {
+	if addr == nil {
+		return nil
+	}
+	return &Address{
+		Name:       addr.Name,
+		Street1:    addr.Street1,
+		Street2:    addr.Street2,
+		City:       addr.City,
+		State:      addr.State,
+		PostalCode: addr.PostalCode,
+		Country:    strings.ToUpper(addr.Country),
+	}
+}
+
+// toProtoAddress converts an optional address to protobuf
+func toProtoAddress(addr *Address) *pb.Address {
+	if addr == nil {
+		return nil
+	}
+	return &pb.Address{
+		Name:       addr.Name,
+		Street1:    addr.Street1,
+		Street2:    addr.Street2,
+		City:       addr.City,
+		State:      addr.State,
+		PostalCode: addr.PostalCode,
+		Country:    addr.Country,
+	}
+}
+
+// toProtoAssessment converts an assessment to protobuf
+func toProtoAssessment(a *Assessment) *pb.Assessment {
+	assessment := &pb.Assessment{
+		Id:              a.ID,
+		OrderId:         a.OrderID,
+		UserId:          a.UserID,
+		Email:           a.Email,
+		IpAddress:       a.IPAddress,
+		Amount:          a.Amount,
+		Currency:        a.Currency,
+		BillingAddress:  toProtoAddress(a.BillingAddress),
+		ShippingAddress: toProtoAddress(a.ShippingAddress),
+		Score:           a.Score,
+		Reasons:         a.Reasons,
+		Status:          a.Status,
+		Reviewer:        a.Reviewer,
+		ReviewNote:      a.ReviewNote,
+		CreatedAt:       timestamppb.New(a.CreatedAt),
+	}
+	if a.ReviewedAt != nil {
+		assessment.ReviewedAt = timestamppb.New(*a.ReviewedAt)
+	}
+	return assessment
+}
@@ -0,0 +1,362 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/fraud/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps one assessment per order and counts velocity over
+// the stored assessments
+type memoryRepository struct {
+	mu          sync.Mutex
+	assessments map[string]Assessment
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{assessments: map[string]Assessment{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, a *Assessment) (*Assessment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.assessments[a.OrderID]; ok {
+		return nil, ErrAssessmentExists
+	}
+	a.ID = uuid.New().String()
+	m.assessments[a.OrderID] = *a
+	return a, nil
+}
+
+func (m *memoryRepository) GetByOrder(ctx context.Context, orderID string) (*Assessment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.assessments[orderID]
+	if !ok {
+		return nil, ErrAssessmentNotFound
+	}
+	return &a, nil
+}
+
+func (m *memoryRepository) Velocity(ctx context.Context, userID, ipAddress, currency string, velocitySince, spendSince time.Time) (Velocity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var v Velocity
+	for _, a := range m.assessments {
+		if a.UserID == userID && !a.CreatedAt.Before(velocitySince) {
+			v.UserCheckouts++
+		}
+		if ipAddress != "" && a.IPAddress == ipAddress && !a.CreatedAt.Before(velocitySince) {
+			v.IPCheckouts++
+		}
+		if a.UserID == userID && a.Currency == currency && !a.CreatedAt.Before(spendSince) {
+			v.UserSpend += a.Amount
+		}
+	}
+	return v, nil
+}
+
+func (m *memoryRepository) ListPendingReview(ctx context.Context, page, pageSize int32) ([]*Assessment, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pending := []*Assessment{}
+	for _, a := range m.assessments {
+		if a.Status == StatusPendingReview {
+			a := a
+			pending = append(pending, &a)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+
+	total := int32(len(pending))
+	start := int((page - 1) * pageSize)
+	if start > len(pending) {
+		start = len(pending)
+	}
+	end := start + int(pageSize)
+	if end > len(pending) {
+		end = len(pending)
+	}
+	return pending[start:end], total, nil
+}
+
+func (m *memoryRepository) Review(ctx context.Context, orderID, status, reviewer, note string, at time.Time) (*Assessment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.assessments[orderID]
+	if !ok {
+		return nil, ErrAssessmentNotFound
+	}
+	if a.Status != StatusPendingReview {
+		return nil, ErrStatusConflict
+	}
+	a.Status, a.Reviewer, a.ReviewNote, a.ReviewedAt = status, reviewer, note, &at
+	m.assessments[orderID] = a
+	return &a, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeOrders records the orders it rejects, failing with err when it is set
+type fakeOrders struct {
+	rejected []string
+	err      error
+}
+
+func (o *fakeOrders) Reject(ctx context.Context, orderID string) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.rejected = append(o.rejected, orderID)
+	return nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	orders  *fakeOrders
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo:   newMemoryRepository(),
+		orders: &fakeOrders{},
+		now:    time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(f.repo, f.orders, DefaultThresholds, logger.New("fraud-test"))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *serviceFixture) score(t *testing.T, req *pb.ScoreCheckoutRequest) *pb.Assessment {
+	t.Helper()
+	resp, err := f.service.ScoreCheckout(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Assessment
+}
+
+func validCheckout(orderID string) *pb.ScoreCheckoutRequest {
+	return &pb.ScoreCheckoutRequest{
+		OrderId:   orderID,
+		UserId:    "user-1",
+		Email:     "ada@example.com",
+		IpAddress: "203.0.113.7",
+		Amount:    40,
+	}
+}
+
+func TestScoreCheckout(t *testing.T) {
+	f := setupService()
+
+	a := f.score(t, validCheckout("order-1"))
+	if a.Status != StatusApproved || a.Score != 0 || a.Currency != DefaultCurrency || a.IpAddress != "203.0.113.7" {
+		t.Errorf("Unexpected assessment %v", a)
+	}
+
+	req := validCheckout("order-2")
+	req.Email = "ada@mailinator.com"
+	req.BillingAddress = &pb.Address{PostalCode: "SW1A 1AA", Country: "gb"}
+	req.ShippingAddress = &pb.Address{PostalCode: "75001", Country: "FR"}
+	a = f.score(t, req)
+	if a.Status != StatusPendingReview || a.Score != 70 || len(a.Reasons) != 2 {
+		t.Errorf("Expected the checkout to be held for review, got %v", a)
+	}
+	if a.BillingAddress.Country != "GB" {
+		t.Errorf("Expected the country to be upper-cased, got %s", a.BillingAddress.Country)
+	}
+
+	// Scoring the order again keeps its decision
+	again := f.score(t, validCheckout("order-2"))
+	if again.Id != a.Id || again.Status != StatusPendingReview {
+		t.Errorf("Expected the existing assessment, got %v", again)
+	}
+}
+
+func TestScoreCheckout_Velocity(t *testing.T) {
+	f := setupService()
+
+	for i := 0; i < 5; i++ {
+		f.score(t, validCheckout(uuid.New().String()))
+		f.now = f.now.Add(time.Minute)
+	}
+	a := f.score(t, validCheckout("order-6"))
+	if a.Score != 35 || a.Reasons[0] != ReasonUserVelocity {
+		t.Errorf("Expected the sixth checkout within an hour to be flagged, got %v", a)
+	}
+
+	f.now = f.now.Add(VelocityWindow)
+	if a := f.score(t, validCheckout("order-7")); a.Score != 0 {
+		t.Errorf("Expected earlier checkouts to age out, got %v", a)
+	}
+}
+
+func TestScoreCheckout_Declined(t *testing.T) {
+	f := setupService()
+
+	req := validCheckout("order-1")
+	req.Email = "x83920174@yopmail.com"
+	req.Amount = 1500
+	req.BillingAddress = &pb.Address{PostalCode: "10001", Country: "US"}
+	req.ShippingAddress = &pb.Address{PostalCode: "10001", Country: "NG"}
+	a := f.score(t, req)
+	if a.Status != StatusRejected || a.Score != 100 || len(a.Reasons) != 4 {
+		t.Errorf("Expected the checkout to be rejected, got %v", a)
+	}
+}
+
+func TestScoreCheckout_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(req *pb.ScoreCheckoutRequest)
+	}{
+		{"missing order", func(req *pb.ScoreCheckoutRequest) { req.OrderId = "" }},
+		{"missing user", func(req *pb.ScoreCheckoutRequest) { req.UserId = "" }},
+		{"missing email", func(req *pb.ScoreCheckoutRequest) { req.Email = " " }},
+		{"invalid email", func(req *pb.ScoreCheckoutRequest) { req.Email = "Ada <ada@example.com>" }},
+		{"invalid IP address", func(req *pb.ScoreCheckoutRequest) { req.IpAddress = "localhost" }},
+		{"invalid currency", func(req *pb.ScoreCheckoutRequest) { req.Currency = "dollars" }},
+		{"zero amount", func(req *pb.ScoreCheckoutRequest) { req.Amount = 0 }},
+		{"invalid country", func(req *pb.ScoreCheckoutRequest) { req.ShippingAddress = &pb.Address{Country: "France"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService()
+			req := validCheckout("order-1")
+			tt.modify(req)
+			if _, err := f.service.ScoreCheckout(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+// hold scores a checkout that is held for review
+func (f *serviceFixture) hold(t *testing.T, orderID string) {
+	t.Helper()
+	req := validCheckout(orderID)
+	req.Email = "ada@mailinator.com"
+	req.Amount = 1200
+	if a := f.score(t, req); a.Status != StatusPendingReview {
+		t.Fatalf("Expected the checkout to be held, got %v", a)
+	}
+}
+
+func TestReviewQueue(t *testing.T) {
+	f := setupService()
+	f.hold(t, "order-1")
+	f.now = f.now.Add(time.Minute)
+	f.score(t, validCheckout("order-2"))
+	f.hold(t, "order-3")
+
+	queue, err := f.service.ListReviewQueue(context.Background(), &pb.ListReviewQueueRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if queue.Total != 2 || queue.Assessments[0].OrderId != "order-1" || queue.Assessments[1].OrderId != "order-3" {
+		t.Errorf("Expected the held checkouts oldest first, got %v", queue.Assessments)
+	}
+
+	approved, err := f.service.ReviewAssessment(context.Background(), &pb.ReviewAssessmentRequest{OrderId: "order-1", Decision: DecisionApprove, Reviewer: " sam "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if approved.Assessment.Status != StatusApproved || approved.Assessment.Reviewer != "sam" || approved.Assessment.ReviewedAt == nil {
+		t.Errorf("Unexpected assessment %v", approved.Assessment)
+	}
+
+	rejected, err := f.service.ReviewAssessment(context.Background(), &pb.ReviewAssessmentRequest{OrderId: "order-3", Decision: DecisionReject, Reviewer: "sam", Note: "Card reported stolen"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rejected.Assessment.Status != StatusRejected || len(f.orders.rejected) != 1 || f.orders.rejected[0] != "order-3" {
+		t.Errorf("Expected order-3 to be rejected, got %v and %v", rejected.Assessment, f.orders.rejected)
+	}
+
+	// Reviewed and approved assessments cannot be reviewed again
+	for _, orderID := range []string{"order-1", "order-2"} {
+		_, err := f.service.ReviewAssessment(context.Background(), &pb.ReviewAssessmentRequest{OrderId: orderID, Decision: DecisionReject, Reviewer: "sam"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("%s: expected FailedPrecondition, got %v", orderID, err)
+		}
+	}
+}
+
+func TestReviewAssessment_RetriesRejection(t *testing.T) {
+	f := setupService()
+	f.hold(t, "order-1")
+	f.orders.err = errors.New("order service down")
+
+	reject := &pb.ReviewAssessmentRequest{OrderId: "order-1", Decision: DecisionReject, Reviewer: "sam"}
+	if _, err := f.service.ReviewAssessment(context.Background(), reject); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected Unavailable, got %v", err)
+	}
+
+	f.orders.err = nil
+	resp, err := f.service.ReviewAssessment(context.Background(), reject)
+	if err != nil {
+		t.Fatalf("Expected the rejection to be retried, got %v", err)
+	}
+	if resp.Assessment.Status != StatusRejected || len(f.orders.rejected) != 1 {
+		t.Errorf("Expected the order to be rejected once, got %v", f.orders.rejected)
+	}
+
+	// Approving a rejected order is not a retry
+	approve := &pb.ReviewAssessmentRequest{OrderId: "order-1", Decision: DecisionApprove, Reviewer: "sam"}
+	if _, err := f.service.ReviewAssessment(context.Background(), approve); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestReviewAssessment_InvalidRequest(t *testing.T) {
+	f := setupService()
+	f.hold(t, "order-1")
+
+	tests := []struct {
+		name     string
+		req      *pb.ReviewAssessmentRequest
+		expected codes.Code
+	}{
+		{"unknown decision", &pb.ReviewAssessmentRequest{OrderId: "order-1", Decision: "MAYBE", Reviewer: "sam"}, codes.InvalidArgument},
+		{"missing reviewer", &pb.ReviewAssessmentRequest{OrderId: "order-1", Decision: DecisionApprove}, codes.InvalidArgument},
+		{"unknown order", &pb.ReviewAssessmentRequest{OrderId: "order-9", Decision: DecisionApprove, Reviewer: "sam"}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.service.ReviewAssessment(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetAssessment(t *testing.T) {
+	f := setupService()
+	f.score(t, validCheckout("order-1"))
+
+	resp, err := f.service.GetAssessment(context.Background(), &pb.GetAssessmentRequest{OrderId: "order-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Assessment.OrderId != "order-1" || resp.Assessment.Status != StatusApproved {
+		t.Errorf("Unexpected assessment %v", resp.Assessment)
+	}
+
+	if _, err := f.service.GetAssessment(context.Background(), &pb.GetAssessmentRequest{OrderId: "order-2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
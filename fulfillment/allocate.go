@@ -0,0 +1,65 @@
+package fulfillment
+
+// allocate splits an order's items between warehouses, given in allocation
+// order, and the stock on hand at each. An order one warehouse can ship
+// whole goes to the first such warehouse. Otherwise each item is taken from
+// the warehouses in order as far as their stock goes, and what none of them
+// has goes to the first warehouse, to be picked once it is restocked.
+// Fulfillments are returned in warehouse order, without their IDs.
+func allocate(items []*Item, warehouses []*Warehouse, stock map[string]map[string]int32) []*Fulfillment {
+	for _, w := range warehouses {
+		if covers(stock[w.ID], items) {
+			return []*Fulfillment{{WarehouseID: w.ID, Status: StatusPending, Items: items}}
+		}
+	}
+
+	byWarehouse := make(map[string]*Fulfillment, len(warehouses))
+	add := func(warehouseID string, item *Item, quantity int32) {
+		f, ok := byWarehouse[warehouseID]
+		if !ok {
+			f = &Fulfillment{WarehouseID: warehouseID, Status: StatusPending}
+			byWarehouse[warehouseID] = f
+		}
+		for _, existing := range f.Items {
+			if existing.ProductID == item.ProductID {
+				existing.Quantity += quantity
+				return
+			}
+		}
+		f.Items = append(f.Items, &Item{ProductID: item.ProductID, SKU: item.SKU, Name: item.Name, Quantity: quantity})
+	}
+
+	for _, item := range items {
+		remaining := item.Quantity
+		for _, w := range warehouses {
+			if remaining == 0 {
+				break
+			}
+			if take := min(stock[w.ID][item.ProductID], remaining); take > 0 {
+				add(w.ID, item, take)
+				remaining -= take
+			}
+		}
+		if remaining > 0 {
+			add(warehouses[0].ID, item, remaining)
+		}
+	}
+
+	fulfillments := make([]*Fulfillment, 0, len(byWarehouse))
+	for _, w := range warehouses {
+		if f, ok := byWarehouse[w.ID]; ok {
+			fulfillments = append(fulfillments, f)
+		}
+	}
+	return fulfillments
+}
+
+// covers reports whether stock holds every item in full
+func covers(stock map[string]int32, items []*Item) bool {
+	for _, item := range items {
+		if stock[item.ProductID] < item.Quantity {
+			return false
+		}
+	}
+	return true
+}
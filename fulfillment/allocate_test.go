@@ -0,0 +1,71 @@
+package fulfillment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	warehouses := []*Warehouse{{ID: "w1"}, {ID: "w2"}, {ID: "w3"}}
+	items := []*Item{{ProductID: "mug", Quantity: 3}, {ProductID: "lamp", Quantity: 1}}
+
+	// allocation maps warehouse IDs to the quantity of each product taken
+	type allocation map[string]map[string]int32
+
+	tests := []struct {
+		name     string
+		stock    map[string]map[string]int32
+		expected allocation
+	}{
+		{
+			name:     "first warehouse covers the order",
+			stock:    map[string]map[string]int32{"w1": {"mug": 3, "lamp": 1}, "w2": {"mug": 9, "lamp": 9}},
+			expected: allocation{"w1": {"mug": 3, "lamp": 1}},
+		},
+		{
+			name:     "later warehouse covers the order",
+			stock:    map[string]map[string]int32{"w1": {"mug": 3}, "w3": {"mug": 3, "lamp": 1}},
+			expected: allocation{"w3": {"mug": 3, "lamp": 1}},
+		},
+		{
+			name:     "split across warehouses",
+			stock:    map[string]map[string]int32{"w1": {"mug": 2}, "w2": {"mug": 2, "lamp": 1}},
+			expected: allocation{"w1": {"mug": 2}, "w2": {"mug": 1, "lamp": 1}},
+		},
+		{
+			name:     "shortfall goes to the first warehouse",
+			stock:    map[string]map[string]int32{"w2": {"mug": 1}},
+			expected: allocation{"w1": {"mug": 2, "lamp": 1}, "w2": {"mug": 1}},
+		},
+		{
+			name:     "no stock anywhere",
+			stock:    map[string]map[string]int32{},
+			expected: allocation{"w1": {"mug": 3, "lamp": 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fulfillments := allocate(items, warehouses, tt.stock)
+
+			got := allocation{}
+			total := map[string]int32{}
+			for _, f := range fulfillments {
+				if f.Status != StatusPending {
+					t.Errorf("Expected PENDING, got %s", f.Status)
+				}
+				got[f.WarehouseID] = map[string]int32{}
+				for _, item := range f.Items {
+					got[f.WarehouseID][item.ProductID] += item.Quantity
+					total[item.ProductID] += item.Quantity
+				}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+			if total["mug"] != 3 || total["lamp"] != 1 {
+				t.Errorf("Expected every item allocated once, got %v", total)
+			}
+		})
+	}
+}
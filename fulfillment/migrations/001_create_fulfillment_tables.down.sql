@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS fulfillment_items;
+DROP INDEX IF EXISTS idx_fulfillments_warehouse;
+DROP TABLE IF EXISTS fulfillments;
+DROP INDEX IF EXISTS idx_fulfillment_orders_unreported;
+DROP TABLE IF EXISTS fulfillment_orders;
+DROP INDEX IF EXISTS idx_warehouse_stock_product;
+DROP TABLE IF EXISTS warehouse_stock;
+DROP INDEX IF EXISTS idx_warehouses_code;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,65 @@
+-- Warehouses orders are shipped from. Orders are allocated to active
+-- warehouses, lowest priority first.
+CREATE TABLE IF NOT EXISTS warehouses (
+    id UUID PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_warehouses_code ON warehouses(code);
+
+-- Quantity of each product on hand at each warehouse
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    product_id VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (warehouse_id, product_id)
+);
+
+-- Where a product is stocked
+CREATE INDEX idx_warehouse_stock_product ON warehouse_stock(product_id);
+
+-- Paid orders being fulfilled. reported_at is set once the order service was
+-- told the order is FULFILLED.
+CREATE TABLE IF NOT EXISTS fulfillment_orders (
+    order_id VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'PARTIALLY_SHIPPED', 'SHIPPED')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reported_at TIMESTAMP
+);
+
+-- Shipped orders the order service has still to be told about
+CREATE INDEX idx_fulfillment_orders_unreported ON fulfillment_orders(updated_at)
+    WHERE status = 'SHIPPED' AND reported_at IS NULL;
+
+-- The part of an order one warehouse picks, packs and ships
+CREATE TABLE IF NOT EXISTS fulfillments (
+    id UUID PRIMARY KEY,
+    order_id VARCHAR(255) NOT NULL REFERENCES fulfillment_orders(order_id),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'PICKED', 'PACKED', 'SHIPPED')),
+    carrier VARCHAR(100) NOT NULL DEFAULT '',
+    tracking_number VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    shipped_at TIMESTAMP,
+    UNIQUE (order_id, warehouse_id)
+);
+
+-- A warehouse's work queue, oldest first
+CREATE INDEX idx_fulfillments_warehouse ON fulfillments(warehouse_id, status, created_at);
+
+CREATE TABLE IF NOT EXISTS fulfillment_items (
+    fulfillment_id UUID NOT NULL REFERENCES fulfillments(id),
+    product_id VARCHAR(255) NOT NULL,
+    sku VARCHAR(100) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (fulfillment_id, product_id)
+);
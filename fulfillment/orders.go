@@ -0,0 +1,41 @@
+package fulfillment
+
+import (
+	"context"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// orderStatusFulfilled is the order status set once every fulfillment of an
+// order has shipped
+const orderStatusFulfilled = "FULFILLED"
+
+// Orders tells the order service about fulfilled orders
+type Orders interface {
+	// MarkFulfilled moves an order to FULFILLED. An order that is not PAID
+	// is left as it is.
+	MarkFulfilled(ctx context.Context, orderID string) error
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+// MarkFulfilled moves an order to FULFILLED. The order service refuses
+// transitions the order state machine does not allow with
+// FAILED_PRECONDITION, which means the order has already moved on, for
+// example because the carrier reported it in transit first.
+func (o *grpcOrders) MarkFulfilled(ctx context.Context, orderID string) error {
+	_, err := o.client.UpdateOrderStatus(ctx, &orderpb.UpdateOrderStatusRequest{OrderId: orderID, Status: orderStatusFulfilled})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil
+	}
+	return err
+}
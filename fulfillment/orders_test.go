@@ -0,0 +1,48 @@
+package fulfillment
+
+import (
+	"context"
+	"testing"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeOrderClient serves UpdateOrderStatus from the order client interface
+type fakeOrderClient struct {
+	orderpb.OrderServiceClient
+	requests []*orderpb.UpdateOrderStatusRequest
+	err      error
+}
+
+func (c *fakeOrderClient) UpdateOrderStatus(ctx context.Context, req *orderpb.UpdateOrderStatusRequest, opts ...grpc.CallOption) (*orderpb.UpdateOrderStatusResponse, error) {
+	c.requests = append(c.requests, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &orderpb.UpdateOrderStatusResponse{}, nil
+}
+
+func TestGRPCOrders_MarkFulfilled(t *testing.T) {
+	client := &fakeOrderClient{}
+	orders := NewGRPCOrders(client)
+
+	if err := orders.MarkFulfilled(context.Background(), "order-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(client.requests) != 1 || client.requests[0].OrderId != "order-1" || client.requests[0].Status != "FULFILLED" {
+		t.Errorf("Unexpected requests %v", client.requests)
+	}
+
+	client.err = status.Error(codes.FailedPrecondition, "invalid status transition")
+	if err := orders.MarkFulfilled(context.Background(), "order-1"); err != nil {
+		t.Errorf("Expected an order that moved on to be ignored, got %v", err)
+	}
+
+	client.err = status.Error(codes.Unavailable, "connection refused")
+	if err := orders.MarkFulfilled(context.Background(), "order-1"); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
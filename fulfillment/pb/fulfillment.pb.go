@@ -0,0 +1,1734 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: fulfillment/fulfillment.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Warehouse is a site orders are picked, packed and shipped from
+type Warehouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // short unique code, e.g. AMS-1
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"` // lower is preferred when allocating orders
+	Active        bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`     // inactive warehouses are given no new orders
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warehouse) Reset() {
+	*x = Warehouse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warehouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warehouse) ProtoMessage() {}
+
+func (x *Warehouse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warehouse.ProtoReflect.Descriptor instead.
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Warehouse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Warehouse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Warehouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Warehouse) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Warehouse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Warehouse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Warehouse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// FulfillmentItem is a product to pick for a fulfillment
+type FulfillmentItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FulfillmentItem) Reset() {
+	*x = FulfillmentItem{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillmentItem) ProtoMessage() {}
+
+func (x *FulfillmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillmentItem.ProtoReflect.Descriptor instead.
+func (*FulfillmentItem) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FulfillmentItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *FulfillmentItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *FulfillmentItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FulfillmentItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Fulfillment is the part of an order one warehouse picks, packs and ships.
+// An order split across warehouses has one fulfillment, and so one shipment,
+// per warehouse.
+type Fulfillment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	WarehouseId    string                 `protobuf:"bytes,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // PENDING, PICKED, PACKED or SHIPPED
+	Items          []*FulfillmentItem     `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Carrier        string                 `protobuf:"bytes,6,opt,name=carrier,proto3" json:"carrier,omitempty"`                                     // set once SHIPPED
+	TrackingNumber string                 `protobuf:"bytes,7,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"` // set once SHIPPED
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ShippedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Fulfillment) Reset() {
+	*x = Fulfillment{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fulfillment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fulfillment) ProtoMessage() {}
+
+func (x *Fulfillment) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fulfillment.ProtoReflect.Descriptor instead.
+func (*Fulfillment) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Fulfillment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetItems() []*FulfillmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Fulfillment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Fulfillment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Fulfillment) GetShippedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return nil
+}
+
+// OrderFulfillment is an order with its fulfillments
+type OrderFulfillment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // OPEN, PARTIALLY_SHIPPED or SHIPPED
+	Fulfillments  []*Fulfillment         `protobuf:"bytes,3,rep,name=fulfillments,proto3" json:"fulfillments,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReportedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"` // when the order service was told the order is FULFILLED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFulfillment) Reset() {
+	*x = OrderFulfillment{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFulfillment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFulfillment) ProtoMessage() {}
+
+func (x *OrderFulfillment) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFulfillment.ProtoReflect.Descriptor instead.
+func (*OrderFulfillment) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderFulfillment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderFulfillment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderFulfillment) GetFulfillments() []*Fulfillment {
+	if x != nil {
+		return x.Fulfillments
+	}
+	return nil
+}
+
+func (x *OrderFulfillment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrderFulfillment) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+// CreateWarehouse adds an active warehouse
+type CreateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseRequest) Reset() {
+	*x = CreateWarehouseRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseRequest) ProtoMessage() {}
+
+func (x *CreateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateWarehouseRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateWarehouseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateWarehouseRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type CreateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseResponse) Reset() {
+	*x = CreateWarehouseResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseResponse) ProtoMessage() {}
+
+func (x *CreateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+// UpdateWarehouse renames, reprioritizes, deactivates or reactivates a
+// warehouse. Fulfillments already allocated to it are kept.
+type UpdateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Active        bool                   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseRequest) Reset() {
+	*x = UpdateWarehouseRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseRequest) ProtoMessage() {}
+
+func (x *UpdateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateWarehouseRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *UpdateWarehouseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateWarehouseRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateWarehouseRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type UpdateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseResponse) Reset() {
+	*x = UpdateWarehouseResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseResponse) ProtoMessage() {}
+
+func (x *UpdateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+// ListWarehouses lists warehouses in allocation order
+type ListWarehousesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeInactive bool                   `protobuf:"varint,1,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListWarehousesRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+type ListWarehousesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouses    []*Warehouse           `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*Warehouse {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+// SetStock sets the quantity of a product on hand at a warehouse
+type SetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStockRequest) Reset() {
+	*x = SetStockRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStockRequest) ProtoMessage() {}
+
+func (x *SetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStockRequest.ProtoReflect.Descriptor instead.
+func (*SetStockRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetStockRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *SetStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type SetStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStockResponse) Reset() {
+	*x = SetStockResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStockResponse) ProtoMessage() {}
+
+func (x *SetStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStockResponse.ProtoReflect.Descriptor instead.
+func (*SetStockResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetStockResponse) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *SetStockResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetStockResponse) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// OrderLine is a line of a paid order
+type OrderLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderLine) Reset() {
+	*x = OrderLine{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderLine) ProtoMessage() {}
+
+func (x *OrderLine) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderLine.ProtoReflect.Descriptor instead.
+func (*OrderLine) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrderLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderLine) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// FulfillOrder allocates a paid order's lines to warehouses and creates a
+// pick and pack task for each. Fulfilling an order again returns its first
+// allocation.
+type FulfillOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Lines         []*OrderLine           `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FulfillOrderRequest) Reset() {
+	*x = FulfillOrderRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillOrderRequest) ProtoMessage() {}
+
+func (x *FulfillOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillOrderRequest.ProtoReflect.Descriptor instead.
+func (*FulfillOrderRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FulfillOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *FulfillOrderRequest) GetLines() []*OrderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type FulfillOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *OrderFulfillment      `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FulfillOrderResponse) Reset() {
+	*x = FulfillOrderResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillOrderResponse) ProtoMessage() {}
+
+func (x *FulfillOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillOrderResponse.ProtoReflect.Descriptor instead.
+func (*FulfillOrderResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FulfillOrderResponse) GetOrder() *OrderFulfillment {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// GetOrderFulfillment returns an order's fulfillments
+type GetOrderFulfillmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderFulfillmentRequest) Reset() {
+	*x = GetOrderFulfillmentRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderFulfillmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderFulfillmentRequest) ProtoMessage() {}
+
+func (x *GetOrderFulfillmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderFulfillmentRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderFulfillmentRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetOrderFulfillmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetOrderFulfillmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *OrderFulfillment      `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderFulfillmentResponse) Reset() {
+	*x = GetOrderFulfillmentResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderFulfillmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderFulfillmentResponse) ProtoMessage() {}
+
+func (x *GetOrderFulfillmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderFulfillmentResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderFulfillmentResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetOrderFulfillmentResponse) GetOrder() *OrderFulfillment {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// ListFulfillments lists a warehouse's fulfillments, oldest first: the
+// warehouse's pick list when filtered by PENDING
+type ListFulfillmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // optional filter
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFulfillmentsRequest) Reset() {
+	*x = ListFulfillmentsRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFulfillmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFulfillmentsRequest) ProtoMessage() {}
+
+func (x *ListFulfillmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFulfillmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListFulfillmentsRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListFulfillmentsRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ListFulfillmentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListFulfillmentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFulfillmentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListFulfillmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fulfillments  []*Fulfillment         `protobuf:"bytes,1,rep,name=fulfillments,proto3" json:"fulfillments,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFulfillmentsResponse) Reset() {
+	*x = ListFulfillmentsResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFulfillmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFulfillmentsResponse) ProtoMessage() {}
+
+func (x *ListFulfillmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFulfillmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListFulfillmentsResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListFulfillmentsResponse) GetFulfillments() []*Fulfillment {
+	if x != nil {
+		return x.Fulfillments
+	}
+	return nil
+}
+
+func (x *ListFulfillmentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListFulfillmentsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFulfillmentsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ConfirmPicked records that a PENDING fulfillment's items were picked
+type ConfirmPickedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FulfillmentId string                 `protobuf:"bytes,1,opt,name=fulfillment_id,json=fulfillmentId,proto3" json:"fulfillment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPickedRequest) Reset() {
+	*x = ConfirmPickedRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPickedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPickedRequest) ProtoMessage() {}
+
+func (x *ConfirmPickedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPickedRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPickedRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConfirmPickedRequest) GetFulfillmentId() string {
+	if x != nil {
+		return x.FulfillmentId
+	}
+	return ""
+}
+
+type ConfirmPickedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fulfillment   *Fulfillment           `protobuf:"bytes,1,opt,name=fulfillment,proto3" json:"fulfillment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPickedResponse) Reset() {
+	*x = ConfirmPickedResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPickedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPickedResponse) ProtoMessage() {}
+
+func (x *ConfirmPickedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPickedResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPickedResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConfirmPickedResponse) GetFulfillment() *Fulfillment {
+	if x != nil {
+		return x.Fulfillment
+	}
+	return nil
+}
+
+// ConfirmPacked records that a PICKED fulfillment was packed
+type ConfirmPackedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FulfillmentId string                 `protobuf:"bytes,1,opt,name=fulfillment_id,json=fulfillmentId,proto3" json:"fulfillment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPackedRequest) Reset() {
+	*x = ConfirmPackedRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPackedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPackedRequest) ProtoMessage() {}
+
+func (x *ConfirmPackedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPackedRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPackedRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ConfirmPackedRequest) GetFulfillmentId() string {
+	if x != nil {
+		return x.FulfillmentId
+	}
+	return ""
+}
+
+type ConfirmPackedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fulfillment   *Fulfillment           `protobuf:"bytes,1,opt,name=fulfillment,proto3" json:"fulfillment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPackedResponse) Reset() {
+	*x = ConfirmPackedResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPackedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPackedResponse) ProtoMessage() {}
+
+func (x *ConfirmPackedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPackedResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPackedResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ConfirmPackedResponse) GetFulfillment() *Fulfillment {
+	if x != nil {
+		return x.Fulfillment
+	}
+	return nil
+}
+
+// ShipFulfillment records that a PACKED fulfillment left the warehouse. Once
+// every fulfillment of the order has shipped the order is FULFILLED.
+type ShipFulfillmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FulfillmentId  string                 `protobuf:"bytes,1,opt,name=fulfillment_id,json=fulfillmentId,proto3" json:"fulfillment_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShipFulfillmentRequest) Reset() {
+	*x = ShipFulfillmentRequest{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipFulfillmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipFulfillmentRequest) ProtoMessage() {}
+
+func (x *ShipFulfillmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipFulfillmentRequest.ProtoReflect.Descriptor instead.
+func (*ShipFulfillmentRequest) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ShipFulfillmentRequest) GetFulfillmentId() string {
+	if x != nil {
+		return x.FulfillmentId
+	}
+	return ""
+}
+
+func (x *ShipFulfillmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *ShipFulfillmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+type ShipFulfillmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fulfillment   *Fulfillment           `protobuf:"bytes,1,opt,name=fulfillment,proto3" json:"fulfillment,omitempty"`
+	OrderStatus   string                 `protobuf:"bytes,2,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"` // the order's fulfillment status after the shipment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipFulfillmentResponse) Reset() {
+	*x = ShipFulfillmentResponse{}
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipFulfillmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipFulfillmentResponse) ProtoMessage() {}
+
+func (x *ShipFulfillmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fulfillment_fulfillment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipFulfillmentResponse.ProtoReflect.Descriptor instead.
+func (*ShipFulfillmentResponse) Descriptor() ([]byte, []int) {
+	return file_fulfillment_fulfillment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ShipFulfillmentResponse) GetFulfillment() *Fulfillment {
+	if x != nil {
+		return x.Fulfillment
+	}
+	return nil
+}
+
+func (x *ShipFulfillmentResponse) GetOrderStatus() string {
+	if x != nil {
+		return x.OrderStatus
+	}
+	return ""
+}
+
+var File_fulfillment_fulfillment_proto protoreflect.FileDescriptor
+
+const file_fulfillment_fulfillment_proto_rawDesc = "" +
+	"\n" +
+	"\x1dfulfillment/fulfillment.proto\x12\vfulfillment\x1a\x1fgoogle/protobuf/timestamp.proto\"\xed\x01\n" +
+	"\tWarehouse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"r\n" +
+	"\x0fFulfillmentItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"\x9b\x03\n" +
+	"\vFulfillment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12!\n" +
+	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x122\n" +
+	"\x05items\x18\x05 \x03(\v2\x1c.fulfillment.FulfillmentItemR\x05items\x12\x18\n" +
+	"\acarrier\x18\x06 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\a \x01(\tR\x0etrackingNumber\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"shipped_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tshippedAt\"\xfb\x01\n" +
+	"\x10OrderFulfillment\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12<\n" +
+	"\ffulfillments\x18\x03 \x03(\v2\x18.fulfillment.FulfillmentR\ffulfillments\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreported_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reportedAt\"\\\n" +
+	"\x16CreateWarehouseRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\"O\n" +
+	"\x17CreateWarehouseResponse\x124\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x16.fulfillment.WarehouseR\twarehouse\"\x83\x01\n" +
+	"\x16UpdateWarehouseRequest\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06active\x18\x04 \x01(\bR\x06active\"O\n" +
+	"\x17UpdateWarehouseResponse\x124\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x16.fulfillment.WarehouseR\twarehouse\"B\n" +
+	"\x15ListWarehousesRequest\x12)\n" +
+	"\x10include_inactive\x18\x01 \x01(\bR\x0fincludeInactive\"P\n" +
+	"\x16ListWarehousesResponse\x126\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x16.fulfillment.WarehouseR\n" +
+	"warehouses\"o\n" +
+	"\x0fSetStockRequest\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"p\n" +
+	"\x10SetStockResponse\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"l\n" +
+	"\tOrderLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"^\n" +
+	"\x13FulfillOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12,\n" +
+	"\x05lines\x18\x02 \x03(\v2\x16.fulfillment.OrderLineR\x05lines\"K\n" +
+	"\x14FulfillOrderResponse\x123\n" +
+	"\x05order\x18\x01 \x01(\v2\x1d.fulfillment.OrderFulfillmentR\x05order\"7\n" +
+	"\x1aGetOrderFulfillmentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"R\n" +
+	"\x1bGetOrderFulfillmentResponse\x123\n" +
+	"\x05order\x18\x01 \x01(\v2\x1d.fulfillment.OrderFulfillmentR\x05order\"\x85\x01\n" +
+	"\x17ListFulfillmentsRequest\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x9f\x01\n" +
+	"\x18ListFulfillmentsResponse\x12<\n" +
+	"\ffulfillments\x18\x01 \x03(\v2\x18.fulfillment.FulfillmentR\ffulfillments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"=\n" +
+	"\x14ConfirmPickedRequest\x12%\n" +
+	"\x0efulfillment_id\x18\x01 \x01(\tR\rfulfillmentId\"S\n" +
+	"\x15ConfirmPickedResponse\x12:\n" +
+	"\vfulfillment\x18\x01 \x01(\v2\x18.fulfillment.FulfillmentR\vfulfillment\"=\n" +
+	"\x14ConfirmPackedRequest\x12%\n" +
+	"\x0efulfillment_id\x18\x01 \x01(\tR\rfulfillmentId\"S\n" +
+	"\x15ConfirmPackedResponse\x12:\n" +
+	"\vfulfillment\x18\x01 \x01(\v2\x18.fulfillment.FulfillmentR\vfulfillment\"\x82\x01\n" +
+	"\x16ShipFulfillmentRequest\x12%\n" +
+	"\x0efulfillment_id\x18\x01 \x01(\tR\rfulfillmentId\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x03 \x01(\tR\x0etrackingNumber\"x\n" +
+	"\x17ShipFulfillmentResponse\x12:\n" +
+	"\vfulfillment\x18\x01 \x01(\v2\x18.fulfillment.FulfillmentR\vfulfillment\x12!\n" +
+	"\forder_status\x18\x02 \x01(\tR\vorderStatus2\xa2\a\n" +
+	"\x12FulfillmentService\x12\\\n" +
+	"\x0fCreateWarehouse\x12#.fulfillment.CreateWarehouseRequest\x1a$.fulfillment.CreateWarehouseResponse\x12\\\n" +
+	"\x0fUpdateWarehouse\x12#.fulfillment.UpdateWarehouseRequest\x1a$.fulfillment.UpdateWarehouseResponse\x12Y\n" +
+	"\x0eListWarehouses\x12\".fulfillment.ListWarehousesRequest\x1a#.fulfillment.ListWarehousesResponse\x12G\n" +
+	"\bSetStock\x12\x1c.fulfillment.SetStockRequest\x1a\x1d.fulfillment.SetStockResponse\x12S\n" +
+	"\fFulfillOrder\x12 .fulfillment.FulfillOrderRequest\x1a!.fulfillment.FulfillOrderResponse\x12h\n" +
+	"\x13GetOrderFulfillment\x12'.fulfillment.GetOrderFulfillmentRequest\x1a(.fulfillment.GetOrderFulfillmentResponse\x12_\n" +
+	"\x10ListFulfillments\x12$.fulfillment.ListFulfillmentsRequest\x1a%.fulfillment.ListFulfillmentsResponse\x12V\n" +
+	"\rConfirmPicked\x12!.fulfillment.ConfirmPickedRequest\x1a\".fulfillment.ConfirmPickedResponse\x12V\n" +
+	"\rConfirmPacked\x12!.fulfillment.ConfirmPackedRequest\x1a\".fulfillment.ConfirmPackedResponse\x12\\\n" +
+	"\x0fShipFulfillment\x12#.fulfillment.ShipFulfillmentRequest\x1a$.fulfillment.ShipFulfillmentResponseB;Z9github.com/Ujjwaljain16/E-commerce-Backend/fulfillment/pbb\x06proto3"
+
+var (
+	file_fulfillment_fulfillment_proto_rawDescOnce sync.Once
+	file_fulfillment_fulfillment_proto_rawDescData []byte
+)
+
+func file_fulfillment_fulfillment_proto_rawDescGZIP() []byte {
+	file_fulfillment_fulfillment_proto_rawDescOnce.Do(func() {
+		file_fulfillment_fulfillment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fulfillment_fulfillment_proto_rawDesc), len(file_fulfillment_fulfillment_proto_rawDesc)))
+	})
+	return file_fulfillment_fulfillment_proto_rawDescData
+}
+
+var file_fulfillment_fulfillment_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_fulfillment_fulfillment_proto_goTypes = []any{
+	(*Warehouse)(nil),                   // 0: fulfillment.Warehouse
+	(*FulfillmentItem)(nil),             // 1: fulfillment.FulfillmentItem
+	(*Fulfillment)(nil),                 // 2: fulfillment.Fulfillment
+	(*OrderFulfillment)(nil),            // 3: fulfillment.OrderFulfillment
+	(*CreateWarehouseRequest)(nil),      // 4: fulfillment.CreateWarehouseRequest
+	(*CreateWarehouseResponse)(nil),     // 5: fulfillment.CreateWarehouseResponse
+	(*UpdateWarehouseRequest)(nil),      // 6: fulfillment.UpdateWarehouseRequest
+	(*UpdateWarehouseResponse)(nil),     // 7: fulfillment.UpdateWarehouseResponse
+	(*ListWarehousesRequest)(nil),       // 8: fulfillment.ListWarehousesRequest
+	(*ListWarehousesResponse)(nil),      // 9: fulfillment.ListWarehousesResponse
+	(*SetStockRequest)(nil),             // 10: fulfillment.SetStockRequest
+	(*SetStockResponse)(nil),            // 11: fulfillment.SetStockResponse
+	(*OrderLine)(nil),                   // 12: fulfillment.OrderLine
+	(*FulfillOrderRequest)(nil),         // 13: fulfillment.FulfillOrderRequest
+	(*FulfillOrderResponse)(nil),        // 14: fulfillment.FulfillOrderResponse
+	(*GetOrderFulfillmentRequest)(nil),  // 15: fulfillment.GetOrderFulfillmentRequest
+	(*GetOrderFulfillmentResponse)(nil), // 16: fulfillment.GetOrderFulfillmentResponse
+	(*ListFulfillmentsRequest)(nil),     // 17: fulfillment.ListFulfillmentsRequest
+	(*ListFulfillmentsResponse)(nil),    // 18: fulfillment.ListFulfillmentsResponse
+	(*ConfirmPickedRequest)(nil),        // 19: fulfillment.ConfirmPickedRequest
+	(*ConfirmPickedResponse)(nil),       // 20: fulfillment.ConfirmPickedResponse
+	(*ConfirmPackedRequest)(nil),        // 21: fulfillment.ConfirmPackedRequest
+	(*ConfirmPackedResponse)(nil),       // 22: fulfillment.ConfirmPackedResponse
+	(*ShipFulfillmentRequest)(nil),      // 23: fulfillment.ShipFulfillmentRequest
+	(*ShipFulfillmentResponse)(nil),     // 24: fulfillment.ShipFulfillmentResponse
+	(*timestamppb.Timestamp)(nil),       // 25: google.protobuf.Timestamp
+}
+var file_fulfillment_fulfillment_proto_depIdxs = []int32{
+	25, // 0: fulfillment.Warehouse.created_at:type_name -> google.protobuf.Timestamp
+	25, // 1: fulfillment.Warehouse.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: fulfillment.Fulfillment.items:type_name -> fulfillment.FulfillmentItem
+	25, // 3: fulfillment.Fulfillment.created_at:type_name -> google.protobuf.Timestamp
+	25, // 4: fulfillment.Fulfillment.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 5: fulfillment.Fulfillment.shipped_at:type_name -> google.protobuf.Timestamp
+	2,  // 6: fulfillment.OrderFulfillment.fulfillments:type_name -> fulfillment.Fulfillment
+	25, // 7: fulfillment.OrderFulfillment.created_at:type_name -> google.protobuf.Timestamp
+	25, // 8: fulfillment.OrderFulfillment.reported_at:type_name -> google.protobuf.Timestamp
+	0,  // 9: fulfillment.CreateWarehouseResponse.warehouse:type_name -> fulfillment.Warehouse
+	0,  // 10: fulfillment.UpdateWarehouseResponse.warehouse:type_name -> fulfillment.Warehouse
+	0,  // 11: fulfillment.ListWarehousesResponse.warehouses:type_name -> fulfillment.Warehouse
+	12, // 12: fulfillment.FulfillOrderRequest.lines:type_name -> fulfillment.OrderLine
+	3,  // 13: fulfillment.FulfillOrderResponse.order:type_name -> fulfillment.OrderFulfillment
+	3,  // 14: fulfillment.GetOrderFulfillmentResponse.order:type_name -> fulfillment.OrderFulfillment
+	2,  // 15: fulfillment.ListFulfillmentsResponse.fulfillments:type_name -> fulfillment.Fulfillment
+	2,  // 16: fulfillment.ConfirmPickedResponse.fulfillment:type_name -> fulfillment.Fulfillment
+	2,  // 17: fulfillment.ConfirmPackedResponse.fulfillment:type_name -> fulfillment.Fulfillment
+	2,  // 18: fulfillment.ShipFulfillmentResponse.fulfillment:type_name -> fulfillment.Fulfillment
+	4,  // 19: fulfillment.FulfillmentService.CreateWarehouse:input_type -> fulfillment.CreateWarehouseRequest
+	6,  // 20: fulfillment.FulfillmentService.UpdateWarehouse:input_type -> fulfillment.UpdateWarehouseRequest
+	8,  // 21: fulfillment.FulfillmentService.ListWarehouses:input_type -> fulfillment.ListWarehousesRequest
+	10, // 22: fulfillment.FulfillmentService.SetStock:input_type -> fulfillment.SetStockRequest
+	13, // 23: fulfillment.FulfillmentService.FulfillOrder:input_type -> fulfillment.FulfillOrderRequest
+	15, // 24: fulfillment.FulfillmentService.GetOrderFulfillment:input_type -> fulfillment.GetOrderFulfillmentRequest
+	17, // 25: fulfillment.FulfillmentService.ListFulfillments:input_type -> fulfillment.ListFulfillmentsRequest
+	19, // 26: fulfillment.FulfillmentService.ConfirmPicked:input_type -> fulfillment.ConfirmPickedRequest
+	21, // 27: fulfillment.FulfillmentService.ConfirmPacked:input_type -> fulfillment.ConfirmPackedRequest
+	23, // 28: fulfillment.FulfillmentService.ShipFulfillment:input_type -> fulfillment.ShipFulfillmentRequest
+	5,  // 29: fulfillment.FulfillmentService.CreateWarehouse:output_type -> fulfillment.CreateWarehouseResponse
+	7,  // 30: fulfillment.FulfillmentService.UpdateWarehouse:output_type -> fulfillment.UpdateWarehouseResponse
+	9,  // 31: fulfillment.FulfillmentService.ListWarehouses:output_type -> fulfillment.ListWarehousesResponse
+	11, // 32: fulfillment.FulfillmentService.SetStock:output_type -> fulfillment.SetStockResponse
+	14, // 33: fulfillment.FulfillmentService.FulfillOrder:output_type -> fulfillment.FulfillOrderResponse
+	16, // 34: fulfillment.FulfillmentService.GetOrderFulfillment:output_type -> fulfillment.GetOrderFulfillmentResponse
+	18, // 35: fulfillment.FulfillmentService.ListFulfillments:output_type -> fulfillment.ListFulfillmentsResponse
+	20, // 36: fulfillment.FulfillmentService.ConfirmPicked:output_type -> fulfillment.ConfirmPickedResponse
+	22, // 37: fulfillment.FulfillmentService.ConfirmPacked:output_type -> fulfillment.ConfirmPackedResponse
+	24, // 38: fulfillment.FulfillmentService.ShipFulfillment:output_type -> fulfillment.ShipFulfillmentResponse
+	29, // [29:39] is the sub-list for method output_type
+	19, // [19:29] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_fulfillment_fulfillment_proto_init() }
+func file_fulfillment_fulfillment_proto_init() {
+	if File_fulfillment_fulfillment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fulfillment_fulfillment_proto_rawDesc), len(file_fulfillment_fulfillment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fulfillment_fulfillment_proto_goTypes,
+		DependencyIndexes: file_fulfillment_fulfillment_proto_depIdxs,
+		MessageInfos:      file_fulfillment_fulfillment_proto_msgTypes,
+	}.Build()
+	File_fulfillment_fulfillment_proto = out.File
+	file_fulfillment_fulfillment_proto_goTypes = nil
+	file_fulfillment_fulfillment_proto_depIdxs = nil
+}
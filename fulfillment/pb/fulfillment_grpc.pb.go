@@ -0,0 +1,469 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: fulfillment/fulfillment.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FulfillmentService_CreateWarehouse_FullMethodName     = "/fulfillment.FulfillmentService/CreateWarehouse"
+	FulfillmentService_UpdateWarehouse_FullMethodName     = "/fulfillment.FulfillmentService/UpdateWarehouse"
+	FulfillmentService_ListWarehouses_FullMethodName      = "/fulfillment.FulfillmentService/ListWarehouses"
+	FulfillmentService_SetStock_FullMethodName            = "/fulfillment.FulfillmentService/SetStock"
+	FulfillmentService_FulfillOrder_FullMethodName        = "/fulfillment.FulfillmentService/FulfillOrder"
+	FulfillmentService_GetOrderFulfillment_FullMethodName = "/fulfillment.FulfillmentService/GetOrderFulfillment"
+	FulfillmentService_ListFulfillments_FullMethodName    = "/fulfillment.FulfillmentService/ListFulfillments"
+	FulfillmentService_ConfirmPicked_FullMethodName       = "/fulfillment.FulfillmentService/ConfirmPicked"
+	FulfillmentService_ConfirmPacked_FullMethodName       = "/fulfillment.FulfillmentService/ConfirmPacked"
+	FulfillmentService_ShipFulfillment_FullMethodName     = "/fulfillment.FulfillmentService/ShipFulfillment"
+)
+
+// FulfillmentServiceClient is the client API for FulfillmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FulfillmentService splits paid orders into pick and pack tasks per
+// warehouse and reports fulfilled orders to the order service
+type FulfillmentServiceClient interface {
+	CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*CreateWarehouseResponse, error)
+	UpdateWarehouse(ctx context.Context, in *UpdateWarehouseRequest, opts ...grpc.CallOption) (*UpdateWarehouseResponse, error)
+	ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error)
+	SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*SetStockResponse, error)
+	FulfillOrder(ctx context.Context, in *FulfillOrderRequest, opts ...grpc.CallOption) (*FulfillOrderResponse, error)
+	GetOrderFulfillment(ctx context.Context, in *GetOrderFulfillmentRequest, opts ...grpc.CallOption) (*GetOrderFulfillmentResponse, error)
+	ListFulfillments(ctx context.Context, in *ListFulfillmentsRequest, opts ...grpc.CallOption) (*ListFulfillmentsResponse, error)
+	ConfirmPicked(ctx context.Context, in *ConfirmPickedRequest, opts ...grpc.CallOption) (*ConfirmPickedResponse, error)
+	ConfirmPacked(ctx context.Context, in *ConfirmPackedRequest, opts ...grpc.CallOption) (*ConfirmPackedResponse, error)
+	ShipFulfillment(ctx context.Context, in *ShipFulfillmentRequest, opts ...grpc.CallOption) (*ShipFulfillmentResponse, error)
+}
+
+type fulfillmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFulfillmentServiceClient(cc grpc.ClientConnInterface) FulfillmentServiceClient {
+	return &fulfillmentServiceClient{cc}
+}
+
+func (c *fulfillmentServiceClient) CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*CreateWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWarehouseResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_CreateWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) UpdateWarehouse(ctx context.Context, in *UpdateWarehouseRequest, opts ...grpc.CallOption) (*UpdateWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWarehouseResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_UpdateWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWarehousesResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_ListWarehouses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*SetStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetStockResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_SetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) FulfillOrder(ctx context.Context, in *FulfillOrderRequest, opts ...grpc.CallOption) (*FulfillOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FulfillOrderResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_FulfillOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) GetOrderFulfillment(ctx context.Context, in *GetOrderFulfillmentRequest, opts ...grpc.CallOption) (*GetOrderFulfillmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderFulfillmentResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_GetOrderFulfillment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) ListFulfillments(ctx context.Context, in *ListFulfillmentsRequest, opts ...grpc.CallOption) (*ListFulfillmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFulfillmentsResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_ListFulfillments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) ConfirmPicked(ctx context.Context, in *ConfirmPickedRequest, opts ...grpc.CallOption) (*ConfirmPickedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmPickedResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_ConfirmPicked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) ConfirmPacked(ctx context.Context, in *ConfirmPackedRequest, opts ...grpc.CallOption) (*ConfirmPackedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmPackedResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_ConfirmPacked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fulfillmentServiceClient) ShipFulfillment(ctx context.Context, in *ShipFulfillmentRequest, opts ...grpc.CallOption) (*ShipFulfillmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShipFulfillmentResponse)
+	err := c.cc.Invoke(ctx, FulfillmentService_ShipFulfillment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FulfillmentServiceServer is the server API for FulfillmentService service.
+// All implementations must embed UnimplementedFulfillmentServiceServer
+// for forward compatibility.
+//
+// FulfillmentService splits paid orders into pick and pack tasks per
+// warehouse and reports fulfilled orders to the order service
+type FulfillmentServiceServer interface {
+	CreateWarehouse(context.Context, *CreateWarehouseRequest) (*CreateWarehouseResponse, error)
+	UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*UpdateWarehouseResponse, error)
+	ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error)
+	SetStock(context.Context, *SetStockRequest) (*SetStockResponse, error)
+	FulfillOrder(context.Context, *FulfillOrderRequest) (*FulfillOrderResponse, error)
+	GetOrderFulfillment(context.Context, *GetOrderFulfillmentRequest) (*GetOrderFulfillmentResponse, error)
+	ListFulfillments(context.Context, *ListFulfillmentsRequest) (*ListFulfillmentsResponse, error)
+	ConfirmPicked(context.Context, *ConfirmPickedRequest) (*ConfirmPickedResponse, error)
+	ConfirmPacked(context.Context, *ConfirmPackedRequest) (*ConfirmPackedResponse, error)
+	ShipFulfillment(context.Context, *ShipFulfillmentRequest) (*ShipFulfillmentResponse, error)
+	mustEmbedUnimplementedFulfillmentServiceServer()
+}
+
+// UnimplementedFulfillmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFulfillmentServiceServer struct{}
+
+func (UnimplementedFulfillmentServiceServer) CreateWarehouse(context.Context, *CreateWarehouseRequest) (*CreateWarehouseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWarehouse not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*UpdateWarehouseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateWarehouse not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWarehouses not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) SetStock(context.Context, *SetStockRequest) (*SetStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetStock not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) FulfillOrder(context.Context, *FulfillOrderRequest) (*FulfillOrderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FulfillOrder not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) GetOrderFulfillment(context.Context, *GetOrderFulfillmentRequest) (*GetOrderFulfillmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrderFulfillment not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) ListFulfillments(context.Context, *ListFulfillmentsRequest) (*ListFulfillmentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFulfillments not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) ConfirmPicked(context.Context, *ConfirmPickedRequest) (*ConfirmPickedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmPicked not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) ConfirmPacked(context.Context, *ConfirmPackedRequest) (*ConfirmPackedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmPacked not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) ShipFulfillment(context.Context, *ShipFulfillmentRequest) (*ShipFulfillmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ShipFulfillment not implemented")
+}
+func (UnimplementedFulfillmentServiceServer) mustEmbedUnimplementedFulfillmentServiceServer() {}
+func (UnimplementedFulfillmentServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeFulfillmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FulfillmentServiceServer will
+// result in compilation errors.
+type UnsafeFulfillmentServiceServer interface {
+	mustEmbedUnimplementedFulfillmentServiceServer()
+}
+
+func RegisterFulfillmentServiceServer(s grpc.ServiceRegistrar, srv FulfillmentServiceServer) {
+	// If the following call panics, it indicates UnimplementedFulfillmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FulfillmentService_ServiceDesc, srv)
+}
+
+func _FulfillmentService_CreateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).CreateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_CreateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).CreateWarehouse(ctx, req.(*CreateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_UpdateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).UpdateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_UpdateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).UpdateWarehouse(ctx, req.(*UpdateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_ListWarehouses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWarehousesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).ListWarehouses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_ListWarehouses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).ListWarehouses(ctx, req.(*ListWarehousesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_SetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).SetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_SetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).SetStock(ctx, req.(*SetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_FulfillOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FulfillOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).FulfillOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_FulfillOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).FulfillOrder(ctx, req.(*FulfillOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_GetOrderFulfillment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderFulfillmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).GetOrderFulfillment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_GetOrderFulfillment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).GetOrderFulfillment(ctx, req.(*GetOrderFulfillmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_ListFulfillments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFulfillmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).ListFulfillments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_ListFulfillments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).ListFulfillments(ctx, req.(*ListFulfillmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_ConfirmPicked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPickedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).ConfirmPicked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_ConfirmPicked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).ConfirmPicked(ctx, req.(*ConfirmPickedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_ConfirmPacked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPackedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).ConfirmPacked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_ConfirmPacked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).ConfirmPacked(ctx, req.(*ConfirmPackedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FulfillmentService_ShipFulfillment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipFulfillmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FulfillmentServiceServer).ShipFulfillment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FulfillmentService_ShipFulfillment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FulfillmentServiceServer).ShipFulfillment(ctx, req.(*ShipFulfillmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FulfillmentService_ServiceDesc is the grpc.ServiceDesc for FulfillmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FulfillmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fulfillment.FulfillmentService",
+	HandlerType: (*FulfillmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWarehouse",
+			Handler:    _FulfillmentService_CreateWarehouse_Handler,
+		},
+		{
+			MethodName: "UpdateWarehouse",
+			Handler:    _FulfillmentService_UpdateWarehouse_Handler,
+		},
+		{
+			MethodName: "ListWarehouses",
+			Handler:    _FulfillmentService_ListWarehouses_Handler,
+		},
+		{
+			MethodName: "SetStock",
+			Handler:    _FulfillmentService_SetStock_Handler,
+		},
+		{
+			MethodName: "FulfillOrder",
+			Handler:    _FulfillmentService_FulfillOrder_Handler,
+		},
+		{
+			MethodName: "GetOrderFulfillment",
+			Handler:    _FulfillmentService_GetOrderFulfillment_Handler,
+		},
+		{
+			MethodName: "ListFulfillments",
+			Handler:    _FulfillmentService_ListFulfillments_Handler,
+		},
+		{
+			MethodName: "ConfirmPicked",
+			Handler:    _FulfillmentService_ConfirmPicked_Handler,
+		},
+		{
+			MethodName: "ConfirmPacked",
+			Handler:    _FulfillmentService_ConfirmPacked_Handler,
+		},
+		{
+			MethodName: "ShipFulfillment",
+			Handler:    _FulfillmentService_ShipFulfillment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "fulfillment/fulfillment.proto",
+}
@@ -0,0 +1,67 @@
+package fulfillment
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// reportBatchSize is how many unreported orders are reported per pass
+const reportBatchSize = 50
+
+// Reporter tells the order service about shipped orders it could not be told
+// about when their last fulfillment shipped
+type Reporter struct {
+	repo   Repository
+	orders Orders
+	log    *logger.Logger
+	now    func() time.Time
+}
+
+// NewReporter creates a fulfilled order reporter
+func NewReporter(repo Repository, orders Orders, log *logger.Logger) *Reporter {
+	return &Reporter{repo: repo, orders: orders, log: log, now: time.Now}
+}
+
+// Run reports shipped orders every interval until ctx is cancelled
+func (r *Reporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.ReportShipped(ctx); err != nil {
+				r.log.Error(ctx, "Failed to report fulfilled orders", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
+
+// ReportShipped reports a batch of unreported shipped orders. An order the
+// order service cannot be told about is left for the next pass.
+func (r *Reporter) ReportShipped(ctx context.Context) error {
+	orderIDs, err := r.repo.Unreported(ctx, reportBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, orderID := range orderIDs {
+		r.report(ctx, orderID)
+	}
+	return nil
+}
+
+// report moves an order to FULFILLED and records that it was reported,
+// logging a failure of either
+func (r *Reporter) report(ctx context.Context, orderID string) {
+	if err := r.orders.MarkFulfilled(ctx, orderID); err != nil {
+		r.log.Warn(ctx, "Failed to report fulfilled order", map[string]interface{}{"order_id": orderID, "error": err.Error()})
+		return
+	}
+	// A failure is logged by the repository; the order is then reported
+	// again on the next pass, which the order service ignores
+	r.repo.MarkReported(ctx, orderID, r.now())
+}
@@ -0,0 +1,642 @@
+package fulfillment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Fulfillment statuses
+const (
+	StatusPending = "PENDING"
+	StatusPicked  = "PICKED"
+	StatusPacked  = "PACKED"
+	StatusShipped = "SHIPPED"
+)
+
+// Order fulfillment statuses
+const (
+	OrderStatusOpen             = "OPEN"
+	OrderStatusPartiallyShipped = "PARTIALLY_SHIPPED"
+	OrderStatusShipped          = "SHIPPED"
+)
+
+var (
+	// ErrWarehouseNotFound is returned when a warehouse does not exist
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+	// ErrCodeTaken is returned when a warehouse code is already used
+	ErrCodeTaken = errors.New("warehouse code already exists")
+	// ErrOrderNotFound is returned when an order has no fulfillments
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrOrderExists is returned when an order was allocated already
+	ErrOrderExists = errors.New("order already fulfilled")
+	// ErrFulfillmentNotFound is returned when a fulfillment does not exist
+	ErrFulfillmentNotFound = errors.New("fulfillment not found")
+	// ErrStatusConflict is returned when a fulfillment is not in the status
+	// the change applies to
+	ErrStatusConflict = errors.New("fulfillment status changed")
+)
+
+// warehouseCodeConstraint is the unique index on warehouse codes
+const warehouseCodeConstraint = "idx_warehouses_code"
+
+// Warehouse is a site orders are shipped from
+type Warehouse struct {
+	ID   string
+	Code string
+	Name string
+	// Priority orders warehouses for allocation; lower is preferred
+	Priority  int32
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Item is a product to pick for a fulfillment
+type Item struct {
+	ProductID string
+	SKU       string
+	Name      string
+	Quantity  int32
+}
+
+// Fulfillment is the part of an order one warehouse picks, packs and ships
+type Fulfillment struct {
+	ID             string
+	OrderID        string
+	WarehouseID    string
+	Status         string
+	Items          []*Item
+	Carrier        string
+	TrackingNumber string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ShippedAt      *time.Time
+}
+
+// Order is a paid order with its fulfillments
+type Order struct {
+	OrderID      string
+	Status       string
+	Fulfillments []*Fulfillment
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// ReportedAt is when the order service was told the order is FULFILLED
+	ReportedAt *time.Time
+}
+
+// Repository handles warehouse and fulfillment data persistence
+type Repository interface {
+	// CreateWarehouse inserts a warehouse, returning ErrCodeTaken when its
+	// code is used
+	CreateWarehouse(ctx context.Context, warehouse *Warehouse) (*Warehouse, error)
+	GetWarehouse(ctx context.Context, id string) (*Warehouse, error)
+	// UpdateWarehouse changes a warehouse's name, priority and active flag
+	UpdateWarehouse(ctx context.Context, warehouse *Warehouse) (*Warehouse, error)
+	// ListWarehouses lists warehouses in allocation order
+	ListWarehouses(ctx context.Context, includeInactive bool) ([]*Warehouse, error)
+	// SetStock sets the quantity of a product on hand at a warehouse
+	SetStock(ctx context.Context, warehouseID, productID string, quantity int32, at time.Time) error
+	// Stock returns the quantity of the products on hand at each warehouse,
+	// keyed by warehouse ID, then product ID
+	Stock(ctx context.Context, productIDs []string) (map[string]map[string]int32, error)
+	// CreateOrder stores an order's fulfillments and takes their items from
+	// warehouse stock, returning ErrOrderExists when the order has some
+	CreateOrder(ctx context.Context, order *Order) (*Order, error)
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+	GetFulfillment(ctx context.Context, id string) (*Fulfillment, error)
+	// ListFulfillments lists a warehouse's fulfillments, oldest first; an
+	// empty status matches every fulfillment
+	ListFulfillments(ctx context.Context, warehouseID, status string, page, pageSize int32) ([]*Fulfillment, int32, error)
+	// Advance moves a fulfillment from one status to another
+	Advance(ctx context.Context, id, from, to string, at time.Time) (*Fulfillment, error)
+	// Ship moves a PACKED fulfillment to SHIPPED and returns it with its
+	// order's status
+	Ship(ctx context.Context, id, carrier, trackingNumber string, at time.Time) (*Fulfillment, string, error)
+	// Unreported lists shipped orders the order service was not told about,
+	// longest shipped first
+	Unreported(ctx context.Context, limit int) ([]string, error)
+	// MarkReported records that the order service was told an order is
+	// FULFILLED
+	MarkReported(ctx context.Context, orderID string, at time.Time) error
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL fulfillment repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const warehouseColumns = `id, code, name, priority, active, created_at, updated_at`
+
+const fulfillmentColumns = `id, order_id, warehouse_id, status, carrier, tracking_number, created_at, updated_at, shipped_at`
+
+// CreateWarehouse inserts a warehouse
+func (r *postgresRepository) CreateWarehouse(ctx context.Context, warehouse *Warehouse) (*Warehouse, error) {
+	warehouse.ID = uuid.New().String()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO warehouses (id, code, name, priority, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, warehouse.ID, warehouse.Code, warehouse.Name, warehouse.Priority, warehouse.Active, warehouse.CreatedAt, warehouse.UpdatedAt)
+	if pgerr.IsUniqueViolation(err, warehouseCodeConstraint) {
+		return nil, ErrCodeTaken
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to create warehouse", map[string]interface{}{"error": err.Error(), "code": warehouse.Code})
+		return nil, fmt.Errorf("failed to create warehouse: %w", err)
+	}
+
+	r.log.Info(ctx, "Warehouse created", map[string]interface{}{"warehouse_id": warehouse.ID, "code": warehouse.Code})
+	return warehouse, nil
+}
+
+// GetWarehouse retrieves a warehouse by ID
+func (r *postgresRepository) GetWarehouse(ctx context.Context, id string) (*Warehouse, error) {
+	query := "SELECT " + warehouseColumns + " FROM warehouses WHERE id = $1"
+
+	warehouse, err := scanWarehouse(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrWarehouseNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get warehouse", map[string]interface{}{"error": err.Error(), "warehouse_id": id})
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	return warehouse, nil
+}
+
+// UpdateWarehouse changes a warehouse
+func (r *postgresRepository) UpdateWarehouse(ctx context.Context, warehouse *Warehouse) (*Warehouse, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE warehouses SET name = $2, priority = $3, active = $4, updated_at = $5
+		WHERE id = $1
+	`, warehouse.ID, warehouse.Name, warehouse.Priority, warehouse.Active, warehouse.UpdatedAt)
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrWarehouseNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update warehouse", map[string]interface{}{"error": err.Error(), "warehouse_id": warehouse.ID})
+		return nil, fmt.Errorf("failed to update warehouse: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update warehouse: %w", err)
+	}
+	if rows == 0 {
+		return nil, ErrWarehouseNotFound
+	}
+
+	r.log.Info(ctx, "Warehouse updated", map[string]interface{}{"warehouse_id": warehouse.ID})
+	return r.GetWarehouse(ctx, warehouse.ID)
+}
+
+// ListWarehouses retrieves warehouses by priority, then code
+func (r *postgresRepository) ListWarehouses(ctx context.Context, includeInactive bool) ([]*Warehouse, error) {
+	query := "SELECT " + warehouseColumns + ` FROM warehouses
+		WHERE ($1 OR active)
+		ORDER BY priority, code
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, includeInactive)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list warehouses", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list warehouses: %w", err)
+	}
+	defer rows.Close()
+
+	var warehouses []*Warehouse
+	for rows.Next() {
+		warehouse, err := scanWarehouse(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse: %w", err)
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating warehouses: %w", err)
+	}
+	return warehouses, nil
+}
+
+// SetStock upserts a warehouse stock level
+func (r *postgresRepository) SetStock(ctx context.Context, warehouseID, productID string, quantity int32, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO warehouse_stock (warehouse_id, product_id, quantity, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (warehouse_id, product_id) DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = EXCLUDED.updated_at
+	`, warehouseID, productID, quantity, at)
+	if err != nil {
+		r.log.Error(ctx, "Failed to set stock", map[string]interface{}{"error": err.Error(), "warehouse_id": warehouseID, "product_id": productID})
+		return fmt.Errorf("failed to set stock: %w", err)
+	}
+	return nil
+}
+
+// Stock reads the stock of the products at every warehouse in one query
+func (r *postgresRepository) Stock(ctx context.Context, productIDs []string) (map[string]map[string]int32, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT warehouse_id, product_id, quantity FROM warehouse_stock
+		WHERE product_id = ANY($1) AND quantity > 0
+	`, pq.Array(productIDs))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get stock", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+	defer rows.Close()
+
+	stock := make(map[string]map[string]int32)
+	for rows.Next() {
+		var warehouseID, productID string
+		var quantity int32
+		if err := rows.Scan(&warehouseID, &productID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan stock: %w", err)
+		}
+		if stock[warehouseID] == nil {
+			stock[warehouseID] = make(map[string]int32)
+		}
+		stock[warehouseID][productID] = quantity
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stock: %w", err)
+	}
+	return stock, nil
+}
+
+// CreateOrder inserts an order's fulfillments and decrements stock in one
+// transaction. Stock is never taken below zero: quantities allocated beyond
+// it are picked once the warehouse is restocked.
+func (r *postgresRepository) CreateOrder(ctx context.Context, order *Order) (*Order, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO fulfillment_orders (order_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (order_id) DO NOTHING
+	`, order.OrderID, order.Status, order.CreatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create fulfillment order", map[string]interface{}{"error": err.Error(), "order_id": order.OrderID})
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	} else if rows == 0 {
+		return nil, ErrOrderExists
+	}
+
+	for _, f := range order.Fulfillments {
+		f.ID = uuid.New().String()
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO fulfillments (id, order_id, warehouse_id, status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $5)
+		`, f.ID, order.OrderID, f.WarehouseID, f.Status, f.CreatedAt)
+		if err != nil {
+			r.log.Error(ctx, "Failed to create fulfillment", map[string]interface{}{"error": err.Error(), "order_id": order.OrderID, "warehouse_id": f.WarehouseID})
+			return nil, fmt.Errorf("failed to create fulfillment: %w", err)
+		}
+		for _, item := range f.Items {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO fulfillment_items (fulfillment_id, product_id, sku, name, quantity)
+				VALUES ($1, $2, $3, $4, $5)
+			`, f.ID, item.ProductID, item.SKU, item.Name, item.Quantity)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create fulfillment item: %w", err)
+			}
+			_, err = tx.ExecContext(ctx, `
+				UPDATE warehouse_stock SET quantity = GREATEST(quantity - $3, 0), updated_at = $4
+				WHERE warehouse_id = $1 AND product_id = $2
+			`, f.WarehouseID, item.ProductID, item.Quantity, f.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to take stock: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Order allocated", map[string]interface{}{"order_id": order.OrderID, "fulfillments": len(order.Fulfillments)})
+	return order, nil
+}
+
+// GetOrder retrieves an order with its fulfillments
+func (r *postgresRepository) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	order := &Order{OrderID: orderID}
+	var reportedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT status, created_at, updated_at, reported_at FROM fulfillment_orders WHERE order_id = $1
+	`, orderID).Scan(&order.Status, &order.CreatedAt, &order.UpdatedAt, &reportedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get fulfillment order", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if reportedAt.Valid {
+		order.ReportedAt = &reportedAt.Time
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+fulfillmentColumns+" FROM fulfillments WHERE order_id = $1 ORDER BY created_at, id", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fulfillments: %w", err)
+	}
+	if order.Fulfillments, err = scanFulfillments(rows); err != nil {
+		return nil, err
+	}
+	if err := r.loadItems(ctx, order.Fulfillments); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetFulfillment retrieves a fulfillment with its items
+func (r *postgresRepository) GetFulfillment(ctx context.Context, id string) (*Fulfillment, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+fulfillmentColumns+" FROM fulfillments WHERE id = $1", id)
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrFulfillmentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get fulfillment", map[string]interface{}{"error": err.Error(), "fulfillment_id": id})
+		return nil, fmt.Errorf("failed to get fulfillment: %w", err)
+	}
+	fulfillments, err := scanFulfillments(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(fulfillments) == 0 {
+		return nil, ErrFulfillmentNotFound
+	}
+	if err := r.loadItems(ctx, fulfillments); err != nil {
+		return nil, err
+	}
+	return fulfillments[0], nil
+}
+
+// ListFulfillments retrieves a warehouse's fulfillments with pagination
+func (r *postgresRepository) ListFulfillments(ctx context.Context, warehouseID, status string, page, pageSize int32) ([]*Fulfillment, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM fulfillments WHERE warehouse_id = $1 AND ($2 = '' OR status = $2)
+	`, warehouseID, status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count fulfillments", map[string]interface{}{"error": err.Error(), "warehouse_id": warehouseID})
+		return nil, 0, fmt.Errorf("failed to count fulfillments: %w", err)
+	}
+
+	query := "SELECT " + fulfillmentColumns + ` FROM fulfillments
+		WHERE warehouse_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, warehouseID, status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list fulfillments", map[string]interface{}{"error": err.Error(), "warehouse_id": warehouseID})
+		return nil, 0, fmt.Errorf("failed to list fulfillments: %w", err)
+	}
+	fulfillments, err := scanFulfillments(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadItems(ctx, fulfillments); err != nil {
+		return nil, 0, err
+	}
+	return fulfillments, total, nil
+}
+
+// Advance moves a fulfillment along when it is still in status from
+func (r *postgresRepository) Advance(ctx context.Context, id, from, to string, at time.Time) (*Fulfillment, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE fulfillments SET status = $3, updated_at = $4
+		WHERE id = $1 AND status = $2
+	`, id, from, to, at)
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrFulfillmentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update fulfillment", map[string]interface{}{"error": err.Error(), "fulfillment_id": id, "status": to})
+		return nil, fmt.Errorf("failed to update fulfillment: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update fulfillment: %w", err)
+	}
+	if rows == 0 {
+		return nil, r.notUpdated(ctx, id)
+	}
+
+	r.log.Info(ctx, "Fulfillment updated", map[string]interface{}{"fulfillment_id": id, "status": to})
+	return r.GetFulfillment(ctx, id)
+}
+
+// Ship marks a fulfillment shipped and updates its order in one
+// transaction. The order row is locked before its fulfillments are counted,
+// so two fulfillments shipped at once cannot both leave the order partially
+// shipped.
+func (r *postgresRepository) Ship(ctx context.Context, id, carrier, trackingNumber string, at time.Time) (*Fulfillment, string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var orderID string
+	err = tx.QueryRowContext(ctx, `
+		UPDATE fulfillments SET status = 'SHIPPED', carrier = $2, tracking_number = $3, shipped_at = $4, updated_at = $4
+		WHERE id = $1 AND status = 'PACKED'
+		RETURNING order_id
+	`, id, carrier, trackingNumber, at).Scan(&orderID)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		tx.Rollback()
+		return nil, "", r.notUpdated(ctx, id)
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to ship fulfillment", map[string]interface{}{"error": err.Error(), "fulfillment_id": id})
+		return nil, "", fmt.Errorf("failed to ship fulfillment: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM fulfillment_orders WHERE order_id = $1 FOR UPDATE", orderID); err != nil {
+		return nil, "", fmt.Errorf("failed to lock order: %w", err)
+	}
+	var unshipped int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM fulfillments WHERE order_id = $1 AND status <> 'SHIPPED'", orderID).Scan(&unshipped)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to count fulfillments: %w", err)
+	}
+	orderStatus := OrderStatusPartiallyShipped
+	if unshipped == 0 {
+		orderStatus = OrderStatusShipped
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE fulfillment_orders SET status = $2, updated_at = $3 WHERE order_id = $1", orderID, orderStatus, at)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update order: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Fulfillment shipped", map[string]interface{}{"fulfillment_id": id, "order_id": orderID, "order_status": orderStatus})
+	fulfillment, err := r.GetFulfillment(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	return fulfillment, orderStatus, nil
+}
+
+// notUpdated tells a missing fulfillment from one in another status after a
+// status-guarded update changed no row
+func (r *postgresRepository) notUpdated(ctx context.Context, id string) error {
+	if _, err := r.GetFulfillment(ctx, id); err != nil {
+		return err
+	}
+	return ErrStatusConflict
+}
+
+// Unreported retrieves shipped orders not yet reported
+func (r *postgresRepository) Unreported(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT order_id FROM fulfillment_orders
+		WHERE status = 'SHIPPED' AND reported_at IS NULL
+		ORDER BY updated_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list unreported orders", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list unreported orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orderIDs []string
+	for rows.Next() {
+		var orderID string
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders: %w", err)
+	}
+	return orderIDs, nil
+}
+
+// MarkReported sets an order's reported_at
+func (r *postgresRepository) MarkReported(ctx context.Context, orderID string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE fulfillment_orders SET reported_at = $2 WHERE order_id = $1", orderID, at)
+	if err != nil {
+		r.log.Error(ctx, "Failed to mark order reported", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+		return fmt.Errorf("failed to mark order reported: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// loadItems reads the items of fulfillments in one query
+func (r *postgresRepository) loadItems(ctx context.Context, fulfillments []*Fulfillment) error {
+	if len(fulfillments) == 0 {
+		return nil
+	}
+	byID := make(map[string]*Fulfillment, len(fulfillments))
+	ids := make([]string, len(fulfillments))
+	for i, f := range fulfillments {
+		byID[f.ID] = f
+		ids[i] = f.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT fulfillment_id, product_id, sku, name, quantity FROM fulfillment_items
+		WHERE fulfillment_id = ANY($1)
+		ORDER BY fulfillment_id, product_id
+	`, pq.Array(ids))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get fulfillment items", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get fulfillment items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fulfillmentID string
+		item := &Item{}
+		if err := rows.Scan(&fulfillmentID, &item.ProductID, &item.SKU, &item.Name, &item.Quantity); err != nil {
+			return fmt.Errorf("failed to scan fulfillment item: %w", err)
+		}
+		if f := byID[fulfillmentID]; f != nil {
+			f.Items = append(f.Items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating fulfillment items: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWarehouse(row rowScanner) (*Warehouse, error) {
+	w := &Warehouse{}
+	err := row.Scan(&w.ID, &w.Code, &w.Name, &w.Priority, &w.Active, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// scanFulfillments reads and closes rows selected with fulfillmentColumns
+func scanFulfillments(rows *sql.Rows) ([]*Fulfillment, error) {
+	defer rows.Close()
+
+	var fulfillments []*Fulfillment
+	for rows.Next() {
+		f := &Fulfillment{}
+		var shippedAt sql.NullTime
+		err := rows.Scan(&f.ID, &f.OrderID, &f.WarehouseID, &f.Status, &f.Carrier, &f.TrackingNumber, &f.CreatedAt, &f.UpdatedAt, &shippedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fulfillment: %w", err)
+		}
+		if shippedAt.Valid {
+			f.ShippedAt = &shippedAt.Time
+		}
+		fulfillments = append(fulfillments, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating fulfillments: %w", err)
+	}
+	return fulfillments, nil
+}
@@ -0,0 +1,192 @@
+package fulfillment
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("fulfillment-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var fulfillmentColumnNames = []string{
+	"id", "order_id", "warehouse_id", "status", "carrier", "tracking_number", "created_at", "updated_at", "shipped_at",
+}
+
+var itemColumnNames = []string{"fulfillment_id", "product_id", "sku", "name", "quantity"}
+
+func TestCreateWarehouse_CodeTaken(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO warehouses`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_warehouses_code"})
+
+	_, err := repo.CreateWarehouse(context.Background(), &Warehouse{Code: "AMS-1", Name: "Amsterdam"})
+	if err != ErrCodeTaken {
+		t.Errorf("Expected ErrCodeTaken, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStock(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT warehouse_id, product_id, quantity FROM warehouse_stock`).
+		WithArgs(pq.Array([]string{"mug", "lamp"})).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_id", "product_id", "quantity"}).
+			AddRow("w1", "mug", 3).
+			AddRow("w1", "lamp", 1).
+			AddRow("w2", "mug", 5))
+
+	stock, err := repo.Stock(context.Background(), []string{"mug", "lamp"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stock["w1"]["mug"] != 3 || stock["w1"]["lamp"] != 1 || stock["w2"]["mug"] != 5 || len(stock["w2"]) != 1 {
+		t.Errorf("Unexpected stock %v", stock)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateOrder(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO fulfillment_orders`).
+		WithArgs("order-1", OrderStatusOpen, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO fulfillments`).
+		WithArgs(sqlmock.AnyArg(), "order-1", "w1", StatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO fulfillment_items`).
+		WithArgs(sqlmock.AnyArg(), "mug", "MUG-1", "Mug", int32(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE warehouse_stock SET quantity = GREATEST`).
+		WithArgs("w1", "mug", int32(2), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	order, err := repo.CreateOrder(context.Background(), &Order{
+		OrderID: "order-1", Status: OrderStatusOpen, CreatedAt: now, UpdatedAt: now,
+		Fulfillments: []*Fulfillment{{
+			WarehouseID: "w1", Status: StatusPending, CreatedAt: now, UpdatedAt: now,
+			Items: []*Item{{ProductID: "mug", SKU: "MUG-1", Name: "Mug", Quantity: 2}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.Fulfillments[0].ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateOrder_Exists(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO fulfillment_orders`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateOrder(context.Background(), &Order{OrderID: "order-1", Status: OrderStatusOpen})
+	if err != ErrOrderExists {
+		t.Errorf("Expected ErrOrderExists, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestShip_LastFulfillment(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE fulfillments SET status = 'SHIPPED'`).
+		WithArgs("f1", "UPS", "1Z999", now).
+		WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow("order-1"))
+	mock.ExpectExec(`SELECT 1 FROM fulfillment_orders WHERE order_id = \$1 FOR UPDATE`).
+		WithArgs("order-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM fulfillments`).
+		WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`UPDATE fulfillment_orders SET status`).
+		WithArgs("order-1", OrderStatusShipped, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT (.+) FROM fulfillments WHERE id`).
+		WithArgs("f1").
+		WillReturnRows(sqlmock.NewRows(fulfillmentColumnNames).
+			AddRow("f1", "order-1", "w1", StatusShipped, "UPS", "1Z999", now, now, now))
+	mock.ExpectQuery(`SELECT (.+) FROM fulfillment_items`).
+		WithArgs(pq.Array([]string{"f1"})).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames).AddRow("f1", "mug", "MUG-1", "Mug", 2))
+
+	fulfillment, orderStatus, err := repo.Ship(context.Background(), "f1", "UPS", "1Z999", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if orderStatus != OrderStatusShipped {
+		t.Errorf("Expected the order shipped, got %s", orderStatus)
+	}
+	if fulfillment.ShippedAt == nil || len(fulfillment.Items) != 1 {
+		t.Errorf("Unexpected fulfillment %+v", fulfillment)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestShip_NotPacked(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE fulfillments SET status = 'SHIPPED'`).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	mock.ExpectQuery(`SELECT (.+) FROM fulfillments WHERE id`).
+		WithArgs("f1").
+		WillReturnRows(sqlmock.NewRows(fulfillmentColumnNames).
+			AddRow("f1", "order-1", "w1", StatusPicked, "", "", now, now, nil))
+	mock.ExpectQuery(`SELECT (.+) FROM fulfillment_items`).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames))
+
+	_, _, err := repo.Ship(context.Background(), "f1", "UPS", "1Z999", now)
+	if err != ErrStatusConflict {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,559 @@
+package fulfillment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/fulfillment/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxFieldLength matches the VARCHAR(255) columns
+	maxFieldLength = 255
+	// maxSKULength matches the sku column and the catalog's SKU limit
+	maxSKULength = 100
+	// maxCarrierLength matches the carrier column
+	maxCarrierLength = 100
+	// maxOrderLines matches the order service's limit on lines per order
+	maxOrderLines = 100
+)
+
+// codePattern is the form of a warehouse code once upper-cased
+var codePattern = regexp.MustCompile(`^[A-Z0-9-]{2,50}$`)
+
+// validStatuses are the statuses a fulfillment list can be filtered by
+var validStatuses = map[string]bool{
+	StatusPending: true, StatusPicked: true, StatusPacked: true, StatusShipped: true,
+}
+
+// AdminMethods are the admin-scoped RPCs of the fulfillment service; they
+// are restricted to the admin network allowlist. Warehouse staff and the
+// order service call them from inside it.
+var AdminMethods = []string{
+	pb.FulfillmentService_CreateWarehouse_FullMethodName,
+	pb.FulfillmentService_UpdateWarehouse_FullMethodName,
+	pb.FulfillmentService_ListWarehouses_FullMethodName,
+	pb.FulfillmentService_SetStock_FullMethodName,
+	pb.FulfillmentService_FulfillOrder_FullMethodName,
+	pb.FulfillmentService_GetOrderFulfillment_FullMethodName,
+	pb.FulfillmentService_ListFulfillments_FullMethodName,
+	pb.FulfillmentService_ConfirmPicked_FullMethodName,
+	pb.FulfillmentService_ConfirmPacked_FullMethodName,
+	pb.FulfillmentService_ShipFulfillment_FullMethodName,
+}
+
+// Service implements the FulfillmentService gRPC interface
+type Service struct {
+	pb.UnimplementedFulfillmentServiceServer
+	repo     Repository
+	reporter *Reporter
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+// NewService creates a new fulfillment service. Orders are reported
+// FULFILLED through orders when their last fulfillment ships.
+func NewService(repo Repository, orders Orders, log *logger.Logger) *Service {
+	return &Service{
+		repo:     repo,
+		reporter: NewReporter(repo, orders, log),
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// CreateWarehouse adds an active warehouse
+func (s *Service) CreateWarehouse(ctx context.Context, req *pb.CreateWarehouseRequest) (*pb.CreateWarehouseResponse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	name := strings.TrimSpace(req.Name)
+	msg := warehouseProblem(name, req.Priority)
+	if !codePattern.MatchString(code) {
+		msg = "code must be 2 to 50 letters, digits or -"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Create warehouse failed: "+msg, map[string]interface{}{"code": req.Code})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	now := s.now()
+	warehouse, err := s.repo.CreateWarehouse(ctx, &Warehouse{
+		Code:      code,
+		Name:      name,
+		Priority:  req.Priority,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if errors.Is(err, ErrCodeTaken) {
+		s.log.Warn(ctx, "Create warehouse failed: code already exists", map[string]interface{}{"code": code})
+		return nil, status.Error(codes.AlreadyExists, "warehouse code already exists")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create warehouse")
+	}
+
+	return &pb.CreateWarehouseResponse{
+		Warehouse: toProtoWarehouse(warehouse),
+	}, nil
+}
+
+// UpdateWarehouse replaces a warehouse's name, priority and active flag
+func (s *Service) UpdateWarehouse(ctx context.Context, req *pb.UpdateWarehouseRequest) (*pb.UpdateWarehouseResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if msg := warehouseProblem(name, req.Priority); msg != "" {
+		s.log.Warn(ctx, "Update warehouse failed: "+msg, map[string]interface{}{"warehouse_id": req.WarehouseId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	warehouse, err := s.loadWarehouse(ctx, req.WarehouseId)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouse.Name = name
+	warehouse.Priority = req.Priority
+	warehouse.Active = req.Active
+	warehouse.UpdatedAt = s.now()
+	warehouse, err = s.repo.UpdateWarehouse(ctx, warehouse)
+	if errors.Is(err, ErrWarehouseNotFound) {
+		return nil, status.Error(codes.NotFound, "warehouse not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update warehouse")
+	}
+
+	return &pb.UpdateWarehouseResponse{
+		Warehouse: toProtoWarehouse(warehouse),
+	}, nil
+}
+
+// warehouseProblem validates a warehouse's name and priority and returns a
+// message describing the first problem, or ""
+func warehouseProblem(name string, priority int32) string {
+	switch {
+	case name == "":
+		return "name is required"
+	case utf8.RuneCountInString(name) > maxFieldLength:
+		return fmt.Sprintf("name cannot exceed %d characters", maxFieldLength)
+	case priority < 0:
+		return "priority cannot be negative"
+	}
+	return ""
+}
+
+// ListWarehouses lists warehouses in allocation order
+func (s *Service) ListWarehouses(ctx context.Context, req *pb.ListWarehousesRequest) (*pb.ListWarehousesResponse, error) {
+	warehouses, err := s.repo.ListWarehouses(ctx, req.IncludeInactive)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list warehouses")
+	}
+
+	protoWarehouses := make([]*pb.Warehouse, len(warehouses))
+	for i, w := range warehouses {
+		protoWarehouses[i] = toProtoWarehouse(w)
+	}
+	return &pb.ListWarehousesResponse{
+		Warehouses: protoWarehouses,
+	}, nil
+}
+
+// SetStock sets the quantity of a product on hand at a warehouse
+func (s *Service) SetStock(ctx context.Context, req *pb.SetStockRequest) (*pb.SetStockResponse, error) {
+	var msg string
+	switch {
+	case req.ProductId == "":
+		msg = "product_id is required"
+	case len(req.ProductId) > maxFieldLength:
+		msg = "product_id is too long"
+	case req.Quantity < 0:
+		msg = "quantity cannot be negative"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Set stock failed: "+msg, map[string]interface{}{"warehouse_id": req.WarehouseId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	warehouse, err := s.loadWarehouse(ctx, req.WarehouseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetStock(ctx, warehouse.ID, req.ProductId, req.Quantity, s.now()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to set stock")
+	}
+	return &pb.SetStockResponse{
+		WarehouseId: warehouse.ID,
+		ProductId:   req.ProductId,
+		Quantity:    req.Quantity,
+	}, nil
+}
+
+// FulfillOrder allocates a paid order to warehouses and creates a pick and
+// pack task for each
+func (s *Service) FulfillOrder(ctx context.Context, req *pb.FulfillOrderRequest) (*pb.FulfillOrderResponse, error) {
+	if msg := fulfillProblem(req); msg != "" {
+		s.log.Warn(ctx, "Fulfill order failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	// A report sent again returns the order's first allocation
+	if order, err := s.repo.GetOrder(ctx, req.OrderId); err == nil {
+		return &pb.FulfillOrderResponse{Order: toProtoOrder(order)}, nil
+	} else if !errors.Is(err, ErrOrderNotFound) {
+		return nil, status.Error(codes.Internal, "failed to get order")
+	}
+
+	warehouses, err := s.repo.ListWarehouses(ctx, false)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list warehouses")
+	}
+	if len(warehouses) == 0 {
+		s.log.Error(ctx, "Fulfill order failed: no active warehouse", map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.FailedPrecondition, "no active warehouse")
+	}
+
+	items := orderItems(req.Lines)
+	productIDs := make([]string, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+	stock, err := s.repo.Stock(ctx, productIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get stock")
+	}
+
+	now := s.now()
+	fulfillments := allocate(items, warehouses, stock)
+	for _, f := range fulfillments {
+		f.OrderID = req.OrderId
+		f.CreatedAt = now
+		f.UpdatedAt = now
+	}
+	order, err := s.repo.CreateOrder(ctx, &Order{
+		OrderID:      req.OrderId,
+		Status:       OrderStatusOpen,
+		Fulfillments: fulfillments,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if errors.Is(err, ErrOrderExists) {
+		// Allocated by a report racing this one
+		order, err = s.repo.GetOrder(ctx, req.OrderId)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to fulfill order")
+	}
+
+	return &pb.FulfillOrderResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+// fulfillProblem validates an order report and returns a message describing
+// the first problem, or ""
+func fulfillProblem(req *pb.FulfillOrderRequest) string {
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case len(req.OrderId) > maxFieldLength:
+		return "order_id is too long"
+	case len(req.Lines) == 0:
+		return "lines are required"
+	case len(req.Lines) > maxOrderLines:
+		return fmt.Sprintf("at most %d lines are allowed", maxOrderLines)
+	}
+	for _, line := range req.Lines {
+		switch {
+		case line.ProductId == "":
+			return "product_id is required for every line"
+		case len(line.ProductId) > maxFieldLength:
+			return "product_id is too long"
+		case line.Quantity <= 0:
+			return "quantity must be positive"
+		case len(line.Sku) > maxSKULength:
+			return "sku is too long"
+		case utf8.RuneCountInString(line.Name) > maxFieldLength:
+			return "name is too long"
+		}
+	}
+	return ""
+}
+
+// orderItems converts order lines to items, adding up lines of one product
+func orderItems(lines []*pb.OrderLine) []*Item {
+	byProduct := make(map[string]*Item, len(lines))
+	var items []*Item
+	for _, line := range lines {
+		if item, ok := byProduct[line.ProductId]; ok {
+			item.Quantity += line.Quantity
+			continue
+		}
+		item := &Item{ProductID: line.ProductId, SKU: line.Sku, Name: line.Name, Quantity: line.Quantity}
+		byProduct[line.ProductId] = item
+		items = append(items, item)
+	}
+	return items
+}
+
+// GetOrderFulfillment returns an order's fulfillments
+func (s *Service) GetOrderFulfillment(ctx context.Context, req *pb.GetOrderFulfillmentRequest) (*pb.GetOrderFulfillmentResponse, error) {
+	if req.OrderId == "" {
+		s.log.Warn(ctx, "Get order fulfillment failed: order ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	order, err := s.repo.GetOrder(ctx, req.OrderId)
+	if errors.Is(err, ErrOrderNotFound) {
+		s.log.Warn(ctx, "Order fulfillment not found", map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get order")
+	}
+
+	return &pb.GetOrderFulfillmentResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+// ListFulfillments lists a warehouse's fulfillments, oldest first
+func (s *Service) ListFulfillments(ctx context.Context, req *pb.ListFulfillmentsRequest) (*pb.ListFulfillmentsResponse, error) {
+	if req.Status != "" && !validStatuses[req.Status] {
+		s.log.Warn(ctx, "List fulfillments failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be PENDING, PICKED, PACKED or SHIPPED")
+	}
+	warehouse, err := s.loadWarehouse(ctx, req.WarehouseId)
+	if err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	fulfillments, total, err := s.repo.ListFulfillments(ctx, warehouse.ID, req.Status, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list fulfillments")
+	}
+
+	protoFulfillments := make([]*pb.Fulfillment, len(fulfillments))
+	for i, f := range fulfillments {
+		protoFulfillments[i] = toProtoFulfillment(f)
+	}
+
+	return &pb.ListFulfillmentsResponse{
+		Fulfillments: protoFulfillments,
+		Total:        total,
+		Page:         page,
+		PageSize:     pageSize,
+	}, nil
+}
+
+// ConfirmPicked records that a PENDING fulfillment's items were picked
+func (s *Service) ConfirmPicked(ctx context.Context, req *pb.ConfirmPickedRequest) (*pb.ConfirmPickedResponse, error) {
+	fulfillment, err := s.advance(ctx, "Confirm picked", req.FulfillmentId, StatusPending, StatusPicked)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ConfirmPickedResponse{
+		Fulfillment: toProtoFulfillment(fulfillment),
+	}, nil
+}
+
+// ConfirmPacked records that a PICKED fulfillment was packed
+func (s *Service) ConfirmPacked(ctx context.Context, req *pb.ConfirmPackedRequest) (*pb.ConfirmPackedResponse, error) {
+	fulfillment, err := s.advance(ctx, "Confirm packed", req.FulfillmentId, StatusPicked, StatusPacked)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ConfirmPackedResponse{
+		Fulfillment: toProtoFulfillment(fulfillment),
+	}, nil
+}
+
+// advance moves a fulfillment from one status to the next
+func (s *Service) advance(ctx context.Context, op, fulfillmentID, from, to string) (*Fulfillment, error) {
+	fulfillment, err := s.load(ctx, fulfillmentID)
+	if err != nil {
+		return nil, err
+	}
+	if fulfillment.Status != from {
+		s.log.Warn(ctx, op+" failed: fulfillment is "+fulfillment.Status, map[string]interface{}{"fulfillment_id": fulfillment.ID})
+		return nil, status.Errorf(codes.FailedPrecondition, "only %s fulfillments can be %s", from, strings.ToLower(to))
+	}
+
+	fulfillment, err = s.repo.Advance(ctx, fulfillment.ID, from, to, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, fulfillmentID)
+	}
+	return fulfillment, nil
+}
+
+// ShipFulfillment records that a PACKED fulfillment left the warehouse. When
+// it was the order's last, the order is reported FULFILLED.
+func (s *Service) ShipFulfillment(ctx context.Context, req *pb.ShipFulfillmentRequest) (*pb.ShipFulfillmentResponse, error) {
+	carrier := strings.TrimSpace(req.Carrier)
+	trackingNumber := strings.TrimSpace(req.TrackingNumber)
+	var msg string
+	switch {
+	case carrier == "":
+		msg = "carrier is required"
+	case utf8.RuneCountInString(carrier) > maxCarrierLength:
+		msg = fmt.Sprintf("carrier cannot exceed %d characters", maxCarrierLength)
+	case trackingNumber == "":
+		msg = "tracking_number is required"
+	case len(trackingNumber) > maxFieldLength:
+		msg = "tracking_number is too long"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Ship fulfillment failed: "+msg, map[string]interface{}{"fulfillment_id": req.FulfillmentId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	fulfillment, err := s.load(ctx, req.FulfillmentId)
+	if err != nil {
+		return nil, err
+	}
+	if fulfillment.Status != StatusPacked {
+		s.log.Warn(ctx, "Ship fulfillment failed: fulfillment is "+fulfillment.Status, map[string]interface{}{"fulfillment_id": fulfillment.ID})
+		return nil, status.Error(codes.FailedPrecondition, "only PACKED fulfillments can be shipped")
+	}
+
+	fulfillment, orderStatus, err := s.repo.Ship(ctx, fulfillment.ID, carrier, trackingNumber, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, req.FulfillmentId)
+	}
+
+	// The shipment is recorded either way; an order not reported now is
+	// reported by the background reporter
+	if orderStatus == OrderStatusShipped {
+		s.reporter.report(ctx, fulfillment.OrderID)
+	}
+
+	return &pb.ShipFulfillmentResponse{
+		Fulfillment: toProtoFulfillment(fulfillment),
+		OrderStatus: orderStatus,
+	}, nil
+}
+
+// loadWarehouse returns a warehouse, or the gRPC error to answer with
+func (s *Service) loadWarehouse(ctx context.Context, warehouseID string) (*Warehouse, error) {
+	if warehouseID == "" {
+		s.log.Warn(ctx, "Warehouse request failed: warehouse ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "warehouse_id is required")
+	}
+
+	warehouse, err := s.repo.GetWarehouse(ctx, warehouseID)
+	if errors.Is(err, ErrWarehouseNotFound) {
+		s.log.Warn(ctx, "Warehouse not found", map[string]interface{}{"warehouse_id": warehouseID})
+		return nil, status.Error(codes.NotFound, "warehouse not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get warehouse")
+	}
+	return warehouse, nil
+}
+
+// load returns a fulfillment, or the gRPC error to answer with
+func (s *Service) load(ctx context.Context, fulfillmentID string) (*Fulfillment, error) {
+	if fulfillmentID == "" {
+		s.log.Warn(ctx, "Fulfillment request failed: fulfillment ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "fulfillment_id is required")
+	}
+
+	fulfillment, err := s.repo.GetFulfillment(ctx, fulfillmentID)
+	if errors.Is(err, ErrFulfillmentNotFound) {
+		s.log.Warn(ctx, "Fulfillment not found", map[string]interface{}{"fulfillment_id": fulfillmentID})
+		return nil, status.Error(codes.NotFound, "fulfillment not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get fulfillment")
+	}
+	return fulfillment, nil
+}
+
+// updateError maps an error changing a fulfillment to a gRPC status
+func (s *Service) updateError(ctx context.Context, err error, fulfillmentID string) error {
+	switch {
+	case errors.Is(err, ErrFulfillmentNotFound):
+		return status.Error(codes.NotFound, "fulfillment not found")
+	case errors.Is(err, ErrStatusConflict):
+		s.log.Warn(ctx, "Fulfillment changed by another request", map[string]interface{}{"fulfillment_id": fulfillmentID})
+		return status.Error(codes.Aborted, "fulfillment was changed by another request")
+	}
+	return status.Error(codes.Internal, "failed to update fulfillment")
+}
+
+// toProtoWarehouse converts a warehouse to protobuf
+func toProtoWarehouse(w *Warehouse) *pb.Warehouse {
+	return &pb.Warehouse{
+		Id:        w.ID,
+		Code:      w.Code,
+		Name:      w.Name,
+		Priority:  w.Priority,
+		Active:    w.Active,
+		CreatedAt: timestamppb.New(w.CreatedAt),
+		UpdatedAt: timestamppb.New(w.UpdatedAt),
+	}
+}
+
+// toProtoFulfillment converts a fulfillment to protobuf
+func toProtoFulfillment(f *Fulfillment) *pb.Fulfillment {
+	items := make([]*pb.FulfillmentItem, len(f.Items))
+	for i, item := range f.Items {
+		items[i] = &pb.FulfillmentItem{
+			ProductId: item.ProductID,
+			Sku:       item.SKU,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+		}
+	}
+	protoFulfillment := &pb.Fulfillment{
+		Id:             f.ID,
+		OrderId:        f.OrderID,
+		WarehouseId:    f.WarehouseID,
+		Status:         f.Status,
+		Items:          items,
+		Carrier:        f.Carrier,
+		TrackingNumber: f.TrackingNumber,
+		CreatedAt:      timestamppb.New(f.CreatedAt),
+		UpdatedAt:      timestamppb.New(f.UpdatedAt),
+	}
+	if f.ShippedAt != nil {
+		protoFulfillment.ShippedAt = timestamppb.New(*f.ShippedAt)
+	}
+	return protoFulfillment
+}
+
+// toProtoOrder converts an order's fulfillments to protobuf
+func toProtoOrder(o *Order) *pb.OrderFulfillment {
+	fulfillments := make([]*pb.Fulfillment, len(o.Fulfillments))
+	for i, f := range o.Fulfillments {
+		fulfillments[i] = toProtoFulfillment(f)
+	}
+	protoOrder := &pb.OrderFulfillment{
+		OrderId:      o.OrderID,
+		Status:       o.Status,
+		Fulfillments: fulfillments,
+		CreatedAt:    timestamppb.New(o.CreatedAt),
+	}
+	if o.ReportedAt != nil {
+		protoOrder.ReportedAt = timestamppb.New(*o.ReportedAt)
+	}
+	return protoOrder
+}
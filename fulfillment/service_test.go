@@ -0,0 +1,555 @@
+package fulfillment
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/fulfillment/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps warehouses, their stock and the fulfillments of
+// each order, advancing a fulfillment only from the status the caller names
+type memoryRepository struct {
+	mu           sync.Mutex
+	warehouses   map[string]*Warehouse
+	stock        map[string]map[string]int32
+	orders       map[string]*Order
+	fulfillments map[string]*Fulfillment
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		warehouses:   map[string]*Warehouse{},
+		stock:        map[string]map[string]int32{},
+		orders:       map[string]*Order{},
+		fulfillments: map[string]*Fulfillment{},
+	}
+}
+
+func (m *memoryRepository) CreateWarehouse(ctx context.Context, warehouse *Warehouse) (*Warehouse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, w := range m.warehouses {
+		if w.Code == warehouse.Code {
+			return nil, ErrCodeTaken
+		}
+	}
+	warehouse.ID = uuid.New().String()
+	stored := *warehouse
+	m.warehouses[warehouse.ID] = &stored
+	return warehouse, nil
+}
+
+func (m *memoryRepository) GetWarehouse(ctx context.Context, id string) (*Warehouse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.warehouses[id]
+	if !ok {
+		return nil, ErrWarehouseNotFound
+	}
+	copied := *w
+	return &copied, nil
+}
+
+func (m *memoryRepository) UpdateWarehouse(ctx context.Context, warehouse *Warehouse) (*Warehouse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.warehouses[warehouse.ID]; !ok {
+		return nil, ErrWarehouseNotFound
+	}
+	stored := *warehouse
+	m.warehouses[warehouse.ID] = &stored
+	return warehouse, nil
+}
+
+func (m *memoryRepository) ListWarehouses(ctx context.Context, includeInactive bool) ([]*Warehouse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var warehouses []*Warehouse
+	for _, w := range m.warehouses {
+		if includeInactive || w.Active {
+			copied := *w
+			warehouses = append(warehouses, &copied)
+		}
+	}
+	sort.Slice(warehouses, func(i, j int) bool {
+		if warehouses[i].Priority != warehouses[j].Priority {
+			return warehouses[i].Priority < warehouses[j].Priority
+		}
+		return warehouses[i].Code < warehouses[j].Code
+	})
+	return warehouses, nil
+}
+
+func (m *memoryRepository) SetStock(ctx context.Context, warehouseID, productID string, quantity int32, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stock[warehouseID] == nil {
+		m.stock[warehouseID] = map[string]int32{}
+	}
+	m.stock[warehouseID][productID] = quantity
+	return nil
+}
+
+func (m *memoryRepository) Stock(ctx context.Context, productIDs []string) (map[string]map[string]int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stock := map[string]map[string]int32{}
+	for warehouseID, products := range m.stock {
+		for _, productID := range productIDs {
+			if q := products[productID]; q > 0 {
+				if stock[warehouseID] == nil {
+					stock[warehouseID] = map[string]int32{}
+				}
+				stock[warehouseID][productID] = q
+			}
+		}
+	}
+	return stock, nil
+}
+
+func (m *memoryRepository) CreateOrder(ctx context.Context, order *Order) (*Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.orders[order.OrderID]; ok {
+		return nil, ErrOrderExists
+	}
+	for _, f := range order.Fulfillments {
+		f.ID = uuid.New().String()
+		m.fulfillments[f.ID] = f
+		for _, item := range f.Items {
+			if q := m.stock[f.WarehouseID][item.ProductID]; q > 0 {
+				m.stock[f.WarehouseID][item.ProductID] = max(q-item.Quantity, 0)
+			}
+		}
+	}
+	m.orders[order.OrderID] = order
+	return order, nil
+}
+
+// copyOrder copies an order and its fulfillments
+func copyOrder(o *Order) *Order {
+	copied := *o
+	copied.Fulfillments = make([]*Fulfillment, len(o.Fulfillments))
+	for i, f := range o.Fulfillments {
+		fc := *f
+		copied.Fulfillments[i] = &fc
+	}
+	return &copied
+}
+
+func (m *memoryRepository) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	return copyOrder(o), nil
+}
+
+func (m *memoryRepository) GetFulfillment(ctx context.Context, id string) (*Fulfillment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.fulfillments[id]
+	if !ok {
+		return nil, ErrFulfillmentNotFound
+	}
+	copied := *f
+	return &copied, nil
+}
+
+func (m *memoryRepository) ListFulfillments(ctx context.Context, warehouseID, status string, page, pageSize int32) ([]*Fulfillment, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var fulfillments []*Fulfillment
+	for _, f := range m.fulfillments {
+		if f.WarehouseID == warehouseID && (status == "" || f.Status == status) {
+			copied := *f
+			fulfillments = append(fulfillments, &copied)
+		}
+	}
+	sort.Slice(fulfillments, func(i, j int) bool { return fulfillments[i].OrderID < fulfillments[j].OrderID })
+	return fulfillments, int32(len(fulfillments)), nil
+}
+
+func (m *memoryRepository) Advance(ctx context.Context, id, from, to string, at time.Time) (*Fulfillment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.fulfillments[id]
+	if !ok {
+		return nil, ErrFulfillmentNotFound
+	}
+	if f.Status != from {
+		return nil, ErrStatusConflict
+	}
+	f.Status, f.UpdatedAt = to, at
+	copied := *f
+	return &copied, nil
+}
+
+func (m *memoryRepository) Ship(ctx context.Context, id, carrier, trackingNumber string, at time.Time) (*Fulfillment, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.fulfillments[id]
+	if !ok {
+		return nil, "", ErrFulfillmentNotFound
+	}
+	if f.Status != StatusPacked {
+		return nil, "", ErrStatusConflict
+	}
+	f.Status, f.Carrier, f.TrackingNumber, f.ShippedAt, f.UpdatedAt = StatusShipped, carrier, trackingNumber, &at, at
+
+	order := m.orders[f.OrderID]
+	order.Status = OrderStatusShipped
+	for _, of := range order.Fulfillments {
+		if of.Status != StatusShipped {
+			order.Status = OrderStatusPartiallyShipped
+		}
+	}
+	order.UpdatedAt = at
+	copied := *f
+	return &copied, order.Status, nil
+}
+
+func (m *memoryRepository) Unreported(ctx context.Context, limit int) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var orderIDs []string
+	for _, o := range m.orders {
+		if o.Status == OrderStatusShipped && o.ReportedAt == nil {
+			orderIDs = append(orderIDs, o.OrderID)
+		}
+	}
+	sort.Strings(orderIDs)
+	return orderIDs, nil
+}
+
+func (m *memoryRepository) MarkReported(ctx context.Context, orderID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orders[orderID].ReportedAt = &at
+	return nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeOrders records the orders reported FULFILLED
+type fakeOrders struct {
+	fulfilled []string
+	err       error
+}
+
+func (o *fakeOrders) MarkFulfilled(ctx context.Context, orderID string) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.fulfilled = append(o.fulfilled, orderID)
+	return nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	orders  *fakeOrders
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo:   newMemoryRepository(),
+		orders: &fakeOrders{},
+		now:    time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(f.repo, f.orders, logger.New("fulfillment-test"))
+	f.service.now = func() time.Time { return f.now }
+	f.service.reporter.now = f.service.now
+	return f
+}
+
+// warehouse creates a warehouse stocking products
+func (f *serviceFixture) warehouse(t *testing.T, code string, priority int32, stock map[string]int32) *pb.Warehouse {
+	t.Helper()
+	resp, err := f.service.CreateWarehouse(context.Background(), &pb.CreateWarehouseRequest{Code: code, Name: code, Priority: priority})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for productID, quantity := range stock {
+		_, err := f.service.SetStock(context.Background(), &pb.SetStockRequest{WarehouseId: resp.Warehouse.Id, ProductId: productID, Quantity: quantity})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	return resp.Warehouse
+}
+
+// fulfill reports a paid order
+func (f *serviceFixture) fulfill(t *testing.T, orderID string, lines ...*pb.OrderLine) *pb.OrderFulfillment {
+	t.Helper()
+	resp, err := f.service.FulfillOrder(context.Background(), &pb.FulfillOrderRequest{OrderId: orderID, Lines: lines})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Order
+}
+
+// ship picks, packs and ships a fulfillment
+func (f *serviceFixture) ship(t *testing.T, fulfillmentID string) *pb.ShipFulfillmentResponse {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := f.service.ConfirmPicked(ctx, &pb.ConfirmPickedRequest{FulfillmentId: fulfillmentID}); err != nil {
+		t.Fatalf("Expected no error picking, got %v", err)
+	}
+	if _, err := f.service.ConfirmPacked(ctx, &pb.ConfirmPackedRequest{FulfillmentId: fulfillmentID}); err != nil {
+		t.Fatalf("Expected no error packing, got %v", err)
+	}
+	resp, err := f.service.ShipFulfillment(ctx, &pb.ShipFulfillmentRequest{FulfillmentId: fulfillmentID, Carrier: "UPS", TrackingNumber: "1Z999"})
+	if err != nil {
+		t.Fatalf("Expected no error shipping, got %v", err)
+	}
+	return resp
+}
+
+func line(productID string, quantity int32) *pb.OrderLine {
+	return &pb.OrderLine{ProductId: productID, Sku: productID + "-SKU", Name: productID, Quantity: quantity}
+}
+
+func TestCreateWarehouse(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+
+	resp, err := f.service.CreateWarehouse(ctx, &pb.CreateWarehouseRequest{Code: " ams-1 ", Name: "Amsterdam", Priority: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if w := resp.Warehouse; w.Code != "AMS-1" || !w.Active || w.Priority != 1 {
+		t.Errorf("Unexpected warehouse %v", w)
+	}
+
+	if _, err := f.service.CreateWarehouse(ctx, &pb.CreateWarehouseRequest{Code: "AMS-1", Name: "Again"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a used code, got %v", err)
+	}
+
+	invalid := []*pb.CreateWarehouseRequest{
+		{Code: "A", Name: "Short code"},
+		{Code: "AMS 2", Name: "Space in code"},
+		{Code: "AMS-2"},
+		{Code: "AMS-2", Name: "Negative", Priority: -1},
+	}
+	for _, req := range invalid {
+		if _, err := f.service.CreateWarehouse(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
+
+func TestUpdateWarehouse(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+	w := f.warehouse(t, "AMS-1", 0, nil)
+
+	resp, err := f.service.UpdateWarehouse(ctx, &pb.UpdateWarehouseRequest{WarehouseId: w.Id, Name: "Amsterdam", Priority: 5, Active: false})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Warehouse.Active || resp.Warehouse.Priority != 5 || resp.Warehouse.Name != "Amsterdam" {
+		t.Errorf("Unexpected warehouse %v", resp.Warehouse)
+	}
+
+	list, err := f.service.ListWarehouses(ctx, &pb.ListWarehousesRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list.Warehouses) != 0 {
+		t.Errorf("Expected inactive warehouses left out, got %v", list.Warehouses)
+	}
+
+	if _, err := f.service.UpdateWarehouse(ctx, &pb.UpdateWarehouseRequest{WarehouseId: "missing", Name: "X"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestSetStock_Invalid(t *testing.T) {
+	f := setupService()
+	w := f.warehouse(t, "AMS-1", 0, nil)
+
+	tests := []struct {
+		name     string
+		req      *pb.SetStockRequest
+		expected codes.Code
+	}{
+		{"missing product", &pb.SetStockRequest{WarehouseId: w.Id, Quantity: 1}, codes.InvalidArgument},
+		{"negative quantity", &pb.SetStockRequest{WarehouseId: w.Id, ProductId: "mug", Quantity: -1}, codes.InvalidArgument},
+		{"missing warehouse", &pb.SetStockRequest{WarehouseId: "missing", ProductId: "mug", Quantity: 1}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.service.SetStock(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestFulfillOrder_SingleWarehouse(t *testing.T) {
+	f := setupService()
+	f.warehouse(t, "AMS-1", 0, map[string]int32{"mug": 5})
+	ber := f.warehouse(t, "BER-1", 1, map[string]int32{"mug": 5, "lamp": 2})
+
+	order := f.fulfill(t, "order-1", line("mug", 2), line("lamp", 1))
+	if order.Status != OrderStatusOpen || len(order.Fulfillments) != 1 {
+		t.Fatalf("Expected one fulfillment, got %v", order)
+	}
+	if order.Fulfillments[0].WarehouseId != ber.Id {
+		t.Errorf("Expected the order shipped whole from BER-1, got %s", order.Fulfillments[0].WarehouseId)
+	}
+	if f.repo.stock[ber.Id]["mug"] != 3 || f.repo.stock[ber.Id]["lamp"] != 1 {
+		t.Errorf("Expected stock taken at BER-1, got %v", f.repo.stock[ber.Id])
+	}
+}
+
+func TestFulfillOrder_Split(t *testing.T) {
+	f := setupService()
+	ams := f.warehouse(t, "AMS-1", 0, map[string]int32{"mug": 1})
+	ber := f.warehouse(t, "BER-1", 1, map[string]int32{"mug": 2, "lamp": 2})
+
+	order := f.fulfill(t, "order-1", line("mug", 1), line("lamp", 1), line("mug", 2))
+	if len(order.Fulfillments) != 2 {
+		t.Fatalf("Expected a split shipment, got %v", order.Fulfillments)
+	}
+	first, second := order.Fulfillments[0], order.Fulfillments[1]
+	if first.WarehouseId != ams.Id || len(first.Items) != 1 || first.Items[0].Quantity != 1 {
+		t.Errorf("Unexpected AMS-1 fulfillment %v", first)
+	}
+	if second.WarehouseId != ber.Id || len(second.Items) != 2 || second.Items[0].ProductId != "mug" || second.Items[0].Quantity != 2 {
+		t.Errorf("Unexpected BER-1 fulfillment %v", second)
+	}
+
+	// A report sent again returns the first allocation
+	again := f.fulfill(t, "order-1", line("lamp", 1))
+	if len(again.Fulfillments) != 2 || again.Fulfillments[0].Id != first.Id {
+		t.Errorf("Expected the first allocation, got %v", again)
+	}
+}
+
+func TestFulfillOrder_Rejected(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+
+	if _, err := f.service.FulfillOrder(ctx, &pb.FulfillOrderRequest{OrderId: "order-1", Lines: []*pb.OrderLine{line("mug", 1)}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without warehouses, got %v", err)
+	}
+
+	invalid := []*pb.FulfillOrderRequest{
+		{Lines: []*pb.OrderLine{line("mug", 1)}},
+		{OrderId: "order-1"},
+		{OrderId: "order-1", Lines: []*pb.OrderLine{line("mug", 0)}},
+		{OrderId: "order-1", Lines: []*pb.OrderLine{{Quantity: 1}}},
+	}
+	for _, req := range invalid {
+		if _, err := f.service.FulfillOrder(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
+
+func TestFulfillmentLifecycle(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+	f.warehouse(t, "AMS-1", 0, map[string]int32{"mug": 1})
+	f.warehouse(t, "BER-1", 1, map[string]int32{"lamp": 1})
+	order := f.fulfill(t, "order-1", line("mug", 1), line("lamp", 1))
+	first, second := order.Fulfillments[0], order.Fulfillments[1]
+
+	if _, err := f.service.ConfirmPacked(ctx, &pb.ConfirmPackedRequest{FulfillmentId: first.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition packing before picking, got %v", err)
+	}
+	if _, err := f.service.ShipFulfillment(ctx, &pb.ShipFulfillmentRequest{FulfillmentId: first.Id, Carrier: "UPS"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a tracking number, got %v", err)
+	}
+	if _, err := f.service.ConfirmPicked(ctx, &pb.ConfirmPickedRequest{FulfillmentId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	shipped := f.ship(t, first.Id)
+	if shipped.OrderStatus != OrderStatusPartiallyShipped || shipped.Fulfillment.Status != StatusShipped || shipped.Fulfillment.TrackingNumber != "1Z999" {
+		t.Errorf("Unexpected shipment %v", shipped)
+	}
+	if len(f.orders.fulfilled) != 0 {
+		t.Errorf("Expected the order not reported while partially shipped, got %v", f.orders.fulfilled)
+	}
+
+	last := f.ship(t, second.Id)
+	if last.OrderStatus != OrderStatusShipped {
+		t.Errorf("Expected the order shipped, got %s", last.OrderStatus)
+	}
+	if len(f.orders.fulfilled) != 1 || f.orders.fulfilled[0] != "order-1" {
+		t.Errorf("Expected the order reported fulfilled, got %v", f.orders.fulfilled)
+	}
+
+	got, err := f.service.GetOrderFulfillment(ctx, &pb.GetOrderFulfillmentRequest{OrderId: "order-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Order.Status != OrderStatusShipped || got.Order.ReportedAt == nil {
+		t.Errorf("Expected the order shipped and reported, got %v", got.Order)
+	}
+}
+
+func TestShipFulfillment_ReportRetried(t *testing.T) {
+	f := setupService()
+	f.warehouse(t, "AMS-1", 0, map[string]int32{"mug": 1})
+	order := f.fulfill(t, "order-1", line("mug", 1))
+
+	f.orders.err = errors.New("unavailable")
+	if resp := f.ship(t, order.Fulfillments[0].Id); resp.OrderStatus != OrderStatusShipped {
+		t.Fatalf("Expected the shipment recorded, got %v", resp)
+	}
+	if f.repo.orders["order-1"].ReportedAt != nil {
+		t.Fatal("Expected the order left unreported")
+	}
+
+	f.orders.err = nil
+	if err := NewReporter(f.repo, f.orders, logger.New("fulfillment-test")).ReportShipped(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(f.orders.fulfilled) != 1 || f.repo.orders["order-1"].ReportedAt == nil {
+		t.Errorf("Expected the reporter to report the order, got %v", f.orders.fulfilled)
+	}
+}
+
+func TestListFulfillments(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+	w := f.warehouse(t, "AMS-1", 0, map[string]int32{"mug": 10})
+	picked := f.fulfill(t, "order-1", line("mug", 1))
+	f.fulfill(t, "order-2", line("mug", 1))
+	if _, err := f.service.ConfirmPicked(ctx, &pb.ConfirmPickedRequest{FulfillmentId: picked.Fulfillments[0].Id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := f.service.ListFulfillments(ctx, &pb.ListFulfillmentsRequest{WarehouseId: w.Id, Status: StatusPending})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 1 || resp.Fulfillments[0].OrderId != "order-2" || resp.PageSize != 10 {
+		t.Errorf("Unexpected pick list %v", resp)
+	}
+
+	if _, err := f.service.ListFulfillments(ctx, &pb.ListFulfillmentsRequest{WarehouseId: w.Id, Status: "LOST"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown status, got %v", err)
+	}
+	if _, err := f.service.ListFulfillments(ctx, &pb.ListFulfillmentsRequest{WarehouseId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing warehouse, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+-- Each day's exchange rates are fetched from the rate provider against its
+-- base currency; a refetch of a day replaces its rates
+CREATE TABLE IF NOT EXISTS fx_rate_sets (
+    rate_date DATE PRIMARY KEY,
+    base CHAR(3) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    fetched_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Units of currency one unit of the day's base buys
+CREATE TABLE IF NOT EXISTS fx_rates (
+    rate_date DATE NOT NULL REFERENCES fx_rate_sets(rate_date) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (rate_date, currency)
+);
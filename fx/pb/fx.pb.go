@@ -0,0 +1,574 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: fx/fx.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Rate is how many units of a currency one unit of the base currency buys
+type Rate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Rate          float64                `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_fx_fx_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rate) ProtoMessage() {}
+
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_fx_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_fx_fx_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rate) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Rate) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+// GetRates returns the exchange rates of a day against a base currency
+type GetRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`             // optional ISO 4217 code; the provider's base when empty
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`             // optional YYYY-MM-DD; the latest rates when empty
+	Currencies    []string               `protobuf:"bytes,3,rep,name=currencies,proto3" json:"currencies,omitempty"` // optional; every currency when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatesRequest) Reset() {
+	*x = GetRatesRequest{}
+	mi := &file_fx_fx_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatesRequest) ProtoMessage() {}
+
+func (x *GetRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_fx_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetRatesRequest) Descriptor() ([]byte, []int) {
+	return file_fx_fx_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRatesRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *GetRatesRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetRatesRequest) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+type GetRatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// date is the day the rates were published for: the requested day, or
+	// the last day before it with rates, such as the Friday before a weekend
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Rates         []*Rate                `protobuf:"bytes,3,rep,name=rates,proto3" json:"rates,omitempty"`
+	FetchedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatesResponse) Reset() {
+	*x = GetRatesResponse{}
+	mi := &file_fx_fx_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatesResponse) ProtoMessage() {}
+
+func (x *GetRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_fx_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetRatesResponse) Descriptor() ([]byte, []int) {
+	return file_fx_fx_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRatesResponse) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *GetRatesResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetRatesResponse) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *GetRatesResponse) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+// Convert converts an amount between two currencies at a day's rates
+type ConvertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Date          string                 `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"` // optional YYYY-MM-DD; the latest rates when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_fx_fx_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_fx_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_fx_fx_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConvertRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ConvertRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type ConvertResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Amount          float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	From            string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To              string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Rate            float64                `protobuf:"fixed64,4,opt,name=rate,proto3" json:"rate,omitempty"`                                              // units of to per unit of from
+	ConvertedAmount float64                `protobuf:"fixed64,5,opt,name=converted_amount,json=convertedAmount,proto3" json:"converted_amount,omitempty"` // rounded to cents
+	Date            string                 `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`                                                // day of the rates used
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	mi := &file_fx_fx_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_fx_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_fx_fx_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConvertResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ConvertResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ConvertResponse) GetConvertedAmount() float64 {
+	if x != nil {
+		return x.ConvertedAmount
+	}
+	return 0
+}
+
+func (x *ConvertResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type RefreshRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRatesRequest) Reset() {
+	*x = RefreshRatesRequest{}
+	mi := &file_fx_fx_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRatesRequest) ProtoMessage() {}
+
+func (x *RefreshRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_fx_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRatesRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRatesRequest) Descriptor() ([]byte, []int) {
+	return file_fx_fx_proto_rawDescGZIP(), []int{5}
+}
+
+type RefreshRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Currencies    int32                  `protobuf:"varint,3,opt,name=currencies,proto3" json:"currencies,omitempty"`
+	FetchedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRatesResponse) Reset() {
+	*x = RefreshRatesResponse{}
+	mi := &file_fx_fx_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRatesResponse) ProtoMessage() {}
+
+func (x *RefreshRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_fx_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRatesResponse.ProtoReflect.Descriptor instead.
+func (*RefreshRatesResponse) Descriptor() ([]byte, []int) {
+	return file_fx_fx_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RefreshRatesResponse) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *RefreshRatesResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *RefreshRatesResponse) GetCurrencies() int32 {
+	if x != nil {
+		return x.Currencies
+	}
+	return 0
+}
+
+func (x *RefreshRatesResponse) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+var File_fx_fx_proto protoreflect.FileDescriptor
+
+const file_fx_fx_proto_rawDesc = "" +
+	"\n" +
+	"\vfx/fx.proto\x12\x02fx\x1a\x1fgoogle/protobuf/timestamp.proto\"6\n" +
+	"\x04Rate\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\"Y\n" +
+	"\x0fGetRatesRequest\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x03 \x03(\tR\n" +
+	"currencies\"\x95\x01\n" +
+	"\x10GetRatesResponse\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x1e\n" +
+	"\x05rates\x18\x03 \x03(\v2\b.fx.RateR\x05rates\x129\n" +
+	"\n" +
+	"fetched_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\"`\n" +
+	"\x0eConvertRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x12\n" +
+	"\x04date\x18\x04 \x01(\tR\x04date\"\xa0\x01\n" +
+	"\x0fConvertResponse\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\x01R\x04rate\x12)\n" +
+	"\x10converted_amount\x18\x05 \x01(\x01R\x0fconvertedAmount\x12\x12\n" +
+	"\x04date\x18\x06 \x01(\tR\x04date\"\x15\n" +
+	"\x13RefreshRatesRequest\"\x99\x01\n" +
+	"\x14RefreshRatesResponse\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x03 \x01(\x05R\n" +
+	"currencies\x129\n" +
+	"\n" +
+	"fetched_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt2\xb9\x01\n" +
+	"\tFXService\x125\n" +
+	"\bGetRates\x12\x13.fx.GetRatesRequest\x1a\x14.fx.GetRatesResponse\x122\n" +
+	"\aConvert\x12\x12.fx.ConvertRequest\x1a\x13.fx.ConvertResponse\x12A\n" +
+	"\fRefreshRates\x12\x17.fx.RefreshRatesRequest\x1a\x18.fx.RefreshRatesResponseB2Z0github.com/Ujjwaljain16/E-commerce-Backend/fx/pbb\x06proto3"
+
+var (
+	file_fx_fx_proto_rawDescOnce sync.Once
+	file_fx_fx_proto_rawDescData []byte
+)
+
+func file_fx_fx_proto_rawDescGZIP() []byte {
+	file_fx_fx_proto_rawDescOnce.Do(func() {
+		file_fx_fx_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fx_fx_proto_rawDesc), len(file_fx_fx_proto_rawDesc)))
+	})
+	return file_fx_fx_proto_rawDescData
+}
+
+var file_fx_fx_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_fx_fx_proto_goTypes = []any{
+	(*Rate)(nil),                  // 0: fx.Rate
+	(*GetRatesRequest)(nil),       // 1: fx.GetRatesRequest
+	(*GetRatesResponse)(nil),      // 2: fx.GetRatesResponse
+	(*ConvertRequest)(nil),        // 3: fx.ConvertRequest
+	(*ConvertResponse)(nil),       // 4: fx.ConvertResponse
+	(*RefreshRatesRequest)(nil),   // 5: fx.RefreshRatesRequest
+	(*RefreshRatesResponse)(nil),  // 6: fx.RefreshRatesResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_fx_fx_proto_depIdxs = []int32{
+	0, // 0: fx.GetRatesResponse.rates:type_name -> fx.Rate
+	7, // 1: fx.GetRatesResponse.fetched_at:type_name -> google.protobuf.Timestamp
+	7, // 2: fx.RefreshRatesResponse.fetched_at:type_name -> google.protobuf.Timestamp
+	1, // 3: fx.FXService.GetRates:input_type -> fx.GetRatesRequest
+	3, // 4: fx.FXService.Convert:input_type -> fx.ConvertRequest
+	5, // 5: fx.FXService.RefreshRates:input_type -> fx.RefreshRatesRequest
+	2, // 6: fx.FXService.GetRates:output_type -> fx.GetRatesResponse
+	4, // 7: fx.FXService.Convert:output_type -> fx.ConvertResponse
+	6, // 8: fx.FXService.RefreshRates:output_type -> fx.RefreshRatesResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_fx_fx_proto_init() }
+func file_fx_fx_proto_init() {
+	if File_fx_fx_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fx_fx_proto_rawDesc), len(file_fx_fx_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fx_fx_proto_goTypes,
+		DependencyIndexes: file_fx_fx_proto_depIdxs,
+		MessageInfos:      file_fx_fx_proto_msgTypes,
+	}.Build()
+	File_fx_fx_proto = out.File
+	file_fx_fx_proto_goTypes = nil
+	file_fx_fx_proto_depIdxs = nil
+}
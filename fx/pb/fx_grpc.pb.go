@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: fx/fx.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FXService_GetRates_FullMethodName     = "/fx.FXService/GetRates"
+	FXService_Convert_FullMethodName      = "/fx.FXService/Convert"
+	FXService_RefreshRates_FullMethodName = "/fx.FXService/RefreshRates"
+)
+
+// FXServiceClient is the client API for FXService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FXService serves daily currency exchange rates fetched from a rate
+// provider, for converting amounts between currencies
+type FXServiceClient interface {
+	GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error)
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	RefreshRates(ctx context.Context, in *RefreshRatesRequest, opts ...grpc.CallOption) (*RefreshRatesResponse, error)
+}
+
+type fXServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFXServiceClient(cc grpc.ClientConnInterface) FXServiceClient {
+	return &fXServiceClient{cc}
+}
+
+func (c *fXServiceClient) GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatesResponse)
+	err := c.cc.Invoke(ctx, FXService_GetRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fXServiceClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, FXService_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fXServiceClient) RefreshRates(ctx context.Context, in *RefreshRatesRequest, opts ...grpc.CallOption) (*RefreshRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshRatesResponse)
+	err := c.cc.Invoke(ctx, FXService_RefreshRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FXServiceServer is the server API for FXService service.
+// All implementations must embed UnimplementedFXServiceServer
+// for forward compatibility.
+//
+// FXService serves daily currency exchange rates fetched from a rate
+// provider, for converting amounts between currencies
+type FXServiceServer interface {
+	GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error)
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	RefreshRates(context.Context, *RefreshRatesRequest) (*RefreshRatesResponse, error)
+	mustEmbedUnimplementedFXServiceServer()
+}
+
+// UnimplementedFXServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFXServiceServer struct{}
+
+func (UnimplementedFXServiceServer) GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRates not implemented")
+}
+func (UnimplementedFXServiceServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedFXServiceServer) RefreshRates(context.Context, *RefreshRatesRequest) (*RefreshRatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefreshRates not implemented")
+}
+func (UnimplementedFXServiceServer) mustEmbedUnimplementedFXServiceServer() {}
+func (UnimplementedFXServiceServer) testEmbeddedByValue()                   {}
+
+// UnsafeFXServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FXServiceServer will
+// result in compilation errors.
+type UnsafeFXServiceServer interface {
+	mustEmbedUnimplementedFXServiceServer()
+}
+
+func RegisterFXServiceServer(s grpc.ServiceRegistrar, srv FXServiceServer) {
+	// If the following call panics, it indicates UnimplementedFXServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FXService_ServiceDesc, srv)
+}
+
+func _FXService_GetRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FXServiceServer).GetRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FXService_GetRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FXServiceServer).GetRates(ctx, req.(*GetRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FXService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FXServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FXService_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FXServiceServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FXService_RefreshRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FXServiceServer).RefreshRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FXService_RefreshRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FXServiceServer).RefreshRates(ctx, req.(*RefreshRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FXService_ServiceDesc is the grpc.ServiceDesc for FXService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FXService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fx.FXService",
+	HandlerType: (*FXServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRates",
+			Handler:    _FXService_GetRates_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _FXService_Convert_Handler,
+		},
+		{
+			MethodName: "RefreshRates",
+			Handler:    _FXService_RefreshRates_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "fx/fx.proto",
+}
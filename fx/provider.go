@@ -0,0 +1,163 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ECBDailyURL is the European Central Bank's daily reference rates, published
+// against EUR on working days around 16:00 CET
+const ECBDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// maxResponseBytes caps the size of a provider response
+const maxResponseBytes = 1 << 20
+
+// Provider fetches the current exchange rates
+type Provider interface {
+	// Name identifies the provider in stored rates
+	Name() string
+	// Fetch returns the provider's latest rates
+	Fetch(ctx context.Context) (*RateSet, error)
+}
+
+// httpProvider fetches and decodes rates from a URL
+type httpProvider struct {
+	name   string
+	url    string
+	accept string
+	decode func(io.Reader) (*RateSet, error)
+	client *http.Client
+}
+
+// NewECBProvider creates a provider reading the European Central Bank's
+// reference rates XML from rawURL, normally ECBDailyURL
+func NewECBProvider(rawURL string) (Provider, error) {
+	return newHTTPProvider("ecb", rawURL, "application/xml", decodeECB)
+}
+
+// NewJSONProvider creates a provider reading rates from a JSON API at rawURL,
+// such as Frankfurter. The response is an object:
+//
+//	{"base": "EUR", "date": "2026-10-15", "rates": {"USD": 1.0921, "GBP": 0.8567}}
+//
+// An API key, where the API needs one, goes in rawURL.
+func NewJSONProvider(rawURL string) (Provider, error) {
+	return newHTTPProvider("json", rawURL, "application/json", decodeJSON)
+}
+
+func newHTTPProvider(name, rawURL, accept string, decode func(io.Reader) (*RateSet, error)) (Provider, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid rate provider URL %q", rawURL)
+	}
+	return &httpProvider{
+		name:   name,
+		url:    rawURL,
+		accept: accept,
+		decode: decode,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (p *httpProvider) Name() string {
+	return p.name
+}
+
+func (p *httpProvider) Fetch(ctx context.Context) (*RateSet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", p.accept)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rate provider responded %s", resp.Status)
+	}
+
+	set, err := p.decode(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode rates: %w", err)
+	}
+	set.Provider = p.name
+	if msg := set.problem(); msg != "" {
+		return nil, fmt.Errorf("invalid rates: %s", msg)
+	}
+	return set, nil
+}
+
+type ecbEnvelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// decodeECB reads the ECB's reference rates, taking the first day listed
+func decodeECB(r io.Reader) (*RateSet, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, err
+	}
+	if len(envelope.Cube.Days) == 0 {
+		return nil, errors.New("no rates")
+	}
+
+	rates := envelope.Cube.Days[0]
+	date, err := time.Parse(DateLayout, rates.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", rates.Time)
+	}
+	set := &RateSet{Base: "EUR", Date: date, Rates: make(map[string]float64, len(rates.Rates))}
+	for _, entry := range rates.Rates {
+		rate, err := strconv.ParseFloat(entry.Rate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate %q of %s", entry.Rate, entry.Currency)
+		}
+		set.Rates[strings.ToUpper(entry.Currency)] = rate
+	}
+	return set, nil
+}
+
+type jsonRates struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+func decodeJSON(r io.Reader) (*RateSet, error) {
+	var body jsonRates
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(DateLayout, body.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", body.Date)
+	}
+	set := &RateSet{Base: strings.ToUpper(body.Base), Date: date, Rates: make(map[string]float64, len(body.Rates))}
+	for currency, rate := range body.Rates {
+		currency = strings.ToUpper(currency)
+		if currency != set.Base {
+			set.Rates[currency] = rate
+		}
+	}
+	return set, nil
+}
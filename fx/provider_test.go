@@ -0,0 +1,105 @@
+package fx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const ecbResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time='2026-10-15'>
+			<Cube currency='USD' rate='1.0921'/>
+			<Cube currency='JPY' rate='163.42'/>
+			<Cube currency='GBP' rate='0.8567'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func serve(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestECBProvider(t *testing.T) {
+	server := serve(t, http.StatusOK, ecbResponse)
+	provider, err := NewECBProvider(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	set, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if set.Base != "EUR" || set.Provider != "ecb" || !set.Date.Equal(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected rates %+v", set)
+	}
+	if len(set.Rates) != 3 || set.Rates["JPY"] != 163.42 {
+		t.Errorf("Expected 3 rates, got %v", set.Rates)
+	}
+}
+
+func TestJSONProvider(t *testing.T) {
+	server := serve(t, http.StatusOK, `{"amount": 1.0, "base": "usd", "date": "2026-10-15", "rates": {"EUR": 0.9157, "usd": 1}}`)
+	provider, err := NewJSONProvider(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	set, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if set.Base != "USD" || len(set.Rates) != 1 || set.Rates["EUR"] != 0.9157 {
+		t.Errorf("Expected the EUR rate against USD, got %+v", set)
+	}
+}
+
+func TestProvider_InvalidResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"server error", http.StatusServiceUnavailable, "", "responded 503"},
+		{"not JSON", http.StatusOK, "<html>", "failed to decode"},
+		{"missing date", http.StatusOK, `{"base": "EUR", "rates": {"USD": 1.09}}`, "invalid date"},
+		{"no rates", http.StatusOK, `{"base": "EUR", "date": "2026-10-15", "rates": {}}`, "no rates"},
+		{"zero rate", http.StatusOK, `{"base": "EUR", "date": "2026-10-15", "rates": {"USD": 0}}`, "out of range"},
+		{"invalid currency", http.StatusOK, `{"base": "EUR", "date": "2026-10-15", "rates": {"US": 1.09}}`, "not a currency code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := serve(t, tt.status, tt.body)
+			provider, err := NewJSONProvider(server.URL)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			_, err = provider.Fetch(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNewProvider_InvalidURL(t *testing.T) {
+	for _, rawURL := range []string{"", "ftp://rates.example.com", "https://"} {
+		if _, err := NewJSONProvider(rawURL); err == nil {
+			t.Errorf("Expected an error for %q", rawURL)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// DateLayout is how rate days are written in requests and responses
+const DateLayout = "2006-01-02"
+
+// maxRate bounds a rate to what the NUMERIC(20, 10) rate column holds
+const maxRate = 9999999999
+
+// ErrUnknownCurrency is returned when a day's rates do not include a currency
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// RateSet is one day's exchange rates against a base currency
+type RateSet struct {
+	Base string
+	// Date is the day the rates were published for, at midnight UTC
+	Date time.Time
+	// Rates are the units of each currency one unit of Base buys. Base
+	// itself is left out.
+	Rates     map[string]float64
+	Provider  string
+	FetchedAt time.Time
+}
+
+// Rate returns the units of to one unit of from buys, crossing through the
+// base currency
+func (s *RateSet) Rate(from, to string) (float64, error) {
+	fromRate, err := s.baseRate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := s.baseRate(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+func (s *RateSet) baseRate(currency string) (float64, error) {
+	if currency == s.Base {
+		return 1, nil
+	}
+	rate, ok := s.Rates[currency]
+	if !ok {
+		return 0, fmt.Errorf("%w %s", ErrUnknownCurrency, currency)
+	}
+	return rate, nil
+}
+
+// currencies lists the base and every rated currency, sorted
+func (s *RateSet) currencies() []string {
+	currencies := make([]string, 0, len(s.Rates)+1)
+	currencies = append(currencies, s.Base)
+	for currency := range s.Rates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// problem validates rates fetched from a provider and returns a message
+// describing the first problem, or ""
+func (s *RateSet) problem() string {
+	switch {
+	case !isCurrencyCode(s.Base):
+		return fmt.Sprintf("base %q is not a currency code", s.Base)
+	case s.Date.IsZero():
+		return "date is missing"
+	case len(s.Rates) == 0:
+		return "no rates"
+	}
+	for currency, rate := range s.Rates {
+		if !isCurrencyCode(currency) {
+			return fmt.Sprintf("%q is not a currency code", currency)
+		}
+		if math.IsNaN(rate) || rate <= 0 || rate > maxRate {
+			return fmt.Sprintf("rate of %s is out of range", currency)
+		}
+	}
+	return ""
+}
+
+// day truncates a time to its day in UTC
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// isCurrencyCode reports whether code is three upper-case letters
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,133 @@
+package fx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// DefaultRefreshInterval is how often rates are fetched. Providers
+	// publish once a day; fetching more often picks up a late publication.
+	DefaultRefreshInterval = time.Hour
+	// maxCachedDays bounds the past days whose rates are kept in memory
+	maxCachedDays = 400
+)
+
+// Cache keeps the latest rates, and the rates of past days asked for, in
+// memory in front of the repository
+type Cache struct {
+	repo   Repository
+	mu     sync.Mutex
+	latest *RateSet
+	days   map[string]*RateSet
+}
+
+// NewCache creates an empty cache reading through to repo
+func NewCache(repo Repository) *Cache {
+	return &Cache{repo: repo, days: map[string]*RateSet{}}
+}
+
+// Latest returns the most recent rates, or ErrRatesNotFound before any were
+// fetched
+func (c *Cache) Latest(ctx context.Context) (*RateSet, error) {
+	c.mu.Lock()
+	latest := c.latest
+	c.mu.Unlock()
+	if latest != nil {
+		return latest, nil
+	}
+
+	set, err := c.repo.LatestRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.Put(set)
+	return set, nil
+}
+
+// On returns the rates of a day, or of the last day before it with rates.
+// Only days that have their own rates are cached, since a day without them
+// yet may still get some.
+func (c *Cache) On(ctx context.Context, date time.Time) (*RateSet, error) {
+	key := date.Format(DateLayout)
+	c.mu.Lock()
+	set, ok := c.days[key]
+	c.mu.Unlock()
+	if ok {
+		return set, nil
+	}
+
+	set, err := c.repo.RatesOn(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if set.Date.Equal(date) {
+		c.mu.Lock()
+		if len(c.days) >= maxCachedDays {
+			c.days = map[string]*RateSet{}
+		}
+		c.days[key] = set
+		c.mu.Unlock()
+	}
+	return set, nil
+}
+
+// Put caches freshly stored rates, replacing any cached for their day
+func (c *Cache) Put(set *RateSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.latest == nil || !set.Date.Before(c.latest.Date) {
+		c.latest = set
+	}
+	delete(c.days, set.Date.Format(DateLayout))
+}
+
+// Refresher fetches the provider's rates, stores them and caches them
+type Refresher struct {
+	provider Provider
+	repo     Repository
+	cache    *Cache
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+// NewRefresher creates a refresher storing provider's rates in repo and cache
+func NewRefresher(provider Provider, repo Repository, cache *Cache, log *logger.Logger) *Refresher {
+	return &Refresher{provider: provider, repo: repo, cache: cache, log: log, now: time.Now}
+}
+
+// Run refreshes the rates every interval until ctx is cancelled
+func (r *Refresher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Refresh(ctx); err != nil {
+				r.log.Error(ctx, "Failed to refresh exchange rates", map[string]interface{}{"error": err.Error(), "provider": r.provider.Name()})
+			}
+		}
+	}
+}
+
+// Refresh fetches the provider's latest rates and stores them
+func (r *Refresher) Refresh(ctx context.Context) (*RateSet, error) {
+	set, err := r.provider.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	set.Date = day(set.Date)
+	set.FetchedAt = r.now()
+
+	if err := r.repo.SaveRates(ctx, set); err != nil {
+		return nil, err
+	}
+	r.cache.Put(set)
+	return set, nil
+}
@@ -0,0 +1,135 @@
+package fx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// ErrRatesNotFound is returned when no rates were stored for a day or any
+// day before it
+var ErrRatesNotFound = errors.New("rates not found")
+
+// Repository handles exchange rate persistence
+type Repository interface {
+	// SaveRates stores a day's rates, replacing any stored for the day
+	SaveRates(ctx context.Context, set *RateSet) error
+	// LatestRates returns the rates of the most recent day stored
+	LatestRates(ctx context.Context) (*RateSet, error)
+	// RatesOn returns the rates of a day, or of the last day before it with
+	// rates
+	RatesOn(ctx context.Context, date time.Time) (*RateSet, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL exchange rate repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+// SaveRates upserts the day and replaces its rates in one transaction
+func (r *postgresRepository) SaveRates(ctx context.Context, set *RateSet) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO fx_rate_sets (rate_date, base, provider, fetched_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (rate_date) DO UPDATE SET base = EXCLUDED.base, provider = EXCLUDED.provider, fetched_at = EXCLUDED.fetched_at
+	`, set.Date, set.Base, set.Provider, set.FetchedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to save rates", map[string]interface{}{"error": err.Error(), "date": set.Date.Format(DateLayout)})
+		return fmt.Errorf("failed to save rates: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM fx_rates WHERE rate_date = $1", set.Date); err != nil {
+		return fmt.Errorf("failed to delete rates: %w", err)
+	}
+	for currency, rate := range set.Rates {
+		_, err := tx.ExecContext(ctx, "INSERT INTO fx_rates (rate_date, currency, rate) VALUES ($1, $2, $3)", set.Date, currency, rate)
+		if err != nil {
+			r.log.Error(ctx, "Failed to save rate", map[string]interface{}{"error": err.Error(), "currency": currency})
+			return fmt.Errorf("failed to save rate: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Rates saved", map[string]interface{}{
+		"date":       set.Date.Format(DateLayout),
+		"base":       set.Base,
+		"currencies": len(set.Rates),
+	})
+	return nil
+}
+
+// LatestRates retrieves the rates of the most recent day
+func (r *postgresRepository) LatestRates(ctx context.Context) (*RateSet, error) {
+	return r.loadRates(ctx, `
+		SELECT rate_date, base, provider, fetched_at FROM fx_rate_sets
+		ORDER BY rate_date DESC
+		LIMIT 1
+	`)
+}
+
+// RatesOn retrieves the rates of the last day on or before date
+func (r *postgresRepository) RatesOn(ctx context.Context, date time.Time) (*RateSet, error) {
+	return r.loadRates(ctx, `
+		SELECT rate_date, base, provider, fetched_at FROM fx_rate_sets
+		WHERE rate_date <= $1
+		ORDER BY rate_date DESC
+		LIMIT 1
+	`, date)
+}
+
+func (r *postgresRepository) loadRates(ctx context.Context, query string, args ...interface{}) (*RateSet, error) {
+	set := &RateSet{Rates: map[string]float64{}}
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&set.Date, &set.Base, &set.Provider, &set.FetchedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrRatesNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rates", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get rates: %w", err)
+	}
+	set.Date = day(set.Date)
+
+	rows, err := r.db.QueryContext(ctx, "SELECT currency, rate FROM fx_rates WHERE rate_date = $1", set.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var currency string
+		var rate float64
+		if err := rows.Scan(&currency, &rate); err != nil {
+			return nil, fmt.Errorf("failed to scan rate: %w", err)
+		}
+		set.Rates[currency] = rate
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rates: %w", err)
+	}
+
+	return set, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
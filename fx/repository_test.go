@@ -0,0 +1,91 @@
+package fx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("fx-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+func TestSaveRates(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	date := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	fetchedAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO fx_rate_sets (.+) ON CONFLICT \(rate_date\) DO UPDATE`).
+		WithArgs(date, "EUR", "ecb", fetchedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`DELETE FROM fx_rates WHERE rate_date = \$1`).
+		WithArgs(date).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO fx_rates`).
+		WithArgs(date, "USD", 1.09).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	set := &RateSet{Base: "EUR", Date: date, Rates: map[string]float64{"USD": 1.09}, Provider: "ecb", FetchedAt: fetchedAt}
+	if err := repo.SaveRates(context.Background(), set); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRatesOn(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	asked := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	friday := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT rate_date, base, provider, fetched_at FROM fx_rate_sets\s+WHERE rate_date <= \$1`).
+		WithArgs(asked).
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base", "provider", "fetched_at"}).AddRow(friday, "EUR", "ecb", friday.Add(15*time.Hour)))
+	mock.ExpectQuery(`SELECT currency, rate FROM fx_rates WHERE rate_date = \$1`).
+		WithArgs(friday).
+		WillReturnRows(sqlmock.NewRows([]string{"currency", "rate"}).AddRow("USD", 1.09).AddRow("GBP", 0.86))
+
+	set, err := repo.RatesOn(context.Background(), asked)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !set.Date.Equal(friday) || set.Base != "EUR" || len(set.Rates) != 2 || set.Rates["GBP"] != 0.86 {
+		t.Errorf("Unexpected rates %+v", set)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLatestRates_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT rate_date, base, provider, fetched_at FROM fx_rate_sets\s+ORDER BY rate_date DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base", "provider", "fetched_at"}))
+
+	if _, err := repo.LatestRates(context.Background()); !errors.Is(err, ErrRatesNotFound) {
+		t.Errorf("Expected ErrRatesNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
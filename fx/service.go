@@ -0,0 +1,206 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/fx/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultMaxRateAge is how old the latest rates may get before
+	// conversions at the latest rates are refused. It spans long weekends,
+	// when providers publish nothing.
+	DefaultMaxRateAge = 7 * 24 * time.Hour
+	// maxAmount bounds the amounts converted
+	maxAmount = 999999999999.99
+	// maxCurrencies bounds the currencies asked for in one GetRates call
+	maxCurrencies = 200
+)
+
+// AdminMethods are the admin-scoped RPCs of the FX service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.FXService_RefreshRates_FullMethodName,
+}
+
+// Service implements the FXService gRPC interface
+type Service struct {
+	pb.UnimplementedFXServiceServer
+	rates     *Cache
+	refresher *Refresher
+	// maxAge is how old the latest rates may get before Convert refuses them
+	maxAge time.Duration
+	log    *logger.Logger
+	now    func() time.Time
+}
+
+// NewService creates a new FX service serving rates from the cache that
+// refresher fills
+func NewService(rates *Cache, refresher *Refresher, maxAge time.Duration, log *logger.Logger) *Service {
+	return &Service{
+		rates:     rates,
+		refresher: refresher,
+		maxAge:    maxAge,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// GetRates returns a day's rates against a base currency
+func (s *Service) GetRates(ctx context.Context, req *pb.GetRatesRequest) (*pb.GetRatesResponse, error) {
+	base := strings.ToUpper(req.Base)
+	currencies := make([]string, len(req.Currencies))
+	for i, currency := range req.Currencies {
+		currencies[i] = strings.ToUpper(currency)
+	}
+	msg := ""
+	switch {
+	case base != "" && !isCurrencyCode(base):
+		msg = "base must be a three-letter ISO 4217 code"
+	case len(currencies) > maxCurrencies:
+		msg = "too many currencies"
+	default:
+		for _, currency := range currencies {
+			if !isCurrencyCode(currency) {
+				msg = "currencies must be three-letter ISO 4217 codes"
+				break
+			}
+		}
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Get rates failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	set, err := s.rateSet(ctx, "Get rates", req.Date)
+	if err != nil {
+		return nil, err
+	}
+	if base == "" {
+		base = set.Base
+	}
+	if len(currencies) == 0 {
+		currencies = set.currencies()
+	}
+
+	resp := &pb.GetRatesResponse{
+		Base:      base,
+		Date:      set.Date.Format(DateLayout),
+		Rates:     make([]*pb.Rate, 0, len(currencies)),
+		FetchedAt: timestamppb.New(set.FetchedAt),
+	}
+	for _, currency := range currencies {
+		if currency == base {
+			continue
+		}
+		rate, err := set.Rate(base, currency)
+		if errors.Is(err, ErrUnknownCurrency) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		resp.Rates = append(resp.Rates, &pb.Rate{Currency: currency, Rate: rate})
+	}
+	return resp, nil
+}
+
+// Convert converts an amount between two currencies at a day's rates
+func (s *Service) Convert(ctx context.Context, req *pb.ConvertRequest) (*pb.ConvertResponse, error) {
+	from, to := strings.ToUpper(req.From), strings.ToUpper(req.To)
+	if msg := convertProblem(req.Amount, from, to); msg != "" {
+		s.log.Warn(ctx, "Convert failed: "+msg, map[string]interface{}{"from": req.From, "to": req.To})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	set, err := s.rateSet(ctx, "Convert", req.Date)
+	if err != nil {
+		return nil, err
+	}
+	if req.Date == "" && s.now().Sub(set.Date) > s.maxAge {
+		s.log.Error(ctx, "Convert failed: exchange rates are out of date", map[string]interface{}{"date": set.Date.Format(DateLayout)})
+		return nil, status.Error(codes.FailedPrecondition, "exchange rates are out of date")
+	}
+
+	rate, err := set.Rate(from, to)
+	if errors.Is(err, ErrUnknownCurrency) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &pb.ConvertResponse{
+		Amount:          req.Amount,
+		From:            from,
+		To:              to,
+		Rate:            rate,
+		ConvertedAmount: roundCents(req.Amount * rate),
+		Date:            set.Date.Format(DateLayout),
+	}, nil
+}
+
+// RefreshRates fetches the provider's latest rates now, without waiting for
+// the next scheduled refresh
+func (s *Service) RefreshRates(ctx context.Context, req *pb.RefreshRatesRequest) (*pb.RefreshRatesResponse, error) {
+	set, err := s.refresher.Refresh(ctx)
+	if err != nil {
+		s.log.Error(ctx, "Failed to refresh exchange rates", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Unavailable, "failed to refresh rates")
+	}
+	return &pb.RefreshRatesResponse{
+		Base:       set.Base,
+		Date:       set.Date.Format(DateLayout),
+		Currencies: int32(len(set.Rates)),
+		FetchedAt:  timestamppb.New(set.FetchedAt),
+	}, nil
+}
+
+// rateSet returns the rates of a YYYY-MM-DD date, or the latest rates when
+// date is empty, returning a gRPC status error on failure
+func (s *Service) rateSet(ctx context.Context, op, date string) (*RateSet, error) {
+	if date == "" {
+		set, err := s.rates.Latest(ctx)
+		if errors.Is(err, ErrRatesNotFound) {
+			s.log.Error(ctx, op+" failed: no exchange rates have been fetched", nil)
+			return nil, status.Error(codes.FailedPrecondition, "no exchange rates available")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get rates")
+		}
+		return set, nil
+	}
+
+	on, err := time.Parse(DateLayout, date)
+	if err != nil {
+		s.log.Warn(ctx, op+" failed: invalid date", map[string]interface{}{"date": date})
+		return nil, status.Error(codes.InvalidArgument, "date must be YYYY-MM-DD")
+	}
+	if on.After(s.now()) {
+		s.log.Warn(ctx, op+" failed: date is in the future", map[string]interface{}{"date": date})
+		return nil, status.Error(codes.InvalidArgument, "date is in the future")
+	}
+	set, err := s.rates.On(ctx, on)
+	if errors.Is(err, ErrRatesNotFound) {
+		return nil, status.Error(codes.NotFound, "no rates on or before "+date)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get rates")
+	}
+	return set, nil
+}
+
+// convertProblem validates a conversion and returns a message describing the
+// first problem, or ""
+func convertProblem(amount float64, from, to string) string {
+	switch {
+	case math.IsNaN(amount) || math.Abs(amount) > maxAmount:
+		return "amount is out of range"
+	case !isCurrencyCode(from):
+		return "from must be a three-letter ISO 4217 code"
+	case !isCurrencyCode(to):
+		return "to must be a three-letter ISO 4217 code"
+	}
+	return ""
+}
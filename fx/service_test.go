@@ -0,0 +1,274 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/fx/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps rate sets by date and counts the reads, so tests can
+// check which lookups the cache serves
+type memoryRepository struct {
+	mu    sync.Mutex
+	sets  map[string]*RateSet
+	reads int
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{sets: map[string]*RateSet{}}
+}
+
+func (m *memoryRepository) SaveRates(ctx context.Context, set *RateSet) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sets[set.Date.Format(DateLayout)] = set
+	return nil
+}
+
+func (m *memoryRepository) LatestRates(ctx context.Context) (*RateSet, error) {
+	return m.RatesOn(ctx, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (m *memoryRepository) RatesOn(ctx context.Context, date time.Time) (*RateSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reads++
+	var found *RateSet
+	for _, set := range m.sets {
+		if !set.Date.After(date) && (found == nil || set.Date.After(found.Date)) {
+			found = set
+		}
+	}
+	if found == nil {
+		return nil, ErrRatesNotFound
+	}
+	return found, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeProvider returns the rates it is given
+type fakeProvider struct {
+	set *RateSet
+	err error
+}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) Fetch(ctx context.Context) (*RateSet, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	set := *p.set
+	return &set, nil
+}
+
+type serviceFixture struct {
+	repo     *memoryRepository
+	provider *fakeProvider
+	service  *Service
+	now      time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo: newMemoryRepository(),
+		provider: &fakeProvider{set: &RateSet{
+			Base:  "EUR",
+			Date:  time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+			Rates: map[string]float64{"USD": 1.25, "GBP": 0.8, "JPY": 160},
+		}},
+		now: time.Date(2026, 10, 15, 17, 0, 0, 0, time.UTC),
+	}
+	rates := NewCache(f.repo)
+	refresher := NewRefresher(f.provider, f.repo, rates, logger.New("fx-test"))
+	refresher.now = func() time.Time { return f.now }
+	f.service = NewService(rates, refresher, DefaultMaxRateAge, logger.New("fx-test"))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *serviceFixture) refresh(t *testing.T) {
+	t.Helper()
+	if _, err := f.service.RefreshRates(context.Background(), &pb.RefreshRatesRequest{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	f := setupService()
+	f.refresh(t)
+
+	tests := []struct {
+		from, to  string
+		amount    float64
+		rate      float64
+		converted float64
+	}{
+		{"EUR", "USD", 10, 1.25, 12.5},
+		{"usd", "eur", 10, 0.8, 8},
+		{"USD", "GBP", 19.99, 0.64, 12.79},
+		{"GBP", "GBP", 5, 1, 5},
+		{"USD", "JPY", -2, 128, -256},
+	}
+
+	for _, tt := range tests {
+		resp, err := f.service.Convert(context.Background(), &pb.ConvertRequest{Amount: tt.amount, From: tt.from, To: tt.to})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if math.Abs(resp.Rate-tt.rate) > 1e-9 || resp.ConvertedAmount != tt.converted || resp.Date != "2026-10-15" {
+			t.Errorf("%s to %s: expected %v at %v, got %v", tt.from, tt.to, tt.converted, tt.rate, resp)
+		}
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	f := setupService()
+
+	// No rates have been fetched yet
+	_, err := f.service.Convert(context.Background(), &pb.ConvertRequest{Amount: 1, From: "EUR", To: "USD"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition before any rates, got %v", err)
+	}
+	f.refresh(t)
+
+	tests := []struct {
+		name string
+		req  *pb.ConvertRequest
+		code codes.Code
+	}{
+		{"invalid from", &pb.ConvertRequest{Amount: 1, From: "EURO", To: "USD"}, codes.InvalidArgument},
+		{"missing to", &pb.ConvertRequest{Amount: 1, From: "EUR"}, codes.InvalidArgument},
+		{"amount out of range", &pb.ConvertRequest{Amount: math.Inf(1), From: "EUR", To: "USD"}, codes.InvalidArgument},
+		{"invalid date", &pb.ConvertRequest{Amount: 1, From: "EUR", To: "USD", Date: "15/10/2026"}, codes.InvalidArgument},
+		{"future date", &pb.ConvertRequest{Amount: 1, From: "EUR", To: "USD", Date: "2026-10-16"}, codes.InvalidArgument},
+		{"no rates that early", &pb.ConvertRequest{Amount: 1, From: "EUR", To: "USD", Date: "2026-10-14"}, codes.NotFound},
+		{"unknown currency", &pb.ConvertRequest{Amount: 1, From: "EUR", To: "CHF"}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.Convert(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestConvert_StaleRates(t *testing.T) {
+	f := setupService()
+	f.refresh(t)
+
+	f.now = f.now.Add(8 * 24 * time.Hour)
+	_, err := f.service.Convert(context.Background(), &pb.ConvertRequest{Amount: 1, From: "EUR", To: "USD"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition with stale rates, got %v", err)
+	}
+
+	// Old rates can still be asked for by date
+	resp, err := f.service.Convert(context.Background(), &pb.ConvertRequest{Amount: 1, From: "EUR", To: "USD", Date: "2026-10-20"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Date != "2026-10-15" {
+		t.Errorf("Expected the rates of the last day before, got %v", resp.Date)
+	}
+}
+
+func TestGetRates(t *testing.T) {
+	f := setupService()
+	f.refresh(t)
+
+	resp, err := f.service.GetRates(context.Background(), &pb.GetRatesRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Base != "EUR" || resp.Date != "2026-10-15" || len(resp.Rates) != 3 || resp.Rates[0].Currency != "GBP" {
+		t.Errorf("Expected every rate against EUR, got %v", resp)
+	}
+
+	resp, err = f.service.GetRates(context.Background(), &pb.GetRatesRequest{Base: "usd", Currencies: []string{"eur", "JPY"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Base != "USD" || len(resp.Rates) != 2 || resp.Rates[0].Rate != 0.8 || resp.Rates[1].Rate != 128 {
+		t.Errorf("Expected EUR and JPY against USD, got %v", resp)
+	}
+
+	if _, err := f.service.GetRates(context.Background(), &pb.GetRatesRequest{Currencies: []string{"CHF"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown currency, got %v", err)
+	}
+}
+
+func TestRefreshRates(t *testing.T) {
+	f := setupService()
+	f.refresh(t)
+
+	// A new day's rates replace the latest ones; the old day stays available
+	f.now = f.now.Add(24 * time.Hour)
+	f.provider.set = &RateSet{Base: "EUR", Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), Rates: map[string]float64{"USD": 1.3}}
+	f.refresh(t)
+
+	resp, err := f.service.Convert(context.Background(), &pb.ConvertRequest{Amount: 10, From: "EUR", To: "USD"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.ConvertedAmount != 13 || resp.Date != "2026-10-16" {
+		t.Errorf("Expected the new rates, got %v", resp)
+	}
+	resp, err = f.service.Convert(context.Background(), &pb.ConvertRequest{Amount: 10, From: "EUR", To: "USD", Date: "2026-10-15"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.ConvertedAmount != 12.5 {
+		t.Errorf("Expected the old day's rates, got %v", resp)
+	}
+
+	f.provider.err = errors.New("connection refused")
+	if _, err := f.service.RefreshRates(context.Background(), &pb.RefreshRatesRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	f := setupService()
+	f.refresh(t)
+
+	// The latest rates are served from memory
+	for i := 0; i < 3; i++ {
+		if _, err := f.service.GetRates(context.Background(), &pb.GetRatesRequest{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if f.repo.reads != 0 {
+		t.Errorf("Expected no repository reads, got %d", f.repo.reads)
+	}
+
+	// A day with its own rates is read once; a later day without rates
+	// every time, as it may still get some
+	for i := 0; i < 2; i++ {
+		for _, date := range []string{"2026-10-15", "2026-10-16"} {
+			f.now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+			if _, err := f.service.GetRates(context.Background(), &pb.GetRatesRequest{Date: date}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+	}
+	if f.repo.reads != 3 {
+		t.Errorf("Expected 3 repository reads, got %d", f.repo.reads)
+	}
+}
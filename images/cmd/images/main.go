@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/images"
+	"github.com/Ujjwaljain16/E-commerce-Backend/images/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("image-service")
+	log.Info(ctx, "Starting Image Service", nil)
+
+	// Get configuration from environment
+	port := getEnv("PORT", "50079")
+	metricsPort := getEnv("METRICS_PORT", "9119")
+	catalogAddr := getEnv("CATALOG_ADDR", "localhost:50052")
+	webpQuality := getEnvInt("WEBP_QUALITY", 80)
+
+	// Renditions are written next to the originals, in the catalog's image bucket
+	store, err := storage.NewS3Client(storage.Config{
+		Endpoint:  getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		Region:    getEnv("S3_REGION", "us-east-1"),
+		Bucket:    getEnv("S3_BUCKET", "product-images"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PathStyle: getEnv("S3_PATH_STYLE", "true") == "true",
+		PublicURL: os.Getenv("S3_PUBLIC_URL"),
+	})
+	if err != nil {
+		log.Error(ctx, "Failed to configure image storage", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// WebP renditions need the cwebp encoder; without it only JPEG and PNG
+	// renditions are made
+	var webp images.WebPEncoder
+	if encoder, err := images.NewCWebP(webpQuality); err == nil {
+		webp = encoder
+	} else {
+		log.Warn(ctx, "WebP renditions are not made (cwebp not found)", nil)
+	}
+
+	// Admin RPCs of the catalog need a SERVICE token signed with JWT_SECRET
+	serviceTokens := auth.ServiceTokensFromEnv("image-service")
+	catalogConn, err := grpc.NewClient(catalogAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()))
+	if err != nil {
+		log.Error(ctx, "Failed to create catalog service client", map[string]interface{}{
+			"error": err.Error(),
+			"addr":  catalogAddr,
+		})
+		os.Exit(1)
+	}
+	defer catalogConn.Close()
+
+	processor := images.NewProcessor(store, catalogpb.NewCatalogServiceClient(catalogConn), images.NewRenderer(images.DefaultSpecs, webp), log)
+	service := images.NewService(processor, log)
+
+	// Process images as the catalog reports them uploaded. Replicas share the
+	// work as one consumer group.
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+	consumerDone := make(chan struct{})
+	if brokers := kafka.ParseBrokers(os.Getenv("KAFKA_BROKERS")); len(brokers) > 0 {
+		group := getEnv("KAFKA_GROUP", "image-service")
+		// Images that cannot be processed go to the catalog events' dead-letter
+		// topic, from which operators requeue them through the dead letter service
+		producer, err := kafka.NewProducer(kafka.Config{Brokers: brokers}, "image-service")
+		if err != nil {
+			log.Error(ctx, "Failed to configure Kafka producer", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer producer.Close()
+		consumer, err := kafka.NewConsumer(kafka.Config{Brokers: brokers}, kafka.ConsumerConfig{
+			Group:       group,
+			Topics:      []string{images.CatalogEventsTopic},
+			DeadLetters: producer,
+		}, "image-service")
+		if err != nil {
+			log.Error(ctx, "Failed to configure Kafka consumer", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer consumer.Close()
+		go func() {
+			defer close(consumerDone)
+			err := consumer.Run(workerCtx, images.EventHandler(processor, images.DefaultRetryPolicy), func(msg kafka.Message, err error) {
+				log.Error(workerCtx, "Failed to process image", map[string]interface{}{
+					"error": err.Error(),
+					"key":   msg.Key,
+				})
+			})
+			if err != nil {
+				log.Error(ctx, "Catalog event consumer stopped", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}()
+		log.Info(ctx, "Processing uploaded images", map[string]interface{}{
+			"topic": images.CatalogEventsTopic,
+			"group": group,
+		})
+	} else {
+		close(consumerDone)
+		log.Warn(ctx, "Uploaded images are not processed (KAFKA_BROKERS not set)", nil)
+	}
+
+	// IP filtering: admin network allowlist and the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, images.AdminMethods)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Create gRPC server with metrics and IP filter interceptors
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("image-service"),
+			ipFilter.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterImageServiceServer(grpcServer, service)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("images.ImageService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Enable reflection for grpcurl/grpcui
+	reflection.Register(grpcServer)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start gRPC server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Error(ctx, "Failed to listen", map[string]interface{}{
+			"error": err.Error(),
+			"port":  port,
+		})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "Image Service listening", map[string]interface{}{
+		"port":         port,
+		"metrics_port": metricsPort,
+		"catalog_addr": catalogAddr,
+		"webp":         webp != nil,
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		stopWorkers()
+		grpcServer.GracefulStop()
+		// Wait for the image in progress; an image interrupted is processed
+		// again, since its event is not committed
+		<-consumerDone
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,237 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CatalogEventsTopic is the Kafka topic the catalog service publishes its events to
+const CatalogEventsTopic = "catalog-events"
+
+// EventImageUploaded is published by the catalog when an uploaded image is
+// attached to a product
+const EventImageUploaded = "product.image_uploaded"
+
+// MaxOriginalSize is the largest original downloaded, matching the catalog's
+// upload limit
+const MaxOriginalSize = 10 << 20
+
+var (
+	// ErrInvalidJob is returned for a job with missing or malformed fields
+	ErrInvalidJob = errors.New("invalid image job")
+	// ErrImageGone is returned when the original or its catalog image was
+	// deleted before it was processed
+	ErrImageGone = errors.New("image no longer exists")
+)
+
+// ObjectStore is the object storage product images are kept in
+type ObjectStore interface {
+	Get(ctx context.Context, key string, maxSize int64) ([]byte, *storage.ObjectInfo, error)
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	URL(key string) string
+}
+
+// Catalog is the part of the catalog service renditions are stored through
+type Catalog interface {
+	SetImageRenditions(ctx context.Context, in *catalogpb.SetImageRenditionsRequest, opts ...grpc.CallOption) (*catalogpb.SetImageRenditionsResponse, error)
+}
+
+// Job identifies an image to process
+type Job struct {
+	ProductID string `json:"product_id"`
+	ImageID   string `json:"image_id"`
+	ObjectKey string `json:"object_key"`
+}
+
+// Validate reports whether a job can be processed. The original must be one
+// of the product's uploads, so a job cannot read other objects.
+func (j Job) Validate() error {
+	if j.ProductID == "" || strings.Contains(j.ProductID, "/") {
+		return fmt.Errorf("%w: invalid product_id", ErrInvalidJob)
+	}
+	if _, err := uuid.Parse(j.ImageID); err != nil {
+		return fmt.Errorf("%w: invalid image_id", ErrInvalidJob)
+	}
+	prefix := "products/" + j.ProductID + "/"
+	if !strings.HasPrefix(j.ObjectKey, prefix) || strings.HasPrefix(j.ObjectKey, prefix+"renditions/") {
+		return fmt.Errorf("%w: object_key is not an upload of the product", ErrInvalidJob)
+	}
+	return nil
+}
+
+// StoredRendition is a rendition written to object storage
+type StoredRendition struct {
+	Rendition
+	URL string
+}
+
+// Processor makes the renditions of uploaded images, writes them to object
+// storage and stores their URLs on the catalog image
+type Processor struct {
+	store    ObjectStore
+	catalog  Catalog
+	renderer *Renderer
+	log      *logger.Logger
+}
+
+// NewProcessor creates a new processor
+func NewProcessor(store ObjectStore, catalog Catalog, renderer *Renderer, log *logger.Logger) *Processor {
+	return &Processor{
+		store:    store,
+		catalog:  catalog,
+		renderer: renderer,
+		log:      log,
+	}
+}
+
+// renditionKey is the object key of a rendition. Keys are stable, so
+// processing an image again overwrites its renditions.
+func renditionKey(job Job, r Rendition) string {
+	return "products/" + job.ProductID + "/renditions/" + job.ImageID + "/" + r.Name + r.Extension()
+}
+
+// Process makes and stores the renditions of an image
+func (p *Processor) Process(ctx context.Context, job Job) ([]StoredRendition, error) {
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+
+	original, _, err := p.store.Get(ctx, job.ObjectKey, MaxOriginalSize)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, ErrImageGone
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download original: %w", err)
+	}
+
+	renditions, err := p.renderer.Render(ctx, original)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make([]StoredRendition, len(renditions))
+	req := &catalogpb.SetImageRenditionsRequest{
+		ProductId:  job.ProductID,
+		ImageId:    job.ImageID,
+		Renditions: make([]*catalogpb.ImageRendition, len(renditions)),
+	}
+	for i, r := range renditions {
+		key := renditionKey(job, r)
+		if err := p.store.Put(ctx, key, r.ContentType(), r.Data); err != nil {
+			return nil, fmt.Errorf("failed to upload %s rendition: %w", r.Name, err)
+		}
+		stored[i] = StoredRendition{Rendition: r, URL: p.store.URL(key)}
+		req.Renditions[i] = &catalogpb.ImageRendition{
+			Name:      r.Name,
+			Format:    r.Format,
+			Url:       stored[i].URL,
+			Width:     int32(r.Width),
+			Height:    int32(r.Height),
+			SizeBytes: int64(len(r.Data)),
+		}
+	}
+
+	if _, err := p.catalog.SetImageRenditions(ctx, req); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrImageGone
+		}
+		return nil, fmt.Errorf("failed to store renditions in catalog: %w", err)
+	}
+
+	p.log.Info(ctx, "Image renditions made", map[string]interface{}{
+		"product_id": job.ProductID,
+		"image_id":   job.ImageID,
+		"renditions": len(stored),
+	})
+	return stored, nil
+}
+
+// permanent reports whether processing a job again cannot succeed
+func permanent(err error) bool {
+	return errors.Is(err, ErrInvalidJob) ||
+		errors.Is(err, ErrImageGone) ||
+		errors.Is(err, ErrUnsupportedImage) ||
+		errors.Is(err, ErrImageTooLarge) ||
+		errors.Is(err, storage.ErrObjectTooLarge)
+}
+
+// outboxEnvelope is the JSON envelope services publish their outbox events in
+type outboxEnvelope struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// RetryPolicy is how often, and how far apart, a job that fails for a
+// transient reason such as an unavailable catalog is tried
+type RetryPolicy struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+// DefaultRetryPolicy tries a job three times, a second and then two apart
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, Backoff: time.Second}
+
+// EventHandler processes the images of product.image_uploaded events
+// consumed from Kafka. Other catalog events are ignored. Images deleted
+// before they are processed are skipped; other jobs that cannot succeed are
+// returned as errors without being retried.
+func EventHandler(p *Processor, retry RetryPolicy) kafka.Handler {
+	return func(ctx context.Context, msg kafka.Message) error {
+		var envelope outboxEnvelope
+		if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+			return fmt.Errorf("failed to decode catalog event: %w", err)
+		}
+		if envelope.Type != EventImageUploaded {
+			return nil
+		}
+
+		var job Job
+		if err := json.Unmarshal(envelope.Data, &job); err != nil {
+			return fmt.Errorf("failed to decode image event data: %w", err)
+		}
+
+		backoff := retry.Backoff
+		for attempt := 1; ; attempt++ {
+			_, err := p.Process(ctx, job)
+			if err == nil {
+				return nil
+			}
+			if errors.Is(err, ErrImageGone) {
+				p.log.Info(ctx, "Skipped deleted image", map[string]interface{}{
+					"product_id": job.ProductID,
+					"image_id":   job.ImageID,
+				})
+				return nil
+			}
+			if permanent(err) || attempt >= retry.Attempts {
+				return fmt.Errorf("image %s of product %s: %w", job.ImageID, job.ProductID, err)
+			}
+
+			p.log.Warn(ctx, "Image processing failed, retrying", map[string]interface{}{
+				"error":    err.Error(),
+				"image_id": job.ImageID,
+				"attempt":  attempt,
+			})
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testImageID = "11111111-1111-1111-1111-111111111111"
+
+// mockStore keeps objects in memory
+type mockStore struct {
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (m *mockStore) Get(ctx context.Context, key string, maxSize int64) ([]byte, *storage.ObjectInfo, error) {
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, nil, storage.ErrObjectNotFound
+	}
+	if int64(len(data)) > maxSize {
+		return nil, nil, storage.ErrObjectTooLarge
+	}
+	return data, &storage.ObjectInfo{Key: key, Size: int64(len(data))}, nil
+}
+
+func (m *mockStore) Put(ctx context.Context, key, contentType string, body []byte) error {
+	m.objects[key] = body
+	m.types[key] = contentType
+	return nil
+}
+
+func (m *mockStore) URL(key string) string {
+	return "https://cdn.test/" + key
+}
+
+// mockCatalog records the renditions stored
+type mockCatalog struct {
+	calls int
+	errs  []error
+	req   *catalogpb.SetImageRenditionsRequest
+}
+
+func (m *mockCatalog) SetImageRenditions(ctx context.Context, in *catalogpb.SetImageRenditionsRequest, opts ...grpc.CallOption) (*catalogpb.SetImageRenditionsResponse, error) {
+	m.calls++
+	if len(m.errs) > 0 {
+		err := m.errs[0]
+		m.errs = m.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	m.req = in
+	return &catalogpb.SetImageRenditionsResponse{}, nil
+}
+
+func setupProcessor(t *testing.T) (*Processor, *mockStore, *mockCatalog) {
+	t.Helper()
+	store := newMockStore()
+	store.objects["products/p1/upload.png"] = encodePNG(t, opaqueImage(600, 300))
+	catalog := &mockCatalog{}
+	renderer := NewRenderer([]Spec{{Name: "thumbnail", MaxSize: 160}, {Name: "small", MaxSize: 480}}, &fakeWebP{})
+	return NewProcessor(store, catalog, renderer, logger.New("image-test")), store, catalog
+}
+
+func TestProcess(t *testing.T) {
+	processor, store, catalog := setupProcessor(t)
+
+	renditions, err := processor.Process(context.Background(), Job{ProductID: "p1", ImageID: testImageID, ObjectKey: "products/p1/upload.png"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(renditions) != 4 {
+		t.Fatalf("Expected 4 renditions, got %d", len(renditions))
+	}
+
+	key := "products/p1/renditions/" + testImageID + "/thumbnail.webp"
+	if store.types[key] != "image/webp" {
+		t.Errorf("Expected WebP thumbnail stored at %s, got %v", key, store.types)
+	}
+
+	if catalog.req == nil || catalog.req.ImageId != testImageID || len(catalog.req.Renditions) != 4 {
+		t.Fatalf("Expected renditions stored in catalog, got %+v", catalog.req)
+	}
+
+	small := catalog.req.Renditions[2]
+	if small.Name != "small" || small.Format != FormatJPEG || small.Url != "https://cdn.test/products/p1/renditions/"+testImageID+"/small.jpg" || small.Width != 480 || small.Height != 240 || small.SizeBytes == 0 {
+		t.Errorf("Unexpected small rendition %+v", small)
+	}
+}
+
+func TestProcess_InvalidJob(t *testing.T) {
+	processor, _, _ := setupProcessor(t)
+
+	jobs := []Job{
+		{ProductID: "p1", ImageID: "not-a-uuid", ObjectKey: "products/p1/upload.png"},
+		{ProductID: "p1", ImageID: testImageID, ObjectKey: "products/p2/upload.png"},
+		{ProductID: "p1", ImageID: testImageID, ObjectKey: "products/p1/renditions/x/small.jpg"},
+		{ProductID: "", ImageID: testImageID, ObjectKey: "products//upload.png"},
+	}
+	for _, job := range jobs {
+		if _, err := processor.Process(context.Background(), job); !errors.Is(err, ErrInvalidJob) {
+			t.Errorf("Expected ErrInvalidJob for %+v, got %v", job, err)
+		}
+	}
+}
+
+func TestProcess_Gone(t *testing.T) {
+	processor, _, catalog := setupProcessor(t)
+
+	if _, err := processor.Process(context.Background(), Job{ProductID: "p1", ImageID: testImageID, ObjectKey: "products/p1/deleted.png"}); !errors.Is(err, ErrImageGone) {
+		t.Errorf("Expected ErrImageGone for a deleted original, got %v", err)
+	}
+
+	catalog.errs = []error{status.Error(codes.NotFound, "image not found")}
+	if _, err := processor.Process(context.Background(), Job{ProductID: "p1", ImageID: testImageID, ObjectKey: "products/p1/upload.png"}); !errors.Is(err, ErrImageGone) {
+		t.Errorf("Expected ErrImageGone for a deleted catalog image, got %v", err)
+	}
+}
+
+func uploadedEvent(objectKey string) kafka.Message {
+	return kafka.Message{
+		Topic: CatalogEventsTopic,
+		Key:   "p1",
+		Value: []byte(`{"id":"event-1","type":"product.image_uploaded","data":{"product_id":"p1","image_id":"` + testImageID + `","object_key":"` + objectKey + `","url":"https://cdn.test/x"}}`),
+	}
+}
+
+func TestEventHandler_RetriesTransientFailures(t *testing.T) {
+	processor, _, catalog := setupProcessor(t)
+	catalog.errs = []error{status.Error(codes.Unavailable, "connection refused"), nil}
+	handle := EventHandler(processor, RetryPolicy{Attempts: 3, Backoff: time.Millisecond})
+
+	if err := handle(context.Background(), uploadedEvent("products/p1/upload.png")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if catalog.calls != 2 || catalog.req == nil {
+		t.Errorf("Expected the job retried once, got %d calls", catalog.calls)
+	}
+}
+
+func TestEventHandler_GivesUp(t *testing.T) {
+	processor, _, catalog := setupProcessor(t)
+	catalog.errs = []error{
+		status.Error(codes.Unavailable, "connection refused"),
+		status.Error(codes.Unavailable, "connection refused"),
+		status.Error(codes.Unavailable, "connection refused"),
+	}
+	handle := EventHandler(processor, RetryPolicy{Attempts: 2, Backoff: time.Millisecond})
+
+	if err := handle(context.Background(), uploadedEvent("products/p1/upload.png")); err == nil || !strings.Contains(err.Error(), testImageID) {
+		t.Errorf("Expected an error naming the image, got %v", err)
+	}
+
+	if catalog.calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", catalog.calls)
+	}
+}
+
+func TestEventHandler_SkipsDeletedAndOtherEvents(t *testing.T) {
+	processor, _, catalog := setupProcessor(t)
+	handle := EventHandler(processor, RetryPolicy{Attempts: 3, Backoff: time.Millisecond})
+
+	if err := handle(context.Background(), uploadedEvent("products/p1/deleted.png")); err != nil {
+		t.Errorf("Expected deleted images skipped, got %v", err)
+	}
+
+	if err := handle(context.Background(), kafka.Message{Value: []byte(`{"id":"event-2","type":"product.updated","data":{}}`)}); err != nil {
+		t.Errorf("Expected other events ignored, got %v", err)
+	}
+
+	if err := handle(context.Background(), kafka.Message{Value: []byte(`not json`)}); err == nil {
+		t.Error("Expected an error for a malformed event")
+	}
+
+	if catalog.calls != 0 {
+		t.Errorf("Expected no renditions stored, got %d calls", catalog.calls)
+	}
+}
@@ -0,0 +1,246 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register GIF decoder
+	"image/jpeg"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register WebP decoder
+)
+
+// Rendition formats
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+var (
+	// ErrUnsupportedImage is returned when the original cannot be decoded
+	ErrUnsupportedImage = errors.New("unsupported image")
+	// ErrImageTooLarge is returned when the original has more pixels than
+	// are decoded, which guards against decompression bombs
+	ErrImageTooLarge = errors.New("image has too many pixels")
+)
+
+// DefaultMaxPixels is the largest original decoded, in pixels
+const DefaultMaxPixels = 50_000_000
+
+// jpegQuality is the quality JPEG renditions are encoded at
+const jpegQuality = 85
+
+// Spec describes a rendition: the image scaled to fit within MaxSize
+// pixels on its longest side. Images are never scaled up.
+type Spec struct {
+	Name    string
+	MaxSize int
+}
+
+// DefaultSpecs are the renditions made of every image
+var DefaultSpecs = []Spec{
+	{Name: "thumbnail", MaxSize: 160},
+	{Name: "small", MaxSize: 480},
+	{Name: "large", MaxSize: 1200},
+}
+
+// Rendition is an encoded rendition of an image
+type Rendition struct {
+	Name   string
+	Format string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// ContentType is the MIME type of the rendition
+func (r Rendition) ContentType() string {
+	return "image/" + r.Format
+}
+
+// Extension is the object key extension of the rendition
+func (r Rendition) Extension() string {
+	if r.Format == FormatJPEG {
+		return ".jpg"
+	}
+	return "." + r.Format
+}
+
+// WebPEncoder encodes images as WebP
+type WebPEncoder interface {
+	EncodeWebP(ctx context.Context, img image.Image) ([]byte, error)
+}
+
+// Renderer makes the renditions of images. Each spec is encoded as JPEG, or
+// as PNG when the image has transparency, and as WebP when a WebP encoder is
+// configured.
+type Renderer struct {
+	specs     []Spec
+	webp      WebPEncoder
+	maxPixels int
+}
+
+// NewRenderer creates a renderer for specs; webp may be nil to skip WebP
+func NewRenderer(specs []Spec, webp WebPEncoder) *Renderer {
+	return &Renderer{
+		specs:     specs,
+		webp:      webp,
+		maxPixels: DefaultMaxPixels,
+	}
+}
+
+// Render decodes an original image and encodes its renditions
+func (r *Renderer) Render(ctx context.Context, data []byte) ([]Rendition, error) {
+	// Check the dimensions before decoding, since a small file can declare
+	// an image too large to hold in memory
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: empty image", ErrUnsupportedImage)
+	}
+	if cfg.Width*cfg.Height > r.maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	transparent := !isOpaque(src)
+
+	renditions := make([]Rendition, 0, len(r.specs)*2)
+	for _, spec := range r.specs {
+		scaled := scale(src, spec.MaxSize, transparent)
+		bounds := scaled.Bounds()
+
+		format := FormatJPEG
+		var buf bytes.Buffer
+		if transparent {
+			format = FormatPNG
+			err = png.Encode(&buf, scaled)
+		} else {
+			err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: jpegQuality})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s rendition: %w", spec.Name, err)
+		}
+		renditions = append(renditions, Rendition{
+			Name:   spec.Name,
+			Format: format,
+			Width:  bounds.Dx(),
+			Height: bounds.Dy(),
+			Data:   buf.Bytes(),
+		})
+
+		if r.webp == nil {
+			continue
+		}
+		webp, err := r.webp.EncodeWebP(ctx, scaled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s WebP rendition: %w", spec.Name, err)
+		}
+		renditions = append(renditions, Rendition{
+			Name:   spec.Name,
+			Format: FormatWebP,
+			Width:  bounds.Dx(),
+			Height: bounds.Dy(),
+			Data:   webp,
+		})
+	}
+	return renditions, nil
+}
+
+// fit returns the size of a width x height image scaled to fit within
+// maxSize on its longest side, never scaling it up
+func fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(1, height*maxSize/width)
+	}
+	return max(1, width*maxSize/height), maxSize
+}
+
+// scale resizes src to fit within maxSize
+func scale(src image.Image, maxSize int, transparent bool) image.Image {
+	bounds := src.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), maxSize)
+	rect := image.Rect(0, 0, width, height)
+
+	var dst draw.Image
+	if transparent {
+		dst = image.NewNRGBA(rect)
+	} else {
+		dst = image.NewRGBA(rect)
+	}
+	draw.CatmullRom.Scale(dst, rect, src, bounds, draw.Src, nil)
+	return dst
+}
+
+// isOpaque reports whether an image has no transparent pixels. Images that
+// cannot tell are treated as transparent, so transparency is never lost.
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// CWebP encodes WebP images with the cwebp command-line encoder, since the
+// standard library and golang.org/x/image only decode WebP
+type CWebP struct {
+	path    string
+	quality int
+}
+
+// NewCWebP finds cwebp on the PATH. It returns an error when it is not
+// installed.
+func NewCWebP(quality int) (*CWebP, error) {
+	path, err := exec.LookPath("cwebp")
+	if err != nil {
+		return nil, err
+	}
+	return &CWebP{path: path, quality: quality}, nil
+}
+
+// EncodeWebP encodes img as WebP
+func (c *CWebP) EncodeWebP(ctx context.Context, img image.Image) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "webp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// cwebp reads PNG losslessly, alpha included
+	in := filepath.Join(dir, "in.png")
+	out := filepath.Join(dir, "out.webp")
+	f, err := os.Create(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, c.path, "-quiet", "-q", strconv.Itoa(c.quality), in, "-o", out)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("cwebp: %w: %s", err, bytes.TrimSpace(output))
+	}
+	return os.ReadFile(out)
+}
@@ -0,0 +1,126 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// fakeWebP records the images it encodes
+type fakeWebP struct {
+	encoded []image.Rectangle
+}
+
+func (f *fakeWebP) EncodeWebP(ctx context.Context, img image.Image) ([]byte, error) {
+	f.encoded = append(f.encoded, img.Bounds())
+	return []byte("RIFF....WEBP"), nil
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func opaqueImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func TestRender_Opaque(t *testing.T) {
+	webp := &fakeWebP{}
+	renderer := NewRenderer([]Spec{{Name: "thumbnail", MaxSize: 160}, {Name: "large", MaxSize: 1200}}, webp)
+
+	renditions, err := renderer.Render(context.Background(), encodePNG(t, opaqueImage(800, 400)))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(renditions) != 4 {
+		t.Fatalf("Expected JPEG and WebP of each spec, got %d renditions", len(renditions))
+	}
+
+	thumb := renditions[0]
+	if thumb.Name != "thumbnail" || thumb.Format != FormatJPEG || thumb.Width != 160 || thumb.Height != 80 {
+		t.Errorf("Expected a 160x80 JPEG thumbnail, got %s %s %dx%d", thumb.Name, thumb.Format, thumb.Width, thumb.Height)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(thumb.Data))
+	if err != nil || cfg.Width != 160 || cfg.Height != 80 {
+		t.Errorf("Expected decodable 160x80 JPEG, got %+v, %v", cfg, err)
+	}
+
+	// The original is smaller than the large spec and is not scaled up
+	large := renditions[2]
+	if large.Width != 800 || large.Height != 400 {
+		t.Errorf("Expected large rendition at original size, got %dx%d", large.Width, large.Height)
+	}
+
+	if renditions[1].Format != FormatWebP || renditions[1].Width != 160 || len(webp.encoded) != 2 {
+		t.Errorf("Expected WebP renditions, got %+v", renditions[1])
+	}
+}
+
+func TestRender_TransparentAsPNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 300, 600))
+	img.Set(10, 10, color.NRGBA{R: 255, A: 255})
+	renderer := NewRenderer([]Spec{{Name: "small", MaxSize: 300}}, nil)
+
+	renditions, err := renderer.Render(context.Background(), encodePNG(t, img))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(renditions) != 1 || renditions[0].Format != FormatPNG || renditions[0].ContentType() != "image/png" {
+		t.Fatalf("Expected one PNG rendition without a WebP encoder, got %+v", renditions)
+	}
+
+	if renditions[0].Width != 150 || renditions[0].Height != 300 {
+		t.Errorf("Expected 150x300, got %dx%d", renditions[0].Width, renditions[0].Height)
+	}
+}
+
+func TestRender_Rejects(t *testing.T) {
+	renderer := NewRenderer(DefaultSpecs, nil)
+	renderer.maxPixels = 100 * 100
+
+	if _, err := renderer.Render(context.Background(), []byte("not an image")); !errors.Is(err, ErrUnsupportedImage) {
+		t.Errorf("Expected ErrUnsupportedImage, got %v", err)
+	}
+
+	if _, err := renderer.Render(context.Background(), encodePNG(t, opaqueImage(200, 100))); !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("Expected ErrImageTooLarge, got %v", err)
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		width, height, maxSize int
+		wantW, wantH           int
+	}{
+		{2000, 1000, 480, 480, 240},
+		{1000, 2000, 480, 240, 480},
+		{300, 200, 480, 300, 200},
+		{5000, 1, 160, 160, 1},
+	}
+	for _, tt := range tests {
+		w, h := fit(tt.width, tt.height, tt.maxSize)
+		if w != tt.wantW || h != tt.wantH {
+			t.Errorf("fit(%d, %d, %d) = %dx%d, want %dx%d", tt.width, tt.height, tt.maxSize, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
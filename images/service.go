@@ -0,0 +1,69 @@
+package images
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/images/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminMethods are the admin-scoped RPCs of the image service; they are
+// restricted to the admin network allowlist. Images are processed from
+// catalog events and the service only serves the backoffice, so that is
+// every RPC.
+var AdminMethods = []string{
+	"/" + pb.ImageService_ServiceDesc.ServiceName + "/",
+}
+
+// Service implements the ImageService gRPC interface
+type Service struct {
+	pb.UnimplementedImageServiceServer
+	processor *Processor
+	log       *logger.Logger
+}
+
+// NewService creates a new image service
+func NewService(processor *Processor, log *logger.Logger) *Service {
+	return &Service{
+		processor: processor,
+		log:       log,
+	}
+}
+
+// ProcessImage makes the renditions of an image again
+func (s *Service) ProcessImage(ctx context.Context, req *pb.ProcessImageRequest) (*pb.ProcessImageResponse, error) {
+	job := Job{ProductID: req.ProductId, ImageID: req.ImageId, ObjectKey: req.ObjectKey}
+
+	renditions, err := s.processor.Process(ctx, job)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrInvalidJob):
+		s.log.Warn(ctx, "Process image failed: "+err.Error(), map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrImageGone):
+		return nil, status.Error(codes.NotFound, "image not found")
+	case errors.Is(err, ErrUnsupportedImage), errors.Is(err, ErrImageTooLarge), errors.Is(err, storage.ErrObjectTooLarge):
+		s.log.Warn(ctx, "Process image failed: "+err.Error(), map[string]interface{}{"image_id": req.ImageId})
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		s.log.Error(ctx, "Failed to process image", map[string]interface{}{"error": err.Error(), "image_id": req.ImageId})
+		return nil, status.Error(codes.Internal, "failed to process image")
+	}
+
+	resp := &pb.ProcessImageResponse{Renditions: make([]*pb.Rendition, len(renditions))}
+	for i, r := range renditions {
+		resp.Renditions[i] = &pb.Rendition{
+			Name:      r.Name,
+			Format:    r.Format,
+			Url:       r.URL,
+			Width:     int32(r.Width),
+			Height:    int32(r.Height),
+			SizeBytes: int64(len(r.Data)),
+		}
+	}
+	return resp, nil
+}
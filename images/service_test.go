@@ -0,0 +1,52 @@
+package images
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/images/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProcessImage(t *testing.T) {
+	processor, _, _ := setupProcessor(t)
+	service := NewService(processor, logger.New("image-test"))
+
+	resp, err := service.ProcessImage(context.Background(), &pb.ProcessImageRequest{ProductId: "p1", ImageId: testImageID, ObjectKey: "products/p1/upload.png"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Renditions) != 4 || resp.Renditions[0].Name != "thumbnail" || resp.Renditions[0].Url == "" {
+		t.Errorf("Unexpected renditions %v", resp.Renditions)
+	}
+}
+
+func TestProcessImage_Errors(t *testing.T) {
+	processor, store, _ := setupProcessor(t)
+	store.objects["products/p1/notes.png"] = []byte("not an image")
+	service := NewService(processor, logger.New("image-test"))
+
+	tests := []struct {
+		name string
+		req  *pb.ProcessImageRequest
+		want codes.Code
+	}{
+		{"invalid", &pb.ProcessImageRequest{ProductId: "p1", ImageId: testImageID, ObjectKey: "products/p2/upload.png"}, codes.InvalidArgument},
+		{"deleted", &pb.ProcessImageRequest{ProductId: "p1", ImageId: testImageID, ObjectKey: "products/p1/deleted.png"}, codes.NotFound},
+		{"not an image", &pb.ProcessImageRequest{ProductId: "p1", ImageId: testImageID, ObjectKey: "products/p1/notes.png"}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ProcessImage(context.Background(), tt.req)
+
+			st, ok := status.FromError(err)
+			if !ok || st.Code() != tt.want {
+				t.Errorf("Expected %s error, got %v", tt.want, err)
+			}
+		})
+	}
+}
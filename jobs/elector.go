@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// DefaultLockKey names the advisory lock the jobs service instances elect a
+// leader with
+const DefaultLockKey = "jobs-scheduler"
+
+// Elector decides which instance of the jobs service fires scheduled runs
+type Elector interface {
+	// Leader reports whether this instance leads, trying to take the lead
+	// when it does not
+	Leader(ctx context.Context) (bool, error)
+	// Resign gives up the lead
+	Resign(ctx context.Context)
+}
+
+// PostgresElector elects the instance holding a session-level Postgres
+// advisory lock. The lock is held on a dedicated connection and goes with
+// it, so an instance that dies or loses its database connection hands the
+// lead to the next instance to ask.
+type PostgresElector struct {
+	db  *sql.DB
+	key string
+	// conn holds the lock while this instance leads
+	conn *sql.Conn
+}
+
+// NewPostgresElector creates an elector contending for the advisory lock
+// named key
+func NewPostgresElector(db *sql.DB, key string) *PostgresElector {
+	return &PostgresElector{db: db, key: key}
+}
+
+// Leader checks that the connection holding the lock is alive, or tries to
+// take the lock on a new connection. It is not safe for concurrent use.
+func (e *PostgresElector) Leader(ctx context.Context) (bool, error) {
+	if e.conn != nil {
+		if err := e.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		e.drop()
+	}
+
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get connection: %w", err)
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", e.key).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, fmt.Errorf("failed to try scheduler lock: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+	e.conn = conn
+	return true, nil
+}
+
+// Resign releases the lock
+func (e *PostgresElector) Resign(ctx context.Context) {
+	if e.conn == nil {
+		return
+	}
+	if _, err := e.conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", e.key); err != nil {
+		e.drop()
+		return
+	}
+	e.conn.Close()
+	e.conn = nil
+}
+
+// drop discards the lock's connection rather than returning it to the pool,
+// where it would go on holding the lock if its session were still alive
+func (e *PostgresElector) drop() {
+	e.conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	e.conn.Close()
+	e.conn = nil
+}
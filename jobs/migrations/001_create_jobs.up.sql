@@ -0,0 +1,28 @@
+-- The configured jobs and when each is next due. The instance holding the
+-- scheduler lease moves next_run_at forward as it claims each run, so a run
+-- is fired once however many instances see it due.
+CREATE TABLE IF NOT EXISTS jobs (
+    name VARCHAR(100) PRIMARY KEY,
+    schedule VARCHAR(100) NOT NULL,
+    next_run_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Job run history. At most one run of a job is RUNNING at a time; a run left
+-- RUNNING by an instance that died is marked FAILED by the next run.
+CREATE TABLE IF NOT EXISTS job_runs (
+    id UUID PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('SCHEDULED', 'MANUAL')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    output TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    instance VARCHAR(255) NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    CHECK ((status = 'RUNNING') = (finished_at IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_job_runs_running ON job_runs(job_name) WHERE status = 'RUNNING';
+CREATE INDEX idx_job_runs_job_started_at ON job_runs(job_name, started_at DESC);
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at DESC);
@@ -0,0 +1,308 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Run statuses
+const (
+	RunRunning   = "RUNNING"
+	RunCompleted = "COMPLETED"
+	RunFailed    = "FAILED"
+)
+
+// Run triggers
+const (
+	TriggerScheduled = "SCHEDULED"
+	TriggerManual    = "MANUAL"
+)
+
+// runningConstraint is the partial unique index allowing one RUNNING run
+const runningConstraint = "idx_job_runs_running"
+
+var (
+	// ErrRunNotFound is returned when a job run does not exist
+	ErrRunNotFound = errors.New("job run not found")
+	// ErrRunInProgress is returned when a run of a job is started while
+	// another run of it is running
+	ErrRunInProgress = errors.New("job run in progress")
+)
+
+// Run is one execution of a job
+type Run struct {
+	ID      string
+	JobName string
+	Trigger string
+	Status  string
+	// Output is what the job reported, such as how many carts it expired
+	Output string
+	// Error is why a FAILED run failed
+	Error string
+	// Instance is the jobs service instance that ran the job
+	Instance   string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// JobState is the stored schedule of a job
+type JobState struct {
+	Name      string
+	Schedule  string
+	NextRunAt time.Time
+}
+
+// Repository handles job schedule and run persistence
+type Repository interface {
+	// SaveJob records a configured job. A new job, or one whose schedule
+	// changed, is next due at nextRunAt; otherwise its stored next run
+	// stands.
+	SaveJob(ctx context.Context, name, schedule string, nextRunAt time.Time) error
+	// ListJobs returns the stored jobs
+	ListJobs(ctx context.Context) ([]*JobState, error)
+	// ClaimRun moves a job's next run from due to next. It reports false
+	// when the job's next run is no longer due, because another instance
+	// claimed it.
+	ClaimRun(ctx context.Context, name string, due, next time.Time) (bool, error)
+	// StartRun records run as RUNNING, assigning its ID. A run of the job
+	// still RUNNING since before staleBefore is taken to have died and is
+	// marked FAILED; any other running run of the job makes it return
+	// ErrRunInProgress.
+	StartRun(ctx context.Context, run *Run, staleBefore time.Time) error
+	// FinishRun records the outcome of run
+	FinishRun(ctx context.Context, run *Run) error
+	// GetRun returns a run, or ErrRunNotFound
+	GetRun(ctx context.Context, id string) (*Run, error)
+	// ListRuns lists runs, most recent first, of one job or of all jobs
+	// when jobName is empty
+	ListRuns(ctx context.Context, jobName string, page, pageSize int32) ([]*Run, int32, error)
+	// LastRuns returns the most recent run of each job that has run
+	LastRuns(ctx context.Context) (map[string]*Run, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL job repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const runColumns = `id, job_name, trigger, status, output, error, instance, started_at, finished_at`
+
+// SaveJob upserts the job, keeping its next run unless the schedule changed
+func (r *postgresRepository) SaveJob(ctx context.Context, name, schedule string, nextRunAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO jobs (name, schedule, next_run_at, updated_at) VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (name) DO UPDATE SET schedule = EXCLUDED.schedule, next_run_at = EXCLUDED.next_run_at, updated_at = NOW()
+		WHERE jobs.schedule <> EXCLUDED.schedule
+	`, name, schedule, nextRunAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to save job", map[string]interface{}{"error": err.Error(), "job": name})
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	return nil
+}
+
+// ListJobs retrieves the stored jobs by name
+func (r *postgresRepository) ListJobs(ctx context.Context) ([]*JobState, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT name, schedule, next_run_at FROM jobs ORDER BY name")
+	if err != nil {
+		r.log.Error(ctx, "Failed to list jobs", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*JobState
+	for rows.Next() {
+		job := &JobState{}
+		if err := rows.Scan(&job.Name, &job.Schedule, &job.NextRunAt); err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ClaimRun compares and swaps next_run_at, so only one instance claims a run
+func (r *postgresRepository) ClaimRun(ctx context.Context, name string, due, next time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE jobs SET next_run_at = $3, updated_at = NOW() WHERE name = $1 AND next_run_at = $2
+	`, name, due, next)
+	if err != nil {
+		r.log.Error(ctx, "Failed to claim job run", map[string]interface{}{"error": err.Error(), "job": name})
+		return false, fmt.Errorf("failed to claim job run: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job run: %w", err)
+	}
+	return n == 1, nil
+}
+
+// StartRun inserts the run; the unique index on running runs keeps a second
+// run of the job from starting
+func (r *postgresRepository) StartRun(ctx context.Context, run *Run, staleBefore time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE job_runs SET status = 'FAILED', error = 'run was interrupted', finished_at = $3
+		WHERE job_name = $1 AND status = 'RUNNING' AND started_at < $2
+	`, run.JobName, staleBefore, run.StartedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to fail stale job runs", map[string]interface{}{"error": err.Error(), "job": run.JobName})
+		return fmt.Errorf("failed to fail stale job runs: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		r.log.Warn(ctx, "Stale job run marked failed", map[string]interface{}{"job": run.JobName})
+	}
+
+	run.ID = uuid.New().String()
+	run.Status = RunRunning
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO job_runs (id, job_name, trigger, status, instance, started_at) VALUES ($1, $2, $3, $4, $5, $6)
+	`, run.ID, run.JobName, run.Trigger, run.Status, run.Instance, run.StartedAt)
+	if pgerr.IsUniqueViolation(err, runningConstraint) {
+		return ErrRunInProgress
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to start job run", map[string]interface{}{"error": err.Error(), "job": run.JobName})
+		return fmt.Errorf("failed to start job run: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit job run", map[string]interface{}{"error": err.Error(), "job": run.JobName})
+		return fmt.Errorf("failed to commit job run: %w", err)
+	}
+	return nil
+}
+
+// FinishRun updates the run's outcome
+func (r *postgresRepository) FinishRun(ctx context.Context, run *Run) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE job_runs SET status = $2, output = $3, error = $4, finished_at = $5 WHERE id = $1
+	`, run.ID, run.Status, run.Output, run.Error, run.FinishedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to finish job run", map[string]interface{}{"error": err.Error(), "run_id": run.ID})
+		return fmt.Errorf("failed to finish job run: %w", err)
+	}
+	return nil
+}
+
+// GetRun retrieves a run by ID
+func (r *postgresRepository) GetRun(ctx context.Context, id string) (*Run, error) {
+	run, err := scanRun(r.db.QueryRowContext(ctx, "SELECT "+runColumns+" FROM job_runs WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrRunNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get job run", map[string]interface{}{"error": err.Error(), "run_id": id})
+		return nil, fmt.Errorf("failed to get job run: %w", err)
+	}
+	return run, nil
+}
+
+// ListRuns lists runs by start time, newest first
+func (r *postgresRepository) ListRuns(ctx context.Context, jobName string, page, pageSize int32) ([]*Run, int32, error) {
+	where := " FROM job_runs WHERE ($1 = '' OR job_name = $1)"
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+where, jobName).Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count job runs", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count job runs: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+runColumns+where+" ORDER BY started_at DESC, id LIMIT $2 OFFSET $3",
+		jobName, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list job runs", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs, err := scanRuns(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// LastRuns picks each job's newest run
+func (r *postgresRepository) LastRuns(ctx context.Context) (map[string]*Run, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT DISTINCT ON (job_name) "+runColumns+" FROM job_runs ORDER BY job_name, started_at DESC, id")
+	if err != nil {
+		r.log.Error(ctx, "Failed to get last job runs", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get last job runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs, err := scanRuns(rows)
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[string]*Run, len(runs))
+	for _, run := range runs {
+		last[run.JobName] = run
+	}
+	return last, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRun scans a run selected with runColumns
+func scanRun(row rowScanner) (*Run, error) {
+	run := &Run{}
+	var finishedAt sql.NullTime
+	err := row.Scan(&run.ID, &run.JobName, &run.Trigger, &run.Status, &run.Output, &run.Error, &run.Instance,
+		&run.StartedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+	return run, nil
+}
+
+func scanRuns(rows *sql.Rows) ([]*Run, error) {
+	var runs []*Run
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,244 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("jobs-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var runColumnNames = []string{"id", "job_name", "trigger", "status", "output", "error", "instance", "started_at", "finished_at"}
+
+func TestSaveJob(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	next := time.Date(2026, time.March, 5, 3, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`INSERT INTO jobs \(name, schedule, next_run_at, updated_at\).*ON CONFLICT \(name\) DO UPDATE.*WHERE jobs.schedule <> EXCLUDED.schedule`).
+		WithArgs("cart-expiry", "0 3 * * *", next).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.SaveJob(context.Background(), "cart-expiry", "0 3 * * *", next); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClaimRun(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	due := time.Date(2026, time.March, 5, 3, 0, 0, 0, time.UTC)
+	next := due.AddDate(0, 0, 1)
+	mock.ExpectExec(`UPDATE jobs SET next_run_at = \$3, updated_at = NOW\(\) WHERE name = \$1 AND next_run_at = \$2`).
+		WithArgs("cart-expiry", due, next).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE jobs SET next_run_at`).
+		WithArgs("cart-expiry", due, next).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	claimed, err := repo.ClaimRun(context.Background(), "cart-expiry", due, next)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !claimed {
+		t.Error("Expected the run claimed")
+	}
+
+	claimed, err = repo.ClaimRun(context.Background(), "cart-expiry", due, next)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if claimed {
+		t.Error("Expected a run already claimed not to be claimed again")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStartRun(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	staleBefore := now.Add(-time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE job_runs SET status = 'FAILED', error = 'run was interrupted', finished_at = \$3\s+WHERE job_name = \$1 AND status = 'RUNNING' AND started_at < \$2`).
+		WithArgs("cart-expiry", staleBefore, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO job_runs \(id, job_name, trigger, status, instance, started_at\)`).
+		WithArgs(sqlmock.AnyArg(), "cart-expiry", TriggerScheduled, RunRunning, "instance-1", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	run := &Run{JobName: "cart-expiry", Trigger: TriggerScheduled, Instance: "instance-1", StartedAt: now}
+	if err := repo.StartRun(context.Background(), run, staleBefore); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if run.ID == "" || run.Status != RunRunning {
+		t.Errorf("Expected a running run with an ID, got %+v", run)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStartRun_InProgress(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE job_runs SET status = 'FAILED'`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO job_runs`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_job_runs_running"})
+	mock.ExpectRollback()
+
+	run := &Run{JobName: "cart-expiry", Trigger: TriggerManual, Instance: "instance-1", StartedAt: now}
+	if err := repo.StartRun(context.Background(), run, now.Add(-time.Hour)); err != ErrRunInProgress {
+		t.Errorf("Expected ErrRunInProgress, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFinishRun(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	finishedAt := time.Now()
+	run := &Run{ID: "run-1", Status: RunCompleted, Output: "expired 3 carts", FinishedAt: &finishedAt}
+	mock.ExpectExec(`UPDATE job_runs SET status = \$2, output = \$3, error = \$4, finished_at = \$5 WHERE id = \$1`).
+		WithArgs("run-1", RunCompleted, "expired 3 carts", "", &finishedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.FinishRun(context.Background(), run); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRun_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id, job_name, trigger, status, output, error, instance, started_at, finished_at FROM job_runs WHERE id = \$1`).
+		WithArgs("run-1").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.GetRun(context.Background(), "run-1"); err != ErrRunNotFound {
+		t.Errorf("Expected ErrRunNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListRuns(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM job_runs WHERE \(\$1 = '' OR job_name = \$1\)`).
+		WithArgs("cart-expiry").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT .* FROM job_runs WHERE .* ORDER BY started_at DESC, id LIMIT \$2 OFFSET \$3`).
+		WithArgs("cart-expiry", int32(2), int32(2)).
+		WillReturnRows(sqlmock.NewRows(runColumnNames).
+			AddRow("run-1", "cart-expiry", TriggerScheduled, RunRunning, "", "", "instance-1", now, nil))
+
+	runs, total, err := repo.ListRuns(context.Background(), "cart-expiry", 2, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 3 || len(runs) != 1 || runs[0].FinishedAt != nil {
+		t.Errorf("Expected 1 running run of 3, got %d of %d", len(runs), total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLastRuns(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT DISTINCT ON \(job_name\) .* FROM job_runs ORDER BY job_name, started_at DESC, id`).
+		WillReturnRows(sqlmock.NewRows(runColumnNames).
+			AddRow("run-1", "cart-expiry", TriggerScheduled, RunCompleted, "expired 3 carts", "", "instance-1", now, now).
+			AddRow("run-2", "price-activation", TriggerManual, RunFailed, "", "unavailable", "instance-2", now, now))
+
+	last, err := repo.LastRuns(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(last) != 2 || last["cart-expiry"].ID != "run-1" || last["price-activation"].Status != RunFailed {
+		t.Errorf("Unexpected last runs: %+v", last)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresElector(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+	elector := NewPostgresElector(db, DefaultLockKey)
+	ctx := context.Background()
+
+	// Another instance holds the lock
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(hashtext\(\$1\)\)`).
+		WithArgs(DefaultLockKey).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+	leader, err := elector.Leader(ctx)
+	if err != nil || leader {
+		t.Fatalf("Expected not to lead, got %v, %v", leader, err)
+	}
+
+	// The lock is free; once taken, leading is checked by pinging its
+	// connection
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock`).
+		WithArgs(DefaultLockKey).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+	mock.ExpectPing()
+	for i := 0; i < 2; i++ {
+		leader, err = elector.Leader(ctx)
+		if err != nil || !leader {
+			t.Fatalf("Expected to lead, got %v, %v", leader, err)
+		}
+	}
+
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(hashtext\(\$1\)\)`).
+		WithArgs(DefaultLockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	elector.Resign(ctx)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// DefaultTickInterval is how often the scheduler looks for due jobs, and
+	// how often an instance that does not lead tries to take the lead
+	DefaultTickInterval = 30 * time.Second
+	// DefaultJobTimeout bounds a run of a job that sets no timeout
+	DefaultJobTimeout = 10 * time.Minute
+)
+
+// ErrJobNotFound is returned when a job is not configured
+var ErrJobNotFound = errors.New("job not found")
+
+// Task carries out a job. It returns a summary of what it did for the run
+// history.
+type Task func(ctx context.Context) (string, error)
+
+// Job is a task run on a schedule
+type Job struct {
+	Name        string
+	Description string
+	// Schedule is a cron expression; see ParseSchedule
+	Schedule string
+	// Timeout bounds a run. A run still RUNNING after twice its timeout is
+	// taken to have died with its instance.
+	Timeout time.Duration
+	Task    Task
+
+	schedule Schedule
+}
+
+// staleBefore returns the start time before which a run of the job still
+// RUNNING at now has died
+func (j *Job) staleBefore(now time.Time) time.Time {
+	return now.Add(-2 * j.Timeout)
+}
+
+// Scheduler fires jobs on their schedules. Every instance of the jobs service
+// runs one, but only the instance the elector picks fires scheduled runs, and
+// it claims each run in the database first, so a run is fired once even
+// while the lead changes hands. Manual runs are carried out by the instance
+// asked. A job never has two runs at once.
+type Scheduler struct {
+	jobs     []*Job
+	byName   map[string]*Job
+	repo     Repository
+	elector  Elector
+	instance string
+	log      *logger.Logger
+	now      func() time.Time
+	// manual holds runs started by Trigger for the Run loop
+	manual chan *started
+	// leader records whether this instance led at the last tick, and saved
+	// whether it has stored the jobs since taking the lead
+	leader, saved bool
+	running       sync.WaitGroup
+}
+
+// started is a run the Run loop is to carry out
+type started struct {
+	job *Job
+	run *Run
+}
+
+// NewScheduler creates a scheduler for jobs, recording runs as instance
+func NewScheduler(jobs []*Job, repo Repository, elector Elector, instance string, log *logger.Logger) (*Scheduler, error) {
+	s := &Scheduler{
+		byName:   make(map[string]*Job, len(jobs)),
+		repo:     repo,
+		elector:  elector,
+		instance: instance,
+		log:      log,
+		now:      time.Now,
+		manual:   make(chan *started, len(jobs)),
+	}
+	for _, job := range jobs {
+		schedule, err := ParseSchedule(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		if schedule.Next(s.now().UTC()).IsZero() {
+			return nil, fmt.Errorf("job %s: schedule %q never runs", job.Name, job.Schedule)
+		}
+		if _, exists := s.byName[job.Name]; exists {
+			return nil, fmt.Errorf("job %s is configured twice", job.Name)
+		}
+		job.schedule = schedule
+		if job.Timeout <= 0 {
+			job.Timeout = DefaultJobTimeout
+		}
+		s.byName[job.Name] = job
+		s.jobs = append(s.jobs, job)
+	}
+	sort.Slice(s.jobs, func(i, j int) bool {
+		return s.jobs[i].Name < s.jobs[j].Name
+	})
+	return s, nil
+}
+
+// Jobs returns the configured jobs, ordered by name
+func (s *Scheduler) Jobs() []*Job {
+	return s.jobs
+}
+
+// Job returns a configured job
+func (s *Scheduler) Job(name string) (*Job, bool) {
+	job, ok := s.byName[name]
+	return job, ok
+}
+
+// NextRun returns when a job would next run after now if it had no stored
+// schedule
+func (s *Scheduler) NextRun(job *Job) time.Time {
+	return job.schedule.Next(s.now().UTC())
+}
+
+// Run ticks every interval, and carries out runs started by Trigger, until
+// ctx is cancelled. It then waits for runs in progress to finish and gives
+// up the lead.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			s.running.Wait()
+			s.elector.Resign(context.WithoutCancel(ctx))
+			return
+		case st := <-s.manual:
+			s.execute(ctx, st)
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick fires the jobs that are due, if this instance leads
+func (s *Scheduler) Tick(ctx context.Context) {
+	leader, err := s.elector.Leader(ctx)
+	if err != nil {
+		s.log.Warn(ctx, "Failed to check scheduler leadership", map[string]interface{}{"error": err.Error()})
+	}
+	if leader != s.leader {
+		if leader {
+			s.log.Info(ctx, "Scheduler leadership acquired", map[string]interface{}{"instance": s.instance})
+		} else {
+			s.log.Warn(ctx, "Scheduler leadership lost", map[string]interface{}{"instance": s.instance})
+		}
+		s.leader, s.saved = leader, false
+	}
+	if !leader {
+		return
+	}
+
+	if !s.saved {
+		if err := s.saveJobs(ctx); err != nil {
+			return
+		}
+		s.saved = true
+	}
+
+	states, err := s.repo.ListJobs(ctx)
+	if err != nil {
+		return
+	}
+	now := s.now().UTC()
+	for _, state := range states {
+		job, ok := s.byName[state.Name]
+		if !ok || state.NextRunAt.After(now) {
+			continue
+		}
+		claimed, err := s.repo.ClaimRun(ctx, job.Name, state.NextRunAt, job.schedule.Next(now))
+		if err != nil || !claimed {
+			continue
+		}
+		st, err := s.start(ctx, job, TriggerScheduled)
+		if errors.Is(err, ErrRunInProgress) {
+			s.log.Warn(ctx, "Job run skipped: the previous run is still in progress", map[string]interface{}{"job": job.Name})
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		s.execute(ctx, st)
+	}
+}
+
+// Trigger starts a run of a job to be carried out by the Run loop and returns
+// it RUNNING, or ErrJobNotFound or ErrRunInProgress
+func (s *Scheduler) Trigger(ctx context.Context, name string) (*Run, error) {
+	job, ok := s.byName[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	st, err := s.start(ctx, job, TriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	run := *st.run
+	s.manual <- st
+	return &run, nil
+}
+
+// saveJobs stores the configured jobs, so that a new job or a changed
+// schedule takes effect
+func (s *Scheduler) saveJobs(ctx context.Context) error {
+	now := s.now().UTC()
+	for _, job := range s.jobs {
+		if err := s.repo.SaveJob(ctx, job.Name, job.Schedule, job.schedule.Next(now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) start(ctx context.Context, job *Job, trigger string) (*started, error) {
+	now := s.now().UTC()
+	run := &Run{JobName: job.Name, Trigger: trigger, Instance: s.instance, StartedAt: now}
+	if err := s.repo.StartRun(ctx, run, job.staleBefore(now)); err != nil {
+		return nil, err
+	}
+	s.log.Info(ctx, "Job run started", map[string]interface{}{"job": job.Name, "run_id": run.ID, "trigger": trigger})
+	return &started{job: job, run: run}, nil
+}
+
+// execute carries out a started run in the background. The outcome is
+// recorded even when ctx is cancelled, so a run interrupted by shutdown is
+// reported FAILED.
+func (s *Scheduler) execute(ctx context.Context, st *started) {
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+
+		runCtx, cancel := context.WithTimeout(ctx, st.job.Timeout)
+		output, err := st.job.Task(runCtx)
+		cancel()
+
+		run := st.run
+		run.Status, run.Output = RunCompleted, output
+		if err != nil {
+			run.Status, run.Error = RunFailed, err.Error()
+		}
+		finishedAt := s.now().UTC()
+		run.FinishedAt = &finishedAt
+
+		if err := s.repo.FinishRun(context.WithoutCancel(ctx), run); err != nil {
+			return
+		}
+		fields := map[string]interface{}{
+			"job":         run.JobName,
+			"run_id":      run.ID,
+			"duration_ms": finishedAt.Sub(run.StartedAt).Milliseconds(),
+		}
+		if run.Status == RunFailed {
+			fields["error"] = run.Error
+			s.log.Error(ctx, "Job run failed", fields)
+		} else {
+			fields["output"] = run.Output
+			s.log.Info(ctx, "Job run completed", fields)
+		}
+	}()
+}
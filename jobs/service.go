@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/jobs/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminMethods are the admin-scoped RPCs of the jobs service; they are
+// restricted to the admin network allowlist. The service only serves the
+// backoffice, so that is every RPC.
+var AdminMethods = []string{
+	"/" + pb.JobService_ServiceDesc.ServiceName + "/",
+}
+
+// Service implements the JobService gRPC interface
+type Service struct {
+	pb.UnimplementedJobServiceServer
+	repo      Repository
+	scheduler *Scheduler
+	log       *logger.Logger
+}
+
+// NewService creates a new jobs service starting manual runs on scheduler
+func NewService(repo Repository, scheduler *Scheduler, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		scheduler: scheduler,
+		log:       log,
+	}
+}
+
+// ListJobs returns the configured jobs with their next and last runs
+func (s *Service) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	states, err := s.repo.ListJobs(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list jobs")
+	}
+	lastRuns, err := s.repo.LastRuns(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list jobs")
+	}
+	stored := make(map[string]*JobState, len(states))
+	for _, state := range states {
+		stored[state.Name] = state
+	}
+
+	jobs := s.scheduler.Jobs()
+	resp := &pb.ListJobsResponse{Jobs: make([]*pb.Job, len(jobs))}
+	for i, job := range jobs {
+		// A job not yet stored, or stored with another schedule, runs on its
+		// configured schedule once the leader stores it
+		nextRunAt := s.scheduler.NextRun(job)
+		if state, ok := stored[job.Name]; ok && state.Schedule == job.Schedule {
+			nextRunAt = state.NextRunAt
+		}
+		resp.Jobs[i] = &pb.Job{
+			Name:        job.Name,
+			Description: job.Description,
+			Schedule:    job.Schedule,
+			NextRunAt:   timestamppb.New(nextRunAt),
+		}
+		if run, ok := lastRuns[job.Name]; ok {
+			resp.Jobs[i].LastRun = toProtoRun(run)
+		}
+	}
+	return resp, nil
+}
+
+// TriggerJob starts a run of a job now
+func (s *Service) TriggerJob(ctx context.Context, req *pb.TriggerJobRequest) (*pb.TriggerJobResponse, error) {
+	if req.Name == "" {
+		s.log.Warn(ctx, "Trigger job failed: name is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	run, err := s.scheduler.Trigger(ctx, req.Name)
+	if errors.Is(err, ErrJobNotFound) {
+		return nil, status.Error(codes.NotFound, "job not found")
+	}
+	if errors.Is(err, ErrRunInProgress) {
+		return nil, status.Error(codes.FailedPrecondition, "a run of the job is already in progress")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to start job run")
+	}
+	return &pb.TriggerJobResponse{Run: toProtoRun(run)}, nil
+}
+
+// GetJobRun returns a run
+func (s *Service) GetJobRun(ctx context.Context, req *pb.GetJobRunRequest) (*pb.GetJobRunResponse, error) {
+	if req.Id == "" {
+		s.log.Warn(ctx, "Get job run failed: id is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	run, err := s.repo.GetRun(ctx, req.Id)
+	if errors.Is(err, ErrRunNotFound) {
+		return nil, status.Error(codes.NotFound, "job run not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get job run")
+	}
+	return &pb.GetJobRunResponse{Run: toProtoRun(run)}, nil
+}
+
+// ListJobRuns lists runs, most recent first
+func (s *Service) ListJobRuns(ctx context.Context, req *pb.ListJobRunsRequest) (*pb.ListJobRunsResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	runs, total, err := s.repo.ListRuns(ctx, req.JobName, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list job runs")
+	}
+
+	protoRuns := make([]*pb.Run, len(runs))
+	for i, run := range runs {
+		protoRuns[i] = toProtoRun(run)
+	}
+
+	return &pb.ListJobRunsResponse{
+		Runs:     protoRuns,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func toProtoRun(run *Run) *pb.Run {
+	r := &pb.Run{
+		Id:        run.ID,
+		JobName:   run.JobName,
+		Trigger:   run.Trigger,
+		Status:    run.Status,
+		Output:    run.Output,
+		Error:     run.Error,
+		Instance:  run.Instance,
+		StartedAt: timestamppb.New(run.StartedAt),
+	}
+	if run.FinishedAt != nil {
+		r.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+	return r
+}
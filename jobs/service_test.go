@@ -0,0 +1,536 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/jobs/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps job schedules and runs, claiming a run only at the
+// due time stored and refusing one while a fresh run of the job is running
+type memoryRepository struct {
+	mu   sync.Mutex
+	jobs map[string]*JobState
+	runs []*Run
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{jobs: map[string]*JobState{}}
+}
+
+func (m *memoryRepository) SaveJob(ctx context.Context, name, schedule string, nextRunAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[name]; ok && job.Schedule == schedule {
+		return nil
+	}
+	m.jobs[name] = &JobState{Name: name, Schedule: schedule, NextRunAt: nextRunAt}
+	return nil
+}
+
+func (m *memoryRepository) ListJobs(ctx context.Context) ([]*JobState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var jobs []*JobState
+	for _, job := range m.jobs {
+		cp := *job
+		jobs = append(jobs, &cp)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, nil
+}
+
+func (m *memoryRepository) ClaimRun(ctx context.Context, name string, due, next time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[name]
+	if !ok || !job.NextRunAt.Equal(due) {
+		return false, nil
+	}
+	job.NextRunAt = next
+	return true, nil
+}
+
+func (m *memoryRepository) StartRun(ctx context.Context, run *Run, staleBefore time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.runs {
+		if r.JobName != run.JobName || r.Status != RunRunning {
+			continue
+		}
+		if !r.StartedAt.Before(staleBefore) {
+			return ErrRunInProgress
+		}
+		r.Status, r.Error, r.FinishedAt = RunFailed, "run was interrupted", &run.StartedAt
+	}
+	run.ID = uuid.New().String()
+	run.Status = RunRunning
+	cp := *run
+	m.runs = append(m.runs, &cp)
+	return nil
+}
+
+func (m *memoryRepository) FinishRun(ctx context.Context, run *Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, r := range m.runs {
+		if r.ID == run.ID {
+			cp := *run
+			m.runs[i] = &cp
+			return nil
+		}
+	}
+	return ErrRunNotFound
+}
+
+func (m *memoryRepository) GetRun(ctx context.Context, id string) (*Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.runs {
+		if r.ID == id {
+			cp := *r
+			return &cp, nil
+		}
+	}
+	return nil, ErrRunNotFound
+}
+
+func (m *memoryRepository) ListRuns(ctx context.Context, jobName string, page, pageSize int32) ([]*Run, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var runs []*Run
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		if jobName == "" || m.runs[i].JobName == jobName {
+			cp := *m.runs[i]
+			runs = append(runs, &cp)
+		}
+	}
+	start := int((page - 1) * pageSize)
+	if start >= len(runs) {
+		return nil, int32(len(runs)), nil
+	}
+	return runs[start:min(start+int(pageSize), len(runs))], int32(len(runs)), nil
+}
+
+func (m *memoryRepository) LastRuns(ctx context.Context) (map[string]*Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	last := map[string]*Run{}
+	for _, r := range m.runs {
+		cp := *r
+		last[r.JobName] = &cp
+	}
+	return last, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeElector makes the instance lead while leader is set
+type fakeElector struct {
+	leader   bool
+	err      error
+	resigned bool
+}
+
+func (f *fakeElector) Leader(ctx context.Context) (bool, error) {
+	return f.leader, f.err
+}
+
+func (f *fakeElector) Resign(ctx context.Context) {
+	f.resigned = true
+}
+
+// countingTask counts its calls and returns output, or err
+type countingTask struct {
+	mu     sync.Mutex
+	calls  int
+	output string
+	err    error
+	// block holds calls until closed
+	block chan struct{}
+}
+
+func (c *countingTask) run(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+	if c.block != nil {
+		<-c.block
+	}
+	return c.output, c.err
+}
+
+func (c *countingTask) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+type serviceFixture struct {
+	service   *Service
+	scheduler *Scheduler
+	repo      *memoryRepository
+	elector   *fakeElector
+	task      *countingTask
+	now       time.Time
+}
+
+// setupService configures one job, "cart-expiry", running daily at 03:00 UTC
+func setupService(t *testing.T) *serviceFixture {
+	f := &serviceFixture{
+		repo:    newMemoryRepository(),
+		elector: &fakeElector{leader: true},
+		task:    &countingTask{output: "expired 3 carts"},
+		now:     time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC),
+	}
+	f.scheduler = newTestScheduler(t, f.repo, f.elector, f.task, "instance-1")
+	f.scheduler.now = func() time.Time { return f.now }
+	f.service = NewService(f.repo, f.scheduler, logger.New("jobs-test"))
+	return f
+}
+
+func newTestScheduler(t *testing.T, repo Repository, elector Elector, task *countingTask, instance string) *Scheduler {
+	scheduler, err := NewScheduler([]*Job{{
+		Name:        "cart-expiry",
+		Description: "Deletes idle carts",
+		Schedule:    "0 3 * * *",
+		Task:        task.run,
+	}}, repo, elector, instance, logger.New("jobs-test"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return scheduler
+}
+
+// tick ticks the scheduler and waits for the runs it fired
+func (f *serviceFixture) tick() {
+	f.scheduler.Tick(context.Background())
+	f.scheduler.running.Wait()
+}
+
+func TestNewScheduler_InvalidJobs(t *testing.T) {
+	repo, elector := newMemoryRepository(), &fakeElector{}
+	log := logger.New("jobs-test")
+	task := func(ctx context.Context) (string, error) { return "", nil }
+
+	tests := []struct {
+		name string
+		jobs []*Job
+	}{
+		{"invalid schedule", []*Job{{Name: "a", Schedule: "every day", Task: task}}},
+		{"schedule never runs", []*Job{{Name: "a", Schedule: "0 0 31 4 *", Task: task}}},
+		{"duplicate name", []*Job{{Name: "a", Schedule: "@daily", Task: task}, {Name: "a", Schedule: "@hourly", Task: task}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewScheduler(tt.jobs, repo, elector, "instance-1", log); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTick_FiresDueJobs(t *testing.T) {
+	f := setupService(t)
+
+	// Taking the lead stores the job, due at the next 03:00
+	f.tick()
+	jobs, _ := f.repo.ListJobs(context.Background())
+	if len(jobs) != 1 || !jobs[0].NextRunAt.Equal(time.Date(2026, time.March, 5, 3, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Expected the job stored due at 03:00 tomorrow, got %+v", jobs)
+	}
+	if f.task.count() != 0 {
+		t.Fatalf("Expected no run before the job is due, got %d", f.task.count())
+	}
+
+	f.now = time.Date(2026, time.March, 5, 3, 0, 20, 0, time.UTC)
+	f.tick()
+	if f.task.count() != 1 {
+		t.Fatalf("Expected 1 run, got %d", f.task.count())
+	}
+	jobs, _ = f.repo.ListJobs(context.Background())
+	if !jobs[0].NextRunAt.Equal(time.Date(2026, time.March, 6, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the next run moved to the following day, got %v", jobs[0].NextRunAt)
+	}
+
+	runs, _, _ := f.repo.ListRuns(context.Background(), "", 1, 10)
+	if len(runs) != 1 {
+		t.Fatalf("Expected 1 run recorded, got %d", len(runs))
+	}
+	run := runs[0]
+	if run.Status != RunCompleted || run.Trigger != TriggerScheduled || run.Output != "expired 3 carts" ||
+		run.Instance != "instance-1" || run.FinishedAt == nil {
+		t.Errorf("Unexpected run: %+v", run)
+	}
+
+	// Ticking again within the minute does not fire the job twice
+	f.tick()
+	if f.task.count() != 1 {
+		t.Errorf("Expected still 1 run, got %d", f.task.count())
+	}
+}
+
+func TestTick_OnlyLeaderFires(t *testing.T) {
+	f := setupService(t)
+	f.repo.SaveJob(context.Background(), "cart-expiry", "0 3 * * *", f.now.Add(-time.Minute))
+
+	f.elector.leader = false
+	f.tick()
+	if f.task.count() != 0 {
+		t.Fatalf("Expected no run while not leading, got %d", f.task.count())
+	}
+
+	f.elector.err = errors.New("connection refused")
+	f.tick()
+	if f.task.count() != 0 {
+		t.Fatalf("Expected no run when leadership cannot be checked, got %d", f.task.count())
+	}
+
+	f.elector.leader, f.elector.err = true, nil
+	f.tick()
+	if f.task.count() != 1 {
+		t.Errorf("Expected 1 run once leading, got %d", f.task.count())
+	}
+}
+
+func TestTick_ClaimsRunOnce(t *testing.T) {
+	// Two instances that both believe they lead, as while the lead changes
+	// hands, fire a due run once between them
+	repo := newMemoryRepository()
+	now := time.Date(2026, time.March, 5, 3, 0, 0, 0, time.UTC)
+	repo.SaveJob(context.Background(), "cart-expiry", "0 3 * * *", now)
+
+	task := &countingTask{}
+	var schedulers []*Scheduler
+	for _, instance := range []string{"instance-1", "instance-2"} {
+		s := newTestScheduler(t, repo, &fakeElector{leader: true}, task, instance)
+		s.now = func() time.Time { return now }
+		schedulers = append(schedulers, s)
+	}
+	for _, s := range schedulers {
+		s.Tick(context.Background())
+		s.running.Wait()
+	}
+
+	if task.count() != 1 {
+		t.Errorf("Expected 1 run, got %d", task.count())
+	}
+}
+
+func TestTick_SkipsWhileRunning(t *testing.T) {
+	f := setupService(t)
+	f.task.block = make(chan struct{})
+	f.repo.SaveJob(context.Background(), "cart-expiry", "0 3 * * *", f.now.Add(-time.Minute))
+
+	if _, err := f.scheduler.Trigger(context.Background(), "cart-expiry"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	f.scheduler.Tick(context.Background())
+	close(f.task.block)
+	f.scheduler.running.Wait()
+
+	runs, _, _ := f.repo.ListRuns(context.Background(), "", 1, 10)
+	if len(runs) != 1 || runs[0].Trigger != TriggerManual {
+		t.Errorf("Expected only the manual run, got %+v", runs)
+	}
+}
+
+func TestTick_ChangedScheduleResetsNextRun(t *testing.T) {
+	f := setupService(t)
+	f.repo.SaveJob(context.Background(), "cart-expiry", "0 4 * * *", f.now.Add(-time.Minute))
+
+	f.tick()
+	if f.task.count() != 0 {
+		t.Errorf("Expected no run under the old schedule, got %d", f.task.count())
+	}
+	jobs, _ := f.repo.ListJobs(context.Background())
+	if jobs[0].Schedule != "0 3 * * *" || !jobs[0].NextRunAt.Equal(time.Date(2026, time.March, 5, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the new schedule stored, got %+v", jobs[0])
+	}
+}
+
+func TestRun_ResignsOnShutdown(t *testing.T) {
+	f := setupService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.scheduler.Run(ctx, time.Hour)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Run to return")
+	}
+	if !f.elector.resigned {
+		t.Error("Expected the lead given up")
+	}
+}
+
+func TestTriggerJob(t *testing.T) {
+	f := setupService(t)
+	ctx := context.Background()
+
+	resp, err := f.service.TriggerJob(ctx, &pb.TriggerJobRequest{Name: "cart-expiry"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Run.Status != RunRunning || resp.Run.Trigger != TriggerManual || resp.Run.FinishedAt != nil {
+		t.Errorf("Expected a running manual run, got %+v", resp.Run)
+	}
+
+	// A second run waits for the first to finish
+	_, err = f.service.TriggerJob(ctx, &pb.TriggerJobRequest{Name: "cart-expiry"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+
+	// The Run loop carries out the run
+	f.scheduler.execute(ctx, <-f.scheduler.manual)
+	f.scheduler.running.Wait()
+
+	got, err := f.service.GetJobRun(ctx, &pb.GetJobRunRequest{Id: resp.Run.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Run.Status != RunCompleted || got.Run.Output != "expired 3 carts" || got.Run.FinishedAt == nil {
+		t.Errorf("Expected a completed run, got %+v", got.Run)
+	}
+}
+
+func TestTriggerJob_Failed(t *testing.T) {
+	f := setupService(t)
+	f.task.err = errors.New("failed to expire carts: unavailable")
+	ctx := context.Background()
+
+	resp, err := f.service.TriggerJob(ctx, &pb.TriggerJobRequest{Name: "cart-expiry"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	f.scheduler.execute(ctx, <-f.scheduler.manual)
+	f.scheduler.running.Wait()
+
+	got, _ := f.service.GetJobRun(ctx, &pb.GetJobRunRequest{Id: resp.Run.Id})
+	if got.Run.Status != RunFailed || got.Run.Error != "failed to expire carts: unavailable" {
+		t.Errorf("Expected a failed run, got %+v", got.Run)
+	}
+}
+
+func TestTriggerJob_StaleRun(t *testing.T) {
+	f := setupService(t)
+	ctx := context.Background()
+
+	// A run left RUNNING by an instance that died
+	stale := &Run{JobName: "cart-expiry", Trigger: TriggerScheduled, Instance: "instance-2", StartedAt: f.now.Add(-time.Hour)}
+	f.repo.StartRun(ctx, stale, stale.StartedAt)
+
+	if _, err := f.service.TriggerJob(ctx, &pb.TriggerJobRequest{Name: "cart-expiry"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, _ := f.repo.GetRun(ctx, stale.ID)
+	if got.Status != RunFailed || got.Error != "run was interrupted" {
+		t.Errorf("Expected the stale run failed, got %+v", got)
+	}
+}
+
+func TestTriggerJob_Invalid(t *testing.T) {
+	f := setupService(t)
+
+	_, err := f.service.TriggerJob(context.Background(), &pb.TriggerJobRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+	_, err = f.service.TriggerJob(context.Background(), &pb.TriggerJobRequest{Name: "token-purge"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestGetJobRun_NotFound(t *testing.T) {
+	f := setupService(t)
+
+	_, err := f.service.GetJobRun(context.Background(), &pb.GetJobRunRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+	_, err = f.service.GetJobRun(context.Background(), &pb.GetJobRunRequest{Id: uuid.New().String()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	f := setupService(t)
+	ctx := context.Background()
+
+	// Before the leader stores it, a job is listed on its configured schedule
+	resp, err := f.service.ListJobs(ctx, &pb.ListJobsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := time.Date(2026, time.March, 5, 3, 0, 0, 0, time.UTC)
+	if len(resp.Jobs) != 1 || !resp.Jobs[0].NextRunAt.AsTime().Equal(want) || resp.Jobs[0].LastRun != nil {
+		t.Fatalf("Unexpected jobs: %+v", resp.Jobs)
+	}
+
+	f.now = want.Add(time.Minute)
+	f.repo.SaveJob(ctx, "cart-expiry", "0 3 * * *", want)
+	f.tick()
+
+	resp, err = f.service.ListJobs(ctx, &pb.ListJobsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	job := resp.Jobs[0]
+	if job.Name != "cart-expiry" || job.Schedule != "0 3 * * *" || job.Description != "Deletes idle carts" {
+		t.Errorf("Unexpected job: %+v", job)
+	}
+	if !job.NextRunAt.AsTime().Equal(want.AddDate(0, 0, 1)) {
+		t.Errorf("Expected next run at %v, got %v", want.AddDate(0, 0, 1), job.NextRunAt.AsTime())
+	}
+	if job.LastRun == nil || job.LastRun.Status != RunCompleted {
+		t.Errorf("Expected the last run completed, got %+v", job.LastRun)
+	}
+}
+
+func TestListJobRuns(t *testing.T) {
+	f := setupService(t)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		f.repo.StartRun(ctx, &Run{JobName: "cart-expiry", Trigger: TriggerManual, StartedAt: f.now.Add(time.Duration(i) * time.Second)}, time.Time{})
+		runs, _, _ := f.repo.ListRuns(ctx, "", 1, 1)
+		runs[0].Status = RunCompleted
+		f.repo.FinishRun(ctx, runs[0])
+	}
+	f.repo.StartRun(ctx, &Run{JobName: "price-activation", Trigger: TriggerManual, StartedAt: f.now}, time.Time{})
+
+	resp, err := f.service.ListJobRuns(ctx, &pb.ListJobRunsRequest{JobName: "cart-expiry", PageSize: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 3 || len(resp.Runs) != 2 || resp.Page != 1 || resp.PageSize != 2 {
+		t.Errorf("Expected 2 of 3 runs on page 1, got %d of %d", len(resp.Runs), resp.Total)
+	}
+
+	resp, err = f.service.ListJobRuns(ctx, &pb.ListJobRunsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 4 || resp.PageSize != 10 {
+		t.Errorf("Expected 4 runs of every job, got %d", resp.Total)
+	}
+}
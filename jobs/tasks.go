@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	cartpb "github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	feedpb "github.com/Ujjwaljain16/E-commerce-Backend/feeds/pb"
+	recommendationpb "github.com/Ujjwaljain16/E-commerce-Backend/recommendation/pb"
+	searchpb "github.com/Ujjwaljain16/E-commerce-Backend/search/pb"
+)
+
+// CartExpiryTask deletes carts left unchanged for longer than idleDays
+// through the cart service; 0 uses the cart service's default
+func CartExpiryTask(client cartpb.CartServiceClient, idleDays int32) Task {
+	return func(ctx context.Context) (string, error) {
+		resp, err := client.ExpireCarts(ctx, &cartpb.ExpireCartsRequest{IdleDays: idleDays})
+		if err != nil {
+			return "", fmt.Errorf("failed to expire carts: %w", err)
+		}
+		return fmt.Sprintf("expired %d carts", resp.Expired), nil
+	}
+}
+
+// PriceActivationTask rebuilds the search index, so that sale prices whose
+// window opened or closed since the last rebuild are searchable at their
+// current price. Price lists and rules need no activation: the pricing
+// service resolves their windows when asked for a price.
+func PriceActivationTask(client searchpb.SearchServiceClient) Task {
+	return func(ctx context.Context) (string, error) {
+		resp, err := client.Reindex(ctx, &searchpb.ReindexRequest{})
+		if err != nil {
+			return "", fmt.Errorf("failed to start reindex: %w", err)
+		}
+		if !resp.Started {
+			return "reindex already pending", nil
+		}
+		return "reindex started", nil
+	}
+}
+
+// FeedGenerationTask regenerates the shopping feeds and sitemaps through the
+// feed service
+func FeedGenerationTask(client feedpb.FeedServiceClient) Task {
+	return func(ctx context.Context) (string, error) {
+		resp, err := client.GenerateFeeds(ctx, &feedpb.GenerateFeedsRequest{})
+		if err != nil {
+			return "", fmt.Errorf("failed to generate feeds: %w", err)
+		}
+		for _, feed := range resp.Feeds {
+			if feed.Name == "GOOGLE_SHOPPING" {
+				return fmt.Sprintf("generated %d feeds with %d products", len(resp.Feeds), feed.Items), nil
+			}
+		}
+		return fmt.Sprintf("generated %d feeds", len(resp.Feeds)), nil
+	}
+}
+
+// AssociationMiningTask mines recent orders into the recommendation
+// service's frequently bought together rules
+func AssociationMiningTask(client recommendationpb.RecommendationServiceClient) Task {
+	return func(ctx context.Context) (string, error) {
+		resp, err := client.MineAssociationRules(ctx, &recommendationpb.MineAssociationRulesRequest{})
+		if err != nil {
+			return "", fmt.Errorf("failed to mine association rules: %w", err)
+		}
+		return fmt.Sprintf("mined %d rules from %d orders", resp.Rules, resp.Orders), nil
+	}
+}
@@ -0,0 +1,56 @@
+package loyalty
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// expiryBatchSize is how many lapsed lots are expired per transaction
+const expiryBatchSize = 500
+
+// PointsExpirer periodically records the expiry of lapsed points. Lapsed
+// points are not redeemable or counted in balances before then; expiring
+// them only zeroes their lots and writes EXPIRE entries to the ledger.
+type PointsExpirer struct {
+	repo Repository
+	log  *logger.Logger
+	now  func() time.Time
+}
+
+// NewPointsExpirer creates a points expirer
+func NewPointsExpirer(repo Repository, log *logger.Logger) *PointsExpirer {
+	return &PointsExpirer{repo: repo, log: log, now: time.Now}
+}
+
+// Run expires lapsed points every interval until ctx is cancelled
+func (e *PointsExpirer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.ExpireLapsed(ctx); err != nil {
+				e.log.Error(ctx, "Failed to expire points", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
+
+// ExpireLapsed expires every lot that has lapsed, a batch at a time
+func (e *PointsExpirer) ExpireLapsed(ctx context.Context) error {
+	at := e.now()
+	for {
+		expired, err := e.repo.Expire(ctx, at, expiryBatchSize)
+		if err != nil {
+			return err
+		}
+		if expired < expiryBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS loyalty_redemption_lots;
+DROP INDEX IF EXISTS idx_loyalty_ledger_expiry;
+DROP INDEX IF EXISTS idx_loyalty_ledger_lots;
+DROP INDEX IF EXISTS idx_loyalty_ledger_user;
+DROP INDEX IF EXISTS idx_loyalty_ledger_redeemed;
+DROP INDEX IF EXISTS idx_loyalty_ledger_earned;
+DROP TABLE IF EXISTS loyalty_ledger;
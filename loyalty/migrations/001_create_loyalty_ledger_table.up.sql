@@ -0,0 +1,34 @@
+-- Loyalty points ledger. Every change to a customer's points is an entry:
+-- EARN entries are the lots points are redeemed from, soonest-expiring
+-- first, and remaining is what is left of each lot. A customer's balance is
+-- the remaining points of their unexpired lots.
+CREATE TABLE IF NOT EXISTS loyalty_ledger (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('EARN', 'REDEEM', 'RELEASE', 'EXPIRE')),
+    points BIGINT NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    value DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (value >= 0),
+    remaining BIGINT NOT NULL DEFAULT 0 CHECK (remaining >= 0),
+    expires_at TIMESTAMP,
+    released_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((type = 'EARN') = (expires_at IS NOT NULL))
+);
+
+-- An order earns points once, and has at most one unreleased redemption
+CREATE UNIQUE INDEX idx_loyalty_ledger_earned ON loyalty_ledger(reference) WHERE type = 'EARN';
+CREATE UNIQUE INDEX idx_loyalty_ledger_redeemed ON loyalty_ledger(reference) WHERE type = 'REDEEM' AND released_at IS NULL;
+-- A customer's ledger, newest first
+CREATE INDEX idx_loyalty_ledger_user ON loyalty_ledger(user_id, created_at DESC);
+-- A customer's lots with points left, and the lots due to expire
+CREATE INDEX idx_loyalty_ledger_lots ON loyalty_ledger(user_id, expires_at) WHERE type = 'EARN' AND remaining > 0;
+CREATE INDEX idx_loyalty_ledger_expiry ON loyalty_ledger(expires_at) WHERE type = 'EARN' AND remaining > 0;
+
+-- The points each redemption took from each lot, given back on release
+CREATE TABLE IF NOT EXISTS loyalty_redemption_lots (
+    redemption_id UUID NOT NULL REFERENCES loyalty_ledger(id) ON DELETE CASCADE,
+    lot_id UUID NOT NULL REFERENCES loyalty_ledger(id) ON DELETE CASCADE,
+    points BIGINT NOT NULL CHECK (points > 0),
+    PRIMARY KEY (redemption_id, lot_id)
+);
@@ -0,0 +1,865 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: loyalty/loyalty.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LedgerEntry is a change to a customer's points
+type LedgerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                               // EARN, REDEEM, RELEASE or EXPIRE
+	Points        int64                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`                          // positive for EARN and RELEASE, negative for REDEEM and EXPIRE
+	Reference     string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`                     // order ID for EARN and REDEEM; the redemption ID for RELEASE; the expired EARN entry ID for EXPIRE
+	Value         float64                `protobuf:"fixed64,6,opt,name=value,proto3" json:"value,omitempty"`                           // what the points were worth at checkout; REDEEM and RELEASE only
+	Remaining     int64                  `protobuf:"varint,7,opt,name=remaining,proto3" json:"remaining,omitempty"`                    // points of an EARN entry not yet redeemed or expired
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`    // EARN only
+	ReleasedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"` // REDEEM only; set once the redemption was given back
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LedgerEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// AwardPoints credits a customer with points for a paid order. Points are
+// earned on the amount paid, less any points redeemed for the order.
+// Awarding an order again returns its first award.
+type AwardPointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`             // order total
+	PaidAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"` // optional; now when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AwardPointsRequest) Reset() {
+	*x = AwardPointsRequest{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AwardPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AwardPointsRequest) ProtoMessage() {}
+
+func (x *AwardPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AwardPointsRequest.ProtoReflect.Descriptor instead.
+func (*AwardPointsRequest) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AwardPointsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AwardPointsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AwardPointsRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AwardPointsRequest) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+type AwardPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *LedgerEntry           `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`      // unset when the order earned no points
+	Awarded       bool                   `protobuf:"varint,2,opt,name=awarded,proto3" json:"awarded,omitempty"` // false when the order was awarded before or earned nothing
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AwardPointsResponse) Reset() {
+	*x = AwardPointsResponse{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AwardPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AwardPointsResponse) ProtoMessage() {}
+
+func (x *AwardPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AwardPointsResponse.ProtoReflect.Descriptor instead.
+func (*AwardPointsResponse) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AwardPointsResponse) GetEntry() *LedgerEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *AwardPointsResponse) GetAwarded() bool {
+	if x != nil {
+		return x.Awarded
+	}
+	return false
+}
+
+// RedeemPoints spends a customer's points on an order, soonest-expiring
+// first. Redeeming for the same order again returns the first redemption.
+type RedeemPointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Points        int64                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderTotal    float64                `protobuf:"fixed64,4,opt,name=order_total,json=orderTotal,proto3" json:"order_total,omitempty"` // the points must be worth less than this
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemPointsRequest) Reset() {
+	*x = RedeemPointsRequest{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemPointsRequest) ProtoMessage() {}
+
+func (x *RedeemPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemPointsRequest.ProtoReflect.Descriptor instead.
+func (*RedeemPointsRequest) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RedeemPointsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RedeemPointsRequest) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *RedeemPointsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RedeemPointsRequest) GetOrderTotal() float64 {
+	if x != nil {
+		return x.OrderTotal
+	}
+	return 0
+}
+
+type RedeemPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Redemption    *LedgerEntry           `protobuf:"bytes,1,opt,name=redemption,proto3" json:"redemption,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemPointsResponse) Reset() {
+	*x = RedeemPointsResponse{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemPointsResponse) ProtoMessage() {}
+
+func (x *RedeemPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemPointsResponse.ProtoReflect.Descriptor instead.
+func (*RedeemPointsResponse) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RedeemPointsResponse) GetRedemption() *LedgerEntry {
+	if x != nil {
+		return x.Redemption
+	}
+	return nil
+}
+
+// ReleaseRedemption gives back the points of a redemption whose checkout
+// was undone. Releasing a released redemption does nothing.
+type ReleaseRedemptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedemptionId  string                 `protobuf:"bytes,1,opt,name=redemption_id,json=redemptionId,proto3" json:"redemption_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRedemptionRequest) Reset() {
+	*x = ReleaseRedemptionRequest{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRedemptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRedemptionRequest) ProtoMessage() {}
+
+func (x *ReleaseRedemptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRedemptionRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRedemptionRequest) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReleaseRedemptionRequest) GetRedemptionId() string {
+	if x != nil {
+		return x.RedemptionId
+	}
+	return ""
+}
+
+type ReleaseRedemptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Redemption    *LedgerEntry           `protobuf:"bytes,1,opt,name=redemption,proto3" json:"redemption,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRedemptionResponse) Reset() {
+	*x = ReleaseRedemptionResponse{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRedemptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRedemptionResponse) ProtoMessage() {}
+
+func (x *ReleaseRedemptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRedemptionResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseRedemptionResponse) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReleaseRedemptionResponse) GetRedemption() *LedgerEntry {
+	if x != nil {
+		return x.Redemption
+	}
+	return nil
+}
+
+// GetBalance returns the points a customer can redeem
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBalanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetBalanceResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Points         int64                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	Value          float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`                                        // what the points are worth at checkout
+	ExpiringPoints int64                  `protobuf:"varint,4,opt,name=expiring_points,json=expiringPoints,proto3" json:"expiring_points,omitempty"` // points expiring within the next 30 days
+	ExpiringAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiring_at,json=expiringAt,proto3" json:"expiring_at,omitempty"`              // when the soonest points expire; unset when no points are held
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetBalanceResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetBalanceResponse) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetExpiringPoints() int64 {
+	if x != nil {
+		return x.ExpiringPoints
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetExpiringAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiringAt
+	}
+	return nil
+}
+
+// GetLedger lists a customer's ledger, newest first
+type GetLedgerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLedgerRequest) Reset() {
+	*x = GetLedgerRequest{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLedgerRequest) ProtoMessage() {}
+
+func (x *GetLedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLedgerRequest.ProtoReflect.Descriptor instead.
+func (*GetLedgerRequest) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetLedgerRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLedgerRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetLedgerRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetLedgerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLedgerResponse) Reset() {
+	*x = GetLedgerResponse{}
+	mi := &file_loyalty_loyalty_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLedgerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLedgerResponse) ProtoMessage() {}
+
+func (x *GetLedgerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalty_loyalty_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLedgerResponse.ProtoReflect.Descriptor instead.
+func (*GetLedgerResponse) Descriptor() ([]byte, []int) {
+	return file_loyalty_loyalty_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetLedgerResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetLedgerResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetLedgerResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetLedgerResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+var File_loyalty_loyalty_proto protoreflect.FileDescriptor
+
+const file_loyalty_loyalty_proto_rawDesc = "" +
+	"\n" +
+	"\x15loyalty/loyalty.proto\x12\aloyalty\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x02\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06points\x18\x04 \x01(\x03R\x06points\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12\x14\n" +
+	"\x05value\x18\x06 \x01(\x01R\x05value\x12\x1c\n" +
+	"\tremaining\x18\a \x01(\x03R\tremaining\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12;\n" +
+	"\vreleased_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x95\x01\n" +
+	"\x12AwardPointsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x123\n" +
+	"\apaid_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06paidAt\"[\n" +
+	"\x13AwardPointsResponse\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.loyalty.LedgerEntryR\x05entry\x12\x18\n" +
+	"\aawarded\x18\x02 \x01(\bR\aawarded\"\x82\x01\n" +
+	"\x13RedeemPointsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x03R\x06points\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vorder_total\x18\x04 \x01(\x01R\n" +
+	"orderTotal\"L\n" +
+	"\x14RedeemPointsResponse\x124\n" +
+	"\n" +
+	"redemption\x18\x01 \x01(\v2\x14.loyalty.LedgerEntryR\n" +
+	"redemption\"?\n" +
+	"\x18ReleaseRedemptionRequest\x12#\n" +
+	"\rredemption_id\x18\x01 \x01(\tR\fredemptionId\"Q\n" +
+	"\x19ReleaseRedemptionResponse\x124\n" +
+	"\n" +
+	"redemption\x18\x01 \x01(\v2\x14.loyalty.LedgerEntryR\n" +
+	"redemption\",\n" +
+	"\x11GetBalanceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc1\x01\n" +
+	"\x12GetBalanceResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x03R\x06points\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12'\n" +
+	"\x0fexpiring_points\x18\x04 \x01(\x03R\x0eexpiringPoints\x12;\n" +
+	"\vexpiring_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiringAt\"\\\n" +
+	"\x10GetLedgerRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x8a\x01\n" +
+	"\x11GetLedgerResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.loyalty.LedgerEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\x8e\x03\n" +
+	"\x0eLoyaltyService\x12H\n" +
+	"\vAwardPoints\x12\x1b.loyalty.AwardPointsRequest\x1a\x1c.loyalty.AwardPointsResponse\x12K\n" +
+	"\fRedeemPoints\x12\x1c.loyalty.RedeemPointsRequest\x1a\x1d.loyalty.RedeemPointsResponse\x12Z\n" +
+	"\x11ReleaseRedemption\x12!.loyalty.ReleaseRedemptionRequest\x1a\".loyalty.ReleaseRedemptionResponse\x12E\n" +
+	"\n" +
+	"GetBalance\x12\x1a.loyalty.GetBalanceRequest\x1a\x1b.loyalty.GetBalanceResponse\x12B\n" +
+	"\tGetLedger\x12\x19.loyalty.GetLedgerRequest\x1a\x1a.loyalty.GetLedgerResponseB7Z5github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pbb\x06proto3"
+
+var (
+	file_loyalty_loyalty_proto_rawDescOnce sync.Once
+	file_loyalty_loyalty_proto_rawDescData []byte
+)
+
+func file_loyalty_loyalty_proto_rawDescGZIP() []byte {
+	file_loyalty_loyalty_proto_rawDescOnce.Do(func() {
+		file_loyalty_loyalty_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_loyalty_loyalty_proto_rawDesc), len(file_loyalty_loyalty_proto_rawDesc)))
+	})
+	return file_loyalty_loyalty_proto_rawDescData
+}
+
+var file_loyalty_loyalty_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_loyalty_loyalty_proto_goTypes = []any{
+	(*LedgerEntry)(nil),               // 0: loyalty.LedgerEntry
+	(*AwardPointsRequest)(nil),        // 1: loyalty.AwardPointsRequest
+	(*AwardPointsResponse)(nil),       // 2: loyalty.AwardPointsResponse
+	(*RedeemPointsRequest)(nil),       // 3: loyalty.RedeemPointsRequest
+	(*RedeemPointsResponse)(nil),      // 4: loyalty.RedeemPointsResponse
+	(*ReleaseRedemptionRequest)(nil),  // 5: loyalty.ReleaseRedemptionRequest
+	(*ReleaseRedemptionResponse)(nil), // 6: loyalty.ReleaseRedemptionResponse
+	(*GetBalanceRequest)(nil),         // 7: loyalty.GetBalanceRequest
+	(*GetBalanceResponse)(nil),        // 8: loyalty.GetBalanceResponse
+	(*GetLedgerRequest)(nil),          // 9: loyalty.GetLedgerRequest
+	(*GetLedgerResponse)(nil),         // 10: loyalty.GetLedgerResponse
+	(*timestamppb.Timestamp)(nil),     // 11: google.protobuf.Timestamp
+}
+var file_loyalty_loyalty_proto_depIdxs = []int32{
+	11, // 0: loyalty.LedgerEntry.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 1: loyalty.LedgerEntry.released_at:type_name -> google.protobuf.Timestamp
+	11, // 2: loyalty.LedgerEntry.created_at:type_name -> google.protobuf.Timestamp
+	11, // 3: loyalty.AwardPointsRequest.paid_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: loyalty.AwardPointsResponse.entry:type_name -> loyalty.LedgerEntry
+	0,  // 5: loyalty.RedeemPointsResponse.redemption:type_name -> loyalty.LedgerEntry
+	0,  // 6: loyalty.ReleaseRedemptionResponse.redemption:type_name -> loyalty.LedgerEntry
+	11, // 7: loyalty.GetBalanceResponse.expiring_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: loyalty.GetLedgerResponse.entries:type_name -> loyalty.LedgerEntry
+	1,  // 9: loyalty.LoyaltyService.AwardPoints:input_type -> loyalty.AwardPointsRequest
+	3,  // 10: loyalty.LoyaltyService.RedeemPoints:input_type -> loyalty.RedeemPointsRequest
+	5,  // 11: loyalty.LoyaltyService.ReleaseRedemption:input_type -> loyalty.ReleaseRedemptionRequest
+	7,  // 12: loyalty.LoyaltyService.GetBalance:input_type -> loyalty.GetBalanceRequest
+	9,  // 13: loyalty.LoyaltyService.GetLedger:input_type -> loyalty.GetLedgerRequest
+	2,  // 14: loyalty.LoyaltyService.AwardPoints:output_type -> loyalty.AwardPointsResponse
+	4,  // 15: loyalty.LoyaltyService.RedeemPoints:output_type -> loyalty.RedeemPointsResponse
+	6,  // 16: loyalty.LoyaltyService.ReleaseRedemption:output_type -> loyalty.ReleaseRedemptionResponse
+	8,  // 17: loyalty.LoyaltyService.GetBalance:output_type -> loyalty.GetBalanceResponse
+	10, // 18: loyalty.LoyaltyService.GetLedger:output_type -> loyalty.GetLedgerResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_loyalty_loyalty_proto_init() }
+func file_loyalty_loyalty_proto_init() {
+	if File_loyalty_loyalty_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_loyalty_loyalty_proto_rawDesc), len(file_loyalty_loyalty_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_loyalty_loyalty_proto_goTypes,
+		DependencyIndexes: file_loyalty_loyalty_proto_depIdxs,
+		MessageInfos:      file_loyalty_loyalty_proto_msgTypes,
+	}.Build()
+	File_loyalty_loyalty_proto = out.File
+	file_loyalty_loyalty_proto_goTypes = nil
+	file_loyalty_loyalty_proto_depIdxs = nil
+}
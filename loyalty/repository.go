@@ -0,0 +1,452 @@
+package loyalty
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Ledger entry types
+const (
+	TypeEarn    = "EARN"
+	TypeRedeem  = "REDEEM"
+	TypeRelease = "RELEASE"
+	TypeExpire  = "EXPIRE"
+)
+
+var (
+	// ErrRedemptionNotFound is returned when a redemption does not exist
+	ErrRedemptionNotFound = errors.New("redemption not found")
+	// ErrInsufficientPoints is returned when a customer has fewer unexpired
+	// points than a redemption asks for
+	ErrInsufficientPoints = errors.New("insufficient points")
+	// ErrReferenceInUse is returned when an order has already redeemed points
+	// for another customer
+	ErrReferenceInUse = errors.New("order already redeemed points for another customer")
+)
+
+// redeemedConstraint is the unique index on an order's unreleased redemption
+const redeemedConstraint = "idx_loyalty_ledger_redeemed"
+
+// Entry is a change to a customer's points
+type Entry struct {
+	ID     string
+	UserID string
+	Type   string
+	// Points is positive for EARN and RELEASE entries and negative for
+	// REDEEM and EXPIRE entries
+	Points int64
+	// Reference is the order of an EARN or REDEEM entry, the redemption of a
+	// RELEASE entry and the lot of an EXPIRE entry
+	Reference string
+	// Value is what the points of a REDEEM or RELEASE entry were worth
+	Value float64
+	// Remaining is what is left of an EARN entry's points
+	Remaining  int64
+	ExpiresAt  *time.Time
+	ReleasedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// Balance is the points a customer can redeem
+type Balance struct {
+	Points int64
+	// ExpiringPoints expire before the expiring horizon passed to Balance
+	ExpiringPoints int64
+	// ExpiringAt is when the soonest points expire; nil without points
+	ExpiringAt *time.Time
+}
+
+// Repository handles ledger data persistence
+type Repository interface {
+	// Award records an EARN entry. When the order has earned points
+	// already, that entry is returned with false.
+	Award(ctx context.Context, entry *Entry) (*Entry, bool, error)
+	// RedeemedValue is the value of the order's unreleased redemption, or 0
+	RedeemedValue(ctx context.Context, orderID string) (float64, error)
+	// Redeem takes points from the customer's unexpired lots, soonest-expiring
+	// first, returning ErrInsufficientPoints when they do not add up. When the
+	// order has an unreleased redemption already, that is returned instead.
+	Redeem(ctx context.Context, redemption *Entry, at time.Time) (*Entry, error)
+	// Release gives a redemption's points back to the lots they came from; a
+	// released redemption is returned unchanged
+	Release(ctx context.Context, id string, at time.Time) (*Entry, error)
+	// Balance adds up the customer's lots unexpired at at, counting those
+	// expiring before horizon as expiring
+	Balance(ctx context.Context, userID string, at, horizon time.Time) (*Balance, error)
+	// Ledger lists the customer's entries, newest first
+	Ledger(ctx context.Context, userID string, page, pageSize int32) ([]*Entry, int32, error)
+	// Expire records EXPIRE entries for up to limit lots that lapsed by at,
+	// and returns how many it expired
+	Expire(ctx context.Context, at time.Time, limit int) (int, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL ledger repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const entryColumns = `id, user_id, type, points, reference, value, remaining, expires_at, released_at, created_at`
+
+// insertEntry is the statement recording an entry
+const insertEntry = `
+	INSERT INTO loyalty_ledger (id, user_id, type, points, reference, value, remaining, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+// Award records an EARN entry unless the order has one
+func (r *postgresRepository) Award(ctx context.Context, entry *Entry) (*Entry, bool, error) {
+	entry.ID = uuid.New().String()
+	entry.Type = TypeEarn
+	entry.Remaining = entry.Points
+
+	result, err := r.db.ExecContext(ctx, insertEntry+`
+		ON CONFLICT (reference) WHERE type = 'EARN' DO NOTHING
+	`, entry.ID, entry.UserID, entry.Type, entry.Points, entry.Reference, entry.Value, entry.Remaining,
+		entry.ExpiresAt, entry.CreatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to award points", map[string]interface{}{"error": err.Error(), "order_id": entry.Reference})
+		return nil, false, fmt.Errorf("failed to award points: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to award points: %w", err)
+	}
+	if rows == 0 {
+		query := "SELECT " + entryColumns + " FROM loyalty_ledger WHERE reference = $1 AND type = 'EARN'"
+		existing, err := scanEntry(r.db.QueryRowContext(ctx, query, entry.Reference))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get award: %w", err)
+		}
+		return existing, false, nil
+	}
+
+	r.log.Info(ctx, "Points awarded", map[string]interface{}{"user_id": entry.UserID, "order_id": entry.Reference, "points": entry.Points})
+	return entry, true, nil
+}
+
+// RedeemedValue returns the value of the order's unreleased redemption
+func (r *postgresRepository) RedeemedValue(ctx context.Context, orderID string) (float64, error) {
+	var value float64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(value), 0) FROM loyalty_ledger
+		WHERE reference = $1 AND type = 'REDEEM' AND released_at IS NULL
+	`, orderID).Scan(&value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get redeemed value: %w", err)
+	}
+	return value, nil
+}
+
+// lot is the part of an EARN entry a redemption takes
+type lot struct {
+	id     string
+	points int64
+}
+
+// Redeem records a redemption in one transaction with the customer's lots
+// locked, so concurrent redemptions cannot spend the same points
+func (r *postgresRepository) Redeem(ctx context.Context, redemption *Entry, at time.Time) (*Entry, error) {
+	// A retried checkout gets the redemption it already made
+	existing, err := r.unreleasedRedemption(ctx, redemption)
+	if err != sql.ErrNoRows {
+		return existing, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, remaining FROM loyalty_ledger
+		WHERE user_id = $1 AND type = 'EARN' AND remaining > 0 AND expires_at > $2
+		ORDER BY expires_at, id
+		FOR UPDATE
+	`, redemption.UserID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock points: %w", err)
+	}
+	var lots []lot
+	needed := -redemption.Points
+	for rows.Next() && needed > 0 {
+		var l lot
+		if err := rows.Scan(&l.id, &l.points); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan points: %w", err)
+		}
+		l.points = min(l.points, needed)
+		needed -= l.points
+		lots = append(lots, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating points: %w", err)
+	}
+	if needed > 0 {
+		return nil, ErrInsufficientPoints
+	}
+
+	redemption.ID = uuid.New().String()
+	redemption.Type = TypeRedeem
+	_, err = tx.ExecContext(ctx, insertEntry, redemption.ID, redemption.UserID, redemption.Type, redemption.Points,
+		redemption.Reference, redemption.Value, 0, nil, redemption.CreatedAt)
+	if pgerr.IsUniqueViolation(err, redeemedConstraint) {
+		tx.Rollback()
+		return r.unreleasedRedemption(ctx, redemption)
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to record redemption", map[string]interface{}{"error": err.Error(), "order_id": redemption.Reference})
+		return nil, fmt.Errorf("failed to record redemption: %w", err)
+	}
+	for _, l := range lots {
+		if _, err := tx.ExecContext(ctx, "UPDATE loyalty_ledger SET remaining = remaining - $2 WHERE id = $1", l.id, l.points); err != nil {
+			return nil, fmt.Errorf("failed to spend points: %w", err)
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO loyalty_redemption_lots (redemption_id, lot_id, points) VALUES ($1, $2, $3)
+		`, redemption.ID, l.id, l.points)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record spent points: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Points redeemed", map[string]interface{}{"redemption_id": redemption.ID, "user_id": redemption.UserID, "order_id": redemption.Reference, "points": -redemption.Points})
+	return redemption, nil
+}
+
+// unreleasedRedemption returns the order's unreleased redemption, or
+// sql.ErrNoRows
+func (r *postgresRepository) unreleasedRedemption(ctx context.Context, redemption *Entry) (*Entry, error) {
+	query := "SELECT " + entryColumns + " FROM loyalty_ledger WHERE reference = $1 AND type = 'REDEEM' AND released_at IS NULL"
+	existing, err := scanEntry(r.db.QueryRowContext(ctx, query, redemption.Reference))
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redemption: %w", err)
+	}
+	if existing.UserID != redemption.UserID {
+		return nil, ErrReferenceInUse
+	}
+	return existing, nil
+}
+
+// Release gives back a redemption's points and records a RELEASE entry in
+// one transaction
+func (r *postgresRepository) Release(ctx context.Context, id string, at time.Time) (*Entry, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT " + entryColumns + " FROM loyalty_ledger WHERE id = $1 AND type = 'REDEEM' FOR UPDATE"
+	redemption, err := scanEntry(tx.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrRedemptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock redemption: %w", err)
+	}
+	if redemption.ReleasedAt != nil {
+		return redemption, nil
+	}
+
+	// Points given back to a lot that has lapsed since expire on the next sweep
+	_, err = tx.ExecContext(ctx, `
+		UPDATE loyalty_ledger l SET remaining = l.remaining + rl.points
+		FROM loyalty_redemption_lots rl
+		WHERE rl.redemption_id = $1 AND l.id = rl.lot_id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to give back points: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE loyalty_ledger SET released_at = $2 WHERE id = $1", id, at); err != nil {
+		r.log.Error(ctx, "Failed to release redemption", map[string]interface{}{"error": err.Error(), "redemption_id": id})
+		return nil, fmt.Errorf("failed to release redemption: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, insertEntry, uuid.New().String(), redemption.UserID, TypeRelease, -redemption.Points,
+		redemption.ID, redemption.Value, 0, nil, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record release: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	redemption.ReleasedAt = &at
+	r.log.Info(ctx, "Redemption released", map[string]interface{}{"redemption_id": id, "user_id": redemption.UserID})
+	return redemption, nil
+}
+
+// Balance adds up the customer's unexpired lots in one query
+func (r *postgresRepository) Balance(ctx context.Context, userID string, at, horizon time.Time) (*Balance, error) {
+	b := &Balance{}
+	var expiringAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(SUM(remaining), 0),
+			COALESCE(SUM(remaining) FILTER (WHERE expires_at <= $3), 0),
+			MIN(expires_at)
+		FROM loyalty_ledger
+		WHERE user_id = $1 AND type = 'EARN' AND remaining > 0 AND expires_at > $2
+	`, userID, at, horizon).Scan(&b.Points, &b.ExpiringPoints, &expiringAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get balance", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	if expiringAt.Valid {
+		b.ExpiringAt = &expiringAt.Time
+	}
+	return b, nil
+}
+
+// Ledger retrieves a customer's entries with pagination
+func (r *postgresRepository) Ledger(ctx context.Context, userID string, page, pageSize int32) ([]*Entry, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM loyalty_ledger WHERE user_id = $1", userID).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count ledger entries", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to count ledger entries: %w", err)
+	}
+
+	query := "SELECT " + entryColumns + ` FROM loyalty_ledger
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list ledger entries", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*Entry, 0, pageSize)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan ledger entry", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating ledger entries", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating ledger entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// Expire zeroes lapsed lots and records their EXPIRE entries in one
+// transaction. Lots locked by a redemption in progress are left for the next
+// sweep.
+func (r *postgresRepository) Expire(ctx context.Context, at time.Time, limit int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, user_id, remaining FROM loyalty_ledger
+		WHERE type = 'EARN' AND remaining > 0 AND expires_at <= $1
+		ORDER BY expires_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, at, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock lapsed points: %w", err)
+	}
+	var lapsed []*Entry
+	for rows.Next() {
+		l := &Entry{}
+		if err := rows.Scan(&l.ID, &l.UserID, &l.Remaining); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan lapsed points: %w", err)
+		}
+		lapsed = append(lapsed, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating lapsed points: %w", err)
+	}
+
+	for _, l := range lapsed {
+		if _, err := tx.ExecContext(ctx, "UPDATE loyalty_ledger SET remaining = 0 WHERE id = $1", l.ID); err != nil {
+			return 0, fmt.Errorf("failed to expire points: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, insertEntry, uuid.New().String(), l.UserID, TypeExpire, -l.Remaining, l.ID, 0, 0, nil, at); err != nil {
+			return 0, fmt.Errorf("failed to record expiry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if len(lapsed) > 0 {
+		r.log.Info(ctx, "Points expired", map[string]interface{}{"lots": len(lapsed)})
+	}
+	return len(lapsed), nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry scans an entry selected with entryColumns
+func scanEntry(row rowScanner) (*Entry, error) {
+	e := &Entry{}
+	var expiresAt, releasedAt sql.NullTime
+
+	err := row.Scan(&e.ID, &e.UserID, &e.Type, &e.Points, &e.Reference, &e.Value, &e.Remaining,
+		&expiresAt, &releasedAt, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		e.ExpiresAt = &expiresAt.Time
+	}
+	if releasedAt.Valid {
+		e.ReleasedAt = &releasedAt.Time
+	}
+	return e, nil
+}
@@ -0,0 +1,263 @@
+package loyalty
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("loyalty-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var entryColumnNames = []string{
+	"id", "user_id", "type", "points", "reference", "value", "remaining", "expires_at", "released_at", "created_at",
+}
+
+func TestAward(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	expiresAt := now.Add(DefaultRules.Expiry)
+	mock.ExpectExec(`INSERT INTO loyalty_ledger .* ON CONFLICT \(reference\) WHERE type = 'EARN' DO NOTHING`).
+		WithArgs(sqlmock.AnyArg(), "user-1", TypeEarn, int64(40), "order-1", 0.0, int64(40), &expiresAt, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	entry, awarded, err := repo.Award(context.Background(), &Entry{
+		UserID: "user-1", Points: 40, Reference: "order-1", ExpiresAt: &expiresAt, CreatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !awarded || entry.ID == "" || entry.Remaining != 40 {
+		t.Errorf("Unexpected award %+v", entry)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAward_AlreadyAwarded(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	expiresAt := now.Add(DefaultRules.Expiry)
+	mock.ExpectExec(`INSERT INTO loyalty_ledger`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM loyalty_ledger WHERE reference = \$1 AND type = 'EARN'`).
+		WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).
+			AddRow("earn-1", "user-1", TypeEarn, 40, "order-1", 0.0, 25, expiresAt, nil, now))
+
+	entry, awarded, err := repo.Award(context.Background(), &Entry{
+		UserID: "user-1", Points: 40, Reference: "order-1", ExpiresAt: &expiresAt, CreatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if awarded || entry.ID != "earn-1" || entry.Remaining != 25 || entry.ExpiresAt == nil {
+		t.Errorf("Expected the existing award, got %+v", entry)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRedeem(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM loyalty_ledger WHERE reference = \$1 AND type = 'REDEEM' AND released_at IS NULL`).
+		WithArgs("order-2").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, remaining FROM loyalty_ledger\s+WHERE user_id = \$1 AND type = 'EARN' AND remaining > 0 AND expires_at > \$2\s+ORDER BY expires_at, id\s+FOR UPDATE`).
+		WithArgs("user-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining"}).AddRow("earn-1", 30).AddRow("earn-2", 100))
+	mock.ExpectExec(`INSERT INTO loyalty_ledger`).
+		WithArgs(sqlmock.AnyArg(), "user-1", TypeRedeem, int64(-50), "order-2", 0.5, 0, nil, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE loyalty_ledger SET remaining = remaining - \$2 WHERE id = \$1`).
+		WithArgs("earn-1", int64(30)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO loyalty_redemption_lots`).
+		WithArgs(sqlmock.AnyArg(), "earn-1", int64(30)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE loyalty_ledger SET remaining = remaining - \$2 WHERE id = \$1`).
+		WithArgs("earn-2", int64(20)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO loyalty_redemption_lots`).
+		WithArgs(sqlmock.AnyArg(), "earn-2", int64(20)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	redemption, err := repo.Redeem(context.Background(), &Entry{
+		UserID: "user-1", Points: -50, Reference: "order-2", Value: 0.5, CreatedAt: now,
+	}, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if redemption.ID == "" || redemption.Type != TypeRedeem {
+		t.Errorf("Unexpected redemption %+v", redemption)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRedeem_InsufficientPoints(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM loyalty_ledger WHERE reference = \$1`).WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, remaining FROM loyalty_ledger`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "remaining"}).AddRow("earn-1", 30))
+	mock.ExpectRollback()
+
+	_, err := repo.Redeem(context.Background(), &Entry{UserID: "user-1", Points: -50, Reference: "order-2", CreatedAt: now}, now)
+	if err != ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRedeem_ReferenceInUse(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM loyalty_ledger WHERE reference = \$1`).
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).
+			AddRow("redeem-1", "user-2", TypeRedeem, -50, "order-2", 0.5, 0, nil, nil, now))
+
+	_, err := repo.Redeem(context.Background(), &Entry{UserID: "user-1", Points: -50, Reference: "order-2", CreatedAt: now}, now)
+	if err != ErrReferenceInUse {
+		t.Errorf("Expected ErrReferenceInUse, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRelease(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM loyalty_ledger WHERE id = \$1 AND type = 'REDEEM' FOR UPDATE`).
+		WithArgs("redeem-1").
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).
+			AddRow("redeem-1", "user-1", TypeRedeem, -50, "order-2", 0.5, 0, nil, nil, now))
+	mock.ExpectExec(`UPDATE loyalty_ledger l SET remaining = l.remaining \+ rl.points\s+FROM loyalty_redemption_lots rl`).
+		WithArgs("redeem-1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE loyalty_ledger SET released_at = \$2 WHERE id = \$1`).
+		WithArgs("redeem-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO loyalty_ledger`).
+		WithArgs(sqlmock.AnyArg(), "user-1", TypeRelease, int64(50), "redeem-1", 0.5, 0, nil, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	redemption, err := repo.Release(context.Background(), "redeem-1", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if redemption.ReleasedAt == nil {
+		t.Error("Expected the redemption to be released")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRelease_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM loyalty_ledger WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+	mock.ExpectRollback()
+
+	_, err := repo.Release(context.Background(), "not-a-uuid", time.Now())
+	if err != ErrRedemptionNotFound {
+		t.Errorf("Expected ErrRedemptionNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestBalance(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	horizon := now.Add(ExpiryNotice)
+	expiringAt := now.Add(10 * 24 * time.Hour)
+	mock.ExpectQuery(`SELECT\s+COALESCE\(SUM\(remaining\), 0\),\s+COALESCE\(SUM\(remaining\) FILTER \(WHERE expires_at <= \$3\), 0\),\s+MIN\(expires_at\)`).
+		WithArgs("user-1", now, horizon).
+		WillReturnRows(sqlmock.NewRows([]string{"sum", "expiring", "min"}).AddRow(200, 120, expiringAt))
+
+	balance, err := repo.Balance(context.Background(), "user-1", now, horizon)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if balance.Points != 200 || balance.ExpiringPoints != 120 || balance.ExpiringAt == nil || !balance.ExpiringAt.Equal(expiringAt) {
+		t.Errorf("Unexpected balance %+v", balance)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, user_id, remaining FROM loyalty_ledger\s+WHERE type = 'EARN' AND remaining > 0 AND expires_at <= \$1\s+ORDER BY expires_at, id\s+LIMIT \$2\s+FOR UPDATE SKIP LOCKED`).
+		WithArgs(now, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "remaining"}).AddRow("earn-1", "user-1", 30))
+	mock.ExpectExec(`UPDATE loyalty_ledger SET remaining = 0 WHERE id = \$1`).
+		WithArgs("earn-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO loyalty_ledger`).
+		WithArgs(sqlmock.AnyArg(), "user-1", TypeExpire, int64(-30), "earn-1", 0, 0, nil, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	expired, err := repo.Expire(context.Background(), now, 500)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("Expected 1 lot expired, got %d", expired)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
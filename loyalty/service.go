@@ -0,0 +1,325 @@
+package loyalty
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the user_id and reference columns of the ledger
+	maxIDLength = 255
+	// maxAmount fits the DECIMAL(10, 2) value column
+	maxAmount = 99999999.99
+	// ExpiryNotice is how far ahead GetBalance reports expiring points
+	ExpiryNotice = 30 * 24 * time.Hour
+)
+
+// Rules set how points are earned, what they are worth and how long they last
+type Rules struct {
+	// PointsPerUnit is the points earned per currency unit paid
+	PointsPerUnit float64
+	// PointValue is what a point is worth at checkout
+	PointValue float64
+	// Expiry is how long points last after the order that earned them was paid
+	Expiry time.Duration
+}
+
+// DefaultRules earn a point per unit paid, worth a cent each, lasting a year
+var DefaultRules = Rules{PointsPerUnit: 1, PointValue: 0.01, Expiry: 365 * 24 * time.Hour}
+
+// AdminMethods are the admin-scoped RPCs of the loyalty service; they are
+// restricted to the admin network allowlist and to the roles of MethodRoles.
+// The order service awards points and checkout redeems them from inside it.
+var AdminMethods = []string{
+	pb.LoyaltyService_AwardPoints_FullMethodName,
+	pb.LoyaltyService_RedeemPoints_FullMethodName,
+	pb.LoyaltyService_ReleaseRedemption_FullMethodName,
+}
+
+// MethodRoles maps each of AdminMethods to the roles allowed to call it:
+// ADMIN tokens, and SERVICE tokens for the order service and checkout
+func MethodRoles() map[string][]string {
+	roles := make(map[string][]string, len(AdminMethods))
+	for _, m := range AdminMethods {
+		roles[m] = []string{auth.RoleAdmin, auth.RoleService}
+	}
+	return roles
+}
+
+// Service implements the LoyaltyService gRPC interface
+type Service struct {
+	pb.UnimplementedLoyaltyServiceServer
+	repo  Repository
+	rules Rules
+	log   *logger.Logger
+	now   func() time.Time
+}
+
+// NewService creates a new loyalty service
+func NewService(repo Repository, rules Rules, log *logger.Logger) *Service {
+	return &Service{
+		repo:  repo,
+		rules: rules,
+		log:   log,
+		now:   time.Now,
+	}
+}
+
+// AwardPoints credits a customer with points for a paid order
+func (s *Service) AwardPoints(ctx context.Context, req *pb.AwardPointsRequest) (*pb.AwardPointsResponse, error) {
+	if msg := awardProblem(req); msg != "" {
+		s.log.Warn(ctx, "Award points failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	// Points paid for part of the order earn nothing
+	redeemed, err := s.repo.RedeemedValue(ctx, req.OrderId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to award points", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Internal, "failed to award points")
+	}
+	points := s.pointsFor(req.Amount - redeemed)
+	if points <= 0 {
+		return &pb.AwardPointsResponse{}, nil
+	}
+
+	paidAt := s.now()
+	if req.PaidAt != nil {
+		paidAt = req.PaidAt.AsTime()
+	}
+	expiresAt := paidAt.Add(s.rules.Expiry)
+	entry, awarded, err := s.repo.Award(ctx, &Entry{
+		UserID:    req.UserId,
+		Points:    points,
+		Reference: req.OrderId,
+		ExpiresAt: &expiresAt,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to award points", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Internal, "failed to award points")
+	}
+
+	return &pb.AwardPointsResponse{
+		Entry:   toProtoEntry(entry),
+		Awarded: awarded,
+	}, nil
+}
+
+// awardProblem validates an award and returns a message describing the first
+// problem, or ""
+func awardProblem(req *pb.AwardPointsRequest) string {
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case len(req.OrderId) > maxIDLength:
+		return "order_id is too long"
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return "user_id is too long"
+	case !isAmount(req.Amount):
+		return "amount must be an amount between 0 and 99999999.99"
+	}
+	return ""
+}
+
+// RedeemPoints spends a customer's points on an order
+func (s *Service) RedeemPoints(ctx context.Context, req *pb.RedeemPointsRequest) (*pb.RedeemPointsResponse, error) {
+	if msg := redeemProblem(req); msg != "" {
+		s.log.Warn(ctx, "Redeem points failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	// Points pay for part of an order, so a payment is still authorized for
+	// the rest
+	value := s.valueOf(req.Points)
+	if value >= req.OrderTotal {
+		s.log.Info(ctx, "Points not redeemed: worth the whole order", map[string]interface{}{"order_id": req.OrderId, "points": req.Points})
+		return nil, status.Error(codes.FailedPrecondition, "points must be worth less than the order total")
+	}
+
+	redemption, err := s.repo.Redeem(ctx, &Entry{
+		UserID:    req.UserId,
+		Points:    -req.Points,
+		Reference: req.OrderId,
+		Value:     value,
+		CreatedAt: s.now(),
+	}, s.now())
+	switch {
+	case errors.Is(err, ErrInsufficientPoints):
+		s.log.Info(ctx, "Points not redeemed: insufficient points", map[string]interface{}{"user_id": req.UserId, "points": req.Points})
+		return nil, status.Error(codes.FailedPrecondition, "insufficient points")
+	case errors.Is(err, ErrReferenceInUse):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		s.log.Error(ctx, "Failed to redeem points", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Internal, "failed to redeem points")
+	}
+
+	return &pb.RedeemPointsResponse{
+		Redemption: toProtoEntry(redemption),
+	}, nil
+}
+
+// redeemProblem validates a redemption and returns a message describing the
+// first problem, or ""
+func redeemProblem(req *pb.RedeemPointsRequest) string {
+	switch {
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return "user_id is too long"
+	case req.OrderId == "":
+		return "order_id is required"
+	case len(req.OrderId) > maxIDLength:
+		return "order_id is too long"
+	case req.Points <= 0:
+		return "points must be positive"
+	case req.OrderTotal <= 0 || req.OrderTotal > maxAmount:
+		return "order_total must be an amount above 0 and at most 99999999.99"
+	}
+	return ""
+}
+
+// ReleaseRedemption gives back the points of a redemption whose checkout was
+// undone
+func (s *Service) ReleaseRedemption(ctx context.Context, req *pb.ReleaseRedemptionRequest) (*pb.ReleaseRedemptionResponse, error) {
+	if req.RedemptionId == "" {
+		s.log.Warn(ctx, "Release redemption failed: redemption ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "redemption_id is required")
+	}
+
+	redemption, err := s.repo.Release(ctx, req.RedemptionId, s.now())
+	if errors.Is(err, ErrRedemptionNotFound) {
+		return nil, status.Error(codes.NotFound, "redemption not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to release redemption", map[string]interface{}{"error": err.Error(), "redemption_id": req.RedemptionId})
+		return nil, status.Error(codes.Internal, "failed to release redemption")
+	}
+
+	return &pb.ReleaseRedemptionResponse{
+		Redemption: toProtoEntry(redemption),
+	}, nil
+}
+
+// GetBalance returns the points a customer can redeem
+func (s *Service) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.GetBalanceResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Get balance failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	now := s.now()
+	balance, err := s.repo.Balance(ctx, req.UserId, now, now.Add(ExpiryNotice))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get balance")
+	}
+
+	resp := &pb.GetBalanceResponse{
+		UserId:         req.UserId,
+		Points:         balance.Points,
+		Value:          s.valueOf(balance.Points),
+		ExpiringPoints: balance.ExpiringPoints,
+	}
+	if balance.ExpiringAt != nil {
+		resp.ExpiringAt = timestamppb.New(*balance.ExpiringAt)
+	}
+	return resp, nil
+}
+
+// GetLedger lists a customer's ledger, newest first
+func (s *Service) GetLedger(ctx context.Context, req *pb.GetLedgerRequest) (*pb.GetLedgerResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Get ledger failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	entries, total, err := s.repo.Ledger(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get ledger")
+	}
+
+	protoEntries := make([]*pb.LedgerEntry, len(entries))
+	for i, e := range entries {
+		protoEntries[i] = toProtoEntry(e)
+	}
+
+	return &pb.GetLedgerResponse{
+		Entries:  protoEntries,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// userProblem validates a customer ID and returns a message describing the
+// problem, or ""
+func userProblem(userID string) string {
+	switch {
+	case userID == "":
+		return "user_id is required"
+	case len(userID) > maxIDLength:
+		return "user_id is too long"
+	}
+	return ""
+}
+
+// pointsFor returns the whole points earned on an amount
+func (s *Service) pointsFor(amount float64) int64 {
+	// Rounding to cents first keeps 19.99 * 100 from earning 1998 points
+	return int64(math.Floor(math.Round(amount*100) / 100 * s.rules.PointsPerUnit))
+}
+
+// valueOf returns what points are worth at checkout, in whole cents
+func (s *Service) valueOf(points int64) float64 {
+	return math.Floor(float64(points)*s.rules.PointValue*100+1e-9) / 100
+}
+
+// isAmount reports whether v fits the amount columns
+func isAmount(v float64) bool {
+	return v >= 0 && v <= maxAmount
+}
+
+// toProtoEntry converts a ledger entry to protobuf
+func toProtoEntry(e *Entry) *pb.LedgerEntry {
+	entry := &pb.LedgerEntry{
+		Id:        e.ID,
+		UserId:    e.UserID,
+		Type:      e.Type,
+		Points:    e.Points,
+		Reference: e.Reference,
+		Value:     e.Value,
+		Remaining: e.Remaining,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.ExpiresAt != nil {
+		entry.ExpiresAt = timestamppb.New(*e.ExpiresAt)
+	}
+	if e.ReleasedAt != nil {
+		entry.ReleasedAt = timestamppb.New(*e.ReleasedAt)
+	}
+	return entry
+}
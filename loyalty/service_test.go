@@ -0,0 +1,529 @@
+package loyalty
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps the points ledger and spends the soonest-expiring
+// lots first, recording what each redemption took so it can be released
+type memoryRepository struct {
+	mu      sync.Mutex
+	entries []*Entry
+	// spent maps a redemption to the points it took from each lot
+	spent map[string]map[string]int64
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{spent: map[string]map[string]int64{}}
+}
+
+func (m *memoryRepository) find(match func(e *Entry) bool) *Entry {
+	for _, e := range m.entries {
+		if match(e) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) Award(ctx context.Context, entry *Entry) (*Entry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e := m.find(func(e *Entry) bool { return e.Type == TypeEarn && e.Reference == entry.Reference }); e != nil {
+		existing := *e
+		return &existing, false, nil
+	}
+	entry.ID = uuid.New().String()
+	entry.Type = TypeEarn
+	entry.Remaining = entry.Points
+	stored := *entry
+	m.entries = append(m.entries, &stored)
+	return entry, true, nil
+}
+
+func (m *memoryRepository) unreleased(orderID string) *Entry {
+	return m.find(func(e *Entry) bool { return e.Type == TypeRedeem && e.Reference == orderID && e.ReleasedAt == nil })
+}
+
+func (m *memoryRepository) RedeemedValue(ctx context.Context, orderID string) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e := m.unreleased(orderID); e != nil {
+		return e.Value, nil
+	}
+	return 0, nil
+}
+
+// lots returns the customer's unexpired lots, soonest-expiring first
+func (m *memoryRepository) lots(userID string, at time.Time) []*Entry {
+	var lots []*Entry
+	for _, e := range m.entries {
+		if e.UserID == userID && e.Type == TypeEarn && e.Remaining > 0 && e.ExpiresAt.After(at) {
+			lots = append(lots, e)
+		}
+	}
+	sort.SliceStable(lots, func(i, j int) bool { return lots[i].ExpiresAt.Before(*lots[j].ExpiresAt) })
+	return lots
+}
+
+func (m *memoryRepository) Redeem(ctx context.Context, redemption *Entry, at time.Time) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e := m.unreleased(redemption.Reference); e != nil {
+		if e.UserID != redemption.UserID {
+			return nil, ErrReferenceInUse
+		}
+		existing := *e
+		return &existing, nil
+	}
+
+	spent := map[string]int64{}
+	needed := -redemption.Points
+	for _, lot := range m.lots(redemption.UserID, at) {
+		if needed == 0 {
+			break
+		}
+		spent[lot.ID] = min(lot.Remaining, needed)
+		needed -= spent[lot.ID]
+	}
+	if needed > 0 {
+		return nil, ErrInsufficientPoints
+	}
+	for id, points := range spent {
+		m.find(func(e *Entry) bool { return e.ID == id }).Remaining -= points
+	}
+
+	redemption.ID = uuid.New().String()
+	redemption.Type = TypeRedeem
+	m.spent[redemption.ID] = spent
+	stored := *redemption
+	m.entries = append(m.entries, &stored)
+	return redemption, nil
+}
+
+func (m *memoryRepository) Release(ctx context.Context, id string, at time.Time) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	redemption := m.find(func(e *Entry) bool { return e.ID == id && e.Type == TypeRedeem })
+	if redemption == nil {
+		return nil, ErrRedemptionNotFound
+	}
+	if redemption.ReleasedAt == nil {
+		for lotID, points := range m.spent[id] {
+			m.find(func(e *Entry) bool { return e.ID == lotID }).Remaining += points
+		}
+		redemption.ReleasedAt = &at
+		m.entries = append(m.entries, &Entry{
+			ID:        uuid.New().String(),
+			UserID:    redemption.UserID,
+			Type:      TypeRelease,
+			Points:    -redemption.Points,
+			Reference: id,
+			Value:     redemption.Value,
+			CreatedAt: at,
+		})
+	}
+	released := *redemption
+	return &released, nil
+}
+
+func (m *memoryRepository) Balance(ctx context.Context, userID string, at, horizon time.Time) (*Balance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b := &Balance{}
+	for _, lot := range m.lots(userID, at) {
+		b.Points += lot.Remaining
+		if !lot.ExpiresAt.After(horizon) {
+			b.ExpiringPoints += lot.Remaining
+		}
+		if b.ExpiringAt == nil {
+			b.ExpiringAt = lot.ExpiresAt
+		}
+	}
+	return b, nil
+}
+
+func (m *memoryRepository) Ledger(ctx context.Context, userID string, page, pageSize int32) ([]*Entry, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []*Entry
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		if e := *m.entries[i]; e.UserID == userID {
+			entries = append(entries, &e)
+		}
+	}
+
+	total := int32(len(entries))
+	start := int((page - 1) * pageSize)
+	if start > len(entries) {
+		start = len(entries)
+	}
+	end := start + int(pageSize)
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[start:end], total, nil
+}
+
+func (m *memoryRepository) Expire(ctx context.Context, at time.Time, limit int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expired := 0
+	for _, lot := range append([]*Entry{}, m.entries...) {
+		if expired == limit {
+			break
+		}
+		if lot.Type != TypeEarn || lot.Remaining == 0 || lot.ExpiresAt.After(at) {
+			continue
+		}
+		m.entries = append(m.entries, &Entry{
+			ID:        uuid.New().String(),
+			UserID:    lot.UserID,
+			Type:      TypeExpire,
+			Points:    -lot.Remaining,
+			Reference: lot.ID,
+			CreatedAt: at,
+		})
+		lot.Remaining = 0
+		expired++
+	}
+	return expired, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo: newMemoryRepository(),
+		now:  time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(f.repo, DefaultRules, logger.New("loyalty-test"))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *serviceFixture) award(t *testing.T, orderID string, amount float64) *pb.AwardPointsResponse {
+	t.Helper()
+	resp, err := f.service.AwardPoints(context.Background(), &pb.AwardPointsRequest{
+		OrderId: orderID,
+		UserId:  "user-1",
+		Amount:  amount,
+		PaidAt:  timestamppb.New(f.now),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp
+}
+
+func (f *serviceFixture) balance(t *testing.T) *pb.GetBalanceResponse {
+	t.Helper()
+	resp, err := f.service.GetBalance(context.Background(), &pb.GetBalanceRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp
+}
+
+func TestAwardPoints(t *testing.T) {
+	f := setupService()
+
+	resp := f.award(t, "order-1", 19.99)
+	if !resp.Awarded || resp.Entry.Points != 19 || resp.Entry.Remaining != 19 || resp.Entry.Type != TypeEarn {
+		t.Errorf("Unexpected award %v", resp)
+	}
+	if !resp.Entry.ExpiresAt.AsTime().Equal(f.now.Add(DefaultRules.Expiry)) {
+		t.Errorf("Expected the points to expire a year after payment, got %v", resp.Entry.ExpiresAt.AsTime())
+	}
+
+	// Awarding the order again returns the first award
+	again := f.award(t, "order-1", 19.99)
+	if again.Awarded || again.Entry.Id != resp.Entry.Id {
+		t.Errorf("Expected the existing award, got %v", again)
+	}
+
+	// Less than a unit earns nothing
+	if resp := f.award(t, "order-2", 0.5); resp.Awarded || resp.Entry != nil {
+		t.Errorf("Expected no points, got %v", resp)
+	}
+}
+
+func TestAwardPoints_ExcludesRedeemedValue(t *testing.T) {
+	f := setupService()
+	f.award(t, "order-1", 500)
+
+	_, err := f.service.RedeemPoints(context.Background(), &pb.RedeemPointsRequest{
+		UserId: "user-1", Points: 500, OrderId: "order-2", OrderTotal: 30,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 5.00 of the 30.00 order was paid with points
+	if resp := f.award(t, "order-2", 30); resp.Entry.Points != 25 {
+		t.Errorf("Expected 25 points, got %v", resp.Entry)
+	}
+}
+
+func TestAwardPoints_InvalidRequest(t *testing.T) {
+	f := setupService()
+	tests := []struct {
+		name string
+		req  *pb.AwardPointsRequest
+	}{
+		{"missing order", &pb.AwardPointsRequest{UserId: "user-1", Amount: 10}},
+		{"missing user", &pb.AwardPointsRequest{OrderId: "order-1", Amount: 10}},
+		{"negative amount", &pb.AwardPointsRequest{OrderId: "order-1", UserId: "user-1", Amount: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.AwardPoints(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRedeemPoints(t *testing.T) {
+	f := setupService()
+	f.award(t, "order-1", 300)
+	f.now = f.now.Add(24 * time.Hour)
+	f.award(t, "order-2", 400)
+
+	req := &pb.RedeemPointsRequest{UserId: "user-1", Points: 450, OrderId: "order-3", OrderTotal: 20}
+	resp, err := f.service.RedeemPoints(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if r := resp.Redemption; r.Type != TypeRedeem || r.Points != -450 || r.Value != 4.5 || r.Reference != "order-3" {
+		t.Errorf("Unexpected redemption %v", r)
+	}
+
+	// The soonest-expiring points are spent first
+	lots := f.repo.lots("user-1", f.now)
+	if len(lots) != 1 || lots[0].Reference != "order-2" || lots[0].Remaining != 250 {
+		t.Errorf("Expected 250 points left on order-2, got %v", lots)
+	}
+
+	// A retried checkout gets the same redemption
+	again, err := f.service.RedeemPoints(context.Background(), req)
+	if err != nil || again.Redemption.Id != resp.Redemption.Id {
+		t.Errorf("Expected the existing redemption, got %v (%v)", again, err)
+	}
+	if b := f.balance(t); b.Points != 250 {
+		t.Errorf("Expected 250 points, got %d", b.Points)
+	}
+}
+
+func TestRedeemPoints_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *pb.RedeemPointsRequest
+		expected codes.Code
+		message  string
+	}{
+		{
+			name:     "insufficient points",
+			req:      &pb.RedeemPointsRequest{UserId: "user-1", Points: 101, OrderId: "order-2", OrderTotal: 20},
+			expected: codes.FailedPrecondition,
+			message:  "insufficient points",
+		},
+		{
+			name:     "worth the whole order",
+			req:      &pb.RedeemPointsRequest{UserId: "user-1", Points: 100, OrderId: "order-2", OrderTotal: 1},
+			expected: codes.FailedPrecondition,
+			message:  "points must be worth less than the order total",
+		},
+		{
+			name:     "no points",
+			req:      &pb.RedeemPointsRequest{UserId: "user-1", OrderId: "order-2", OrderTotal: 20},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "missing order",
+			req:      &pb.RedeemPointsRequest{UserId: "user-1", Points: 10, OrderTotal: 20},
+			expected: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService()
+			f.award(t, "order-1", 100)
+
+			_, err := f.service.RedeemPoints(context.Background(), tt.req)
+			st := status.Convert(err)
+			if st.Code() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if tt.message != "" && st.Message() != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, st.Message())
+			}
+		})
+	}
+}
+
+func TestRedeemPoints_ExpiredPoints(t *testing.T) {
+	f := setupService()
+	f.award(t, "order-1", 100)
+	f.now = f.now.Add(DefaultRules.Expiry)
+
+	_, err := f.service.RedeemPoints(context.Background(), &pb.RedeemPointsRequest{
+		UserId: "user-1", Points: 50, OrderId: "order-2", OrderTotal: 20,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected lapsed points not to be redeemable, got %v", err)
+	}
+}
+
+func TestReleaseRedemption(t *testing.T) {
+	f := setupService()
+	f.award(t, "order-1", 100)
+	redeemed, err := f.service.RedeemPoints(context.Background(), &pb.RedeemPointsRequest{
+		UserId: "user-1", Points: 60, OrderId: "order-2", OrderTotal: 20,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := f.service.ReleaseRedemption(context.Background(), &pb.ReleaseRedemptionRequest{RedemptionId: redeemed.Redemption.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Redemption.ReleasedAt == nil {
+		t.Errorf("Expected the redemption to be released, got %v", resp.Redemption)
+	}
+	if b := f.balance(t); b.Points != 100 {
+		t.Errorf("Expected the points back, got %d", b.Points)
+	}
+
+	// Releasing again changes nothing
+	if _, err := f.service.ReleaseRedemption(context.Background(), &pb.ReleaseRedemptionRequest{RedemptionId: redeemed.Redemption.Id}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if b := f.balance(t); b.Points != 100 {
+		t.Errorf("Expected 100 points, got %d", b.Points)
+	}
+
+	_, err = f.service.ReleaseRedemption(context.Background(), &pb.ReleaseRedemptionRequest{RedemptionId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	f := setupService()
+	if b := f.balance(t); b.Points != 0 || b.ExpiringAt != nil {
+		t.Errorf("Expected an empty balance, got %v", b)
+	}
+
+	first := f.now
+	f.award(t, "order-1", 120)
+	f.now = f.now.Add(60 * 24 * time.Hour)
+	f.award(t, "order-2", 80)
+
+	// The first order's points expire within 30 days
+	f.now = first.Add(DefaultRules.Expiry - 10*24*time.Hour)
+	b := f.balance(t)
+	if b.Points != 200 || b.Value != 2 || b.ExpiringPoints != 120 || !b.ExpiringAt.AsTime().Equal(first.Add(DefaultRules.Expiry)) {
+		t.Errorf("Unexpected balance %v", b)
+	}
+
+	// Lapsed points no longer count
+	f.now = first.Add(DefaultRules.Expiry)
+	if b := f.balance(t); b.Points != 80 || b.ExpiringPoints != 0 {
+		t.Errorf("Unexpected balance %v", b)
+	}
+}
+
+func TestGetLedger(t *testing.T) {
+	f := setupService()
+	f.award(t, "order-1", 100)
+	f.now = f.now.Add(time.Hour)
+	f.service.RedeemPoints(context.Background(), &pb.RedeemPointsRequest{UserId: "user-1", Points: 40, OrderId: "order-2", OrderTotal: 20})
+
+	resp, err := f.service.GetLedger(context.Background(), &pb.GetLedgerRequest{UserId: "user-1", PageSize: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || resp.PageSize != 100 || resp.Page != 1 {
+		t.Errorf("Unexpected paging %v", resp)
+	}
+	if len(resp.Entries) != 2 || resp.Entries[0].Type != TypeRedeem || resp.Entries[1].Type != TypeEarn {
+		t.Errorf("Expected the newest entry first, got %v", resp.Entries)
+	}
+
+	_, err = f.service.GetLedger(context.Background(), &pb.GetLedgerRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestPointsExpirer(t *testing.T) {
+	f := setupService()
+	f.award(t, "order-1", 100)
+	f.now = f.now.Add(24 * time.Hour)
+	f.award(t, "order-2", 50)
+
+	expirer := NewPointsExpirer(f.repo, logger.New("loyalty-test"))
+	expirer.now = func() time.Time { return f.now.Add(DefaultRules.Expiry - time.Hour) }
+	if err := expirer.ExpireLapsed(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entries, _, _ := f.repo.Ledger(context.Background(), "user-1", 1, 10)
+	if len(entries) != 3 || entries[0].Type != TypeExpire || entries[0].Points != -100 {
+		t.Errorf("Expected the first order's points to expire, got %v", entries)
+	}
+}
+
+func TestMethodRoles(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", 15*time.Minute, time.Hour)
+	interceptor := auth.NewInterceptor(tokens, auth.InterceptorConfig{ExemptMethods: []string{"/"}, RequiredRoles: MethodRoles()})
+	withToken := func(role string) context.Context {
+		token, _ := tokens.GenerateAccessToken("caller-1", "", role)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+
+	for _, method := range AdminMethods {
+		tests := []struct {
+			name     string
+			ctx      context.Context
+			expected codes.Code
+		}{
+			{"no token", context.Background(), codes.Unauthenticated},
+			{"customer", withToken(auth.RoleUser), codes.PermissionDenied},
+			{"service", withToken(auth.RoleService), codes.OK},
+			{"admin", withToken(auth.RoleAdmin), codes.OK},
+		}
+		for _, tt := range tests {
+			_, err := interceptor.Authenticate(tt.ctx, method)
+			if status.Code(err) != tt.expected {
+				t.Errorf("%s by %s: expected %v, got %v", method, tt.name, tt.expected, err)
+			}
+		}
+	}
+}
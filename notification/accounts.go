@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUnknownUser is returned when a user has no account
+var ErrUnknownUser = errors.New("unknown user")
+
+// Contact is how a user is addressed and reached
+type Contact struct {
+	ID    string
+	Name  string
+	Email string
+	Phone string
+}
+
+// Accounts looks up users' contact details
+type Accounts interface {
+	// GetContact returns a user's contact details, or ErrUnknownUser
+	GetContact(ctx context.Context, userID string) (*Contact, error)
+}
+
+type grpcAccounts struct {
+	client accountpb.AccountServiceClient
+}
+
+// NewGRPCAccounts creates Accounts backed by the account service
+func NewGRPCAccounts(client accountpb.AccountServiceClient) Accounts {
+	return &grpcAccounts{client: client}
+}
+
+func (a *grpcAccounts) GetContact(ctx context.Context, userID string) (*Contact, error) {
+	resp, err := a.client.GetProfile(ctx, &accountpb.GetProfileRequest{UserId: userID})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrUnknownUser
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	return &Contact{
+		ID:    resp.User.Id,
+		Name:  resp.User.Name,
+		Email: resp.User.Email,
+		Phone: resp.User.Phone,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// maxAttempts is how many times a notification is tried before it is FAILED
+	maxAttempts = 5
+	// retryBackoff is the wait after the first failed attempt; it doubles
+	// with each further attempt
+	retryBackoff = time.Minute
+	// claimLease is how long a claimed notification is left to its dispatcher
+	// before another may claim it; it outlasts a send
+	claimLease = 2 * time.Minute
+	// dispatchBatchSize is how many due notifications are claimed per pass
+	dispatchBatchSize = 100
+)
+
+// Dispatcher sends notifications through the senders of their channel and
+// records each attempt. Failed sends are retried with exponential backoff
+// until maxAttempts, except those the provider rejected. Notifications that
+// fail for good are dead letters, left for an operator to retry or discard.
+type Dispatcher struct {
+	repo    Repository
+	senders map[string]Sender
+	log     *logger.Logger
+	now     func() time.Time
+}
+
+// NewDispatcher creates a dispatcher for the channels of senders. When a
+// channel has several senders, they are tried in the order given, failing
+// over to the next when a send fails.
+func NewDispatcher(repo Repository, senders []Sender, log *logger.Logger) *Dispatcher {
+	byChannel := make(map[string][]Sender)
+	for _, s := range senders {
+		byChannel[s.Channel()] = append(byChannel[s.Channel()], s)
+	}
+
+	d := &Dispatcher{repo: repo, senders: make(map[string]Sender, len(byChannel)), log: log, now: time.Now}
+	for channel, channelSenders := range byChannel {
+		d.senders[channel] = NewFailoverSender(channelSenders...)
+	}
+	return d
+}
+
+// Supports reports whether the dispatcher has a sender for channel
+func (d *Dispatcher) Supports(channel string) bool {
+	_, ok := d.senders[channel]
+	return ok
+}
+
+// Deliver sends a notification and records the attempt on n. The error is
+// only for failing to record the attempt; a failed send is recorded on n.
+func (d *Dispatcher) Deliver(ctx context.Context, n *Notification) error {
+	fields := map[string]interface{}{"notification_id": n.ID, "channel": n.Channel, "event_id": n.EventID}
+
+	var err error
+	if sender, ok := d.senders[n.Channel]; ok {
+		err = sender.Send(ctx, Message{Recipient: n.Recipient, Subject: n.Subject, Body: n.Body})
+	} else {
+		err = errors.New("no sender for channel")
+	}
+
+	now := d.now()
+	n.Attempts++
+	switch {
+	case err == nil:
+		n.Status, n.LastError, n.SentAt, n.NextAttemptAt = StatusSent, "", &now, nil
+		d.log.Info(ctx, "Notification sent", fields)
+	case errors.Is(err, ErrRejected) || n.Attempts >= maxAttempts:
+		n.Status, n.LastError, n.NextAttemptAt, n.FailedAt = StatusFailed, err.Error(), nil, &now
+		fields["error"] = err.Error()
+		fields["attempts"] = n.Attempts
+		d.log.Error(ctx, "Notification failed", fields)
+	default:
+		next := now.Add(retryBackoff << (n.Attempts - 1))
+		n.Status, n.LastError, n.NextAttemptAt = StatusPending, err.Error(), &next
+		fields["error"] = err.Error()
+		fields["attempts"] = n.Attempts
+		d.log.Warn(ctx, "Notification send failed, will retry", fields)
+	}
+
+	// The send happened; record it even if the caller has gone away
+	return d.repo.RecordAttempt(context.WithoutCancel(ctx), n)
+}
+
+// DeliverDue sends the notifications that are due and returns how many were
+// attempted
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	due, err := d.repo.ClaimDue(ctx, d.now(), claimLease, dispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i, n := range due {
+		if err := d.Deliver(ctx, n); err != nil {
+			return i, err
+		}
+	}
+	return len(due), nil
+}
+
+// Run sends due notifications every interval until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := d.DeliverDue(ctx); err != nil {
+				d.log.Error(ctx, "Failed to deliver due notifications", map[string]interface{}{"error": err.Error(), "delivered": n})
+			}
+		}
+	}
+}
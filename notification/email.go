@@ -0,0 +1,250 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SMTPConfig holds the settings of the SMTP email sender
+type SMTPConfig struct {
+	// Addr is the host:port of the SMTP server
+	Addr string
+	// Username and Password authenticate with PLAIN auth when set
+	Username string
+	Password string
+	// From is the sender address, e.g. "Shop <no-reply@example.com>"
+	From    string
+	Timeout time.Duration
+}
+
+// SMTPSender sends email through an SMTP server, upgrading the connection
+// with STARTTLS when the server offers it
+type SMTPSender struct {
+	cfg  SMTPConfig
+	from *mail.Address
+	host string
+}
+
+// NewSMTPSender creates a new SMTP email sender
+func NewSMTPSender(cfg SMTPConfig) (*SMTPSender, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("smtp: invalid address %q: %w", cfg.Addr, err)
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("smtp: invalid from address %q: %w", cfg.From, err)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &SMTPSender{cfg: cfg, from: from, host: host}, nil
+}
+
+// Channel returns EMAIL
+func (s *SMTPSender) Channel() string {
+	return ChannelEmail
+}
+
+// Send sends a plain text email
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	to, err := mail.ParseAddress(msg.Recipient)
+	if err != nil {
+		return fmt.Errorf("%w: invalid email address", ErrRejected)
+	}
+
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("smtp: dial failed: %w", err)
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp: handshake failed: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("smtp: starttls failed: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.host)); err != nil {
+			return fmt.Errorf("smtp: auth failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("smtp: MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		// 5xx replies are permanent, e.g. 550 for a mailbox that does not exist
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code >= 500 {
+			return fmt.Errorf("%w: smtp: %v", ErrRejected, err)
+		}
+		return fmt.Errorf("smtp: RCPT TO failed: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp: DATA failed: %w", err)
+	}
+	if _, err := w.Write(s.compose(to, msg)); err != nil {
+		return fmt.Errorf("smtp: write failed: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp: message not accepted: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose builds the email with its headers
+func (s *SMTPSender) compose(to *mail.Address, msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.from.String() + "\r\n")
+	b.WriteString("To: " + to.String() + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("Message-ID: <" + uuid.New().String() + "@" + s.host + ">\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// SendGridConfig holds the settings of the SendGrid email sender
+type SendGridConfig struct {
+	APIKey string
+	// From is the sender address, e.g. "Shop <no-reply@example.com>"
+	From string
+	// BaseURL defaults to https://api.sendgrid.com
+	BaseURL string
+	Timeout time.Duration
+}
+
+// SendGridSender sends email through the SendGrid Mail Send API
+type SendGridSender struct {
+	cfg        SendGridConfig
+	from       *mail.Address
+	httpClient *http.Client
+}
+
+// NewSendGridSender creates a new SendGrid email sender
+func NewSendGridSender(cfg SendGridConfig) (*SendGridSender, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("sendgrid: API key is required")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("sendgrid: invalid from address %q: %w", cfg.From, err)
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.sendgrid.com"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &SendGridSender{
+		cfg:        cfg,
+		from:       from,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Channel returns EMAIL
+func (s *SendGridSender) Channel() string {
+	return ChannelEmail
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+type sendGridErrors struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Send sends a plain text email
+func (s *SendGridSender) Send(ctx context.Context, msg Message) error {
+	to, err := mail.ParseAddress(msg.Recipient)
+	if err != nil {
+		return fmt.Errorf("%w: invalid email address", ErrRejected)
+	}
+
+	body, err := json.Marshal(sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: to.Address, Name: to.Name}}}},
+		From:             sendGridAddress{Email: s.from.Address, Name: s.from.Name},
+		Subject:          msg.Subject,
+		Content:          []sendGridContent{{Type: "text/plain", Value: msg.Body}},
+	})
+	if err != nil {
+		return fmt.Errorf("sendgrid: failed to encode message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+
+	code, respBody, err := send(s.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("sendgrid: request failed: %w", err)
+	}
+	if code != http.StatusAccepted && code != http.StatusOK {
+		var decoded sendGridErrors
+		_ = json.Unmarshal(respBody, &decoded)
+		detail := ""
+		if len(decoded.Errors) > 0 {
+			detail = decoded.Errors[0].Message
+		}
+		return statusError("sendgrid", code, detail)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_notifications_dead_letters;
+UPDATE notifications SET status = 'FAILED' WHERE status = 'DISCARDED';
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_status_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_status_check
+    CHECK (status IN ('PENDING', 'SENT', 'FAILED'));
+ALTER TABLE notifications DROP COLUMN IF EXISTS failed_at;
@@ -0,0 +1,12 @@
+-- Notifications that FAILED for good are dead letters: they stay FAILED until
+-- an operator requeues them or marks them DISCARDED. failed_at orders the
+-- dead-letter view.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;
+
+UPDATE notifications SET failed_at = created_at WHERE status = 'FAILED' AND failed_at IS NULL;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_status_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_status_check
+    CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'DISCARDED'));
+
+CREATE INDEX IF NOT EXISTS idx_notifications_dead_letters ON notifications(failed_at DESC) WHERE status = 'FAILED';
@@ -0,0 +1,109 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// FCMConfig holds the settings of the Firebase Cloud Messaging push sender
+type FCMConfig struct {
+	// ServerKey authorizes requests to the FCM HTTP API
+	ServerKey string
+	// BaseURL defaults to https://fcm.googleapis.com
+	BaseURL string
+	Timeout time.Duration
+}
+
+// FCMSender sends push notifications to device tokens through the FCM HTTP API
+type FCMSender struct {
+	cfg        FCMConfig
+	httpClient *http.Client
+}
+
+// NewFCMSender creates a new FCM push sender
+func NewFCMSender(cfg FCMConfig) (*FCMSender, error) {
+	if cfg.ServerKey == "" {
+		return nil, errors.New("fcm: server key is required")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://fcm.googleapis.com"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &FCMSender{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Channel returns PUSH
+func (s *FCMSender) Channel() string {
+	return ChannelPush
+}
+
+type fcmRequest struct {
+	To           string `json:"to"`
+	Notification struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	} `json:"notification"`
+}
+
+type fcmResponse struct {
+	Failure int `json:"failure"`
+	Results []struct {
+		Error string `json:"error"`
+	} `json:"results"`
+}
+
+// fcmRetryable are the per-message FCM errors worth retrying
+var fcmRetryable = map[string]bool{"Unavailable": true, "InternalServerError": true, "DeviceMessageRateExceeded": true}
+
+// Send sends the message to the recipient device token, with the subject as
+// the notification's title
+func (s *FCMSender) Send(ctx context.Context, msg Message) error {
+	var payload fcmRequest
+	payload.To = msg.Recipient
+	payload.Notification.Title = msg.Subject
+	payload.Notification.Body = msg.Body
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/fcm/send", bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "key="+s.cfg.ServerKey)
+
+	code, body, err := send(s.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("fcm: request failed: %w", err)
+	}
+	if code != http.StatusOK {
+		return statusError("fcm", code, string(bytes.TrimSpace(body)))
+	}
+
+	// FCM answers 200 and reports per-message errors in the results
+	var decoded fcmResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return fmt.Errorf("fcm: invalid response: %w", err)
+	}
+	if decoded.Failure == 0 || len(decoded.Results) == 0 {
+		return nil
+	}
+	reason := decoded.Results[0].Error
+	if fcmRetryable[reason] {
+		return fmt.Errorf("fcm: %s", reason)
+	}
+	return fmt.Errorf("%w: fcm: %s", ErrRejected, reason)
+}
@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/readstate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ReadStatePrefix namespaces the read state keys of the notification service
+const ReadStatePrefix = "notification:readstate:"
+
+// ReadState keeps the read marks of users together with the notifications
+// they were sent, for unread counts. readstate.RedisStore implements it.
+type ReadState interface {
+	readstate.Store
+	// Record adds a notification to a user's channel
+	Record(ctx context.Context, userID, channel, notificationID string, at time.Time) error
+	// UnreadCounts returns the unread notifications of each channel of a user
+	// that has any
+	UnreadCounts(ctx context.Context, userID string) (map[string]int64, error)
+}
+
+// WithReadState makes the service record the notifications it creates in
+// store and serve MarkRead and GetUnreadCount from it
+func (s *Service) WithReadState(store ReadState) *Service {
+	s.readState = store
+	return s
+}
+
+// recordUnread adds notifications to the read state. A failure only leaves
+// them out of the unread counts, so it is logged rather than returned.
+func (s *Service) recordUnread(ctx context.Context, notifications []*Notification) {
+	if s.readState == nil {
+		return
+	}
+	for _, n := range notifications {
+		if err := s.readState.Record(ctx, n.UserID, n.Channel, n.ID, n.CreatedAt); err != nil {
+			s.log.Warn(ctx, "Failed to record unread notification", map[string]interface{}{"error": err.Error(), "notification_id": n.ID})
+		}
+	}
+}
+
+// MarkRead marks a user's notifications read up to a time on each channel.
+// Marks in the future are taken as now, so notifications sent later still
+// count as unread.
+func (s *Service) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error) {
+	switch {
+	case req.UserId == "":
+		s.log.Warn(ctx, "Mark read failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	case len(req.UserId) > maxIDLength:
+		return nil, status.Error(codes.InvalidArgument, "user_id is too long")
+	case len(req.ReadUpTo) == 0:
+		return nil, status.Error(codes.InvalidArgument, "read_up_to is required")
+	}
+
+	now := s.now()
+	marks := make(readstate.Marks, len(req.ReadUpTo))
+	for channel, at := range req.ReadUpTo {
+		if channel != ChannelEmail && channel != ChannelSMS && channel != ChannelPush {
+			return nil, status.Error(codes.InvalidArgument, "channel must be EMAIL, SMS or PUSH")
+		}
+		if at == nil || !at.IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "read_up_to of %s is invalid", channel)
+		}
+		marks[channel] = at.AsTime()
+		if marks[channel].After(now) {
+			marks[channel] = now
+		}
+	}
+
+	if s.readState == nil {
+		s.log.Warn(ctx, "Mark read failed: read state is not configured", nil)
+		return nil, status.Error(codes.FailedPrecondition, "read state requires Redis")
+	}
+	merged, err := s.readState.Merge(ctx, req.UserId, marks)
+	if err != nil {
+		s.log.Error(ctx, "Failed to merge read marks", map[string]interface{}{"error": err.Error(), "user_id": req.UserId})
+		return nil, status.Error(codes.Unavailable, "failed to save read state")
+	}
+
+	return &pb.MarkReadResponse{ReadUpTo: toProtoMarks(merged)}, nil
+}
+
+// GetUnreadCount returns a user's unread notifications per channel and the
+// read marks, so a device can catch up with the others
+func (s *Service) GetUnreadCount(ctx context.Context, req *pb.GetUnreadCountRequest) (*pb.GetUnreadCountResponse, error) {
+	switch {
+	case req.UserId == "":
+		s.log.Warn(ctx, "Get unread count failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	case len(req.UserId) > maxIDLength:
+		return nil, status.Error(codes.InvalidArgument, "user_id is too long")
+	}
+
+	if s.readState == nil {
+		s.log.Warn(ctx, "Get unread count failed: read state is not configured", nil)
+		return nil, status.Error(codes.FailedPrecondition, "read state requires Redis")
+	}
+	unread, err := s.readState.UnreadCounts(ctx, req.UserId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to count unread notifications", map[string]interface{}{"error": err.Error(), "user_id": req.UserId})
+		return nil, status.Error(codes.Unavailable, "failed to get read state")
+	}
+	marks, err := s.readState.Get(ctx, req.UserId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get read marks", map[string]interface{}{"error": err.Error(), "user_id": req.UserId})
+		return nil, status.Error(codes.Unavailable, "failed to get read state")
+	}
+
+	resp := &pb.GetUnreadCountResponse{Unread: unread, ReadUpTo: toProtoMarks(marks)}
+	for _, n := range unread {
+		resp.Total += n
+	}
+	return resp, nil
+}
+
+// toProtoMarks converts read marks to protobuf
+func toProtoMarks(marks readstate.Marks) map[string]*timestamppb.Timestamp {
+	converted := make(map[string]*timestamppb.Timestamp, len(marks))
+	for channel, at := range marks {
+		converted[channel] = timestamppb.New(at)
+	}
+	return converted
+}
@@ -0,0 +1,487 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Notification channels
+const (
+	ChannelEmail = "EMAIL"
+	ChannelSMS   = "SMS"
+	ChannelPush  = "PUSH"
+)
+
+// Delivery statuses of notifications
+const (
+	StatusPending = "PENDING"
+	StatusSent    = "SENT"
+	StatusFailed  = "FAILED"
+	// StatusDiscarded is a FAILED notification an operator chose not to retry
+	StatusDiscarded = "DISCARDED"
+)
+
+var (
+	// ErrNotificationNotFound is returned when a notification does not exist
+	ErrNotificationNotFound = errors.New("notification not found")
+	// ErrDuplicateEvent is returned when an event has already been recorded
+	ErrDuplicateEvent = errors.New("event already recorded")
+	// ErrPreferencesNotFound is returned when a user has not set preferences
+	ErrPreferencesNotFound = errors.New("preferences not found")
+	// ErrNotDeadLetter is returned when retrying or discarding a notification
+	// that has not FAILED
+	ErrNotDeadLetter = errors.New("notification is not a dead letter")
+)
+
+// Event is a domain event handed to the notification service
+type Event struct {
+	ID         string
+	Type       string
+	UserID     string
+	Data       map[string]string
+	OccurredAt time.Time
+	ReceivedAt time.Time
+}
+
+// Notification is one message sent to a user on one channel for an event
+type Notification struct {
+	ID        string
+	EventID   string
+	EventType string
+	UserID    string
+	Channel   string
+	Recipient string
+	Subject   string
+	Body      string
+	Status    string
+	Attempts  int32
+	LastError string
+	// NextAttemptAt is when a PENDING notification is due to be sent
+	NextAttemptAt *time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+	// FailedAt is when the notification last FAILED for good
+	FailedAt *time.Time
+}
+
+// Preferences are the channels a user receives notifications on
+type Preferences struct {
+	UserID       string
+	EmailEnabled bool
+	SMSEnabled   bool
+	PushEnabled  bool
+	PushToken    string
+	UpdatedAt    time.Time
+}
+
+// DefaultPreferences are the preferences of a user who has not set any:
+// email and push notifications, no SMS
+func DefaultPreferences(userID string) *Preferences {
+	return &Preferences{UserID: userID, EmailEnabled: true, PushEnabled: true}
+}
+
+// Enabled reports whether the user receives notifications on channel
+func (p *Preferences) Enabled(channel string) bool {
+	switch channel {
+	case ChannelEmail:
+		return p.EmailEnabled
+	case ChannelSMS:
+		return p.SMSEnabled
+	case ChannelPush:
+		return p.PushEnabled
+	}
+	return false
+}
+
+// Repository handles notification data persistence
+type Repository interface {
+	// CreateForEvent records an event with its notifications, or returns
+	// ErrDuplicateEvent when the event has been recorded before
+	CreateForEvent(ctx context.Context, event *Event, notifications []*Notification) error
+	GetByID(ctx context.Context, id string) (*Notification, error)
+	ListByEvent(ctx context.Context, eventID string) ([]*Notification, error)
+	// ListByUser lists a user's notifications, newest first, optionally only
+	// those with status
+	ListByUser(ctx context.Context, userID, status string, page, pageSize int32) ([]*Notification, int32, error)
+	// ClaimDue returns up to limit PENDING notifications due by now and
+	// defers them to now plus lease, so that no other dispatcher claims them
+	// while they are being sent
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Notification, error)
+	// RecordAttempt stores the outcome of a delivery attempt: the
+	// notification's status, attempts, last error, sent, failed and next
+	// attempt times
+	RecordAttempt(ctx context.Context, n *Notification) error
+	// ListDeadLetters lists the FAILED notifications, most recently failed
+	// first, optionally only those on channel
+	ListDeadLetters(ctx context.Context, channel string, page, pageSize int32) ([]*Notification, int32, error)
+	// Requeue moves a FAILED notification back to PENDING with its attempts
+	// reset, claimed until claimedUntil. A non-empty recipient replaces the
+	// notification's recipient. It returns ErrNotDeadLetter when the
+	// notification has not FAILED.
+	Requeue(ctx context.Context, id, recipient string, claimedUntil time.Time) (*Notification, error)
+	// Discard marks a FAILED notification DISCARDED, or returns
+	// ErrNotDeadLetter when it has not FAILED
+	Discard(ctx context.Context, id string) (*Notification, error)
+	// GetPreferences returns a user's preferences, or ErrPreferencesNotFound
+	GetPreferences(ctx context.Context, userID string) (*Preferences, error)
+	SavePreferences(ctx context.Context, prefs *Preferences) (*Preferences, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL notification repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const notificationColumns = `id, event_id, event_type, user_id, channel, recipient, subject, body, status, attempts,
+	last_error, next_attempt_at, created_at, sent_at, failed_at`
+
+// CreateForEvent inserts an event and its notifications in one transaction
+func (r *postgresRepository) CreateForEvent(ctx context.Context, event *Event, notifications []*Notification) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event data: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO notification_events (id, type, user_id, data, occurred_at, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO NOTHING
+	`, event.ID, event.Type, event.UserID, data, event.OccurredAt, event.ReceivedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record event", map[string]interface{}{"error": err.Error(), "event_id": event.ID})
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	} else if n == 0 {
+		return ErrDuplicateEvent
+	}
+
+	for _, n := range notifications {
+		n.ID = uuid.New().String()
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notifications (id, event_id, event_type, user_id, channel, recipient, subject, body, status,
+				attempts, next_attempt_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		`, n.ID, n.EventID, n.EventType, n.UserID, n.Channel, n.Recipient, n.Subject, n.Body, n.Status,
+			n.Attempts, n.NextAttemptAt, n.CreatedAt)
+		if err != nil {
+			r.log.Error(ctx, "Failed to create notification", map[string]interface{}{"error": err.Error(), "event_id": event.ID, "channel": n.Channel})
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Event recorded", map[string]interface{}{"event_id": event.ID, "type": event.Type, "notifications": len(notifications)})
+	return nil
+}
+
+// GetByID retrieves a notification
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Notification, error) {
+	query := "SELECT " + notificationColumns + " FROM notifications WHERE id = $1"
+
+	n, err := scanNotification(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrNotificationNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get notification", map[string]interface{}{"error": err.Error(), "notification_id": id})
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	return n, nil
+}
+
+// ListByEvent retrieves the notifications of an event
+func (r *postgresRepository) ListByEvent(ctx context.Context, eventID string) ([]*Notification, error) {
+	query := "SELECT " + notificationColumns + " FROM notifications WHERE event_id = $1 ORDER BY channel"
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list event notifications", map[string]interface{}{"error": err.Error(), "event_id": eventID})
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	return scanNotifications(rows)
+}
+
+// ListByUser retrieves a user's notifications with pagination
+func (r *postgresRepository) ListByUser(ctx context.Context, userID, status string, page, pageSize int32) ([]*Notification, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	// An empty status matches every status
+	var total int32
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND ($2 = '' OR status = $2)
+	`, userID, status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count notifications", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE user_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list notifications", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	notifications, err := scanNotifications(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// ClaimDue defers due notifications by lease and returns them. Rows claimed
+// by a concurrent dispatcher are skipped rather than waited for.
+func (r *postgresRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Notification, error) {
+	query := `
+		UPDATE notifications SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE status = 'PENDING' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + notificationColumns
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+	return scanNotifications(rows)
+}
+
+// RecordAttempt stores a delivery attempt's outcome
+func (r *postgresRepository) RecordAttempt(ctx context.Context, n *Notification) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE notifications
+		SET status = $2, attempts = $3, last_error = $4, sent_at = $5, next_attempt_at = $6, failed_at = $7
+		WHERE id = $1
+	`, n.ID, n.Status, n.Attempts, n.LastError, n.SentAt, n.NextAttemptAt, n.FailedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record delivery attempt", map[string]interface{}{"error": err.Error(), "notification_id": n.ID})
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetters retrieves FAILED notifications with pagination
+func (r *postgresRepository) ListDeadLetters(ctx context.Context, channel string, page, pageSize int32) ([]*Notification, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	// An empty channel matches every channel
+	var total int32
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE status = 'FAILED' AND ($1 = '' OR channel = $1)
+	`, channel).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count dead letters", map[string]interface{}{"error": err.Error(), "channel": channel})
+		return nil, 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE status = 'FAILED' AND ($1 = '' OR channel = $1)
+		ORDER BY failed_at DESC NULLS LAST, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, channel, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list dead letters", map[string]interface{}{"error": err.Error(), "channel": channel})
+		return nil, 0, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	notifications, err := scanNotifications(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// Requeue resets a FAILED notification to PENDING. The last error is kept
+// until the next attempt replaces it.
+func (r *postgresRepository) Requeue(ctx context.Context, id, recipient string, claimedUntil time.Time) (*Notification, error) {
+	query := `
+		UPDATE notifications
+		SET status = 'PENDING', attempts = 0, recipient = CASE WHEN $2 = '' THEN recipient ELSE $2 END,
+			next_attempt_at = $3, failed_at = NULL
+		WHERE id = $1 AND status = 'FAILED'
+		RETURNING ` + notificationColumns
+
+	n, err := r.updateDeadLetter(ctx, id, query, id, recipient, claimedUntil)
+	if err != nil {
+		return nil, err
+	}
+	r.log.Info(ctx, "Dead letter requeued", map[string]interface{}{"notification_id": id, "channel": n.Channel})
+	return n, nil
+}
+
+// Discard marks a FAILED notification DISCARDED
+func (r *postgresRepository) Discard(ctx context.Context, id string) (*Notification, error) {
+	query := `
+		UPDATE notifications SET status = 'DISCARDED'
+		WHERE id = $1 AND status = 'FAILED'
+		RETURNING ` + notificationColumns
+
+	n, err := r.updateDeadLetter(ctx, id, query, id)
+	if err != nil {
+		return nil, err
+	}
+	r.log.Info(ctx, "Dead letter discarded", map[string]interface{}{"notification_id": id, "channel": n.Channel})
+	return n, nil
+}
+
+// updateDeadLetter runs an update of the FAILED notification id that returns
+// the notification. When no row is updated it tells a missing notification
+// from one that has not FAILED.
+func (r *postgresRepository) updateDeadLetter(ctx context.Context, id, query string, args ...interface{}) (*Notification, error) {
+	n, err := scanNotification(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrNotDeadLetter
+	}
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrNotificationNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update dead letter", map[string]interface{}{"error": err.Error(), "notification_id": id})
+		return nil, fmt.Errorf("failed to update dead letter: %w", err)
+	}
+	return n, nil
+}
+
+// GetPreferences retrieves a user's preferences
+func (r *postgresRepository) GetPreferences(ctx context.Context, userID string) (*Preferences, error) {
+	p := &Preferences{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT user_id, email_enabled, sms_enabled, push_enabled, push_token, updated_at
+		FROM notification_preferences WHERE user_id = $1
+	`, userID).Scan(&p.UserID, &p.EmailEnabled, &p.SMSEnabled, &p.PushEnabled, &p.PushToken, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrPreferencesNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get preferences", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return p, nil
+}
+
+// SavePreferences inserts or replaces a user's preferences
+func (r *postgresRepository) SavePreferences(ctx context.Context, prefs *Preferences) (*Preferences, error) {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO notification_preferences (user_id, email_enabled, sms_enabled, push_enabled, push_token, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE
+		SET email_enabled = EXCLUDED.email_enabled, sms_enabled = EXCLUDED.sms_enabled,
+			push_enabled = EXCLUDED.push_enabled, push_token = EXCLUDED.push_token, updated_at = EXCLUDED.updated_at
+	`, prefs.UserID, prefs.EmailEnabled, prefs.SMSEnabled, prefs.PushEnabled, prefs.PushToken, prefs.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to save preferences", map[string]interface{}{"error": err.Error(), "user_id": prefs.UserID})
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanNotification scans a notification selected with notificationColumns
+func scanNotification(row rowScanner) (*Notification, error) {
+	n := &Notification{}
+	var nextAttemptAt, sentAt, failedAt sql.NullTime
+
+	err := row.Scan(&n.ID, &n.EventID, &n.EventType, &n.UserID, &n.Channel, &n.Recipient, &n.Subject, &n.Body, &n.Status,
+		&n.Attempts, &n.LastError, &nextAttemptAt, &n.CreatedAt, &sentAt, &failedAt)
+	if err != nil {
+		return nil, err
+	}
+	if nextAttemptAt.Valid {
+		n.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if sentAt.Valid {
+		n.SentAt = &sentAt.Time
+	}
+	if failedAt.Valid {
+		n.FailedAt = &failedAt.Time
+	}
+	return n, nil
+}
+
+// scanNotifications scans and closes rows of notifications
+func scanNotifications(rows *sql.Rows) ([]*Notification, error) {
+	defer rows.Close()
+
+	var notifications []*Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notifications: %w", err)
+	}
+	return notifications, nil
+}
@@ -0,0 +1,158 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("notification-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var notificationColumnNames = []string{
+	"id", "event_id", "event_type", "user_id", "channel", "recipient", "subject", "body", "status", "attempts",
+	"last_error", "next_attempt_at", "created_at", "sent_at", "failed_at",
+}
+
+func TestCreateForEvent_Duplicate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	event := &Event{ID: "order.paid:order-1", Type: "order.paid", UserID: "user-1", OccurredAt: now, ReceivedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO notification_events .* ON CONFLICT \(id\) DO NOTHING`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.CreateForEvent(context.Background(), event, []*Notification{{EventID: event.ID, Channel: ChannelEmail}})
+	if err != ErrDuplicateEvent {
+		t.Errorf("Expected ErrDuplicateEvent, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateForEvent(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	event := &Event{ID: "order.paid:order-1", Type: "order.paid", UserID: "user-1", Data: map[string]string{"order_id": "order-1"}, OccurredAt: now, ReceivedAt: now}
+	n := &Notification{EventID: event.ID, EventType: event.Type, UserID: "user-1", Channel: ChannelEmail, Recipient: "ada@example.com", Status: StatusPending, CreatedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO notification_events`).
+		WithArgs(event.ID, "order.paid", "user-1", []byte(`{"order_id":"order-1"}`), now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO notifications`).
+		WithArgs(sqlmock.AnyArg(), event.ID, "order.paid", "user-1", ChannelEmail, "ada@example.com", "", "", StatusPending, 0, nil, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.CreateForEvent(context.Background(), event, []*Notification{n}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n.ID == "" {
+		t.Error("Expected the notification to be given an ID")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClaimDue(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	until := now.Add(claimLease)
+	mock.ExpectQuery(`UPDATE notifications SET next_attempt_at = \$2\s+WHERE id IN \(\s+SELECT id FROM notifications\s+WHERE status = 'PENDING' AND next_attempt_at <= \$1 .* FOR UPDATE SKIP LOCKED`).
+		WithArgs(now, until, 100).
+		WillReturnRows(sqlmock.NewRows(notificationColumnNames).
+			AddRow("n-1", "order.paid:order-1", "order.paid", "user-1", ChannelSMS, "+15550100", "", "Paid", StatusPending, 1,
+				"timeout", until, now, nil, nil))
+
+	due, err := repo.ClaimDue(context.Background(), now, claimLease, 100)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(due) != 1 || due[0].NextAttemptAt == nil || !due[0].NextAttemptAt.Equal(until) || due[0].SentAt != nil {
+		t.Errorf("Unexpected notifications %+v", due)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM notifications WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	if _, err := repo.GetByID(context.Background(), "not-a-uuid"); err != ErrNotificationNotFound {
+		t.Errorf("Expected ErrNotificationNotFound, got %v", err)
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	until := now.Add(claimLease)
+	mock.ExpectQuery(`UPDATE notifications\s+SET status = 'PENDING', attempts = 0, .* WHERE id = \$1 AND status = 'FAILED'`).
+		WithArgs("n-1", "grace@example.com", until).
+		WillReturnRows(sqlmock.NewRows(notificationColumnNames).
+			AddRow("n-1", "order.paid:order-1", "order.paid", "user-1", ChannelEmail, "grace@example.com", "Paid", "Paid", StatusPending, 0,
+				"mailbox not found", until, now, nil, nil))
+
+	n, err := repo.Requeue(context.Background(), "n-1", "grace@example.com", until)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n.Status != StatusPending || n.Recipient != "grace@example.com" || n.FailedAt != nil {
+		t.Errorf("Unexpected notification %+v", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDiscard_NotDeadLetter(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE notifications SET status = 'DISCARDED'`).
+		WithArgs("n-1").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM notifications WHERE id = \$1`).
+		WithArgs("n-1").
+		WillReturnRows(sqlmock.NewRows(notificationColumnNames).
+			AddRow("n-1", "order.paid:order-1", "order.paid", "user-1", ChannelEmail, "ada@example.com", "Paid", "Paid", StatusSent, 1,
+				"", nil, now, now, nil))
+
+	if _, err := repo.Discard(context.Background(), "n-1"); err != ErrNotDeadLetter {
+		t.Errorf("Expected ErrNotDeadLetter, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
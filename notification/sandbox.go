@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Recipients the sandbox senders reject, so rejected notifications can be
+// exercised
+const (
+	SandboxRejectedEmail = "rejected@example.com"
+	SandboxRejectedPhone = "+15005550001"
+)
+
+// maxSentMessages caps the messages a sandbox keeps; the oldest are dropped
+// first
+const maxSentMessages = 1000
+
+// SentMessage is a message a sandbox sender accepted
+type SentMessage struct {
+	Channel   string
+	Recipient string
+	Subject   string
+	Body      string
+	SentAt    time.Time
+}
+
+// Sandbox replaces the email, SMS and push providers in sandbox mode. Its
+// senders make no network calls, accept every recipient except
+// SandboxRejectedEmail and SandboxRejectedPhone, and record what they send so
+// it can be inspected.
+type Sandbox struct {
+	now  func() time.Time
+	mu   sync.Mutex
+	sent []*SentMessage
+}
+
+// NewSandbox creates an empty sandbox
+func NewSandbox() *Sandbox {
+	return &Sandbox{now: time.Now}
+}
+
+// Senders returns a sender for each channel, recording into the sandbox
+func (s *Sandbox) Senders() []Sender {
+	return []Sender{
+		&sandboxSender{sandbox: s, channel: ChannelEmail},
+		&sandboxSender{sandbox: s, channel: ChannelSMS},
+		&sandboxSender{sandbox: s, channel: ChannelPush},
+	}
+}
+
+// Sent returns the messages sent on channel so far, oldest first, to
+// recipient or, when it is empty, to anyone
+func (s *Sandbox) Sent(channel, recipient string) []*SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sent []*SentMessage
+	for _, m := range s.sent {
+		if m.Channel == channel && (recipient == "" || m.Recipient == recipient) {
+			sent = append(sent, m)
+		}
+	}
+	return sent
+}
+
+func (s *Sandbox) record(channel string, msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.sent) >= maxSentMessages {
+		s.sent = s.sent[1:]
+	}
+	s.sent = append(s.sent, &SentMessage{
+		Channel:   channel,
+		Recipient: msg.Recipient,
+		Subject:   msg.Subject,
+		Body:      msg.Body,
+		SentAt:    s.now(),
+	})
+}
+
+// sandboxSender sends the messages of one channel into a sandbox
+type sandboxSender struct {
+	sandbox *Sandbox
+	channel string
+}
+
+func (s *sandboxSender) Channel() string {
+	return s.channel
+}
+
+func (s *sandboxSender) Send(ctx context.Context, msg Message) error {
+	if msg.Recipient == SandboxRejectedEmail || msg.Recipient == SandboxRejectedPhone {
+		return fmt.Errorf("%w: sandbox test recipient", ErrRejected)
+	}
+	s.sandbox.record(s.channel, msg)
+	return nil
+}
+
+// WithSandbox makes the service serve the inspection RPCs of sandbox
+func (s *Service) WithSandbox(sandbox *Sandbox) *Service {
+	s.sandbox = sandbox
+	return s
+}
+
+// ListSentEmails lists the emails the sandbox sent, oldest first, so a flow
+// can be checked without a provider account. It is only served in sandbox
+// mode.
+func (s *Service) ListSentEmails(ctx context.Context, req *pb.ListSentEmailsRequest) (*pb.ListSentEmailsResponse, error) {
+	sent, err := s.sent(ctx, "List sent emails", ChannelEmail, req.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListSentEmailsResponse{Messages: sent}, nil
+}
+
+// ListSentSMS lists the text messages the sandbox sent, oldest first. It is
+// only served in sandbox mode.
+func (s *Service) ListSentSMS(ctx context.Context, req *pb.ListSentSMSRequest) (*pb.ListSentSMSResponse, error) {
+	sent, err := s.sent(ctx, "List sent SMS", ChannelSMS, req.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListSentSMSResponse{Messages: sent}, nil
+}
+
+func (s *Service) sent(ctx context.Context, op, channel, recipient string) ([]*pb.SentMessage, error) {
+	if s.sandbox == nil {
+		s.log.Warn(ctx, op+" failed: sandbox mode is off", nil)
+		return nil, status.Error(codes.FailedPrecondition, "sent messages are only recorded in sandbox mode")
+	}
+	sent := s.sandbox.Sent(channel, recipient)
+	messages := make([]*pb.SentMessage, len(sent))
+	for i, m := range sent {
+		messages[i] = &pb.SentMessage{
+			Channel:   m.Channel,
+			Recipient: m.Recipient,
+			Subject:   m.Subject,
+			Body:      m.Body,
+			SentAt:    timestamppb.New(m.SentAt),
+		}
+	}
+	return messages, nil
+}
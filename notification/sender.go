@@ -0,0 +1,144 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// defaultTimeout bounds provider API calls when no timeout is configured
+const defaultTimeout = 10 * time.Second
+
+// maxResponseSize caps the provider response body that is read, in bytes
+const maxResponseSize = 1 << 20
+
+// ErrRejected is returned by senders when the provider refuses a message for
+// good, for example because the recipient does not exist. Rejected
+// notifications are not retried.
+var ErrRejected = errors.New("message rejected by provider")
+
+// Message is a rendered notification addressed to its recipient
+type Message struct {
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// Sender delivers messages on one channel
+type Sender interface {
+	// Channel returns the channel the sender delivers on
+	Channel() string
+	// Send delivers a message. Errors wrapping ErrRejected are permanent;
+	// other errors are retried.
+	Send(ctx context.Context, msg Message) error
+}
+
+type logSender struct {
+	channel string
+	log     *logger.Logger
+}
+
+// NewLogSender creates a Sender that only logs the messages it would send on
+// channel, for development and deployments without a provider
+func NewLogSender(channel string, log *logger.Logger) Sender {
+	return &logSender{channel: channel, log: log}
+}
+
+func (s *logSender) Channel() string {
+	return s.channel
+}
+
+func (s *logSender) Send(ctx context.Context, msg Message) error {
+	s.log.Info(ctx, "Notification sent to log", map[string]interface{}{
+		"channel": s.channel,
+		"subject": msg.Subject,
+		"length":  len(msg.Body),
+	})
+	return nil
+}
+
+// failoverSender tries the senders of one channel in order
+type failoverSender struct {
+	senders []Sender
+}
+
+// NewFailoverSender creates a Sender that delivers through the first of
+// senders, which share a channel, and fails over to the next when a send
+// fails. The message is rejected only when every sender rejects it.
+func NewFailoverSender(senders ...Sender) Sender {
+	if len(senders) == 1 {
+		return senders[0]
+	}
+	return &failoverSender{senders: senders}
+}
+
+func (s *failoverSender) Channel() string {
+	return s.senders[0].Channel()
+}
+
+func (s *failoverSender) Send(ctx context.Context, msg Message) error {
+	failed := &failoverError{rejected: true}
+	for _, sender := range s.senders {
+		err := sender.Send(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		failed.errs = append(failed.errs, err)
+		failed.rejected = failed.rejected && errors.Is(err, ErrRejected)
+		if ctx.Err() != nil {
+			failed.rejected = false
+			break
+		}
+	}
+	return failed
+}
+
+// failoverError holds the errors of each sender tried. It is ErrRejected only
+// when every sender rejected the message, as another provider may accept a
+// message one refused, e.g. for its own misconfiguration.
+type failoverError struct {
+	errs     []error
+	rejected bool
+}
+
+func (e *failoverError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *failoverError) Is(target error) bool {
+	return e.rejected && target == ErrRejected
+}
+
+// send sends an API request and returns the response code and body
+func send(client *http.Client, req *http.Request) (int, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// statusError returns the error for an unsuccessful provider response: client
+// errors other than rate limiting reject the message, anything else is retried
+func statusError(provider string, code int, detail string) error {
+	if code >= 400 && code < 500 && code != http.StatusTooManyRequests && code != http.StatusRequestTimeout {
+		return fmt.Errorf("%w: %s: status %d: %s", ErrRejected, provider, code, detail)
+	}
+	return fmt.Errorf("%s: unexpected status %d: %s", provider, code, detail)
+}
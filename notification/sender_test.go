@@ -0,0 +1,188 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTwilioSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if r.URL.Path != "/2010-04-01/Accounts/AC1/Messages.json" || user != "AC1" || pass != "token" {
+			t.Errorf("Unexpected request %s as %s", r.URL.Path, user)
+		}
+		if r.FormValue("To") == "+1invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": 21211, "message": "Invalid 'To' Phone Number"}`))
+			return
+		}
+		if r.FormValue("From") != "+15550000" || r.FormValue("Body") != "Delivered" {
+			t.Errorf("Unexpected form %v", r.Form)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	sender, err := NewTwilioSender(TwilioConfig{AccountSID: "AC1", AuthToken: "token", From: "+15550000", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %v", err)
+	}
+
+	if err := sender.Send(context.Background(), Message{Recipient: "+15550100", Body: "Delivered"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := sender.Send(context.Background(), Message{Recipient: "+1invalid", Body: "Delivered"}); !errors.Is(err, ErrRejected) {
+		t.Errorf("Expected ErrRejected for an invalid number, got %v", err)
+	}
+}
+
+func TestFCMSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key=server-key" {
+			t.Errorf("Unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		switch r.Header.Get("X-Test") {
+		case "unregistered":
+			w.Write([]byte(`{"success": 0, "failure": 1, "results": [{"error": "NotRegistered"}]}`))
+		case "unavailable":
+			w.Write([]byte(`{"success": 0, "failure": 1, "results": [{"error": "Unavailable"}]}`))
+		case "down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"success": 1, "failure": 0, "results": [{"message_id": "m1"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	sender, err := NewFCMSender(FCMConfig{ServerKey: "server-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %v", err)
+	}
+	// Route each case through a header the test server switches on
+	transport := sender.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	tests := []struct {
+		name     string
+		rejected bool
+		ok       bool
+	}{
+		{"sent", false, true},
+		{"unregistered", true, false},
+		{"unavailable", false, false},
+		{"down", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := tt.name
+			sender.httpClient.Transport = roundTripper(func(r *http.Request) (*http.Response, error) {
+				r.Header.Set("X-Test", name)
+				return transport.RoundTrip(r)
+			})
+			err := sender.Send(context.Background(), Message{Recipient: "device-1", Subject: "Delivered", Body: "Your order has been delivered."})
+			if tt.ok != (err == nil) || tt.rejected != errors.Is(err, ErrRejected) {
+				t.Errorf("Unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestSendGridSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mail/send" || r.Header.Get("Authorization") != "Bearer sg-key" {
+			t.Errorf("Unexpected request %s with %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var req sendGridRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		switch req.Personalizations[0].To[0].Email {
+		case "blocked@example.com":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors": [{"message": "recipient is blocked"}]}`))
+		case "busy@example.com":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			if req.From.Email != "no-reply@example.com" || req.Subject != "Delivered" || req.Content[0].Value != "Your order has been delivered." {
+				t.Errorf("Unexpected message %+v", req)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	sender, err := NewSendGridSender(SendGridConfig{APIKey: "sg-key", From: "Shop <no-reply@example.com>", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %v", err)
+	}
+
+	tests := []struct {
+		recipient string
+		rejected  bool
+		ok        bool
+	}{
+		{"Ada <ada@example.com>", false, true},
+		{"blocked@example.com", true, false},
+		{"busy@example.com", false, false},
+		{"not an address", true, false},
+	}
+
+	for _, tt := range tests {
+		err := sender.Send(context.Background(), Message{Recipient: tt.recipient, Subject: "Delivered", Body: "Your order has been delivered."})
+		if tt.ok != (err == nil) || tt.rejected != errors.Is(err, ErrRejected) {
+			t.Errorf("%s: unexpected error %v", tt.recipient, err)
+		}
+	}
+}
+
+func TestFailoverSender(t *testing.T) {
+	down := errors.New("connection refused")
+	rejected := fmt.Errorf("%w: mailbox not found", ErrRejected)
+
+	tests := []struct {
+		name     string
+		primary  error
+		fallback error
+		sent     int
+		rejected bool
+	}{
+		{"primary sends", nil, nil, 1, false},
+		{"fails over", down, nil, 1, false},
+		{"fails over on rejection", rejected, nil, 1, false},
+		{"both fail", down, rejected, 0, false},
+		{"both reject", rejected, rejected, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeSender{channel: ChannelEmail, err: tt.primary}
+			fallback := &fakeSender{channel: ChannelEmail, err: tt.fallback}
+			err := NewFailoverSender(primary, fallback).Send(context.Background(), Message{Recipient: "ada@example.com"})
+
+			if sent := len(primary.Sent()) + len(fallback.Sent()); sent != tt.sent {
+				t.Errorf("Expected %d sent, got %d", tt.sent, sent)
+			}
+			if (tt.sent == 1) != (err == nil) || tt.rejected != errors.Is(err, ErrRejected) {
+				t.Errorf("Unexpected error %v", err)
+			}
+			if tt.sent == 0 && !strings.Contains(err.Error(), "; ") {
+				t.Errorf("Expected the error to list both senders, got %v", err)
+			}
+		})
+	}
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,467 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the event and user ID columns
+	maxIDLength = 255
+	// maxDataEntries and maxDataSize bound an event's data
+	maxDataEntries = 50
+	maxDataSize    = 16 << 10
+	// maxPushTokenLength matches the push_token column
+	maxPushTokenLength = 4096
+	// maxRecipientLength matches the recipient column
+	maxRecipientLength = 4096
+)
+
+// AdminMethods are the admin-scoped RPCs of the notification service; they are
+// restricted to the admin network allowlist. Events are published by the
+// other services only, and dead letters are handled by operators.
+var AdminMethods = []string{
+	pb.NotificationService_PublishEvent_FullMethodName,
+	pb.NotificationService_ListDeadLetters_FullMethodName,
+	pb.NotificationService_RetryNotification_FullMethodName,
+	pb.NotificationService_DiscardNotification_FullMethodName,
+}
+
+// Service implements the NotificationService gRPC interface
+type Service struct {
+	pb.UnimplementedNotificationServiceServer
+	repo       Repository
+	templates  *Templates
+	accounts   Accounts
+	dispatcher *Dispatcher
+	sandbox    *Sandbox
+	readState  ReadState
+	log        *logger.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new notification service
+func NewService(repo Repository, templates *Templates, accounts Accounts, dispatcher *Dispatcher, log *logger.Logger) *Service {
+	return &Service{
+		repo:       repo,
+		templates:  templates,
+		accounts:   accounts,
+		dispatcher: dispatcher,
+		log:        log,
+		now:        time.Now,
+	}
+}
+
+// PublishEvent renders an event's notifications for the channels the user
+// has enabled, records them with the event and sends them. Notifications
+// that cannot be sent now are retried by the dispatcher.
+func (s *Service) PublishEvent(ctx context.Context, req *pb.PublishEventRequest) (*pb.PublishEventResponse, error) {
+	if msg := s.eventProblem(req); msg != "" {
+		s.log.Warn(ctx, "Publish event failed: "+msg, map[string]interface{}{"event_id": req.EventId, "type": req.Type})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	now := s.now()
+	event := &Event{
+		ID:         req.EventId,
+		Type:       req.Type,
+		UserID:     req.UserId,
+		Data:       req.Data,
+		OccurredAt: now,
+		ReceivedAt: now,
+	}
+	if req.OccurredAt != nil {
+		event.OccurredAt = req.OccurredAt.AsTime()
+	}
+	if event.Data == nil {
+		event.Data = map[string]string{}
+	}
+
+	notifications, err := s.render(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.CreateForEvent(ctx, event, notifications)
+	if errors.Is(err, ErrDuplicateEvent) {
+		s.log.Info(ctx, "Duplicate event skipped", map[string]interface{}{"event_id": event.ID, "type": event.Type})
+		existing, err := s.repo.ListByEvent(ctx, event.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get notifications")
+		}
+		return &pb.PublishEventResponse{Notifications: toProtoNotifications(existing), Duplicate: true}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to record event")
+	}
+	s.recordUnread(ctx, notifications)
+
+	for _, n := range notifications {
+		if err := s.dispatcher.Deliver(ctx, n); err != nil {
+			// The notification stays claimed and is retried once its lease ends
+			s.log.Error(ctx, "Failed to record delivery attempt", map[string]interface{}{"error": err.Error(), "notification_id": n.ID})
+		}
+	}
+
+	return &pb.PublishEventResponse{Notifications: toProtoNotifications(notifications)}, nil
+}
+
+// eventProblem validates an event and returns a message describing the first
+// problem, or ""
+func (s *Service) eventProblem(req *pb.PublishEventRequest) string {
+	switch {
+	case req.EventId == "":
+		return "event_id is required"
+	case len(req.EventId) > maxIDLength:
+		return "event_id is too long"
+	case req.Type == "":
+		return "type is required"
+	case len(s.templates.Channels(req.Type)) == 0:
+		return "unknown event type " + req.Type
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return "user_id is too long"
+	case len(req.Data) > maxDataEntries:
+		return "data has too many entries"
+	}
+	size := 0
+	for k, v := range req.Data {
+		size += len(k) + len(v)
+	}
+	if size > maxDataSize {
+		return "data is too large"
+	}
+	return ""
+}
+
+// render builds an event's notifications: one per channel the event has a
+// template for, the user has enabled and a sender is configured for, and the
+// user can be reached on. They are due at once but claimed for this call, so
+// the dispatcher leaves them alone while they are sent.
+func (s *Service) render(ctx context.Context, event *Event) ([]*Notification, error) {
+	fields := map[string]interface{}{"event_id": event.ID, "type": event.Type, "user_id": event.UserID}
+
+	contact, err := s.accounts.GetContact(ctx, event.UserID)
+	if errors.Is(err, ErrUnknownUser) {
+		// The event is still recorded, so that retries are not looked up again
+		s.log.Warn(ctx, "Event for unknown user is not notified", fields)
+		return nil, nil
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get user contact details", map[string]interface{}{"error": err.Error(), "user_id": event.UserID})
+		return nil, status.Error(codes.Unavailable, "failed to get user contact details")
+	}
+
+	prefs, err := s.preferences(ctx, event.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get preferences")
+	}
+
+	data := TemplateData{Event: event.Type, User: *contact, Data: event.Data}
+	claimedUntil := event.ReceivedAt.Add(claimLease)
+
+	var notifications []*Notification
+	for _, channel := range s.templates.Channels(event.Type) {
+		if !prefs.Enabled(channel) || !s.dispatcher.Supports(channel) {
+			continue
+		}
+		recipient := recipientFor(channel, contact, prefs)
+		if recipient == "" {
+			continue
+		}
+
+		subject, body, err := s.templates.Render(event.Type, channel, data)
+		if err != nil {
+			s.log.Warn(ctx, "Publish event failed: template rendering failed", map[string]interface{}{"error": err.Error(), "event_id": event.ID, "channel": channel})
+			return nil, status.Errorf(codes.InvalidArgument, "event data does not fit the %s template: %v", channel, err)
+		}
+
+		notifications = append(notifications, &Notification{
+			EventID:       event.ID,
+			EventType:     event.Type,
+			UserID:        event.UserID,
+			Channel:       channel,
+			Recipient:     recipient,
+			Subject:       subject,
+			Body:          body,
+			Status:        StatusPending,
+			NextAttemptAt: &claimedUntil,
+			CreatedAt:     event.ReceivedAt,
+		})
+	}
+	return notifications, nil
+}
+
+// recipientFor returns where a user is reached on channel, or "" when unknown
+func recipientFor(channel string, contact *Contact, prefs *Preferences) string {
+	switch channel {
+	case ChannelEmail:
+		return contact.Email
+	case ChannelSMS:
+		return contact.Phone
+	case ChannelPush:
+		return prefs.PushToken
+	}
+	return ""
+}
+
+// GetNotification retrieves a notification by ID
+func (s *Service) GetNotification(ctx context.Context, req *pb.GetNotificationRequest) (*pb.GetNotificationResponse, error) {
+	if req.NotificationId == "" {
+		s.log.Warn(ctx, "Get notification failed: notification ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "notification_id is required")
+	}
+
+	n, err := s.repo.GetByID(ctx, req.NotificationId)
+	if errors.Is(err, ErrNotificationNotFound) {
+		s.log.Warn(ctx, "Notification not found", map[string]interface{}{"notification_id": req.NotificationId})
+		return nil, status.Error(codes.NotFound, "notification not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get notification")
+	}
+
+	return &pb.GetNotificationResponse{
+		Notification: toProtoNotification(n),
+	}, nil
+}
+
+// ListNotifications lists a user's notifications, newest first
+func (s *Service) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	if req.UserId == "" {
+		s.log.Warn(ctx, "List notifications failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Status != "" && req.Status != StatusPending && req.Status != StatusSent && req.Status != StatusFailed && req.Status != StatusDiscarded {
+		return nil, status.Error(codes.InvalidArgument, "status must be PENDING, SENT, FAILED or DISCARDED")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	notifications, total, err := s.repo.ListByUser(ctx, req.UserId, req.Status, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list notifications")
+	}
+
+	return &pb.ListNotificationsResponse{
+		Notifications: toProtoNotifications(notifications),
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+	}, nil
+}
+
+// ListDeadLetters lists the notifications that FAILED for good, most recently
+// failed first
+func (s *Service) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	if req.Channel != "" && req.Channel != ChannelEmail && req.Channel != ChannelSMS && req.Channel != ChannelPush {
+		return nil, status.Error(codes.InvalidArgument, "channel must be EMAIL, SMS or PUSH")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	notifications, total, err := s.repo.ListDeadLetters(ctx, req.Channel, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list dead letters")
+	}
+
+	return &pb.ListDeadLettersResponse{
+		Notifications: toProtoNotifications(notifications),
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+	}, nil
+}
+
+// RetryNotification requeues a dead letter, optionally to a corrected
+// recipient, and sends it. A send that fails again is retried by the
+// dispatcher like a new notification.
+func (s *Service) RetryNotification(ctx context.Context, req *pb.RetryNotificationRequest) (*pb.RetryNotificationResponse, error) {
+	switch {
+	case req.NotificationId == "":
+		s.log.Warn(ctx, "Retry notification failed: notification ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "notification_id is required")
+	case len(req.Recipient) > maxRecipientLength:
+		return nil, status.Error(codes.InvalidArgument, "recipient is too long")
+	}
+
+	n, err := s.repo.Requeue(ctx, req.NotificationId, req.Recipient, s.now().Add(claimLease))
+	if err != nil {
+		return nil, s.deadLetterError(ctx, "Retry notification", req.NotificationId, err)
+	}
+
+	if err := s.dispatcher.Deliver(ctx, n); err != nil {
+		// The notification stays claimed and is retried once its lease ends
+		s.log.Error(ctx, "Failed to record delivery attempt", map[string]interface{}{"error": err.Error(), "notification_id": n.ID})
+	}
+
+	return &pb.RetryNotificationResponse{
+		Notification: toProtoNotification(n),
+	}, nil
+}
+
+// DiscardNotification marks a dead letter DISCARDED
+func (s *Service) DiscardNotification(ctx context.Context, req *pb.DiscardNotificationRequest) (*pb.DiscardNotificationResponse, error) {
+	if req.NotificationId == "" {
+		s.log.Warn(ctx, "Discard notification failed: notification ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "notification_id is required")
+	}
+
+	n, err := s.repo.Discard(ctx, req.NotificationId)
+	if err != nil {
+		return nil, s.deadLetterError(ctx, "Discard notification", req.NotificationId, err)
+	}
+
+	return &pb.DiscardNotificationResponse{
+		Notification: toProtoNotification(n),
+	}, nil
+}
+
+// deadLetterError maps an error from requeueing or discarding a notification
+// to a gRPC status
+func (s *Service) deadLetterError(ctx context.Context, op, id string, err error) error {
+	fields := map[string]interface{}{"notification_id": id}
+	switch {
+	case errors.Is(err, ErrNotificationNotFound):
+		s.log.Warn(ctx, op+" failed: notification not found", fields)
+		return status.Error(codes.NotFound, "notification not found")
+	case errors.Is(err, ErrNotDeadLetter):
+		s.log.Warn(ctx, op+" failed: notification has not failed", fields)
+		return status.Error(codes.FailedPrecondition, "only FAILED notifications can be retried or discarded")
+	}
+	return status.Error(codes.Internal, "failed to update notification")
+}
+
+// GetPreferences returns a user's preferences, or the defaults when the user
+// has not set any
+func (s *Service) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.GetPreferencesResponse, error) {
+	if req.UserId == "" {
+		s.log.Warn(ctx, "Get preferences failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	prefs, err := s.preferences(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get preferences")
+	}
+
+	return &pb.GetPreferencesResponse{
+		Preferences: toProtoPreferences(prefs),
+	}, nil
+}
+
+// UpdatePreferences replaces a user's preferences
+func (s *Service) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.UpdatePreferencesResponse, error) {
+	switch {
+	case req.UserId == "":
+		s.log.Warn(ctx, "Update preferences failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	case len(req.UserId) > maxIDLength:
+		return nil, status.Error(codes.InvalidArgument, "user_id is too long")
+	case len(req.PushToken) > maxPushTokenLength:
+		return nil, status.Error(codes.InvalidArgument, "push_token is too long")
+	}
+
+	prefs, err := s.repo.SavePreferences(ctx, &Preferences{
+		UserID:       req.UserId,
+		EmailEnabled: req.EmailEnabled,
+		SMSEnabled:   req.SmsEnabled,
+		PushEnabled:  req.PushEnabled,
+		PushToken:    req.PushToken,
+		UpdatedAt:    s.now(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to save preferences")
+	}
+
+	return &pb.UpdatePreferencesResponse{
+		Preferences: toProtoPreferences(prefs),
+	}, nil
+}
+
+// preferences returns a user's stored preferences or the defaults
+func (s *Service) preferences(ctx context.Context, userID string) (*Preferences, error) {
+	prefs, err := s.repo.GetPreferences(ctx, userID)
+	if errors.Is(err, ErrPreferencesNotFound) {
+		return DefaultPreferences(userID), nil
+	}
+	return prefs, err
+}
+
+// toProtoNotification converts a notification to protobuf
+func toProtoNotification(n *Notification) *pb.Notification {
+	notification := &pb.Notification{
+		Id:        n.ID,
+		EventId:   n.EventID,
+		EventType: n.EventType,
+		UserId:    n.UserID,
+		Channel:   n.Channel,
+		Recipient: n.Recipient,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		Status:    n.Status,
+		Attempts:  n.Attempts,
+		LastError: n.LastError,
+		CreatedAt: timestamppb.New(n.CreatedAt),
+	}
+	if n.SentAt != nil {
+		notification.SentAt = timestamppb.New(*n.SentAt)
+	}
+	if n.FailedAt != nil {
+		notification.FailedAt = timestamppb.New(*n.FailedAt)
+	}
+	return notification
+}
+
+func toProtoNotifications(notifications []*Notification) []*pb.Notification {
+	protoNotifications := make([]*pb.Notification, len(notifications))
+	for i, n := range notifications {
+		protoNotifications[i] = toProtoNotification(n)
+	}
+	return protoNotifications
+}
+
+// toProtoPreferences converts preferences to protobuf
+func toProtoPreferences(p *Preferences) *pb.Preferences {
+	prefs := &pb.Preferences{
+		UserId:       p.UserID,
+		EmailEnabled: p.EmailEnabled,
+		SmsEnabled:   p.SMSEnabled,
+		PushEnabled:  p.PushEnabled,
+		PushToken:    p.PushToken,
+	}
+	if !p.UpdatedAt.IsZero() {
+		prefs.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+	return prefs
+}
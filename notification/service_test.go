@@ -0,0 +1,671 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/readstate"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps events, their notifications and users' preferences,
+// refusing an event it already has
+type memoryRepository struct {
+	mu            sync.Mutex
+	events        map[string]Event
+	notifications map[string]Notification
+	preferences   map[string]Preferences
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		events:        map[string]Event{},
+		notifications: map[string]Notification{},
+		preferences:   map[string]Preferences{},
+	}
+}
+
+func (m *memoryRepository) CreateForEvent(ctx context.Context, event *Event, notifications []*Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.events[event.ID]; ok {
+		return ErrDuplicateEvent
+	}
+	m.events[event.ID] = *event
+	for _, n := range notifications {
+		n.ID = uuid.New().String()
+		m.notifications[n.ID] = *n
+	}
+	return nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.notifications[id]
+	if !ok {
+		return nil, ErrNotificationNotFound
+	}
+	return &n, nil
+}
+
+func (m *memoryRepository) ListByEvent(ctx context.Context, eventID string) ([]*Notification, error) {
+	return m.list(func(n Notification) bool { return n.EventID == eventID }), nil
+}
+
+func (m *memoryRepository) ListByUser(ctx context.Context, userID, status string, page, pageSize int32) ([]*Notification, int32, error) {
+	all := m.list(func(n Notification) bool { return n.UserID == userID && (status == "" || n.Status == status) })
+	total := int32(len(all))
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	return all[start:end], total, nil
+}
+
+func (m *memoryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Notification, error) {
+	due := m.list(func(n Notification) bool {
+		return n.Status == StatusPending && n.NextAttemptAt != nil && !n.NextAttemptAt.After(now)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	until := now.Add(lease)
+	for _, n := range due {
+		n.NextAttemptAt = &until
+		m.notifications[n.ID] = *n
+	}
+	return due, nil
+}
+
+func (m *memoryRepository) RecordAttempt(ctx context.Context, n *Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifications[n.ID] = *n
+	return nil
+}
+
+func (m *memoryRepository) ListDeadLetters(ctx context.Context, channel string, page, pageSize int32) ([]*Notification, int32, error) {
+	all := m.list(func(n Notification) bool { return n.Status == StatusFailed && (channel == "" || n.Channel == channel) })
+	sort.SliceStable(all, func(i, j int) bool { return all[i].FailedAt.After(*all[j].FailedAt) })
+	total := int32(len(all))
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	return all[start:end], total, nil
+}
+
+func (m *memoryRepository) Requeue(ctx context.Context, id, recipient string, claimedUntil time.Time) (*Notification, error) {
+	return m.updateDeadLetter(id, func(n *Notification) {
+		n.Status, n.Attempts, n.NextAttemptAt, n.FailedAt = StatusPending, 0, &claimedUntil, nil
+		if recipient != "" {
+			n.Recipient = recipient
+		}
+	})
+}
+
+func (m *memoryRepository) Discard(ctx context.Context, id string) (*Notification, error) {
+	return m.updateDeadLetter(id, func(n *Notification) { n.Status = StatusDiscarded })
+}
+
+func (m *memoryRepository) updateDeadLetter(id string, update func(n *Notification)) (*Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.notifications[id]
+	if !ok {
+		return nil, ErrNotificationNotFound
+	}
+	if n.Status != StatusFailed {
+		return nil, ErrNotDeadLetter
+	}
+	update(&n)
+	m.notifications[id] = n
+	return &n, nil
+}
+
+func (m *memoryRepository) GetPreferences(ctx context.Context, userID string) (*Preferences, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.preferences[userID]
+	if !ok {
+		return nil, ErrPreferencesNotFound
+	}
+	return &p, nil
+}
+
+func (m *memoryRepository) SavePreferences(ctx context.Context, prefs *Preferences) (*Preferences, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.preferences[prefs.UserID] = *prefs
+	return prefs, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// list returns the notifications matching keep, newest first, by channel
+func (m *memoryRepository) list(keep func(Notification) bool) []*Notification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var notifications []*Notification
+	for _, n := range m.notifications {
+		if keep(n) {
+			notifications = append(notifications, &n)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		a, b := notifications[i], notifications[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.Channel < b.Channel
+	})
+	return notifications
+}
+
+// fakeAccounts holds users' contact details by ID and fails lookups with err
+type fakeAccounts struct {
+	contacts map[string]Contact
+	err      error
+}
+
+func (a *fakeAccounts) GetContact(ctx context.Context, userID string) (*Contact, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	c, ok := a.contacts[userID]
+	if !ok {
+		return nil, ErrUnknownUser
+	}
+	return &c, nil
+}
+
+// fakeSender records the messages sent on its channel and fails sends with err
+type fakeSender struct {
+	channel string
+	mu      sync.Mutex
+	sent    []Message
+	err     error
+}
+
+func (s *fakeSender) Channel() string {
+	return s.channel
+}
+
+func (s *fakeSender) Send(ctx context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *fakeSender) Sent() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.sent...)
+}
+
+type testSenders struct {
+	email, sms, push *fakeSender
+}
+
+func setupService(t *testing.T) (*Service, *memoryRepository, testSenders) {
+	templates, err := DefaultTemplates()
+	if err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+	repo := newMemoryRepository()
+	senders := testSenders{
+		email: &fakeSender{channel: ChannelEmail},
+		sms:   &fakeSender{channel: ChannelSMS},
+		push:  &fakeSender{channel: ChannelPush},
+	}
+	accounts := &fakeAccounts{contacts: map[string]Contact{
+		"user-1": {ID: "user-1", Name: "Ada", Email: "ada@example.com", Phone: "+15550100"},
+	}}
+	log := logger.New("notification-test")
+	dispatcher := NewDispatcher(repo, []Sender{senders.email, senders.sms, senders.push}, log)
+	return NewService(repo, templates, accounts, dispatcher, log), repo, senders
+}
+
+func orderPaid(eventID string) *pb.PublishEventRequest {
+	return &pb.PublishEventRequest{
+		EventId: eventID,
+		Type:    "order.paid",
+		UserId:  "user-1",
+		Data:    map[string]string{"order_id": "order-1", "amount": "25.00", "currency": "USD"},
+	}
+}
+
+func TestPublishEvent(t *testing.T) {
+	service, _, senders := setupService(t)
+
+	resp, err := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// SMS is off and there is no push token by default
+	if len(resp.Notifications) != 1 || resp.Notifications[0].Channel != ChannelEmail || resp.Notifications[0].Status != StatusSent {
+		t.Fatalf("Expected one sent email, got %v", resp.Notifications)
+	}
+
+	sent := senders.email.Sent()
+	if len(sent) != 1 || sent[0].Recipient != "ada@example.com" || sent[0].Subject != "Order order-1 confirmed" {
+		t.Fatalf("Unexpected email %+v", sent)
+	}
+
+	again, err := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !again.Duplicate || len(again.Notifications) != 1 || again.Notifications[0].Id != resp.Notifications[0].Id {
+		t.Errorf("Expected the first publication's notifications, got %v", again)
+	}
+	if len(senders.email.Sent()) != 1 {
+		t.Error("Expected a duplicate event not to be sent again")
+	}
+}
+
+func TestPublishEvent_Preferences(t *testing.T) {
+	service, _, senders := setupService(t)
+
+	_, err := service.UpdatePreferences(context.Background(), &pb.UpdatePreferencesRequest{
+		UserId: "user-1", EmailEnabled: false, SmsEnabled: true, PushEnabled: true, PushToken: "device-1",
+	})
+	if err != nil {
+		t.Fatalf("Failed to update preferences: %v", err)
+	}
+
+	resp, err := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Notifications) != 2 {
+		t.Fatalf("Expected SMS and push notifications, got %v", resp.Notifications)
+	}
+	if len(senders.email.Sent()) != 0 {
+		t.Error("Expected no email")
+	}
+	if sms := senders.sms.Sent(); len(sms) != 1 || sms[0].Recipient != "+15550100" || sms[0].Subject != "" {
+		t.Errorf("Unexpected SMS %+v", sms)
+	}
+	if push := senders.push.Sent(); len(push) != 1 || push[0].Recipient != "device-1" {
+		t.Errorf("Unexpected push %+v", push)
+	}
+}
+
+func TestPublishEvent_Invalid(t *testing.T) {
+	service, repo, _ := setupService(t)
+
+	missingData := orderPaid("order.paid:order-1")
+	delete(missingData.Data, "amount")
+
+	tests := []struct {
+		name     string
+		req      *pb.PublishEventRequest
+		expected codes.Code
+	}{
+		{"missing event ID", &pb.PublishEventRequest{Type: "order.paid", UserId: "user-1"}, codes.InvalidArgument},
+		{"unknown type", &pb.PublishEventRequest{EventId: "e1", Type: "order.lost", UserId: "user-1"}, codes.InvalidArgument},
+		{"missing user", &pb.PublishEventRequest{EventId: "e1", Type: "order.paid"}, codes.InvalidArgument},
+		{"data missing a template key", missingData, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.PublishEvent(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	service.accounts.(*fakeAccounts).err = errors.New("account service down")
+	if _, err := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1")); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable when contact details cannot be looked up, got %v", err)
+	}
+	if len(repo.events) != 0 {
+		t.Errorf("Expected no event to be recorded, got %d", len(repo.events))
+	}
+}
+
+func TestPublishEvent_UnknownUserIsRecorded(t *testing.T) {
+	service, repo, senders := setupService(t)
+
+	req := orderPaid("order.paid:order-1")
+	req.UserId = "deleted-user"
+	resp, err := service.PublishEvent(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Notifications) != 0 || len(senders.email.Sent()) != 0 {
+		t.Errorf("Expected nothing to be sent, got %v", resp.Notifications)
+	}
+	if _, ok := repo.events[req.EventId]; !ok {
+		t.Error("Expected the event to be recorded")
+	}
+}
+
+func TestDispatcher_RetriesFailedSends(t *testing.T) {
+	service, repo, senders := setupService(t)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	service.dispatcher.now = func() time.Time { return now }
+
+	senders.email.err = errors.New("connection refused")
+	resp, err := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	n := resp.Notifications[0]
+	if n.Status != StatusPending || n.Attempts != 1 || n.LastError != "connection refused" {
+		t.Fatalf("Expected a pending notification after one attempt, got %+v", n)
+	}
+
+	// Not due until the backoff has passed
+	if attempted, _ := service.dispatcher.DeliverDue(context.Background()); attempted != 0 {
+		t.Errorf("Expected nothing due yet, got %d", attempted)
+	}
+
+	senders.email.err = nil
+	now = now.Add(retryBackoff)
+	attempted, err := service.dispatcher.DeliverDue(context.Background())
+	if err != nil || attempted != 1 {
+		t.Fatalf("Expected 1 notification attempted, got %d (%v)", attempted, err)
+	}
+	stored, _ := repo.GetByID(context.Background(), n.Id)
+	if stored.Status != StatusSent || stored.Attempts != 2 || stored.SentAt == nil {
+		t.Errorf("Expected the retry to send the notification, got %+v", stored)
+	}
+}
+
+func TestDispatcher_RejectedAndExhaustedSendsFail(t *testing.T) {
+	service, repo, senders := setupService(t)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	service.dispatcher.now = func() time.Time { return now }
+
+	senders.email.err = ErrRejected
+	resp, _ := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+	if n := resp.Notifications[0]; n.Status != StatusFailed || n.Attempts != 1 {
+		t.Errorf("Expected a rejected notification to fail at once, got %+v", n)
+	}
+
+	senders.email.err = errors.New("timeout")
+	resp, _ = service.PublishEvent(context.Background(), orderPaid("order.paid:order-2"))
+	id := resp.Notifications[0].Id
+	for i := 1; i < maxAttempts; i++ {
+		now = now.Add(time.Hour)
+		service.dispatcher.DeliverDue(context.Background())
+	}
+	stored, _ := repo.GetByID(context.Background(), id)
+	if stored.Status != StatusFailed || stored.Attempts != maxAttempts {
+		t.Errorf("Expected the notification to fail after %d attempts, got %+v", maxAttempts, stored)
+	}
+}
+
+func TestDispatcher_FailsOverToNextSender(t *testing.T) {
+	repo := newMemoryRepository()
+	primary := &fakeSender{channel: ChannelEmail, err: errors.New("connection refused")}
+	fallback := &fakeSender{channel: ChannelEmail}
+	dispatcher := NewDispatcher(repo, []Sender{primary, fallback}, logger.New("notification-test"))
+
+	n := &Notification{ID: "n-1", Channel: ChannelEmail, Recipient: "ada@example.com", Status: StatusPending}
+	if err := dispatcher.Deliver(context.Background(), n); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n.Status != StatusSent || n.Attempts != 1 || len(fallback.Sent()) != 1 {
+		t.Errorf("Expected the fallback sender to send the notification, got %+v", n)
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	service, repo, senders := setupService(t)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	service.dispatcher.now = func() time.Time { return now }
+
+	senders.email.err = ErrRejected
+	first, _ := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+	now = now.Add(time.Minute)
+	second, _ := service.PublishEvent(context.Background(), orderPaid("order.paid:order-2"))
+
+	dead, err := service.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{Channel: ChannelEmail})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dead.Total != 2 || dead.Notifications[0].Id != second.Notifications[0].Id || !dead.Notifications[0].FailedAt.AsTime().Equal(now) {
+		t.Fatalf("Expected both failed emails, most recent first, got %v", dead)
+	}
+
+	senders.email.err = nil
+	retried, err := service.RetryNotification(context.Background(), &pb.RetryNotificationRequest{
+		NotificationId: first.Notifications[0].Id, Recipient: "ada.lovelace@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n := retried.Notification; n.Status != StatusSent || n.Attempts != 1 || n.FailedAt != nil {
+		t.Errorf("Expected the retry to send the notification, got %+v", n)
+	}
+	if sent := senders.email.Sent(); len(sent) != 1 || sent[0].Recipient != "ada.lovelace@example.com" {
+		t.Errorf("Expected the email to go to the corrected recipient, got %+v", sent)
+	}
+
+	discarded, err := service.DiscardNotification(context.Background(), &pb.DiscardNotificationRequest{NotificationId: second.Notifications[0].Id})
+	if err != nil || discarded.Notification.Status != StatusDiscarded {
+		t.Fatalf("Expected the notification to be discarded, got %v, %v", discarded, err)
+	}
+	if dead, _ := service.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{}); dead.Total != 0 {
+		t.Errorf("Expected no dead letters left, got %v", dead)
+	}
+	if stored, _ := repo.GetByID(context.Background(), second.Notifications[0].Id); stored.Status != StatusDiscarded {
+		t.Errorf("Expected the discarded notification to be kept, got %+v", stored)
+	}
+}
+
+func TestDeadLetters_Refused(t *testing.T) {
+	service, _, _ := setupService(t)
+	sent, _ := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+
+	tests := []struct {
+		name     string
+		call     func() error
+		expected codes.Code
+	}{
+		{"unknown channel", func() error {
+			_, err := service.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{Channel: "FAX"})
+			return err
+		}, codes.InvalidArgument},
+		{"retry without ID", func() error {
+			_, err := service.RetryNotification(context.Background(), &pb.RetryNotificationRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"retry unknown", func() error {
+			_, err := service.RetryNotification(context.Background(), &pb.RetryNotificationRequest{NotificationId: "missing"})
+			return err
+		}, codes.NotFound},
+		{"retry sent", func() error {
+			_, err := service.RetryNotification(context.Background(), &pb.RetryNotificationRequest{NotificationId: sent.Notifications[0].Id})
+			return err
+		}, codes.FailedPrecondition},
+		{"discard sent", func() error {
+			_, err := service.DiscardNotification(context.Background(), &pb.DiscardNotificationRequest{NotificationId: sent.Notifications[0].Id})
+			return err
+		}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestListNotifications(t *testing.T) {
+	service, _, _ := setupService(t)
+	service.PublishEvent(context.Background(), orderPaid("order.paid:order-1"))
+	service.PublishEvent(context.Background(), &pb.PublishEventRequest{EventId: "user.registered:user-1", Type: "user.registered", UserId: "user-1"})
+
+	resp, err := service.ListNotifications(context.Background(), &pb.ListNotificationsRequest{UserId: "user-1", Status: StatusSent})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || len(resp.Notifications) != 2 || resp.PageSize != 10 {
+		t.Errorf("Expected 2 sent notifications, got %v", resp)
+	}
+
+	if _, err := service.ListNotifications(context.Background(), &pb.ListNotificationsRequest{UserId: "user-1", Status: "READ"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown status, got %v", err)
+	}
+}
+
+func TestGetPreferences_Defaults(t *testing.T) {
+	service, _, _ := setupService(t)
+
+	resp, err := service.GetPreferences(context.Background(), &pb.GetPreferencesRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	p := resp.Preferences
+	if !p.EmailEnabled || p.SmsEnabled || !p.PushEnabled || p.UpdatedAt != nil {
+		t.Errorf("Expected the default preferences, got %+v", p)
+	}
+}
+
+func TestGetNotification_NotFound(t *testing.T) {
+	service, _, _ := setupService(t)
+
+	if _, err := service.GetNotification(context.Background(), &pb.GetNotificationRequest{NotificationId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListSentEmails(t *testing.T) {
+	service, _, _ := setupService(t)
+	if _, err := service.ListSentEmails(context.Background(), &pb.ListSentEmailsRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition outside sandbox mode, got %v", err)
+	}
+
+	sandbox := NewSandbox()
+	service.dispatcher = NewDispatcher(service.repo, sandbox.Senders(), service.log)
+	service.WithSandbox(sandbox)
+	service.accounts.(*fakeAccounts).contacts["user-2"] = Contact{ID: "user-2", Name: "Bob", Email: SandboxRejectedEmail}
+
+	if _, err := service.PublishEvent(context.Background(), orderPaid("order.paid:order-1")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rejected := orderPaid("order.paid:order-2")
+	rejected.UserId = "user-2"
+	resp, err := service.PublishEvent(context.Background(), rejected)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Notifications[0].Status != StatusFailed {
+		t.Errorf("Expected the sandbox to reject %s, got %+v", SandboxRejectedEmail, resp.Notifications[0])
+	}
+
+	sent, err := service.ListSentEmails(context.Background(), &pb.ListSentEmailsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sent.Messages) != 1 || sent.Messages[0].Recipient != "ada@example.com" || sent.Messages[0].Subject != "Order order-1 confirmed" {
+		t.Errorf("Expected the email to ada@example.com only, got %v", sent.Messages)
+	}
+	if other, _ := service.ListSentEmails(context.Background(), &pb.ListSentEmailsRequest{Recipient: "bob@example.com"}); len(other.Messages) != 0 {
+		t.Errorf("Expected no email to bob@example.com, got %v", other.Messages)
+	}
+	if sms, _ := service.ListSentSMS(context.Background(), &pb.ListSentSMSRequest{}); len(sms.Messages) != 0 {
+		t.Errorf("Expected no SMS, got %v", sms.Messages)
+	}
+}
+
+func TestReadState(t *testing.T) {
+	service, _, _ := setupService(t)
+	ctx := context.Background()
+	if _, err := service.GetUnreadCount(ctx, &pb.GetUnreadCountRequest{UserId: "user-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without a read state, got %v", err)
+	}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	service.WithReadState(readstate.NewRedisStore(client, ReadStatePrefix, 0))
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	if _, err := service.PublishEvent(ctx, orderPaid("order.paid:order-1")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	firstAt := now
+	now = now.Add(time.Minute)
+	if _, err := service.PublishEvent(ctx, orderPaid("order.paid:order-2")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	unread, err := service.GetUnreadCount(ctx, &pb.GetUnreadCountRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if unread.Total != 2 || unread.Unread[ChannelEmail] != 2 || len(unread.ReadUpTo) != 0 {
+		t.Errorf("Expected 2 unread emails and no marks, got %v", unread)
+	}
+
+	marked, err := service.MarkRead(ctx, &pb.MarkReadRequest{UserId: "user-1", ReadUpTo: map[string]*timestamppb.Timestamp{ChannelEmail: timestamppb.New(firstAt)}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !marked.ReadUpTo[ChannelEmail].AsTime().Equal(firstAt) {
+		t.Errorf("Expected emails read up to %v, got %v", firstAt, marked.ReadUpTo)
+	}
+	if unread, _ := service.GetUnreadCount(ctx, &pb.GetUnreadCountRequest{UserId: "user-1"}); unread.Total != 1 || !unread.ReadUpTo[ChannelEmail].AsTime().Equal(firstAt) {
+		t.Errorf("Expected 1 unread email after the first was read, got %v", unread)
+	}
+
+	// A mark in the future is taken as now, so later notifications are unread
+	future := map[string]*timestamppb.Timestamp{ChannelEmail: timestamppb.New(now.Add(time.Hour))}
+	if _, err := service.MarkRead(ctx, &pb.MarkReadRequest{UserId: "user-1", ReadUpTo: future}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	service.PublishEvent(ctx, orderPaid("order.paid:order-3"))
+	if unread, _ := service.GetUnreadCount(ctx, &pb.GetUnreadCountRequest{UserId: "user-1"}); unread.Total != 1 {
+		t.Errorf("Expected only the latest email unread, got %v", unread)
+	}
+
+	// A mark never moves backwards
+	if marked, _ := service.MarkRead(ctx, &pb.MarkReadRequest{UserId: "user-1", ReadUpTo: map[string]*timestamppb.Timestamp{ChannelEmail: timestamppb.New(firstAt)}}); !marked.ReadUpTo[ChannelEmail].AsTime().After(firstAt) {
+		t.Errorf("Expected the later mark to be kept, got %v", marked.ReadUpTo)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.MarkReadRequest
+	}{
+		{"missing user", &pb.MarkReadRequest{ReadUpTo: future}},
+		{"missing marks", &pb.MarkReadRequest{UserId: "user-1"}},
+		{"unknown channel", &pb.MarkReadRequest{UserId: "user-1", ReadUpTo: map[string]*timestamppb.Timestamp{"FAX": timestamppb.New(now)}}},
+		{"missing time", &pb.MarkReadRequest{UserId: "user-1", ReadUpTo: map[string]*timestamppb.Timestamp{ChannelSMS: nil}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.MarkRead(ctx, tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TwilioConfig holds the settings of the Twilio SMS sender
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	// From is the Twilio phone number messages are sent from
+	From string
+	// BaseURL defaults to https://api.twilio.com
+	BaseURL string
+	Timeout time.Duration
+}
+
+// TwilioSender sends SMS through the Twilio Messages API
+type TwilioSender struct {
+	cfg        TwilioConfig
+	httpClient *http.Client
+}
+
+// NewTwilioSender creates a new Twilio SMS sender
+func NewTwilioSender(cfg TwilioConfig) (*TwilioSender, error) {
+	if cfg.AccountSID == "" || cfg.AuthToken == "" || cfg.From == "" {
+		return nil, errors.New("twilio: account SID, auth token and from number are required")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.twilio.com"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &TwilioSender{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Channel returns SMS
+func (s *TwilioSender) Channel() string {
+	return ChannelSMS
+}
+
+type twilioError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Send sends the message body as an SMS to the recipient's phone number
+func (s *TwilioSender) Send(ctx context.Context, msg Message) error {
+	form := url.Values{}
+	form.Set("To", msg.Recipient)
+	form.Set("From", s.cfg.From)
+	form.Set("Body", msg.Body)
+
+	endpoint := s.cfg.BaseURL + "/2010-04-01/Accounts/" + url.PathEscape(s.cfg.AccountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.cfg.AccountSID, s.cfg.AuthToken)
+
+	code, body, err := send(s.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("twilio: request failed: %w", err)
+	}
+	if code != http.StatusCreated && code != http.StatusOK {
+		var decoded twilioError
+		_ = json.Unmarshal(body, &decoded)
+		return statusError("twilio", code, decoded.Message)
+	}
+	return nil
+}
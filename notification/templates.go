@@ -0,0 +1,174 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// templateSuffix is the file extension of notification templates
+const templateSuffix = ".tmpl"
+
+// maxSubjectLength matches the subject column of notifications
+const maxSubjectLength = 255
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// ErrNoTemplate is returned when an event type has no template for a channel
+var ErrNoTemplate = errors.New("no template for event and channel")
+
+// channelNames maps the channel part of a template file name to its channel
+var channelNames = map[string]string{
+	"email": ChannelEmail,
+	"sms":   ChannelSMS,
+	"push":  ChannelPush,
+}
+
+// TemplateData is what notification templates are executed with
+type TemplateData struct {
+	// Event is the event type, e.g. order.paid
+	Event string
+	User  Contact
+	// Data is the event's data. Referring to a key the event does not have,
+	// as in {{.Data.order_id}}, fails the rendering; use
+	// {{index .Data "key"}} for optional keys.
+	Data map[string]string
+}
+
+// Templates holds the notification templates of each event type and channel.
+// A template is a file named <event type>.<channel>.tmpl, for example
+// order.paid.email.tmpl, that defines a "body" template and, for email and
+// push, a "subject" template.
+type Templates struct {
+	templates map[string]map[string]*template.Template
+}
+
+// DefaultTemplates returns the templates built into the service
+func DefaultTemplates() (*Templates, error) {
+	fsys, err := fs.Sub(defaultTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return LoadTemplates(fsys)
+}
+
+// LoadTemplates parses the templates in the root of fsys
+func LoadTemplates(fsys fs.FS) (*Templates, error) {
+	names, err := fs.Glob(fsys, "*"+templateSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{templates: make(map[string]map[string]*template.Template)}
+	for _, name := range names {
+		eventType, channel, err := parseTemplateName(name)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", name, err)
+		}
+		if tmpl.Lookup("body") == nil {
+			return nil, fmt.Errorf("template %s does not define a body", name)
+		}
+		if channel != ChannelSMS && tmpl.Lookup("subject") == nil {
+			return nil, fmt.Errorf("template %s does not define a subject", name)
+		}
+
+		if t.templates[eventType] == nil {
+			t.templates[eventType] = make(map[string]*template.Template)
+		}
+		t.templates[eventType][channel] = tmpl
+	}
+
+	if len(t.templates) == 0 {
+		return nil, errors.New("no notification templates found")
+	}
+	return t, nil
+}
+
+// parseTemplateName splits a template file name into its event type and channel
+func parseTemplateName(name string) (string, string, error) {
+	base := strings.TrimSuffix(path.Base(name), templateSuffix)
+	i := strings.LastIndex(base, ".")
+	if i <= 0 {
+		return "", "", fmt.Errorf("template %s is not named <event type>.<channel>%s", name, templateSuffix)
+	}
+	channel, ok := channelNames[base[i+1:]]
+	if !ok {
+		return "", "", fmt.Errorf("template %s has unknown channel %q", name, base[i+1:])
+	}
+	return base[:i], channel, nil
+}
+
+// EventTypes returns the event types that have templates, sorted
+func (t *Templates) EventTypes() []string {
+	types := make([]string, 0, len(t.templates))
+	for eventType := range t.templates {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Channels returns the channels an event type has templates for, sorted
+func (t *Templates) Channels(eventType string) []string {
+	channels := make([]string, 0, len(t.templates[eventType]))
+	for channel := range t.templates[eventType] {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Render executes an event type's template for channel and returns the
+// subject, empty for SMS, and body
+func (t *Templates) Render(eventType, channel string, data TemplateData) (string, string, error) {
+	tmpl, ok := t.templates[eventType][channel]
+	if !ok {
+		return "", "", ErrNoTemplate
+	}
+
+	var subject string
+	if tmpl.Lookup("subject") != nil && channel != ChannelSMS {
+		s, err := execute(tmpl, "subject", data)
+		if err != nil {
+			return "", "", err
+		}
+		// Subjects are a single line
+		subject = strings.Join(strings.Fields(s), " ")
+		if len(subject) > maxSubjectLength {
+			return "", "", fmt.Errorf("subject longer than %d bytes", maxSubjectLength)
+		}
+	}
+
+	body, err := execute(tmpl, "body", data)
+	if err != nil {
+		return "", "", err
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", "", errors.New("empty body")
+	}
+	return subject, body, nil
+}
+
+func execute(tmpl *template.Template, name string, data TemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
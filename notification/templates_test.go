@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDefaultTemplates(t *testing.T) {
+	templates, err := DefaultTemplates()
+	if err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+
+	expected := []string{"order.paid", "product.back_in_stock", "report.failed", "report.ready", "shipment.delivered", "user.registered"}
+	if types := templates.EventTypes(); strings.Join(types, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected event types %v, got %v", expected, types)
+	}
+
+	data := TemplateData{
+		Event: "shipment.delivered",
+		User:  Contact{Name: "Ada"},
+		Data:  map[string]string{"order_id": "order-1", "tracking_number": "FR1"},
+	}
+	subject, body, err := templates.Render("shipment.delivered", ChannelEmail, data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if subject != "Order order-1 delivered" {
+		t.Errorf("Unexpected subject %q", subject)
+	}
+	if !strings.Contains(body, "has been delivered.") || !strings.HasPrefix(body, "Hi Ada,") {
+		t.Errorf("Unexpected body %q", body)
+	}
+
+	data.Data["location"] = "Austin, TX"
+	if _, body, _ = templates.Render("shipment.delivered", ChannelEmail, data); !strings.Contains(body, "delivered in Austin, TX.") {
+		t.Errorf("Expected the optional location, got %q", body)
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	templates, err := DefaultTemplates()
+	if err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+
+	if _, _, err := templates.Render("user.registered", ChannelSMS, TemplateData{}); err != ErrNoTemplate {
+		t.Errorf("Expected ErrNoTemplate, got %v", err)
+	}
+	_, _, err = templates.Render("order.paid", ChannelEmail, TemplateData{Data: map[string]string{"order_id": "order-1"}})
+	if err == nil || !strings.Contains(err.Error(), "amount") {
+		t.Errorf("Expected an error naming the missing key, got %v", err)
+	}
+}
+
+func TestLoadTemplates_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		body string
+	}{
+		{"no channel", "order.tmpl", `{{define "body"}}x{{end}}`},
+		{"unknown channel", "order.paid.fax.tmpl", `{{define "body"}}x{{end}}`},
+		{"no body", "order.paid.sms.tmpl", `{{define "subject"}}x{{end}}`},
+		{"email without subject", "order.paid.email.tmpl", `{{define "body"}}x{{end}}`},
+		{"syntax error", "order.paid.sms.tmpl", `{{define "body"}}{{.Data.x{{end}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{tt.file: {Data: []byte(tt.body)}}
+			if _, err := LoadTemplates(fsys); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
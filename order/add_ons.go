@@ -0,0 +1,138 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddOnGiftWrap is the add-on a cart line gets when gift wrap is requested
+const AddOnGiftWrap = "gift_wrap"
+
+// maxGiftMessageLength matches the gift_message column of order_items
+const maxGiftMessageLength = 250
+
+// ParseAddOns parses the add-ons offered on order lines from a
+// comma-separated list of code=product_id, such as
+// "gift_wrap=<product id>,engraving=<product id>". Each add-on is sold as the
+// catalog product it names and priced like any other product.
+func ParseAddOns(s string) (map[string]string, error) {
+	addOns := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		code, productID, ok := strings.Cut(entry, "=")
+		code, productID = strings.TrimSpace(code), strings.TrimSpace(productID)
+		if !ok || code == "" || productID == "" {
+			return nil, fmt.Errorf("invalid add-on %q: expected code=product_id", entry)
+		}
+		if _, dup := addOns[code]; dup {
+			return nil, fmt.Errorf("invalid add-on %q: code %s appears more than once", entry, code)
+		}
+		addOns[code] = productID
+	}
+	return addOns, nil
+}
+
+// WithAddOns offers paid add-ons on order lines, keyed by code with the
+// catalog product each is sold as. Without add-ons, carts asking for gift
+// wrap or an add-on are rejected.
+func (s *Service) WithAddOns(addOns map[string]string) *Service {
+	s.addOns = addOns
+	return s
+}
+
+// addOnCodes returns the add-ons a cart line asks for, gift wrap first
+func addOnCodes(item *pb.CartItem) []string {
+	if !item.GiftWrap {
+		return item.AddOns
+	}
+	return append([]string{AddOnGiftWrap}, item.AddOns...)
+}
+
+// addOnProblem validates the gift options and add-ons of a cart line and
+// returns a message describing the first problem, or ""
+func addOnProblem(item *pb.CartItem) string {
+	if len([]rune(item.GiftMessage)) > maxGiftMessageLength {
+		return fmt.Sprintf("gift_message cannot be longer than %d characters", maxGiftMessageLength)
+	}
+	seen := make(map[string]bool, len(item.AddOns)+1)
+	for _, code := range addOnCodes(item) {
+		switch {
+		case code == "":
+			return "add-on code is required"
+		case seen[code]:
+			return "add-on " + code + " appears more than once on product " + item.ProductId
+		}
+		seen[code] = true
+	}
+	return ""
+}
+
+// priceAddOns snapshots the add-ons of a cart line, one per unit of the line,
+// at the price the catalog or pricing service gives their products
+func (s *Service) priceAddOns(ctx context.Context, item *pb.CartItem, terms PriceTerms) ([]*OrderItemAddOn, error) {
+	wanted := addOnCodes(item)
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+
+	addOns := make([]*OrderItemAddOn, len(wanted))
+	for i, code := range wanted {
+		productID, ok := s.addOns[code]
+		if !ok {
+			return nil, s.unknownAddOnError(ctx, code)
+		}
+		snapshot, err := s.catalog.Snapshot(ctx, productID, item.Quantity, terms)
+		if err != nil {
+			return nil, s.snapshotError(ctx, err, "add-on "+code, productID, terms.Currency)
+		}
+		addOns[i] = &OrderItemAddOn{
+			Code:      code,
+			ProductID: snapshot.ProductID,
+			SKU:       snapshot.SKU,
+			Name:      snapshot.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: snapshot.UnitPrice,
+			LineTotal: snapshot.LineTotal,
+		}
+	}
+	return addOns, nil
+}
+
+// unknownAddOnError rejects an add-on that is not offered. Gift wrap is a
+// field of the cart rather than a code the caller chose, so it not being
+// offered is a precondition rather than a bad argument.
+func (s *Service) unknownAddOnError(ctx context.Context, code string) error {
+	s.log.Warn(ctx, "Create order failed: add-on not offered", map[string]interface{}{"add_on": code})
+	if code == AddOnGiftWrap {
+		return status.Error(codes.FailedPrecondition, "gift wrap is not offered")
+	}
+	return status.Error(codes.InvalidArgument, "add-on "+code+" is not offered")
+}
+
+// snapshotError maps an error pricing a product or add-on, described by
+// what, to a gRPC status
+func (s *Service) snapshotError(ctx context.Context, err error, what, productID, currency string) error {
+	if errors.Is(err, ErrProductUnavailable) {
+		s.log.Warn(ctx, "Create order failed: product unavailable", map[string]interface{}{"product_id": productID})
+		return status.Error(codes.FailedPrecondition, what+" is unavailable")
+	}
+	if errors.Is(err, ErrNoPrice) {
+		s.log.Warn(ctx, "Create order failed: no price in currency", map[string]interface{}{"product_id": productID, "currency": currency})
+		return status.Error(codes.FailedPrecondition, what+" has no price in "+currency)
+	}
+	if errors.Is(err, ErrInvalidQuantity) {
+		s.log.Warn(ctx, "Create order failed: invalid quantity", map[string]interface{}{"product_id": productID, "error": err.Error()})
+		return status.Error(codes.InvalidArgument, what+": "+err.Error())
+	}
+	s.log.Error(ctx, "Failed to price order item", map[string]interface{}{"error": err.Error(), "product_id": productID})
+	return status.Error(codes.Unavailable, "failed to price order")
+}
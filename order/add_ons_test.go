@@ -0,0 +1,110 @@
+package order
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseAddOns(t *testing.T) {
+	addOns, err := ParseAddOns("gift_wrap=wrap-1, engraving = engrave-1,")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := map[string]string{"gift_wrap": "wrap-1", "engraving": "engrave-1"}
+	if !reflect.DeepEqual(addOns, expected) {
+		t.Errorf("Expected %v, got %v", expected, addOns)
+	}
+
+	for _, invalid := range []string{"gift_wrap", "=wrap-1", "gift_wrap=", "gift_wrap=a,gift_wrap=b"} {
+		if _, err := ParseAddOns(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestCreateOrder_AddOns(t *testing.T) {
+	var got *Order
+	mockRepo := &MockRepository{
+		CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+			got = order
+			return order, nil
+		},
+	}
+	catalog := &mockCatalog{prices: map[string]float64{"p1": 20, "p2": 5, "wrap": 2.5, "engrave": 7}}
+	service := setupService(mockRepo, catalog).WithAddOns(map[string]string{AddOnGiftWrap: "wrap", "engraving": "engrave"})
+
+	resp, err := service.CreateOrder(callerContext("checkout-service", auth.RoleService), &pb.CreateOrderRequest{
+		UserId: "user-1",
+		Items: []*pb.CartItem{
+			{ProductId: "p1", Quantity: 2, GiftWrap: true, GiftMessage: "Happy birthday!", AddOns: []string{"engraving"}},
+			{ProductId: "p2", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 40 for p1, 5 for gift wrap, 14 for engraving and 5 for p2
+	if got.TotalAmount != 64 {
+		t.Errorf("Expected total 64, got %v", got.TotalAmount)
+	}
+	expected := []*OrderItemAddOn{
+		{Code: AddOnGiftWrap, ProductID: "wrap", SKU: "SKU-wrap", Name: "Product wrap", Quantity: 2, UnitPrice: 2.5, LineTotal: 5},
+		{Code: "engraving", ProductID: "engrave", SKU: "SKU-engrave", Name: "Product engrave", Quantity: 2, UnitPrice: 7, LineTotal: 14},
+	}
+	if !reflect.DeepEqual(got.Items[0].AddOns, expected) {
+		t.Errorf("Unexpected add-ons %+v", got.Items[0].AddOns)
+	}
+	if got.Items[0].LineTotal != 40 || got.Items[0].GiftMessage != "Happy birthday!" {
+		t.Errorf("Unexpected first item %+v", got.Items[0])
+	}
+
+	first := resp.Order.Items[0]
+	if !first.GiftWrap || first.GiftMessage != "Happy birthday!" || len(first.AddOns) != 2 || first.AddOns[1].LineTotal != 14 {
+		t.Errorf("Unexpected first item %v", first)
+	}
+	if second := resp.Order.Items[1]; second.GiftWrap || len(second.AddOns) != 0 {
+		t.Errorf("Unexpected second item %v", second)
+	}
+}
+
+func TestCreateOrder_AddOnErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		addOns   map[string]string
+		item     *pb.CartItem
+		expected codes.Code
+	}{
+		{"long gift message", nil, &pb.CartItem{ProductId: "p1", Quantity: 1, GiftMessage: strings.Repeat("x", maxGiftMessageLength+1)}, codes.InvalidArgument},
+		{"empty add-on", nil, &pb.CartItem{ProductId: "p1", Quantity: 1, AddOns: []string{""}}, codes.InvalidArgument},
+		{"duplicate add-on", nil, &pb.CartItem{ProductId: "p1", Quantity: 1, AddOns: []string{"engraving", "engraving"}}, codes.InvalidArgument},
+		{"gift wrap twice", nil, &pb.CartItem{ProductId: "p1", Quantity: 1, GiftWrap: true, AddOns: []string{AddOnGiftWrap}}, codes.InvalidArgument},
+		{"add-on not offered", map[string]string{AddOnGiftWrap: "wrap"}, &pb.CartItem{ProductId: "p1", Quantity: 1, AddOns: []string{"engraving"}}, codes.InvalidArgument},
+		{"gift wrap not offered", nil, &pb.CartItem{ProductId: "p1", Quantity: 1, GiftWrap: true}, codes.FailedPrecondition},
+		{"add-on unavailable", map[string]string{AddOnGiftWrap: "gone"}, &pb.CartItem{ProductId: "p1", Quantity: 1, GiftWrap: true}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+					t.Fatal("Expected no order to be created")
+					return nil, nil
+				},
+			}
+			service := setupService(mockRepo, &mockCatalog{prices: map[string]float64{"p1": 10, "wrap": 2}}).WithAddOns(tt.addOns)
+
+			_, err := service.CreateOrder(callerContext("checkout-service", auth.RoleService), &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{tt.item}})
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
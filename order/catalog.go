@@ -0,0 +1,128 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	pricingpb "github.com/Ujjwaljain16/E-commerce-Backend/pricing/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrProductUnavailable is returned when a product does not exist or is not for sale
+	ErrProductUnavailable = errors.New("product unavailable")
+	// ErrInvalidQuantity is returned when a quantity breaks a product's order quantity rules
+	ErrInvalidQuantity = errors.New("invalid quantity")
+	// ErrNoPrice is returned when the pricing service has no price for a
+	// product in the order currency
+	ErrNoPrice = errors.New("no price in currency")
+)
+
+// catalogStatusActive is the catalog status of products that are for sale
+const catalogStatusActive = "ACTIVE"
+
+// ProductSnapshot is the catalog data copied onto an order line
+type ProductSnapshot struct {
+	ProductID string
+	SKU       string
+	Name      string
+	UnitPrice float64
+	LineTotal float64
+}
+
+// PriceTerms are who an order is priced for, where and in which currency
+type PriceTerms struct {
+	CustomerSegment string
+	Channel         string
+	Currency        string
+}
+
+// Catalog looks up and prices products for order lines
+type Catalog interface {
+	// Snapshot returns the current details and price of quantity units of a
+	// product. It returns ErrProductUnavailable, ErrNoPrice or an error
+	// wrapping ErrInvalidQuantity when the line cannot be ordered.
+	Snapshot(ctx context.Context, productID string, quantity int32, terms PriceTerms) (*ProductSnapshot, error)
+}
+
+type grpcCatalog struct {
+	client  catalogpb.CatalogServiceClient
+	pricing pricingpb.PricingServiceClient
+}
+
+// NewGRPCCatalog creates a Catalog backed by the catalog service. Lines are
+// priced at the catalog price and price terms are ignored.
+func NewGRPCCatalog(client catalogpb.CatalogServiceClient) Catalog {
+	return &grpcCatalog{client: client}
+}
+
+// NewPricedCatalog creates a Catalog that reads products from the catalog
+// service and prices them with the pricing service
+func NewPricedCatalog(client catalogpb.CatalogServiceClient, pricing pricingpb.PricingServiceClient) Catalog {
+	return &grpcCatalog{client: client, pricing: pricing}
+}
+
+// Snapshot reads the product and its price for the quantity, which applies
+// sale prices, quantity tiers and order quantity rules
+func (c *grpcCatalog) Snapshot(ctx context.Context, productID string, quantity int32, terms PriceTerms) (*ProductSnapshot, error) {
+	product, err := c.client.GetProduct(ctx, &catalogpb.GetProductRequest{Id: productID})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrProductUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product.Product.Status != catalogStatusActive {
+		return nil, ErrProductUnavailable
+	}
+
+	snapshot := &ProductSnapshot{
+		ProductID: productID,
+		SKU:       product.Product.Sku,
+		Name:      product.Product.Name,
+	}
+	if c.pricing != nil {
+		price, err := c.pricing.ResolvePrice(ctx, &pricingpb.ResolvePriceRequest{
+			ProductId:       productID,
+			Quantity:        quantity,
+			CustomerSegment: terms.CustomerSegment,
+			Channel:         terms.Channel,
+			Currency:        terms.Currency,
+		})
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, ErrNoPrice
+		}
+		if err := priceError(err); err != nil {
+			return nil, err
+		}
+		snapshot.UnitPrice = price.Price.UnitPrice
+		snapshot.LineTotal = price.Price.LineTotal
+		return snapshot, nil
+	}
+
+	price, err := c.client.GetPriceForQuantity(ctx, &catalogpb.GetPriceForQuantityRequest{ProductId: productID, Quantity: quantity})
+	if err := priceError(err); err != nil {
+		return nil, err
+	}
+	snapshot.UnitPrice = price.UnitPrice
+	snapshot.LineTotal = price.TotalPrice
+	return snapshot, nil
+}
+
+// priceError maps an error pricing a line with the catalog or pricing
+// service; it is nil when the line was priced
+func priceError(err error) error {
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.NotFound:
+		return ErrProductUnavailable
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidQuantity, status.Convert(err).Message())
+	default:
+		return fmt.Errorf("failed to price product: %w", err)
+	}
+}
@@ -0,0 +1,123 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	pricingpb "github.com/Ujjwaljain16/E-commerce-Backend/pricing/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCatalogClient serves one product from the catalog service client interface
+type fakeCatalogClient struct {
+	catalogpb.CatalogServiceClient
+	product  *catalogpb.Product
+	getErr   error
+	priceErr error
+}
+
+func (c *fakeCatalogClient) GetProduct(ctx context.Context, req *catalogpb.GetProductRequest, opts ...grpc.CallOption) (*catalogpb.GetProductResponse, error) {
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	return &catalogpb.GetProductResponse{Product: c.product}, nil
+}
+
+func (c *fakeCatalogClient) GetPriceForQuantity(ctx context.Context, req *catalogpb.GetPriceForQuantityRequest, opts ...grpc.CallOption) (*catalogpb.GetPriceForQuantityResponse, error) {
+	if c.priceErr != nil {
+		return nil, c.priceErr
+	}
+	return &catalogpb.GetPriceForQuantityResponse{
+		ProductId:  req.ProductId,
+		Quantity:   req.Quantity,
+		UnitPrice:  9.5,
+		TotalPrice: 9.5 * float64(req.Quantity),
+	}, nil
+}
+
+func TestGRPCCatalogSnapshot(t *testing.T) {
+	client := &fakeCatalogClient{product: &catalogpb.Product{Id: "p1", Sku: "MUG-1", Name: "Mug", Price: 10, Status: "ACTIVE"}}
+
+	snapshot, err := NewGRPCCatalog(client).Snapshot(context.Background(), "p1", 4, PriceTerms{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if snapshot.SKU != "MUG-1" || snapshot.Name != "Mug" || snapshot.UnitPrice != 9.5 || snapshot.LineTotal != 38 {
+		t.Errorf("Unexpected snapshot %+v", snapshot)
+	}
+}
+
+func TestGRPCCatalogSnapshot_Errors(t *testing.T) {
+	active := &catalogpb.Product{Id: "p1", Status: "ACTIVE"}
+	tests := []struct {
+		name   string
+		client *fakeCatalogClient
+		want   error
+	}{
+		{"missing product", &fakeCatalogClient{getErr: status.Error(codes.NotFound, "product not found")}, ErrProductUnavailable},
+		{"draft product", &fakeCatalogClient{product: &catalogpb.Product{Id: "p1", Status: "DRAFT"}}, ErrProductUnavailable},
+		{"quantity rule", &fakeCatalogClient{product: active, priceErr: status.Error(codes.InvalidArgument, "quantity must be at least 5")}, ErrInvalidQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGRPCCatalog(tt.client).Snapshot(context.Background(), "p1", 1, PriceTerms{})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	_, err := NewGRPCCatalog(&fakeCatalogClient{getErr: status.Error(codes.Unavailable, "connection refused")}).Snapshot(context.Background(), "p1", 1, PriceTerms{})
+	if err == nil || errors.Is(err, ErrProductUnavailable) {
+		t.Errorf("Expected a transport error, got %v", err)
+	}
+}
+
+// fakePricingClient resolves every price from the pricing service client
+// interface
+type fakePricingClient struct {
+	pricingpb.PricingServiceClient
+	last *pricingpb.ResolvePriceRequest
+	err  error
+}
+
+func (c *fakePricingClient) ResolvePrice(ctx context.Context, req *pricingpb.ResolvePriceRequest, opts ...grpc.CallOption) (*pricingpb.ResolvePriceResponse, error) {
+	c.last = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &pricingpb.ResolvePriceResponse{Price: &pricingpb.ResolvedPrice{
+		ProductId: req.ProductId,
+		Quantity:  req.Quantity,
+		Currency:  req.Currency,
+		UnitPrice: 8,
+		LineTotal: 8 * float64(req.Quantity),
+	}}, nil
+}
+
+func TestPricedCatalogSnapshot(t *testing.T) {
+	client := &fakeCatalogClient{product: &catalogpb.Product{Id: "p1", Sku: "MUG-1", Name: "Mug", Price: 10, Status: "ACTIVE"}}
+	pricing := &fakePricingClient{}
+
+	terms := PriceTerms{CustomerSegment: "wholesale", Channel: "pos", Currency: "EUR"}
+	snapshot, err := NewPricedCatalog(client, pricing).Snapshot(context.Background(), "p1", 4, terms)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if snapshot.SKU != "MUG-1" || snapshot.UnitPrice != 8 || snapshot.LineTotal != 32 {
+		t.Errorf("Unexpected snapshot %+v", snapshot)
+	}
+	if pricing.last.CustomerSegment != "wholesale" || pricing.last.Channel != "pos" || pricing.last.Currency != "EUR" {
+		t.Errorf("Expected the price terms passed on, got %v", pricing.last)
+	}
+
+	pricing.err = status.Error(codes.FailedPrecondition, "product p1 has no price in EUR")
+	if _, err := NewPricedCatalog(client, pricing).Snapshot(context.Background(), "p1", 4, terms); err != ErrNoPrice {
+		t.Errorf("Expected ErrNoPrice, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package order
+
+import (
+	"context"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EraseUserData anonymizes a customer's orders for the privacy service when
+// their account is deleted. The orders are kept for accounting with the
+// customer replaced by the pseudonym. Erasing a customer again changes
+// nothing.
+func (s *Service) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	var msg string
+	switch {
+	case req.UserId == "":
+		msg = "user_id is required"
+	case req.Pseudonym == "" || req.Pseudonym == req.UserId:
+		msg = "pseudonym is required and must differ from user_id"
+	case len(req.Pseudonym) > maxUserIDLength:
+		msg = "pseudonym is too long"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Erase user data failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	records, err := s.repo.AnonymizeUser(ctx, req.UserId, req.Pseudonym)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to erase user data")
+	}
+	return &pb.EraseUserDataResponse{
+		Records: records,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEraseUserData(t *testing.T) {
+	var gotUser, gotPseudonym string
+	mockRepo := &MockRepository{
+		AnonymizeFunc: func(ctx context.Context, userID, pseudonym string) (int32, error) {
+			gotUser, gotPseudonym = userID, pseudonym
+			return 3, nil
+		},
+	}
+	service := setupService(mockRepo, &mockCatalog{})
+
+	resp, err := service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{UserId: "user-1", Pseudonym: "erased-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Records != 3 {
+		t.Errorf("Expected 3 records, got %d", resp.Records)
+	}
+	if gotUser != "user-1" || gotPseudonym != "erased-1" {
+		t.Errorf("Expected user-1 to be anonymized as erased-1, got %s as %s", gotUser, gotPseudonym)
+	}
+}
+
+func TestEraseUserData_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *pb.EraseUserDataRequest
+		repoErr  error
+		expected codes.Code
+	}{
+		{"missing user ID", &pb.EraseUserDataRequest{Pseudonym: "erased-1"}, nil, codes.InvalidArgument},
+		{"missing pseudonym", &pb.EraseUserDataRequest{UserId: "user-1"}, nil, codes.InvalidArgument},
+		{"pseudonym is the user", &pb.EraseUserDataRequest{UserId: "user-1", Pseudonym: "user-1"}, nil, codes.InvalidArgument},
+		{"database error", &pb.EraseUserDataRequest{UserId: "user-1", Pseudonym: "erased-1"}, errors.New("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				AnonymizeFunc: func(ctx context.Context, userID, pseudonym string) (int32, error) {
+					if tt.repoErr != nil {
+						return 0, tt.repoErr
+					}
+					t.Fatal("Expected no anonymization")
+					return 0, nil
+				},
+			}
+			service := setupService(mockRepo, &mockCatalog{})
+
+			_, err := service.EraseUserData(context.Background(), tt.req)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
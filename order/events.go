@@ -0,0 +1,51 @@
+package order
+
+import (
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/outbox"
+)
+
+const (
+	// EventsTopic is the Kafka topic order events are published to
+	EventsTopic = "order-events"
+	// OutboxTable holds order events until they are published
+	OutboxTable = "order_outbox"
+)
+
+// EventOrderStatusChanged is published when an order moves to a new status
+const EventOrderStatusChanged = "order.status_changed"
+
+// events is the outbox order changes add their events to
+var events = outbox.New(OutboxTable, EventsTopic)
+
+// statusChangedEventData is the data of an order.status_changed event; it
+// carries the customer, so the event can be routed to them without loading
+// the order
+type statusChangedEventData struct {
+	OrderID     string    `json:"order_id"`
+	TenantID    string    `json:"tenant_id"`
+	UserID      string    `json:"user_id"`
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	TotalAmount float64   `json:"total_amount"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+// statusChangedEvent describes order o moving from status from
+func statusChangedEvent(o *Order, from string) outbox.Event {
+	return outbox.Event{
+		Type:       EventOrderStatusChanged,
+		Key:        o.ID,
+		OccurredAt: o.UpdatedAt,
+		Data: statusChangedEventData{
+			OrderID:     o.ID,
+			TenantID:    o.TenantID,
+			UserID:      o.UserID,
+			From:        from,
+			To:          o.Status,
+			TotalAmount: o.TotalAmount,
+			ChangedAt:   o.UpdatedAt.UTC(),
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	fulfillmentpb "github.com/Ujjwaljain16/E-commerce-Backend/fulfillment/pb"
+)
+
+type grpcFulfillment struct {
+	client fulfillmentpb.FulfillmentServiceClient
+}
+
+// NewGRPCFulfillment creates a Purchases that hands paid orders to the
+// fulfillment service to be picked, packed and shipped
+func NewGRPCFulfillment(client fulfillmentpb.FulfillmentServiceClient) Purchases {
+	return &grpcFulfillment{client: client}
+}
+
+// OrderPaid sends the order's lines. The fulfillment service allocates an
+// order once, so a report sent again changes nothing.
+func (f *grpcFulfillment) OrderPaid(ctx context.Context, order *Order) error {
+	lines := make([]*fulfillmentpb.OrderLine, len(order.Items))
+	for i, item := range order.Items {
+		lines[i] = &fulfillmentpb.OrderLine{
+			ProductId: item.ProductID,
+			Sku:       item.SKU,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+		}
+	}
+	_, err := f.client.FulfillOrder(ctx, &fulfillmentpb.FulfillOrderRequest{
+		OrderId: order.ID,
+		Lines:   lines,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fulfill order: %w", err)
+	}
+	return nil
+}
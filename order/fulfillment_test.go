@@ -0,0 +1,50 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fulfillmentpb "github.com/Ujjwaljain16/E-commerce-Backend/fulfillment/pb"
+	"google.golang.org/grpc"
+)
+
+// fakeFulfillmentClient records reports from the fulfillment service client
+// interface
+type fakeFulfillmentClient struct {
+	fulfillmentpb.FulfillmentServiceClient
+	got *fulfillmentpb.FulfillOrderRequest
+	err error
+}
+
+func (c *fakeFulfillmentClient) FulfillOrder(ctx context.Context, req *fulfillmentpb.FulfillOrderRequest, opts ...grpc.CallOption) (*fulfillmentpb.FulfillOrderResponse, error) {
+	c.got = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fulfillmentpb.FulfillOrderResponse{}, nil
+}
+
+func TestGRPCFulfillment_OrderPaid(t *testing.T) {
+	client := &fakeFulfillmentClient{}
+	fulfillment := NewGRPCFulfillment(client)
+
+	order := &Order{ID: "order-1", UserID: "user-1", Status: StatusPaid, Items: []*OrderItem{
+		{ProductID: "mug", SKU: "MUG-1", Name: "Mug", Quantity: 2, UnitPrice: 12.5, LineTotal: 25},
+	}}
+	if err := fulfillment.OrderPaid(context.Background(), order); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := client.got
+	if got.OrderId != "order-1" || len(got.Lines) != 1 {
+		t.Fatalf("Unexpected report %v", got)
+	}
+	if line := got.Lines[0]; line.ProductId != "mug" || line.Sku != "MUG-1" || line.Name != "Mug" || line.Quantity != 2 {
+		t.Errorf("Unexpected line %v", line)
+	}
+
+	client.err = errors.New("unavailable")
+	if err := fulfillment.OrderPaid(context.Background(), order); err == nil {
+		t.Error("Expected an error when the fulfillment service fails")
+	}
+}
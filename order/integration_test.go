@@ -0,0 +1,233 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	_ "github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Products served by the integration test catalog
+const (
+	integrationMug = "11111111-1111-1111-1111-111111111111"
+	integrationTea = "22222222-2222-2222-2222-222222222222"
+)
+
+// setupIntegrationTest creates a PostgreSQL container and returns a configured service
+func setupIntegrationTest(t *testing.T) (*Service, func()) {
+	t.Helper()
+	ctx := context.Background()
+
+	// Create PostgreSQL container
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start postgres container: %v", err)
+	}
+
+	// Get connection string
+	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("Failed to get connection string: %v", err)
+	}
+
+	// Connect to database
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// Run migrations
+	if err := runMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Create repository and service
+	log := logger.New("order-integration-test")
+	repo := NewPostgresRepository(db, log)
+	catalog := &mockCatalog{prices: map[string]float64{integrationMug: 12.5, integrationTea: 4.25}}
+	service := NewService(repo, catalog, NewLogPurchases(log), log)
+
+	// Cleanup function
+	cleanup := func() {
+		db.Close()
+		if err := pgContainer.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}
+
+	return service, cleanup
+}
+
+// runMigrations applies database schema
+func runMigrations(db *sql.DB) error {
+	migrations := []string{
+		`CREATE TABLE IF NOT EXISTS orders (
+			id UUID PRIMARY KEY,
+			user_id VARCHAR(255) NOT NULL,
+			status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'PAID', 'FULFILLED', 'DELIVERED', 'CANCELLED')),
+			total_amount DECIMAL(12, 2) NOT NULL CHECK (total_amount >= 0),
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			tenant_id VARCHAR(63) NOT NULL DEFAULT 'default',
+			quote_id UUID,
+			po_number VARCHAR(100) NOT NULL DEFAULT ''
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_orders_tenant_user ON orders(tenant_id, user_id, created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_orders_tenant_status ON orders(tenant_id, status, created_at DESC)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_quote ON orders(tenant_id, quote_id) WHERE quote_id IS NOT NULL`,
+		`CREATE TABLE IF NOT EXISTS order_items (
+			id UUID PRIMARY KEY,
+			order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			product_id UUID NOT NULL,
+			sku VARCHAR(100) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			quantity INTEGER NOT NULL CHECK (quantity > 0),
+			unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+			line_total DECIMAL(12, 2) NOT NULL CHECK (line_total >= 0),
+			position INTEGER NOT NULL,
+			gift_message VARCHAR(250) NOT NULL DEFAULT '',
+			add_ons JSONB NOT NULL DEFAULT '[]',
+			UNIQUE (order_id, product_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_order_items_order ON order_items(order_id, position)`,
+		`CREATE TABLE IF NOT EXISTS order_outbox (
+			id BIGSERIAL PRIMARY KEY,
+			event_id UUID NOT NULL UNIQUE,
+			topic VARCHAR(255) NOT NULL,
+			event_key VARCHAR(255) NOT NULL,
+			event_type VARCHAR(100) NOT NULL,
+			payload TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			published_at TIMESTAMP
+		)`,
+	}
+
+	for _, m := range migrations {
+		if _, err := db.Exec(m); err != nil {
+			return fmt.Errorf("failed to run migration: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func TestIntegration_OrderLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	service, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := callerContext("checkout-service", auth.RoleService)
+
+	created, err := service.CreateOrder(ctx, &pb.CreateOrderRequest{
+		UserId: "user-1",
+		Items: []*pb.CartItem{
+			{ProductId: integrationMug, Quantity: 2},
+			{ProductId: integrationTea, Quantity: 4},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if created.Order.Status != StatusPending || created.Order.TotalAmount != 42 {
+		t.Errorf("Unexpected order %v", created.Order)
+	}
+
+	got, err := service.GetOrder(ctx, &pb.GetOrderRequest{OrderId: created.Order.Id})
+	if err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	if len(got.Order.Items) != 2 || got.Order.Items[0].ProductId != integrationMug || got.Order.Items[1].LineTotal != 17 {
+		t.Errorf("Unexpected items %v", got.Order.Items)
+	}
+
+	for _, next := range []string{StatusPaid, StatusFulfilled, StatusDelivered} {
+		resp, err := service.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{OrderId: created.Order.Id, Status: next})
+		if err != nil {
+			t.Fatalf("UpdateOrderStatus(%s) failed: %v", next, err)
+		}
+		if resp.Order.Status != next || len(resp.Order.Items) != 2 {
+			t.Errorf("Unexpected order after %s: %v", next, resp.Order)
+		}
+	}
+
+	_, err = service.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{OrderId: created.Order.Id, Status: StatusCancelled})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition cancelling a delivered order, got %v", err)
+	}
+
+	_, err = service.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "not-a-uuid"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a malformed ID, got %v", err)
+	}
+}
+
+func TestIntegration_ListOrders(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	service, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := callerContext("checkout-service", auth.RoleService)
+
+	var ids []string
+	for _, user := range []string{"user-1", "user-1", "user-2"} {
+		resp, err := service.CreateOrder(ctx, &pb.CreateOrderRequest{
+			UserId: user,
+			Items:  []*pb.CartItem{{ProductId: integrationMug, Quantity: 1}},
+		})
+		if err != nil {
+			t.Fatalf("CreateOrder failed: %v", err)
+		}
+		ids = append(ids, resp.Order.Id)
+	}
+
+	if _, err := service.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{OrderId: ids[0], Status: StatusCancelled}); err != nil {
+		t.Fatalf("UpdateOrderStatus failed: %v", err)
+	}
+
+	resp, err := service.ListOrders(ctx, &pb.ListOrdersRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("ListOrders failed: %v", err)
+	}
+	if resp.Total != 2 || len(resp.Orders) != 2 {
+		t.Errorf("Expected 2 orders for user-1, got %d", resp.Total)
+	}
+
+	resp, err = service.ListOrders(ctx, &pb.ListOrdersRequest{Status: StatusPending})
+	if err != nil {
+		t.Fatalf("ListOrders failed: %v", err)
+	}
+	if resp.Total != 2 {
+		t.Errorf("Expected 2 pending orders, got %d", resp.Total)
+	}
+	for _, o := range resp.Orders {
+		if o.Id == ids[0] || len(o.Items) != 1 {
+			t.Errorf("Unexpected order %v", o)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	loyaltypb "github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcLoyalty struct {
+	client loyaltypb.LoyaltyServiceClient
+}
+
+// NewGRPCLoyalty creates a Purchases that awards loyalty points for paid
+// orders through the loyalty service
+func NewGRPCLoyalty(client loyaltypb.LoyaltyServiceClient) Purchases {
+	return &grpcLoyalty{client: client}
+}
+
+// OrderPaid awards the order's points. The loyalty service awards an order
+// once, and takes off the points redeemed for it at checkout.
+func (l *grpcLoyalty) OrderPaid(ctx context.Context, order *Order) error {
+	_, err := l.client.AwardPoints(ctx, &loyaltypb.AwardPointsRequest{
+		OrderId: order.ID,
+		UserId:  order.UserID,
+		Amount:  order.TotalAmount,
+		PaidAt:  timestamppb.New(order.UpdatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to award points: %w", err)
+	}
+	return nil
+}
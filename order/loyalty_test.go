@@ -0,0 +1,46 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	loyaltypb "github.com/Ujjwaljain16/E-commerce-Backend/loyalty/pb"
+	"google.golang.org/grpc"
+)
+
+// fakeLoyaltyClient records awards from the loyalty service client interface
+type fakeLoyaltyClient struct {
+	loyaltypb.LoyaltyServiceClient
+	got *loyaltypb.AwardPointsRequest
+	err error
+}
+
+func (c *fakeLoyaltyClient) AwardPoints(ctx context.Context, req *loyaltypb.AwardPointsRequest, opts ...grpc.CallOption) (*loyaltypb.AwardPointsResponse, error) {
+	c.got = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &loyaltypb.AwardPointsResponse{Awarded: true}, nil
+}
+
+func TestGRPCLoyalty_OrderPaid(t *testing.T) {
+	paidAt := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	client := &fakeLoyaltyClient{}
+	loyalty := NewGRPCLoyalty(client)
+
+	order := &Order{ID: "order-1", UserID: "user-1", Status: StatusPaid, TotalAmount: 42.5, UpdatedAt: paidAt}
+	if err := loyalty.OrderPaid(context.Background(), order); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := client.got
+	if got.OrderId != "order-1" || got.UserId != "user-1" || got.Amount != 42.5 || !got.PaidAt.AsTime().Equal(paidAt) {
+		t.Errorf("Unexpected award %v", got)
+	}
+
+	client.err = errors.New("unavailable")
+	if err := loyalty.OrderPaid(context.Background(), order); err == nil {
+		t.Error("Expected an error when the loyalty service fails")
+	}
+}
@@ -0,0 +1,13 @@
+-- Customer orders. status follows the order state machine:
+-- PENDING -> PAID -> FULFILLED -> DELIVERED, and PENDING or PAID -> CANCELLED.
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'PAID', 'FULFILLED', 'DELIVERED', 'CANCELLED')),
+    total_amount DECIMAL(12, 2) NOT NULL CHECK (total_amount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_orders_user ON orders(user_id, created_at DESC);
+CREATE INDEX idx_orders_status ON orders(status, created_at DESC);
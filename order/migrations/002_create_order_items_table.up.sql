@@ -0,0 +1,16 @@
+-- Order lines. SKU, name and prices are copied from the catalog when the order
+-- is placed so later catalog edits do not change past orders.
+CREATE TABLE IF NOT EXISTS order_items (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    sku VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+    line_total DECIMAL(12, 2) NOT NULL CHECK (line_total >= 0),
+    position INTEGER NOT NULL,
+    UNIQUE (order_id, product_id)
+);
+
+CREATE INDEX idx_order_items_order ON order_items(order_id, position);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_tenant_status;
+DROP INDEX IF EXISTS idx_orders_tenant_user;
+CREATE INDEX idx_orders_user ON orders(user_id, created_at DESC);
+CREATE INDEX idx_orders_status ON orders(status, created_at DESC);
+
+ALTER TABLE orders DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,8 @@
+-- Orders belong to a tenant (store). Existing orders belong to the default
+-- tenant.
+ALTER TABLE orders ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+DROP INDEX IF EXISTS idx_orders_user;
+DROP INDEX IF EXISTS idx_orders_status;
+CREATE INDEX idx_orders_tenant_user ON orders(tenant_id, user_id, created_at DESC);
+CREATE INDEX idx_orders_tenant_status ON orders(tenant_id, status, created_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS gift_message,
+    DROP COLUMN IF EXISTS add_ons;
@@ -0,0 +1,6 @@
+-- Gift options and paid add-ons of order lines. add_ons is a snapshot of the
+-- add-on products and their prices when the order was placed, as a JSON list
+-- of {code, product_id, sku, name, quantity, unit_price, line_total}.
+ALTER TABLE order_items
+    ADD COLUMN gift_message VARCHAR(250) NOT NULL DEFAULT '',
+    ADD COLUMN add_ons JSONB NOT NULL DEFAULT '[]';
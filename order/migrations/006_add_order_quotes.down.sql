@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_orders_quote;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS quote_id,
+    DROP COLUMN IF EXISTS po_number;
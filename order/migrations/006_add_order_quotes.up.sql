@@ -0,0 +1,7 @@
+-- Orders converted from accepted B2B quotes record the quote and the
+-- customer's purchase order number. A quote is converted into one order.
+ALTER TABLE orders
+    ADD COLUMN quote_id UUID,
+    ADD COLUMN po_number VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_orders_quote ON orders(tenant_id, quote_id) WHERE quote_id IS NOT NULL;
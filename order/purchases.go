@@ -0,0 +1,86 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	recommendationpb "github.com/Ujjwaljain16/E-commerce-Backend/recommendation/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Purchases reports what customers buy
+type Purchases interface {
+	// OrderPaid is called once when an order moves to PAID
+	OrderPaid(ctx context.Context, order *Order) error
+}
+
+type logPurchases struct {
+	log *logger.Logger
+}
+
+// NewLogPurchases creates a Purchases that only logs paid orders, for
+// deployments without a recommendation service
+func NewLogPurchases(log *logger.Logger) Purchases {
+	return &logPurchases{log: log}
+}
+
+func (p *logPurchases) OrderPaid(ctx context.Context, order *Order) error {
+	p.log.Info(ctx, "Order paid", map[string]interface{}{
+		"order_id": order.ID,
+		"user_id":  order.UserID,
+		"items":    len(order.Items),
+	})
+	return nil
+}
+
+type multiPurchases []Purchases
+
+// NewMultiPurchases creates a Purchases that reports paid orders to each of
+// purchases in turn
+func NewMultiPurchases(purchases ...Purchases) Purchases {
+	return multiPurchases(purchases)
+}
+
+// OrderPaid reports the order to every Purchases, so one failing does not
+// keep the order from the others
+func (m multiPurchases) OrderPaid(ctx context.Context, order *Order) error {
+	var errs []error
+	for _, p := range m {
+		if err := p.OrderPaid(ctx, order); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type grpcPurchases struct {
+	client recommendationpb.RecommendationServiceClient
+}
+
+// NewGRPCPurchases creates a Purchases that records paid orders with the
+// recommendation service
+func NewGRPCPurchases(client recommendationpb.RecommendationServiceClient) Purchases {
+	return &grpcPurchases{client: client}
+}
+
+// OrderPaid records the order's products. The recommendation service ignores
+// an order it has already recorded.
+func (p *grpcPurchases) OrderPaid(ctx context.Context, order *Order) error {
+	productIDs := make([]string, len(order.Items))
+	for i, item := range order.Items {
+		productIDs[i] = item.ProductID
+	}
+
+	_, err := p.client.RecordPurchase(ctx, &recommendationpb.RecordPurchaseRequest{
+		OrderId:    order.ID,
+		UserId:     order.UserID,
+		ProductIds: productIDs,
+		OccurredAt: timestamppb.New(order.UpdatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record purchase: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	recommendationpb "github.com/Ujjwaljain16/E-commerce-Backend/recommendation/pb"
+	"google.golang.org/grpc"
+)
+
+// fakeRecommendationClient records purchases from the recommendation service
+// client interface
+type fakeRecommendationClient struct {
+	recommendationpb.RecommendationServiceClient
+	got *recommendationpb.RecordPurchaseRequest
+	err error
+}
+
+func (c *fakeRecommendationClient) RecordPurchase(ctx context.Context, req *recommendationpb.RecordPurchaseRequest, opts ...grpc.CallOption) (*recommendationpb.RecordPurchaseResponse, error) {
+	c.got = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &recommendationpb.RecordPurchaseResponse{Recorded: true}, nil
+}
+
+func TestGRPCPurchases_OrderPaid(t *testing.T) {
+	paidAt := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	client := &fakeRecommendationClient{}
+	purchases := NewGRPCPurchases(client)
+
+	order := &Order{
+		ID:        "order-1",
+		UserID:    "user-1",
+		Status:    StatusPaid,
+		Items:     []*OrderItem{{ProductID: "mug"}, {ProductID: "tea"}},
+		UpdatedAt: paidAt,
+	}
+	if err := purchases.OrderPaid(context.Background(), order); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := client.got
+	if got.OrderId != "order-1" || got.UserId != "user-1" || !got.OccurredAt.AsTime().Equal(paidAt) {
+		t.Errorf("Unexpected purchase %v", got)
+	}
+	if len(got.ProductIds) != 2 || got.ProductIds[0] != "mug" || got.ProductIds[1] != "tea" {
+		t.Errorf("Expected products [mug tea], got %v", got.ProductIds)
+	}
+
+	client.err = errors.New("unavailable")
+	if err := purchases.OrderPaid(context.Background(), order); err == nil {
+		t.Error("Expected an error when the recommendation service fails")
+	}
+}
+
+func TestMultiPurchases_OrderPaid(t *testing.T) {
+	failing := &mockPurchases{err: errors.New("recommendation service unavailable")}
+	working := &mockPurchases{}
+	purchases := NewMultiPurchases(failing, working)
+
+	order := &Order{ID: "order-1", Status: StatusPaid}
+	err := purchases.OrderPaid(context.Background(), order)
+	if err == nil || err.Error() != "recommendation service unavailable" {
+		t.Errorf("Expected the failure to be returned, got %v", err)
+	}
+	if len(working.paid) != 1 || working.paid[0].ID != "order-1" {
+		t.Errorf("Expected the order to be reported past the failure, got %v", working.paid)
+	}
+}
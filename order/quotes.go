@@ -0,0 +1,114 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPONumberLength matches the po_number column of orders
+const maxPONumberLength = 100
+
+// CreateQuotedOrder places a PENDING order for an accepted quote. The lines
+// keep the catalog's product details but are charged at the negotiated
+// prices of the quote instead of being priced again. A quote is converted
+// into one order; converting it again returns that order, so the quote
+// service can retry a conversion it did not record.
+func (s *Service) CreateQuotedOrder(ctx context.Context, req *pb.CreateQuotedOrderRequest) (*pb.CreateQuotedOrderResponse, error) {
+	if msg := quotedOrderProblem(req); msg != "" {
+		s.log.Warn(ctx, "Create quoted order failed: "+msg, map[string]interface{}{"quote_id": req.QuoteId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	existing, err := s.repo.GetByQuoteID(ctx, req.QuoteId)
+	if err == nil {
+		s.log.Info(ctx, "Quote already ordered", map[string]interface{}{"quote_id": req.QuoteId, "order_id": existing.ID})
+		return &pb.CreateQuotedOrderResponse{Order: toProtoOrder(existing)}, nil
+	}
+	if !errors.Is(err, ErrOrderNotFound) {
+		s.log.Error(ctx, "Failed to get quoted order", map[string]interface{}{"error": err.Error(), "quote_id": req.QuoteId})
+		return nil, status.Error(codes.Internal, "failed to create order")
+	}
+
+	now := s.now()
+	order := &Order{
+		UserID:    req.UserId,
+		Status:    StatusPending,
+		Items:     make([]*OrderItem, len(req.Items)),
+		QuoteID:   req.QuoteId,
+		PONumber:  req.PoNumber,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for i, item := range req.Items {
+		snapshot, err := s.catalog.Snapshot(ctx, item.ProductId, item.Quantity, PriceTerms{})
+		if err != nil {
+			return nil, s.snapshotError(ctx, err, "product "+item.ProductId, item.ProductId, "")
+		}
+		order.Items[i] = &OrderItem{
+			ProductID: snapshot.ProductID,
+			SKU:       snapshot.SKU,
+			Name:      snapshot.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			LineTotal: roundCents(item.UnitPrice * float64(item.Quantity)),
+		}
+		order.TotalAmount += order.Items[i].LineTotal
+	}
+	order.TotalAmount = roundCents(order.TotalAmount)
+
+	created, err := s.repo.Create(ctx, order)
+	if errors.Is(err, ErrQuoteOrdered) {
+		// Converted concurrently; the other conversion's order stands
+		created, err = s.repo.GetByQuoteID(ctx, req.QuoteId)
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to create quoted order", map[string]interface{}{"error": err.Error(), "quote_id": req.QuoteId})
+		return nil, status.Error(codes.Internal, "failed to create order")
+	}
+
+	return &pb.CreateQuotedOrderResponse{
+		Order: toProtoOrder(created),
+	}, nil
+}
+
+// quotedOrderProblem validates a quoted order and returns a message
+// describing the first problem, or ""
+func quotedOrderProblem(req *pb.CreateQuotedOrderRequest) string {
+	switch {
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxUserIDLength:
+		return "user_id is too long"
+	case req.QuoteId == "":
+		return "quote_id is required"
+	case req.PoNumber == "":
+		return "po_number is required"
+	case len(req.PoNumber) > maxPONumberLength:
+		return fmt.Sprintf("po_number cannot be longer than %d characters", maxPONumberLength)
+	case len(req.Items) == 0:
+		return "items are required"
+	case len(req.Items) > maxOrderItems:
+		return fmt.Sprintf("an order cannot have more than %d items", maxOrderItems)
+	}
+	seen := make(map[string]bool, len(req.Items))
+	for _, item := range req.Items {
+		switch {
+		case item.ProductId == "":
+			return "product_id is required"
+		case item.Quantity <= 0:
+			return "quantity must be positive"
+		case item.UnitPrice < 0 || math.IsNaN(item.UnitPrice) || math.IsInf(item.UnitPrice, 0):
+			return "unit_price must be a non-negative amount"
+		case seen[item.ProductId]:
+			return "product " + item.ProductId + " appears more than once"
+		}
+		seen[item.ProductId] = true
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func validQuotedOrder() *pb.CreateQuotedOrderRequest {
+	return &pb.CreateQuotedOrderRequest{
+		UserId:   "user-1",
+		QuoteId:  "quote-1",
+		PoNumber: "PO-7",
+		Items: []*pb.QuotedItem{
+			{ProductId: "p1", Quantity: 10, UnitPrice: 17.5},
+			{ProductId: "p2", Quantity: 3, UnitPrice: 4.99},
+		},
+	}
+}
+
+func TestCreateQuotedOrder(t *testing.T) {
+	var got *Order
+	mockRepo := &MockRepository{
+		CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+			got = order
+			order.ID = "order-1"
+			return order, nil
+		},
+	}
+	service := setupService(mockRepo, &mockCatalog{prices: map[string]float64{"p1": 19.99, "p2": 5.10}})
+
+	resp, err := service.CreateQuotedOrder(context.Background(), validQuotedOrder())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Lines are charged at the quoted prices, not the catalog's
+	if got.Items[0].UnitPrice != 17.5 || got.Items[0].LineTotal != 175 || got.Items[1].LineTotal != 14.97 {
+		t.Errorf("Unexpected items %+v %+v", got.Items[0], got.Items[1])
+	}
+	if got.Items[0].SKU != "SKU-p1" || got.Items[0].Name != "Product p1" {
+		t.Errorf("Expected the catalog's product details, got %+v", got.Items[0])
+	}
+	if got.TotalAmount != 189.97 || got.Status != StatusPending {
+		t.Errorf("Unexpected order %+v", got)
+	}
+	if resp.Order.QuoteId != "quote-1" || resp.Order.PoNumber != "PO-7" {
+		t.Errorf("Unexpected order %v", resp.Order)
+	}
+}
+
+func TestCreateQuotedOrder_AlreadyOrdered(t *testing.T) {
+	existing := &Order{ID: "order-1", UserID: "user-1", Status: StatusPaid, QuoteID: "quote-1", PONumber: "PO-7"}
+
+	t.Run("before creating", func(t *testing.T) {
+		mockRepo := &MockRepository{
+			GetByQuoteIDFunc: func(ctx context.Context, quoteID string) (*Order, error) {
+				return existing, nil
+			},
+			CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+				t.Fatal("Expected no order to be created")
+				return nil, nil
+			},
+		}
+		// The catalog is not consulted, so a product gone since does not matter
+		service := setupService(mockRepo, &mockCatalog{})
+
+		resp, err := service.CreateQuotedOrder(context.Background(), validQuotedOrder())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Order.Id != "order-1" {
+			t.Errorf("Expected the existing order, got %v", resp.Order)
+		}
+	})
+
+	t.Run("concurrently", func(t *testing.T) {
+		lookups := 0
+		mockRepo := &MockRepository{
+			GetByQuoteIDFunc: func(ctx context.Context, quoteID string) (*Order, error) {
+				lookups++
+				if lookups == 1 {
+					return nil, ErrOrderNotFound
+				}
+				return existing, nil
+			},
+			CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+				return nil, ErrQuoteOrdered
+			},
+		}
+		service := setupService(mockRepo, &mockCatalog{prices: map[string]float64{"p1": 19.99, "p2": 5.10}})
+
+		resp, err := service.CreateQuotedOrder(context.Background(), validQuotedOrder())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Order.Id != "order-1" {
+			t.Errorf("Expected the existing order, got %v", resp.Order)
+		}
+	})
+}
+
+func TestCreateQuotedOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(req *pb.CreateQuotedOrderRequest)
+		expected codes.Code
+	}{
+		{"missing quote", func(req *pb.CreateQuotedOrderRequest) { req.QuoteId = "" }, codes.InvalidArgument},
+		{"missing PO number", func(req *pb.CreateQuotedOrderRequest) { req.PoNumber = "" }, codes.InvalidArgument},
+		{"negative price", func(req *pb.CreateQuotedOrderRequest) { req.Items[0].UnitPrice = -1 }, codes.InvalidArgument},
+		{"duplicate product", func(req *pb.CreateQuotedOrderRequest) { req.Items[1].ProductId = "p1" }, codes.InvalidArgument},
+		{"unavailable product", func(req *pb.CreateQuotedOrderRequest) { req.Items[1].ProductId = "gone" }, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+					t.Fatal("Expected no order to be created")
+					return nil, nil
+				},
+			}
+			service := setupService(mockRepo, &mockCatalog{prices: map[string]float64{"p1": 19.99, "p2": 5.10}})
+
+			req := validQuotedOrder()
+			tt.modify(req)
+			if _, err := service.CreateQuotedOrder(context.Background(), req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,433 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrOrderNotFound is returned when an order does not exist
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrStatusConflict is returned when an order's status changed since it was read
+	ErrStatusConflict = errors.New("order status changed")
+	// ErrQuoteOrdered is returned when the quote of an order was already
+	// converted into another order
+	ErrQuoteOrdered = errors.New("quote already ordered")
+)
+
+// Order is a customer order
+type Order struct {
+	ID          string
+	TenantID    string
+	UserID      string
+	Status      string
+	Items       []*OrderItem
+	TotalAmount float64
+	// QuoteID and PONumber are set on orders converted from a quote
+	QuoteID   string
+	PONumber  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrderItem is an order line. SKU, Name and the prices are snapshots of the
+// catalog when the order was placed.
+type OrderItem struct {
+	ID        string
+	OrderID   string
+	ProductID string
+	SKU       string
+	Name      string
+	Quantity  int32
+	UnitPrice float64
+	LineTotal float64
+	// GiftMessage is printed on the gift note of the line; "" for none
+	GiftMessage string
+	// AddOns are the paid add-ons of the line, such as gift wrap
+	AddOns []*OrderItemAddOn
+}
+
+// OrderItemAddOn is a paid add-on to an order line, sold as a catalog product
+// once per unit of the line. Like the line, it is a snapshot of the catalog
+// when the order was placed.
+type OrderItemAddOn struct {
+	Code      string  `json:"code"`
+	ProductID string  `json:"product_id"`
+	SKU       string  `json:"sku"`
+	Name      string  `json:"name"`
+	Quantity  int32   `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	LineTotal float64 `json:"line_total"`
+}
+
+// GiftWrapped reports whether the line has the gift wrap add-on
+func (i *OrderItem) GiftWrapped() bool {
+	for _, a := range i.AddOns {
+		if a.Code == AddOnGiftWrap {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderFilter narrows a list of orders; empty fields match every order
+type OrderFilter struct {
+	UserID string
+	Status string
+}
+
+// Repository handles order data persistence. Orders are scoped to the tenant
+// of the request context; an order of another tenant is not found.
+type Repository interface {
+	Create(ctx context.Context, order *Order) (*Order, error)
+	GetByID(ctx context.Context, id string) (*Order, error)
+	// GetByQuoteID retrieves the order a quote was converted into
+	GetByQuoteID(ctx context.Context, quoteID string) (*Order, error)
+	List(ctx context.Context, filter OrderFilter, page, pageSize int32) ([]*Order, int32, error)
+	// UpdateStatus moves an order from status from to status to. It returns
+	// ErrStatusConflict when the order is no longer in status from.
+	UpdateStatus(ctx context.Context, id, from, to string, at time.Time) (*Order, error)
+	// AnonymizeUser replaces userID on their orders with pseudonym and
+	// removes the PO numbers and gift messages, returning the number of
+	// orders changed
+	AnonymizeUser(ctx context.Context, userID, pseudonym string) (int32, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL order repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const orderColumns = "id, user_id, status, total_amount, created_at, updated_at, tenant_id, COALESCE(quote_id::text, ''), po_number"
+
+const itemColumns = "id, order_id, product_id, sku, name, quantity, unit_price, line_total, gift_message, add_ons"
+
+// quoteConstraint is the unique index allowing one order per quote
+const quoteConstraint = "idx_orders_quote"
+
+// Create inserts an order together with its items. It returns
+// ErrQuoteOrdered when the order's quote already has an order.
+func (r *postgresRepository) Create(ctx context.Context, order *Order) (*Order, error) {
+	order.ID = uuid.New().String()
+	order.TenantID = tenant.FromContext(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO orders (id, user_id, status, total_amount, created_at, updated_at, tenant_id, quote_id, po_number)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::uuid, $9)
+	`, order.ID, order.UserID, order.Status, order.TotalAmount, order.CreatedAt, order.UpdatedAt, order.TenantID, order.QuoteID, order.PONumber)
+	if pgerr.IsUniqueViolation(err, quoteConstraint) {
+		return nil, ErrQuoteOrdered
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to create order", map[string]interface{}{"error": err.Error(), "user_id": order.UserID})
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	for i, item := range order.Items {
+		item.ID = uuid.New().String()
+		item.OrderID = order.ID
+		addOns, err := json.Marshal(addOnsOrEmpty(item.AddOns))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode order item add-ons: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO order_items (id, order_id, product_id, sku, name, quantity, unit_price, line_total, position, gift_message, add_ons)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`, item.ID, item.OrderID, item.ProductID, item.SKU, item.Name, item.Quantity, item.UnitPrice, item.LineTotal, i, item.GiftMessage, addOns)
+		if err != nil {
+			r.log.Error(ctx, "Failed to create order item", map[string]interface{}{"error": err.Error(), "order_id": order.ID, "product_id": item.ProductID})
+			return nil, fmt.Errorf("failed to create order item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Order created", map[string]interface{}{"order_id": order.ID, "user_id": order.UserID, "items": len(order.Items)})
+	return order, nil
+}
+
+// GetByID retrieves an order with its items
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Order, error) {
+	query := "SELECT " + orderColumns + " FROM orders WHERE id = $1 AND tenant_id = $2"
+
+	order, err := scanOrder(r.db.QueryRowContext(ctx, query, id, tenant.FromContext(ctx)))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get order", map[string]interface{}{"error": err.Error(), "order_id": id})
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetByQuoteID retrieves the order a quote was converted into, with its items
+func (r *postgresRepository) GetByQuoteID(ctx context.Context, quoteID string) (*Order, error) {
+	query := "SELECT " + orderColumns + " FROM orders WHERE quote_id = $1 AND tenant_id = $2"
+
+	order, err := scanOrder(r.db.QueryRowContext(ctx, query, quoteID, tenant.FromContext(ctx)))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get quoted order", map[string]interface{}{"error": err.Error(), "quote_id": quoteID})
+		return nil, fmt.Errorf("failed to get quoted order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// List retrieves orders matching filter with pagination, newest first
+func (r *postgresRepository) List(ctx context.Context, filter OrderFilter, page, pageSize int32) ([]*Order, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+	tenantID := tenant.FromContext(ctx)
+	where := "WHERE tenant_id = $3 AND ($1 = '' OR user_id = $1) AND ($2 = '' OR status = $2)"
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders "+where, filter.UserID, filter.Status, tenantID).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count orders", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		` + where + `
+		ORDER BY created_at DESC, id
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.UserID, filter.Status, tenantID, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list orders", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]*Order, 0, pageSize)
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan order", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating orders: %w", err)
+	}
+
+	if err := r.loadItems(ctx, orders...); err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// UpdateStatus moves an order from one status to another. The update only
+// applies while the order is still in status from, so concurrent transitions
+// cannot both succeed. The order.status_changed event is added to the outbox
+// in the same transaction.
+func (r *postgresRepository) UpdateStatus(ctx context.Context, id, from, to string, at time.Time) (*Order, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE orders
+		SET status = $3, updated_at = $4
+		WHERE id = $1 AND status = $2 AND tenant_id = $5
+		RETURNING ` + orderColumns
+
+	tenantID := tenant.FromContext(ctx)
+	order, err := scanOrder(tx.QueryRowContext(ctx, query, id, from, to, at, tenantID))
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrOrderNotFound
+	}
+	if err == sql.ErrNoRows {
+		var current string
+		err = tx.QueryRowContext(ctx, "SELECT status FROM orders WHERE id = $1 AND tenant_id = $2", id, tenantID).Scan(&current)
+		if err == sql.ErrNoRows {
+			return nil, ErrOrderNotFound
+		}
+		if err == nil {
+			return nil, ErrStatusConflict
+		}
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update order status", map[string]interface{}{"error": err.Error(), "order_id": id})
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	if err := events.Add(ctx, tx, statusChangedEvent(order, from)); err != nil {
+		r.log.Error(ctx, "Failed to record order event", map[string]interface{}{"error": err.Error(), "order_id": id})
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit order status", map[string]interface{}{"error": err.Error(), "order_id": id})
+		return nil, fmt.Errorf("failed to commit order status: %w", err)
+	}
+
+	if err := r.loadItems(ctx, order); err != nil {
+		return nil, err
+	}
+
+	r.log.Info(ctx, "Order status updated", map[string]interface{}{"order_id": id, "from": from, "to": to})
+	return order, nil
+}
+
+// AnonymizeUser anonymizes a customer's orders of the context tenant. The
+// lines, totals and statuses are kept for accounting.
+func (r *postgresRepository) AnonymizeUser(ctx context.Context, userID, pseudonym string) (int32, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tenantID := tenant.FromContext(ctx)
+	_, err = tx.ExecContext(ctx, `
+		UPDATE order_items SET gift_message = ''
+		WHERE gift_message <> '' AND order_id IN (SELECT id FROM orders WHERE user_id = $1 AND tenant_id = $2)
+	`, userID, tenantID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to anonymize order items", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return 0, fmt.Errorf("failed to anonymize order items: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE orders SET user_id = $2, po_number = ''
+		WHERE user_id = $1 AND tenant_id = $3
+	`, userID, pseudonym, tenantID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to anonymize orders", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return 0, fmt.Errorf("failed to anonymize orders: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize orders: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit order anonymization: %w", err)
+	}
+
+	r.log.Info(ctx, "Orders anonymized", map[string]interface{}{"user_id": userID, "orders": rows})
+	return int32(rows), nil
+}
+
+// loadItems fills in the items of orders in their original order
+func (r *postgresRepository) loadItems(ctx context.Context, orders ...*Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*Order, len(orders))
+	ids := make([]string, len(orders))
+	for i, o := range orders {
+		o.Items = []*OrderItem{}
+		byID[o.ID] = o
+		ids[i] = o.ID
+	}
+
+	query := "SELECT " + itemColumns + " FROM order_items WHERE order_id = ANY($1) ORDER BY order_id, position"
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get order items", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get order items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := &OrderItem{}
+		var addOns []byte
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.SKU, &item.Name, &item.Quantity, &item.UnitPrice, &item.LineTotal,
+			&item.GiftMessage, &addOns); err != nil {
+			r.log.Error(ctx, "Failed to scan order item", map[string]interface{}{"error": err.Error()})
+			return fmt.Errorf("failed to scan order item: %w", err)
+		}
+		if err := json.Unmarshal(addOns, &item.AddOns); err != nil {
+			return fmt.Errorf("failed to decode order item add-ons: %w", err)
+		}
+		if o, ok := byID[item.OrderID]; ok {
+			o.Items = append(o.Items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating order items: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// addOnsOrEmpty returns addOns, or an empty list for a line without add-ons
+// so that it is stored as [] rather than null
+func addOnsOrEmpty(addOns []*OrderItemAddOn) []*OrderItemAddOn {
+	if addOns == nil {
+		return []*OrderItemAddOn{}
+	}
+	return addOns
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanOrder scans an order row, without its items
+func scanOrder(row rowScanner) (*Order, error) {
+	o := &Order{}
+	if err := row.Scan(&o.ID, &o.UserID, &o.Status, &o.TotalAmount, &o.CreatedAt, &o.UpdatedAt, &o.TenantID, &o.QuoteID, &o.PONumber); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
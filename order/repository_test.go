@@ -0,0 +1,362 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("order-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var orderColumnNames = []string{"id", "user_id", "status", "total_amount", "created_at", "updated_at", "tenant_id", "quote_id", "po_number"}
+
+var itemColumnNames = []string{"id", "order_id", "product_id", "sku", "name", "quantity", "unit_price", "line_total", "gift_message", "add_ons"}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	order := &Order{
+		UserID:      "user-1",
+		Status:      StatusPending,
+		TotalAmount: 45.0,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Items: []*OrderItem{
+			{ProductID: "p1", SKU: "SKU-1", Name: "Mug", Quantity: 2, UnitPrice: 10, LineTotal: 20},
+			{ProductID: "p2", SKU: "SKU-2", Name: "Tea", Quantity: 5, UnitPrice: 5, LineTotal: 25},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO orders`).
+		WithArgs(sqlmock.AnyArg(), "user-1", StatusPending, 45.0, now, now, tenant.Default, "", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO order_items`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "p1", "SKU-1", "Mug", int32(2), 10.0, 20.0, 0, "", []byte("[]")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO order_items`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "p2", "SKU-2", "Tea", int32(5), 5.0, 25.0, 1, "", []byte("[]")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	created, err := repo.Create(context.Background(), order)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected order ID to be set")
+	}
+	for _, item := range created.Items {
+		if item.ID == "" || item.OrderID != created.ID {
+			t.Errorf("Unexpected item %+v", item)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_ItemFailureRollsBack(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO orders`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO order_items`).WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	_, err := repo.Create(context.Background(), &Order{
+		UserID: "user-1",
+		Status: StatusPending,
+		Items:  []*OrderItem{{ProductID: "p1", Quantity: 1}},
+	})
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_QuoteAlreadyOrdered(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO orders`).
+		WithArgs(sqlmock.AnyArg(), "user-1", StatusPending, 90.0, sqlmock.AnyArg(), sqlmock.AnyArg(), tenant.Default, "quote-1", "PO-7").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: quoteConstraint})
+	mock.ExpectRollback()
+
+	_, err := repo.Create(context.Background(), &Order{
+		UserID:      "user-1",
+		Status:      StatusPending,
+		TotalAmount: 90,
+		QuoteID:     "quote-1",
+		PONumber:    "PO-7",
+		Items:       []*OrderItem{{ProductID: "p1", Quantity: 10, UnitPrice: 9, LineTotal: 90}},
+	})
+	if !errors.Is(err, ErrQuoteOrdered) {
+		t.Errorf("Expected ErrQuoteOrdered, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByQuoteID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM orders WHERE quote_id = \$1 AND tenant_id = \$2`).
+		WithArgs("quote-1", tenant.Default).
+		WillReturnRows(sqlmock.NewRows(orderColumnNames).
+			AddRow("order-1", "user-1", StatusPending, 90.0, now, now, tenant.Default, "quote-1", "PO-7"))
+	mock.ExpectQuery(`SELECT (.+) FROM order_items WHERE order_id = ANY\(\$1\)`).
+		WithArgs(pq.Array([]string{"order-1"})).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames).
+			AddRow("item-1", "order-1", "p1", "SKU-1", "Mug", 10, 9.0, 90.0, "", []byte("[]")))
+	mock.ExpectQuery(`SELECT (.+) FROM orders WHERE quote_id = \$1 AND tenant_id = \$2`).
+		WithArgs("quote-2", tenant.Default).
+		WillReturnError(sql.ErrNoRows)
+
+	order, err := repo.GetByQuoteID(context.Background(), "quote-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.QuoteID != "quote-1" || order.PONumber != "PO-7" || len(order.Items) != 1 {
+		t.Errorf("Unexpected order %+v", order)
+	}
+	if _, err := repo.GetByQuoteID(context.Background(), "quote-2"); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("Expected ErrOrderNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM orders WHERE id = \$1 AND tenant_id = \$2`).
+		WithArgs("order-1", tenant.Default).
+		WillReturnRows(sqlmock.NewRows(orderColumnNames).
+			AddRow("order-1", "user-1", StatusPaid, 20.0, now, now, tenant.Default, "", ""))
+	mock.ExpectQuery(`SELECT (.+) FROM order_items WHERE order_id = ANY\(\$1\) ORDER BY order_id, position`).
+		WithArgs(pq.Array([]string{"order-1"})).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames).
+			AddRow("item-1", "order-1", "p1", "SKU-1", "Mug", 2, 10.0, 20.0, "For you",
+				[]byte(`[{"code":"gift_wrap","product_id":"wrap","sku":"SKU-W","name":"Gift wrap","quantity":2,"unit_price":2.5,"line_total":5}]`)))
+
+	order, err := repo.GetByID(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.Status != StatusPaid || len(order.Items) != 1 || order.Items[0].Name != "Mug" {
+		t.Errorf("Unexpected order %+v", order)
+	}
+	if item := order.Items[0]; item.GiftMessage != "For you" || !item.GiftWrapped() || item.AddOns[0].LineTotal != 5 {
+		t.Errorf("Unexpected gift options %+v", item)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM orders WHERE id = \$1 AND tenant_id = \$2`).
+		WithArgs("missing", tenant.Default).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT (.+) FROM orders WHERE id = \$1 AND tenant_id = \$2`).
+		WithArgs("not-a-uuid", tenant.Default).
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(context.Background(), id); !errors.Is(err, ErrOrderNotFound) {
+			t.Errorf("GetByID(%q): expected ErrOrderNotFound, got %v", id, err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID_OtherTenant(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// The order exists, but for another store
+	mock.ExpectQuery(`SELECT (.+) FROM orders WHERE id = \$1 AND tenant_id = \$2`).
+		WithArgs("order-1", "acme").
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := tenant.NewContext(context.Background(), "acme")
+	if _, err := repo.GetByID(ctx, "order-1"); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("Expected ErrOrderNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM orders WHERE`).
+		WithArgs("user-1", "", tenant.Default).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(`SELECT (.+) FROM orders WHERE (.+) ORDER BY created_at DESC, id LIMIT \$4 OFFSET \$5`).
+		WithArgs("user-1", "", tenant.Default, int32(10), int32(10)).
+		WillReturnRows(sqlmock.NewRows(orderColumnNames).
+			AddRow("order-2", "user-1", StatusPending, 5.0, now, now, tenant.Default, "", "").
+			AddRow("order-1", "user-1", StatusDelivered, 20.0, now, now, tenant.Default, "", ""))
+	mock.ExpectQuery(`SELECT (.+) FROM order_items WHERE order_id = ANY\(\$1\)`).
+		WithArgs(pq.Array([]string{"order-2", "order-1"})).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames).
+			AddRow("item-1", "order-1", "p1", "SKU-1", "Mug", 2, 10.0, 20.0, "", []byte("[]")).
+			AddRow("item-2", "order-2", "p2", "SKU-2", "Tea", 1, 5.0, 5.0, "", []byte("[]")))
+
+	orders, total, err := repo.List(context.Background(), OrderFilter{UserID: "user-1"}, 2, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 12 || len(orders) != 2 {
+		t.Fatalf("Expected 2 of 12 orders, got %d of %d", len(orders), total)
+	}
+	if orders[0].ID != "order-2" || len(orders[0].Items) != 1 || orders[0].Items[0].ID != "item-2" {
+		t.Errorf("Unexpected first order %+v", orders[0])
+	}
+	if len(orders[1].Items) != 1 || orders[1].Items[0].ID != "item-1" {
+		t.Errorf("Unexpected second order %+v", orders[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE orders SET status = \$3, updated_at = \$4 WHERE id = \$1 AND status = \$2 AND tenant_id = \$5 RETURNING`).
+		WithArgs("order-1", StatusPending, StatusPaid, now, tenant.Default).
+		WillReturnRows(sqlmock.NewRows(orderColumnNames).
+			AddRow("order-1", "user-1", StatusPaid, 20.0, now, now, tenant.Default, "", ""))
+	mock.ExpectExec(`INSERT INTO order_outbox \(event_id, topic, event_key, event_type, payload, created_at\)`).
+		WithArgs(sqlmock.AnyArg(), EventsTopic, "order-1", EventOrderStatusChanged,
+			sqlmock.AnyArg(), now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT (.+) FROM order_items`).
+		WithArgs(pq.Array([]string{"order-1"})).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames))
+
+	order, err := repo.UpdateStatus(context.Background(), "order-1", StatusPending, StatusPaid, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.Status != StatusPaid {
+		t.Errorf("Expected status %s, got %s", StatusPaid, order.Status)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateStatus_NoMatch(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE orders`).
+		WithArgs("order-1", StatusPending, StatusPaid, now, tenant.Default).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \$1 AND tenant_id = \$2`).
+		WithArgs("order-1", tenant.Default).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(StatusCancelled))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE orders`).
+		WithArgs("missing", StatusPending, StatusPaid, now, tenant.Default).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \$1 AND tenant_id = \$2`).
+		WithArgs("missing", tenant.Default).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	if _, err := repo.UpdateStatus(context.Background(), "order-1", StatusPending, StatusPaid, now); !errors.Is(err, ErrStatusConflict) {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+	if _, err := repo.UpdateStatus(context.Background(), "missing", StatusPending, StatusPaid, now); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("Expected ErrOrderNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE order_items SET gift_message = ''`).
+		WithArgs("user-1", tenant.Default).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE orders SET user_id = \$2, po_number = ''`).
+		WithArgs("user-1", "erased-1", tenant.Default).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	records, err := repo.AnonymizeUser(context.Background(), "user-1", "erased-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if records != 2 {
+		t.Errorf("Expected 2 orders, got %d", records)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,351 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxOrderItems caps the number of lines in one order
+const maxOrderItems = 100
+
+// maxUserIDLength matches the user_id column of orders
+const maxUserIDLength = 255
+
+// AdminMethods are the admin-scoped RPCs of the order service; they are
+// restricted to the admin network allowlist and to ADMIN and SERVICE tokens
+var AdminMethods = []string{
+	pb.OrderService_UpdateOrderStatus_FullMethodName,
+	pb.OrderService_CreateQuotedOrder_FullMethodName,
+	pb.OrderService_EraseUserData_FullMethodName,
+}
+
+// UserMethods are the RPCs acting for one customer, which need an access
+// token; the customer is the token's unless its role is ADMIN or SERVICE
+var UserMethods = []string{
+	pb.OrderService_CreateOrder_FullMethodName,
+	pb.OrderService_GetOrder_FullMethodName,
+	pb.OrderService_ListOrders_FullMethodName,
+}
+
+// MethodRoles maps each of UserMethods and AdminMethods to the roles allowed
+// to call it: ADMIN and SERVICE tokens may call all of them, and customers
+// UserMethods only
+func MethodRoles() map[string][]string {
+	roles := make(map[string][]string, len(UserMethods)+len(AdminMethods))
+	for _, m := range UserMethods {
+		roles[m] = []string{auth.RoleUser, auth.RoleAdmin, auth.RoleService}
+	}
+	for _, m := range AdminMethods {
+		roles[m] = []string{auth.RoleAdmin, auth.RoleService}
+	}
+	return roles
+}
+
+// Service implements the OrderService gRPC interface
+type Service struct {
+	pb.UnimplementedOrderServiceServer
+	repo      Repository
+	catalog   Catalog
+	purchases Purchases
+	log       *logger.Logger
+	now       func() time.Time
+	// addOns maps the codes of the add-ons offered to their catalog products
+	addOns map[string]string
+}
+
+// NewService creates a new order service
+func NewService(repo Repository, catalog Catalog, purchases Purchases, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		catalog:   catalog,
+		purchases: purchases,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// CreateOrder places a PENDING order for the contents of a cart. Each line is
+// priced by the catalog, or the pricing service when configured, and its
+// product details are copied onto the order. Gift wrap and other add-ons are
+// priced the same way and kept as separate lines of the order item, so the
+// order total and anything billed from the order show them apart from the
+// product.
+func (s *Service) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "Create order failed: "+status.Convert(err).Message(), map[string]interface{}{"user_id": req.UserId})
+		return nil, err
+	}
+	if userID == "" {
+		s.log.Warn(ctx, "Create order failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if len(userID) > maxUserIDLength {
+		s.log.Warn(ctx, "Create order failed: user ID is too long", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is too long")
+	}
+	if msg := cartProblem(req.Items); msg != "" {
+		s.log.Warn(ctx, "Create order failed: "+msg, map[string]interface{}{"user_id": userID})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	terms := PriceTerms{
+		CustomerSegment: req.CustomerSegment,
+		Channel:         req.Channel,
+		Currency:        req.Currency,
+	}
+	now := s.now()
+	order := &Order{
+		UserID:    userID,
+		Status:    StatusPending,
+		Items:     make([]*OrderItem, len(req.Items)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for i, item := range req.Items {
+		snapshot, err := s.catalog.Snapshot(ctx, item.ProductId, item.Quantity, terms)
+		if err != nil {
+			return nil, s.snapshotError(ctx, err, "product "+item.ProductId, item.ProductId, req.Currency)
+		}
+		addOns, err := s.priceAddOns(ctx, item, terms)
+		if err != nil {
+			return nil, err
+		}
+
+		order.Items[i] = &OrderItem{
+			ProductID:   snapshot.ProductID,
+			SKU:         snapshot.SKU,
+			Name:        snapshot.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   snapshot.UnitPrice,
+			LineTotal:   snapshot.LineTotal,
+			GiftMessage: item.GiftMessage,
+			AddOns:      addOns,
+		}
+		order.TotalAmount += snapshot.LineTotal
+		for _, addOn := range addOns {
+			order.TotalAmount += addOn.LineTotal
+		}
+	}
+	order.TotalAmount = roundCents(order.TotalAmount)
+
+	created, err := s.repo.Create(ctx, order)
+	if err != nil {
+		s.log.Error(ctx, "Failed to create order", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, status.Error(codes.Internal, "failed to create order")
+	}
+
+	return &pb.CreateOrderResponse{
+		Order: toProtoOrder(created),
+	}, nil
+}
+
+// GetOrder retrieves an order by ID. Customers get their own orders only;
+// another customer's is not found, so its existence is not revealed.
+func (s *Service) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	if req.OrderId == "" {
+		s.log.Warn(ctx, "Get order failed: order ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	userID, err := auth.ActingUser(ctx, "", auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "Get order failed: "+status.Convert(err).Message(), map[string]interface{}{"order_id": req.OrderId})
+		return nil, err
+	}
+
+	order, err := s.repo.GetByID(ctx, req.OrderId)
+	if err == nil && userID != "" && order.UserID != userID {
+		err = ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, s.orderLookupError(ctx, err, req.OrderId)
+	}
+
+	return &pb.GetOrderResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+// ListOrders lists orders, newest first, optionally in one status. Customers
+// list their own orders; admins and services list those of every customer
+// or one.
+func (s *Service) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId, auth.RoleAdmin, auth.RoleService)
+	if err != nil {
+		s.log.Warn(ctx, "List orders failed: "+status.Convert(err).Message(), map[string]interface{}{"user_id": req.UserId})
+		return nil, err
+	}
+	if req.Status != "" && !IsValidStatus(req.Status) {
+		s.log.Warn(ctx, "List orders failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be PENDING, PAID, FULFILLED, DELIVERED or CANCELLED")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	orders, total, err := s.repo.List(ctx, OrderFilter{UserID: userID, Status: req.Status}, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list orders", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list orders")
+	}
+
+	protoOrders := make([]*pb.Order, len(orders))
+	for i, o := range orders {
+		protoOrders[i] = toProtoOrder(o)
+	}
+
+	return &pb.ListOrdersResponse{
+		Orders:   protoOrders,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// UpdateOrderStatus moves an order to a new status. Only the transitions of
+// the order state machine are allowed.
+func (s *Service) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	if req.OrderId == "" {
+		s.log.Warn(ctx, "Update order status failed: order ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if !IsValidStatus(req.Status) {
+		s.log.Warn(ctx, "Update order status failed: invalid status", map[string]interface{}{"order_id": req.OrderId, "status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be PENDING, PAID, FULFILLED, DELIVERED or CANCELLED")
+	}
+
+	order, err := s.repo.GetByID(ctx, req.OrderId)
+	if err != nil {
+		return nil, s.orderLookupError(ctx, err, req.OrderId)
+	}
+	if !CanTransition(order.Status, req.Status) {
+		s.log.Warn(ctx, "Update order status failed: transition not allowed", map[string]interface{}{"order_id": req.OrderId, "from": order.Status, "to": req.Status})
+		return nil, status.Error(codes.FailedPrecondition, "cannot move order from "+order.Status+" to "+req.Status)
+	}
+
+	updated, err := s.repo.UpdateStatus(ctx, req.OrderId, order.Status, req.Status, s.now())
+	if errors.Is(err, ErrStatusConflict) {
+		s.log.Warn(ctx, "Update order status failed: status changed concurrently", map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.Aborted, "order status was changed by another request; reload the order and retry")
+	}
+	if err != nil {
+		return nil, s.orderLookupError(ctx, err, req.OrderId)
+	}
+
+	if updated.Status == StatusPaid {
+		// The order is paid either way; a missed report only weakens
+		// recommendations or leaves points, vendor commissions and the
+		// order's fulfillment to be handled by hand
+		if err := s.purchases.OrderPaid(context.WithoutCancel(ctx), updated); err != nil {
+			s.log.Error(ctx, "Failed to report paid order", map[string]interface{}{"error": err.Error(), "order_id": updated.ID})
+		}
+	}
+
+	return &pb.UpdateOrderStatusResponse{
+		Order: toProtoOrder(updated),
+	}, nil
+}
+
+// cartProblem validates the lines of a cart and returns a message describing
+// the first problem, or ""
+func cartProblem(items []*pb.CartItem) string {
+	if len(items) == 0 {
+		return "items are required"
+	}
+	if len(items) > maxOrderItems {
+		return fmt.Sprintf("an order cannot have more than %d items", maxOrderItems)
+	}
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		switch {
+		case item.ProductId == "":
+			return "product_id is required"
+		case item.Quantity <= 0:
+			return "quantity must be positive"
+		case seen[item.ProductId]:
+			return "product " + item.ProductId + " appears more than once"
+		}
+		if msg := addOnProblem(item); msg != "" {
+			return msg
+		}
+		seen[item.ProductId] = true
+	}
+	return ""
+}
+
+// orderLookupError maps an error reading or updating an order to a gRPC status
+func (s *Service) orderLookupError(ctx context.Context, err error, orderID string) error {
+	if errors.Is(err, ErrOrderNotFound) {
+		s.log.Warn(ctx, "Order not found", map[string]interface{}{"order_id": orderID})
+		return status.Error(codes.NotFound, "order not found")
+	}
+	s.log.Error(ctx, "Failed to get order", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+	return status.Error(codes.Internal, "failed to get order")
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// toProtoOrder converts an order and its items to protobuf
+func toProtoOrder(o *Order) *pb.Order {
+	order := &pb.Order{
+		Id:          o.ID,
+		UserId:      o.UserID,
+		TenantId:    o.TenantID,
+		Status:      o.Status,
+		Items:       make([]*pb.OrderItem, len(o.Items)),
+		TotalAmount: o.TotalAmount,
+		QuoteId:     o.QuoteID,
+		PoNumber:    o.PONumber,
+		CreatedAt:   timestamppb.New(o.CreatedAt),
+		UpdatedAt:   timestamppb.New(o.UpdatedAt),
+	}
+	for i, item := range o.Items {
+		order.Items[i] = &pb.OrderItem{
+			Id:          item.ID,
+			ProductId:   item.ProductID,
+			Sku:         item.SKU,
+			Name:        item.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			LineTotal:   item.LineTotal,
+			GiftWrap:    item.GiftWrapped(),
+			GiftMessage: item.GiftMessage,
+			AddOns:      make([]*pb.OrderItemAddOn, len(item.AddOns)),
+		}
+		for j, addOn := range item.AddOns {
+			order.Items[i].AddOns[j] = &pb.OrderItemAddOn{
+				Code:      addOn.Code,
+				ProductId: addOn.ProductID,
+				Sku:       addOn.SKU,
+				Name:      addOn.Name,
+				Quantity:  addOn.Quantity,
+				UnitPrice: addOn.UnitPrice,
+				LineTotal: addOn.LineTotal,
+			}
+		}
+	}
+	return order
+}
@@ -0,0 +1,480 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MockRepository is a mock implementation of Repository for testing
+type MockRepository struct {
+	CreateFunc       func(ctx context.Context, order *Order) (*Order, error)
+	GetByIDFunc      func(ctx context.Context, id string) (*Order, error)
+	GetByQuoteIDFunc func(ctx context.Context, quoteID string) (*Order, error)
+	ListFunc         func(ctx context.Context, filter OrderFilter, page, pageSize int32) ([]*Order, int32, error)
+	UpdateStatusFunc func(ctx context.Context, id, from, to string, at time.Time) (*Order, error)
+	AnonymizeFunc    func(ctx context.Context, userID, pseudonym string) (int32, error)
+	CloseFunc        func() error
+}
+
+func (m *MockRepository) Create(ctx context.Context, order *Order) (*Order, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, order)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Order, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, ErrOrderNotFound
+}
+
+func (m *MockRepository) GetByQuoteID(ctx context.Context, quoteID string) (*Order, error) {
+	if m.GetByQuoteIDFunc != nil {
+		return m.GetByQuoteIDFunc(ctx, quoteID)
+	}
+	return nil, ErrOrderNotFound
+}
+
+func (m *MockRepository) List(ctx context.Context, filter OrderFilter, page, pageSize int32) ([]*Order, int32, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter, page, pageSize)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) UpdateStatus(ctx context.Context, id, from, to string, at time.Time) (*Order, error) {
+	if m.UpdateStatusFunc != nil {
+		return m.UpdateStatusFunc(ctx, id, from, to, at)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) AnonymizeUser(ctx context.Context, userID, pseudonym string) (int32, error) {
+	if m.AnonymizeFunc != nil {
+		return m.AnonymizeFunc(ctx, userID, pseudonym)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *MockRepository) Close() error {
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return nil
+}
+
+// mockCatalog prices every product at its price in prices
+type mockCatalog struct {
+	prices map[string]float64
+	err    error
+	terms  PriceTerms
+}
+
+func (c *mockCatalog) Snapshot(ctx context.Context, productID string, quantity int32, terms PriceTerms) (*ProductSnapshot, error) {
+	c.terms = terms
+	if c.err != nil {
+		return nil, c.err
+	}
+	price, ok := c.prices[productID]
+	if !ok {
+		return nil, ErrProductUnavailable
+	}
+	return &ProductSnapshot{
+		ProductID: productID,
+		SKU:       "SKU-" + productID,
+		Name:      "Product " + productID,
+		UnitPrice: price,
+		LineTotal: roundCents(price * float64(quantity)),
+	}, nil
+}
+
+// mockPurchases records the orders reported as paid
+type mockPurchases struct {
+	paid []*Order
+	err  error
+}
+
+func (p *mockPurchases) OrderPaid(ctx context.Context, order *Order) error {
+	p.paid = append(p.paid, order)
+	return p.err
+}
+
+func setupService(repo Repository, catalog Catalog) *Service {
+	return NewService(repo, catalog, &mockPurchases{}, logger.New("order-test"))
+}
+
+// callerContext returns a context carrying the claims of a token of userID
+// in role, as the auth interceptor leaves them
+func callerContext(userID, role string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: userID, Role: role})
+}
+
+func TestCreateOrder_Success(t *testing.T) {
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	var got *Order
+	mockRepo := &MockRepository{
+		CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+			got = order
+			order.ID = "order-1"
+			for i, item := range order.Items {
+				item.ID = fmt.Sprintf("item-%d", i+1)
+				item.OrderID = order.ID
+			}
+			return order, nil
+		},
+	}
+	catalog := &mockCatalog{prices: map[string]float64{"p1": 19.99, "p2": 5.10}}
+	service := setupService(mockRepo, catalog)
+	service.now = func() time.Time { return now }
+
+	resp, err := service.CreateOrder(callerContext("checkout-service", auth.RoleService), &pb.CreateOrderRequest{
+		UserId: "user-1",
+		Items: []*pb.CartItem{
+			{ProductId: "p1", Quantity: 2},
+			{ProductId: "p2", Quantity: 3},
+		},
+		CustomerSegment: "vip",
+		Channel:         "app",
+		Currency:        "EUR",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if catalog.terms != (PriceTerms{CustomerSegment: "vip", Channel: "app", Currency: "EUR"}) {
+		t.Errorf("Expected the price terms passed to the catalog, got %+v", catalog.terms)
+	}
+
+	if got.Status != StatusPending || got.UserID != "user-1" || !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+		t.Errorf("Unexpected order %+v", got)
+	}
+	if got.TotalAmount != 55.28 {
+		t.Errorf("Expected total 55.28, got %v", got.TotalAmount)
+	}
+
+	o := resp.Order
+	if o.Id != "order-1" || o.Status != StatusPending || o.TotalAmount != 55.28 || len(o.Items) != 2 {
+		t.Fatalf("Unexpected response %v", o)
+	}
+	if item := o.Items[0]; item.ProductId != "p1" || item.Sku != "SKU-p1" || item.Name != "Product p1" ||
+		item.Quantity != 2 || item.UnitPrice != 19.99 || item.LineTotal != 39.98 {
+		t.Errorf("Unexpected first item %v", item)
+	}
+	if item := o.Items[1]; item.ProductId != "p2" || item.LineTotal != 15.3 {
+		t.Errorf("Unexpected second item %v", item)
+	}
+}
+
+func TestCreateOrder_Errors(t *testing.T) {
+	tooMany := make([]*pb.CartItem, maxOrderItems+1)
+	for i := range tooMany {
+		tooMany[i] = &pb.CartItem{ProductId: fmt.Sprintf("p%d", i), Quantity: 1}
+	}
+
+	tests := []struct {
+		name       string
+		req        *pb.CreateOrderRequest
+		catalogErr error
+		expected   codes.Code
+	}{
+		{"missing user", &pb.CreateOrderRequest{Items: []*pb.CartItem{{ProductId: "p1", Quantity: 1}}}, nil, codes.InvalidArgument},
+		{"long user", &pb.CreateOrderRequest{UserId: strings.Repeat("u", maxUserIDLength+1), Items: []*pb.CartItem{{ProductId: "p1", Quantity: 1}}}, nil, codes.InvalidArgument},
+		{"empty cart", &pb.CreateOrderRequest{UserId: "user-1"}, nil, codes.InvalidArgument},
+		{"too many items", &pb.CreateOrderRequest{UserId: "user-1", Items: tooMany}, nil, codes.InvalidArgument},
+		{"missing product", &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{Quantity: 1}}}, nil, codes.InvalidArgument},
+		{"zero quantity", &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{ProductId: "p1"}}}, nil, codes.InvalidArgument},
+		{"duplicate product", &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{ProductId: "p1", Quantity: 1}, {ProductId: "p1", Quantity: 2}}}, nil, codes.InvalidArgument},
+		{"unavailable product", &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{ProductId: "gone", Quantity: 1}}}, nil, codes.FailedPrecondition},
+		{"quantity rule", &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{ProductId: "p1", Quantity: 1}}}, fmt.Errorf("%w: quantity must be at least 5", ErrInvalidQuantity), codes.InvalidArgument},
+		{"no price in currency", &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{ProductId: "p1", Quantity: 1}}, Currency: "JPY"}, ErrNoPrice, codes.FailedPrecondition},
+		{"catalog down", &pb.CreateOrderRequest{UserId: "user-1", Items: []*pb.CartItem{{ProductId: "p1", Quantity: 1}}}, errors.New("connection refused"), codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+					t.Fatal("Expected no order to be created")
+					return nil, nil
+				},
+			}
+			service := setupService(mockRepo, &mockCatalog{prices: map[string]float64{"p1": 10}, err: tt.catalogErr})
+
+			_, err := service.CreateOrder(callerContext("checkout-service", auth.RoleService), tt.req)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Order, error) {
+			switch id {
+			case "order-1":
+				return &Order{ID: id, UserID: "user-1", Status: StatusPaid, Items: []*OrderItem{{ID: "item-1", ProductID: "p1", Quantity: 1}}}, nil
+			case "broken":
+				return nil, errors.New("connection refused")
+			}
+			return nil, ErrOrderNotFound
+		},
+	}
+	service := setupService(mockRepo, &mockCatalog{})
+
+	resp, err := service.GetOrder(callerContext("user-1", auth.RoleUser), &pb.GetOrderRequest{OrderId: "order-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Order.Status != StatusPaid || len(resp.Order.Items) != 1 {
+		t.Errorf("Unexpected order %v", resp.Order)
+	}
+
+	for id, expected := range map[string]codes.Code{"": codes.InvalidArgument, "missing": codes.NotFound, "broken": codes.Internal} {
+		_, err := service.GetOrder(callerContext("admin-1", auth.RoleAdmin), &pb.GetOrderRequest{OrderId: id})
+		if status.Code(err) != expected {
+			t.Errorf("GetOrder(%q): expected %v, got %v", id, expected, err)
+		}
+	}
+
+	// Another customer's order is not found, and a caller needs a token
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected codes.Code
+	}{
+		{"another customer", callerContext("user-2", auth.RoleUser), codes.NotFound},
+		{"service", callerContext("returns-service", auth.RoleService), codes.OK},
+		{"no token", context.Background(), codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetOrder(tt.ctx, &pb.GetOrderRequest{OrderId: "order-1"})
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	var gotFilter OrderFilter
+	var gotPage, gotPageSize int32
+	mockRepo := &MockRepository{
+		ListFunc: func(ctx context.Context, filter OrderFilter, page, pageSize int32) ([]*Order, int32, error) {
+			gotFilter, gotPage, gotPageSize = filter, page, pageSize
+			return []*Order{{ID: "order-1", Status: StatusPending}}, 11, nil
+		},
+	}
+	service := setupService(mockRepo, &mockCatalog{})
+
+	resp, err := service.ListOrders(callerContext("admin-1", auth.RoleAdmin), &pb.ListOrdersRequest{UserId: "user-1", Status: StatusPending, Page: 2, PageSize: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotFilter != (OrderFilter{UserID: "user-1", Status: StatusPending}) || gotPage != 2 || gotPageSize != 100 {
+		t.Errorf("Unexpected list call %+v page %d size %d", gotFilter, gotPage, gotPageSize)
+	}
+	if len(resp.Orders) != 1 || resp.Total != 11 || resp.Page != 2 || resp.PageSize != 100 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	_, err = service.ListOrders(callerContext("admin-1", auth.RoleAdmin), &pb.ListOrdersRequest{Status: "SHIPPED"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unknown status, got %v", err)
+	}
+}
+
+func TestOrders_ActingUser(t *testing.T) {
+	var created *Order
+	var gotFilter OrderFilter
+	mockRepo := &MockRepository{
+		CreateFunc: func(ctx context.Context, order *Order) (*Order, error) {
+			created = order
+			return order, nil
+		},
+		ListFunc: func(ctx context.Context, filter OrderFilter, page, pageSize int32) ([]*Order, int32, error) {
+			gotFilter = filter
+			return nil, 0, nil
+		},
+	}
+	service := setupService(mockRepo, &mockCatalog{prices: map[string]float64{"p1": 10}})
+	customer := callerContext("user-1", auth.RoleUser)
+	items := []*pb.CartItem{{ProductId: "p1", Quantity: 1}}
+
+	if _, err := service.CreateOrder(customer, &pb.CreateOrderRequest{Items: items}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created == nil || created.UserID != "user-1" {
+		t.Errorf("Expected the order of the token's user, got %+v", created)
+	}
+	if _, err := service.CreateOrder(customer, &pb.CreateOrderRequest{UserId: "user-2", Items: items}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for another user's order, got %v", err)
+	}
+	if _, err := service.CreateOrder(context.Background(), &pb.CreateOrderRequest{UserId: "user-1", Items: items}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := service.CreateOrder(callerContext("checkout-service", auth.RoleService), &pb.CreateOrderRequest{Items: items}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a service naming no user, got %v", err)
+	}
+
+	if _, err := service.ListOrders(customer, &pb.ListOrdersRequest{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotFilter.UserID != "user-1" {
+		t.Errorf("Expected the orders of the token's user, got filter %+v", gotFilter)
+	}
+	if _, err := service.ListOrders(customer, &pb.ListOrdersRequest{UserId: "user-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for another user's orders, got %v", err)
+	}
+}
+
+func TestUpdateOrderStatus_Success(t *testing.T) {
+	now := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	var gotFrom, gotTo string
+	var gotAt time.Time
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Order, error) {
+			return &Order{ID: id, Status: StatusPaid}, nil
+		},
+		UpdateStatusFunc: func(ctx context.Context, id, from, to string, at time.Time) (*Order, error) {
+			gotFrom, gotTo, gotAt = from, to, at
+			return &Order{ID: id, Status: to, UpdatedAt: at}, nil
+		},
+	}
+	service := setupService(mockRepo, &mockCatalog{})
+	service.now = func() time.Time { return now }
+
+	resp, err := service.UpdateOrderStatus(context.Background(), &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusFulfilled})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotFrom != StatusPaid || gotTo != StatusFulfilled || !gotAt.Equal(now) {
+		t.Errorf("Unexpected update from %s to %s at %v", gotFrom, gotTo, gotAt)
+	}
+	if resp.Order.Status != StatusFulfilled {
+		t.Errorf("Expected status %s, got %s", StatusFulfilled, resp.Order.Status)
+	}
+}
+
+func TestUpdateOrderStatus_ReportsPaidOrder(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*Order, error) {
+			return &Order{ID: id, Status: StatusPending}, nil
+		},
+		UpdateStatusFunc: func(ctx context.Context, id, from, to string, at time.Time) (*Order, error) {
+			return &Order{ID: id, UserID: "user-1", Status: to, UpdatedAt: at}, nil
+		},
+	}
+	service := setupService(mockRepo, &mockCatalog{})
+	purchases := &mockPurchases{err: errors.New("recommendation service unavailable")}
+	service.purchases = purchases
+
+	// A failed report does not fail the status change
+	resp, err := service.UpdateOrderStatus(context.Background(), &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusPaid})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Order.Status != StatusPaid {
+		t.Errorf("Expected status %s, got %s", StatusPaid, resp.Order.Status)
+	}
+	if len(purchases.paid) != 1 || purchases.paid[0].ID != "order-1" {
+		t.Errorf("Expected order-1 to be reported paid, got %v", purchases.paid)
+	}
+
+	// Other transitions are not reported
+	mockRepo.GetByIDFunc = func(ctx context.Context, id string) (*Order, error) {
+		return &Order{ID: id, Status: StatusPaid}, nil
+	}
+	if _, err := service.UpdateOrderStatus(context.Background(), &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusFulfilled}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(purchases.paid) != 1 {
+		t.Errorf("Expected only the paid transition to be reported, got %d reports", len(purchases.paid))
+	}
+}
+
+func TestUpdateOrderStatus_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *pb.UpdateOrderStatusRequest
+		current   string
+		updateErr error
+		expected  codes.Code
+	}{
+		{"missing order ID", &pb.UpdateOrderStatusRequest{Status: StatusPaid}, StatusPending, nil, codes.InvalidArgument},
+		{"unknown status", &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: "SHIPPED"}, StatusPending, nil, codes.InvalidArgument},
+		{"order not found", &pb.UpdateOrderStatusRequest{OrderId: "missing", Status: StatusPaid}, StatusPending, nil, codes.NotFound},
+		{"skipping a step", &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusDelivered}, StatusPending, nil, codes.FailedPrecondition},
+		{"cancelling a fulfilled order", &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusCancelled}, StatusFulfilled, nil, codes.FailedPrecondition},
+		{"leaving a final status", &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusPaid}, StatusCancelled, nil, codes.FailedPrecondition},
+		{"concurrent change", &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusPaid}, StatusPending, ErrStatusConflict, codes.Aborted},
+		{"database error", &pb.UpdateOrderStatusRequest{OrderId: "order-1", Status: StatusPaid}, StatusPending, errors.New("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				GetByIDFunc: func(ctx context.Context, id string) (*Order, error) {
+					if id == "missing" {
+						return nil, ErrOrderNotFound
+					}
+					return &Order{ID: id, Status: tt.current}, nil
+				},
+				UpdateStatusFunc: func(ctx context.Context, id, from, to string, at time.Time) (*Order, error) {
+					if tt.updateErr != nil {
+						return nil, tt.updateErr
+					}
+					t.Fatal("Expected no status update")
+					return nil, nil
+				},
+			}
+			service := setupService(mockRepo, &mockCatalog{})
+
+			_, err := service.UpdateOrderStatus(context.Background(), tt.req)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestMethodRoles(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", 15*time.Minute, time.Hour)
+	interceptor := auth.NewInterceptor(tokens, auth.InterceptorConfig{ExemptMethods: []string{"/"}, RequiredRoles: MethodRoles()})
+	withToken := func(role string) context.Context {
+		token, _ := tokens.GenerateAccessToken("caller-1", "", role)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+
+	for _, method := range AdminMethods {
+		tests := []struct {
+			name     string
+			ctx      context.Context
+			expected codes.Code
+		}{
+			{"no token", context.Background(), codes.Unauthenticated},
+			{"customer", withToken(auth.RoleUser), codes.PermissionDenied},
+			{"service", withToken(auth.RoleService), codes.OK},
+			{"admin", withToken(auth.RoleAdmin), codes.OK},
+		}
+		for _, tt := range tests {
+			_, err := interceptor.Authenticate(tt.ctx, method)
+			if status.Code(err) != tt.expected {
+				t.Errorf("%s by %s: expected %v, got %v", method, tt.name, tt.expected, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package order
+
+// Order statuses
+const (
+	StatusPending   = "PENDING"
+	StatusPaid      = "PAID"
+	StatusFulfilled = "FULFILLED"
+	StatusDelivered = "DELIVERED"
+	StatusCancelled = "CANCELLED"
+)
+
+// statusTransitions lists the statuses an order can move to from each status.
+// DELIVERED and CANCELLED are final.
+var statusTransitions = map[string][]string{
+	StatusPending:   {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusFulfilled, StatusCancelled},
+	StatusFulfilled: {StatusDelivered},
+	StatusDelivered: {},
+	StatusCancelled: {},
+}
+
+// IsValidStatus reports whether status is an order status
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether an order in status from can move to status to
+func CanTransition(from, to string) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
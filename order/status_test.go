@@ -0,0 +1,44 @@
+package order
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StatusPending, StatusPaid, true},
+		{StatusPending, StatusCancelled, true},
+		{StatusPaid, StatusFulfilled, true},
+		{StatusPaid, StatusCancelled, true},
+		{StatusFulfilled, StatusDelivered, true},
+		{StatusPending, StatusFulfilled, false},
+		{StatusPending, StatusDelivered, false},
+		{StatusPaid, StatusPending, false},
+		{StatusFulfilled, StatusCancelled, false},
+		{StatusDelivered, StatusCancelled, false},
+		{StatusCancelled, StatusPaid, false},
+		{StatusPending, StatusPending, false},
+		{"UNKNOWN", StatusPaid, false},
+		{StatusPending, "UNKNOWN", false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidStatus(t *testing.T) {
+	for _, s := range []string{StatusPending, StatusPaid, StatusFulfilled, StatusDelivered, StatusCancelled} {
+		if !IsValidStatus(s) {
+			t.Errorf("IsValidStatus(%s) = false", s)
+		}
+	}
+	for _, s := range []string{"", "pending", "SHIPPED"} {
+		if IsValidStatus(s) {
+			t.Errorf("IsValidStatus(%q) = true", s)
+		}
+	}
+}
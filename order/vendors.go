@@ -0,0 +1,39 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	vendorspb "github.com/Ujjwaljain16/E-commerce-Backend/vendors/pb"
+)
+
+type grpcCommissions struct {
+	client vendorspb.VendorServiceClient
+}
+
+// NewGRPCCommissions creates a Purchases that records marketplace vendor
+// commissions on paid orders through the vendor service
+func NewGRPCCommissions(client vendorspb.VendorServiceClient) Purchases {
+	return &grpcCommissions{client: client}
+}
+
+// OrderPaid reports the order's lines. The vendor service finds the vendor
+// selling each product and records an order once.
+func (c *grpcCommissions) OrderPaid(ctx context.Context, order *Order) error {
+	lines := make([]*vendorspb.OrderLine, len(order.Items))
+	for i, item := range order.Items {
+		lines[i] = &vendorspb.OrderLine{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		}
+	}
+	_, err := c.client.RecordOrderCommissions(ctx, &vendorspb.RecordOrderCommissionsRequest{
+		OrderId: order.ID,
+		Lines:   lines,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record commissions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	vendorspb "github.com/Ujjwaljain16/E-commerce-Backend/vendors/pb"
+	"google.golang.org/grpc"
+)
+
+// fakeVendorClient records reports from the vendor service client interface
+type fakeVendorClient struct {
+	vendorspb.VendorServiceClient
+	got *vendorspb.RecordOrderCommissionsRequest
+	err error
+}
+
+func (c *fakeVendorClient) RecordOrderCommissions(ctx context.Context, req *vendorspb.RecordOrderCommissionsRequest, opts ...grpc.CallOption) (*vendorspb.RecordOrderCommissionsResponse, error) {
+	c.got = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &vendorspb.RecordOrderCommissionsResponse{}, nil
+}
+
+func TestGRPCCommissions_OrderPaid(t *testing.T) {
+	client := &fakeVendorClient{}
+	commissions := NewGRPCCommissions(client)
+
+	order := &Order{ID: "order-1", UserID: "user-1", Status: StatusPaid, Items: []*OrderItem{
+		{ProductID: "mug", Quantity: 2, UnitPrice: 12.5, LineTotal: 25},
+		{ProductID: "lamp", Quantity: 1, UnitPrice: 40, LineTotal: 40},
+	}}
+	if err := commissions.OrderPaid(context.Background(), order); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := client.got
+	if got.OrderId != "order-1" || len(got.Lines) != 2 {
+		t.Fatalf("Unexpected report %v", got)
+	}
+	if line := got.Lines[0]; line.ProductId != "mug" || line.Quantity != 2 || line.UnitPrice != 12.5 {
+		t.Errorf("Unexpected line %v", line)
+	}
+
+	client.err = errors.New("unavailable")
+	if err := commissions.OrderPaid(context.Background(), order); err == nil {
+		t.Error("Expected an error when the vendor service fails")
+	}
+}
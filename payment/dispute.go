@@ -0,0 +1,369 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Dispute statuses. WON and LOST are final.
+const (
+	DisputeOpen        = "OPEN"
+	DisputeUnderReview = "UNDER_REVIEW"
+	DisputeWon         = "WON"
+	DisputeLost        = "LOST"
+)
+
+// disputeStages orders the dispute statuses, so that an event delivered late
+// cannot move a dispute back
+var disputeStages = map[string]int{
+	DisputeOpen:        0,
+	DisputeUnderReview: 1,
+	DisputeWon:         2,
+	DisputeLost:        2,
+}
+
+// Evidence types
+const (
+	EvidenceReceipt       = "RECEIPT"
+	EvidenceShipping      = "SHIPPING"
+	EvidenceCommunication = "COMMUNICATION"
+	EvidencePolicy        = "POLICY"
+	EvidenceOther         = "OTHER"
+)
+
+var evidenceTypes = []string{EvidenceReceipt, EvidenceShipping, EvidenceCommunication, EvidencePolicy, EvidenceOther}
+
+// Limits on evidence, matching the dispute_evidence columns
+const (
+	maxEvidenceDescriptionLength = 2000
+	maxEvidenceURLLength         = 2048
+	maxSubmittedByLength         = 255
+)
+
+var (
+	// ErrDisputeNotFound is returned when a dispute does not exist
+	ErrDisputeNotFound = errors.New("dispute not found")
+	// ErrDisputeClosed is returned when evidence is added to a dispute that is
+	// already decided
+	ErrDisputeClosed = errors.New("dispute is closed")
+)
+
+// DisputeEvent is a dispute as a provider reports it in a webhook event
+type DisputeEvent struct {
+	// ID is the provider's dispute ID
+	ID     string
+	Status string
+	Reason string
+	// Amount is the disputed amount in minor units
+	Amount   int64
+	Currency string
+	// EvidenceDueBy is when the provider stops accepting evidence; zero when
+	// unknown
+	EvidenceDueBy time.Time
+}
+
+// Dispute is a customer's challenge of a captured payment through their bank,
+// also called a chargeback. Amounts are in the currency's major units.
+type Dispute struct {
+	ID                  string
+	PaymentID           string
+	OrderID             string
+	Provider            string
+	ProviderDisputeID   string
+	Status              string
+	Reason              string
+	Amount              float64
+	Currency            string
+	EvidenceDueBy       *time.Time
+	EvidenceSubmittedAt *time.Time
+	// AdjustedAt is when the order and stock were adjusted for a lost dispute
+	AdjustedAt *time.Time
+	ClosedAt   *time.Time
+	Evidence   []*Evidence
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Evidence is an item submitted to the provider to contest a dispute
+type Evidence struct {
+	Type        string
+	Description string
+	URL         string
+	SubmittedBy string
+	SubmittedAt time.Time
+}
+
+// applyDispute records the dispute an event reports against its payment. The
+// first time a dispute is lost, its order and stock are adjusted.
+func (s *Service) applyDispute(ctx context.Context, provider string, event *Event) error {
+	payment, err := s.repo.GetByProviderRef(ctx, provider, event.PaymentRef)
+	if errors.Is(err, ErrPaymentNotFound) {
+		s.log.Warn(ctx, "Dispute event for unknown payment", map[string]interface{}{"provider": provider, "event_id": event.ID, "payment_ref": event.PaymentRef})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	reported := event.Dispute
+	currency := payment.Currency
+	if reported.Currency != "" {
+		currency = strings.ToUpper(reported.Currency)
+	}
+	amount := payment.CapturedAmount
+	if reported.Amount > 0 {
+		amount = fromMinorUnits(reported.Amount, currency)
+	}
+	dispute := &Dispute{
+		PaymentID:         payment.ID,
+		OrderID:           payment.OrderID,
+		Provider:          provider,
+		ProviderDisputeID: reported.ID,
+		Status:            reported.Status,
+		Reason:            reported.Reason,
+		Amount:            amount,
+		Currency:          currency,
+		UpdatedAt:         s.now(),
+	}
+	if !reported.EvidenceDueBy.IsZero() {
+		dispute.EvidenceDueBy = &reported.EvidenceDueBy
+	}
+
+	saved, err := s.repo.UpsertDispute(ctx, dispute)
+	if err != nil {
+		s.log.Error(ctx, "Failed to record dispute", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID, "event_id": event.ID})
+		return err
+	}
+	s.log.Info(ctx, "Dispute recorded", map[string]interface{}{"dispute_id": saved.ID, "payment_id": payment.ID, "order_id": payment.OrderID, "status": saved.Status})
+
+	if saved.Status != DisputeLost || saved.AdjustedAt != nil {
+		return nil
+	}
+	return s.adjustForLostDispute(ctx, saved)
+}
+
+// adjustForLostDispute cancels the order of a lost dispute and puts its
+// items back into stock, unless the order has already shipped. The order is
+// read and cancelled before the adjustment is claimed, so a failure there is
+// retried with the event; once claimed, stock is restored at most once and
+// restock failures are logged for reconciliation.
+func (s *Service) adjustForLostDispute(ctx context.Context, dispute *Dispute) error {
+	order, err := s.orders.Get(ctx, dispute.OrderID)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get order of lost dispute", map[string]interface{}{"error": err.Error(), "dispute_id": dispute.ID, "order_id": dispute.OrderID})
+		return err
+	}
+	restock := order.Status == orderStatusPaid
+	if restock {
+		if err := s.orders.Cancel(ctx, dispute.OrderID); err != nil {
+			s.log.Error(ctx, "Failed to cancel order of lost dispute", map[string]interface{}{"error": err.Error(), "dispute_id": dispute.ID, "order_id": dispute.OrderID})
+			return err
+		}
+	}
+
+	claimed, err := s.repo.MarkDisputeAdjusted(ctx, dispute.ID, s.now())
+	if err != nil {
+		s.log.Error(ctx, "Failed to mark dispute adjusted", map[string]interface{}{"error": err.Error(), "dispute_id": dispute.ID})
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	if !restock {
+		// Shipped goods are not back in stock, and an order cancelled before
+		// the dispute was decided has already been settled
+		s.log.Info(ctx, "Lost dispute leaves order unchanged", map[string]interface{}{"dispute_id": dispute.ID, "order_id": dispute.OrderID, "order_status": order.Status})
+		return nil
+	}
+
+	for _, item := range order.Items {
+		if err := s.inventory.Restock(ctx, item.ProductID, item.Quantity); err != nil {
+			s.log.Error(ctx, "Failed to restock item of lost dispute; reconcile stock manually", map[string]interface{}{
+				"error":      err.Error(),
+				"dispute_id": dispute.ID,
+				"order_id":   dispute.OrderID,
+				"product_id": item.ProductID,
+				"quantity":   item.Quantity,
+			})
+		}
+	}
+	s.log.Info(ctx, "Order cancelled for lost dispute", map[string]interface{}{"dispute_id": dispute.ID, "order_id": dispute.OrderID})
+	return nil
+}
+
+// GetDispute retrieves a dispute with its evidence
+func (s *Service) GetDispute(ctx context.Context, req *pb.GetDisputeRequest) (*pb.GetDisputeResponse, error) {
+	if req.DisputeId == "" {
+		s.log.Warn(ctx, "Get dispute failed: dispute ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "dispute_id is required")
+	}
+
+	dispute, err := s.repo.GetDispute(ctx, req.DisputeId)
+	if err != nil {
+		return nil, s.disputeLookupError(ctx, err, req.DisputeId)
+	}
+
+	return &pb.GetDisputeResponse{
+		Dispute: toProtoDispute(dispute),
+	}, nil
+}
+
+// ListDisputes lists disputes, those whose evidence is due soonest first
+func (s *Service) ListDisputes(ctx context.Context, req *pb.ListDisputesRequest) (*pb.ListDisputesResponse, error) {
+	if _, ok := disputeStages[req.Status]; req.Status != "" && !ok {
+		s.log.Warn(ctx, "List disputes failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be OPEN, UNDER_REVIEW, WON or LOST")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	disputes, total, err := s.repo.ListDisputes(ctx, req.Status, int(page), int(pageSize))
+	if err != nil {
+		s.log.Error(ctx, "Failed to list disputes", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list disputes")
+	}
+
+	resp := &pb.ListDisputesResponse{
+		Disputes: make([]*pb.Dispute, len(disputes)),
+		Total:    int32(total),
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for i, dispute := range disputes {
+		resp.Disputes[i] = toProtoDispute(dispute)
+	}
+	return resp, nil
+}
+
+// SubmitDisputeEvidence records evidence submitted to the provider to
+// contest a dispute. Evidence is refused once the dispute is decided or the
+// provider's deadline has passed.
+func (s *Service) SubmitDisputeEvidence(ctx context.Context, req *pb.SubmitDisputeEvidenceRequest) (*pb.SubmitDisputeEvidenceResponse, error) {
+	if msg := evidenceProblem(req); msg != "" {
+		s.log.Warn(ctx, "Submit dispute evidence failed: "+msg, map[string]interface{}{"dispute_id": req.DisputeId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	dispute, err := s.repo.GetDispute(ctx, req.DisputeId)
+	if err != nil {
+		return nil, s.disputeLookupError(ctx, err, req.DisputeId)
+	}
+	now := s.now()
+	if dispute.EvidenceDueBy != nil && now.After(*dispute.EvidenceDueBy) {
+		s.log.Warn(ctx, "Submit dispute evidence failed: deadline passed", map[string]interface{}{"dispute_id": dispute.ID, "due_by": *dispute.EvidenceDueBy})
+		return nil, status.Error(codes.FailedPrecondition, "the evidence deadline has passed")
+	}
+
+	evidence := &Evidence{
+		Type:        req.Type,
+		Description: req.Description,
+		URL:         req.Url,
+		SubmittedBy: req.SubmittedBy,
+		SubmittedAt: now,
+	}
+	saved, err := s.repo.AddDisputeEvidence(ctx, dispute.ID, evidence)
+	if errors.Is(err, ErrDisputeClosed) {
+		s.log.Warn(ctx, "Submit dispute evidence failed: dispute closed", map[string]interface{}{"dispute_id": dispute.ID})
+		return nil, status.Error(codes.FailedPrecondition, "dispute is already decided")
+	}
+	if err != nil {
+		return nil, s.disputeLookupError(ctx, err, dispute.ID)
+	}
+
+	s.log.Info(ctx, "Dispute evidence recorded", map[string]interface{}{"dispute_id": dispute.ID, "type": req.Type})
+	return &pb.SubmitDisputeEvidenceResponse{
+		Dispute: toProtoDispute(saved),
+	}, nil
+}
+
+// evidenceProblem validates an evidence submission and returns a message
+// describing the first problem, or ""
+func evidenceProblem(req *pb.SubmitDisputeEvidenceRequest) string {
+	switch {
+	case req.DisputeId == "":
+		return "dispute_id is required"
+	case !slices.Contains(evidenceTypes, req.Type):
+		return "type must be one of " + strings.Join(evidenceTypes, ", ")
+	case req.Description == "" && req.Url == "":
+		return "description or url is required"
+	case len(req.Description) > maxEvidenceDescriptionLength:
+		return "description is too long"
+	case len(req.Url) > maxEvidenceURLLength:
+		return "url is too long"
+	case req.SubmittedBy == "":
+		return "submitted_by is required"
+	case len(req.SubmittedBy) > maxSubmittedByLength:
+		return "submitted_by is too long"
+	}
+	return ""
+}
+
+// disputeLookupError maps an error reading a dispute to a gRPC status
+func (s *Service) disputeLookupError(ctx context.Context, err error, disputeID string) error {
+	if errors.Is(err, ErrDisputeNotFound) {
+		s.log.Warn(ctx, "Dispute not found", map[string]interface{}{"dispute_id": disputeID})
+		return status.Error(codes.NotFound, "dispute not found")
+	}
+	s.log.Error(ctx, "Failed to get dispute", map[string]interface{}{"error": err.Error(), "dispute_id": disputeID})
+	return status.Error(codes.Internal, "failed to get dispute")
+}
+
+// toProtoDispute converts a dispute to protobuf
+func toProtoDispute(d *Dispute) *pb.Dispute {
+	dispute := &pb.Dispute{
+		Id:                d.ID,
+		PaymentId:         d.PaymentID,
+		OrderId:           d.OrderID,
+		Provider:          d.Provider,
+		ProviderDisputeId: d.ProviderDisputeID,
+		Status:            d.Status,
+		Reason:            d.Reason,
+		Amount:            d.Amount,
+		Currency:          d.Currency,
+		Evidence:          make([]*pb.Evidence, len(d.Evidence)),
+		CreatedAt:         timestamppb.New(d.CreatedAt),
+		UpdatedAt:         timestamppb.New(d.UpdatedAt),
+	}
+	if d.EvidenceDueBy != nil {
+		dispute.EvidenceDueBy = timestamppb.New(*d.EvidenceDueBy)
+	}
+	if d.EvidenceSubmittedAt != nil {
+		dispute.EvidenceSubmittedAt = timestamppb.New(*d.EvidenceSubmittedAt)
+	}
+	if d.AdjustedAt != nil {
+		dispute.AdjustedAt = timestamppb.New(*d.AdjustedAt)
+	}
+	if d.ClosedAt != nil {
+		dispute.ClosedAt = timestamppb.New(*d.ClosedAt)
+	}
+	for i, e := range d.Evidence {
+		dispute.Evidence[i] = &pb.Evidence{
+			Type:        e.Type,
+			Description: e.Description,
+			Url:         e.URL,
+			SubmittedBy: e.SubmittedBy,
+			SubmittedAt: timestamppb.New(e.SubmittedAt),
+		}
+	}
+	return dispute
+}
@@ -0,0 +1,282 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+const disputeColumns = `id, payment_id, order_id, provider, provider_dispute_id, status, reason, amount, currency,
+	evidence_due_by, evidence_submitted_at, adjusted_at, closed_at, created_at, updated_at`
+
+// UpsertDispute records a reported dispute. The dispute row is locked while a
+// report is merged into it, so concurrent deliveries of events about the same
+// dispute are applied one after the other.
+func (r *postgresRepository) UpsertDispute(ctx context.Context, dispute *Dispute) (*Dispute, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	dispute.ID = uuid.New().String()
+	dispute.CreatedAt = dispute.UpdatedAt
+	if isFinalDispute(dispute.Status) {
+		dispute.ClosedAt = &dispute.UpdatedAt
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO disputes (id, payment_id, order_id, provider, provider_dispute_id, status, reason, amount, currency,
+			evidence_due_by, closed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (provider, provider_dispute_id) DO NOTHING
+	`, dispute.ID, dispute.PaymentID, dispute.OrderID, dispute.Provider, dispute.ProviderDisputeID, dispute.Status, dispute.Reason,
+		dispute.Amount, dispute.Currency, dispute.EvidenceDueBy, dispute.ClosedAt, dispute.CreatedAt, dispute.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create dispute", map[string]interface{}{"error": err.Error(), "payment_id": dispute.PaymentID})
+		return nil, fmt.Errorf("failed to create dispute: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dispute: %w", err)
+	}
+
+	saved := dispute
+	if rows == 0 {
+		saved, err = r.mergeDispute(ctx, tx, dispute)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Dispute recorded", map[string]interface{}{"dispute_id": saved.ID, "payment_id": saved.PaymentID, "status": saved.Status})
+	return saved, nil
+}
+
+// mergeDispute applies a report to a dispute already recorded. A decided
+// dispute is left as it is, and the status only moves forward.
+func (r *postgresRepository) mergeDispute(ctx context.Context, tx *sql.Tx, reported *Dispute) (*Dispute, error) {
+	query := "SELECT " + disputeColumns + " FROM disputes WHERE provider = $1 AND provider_dispute_id = $2 FOR UPDATE"
+	existing, err := scanDispute(tx.QueryRowContext(ctx, query, reported.Provider, reported.ProviderDisputeID))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get dispute", map[string]interface{}{"error": err.Error(), "provider_dispute_id": reported.ProviderDisputeID})
+		return nil, fmt.Errorf("failed to get dispute: %w", err)
+	}
+	if isFinalDispute(existing.Status) {
+		return existing, nil
+	}
+
+	next := existing.Status
+	if disputeStages[reported.Status] > disputeStages[existing.Status] {
+		next = reported.Status
+	}
+	var closedAt *time.Time
+	if isFinalDispute(next) {
+		closedAt = &reported.UpdatedAt
+	}
+
+	query = `
+		UPDATE disputes
+		SET status = $2, reason = $3, amount = $4, currency = $5, evidence_due_by = COALESCE($6, evidence_due_by),
+			closed_at = $7, updated_at = $8
+		WHERE id = $1
+		RETURNING ` + disputeColumns
+	updated, err := scanDispute(tx.QueryRowContext(ctx, query, existing.ID, next, reported.Reason, reported.Amount, reported.Currency,
+		reported.EvidenceDueBy, closedAt, reported.UpdatedAt))
+	if err != nil {
+		r.log.Error(ctx, "Failed to update dispute", map[string]interface{}{"error": err.Error(), "dispute_id": existing.ID})
+		return nil, fmt.Errorf("failed to update dispute: %w", err)
+	}
+	return updated, nil
+}
+
+// GetDispute retrieves a dispute and its evidence, oldest first
+func (r *postgresRepository) GetDispute(ctx context.Context, id string) (*Dispute, error) {
+	query := "SELECT " + disputeColumns + " FROM disputes WHERE id = $1"
+
+	dispute, err := scanDispute(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrDisputeNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get dispute", map[string]interface{}{"error": err.Error(), "dispute_id": id})
+		return nil, fmt.Errorf("failed to get dispute: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT type, description, url, submitted_by, submitted_at
+		FROM dispute_evidence
+		WHERE dispute_id = $1
+		ORDER BY submitted_at, id
+	`, id)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get dispute evidence", map[string]interface{}{"error": err.Error(), "dispute_id": id})
+		return nil, fmt.Errorf("failed to get dispute evidence: %w", err)
+	}
+	defer rows.Close()
+
+	dispute.Evidence = []*Evidence{}
+	for rows.Next() {
+		e := &Evidence{}
+		if err := rows.Scan(&e.Type, &e.Description, &e.URL, &e.SubmittedBy, &e.SubmittedAt); err != nil {
+			r.log.Error(ctx, "Failed to scan dispute evidence", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan dispute evidence: %w", err)
+		}
+		dispute.Evidence = append(dispute.Evidence, e)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating dispute evidence", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating dispute evidence: %w", err)
+	}
+	return dispute, nil
+}
+
+// ListDisputes retrieves a page of disputes without their evidence. Disputes
+// with no evidence deadline come after those with one.
+func (r *postgresRepository) ListDisputes(ctx context.Context, status string, page, pageSize int) ([]*Dispute, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM disputes WHERE ($1 = '' OR status = $1)", status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count disputes", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count disputes: %w", err)
+	}
+
+	query := "SELECT " + disputeColumns + `
+		FROM disputes
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY evidence_due_by ASC NULLS LAST, created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list disputes", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list disputes: %w", err)
+	}
+	defer rows.Close()
+
+	disputes := []*Dispute{}
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan dispute", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan dispute: %w", err)
+		}
+		disputes = append(disputes, dispute)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating disputes", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating disputes: %w", err)
+	}
+	return disputes, total, nil
+}
+
+// AddDisputeEvidence records evidence for an undecided dispute and notes when
+// evidence was first submitted
+func (r *postgresRepository) AddDisputeEvidence(ctx context.Context, disputeID string, evidence *Evidence) (*Dispute, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.Error(ctx, "Failed to begin transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM disputes WHERE id = $1 FOR UPDATE", disputeID).Scan(&current)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrDisputeNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get dispute", map[string]interface{}{"error": err.Error(), "dispute_id": disputeID})
+		return nil, fmt.Errorf("failed to get dispute: %w", err)
+	}
+	if isFinalDispute(current) {
+		return nil, ErrDisputeClosed
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dispute_evidence (dispute_id, type, description, url, submitted_by, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, disputeID, evidence.Type, evidence.Description, evidence.URL, evidence.SubmittedBy, evidence.SubmittedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to add dispute evidence", map[string]interface{}{"error": err.Error(), "dispute_id": disputeID})
+		return nil, fmt.Errorf("failed to add dispute evidence: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE disputes
+		SET evidence_submitted_at = COALESCE(evidence_submitted_at, $2), updated_at = $2
+		WHERE id = $1
+	`, disputeID, evidence.SubmittedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to update dispute", map[string]interface{}{"error": err.Error(), "dispute_id": disputeID})
+		return nil, fmt.Errorf("failed to update dispute: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(ctx, "Failed to commit transaction", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Dispute evidence added", map[string]interface{}{"dispute_id": disputeID, "type": evidence.Type})
+	return r.GetDispute(ctx, disputeID)
+}
+
+// MarkDisputeAdjusted claims the adjustment of a lost dispute, so that its
+// order and stock are adjusted once however many events report the loss
+func (r *postgresRepository) MarkDisputeAdjusted(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE disputes
+		SET adjusted_at = $2, updated_at = $2
+		WHERE id = $1 AND status = 'LOST' AND adjusted_at IS NULL
+	`, id, at)
+	if err != nil {
+		r.log.Error(ctx, "Failed to mark dispute adjusted", map[string]interface{}{"error": err.Error(), "dispute_id": id})
+		return false, fmt.Errorf("failed to mark dispute adjusted: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark dispute adjusted: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// isFinalDispute reports whether a dispute in status is decided
+func isFinalDispute(status string) bool {
+	return status == DisputeWon || status == DisputeLost
+}
+
+// scanDispute reads a dispute in disputeColumns order
+func scanDispute(row rowScanner) (*Dispute, error) {
+	d := &Dispute{}
+	var evidenceDueBy, evidenceSubmittedAt, adjustedAt, closedAt sql.NullTime
+	err := row.Scan(&d.ID, &d.PaymentID, &d.OrderID, &d.Provider, &d.ProviderDisputeID, &d.Status, &d.Reason, &d.Amount, &d.Currency,
+		&evidenceDueBy, &evidenceSubmittedAt, &adjustedAt, &closedAt, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range []struct {
+		src sql.NullTime
+		dst **time.Time
+	}{
+		{evidenceDueBy, &d.EvidenceDueBy},
+		{evidenceSubmittedAt, &d.EvidenceSubmittedAt},
+		{adjustedAt, &d.AdjustedAt},
+		{closedAt, &d.ClosedAt},
+	} {
+		if t.src.Valid {
+			value := t.src.Time
+			*t.dst = &value
+		}
+	}
+	return d, nil
+}
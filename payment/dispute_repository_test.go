@@ -0,0 +1,140 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var disputeColumnNames = []string{
+	"id", "payment_id", "order_id", "provider", "provider_dispute_id", "status", "reason", "amount", "currency",
+	"evidence_due_by", "evidence_submitted_at", "adjusted_at", "closed_at", "created_at", "updated_at",
+}
+
+func disputeRow(status string, closedAt interface{}) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows(disputeColumnNames).
+		AddRow("dispute-1", "pay-1", "order-1", ProviderStripe, "dp_1", status, "fraudulent", 12.5, "USD", now, nil, nil, closedAt, now, now)
+}
+
+func reportedDispute(status string) *Dispute {
+	return &Dispute{PaymentID: "pay-1", OrderID: "order-1", Provider: ProviderStripe, ProviderDisputeID: "dp_1",
+		Status: status, Reason: "fraudulent", Amount: 12.5, Currency: "USD", UpdatedAt: time.Now()}
+}
+
+func TestUpsertDispute_Create(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO disputes .* ON CONFLICT \(provider, provider_dispute_id\) DO NOTHING`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	saved, err := repo.UpsertDispute(context.Background(), reportedDispute(DisputeOpen))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.ID == "" || saved.ClosedAt != nil {
+		t.Errorf("Unexpected dispute %+v", saved)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpsertDispute_Advances(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	reported := reportedDispute(DisputeLost)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO disputes`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM disputes WHERE provider = \$1 AND provider_dispute_id = \$2 FOR UPDATE`).
+		WithArgs(ProviderStripe, "dp_1").
+		WillReturnRows(disputeRow(DisputeUnderReview, nil))
+	mock.ExpectQuery(`UPDATE disputes`).
+		WithArgs("dispute-1", DisputeLost, "fraudulent", 12.5, "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), reported.UpdatedAt).
+		WillReturnRows(disputeRow(DisputeLost, time.Now()))
+	mock.ExpectCommit()
+
+	saved, err := repo.UpsertDispute(context.Background(), reported)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.ID != "dispute-1" || saved.Status != DisputeLost || saved.ClosedAt == nil {
+		t.Errorf("Unexpected dispute %+v", saved)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpsertDispute_DecidedUnchanged(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO disputes`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM disputes .* FOR UPDATE`).WillReturnRows(disputeRow(DisputeWon, time.Now()))
+	mock.ExpectCommit()
+
+	saved, err := repo.UpsertDispute(context.Background(), reportedDispute(DisputeLost))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Status != DisputeWon {
+		t.Errorf("Expected the decided dispute to stay WON, got %s", saved.Status)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddDisputeEvidence_Closed(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM disputes WHERE id = \$1 FOR UPDATE`).
+		WithArgs("dispute-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(DisputeLost))
+	mock.ExpectRollback()
+
+	_, err := repo.AddDisputeEvidence(context.Background(), "dispute-1", &Evidence{Type: EvidenceReceipt, SubmittedBy: "admin-1", SubmittedAt: time.Now()})
+	if !errors.Is(err, ErrDisputeClosed) {
+		t.Errorf("Expected ErrDisputeClosed, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkDisputeAdjusted(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE disputes SET adjusted_at = \$2, updated_at = \$2 WHERE id = \$1 AND status = 'LOST' AND adjusted_at IS NULL`).
+		WithArgs("dispute-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE disputes SET adjusted_at`).
+		WithArgs("dispute-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for i, expected := range []bool{true, false} {
+		claimed, err := repo.MarkDisputeAdjusted(context.Background(), "dispute-1", now)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if claimed != expected {
+			t.Errorf("Call %d: expected claimed=%v, got %v", i+1, expected, claimed)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
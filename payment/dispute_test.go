@@ -0,0 +1,229 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// capturedPayment authorizes and captures a payment for order-1 and returns
+// its provider payment ID
+func (f *serviceFixture) capturedPayment(t *testing.T) string {
+	authorized := f.authorize(t, validAuthorization())
+	ref := authorized.ProviderPaymentId
+	if code := f.deliver(t, ProviderStripe, `{"id": "evt_capture", "type": "captured", "payment_ref": "`+ref+`"}`); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	return ref
+}
+
+func disputeEvent(id, ref, disputeStatus string) string {
+	return `{"id": "` + id + `", "type": "dispute", "payment_ref": "` + ref + `", "dispute_id": "dp_1", "dispute_status": "` + disputeStatus + `", "reason": "fraudulent", "amount": 2550}`
+}
+
+// onlyDispute returns the fixture's one recorded dispute
+func (f *serviceFixture) onlyDispute(t *testing.T) Dispute {
+	if len(f.repo.disputes) != 1 {
+		t.Fatalf("Expected one dispute, got %d", len(f.repo.disputes))
+	}
+	for _, d := range f.repo.disputes {
+		return d
+	}
+	return Dispute{}
+}
+
+func TestWebhook_DisputeRecorded(t *testing.T) {
+	f := setupService(t)
+	ref := f.capturedPayment(t)
+
+	if code := f.deliver(t, ProviderStripe, disputeEvent("evt_1", ref, DisputeOpen)); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if code := f.deliver(t, ProviderStripe, disputeEvent("evt_2", ref, DisputeUnderReview)); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	// A late event does not move the dispute back
+	f.deliver(t, ProviderStripe, disputeEvent("evt_3", ref, DisputeOpen))
+
+	dispute := f.onlyDispute(t)
+	if dispute.Status != DisputeUnderReview || dispute.Amount != 25.5 || dispute.Currency != "USD" || dispute.OrderID != "order-1" || dispute.Reason != "fraudulent" {
+		t.Errorf("Unexpected dispute %+v", dispute)
+	}
+	if calls := f.orders.Calls(); !reflect.DeepEqual(calls, []string{"paid order-1"}) {
+		t.Errorf("Expected an open dispute to leave the order, got %v", calls)
+	}
+}
+
+func TestWebhook_LostDisputeCancelsAndRestocksOnce(t *testing.T) {
+	f := setupService(t)
+	ref := f.capturedPayment(t)
+	f.orders.orders["order-1"] = &Order{Status: orderStatusPaid, Items: []OrderItem{{"prod-1", 2}, {"prod-2", 1}}}
+
+	f.deliver(t, ProviderStripe, disputeEvent("evt_1", ref, DisputeOpen))
+	for i, id := range []string{"evt_2", "evt_3"} {
+		if code := f.deliver(t, ProviderStripe, disputeEvent(id, ref, DisputeLost)); code != http.StatusOK {
+			t.Fatalf("Delivery %d: expected 200, got %d", i+1, code)
+		}
+		f.orders.orders["order-1"].Status = orderStatusCancelled
+	}
+
+	dispute := f.onlyDispute(t)
+	if dispute.Status != DisputeLost || dispute.AdjustedAt == nil || dispute.ClosedAt == nil {
+		t.Errorf("Expected an adjusted lost dispute, got %+v", dispute)
+	}
+	if calls := f.orders.Calls(); !reflect.DeepEqual(calls, []string{"paid order-1", "cancel order-1"}) {
+		t.Errorf("Expected the order to be cancelled once, got %v", calls)
+	}
+	if restocks := f.inventory.Restocks(); !reflect.DeepEqual(restocks, []string{"prod-1 2", "prod-2 1"}) {
+		t.Errorf("Expected the items to be restocked once, got %v", restocks)
+	}
+}
+
+func TestWebhook_LostDisputeLeavesShippedOrder(t *testing.T) {
+	f := setupService(t)
+	ref := f.capturedPayment(t)
+	f.orders.orders["order-1"] = &Order{Status: "FULFILLED", Items: []OrderItem{{"prod-1", 2}}}
+
+	if code := f.deliver(t, ProviderStripe, disputeEvent("evt_1", ref, DisputeLost)); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+
+	if dispute := f.onlyDispute(t); dispute.AdjustedAt == nil {
+		t.Errorf("Expected the dispute to be marked adjusted, got %+v", dispute)
+	}
+	if calls := f.orders.Calls(); len(calls) != 1 {
+		t.Errorf("Expected the shipped order to be left, got %v", calls)
+	}
+	if restocks := f.inventory.Restocks(); len(restocks) != 0 {
+		t.Errorf("Expected no restock, got %v", restocks)
+	}
+}
+
+func TestWebhook_LostDisputeRetriedWhenOrderUnavailable(t *testing.T) {
+	f := setupService(t)
+	ref := f.capturedPayment(t)
+	f.orders.orders["order-1"] = &Order{Status: orderStatusPaid, Items: []OrderItem{{"prod-1", 1}}}
+
+	f.orders.err = errors.New("order service unavailable")
+	if code := f.deliver(t, ProviderStripe, disputeEvent("evt_1", ref, DisputeLost)); code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", code)
+	}
+	if dispute := f.onlyDispute(t); dispute.AdjustedAt != nil {
+		t.Fatalf("Expected the adjustment to be left for the retry, got %+v", dispute)
+	}
+
+	f.orders.err = nil
+	if code := f.deliver(t, ProviderStripe, disputeEvent("evt_1", ref, DisputeLost)); code != http.StatusOK {
+		t.Fatalf("Expected 200 on retry, got %d", code)
+	}
+	if restocks := f.inventory.Restocks(); !reflect.DeepEqual(restocks, []string{"prod-1 1"}) {
+		t.Errorf("Expected the retry to restock, got %v", restocks)
+	}
+}
+
+func TestSubmitDisputeEvidence(t *testing.T) {
+	f := setupService(t)
+	ref := f.capturedPayment(t)
+	f.deliver(t, ProviderStripe, disputeEvent("evt_1", ref, DisputeOpen))
+	dispute := f.onlyDispute(t)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	f.service.now = func() time.Time { return now }
+	resp, err := f.service.SubmitDisputeEvidence(context.Background(), &pb.SubmitDisputeEvidenceRequest{
+		DisputeId:   dispute.ID,
+		Type:        EvidenceShipping,
+		Url:         "https://files.example.com/tracking.pdf",
+		SubmittedBy: "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Dispute.Evidence) != 1 || resp.Dispute.Evidence[0].Type != EvidenceShipping || !resp.Dispute.EvidenceSubmittedAt.AsTime().Equal(now) {
+		t.Errorf("Unexpected dispute %v", resp.Dispute)
+	}
+
+	got, err := f.service.GetDispute(context.Background(), &pb.GetDisputeRequest{DisputeId: dispute.ID})
+	if err != nil || len(got.Dispute.Evidence) != 1 {
+		t.Errorf("Expected the evidence to be stored, got %v, %v", got, err)
+	}
+}
+
+func TestSubmitDisputeEvidence_Refused(t *testing.T) {
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		status   string
+		now      time.Time
+		req      func(id string) *pb.SubmitDisputeEvidenceRequest
+		expected codes.Code
+	}{
+		{"missing dispute", DisputeOpen, due, func(id string) *pb.SubmitDisputeEvidenceRequest {
+			return &pb.SubmitDisputeEvidenceRequest{Type: EvidenceReceipt, Description: "receipt", SubmittedBy: "admin-1"}
+		}, codes.InvalidArgument},
+		{"unknown type", DisputeOpen, due, func(id string) *pb.SubmitDisputeEvidenceRequest {
+			return &pb.SubmitDisputeEvidenceRequest{DisputeId: id, Type: "PHOTO", Description: "receipt", SubmittedBy: "admin-1"}
+		}, codes.InvalidArgument},
+		{"no content", DisputeOpen, due, func(id string) *pb.SubmitDisputeEvidenceRequest {
+			return &pb.SubmitDisputeEvidenceRequest{DisputeId: id, Type: EvidenceReceipt, SubmittedBy: "admin-1"}
+		}, codes.InvalidArgument},
+		{"unknown dispute", DisputeOpen, due, func(id string) *pb.SubmitDisputeEvidenceRequest {
+			return &pb.SubmitDisputeEvidenceRequest{DisputeId: "dispute-missing", Type: EvidenceReceipt, Description: "receipt", SubmittedBy: "admin-1"}
+		}, codes.NotFound},
+		{"deadline passed", DisputeOpen, due.Add(time.Second), func(id string) *pb.SubmitDisputeEvidenceRequest {
+			return &pb.SubmitDisputeEvidenceRequest{DisputeId: id, Type: EvidenceReceipt, Description: "receipt", SubmittedBy: "admin-1"}
+		}, codes.FailedPrecondition},
+		{"decided", DisputeWon, due, func(id string) *pb.SubmitDisputeEvidenceRequest {
+			return &pb.SubmitDisputeEvidenceRequest{DisputeId: id, Type: EvidenceReceipt, Description: "receipt", SubmittedBy: "admin-1"}
+		}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			dispute, _ := f.repo.UpsertDispute(context.Background(), &Dispute{
+				Provider: ProviderStripe, ProviderDisputeID: "dp_1", Status: tt.status, EvidenceDueBy: &due, UpdatedAt: due,
+			})
+			f.service.now = func() time.Time { return tt.now }
+
+			_, err := f.service.SubmitDisputeEvidence(context.Background(), tt.req(dispute.ID))
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestListDisputes(t *testing.T) {
+	f := setupService(t)
+	soon := time.Now().Add(24 * time.Hour)
+	later := soon.Add(24 * time.Hour)
+	for i, d := range []*Dispute{
+		{ProviderDisputeID: "dp_later", Status: DisputeOpen, EvidenceDueBy: &later},
+		{ProviderDisputeID: "dp_soon", Status: DisputeOpen, EvidenceDueBy: &soon},
+		{ProviderDisputeID: "dp_won", Status: DisputeWon},
+	} {
+		d.Provider, d.UpdatedAt = ProviderStripe, time.Now().Add(time.Duration(i)*time.Second)
+		f.repo.UpsertDispute(context.Background(), d)
+	}
+
+	resp, err := f.service.ListDisputes(context.Background(), &pb.ListDisputesRequest{Status: DisputeOpen})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || len(resp.Disputes) != 2 || resp.Disputes[0].ProviderDisputeId != "dp_soon" || resp.PageSize != 10 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	_, err = f.service.ListDisputes(context.Background(), &pb.ListDisputesRequest{Status: "CLOSED"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
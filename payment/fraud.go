@@ -0,0 +1,73 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	fraudpb "github.com/Ujjwaljain16/E-commerce-Backend/fraud/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Fraud assessment statuses that stop a capture
+const (
+	fraudStatusPendingReview = "PENDING_REVIEW"
+	fraudStatusRejected      = "REJECTED"
+)
+
+var (
+	// ErrFraudReview is returned while an order's checkout is held for fraud review
+	ErrFraudReview = errors.New("order is held for fraud review")
+	// ErrFraudRejected is returned when an order's checkout was rejected as fraudulent
+	ErrFraudRejected = errors.New("order was rejected as fraudulent")
+)
+
+// FraudChecks decides whether an order's payment may be captured
+type FraudChecks interface {
+	// CaptureAllowed returns ErrFraudReview or ErrFraudRejected when the
+	// order's payment must not be captured
+	CaptureAllowed(ctx context.Context, orderID string) error
+}
+
+type noFraudChecks struct{}
+
+// NewNoFraudChecks creates FraudChecks that allow every capture, for
+// deployments without a fraud service
+func NewNoFraudChecks() FraudChecks {
+	return noFraudChecks{}
+}
+
+func (noFraudChecks) CaptureAllowed(ctx context.Context, orderID string) error {
+	return nil
+}
+
+type grpcFraudChecks struct {
+	client fraudpb.FraudServiceClient
+}
+
+// NewGRPCFraudChecks creates FraudChecks backed by the fraud service
+func NewGRPCFraudChecks(client fraudpb.FraudServiceClient) FraudChecks {
+	return &grpcFraudChecks{client: client}
+}
+
+// CaptureAllowed reads the order's assessment. An order without one was not
+// scored at checkout, e.g. because it predates the fraud service, and is
+// allowed.
+func (f *grpcFraudChecks) CaptureAllowed(ctx context.Context, orderID string) error {
+	resp, err := f.client.GetAssessment(ctx, &fraudpb.GetAssessmentRequest{OrderId: orderID})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get fraud assessment: %w", err)
+	}
+
+	switch resp.Assessment.Status {
+	case fraudStatusPendingReview:
+		return ErrFraudReview
+	case fraudStatusRejected:
+		return ErrFraudRejected
+	}
+	return nil
+}
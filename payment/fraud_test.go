@@ -0,0 +1,58 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fraudpb "github.com/Ujjwaljain16/E-commerce-Backend/fraud/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeFraudClient answers GetAssessment with the assessments it holds
+type fakeFraudClient struct {
+	fraudpb.FraudServiceClient
+	assessments map[string]string
+	err         error
+}
+
+func (c *fakeFraudClient) GetAssessment(ctx context.Context, req *fraudpb.GetAssessmentRequest, opts ...grpc.CallOption) (*fraudpb.GetAssessmentResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	s, ok := c.assessments[req.OrderId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "assessment not found")
+	}
+	return &fraudpb.GetAssessmentResponse{Assessment: &fraudpb.Assessment{OrderId: req.OrderId, Status: s}}, nil
+}
+
+func TestGRPCFraudChecks(t *testing.T) {
+	checks := NewGRPCFraudChecks(&fakeFraudClient{assessments: map[string]string{
+		"approved": "APPROVED",
+		"held":     "PENDING_REVIEW",
+		"rejected": "REJECTED",
+	}})
+
+	tests := []struct {
+		orderID  string
+		expected error
+	}{
+		{"approved", nil},
+		{"held", ErrFraudReview},
+		{"rejected", ErrFraudRejected},
+		{"unscored", nil},
+	}
+	for _, tt := range tests {
+		if err := checks.CaptureAllowed(context.Background(), tt.orderID); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.orderID, tt.expected, err)
+		}
+	}
+
+	down := NewGRPCFraudChecks(&fakeFraudClient{err: status.Error(codes.Unavailable, "connection refused")})
+	if err := down.CaptureAllowed(context.Background(), "approved"); err == nil || errors.Is(err, ErrFraudReview) || errors.Is(err, ErrFraudRejected) {
+		t.Errorf("Expected an outage error, got %v", err)
+	}
+}
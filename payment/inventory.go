@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+)
+
+// Inventory puts the items of orders cancelled after a lost dispute back
+// into stock
+type Inventory interface {
+	// Restock adds quantity to a product's stock
+	Restock(ctx context.Context, productID string, quantity int32) error
+}
+
+type grpcInventory struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCInventory creates Inventory backed by the catalog service
+func NewGRPCInventory(client catalogpb.CatalogServiceClient) Inventory {
+	return &grpcInventory{client: client}
+}
+
+func (i *grpcInventory) Restock(ctx context.Context, productID string, quantity int32) error {
+	_, err := i.client.IncrementStock(ctx, &catalogpb.IncrementStockRequest{ProductId: productID, Quantity: quantity})
+	if err != nil {
+		return fmt.Errorf("failed to increment stock: %w", err)
+	}
+	return nil
+}
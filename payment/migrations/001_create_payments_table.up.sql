@@ -0,0 +1,23 @@
+-- Order payments. status follows the payment lifecycle:
+-- PENDING -> AUTHORIZED -> CAPTURED -> REFUNDED, AUTHORIZED -> VOIDED,
+-- and PENDING -> DECLINED or FAILED when the provider refuses or errors.
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    provider_payment_id VARCHAR(255),
+    provider_capture_id VARCHAR(255),
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'AUTHORIZED', 'CAPTURED', 'VOIDED', 'REFUNDED', 'DECLINED', 'FAILED')),
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    captured_amount DECIMAL(12, 2) NOT NULL DEFAULT 0 CHECK (captured_amount >= 0 AND captured_amount <= amount),
+    refunded_amount DECIMAL(12, 2) NOT NULL DEFAULT 0 CHECK (refunded_amount >= 0 AND refunded_amount <= captured_amount),
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, provider_payment_id)
+);
+
+CREATE INDEX idx_payments_order ON payments(order_id, created_at DESC);
+CREATE INDEX idx_payments_status ON payments(status, created_at DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_provider_capture;
+DROP TABLE IF EXISTS webhook_events;
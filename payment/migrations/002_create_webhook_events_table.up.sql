@@ -0,0 +1,12 @@
+-- Provider webhook events already applied, so redeliveries of an event are
+-- acknowledged without being applied twice
+CREATE TABLE IF NOT EXISTS webhook_events (
+    provider VARCHAR(20) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, event_id)
+);
+
+-- Webhook events name a payment by its provider payment or capture ID
+CREATE INDEX idx_payments_provider_capture ON payments(provider, provider_capture_id);
@@ -0,0 +1,37 @@
+-- Customer disputes (chargebacks) of captured payments, as reported by the
+-- providers' webhooks. status moves OPEN -> UNDER_REVIEW -> WON or LOST;
+-- adjusted_at is set once the order and stock of a lost dispute are adjusted.
+CREATE TABLE IF NOT EXISTS disputes (
+    id UUID PRIMARY KEY,
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    order_id UUID NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    provider_dispute_id VARCHAR(255) NOT NULL,
+    status VARCHAR(12) NOT NULL CHECK (status IN ('OPEN', 'UNDER_REVIEW', 'WON', 'LOST')),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL,
+    evidence_due_by TIMESTAMP,
+    evidence_submitted_at TIMESTAMP,
+    adjusted_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, provider_dispute_id)
+);
+
+CREATE INDEX idx_disputes_status_due ON disputes(status, evidence_due_by);
+CREATE INDEX idx_disputes_payment ON disputes(payment_id);
+
+-- Evidence submitted to the provider to contest a dispute
+CREATE TABLE IF NOT EXISTS dispute_evidence (
+    id BIGSERIAL PRIMARY KEY,
+    dispute_id UUID NOT NULL REFERENCES disputes(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    description VARCHAR(2000) NOT NULL DEFAULT '',
+    url VARCHAR(2048) NOT NULL DEFAULT '',
+    submitted_by VARCHAR(255) NOT NULL,
+    submitted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_dispute_evidence_dispute ON dispute_evidence(dispute_id, submitted_at);
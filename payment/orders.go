@@ -0,0 +1,81 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Order statuses set by the payment service
+const (
+	orderStatusPaid      = "PAID"
+	orderStatusCancelled = "CANCELLED"
+)
+
+// Order is what the payment service reads of an order
+type Order struct {
+	Status string
+	Items  []OrderItem
+}
+
+// OrderItem is an order line
+type OrderItem struct {
+	ProductID string
+	Quantity  int32
+}
+
+// Orders moves orders along when their payments change outside a checkout,
+// e.g. when a provider reports a capture or a failure by webhook
+type Orders interface {
+	// Get retrieves an order's status and items
+	Get(ctx context.Context, orderID string) (*Order, error)
+	// MarkPaid moves an order to PAID. An order that is already past PENDING
+	// is left as it is.
+	MarkPaid(ctx context.Context, orderID string) error
+	// Cancel moves an order to CANCELLED. An order that can no longer be
+	// cancelled is left as it is.
+	Cancel(ctx context.Context, orderID string) error
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+func (o *grpcOrders) Get(ctx context.Context, orderID string) (*Order, error) {
+	resp, err := o.client.GetOrder(ctx, &orderpb.GetOrderRequest{OrderId: orderID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	order := &Order{Status: resp.Order.Status, Items: make([]OrderItem, len(resp.Order.Items))}
+	for i, item := range resp.Order.Items {
+		order.Items[i] = OrderItem{ProductID: item.ProductId, Quantity: item.Quantity}
+	}
+	return order, nil
+}
+
+func (o *grpcOrders) MarkPaid(ctx context.Context, orderID string) error {
+	return o.setStatus(ctx, orderID, orderStatusPaid)
+}
+
+func (o *grpcOrders) Cancel(ctx context.Context, orderID string) error {
+	return o.setStatus(ctx, orderID, orderStatusCancelled)
+}
+
+// setStatus moves an order to status. The order service refuses
+// transitions the order state machine does not allow with FAILED_PRECONDITION,
+// which means the order has already moved on.
+func (o *grpcOrders) setStatus(ctx context.Context, orderID, to string) error {
+	_, err := o.client.UpdateOrderStatus(ctx, &orderpb.UpdateOrderStatusRequest{OrderId: orderID, Status: to})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil
+	}
+	return err
+}
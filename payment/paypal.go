@@ -0,0 +1,441 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PayPalConfig holds the settings of the PayPal provider
+type PayPalConfig struct {
+	ClientID     string
+	ClientSecret string
+	// WebhookID is the ID PayPal assigned to the webhook, used to verify its
+	// deliveries. Webhooks are rejected when it is empty.
+	WebhookID string
+	// BaseURL defaults to https://api-m.paypal.com
+	BaseURL string
+	Timeout time.Duration
+}
+
+// PayPalProvider takes payments through PayPal Orders with intent AUTHORIZE.
+// The customer approves a PayPal order on the client; that order ID is the
+// payment method passed to Authorize.
+type PayPalProvider struct {
+	cfg        PayPalConfig
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewPayPalProvider creates a new PayPal provider
+func NewPayPalProvider(cfg PayPalConfig) (*PayPalProvider, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, errors.New("paypal: client ID and client secret are required")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api-m.paypal.com"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &PayPalProvider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		now:        time.Now,
+	}, nil
+}
+
+// Name returns "paypal"
+func (p *PayPalProvider) Name() string {
+	return ProviderPayPal
+}
+
+type paypalAmount struct {
+	CurrencyCode string `json:"currency_code"`
+	Value        string `json:"value"`
+}
+
+type paypalAuthorization struct {
+	ID     string       `json:"id"`
+	Status string       `json:"status"`
+	Amount paypalAmount `json:"amount"`
+}
+
+type paypalOrder struct {
+	ID            string `json:"id"`
+	PurchaseUnits []struct {
+		Payments struct {
+			Authorizations []paypalAuthorization `json:"authorizations"`
+		} `json:"payments"`
+	} `json:"purchase_units"`
+}
+
+type paypalErrorResponse struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Details []struct {
+		Issue string `json:"issue"`
+	} `json:"details"`
+}
+
+// declinedIssues are PayPal error issues that mean the payer's funding was refused
+var declinedIssues = map[string]bool{
+	"INSTRUMENT_DECLINED":                     true,
+	"PAYER_ACTION_REQUIRED":                   true,
+	"PAYER_CANNOT_PAY":                        true,
+	"TRANSACTION_REFUSED":                     true,
+	"ORDER_NOT_APPROVED":                      true,
+	"PAYEE_BLOCKED_TRANSACTION":               true,
+	"COMPLIANCE_VIOLATION":                    true,
+	"MAX_NUMBER_OF_PAYMENT_ATTEMPTS_EXCEEDED": true,
+}
+
+// Authorize authorizes the approved PayPal order and returns the
+// authorization ID, after checking it is for the charge's amount
+func (p *PayPalProvider) Authorize(ctx context.Context, charge Charge) (string, error) {
+	var order paypalOrder
+	path := "/v2/checkout/orders/" + url.PathEscape(charge.PaymentMethod) + "/authorize"
+	if err := p.post(ctx, path, struct{}{}, "authorize-"+charge.PaymentID, &order); err != nil {
+		return "", err
+	}
+
+	if len(order.PurchaseUnits) == 0 || len(order.PurchaseUnits[0].Payments.Authorizations) == 0 {
+		return "", fmt.Errorf("paypal: order %s has no authorization", order.ID)
+	}
+	auth := order.PurchaseUnits[0].Payments.Authorizations[0]
+	if auth.Status == "DENIED" {
+		return "", fmt.Errorf("%w: authorization denied", ErrPaymentDeclined)
+	}
+	want := formatMinorUnits(charge.Amount, charge.Currency)
+	if auth.Amount.Value != want || !strings.EqualFold(auth.Amount.CurrencyCode, charge.Currency) {
+		return "", fmt.Errorf("paypal: authorization %s is for %s %s, expected %s %s",
+			auth.ID, auth.Amount.Value, auth.Amount.CurrencyCode, want, charge.Currency)
+	}
+	return auth.ID, nil
+}
+
+// Capture captures amount of the authorization as its final capture and
+// returns the capture ID
+func (p *PayPalProvider) Capture(ctx context.Context, payment *Payment, amount int64) (string, error) {
+	body := map[string]interface{}{
+		"amount":        paypalAmount{CurrencyCode: payment.Currency, Value: formatMinorUnits(amount, payment.Currency)},
+		"final_capture": true,
+	}
+
+	var capture struct {
+		ID string `json:"id"`
+	}
+	path := "/v2/payments/authorizations/" + url.PathEscape(payment.ProviderPaymentID) + "/capture"
+	if err := p.post(ctx, path, body, "capture-"+payment.ID, &capture); err != nil {
+		return "", err
+	}
+	return capture.ID, nil
+}
+
+// Void voids the authorization
+func (p *PayPalProvider) Void(ctx context.Context, payment *Payment) error {
+	path := "/v2/payments/authorizations/" + url.PathEscape(payment.ProviderPaymentID) + "/void"
+	return p.post(ctx, path, nil, "void-"+payment.ID, nil)
+}
+
+// Refund refunds amount of the capture
+func (p *PayPalProvider) Refund(ctx context.Context, payment *Payment, amount int64) error {
+	body := map[string]interface{}{
+		"amount": paypalAmount{CurrencyCode: payment.Currency, Value: formatMinorUnits(amount, payment.Currency)},
+	}
+	path := "/v2/payments/captures/" + url.PathEscape(payment.ProviderCaptureID) + "/refund"
+	return p.post(ctx, path, body, refundKey(payment), nil)
+}
+
+type paypalEvent struct {
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	Resource  json.RawMessage `json:"resource"`
+}
+
+type paypalResource struct {
+	ID            string       `json:"id"`
+	Amount        paypalAmount `json:"amount"`
+	StatusDetails struct {
+		Reason string `json:"reason"`
+	} `json:"status_details"`
+	SupplementaryData struct {
+		RelatedIDs struct {
+			AuthorizationID string `json:"authorization_id"`
+		} `json:"related_ids"`
+	} `json:"supplementary_data"`
+	SellerPayableBreakdown struct {
+		TotalRefundedAmount paypalAmount `json:"total_refunded_amount"`
+	} `json:"seller_payable_breakdown"`
+	Links []struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	} `json:"links"`
+}
+
+// ParseWebhook verifies a delivery with PayPal's verify-webhook-signature
+// API and parses an authorization, capture, refund or dispute event
+func (p *PayPalProvider) ParseWebhook(ctx context.Context, header http.Header, body []byte) (*Event, error) {
+	if p.cfg.WebhookID == "" {
+		return nil, ErrWebhooksDisabled
+	}
+
+	var raw paypalEvent
+	if err := json.Unmarshal(body, &raw); err != nil || raw.ID == "" {
+		return nil, errors.New("paypal: invalid event")
+	}
+	if err := p.verifyWebhook(ctx, header, body); err != nil {
+		return nil, err
+	}
+
+	var resource paypalResource
+	if err := json.Unmarshal(raw.Resource, &resource); err != nil {
+		return nil, fmt.Errorf("paypal: invalid event resource: %w", err)
+	}
+
+	event := &Event{ID: raw.ID, Type: raw.EventType}
+	switch raw.EventType {
+	case "PAYMENT.AUTHORIZATION.CREATED":
+		event.Kind = EventAuthorized
+		event.PaymentRef = resource.ID
+	case "PAYMENT.AUTHORIZATION.VOIDED":
+		event.Kind = EventVoided
+		event.PaymentRef = resource.ID
+	case "PAYMENT.CAPTURE.COMPLETED":
+		amount, err := parseMinorUnits(resource.Amount.Value, resource.Amount.CurrencyCode)
+		if err != nil {
+			return nil, fmt.Errorf("paypal: invalid capture amount %q", resource.Amount.Value)
+		}
+		event.Kind = EventCaptured
+		event.PaymentRef = resource.SupplementaryData.RelatedIDs.AuthorizationID
+		event.CaptureID = resource.ID
+		event.Amount = amount
+	case "PAYMENT.CAPTURE.DENIED":
+		event.Kind = EventFailed
+		event.PaymentRef = resource.SupplementaryData.RelatedIDs.AuthorizationID
+		event.Reason = "capture denied"
+		if resource.StatusDetails.Reason != "" {
+			event.Reason = resource.StatusDetails.Reason
+		}
+	case "PAYMENT.CAPTURE.REFUNDED":
+		// The refund links up to the capture it refunds
+		for _, link := range resource.Links {
+			if link.Rel == "up" {
+				event.PaymentRef = link.Href[strings.LastIndex(link.Href, "/")+1:]
+			}
+		}
+		total := resource.SellerPayableBreakdown.TotalRefundedAmount
+		if total.Value != "" {
+			amount, err := parseMinorUnits(total.Value, total.CurrencyCode)
+			if err != nil {
+				return nil, fmt.Errorf("paypal: invalid refunded amount %q", total.Value)
+			}
+			event.Amount = amount
+		}
+		event.Kind = EventRefunded
+	case "CUSTOMER.DISPUTE.CREATED", "CUSTOMER.DISPUTE.UPDATED", "CUSTOMER.DISPUTE.RESOLVED":
+		if err := parsePayPalDispute(raw.Resource, event); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+// paypalDispute is the resource of a dispute event
+type paypalDispute struct {
+	DisputeID             string       `json:"dispute_id"`
+	Reason                string       `json:"reason"`
+	Status                string       `json:"status"`
+	DisputeAmount         paypalAmount `json:"dispute_amount"`
+	SellerResponseDueDate string       `json:"seller_response_due_date"`
+	DisputeOutcome        struct {
+		OutcomeCode string `json:"outcome_code"`
+	} `json:"dispute_outcome"`
+	DisputedTransactions []struct {
+		SellerTransactionID string `json:"seller_transaction_id"`
+	} `json:"disputed_transactions"`
+}
+
+// parsePayPalDispute sets the dispute of a dispute event. A dispute names the
+// capture it disputes as its seller transaction.
+func parsePayPalDispute(resource json.RawMessage, event *Event) error {
+	var dispute paypalDispute
+	if err := json.Unmarshal(resource, &dispute); err != nil || dispute.DisputeID == "" || len(dispute.DisputedTransactions) == 0 {
+		return errors.New("paypal: invalid dispute")
+	}
+
+	event.Kind = EventDispute
+	event.PaymentRef = dispute.DisputedTransactions[0].SellerTransactionID
+	event.Dispute = &DisputeEvent{
+		ID:       dispute.DisputeID,
+		Status:   paypalDisputeStatus(dispute.Status, dispute.DisputeOutcome.OutcomeCode),
+		Reason:   dispute.Reason,
+		Currency: dispute.DisputeAmount.CurrencyCode,
+	}
+	if dispute.DisputeAmount.Value != "" {
+		amount, err := parseMinorUnits(dispute.DisputeAmount.Value, dispute.DisputeAmount.CurrencyCode)
+		if err != nil {
+			return fmt.Errorf("paypal: invalid dispute amount %q", dispute.DisputeAmount.Value)
+		}
+		event.Dispute.Amount = amount
+	}
+	if dispute.SellerResponseDueDate != "" {
+		due, err := time.Parse(time.RFC3339, dispute.SellerResponseDueDate)
+		if err != nil {
+			return fmt.Errorf("paypal: invalid dispute due date %q", dispute.SellerResponseDueDate)
+		}
+		event.Dispute.EvidenceDueBy = due.UTC()
+	}
+	return nil
+}
+
+// paypalDisputeStatus maps a PayPal dispute status and outcome to a dispute
+// status. A resolved dispute is won unless it was decided for the buyer or
+// the claim was accepted.
+func paypalDisputeStatus(status, outcome string) string {
+	switch {
+	case outcome == "RESOLVED_BUYER_FAVOUR" || outcome == "ACCEPTED":
+		return DisputeLost
+	case outcome != "" || status == "RESOLVED":
+		return DisputeWon
+	case status == "OPEN" || status == "WAITING_FOR_SELLER_RESPONSE":
+		return DisputeOpen
+	default:
+		return DisputeUnderReview
+	}
+}
+
+// verifyWebhook asks PayPal whether a delivery was signed by PayPal for the
+// configured webhook
+func (p *PayPalProvider) verifyWebhook(ctx context.Context, header http.Header, body []byte) error {
+	transmissionID := header.Get("PAYPAL-TRANSMISSION-ID")
+	if transmissionID == "" {
+		return fmt.Errorf("%w: missing PAYPAL-TRANSMISSION-ID header", ErrInvalidSignature)
+	}
+
+	request := map[string]interface{}{
+		"auth_algo":         header.Get("PAYPAL-AUTH-ALGO"),
+		"cert_url":          header.Get("PAYPAL-CERT-URL"),
+		"transmission_id":   transmissionID,
+		"transmission_sig":  header.Get("PAYPAL-TRANSMISSION-SIG"),
+		"transmission_time": header.Get("PAYPAL-TRANSMISSION-TIME"),
+		"webhook_id":        p.cfg.WebhookID,
+		"webhook_event":     json.RawMessage(body),
+	}
+
+	var result struct {
+		VerificationStatus string `json:"verification_status"`
+	}
+	if err := p.post(ctx, "/v1/notifications/verify-webhook-signature", request, "verify-"+transmissionID, &result); err != nil {
+		return err
+	}
+	if result.VerificationStatus != "SUCCESS" {
+		return fmt.Errorf("%w: verification status %s", ErrInvalidSignature, result.VerificationStatus)
+	}
+	return nil
+}
+
+// post sends a JSON request and decodes the response into out when it is not
+// nil. Refused funding is returned as ErrPaymentDeclined.
+func (p *PayPalProvider) post(ctx context.Context, path string, body interface{}, requestID string, out interface{}) error {
+	token, err := p.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var encoded []byte
+	if body != nil {
+		if encoded, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+path, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("PayPal-Request-Id", requestID)
+
+	code, respBody, err := send(p.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("paypal: request failed: %w", err)
+	}
+
+	if code < 200 || code > 299 {
+		var decoded paypalErrorResponse
+		_ = json.Unmarshal(respBody, &decoded)
+		for _, d := range decoded.Details {
+			if declinedIssues[d.Issue] {
+				return fmt.Errorf("%w: %s", ErrPaymentDeclined, d.Issue)
+			}
+		}
+		if code == http.StatusUnauthorized {
+			p.mu.Lock()
+			p.token = ""
+			p.mu.Unlock()
+		}
+		return fmt.Errorf("paypal: unexpected status %d: %s", code, decoded.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("paypal: invalid response: %w", err)
+	}
+	return nil
+}
+
+// accessToken returns a cached OAuth access token, requesting a new one when
+// it is missing or about to expire
+func (p *PayPalProvider) accessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && p.now().Before(p.tokenExpiry) {
+		return p.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+"/v1/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(p.cfg.ClientID, p.cfg.ClientSecret)
+
+	code, body, err := send(p.httpClient, req)
+	if err != nil {
+		return "", fmt.Errorf("paypal: token request failed: %w", err)
+	}
+	if code != http.StatusOK {
+		return "", fmt.Errorf("paypal: token request returned status %d", code)
+	}
+
+	var decoded struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil || decoded.AccessToken == "" {
+		return "", errors.New("paypal: invalid token response")
+	}
+
+	// Renew a minute early so a token never expires mid-request
+	p.token = decoded.AccessToken
+	p.tokenExpiry = p.now().Add(time.Duration(decoded.ExpiresIn)*time.Second - time.Minute)
+	return p.token, nil
+}
@@ -0,0 +1,228 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// paypalServer fakes the PayPal API, issuing token-1 and recording the paths called
+type paypalServer struct {
+	tokens int
+	paths  []string
+	bodies []map[string]interface{}
+	status int
+	reply  string
+}
+
+func (s *paypalServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/v1/oauth2/token" {
+		s.tokens++
+		w.Write([]byte(`{"access_token": "token-1", "expires_in": 32400}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer token-1" || r.Header.Get("PayPal-Request-Id") == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var body map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&body)
+	s.paths = append(s.paths, r.URL.Path)
+	s.bodies = append(s.bodies, body)
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+	}
+	w.Write([]byte(s.reply))
+}
+
+func newTestPayPal(t *testing.T, fake *paypalServer) *PayPalProvider {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	provider, err := NewPayPalProvider(PayPalConfig{ClientID: "client", ClientSecret: "secret", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return provider
+}
+
+func TestPayPalAuthorize_Success(t *testing.T) {
+	fake := &paypalServer{reply: `{"id": "ORDER-1", "purchase_units": [{"payments": {"authorizations": [
+		{"id": "AUTH-1", "status": "CREATED", "amount": {"currency_code": "USD", "value": "12.50"}}]}}]}`}
+	provider := newTestPayPal(t, fake)
+
+	id, err := provider.Authorize(context.Background(), Charge{PaymentID: "pay-1", Amount: 1250, Currency: "USD", PaymentMethod: "ORDER-1"})
+	if err != nil || id != "AUTH-1" {
+		t.Fatalf("Expected AUTH-1, got %q, %v", id, err)
+	}
+	if len(fake.paths) != 1 || fake.paths[0] != "/v2/checkout/orders/ORDER-1/authorize" {
+		t.Errorf("Unexpected calls %v", fake.paths)
+	}
+}
+
+func TestPayPalAuthorize_Failures(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		reply    string
+		declined bool
+	}{
+		{"instrument declined", http.StatusUnprocessableEntity, `{"name": "UNPROCESSABLE_ENTITY", "details": [{"issue": "INSTRUMENT_DECLINED"}]}`, true},
+		{"authorization denied", 0, `{"id": "ORDER-1", "purchase_units": [{"payments": {"authorizations": [
+			{"id": "AUTH-1", "status": "DENIED", "amount": {"currency_code": "USD", "value": "12.50"}}]}}]}`, true},
+		{"amount mismatch", 0, `{"id": "ORDER-1", "purchase_units": [{"payments": {"authorizations": [
+			{"id": "AUTH-1", "status": "CREATED", "amount": {"currency_code": "USD", "value": "1.00"}}]}}]}`, false},
+		{"server error", http.StatusInternalServerError, `{"name": "INTERNAL_SERVER_ERROR"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestPayPal(t, &paypalServer{status: tt.status, reply: tt.reply})
+
+			_, err := provider.Authorize(context.Background(), Charge{PaymentID: "pay-1", Amount: 1250, Currency: "USD", PaymentMethod: "ORDER-1"})
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if errors.Is(err, ErrPaymentDeclined) != tt.declined {
+				t.Errorf("Expected declined=%v, got %v", tt.declined, err)
+			}
+		})
+	}
+}
+
+func TestPayPalCaptureVoidRefund(t *testing.T) {
+	fake := &paypalServer{reply: `{"id": "CAPTURE-1"}`}
+	provider := newTestPayPal(t, fake)
+	payment := &Payment{ID: "pay-1", ProviderPaymentID: "AUTH-1", ProviderCaptureID: "CAPTURE-1", Currency: "USD"}
+
+	captureID, err := provider.Capture(context.Background(), payment, 1250)
+	if err != nil || captureID != "CAPTURE-1" {
+		t.Fatalf("Expected CAPTURE-1, got %q, %v", captureID, err)
+	}
+	if err := provider.Void(context.Background(), payment); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := provider.Refund(context.Background(), payment, 500); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []string{
+		"/v2/payments/authorizations/AUTH-1/capture",
+		"/v2/payments/authorizations/AUTH-1/void",
+		"/v2/payments/captures/CAPTURE-1/refund",
+	}
+	if len(fake.paths) != len(want) {
+		t.Fatalf("Unexpected calls %v", fake.paths)
+	}
+	for i := range want {
+		if fake.paths[i] != want[i] {
+			t.Errorf("Unexpected calls %v", fake.paths)
+		}
+	}
+	if amount := fake.bodies[2]["amount"].(map[string]interface{}); amount["value"] != "5.00" || amount["currency_code"] != "USD" {
+		t.Errorf("Unexpected refund amount %v", amount)
+	}
+	if fake.tokens != 1 {
+		t.Errorf("Expected the access token to be reused, requested %d", fake.tokens)
+	}
+}
+
+func TestPayPalParseWebhook(t *testing.T) {
+	fake := &paypalServer{reply: `{"verification_status": "SUCCESS"}`}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	provider, err := NewPayPalProvider(PayPalConfig{ClientID: "client", ClientSecret: "secret", WebhookID: "WH-1", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	body := []byte(`{"id": "WH-EVT-1", "event_type": "PAYMENT.CAPTURE.COMPLETED", "resource": {
+		"id": "CAP-1", "amount": {"currency_code": "USD", "value": "12.50"},
+		"supplementary_data": {"related_ids": {"authorization_id": "AUTH-1"}}}}`)
+	header := http.Header{
+		"Paypal-Transmission-Id":   {"tx-1"},
+		"Paypal-Transmission-Sig":  {"sig"},
+		"Paypal-Transmission-Time": {"2024-01-01T00:00:00Z"},
+		"Paypal-Auth-Algo":         {"SHA256withRSA"},
+		"Paypal-Cert-Url":          {"https://api.paypal.com/cert"},
+	}
+
+	event, err := provider.ParseWebhook(context.Background(), header, body)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := Event{ID: "WH-EVT-1", Type: "PAYMENT.CAPTURE.COMPLETED", Kind: EventCaptured, PaymentRef: "AUTH-1", CaptureID: "CAP-1", Amount: 1250}
+	if *event != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *event)
+	}
+	if len(fake.paths) != 1 || fake.paths[0] != "/v1/notifications/verify-webhook-signature" {
+		t.Fatalf("Unexpected calls %v", fake.paths)
+	}
+	if fake.bodies[0]["webhook_id"] != "WH-1" || fake.bodies[0]["transmission_id"] != "tx-1" {
+		t.Errorf("Unexpected verification request %v", fake.bodies[0])
+	}
+
+	fake.reply = `{"verification_status": "FAILURE"}`
+	if _, err := provider.ParseWebhook(context.Background(), header, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestPayPalParseWebhook_Refund(t *testing.T) {
+	provider := newTestPayPal(t, &paypalServer{reply: `{"verification_status": "SUCCESS"}`})
+	provider.cfg.WebhookID = "WH-1"
+
+	body := []byte(`{"id": "WH-EVT-2", "event_type": "PAYMENT.CAPTURE.REFUNDED", "resource": {
+		"id": "REF-1", "amount": {"currency_code": "USD", "value": "5.00"},
+		"seller_payable_breakdown": {"total_refunded_amount": {"currency_code": "USD", "value": "7.50"}},
+		"links": [{"rel": "self", "href": "https://api.paypal.com/v2/payments/refunds/REF-1"},
+			{"rel": "up", "href": "https://api.paypal.com/v2/payments/captures/CAP-1"}]}}`)
+
+	event, err := provider.ParseWebhook(context.Background(), http.Header{"Paypal-Transmission-Id": {"tx-2"}}, body)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.Kind != EventRefunded || event.PaymentRef != "CAP-1" || event.Amount != 750 {
+		t.Errorf("Unexpected refund event %+v", event)
+	}
+}
+
+func TestPayPalParseWebhook_Dispute(t *testing.T) {
+	provider := newTestPayPal(t, &paypalServer{reply: `{"verification_status": "SUCCESS"}`})
+	provider.cfg.WebhookID = "WH-1"
+
+	body := []byte(`{"id": "WH-EVT-3", "event_type": "CUSTOMER.DISPUTE.CREATED", "resource": {
+		"dispute_id": "PP-D-1", "reason": "UNAUTHORISED", "status": "WAITING_FOR_SELLER_RESPONSE",
+		"dispute_amount": {"currency_code": "USD", "value": "12.50"},
+		"seller_response_due_date": "2024-03-01T00:00:00Z",
+		"disputed_transactions": [{"seller_transaction_id": "CAP-1"}]}}`)
+
+	event, err := provider.ParseWebhook(context.Background(), http.Header{"Paypal-Transmission-Id": {"tx-3"}}, body)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := DisputeEvent{ID: "PP-D-1", Status: DisputeOpen, Reason: "UNAUTHORISED", Amount: 1250, Currency: "USD", EvidenceDueBy: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+	if event.Kind != EventDispute || event.PaymentRef != "CAP-1" || event.Dispute == nil || *event.Dispute != expected {
+		t.Errorf("Unexpected dispute event %+v", event)
+	}
+
+	tests := []struct {
+		status, outcome, expected string
+	}{
+		{"UNDER_REVIEW", "", DisputeUnderReview},
+		{"RESOLVED", "RESOLVED_BUYER_FAVOUR", DisputeLost},
+		{"RESOLVED", "ACCEPTED", DisputeLost},
+		{"RESOLVED", "RESOLVED_SELLER_FAVOUR", DisputeWon},
+	}
+	for _, tt := range tests {
+		if got := paypalDisputeStatus(tt.status, tt.outcome); got != tt.expected {
+			t.Errorf("paypalDisputeStatus(%q, %q) = %s, want %s", tt.status, tt.outcome, got, tt.expected)
+		}
+	}
+}
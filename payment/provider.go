@@ -0,0 +1,211 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Payment provider names
+const (
+	ProviderStripe   = "stripe"
+	ProviderRazorpay = "razorpay"
+	ProviderPayPal   = "paypal"
+	ProviderSandbox  = "sandbox"
+)
+
+var (
+	// ErrPaymentDeclined is returned when a provider declines a payment
+	ErrPaymentDeclined = errors.New("payment declined")
+	// ErrUnknownProvider is returned when a requested provider is not configured
+	ErrUnknownProvider = errors.New("payment provider not configured")
+)
+
+const (
+	// maxResponseSize caps the provider response body that is read, in bytes
+	maxResponseSize = 64 << 10
+	// defaultTimeout bounds provider API calls when no timeout is configured
+	defaultTimeout = 30 * time.Second
+)
+
+// Charge is an authorization request sent to a provider
+type Charge struct {
+	// PaymentID identifies the payment; providers that support it use it as the idempotency key
+	PaymentID string
+	OrderID   string
+	// Amount is in the currency's minor units, e.g. cents
+	Amount   int64
+	Currency string
+	// PaymentMethod is the provider's client-side token: a Stripe PaymentMethod,
+	// a Razorpay payment ID or an approved PayPal order ID
+	PaymentMethod string
+}
+
+// Provider is a payment service provider. Payments are authorized first and
+// captured later, e.g. when the order ships.
+type Provider interface {
+	Name() string
+	// Authorize places a hold for the charge and returns the provider's payment
+	// ID. It returns an error wrapping ErrPaymentDeclined when the payment
+	// method is refused.
+	Authorize(ctx context.Context, charge Charge) (string, error)
+	// Capture collects amount minor units of an authorized payment and returns
+	// the provider's capture ID
+	Capture(ctx context.Context, p *Payment, amount int64) (string, error)
+	// Void releases the hold of an authorized payment that will not be captured
+	Void(ctx context.Context, p *Payment) error
+	// Refund returns amount minor units of a captured payment
+	Refund(ctx context.Context, p *Payment, amount int64) error
+}
+
+// Router selects the provider for a payment. A provider named in the request
+// wins; otherwise the provider routed for the customer's region is used, and
+// otherwise the default.
+type Router struct {
+	providers map[string]Provider
+	regions   map[string]string
+	fallback  string
+}
+
+// NewRouter creates a router over providers. regions maps ISO 3166 country
+// codes to provider names and fallback names the default provider.
+func NewRouter(providers []Provider, regions map[string]string, fallback string) (*Router, error) {
+	r := &Router{
+		providers: make(map[string]Provider, len(providers)),
+		regions:   make(map[string]string, len(regions)),
+		fallback:  fallback,
+	}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	if _, ok := r.providers[fallback]; !ok {
+		return nil, fmt.Errorf("default payment provider %q is not configured", fallback)
+	}
+	for region, name := range regions {
+		if _, ok := r.providers[name]; !ok {
+			return nil, fmt.Errorf("payment provider %q for region %s is not configured", name, region)
+		}
+		r.regions[strings.ToUpper(region)] = name
+	}
+	return r, nil
+}
+
+// Select returns the provider for a payment
+func (r *Router) Select(requested, region string) (Provider, error) {
+	if requested != "" {
+		p, ok := r.providers[requested]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, requested)
+		}
+		return p, nil
+	}
+	if name, ok := r.regions[strings.ToUpper(region)]; ok {
+		return r.providers[name], nil
+	}
+	return r.providers[r.fallback], nil
+}
+
+// Get returns a provider by name
+func (r *Router) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// Names returns the configured provider names, sorted
+func (r *Router) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseRegionRoutes parses comma-separated region=provider pairs such as
+// "IN=razorpay,US=stripe"
+func ParseRegionRoutes(s string) (map[string]string, error) {
+	routes := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		region, name, ok := strings.Cut(pair, "=")
+		region, name = strings.TrimSpace(region), strings.TrimSpace(name)
+		if !ok || len(region) != 2 || name == "" {
+			return nil, fmt.Errorf("invalid payment region route %q", pair)
+		}
+		routes[strings.ToUpper(region)] = strings.ToLower(name)
+	}
+	return routes, nil
+}
+
+// zeroDecimalCurrencies have no minor unit
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true, "KRW": true, "MGA": true,
+	"PYG": true, "RWF": true, "UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// toMinorUnits converts an amount to the currency's minor units
+func toMinorUnits(amount float64, currency string) int64 {
+	if zeroDecimalCurrencies[currency] {
+		return int64(math.Round(amount))
+	}
+	return int64(math.Round(amount * 100))
+}
+
+// fromMinorUnits converts minor units of a currency to an amount
+func fromMinorUnits(amount int64, currency string) float64 {
+	if zeroDecimalCurrencies[currency] {
+		return float64(amount)
+	}
+	return float64(amount) / 100
+}
+
+// formatMinorUnits renders minor units as a decimal string such as "12.50"
+func formatMinorUnits(amount int64, currency string) string {
+	if zeroDecimalCurrencies[currency] {
+		return strconv.FormatInt(amount, 10)
+	}
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+// parseMinorUnits converts a decimal string such as "12.50" to minor units
+func parseMinorUnits(value, currency string) (int64, error) {
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return toMinorUnits(amount, strings.ToUpper(currency)), nil
+}
+
+// send performs a provider API request and returns the status code and body
+func send(client *http.Client, req *http.Request) (int, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// refundKey is the idempotency key of the next refund of a payment. Retries
+// of the same refund share a key; later refunds get a new one.
+func refundKey(p *Payment) string {
+	return fmt.Sprintf("refund-%s-%d", p.ID, toMinorUnits(p.RefundedAmount, p.Currency))
+}
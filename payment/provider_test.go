@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestRouter(t *testing.T) *Router {
+	router, err := NewRouter(
+		[]Provider{NewSandboxProvider(ProviderStripe), NewSandboxProvider(ProviderRazorpay), NewSandboxProvider(ProviderPayPal)},
+		map[string]string{"in": ProviderRazorpay},
+		ProviderStripe,
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return router
+}
+
+func TestRouterSelect(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name      string
+		requested string
+		region    string
+		expected  string
+	}{
+		{"requested provider wins", ProviderPayPal, "IN", ProviderPayPal},
+		{"region route", "", "IN", ProviderRazorpay},
+		{"region is case insensitive", "", "in", ProviderRazorpay},
+		{"unrouted region uses default", "", "US", ProviderStripe},
+		{"no region uses default", "", "", ProviderStripe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := router.Select(tt.requested, tt.region)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if provider.Name() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, provider.Name())
+			}
+		})
+	}
+
+	if _, err := router.Select("adyen", ""); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("Expected ErrUnknownProvider, got %v", err)
+	}
+	if names := router.Names(); !reflect.DeepEqual(names, []string{"paypal", "razorpay", "stripe"}) {
+		t.Errorf("Unexpected names %v", names)
+	}
+}
+
+func TestNewRouter_RejectsUnconfiguredProviders(t *testing.T) {
+	providers := []Provider{NewSandboxProvider(ProviderStripe)}
+
+	if _, err := NewRouter(providers, nil, ProviderPayPal); err == nil {
+		t.Error("Expected error for unconfigured default provider")
+	}
+	if _, err := NewRouter(providers, map[string]string{"IN": ProviderRazorpay}, ProviderStripe); err == nil {
+		t.Error("Expected error for unconfigured region provider")
+	}
+}
+
+func TestParseRegionRoutes(t *testing.T) {
+	routes, err := ParseRegionRoutes(" in=Razorpay, US=stripe ,,")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(routes, map[string]string{"IN": "razorpay", "US": "stripe"}) {
+		t.Errorf("Unexpected routes %v", routes)
+	}
+
+	for _, invalid := range []string{"IN", "IND=razorpay", "IN="} {
+		if _, err := ParseRegionRoutes(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount    float64
+		currency  string
+		minor     int64
+		formatted string
+	}{
+		{12.5, "USD", 1250, "12.50"},
+		{0.1 + 0.2, "EUR", 30, "0.30"},
+		{499, "INR", 49900, "499.00"},
+		{1500, "JPY", 1500, "1500"},
+	}
+
+	for _, tt := range tests {
+		minor := toMinorUnits(tt.amount, tt.currency)
+		if minor != tt.minor {
+			t.Errorf("toMinorUnits(%v, %s) = %d, want %d", tt.amount, tt.currency, minor, tt.minor)
+		}
+		if got := formatMinorUnits(minor, tt.currency); got != tt.formatted {
+			t.Errorf("formatMinorUnits(%d, %s) = %s, want %s", minor, tt.currency, got, tt.formatted)
+		}
+		if back := toMinorUnits(fromMinorUnits(minor, tt.currency), tt.currency); back != minor {
+			t.Errorf("Round trip of %d %s gave %d", minor, tt.currency, back)
+		}
+	}
+}
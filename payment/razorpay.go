@@ -0,0 +1,258 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RazorpayConfig holds the settings of the Razorpay provider
+type RazorpayConfig struct {
+	KeyID     string
+	KeySecret string
+	// WebhookSecret is the secret set on the webhook in the Razorpay
+	// dashboard. Webhooks are rejected when it is empty.
+	WebhookSecret string
+	// BaseURL defaults to https://api.razorpay.com
+	BaseURL string
+	Timeout time.Duration
+}
+
+// RazorpayProvider takes payments through Razorpay. The customer pays in
+// Razorpay Checkout, which authorizes the payment and hands its payment ID to
+// the client; that ID is the payment method passed to Authorize.
+type RazorpayProvider struct {
+	cfg        RazorpayConfig
+	httpClient *http.Client
+}
+
+// NewRazorpayProvider creates a new Razorpay provider
+func NewRazorpayProvider(cfg RazorpayConfig) (*RazorpayProvider, error) {
+	if cfg.KeyID == "" || cfg.KeySecret == "" {
+		return nil, errors.New("razorpay: key ID and key secret are required")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.razorpay.com"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &RazorpayProvider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Name returns "razorpay"
+func (p *RazorpayProvider) Name() string {
+	return ProviderRazorpay
+}
+
+type razorpayPayment struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type razorpayErrorResponse struct {
+	Error struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+// Authorize verifies that the Razorpay payment is authorized for the charge's
+// amount and currency
+func (p *RazorpayProvider) Authorize(ctx context.Context, charge Charge) (string, error) {
+	var payment razorpayPayment
+	if err := p.do(ctx, http.MethodGet, "/v1/payments/"+url.PathEscape(charge.PaymentMethod), nil, &payment); err != nil {
+		return "", err
+	}
+
+	switch payment.Status {
+	case "authorized":
+	case "failed":
+		return "", fmt.Errorf("%w: %s", ErrPaymentDeclined, payment.ErrorDescription)
+	default:
+		return "", fmt.Errorf("razorpay: payment %s is %s, not authorized", payment.ID, payment.Status)
+	}
+	if payment.Amount != charge.Amount || !strings.EqualFold(payment.Currency, charge.Currency) {
+		return "", fmt.Errorf("razorpay: payment %s is for %d %s, expected %d %s",
+			payment.ID, payment.Amount, payment.Currency, charge.Amount, charge.Currency)
+	}
+	return payment.ID, nil
+}
+
+// Capture captures the payment. Razorpay has no separate capture object, so
+// the payment ID is returned as the capture ID.
+func (p *RazorpayProvider) Capture(ctx context.Context, payment *Payment, amount int64) (string, error) {
+	body := map[string]interface{}{"amount": amount, "currency": payment.Currency}
+	path := "/v1/payments/" + url.PathEscape(payment.ProviderPaymentID) + "/capture"
+	if err := p.do(ctx, http.MethodPost, path, body, nil); err != nil {
+		return "", err
+	}
+	return payment.ProviderPaymentID, nil
+}
+
+// Void does nothing: Razorpay has no void call and refunds payments that are
+// not captured within the account's auto-refund window
+func (p *RazorpayProvider) Void(ctx context.Context, payment *Payment) error {
+	return nil
+}
+
+// Refund refunds amount of the captured payment
+func (p *RazorpayProvider) Refund(ctx context.Context, payment *Payment, amount int64) error {
+	body := map[string]interface{}{"amount": amount, "receipt": refundKey(payment)}
+	path := "/v1/payments/" + url.PathEscape(payment.ProviderPaymentID) + "/refund"
+	return p.do(ctx, http.MethodPost, path, body, nil)
+}
+
+type razorpayEvent struct {
+	Event   string `json:"event"`
+	Payload struct {
+		Payment struct {
+			Entity struct {
+				razorpayPayment
+				AmountRefunded int64 `json:"amount_refunded"`
+			} `json:"entity"`
+		} `json:"payment"`
+		Refund struct {
+			Entity struct {
+				PaymentID string `json:"payment_id"`
+			} `json:"entity"`
+		} `json:"refund"`
+		Dispute struct {
+			Entity struct {
+				ID         string `json:"id"`
+				PaymentID  string `json:"payment_id"`
+				Amount     int64  `json:"amount"`
+				Currency   string `json:"currency"`
+				ReasonCode string `json:"reason_code"`
+				RespondBy  int64  `json:"respond_by"`
+				Status     string `json:"status"`
+			} `json:"entity"`
+		} `json:"dispute"`
+	} `json:"payload"`
+}
+
+// ParseWebhook verifies the X-Razorpay-Signature header and parses a payment,
+// refund or dispute event. Razorpay sends the event ID in the X-Razorpay-Event-Id
+// header.
+func (p *RazorpayProvider) ParseWebhook(ctx context.Context, header http.Header, body []byte) (*Event, error) {
+	if p.cfg.WebhookSecret == "" {
+		return nil, ErrWebhooksDisabled
+	}
+	if !verifyHMAC(body, p.cfg.WebhookSecret, header.Get("X-Razorpay-Signature")) {
+		return nil, ErrInvalidSignature
+	}
+
+	eventID := header.Get("X-Razorpay-Event-Id")
+	var raw razorpayEvent
+	if err := json.Unmarshal(body, &raw); err != nil || eventID == "" {
+		return nil, errors.New("razorpay: invalid event")
+	}
+
+	payment := raw.Payload.Payment.Entity
+	event := &Event{ID: eventID, Type: raw.Event, PaymentRef: payment.ID}
+	switch raw.Event {
+	case "payment.authorized":
+		event.Kind = EventAuthorized
+	case "payment.captured":
+		event.Kind = EventCaptured
+		event.CaptureID = payment.ID
+		event.Amount = payment.Amount
+	case "payment.failed":
+		event.Kind = EventFailed
+		event.Reason = payment.ErrorDescription
+	case "refund.processed":
+		event.Kind = EventRefunded
+		event.PaymentRef = raw.Payload.Refund.Entity.PaymentID
+		event.Amount = payment.AmountRefunded
+	case "payment.dispute.created", "payment.dispute.under_review", "payment.dispute.action_required",
+		"payment.dispute.won", "payment.dispute.lost", "payment.dispute.closed":
+		dispute := raw.Payload.Dispute.Entity
+		if dispute.ID == "" {
+			return nil, errors.New("razorpay: invalid dispute")
+		}
+		event.Kind = EventDispute
+		if event.PaymentRef == "" {
+			event.PaymentRef = dispute.PaymentID
+		}
+		event.Dispute = &DisputeEvent{
+			ID:       dispute.ID,
+			Status:   razorpayDisputeStatus(dispute.Status),
+			Reason:   dispute.ReasonCode,
+			Amount:   dispute.Amount,
+			Currency: dispute.Currency,
+		}
+		if dispute.RespondBy > 0 {
+			event.Dispute.EvidenceDueBy = time.Unix(dispute.RespondBy, 0).UTC()
+		}
+	}
+	return event, nil
+}
+
+// razorpayDisputeStatus maps a Razorpay dispute status to a dispute status.
+// A closed dispute was accepted by the merchant, so the customer kept the
+// funds.
+func razorpayDisputeStatus(status string) string {
+	switch status {
+	case "won":
+		return DisputeWon
+	case "lost", "closed":
+		return DisputeLost
+	case "under_review":
+		return DisputeUnderReview
+	default:
+		return DisputeOpen
+	}
+}
+
+// do sends a request with a JSON body when body is not nil and decodes the
+// response into out when it is not nil
+func (p *RazorpayProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var encoded []byte
+	if body != nil {
+		var err error
+		if encoded, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.cfg.BaseURL+path, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.SetBasicAuth(p.cfg.KeyID, p.cfg.KeySecret)
+
+	code, respBody, err := send(p.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("razorpay: request failed: %w", err)
+	}
+
+	if code != http.StatusOK {
+		var decoded razorpayErrorResponse
+		_ = json.Unmarshal(respBody, &decoded)
+		return fmt.Errorf("razorpay: unexpected status %d: %s", code, decoded.Error.Description)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("razorpay: invalid response: %w", err)
+	}
+	return nil
+}
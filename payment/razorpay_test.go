@@ -0,0 +1,168 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestRazorpay(t *testing.T, handler http.HandlerFunc) *RazorpayProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := NewRazorpayProvider(RazorpayConfig{KeyID: "rzp_key", KeySecret: "rzp_secret", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return provider
+}
+
+func TestRazorpayAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantErr  bool
+		declined bool
+	}{
+		{"authorized", `{"id": "pay_1", "status": "authorized", "amount": 49900, "currency": "INR"}`, false, false},
+		{"failed", `{"id": "pay_1", "status": "failed", "error_description": "bank declined"}`, true, true},
+		{"amount mismatch", `{"id": "pay_1", "status": "authorized", "amount": 100, "currency": "INR"}`, true, false},
+		{"already captured", `{"id": "pay_1", "status": "captured", "amount": 49900, "currency": "INR"}`, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestRazorpay(t, func(w http.ResponseWriter, r *http.Request) {
+				if user, pass, ok := r.BasicAuth(); !ok || user != "rzp_key" || pass != "rzp_secret" {
+					t.Errorf("Expected basic auth, got %q %q", user, pass)
+				}
+				if r.Method != http.MethodGet || r.URL.Path != "/v1/payments/pay_1" {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Write([]byte(tt.body))
+			})
+
+			id, err := provider.Authorize(context.Background(), Charge{PaymentID: "p-1", Amount: 49900, Currency: "INR", PaymentMethod: "pay_1"})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error")
+				}
+				if errors.Is(err, ErrPaymentDeclined) != tt.declined {
+					t.Errorf("Expected declined=%v, got %v", tt.declined, err)
+				}
+				return
+			}
+			if err != nil || id != "pay_1" {
+				t.Errorf("Expected pay_1, got %q, %v", id, err)
+			}
+		})
+	}
+}
+
+func TestRazorpayCaptureAndRefund(t *testing.T) {
+	var paths []string
+	var bodies []map[string]interface{}
+	provider := newTestRazorpay(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"id": "pay_1"}`))
+	})
+	payment := &Payment{ID: "p-1", ProviderPaymentID: "pay_1", Currency: "INR"}
+
+	captureID, err := provider.Capture(context.Background(), payment, 49900)
+	if err != nil || captureID != "pay_1" {
+		t.Fatalf("Expected pay_1, got %q, %v", captureID, err)
+	}
+	if err := provider.Void(context.Background(), payment); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := provider.Refund(context.Background(), payment, 100); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(paths) != 2 || paths[0] != "/v1/payments/pay_1/capture" || paths[1] != "/v1/payments/pay_1/refund" {
+		t.Fatalf("Unexpected calls %v", paths)
+	}
+	if bodies[0]["amount"] != 49900.0 || bodies[0]["currency"] != "INR" || bodies[1]["amount"] != 100.0 {
+		t.Errorf("Unexpected bodies %v", bodies)
+	}
+}
+
+func TestRazorpayError(t *testing.T) {
+	provider := newTestRazorpay(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"code": "BAD_REQUEST_ERROR", "description": "capture amount exceeds"}}`))
+	})
+
+	_, err := provider.Capture(context.Background(), &Payment{ProviderPaymentID: "pay_1", Currency: "INR"}, 1)
+	if err == nil || errors.Is(err, ErrPaymentDeclined) {
+		t.Errorf("Expected provider error, got %v", err)
+	}
+}
+
+func TestRazorpayParseWebhook(t *testing.T) {
+	provider, err := NewRazorpayProvider(RazorpayConfig{KeyID: "rzp_test", KeySecret: "secret", WebhookSecret: "hook-secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	body := []byte(`{"event": "refund.processed", "payload": {
+		"refund": {"entity": {"id": "rfnd_1", "payment_id": "pay_1", "amount": 500}},
+		"payment": {"entity": {"id": "pay_1", "amount": 1250, "amount_refunded": 500}}}}`)
+	mac := hmac.New(sha256.New, []byte("hook-secret"))
+	mac.Write(body)
+	header := http.Header{
+		"X-Razorpay-Signature": {hex.EncodeToString(mac.Sum(nil))},
+		"X-Razorpay-Event-Id":  {"evt_1"},
+	}
+
+	event, err := provider.ParseWebhook(context.Background(), header, body)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := Event{ID: "evt_1", Type: "refund.processed", Kind: EventRefunded, PaymentRef: "pay_1", Amount: 500}
+	if *event != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *event)
+	}
+
+	header.Set("X-Razorpay-Signature", "00")
+	if _, err := provider.ParseWebhook(context.Background(), header, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestRazorpayParseWebhook_Dispute(t *testing.T) {
+	provider, err := NewRazorpayProvider(RazorpayConfig{KeyID: "rzp_test", KeySecret: "secret", WebhookSecret: "hook-secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	body := []byte(`{"event": "payment.dispute.lost", "payload": {
+		"payment": {"entity": {"id": "pay_1", "amount": 1250}},
+		"dispute": {"entity": {"id": "disp_1", "payment_id": "pay_1", "amount": 1250, "currency": "INR",
+			"reason_code": "chargeback", "respond_by": 1700600000, "status": "lost"}}}}`)
+	mac := hmac.New(sha256.New, []byte("hook-secret"))
+	mac.Write(body)
+	header := http.Header{
+		"X-Razorpay-Signature": {hex.EncodeToString(mac.Sum(nil))},
+		"X-Razorpay-Event-Id":  {"evt_2"},
+	}
+
+	event, err := provider.ParseWebhook(context.Background(), header, body)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := DisputeEvent{ID: "disp_1", Status: DisputeLost, Reason: "chargeback", Amount: 1250, Currency: "INR", EvidenceDueBy: time.Unix(1700600000, 0).UTC()}
+	if event.Kind != EventDispute || event.PaymentRef != "pay_1" || event.Dispute == nil || *event.Dispute != expected {
+		t.Errorf("Unexpected dispute event %+v", event)
+	}
+}
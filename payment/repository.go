@@ -0,0 +1,295 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Payment statuses
+const (
+	StatusPending    = "PENDING"
+	StatusAuthorized = "AUTHORIZED"
+	StatusCaptured   = "CAPTURED"
+	StatusVoided     = "VOIDED"
+	StatusRefunded   = "REFUNDED"
+	StatusDeclined   = "DECLINED"
+	StatusFailed     = "FAILED"
+)
+
+var (
+	// ErrPaymentNotFound is returned when a payment does not exist
+	ErrPaymentNotFound = errors.New("payment not found")
+	// ErrStatusConflict is returned when a payment changed since it was read
+	ErrStatusConflict = errors.New("payment status changed")
+)
+
+// Payment is an order payment held or collected through a provider. Amounts
+// are in the currency's major units.
+type Payment struct {
+	ID                string
+	OrderID           string
+	UserID            string
+	Provider          string
+	ProviderPaymentID string
+	ProviderCaptureID string
+	Status            string
+	Amount            float64
+	Currency          string
+	CapturedAmount    float64
+	RefundedAmount    float64
+	FailureReason     string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Repository handles payment data persistence
+type Repository interface {
+	Create(ctx context.Context, payment *Payment) (*Payment, error)
+	GetByID(ctx context.Context, id string) (*Payment, error)
+	// GetByProviderRef retrieves the payment a provider knows by ref, which is
+	// either its provider payment ID or its provider capture ID
+	GetByProviderRef(ctx context.Context, provider, ref string) (*Payment, error)
+	// ListByOrder retrieves the payments of an order, newest first
+	ListByOrder(ctx context.Context, orderID string) ([]*Payment, error)
+	// Update writes the payment's status, provider IDs, captured and refunded
+	// amounts and failure reason. It returns ErrStatusConflict when the stored payment is
+	// no longer in status from.
+	Update(ctx context.Context, payment *Payment, from string) (*Payment, error)
+	// AddRefund adds amount to a captured payment's refunded amount, marking
+	// it REFUNDED once fully refunded. It returns ErrStatusConflict when the
+	// payment is not captured or the refund exceeds what remains.
+	AddRefund(ctx context.Context, id string, amount float64, at time.Time) (*Payment, error)
+	// RecordEvent records a provider webhook event. It returns false when the
+	// event was already recorded.
+	RecordEvent(ctx context.Context, provider, eventID, eventType string, at time.Time) (bool, error)
+	// DeleteEvent forgets a recorded webhook event, so a redelivery is applied
+	DeleteEvent(ctx context.Context, provider, eventID string) error
+	// UpsertDispute records a dispute reported by a provider, creating it the
+	// first time it is reported. A dispute is not moved back to an earlier
+	// status, and a decided dispute is not changed. It returns the stored
+	// dispute.
+	UpsertDispute(ctx context.Context, dispute *Dispute) (*Dispute, error)
+	// GetDispute retrieves a dispute with its evidence
+	GetDispute(ctx context.Context, id string) (*Dispute, error)
+	// ListDisputes retrieves a page of disputes, optionally in one status,
+	// those whose evidence is due soonest first, and the total
+	ListDisputes(ctx context.Context, status string, page, pageSize int) ([]*Dispute, int, error)
+	// AddDisputeEvidence records evidence submitted for a dispute. It returns
+	// ErrDisputeClosed when the dispute is decided.
+	AddDisputeEvidence(ctx context.Context, disputeID string, evidence *Evidence) (*Dispute, error)
+	// MarkDisputeAdjusted records that the order and stock of a lost dispute
+	// were adjusted. It returns false when they already were.
+	MarkDisputeAdjusted(ctx context.Context, id string, at time.Time) (bool, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL payment repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const paymentColumns = `id, order_id, user_id, provider, COALESCE(provider_payment_id, ''), COALESCE(provider_capture_id, ''),
+	status, amount, currency, captured_amount, refunded_amount, failure_reason, created_at, updated_at`
+
+// Create inserts a payment
+func (r *postgresRepository) Create(ctx context.Context, payment *Payment) (*Payment, error) {
+	payment.ID = uuid.New().String()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO payments (id, order_id, user_id, provider, status, amount, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, payment.ID, payment.OrderID, payment.UserID, payment.Provider, payment.Status, payment.Amount, payment.Currency, payment.CreatedAt, payment.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create payment", map[string]interface{}{"error": err.Error(), "order_id": payment.OrderID})
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	r.log.Info(ctx, "Payment created", map[string]interface{}{"payment_id": payment.ID, "order_id": payment.OrderID, "provider": payment.Provider})
+	return payment, nil
+}
+
+// GetByID retrieves a payment
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Payment, error) {
+	query := "SELECT " + paymentColumns + " FROM payments WHERE id = $1"
+
+	payment, err := scanPayment(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrPaymentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get payment", map[string]interface{}{"error": err.Error(), "payment_id": id})
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return payment, nil
+}
+
+// GetByProviderRef retrieves a payment by its provider payment or capture ID
+func (r *postgresRepository) GetByProviderRef(ctx context.Context, provider, ref string) (*Payment, error) {
+	query := "SELECT " + paymentColumns + `
+		FROM payments
+		WHERE provider = $1 AND (provider_payment_id = $2 OR provider_capture_id = $2)
+		LIMIT 1`
+
+	payment, err := scanPayment(r.db.QueryRowContext(ctx, query, provider, ref))
+	if err == sql.ErrNoRows {
+		return nil, ErrPaymentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get payment by provider reference", map[string]interface{}{"error": err.Error(), "provider": provider, "ref": ref})
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return payment, nil
+}
+
+// ListByOrder retrieves the payments of an order. An order ID that is not a
+// UUID has no payments.
+func (r *postgresRepository) ListByOrder(ctx context.Context, orderID string) ([]*Payment, error) {
+	query := "SELECT " + paymentColumns + " FROM payments WHERE order_id = $1 ORDER BY created_at DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, orderID)
+	if pgerr.IsMalformedID(err) {
+		return []*Payment{}, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to list payments", map[string]interface{}{"error": err.Error(), "order_id": orderID})
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []*Payment{}
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan payment", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating payments", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating payments: %w", err)
+	}
+	return payments, nil
+}
+
+// Update writes a payment's new state. The update only applies while the
+// payment is still in status from, so concurrent transitions cannot both
+// succeed.
+func (r *postgresRepository) Update(ctx context.Context, payment *Payment, from string) (*Payment, error) {
+	query := `
+		UPDATE payments
+		SET status = $3, provider_payment_id = NULLIF($4, ''), provider_capture_id = NULLIF($5, ''),
+			captured_amount = $6, refunded_amount = $7, failure_reason = $8, updated_at = $9
+		WHERE id = $1 AND status = $2
+		RETURNING ` + paymentColumns
+
+	updated, err := scanPayment(r.db.QueryRowContext(ctx, query, payment.ID, from, payment.Status,
+		payment.ProviderPaymentID, payment.ProviderCaptureID, payment.CapturedAmount, payment.RefundedAmount, payment.FailureReason, payment.UpdatedAt))
+	if err == sql.ErrNoRows {
+		return nil, r.missOrConflict(ctx, payment.ID)
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update payment", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID})
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	r.log.Info(ctx, "Payment updated", map[string]interface{}{"payment_id": payment.ID, "from": from, "to": payment.Status})
+	return updated, nil
+}
+
+// AddRefund records a refund against a captured payment
+func (r *postgresRepository) AddRefund(ctx context.Context, id string, amount float64, at time.Time) (*Payment, error) {
+	query := `
+		UPDATE payments
+		SET refunded_amount = refunded_amount + $2,
+			status = CASE WHEN refunded_amount + $2 >= captured_amount THEN 'REFUNDED' ELSE status END,
+			updated_at = $3
+		WHERE id = $1 AND status = 'CAPTURED' AND refunded_amount + $2 <= captured_amount
+		RETURNING ` + paymentColumns
+
+	updated, err := scanPayment(r.db.QueryRowContext(ctx, query, id, amount, at))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, r.missOrConflict(ctx, id)
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to record refund", map[string]interface{}{"error": err.Error(), "payment_id": id})
+		return nil, fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	r.log.Info(ctx, "Payment refunded", map[string]interface{}{"payment_id": id, "amount": amount, "status": updated.Status})
+	return updated, nil
+}
+
+// RecordEvent records a webhook event unless it was already recorded
+func (r *postgresRepository) RecordEvent(ctx context.Context, provider, eventID, eventType string, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_events (provider, event_id, event_type, received_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, event_id) DO NOTHING
+	`, provider, eventID, eventType, at)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record webhook event", map[string]interface{}{"error": err.Error(), "provider": provider, "event_id": eventID})
+		return false, fmt.Errorf("failed to record webhook event: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record webhook event: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// DeleteEvent removes a recorded webhook event
+func (r *postgresRepository) DeleteEvent(ctx context.Context, provider, eventID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM webhook_events WHERE provider = $1 AND event_id = $2", provider, eventID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to delete webhook event", map[string]interface{}{"error": err.Error(), "provider": provider, "event_id": eventID})
+		return fmt.Errorf("failed to delete webhook event: %w", err)
+	}
+	return nil
+}
+
+// missOrConflict explains a conditional update that matched no row
+func (r *postgresRepository) missOrConflict(ctx context.Context, id string) error {
+	var current string
+	err := r.db.QueryRowContext(ctx, "SELECT status FROM payments WHERE id = $1", id).Scan(&current)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return ErrPaymentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get payment status: %w", err)
+	}
+	return ErrStatusConflict
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPayment(row rowScanner) (*Payment, error) {
+	p := &Payment{}
+	err := row.Scan(&p.ID, &p.OrderID, &p.UserID, &p.Provider, &p.ProviderPaymentID, &p.ProviderCaptureID,
+		&p.Status, &p.Amount, &p.Currency, &p.CapturedAmount, &p.RefundedAmount, &p.FailureReason, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
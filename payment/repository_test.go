@@ -0,0 +1,228 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("payment-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var paymentColumnNames = []string{
+	"id", "order_id", "user_id", "provider", "provider_payment_id", "provider_capture_id", "status",
+	"amount", "currency", "captured_amount", "refunded_amount", "failure_reason", "created_at", "updated_at",
+}
+
+func paymentRow(status string, captured, refunded float64) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows(paymentColumnNames).
+		AddRow("pay-1", "order-1", "user-1", ProviderStripe, "pi_1", "", status, 12.5, "USD", captured, refunded, "", now, now)
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	payment := &Payment{OrderID: "order-1", UserID: "user-1", Provider: ProviderStripe, Status: StatusPending, Amount: 12.5, Currency: "USD", CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectExec(`INSERT INTO payments`).
+		WithArgs(sqlmock.AnyArg(), "order-1", "user-1", ProviderStripe, StatusPending, 12.5, "USD", now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	created, err := repo.Create(context.Background(), payment)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM payments WHERE id = \$1`).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM payments WHERE id = \$1`).WithArgs("not-a-uuid").WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(context.Background(), id); !errors.Is(err, ErrPaymentNotFound) {
+			t.Errorf("Expected ErrPaymentNotFound for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestListByOrder(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM payments WHERE order_id = \$1 ORDER BY created_at DESC`).
+		WithArgs("order-1").
+		WillReturnRows(paymentRow(StatusCaptured, 12.5, 0))
+
+	payments, err := repo.ListByOrder(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(payments) != 1 || payments[0].ID != "pay-1" || payments[0].Status != StatusCaptured {
+		t.Errorf("Unexpected payments %+v", payments)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	payment := &Payment{ID: "pay-1", Status: StatusAuthorized, ProviderPaymentID: "pi_1", UpdatedAt: now}
+
+	mock.ExpectQuery(`UPDATE payments SET status = \$3, .* WHERE id = \$1 AND status = \$2 RETURNING`).
+		WithArgs("pay-1", StatusPending, StatusAuthorized, "pi_1", "", 0.0, 0.0, "", now).
+		WillReturnRows(paymentRow(StatusAuthorized, 0, 0))
+
+	updated, err := repo.Update(context.Background(), payment, StatusPending)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Status != StatusAuthorized || updated.ProviderPaymentID != "pi_1" {
+		t.Errorf("Unexpected payment %+v", updated)
+	}
+}
+
+func TestUpdate_Conflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		lookup   func(*sqlmock.ExpectedQuery)
+		expected error
+	}{
+		{
+			name: "status changed",
+			lookup: func(q *sqlmock.ExpectedQuery) {
+				q.WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(StatusVoided))
+			},
+			expected: ErrStatusConflict,
+		},
+		{
+			name:     "payment missing",
+			lookup:   func(q *sqlmock.ExpectedQuery) { q.WillReturnError(sql.ErrNoRows) },
+			expected: ErrPaymentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+
+			mock.ExpectQuery(`UPDATE payments`).WillReturnError(sql.ErrNoRows)
+			tt.lookup(mock.ExpectQuery(`SELECT status FROM payments WHERE id = \$1`).WithArgs("pay-1"))
+
+			_, err := repo.Update(context.Background(), &Payment{ID: "pay-1", Status: StatusCaptured}, StatusAuthorized)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestAddRefund(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE payments SET refunded_amount = refunded_amount \+ \$2, .* WHERE id = \$1 AND status = 'CAPTURED' AND refunded_amount \+ \$2 <= captured_amount`).
+		WithArgs("pay-1", 12.5, now).
+		WillReturnRows(paymentRow(StatusRefunded, 12.5, 12.5))
+
+	updated, err := repo.AddRefund(context.Background(), "pay-1", 12.5, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Status != StatusRefunded || updated.RefundedAmount != 12.5 {
+		t.Errorf("Unexpected payment %+v", updated)
+	}
+}
+
+func TestAddRefund_TooLarge(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`UPDATE payments`).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT status FROM payments`).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(StatusCaptured))
+
+	if _, err := repo.AddRefund(context.Background(), "pay-1", 100, time.Now()); !errors.Is(err, ErrStatusConflict) {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+}
+
+func TestGetByProviderRef(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM payments WHERE provider = \$1 AND \(provider_payment_id = \$2 OR provider_capture_id = \$2\)`).
+		WithArgs(ProviderStripe, "pi_1").
+		WillReturnRows(paymentRow(StatusAuthorized, 0, 0))
+	mock.ExpectQuery(`SELECT .* FROM payments WHERE provider = \$1`).
+		WithArgs(ProviderStripe, "pi_missing").
+		WillReturnError(sql.ErrNoRows)
+
+	payment, err := repo.GetByProviderRef(context.Background(), ProviderStripe, "pi_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if payment.ID != "pay-1" {
+		t.Errorf("Expected pay-1, got %s", payment.ID)
+	}
+	if _, err := repo.GetByProviderRef(context.Background(), ProviderStripe, "pi_missing"); !errors.Is(err, ErrPaymentNotFound) {
+		t.Errorf("Expected ErrPaymentNotFound, got %v", err)
+	}
+}
+
+func TestRecordEvent(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO webhook_events .* ON CONFLICT \(provider, event_id\) DO NOTHING`).
+		WithArgs(ProviderStripe, "evt_1", "payment_intent.succeeded", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO webhook_events`).
+		WithArgs(ProviderStripe, "evt_1", "payment_intent.succeeded", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for i, expected := range []bool{true, false} {
+		fresh, err := repo.RecordEvent(context.Background(), ProviderStripe, "evt_1", "payment_intent.succeeded", now)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if fresh != expected {
+			t.Errorf("Delivery %d: expected fresh=%v, got %v", i+1, expected, fresh)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
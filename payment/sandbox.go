@@ -0,0 +1,220 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SandboxDeclinedPaymentMethod is the payment method the sandbox provider declines
+const SandboxDeclinedPaymentMethod = "tok_declined"
+
+// Operations of fake charges
+const (
+	FakeAuthorize = "AUTHORIZE"
+	FakeDecline   = "DECLINE"
+	FakeCapture   = "CAPTURE"
+	FakeVoid      = "VOID"
+	FakeRefund    = "REFUND"
+)
+
+// maxFakeCharges caps the fake charges a sandbox keeps; the oldest are
+// dropped first
+const maxFakeCharges = 1000
+
+// FakeCharge is an operation a sandbox provider was asked to perform. Amount
+// is in minor units.
+type FakeCharge struct {
+	Provider  string
+	PaymentID string
+	Operation string
+	Amount    int64
+	Currency  string
+	Reference string
+	CreatedAt time.Time
+}
+
+// SandboxProvider is a payment provider for sandbox mode. It approves every
+// payment method except SandboxDeclinedPaymentMethod, makes no network calls
+// and records the calls it receives so they can be inspected. Its references
+// derive from the payment ID, so a flow run again yields the same ones.
+type SandboxProvider struct {
+	name    string
+	now     func() time.Time
+	mu      sync.Mutex
+	calls   []string
+	charges []*FakeCharge
+}
+
+// NewSandboxProvider creates a sandbox provider registered as name, so one
+// sandbox can stand in for each real provider
+func NewSandboxProvider(name string) *SandboxProvider {
+	return &SandboxProvider{name: name, now: time.Now}
+}
+
+// Name returns the name the sandbox was created with
+func (p *SandboxProvider) Name() string {
+	return p.name
+}
+
+// Authorize approves the charge unless the payment method is the declined test token
+func (p *SandboxProvider) Authorize(ctx context.Context, charge Charge) (string, error) {
+	if charge.PaymentMethod == SandboxDeclinedPaymentMethod {
+		p.charge(charge.PaymentID, FakeDecline, charge.Amount, charge.Currency, "")
+		return "", fmt.Errorf("%w: sandbox test card", ErrPaymentDeclined)
+	}
+	id := "sandbox_" + charge.PaymentID
+	p.record("authorize %s %d %s", charge.PaymentID, charge.Amount, charge.Currency)
+	p.charge(charge.PaymentID, FakeAuthorize, charge.Amount, charge.Currency, id)
+	return id, nil
+}
+
+// Capture records the capture and returns its capture ID
+func (p *SandboxProvider) Capture(ctx context.Context, payment *Payment, amount int64) (string, error) {
+	id := "sandbox_capture_" + payment.ID
+	p.record("capture %s %d", payment.ID, amount)
+	p.charge(payment.ID, FakeCapture, amount, payment.Currency, id)
+	return id, nil
+}
+
+// Void records the void
+func (p *SandboxProvider) Void(ctx context.Context, payment *Payment) error {
+	p.record("void %s", payment.ID)
+	p.charge(payment.ID, FakeVoid, toMinorUnits(payment.Amount, payment.Currency), payment.Currency, payment.ProviderPaymentID)
+	return nil
+}
+
+// Refund records the refund
+func (p *SandboxProvider) Refund(ctx context.Context, payment *Payment, amount int64) error {
+	p.record("refund %s %d", payment.ID, amount)
+	p.charge(payment.ID, FakeRefund, amount, payment.Currency, payment.ProviderPaymentID)
+	return nil
+}
+
+// ParseWebhook parses an unsigned sandbox event of the form
+// {"id", "type", "payment_ref", "capture_ref", "amount", "reason"}, where type
+// is an event kind such as "captured" and amount is in minor units. Dispute
+// events also carry "dispute_id" and "dispute_status", e.g. "LOST". It lets
+// webhook handling be exercised without a provider account.
+func (p *SandboxProvider) ParseWebhook(ctx context.Context, header http.Header, body []byte) (*Event, error) {
+	var raw struct {
+		ID            string `json:"id"`
+		Type          string `json:"type"`
+		PaymentRef    string `json:"payment_ref"`
+		CaptureRef    string `json:"capture_ref"`
+		Amount        int64  `json:"amount"`
+		Reason        string `json:"reason"`
+		DisputeID     string `json:"dispute_id"`
+		DisputeStatus string `json:"dispute_status"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil || raw.ID == "" {
+		return nil, errors.New("sandbox: invalid event")
+	}
+	event := &Event{
+		ID:         raw.ID,
+		Type:       raw.Type,
+		PaymentRef: raw.PaymentRef,
+		CaptureID:  raw.CaptureRef,
+		Amount:     raw.Amount,
+		Reason:     raw.Reason,
+	}
+	if _, ok := eventTargets[raw.Type]; ok {
+		event.Kind = raw.Type
+	}
+	if raw.Type == EventDispute {
+		if _, ok := disputeStages[raw.DisputeStatus]; !ok || raw.DisputeID == "" {
+			return nil, errors.New("sandbox: invalid dispute")
+		}
+		event.Kind = EventDispute
+		event.Dispute = &DisputeEvent{ID: raw.DisputeID, Status: raw.DisputeStatus, Reason: raw.Reason, Amount: raw.Amount}
+	}
+	return event, nil
+}
+
+// Calls returns the calls received so far, in order
+func (p *SandboxProvider) Calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.calls...)
+}
+
+// Charges returns the fake charges recorded so far, oldest first, of the
+// payment paymentID or, when it is empty, of every payment
+func (p *SandboxProvider) Charges(paymentID string) []*FakeCharge {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var charges []*FakeCharge
+	for _, c := range p.charges {
+		if paymentID == "" || c.PaymentID == paymentID {
+			charges = append(charges, c)
+		}
+	}
+	return charges
+}
+
+func (p *SandboxProvider) charge(paymentID, operation string, amount int64, currency, reference string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.charges) >= maxFakeCharges {
+		p.charges = p.charges[1:]
+	}
+	p.charges = append(p.charges, &FakeCharge{
+		Provider:  p.name,
+		PaymentID: paymentID,
+		Operation: operation,
+		Amount:    amount,
+		Currency:  currency,
+		Reference: reference,
+		CreatedAt: p.now(),
+	})
+}
+
+func (p *SandboxProvider) record(format string, args ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, fmt.Sprintf(format, args...))
+}
+
+// ListFakeCharges lists what the sandbox providers were asked to do, oldest
+// first, so a flow can be checked without a provider account. It is only
+// served in sandbox mode.
+func (s *Service) ListFakeCharges(ctx context.Context, req *pb.ListFakeChargesRequest) (*pb.ListFakeChargesResponse, error) {
+	var charges []*FakeCharge
+	sandbox := false
+	for _, name := range s.router.Names() {
+		provider, err := s.router.Get(name)
+		if p, ok := provider.(*SandboxProvider); ok && err == nil {
+			sandbox = true
+			charges = append(charges, p.Charges(req.PaymentId)...)
+		}
+	}
+	if !sandbox {
+		s.log.Warn(ctx, "List fake charges failed: sandbox mode is off", nil)
+		return nil, status.Error(codes.FailedPrecondition, "fake charges are only recorded in sandbox mode")
+	}
+	sort.SliceStable(charges, func(i, j int) bool { return charges[i].CreatedAt.Before(charges[j].CreatedAt) })
+
+	resp := &pb.ListFakeChargesResponse{Charges: make([]*pb.FakeCharge, len(charges))}
+	for i, c := range charges {
+		resp.Charges[i] = &pb.FakeCharge{
+			Provider:  c.Provider,
+			PaymentId: c.PaymentID,
+			Operation: c.Operation,
+			Amount:    fromMinorUnits(c.Amount, c.Currency),
+			Currency:  c.Currency,
+			Reference: c.Reference,
+			CreatedAt: timestamppb.New(c.CreatedAt),
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,399 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultCurrency is used when an authorization names no currency
+const DefaultCurrency = "USD"
+
+// maxUserIDLength matches the user_id column of payments
+const maxUserIDLength = 255
+
+// AdminMethods are the admin-scoped RPCs of the payment service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.PaymentService_CapturePayment_FullMethodName,
+	pb.PaymentService_RefundPayment_FullMethodName,
+	pb.PaymentService_GetDispute_FullMethodName,
+	pb.PaymentService_ListDisputes_FullMethodName,
+	pb.PaymentService_SubmitDisputeEvidence_FullMethodName,
+}
+
+// Service implements the PaymentService gRPC interface
+type Service struct {
+	pb.UnimplementedPaymentServiceServer
+	repo      Repository
+	router    *Router
+	orders    Orders
+	inventory Inventory
+	fraud     FraudChecks
+	log       *logger.Logger
+	now       func() time.Time
+}
+
+// NewService creates a new payment service. orders is used to bring orders in
+// line with payments changed by provider webhooks, inventory restocks the
+// items of orders cancelled after a lost dispute, and fraud is consulted
+// before a payment is captured.
+func NewService(repo Repository, router *Router, orders Orders, inventory Inventory, fraud FraudChecks, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		router:    router,
+		orders:    orders,
+		inventory: inventory,
+		fraud:     fraud,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// AuthorizePayment holds an order's amount on the customer's payment method
+// through the selected provider. The payment is recorded as PENDING before the
+// provider is called, so every provider call can be traced to a payment.
+func (s *Service) AuthorizePayment(ctx context.Context, req *pb.AuthorizePaymentRequest) (*pb.AuthorizePaymentResponse, error) {
+	currency := strings.ToUpper(req.Currency)
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if msg := authorizationProblem(req, currency); msg != "" {
+		s.log.Warn(ctx, "Authorize payment failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	provider, err := s.router.Select(strings.ToLower(req.Provider), req.Region)
+	if err != nil {
+		s.log.Warn(ctx, "Authorize payment failed: unknown provider", map[string]interface{}{"provider": req.Provider})
+		return nil, status.Error(codes.InvalidArgument, "provider must be one of "+strings.Join(s.router.Names(), ", "))
+	}
+
+	amount := toMinorUnits(req.Amount, currency)
+	now := s.now()
+	payment, err := s.repo.Create(ctx, &Payment{
+		OrderID:   req.OrderId,
+		UserID:    req.UserId,
+		Provider:  provider.Name(),
+		Status:    StatusPending,
+		Amount:    fromMinorUnits(amount, currency),
+		Currency:  currency,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to create payment", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Internal, "failed to create payment")
+	}
+
+	providerID, authErr := provider.Authorize(ctx, Charge{
+		PaymentID:     payment.ID,
+		OrderID:       payment.OrderID,
+		Amount:        amount,
+		Currency:      currency,
+		PaymentMethod: req.PaymentMethod,
+	})
+	switch {
+	case authErr == nil:
+		payment.Status = StatusAuthorized
+		payment.ProviderPaymentID = providerID
+	case errors.Is(authErr, ErrPaymentDeclined):
+		payment.Status = StatusDeclined
+		payment.FailureReason = authErr.Error()
+	default:
+		payment.Status = StatusFailed
+		payment.FailureReason = authErr.Error()
+	}
+	payment.UpdatedAt = s.now()
+
+	// The provider has acted, so record the outcome even if the caller gave up
+	saved, err := s.repo.Update(context.WithoutCancel(ctx), payment, StatusPending)
+	if err != nil {
+		s.log.Error(ctx, "Failed to record authorization", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID, "status": payment.Status})
+		if authErr == nil {
+			s.release(ctx, provider, payment)
+		}
+		return nil, status.Error(codes.Internal, "failed to record payment")
+	}
+
+	if authErr != nil {
+		return nil, s.providerError(ctx, authErr, payment, "authorize")
+	}
+
+	s.log.Info(ctx, "Payment authorized", map[string]interface{}{"payment_id": saved.ID, "order_id": saved.OrderID, "provider": saved.Provider})
+	return &pb.AuthorizePaymentResponse{
+		Payment: toProtoPayment(saved),
+	}, nil
+}
+
+// CapturePayment collects an authorized payment, in full or in part. An
+// authorization can be captured once, and not while its order is held for
+// fraud review or after the order was rejected as fraudulent.
+func (s *Service) CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
+	payment, provider, err := s.load(ctx, req.PaymentId, StatusAuthorized)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fraud.CaptureAllowed(ctx, payment.OrderID); err != nil {
+		return nil, s.fraudError(ctx, err, payment)
+	}
+
+	authorized := toMinorUnits(payment.Amount, payment.Currency)
+	amount, err := s.requestedAmount(ctx, req.Amount, payment, authorized, "the authorized amount")
+	if err != nil {
+		return nil, err
+	}
+
+	captureID, err := provider.Capture(ctx, payment, amount)
+	if err != nil {
+		return nil, s.providerError(ctx, err, payment, "capture")
+	}
+
+	payment.Status = StatusCaptured
+	payment.ProviderCaptureID = captureID
+	payment.CapturedAmount = fromMinorUnits(amount, payment.Currency)
+	payment.UpdatedAt = s.now()
+
+	saved, err := s.repo.Update(context.WithoutCancel(ctx), payment, StatusAuthorized)
+	if err != nil {
+		return nil, s.saveError(ctx, err, payment)
+	}
+
+	return &pb.CapturePaymentResponse{
+		Payment: toProtoPayment(saved),
+	}, nil
+}
+
+// VoidPayment releases an authorized payment that will not be captured
+func (s *Service) VoidPayment(ctx context.Context, req *pb.VoidPaymentRequest) (*pb.VoidPaymentResponse, error) {
+	payment, provider, err := s.load(ctx, req.PaymentId, StatusAuthorized)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.Void(ctx, payment); err != nil {
+		return nil, s.providerError(ctx, err, payment, "void")
+	}
+
+	payment.Status = StatusVoided
+	payment.UpdatedAt = s.now()
+
+	saved, err := s.repo.Update(context.WithoutCancel(ctx), payment, StatusAuthorized)
+	if err != nil {
+		return nil, s.saveError(ctx, err, payment)
+	}
+
+	return &pb.VoidPaymentResponse{
+		Payment: toProtoPayment(saved),
+	}, nil
+}
+
+// RefundPayment returns money from a captured payment. Partial refunds keep
+// the payment CAPTURED until the whole captured amount has been refunded.
+func (s *Service) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	payment, provider, err := s.load(ctx, req.PaymentId, StatusCaptured)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := toMinorUnits(payment.CapturedAmount, payment.Currency) - toMinorUnits(payment.RefundedAmount, payment.Currency)
+	amount, err := s.requestedAmount(ctx, req.Amount, payment, remaining, "the amount left to refund")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.Refund(ctx, payment, amount); err != nil {
+		return nil, s.providerError(ctx, err, payment, "refund")
+	}
+
+	saved, err := s.repo.AddRefund(context.WithoutCancel(ctx), payment.ID, fromMinorUnits(amount, payment.Currency), s.now())
+	if err != nil {
+		return nil, s.saveError(ctx, err, payment)
+	}
+
+	return &pb.RefundPaymentResponse{
+		Payment: toProtoPayment(saved),
+	}, nil
+}
+
+// GetPayment retrieves a payment by ID
+func (s *Service) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.GetPaymentResponse, error) {
+	if req.PaymentId == "" {
+		s.log.Warn(ctx, "Get payment failed: payment ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "payment_id is required")
+	}
+
+	payment, err := s.repo.GetByID(ctx, req.PaymentId)
+	if err != nil {
+		return nil, s.paymentLookupError(ctx, err, req.PaymentId)
+	}
+
+	return &pb.GetPaymentResponse{
+		Payment: toProtoPayment(payment),
+	}, nil
+}
+
+// ListPayments retrieves the payments of an order, newest first
+func (s *Service) ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error) {
+	if req.OrderId == "" {
+		s.log.Warn(ctx, "List payments failed: order ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	payments, err := s.repo.ListByOrder(ctx, req.OrderId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list payments", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Internal, "failed to list payments")
+	}
+
+	resp := &pb.ListPaymentsResponse{Payments: make([]*pb.Payment, 0, len(payments))}
+	for _, payment := range payments {
+		resp.Payments = append(resp.Payments, toProtoPayment(payment))
+	}
+	return resp, nil
+}
+
+// authorizationProblem validates an authorization request and returns a
+// message describing the first problem, or ""
+func authorizationProblem(req *pb.AuthorizePaymentRequest, currency string) string {
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxUserIDLength:
+		return "user_id is too long"
+	case req.PaymentMethod == "":
+		return "payment_method is required"
+	case len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "":
+		return "currency must be a three-letter ISO 4217 code"
+	case toMinorUnits(req.Amount, currency) <= 0:
+		return "amount must be positive"
+	}
+	return ""
+}
+
+// load reads a payment that must be in status want, together with the
+// provider that holds it
+func (s *Service) load(ctx context.Context, paymentID, want string) (*Payment, Provider, error) {
+	if paymentID == "" {
+		s.log.Warn(ctx, "Payment request failed: payment ID is required", nil)
+		return nil, nil, status.Error(codes.InvalidArgument, "payment_id is required")
+	}
+
+	payment, err := s.repo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, nil, s.paymentLookupError(ctx, err, paymentID)
+	}
+	if payment.Status != want {
+		s.log.Warn(ctx, "Payment request failed: wrong status", map[string]interface{}{"payment_id": paymentID, "status": payment.Status, "want": want})
+		return nil, nil, status.Error(codes.FailedPrecondition, "payment is "+payment.Status+", not "+want)
+	}
+
+	provider, err := s.router.Get(payment.Provider)
+	if err != nil {
+		s.log.Error(ctx, "Payment provider not configured", map[string]interface{}{"payment_id": paymentID, "provider": payment.Provider})
+		return nil, nil, status.Error(codes.FailedPrecondition, "payment provider "+payment.Provider+" is not configured")
+	}
+	return payment, provider, nil
+}
+
+// requestedAmount converts a requested amount to minor units, defaulting to
+// limit when zero and rejecting amounts above limit
+func (s *Service) requestedAmount(ctx context.Context, requested float64, payment *Payment, limit int64, what string) (int64, error) {
+	if requested == 0 {
+		return limit, nil
+	}
+	amount := toMinorUnits(requested, payment.Currency)
+	if amount <= 0 {
+		s.log.Warn(ctx, "Payment request failed: amount is not positive", map[string]interface{}{"payment_id": payment.ID})
+		return 0, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	if amount > limit {
+		s.log.Warn(ctx, "Payment request failed: amount too large", map[string]interface{}{"payment_id": payment.ID, "amount": requested})
+		return 0, status.Error(codes.InvalidArgument, "amount exceeds "+what)
+	}
+	return amount, nil
+}
+
+// release voids an authorization whose outcome could not be recorded, so the
+// customer's funds are not held for a payment the service has no record of
+func (s *Service) release(ctx context.Context, provider Provider, payment *Payment) {
+	if err := provider.Void(context.WithoutCancel(ctx), payment); err != nil {
+		s.log.Error(ctx, "Failed to void unrecorded authorization", map[string]interface{}{
+			"error":               err.Error(),
+			"payment_id":          payment.ID,
+			"provider":            payment.Provider,
+			"provider_payment_id": payment.ProviderPaymentID,
+		})
+	}
+}
+
+// providerError maps a provider failure to a gRPC status
+func (s *Service) providerError(ctx context.Context, err error, payment *Payment, action string) error {
+	if errors.Is(err, ErrPaymentDeclined) {
+		s.log.Warn(ctx, "Payment declined", map[string]interface{}{"payment_id": payment.ID, "provider": payment.Provider, "action": action, "reason": err.Error()})
+		return status.Error(codes.FailedPrecondition, "payment declined")
+	}
+	s.log.Error(ctx, "Payment provider call failed", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID, "provider": payment.Provider, "action": action})
+	return status.Error(codes.Unavailable, "payment provider unavailable")
+}
+
+// fraudError maps a failed fraud check to a gRPC status
+func (s *Service) fraudError(ctx context.Context, err error, payment *Payment) error {
+	if errors.Is(err, ErrFraudReview) || errors.Is(err, ErrFraudRejected) {
+		s.log.Warn(ctx, "Capture refused: "+err.Error(), map[string]interface{}{"payment_id": payment.ID, "order_id": payment.OrderID})
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	s.log.Error(ctx, "Fraud check failed", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID, "order_id": payment.OrderID})
+	return status.Error(codes.Unavailable, "fraud check unavailable")
+}
+
+// saveError maps a failure to record a completed provider call to a gRPC
+// status. The provider has already acted, so the mismatch is logged for
+// reconciliation.
+func (s *Service) saveError(ctx context.Context, err error, payment *Payment) error {
+	if errors.Is(err, ErrStatusConflict) {
+		s.log.Warn(ctx, "Payment changed during provider call", map[string]interface{}{"payment_id": payment.ID, "status": payment.Status})
+		return status.Error(codes.Aborted, "payment was changed by another request; reload the payment and retry")
+	}
+	s.log.Error(ctx, "Failed to record payment update", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID, "status": payment.Status})
+	return status.Error(codes.Internal, "failed to record payment")
+}
+
+// paymentLookupError maps an error reading a payment to a gRPC status
+func (s *Service) paymentLookupError(ctx context.Context, err error, paymentID string) error {
+	if errors.Is(err, ErrPaymentNotFound) {
+		s.log.Warn(ctx, "Payment not found", map[string]interface{}{"payment_id": paymentID})
+		return status.Error(codes.NotFound, "payment not found")
+	}
+	s.log.Error(ctx, "Failed to get payment", map[string]interface{}{"error": err.Error(), "payment_id": paymentID})
+	return status.Error(codes.Internal, "failed to get payment")
+}
+
+// toProtoPayment converts a payment to protobuf
+func toProtoPayment(p *Payment) *pb.Payment {
+	return &pb.Payment{
+		Id:                p.ID,
+		OrderId:           p.OrderID,
+		UserId:            p.UserID,
+		Provider:          p.Provider,
+		ProviderPaymentId: p.ProviderPaymentID,
+		Status:            p.Status,
+		Amount:            p.Amount,
+		Currency:          p.Currency,
+		CapturedAmount:    p.CapturedAmount,
+		RefundedAmount:    p.RefundedAmount,
+		FailureReason:     p.FailureReason,
+		CreatedAt:         timestamppb.New(p.CreatedAt),
+		UpdatedAt:         timestamppb.New(p.UpdatedAt),
+	}
+}
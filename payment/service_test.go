@@ -0,0 +1,647 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps payments, webhook events and disputes, updating a
+// payment only from the status the caller names and refunding only up to
+// what was captured
+type memoryRepository struct {
+	mu        sync.Mutex
+	payments  map[string]Payment
+	events    map[string]bool
+	disputes  map[string]Dispute
+	updateErr error
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{payments: map[string]Payment{}, events: map[string]bool{}, disputes: map[string]Dispute{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, payment *Payment) (*Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	payment.ID = uuid.New().String()
+	m.payments[payment.ID] = *payment
+	return payment, nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.payments[id]
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+	return &p, nil
+}
+
+func (m *memoryRepository) GetByProviderRef(ctx context.Context, provider, ref string) (*Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.payments {
+		if p.Provider == provider && ref != "" && (p.ProviderPaymentID == ref || p.ProviderCaptureID == ref) {
+			return &p, nil
+		}
+	}
+	return nil, ErrPaymentNotFound
+}
+
+func (m *memoryRepository) ListByOrder(ctx context.Context, orderID string) ([]*Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	payments := []*Payment{}
+	for _, p := range m.payments {
+		if p.OrderID == orderID {
+			p := p
+			payments = append(payments, &p)
+		}
+	}
+	sort.Slice(payments, func(i, j int) bool { return payments[i].CreatedAt.After(payments[j].CreatedAt) })
+	return payments, nil
+}
+
+func (m *memoryRepository) Update(ctx context.Context, payment *Payment, from string) (*Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.updateErr != nil {
+		return nil, m.updateErr
+	}
+	stored, ok := m.payments[payment.ID]
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+	if stored.Status != from {
+		return nil, ErrStatusConflict
+	}
+	stored.Status = payment.Status
+	stored.ProviderPaymentID = payment.ProviderPaymentID
+	stored.ProviderCaptureID = payment.ProviderCaptureID
+	stored.CapturedAmount = payment.CapturedAmount
+	stored.RefundedAmount = payment.RefundedAmount
+	stored.FailureReason = payment.FailureReason
+	stored.UpdatedAt = payment.UpdatedAt
+	m.payments[payment.ID] = stored
+	return &stored, nil
+}
+
+func (m *memoryRepository) AddRefund(ctx context.Context, id string, amount float64, at time.Time) (*Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.payments[id]
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+	if stored.Status != StatusCaptured || stored.RefundedAmount+amount > stored.CapturedAmount {
+		return nil, ErrStatusConflict
+	}
+	stored.RefundedAmount += amount
+	if stored.RefundedAmount >= stored.CapturedAmount {
+		stored.Status = StatusRefunded
+	}
+	stored.UpdatedAt = at
+	m.payments[id] = stored
+	return &stored, nil
+}
+
+func (m *memoryRepository) RecordEvent(ctx context.Context, provider, eventID, eventType string, at time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := provider + "/" + eventID
+	if m.events[key] {
+		return false, nil
+	}
+	m.events[key] = true
+	return true, nil
+}
+
+func (m *memoryRepository) DeleteEvent(ctx context.Context, provider, eventID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.events, provider+"/"+eventID)
+	return nil
+}
+
+func (m *memoryRepository) UpsertDispute(ctx context.Context, dispute *Dispute) (*Dispute, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, stored := range m.disputes {
+		if stored.Provider != dispute.Provider || stored.ProviderDisputeID != dispute.ProviderDisputeID {
+			continue
+		}
+		if isFinalDispute(stored.Status) {
+			return &stored, nil
+		}
+		if disputeStages[dispute.Status] > disputeStages[stored.Status] {
+			stored.Status = dispute.Status
+		}
+		if isFinalDispute(stored.Status) {
+			stored.ClosedAt = &dispute.UpdatedAt
+		}
+		stored.Reason, stored.Amount, stored.Currency = dispute.Reason, dispute.Amount, dispute.Currency
+		if dispute.EvidenceDueBy != nil {
+			stored.EvidenceDueBy = dispute.EvidenceDueBy
+		}
+		stored.UpdatedAt = dispute.UpdatedAt
+		m.disputes[id] = stored
+		return &stored, nil
+	}
+
+	dispute.ID = uuid.New().String()
+	dispute.CreatedAt = dispute.UpdatedAt
+	if isFinalDispute(dispute.Status) {
+		dispute.ClosedAt = &dispute.UpdatedAt
+	}
+	m.disputes[dispute.ID] = *dispute
+	return dispute, nil
+}
+
+func (m *memoryRepository) GetDispute(ctx context.Context, id string) (*Dispute, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.disputes[id]
+	if !ok {
+		return nil, ErrDisputeNotFound
+	}
+	return &d, nil
+}
+
+func (m *memoryRepository) ListDisputes(ctx context.Context, status string, page, pageSize int) ([]*Dispute, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	disputes := []*Dispute{}
+	for _, d := range m.disputes {
+		if status == "" || d.Status == status {
+			d := d
+			d.Evidence = nil
+			disputes = append(disputes, &d)
+		}
+	}
+	sort.Slice(disputes, func(i, j int) bool {
+		a, b := disputes[i].EvidenceDueBy, disputes[j].EvidenceDueBy
+		return a != nil && (b == nil || a.Before(*b))
+	})
+	total := len(disputes)
+	start := min((page-1)*pageSize, total)
+	return disputes[start:min(start+pageSize, total)], total, nil
+}
+
+func (m *memoryRepository) AddDisputeEvidence(ctx context.Context, disputeID string, evidence *Evidence) (*Dispute, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.disputes[disputeID]
+	if !ok {
+		return nil, ErrDisputeNotFound
+	}
+	if isFinalDispute(stored.Status) {
+		return nil, ErrDisputeClosed
+	}
+	stored.Evidence = append(append([]*Evidence(nil), stored.Evidence...), evidence)
+	if stored.EvidenceSubmittedAt == nil {
+		stored.EvidenceSubmittedAt = &evidence.SubmittedAt
+	}
+	stored.UpdatedAt = evidence.SubmittedAt
+	m.disputes[disputeID] = stored
+	return &stored, nil
+}
+
+func (m *memoryRepository) MarkDisputeAdjusted(ctx context.Context, id string, at time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.disputes[id]
+	if !ok || stored.Status != DisputeLost || stored.AdjustedAt != nil {
+		return false, nil
+	}
+	stored.AdjustedAt = &at
+	m.disputes[id] = stored
+	return true, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// failingProvider fails every call with err
+type failingProvider struct {
+	name string
+	err  error
+}
+
+func (p *failingProvider) Name() string { return p.name }
+
+func (p *failingProvider) Authorize(ctx context.Context, charge Charge) (string, error) {
+	return "", p.err
+}
+
+func (p *failingProvider) Capture(ctx context.Context, payment *Payment, amount int64) (string, error) {
+	return "", p.err
+}
+
+func (p *failingProvider) Void(ctx context.Context, payment *Payment) error { return p.err }
+
+func (p *failingProvider) Refund(ctx context.Context, payment *Payment, amount int64) error {
+	return p.err
+}
+
+// fakeOrders records the order updates it receives
+type fakeOrders struct {
+	mu     sync.Mutex
+	calls  []string
+	err    error
+	orders map[string]*Order
+}
+
+func (o *fakeOrders) Get(ctx context.Context, orderID string) (*Order, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return nil, o.err
+	}
+	order, ok := o.orders[orderID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return order, nil
+}
+
+func (o *fakeOrders) MarkPaid(ctx context.Context, orderID string) error {
+	return o.record("paid " + orderID)
+}
+
+func (o *fakeOrders) Cancel(ctx context.Context, orderID string) error {
+	return o.record("cancel " + orderID)
+}
+
+func (o *fakeOrders) record(call string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return o.err
+	}
+	o.calls = append(o.calls, call)
+	return nil
+}
+
+// fakeInventory records the restocks it receives
+type fakeInventory struct {
+	mu       sync.Mutex
+	restocks []string
+	err      error
+}
+
+func (i *fakeInventory) Restock(ctx context.Context, productID string, quantity int32) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.err != nil {
+		return i.err
+	}
+	i.restocks = append(i.restocks, fmt.Sprintf("%s %d", productID, quantity))
+	return nil
+}
+
+func (i *fakeInventory) Restocks() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]string(nil), i.restocks...)
+}
+
+func (o *fakeOrders) Calls() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]string(nil), o.calls...)
+}
+
+// fakeFraudChecks refuses captures of the orders in refused
+type fakeFraudChecks struct {
+	refused map[string]error
+}
+
+func (f *fakeFraudChecks) CaptureAllowed(ctx context.Context, orderID string) error {
+	return f.refused[orderID]
+}
+
+type serviceFixture struct {
+	repo      *memoryRepository
+	orders    *fakeOrders
+	inventory *fakeInventory
+	fraud     *fakeFraudChecks
+	stripe    *SandboxProvider
+	razorpay  *SandboxProvider
+	service   *Service
+}
+
+func setupService(t *testing.T, extra ...Provider) *serviceFixture {
+	f := &serviceFixture{
+		repo:      newMemoryRepository(),
+		orders:    &fakeOrders{orders: map[string]*Order{}},
+		inventory: &fakeInventory{},
+		fraud:     &fakeFraudChecks{refused: map[string]error{}},
+		stripe:    NewSandboxProvider(ProviderStripe),
+		razorpay:  NewSandboxProvider(ProviderRazorpay),
+	}
+	router, err := NewRouter(append([]Provider{f.stripe, f.razorpay}, extra...), map[string]string{"IN": ProviderRazorpay}, ProviderStripe)
+	if err != nil {
+		t.Fatalf("Failed to create router: %v", err)
+	}
+	f.service = NewService(f.repo, router, f.orders, f.inventory, f.fraud, logger.New("payment-test"))
+	return f
+}
+
+func (f *serviceFixture) authorize(t *testing.T, req *pb.AuthorizePaymentRequest) *pb.Payment {
+	resp, err := f.service.AuthorizePayment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Payment
+}
+
+func validAuthorization() *pb.AuthorizePaymentRequest {
+	return &pb.AuthorizePaymentRequest{OrderId: "order-1", UserId: "user-1", Amount: 25.5, PaymentMethod: "tok_visa"}
+}
+
+func TestAuthorizePayment_Routing(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		region   string
+		expected string
+	}{
+		{"default provider", "", "", ProviderStripe},
+		{"region route", "", "IN", ProviderRazorpay},
+		{"requested provider", "Stripe", "IN", ProviderStripe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			req := validAuthorization()
+			req.Provider, req.Region = tt.provider, tt.region
+
+			payment := f.authorize(t, req)
+			if payment.Provider != tt.expected || payment.Status != StatusAuthorized || payment.ProviderPaymentId == "" {
+				t.Errorf("Unexpected payment %v", payment)
+			}
+			if payment.Currency != DefaultCurrency || payment.Amount != 25.5 {
+				t.Errorf("Unexpected amount %v %s", payment.Amount, payment.Currency)
+			}
+		})
+	}
+}
+
+func TestAuthorizePayment_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(req *pb.AuthorizePaymentRequest)
+	}{
+		{"missing order", func(req *pb.AuthorizePaymentRequest) { req.OrderId = "" }},
+		{"missing user", func(req *pb.AuthorizePaymentRequest) { req.UserId = "" }},
+		{"missing payment method", func(req *pb.AuthorizePaymentRequest) { req.PaymentMethod = "" }},
+		{"zero amount", func(req *pb.AuthorizePaymentRequest) { req.Amount = 0 }},
+		{"bad currency", func(req *pb.AuthorizePaymentRequest) { req.Currency = "US1" }},
+		{"unknown provider", func(req *pb.AuthorizePaymentRequest) { req.Provider = "adyen" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			req := validAuthorization()
+			tt.modify(req)
+
+			_, err := f.service.AuthorizePayment(context.Background(), req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+			if len(f.repo.payments) != 0 {
+				t.Error("Expected no payment to be recorded")
+			}
+		})
+	}
+}
+
+func TestAuthorizePayment_ProviderFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     codes.Code
+		recorded string
+	}{
+		{"declined", ErrPaymentDeclined, codes.FailedPrecondition, StatusDeclined},
+		{"provider down", errors.New("timeout"), codes.Unavailable, StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t, &failingProvider{name: ProviderPayPal, err: tt.err})
+			req := validAuthorization()
+			req.Provider = ProviderPayPal
+
+			_, err := f.service.AuthorizePayment(context.Background(), req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+			for _, p := range f.repo.payments {
+				if p.Status != tt.recorded || p.FailureReason == "" {
+					t.Errorf("Expected a %s payment with a reason, got %+v", tt.recorded, p)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorizePayment_VoidsUnrecordedAuthorization(t *testing.T) {
+	f := setupService(t)
+	f.repo.updateErr = errors.New("database down")
+
+	_, err := f.service.AuthorizePayment(context.Background(), validAuthorization())
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+	calls := f.stripe.Calls()
+	if len(calls) != 2 || calls[1][:4] != "void" {
+		t.Errorf("Expected the authorization to be voided, got %v", calls)
+	}
+}
+
+func TestCaptureAndRefund(t *testing.T) {
+	f := setupService(t)
+	payment := f.authorize(t, validAuthorization())
+	ctx := context.Background()
+
+	if _, err := f.service.CapturePayment(ctx, &pb.CapturePaymentRequest{PaymentId: payment.Id, Amount: 30}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for over-capture, got %v", err)
+	}
+	if _, err := f.service.RefundPayment(ctx, &pb.RefundPaymentRequest{PaymentId: payment.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for refunding an uncaptured payment, got %v", err)
+	}
+
+	captured, err := f.service.CapturePayment(ctx, &pb.CapturePaymentRequest{PaymentId: payment.Id, Amount: 20})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if captured.Payment.Status != StatusCaptured || captured.Payment.CapturedAmount != 20 {
+		t.Errorf("Unexpected payment %v", captured.Payment)
+	}
+
+	partial, err := f.service.RefundPayment(ctx, &pb.RefundPaymentRequest{PaymentId: payment.Id, Amount: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if partial.Payment.Status != StatusCaptured || partial.Payment.RefundedAmount != 5 {
+		t.Errorf("Unexpected payment %v", partial.Payment)
+	}
+	if _, err := f.service.RefundPayment(ctx, &pb.RefundPaymentRequest{PaymentId: payment.Id, Amount: 16}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for over-refund, got %v", err)
+	}
+
+	full, err := f.service.RefundPayment(ctx, &pb.RefundPaymentRequest{PaymentId: payment.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if full.Payment.Status != StatusRefunded || full.Payment.RefundedAmount != 20 {
+		t.Errorf("Unexpected payment %v", full.Payment)
+	}
+
+	want := []string{"capture " + payment.Id + " 2000", "refund " + payment.Id + " 500", "refund " + payment.Id + " 1500"}
+	if calls := f.stripe.Calls()[1:]; !reflect.DeepEqual(calls, want) {
+		t.Errorf("Unexpected provider calls\n got: %v\nwant: %v", calls, want)
+	}
+}
+
+func TestListFakeCharges(t *testing.T) {
+	f := setupService(t)
+	ctx := context.Background()
+	payment := f.authorize(t, validAuthorization())
+	if _, err := f.service.CapturePayment(ctx, &pb.CapturePaymentRequest{PaymentId: payment.Id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	declined := validAuthorization()
+	declined.PaymentMethod, declined.Region = SandboxDeclinedPaymentMethod, "IN"
+	if _, err := f.service.AuthorizePayment(ctx, declined); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition, got %v", err)
+	}
+
+	resp, err := f.service.ListFakeCharges(ctx, &pb.ListFakeChargesRequest{PaymentId: payment.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Charges) != 2 {
+		t.Fatalf("Expected 2 charges, got %v", resp.Charges)
+	}
+	authorized, captured := resp.Charges[0], resp.Charges[1]
+	if authorized.Operation != FakeAuthorize || authorized.Amount != 25.5 || authorized.Reference != "sandbox_"+payment.Id || authorized.Reference != payment.ProviderPaymentId {
+		t.Errorf("Unexpected authorization %v", authorized)
+	}
+	if captured.Operation != FakeCapture || captured.Provider != ProviderStripe || captured.Amount != 25.5 {
+		t.Errorf("Unexpected capture %v", captured)
+	}
+
+	all, err := f.service.ListFakeCharges(ctx, &pb.ListFakeChargesRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(all.Charges) != 3 || all.Charges[2].Operation != FakeDecline || all.Charges[2].Provider != ProviderRazorpay {
+		t.Errorf("Expected the decline to be listed last, got %v", all.Charges)
+	}
+
+	stripe, err := NewStripeProvider(StripeConfig{SecretKey: "sk_test"})
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	router, _ := NewRouter([]Provider{stripe}, nil, ProviderStripe)
+	live := NewService(f.repo, router, f.orders, f.inventory, f.fraud, logger.New("payment-test"))
+	if _, err := live.ListFakeCharges(ctx, &pb.ListFakeChargesRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition outside sandbox mode, got %v", err)
+	}
+}
+
+func TestCapturePayment_FraudChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		refusal  error
+		expected codes.Code
+	}{
+		{"held for review", ErrFraudReview, codes.FailedPrecondition},
+		{"rejected", ErrFraudRejected, codes.FailedPrecondition},
+		{"fraud service down", errors.New("connection refused"), codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			payment := f.authorize(t, validAuthorization())
+			f.fraud.refused[payment.OrderId] = tt.refusal
+
+			_, err := f.service.CapturePayment(context.Background(), &pb.CapturePaymentRequest{PaymentId: payment.Id})
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if stored := f.repo.payments[payment.Id]; stored.Status != StatusAuthorized {
+				t.Errorf("Expected the payment to stay AUTHORIZED, got %s", stored.Status)
+			}
+		})
+	}
+}
+
+func TestVoidPayment(t *testing.T) {
+	f := setupService(t)
+	payment := f.authorize(t, validAuthorization())
+
+	resp, err := f.service.VoidPayment(context.Background(), &pb.VoidPaymentRequest{PaymentId: payment.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Payment.Status != StatusVoided {
+		t.Errorf("Expected VOIDED, got %s", resp.Payment.Status)
+	}
+
+	if _, err := f.service.CapturePayment(context.Background(), &pb.CapturePaymentRequest{PaymentId: payment.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition capturing a voided payment, got %v", err)
+	}
+}
+
+func TestGetPayment(t *testing.T) {
+	f := setupService(t)
+	payment := f.authorize(t, validAuthorization())
+
+	resp, err := f.service.GetPayment(context.Background(), &pb.GetPaymentRequest{PaymentId: payment.Id})
+	if err != nil || resp.Payment.Id != payment.Id {
+		t.Errorf("Expected payment %s, got %v, %v", payment.Id, resp, err)
+	}
+	if _, err := f.service.GetPayment(context.Background(), &pb.GetPaymentRequest{PaymentId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := f.service.GetPayment(context.Background(), &pb.GetPaymentRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestListPayments(t *testing.T) {
+	f := setupService(t)
+	payment := f.authorize(t, validAuthorization())
+
+	resp, err := f.service.ListPayments(context.Background(), &pb.ListPaymentsRequest{OrderId: payment.OrderId})
+	if err != nil || len(resp.Payments) != 1 || resp.Payments[0].Id != payment.Id {
+		t.Errorf("Expected payment %s, got %v, %v", payment.Id, resp, err)
+	}
+	resp, err = f.service.ListPayments(context.Background(), &pb.ListPaymentsRequest{OrderId: "other-order"})
+	if err != nil || len(resp.Payments) != 0 {
+		t.Errorf("Expected no payments, got %v, %v", resp, err)
+	}
+	if _, err := f.service.ListPayments(context.Background(), &pb.ListPaymentsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StripeConfig holds the settings of the Stripe provider
+type StripeConfig struct {
+	SecretKey string
+	// WebhookSecret is the endpoint's signing secret (whsec_...). Webhooks
+	// are rejected when it is empty.
+	WebhookSecret string
+	// BaseURL defaults to https://api.stripe.com
+	BaseURL string
+	Timeout time.Duration
+}
+
+// StripeProvider takes payments through Stripe PaymentIntents with manual capture
+type StripeProvider struct {
+	cfg        StripeConfig
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewStripeProvider creates a new Stripe provider
+func NewStripeProvider(cfg StripeConfig) (*StripeProvider, error) {
+	if cfg.SecretKey == "" {
+		return nil, errors.New("stripe: secret key is required")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.stripe.com"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &StripeProvider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		now:        time.Now,
+	}, nil
+}
+
+// Name returns "stripe"
+func (p *StripeProvider) Name() string {
+	return ProviderStripe
+}
+
+type stripePaymentIntent struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	LatestCharge     string `json:"latest_charge"`
+	LastPaymentError *struct {
+		Message string `json:"message"`
+	} `json:"last_payment_error"`
+}
+
+type stripeErrorResponse struct {
+	Error struct {
+		Type        string `json:"type"`
+		Code        string `json:"code"`
+		DeclineCode string `json:"decline_code"`
+		Message     string `json:"message"`
+	} `json:"error"`
+}
+
+// Authorize creates and confirms a PaymentIntent with manual capture, so the
+// amount is held on the card until Capture
+func (p *StripeProvider) Authorize(ctx context.Context, charge Charge) (string, error) {
+	form := url.Values{
+		"amount":               {strconv.FormatInt(charge.Amount, 10)},
+		"currency":             {strings.ToLower(charge.Currency)},
+		"payment_method":       {charge.PaymentMethod},
+		"confirm":              {"true"},
+		"capture_method":       {"manual"},
+		"metadata[order_id]":   {charge.OrderID},
+		"metadata[payment_id]": {charge.PaymentID},
+	}
+
+	var intent stripePaymentIntent
+	if err := p.post(ctx, "/v1/payment_intents", form, "authorize-"+charge.PaymentID, &intent); err != nil {
+		return "", err
+	}
+
+	switch intent.Status {
+	case "requires_capture":
+		return intent.ID, nil
+	case "requires_action":
+		return "", fmt.Errorf("%w: authentication required", ErrPaymentDeclined)
+	case "requires_payment_method":
+		reason := "payment method refused"
+		if intent.LastPaymentError != nil && intent.LastPaymentError.Message != "" {
+			reason = intent.LastPaymentError.Message
+		}
+		return "", fmt.Errorf("%w: %s", ErrPaymentDeclined, reason)
+	default:
+		return "", fmt.Errorf("stripe: unexpected payment intent status %q", intent.Status)
+	}
+}
+
+// Capture captures amount of the PaymentIntent and returns the charge ID
+func (p *StripeProvider) Capture(ctx context.Context, payment *Payment, amount int64) (string, error) {
+	form := url.Values{"amount_to_capture": {strconv.FormatInt(amount, 10)}}
+
+	var intent stripePaymentIntent
+	path := "/v1/payment_intents/" + url.PathEscape(payment.ProviderPaymentID) + "/capture"
+	if err := p.post(ctx, path, form, "capture-"+payment.ID, &intent); err != nil {
+		return "", err
+	}
+	return intent.LatestCharge, nil
+}
+
+// Void cancels the PaymentIntent, releasing the hold
+func (p *StripeProvider) Void(ctx context.Context, payment *Payment) error {
+	path := "/v1/payment_intents/" + url.PathEscape(payment.ProviderPaymentID) + "/cancel"
+	return p.post(ctx, path, url.Values{}, "void-"+payment.ID, nil)
+}
+
+// Refund refunds amount of the captured PaymentIntent
+func (p *StripeProvider) Refund(ctx context.Context, payment *Payment, amount int64) error {
+	form := url.Values{
+		"payment_intent": {payment.ProviderPaymentID},
+		"amount":         {strconv.FormatInt(amount, 10)},
+	}
+	return p.post(ctx, "/v1/refunds", form, refundKey(payment), nil)
+}
+
+// stripeWebhookTolerance is how old a signed webhook may be, to stop replays
+const stripeWebhookTolerance = 5 * time.Minute
+
+type stripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// ParseWebhook verifies the Stripe-Signature header and parses a
+// PaymentIntent, charge or dispute event
+func (p *StripeProvider) ParseWebhook(ctx context.Context, header http.Header, body []byte) (*Event, error) {
+	if p.cfg.WebhookSecret == "" {
+		return nil, ErrWebhooksDisabled
+	}
+	if err := p.verifySignature(header.Get("Stripe-Signature"), body); err != nil {
+		return nil, err
+	}
+
+	var raw stripeEvent
+	if err := json.Unmarshal(body, &raw); err != nil || raw.ID == "" {
+		return nil, errors.New("stripe: invalid event")
+	}
+	event := &Event{ID: raw.ID, Type: raw.Type}
+
+	switch raw.Type {
+	case "payment_intent.amount_capturable_updated", "payment_intent.succeeded",
+		"payment_intent.canceled", "payment_intent.payment_failed":
+		var intent struct {
+			stripePaymentIntent
+			AmountReceived int64 `json:"amount_received"`
+		}
+		if err := json.Unmarshal(raw.Data.Object, &intent); err != nil {
+			return nil, fmt.Errorf("stripe: invalid payment intent: %w", err)
+		}
+		event.PaymentRef = intent.ID
+		switch raw.Type {
+		case "payment_intent.amount_capturable_updated":
+			event.Kind = EventAuthorized
+		case "payment_intent.succeeded":
+			event.Kind = EventCaptured
+			event.CaptureID = intent.LatestCharge
+			event.Amount = intent.AmountReceived
+		case "payment_intent.canceled":
+			event.Kind = EventVoided
+		case "payment_intent.payment_failed":
+			event.Kind = EventFailed
+			event.Reason = "payment method refused"
+			if intent.LastPaymentError != nil && intent.LastPaymentError.Message != "" {
+				event.Reason = intent.LastPaymentError.Message
+			}
+		}
+	case "charge.refunded":
+		var charge struct {
+			PaymentIntent  string `json:"payment_intent"`
+			AmountRefunded int64  `json:"amount_refunded"`
+		}
+		if err := json.Unmarshal(raw.Data.Object, &charge); err != nil {
+			return nil, fmt.Errorf("stripe: invalid charge: %w", err)
+		}
+		event.Kind = EventRefunded
+		event.PaymentRef = charge.PaymentIntent
+		event.Amount = charge.AmountRefunded
+	case "charge.dispute.created", "charge.dispute.updated", "charge.dispute.closed":
+		var dispute struct {
+			ID              string `json:"id"`
+			Amount          int64  `json:"amount"`
+			Currency        string `json:"currency"`
+			Charge          string `json:"charge"`
+			PaymentIntent   string `json:"payment_intent"`
+			Reason          string `json:"reason"`
+			Status          string `json:"status"`
+			EvidenceDetails struct {
+				DueBy int64 `json:"due_by"`
+			} `json:"evidence_details"`
+		}
+		if err := json.Unmarshal(raw.Data.Object, &dispute); err != nil || dispute.ID == "" {
+			return nil, errors.New("stripe: invalid dispute")
+		}
+		event.Kind = EventDispute
+		event.PaymentRef = dispute.PaymentIntent
+		if event.PaymentRef == "" {
+			event.PaymentRef = dispute.Charge
+		}
+		event.Dispute = &DisputeEvent{
+			ID:       dispute.ID,
+			Status:   stripeDisputeStatus(dispute.Status),
+			Reason:   dispute.Reason,
+			Amount:   dispute.Amount,
+			Currency: dispute.Currency,
+		}
+		if dispute.EvidenceDetails.DueBy > 0 {
+			event.Dispute.EvidenceDueBy = time.Unix(dispute.EvidenceDetails.DueBy, 0).UTC()
+		}
+	}
+	return event, nil
+}
+
+// stripeDisputeStatus maps a Stripe dispute status to a dispute status.
+// Inquiries (warning_*) are tracked like the disputes they may become.
+func stripeDisputeStatus(status string) string {
+	switch status {
+	case "won", "warning_closed":
+		return DisputeWon
+	case "lost":
+		return DisputeLost
+	case "under_review", "warning_under_review":
+		return DisputeUnderReview
+	default:
+		return DisputeOpen
+	}
+}
+
+// verifySignature checks a Stripe-Signature header of the form
+// t=<unix time>,v1=<hex HMAC of "<t>.<body>">. Any of several v1 signatures
+// may match, as Stripe sends one per active secret while rolling secrets.
+func (p *StripeProvider) verifySignature(header string, body []byte) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("%w: malformed Stripe-Signature header", ErrInvalidSignature)
+	}
+	if age := p.now().Sub(time.Unix(seconds, 0)); age > stripeWebhookTolerance || age < -stripeWebhookTolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+
+	payload := append([]byte(timestamp+"."), body...)
+	for _, signature := range signatures {
+		if verifyHMAC(payload, p.cfg.WebhookSecret, signature) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// post sends a form-encoded request and decodes the response into out when
+// it is not nil. Card errors are returned as ErrPaymentDeclined.
+func (p *StripeProvider) post(ctx context.Context, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+p.cfg.SecretKey)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	code, body, err := send(p.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("stripe: request failed: %w", err)
+	}
+
+	if code != http.StatusOK {
+		var decoded stripeErrorResponse
+		_ = json.Unmarshal(body, &decoded)
+		if decoded.Error.Type == "card_error" {
+			reason := decoded.Error.DeclineCode
+			if reason == "" {
+				reason = decoded.Error.Code
+			}
+			return fmt.Errorf("%w: %s", ErrPaymentDeclined, reason)
+		}
+		return fmt.Errorf("stripe: unexpected status %d: %s", code, decoded.Error.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("stripe: invalid response: %w", err)
+	}
+	return nil
+}
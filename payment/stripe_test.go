@@ -0,0 +1,223 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestStripe(t *testing.T, handler http.HandlerFunc) *StripeProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := NewStripeProvider(StripeConfig{SecretKey: "sk_test", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return provider
+}
+
+func TestStripeAuthorize_Success(t *testing.T) {
+	provider := newTestStripe(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/payment_intents" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer sk_test" || r.Header.Get("Idempotency-Key") != "authorize-pay-1" {
+			t.Errorf("Unexpected headers %v", r.Header)
+		}
+		r.ParseForm()
+		if r.Form.Get("amount") != "1250" || r.Form.Get("currency") != "usd" || r.Form.Get("capture_method") != "manual" ||
+			r.Form.Get("confirm") != "true" || r.Form.Get("payment_method") != "pm_card_visa" || r.Form.Get("metadata[order_id]") != "order-1" {
+			t.Errorf("Unexpected form %v", r.Form)
+		}
+		w.Write([]byte(`{"id": "pi_1", "status": "requires_capture"}`))
+	})
+
+	id, err := provider.Authorize(context.Background(), Charge{PaymentID: "pay-1", OrderID: "order-1", Amount: 1250, Currency: "USD", PaymentMethod: "pm_card_visa"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if id != "pi_1" {
+		t.Errorf("Expected pi_1, got %s", id)
+	}
+}
+
+func TestStripeAuthorize_Declined(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		body     string
+		declined bool
+	}{
+		{"card error", http.StatusPaymentRequired, `{"error": {"type": "card_error", "decline_code": "insufficient_funds"}}`, true},
+		{"authentication required", http.StatusOK, `{"id": "pi_1", "status": "requires_action"}`, true},
+		{"api error", http.StatusInternalServerError, `{"error": {"type": "api_error", "message": "boom"}}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestStripe(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.code)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := provider.Authorize(context.Background(), Charge{PaymentID: "pay-1", Amount: 100, Currency: "USD", PaymentMethod: "pm"})
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if errors.Is(err, ErrPaymentDeclined) != tt.declined {
+				t.Errorf("Expected declined=%v, got %v", tt.declined, err)
+			}
+		})
+	}
+}
+
+func TestStripeCaptureVoidRefund(t *testing.T) {
+	var calls []string
+	provider := newTestStripe(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		calls = append(calls, r.URL.Path+" "+r.Header.Get("Idempotency-Key")+" "+r.Form.Encode())
+		w.Write([]byte(`{"id": "pi_1", "status": "succeeded", "latest_charge": "ch_1"}`))
+	})
+	payment := &Payment{ID: "pay-1", ProviderPaymentID: "pi_1", Currency: "USD", RefundedAmount: 2}
+
+	captureID, err := provider.Capture(context.Background(), payment, 1000)
+	if err != nil || captureID != "ch_1" {
+		t.Fatalf("Expected ch_1, got %q, %v", captureID, err)
+	}
+	if err := provider.Void(context.Background(), payment); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := provider.Refund(context.Background(), payment, 300); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []string{
+		"/v1/payment_intents/pi_1/capture capture-pay-1 amount_to_capture=1000",
+		"/v1/payment_intents/pi_1/cancel void-pay-1 ",
+		"/v1/refunds refund-pay-1-200 amount=300&payment_intent=pi_1",
+	}
+	for i := range want {
+		if i >= len(calls) || calls[i] != want[i] {
+			t.Errorf("Unexpected calls\n got: %q\nwant: %q", calls, want)
+			break
+		}
+	}
+}
+
+func TestNewStripeProvider_RequiresSecretKey(t *testing.T) {
+	if _, err := NewStripeProvider(StripeConfig{}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+// stripeSignature signs body the way Stripe does
+func stripeSignature(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestStripeParseWebhook(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	provider, err := NewStripeProvider(StripeConfig{SecretKey: "sk_test", WebhookSecret: "whsec_test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	provider.now = func() time.Time { return now }
+
+	body := []byte(`{"id": "evt_1", "type": "payment_intent.succeeded",
+		"data": {"object": {"id": "pi_1", "amount_received": 1250, "latest_charge": "ch_1"}}}`)
+	header := http.Header{"Stripe-Signature": {stripeSignature("whsec_test", now, body)}}
+
+	event, err := provider.ParseWebhook(context.Background(), header, body)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := Event{ID: "evt_1", Type: "payment_intent.succeeded", Kind: EventCaptured, PaymentRef: "pi_1", CaptureID: "ch_1", Amount: 1250}
+	if *event != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *event)
+	}
+
+	refund := []byte(`{"id": "evt_2", "type": "charge.refunded", "data": {"object": {"id": "ch_1", "payment_intent": "pi_1", "amount_refunded": 500}}}`)
+	event, err = provider.ParseWebhook(context.Background(), http.Header{"Stripe-Signature": {stripeSignature("whsec_test", now, refund)}}, refund)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.Kind != EventRefunded || event.PaymentRef != "pi_1" || event.Amount != 500 {
+		t.Errorf("Unexpected refund event %+v", event)
+	}
+}
+
+func TestStripeParseWebhook_InvalidSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	provider, _ := NewStripeProvider(StripeConfig{SecretKey: "sk_test", WebhookSecret: "whsec_test"})
+	provider.now = func() time.Time { return now }
+	body := []byte(`{"id": "evt_1", "type": "payment_intent.canceled", "data": {"object": {"id": "pi_1"}}}`)
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"missing", ""},
+		{"wrong secret", stripeSignature("whsec_other", now, body)},
+		{"too old", stripeSignature("whsec_test", now.Add(-10*time.Minute), body)},
+		{"tampered body", stripeSignature("whsec_test", now, []byte(`{}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.ParseWebhook(context.Background(), http.Header{"Stripe-Signature": {tt.header}}, body)
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+
+	unsigned, _ := NewStripeProvider(StripeConfig{SecretKey: "sk_test"})
+	if _, err := unsigned.ParseWebhook(context.Background(), http.Header{}, body); !errors.Is(err, ErrWebhooksDisabled) {
+		t.Errorf("Expected ErrWebhooksDisabled, got %v", err)
+	}
+}
+
+func TestStripeParseWebhook_Dispute(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	provider, err := NewStripeProvider(StripeConfig{SecretKey: "sk_test", WebhookSecret: "whsec_test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	provider.now = func() time.Time { return now }
+
+	body := []byte(`{"id": "evt_1", "type": "charge.dispute.created", "data": {"object": {
+		"id": "dp_1", "amount": 1250, "currency": "usd", "charge": "ch_1", "payment_intent": "pi_1",
+		"reason": "fraudulent", "status": "needs_response", "evidence_details": {"due_by": 1700600000}}}}`)
+	event, err := provider.ParseWebhook(context.Background(), http.Header{"Stripe-Signature": {stripeSignature("whsec_test", now, body)}}, body)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := DisputeEvent{ID: "dp_1", Status: DisputeOpen, Reason: "fraudulent", Amount: 1250, Currency: "usd", EvidenceDueBy: time.Unix(1700600000, 0).UTC()}
+	if event.Kind != EventDispute || event.PaymentRef != "pi_1" || event.Dispute == nil || *event.Dispute != expected {
+		t.Errorf("Unexpected dispute event %+v", event)
+	}
+
+	tests := map[string]string{
+		"warning_under_review": DisputeUnderReview,
+		"won":                  DisputeWon,
+		"warning_closed":       DisputeWon,
+		"lost":                 DisputeLost,
+	}
+	for stripeStatus, expected := range tests {
+		if got := stripeDisputeStatus(stripeStatus); got != expected {
+			t.Errorf("stripeDisputeStatus(%q) = %s, want %s", stripeStatus, got, expected)
+		}
+	}
+}
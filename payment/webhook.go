@@ -0,0 +1,256 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// Webhook event kinds, normalized across providers
+const (
+	EventAuthorized = "authorized"
+	EventCaptured   = "captured"
+	EventVoided     = "voided"
+	EventRefunded   = "refunded"
+	EventFailed     = "failed"
+	EventDispute    = "dispute"
+)
+
+// maxWebhookSize caps the webhook body that is read, in bytes
+const maxWebhookSize = 1 << 20
+
+var (
+	// ErrWebhooksDisabled is returned when a provider has no webhook secret configured
+	ErrWebhooksDisabled = errors.New("webhooks are not configured for this provider")
+	// ErrInvalidSignature is returned when a webhook's signature does not verify
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Event is a verified provider webhook event
+type Event struct {
+	// ID is the provider's event ID; deliveries of the same event share it
+	ID string
+	// Type is the provider's event type, e.g. payment_intent.succeeded
+	Type string
+	// Kind is the normalized kind, or empty for events the service ignores
+	Kind string
+	// PaymentRef is the provider payment or capture ID the event is about
+	PaymentRef string
+	// CaptureID is the provider's capture ID for captured events
+	CaptureID string
+	// Amount is in minor units: the amount captured for captured events and
+	// the total refunded so far for refunded events. Zero when unknown.
+	Amount int64
+	// Reason describes a failed payment
+	Reason string
+	// Dispute is the dispute reported by dispute events
+	Dispute *DisputeEvent
+}
+
+// WebhookVerifier is implemented by providers that send webhooks
+type WebhookVerifier interface {
+	// ParseWebhook verifies a webhook delivery's signature and parses its
+	// event. It returns ErrInvalidSignature when the signature does not verify
+	// and ErrWebhooksDisabled when no webhook secret is configured.
+	ParseWebhook(ctx context.Context, header http.Header, body []byte) (*Event, error)
+}
+
+// WebhookHandler serves provider webhooks at POST /webhooks/{provider}, e.g.
+// /webhooks/stripe
+func (s *Service) WebhookHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhooks/{provider}", s.serveWebhook)
+	return mux
+}
+
+// serveWebhook verifies and applies one webhook delivery. Any response other
+// than 2xx makes the provider deliver the event again later.
+func (s *Service) serveWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("provider")
+
+	provider, err := s.router.Get(name)
+	verifier, ok := provider.(WebhookVerifier)
+	if err != nil || !ok {
+		s.log.Warn(ctx, "Webhook for unknown provider", map[string]interface{}{"provider": name})
+		http.Error(w, "unknown provider", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		s.log.Warn(ctx, "Webhook body rejected", map[string]interface{}{"provider": name, "error": err.Error()})
+		http.Error(w, "invalid body", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	event, err := verifier.ParseWebhook(ctx, r.Header, body)
+	switch {
+	case errors.Is(err, ErrWebhooksDisabled):
+		s.log.Warn(ctx, "Webhook for provider without webhook secret", map[string]interface{}{"provider": name})
+		http.Error(w, "webhooks not configured", http.StatusNotFound)
+		return
+	case errors.Is(err, ErrInvalidSignature):
+		s.log.Warn(ctx, "Webhook signature rejected", map[string]interface{}{"provider": name, "error": err.Error()})
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	case err != nil:
+		s.log.Warn(ctx, "Webhook could not be parsed", map[string]interface{}{"provider": name, "error": err.Error()})
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	err = s.HandleEvent(ctx, name, event)
+	if errors.Is(err, ErrPaymentNotFound) {
+		http.Error(w, "unknown payment", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "event not processed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleEvent applies a verified webhook event from provider. Each event is
+// applied once: deliveries of an event already recorded are acknowledged and
+// skipped. An event that fails to apply is forgotten so that the provider's
+// retry is applied.
+func (s *Service) HandleEvent(ctx context.Context, provider string, event *Event) error {
+	fields := map[string]interface{}{"provider": provider, "event_id": event.ID, "event_type": event.Type}
+	if event.Kind == "" {
+		s.log.Info(ctx, "Webhook event ignored", fields)
+		return nil
+	}
+
+	fresh, err := s.repo.RecordEvent(ctx, provider, event.ID, event.Type, s.now())
+	if err != nil {
+		s.log.Error(ctx, "Failed to record webhook event", map[string]interface{}{"error": err.Error(), "provider": provider, "event_id": event.ID})
+		return err
+	}
+	if !fresh {
+		s.log.Info(ctx, "Duplicate webhook event skipped", fields)
+		return nil
+	}
+
+	apply := s.applyEvent
+	if event.Kind == EventDispute {
+		apply = s.applyDispute
+	}
+	if err := apply(ctx, provider, event); err != nil {
+		if ferr := s.repo.DeleteEvent(context.WithoutCancel(ctx), provider, event.ID); ferr != nil {
+			s.log.Error(ctx, "Failed to forget webhook event", map[string]interface{}{"error": ferr.Error(), "provider": provider, "event_id": event.ID})
+		}
+		return err
+	}
+	return nil
+}
+
+// eventTargets maps an event kind to the payment status it leads to and the
+// statuses a payment can move from
+var eventTargets = map[string]struct {
+	to   string
+	from []string
+}{
+	EventAuthorized: {StatusAuthorized, []string{StatusPending}},
+	EventCaptured:   {StatusCaptured, []string{StatusAuthorized}},
+	EventVoided:     {StatusVoided, []string{StatusAuthorized}},
+	EventFailed:     {StatusDeclined, []string{StatusPending, StatusAuthorized}},
+	EventRefunded:   {StatusRefunded, []string{StatusCaptured}},
+}
+
+// applyEvent moves the event's payment to the status the event reports, then
+// brings its order in line. Events that the payment has already moved past
+// leave it unchanged.
+func (s *Service) applyEvent(ctx context.Context, provider string, event *Event) error {
+	payment, err := s.repo.GetByProviderRef(ctx, provider, event.PaymentRef)
+	if errors.Is(err, ErrPaymentNotFound) {
+		s.log.Warn(ctx, "Webhook event for unknown payment", map[string]interface{}{"provider": provider, "event_id": event.ID, "payment_ref": event.PaymentRef})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	target := eventTargets[event.Kind]
+	from := payment.Status
+	if slices.Contains(target.from, from) {
+		applyEventToPayment(payment, event, target.to)
+		payment.UpdatedAt = s.now()
+		updated, err := s.repo.Update(ctx, payment, from)
+		if err != nil {
+			s.log.Error(ctx, "Failed to apply webhook event", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID, "event_id": event.ID})
+			return err
+		}
+		s.log.Info(ctx, "Webhook event applied", map[string]interface{}{"payment_id": payment.ID, "event_id": event.ID, "from": from, "to": updated.Status})
+		payment = updated
+	} else {
+		s.log.Info(ctx, "Webhook event does not change payment", map[string]interface{}{"payment_id": payment.ID, "event_id": event.ID, "status": from})
+	}
+
+	return s.syncOrder(ctx, payment)
+}
+
+// applyEventToPayment sets the fields an event reports on payment
+func applyEventToPayment(payment *Payment, event *Event, to string) {
+	switch event.Kind {
+	case EventCaptured:
+		payment.CapturedAmount = payment.Amount
+		if event.Amount > 0 {
+			payment.CapturedAmount = fromMinorUnits(event.Amount, payment.Currency)
+		}
+		if event.CaptureID != "" {
+			payment.ProviderCaptureID = event.CaptureID
+		}
+	case EventFailed:
+		payment.FailureReason = event.Reason
+	case EventRefunded:
+		refunded := payment.CapturedAmount
+		if event.Amount > 0 {
+			refunded = fromMinorUnits(event.Amount, payment.Currency)
+		}
+		refunded = min(refunded, payment.CapturedAmount)
+		if refunded > payment.RefundedAmount {
+			payment.RefundedAmount = refunded
+		}
+		if toMinorUnits(payment.RefundedAmount, payment.Currency) < toMinorUnits(payment.CapturedAmount, payment.Currency) {
+			// Partially refunded payments stay captured
+			to = StatusCaptured
+		}
+	}
+	payment.Status = to
+}
+
+// syncOrder moves a payment's order to match the payment: a captured payment
+// pays the order, and a voided or declined payment cancels it
+func (s *Service) syncOrder(ctx context.Context, payment *Payment) error {
+	var err error
+	switch payment.Status {
+	case StatusCaptured:
+		err = s.orders.MarkPaid(ctx, payment.OrderID)
+	case StatusVoided, StatusDeclined:
+		err = s.orders.Cancel(ctx, payment.OrderID)
+	default:
+		return nil
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to update order for payment", map[string]interface{}{"error": err.Error(), "payment_id": payment.ID, "order_id": payment.OrderID, "status": payment.Status})
+	}
+	return err
+}
+
+// verifyHMAC reports whether hexSignature is the HMAC-SHA256 of payload with secret
+func verifyHMAC(payload []byte, secret, hexSignature string) bool {
+	signature, err := hex.DecodeString(hexSignature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), signature)
+}
@@ -0,0 +1,151 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// deliver posts a sandbox webhook event for provider and returns the response code
+func (f *serviceFixture) deliver(t *testing.T, provider, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/"+provider, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	f.service.WebhookHandler().ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestWebhook_CapturedPaysOrderOnce(t *testing.T) {
+	f := setupService(t)
+	authorized := f.authorize(t, validAuthorization())
+
+	body := `{"id": "evt_1", "type": "captured", "payment_ref": "` + authorized.ProviderPaymentId + `", "capture_ref": "cap_1", "amount": 2550}`
+	for i := 0; i < 2; i++ {
+		if code := f.deliver(t, ProviderStripe, body); code != http.StatusOK {
+			t.Fatalf("Delivery %d: expected 200, got %d", i+1, code)
+		}
+	}
+
+	payment, _ := f.repo.GetByID(context.Background(), authorized.Id)
+	if payment.Status != StatusCaptured || payment.CapturedAmount != 25.5 || payment.ProviderCaptureID != "cap_1" {
+		t.Errorf("Unexpected payment %+v", payment)
+	}
+	if calls := f.orders.Calls(); !reflect.DeepEqual(calls, []string{"paid order-1"}) {
+		t.Errorf("Expected the order to be paid once, got %v", calls)
+	}
+}
+
+func TestWebhook_FailedCancelsOrder(t *testing.T) {
+	f := setupService(t)
+	authorized := f.authorize(t, validAuthorization())
+
+	body := `{"id": "evt_1", "type": "failed", "payment_ref": "` + authorized.ProviderPaymentId + `", "reason": "expired"}`
+	if code := f.deliver(t, ProviderStripe, body); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+
+	payment, _ := f.repo.GetByID(context.Background(), authorized.Id)
+	if payment.Status != StatusDeclined || payment.FailureReason != "expired" {
+		t.Errorf("Unexpected payment %+v", payment)
+	}
+	if calls := f.orders.Calls(); !reflect.DeepEqual(calls, []string{"cancel order-1"}) {
+		t.Errorf("Expected the order to be cancelled, got %v", calls)
+	}
+}
+
+func TestWebhook_PartialRefundStaysCaptured(t *testing.T) {
+	f := setupService(t)
+	authorized := f.authorize(t, validAuthorization())
+	ref := authorized.ProviderPaymentId
+
+	f.deliver(t, ProviderStripe, `{"id": "evt_1", "type": "captured", "payment_ref": "`+ref+`"}`)
+	f.deliver(t, ProviderStripe, `{"id": "evt_2", "type": "refunded", "payment_ref": "`+ref+`", "amount": 1000}`)
+
+	payment, _ := f.repo.GetByID(context.Background(), authorized.Id)
+	if payment.Status != StatusCaptured || payment.RefundedAmount != 10 {
+		t.Errorf("Expected a partially refunded captured payment, got %+v", payment)
+	}
+
+	f.deliver(t, ProviderStripe, `{"id": "evt_3", "type": "refunded", "payment_ref": "`+ref+`", "amount": 2550}`)
+	payment, _ = f.repo.GetByID(context.Background(), authorized.Id)
+	if payment.Status != StatusRefunded || payment.RefundedAmount != 25.5 {
+		t.Errorf("Expected a refunded payment, got %+v", payment)
+	}
+}
+
+func TestWebhook_StaleEventLeavesPayment(t *testing.T) {
+	f := setupService(t)
+	authorized := f.authorize(t, validAuthorization())
+	ref := authorized.ProviderPaymentId
+
+	f.deliver(t, ProviderStripe, `{"id": "evt_2", "type": "voided", "payment_ref": "`+ref+`"}`)
+	if code := f.deliver(t, ProviderStripe, `{"id": "evt_1", "type": "authorized", "payment_ref": "`+ref+`"}`); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+
+	payment, _ := f.repo.GetByID(context.Background(), authorized.Id)
+	if payment.Status != StatusVoided {
+		t.Errorf("Expected the payment to stay VOIDED, got %s", payment.Status)
+	}
+}
+
+func TestWebhook_FailedEventIsRetried(t *testing.T) {
+	f := setupService(t)
+	authorized := f.authorize(t, validAuthorization())
+	body := `{"id": "evt_1", "type": "captured", "payment_ref": "` + authorized.ProviderPaymentId + `"}`
+
+	f.orders.err = errors.New("order service unavailable")
+	if code := f.deliver(t, ProviderStripe, body); code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", code)
+	}
+
+	f.orders.err = nil
+	if code := f.deliver(t, ProviderStripe, body); code != http.StatusOK {
+		t.Fatalf("Expected 200 on retry, got %d", code)
+	}
+	if calls := f.orders.Calls(); !reflect.DeepEqual(calls, []string{"paid order-1"}) {
+		t.Errorf("Expected the retry to pay the order, got %v", calls)
+	}
+}
+
+func TestWebhook_Rejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		body     string
+		expected int
+	}{
+		{"unknown provider", "square", `{"id": "evt_1", "type": "captured"}`, http.StatusNotFound},
+		{"invalid event", ProviderStripe, `not json`, http.StatusBadRequest},
+		{"unknown payment", ProviderStripe, `{"id": "evt_1", "type": "captured", "payment_ref": "pi_missing"}`, http.StatusNotFound},
+		{"ignored event", ProviderStripe, `{"id": "evt_1", "type": "customer.created"}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			if code := f.deliver(t, tt.provider, tt.body); code != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, code)
+			}
+			if len(f.repo.events) != 0 {
+				t.Errorf("Expected no event to be recorded, got %v", f.repo.events)
+			}
+		})
+	}
+}
+
+func TestWebhook_InvalidSignature(t *testing.T) {
+	stripe, _ := NewStripeProvider(StripeConfig{SecretKey: "sk_test", WebhookSecret: "whsec_test"})
+	router, _ := NewRouter([]Provider{stripe}, nil, ProviderStripe)
+	f := &serviceFixture{repo: newMemoryRepository(), orders: &fakeOrders{}, inventory: &fakeInventory{}, fraud: &fakeFraudChecks{}}
+	f.service = NewService(f.repo, router, f.orders, f.inventory, f.fraud, logger.New("payment-test"))
+
+	if code := f.deliver(t, ProviderStripe, `{"id": "evt_1"}`); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", code)
+	}
+}
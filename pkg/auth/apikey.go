@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultAPIKeyStorePrefix is the prefix of the API key records shared by
+// all services
+const DefaultAPIKeyStorePrefix = "auth:apikey:"
+
+const (
+	apiKeyIDSize     = 8
+	apiKeySecretSize = 32
+)
+
+var (
+	// ErrInvalidAPIKey is returned for an API key that is malformed, unknown
+	// or whose secret does not match
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	// ErrAPIKeyExpired is returned for an API key past its expiry
+	ErrAPIKeyExpired = errors.New("API key expired")
+	// ErrAPIKeyStoreUnavailable is returned when an API key cannot be looked
+	// up; the key is refused rather than trusted
+	ErrAPIKeyStoreUnavailable = errors.New("API key store unavailable")
+)
+
+// RateLimit is the request budget of an API key, enforced by the service
+// accepting the key, e.g. the gateway. A zero RateLimit is unlimited.
+type RateLimit struct {
+	Requests int           `json:"requests,omitempty"`
+	Window   time.Duration `json:"window,omitempty"`
+}
+
+// APIKey is the record of an API key: everything but its secret, of which
+// only the SHA-256 hash is kept. The key handed out is
+// <prefix>_<id>_<secret>, so a leaked key is recognisable by its prefix and
+// its record is found by ID without scanning hashes.
+type APIKey struct {
+	ID     string `json:"id"`
+	Prefix string `json:"prefix"`
+	Hash   string `json:"hash"`
+	// Owner is the user, vendor or integration the key acts for
+	Owner    string   `json:"owner"`
+	TenantID string   `json:"tenant_id,omitempty"`
+	Scopes   []string `json:"scopes"`
+	// ExpiresAt is when the key stops working; zero never
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	CreatedAt time.Time `json:"created_at"`
+	RateLimit RateLimit `json:"rate_limit,omitzero"`
+}
+
+// GenerateAPIKey creates an API key starting with prefix, e.g. "ek_live", for
+// the owner, tenant, scopes, expiry and rate limit of template. It returns
+// the key, to be shown once, and its record, to be stored.
+func GenerateAPIKey(prefix string, template APIKey) (string, *APIKey, error) {
+	if prefix == "" {
+		return "", nil, errors.New("API key prefix is required")
+	}
+	id := make([]byte, apiKeyIDSize)
+	secret := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, err
+	}
+
+	record := template
+	record.ID = hex.EncodeToString(id)
+	record.Prefix = prefix
+	record.Scopes = slices.Clone(template.Scopes)
+	record.CreatedAt = time.Now().UTC()
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	record.Hash = tokenHash(encoded)
+	return prefix + "_" + record.ID + "_" + encoded, &record, nil
+}
+
+// ParseAPIKey splits a key starting with prefix into its ID and secret
+func ParseAPIKey(prefix, key string) (id, secret string, err error) {
+	rest, ok := strings.CutPrefix(key, prefix+"_")
+	if !ok {
+		return "", "", ErrInvalidAPIKey
+	}
+	// The ID is hex, so the first underscore ends it; the secret may hold more
+	id, secret, ok = strings.Cut(rest, "_")
+	if !ok || len(id) != 2*apiKeyIDSize || secret == "" {
+		return "", "", ErrInvalidAPIKey
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", "", ErrInvalidAPIKey
+	}
+	return id, secret, nil
+}
+
+// Verify checks that key is this API key and has not expired at now. The
+// secret is compared by hash in constant time.
+func (k *APIKey) Verify(key string, now time.Time) error {
+	id, secret, err := ParseAPIKey(k.Prefix, key)
+	if err != nil {
+		return err
+	}
+	hashMatches := subtle.ConstantTimeCompare([]byte(tokenHash(secret)), []byte(k.Hash)) == 1
+	if !hashMatches || id != k.ID {
+		return ErrInvalidAPIKey
+	}
+	if !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt) {
+		return ErrAPIKeyExpired
+	}
+	return nil
+}
+
+// HasScope reports whether the key was granted scope
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// Claims returns the claims of calls made with the key, for ContextWithClaims,
+// so that RequireScope applies to them as to scoped tokens. They carry no
+// role.
+func (k *APIKey) Claims() *Claims {
+	claims := &Claims{
+		UserID:   k.Owner,
+		TenantID: k.TenantID,
+		Scope:    strings.Join(k.Scopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       k.ID,
+			IssuedAt: jwt.NewNumericDate(k.CreatedAt),
+		},
+	}
+	if !k.ExpiresAt.IsZero() {
+		claims.ExpiresAt = jwt.NewNumericDate(k.ExpiresAt)
+	}
+	return claims
+}
+
+// APIKeyStore holds the records of the API keys issued, by key ID
+type APIKeyStore interface {
+	Save(ctx context.Context, key *APIKey) error
+	// Get returns the record of the key id, or nil when there is none
+	Get(ctx context.Context, id string) (*APIKey, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ValidateAPIKey looks up key in store and returns its record when key is
+// valid
+func ValidateAPIKey(ctx context.Context, store APIKeyStore, prefix, key string) (*APIKey, error) {
+	id, _, err := ParseAPIKey(prefix, key)
+	if err != nil {
+		return nil, err
+	}
+	record, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, ErrAPIKeyStoreUnavailable
+	}
+	if record == nil || record.Prefix != prefix {
+		return nil, ErrInvalidAPIKey
+	}
+	if err := record.Verify(key, time.Now()); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// RedisAPIKeyStore keeps each API key record as a JSON Redis key, expiring
+// with the API key when it has an expiry
+type RedisAPIKeyStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisAPIKeyStore creates a store using the keys starting with prefix
+func NewRedisAPIKeyStore(client redis.Cmdable, prefix string) *RedisAPIKeyStore {
+	return &RedisAPIKeyStore{client: client, prefix: prefix}
+}
+
+// Save stores the record of key until it expires
+func (s *RedisAPIKeyStore) Save(ctx context.Context, key *APIKey) error {
+	var ttl time.Duration
+	if !key.ExpiresAt.IsZero() {
+		if ttl = time.Until(key.ExpiresAt); ttl <= 0 {
+			return nil
+		}
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.prefix+key.ID, data, ttl).Err()
+}
+
+// Get returns the record of the key id
+func (s *RedisAPIKeyStore) Get(ctx context.Context, id string) (*APIKey, error) {
+	data, err := s.client.Get(ctx, s.prefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var key APIKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Delete removes the record of the key id, revoking it
+func (s *RedisAPIKeyStore) Delete(ctx context.Context, id string) error {
+	return s.client.Del(ctx, s.prefix+id).Err()
+}
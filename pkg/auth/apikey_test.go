@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, record, err := GenerateAPIKey("ek_live", APIKey{
+		Owner:     "vendor-42",
+		TenantID:  "acme",
+		Scopes:    []string{"webhooks:read", "catalog:product:read"},
+		ExpiresAt: time.Now().Add(time.Hour),
+		RateLimit: RateLimit{Requests: 100, Window: time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(key, "ek_live_"+record.ID+"_") || strings.Contains(record.Hash, key) {
+		t.Fatalf("unexpected key %s for record %+v", key, record)
+	}
+	if record.Owner != "vendor-42" || record.RateLimit.Requests != 100 || !record.HasScope("webhooks:read") || record.HasScope("webhooks:write") {
+		t.Errorf("unexpected record %+v", record)
+	}
+
+	if err := record.Verify(key, time.Now()); err != nil {
+		t.Errorf("expected the key to verify, got %v", err)
+	}
+	if err := record.Verify(key+"x", time.Now()); err != ErrInvalidAPIKey {
+		t.Errorf("expected ErrInvalidAPIKey for another secret, got %v", err)
+	}
+	if err := record.Verify(key, time.Now().Add(2*time.Hour)); err != ErrAPIKeyExpired {
+		t.Errorf("expected ErrAPIKeyExpired, got %v", err)
+	}
+
+	claims := record.Claims()
+	if claims.UserID != "vendor-42" || claims.TenantID != "acme" || !claims.HasScope("catalog:product:read") || claims.Role != "" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	for _, malformed := range []string{"", "ek_test_" + record.ID + "_secret", "ek_live_nothex_secret", "ek_live_" + record.ID, "ek_live_" + record.ID + "_"} {
+		if _, _, err := ParseAPIKey("ek_live", malformed); err != ErrInvalidAPIKey {
+			t.Errorf("%q: expected ErrInvalidAPIKey, got %v", malformed, err)
+		}
+	}
+}
+
+func TestValidateAPIKey(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	store := NewRedisAPIKeyStore(client, DefaultAPIKeyStorePrefix)
+	ctx := context.Background()
+
+	key, record, _ := GenerateAPIKey("ek_live", APIKey{Owner: "vendor-42", Scopes: []string{"webhooks:read"}, ExpiresAt: time.Now().Add(time.Hour)})
+	if err := store.Save(ctx, record); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ttl := server.TTL(DefaultAPIKeyStorePrefix + record.ID); ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Errorf("expected the record to expire with the key, got TTL %v", ttl)
+	}
+
+	got, err := ValidateAPIKey(ctx, store, "ek_live", key)
+	if err != nil {
+		t.Fatalf("expected the key to be valid, got %v", err)
+	}
+	if got.Owner != "vendor-42" || !got.HasScope("webhooks:read") {
+		t.Errorf("unexpected record %+v", got)
+	}
+
+	// Keys without an expiry are kept until deleted
+	permanent, permanentRecord, _ := GenerateAPIKey("ek_live", APIKey{Owner: "indexer"})
+	store.Save(ctx, permanentRecord)
+	if _, err := ValidateAPIKey(ctx, store, "ek_live", permanent); err != nil {
+		t.Errorf("expected a key without expiry to be valid, got %v", err)
+	}
+
+	// Deleting a key revokes it
+	store.Delete(ctx, record.ID)
+	if _, err := ValidateAPIKey(ctx, store, "ek_live", key); err != ErrInvalidAPIKey {
+		t.Errorf("expected ErrInvalidAPIKey after deletion, got %v", err)
+	}
+
+	// Refused, not trusted, while the store is down
+	server.Close()
+	if _, err := ValidateAPIKey(ctx, store, "ek_live", permanent); err != ErrAPIKeyStoreUnavailable {
+		t.Errorf("expected ErrAPIKeyStoreUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultClientTokenDuration is how long client-credentials tokens are valid
+const DefaultClientTokenDuration = 5 * time.Minute
+
+// clientTokenTimeout bounds a request to the token endpoint
+const clientTokenTimeout = 10 * time.Second
+
+var (
+	// ErrInvalidClient is returned when a client ID is unknown or its secret
+	// does not match
+	ErrInvalidClient = errors.New("invalid client credentials")
+	// ErrInvalidScope is returned when a client requests a scope it was not
+	// granted
+	ErrInvalidScope = errors.New("scope not granted to client")
+)
+
+// ServiceAccount is a machine client allowed to obtain tokens with its
+// client ID and secret
+type ServiceAccount struct {
+	ClientID string `json:"client_id"`
+	// SecretHash is HashClientSecret of the client secret; the secret
+	// itself is only known to the client
+	SecretHash string `json:"secret_hash"`
+	// Scopes are the scopes the client's tokens may carry; at least one, as a
+	// SERVICE token without a scope is only checked against roles
+	Scopes []string `json:"scopes"`
+	// Permissions are the permission patterns of every token of the client
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// ParseServiceAccounts parses a JSON array of service accounts
+func ParseServiceAccounts(data []byte) ([]ServiceAccount, error) {
+	var accounts []ServiceAccount
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, err
+	}
+	for _, a := range accounts {
+		if a.ClientID == "" || a.SecretHash == "" {
+			return nil, errors.New("service account needs a client_id and a secret_hash")
+		}
+		if len(a.Scopes) == 0 {
+			return nil, fmt.Errorf("service account %s needs at least one scope", a.ClientID)
+		}
+		for _, scope := range a.Scopes {
+			if scope == "" || strings.ContainsAny(scope, " \t\n") {
+				return nil, fmt.Errorf("service account %s has an invalid scope %q", a.ClientID, scope)
+			}
+		}
+	}
+	return accounts, nil
+}
+
+// GenerateClientSecret returns a random client secret
+func GenerateClientSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashClientSecret returns the hex SHA-256 of secret, as stored in
+// ServiceAccount.SecretHash. Client secrets are random, so a fast hash is
+// enough; it is the output of `printf %s "$SECRET" | sha256sum`.
+func HashClientSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ClientCredentials issues short-lived SERVICE tokens to service accounts
+// presenting their client ID and secret (the OAuth 2.0 client credentials
+// grant). The token's user ID is the client ID, so calls made with it are
+// attributed to the service account, and its scope limits what it may call.
+type ClientCredentials struct {
+	tokens   *TokenService
+	accounts map[string]ServiceAccount
+	duration time.Duration
+}
+
+// NewClientCredentials creates an issuer of tokens signed by tokens and
+// valid for duration
+func NewClientCredentials(tokens *TokenService, accounts []ServiceAccount, duration time.Duration) *ClientCredentials {
+	c := &ClientCredentials{tokens: tokens, accounts: make(map[string]ServiceAccount, len(accounts)), duration: duration}
+	for _, a := range accounts {
+		c.accounts[a.ClientID] = a
+	}
+	return c
+}
+
+// Issue returns a token for clientID carrying scopes, or every scope of the
+// client when scopes is empty, and the scopes granted
+func (c *ClientCredentials) Issue(clientID, secret string, scopes []string) (string, []string, error) {
+	account, ok := c.accounts[clientID]
+	// The secret is hashed for unknown clients too, so that they take as
+	// long as known ones
+	hash := HashClientSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(account.SecretHash)) != 1 || !ok {
+		return "", nil, ErrInvalidClient
+	}
+
+	if len(scopes) == 0 {
+		scopes = account.Scopes
+	}
+	// A token without a scope would reach every RPC its role allows
+	if len(scopes) == 0 {
+		return "", nil, ErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(account.Scopes, scope) {
+			return "", nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+
+	claims := newClaims("", clientID, "", RoleService, c.duration)
+	claims.Scope = strings.Join(scopes, " ")
+	claims.Permissions = account.Permissions
+	token, err := c.tokens.issue(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, scopes, nil
+}
+
+// tokenResponse is the response of the token endpoint (RFC 6749 5.1)
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// TokenHandler serves the token endpoint of the client credentials grant:
+// a POST with grant_type=client_credentials, an optional space-separated
+// scope, and the client ID and secret in HTTP basic auth or the form fields
+// client_id and client_secret
+func (c *ClientCredentials) TokenHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.PostFormValue("grant_type") != "client_credentials" {
+			writeTokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+			return
+		}
+		clientID, secret, ok := r.BasicAuth()
+		if !ok {
+			clientID, secret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+		}
+
+		token, scopes, err := c.Issue(clientID, secret, strings.Fields(r.PostFormValue("scope")))
+		switch {
+		case errors.Is(err, ErrInvalidClient):
+			w.Header().Set("WWW-Authenticate", `Basic realm="token"`)
+			writeTokenError(w, http.StatusUnauthorized, "invalid_client")
+			return
+		case errors.Is(err, ErrInvalidScope):
+			writeTokenError(w, http.StatusBadRequest, "invalid_scope")
+			return
+		case err != nil:
+			writeTokenError(w, http.StatusInternalServerError, "server_error")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(tokenResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   int64(c.duration / time.Second),
+			Scope:       strings.Join(scopes, " "),
+		})
+	})
+}
+
+// writeTokenError writes an error response of the token endpoint
+// (RFC 6749 5.2)
+func writeTokenError(w http.ResponseWriter, code int, errorCode string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": errorCode})
+}
+
+// NewClientCredentialsTokens creates service tokens obtained from the token
+// endpoint at tokenURL with a service account's client ID and secret, in
+// place of tokens the service signs itself. Empty scopes request every scope
+// of the account.
+func NewClientCredentialsTokens(tokenURL, clientID, clientSecret string, scopes ...string) *ServiceTokens {
+	client := &http.Client{Timeout: clientTokenTimeout}
+	fetch := func() (string, time.Duration, error) {
+		form := url.Values{"grant_type": {"client_credentials"}}
+		if len(scopes) > 0 {
+			form.Set("scope", strings.Join(scopes, " "))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), clientTokenTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", 0, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(clientID, clientSecret)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			var body struct {
+				Error string `json:"error"`
+			}
+			json.NewDecoder(resp.Body).Decode(&body)
+			return "", 0, fmt.Errorf("token endpoint returned %d %s", resp.StatusCode, body.Error)
+		}
+		var body tokenResponse
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return "", 0, err
+		}
+		if body.AccessToken == "" || body.ExpiresIn <= 0 {
+			return "", 0, errors.New("token endpoint returned no token")
+		}
+		return body.AccessToken, time.Duration(body.ExpiresIn) * time.Second, nil
+	}
+	return &ServiceTokens{fetch: fetch, now: time.Now}
+}
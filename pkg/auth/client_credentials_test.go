@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestClientCredentials(t *testing.T) (*ClientCredentials, *TokenService, string) {
+	t.Helper()
+	secret, err := GenerateClientSecret()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	tokens := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+	accounts, err := ParseServiceAccounts([]byte(`[{"client_id": "search-indexer", "secret_hash": "` + HashClientSecret(secret) + `", "scopes": ["catalog:read", "catalog:ratings"]}]`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return NewClientCredentials(tokens, accounts, DefaultClientTokenDuration), tokens, secret
+}
+
+func TestClientCredentials_Issue(t *testing.T) {
+	cc, tokens, secret := newTestClientCredentials(t)
+
+	token, scopes, err := cc.Issue("search-indexer", secret, []string{"catalog:read"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	claims, err := tokens.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.UserID != "search-indexer" || claims.Role != RoleService || claims.Scope != "catalog:read" || len(scopes) != 1 {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if d := claims.ExpiresAt.Sub(claims.IssuedAt.Time); d != DefaultClientTokenDuration {
+		t.Errorf("expected a %v token, got %v", DefaultClientTokenDuration, d)
+	}
+
+	// Without scopes the token carries every scope of the client
+	token, scopes, _ = cc.Issue("search-indexer", secret, nil)
+	if claims, _ := tokens.ValidateToken(token); claims.Scope != "catalog:read catalog:ratings" || len(scopes) != 2 {
+		t.Errorf("expected every scope, got %q", claims.Scope)
+	}
+
+	if _, _, err := cc.Issue("search-indexer", "wrong", nil); err != ErrInvalidClient {
+		t.Errorf("expected ErrInvalidClient, got %v", err)
+	}
+	if _, _, err := cc.Issue("unknown", secret, nil); err != ErrInvalidClient {
+		t.Errorf("expected ErrInvalidClient for an unknown client, got %v", err)
+	}
+	if _, _, err := cc.Issue("search-indexer", secret, []string{"catalog:inventory"}); !errors.Is(err, ErrInvalidScope) {
+		t.Errorf("expected ErrInvalidScope, got %v", err)
+	}
+	if _, err := ParseServiceAccounts([]byte(`[{"client_id": "search-indexer"}]`)); err == nil {
+		t.Error("expected an error for an account without a secret hash")
+	}
+}
+
+func TestParseServiceAccounts_Scopes(t *testing.T) {
+	hash := HashClientSecret("secret")
+	for name, data := range map[string]string{
+		"no scopes":    `[{"client_id": "search-indexer", "secret_hash": "` + hash + `"}]`,
+		"empty scopes": `[{"client_id": "search-indexer", "secret_hash": "` + hash + `", "scopes": []}]`,
+		"blank scope":  `[{"client_id": "search-indexer", "secret_hash": "` + hash + `", "scopes": [""]}]`,
+		"joined scope": `[{"client_id": "search-indexer", "secret_hash": "` + hash + `", "scopes": ["catalog:read catalog:inventory"]}]`,
+	} {
+		if _, err := ParseServiceAccounts([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// An account built without scopes gets no token rather than an unscoped one
+	cc := NewClientCredentials(NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour), []ServiceAccount{{ClientID: "search-indexer", SecretHash: hash}}, DefaultClientTokenDuration)
+	if _, _, err := cc.Issue("search-indexer", "secret", nil); !errors.Is(err, ErrInvalidScope) {
+		t.Errorf("expected ErrInvalidScope, got %v", err)
+	}
+}
+
+func TestClientCredentials_TokenHandler(t *testing.T) {
+	cc, _, secret := newTestClientCredentials(t)
+	handler := cc.TokenHandler()
+	post := func(form url.Values, clientID, clientSecret string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/oauth2/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if clientID != "" {
+			req.SetBasicAuth(clientID, clientSecret)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(url.Values{"grant_type": {"client_credentials"}, "scope": {"catalog:read"}}, "search-indexer", secret)
+	var resp tokenResponse
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &resp) != nil {
+		t.Fatalf("expected 200 with a token, got %d %s", rec.Code, rec.Body)
+	}
+	if resp.AccessToken == "" || resp.TokenType != "Bearer" || resp.ExpiresIn != 300 || resp.Scope != "catalog:read" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	// Credentials may be sent as form fields
+	form := url.Values{"grant_type": {"client_credentials"}, "client_id": {"search-indexer"}, "client_secret": {secret}}
+	if rec := post(form, "", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected 200 with form credentials, got %d %s", rec.Code, rec.Body)
+	}
+
+	tests := []struct {
+		name     string
+		form     url.Values
+		secret   string
+		code     int
+		errorMsg string
+	}{
+		{"wrong secret", url.Values{"grant_type": {"client_credentials"}}, "wrong", http.StatusUnauthorized, "invalid_client"},
+		{"scope not granted", url.Values{"grant_type": {"client_credentials"}, "scope": {"catalog:inventory"}}, secret, http.StatusBadRequest, "invalid_scope"},
+		{"password grant", url.Values{"grant_type": {"password"}}, secret, http.StatusBadRequest, "unsupported_grant_type"},
+	}
+	for _, tt := range tests {
+		rec := post(tt.form, "search-indexer", tt.secret)
+		if rec.Code != tt.code || !strings.Contains(rec.Body.String(), `"error":"`+tt.errorMsg+`"`) {
+			t.Errorf("%s: expected %d %s, got %d %s", tt.name, tt.code, tt.errorMsg, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestNewClientCredentialsTokens(t *testing.T) {
+	cc, tokens, secret := newTestClientCredentials(t)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		cc.TokenHandler().ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	serviceTokens := NewClientCredentialsTokens(server.URL, "search-indexer", secret, "catalog:read")
+	token, err := serviceTokens.Token()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	claims, err := tokens.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.UserID != "search-indexer" || claims.Scope != "catalog:read" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if again, _ := serviceTokens.Token(); again != token || calls != 1 {
+		t.Errorf("expected the token to be reused, got %d requests", calls)
+	}
+
+	if _, err := NewClientCredentialsTokens(server.URL, "search-indexer", "wrong").Token(); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Errorf("expected invalid_client, got %v", err)
+	}
+}
+
+func TestInterceptor_RequiredScopes(t *testing.T) {
+	cc, tokens, secret := newTestClientCredentials(t)
+	interceptor := NewInterceptor(tokens, InterceptorConfig{
+		ExemptMethods: []string{"/"},
+		RequiredRoles: map[string][]string{
+			"/catalog.CatalogService/StreamProducts":        {RoleAdmin, RoleService},
+			"/catalog.CatalogService/UpdateRatingAggregate": {RoleAdmin, RoleService},
+			"/catalog.CatalogService/IncrementStock":        {RoleAdmin, RoleService},
+		},
+		RequiredScopes: map[string][]string{
+			"/catalog.CatalogService/StreamProducts":        {"catalog:read"},
+			"/catalog.CatalogService/UpdateRatingAggregate": {"catalog:ratings"},
+		},
+	})
+	unary := interceptor.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	scoped, _, _ := cc.Issue("search-indexer", secret, []string{"catalog:read"})
+	service, _ := NewServiceTokens(tokens, "review-service").Token()
+
+	tests := []struct {
+		method string
+		token  string
+		want   codes.Code
+	}{
+		{"/catalog.CatalogService/StreamProducts", scoped, codes.OK},
+		{"/catalog.CatalogService/UpdateRatingAggregate", scoped, codes.PermissionDenied},
+		{"/catalog.CatalogService/IncrementStock", scoped, codes.PermissionDenied},
+		{"/catalog.CatalogService/GetProduct", scoped, codes.OK},
+		// Tokens without a scope are only checked against their role
+		{"/catalog.CatalogService/UpdateRatingAggregate", service, codes.OK},
+		{"/catalog.CatalogService/IncrementStock", service, codes.OK},
+	}
+	for _, tt := range tests {
+		if _, err := unary(withToken(tt.token), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler); status.Code(err) != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.method, tt.want, err)
+		}
+	}
+}
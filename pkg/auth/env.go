@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"os"
+	"time"
+)
+
+// DefaultJWTSecret is the JWT_SECRET of local development; deployments set
+// their own
+const DefaultJWTSecret = "your-secret-key-change-in-production"
+
+// Durations of the tokens issued by the account service, which the services
+// sharing its secret sign their SERVICE tokens with as well
+const (
+	AccessTokenDuration  = 15 * time.Minute
+	RefreshTokenDuration = 7 * 24 * time.Hour
+)
+
+// TokenServiceFromEnv creates the token service the services validate access
+// tokens and sign their SERVICE tokens with, configured like the account
+// service's from JWT_SECRET, JWT_ISSUER, JWT_AUDIENCE and JWT_LEEWAY
+func TokenServiceFromEnv() *TokenService {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		secret = DefaultJWTSecret
+	}
+	ts := NewTokenService(secret, AccessTokenDuration, RefreshTokenDuration).
+		WithIssuer(os.Getenv("JWT_ISSUER")).
+		WithAudience(os.Getenv("JWT_AUDIENCE"))
+	if leeway, err := time.ParseDuration(os.Getenv("JWT_LEEWAY")); err == nil && leeway > 0 {
+		ts.WithLeeway(leeway)
+	}
+	return ts
+}
+
+// ServiceTokensFromEnv creates the SERVICE tokens service presents on its
+// calls, signed by TokenServiceFromEnv
+func ServiceTokensFromEnv(service string) *ServiceTokens {
+	return NewServiceTokens(TokenServiceFromEnv(), service)
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenServiceFromEnv(t *testing.T) {
+	t.Setenv("JWT_SECRET", "env-secret")
+	t.Setenv("JWT_ISSUER", "https://account.example.com")
+	t.Setenv("JWT_AUDIENCE", "ecommerce")
+	t.Setenv("JWT_LEEWAY", "30s")
+
+	ts := TokenServiceFromEnv()
+	if ts.leeway != 30*time.Second || ts.accessTokenDuration != AccessTokenDuration {
+		t.Errorf("expected a 30s leeway and the access token duration, got %v and %v", ts.leeway, ts.accessTokenDuration)
+	}
+
+	token, err := ServiceTokensFromEnv("payment-service").Token()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	claims, err := NewTokenService("env-secret", time.Minute, time.Minute).WithIssuer("https://account.example.com").WithAudience("ecommerce").ValidateToken(token)
+	if err != nil {
+		t.Fatalf("expected the service token to carry the issuer and audience, got %v", err)
+	}
+	if claims.UserID != "payment-service" || claims.Role != RoleService {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	t.Setenv("JWT_SECRET", "")
+	if string(TokenServiceFromEnv().secret) != DefaultJWTSecret {
+		t.Error("expected the development secret without JWT_SECRET")
+	}
+}
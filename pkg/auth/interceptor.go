@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the gRPC metadata key carrying the bearer token
+const MetadataKey = "authorization"
+
+// Roles carried in the role claim
+const (
+	RoleUser  = "USER"
+	RoleAdmin = "ADMIN"
+	// RoleService is the role of tokens services present to each other
+	RoleService = "SERVICE"
+)
+
+// Validator validates a token and returns its claims. TokenService
+// implements it.
+type Validator interface {
+	ValidateToken(tokenString string) (*Claims, error)
+}
+
+// ContextValidator is a Validator that can validate a token in the context
+// of a call, e.g. to ask the account service about it for the call's tenant.
+// Verifier implements it.
+type ContextValidator interface {
+	Validator
+	ValidateTokenContext(ctx context.Context, tokenString string) (*Claims, error)
+}
+
+// validate validates tokenString with v in ctx when v supports it
+func validate(ctx context.Context, v Validator, tokenString string) (*Claims, error) {
+	if cv, ok := v.(ContextValidator); ok {
+		return cv.ValidateTokenContext(ctx, tokenString)
+	}
+	return v.ValidateToken(tokenString)
+}
+
+type contextKey struct{}
+
+// ContextWithClaims returns a copy of ctx carrying claims, as the
+// interceptor does for the calls it authenticates
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims the interceptor validated for the
+// call of ctx
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// UserIDFromContext returns the user ID of the validated token of ctx. It is
+// the caller's identity; user IDs in request fields are only claims.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.UserID == "" {
+		return "", false
+	}
+	return claims.UserID, true
+}
+
+// RoleFromContext returns the role of the validated token of ctx
+func RoleFromContext(ctx context.Context) (string, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return claims.Role, true
+}
+
+// TenantFromContext returns the tenant the validated token of ctx binds the
+// call to, "" standing for the default tenant. SERVICE tokens act for every
+// tenant and bind none. It is a tenant.TokenTenant.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.Role == RoleService {
+		return "", false
+	}
+	return claims.TenantID, true
+}
+
+// InterceptorConfig holds the settings of an Interceptor
+type InterceptorConfig struct {
+	// ExemptMethods lists the gRPC full method names callable without a
+	// token, such as Login. Entries ending in "/" match every method below
+	// them.
+	ExemptMethods []string
+	// RequiredRoles maps gRPC full method names to the roles that may call
+	// them; the caller's token must carry one of them. A method listed here
+	// needs a token even when ExemptMethods matches it, so a service can
+	// exempt "/" and list only its protected methods.
+	RequiredRoles map[string][]string
+	// RequiredScopes maps gRPC full method names to the scopes allowing a
+	// scoped token, such as a client-credentials token, to call them. A
+	// scoped token may only call the methods listed here with one of its
+	// scopes, besides exempt ones; tokens without a scope are only checked
+	// against RequiredRoles.
+	RequiredScopes map[string][]string
+	// RequiredPermissions maps gRPC full method names to the permission
+	// they require, evaluated with Policy. Like RequiredRoles, such a method
+	// needs a token even when ExemptMethods matches it.
+	RequiredPermissions map[string]string
+	// Policy evaluates permissions in the calls authenticated, for
+	// RequiredPermissions and CanDo; DefaultPolicy when nil
+	Policy *Policy
+}
+
+// Interceptor authenticates gRPC calls with the bearer token in the
+// authorization metadata and puts its claims in the call context. Calls of
+// exempt methods pass without a token; a valid token still puts its claims
+// in the context, and an invalid one is ignored.
+type Interceptor struct {
+	tokens Validator
+	cfg    InterceptorConfig
+}
+
+// NewInterceptor creates an interceptor validating tokens with tokens
+func NewInterceptor(tokens Validator, cfg InterceptorConfig) *Interceptor {
+	return &Interceptor{tokens: tokens, cfg: cfg}
+}
+
+// IsExempt reports whether method is callable without a token
+func (i *Interceptor) IsExempt(method string) bool {
+	for _, m := range i.cfg.ExemptMethods {
+		if m == method || (strings.HasSuffix(m, "/") && strings.HasPrefix(method, m)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate validates the bearer token of a call of method, checks that
+// it carries a role and permission the method requires, and returns ctx
+// carrying its claims
+func (i *Interceptor) Authenticate(ctx context.Context, method string) (context.Context, error) {
+	token := BearerToken(ctx)
+	if i.cfg.Policy != nil {
+		ctx = ContextWithPolicy(ctx, i.cfg.Policy)
+	}
+	roles, restricted := i.cfg.RequiredRoles[method]
+	permission, needsPermission := i.cfg.RequiredPermissions[method]
+	if !restricted && !needsPermission && i.IsExempt(method) {
+		if token != "" {
+			if claims, err := validate(ctx, i.tokens, token); err == nil {
+				ctx = ContextWithClaims(ctx, claims)
+			}
+		}
+		return ctx, nil
+	}
+
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	claims, err := validate(ctx, i.tokens, token)
+	switch {
+	case errors.Is(err, ErrTokenExpired):
+		return nil, status.Error(codes.Unauthenticated, "token expired")
+	case errors.Is(err, ErrTokenRevoked):
+		return nil, status.Error(codes.Unauthenticated, "token revoked")
+	case errors.Is(err, ErrRevocationUnavailable):
+		return nil, status.Error(codes.Unavailable, "failed to check token revocation")
+	case errors.Is(err, ErrTokenStoreUnavailable):
+		return nil, status.Error(codes.Unavailable, "failed to look up token")
+	case errors.Is(err, ErrVerifierUnavailable):
+		return nil, status.Error(codes.Unavailable, "failed to verify token")
+	case err != nil:
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	ctx = ContextWithClaims(ctx, claims)
+	if restricted {
+		if err := RequireRole(ctx, roles...); err != nil {
+			return nil, err
+		}
+	}
+	if needsPermission {
+		if err := RequirePermission(ctx, permission); err != nil {
+			return nil, err
+		}
+	}
+	if claims.Scope != "" {
+		if err := RequireScope(ctx, i.cfg.RequiredScopes[method]...); err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
+
+// HasScope reports whether the claims' scope includes scope
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(c.Scope), scope)
+}
+
+// RequireScope returns a PermissionDenied error unless the claims of ctx
+// carry one of scopes, and an Unauthenticated error when ctx carries no
+// claims
+func RequireScope(ctx context.Context, scopes ...string) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	for _, scope := range scopes {
+		if claims.HasScope(scope) {
+			return nil
+		}
+	}
+	if len(scopes) == 0 {
+		return status.Error(codes.PermissionDenied, "token scope does not allow this method")
+	}
+	return status.Error(codes.PermissionDenied, "one of scopes "+strings.Join(scopes, ", ")+" required")
+}
+
+// RequireRole returns a PermissionDenied error unless the claims of ctx carry
+// one of roles, and an Unauthenticated error when ctx carries no claims
+func RequireRole(ctx context.Context, roles ...string) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	for _, role := range roles {
+		if claims.Role == role {
+			return nil
+		}
+	}
+	if len(roles) == 1 {
+		return status.Error(codes.PermissionDenied, strings.ToLower(roles[0])+" role required")
+	}
+	return status.Error(codes.PermissionDenied, "one of roles "+strings.Join(roles, ", ")+" required")
+}
+
+// ActingUser returns the user a call of ctx acts for: requested when the
+// token carries one of roles, and otherwise the token's user, refusing a
+// requested user other than it. The user is "" when a caller with one of
+// roles names none.
+func ActingUser(ctx context.Context, requested string, roles ...string) (string, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	if slices.Contains(roles, claims.Role) {
+		return requested, nil
+	}
+	if requested != "" && requested != claims.UserID {
+		return "", status.Error(codes.PermissionDenied, "cannot act for another user")
+	}
+	return claims.UserID, nil
+}
+
+// UnaryServerInterceptor authenticates unary gRPC calls
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.Authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming gRPC calls
+func (i *Interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.Authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream is a server stream whose context carries the caller's claims
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// BearerToken returns the bearer token in the incoming metadata of ctx, or
+// "" when there is none
+func BearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(values[0], "Bearer "))
+}
@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestInterceptor() (*Interceptor, *TokenService) {
+	tokens := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+	return NewInterceptor(tokens, InterceptorConfig{
+		ExemptMethods: []string{"/account.AccountService/Login", "/grpc.health.v1.Health/"},
+	}), tokens
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "Bearer "+token))
+}
+
+func TestInterceptor_Unary(t *testing.T) {
+	interceptor, tokens := newTestInterceptor()
+	unary := interceptor.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/account.AccountService/GetProfile"}
+	var got *Claims
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = ClaimsFromContext(ctx)
+		return "ok", nil
+	}
+
+	token, _ := tokens.GenerateAccessToken("user123", "test@example.com", "USER")
+	resp, err := unary(withToken(token), nil, info, handler)
+	if err != nil || resp != "ok" {
+		t.Fatalf("expected call to pass, got %v, %v", resp, err)
+	}
+	if got == nil || got.UserID != "user123" {
+		t.Errorf("expected claims of user123 in the context, got %+v", got)
+	}
+
+	expired := NewTokenService("test-secret", -time.Minute, time.Hour)
+	expiredToken, _ := expired.GenerateAccessToken("user123", "test@example.com", "USER")
+	forged, _ := NewTokenService("other-secret", 15*time.Minute, time.Hour).GenerateAccessToken("user123", "test@example.com", "ADMIN")
+
+	for name, ctx := range map[string]context.Context{
+		"no metadata": context.Background(),
+		"no token":    metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant-id", "default")),
+		"expired":     withToken(expiredToken),
+		"forged":      withToken(forged),
+	} {
+		if _, err := unary(ctx, nil, info, handler); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %v", name, err)
+		}
+	}
+}
+
+func TestInterceptor_ExemptMethods(t *testing.T) {
+	interceptor, tokens := newTestInterceptor()
+	unary := interceptor.UnaryServerInterceptor()
+	var got *Claims
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = ClaimsFromContext(ctx)
+		return "ok", nil
+	}
+
+	for _, method := range []string{"/account.AccountService/Login", "/grpc.health.v1.Health/Check"} {
+		got = nil
+		if _, err := unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil {
+			t.Errorf("expected %s to pass without a token, got %v", method, err)
+		}
+		if got != nil {
+			t.Errorf("expected no claims for %s, got %+v", method, got)
+		}
+	}
+
+	// An invalid token is ignored on an exempt method, a valid one is used
+	info := &grpc.UnaryServerInfo{FullMethod: "/account.AccountService/Login"}
+	if _, err := unary(withToken("not-a-token"), nil, info, handler); err != nil || got != nil {
+		t.Errorf("expected invalid token to be ignored, got %v, %+v", err, got)
+	}
+	token, _ := tokens.GenerateAccessToken("user123", "test@example.com", "USER")
+	if _, err := unary(withToken(token), nil, info, handler); err != nil || got == nil || got.UserID != "user123" {
+		t.Errorf("expected claims of user123, got %v, %+v", err, got)
+	}
+}
+
+func TestContextHelpers(t *testing.T) {
+	ctx := ContextWithClaims(context.Background(), &Claims{UserID: "user123", Role: RoleAdmin})
+	if id, ok := UserIDFromContext(ctx); !ok || id != "user123" {
+		t.Errorf("expected user123, got %q", id)
+	}
+	if role, ok := RoleFromContext(ctx); !ok || role != RoleAdmin {
+		t.Errorf("expected ADMIN, got %q", role)
+	}
+
+	for name, ctx := range map[string]context.Context{
+		"no claims":  context.Background(),
+		"nil claims": ContextWithClaims(context.Background(), nil),
+	} {
+		if _, ok := UserIDFromContext(ctx); ok {
+			t.Errorf("%s: expected no user ID", name)
+		}
+		if _, ok := RoleFromContext(ctx); ok {
+			t.Errorf("%s: expected no role", name)
+		}
+	}
+	if _, ok := UserIDFromContext(ContextWithClaims(context.Background(), &Claims{Role: RoleService})); ok {
+		t.Error("expected no user ID for claims without one")
+	}
+
+	if id, ok := TenantFromContext(ContextWithClaims(context.Background(), &Claims{Role: RoleUser, TenantID: "acme"})); !ok || id != "acme" {
+		t.Errorf("expected tenant acme, got %q, %v", id, ok)
+	}
+	if _, ok := TenantFromContext(ContextWithClaims(context.Background(), &Claims{Role: RoleService, TenantID: "acme"})); ok {
+		t.Error("expected a SERVICE token to bind no tenant")
+	}
+	if _, ok := TenantFromContext(context.Background()); ok {
+		t.Error("expected no tenant without claims")
+	}
+}
+
+func TestActingUser(t *testing.T) {
+	user := ContextWithClaims(context.Background(), &Claims{UserID: "user123", Role: RoleUser})
+	service := ContextWithClaims(context.Background(), &Claims{UserID: "checkout-service", Role: RoleService})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		requested string
+		want      string
+		code      codes.Code
+	}{
+		{"no claims", context.Background(), "user123", "", codes.Unauthenticated},
+		{"own user", user, "user123", "user123", codes.OK},
+		{"user omitted", user, "", "user123", codes.OK},
+		{"another user", user, "user456", "", codes.PermissionDenied},
+		{"service for a user", service, "user456", "user456", codes.OK},
+		{"service without a user", service, "", "", codes.OK},
+	}
+	for _, tt := range tests {
+		got, err := ActingUser(tt.ctx, tt.requested, RoleAdmin, RoleService)
+		if status.Code(err) != tt.code || got != tt.want {
+			t.Errorf("%s: expected %q and %v, got %q and %v", tt.name, tt.want, tt.code, got, err)
+		}
+	}
+}
+
+// testServerStream is a server stream carrying only a context
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func TestInterceptor_Stream(t *testing.T) {
+	interceptor, tokens := newTestInterceptor()
+	stream := interceptor.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/order.OrderService/WatchOrders", IsServerStream: true}
+	var got *Claims
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got, _ = ClaimsFromContext(ss.Context())
+		return nil
+	}
+
+	if err := stream(nil, &testServerStream{ctx: context.Background()}, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", err)
+	}
+	if got != nil {
+		t.Error("expected handler not to be called")
+	}
+
+	token, _ := tokens.GenerateAccessToken("user123", "test@example.com", "USER")
+	if err := stream(nil, &testServerStream{ctx: withToken(token)}, info, handler); err != nil {
+		t.Fatalf("expected stream to pass, got %v", err)
+	}
+	if got == nil || got.UserID != "user123" {
+		t.Errorf("expected claims of user123 in the stream context, got %+v", got)
+	}
+}
+
+func TestInterceptor_RequiredRoles(t *testing.T) {
+	tokens := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+	interceptor := NewInterceptor(tokens, InterceptorConfig{
+		ExemptMethods: []string{"/"},
+		RequiredRoles: map[string][]string{
+			"/catalog.CatalogService/CreateProduct":  {RoleAdmin},
+			"/catalog.CatalogService/IncrementStock": {RoleAdmin, RoleService},
+		},
+	})
+	unary := interceptor.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	admin, _ := tokens.GenerateAccessToken("admin1", "admin@example.com", RoleAdmin)
+	user, _ := tokens.GenerateAccessToken("user123", "test@example.com", RoleUser)
+	service, _ := NewServiceTokens(tokens, "payment-service").Token()
+
+	tests := []struct {
+		method string
+		ctx    context.Context
+		want   codes.Code
+	}{
+		{"/catalog.CatalogService/GetProduct", context.Background(), codes.OK},
+		{"/catalog.CatalogService/CreateProduct", context.Background(), codes.Unauthenticated},
+		{"/catalog.CatalogService/CreateProduct", withToken(user), codes.PermissionDenied},
+		{"/catalog.CatalogService/CreateProduct", withToken(service), codes.PermissionDenied},
+		{"/catalog.CatalogService/CreateProduct", withToken(admin), codes.OK},
+		{"/catalog.CatalogService/IncrementStock", withToken(user), codes.PermissionDenied},
+		{"/catalog.CatalogService/IncrementStock", withToken(service), codes.OK},
+		{"/catalog.CatalogService/IncrementStock", withToken(admin), codes.OK},
+	}
+	for _, tt := range tests {
+		if _, err := unary(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler); status.Code(err) != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.method, tt.want, err)
+		}
+	}
+}
+
+func TestServiceTokens(t *testing.T) {
+	tokens := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+	serviceTokens := NewServiceTokens(tokens, "payment-service")
+	now := time.Now()
+	serviceTokens.now = func() time.Time { return now }
+
+	first, err := serviceTokens.Token()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	claims, err := tokens.ValidateToken(first)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.UserID != "payment-service" || claims.Role != RoleService {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	expiresAt := serviceTokens.expiresAt
+	now = now.Add(10 * time.Minute)
+	if again, _ := serviceTokens.Token(); again != first || !serviceTokens.expiresAt.Equal(expiresAt) {
+		t.Error("expected the token to be reused")
+	}
+	now = now.Add(4*time.Minute + 30*time.Second)
+	serviceTokens.Token()
+	if !serviceTokens.expiresAt.After(expiresAt) {
+		t.Error("expected the token to be renewed shortly before it expires")
+	}
+
+	// The client interceptor adds the token, unless the call carries one
+	unary := serviceTokens.UnaryClientInterceptor()
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(MetadataKey)
+		return nil
+	}
+	if err := unary(context.Background(), "/catalog.CatalogService/IncrementStock", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sent) != 1 || sent[0] != "Bearer "+serviceTokens.token {
+		t.Errorf("expected the current service token, got %v", sent)
+	}
+	forwarded := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, "Bearer user-token")
+	if err := unary(forwarded, "/catalog.CatalogService/GetProduct", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sent) != 1 || sent[0] != "Bearer user-token" {
+		t.Errorf("expected the forwarded token to be kept, got %v", sent)
+	}
+}
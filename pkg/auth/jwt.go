@@ -1,10 +1,16 @@
 package auth
 
 import (
+	"crypto"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
@@ -12,6 +18,13 @@ var (
 	ErrInvalidToken = errors.New("invalid token")
 	// ErrTokenExpired is returned when JWT token is expired
 	ErrTokenExpired = errors.New("token expired")
+	// ErrKeyInUse is returned when retiring the key new tokens are signed with
+	ErrKeyInUse = errors.New("key is signing new tokens")
+	// ErrInvalidIssuer is returned when a token was issued by another issuer
+	ErrInvalidIssuer = errors.New("token issuer not accepted")
+	// ErrInvalidAudience is returned when a token is not meant for this
+	// audience
+	ErrInvalidAudience = errors.New("token audience not accepted")
 )
 
 // Claims represents JWT token claims
@@ -19,14 +32,69 @@ type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role,omitempty"` // For future RBAC
+	// TenantID is the store the user belongs to. Tokens issued before stores
+	// were introduced leave it empty and belong to the default store.
+	TenantID string `json:"tenant_id,omitempty"`
+	// Scope lists, space-separated, what a client-credentials token may do.
+	// User tokens have none and are limited by their role only.
+	Scope string `json:"scope,omitempty"`
+	// Permissions are the permission patterns granted to the token itself,
+	// on top of those a Policy grants its role
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// TokenService handles JWT token generation and validation
+// TokenService handles JWT token generation and validation. Tokens are
+// signed with an HMAC secret (HS256), or with an RSA or ECDSA key (RS256 or
+// ES256) once a signing key is active, whose public key verifiers fetch as a
+// JWKS instead of sharing the secret. Several signing keys can be held at
+// once, identified by the kid header, so keys are rotated without
+// invalidating the tokens signed with the previous one.
 type TokenService struct {
 	secret               []byte
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
+
+	mu sync.RWMutex
+	// signingKeys sign new tokens in place of the secret; the one activated
+	// last is used
+	signingKeys []signingKey
+	// publicKeys verify RS256 and ES256 tokens by key ID
+	publicKeys map[string]verificationKey
+	// revocations hold the revoked token IDs when set
+	revocations RevocationStore
+	// opaque holds the records of opaque tokens when set; issueOpaque
+	// issues them in place of JWTs
+	opaque      TokenStore
+	issueOpaque bool
+	// pasetoKey decrypts PASETO v4 local tokens when set; issuePASETO
+	// issues them in place of JWTs
+	pasetoKey   []byte
+	issuePASETO bool
+	// issuer and audience are set in the iss and aud claims of new tokens
+	// and required of validated ones when not empty
+	issuer   string
+	audience string
+	// leeway is how far past its expiry a token is still accepted, for
+	// clocks that are slightly apart
+	leeway time.Duration
+}
+
+// signingKey is an asymmetric key tokens are signed with from activeFrom on
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	key        crypto.Signer
+	activeFrom time.Time
+}
+
+// verificationKey is a public key tokens are verified with
+type verificationKey struct {
+	jwk    JWK
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+	// trusted keys were passed to TrustKeys rather than signed with here
+	trusted bool
 }
 
 // NewTokenService creates a new JWT token service
@@ -35,49 +103,213 @@ func NewTokenService(secret string, accessDuration, refreshDuration time.Duratio
 		secret:               []byte(secret),
 		accessTokenDuration:  accessDuration,
 		refreshTokenDuration: refreshDuration,
+		publicKeys:           map[string]verificationKey{},
 	}
 }
 
-// GenerateAccessToken generates a JWT access token
-func (ts *TokenService) GenerateAccessToken(userID, email, role string) (string, error) {
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ts.accessTokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// WithSigningKey signs new tokens with key, RS256 for an RSA key and ES256
+// for a P-256 key, naming it in the kid header. HS256 tokens signed with the
+// secret are still accepted, so sessions issued before the switch and tokens
+// minted by services sharing the secret stay valid; pass an empty secret to
+// NewTokenService to accept only signed tokens.
+func (ts *TokenService) WithSigningKey(key crypto.Signer) (*TokenService, error) {
+	if err := ts.AddSigningKey(key, time.Time{}); err != nil {
+		return nil, err
 	}
+	return ts, nil
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(ts.secret)
+// AddSigningKey publishes key in the JWKS and accepts tokens signed with it
+// right away, and signs new tokens with it from activeFrom on, unless a key
+// with a later activeFrom is active by then. Adding a key again changes its
+// activeFrom.
+//
+// To rotate keys, add the next key with an activeFrom past the time
+// verifiers cache the JWKS, so they know it before the first token signed
+// with it, and retire the previous key with RetireKey once the tokens it
+// signed have expired.
+func (ts *TokenService) AddSigningKey(key crypto.Signer, activeFrom time.Time) error {
+	method, err := signingMethod(key.Public())
+	if err != nil {
+		return err
+	}
+	jwk, err := NewJWK(key.Public())
+	if err != nil {
+		return err
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	signer := signingKey{id: jwk.KeyID, method: method, key: key, activeFrom: activeFrom}
+	for i := range ts.signingKeys {
+		if ts.signingKeys[i].id == jwk.KeyID {
+			ts.signingKeys[i] = signer
+			return nil
+		}
+	}
+	ts.signingKeys = append(ts.signingKeys, signer)
+	ts.publicKeys[jwk.KeyID] = verificationKey{jwk: jwk, method: method, key: key.Public()}
+	return nil
 }
 
-// GenerateRefreshToken generates a JWT refresh token
-func (ts *TokenService) GenerateRefreshToken(userID, email, role string) (string, error) {
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ts.refreshTokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// RetireKey stops accepting tokens signed with the key kid and removes it
+// from the JWKS. The key new tokens are signed with cannot be retired.
+func (ts *TokenService) RetireKey(kid string) error {
+	if current, ok := ts.currentSigner(time.Now()); ok && current.id == kid {
+		return ErrKeyInUse
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(ts.secret)
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	keys := ts.signingKeys[:0]
+	for _, key := range ts.signingKeys {
+		if key.id != kid {
+			keys = append(keys, key)
+		}
+	}
+	ts.signingKeys = keys
+	delete(ts.publicKeys, kid)
+	return nil
+}
+
+// SigningKeyID returns the kid of the key new tokens are signed with, or ""
+// while they are signed with the secret
+func (ts *TokenService) SigningKeyID() string {
+	current, _ := ts.currentSigner(time.Now())
+	return current.id
+}
+
+// currentSigner returns the signing key activated last as of now
+func (ts *TokenService) currentSigner(now time.Time) (signingKey, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	var current signingKey
+	found := false
+	for _, key := range ts.signingKeys {
+		if key.activeFrom.After(now) {
+			continue
+		}
+		if !found || !key.activeFrom.Before(current.activeFrom) {
+			current, found = key, true
+		}
+	}
+	return current, found
+}
+
+// TrustKeys accepts tokens signed with the keys of set, e.g. fetched from the
+// issuing service's JWKS endpoint, so they are verified locally. The set
+// replaces the keys of earlier calls, so fetching the JWKS again picks up
+// added keys and drops retired ones.
+func (ts *TokenService) TrustKeys(set *JWKS) error {
+	keys := make(map[string]verificationKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.KeyID == "" {
+			return errors.New("JWK has no key ID")
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			return err
+		}
+		method, err := signingMethod(key)
+		if err != nil {
+			return err
+		}
+		if jwk.Algorithm != "" && jwk.Algorithm != method.Alg() {
+			return ErrUnsupportedKey
+		}
+		keys[jwk.KeyID] = verificationKey{jwk: jwk, method: method, key: key, trusted: true}
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for id, key := range ts.publicKeys {
+		if key.trusted {
+			delete(ts.publicKeys, id)
+		}
+	}
+	for id, key := range keys {
+		if _, own := ts.publicKeys[id]; own {
+			continue
+		}
+		ts.publicKeys[id] = key
+	}
+	return nil
+}
+
+// JWKS returns the public keys tokens are verified with
+func (ts *TokenService) JWKS() *JWKS {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	set := &JWKS{Keys: make([]JWK, 0, len(ts.publicKeys))}
+	for _, key := range ts.publicKeys {
+		set.Keys = append(set.Keys, key.jwk)
+	}
+	return set
+}
+
+// JWKSHandler serves the public keys as a JWKS, conventionally at
+// /.well-known/jwks.json, for verifiers to pass to TrustKeys
+func (ts *TokenService) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(ts.JWKS())
+	})
+}
+
+// WithIssuer names issuer in the iss claim of new tokens and makes
+// ValidateToken refuse tokens of other issuers, e.g. of another environment.
+// Tokens issued without an issuer are refused as well.
+func (ts *TokenService) WithIssuer(issuer string) *TokenService {
+	ts.issuer = issuer
+	return ts
+}
+
+// WithAudience names audience in the aud claim of new tokens and makes
+// ValidateToken refuse tokens not meant for it. Tokens issued without an
+// audience are refused as well.
+func (ts *TokenService) WithAudience(audience string) *TokenService {
+	ts.audience = audience
+	return ts
+}
+
+// WithLeeway makes ValidateToken accept tokens up to leeway past their
+// expiry, so a token issued by a service whose clock runs slightly ahead or
+// behind is not refused as expired
+func (ts *TokenService) WithLeeway(leeway time.Duration) *TokenService {
+	ts.leeway = leeway
+	return ts
+}
+
+// GenerateAccessToken generates a JWT access token
+func (ts *TokenService) GenerateAccessToken(userID, email, role string) (string, error) {
+	return ts.generate("", userID, email, role, ts.accessTokenDuration)
+}
+
+// GenerateRefreshToken generates a JWT refresh token
+func (ts *TokenService) GenerateRefreshToken(userID, email, role string) (string, error) {
+	return ts.generate("", userID, email, role, ts.refreshTokenDuration)
 }
 
 // GenerateTokenPair generates both access and refresh tokens
 func (ts *TokenService) GenerateTokenPair(userID, email, role string) (accessToken, refreshToken string, err error) {
-	accessToken, err = ts.GenerateAccessToken(userID, email, role)
+	return ts.GenerateTenantTokenPair("", userID, email, role)
+}
+
+// GenerateTenantTokenPair generates both access and refresh tokens for a user
+// of tenantID
+func (ts *TokenService) GenerateTenantTokenPair(tenantID, userID, email, role string) (accessToken, refreshToken string, err error) {
+	accessToken, err = ts.generate(tenantID, userID, email, role, ts.accessTokenDuration)
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshToken, err = ts.GenerateRefreshToken(userID, email, role)
+	refreshToken, err = ts.generate(tenantID, userID, email, role, ts.refreshTokenDuration)
 	if err != nil {
 		return "", "", err
 	}
@@ -85,15 +317,80 @@ func (ts *TokenService) GenerateTokenPair(userID, email, role string) (accessTok
 	return accessToken, refreshToken, nil
 }
 
-// ValidateToken parses and validates a JWT token
+// newClaims returns the claims of a token valid for duration
+func newClaims(tenantID, userID, email, role string, duration time.Duration) *Claims {
+	now := time.Now()
+	return &Claims{
+		UserID:   userID,
+		Email:    email,
+		Role:     role,
+		TenantID: tenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+}
+
+// generate issues a token valid for duration
+func (ts *TokenService) generate(tenantID, userID, email, role string, duration time.Duration) (string, error) {
+	return ts.issue(newClaims(tenantID, userID, email, role, duration))
+}
+
+// issue issues a token carrying claims: an opaque token when opaque tokens
+// are enabled, a PASETO token when PASETO is, or a signed JWT
+func (ts *TokenService) issue(claims *Claims) (string, error) {
+	claims.Issuer = ts.issuer
+	if ts.audience != "" {
+		claims.Audience = jwt.ClaimStrings{ts.audience}
+	}
+	if ts.issueOpaque {
+		return ts.generateOpaque(claims)
+	}
+	if ts.issuePASETO {
+		return ts.generatePASETO(claims)
+	}
+
+	if signer, ok := ts.currentSigner(time.Now()); ok {
+		token := jwt.NewWithClaims(signer.method, claims)
+		token.Header["kid"] = signer.id
+		return token.SignedString(signer.key)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(ts.secret)
+}
+
+// ValidateToken parses and validates a JWT token, or looks up an opaque one
+// when a token store is set or decrypts a PASETO one when a PASETO key is,
+// checks its issuer and audience when set, and checks that it has not been
+// revoked when a revocation store is set
 func (ts *TokenService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
+	if IsOpaque(tokenString) {
+		claims, err := ts.validateOpaque(tokenString)
+		if err != nil {
+			return nil, err
 		}
-		return ts.secret, nil
-	})
+		if err := ts.checkIssuer(claims); err != nil {
+			return nil, err
+		}
+		return claims, nil
+	}
+	if IsPASETO(tokenString) {
+		claims, err := ts.validatePASETO(tokenString)
+		if err != nil {
+			return nil, err
+		}
+		if err := ts.checkIssuer(claims); err != nil {
+			return nil, err
+		}
+		if err := ts.checkRevoked(claims); err != nil {
+			return nil, err
+		}
+		return claims, nil
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, ts.keyFor, jwt.WithLeeway(ts.leeway))
 
 	if err != nil {
 		// Check if it's an expiration error
@@ -107,15 +404,37 @@ func (ts *TokenService) ValidateToken(tokenString string) (*Claims, error) {
 	if !ok || !token.Valid {
 		return nil, ErrInvalidToken
 	}
+	if err := ts.checkIssuer(claims); err != nil {
+		return nil, err
+	}
+	if err := ts.checkRevoked(claims); err != nil {
+		return nil, err
+	}
 
 	return claims, nil
 }
 
+// checkIssuer returns an error unless claims carry the expected issuer and
+// audience
+func (ts *TokenService) checkIssuer(claims *Claims) error {
+	if ts.issuer != "" && claims.Issuer != ts.issuer {
+		return ErrInvalidIssuer
+	}
+	if ts.audience != "" && !slices.Contains(claims.Audience, ts.audience) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
 // GetClaimsFromToken extracts claims without full validation (useful for expired token info)
 func (ts *TokenService) GetClaimsFromToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return ts.secret, nil
-	}, jwt.WithoutClaimsValidation())
+	if IsOpaque(tokenString) {
+		return ts.lookupOpaque(tokenString)
+	}
+	if IsPASETO(tokenString) {
+		return ts.parsePASETO(tokenString)
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, ts.keyFor, jwt.WithoutClaimsValidation())
 
 	if err != nil {
 		return nil, ErrInvalidToken
@@ -128,3 +447,24 @@ func (ts *TokenService) GetClaimsFromToken(tokenString string) (*Claims, error)
 
 	return claims, nil
 }
+
+// keyFor returns the key a token must be signed with: the secret
+// for HS256, or the trusted public key named by its kid header, whose
+// algorithm it must use
+func (ts *TokenService) keyFor(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(ts.secret) == 0 {
+			return nil, ErrInvalidToken
+		}
+		return ts.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	ts.mu.RLock()
+	key, ok := ts.publicKeys[kid]
+	ts.mu.RUnlock()
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, ErrInvalidToken
+	}
+	return key.key, nil
+}
@@ -1,6 +1,14 @@
 package auth
 
 import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -146,6 +154,25 @@ func TestTokenService_ValidateToken_Expired(t *testing.T) {
 	}
 }
 
+func TestTokenService_Leeway(t *testing.T) {
+	// A token expired 10 seconds ago by the issuer's clock
+	issuer := NewTokenService("test-secret", -10*time.Second, time.Hour)
+	token, err := issuer.GenerateAccessToken("user123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	if _, err := NewTokenService("test-secret", time.Minute, time.Hour).ValidateToken(token); err != ErrTokenExpired {
+		t.Errorf("expected ErrTokenExpired without leeway, got %v", err)
+	}
+	if _, err := NewTokenService("test-secret", time.Minute, time.Hour).WithLeeway(30 * time.Second).ValidateToken(token); err != nil {
+		t.Errorf("expected token to be valid within the leeway, got %v", err)
+	}
+	if _, err := NewTokenService("test-secret", time.Minute, time.Hour).WithLeeway(5 * time.Second).ValidateToken(token); err != ErrTokenExpired {
+		t.Errorf("expected ErrTokenExpired past the leeway, got %v", err)
+	}
+}
+
 func TestTokenService_GetClaimsFromToken(t *testing.T) {
 	ts := NewTokenService("test-secret", 1*time.Millisecond, 1*time.Millisecond)
 
@@ -223,6 +250,75 @@ func TestTokenService_RoleInClaims(t *testing.T) {
 	}
 }
 
+func TestTokenService_TenantInClaims(t *testing.T) {
+	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+
+	access, refresh, err := ts.GenerateTenantTokenPair("acme", "user123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("failed to generate tokens: %v", err)
+	}
+	for _, token := range []string{access, refresh} {
+		claims, err := ts.ValidateToken(token)
+		if err != nil {
+			t.Fatalf("failed to validate token: %v", err)
+		}
+		if claims.TenantID != "acme" {
+			t.Errorf("expected tenant 'acme', got '%s'", claims.TenantID)
+		}
+	}
+
+	// Tokens generated without a tenant carry none
+	access, err = ts.GenerateAccessToken("user123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	claims, err := ts.ValidateToken(access)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if claims.TenantID != "" {
+		t.Errorf("expected no tenant, got '%s'", claims.TenantID)
+	}
+}
+
+func TestTokenService_IssuerAndAudience(t *testing.T) {
+	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithIssuer("https://auth.staging.example.com").WithAudience("ecommerce-staging")
+
+	token, err := ts.GenerateAccessToken("user123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	claims, err := ts.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if claims.Issuer != "https://auth.staging.example.com" || len(claims.Audience) != 1 || claims.Audience[0] != "ecommerce-staging" {
+		t.Errorf("expected iss and aud to be set, got %q, %v", claims.Issuer, claims.Audience)
+	}
+
+	tests := []struct {
+		name   string
+		issuer *TokenService
+		want   error
+	}{
+		{"other issuer", NewTokenService("test-secret", time.Minute, time.Hour).WithIssuer("https://auth.example.com").WithAudience("ecommerce-staging"), ErrInvalidIssuer},
+		{"other audience", NewTokenService("test-secret", time.Minute, time.Hour).WithIssuer("https://auth.staging.example.com").WithAudience("ecommerce-production"), ErrInvalidAudience},
+		{"no issuer", NewTokenService("test-secret", time.Minute, time.Hour), ErrInvalidIssuer},
+		{"no audience", NewTokenService("test-secret", time.Minute, time.Hour).WithIssuer("https://auth.staging.example.com"), ErrInvalidAudience},
+	}
+	for _, tt := range tests {
+		token, _ := tt.issuer.GenerateAccessToken("user123", "test@example.com", "USER")
+		if _, err := ts.ValidateToken(token); err != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	// Services without expectations accept the token
+	if _, err := NewTokenService("test-secret", time.Minute, time.Hour).ValidateToken(token); err != nil {
+		t.Errorf("expected token to be valid without expectations, got %v", err)
+	}
+}
+
 func TestTokenService_SigningMethodValidation(t *testing.T) {
 	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
 
@@ -246,3 +342,177 @@ func TestTokenService_SigningMethodValidation(t *testing.T) {
 		t.Errorf("expected ErrInvalidToken for wrong signing method, got %v", err)
 	}
 }
+
+func TestTokenService_SigningKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		key  crypto.Signer
+		alg  string
+	}{
+		{"RSA", rsaKey, "RS256"},
+		{"ECDSA", ecKey, "ES256"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer, err := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithSigningKey(tt.key)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			token, err := issuer.GenerateAccessToken("user123", "test@example.com", "USER")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			if err != nil {
+				t.Fatalf("failed to parse token: %v", err)
+			}
+			if parsed.Method.Alg() != tt.alg || parsed.Header["kid"] != issuer.JWKS().Keys[0].KeyID {
+				t.Errorf("expected %s with the key's kid, got %v", tt.alg, parsed.Header)
+			}
+
+			// A verifier holding only the JWKS, not the secret
+			verifier := NewTokenService("", 15*time.Minute, 7*24*time.Hour)
+			if err := verifier.TrustKeys(issuer.JWKS()); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			claims, err := verifier.ValidateToken(token)
+			if err != nil {
+				t.Fatalf("expected valid token, got error: %v", err)
+			}
+			if claims.UserID != "user123" {
+				t.Errorf("expected UserID 'user123', got '%s'", claims.UserID)
+			}
+
+			// The issuer still accepts tokens signed with the secret
+			legacy, _ := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).GenerateAccessToken("user123", "test@example.com", "USER")
+			if _, err := issuer.ValidateToken(legacy); err != nil {
+				t.Errorf("expected HS256 token to be accepted by the issuer, got %v", err)
+			}
+			if _, err := verifier.ValidateToken(legacy); err != ErrInvalidToken {
+				t.Errorf("expected ErrInvalidToken for HS256 without a secret, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTokenService_UntrustedKey(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	issuer, _ := NewTokenService("", 15*time.Minute, 7*24*time.Hour).WithSigningKey(key)
+	impostor, _ := NewTokenService("", 15*time.Minute, 7*24*time.Hour).WithSigningKey(other)
+	token, _ := impostor.GenerateAccessToken("user123", "test@example.com", "ADMIN")
+
+	if _, err := issuer.ValidateToken(token); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for an untrusted key, got %v", err)
+	}
+
+	// A token claiming the trusted kid but signed by another key
+	claims := &Claims{UserID: "user123", RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}}
+	forged := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	forged.Header["kid"] = issuer.JWKS().Keys[0].KeyID
+	forgedString, _ := forged.SignedString(other)
+	if _, err := issuer.ValidateToken(forgedString); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for a forged kid, got %v", err)
+	}
+}
+
+func TestTokenService_KeyRotation(t *testing.T) {
+	previous, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	next, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	issuer, _ := NewTokenService("", 15*time.Minute, 7*24*time.Hour).WithSigningKey(previous)
+	previousID := issuer.SigningKeyID()
+	oldToken, _ := issuer.GenerateAccessToken("user123", "test@example.com", "USER")
+
+	// The next key is published before it signs anything
+	if err := issuer.AddSigningKey(next, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if issuer.SigningKeyID() != previousID {
+		t.Errorf("expected the previous key to sign until the next one is active")
+	}
+	if len(issuer.JWKS().Keys) != 2 {
+		t.Fatalf("expected both keys in the JWKS, got %d", len(issuer.JWKS().Keys))
+	}
+	verifier := NewTokenService("", 15*time.Minute, 7*24*time.Hour)
+	if err := verifier.TrustKeys(issuer.JWKS()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Once active, the next key signs new tokens and both are accepted
+	if err := issuer.AddSigningKey(next, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	nextID := issuer.SigningKeyID()
+	if nextID == previousID || nextID == "" {
+		t.Fatalf("expected the next key to sign, got %q", nextID)
+	}
+	newToken, _ := issuer.GenerateAccessToken("user123", "test@example.com", "USER")
+	parsed, _, _ := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	if parsed.Header["kid"] != nextID || parsed.Method.Alg() != "RS256" {
+		t.Errorf("expected RS256 with kid %s, got %v", nextID, parsed.Header)
+	}
+	for _, ts := range []*TokenService{issuer, verifier} {
+		for _, token := range []string{oldToken, newToken} {
+			if _, err := ts.ValidateToken(token); err != nil {
+				t.Errorf("expected valid token during rotation, got %v", err)
+			}
+		}
+	}
+
+	if err := issuer.RetireKey(nextID); err != ErrKeyInUse {
+		t.Errorf("expected ErrKeyInUse, got %v", err)
+	}
+
+	// Retiring the previous key drops it from the issuer and, on the next
+	// fetch, from verifiers
+	if err := issuer.RetireKey(previousID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := verifier.TrustKeys(issuer.JWKS()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, ts := range []*TokenService{issuer, verifier} {
+		if _, err := ts.ValidateToken(oldToken); err != ErrInvalidToken {
+			t.Errorf("expected ErrInvalidToken for a retired key, got %v", err)
+		}
+		if _, err := ts.ValidateToken(newToken); err != nil {
+			t.Errorf("expected valid token, got %v", err)
+		}
+	}
+}
+
+func TestTokenService_JWKSHandler(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	issuer, _ := NewTokenService("", 15*time.Minute, 7*24*time.Hour).WithSigningKey(key)
+
+	server := httptest.NewServer(issuer.JWKSHandler())
+	defer server.Close()
+
+	set, err := FetchJWKS(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(set.Keys) != 1 || set.Keys[0].KeyType != "RSA" || set.Keys[0].Algorithm != "RS256" || set.Keys[0].Use != "sig" {
+		t.Errorf("unexpected key set %+v", set)
+	}
+
+	resp, err := http.Post(server.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", resp.StatusCode)
+	}
+}
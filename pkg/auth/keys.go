@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnsupportedKey is returned for keys other than RSA keys of at least 2048
+// bits and ECDSA P-256 keys
+var ErrUnsupportedKey = errors.New("unsupported key: use RSA (2048 bits or more) or ECDSA P-256")
+
+// minRSABits is the smallest RSA key accepted for signing or verification
+const minRSABits = 2048
+
+// JWK is a public key in JSON Web Key form (RFC 7517). Only the members of
+// RSA and P-256 signing keys are supported.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	// N and E are the modulus and exponent of an RSA key
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve, X and Y are the curve and coordinates of an EC key
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, the document verifiers fetch the public keys
+// of a token issuer from
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK describes a public signing key. Its key ID is the key's RFC 7638
+// thumbprint, so the same key always has the same ID.
+func NewJWK(key crypto.PublicKey) (JWK, error) {
+	var jwk JWK
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSABits {
+			return JWK{}, ErrUnsupportedKey
+		}
+		jwk = JWK{
+			KeyType:   "RSA",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			N:         encodeSegment(k.N.Bytes()),
+			E:         encodeSegment(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return JWK{}, ErrUnsupportedKey
+		}
+		point, err := k.ECDH()
+		if err != nil {
+			return JWK{}, ErrUnsupportedKey
+		}
+		// An uncompressed point is 0x04 followed by X and Y, 32 bytes each
+		raw := point.Bytes()
+		jwk = JWK{
+			KeyType:   "EC",
+			Algorithm: jwt.SigningMethodES256.Alg(),
+			Curve:     "P-256",
+			X:         encodeSegment(raw[1:33]),
+			Y:         encodeSegment(raw[33:]),
+		}
+	default:
+		return JWK{}, ErrUnsupportedKey
+	}
+	jwk.Use = "sig"
+	jwk.KeyID = jwk.thumbprint()
+	return jwk, nil
+}
+
+// thumbprint returns the RFC 7638 thumbprint of the key: the SHA-256 of its
+// required members in lexicographic order
+func (k JWK) thumbprint() string {
+	var members string
+	if k.KeyType == "RSA" {
+		members = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, k.E, k.N)
+	} else {
+		members = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, k.Curve, k.X, k.Y)
+	}
+	sum := sha256.Sum256([]byte(members))
+	return encodeSegment(sum[:])
+}
+
+// PublicKey decodes the key
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, err
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if key.N.BitLen() < minRSABits || key.E < 3 {
+			return nil, ErrUnsupportedKey
+		}
+		return key, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, ErrUnsupportedKey
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeSegment(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("%w: P-256 coordinates must be 32 bytes", ErrUnsupportedKey)
+		}
+		// Parsing the point rejects one that is not on the curve
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid EC key: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// signingMethod returns the JWT algorithm of a supported public key: RS256
+// for RSA and ES256 for P-256
+func signingMethod(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() >= minRSABits {
+			return jwt.SigningMethodRS256, nil
+		}
+	case *ecdsa.PublicKey:
+		if k.Curve == elliptic.P256() {
+			return jwt.SigningMethodES256, nil
+		}
+	}
+	return nil, ErrUnsupportedKey
+}
+
+// ParsePrivateKeyPEM parses a PEM-encoded RSA or ECDSA P-256 private key in
+// PKCS #8, PKCS #1 or SEC 1 form, e.g. from openssl genpkey
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+	if _, err := signingMethod(signer.Public()); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// FetchJWKS reads the key set a token issuer serves at url
+func FetchJWKS(ctx context.Context, client *http.Client, url string) (*JWKS, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: %s", resp.Status)
+	}
+
+	var set JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+	return &set, nil
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK member: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+func TestJWK_RoundTrip(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	for _, key := range []interface{ Equal(x crypto.PublicKey) bool }{&rsaKey.PublicKey, &ecKey.PublicKey} {
+		jwk, err := NewJWK(key)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		again, _ := NewJWK(key)
+		if jwk.KeyID == "" || jwk.KeyID != again.KeyID {
+			t.Errorf("expected a stable key ID, got %q and %q", jwk.KeyID, again.KeyID)
+		}
+
+		decoded, err := jwk.PublicKey()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !key.Equal(decoded) {
+			t.Errorf("expected %s key to survive encoding", jwk.KeyType)
+		}
+	}
+}
+
+func TestJWK_Unsupported(t *testing.T) {
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	for _, key := range []interface{}{&small.PublicKey, &p384.PublicKey, "not a key"} {
+		if _, err := NewJWK(key); !errors.Is(err, ErrUnsupportedKey) {
+			t.Errorf("expected ErrUnsupportedKey for %T, got %v", key, err)
+		}
+	}
+
+	offCurve := JWK{KeyType: "EC", Curve: "P-256", X: encodeSegment(make([]byte, 32)), Y: encodeSegment(make([]byte, 32))}
+	if _, err := offCurve.PublicKey(); err == nil {
+		t.Error("expected an error for a point not on the curve")
+	}
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(ecKey)
+	sec1, _ := x509.MarshalECPrivateKey(ecKey)
+
+	for _, block := range []*pem.Block{
+		{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+		{Type: "PRIVATE KEY", Bytes: pkcs8},
+		{Type: "EC PRIVATE KEY", Bytes: sec1},
+	} {
+		if _, err := ParsePrivateKeyPEM(pem.EncodeToMemory(block)); err != nil {
+			t.Errorf("expected %s to parse, got %v", block.Type, err)
+		}
+	}
+
+	if _, err := ParsePrivateKeyPEM([]byte("not pem")); err == nil {
+		t.Error("expected an error for data without a PEM block")
+	}
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// testCA issues SPIFFE certificates for tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+// issue writes a certificate for id to a temporary directory and returns
+// the certificate and key files
+func (ca *testCA) issue(t *testing.T, id string) (certFile, keyFile string) {
+	t.Helper()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	uri, _ := url.Parse(id)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{uri},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	return certFile, keyFile
+}
+
+func (ca *testCA) mtls(t *testing.T, id string, allowed ...string) *MTLS {
+	t.Helper()
+	certFile, keyFile := ca.issue(t, id)
+	m, err := NewMTLS(MTLSConfig{CertFile: certFile, KeyFile: keyFile, CAFile: ca.file, AllowedPeers: allowed})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return m
+}
+
+// handshake connects client to server over loopback and returns the
+// server's view of the connection and the client's error
+func handshake(t *testing.T, server, client *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	done := make(chan tls.ConnectionState, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			done <- tls.ConnectionState{}
+			return
+		}
+		defer conn.Close()
+		tlsConn := tls.Server(conn, server)
+		tlsConn.Handshake()
+		done <- tlsConn.ConnectionState()
+	}()
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tlsConn := tls.Client(conn, client)
+	err = tlsConn.Handshake()
+	if err == nil {
+		// The server verifies the client after the client's handshake is done
+		tlsConn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		tlsConn.Read(make([]byte, 1))
+	}
+	conn.Close()
+	return <-done, err
+}
+
+func TestMTLS_Handshake(t *testing.T) {
+	ca := newTestCA(t)
+	catalog := ca.mtls(t, "spiffe://ecommerce.local/catalog-service", "payment-service")
+	payment := ca.mtls(t, "spiffe://ecommerce.local/payment-service")
+
+	if id := catalog.ID(); id != "spiffe://ecommerce.local/catalog-service" {
+		t.Errorf("expected the catalog SPIFFE ID, got %q", id)
+	}
+	state, err := handshake(t, catalog.serverConfig(), payment.clientConfig(payment.ServiceID("catalog-service")))
+	if err != nil {
+		t.Fatalf("expected handshake to succeed, got %v", err)
+	}
+	if len(state.PeerCertificates) == 0 {
+		t.Fatal("expected the client certificate on the server")
+	}
+	if id, _ := SPIFFEID(state.PeerCertificates[0]); id != "spiffe://ecommerce.local/payment-service" {
+		t.Errorf("expected the payment SPIFFE ID, got %q", id)
+	}
+
+	// The client refuses a server presenting another identity
+	if _, err := handshake(t, catalog.serverConfig(), payment.clientConfig(payment.ServiceID("order-service"))); err == nil {
+		t.Error("expected handshake with the wrong server identity to fail")
+	}
+
+	// The server refuses clients it does not allow
+	review := ca.mtls(t, "spiffe://ecommerce.local/review-service")
+	if state, _ := handshake(t, catalog.serverConfig(), review.clientConfig(review.ServiceID("catalog-service"))); state.HandshakeComplete {
+		t.Error("expected a client outside AllowedPeers to be refused")
+	}
+
+	// Certificates of another CA or trust domain are refused
+	rogue := newTestCA(t).mtls(t, "spiffe://ecommerce.local/payment-service")
+	if state, _ := handshake(t, catalog.serverConfig(), rogue.clientConfig(rogue.ServiceID("catalog-service"))); state.HandshakeComplete {
+		t.Error("expected a certificate of another CA to be refused")
+	}
+	foreign := ca.mtls(t, "spiffe://example.org/payment-service")
+	open := ca.mtls(t, "spiffe://ecommerce.local/catalog-service")
+	if state, _ := handshake(t, open.serverConfig(), foreign.clientConfig("spiffe://ecommerce.local/catalog-service")); state.HandshakeComplete {
+		t.Error("expected a certificate of another trust domain to be refused")
+	}
+}
+
+func TestMTLS_Reload(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, "spiffe://ecommerce.local/catalog-service")
+	m, err := NewMTLS(MTLSConfig{CertFile: certFile, KeyFile: keyFile, CAFile: ca.file})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// A rotated certificate is picked up without a restart
+	newCert, newKey := ca.issue(t, "spiffe://ecommerce.local/search-service")
+	data, _ := os.ReadFile(newCert)
+	os.WriteFile(certFile, data, 0o600)
+	data, _ = os.ReadFile(newKey)
+	os.WriteFile(keyFile, data, 0o600)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, later, later)
+	if id := m.ID(); id != "spiffe://ecommerce.local/search-service" {
+		t.Errorf("expected the rotated certificate, got %q", id)
+	}
+
+	// A broken file keeps the loaded certificate
+	os.WriteFile(certFile, []byte("garbage"), 0o600)
+	os.Chtimes(certFile, later.Add(time.Minute), later.Add(time.Minute))
+	if id := m.ID(); id != "spiffe://ecommerce.local/search-service" {
+		t.Errorf("expected the loaded certificate to be kept, got %q", id)
+	}
+}
+
+func TestNewMTLS_Disabled(t *testing.T) {
+	m, err := NewMTLS(MTLSConfig{})
+	if m != nil || err != nil {
+		t.Fatalf("expected mTLS to be disabled, got %v, %v", m, err)
+	}
+	if protocol := m.ClientCredentials("catalog-service").Info().SecurityProtocol; protocol != "insecure" {
+		t.Errorf("expected plaintext credentials, got %s", protocol)
+	}
+	if _, err := NewMTLS(MTLSConfig{CertFile: "cert.pem"}); err == nil {
+		t.Error("expected an error without a key and CA file")
+	}
+}
+
+func TestMTLS_RequirePeer(t *testing.T) {
+	ca := newTestCA(t)
+	m := ca.mtls(t, "spiffe://ecommerce.local/catalog-service")
+	certFile, _ := ca.issue(t, "spiffe://ecommerce.local/payment-service")
+	data, _ := os.ReadFile(certFile)
+	block, _ := pem.Decode(data)
+	cert, _ := x509.ParseCertificate(block.Bytes)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+	if id, ok := PeerID(ctx); !ok || id != "spiffe://ecommerce.local/payment-service" {
+		t.Errorf("expected the payment SPIFFE ID, got %q", id)
+	}
+	if err := m.RequirePeer(ctx, "order-service", "payment-service"); err != nil {
+		t.Errorf("expected payment-service to be allowed, got %v", err)
+	}
+	if err := m.RequirePeer(ctx, "spiffe://ecommerce.local/order-service"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	if err := m.RequirePeer(context.Background(), "payment-service"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without mTLS, got %v", err)
+	}
+}
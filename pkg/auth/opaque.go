@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OpaquePrefix starts every opaque token, telling it apart from a JWT
+const OpaquePrefix = "opq_"
+
+// DefaultTokenStorePrefix is the prefix of the opaque token keys shared by
+// all services
+const DefaultTokenStorePrefix = "auth:token:"
+
+// ErrTokenStoreUnavailable is returned when an opaque token cannot be looked
+// up; the token is refused rather than trusted
+var ErrTokenStoreUnavailable = errors.New("token store unavailable")
+
+// TokenStore holds the claims of the opaque tokens issued, by the SHA-256
+// hash of the token so that the store does not hold usable tokens
+type TokenStore interface {
+	// Save stores claims under hash until they expire
+	Save(ctx context.Context, hash string, claims *Claims) error
+	// Get returns the claims stored under hash, or nil when there are none
+	Get(ctx context.Context, hash string) (*Claims, error)
+	Delete(ctx context.Context, hash string) error
+}
+
+// RedisTokenStore keeps the claims of each opaque token as a JSON Redis key
+// that expires with the token
+type RedisTokenStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisTokenStore creates a store using the keys starting with prefix
+func NewRedisTokenStore(client redis.Cmdable, prefix string) *RedisTokenStore {
+	return &RedisTokenStore{client: client, prefix: prefix}
+}
+
+// Save stores claims until they expire
+func (s *RedisTokenStore) Save(ctx context.Context, hash string, claims *Claims) error {
+	if claims.ExpiresAt == nil {
+		return ErrInvalidToken
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.prefix+hash, data, ttl).Err()
+}
+
+// Get returns the claims stored under hash
+func (s *RedisTokenStore) Get(ctx context.Context, hash string) (*Claims, error) {
+	data, err := s.client.Get(ctx, s.prefix+hash).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var claims Claims
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// Delete removes the claims stored under hash
+func (s *RedisTokenStore) Delete(ctx context.Context, hash string) error {
+	return s.client.Del(ctx, s.prefix+hash).Err()
+}
+
+// WithTokenStore makes ValidateToken accept the opaque tokens recorded in
+// store, so a service sharing the store with the issuer validates them
+// without calling it. JWTs are still accepted.
+func (ts *TokenService) WithTokenStore(store TokenStore) *TokenService {
+	ts.opaque = store
+	return ts
+}
+
+// WithOpaqueTokens issues opaque tokens recorded in store in place of JWTs,
+// for deployments where a token must not carry its own claims and must stop
+// working as soon as its record is deleted. JWTs issued before the switch
+// stay valid until they expire.
+func (ts *TokenService) WithOpaqueTokens(store TokenStore) *TokenService {
+	ts.opaque = store
+	ts.issueOpaque = true
+	return ts
+}
+
+// IsOpaque reports whether tokenString is an opaque token rather than a JWT
+func IsOpaque(tokenString string) bool {
+	return strings.HasPrefix(tokenString, OpaquePrefix)
+}
+
+// tokenHash returns the key an opaque token is stored under
+func tokenHash(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateOpaque records claims and returns a random token referring to them
+func (ts *TokenService) generateOpaque(claims *Claims) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := OpaquePrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	ctx, cancel := context.WithTimeout(context.Background(), revocationCheckTimeout)
+	defer cancel()
+	if err := ts.opaque.Save(ctx, tokenHash(token), claims); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// lookupOpaque returns the claims recorded for an opaque token, expired or
+// not
+func (ts *TokenService) lookupOpaque(tokenString string) (*Claims, error) {
+	if ts.opaque == nil {
+		return nil, ErrInvalidToken
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), revocationCheckTimeout)
+	defer cancel()
+	claims, err := ts.opaque.Get(ctx, tokenHash(tokenString))
+	if err != nil {
+		return nil, ErrTokenStoreUnavailable
+	}
+	if claims == nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// validateOpaque returns the claims of an opaque token that has not expired
+func (ts *TokenService) validateOpaque(tokenString string) (*Claims, error) {
+	claims, err := ts.lookupOpaque(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == nil || !time.Now().Before(claims.ExpiresAt.Add(ts.leeway)) {
+		return nil, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+// revokeOpaque deletes the record of an opaque token
+func (ts *TokenService) revokeOpaque(ctx context.Context, tokenString string) error {
+	if _, err := ts.lookupOpaque(tokenString); err != nil {
+		return err
+	}
+	return ts.opaque.Delete(ctx, tokenHash(tokenString))
+}
+
+// Introspection is the response of the token introspection endpoint
+// (RFC 7662). Only Active is set for a token that is not.
+type Introspection struct {
+	Active      bool     `json:"active"`
+	Subject     string   `json:"sub,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Role        string   `json:"role,omitempty"`
+	TenantID    string   `json:"tenant_id,omitempty"`
+	Scope       string   `json:"scope,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	TokenID     string   `json:"jti,omitempty"`
+	IssuedAt    int64    `json:"iat,omitempty"`
+	Expiry      int64    `json:"exp,omitempty"`
+}
+
+// Introspect returns the state of tokenString, opaque or JWT. A token that
+// does not validate is inactive; only a failed lookup returns an error.
+func (ts *TokenService) Introspect(tokenString string) (*Introspection, error) {
+	claims, err := ts.ValidateToken(tokenString)
+	switch {
+	case errors.Is(err, ErrTokenStoreUnavailable), errors.Is(err, ErrRevocationUnavailable):
+		return nil, err
+	case err != nil:
+		return &Introspection{}, nil
+	}
+	return NewIntrospection(claims), nil
+}
+
+// NewIntrospection describes the active token carrying claims
+func NewIntrospection(claims *Claims) *Introspection {
+	in := &Introspection{
+		Active:      true,
+		Subject:     claims.UserID,
+		Email:       claims.Email,
+		Role:        claims.Role,
+		TenantID:    claims.TenantID,
+		Scope:       claims.Scope,
+		Permissions: claims.Permissions,
+		TokenID:     claims.ID,
+	}
+	if claims.IssuedAt != nil {
+		in.IssuedAt = claims.IssuedAt.Unix()
+	}
+	if claims.ExpiresAt != nil {
+		in.Expiry = claims.ExpiresAt.Unix()
+	}
+	return in
+}
+
+// IntrospectionHandler serves token introspection (RFC 7662): a POST with the
+// form field token returns its Introspection. Callers authenticate with a
+// bearer token carrying the SERVICE or ADMIN role, so the endpoint cannot be
+// used to probe stolen tokens.
+func (ts *TokenService) IntrospectionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		caller, err := ts.ValidateToken(strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "invalid caller token", http.StatusUnauthorized)
+			return
+		}
+		if caller.Role != RoleService && caller.Role != RoleAdmin {
+			http.Error(w, "service or admin role required", http.StatusForbidden)
+			return
+		}
+		token := r.PostFormValue("token")
+		if token == "" {
+			http.Error(w, "token is required", http.StatusBadRequest)
+			return
+		}
+
+		in, err := ts.Introspect(token)
+		if err != nil {
+			http.Error(w, "failed to introspect token", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(in)
+	})
+}
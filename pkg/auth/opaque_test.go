@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestTokenStore(t *testing.T) (*RedisTokenStore, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisTokenStore(client, DefaultTokenStorePrefix), server
+}
+
+func TestTokenService_OpaqueTokens(t *testing.T) {
+	store, server := newTestTokenStore(t)
+	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithOpaqueTokens(store)
+
+	access, refresh, err := ts.GenerateTenantTokenPair("acme", "user123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !IsOpaque(access) || strings.Count(access, ".") != 0 {
+		t.Fatalf("expected an opaque token, got %s", access)
+	}
+	claims, err := ts.ValidateToken(access)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.UserID != "user123" || claims.TenantID != "acme" || claims.ID == "" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	// The store holds the hash of the token, expiring with it
+	key := DefaultTokenStorePrefix + tokenHash(access)
+	if !server.Exists(key) {
+		t.Fatalf("expected the record under the token hash, got %v", server.Keys())
+	}
+	if ttl := server.TTL(key); ttl <= 14*time.Minute || ttl > 15*time.Minute {
+		t.Errorf("expected the record to expire with the token, got TTL %v", ttl)
+	}
+	for _, k := range server.Keys() {
+		if strings.Contains(k, access) || strings.Contains(k, refresh) {
+			t.Errorf("expected the store not to hold the token, got key %s", k)
+		}
+	}
+
+	// Services sharing the store validate the token without issuing opaque ones
+	verifier := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithTokenStore(store)
+	if _, err := verifier.ValidateToken(access); err != nil {
+		t.Errorf("expected the token to be valid on another service, got %v", err)
+	}
+	if jwtToken, _ := verifier.GenerateAccessToken("user123", "test@example.com", "USER"); IsOpaque(jwtToken) {
+		t.Error("expected the verifier to keep issuing JWTs")
+	}
+
+	// Revoking deletes the record
+	if err := ts.Revoke(context.Background(), access); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := verifier.ValidateToken(access); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken after revocation, got %v", err)
+	}
+	if _, err := ts.ValidateToken(refresh); err != nil {
+		t.Errorf("expected the refresh token to stay valid, got %v", err)
+	}
+
+	// Unknown opaque tokens and opaque tokens without a store are invalid
+	if _, err := ts.ValidateToken(OpaquePrefix + "unknown"); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken, got %v", err)
+	}
+	if _, err := NewTokenService("test-secret", time.Minute, time.Hour).ValidateToken(refresh); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken without a store, got %v", err)
+	}
+
+	// Refused, not trusted, while the store is down
+	server.Close()
+	if _, err := ts.ValidateToken(refresh); err != ErrTokenStoreUnavailable {
+		t.Errorf("expected ErrTokenStoreUnavailable, got %v", err)
+	}
+}
+
+func TestTokenService_OpaqueTokens_Expired(t *testing.T) {
+	store, _ := newTestTokenStore(t)
+	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithOpaqueTokens(store)
+	token, _ := ts.GenerateAccessToken("user123", "test@example.com", "USER")
+
+	// A record read just before Redis expires it is still refused
+	claims, _ := ts.lookupOpaque(token)
+	claims.ExpiresAt.Time = time.Now().Add(-time.Second)
+	data, _ := json.Marshal(claims)
+	store.client.Set(context.Background(), DefaultTokenStorePrefix+tokenHash(token), data, time.Minute)
+	if _, err := ts.ValidateToken(token); err != ErrTokenExpired {
+		t.Errorf("expected ErrTokenExpired, got %v", err)
+	}
+}
+
+func TestTokenService_IntrospectionHandler(t *testing.T) {
+	store, _ := newTestTokenStore(t)
+	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithOpaqueTokens(store)
+	handler := ts.IntrospectionHandler()
+
+	opaque, _ := ts.GenerateAccessToken("user123", "test@example.com", RoleUser)
+	service, _ := NewServiceTokens(ts, "order-service").Token()
+	introspect := func(caller, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/oauth2/introspect", strings.NewReader(url.Values{"token": {token}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if caller != "" {
+			req.Header.Set("Authorization", "Bearer "+caller)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := introspect(service, opaque)
+	var in Introspection
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &in) != nil {
+		t.Fatalf("expected 200 with an introspection, got %d %s", rec.Code, rec.Body)
+	}
+	if !in.Active || in.Subject != "user123" || in.Role != RoleUser || in.Expiry <= time.Now().Unix() {
+		t.Errorf("unexpected introspection %+v", in)
+	}
+
+	// JWTs are introspected too, invalid tokens are inactive
+	jwtToken, _ := NewTokenService("test-secret", time.Minute, time.Hour).GenerateAccessToken("user456", "", RoleUser)
+	if rec := introspect(service, jwtToken); !strings.Contains(rec.Body.String(), `"sub":"user456"`) {
+		t.Errorf("expected the JWT to be active, got %s", rec.Body)
+	}
+	if rec := introspect(service, "not-a-token"); strings.TrimSpace(rec.Body.String()) != `{"active":false}` {
+		t.Errorf("expected an inactive token, got %s", rec.Body)
+	}
+
+	// Only services and admins may introspect
+	if rec := introspect("", opaque); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a caller token, got %d", rec.Code)
+	}
+	if rec := introspect(opaque, opaque); rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a user caller, got %d", rec.Code)
+	}
+	if rec := introspect(service, ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a token, got %d", rec.Code)
+	}
+	get := httptest.NewRecorder()
+	handler.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/oauth2/introspect", nil))
+	if get.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", get.Code)
+	}
+}
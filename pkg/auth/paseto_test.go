@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPASETOKey is the key of the v4.local test vectors of the PASETO spec
+const testPASETOKey = "707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f"
+
+func TestPASETO_TestVector(t *testing.T) {
+	// Test vector 4-E-1 of the PASETO spec
+	key, _ := hex.DecodeString(testPASETOKey)
+	message := `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`
+	token := "v4.local.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAr68PS4AXe7If_ZgesdkUMvSwscFlAl1pk5HC0e8kApeaqMfGo_7OpBnwJOAbY9V7WU6abu74MmcUE8YWAiaArVI8XJ5hOb_4v9RmDkneN0S92dx0OW4pgy7omxgf3S8c3LlQg"
+
+	if got := pasetoEncrypt(key, make([]byte, pasetoNonceSize), []byte(message)); got != token {
+		t.Errorf("expected the test vector token, got %s", got)
+	}
+	decrypted, err := pasetoDecrypt(key, token)
+	if err != nil || string(decrypted) != message {
+		t.Errorf("expected the test vector message, got %q, %v", decrypted, err)
+	}
+
+	// Any change to the token or the key fails authentication
+	tampered := strings.Replace(token, "r68PS4", "r68PS5", 1)
+	if _, err := pasetoDecrypt(key, tampered); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for a tampered token, got %v", err)
+	}
+	otherKey, _ := hex.DecodeString(strings.Repeat("00", PASETOKeySize))
+	if _, err := pasetoDecrypt(otherKey, token); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for another key, got %v", err)
+	}
+}
+
+func TestTokenService_PASETO(t *testing.T) {
+	key, err := ParsePASETOKey(testPASETOKey)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ts, err := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithIssuer("account-service").WithAudience("ecommerce").WithPASETO(key)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	access, refresh, err := ts.GenerateTenantTokenPair("acme", "user123", "test@example.com", RoleUser)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !IsPASETO(access) || strings.Contains(access, "user123") {
+		t.Fatalf("expected an encrypted PASETO token, got %s", access)
+	}
+	claims, err := ts.ValidateToken(access)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.UserID != "user123" || claims.TenantID != "acme" || claims.Role != RoleUser || claims.ID == "" || claims.Issuer != "account-service" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if d := claims.ExpiresAt.Sub(claims.IssuedAt.Time); d != 15*time.Minute {
+		t.Errorf("expected a 15 minute access token, got %v", d)
+	}
+
+	// Services holding the key validate the token and keep issuing JWTs
+	verifier, _ := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithPASETOKey(key)
+	if _, err := verifier.ValidateToken(refresh); err != nil {
+		t.Errorf("expected the token to be valid on another service, got %v", err)
+	}
+	if jwtToken, _ := verifier.GenerateAccessToken("user123", "test@example.com", RoleUser); IsPASETO(jwtToken) {
+		t.Error("expected the verifier to keep issuing JWTs")
+	}
+
+	// Without the key, or with another audience, the token is refused
+	if _, err := NewTokenService("test-secret", time.Minute, time.Hour).ValidateToken(access); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken without the key, got %v", err)
+	}
+	other, _ := NewTokenService("test-secret", time.Minute, time.Hour).WithAudience("admin").WithPASETOKey(key)
+	if _, err := other.ValidateToken(access); err != ErrInvalidAudience {
+		t.Errorf("expected ErrInvalidAudience, got %v", err)
+	}
+	if _, err := NewTokenService("test-secret", time.Minute, time.Hour).WithPASETO(key[:16]); err != ErrInvalidPASETOKey {
+		t.Errorf("expected ErrInvalidPASETOKey, got %v", err)
+	}
+	if _, err := ParsePASETOKey("not-hex"); err != ErrInvalidPASETOKey {
+		t.Errorf("expected ErrInvalidPASETOKey, got %v", err)
+	}
+}
+
+func TestTokenService_PASETO_ExpiredAndRevoked(t *testing.T) {
+	key, _ := ParsePASETOKey(testPASETOKey)
+	ts, _ := NewTokenService("test-secret", -time.Second, time.Hour).WithPASETO(key)
+	expired, _ := ts.GenerateAccessToken("user123", "test@example.com", RoleUser)
+	if _, err := ts.ValidateToken(expired); err != ErrTokenExpired {
+		t.Errorf("expected ErrTokenExpired, got %v", err)
+	}
+	if _, err := ts.WithLeeway(time.Minute).ValidateToken(expired); err != nil {
+		t.Errorf("expected the token to be accepted within the leeway, got %v", err)
+	}
+	if claims, err := ts.GetClaimsFromToken(expired); err != nil || claims.UserID != "user123" {
+		t.Errorf("expected the claims of the expired token, got %v", err)
+	}
+
+	store, _ := newTestRevocationStore(t)
+	ts.WithRevocationStore(store)
+	refresh, _ := ts.GenerateRefreshToken("user123", "test@example.com", RoleUser)
+	if err := ts.Revoke(context.Background(), refresh); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ts.ValidateToken(refresh); err != ErrTokenRevoked {
+		t.Errorf("expected ErrTokenRevoked, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultPolicy is the policy of calls whose context carries none: ADMIN
+// tokens may do anything, other tokens only what they carry
+var DefaultPolicy = NewPolicy(map[string][]string{
+	RoleAdmin: {"*"},
+})
+
+// Policy evaluates permissions, strings of colon-separated segments naming a
+// resource and an action such as "catalog:product:write". A token is granted
+// the permissions it carries and those its role is granted by the policy, so
+// services check what a caller may do rather than which role it has.
+//
+// Granted permissions are patterns: a "*" segment matches any one segment,
+// and a trailing "*" any number of remaining ones, so "catalog:*:read"
+// grants "catalog:product:read" and "catalog:*" every catalog permission.
+type Policy struct {
+	grants map[string][]string
+}
+
+// NewPolicy creates a policy granting roles the permission patterns of
+// grants
+func NewPolicy(grants map[string][]string) *Policy {
+	p := &Policy{grants: make(map[string][]string, len(grants))}
+	for role, permissions := range grants {
+		p.grants[role] = slices.Clone(permissions)
+	}
+	return p
+}
+
+// Permissions returns the permission patterns granted to claims
+func (p *Policy) Permissions(claims *Claims) []string {
+	return append(slices.Clone(claims.Permissions), p.grants[claims.Role]...)
+}
+
+// Allows reports whether claims are granted permission
+func (p *Policy) Allows(claims *Claims, permission string) bool {
+	for _, pattern := range p.Permissions(claims) {
+		if MatchPermission(pattern, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPermission reports whether the permission pattern grants permission
+func MatchPermission(pattern, permission string) bool {
+	want := strings.Split(permission, ":")
+	have := strings.Split(pattern, ":")
+	for i, segment := range have {
+		if segment == "*" && i == len(have)-1 {
+			return len(want) >= len(have)
+		}
+		if i >= len(want) || (segment != "*" && segment != want[i]) {
+			return false
+		}
+	}
+	return len(have) == len(want)
+}
+
+type policyKey struct{}
+
+// ContextWithPolicy returns a copy of ctx whose permissions are evaluated
+// with p, as the interceptor does with InterceptorConfig.Policy
+func ContextWithPolicy(ctx context.Context, p *Policy) context.Context {
+	return context.WithValue(ctx, policyKey{}, p)
+}
+
+// PolicyFromContext returns the policy of ctx, DefaultPolicy when it carries
+// none
+func PolicyFromContext(ctx context.Context) *Policy {
+	if p, ok := ctx.Value(policyKey{}).(*Policy); ok && p != nil {
+		return p
+	}
+	return DefaultPolicy
+}
+
+// CanDo reports whether the claims of ctx are granted permission by the
+// policy of ctx
+func CanDo(ctx context.Context, permission string) bool {
+	claims, ok := ClaimsFromContext(ctx)
+	return ok && PolicyFromContext(ctx).Allows(claims, permission)
+}
+
+// RequirePermission returns a PermissionDenied error unless the claims of
+// ctx are granted permission, and an Unauthenticated error when ctx carries
+// no claims
+func RequirePermission(ctx context.Context, permission string) error {
+	if _, ok := ClaimsFromContext(ctx); !ok {
+		return status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	if !CanDo(ctx, permission) {
+		return status.Error(codes.PermissionDenied, "permission "+permission+" required")
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMatchPermission(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		permission string
+		want       bool
+	}{
+		{"catalog:product:write", "catalog:product:write", true},
+		{"catalog:product:write", "catalog:product:read", false},
+		{"catalog:product:write", "catalog:product", false},
+		{"catalog:product", "catalog:product:write", false},
+		{"catalog:*:read", "catalog:product:read", true},
+		{"catalog:*:read", "catalog:product:write", false},
+		{"catalog:*:read", "catalog:product:variant:read", false},
+		{"catalog:*", "catalog:product:write", true},
+		{"catalog:*", "catalog:asset", true},
+		{"catalog:*", "catalog", false},
+		{"catalog:*", "order:refund:create", false},
+		{"*", "order:refund:create", true},
+		{"*:*:read", "order:refund:read", true},
+	}
+	for _, tt := range tests {
+		if got := MatchPermission(tt.pattern, tt.permission); got != tt.want {
+			t.Errorf("MatchPermission(%q, %q): expected %v, got %v", tt.pattern, tt.permission, tt.want, got)
+		}
+	}
+}
+
+func TestPolicy_Allows(t *testing.T) {
+	policy := NewPolicy(map[string][]string{
+		RoleAdmin:  {"*"},
+		"MERCHANT": {"catalog:product:*", "catalog:asset:read"},
+	})
+
+	merchant := &Claims{Role: "MERCHANT"}
+	if !policy.Allows(merchant, "catalog:product:write") {
+		t.Error("expected the MERCHANT role to grant catalog:product:write")
+	}
+	if policy.Allows(merchant, "catalog:asset:download") {
+		t.Error("expected the MERCHANT role not to grant catalog:asset:download")
+	}
+
+	user := &Claims{Role: RoleUser, Permissions: []string{"catalog:asset:download"}}
+	if !policy.Allows(user, "catalog:asset:download") {
+		t.Error("expected the token's own permission to be granted")
+	}
+	if policy.Allows(user, "catalog:product:write") {
+		t.Error("expected USER without permissions not to be granted catalog:product:write")
+	}
+	if !policy.Allows(&Claims{Role: RoleAdmin}, "order:refund:create") {
+		t.Error("expected ADMIN to be granted every permission")
+	}
+	if got := policy.Permissions(user); len(got) != 1 || len(user.Permissions) != 1 {
+		t.Errorf("expected the permissions of user only, got %v", got)
+	}
+}
+
+func TestCanDo(t *testing.T) {
+	ctx := context.Background()
+	if CanDo(ctx, "catalog:product:write") {
+		t.Error("expected no permission without claims")
+	}
+	if status.Code(RequirePermission(ctx, "catalog:product:write")) != codes.Unauthenticated {
+		t.Error("expected Unauthenticated without claims")
+	}
+
+	ctx = ContextWithClaims(ctx, &Claims{Role: "MERCHANT"})
+	if CanDo(ctx, "catalog:product:write") {
+		t.Error("expected the default policy to grant MERCHANT nothing")
+	}
+	if status.Code(RequirePermission(ctx, "catalog:product:write")) != codes.PermissionDenied {
+		t.Error("expected PermissionDenied for MERCHANT under the default policy")
+	}
+
+	ctx = ContextWithPolicy(ctx, NewPolicy(map[string][]string{"MERCHANT": {"catalog:product:*"}}))
+	if !CanDo(ctx, "catalog:product:write") {
+		t.Error("expected the policy of ctx to grant MERCHANT catalog:product:write")
+	}
+	if err := RequirePermission(ctx, "catalog:product:write"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestPermissions_TokenRoundTrip(t *testing.T) {
+	key, _ := ParsePASETOKey("707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f")
+	jwtTokens := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+	pasetoTokens, _ := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithPASETO(key)
+
+	for name, tokens := range map[string]*TokenService{"jwt": jwtTokens, "paseto": pasetoTokens} {
+		claims := newClaims("", "user123", "test@example.com", RoleUser, time.Minute)
+		claims.Permissions = []string{"catalog:product:*"}
+		token, err := tokens.issue(claims)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+		got, err := tokens.ValidateToken(token)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+		if len(got.Permissions) != 1 || got.Permissions[0] != "catalog:product:*" {
+			t.Errorf("%s: expected permissions [catalog:product:*], got %v", name, got.Permissions)
+		}
+	}
+}
+
+func TestInterceptor_RequiredPermissions(t *testing.T) {
+	tokens := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+	interceptor := NewInterceptor(tokens, InterceptorConfig{
+		ExemptMethods: []string{"/"},
+		RequiredPermissions: map[string]string{
+			"/catalog.CatalogService/CreateProduct": "catalog:product:write",
+		},
+		Policy: NewPolicy(map[string][]string{
+			RoleAdmin:  {"*"},
+			"MERCHANT": {"catalog:product:*"},
+		}),
+	})
+	unary := interceptor.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		if !CanDo(ctx, "catalog:product:read") {
+			return nil, status.Error(codes.PermissionDenied, "handler check failed")
+		}
+		return "ok", nil
+	}
+
+	merchant, _ := tokens.GenerateAccessToken("vendor1", "vendor@example.com", "MERCHANT")
+	user, _ := tokens.GenerateAccessToken("user123", "test@example.com", RoleUser)
+	granted := newClaims("", "user456", "editor@example.com", RoleUser, time.Minute)
+	granted.Permissions = []string{"catalog:product:write", "catalog:product:read"}
+	editor, _ := tokens.issue(granted)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"no token", context.Background(), codes.Unauthenticated},
+		{"role without permission", withToken(user), codes.PermissionDenied},
+		{"role granted by the policy", withToken(merchant), codes.OK},
+		{"permission carried by the token", withToken(editor), codes.OK},
+	}
+	for _, tt := range tests {
+		_, err := unary(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/catalog.CatalogService/CreateProduct"}, handler)
+		if status.Code(err) != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
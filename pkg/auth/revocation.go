@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRevocationPrefix is the prefix of the revocation keys shared by all
+// services
+const DefaultRevocationPrefix = "auth:revoked:"
+
+// revocationCheckTimeout bounds the revocation lookup of ValidateToken
+const revocationCheckTimeout = time.Second
+
+var (
+	// ErrTokenRevoked is returned when a token was revoked before it expired
+	ErrTokenRevoked = errors.New("token revoked")
+	// ErrRevocationUnavailable is returned when it cannot be checked whether a
+	// token was revoked; the token is refused rather than trusted
+	ErrRevocationUnavailable = errors.New("token revocation list unavailable")
+	// ErrNoTokenID is returned when revoking a token issued without an ID
+	ErrNoTokenID = errors.New("token has no ID")
+	// ErrRevocationNotConfigured is returned when revoking a token without a
+	// revocation store
+	ErrRevocationNotConfigured = errors.New("token revocation is not configured")
+)
+
+// RevocationStore holds the IDs (jti) of revoked tokens
+type RevocationStore interface {
+	// Revoke rejects the token jti until expiresAt, after which it is
+	// rejected as expired anyway
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// RedisRevocationStore keeps each revoked token ID as a Redis key that
+// expires with the token, so the list only holds tokens still to be refused
+type RedisRevocationStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisRevocationStore creates a store using the keys starting with prefix
+func NewRedisRevocationStore(client redis.Cmdable, prefix string) *RedisRevocationStore {
+	return &RedisRevocationStore{client: client, prefix: prefix}
+}
+
+// Revoke stores jti until expiresAt. A token that has already expired is not
+// stored.
+func (s *RedisRevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.client.Set(ctx, s.prefix+jti, expiresAt.Unix(), ttl).Err()
+}
+
+// IsRevoked reports whether jti is stored
+func (s *RedisRevocationStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	n, err := s.client.Exists(ctx, s.prefix+jti).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// WithRevocationStore makes ValidateToken refuse tokens whose ID is in store.
+// Tokens issued before tokens carried an ID cannot be revoked.
+func (ts *TokenService) WithRevocationStore(store RevocationStore) *TokenService {
+	ts.revocations = store
+	return ts
+}
+
+// Revoke rejects tokenString, which must be signed by a key of ts, on every
+// service sharing the revocation store until it expires. An opaque token is
+// revoked by deleting its record, without a revocation store.
+func (ts *TokenService) Revoke(ctx context.Context, tokenString string) error {
+	if IsOpaque(tokenString) {
+		return ts.revokeOpaque(ctx, tokenString)
+	}
+	if ts.revocations == nil {
+		return ErrRevocationNotConfigured
+	}
+	claims, err := ts.GetClaimsFromToken(tokenString)
+	if err != nil {
+		return err
+	}
+	if claims.ID == "" {
+		return ErrNoTokenID
+	}
+	if claims.ExpiresAt == nil {
+		return ErrInvalidToken
+	}
+	return ts.revocations.Revoke(ctx, claims.ID, claims.ExpiresAt.Time)
+}
+
+// checkRevoked returns ErrTokenRevoked when claims belong to a revoked token
+func (ts *TokenService) checkRevoked(claims *Claims) error {
+	if ts.revocations == nil || claims.ID == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), revocationCheckTimeout)
+	defer cancel()
+	revoked, err := ts.revocations.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return ErrRevocationUnavailable
+	}
+	if revoked {
+		return ErrTokenRevoked
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRevocationStore(t *testing.T) (*RedisRevocationStore, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisRevocationStore(client, DefaultRevocationPrefix), server
+}
+
+func TestTokenService_Revoke(t *testing.T) {
+	store, server := newTestRevocationStore(t)
+	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithRevocationStore(store)
+
+	access, refresh, err := ts.GenerateTokenPair("user123", "test@example.com", "USER")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	claims, err := ts.ValidateToken(access)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.ID == "" {
+		t.Fatal("expected the token to have an ID")
+	}
+
+	if err := ts.Revoke(context.Background(), access); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ts.ValidateToken(access); err != ErrTokenRevoked {
+		t.Errorf("expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := ts.ValidateToken(refresh); err != nil {
+		t.Errorf("expected the refresh token to stay valid, got %v", err)
+	}
+
+	// Other services sharing the store refuse the token too
+	other := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithRevocationStore(store)
+	if _, err := other.ValidateToken(access); err != ErrTokenRevoked {
+		t.Errorf("expected ErrTokenRevoked on another service, got %v", err)
+	}
+
+	// The entry expires with the token
+	ttl := server.TTL(DefaultRevocationPrefix + claims.ID)
+	if ttl <= 14*time.Minute || ttl > 15*time.Minute {
+		t.Errorf("expected the entry to expire with the token, got TTL %v", ttl)
+	}
+
+	// Refused, not trusted, while the store is down
+	server.Close()
+	if _, err := ts.ValidateToken(refresh); err != ErrRevocationUnavailable {
+		t.Errorf("expected ErrRevocationUnavailable, got %v", err)
+	}
+}
+
+func TestTokenService_Revoke_Errors(t *testing.T) {
+	store, server := newTestRevocationStore(t)
+	ts := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour)
+	token, _ := ts.GenerateAccessToken("user123", "test@example.com", "USER")
+
+	if err := ts.Revoke(context.Background(), token); err == nil {
+		t.Error("expected an error without a revocation store")
+	}
+
+	ts.WithRevocationStore(store)
+	forged, _ := NewTokenService("other-secret", 15*time.Minute, time.Hour).GenerateAccessToken("user123", "test@example.com", "USER")
+	if err := ts.Revoke(context.Background(), forged); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for a forged token, got %v", err)
+	}
+
+	// Tokens issued before tokens had IDs are still accepted but cannot be revoked
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		UserID:           "user123",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+	})
+	legacyString, _ := legacy.SignedString([]byte("test-secret"))
+	if err := ts.Revoke(context.Background(), legacyString); err != ErrNoTokenID {
+		t.Errorf("expected ErrNoTokenID, got %v", err)
+	}
+	if _, err := ts.ValidateToken(legacyString); err != nil {
+		t.Errorf("expected token without ID to be valid, got %v", err)
+	}
+
+	// Expired tokens are not stored
+	expired, _ := NewTokenService("test-secret", -time.Minute, time.Hour).GenerateAccessToken("user123", "test@example.com", "USER")
+	if err := ts.Revoke(context.Background(), expired); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Errorf("expected no entries, got %v", keys)
+	}
+}
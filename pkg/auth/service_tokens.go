@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// serviceTokenRenewal is how long before it expires a service token is
+// replaced
+const serviceTokenRenewal = time.Minute
+
+// ServiceTokens issues the SERVICE tokens a service presents on its calls to
+// other services, reusing a token until shortly before it expires. Tokens
+// are signed by the service itself, or obtained from a token endpoint with
+// NewClientCredentialsTokens.
+type ServiceTokens struct {
+	// fetch returns a new token and how long it is valid
+	fetch func() (string, time.Duration, error)
+	now   func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewServiceTokens creates service tokens for service, signed by tokens with
+// its access token duration. The service name is the token's user ID.
+func NewServiceTokens(tokens *TokenService, service string) *ServiceTokens {
+	fetch := func() (string, time.Duration, error) {
+		token, err := tokens.GenerateAccessToken(service, "", RoleService)
+		return token, tokens.accessTokenDuration, err
+	}
+	return &ServiceTokens{fetch: fetch, now: time.Now}
+}
+
+// Token returns a SERVICE token valid for at least another minute
+func (s *ServiceTokens) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Add(serviceTokenRenewal).Before(s.expiresAt) {
+		return s.token, nil
+	}
+	token, validFor, err := s.fetch()
+	if err != nil {
+		return "", err
+	}
+	s.token, s.expiresAt = token, now.Add(validFor)
+	return token, nil
+}
+
+// outgoingContext adds a service token to the outgoing metadata of ctx,
+// unless the call already carries a token, e.g. one forwarded for a user
+func (s *ServiceTokens) outgoingContext(ctx context.Context) (context.Context, error) {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx, nil
+	}
+	token, err := s.Token()
+	if err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, "Bearer "+token), nil
+}
+
+// UnaryClientInterceptor presents a service token on unary calls
+func (s *ServiceTokens) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := s.outgoingContext(ctx)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor presents a service token on streaming calls
+func (s *ServiceTokens) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := s.outgoingContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
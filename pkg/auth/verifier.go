@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultVerifierCacheTTL is how long a token verified by the account service
+// is trusted without asking again
+const DefaultVerifierCacheTTL = 30 * time.Second
+
+const (
+	// remoteVerifyTimeout bounds a call to the account service
+	remoteVerifyTimeout = 5 * time.Second
+	// jwksRefetchInterval is how often at most the JWKS is fetched again for
+	// a token signed with an unknown key
+	jwksRefetchInterval = time.Minute
+	// maxCachedTokens bounds the cache of remotely verified tokens
+	maxCachedTokens = 10000
+)
+
+// ErrVerifierUnavailable is returned when a token cannot be verified with the
+// account service; the token is refused rather than trusted
+var ErrVerifierUnavailable = errors.New("token verification unavailable")
+
+// RemoteVerifier verifies a token with the service that issued it. It returns
+// ErrInvalidToken for a token that is not valid and ErrVerifierUnavailable
+// when the service cannot be asked. account.RemoteVerifier asks the account
+// service.
+type RemoteVerifier func(ctx context.Context, tokenString string) (*Claims, error)
+
+// Verifier validates the tokens of the account service for the services that
+// embed it. Tokens are validated locally with the keys of a TokenService when
+// possible: the shared secret, the keys of the account service's JWKS, which
+// is fetched again when a token names an unknown key, and the token store or
+// PASETO key when configured. Other tokens, such as opaque tokens without
+// access to the token store, are verified with the account service, whose
+// answer is cached for a short TTL so that repeated calls with the same token
+// do not each reach it.
+type Verifier struct {
+	local  *TokenService
+	remote RemoteVerifier
+	ttl    time.Duration
+	now    func() time.Time
+
+	jwksClient *http.Client
+	jwksURL    string
+
+	mu          sync.Mutex
+	cache       map[string]verifiedToken
+	jwksFetched time.Time
+}
+
+// verifiedToken is the cached answer of the account service about a token
+type verifiedToken struct {
+	claims *Claims
+	err    error
+	until  time.Time
+}
+
+// NewVerifier creates a verifier validating tokens with local and falling
+// back to remote, whose answers are cached for ttl. remote may be nil to
+// validate locally only.
+func NewVerifier(local *TokenService, remote RemoteVerifier, ttl time.Duration) *Verifier {
+	return &Verifier{
+		local:  local,
+		remote: remote,
+		ttl:    ttl,
+		now:    time.Now,
+		cache:  map[string]verifiedToken{},
+	}
+}
+
+// WithJWKS trusts the keys of the JWKS served at url and fetches it again,
+// at most once a minute, when a token is signed with a key it does not
+// contain, so tokens signed with a newly rotated key validate locally
+func (v *Verifier) WithJWKS(client *http.Client, url string) *Verifier {
+	v.jwksClient = client
+	v.jwksURL = url
+	return v
+}
+
+// ValidateToken validates tokenString for the default tenant
+func (v *Verifier) ValidateToken(tokenString string) (*Claims, error) {
+	return v.ValidateTokenContext(context.Background(), tokenString)
+}
+
+// ValidateTokenContext validates tokenString locally, or with the account
+// service for the tenant of ctx when it cannot be validated locally
+func (v *Verifier) ValidateTokenContext(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := v.local.ValidateToken(tokenString)
+	if errors.Is(err, ErrInvalidToken) && v.refreshKeys(ctx, tokenString) {
+		claims, err = v.local.ValidateToken(tokenString)
+	}
+	if v.remote == nil || !needsRemote(err) {
+		return claims, err
+	}
+	return v.verifyRemote(ctx, tokenString)
+}
+
+// needsRemote reports whether the local validation error err leaves the
+// token to the account service: the token may be one local keys cannot
+// validate, or a store it needed was unreachable. Expired, revoked and
+// misaddressed tokens are refused locally.
+func needsRemote(err error) bool {
+	return errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrRevocationUnavailable) || errors.Is(err, ErrTokenStoreUnavailable)
+}
+
+// verifyRemote returns the cached answer of the account service about
+// tokenString, asking it when there is none
+func (v *Verifier) verifyRemote(ctx context.Context, tokenString string) (*Claims, error) {
+	// Answers differ per tenant, and the cache holds hashes, not tokens
+	key := tenant.FromContext(ctx) + ":" + tokenHash(tokenString)
+	now := v.now()
+	v.mu.Lock()
+	cached, ok := v.cache[key]
+	v.mu.Unlock()
+	if ok && now.Before(cached.until) {
+		return cached.claims, cached.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteVerifyTimeout)
+	defer cancel()
+	claims, err := v.remote(ctx, tokenString)
+	if err != nil && !errors.Is(err, ErrInvalidToken) {
+		return nil, err
+	}
+
+	until := now.Add(v.ttl)
+	if claims != nil && claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(until) {
+		until = claims.ExpiresAt.Time
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.cache) >= maxCachedTokens {
+		v.evict(now)
+	}
+	v.cache[key] = verifiedToken{claims: claims, err: err, until: until}
+	return claims, err
+}
+
+// evict removes the expired answers, or every answer when none has expired.
+// v.mu must be held.
+func (v *Verifier) evict(now time.Time) {
+	for key, cached := range v.cache {
+		if !now.Before(cached.until) {
+			delete(v.cache, key)
+		}
+	}
+	if len(v.cache) >= maxCachedTokens {
+		clear(v.cache)
+	}
+}
+
+// refreshKeys fetches the JWKS again when tokenString is a JWT signed with a
+// key the local token service does not know, and reports whether it did
+func (v *Verifier) refreshKeys(ctx context.Context, tokenString string) bool {
+	if v.jwksURL == "" {
+		return false
+	}
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+	if err != nil {
+		return false
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" || v.local.hasKey(kid) {
+		return false
+	}
+
+	v.mu.Lock()
+	now := v.now()
+	due := now.Sub(v.jwksFetched) >= jwksRefetchInterval
+	if due {
+		v.jwksFetched = now
+	}
+	v.mu.Unlock()
+	return due && v.LoadJWKS(ctx) == nil
+}
+
+// LoadJWKS fetches the JWKS and trusts its keys
+func (v *Verifier) LoadJWKS(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, remoteVerifyTimeout)
+	defer cancel()
+	set, err := FetchJWKS(ctx, v.jwksClient, v.jwksURL)
+	if err != nil {
+		return err
+	}
+	return v.local.TrustKeys(set)
+}
+
+// hasKey reports whether tokens signed with the key kid are accepted
+func (ts *TokenService) hasKey(kid string) bool {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	_, ok := ts.publicKeys[kid]
+	return ok
+}
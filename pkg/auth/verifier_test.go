@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRemote verifies tokens as the account service would, with its tokens
+type fakeRemote struct {
+	tokens *TokenService
+	calls  int
+	err    error
+}
+
+func (r *fakeRemote) verify(ctx context.Context, tokenString string) (*Claims, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerifierUnavailable, r.err)
+	}
+	claims, err := r.tokens.ValidateToken(tokenString)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func TestVerifier_RemoteFallback(t *testing.T) {
+	store, _ := newTestTokenStore(t)
+	issuer := NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour).WithOpaqueTokens(store)
+	client := &fakeRemote{tokens: issuer}
+	verifier := NewVerifier(NewTokenService("test-secret", 15*time.Minute, 7*24*time.Hour), client.verify, DefaultVerifierCacheTTL)
+	now := time.Now()
+	verifier.now = func() time.Time { return now }
+
+	// JWTs are validated locally
+	jwtToken, _ := NewTokenService("test-secret", time.Minute, time.Hour).GenerateAccessToken("user123", "test@example.com", RoleUser)
+	if claims, err := verifier.ValidateToken(jwtToken); err != nil || claims.UserID != "user123" {
+		t.Fatalf("expected a valid token, got %v", err)
+	}
+	if client.calls != 0 {
+		t.Errorf("expected no call to the account service, got %d", client.calls)
+	}
+
+	// Opaque tokens are verified remotely, once per TTL
+	opaque, _ := issuer.GenerateAccessToken("user456", "other@example.com", RoleAdmin)
+	claims, err := verifier.ValidateToken(opaque)
+	if err != nil {
+		t.Fatalf("expected a valid token, got %v", err)
+	}
+	if claims.UserID != "user456" || claims.Role != RoleAdmin || claims.ID == "" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	verifier.ValidateToken(opaque)
+	if client.calls != 1 {
+		t.Errorf("expected the answer to be cached, got %d calls", client.calls)
+	}
+	now = now.Add(DefaultVerifierCacheTTL)
+	verifier.ValidateToken(opaque)
+	if client.calls != 2 {
+		t.Errorf("expected the token to be verified again after the TTL, got %d calls", client.calls)
+	}
+
+	// Invalid tokens are cached too; expired ones are refused locally
+	verifier.ValidateToken(OpaquePrefix + "unknown")
+	if _, err := verifier.ValidateToken(OpaquePrefix + "unknown"); err != ErrInvalidToken || client.calls != 3 {
+		t.Errorf("expected a cached ErrInvalidToken, got %v after %d calls", err, client.calls)
+	}
+	expired, _ := NewTokenService("test-secret", -time.Minute, time.Hour).GenerateAccessToken("user123", "", RoleUser)
+	if _, err := verifier.ValidateToken(expired); err != ErrTokenExpired || client.calls != 3 {
+		t.Errorf("expected ErrTokenExpired without a call, got %v", err)
+	}
+
+	// Refused, not trusted, while the account service is down
+	client.err = status.Error(codes.Unavailable, "connection refused")
+	other, _ := issuer.GenerateAccessToken("user789", "", RoleUser)
+	if _, err := verifier.ValidateToken(other); !errors.Is(err, ErrVerifierUnavailable) {
+		t.Errorf("expected ErrVerifierUnavailable, got %v", err)
+	}
+	client.err = nil
+	if _, err := verifier.ValidateToken(other); err != nil {
+		t.Errorf("expected the failure not to be cached, got %v", err)
+	}
+
+	interceptor := NewInterceptor(verifier, InterceptorConfig{})
+	client.err = status.Error(codes.Unavailable, "connection refused")
+	fresh, _ := issuer.GenerateAccessToken("user000", "", RoleUser)
+	if _, err := interceptor.Authenticate(withToken(fresh), "/catalog.CatalogService/CreateProduct"); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	issuer, _ := NewTokenService("", 15*time.Minute, 7*24*time.Hour).WithSigningKey(key)
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		issuer.JWKSHandler().ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	verifier := NewVerifier(NewTokenService("", 15*time.Minute, 7*24*time.Hour), nil, DefaultVerifierCacheTTL).WithJWKS(server.Client(), server.URL)
+	now := time.Now()
+	verifier.now = func() time.Time { return now }
+
+	// A token signed with an unknown key fetches the JWKS
+	token, _ := issuer.GenerateAccessToken("user123", "test@example.com", RoleUser)
+	if _, err := verifier.ValidateToken(token); err != nil || fetches != 1 {
+		t.Fatalf("expected the token to validate with the fetched keys, got %v after %d fetches", err, fetches)
+	}
+	if _, err := verifier.ValidateToken(token); err != nil || fetches != 1 {
+		t.Errorf("expected the keys to be reused, got %v after %d fetches", err, fetches)
+	}
+
+	// A rotated key is picked up, at most once a minute
+	next, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	issuer.AddSigningKey(next, time.Time{})
+	rotated, _ := issuer.GenerateAccessToken("user123", "test@example.com", RoleUser)
+	if _, err := verifier.ValidateToken(rotated); err != ErrInvalidToken || fetches != 1 {
+		t.Errorf("expected no fetch within a minute, got %v after %d fetches", err, fetches)
+	}
+	now = now.Add(jwksRefetchInterval)
+	if _, err := verifier.ValidateToken(rotated); err != nil || fetches != 2 {
+		t.Errorf("expected the rotated key to be fetched, got %v after %d fetches", err, fetches)
+	}
+}
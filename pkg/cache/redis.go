@@ -0,0 +1,103 @@
+// Package cache provides the shared Redis client and Redis-backed primitives
+// such as sliding-window counters and expiring flags.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Config holds the connection settings of a Redis server
+type Config struct {
+	Addr     string // host:port
+	Password string
+	DB       int
+}
+
+// NewRedisClient connects to Redis and verifies the connection
+func NewRedisClient(ctx context.Context, cfg Config) (*redis.Client, error) {
+	if cfg.Addr == "" {
+		return nil, errors.New("cache: address is required")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("cache: ping failed: %w", err)
+	}
+	return client, nil
+}
+
+// Counters keeps sliding-window event counters and expiring flags in Redis.
+// Keys are namespaced with a prefix so several features can share one database.
+type Counters struct {
+	client redis.Cmdable
+	prefix string
+	seq    atomic.Uint64
+}
+
+// NewCounters creates counters stored under prefix
+func NewCounters(client redis.Cmdable, prefix string) *Counters {
+	return &Counters{client: client, prefix: prefix}
+}
+
+// Hit records an event for key at now and returns the number of events within
+// the window ending at now, including this one.
+func (c *Counters) Hit(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error) {
+	k := c.prefix + key
+	// Members must be unique so that events at the same instant are all counted
+	member := strconv.FormatInt(now.UnixNano(), 10) + "-" + strconv.FormatUint(c.seq.Add(1), 10)
+
+	pipe := c.client.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, k, "-inf", windowStart(now, window))
+	pipe.ZAdd(ctx, k, redis.Z{Score: float64(now.UnixNano()), Member: member})
+	count := pipe.ZCard(ctx, k)
+	pipe.Expire(ctx, k, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("cache: hit %s: %w", key, err)
+	}
+	return count.Val(), nil
+}
+
+// Count returns the number of events for key within the window ending at now
+func (c *Counters) Count(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error) {
+	count, err := c.client.ZCount(ctx, c.prefix+key, windowStart(now, window), "+inf").Result()
+	if err != nil {
+		return 0, fmt.Errorf("cache: count %s: %w", key, err)
+	}
+	return count, nil
+}
+
+// Flag sets key for ttl. It reports true only if the flag was not already set.
+func (c *Counters) Flag(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	set, err := c.client.SetNX(ctx, c.prefix+"flag:"+key, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("cache: flag %s: %w", key, err)
+	}
+	return set, nil
+}
+
+// IsFlagged reports whether key is currently flagged
+func (c *Counters) IsFlagged(ctx context.Context, key string) (bool, error) {
+	n, err := c.client.Exists(ctx, c.prefix+"flag:"+key).Result()
+	if err != nil {
+		return false, fmt.Errorf("cache: flag %s: %w", key, err)
+	}
+	return n > 0, nil
+}
+
+// windowStart is the exclusive lower score bound of a window ending at now
+func windowStart(now time.Time, window time.Duration) string {
+	return "(" + strconv.FormatInt(now.Add(-window).UnixNano(), 10)
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestCounters(t *testing.T) (*Counters, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewCounters(client, "test:"), server
+}
+
+func TestCounters_SlidingWindow(t *testing.T) {
+	counters, _ := newTestCounters(t)
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if _, err := counters.Hit(ctx, "ip:1.2.3.4", time.Minute, start); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	count, err := counters.Hit(ctx, "ip:1.2.3.4", time.Minute, start.Add(30*time.Second))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 events in window, got %d", count)
+	}
+
+	// The first three events fall out of the window
+	count, err = counters.Count(ctx, "ip:1.2.3.4", time.Minute, start.Add(61*time.Second))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 event in window, got %d", count)
+	}
+}
+
+func TestCounters_Flag(t *testing.T) {
+	counters, server := newTestCounters(t)
+	ctx := context.Background()
+
+	first, err := counters.Flag(ctx, "ip:1.2.3.4", time.Minute)
+	if err != nil || !first {
+		t.Fatalf("expected new flag, got %v, %v", first, err)
+	}
+
+	again, _ := counters.Flag(ctx, "ip:1.2.3.4", time.Minute)
+	if again {
+		t.Error("expected existing flag not to be reported as new")
+	}
+
+	if flagged, _ := counters.IsFlagged(ctx, "ip:1.2.3.4"); !flagged {
+		t.Error("expected key to be flagged")
+	}
+
+	server.FastForward(2 * time.Minute)
+
+	if flagged, _ := counters.IsFlagged(ctx, "ip:1.2.3.4"); flagged {
+		t.Error("expected flag to expire")
+	}
+}
+
+func TestNewRedisClient_RequiresAddress(t *testing.T) {
+	if _, err := NewRedisClient(context.Background(), Config{}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -0,0 +1,140 @@
+// Package captioning generates alt text for images through a pluggable provider.
+package captioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoCaption is returned when a provider could not describe the image
+var ErrNoCaption = errors.New("captioning: no caption generated")
+
+// maxResponseSize caps the provider response body that is read, in bytes
+const maxResponseSize = 64 << 10
+
+// Provider generates a short description of an image suitable as alt text
+type Provider interface {
+	Caption(ctx context.Context, imageURL string, hint string) (string, error)
+}
+
+// Config holds the settings of an HTTP captioning provider
+type Config struct {
+	// Endpoint receives POST {"image_url": ..., "hint": ...} and answers {"caption": ...}
+	Endpoint string
+	// APIKey is sent as a bearer token when set
+	APIKey  string
+	Timeout time.Duration
+}
+
+// HTTPProvider calls a captioning service over HTTP
+type HTTPProvider struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewHTTPProvider creates a new HTTP captioning provider
+func NewHTTPProvider(cfg Config) (*HTTPProvider, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("captioning: endpoint is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	return &HTTPProvider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+type captionRequest struct {
+	ImageURL string `json:"image_url"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+type captionResponse struct {
+	Caption string `json:"caption"`
+}
+
+// Caption asks the service to describe the image at imageURL. hint carries context
+// such as the product name and may be empty.
+func (p *HTTPProvider) Caption(ctx context.Context, imageURL string, hint string) (string, error) {
+	body, err := json.Marshal(captionRequest{ImageURL: imageURL, Hint: hint})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("captioning: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("captioning: unexpected status %d", resp.StatusCode)
+	}
+
+	var decoded captionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&decoded); err != nil {
+		return "", fmt.Errorf("captioning: invalid response: %w", err)
+	}
+
+	caption := strings.TrimSpace(decoded.Caption)
+	if caption == "" {
+		return "", ErrNoCaption
+	}
+	return caption, nil
+}
+
+// FakeProvider is a deterministic provider for sandbox mode. It makes no
+// network calls and records every request so tests can inspect them.
+type FakeProvider struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+// NewFakeProvider creates a fake captioning provider
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+// Caption derives a caption from the hint, or the image file name without one
+func (f *FakeProvider) Caption(ctx context.Context, imageURL string, hint string) (string, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, imageURL)
+	f.mu.Unlock()
+
+	if hint = strings.TrimSpace(hint); hint != "" {
+		return "Image of " + hint, nil
+	}
+	name := strings.TrimSuffix(path.Base(imageURL), path.Ext(imageURL))
+	if name == "" || name == "." || name == "/" {
+		return "", ErrNoCaption
+	}
+	return "Image " + name, nil
+}
+
+// Requests returns the image URLs captioned so far, in call order
+func (f *FakeProvider) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
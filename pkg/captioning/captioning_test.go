@@ -0,0 +1,96 @@
+package captioning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCaption_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+
+		var req captionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("expected JSON body, got %v", err)
+		}
+		if req.ImageURL != "https://cdn.test/a.png" || req.Hint != "Desk Lamp" {
+			t.Errorf("unexpected request %+v", req)
+		}
+
+		w.Write([]byte(`{"caption": "  A brass desk lamp on a white background "}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPProvider(Config{Endpoint: server.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	caption, err := provider.Caption(context.Background(), "https://cdn.test/a.png", "Desk Lamp")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if caption != "A brass desk lamp on a white background" {
+		t.Errorf("unexpected caption %q", caption)
+	}
+}
+
+func TestCaption_Empty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"caption": ""}`))
+	}))
+	defer server.Close()
+
+	provider, _ := NewHTTPProvider(Config{Endpoint: server.URL})
+
+	_, err := provider.Caption(context.Background(), "https://cdn.test/a.png", "")
+
+	if !errors.Is(err, ErrNoCaption) {
+		t.Errorf("expected ErrNoCaption, got %v", err)
+	}
+}
+
+func TestCaption_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	provider, _ := NewHTTPProvider(Config{Endpoint: server.URL})
+
+	if _, err := provider.Caption(context.Background(), "https://cdn.test/a.png", ""); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestNewHTTPProvider_RequiresEndpoint(t *testing.T) {
+	if _, err := NewHTTPProvider(Config{}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestFakeProvider(t *testing.T) {
+	provider := NewFakeProvider()
+	ctx := context.Background()
+
+	caption, err := provider.Caption(ctx, "https://cdn.example.com/products/p1/red-mug.png", "Red Mug")
+	if err != nil || caption != "Image of Red Mug" {
+		t.Errorf("expected caption from hint, got %q, %v", caption, err)
+	}
+
+	caption, err = provider.Caption(ctx, "https://cdn.example.com/products/p1/red-mug.png", "")
+	if err != nil || caption != "Image red-mug" {
+		t.Errorf("expected caption from file name, got %q, %v", caption, err)
+	}
+
+	if requests := provider.Requests(); len(requests) != 2 {
+		t.Errorf("expected 2 recorded requests, got %v", requests)
+	}
+}
@@ -0,0 +1,150 @@
+package evidence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// memoryStore keeps uploaded objects in memory
+type memoryStore struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (m *memoryStore) Put(ctx context.Context, key, contentType string, body []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.objects[key] = body
+	return nil
+}
+
+func staticSource(name string, records interface{}, err error) Source {
+	return SourceFunc{
+		SourceName: name,
+		Fn: func(ctx context.Context, from, to time.Time) (interface{}, error) {
+			return records, err
+		},
+	}
+}
+
+func newTestExporter(store Store, key []byte, sources ...Source) *Exporter {
+	e := NewExporter("catalog", store, key, logger.New("evidence-test"), sources...)
+	e.now = func() time.Time { return time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) }
+	return e
+}
+
+func TestExport_SignedBundleVerifies(t *testing.T) {
+	store := &memoryStore{objects: map[string][]byte{}}
+	key := []byte("evidence-key")
+	exporter := newTestExporter(store, key,
+		staticSource("admin_actions", []map[string]string{{"actor": "admin-1", "action": "UpdateProduct"}}, nil),
+		staticSource("backup_verification", nil, errors.New("report missing")),
+	)
+
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	objectKey, err := exporter.Export(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if objectKey != "evidence/catalog/2024-02/20240201T000000Z_20240301T000000Z.json" {
+		t.Errorf("unexpected object key %s", objectKey)
+	}
+
+	bundle, err := Verify(store.objects[objectKey], key)
+	if err != nil {
+		t.Fatalf("expected bundle to verify, got %v", err)
+	}
+	if len(bundle.Sections) != 2 || bundle.Sections[1].Error != "report missing" {
+		t.Errorf("unexpected sections %+v", bundle.Sections)
+	}
+	if !strings.Contains(string(bundle.Sections[0].Records), "admin-1") {
+		t.Errorf("expected admin action records, got %s", bundle.Sections[0].Records)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	store := &memoryStore{objects: map[string][]byte{}}
+	key := []byte("evidence-key")
+	exporter := newTestExporter(store, key, staticSource("admin_actions", []string{"admin-1"}, nil))
+
+	objectKey, err := exporter.Export(context.Background(), time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data := store.objects[objectKey]
+
+	tampered := []byte(strings.Replace(string(data), "admin-1", "admin-2", 1))
+	if _, err := Verify(tampered, key); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for edited records, got %v", err)
+	}
+
+	if _, err := Verify(data, []byte("other-key")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for wrong key, got %v", err)
+	}
+}
+
+func TestVerify_Unsigned(t *testing.T) {
+	store := &memoryStore{objects: map[string][]byte{}}
+	exporter := newTestExporter(store, nil)
+
+	objectKey, err := exporter.Export(context.Background(), time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := Verify(store.objects[objectKey], []byte("key")); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("expected ErrUnsigned, got %v", err)
+	}
+}
+
+func TestConfigSnapshot_FingerprintsSecrets(t *testing.T) {
+	t.Setenv("EVIDENCE_TEST_PORT", "50052")
+	t.Setenv("EVIDENCE_TEST_JWT_SECRET", "super-secret")
+
+	records, err := ConfigSnapshot([]string{"EVIDENCE_TEST_PORT", "EVIDENCE_TEST_JWT_SECRET", "EVIDENCE_TEST_UNSET"}).
+		Collect(context.Background(), time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	snapshot := records.(map[string]string)
+	if snapshot["EVIDENCE_TEST_PORT"] != "50052" {
+		t.Errorf("expected plain value, got %q", snapshot["EVIDENCE_TEST_PORT"])
+	}
+	if secret := snapshot["EVIDENCE_TEST_JWT_SECRET"]; !strings.HasPrefix(secret, "sha256:") || strings.Contains(secret, "super-secret") {
+		t.Errorf("expected fingerprint, got %q", secret)
+	}
+	if snapshot["EVIDENCE_TEST_UNSET"] != "<unset>" {
+		t.Errorf("expected unset marker, got %q", snapshot["EVIDENCE_TEST_UNSET"])
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.json")
+	if err := os.WriteFile(path, []byte(`{"verified": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := FileSource("backup_verification", path).Collect(context.Background(), time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, _ := json.Marshal(records)
+	if string(data) != `{"verified":true}` {
+		t.Errorf("unexpected records %s", data)
+	}
+
+	if _, err := FileSource("backup_verification", filepath.Join(t.TempDir(), "missing.json")).
+		Collect(context.Background(), time.Time{}, time.Now()); err == nil {
+		t.Error("expected error for missing report")
+	}
+}
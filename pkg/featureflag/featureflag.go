@@ -0,0 +1,220 @@
+// Package featureflag evaluates feature flags shared between services. Flags
+// are managed through the flags service and kept in a Store; a Client in each
+// service syncs them into memory and evaluates them locally, so checking a
+// flag costs no network call.
+//
+// A flag is off for everyone while disabled. Once enabled it is on for its
+// targeted users and for a percentage of the rest, bucketed by a hash of the
+// flag key and user ID, so a user who is in the rollout stays in as the
+// percentage grows:
+//
+//	if flags.Enabled("search-elasticsearch", userID) {
+//		// new behavior
+//	}
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reasons a flag evaluated as it did
+const (
+	// ReasonUnknown is given for a flag that does not exist; it is off
+	ReasonUnknown = "UNKNOWN_FLAG"
+	// ReasonDisabled is given for a disabled flag
+	ReasonDisabled = "DISABLED"
+	// ReasonTargeted is given for a user the flag targets
+	ReasonTargeted = "TARGETED"
+	// ReasonRollout is given for a user in the flag's percentage
+	ReasonRollout = "ROLLOUT"
+	// ReasonOutOfRollout is given for a user outside the flag's percentage
+	ReasonOutOfRollout = "OUT_OF_ROLLOUT"
+)
+
+// Flag is a feature flag
+type Flag struct {
+	Key         string
+	Description string
+	// Enabled switches the flag; a disabled flag is off for everyone
+	Enabled bool
+	// Percentage of users the enabled flag is on for, from 0 to 100. 100
+	// makes it a plain on switch.
+	Percentage int
+	// Users the enabled flag is on for whatever the percentage
+	Users     []string
+	UpdatedAt time.Time
+}
+
+// Evaluation is the value of a flag for a user
+type Evaluation struct {
+	Enabled bool
+	Reason  string
+}
+
+// Evaluate returns the value of the flag for a user. userID may be empty for
+// anonymous callers, for whom a flag is on only at 100 percent.
+func (f Flag) Evaluate(userID string) Evaluation {
+	if !f.Enabled {
+		return Evaluation{Reason: ReasonDisabled}
+	}
+	if userID != "" {
+		for _, user := range f.Users {
+			if user == userID {
+				return Evaluation{Enabled: true, Reason: ReasonTargeted}
+			}
+		}
+	}
+	if f.Percentage >= 100 || (userID != "" && Bucket(f.Key, userID) < f.Percentage) {
+		return Evaluation{Enabled: true, Reason: ReasonRollout}
+	}
+	return Evaluation{Reason: ReasonOutOfRollout}
+}
+
+// Bucket places a user in one of 100 buckets of a flag. Each flag buckets
+// users independently, so the same users are not always the first to get
+// every feature.
+func Bucket(key, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
+
+// Store persists the flags so that all services share them
+type Store interface {
+	Load(ctx context.Context) ([]Flag, error)
+	Save(ctx context.Context, flag Flag) error
+	Delete(ctx context.Context, key string) error
+}
+
+// Client keeps the flags in memory and evaluates them
+type Client struct {
+	store Store
+	now   func() time.Time
+
+	mu    sync.RWMutex
+	flags map[string]Flag
+}
+
+// New creates a client. store may be nil to keep the flags in memory only.
+func New(store Store) *Client {
+	return &Client{
+		store: store,
+		now:   time.Now,
+		flags: make(map[string]Flag),
+	}
+}
+
+// Enabled reports whether a flag is on for a user. Unknown flags are off.
+func (c *Client) Enabled(key, userID string) bool {
+	return c.Evaluate(key, userID).Enabled
+}
+
+// Evaluate returns the value of a flag for a user and why
+func (c *Client) Evaluate(key, userID string) Evaluation {
+	flag, ok := c.Flag(key)
+	if !ok {
+		return Evaluation{Reason: ReasonUnknown}
+	}
+	return flag.Evaluate(userID)
+}
+
+// Flag returns a flag and whether it exists
+func (c *Client) Flag(key string) (Flag, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	flag, ok := c.flags[key]
+	return flag, ok
+}
+
+// Flags returns every flag, ordered by key
+func (c *Client) Flags() []Flag {
+	c.mu.RLock()
+	flags := make([]Flag, 0, len(c.flags))
+	for _, flag := range c.flags {
+		flags = append(flags, flag)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Key < flags[j].Key
+	})
+	return flags
+}
+
+// Set adds or replaces a flag
+func (c *Client) Set(ctx context.Context, flag Flag) error {
+	flag.UpdatedAt = c.now()
+
+	if c.store != nil {
+		if err := c.store.Save(ctx, flag); err != nil {
+			return fmt.Errorf("featureflag: save flag: %w", err)
+		}
+	}
+
+	c.mu.Lock()
+	c.flags[flag.Key] = flag
+	c.mu.Unlock()
+	return nil
+}
+
+// Delete removes a flag. It reports whether the flag existed.
+func (c *Client) Delete(ctx context.Context, key string) (bool, error) {
+	if c.store != nil {
+		if err := c.store.Delete(ctx, key); err != nil {
+			return false, fmt.Errorf("featureflag: delete flag: %w", err)
+		}
+	}
+
+	c.mu.Lock()
+	_, existed := c.flags[key]
+	delete(c.flags, key)
+	c.mu.Unlock()
+	return existed, nil
+}
+
+// Sync replaces the in-memory flags with the ones in the store
+func (c *Client) Sync(ctx context.Context) error {
+	if c.store == nil {
+		return nil
+	}
+
+	loaded, err := c.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("featureflag: load flags: %w", err)
+	}
+
+	flags := make(map[string]Flag, len(loaded))
+	for _, flag := range loaded {
+		flags[flag.Key] = flag
+	}
+
+	c.mu.Lock()
+	c.flags = flags
+	c.mu.Unlock()
+	return nil
+}
+
+// Run syncs the flags every interval until ctx is cancelled. Sync errors are
+// passed to onError and the previous flags stay in effect.
+func (c *Client) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Sync(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
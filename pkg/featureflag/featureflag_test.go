@@ -0,0 +1,95 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		flag   Flag
+		userID string
+		want   Evaluation
+	}{
+		{"disabled", Flag{Key: "f", Percentage: 100, Users: []string{"u1"}}, "u1", Evaluation{Reason: ReasonDisabled}},
+		{"targeted", Flag{Key: "f", Enabled: true, Users: []string{"u1"}}, "u1", Evaluation{Enabled: true, Reason: ReasonTargeted}},
+		{"switched on", Flag{Key: "f", Enabled: true, Percentage: 100}, "u2", Evaluation{Enabled: true, Reason: ReasonRollout}},
+		{"anonymous at 100", Flag{Key: "f", Enabled: true, Percentage: 100}, "", Evaluation{Enabled: true, Reason: ReasonRollout}},
+		{"anonymous in rollout", Flag{Key: "f", Enabled: true, Percentage: 99}, "", Evaluation{Reason: ReasonOutOfRollout}},
+		{"nobody", Flag{Key: "f", Enabled: true}, "u2", Evaluation{Reason: ReasonOutOfRollout}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.flag.Evaluate(tt.userID); got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestEvaluate_Percentage(t *testing.T) {
+	flag := Flag{Key: "search-elasticsearch", Enabled: true, Percentage: 25}
+
+	on := 0
+	for i := 0; i < 10000; i++ {
+		if flag.Evaluate(fmt.Sprintf("user-%d", i)).Enabled {
+			on++
+		}
+	}
+	if on < 2300 || on > 2700 {
+		t.Errorf("expected about 25%% of users, got %d of 10000", on)
+	}
+
+	// Users in the rollout stay in as it grows
+	grown := Flag{Key: flag.Key, Enabled: true, Percentage: 50}
+	for i := 0; i < 1000; i++ {
+		user := fmt.Sprintf("user-%d", i)
+		if flag.Evaluate(user).Enabled && !grown.Evaluate(user).Enabled {
+			t.Fatalf("expected %s to stay in the rollout", user)
+		}
+	}
+}
+
+func TestClient_UnknownFlag(t *testing.T) {
+	c := New(nil)
+	if got := c.Evaluate("missing", "u1"); got.Enabled || got.Reason != ReasonUnknown {
+		t.Errorf("expected unknown flag to be off, got %+v", got)
+	}
+}
+
+func TestRedisStore_SharedFlags(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	writer := New(NewRedisStore(client, "flags"))
+	reader := New(NewRedisStore(client, "flags"))
+
+	if err := writer.Set(ctx, Flag{Key: "new-checkout", Enabled: true, Users: []string{"u1"}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := reader.Sync(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reader.Enabled("new-checkout", "u1") || reader.Enabled("new-checkout", "u2") {
+		t.Errorf("expected synced flag, got %+v", reader.Flags())
+	}
+	if flag, _ := reader.Flag("new-checkout"); flag.UpdatedAt.IsZero() {
+		t.Error("expected the update time to be stored")
+	}
+
+	if existed, err := writer.Delete(ctx, "new-checkout"); err != nil || !existed {
+		t.Fatalf("expected the flag to be deleted, got %v (%v)", existed, err)
+	}
+	if err := reader.Sync(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(reader.Flags()) != 0 {
+		t.Error("expected deletion to propagate")
+	}
+}
@@ -0,0 +1,78 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisKey is the hash shared by all services
+const DefaultRedisKey = "featureflags"
+
+// RedisStore keeps the flags in a Redis hash keyed by flag key
+type RedisStore struct {
+	client redis.Cmdable
+	key    string
+}
+
+// NewRedisStore creates a store using the hash at key
+func NewRedisStore(client redis.Cmdable, key string) *RedisStore {
+	return &RedisStore{client: client, key: key}
+}
+
+// redisFlag is the stored form of a flag
+type redisFlag struct {
+	Description string    `json:"description"`
+	Enabled     bool      `json:"enabled"`
+	Percentage  int       `json:"percentage"`
+	Users       []string  `json:"users"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Load returns all flags
+func (s *RedisStore) Load(ctx context.Context) ([]Flag, error) {
+	fields, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]Flag, 0, len(fields))
+	for field, value := range fields {
+		var stored redisFlag
+		if err := json.Unmarshal([]byte(value), &stored); err != nil {
+			return nil, fmt.Errorf("invalid flag %q: %w", field, err)
+		}
+		flags = append(flags, Flag{
+			Key:         field,
+			Description: stored.Description,
+			Enabled:     stored.Enabled,
+			Percentage:  stored.Percentage,
+			Users:       stored.Users,
+			UpdatedAt:   stored.UpdatedAt,
+		})
+	}
+	return flags, nil
+}
+
+// Save stores a flag
+func (s *RedisStore) Save(ctx context.Context, flag Flag) error {
+	value, err := json.Marshal(redisFlag{
+		Description: flag.Description,
+		Enabled:     flag.Enabled,
+		Percentage:  flag.Percentage,
+		Users:       flag.Users,
+		UpdatedAt:   flag.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key, flag.Key, value).Err()
+}
+
+// Delete removes a flag
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	return s.client.HDel(ctx, s.key, key).Err()
+}
@@ -0,0 +1,172 @@
+// Package imagecheck validates product images against a merchandising policy:
+// minimum dimensions, allowed aspect ratios and the background around the subject.
+package imagecheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+)
+
+// Background policies
+const (
+	// BackgroundAny accepts any background
+	BackgroundAny = "any"
+	// BackgroundWhite requires the image border to be (near) white
+	BackgroundWhite = "white"
+	// BackgroundTransparent requires the image border to be transparent
+	BackgroundTransparent = "transparent"
+)
+
+// ErrUnsupportedFormat is returned when the image cannot be decoded
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+const (
+	// whiteThreshold is the lowest 8-bit channel value counted as white
+	whiteThreshold = 240
+	// borderTolerance is the share of border samples allowed to violate the background policy
+	borderTolerance = 0.05
+	// borderSamples is the number of samples taken along each edge
+	borderSamples = 64
+)
+
+// Policy describes the requirements an image must meet
+type Policy struct {
+	MinWidth  int
+	MinHeight int
+	// MinAspectRatio and MaxAspectRatio bound width/height; zero disables the bound
+	MinAspectRatio float64
+	MaxAspectRatio float64
+	Background     string
+}
+
+// DefaultPolicy is the policy applied when none is configured
+var DefaultPolicy = Policy{
+	MinWidth:       500,
+	MinHeight:      500,
+	MinAspectRatio: 0.5,
+	MaxAspectRatio: 2.0,
+	Background:     BackgroundAny,
+}
+
+// Validate reports whether the policy itself is consistent
+func (p Policy) Validate() error {
+	if p.MinWidth < 0 || p.MinHeight < 0 {
+		return errors.New("minimum dimensions cannot be negative")
+	}
+	if p.MinAspectRatio < 0 || p.MaxAspectRatio < 0 {
+		return errors.New("aspect ratio bounds cannot be negative")
+	}
+	if p.MinAspectRatio > 0 && p.MaxAspectRatio > 0 && p.MinAspectRatio > p.MaxAspectRatio {
+		return errors.New("minimum aspect ratio exceeds maximum aspect ratio")
+	}
+	switch p.Background {
+	case "", BackgroundAny, BackgroundWhite, BackgroundTransparent:
+		return nil
+	default:
+		return fmt.Errorf("unknown background policy %q", p.Background)
+	}
+}
+
+// Result is the outcome of checking an image
+type Result struct {
+	Width    int
+	Height   int
+	Format   string
+	Problems []string
+}
+
+// Passed reports whether the image met every requirement
+func (r *Result) Passed() bool {
+	return len(r.Problems) == 0
+}
+
+// Check decodes an image and checks it against the policy. Policy violations are
+// reported in Result.Problems; an error is only returned when the image cannot be decoded.
+func Check(data []byte, policy Policy) (*Result, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+
+	result := &Result{Width: cfg.Width, Height: cfg.Height, Format: format}
+
+	if cfg.Width < policy.MinWidth || cfg.Height < policy.MinHeight {
+		result.Problems = append(result.Problems, fmt.Sprintf(
+			"image is %dx%d, minimum is %dx%d", cfg.Width, cfg.Height, policy.MinWidth, policy.MinHeight))
+	}
+
+	if cfg.Height > 0 {
+		ratio := float64(cfg.Width) / float64(cfg.Height)
+		if policy.MinAspectRatio > 0 && ratio < policy.MinAspectRatio {
+			result.Problems = append(result.Problems, fmt.Sprintf(
+				"aspect ratio %.2f is below the minimum of %.2f", ratio, policy.MinAspectRatio))
+		}
+		if policy.MaxAspectRatio > 0 && ratio > policy.MaxAspectRatio {
+			result.Problems = append(result.Problems, fmt.Sprintf(
+				"aspect ratio %.2f exceeds the maximum of %.2f", ratio, policy.MaxAspectRatio))
+		}
+	}
+
+	if policy.Background == BackgroundWhite || policy.Background == BackgroundTransparent {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+		}
+		if !borderMatches(img, backgroundMatcher(policy.Background)) {
+			result.Problems = append(result.Problems, fmt.Sprintf("background is not %s", policy.Background))
+		}
+	}
+
+	return result, nil
+}
+
+// backgroundMatcher returns the pixel test of a background policy
+func backgroundMatcher(background string) func(color.Color) bool {
+	if background == BackgroundTransparent {
+		return func(c color.Color) bool {
+			_, _, _, a := c.RGBA()
+			return a == 0
+		}
+	}
+	return func(c color.Color) bool {
+		nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+		if nrgba.A == 0 {
+			return false
+		}
+		return nrgba.R >= whiteThreshold && nrgba.G >= whiteThreshold && nrgba.B >= whiteThreshold
+	}
+}
+
+// borderMatches samples the outermost pixels of each edge and reports whether
+// nearly all of them satisfy match
+func borderMatches(img image.Image, match func(color.Color) bool) bool {
+	b := img.Bounds()
+	if b.Empty() {
+		return false
+	}
+
+	total, misses := 0, 0
+	sample := func(x, y int) {
+		total++
+		if !match(img.At(x, y)) {
+			misses++
+		}
+	}
+
+	for i := 0; i < borderSamples; i++ {
+		x := b.Min.X + i*(b.Dx()-1)/(borderSamples-1)
+		y := b.Min.Y + i*(b.Dy()-1)/(borderSamples-1)
+		sample(x, b.Min.Y)
+		sample(x, b.Max.Y-1)
+		sample(b.Min.X, y)
+		sample(b.Max.X-1, y)
+	}
+
+	return float64(misses) <= float64(total)*borderTolerance
+}
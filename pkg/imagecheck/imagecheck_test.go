@@ -0,0 +1,113 @@
+package imagecheck
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// encodePNG renders a width x height PNG with the given border and center colors
+func encodePNG(t *testing.T, width, height int, border, center color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := border
+			if x > width/4 && x < width*3/4 && y > height/4 && y < height*3/4 {
+				c = center
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestCheck_Passes(t *testing.T) {
+	data := encodePNG(t, 600, 600, color.White, color.Black)
+
+	result, err := Check(data, Policy{MinWidth: 500, MinHeight: 500, MaxAspectRatio: 2, Background: BackgroundWhite})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !result.Passed() {
+		t.Errorf("expected image to pass, got problems %v", result.Problems)
+	}
+
+	if result.Width != 600 || result.Height != 600 || result.Format != "png" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestCheck_ReportsProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		border color.Color
+		policy Policy
+	}{
+		{"too small", 100, 100, color.White, Policy{MinWidth: 500, MinHeight: 500}},
+		{"too wide", 900, 300, color.White, Policy{MaxAspectRatio: 2}},
+		{"too tall", 300, 900, color.White, Policy{MinAspectRatio: 0.5}},
+		{"not white", 100, 100, color.Black, Policy{Background: BackgroundWhite}},
+		{"not transparent", 100, 100, color.White, Policy{Background: BackgroundTransparent}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := encodePNG(t, tt.width, tt.height, tt.border, color.Black)
+
+			result, err := Check(data, tt.policy)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if result.Passed() {
+				t.Error("expected image to fail the policy")
+			}
+		})
+	}
+}
+
+func TestCheck_TransparentBackground(t *testing.T) {
+	data := encodePNG(t, 100, 100, color.Transparent, color.Black)
+
+	result, err := Check(data, Policy{Background: BackgroundTransparent})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !result.Passed() {
+		t.Errorf("expected transparent border to pass, got problems %v", result.Problems)
+	}
+}
+
+func TestCheck_UnsupportedFormat(t *testing.T) {
+	_, err := Check([]byte("RIFF....WEBPVP8 "), DefaultPolicy)
+
+	if !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	if err := DefaultPolicy.Validate(); err != nil {
+		t.Errorf("expected default policy to be valid, got %v", err)
+	}
+
+	if err := (Policy{MinAspectRatio: 2, MaxAspectRatio: 1}).Validate(); err == nil {
+		t.Error("expected inverted aspect ratio bounds to be rejected")
+	}
+
+	if err := (Policy{Background: "green"}).Validate(); err == nil {
+		t.Error("expected unknown background policy to be rejected")
+	}
+}
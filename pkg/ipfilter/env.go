@@ -0,0 +1,83 @@
+package ipfilter
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/cache"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultSyncInterval is how often a filter built from the environment syncs
+// its deny list when DENY_LIST_SYNC_INTERVAL is not set
+const DefaultSyncInterval = 30 * time.Second
+
+// FromEnv builds the filter of a service from the environment:
+// ADMIN_ALLOWED_IPS restricts adminMethods, and TRUSTED_PROXIES lists the
+// peers whose x-forwarded-for is trusted. With REDIS_ADDR set the deny list,
+// managed through the account service, is read from Redis and synced every
+// DENY_LIST_SYNC_INTERVAL until ctx is done; the connection is closed then.
+func FromEnv(ctx context.Context, log *logger.Logger, adminMethods []string) (*Filter, error) {
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		return FromEnvWithRedis(ctx, log, adminMethods, nil)
+	}
+
+	client, err := cache.NewRedisClient(ctx, cache.Config{
+		Addr:     redisAddr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	filter, err := FromEnvWithRedis(ctx, log, adminMethods, client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		client.Close()
+	}()
+	return filter, nil
+}
+
+// FromEnvWithRedis is FromEnv for a service that connects to Redis for other
+// uses as well, reading the deny list through client; without a client the
+// deny list is kept in memory
+func FromEnvWithRedis(ctx context.Context, log *logger.Logger, adminMethods []string, client *redis.Client) (*Filter, error) {
+	cfg := Config{AdminMethods: adminMethods}
+	if len(adminMethods) > 0 {
+		allowlist, err := ParsePrefixes(os.Getenv("ADMIN_ALLOWED_IPS"))
+		if err != nil {
+			return nil, err
+		}
+		cfg.AdminAllowlist = allowlist
+	}
+	proxies, err := ParsePrefixes(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.TrustedProxies = proxies
+
+	if client == nil {
+		log.Info(ctx, "IP deny list kept in memory (REDIS_ADDR not set)", nil)
+		return New(cfg, nil), nil
+	}
+
+	filter := New(cfg, NewRedisStore(client, DefaultRedisKey))
+	if err := filter.Sync(ctx); err != nil {
+		return nil, err
+	}
+
+	interval := DefaultSyncInterval
+	if value, err := time.ParseDuration(os.Getenv("DENY_LIST_SYNC_INTERVAL")); err == nil && value > 0 {
+		interval = value
+	}
+	go filter.Run(ctx, interval, func(err error) {
+		log.Warn(ctx, "Failed to sync IP deny list", map[string]interface{}{"error": err.Error()})
+	})
+	return filter, nil
+}
@@ -0,0 +1,45 @@
+package ipfilter
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestFromEnv(t *testing.T) {
+	server := miniredis.RunT(t)
+	t.Setenv("ADMIN_ALLOWED_IPS", "10.0.0.0/8")
+	t.Setenv("TRUSTED_PROXIES", "172.16.0.1")
+	t.Setenv("REDIS_ADDR", server.Addr())
+	server.HSet(DefaultRedisKey, "198.51.100.7/32", `{"reason":"scraping"}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := logger.New("ipfilter-test")
+
+	f, err := FromEnv(ctx, log, []string{"/account.AccountService/DenyIP"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := f.Check("/account.AccountService/DenyIP", netip.MustParseAddr("198.51.100.8")); err != ErrAdminNetwork {
+		t.Errorf("expected the admin allowlist to apply, got %v", err)
+	}
+	if list := f.DenyList(); len(list) != 1 || list[0].Reason != "scraping" {
+		t.Errorf("expected the deny list to be read from Redis, got %+v", list)
+	}
+	if got := f.ClientAddr(netip.MustParseAddr("172.16.0.1"), "203.0.113.5"); got != netip.MustParseAddr("203.0.113.5") {
+		t.Errorf("expected the trusted proxy's forwarded address, got %v", got)
+	}
+
+	// Services without admin RPCs ignore the allowlist
+	t.Setenv("ADMIN_ALLOWED_IPS", "nope")
+	t.Setenv("REDIS_ADDR", "")
+	if _, err := FromEnv(ctx, log, nil); err != nil {
+		t.Errorf("expected no error without admin methods, got %v", err)
+	}
+	if _, err := FromEnv(ctx, log, []string{"/account.AccountService/DenyIP"}); err == nil {
+		t.Error("expected an invalid allowlist to be refused")
+	}
+}
@@ -0,0 +1,360 @@
+// Package ipfilter restricts admin-scoped endpoints to trusted networks and
+// blocks denied IPs. A Filter is enforced by a gRPC interceptor in each service
+// and by an HTTP middleware in the gateway; the deny list can be shared between
+// processes through a Store.
+package ipfilter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrDenied is returned when the client IP is on the deny list
+	ErrDenied = errors.New("ip address is denied")
+	// ErrAdminNetwork is returned when an admin endpoint is called from outside the allowlist
+	ErrAdminNetwork = errors.New("admin endpoints are not reachable from this network")
+	// ErrUnknownClient is returned when the client IP cannot be determined
+	ErrUnknownClient = errors.New("client address unknown")
+)
+
+// DenyEntry is one entry of the deny list
+type DenyEntry struct {
+	Prefix    netip.Prefix
+	Reason    string
+	CreatedAt time.Time
+	// ExpiresAt is zero for entries that never expire
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the entry is no longer in effect at now
+func (e DenyEntry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// Store persists the deny list so that all services share it
+type Store interface {
+	Load(ctx context.Context) ([]DenyEntry, error)
+	Save(ctx context.Context, entry DenyEntry) error
+	Delete(ctx context.Context, prefix netip.Prefix) error
+}
+
+// Config holds the static part of a filter
+type Config struct {
+	// AdminAllowlist lists the networks admin endpoints may be called from. An
+	// empty allowlist leaves admin endpoints unrestricted.
+	AdminAllowlist []netip.Prefix
+	// AdminMethods lists gRPC full method names or HTTP paths of admin endpoints.
+	// Entries ending in "/" match every method or path below them.
+	AdminMethods []string
+	// TrustedProxies lists the networks whose x-forwarded-for header is trusted
+	TrustedProxies []netip.Prefix
+}
+
+// Filter enforces the admin allowlist and the deny list
+type Filter struct {
+	cfg   Config
+	store Store
+	now   func() time.Time
+
+	mu   sync.RWMutex
+	deny map[netip.Prefix]DenyEntry
+}
+
+// New creates a filter. store may be nil to keep the deny list in memory only.
+func New(cfg Config, store Store) *Filter {
+	return &Filter{
+		cfg:   cfg,
+		store: store,
+		now:   time.Now,
+		deny:  make(map[netip.Prefix]DenyEntry),
+	}
+}
+
+// Deny adds or replaces a deny list entry
+func (f *Filter) Deny(ctx context.Context, entry DenyEntry) error {
+	entry.Prefix = entry.Prefix.Masked()
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = f.now()
+	}
+
+	if f.store != nil {
+		if err := f.store.Save(ctx, entry); err != nil {
+			return fmt.Errorf("ipfilter: save deny entry: %w", err)
+		}
+	}
+
+	f.mu.Lock()
+	f.deny[entry.Prefix] = entry
+	f.mu.Unlock()
+	return nil
+}
+
+// Remove deletes a deny list entry. It reports whether the entry existed.
+func (f *Filter) Remove(ctx context.Context, prefix netip.Prefix) (bool, error) {
+	prefix = prefix.Masked()
+
+	if f.store != nil {
+		if err := f.store.Delete(ctx, prefix); err != nil {
+			return false, fmt.Errorf("ipfilter: delete deny entry: %w", err)
+		}
+	}
+
+	f.mu.Lock()
+	_, existed := f.deny[prefix]
+	delete(f.deny, prefix)
+	f.mu.Unlock()
+	return existed, nil
+}
+
+// DenyList returns the entries in effect, ordered by prefix
+func (f *Filter) DenyList() []DenyEntry {
+	now := f.now()
+
+	f.mu.RLock()
+	entries := make([]DenyEntry, 0, len(f.deny))
+	for _, e := range f.deny {
+		if !e.Expired(now) {
+			entries = append(entries, e)
+		}
+	}
+	f.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Prefix.String() < entries[j].Prefix.String()
+	})
+	return entries
+}
+
+// Sync replaces the in-memory deny list with the one in the store
+func (f *Filter) Sync(ctx context.Context) error {
+	if f.store == nil {
+		return nil
+	}
+
+	entries, err := f.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("ipfilter: load deny list: %w", err)
+	}
+
+	deny := make(map[netip.Prefix]DenyEntry, len(entries))
+	for _, e := range entries {
+		deny[e.Prefix.Masked()] = e
+	}
+
+	f.mu.Lock()
+	f.deny = deny
+	f.mu.Unlock()
+	return nil
+}
+
+// Run syncs the deny list every interval until ctx is cancelled. Sync errors
+// are passed to onError and the previous list stays in effect.
+func (f *Filter) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Sync(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// IsAdmin reports whether method is an admin endpoint
+func (f *Filter) IsAdmin(method string) bool {
+	for _, m := range f.cfg.AdminMethods {
+		if m == method || (strings.HasSuffix(m, "/") && strings.HasPrefix(method, m)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error if addr may not call method
+func (f *Filter) Check(method string, addr netip.Addr) error {
+	if !addr.IsValid() {
+		// Without an address neither list can be enforced; only admin calls are refused
+		if f.IsAdmin(method) && len(f.cfg.AdminAllowlist) > 0 {
+			return ErrUnknownClient
+		}
+		return nil
+	}
+	addr = addr.Unmap()
+
+	if f.denied(addr) {
+		return ErrDenied
+	}
+
+	if f.IsAdmin(method) && len(f.cfg.AdminAllowlist) > 0 && !containsAddr(f.cfg.AdminAllowlist, addr) {
+		return ErrAdminNetwork
+	}
+	return nil
+}
+
+// denied reports whether addr is covered by an unexpired deny entry
+func (f *Filter) denied(addr netip.Addr) bool {
+	now := f.now()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for prefix, e := range f.deny {
+		if prefix.Contains(addr) && !e.Expired(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientAddr resolves the client address of a connection from remote. The first
+// forwarded address is used only when remote is a trusted proxy.
+func (f *Filter) ClientAddr(remote netip.Addr, forwardedFor string) netip.Addr {
+	remote = remote.Unmap()
+	if forwardedFor == "" || !remote.IsValid() || !containsAddr(f.cfg.TrustedProxies, remote) {
+		return remote
+	}
+
+	first := strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+	if addr, err := netip.ParseAddr(first); err == nil {
+		return addr.Unmap()
+	}
+	return remote
+}
+
+// UnaryServerInterceptor enforces the filter on gRPC calls using the peer address
+func (f *Filter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := f.Check(info.FullMethod, f.peerAddr(ctx)); err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor enforces the filter on streaming gRPC calls
+func (f *Filter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := f.Check(info.FullMethod, f.peerAddr(ss.Context())); err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		return handler(srv, ss)
+	}
+}
+
+// peerAddr returns the client address of a gRPC call
+func (f *Filter) peerAddr(ctx context.Context) netip.Addr {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return netip.Addr{}
+	}
+
+	remote := parseHostPort(p.Addr.String())
+
+	var forwarded string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("x-forwarded-for"); len(v) > 0 {
+			forwarded = v[0]
+		}
+	}
+	return f.ClientAddr(remote, forwarded)
+}
+
+// Middleware enforces the filter on HTTP requests, matching admin endpoints by path
+func (f *Filter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr := f.ClientAddr(parseHostPort(r.RemoteAddr), r.Header.Get("X-Forwarded-For"))
+		if err := f.Check(r.URL.Path, addr); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ParsePrefixes parses a comma-separated list of IPs and CIDRs. Bare IPs become
+// single-address prefixes.
+func ParsePrefixes(list string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		prefix, err := ParsePrefix(item)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// ParsePrefix parses an IP or CIDR
+func ParsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("ipfilter: invalid CIDR %q", s)
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("ipfilter: invalid IP %q", s)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// parseHostPort parses the address part of host:port, or a bare address
+func parseHostPort(s string) netip.Addr {
+	host, _, err := net.SplitHostPort(s)
+	if err != nil {
+		host = s
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr
+}
+
+// containsAddr reports whether any prefix contains addr
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package ipfilter
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func mustPrefixes(t *testing.T, list string) []netip.Prefix {
+	t.Helper()
+	prefixes, err := ParsePrefixes(list)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return prefixes
+}
+
+func newTestFilter(t *testing.T, store Store) *Filter {
+	t.Helper()
+	return New(Config{
+		AdminAllowlist: mustPrefixes(t, "10.0.0.0/8, 192.0.2.10"),
+		AdminMethods:   []string{"/account.AccountService/DenyIP", "/admin/"},
+		TrustedProxies: mustPrefixes(t, "172.16.0.1"),
+	}, store)
+}
+
+func TestCheck_AdminAllowlist(t *testing.T) {
+	f := newTestFilter(t, nil)
+
+	tests := []struct {
+		method string
+		addr   string
+		want   error
+	}{
+		{"/account.AccountService/DenyIP", "10.1.2.3", nil},
+		{"/account.AccountService/DenyIP", "192.0.2.10", nil},
+		{"/account.AccountService/DenyIP", "198.51.100.7", ErrAdminNetwork},
+		{"/admin/products", "198.51.100.7", ErrAdminNetwork},
+		{"/account.AccountService/Login", "198.51.100.7", nil},
+		{"/account.AccountService/DenyIP", "", ErrUnknownClient},
+	}
+
+	for _, tt := range tests {
+		var addr netip.Addr
+		if tt.addr != "" {
+			addr = netip.MustParseAddr(tt.addr)
+		}
+		if err := f.Check(tt.method, addr); err != tt.want {
+			t.Errorf("Check(%s, %s) = %v, want %v", tt.method, tt.addr, err, tt.want)
+		}
+	}
+}
+
+func TestDeny_ExpiresAndRemoves(t *testing.T) {
+	f := newTestFilter(t, nil)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	prefix, _ := ParsePrefix("203.0.113.0/24")
+	if err := f.Deny(ctx, DenyEntry{Prefix: prefix, Reason: "abuse", ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := f.Check("/account.AccountService/Login", netip.MustParseAddr("203.0.113.9")); err != ErrDenied {
+		t.Errorf("expected ErrDenied, got %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if err := f.Check("/account.AccountService/Login", netip.MustParseAddr("203.0.113.9")); err != nil {
+		t.Errorf("expected expired entry to be ignored, got %v", err)
+	}
+	if len(f.DenyList()) != 0 {
+		t.Errorf("expected expired entry to be hidden, got %v", f.DenyList())
+	}
+
+	existed, err := f.Remove(ctx, prefix)
+	if err != nil || !existed {
+		t.Errorf("expected entry to be removed, got %v, %v", existed, err)
+	}
+}
+
+func TestClientAddr_TrustedProxyOnly(t *testing.T) {
+	f := newTestFilter(t, nil)
+
+	if got := f.ClientAddr(netip.MustParseAddr("172.16.0.1"), "10.1.1.1, 172.16.0.1"); got.String() != "10.1.1.1" {
+		t.Errorf("expected forwarded address from trusted proxy, got %s", got)
+	}
+	if got := f.ClientAddr(netip.MustParseAddr("198.51.100.7"), "10.1.1.1"); got.String() != "198.51.100.7" {
+		t.Errorf("expected forwarded header from untrusted peer to be ignored, got %s", got)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	f := newTestFilter(t, nil)
+	interceptor := f.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/account.AccountService/DenyIP"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.7"), Port: 4000}})
+	if _, err := interceptor(ctx, nil, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+
+	// Spoofed header from an untrusted peer is ignored
+	spoofed := metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "10.0.0.1"))
+	if _, err := interceptor(spoofed, nil, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 4000}})
+	if resp, err := interceptor(ctx, nil, info, handler); err != nil || resp != "ok" {
+		t.Errorf("expected call to pass, got %v, %v", resp, err)
+	}
+}
+
+// testServerStream is a server stream carrying only a context
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	f := newTestFilter(t, nil)
+	interceptor := f.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/account.AccountService/DenyIP", IsServerStream: true}
+	called := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		called = true
+		return nil
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.7"), Port: 4000}})
+	if err := interceptor(nil, &testServerStream{ctx: ctx}, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	if called {
+		t.Error("expected handler not to be called")
+	}
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 4000}})
+	if err := interceptor(nil, &testServerStream{ctx: ctx}, info, handler); err != nil || !called {
+		t.Errorf("expected stream to pass, got %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	f := newTestFilter(t, nil)
+	handler := f.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/products", nil)
+	req.RemoteAddr = "198.51.100.7:4000"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/products", nil)
+	req.RemoteAddr = "172.16.0.1:4000"
+	req.Header.Set("X-Forwarded-For", "10.2.3.4")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestParsePrefixes_Invalid(t *testing.T) {
+	if _, err := ParsePrefixes("10.0.0.0/8, nope"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestRedisStore_SharedDenyList(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	writer := newTestFilter(t, NewRedisStore(client, "deny"))
+	reader := newTestFilter(t, NewRedisStore(client, "deny"))
+
+	prefix, _ := ParsePrefix("198.51.100.7")
+	if err := writer.Deny(ctx, DenyEntry{Prefix: prefix, Reason: "scraping"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expired, _ := ParsePrefix("198.51.100.8")
+	if err := writer.Deny(ctx, DenyEntry{Prefix: expired, ExpiresAt: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := reader.Sync(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	list := reader.DenyList()
+	if len(list) != 1 || list[0].Prefix != prefix || list[0].Reason != "scraping" {
+		t.Fatalf("expected synced entry, got %+v", list)
+	}
+	if server.HGet("deny", expired.String()) != "" {
+		t.Error("expected expired entry to be purged from the store")
+	}
+
+	if _, err := writer.Remove(ctx, prefix); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := reader.Sync(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(reader.DenyList()) != 0 {
+		t.Error("expected removal to propagate")
+	}
+}
@@ -0,0 +1,92 @@
+package ipfilter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisKey is the hash shared by all services
+const DefaultRedisKey = "ipfilter:deny"
+
+// RedisStore keeps the deny list in a Redis hash keyed by prefix
+type RedisStore struct {
+	client redis.Cmdable
+	key    string
+}
+
+// NewRedisStore creates a store using the hash at key
+func NewRedisStore(client redis.Cmdable, key string) *RedisStore {
+	return &RedisStore{client: client, key: key}
+}
+
+// redisEntry is the stored form of a deny entry
+type redisEntry struct {
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Load returns all unexpired entries and removes expired ones
+func (s *RedisStore) Load(ctx context.Context) ([]DenyEntry, error) {
+	fields, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]DenyEntry, 0, len(fields))
+	var expired []string
+	for field, value := range fields {
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deny entry %q: %w", field, err)
+		}
+
+		var stored redisEntry
+		if err := json.Unmarshal([]byte(value), &stored); err != nil {
+			return nil, fmt.Errorf("invalid deny entry %q: %w", field, err)
+		}
+
+		entry := DenyEntry{
+			Prefix:    prefix,
+			Reason:    stored.Reason,
+			CreatedAt: stored.CreatedAt,
+			ExpiresAt: stored.ExpiresAt,
+		}
+		if entry.Expired(now) {
+			expired = append(expired, field)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(expired) > 0 {
+		if err := s.client.HDel(ctx, s.key, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// Save stores an entry
+func (s *RedisStore) Save(ctx context.Context, entry DenyEntry) error {
+	value, err := json.Marshal(redisEntry{
+		Reason:    entry.Reason,
+		CreatedAt: entry.CreatedAt,
+		ExpiresAt: entry.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key, entry.Prefix.String(), value).Err()
+}
+
+// Delete removes an entry
+func (s *RedisStore) Delete(ctx context.Context, prefix netip.Prefix) error {
+	return s.client.HDel(ctx, s.key, prefix.String()).Err()
+}
@@ -0,0 +1,129 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// ConsumerConfig selects the messages a consumer reads
+type ConsumerConfig struct {
+	// Group is the consumer group. Consumers of one group share the
+	// partitions of the topics and resume from the group's committed offsets.
+	Group  string
+	Topics []string
+	// FromNewest starts a group without committed offsets at the newest
+	// messages rather than the oldest
+	FromNewest bool
+	// DeadLetters, when set, receives the messages the handler fails on,
+	// published to the dead-letter topic of their topic before they are
+	// committed
+	DeadLetters Publisher
+}
+
+// Handler processes a consumed message
+type Handler func(ctx context.Context, msg Message) error
+
+// reader is implemented by *kafkago.Reader
+type reader interface {
+	FetchMessage(ctx context.Context) (kafkago.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
+// Consumer reads messages from Kafka as a member of a consumer group
+type Consumer struct {
+	reader      reader
+	group       string
+	deadLetters Publisher
+	service     string
+	now         func() time.Time
+}
+
+// NewConsumer creates a consumer for service, which labels its metrics
+func NewConsumer(cfg Config, consumer ConsumerConfig, service string) (*Consumer, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	if consumer.Group == "" || len(consumer.Topics) == 0 {
+		return nil, errors.New("kafka: a consumer group and at least one topic are required")
+	}
+	startOffset := kafkago.FirstOffset
+	if consumer.FromNewest {
+		startOffset = kafkago.LastOffset
+	}
+
+	return &Consumer{
+		reader: kafkago.NewReader(kafkago.ReaderConfig{
+			Brokers:     cfg.Brokers,
+			GroupID:     consumer.Group,
+			GroupTopics: consumer.Topics,
+			StartOffset: startOffset,
+		}),
+		group:       consumer.Group,
+		deadLetters: consumer.DeadLetters,
+		service:     service,
+		now:         time.Now,
+	}, nil
+}
+
+// Run hands each message to handle, in order within a partition, and commits
+// it once handled, until ctx is cancelled. A message handle fails on is
+// passed to onError, published to its dead-letter topic when the consumer
+// has one, and committed all the same, so one bad message cannot stall its
+// partition; handlers that must not lose a message retry it themselves.
+// Messages requeued for another group are committed without being handled.
+// Run returns nil when ctx is cancelled, or the error that stopped it
+// reading or dead-lettering.
+func (c *Consumer) Run(ctx context.Context, handle Handler, onError func(msg Message, err error)) error {
+	for {
+		record, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("kafka: fetch: %w", err)
+		}
+
+		msg := Message{Topic: record.Topic, Key: string(record.Key), Value: record.Value, Partition: record.Partition, Offset: record.Offset}
+		if len(record.Headers) > 0 {
+			msg.Headers = make(map[string]string, len(record.Headers))
+			for _, h := range record.Headers {
+				msg.Headers[h.Key] = string(h.Value)
+			}
+		}
+
+		status := "success"
+		if group := msg.Headers[HeaderRequeuedFor]; group != "" && group != c.group {
+			status = "skipped"
+		} else if err := handle(ctx, msg); err != nil {
+			status = "error"
+			onError(msg, err)
+			if c.deadLetters != nil {
+				// Left uncommitted when it cannot be dead-lettered, so it is
+				// read again
+				if err := c.deadLetters.Publish(ctx, DeadLetter(msg, c.group, err, c.now())); err != nil {
+					return fmt.Errorf("kafka: dead-letter: %w", err)
+				}
+				status = "dead_lettered"
+			}
+		}
+		metrics.KafkaMessagesConsumed.WithLabelValues(c.service, msg.Topic, status).Inc()
+
+		if err := c.reader.CommitMessages(ctx, record); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("kafka: commit: %w", err)
+		}
+	}
+}
+
+// Close leaves the consumer group and closes the connections to the brokers
+func (c *Consumer) Close() error {
+	return c.reader.Close()
+}
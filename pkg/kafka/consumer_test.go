@@ -0,0 +1,166 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// fakeReader serves queued messages, then blocks until the context is cancelled
+type fakeReader struct {
+	queue     []kafkago.Message
+	committed []kafkago.Message
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafkago.Message, error) {
+	if len(r.queue) == 0 {
+		<-ctx.Done()
+		return kafkago.Message{}, ctx.Err()
+	}
+	msg := r.queue[0]
+	r.queue = r.queue[1:]
+	return msg, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+func TestConsumer_Run(t *testing.T) {
+	r := &fakeReader{queue: []kafkago.Message{
+		{Topic: "order-events", Key: []byte("order-1"), Value: []byte(`{"n":1}`), Headers: []kafkago.Header{{Key: "event-type", Value: []byte("order.status_changed")}}},
+		{Topic: "order-events", Key: []byte("order-2"), Value: []byte(`{"n":2}`)},
+	}}
+	c := &Consumer{reader: r, service: "test-service"}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var handled []Message
+	var failed []string
+	err := c.Run(ctx, func(ctx context.Context, msg Message) error {
+		handled = append(handled, msg)
+		if len(handled) == 2 {
+			defer cancel()
+			return errors.New("bad payload")
+		}
+		return nil
+	}, func(msg Message, err error) {
+		failed = append(failed, msg.Key)
+	})
+	if err != nil {
+		t.Fatalf("expected no error once cancelled, got %v", err)
+	}
+
+	if len(handled) != 2 || handled[0].Key != "order-1" || handled[0].Headers["event-type"] != "order.status_changed" {
+		t.Fatalf("unexpected messages handled: %+v", handled)
+	}
+	if len(failed) != 1 || failed[0] != "order-2" {
+		t.Errorf("expected order-2 reported failed, got %v", failed)
+	}
+	// A failed message is committed too, so it does not stall the partition
+	if len(r.committed) != 2 {
+		t.Errorf("expected 2 messages committed, got %d", len(r.committed))
+	}
+}
+
+func TestNewConsumer_RequiresGroupAndTopics(t *testing.T) {
+	cfg := Config{Brokers: []string{"kafka:9092"}}
+	if _, err := NewConsumer(Config{}, ConsumerConfig{Group: "g", Topics: []string{"t"}}, "test-service"); err == nil {
+		t.Error("expected error without brokers")
+	}
+	if _, err := NewConsumer(cfg, ConsumerConfig{Topics: []string{"t"}}, "test-service"); err == nil {
+		t.Error("expected error without a group")
+	}
+	if _, err := NewConsumer(cfg, ConsumerConfig{Group: "g"}, "test-service"); err == nil {
+		t.Error("expected error without topics")
+	}
+}
+
+type fakePublisher struct {
+	published []Message
+	err       error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, msgs ...Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, msgs...)
+	return nil
+}
+
+func TestConsumer_RunDeadLettersFailures(t *testing.T) {
+	failedAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	r := &fakeReader{queue: []kafkago.Message{
+		{Topic: "catalog-events", Partition: 2, Offset: 41, Key: []byte("prod-1"), Value: []byte(`{"n":1}`), Headers: []kafkago.Header{{Key: "event-id", Value: []byte("e1")}}},
+		{Topic: "catalog-events", Partition: 2, Offset: 42, Key: []byte("prod-2"), Value: []byte(`{"n":2}`), Headers: []kafkago.Header{{Key: HeaderRequeuedFor, Value: []byte("search-service")}}},
+	}}
+	dlq := &fakePublisher{}
+	c := &Consumer{reader: r, group: "image-service", deadLetters: dlq, service: "test-service", now: func() time.Time { return failedAt }}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var handled int
+	err := c.Run(ctx, func(ctx context.Context, msg Message) error {
+		handled++
+		return errors.New("image not found")
+	}, func(msg Message, err error) { cancel() })
+	if err != nil {
+		t.Fatalf("expected no error once cancelled, got %v", err)
+	}
+
+	// The message requeued for another group is skipped
+	if handled != 1 || len(dlq.published) != 1 {
+		t.Fatalf("expected one message handled and dead-lettered, got %d and %v", handled, dlq.published)
+	}
+	dead := dlq.published[0]
+	if dead.Topic != "catalog-events.dlq" || dead.Key != "prod-1" || string(dead.Value) != `{"n":1}` {
+		t.Errorf("unexpected dead letter %+v", dead)
+	}
+	expected := map[string]string{
+		"event-id":                "e1",
+		HeaderDeadLetterTopic:     "catalog-events",
+		HeaderDeadLetterPartition: "2",
+		HeaderDeadLetterOffset:    "41",
+		HeaderDeadLetterGroup:     "image-service",
+		HeaderDeadLetterError:     "image not found",
+		HeaderDeadLetterFailedAt:  "2026-06-01T12:00:00Z",
+	}
+	if !reflect.DeepEqual(dead.Headers, expected) {
+		t.Errorf("expected headers %v, got %v", expected, dead.Headers)
+	}
+	if len(r.committed) != 2 {
+		t.Errorf("expected 2 messages committed, got %d", len(r.committed))
+	}
+}
+
+func TestConsumer_RunStopsWhenDeadLetterFails(t *testing.T) {
+	r := &fakeReader{queue: []kafkago.Message{{Topic: "catalog-events", Key: []byte("prod-1")}}}
+	c := &Consumer{reader: r, group: "image-service", deadLetters: &fakePublisher{err: errors.New("broker down")}, service: "test-service", now: time.Now}
+
+	err := c.Run(context.Background(), func(ctx context.Context, msg Message) error {
+		return errors.New("image not found")
+	}, func(msg Message, err error) {})
+	if err == nil {
+		t.Fatal("expected the consumer to stop")
+	}
+	if len(r.committed) != 0 {
+		t.Errorf("expected the message to be left uncommitted, got %d committed", len(r.committed))
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	dead := DeadLetter(Message{Topic: "catalog-events", Key: "prod-1", Value: []byte(`{}`), Headers: map[string]string{"event-id": "e1"}},
+		"image-service", errors.New("timeout"), time.Now())
+
+	msg := Requeue("catalog-events", dead.Key, dead.Value, dead.Headers, "image-service")
+	expected := map[string]string{"event-id": "e1", HeaderRequeuedFor: "image-service"}
+	if msg.Topic != "catalog-events" || !reflect.DeepEqual(msg.Headers, expected) {
+		t.Errorf("unexpected requeued message %+v", msg)
+	}
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeadLetterSuffix is appended to a topic to name its dead-letter topic, e.g.
+// catalog-events.dlq
+const DeadLetterSuffix = ".dlq"
+
+// Headers set on dead letters, in addition to the headers of the message
+const (
+	HeaderDeadLetterTopic     = "dlq-topic"
+	HeaderDeadLetterPartition = "dlq-partition"
+	HeaderDeadLetterOffset    = "dlq-offset"
+	HeaderDeadLetterGroup     = "dlq-group"
+	HeaderDeadLetterError     = "dlq-error"
+	HeaderDeadLetterFailedAt  = "dlq-failed-at"
+	// HeaderRequeuedFor names the consumer group a requeued message is for;
+	// consumers of other groups skip it
+	HeaderRequeuedFor = "requeued-for"
+)
+
+// maxDeadLetterError caps the error recorded on a dead letter, in bytes
+const maxDeadLetterError = 1024
+
+// Publisher publishes messages; it is implemented by *Producer
+type Publisher interface {
+	Publish(ctx context.Context, msgs ...Message) error
+}
+
+// DeadLetterTopic returns the dead-letter topic of topic
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterSuffix
+}
+
+// DeadLetter returns the dead letter of a consumed message that group failed
+// to handle with err: the message, on its topic's dead-letter topic, with
+// where it was read from and why it failed in its headers
+func DeadLetter(msg Message, group string, err error, failedAt time.Time) Message {
+	reason := err.Error()
+	if len(reason) > maxDeadLetterError {
+		reason = reason[:maxDeadLetterError]
+	}
+
+	headers := make(map[string]string, len(msg.Headers)+6)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	// A requeued message that fails again is dead-lettered afresh
+	delete(headers, HeaderRequeuedFor)
+	headers[HeaderDeadLetterTopic] = msg.Topic
+	headers[HeaderDeadLetterPartition] = strconv.Itoa(msg.Partition)
+	headers[HeaderDeadLetterOffset] = strconv.FormatInt(msg.Offset, 10)
+	headers[HeaderDeadLetterGroup] = group
+	headers[HeaderDeadLetterError] = reason
+	headers[HeaderDeadLetterFailedAt] = failedAt.UTC().Format(time.RFC3339Nano)
+
+	return Message{Topic: DeadLetterTopic(msg.Topic), Key: msg.Key, Value: msg.Value, Headers: headers}
+}
+
+// Requeue returns the message that puts a dead letter back on topic for
+// group alone to consume again
+func Requeue(topic, key string, value []byte, headers map[string]string, group string) Message {
+	requeued := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		if !strings.HasPrefix(k, "dlq-") {
+			requeued[k] = v
+		}
+	}
+	requeued[HeaderRequeuedFor] = group
+	return Message{Topic: topic, Key: key, Value: value, Headers: requeued}
+}
@@ -0,0 +1,111 @@
+// Package kafka provides the shared Kafka producer the services publish
+// domain events with.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Config holds the connection settings of a Kafka cluster
+type Config struct {
+	Brokers []string // host:port of one or more brokers
+	// WriteTimeout bounds a write to the brokers; 10 seconds when zero
+	WriteTimeout time.Duration
+}
+
+// ParseBrokers splits a comma-separated list of brokers, as found in the
+// KAFKA_BROKERS setting
+func ParseBrokers(s string) []string {
+	var brokers []string
+	for _, b := range strings.Split(s, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			brokers = append(brokers, b)
+		}
+	}
+	return brokers
+}
+
+// Message is a record to publish. Messages with the same key go to the same
+// partition, so consumers see them in the order they were published.
+type Message struct {
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers map[string]string
+	// Partition and Offset are where a consumed message was read from
+	Partition int
+	Offset    int64
+}
+
+// writer is implemented by *kafkago.Writer
+type writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
+// Producer publishes messages to Kafka. Writes wait for every in-sync replica
+// to acknowledge them.
+type Producer struct {
+	writer  writer
+	service string
+}
+
+// NewProducer creates a producer for service, which labels its metrics
+func NewProducer(cfg Config, service string) (*Producer, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	timeout := cfg.WriteTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Producer{
+		writer: &kafkago.Writer{
+			Addr:                   kafkago.TCP(cfg.Brokers...),
+			Balancer:               &kafkago.Hash{},
+			RequiredAcks:           kafkago.RequireAll,
+			WriteTimeout:           timeout,
+			AllowAutoTopicCreation: true,
+		},
+		service: service,
+	}, nil
+}
+
+// Publish writes msgs and returns once all of them are acknowledged. On error
+// some of them may have been written.
+func (p *Producer) Publish(ctx context.Context, msgs ...Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	records := make([]kafkago.Message, len(msgs))
+	for i, m := range msgs {
+		records[i] = kafkago.Message{Topic: m.Topic, Key: []byte(m.Key), Value: m.Value}
+		for _, k := range slices.Sorted(maps.Keys(m.Headers)) {
+			records[i].Headers = append(records[i].Headers, kafkago.Header{Key: k, Value: []byte(m.Headers[k])})
+		}
+	}
+
+	if err := p.writer.WriteMessages(ctx, records...); err != nil {
+		return fmt.Errorf("kafka: publish: %w", err)
+	}
+	for _, m := range msgs {
+		metrics.KafkaMessagesProduced.WithLabelValues(p.service, m.Topic).Inc()
+	}
+	return nil
+}
+
+// Close flushes pending writes and closes the connections to the brokers
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,80 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type fakeWriter struct {
+	written []kafkago.Message
+	err     error
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+func TestProducer_Publish(t *testing.T) {
+	w := &fakeWriter{}
+	p := &Producer{writer: w, service: "test-service"}
+
+	err := p.Publish(context.Background(), Message{
+		Topic:   "catalog-events",
+		Key:     "product-1",
+		Value:   []byte(`{"type":"product.created"}`),
+		Headers: map[string]string{"event-type": "product.created", "event-id": "e1"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(w.written) != 1 {
+		t.Fatalf("expected 1 message written, got %d", len(w.written))
+	}
+
+	got := w.written[0]
+	if got.Topic != "catalog-events" || string(got.Key) != "product-1" || string(got.Value) != `{"type":"product.created"}` {
+		t.Errorf("unexpected message %+v", got)
+	}
+	want := []kafkago.Header{
+		{Key: "event-id", Value: []byte("e1")},
+		{Key: "event-type", Value: []byte("product.created")},
+	}
+	if !reflect.DeepEqual(got.Headers, want) {
+		t.Errorf("expected headers %v, got %v", want, got.Headers)
+	}
+}
+
+func TestProducer_PublishError(t *testing.T) {
+	p := &Producer{writer: &fakeWriter{err: errors.New("broker down")}, service: "test-service"}
+
+	if err := p.Publish(context.Background(), Message{Topic: "t"}); err == nil {
+		t.Error("expected write error")
+	}
+}
+
+func TestNewProducer_RequiresBrokers(t *testing.T) {
+	if _, err := NewProducer(Config{}, "test-service"); err == nil {
+		t.Error("expected error without brokers")
+	}
+}
+
+func TestParseBrokers(t *testing.T) {
+	got := ParseBrokers(" kafka-1:9092, ,kafka-2:9092 ")
+	want := []string{"kafka-1:9092", "kafka-2:9092"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if ParseBrokers("") != nil {
+		t.Error("expected no brokers for empty setting")
+	}
+}
@@ -0,0 +1,165 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// ErrGroupActive is returned when committing offsets for a consumer group
+// that has members; its consumers must be stopped first
+var ErrGroupActive = errors.New("kafka: consumer group has active members")
+
+// PartitionOffset is the offset a consumer group resumes a partition from
+type PartitionOffset struct {
+	Topic     string
+	Partition int
+	Offset    int64
+}
+
+// admin is implemented by *kafkago.Client
+type admin interface {
+	Metadata(ctx context.Context, req *kafkago.MetadataRequest) (*kafkago.MetadataResponse, error)
+	ListOffsets(ctx context.Context, req *kafkago.ListOffsetsRequest) (*kafkago.ListOffsetsResponse, error)
+	DescribeGroups(ctx context.Context, req *kafkago.DescribeGroupsRequest) (*kafkago.DescribeGroupsResponse, error)
+	OffsetCommit(ctx context.Context, req *kafkago.OffsetCommitRequest) (*kafkago.OffsetCommitResponse, error)
+}
+
+// Rewinder moves the committed offsets of consumer groups, so that a group
+// consumes messages again from a point in time
+type Rewinder struct {
+	client admin
+}
+
+// NewRewinder creates a rewinder for the cluster of cfg
+func NewRewinder(cfg Config) (*Rewinder, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	timeout := cfg.WriteTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Rewinder{client: &kafkago.Client{Addr: kafkago.TCP(cfg.Brokers...), Timeout: timeout}}, nil
+}
+
+// OffsetsAt returns, for every partition of topics, the offset of the first
+// message at or after at. Partitions with no message since then get their
+// end offset, so nothing of theirs is consumed again.
+func (r *Rewinder) OffsetsAt(ctx context.Context, topics []string, at time.Time) ([]PartitionOffset, error) {
+	meta, err := r.client.Metadata(ctx, &kafkago.MetadataRequest{Topics: topics})
+	if err != nil {
+		return nil, fmt.Errorf("kafka: metadata: %w", err)
+	}
+
+	atTime := &kafkago.ListOffsetsRequest{Topics: map[string][]kafkago.OffsetRequest{}}
+	for _, t := range meta.Topics {
+		if t.Error != nil {
+			return nil, fmt.Errorf("kafka: topic %s: %w", t.Name, t.Error)
+		}
+		for _, p := range t.Partitions {
+			atTime.Topics[t.Name] = append(atTime.Topics[t.Name], kafkago.TimeOffsetOf(p.ID, at))
+		}
+	}
+	listed, err := r.listOffsets(ctx, atTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var offsets []PartitionOffset
+	atEnd := &kafkago.ListOffsetsRequest{Topics: map[string][]kafkago.OffsetRequest{}}
+	for topic, partitions := range listed.Topics {
+		for _, p := range partitions {
+			offset := int64(-1)
+			for o := range p.Offsets {
+				offset = o
+			}
+			if offset < 0 {
+				atEnd.Topics[topic] = append(atEnd.Topics[topic], kafkago.LastOffsetOf(p.Partition))
+				continue
+			}
+			offsets = append(offsets, PartitionOffset{Topic: topic, Partition: p.Partition, Offset: offset})
+		}
+	}
+
+	if len(atEnd.Topics) > 0 {
+		listed, err := r.listOffsets(ctx, atEnd)
+		if err != nil {
+			return nil, err
+		}
+		for topic, partitions := range listed.Topics {
+			for _, p := range partitions {
+				offsets = append(offsets, PartitionOffset{Topic: topic, Partition: p.Partition, Offset: p.LastOffset})
+			}
+		}
+	}
+
+	sort.Slice(offsets, func(i, j int) bool {
+		if offsets[i].Topic != offsets[j].Topic {
+			return offsets[i].Topic < offsets[j].Topic
+		}
+		return offsets[i].Partition < offsets[j].Partition
+	})
+	return offsets, nil
+}
+
+// listOffsets lists offsets and fails on the first partition error
+func (r *Rewinder) listOffsets(ctx context.Context, req *kafkago.ListOffsetsRequest) (*kafkago.ListOffsetsResponse, error) {
+	resp, err := r.client.ListOffsets(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: list offsets: %w", err)
+	}
+	for topic, partitions := range resp.Topics {
+		for _, p := range partitions {
+			if p.Error != nil {
+				return nil, fmt.Errorf("kafka: list offsets of %s/%d: %w", topic, p.Partition, p.Error)
+			}
+		}
+	}
+	return resp, nil
+}
+
+// Commit sets the committed offsets of group, which it resumes from when its
+// consumers next start. A group that does not exist yet is created. It
+// returns ErrGroupActive when the group has members, as they would overwrite
+// the offsets with their own.
+func (r *Rewinder) Commit(ctx context.Context, group string, offsets []PartitionOffset) error {
+	described, err := r.client.DescribeGroups(ctx, &kafkago.DescribeGroupsRequest{GroupIDs: []string{group}})
+	if err != nil {
+		return fmt.Errorf("kafka: describe group %s: %w", group, err)
+	}
+	for _, g := range described.Groups {
+		if g.Error != nil {
+			return fmt.Errorf("kafka: describe group %s: %w", group, g.Error)
+		}
+		if len(g.Members) > 0 {
+			return fmt.Errorf("%w: %s has %d", ErrGroupActive, group, len(g.Members))
+		}
+	}
+
+	req := &kafkago.OffsetCommitRequest{
+		GroupID: group,
+		// An empty group takes commits from outside any generation
+		GenerationID: -1,
+		Topics:       map[string][]kafkago.OffsetCommit{},
+	}
+	for _, o := range offsets {
+		req.Topics[o.Topic] = append(req.Topics[o.Topic], kafkago.OffsetCommit{Partition: o.Partition, Offset: o.Offset})
+	}
+	resp, err := r.client.OffsetCommit(ctx, req)
+	if err != nil {
+		return fmt.Errorf("kafka: commit offsets of %s: %w", group, err)
+	}
+	for topic, partitions := range resp.Topics {
+		for _, p := range partitions {
+			if p.Error != nil {
+				return fmt.Errorf("kafka: commit offset of %s/%d for %s: %w", topic, p.Partition, group, p.Error)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// fakeAdmin serves one topic with two partitions. Partition 1 has no message
+// after the requested time.
+type fakeAdmin struct {
+	members   int
+	committed *kafkago.OffsetCommitRequest
+}
+
+func (a *fakeAdmin) Metadata(ctx context.Context, req *kafkago.MetadataRequest) (*kafkago.MetadataResponse, error) {
+	return &kafkago.MetadataResponse{Topics: []kafkago.Topic{
+		{Name: "order-events", Partitions: []kafkago.Partition{{ID: 0}, {ID: 1}}},
+	}}, nil
+}
+
+func (a *fakeAdmin) ListOffsets(ctx context.Context, req *kafkago.ListOffsetsRequest) (*kafkago.ListOffsetsResponse, error) {
+	resp := &kafkago.ListOffsetsResponse{Topics: map[string][]kafkago.PartitionOffsets{}}
+	for topic, reqs := range req.Topics {
+		for _, r := range reqs {
+			p := kafkago.PartitionOffsets{Partition: r.Partition, Offsets: map[int64]time.Time{}}
+			switch {
+			case r.Timestamp == kafkago.LastOffset:
+				p.LastOffset = 90
+			case r.Partition == 0:
+				p.Offsets[42] = time.Now()
+			default:
+				p.Offsets[-1] = time.Time{}
+			}
+			resp.Topics[topic] = append(resp.Topics[topic], p)
+		}
+	}
+	return resp, nil
+}
+
+func (a *fakeAdmin) DescribeGroups(ctx context.Context, req *kafkago.DescribeGroupsRequest) (*kafkago.DescribeGroupsResponse, error) {
+	group := kafkago.DescribeGroupsResponseGroup{GroupID: req.GroupIDs[0], GroupState: "Empty"}
+	for i := 0; i < a.members; i++ {
+		group.Members = append(group.Members, kafkago.DescribeGroupsResponseMember{})
+	}
+	return &kafkago.DescribeGroupsResponse{Groups: []kafkago.DescribeGroupsResponseGroup{group}}, nil
+}
+
+func (a *fakeAdmin) OffsetCommit(ctx context.Context, req *kafkago.OffsetCommitRequest) (*kafkago.OffsetCommitResponse, error) {
+	a.committed = req
+	return &kafkago.OffsetCommitResponse{}, nil
+}
+
+func TestRewinder_OffsetsAt(t *testing.T) {
+	r := &Rewinder{client: &fakeAdmin{}}
+
+	offsets, err := r.OffsetsAt(context.Background(), []string{"order-events"}, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []PartitionOffset{{"order-events", 0, 42}, {"order-events", 1, 90}}
+	if !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("expected %v, got %v", expected, offsets)
+	}
+}
+
+func TestRewinder_Commit(t *testing.T) {
+	admin := &fakeAdmin{}
+	r := &Rewinder{client: admin}
+	offsets := []PartitionOffset{{"order-events", 0, 42}, {"order-events", 1, 90}}
+
+	if err := r.Commit(context.Background(), "search-projection", offsets); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if admin.committed.GroupID != "search-projection" || admin.committed.GenerationID != -1 || len(admin.committed.Topics["order-events"]) != 2 {
+		t.Errorf("unexpected commit %+v", admin.committed)
+	}
+
+	admin.members, admin.committed = 1, nil
+	if err := r.Commit(context.Background(), "search-projection", offsets); !errors.Is(err, ErrGroupActive) {
+		t.Errorf("expected ErrGroupActive, got %v", err)
+	}
+	if admin.committed != nil {
+		t.Error("expected nothing to be committed for an active group")
+	}
+}
@@ -31,3 +31,26 @@ func UnaryServerInterceptor(serviceName string) grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// StreamServerInterceptor returns a gRPC stream server interceptor for metrics.
+// The duration covers the whole stream.
+func StreamServerInterceptor(serviceName string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		duration := time.Since(start).Seconds()
+		statusCode := status.Code(err).String()
+
+		GRPCRequestsTotal.WithLabelValues(serviceName, info.FullMethod, statusCode).Inc()
+		GRPCRequestDuration.WithLabelValues(serviceName, info.FullMethod).Observe(duration)
+
+		return err
+	}
+}
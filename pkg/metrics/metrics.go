@@ -94,4 +94,68 @@ var (
 		},
 		[]string{"service", "topic", "status"},
 	)
+
+	// SecurityEventsTotal tracks security events such as detected traffic anomalies
+	SecurityEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "security_events_total",
+			Help: "Total security events emitted",
+		},
+		[]string{"service", "type", "kind"},
+	)
+
+	// StockEventsTotal tracks inventory events such as products falling to their low-stock threshold
+	StockEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stock_events_total",
+			Help: "Total inventory events emitted",
+		},
+		[]string{"service", "type"},
+	)
+
+	// ProbeRunsTotal tracks synthetic probe runs by journey and result
+	ProbeRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "probe_runs_total",
+			Help: "Total synthetic probe runs",
+		},
+		[]string{"journey", "result"},
+	)
+
+	// ProbeDuration tracks synthetic probe journey duration in seconds
+	ProbeDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "probe_duration_seconds",
+			Help:    "Synthetic probe journey duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"journey"},
+	)
+
+	// ProbeUp is 1 when the last run of a synthetic probe journey succeeded
+	ProbeUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "probe_up",
+			Help: "Whether the last synthetic probe run succeeded",
+		},
+		[]string{"journey"},
+	)
+
+	// RateLimitedTotal tracks requests rejected by rate limits
+	RateLimitedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limited_requests_total",
+			Help: "Total requests rejected by rate limits",
+		},
+		[]string{"service", "kind"},
+	)
+
+	// RealtimeConnections tracks open client connections to the realtime gateway
+	RealtimeConnections = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "realtime_connections",
+			Help: "Open realtime client connections",
+		},
+		[]string{"service", "transport"},
+	)
 )
@@ -0,0 +1,102 @@
+// Package outbox implements the transactional outbox. A service inserts the
+// events of a change into its outbox table in the same transaction as the
+// change, and a relay publishes them to Kafka afterwards: a committed change
+// always gets its events, and a rolled back one never does.
+//
+// Each service has its own outbox table, created by its migrations:
+//
+//	CREATE TABLE <service>_outbox (
+//	    id BIGSERIAL PRIMARY KEY,
+//	    event_id UUID NOT NULL UNIQUE,
+//	    topic VARCHAR(255) NOT NULL,
+//	    event_key VARCHAR(255) NOT NULL,
+//	    event_type VARCHAR(100) NOT NULL,
+//	    payload TEXT NOT NULL,
+//	    created_at TIMESTAMP NOT NULL,
+//	    published_at TIMESTAMP
+//	);
+//	CREATE INDEX idx_<service>_outbox_unpublished ON <service>_outbox(id) WHERE published_at IS NULL;
+//	CREATE INDEX idx_<service>_outbox_published_at ON <service>_outbox(published_at);
+//
+// Events are published at least once; consumers deduplicate them by ID.
+// Published events are kept for a week, during which they can be replayed.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a domain event to publish
+type Event struct {
+	// Type names what happened, e.g. product.updated
+	Type string
+	// Key is the ID of the entity the event is about. The events of one
+	// entity are published to the same partition, in order.
+	Key        string
+	OccurredAt time.Time
+	// Data is encoded as JSON
+	Data interface{}
+}
+
+// envelope is the JSON published for an event
+type envelope struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Execer is implemented by *sql.Tx and *sql.DB
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Outbox adds events bound for one topic to a service's outbox table
+type Outbox struct {
+	table string
+	topic string
+}
+
+// New creates an outbox writing to table, whose events are published to topic
+func New(table, topic string) *Outbox {
+	return &Outbox{table: table, topic: topic}
+}
+
+// Table returns the outbox table, for the relay that publishes it
+func (o *Outbox) Table() string {
+	return o.table
+}
+
+// Add inserts an event within tx, the transaction of the change it describes
+func (o *Outbox) Add(ctx context.Context, tx Execer, event Event) error {
+	if event.Type == "" || event.Key == "" {
+		return errors.New("outbox: event type and key are required")
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	id := uuid.New().String()
+	payload, err := json.Marshal(envelope{
+		ID:         id,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt.UTC(),
+		Data:       event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("outbox: encode %s event: %w", event.Type, err)
+	}
+
+	query := "INSERT INTO " + o.table + " (event_id, topic, event_key, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	if _, err := tx.ExecContext(ctx, query, id, o.topic, event.Key, event.Type, string(payload), event.OccurredAt); err != nil {
+		return fmt.Errorf("outbox: add %s event: %w", event.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// payloadArg captures the payload inserted for an event
+type payloadArg struct {
+	payload *envelope
+}
+
+func (a payloadArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && json.Unmarshal([]byte(s), a.payload) == nil
+}
+
+func TestOutbox_Add(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	var payload envelope
+	mock.ExpectExec(`INSERT INTO catalog_outbox \(event_id, topic, event_key, event_type, payload, created_at\)`).
+		WithArgs(sqlmock.AnyArg(), "catalog-events", "p1", "product.created", payloadArg{&payload}, at).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	o := New("catalog_outbox", "catalog-events")
+	err = o.Add(context.Background(), db, Event{
+		Type:       "product.created",
+		Key:        "p1",
+		OccurredAt: at,
+		Data:       map[string]string{"sku": "SKU-1"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+
+	if payload.ID == "" || payload.Type != "product.created" || !payload.OccurredAt.Equal(at) {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+	if data, _ := payload.Data.(map[string]interface{}); data["sku"] != "SKU-1" {
+		t.Errorf("Expected data to be published, got %v", payload.Data)
+	}
+}
+
+func TestOutbox_AddRequiresTypeAndKey(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	o := New("catalog_outbox", "catalog-events")
+	if err := o.Add(context.Background(), db, Event{Type: "product.created"}); err == nil {
+		t.Error("Expected error for event without key")
+	}
+	if err := o.Add(context.Background(), db, Event{Key: "p1"}); err == nil {
+		t.Error("Expected error for event without type")
+	}
+}
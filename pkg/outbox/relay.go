@@ -0,0 +1,164 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+const (
+	// relayBatchSize is how many events are published per pass
+	relayBatchSize = 100
+	// retention is how long published events are kept before they are purged
+	retention = 7 * 24 * time.Hour
+	// purgeInterval is how often published events are purged
+	purgeInterval = time.Hour
+)
+
+// Headers set on every published message
+const (
+	HeaderEventID   = "event-id"
+	HeaderEventType = "event-type"
+)
+
+// Publisher publishes messages; it is implemented by *kafka.Producer
+type Publisher interface {
+	Publish(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Relay publishes the events in an outbox table, oldest first, and marks them
+// published. Several instances of a service may relay the same table: events
+// are claimed with SKIP LOCKED, so each is published by one of them.
+type Relay struct {
+	db        *sql.DB
+	table     string
+	publisher Publisher
+	log       *logger.Logger
+	now       func() time.Time
+}
+
+// NewRelay creates a relay publishing the events of table
+func NewRelay(db *sql.DB, table string, publisher Publisher, log *logger.Logger) *Relay {
+	return &Relay{
+		db:        db,
+		table:     table,
+		publisher: publisher,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// RelayPending publishes a batch of unpublished events and returns how many
+// were published. The batch stays locked while it is published; if
+// publishing fails it is left unpublished and tried again on the next pass.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, event_id, topic, event_key, event_type, payload
+		FROM ` + r.table + `
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.QueryContext(ctx, query, relayBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: read pending events: %w", err)
+	}
+
+	var ids []int64
+	var msgs []kafka.Message
+	for rows.Next() {
+		var id int64
+		var eventID, eventType, payload string
+		msg := kafka.Message{}
+		if err := rows.Scan(&id, &eventID, &msg.Topic, &msg.Key, &eventType, &payload); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("outbox: scan event: %w", err)
+		}
+		msg.Value = []byte(payload)
+		msg.Headers = map[string]string{HeaderEventID: eventID, HeaderEventType: eventType}
+		ids = append(ids, id)
+		msgs = append(msgs, msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("outbox: read pending events: %w", err)
+	}
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+
+	if err := r.publisher.Publish(ctx, msgs...); err != nil {
+		return 0, err
+	}
+
+	// The events are out; should marking them fail they are published again
+	// on the next pass
+	update := "UPDATE " + r.table + " SET published_at = $1 WHERE id = ANY($2)"
+	if _, err := tx.ExecContext(ctx, update, r.now(), pq.Array(ids)); err != nil {
+		return 0, fmt.Errorf("outbox: mark events published: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("outbox: mark events published: %w", err)
+	}
+	return len(msgs), nil
+}
+
+// Purge deletes the events published before before and returns how many were
+// deleted
+func (r *Relay) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM "+r.table+" WHERE published_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: purge published events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// Run publishes pending events every interval until ctx is cancelled. A full
+// batch is followed at once by the next. Published events are purged after
+// the retention period.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for {
+		for {
+			n, err := r.RelayPending(ctx)
+			if err != nil {
+				r.log.Error(ctx, "Failed to relay outbox events", map[string]interface{}{"error": err.Error(), "table": r.table})
+			}
+			if err != nil || n < relayBatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if now := r.now(); now.Sub(lastPurge) >= purgeInterval {
+			if n, err := r.Purge(ctx, now.Add(-retention)); err != nil {
+				r.log.Error(ctx, "Failed to purge outbox events", map[string]interface{}{"error": err.Error(), "table": r.table})
+			} else {
+				lastPurge = now
+				if n > 0 {
+					r.log.Info(ctx, "Purged published outbox events", map[string]interface{}{"count": n, "table": r.table})
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+type fakePublisher struct {
+	published []kafka.Message
+	err       error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, msgs ...kafka.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, msgs...)
+	return nil
+}
+
+var outboxColumns = []string{"id", "event_id", "topic", "event_key", "event_type", "payload"}
+
+func newTestRelay(t *testing.T, publisher Publisher) (*Relay, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	relay := NewRelay(db, "account_outbox", publisher, logger.New("outbox-test"))
+	relay.now = func() time.Time { return time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return relay, mock
+}
+
+func TestRelay_RelayPending(t *testing.T) {
+	publisher := &fakePublisher{}
+	relay, mock := newTestRelay(t, publisher)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM account_outbox WHERE published_at IS NULL ORDER BY id LIMIT \$1 FOR UPDATE SKIP LOCKED`).
+		WithArgs(relayBatchSize).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow(1, "e1", "account-events", "a1", "account.created", `{"id":"e1"}`).
+			AddRow(2, "e2", "account-events", "a1", "account.updated", `{"id":"e2"}`))
+	mock.ExpectExec(`UPDATE account_outbox SET published_at = \$1 WHERE id = ANY\(\$2\)`).
+		WithArgs(relay.now(), "{1,2}").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	n, err := relay.RelayPending(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 events published, got %d", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+
+	if len(publisher.published) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(publisher.published))
+	}
+	msg := publisher.published[0]
+	if msg.Topic != "account-events" || msg.Key != "a1" || string(msg.Value) != `{"id":"e1"}` {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+	if msg.Headers[HeaderEventID] != "e1" || msg.Headers[HeaderEventType] != "account.created" {
+		t.Errorf("Unexpected headers %v", msg.Headers)
+	}
+}
+
+func TestRelay_RelayPendingPublishFailure(t *testing.T) {
+	relay, mock := newTestRelay(t, &fakePublisher{err: errors.New("broker down")})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM account_outbox`).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow(1, "e1", "account-events", "a1", "account.created", `{}`))
+	// Nothing is marked published: the events are tried again
+	mock.ExpectRollback()
+
+	if _, err := relay.RelayPending(context.Background()); err == nil {
+		t.Error("Expected publish error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRelay_RelayPendingNothingPending(t *testing.T) {
+	publisher := &fakePublisher{}
+	relay, mock := newTestRelay(t, publisher)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM account_outbox`).
+		WillReturnRows(sqlmock.NewRows(outboxColumns))
+	mock.ExpectRollback()
+
+	n, err := relay.RelayPending(context.Background())
+	if err != nil || n != 0 {
+		t.Errorf("Expected nothing published, got %d, %v", n, err)
+	}
+	if len(publisher.published) != 0 {
+		t.Errorf("Expected no messages, got %d", len(publisher.published))
+	}
+}
+
+func TestRelay_Purge(t *testing.T) {
+	relay, mock := newTestRelay(t, &fakePublisher{})
+
+	before := relay.now().Add(-retention)
+	mock.ExpectExec(`DELETE FROM account_outbox WHERE published_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	n, err := relay.Purge(context.Background(), before)
+	if err != nil || n != 3 {
+		t.Errorf("Expected 3 events purged, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/lib/pq"
+)
+
+// HeaderReplayed is set to "true" on messages published again by Replay
+const HeaderReplayed = "replayed"
+
+// ReplayFilter selects the published events to publish again
+type ReplayFilter struct {
+	// Since and Until bound when the events occurred, Until excluded; a zero
+	// time leaves that end open
+	Since time.Time
+	Until time.Time
+	// Types limits the replay to the given event types; empty replays every type
+	Types []string
+	// Topic replaces the topic of every event when set, e.g. to feed a
+	// projection being rebuilt without redelivering to the other consumers
+	Topic string
+}
+
+// Replay publishes the events of the outbox table matching filter again,
+// oldest first, and returns how many were published. Only published events
+// are replayed, so only those within the retention period: unpublished ones
+// are left to the relay. Replayed events keep their IDs.
+func (r *Relay) Replay(ctx context.Context, filter ReplayFilter) (int, error) {
+	query := `
+		SELECT id, event_id, topic, event_key, event_type, payload
+		FROM ` + r.table + `
+		WHERE id > $1 AND published_at IS NOT NULL
+			AND ($2::timestamp IS NULL OR created_at >= $2)
+			AND ($3::timestamp IS NULL OR created_at < $3)
+			AND (cardinality($4::text[]) = 0 OR event_type = ANY($4))
+		ORDER BY id
+		LIMIT $5
+	`
+	since := sql.NullTime{Time: filter.Since, Valid: !filter.Since.IsZero()}
+	until := sql.NullTime{Time: filter.Until, Valid: !filter.Until.IsZero()}
+	types := pq.Array(filter.Types)
+	if filter.Types == nil {
+		types = pq.Array([]string{})
+	}
+
+	replayed := 0
+	var after int64
+	for {
+		rows, err := r.db.QueryContext(ctx, query, after, since, until, types, relayBatchSize)
+		if err != nil {
+			return replayed, fmt.Errorf("outbox: read events to replay: %w", err)
+		}
+
+		var msgs []kafka.Message
+		for rows.Next() {
+			var eventID, eventType, payload string
+			msg := kafka.Message{}
+			if err := rows.Scan(&after, &eventID, &msg.Topic, &msg.Key, &eventType, &payload); err != nil {
+				rows.Close()
+				return replayed, fmt.Errorf("outbox: scan event: %w", err)
+			}
+			if filter.Topic != "" {
+				msg.Topic = filter.Topic
+			}
+			msg.Value = []byte(payload)
+			msg.Headers = map[string]string{HeaderEventID: eventID, HeaderEventType: eventType, HeaderReplayed: "true"}
+			msgs = append(msgs, msg)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return replayed, fmt.Errorf("outbox: read events to replay: %w", err)
+		}
+		if len(msgs) == 0 {
+			return replayed, nil
+		}
+
+		if err := r.publisher.Publish(ctx, msgs...); err != nil {
+			return replayed, err
+		}
+		replayed += len(msgs)
+		r.log.Info(ctx, "Replayed outbox events", map[string]interface{}{"table": r.table, "count": len(msgs), "total": replayed})
+
+		if len(msgs) < relayBatchSize {
+			return replayed, nil
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestRelay_Replay(t *testing.T) {
+	publisher := &fakePublisher{}
+	relay, mock := newTestRelay(t, publisher)
+	since := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT (.+) FROM account_outbox WHERE id > \$1 AND published_at IS NOT NULL (.+) ORDER BY id LIMIT \$5`).
+		WithArgs(0, since, nil, `{"account.created"}`, relayBatchSize).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow(3, "e3", "account-events", "a1", "account.created", `{"id":"e3"}`).
+			AddRow(7, "e7", "account-events", "a2", "account.created", `{"id":"e7"}`))
+
+	n, err := relay.Replay(context.Background(), ReplayFilter{Since: since, Types: []string{"account.created"}, Topic: "account-events.search"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 2 || len(publisher.published) != 2 {
+		t.Fatalf("Expected 2 events replayed, got %d", n)
+	}
+	msg := publisher.published[1]
+	if msg.Topic != "account-events.search" || msg.Key != "a2" || string(msg.Value) != `{"id":"e7"}` {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+	if msg.Headers[HeaderEventID] != "e7" || msg.Headers[HeaderReplayed] != "true" {
+		t.Errorf("Unexpected headers %v", msg.Headers)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRelay_ReplayPagesThroughBatches(t *testing.T) {
+	publisher := &fakePublisher{}
+	relay, mock := newTestRelay(t, publisher)
+
+	full := sqlmock.NewRows(outboxColumns)
+	for i := 1; i <= relayBatchSize; i++ {
+		full.AddRow(i, "e", "account-events", "a1", "account.updated", `{}`)
+	}
+	mock.ExpectQuery(`SELECT (.+) FROM account_outbox`).
+		WithArgs(0, nil, nil, "{}", relayBatchSize).
+		WillReturnRows(full)
+	mock.ExpectQuery(`SELECT (.+) FROM account_outbox`).
+		WithArgs(relayBatchSize, nil, nil, "{}", relayBatchSize).
+		WillReturnRows(sqlmock.NewRows(outboxColumns))
+
+	n, err := relay.Replay(context.Background(), ReplayFilter{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != relayBatchSize || publisher.published[0].Topic != "account-events" {
+		t.Errorf("Expected %d events replayed to their own topic, got %d", relayBatchSize, n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
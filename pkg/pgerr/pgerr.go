@@ -0,0 +1,47 @@
+// Package pgerr classifies the errors Postgres returns to the repositories.
+// It recognises the errors of both drivers in use, lib/pq and pgx.
+package pgerr
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+// IsMalformedID reports whether err is Postgres rejecting an ID that is not a
+// valid UUID; such an ID matches no row
+func IsMalformedID(err error) bool {
+	code, _ := classify(err)
+	return code == "22P02"
+}
+
+// IsUniqueViolation reports whether err is Postgres rejecting a duplicate key
+// of the named unique constraint or index. Naming it keeps a duplicate of
+// another key, such as a primary key, from being mistaken for the one the
+// caller handles.
+func IsUniqueViolation(err error, constraint string) bool {
+	code, name := classify(err)
+	return code == "23505" && name == constraint
+}
+
+// IsForeignKeyViolation reports whether err is Postgres rejecting a reference
+// to a row that does not exist
+func IsForeignKeyViolation(err error) bool {
+	code, _ := classify(err)
+	return code == "23503"
+}
+
+// classify returns the SQLSTATE code and the constraint name of a Postgres
+// error, or empty strings when err is not one
+func classify(err error) (code, constraint string) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code), pqErr.Constraint
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code, pgErr.ConstraintName
+	}
+	return "", ""
+}
@@ -0,0 +1,72 @@
+package pgerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+func TestIsMalformedID(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"invalid text representation", &pq.Error{Code: "22P02"}, true},
+		{"wrapped", fmt.Errorf("failed to get order: %w", &pq.Error{Code: "22P02"}), true},
+		{"pgx", &pgconn.PgError{Code: "22P02"}, true},
+		{"unique violation", &pq.Error{Code: "23505"}, false},
+		{"not a Postgres error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsMalformedID(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"named constraint", &pq.Error{Code: "23505", Constraint: "products_sku_key"}, true},
+		{"wrapped", fmt.Errorf("failed to create product: %w", &pq.Error{Code: "23505", Constraint: "products_sku_key"}), true},
+		{"pgx", &pgconn.PgError{Code: "23505", ConstraintName: "products_sku_key"}, true},
+		{"other constraint", &pq.Error{Code: "23505", Constraint: "products_pkey"}, false},
+		{"pgx other constraint", &pgconn.PgError{Code: "23505", ConstraintName: "products_pkey"}, false},
+		{"foreign key violation", &pq.Error{Code: "23503", Constraint: "products_sku_key"}, false},
+		{"not a Postgres error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsUniqueViolation(tt.err, "products_sku_key"); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestIsForeignKeyViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"foreign key violation", &pq.Error{Code: "23503"}, true},
+		{"wrapped", fmt.Errorf("failed to subscribe: %w", &pq.Error{Code: "23503"}), true},
+		{"pgx", &pgconn.PgError{Code: "23503"}, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"not a Postgres error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsForeignKeyViolation(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
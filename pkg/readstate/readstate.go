@@ -0,0 +1,215 @@
+// Package readstate keeps the in-app notification read state of users in sync
+// across devices. Read state is a last-read timestamp per channel; devices
+// merge their marks by keeping the later timestamp, so concurrent updates from
+// several devices converge without conflicts.
+package readstate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Marks maps a notification channel to the time up to which it has been read
+type Marks map[string]time.Time
+
+// Merge returns the marks of m and other, keeping the later mark of each
+// channel. Merging is commutative, associative and idempotent, so devices
+// can sync in any order and any number of times.
+func (m Marks) Merge(other Marks) Marks {
+	merged := make(Marks, len(m)+len(other))
+	for channel, at := range m {
+		merged[channel] = at
+	}
+	for channel, at := range other {
+		if current, ok := merged[channel]; !ok || at.After(current) {
+			merged[channel] = at
+		}
+	}
+	return merged
+}
+
+// IsRead reports whether a notification sent to channel at the given time has been read
+func (m Marks) IsRead(channel string, at time.Time) bool {
+	mark, ok := m[channel]
+	return ok && !at.After(mark)
+}
+
+// Store persists the read marks of users
+type Store interface {
+	// Get returns the read marks of a user
+	Get(ctx context.Context, userID string) (Marks, error)
+	// Merge merges marks into the stored marks of a user and returns the result
+	Merge(ctx context.Context, userID string, marks Marks) (Marks, error)
+}
+
+// MemoryStore keeps read marks in memory, for tests and single-instance use
+type MemoryStore struct {
+	mu    sync.Mutex
+	marks map[string]Marks
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{marks: make(map[string]Marks)}
+}
+
+// Get returns the read marks of a user
+func (s *MemoryStore) Get(ctx context.Context, userID string) (Marks, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.marks[userID].Merge(nil), nil
+}
+
+// Merge merges marks into the stored marks of a user
+func (s *MemoryStore) Merge(ctx context.Context, userID string, marks Marks) (Marks, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marks[userID] = s.marks[userID].Merge(marks)
+	return s.marks[userID].Merge(nil), nil
+}
+
+// DefaultRetention is how long RedisStore keeps notifications for unread counts
+const DefaultRetention = 30 * 24 * time.Hour
+
+// Marks and notification times are stored as Unix microseconds, which Lua
+// numbers and sorted set scores represent exactly
+
+// mergeScript sets each field of a hash to the greater of its current and
+// given value, so concurrent merges cannot move a mark backwards
+var mergeScript = redis.NewScript(`
+for i = 1, #ARGV, 2 do
+	local current = tonumber(redis.call('HGET', KEYS[1], ARGV[i]) or '0')
+	if tonumber(ARGV[i + 1]) > current then
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+	end
+end
+return redis.call('HGETALL', KEYS[1])
+`)
+
+// RedisStore keeps read marks in Redis together with the recent notifications
+// of each user, so unread counts are served without touching the database.
+// Keys are namespaced with a prefix.
+type RedisStore struct {
+	client    redis.Cmdable
+	prefix    string
+	retention time.Duration
+}
+
+// NewRedisStore creates a store under prefix that keeps notifications for
+// retention, or DefaultRetention when zero
+func NewRedisStore(client redis.Cmdable, prefix string, retention time.Duration) *RedisStore {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &RedisStore{client: client, prefix: prefix, retention: retention}
+}
+
+// Get returns the read marks of a user
+func (s *RedisStore) Get(ctx context.Context, userID string) (Marks, error) {
+	fields, err := s.client.HGetAll(ctx, s.marksKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("readstate: get %s: %w", userID, err)
+	}
+
+	marks := make(Marks, len(fields))
+	for channel, value := range fields {
+		micros, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("readstate: invalid mark for %s/%s: %w", userID, channel, err)
+		}
+		marks[channel] = time.UnixMicro(micros).UTC()
+	}
+	return marks, nil
+}
+
+// Merge atomically merges marks into the stored marks of a user
+func (s *RedisStore) Merge(ctx context.Context, userID string, marks Marks) (Marks, error) {
+	args := make([]interface{}, 0, 2*len(marks))
+	for channel, at := range marks {
+		args = append(args, channel, at.UnixMicro())
+	}
+
+	result, err := mergeScript.Run(ctx, s.client, []string{s.marksKey(userID)}, args...).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("readstate: merge %s: %w", userID, err)
+	}
+
+	merged := make(Marks, len(result)/2)
+	for i := 0; i+1 < len(result); i += 2 {
+		micros, err := strconv.ParseInt(result[i+1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("readstate: invalid mark for %s/%s: %w", userID, result[i], err)
+		}
+		merged[result[i]] = time.UnixMicro(micros).UTC()
+	}
+	return merged, nil
+}
+
+// Record adds a notification to a user's channel for unread counting.
+// Notifications older than the retention are dropped.
+func (s *RedisStore) Record(ctx context.Context, userID, channel, notificationID string, at time.Time) error {
+	key := s.inboxKey(userID, channel)
+	cutoff := at.Add(-s.retention).UnixMicro()
+
+	pipe := s.client.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(at.UnixMicro()), Member: notificationID})
+	pipe.ZRemRangeByScore(ctx, key, "-inf", "("+strconv.FormatInt(cutoff, 10))
+	pipe.Expire(ctx, key, s.retention)
+	pipe.SAdd(ctx, s.channelsKey(userID), channel)
+	pipe.Expire(ctx, s.channelsKey(userID), s.retention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("readstate: record %s/%s: %w", userID, channel, err)
+	}
+	return nil
+}
+
+// UnreadCounts returns the number of unread notifications in each channel of
+// a user that has any
+func (s *RedisStore) UnreadCounts(ctx context.Context, userID string) (map[string]int64, error) {
+	marks, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := s.client.SMembers(ctx, s.channelsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("readstate: channels of %s: %w", userID, err)
+	}
+
+	pipe := s.client.Pipeline()
+	counts := make(map[string]*redis.IntCmd, len(channels))
+	for _, channel := range channels {
+		min := "-inf"
+		if mark, ok := marks[channel]; ok {
+			min = "(" + strconv.FormatInt(mark.UnixMicro(), 10)
+		}
+		counts[channel] = pipe.ZCount(ctx, s.inboxKey(userID, channel), min, "+inf")
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("readstate: unread counts of %s: %w", userID, err)
+	}
+
+	unread := make(map[string]int64, len(counts))
+	for channel, cmd := range counts {
+		if n := cmd.Val(); n > 0 {
+			unread[channel] = n
+		}
+	}
+	return unread, nil
+}
+
+func (s *RedisStore) marksKey(userID string) string {
+	return s.prefix + "marks:" + userID
+}
+
+func (s *RedisStore) inboxKey(userID, channel string) string {
+	return s.prefix + "inbox:" + userID + ":" + channel
+}
+
+func (s *RedisStore) channelsKey(userID string) string {
+	return s.prefix + "channels:" + userID
+}
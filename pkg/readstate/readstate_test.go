@@ -0,0 +1,142 @@
+package readstate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var base = time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestRedisStore(t *testing.T) *RedisStore {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisStore(client, "test:", time.Hour)
+}
+
+func TestMarks_MergeIsOrderIndependent(t *testing.T) {
+	phone := Marks{"orders": base.Add(time.Minute), "promotions": base}
+	laptop := Marks{"orders": base, "security": base.Add(2 * time.Minute)}
+
+	a := phone.Merge(laptop)
+	b := laptop.Merge(phone)
+
+	for _, merged := range []Marks{a, b, a.Merge(b), a.Merge(a)} {
+		if len(merged) != 3 || !merged["orders"].Equal(base.Add(time.Minute)) || !merged["security"].Equal(base.Add(2*time.Minute)) {
+			t.Errorf("unexpected merge result %v", merged)
+		}
+	}
+}
+
+func TestMarks_IsRead(t *testing.T) {
+	marks := Marks{"orders": base}
+
+	if !marks.IsRead("orders", base) || marks.IsRead("orders", base.Add(time.Second)) {
+		t.Error("expected notifications up to the mark to be read")
+	}
+	if marks.IsRead("promotions", base) {
+		t.Error("expected channels without a mark to be unread")
+	}
+}
+
+func TestStores_MergeNeverMovesBackwards(t *testing.T) {
+	stores := map[string]Store{
+		"memory": NewMemoryStore(),
+		"redis":  newTestRedisStore(t),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if _, err := store.Merge(ctx, "u1", Marks{"orders": base.Add(time.Minute)}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			// A stale device syncs an older mark
+			merged, err := store.Merge(ctx, "u1", Marks{"orders": base, "security": base})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !merged["orders"].Equal(base.Add(time.Minute)) || !merged["security"].Equal(base) {
+				t.Errorf("unexpected merged marks %v", merged)
+			}
+
+			stored, err := store.Get(ctx, "u1")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(stored) != 2 || !stored["orders"].Equal(base.Add(time.Minute)) {
+				t.Errorf("unexpected stored marks %v", stored)
+			}
+
+			empty, err := store.Get(ctx, "u2")
+			if err != nil || len(empty) != 0 {
+				t.Errorf("expected no marks for a new user, got %v, %v", empty, err)
+			}
+		})
+	}
+}
+
+func TestRedisStore_UnreadCounts(t *testing.T) {
+	store := newTestRedisStore(t)
+	ctx := context.Background()
+
+	for i, n := range []struct {
+		channel string
+		at      time.Time
+	}{
+		{"orders", base},
+		{"orders", base.Add(time.Minute)},
+		{"orders", base.Add(2 * time.Minute)},
+		{"promotions", base},
+	} {
+		if err := store.Record(ctx, "u1", n.channel, string(rune('a'+i)), n.at); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if _, err := store.Merge(ctx, "u1", Marks{"orders": base.Add(time.Minute)}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	unread, err := store.UnreadCounts(ctx, "u1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(unread) != 2 || unread["orders"] != 1 || unread["promotions"] != 1 {
+		t.Errorf("unexpected unread counts %v", unread)
+	}
+
+	if _, err := store.Merge(ctx, "u1", Marks{"orders": base.Add(time.Hour), "promotions": base.Add(time.Hour)}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	unread, err = store.UnreadCounts(ctx, "u1")
+	if err != nil || len(unread) != 0 {
+		t.Errorf("expected everything read, got %v, %v", unread, err)
+	}
+}
+
+func TestRedisStore_RecordDropsExpiredNotifications(t *testing.T) {
+	store := newTestRedisStore(t)
+	ctx := context.Background()
+
+	if err := store.Record(ctx, "u1", "orders", "old", base); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := store.Record(ctx, "u1", "orders", "new", base.Add(2*time.Hour)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	unread, err := store.UnreadCounts(ctx, "u1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if unread["orders"] != 1 {
+		t.Errorf("expected only the notification within retention, got %v", unread)
+	}
+}
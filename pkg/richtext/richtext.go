@@ -0,0 +1,224 @@
+// Package richtext implements structured rich content blocks for product descriptions.
+// Blocks are sanitized on write by the owning service and rendered to HTML or plain
+// text by readers such as the API gateway.
+package richtext
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Block types
+const (
+	Paragraph   = "paragraph"
+	Heading     = "heading"
+	List        = "list"
+	OrderedList = "ordered_list"
+	Quote       = "quote"
+	Image       = "image"
+)
+
+const (
+	// MaxBlocks is the maximum number of blocks in a document
+	MaxBlocks = 100
+	// MaxTextLength is the maximum length of a block's text or list item
+	MaxTextLength = 5000
+	// MaxListItems is the maximum number of items in a list block
+	MaxListItems = 50
+)
+
+var (
+	// ErrInvalidBlock is returned when a block fails validation
+	ErrInvalidBlock = errors.New("invalid content block")
+)
+
+// Block is a single structured content block. Text may contain inline
+// markdown marks: **bold**, _italic_ and [label](https://link).
+type Block struct {
+	Type  string   `json:"type"`
+	Text  string   `json:"text,omitempty"`
+	Level int32    `json:"level,omitempty"` // heading level 1-6
+	Items []string `json:"items,omitempty"` // list items
+	URL   string   `json:"url,omitempty"`   // image source
+	Alt   string   `json:"alt,omitempty"`   // image alt text
+}
+
+var (
+	tagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+	boldPattern   = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italicPattern = regexp.MustCompile(`(^|\W)_([^_]+)_(\W|$)`)
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// Sanitize validates blocks and strips markup and control characters from their text.
+// The returned slice is safe to store and render.
+func Sanitize(blocks []Block) ([]Block, error) {
+	if len(blocks) > MaxBlocks {
+		return nil, fmt.Errorf("%w: at most %d blocks are allowed", ErrInvalidBlock, MaxBlocks)
+	}
+
+	clean := make([]Block, 0, len(blocks))
+	for i, b := range blocks {
+		out := Block{Type: b.Type}
+
+		switch b.Type {
+		case Paragraph, Quote:
+			out.Text = cleanText(b.Text)
+			if out.Text == "" {
+				continue
+			}
+		case Heading:
+			if b.Level < 1 || b.Level > 6 {
+				return nil, fmt.Errorf("%w: block %d: heading level must be 1-6", ErrInvalidBlock, i)
+			}
+			out.Level = b.Level
+			out.Text = cleanText(b.Text)
+			if out.Text == "" {
+				return nil, fmt.Errorf("%w: block %d: heading text is required", ErrInvalidBlock, i)
+			}
+		case List, OrderedList:
+			if len(b.Items) > MaxListItems {
+				return nil, fmt.Errorf("%w: block %d: at most %d list items are allowed", ErrInvalidBlock, i, MaxListItems)
+			}
+			for _, item := range b.Items {
+				if item = cleanText(item); item != "" {
+					out.Items = append(out.Items, item)
+				}
+			}
+			if len(out.Items) == 0 {
+				continue
+			}
+		case Image:
+			if !isSafeURL(b.URL) {
+				return nil, fmt.Errorf("%w: block %d: image url must be http or https", ErrInvalidBlock, i)
+			}
+			out.URL = b.URL
+			out.Alt = cleanText(b.Alt)
+		default:
+			return nil, fmt.Errorf("%w: block %d: unsupported type %q", ErrInvalidBlock, i, b.Type)
+		}
+
+		if len(out.Text) > MaxTextLength {
+			return nil, fmt.Errorf("%w: block %d: text exceeds %d characters", ErrInvalidBlock, i, MaxTextLength)
+		}
+		for _, item := range out.Items {
+			if len(item) > MaxTextLength {
+				return nil, fmt.Errorf("%w: block %d: list item exceeds %d characters", ErrInvalidBlock, i, MaxTextLength)
+			}
+		}
+
+		clean = append(clean, out)
+	}
+
+	return clean, nil
+}
+
+// RenderHTML renders sanitized blocks to HTML. All text is escaped before
+// inline marks are applied, and links are limited to http, https and mailto.
+func RenderHTML(blocks []Block) string {
+	var b strings.Builder
+	for _, block := range blocks {
+		switch block.Type {
+		case Paragraph:
+			fmt.Fprintf(&b, "<p>%s</p>", renderInline(block.Text))
+		case Heading:
+			fmt.Fprintf(&b, "<h%d>%s</h%d>", block.Level, renderInline(block.Text), block.Level)
+		case Quote:
+			fmt.Fprintf(&b, "<blockquote>%s</blockquote>", renderInline(block.Text))
+		case List, OrderedList:
+			tag := "ul"
+			if block.Type == OrderedList {
+				tag = "ol"
+			}
+			fmt.Fprintf(&b, "<%s>", tag)
+			for _, item := range block.Items {
+				fmt.Fprintf(&b, "<li>%s</li>", renderInline(item))
+			}
+			fmt.Fprintf(&b, "</%s>", tag)
+		case Image:
+			if isSafeURL(block.URL) {
+				fmt.Fprintf(&b, `<img src="%s" alt="%s">`, html.EscapeString(block.URL), html.EscapeString(block.Alt))
+			}
+		}
+	}
+	return b.String()
+}
+
+// RenderPlainText renders blocks to plain text without inline marks, one block per paragraph
+func RenderPlainText(blocks []Block) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		switch block.Type {
+		case Paragraph, Heading, Quote:
+			parts = append(parts, stripInline(block.Text))
+		case List, OrderedList:
+			items := make([]string, len(block.Items))
+			for i, item := range block.Items {
+				prefix := "- "
+				if block.Type == OrderedList {
+					prefix = fmt.Sprintf("%d. ", i+1)
+				}
+				items[i] = prefix + stripInline(item)
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		case Image:
+			if block.Alt != "" {
+				parts = append(parts, block.Alt)
+			}
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// cleanText removes HTML tags and control characters and trims whitespace
+func cleanText(s string) string {
+	s = tagPattern.ReplaceAllString(s, "")
+	s = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// renderInline escapes text and converts inline markdown marks to HTML
+func renderInline(s string) string {
+	s = html.EscapeString(s)
+	s = linkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		parts := linkPattern.FindStringSubmatch(m)
+		href := html.UnescapeString(parts[2])
+		if !isSafeURL(href) && !strings.HasPrefix(href, "mailto:") {
+			return parts[1]
+		}
+		return fmt.Sprintf(`<a href="%s" rel="nofollow noopener">%s</a>`, html.EscapeString(href), parts[1])
+	})
+	s = boldPattern.ReplaceAllString(s, "<strong>$1</strong>")
+	s = italicPattern.ReplaceAllString(s, "$1<em>$2</em>$3")
+	return s
+}
+
+// stripInline removes inline markdown marks, keeping link labels
+func stripInline(s string) string {
+	s = linkPattern.ReplaceAllString(s, "$1")
+	s = boldPattern.ReplaceAllString(s, "$1")
+	s = italicPattern.ReplaceAllString(s, "$1$2$3")
+	return s
+}
+
+// isSafeURL reports whether u is an absolute http or https URL
+func isSafeURL(u string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
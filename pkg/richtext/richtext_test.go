@@ -0,0 +1,114 @@
+package richtext
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSanitize_StripsMarkup(t *testing.T) {
+	blocks := []Block{
+		{Type: Paragraph, Text: "Hello <script>alert(1)</script>world\x00"},
+		{Type: List, Items: []string{"<b>one</b>", "  ", "two"}},
+		{Type: Paragraph, Text: "   "},
+	}
+
+	clean, err := Sanitize(blocks)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(clean) != 2 {
+		t.Fatalf("expected empty blocks to be dropped, got %d blocks", len(clean))
+	}
+
+	if clean[0].Text != "Hello alert(1)world" {
+		t.Errorf("expected tags and control characters removed, got %q", clean[0].Text)
+	}
+
+	if len(clean[1].Items) != 2 || clean[1].Items[0] != "one" {
+		t.Errorf("expected cleaned list items, got %v", clean[1].Items)
+	}
+}
+
+func TestSanitize_RejectsInvalidBlocks(t *testing.T) {
+	tests := []struct {
+		name  string
+		block Block
+	}{
+		{"unknown type", Block{Type: "video", Text: "x"}},
+		{"heading level", Block{Type: Heading, Level: 7, Text: "Title"}},
+		{"javascript image", Block{Type: Image, URL: "javascript:alert(1)"}},
+		{"relative image", Block{Type: Image, URL: "/images/a.png"}},
+		{"long text", Block{Type: Paragraph, Text: strings.Repeat("a", MaxTextLength+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Sanitize([]Block{tt.block})
+			if !errors.Is(err, ErrInvalidBlock) {
+				t.Errorf("expected ErrInvalidBlock, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSanitize_TooManyBlocks(t *testing.T) {
+	blocks := make([]Block, MaxBlocks+1)
+	for i := range blocks {
+		blocks[i] = Block{Type: Paragraph, Text: "x"}
+	}
+
+	if _, err := Sanitize(blocks); !errors.Is(err, ErrInvalidBlock) {
+		t.Errorf("expected ErrInvalidBlock, got %v", err)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	blocks := []Block{
+		{Type: Heading, Level: 2, Text: "Features"},
+		{Type: Paragraph, Text: "**Fast** & _quiet_ see [docs](https://example.com/a?b=1&c=2)"},
+		{Type: OrderedList, Items: []string{"one", "two"}},
+		{Type: Image, URL: "https://cdn.example.com/a.png", Alt: `say "hi"`},
+	}
+
+	got := RenderHTML(blocks)
+	want := `<h2>Features</h2>` +
+		`<p><strong>Fast</strong> &amp; <em>quiet</em> see <a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener">docs</a></p>` +
+		`<ol><li>one</li><li>two</li></ol>` +
+		`<img src="https://cdn.example.com/a.png" alt="say &#34;hi&#34;">`
+
+	if got != want {
+		t.Errorf("unexpected HTML\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestRenderHTML_UnderscoresInLinks(t *testing.T) {
+	got := RenderHTML([]Block{{Type: Paragraph, Text: "[spec](https://example.com/a_b_c) snake_case_name"}})
+	want := `<p><a href="https://example.com/a_b_c" rel="nofollow noopener">spec</a> snake_case_name</p>`
+
+	if got != want {
+		t.Errorf("expected underscores to be left alone\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestRenderHTML_UnsafeLink(t *testing.T) {
+	got := RenderHTML([]Block{{Type: Paragraph, Text: "[click](javascript:alert(1))"}})
+
+	if strings.Contains(got, "href") {
+		t.Errorf("expected unsafe link to be dropped, got %s", got)
+	}
+}
+
+func TestRenderPlainText(t *testing.T) {
+	blocks := []Block{
+		{Type: Heading, Level: 1, Text: "Title"},
+		{Type: Paragraph, Text: "A **bold** [link](https://example.com)"},
+		{Type: List, Items: []string{"a", "b"}},
+	}
+
+	want := "Title\n\nA bold link\n\n- a\n- b"
+	if got := RenderPlainText(blocks); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,273 @@
+// Package saga runs sagas: a sequence of steps across services, each
+// committed on its own, where the completed steps are undone by their
+// compensations in reverse order when a later step fails.
+//
+// A Definition lists the steps of a saga with the compensation of each, or
+// NoCompensation, so a forgotten compensation fails NewOrchestrator instead
+// of leaving a step in place when a saga is rolled back. Each step's action
+// returns a reference to what it did, such as the ID of the reservation it
+// made. The references of completed steps are saved in a
+// Store as the saga runs, and compensations are looked up by step name, so a
+// saga left running by a process that stopped can be compensated by another
+// one with Recover.
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// Saga statuses
+const (
+	// StatusRunning sagas are running their steps
+	StatusRunning = "RUNNING"
+	// StatusCompleted sagas ran every step
+	StatusCompleted = "COMPLETED"
+	// StatusCompensating sagas had a step fail and are undoing the others
+	StatusCompensating = "COMPENSATING"
+	// StatusCompensated sagas had a step fail and every completed step undone
+	StatusCompensated = "COMPENSATED"
+	// StatusFailed sagas had a compensation fail; they need manual follow-up
+	StatusFailed = "FAILED"
+)
+
+// recoverBatchSize is how many abandoned sagas Recover claims at a time
+const recoverBatchSize = 100
+
+var (
+	// ErrStepTimeout is returned when a step does not finish within its timeout
+	ErrStepTimeout = errors.New("saga: step timed out")
+	// ErrUnknownStep is returned by Execute for a step its definition does
+	// not list; the saga is not started
+	ErrUnknownStep = errors.New("saga: unknown step")
+)
+
+// Compensation undoes a completed step, given the reference its action
+// returned. It must be safe to call more than once.
+type Compensation func(ctx context.Context, ref string) error
+
+// NoCompensation marks a step of Definition.Compensations that has nothing
+// to undo
+var NoCompensation Compensation
+
+// Definition describes a kind of saga
+type Definition struct {
+	// Name identifies the kind of saga in the store and the logs
+	Name string
+	// Steps names every step of the saga
+	Steps []string
+	// Compensations undo completed steps, by step name. Every step of Steps
+	// needs an entry, NoCompensation for a step with nothing to undo.
+	Compensations map[string]Compensation
+	// StepTimeout bounds each action and compensation; 0 for no limit
+	StepTimeout time.Duration
+}
+
+// Step is one step of a saga run
+type Step struct {
+	Name string
+	// Action performs the step and returns a reference to what it did, which
+	// is passed to the step's compensation. An empty reference means the
+	// step did nothing to undo.
+	Action func(ctx context.Context) (string, error)
+	// Timeout replaces the definition's StepTimeout for this step
+	Timeout time.Duration
+}
+
+// StepError reports the step that failed. The steps before it have been
+// compensated by the time it is returned.
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return e.Step + ": " + e.Err.Error()
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Orchestrator runs the sagas of one definition
+type Orchestrator struct {
+	def   Definition
+	store Store
+	log   *logger.Logger
+	now   func() time.Time
+}
+
+// NewOrchestrator creates an orchestrator running sagas of def and saving
+// their state in store. It returns an error when a step of def has no entry
+// in its compensations.
+func NewOrchestrator(def Definition, store Store, log *logger.Logger) (*Orchestrator, error) {
+	for _, name := range def.Steps {
+		if _, ok := def.Compensations[name]; !ok {
+			return nil, fmt.Errorf("saga: %s step %s has no compensation; use NoCompensation when it has nothing to undo", def.Name, name)
+		}
+	}
+	return &Orchestrator{def: def, store: store, log: log, now: time.Now}, nil
+}
+
+// WithStore returns an orchestrator running the sagas of the same definition
+// and saving their state in store
+func (o *Orchestrator) WithStore(store Store) *Orchestrator {
+	copied := *o
+	copied.store = store
+	return &copied
+}
+
+// Execute runs steps in order as the saga id. When a step fails, the
+// completed steps are compensated in reverse order and a *StepError is
+// returned. The saga is not started if a step is not one of the definition
+// or its state cannot be saved; once started, a failure to save its progress
+// is logged and the saga carries on.
+func (o *Orchestrator) Execute(ctx context.Context, id string, steps []Step) error {
+	for _, step := range steps {
+		if !slices.Contains(o.def.Steps, step.Name) {
+			return fmt.Errorf("%w: %s step %s", ErrUnknownStep, o.def.Name, step.Name)
+		}
+	}
+
+	now := o.now()
+	state := &State{ID: id, Name: o.def.Name, Status: StatusRunning, CreatedAt: now, UpdatedAt: now}
+	if err := o.store.Create(ctx, state); err != nil {
+		return fmt.Errorf("saga: save %s %s: %w", o.def.Name, id, err)
+	}
+
+	for _, step := range steps {
+		ref, err := o.runStep(ctx, step)
+		if err != nil {
+			o.log.Warn(ctx, "Saga step failed", map[string]interface{}{"saga": o.def.Name, "saga_id": id, "step": step.Name, "error": err.Error()})
+			state.FailedStep, state.Error = step.Name, err.Error()
+			o.compensate(ctx, state)
+			return &StepError{Step: step.Name, Err: err}
+		}
+		state.Steps = append(state.Steps, StepRecord{Name: step.Name, Ref: ref})
+		o.save(ctx, state)
+	}
+
+	state.Status = StatusCompleted
+	o.save(ctx, state)
+	return nil
+}
+
+// runStep runs a step's action within its timeout
+func (o *Orchestrator) runStep(ctx context.Context, step Step) (string, error) {
+	timeout := step.Timeout
+	if timeout <= 0 {
+		timeout = o.def.StepTimeout
+	}
+	if timeout <= 0 {
+		return step.Action(ctx)
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ref, err := step.Action(stepCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(stepCtx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%w after %s: %w", ErrStepTimeout, timeout, err)
+	}
+	return ref, err
+}
+
+// compensate undoes the completed steps of a saga in reverse order. It runs
+// even when the caller has gone away, and keeps going past failures so one
+// stuck step does not leave the others in place; the saga then ends FAILED.
+func (o *Orchestrator) compensate(ctx context.Context, state *State) {
+	ctx = context.WithoutCancel(ctx)
+	state.Status = StatusCompensating
+	o.save(ctx, state)
+
+	failed := false
+	for i := len(state.Steps) - 1; i >= 0; i-- {
+		step := &state.Steps[i]
+		// Steps of sagas saved by an older definition may have none
+		undo := o.def.Compensations[step.Name]
+		if step.Compensated || undo == nil || step.Ref == "" {
+			continue
+		}
+		if err := o.undo(ctx, undo, step.Ref); err != nil {
+			failed = true
+			o.log.Error(ctx, "Saga compensation failed", map[string]interface{}{"saga": o.def.Name, "saga_id": state.ID, "step": step.Name, "ref": step.Ref, "error": err.Error()})
+			continue
+		}
+		step.Compensated = true
+		o.save(ctx, state)
+	}
+
+	state.Status = StatusCompensated
+	if failed {
+		state.Status = StatusFailed
+	}
+	o.save(ctx, state)
+}
+
+// undo runs a compensation within the step timeout
+func (o *Orchestrator) undo(ctx context.Context, undo Compensation, ref string) error {
+	if o.def.StepTimeout <= 0 {
+		return undo(ctx, ref)
+	}
+	ctx, cancel := context.WithTimeout(ctx, o.def.StepTimeout)
+	defer cancel()
+	return undo(ctx, ref)
+}
+
+// save records a saga's progress; a failure is logged and the saga carries on
+func (o *Orchestrator) save(ctx context.Context, state *State) {
+	state.UpdatedAt = o.now()
+	if err := o.store.Update(ctx, state); err != nil {
+		o.log.Error(ctx, "Failed to save saga state", map[string]interface{}{"saga": o.def.Name, "saga_id": state.ID, "status": state.Status, "error": err.Error()})
+	}
+}
+
+// Recover compensates sagas that are still running or compensating but were
+// not updated for staleAfter, because the process running them stopped, and
+// returns how many it compensated. staleAfter must be longer than any step
+// takes, or sagas still in progress are compensated under their runner.
+func (o *Orchestrator) Recover(ctx context.Context, staleAfter time.Duration) (int, error) {
+	recovered := 0
+	for {
+		now := o.now()
+		states, err := o.store.ClaimStale(ctx, o.def.Name, now.Add(-staleAfter), now, recoverBatchSize)
+		if err != nil {
+			return recovered, fmt.Errorf("saga: claim abandoned %s sagas: %w", o.def.Name, err)
+		}
+		for _, state := range states {
+			o.log.Warn(ctx, "Compensating abandoned saga", map[string]interface{}{"saga": o.def.Name, "saga_id": state.ID, "status": state.Status, "steps": len(state.Steps)})
+			if state.Error == "" {
+				state.Error = "abandoned"
+			}
+			o.compensate(ctx, state)
+			recovered++
+		}
+		if len(states) < recoverBatchSize || ctx.Err() != nil {
+			return recovered, nil
+		}
+	}
+}
+
+// Run recovers abandoned sagas every interval until ctx is cancelled
+func (o *Orchestrator) Run(ctx context.Context, interval, staleAfter time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := o.Recover(ctx, staleAfter); err != nil {
+			o.log.Error(ctx, "Failed to recover sagas", map[string]interface{}{"saga": o.def.Name, "error": err.Error()})
+		} else if n > 0 {
+			o.log.Info(ctx, "Recovered abandoned sagas", map[string]interface{}{"saga": o.def.Name, "count": n})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
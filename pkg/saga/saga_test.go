@@ -0,0 +1,208 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// recordingDefinition returns a definition whose compensations record the
+// references they undo, failing for those in fail
+func recordingDefinition(undone *[]string, fail map[string]bool) Definition {
+	undo := func(ctx context.Context, ref string) error {
+		if fail[ref] {
+			return errors.New("service down")
+		}
+		*undone = append(*undone, ref)
+		return nil
+	}
+	return Definition{
+		Name:  "test",
+		Steps: []string{"reserve", "screen", "charge", "ship"},
+		Compensations: map[string]Compensation{
+			"reserve": undo,
+			"screen":  NoCompensation,
+			"charge":  undo,
+			"ship":    NoCompensation,
+		},
+	}
+}
+
+// newOrchestrator creates an orchestrator of def, failing the test when def
+// is rejected
+func newOrchestrator(t *testing.T, def Definition, store Store) *Orchestrator {
+	t.Helper()
+	o, err := NewOrchestrator(def, store, logger.New("saga-test"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return o
+}
+
+func step(name, ref string, err error) Step {
+	return Step{Name: name, Action: func(ctx context.Context) (string, error) { return ref, err }}
+}
+
+func TestExecute_Success(t *testing.T) {
+	var undone []string
+	store := NewMemoryStore()
+	o := newOrchestrator(t, recordingDefinition(&undone, nil), store)
+
+	err := o.Execute(context.Background(), "saga-1", []Step{step("reserve", "res-1", nil), step("charge", "ch-1", nil)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(undone) != 0 {
+		t.Errorf("Expected nothing compensated, got %v", undone)
+	}
+}
+
+func TestExecute_CompensatesInReverse(t *testing.T) {
+	var undone []string
+	o := newOrchestrator(t, recordingDefinition(&undone, nil), NewMemoryStore())
+
+	failure := errors.New("declined")
+	err := o.Execute(context.Background(), "saga-1", []Step{
+		step("reserve", "res-1", nil),
+		step("reserve", "res-2", nil),
+		step("screen", "", nil),
+		step("charge", "", nil), // nothing to undo
+		step("ship", "", failure),
+	})
+
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Step != "ship" || !errors.Is(err, failure) {
+		t.Fatalf("Expected failure at ship, got %v", err)
+	}
+	if want := []string{"res-2", "res-1"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("Expected %v compensated, got %v", want, undone)
+	}
+}
+
+func TestExecute_CompensationFailure(t *testing.T) {
+	var undone []string
+	store := NewMemoryStore()
+	o := newOrchestrator(t, recordingDefinition(&undone, map[string]bool{"res-2": true}), store)
+
+	err := o.Execute(context.Background(), "saga-1", []Step{
+		step("reserve", "res-1", nil),
+		step("reserve", "res-2", nil),
+		step("charge", "", errors.New("declined")),
+	})
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	// The failed compensation does not stop the others
+	if want := []string{"res-1"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("Expected %v compensated, got %v", want, undone)
+	}
+
+	state := store.(*memoryStore).states["saga-1"]
+	if state == nil || state.Status != StatusFailed || state.FailedStep != "charge" || state.Error != "declined" {
+		t.Fatalf("Unexpected state %+v", state)
+	}
+	if !state.Steps[0].Compensated || state.Steps[1].Compensated {
+		t.Errorf("Unexpected steps %+v", state.Steps)
+	}
+}
+
+func TestExecute_StepTimeout(t *testing.T) {
+	var undone []string
+	def := recordingDefinition(&undone, nil)
+	def.StepTimeout = 10 * time.Millisecond
+	o := newOrchestrator(t, def, NewMemoryStore())
+
+	slow := Step{Name: "charge", Action: func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}}
+	err := o.Execute(context.Background(), "saga-1", []Step{step("reserve", "res-1", nil), slow})
+	if !errors.Is(err, ErrStepTimeout) {
+		t.Fatalf("Expected ErrStepTimeout, got %v", err)
+	}
+	if want := []string{"res-1"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("Expected %v compensated, got %v", want, undone)
+	}
+}
+
+func TestExecute_StoreFailure(t *testing.T) {
+	store := NewMemoryStore()
+	if err := store.Create(context.Background(), &State{ID: "saga-1", Name: "test", Status: StatusRunning}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	o := newOrchestrator(t, Definition{Name: "test", Steps: []string{"reserve"}, Compensations: map[string]Compensation{"reserve": NoCompensation}}, store)
+
+	// A saga whose state cannot be saved does not start
+	ran := false
+	err := o.Execute(context.Background(), "saga-1", []Step{{Name: "reserve", Action: func(ctx context.Context) (string, error) {
+		ran = true
+		return "", nil
+	}}})
+	if err == nil || ran {
+		t.Errorf("Expected the saga not to start, got %v", err)
+	}
+}
+
+func TestNewOrchestrator_MissingCompensation(t *testing.T) {
+	def := recordingDefinition(new([]string), nil)
+	delete(def.Compensations, "ship")
+	if _, err := NewOrchestrator(def, NewMemoryStore(), logger.New("saga-test")); err == nil {
+		t.Error("Expected a step without a compensation to be rejected")
+	}
+}
+
+func TestExecute_UnknownStep(t *testing.T) {
+	var undone []string
+	store := NewMemoryStore()
+	o := newOrchestrator(t, recordingDefinition(&undone, nil), store)
+
+	err := o.Execute(context.Background(), "saga-1", []Step{step("reserve", "res-1", nil), step("refund", "", nil)})
+	if !errors.Is(err, ErrUnknownStep) {
+		t.Fatalf("Expected ErrUnknownStep, got %v", err)
+	}
+	if _, ok := store.(*memoryStore).states["saga-1"]; ok {
+		t.Error("Expected the saga not to start")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var undone []string
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	abandoned := &State{ID: "old", Name: "test", Status: StatusRunning, UpdatedAt: now.Add(-time.Hour),
+		Steps: []StepRecord{{Name: "reserve", Ref: "res-1"}, {Name: "charge", Ref: "ch-1"}}}
+	running := &State{ID: "new", Name: "test", Status: StatusRunning, UpdatedAt: now.Add(-time.Second),
+		Steps: []StepRecord{{Name: "reserve", Ref: "res-2"}}}
+	other := &State{ID: "other", Name: "other", Status: StatusRunning, UpdatedAt: now.Add(-time.Hour),
+		Steps: []StepRecord{{Name: "reserve", Ref: "res-3"}}}
+	for _, s := range []*State{abandoned, running, other} {
+		if err := store.Create(ctx, s); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	o := newOrchestrator(t, recordingDefinition(&undone, nil), store)
+	o.now = func() time.Time { return now }
+
+	n, err := o.Recover(ctx, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 saga recovered, got %d", n)
+	}
+	if want := []string{"ch-1", "res-1"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("Expected %v compensated, got %v", want, undone)
+	}
+
+	// A recovered saga is not claimed again
+	if n, _ := o.Recover(ctx, 10*time.Minute); n != 0 {
+		t.Errorf("Expected nothing left to recover, got %d", n)
+	}
+}
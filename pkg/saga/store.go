@@ -0,0 +1,219 @@
+package saga
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StepRecord is a completed step of a saga
+type StepRecord struct {
+	Name        string `json:"name"`
+	Ref         string `json:"ref,omitempty"`
+	Compensated bool   `json:"compensated,omitempty"`
+}
+
+// State is the progress of a saga
+type State struct {
+	ID     string
+	Name   string
+	Status string
+	// Steps are the completed steps, in the order they ran
+	Steps []StepRecord
+	// FailedStep and Error describe why the saga was compensated
+	FailedStep string
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Store saves the state of sagas
+type Store interface {
+	// Create saves a new saga
+	Create(ctx context.Context, state *State) error
+	// Update saves the status, steps, failure and updated time of a saga
+	Update(ctx context.Context, state *State) error
+	// ClaimStale returns up to limit sagas of name that are running or
+	// compensating and were last updated before before, and sets their
+	// updated time to now so that they are not claimed again until they are
+	// stale once more
+	ClaimStale(ctx context.Context, name string, before, now time.Time, limit int) ([]*State, error)
+}
+
+// memoryStore keeps saga state in memory
+type memoryStore struct {
+	mu     sync.Mutex
+	states map[string]*State
+}
+
+// NewMemoryStore creates a Store that keeps saga state in memory, for
+// services without a database. Sagas are lost when the process stops, so
+// there is nothing for Recover to find.
+func NewMemoryStore() Store {
+	return &memoryStore{states: make(map[string]*State)}
+}
+
+func (s *memoryStore) Create(ctx context.Context, state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.states[state.ID]; ok {
+		return fmt.Errorf("saga %s already exists", state.ID)
+	}
+	s.states[state.ID] = copyState(state)
+	return nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.states[state.ID]; !ok {
+		return fmt.Errorf("saga %s not found", state.ID)
+	}
+	// Finished sagas are dropped, since nothing reads them back
+	if state.Status == StatusCompleted || state.Status == StatusCompensated {
+		delete(s.states, state.ID)
+		return nil
+	}
+	s.states[state.ID] = copyState(state)
+	return nil
+}
+
+func (s *memoryStore) ClaimStale(ctx context.Context, name string, before, now time.Time, limit int) ([]*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stale []*State
+	for _, state := range s.states {
+		if state.Name == name && isActive(state.Status) && state.UpdatedAt.Before(before) {
+			stale = append(stale, state)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].UpdatedAt.Before(stale[j].UpdatedAt) })
+	if len(stale) > limit {
+		stale = stale[:limit]
+	}
+
+	claimed := make([]*State, len(stale))
+	for i, state := range stale {
+		state.UpdatedAt = now
+		claimed[i] = copyState(state)
+	}
+	return claimed, nil
+}
+
+// copyState copies a state so the store does not share its steps with the caller
+func copyState(state *State) *State {
+	c := *state
+	c.Steps = append([]StepRecord(nil), state.Steps...)
+	return &c
+}
+
+// isActive reports whether a saga with status is still in progress
+func isActive(status string) bool {
+	return status == StatusRunning || status == StatusCompensating
+}
+
+// postgresStore keeps saga state in a service's saga table, created by its
+// migrations:
+//
+//	CREATE TABLE <service>_sagas (
+//	    id VARCHAR(255) PRIMARY KEY,
+//	    name VARCHAR(100) NOT NULL,
+//	    status VARCHAR(20) NOT NULL,
+//	    steps JSONB NOT NULL DEFAULT '[]',
+//	    failed_step VARCHAR(100) NOT NULL DEFAULT '',
+//	    error TEXT NOT NULL DEFAULT '',
+//	    created_at TIMESTAMP NOT NULL,
+//	    updated_at TIMESTAMP NOT NULL
+//	);
+//	CREATE INDEX idx_<service>_sagas_active ON <service>_sagas(name, updated_at)
+//	    WHERE status IN ('RUNNING', 'COMPENSATING');
+type postgresStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPostgresStore creates a Store that keeps saga state in table
+func NewPostgresStore(db *sql.DB, table string) Store {
+	return &postgresStore{db: db, table: table}
+}
+
+func (s *postgresStore) Create(ctx context.Context, state *State) error {
+	steps, err := encodeSteps(state.Steps)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		"INSERT INTO "+s.table+" (id, name, status, steps, failed_step, error, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		state.ID, state.Name, state.Status, steps, state.FailedStep, state.Error, state.CreatedAt, state.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("saga: insert state: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) Update(ctx context.Context, state *State) error {
+	steps, err := encodeSteps(state.Steps)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		"UPDATE "+s.table+" SET status = $2, steps = $3, failed_step = $4, error = $5, updated_at = $6 WHERE id = $1",
+		state.ID, state.Status, steps, state.FailedStep, state.Error, state.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("saga: update state: %w", err)
+	}
+	return nil
+}
+
+// ClaimStale locks the stale sagas with SKIP LOCKED, so instances recovering
+// the same table claim different sagas
+func (s *postgresStore) ClaimStale(ctx context.Context, name string, before, now time.Time, limit int) ([]*State, error) {
+	query := `
+		UPDATE ` + s.table + ` SET updated_at = $3
+		WHERE id IN (
+			SELECT id FROM ` + s.table + `
+			WHERE name = $1 AND status IN ('` + StatusRunning + `', '` + StatusCompensating + `') AND updated_at < $2
+			ORDER BY updated_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, name, status, steps, failed_step, error, created_at, updated_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, name, before, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("saga: claim stale sagas: %w", err)
+	}
+	defer rows.Close()
+
+	var states []*State
+	for rows.Next() {
+		state := &State{}
+		var steps []byte
+		if err := rows.Scan(&state.ID, &state.Name, &state.Status, &steps, &state.FailedStep, &state.Error, &state.CreatedAt, &state.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("saga: scan state: %w", err)
+		}
+		if err := json.Unmarshal(steps, &state.Steps); err != nil {
+			return nil, fmt.Errorf("saga: decode steps of %s: %w", state.ID, err)
+		}
+		states = append(states, state)
+	}
+	return states, rows.Err()
+}
+
+// encodeSteps encodes steps as a JSON list, [] when there are none
+func encodeSteps(steps []StepRecord) ([]byte, error) {
+	if steps == nil {
+		steps = []StepRecord{}
+	}
+	b, err := json.Marshal(steps)
+	if err != nil {
+		return nil, fmt.Errorf("saga: encode steps: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,53 @@
+package saga
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+	store := NewPostgresStore(db, "checkout_sagas")
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO checkout_sagas \(id, name, status, steps, failed_step, error, created_at, updated_at\)`).
+		WithArgs("saga-1", "checkout", StatusRunning, []byte("[]"), "", "", now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE checkout_sagas SET status = \$2, steps = \$3, failed_step = \$4, error = \$5, updated_at = \$6 WHERE id = \$1`).
+		WithArgs("saga-1", StatusCompensating, []byte(`[{"name":"reserve","ref":"res-1"}]`), "charge", "declined", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`UPDATE checkout_sagas SET updated_at = \$3 WHERE id IN (.+)status IN \('RUNNING', 'COMPENSATING'\) AND updated_at < \$2(.+)FOR UPDATE SKIP LOCKED`).
+		WithArgs("checkout", now.Add(-time.Hour), now, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status", "steps", "failed_step", "error", "created_at", "updated_at"}).
+			AddRow("saga-1", "checkout", StatusCompensating, []byte(`[{"name":"reserve","ref":"res-1","compensated":true}]`), "charge", "declined", now, now))
+
+	state := &State{ID: "saga-1", Name: "checkout", Status: StatusRunning, CreatedAt: now, UpdatedAt: now}
+	if err := store.Create(ctx, state); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	state.Status, state.FailedStep, state.Error = StatusCompensating, "charge", "declined"
+	state.Steps = []StepRecord{{Name: "reserve", Ref: "res-1"}}
+	if err := store.Update(ctx, state); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	states, err := store.ClaimStale(ctx, "checkout", now.Add(-time.Hour), now, 100)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(states) != 1 || len(states[0].Steps) != 1 || !states[0].Steps[0].Compensated || states[0].FailedStep != "charge" {
+		t.Errorf("Unexpected states %+v", states)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,380 @@
+// Package storage provides a minimal S3-compatible object storage client.
+// It signs requests with AWS Signature Version 4 and works with AWS S3 and MinIO.
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrObjectNotFound is returned when an object does not exist in the bucket
+	ErrObjectNotFound = errors.New("object not found")
+	// ErrObjectTooLarge is returned when an object exceeds the size a download allows
+	ErrObjectTooLarge = errors.New("object too large")
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	amzDateFormat    = "20060102T150405Z"
+	shortDateFormat  = "20060102"
+)
+
+// Config holds the connection settings of an S3-compatible bucket
+type Config struct {
+	Endpoint  string // e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket as endpoint/bucket/key (required for MinIO)
+	PathStyle bool
+	// PublicURL is the base URL objects are served from; defaults to the bucket URL
+	PublicURL string
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+}
+
+// S3Client signs and sends requests to an S3-compatible bucket
+type S3Client struct {
+	cfg        Config
+	endpoint   *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3Client creates a new S3 client
+func NewS3Client(cfg Config) (*S3Client, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("storage: endpoint and bucket are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("storage: invalid endpoint: %w", err)
+	}
+
+	return &S3Client{
+		cfg:        cfg,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+	}, nil
+}
+
+// PresignPut returns a URL that allows uploading an object with the given content
+// type and length until it expires. The client must send both headers unchanged.
+func (c *S3Client) PresignPut(key, contentType string, contentLength int64, expires time.Duration) (string, error) {
+	headers := map[string]string{
+		"content-type":   contentType,
+		"content-length": strconv.FormatInt(contentLength, 10),
+	}
+	return c.presign(http.MethodPut, key, headers, expires)
+}
+
+// PresignGet returns a URL that allows downloading an object until it expires
+func (c *S3Client) PresignGet(key string, expires time.Duration) (string, error) {
+	return c.presign(http.MethodGet, key, nil, expires)
+}
+
+// Stat retrieves object metadata with a HEAD request
+func (c *S3Client) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.sign(req, unsignedPayload)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage: head object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("storage: head object: unexpected status %d", resp.StatusCode)
+	}
+
+	return &ObjectInfo{
+		Key:         key,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+	}, nil
+}
+
+// Get downloads an object. Objects larger than maxSize bytes are refused
+// without being read in full.
+func (c *S3Client) Get(ctx context.Context, key string, maxSize int64) ([]byte, *ObjectInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.sign(req, unsignedPayload)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("storage: get object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil, ErrObjectNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("storage: get object: unexpected status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, nil, ErrObjectTooLarge
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("storage: get object: %w", err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, nil, ErrObjectTooLarge
+	}
+
+	return body, &ObjectInfo{
+		Key:         key,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        int64(len(body)),
+	}, nil
+}
+
+// PutOptions are the headers an object is stored with and later served with
+type PutOptions struct {
+	ContentType string
+	// CacheControl is served as the object's Cache-Control header; empty
+	// leaves caching to the bucket and CDN defaults
+	CacheControl string
+}
+
+// Put uploads an object. The payload hash is signed, so the body cannot be
+// altered in transit.
+func (c *S3Client) Put(ctx context.Context, key, contentType string, body []byte) error {
+	return c.PutObject(ctx, key, body, PutOptions{ContentType: contentType})
+}
+
+// PutObject uploads an object with opts
+func (c *S3Client) PutObject(ctx context.Context, key string, body []byte, opts PutOptions) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(key).String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", opts.ContentType)
+	if opts.CacheControl != "" {
+		req.Header.Set("Cache-Control", opts.CacheControl)
+	}
+	hash := sha256.Sum256(body)
+	c.sign(req, hex.EncodeToString(hash[:]))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("storage: put object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("storage: put object: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// URL returns the public URL of an object
+func (c *S3Client) URL(key string) string {
+	if c.cfg.PublicURL != "" {
+		return strings.TrimRight(c.cfg.PublicURL, "/") + "/" + encodePath(key)
+	}
+	return c.objectURL(key).String()
+}
+
+// objectURL builds the bucket URL of an object for path or virtual-hosted style
+func (c *S3Client) objectURL(key string) *url.URL {
+	u := *c.endpoint
+	if c.cfg.PathStyle {
+		u.Path = "/" + c.cfg.Bucket + "/" + key
+	} else {
+		u.Host = c.cfg.Bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	u.RawPath = ""
+	return &u
+}
+
+// presign builds a query-string signed URL
+func (c *S3Client) presign(method, key string, headers map[string]string, expires time.Duration) (string, error) {
+	if expires <= 0 || expires > 7*24*time.Hour {
+		return "", errors.New("storage: expiry must be between 1s and 7 days")
+	}
+
+	now := c.now().UTC()
+	u := c.objectURL(key)
+
+	signed := map[string]string{"host": u.Host}
+	for k, v := range headers {
+		signed[strings.ToLower(k)] = v
+	}
+	signedHeaders := sortedKeys(signed)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", signingAlgorithm)
+	query.Set("X-Amz-Credential", c.cfg.AccessKey+"/"+c.scope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", strings.Join(signedHeaders, ";"))
+
+	canonicalQuery := canonicalQueryString(query)
+	canonical := strings.Join([]string{
+		method,
+		encodePath(u.Path),
+		canonicalQuery,
+		canonicalHeaders(signed, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		unsignedPayload,
+	}, "\n")
+
+	signature := c.signature(now, canonical)
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String(), nil
+}
+
+// sign adds an Authorization header to a request. payloadHash is the hex
+// SHA-256 of the body, or unsignedPayload.
+func (c *S3Client) sign(req *http.Request, payloadHash string) {
+	now := c.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           now.Format(amzDateFormat),
+	}
+	signedHeaders := sortedKeys(signed)
+
+	canonical := strings.Join([]string{
+		req.Method,
+		encodePath(req.URL.Path),
+		canonicalQueryString(req.URL.Query()),
+		canonicalHeaders(signed, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, c.cfg.AccessKey, c.scope(now), strings.Join(signedHeaders, ";"), c.signature(now, canonical)))
+}
+
+// scope returns the credential scope for the given time
+func (c *S3Client) scope(t time.Time) string {
+	return t.Format(shortDateFormat) + "/" + c.cfg.Region + "/s3/aws4_request"
+}
+
+// signature computes the SigV4 signature of a canonical request
+func (c *S3Client) signature(t time.Time, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		t.Format(amzDateFormat),
+		c.scope(t),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.cfg.SecretKey), t.Format(shortDateFormat))
+	key = hmacSHA256(key, c.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func canonicalHeaders(headers map[string]string, keys []string) string {
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte(':')
+		b.WriteString(strings.TrimSpace(headers[k]))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func canonicalQueryString(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, encode(k, true)+"="+encode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// encodePath URI-encodes a path, keeping slashes
+func encodePath(path string) string {
+	return encode(path, false)
+}
+
+// encode applies SigV4 URI encoding: only unreserved characters are left as is
+func encode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~':
+			b.WriteByte(ch)
+		case ch == '/' && !encodeSlash:
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,169 @@
+// Package tenant carries the store a request is made for, so that one
+// deployment can serve several independent stores. A server interceptor puts
+// the tenant in the request context, where repositories read it to scope
+// their rows, and a client interceptor forwards it on calls to other
+// services. The tenant is that of the caller's validated token; calls
+// without one, such as logins, and calls of services, which act for every
+// tenant, name it in the x-tenant-id gRPC metadata.
+//
+// Rows are scoped by tenant in the account, order, privacy, cart, review and
+// recommendation services. Scoping the catalog and the services left, such as
+// payments, wallet, loyalty and quote, is a follow-up; until then they keep
+// one set of rows for every tenant, so stores served by one deployment share
+// their catalog, and counts drawn from it, such as ratings and co-purchases,
+// cover every tenant.
+package tenant
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MetadataKey is the gRPC metadata key carrying the tenant ID
+	MetadataKey = "x-tenant-id"
+	// Default is the tenant of requests that name none, and of every row
+	// written before tenants were introduced
+	Default = "default"
+	// maxIDLength is the longest tenant ID, so that an ID fits a DNS label
+	maxIDLength = 63
+)
+
+// ErrInvalidID is returned for a tenant ID that is not a lowercase slug
+var ErrInvalidID = errors.New("tenant ID must be 1-63 lowercase letters, digits or hyphens, not starting or ending with a hyphen")
+
+type contextKey struct{}
+
+// Validate reports whether id is a valid tenant ID
+func Validate(id string) error {
+	if id == "" || len(id) > maxIDLength || id[0] == '-' || id[len(id)-1] == '-' {
+		return ErrInvalidID
+	}
+	for _, c := range id {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return ErrInvalidID
+		}
+	}
+	return nil
+}
+
+// OrDefault returns id, or Default when id is empty. Tokens issued before
+// tenants were introduced carry no tenant and belong to the default one.
+func OrDefault(id string) string {
+	if id == "" {
+		return Default
+	}
+	return id
+}
+
+// NewContext returns a copy of ctx carrying tenant id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant of ctx, or Default when it carries none
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return Default
+}
+
+// fromIncomingMetadata returns the tenant named in the incoming metadata of
+// ctx, or "" when none is named
+func fromIncomingMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(MetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// TokenTenant returns the tenant the validated token of a call binds it to,
+// and false when the call carries no token binding one.
+// auth.TenantFromContext is one.
+type TokenTenant func(ctx context.Context) (string, bool)
+
+// resolve returns the tenant of a call: that of its token, when the token
+// binds one, and otherwise the one named in the metadata, or Default. A
+// named tenant other than the token's is refused.
+func resolve(ctx context.Context, token TokenTenant) (string, error) {
+	named := fromIncomingMetadata(ctx)
+	id := OrDefault(named)
+	if token != nil {
+		if bound, ok := token(ctx); ok {
+			if id = OrDefault(bound); named != "" && named != id {
+				return "", status.Error(codes.PermissionDenied, "tenant does not match the token")
+			}
+		}
+	}
+	if err := Validate(id); err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return id, nil
+}
+
+// UnaryServerInterceptor puts the tenant of each request in the request
+// context: the tenant of its token, with token, or the one named in the
+// request metadata. Requests naming no tenant are served for Default;
+// requests naming an invalid one, or one other than their token's, are
+// rejected. It must run after the interceptor validating tokens.
+func UnaryServerInterceptor(token TokenTenant) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id, err := resolve(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return handler(NewContext(ctx, id), req)
+	}
+}
+
+// StreamServerInterceptor puts the tenant of each stream in the stream
+// context, as UnaryServerInterceptor does for unary calls
+func StreamServerInterceptor(token TokenTenant) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id, err := resolve(ss.Context(), token)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: NewContext(ss.Context(), id)})
+	}
+}
+
+// serverStream is a server stream whose context carries the tenant
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// UnaryClientInterceptor forwards the tenant of the call context to the
+// called service, unless the outgoing metadata already names one
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if md, ok := metadata.FromOutgoingContext(ctx); !ok || len(md.Get(MetadataKey)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, FromContext(ctx))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the tenant of the call context on
+// streaming calls, as UnaryClientInterceptor does for unary calls
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if md, ok := metadata.FromOutgoingContext(ctx); !ok || len(md.Get(MetadataKey)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, FromContext(ctx))
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
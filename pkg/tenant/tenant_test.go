@@ -0,0 +1,151 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"default", true},
+		{"acme-store", true},
+		{"store42", true},
+		{"", false},
+		{"Acme", false},
+		{"-acme", false},
+		{"acme-", false},
+		{"acme_store", false},
+		{"acme/store", false},
+		{string(make([]byte, 64)), false},
+	}
+
+	for _, tt := range tests {
+		if err := Validate(tt.id); (err == nil) != tt.valid {
+			t.Errorf("Validate(%q) = %v, want valid %v", tt.id, err, tt.valid)
+		}
+	}
+}
+
+func TestFromContext_DefaultsWhenUnset(t *testing.T) {
+	if got := FromContext(context.Background()); got != Default {
+		t.Errorf("expected %q, got %q", Default, got)
+	}
+	if got := FromContext(NewContext(context.Background(), "acme")); got != "acme" {
+		t.Errorf("expected acme, got %q", got)
+	}
+}
+
+// tokenOf returns a TokenTenant binding every call to id
+func tokenOf(id string) TokenTenant {
+	return func(ctx context.Context) (string, bool) { return id, true }
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/order.OrderService/GetOrder"}
+
+	tests := []struct {
+		name     string
+		token    TokenTenant
+		md       metadata.MD
+		want     string
+		wantCode codes.Code
+	}{
+		{"no metadata", nil, nil, Default, codes.OK},
+		{"named tenant", nil, metadata.Pairs(MetadataKey, "acme"), "acme", codes.OK},
+		{"invalid tenant", nil, metadata.Pairs(MetadataKey, "../acme"), "", codes.InvalidArgument},
+		{"token tenant", tokenOf("acme"), nil, "acme", codes.OK},
+		{"token of the default tenant", tokenOf(""), nil, Default, codes.OK},
+		{"token tenant named", tokenOf("acme"), metadata.Pairs(MetadataKey, "acme"), "acme", codes.OK},
+		{"other tenant named", tokenOf("acme"), metadata.Pairs(MetadataKey, "globex"), "", codes.PermissionDenied},
+		{"token binding none", func(ctx context.Context) (string, bool) { return "", false }, metadata.Pairs(MetadataKey, "globex"), "globex", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			var got string
+			_, err := UnaryServerInterceptor(tt.token)(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				got = FromContext(ctx)
+				return nil, nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected %v, got %v", tt.wantCode, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected tenant %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// testServerStream is a server stream carrying only a context
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor(tokenOf("acme"))
+	info := &grpc.StreamServerInfo{FullMethod: "/order.OrderService/WatchOrders", IsServerStream: true}
+	var got string
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got = FromContext(ss.Context())
+		return nil
+	}
+
+	if err := interceptor(nil, &testServerStream{ctx: context.Background()}, info, handler); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got != "acme" {
+		t.Errorf("expected tenant acme, got %q", got)
+	}
+
+	got = ""
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "globex"))
+	if err := interceptor(nil, &testServerStream{ctx: ctx}, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	if got != "" {
+		t.Error("expected handler not to be called")
+	}
+}
+
+func TestUnaryClientInterceptor_ForwardsTenant(t *testing.T) {
+	interceptor := UnaryClientInterceptor()
+
+	var got []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		got = md.Get(MetadataKey)
+		return nil
+	}
+
+	ctx := NewContext(context.Background(), "acme")
+	if err := interceptor(ctx, "/catalog.CatalogService/GetProduct", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != 1 || got[0] != "acme" {
+		t.Errorf("expected [acme], got %v", got)
+	}
+
+	// A tenant set explicitly by the caller is not overridden
+	ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, "other")
+	if err := interceptor(ctx, "/catalog.CatalogService/GetProduct", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != 1 || got[0] != "other" {
+		t.Errorf("expected [other], got %v", got)
+	}
+}
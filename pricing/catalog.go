@@ -0,0 +1,52 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrProductNotFound is returned when the catalog has no such product
+	ErrProductNotFound = errors.New("product not found")
+	// ErrInvalidQuantity is returned when a quantity breaks a product's order
+	// quantity rules
+	ErrInvalidQuantity = errors.New("invalid quantity")
+)
+
+// Catalog prices products in the catalog currency
+type Catalog interface {
+	// UnitPrice returns the catalog unit price of quantity units of a
+	// product. It returns ErrProductNotFound or an error wrapping
+	// ErrInvalidQuantity when the line cannot be priced.
+	UnitPrice(ctx context.Context, productID string, quantity int32) (float64, error)
+}
+
+type grpcCatalog struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCCatalog creates a Catalog backed by the catalog service
+func NewGRPCCatalog(client catalogpb.CatalogServiceClient) Catalog {
+	return &grpcCatalog{client: client}
+}
+
+// UnitPrice asks the catalog, which applies sale prices, quantity tiers and
+// order quantity rules
+func (c *grpcCatalog) UnitPrice(ctx context.Context, productID string, quantity int32) (float64, error) {
+	price, err := c.client.GetPriceForQuantity(ctx, &catalogpb.GetPriceForQuantityRequest{ProductId: productID, Quantity: quantity})
+	switch status.Code(err) {
+	case codes.OK:
+		return price.UnitPrice, nil
+	case codes.NotFound:
+		return 0, ErrProductNotFound
+	case codes.InvalidArgument:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidQuantity, status.Convert(err).Message())
+	default:
+		return 0, fmt.Errorf("failed to get catalog price: %w", err)
+	}
+}
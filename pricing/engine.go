@@ -0,0 +1,156 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+)
+
+// Sources of a resolved base price
+const (
+	SourcePriceList = "PRICE_LIST"
+	SourceCatalog   = "CATALOG"
+	// SourceConvertedCatalog is the catalog price converted into another
+	// currency at the latest exchange rate
+	SourceConvertedCatalog = "CATALOG_CONVERTED"
+)
+
+// ErrNoPrice is returned when no price list prices a product in a currency
+// other than the catalog's
+var ErrNoPrice = errors.New("no price in currency")
+
+// Terms are what a price is resolved for
+type Terms struct {
+	ProductID       string
+	Quantity        int32
+	CustomerSegment string
+	Channel         string
+	Currency        string
+}
+
+// Quote is a resolved price and how it was reached
+type Quote struct {
+	// BaseUnitPrice comes from the price list, or the catalog when no list
+	// has a price
+	BaseUnitPrice float64
+	UnitPrice     float64
+	LineTotal     float64
+	Source        string
+	PriceListID   string
+	RuleID        string
+}
+
+// Resolve works out the price of a line. The best price list offer sets the
+// base price; without one the catalog price is used, which is only in the
+// catalog currency. The best matching rule then adjusts it. Offers and rules
+// must already be limited to the product and to those active now.
+func Resolve(terms Terms, catalogPrice float64, catalogCurrency string, offers []*Offer, rules []*PriceRule) (*Quote, error) {
+	q := &Quote{}
+	if offer := bestOffer(offers, terms); offer != nil {
+		q.BaseUnitPrice = offer.UnitPrice
+		q.Source = SourcePriceList
+		q.PriceListID = offer.PriceListID
+	} else if terms.Currency == catalogCurrency {
+		q.BaseUnitPrice = catalogPrice
+		q.Source = SourceCatalog
+	} else {
+		return nil, ErrNoPrice
+	}
+
+	q.UnitPrice = q.BaseUnitPrice
+	if rule := bestRule(rules, terms, q.BaseUnitPrice); rule != nil {
+		q.UnitPrice = applyRule(rule, q.BaseUnitPrice)
+		q.RuleID = rule.ID
+	}
+	q.LineTotal = roundCents(q.UnitPrice * float64(terms.Quantity))
+	return q, nil
+}
+
+// bestOffer picks the offer of the highest priority list. Between lists of
+// equal priority the one naming the segment or channel wins over a general
+// one; then the largest quantity break, then the lowest price.
+func bestOffer(offers []*Offer, terms Terms) *Offer {
+	var best *Offer
+	for _, o := range offers {
+		if !matches(o.CustomerSegment, terms.CustomerSegment) || !matches(o.Channel, terms.Channel) || o.MinQuantity > terms.Quantity {
+			continue
+		}
+		if best == nil || betterOffer(o, best) {
+			best = o
+		}
+	}
+	return best
+}
+
+func betterOffer(o, than *Offer) bool {
+	if o.Priority != than.Priority {
+		return o.Priority > than.Priority
+	}
+	if s, t := specificity(o.CustomerSegment, o.Channel), specificity(than.CustomerSegment, than.Channel); s != t {
+		return s > t
+	}
+	if o.MinQuantity != than.MinQuantity {
+		return o.MinQuantity > than.MinQuantity
+	}
+	return o.UnitPrice < than.UnitPrice
+}
+
+// bestRule picks the highest priority rule matching the terms; between rules
+// of equal priority the one leaving the lowest price wins
+func bestRule(rules []*PriceRule, terms Terms, price float64) *PriceRule {
+	var best *PriceRule
+	bestPrice := 0.0
+	for _, r := range rules {
+		if !ruleMatches(r, terms) {
+			continue
+		}
+		p := applyRule(r, price)
+		if best == nil || r.Priority > best.Priority || (r.Priority == best.Priority && p < bestPrice) {
+			best, bestPrice = r, p
+		}
+	}
+	return best
+}
+
+func ruleMatches(r *PriceRule, terms Terms) bool {
+	return matches(r.ProductID, terms.ProductID) &&
+		matches(r.CustomerSegment, terms.CustomerSegment) &&
+		matches(r.Channel, terms.Channel) &&
+		matches(r.Currency, terms.Currency) &&
+		r.MinQuantity <= terms.Quantity
+}
+
+// applyRule adjusts a unit price by a rule. The price never goes below 0.
+func applyRule(r *PriceRule, price float64) float64 {
+	switch r.Type {
+	case RulePercentOff:
+		price -= price * r.Value / 100
+	case RuleAmountOff:
+		price -= r.Value
+	case RuleFixedPrice:
+		price = r.Value
+	}
+	return roundCents(math.Max(price, 0))
+}
+
+// matches reports whether a price list or rule limited to want, or to
+// nothing when want is empty, applies to got
+func matches(want, got string) bool {
+	return want == "" || want == got
+}
+
+// specificity counts the terms a price list is limited to
+func specificity(segment, channel string) int {
+	n := 0
+	if segment != "" {
+		n++
+	}
+	if channel != "" {
+		n++
+	}
+	return n
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
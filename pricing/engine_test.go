@@ -0,0 +1,87 @@
+package pricing
+
+import "testing"
+
+func TestResolve_Offers(t *testing.T) {
+	offers := []*Offer{
+		{PriceListID: "general", Priority: 1, MinQuantity: 1, UnitPrice: 9},
+		{PriceListID: "general", Priority: 1, MinQuantity: 10, UnitPrice: 8},
+		{PriceListID: "wholesale", CustomerSegment: "wholesale", Priority: 1, MinQuantity: 1, UnitPrice: 8.5},
+		{PriceListID: "app", Channel: "app", Priority: 5, MinQuantity: 1, UnitPrice: 9.5},
+	}
+
+	tests := []struct {
+		name   string
+		terms  Terms
+		list   string
+		price  float64
+		source string
+	}{
+		{"general list", Terms{Quantity: 1, Currency: "EUR"}, "general", 9, SourcePriceList},
+		{"quantity break", Terms{Quantity: 12, Currency: "EUR"}, "general", 8, SourcePriceList},
+		{"segment list wins at equal priority", Terms{Quantity: 12, CustomerSegment: "wholesale", Currency: "EUR"}, "wholesale", 8.5, SourcePriceList},
+		{"higher priority wins", Terms{Quantity: 1, CustomerSegment: "wholesale", Channel: "app", Currency: "EUR"}, "app", 9.5, SourcePriceList},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Resolve(tt.terms, 10, "USD", offers, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if q.PriceListID != tt.list || q.UnitPrice != tt.price || q.Source != tt.source {
+				t.Errorf("Expected %v from %q, got %+v", tt.price, tt.list, q)
+			}
+		})
+	}
+}
+
+func TestResolve_CatalogFallback(t *testing.T) {
+	q, err := Resolve(Terms{Quantity: 3, Currency: "USD"}, 10, "USD", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if q.Source != SourceCatalog || q.UnitPrice != 10 || q.LineTotal != 30 {
+		t.Errorf("Expected the catalog price, got %+v", q)
+	}
+
+	if _, err := Resolve(Terms{Quantity: 3, Currency: "EUR"}, 10, "USD", nil, nil); err != ErrNoPrice {
+		t.Errorf("Expected ErrNoPrice, got %v", err)
+	}
+}
+
+func TestResolve_Rules(t *testing.T) {
+	rules := []*PriceRule{
+		{ID: "vip", Type: RulePercentOff, Value: 10, CustomerSegment: "vip"},
+		{ID: "vip-big", Type: RuleAmountOff, Value: 3, CustomerSegment: "vip", Currency: "USD"},
+		{ID: "bulk", Type: RuleFixedPrice, Value: 7, MinQuantity: 50, Priority: 1, Currency: "USD"},
+		{ID: "pos", Type: RuleAmountOff, Value: 50, Channel: "pos", Currency: "USD"},
+		{ID: "other", Type: RulePercentOff, Value: 50, ProductID: "other"},
+	}
+
+	tests := []struct {
+		name  string
+		terms Terms
+		rule  string
+		price float64
+	}{
+		{"no match", Terms{ProductID: "p1", Quantity: 1, Currency: "USD"}, "", 20},
+		{"lowest price at equal priority", Terms{ProductID: "p1", Quantity: 1, CustomerSegment: "vip", Currency: "USD"}, "vip-big", 17},
+		{"amount rule needs its currency", Terms{ProductID: "p1", Quantity: 1, CustomerSegment: "vip", Currency: "EUR"}, "vip", 18},
+		{"priority beats price", Terms{ProductID: "p1", Quantity: 50, CustomerSegment: "vip", Currency: "USD"}, "bulk", 7},
+		{"floored at zero", Terms{ProductID: "p1", Quantity: 1, Channel: "pos", Currency: "USD"}, "pos", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offers := []*Offer{{PriceListID: "list", MinQuantity: 1, UnitPrice: 20}}
+			q, err := Resolve(tt.terms, 25, "USD", offers, rules)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if q.RuleID != tt.rule || q.UnitPrice != tt.price || q.BaseUnitPrice != 20 {
+				t.Errorf("Expected %v by rule %q, got %+v", tt.price, tt.rule, q)
+			}
+		})
+	}
+}
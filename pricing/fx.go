@@ -0,0 +1,55 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	fxpb "github.com/Ujjwaljain16/E-commerce-Backend/fx/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoRate is returned when an amount cannot be converted between two
+// currencies, including in deployments without an FX service
+var ErrNoRate = errors.New("no exchange rate")
+
+// Converter converts catalog prices into other currencies
+type Converter interface {
+	// Convert converts an amount at the latest rates, rounded to cents. It
+	// returns an error wrapping ErrNoRate when there is no usable rate.
+	Convert(ctx context.Context, amount float64, from, to string) (float64, error)
+}
+
+type noConverter struct{}
+
+// NewNoConverter creates a Converter without rates, for deployments without
+// an FX service; other currencies then need price lists
+func NewNoConverter() Converter {
+	return noConverter{}
+}
+
+func (noConverter) Convert(ctx context.Context, amount float64, from, to string) (float64, error) {
+	return 0, ErrNoRate
+}
+
+type grpcConverter struct {
+	client fxpb.FXServiceClient
+}
+
+// NewGRPCConverter creates a Converter backed by the FX service
+func NewGRPCConverter(client fxpb.FXServiceClient) Converter {
+	return &grpcConverter{client: client}
+}
+
+func (c *grpcConverter) Convert(ctx context.Context, amount float64, from, to string) (float64, error) {
+	resp, err := c.client.Convert(ctx, &fxpb.ConvertRequest{Amount: amount, From: from, To: to})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp.ConvertedAmount, nil
+	case codes.NotFound, codes.FailedPrecondition, codes.InvalidArgument:
+		return 0, fmt.Errorf("%w: %s", ErrNoRate, status.Convert(err).Message())
+	default:
+		return 0, fmt.Errorf("failed to convert price: %w", err)
+	}
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_price_rules_product;
+DROP TABLE IF EXISTS price_rules;
+DROP INDEX IF EXISTS idx_price_list_entries_product;
+DROP TABLE IF EXISTS price_list_entries;
+DROP TABLE IF EXISTS price_lists;
@@ -0,0 +1,54 @@
+-- Price lists set product prices in one currency for a customer segment and
+-- a sales channel; an empty segment or channel matches every one
+CREATE TABLE IF NOT EXISTS price_lists (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    customer_segment VARCHAR(100) NOT NULL DEFAULT '',
+    channel VARCHAR(100) NOT NULL DEFAULT '',
+    priority INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+-- A product's unit price in a list from a quantity on
+CREATE TABLE IF NOT EXISTS price_list_entries (
+    price_list_id UUID NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    product_id VARCHAR(255) NOT NULL,
+    min_quantity INTEGER NOT NULL CHECK (min_quantity >= 1),
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+    PRIMARY KEY (price_list_id, product_id, min_quantity)
+);
+
+-- Price lists with a price for a product, looked up on every resolve
+CREATE INDEX idx_price_list_entries_product ON price_list_entries(product_id);
+
+-- Price rules adjust the price a list or the catalog gave. An empty product,
+-- segment, channel or currency matches every one.
+CREATE TABLE IF NOT EXISTS price_rules (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('PERCENT_OFF', 'AMOUNT_OFF', 'FIXED_PRICE')),
+    value DECIMAL(10, 2) NOT NULL CHECK (value >= 0),
+    product_id VARCHAR(255) NOT NULL DEFAULT '',
+    customer_segment VARCHAR(100) NOT NULL DEFAULT '',
+    channel VARCHAR(100) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    min_quantity INTEGER NOT NULL DEFAULT 0 CHECK (min_quantity >= 0),
+    priority INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (type = 'PERCENT_OFF' OR currency <> ''),
+    CHECK (type <> 'PERCENT_OFF' OR value <= 100),
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+-- Active rules for a product and for every product
+CREATE INDEX idx_price_rules_product ON price_rules(product_id) WHERE active;
@@ -0,0 +1,2004 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: pricing/pricing.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PriceList sets product prices in one currency for the customers and
+// channels it applies to
+type PriceList struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Currency        string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`                                      // ISO 4217 code
+	CustomerSegment string                 `protobuf:"bytes,4,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"` // empty for every segment
+	Channel         string                 `protobuf:"bytes,5,opt,name=channel,proto3" json:"channel,omitempty"`                                        // empty for every channel, otherwise such as web, app or pos
+	Priority        int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`                                     // the highest priority list with a price for the product wins
+	Active          bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	StartsAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"` // unset when the list applies from its creation
+	EndsAt          *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`       // unset when the list does not expire
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Entries         []*PriceListEntry      `protobuf:"bytes,12,rep,name=entries,proto3" json:"entries,omitempty"` // GetPriceList only
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PriceList) Reset() {
+	*x = PriceList{}
+	mi := &file_pricing_pricing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceList) ProtoMessage() {}
+
+func (x *PriceList) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceList.ProtoReflect.Descriptor instead.
+func (*PriceList) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PriceList) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PriceList) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceList) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *PriceList) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PriceList) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *PriceList) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *PriceList) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *PriceList) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *PriceList) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PriceList) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PriceList) GetEntries() []*PriceListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// PriceListEntry is a product's unit price from a quantity on
+type PriceListEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MinQuantity   int32                  `protobuf:"varint,2,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceListEntry) Reset() {
+	*x = PriceListEntry{}
+	mi := &file_pricing_pricing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceListEntry) ProtoMessage() {}
+
+func (x *PriceListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceListEntry.ProtoReflect.Descriptor instead.
+func (*PriceListEntry) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PriceListEntry) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceListEntry) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *PriceListEntry) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+// PriceRule adjusts the price a price list or the catalog gave
+type PriceRule struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type            string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                                              // PERCENT_OFF, AMOUNT_OFF or FIXED_PRICE
+	Value           float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`                                          // percent for PERCENT_OFF, otherwise an amount in currency
+	ProductId       string                 `protobuf:"bytes,5,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`                   // empty for every product
+	CustomerSegment string                 `protobuf:"bytes,6,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"` // empty for every segment
+	Channel         string                 `protobuf:"bytes,7,opt,name=channel,proto3" json:"channel,omitempty"`                                        // empty for every channel
+	Currency        string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`                                      // required for AMOUNT_OFF and FIXED_PRICE; empty for every currency
+	MinQuantity     int32                  `protobuf:"varint,9,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`            // 0 for any quantity
+	Priority        int32                  `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`                                    // the highest priority matching rule applies
+	Active          bool                   `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	StartsAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PriceRule) Reset() {
+	*x = PriceRule{}
+	mi := &file_pricing_pricing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceRule) ProtoMessage() {}
+
+func (x *PriceRule) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceRule.ProtoReflect.Descriptor instead.
+func (*PriceRule) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PriceRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PriceRule) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PriceRule) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PriceRule) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceRule) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *PriceRule) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PriceRule) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceRule) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *PriceRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *PriceRule) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *PriceRule) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *PriceRule) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *PriceRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PriceRule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// PriceLine is a product and quantity to price
+type PriceLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceLine) Reset() {
+	*x = PriceLine{}
+	mi := &file_pricing_pricing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLine) ProtoMessage() {}
+
+func (x *PriceLine) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLine.ProtoReflect.Descriptor instead.
+func (*PriceLine) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PriceLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// ResolvedPrice is the final price of a line and how it was reached
+type ResolvedPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	BaseUnitPrice float64                `protobuf:"fixed64,4,opt,name=base_unit_price,json=baseUnitPrice,proto3" json:"base_unit_price,omitempty"` // from the price list, or the catalog when no list has a price
+	UnitPrice     float64                `protobuf:"fixed64,5,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`               // base_unit_price after the rule
+	LineTotal     float64                `protobuf:"fixed64,6,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	Source        string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`                                // PRICE_LIST, CATALOG or CATALOG_CONVERTED
+	PriceListId   string                 `protobuf:"bytes,8,opt,name=price_list_id,json=priceListId,proto3" json:"price_list_id,omitempty"` // empty when the catalog price was used
+	RuleId        string                 `protobuf:"bytes,9,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`                  // empty when no rule applied
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedPrice) Reset() {
+	*x = ResolvedPrice{}
+	mi := &file_pricing_pricing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedPrice) ProtoMessage() {}
+
+func (x *ResolvedPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedPrice.ProtoReflect.Descriptor instead.
+func (*ResolvedPrice) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResolvedPrice) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ResolvedPrice) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ResolvedPrice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ResolvedPrice) GetBaseUnitPrice() float64 {
+	if x != nil {
+		return x.BaseUnitPrice
+	}
+	return 0
+}
+
+func (x *ResolvedPrice) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *ResolvedPrice) GetLineTotal() float64 {
+	if x != nil {
+		return x.LineTotal
+	}
+	return 0
+}
+
+func (x *ResolvedPrice) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ResolvedPrice) GetPriceListId() string {
+	if x != nil {
+		return x.PriceListId
+	}
+	return ""
+}
+
+func (x *ResolvedPrice) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+// ResolvePrice prices a quantity of a product for a customer segment, a
+// channel and a currency
+type ResolvePriceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity        int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CustomerSegment string                 `protobuf:"bytes,3,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"` // optional
+	Channel         string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`                                        // optional
+	Currency        string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`                                      // optional; the catalog currency when empty
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResolvePriceRequest) Reset() {
+	*x = ResolvePriceRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePriceRequest) ProtoMessage() {}
+
+func (x *ResolvePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePriceRequest.ProtoReflect.Descriptor instead.
+func (*ResolvePriceRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolvePriceRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ResolvePriceRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ResolvePriceRequest) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *ResolvePriceRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ResolvePriceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ResolvePriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         *ResolvedPrice         `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvePriceResponse) Reset() {
+	*x = ResolvePriceResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePriceResponse) ProtoMessage() {}
+
+func (x *ResolvePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePriceResponse.ProtoReflect.Descriptor instead.
+func (*ResolvePriceResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResolvePriceResponse) GetPrice() *ResolvedPrice {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+// ResolvePrices prices the lines of a cart
+type ResolvePricesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Lines           []*PriceLine           `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	CustomerSegment string                 `protobuf:"bytes,2,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"`
+	Channel         string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Currency        string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResolvePricesRequest) Reset() {
+	*x = ResolvePricesRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePricesRequest) ProtoMessage() {}
+
+func (x *ResolvePricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePricesRequest.ProtoReflect.Descriptor instead.
+func (*ResolvePricesRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResolvePricesRequest) GetLines() []*PriceLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ResolvePricesRequest) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *ResolvePricesRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ResolvePricesRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ResolvePricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*ResolvedPrice       `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"` // in the order of the lines
+	Subtotal      float64                `protobuf:"fixed64,2,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvePricesResponse) Reset() {
+	*x = ResolvePricesResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePricesResponse) ProtoMessage() {}
+
+func (x *ResolvePricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePricesResponse.ProtoReflect.Descriptor instead.
+func (*ResolvePricesResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResolvePricesResponse) GetPrices() []*ResolvedPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *ResolvePricesResponse) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *ResolvePricesResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// CreatePriceList creates an active price list without entries
+type CreatePriceListRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Currency        string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	CustomerSegment string                 `protobuf:"bytes,3,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"`
+	Channel         string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	Priority        int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	StartsAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePriceListRequest) Reset() {
+	*x = CreatePriceListRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceListRequest) ProtoMessage() {}
+
+func (x *CreatePriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceListRequest.ProtoReflect.Descriptor instead.
+func (*CreatePriceListRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreatePriceListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreatePriceListRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreatePriceListRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type CreatePriceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=price_list,json=priceList,proto3" json:"price_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceListResponse) Reset() {
+	*x = CreatePriceListResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceListResponse) ProtoMessage() {}
+
+func (x *CreatePriceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceListResponse.ProtoReflect.Descriptor instead.
+func (*CreatePriceListResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreatePriceListResponse) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+// GetPriceList returns a price list with its entries
+type GetPriceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceListRequest) Reset() {
+	*x = GetPriceListRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceListRequest) ProtoMessage() {}
+
+func (x *GetPriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceListRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceListRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPriceListRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPriceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=price_list,json=priceList,proto3" json:"price_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceListResponse) Reset() {
+	*x = GetPriceListResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceListResponse) ProtoMessage() {}
+
+func (x *GetPriceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceListResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceListResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPriceListResponse) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+// ListPriceLists lists price lists by priority, highest first
+type ListPriceListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceListsRequest) Reset() {
+	*x = ListPriceListsRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceListsRequest) ProtoMessage() {}
+
+func (x *ListPriceListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceListsRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceListsRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListPriceListsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPriceListsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListPriceListsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceLists    []*PriceList           `protobuf:"bytes,1,rep,name=price_lists,json=priceLists,proto3" json:"price_lists,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceListsResponse) Reset() {
+	*x = ListPriceListsResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceListsResponse) ProtoMessage() {}
+
+func (x *ListPriceListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceListsResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceListsResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListPriceListsResponse) GetPriceLists() []*PriceList {
+	if x != nil {
+		return x.PriceLists
+	}
+	return nil
+}
+
+func (x *ListPriceListsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPriceListsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPriceListsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// SetPriceListActive switches a price list on or off
+type SetPriceListActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceListActiveRequest) Reset() {
+	*x = SetPriceListActiveRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceListActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceListActiveRequest) ProtoMessage() {}
+
+func (x *SetPriceListActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceListActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetPriceListActiveRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetPriceListActiveRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetPriceListActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type SetPriceListActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=price_list,json=priceList,proto3" json:"price_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceListActiveResponse) Reset() {
+	*x = SetPriceListActiveResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceListActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceListActiveResponse) ProtoMessage() {}
+
+func (x *SetPriceListActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceListActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetPriceListActiveResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetPriceListActiveResponse) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+// SetPriceListEntries replaces a product's prices in a price list. Empty
+// entries remove the product from the list.
+type SetPriceListEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceListId   string                 `protobuf:"bytes,1,opt,name=price_list_id,json=priceListId,proto3" json:"price_list_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Entries       []*PriceListEntry      `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"` // product_id of the entries is ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceListEntriesRequest) Reset() {
+	*x = SetPriceListEntriesRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceListEntriesRequest) ProtoMessage() {}
+
+func (x *SetPriceListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*SetPriceListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetPriceListEntriesRequest) GetPriceListId() string {
+	if x != nil {
+		return x.PriceListId
+	}
+	return ""
+}
+
+func (x *SetPriceListEntriesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetPriceListEntriesRequest) GetEntries() []*PriceListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type SetPriceListEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*PriceListEntry      `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceListEntriesResponse) Reset() {
+	*x = SetPriceListEntriesResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceListEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceListEntriesResponse) ProtoMessage() {}
+
+func (x *SetPriceListEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceListEntriesResponse.ProtoReflect.Descriptor instead.
+func (*SetPriceListEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetPriceListEntriesResponse) GetEntries() []*PriceListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// CreatePriceRule creates an active price rule
+type CreatePriceRuleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value           float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	ProductId       string                 `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CustomerSegment string                 `protobuf:"bytes,5,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"`
+	Channel         string                 `protobuf:"bytes,6,opt,name=channel,proto3" json:"channel,omitempty"`
+	Currency        string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	MinQuantity     int32                  `protobuf:"varint,8,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	Priority        int32                  `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	StartsAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePriceRuleRequest) Reset() {
+	*x = CreatePriceRuleRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceRuleRequest) ProtoMessage() {}
+
+func (x *CreatePriceRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreatePriceRuleRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreatePriceRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePriceRuleRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreatePriceRuleRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CreatePriceRuleRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CreatePriceRuleRequest) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *CreatePriceRuleRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *CreatePriceRuleRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreatePriceRuleRequest) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *CreatePriceRuleRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreatePriceRuleRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreatePriceRuleRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type CreatePriceRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *PriceRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceRuleResponse) Reset() {
+	*x = CreatePriceRuleResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceRuleResponse) ProtoMessage() {}
+
+func (x *CreatePriceRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreatePriceRuleResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreatePriceRuleResponse) GetRule() *PriceRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// ListPriceRules lists price rules by priority, highest first
+type ListPriceRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceRulesRequest) Reset() {
+	*x = ListPriceRulesRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceRulesRequest) ProtoMessage() {}
+
+func (x *ListPriceRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceRulesRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListPriceRulesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPriceRulesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListPriceRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*PriceRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceRulesResponse) Reset() {
+	*x = ListPriceRulesResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceRulesResponse) ProtoMessage() {}
+
+func (x *ListPriceRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceRulesResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPriceRulesResponse) GetRules() []*PriceRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ListPriceRulesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPriceRulesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPriceRulesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// SetPriceRuleActive switches a price rule on or off
+type SetPriceRuleActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceRuleActiveRequest) Reset() {
+	*x = SetPriceRuleActiveRequest{}
+	mi := &file_pricing_pricing_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceRuleActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceRuleActiveRequest) ProtoMessage() {}
+
+func (x *SetPriceRuleActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceRuleActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetPriceRuleActiveRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetPriceRuleActiveRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetPriceRuleActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type SetPriceRuleActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *PriceRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceRuleActiveResponse) Reset() {
+	*x = SetPriceRuleActiveResponse{}
+	mi := &file_pricing_pricing_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceRuleActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceRuleActiveResponse) ProtoMessage() {}
+
+func (x *SetPriceRuleActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_pricing_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceRuleActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetPriceRuleActiveResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_pricing_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetPriceRuleActiveResponse) GetRule() *PriceRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+var File_pricing_pricing_proto protoreflect.FileDescriptor
+
+const file_pricing_pricing_proto_rawDesc = "" +
+	"\n" +
+	"\x15pricing/pricing.proto\x12\apricing\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdb\x03\n" +
+	"\tPriceList\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12)\n" +
+	"\x10customer_segment\x18\x04 \x01(\tR\x0fcustomerSegment\x12\x18\n" +
+	"\achannel\x18\x05 \x01(\tR\achannel\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x127\n" +
+	"\tstarts_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x121\n" +
+	"\aentries\x18\f \x03(\v2\x17.pricing.PriceListEntryR\aentries\"q\n" +
+	"\x0ePriceListEntry\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fmin_quantity\x18\x02 \x01(\x05R\vminQuantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\"\x94\x04\n" +
+	"\tPriceRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x05 \x01(\tR\tproductId\x12)\n" +
+	"\x10customer_segment\x18\x06 \x01(\tR\x0fcustomerSegment\x12\x18\n" +
+	"\achannel\x18\a \x01(\tR\achannel\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12!\n" +
+	"\fmin_quantity\x18\t \x01(\x05R\vminQuantity\x12\x1a\n" +
+	"\bpriority\x18\n" +
+	" \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06active\x18\v \x01(\bR\x06active\x127\n" +
+	"\tstarts_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"F\n" +
+	"\tPriceLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xa1\x02\n" +
+	"\rResolvedPrice\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12&\n" +
+	"\x0fbase_unit_price\x18\x04 \x01(\x01R\rbaseUnitPrice\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x05 \x01(\x01R\tunitPrice\x12\x1d\n" +
+	"\n" +
+	"line_total\x18\x06 \x01(\x01R\tlineTotal\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x12\"\n" +
+	"\rprice_list_id\x18\b \x01(\tR\vpriceListId\x12\x17\n" +
+	"\arule_id\x18\t \x01(\tR\x06ruleId\"\xb1\x01\n" +
+	"\x13ResolvePriceRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12)\n" +
+	"\x10customer_segment\x18\x03 \x01(\tR\x0fcustomerSegment\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"D\n" +
+	"\x14ResolvePriceResponse\x12,\n" +
+	"\x05price\x18\x01 \x01(\v2\x16.pricing.ResolvedPriceR\x05price\"\xa1\x01\n" +
+	"\x14ResolvePricesRequest\x12(\n" +
+	"\x05lines\x18\x01 \x03(\v2\x12.pricing.PriceLineR\x05lines\x12)\n" +
+	"\x10customer_segment\x18\x02 \x01(\tR\x0fcustomerSegment\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\x7f\n" +
+	"\x15ResolvePricesResponse\x12.\n" +
+	"\x06prices\x18\x01 \x03(\v2\x16.pricing.ResolvedPriceR\x06prices\x12\x1a\n" +
+	"\bsubtotal\x18\x02 \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x97\x02\n" +
+	"\x16CreatePriceListRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12)\n" +
+	"\x10customer_segment\x18\x03 \x01(\tR\x0fcustomerSegment\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x127\n" +
+	"\tstarts_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\"L\n" +
+	"\x17CreatePriceListResponse\x121\n" +
+	"\n" +
+	"price_list\x18\x01 \x01(\v2\x12.pricing.PriceListR\tpriceList\"%\n" +
+	"\x13GetPriceListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x14GetPriceListResponse\x121\n" +
+	"\n" +
+	"price_list\x18\x01 \x01(\v2\x12.pricing.PriceListR\tpriceList\"H\n" +
+	"\x15ListPriceListsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x94\x01\n" +
+	"\x16ListPriceListsResponse\x123\n" +
+	"\vprice_lists\x18\x01 \x03(\v2\x12.pricing.PriceListR\n" +
+	"priceLists\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"C\n" +
+	"\x19SetPriceListActiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"O\n" +
+	"\x1aSetPriceListActiveResponse\x121\n" +
+	"\n" +
+	"price_list\x18\x01 \x01(\v2\x12.pricing.PriceListR\tpriceList\"\x92\x01\n" +
+	"\x1aSetPriceListEntriesRequest\x12\"\n" +
+	"\rprice_list_id\x18\x01 \x01(\tR\vpriceListId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x121\n" +
+	"\aentries\x18\x03 \x03(\v2\x17.pricing.PriceListEntryR\aentries\"P\n" +
+	"\x1bSetPriceListEntriesResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.pricing.PriceListEntryR\aentries\"\x83\x03\n" +
+	"\x16CreatePriceRuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\tR\tproductId\x12)\n" +
+	"\x10customer_segment\x18\x05 \x01(\tR\x0fcustomerSegment\x12\x18\n" +
+	"\achannel\x18\x06 \x01(\tR\achannel\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12!\n" +
+	"\fmin_quantity\x18\b \x01(\x05R\vminQuantity\x12\x1a\n" +
+	"\bpriority\x18\t \x01(\x05R\bpriority\x127\n" +
+	"\tstarts_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\"A\n" +
+	"\x17CreatePriceRuleResponse\x12&\n" +
+	"\x04rule\x18\x01 \x01(\v2\x12.pricing.PriceRuleR\x04rule\"H\n" +
+	"\x15ListPriceRulesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x89\x01\n" +
+	"\x16ListPriceRulesResponse\x12(\n" +
+	"\x05rules\x18\x01 \x03(\v2\x12.pricing.PriceRuleR\x05rules\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"C\n" +
+	"\x19SetPriceRuleActiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"D\n" +
+	"\x1aSetPriceRuleActiveResponse\x12&\n" +
+	"\x04rule\x18\x01 \x01(\v2\x12.pricing.PriceRuleR\x04rule2\xec\x06\n" +
+	"\x0ePricingService\x12K\n" +
+	"\fResolvePrice\x12\x1c.pricing.ResolvePriceRequest\x1a\x1d.pricing.ResolvePriceResponse\x12N\n" +
+	"\rResolvePrices\x12\x1d.pricing.ResolvePricesRequest\x1a\x1e.pricing.ResolvePricesResponse\x12T\n" +
+	"\x0fCreatePriceList\x12\x1f.pricing.CreatePriceListRequest\x1a .pricing.CreatePriceListResponse\x12K\n" +
+	"\fGetPriceList\x12\x1c.pricing.GetPriceListRequest\x1a\x1d.pricing.GetPriceListResponse\x12Q\n" +
+	"\x0eListPriceLists\x12\x1e.pricing.ListPriceListsRequest\x1a\x1f.pricing.ListPriceListsResponse\x12]\n" +
+	"\x12SetPriceListActive\x12\".pricing.SetPriceListActiveRequest\x1a#.pricing.SetPriceListActiveResponse\x12`\n" +
+	"\x13SetPriceListEntries\x12#.pricing.SetPriceListEntriesRequest\x1a$.pricing.SetPriceListEntriesResponse\x12T\n" +
+	"\x0fCreatePriceRule\x12\x1f.pricing.CreatePriceRuleRequest\x1a .pricing.CreatePriceRuleResponse\x12Q\n" +
+	"\x0eListPriceRules\x12\x1e.pricing.ListPriceRulesRequest\x1a\x1f.pricing.ListPriceRulesResponse\x12]\n" +
+	"\x12SetPriceRuleActive\x12\".pricing.SetPriceRuleActiveRequest\x1a#.pricing.SetPriceRuleActiveResponseB7Z5github.com/Ujjwaljain16/E-commerce-Backend/pricing/pbb\x06proto3"
+
+var (
+	file_pricing_pricing_proto_rawDescOnce sync.Once
+	file_pricing_pricing_proto_rawDescData []byte
+)
+
+func file_pricing_pricing_proto_rawDescGZIP() []byte {
+	file_pricing_pricing_proto_rawDescOnce.Do(func() {
+		file_pricing_pricing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pricing_pricing_proto_rawDesc), len(file_pricing_pricing_proto_rawDesc)))
+	})
+	return file_pricing_pricing_proto_rawDescData
+}
+
+var file_pricing_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_pricing_pricing_proto_goTypes = []any{
+	(*PriceList)(nil),                   // 0: pricing.PriceList
+	(*PriceListEntry)(nil),              // 1: pricing.PriceListEntry
+	(*PriceRule)(nil),                   // 2: pricing.PriceRule
+	(*PriceLine)(nil),                   // 3: pricing.PriceLine
+	(*ResolvedPrice)(nil),               // 4: pricing.ResolvedPrice
+	(*ResolvePriceRequest)(nil),         // 5: pricing.ResolvePriceRequest
+	(*ResolvePriceResponse)(nil),        // 6: pricing.ResolvePriceResponse
+	(*ResolvePricesRequest)(nil),        // 7: pricing.ResolvePricesRequest
+	(*ResolvePricesResponse)(nil),       // 8: pricing.ResolvePricesResponse
+	(*CreatePriceListRequest)(nil),      // 9: pricing.CreatePriceListRequest
+	(*CreatePriceListResponse)(nil),     // 10: pricing.CreatePriceListResponse
+	(*GetPriceListRequest)(nil),         // 11: pricing.GetPriceListRequest
+	(*GetPriceListResponse)(nil),        // 12: pricing.GetPriceListResponse
+	(*ListPriceListsRequest)(nil),       // 13: pricing.ListPriceListsRequest
+	(*ListPriceListsResponse)(nil),      // 14: pricing.ListPriceListsResponse
+	(*SetPriceListActiveRequest)(nil),   // 15: pricing.SetPriceListActiveRequest
+	(*SetPriceListActiveResponse)(nil),  // 16: pricing.SetPriceListActiveResponse
+	(*SetPriceListEntriesRequest)(nil),  // 17: pricing.SetPriceListEntriesRequest
+	(*SetPriceListEntriesResponse)(nil), // 18: pricing.SetPriceListEntriesResponse
+	(*CreatePriceRuleRequest)(nil),      // 19: pricing.CreatePriceRuleRequest
+	(*CreatePriceRuleResponse)(nil),     // 20: pricing.CreatePriceRuleResponse
+	(*ListPriceRulesRequest)(nil),       // 21: pricing.ListPriceRulesRequest
+	(*ListPriceRulesResponse)(nil),      // 22: pricing.ListPriceRulesResponse
+	(*SetPriceRuleActiveRequest)(nil),   // 23: pricing.SetPriceRuleActiveRequest
+	(*SetPriceRuleActiveResponse)(nil),  // 24: pricing.SetPriceRuleActiveResponse
+	(*timestamppb.Timestamp)(nil),       // 25: google.protobuf.Timestamp
+}
+var file_pricing_pricing_proto_depIdxs = []int32{
+	25, // 0: pricing.PriceList.starts_at:type_name -> google.protobuf.Timestamp
+	25, // 1: pricing.PriceList.ends_at:type_name -> google.protobuf.Timestamp
+	25, // 2: pricing.PriceList.created_at:type_name -> google.protobuf.Timestamp
+	25, // 3: pricing.PriceList.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 4: pricing.PriceList.entries:type_name -> pricing.PriceListEntry
+	25, // 5: pricing.PriceRule.starts_at:type_name -> google.protobuf.Timestamp
+	25, // 6: pricing.PriceRule.ends_at:type_name -> google.protobuf.Timestamp
+	25, // 7: pricing.PriceRule.created_at:type_name -> google.protobuf.Timestamp
+	25, // 8: pricing.PriceRule.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 9: pricing.ResolvePriceResponse.price:type_name -> pricing.ResolvedPrice
+	3,  // 10: pricing.ResolvePricesRequest.lines:type_name -> pricing.PriceLine
+	4,  // 11: pricing.ResolvePricesResponse.prices:type_name -> pricing.ResolvedPrice
+	25, // 12: pricing.CreatePriceListRequest.starts_at:type_name -> google.protobuf.Timestamp
+	25, // 13: pricing.CreatePriceListRequest.ends_at:type_name -> google.protobuf.Timestamp
+	0,  // 14: pricing.CreatePriceListResponse.price_list:type_name -> pricing.PriceList
+	0,  // 15: pricing.GetPriceListResponse.price_list:type_name -> pricing.PriceList
+	0,  // 16: pricing.ListPriceListsResponse.price_lists:type_name -> pricing.PriceList
+	0,  // 17: pricing.SetPriceListActiveResponse.price_list:type_name -> pricing.PriceList
+	1,  // 18: pricing.SetPriceListEntriesRequest.entries:type_name -> pricing.PriceListEntry
+	1,  // 19: pricing.SetPriceListEntriesResponse.entries:type_name -> pricing.PriceListEntry
+	25, // 20: pricing.CreatePriceRuleRequest.starts_at:type_name -> google.protobuf.Timestamp
+	25, // 21: pricing.CreatePriceRuleRequest.ends_at:type_name -> google.protobuf.Timestamp
+	2,  // 22: pricing.CreatePriceRuleResponse.rule:type_name -> pricing.PriceRule
+	2,  // 23: pricing.ListPriceRulesResponse.rules:type_name -> pricing.PriceRule
+	2,  // 24: pricing.SetPriceRuleActiveResponse.rule:type_name -> pricing.PriceRule
+	5,  // 25: pricing.PricingService.ResolvePrice:input_type -> pricing.ResolvePriceRequest
+	7,  // 26: pricing.PricingService.ResolvePrices:input_type -> pricing.ResolvePricesRequest
+	9,  // 27: pricing.PricingService.CreatePriceList:input_type -> pricing.CreatePriceListRequest
+	11, // 28: pricing.PricingService.GetPriceList:input_type -> pricing.GetPriceListRequest
+	13, // 29: pricing.PricingService.ListPriceLists:input_type -> pricing.ListPriceListsRequest
+	15, // 30: pricing.PricingService.SetPriceListActive:input_type -> pricing.SetPriceListActiveRequest
+	17, // 31: pricing.PricingService.SetPriceListEntries:input_type -> pricing.SetPriceListEntriesRequest
+	19, // 32: pricing.PricingService.CreatePriceRule:input_type -> pricing.CreatePriceRuleRequest
+	21, // 33: pricing.PricingService.ListPriceRules:input_type -> pricing.ListPriceRulesRequest
+	23, // 34: pricing.PricingService.SetPriceRuleActive:input_type -> pricing.SetPriceRuleActiveRequest
+	6,  // 35: pricing.PricingService.ResolvePrice:output_type -> pricing.ResolvePriceResponse
+	8,  // 36: pricing.PricingService.ResolvePrices:output_type -> pricing.ResolvePricesResponse
+	10, // 37: pricing.PricingService.CreatePriceList:output_type -> pricing.CreatePriceListResponse
+	12, // 38: pricing.PricingService.GetPriceList:output_type -> pricing.GetPriceListResponse
+	14, // 39: pricing.PricingService.ListPriceLists:output_type -> pricing.ListPriceListsResponse
+	16, // 40: pricing.PricingService.SetPriceListActive:output_type -> pricing.SetPriceListActiveResponse
+	18, // 41: pricing.PricingService.SetPriceListEntries:output_type -> pricing.SetPriceListEntriesResponse
+	20, // 42: pricing.PricingService.CreatePriceRule:output_type -> pricing.CreatePriceRuleResponse
+	22, // 43: pricing.PricingService.ListPriceRules:output_type -> pricing.ListPriceRulesResponse
+	24, // 44: pricing.PricingService.SetPriceRuleActive:output_type -> pricing.SetPriceRuleActiveResponse
+	35, // [35:45] is the sub-list for method output_type
+	25, // [25:35] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_pricing_pricing_proto_init() }
+func file_pricing_pricing_proto_init() {
+	if File_pricing_pricing_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pricing_pricing_proto_rawDesc), len(file_pricing_pricing_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pricing_pricing_proto_goTypes,
+		DependencyIndexes: file_pricing_pricing_proto_depIdxs,
+		MessageInfos:      file_pricing_pricing_proto_msgTypes,
+	}.Build()
+	File_pricing_pricing_proto = out.File
+	file_pricing_pricing_proto_goTypes = nil
+	file_pricing_pricing_proto_depIdxs = nil
+}
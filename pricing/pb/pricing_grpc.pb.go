@@ -0,0 +1,469 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: pricing/pricing.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PricingService_ResolvePrice_FullMethodName        = "/pricing.PricingService/ResolvePrice"
+	PricingService_ResolvePrices_FullMethodName       = "/pricing.PricingService/ResolvePrices"
+	PricingService_CreatePriceList_FullMethodName     = "/pricing.PricingService/CreatePriceList"
+	PricingService_GetPriceList_FullMethodName        = "/pricing.PricingService/GetPriceList"
+	PricingService_ListPriceLists_FullMethodName      = "/pricing.PricingService/ListPriceLists"
+	PricingService_SetPriceListActive_FullMethodName  = "/pricing.PricingService/SetPriceListActive"
+	PricingService_SetPriceListEntries_FullMethodName = "/pricing.PricingService/SetPriceListEntries"
+	PricingService_CreatePriceRule_FullMethodName     = "/pricing.PricingService/CreatePriceRule"
+	PricingService_ListPriceRules_FullMethodName      = "/pricing.PricingService/ListPriceRules"
+	PricingService_SetPriceRuleActive_FullMethodName  = "/pricing.PricingService/SetPriceRuleActive"
+)
+
+// PricingServiceClient is the client API for PricingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PricingService resolves the final price of products from price lists and
+// price rules on top of the catalog price
+type PricingServiceClient interface {
+	ResolvePrice(ctx context.Context, in *ResolvePriceRequest, opts ...grpc.CallOption) (*ResolvePriceResponse, error)
+	ResolvePrices(ctx context.Context, in *ResolvePricesRequest, opts ...grpc.CallOption) (*ResolvePricesResponse, error)
+	CreatePriceList(ctx context.Context, in *CreatePriceListRequest, opts ...grpc.CallOption) (*CreatePriceListResponse, error)
+	GetPriceList(ctx context.Context, in *GetPriceListRequest, opts ...grpc.CallOption) (*GetPriceListResponse, error)
+	ListPriceLists(ctx context.Context, in *ListPriceListsRequest, opts ...grpc.CallOption) (*ListPriceListsResponse, error)
+	SetPriceListActive(ctx context.Context, in *SetPriceListActiveRequest, opts ...grpc.CallOption) (*SetPriceListActiveResponse, error)
+	SetPriceListEntries(ctx context.Context, in *SetPriceListEntriesRequest, opts ...grpc.CallOption) (*SetPriceListEntriesResponse, error)
+	CreatePriceRule(ctx context.Context, in *CreatePriceRuleRequest, opts ...grpc.CallOption) (*CreatePriceRuleResponse, error)
+	ListPriceRules(ctx context.Context, in *ListPriceRulesRequest, opts ...grpc.CallOption) (*ListPriceRulesResponse, error)
+	SetPriceRuleActive(ctx context.Context, in *SetPriceRuleActiveRequest, opts ...grpc.CallOption) (*SetPriceRuleActiveResponse, error)
+}
+
+type pricingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPricingServiceClient(cc grpc.ClientConnInterface) PricingServiceClient {
+	return &pricingServiceClient{cc}
+}
+
+func (c *pricingServiceClient) ResolvePrice(ctx context.Context, in *ResolvePriceRequest, opts ...grpc.CallOption) (*ResolvePriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolvePriceResponse)
+	err := c.cc.Invoke(ctx, PricingService_ResolvePrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) ResolvePrices(ctx context.Context, in *ResolvePricesRequest, opts ...grpc.CallOption) (*ResolvePricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolvePricesResponse)
+	err := c.cc.Invoke(ctx, PricingService_ResolvePrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) CreatePriceList(ctx context.Context, in *CreatePriceListRequest, opts ...grpc.CallOption) (*CreatePriceListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePriceListResponse)
+	err := c.cc.Invoke(ctx, PricingService_CreatePriceList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) GetPriceList(ctx context.Context, in *GetPriceListRequest, opts ...grpc.CallOption) (*GetPriceListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceListResponse)
+	err := c.cc.Invoke(ctx, PricingService_GetPriceList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) ListPriceLists(ctx context.Context, in *ListPriceListsRequest, opts ...grpc.CallOption) (*ListPriceListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPriceListsResponse)
+	err := c.cc.Invoke(ctx, PricingService_ListPriceLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) SetPriceListActive(ctx context.Context, in *SetPriceListActiveRequest, opts ...grpc.CallOption) (*SetPriceListActiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPriceListActiveResponse)
+	err := c.cc.Invoke(ctx, PricingService_SetPriceListActive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) SetPriceListEntries(ctx context.Context, in *SetPriceListEntriesRequest, opts ...grpc.CallOption) (*SetPriceListEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPriceListEntriesResponse)
+	err := c.cc.Invoke(ctx, PricingService_SetPriceListEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) CreatePriceRule(ctx context.Context, in *CreatePriceRuleRequest, opts ...grpc.CallOption) (*CreatePriceRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePriceRuleResponse)
+	err := c.cc.Invoke(ctx, PricingService_CreatePriceRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) ListPriceRules(ctx context.Context, in *ListPriceRulesRequest, opts ...grpc.CallOption) (*ListPriceRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPriceRulesResponse)
+	err := c.cc.Invoke(ctx, PricingService_ListPriceRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) SetPriceRuleActive(ctx context.Context, in *SetPriceRuleActiveRequest, opts ...grpc.CallOption) (*SetPriceRuleActiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPriceRuleActiveResponse)
+	err := c.cc.Invoke(ctx, PricingService_SetPriceRuleActive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PricingServiceServer is the server API for PricingService service.
+// All implementations must embed UnimplementedPricingServiceServer
+// for forward compatibility.
+//
+// PricingService resolves the final price of products from price lists and
+// price rules on top of the catalog price
+type PricingServiceServer interface {
+	ResolvePrice(context.Context, *ResolvePriceRequest) (*ResolvePriceResponse, error)
+	ResolvePrices(context.Context, *ResolvePricesRequest) (*ResolvePricesResponse, error)
+	CreatePriceList(context.Context, *CreatePriceListRequest) (*CreatePriceListResponse, error)
+	GetPriceList(context.Context, *GetPriceListRequest) (*GetPriceListResponse, error)
+	ListPriceLists(context.Context, *ListPriceListsRequest) (*ListPriceListsResponse, error)
+	SetPriceListActive(context.Context, *SetPriceListActiveRequest) (*SetPriceListActiveResponse, error)
+	SetPriceListEntries(context.Context, *SetPriceListEntriesRequest) (*SetPriceListEntriesResponse, error)
+	CreatePriceRule(context.Context, *CreatePriceRuleRequest) (*CreatePriceRuleResponse, error)
+	ListPriceRules(context.Context, *ListPriceRulesRequest) (*ListPriceRulesResponse, error)
+	SetPriceRuleActive(context.Context, *SetPriceRuleActiveRequest) (*SetPriceRuleActiveResponse, error)
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+// UnimplementedPricingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPricingServiceServer struct{}
+
+func (UnimplementedPricingServiceServer) ResolvePrice(context.Context, *ResolvePriceRequest) (*ResolvePriceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolvePrice not implemented")
+}
+func (UnimplementedPricingServiceServer) ResolvePrices(context.Context, *ResolvePricesRequest) (*ResolvePricesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolvePrices not implemented")
+}
+func (UnimplementedPricingServiceServer) CreatePriceList(context.Context, *CreatePriceListRequest) (*CreatePriceListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePriceList not implemented")
+}
+func (UnimplementedPricingServiceServer) GetPriceList(context.Context, *GetPriceListRequest) (*GetPriceListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPriceList not implemented")
+}
+func (UnimplementedPricingServiceServer) ListPriceLists(context.Context, *ListPriceListsRequest) (*ListPriceListsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPriceLists not implemented")
+}
+func (UnimplementedPricingServiceServer) SetPriceListActive(context.Context, *SetPriceListActiveRequest) (*SetPriceListActiveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPriceListActive not implemented")
+}
+func (UnimplementedPricingServiceServer) SetPriceListEntries(context.Context, *SetPriceListEntriesRequest) (*SetPriceListEntriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPriceListEntries not implemented")
+}
+func (UnimplementedPricingServiceServer) CreatePriceRule(context.Context, *CreatePriceRuleRequest) (*CreatePriceRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePriceRule not implemented")
+}
+func (UnimplementedPricingServiceServer) ListPriceRules(context.Context, *ListPriceRulesRequest) (*ListPriceRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPriceRules not implemented")
+}
+func (UnimplementedPricingServiceServer) SetPriceRuleActive(context.Context, *SetPriceRuleActiveRequest) (*SetPriceRuleActiveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPriceRuleActive not implemented")
+}
+func (UnimplementedPricingServiceServer) mustEmbedUnimplementedPricingServiceServer() {}
+func (UnimplementedPricingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePricingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PricingServiceServer will
+// result in compilation errors.
+type UnsafePricingServiceServer interface {
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+func RegisterPricingServiceServer(s grpc.ServiceRegistrar, srv PricingServiceServer) {
+	// If the following call panics, it indicates UnimplementedPricingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PricingService_ServiceDesc, srv)
+}
+
+func _PricingService_ResolvePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolvePriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ResolvePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ResolvePrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ResolvePrice(ctx, req.(*ResolvePriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_ResolvePrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolvePricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ResolvePrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ResolvePrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ResolvePrices(ctx, req.(*ResolvePricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_CreatePriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePriceListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).CreatePriceList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_CreatePriceList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).CreatePriceList(ctx, req.(*CreatePriceListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_GetPriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).GetPriceList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_GetPriceList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).GetPriceList(ctx, req.(*GetPriceListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_ListPriceLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ListPriceLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ListPriceLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ListPriceLists(ctx, req.(*ListPriceListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_SetPriceListActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceListActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).SetPriceListActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_SetPriceListActive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).SetPriceListActive(ctx, req.(*SetPriceListActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_SetPriceListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceListEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).SetPriceListEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_SetPriceListEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).SetPriceListEntries(ctx, req.(*SetPriceListEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_CreatePriceRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePriceRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).CreatePriceRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_CreatePriceRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).CreatePriceRule(ctx, req.(*CreatePriceRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_ListPriceRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ListPriceRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ListPriceRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ListPriceRules(ctx, req.(*ListPriceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_SetPriceRuleActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceRuleActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).SetPriceRuleActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_SetPriceRuleActive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).SetPriceRuleActive(ctx, req.(*SetPriceRuleActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PricingService_ServiceDesc is the grpc.ServiceDesc for PricingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PricingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pricing.PricingService",
+	HandlerType: (*PricingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ResolvePrice",
+			Handler:    _PricingService_ResolvePrice_Handler,
+		},
+		{
+			MethodName: "ResolvePrices",
+			Handler:    _PricingService_ResolvePrices_Handler,
+		},
+		{
+			MethodName: "CreatePriceList",
+			Handler:    _PricingService_CreatePriceList_Handler,
+		},
+		{
+			MethodName: "GetPriceList",
+			Handler:    _PricingService_GetPriceList_Handler,
+		},
+		{
+			MethodName: "ListPriceLists",
+			Handler:    _PricingService_ListPriceLists_Handler,
+		},
+		{
+			MethodName: "SetPriceListActive",
+			Handler:    _PricingService_SetPriceListActive_Handler,
+		},
+		{
+			MethodName: "SetPriceListEntries",
+			Handler:    _PricingService_SetPriceListEntries_Handler,
+		},
+		{
+			MethodName: "CreatePriceRule",
+			Handler:    _PricingService_CreatePriceRule_Handler,
+		},
+		{
+			MethodName: "ListPriceRules",
+			Handler:    _PricingService_ListPriceRules_Handler,
+		},
+		{
+			MethodName: "SetPriceRuleActive",
+			Handler:    _PricingService_SetPriceRuleActive_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pricing/pricing.proto",
+}
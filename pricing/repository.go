@@ -0,0 +1,467 @@
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Price rule types
+const (
+	RulePercentOff = "PERCENT_OFF"
+	RuleAmountOff  = "AMOUNT_OFF"
+	RuleFixedPrice = "FIXED_PRICE"
+)
+
+var (
+	// ErrPriceListNotFound is returned when a price list does not exist
+	ErrPriceListNotFound = errors.New("price list not found")
+	// ErrPriceRuleNotFound is returned when a price rule does not exist
+	ErrPriceRuleNotFound = errors.New("price rule not found")
+)
+
+// PriceList sets product prices in one currency for the customers and
+// channels it applies to
+type PriceList struct {
+	ID       string
+	Name     string
+	Currency string
+	// CustomerSegment and Channel are empty when the list applies to every
+	// segment or channel
+	CustomerSegment string
+	Channel         string
+	Priority        int32
+	Active          bool
+	StartsAt        *time.Time
+	EndsAt          *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	// Entries are only loaded by GetPriceList
+	Entries []*PriceListEntry
+}
+
+// PriceListEntry is a product's unit price from a quantity on
+type PriceListEntry struct {
+	ProductID   string
+	MinQuantity int32
+	UnitPrice   float64
+}
+
+// PriceRule adjusts the price a price list or the catalog gave. Empty
+// ProductID, CustomerSegment, Channel and Currency match every one.
+type PriceRule struct {
+	ID              string
+	Name            string
+	Type            string
+	Value           float64
+	ProductID       string
+	CustomerSegment string
+	Channel         string
+	Currency        string
+	MinQuantity     int32
+	Priority        int32
+	Active          bool
+	StartsAt        *time.Time
+	EndsAt          *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Offer is a price list entry for a product together with the terms of its
+// list
+type Offer struct {
+	PriceListID     string
+	CustomerSegment string
+	Channel         string
+	Priority        int32
+	MinQuantity     int32
+	UnitPrice       float64
+}
+
+// Repository handles pricing data persistence
+type Repository interface {
+	CreatePriceList(ctx context.Context, list *PriceList) error
+	// GetPriceList returns a price list with its entries
+	GetPriceList(ctx context.Context, id string) (*PriceList, error)
+	// ListPriceLists lists price lists by priority, highest first
+	ListPriceLists(ctx context.Context, page, pageSize int32) ([]*PriceList, int32, error)
+	SetPriceListActive(ctx context.Context, id string, active bool, at time.Time) (*PriceList, error)
+	// SetPriceListEntries replaces the entries of a product in a price list
+	SetPriceListEntries(ctx context.Context, listID, productID string, entries []*PriceListEntry, at time.Time) error
+	CreatePriceRule(ctx context.Context, rule *PriceRule) error
+	// ListPriceRules lists price rules by priority, highest first
+	ListPriceRules(ctx context.Context, page, pageSize int32) ([]*PriceRule, int32, error)
+	SetPriceRuleActive(ctx context.Context, id string, active bool, at time.Time) (*PriceRule, error)
+	// Offers returns the entries for a product of the price lists in a
+	// currency that are active at a time
+	Offers(ctx context.Context, productID, currency string, at time.Time) ([]*Offer, error)
+	// Rules returns the price rules for a product or every product that are
+	// active at a time
+	Rules(ctx context.Context, productID string, at time.Time) ([]*PriceRule, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL pricing repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const priceListColumns = `id, name, currency, customer_segment, channel, priority, active, starts_at, ends_at, created_at, updated_at`
+
+const priceRuleColumns = `id, name, type, value, product_id, customer_segment, channel, currency, min_quantity, priority, active,
+	starts_at, ends_at, created_at, updated_at`
+
+// CreatePriceList inserts a new price list
+func (r *postgresRepository) CreatePriceList(ctx context.Context, list *PriceList) error {
+	list.ID = uuid.New().String()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO price_lists (id, name, currency, customer_segment, channel, priority, active, starts_at, ends_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, list.ID, list.Name, list.Currency, list.CustomerSegment, list.Channel, list.Priority, list.Active,
+		list.StartsAt, list.EndsAt, list.CreatedAt, list.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create price list", map[string]interface{}{"error": err.Error(), "name": list.Name})
+		return fmt.Errorf("failed to create price list: %w", err)
+	}
+
+	r.log.Info(ctx, "Price list created", map[string]interface{}{"price_list_id": list.ID, "currency": list.Currency})
+	return nil
+}
+
+// GetPriceList retrieves a price list and its entries
+func (r *postgresRepository) GetPriceList(ctx context.Context, id string) (*PriceList, error) {
+	query := "SELECT " + priceListColumns + " FROM price_lists WHERE id = $1"
+	list, err := scanPriceList(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrPriceListNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get price list", map[string]interface{}{"error": err.Error(), "price_list_id": id})
+		return nil, fmt.Errorf("failed to get price list: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT product_id, min_quantity, unit_price FROM price_list_entries
+		WHERE price_list_id = $1
+		ORDER BY product_id, min_quantity
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price list entries: %w", err)
+	}
+	defer rows.Close()
+
+	list.Entries = []*PriceListEntry{}
+	for rows.Next() {
+		e := &PriceListEntry{}
+		if err := rows.Scan(&e.ProductID, &e.MinQuantity, &e.UnitPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan price list entry: %w", err)
+		}
+		list.Entries = append(list.Entries, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating price list entries: %w", err)
+	}
+
+	return list, nil
+}
+
+// ListPriceLists retrieves price lists with pagination
+func (r *postgresRepository) ListPriceLists(ctx context.Context, page, pageSize int32) ([]*PriceList, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM price_lists").Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count price lists", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count price lists: %w", err)
+	}
+
+	query := "SELECT " + priceListColumns + ` FROM price_lists
+		ORDER BY priority DESC, created_at, id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list price lists", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list price lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := make([]*PriceList, 0, pageSize)
+	for rows.Next() {
+		list, err := scanPriceList(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan price list", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan price list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating price lists", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating price lists: %w", err)
+	}
+
+	return lists, total, nil
+}
+
+// SetPriceListActive switches a price list on or off
+func (r *postgresRepository) SetPriceListActive(ctx context.Context, id string, active bool, at time.Time) (*PriceList, error) {
+	query := "UPDATE price_lists SET active = $2, updated_at = $3 WHERE id = $1 RETURNING " + priceListColumns
+	list, err := scanPriceList(r.db.QueryRowContext(ctx, query, id, active, at))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrPriceListNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update price list", map[string]interface{}{"error": err.Error(), "price_list_id": id})
+		return nil, fmt.Errorf("failed to update price list: %w", err)
+	}
+
+	r.log.Info(ctx, "Price list updated", map[string]interface{}{"price_list_id": id, "active": active})
+	return list, nil
+}
+
+// SetPriceListEntries deletes the product's entries and inserts the new ones
+// in one transaction. The list row is locked so concurrent replacements of
+// the same product do not interleave.
+func (r *postgresRepository) SetPriceListEntries(ctx context.Context, listID, productID string, entries []*PriceListEntry, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowContext(ctx, "UPDATE price_lists SET updated_at = $2 WHERE id = $1 RETURNING id", listID, at).Scan(&id)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return ErrPriceListNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock price list: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM price_list_entries WHERE price_list_id = $1 AND product_id = $2", listID, productID)
+	if err != nil {
+		return fmt.Errorf("failed to delete price list entries: %w", err)
+	}
+	for _, e := range entries {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO price_list_entries (price_list_id, product_id, min_quantity, unit_price) VALUES ($1, $2, $3, $4)
+		`, listID, productID, e.MinQuantity, e.UnitPrice)
+		if err != nil {
+			r.log.Error(ctx, "Failed to insert price list entry", map[string]interface{}{"error": err.Error(), "price_list_id": listID})
+			return fmt.Errorf("failed to insert price list entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Price list entries set", map[string]interface{}{"price_list_id": listID, "product_id": productID, "entries": len(entries)})
+	return nil
+}
+
+// CreatePriceRule inserts a new price rule
+func (r *postgresRepository) CreatePriceRule(ctx context.Context, rule *PriceRule) error {
+	rule.ID = uuid.New().String()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO price_rules (id, name, type, value, product_id, customer_segment, channel, currency, min_quantity,
+			priority, active, starts_at, ends_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`, rule.ID, rule.Name, rule.Type, rule.Value, rule.ProductID, rule.CustomerSegment, rule.Channel, rule.Currency,
+		rule.MinQuantity, rule.Priority, rule.Active, rule.StartsAt, rule.EndsAt, rule.CreatedAt, rule.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create price rule", map[string]interface{}{"error": err.Error(), "name": rule.Name})
+		return fmt.Errorf("failed to create price rule: %w", err)
+	}
+
+	r.log.Info(ctx, "Price rule created", map[string]interface{}{"rule_id": rule.ID, "type": rule.Type})
+	return nil
+}
+
+// ListPriceRules retrieves price rules with pagination
+func (r *postgresRepository) ListPriceRules(ctx context.Context, page, pageSize int32) ([]*PriceRule, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM price_rules").Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count price rules", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count price rules: %w", err)
+	}
+
+	query := "SELECT " + priceRuleColumns + ` FROM price_rules
+		ORDER BY priority DESC, created_at, id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list price rules", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list price rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules, err := scanPriceRules(rows)
+	if err != nil {
+		r.log.Error(ctx, "Failed to scan price rules", map[string]interface{}{"error": err.Error()})
+		return nil, 0, err
+	}
+	return rules, total, nil
+}
+
+// SetPriceRuleActive switches a price rule on or off
+func (r *postgresRepository) SetPriceRuleActive(ctx context.Context, id string, active bool, at time.Time) (*PriceRule, error) {
+	query := "UPDATE price_rules SET active = $2, updated_at = $3 WHERE id = $1 RETURNING " + priceRuleColumns
+	rule, err := scanPriceRule(r.db.QueryRowContext(ctx, query, id, active, at))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrPriceRuleNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update price rule", map[string]interface{}{"error": err.Error(), "rule_id": id})
+		return nil, fmt.Errorf("failed to update price rule: %w", err)
+	}
+
+	r.log.Info(ctx, "Price rule updated", map[string]interface{}{"rule_id": id, "active": active})
+	return rule, nil
+}
+
+// Offers reads the product's entries in the active price lists of the
+// currency. Matching the segment, channel and quantity is left to the engine.
+func (r *postgresRepository) Offers(ctx context.Context, productID, currency string, at time.Time) ([]*Offer, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.id, l.customer_segment, l.channel, l.priority, e.min_quantity, e.unit_price
+		FROM price_list_entries e
+		JOIN price_lists l ON l.id = e.price_list_id
+		WHERE e.product_id = $1 AND l.currency = $2 AND l.active
+			AND (l.starts_at IS NULL OR l.starts_at <= $3) AND (l.ends_at IS NULL OR l.ends_at > $3)
+	`, productID, currency, at)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get price list offers", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get price list offers: %w", err)
+	}
+	defer rows.Close()
+
+	var offers []*Offer
+	for rows.Next() {
+		o := &Offer{}
+		if err := rows.Scan(&o.PriceListID, &o.CustomerSegment, &o.Channel, &o.Priority, &o.MinQuantity, &o.UnitPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan price list offer: %w", err)
+		}
+		offers = append(offers, o)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating price list offers: %w", err)
+	}
+	return offers, nil
+}
+
+// Rules reads the active rules for the product and for every product
+func (r *postgresRepository) Rules(ctx context.Context, productID string, at time.Time) ([]*PriceRule, error) {
+	query := "SELECT " + priceRuleColumns + ` FROM price_rules
+		WHERE product_id IN ('', $1) AND active
+			AND (starts_at IS NULL OR starts_at <= $2) AND (ends_at IS NULL OR ends_at > $2)
+	`
+	rows, err := r.db.QueryContext(ctx, query, productID, at)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get price rules", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get price rules: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPriceRules(rows)
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPriceList scans a price list selected with priceListColumns
+func scanPriceList(row rowScanner) (*PriceList, error) {
+	l := &PriceList{}
+	var startsAt, endsAt sql.NullTime
+
+	err := row.Scan(&l.ID, &l.Name, &l.Currency, &l.CustomerSegment, &l.Channel, &l.Priority, &l.Active,
+		&startsAt, &endsAt, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startsAt.Valid {
+		l.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		l.EndsAt = &endsAt.Time
+	}
+	return l, nil
+}
+
+// scanPriceRule scans a price rule selected with priceRuleColumns
+func scanPriceRule(row rowScanner) (*PriceRule, error) {
+	rule := &PriceRule{}
+	var startsAt, endsAt sql.NullTime
+
+	err := row.Scan(&rule.ID, &rule.Name, &rule.Type, &rule.Value, &rule.ProductID, &rule.CustomerSegment, &rule.Channel,
+		&rule.Currency, &rule.MinQuantity, &rule.Priority, &rule.Active, &startsAt, &endsAt, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startsAt.Valid {
+		rule.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		rule.EndsAt = &endsAt.Time
+	}
+	return rule, nil
+}
+
+// scanPriceRules scans every row of a price rule query
+func scanPriceRules(rows *sql.Rows) ([]*PriceRule, error) {
+	rules := []*PriceRule{}
+	for rows.Next() {
+		rule, err := scanPriceRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating price rules: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,177 @@
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("pricing-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var priceListColumnNames = []string{"id", "name", "currency", "customer_segment", "channel", "priority", "active",
+	"starts_at", "ends_at", "created_at", "updated_at"}
+
+func TestCreatePriceList(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO price_lists`).
+		WithArgs(sqlmock.AnyArg(), "Euro", "EUR", "", "web", int32(1), true, nil, nil, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	list := &PriceList{Name: "Euro", Currency: "EUR", Channel: "web", Priority: 1, Active: true, CreatedAt: now, UpdatedAt: now}
+	if err := repo.CreatePriceList(context.Background(), list); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPriceList(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM price_lists WHERE id = \$1`).
+		WithArgs("list-1").
+		WillReturnRows(sqlmock.NewRows(priceListColumnNames).AddRow("list-1", "Euro", "EUR", "", "", 0, true, nil, nil, now, now))
+	mock.ExpectQuery(`SELECT product_id, min_quantity, unit_price FROM price_list_entries\s+WHERE price_list_id = \$1`).
+		WithArgs("list-1").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "min_quantity", "unit_price"}).AddRow("prod-1", 1, 9.0).AddRow("prod-1", 10, 8.0))
+
+	list, err := repo.GetPriceList(context.Background(), "list-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list.Currency != "EUR" || len(list.Entries) != 2 || list.Entries[1].UnitPrice != 8 {
+		t.Errorf("Unexpected price list %+v", list)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPriceList_MalformedID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM price_lists WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	if _, err := repo.GetPriceList(context.Background(), "not-a-uuid"); err != ErrPriceListNotFound {
+		t.Errorf("Expected ErrPriceListNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetPriceListEntries(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE price_lists SET updated_at = \$2 WHERE id = \$1 RETURNING id`).
+		WithArgs("list-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("list-1"))
+	mock.ExpectExec(`DELETE FROM price_list_entries WHERE price_list_id = \$1 AND product_id = \$2`).
+		WithArgs("list-1", "prod-1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT INTO price_list_entries`).
+		WithArgs("list-1", "prod-1", int32(1), 9.0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.SetPriceListEntries(context.Background(), "list-1", "prod-1", []*PriceListEntry{{MinQuantity: 1, UnitPrice: 9}}, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetPriceListEntries_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE price_lists SET updated_at`).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err := repo.SetPriceListEntries(context.Background(), "list-1", "prod-1", nil, time.Now())
+	if err != ErrPriceListNotFound {
+		t.Errorf("Expected ErrPriceListNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestOffers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT l.id, l.customer_segment, l.channel, l.priority, e.min_quantity, e.unit_price\s+FROM price_list_entries e\s+JOIN price_lists l ON l.id = e.price_list_id\s+WHERE e.product_id = \$1 AND l.currency = \$2 AND l.active`).
+		WithArgs("prod-1", "EUR", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "customer_segment", "channel", "priority", "min_quantity", "unit_price"}).
+			AddRow("list-1", "wholesale", "", 1, 10, 7.5))
+
+	offers, err := repo.Offers(context.Background(), "prod-1", "EUR", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(offers) != 1 || offers[0].CustomerSegment != "wholesale" || offers[0].MinQuantity != 10 || offers[0].UnitPrice != 7.5 {
+		t.Errorf("Unexpected offers %v", offers)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRules(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM price_rules\s+WHERE product_id IN \('', \$1\) AND active`).
+		WithArgs("prod-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "type", "value", "product_id", "customer_segment", "channel",
+			"currency", "min_quantity", "priority", "active", "starts_at", "ends_at", "created_at", "updated_at"}).
+			AddRow("rule-1", "VIP", RulePercentOff, 10.0, "", "vip", "", "", 0, 0, true, nil, now, now, now))
+
+	rules, err := repo.Rules(context.Background(), "prod-1", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rules) != 1 || rules[0].CustomerSegment != "vip" || rules[0].StartsAt != nil || rules[0].EndsAt == nil {
+		t.Errorf("Unexpected rules %v", rules)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
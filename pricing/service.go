@@ -0,0 +1,642 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pricing/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the product_id columns
+	maxIDLength = 255
+	// maxNameLength matches the name columns
+	maxNameLength = 255
+	// maxTermLength matches the customer_segment and channel columns
+	maxTermLength = 100
+	// maxAmount fits the DECIMAL(10, 2) price columns
+	maxAmount = 99999999.99
+	// maxLines bounds the lines priced by one ResolvePrices call
+	maxLines = 100
+	// maxEntries bounds the quantity breaks of a product in a price list
+	maxEntries = 20
+)
+
+// AdminMethods are the admin-scoped RPCs of the pricing service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.PricingService_CreatePriceList_FullMethodName,
+	pb.PricingService_GetPriceList_FullMethodName,
+	pb.PricingService_ListPriceLists_FullMethodName,
+	pb.PricingService_SetPriceListActive_FullMethodName,
+	pb.PricingService_SetPriceListEntries_FullMethodName,
+	pb.PricingService_CreatePriceRule_FullMethodName,
+	pb.PricingService_ListPriceRules_FullMethodName,
+	pb.PricingService_SetPriceRuleActive_FullMethodName,
+}
+
+// Service implements the PricingService gRPC interface
+type Service struct {
+	pb.UnimplementedPricingServiceServer
+	repo      Repository
+	catalog   Catalog
+	converter Converter
+	// currency is the currency of catalog prices
+	currency string
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+// NewService creates a new pricing service. currency is the currency the
+// catalog prices products in; converter converts catalog prices into other
+// currencies that have no price list price.
+func NewService(repo Repository, catalog Catalog, converter Converter, currency string, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		catalog:   catalog,
+		converter: converter,
+		currency:  currency,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// ResolvePrice prices a quantity of a product for a customer segment, a
+// channel and a currency
+func (s *Service) ResolvePrice(ctx context.Context, req *pb.ResolvePriceRequest) (*pb.ResolvePriceResponse, error) {
+	terms := Terms{
+		ProductID:       req.ProductId,
+		Quantity:        req.Quantity,
+		CustomerSegment: req.CustomerSegment,
+		Channel:         req.Channel,
+		Currency:        s.currencyOrDefault(req.Currency),
+	}
+	msg := lineProblem(req.ProductId, req.Quantity)
+	if msg == "" {
+		msg = termsProblem(terms)
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Resolve price failed: "+msg, map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	quote, err := s.resolve(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResolvePriceResponse{
+		Price: toProtoPrice(terms, quote),
+	}, nil
+}
+
+// ResolvePrices prices the lines of a cart
+func (s *Service) ResolvePrices(ctx context.Context, req *pb.ResolvePricesRequest) (*pb.ResolvePricesResponse, error) {
+	terms := Terms{
+		CustomerSegment: req.CustomerSegment,
+		Channel:         req.Channel,
+		Currency:        s.currencyOrDefault(req.Currency),
+	}
+	msg := termsProblem(terms)
+	if msg == "" {
+		msg = linesProblem(req.Lines)
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Resolve prices failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	resp := &pb.ResolvePricesResponse{
+		Prices:   make([]*pb.ResolvedPrice, len(req.Lines)),
+		Currency: terms.Currency,
+	}
+	for i, line := range req.Lines {
+		terms.ProductID = line.ProductId
+		terms.Quantity = line.Quantity
+		quote, err := s.resolve(ctx, terms)
+		if err != nil {
+			return nil, err
+		}
+		resp.Prices[i] = toProtoPrice(terms, quote)
+		resp.Subtotal += quote.LineTotal
+	}
+	resp.Subtotal = roundCents(resp.Subtotal)
+	return resp, nil
+}
+
+// resolve prices one line from the catalog price and the price lists and
+// rules active now, returning a gRPC status error on failure
+func (s *Service) resolve(ctx context.Context, terms Terms) (*Quote, error) {
+	catalogPrice, err := s.catalog.UnitPrice(ctx, terms.ProductID, terms.Quantity)
+	if errors.Is(err, ErrProductNotFound) {
+		return nil, status.Error(codes.NotFound, "product "+terms.ProductID+" not found")
+	}
+	if errors.Is(err, ErrInvalidQuantity) {
+		return nil, status.Error(codes.InvalidArgument, "product "+terms.ProductID+": "+err.Error())
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get catalog price", map[string]interface{}{"error": err.Error(), "product_id": terms.ProductID})
+		return nil, status.Error(codes.Unavailable, "failed to get catalog price")
+	}
+
+	now := s.now()
+	offers, err := s.repo.Offers(ctx, terms.ProductID, terms.Currency, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to resolve price")
+	}
+	rules, err := s.repo.Rules(ctx, terms.ProductID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to resolve price")
+	}
+
+	quote, err := Resolve(terms, catalogPrice, s.currency, offers, rules)
+	if !errors.Is(err, ErrNoPrice) {
+		return quote, err
+	}
+
+	// No price list prices the product in the currency, so convert the
+	// catalog price into it
+	converted, err := s.converter.Convert(ctx, catalogPrice, s.currency, terms.Currency)
+	if errors.Is(err, ErrNoRate) {
+		s.log.Info(ctx, "No price for product in currency", map[string]interface{}{"product_id": terms.ProductID, "currency": terms.Currency})
+		return nil, status.Error(codes.FailedPrecondition, "product "+terms.ProductID+" has no price in "+terms.Currency)
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to convert catalog price", map[string]interface{}{"error": err.Error(), "product_id": terms.ProductID})
+		return nil, status.Error(codes.Unavailable, "failed to convert catalog price")
+	}
+	quote, err = Resolve(terms, converted, terms.Currency, nil, rules)
+	if err != nil {
+		return nil, err
+	}
+	quote.Source = SourceConvertedCatalog
+	return quote, nil
+}
+
+// currencyOrDefault upper-cases a requested currency, defaulting to the
+// catalog currency
+func (s *Service) currencyOrDefault(currency string) string {
+	if currency == "" {
+		return s.currency
+	}
+	return strings.ToUpper(currency)
+}
+
+// termsProblem validates the segment, channel and currency of a price
+// request and returns a message describing the first problem, or ""
+func termsProblem(terms Terms) string {
+	switch {
+	case len(terms.CustomerSegment) > maxTermLength:
+		return "customer_segment is too long"
+	case len(terms.Channel) > maxTermLength:
+		return "channel is too long"
+	case !isCurrencyCode(terms.Currency):
+		return "currency must be a three-letter ISO 4217 code"
+	}
+	return ""
+}
+
+// lineProblem validates a product and quantity and returns a message
+// describing the problem, or ""
+func lineProblem(productID string, quantity int32) string {
+	switch {
+	case productID == "":
+		return "product_id is required"
+	case len(productID) > maxIDLength:
+		return "product_id is too long"
+	case quantity <= 0:
+		return "quantity must be positive"
+	}
+	return ""
+}
+
+// linesProblem validates the lines of a ResolvePrices call and returns a
+// message describing the first problem, or ""
+func linesProblem(lines []*pb.PriceLine) string {
+	if len(lines) == 0 {
+		return "lines are required"
+	}
+	if len(lines) > maxLines {
+		return fmt.Sprintf("at most %d lines can be priced at once", maxLines)
+	}
+	seen := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		if msg := lineProblem(line.ProductId, line.Quantity); msg != "" {
+			return msg
+		}
+		if seen[line.ProductId] {
+			return "product " + line.ProductId + " appears more than once"
+		}
+		seen[line.ProductId] = true
+	}
+	return ""
+}
+
+// CreatePriceList creates an active price list without entries
+func (s *Service) CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.CreatePriceListResponse, error) {
+	now := s.now()
+	list := &PriceList{
+		Name:            req.Name,
+		Currency:        strings.ToUpper(req.Currency),
+		CustomerSegment: req.CustomerSegment,
+		Channel:         req.Channel,
+		Priority:        req.Priority,
+		Active:          true,
+		StartsAt:        optionalTime(req.StartsAt),
+		EndsAt:          optionalTime(req.EndsAt),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if msg := priceListProblem(list); msg != "" {
+		s.log.Warn(ctx, "Create price list failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	if err := s.repo.CreatePriceList(ctx, list); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create price list")
+	}
+
+	return &pb.CreatePriceListResponse{
+		PriceList: toProtoPriceList(list),
+	}, nil
+}
+
+// priceListProblem validates a new price list and returns a message
+// describing the first problem, or ""
+func priceListProblem(list *PriceList) string {
+	switch {
+	case list.Name == "":
+		return "name is required"
+	case len(list.Name) > maxNameLength:
+		return "name is too long"
+	case !isCurrencyCode(list.Currency):
+		return "currency must be a three-letter ISO 4217 code"
+	case len(list.CustomerSegment) > maxTermLength:
+		return "customer_segment is too long"
+	case len(list.Channel) > maxTermLength:
+		return "channel is too long"
+	}
+	return windowProblem(list.StartsAt, list.EndsAt)
+}
+
+// GetPriceList returns a price list with its entries
+func (s *Service) GetPriceList(ctx context.Context, req *pb.GetPriceListRequest) (*pb.GetPriceListResponse, error) {
+	if req.Id == "" {
+		s.log.Warn(ctx, "Get price list failed: ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	list, err := s.repo.GetPriceList(ctx, req.Id)
+	if errors.Is(err, ErrPriceListNotFound) {
+		return nil, status.Error(codes.NotFound, "price list not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get price list")
+	}
+
+	return &pb.GetPriceListResponse{
+		PriceList: toProtoPriceList(list),
+	}, nil
+}
+
+// ListPriceLists lists price lists by priority, highest first
+func (s *Service) ListPriceLists(ctx context.Context, req *pb.ListPriceListsRequest) (*pb.ListPriceListsResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	lists, total, err := s.repo.ListPriceLists(ctx, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list price lists")
+	}
+
+	protoLists := make([]*pb.PriceList, len(lists))
+	for i, l := range lists {
+		protoLists[i] = toProtoPriceList(l)
+	}
+
+	return &pb.ListPriceListsResponse{
+		PriceLists: protoLists,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// SetPriceListActive switches a price list on or off
+func (s *Service) SetPriceListActive(ctx context.Context, req *pb.SetPriceListActiveRequest) (*pb.SetPriceListActiveResponse, error) {
+	if req.Id == "" {
+		s.log.Warn(ctx, "Set price list active failed: ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	list, err := s.repo.SetPriceListActive(ctx, req.Id, req.Active, s.now())
+	if errors.Is(err, ErrPriceListNotFound) {
+		return nil, status.Error(codes.NotFound, "price list not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update price list")
+	}
+
+	return &pb.SetPriceListActiveResponse{
+		PriceList: toProtoPriceList(list),
+	}, nil
+}
+
+// SetPriceListEntries replaces a product's prices in a price list
+func (s *Service) SetPriceListEntries(ctx context.Context, req *pb.SetPriceListEntriesRequest) (*pb.SetPriceListEntriesResponse, error) {
+	if msg := entriesProblem(req); msg != "" {
+		s.log.Warn(ctx, "Set price list entries failed: "+msg, map[string]interface{}{"price_list_id": req.PriceListId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	entries := make([]*PriceListEntry, len(req.Entries))
+	for i, e := range req.Entries {
+		entries[i] = &PriceListEntry{
+			ProductID:   req.ProductId,
+			MinQuantity: e.MinQuantity,
+			UnitPrice:   roundCents(e.UnitPrice),
+		}
+	}
+
+	err := s.repo.SetPriceListEntries(ctx, req.PriceListId, req.ProductId, entries, s.now())
+	if errors.Is(err, ErrPriceListNotFound) {
+		return nil, status.Error(codes.NotFound, "price list not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to set price list entries")
+	}
+
+	protoEntries := make([]*pb.PriceListEntry, len(entries))
+	for i, e := range entries {
+		protoEntries[i] = toProtoEntry(e)
+	}
+	return &pb.SetPriceListEntriesResponse{
+		Entries: protoEntries,
+	}, nil
+}
+
+// entriesProblem validates the entries of a product in a price list and
+// returns a message describing the first problem, or ""
+func entriesProblem(req *pb.SetPriceListEntriesRequest) string {
+	switch {
+	case req.PriceListId == "":
+		return "price_list_id is required"
+	case req.ProductId == "":
+		return "product_id is required"
+	case len(req.ProductId) > maxIDLength:
+		return "product_id is too long"
+	case len(req.Entries) > maxEntries:
+		return fmt.Sprintf("a product can have at most %d entries in a price list", maxEntries)
+	}
+	seen := make(map[int32]bool, len(req.Entries))
+	for _, e := range req.Entries {
+		switch {
+		case e.MinQuantity < 1:
+			return "min_quantity must be at least 1"
+		case seen[e.MinQuantity]:
+			return fmt.Sprintf("min_quantity %d appears more than once", e.MinQuantity)
+		case e.UnitPrice < 0 || e.UnitPrice > maxAmount:
+			return "unit_price must be between 0 and 99999999.99"
+		}
+		seen[e.MinQuantity] = true
+	}
+	return ""
+}
+
+// CreatePriceRule creates an active price rule
+func (s *Service) CreatePriceRule(ctx context.Context, req *pb.CreatePriceRuleRequest) (*pb.CreatePriceRuleResponse, error) {
+	now := s.now()
+	rule := &PriceRule{
+		Name:            req.Name,
+		Type:            req.Type,
+		Value:           roundCents(req.Value),
+		ProductID:       req.ProductId,
+		CustomerSegment: req.CustomerSegment,
+		Channel:         req.Channel,
+		Currency:        strings.ToUpper(req.Currency),
+		MinQuantity:     req.MinQuantity,
+		Priority:        req.Priority,
+		Active:          true,
+		StartsAt:        optionalTime(req.StartsAt),
+		EndsAt:          optionalTime(req.EndsAt),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if msg := priceRuleProblem(rule); msg != "" {
+		s.log.Warn(ctx, "Create price rule failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	if err := s.repo.CreatePriceRule(ctx, rule); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create price rule")
+	}
+
+	return &pb.CreatePriceRuleResponse{
+		Rule: toProtoPriceRule(rule),
+	}, nil
+}
+
+// priceRuleProblem validates a new price rule and returns a message
+// describing the first problem, or ""
+func priceRuleProblem(rule *PriceRule) string {
+	switch {
+	case rule.Name == "":
+		return "name is required"
+	case len(rule.Name) > maxNameLength:
+		return "name is too long"
+	case rule.Type != RulePercentOff && rule.Type != RuleAmountOff && rule.Type != RuleFixedPrice:
+		return "type must be PERCENT_OFF, AMOUNT_OFF or FIXED_PRICE"
+	case rule.Type == RulePercentOff && (rule.Value <= 0 || rule.Value > 100):
+		return "value must be a percentage above 0 and at most 100"
+	case rule.Type == RuleAmountOff && (rule.Value <= 0 || rule.Value > maxAmount):
+		return "value must be an amount above 0 and at most 99999999.99"
+	case rule.Type == RuleFixedPrice && (rule.Value < 0 || rule.Value > maxAmount):
+		return "value must be an amount between 0 and 99999999.99"
+	case rule.Type != RulePercentOff && rule.Currency == "":
+		return "currency is required for AMOUNT_OFF and FIXED_PRICE rules"
+	case rule.Currency != "" && !isCurrencyCode(rule.Currency):
+		return "currency must be a three-letter ISO 4217 code"
+	case len(rule.ProductID) > maxIDLength:
+		return "product_id is too long"
+	case len(rule.CustomerSegment) > maxTermLength:
+		return "customer_segment is too long"
+	case len(rule.Channel) > maxTermLength:
+		return "channel is too long"
+	case rule.MinQuantity < 0:
+		return "min_quantity cannot be negative"
+	}
+	return windowProblem(rule.StartsAt, rule.EndsAt)
+}
+
+// ListPriceRules lists price rules by priority, highest first
+func (s *Service) ListPriceRules(ctx context.Context, req *pb.ListPriceRulesRequest) (*pb.ListPriceRulesResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	rules, total, err := s.repo.ListPriceRules(ctx, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list price rules")
+	}
+
+	protoRules := make([]*pb.PriceRule, len(rules))
+	for i, r := range rules {
+		protoRules[i] = toProtoPriceRule(r)
+	}
+
+	return &pb.ListPriceRulesResponse{
+		Rules:    protoRules,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// SetPriceRuleActive switches a price rule on or off
+func (s *Service) SetPriceRuleActive(ctx context.Context, req *pb.SetPriceRuleActiveRequest) (*pb.SetPriceRuleActiveResponse, error) {
+	if req.Id == "" {
+		s.log.Warn(ctx, "Set price rule active failed: ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	rule, err := s.repo.SetPriceRuleActive(ctx, req.Id, req.Active, s.now())
+	if errors.Is(err, ErrPriceRuleNotFound) {
+		return nil, status.Error(codes.NotFound, "price rule not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update price rule")
+	}
+
+	return &pb.SetPriceRuleActiveResponse{
+		Rule: toProtoPriceRule(rule),
+	}, nil
+}
+
+// windowProblem validates an optional validity window and returns a message
+// describing the problem, or ""
+func windowProblem(startsAt, endsAt *time.Time) string {
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return "ends_at must be after starts_at"
+	}
+	return ""
+}
+
+// isCurrencyCode reports whether code is three upper-case letters
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// optionalTime converts an optional protobuf timestamp
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// toProtoPrice converts a quote for terms to protobuf
+func toProtoPrice(terms Terms, q *Quote) *pb.ResolvedPrice {
+	return &pb.ResolvedPrice{
+		ProductId:     terms.ProductID,
+		Quantity:      terms.Quantity,
+		Currency:      terms.Currency,
+		BaseUnitPrice: q.BaseUnitPrice,
+		UnitPrice:     q.UnitPrice,
+		LineTotal:     q.LineTotal,
+		Source:        q.Source,
+		PriceListId:   q.PriceListID,
+		RuleId:        q.RuleID,
+	}
+}
+
+// toProtoPriceList converts a price list to protobuf
+func toProtoPriceList(l *PriceList) *pb.PriceList {
+	list := &pb.PriceList{
+		Id:              l.ID,
+		Name:            l.Name,
+		Currency:        l.Currency,
+		CustomerSegment: l.CustomerSegment,
+		Channel:         l.Channel,
+		Priority:        l.Priority,
+		Active:          l.Active,
+		CreatedAt:       timestamppb.New(l.CreatedAt),
+		UpdatedAt:       timestamppb.New(l.UpdatedAt),
+	}
+	if l.StartsAt != nil {
+		list.StartsAt = timestamppb.New(*l.StartsAt)
+	}
+	if l.EndsAt != nil {
+		list.EndsAt = timestamppb.New(*l.EndsAt)
+	}
+	for _, e := range l.Entries {
+		list.Entries = append(list.Entries, toProtoEntry(e))
+	}
+	return list
+}
+
+// toProtoEntry converts a price list entry to protobuf
+func toProtoEntry(e *PriceListEntry) *pb.PriceListEntry {
+	return &pb.PriceListEntry{
+		ProductId:   e.ProductID,
+		MinQuantity: e.MinQuantity,
+		UnitPrice:   e.UnitPrice,
+	}
+}
+
+// toProtoPriceRule converts a price rule to protobuf
+func toProtoPriceRule(r *PriceRule) *pb.PriceRule {
+	rule := &pb.PriceRule{
+		Id:              r.ID,
+		Name:            r.Name,
+		Type:            r.Type,
+		Value:           r.Value,
+		ProductId:       r.ProductID,
+		CustomerSegment: r.CustomerSegment,
+		Channel:         r.Channel,
+		Currency:        r.Currency,
+		MinQuantity:     r.MinQuantity,
+		Priority:        r.Priority,
+		Active:          r.Active,
+		CreatedAt:       timestamppb.New(r.CreatedAt),
+		UpdatedAt:       timestamppb.New(r.UpdatedAt),
+	}
+	if r.StartsAt != nil {
+		rule.StartsAt = timestamppb.New(*r.StartsAt)
+	}
+	if r.EndsAt != nil {
+		rule.EndsAt = timestamppb.New(*r.EndsAt)
+	}
+	return rule
+}
@@ -0,0 +1,530 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pricing/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps price lists, their entries and price rules, and
+// offers only the active ones in their window
+type memoryRepository struct {
+	mu      sync.Mutex
+	lists   []*PriceList
+	entries map[string][]*PriceListEntry // by price list ID
+	rules   []*PriceRule
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{entries: map[string][]*PriceListEntry{}}
+}
+
+func (m *memoryRepository) findList(id string) *PriceList {
+	for _, l := range m.lists {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) CreatePriceList(ctx context.Context, list *PriceList) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list.ID = uuid.New().String()
+	stored := *list
+	m.lists = append(m.lists, &stored)
+	return nil
+}
+
+func (m *memoryRepository) GetPriceList(ctx context.Context, id string) (*PriceList, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l := m.findList(id)
+	if l == nil {
+		return nil, ErrPriceListNotFound
+	}
+	list := *l
+	list.Entries = append([]*PriceListEntry{}, m.entries[id]...)
+	return &list, nil
+}
+
+func (m *memoryRepository) ListPriceLists(ctx context.Context, page, pageSize int32) ([]*PriceList, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lists := make([]*PriceList, len(m.lists))
+	for i, l := range m.lists {
+		list := *l
+		lists[i] = &list
+	}
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Priority > lists[j].Priority })
+	return lists, int32(len(lists)), nil
+}
+
+func (m *memoryRepository) SetPriceListActive(ctx context.Context, id string, active bool, at time.Time) (*PriceList, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l := m.findList(id)
+	if l == nil {
+		return nil, ErrPriceListNotFound
+	}
+	l.Active = active
+	l.UpdatedAt = at
+	list := *l
+	return &list, nil
+}
+
+func (m *memoryRepository) SetPriceListEntries(ctx context.Context, listID, productID string, entries []*PriceListEntry, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.findList(listID) == nil {
+		return ErrPriceListNotFound
+	}
+	var kept []*PriceListEntry
+	for _, e := range m.entries[listID] {
+		if e.ProductID != productID {
+			kept = append(kept, e)
+		}
+	}
+	m.entries[listID] = append(kept, entries...)
+	return nil
+}
+
+func (m *memoryRepository) CreatePriceRule(ctx context.Context, rule *PriceRule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rule.ID = uuid.New().String()
+	stored := *rule
+	m.rules = append(m.rules, &stored)
+	return nil
+}
+
+func (m *memoryRepository) ListPriceRules(ctx context.Context, page, pageSize int32) ([]*PriceRule, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rules := make([]*PriceRule, len(m.rules))
+	for i, r := range m.rules {
+		rule := *r
+		rules[i] = &rule
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+	return rules, int32(len(rules)), nil
+}
+
+func (m *memoryRepository) SetPriceRuleActive(ctx context.Context, id string, active bool, at time.Time) (*PriceRule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.rules {
+		if r.ID == id {
+			r.Active = active
+			r.UpdatedAt = at
+			rule := *r
+			return &rule, nil
+		}
+	}
+	return nil, ErrPriceRuleNotFound
+}
+
+// inWindow reports whether an active list or rule applies at a time
+func inWindow(active bool, startsAt, endsAt *time.Time, at time.Time) bool {
+	return active && (startsAt == nil || !at.Before(*startsAt)) && (endsAt == nil || at.Before(*endsAt))
+}
+
+func (m *memoryRepository) Offers(ctx context.Context, productID, currency string, at time.Time) ([]*Offer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var offers []*Offer
+	for _, l := range m.lists {
+		if l.Currency != currency || !inWindow(l.Active, l.StartsAt, l.EndsAt, at) {
+			continue
+		}
+		for _, e := range m.entries[l.ID] {
+			if e.ProductID == productID {
+				offers = append(offers, &Offer{
+					PriceListID:     l.ID,
+					CustomerSegment: l.CustomerSegment,
+					Channel:         l.Channel,
+					Priority:        l.Priority,
+					MinQuantity:     e.MinQuantity,
+					UnitPrice:       e.UnitPrice,
+				})
+			}
+		}
+	}
+	return offers, nil
+}
+
+func (m *memoryRepository) Rules(ctx context.Context, productID string, at time.Time) ([]*PriceRule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var rules []*PriceRule
+	for _, r := range m.rules {
+		if (r.ProductID == "" || r.ProductID == productID) && inWindow(r.Active, r.StartsAt, r.EndsAt, at) {
+			rule := *r
+			rules = append(rules, &rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeCatalog prices products at fixed catalog prices
+type fakeCatalog struct {
+	prices map[string]float64
+	err    error
+}
+
+func (c *fakeCatalog) UnitPrice(ctx context.Context, productID string, quantity int32) (float64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	price, ok := c.prices[productID]
+	if !ok {
+		return 0, ErrProductNotFound
+	}
+	if quantity > 100 {
+		return 0, fmt.Errorf("%w: quantity must be at most 100", ErrInvalidQuantity)
+	}
+	return price, nil
+}
+
+// fakeConverter converts from USD at fixed rates
+type fakeConverter struct {
+	rates map[string]float64
+	err   error
+}
+
+func (c *fakeConverter) Convert(ctx context.Context, amount float64, from, to string) (float64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	rate, ok := c.rates[to]
+	if from != "USD" || !ok {
+		return 0, fmt.Errorf("%w: unknown currency %s", ErrNoRate, to)
+	}
+	return roundCents(amount * rate), nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	catalog *fakeCatalog
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo:    newMemoryRepository(),
+		catalog: &fakeCatalog{prices: map[string]float64{"prod-1": 10, "prod-2": 4.5}},
+		now:     time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(f.repo, f.catalog, NewNoConverter(), "USD", logger.New("pricing-test"))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+// priceList creates a price list with entries for prod-1
+func (f *serviceFixture) priceList(t *testing.T, req *pb.CreatePriceListRequest, entries ...*pb.PriceListEntry) *pb.PriceList {
+	t.Helper()
+	resp, err := f.service.CreatePriceList(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = f.service.SetPriceListEntries(context.Background(), &pb.SetPriceListEntriesRequest{
+		PriceListId: resp.PriceList.Id,
+		ProductId:   "prod-1",
+		Entries:     entries,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.PriceList
+}
+
+func (f *serviceFixture) rule(t *testing.T, req *pb.CreatePriceRuleRequest) *pb.PriceRule {
+	t.Helper()
+	resp, err := f.service.CreatePriceRule(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Rule
+}
+
+func (f *serviceFixture) resolve(t *testing.T, req *pb.ResolvePriceRequest) *pb.ResolvedPrice {
+	t.Helper()
+	resp, err := f.service.ResolvePrice(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Price
+}
+
+func TestResolvePrice_Catalog(t *testing.T) {
+	f := setupService()
+
+	price := f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 3})
+	if price.Source != SourceCatalog || price.Currency != "USD" || price.UnitPrice != 10 || price.LineTotal != 30 {
+		t.Errorf("Expected the catalog price, got %v", price)
+	}
+}
+
+func TestResolvePrice_PriceListsAndRules(t *testing.T) {
+	f := setupService()
+	general := f.priceList(t, &pb.CreatePriceListRequest{Name: "Euro", Currency: "eur"},
+		&pb.PriceListEntry{MinQuantity: 1, UnitPrice: 9},
+		&pb.PriceListEntry{MinQuantity: 10, UnitPrice: 8})
+	wholesale := f.priceList(t, &pb.CreatePriceListRequest{Name: "Wholesale", Currency: "EUR", CustomerSegment: "wholesale", Priority: 1},
+		&pb.PriceListEntry{MinQuantity: 1, UnitPrice: 7})
+	rule := f.rule(t, &pb.CreatePriceRuleRequest{Name: "App launch", Type: RulePercentOff, Value: 10, Channel: "app"})
+
+	price := f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 10, Currency: "eur"})
+	if price.PriceListId != general.Id || price.UnitPrice != 8 || price.LineTotal != 80 || price.Currency != "EUR" {
+		t.Errorf("Expected the general quantity break, got %v", price)
+	}
+
+	price = f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 10, Currency: "EUR", CustomerSegment: "wholesale", Channel: "app"})
+	if price.PriceListId != wholesale.Id || price.BaseUnitPrice != 7 || price.RuleId != rule.Id || price.UnitPrice != 6.3 || price.LineTotal != 63 {
+		t.Errorf("Expected the wholesale price less 10%%, got %v", price)
+	}
+
+	// A list switched off no longer prices
+	if _, err := f.service.SetPriceListActive(context.Background(), &pb.SetPriceListActiveRequest{Id: wholesale.Id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	price = f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1, Currency: "EUR", CustomerSegment: "wholesale"})
+	if price.PriceListId != general.Id {
+		t.Errorf("Expected the general list, got %v", price)
+	}
+}
+
+func TestResolvePrice_Window(t *testing.T) {
+	f := setupService()
+	starts := f.now.Add(time.Hour)
+	f.priceList(t, &pb.CreatePriceListRequest{Name: "Summer", Currency: "USD", StartsAt: timestamppb.New(starts)},
+		&pb.PriceListEntry{MinQuantity: 1, UnitPrice: 5})
+
+	if price := f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1}); price.Source != SourceCatalog {
+		t.Errorf("Expected the catalog price before the list starts, got %v", price)
+	}
+	f.now = starts
+	if price := f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1}); price.UnitPrice != 5 {
+		t.Errorf("Expected the list price once it starts, got %v", price)
+	}
+}
+
+func TestResolvePrice_ConvertedCatalog(t *testing.T) {
+	f := setupService()
+	f.service.converter = &fakeConverter{rates: map[string]float64{"EUR": 0.9}}
+	rule := f.rule(t, &pb.CreatePriceRuleRequest{Name: "Euro launch", Type: RuleAmountOff, Value: 1, Currency: "EUR"})
+
+	price := f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 2, Currency: "EUR"})
+	if price.Source != SourceConvertedCatalog || price.BaseUnitPrice != 9 || price.RuleId != rule.Id || price.UnitPrice != 8 || price.LineTotal != 16 {
+		t.Errorf("Expected the converted catalog price less 1, got %v", price)
+	}
+
+	// A price list price wins over the converted catalog price
+	list := f.priceList(t, &pb.CreatePriceListRequest{Name: "Euro", Currency: "EUR"}, &pb.PriceListEntry{MinQuantity: 1, UnitPrice: 9.5})
+	if price := f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1, Currency: "EUR"}); price.PriceListId != list.Id {
+		t.Errorf("Expected the price list price, got %v", price)
+	}
+
+	_, err := f.service.ResolvePrice(context.Background(), &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1, Currency: "JPY"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without a rate, got %v", err)
+	}
+
+	f.service.converter = &fakeConverter{err: errors.New("connection refused")}
+	_, err = f.service.ResolvePrice(context.Background(), &pb.ResolvePriceRequest{ProductId: "prod-2", Quantity: 1, Currency: "EUR"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
+
+func TestResolvePrice_Errors(t *testing.T) {
+	f := setupService()
+
+	tests := []struct {
+		name string
+		req  *pb.ResolvePriceRequest
+		code codes.Code
+	}{
+		{"missing product", &pb.ResolvePriceRequest{Quantity: 1}, codes.InvalidArgument},
+		{"zero quantity", &pb.ResolvePriceRequest{ProductId: "prod-1"}, codes.InvalidArgument},
+		{"invalid currency", &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1, Currency: "EURO"}, codes.InvalidArgument},
+		{"unknown product", &pb.ResolvePriceRequest{ProductId: "missing", Quantity: 1}, codes.NotFound},
+		{"quantity rules", &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 101}, codes.InvalidArgument},
+		{"no price in currency", &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1, Currency: "EUR"}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.ResolvePrice(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestResolvePrice_CatalogUnavailable(t *testing.T) {
+	f := setupService()
+	f.catalog.err = fmt.Errorf("connection refused")
+
+	_, err := f.service.ResolvePrice(context.Background(), &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
+
+func TestResolvePrices(t *testing.T) {
+	f := setupService()
+	f.rule(t, &pb.CreatePriceRuleRequest{Name: "Bulk", Type: RuleAmountOff, Value: 1, Currency: "USD", MinQuantity: 2})
+
+	resp, err := f.service.ResolvePrices(context.Background(), &pb.ResolvePricesRequest{
+		Lines: []*pb.PriceLine{{ProductId: "prod-1", Quantity: 2}, {ProductId: "prod-2", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Prices) != 2 || resp.Prices[0].UnitPrice != 9 || resp.Prices[1].UnitPrice != 4.5 || resp.Subtotal != 22.5 || resp.Currency != "USD" {
+		t.Errorf("Unexpected prices %v", resp)
+	}
+
+	_, err = f.service.ResolvePrices(context.Background(), &pb.ResolvePricesRequest{
+		Lines: []*pb.PriceLine{{ProductId: "prod-1", Quantity: 1}, {ProductId: "prod-1", Quantity: 2}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a repeated product, got %v", err)
+	}
+}
+
+func TestSetPriceListEntriesRPC(t *testing.T) {
+	f := setupService()
+	list := f.priceList(t, &pb.CreatePriceListRequest{Name: "Euro", Currency: "EUR"},
+		&pb.PriceListEntry{MinQuantity: 1, UnitPrice: 9.999})
+
+	got, err := f.service.GetPriceList(context.Background(), &pb.GetPriceListRequest{Id: list.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got.PriceList.Entries) != 1 || got.PriceList.Entries[0].UnitPrice != 10 || got.PriceList.Entries[0].ProductId != "prod-1" {
+		t.Errorf("Unexpected entries %v", got.PriceList.Entries)
+	}
+
+	// Empty entries remove the product from the list
+	_, err = f.service.SetPriceListEntries(context.Background(), &pb.SetPriceListEntriesRequest{PriceListId: list.Id, ProductId: "prod-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, _ = f.service.GetPriceList(context.Background(), &pb.GetPriceListRequest{Id: list.Id})
+	if len(got.PriceList.Entries) != 0 {
+		t.Errorf("Expected no entries, got %v", got.PriceList.Entries)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.SetPriceListEntriesRequest
+		code codes.Code
+	}{
+		{"missing product", &pb.SetPriceListEntriesRequest{PriceListId: list.Id}, codes.InvalidArgument},
+		{"zero min quantity", &pb.SetPriceListEntriesRequest{PriceListId: list.Id, ProductId: "prod-1",
+			Entries: []*pb.PriceListEntry{{UnitPrice: 1}}}, codes.InvalidArgument},
+		{"repeated min quantity", &pb.SetPriceListEntriesRequest{PriceListId: list.Id, ProductId: "prod-1",
+			Entries: []*pb.PriceListEntry{{MinQuantity: 1, UnitPrice: 1}, {MinQuantity: 1, UnitPrice: 2}}}, codes.InvalidArgument},
+		{"negative price", &pb.SetPriceListEntriesRequest{PriceListId: list.Id, ProductId: "prod-1",
+			Entries: []*pb.PriceListEntry{{MinQuantity: 1, UnitPrice: -1}}}, codes.InvalidArgument},
+		{"unknown list", &pb.SetPriceListEntriesRequest{PriceListId: "missing", ProductId: "prod-1"}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.SetPriceListEntries(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestCreatePriceList_InvalidInput(t *testing.T) {
+	f := setupService()
+	starts := timestamppb.New(f.now)
+
+	tests := []struct {
+		name string
+		req  *pb.CreatePriceListRequest
+	}{
+		{"missing name", &pb.CreatePriceListRequest{Currency: "EUR"}},
+		{"missing currency", &pb.CreatePriceListRequest{Name: "Euro"}},
+		{"ends before it starts", &pb.CreatePriceListRequest{Name: "Euro", Currency: "EUR", StartsAt: starts, EndsAt: starts}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreatePriceList(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreatePriceRule_InvalidInput(t *testing.T) {
+	f := setupService()
+
+	tests := []struct {
+		name string
+		req  *pb.CreatePriceRuleRequest
+	}{
+		{"missing name", &pb.CreatePriceRuleRequest{Type: RulePercentOff, Value: 10}},
+		{"unknown type", &pb.CreatePriceRuleRequest{Name: "r", Type: "BOGO", Value: 10}},
+		{"percent above 100", &pb.CreatePriceRuleRequest{Name: "r", Type: RulePercentOff, Value: 120}},
+		{"amount without currency", &pb.CreatePriceRuleRequest{Name: "r", Type: RuleAmountOff, Value: 5}},
+		{"zero amount off", &pb.CreatePriceRuleRequest{Name: "r", Type: RuleAmountOff, Currency: "USD"}},
+		{"negative min quantity", &pb.CreatePriceRuleRequest{Name: "r", Type: RulePercentOff, Value: 10, MinQuantity: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreatePriceRule(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetPriceRuleActive(t *testing.T) {
+	f := setupService()
+	rule := f.rule(t, &pb.CreatePriceRuleRequest{Name: "Sitewide", Type: RulePercentOff, Value: 50})
+
+	resp, err := f.service.SetPriceRuleActive(context.Background(), &pb.SetPriceRuleActiveRequest{Id: rule.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Rule.Active {
+		t.Errorf("Expected the rule switched off, got %v", resp.Rule)
+	}
+	if price := f.resolve(t, &pb.ResolvePriceRequest{ProductId: "prod-1", Quantity: 1}); price.RuleId != "" || price.UnitPrice != 10 {
+		t.Errorf("Expected no rule applied, got %v", price)
+	}
+
+	_, err = f.service.SetPriceRuleActive(context.Background(), &pb.SetPriceRuleActiveRequest{Id: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package privacy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature is returned when a certificate does not match its signature
+	ErrInvalidSignature = errors.New("privacy: invalid certificate signature")
+	// ErrUnsigned is returned when verifying a certificate issued without a key
+	ErrUnsigned = errors.New("privacy: certificate is not signed")
+)
+
+// Certificate attests that a customer's data was erased from every system.
+// It names the customer only by Subject, so it can be kept and handed to the
+// customer or a regulator after the erasure.
+type Certificate struct {
+	ErasureID   string         `json:"erasure_id"`
+	Subject     string         `json:"subject"`
+	TenantID    string         `json:"tenant_id"`
+	RequestedAt time.Time      `json:"requested_at"`
+	CompletedAt time.Time      `json:"completed_at"`
+	Systems     []ErasedSystem `json:"systems"`
+	// Signature is the HMAC-SHA256 of the certificate encoded with an empty
+	// signature
+	Signature string `json:"signature,omitempty"`
+}
+
+// ErasedSystem is what was erased in one system
+type ErasedSystem struct {
+	System      string    `json:"system"`
+	Action      string    `json:"action"`
+	Records     int32     `json:"records"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// Subject identifies a customer on certificates without naming them: the hex
+// SHA-256 of "<tenant ID>/<user ID>". Whoever knows the customer can check it.
+func Subject(tenantID, userID string) string {
+	sum := sha256.Sum256([]byte(tenantID + "/" + userID))
+	return hex.EncodeToString(sum[:])
+}
+
+// newCertificate describes a completed erasure, its tasks in system order
+func newCertificate(e *Erasure, completedAt time.Time) Certificate {
+	c := Certificate{
+		ErasureID:   e.ID,
+		Subject:     Subject(e.TenantID, e.UserID),
+		TenantID:    e.TenantID,
+		RequestedAt: e.RequestedAt.UTC(),
+		CompletedAt: completedAt.UTC(),
+		Systems:     make([]ErasedSystem, len(e.Tasks)),
+	}
+	for i, t := range e.Tasks {
+		c.Systems[i] = ErasedSystem{System: t.System, Action: t.Action, Records: t.Records}
+		if t.CompletedAt != nil {
+			c.Systems[i].CompletedAt = t.CompletedAt.UTC()
+		}
+	}
+	return c
+}
+
+// sign returns the signature of c, or "" without a key
+func (c Certificate) sign(key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+	c.Signature = ""
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifyCertificate decodes a certificate and checks its signature
+func VerifyCertificate(data []byte, key []byte) (*Certificate, error) {
+	var c Certificate
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("privacy: decode certificate: %w", err)
+	}
+	if c.Signature == "" {
+		return nil, ErrUnsigned
+	}
+
+	expected, err := c.sign(key)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(c.Signature), []byte(expected)) {
+		return nil, ErrInvalidSignature
+	}
+	return &c, nil
+}
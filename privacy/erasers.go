@@ -0,0 +1,115 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+
+	cartpb "github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	recommendationpb "github.com/Ujjwaljain16/E-commerce-Backend/recommendation/pb"
+	reviewpb "github.com/Ujjwaljain16/E-commerce-Backend/review/pb"
+)
+
+// Systems holding customers' personal data
+const (
+	SystemOrders    = "orders"
+	SystemReviews   = "reviews"
+	SystemCarts     = "carts"
+	SystemAnalytics = "analytics"
+)
+
+// Eraser erases a customer's data in one system. Erasing a customer again
+// must succeed and change nothing, since a failed task is retried and
+// replicas may erase the same customer at once.
+type Eraser interface {
+	// System names the system on erasure tasks and certificates
+	System() string
+	// Action is ActionAnonymized for systems keeping the customer's records
+	// under the pseudonym, or ActionDeleted
+	Action() string
+	// Erase erases the customer's data in the context tenant and returns the
+	// number of records erased
+	Erase(ctx context.Context, userID, pseudonym string) (int32, error)
+}
+
+// grpcEraser is an Eraser calling a service's EraseUserData
+type grpcEraser struct {
+	system string
+	action string
+	erase  func(ctx context.Context, userID, pseudonym string) (int32, error)
+}
+
+func (e *grpcEraser) System() string { return e.system }
+
+func (e *grpcEraser) Action() string { return e.action }
+
+func (e *grpcEraser) Erase(ctx context.Context, userID, pseudonym string) (int32, error) {
+	records, err := e.erase(ctx, userID, pseudonym)
+	if err != nil {
+		return 0, fmt.Errorf("failed to erase %s: %w", e.system, err)
+	}
+	return records, nil
+}
+
+// NewOrderEraser creates an Eraser anonymizing the customer's orders, which
+// are kept for accounting
+func NewOrderEraser(client orderpb.OrderServiceClient) Eraser {
+	return &grpcEraser{
+		system: SystemOrders,
+		action: ActionAnonymized,
+		erase: func(ctx context.Context, userID, pseudonym string) (int32, error) {
+			resp, err := client.EraseUserData(ctx, &orderpb.EraseUserDataRequest{UserId: userID, Pseudonym: pseudonym})
+			if err != nil {
+				return 0, err
+			}
+			return resp.Records, nil
+		},
+	}
+}
+
+// NewReviewEraser creates an Eraser anonymizing the customer's reviews, whose
+// ratings are kept
+func NewReviewEraser(client reviewpb.ReviewServiceClient) Eraser {
+	return &grpcEraser{
+		system: SystemReviews,
+		action: ActionAnonymized,
+		erase: func(ctx context.Context, userID, pseudonym string) (int32, error) {
+			resp, err := client.EraseUserData(ctx, &reviewpb.EraseUserDataRequest{UserId: userID, Pseudonym: pseudonym})
+			if err != nil {
+				return 0, err
+			}
+			return resp.Records, nil
+		},
+	}
+}
+
+// NewCartEraser creates an Eraser deleting the customer's cart
+func NewCartEraser(client cartpb.CartServiceClient) Eraser {
+	return &grpcEraser{
+		system: SystemCarts,
+		action: ActionDeleted,
+		erase: func(ctx context.Context, userID, _ string) (int32, error) {
+			resp, err := client.EraseUserData(ctx, &cartpb.EraseUserDataRequest{UserId: userID})
+			if err != nil {
+				return 0, err
+			}
+			return resp.Records, nil
+		},
+	}
+}
+
+// NewAnalyticsEraser creates an Eraser deleting the customer's product views
+// and purchase history from the recommendation service
+func NewAnalyticsEraser(client recommendationpb.RecommendationServiceClient) Eraser {
+	return &grpcEraser{
+		system: SystemAnalytics,
+		action: ActionDeleted,
+		erase: func(ctx context.Context, userID, _ string) (int32, error) {
+			resp, err := client.EraseUserData(ctx, &recommendationpb.EraseUserDataRequest{UserId: userID})
+			if err != nil {
+				return 0, err
+			}
+			return resp.Records, nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+)
+
+// AccountEventsTopic is the Kafka topic the account service publishes its events to
+const AccountEventsTopic = "account-events"
+
+// EventAccountDeleted requests the erasure of the deleted customer's data
+const EventAccountDeleted = "account.deleted"
+
+// outboxEnvelope is the JSON envelope services publish their outbox events in
+type outboxEnvelope struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// accountDeleted is the part of an account.deleted event's data the erasure
+// needs
+type accountDeleted struct {
+	AccountID string `json:"account_id"`
+	TenantID  string `json:"tenant_id"`
+}
+
+// EventHandler requests the erasure of customers whose accounts are deleted,
+// consumed from Kafka. Other account events are ignored. An event delivered
+// again finds the customer's erasure already requested.
+func EventHandler(o *Orchestrator) kafka.Handler {
+	return func(ctx context.Context, msg kafka.Message) error {
+		var envelope outboxEnvelope
+		if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+			return fmt.Errorf("failed to decode account event: %w", err)
+		}
+		if envelope.Type != EventAccountDeleted {
+			return nil
+		}
+
+		var data accountDeleted
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode account event data: %w", err)
+		}
+		if data.AccountID == "" {
+			return fmt.Errorf("account event %s has no account", envelope.ID)
+		}
+		tenantID := tenant.OrDefault(data.TenantID)
+		if err := tenant.Validate(tenantID); err != nil {
+			return fmt.Errorf("account event %s: %w", envelope.ID, err)
+		}
+
+		_, err := o.Request(tenant.NewContext(ctx, tenantID), data.AccountID)
+		return err
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS erasure_tasks;
+DROP INDEX IF EXISTS idx_erasures_pending;
+DROP INDEX IF EXISTS idx_erasures_tenant_requested_at;
+DROP TABLE IF EXISTS erasures;
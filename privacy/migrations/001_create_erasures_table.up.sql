@@ -0,0 +1,33 @@
+-- Erasures of deleted customers' data. A customer has at most one erasure
+-- per tenant, so an account deletion delivered twice is erased once. The
+-- certificate is the signed deletion certificate JSON, set when the erasure
+-- completes.
+CREATE TABLE IF NOT EXISTS erasures (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(63) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'COMPLETED')),
+    requested_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    certificate TEXT,
+    UNIQUE (tenant_id, user_id),
+    CHECK ((status = 'COMPLETED') = (completed_at IS NOT NULL AND certificate IS NOT NULL))
+);
+
+CREATE INDEX idx_erasures_tenant_requested_at ON erasures(tenant_id, requested_at DESC);
+CREATE INDEX idx_erasures_pending ON erasures(requested_at) WHERE status = 'PENDING';
+
+-- The erasure of an erasure's data in each system. Failed tasks stay PENDING
+-- and are retried.
+CREATE TABLE IF NOT EXISTS erasure_tasks (
+    erasure_id UUID NOT NULL REFERENCES erasures(id) ON DELETE CASCADE,
+    system VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('anonymized', 'deleted')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'DONE')),
+    records INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP,
+    PRIMARY KEY (erasure_id, system),
+    CHECK ((status = 'DONE') = (completed_at IS NOT NULL))
+);
@@ -0,0 +1,216 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+)
+
+const (
+	// DefaultInterval is how often pending erasures are retried
+	DefaultInterval = time.Minute
+	// eraseTimeout bounds one system's erasure of a customer
+	eraseTimeout = 30 * time.Second
+	// pendingBatch is how many pending erasures are processed at a time
+	pendingBatch = 50
+)
+
+// Orchestrator erases customers' data across the systems holding it. Each
+// erasure has a task per system; the tasks of an erasure run concurrently
+// and failed tasks are retried every interval until they succeed. An erasure
+// completes with a signed deletion certificate once every task is done.
+type Orchestrator struct {
+	repo    Repository
+	erasers []Eraser
+	key     []byte
+	log     *logger.Logger
+	now     func() time.Time
+	// wake tells the Run loop a new erasure was requested
+	wake chan struct{}
+}
+
+// NewOrchestrator creates an orchestrator erasing through erasers and
+// signing certificates with key. Certificates are not signed without a key.
+func NewOrchestrator(repo Repository, key []byte, log *logger.Logger, erasers ...Eraser) *Orchestrator {
+	return &Orchestrator{
+		repo:    repo,
+		erasers: erasers,
+		key:     key,
+		log:     log,
+		now:     time.Now,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Request records the erasure of a customer in the context tenant, to be
+// carried out by the Run loop, and returns it. A customer already erased, or
+// being erased, keeps their erasure.
+func (o *Orchestrator) Request(ctx context.Context, userID string) (*Erasure, error) {
+	e := &Erasure{
+		TenantID:    tenant.FromContext(ctx),
+		UserID:      userID,
+		Status:      StatusPending,
+		Tasks:       make([]*Task, len(o.erasers)),
+		RequestedAt: o.now(),
+	}
+	for i, eraser := range o.erasers {
+		e.Tasks[i] = &Task{System: eraser.System(), Action: eraser.Action(), Status: TaskPending}
+	}
+
+	e, created, err := o.repo.Create(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		select {
+		case o.wake <- struct{}{}:
+		default:
+		}
+	}
+	return e, nil
+}
+
+// Run processes pending erasures every interval, and whenever one is
+// requested, until ctx is cancelled
+func (o *Orchestrator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := o.ProcessPending(ctx); err != nil && ctx.Err() == nil {
+			o.log.Error(ctx, "Failed to process erasures", map[string]interface{}{"error": err.Error()})
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessPending attempts the pending tasks of the oldest pending erasures
+// and returns how many erasures completed
+func (o *Orchestrator) ProcessPending(ctx context.Context) (int, error) {
+	erasures, err := o.repo.ListPending(ctx, pendingBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, e := range erasures {
+		if ctx.Err() != nil {
+			break
+		}
+		done, err := o.Process(tenant.NewContext(ctx, e.TenantID), e)
+		if err != nil {
+			o.log.Error(ctx, "Failed to process erasure", map[string]interface{}{"error": err.Error(), "erasure_id": e.ID})
+			continue
+		}
+		if done {
+			completed++
+		}
+	}
+	return completed, nil
+}
+
+// Process attempts an erasure's pending tasks concurrently, records their
+// outcomes and, once every task is done, completes the erasure with its
+// certificate. It reports whether the erasure completed; a failed task is
+// recorded and retried later, not returned.
+func (o *Orchestrator) Process(ctx context.Context, e *Erasure) (bool, error) {
+	var wg sync.WaitGroup
+	errs := make([]error, len(e.Tasks))
+	for i, t := range e.Tasks {
+		if t.Status == TaskDone {
+			continue
+		}
+		eraser := o.eraser(t.System)
+		if eraser == nil {
+			// A system no longer erased through this service; the task
+			// stays pending and keeps the erasure from completing
+			o.log.Warn(ctx, "No eraser for erasure task", map[string]interface{}{"erasure_id": e.ID, "system": t.System})
+			continue
+		}
+		wg.Add(1)
+		go func(i int, t *Task) {
+			defer wg.Done()
+			errs[i] = o.attempt(ctx, e, t, eraser)
+		}(i, t)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, t := range e.Tasks {
+		if t.Status != TaskDone {
+			return false, nil
+		}
+	}
+
+	completedAt := o.now()
+	certificate, err := o.certify(e, completedAt)
+	if err != nil {
+		return false, err
+	}
+	if err := o.repo.Complete(ctx, e.ID, completedAt, certificate); err != nil {
+		return false, err
+	}
+	e.Status, e.CompletedAt, e.Certificate = StatusCompleted, &completedAt, certificate
+	return true, nil
+}
+
+// attempt erases the customer in one system and records the outcome. Only
+// failing to record it is returned.
+func (o *Orchestrator) attempt(ctx context.Context, e *Erasure, t *Task, eraser Eraser) error {
+	eraseCtx, cancel := context.WithTimeout(ctx, eraseTimeout)
+	records, err := eraser.Erase(eraseCtx, e.UserID, e.Pseudonym())
+	cancel()
+
+	t.Attempts++
+	if err != nil {
+		t.LastError = err.Error()
+		o.log.Warn(ctx, "Erasure task failed", map[string]interface{}{
+			"error":      err.Error(),
+			"erasure_id": e.ID,
+			"system":     t.System,
+			"attempts":   t.Attempts,
+		})
+	} else {
+		completedAt := o.now()
+		t.Status, t.Records, t.LastError, t.CompletedAt = TaskDone, records, "", &completedAt
+	}
+	return o.repo.UpdateTask(context.WithoutCancel(ctx), e.ID, t)
+}
+
+func (o *Orchestrator) eraser(system string) Eraser {
+	for _, eraser := range o.erasers {
+		if eraser.System() == system {
+			return eraser
+		}
+	}
+	return nil
+}
+
+// certify returns the signed deletion certificate of an erasure as JSON
+func (o *Orchestrator) certify(e *Erasure, completedAt time.Time) (string, error) {
+	c := newCertificate(e, completedAt)
+	signature, err := c.sign(o.key)
+	if err != nil {
+		return "", err
+	}
+	c.Signature = signature
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
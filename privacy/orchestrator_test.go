@@ -0,0 +1,137 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+)
+
+func TestProcessPending(t *testing.T) {
+	_, orchestrator, repo, fakes := setupService()
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	e, err := orchestrator.Request(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	completed, err := orchestrator.ProcessPending(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if completed != 1 {
+		t.Fatalf("Expected 1 erasure completed, got %d", completed)
+	}
+
+	for _, f := range fakes {
+		if len(f.calls) != 1 || f.calls[0] != "acme/user-1/"+e.Pseudonym() {
+			t.Errorf("Expected %s to erase acme/user-1 once, got %v", f.system, f.calls)
+		}
+	}
+
+	stored, _ := repo.GetByID(ctx, e.ID)
+	if stored.Status != StatusCompleted || stored.CompletedAt == nil {
+		t.Fatalf("Expected the erasure to be COMPLETED, got %+v", stored)
+	}
+	c, err := VerifyCertificate([]byte(stored.Certificate), testKey)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if c.ErasureID != e.ID || strings.Contains(stored.Certificate, "user-1") {
+		t.Errorf("Expected a certificate naming the customer only by subject, got %s", stored.Certificate)
+	}
+	if c.Systems[0].System != SystemOrders || c.Systems[0].Action != ActionAnonymized || c.Systems[0].Records != 3 {
+		t.Errorf("Unexpected systems %+v", c.Systems)
+	}
+}
+
+func TestProcessPending_RetriesFailedTasks(t *testing.T) {
+	_, orchestrator, repo, fakes := setupService()
+	ctx := tenant.NewContext(context.Background(), "acme")
+	fakes[1].failures = 1
+
+	e, err := orchestrator.Request(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if completed, _ := orchestrator.ProcessPending(context.Background()); completed != 0 {
+		t.Fatalf("Expected no erasure completed, got %d", completed)
+	}
+
+	stored, _ := repo.GetByID(ctx, e.ID)
+	reviews := stored.Tasks[1]
+	if stored.Status != StatusPending || reviews.Status != TaskPending || reviews.Attempts != 1 || reviews.LastError == "" {
+		t.Fatalf("Expected the reviews task to be pending after a failed attempt, got %+v", reviews)
+	}
+
+	if completed, _ := orchestrator.ProcessPending(context.Background()); completed != 1 {
+		t.Fatalf("Expected the erasure to complete on retry, got %d", completed)
+	}
+	if len(fakes[0].calls) != 1 || len(fakes[1].calls) != 2 {
+		t.Errorf("Expected only the failed task to be retried, got %d and %d calls", len(fakes[0].calls), len(fakes[1].calls))
+	}
+	stored, _ = repo.GetByID(ctx, e.ID)
+	if reviews := stored.Tasks[1]; reviews.Attempts != 2 || reviews.LastError != "" {
+		t.Errorf("Expected the reviews task done on its second attempt, got %+v", reviews)
+	}
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	_, orchestrator, repo, _ := setupService()
+	ctx := context.Background()
+
+	e, _ := orchestrator.Request(ctx, "user-1")
+	orchestrator.ProcessPending(ctx)
+	stored, _ := repo.GetByID(ctx, e.ID)
+
+	if _, err := VerifyCertificate([]byte(stored.Certificate), []byte("other-key")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature with another key, got %v", err)
+	}
+	tampered := strings.Replace(stored.Certificate, `"records":3`, `"records":0`, 1)
+	if _, err := VerifyCertificate([]byte(tampered), testKey); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for a tampered certificate, got %v", err)
+	}
+
+	unsigned := NewOrchestrator(repo, nil, orchestrator.log, &fakeEraser{system: SystemCarts, action: ActionDeleted})
+	e, _ = unsigned.Request(ctx, "user-2")
+	unsigned.ProcessPending(ctx)
+	stored, _ = repo.GetByID(ctx, e.ID)
+	if _, err := VerifyCertificate([]byte(stored.Certificate), testKey); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Expected ErrUnsigned, got %v", err)
+	}
+}
+
+func TestEventHandler(t *testing.T) {
+	_, orchestrator, repo, _ := setupService()
+	handle := EventHandler(orchestrator)
+
+	event := func(eventType, data string) kafka.Message {
+		value, _ := json.Marshal(map[string]interface{}{"id": "event-1", "type": eventType, "data": json.RawMessage(data)})
+		return kafka.Message{Topic: AccountEventsTopic, Value: value}
+	}
+
+	deleted := event(EventAccountDeleted, `{"account_id":"user-1","tenant_id":"acme"}`)
+	for i := 0; i < 2; i++ {
+		if err := handle(context.Background(), deleted); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if err := handle(context.Background(), event("account.updated", `{"account_id":"user-2","tenant_id":"acme"}`)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	erasures, total, _ := repo.List(tenant.NewContext(context.Background(), "acme"), "", 1, 10)
+	if total != 1 || erasures[0].UserID != "user-1" {
+		t.Errorf("Expected one erasure of user-1 in the tenant, got %d", total)
+	}
+
+	for _, data := range []string{`{"tenant_id":"acme"}`, `{"account_id":"user-1","tenant_id":"Not A Tenant"}`} {
+		if err := handle(context.Background(), event(EventAccountDeleted, data)); err == nil {
+			t.Errorf("Expected an error for %s", data)
+		}
+	}
+}
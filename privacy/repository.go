@@ -0,0 +1,340 @@
+package privacy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Erasure statuses
+const (
+	StatusPending   = "PENDING"
+	StatusCompleted = "COMPLETED"
+)
+
+// Task statuses
+const (
+	TaskPending = "PENDING"
+	TaskDone    = "DONE"
+)
+
+// Erasure actions
+const (
+	ActionAnonymized = "anonymized"
+	ActionDeleted    = "deleted"
+)
+
+// ErrErasureNotFound is returned when an erasure does not exist
+var ErrErasureNotFound = errors.New("erasure not found")
+
+// Erasure is the erasure of a customer's personal data across the systems
+// holding it
+type Erasure struct {
+	ID          string
+	TenantID    string
+	UserID      string
+	Status      string
+	Tasks       []*Task
+	RequestedAt time.Time
+	// CompletedAt and Certificate are set when the erasure is COMPLETED
+	CompletedAt *time.Time
+	Certificate string
+}
+
+// Pseudonym replaces the customer in the systems that keep their records
+func (e *Erasure) Pseudonym() string {
+	return "erased-" + e.ID
+}
+
+// Task is the erasure of the customer's data in one system
+type Task struct {
+	System    string
+	Action    string
+	Status    string
+	Records   int32
+	Attempts  int32
+	LastError string
+	// CompletedAt is set when the task is DONE
+	CompletedAt *time.Time
+}
+
+// Repository handles erasure data persistence. Erasures are read in the
+// context tenant, except by ListPending.
+type Repository interface {
+	// Create records an erasure and its tasks, unless the customer already
+	// has one in the tenant, and returns the customer's erasure and whether
+	// it was created
+	Create(ctx context.Context, e *Erasure) (*Erasure, bool, error)
+	GetByID(ctx context.Context, id string) (*Erasure, error)
+	List(ctx context.Context, status string, page, pageSize int32) ([]*Erasure, int32, error)
+	// ListPending returns up to limit PENDING erasures of every tenant,
+	// oldest first
+	ListPending(ctx context.Context, limit int) ([]*Erasure, error)
+	// UpdateTask saves the outcome of an attempt at a task
+	UpdateTask(ctx context.Context, erasureID string, t *Task) error
+	// Complete marks a PENDING erasure COMPLETED with its certificate
+	Complete(ctx context.Context, id string, completedAt time.Time, certificate string) error
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL erasure repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const erasureColumns = `id, tenant_id, user_id, status, requested_at, completed_at, COALESCE(certificate, '')`
+
+const taskColumns = `erasure_id, system, action, status, records, attempts, last_error, completed_at`
+
+// Create inserts an erasure and its tasks. A customer already erased, or
+// being erased, keeps their erasure.
+func (r *postgresRepository) Create(ctx context.Context, e *Erasure) (*Erasure, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	e.ID = uuid.New().String()
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO erasures (id, tenant_id, user_id, status, requested_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, user_id) DO NOTHING
+	`, e.ID, e.TenantID, e.UserID, e.Status, e.RequestedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create erasure", map[string]interface{}{"error": err.Error(), "user_id": e.UserID})
+		return nil, false, fmt.Errorf("failed to create erasure: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create erasure: %w", err)
+	}
+	if rows == 0 {
+		existing, err := r.getOne(ctx, "tenant_id = $1 AND user_id = $2", e.TenantID, e.UserID)
+		if err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	for _, t := range e.Tasks {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO erasure_tasks (erasure_id, system, action, status)
+			VALUES ($1, $2, $3, $4)
+		`, e.ID, t.System, t.Action, t.Status)
+		if err != nil {
+			r.log.Error(ctx, "Failed to create erasure task", map[string]interface{}{"error": err.Error(), "erasure_id": e.ID})
+			return nil, false, fmt.Errorf("failed to create erasure task: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit erasure: %w", err)
+	}
+
+	r.log.Info(ctx, "Erasure created", map[string]interface{}{"erasure_id": e.ID, "tenant_id": e.TenantID, "user_id": e.UserID})
+	return e, true, nil
+}
+
+// GetByID retrieves an erasure of the context tenant and its tasks
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Erasure, error) {
+	return r.getOne(ctx, "id = $1 AND tenant_id = $2", id, tenant.FromContext(ctx))
+}
+
+func (r *postgresRepository) getOne(ctx context.Context, where string, args ...interface{}) (*Erasure, error) {
+	query := "SELECT " + erasureColumns + " FROM erasures WHERE " + where
+
+	e, err := scanErasure(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrErasureNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get erasure", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get erasure: %w", err)
+	}
+
+	if err := r.loadTasks(ctx, []*Erasure{e}); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// List retrieves erasures of the context tenant with pagination, newest first
+func (r *postgresRepository) List(ctx context.Context, status string, page, pageSize int32) ([]*Erasure, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+	tenantID := tenant.FromContext(ctx)
+
+	const where = `
+		WHERE tenant_id = $1
+			AND ($2 = '' OR status = $2)`
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM erasures"+where, tenantID, status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count erasures", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count erasures: %w", err)
+	}
+
+	query := "SELECT " + erasureColumns + " FROM erasures" + where + `
+		ORDER BY requested_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	erasures, err := r.query(ctx, query, tenantID, status, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return erasures, total, nil
+}
+
+// ListPending retrieves the oldest PENDING erasures of every tenant
+func (r *postgresRepository) ListPending(ctx context.Context, limit int) ([]*Erasure, error) {
+	query := "SELECT " + erasureColumns + " FROM erasures WHERE status = $1 ORDER BY requested_at, id LIMIT $2"
+	return r.query(ctx, query, StatusPending, limit)
+}
+
+func (r *postgresRepository) query(ctx context.Context, query string, args ...interface{}) ([]*Erasure, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list erasures", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list erasures: %w", err)
+	}
+	defer rows.Close()
+
+	var erasures []*Erasure
+	for rows.Next() {
+		e, err := scanErasure(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan erasure", map[string]interface{}{"error": err.Error()})
+			return nil, fmt.Errorf("failed to scan erasure: %w", err)
+		}
+		erasures = append(erasures, e)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating erasures", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error iterating erasures: %w", err)
+	}
+
+	if err := r.loadTasks(ctx, erasures); err != nil {
+		return nil, err
+	}
+	return erasures, nil
+}
+
+// loadTasks reads the tasks of erasures in one query, in system order
+func (r *postgresRepository) loadTasks(ctx context.Context, erasures []*Erasure) error {
+	if len(erasures) == 0 {
+		return nil
+	}
+	ids := make([]string, len(erasures))
+	byID := make(map[string]*Erasure, len(erasures))
+	for i, e := range erasures {
+		ids[i] = e.ID
+		byID[e.ID] = e
+		e.Tasks = []*Task{}
+	}
+
+	query := "SELECT " + taskColumns + " FROM erasure_tasks WHERE erasure_id = ANY($1) ORDER BY erasure_id, system"
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get erasure tasks", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get erasure tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var erasureID string
+		var completedAt sql.NullTime
+		t := &Task{}
+		if err := rows.Scan(&erasureID, &t.System, &t.Action, &t.Status, &t.Records, &t.Attempts, &t.LastError, &completedAt); err != nil {
+			return fmt.Errorf("failed to scan erasure task: %w", err)
+		}
+		if completedAt.Valid {
+			t.CompletedAt = &completedAt.Time
+		}
+		if e, ok := byID[erasureID]; ok {
+			e.Tasks = append(e.Tasks, t)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating erasure tasks: %w", err)
+	}
+	return nil
+}
+
+// UpdateTask saves a task's status, records, attempts, error and completion
+func (r *postgresRepository) UpdateTask(ctx context.Context, erasureID string, t *Task) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE erasure_tasks
+		SET status = $3, records = $4, attempts = $5, last_error = $6, completed_at = $7
+		WHERE erasure_id = $1 AND system = $2
+	`, erasureID, t.System, t.Status, t.Records, t.Attempts, t.LastError, t.CompletedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to update erasure task", map[string]interface{}{"error": err.Error(), "erasure_id": erasureID, "system": t.System})
+		return fmt.Errorf("failed to update erasure task: %w", err)
+	}
+	return nil
+}
+
+// Complete marks an erasure COMPLETED. An erasure already completed, e.g. by
+// another replica, keeps its certificate.
+func (r *postgresRepository) Complete(ctx context.Context, id string, completedAt time.Time, certificate string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE erasures
+		SET status = $2, completed_at = $3, certificate = $4
+		WHERE id = $1 AND status = $5
+	`, id, StatusCompleted, completedAt, certificate, StatusPending)
+	if err != nil {
+		r.log.Error(ctx, "Failed to complete erasure", map[string]interface{}{"error": err.Error(), "erasure_id": id})
+		return fmt.Errorf("failed to complete erasure: %w", err)
+	}
+
+	r.log.Info(ctx, "Erasure completed", map[string]interface{}{"erasure_id": id})
+	return nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanErasure(row rowScanner) (*Erasure, error) {
+	e := &Erasure{}
+	var completedAt sql.NullTime
+	err := row.Scan(&e.ID, &e.TenantID, &e.UserID, &e.Status, &e.RequestedAt, &completedAt, &e.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		e.CompletedAt = &completedAt.Time
+	}
+	return e, nil
+}
@@ -0,0 +1,159 @@
+package privacy
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("privacy-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var erasureColumnNames = []string{"id", "tenant_id", "user_id", "status", "requested_at", "completed_at", "certificate"}
+
+var taskColumnNames = []string{"erasure_id", "system", "action", "status", "records", "attempts", "last_error", "completed_at"}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	e := &Erasure{
+		TenantID:    "acme",
+		UserID:      "user-1",
+		Status:      StatusPending,
+		Tasks:       []*Task{{System: SystemCarts, Action: ActionDeleted, Status: TaskPending}},
+		RequestedAt: now,
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO erasures .* ON CONFLICT \(tenant_id, user_id\) DO NOTHING`).
+		WithArgs(sqlmock.AnyArg(), "acme", "user-1", StatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO erasure_tasks`).
+		WithArgs(sqlmock.AnyArg(), SystemCarts, ActionDeleted, TaskPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	created, ok, err := repo.Create(context.Background(), e)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !ok || created.ID == "" {
+		t.Errorf("Expected the erasure to be created with an ID, got %+v", created)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_Existing(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO erasures`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM erasures WHERE tenant_id = \$1 AND user_id = \$2`).
+		WithArgs("acme", "user-1").
+		WillReturnRows(sqlmock.NewRows(erasureColumnNames).
+			AddRow("erasure-1", "acme", "user-1", StatusCompleted, now, now, `{"erasure_id":"erasure-1"}`))
+	mock.ExpectQuery(`SELECT .* FROM erasure_tasks WHERE erasure_id = ANY\(\$1\)`).
+		WithArgs(pq.Array([]string{"erasure-1"})).
+		WillReturnRows(sqlmock.NewRows(taskColumnNames).
+			AddRow("erasure-1", SystemCarts, ActionDeleted, TaskDone, 1, 1, "", now))
+	mock.ExpectRollback()
+
+	e := &Erasure{TenantID: "acme", UserID: "user-1", Status: StatusPending, RequestedAt: now}
+	existing, created, err := repo.Create(context.Background(), e)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created || existing.ID != "erasure-1" || existing.Status != StatusCompleted || len(existing.Tasks) != 1 {
+		t.Errorf("Expected the existing erasure, got %+v", existing)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ctx := tenant.NewContext(context.Background(), "acme")
+	mock.ExpectQuery(`SELECT .* FROM erasures WHERE id = \$1 AND tenant_id = \$2`).WithArgs("missing", "acme").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM erasures WHERE id = \$1 AND tenant_id = \$2`).WithArgs("not-a-uuid", "acme").WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(ctx, id); err != ErrErasureNotFound {
+			t.Errorf("Expected ErrErasureNotFound for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestListPending(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM erasures WHERE status = \$1 ORDER BY requested_at, id LIMIT \$2`).
+		WithArgs(StatusPending, 50).
+		WillReturnRows(sqlmock.NewRows(erasureColumnNames).
+			AddRow("erasure-1", "acme", "user-1", StatusPending, now, nil, "").
+			AddRow("erasure-2", "globex", "user-2", StatusPending, now, nil, ""))
+	mock.ExpectQuery(`FROM erasure_tasks`).
+		WithArgs(pq.Array([]string{"erasure-1", "erasure-2"})).
+		WillReturnRows(sqlmock.NewRows(taskColumnNames).
+			AddRow("erasure-1", SystemOrders, ActionAnonymized, TaskDone, 3, 1, "", now).
+			AddRow("erasure-2", SystemOrders, ActionAnonymized, TaskPending, 0, 2, "connection refused", nil))
+
+	erasures, err := repo.ListPending(context.Background(), 50)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(erasures) != 2 || erasures[1].TenantID != "globex" {
+		t.Fatalf("Expected the pending erasures of both tenants, got %+v", erasures)
+	}
+	if task := erasures[1].Tasks[0]; task.Attempts != 2 || task.LastError != "connection refused" || task.CompletedAt != nil {
+		t.Errorf("Unexpected task %+v", task)
+	}
+}
+
+func TestUpdateTaskAndComplete(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	task := &Task{System: SystemOrders, Status: TaskDone, Records: 3, Attempts: 1, CompletedAt: &now}
+	mock.ExpectExec(`UPDATE erasure_tasks\s+SET status = \$3, .*\s+WHERE erasure_id = \$1 AND system = \$2`).
+		WithArgs("erasure-1", SystemOrders, TaskDone, int32(3), int32(1), "", task.CompletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE erasures\s+SET status = \$2, .*\s+WHERE id = \$1 AND status = \$5`).
+		WithArgs("erasure-1", StatusCompleted, now, `{"erasure_id":"erasure-1"}`, StatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdateTask(context.Background(), "erasure-1", task); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.Complete(context.Background(), "erasure-1", now, `{"erasure_id":"erasure-1"}`); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/privacy/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxUserIDLength matches the user_id column of erasures
+const maxUserIDLength = 255
+
+// validStatuses are the statuses an erasure list can be filtered by
+var validStatuses = map[string]bool{StatusPending: true, StatusCompleted: true}
+
+// AdminMethods are the admin-scoped RPCs of the privacy service, which are
+// all of them; they are restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.PrivacyService_RequestErasure_FullMethodName,
+	pb.PrivacyService_GetErasure_FullMethodName,
+	pb.PrivacyService_ListErasures_FullMethodName,
+	pb.PrivacyService_GetDeletionCertificate_FullMethodName,
+}
+
+// Service implements the PrivacyService gRPC interface
+type Service struct {
+	pb.UnimplementedPrivacyServiceServer
+	repo         Repository
+	orchestrator *Orchestrator
+	log          *logger.Logger
+}
+
+// NewService creates a new privacy service requesting erasures from
+// orchestrator
+func NewService(repo Repository, orchestrator *Orchestrator, log *logger.Logger) *Service {
+	return &Service{
+		repo:         repo,
+		orchestrator: orchestrator,
+		log:          log,
+	}
+}
+
+// RequestErasure erases a customer's data in the caller's tenant, for
+// deletions support handles outside the account service. The erasure is
+// carried out in the background.
+func (s *Service) RequestErasure(ctx context.Context, req *pb.RequestErasureRequest) (*pb.RequestErasureResponse, error) {
+	var msg string
+	switch {
+	case req.UserId == "":
+		msg = "user_id is required"
+	case len(req.UserId) > maxUserIDLength:
+		msg = "user_id is too long"
+	}
+	if msg != "" {
+		s.log.Warn(ctx, "Request erasure failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	e, err := s.orchestrator.Request(ctx, req.UserId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to request erasure", map[string]interface{}{"error": err.Error(), "user_id": req.UserId})
+		return nil, status.Error(codes.Internal, "failed to request erasure")
+	}
+
+	return &pb.RequestErasureResponse{
+		Erasure: toProtoErasure(e),
+	}, nil
+}
+
+// GetErasure retrieves an erasure and the progress of its tasks
+func (s *Service) GetErasure(ctx context.Context, req *pb.GetErasureRequest) (*pb.GetErasureResponse, error) {
+	e, err := s.load(ctx, req.ErasureId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetErasureResponse{
+		Erasure: toProtoErasure(e),
+	}, nil
+}
+
+// ListErasures lists the tenant's erasures, newest first
+func (s *Service) ListErasures(ctx context.Context, req *pb.ListErasuresRequest) (*pb.ListErasuresResponse, error) {
+	if req.Status != "" && !validStatuses[req.Status] {
+		s.log.Warn(ctx, "List erasures failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be PENDING or COMPLETED")
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	erasures, total, err := s.repo.List(ctx, req.Status, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list erasures", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list erasures")
+	}
+
+	protoErasures := make([]*pb.Erasure, len(erasures))
+	for i, e := range erasures {
+		protoErasures[i] = toProtoErasure(e)
+	}
+
+	return &pb.ListErasuresResponse{
+		Erasures: protoErasures,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// GetDeletionCertificate returns the certificate of a COMPLETED erasure, and
+// the JSON it was signed as
+func (s *Service) GetDeletionCertificate(ctx context.Context, req *pb.GetDeletionCertificateRequest) (*pb.GetDeletionCertificateResponse, error) {
+	e, err := s.load(ctx, req.ErasureId)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != StatusCompleted {
+		s.log.Warn(ctx, "Get deletion certificate failed: erasure is not completed", map[string]interface{}{"erasure_id": e.ID})
+		return nil, status.Error(codes.FailedPrecondition, "erasure is not completed")
+	}
+
+	var c Certificate
+	if err := json.Unmarshal([]byte(e.Certificate), &c); err != nil {
+		s.log.Error(ctx, "Failed to decode deletion certificate", map[string]interface{}{"error": err.Error(), "erasure_id": e.ID})
+		return nil, status.Error(codes.Internal, "failed to get deletion certificate")
+	}
+
+	return &pb.GetDeletionCertificateResponse{
+		Certificate: toProtoCertificate(&c),
+		Document:    e.Certificate,
+	}, nil
+}
+
+// load retrieves an erasure of the caller's tenant, mapping repository errors
+// to gRPC status errors
+func (s *Service) load(ctx context.Context, id string) (*Erasure, error) {
+	if id == "" {
+		s.log.Warn(ctx, "Erasure request failed: erasure ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "erasure_id is required")
+	}
+
+	e, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, ErrErasureNotFound) {
+		s.log.Warn(ctx, "Erasure not found", map[string]interface{}{"erasure_id": id})
+		return nil, status.Error(codes.NotFound, "erasure not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get erasure", map[string]interface{}{"error": err.Error(), "erasure_id": id})
+		return nil, status.Error(codes.Internal, "failed to get erasure")
+	}
+	return e, nil
+}
+
+func toProtoErasure(e *Erasure) *pb.Erasure {
+	erasure := &pb.Erasure{
+		Id:          e.ID,
+		UserId:      e.UserID,
+		TenantId:    e.TenantID,
+		Status:      e.Status,
+		Tasks:       make([]*pb.ErasureTask, len(e.Tasks)),
+		RequestedAt: timestamppb.New(e.RequestedAt),
+	}
+	for i, t := range e.Tasks {
+		erasure.Tasks[i] = &pb.ErasureTask{
+			System:    t.System,
+			Action:    t.Action,
+			Status:    t.Status,
+			Records:   t.Records,
+			Attempts:  t.Attempts,
+			LastError: t.LastError,
+		}
+		if t.CompletedAt != nil {
+			erasure.Tasks[i].CompletedAt = timestamppb.New(*t.CompletedAt)
+		}
+	}
+	if e.CompletedAt != nil {
+		erasure.CompletedAt = timestamppb.New(*e.CompletedAt)
+	}
+	return erasure
+}
+
+func toProtoCertificate(c *Certificate) *pb.DeletionCertificate {
+	certificate := &pb.DeletionCertificate{
+		ErasureId:   c.ErasureID,
+		Subject:     c.Subject,
+		TenantId:    c.TenantID,
+		RequestedAt: timestamppb.New(c.RequestedAt),
+		CompletedAt: timestamppb.New(c.CompletedAt),
+		Systems:     make([]*pb.ErasedSystem, len(c.Systems)),
+		Signature:   c.Signature,
+	}
+	for i, system := range c.Systems {
+		certificate.Systems[i] = &pb.ErasedSystem{
+			System:      system.System,
+			Action:      system.Action,
+			Records:     system.Records,
+			CompletedAt: timestamppb.New(system.CompletedAt),
+		}
+	}
+	return certificate
+}
@@ -0,0 +1,281 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/Ujjwaljain16/E-commerce-Backend/privacy/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository is an in-memory Repository scoped by tenant like the
+// Postgres repository
+type memoryRepository struct {
+	mu       sync.Mutex
+	erasures map[string]*Erasure
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{erasures: map[string]*Erasure{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, e *Erasure) (*Erasure, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.erasures {
+		if existing.TenantID == e.TenantID && existing.UserID == e.UserID {
+			return copyErasure(existing), false, nil
+		}
+	}
+	e.ID = uuid.New().String()
+	m.erasures[e.ID] = copyErasure(e)
+	return copyErasure(e), true, nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Erasure, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.erasures[id]
+	if !ok || e.TenantID != tenant.FromContext(ctx) {
+		return nil, ErrErasureNotFound
+	}
+	return copyErasure(e), nil
+}
+
+func (m *memoryRepository) List(ctx context.Context, status string, page, pageSize int32) ([]*Erasure, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []*Erasure
+	for _, e := range m.erasures {
+		if e.TenantID == tenant.FromContext(ctx) && (status == "" || e.Status == status) {
+			matched = append(matched, copyErasure(e))
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].RequestedAt.After(matched[j].RequestedAt) })
+	start := int((page - 1) * pageSize)
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + int(pageSize)
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], int32(len(matched)), nil
+}
+
+func (m *memoryRepository) ListPending(ctx context.Context, limit int) ([]*Erasure, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var pending []*Erasure
+	for _, e := range m.erasures {
+		if e.Status == StatusPending {
+			pending = append(pending, copyErasure(e))
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].RequestedAt.Before(pending[j].RequestedAt) })
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
+
+func (m *memoryRepository) UpdateTask(ctx context.Context, erasureID string, t *Task) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, stored := range m.erasures[erasureID].Tasks {
+		if stored.System == t.System {
+			*stored = *t
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) Complete(ctx context.Context, id string, completedAt time.Time, certificate string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.erasures[id]
+	if e.Status == StatusPending {
+		e.Status, e.CompletedAt, e.Certificate = StatusCompleted, &completedAt, certificate
+	}
+	return nil
+}
+
+func (m *memoryRepository) Close() error { return nil }
+
+func copyErasure(e *Erasure) *Erasure {
+	c := *e
+	c.Tasks = make([]*Task, len(e.Tasks))
+	for i, t := range e.Tasks {
+		task := *t
+		c.Tasks[i] = &task
+	}
+	return &c
+}
+
+// fakeEraser erases nothing; it fails while failures are left
+type fakeEraser struct {
+	system   string
+	action   string
+	records  int32
+	mu       sync.Mutex
+	failures int
+	calls    []string
+}
+
+func (f *fakeEraser) System() string { return f.system }
+
+func (f *fakeEraser) Action() string { return f.action }
+
+func (f *fakeEraser) Erase(ctx context.Context, userID, pseudonym string) (int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, tenant.FromContext(ctx)+"/"+userID+"/"+pseudonym)
+	if f.failures > 0 {
+		f.failures--
+		return 0, errors.New("connection refused")
+	}
+	return f.records, nil
+}
+
+var testKey = []byte("certificate-key")
+
+func setupService() (*Service, *Orchestrator, *memoryRepository, []*fakeEraser) {
+	repo := newMemoryRepository()
+	fakes := []*fakeEraser{
+		{system: SystemOrders, action: ActionAnonymized, records: 3},
+		{system: SystemReviews, action: ActionAnonymized, records: 1},
+		{system: SystemCarts, action: ActionDeleted, records: 1},
+		{system: SystemAnalytics, action: ActionDeleted, records: 12},
+	}
+	erasers := make([]Eraser, len(fakes))
+	for i, f := range fakes {
+		erasers[i] = f
+	}
+	log := logger.New("privacy-test")
+	orchestrator := NewOrchestrator(repo, testKey, log, erasers...)
+	return NewService(repo, orchestrator, log), orchestrator, repo, fakes
+}
+
+func TestRequestErasure(t *testing.T) {
+	service, _, _, _ := setupService()
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	resp, err := service.RequestErasure(ctx, &pb.RequestErasureRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	e := resp.Erasure
+	if e.Status != StatusPending || e.TenantId != "acme" || len(e.Tasks) != 4 {
+		t.Fatalf("Unexpected erasure %+v", e)
+	}
+	for _, task := range e.Tasks {
+		if task.Status != TaskPending {
+			t.Errorf("Expected task %s to be PENDING, got %s", task.System, task.Status)
+		}
+	}
+
+	again, err := service.RequestErasure(ctx, &pb.RequestErasureRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if again.Erasure.Id != e.Id {
+		t.Errorf("Expected erasure %s again, got %s", e.Id, again.Erasure.Id)
+	}
+}
+
+func TestRequestErasure_Errors(t *testing.T) {
+	service, _, _, _ := setupService()
+
+	long := make([]byte, maxUserIDLength+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+	for _, userID := range []string{"", string(long)} {
+		_, err := service.RequestErasure(context.Background(), &pb.RequestErasureRequest{UserId: userID})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %d characters, got %v", len(userID), err)
+		}
+	}
+}
+
+func TestGetDeletionCertificate(t *testing.T) {
+	service, orchestrator, _, _ := setupService()
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	requested, err := service.RequestErasure(ctx, &pb.RequestErasureRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = service.GetDeletionCertificate(ctx, &pb.GetDeletionCertificateRequest{ErasureId: requested.Erasure.Id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition before the erasure completes, got %v", err)
+	}
+
+	if _, err := orchestrator.ProcessPending(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := service.GetDeletionCertificate(ctx, &pb.GetDeletionCertificateRequest{ErasureId: requested.Erasure.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	c := resp.Certificate
+	if c.Subject != Subject("acme", "user-1") || c.TenantId != "acme" || len(c.Systems) != 4 || c.Signature == "" {
+		t.Errorf("Unexpected certificate %+v", c)
+	}
+	if _, err := VerifyCertificate([]byte(resp.Document), testKey); err != nil {
+		t.Errorf("Expected the document to verify, got %v", err)
+	}
+
+	other := tenant.NewContext(context.Background(), "globex")
+	_, err = service.GetDeletionCertificate(other, &pb.GetDeletionCertificateRequest{ErasureId: requested.Erasure.Id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound in another tenant, got %v", err)
+	}
+}
+
+func TestListErasures(t *testing.T) {
+	service, orchestrator, _, _ := setupService()
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	for _, userID := range []string{"user-1", "user-2"} {
+		if _, err := service.RequestErasure(ctx, &pb.RequestErasureRequest{UserId: userID}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if _, err := service.RequestErasure(context.Background(), &pb.RequestErasureRequest{UserId: "user-3"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := service.ListErasures(ctx, &pb.ListErasuresRequest{Status: StatusPending})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || resp.PageSize != 10 {
+		t.Errorf("Expected 2 pending erasures in the tenant, got %d (page size %d)", resp.Total, resp.PageSize)
+	}
+
+	if _, err := orchestrator.ProcessPending(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp, err = service.ListErasures(ctx, &pb.ListErasuresRequest{Status: StatusCompleted})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 {
+		t.Errorf("Expected 2 completed erasures, got %d", resp.Total)
+	}
+
+	_, err = service.ListErasures(ctx, &pb.ListErasuresRequest{Status: "DONE"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
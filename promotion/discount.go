@@ -0,0 +1,143 @@
+package promotion
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Reasons a coupon does not apply to a cart
+var (
+	ErrCouponInactive       = errors.New("coupon is not active")
+	ErrCouponNotStarted     = errors.New("coupon is not valid yet")
+	ErrCouponExpired        = errors.New("coupon has expired")
+	ErrSegmentNotEligible   = errors.New("coupon is not available to this customer")
+	ErrMinCartValue         = errors.New("cart is below the coupon's minimum value")
+	ErrNoEligibleItems      = errors.New("cart has no items the coupon applies to")
+	ErrUsageLimitReached    = errors.New("coupon has been used up")
+	ErrCustomerLimitReached = errors.New("customer has already used this coupon the maximum number of times")
+)
+
+// Line is a priced cart line
+type Line struct {
+	ProductID string
+	Category  string
+	UnitPrice float64
+	Quantity  int32
+}
+
+// Cart is what a coupon is applied to
+type Cart struct {
+	Segment  string
+	Lines    []Line
+	Shipping float64
+}
+
+// LineDiscount is the part of a discount taken off one line
+type LineDiscount struct {
+	ProductID string
+	Discount  float64
+}
+
+// Discount is the outcome of applying a coupon to a cart
+type Discount struct {
+	Subtotal float64
+	// Items is taken off the lines in Lines
+	Items    float64
+	Shipping float64
+	Lines    []LineDiscount
+}
+
+// Total is the discount off items and shipping together
+func (d *Discount) Total() float64 {
+	return roundCents(d.Items + d.Shipping)
+}
+
+// Apply checks that a coupon applies to a cart at a time and works out the
+// discount. Usage limits are not checked; they depend on the redemptions
+// made, which Repository.Redeem counts under lock.
+func Apply(c *Coupon, cart Cart, now time.Time) (*Discount, error) {
+	switch {
+	case !c.Active:
+		return nil, ErrCouponInactive
+	case c.StartsAt != nil && now.Before(*c.StartsAt):
+		return nil, ErrCouponNotStarted
+	case c.EndsAt != nil && !now.Before(*c.EndsAt):
+		return nil, ErrCouponExpired
+	case len(c.CustomerSegments) > 0 && !slices.Contains(c.CustomerSegments, cart.Segment):
+		return nil, ErrSegmentNotEligible
+	}
+
+	d := &Discount{}
+	var eligible []Line
+	eligibleSubtotal := 0.0
+	for _, line := range cart.Lines {
+		total := line.UnitPrice * float64(line.Quantity)
+		d.Subtotal += total
+		if appliesTo(c, line.Category) {
+			eligible = append(eligible, line)
+			eligibleSubtotal += total
+		}
+	}
+	d.Subtotal = roundCents(d.Subtotal)
+	eligibleSubtotal = roundCents(eligibleSubtotal)
+
+	if d.Subtotal < c.MinCartValue {
+		return nil, ErrMinCartValue
+	}
+	if len(eligible) == 0 {
+		return nil, ErrNoEligibleItems
+	}
+
+	switch c.Type {
+	case TypePercentage:
+		d.Items = roundCents(eligibleSubtotal * c.Value / 100)
+		if c.MaxDiscount > 0 && d.Items > c.MaxDiscount {
+			d.Items = c.MaxDiscount
+		}
+	case TypeFixed:
+		d.Items = math.Min(c.Value, eligibleSubtotal)
+	case TypeFreeShipping:
+		d.Shipping = roundCents(cart.Shipping)
+	}
+	if d.Items > 0 {
+		d.Lines = spread(d.Items, eligible, eligibleSubtotal)
+	}
+	return d, nil
+}
+
+// appliesTo reports whether a coupon's discount applies to a category
+func appliesTo(c *Coupon, category string) bool {
+	if len(c.Categories) == 0 {
+		return true
+	}
+	for _, cat := range c.Categories {
+		if strings.EqualFold(cat, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// spread divides a discount across lines in proportion to their totals. The
+// last line takes the rounding remainder, so the parts add up exactly.
+func spread(discount float64, lines []Line, subtotal float64) []LineDiscount {
+	parts := make([]LineDiscount, len(lines))
+	remaining := discount
+	for i, line := range lines {
+		part := remaining
+		if i < len(lines)-1 {
+			part = roundCents(discount * line.UnitPrice * float64(line.Quantity) / subtotal)
+			remaining = roundCents(remaining - part)
+		}
+		parts[i] = LineDiscount{ProductID: line.ProductID, Discount: part}
+	}
+	return parts
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,109 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func testCart() Cart {
+	return Cart{
+		Lines: []Line{
+			{ProductID: "shirt", Category: "Apparel", UnitPrice: 20, Quantity: 2},
+			{ProductID: "mug", Category: "Kitchen", UnitPrice: 10, Quantity: 1},
+			{ProductID: "cap", Category: "apparel", UnitPrice: 9.99, Quantity: 1},
+		},
+		Shipping: 5.99,
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		coupon   Coupon
+		items    float64
+		shipping float64
+		lines    int
+	}{
+		{"percentage", Coupon{Type: TypePercentage, Value: 10}, 6.00, 0, 3},
+		{"percentage capped", Coupon{Type: TypePercentage, Value: 50, MaxDiscount: 15}, 15, 0, 3},
+		{"percentage of a category", Coupon{Type: TypePercentage, Value: 10, Categories: []string{"APPAREL"}}, 5.00, 0, 2},
+		{"fixed", Coupon{Type: TypeFixed, Value: 12.5}, 12.5, 0, 3},
+		{"fixed above the eligible lines", Coupon{Type: TypeFixed, Value: 25, Categories: []string{"Kitchen"}}, 10, 0, 1},
+		{"free shipping", Coupon{Type: TypeFreeShipping}, 0, 5.99, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.coupon.Active = true
+			d, err := Apply(&tt.coupon, testCart(), now)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if d.Subtotal != 59.99 {
+				t.Errorf("Expected subtotal 59.99, got %v", d.Subtotal)
+			}
+			if d.Items != tt.items || d.Shipping != tt.shipping || len(d.Lines) != tt.lines {
+				t.Errorf("Expected %v off items, %v off shipping over %d lines, got %+v", tt.items, tt.shipping, tt.lines, d)
+			}
+			sum := 0.0
+			for _, l := range d.Lines {
+				sum += l.Discount
+			}
+			if roundCents(sum) != d.Items {
+				t.Errorf("Expected line discounts to add up to %v, got %v", d.Items, sum)
+			}
+		})
+	}
+}
+
+func TestApply_Ineligible(t *testing.T) {
+	yesterday, tomorrow := now.Add(-24*time.Hour), now.Add(24*time.Hour)
+
+	tests := []struct {
+		name     string
+		coupon   Coupon
+		segment  string
+		expected error
+	}{
+		{"inactive", Coupon{Type: TypeFixed, Value: 5}, "", ErrCouponInactive},
+		{"not started", Coupon{Type: TypeFixed, Value: 5, Active: true, StartsAt: &tomorrow}, "", ErrCouponNotStarted},
+		{"expired", Coupon{Type: TypeFixed, Value: 5, Active: true, EndsAt: &yesterday}, "", ErrCouponExpired},
+		{"ends now", Coupon{Type: TypeFixed, Value: 5, Active: true, EndsAt: &now}, "", ErrCouponExpired},
+		{"other segment", Coupon{Type: TypeFixed, Value: 5, Active: true, CustomerSegments: []string{"vip"}}, "wholesale", ErrSegmentNotEligible},
+		{"no segment", Coupon{Type: TypeFixed, Value: 5, Active: true, CustomerSegments: []string{"vip"}}, "", ErrSegmentNotEligible},
+		{"below minimum", Coupon{Type: TypeFixed, Value: 5, Active: true, MinCartValue: 60}, "", ErrMinCartValue},
+		{"no eligible lines", Coupon{Type: TypeFreeShipping, Active: true, Categories: []string{"Books"}}, "", ErrNoEligibleItems},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := testCart()
+			cart.Segment = tt.segment
+			if _, err := Apply(&tt.coupon, cart, now); err != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	c := Coupon{Type: TypeFixed, Value: 5, Active: true, StartsAt: &yesterday, EndsAt: &tomorrow, MinCartValue: 59.99, CustomerSegments: []string{"vip"}}
+	cart := testCart()
+	cart.Segment = "vip"
+	if _, err := Apply(&c, cart, now); err != nil {
+		t.Errorf("Expected a coupon within its window, minimum and segment to apply, got %v", err)
+	}
+}
+
+func TestSpread_Remainder(t *testing.T) {
+	lines := []Line{
+		{ProductID: "a", UnitPrice: 1, Quantity: 1},
+		{ProductID: "b", UnitPrice: 1, Quantity: 1},
+		{ProductID: "c", UnitPrice: 1, Quantity: 1},
+	}
+
+	parts := spread(1, lines, 3)
+	if parts[0].Discount != 0.33 || parts[1].Discount != 0.33 || parts[2].Discount != 0.34 {
+		t.Errorf("Expected the last line to take the remainder, got %+v", parts)
+	}
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_coupon_redemptions_user;
+DROP INDEX IF EXISTS idx_coupon_redemptions_reference;
+DROP TABLE IF EXISTS coupon_redemptions;
+DROP INDEX IF EXISTS idx_coupons_created;
+DROP TABLE IF EXISTS coupons;
@@ -0,0 +1,42 @@
+-- Coupon codes and the rules deciding which carts they apply to
+CREATE TABLE IF NOT EXISTS coupons (
+    id UUID PRIMARY KEY,
+    code VARCHAR(32) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    type VARCHAR(20) NOT NULL CHECK (type IN ('PERCENTAGE', 'FIXED', 'FREE_SHIPPING')),
+    value DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (value >= 0),
+    max_discount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (max_discount >= 0),
+    min_cart_value DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (min_cart_value >= 0),
+    categories TEXT[] NOT NULL DEFAULT '{}',
+    customer_segments TEXT[] NOT NULL DEFAULT '{}',
+    usage_limit INTEGER NOT NULL DEFAULT 0 CHECK (usage_limit >= 0),
+    per_customer_limit INTEGER NOT NULL DEFAULT 0 CHECK (per_customer_limit >= 0),
+    times_used INTEGER NOT NULL DEFAULT 0 CHECK (times_used >= 0),
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT coupons_code_key UNIQUE (code)
+);
+
+CREATE INDEX idx_coupons_created ON coupons(created_at DESC);
+
+-- Coupons applied to checkouts. A released redemption no longer counts
+-- towards the coupon's limits.
+CREATE TABLE IF NOT EXISTS coupon_redemptions (
+    id UUID PRIMARY KEY,
+    coupon_id UUID NOT NULL REFERENCES coupons(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    discount DECIMAL(10, 2) NOT NULL CHECK (discount >= 0),
+    shipping_discount DECIMAL(10, 2) NOT NULL CHECK (shipping_discount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP
+);
+
+-- A checkout redeems a coupon once
+CREATE UNIQUE INDEX idx_coupon_redemptions_reference ON coupon_redemptions(coupon_id, reference) WHERE released_at IS NULL;
+
+-- A customer's redemptions of a coupon, for the per-customer limit
+CREATE INDEX idx_coupon_redemptions_user ON coupon_redemptions(coupon_id, user_id) WHERE released_at IS NULL;
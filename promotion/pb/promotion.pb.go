@@ -0,0 +1,1432 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: promotion/promotion.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Coupon is a promotion code customers enter at checkout
+type Coupon struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code             string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // upper case letters, digits, - and _
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type             string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`                                                     // PERCENTAGE, FIXED or FREE_SHIPPING
+	Value            float64                `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`                                                 // percentage off for PERCENTAGE, amount off for FIXED; 0 for FREE_SHIPPING
+	MaxDiscount      float64                `protobuf:"fixed64,6,opt,name=max_discount,json=maxDiscount,proto3" json:"max_discount,omitempty"`                  // cap on a PERCENTAGE discount; 0 for no cap
+	MinCartValue     float64                `protobuf:"fixed64,7,opt,name=min_cart_value,json=minCartValue,proto3" json:"min_cart_value,omitempty"`             // cart subtotal required; 0 for none
+	Categories       []string               `protobuf:"bytes,8,rep,name=categories,proto3" json:"categories,omitempty"`                                         // product categories the discount applies to; empty for all
+	CustomerSegments []string               `protobuf:"bytes,9,rep,name=customer_segments,json=customerSegments,proto3" json:"customer_segments,omitempty"`     // segments the coupon is for; empty for everyone
+	UsageLimit       int32                  `protobuf:"varint,10,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`                     // redemptions in total; 0 for unlimited
+	PerCustomerLimit int32                  `protobuf:"varint,11,opt,name=per_customer_limit,json=perCustomerLimit,proto3" json:"per_customer_limit,omitempty"` // redemptions per customer; 0 for unlimited
+	TimesUsed        int32                  `protobuf:"varint,12,opt,name=times_used,json=timesUsed,proto3" json:"times_used,omitempty"`
+	StartsAt         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"` // unset for valid from creation
+	EndsAt           *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`       // unset for no expiry
+	Active           bool                   `protobuf:"varint,15,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Coupon) Reset() {
+	*x = Coupon{}
+	mi := &file_promotion_promotion_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coupon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coupon) ProtoMessage() {}
+
+func (x *Coupon) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coupon.ProtoReflect.Descriptor instead.
+func (*Coupon) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Coupon) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Coupon) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Coupon) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Coupon) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Coupon) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Coupon) GetMaxDiscount() float64 {
+	if x != nil {
+		return x.MaxDiscount
+	}
+	return 0
+}
+
+func (x *Coupon) GetMinCartValue() float64 {
+	if x != nil {
+		return x.MinCartValue
+	}
+	return 0
+}
+
+func (x *Coupon) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Coupon) GetCustomerSegments() []string {
+	if x != nil {
+		return x.CustomerSegments
+	}
+	return nil
+}
+
+func (x *Coupon) GetUsageLimit() int32 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *Coupon) GetPerCustomerLimit() int32 {
+	if x != nil {
+		return x.PerCustomerLimit
+	}
+	return 0
+}
+
+func (x *Coupon) GetTimesUsed() int32 {
+	if x != nil {
+		return x.TimesUsed
+	}
+	return 0
+}
+
+func (x *Coupon) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Coupon) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Coupon) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Coupon) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Coupon) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Redemption is a coupon applied to a checkout
+type Redemption struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CouponId         string                 `protobuf:"bytes,2,opt,name=coupon_id,json=couponId,proto3" json:"coupon_id,omitempty"`
+	Code             string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	UserId           string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reference        string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"` // caller's checkout or order ID
+	Discount         float64                `protobuf:"fixed64,6,opt,name=discount,proto3" json:"discount,omitempty"` // off the items
+	ShippingDiscount float64                `protobuf:"fixed64,7,opt,name=shipping_discount,json=shippingDiscount,proto3" json:"shipping_discount,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReleasedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"` // set when the checkout was undone
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Redemption) Reset() {
+	*x = Redemption{}
+	mi := &file_promotion_promotion_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Redemption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Redemption) ProtoMessage() {}
+
+func (x *Redemption) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Redemption.ProtoReflect.Descriptor instead.
+func (*Redemption) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Redemption) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Redemption) GetCouponId() string {
+	if x != nil {
+		return x.CouponId
+	}
+	return ""
+}
+
+func (x *Redemption) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Redemption) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Redemption) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Redemption) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *Redemption) GetShippingDiscount() float64 {
+	if x != nil {
+		return x.ShippingDiscount
+	}
+	return 0
+}
+
+func (x *Redemption) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Redemption) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+// CartLine is a priced line of the cart a coupon is applied to
+type CartLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartLine) Reset() {
+	*x = CartLine{}
+	mi := &file_promotion_promotion_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartLine) ProtoMessage() {}
+
+func (x *CartLine) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartLine.ProtoReflect.Descriptor instead.
+func (*CartLine) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CartLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CartLine) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CartLine) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *CartLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// LineDiscount is the part of a discount taken off one cart line
+type LineDiscount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Discount      float64                `protobuf:"fixed64,2,opt,name=discount,proto3" json:"discount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineDiscount) Reset() {
+	*x = LineDiscount{}
+	mi := &file_promotion_promotion_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineDiscount) ProtoMessage() {}
+
+func (x *LineDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineDiscount.ProtoReflect.Descriptor instead.
+func (*LineDiscount) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LineDiscount) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *LineDiscount) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+type CreateCouponRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // matched case-insensitively; stored upper case
+	Description      string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type             string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Value            float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	MaxDiscount      float64                `protobuf:"fixed64,5,opt,name=max_discount,json=maxDiscount,proto3" json:"max_discount,omitempty"`
+	MinCartValue     float64                `protobuf:"fixed64,6,opt,name=min_cart_value,json=minCartValue,proto3" json:"min_cart_value,omitempty"`
+	Categories       []string               `protobuf:"bytes,7,rep,name=categories,proto3" json:"categories,omitempty"`
+	CustomerSegments []string               `protobuf:"bytes,8,rep,name=customer_segments,json=customerSegments,proto3" json:"customer_segments,omitempty"`
+	UsageLimit       int32                  `protobuf:"varint,9,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	PerCustomerLimit int32                  `protobuf:"varint,10,opt,name=per_customer_limit,json=perCustomerLimit,proto3" json:"per_customer_limit,omitempty"`
+	StartsAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt           *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateCouponRequest) Reset() {
+	*x = CreateCouponRequest{}
+	mi := &file_promotion_promotion_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCouponRequest) ProtoMessage() {}
+
+func (x *CreateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCouponRequest.ProtoReflect.Descriptor instead.
+func (*CreateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateCouponRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCouponRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateCouponRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CreateCouponRequest) GetMaxDiscount() float64 {
+	if x != nil {
+		return x.MaxDiscount
+	}
+	return 0
+}
+
+func (x *CreateCouponRequest) GetMinCartValue() float64 {
+	if x != nil {
+		return x.MinCartValue
+	}
+	return 0
+}
+
+func (x *CreateCouponRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *CreateCouponRequest) GetCustomerSegments() []string {
+	if x != nil {
+		return x.CustomerSegments
+	}
+	return nil
+}
+
+func (x *CreateCouponRequest) GetUsageLimit() int32 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *CreateCouponRequest) GetPerCustomerLimit() int32 {
+	if x != nil {
+		return x.PerCustomerLimit
+	}
+	return 0
+}
+
+func (x *CreateCouponRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreateCouponRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type CreateCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCouponResponse) Reset() {
+	*x = CreateCouponResponse{}
+	mi := &file_promotion_promotion_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCouponResponse) ProtoMessage() {}
+
+func (x *CreateCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCouponResponse.ProtoReflect.Descriptor instead.
+func (*CreateCouponResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// GetCoupon looks a coupon up by ID or by code
+type GetCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CouponId      string                 `protobuf:"bytes,1,opt,name=coupon_id,json=couponId,proto3" json:"coupon_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCouponRequest) Reset() {
+	*x = GetCouponRequest{}
+	mi := &file_promotion_promotion_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCouponRequest) ProtoMessage() {}
+
+func (x *GetCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCouponRequest.ProtoReflect.Descriptor instead.
+func (*GetCouponRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCouponRequest) GetCouponId() string {
+	if x != nil {
+		return x.CouponId
+	}
+	return ""
+}
+
+func (x *GetCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type GetCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCouponResponse) Reset() {
+	*x = GetCouponResponse{}
+	mi := &file_promotion_promotion_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCouponResponse) ProtoMessage() {}
+
+func (x *GetCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCouponResponse.ProtoReflect.Descriptor instead.
+func (*GetCouponResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// ListCoupons lists coupons, newest first
+type ListCouponsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouponsRequest) Reset() {
+	*x = ListCouponsRequest{}
+	mi := &file_promotion_promotion_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouponsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouponsRequest) ProtoMessage() {}
+
+func (x *ListCouponsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouponsRequest.ProtoReflect.Descriptor instead.
+func (*ListCouponsRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListCouponsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+func (x *ListCouponsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCouponsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListCouponsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupons       []*Coupon              `protobuf:"bytes,1,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouponsResponse) Reset() {
+	*x = ListCouponsResponse{}
+	mi := &file_promotion_promotion_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouponsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouponsResponse) ProtoMessage() {}
+
+func (x *ListCouponsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouponsResponse.ProtoReflect.Descriptor instead.
+func (*ListCouponsResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCouponsResponse) GetCoupons() []*Coupon {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *ListCouponsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListCouponsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCouponsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// SetCouponActive switches a coupon on or off
+type SetCouponActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CouponId      string                 `protobuf:"bytes,1,opt,name=coupon_id,json=couponId,proto3" json:"coupon_id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCouponActiveRequest) Reset() {
+	*x = SetCouponActiveRequest{}
+	mi := &file_promotion_promotion_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCouponActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCouponActiveRequest) ProtoMessage() {}
+
+func (x *SetCouponActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCouponActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetCouponActiveRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetCouponActiveRequest) GetCouponId() string {
+	if x != nil {
+		return x.CouponId
+	}
+	return ""
+}
+
+func (x *SetCouponActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type SetCouponActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCouponActiveResponse) Reset() {
+	*x = SetCouponActiveResponse{}
+	mi := &file_promotion_promotion_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCouponActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCouponActiveResponse) ProtoMessage() {}
+
+func (x *SetCouponActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCouponActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetCouponActiveResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetCouponActiveResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// ValidateAndApply checks a coupon against a cart and works out the
+// discount. Unless dry_run is set, the coupon is redeemed for the reference:
+// it counts towards the coupon's usage limits until the redemption is
+// released. Applying the same coupon to the same reference again returns
+// the first redemption.
+type ValidateAndApplyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Code            string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CustomerSegment string                 `protobuf:"bytes,3,opt,name=customer_segment,json=customerSegment,proto3" json:"customer_segment,omitempty"` // segment of the customer; empty for none
+	Items           []*CartLine            `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	ShippingAmount  float64                `protobuf:"fixed64,5,opt,name=shipping_amount,json=shippingAmount,proto3" json:"shipping_amount,omitempty"`
+	Reference       string                 `protobuf:"bytes,6,opt,name=reference,proto3" json:"reference,omitempty"`          // checkout or order ID; required unless dry_run
+	DryRun          bool                   `protobuf:"varint,7,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // validate and price without redeeming
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidateAndApplyRequest) Reset() {
+	*x = ValidateAndApplyRequest{}
+	mi := &file_promotion_promotion_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAndApplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAndApplyRequest) ProtoMessage() {}
+
+func (x *ValidateAndApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAndApplyRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAndApplyRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ValidateAndApplyRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidateAndApplyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ValidateAndApplyRequest) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *ValidateAndApplyRequest) GetItems() []*CartLine {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ValidateAndApplyRequest) GetShippingAmount() float64 {
+	if x != nil {
+		return x.ShippingAmount
+	}
+	return 0
+}
+
+func (x *ValidateAndApplyRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ValidateAndApplyRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ValidateAndApplyResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Coupon           *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Subtotal         float64                `protobuf:"fixed64,2,opt,name=subtotal,proto3" json:"subtotal,omitempty"` // of the cart before the discount
+	Discount         float64                `protobuf:"fixed64,3,opt,name=discount,proto3" json:"discount,omitempty"` // off the items
+	ShippingDiscount float64                `protobuf:"fixed64,4,opt,name=shipping_discount,json=shippingDiscount,proto3" json:"shipping_discount,omitempty"`
+	TotalDiscount    float64                `protobuf:"fixed64,5,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"`
+	Total            float64                `protobuf:"fixed64,6,opt,name=total,proto3" json:"total,omitempty"`                                    // subtotal plus shipping, less the total discount
+	LineDiscounts    []*LineDiscount        `protobuf:"bytes,7,rep,name=line_discounts,json=lineDiscounts,proto3" json:"line_discounts,omitempty"` // for the lines the discount applies to
+	Redemption       *Redemption            `protobuf:"bytes,8,opt,name=redemption,proto3" json:"redemption,omitempty"`                            // unset for a dry run
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ValidateAndApplyResponse) Reset() {
+	*x = ValidateAndApplyResponse{}
+	mi := &file_promotion_promotion_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAndApplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAndApplyResponse) ProtoMessage() {}
+
+func (x *ValidateAndApplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAndApplyResponse.ProtoReflect.Descriptor instead.
+func (*ValidateAndApplyResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ValidateAndApplyResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *ValidateAndApplyResponse) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *ValidateAndApplyResponse) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *ValidateAndApplyResponse) GetShippingDiscount() float64 {
+	if x != nil {
+		return x.ShippingDiscount
+	}
+	return 0
+}
+
+func (x *ValidateAndApplyResponse) GetTotalDiscount() float64 {
+	if x != nil {
+		return x.TotalDiscount
+	}
+	return 0
+}
+
+func (x *ValidateAndApplyResponse) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ValidateAndApplyResponse) GetLineDiscounts() []*LineDiscount {
+	if x != nil {
+		return x.LineDiscounts
+	}
+	return nil
+}
+
+func (x *ValidateAndApplyResponse) GetRedemption() *Redemption {
+	if x != nil {
+		return x.Redemption
+	}
+	return nil
+}
+
+// ReleaseRedemption gives back a redemption when the checkout it was made
+// for is undone. Releasing a released redemption does nothing.
+type ReleaseRedemptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedemptionId  string                 `protobuf:"bytes,1,opt,name=redemption_id,json=redemptionId,proto3" json:"redemption_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRedemptionRequest) Reset() {
+	*x = ReleaseRedemptionRequest{}
+	mi := &file_promotion_promotion_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRedemptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRedemptionRequest) ProtoMessage() {}
+
+func (x *ReleaseRedemptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRedemptionRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRedemptionRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReleaseRedemptionRequest) GetRedemptionId() string {
+	if x != nil {
+		return x.RedemptionId
+	}
+	return ""
+}
+
+type ReleaseRedemptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Redemption    *Redemption            `protobuf:"bytes,1,opt,name=redemption,proto3" json:"redemption,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRedemptionResponse) Reset() {
+	*x = ReleaseRedemptionResponse{}
+	mi := &file_promotion_promotion_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRedemptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRedemptionResponse) ProtoMessage() {}
+
+func (x *ReleaseRedemptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_promotion_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRedemptionResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseRedemptionResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_promotion_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReleaseRedemptionResponse) GetRedemption() *Redemption {
+	if x != nil {
+		return x.Redemption
+	}
+	return nil
+}
+
+var File_promotion_promotion_proto protoreflect.FileDescriptor
+
+const file_promotion_promotion_proto_rawDesc = "" +
+	"\n" +
+	"\x19promotion/promotion.proto\x12\tpromotion\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf8\x04\n" +
+	"\x06Coupon\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x01R\x05value\x12!\n" +
+	"\fmax_discount\x18\x06 \x01(\x01R\vmaxDiscount\x12$\n" +
+	"\x0emin_cart_value\x18\a \x01(\x01R\fminCartValue\x12\x1e\n" +
+	"\n" +
+	"categories\x18\b \x03(\tR\n" +
+	"categories\x12+\n" +
+	"\x11customer_segments\x18\t \x03(\tR\x10customerSegments\x12\x1f\n" +
+	"\vusage_limit\x18\n" +
+	" \x01(\x05R\n" +
+	"usageLimit\x12,\n" +
+	"\x12per_customer_limit\x18\v \x01(\x05R\x10perCustomerLimit\x12\x1d\n" +
+	"\n" +
+	"times_used\x18\f \x01(\x05R\ttimesUsed\x127\n" +
+	"\tstarts_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x16\n" +
+	"\x06active\x18\x0f \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc5\x02\n" +
+	"\n" +
+	"Redemption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tcoupon_id\x18\x02 \x01(\tR\bcouponId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12\x1a\n" +
+	"\bdiscount\x18\x06 \x01(\x01R\bdiscount\x12+\n" +
+	"\x11shipping_discount\x18\a \x01(\x01R\x10shippingDiscount\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreleased_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\"\x80\x01\n" +
+	"\bCartLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"I\n" +
+	"\fLineDiscount\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bdiscount\x18\x02 \x01(\x01R\bdiscount\"\xc8\x03\n" +
+	"\x13CreateCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12!\n" +
+	"\fmax_discount\x18\x05 \x01(\x01R\vmaxDiscount\x12$\n" +
+	"\x0emin_cart_value\x18\x06 \x01(\x01R\fminCartValue\x12\x1e\n" +
+	"\n" +
+	"categories\x18\a \x03(\tR\n" +
+	"categories\x12+\n" +
+	"\x11customer_segments\x18\b \x03(\tR\x10customerSegments\x12\x1f\n" +
+	"\vusage_limit\x18\t \x01(\x05R\n" +
+	"usageLimit\x12,\n" +
+	"\x12per_customer_limit\x18\n" +
+	" \x01(\x05R\x10perCustomerLimit\x127\n" +
+	"\tstarts_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\"A\n" +
+	"\x14CreateCouponResponse\x12)\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x11.promotion.CouponR\x06coupon\"C\n" +
+	"\x10GetCouponRequest\x12\x1b\n" +
+	"\tcoupon_id\x18\x01 \x01(\tR\bcouponId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\">\n" +
+	"\x11GetCouponResponse\x12)\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x11.promotion.CouponR\x06coupon\"f\n" +
+	"\x12ListCouponsRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x89\x01\n" +
+	"\x13ListCouponsResponse\x12+\n" +
+	"\acoupons\x18\x01 \x03(\v2\x11.promotion.CouponR\acoupons\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"M\n" +
+	"\x16SetCouponActiveRequest\x12\x1b\n" +
+	"\tcoupon_id\x18\x01 \x01(\tR\bcouponId\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"D\n" +
+	"\x17SetCouponActiveResponse\x12)\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x11.promotion.CouponR\x06coupon\"\xfc\x01\n" +
+	"\x17ValidateAndApplyRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12)\n" +
+	"\x10customer_segment\x18\x03 \x01(\tR\x0fcustomerSegment\x12)\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.promotion.CartLineR\x05items\x12'\n" +
+	"\x0fshipping_amount\x18\x05 \x01(\x01R\x0eshippingAmount\x12\x1c\n" +
+	"\treference\x18\x06 \x01(\tR\treference\x12\x17\n" +
+	"\adry_run\x18\a \x01(\bR\x06dryRun\"\xde\x02\n" +
+	"\x18ValidateAndApplyResponse\x12)\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x11.promotion.CouponR\x06coupon\x12\x1a\n" +
+	"\bsubtotal\x18\x02 \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\x03 \x01(\x01R\bdiscount\x12+\n" +
+	"\x11shipping_discount\x18\x04 \x01(\x01R\x10shippingDiscount\x12%\n" +
+	"\x0etotal_discount\x18\x05 \x01(\x01R\rtotalDiscount\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x01R\x05total\x12>\n" +
+	"\x0eline_discounts\x18\a \x03(\v2\x17.promotion.LineDiscountR\rlineDiscounts\x125\n" +
+	"\n" +
+	"redemption\x18\b \x01(\v2\x15.promotion.RedemptionR\n" +
+	"redemption\"?\n" +
+	"\x18ReleaseRedemptionRequest\x12#\n" +
+	"\rredemption_id\x18\x01 \x01(\tR\fredemptionId\"R\n" +
+	"\x19ReleaseRedemptionResponse\x125\n" +
+	"\n" +
+	"redemption\x18\x01 \x01(\v2\x15.promotion.RedemptionR\n" +
+	"redemption2\x90\x04\n" +
+	"\x10PromotionService\x12O\n" +
+	"\fCreateCoupon\x12\x1e.promotion.CreateCouponRequest\x1a\x1f.promotion.CreateCouponResponse\x12F\n" +
+	"\tGetCoupon\x12\x1b.promotion.GetCouponRequest\x1a\x1c.promotion.GetCouponResponse\x12L\n" +
+	"\vListCoupons\x12\x1d.promotion.ListCouponsRequest\x1a\x1e.promotion.ListCouponsResponse\x12X\n" +
+	"\x0fSetCouponActive\x12!.promotion.SetCouponActiveRequest\x1a\".promotion.SetCouponActiveResponse\x12[\n" +
+	"\x10ValidateAndApply\x12\".promotion.ValidateAndApplyRequest\x1a#.promotion.ValidateAndApplyResponse\x12^\n" +
+	"\x11ReleaseRedemption\x12#.promotion.ReleaseRedemptionRequest\x1a$.promotion.ReleaseRedemptionResponseB9Z7github.com/Ujjwaljain16/E-commerce-Backend/promotion/pbb\x06proto3"
+
+var (
+	file_promotion_promotion_proto_rawDescOnce sync.Once
+	file_promotion_promotion_proto_rawDescData []byte
+)
+
+func file_promotion_promotion_proto_rawDescGZIP() []byte {
+	file_promotion_promotion_proto_rawDescOnce.Do(func() {
+		file_promotion_promotion_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_promotion_promotion_proto_rawDesc), len(file_promotion_promotion_proto_rawDesc)))
+	})
+	return file_promotion_promotion_proto_rawDescData
+}
+
+var file_promotion_promotion_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_promotion_promotion_proto_goTypes = []any{
+	(*Coupon)(nil),                    // 0: promotion.Coupon
+	(*Redemption)(nil),                // 1: promotion.Redemption
+	(*CartLine)(nil),                  // 2: promotion.CartLine
+	(*LineDiscount)(nil),              // 3: promotion.LineDiscount
+	(*CreateCouponRequest)(nil),       // 4: promotion.CreateCouponRequest
+	(*CreateCouponResponse)(nil),      // 5: promotion.CreateCouponResponse
+	(*GetCouponRequest)(nil),          // 6: promotion.GetCouponRequest
+	(*GetCouponResponse)(nil),         // 7: promotion.GetCouponResponse
+	(*ListCouponsRequest)(nil),        // 8: promotion.ListCouponsRequest
+	(*ListCouponsResponse)(nil),       // 9: promotion.ListCouponsResponse
+	(*SetCouponActiveRequest)(nil),    // 10: promotion.SetCouponActiveRequest
+	(*SetCouponActiveResponse)(nil),   // 11: promotion.SetCouponActiveResponse
+	(*ValidateAndApplyRequest)(nil),   // 12: promotion.ValidateAndApplyRequest
+	(*ValidateAndApplyResponse)(nil),  // 13: promotion.ValidateAndApplyResponse
+	(*ReleaseRedemptionRequest)(nil),  // 14: promotion.ReleaseRedemptionRequest
+	(*ReleaseRedemptionResponse)(nil), // 15: promotion.ReleaseRedemptionResponse
+	(*timestamppb.Timestamp)(nil),     // 16: google.protobuf.Timestamp
+}
+var file_promotion_promotion_proto_depIdxs = []int32{
+	16, // 0: promotion.Coupon.starts_at:type_name -> google.protobuf.Timestamp
+	16, // 1: promotion.Coupon.ends_at:type_name -> google.protobuf.Timestamp
+	16, // 2: promotion.Coupon.created_at:type_name -> google.protobuf.Timestamp
+	16, // 3: promotion.Coupon.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 4: promotion.Redemption.created_at:type_name -> google.protobuf.Timestamp
+	16, // 5: promotion.Redemption.released_at:type_name -> google.protobuf.Timestamp
+	16, // 6: promotion.CreateCouponRequest.starts_at:type_name -> google.protobuf.Timestamp
+	16, // 7: promotion.CreateCouponRequest.ends_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: promotion.CreateCouponResponse.coupon:type_name -> promotion.Coupon
+	0,  // 9: promotion.GetCouponResponse.coupon:type_name -> promotion.Coupon
+	0,  // 10: promotion.ListCouponsResponse.coupons:type_name -> promotion.Coupon
+	0,  // 11: promotion.SetCouponActiveResponse.coupon:type_name -> promotion.Coupon
+	2,  // 12: promotion.ValidateAndApplyRequest.items:type_name -> promotion.CartLine
+	0,  // 13: promotion.ValidateAndApplyResponse.coupon:type_name -> promotion.Coupon
+	3,  // 14: promotion.ValidateAndApplyResponse.line_discounts:type_name -> promotion.LineDiscount
+	1,  // 15: promotion.ValidateAndApplyResponse.redemption:type_name -> promotion.Redemption
+	1,  // 16: promotion.ReleaseRedemptionResponse.redemption:type_name -> promotion.Redemption
+	4,  // 17: promotion.PromotionService.CreateCoupon:input_type -> promotion.CreateCouponRequest
+	6,  // 18: promotion.PromotionService.GetCoupon:input_type -> promotion.GetCouponRequest
+	8,  // 19: promotion.PromotionService.ListCoupons:input_type -> promotion.ListCouponsRequest
+	10, // 20: promotion.PromotionService.SetCouponActive:input_type -> promotion.SetCouponActiveRequest
+	12, // 21: promotion.PromotionService.ValidateAndApply:input_type -> promotion.ValidateAndApplyRequest
+	14, // 22: promotion.PromotionService.ReleaseRedemption:input_type -> promotion.ReleaseRedemptionRequest
+	5,  // 23: promotion.PromotionService.CreateCoupon:output_type -> promotion.CreateCouponResponse
+	7,  // 24: promotion.PromotionService.GetCoupon:output_type -> promotion.GetCouponResponse
+	9,  // 25: promotion.PromotionService.ListCoupons:output_type -> promotion.ListCouponsResponse
+	11, // 26: promotion.PromotionService.SetCouponActive:output_type -> promotion.SetCouponActiveResponse
+	13, // 27: promotion.PromotionService.ValidateAndApply:output_type -> promotion.ValidateAndApplyResponse
+	15, // 28: promotion.PromotionService.ReleaseRedemption:output_type -> promotion.ReleaseRedemptionResponse
+	23, // [23:29] is the sub-list for method output_type
+	17, // [17:23] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_promotion_promotion_proto_init() }
+func file_promotion_promotion_proto_init() {
+	if File_promotion_promotion_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_promotion_promotion_proto_rawDesc), len(file_promotion_promotion_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_promotion_promotion_proto_goTypes,
+		DependencyIndexes: file_promotion_promotion_proto_depIdxs,
+		MessageInfos:      file_promotion_promotion_proto_msgTypes,
+	}.Build()
+	File_promotion_promotion_proto = out.File
+	file_promotion_promotion_proto_goTypes = nil
+	file_promotion_promotion_proto_depIdxs = nil
+}
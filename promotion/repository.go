@@ -0,0 +1,412 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Coupon types
+const (
+	TypePercentage   = "PERCENTAGE"
+	TypeFixed        = "FIXED"
+	TypeFreeShipping = "FREE_SHIPPING"
+)
+
+var (
+	// ErrCouponNotFound is returned when a coupon does not exist
+	ErrCouponNotFound = errors.New("coupon not found")
+	// ErrDuplicateCode is returned when a coupon code is already taken
+	ErrDuplicateCode = errors.New("coupon code already exists")
+	// ErrRedemptionNotFound is returned when a redemption does not exist
+	ErrRedemptionNotFound = errors.New("redemption not found")
+	// ErrReferenceInUse is returned when a checkout reference has already
+	// redeemed a coupon for another customer
+	ErrReferenceInUse = errors.New("reference already redeemed the coupon for another customer")
+)
+
+// codeConstraint is the unique constraint on coupons(code)
+const codeConstraint = "coupons_code_key"
+
+// Coupon is a promotion code and the rules of the carts it applies to
+type Coupon struct {
+	ID          string
+	Code        string
+	Description string
+	Type        string
+	// Value is the percentage off for PERCENTAGE coupons and the amount off
+	// for FIXED coupons
+	Value float64
+	// MaxDiscount caps a PERCENTAGE discount; 0 for no cap
+	MaxDiscount  float64
+	MinCartValue float64
+	// Categories limits the discount to lines of these categories; empty for all
+	Categories []string
+	// CustomerSegments limits the coupon to these segments; empty for everyone
+	CustomerSegments []string
+	// UsageLimit and PerCustomerLimit cap redemptions; 0 for unlimited
+	UsageLimit       int32
+	PerCustomerLimit int32
+	TimesUsed        int32
+	StartsAt         *time.Time
+	EndsAt           *time.Time
+	Active           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Redemption is a coupon applied to a checkout
+type Redemption struct {
+	ID               string
+	CouponID         string
+	Code             string
+	UserID           string
+	Reference        string
+	Discount         float64
+	ShippingDiscount float64
+	CreatedAt        time.Time
+	ReleasedAt       *time.Time
+}
+
+// Repository handles coupon data persistence
+type Repository interface {
+	// Create inserts a coupon, or returns ErrDuplicateCode
+	Create(ctx context.Context, coupon *Coupon) (*Coupon, error)
+	GetByID(ctx context.Context, id string) (*Coupon, error)
+	GetByCode(ctx context.Context, code string) (*Coupon, error)
+	List(ctx context.Context, activeOnly bool, page, pageSize int32) ([]*Coupon, int32, error)
+	SetActive(ctx context.Context, id string, active bool, at time.Time) (*Coupon, error)
+	// CountRedemptions counts a customer's unreleased redemptions of a coupon
+	CountRedemptions(ctx context.Context, couponID, userID string) (int32, error)
+	// Redeem records a redemption and counts it against the coupon's limits,
+	// returning ErrUsageLimitReached or ErrCustomerLimitReached when a limit
+	// is used up. When the reference has already redeemed the coupon, that
+	// redemption is returned instead.
+	Redeem(ctx context.Context, redemption *Redemption) (*Redemption, error)
+	// Release marks a redemption released and gives it back to the coupon's
+	// limits; a released redemption is returned unchanged
+	Release(ctx context.Context, id string, at time.Time) (*Redemption, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL coupon repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const couponColumns = `id, code, description, type, value, max_discount, min_cart_value, categories, customer_segments,
+	usage_limit, per_customer_limit, times_used, starts_at, ends_at, active, created_at, updated_at`
+
+const redemptionColumns = `r.id, r.coupon_id, c.code, r.user_id, r.reference, r.discount, r.shipping_discount,
+	r.created_at, r.released_at`
+
+// Create inserts a coupon
+func (r *postgresRepository) Create(ctx context.Context, coupon *Coupon) (*Coupon, error) {
+	coupon.ID = uuid.New().String()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO coupons (id, code, description, type, value, max_discount, min_cart_value, categories,
+			customer_segments, usage_limit, per_customer_limit, starts_at, ends_at, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`, coupon.ID, coupon.Code, coupon.Description, coupon.Type, coupon.Value, coupon.MaxDiscount, coupon.MinCartValue,
+		pq.Array(coupon.Categories), pq.Array(coupon.CustomerSegments), coupon.UsageLimit, coupon.PerCustomerLimit,
+		coupon.StartsAt, coupon.EndsAt, coupon.Active, coupon.CreatedAt, coupon.UpdatedAt)
+	if pgerr.IsUniqueViolation(err, codeConstraint) {
+		return nil, ErrDuplicateCode
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to create coupon", map[string]interface{}{"error": err.Error(), "code": coupon.Code})
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+
+	r.log.Info(ctx, "Coupon created", map[string]interface{}{"coupon_id": coupon.ID, "code": coupon.Code})
+	return coupon, nil
+}
+
+// GetByID retrieves a coupon
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Coupon, error) {
+	query := "SELECT " + couponColumns + " FROM coupons WHERE id = $1"
+
+	coupon, err := scanCoupon(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get coupon", map[string]interface{}{"error": err.Error(), "coupon_id": id})
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+	return coupon, nil
+}
+
+// GetByCode retrieves a coupon by its code
+func (r *postgresRepository) GetByCode(ctx context.Context, code string) (*Coupon, error) {
+	query := "SELECT " + couponColumns + " FROM coupons WHERE code = $1"
+
+	coupon, err := scanCoupon(r.db.QueryRowContext(ctx, query, code))
+	if err == sql.ErrNoRows {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get coupon", map[string]interface{}{"error": err.Error(), "code": code})
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+	return coupon, nil
+}
+
+// List retrieves coupons with pagination
+func (r *postgresRepository) List(ctx context.Context, activeOnly bool, page, pageSize int32) ([]*Coupon, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM coupons WHERE active OR NOT $1", activeOnly).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count coupons", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count coupons: %w", err)
+	}
+
+	query := `
+		SELECT ` + couponColumns + `
+		FROM coupons
+		WHERE active OR NOT $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, activeOnly, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list coupons", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list coupons: %w", err)
+	}
+	defer rows.Close()
+
+	coupons := make([]*Coupon, 0, pageSize)
+	for rows.Next() {
+		coupon, err := scanCoupon(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan coupon", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan coupon: %w", err)
+		}
+		coupons = append(coupons, coupon)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating coupons", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating coupons: %w", err)
+	}
+
+	return coupons, total, nil
+}
+
+// SetActive switches a coupon on or off
+func (r *postgresRepository) SetActive(ctx context.Context, id string, active bool, at time.Time) (*Coupon, error) {
+	query := "UPDATE coupons SET active = $2, updated_at = $3 WHERE id = $1 RETURNING " + couponColumns
+
+	coupon, err := scanCoupon(r.db.QueryRowContext(ctx, query, id, active, at))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update coupon", map[string]interface{}{"error": err.Error(), "coupon_id": id})
+		return nil, fmt.Errorf("failed to update coupon: %w", err)
+	}
+
+	r.log.Info(ctx, "Coupon updated", map[string]interface{}{"coupon_id": id, "active": active})
+	return coupon, nil
+}
+
+// CountRedemptions counts a customer's unreleased redemptions of a coupon
+func (r *postgresRepository) CountRedemptions(ctx context.Context, couponID, userID string) (int32, error) {
+	var count int32
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM coupon_redemptions
+		WHERE coupon_id = $1 AND user_id = $2 AND released_at IS NULL
+	`, couponID, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count redemptions: %w", err)
+	}
+	return count, nil
+}
+
+// Redeem records a redemption in one transaction with the coupon locked, so
+// concurrent checkouts cannot redeem past its limits
+func (r *postgresRepository) Redeem(ctx context.Context, redemption *Redemption) (*Redemption, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var code string
+	var usageLimit, perCustomerLimit, timesUsed int32
+	err = tx.QueryRowContext(ctx, `
+		SELECT code, usage_limit, per_customer_limit, times_used FROM coupons WHERE id = $1 FOR UPDATE
+	`, redemption.CouponID).Scan(&code, &usageLimit, &perCustomerLimit, &timesUsed)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock coupon: %w", err)
+	}
+
+	// A retried checkout gets the redemption it already made
+	query := "SELECT " + redemptionColumns + `
+		FROM coupon_redemptions r JOIN coupons c ON c.id = r.coupon_id
+		WHERE r.coupon_id = $1 AND r.reference = $2 AND r.released_at IS NULL`
+	existing, err := scanRedemption(tx.QueryRowContext(ctx, query, redemption.CouponID, redemption.Reference))
+	if err == nil {
+		if existing.UserID != redemption.UserID {
+			return nil, ErrReferenceInUse
+		}
+		return existing, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get redemption: %w", err)
+	}
+
+	if usageLimit > 0 && timesUsed >= usageLimit {
+		return nil, ErrUsageLimitReached
+	}
+	if perCustomerLimit > 0 {
+		var count int32
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM coupon_redemptions
+			WHERE coupon_id = $1 AND user_id = $2 AND released_at IS NULL
+		`, redemption.CouponID, redemption.UserID).Scan(&count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count redemptions: %w", err)
+		}
+		if count >= perCustomerLimit {
+			return nil, ErrCustomerLimitReached
+		}
+	}
+
+	redemption.ID = uuid.New().String()
+	redemption.Code = code
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coupon_redemptions (id, coupon_id, user_id, reference, discount, shipping_discount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, redemption.ID, redemption.CouponID, redemption.UserID, redemption.Reference, redemption.Discount,
+		redemption.ShippingDiscount, redemption.CreatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record redemption", map[string]interface{}{"error": err.Error(), "coupon_id": redemption.CouponID})
+		return nil, fmt.Errorf("failed to record redemption: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE coupons SET times_used = times_used + 1 WHERE id = $1", redemption.CouponID); err != nil {
+		return nil, fmt.Errorf("failed to count redemption: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Coupon redeemed", map[string]interface{}{"redemption_id": redemption.ID, "code": code, "reference": redemption.Reference})
+	return redemption, nil
+}
+
+// Release marks a redemption released and decrements its coupon's usage in
+// one transaction
+func (r *postgresRepository) Release(ctx context.Context, id string, at time.Time) (*Redemption, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT " + redemptionColumns + `
+		FROM coupon_redemptions r JOIN coupons c ON c.id = r.coupon_id
+		WHERE r.id = $1
+		FOR UPDATE OF r`
+	redemption, err := scanRedemption(tx.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrRedemptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock redemption: %w", err)
+	}
+	if redemption.ReleasedAt != nil {
+		return redemption, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE coupon_redemptions SET released_at = $2 WHERE id = $1", id, at); err != nil {
+		r.log.Error(ctx, "Failed to release redemption", map[string]interface{}{"error": err.Error(), "redemption_id": id})
+		return nil, fmt.Errorf("failed to release redemption: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE coupons SET times_used = times_used - 1 WHERE id = $1", redemption.CouponID); err != nil {
+		return nil, fmt.Errorf("failed to give back redemption: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	redemption.ReleasedAt = &at
+	r.log.Info(ctx, "Redemption released", map[string]interface{}{"redemption_id": id, "code": redemption.Code})
+	return redemption, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCoupon scans a coupon selected with couponColumns
+func scanCoupon(row rowScanner) (*Coupon, error) {
+	c := &Coupon{}
+	var startsAt, endsAt sql.NullTime
+
+	err := row.Scan(&c.ID, &c.Code, &c.Description, &c.Type, &c.Value, &c.MaxDiscount, &c.MinCartValue,
+		pq.Array(&c.Categories), pq.Array(&c.CustomerSegments), &c.UsageLimit, &c.PerCustomerLimit, &c.TimesUsed,
+		&startsAt, &endsAt, &c.Active, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startsAt.Valid {
+		c.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		c.EndsAt = &endsAt.Time
+	}
+	return c, nil
+}
+
+// scanRedemption scans a redemption selected with redemptionColumns
+func scanRedemption(row rowScanner) (*Redemption, error) {
+	r := &Redemption{}
+	var releasedAt sql.NullTime
+
+	err := row.Scan(&r.ID, &r.CouponID, &r.Code, &r.UserID, &r.Reference, &r.Discount, &r.ShippingDiscount,
+		&r.CreatedAt, &releasedAt)
+	if err != nil {
+		return nil, err
+	}
+	if releasedAt.Valid {
+		r.ReleasedAt = &releasedAt.Time
+	}
+	return r, nil
+}
@@ -0,0 +1,147 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("promotion-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var redemptionColumnNames = []string{
+	"id", "coupon_id", "code", "user_id", "reference", "discount", "shipping_discount", "created_at", "released_at",
+}
+
+func TestCreate_DuplicateCode(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO coupons`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: codeConstraint})
+
+	_, err := repo.Create(context.Background(), &Coupon{Code: "SAVE5", Type: TypeFixed, Value: 5})
+	if err != ErrDuplicateCode {
+		t.Errorf("Expected ErrDuplicateCode, got %v", err)
+	}
+}
+
+func TestRedeem_UsageLimitReached(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT code, usage_limit, per_customer_limit, times_used FROM coupons WHERE id = \$1 FOR UPDATE`).
+		WithArgs("coupon-1").
+		WillReturnRows(sqlmock.NewRows([]string{"code", "usage_limit", "per_customer_limit", "times_used"}).AddRow("ONCE", 1, 0, 1))
+	mock.ExpectQuery(`FROM coupon_redemptions r JOIN coupons c ON c.id = r.coupon_id\s+WHERE r.coupon_id = \$1 AND r.reference = \$2 AND r.released_at IS NULL`).
+		WithArgs("coupon-1", "checkout-2").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := repo.Redeem(context.Background(), &Redemption{CouponID: "coupon-1", UserID: "user-1", Reference: "checkout-2"})
+	if err != ErrUsageLimitReached {
+		t.Errorf("Expected ErrUsageLimitReached, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRedeem_ReturnsExistingRedemption(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT code, usage_limit, per_customer_limit, times_used FROM coupons`).
+		WillReturnRows(sqlmock.NewRows([]string{"code", "usage_limit", "per_customer_limit", "times_used"}).AddRow("ONCE", 1, 1, 1))
+	mock.ExpectQuery(`FROM coupon_redemptions r JOIN coupons c`).
+		WillReturnRows(sqlmock.NewRows(redemptionColumnNames).
+			AddRow("redemption-1", "coupon-1", "ONCE", "user-1", "checkout-1", 5.0, 0.0, now, nil))
+	mock.ExpectRollback()
+
+	redemption, err := repo.Redeem(context.Background(), &Redemption{CouponID: "coupon-1", UserID: "user-1", Reference: "checkout-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if redemption.ID != "redemption-1" || redemption.Discount != 5 {
+		t.Errorf("Expected the existing redemption, got %+v", redemption)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRedeem(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT code, usage_limit, per_customer_limit, times_used FROM coupons`).
+		WillReturnRows(sqlmock.NewRows([]string{"code", "usage_limit", "per_customer_limit", "times_used"}).AddRow("SAVE5", 0, 2, 7))
+	mock.ExpectQuery(`FROM coupon_redemptions r JOIN coupons c`).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM coupon_redemptions\s+WHERE coupon_id = \$1 AND user_id = \$2 AND released_at IS NULL`).
+		WithArgs("coupon-1", "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`INSERT INTO coupon_redemptions`).
+		WithArgs(sqlmock.AnyArg(), "coupon-1", "user-1", "checkout-1", 5.0, 0.0, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE coupons SET times_used = times_used \+ 1 WHERE id = \$1`).
+		WithArgs("coupon-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	redemption, err := repo.Redeem(context.Background(), &Redemption{
+		CouponID: "coupon-1", UserID: "user-1", Reference: "checkout-1", Discount: 5, CreatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if redemption.ID == "" || redemption.Code != "SAVE5" {
+		t.Errorf("Unexpected redemption %+v", redemption)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRelease_AlreadyReleased(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM coupon_redemptions r JOIN coupons c ON c.id = r.coupon_id\s+WHERE r.id = \$1\s+FOR UPDATE OF r`).
+		WithArgs("redemption-1").
+		WillReturnRows(sqlmock.NewRows(redemptionColumnNames).
+			AddRow("redemption-1", "coupon-1", "ONCE", "user-1", "checkout-1", 5.0, 0.0, now, now))
+	mock.ExpectRollback()
+
+	redemption, err := repo.Release(context.Background(), "redemption-1", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !redemption.ReleasedAt.Equal(now) {
+		t.Errorf("Expected the redemption unchanged, got %+v", redemption)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
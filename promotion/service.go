@@ -0,0 +1,478 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/promotion/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxDescriptionLength matches the description column of coupons
+	maxDescriptionLength = 255
+	// maxIDLength matches the user_id and reference columns of redemptions
+	maxIDLength = 255
+	// maxRuleValues caps the categories and customer segments of a coupon
+	maxRuleValues = 50
+	// maxRuleValueLength caps the length of a category or segment name
+	maxRuleValueLength = 100
+	// maxCartLines matches the order service's limit on lines per order
+	maxCartLines = 100
+	// maxAmount fits the DECIMAL(10, 2) amount columns
+	maxAmount = 99999999.99
+)
+
+// codePattern is the form of a coupon code once upper-cased
+var codePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// AdminMethods are the admin-scoped RPCs of the promotion service; they are
+// restricted to the admin network allowlist. Checkout applies coupons from
+// inside it.
+var AdminMethods = []string{
+	pb.PromotionService_CreateCoupon_FullMethodName,
+	pb.PromotionService_ListCoupons_FullMethodName,
+	pb.PromotionService_SetCouponActive_FullMethodName,
+	pb.PromotionService_ValidateAndApply_FullMethodName,
+	pb.PromotionService_ReleaseRedemption_FullMethodName,
+}
+
+// Service implements the PromotionService gRPC interface
+type Service struct {
+	pb.UnimplementedPromotionServiceServer
+	repo Repository
+	log  *logger.Logger
+	now  func() time.Time
+}
+
+// NewService creates a new promotion service
+func NewService(repo Repository, log *logger.Logger) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+// CreateCoupon creates an active coupon
+func (s *Service) CreateCoupon(ctx context.Context, req *pb.CreateCouponRequest) (*pb.CreateCouponResponse, error) {
+	coupon, msg := couponFromRequest(req)
+	if msg != "" {
+		s.log.Warn(ctx, "Create coupon failed: "+msg, map[string]interface{}{"code": req.Code})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	now := s.now()
+	coupon.Active = true
+	coupon.CreatedAt = now
+	coupon.UpdatedAt = now
+
+	created, err := s.repo.Create(ctx, coupon)
+	if errors.Is(err, ErrDuplicateCode) {
+		return nil, status.Error(codes.AlreadyExists, "coupon code already exists")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to create coupon", map[string]interface{}{"error": err.Error(), "code": coupon.Code})
+		return nil, status.Error(codes.Internal, "failed to create coupon")
+	}
+
+	return &pb.CreateCouponResponse{
+		Coupon: toProtoCoupon(created),
+	}, nil
+}
+
+// couponFromRequest validates a new coupon and returns it, or a message
+// describing the first problem
+func couponFromRequest(req *pb.CreateCouponRequest) (*Coupon, string) {
+	c := &Coupon{
+		Code:             normalizeCode(req.Code),
+		Description:      strings.TrimSpace(req.Description),
+		Type:             req.Type,
+		Value:            req.Value,
+		MaxDiscount:      req.MaxDiscount,
+		MinCartValue:     req.MinCartValue,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+	}
+	if req.StartsAt != nil {
+		startsAt := req.StartsAt.AsTime()
+		c.StartsAt = &startsAt
+	}
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.AsTime()
+		c.EndsAt = &endsAt
+	}
+
+	switch {
+	case c.Code == "":
+		return nil, "code is required"
+	case !codePattern.MatchString(c.Code):
+		return nil, "code must be 3 to 32 letters, digits, - or _"
+	case utf8.RuneCountInString(c.Description) > maxDescriptionLength:
+		return nil, fmt.Sprintf("description cannot exceed %d characters", maxDescriptionLength)
+	case !isAmount(c.Value) || !isAmount(c.MaxDiscount) || !isAmount(c.MinCartValue):
+		return nil, "value, max_discount and min_cart_value must be amounts between 0 and 99999999.99"
+	case c.UsageLimit < 0 || c.PerCustomerLimit < 0:
+		return nil, "usage limits cannot be negative"
+	case c.UsageLimit > 0 && c.PerCustomerLimit > c.UsageLimit:
+		return nil, "per_customer_limit cannot exceed usage_limit"
+	case c.StartsAt != nil && c.EndsAt != nil && !c.EndsAt.After(*c.StartsAt):
+		return nil, "ends_at must be after starts_at"
+	}
+
+	switch c.Type {
+	case TypePercentage:
+		if c.Value <= 0 || c.Value > 100 {
+			return nil, "a PERCENTAGE coupon's value must be above 0 and at most 100"
+		}
+	case TypeFixed:
+		if c.Value <= 0 {
+			return nil, "a FIXED coupon's value must be positive"
+		}
+		if c.MaxDiscount != 0 {
+			return nil, "max_discount only applies to PERCENTAGE coupons"
+		}
+	case TypeFreeShipping:
+		if c.Value != 0 || c.MaxDiscount != 0 {
+			return nil, "a FREE_SHIPPING coupon has no value or max_discount"
+		}
+	default:
+		return nil, "type must be PERCENTAGE, FIXED or FREE_SHIPPING"
+	}
+
+	var msg string
+	if c.Categories, msg = ruleValues("categories", req.Categories); msg != "" {
+		return nil, msg
+	}
+	if c.CustomerSegments, msg = ruleValues("customer_segments", req.CustomerSegments); msg != "" {
+		return nil, msg
+	}
+	return c, ""
+}
+
+// ruleValues trims and de-duplicates the categories or segments of a coupon
+func ruleValues(field string, values []string) ([]string, string) {
+	if len(values) > maxRuleValues {
+		return nil, fmt.Sprintf("%s cannot have more than %d entries", field, maxRuleValues)
+	}
+	cleaned := []string{}
+	seen := map[string]bool{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, field + " cannot contain empty names"
+		}
+		if utf8.RuneCountInString(v) > maxRuleValueLength {
+			return nil, fmt.Sprintf("%s names cannot exceed %d characters", field, maxRuleValueLength)
+		}
+		if !seen[v] {
+			seen[v] = true
+			cleaned = append(cleaned, v)
+		}
+	}
+	return cleaned, ""
+}
+
+// GetCoupon retrieves a coupon by ID or code
+func (s *Service) GetCoupon(ctx context.Context, req *pb.GetCouponRequest) (*pb.GetCouponResponse, error) {
+	var coupon *Coupon
+	var err error
+	switch {
+	case req.CouponId != "":
+		coupon, err = s.repo.GetByID(ctx, req.CouponId)
+	case req.Code != "":
+		coupon, err = s.repo.GetByCode(ctx, normalizeCode(req.Code))
+	default:
+		s.log.Warn(ctx, "Get coupon failed: coupon ID or code is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "coupon_id or code is required")
+	}
+	if err != nil {
+		return nil, s.couponLookupError(ctx, err)
+	}
+
+	return &pb.GetCouponResponse{
+		Coupon: toProtoCoupon(coupon),
+	}, nil
+}
+
+// ListCoupons lists coupons, newest first
+func (s *Service) ListCoupons(ctx context.Context, req *pb.ListCouponsRequest) (*pb.ListCouponsResponse, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	coupons, total, err := s.repo.List(ctx, req.ActiveOnly, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list coupons", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list coupons")
+	}
+
+	protoCoupons := make([]*pb.Coupon, len(coupons))
+	for i, c := range coupons {
+		protoCoupons[i] = toProtoCoupon(c)
+	}
+
+	return &pb.ListCouponsResponse{
+		Coupons:  protoCoupons,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// SetCouponActive switches a coupon on or off
+func (s *Service) SetCouponActive(ctx context.Context, req *pb.SetCouponActiveRequest) (*pb.SetCouponActiveResponse, error) {
+	if req.CouponId == "" {
+		s.log.Warn(ctx, "Set coupon active failed: coupon ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "coupon_id is required")
+	}
+
+	coupon, err := s.repo.SetActive(ctx, req.CouponId, req.Active, s.now())
+	if err != nil {
+		return nil, s.couponLookupError(ctx, err)
+	}
+
+	return &pb.SetCouponActiveResponse{
+		Coupon: toProtoCoupon(coupon),
+	}, nil
+}
+
+// ValidateAndApply checks a coupon against a cart, works out the discount
+// and, unless it is a dry run, redeems the coupon for the checkout
+func (s *Service) ValidateAndApply(ctx context.Context, req *pb.ValidateAndApplyRequest) (*pb.ValidateAndApplyResponse, error) {
+	cart, msg := cartFromRequest(req)
+	if msg != "" {
+		s.log.Warn(ctx, "Validate and apply failed: "+msg, map[string]interface{}{"code": req.Code})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	coupon, err := s.repo.GetByCode(ctx, normalizeCode(req.Code))
+	if err != nil {
+		return nil, s.couponLookupError(ctx, err)
+	}
+
+	now := s.now()
+	discount, err := Apply(coupon, cart, now)
+	if err == nil && req.DryRun {
+		err = s.checkLimits(ctx, coupon, req.UserId)
+	}
+	if err != nil {
+		return nil, s.applyError(ctx, err, coupon, req.UserId)
+	}
+
+	resp := &pb.ValidateAndApplyResponse{
+		Coupon:           toProtoCoupon(coupon),
+		Subtotal:         discount.Subtotal,
+		Discount:         discount.Items,
+		ShippingDiscount: discount.Shipping,
+		TotalDiscount:    discount.Total(),
+		Total:            roundCents(discount.Subtotal + cart.Shipping - discount.Total()),
+		LineDiscounts:    make([]*pb.LineDiscount, len(discount.Lines)),
+	}
+	for i, l := range discount.Lines {
+		resp.LineDiscounts[i] = &pb.LineDiscount{ProductId: l.ProductID, Discount: l.Discount}
+	}
+	if req.DryRun {
+		return resp, nil
+	}
+
+	redemption, err := s.repo.Redeem(ctx, &Redemption{
+		CouponID:         coupon.ID,
+		UserID:           req.UserId,
+		Reference:        req.Reference,
+		Discount:         discount.Items,
+		ShippingDiscount: discount.Shipping,
+		CreatedAt:        now,
+	})
+	if err != nil {
+		return nil, s.applyError(ctx, err, coupon, req.UserId)
+	}
+	resp.Redemption = toProtoRedemption(redemption)
+
+	return resp, nil
+}
+
+// cartFromRequest validates the cart a coupon is applied to and returns a
+// message describing the first problem, or ""
+func cartFromRequest(req *pb.ValidateAndApplyRequest) (Cart, string) {
+	switch {
+	case strings.TrimSpace(req.Code) == "":
+		return Cart{}, "code is required"
+	case req.UserId == "":
+		return Cart{}, "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return Cart{}, "user_id is too long"
+	case req.Reference == "" && !req.DryRun:
+		return Cart{}, "reference is required"
+	case len(req.Reference) > maxIDLength:
+		return Cart{}, "reference is too long"
+	case !isAmount(req.ShippingAmount):
+		return Cart{}, "shipping_amount must be an amount between 0 and 99999999.99"
+	case len(req.Items) == 0:
+		return Cart{}, "items are required"
+	case len(req.Items) > maxCartLines:
+		return Cart{}, fmt.Sprintf("a cart cannot have more than %d items", maxCartLines)
+	}
+
+	cart := Cart{
+		Segment:  strings.TrimSpace(req.CustomerSegment),
+		Lines:    make([]Line, len(req.Items)),
+		Shipping: req.ShippingAmount,
+	}
+	for i, item := range req.Items {
+		switch {
+		case item.ProductId == "":
+			return Cart{}, "product_id is required"
+		case item.Quantity <= 0:
+			return Cart{}, "quantity must be positive"
+		case !isAmount(item.UnitPrice):
+			return Cart{}, "unit_price must be an amount between 0 and 99999999.99"
+		}
+		cart.Lines[i] = Line{
+			ProductID: item.ProductId,
+			Category:  strings.TrimSpace(item.Category),
+			UnitPrice: item.UnitPrice,
+			Quantity:  item.Quantity,
+		}
+	}
+	return cart, ""
+}
+
+// checkLimits reports whether a coupon or the customer's share of it is used
+// up. A redemption checks the same limits under lock.
+func (s *Service) checkLimits(ctx context.Context, coupon *Coupon, userID string) error {
+	if coupon.UsageLimit > 0 && coupon.TimesUsed >= coupon.UsageLimit {
+		return ErrUsageLimitReached
+	}
+	if coupon.PerCustomerLimit == 0 {
+		return nil
+	}
+	count, err := s.repo.CountRedemptions(ctx, coupon.ID, userID)
+	if err != nil {
+		return err
+	}
+	if count >= coupon.PerCustomerLimit {
+		return ErrCustomerLimitReached
+	}
+	return nil
+}
+
+// ReleaseRedemption gives back a redemption whose checkout was undone
+func (s *Service) ReleaseRedemption(ctx context.Context, req *pb.ReleaseRedemptionRequest) (*pb.ReleaseRedemptionResponse, error) {
+	if req.RedemptionId == "" {
+		s.log.Warn(ctx, "Release redemption failed: redemption ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "redemption_id is required")
+	}
+
+	redemption, err := s.repo.Release(ctx, req.RedemptionId, s.now())
+	if errors.Is(err, ErrRedemptionNotFound) {
+		return nil, status.Error(codes.NotFound, "redemption not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to release redemption", map[string]interface{}{"error": err.Error(), "redemption_id": req.RedemptionId})
+		return nil, status.Error(codes.Internal, "failed to release redemption")
+	}
+
+	return &pb.ReleaseRedemptionResponse{
+		Redemption: toProtoRedemption(redemption),
+	}, nil
+}
+
+// couponLookupError maps a failed coupon lookup to a gRPC status
+func (s *Service) couponLookupError(ctx context.Context, err error) error {
+	if errors.Is(err, ErrCouponNotFound) {
+		return status.Error(codes.NotFound, "coupon not found")
+	}
+	s.log.Error(ctx, "Failed to get coupon", map[string]interface{}{"error": err.Error()})
+	return status.Error(codes.Internal, "failed to get coupon")
+}
+
+// applyError maps a coupon that cannot be applied to a gRPC status. A coupon
+// that does not apply to the cart is a failed precondition carrying the
+// reason, so checkout can show it to the customer.
+func (s *Service) applyError(ctx context.Context, err error, coupon *Coupon, userID string) error {
+	switch {
+	case errors.Is(err, ErrCouponInactive), errors.Is(err, ErrCouponNotStarted), errors.Is(err, ErrCouponExpired),
+		errors.Is(err, ErrSegmentNotEligible), errors.Is(err, ErrMinCartValue), errors.Is(err, ErrNoEligibleItems),
+		errors.Is(err, ErrUsageLimitReached), errors.Is(err, ErrCustomerLimitReached):
+		s.log.Info(ctx, "Coupon not applied", map[string]interface{}{"code": coupon.Code, "user_id": userID, "reason": err.Error()})
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrReferenceInUse):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrCouponNotFound):
+		return status.Error(codes.NotFound, "coupon not found")
+	}
+	s.log.Error(ctx, "Failed to redeem coupon", map[string]interface{}{"error": err.Error(), "code": coupon.Code})
+	return status.Error(codes.Internal, "failed to redeem coupon")
+}
+
+// normalizeCode returns the stored form of a coupon code
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// isAmount reports whether v fits the amount columns
+func isAmount(v float64) bool {
+	return v >= 0 && v <= maxAmount
+}
+
+// toProtoCoupon converts a coupon to protobuf
+func toProtoCoupon(c *Coupon) *pb.Coupon {
+	coupon := &pb.Coupon{
+		Id:               c.ID,
+		Code:             c.Code,
+		Description:      c.Description,
+		Type:             c.Type,
+		Value:            c.Value,
+		MaxDiscount:      c.MaxDiscount,
+		MinCartValue:     c.MinCartValue,
+		Categories:       c.Categories,
+		CustomerSegments: c.CustomerSegments,
+		UsageLimit:       c.UsageLimit,
+		PerCustomerLimit: c.PerCustomerLimit,
+		TimesUsed:        c.TimesUsed,
+		Active:           c.Active,
+		CreatedAt:        timestamppb.New(c.CreatedAt),
+		UpdatedAt:        timestamppb.New(c.UpdatedAt),
+	}
+	if c.StartsAt != nil {
+		coupon.StartsAt = timestamppb.New(*c.StartsAt)
+	}
+	if c.EndsAt != nil {
+		coupon.EndsAt = timestamppb.New(*c.EndsAt)
+	}
+	return coupon
+}
+
+// toProtoRedemption converts a redemption to protobuf
+func toProtoRedemption(r *Redemption) *pb.Redemption {
+	redemption := &pb.Redemption{
+		Id:               r.ID,
+		CouponId:         r.CouponID,
+		Code:             r.Code,
+		UserId:           r.UserID,
+		Reference:        r.Reference,
+		Discount:         r.Discount,
+		ShippingDiscount: r.ShippingDiscount,
+		CreatedAt:        timestamppb.New(r.CreatedAt),
+	}
+	if r.ReleasedAt != nil {
+		redemption.ReleasedAt = timestamppb.New(*r.ReleasedAt)
+	}
+	return redemption
+}
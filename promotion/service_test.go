@@ -0,0 +1,383 @@
+package promotion
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/promotion/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps coupons and their redemptions, enforcing the usage
+// and per-customer limits when a coupon is redeemed
+type memoryRepository struct {
+	mu          sync.Mutex
+	coupons     map[string]*Coupon
+	redemptions map[string]*Redemption
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		coupons:     map[string]*Coupon{},
+		redemptions: map[string]*Redemption{},
+	}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, coupon *Coupon) (*Coupon, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.coupons {
+		if c.Code == coupon.Code {
+			return nil, ErrDuplicateCode
+		}
+	}
+	coupon.ID = uuid.New().String()
+	stored := *coupon
+	m.coupons[coupon.ID] = &stored
+	return coupon, nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Coupon, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.coupons[id]
+	if !ok {
+		return nil, ErrCouponNotFound
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (m *memoryRepository) GetByCode(ctx context.Context, code string) (*Coupon, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.coupons {
+		if c.Code == code {
+			copied := *c
+			return &copied, nil
+		}
+	}
+	return nil, ErrCouponNotFound
+}
+
+func (m *memoryRepository) List(ctx context.Context, activeOnly bool, page, pageSize int32) ([]*Coupon, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var coupons []*Coupon
+	for _, c := range m.coupons {
+		if c.Active || !activeOnly {
+			copied := *c
+			coupons = append(coupons, &copied)
+		}
+	}
+	sort.Slice(coupons, func(i, j int) bool { return coupons[i].CreatedAt.After(coupons[j].CreatedAt) })
+	total := int32(len(coupons))
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	return coupons[start:end], total, nil
+}
+
+func (m *memoryRepository) SetActive(ctx context.Context, id string, active bool, at time.Time) (*Coupon, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.coupons[id]
+	if !ok {
+		return nil, ErrCouponNotFound
+	}
+	c.Active = active
+	c.UpdatedAt = at
+	copied := *c
+	return &copied, nil
+}
+
+func (m *memoryRepository) CountRedemptions(ctx context.Context, couponID, userID string) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.countRedemptions(couponID, userID), nil
+}
+
+func (m *memoryRepository) countRedemptions(couponID, userID string) int32 {
+	var count int32
+	for _, r := range m.redemptions {
+		if r.CouponID == couponID && r.UserID == userID && r.ReleasedAt == nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *memoryRepository) Redeem(ctx context.Context, redemption *Redemption) (*Redemption, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.coupons[redemption.CouponID]
+	if !ok {
+		return nil, ErrCouponNotFound
+	}
+	for _, r := range m.redemptions {
+		if r.CouponID == redemption.CouponID && r.Reference == redemption.Reference && r.ReleasedAt == nil {
+			if r.UserID != redemption.UserID {
+				return nil, ErrReferenceInUse
+			}
+			copied := *r
+			return &copied, nil
+		}
+	}
+	if c.UsageLimit > 0 && c.TimesUsed >= c.UsageLimit {
+		return nil, ErrUsageLimitReached
+	}
+	if c.PerCustomerLimit > 0 && m.countRedemptions(c.ID, redemption.UserID) >= c.PerCustomerLimit {
+		return nil, ErrCustomerLimitReached
+	}
+	redemption.ID = uuid.New().String()
+	redemption.Code = c.Code
+	stored := *redemption
+	m.redemptions[redemption.ID] = &stored
+	c.TimesUsed++
+	return redemption, nil
+}
+
+func (m *memoryRepository) Release(ctx context.Context, id string, at time.Time) (*Redemption, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.redemptions[id]
+	if !ok {
+		return nil, ErrRedemptionNotFound
+	}
+	if r.ReleasedAt == nil {
+		r.ReleasedAt = &at
+		m.coupons[r.CouponID].TimesUsed--
+	}
+	copied := *r
+	return &copied, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+func setupService() (*Service, *memoryRepository) {
+	repo := newMemoryRepository()
+	service := NewService(repo, logger.New("promotion-test"))
+	service.now = func() time.Time { return now }
+	return service, repo
+}
+
+func createCoupon(t *testing.T, service *Service, req *pb.CreateCouponRequest) *pb.Coupon {
+	t.Helper()
+	resp, err := service.CreateCoupon(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Failed to create coupon: %v", err)
+	}
+	return resp.Coupon
+}
+
+func applyRequest(code, userID, reference string) *pb.ValidateAndApplyRequest {
+	return &pb.ValidateAndApplyRequest{
+		Code:   code,
+		UserId: userID,
+		Items: []*pb.CartLine{
+			{ProductId: "shirt", Category: "Apparel", UnitPrice: 20, Quantity: 2},
+			{ProductId: "mug", Category: "Kitchen", UnitPrice: 10, Quantity: 1},
+		},
+		ShippingAmount: 5,
+		Reference:      reference,
+	}
+}
+
+func TestCreateCoupon(t *testing.T) {
+	service, _ := setupService()
+
+	coupon := createCoupon(t, service, &pb.CreateCouponRequest{
+		Code:       " summer-10 ",
+		Type:       TypePercentage,
+		Value:      10,
+		Categories: []string{" Apparel ", "Apparel"},
+		EndsAt:     timestamppb.New(now.Add(30 * 24 * time.Hour)),
+	})
+	if coupon.Code != "SUMMER-10" || !coupon.Active || len(coupon.Categories) != 1 || coupon.Categories[0] != "Apparel" {
+		t.Errorf("Unexpected coupon %+v", coupon)
+	}
+
+	_, err := service.CreateCoupon(context.Background(), &pb.CreateCouponRequest{Code: "Summer-10", Type: TypeFixed, Value: 5})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a code differing only in case, got %v", err)
+	}
+}
+
+func TestCreateCoupon_Invalid(t *testing.T) {
+	service, _ := setupService()
+
+	tests := []struct {
+		name string
+		req  *pb.CreateCouponRequest
+	}{
+		{"missing code", &pb.CreateCouponRequest{Type: TypeFixed, Value: 5}},
+		{"bad code", &pb.CreateCouponRequest{Code: "10% OFF", Type: TypeFixed, Value: 5}},
+		{"unknown type", &pb.CreateCouponRequest{Code: "SAVE", Type: "BOGO", Value: 5}},
+		{"percentage over 100", &pb.CreateCouponRequest{Code: "SAVE", Type: TypePercentage, Value: 120}},
+		{"fixed without value", &pb.CreateCouponRequest{Code: "SAVE", Type: TypeFixed}},
+		{"fixed with cap", &pb.CreateCouponRequest{Code: "SAVE", Type: TypeFixed, Value: 5, MaxDiscount: 5}},
+		{"free shipping with value", &pb.CreateCouponRequest{Code: "SHIP", Type: TypeFreeShipping, Value: 5}},
+		{"negative minimum", &pb.CreateCouponRequest{Code: "SAVE", Type: TypeFixed, Value: 5, MinCartValue: -1}},
+		{"per customer over total", &pb.CreateCouponRequest{Code: "SAVE", Type: TypeFixed, Value: 5, UsageLimit: 1, PerCustomerLimit: 2}},
+		{"empty category", &pb.CreateCouponRequest{Code: "SAVE", Type: TypeFixed, Value: 5, Categories: []string{" "}}},
+		{"window backwards", &pb.CreateCouponRequest{Code: "SAVE", Type: TypeFixed, Value: 5, StartsAt: timestamppb.New(now), EndsAt: timestamppb.New(now)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.CreateCoupon(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateAndApply(t *testing.T) {
+	service, repo := setupService()
+	coupon := createCoupon(t, service, &pb.CreateCouponRequest{Code: "APPAREL20", Type: TypePercentage, Value: 20, Categories: []string{"apparel"}})
+
+	resp, err := service.ValidateAndApply(context.Background(), applyRequest("apparel20", "user-1", "checkout-1"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Subtotal != 50 || resp.Discount != 8 || resp.TotalDiscount != 8 || resp.Total != 47 {
+		t.Errorf("Expected 8 off a 50 subtotal, got %+v", resp)
+	}
+	if len(resp.LineDiscounts) != 1 || resp.LineDiscounts[0].ProductId != "shirt" {
+		t.Errorf("Expected the discount on the shirt only, got %v", resp.LineDiscounts)
+	}
+	if resp.Redemption == nil || resp.Redemption.Reference != "checkout-1" || resp.Redemption.Discount != 8 {
+		t.Fatalf("Expected a redemption, got %+v", resp.Redemption)
+	}
+
+	again, err := service.ValidateAndApply(context.Background(), applyRequest("APPAREL20", "user-1", "checkout-1"))
+	if err != nil {
+		t.Fatalf("Expected a retried checkout to succeed, got %v", err)
+	}
+	if again.Redemption.Id != resp.Redemption.Id {
+		t.Error("Expected a retried checkout to get its redemption back")
+	}
+	if stored, _ := repo.GetByID(context.Background(), coupon.Id); stored.TimesUsed != 1 {
+		t.Errorf("Expected the coupon used once, got %d", stored.TimesUsed)
+	}
+
+	if _, err := service.ValidateAndApply(context.Background(), applyRequest("APPAREL20", "user-2", "checkout-1")); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for another customer's reference, got %v", err)
+	}
+}
+
+func TestValidateAndApply_DryRun(t *testing.T) {
+	service, repo := setupService()
+	coupon := createCoupon(t, service, &pb.CreateCouponRequest{Code: "SHIPFREE", Type: TypeFreeShipping, MinCartValue: 40})
+
+	req := applyRequest("SHIPFREE", "user-1", "")
+	req.DryRun = true
+	resp, err := service.ValidateAndApply(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.ShippingDiscount != 5 || resp.Total != 50 || resp.Redemption != nil {
+		t.Errorf("Expected free shipping without a redemption, got %+v", resp)
+	}
+	if stored, _ := repo.GetByID(context.Background(), coupon.Id); stored.TimesUsed != 0 {
+		t.Errorf("Expected a dry run not to use the coupon, got %d", stored.TimesUsed)
+	}
+
+	req.Items = req.Items[1:]
+	if _, err := service.ValidateAndApply(context.Background(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition below the minimum cart value, got %v", err)
+	}
+}
+
+func TestValidateAndApply_Limits(t *testing.T) {
+	service, _ := setupService()
+	createCoupon(t, service, &pb.CreateCouponRequest{Code: "ONCE", Type: TypeFixed, Value: 5, UsageLimit: 2, PerCustomerLimit: 1})
+
+	if _, err := service.ValidateAndApply(context.Background(), applyRequest("ONCE", "user-1", "checkout-1")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	second := applyRequest("ONCE", "user-1", "checkout-2")
+	second.DryRun = true
+	if _, err := service.ValidateAndApply(context.Background(), second); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected a dry run to report the customer limit, got %v", err)
+	}
+	second.DryRun = false
+	if _, err := service.ValidateAndApply(context.Background(), second); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition past the customer limit, got %v", err)
+	}
+
+	resp, err := service.ValidateAndApply(context.Background(), applyRequest("ONCE", "user-2", "checkout-3"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.ValidateAndApply(context.Background(), applyRequest("ONCE", "user-3", "checkout-4")); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition past the usage limit, got %v", err)
+	}
+
+	// A released redemption frees its use
+	released, err := service.ReleaseRedemption(context.Background(), &pb.ReleaseRedemptionRequest{RedemptionId: resp.Redemption.Id})
+	if err != nil || released.Redemption.ReleasedAt == nil {
+		t.Fatalf("Expected the redemption released, got %v (%v)", released, err)
+	}
+	if _, err := service.ReleaseRedemption(context.Background(), &pb.ReleaseRedemptionRequest{RedemptionId: resp.Redemption.Id}); err != nil {
+		t.Errorf("Expected releasing twice to succeed, got %v", err)
+	}
+	if _, err := service.ValidateAndApply(context.Background(), applyRequest("ONCE", "user-3", "checkout-4")); err != nil {
+		t.Errorf("Expected the released use to be available, got %v", err)
+	}
+}
+
+func TestValidateAndApply_Errors(t *testing.T) {
+	service, _ := setupService()
+	coupon := createCoupon(t, service, &pb.CreateCouponRequest{Code: "VIP5", Type: TypeFixed, Value: 5, CustomerSegments: []string{"vip"}})
+
+	if _, err := service.ValidateAndApply(context.Background(), applyRequest("NOPE", "user-1", "checkout-1")); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown code, got %v", err)
+	}
+	if _, err := service.ValidateAndApply(context.Background(), applyRequest("VIP5", "user-1", "")); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a reference, got %v", err)
+	}
+
+	req := applyRequest("VIP5", "user-1", "checkout-1")
+	_, err := service.ValidateAndApply(context.Background(), req)
+	if status.Code(err) != codes.FailedPrecondition || status.Convert(err).Message() != ErrSegmentNotEligible.Error() {
+		t.Errorf("Expected the segment to be refused, got %v", err)
+	}
+	req.CustomerSegment = "vip"
+	if _, err := service.ValidateAndApply(context.Background(), req); err != nil {
+		t.Errorf("Expected the coupon to apply to its segment, got %v", err)
+	}
+
+	if _, err := service.SetCouponActive(context.Background(), &pb.SetCouponActiveRequest{CouponId: coupon.Id, Active: false}); err != nil {
+		t.Fatalf("Failed to deactivate coupon: %v", err)
+	}
+	req.Reference = "checkout-2"
+	if _, err := service.ValidateAndApply(context.Background(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected an inactive coupon to be refused, got %v", err)
+	}
+}
+
+func TestGetCoupon(t *testing.T) {
+	service, _ := setupService()
+	coupon := createCoupon(t, service, &pb.CreateCouponRequest{Code: "SAVE5", Type: TypeFixed, Value: 5})
+
+	resp, err := service.GetCoupon(context.Background(), &pb.GetCouponRequest{Code: "save5"})
+	if err != nil || resp.Coupon.Id != coupon.Id {
+		t.Errorf("Expected the coupon by code, got %v (%v)", resp, err)
+	}
+	if _, err := service.GetCoupon(context.Background(), &pb.GetCouponRequest{CouponId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := service.GetCoupon(context.Background(), &pb.GetCouponRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
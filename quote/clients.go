@@ -0,0 +1,159 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cartpb "github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrProductUnavailable is returned when a product does not exist or is not for sale
+	ErrProductUnavailable = errors.New("product unavailable")
+	// ErrInvalidQuantity is returned when a quantity breaks a product's order quantity rules
+	ErrInvalidQuantity = errors.New("invalid quantity")
+	// ErrOrderRejected is returned when the order service refuses to convert a quote
+	ErrOrderRejected = errors.New("order rejected")
+)
+
+// catalogStatusActive is the catalog status of products that are for sale
+const catalogStatusActive = "ACTIVE"
+
+// CartLine is a product and quantity in a customer's cart
+type CartLine struct {
+	ProductID string
+	Quantity  int32
+}
+
+// Carts reads customers' carts from the cart service
+type Carts interface {
+	// Contents returns the lines of a customer's cart; none when it is empty
+	Contents(ctx context.Context, userID string) ([]CartLine, error)
+}
+
+type grpcCarts struct {
+	client cartpb.CartServiceClient
+}
+
+// NewGRPCCarts creates Carts backed by the cart service
+func NewGRPCCarts(client cartpb.CartServiceClient) Carts {
+	return &grpcCarts{client: client}
+}
+
+func (c *grpcCarts) Contents(ctx context.Context, userID string) ([]CartLine, error) {
+	resp, err := c.client.GetCart(ctx, &cartpb.GetCartRequest{UserId: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+	lines := make([]CartLine, len(resp.Cart.Items))
+	for i, item := range resp.Cart.Items {
+		lines[i] = CartLine{ProductID: item.ProductId, Quantity: item.Quantity}
+	}
+	return lines, nil
+}
+
+// ListPrice is a product's details and catalog unit price for a quantity
+type ListPrice struct {
+	SKU       string
+	Name      string
+	UnitPrice float64
+}
+
+// Catalog reads list prices from the catalog service
+type Catalog interface {
+	// ListPrice returns the catalog unit price of a product for a quantity,
+	// which applies sale prices and quantity tiers. It returns
+	// ErrProductUnavailable, or an error wrapping ErrInvalidQuantity when the
+	// quantity cannot be ordered.
+	ListPrice(ctx context.Context, productID string, quantity int32) (*ListPrice, error)
+}
+
+type grpcCatalog struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCCatalog creates a Catalog backed by the catalog service
+func NewGRPCCatalog(client catalogpb.CatalogServiceClient) Catalog {
+	return &grpcCatalog{client: client}
+}
+
+func (c *grpcCatalog) ListPrice(ctx context.Context, productID string, quantity int32) (*ListPrice, error) {
+	product, err := c.client.GetProduct(ctx, &catalogpb.GetProductRequest{Id: productID})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrProductUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product.Product.Status != catalogStatusActive {
+		return nil, ErrProductUnavailable
+	}
+
+	price, err := c.client.GetPriceForQuantity(ctx, &catalogpb.GetPriceForQuantityRequest{ProductId: productID, Quantity: quantity})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return nil, ErrProductUnavailable
+	case codes.InvalidArgument:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQuantity, status.Convert(err).Message())
+	default:
+		return nil, fmt.Errorf("failed to price product: %w", err)
+	}
+
+	return &ListPrice{
+		SKU:       product.Product.Sku,
+		Name:      product.Product.Name,
+		UnitPrice: price.UnitPrice,
+	}, nil
+}
+
+// PlacedOrder is the order a quote was converted into
+type PlacedOrder struct {
+	ID       string
+	PONumber string
+}
+
+// Orders converts quotes into orders through the order service
+type Orders interface {
+	// CreateOrder places an order for a quote at its quoted prices. Placing
+	// it again returns the same order. It returns an error wrapping
+	// ErrOrderRejected when the order service refuses the order, e.g.
+	// because a product is no longer for sale.
+	CreateOrder(ctx context.Context, q *Quote, poNumber string) (*PlacedOrder, error)
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+func (o *grpcOrders) CreateOrder(ctx context.Context, q *Quote, poNumber string) (*PlacedOrder, error) {
+	items := make([]*orderpb.QuotedItem, len(q.Items))
+	for i, item := range q.Items {
+		items[i] = &orderpb.QuotedItem{ProductId: item.ProductID, Quantity: item.Quantity, UnitPrice: item.QuotedPrice}
+	}
+
+	resp, err := o.client.CreateQuotedOrder(ctx, &orderpb.CreateQuotedOrderRequest{
+		UserId:   q.UserID,
+		QuoteId:  q.ID,
+		PoNumber: poNumber,
+		Items:    items,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return nil, fmt.Errorf("%w: %s", ErrOrderRejected, status.Convert(err).Message())
+	default:
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	return &PlacedOrder{ID: resp.Order.Id, PONumber: resp.Order.PoNumber}, nil
+}
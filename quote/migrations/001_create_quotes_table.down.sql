@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS quote_items;
+DROP INDEX IF EXISTS idx_quotes_status;
+DROP INDEX IF EXISTS idx_quotes_user;
+DROP TABLE IF EXISTS quotes;
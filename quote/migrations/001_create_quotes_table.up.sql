@@ -0,0 +1,36 @@
+-- B2B quotes. status follows the negotiation:
+-- REQUESTED -> COUNTERED (any number of times) or APPROVED -> ORDERED, with
+-- REQUESTED and COUNTERED quotes rejectable by sales and COUNTERED and
+-- APPROVED quotes declinable by the customer.
+CREATE TABLE IF NOT EXISTS quotes (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    company VARCHAR(255) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'REQUESTED' CHECK (status IN ('REQUESTED', 'COUNTERED', 'APPROVED', 'REJECTED', 'DECLINED', 'ORDERED')),
+    note TEXT NOT NULL DEFAULT '',
+    response_note TEXT NOT NULL DEFAULT '',
+    list_total DECIMAL(12, 2) NOT NULL CHECK (list_total >= 0),
+    quoted_total DECIMAL(12, 2) NOT NULL DEFAULT 0 CHECK (quoted_total >= 0),
+    valid_until TIMESTAMP,
+    po_number VARCHAR(100) NOT NULL DEFAULT '',
+    order_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_quotes_user ON quotes(user_id, created_at DESC);
+CREATE INDEX idx_quotes_status ON quotes(status, created_at DESC);
+
+-- The cart lines of a quote, with their list, target and quoted unit prices
+CREATE TABLE IF NOT EXISTS quote_items (
+    quote_id UUID NOT NULL REFERENCES quotes(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    sku VARCHAR(100) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    list_price DECIMAL(10, 2) NOT NULL CHECK (list_price >= 0),
+    target_price DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (target_price >= 0),
+    quoted_price DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (quoted_price >= 0),
+    position INTEGER NOT NULL,
+    PRIMARY KEY (quote_id, product_id)
+);
@@ -0,0 +1,1379 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: quote/quote.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Quote is a business customer's request for negotiated prices on the
+// contents of their cart. Amounts are in the catalog currency.
+type Quote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Company       string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // REQUESTED, COUNTERED, APPROVED, REJECTED, DECLINED or ORDERED
+	Items         []*QuoteItem           `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Note          string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`                                     // from the customer
+	ResponseNote  string                 `protobuf:"bytes,7,opt,name=response_note,json=responseNote,proto3" json:"response_note,omitempty"` // from sales, when countered, approved or rejected
+	ListTotal     float64                `protobuf:"fixed64,8,opt,name=list_total,json=listTotal,proto3" json:"list_total,omitempty"`        // the lines at their list prices
+	QuotedTotal   float64                `protobuf:"fixed64,9,opt,name=quoted_total,json=quotedTotal,proto3" json:"quoted_total,omitempty"`  // the lines at their quoted prices, once countered or approved
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`      // once countered or approved; the quote cannot be accepted after it
+	PoNumber      string                 `protobuf:"bytes,11,opt,name=po_number,json=poNumber,proto3" json:"po_number,omitempty"`            // the customer's purchase order number, once ORDERED
+	OrderId       string                 `protobuf:"bytes,12,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`               // the order the quote was converted into, once ORDERED
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_quote_quote_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Quote) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Quote) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Quote) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Quote) GetItems() []*QuoteItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Quote) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Quote) GetResponseNote() string {
+	if x != nil {
+		return x.ResponseNote
+	}
+	return ""
+}
+
+func (x *Quote) GetListTotal() float64 {
+	if x != nil {
+		return x.ListTotal
+	}
+	return 0
+}
+
+func (x *Quote) GetQuotedTotal() float64 {
+	if x != nil {
+		return x.QuotedTotal
+	}
+	return 0
+}
+
+func (x *Quote) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *Quote) GetPoNumber() string {
+	if x != nil {
+		return x.PoNumber
+	}
+	return ""
+}
+
+func (x *Quote) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Quote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Quote) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// QuoteItem is a cart line of a quote
+type QuoteItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`   // when the quote was requested
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // when the quote was requested
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ListPrice     float64                `protobuf:"fixed64,5,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"`       // catalog unit price for the quantity when the quote was requested
+	TargetPrice   float64                `protobuf:"fixed64,6,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"` // unit price the customer asked for; 0 when none
+	QuotedPrice   float64                `protobuf:"fixed64,7,opt,name=quoted_price,json=quotedPrice,proto3" json:"quoted_price,omitempty"` // negotiated unit price, once countered or approved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteItem) Reset() {
+	*x = QuoteItem{}
+	mi := &file_quote_quote_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteItem) ProtoMessage() {}
+
+func (x *QuoteItem) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteItem.ProtoReflect.Descriptor instead.
+func (*QuoteItem) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuoteItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *QuoteItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *QuoteItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QuoteItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *QuoteItem) GetListPrice() float64 {
+	if x != nil {
+		return x.ListPrice
+	}
+	return 0
+}
+
+func (x *QuoteItem) GetTargetPrice() float64 {
+	if x != nil {
+		return x.TargetPrice
+	}
+	return 0
+}
+
+func (x *QuoteItem) GetQuotedPrice() float64 {
+	if x != nil {
+		return x.QuotedPrice
+	}
+	return 0
+}
+
+// ItemPrice is a unit price for one product of a quote
+type ItemPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,2,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemPrice) Reset() {
+	*x = ItemPrice{}
+	mi := &file_quote_quote_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemPrice) ProtoMessage() {}
+
+func (x *ItemPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemPrice.ProtoReflect.Descriptor instead.
+func (*ItemPrice) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ItemPrice) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ItemPrice) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+// RequestQuote requests a quote on the contents of the customer's cart. The
+// cart is left as it is.
+type RequestQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Company       string                 `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`                               // required; the business the quote is for
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`                                     // optional, at most 1000 characters
+	TargetPrices  []*ItemPrice           `protobuf:"bytes,4,rep,name=target_prices,json=targetPrices,proto3" json:"target_prices,omitempty"` // optional prices the customer asks for, by product
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestQuoteRequest) Reset() {
+	*x = RequestQuoteRequest{}
+	mi := &file_quote_quote_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestQuoteRequest) ProtoMessage() {}
+
+func (x *RequestQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestQuoteRequest.ProtoReflect.Descriptor instead.
+func (*RequestQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RequestQuoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RequestQuoteRequest) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *RequestQuoteRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *RequestQuoteRequest) GetTargetPrices() []*ItemPrice {
+	if x != nil {
+		return x.TargetPrices
+	}
+	return nil
+}
+
+type RequestQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestQuoteResponse) Reset() {
+	*x = RequestQuoteResponse{}
+	mi := &file_quote_quote_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestQuoteResponse) ProtoMessage() {}
+
+func (x *RequestQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestQuoteResponse.ProtoReflect.Descriptor instead.
+func (*RequestQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RequestQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+type GetQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_quote_quote_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+type GetQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteResponse) Reset() {
+	*x = GetQuoteResponse{}
+	mi := &file_quote_quote_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteResponse) ProtoMessage() {}
+
+func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteResponse.ProtoReflect.Descriptor instead.
+func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// ListQuotes lists quotes, newest first. All filters are optional.
+type ListQuotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuotesRequest) Reset() {
+	*x = ListQuotesRequest{}
+	mi := &file_quote_quote_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuotesRequest) ProtoMessage() {}
+
+func (x *ListQuotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuotesRequest.ProtoReflect.Descriptor instead.
+func (*ListQuotesRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListQuotesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListQuotesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListQuotesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListQuotesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListQuotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quotes        []*Quote               `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuotesResponse) Reset() {
+	*x = ListQuotesResponse{}
+	mi := &file_quote_quote_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuotesResponse) ProtoMessage() {}
+
+func (x *ListQuotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuotesResponse.ProtoReflect.Descriptor instead.
+func (*ListQuotesResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListQuotesResponse) GetQuotes() []*Quote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *ListQuotesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListQuotesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListQuotesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// CounterQuote offers the customer prices of sales' choosing. Lines not
+// priced keep their earlier quoted price, or else get the customer's target
+// price or the list price. A countered quote can be countered again.
+type CounterQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Prices        []*ItemPrice           `protobuf:"bytes,2,rep,name=prices,proto3" json:"prices,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`                             // optional
+	ValidDays     int32                  `protobuf:"varint,4,opt,name=valid_days,json=validDays,proto3" json:"valid_days,omitempty"` // how long the offer stands; the service default when 0, max 365
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CounterQuoteRequest) Reset() {
+	*x = CounterQuoteRequest{}
+	mi := &file_quote_quote_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CounterQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterQuoteRequest) ProtoMessage() {}
+
+func (x *CounterQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterQuoteRequest.ProtoReflect.Descriptor instead.
+func (*CounterQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CounterQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *CounterQuoteRequest) GetPrices() []*ItemPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *CounterQuoteRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CounterQuoteRequest) GetValidDays() int32 {
+	if x != nil {
+		return x.ValidDays
+	}
+	return 0
+}
+
+type CounterQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CounterQuoteResponse) Reset() {
+	*x = CounterQuoteResponse{}
+	mi := &file_quote_quote_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CounterQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterQuoteResponse) ProtoMessage() {}
+
+func (x *CounterQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterQuoteResponse.ProtoReflect.Descriptor instead.
+func (*CounterQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CounterQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// ApproveQuote accepts the customer's target prices, with the list price for
+// lines without one
+type ApproveQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`                             // optional
+	ValidDays     int32                  `protobuf:"varint,3,opt,name=valid_days,json=validDays,proto3" json:"valid_days,omitempty"` // how long the offer stands; the service default when 0, max 365
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveQuoteRequest) Reset() {
+	*x = ApproveQuoteRequest{}
+	mi := &file_quote_quote_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveQuoteRequest) ProtoMessage() {}
+
+func (x *ApproveQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveQuoteRequest.ProtoReflect.Descriptor instead.
+func (*ApproveQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApproveQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *ApproveQuoteRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ApproveQuoteRequest) GetValidDays() int32 {
+	if x != nil {
+		return x.ValidDays
+	}
+	return 0
+}
+
+type ApproveQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveQuoteResponse) Reset() {
+	*x = ApproveQuoteResponse{}
+	mi := &file_quote_quote_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveQuoteResponse) ProtoMessage() {}
+
+func (x *ApproveQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveQuoteResponse.ProtoReflect.Descriptor instead.
+func (*ApproveQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApproveQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// RejectQuote turns down a requested or countered quote
+type RejectQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"` // required; tells the customer why
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectQuoteRequest) Reset() {
+	*x = RejectQuoteRequest{}
+	mi := &file_quote_quote_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectQuoteRequest) ProtoMessage() {}
+
+func (x *RejectQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectQuoteRequest.ProtoReflect.Descriptor instead.
+func (*RejectQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RejectQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *RejectQuoteRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type RejectQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectQuoteResponse) Reset() {
+	*x = RejectQuoteResponse{}
+	mi := &file_quote_quote_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectQuoteResponse) ProtoMessage() {}
+
+func (x *RejectQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectQuoteResponse.ProtoReflect.Descriptor instead.
+func (*RejectQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RejectQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// AcceptQuote accepts a countered or approved quote before it expires and
+// converts it into a PENDING order at the quoted prices
+type AcceptQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // must be the customer who requested the quote
+	PoNumber      string                 `protobuf:"bytes,3,opt,name=po_number,json=poNumber,proto3" json:"po_number,omitempty"` // required, at most 100 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptQuoteRequest) Reset() {
+	*x = AcceptQuoteRequest{}
+	mi := &file_quote_quote_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptQuoteRequest) ProtoMessage() {}
+
+func (x *AcceptQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptQuoteRequest.ProtoReflect.Descriptor instead.
+func (*AcceptQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AcceptQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *AcceptQuoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AcceptQuoteRequest) GetPoNumber() string {
+	if x != nil {
+		return x.PoNumber
+	}
+	return ""
+}
+
+type AcceptQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptQuoteResponse) Reset() {
+	*x = AcceptQuoteResponse{}
+	mi := &file_quote_quote_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptQuoteResponse) ProtoMessage() {}
+
+func (x *AcceptQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptQuoteResponse.ProtoReflect.Descriptor instead.
+func (*AcceptQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AcceptQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// DeclineQuote turns down a countered or approved quote
+type DeclineQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // must be the customer who requested the quote
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineQuoteRequest) Reset() {
+	*x = DeclineQuoteRequest{}
+	mi := &file_quote_quote_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineQuoteRequest) ProtoMessage() {}
+
+func (x *DeclineQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineQuoteRequest.ProtoReflect.Descriptor instead.
+func (*DeclineQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeclineQuoteRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *DeclineQuoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeclineQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineQuoteResponse) Reset() {
+	*x = DeclineQuoteResponse{}
+	mi := &file_quote_quote_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineQuoteResponse) ProtoMessage() {}
+
+func (x *DeclineQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quote_quote_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineQuoteResponse.ProtoReflect.Descriptor instead.
+func (*DeclineQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_quote_quote_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeclineQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+var File_quote_quote_proto protoreflect.FileDescriptor
+
+const file_quote_quote_proto_rawDesc = "" +
+	"\n" +
+	"\x11quote/quote.proto\x12\x05quote\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf0\x03\n" +
+	"\x05Quote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12&\n" +
+	"\x05items\x18\x05 \x03(\v2\x10.quote.QuoteItemR\x05items\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\x12#\n" +
+	"\rresponse_note\x18\a \x01(\tR\fresponseNote\x12\x1d\n" +
+	"\n" +
+	"list_total\x18\b \x01(\x01R\tlistTotal\x12!\n" +
+	"\fquoted_total\x18\t \x01(\x01R\vquotedTotal\x12;\n" +
+	"\vvalid_until\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12\x1b\n" +
+	"\tpo_number\x18\v \x01(\tR\bpoNumber\x12\x19\n" +
+	"\border_id\x18\f \x01(\tR\aorderId\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd1\x01\n" +
+	"\tQuoteItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\x05 \x01(\x01R\tlistPrice\x12!\n" +
+	"\ftarget_price\x18\x06 \x01(\x01R\vtargetPrice\x12!\n" +
+	"\fquoted_price\x18\a \x01(\x01R\vquotedPrice\"I\n" +
+	"\tItemPrice\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x02 \x01(\x01R\tunitPrice\"\x93\x01\n" +
+	"\x13RequestQuoteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\acompany\x18\x02 \x01(\tR\acompany\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\x125\n" +
+	"\rtarget_prices\x18\x04 \x03(\v2\x10.quote.ItemPriceR\ftargetPrices\":\n" +
+	"\x14RequestQuoteResponse\x12\"\n" +
+	"\x05quote\x18\x01 \x01(\v2\f.quote.QuoteR\x05quote\",\n" +
+	"\x0fGetQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\"6\n" +
+	"\x10GetQuoteResponse\x12\"\n" +
+	"\x05quote\x18\x01 \x01(\v2\f.quote.QuoteR\x05quote\"u\n" +
+	"\x11ListQuotesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x81\x01\n" +
+	"\x12ListQuotesResponse\x12$\n" +
+	"\x06quotes\x18\x01 \x03(\v2\f.quote.QuoteR\x06quotes\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x8d\x01\n" +
+	"\x13CounterQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12(\n" +
+	"\x06prices\x18\x02 \x03(\v2\x10.quote.ItemPriceR\x06prices\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"valid_days\x18\x04 \x01(\x05R\tvalidDays\":\n" +
+	"\x14CounterQuoteResponse\x12\"\n" +
+	"\x05quote\x18\x01 \x01(\v2\f.quote.QuoteR\x05quote\"c\n" +
+	"\x13ApproveQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"valid_days\x18\x03 \x01(\x05R\tvalidDays\":\n" +
+	"\x14ApproveQuoteResponse\x12\"\n" +
+	"\x05quote\x18\x01 \x01(\v2\f.quote.QuoteR\x05quote\"C\n" +
+	"\x12RejectQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"9\n" +
+	"\x13RejectQuoteResponse\x12\"\n" +
+	"\x05quote\x18\x01 \x01(\v2\f.quote.QuoteR\x05quote\"e\n" +
+	"\x12AcceptQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpo_number\x18\x03 \x01(\tR\bpoNumber\"9\n" +
+	"\x13AcceptQuoteResponse\x12\"\n" +
+	"\x05quote\x18\x01 \x01(\v2\f.quote.QuoteR\x05quote\"I\n" +
+	"\x13DeclineQuoteRequest\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\":\n" +
+	"\x14DeclineQuoteResponse\x12\"\n" +
+	"\x05quote\x18\x01 \x01(\v2\f.quote.QuoteR\x05quote2\xbe\x04\n" +
+	"\fQuoteService\x12G\n" +
+	"\fRequestQuote\x12\x1a.quote.RequestQuoteRequest\x1a\x1b.quote.RequestQuoteResponse\x12;\n" +
+	"\bGetQuote\x12\x16.quote.GetQuoteRequest\x1a\x17.quote.GetQuoteResponse\x12A\n" +
+	"\n" +
+	"ListQuotes\x12\x18.quote.ListQuotesRequest\x1a\x19.quote.ListQuotesResponse\x12G\n" +
+	"\fCounterQuote\x12\x1a.quote.CounterQuoteRequest\x1a\x1b.quote.CounterQuoteResponse\x12G\n" +
+	"\fApproveQuote\x12\x1a.quote.ApproveQuoteRequest\x1a\x1b.quote.ApproveQuoteResponse\x12D\n" +
+	"\vRejectQuote\x12\x19.quote.RejectQuoteRequest\x1a\x1a.quote.RejectQuoteResponse\x12D\n" +
+	"\vAcceptQuote\x12\x19.quote.AcceptQuoteRequest\x1a\x1a.quote.AcceptQuoteResponse\x12G\n" +
+	"\fDeclineQuote\x12\x1a.quote.DeclineQuoteRequest\x1a\x1b.quote.DeclineQuoteResponseB5Z3github.com/Ujjwaljain16/E-commerce-Backend/quote/pbb\x06proto3"
+
+var (
+	file_quote_quote_proto_rawDescOnce sync.Once
+	file_quote_quote_proto_rawDescData []byte
+)
+
+func file_quote_quote_proto_rawDescGZIP() []byte {
+	file_quote_quote_proto_rawDescOnce.Do(func() {
+		file_quote_quote_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_quote_quote_proto_rawDesc), len(file_quote_quote_proto_rawDesc)))
+	})
+	return file_quote_quote_proto_rawDescData
+}
+
+var file_quote_quote_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_quote_quote_proto_goTypes = []any{
+	(*Quote)(nil),                 // 0: quote.Quote
+	(*QuoteItem)(nil),             // 1: quote.QuoteItem
+	(*ItemPrice)(nil),             // 2: quote.ItemPrice
+	(*RequestQuoteRequest)(nil),   // 3: quote.RequestQuoteRequest
+	(*RequestQuoteResponse)(nil),  // 4: quote.RequestQuoteResponse
+	(*GetQuoteRequest)(nil),       // 5: quote.GetQuoteRequest
+	(*GetQuoteResponse)(nil),      // 6: quote.GetQuoteResponse
+	(*ListQuotesRequest)(nil),     // 7: quote.ListQuotesRequest
+	(*ListQuotesResponse)(nil),    // 8: quote.ListQuotesResponse
+	(*CounterQuoteRequest)(nil),   // 9: quote.CounterQuoteRequest
+	(*CounterQuoteResponse)(nil),  // 10: quote.CounterQuoteResponse
+	(*ApproveQuoteRequest)(nil),   // 11: quote.ApproveQuoteRequest
+	(*ApproveQuoteResponse)(nil),  // 12: quote.ApproveQuoteResponse
+	(*RejectQuoteRequest)(nil),    // 13: quote.RejectQuoteRequest
+	(*RejectQuoteResponse)(nil),   // 14: quote.RejectQuoteResponse
+	(*AcceptQuoteRequest)(nil),    // 15: quote.AcceptQuoteRequest
+	(*AcceptQuoteResponse)(nil),   // 16: quote.AcceptQuoteResponse
+	(*DeclineQuoteRequest)(nil),   // 17: quote.DeclineQuoteRequest
+	(*DeclineQuoteResponse)(nil),  // 18: quote.DeclineQuoteResponse
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
+}
+var file_quote_quote_proto_depIdxs = []int32{
+	1,  // 0: quote.Quote.items:type_name -> quote.QuoteItem
+	19, // 1: quote.Quote.valid_until:type_name -> google.protobuf.Timestamp
+	19, // 2: quote.Quote.created_at:type_name -> google.protobuf.Timestamp
+	19, // 3: quote.Quote.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 4: quote.RequestQuoteRequest.target_prices:type_name -> quote.ItemPrice
+	0,  // 5: quote.RequestQuoteResponse.quote:type_name -> quote.Quote
+	0,  // 6: quote.GetQuoteResponse.quote:type_name -> quote.Quote
+	0,  // 7: quote.ListQuotesResponse.quotes:type_name -> quote.Quote
+	2,  // 8: quote.CounterQuoteRequest.prices:type_name -> quote.ItemPrice
+	0,  // 9: quote.CounterQuoteResponse.quote:type_name -> quote.Quote
+	0,  // 10: quote.ApproveQuoteResponse.quote:type_name -> quote.Quote
+	0,  // 11: quote.RejectQuoteResponse.quote:type_name -> quote.Quote
+	0,  // 12: quote.AcceptQuoteResponse.quote:type_name -> quote.Quote
+	0,  // 13: quote.DeclineQuoteResponse.quote:type_name -> quote.Quote
+	3,  // 14: quote.QuoteService.RequestQuote:input_type -> quote.RequestQuoteRequest
+	5,  // 15: quote.QuoteService.GetQuote:input_type -> quote.GetQuoteRequest
+	7,  // 16: quote.QuoteService.ListQuotes:input_type -> quote.ListQuotesRequest
+	9,  // 17: quote.QuoteService.CounterQuote:input_type -> quote.CounterQuoteRequest
+	11, // 18: quote.QuoteService.ApproveQuote:input_type -> quote.ApproveQuoteRequest
+	13, // 19: quote.QuoteService.RejectQuote:input_type -> quote.RejectQuoteRequest
+	15, // 20: quote.QuoteService.AcceptQuote:input_type -> quote.AcceptQuoteRequest
+	17, // 21: quote.QuoteService.DeclineQuote:input_type -> quote.DeclineQuoteRequest
+	4,  // 22: quote.QuoteService.RequestQuote:output_type -> quote.RequestQuoteResponse
+	6,  // 23: quote.QuoteService.GetQuote:output_type -> quote.GetQuoteResponse
+	8,  // 24: quote.QuoteService.ListQuotes:output_type -> quote.ListQuotesResponse
+	10, // 25: quote.QuoteService.CounterQuote:output_type -> quote.CounterQuoteResponse
+	12, // 26: quote.QuoteService.ApproveQuote:output_type -> quote.ApproveQuoteResponse
+	14, // 27: quote.QuoteService.RejectQuote:output_type -> quote.RejectQuoteResponse
+	16, // 28: quote.QuoteService.AcceptQuote:output_type -> quote.AcceptQuoteResponse
+	18, // 29: quote.QuoteService.DeclineQuote:output_type -> quote.DeclineQuoteResponse
+	22, // [22:30] is the sub-list for method output_type
+	14, // [14:22] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_quote_quote_proto_init() }
+func file_quote_quote_proto_init() {
+	if File_quote_quote_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quote_quote_proto_rawDesc), len(file_quote_quote_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_quote_quote_proto_goTypes,
+		DependencyIndexes: file_quote_quote_proto_depIdxs,
+		MessageInfos:      file_quote_quote_proto_msgTypes,
+	}.Build()
+	File_quote_quote_proto = out.File
+	file_quote_quote_proto_goTypes = nil
+	file_quote_quote_proto_depIdxs = nil
+}
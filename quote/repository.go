@@ -0,0 +1,325 @@
+package quote
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Quote statuses
+const (
+	StatusRequested = "REQUESTED"
+	StatusCountered = "COUNTERED"
+	StatusApproved  = "APPROVED"
+	StatusRejected  = "REJECTED"
+	StatusDeclined  = "DECLINED"
+	StatusOrdered   = "ORDERED"
+)
+
+var (
+	// ErrQuoteNotFound is returned when a quote does not exist
+	ErrQuoteNotFound = errors.New("quote not found")
+	// ErrStatusConflict is returned when a quote changed since it was read
+	ErrStatusConflict = errors.New("quote status changed")
+)
+
+// Quote is a business customer's request for negotiated prices on the
+// contents of their cart. Amounts are in the catalog currency.
+type Quote struct {
+	ID           string
+	UserID       string
+	Company      string
+	Status       string
+	Items        []Item
+	Note         string
+	ResponseNote string
+	ListTotal    float64
+	QuotedTotal  float64
+	// ValidUntil is set when the quote is countered or approved
+	ValidUntil *time.Time
+	// PONumber and OrderID are set when the quote is ORDERED
+	PONumber  string
+	OrderID   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Item is a cart line of a quote. SKU, Name and ListPrice are snapshots of
+// the catalog when the quote was requested; TargetPrice is 0 when the
+// customer did not ask for a price and QuotedPrice is 0 until the quote is
+// countered or approved.
+type Item struct {
+	ProductID   string
+	SKU         string
+	Name        string
+	Quantity    int32
+	ListPrice   float64
+	TargetPrice float64
+	QuotedPrice float64
+}
+
+// Filter narrows a list of quotes; empty fields match every quote
+type Filter struct {
+	UserID string
+	Status string
+}
+
+// Repository handles quote data persistence
+type Repository interface {
+	Create(ctx context.Context, q *Quote) (*Quote, error)
+	GetByID(ctx context.Context, id string) (*Quote, error)
+	List(ctx context.Context, filter Filter, page, pageSize int32) ([]*Quote, int32, error)
+	// Update saves the status, response, quoted prices, validity and order
+	// of a quote that is still in status from. It returns ErrStatusConflict
+	// when the quote is no longer in status from.
+	Update(ctx context.Context, q *Quote, from string) (*Quote, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL quote repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const quoteColumns = `id, user_id, company, status, note, response_note, list_total, quoted_total, valid_until,
+	po_number, COALESCE(order_id::text, ''), created_at, updated_at`
+
+const itemColumns = `quote_id, product_id, sku, name, quantity, list_price, target_price, quoted_price`
+
+// Create inserts a quote and its items
+func (r *postgresRepository) Create(ctx context.Context, q *Quote) (*Quote, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q.ID = uuid.New().String()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO quotes (id, user_id, company, status, note, list_total, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, q.ID, q.UserID, q.Company, q.Status, q.Note, q.ListTotal, q.CreatedAt, q.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create quote", map[string]interface{}{"error": err.Error(), "user_id": q.UserID})
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	for i, item := range q.Items {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO quote_items (quote_id, product_id, sku, name, quantity, list_price, target_price, position)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, q.ID, item.ProductID, item.SKU, item.Name, item.Quantity, item.ListPrice, item.TargetPrice, i)
+		if err != nil {
+			r.log.Error(ctx, "Failed to create quote item", map[string]interface{}{"error": err.Error(), "quote_id": q.ID})
+			return nil, fmt.Errorf("failed to create quote item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit quote: %w", err)
+	}
+
+	r.log.Info(ctx, "Quote created", map[string]interface{}{"quote_id": q.ID, "user_id": q.UserID, "items": len(q.Items)})
+	return q, nil
+}
+
+// GetByID retrieves a quote and its items
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Quote, error) {
+	query := "SELECT " + quoteColumns + " FROM quotes WHERE id = $1"
+
+	q, err := scanQuote(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrQuoteNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get quote", map[string]interface{}{"error": err.Error(), "quote_id": id})
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+
+	if err := r.loadItems(ctx, []*Quote{q}); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// List retrieves quotes with pagination, newest first
+func (r *postgresRepository) List(ctx context.Context, filter Filter, page, pageSize int32) ([]*Quote, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	const where = `
+		WHERE ($1 = '' OR user_id = $1)
+			AND ($2 = '' OR status = $2)`
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM quotes"+where, filter.UserID, filter.Status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count quotes", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count quotes: %w", err)
+	}
+
+	query := "SELECT " + quoteColumns + " FROM quotes" + where + `
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.UserID, filter.Status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list quotes", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list quotes: %w", err)
+	}
+	defer rows.Close()
+
+	quotes := make([]*Quote, 0, pageSize)
+	for rows.Next() {
+		q, err := scanQuote(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan quote", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan quote: %w", err)
+		}
+		quotes = append(quotes, q)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating quotes", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating quotes: %w", err)
+	}
+
+	if err := r.loadItems(ctx, quotes); err != nil {
+		return nil, 0, err
+	}
+	return quotes, total, nil
+}
+
+// loadItems reads the items of quotes in one query, in cart order
+func (r *postgresRepository) loadItems(ctx context.Context, quotes []*Quote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+	ids := make([]string, len(quotes))
+	byID := make(map[string]*Quote, len(quotes))
+	for i, q := range quotes {
+		ids[i] = q.ID
+		byID[q.ID] = q
+		q.Items = []Item{}
+	}
+
+	query := "SELECT " + itemColumns + " FROM quote_items WHERE quote_id = ANY($1) ORDER BY quote_id, position"
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get quote items", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get quote items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var quoteID string
+		var item Item
+		if err := rows.Scan(&quoteID, &item.ProductID, &item.SKU, &item.Name, &item.Quantity,
+			&item.ListPrice, &item.TargetPrice, &item.QuotedPrice); err != nil {
+			return fmt.Errorf("failed to scan quote item: %w", err)
+		}
+		if q, ok := byID[quoteID]; ok {
+			q.Items = append(q.Items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating quote items: %w", err)
+	}
+	return nil
+}
+
+// Update saves a quote and the quoted prices of its items, only while the
+// quote is still in status from, so that concurrent responses cannot both
+// succeed
+func (r *postgresRepository) Update(ctx context.Context, q *Quote, from string) (*Quote, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE quotes
+		SET status = $3, response_note = $4, quoted_total = $5, valid_until = $6, po_number = $7,
+			order_id = NULLIF($8, '')::uuid, updated_at = $9
+		WHERE id = $1 AND status = $2
+	`, q.ID, from, q.Status, q.ResponseNote, q.QuotedTotal, q.ValidUntil, q.PONumber, q.OrderID, q.UpdatedAt)
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrQuoteNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update quote", map[string]interface{}{"error": err.Error(), "quote_id": q.ID})
+		return nil, fmt.Errorf("failed to update quote: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update quote: %w", err)
+	}
+	if rows == 0 {
+		if _, err := r.GetByID(ctx, q.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	}
+
+	for _, item := range q.Items {
+		_, err := tx.ExecContext(ctx,
+			"UPDATE quote_items SET quoted_price = $3 WHERE quote_id = $1 AND product_id = $2", q.ID, item.ProductID, item.QuotedPrice)
+		if err != nil {
+			r.log.Error(ctx, "Failed to update quote item", map[string]interface{}{"error": err.Error(), "quote_id": q.ID, "product_id": item.ProductID})
+			return nil, fmt.Errorf("failed to update quote item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit quote: %w", err)
+	}
+
+	r.log.Info(ctx, "Quote updated", map[string]interface{}{"quote_id": q.ID, "from": from, "to": q.Status})
+	return r.GetByID(ctx, q.ID)
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanQuote(row rowScanner) (*Quote, error) {
+	q := &Quote{}
+	var validUntil sql.NullTime
+	err := row.Scan(&q.ID, &q.UserID, &q.Company, &q.Status, &q.Note, &q.ResponseNote, &q.ListTotal, &q.QuotedTotal,
+		&validUntil, &q.PONumber, &q.OrderID, &q.CreatedAt, &q.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if validUntil.Valid {
+		q.ValidUntil = &validUntil.Time
+	}
+	return q, nil
+}
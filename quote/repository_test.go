@@ -0,0 +1,176 @@
+package quote
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("quote-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var quoteColumnNames = []string{
+	"id", "user_id", "company", "status", "note", "response_note", "list_total", "quoted_total", "valid_until",
+	"po_number", "order_id", "created_at", "updated_at",
+}
+
+var itemColumnNames = []string{"quote_id", "product_id", "sku", "name", "quantity", "list_price", "target_price", "quoted_price"}
+
+func testQuote() *Quote {
+	now := time.Now()
+	return &Quote{
+		UserID:    "buyer-1",
+		Company:   "Acme Offices",
+		Status:    StatusRequested,
+		Items:     []Item{{ProductID: "chair", SKU: "CHAIR-1", Name: "Chair", Quantity: 40, ListPrice: 89.99, TargetPrice: 75}},
+		ListTotal: 3599.6,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	q := testQuote()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO quotes`).
+		WithArgs(sqlmock.AnyArg(), "buyer-1", "Acme Offices", StatusRequested, "", 3599.6, q.CreatedAt, q.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO quote_items`).
+		WithArgs(sqlmock.AnyArg(), "chair", "CHAIR-1", "Chair", int32(40), 89.99, 75.0, 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	created, err := repo.Create(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	validUntil := now.Add(DefaultValidity)
+	mock.ExpectQuery(`SELECT .* FROM quotes WHERE id = \$1`).
+		WithArgs("quote-1").
+		WillReturnRows(sqlmock.NewRows(quoteColumnNames).
+			AddRow("quote-1", "buyer-1", "Acme Offices", StatusApproved, "", "", 3599.6, 3000.0, validUntil, "", "", now, now))
+	mock.ExpectQuery(`SELECT .* FROM quote_items WHERE quote_id = ANY\(\$1\)`).
+		WithArgs(pq.Array([]string{"quote-1"})).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames).
+			AddRow("quote-1", "chair", "CHAIR-1", "Chair", 40, 89.99, 75.0, 75.0))
+
+	q, err := repo.GetByID(context.Background(), "quote-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if q.ValidUntil == nil || !q.ValidUntil.Equal(validUntil) || q.QuotedTotal != 3000 {
+		t.Errorf("Unexpected quote %+v", q)
+	}
+	if len(q.Items) != 1 || q.Items[0].QuotedPrice != 75 {
+		t.Errorf("Unexpected items %+v", q.Items)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM quotes WHERE id = \$1`).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM quotes WHERE id = \$1`).WithArgs("not-a-uuid").WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(context.Background(), id); err != ErrQuoteNotFound {
+			t.Errorf("Expected ErrQuoteNotFound for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	q := testQuote()
+	q.ID = "quote-1"
+	q.Status = StatusApproved
+	q.Items[0].QuotedPrice = 75
+	q.QuotedTotal = 3000
+	q.ValidUntil = &now
+	q.UpdatedAt = now
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE quotes\s+SET status = \$3, .*\s+WHERE id = \$1 AND status = \$2`).
+		WithArgs("quote-1", StatusRequested, StatusApproved, "", 3000.0, q.ValidUntil, "", "", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE quote_items SET quoted_price = \$3 WHERE quote_id = \$1 AND product_id = \$2`).
+		WithArgs("quote-1", "chair", 75.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT .* FROM quotes WHERE id = \$1`).
+		WithArgs("quote-1").
+		WillReturnRows(sqlmock.NewRows(quoteColumnNames).
+			AddRow("quote-1", "buyer-1", "Acme Offices", StatusApproved, "", "", 3599.6, 3000.0, now, "", "", now, now))
+	mock.ExpectQuery(`FROM quote_items`).WillReturnRows(sqlmock.NewRows(itemColumnNames))
+
+	updated, err := repo.Update(context.Background(), q, StatusRequested)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Status != StatusApproved {
+		t.Errorf("Expected APPROVED, got %s", updated.Status)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdate_Conflict(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	q := testQuote()
+	q.ID = "quote-1"
+	q.Status = StatusApproved
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE quotes`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM quotes WHERE id = \$1`).
+		WithArgs("quote-1").
+		WillReturnRows(sqlmock.NewRows(quoteColumnNames).
+			AddRow("quote-1", "buyer-1", "Acme Offices", StatusRejected, "", "No", 3599.6, 0.0, nil, "", "", now, now))
+	mock.ExpectQuery(`FROM quote_items`).WillReturnRows(sqlmock.NewRows(itemColumnNames))
+	mock.ExpectRollback()
+
+	if _, err := repo.Update(context.Background(), q, StatusRequested); err != ErrStatusConflict {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
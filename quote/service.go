@@ -0,0 +1,580 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/quote/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the user_id and company columns of quotes
+	maxIDLength = 255
+	// maxTextLength caps a customer note and a response note
+	maxTextLength = 1000
+	// maxPONumberLength matches the po_number column of quotes
+	maxPONumberLength = 100
+	// maxQuoteItems matches the cart service's limit on products per cart
+	maxQuoteItems = 100
+	// maxValidDays caps how long a countered or approved quote can stand
+	maxValidDays = 365
+	// DefaultValidity is how long a countered or approved quote stands when
+	// the response does not say
+	DefaultValidity = 30 * 24 * time.Hour
+)
+
+// validStatuses are the statuses a quote list can be filtered by
+var validStatuses = map[string]bool{
+	StatusRequested: true, StatusCountered: true, StatusApproved: true, StatusRejected: true, StatusDeclined: true, StatusOrdered: true,
+}
+
+// AdminMethods are the admin-scoped RPCs of the quote service, used by sales;
+// they are restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.QuoteService_ListQuotes_FullMethodName,
+	pb.QuoteService_CounterQuote_FullMethodName,
+	pb.QuoteService_ApproveQuote_FullMethodName,
+	pb.QuoteService_RejectQuote_FullMethodName,
+}
+
+// Service implements the QuoteService gRPC interface
+type Service struct {
+	pb.UnimplementedQuoteServiceServer
+	repo     Repository
+	carts    Carts
+	catalog  Catalog
+	orders   Orders
+	validity time.Duration
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+// NewService creates a new quote service. Countered and approved quotes
+// stand for validity unless the response says otherwise.
+func NewService(repo Repository, carts Carts, catalog Catalog, orders Orders, validity time.Duration, log *logger.Logger) *Service {
+	return &Service{
+		repo:     repo,
+		carts:    carts,
+		catalog:  catalog,
+		orders:   orders,
+		validity: validity,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// RequestQuote requests a quote on the contents of the customer's cart. Each
+// line is priced at its catalog price for the quantity, and the customer may
+// name the unit prices they are after.
+func (s *Service) RequestQuote(ctx context.Context, req *pb.RequestQuoteRequest) (*pb.RequestQuoteResponse, error) {
+	company, note := strings.TrimSpace(req.Company), strings.TrimSpace(req.Note)
+	if msg := requestProblem(req, company, note); msg != "" {
+		s.log.Warn(ctx, "Request quote failed: "+msg, map[string]interface{}{"user_id": req.UserId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	lines, err := s.carts.Contents(ctx, req.UserId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get cart", map[string]interface{}{"error": err.Error(), "user_id": req.UserId})
+		return nil, status.Error(codes.Unavailable, "failed to get cart")
+	}
+	if len(lines) == 0 {
+		s.log.Warn(ctx, "Request quote failed: cart is empty", map[string]interface{}{"user_id": req.UserId})
+		return nil, status.Error(codes.FailedPrecondition, "cart is empty")
+	}
+	if len(lines) > maxQuoteItems {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("a quote cannot have more than %d items", maxQuoteItems))
+	}
+
+	inCart := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		inCart[line.ProductID] = true
+	}
+	targets := make(map[string]float64, len(req.TargetPrices))
+	for _, target := range req.TargetPrices {
+		if !inCart[target.ProductId] {
+			msg := fmt.Sprintf("product %s is not in the cart", target.ProductId)
+			s.log.Warn(ctx, "Request quote failed: "+msg, map[string]interface{}{"user_id": req.UserId})
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+		targets[target.ProductId] = target.UnitPrice
+	}
+
+	now := s.now()
+	q := &Quote{
+		UserID:    req.UserId,
+		Company:   company,
+		Status:    StatusRequested,
+		Note:      note,
+		Items:     make([]Item, len(lines)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for i, line := range lines {
+		price, err := s.catalog.ListPrice(ctx, line.ProductID, line.Quantity)
+		if err != nil {
+			return nil, s.listPriceError(ctx, err, line.ProductID)
+		}
+		q.Items[i] = Item{
+			ProductID:   line.ProductID,
+			SKU:         price.SKU,
+			Name:        price.Name,
+			Quantity:    line.Quantity,
+			ListPrice:   price.UnitPrice,
+			TargetPrice: targets[line.ProductID],
+		}
+		q.ListTotal += roundCents(price.UnitPrice * float64(line.Quantity))
+	}
+	q.ListTotal = roundCents(q.ListTotal)
+
+	created, err := s.repo.Create(ctx, q)
+	if err != nil {
+		s.log.Error(ctx, "Failed to create quote", map[string]interface{}{"error": err.Error(), "user_id": req.UserId})
+		return nil, status.Error(codes.Internal, "failed to create quote")
+	}
+
+	return &pb.RequestQuoteResponse{
+		Quote: toProtoQuote(created),
+	}, nil
+}
+
+// requestProblem validates a quote request and returns a message describing
+// the first problem, or ""
+func requestProblem(req *pb.RequestQuoteRequest, company, note string) string {
+	switch {
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return "user_id is too long"
+	case company == "":
+		return "company is required"
+	case utf8.RuneCountInString(company) > maxIDLength:
+		return fmt.Sprintf("company cannot exceed %d characters", maxIDLength)
+	case utf8.RuneCountInString(note) > maxTextLength:
+		return fmt.Sprintf("note cannot exceed %d characters", maxTextLength)
+	}
+
+	seen := map[string]bool{}
+	for _, target := range req.TargetPrices {
+		switch {
+		case target.ProductId == "":
+			return "product_id is required"
+		case !validPrice(target.UnitPrice) || target.UnitPrice == 0:
+			return "target unit_price must be positive"
+		case seen[target.ProductId]:
+			return fmt.Sprintf("product %s is listed more than once", target.ProductId)
+		}
+		seen[target.ProductId] = true
+	}
+	return ""
+}
+
+// listPriceError maps an error reading a cart line's list price to a gRPC
+// status
+func (s *Service) listPriceError(ctx context.Context, err error, productID string) error {
+	switch {
+	case errors.Is(err, ErrProductUnavailable):
+		s.log.Warn(ctx, "Request quote failed: product unavailable", map[string]interface{}{"product_id": productID})
+		return status.Error(codes.FailedPrecondition, "product "+productID+" is unavailable")
+	case errors.Is(err, ErrInvalidQuantity):
+		s.log.Warn(ctx, "Request quote failed: invalid quantity", map[string]interface{}{"product_id": productID, "error": err.Error()})
+		return status.Error(codes.FailedPrecondition, "product "+productID+": "+err.Error())
+	}
+	s.log.Error(ctx, "Failed to price quote item", map[string]interface{}{"error": err.Error(), "product_id": productID})
+	return status.Error(codes.Unavailable, "failed to price quote")
+}
+
+// GetQuote retrieves a quote by ID
+func (s *Service) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.GetQuoteResponse, error) {
+	q, err := s.load(ctx, req.QuoteId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetQuoteResponse{
+		Quote: toProtoQuote(q),
+	}, nil
+}
+
+// ListQuotes lists quotes, newest first
+func (s *Service) ListQuotes(ctx context.Context, req *pb.ListQuotesRequest) (*pb.ListQuotesResponse, error) {
+	if req.Status != "" && !validStatuses[req.Status] {
+		s.log.Warn(ctx, "List quotes failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be REQUESTED, COUNTERED, APPROVED, REJECTED, DECLINED or ORDERED")
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	quotes, total, err := s.repo.List(ctx, Filter{UserID: req.UserId, Status: req.Status}, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list quotes", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list quotes")
+	}
+
+	protoQuotes := make([]*pb.Quote, len(quotes))
+	for i, q := range quotes {
+		protoQuotes[i] = toProtoQuote(q)
+	}
+
+	return &pb.ListQuotesResponse{
+		Quotes:   protoQuotes,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// CounterQuote offers the customer prices of sales' choosing on a REQUESTED
+// or COUNTERED quote
+func (s *Service) CounterQuote(ctx context.Context, req *pb.CounterQuoteRequest) (*pb.CounterQuoteResponse, error) {
+	if msg := responseProblem(req.Note, req.ValidDays); msg != "" {
+		s.log.Warn(ctx, "Counter quote failed: "+msg, map[string]interface{}{"quote_id": req.QuoteId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	prices := make(map[string]float64, len(req.Prices))
+	for _, price := range req.Prices {
+		msg := ""
+		switch _, dup := prices[price.ProductId]; {
+		case price.ProductId == "":
+			msg = "product_id is required"
+		case !validPrice(price.UnitPrice):
+			msg = "unit_price must be a non-negative amount"
+		case dup:
+			msg = fmt.Sprintf("product %s is listed more than once", price.ProductId)
+		}
+		if msg != "" {
+			s.log.Warn(ctx, "Counter quote failed: "+msg, map[string]interface{}{"quote_id": req.QuoteId})
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+		prices[price.ProductId] = price.UnitPrice
+	}
+
+	q, err := s.load(ctx, req.QuoteId)
+	if err != nil {
+		return nil, err
+	}
+	if q.Status != StatusRequested && q.Status != StatusCountered {
+		return nil, status.Error(codes.FailedPrecondition, "quote is "+q.Status+", not REQUESTED or COUNTERED")
+	}
+
+	for i := range q.Items {
+		item := &q.Items[i]
+		if price, ok := prices[item.ProductID]; ok {
+			item.QuotedPrice = price
+			delete(prices, item.ProductID)
+		} else if q.Status == StatusRequested {
+			item.QuotedPrice = askingPrice(item)
+		}
+	}
+	for productID := range prices {
+		msg := fmt.Sprintf("product %s is not on the quote", productID)
+		s.log.Warn(ctx, "Counter quote failed: "+msg, map[string]interface{}{"quote_id": q.ID})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	countered, err := s.respond(ctx, q, StatusCountered, req.Note, req.ValidDays)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CounterQuoteResponse{
+		Quote: toProtoQuote(countered),
+	}, nil
+}
+
+// ApproveQuote grants a REQUESTED quote at the customer's target prices
+func (s *Service) ApproveQuote(ctx context.Context, req *pb.ApproveQuoteRequest) (*pb.ApproveQuoteResponse, error) {
+	if msg := responseProblem(req.Note, req.ValidDays); msg != "" {
+		s.log.Warn(ctx, "Approve quote failed: "+msg, map[string]interface{}{"quote_id": req.QuoteId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	q, err := s.load(ctx, req.QuoteId)
+	if err != nil {
+		return nil, err
+	}
+	if q.Status != StatusRequested {
+		return nil, status.Error(codes.FailedPrecondition, "quote is "+q.Status+", not REQUESTED")
+	}
+	for i := range q.Items {
+		q.Items[i].QuotedPrice = askingPrice(&q.Items[i])
+	}
+
+	approved, err := s.respond(ctx, q, StatusApproved, req.Note, req.ValidDays)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ApproveQuoteResponse{
+		Quote: toProtoQuote(approved),
+	}, nil
+}
+
+// RejectQuote turns down a REQUESTED or COUNTERED quote. The note tells the
+// customer why.
+func (s *Service) RejectQuote(ctx context.Context, req *pb.RejectQuoteRequest) (*pb.RejectQuoteResponse, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		s.log.Warn(ctx, "Reject quote failed: note is required", map[string]interface{}{"quote_id": req.QuoteId})
+		return nil, status.Error(codes.InvalidArgument, "note is required")
+	}
+	if msg := responseProblem(note, 0); msg != "" {
+		s.log.Warn(ctx, "Reject quote failed: "+msg, map[string]interface{}{"quote_id": req.QuoteId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	q, err := s.load(ctx, req.QuoteId)
+	if err != nil {
+		return nil, err
+	}
+	if q.Status != StatusRequested && q.Status != StatusCountered {
+		return nil, status.Error(codes.FailedPrecondition, "quote is "+q.Status+", not REQUESTED or COUNTERED")
+	}
+
+	from := q.Status
+	q.Status, q.ResponseNote, q.UpdatedAt = StatusRejected, note, s.now()
+	rejected, err := s.repo.Update(ctx, q, from)
+	if err != nil {
+		return nil, s.updateError(ctx, err, q.ID)
+	}
+	return &pb.RejectQuoteResponse{
+		Quote: toProtoQuote(rejected),
+	}, nil
+}
+
+// AcceptQuote accepts a COUNTERED or APPROVED quote before it expires and
+// converts it into an order at the quoted prices, under the customer's
+// purchase order number. A quote whose order was placed but not recorded is
+// converted again on retry, which returns the same order.
+func (s *Service) AcceptQuote(ctx context.Context, req *pb.AcceptQuoteRequest) (*pb.AcceptQuoteResponse, error) {
+	poNumber := strings.TrimSpace(req.PoNumber)
+	switch {
+	case poNumber == "":
+		s.log.Warn(ctx, "Accept quote failed: PO number is required", map[string]interface{}{"quote_id": req.QuoteId})
+		return nil, status.Error(codes.InvalidArgument, "po_number is required")
+	case len(poNumber) > maxPONumberLength:
+		s.log.Warn(ctx, "Accept quote failed: PO number too long", map[string]interface{}{"quote_id": req.QuoteId})
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("po_number cannot exceed %d characters", maxPONumberLength))
+	}
+
+	q, err := s.loadOwned(ctx, req.QuoteId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.offerProblem(ctx, q); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orders.CreateOrder(ctx, q, poNumber)
+	if errors.Is(err, ErrOrderRejected) {
+		s.log.Warn(ctx, "Accept quote failed: order rejected", map[string]interface{}{"quote_id": q.ID, "error": err.Error()})
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to create quoted order", map[string]interface{}{"error": err.Error(), "quote_id": q.ID})
+		return nil, status.Error(codes.Unavailable, "failed to create order")
+	}
+
+	// The order is placed, so it is recorded even if the caller has gone
+	from := q.Status
+	q.Status, q.OrderID, q.PONumber, q.UpdatedAt = StatusOrdered, order.ID, order.PONumber, s.now()
+	ordered, err := s.repo.Update(context.WithoutCancel(ctx), q, from)
+	if err != nil {
+		return nil, s.updateError(ctx, err, q.ID)
+	}
+
+	s.log.Info(ctx, "Quote ordered", map[string]interface{}{"quote_id": q.ID, "order_id": order.ID})
+	return &pb.AcceptQuoteResponse{
+		Quote: toProtoQuote(ordered),
+	}, nil
+}
+
+// DeclineQuote turns down a COUNTERED or APPROVED quote
+func (s *Service) DeclineQuote(ctx context.Context, req *pb.DeclineQuoteRequest) (*pb.DeclineQuoteResponse, error) {
+	q, err := s.loadOwned(ctx, req.QuoteId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if q.Status != StatusCountered && q.Status != StatusApproved {
+		return nil, status.Error(codes.FailedPrecondition, "quote is "+q.Status+", not COUNTERED or APPROVED")
+	}
+
+	from := q.Status
+	q.Status, q.UpdatedAt = StatusDeclined, s.now()
+	declined, err := s.repo.Update(ctx, q, from)
+	if err != nil {
+		return nil, s.updateError(ctx, err, q.ID)
+	}
+	return &pb.DeclineQuoteResponse{
+		Quote: toProtoQuote(declined),
+	}, nil
+}
+
+// offerProblem checks that a quote can be accepted: it is COUNTERED or
+// APPROVED and has not expired
+func (s *Service) offerProblem(ctx context.Context, q *Quote) error {
+	if q.Status != StatusCountered && q.Status != StatusApproved {
+		return status.Error(codes.FailedPrecondition, "quote is "+q.Status+", not COUNTERED or APPROVED")
+	}
+	if q.ValidUntil != nil && s.now().After(*q.ValidUntil) {
+		s.log.Warn(ctx, "Accept quote failed: quote expired", map[string]interface{}{"quote_id": q.ID})
+		return status.Error(codes.FailedPrecondition, "quote expired on "+q.ValidUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// respond saves sales' response to a quote with its quoted prices set: the
+// new status, the note and how long the offer stands
+func (s *Service) respond(ctx context.Context, q *Quote, to, note string, validDays int32) (*Quote, error) {
+	validity := s.validity
+	if validDays > 0 {
+		validity = time.Duration(validDays) * 24 * time.Hour
+	}
+	now := s.now()
+	validUntil := now.Add(validity)
+
+	from := q.Status
+	q.Status, q.ResponseNote, q.ValidUntil, q.UpdatedAt = to, strings.TrimSpace(note), &validUntil, now
+	q.QuotedTotal = 0
+	for _, item := range q.Items {
+		q.QuotedTotal += roundCents(item.QuotedPrice * float64(item.Quantity))
+	}
+	q.QuotedTotal = roundCents(q.QuotedTotal)
+
+	updated, err := s.repo.Update(ctx, q, from)
+	if err != nil {
+		return nil, s.updateError(ctx, err, q.ID)
+	}
+	return updated, nil
+}
+
+// responseProblem validates the note and validity of a response to a quote
+// and returns a message describing the first problem, or ""
+func responseProblem(note string, validDays int32) string {
+	switch {
+	case utf8.RuneCountInString(strings.TrimSpace(note)) > maxTextLength:
+		return fmt.Sprintf("note cannot exceed %d characters", maxTextLength)
+	case validDays < 0 || validDays > maxValidDays:
+		return fmt.Sprintf("valid_days must be between 0 and %d", maxValidDays)
+	}
+	return ""
+}
+
+// askingPrice is the unit price the customer asked for on a line, or its
+// list price when they did not ask
+func askingPrice(item *Item) float64 {
+	if item.TargetPrice > 0 {
+		return item.TargetPrice
+	}
+	return item.ListPrice
+}
+
+// validPrice reports whether a unit price is a finite, non-negative amount
+func validPrice(price float64) bool {
+	return price >= 0 && !math.IsInf(price, 0) && !math.IsNaN(price)
+}
+
+// load reads a quote by ID
+func (s *Service) load(ctx context.Context, quoteID string) (*Quote, error) {
+	if quoteID == "" {
+		s.log.Warn(ctx, "Quote request failed: quote ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "quote_id is required")
+	}
+
+	q, err := s.repo.GetByID(ctx, quoteID)
+	if errors.Is(err, ErrQuoteNotFound) {
+		s.log.Warn(ctx, "Quote not found", map[string]interface{}{"quote_id": quoteID})
+		return nil, status.Error(codes.NotFound, "quote not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get quote", map[string]interface{}{"error": err.Error(), "quote_id": quoteID})
+		return nil, status.Error(codes.Internal, "failed to get quote")
+	}
+	return q, nil
+}
+
+// loadOwned reads a quote of the customer userID; another customer's quote is
+// reported as not found
+func (s *Service) loadOwned(ctx context.Context, quoteID, userID string) (*Quote, error) {
+	if userID == "" {
+		s.log.Warn(ctx, "Quote request failed: user ID is required", map[string]interface{}{"quote_id": quoteID})
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	q, err := s.load(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if q.UserID != userID {
+		s.log.Warn(ctx, "Quote not found for user", map[string]interface{}{"quote_id": quoteID, "user_id": userID})
+		return nil, status.Error(codes.NotFound, "quote not found")
+	}
+	return q, nil
+}
+
+// updateError maps an error changing a quote to a gRPC status
+func (s *Service) updateError(ctx context.Context, err error, quoteID string) error {
+	switch {
+	case errors.Is(err, ErrQuoteNotFound):
+		return status.Error(codes.NotFound, "quote not found")
+	case errors.Is(err, ErrStatusConflict):
+		s.log.Warn(ctx, "Quote changed by another request", map[string]interface{}{"quote_id": quoteID})
+		return status.Error(codes.Aborted, "quote was changed by another request")
+	}
+	s.log.Error(ctx, "Failed to update quote", map[string]interface{}{"error": err.Error(), "quote_id": quoteID})
+	return status.Error(codes.Internal, "failed to update quote")
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// toProtoQuote converts a quote to protobuf
+func toProtoQuote(q *Quote) *pb.Quote {
+	quote := &pb.Quote{
+		Id:           q.ID,
+		UserId:       q.UserID,
+		Company:      q.Company,
+		Status:       q.Status,
+		Items:        make([]*pb.QuoteItem, len(q.Items)),
+		Note:         q.Note,
+		ResponseNote: q.ResponseNote,
+		ListTotal:    q.ListTotal,
+		QuotedTotal:  q.QuotedTotal,
+		PoNumber:     q.PONumber,
+		OrderId:      q.OrderID,
+		CreatedAt:    timestamppb.New(q.CreatedAt),
+		UpdatedAt:    timestamppb.New(q.UpdatedAt),
+	}
+	for i, item := range q.Items {
+		quote.Items[i] = &pb.QuoteItem{
+			ProductId:   item.ProductID,
+			Sku:         item.SKU,
+			Name:        item.Name,
+			Quantity:    item.Quantity,
+			ListPrice:   item.ListPrice,
+			TargetPrice: item.TargetPrice,
+			QuotedPrice: item.QuotedPrice,
+		}
+	}
+	if q.ValidUntil != nil {
+		quote.ValidUntil = timestamppb.New(*q.ValidUntil)
+	}
+	return quote
+}
@@ -0,0 +1,428 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/quote/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps quotes by ID and updates a quote only from the
+// status the caller names
+type memoryRepository struct {
+	mu     sync.Mutex
+	quotes map[string]Quote
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{quotes: map[string]Quote{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, q *Quote) (*Quote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q.ID = uuid.New().String()
+	m.store(q)
+	return m.get(q.ID), nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Quote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.quotes[id]; !ok {
+		return nil, ErrQuoteNotFound
+	}
+	return m.get(id), nil
+}
+
+func (m *memoryRepository) List(ctx context.Context, filter Filter, page, pageSize int32) ([]*Quote, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []*Quote
+	for id, q := range m.quotes {
+		if (filter.UserID == "" || q.UserID == filter.UserID) && (filter.Status == "" || q.Status == filter.Status) {
+			matched = append(matched, m.get(id))
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	start := int((page - 1) * pageSize)
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + int(pageSize)
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], int32(len(matched)), nil
+}
+
+func (m *memoryRepository) Update(ctx context.Context, q *Quote, from string) (*Quote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.quotes[q.ID]
+	if !ok {
+		return nil, ErrQuoteNotFound
+	}
+	if current.Status != from {
+		return nil, ErrStatusConflict
+	}
+	m.store(q)
+	return m.get(q.ID), nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// store keeps a copy of a quote, so callers cannot change it in place
+func (m *memoryRepository) store(q *Quote) {
+	stored := *q
+	stored.Items = append([]Item(nil), q.Items...)
+	m.quotes[q.ID] = stored
+}
+
+func (m *memoryRepository) get(id string) *Quote {
+	q := m.quotes[id]
+	q.Items = append([]Item(nil), q.Items...)
+	return &q
+}
+
+// fakeCarts serves fixed carts
+type fakeCarts struct {
+	carts map[string][]CartLine
+	err   error
+}
+
+func (f *fakeCarts) Contents(ctx context.Context, userID string) ([]CartLine, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.carts[userID], nil
+}
+
+// fakeCatalog prices every product at its price in prices
+type fakeCatalog struct {
+	prices map[string]float64
+}
+
+func (f *fakeCatalog) ListPrice(ctx context.Context, productID string, quantity int32) (*ListPrice, error) {
+	price, ok := f.prices[productID]
+	if !ok {
+		return nil, ErrProductUnavailable
+	}
+	return &ListPrice{SKU: "SKU-" + productID, Name: "Product " + productID, UnitPrice: price}, nil
+}
+
+// fakeOrders places one order per quote, like the order service
+type fakeOrders struct {
+	orders map[string]*PlacedOrder
+	placed []*Quote
+	err    error
+}
+
+func (f *fakeOrders) CreateOrder(ctx context.Context, q *Quote, poNumber string) (*PlacedOrder, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if order, ok := f.orders[q.ID]; ok {
+		return order, nil
+	}
+	order := &PlacedOrder{ID: "order-" + q.ID, PONumber: poNumber}
+	f.orders[q.ID] = order
+	f.placed = append(f.placed, q)
+	return order, nil
+}
+
+var now = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	service *Service
+	repo    *memoryRepository
+	carts   *fakeCarts
+	orders  *fakeOrders
+}
+
+func setupService(t *testing.T) *fixture {
+	f := &fixture{
+		repo: newMemoryRepository(),
+		carts: &fakeCarts{carts: map[string][]CartLine{
+			"buyer-1": {{ProductID: "chair", Quantity: 40}, {ProductID: "desk", Quantity: 20}},
+		}},
+		orders: &fakeOrders{orders: map[string]*PlacedOrder{}},
+	}
+	catalog := &fakeCatalog{prices: map[string]float64{"chair": 89.99, "desk": 249}}
+	f.service = NewService(f.repo, f.carts, catalog, f.orders, DefaultValidity, logger.New("quote-test"))
+	f.service.now = func() time.Time { return now }
+	return f
+}
+
+func validRequest() *pb.RequestQuoteRequest {
+	return &pb.RequestQuoteRequest{
+		UserId:       "buyer-1",
+		Company:      " Acme Offices ",
+		Note:         "Fit-out of our new floor",
+		TargetPrices: []*pb.ItemPrice{{ProductId: "chair", UnitPrice: 75}},
+	}
+}
+
+// request requests a quote and fails the test on error
+func (f *fixture) request(t *testing.T) *pb.Quote {
+	resp, err := f.service.RequestQuote(context.Background(), validRequest())
+	if err != nil {
+		t.Fatalf("Failed to request quote: %v", err)
+	}
+	return resp.Quote
+}
+
+func TestRequestQuote(t *testing.T) {
+	f := setupService(t)
+
+	q := f.request(t)
+	if q.Status != StatusRequested || q.Company != "Acme Offices" || q.UserId != "buyer-1" {
+		t.Errorf("Unexpected quote %+v", q)
+	}
+	// 40 chairs at 89.99 and 20 desks at 249
+	if q.ListTotal != 8579.6 || q.QuotedTotal != 0 || q.ValidUntil != nil {
+		t.Errorf("Unexpected totals %+v", q)
+	}
+	if len(q.Items) != 2 || q.Items[0].Sku != "SKU-chair" || q.Items[0].ListPrice != 89.99 || q.Items[0].TargetPrice != 75 || q.Items[1].TargetPrice != 0 {
+		t.Errorf("Unexpected items %+v", q.Items)
+	}
+}
+
+func TestRequestQuote_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*fixture, *pb.RequestQuoteRequest)
+		expected codes.Code
+	}{
+		{"missing user", func(f *fixture, r *pb.RequestQuoteRequest) { r.UserId = "" }, codes.InvalidArgument},
+		{"missing company", func(f *fixture, r *pb.RequestQuoteRequest) { r.Company = " " }, codes.InvalidArgument},
+		{"zero target", func(f *fixture, r *pb.RequestQuoteRequest) { r.TargetPrices[0].UnitPrice = 0 }, codes.InvalidArgument},
+		{"target not in cart", func(f *fixture, r *pb.RequestQuoteRequest) { r.TargetPrices[0].ProductId = "lamp" }, codes.InvalidArgument},
+		{"empty cart", func(f *fixture, r *pb.RequestQuoteRequest) { r.UserId, r.TargetPrices = "buyer-2", nil }, codes.FailedPrecondition},
+		{"unavailable product", func(f *fixture, r *pb.RequestQuoteRequest) {
+			f.carts.carts["buyer-1"] = append(f.carts.carts["buyer-1"], CartLine{ProductID: "gone", Quantity: 1})
+		}, codes.FailedPrecondition},
+		{"cart service down", func(f *fixture, r *pb.RequestQuoteRequest) { f.carts.err = errors.New("connection refused") }, codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			req := validRequest()
+			tt.modify(f, req)
+			if _, err := f.service.RequestQuote(context.Background(), req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if len(f.repo.quotes) != 0 {
+				t.Errorf("Expected no quote, got %d", len(f.repo.quotes))
+			}
+		})
+	}
+}
+
+func TestCounterQuote(t *testing.T) {
+	f := setupService(t)
+	q := f.request(t)
+
+	resp, err := f.service.CounterQuote(context.Background(), &pb.CounterQuoteRequest{
+		QuoteId:   q.Id,
+		Prices:    []*pb.ItemPrice{{ProductId: "chair", UnitPrice: 80}},
+		Note:      "Best we can do on chairs",
+		ValidDays: 14,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := resp.Quote
+	// Chairs at the counter price, desks at list since no target was given
+	if got.Status != StatusCountered || got.Items[0].QuotedPrice != 80 || got.Items[1].QuotedPrice != 249 || got.QuotedTotal != 8180 {
+		t.Errorf("Unexpected quote %+v", got)
+	}
+	if !got.ValidUntil.AsTime().Equal(now.Add(14*24*time.Hour)) || got.ResponseNote != "Best we can do on chairs" {
+		t.Errorf("Unexpected response %+v", got)
+	}
+
+	// Countering again keeps the lines not priced
+	resp, err = f.service.CounterQuote(context.Background(), &pb.CounterQuoteRequest{
+		QuoteId: q.Id,
+		Prices:  []*pb.ItemPrice{{ProductId: "desk", UnitPrice: 230}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := resp.Quote; got.Items[0].QuotedPrice != 80 || got.Items[1].QuotedPrice != 230 || got.QuotedTotal != 7800 {
+		t.Errorf("Unexpected quote %+v", got)
+	}
+	if !resp.Quote.ValidUntil.AsTime().Equal(now.Add(DefaultValidity)) {
+		t.Errorf("Expected the default validity, got %v", resp.Quote.ValidUntil.AsTime())
+	}
+
+	if _, err := f.service.CounterQuote(context.Background(), &pb.CounterQuoteRequest{
+		QuoteId: q.Id,
+		Prices:  []*pb.ItemPrice{{ProductId: "lamp", UnitPrice: 10}},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a product not on the quote, got %v", err)
+	}
+}
+
+func TestApproveQuote(t *testing.T) {
+	f := setupService(t)
+	q := f.request(t)
+
+	resp, err := f.service.ApproveQuote(context.Background(), &pb.ApproveQuoteRequest{QuoteId: q.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := resp.Quote
+	if got.Status != StatusApproved || got.Items[0].QuotedPrice != 75 || got.Items[1].QuotedPrice != 249 || got.QuotedTotal != 7980 {
+		t.Errorf("Expected the target prices approved, got %+v", got)
+	}
+
+	if _, err := f.service.ApproveQuote(context.Background(), &pb.ApproveQuoteRequest{QuoteId: q.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for an approved quote, got %v", err)
+	}
+	if _, err := f.service.RejectQuote(context.Background(), &pb.RejectQuoteRequest{QuoteId: q.Id, Note: "No"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition rejecting an approved quote, got %v", err)
+	}
+}
+
+func TestRejectQuote(t *testing.T) {
+	f := setupService(t)
+	q := f.request(t)
+
+	if _, err := f.service.RejectQuote(context.Background(), &pb.RejectQuoteRequest{QuoteId: q.Id}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a note, got %v", err)
+	}
+	resp, err := f.service.RejectQuote(context.Background(), &pb.RejectQuoteRequest{QuoteId: q.Id, Note: "Below cost"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Quote.Status != StatusRejected || resp.Quote.ResponseNote != "Below cost" {
+		t.Errorf("Unexpected quote %+v", resp.Quote)
+	}
+}
+
+func TestAcceptQuote(t *testing.T) {
+	f := setupService(t)
+	q := f.request(t)
+	if _, err := f.service.ApproveQuote(context.Background(), &pb.ApproveQuoteRequest{QuoteId: q.Id}); err != nil {
+		t.Fatalf("Failed to approve quote: %v", err)
+	}
+
+	resp, err := f.service.AcceptQuote(context.Background(), &pb.AcceptQuoteRequest{QuoteId: q.Id, UserId: "buyer-1", PoNumber: " PO-2026-118 "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := resp.Quote
+	if got.Status != StatusOrdered || got.OrderId != "order-"+q.Id || got.PoNumber != "PO-2026-118" {
+		t.Errorf("Unexpected quote %+v", got)
+	}
+	if len(f.orders.placed) != 1 || f.orders.placed[0].Items[0].QuotedPrice != 75 {
+		t.Errorf("Expected one order at the quoted prices, got %+v", f.orders.placed)
+	}
+
+	if _, err := f.service.AcceptQuote(context.Background(), &pb.AcceptQuoteRequest{QuoteId: q.Id, UserId: "buyer-1", PoNumber: "PO-2"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for an ordered quote, got %v", err)
+	}
+}
+
+func TestAcceptQuote_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*fixture)
+		req      *pb.AcceptQuoteRequest
+		expected codes.Code
+	}{
+		{"missing PO number", nil, &pb.AcceptQuoteRequest{UserId: "buyer-1"}, codes.InvalidArgument},
+		{"another customer", nil, &pb.AcceptQuoteRequest{UserId: "buyer-2", PoNumber: "PO-1"}, codes.NotFound},
+		{"expired", func(f *fixture) {
+			f.service.now = func() time.Time { return now.Add(DefaultValidity + time.Minute) }
+		}, &pb.AcceptQuoteRequest{UserId: "buyer-1", PoNumber: "PO-1"}, codes.FailedPrecondition},
+		{"order rejected", func(f *fixture) {
+			f.orders.err = errors.Join(ErrOrderRejected, errors.New("product chair is unavailable"))
+		}, &pb.AcceptQuoteRequest{UserId: "buyer-1", PoNumber: "PO-1"}, codes.FailedPrecondition},
+		{"order service down", func(f *fixture) {
+			f.orders.err = errors.New("connection refused")
+		}, &pb.AcceptQuoteRequest{UserId: "buyer-1", PoNumber: "PO-1"}, codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			q := f.request(t)
+			if _, err := f.service.ApproveQuote(context.Background(), &pb.ApproveQuoteRequest{QuoteId: q.Id}); err != nil {
+				t.Fatalf("Failed to approve quote: %v", err)
+			}
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			tt.req.QuoteId = q.Id
+			if _, err := f.service.AcceptQuote(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if stored, _ := f.repo.GetByID(context.Background(), q.Id); stored.Status != StatusApproved {
+				t.Errorf("Expected the quote to stay APPROVED, got %s", stored.Status)
+			}
+		})
+	}
+}
+
+func TestAcceptQuote_NotAccepted(t *testing.T) {
+	f := setupService(t)
+	q := f.request(t)
+
+	if _, err := f.service.AcceptQuote(context.Background(), &pb.AcceptQuoteRequest{QuoteId: q.Id, UserId: "buyer-1", PoNumber: "PO-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a requested quote, got %v", err)
+	}
+	if len(f.orders.placed) != 0 {
+		t.Errorf("Expected no order, got %d", len(f.orders.placed))
+	}
+}
+
+func TestDeclineQuote(t *testing.T) {
+	f := setupService(t)
+	q := f.request(t)
+
+	if _, err := f.service.DeclineQuote(context.Background(), &pb.DeclineQuoteRequest{QuoteId: q.Id, UserId: "buyer-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a requested quote, got %v", err)
+	}
+	if _, err := f.service.CounterQuote(context.Background(), &pb.CounterQuoteRequest{QuoteId: q.Id}); err != nil {
+		t.Fatalf("Failed to counter quote: %v", err)
+	}
+
+	resp, err := f.service.DeclineQuote(context.Background(), &pb.DeclineQuoteRequest{QuoteId: q.Id, UserId: "buyer-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Quote.Status != StatusDeclined {
+		t.Errorf("Expected DECLINED, got %s", resp.Quote.Status)
+	}
+}
+
+func TestListQuotes(t *testing.T) {
+	f := setupService(t)
+	f.request(t)
+
+	resp, err := f.service.ListQuotes(context.Background(), &pb.ListQuotesRequest{UserId: "buyer-1", Status: StatusRequested})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 1 || len(resp.Quotes) != 1 || resp.Page != 1 || resp.PageSize != 10 {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+
+	if _, err := f.service.ListQuotes(context.Background(), &pb.ListQuotesRequest{Status: "OPEN"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown status, got %v", err)
+	}
+}
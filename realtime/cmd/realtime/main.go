@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/account"
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/cache"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/realtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("realtime-service")
+	log.Info(ctx, "Starting Realtime Gateway", nil)
+
+	// Get configuration from environment
+	httpPort := getEnv("HTTP_PORT", "8088")
+	metricsPort := getEnv("METRICS_PORT", "9118")
+	catalogAddr := getEnv("CATALOG_ADDR", "localhost:50052")
+	maxPerUser := getEnvInt("MAX_CONNECTIONS_PER_USER", realtime.DefaultMaxConnectionsPerUser)
+	heartbeat := getEnvDuration("HEARTBEAT_INTERVAL", realtime.DefaultHeartbeatInterval)
+
+	// Connections are authenticated with access tokens issued by the account
+	// service; only validation is used. With JWKS_URL set, tokens the account
+	// service signs with its private key are verified with its public keys,
+	// fetched again every JWKS_REFRESH_INTERVAL and when a token names a key
+	// they do not contain, to follow key rotation.
+	tokens := auth.TokenServiceFromEnv()
+
+	// PASETO tokens the account service issues with TOKEN_FORMAT=paseto are
+	// decrypted with the shared PASETO_KEY
+	if pasetoKey := os.Getenv("PASETO_KEY"); pasetoKey != "" {
+		key, err := auth.ParsePASETOKey(pasetoKey)
+		if err == nil {
+			_, err = tokens.WithPASETOKey(key)
+		}
+		if err != nil {
+			log.Error(ctx, "Invalid PASETO_KEY", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+	}
+
+	// With ACCOUNT_ADDR set, tokens none of the local keys validate, such as
+	// opaque tokens without REDIS_ADDR, are verified with the account service,
+	// whose answers are cached for TOKEN_CACHE_TTL
+	var remote auth.RemoteVerifier
+	if accountAddr := os.Getenv("ACCOUNT_ADDR"); accountAddr != "" {
+		accountConn, err := grpc.NewClient(accountAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Error(ctx, "Failed to create account service client", map[string]interface{}{
+				"error": err.Error(),
+				"addr":  accountAddr,
+			})
+			os.Exit(1)
+		}
+		defer accountConn.Close()
+		remote = account.RemoteVerifier(accountpb.NewAccountServiceClient(accountConn))
+	}
+	verifier := auth.NewVerifier(tokens, remote, getEnvDuration("TOKEN_CACHE_TTL", auth.DefaultVerifierCacheTTL))
+
+	jwksURL := os.Getenv("JWKS_URL")
+	if jwksURL != "" {
+		verifier.WithJWKS(http.DefaultClient, jwksURL)
+		if err := verifier.LoadJWKS(ctx); err != nil {
+			log.Error(ctx, "Failed to load JWKS", map[string]interface{}{
+				"error": err.Error(),
+				"url":   jwksURL,
+			})
+			os.Exit(1)
+		}
+		log.Info(ctx, "Verifying tokens with JWKS", map[string]interface{}{
+			"url": jwksURL,
+		})
+	}
+
+	// Tokens revoked through the account service are refused, and the opaque
+	// tokens it issues with TOKEN_FORMAT=opaque are looked up
+	if redisAddr := os.Getenv("REDIS_ADDR"); redisAddr != "" {
+		revocationClient, err := cache.NewRedisClient(ctx, cache.Config{
+			Addr:     redisAddr,
+			Password: os.Getenv("REDIS_PASSWORD"),
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to connect to Redis", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer revocationClient.Close()
+		tokens.WithRevocationStore(auth.NewRedisRevocationStore(revocationClient, auth.DefaultRevocationPrefix))
+		tokens.WithTokenStore(auth.NewRedisTokenStore(revocationClient, auth.DefaultTokenStorePrefix))
+	}
+	hub := realtime.NewHub(maxPerUser)
+
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+
+	if jwksURL != "" {
+		go func() {
+			ticker := time.NewTicker(getEnvDuration("JWKS_REFRESH_INTERVAL", 5*time.Minute))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-workerCtx.Done():
+					return
+				case <-ticker.C:
+					// On failure the keys already trusted stay in use
+					if err := verifier.LoadJWKS(workerCtx); err != nil {
+						log.Warn(workerCtx, "Failed to refresh JWKS", map[string]interface{}{
+							"error": err.Error(),
+							"url":   jwksURL,
+						})
+					}
+				}
+			}
+		}()
+	}
+
+	// Forward order status changes from Kafka. Every replica must see every
+	// event, since a customer may be connected to any of them, so each joins
+	// a consumer group of its own.
+	if brokers := kafka.ParseBrokers(os.Getenv("KAFKA_BROKERS")); len(brokers) > 0 {
+		hostname, _ := os.Hostname()
+		group := getEnv("KAFKA_GROUP", "realtime-"+hostname)
+		consumer, err := kafka.NewConsumer(kafka.Config{Brokers: brokers}, kafka.ConsumerConfig{
+			Group:      group,
+			Topics:     []string{realtime.OrderEventsTopic},
+			FromNewest: true,
+		}, "realtime-service")
+		if err != nil {
+			log.Error(ctx, "Failed to configure Kafka consumer", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		defer consumer.Close()
+		go func() {
+			err := consumer.Run(workerCtx, realtime.OrderEventHandler(hub), func(msg kafka.Message, err error) {
+				log.Warn(workerCtx, "Failed to forward order event", map[string]interface{}{
+					"error": err.Error(),
+					"key":   msg.Key,
+				})
+			})
+			if err != nil {
+				log.Error(ctx, "Order event consumer stopped", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}()
+		log.Info(ctx, "Forwarding order events", map[string]interface{}{
+			"topic": realtime.OrderEventsTopic,
+			"group": group,
+		})
+	} else {
+		log.Warn(ctx, "Order events are not forwarded (KAFKA_BROKERS not set)", nil)
+	}
+
+	// Forward stock changes from the catalog's product change stream.
+	// Admin RPCs of the catalog need a SERVICE token signed with JWT_SECRET.
+	serviceTokens := auth.NewServiceTokens(tokens, "realtime-service")
+	catalogConn, err := grpc.NewClient(catalogAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()), grpc.WithStreamInterceptor(serviceTokens.StreamClientInterceptor()))
+	if err != nil {
+		log.Error(ctx, "Failed to create catalog service client", map[string]interface{}{
+			"error": err.Error(),
+			"addr":  catalogAddr,
+		})
+		os.Exit(1)
+	}
+	defer catalogConn.Close()
+	go realtime.NewStockWatcher(catalogpb.NewCatalogServiceClient(catalogConn), hub, log).Run(workerCtx)
+
+	// IP filtering: the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, nil)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Connections are long-lived, so they hang off a context cancelled on
+	// shutdown rather than a write timeout
+	connCtx, closeConnections := context.WithCancel(ctx)
+	defer closeConnections()
+	server := realtime.NewServer(hub, verifier, realtime.Config{
+		AllowedOrigins:    splitList(os.Getenv("ALLOWED_ORIGINS")),
+		HeartbeatInterval: heartbeat,
+	}, log)
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", httpPort),
+		Handler:           ipFilter.Middleware(server.Handler()),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return connCtx },
+	}
+
+	log.Info(ctx, "Realtime Gateway listening", map[string]interface{}{
+		"http_port":    httpPort,
+		"metrics_port": metricsPort,
+		"catalog_addr": catalogAddr,
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		stopWorkers()
+		closeConnections()
+		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	// Start serving
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+// splitList parses a comma-separated list, dropping blanks
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
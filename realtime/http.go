@@ -0,0 +1,293 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// DefaultHeartbeatInterval is how often an idle connection is sent a heartbeat
+	DefaultHeartbeatInterval = 25 * time.Second
+	// writeTimeout is how long a WebSocket client has to accept a message
+	writeTimeout = 10 * time.Second
+	// maxClientMessage is the largest message a WebSocket client may send
+	maxClientMessage = 4096
+)
+
+// eventHeartbeat is sent over WebSocket connections that have been idle for a
+// heartbeat interval; SSE connections are sent a comment instead
+const eventHeartbeat = "heartbeat"
+
+// Config configures the HTTP server
+type Config struct {
+	// AllowedOrigins are the origins browsers may open WebSocket connections
+	// from. When empty, only the gateway's own origin is allowed.
+	AllowedOrigins []string
+	// HeartbeatInterval of 0 uses DefaultHeartbeatInterval
+	HeartbeatInterval time.Duration
+}
+
+// Server serves the gateway's connections over SSE and WebSocket
+type Server struct {
+	hub       *Hub
+	tokens    auth.Validator
+	origins   map[string]bool
+	heartbeat time.Duration
+	log       *logger.Logger
+}
+
+// NewServer creates a server for the hub's clients, authenticated with tokens
+func NewServer(hub *Hub, tokens auth.Validator, cfg Config, log *logger.Logger) *Server {
+	heartbeat := cfg.HeartbeatInterval
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeatInterval
+	}
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		origins[strings.TrimSuffix(origin, "/")] = true
+	}
+	return &Server{
+		hub:       hub,
+		tokens:    tokens,
+		origins:   origins,
+		heartbeat: heartbeat,
+		log:       log,
+	}
+}
+
+// Handler serves the gateway's endpoints:
+//
+//	GET /events?products=p1,p2   Server-Sent Events
+//	GET /ws?products=p1,p2       WebSocket
+//	GET /healthz                 Health check
+//
+// Connections are authenticated with an access token issued by the account
+// service, sent as a bearer token in the Authorization header or, since
+// browsers cannot set headers on EventSource and WebSocket, in the
+// access_token query parameter. A connection is closed when its token
+// expires.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /events", s.serveSSE)
+	mux.HandleFunc("GET /ws", s.serveWebSocket)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+// serveSSE streams events to the client as Server-Sent Events
+func (s *Server) serveSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	client, expires, ok := s.connect(w, r)
+	if !ok {
+		return
+	}
+	defer s.hub.Unregister(client)
+	metrics.RealtimeConnections.WithLabelValues("realtime-service", "sse").Inc()
+	defer metrics.RealtimeConnections.WithLabelValues("realtime-service", "sse").Dec()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Keep reverse proxies from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	s.stream(r.Context(), client, expires, func(event *Event) error {
+		if event == nil {
+			_, err := fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+			return err
+		}
+		if event.ID != "" {
+			fmt.Fprintf(w, "id: %s\n", event.ID)
+		}
+		_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, event.Data)
+		flusher.Flush()
+		return err
+	})
+}
+
+// serveWebSocket streams events to the client as WebSocket text messages,
+// each an Event in JSON
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedOrigin(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	client, expires, ok := s.connect(w, r)
+	if !ok {
+		return
+	}
+	defer s.hub.Unregister(client)
+	metrics.RealtimeConnections.WithLabelValues("realtime-service", "websocket").Inc()
+	defer metrics.RealtimeConnections.WithLabelValues("realtime-service", "websocket").Dec()
+
+	websocket.Server{
+		// The origin was checked before the upgrade
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = maxClientMessage
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+
+			// Clients send nothing; reading notices when they close the connection
+			go func() {
+				defer cancel()
+				var discard []byte
+				for {
+					if err := websocket.Message.Receive(ws, &discard); err != nil {
+						return
+					}
+				}
+			}()
+
+			s.stream(ctx, client, expires, func(event *Event) error {
+				if event == nil {
+					event = &Event{Type: eventHeartbeat}
+				}
+				ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+				return websocket.JSON.Send(ws, event)
+			})
+		},
+	}.ServeHTTP(w, r)
+}
+
+// connect authenticates a request and registers its client, answering the
+// request itself when it cannot be connected
+func (s *Server) connect(w http.ResponseWriter, r *http.Request) (*Client, time.Time, bool) {
+	claims, err := s.authenticate(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, time.Time{}, false
+	}
+	products := parseProducts(r.URL.Query().Get("products"))
+
+	client, err := s.hub.Register(claims.UserID, products)
+	switch {
+	case errors.Is(err, ErrTooManyConnections):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return nil, time.Time{}, false
+	case errors.Is(err, ErrTooManyProducts):
+		http.Error(w, fmt.Sprintf("at most %d products can be watched", MaxProductsPerConnection), http.StatusBadRequest)
+		return nil, time.Time{}, false
+	case err != nil:
+		http.Error(w, "failed to connect", http.StatusInternalServerError)
+		return nil, time.Time{}, false
+	}
+
+	var expires time.Time
+	if claims.ExpiresAt != nil {
+		expires = claims.ExpiresAt.Time
+	}
+	return client, expires, true
+}
+
+// authenticate validates the request's access token
+func (s *Server) authenticate(r *http.Request) (*auth.Claims, error) {
+	token := r.URL.Query().Get("access_token")
+	if header := r.Header.Get("Authorization"); header != "" {
+		var ok bool
+		token, ok = strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			return nil, errors.New("authorization header must be a bearer token")
+		}
+	}
+	if token == "" {
+		return nil, errors.New("missing access token")
+	}
+
+	claims, err := s.tokens.ValidateToken(token)
+	if errors.Is(err, auth.ErrTokenExpired) {
+		return nil, errors.New("access token expired")
+	}
+	if errors.Is(err, auth.ErrTokenRevoked) {
+		return nil, errors.New("access token revoked")
+	}
+	if err != nil || claims.UserID == "" {
+		return nil, errors.New("invalid access token")
+	}
+	return claims, nil
+}
+
+// stream writes the client's events with write until ctx is cancelled, the
+// client is dropped, its token expires or a write fails. write is passed nil
+// for a heartbeat.
+func (s *Server) stream(ctx context.Context, client *Client, expires time.Time, write func(event *Event) error) {
+	var expired <-chan time.Time
+	if !expires.IsZero() {
+		timer := time.NewTimer(time.Until(expires))
+		defer timer.Stop()
+		expired = timer.C
+	}
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case <-client.Done():
+			s.log.Warn(ctx, "Slow client dropped", map[string]interface{}{"user_id": client.UserID})
+			return
+		case <-expired:
+			return
+		case <-heartbeat.C:
+			err = write(nil)
+		case event := <-client.Events():
+			err = write(&event)
+			heartbeat.Reset(s.heartbeat)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// allowedOrigin reports whether a browser on the request's origin may open a
+// WebSocket connection. Requests without an Origin header do not come from a
+// browser and are allowed.
+func (s *Server) allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if len(s.origins) > 0 {
+		return s.origins[origin]
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// parseProducts parses a comma-separated list of product IDs, dropping
+// blanks and duplicates
+func parseProducts(list string) []string {
+	var products []string
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(list, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		products = append(products, id)
+	}
+	return products
+}
@@ -0,0 +1,148 @@
+package realtime
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"golang.org/x/net/websocket"
+)
+
+var testTokens = auth.NewTokenService("test-secret", time.Hour, 24*time.Hour)
+
+func newTestServer(t *testing.T, hub *Hub, cfg Config) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(NewServer(hub, testTokens, cfg, logger.New("realtime-test")).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// waitForConnections waits until the hub has n connections
+func waitForConnections(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.Connections() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d connections, got %d", n, hub.Connections())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_SSE(t *testing.T) {
+	hub := NewHub(0)
+	server := newTestServer(t, hub, Config{})
+	token, err := testTokens.GenerateAccessToken("user-1", "user@example.com", "USER")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/events?products=product-1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	waitForConnections(t, hub, 1)
+	hub.SendToUser("user-1", Event{ID: "event-1", Type: EventOrderStatusChanged, Data: []byte(`{"order_id":"order-1"}`)})
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 3 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Expected an event, got %v", err)
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	want := []string{"id: event-1", "event: order.status_changed", `data: {"order_id":"order-1"}`}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], lines[i])
+		}
+	}
+}
+
+func TestServer_Authentication(t *testing.T) {
+	hub := NewHub(1)
+	server := newTestServer(t, hub, Config{})
+	expired, _ := auth.NewTokenService("test-secret", -time.Minute, time.Hour).GenerateAccessToken("user-1", "user@example.com", "USER")
+	forged, _ := auth.NewTokenService("other-secret", time.Hour, time.Hour).GenerateAccessToken("user-1", "user@example.com", "USER")
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"missing token", "", http.StatusUnauthorized},
+		{"expired token", "?access_token=" + expired, http.StatusUnauthorized},
+		{"forged token", "?access_token=" + forged, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + "/events" + tt.query)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, resp.StatusCode)
+			}
+		})
+	}
+	if hub.Connections() != 0 {
+		t.Errorf("Expected no connections, got %d", hub.Connections())
+	}
+}
+
+func TestServer_WebSocket(t *testing.T) {
+	hub := NewHub(0)
+	server := newTestServer(t, hub, Config{AllowedOrigins: []string{"https://shop.example.com"}})
+	token, _ := testTokens.GenerateAccessToken("user-1", "user@example.com", "USER")
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?products=product-1&access_token=" + token
+
+	if _, err := websocket.Dial(wsURL, "", "https://evil.example.com"); err == nil {
+		t.Fatal("Expected a disallowed origin rejected")
+	}
+
+	ws, err := websocket.Dial(wsURL, "", "https://shop.example.com")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ws.Close()
+
+	waitForConnections(t, hub, 1)
+	hub.SendToProduct("product-1", Event{Type: EventStockChanged, Data: []byte(`{"product_id":"product-1","status":"LOW_STOCK"}`)})
+
+	var event Event
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := websocket.JSON.Receive(ws, &event); err != nil {
+		t.Fatalf("Expected an event, got %v", err)
+	}
+	if event.Type != EventStockChanged || !strings.Contains(string(event.Data), "LOW_STOCK") {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	ws.Close()
+	waitForConnections(t, hub, 0)
+}
+
+func TestParseProducts(t *testing.T) {
+	got := parseProducts(" product-1,,product-2,product-1 ")
+	if len(got) != 2 || got[0] != "product-1" || got[1] != "product-2" {
+		t.Errorf("Expected [product-1 product-2], got %v", got)
+	}
+	if got := parseProducts(""); len(got) != 0 {
+		t.Errorf("Expected no products, got %v", got)
+	}
+}
@@ -0,0 +1,180 @@
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+const (
+	// DefaultMaxConnectionsPerUser is how many connections one user may hold open at once
+	DefaultMaxConnectionsPerUser = 5
+	// MaxProductsPerConnection is how many products one connection may watch
+	MaxProductsPerConnection = 50
+	// clientBuffer is how many events may wait for a client before it is dropped
+	clientBuffer = 64
+)
+
+var (
+	// ErrTooManyConnections is returned when a user already holds the most connections allowed
+	ErrTooManyConnections = errors.New("too many connections")
+	// ErrTooManyProducts is returned when a connection asks to watch too many products
+	ErrTooManyProducts = errors.New("too many products")
+)
+
+// Event is a message sent to connected clients
+type Event struct {
+	ID   string          `json:"id,omitempty"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Client is one connection to the gateway. Events addressed to its user or
+// to a product it watches are queued on Events; Done is closed when the hub
+// drops it because it fell too far behind.
+type Client struct {
+	UserID   string
+	products []string
+	events   chan Event
+	done     chan struct{}
+	dropOnce sync.Once
+}
+
+// Events returns the client's queued events
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Done is closed when the client has been dropped
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) drop() {
+	c.dropOnce.Do(func() { close(c.done) })
+}
+
+// Hub routes events to connected clients by user and by watched product
+type Hub struct {
+	mu         sync.Mutex
+	users      map[string]map[*Client]struct{}
+	products   map[string]map[*Client]struct{}
+	maxPerUser int
+}
+
+// NewHub creates a hub. A maxPerUser of 0 uses DefaultMaxConnectionsPerUser.
+func NewHub(maxPerUser int) *Hub {
+	if maxPerUser <= 0 {
+		maxPerUser = DefaultMaxConnectionsPerUser
+	}
+	return &Hub{
+		users:      make(map[string]map[*Client]struct{}),
+		products:   make(map[string]map[*Client]struct{}),
+		maxPerUser: maxPerUser,
+	}
+}
+
+// Register connects a client for userID watching products. Callers must
+// Unregister the client when its connection ends.
+func (h *Hub) Register(userID string, products []string) (*Client, error) {
+	if len(products) > MaxProductsPerConnection {
+		return nil, ErrTooManyProducts
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.users[userID]) >= h.maxPerUser {
+		return nil, ErrTooManyConnections
+	}
+
+	c := &Client{
+		UserID:   userID,
+		products: products,
+		events:   make(chan Event, clientBuffer),
+		done:     make(chan struct{}),
+	}
+	add(h.users, userID, c)
+	for _, productID := range products {
+		add(h.products, productID, c)
+	}
+	return c, nil
+}
+
+// Unregister disconnects a client. Unregistering a client twice is harmless.
+func (h *Hub) Unregister(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(c)
+}
+
+// SendToUser queues an event for every connection of a user and returns how
+// many it was queued for
+func (h *Hub) SendToUser(userID string, event Event) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.send(h.users[userID], event)
+}
+
+// SendToProduct queues an event for every connection watching a product and
+// returns how many it was queued for
+func (h *Hub) SendToProduct(productID string, event Event) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.send(h.products[productID], event)
+}
+
+// Connections returns how many clients are connected
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for _, clients := range h.users {
+		n += len(clients)
+	}
+	return n
+}
+
+// send queues event for clients. A client whose queue is full is dropped
+// rather than holding up everyone else; it reconnects and reloads what it
+// missed.
+func (h *Hub) send(clients map[*Client]struct{}, event Event) int {
+	sent := 0
+	for c := range clients {
+		select {
+		case c.events <- event:
+			sent++
+		default:
+			h.remove(c)
+			c.drop()
+		}
+	}
+	return sent
+}
+
+// remove takes a client out of the routing maps; h.mu must be held
+func (h *Hub) remove(c *Client) {
+	del(h.users, c.UserID, c)
+	for _, productID := range c.products {
+		del(h.products, productID, c)
+	}
+}
+
+func add(m map[string]map[*Client]struct{}, key string, c *Client) {
+	clients, ok := m[key]
+	if !ok {
+		clients = make(map[*Client]struct{})
+		m[key] = clients
+	}
+	clients[c] = struct{}{}
+}
+
+func del(m map[string]map[*Client]struct{}, key string, c *Client) {
+	clients, ok := m[key]
+	if !ok {
+		return
+	}
+	delete(clients, c)
+	if len(clients) == 0 {
+		delete(m, key)
+	}
+}
@@ -0,0 +1,87 @@
+package realtime
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHub_RoutesByUserAndProduct(t *testing.T) {
+	hub := NewHub(0)
+	alice, err := hub.Register("user-1", []string{"product-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	bob, err := hub.Register("user-2", []string{"product-1", "product-2"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if n := hub.SendToUser("user-1", Event{Type: EventOrderStatusChanged}); n != 1 {
+		t.Errorf("Expected the event queued for 1 connection, got %d", n)
+	}
+	if n := hub.SendToProduct("product-1", Event{Type: EventStockChanged}); n != 2 {
+		t.Errorf("Expected the event queued for 2 connections, got %d", n)
+	}
+	if n := hub.SendToProduct("product-3", Event{Type: EventStockChanged}); n != 0 {
+		t.Errorf("Expected no connection watching product-3, got %d", n)
+	}
+
+	if len(alice.Events()) != 2 || len(bob.Events()) != 1 {
+		t.Errorf("Expected 2 and 1 queued events, got %d and %d", len(alice.Events()), len(bob.Events()))
+	}
+	if event := <-alice.Events(); event.Type != EventOrderStatusChanged {
+		t.Errorf("Expected events in order, got %s first", event.Type)
+	}
+
+	hub.Unregister(bob)
+	hub.Unregister(bob)
+	if n := hub.SendToProduct("product-2", Event{Type: EventStockChanged}); n != 0 {
+		t.Errorf("Expected unregistered connections to get nothing, got %d", n)
+	}
+	if hub.Connections() != 1 {
+		t.Errorf("Expected 1 connection, got %d", hub.Connections())
+	}
+}
+
+func TestHub_Limits(t *testing.T) {
+	hub := NewHub(2)
+	for i := 0; i < 2; i++ {
+		if _, err := hub.Register("user-1", nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if _, err := hub.Register("user-1", nil); err != ErrTooManyConnections {
+		t.Errorf("Expected ErrTooManyConnections, got %v", err)
+	}
+	if _, err := hub.Register("user-2", nil); err != nil {
+		t.Errorf("Expected other users unaffected, got %v", err)
+	}
+
+	products := make([]string, MaxProductsPerConnection+1)
+	for i := range products {
+		products[i] = fmt.Sprintf("product-%d", i)
+	}
+	if _, err := hub.Register("user-3", products); err != ErrTooManyProducts {
+		t.Errorf("Expected ErrTooManyProducts, got %v", err)
+	}
+}
+
+func TestHub_DropsSlowClients(t *testing.T) {
+	hub := NewHub(0)
+	slow, _ := hub.Register("user-1", nil)
+	for i := 0; i < clientBuffer; i++ {
+		hub.SendToUser("user-1", Event{Type: EventOrderStatusChanged})
+	}
+
+	if n := hub.SendToUser("user-1", Event{Type: EventOrderStatusChanged}); n != 0 {
+		t.Errorf("Expected the event not queued for a full client, got %d", n)
+	}
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("Expected the slow client dropped")
+	}
+	if hub.Connections() != 0 {
+		t.Errorf("Expected the slow client unregistered, got %d connections", hub.Connections())
+	}
+}
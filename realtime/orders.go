@@ -0,0 +1,53 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+)
+
+// OrderEventsTopic is the Kafka topic the order service publishes its events to
+const OrderEventsTopic = "order-events"
+
+// EventOrderStatusChanged is forwarded to the customer whose order changed status
+const EventOrderStatusChanged = "order.status_changed"
+
+// outboxEnvelope is the JSON envelope services publish their outbox events in
+type outboxEnvelope struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// orderStatusChanged is the part of an order.status_changed event's data the
+// gateway routes by
+type orderStatusChanged struct {
+	UserID string `json:"user_id"`
+}
+
+// OrderEventHandler forwards order status changes consumed from Kafka to the
+// connections of the order's customer. Other order events are ignored.
+func OrderEventHandler(hub *Hub) kafka.Handler {
+	return func(ctx context.Context, msg kafka.Message) error {
+		var envelope outboxEnvelope
+		if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+			return fmt.Errorf("failed to decode order event: %w", err)
+		}
+		if envelope.Type != EventOrderStatusChanged {
+			return nil
+		}
+
+		var data orderStatusChanged
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode order event data: %w", err)
+		}
+		if data.UserID == "" {
+			return fmt.Errorf("order event %s has no user", envelope.ID)
+		}
+
+		hub.SendToUser(data.UserID, Event{ID: envelope.ID, Type: envelope.Type, Data: envelope.Data})
+		return nil
+	}
+}
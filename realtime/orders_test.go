@@ -0,0 +1,51 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+)
+
+func TestOrderEventHandler(t *testing.T) {
+	hub := NewHub(0)
+	client, _ := hub.Register("user-1", nil)
+	handle := OrderEventHandler(hub)
+
+	value := []byte(`{"id":"event-1","type":"order.status_changed","occurred_at":"2026-06-01T12:00:00Z","data":{"order_id":"order-1","user_id":"user-1","from":"PENDING","to":"PAID"}}`)
+	if err := handle(context.Background(), kafka.Message{Topic: OrderEventsTopic, Key: "order-1", Value: value}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case event := <-client.Events():
+		var data struct {
+			OrderID string `json:"order_id"`
+			To      string `json:"to"`
+		}
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			t.Fatalf("Expected JSON data, got %v", err)
+		}
+		if event.ID != "event-1" || event.Type != EventOrderStatusChanged || data.OrderID != "order-1" || data.To != "PAID" {
+			t.Errorf("Unexpected event %+v", event)
+		}
+	default:
+		t.Fatal("Expected the event forwarded to the customer")
+	}
+}
+
+func TestOrderEventHandler_Invalid(t *testing.T) {
+	hub := NewHub(0)
+	handle := OrderEventHandler(hub)
+
+	if err := handle(context.Background(), kafka.Message{Value: []byte(`not json`)}); err == nil {
+		t.Error("Expected an error for a malformed event")
+	}
+	if err := handle(context.Background(), kafka.Message{Value: []byte(`{"id":"event-1","type":"order.status_changed","data":{"order_id":"order-1"}}`)}); err == nil {
+		t.Error("Expected an error for an event without a user")
+	}
+	if err := handle(context.Background(), kafka.Message{Value: []byte(`{"id":"event-2","type":"order.created","data":{}}`)}); err != nil {
+		t.Errorf("Expected other events ignored, got %v", err)
+	}
+}
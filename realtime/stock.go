@@ -0,0 +1,139 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// EventStockChanged is sent to the connections watching a product whose stock changed
+const EventStockChanged = "stock.changed"
+
+// Stock statuses sent with stock.changed
+const (
+	StockInStock    = "IN_STOCK"
+	StockLow        = "LOW_STOCK"
+	StockOutOfStock = "OUT_OF_STOCK"
+)
+
+// Catalog values the watcher reads
+const (
+	catalogChangeDeleted = "DELETED"
+	catalogTypeDigital   = "DIGITAL"
+)
+
+// retryDelay is how long the watcher waits after the product change stream breaks
+const retryDelay = 5 * time.Second
+
+// stockChanged is the data of a stock.changed event
+type stockChanged struct {
+	ProductID string    `json:"product_id"`
+	Stock     int32     `json:"stock"`
+	Status    string    `json:"status"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// StockWatcher follows the catalog's product change stream and tells the
+// connections watching a product when its stock changes
+type StockWatcher struct {
+	catalog    catalogpb.CatalogServiceClient
+	hub        *Hub
+	stock      map[string]int32
+	retryDelay time.Duration
+	log        *logger.Logger
+}
+
+// NewStockWatcher creates a stock watcher
+func NewStockWatcher(catalog catalogpb.CatalogServiceClient, hub *Hub, log *logger.Logger) *StockWatcher {
+	return &StockWatcher{
+		catalog:    catalog,
+		hub:        hub,
+		stock:      make(map[string]int32),
+		retryDelay: retryDelay,
+		log:        log,
+	}
+}
+
+// Run follows product changes until ctx is cancelled, subscribing again after
+// a delay whenever the stream breaks
+func (w *StockWatcher) Run(ctx context.Context) {
+	for {
+		err := w.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.log.Warn(ctx, "Stock watching interrupted", map[string]interface{}{"error": err.Error(), "retry_in": w.retryDelay.String()})
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.retryDelay):
+		}
+	}
+}
+
+// follow applies product changes until the stream breaks
+func (w *StockWatcher) follow(ctx context.Context) error {
+	stream, err := w.catalog.WatchProducts(ctx, &catalogpb.WatchProductsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to watch products: %w", err)
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("product change stream ended")
+			}
+			return fmt.Errorf("product change stream broke: %w", err)
+		}
+		w.apply(event)
+	}
+}
+
+// apply sends a stock.changed event when a change moved a product's stock.
+// The first change seen for a product is always sent, since the stock it had
+// before is not known.
+func (w *StockWatcher) apply(event *catalogpb.ProductChangeEvent) {
+	if event.Type == catalogChangeDeleted {
+		delete(w.stock, event.ProductId)
+		return
+	}
+	p := event.Product
+	if p == nil || p.ProductType == catalogTypeDigital {
+		return
+	}
+	if previous, ok := w.stock[p.Id]; ok && previous == p.Stock {
+		return
+	}
+	w.stock[p.Id] = p.Stock
+
+	data, err := json.Marshal(stockChanged{
+		ProductID: p.Id,
+		Stock:     p.Stock,
+		Status:    stockStatus(p.Stock, p.LowStockThreshold),
+		ChangedAt: event.ChangedAt.AsTime().UTC(),
+	})
+	if err != nil {
+		return
+	}
+	w.hub.SendToProduct(p.Id, Event{Type: EventStockChanged, Data: data})
+}
+
+// stockStatus describes a stock level against a product's low-stock
+// threshold; a threshold of 0 disables LOW_STOCK
+func stockStatus(stock, threshold int32) string {
+	switch {
+	case stock <= 0:
+		return StockOutOfStock
+	case stock <= threshold:
+		return StockLow
+	default:
+		return StockInStock
+	}
+}
@@ -0,0 +1,86 @@
+package realtime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func productChange(changeType string, product *catalogpb.Product) *catalogpb.ProductChangeEvent {
+	return &catalogpb.ProductChangeEvent{
+		Type:      changeType,
+		ProductId: product.Id,
+		ChangedAt: timestamppb.New(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)),
+		Product:   product,
+	}
+}
+
+func TestStockWatcher_Apply(t *testing.T) {
+	hub := NewHub(0)
+	client, _ := hub.Register("user-1", []string{"product-1"})
+	watcher := NewStockWatcher(nil, hub, logger.New("realtime-test"))
+
+	watcher.apply(productChange("UPDATED", &catalogpb.Product{Id: "product-1", Stock: 10, LowStockThreshold: 5}))
+	watcher.apply(productChange("UPDATED", &catalogpb.Product{Id: "product-1", Stock: 10, LowStockThreshold: 5, Name: "Renamed"}))
+	watcher.apply(productChange("UPDATED", &catalogpb.Product{Id: "product-1", Stock: 3, LowStockThreshold: 5}))
+	watcher.apply(productChange("UPDATED", &catalogpb.Product{Id: "product-2", Stock: 0}))
+
+	var statuses []string
+	for len(client.Events()) > 0 {
+		event := <-client.Events()
+		var data stockChanged
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			t.Fatalf("Expected JSON data, got %v", err)
+		}
+		if event.Type != EventStockChanged || data.ProductID != "product-1" {
+			t.Errorf("Unexpected event %+v", event)
+		}
+		statuses = append(statuses, data.Status)
+	}
+	// A change that leaves stock alone is not sent
+	if len(statuses) != 2 || statuses[0] != StockInStock || statuses[1] != StockLow {
+		t.Errorf("Expected IN_STOCK then LOW_STOCK, got %v", statuses)
+	}
+}
+
+func TestStockWatcher_ApplyIgnoresDigitalAndDeleted(t *testing.T) {
+	hub := NewHub(0)
+	client, _ := hub.Register("user-1", []string{"product-1", "ebook-1"})
+	watcher := NewStockWatcher(nil, hub, logger.New("realtime-test"))
+
+	watcher.apply(productChange("UPDATED", &catalogpb.Product{Id: "ebook-1", ProductType: "DIGITAL"}))
+	watcher.apply(productChange("UPDATED", &catalogpb.Product{Id: "product-1", Stock: 4}))
+	<-client.Events()
+	watcher.apply(&catalogpb.ProductChangeEvent{Type: "DELETED", ProductId: "product-1"})
+	if len(client.Events()) != 0 {
+		t.Fatalf("Expected digital products and deletions not sent, got %d events", len(client.Events()))
+	}
+
+	// A product created again after being deleted is sent afresh
+	watcher.apply(productChange("CREATED", &catalogpb.Product{Id: "product-1", Stock: 4}))
+	if len(client.Events()) != 1 {
+		t.Errorf("Expected 1 event, got %d", len(client.Events()))
+	}
+}
+
+func TestStockStatus(t *testing.T) {
+	tests := []struct {
+		stock, threshold int32
+		want             string
+	}{
+		{0, 5, StockOutOfStock},
+		{-1, 0, StockOutOfStock},
+		{5, 5, StockLow},
+		{6, 5, StockInStock},
+		{1, 0, StockInStock},
+	}
+	for _, tt := range tests {
+		if got := stockStatus(tt.stock, tt.threshold); got != tt.want {
+			t.Errorf("stockStatus(%d, %d) = %s, want %s", tt.stock, tt.threshold, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS product_pairs;
+DROP INDEX IF EXISTS idx_purchases_time;
+DROP INDEX IF EXISTS idx_purchases_user;
+DROP TABLE IF EXISTS purchases;
+DROP TABLE IF EXISTS purchase_orders;
+DROP INDEX IF EXISTS idx_product_views_user;
+DROP TABLE IF EXISTS product_views;
@@ -0,0 +1,36 @@
+-- Products viewed by signed-in customers
+CREATE TABLE IF NOT EXISTS product_views (
+    user_id VARCHAR(255) NOT NULL,
+    product_id UUID NOT NULL,
+    viewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_views_user ON product_views(user_id, viewed_at DESC);
+
+-- Paid orders already recorded, so an order is counted once
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    order_id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    purchased_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Products of paid orders
+CREATE TABLE IF NOT EXISTS purchases (
+    order_id UUID NOT NULL REFERENCES purchase_orders(order_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    product_id UUID NOT NULL,
+    purchased_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_id, product_id)
+);
+
+CREATE INDEX idx_purchases_user ON purchases(user_id, purchased_at DESC);
+CREATE INDEX idx_purchases_time ON purchases(purchased_at DESC);
+
+-- For each product, the products bought by the same customers and how many
+-- customers bought both
+CREATE TABLE IF NOT EXISTS product_pairs (
+    product_id UUID NOT NULL,
+    related_id UUID NOT NULL,
+    customers INTEGER NOT NULL DEFAULT 0 CHECK (customers >= 0),
+    PRIMARY KEY (product_id, related_id)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS association_rules;
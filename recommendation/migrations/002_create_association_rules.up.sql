@@ -0,0 +1,13 @@
+-- Association rules mined from orders: customers who order product_id often
+-- order related_id in the same order. The table is replaced by each mining.
+CREATE TABLE IF NOT EXISTS association_rules (
+    product_id UUID NOT NULL,
+    related_id UUID NOT NULL,
+    orders INTEGER NOT NULL CHECK (orders > 0),
+    support DOUBLE PRECISION NOT NULL,
+    confidence DOUBLE PRECISION NOT NULL,
+    lift DOUBLE PRECISION NOT NULL,
+    mined_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (product_id, related_id)
+);
+
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_purchases_tenant_user;
+DROP INDEX IF EXISTS idx_purchase_orders_tenant_user;
+DROP INDEX IF EXISTS idx_product_views_tenant_user;
+CREATE INDEX idx_product_views_user ON product_views(user_id, viewed_at DESC);
+CREATE INDEX idx_purchases_user ON purchases(user_id, purchased_at DESC);
+
+ALTER TABLE purchases DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE product_views DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,12 @@
+-- Views and purchases belong to the tenant (store) they were made in.
+-- Existing rows belong to the default tenant. Product pairs and association
+-- rules stay shared, as they describe the catalog every tenant shares.
+ALTER TABLE product_views ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE purchase_orders ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE purchases ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+DROP INDEX IF EXISTS idx_product_views_user;
+DROP INDEX IF EXISTS idx_purchases_user;
+CREATE INDEX idx_product_views_tenant_user ON product_views(tenant_id, user_id, viewed_at DESC);
+CREATE INDEX idx_purchase_orders_tenant_user ON purchase_orders(tenant_id, user_id);
+CREATE INDEX idx_purchases_tenant_user ON purchases(tenant_id, user_id, purchased_at DESC);
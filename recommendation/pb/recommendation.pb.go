@@ -0,0 +1,932 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: recommendation/recommendation.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Recommendation is a product suggested to a customer
+type Recommendation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // relative strength; higher is better
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // ALSO_BOUGHT, PERSONALIZED or POPULAR
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Recommendation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Recommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Recommendation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// RecordView records that a signed-in customer viewed a product
+type RecordViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // optional; now when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewRequest) Reset() {
+	*x = RecordViewRequest{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewRequest) ProtoMessage() {}
+
+func (x *RecordViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewRequest.ProtoReflect.Descriptor instead.
+func (*RecordViewRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecordViewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type RecordViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewResponse) Reset() {
+	*x = RecordViewResponse{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewResponse) ProtoMessage() {}
+
+func (x *RecordViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewResponse.ProtoReflect.Descriptor instead.
+func (*RecordViewResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{2}
+}
+
+// RecordPurchase records the products of a paid order. Recording an order
+// again has no effect.
+type RecordPurchaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductIds    []string               `protobuf:"bytes,3,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // optional; now when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordPurchaseRequest) Reset() {
+	*x = RecordPurchaseRequest{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordPurchaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordPurchaseRequest) ProtoMessage() {}
+
+func (x *RecordPurchaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordPurchaseRequest.ProtoReflect.Descriptor instead.
+func (*RecordPurchaseRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecordPurchaseRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RecordPurchaseRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecordPurchaseRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *RecordPurchaseRequest) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type RecordPurchaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recorded      bool                   `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"` // false when the order was already recorded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordPurchaseResponse) Reset() {
+	*x = RecordPurchaseResponse{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordPurchaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordPurchaseResponse) ProtoMessage() {}
+
+func (x *RecordPurchaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordPurchaseResponse.ProtoReflect.Descriptor instead.
+func (*RecordPurchaseResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecordPurchaseResponse) GetRecorded() bool {
+	if x != nil {
+		return x.Recorded
+	}
+	return false
+}
+
+// GetRecommendations suggests products for a page. The PRODUCT context
+// suggests what customers who bought product_id also bought; the HOME context
+// suggests products from the customer's own views and purchases. Both fall
+// back to popular products.
+type GetRecommendationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // optional for anonymous visitors
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // required for the PRODUCT context
+	Context       string                 `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`                      // PRODUCT or HOME; PRODUCT when product_id is set, HOME otherwise
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                         // default 10, max 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsRequest) Reset() {
+	*x = GetRecommendationsRequest{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetRecommendationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRecommendationsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetRecommendationsRequest) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *GetRecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetRecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*Recommendation      `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	Context         string                 `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"` // the context used
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsResponse) Reset() {
+	*x = GetRecommendationsResponse{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRecommendationsResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+func (x *GetRecommendationsResponse) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+// BoughtTogether is a product often in the same orders as another, from an
+// association rule
+type BoughtTogether struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Orders        int32                  `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`          // orders with both products
+	Confidence    float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"` // share of the other product's orders that have this one too
+	Lift          float64                `protobuf:"fixed64,4,opt,name=lift,proto3" json:"lift,omitempty"`             // how much likelier this product is with the other than without
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoughtTogether) Reset() {
+	*x = BoughtTogether{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoughtTogether) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoughtTogether) ProtoMessage() {}
+
+func (x *BoughtTogether) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoughtTogether.ProtoReflect.Descriptor instead.
+func (*BoughtTogether) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BoughtTogether) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BoughtTogether) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *BoughtTogether) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *BoughtTogether) GetLift() float64 {
+	if x != nil {
+		return x.Lift
+	}
+	return 0
+}
+
+// GetFrequentlyBoughtTogether returns the products most often ordered with
+// product_id, from the rules last mined
+type GetFrequentlyBoughtTogetherRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // default 5, max 20
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFrequentlyBoughtTogetherRequest) Reset() {
+	*x = GetFrequentlyBoughtTogetherRequest{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFrequentlyBoughtTogetherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFrequentlyBoughtTogetherRequest) ProtoMessage() {}
+
+func (x *GetFrequentlyBoughtTogetherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFrequentlyBoughtTogetherRequest.ProtoReflect.Descriptor instead.
+func (*GetFrequentlyBoughtTogetherRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetFrequentlyBoughtTogetherRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetFrequentlyBoughtTogetherRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetFrequentlyBoughtTogetherResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*BoughtTogether      `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	MinedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=mined_at,json=minedAt,proto3" json:"mined_at,omitempty"` // unset when the product has no rules
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFrequentlyBoughtTogetherResponse) Reset() {
+	*x = GetFrequentlyBoughtTogetherResponse{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFrequentlyBoughtTogetherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFrequentlyBoughtTogetherResponse) ProtoMessage() {}
+
+func (x *GetFrequentlyBoughtTogetherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFrequentlyBoughtTogetherResponse.ProtoReflect.Descriptor instead.
+func (*GetFrequentlyBoughtTogetherResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetFrequentlyBoughtTogetherResponse) GetProducts() []*BoughtTogether {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetFrequentlyBoughtTogetherResponse) GetMinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MinedAt
+	}
+	return nil
+}
+
+// MineAssociationRules replaces the association rules with ones mined from
+// the orders of the last 180 days. The jobs service calls it on a schedule.
+type MineAssociationRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MineAssociationRulesRequest) Reset() {
+	*x = MineAssociationRulesRequest{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MineAssociationRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MineAssociationRulesRequest) ProtoMessage() {}
+
+func (x *MineAssociationRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MineAssociationRulesRequest.ProtoReflect.Descriptor instead.
+func (*MineAssociationRulesRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{10}
+}
+
+type MineAssociationRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        int32                  `protobuf:"varint,1,opt,name=orders,proto3" json:"orders,omitempty"` // orders mined
+	Rules         int32                  `protobuf:"varint,2,opt,name=rules,proto3" json:"rules,omitempty"`   // rules kept
+	MinedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=mined_at,json=minedAt,proto3" json:"mined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MineAssociationRulesResponse) Reset() {
+	*x = MineAssociationRulesResponse{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MineAssociationRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MineAssociationRulesResponse) ProtoMessage() {}
+
+func (x *MineAssociationRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MineAssociationRulesResponse.ProtoReflect.Descriptor instead.
+func (*MineAssociationRulesResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MineAssociationRulesResponse) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *MineAssociationRulesResponse) GetRules() int32 {
+	if x != nil {
+		return x.Rules
+	}
+	return 0
+}
+
+func (x *MineAssociationRulesResponse) GetMinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MinedAt
+	}
+	return nil
+}
+
+// EraseUserData deletes a customer's viewing and purchase history when their
+// account is deleted. Product pairs and association rules are counts over
+// many customers and are kept.
+type EraseUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EraseUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EraseUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       int32                  `protobuf:"varint,1,opt,name=records,proto3" json:"records,omitempty"` // views and purchased orders deleted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_recommendation_recommendation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_recommendation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_recommendation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EraseUserDataResponse) GetRecords() int32 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+var File_recommendation_recommendation_proto protoreflect.FileDescriptor
+
+const file_recommendation_recommendation_proto_rawDesc = "" +
+	"\n" +
+	"#recommendation/recommendation.proto\x12\x0erecommendation\x1a\x1fgoogle/protobuf/timestamp.proto\"]\n" +
+	"\x0eRecommendation\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x88\x01\n" +
+	"\x11RecordViewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x14\n" +
+	"\x12RecordViewResponse\"\xa9\x01\n" +
+	"\x15RecordPurchaseRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vproduct_ids\x18\x03 \x03(\tR\n" +
+	"productIds\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"4\n" +
+	"\x16RecordPurchaseResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\bR\brecorded\"\x83\x01\n" +
+	"\x19GetRecommendationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x18\n" +
+	"\acontext\x18\x03 \x01(\tR\acontext\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x80\x01\n" +
+	"\x1aGetRecommendationsResponse\x12H\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x1e.recommendation.RecommendationR\x0frecommendations\x12\x18\n" +
+	"\acontext\x18\x02 \x01(\tR\acontext\"{\n" +
+	"\x0eBoughtTogether\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x05R\x06orders\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x01R\n" +
+	"confidence\x12\x12\n" +
+	"\x04lift\x18\x04 \x01(\x01R\x04lift\"Y\n" +
+	"\"GetFrequentlyBoughtTogetherRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x98\x01\n" +
+	"#GetFrequentlyBoughtTogetherResponse\x12:\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1e.recommendation.BoughtTogetherR\bproducts\x125\n" +
+	"\bmined_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aminedAt\"\x1d\n" +
+	"\x1bMineAssociationRulesRequest\"\x83\x01\n" +
+	"\x1cMineAssociationRulesResponse\x12\x16\n" +
+	"\x06orders\x18\x01 \x01(\x05R\x06orders\x12\x14\n" +
+	"\x05rules\x18\x02 \x01(\x05R\x05rules\x125\n" +
+	"\bmined_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aminedAt\"/\n" +
+	"\x14EraseUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"1\n" +
+	"\x15EraseUserDataResponse\x12\x18\n" +
+	"\arecords\x18\x01 \x01(\x05R\arecords2\x94\x05\n" +
+	"\x15RecommendationService\x12S\n" +
+	"\n" +
+	"RecordView\x12!.recommendation.RecordViewRequest\x1a\".recommendation.RecordViewResponse\x12_\n" +
+	"\x0eRecordPurchase\x12%.recommendation.RecordPurchaseRequest\x1a&.recommendation.RecordPurchaseResponse\x12k\n" +
+	"\x12GetRecommendations\x12).recommendation.GetRecommendationsRequest\x1a*.recommendation.GetRecommendationsResponse\x12\x86\x01\n" +
+	"\x1bGetFrequentlyBoughtTogether\x122.recommendation.GetFrequentlyBoughtTogetherRequest\x1a3.recommendation.GetFrequentlyBoughtTogetherResponse\x12q\n" +
+	"\x14MineAssociationRules\x12+.recommendation.MineAssociationRulesRequest\x1a,.recommendation.MineAssociationRulesResponse\x12\\\n" +
+	"\rEraseUserData\x12$.recommendation.EraseUserDataRequest\x1a%.recommendation.EraseUserDataResponseB>Z<github.com/Ujjwaljain16/E-commerce-Backend/recommendation/pbb\x06proto3"
+
+var (
+	file_recommendation_recommendation_proto_rawDescOnce sync.Once
+	file_recommendation_recommendation_proto_rawDescData []byte
+)
+
+func file_recommendation_recommendation_proto_rawDescGZIP() []byte {
+	file_recommendation_recommendation_proto_rawDescOnce.Do(func() {
+		file_recommendation_recommendation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recommendation_recommendation_proto_rawDesc), len(file_recommendation_recommendation_proto_rawDesc)))
+	})
+	return file_recommendation_recommendation_proto_rawDescData
+}
+
+var file_recommendation_recommendation_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_recommendation_recommendation_proto_goTypes = []any{
+	(*Recommendation)(nil),                      // 0: recommendation.Recommendation
+	(*RecordViewRequest)(nil),                   // 1: recommendation.RecordViewRequest
+	(*RecordViewResponse)(nil),                  // 2: recommendation.RecordViewResponse
+	(*RecordPurchaseRequest)(nil),               // 3: recommendation.RecordPurchaseRequest
+	(*RecordPurchaseResponse)(nil),              // 4: recommendation.RecordPurchaseResponse
+	(*GetRecommendationsRequest)(nil),           // 5: recommendation.GetRecommendationsRequest
+	(*GetRecommendationsResponse)(nil),          // 6: recommendation.GetRecommendationsResponse
+	(*BoughtTogether)(nil),                      // 7: recommendation.BoughtTogether
+	(*GetFrequentlyBoughtTogetherRequest)(nil),  // 8: recommendation.GetFrequentlyBoughtTogetherRequest
+	(*GetFrequentlyBoughtTogetherResponse)(nil), // 9: recommendation.GetFrequentlyBoughtTogetherResponse
+	(*MineAssociationRulesRequest)(nil),         // 10: recommendation.MineAssociationRulesRequest
+	(*MineAssociationRulesResponse)(nil),        // 11: recommendation.MineAssociationRulesResponse
+	(*EraseUserDataRequest)(nil),                // 12: recommendation.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),               // 13: recommendation.EraseUserDataResponse
+	(*timestamppb.Timestamp)(nil),               // 14: google.protobuf.Timestamp
+}
+var file_recommendation_recommendation_proto_depIdxs = []int32{
+	14, // 0: recommendation.RecordViewRequest.occurred_at:type_name -> google.protobuf.Timestamp
+	14, // 1: recommendation.RecordPurchaseRequest.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: recommendation.GetRecommendationsResponse.recommendations:type_name -> recommendation.Recommendation
+	7,  // 3: recommendation.GetFrequentlyBoughtTogetherResponse.products:type_name -> recommendation.BoughtTogether
+	14, // 4: recommendation.GetFrequentlyBoughtTogetherResponse.mined_at:type_name -> google.protobuf.Timestamp
+	14, // 5: recommendation.MineAssociationRulesResponse.mined_at:type_name -> google.protobuf.Timestamp
+	1,  // 6: recommendation.RecommendationService.RecordView:input_type -> recommendation.RecordViewRequest
+	3,  // 7: recommendation.RecommendationService.RecordPurchase:input_type -> recommendation.RecordPurchaseRequest
+	5,  // 8: recommendation.RecommendationService.GetRecommendations:input_type -> recommendation.GetRecommendationsRequest
+	8,  // 9: recommendation.RecommendationService.GetFrequentlyBoughtTogether:input_type -> recommendation.GetFrequentlyBoughtTogetherRequest
+	10, // 10: recommendation.RecommendationService.MineAssociationRules:input_type -> recommendation.MineAssociationRulesRequest
+	12, // 11: recommendation.RecommendationService.EraseUserData:input_type -> recommendation.EraseUserDataRequest
+	2,  // 12: recommendation.RecommendationService.RecordView:output_type -> recommendation.RecordViewResponse
+	4,  // 13: recommendation.RecommendationService.RecordPurchase:output_type -> recommendation.RecordPurchaseResponse
+	6,  // 14: recommendation.RecommendationService.GetRecommendations:output_type -> recommendation.GetRecommendationsResponse
+	9,  // 15: recommendation.RecommendationService.GetFrequentlyBoughtTogether:output_type -> recommendation.GetFrequentlyBoughtTogetherResponse
+	11, // 16: recommendation.RecommendationService.MineAssociationRules:output_type -> recommendation.MineAssociationRulesResponse
+	13, // 17: recommendation.RecommendationService.EraseUserData:output_type -> recommendation.EraseUserDataResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_recommendation_recommendation_proto_init() }
+func file_recommendation_recommendation_proto_init() {
+	if File_recommendation_recommendation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recommendation_recommendation_proto_rawDesc), len(file_recommendation_recommendation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recommendation_recommendation_proto_goTypes,
+		DependencyIndexes: file_recommendation_recommendation_proto_depIdxs,
+		MessageInfos:      file_recommendation_recommendation_proto_msgTypes,
+	}.Build()
+	File_recommendation_recommendation_proto = out.File
+	file_recommendation_recommendation_proto_goTypes = nil
+	file_recommendation_recommendation_proto_depIdxs = nil
+}
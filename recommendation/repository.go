@@ -0,0 +1,495 @@
+package recommendation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/lib/pq"
+)
+
+// maxPurchaseHistory caps how many of a customer's earlier products a new
+// purchase is paired with
+const maxPurchaseHistory = 50
+
+// Purchase is the products of a paid order
+type Purchase struct {
+	OrderID     string
+	UserID      string
+	ProductIDs  []string
+	PurchasedAt time.Time
+}
+
+// Seed is a product recommendations are drawn from, weighted by how strongly
+// it reflects the customer's interest
+type Seed struct {
+	ProductID string
+	Weight    float64
+}
+
+// Scored is a product and its score
+type Scored struct {
+	ProductID string
+	Score     float64
+}
+
+// MiningParams bound the association rules mined from orders
+type MiningParams struct {
+	// Since is the oldest order mined
+	Since time.Time
+	// MinOrders is the fewest orders a pair of products must share
+	MinOrders int32
+	// MinConfidence is the smallest share of a product's orders that must
+	// have the related product too
+	MinConfidence float64
+	// MaxRules caps the rules kept per product, the most confident first
+	MaxRules int32
+}
+
+// MiningResult is the outcome of mining association rules
+type MiningResult struct {
+	Orders  int32
+	Rules   int32
+	MinedAt time.Time
+}
+
+// Rule is an association rule: orders with ProductID often have RelatedID
+// too
+type Rule struct {
+	ProductID  string
+	RelatedID  string
+	Orders     int32
+	Support    float64
+	Confidence float64
+	Lift       float64
+	MinedAt    time.Time
+}
+
+// Activity is a customer's recent products, most recent first
+type Activity struct {
+	Viewed    []string
+	Purchased []string
+}
+
+// Repository handles recommendation data persistence. A customer's views
+// and purchases are scoped to the tenant of the request context. Product
+// pairs, popularity and association rules cover every tenant, as the catalog
+// they describe is shared by all tenants.
+type Repository interface {
+	RecordView(ctx context.Context, userID, productID string, at time.Time) error
+	// RecordPurchase records a paid order and pairs each product the customer
+	// buys for the first time with the customer's other products. It returns
+	// false when the order was already recorded.
+	RecordPurchase(ctx context.Context, purchase *Purchase) (bool, error)
+	// AlsoBought scores the products bought by customers who bought the
+	// seeds, leaving out the excluded products
+	AlsoBought(ctx context.Context, seeds []Seed, exclude []string, limit int32) ([]Scored, error)
+	// Popular scores products by how many customers bought them since a time,
+	// leaving out the excluded products
+	Popular(ctx context.Context, since time.Time, exclude []string, limit int32) ([]Scored, error)
+	// Activity retrieves up to limit of a customer's recently viewed and
+	// purchased products
+	Activity(ctx context.Context, userID string, limit int32) (*Activity, error)
+	// MineRules replaces the association rules with the ones mined from the
+	// orders since params.Since, stamped minedAt
+	MineRules(ctx context.Context, params MiningParams, minedAt time.Time) (*MiningResult, error)
+	// BoughtTogether retrieves up to limit rules of a product, the most
+	// confident first
+	BoughtTogether(ctx context.Context, productID string, limit int32) ([]Rule, error)
+	// DeleteUser deletes a customer's views and purchases and returns how
+	// many views and orders it deleted
+	DeleteUser(ctx context.Context, userID string) (int32, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL recommendation repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+// RecordView inserts a product view
+func (r *postgresRepository) RecordView(ctx context.Context, userID, productID string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO product_views (tenant_id, user_id, product_id, viewed_at) VALUES ($1, $2, $3, $4)", tenant.FromContext(ctx), userID, productID, at)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record view", map[string]interface{}{"error": err.Error(), "user_id": userID, "product_id": productID})
+		return fmt.Errorf("failed to record view: %w", err)
+	}
+	return nil
+}
+
+// RecordPurchase records a paid order. A customer's purchases are serialized
+// on an advisory lock, so concurrent orders see each other's products.
+func (r *postgresRepository) RecordPurchase(ctx context.Context, purchase *Purchase) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", purchase.UserID); err != nil {
+		return false, fmt.Errorf("failed to lock customer purchases: %w", err)
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO purchase_orders (order_id, tenant_id, user_id, purchased_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (order_id) DO NOTHING
+	`, purchase.OrderID, tenantID, purchase.UserID, purchase.PurchasedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record purchase", map[string]interface{}{"error": err.Error(), "order_id": purchase.OrderID})
+		return false, fmt.Errorf("failed to record purchase: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record purchase: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	var previous []string
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(array_agg(product_id::text), '{}') FROM (
+			SELECT product_id FROM purchases
+			WHERE tenant_id = $3 AND user_id = $1
+			GROUP BY product_id
+			ORDER BY MAX(purchased_at) DESC
+			LIMIT $2
+		) recent
+	`, purchase.UserID, maxPurchaseHistory, tenantID).Scan(pq.Array(&previous))
+	if err != nil {
+		return false, fmt.Errorf("failed to get earlier purchases: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO purchases (order_id, user_id, product_id, purchased_at, tenant_id)
+		SELECT $1, $2, product_id, $4, $5 FROM unnest($3::uuid[]) AS product_id
+	`, purchase.OrderID, purchase.UserID, pq.Array(purchase.ProductIDs), purchase.PurchasedAt, tenantID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record purchased products", map[string]interface{}{"error": err.Error(), "order_id": purchase.OrderID})
+		return false, fmt.Errorf("failed to record purchased products: %w", err)
+	}
+
+	from, to := pairs(purchase.ProductIDs, previous)
+	if len(from) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO product_pairs (product_id, related_id, customers)
+			SELECT product_id, related_id, 1 FROM unnest($1::uuid[], $2::uuid[]) AS p(product_id, related_id)
+			ON CONFLICT (product_id, related_id) DO UPDATE SET customers = product_pairs.customers + 1
+		`, pq.Array(from), pq.Array(to))
+		if err != nil {
+			r.log.Error(ctx, "Failed to update product pairs", map[string]interface{}{"error": err.Error(), "order_id": purchase.OrderID})
+			return false, fmt.Errorf("failed to update product pairs: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit purchase: %w", err)
+	}
+
+	r.log.Info(ctx, "Purchase recorded", map[string]interface{}{"order_id": purchase.OrderID, "user_id": purchase.UserID, "pairs": len(from)})
+	return true, nil
+}
+
+// AlsoBought sums the co-purchase counts of the seeds' related products,
+// weighted by seed
+func (r *postgresRepository) AlsoBought(ctx context.Context, seeds []Seed, exclude []string, limit int32) ([]Scored, error) {
+	if len(seeds) == 0 {
+		return []Scored{}, nil
+	}
+	ids := make([]string, len(seeds))
+	weights := make([]float64, len(seeds))
+	for i, s := range seeds {
+		ids[i] = s.ProductID
+		weights[i] = s.Weight
+	}
+
+	query := `
+		SELECT p.related_id, SUM(p.customers * s.weight) AS score
+		FROM product_pairs p
+		JOIN unnest($1::uuid[], $2::float8[]) AS s(product_id, weight) ON s.product_id = p.product_id
+		WHERE NOT (p.related_id = ANY($3::uuid[]))
+		GROUP BY p.related_id
+		ORDER BY score DESC, p.related_id
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids), pq.Array(weights), pq.Array(exclude), limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get also bought products", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get also bought products: %w", err)
+	}
+	return scanScored(rows)
+}
+
+// Popular counts the distinct customers of each product since a time
+func (r *postgresRepository) Popular(ctx context.Context, since time.Time, exclude []string, limit int32) ([]Scored, error) {
+	query := `
+		SELECT product_id, COUNT(DISTINCT user_id) AS score
+		FROM purchases
+		WHERE purchased_at >= $1 AND NOT (product_id = ANY($2::uuid[]))
+		GROUP BY product_id
+		ORDER BY score DESC, product_id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since, pq.Array(exclude), limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get popular products", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to get popular products: %w", err)
+	}
+	return scanScored(rows)
+}
+
+// Activity retrieves a customer's recent distinct products
+func (r *postgresRepository) Activity(ctx context.Context, userID string, limit int32) (*Activity, error) {
+	activity := &Activity{}
+	tenantID := tenant.FromContext(ctx)
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(array_agg(product_id::text ORDER BY last_viewed DESC), '{}') FROM (
+			SELECT product_id, MAX(viewed_at) AS last_viewed FROM product_views
+			WHERE tenant_id = $3 AND user_id = $1
+			GROUP BY product_id
+			ORDER BY last_viewed DESC
+			LIMIT $2
+		) recent
+	`, userID, limit, tenantID).Scan(pq.Array(&activity.Viewed))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get viewed products", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, fmt.Errorf("failed to get viewed products: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(array_agg(product_id::text ORDER BY last_purchased DESC), '{}') FROM (
+			SELECT product_id, MAX(purchased_at) AS last_purchased FROM purchases
+			WHERE tenant_id = $3 AND user_id = $1
+			GROUP BY product_id
+			ORDER BY last_purchased DESC
+			LIMIT $2
+		) recent
+	`, userID, limit, tenantID).Scan(pq.Array(&activity.Purchased))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get purchased products", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, fmt.Errorf("failed to get purchased products: %w", err)
+	}
+
+	return activity, nil
+}
+
+// MineRules counts, over the orders in the window, the orders with each
+// product and with each pair of products, and keeps the pairs ordered
+// together often and more often than chance (a lift above 1). Minings are
+// serialized on an advisory lock, and the rules are replaced in one
+// transaction, so readers see the previous rules until the new ones are
+// committed.
+func (r *postgresRepository) MineRules(ctx context.Context, params MiningParams, minedAt time.Time) (*MiningResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('association_rules'))"); err != nil {
+		return nil, fmt.Errorf("failed to lock association rules: %w", err)
+	}
+
+	result := &MiningResult{MinedAt: minedAt}
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(DISTINCT order_id) FROM purchases WHERE purchased_at >= $1", params.Since).Scan(&result.Orders)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count orders to mine", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM association_rules"); err != nil {
+		return nil, fmt.Errorf("failed to clear association rules: %w", err)
+	}
+
+	inserted, err := tx.ExecContext(ctx, `
+		WITH baskets AS (
+			SELECT order_id, product_id FROM purchases WHERE purchased_at >= $1
+		), item_orders AS (
+			SELECT product_id, COUNT(*) AS orders FROM baskets GROUP BY product_id
+		), pair_orders AS (
+			SELECT a.product_id, b.product_id AS related_id, COUNT(*) AS orders
+			FROM baskets a JOIN baskets b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+			GROUP BY a.product_id, b.product_id
+			HAVING COUNT(*) >= $2
+		), rules AS (
+			SELECT p.product_id, p.related_id, p.orders,
+				p.orders::float8 / $3 AS support,
+				p.orders::float8 / a.orders AS confidence,
+				p.orders::float8 * $3 / (a.orders * b.orders) AS lift
+			FROM pair_orders p
+			JOIN item_orders a ON a.product_id = p.product_id
+			JOIN item_orders b ON b.product_id = p.related_id
+		), ranked AS (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY confidence DESC, orders DESC, related_id) AS rank
+			FROM rules
+			WHERE confidence >= $4 AND lift > 1
+		)
+		INSERT INTO association_rules (product_id, related_id, orders, support, confidence, lift, mined_at)
+		SELECT product_id, related_id, orders, support, confidence, lift, $6 FROM ranked WHERE rank <= $5
+	`, params.Since, params.MinOrders, result.Orders, params.MinConfidence, params.MaxRules, minedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to mine association rules", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to mine association rules: %w", err)
+	}
+	rules, err := inserted.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to mine association rules: %w", err)
+	}
+	result.Rules = int32(rules)
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit association rules: %w", err)
+	}
+
+	r.log.Info(ctx, "Association rules mined", map[string]interface{}{"orders": result.Orders, "rules": result.Rules})
+	return result, nil
+}
+
+// BoughtTogether retrieves a product's association rules
+func (r *postgresRepository) BoughtTogether(ctx context.Context, productID string, limit int32) ([]Rule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT product_id, related_id, orders, support, confidence, lift, mined_at
+		FROM association_rules
+		WHERE product_id = $1
+		ORDER BY confidence DESC, orders DESC, related_id
+		LIMIT $2
+	`, productID, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to get bought together products", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get bought together products: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []Rule{}
+	for rows.Next() {
+		var rule Rule
+		if err := rows.Scan(&rule.ProductID, &rule.RelatedID, &rule.Orders, &rule.Support, &rule.Confidence, &rule.Lift, &rule.MinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan association rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating association rules: %w", err)
+	}
+	return rules, nil
+}
+
+// DeleteUser deletes a customer's history. It takes the customer's purchase
+// lock, so a purchase being recorded is either deleted with the rest or
+// recorded after.
+func (r *postgresRepository) DeleteUser(ctx context.Context, userID string) (int32, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", userID); err != nil {
+		return 0, fmt.Errorf("failed to lock customer purchases: %w", err)
+	}
+
+	var deleted int64
+	// The purchases of an order are deleted with it
+	for _, query := range []string{
+		"DELETE FROM product_views WHERE user_id = $1 AND tenant_id = $2",
+		"DELETE FROM purchase_orders WHERE user_id = $1 AND tenant_id = $2",
+	} {
+		result, err := tx.ExecContext(ctx, query, userID, tenant.FromContext(ctx))
+		if err != nil {
+			r.log.Error(ctx, "Failed to delete customer history", map[string]interface{}{"error": err.Error(), "user_id": userID})
+			return 0, fmt.Errorf("failed to delete customer history: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete customer history: %w", err)
+		}
+		deleted += rows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit customer history deletion: %w", err)
+	}
+
+	r.log.Info(ctx, "Customer history deleted", map[string]interface{}{"user_id": userID, "records": deleted})
+	return int32(deleted), nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+func scanScored(rows *sql.Rows) ([]Scored, error) {
+	defer rows.Close()
+
+	scored := []Scored{}
+	for rows.Next() {
+		var s Scored
+		if err := rows.Scan(&s.ProductID, &s.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan product score: %w", err)
+		}
+		scored = append(scored, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating product scores: %w", err)
+	}
+	return scored, nil
+}
+
+// pairs lists the product pairs to count for a customer buying products
+// after previous. Only products new to the customer are paired, so each
+// pair counts a customer once: a new product is paired both ways with each
+// earlier product, and with each other new product. The pairs are sorted so
+// concurrent purchases update them in the same order.
+func pairs(products, previous []string) (from, to []string) {
+	bought := make(map[string]bool, len(previous))
+	for _, p := range previous {
+		bought[p] = true
+	}
+	var fresh []string
+	for _, p := range products {
+		if !bought[p] {
+			bought[p] = true
+			fresh = append(fresh, p)
+		}
+	}
+
+	type pair struct{ from, to string }
+	var all []pair
+	for i, p := range fresh {
+		for _, q := range previous {
+			all = append(all, pair{p, q}, pair{q, p})
+		}
+		for _, q := range fresh[i+1:] {
+			all = append(all, pair{p, q}, pair{q, p})
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].from != all[j].from {
+			return all[i].from < all[j].from
+		}
+		return all[i].to < all[j].to
+	})
+
+	for _, p := range all {
+		from = append(from, p.from)
+		to = append(to, p.to)
+	}
+	return from, to
+}
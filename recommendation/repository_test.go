@@ -0,0 +1,223 @@
+package recommendation
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("recommendation-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var scoredColumnNames = []string{"product_id", "score"}
+
+func TestRecordPurchase_PairsNewProducts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	purchase := &Purchase{OrderID: "order-2", UserID: "user-1", ProductIDs: []string{"mug", "tea"}, PurchasedAt: time.Now()}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\(\$1\)\)`).
+		WithArgs("user-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO purchase_orders").
+		WithArgs("order-2", tenant.Default, "user-1", purchase.PurchasedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(array_agg").
+		WithArgs("user-1", maxPurchaseHistory, tenant.Default).
+		WillReturnRows(sqlmock.NewRows([]string{"array_agg"}).AddRow("{mug}"))
+	mock.ExpectExec("INSERT INTO purchases").
+		WithArgs("order-2", "user-1", pq.Array([]string{"mug", "tea"}), purchase.PurchasedAt, tenant.Default).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	// The mug was bought before, so only tea is paired
+	mock.ExpectExec("INSERT INTO product_pairs").
+		WithArgs(pq.Array([]string{"mug", "tea"}), pq.Array([]string{"tea", "mug"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	recorded, err := repo.RecordPurchase(context.Background(), purchase)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !recorded {
+		t.Error("Expected the purchase to be recorded")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecordPurchase_AlreadyRecorded(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO purchase_orders").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	recorded, err := repo.RecordPurchase(context.Background(), &Purchase{OrderID: "order-1", UserID: "user-1", ProductIDs: []string{"mug"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recorded {
+		t.Error("Expected a repeated order not to be recorded")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAlsoBought(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT p.related_id, SUM\(p.customers \* s.weight\)`).
+		WithArgs(pq.Array([]string{"mug", "kettle"}), pq.Array([]float64{2, 1}), pq.Array([]string{"mug"}), int32(5)).
+		WillReturnRows(sqlmock.NewRows(scoredColumnNames).
+			AddRow("tea", 5.0).
+			AddRow("spoon", 1.0))
+
+	scored, err := repo.AlsoBought(context.Background(), []Seed{{"mug", 2}, {"kettle", 1}}, []string{"mug"}, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(scored) != 2 || scored[0].ProductID != "tea" || scored[0].Score != 5 {
+		t.Errorf("Unexpected scores %v", scored)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAlsoBought_NoSeeds(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	scored, err := repo.AlsoBought(context.Background(), nil, nil, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(scored) != 0 {
+		t.Errorf("Expected no scores, got %v", scored)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestActivity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`FROM product_views\s+WHERE tenant_id = \$3 AND user_id = \$1`).
+		WithArgs("user-1", int32(20), "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"array_agg"}).AddRow("{kettle,mug}"))
+	mock.ExpectQuery(`FROM purchases\s+WHERE tenant_id = \$3 AND user_id = \$1`).
+		WithArgs("user-1", int32(20), "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"array_agg"}).AddRow("{}"))
+
+	activity, err := repo.Activity(tenant.NewContext(context.Background(), "acme"), "user-1", 20)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(activity.Viewed) != 2 || activity.Viewed[0] != "kettle" || len(activity.Purchased) != 0 {
+		t.Errorf("Unexpected activity %+v", activity)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\(\$1\)\)`).WithArgs("user-1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM product_views WHERE user_id = \$1 AND tenant_id = \$2`).WithArgs("user-1", tenant.Default).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(`DELETE FROM purchase_orders WHERE user_id = \$1 AND tenant_id = \$2`).WithArgs("user-1", tenant.Default).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteUser(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if deleted != 6 {
+		t.Errorf("Expected 6 records deleted, got %d", deleted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMineRules(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	minedAt := since.Add(180 * 24 * time.Hour)
+	params := MiningParams{Since: since, MinOrders: 3, MinConfidence: 0.05, MaxRules: 20}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\('association_rules'\)\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT order_id\) FROM purchases WHERE purchased_at >= \$1`).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(120))
+	mock.ExpectExec(`DELETE FROM association_rules`).WillReturnResult(sqlmock.NewResult(0, 14))
+	mock.ExpectExec(`INSERT INTO association_rules .* FROM ranked WHERE rank <= \$5`).
+		WithArgs(since, int32(3), int32(120), 0.05, int32(20), minedAt).
+		WillReturnResult(sqlmock.NewResult(0, 16))
+	mock.ExpectCommit()
+
+	result, err := repo.MineRules(context.Background(), params, minedAt)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Orders != 120 || result.Rules != 16 || !result.MinedAt.Equal(minedAt) {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestBoughtTogether(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	minedAt := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM association_rules WHERE product_id = \$1 ORDER BY confidence DESC`).
+		WithArgs("mug", int32(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "related_id", "orders", "support", "confidence", "lift", "mined_at"}).
+			AddRow("mug", "tea", 30, 0.25, 0.75, 2.0, minedAt))
+
+	rules, err := repo.BoughtTogether(context.Background(), "mug", 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rules) != 1 || rules[0].RelatedID != "tea" || rules[0].Orders != 30 || rules[0].Confidence != 0.75 {
+		t.Errorf("Unexpected rules %+v", rules)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,358 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/recommendation/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Recommendation contexts
+const (
+	ContextProduct = "PRODUCT"
+	ContextHome    = "HOME"
+)
+
+// Recommendation reasons
+const (
+	ReasonAlsoBought   = "ALSO_BOUGHT"
+	ReasonPersonalized = "PERSONALIZED"
+	ReasonPopular      = "POPULAR"
+)
+
+const (
+	// maxUserIDLength matches the user_id columns
+	maxUserIDLength = 255
+	// maxOrderProducts matches the order service's limit on lines per order
+	maxOrderProducts = 100
+	// maxLimit caps the recommendations returned at once
+	maxLimit = 50
+	// activityLimit is how many recently viewed and purchased products seed
+	// personalized picks
+	activityLimit = 20
+	// popularWindow is how far back purchases count towards popularity
+	popularWindow = 30 * 24 * time.Hour
+	// maxBoughtTogether caps the frequently bought together products
+	// returned at once
+	maxBoughtTogether = 20
+)
+
+// DefaultMiningParams mine the orders of the last 180 days (Since is set
+// when mining) into rules of pairs in at least 3 orders, and in at least 5%
+// of the orders of the product, keeping 20 rules per product
+var DefaultMiningParams = MiningParams{
+	MinOrders:     3,
+	MinConfidence: 0.05,
+	MaxRules:      maxBoughtTogether,
+}
+
+// miningWindow is how far back orders are mined for association rules
+const miningWindow = 180 * 24 * time.Hour
+
+// Seed weights of personalized picks: buying a product says more about a
+// customer's taste than viewing it
+const (
+	viewWeight     = 1.0
+	purchaseWeight = 2.0
+)
+
+// AdminMethods are the admin-scoped RPCs of the recommendation service; they
+// are restricted to the admin network allowlist. The order service records
+// purchases from inside it, and the jobs service mines association rules.
+var AdminMethods = []string{
+	pb.RecommendationService_RecordPurchase_FullMethodName,
+	pb.RecommendationService_MineAssociationRules_FullMethodName,
+	pb.RecommendationService_EraseUserData_FullMethodName,
+}
+
+// MethodRoles maps the RPCs needing a token to the roles allowed to call
+// them: EraseUserData is called by the privacy service, with a SERVICE token
+func MethodRoles() map[string][]string {
+	return map[string][]string{
+		pb.RecommendationService_EraseUserData_FullMethodName: {auth.RoleService},
+	}
+}
+
+// Service implements the RecommendationService gRPC interface
+type Service struct {
+	pb.UnimplementedRecommendationServiceServer
+	repo Repository
+	log  *logger.Logger
+	now  func() time.Time
+}
+
+// NewService creates a new recommendation service
+func NewService(repo Repository, log *logger.Logger) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+// RecordView records that a signed-in customer viewed a product
+func (s *Service) RecordView(ctx context.Context, req *pb.RecordViewRequest) (*pb.RecordViewResponse, error) {
+	switch msg := userProblem(req.UserId); {
+	case msg != "":
+		s.log.Warn(ctx, "Record view failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	case !isUUID(req.ProductId):
+		s.log.Warn(ctx, "Record view failed: invalid product ID", map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, "product_id must be a UUID")
+	}
+
+	at := s.now()
+	if req.OccurredAt != nil {
+		at = req.OccurredAt.AsTime()
+	}
+
+	if err := s.repo.RecordView(ctx, req.UserId, req.ProductId, at); err != nil {
+		return nil, status.Error(codes.Internal, "failed to record view")
+	}
+	return &pb.RecordViewResponse{}, nil
+}
+
+// RecordPurchase records the products of a paid order. Recording an order
+// again has no effect, so the order service can retry.
+func (s *Service) RecordPurchase(ctx context.Context, req *pb.RecordPurchaseRequest) (*pb.RecordPurchaseResponse, error) {
+	products, msg := purchaseProblem(req)
+	if msg != "" {
+		s.log.Warn(ctx, "Record purchase failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	purchase := &Purchase{
+		OrderID:     req.OrderId,
+		UserID:      req.UserId,
+		ProductIDs:  products,
+		PurchasedAt: s.now(),
+	}
+	if req.OccurredAt != nil {
+		purchase.PurchasedAt = req.OccurredAt.AsTime()
+	}
+
+	recorded, err := s.repo.RecordPurchase(ctx, purchase)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to record purchase")
+	}
+	return &pb.RecordPurchaseResponse{Recorded: recorded}, nil
+}
+
+// EraseUserData deletes a customer's history for the privacy service when
+// their account is deleted. Erasing a customer again deletes nothing.
+func (s *Service) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Erase user data failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	records, err := s.repo.DeleteUser(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to erase user data")
+	}
+	return &pb.EraseUserDataResponse{Records: records}, nil
+}
+
+// purchaseProblem validates a purchase and returns its de-duplicated
+// products, or a message describing the first problem
+func purchaseProblem(req *pb.RecordPurchaseRequest) ([]string, string) {
+	switch {
+	case !isUUID(req.OrderId):
+		return nil, "order_id must be a UUID"
+	case userProblem(req.UserId) != "":
+		return nil, userProblem(req.UserId)
+	case len(req.ProductIds) == 0:
+		return nil, "product_ids are required"
+	case len(req.ProductIds) > maxOrderProducts:
+		return nil, fmt.Sprintf("an order cannot have more than %d products", maxOrderProducts)
+	}
+
+	products := make([]string, 0, len(req.ProductIds))
+	seen := map[string]bool{}
+	for _, id := range req.ProductIds {
+		if !isUUID(id) {
+			return nil, "product_ids must be UUIDs"
+		}
+		if !seen[id] {
+			seen[id] = true
+			products = append(products, id)
+		}
+	}
+	return products, ""
+}
+
+// GetRecommendations suggests products for a product page or a customer's
+// home page, topped up with popular products
+func (s *Service) GetRecommendations(ctx context.Context, req *pb.GetRecommendationsRequest) (*pb.GetRecommendationsResponse, error) {
+	recContext := req.Context
+	if recContext == "" {
+		recContext = ContextHome
+		if req.ProductId != "" {
+			recContext = ContextProduct
+		}
+	}
+
+	switch {
+	case recContext != ContextProduct && recContext != ContextHome:
+		s.log.Warn(ctx, "Get recommendations failed: invalid context", map[string]interface{}{"context": req.Context})
+		return nil, status.Error(codes.InvalidArgument, "context must be PRODUCT or HOME")
+	case recContext == ContextProduct && !isUUID(req.ProductId):
+		s.log.Warn(ctx, "Get recommendations failed: invalid product ID", map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, "product_id must be a UUID for the PRODUCT context")
+	case len(req.UserId) > maxUserIDLength:
+		s.log.Warn(ctx, "Get recommendations failed: user ID too long", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is too long")
+	}
+
+	limit := req.Limit
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	activity := &Activity{}
+	if req.UserId != "" {
+		var err error
+		if activity, err = s.repo.Activity(ctx, req.UserId, activityLimit); err != nil {
+			return nil, status.Error(codes.Internal, "failed to get recommendations")
+		}
+	}
+
+	// Products the customer already owns are not suggested again
+	exclude := append([]string{}, activity.Purchased...)
+	var seeds []Seed
+	reason := ReasonPersonalized
+	if recContext == ContextProduct {
+		seeds = []Seed{{ProductID: req.ProductId, Weight: 1}}
+		exclude = append(exclude, req.ProductId)
+		reason = ReasonAlsoBought
+	} else {
+		seeds = personalSeeds(activity)
+	}
+
+	picks, err := s.repo.AlsoBought(ctx, seeds, exclude, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get recommendations")
+	}
+	resp := &pb.GetRecommendationsResponse{Context: recContext}
+	for _, p := range picks {
+		resp.Recommendations = append(resp.Recommendations, &pb.Recommendation{ProductId: p.ProductID, Score: p.Score, Reason: reason})
+		exclude = append(exclude, p.ProductID)
+	}
+
+	if missing := limit - int32(len(picks)); missing > 0 {
+		popular, err := s.repo.Popular(ctx, s.now().Add(-popularWindow), exclude, missing)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get recommendations")
+		}
+		for _, p := range popular {
+			resp.Recommendations = append(resp.Recommendations, &pb.Recommendation{ProductId: p.ProductID, Score: p.Score, Reason: ReasonPopular})
+		}
+	}
+
+	return resp, nil
+}
+
+// GetFrequentlyBoughtTogether returns the products most often in the same
+// orders as a product, from the association rules last mined. A product
+// without rules, such as a new one, has none.
+func (s *Service) GetFrequentlyBoughtTogether(ctx context.Context, req *pb.GetFrequentlyBoughtTogetherRequest) (*pb.GetFrequentlyBoughtTogetherResponse, error) {
+	if !isUUID(req.ProductId) {
+		s.log.Warn(ctx, "Get frequently bought together failed: invalid product ID", map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, "product_id must be a UUID")
+	}
+
+	limit := req.Limit
+	if limit < 1 {
+		limit = 5
+	}
+	if limit > maxBoughtTogether {
+		limit = maxBoughtTogether
+	}
+
+	rules, err := s.repo.BoughtTogether(ctx, req.ProductId, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get frequently bought together products")
+	}
+
+	resp := &pb.GetFrequentlyBoughtTogetherResponse{}
+	for _, rule := range rules {
+		resp.Products = append(resp.Products, &pb.BoughtTogether{
+			ProductId:  rule.RelatedID,
+			Orders:     rule.Orders,
+			Confidence: rule.Confidence,
+			Lift:       rule.Lift,
+		})
+	}
+	if len(rules) > 0 {
+		resp.MinedAt = timestamppb.New(rules[0].MinedAt)
+	}
+	return resp, nil
+}
+
+// MineAssociationRules replaces the association rules with ones mined from
+// recent orders
+func (s *Service) MineAssociationRules(ctx context.Context, req *pb.MineAssociationRulesRequest) (*pb.MineAssociationRulesResponse, error) {
+	now := s.now()
+	params := DefaultMiningParams
+	params.Since = now.Add(-miningWindow)
+
+	result, err := s.repo.MineRules(ctx, params, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to mine association rules")
+	}
+	return &pb.MineAssociationRulesResponse{
+		Orders:  result.Orders,
+		Rules:   result.Rules,
+		MinedAt: timestamppb.New(result.MinedAt),
+	}, nil
+}
+
+// personalSeeds weights a customer's recent products. A product both viewed
+// and bought counts as both.
+func personalSeeds(activity *Activity) []Seed {
+	weights := map[string]float64{}
+	var order []string
+	add := func(products []string, weight float64) {
+		for _, p := range products {
+			if _, ok := weights[p]; !ok {
+				order = append(order, p)
+			}
+			weights[p] += weight
+		}
+	}
+	add(activity.Purchased, purchaseWeight)
+	add(activity.Viewed, viewWeight)
+
+	seeds := make([]Seed, len(order))
+	for i, p := range order {
+		seeds[i] = Seed{ProductID: p, Weight: weights[p]}
+	}
+	return seeds
+}
+
+// userProblem validates a customer ID and returns a message describing the
+// problem, or ""
+func userProblem(userID string) string {
+	switch {
+	case userID == "":
+		return "user_id is required"
+	case len(userID) > maxUserIDLength:
+		return "user_id is too long"
+	}
+	return ""
+}
+
+func isUUID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
@@ -0,0 +1,635 @@
+package recommendation
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/recommendation/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type view struct {
+	userID, productID string
+	at                time.Time
+}
+
+// memoryRepository is an in-memory Repository that pairs products the same
+// way as the Postgres repository
+type memoryRepository struct {
+	mu        sync.Mutex
+	views     []view
+	purchases []Purchase
+	orders    map[string]bool
+	pairs     map[[2]string]float64
+	rules     []Rule
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{orders: map[string]bool{}, pairs: map[[2]string]float64{}}
+}
+
+func (m *memoryRepository) RecordView(ctx context.Context, userID, productID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.views = append(m.views, view{userID, productID, at})
+	return nil
+}
+
+func (m *memoryRepository) RecordPurchase(ctx context.Context, purchase *Purchase) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.orders[purchase.OrderID] {
+		return false, nil
+	}
+	m.orders[purchase.OrderID] = true
+
+	var previous []string
+	for _, p := range m.purchases {
+		if p.UserID == purchase.UserID {
+			previous = append(previous, p.ProductIDs...)
+		}
+	}
+	from, to := pairs(purchase.ProductIDs, previous)
+	for i := range from {
+		m.pairs[[2]string{from[i], to[i]}]++
+	}
+	m.purchases = append(m.purchases, *purchase)
+	return true, nil
+}
+
+func (m *memoryRepository) AlsoBought(ctx context.Context, seeds []Seed, exclude []string, limit int32) ([]Scored, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	scores := map[string]float64{}
+	for _, s := range seeds {
+		for pair, customers := range m.pairs {
+			if pair[0] == s.ProductID {
+				scores[pair[1]] += customers * s.Weight
+			}
+		}
+	}
+	return top(scores, exclude, limit), nil
+}
+
+func (m *memoryRepository) Popular(ctx context.Context, since time.Time, exclude []string, limit int32) ([]Scored, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	customers := map[string]map[string]bool{}
+	for _, p := range m.purchases {
+		if p.PurchasedAt.Before(since) {
+			continue
+		}
+		for _, id := range p.ProductIDs {
+			if customers[id] == nil {
+				customers[id] = map[string]bool{}
+			}
+			customers[id][p.UserID] = true
+		}
+	}
+	scores := map[string]float64{}
+	for id, users := range customers {
+		scores[id] = float64(len(users))
+	}
+	return top(scores, exclude, limit), nil
+}
+
+func (m *memoryRepository) Activity(ctx context.Context, userID string, limit int32) (*Activity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	activity := &Activity{}
+	seen := map[string]bool{}
+	for i := len(m.views) - 1; i >= 0 && int32(len(activity.Viewed)) < limit; i-- {
+		if v := m.views[i]; v.userID == userID && !seen[v.productID] {
+			seen[v.productID] = true
+			activity.Viewed = append(activity.Viewed, v.productID)
+		}
+	}
+	seen = map[string]bool{}
+	for i := len(m.purchases) - 1; i >= 0; i-- {
+		if p := m.purchases[i]; p.UserID == userID {
+			for _, id := range p.ProductIDs {
+				if !seen[id] && int32(len(activity.Purchased)) < limit {
+					seen[id] = true
+					activity.Purchased = append(activity.Purchased, id)
+				}
+			}
+		}
+	}
+	return activity, nil
+}
+
+func (m *memoryRepository) MineRules(ctx context.Context, params MiningParams, minedAt time.Time) (*MiningResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items := map[string]int32{}
+	together := map[[2]string]int32{}
+	var orders int32
+	for _, p := range m.purchases {
+		if p.PurchasedAt.Before(params.Since) {
+			continue
+		}
+		orders++
+		for _, a := range p.ProductIDs {
+			items[a]++
+			for _, b := range p.ProductIDs {
+				if a != b {
+					together[[2]string{a, b}]++
+				}
+			}
+		}
+	}
+
+	byProduct := map[string][]Rule{}
+	for pair, n := range together {
+		rule := Rule{
+			ProductID:  pair[0],
+			RelatedID:  pair[1],
+			Orders:     n,
+			Support:    float64(n) / float64(orders),
+			Confidence: float64(n) / float64(items[pair[0]]),
+			Lift:       float64(n) * float64(orders) / float64(items[pair[0]]*items[pair[1]]),
+			MinedAt:    minedAt,
+		}
+		if n >= params.MinOrders && rule.Confidence >= params.MinConfidence && rule.Lift > 1 {
+			byProduct[pair[0]] = append(byProduct[pair[0]], rule)
+		}
+	}
+	m.rules = nil
+	for _, rules := range byProduct {
+		sortRules(rules)
+		if int32(len(rules)) > params.MaxRules {
+			rules = rules[:params.MaxRules]
+		}
+		m.rules = append(m.rules, rules...)
+	}
+	return &MiningResult{Orders: orders, Rules: int32(len(m.rules)), MinedAt: minedAt}, nil
+}
+
+func (m *memoryRepository) BoughtTogether(ctx context.Context, productID string, limit int32) ([]Rule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rules := []Rule{}
+	for _, rule := range m.rules {
+		if rule.ProductID == productID {
+			rules = append(rules, rule)
+		}
+	}
+	sortRules(rules)
+	if int32(len(rules)) > limit {
+		rules = rules[:limit]
+	}
+	return rules, nil
+}
+
+// sortRules orders rules like the Postgres queries: most confident first,
+// then by orders and related product
+func sortRules(rules []Rule) {
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Confidence != rules[j].Confidence {
+			return rules[i].Confidence > rules[j].Confidence
+		}
+		if rules[i].Orders != rules[j].Orders {
+			return rules[i].Orders > rules[j].Orders
+		}
+		return rules[i].RelatedID < rules[j].RelatedID
+	})
+}
+
+func (m *memoryRepository) DeleteUser(ctx context.Context, userID string) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int32
+	views := m.views[:0]
+	for _, v := range m.views {
+		if v.userID == userID {
+			deleted++
+			continue
+		}
+		views = append(views, v)
+	}
+	m.views = views
+	purchases := m.purchases[:0]
+	for _, p := range m.purchases {
+		if p.UserID == userID {
+			delete(m.orders, p.OrderID)
+			deleted++
+			continue
+		}
+		purchases = append(purchases, p)
+	}
+	m.purchases = purchases
+	return deleted, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// top orders scores like the Postgres queries: highest first, then by ID
+func top(scores map[string]float64, exclude []string, limit int32) []Scored {
+	for _, id := range exclude {
+		delete(scores, id)
+	}
+	scored := []Scored{}
+	for id, score := range scores {
+		scored = append(scored, Scored{ProductID: id, Score: score})
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].ProductID < scored[j].ProductID
+	})
+	if int32(len(scored)) > limit {
+		scored = scored[:limit]
+	}
+	return scored
+}
+
+var (
+	now    = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	mug    = uuid.NewString()
+	tea    = uuid.NewString()
+	kettle = uuid.NewString()
+	spoon  = uuid.NewString()
+)
+
+func setupService(repo Repository) *Service {
+	service := NewService(repo, logger.New("recommendation-test"))
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func purchase(t *testing.T, service *Service, userID string, products ...string) {
+	t.Helper()
+	_, err := service.RecordPurchase(context.Background(), &pb.RecordPurchaseRequest{
+		OrderId:    uuid.NewString(),
+		UserId:     userID,
+		ProductIds: products,
+	})
+	if err != nil {
+		t.Fatalf("Failed to record purchase: %v", err)
+	}
+}
+
+func productIDs(recs []*pb.Recommendation) []string {
+	ids := make([]string, len(recs))
+	for i, r := range recs {
+		ids[i] = r.ProductId
+	}
+	return ids
+}
+
+func TestRecordPurchase(t *testing.T) {
+	repo := newMemoryRepository()
+	service := setupService(repo)
+	orderID := uuid.NewString()
+	req := &pb.RecordPurchaseRequest{
+		OrderId:    orderID,
+		UserId:     "user-1",
+		ProductIds: []string{mug, tea, mug},
+		OccurredAt: timestamppb.New(now.Add(-time.Hour)),
+	}
+
+	resp, err := service.RecordPurchase(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Recorded {
+		t.Error("Expected the purchase to be recorded")
+	}
+	got := repo.purchases[0]
+	if len(got.ProductIDs) != 2 || !got.PurchasedAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected two distinct products purchased an hour ago, got %+v", got)
+	}
+
+	// The order service retries; the order is only counted once
+	resp, err = service.RecordPurchase(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Recorded {
+		t.Error("Expected a repeated order not to be recorded")
+	}
+	if repo.pairs[[2]string{mug, tea}] != 1 {
+		t.Errorf("Expected the pair to be counted once, got %v", repo.pairs[[2]string{mug, tea}])
+	}
+}
+
+func TestRecordPurchase_Validation(t *testing.T) {
+	tooMany := make([]string, maxOrderProducts+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+	tests := []struct {
+		name string
+		req  *pb.RecordPurchaseRequest
+	}{
+		{"invalid order ID", &pb.RecordPurchaseRequest{OrderId: "order-1", UserId: "user-1", ProductIds: []string{mug}}},
+		{"missing user", &pb.RecordPurchaseRequest{OrderId: uuid.NewString(), ProductIds: []string{mug}}},
+		{"no products", &pb.RecordPurchaseRequest{OrderId: uuid.NewString(), UserId: "user-1"}},
+		{"too many products", &pb.RecordPurchaseRequest{OrderId: uuid.NewString(), UserId: "user-1", ProductIds: tooMany}},
+		{"invalid product ID", &pb.RecordPurchaseRequest{OrderId: uuid.NewString(), UserId: "user-1", ProductIds: []string{"mug"}}},
+	}
+
+	service := setupService(newMemoryRepository())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.RecordPurchase(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRecordView(t *testing.T) {
+	repo := newMemoryRepository()
+	service := setupService(repo)
+
+	if _, err := service.RecordView(context.Background(), &pb.RecordViewRequest{UserId: "user-1", ProductId: mug}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(repo.views) != 1 || !repo.views[0].at.Equal(now) {
+		t.Errorf("Expected a view now, got %v", repo.views)
+	}
+
+	_, err := service.RecordView(context.Background(), &pb.RecordViewRequest{ProductId: mug})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a missing user, got %v", err)
+	}
+	_, err = service.RecordView(context.Background(), &pb.RecordViewRequest{UserId: "user-1", ProductId: "mug"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an invalid product, got %v", err)
+	}
+}
+
+func TestEraseUserData(t *testing.T) {
+	repo := newMemoryRepository()
+	service := setupService(repo)
+	purchase(t, service, "user-1", mug, tea)
+	purchase(t, service, "user-1", kettle)
+	purchase(t, service, "user-2", mug)
+	if _, err := service.RecordView(context.Background(), &pb.RecordViewRequest{UserId: "user-1", ProductId: spoon}); err != nil {
+		t.Fatalf("Failed to record view: %v", err)
+	}
+
+	resp, err := service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Two orders and one view
+	if resp.Records != 3 {
+		t.Errorf("Expected 3 records erased, got %d", resp.Records)
+	}
+	if len(repo.purchases) != 1 || repo.purchases[0].UserID != "user-2" || len(repo.views) != 0 {
+		t.Errorf("Expected only user-2's purchase left, got %v and %v", repo.purchases, repo.views)
+	}
+	// The pairs are counts over customers and are kept
+	if repo.pairs[[2]string{mug, tea}] != 1 {
+		t.Errorf("Expected the mug and tea pair kept, got %v", repo.pairs)
+	}
+
+	if _, err := service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a user, got %v", err)
+	}
+}
+
+func TestGetRecommendations_AlsoBought(t *testing.T) {
+	service := setupService(newMemoryRepository())
+	purchase(t, service, "user-1", mug, tea)
+	purchase(t, service, "user-2", mug, tea)
+	purchase(t, service, "user-3", mug)
+	purchase(t, service, "user-3", kettle)
+	purchase(t, service, "user-4", spoon)
+
+	resp, err := service.GetRecommendations(context.Background(), &pb.GetRecommendationsRequest{ProductId: mug, Limit: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Context != ContextProduct {
+		t.Errorf("Expected the PRODUCT context, got %s", resp.Context)
+	}
+	recs := resp.Recommendations
+	if len(recs) != 3 {
+		t.Fatalf("Expected 3 recommendations, got %v", productIDs(recs))
+	}
+	// Tea was bought with the mug by two customers, the kettle by one
+	if recs[0].ProductId != tea || recs[0].Score != 2 || recs[0].Reason != ReasonAlsoBought {
+		t.Errorf("Expected tea first, got %v", recs[0])
+	}
+	if recs[1].ProductId != kettle || recs[1].Reason != ReasonAlsoBought {
+		t.Errorf("Expected the kettle second, got %v", recs[1])
+	}
+	// The remaining slot is filled with a popular product other than the mug
+	if recs[2].ProductId != spoon || recs[2].Reason != ReasonPopular {
+		t.Errorf("Expected the spoon as a popular pick, got %v", recs[2])
+	}
+}
+
+func TestGetRecommendations_Personalized(t *testing.T) {
+	service := setupService(newMemoryRepository())
+	purchase(t, service, "user-1", mug, tea)
+	purchase(t, service, "user-2", kettle, spoon)
+	purchase(t, service, "user-3", mug)
+
+	// The customer bought a mug and looked at the kettle
+	if _, err := service.RecordView(context.Background(), &pb.RecordViewRequest{UserId: "user-3", ProductId: kettle}); err != nil {
+		t.Fatalf("Failed to record view: %v", err)
+	}
+
+	resp, err := service.GetRecommendations(context.Background(), &pb.GetRecommendationsRequest{UserId: "user-3"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Context != ContextHome {
+		t.Errorf("Expected the HOME context, got %s", resp.Context)
+	}
+	recs := resp.Recommendations
+	if len(recs) != 3 {
+		t.Fatalf("Expected 3 recommendations, got %v", productIDs(recs))
+	}
+	// Purchases weigh more than views
+	if recs[0].ProductId != tea || recs[0].Score != purchaseWeight || recs[0].Reason != ReasonPersonalized {
+		t.Errorf("Expected tea first, got %v", recs[0])
+	}
+	if recs[1].ProductId != spoon || recs[1].Score != viewWeight || recs[1].Reason != ReasonPersonalized {
+		t.Errorf("Expected the spoon second, got %v", recs[1])
+	}
+	// The viewed kettle is still suggested; the purchased mug is not
+	if recs[2].ProductId != kettle || recs[2].Reason != ReasonPopular {
+		t.Errorf("Expected the kettle as a popular pick, got %v", recs[2])
+	}
+}
+
+func TestGetRecommendations_AnonymousHome(t *testing.T) {
+	service := setupService(newMemoryRepository())
+	purchase(t, service, "user-1", mug)
+	purchase(t, service, "user-2", mug, tea)
+
+	resp, err := service.GetRecommendations(context.Background(), &pb.GetRecommendationsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ids := productIDs(resp.Recommendations)
+	if len(ids) != 2 || ids[0] != mug || ids[1] != tea {
+		t.Errorf("Expected [mug tea] by popularity, got %v", ids)
+	}
+	for _, r := range resp.Recommendations {
+		if r.Reason != ReasonPopular {
+			t.Errorf("Expected only popular picks, got %v", r)
+		}
+	}
+}
+
+func TestGetRecommendations_PopularWindow(t *testing.T) {
+	service := setupService(newMemoryRepository())
+	_, err := service.RecordPurchase(context.Background(), &pb.RecordPurchaseRequest{
+		OrderId:    uuid.NewString(),
+		UserId:     "user-1",
+		ProductIds: []string{mug},
+		OccurredAt: timestamppb.New(now.Add(-popularWindow - time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("Failed to record purchase: %v", err)
+	}
+
+	resp, err := service.GetRecommendations(context.Background(), &pb.GetRecommendationsRequest{Context: ContextHome})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Recommendations) != 0 {
+		t.Errorf("Expected old purchases not to count as popular, got %v", productIDs(resp.Recommendations))
+	}
+}
+
+func TestGetRecommendations_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.GetRecommendationsRequest
+	}{
+		{"unknown context", &pb.GetRecommendationsRequest{Context: "CART"}},
+		{"product context without product", &pb.GetRecommendationsRequest{Context: ContextProduct}},
+		{"invalid product ID", &pb.GetRecommendationsRequest{ProductId: "mug"}},
+	}
+
+	service := setupService(newMemoryRepository())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetRecommendations(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetFrequentlyBoughtTogether(t *testing.T) {
+	repo := newMemoryRepository()
+	service := setupService(repo)
+	// The mug is bought with tea in 3 of its 4 orders and with the kettle in
+	// 1; the spoon is in every order, so it is no likelier with the mug
+	for i := 0; i < 3; i++ {
+		purchase(t, service, "user-1", mug, tea, spoon)
+	}
+	purchase(t, service, "user-2", mug, kettle, spoon)
+	for i := 0; i < 4; i++ {
+		purchase(t, service, "user-3", kettle, spoon)
+	}
+	old := &pb.RecordPurchaseRequest{OrderId: uuid.NewString(), UserId: "user-4", ProductIds: []string{mug, kettle},
+		OccurredAt: timestamppb.New(now.Add(-miningWindow - time.Hour))}
+	if _, err := service.RecordPurchase(context.Background(), old); err != nil {
+		t.Fatalf("Failed to record purchase: %v", err)
+	}
+
+	resp, err := service.GetFrequentlyBoughtTogether(context.Background(), &pb.GetFrequentlyBoughtTogetherRequest{ProductId: mug})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Products) != 0 || resp.MinedAt != nil {
+		t.Errorf("Expected nothing before the first mining, got %v", resp)
+	}
+
+	mined, err := service.MineAssociationRules(context.Background(), &pb.MineAssociationRulesRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mined.Orders != 8 || !mined.MinedAt.AsTime().Equal(now) {
+		t.Errorf("Unexpected mining %v", mined)
+	}
+
+	resp, err = service.GetFrequentlyBoughtTogether(context.Background(), &pb.GetFrequentlyBoughtTogetherRequest{ProductId: mug})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Products) != 1 || resp.Products[0].ProductId != tea || resp.Products[0].Orders != 3 || resp.Products[0].Confidence != 0.75 {
+		t.Errorf("Expected tea alone, got %v", resp.Products)
+	}
+	if lift := resp.Products[0].Lift; lift != 2 {
+		t.Errorf("Expected a lift of 2, got %v", lift)
+	}
+	if !resp.MinedAt.AsTime().Equal(now) {
+		t.Errorf("Expected mined_at %v, got %v", now, resp.MinedAt.AsTime())
+	}
+
+	_, err = service.GetFrequentlyBoughtTogether(context.Background(), &pb.GetFrequentlyBoughtTogetherRequest{ProductId: "mug"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestPairs(t *testing.T) {
+	from, to := pairs([]string{"c", "a", "b"}, []string{"a"})
+
+	// b and c are new; a was bought before and is not counted again
+	want := [][2]string{{"a", "b"}, {"a", "c"}, {"b", "a"}, {"b", "c"}, {"c", "a"}, {"c", "b"}}
+	if len(from) != len(want) {
+		t.Fatalf("Expected %d pairs, got %v %v", len(want), from, to)
+	}
+	for i, w := range want {
+		if from[i] != w[0] || to[i] != w[1] {
+			t.Errorf("Pair %d: expected %v, got [%s %s]", i, w, from[i], to[i])
+		}
+	}
+
+	if from, _ := pairs([]string{"a"}, []string{"a"}); len(from) != 0 {
+		t.Errorf("Expected no pairs for a repeat purchase, got %v", from)
+	}
+}
+
+func TestMethodRoles(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", 15*time.Minute, time.Hour)
+	interceptor := auth.NewInterceptor(tokens, auth.InterceptorConfig{ExemptMethods: []string{"/"}, RequiredRoles: MethodRoles()})
+	withToken := func(role string) context.Context {
+		token, _ := tokens.GenerateAccessToken("caller-1", "", role)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected codes.Code
+	}{
+		{"no token", context.Background(), codes.Unauthenticated},
+		{"customer", withToken(auth.RoleUser), codes.PermissionDenied},
+		{"admin", withToken(auth.RoleAdmin), codes.PermissionDenied},
+		{"service", withToken(auth.RoleService), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor.Authenticate(tt.ctx, pb.RecommendationService_EraseUserData_FullMethodName)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
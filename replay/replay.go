@@ -0,0 +1,131 @@
+// Package replay configures the event replay tool, which delivers persisted
+// domain events again to recover search indexes and read models. Events are
+// replayed from a service's outbox table, republished to their topic or to a
+// topic of their own, or from Kafka, by rewinding a consumer group.
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/kafka"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/outbox"
+)
+
+// Sources events are replayed from
+const (
+	// SourceOutbox publishes the events of an outbox table again
+	SourceOutbox = "outbox"
+	// SourceKafka rewinds a consumer group to consume its topics again
+	SourceKafka = "kafka"
+)
+
+// outboxTable matches the outbox tables of the services, e.g. order_outbox;
+// the name is written into the replay query
+var outboxTable = regexp.MustCompile(`^[a-z][a-z_]*_outbox$`)
+
+// Config is a replay
+type Config struct {
+	Source  string
+	Brokers []string
+
+	// Table and Filter select the events of an outbox replay
+	Table  string
+	Filter outbox.ReplayFilter
+
+	// Group is rewound on Topics to the first message at or after Since in
+	// a Kafka replay
+	Group  string
+	Topics []string
+	Since  time.Time
+	// DryRun reports the offsets a Kafka replay would commit without
+	// committing them
+	DryRun bool
+}
+
+// ConfigFromEnv reads a replay from the environment through getenv
+func ConfigFromEnv(getenv func(string) string) (Config, error) {
+	cfg := Config{
+		Source:  getenv("REPLAY_SOURCE"),
+		Brokers: kafka.ParseBrokers(getenv("KAFKA_BROKERS")),
+	}
+	if len(cfg.Brokers) == 0 {
+		return Config{}, errors.New("KAFKA_BROKERS is required")
+	}
+
+	since, err := parseTime(getenv, "REPLAY_SINCE")
+	if err != nil {
+		return Config{}, err
+	}
+
+	switch cfg.Source {
+	case SourceOutbox:
+		until, err := parseTime(getenv, "REPLAY_UNTIL")
+		if err != nil {
+			return Config{}, err
+		}
+		if !since.IsZero() && !until.IsZero() && !until.After(since) {
+			return Config{}, errors.New("REPLAY_UNTIL must be after REPLAY_SINCE")
+		}
+		cfg.Table = getenv("REPLAY_TABLE")
+		if !outboxTable.MatchString(cfg.Table) {
+			return Config{}, fmt.Errorf("REPLAY_TABLE must name an outbox table, e.g. order_outbox, got %q", cfg.Table)
+		}
+		cfg.Filter = outbox.ReplayFilter{
+			Since: since,
+			Until: until,
+			Types: splitList(getenv("REPLAY_TYPES")),
+			Topic: getenv("REPLAY_TOPIC"),
+		}
+
+	case SourceKafka:
+		cfg.Group = getenv("REPLAY_GROUP")
+		cfg.Topics = splitList(getenv("REPLAY_TOPICS"))
+		cfg.Since = since
+		switch {
+		case cfg.Group == "":
+			return Config{}, errors.New("REPLAY_GROUP is required")
+		case len(cfg.Topics) == 0:
+			return Config{}, errors.New("REPLAY_TOPICS is required")
+		case cfg.Since.IsZero():
+			return Config{}, errors.New("REPLAY_SINCE is required")
+		}
+		if v := getenv("REPLAY_DRY_RUN"); v != "" {
+			if cfg.DryRun, err = strconv.ParseBool(v); err != nil {
+				return Config{}, fmt.Errorf("REPLAY_DRY_RUN must be true or false, got %q", v)
+			}
+		}
+
+	default:
+		return Config{}, fmt.Errorf("REPLAY_SOURCE must be %s or %s, got %q", SourceOutbox, SourceKafka, cfg.Source)
+	}
+	return cfg, nil
+}
+
+// parseTime reads an optional RFC 3339 time
+func parseTime(getenv func(string) string, key string) (time.Time, error) {
+	v := getenv(key)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC 3339 time, got %q", key, v)
+	}
+	return t, nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
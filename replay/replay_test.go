@@ -0,0 +1,72 @@
+package replay
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestConfigFromEnv_Outbox(t *testing.T) {
+	cfg, err := ConfigFromEnv(env(map[string]string{
+		"REPLAY_SOURCE": SourceOutbox,
+		"KAFKA_BROKERS": "kafka:9092",
+		"REPLAY_TABLE":  "catalog_outbox",
+		"REPLAY_SINCE":  "2026-05-01T00:00:00Z",
+		"REPLAY_TYPES":  "product.created, product.updated",
+		"REPLAY_TOPIC":  "catalog-events.search-rebuild",
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Table != "catalog_outbox" || !cfg.Filter.Since.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)) || !cfg.Filter.Until.IsZero() {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.Filter.Types, []string{"product.created", "product.updated"}) || cfg.Filter.Topic != "catalog-events.search-rebuild" {
+		t.Errorf("unexpected filter %+v", cfg.Filter)
+	}
+}
+
+func TestConfigFromEnv_Kafka(t *testing.T) {
+	cfg, err := ConfigFromEnv(env(map[string]string{
+		"REPLAY_SOURCE":  SourceKafka,
+		"KAFKA_BROKERS":  "kafka:9092",
+		"REPLAY_GROUP":   "image-service",
+		"REPLAY_TOPICS":  "catalog-events",
+		"REPLAY_SINCE":   "2026-05-01T00:00:00Z",
+		"REPLAY_DRY_RUN": "true",
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Group != "image-service" || !reflect.DeepEqual(cfg.Topics, []string{"catalog-events"}) || !cfg.DryRun {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"no source", map[string]string{"KAFKA_BROKERS": "kafka:9092"}},
+		{"no brokers", map[string]string{"REPLAY_SOURCE": SourceOutbox, "REPLAY_TABLE": "order_outbox"}},
+		{"not an outbox table", map[string]string{"REPLAY_SOURCE": SourceOutbox, "KAFKA_BROKERS": "kafka:9092", "REPLAY_TABLE": "orders; DROP TABLE orders"}},
+		{"bad time", map[string]string{"REPLAY_SOURCE": SourceOutbox, "KAFKA_BROKERS": "kafka:9092", "REPLAY_TABLE": "order_outbox", "REPLAY_SINCE": "yesterday"}},
+		{"empty range", map[string]string{"REPLAY_SOURCE": SourceOutbox, "KAFKA_BROKERS": "kafka:9092", "REPLAY_TABLE": "order_outbox",
+			"REPLAY_SINCE": "2026-05-02T00:00:00Z", "REPLAY_UNTIL": "2026-05-01T00:00:00Z"}},
+		{"no group", map[string]string{"REPLAY_SOURCE": SourceKafka, "KAFKA_BROKERS": "kafka:9092", "REPLAY_TOPICS": "order-events", "REPLAY_SINCE": "2026-05-01T00:00:00Z"}},
+		{"no since", map[string]string{"REPLAY_SOURCE": SourceKafka, "KAFKA_BROKERS": "kafka:9092", "REPLAY_GROUP": "g", "REPLAY_TOPICS": "order-events"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConfigFromEnv(env(tt.env)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
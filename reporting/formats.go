@@ -0,0 +1,173 @@
+package reporting
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Report formats
+const (
+	FormatCSV  = "CSV"
+	FormatXLSX = "XLSX"
+)
+
+// contentTypes are the MIME types reports are stored with, by format
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// extensions are the file extensions of reports, by format
+var extensions = map[string]string{
+	FormatCSV:  ".csv",
+	FormatXLSX: ".xlsx",
+}
+
+// Column is a report column. Numeric columns hold decimal numbers and are
+// written as numbers to spreadsheets.
+type Column struct {
+	Name    string
+	Numeric bool
+}
+
+// Table is the content of a report: a header of columns and rows of cells
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+}
+
+// Encode writes the table in a format
+func (t *Table) Encode(format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatCSV:
+		err = t.writeCSV(&buf)
+	case FormatXLSX:
+		err = t.writeXLSX(&buf)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCSV writes the table as RFC 4180 CSV. Text cells a spreadsheet would
+// take for a formula are prefixed with a quote, so that opening an export
+// cannot run a formula a customer put in their name.
+func (t *Table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		header[i] = col.Name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			record[i] = cell
+			if !t.Columns[i].Numeric && cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+				record[i] = "'" + cell
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// xlsxParts are the fixed parts of a one-sheet workbook
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// writeXLSX writes the table as an Office Open XML workbook of one sheet.
+// Text is written as inline strings, so no shared string table is needed.
+func (t *Table) writeXLSX(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	sheet := &bytes.Buffer{}
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	header := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		header[i] = col.Name
+	}
+	writeXLSXRow(sheet, 1, header, nil)
+	for i, row := range t.Rows {
+		writeXLSXRow(sheet, i+2, row, t.Columns)
+		// Flush as the sheet grows, so large reports are not held twice
+		if sheet.Len() > 1<<20 {
+			if _, err := sheet.WriteTo(f); err != nil {
+				return err
+			}
+		}
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+	if _, err := sheet.WriteTo(f); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeXLSXRow writes a row; cells of numeric columns that parse as numbers
+// are written as numbers, everything else as text
+func writeXLSXRow(buf *bytes.Buffer, n int, cells []string, columns []Column) {
+	fmt.Fprintf(buf, `<row r="%d">`, n)
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(n)
+		if columns != nil && columns[i].Numeric {
+			if _, err := strconv.ParseFloat(cell, 64); err == nil {
+				fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, cell)
+				continue
+			}
+		}
+		fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		xml.EscapeText(buf, []byte(cell))
+		buf.WriteString(`</t></is></c>`)
+	}
+	buf.WriteString(`</row>`)
+}
+
+// columnName returns the spreadsheet name of the column at index i: A, B,
+// ..., Z, AA, AB and so on
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
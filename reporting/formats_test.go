@@ -0,0 +1,96 @@
+package reporting
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+)
+
+var testTable = &Table{
+	Columns: []Column{{Name: "name"}, {Name: "amount", Numeric: true}},
+	Rows: [][]string{
+		{"Ada", "12.50"},
+		{"=HYPERLINK(\"http://example.com\")", "-3.00"},
+		{"Bits & <bytes>", ""},
+	},
+}
+
+func TestEncode_CSV(t *testing.T) {
+	body, err := testTable.Encode(FormatCSV)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(records) != 4 || records[0][0] != "name" || records[1][1] != "12.50" {
+		t.Fatalf("Unexpected records: %v", records)
+	}
+	// Formula-like text is neutralised; negative numbers are left alone
+	if records[2][0] != "'=HYPERLINK(\"http://example.com\")" {
+		t.Errorf("Expected the formula quoted, got %q", records[2][0])
+	}
+	if records[2][1] != "-3.00" {
+		t.Errorf("Expected the numeric cell unchanged, got %q", records[2][1])
+	}
+}
+
+func TestEncode_XLSX(t *testing.T) {
+	body, err := testTable.Encode(FormatXLSX)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("Expected a zip archive, got %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Expected part %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="B2"><v>12.50</v></c>`,
+		`<c r="B3"><v>-3.00</v></c>`,
+		`Bits &amp; &lt;bytes&gt;`,
+		`<c r="B4" t="inlineStr">`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("Expected the sheet to contain %s", want)
+		}
+	}
+}
+
+func TestEncode_UnknownFormat(t *testing.T) {
+	if _, err := testTable.Encode("PDF"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
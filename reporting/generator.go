@@ -0,0 +1,180 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// DefaultPollInterval is how often the generator looks for pending
+	// reports it was not woken for, such as those requested on other replicas
+	DefaultPollInterval = 30 * time.Second
+	// generateTimeout bounds the generation of one report
+	generateTimeout = 10 * time.Minute
+	// staleAfter is how long a report may stay RUNNING before it is taken to
+	// have been left by a generator that died
+	staleAfter = 2 * generateTimeout
+	// downloadURLExpiry is how long a download link stays valid
+	downloadURLExpiry = 24 * time.Hour
+)
+
+// Store is the private object storage holding generated reports
+type Store interface {
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	PresignGet(key string, expires time.Duration) (string, error)
+}
+
+// Generator builds pending reports, stores them and notifies their requesters
+type Generator struct {
+	repo     Repository
+	source   Source
+	store    Store
+	notifier Notifier
+	log      *logger.Logger
+	wake     chan struct{}
+	now      func() time.Time
+}
+
+// NewGenerator creates a report generator
+func NewGenerator(repo Repository, source Source, store Store, notifier Notifier, log *logger.Logger) *Generator {
+	return &Generator{
+		repo:     repo,
+		source:   source,
+		store:    store,
+		notifier: notifier,
+		log:      log,
+		wake:     make(chan struct{}, 1),
+		now:      time.Now,
+	}
+}
+
+// Wake makes Run look for pending reports now rather than at its next poll
+func (g *Generator) Wake() {
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run generates pending reports every interval, and when woken, until ctx is
+// cancelled
+func (g *Generator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := g.ProcessNext(ctx)
+			if err != nil && ctx.Err() == nil {
+				g.log.Error(ctx, "Failed to claim report", map[string]interface{}{"error": err.Error()})
+			}
+			if !processed || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-g.wake:
+		}
+	}
+}
+
+// ProcessNext claims the oldest pending report and generates it. It returns
+// false when no report was pending.
+func (g *Generator) ProcessNext(ctx context.Context) (bool, error) {
+	now := g.now()
+	report, err := g.repo.ClaimReport(ctx, now, now.Add(-staleAfter))
+	if err != nil || report == nil {
+		return false, err
+	}
+	g.generate(ctx, report)
+	return true, nil
+}
+
+// generate builds, stores and records a claimed report, then notifies its
+// requester. The outcome is recorded even when ctx is cancelled, so a report
+// interrupted by shutdown is FAILED rather than left RUNNING.
+func (g *Generator) generate(ctx context.Context, report *Report) {
+	fields := map[string]interface{}{"report_id": report.ID, "type": report.Type, "format": report.Format}
+	start := g.now()
+
+	genCtx, cancel := context.WithTimeout(ctx, generateTimeout)
+	err := g.build(genCtx, report, start)
+	cancel()
+
+	completedAt := g.now()
+	report.CompletedAt = &completedAt
+	report.Status = StatusReady
+	if err != nil {
+		// The requester is told why the report failed, so errors of the
+		// services read are only logged
+		report.Status = StatusFailed
+		switch {
+		case errors.Is(err, ErrTooManyRows):
+			report.Error = err.Error()
+		case errors.Is(err, context.DeadlineExceeded):
+			report.Error = "report generation timed out"
+		default:
+			report.Error = "report could not be generated"
+		}
+		fields["error"] = err.Error()
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	if err := g.repo.CompleteReport(ctx, report); err != nil {
+		// The report stays RUNNING until it is claimed again as stale
+		return
+	}
+
+	fields["duration_ms"] = completedAt.Sub(start).Milliseconds()
+	if report.Status == StatusFailed {
+		g.log.Warn(ctx, "Report generation failed", fields)
+	} else {
+		fields["rows"] = report.Rows
+		fields["size_bytes"] = report.SizeBytes
+		g.log.Info(ctx, "Report generated", fields)
+	}
+
+	var downloadURL string
+	if report.Status == StatusReady {
+		if downloadURL, err = g.store.PresignGet(report.ObjectKey, downloadURLExpiry); err != nil {
+			g.log.Error(ctx, "Failed to sign report download", map[string]interface{}{"report_id": report.ID, "error": err.Error()})
+			return
+		}
+	}
+	if err := g.notifier.ReportFinished(ctx, report, downloadURL); err != nil {
+		g.log.Error(ctx, "Failed to notify report requester", map[string]interface{}{"report_id": report.ID, "error": err.Error()})
+	}
+}
+
+// build reads and encodes the report's content and stores it
+func (g *Generator) build(ctx context.Context, report *Report, now time.Time) error {
+	table, err := g.source.Table(ctx, report, now)
+	if err != nil {
+		return err
+	}
+	body, err := table.Encode(report.Format)
+	if err != nil {
+		return err
+	}
+
+	key := objectKey(report)
+	if err := g.store.Put(ctx, key, contentTypes[report.Format], body); err != nil {
+		return err
+	}
+	report.ObjectKey = key
+	report.Rows = int32(len(table.Rows))
+	report.SizeBytes = int64(len(body))
+	return nil
+}
+
+// objectKey is where a report is stored
+func objectKey(report *Report) string {
+	return "reports/" + report.ID + extensions[report.Format]
+}
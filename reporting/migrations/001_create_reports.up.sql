@@ -0,0 +1,24 @@
+-- Requested reports. A generator claims the oldest PENDING report, writes it
+-- to object storage and records where; a report left RUNNING by a generator
+-- that died is claimed again.
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('SALES', 'INVENTORY', 'CUSTOMERS')),
+    format VARCHAR(10) NOT NULL CHECK (format IN ('CSV', 'XLSX')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'RUNNING', 'READY', 'FAILED')),
+    requested_by VARCHAR(255) NOT NULL,
+    date_from DATE,
+    date_to DATE,
+    rows INT NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    object_key VARCHAR(255) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    CHECK (date_from IS NULL OR date_to IS NULL OR date_from <= date_to)
+);
+
+CREATE INDEX idx_reports_pending ON reports(created_at, id) WHERE status IN ('PENDING', 'RUNNING');
+CREATE INDEX idx_reports_requested_by ON reports(requested_by, created_at DESC);
+CREATE INDEX idx_reports_created_at ON reports(created_at DESC);
@@ -0,0 +1,84 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	notificationpb "github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Notification service events for finished reports
+const (
+	eventReportReady  = "report.ready"
+	eventReportFailed = "report.failed"
+)
+
+// Notifier tells requesters about their reports
+type Notifier interface {
+	// ReportFinished is called once when a report is READY or FAILED. A READY
+	// report comes with a link to download it.
+	ReportFinished(ctx context.Context, report *Report, downloadURL string) error
+}
+
+type logNotifier struct {
+	log *logger.Logger
+}
+
+// NewLogNotifier creates a Notifier that only logs the notifications it would
+// send, for deployments without a notification service
+func NewLogNotifier(log *logger.Logger) Notifier {
+	return &logNotifier{log: log}
+}
+
+func (n *logNotifier) ReportFinished(ctx context.Context, report *Report, downloadURL string) error {
+	n.log.Info(ctx, "Report notification", map[string]interface{}{
+		"report_id":    report.ID,
+		"requested_by": report.RequestedBy,
+		"status":       report.Status,
+		"error":        report.Error,
+	})
+	return nil
+}
+
+type grpcNotifier struct {
+	notifications notificationpb.NotificationServiceClient
+}
+
+// NewGRPCNotifier creates a Notifier that publishes report.ready and
+// report.failed events to the notification service, addressed to the
+// requester
+func NewGRPCNotifier(notifications notificationpb.NotificationServiceClient) Notifier {
+	return &grpcNotifier{notifications: notifications}
+}
+
+func (n *grpcNotifier) ReportFinished(ctx context.Context, report *Report, downloadURL string) error {
+	eventType := eventReportReady
+	data := map[string]string{
+		"report_id":   report.ID,
+		"report_type": report.Type,
+		"format":      report.Format,
+	}
+	if report.Status == StatusReady {
+		data["rows"] = strconv.Itoa(int(report.Rows))
+		data["download_url"] = downloadURL
+	} else {
+		eventType = eventReportFailed
+		data["error"] = report.Error
+	}
+
+	// A report finishes once, so its ID identifies the event
+	_, err := n.notifications.PublishEvent(ctx, &notificationpb.PublishEventRequest{
+		EventId:    eventType + ":" + report.ID,
+		Type:       eventType,
+		UserId:     report.RequestedBy,
+		Data:       data,
+		OccurredAt: timestamppb.New(*report.CompletedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", eventType, err)
+	}
+	return nil
+}
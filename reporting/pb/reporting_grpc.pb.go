@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: reporting/reporting.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReportingService_RequestReport_FullMethodName = "/reporting.ReportingService/RequestReport"
+	ReportingService_GetReport_FullMethodName     = "/reporting.ReportingService/GetReport"
+	ReportingService_ListReports_FullMethodName   = "/reporting.ReportingService/ListReports"
+)
+
+// ReportingServiceClient is the client API for ReportingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReportingService generates sales, inventory and customer exports
+type ReportingServiceClient interface {
+	RequestReport(ctx context.Context, in *RequestReportRequest, opts ...grpc.CallOption) (*RequestReportResponse, error)
+	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
+	ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error)
+}
+
+type reportingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReportingServiceClient(cc grpc.ClientConnInterface) ReportingServiceClient {
+	return &reportingServiceClient{cc}
+}
+
+func (c *reportingServiceClient) RequestReport(ctx context.Context, in *RequestReportRequest, opts ...grpc.CallOption) (*RequestReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestReportResponse)
+	err := c.cc.Invoke(ctx, ReportingService_RequestReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportingServiceClient) GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReportResponse)
+	err := c.cc.Invoke(ctx, ReportingService_GetReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportingServiceClient) ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReportsResponse)
+	err := c.cc.Invoke(ctx, ReportingService_ListReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportingServiceServer is the server API for ReportingService service.
+// All implementations must embed UnimplementedReportingServiceServer
+// for forward compatibility.
+//
+// ReportingService generates sales, inventory and customer exports
+type ReportingServiceServer interface {
+	RequestReport(context.Context, *RequestReportRequest) (*RequestReportResponse, error)
+	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
+	ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error)
+	mustEmbedUnimplementedReportingServiceServer()
+}
+
+// UnimplementedReportingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReportingServiceServer struct{}
+
+func (UnimplementedReportingServiceServer) RequestReport(context.Context, *RequestReportRequest) (*RequestReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestReport not implemented")
+}
+func (UnimplementedReportingServiceServer) GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedReportingServiceServer) ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReports not implemented")
+}
+func (UnimplementedReportingServiceServer) mustEmbedUnimplementedReportingServiceServer() {}
+func (UnimplementedReportingServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeReportingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReportingServiceServer will
+// result in compilation errors.
+type UnsafeReportingServiceServer interface {
+	mustEmbedUnimplementedReportingServiceServer()
+}
+
+func RegisterReportingServiceServer(s grpc.ServiceRegistrar, srv ReportingServiceServer) {
+	// If the following call panics, it indicates UnimplementedReportingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReportingService_ServiceDesc, srv)
+}
+
+func _ReportingService_RequestReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportingServiceServer).RequestReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportingService_RequestReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportingServiceServer).RequestReport(ctx, req.(*RequestReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportingService_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportingServiceServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportingService_GetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportingServiceServer).GetReport(ctx, req.(*GetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportingService_ListReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportingServiceServer).ListReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportingService_ListReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportingServiceServer).ListReports(ctx, req.(*ListReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReportingService_ServiceDesc is the grpc.ServiceDesc for ReportingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReportingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reporting.ReportingService",
+	HandlerType: (*ReportingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestReport",
+			Handler:    _ReportingService_RequestReport_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _ReportingService_GetReport_Handler,
+		},
+		{
+			MethodName: "ListReports",
+			Handler:    _ReportingService_ListReports_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reporting/reporting.proto",
+}
@@ -0,0 +1,203 @@
+package reporting
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// Report statuses
+const (
+	StatusPending = "PENDING"
+	StatusRunning = "RUNNING"
+	StatusReady   = "READY"
+	StatusFailed  = "FAILED"
+)
+
+// ErrReportNotFound is returned when a report does not exist
+var ErrReportNotFound = errors.New("report not found")
+
+// Report is a requested export and its outcome
+type Report struct {
+	ID     string
+	Type   string
+	Format string
+	Status string
+	// RequestedBy is the account notified when the report is ready
+	RequestedBy string
+	// From and To bound the days a SALES or CUSTOMERS report covers; either
+	// is nil when unbounded
+	From *time.Time
+	To   *time.Time
+	// Rows counts the data rows of a READY report
+	Rows      int32
+	SizeBytes int64
+	// ObjectKey is where a READY report is stored
+	ObjectKey string
+	// Error is why a FAILED report failed
+	Error       string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// Repository handles report persistence
+type Repository interface {
+	// CreateReport stores a PENDING report
+	CreateReport(ctx context.Context, report *Report) error
+	// ClaimReport marks the oldest PENDING report RUNNING and returns it, or
+	// nil when none is pending. A report RUNNING since before staleBefore is
+	// taken to have been left by a generator that died and is claimed
+	// again.
+	ClaimReport(ctx context.Context, now, staleBefore time.Time) (*Report, error)
+	// CompleteReport records the outcome of a report
+	CompleteReport(ctx context.Context, report *Report) error
+	// GetReport returns a report, or ErrReportNotFound
+	GetReport(ctx context.Context, id string) (*Report, error)
+	// ListReports lists reports, newest first, of one requester or of
+	// everyone when requestedBy is empty
+	ListReports(ctx context.Context, requestedBy string, page, pageSize int32) ([]*Report, int32, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL report repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const reportColumns = `id, type, format, status, requested_by, date_from, date_to, rows, size_bytes, object_key, error, created_at, started_at, completed_at`
+
+// CreateReport inserts a report
+func (r *postgresRepository) CreateReport(ctx context.Context, report *Report) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO reports (id, type, format, status, requested_by, date_from, date_to, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, report.ID, report.Type, report.Format, report.Status, report.RequestedBy, report.From, report.To, report.CreatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create report", map[string]interface{}{"error": err.Error(), "report_id": report.ID})
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	return nil
+}
+
+// ClaimReport claims with FOR UPDATE SKIP LOCKED, so replicas never generate
+// the same report at once
+func (r *postgresRepository) ClaimReport(ctx context.Context, now, staleBefore time.Time) (*Report, error) {
+	report, err := scanReport(r.db.QueryRowContext(ctx, `
+		UPDATE reports SET status = 'RUNNING', started_at = $1
+		WHERE id = (
+			SELECT id FROM reports
+			WHERE status = 'PENDING' OR (status = 'RUNNING' AND started_at < $2)
+			ORDER BY created_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+reportColumns, now, staleBefore))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to claim report", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to claim report: %w", err)
+	}
+	return report, nil
+}
+
+// CompleteReport updates the report's outcome
+func (r *postgresRepository) CompleteReport(ctx context.Context, report *Report) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE reports SET status = $2, rows = $3, size_bytes = $4, object_key = $5, error = $6, completed_at = $7
+		WHERE id = $1
+	`, report.ID, report.Status, report.Rows, report.SizeBytes, report.ObjectKey, report.Error, report.CompletedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to complete report", map[string]interface{}{"error": err.Error(), "report_id": report.ID})
+		return fmt.Errorf("failed to complete report: %w", err)
+	}
+	return nil
+}
+
+// GetReport retrieves a report by ID
+func (r *postgresRepository) GetReport(ctx context.Context, id string) (*Report, error) {
+	report, err := scanReport(r.db.QueryRowContext(ctx, "SELECT "+reportColumns+" FROM reports WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrReportNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get report", map[string]interface{}{"error": err.Error(), "report_id": id})
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	return report, nil
+}
+
+// ListReports lists reports by creation time, newest first
+func (r *postgresRepository) ListReports(ctx context.Context, requestedBy string, page, pageSize int32) ([]*Report, int32, error) {
+	where := " FROM reports WHERE ($1 = '' OR requested_by = $1)"
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+where, requestedBy).Scan(&total); err != nil {
+		r.log.Error(ctx, "Failed to count reports", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+reportColumns+where+" ORDER BY created_at DESC, id LIMIT $2 OFFSET $3",
+		requestedBy, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list reports", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+	return reports, total, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReport scans a report selected with reportColumns
+func scanReport(row rowScanner) (*Report, error) {
+	report := &Report{}
+	var from, to, startedAt, completedAt sql.NullTime
+	err := row.Scan(&report.ID, &report.Type, &report.Format, &report.Status, &report.RequestedBy, &from, &to,
+		&report.Rows, &report.SizeBytes, &report.ObjectKey, &report.Error, &report.CreatedAt, &startedAt, &completedAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range []struct {
+		src sql.NullTime
+		dst **time.Time
+	}{{from, &report.From}, {to, &report.To}, {startedAt, &report.StartedAt}, {completedAt, &report.CompletedAt}} {
+		if t.src.Valid {
+			v := t.src.Time
+			*t.dst = &v
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,142 @@
+package reporting
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("reporting-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var reportColumnNames = []string{"id", "type", "format", "status", "requested_by", "date_from", "date_to", "rows", "size_bytes", "object_key", "error", "created_at", "started_at", "completed_at"}
+
+func TestCreateReport(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+	report := &Report{ID: "report-1", Type: TypeSales, Format: FormatCSV, Status: StatusPending, RequestedBy: "admin-1", From: &from, CreatedAt: now}
+	mock.ExpectExec(`INSERT INTO reports \(id, type, format, status, requested_by, date_from, date_to, created_at\)`).
+		WithArgs("report-1", TypeSales, FormatCSV, StatusPending, "admin-1", &from, nil, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.CreateReport(context.Background(), report); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClaimReport(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	staleBefore := now.Add(-time.Hour)
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`UPDATE reports SET status = 'RUNNING', started_at = \$1\s+WHERE id = \(\s+SELECT id FROM reports\s+WHERE status = 'PENDING' OR \(status = 'RUNNING' AND started_at < \$2\).*FOR UPDATE SKIP LOCKED\s+\)\s+RETURNING`).
+		WithArgs(now, staleBefore).
+		WillReturnRows(sqlmock.NewRows(reportColumnNames).
+			AddRow("report-1", TypeSales, FormatCSV, StatusRunning, "admin-1", from, nil, 0, 0, "", "", now, now, nil))
+
+	report, err := repo.ClaimReport(context.Background(), now, staleBefore)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report == nil || report.ID != "report-1" || report.From == nil || !report.From.Equal(from) || report.To != nil || report.StartedAt == nil {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClaimReport_NonePending(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`UPDATE reports SET status = 'RUNNING'`).WillReturnError(sql.ErrNoRows)
+
+	report, err := repo.ClaimReport(context.Background(), time.Now(), time.Now())
+	if err != nil || report != nil {
+		t.Errorf("Expected no report and no error, got %+v, %v", report, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCompleteReport(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	completedAt := time.Now()
+	report := &Report{ID: "report-1", Status: StatusReady, Rows: 12, SizeBytes: 640, ObjectKey: "reports/report-1.csv", CompletedAt: &completedAt}
+	mock.ExpectExec(`UPDATE reports SET status = \$2, rows = \$3, size_bytes = \$4, object_key = \$5, error = \$6, completed_at = \$7\s+WHERE id = \$1`).
+		WithArgs("report-1", StatusReady, int32(12), int64(640), "reports/report-1.csv", "", &completedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.CompleteReport(context.Background(), report); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetReport_RepositoryNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM reports WHERE id = \$1`).
+		WithArgs("report-1").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.GetReport(context.Background(), "report-1"); err != ErrReportNotFound {
+		t.Errorf("Expected ErrReportNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListReports_Repository(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reports WHERE \(\$1 = '' OR requested_by = \$1\)`).
+		WithArgs("admin-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT .* FROM reports WHERE .* ORDER BY created_at DESC, id LIMIT \$2 OFFSET \$3`).
+		WithArgs("admin-1", int32(2), int32(2)).
+		WillReturnRows(sqlmock.NewRows(reportColumnNames).
+			AddRow("report-1", TypeInventory, FormatXLSX, StatusReady, "admin-1", nil, nil, 40, 4096, "reports/report-1.xlsx", "", now, now, now))
+
+	reports, total, err := repo.ListReports(context.Background(), "admin-1", 2, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 3 || len(reports) != 1 || reports[0].CompletedAt == nil || reports[0].Rows != 40 {
+		t.Errorf("Expected 1 ready report of 3, got %d of %d", len(reports), total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/reporting/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminMethods are the admin-scoped RPCs of the reporting service; they are
+// restricted to the admin network allowlist. Reports hold customer data and
+// the service only serves the backoffice, so that is every RPC.
+var AdminMethods = []string{
+	"/" + pb.ReportingService_ServiceDesc.ServiceName + "/",
+}
+
+// Service implements the ReportingService gRPC interface
+type Service struct {
+	pb.UnimplementedReportingServiceServer
+	repo      Repository
+	store     Store
+	generator *Generator
+	log       *logger.Logger
+	now       func() time.Time
+}
+
+// NewService creates a new reporting service. Requested reports are handed to
+// generator, and download links are signed with store.
+func NewService(repo Repository, store Store, generator *Generator, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		store:     store,
+		generator: generator,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// RequestReport validates and queues a report
+func (s *Service) RequestReport(ctx context.Context, req *pb.RequestReportRequest) (*pb.RequestReportResponse, error) {
+	report, msg := s.newReport(req)
+	if msg != "" {
+		s.log.Warn(ctx, "Request report failed: "+msg, map[string]interface{}{"type": req.Type})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	if err := s.repo.CreateReport(ctx, report); err != nil {
+		return nil, status.Error(codes.Internal, "failed to request report")
+	}
+	s.generator.Wake()
+
+	s.log.Info(ctx, "Report requested", map[string]interface{}{
+		"report_id":    report.ID,
+		"type":         report.Type,
+		"format":       report.Format,
+		"requested_by": report.RequestedBy,
+	})
+	return &pb.RequestReportResponse{Report: toProtoReport(report, "")}, nil
+}
+
+// newReport builds a PENDING report from a request, or returns why the
+// request is invalid
+func (s *Service) newReport(req *pb.RequestReportRequest) (*Report, string) {
+	switch req.Type {
+	case TypeSales, TypeInventory, TypeCustomers:
+	case "":
+		return nil, "type is required"
+	default:
+		return nil, "type must be SALES, INVENTORY or CUSTOMERS"
+	}
+	format := req.Format
+	if format == "" {
+		format = FormatCSV
+	}
+	if _, ok := extensions[format]; !ok {
+		return nil, "format must be CSV or XLSX"
+	}
+	if req.RequestedBy == "" {
+		return nil, "requested_by is required"
+	}
+
+	report := &Report{
+		ID:          uuid.New().String(),
+		Type:        req.Type,
+		Format:      format,
+		Status:      StatusPending,
+		RequestedBy: req.RequestedBy,
+		CreatedAt:   s.now(),
+	}
+	if req.Type == TypeInventory {
+		if req.From != "" || req.To != "" {
+			return nil, "inventory reports take no dates"
+		}
+		return report, ""
+	}
+
+	for _, d := range []struct {
+		value string
+		dst   **time.Time
+	}{{req.From, &report.From}, {req.To, &report.To}} {
+		if d.value == "" {
+			continue
+		}
+		t, err := time.Parse(DateLayout, d.value)
+		if err != nil {
+			return nil, "from and to must be dates in YYYY-MM-DD format"
+		}
+		*d.dst = &t
+	}
+	if report.From != nil && report.To != nil && report.To.Before(*report.From) {
+		return nil, "to must not be before from"
+	}
+	return report, ""
+}
+
+// GetReport returns a report, with a download link once it is READY
+func (s *Service) GetReport(ctx context.Context, req *pb.GetReportRequest) (*pb.GetReportResponse, error) {
+	if req.Id == "" {
+		s.log.Warn(ctx, "Get report failed: id is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	report, err := s.repo.GetReport(ctx, req.Id)
+	if errors.Is(err, ErrReportNotFound) {
+		return nil, status.Error(codes.NotFound, "report not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get report")
+	}
+
+	var downloadURL string
+	if report.Status == StatusReady {
+		if downloadURL, err = s.store.PresignGet(report.ObjectKey, downloadURLExpiry); err != nil {
+			s.log.Error(ctx, "Failed to sign report download", map[string]interface{}{"report_id": report.ID, "error": err.Error()})
+			return nil, status.Error(codes.Internal, "failed to get report")
+		}
+	}
+	return &pb.GetReportResponse{Report: toProtoReport(report, downloadURL)}, nil
+}
+
+// ListReports lists reports, newest first
+func (s *Service) ListReports(ctx context.Context, req *pb.ListReportsRequest) (*pb.ListReportsResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	reports, total, err := s.repo.ListReports(ctx, req.RequestedBy, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list reports")
+	}
+
+	protoReports := make([]*pb.Report, len(reports))
+	for i, report := range reports {
+		protoReports[i] = toProtoReport(report, "")
+	}
+
+	return &pb.ListReportsResponse{
+		Reports:  protoReports,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func toProtoReport(report *Report, downloadURL string) *pb.Report {
+	r := &pb.Report{
+		Id:          report.ID,
+		Type:        report.Type,
+		Format:      report.Format,
+		Status:      report.Status,
+		RequestedBy: report.RequestedBy,
+		Rows:        report.Rows,
+		SizeBytes:   report.SizeBytes,
+		Error:       report.Error,
+		DownloadUrl: downloadURL,
+		CreatedAt:   timestamppb.New(report.CreatedAt),
+	}
+	if report.From != nil {
+		r.From = report.From.Format(DateLayout)
+	}
+	if report.To != nil {
+		r.To = report.To.Format(DateLayout)
+	}
+	if report.CompletedAt != nil {
+		r.CompletedAt = timestamppb.New(*report.CompletedAt)
+	}
+	return r
+}
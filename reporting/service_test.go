@@ -0,0 +1,363 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/reporting/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps reports in request order, claiming pending reports
+// and those left running since staleBefore
+type memoryRepository struct {
+	mu      sync.Mutex
+	reports []*Report
+}
+
+func (m *memoryRepository) CreateReport(ctx context.Context, report *Report) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *report
+	m.reports = append(m.reports, &cp)
+	return nil
+}
+
+func (m *memoryRepository) ClaimReport(ctx context.Context, now, staleBefore time.Time) (*Report, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.reports {
+		if r.Status == StatusPending || (r.Status == StatusRunning && r.StartedAt.Before(staleBefore)) {
+			r.Status = StatusRunning
+			r.StartedAt = &now
+			cp := *r
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryRepository) CompleteReport(ctx context.Context, report *Report) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, r := range m.reports {
+		if r.ID == report.ID {
+			cp := *report
+			m.reports[i] = &cp
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) GetReport(ctx context.Context, id string) (*Report, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.reports {
+		if r.ID == id {
+			cp := *r
+			return &cp, nil
+		}
+	}
+	return nil, ErrReportNotFound
+}
+
+func (m *memoryRepository) ListReports(ctx context.Context, requestedBy string, page, pageSize int32) ([]*Report, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var reports []*Report
+	for _, r := range m.reports {
+		if requestedBy == "" || r.RequestedBy == requestedBy {
+			cp := *r
+			reports = append(reports, &cp)
+		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool { return reports[i].CreatedAt.After(reports[j].CreatedAt) })
+	total := int32(len(reports))
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return reports[start:end], total, nil
+}
+
+func (m *memoryRepository) Close() error { return nil }
+
+// fakeSource returns a fixed table or error
+type fakeSource struct {
+	table *Table
+	err   error
+}
+
+func (f *fakeSource) Table(ctx context.Context, report *Report, now time.Time) (*Table, error) {
+	return f.table, f.err
+}
+
+// memoryStore keeps stored reports in memory
+type memoryStore struct {
+	objects map[string][]byte
+}
+
+func (m *memoryStore) Put(ctx context.Context, key, contentType string, body []byte) error {
+	m.objects[key] = body
+	return nil
+}
+
+func (m *memoryStore) PresignGet(key string, expires time.Duration) (string, error) {
+	return "https://storage.example.com/" + key + "?signed", nil
+}
+
+// recordingNotifier records the reports it is told about
+type recordingNotifier struct {
+	reports []*Report
+	urls    []string
+}
+
+func (n *recordingNotifier) ReportFinished(ctx context.Context, report *Report, downloadURL string) error {
+	n.reports = append(n.reports, report)
+	n.urls = append(n.urls, downloadURL)
+	return nil
+}
+
+type testEnv struct {
+	repo      *memoryRepository
+	store     *memoryStore
+	source    *fakeSource
+	notifier  *recordingNotifier
+	generator *Generator
+	service   *Service
+}
+
+func newTestEnv() *testEnv {
+	log := logger.New("reporting-test")
+	env := &testEnv{
+		repo:     &memoryRepository{},
+		store:    &memoryStore{objects: map[string][]byte{}},
+		source:   &fakeSource{table: &Table{Columns: []Column{{Name: "sku"}}, Rows: [][]string{{"SKU-1"}, {"SKU-2"}}}},
+		notifier: &recordingNotifier{},
+	}
+	env.generator = NewGenerator(env.repo, env.source, env.store, env.notifier, log)
+	env.service = NewService(env.repo, env.store, env.generator, log)
+	return env
+}
+
+func TestRequestReport_Validation(t *testing.T) {
+	env := newTestEnv()
+	tests := []struct {
+		name string
+		req  *pb.RequestReportRequest
+	}{
+		{"missing type", &pb.RequestReportRequest{RequestedBy: "admin-1"}},
+		{"unknown type", &pb.RequestReportRequest{Type: "RETURNS", RequestedBy: "admin-1"}},
+		{"unknown format", &pb.RequestReportRequest{Type: TypeSales, Format: "PDF", RequestedBy: "admin-1"}},
+		{"missing requester", &pb.RequestReportRequest{Type: TypeSales}},
+		{"bad date", &pb.RequestReportRequest{Type: TypeSales, RequestedBy: "admin-1", From: "03/01/2026"}},
+		{"to before from", &pb.RequestReportRequest{Type: TypeSales, RequestedBy: "admin-1", From: "2026-03-02", To: "2026-03-01"}},
+		{"dated inventory", &pb.RequestReportRequest{Type: TypeInventory, RequestedBy: "admin-1", From: "2026-03-01"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.service.RequestReport(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRequestReport_Generated(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+
+	resp, err := env.service.RequestReport(ctx, &pb.RequestReportRequest{
+		Type:        TypeSales,
+		Format:      FormatXLSX,
+		RequestedBy: "admin-1",
+		From:        "2026-03-01",
+		To:          "2026-03-31",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Report.Status != StatusPending || resp.Report.From != "2026-03-01" || resp.Report.To != "2026-03-31" {
+		t.Fatalf("Unexpected report: %+v", resp.Report)
+	}
+
+	processed, err := env.generator.ProcessNext(ctx)
+	if err != nil || !processed {
+		t.Fatalf("Expected the report processed, got %v, %v", processed, err)
+	}
+	if processed, _ := env.generator.ProcessNext(ctx); processed {
+		t.Error("Expected no report left pending")
+	}
+
+	get, err := env.service.GetReport(ctx, &pb.GetReportRequest{Id: resp.Report.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	report := get.Report
+	if report.Status != StatusReady || report.Rows != 2 || report.SizeBytes == 0 || report.CompletedAt == nil {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	key := "reports/" + report.Id + ".xlsx"
+	if _, ok := env.store.objects[key]; !ok {
+		t.Errorf("Expected the report stored at %s", key)
+	}
+	if report.DownloadUrl != "https://storage.example.com/"+key+"?signed" {
+		t.Errorf("Unexpected download URL %q", report.DownloadUrl)
+	}
+
+	if len(env.notifier.reports) != 1 || env.notifier.reports[0].Status != StatusReady || env.notifier.urls[0] == "" {
+		t.Errorf("Expected the requester notified of the ready report, got %+v", env.notifier.reports)
+	}
+}
+
+func TestRequestReport_Failed(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantError string
+	}{
+		{"too many rows", ErrTooManyRows, ErrTooManyRows.Error()},
+		{"source unavailable", status.Error(codes.Unavailable, "connection refused"), "report could not be generated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			env.source.err = tt.err
+			ctx := context.Background()
+
+			resp, err := env.service.RequestReport(ctx, &pb.RequestReportRequest{Type: TypeCustomers, RequestedBy: "admin-1"})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if _, err := env.generator.ProcessNext(ctx); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			get, err := env.service.GetReport(ctx, &pb.GetReportRequest{Id: resp.Report.Id})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if get.Report.Status != StatusFailed || get.Report.Error != tt.wantError || get.Report.DownloadUrl != "" {
+				t.Errorf("Unexpected report: %+v", get.Report)
+			}
+			if len(env.notifier.reports) != 1 || env.notifier.reports[0].Status != StatusFailed {
+				t.Errorf("Expected the requester notified of the failure, got %+v", env.notifier.reports)
+			}
+		})
+	}
+}
+
+func TestGetReport_NotFound(t *testing.T) {
+	env := newTestEnv()
+	_, err := env.service.GetReport(context.Background(), &pb.GetReportRequest{Id: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListReports(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	for _, requester := range []string{"admin-1", "admin-2", "admin-1"} {
+		if _, err := env.service.RequestReport(ctx, &pb.RequestReportRequest{Type: TypeInventory, RequestedBy: requester}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	resp, err := env.service.ListReports(ctx, &pb.ListReportsRequest{RequestedBy: "admin-1", PageSize: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || len(resp.Reports) != 2 || resp.Page != 1 || resp.PageSize != 100 {
+		t.Errorf("Unexpected response: total %d, %d reports, page %d of size %d", resp.Total, len(resp.Reports), resp.Page, resp.PageSize)
+	}
+}
+
+// fakeOrderClient serves fixed pages of orders, newest first
+type fakeOrderClient struct {
+	orderpb.OrderServiceClient
+	orders []*orderpb.Order
+	pages  int
+}
+
+func (f *fakeOrderClient) ListOrders(ctx context.Context, req *orderpb.ListOrdersRequest, opts ...grpc.CallOption) (*orderpb.ListOrdersResponse, error) {
+	f.pages++
+	start := int((req.Page - 1) * req.PageSize)
+	end := start + int(req.PageSize)
+	if start > len(f.orders) {
+		start = len(f.orders)
+	}
+	if end > len(f.orders) {
+		end = len(f.orders)
+	}
+	return &orderpb.ListOrdersResponse{Orders: f.orders[start:end], Total: int32(len(f.orders))}, nil
+}
+
+func TestSalesSource(t *testing.T) {
+	day := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	item := []*orderpb.OrderItem{{Sku: "SKU-1", Name: "Mug", Quantity: 2, UnitPrice: 4.5, LineTotal: 9}}
+	var orders []*orderpb.Order
+	// 250 orders, one an hour, newest first; the oldest are before the period
+	for i := 0; i < 250; i++ {
+		status := "PAID"
+		if i%10 == 0 {
+			status = "CANCELLED"
+		}
+		orders = append(orders, &orderpb.Order{
+			Id:        fmt.Sprintf("order-%d", i),
+			Status:    status,
+			Items:     item,
+			CreatedAt: timestamppb.New(day.Add(-time.Duration(i) * time.Hour)),
+		})
+	}
+	client := &fakeOrderClient{orders: orders}
+	source := NewGRPCSource(client, nil, nil, nil)
+
+	from := time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC)
+	table, err := source.Table(context.Background(), &Report{Type: TypeSales, From: &from, To: &to}, day)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Lines of the orders placed from March 6 to March 9 inclusive, but not
+	// cancelled
+	var want int
+	for i := 0; i < 250; i++ {
+		createdAt := day.Add(-time.Duration(i) * time.Hour)
+		if !createdAt.Before(from) && createdAt.Before(to.AddDate(0, 0, 1)) && i%10 != 0 {
+			want++
+		}
+	}
+	if len(table.Rows) != want {
+		t.Errorf("Expected %d rows, got %d", want, len(table.Rows))
+	}
+	if row := table.Rows[0]; row[4] != "SKU-1" || row[6] != "2" || row[7] != "4.50" || row[8] != "9.00" {
+		t.Errorf("Unexpected row %v", row)
+	}
+	if client.pages != 2 {
+		t.Errorf("Expected paging to stop before the period, read %d pages", client.pages)
+	}
+}
+
+func TestCustomersSource_NotConfigured(t *testing.T) {
+	source := NewGRPCSource(nil, nil, nil, nil)
+	if _, err := source.Table(context.Background(), &Report{Type: TypeCustomers}, time.Now()); err == nil || errors.Is(err, ErrTooManyRows) {
+		t.Errorf("Expected a configuration error, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Report types
+const (
+	TypeSales     = "SALES"
+	TypeInventory = "INVENTORY"
+	TypeCustomers = "CUSTOMERS"
+)
+
+// DateLayout is the layout of report dates
+const DateLayout = "2006-01-02"
+
+// sourcePageSize is the page size orders, products and accounts are read with
+const sourcePageSize = 100
+
+// maxRows caps the data rows of one report
+const maxRows = 200000
+
+// ErrTooManyRows is returned when a report would hold more than maxRows rows
+var ErrTooManyRows = fmt.Errorf("report exceeds %d rows; narrow its dates", maxRows)
+
+// soldStatuses are the statuses of orders that count as sales
+var soldStatuses = map[string]bool{"PAID": true, "FULFILLED": true, "DELIVERED": true}
+
+// Source reads the data of reports from the services that own it
+type Source interface {
+	// Table builds the content of a report as of now
+	Table(ctx context.Context, report *Report, now time.Time) (*Table, error)
+}
+
+type grpcSource struct {
+	orders   orderpb.OrderServiceClient
+	catalog  catalogpb.CatalogServiceClient
+	accounts accountpb.AccountServiceClient
+	tokens   *auth.TokenService
+}
+
+// NewGRPCSource creates a Source reading from the order, catalog and account
+// services. The account service only lists accounts to admins, so customer
+// reports are read with an admin token issued by tokens, which must share the
+// account service's JWT secret.
+func NewGRPCSource(orders orderpb.OrderServiceClient, catalog catalogpb.CatalogServiceClient, accounts accountpb.AccountServiceClient, tokens *auth.TokenService) Source {
+	return &grpcSource{orders: orders, catalog: catalog, accounts: accounts, tokens: tokens}
+}
+
+func (s *grpcSource) Table(ctx context.Context, report *Report, now time.Time) (*Table, error) {
+	from, to := period(report, now)
+	switch report.Type {
+	case TypeSales:
+		return s.sales(ctx, from, to)
+	case TypeInventory:
+		return s.inventory(ctx)
+	case TypeCustomers:
+		return s.customers(ctx, from, to)
+	default:
+		return nil, fmt.Errorf("unknown report type %q", report.Type)
+	}
+}
+
+// period returns the [from, to) range a report covers. To is inclusive in
+// the request, so the range runs to the start of the following day; without
+// one it runs to now. Without from it starts at the zero time.
+func period(report *Report, now time.Time) (time.Time, time.Time) {
+	var from time.Time
+	to := now
+	if report.From != nil {
+		from = *report.From
+	}
+	if report.To != nil {
+		to = report.To.AddDate(0, 0, 1)
+	}
+	return from, to
+}
+
+var salesColumns = []Column{
+	{Name: "order_id"}, {Name: "order_date"}, {Name: "customer_id"}, {Name: "status"},
+	{Name: "sku"}, {Name: "product_name"}, {Name: "quantity", Numeric: true},
+	{Name: "unit_price", Numeric: true}, {Name: "line_total", Numeric: true},
+}
+
+// sales lists the lines of the paid, fulfilled and delivered orders placed in
+// [from, to). Orders are listed newest first, so paging stops at the first
+// order placed before from; an order placed while paging shifts the pages by
+// one, so orders already seen are skipped.
+func (s *grpcSource) sales(ctx context.Context, from, to time.Time) (*Table, error) {
+	table := &Table{Columns: salesColumns}
+	seen := make(map[string]bool)
+	for page := int32(1); ; page++ {
+		resp, err := s.orders.ListOrders(ctx, &orderpb.ListOrdersRequest{Page: page, PageSize: sourcePageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders: %w", err)
+		}
+
+		for _, o := range resp.Orders {
+			createdAt := o.CreatedAt.AsTime()
+			if createdAt.Before(from) {
+				return table, nil
+			}
+			if !createdAt.Before(to) || seen[o.Id] || !soldStatuses[o.Status] {
+				continue
+			}
+			seen[o.Id] = true
+			for _, item := range o.Items {
+				if len(table.Rows) == maxRows {
+					return nil, ErrTooManyRows
+				}
+				table.Rows = append(table.Rows, []string{
+					o.Id, createdAt.UTC().Format(time.RFC3339), o.UserId, o.Status,
+					item.Sku, item.Name, strconv.Itoa(int(item.Quantity)),
+					formatAmount(item.UnitPrice), formatAmount(item.LineTotal),
+				})
+			}
+		}
+
+		if len(resp.Orders) < sourcePageSize || page*sourcePageSize >= resp.Total {
+			return table, nil
+		}
+	}
+}
+
+var inventoryColumns = []Column{
+	{Name: "product_id"}, {Name: "sku"}, {Name: "name"}, {Name: "category"}, {Name: "status"},
+	{Name: "stock", Numeric: true}, {Name: "low_stock_threshold", Numeric: true},
+	{Name: "price", Numeric: true}, {Name: "stock_value", Numeric: true},
+}
+
+// inventory lists every product, drafts included, with its stock valued at
+// its list price
+func (s *grpcSource) inventory(ctx context.Context) (*Table, error) {
+	table := &Table{Columns: inventoryColumns}
+	seen := make(map[string]bool)
+	for page := int32(1); ; page++ {
+		resp, err := s.catalog.ListProducts(ctx, &catalogpb.ListProductsRequest{
+			Page:          page,
+			PageSize:      sourcePageSize,
+			IncludeDrafts: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list products: %w", err)
+		}
+
+		for _, p := range resp.Products {
+			if seen[p.Id] {
+				continue
+			}
+			if len(table.Rows) == maxRows {
+				return nil, ErrTooManyRows
+			}
+			seen[p.Id] = true
+			table.Rows = append(table.Rows, []string{
+				p.Id, p.Sku, p.Name, p.Category, p.Status,
+				strconv.Itoa(int(p.Stock)), strconv.Itoa(int(p.LowStockThreshold)),
+				formatAmount(p.Price), formatAmount(p.Price * float64(p.Stock)),
+			})
+		}
+
+		if len(resp.Products) < sourcePageSize || page*sourcePageSize >= resp.Total {
+			return table, nil
+		}
+	}
+}
+
+var customerColumns = []Column{
+	{Name: "customer_id"}, {Name: "email"}, {Name: "name"}, {Name: "phone"},
+	{Name: "verified"}, {Name: "registered_at"},
+}
+
+// customers lists the active customer accounts registered in [from, to).
+// An account registered while paging shifts the pages by one, so accounts
+// already seen are skipped.
+func (s *grpcSource) customers(ctx context.Context, from, to time.Time) (*Table, error) {
+	if s.tokens == nil {
+		return nil, errors.New("customer reports are not configured")
+	}
+	token, err := s.tokens.GenerateAccessToken("reporting-service", "", "ADMIN")
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue token: %w", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
+	req := &accountpb.ListAccountsRequest{CreatedTo: timestamppb.New(to), Role: "USER", PageSize: sourcePageSize}
+	if !from.IsZero() {
+		req.CreatedFrom = timestamppb.New(from)
+	}
+
+	table := &Table{Columns: customerColumns}
+	seen := make(map[string]bool)
+	for page := int32(1); ; page++ {
+		req.Page = page
+		resp, err := s.accounts.ListAccounts(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+		if resp.Total > maxRows {
+			return nil, ErrTooManyRows
+		}
+
+		for _, u := range resp.Users {
+			if seen[u.Id] {
+				continue
+			}
+			seen[u.Id] = true
+			table.Rows = append(table.Rows, []string{
+				u.Id, u.Email, u.Name, u.Phone, strconv.FormatBool(u.IsVerified),
+				u.CreatedAt.AsTime().UTC().Format(time.RFC3339),
+			})
+		}
+		if len(resp.Users) < sourcePageSize || page*sourcePageSize >= resp.Total {
+			return table, nil
+		}
+	}
+}
+
+// formatAmount writes an amount with two decimals
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS return_items;
+DROP INDEX IF EXISTS idx_returns_status;
+DROP INDEX IF EXISTS idx_returns_user;
+DROP INDEX IF EXISTS idx_returns_order;
+DROP TABLE IF EXISTS returns;
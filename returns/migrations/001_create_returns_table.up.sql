@@ -0,0 +1,34 @@
+-- Return requests (RMAs). status follows the approval workflow:
+-- REQUESTED -> APPROVED -> RECEIVED -> REFUNDED, and REQUESTED -> REJECTED.
+CREATE TABLE IF NOT EXISTS returns (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'REQUESTED' CHECK (status IN ('REQUESTED', 'APPROVED', 'REJECTED', 'RECEIVED', 'REFUNDED')),
+    reason TEXT NOT NULL,
+    refund_amount DECIMAL(10, 2) NOT NULL CHECK (refund_amount >= 0),
+    resolution_note TEXT NOT NULL DEFAULT '',
+    restock BOOLEAN NOT NULL DEFAULT FALSE,
+    payment_id UUID,
+    refunded_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (refunded_amount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    received_at TIMESTAMP,
+    refunded_at TIMESTAMP
+);
+
+CREATE INDEX idx_returns_order ON returns(order_id);
+CREATE INDEX idx_returns_user ON returns(user_id, created_at DESC);
+CREATE INDEX idx_returns_status ON returns(status, created_at DESC);
+
+-- The order lines of a return, with the order's prices
+CREATE TABLE IF NOT EXISTS return_items (
+    return_id UUID NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    sku VARCHAR(100) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+    restocked BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (return_id, product_id)
+);
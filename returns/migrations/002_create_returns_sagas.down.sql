@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_returns_sagas_active;
+DROP TABLE IF EXISTS returns_sagas;
@@ -0,0 +1,16 @@
+-- Progress of the sagas that restock and refund received returns, saved by
+-- pkg/saga as each step completes
+CREATE TABLE IF NOT EXISTS returns_sagas (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    steps JSONB NOT NULL DEFAULT '[]',
+    failed_step VARCHAR(100) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Recovery only scans sagas still in progress
+CREATE INDEX idx_returns_sagas_active ON returns_sagas(name, updated_at)
+    WHERE status IN ('RUNNING', 'COMPENSATING');
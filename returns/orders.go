@@ -0,0 +1,81 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrOrderNotFound is returned when an order does not exist
+var ErrOrderNotFound = errors.New("order not found")
+
+// orderStatusDelivered is the only order status whose items can be returned
+const orderStatusDelivered = "DELIVERED"
+
+// Order is the part of an order that return eligibility is checked against
+type Order struct {
+	ID     string
+	UserID string
+	Status string
+	Items  []OrderItem
+	// UpdatedAt is when the order last changed status; for a DELIVERED order,
+	// which changes no further, that is when it was delivered
+	UpdatedAt time.Time
+}
+
+// OrderItem is an order line
+type OrderItem struct {
+	ProductID string
+	SKU       string
+	Name      string
+	Quantity  int32
+	UnitPrice float64
+}
+
+// Orders reads orders from the order service
+type Orders interface {
+	// GetOrder returns an order, or ErrOrderNotFound
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+func (o *grpcOrders) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	resp, err := o.client.GetOrder(ctx, &orderpb.GetOrderRequest{OrderId: orderID})
+	if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	order := &Order{
+		ID:        resp.Order.Id,
+		UserID:    resp.Order.UserId,
+		Status:    resp.Order.Status,
+		Items:     make([]OrderItem, len(resp.Order.Items)),
+		UpdatedAt: resp.Order.UpdatedAt.AsTime(),
+	}
+	for i, item := range resp.Order.Items {
+		order.Items[i] = OrderItem{
+			ProductID: item.ProductId,
+			SKU:       item.Sku,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,74 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+)
+
+// ErrNothingToRefund is returned when an order has no captured payment with
+// money left to refund
+var ErrNothingToRefund = errors.New("order has no captured payment left to refund")
+
+// paymentStatusCaptured is the status of a payment that can be refunded
+const paymentStatusCaptured = "CAPTURED"
+
+// Refund is money returned to the customer
+type Refund struct {
+	PaymentID string
+	Amount    float64
+}
+
+// Payments refunds returned items through the payment service
+type Payments interface {
+	// Refund returns up to amount from the order's captured payment. Less is
+	// refunded when less is left on the payment.
+	Refund(ctx context.Context, orderID string, amount float64) (*Refund, error)
+}
+
+type grpcPayments struct {
+	client paymentpb.PaymentServiceClient
+}
+
+// NewGRPCPayments creates Payments backed by the payment service
+func NewGRPCPayments(client paymentpb.PaymentServiceClient) Payments {
+	return &grpcPayments{client: client}
+}
+
+// Refund refunds the order's most recent captured payment. Earlier payments
+// of the order were declined or voided.
+func (p *grpcPayments) Refund(ctx context.Context, orderID string, amount float64) (*Refund, error) {
+	resp, err := p.client.ListPayments(ctx, &paymentpb.ListPaymentsRequest{OrderId: orderID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	for _, payment := range resp.Payments {
+		if payment.Status != paymentStatusCaptured {
+			continue
+		}
+		remaining := roundCents(payment.CapturedAmount - payment.RefundedAmount)
+		if remaining <= 0 {
+			break
+		}
+		amount = math.Min(amount, remaining)
+
+		refunded, err := p.client.RefundPayment(ctx, &paymentpb.RefundPaymentRequest{PaymentId: payment.Id, Amount: amount})
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund payment: %w", err)
+		}
+		return &Refund{
+			PaymentID: refunded.Payment.Id,
+			Amount:    roundCents(refunded.Payment.RefundedAmount - payment.RefundedAmount),
+		}, nil
+	}
+	return nil, ErrNothingToRefund
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
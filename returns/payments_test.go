@@ -0,0 +1,81 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	paymentpb "github.com/Ujjwaljain16/E-commerce-Backend/payment/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakePaymentClient serves an order's payments from the payment service
+// client interface and records refunds
+type fakePaymentClient struct {
+	paymentpb.PaymentServiceClient
+	payments []*paymentpb.Payment
+	refunds  []*paymentpb.RefundPaymentRequest
+}
+
+func (c *fakePaymentClient) ListPayments(ctx context.Context, req *paymentpb.ListPaymentsRequest, opts ...grpc.CallOption) (*paymentpb.ListPaymentsResponse, error) {
+	return &paymentpb.ListPaymentsResponse{Payments: c.payments}, nil
+}
+
+func (c *fakePaymentClient) RefundPayment(ctx context.Context, req *paymentpb.RefundPaymentRequest, opts ...grpc.CallOption) (*paymentpb.RefundPaymentResponse, error) {
+	c.refunds = append(c.refunds, req)
+	for _, p := range c.payments {
+		if p.Id == req.PaymentId {
+			refunded := proto.Clone(p).(*paymentpb.Payment)
+			refunded.RefundedAmount += req.Amount
+			return &paymentpb.RefundPaymentResponse{Payment: refunded}, nil
+		}
+	}
+	return nil, errors.New("payment not found")
+}
+
+func TestGRPCPaymentsRefund(t *testing.T) {
+	client := &fakePaymentClient{payments: []*paymentpb.Payment{
+		{Id: "payment-2", Status: paymentStatusCaptured, CapturedAmount: 50, RefundedAmount: 10},
+		{Id: "payment-1", Status: "VOIDED", Amount: 50},
+	}}
+
+	refund, err := NewGRPCPayments(client).Refund(context.Background(), "order-1", 25)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if refund.PaymentID != "payment-2" || refund.Amount != 25 {
+		t.Errorf("Unexpected refund %+v", refund)
+	}
+
+	refund, err = NewGRPCPayments(client).Refund(context.Background(), "order-1", 45)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if refund.Amount != 40 || client.refunds[1].Amount != 40 {
+		t.Errorf("Expected the refund capped at the 40 left, got %+v", refund)
+	}
+}
+
+func TestGRPCPaymentsRefund_NothingToRefund(t *testing.T) {
+	tests := []struct {
+		name     string
+		payments []*paymentpb.Payment
+	}{
+		{"no payments", nil},
+		{"not captured", []*paymentpb.Payment{{Id: "payment-1", Status: "AUTHORIZED", Amount: 50}}},
+		{"fully refunded", []*paymentpb.Payment{{Id: "payment-1", Status: "REFUNDED", CapturedAmount: 50, RefundedAmount: 50}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakePaymentClient{payments: tt.payments}
+			if _, err := NewGRPCPayments(client).Refund(context.Background(), "order-1", 10); !errors.Is(err, ErrNothingToRefund) {
+				t.Errorf("Expected ErrNothingToRefund, got %v", err)
+			}
+			if len(client.refunds) != 0 {
+				t.Errorf("Expected no refund, got %v", client.refunds)
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package returns
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Return statuses
+const (
+	StatusRequested = "REQUESTED"
+	StatusApproved  = "APPROVED"
+	StatusRejected  = "REJECTED"
+	StatusReceived  = "RECEIVED"
+	StatusRefunded  = "REFUNDED"
+)
+
+var (
+	// ErrReturnNotFound is returned when a return does not exist
+	ErrReturnNotFound = errors.New("return not found")
+	// ErrStatusConflict is returned when a return changed since it was read
+	ErrStatusConflict = errors.New("return status changed")
+	// ErrQuantityExceeded is returned when a return asks for more units of an
+	// order line than are left to return
+	ErrQuantityExceeded = errors.New("quantity exceeds what is left to return")
+)
+
+// Return is a customer's request to send back items of a delivered order.
+// Amounts are in the order's currency.
+type Return struct {
+	ID             string
+	OrderID        string
+	UserID         string
+	Status         string
+	Reason         string
+	Items          []Item
+	RefundAmount   float64
+	ResolutionNote string
+	Restock        bool
+	PaymentID      string
+	RefundedAmount float64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ReceivedAt     *time.Time
+	RefundedAt     *time.Time
+}
+
+// Item is an order line being returned, with the order's price
+type Item struct {
+	ProductID string
+	SKU       string
+	Name      string
+	Quantity  int32
+	UnitPrice float64
+	Restocked bool
+}
+
+// Filter narrows a list of returns; empty fields match every return
+type Filter struct {
+	UserID  string
+	OrderID string
+	Status  string
+}
+
+// Repository handles return data persistence
+type Repository interface {
+	// Create inserts a return. ordered holds the quantity of each product on
+	// the order; ErrQuantityExceeded is returned when the return and the
+	// order's other returns that were not rejected would exceed it.
+	Create(ctx context.Context, ret *Return, ordered map[string]int32) (*Return, error)
+	GetByID(ctx context.Context, id string) (*Return, error)
+	List(ctx context.Context, filter Filter, page, pageSize int32) ([]*Return, int32, error)
+	// Resolve approves or rejects a REQUESTED return. It returns
+	// ErrStatusConflict when the return is no longer REQUESTED.
+	Resolve(ctx context.Context, id, status, note string, at time.Time) (*Return, error)
+	// Receive moves an APPROVED return to RECEIVED. It returns
+	// ErrStatusConflict when the return is no longer APPROVED.
+	Receive(ctx context.Context, id string, restock bool, at time.Time) (*Return, error)
+	// MarkRestocked records that an item of a return is back in stock
+	MarkRestocked(ctx context.Context, id, productID string) error
+	// MarkRefunded moves a RECEIVED return to REFUNDED. It returns
+	// ErrStatusConflict when the return is no longer RECEIVED.
+	MarkRefunded(ctx context.Context, id, paymentID string, amount float64, at time.Time) (*Return, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL return repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const returnColumns = `id, order_id, user_id, status, reason, refund_amount, resolution_note, restock,
+	COALESCE(payment_id::text, ''), refunded_amount, created_at, updated_at, received_at, refunded_at`
+
+const itemColumns = `return_id, product_id, sku, name, quantity, unit_price, restocked`
+
+// Create inserts a return and its items. The order's returns are serialized
+// on an advisory lock, so concurrent requests cannot together return more
+// than was ordered.
+func (r *postgresRepository) Create(ctx context.Context, ret *Return, ordered map[string]int32) (*Return, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", ret.OrderID); err != nil {
+		return nil, fmt.Errorf("failed to lock order returns: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT i.product_id, SUM(i.quantity)
+		FROM return_items i JOIN returns r ON r.id = i.return_id
+		WHERE r.order_id = $1 AND r.status <> 'REJECTED'
+		GROUP BY i.product_id
+	`, ret.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count returned quantities: %w", err)
+	}
+	returned := map[string]int32{}
+	for rows.Next() {
+		var productID string
+		var quantity int32
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan returned quantity: %w", err)
+		}
+		returned[productID] = quantity
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating returned quantities: %w", err)
+	}
+	rows.Close()
+
+	for _, item := range ret.Items {
+		if returned[item.ProductID]+item.Quantity > ordered[item.ProductID] {
+			return nil, ErrQuantityExceeded
+		}
+	}
+
+	ret.ID = uuid.New().String()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO returns (id, order_id, user_id, status, reason, refund_amount, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, ret.ID, ret.OrderID, ret.UserID, ret.Status, ret.Reason, ret.RefundAmount, ret.CreatedAt, ret.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create return", map[string]interface{}{"error": err.Error(), "order_id": ret.OrderID})
+		return nil, fmt.Errorf("failed to create return: %w", err)
+	}
+
+	for _, item := range ret.Items {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO return_items (return_id, product_id, sku, name, quantity, unit_price)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, ret.ID, item.ProductID, item.SKU, item.Name, item.Quantity, item.UnitPrice)
+		if err != nil {
+			r.log.Error(ctx, "Failed to create return item", map[string]interface{}{"error": err.Error(), "return_id": ret.ID})
+			return nil, fmt.Errorf("failed to create return item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit return: %w", err)
+	}
+
+	r.log.Info(ctx, "Return created", map[string]interface{}{"return_id": ret.ID, "order_id": ret.OrderID, "user_id": ret.UserID})
+	return ret, nil
+}
+
+// GetByID retrieves a return and its items
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Return, error) {
+	query := "SELECT " + returnColumns + " FROM returns WHERE id = $1"
+
+	ret, err := scanReturn(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrReturnNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get return", map[string]interface{}{"error": err.Error(), "return_id": id})
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+
+	if err := r.loadItems(ctx, []*Return{ret}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// List retrieves returns with pagination, newest first
+func (r *postgresRepository) List(ctx context.Context, filter Filter, page, pageSize int32) ([]*Return, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	const where = `
+		WHERE ($1 = '' OR user_id = $1)
+			AND ($2 = '' OR order_id = NULLIF($2, '')::uuid)
+			AND ($3 = '' OR status = $3)`
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM returns"+where, filter.UserID, filter.OrderID, filter.Status).Scan(&total)
+	if pgerr.IsMalformedID(err) {
+		return []*Return{}, 0, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to count returns", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count returns: %w", err)
+	}
+
+	query := "SELECT " + returnColumns + " FROM returns" + where + `
+		ORDER BY created_at DESC, id
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.UserID, filter.OrderID, filter.Status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list returns", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list returns: %w", err)
+	}
+	defer rows.Close()
+
+	returns := make([]*Return, 0, pageSize)
+	for rows.Next() {
+		ret, err := scanReturn(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan return", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan return: %w", err)
+		}
+		returns = append(returns, ret)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating returns", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating returns: %w", err)
+	}
+
+	if err := r.loadItems(ctx, returns); err != nil {
+		return nil, 0, err
+	}
+	return returns, total, nil
+}
+
+// loadItems reads the items of returns in one query
+func (r *postgresRepository) loadItems(ctx context.Context, returns []*Return) error {
+	if len(returns) == 0 {
+		return nil
+	}
+	ids := make([]string, len(returns))
+	byID := make(map[string]*Return, len(returns))
+	for i, ret := range returns {
+		ids[i] = ret.ID
+		byID[ret.ID] = ret
+		ret.Items = []Item{}
+	}
+
+	query := "SELECT " + itemColumns + " FROM return_items WHERE return_id = ANY($1) ORDER BY return_id, product_id"
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.log.Error(ctx, "Failed to get return items", map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to get return items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var returnID string
+		var item Item
+		if err := rows.Scan(&returnID, &item.ProductID, &item.SKU, &item.Name, &item.Quantity, &item.UnitPrice, &item.Restocked); err != nil {
+			return fmt.Errorf("failed to scan return item: %w", err)
+		}
+		if ret, ok := byID[returnID]; ok {
+			ret.Items = append(ret.Items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating return items: %w", err)
+	}
+	return nil
+}
+
+// Resolve records the approval or rejection of a REQUESTED return
+func (r *postgresRepository) Resolve(ctx context.Context, id, status, note string, at time.Time) (*Return, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE returns SET status = $2, resolution_note = $3, updated_at = $4
+		WHERE id = $1 AND status = 'REQUESTED'
+	`, id, status, note, at)
+	return r.afterUpdate(ctx, id, result, err, StatusRequested, status)
+}
+
+// Receive records that the items of an APPROVED return arrived back
+func (r *postgresRepository) Receive(ctx context.Context, id string, restock bool, at time.Time) (*Return, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE returns SET status = 'RECEIVED', restock = $2, received_at = $3, updated_at = $3
+		WHERE id = $1 AND status = 'APPROVED'
+	`, id, restock, at)
+	return r.afterUpdate(ctx, id, result, err, StatusApproved, StatusReceived)
+}
+
+// MarkRestocked flags an item of a return as back in stock
+func (r *postgresRepository) MarkRestocked(ctx context.Context, id, productID string) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE return_items SET restocked = TRUE WHERE return_id = $1 AND product_id = $2", id, productID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to mark return item restocked", map[string]interface{}{"error": err.Error(), "return_id": id, "product_id": productID})
+		return fmt.Errorf("failed to mark return item restocked: %w", err)
+	}
+	return nil
+}
+
+// MarkRefunded records the refund of a RECEIVED return
+func (r *postgresRepository) MarkRefunded(ctx context.Context, id, paymentID string, amount float64, at time.Time) (*Return, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE returns SET status = 'REFUNDED', payment_id = NULLIF($2, '')::uuid, refunded_amount = $3, refunded_at = $4, updated_at = $4
+		WHERE id = $1 AND status = 'RECEIVED'
+	`, id, paymentID, amount, at)
+	return r.afterUpdate(ctx, id, result, err, StatusReceived, StatusRefunded)
+}
+
+// afterUpdate checks the result of a status change made only while the return
+// was in status from, and reads the changed return
+func (r *postgresRepository) afterUpdate(ctx context.Context, id string, result sql.Result, err error, from, to string) (*Return, error) {
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrReturnNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update return", map[string]interface{}{"error": err.Error(), "return_id": id})
+		return nil, fmt.Errorf("failed to update return: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update return: %w", err)
+	}
+	if rows == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	}
+
+	r.log.Info(ctx, "Return updated", map[string]interface{}{"return_id": id, "from": from, "to": to})
+	return r.GetByID(ctx, id)
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReturn(row rowScanner) (*Return, error) {
+	ret := &Return{}
+	var receivedAt, refundedAt sql.NullTime
+	err := row.Scan(&ret.ID, &ret.OrderID, &ret.UserID, &ret.Status, &ret.Reason, &ret.RefundAmount, &ret.ResolutionNote,
+		&ret.Restock, &ret.PaymentID, &ret.RefundedAmount, &ret.CreatedAt, &ret.UpdatedAt, &receivedAt, &refundedAt)
+	if err != nil {
+		return nil, err
+	}
+	if receivedAt.Valid {
+		ret.ReceivedAt = &receivedAt.Time
+	}
+	if refundedAt.Valid {
+		ret.RefundedAt = &refundedAt.Time
+	}
+	return ret, nil
+}
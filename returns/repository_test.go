@@ -0,0 +1,181 @@
+package returns
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("returns-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var returnColumnNames = []string{
+	"id", "order_id", "user_id", "status", "reason", "refund_amount", "resolution_note", "restock",
+	"payment_id", "refunded_amount", "created_at", "updated_at", "received_at", "refunded_at",
+}
+
+var itemColumnNames = []string{"return_id", "product_id", "sku", "name", "quantity", "unit_price", "restocked"}
+
+func testReturn() *Return {
+	now := time.Now()
+	return &Return{
+		OrderID:      "order-1",
+		UserID:       "user-1",
+		Status:       StatusRequested,
+		Reason:       "Too small",
+		Items:        []Item{{ProductID: "shirt", SKU: "SHIRT-M", Name: "Shirt", Quantity: 1, UnitPrice: 19.99}},
+		RefundAmount: 19.99,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	ret := testReturn()
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\(\$1\)\)`).
+		WithArgs("order-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT i.product_id, SUM\(i.quantity\)\s+FROM return_items i JOIN returns r ON r.id = i.return_id\s+WHERE r.order_id = \$1 AND r.status <> 'REJECTED'`).
+		WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "sum"}).AddRow("shirt", 1))
+	mock.ExpectExec(`INSERT INTO returns`).
+		WithArgs(sqlmock.AnyArg(), "order-1", "user-1", StatusRequested, "Too small", 19.99, ret.CreatedAt, ret.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO return_items`).
+		WithArgs(sqlmock.AnyArg(), "shirt", "SHIRT-M", "Shirt", int32(1), 19.99).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	created, err := repo.Create(context.Background(), ret, map[string]int32{"shirt": 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_QuantityExceeded(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT i.product_id, SUM\(i.quantity\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "sum"}).AddRow("shirt", 1))
+	mock.ExpectRollback()
+
+	_, err := repo.Create(context.Background(), testReturn(), map[string]int32{"shirt": 1})
+	if err != ErrQuantityExceeded {
+		t.Errorf("Expected ErrQuantityExceeded, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM returns WHERE id = \$1`).
+		WithArgs("return-1").
+		WillReturnRows(sqlmock.NewRows(returnColumnNames).
+			AddRow("return-1", "order-1", "user-1", StatusReceived, "Too small", 19.99, "", true, "", 0.0, now, now, now, nil))
+	mock.ExpectQuery(`SELECT .* FROM return_items WHERE return_id = ANY\(\$1\)`).
+		WithArgs(pq.Array([]string{"return-1"})).
+		WillReturnRows(sqlmock.NewRows(itemColumnNames).AddRow("return-1", "shirt", "SHIRT-M", "Shirt", 1, 19.99, false))
+
+	ret, err := repo.GetByID(context.Background(), "return-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ret.Status != StatusReceived || !ret.Restock || ret.ReceivedAt == nil || ret.RefundedAt != nil {
+		t.Errorf("Unexpected return %+v", ret)
+	}
+	if len(ret.Items) != 1 || ret.Items[0].ProductID != "shirt" {
+		t.Errorf("Unexpected items %+v", ret.Items)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM returns WHERE id = \$1`).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM returns WHERE id = \$1`).WithArgs("not-a-uuid").WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(context.Background(), id); err != ErrReturnNotFound {
+			t.Errorf("Expected ErrReturnNotFound for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestResolve_Conflict(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE returns SET status = \$2, resolution_note = \$3, updated_at = \$4\s+WHERE id = \$1 AND status = 'REQUESTED'`).
+		WithArgs("return-1", StatusApproved, "", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM returns WHERE id = \$1`).
+		WillReturnRows(sqlmock.NewRows(returnColumnNames).
+			AddRow("return-1", "order-1", "user-1", StatusRejected, "Too small", 19.99, "Worn", false, "", 0.0, now, now, nil, nil))
+	mock.ExpectQuery(`FROM return_items`).WillReturnRows(sqlmock.NewRows(itemColumnNames))
+
+	if _, err := repo.Resolve(context.Background(), "return-1", StatusApproved, "", now); err != ErrStatusConflict {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkRefunded(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE returns SET status = 'REFUNDED', payment_id = NULLIF\(\$2, ''\)::uuid, refunded_amount = \$3, refunded_at = \$4, updated_at = \$4\s+WHERE id = \$1 AND status = 'RECEIVED'`).
+		WithArgs("return-1", "payment-1", 19.99, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .* FROM returns WHERE id = \$1`).
+		WillReturnRows(sqlmock.NewRows(returnColumnNames).
+			AddRow("return-1", "order-1", "user-1", StatusRefunded, "Too small", 19.99, "", true, "payment-1", 19.99, now, now, now, now))
+	mock.ExpectQuery(`FROM return_items`).WillReturnRows(sqlmock.NewRows(itemColumnNames))
+
+	ret, err := repo.MarkRefunded(context.Background(), "return-1", "payment-1", 19.99, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ret.Status != StatusRefunded || ret.PaymentID != "payment-1" || ret.RefundedAt == nil {
+		t.Errorf("Unexpected return %+v", ret)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
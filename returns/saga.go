@@ -0,0 +1,87 @@
+package returns
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/saga"
+)
+
+// Steps of the saga that settles a received return
+const (
+	StepRestock       = "restock"
+	StepRecordRestock = "record_restock"
+	StepRefund        = "refund"
+)
+
+// SagaName names the sagas of received returns in the saga store
+const SagaName = "return"
+
+// stepTimeout bounds each step of a received return
+const stepTimeout = 30 * time.Second
+
+// sagaDefinition describes the saga of a received return. The steps have no
+// compensations: a return whose saga stopped stays RECEIVED and is resumed
+// by calling ReceiveReturn again, so RunRecovery only closes the sagas of
+// instances that stopped.
+var sagaDefinition = saga.Definition{
+	Name:  SagaName,
+	Steps: []string{StepRestock, StepRecordRestock, StepRefund},
+	Compensations: map[string]saga.Compensation{
+		StepRestock:       saga.NoCompensation,
+		StepRecordRestock: saga.NoCompensation,
+		StepRefund:        saga.NoCompensation,
+	},
+	StepTimeout: stepTimeout,
+}
+
+// WithSagaStore saves the progress of received returns in store
+func (s *Service) WithSagaStore(store saga.Store) *Service {
+	s.saga = s.saga.WithStore(store)
+	return s
+}
+
+// RunRecovery closes the sagas of received returns not updated for
+// staleAfter every interval until ctx is cancelled
+func (s *Service) RunRecovery(ctx context.Context, interval, staleAfter time.Duration) {
+	s.saga.Run(ctx, interval, staleAfter)
+}
+
+// settleSteps are the steps that restock the items of a received return that
+// are not yet back in stock, when restock is set, and refund it. refund is
+// set to the refund made.
+func (s *Service) settleSteps(ret *Return, refund **Refund) []saga.Step {
+	var steps []saga.Step
+	if ret.Restock {
+		for _, item := range ret.Items {
+			if item.Restocked {
+				continue
+			}
+			steps = append(steps,
+				saga.Step{Name: StepRestock, Action: func(ctx context.Context) (string, error) {
+					if err := s.inventory.Restock(ctx, item.ProductID, item.Quantity); err != nil {
+						s.log.Error(ctx, "Failed to restock returned item", map[string]interface{}{"error": err.Error(), "return_id": ret.ID, "product_id": item.ProductID})
+						return "", err
+					}
+					return item.ProductID, nil
+				}},
+				// The stock is already back, so the flag is recorded even if the caller has gone
+				saga.Step{Name: StepRecordRestock, Action: func(ctx context.Context) (string, error) {
+					return item.ProductID, s.repo.MarkRestocked(context.WithoutCancel(ctx), ret.ID, item.ProductID)
+				}},
+			)
+		}
+	}
+
+	if ret.RefundAmount > 0 {
+		steps = append(steps, saga.Step{Name: StepRefund, Action: func(ctx context.Context) (string, error) {
+			r, err := s.payments.Refund(ctx, ret.OrderID, ret.RefundAmount)
+			if err != nil {
+				return "", err
+			}
+			*refund = r
+			return r.PaymentID, nil
+		}})
+	}
+	return steps
+}
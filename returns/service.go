@@ -0,0 +1,420 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/saga"
+	"github.com/Ujjwaljain16/E-commerce-Backend/returns/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the user_id column of returns
+	maxIDLength = 255
+	// maxTextLength caps a return reason and a resolution note
+	maxTextLength = 1000
+	// maxReturnItems matches the order service's limit on lines per order
+	maxReturnItems = 100
+	// DefaultWindow is how long after delivery an order's items can be returned
+	DefaultWindow = 30 * 24 * time.Hour
+)
+
+// validStatuses are the statuses a return list can be filtered by
+var validStatuses = map[string]bool{
+	StatusRequested: true, StatusApproved: true, StatusRejected: true, StatusReceived: true, StatusRefunded: true,
+}
+
+// AdminMethods are the admin-scoped RPCs of the returns service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.ReturnsService_ListReturns_FullMethodName,
+	pb.ReturnsService_ApproveReturn_FullMethodName,
+	pb.ReturnsService_RejectReturn_FullMethodName,
+	pb.ReturnsService_ReceiveReturn_FullMethodName,
+}
+
+// Service implements the ReturnsService gRPC interface
+type Service struct {
+	pb.UnimplementedReturnsServiceServer
+	repo      Repository
+	orders    Orders
+	inventory Inventory
+	payments  Payments
+	window    time.Duration
+	log       *logger.Logger
+	now       func() time.Time
+	saga      *saga.Orchestrator
+}
+
+// NewService creates a new returns service. Items can be returned for window
+// after their order was delivered. Saga state is kept in memory until
+// WithSagaStore is called.
+func NewService(repo Repository, orders Orders, inventory Inventory, payments Payments, window time.Duration, log *logger.Logger) (*Service, error) {
+	orchestrator, err := saga.NewOrchestrator(sagaDefinition, saga.NewMemoryStore(), log)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		repo:      repo,
+		orders:    orders,
+		inventory: inventory,
+		payments:  payments,
+		window:    window,
+		log:       log,
+		now:       time.Now,
+		saga:      orchestrator,
+	}, nil
+}
+
+// CreateReturn requests a return of items of a delivered order. The order
+// must belong to the customer and have been delivered within the return
+// window, and each item can be returned up to the quantity ordered.
+func (s *Service) CreateReturn(ctx context.Context, req *pb.CreateReturnRequest) (*pb.CreateReturnResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if msg := createProblem(req, reason); msg != "" {
+		s.log.Warn(ctx, "Create return failed: "+msg, map[string]interface{}{"order_id": req.OrderId, "user_id": req.UserId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	order, err := s.orders.GetOrder(ctx, req.OrderId)
+	if errors.Is(err, ErrOrderNotFound) || (err == nil && order.UserID != req.UserId) {
+		s.log.Warn(ctx, "Create return failed: order not found", map[string]interface{}{"order_id": req.OrderId, "user_id": req.UserId})
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get order", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Unavailable, "failed to get order")
+	}
+
+	now := s.now()
+	if order.Status != orderStatusDelivered {
+		s.log.Warn(ctx, "Create return failed: order not delivered", map[string]interface{}{"order_id": order.ID, "status": order.Status})
+		return nil, status.Error(codes.FailedPrecondition, "only delivered orders can be returned")
+	}
+	if now.After(order.UpdatedAt.Add(s.window)) {
+		s.log.Warn(ctx, "Create return failed: return window closed", map[string]interface{}{"order_id": order.ID})
+		return nil, status.Error(codes.FailedPrecondition, "the return window for this order has closed")
+	}
+
+	ret := &Return{
+		OrderID:   order.ID,
+		UserID:    req.UserId,
+		Status:    StatusRequested,
+		Reason:    reason,
+		Items:     make([]Item, len(req.Items)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	lines := make(map[string]OrderItem, len(order.Items))
+	ordered := make(map[string]int32, len(order.Items))
+	for _, line := range order.Items {
+		lines[line.ProductID] = line
+		ordered[line.ProductID] += line.Quantity
+	}
+	for i, item := range req.Items {
+		line, ok := lines[item.ProductId]
+		if !ok {
+			msg := fmt.Sprintf("product %s is not on the order", item.ProductId)
+			s.log.Warn(ctx, "Create return failed: "+msg, map[string]interface{}{"order_id": order.ID})
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+		ret.Items[i] = Item{
+			ProductID: line.ProductID,
+			SKU:       line.SKU,
+			Name:      line.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: line.UnitPrice,
+		}
+		ret.RefundAmount += line.UnitPrice * float64(item.Quantity)
+	}
+	ret.RefundAmount = roundCents(ret.RefundAmount)
+
+	created, err := s.repo.Create(ctx, ret, ordered)
+	if errors.Is(err, ErrQuantityExceeded) {
+		s.log.Warn(ctx, "Create return failed: quantity exceeded", map[string]interface{}{"order_id": order.ID})
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to create return", map[string]interface{}{"error": err.Error(), "order_id": order.ID})
+		return nil, status.Error(codes.Internal, "failed to create return")
+	}
+
+	return &pb.CreateReturnResponse{
+		Return: toProtoReturn(created),
+	}, nil
+}
+
+// createProblem validates a return request and returns a message describing
+// the first problem, or ""
+func createProblem(req *pb.CreateReturnRequest, reason string) string {
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return "user_id is too long"
+	case reason == "":
+		return "reason is required"
+	case utf8.RuneCountInString(reason) > maxTextLength:
+		return fmt.Sprintf("reason cannot exceed %d characters", maxTextLength)
+	case len(req.Items) == 0:
+		return "items are required"
+	case len(req.Items) > maxReturnItems:
+		return fmt.Sprintf("a return cannot have more than %d items", maxReturnItems)
+	}
+
+	seen := map[string]bool{}
+	for _, item := range req.Items {
+		switch {
+		case item.ProductId == "":
+			return "product_id is required"
+		case item.Quantity <= 0:
+			return "quantity must be positive"
+		case seen[item.ProductId]:
+			return fmt.Sprintf("product %s is listed more than once", item.ProductId)
+		}
+		seen[item.ProductId] = true
+	}
+	return ""
+}
+
+// GetReturn retrieves a return by ID
+func (s *Service) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.GetReturnResponse, error) {
+	ret, err := s.load(ctx, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetReturnResponse{
+		Return: toProtoReturn(ret),
+	}, nil
+}
+
+// ListReturns lists returns, newest first
+func (s *Service) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	if req.Status != "" && !validStatuses[req.Status] {
+		s.log.Warn(ctx, "List returns failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be REQUESTED, APPROVED, REJECTED, RECEIVED or REFUNDED")
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	returns, total, err := s.repo.List(ctx, Filter{UserID: req.UserId, OrderID: req.OrderId, Status: req.Status}, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list returns", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to list returns")
+	}
+
+	protoReturns := make([]*pb.Return, len(returns))
+	for i, ret := range returns {
+		protoReturns[i] = toProtoReturn(ret)
+	}
+
+	return &pb.ListReturnsResponse{
+		Returns:  protoReturns,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// ApproveReturn authorizes the customer to send a REQUESTED return's items back
+func (s *Service) ApproveReturn(ctx context.Context, req *pb.ApproveReturnRequest) (*pb.ApproveReturnResponse, error) {
+	ret, err := s.resolve(ctx, req.ReturnId, StatusApproved, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ApproveReturnResponse{
+		Return: toProtoReturn(ret),
+	}, nil
+}
+
+// RejectReturn declines a REQUESTED return. The note tells the customer why.
+func (s *Service) RejectReturn(ctx context.Context, req *pb.RejectReturnRequest) (*pb.RejectReturnResponse, error) {
+	if strings.TrimSpace(req.Note) == "" {
+		s.log.Warn(ctx, "Reject return failed: note is required", map[string]interface{}{"return_id": req.ReturnId})
+		return nil, status.Error(codes.InvalidArgument, "note is required")
+	}
+
+	ret, err := s.resolve(ctx, req.ReturnId, StatusRejected, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RejectReturnResponse{
+		Return: toProtoReturn(ret),
+	}, nil
+}
+
+// resolve approves or rejects a REQUESTED return
+func (s *Service) resolve(ctx context.Context, returnID, to, note string) (*Return, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxTextLength {
+		s.log.Warn(ctx, "Resolve return failed: note too long", map[string]interface{}{"return_id": returnID})
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("note cannot exceed %d characters", maxTextLength))
+	}
+
+	ret, err := s.load(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != StatusRequested {
+		return nil, status.Error(codes.FailedPrecondition, "return is "+ret.Status+", not REQUESTED")
+	}
+
+	resolved, err := s.repo.Resolve(ctx, ret.ID, to, note, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, ret.ID)
+	}
+	return resolved, nil
+}
+
+// ReceiveReturn records that an APPROVED return's items arrived back, puts
+// them back into stock when restock is set and refunds their value to the
+// order's payment. A RECEIVED return whose restock or refund failed is picked
+// up where it stopped when ReceiveReturn is called again; restock is then
+// ignored in favour of the value recorded on receipt.
+func (s *Service) ReceiveReturn(ctx context.Context, req *pb.ReceiveReturnRequest) (*pb.ReceiveReturnResponse, error) {
+	ret, err := s.load(ctx, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ret.Status {
+	case StatusApproved:
+		ret, err = s.repo.Receive(ctx, ret.ID, req.Restock, s.now())
+		if err != nil {
+			return nil, s.updateError(ctx, err, req.ReturnId)
+		}
+	case StatusReceived:
+		s.log.Info(ctx, "Resuming received return", map[string]interface{}{"return_id": ret.ID})
+	default:
+		return nil, status.Error(codes.FailedPrecondition, "return is "+ret.Status+", not APPROVED")
+	}
+
+	// Each attempt is its own saga, since an earlier one may have stopped part way
+	refund := &Refund{}
+	if err := s.saga.Execute(ctx, uuid.New().String(), s.settleSteps(ret, &refund)); err != nil {
+		return nil, s.settleError(ctx, err, ret)
+	}
+
+	// The money is already back, so the refund is recorded even if the caller has gone
+	refunded, err := s.repo.MarkRefunded(context.WithoutCancel(ctx), ret.ID, refund.PaymentID, refund.Amount, s.now())
+	if err != nil {
+		s.log.Error(ctx, "Failed to record return refund", map[string]interface{}{"error": err.Error(), "return_id": ret.ID, "payment_id": refund.PaymentID})
+		return nil, status.Error(codes.Internal, "failed to record refund")
+	}
+
+	return &pb.ReceiveReturnResponse{
+		Return: toProtoReturn(refunded),
+	}, nil
+}
+
+// settleError maps the failure of a received return's saga to a gRPC status
+func (s *Service) settleError(ctx context.Context, err error, ret *Return) error {
+	if errors.Is(err, ErrNothingToRefund) {
+		s.log.Warn(ctx, "Receive return failed: nothing to refund", map[string]interface{}{"return_id": ret.ID, "order_id": ret.OrderID})
+		return status.Error(codes.FailedPrecondition, ErrNothingToRefund.Error())
+	}
+
+	var stepErr *saga.StepError
+	if !errors.As(err, &stepErr) {
+		s.log.Error(ctx, "Failed to start return saga", map[string]interface{}{"error": err.Error(), "return_id": ret.ID})
+		return status.Error(codes.Internal, "failed to receive return")
+	}
+	switch stepErr.Step {
+	case StepRestock:
+		return status.Error(codes.Unavailable, "failed to restock returned items")
+	case StepRecordRestock:
+		return status.Error(codes.Internal, "failed to record restock")
+	default:
+		s.log.Error(ctx, "Failed to refund return", map[string]interface{}{"error": err.Error(), "return_id": ret.ID, "order_id": ret.OrderID})
+		return status.Error(codes.Unavailable, "failed to refund return")
+	}
+}
+
+// load reads a return by ID
+func (s *Service) load(ctx context.Context, returnID string) (*Return, error) {
+	if returnID == "" {
+		s.log.Warn(ctx, "Return request failed: return ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "return_id is required")
+	}
+
+	ret, err := s.repo.GetByID(ctx, returnID)
+	if errors.Is(err, ErrReturnNotFound) {
+		s.log.Warn(ctx, "Return not found", map[string]interface{}{"return_id": returnID})
+		return nil, status.Error(codes.NotFound, "return not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get return", map[string]interface{}{"error": err.Error(), "return_id": returnID})
+		return nil, status.Error(codes.Internal, "failed to get return")
+	}
+	return ret, nil
+}
+
+// updateError maps an error changing a return's status to a gRPC status
+func (s *Service) updateError(ctx context.Context, err error, returnID string) error {
+	switch {
+	case errors.Is(err, ErrReturnNotFound):
+		return status.Error(codes.NotFound, "return not found")
+	case errors.Is(err, ErrStatusConflict):
+		s.log.Warn(ctx, "Return changed by another request", map[string]interface{}{"return_id": returnID})
+		return status.Error(codes.Aborted, "return was changed by another request")
+	}
+	s.log.Error(ctx, "Failed to update return", map[string]interface{}{"error": err.Error(), "return_id": returnID})
+	return status.Error(codes.Internal, "failed to update return")
+}
+
+// toProtoReturn converts a return to protobuf
+func toProtoReturn(r *Return) *pb.Return {
+	ret := &pb.Return{
+		Id:             r.ID,
+		OrderId:        r.OrderID,
+		UserId:         r.UserID,
+		Status:         r.Status,
+		Reason:         r.Reason,
+		Items:          make([]*pb.ReturnItem, len(r.Items)),
+		RefundAmount:   r.RefundAmount,
+		ResolutionNote: r.ResolutionNote,
+		Restock:        r.Restock,
+		PaymentId:      r.PaymentID,
+		RefundedAmount: r.RefundedAmount,
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+		UpdatedAt:      timestamppb.New(r.UpdatedAt),
+	}
+	for i, item := range r.Items {
+		ret.Items[i] = &pb.ReturnItem{
+			ProductId: item.ProductID,
+			Sku:       item.SKU,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Restocked: item.Restocked,
+		}
+	}
+	if r.ReceivedAt != nil {
+		ret.ReceivedAt = timestamppb.New(*r.ReceivedAt)
+	}
+	if r.RefundedAt != nil {
+		ret.RefundedAt = timestamppb.New(*r.RefundedAt)
+	}
+	return ret
+}
@@ -0,0 +1,577 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/saga"
+	"github.com/Ujjwaljain16/E-commerce-Backend/returns/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository is an in-memory Repository with the same quantity and
+// status checks as the Postgres repository
+type memoryRepository struct {
+	mu      sync.Mutex
+	returns map[string]Return
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{returns: map[string]Return{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, ret *Return, ordered map[string]int32) (*Return, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	returned := map[string]int32{}
+	for _, r := range m.returns {
+		if r.OrderID == ret.OrderID && r.Status != StatusRejected {
+			for _, item := range r.Items {
+				returned[item.ProductID] += item.Quantity
+			}
+		}
+	}
+	for _, item := range ret.Items {
+		if returned[item.ProductID]+item.Quantity > ordered[item.ProductID] {
+			return nil, ErrQuantityExceeded
+		}
+	}
+	ret.ID = uuid.New().String()
+	m.store(ret)
+	return m.get(ret.ID), nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Return, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.returns[id]; !ok {
+		return nil, ErrReturnNotFound
+	}
+	return m.get(id), nil
+}
+
+func (m *memoryRepository) List(ctx context.Context, filter Filter, page, pageSize int32) ([]*Return, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []*Return
+	for id, r := range m.returns {
+		if (filter.UserID == "" || r.UserID == filter.UserID) && (filter.OrderID == "" || r.OrderID == filter.OrderID) &&
+			(filter.Status == "" || r.Status == filter.Status) {
+			matched = append(matched, m.get(id))
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	start := int((page - 1) * pageSize)
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + int(pageSize)
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], int32(len(matched)), nil
+}
+
+func (m *memoryRepository) Resolve(ctx context.Context, id, status, note string, at time.Time) (*Return, error) {
+	return m.update(id, StatusRequested, func(r *Return) {
+		r.Status = status
+		r.ResolutionNote = note
+		r.UpdatedAt = at
+	})
+}
+
+func (m *memoryRepository) Receive(ctx context.Context, id string, restock bool, at time.Time) (*Return, error) {
+	return m.update(id, StatusApproved, func(r *Return) {
+		r.Status = StatusReceived
+		r.Restock = restock
+		r.ReceivedAt = &at
+		r.UpdatedAt = at
+	})
+}
+
+func (m *memoryRepository) MarkRestocked(ctx context.Context, id, productID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := m.returns[id]
+	for i := range r.Items {
+		if r.Items[i].ProductID == productID {
+			r.Items[i].Restocked = true
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) MarkRefunded(ctx context.Context, id, paymentID string, amount float64, at time.Time) (*Return, error) {
+	return m.update(id, StatusReceived, func(r *Return) {
+		r.Status = StatusRefunded
+		r.PaymentID = paymentID
+		r.RefundedAmount = amount
+		r.RefundedAt = &at
+		r.UpdatedAt = at
+	})
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+func (m *memoryRepository) update(id, from string, change func(*Return)) (*Return, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.returns[id]
+	if !ok {
+		return nil, ErrReturnNotFound
+	}
+	if r.Status != from {
+		return nil, ErrStatusConflict
+	}
+	change(&r)
+	m.returns[id] = r
+	return m.get(id), nil
+}
+
+// store keeps a copy of a return, so callers cannot change it in place
+func (m *memoryRepository) store(r *Return) {
+	stored := *r
+	stored.Items = append([]Item(nil), r.Items...)
+	m.returns[r.ID] = stored
+}
+
+func (m *memoryRepository) get(id string) *Return {
+	r := m.returns[id]
+	r.Items = append([]Item(nil), r.Items...)
+	return &r
+}
+
+// fakeOrders serves fixed orders
+type fakeOrders struct {
+	orders map[string]*Order
+	err    error
+}
+
+func (f *fakeOrders) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	order, ok := f.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// fakeInventory records restocks and fails for the products in failFor
+type fakeInventory struct {
+	mu       sync.Mutex
+	restocks map[string]int32
+	failFor  map[string]bool
+}
+
+func (f *fakeInventory) Restock(ctx context.Context, productID string, quantity int32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failFor[productID] {
+		return errors.New("catalog unavailable")
+	}
+	f.restocks[productID] += quantity
+	return nil
+}
+
+// fakePayments refunds from a single captured payment of captured
+type fakePayments struct {
+	captured float64
+	refunded float64
+	calls    int
+	err      error
+}
+
+func (f *fakePayments) Refund(ctx context.Context, orderID string, amount float64) (*Refund, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	remaining := roundCents(f.captured - f.refunded)
+	if remaining <= 0 {
+		return nil, ErrNothingToRefund
+	}
+	if amount > remaining {
+		amount = remaining
+	}
+	f.refunded = roundCents(f.refunded + amount)
+	return &Refund{PaymentID: "payment-1", Amount: amount}, nil
+}
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	service   *Service
+	repo      *memoryRepository
+	orders    *fakeOrders
+	inventory *fakeInventory
+	payments  *fakePayments
+}
+
+func setupService(t *testing.T) *fixture {
+	f := &fixture{
+		repo: newMemoryRepository(),
+		orders: &fakeOrders{orders: map[string]*Order{
+			"order-1": {
+				ID:     "order-1",
+				UserID: "user-1",
+				Status: orderStatusDelivered,
+				Items: []OrderItem{
+					{ProductID: "shirt", SKU: "SHIRT-M", Name: "Shirt", Quantity: 2, UnitPrice: 19.99},
+					{ProductID: "mug", SKU: "MUG", Name: "Mug", Quantity: 1, UnitPrice: 8.5},
+				},
+				UpdatedAt: now.Add(-5 * 24 * time.Hour),
+			},
+		}},
+		inventory: &fakeInventory{restocks: map[string]int32{}, failFor: map[string]bool{}},
+		payments:  &fakePayments{captured: 48.48},
+	}
+	service, err := NewService(f.repo, f.orders, f.inventory, f.payments, DefaultWindow, logger.New("returns-test"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	f.service = service
+	f.service.now = func() time.Time { return now }
+	return f
+}
+
+func validRequest() *pb.CreateReturnRequest {
+	return &pb.CreateReturnRequest{
+		OrderId: "order-1",
+		UserId:  "user-1",
+		Reason:  " Too small ",
+		Items: []*pb.ReturnItemRequest{
+			{ProductId: "shirt", Quantity: 2},
+			{ProductId: "mug", Quantity: 1},
+		},
+	}
+}
+
+// create creates a return and fails the test on error
+func (f *fixture) create(t *testing.T, req *pb.CreateReturnRequest) *pb.Return {
+	resp, err := f.service.CreateReturn(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Failed to create return: %v", err)
+	}
+	return resp.Return
+}
+
+// approve creates a return and approves it
+func (f *fixture) approve(t *testing.T, req *pb.CreateReturnRequest) *pb.Return {
+	ret := f.create(t, req)
+	resp, err := f.service.ApproveReturn(context.Background(), &pb.ApproveReturnRequest{ReturnId: ret.Id})
+	if err != nil {
+		t.Fatalf("Failed to approve return: %v", err)
+	}
+	return resp.Return
+}
+
+func TestCreateReturn(t *testing.T) {
+	f := setupService(t)
+
+	ret := f.create(t, validRequest())
+	if ret.Status != StatusRequested || ret.Reason != "Too small" || ret.UserId != "user-1" {
+		t.Errorf("Unexpected return %+v", ret)
+	}
+	if ret.RefundAmount != 48.48 {
+		t.Errorf("Expected a refund amount of 48.48, got %v", ret.RefundAmount)
+	}
+	if len(ret.Items) != 2 || ret.Items[0].Sku != "SHIRT-M" || ret.Items[0].UnitPrice != 19.99 {
+		t.Errorf("Expected the items to carry the order's SKUs and prices, got %+v", ret.Items)
+	}
+}
+
+func TestCreateReturn_InvalidRequest(t *testing.T) {
+	f := setupService(t)
+
+	tests := []struct {
+		name   string
+		modify func(*pb.CreateReturnRequest)
+	}{
+		{"missing order", func(r *pb.CreateReturnRequest) { r.OrderId = "" }},
+		{"missing user", func(r *pb.CreateReturnRequest) { r.UserId = "" }},
+		{"missing reason", func(r *pb.CreateReturnRequest) { r.Reason = "  " }},
+		{"no items", func(r *pb.CreateReturnRequest) { r.Items = nil }},
+		{"zero quantity", func(r *pb.CreateReturnRequest) { r.Items[0].Quantity = 0 }},
+		{"duplicate product", func(r *pb.CreateReturnRequest) { r.Items[1].ProductId = "shirt" }},
+		{"product not on the order", func(r *pb.CreateReturnRequest) { r.Items[1].ProductId = "hat" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validRequest()
+			tt.modify(req)
+			if _, err := f.service.CreateReturn(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateReturn_Eligibility(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Order, *pb.CreateReturnRequest)
+		expected codes.Code
+	}{
+		{"unknown order", func(o *Order, r *pb.CreateReturnRequest) { r.OrderId = "order-2" }, codes.NotFound},
+		{"another customer's order", func(o *Order, r *pb.CreateReturnRequest) { r.UserId = "user-2" }, codes.NotFound},
+		{"not delivered", func(o *Order, r *pb.CreateReturnRequest) { o.Status = "FULFILLED" }, codes.FailedPrecondition},
+		{"window closed", func(o *Order, r *pb.CreateReturnRequest) { o.UpdatedAt = now.Add(-31 * 24 * time.Hour) }, codes.FailedPrecondition},
+		{"more than ordered", func(o *Order, r *pb.CreateReturnRequest) { r.Items[0].Quantity = 3 }, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t)
+			req := validRequest()
+			tt.modify(f.orders.orders["order-1"], req)
+			if _, err := f.service.CreateReturn(context.Background(), req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	f := setupService(t)
+	f.orders.err = errors.New("connection refused")
+	if _, err := f.service.CreateReturn(context.Background(), validRequest()); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable when the order service is down, got %v", err)
+	}
+}
+
+func TestCreateReturn_CountsEarlierReturns(t *testing.T) {
+	f := setupService(t)
+
+	first := f.create(t, &pb.CreateReturnRequest{
+		OrderId: "order-1", UserId: "user-1", Reason: "Too small",
+		Items: []*pb.ReturnItemRequest{{ProductId: "shirt", Quantity: 1}},
+	})
+
+	req := validRequest()
+	if _, err := f.service.CreateReturn(context.Background(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition for a shirt already returned, got %v", err)
+	}
+
+	_, err := f.service.RejectReturn(context.Background(), &pb.RejectReturnRequest{ReturnId: first.Id, Note: "Worn"})
+	if err != nil {
+		t.Fatalf("Failed to reject return: %v", err)
+	}
+	f.create(t, req)
+}
+
+func TestApproveAndRejectReturn(t *testing.T) {
+	f := setupService(t)
+	ret := f.create(t, validRequest())
+
+	if _, err := f.service.RejectReturn(context.Background(), &pb.RejectReturnRequest{ReturnId: ret.Id}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a rejection without a note, got %v", err)
+	}
+
+	resp, err := f.service.ApproveReturn(context.Background(), &pb.ApproveReturnRequest{ReturnId: ret.Id, Note: " Print the label "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Return.Status != StatusApproved || resp.Return.ResolutionNote != "Print the label" {
+		t.Errorf("Unexpected return %+v", resp.Return)
+	}
+
+	if _, err := f.service.RejectReturn(context.Background(), &pb.RejectReturnRequest{ReturnId: ret.Id, Note: "No"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for an approved return, got %v", err)
+	}
+	if _, err := f.service.ApproveReturn(context.Background(), &pb.ApproveReturnRequest{ReturnId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestReceiveReturn(t *testing.T) {
+	f := setupService(t)
+	ret := f.approve(t, validRequest())
+
+	resp, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id, Restock: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := resp.Return
+	if got.Status != StatusRefunded || got.PaymentId != "payment-1" || got.RefundedAmount != 48.48 {
+		t.Errorf("Expected the return refunded in full, got %+v", got)
+	}
+	if got.ReceivedAt == nil || got.RefundedAt == nil {
+		t.Errorf("Expected receipt and refund times, got %+v", got)
+	}
+	if f.inventory.restocks["shirt"] != 2 || f.inventory.restocks["mug"] != 1 {
+		t.Errorf("Expected the items restocked, got %v", f.inventory.restocks)
+	}
+
+	if _, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a refunded return, got %v", err)
+	}
+}
+
+func TestReceiveReturn_WithoutRestock(t *testing.T) {
+	f := setupService(t)
+	ret := f.approve(t, validRequest())
+
+	resp, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Return.Status != StatusRefunded || resp.Return.Restock {
+		t.Errorf("Unexpected return %+v", resp.Return)
+	}
+	if len(f.inventory.restocks) != 0 {
+		t.Errorf("Expected nothing restocked, got %v", f.inventory.restocks)
+	}
+}
+
+func TestReceiveReturn_NotApproved(t *testing.T) {
+	f := setupService(t)
+	ret := f.create(t, validRequest())
+
+	if _, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a requested return, got %v", err)
+	}
+	if f.payments.calls != 0 {
+		t.Errorf("Expected no refund, got %d calls", f.payments.calls)
+	}
+}
+
+func TestReceiveReturn_ResumesAfterFailures(t *testing.T) {
+	f := setupService(t)
+	ret := f.approve(t, validRequest())
+
+	f.inventory.failFor["mug"] = true
+	if _, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id, Restock: true}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected Unavailable when the catalog fails, got %v", err)
+	}
+	f.inventory.failFor["mug"] = false
+	f.payments.err = errors.New("connection refused")
+	if _, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected Unavailable when the payment service fails, got %v", err)
+	}
+	f.payments.err = nil
+
+	resp, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Return.Status != StatusRefunded || !resp.Return.Restock {
+		t.Errorf("Expected the return refunded with the restock recorded on receipt, got %+v", resp.Return)
+	}
+	if f.inventory.restocks["shirt"] != 2 || f.inventory.restocks["mug"] != 1 {
+		t.Errorf("Expected each item restocked once, got %v", f.inventory.restocks)
+	}
+	if f.payments.refunded != 48.48 {
+		t.Errorf("Expected one refund of 48.48, got %v", f.payments.refunded)
+	}
+}
+
+// recordingStore keeps the last state saved of each saga
+type recordingStore struct {
+	saga.Store
+	last map[string]saga.State
+}
+
+func (s *recordingStore) Create(ctx context.Context, state *saga.State) error {
+	s.last[state.ID] = *state
+	return nil
+}
+
+func (s *recordingStore) Update(ctx context.Context, state *saga.State) error {
+	saved := *state
+	saved.Steps = append([]saga.StepRecord(nil), state.Steps...)
+	s.last[state.ID] = saved
+	return nil
+}
+
+func TestReceiveReturn_SavesSagaProgress(t *testing.T) {
+	f := setupService(t)
+	store := &recordingStore{last: map[string]saga.State{}}
+	f.service.WithSagaStore(store)
+	ret := f.approve(t, validRequest())
+
+	f.inventory.failFor["mug"] = true
+	if _, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id, Restock: true}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected Unavailable when the catalog fails, got %v", err)
+	}
+	if len(store.last) != 1 {
+		t.Fatalf("Expected one saga, got %d", len(store.last))
+	}
+	for _, state := range store.last {
+		expected := []saga.StepRecord{{Name: StepRestock, Ref: "shirt"}, {Name: StepRecordRestock, Ref: "shirt"}}
+		if state.Name != SagaName || state.Status != saga.StatusCompensated || state.FailedStep != StepRestock || !reflect.DeepEqual(state.Steps, expected) {
+			t.Errorf("Unexpected saga state %+v", state)
+		}
+	}
+}
+
+func TestReceiveReturn_PartialRefund(t *testing.T) {
+	f := setupService(t)
+	f.payments.refunded = 40
+	ret := f.approve(t, validRequest())
+
+	resp, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Return.RefundAmount != 48.48 || resp.Return.RefundedAmount != 8.48 {
+		t.Errorf("Expected only what was left on the payment refunded, got %+v", resp.Return)
+	}
+
+	f = setupService(t)
+	f.payments.refunded = f.payments.captured
+	ret = f.approve(t, validRequest())
+	if _, err := f.service.ReceiveReturn(context.Background(), &pb.ReceiveReturnRequest{ReturnId: ret.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition with nothing left to refund, got %v", err)
+	}
+}
+
+func TestListReturns(t *testing.T) {
+	f := setupService(t)
+	ret := f.create(t, &pb.CreateReturnRequest{
+		OrderId: "order-1", UserId: "user-1", Reason: "Broken",
+		Items: []*pb.ReturnItemRequest{{ProductId: "mug", Quantity: 1}},
+	})
+
+	resp, err := f.service.ListReturns(context.Background(), &pb.ListReturnsRequest{UserId: "user-1", Status: StatusRequested})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 1 || resp.Returns[0].Id != ret.Id || resp.Page != 1 || resp.PageSize != 10 {
+		t.Errorf("Unexpected list %+v", resp)
+	}
+
+	resp, err = f.service.ListReturns(context.Background(), &pb.ListReturnsRequest{Status: StatusApproved})
+	if err != nil || resp.Total != 0 {
+		t.Errorf("Expected no approved returns, got %v, %v", resp, err)
+	}
+	if _, err := f.service.ListReturns(context.Background(), &pb.ListReturnsRequest{Status: "LOST"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetReturn(t *testing.T) {
+	f := setupService(t)
+	ret := f.create(t, validRequest())
+
+	resp, err := f.service.GetReturn(context.Background(), &pb.GetReturnRequest{ReturnId: ret.Id})
+	if err != nil || resp.Return.Id != ret.Id {
+		t.Errorf("Expected return %s, got %v, %v", ret.Id, resp, err)
+	}
+	if _, err := f.service.GetReturn(context.Background(), &pb.GetReturnRequest{ReturnId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := f.service.GetReturn(context.Background(), &pb.GetReturnRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
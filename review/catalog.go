@@ -0,0 +1,40 @@
+package review
+
+import (
+	"context"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Catalog receives the rating aggregates of products
+type Catalog interface {
+	// UpdateRatingAggregate stores a product's aggregate. Aggregates older
+	// than the stored one, and aggregates of deleted products, are accepted
+	// and ignored.
+	UpdateRatingAggregate(ctx context.Context, agg *Aggregate) error
+}
+
+type grpcCatalog struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCCatalog creates a Catalog backed by the catalog service
+func NewGRPCCatalog(client catalogpb.CatalogServiceClient) Catalog {
+	return &grpcCatalog{client: client}
+}
+
+func (c *grpcCatalog) UpdateRatingAggregate(ctx context.Context, agg *Aggregate) error {
+	_, err := c.client.UpdateRatingAggregate(ctx, &catalogpb.UpdateRatingAggregateRequest{
+		ProductId:     agg.ProductID,
+		AverageRating: agg.AverageRating,
+		ReviewCount:   agg.ReviewCount,
+		AsOf:          timestamppb.New(agg.ComputedAt),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_rating_aggregates_unpublished;
+DROP TABLE IF EXISTS rating_aggregates;
+DROP INDEX IF EXISTS idx_reviews_product_rating;
+DROP INDEX IF EXISTS idx_reviews_product_created;
+DROP TABLE IF EXISTS reviews;
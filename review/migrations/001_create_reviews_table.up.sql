@@ -0,0 +1,34 @@
+-- Customer reviews of products they bought. Reviews are PENDING until a
+-- moderator approves or rejects them; only APPROVED ones are listed and
+-- counted in ratings. Products and orders live in their own services, so
+-- they are not foreign keys.
+CREATE TABLE IF NOT EXISTS reviews (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    order_id UUID NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title VARCHAR(150) NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    moderation_note TEXT NOT NULL DEFAULT '',
+    moderated_by VARCHAR(255),
+    moderated_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT reviews_product_user_key UNIQUE (product_id, user_id)
+);
+
+CREATE INDEX idx_reviews_product_created ON reviews(product_id, status, created_at DESC);
+CREATE INDEX idx_reviews_product_rating ON reviews(product_id, status, rating DESC);
+
+-- The rating of each product's approved reviews, as last computed.
+-- published_at is NULL until the aggregate has been pushed to the catalog.
+CREATE TABLE IF NOT EXISTS rating_aggregates (
+    product_id UUID PRIMARY KEY,
+    average_rating DECIMAL(3, 2) NOT NULL CHECK (average_rating >= 0 AND average_rating <= 5),
+    review_count INTEGER NOT NULL CHECK (review_count >= 0),
+    computed_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_rating_aggregates_unpublished ON rating_aggregates(computed_at) WHERE published_at IS NULL;
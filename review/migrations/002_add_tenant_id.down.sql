@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_reviews_product_status;
+DROP INDEX IF EXISTS idx_reviews_tenant_product_rating;
+DROP INDEX IF EXISTS idx_reviews_tenant_product_created;
+CREATE INDEX idx_reviews_product_created ON reviews(product_id, status, created_at DESC);
+CREATE INDEX idx_reviews_product_rating ON reviews(product_id, status, rating DESC);
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_tenant_product_user_key;
+ALTER TABLE reviews ADD CONSTRAINT reviews_product_user_key UNIQUE (product_id, user_id);
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS tenant_id;
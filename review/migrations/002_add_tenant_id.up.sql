@@ -0,0 +1,15 @@
+-- Reviews belong to the tenant (store) the customer bought with; a customer
+-- reviews a product once in each store. Existing reviews belong to the
+-- default tenant. Rating aggregates stay per product, as they are pushed to
+-- the catalog, which every tenant shares.
+ALTER TABLE reviews ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_product_user_key;
+ALTER TABLE reviews ADD CONSTRAINT reviews_tenant_product_user_key UNIQUE (tenant_id, product_id, user_id);
+
+DROP INDEX IF EXISTS idx_reviews_product_created;
+DROP INDEX IF EXISTS idx_reviews_product_rating;
+CREATE INDEX idx_reviews_tenant_product_created ON reviews(tenant_id, product_id, status, created_at DESC);
+CREATE INDEX idx_reviews_tenant_product_rating ON reviews(tenant_id, product_id, status, rating DESC);
+-- The aggregate of a product is computed over the reviews of every tenant
+CREATE INDEX idx_reviews_product_status ON reviews(product_id, status);
@@ -0,0 +1,63 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+)
+
+// ErrNotPurchased is returned when a customer has no order for a product
+var ErrNotPurchased = errors.New("product not purchased")
+
+// maxOrderPages bounds how far back a customer's orders are searched for a
+// purchase, in pages of the order service's largest page size
+const (
+	maxOrderPages = 10
+	orderPageSize = 100
+)
+
+// purchasedStatuses are the order statuses that count as a purchase; PENDING
+// orders are unpaid and CANCELLED ones were never fulfilled
+var purchasedStatuses = map[string]bool{"PAID": true, "FULFILLED": true, "DELIVERED": true}
+
+// Orders verifies purchases against the order service
+type Orders interface {
+	// FindPurchase returns the ID of the customer's most recent paid order
+	// that contains the product, or ErrNotPurchased
+	FindPurchase(ctx context.Context, userID, productID string) (string, error)
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+// FindPurchase searches the customer's orders, newest first
+func (o *grpcOrders) FindPurchase(ctx context.Context, userID, productID string) (string, error) {
+	for page := int32(1); page <= maxOrderPages; page++ {
+		resp, err := o.client.ListOrders(ctx, &orderpb.ListOrdersRequest{UserId: userID, Page: page, PageSize: orderPageSize})
+		if err != nil {
+			return "", fmt.Errorf("failed to list orders: %w", err)
+		}
+		for _, order := range resp.Orders {
+			if !purchasedStatuses[order.Status] {
+				continue
+			}
+			for _, item := range order.Items {
+				if item.ProductId == productID {
+					return order.Id, nil
+				}
+			}
+		}
+		if page*orderPageSize >= resp.Total {
+			break
+		}
+	}
+	return "", ErrNotPurchased
+}
@@ -0,0 +1,71 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// publishBatchSize is how many unpublished aggregates are pushed per pass
+const publishBatchSize = 100
+
+// AggregatePublisher pushes recomputed rating aggregates to the catalog.
+// Aggregates are stored unpublished when they are recomputed, so one the
+// catalog did not take is pushed again on the next pass.
+type AggregatePublisher struct {
+	repo    Repository
+	catalog Catalog
+	log     *logger.Logger
+	now     func() time.Time
+}
+
+// NewAggregatePublisher creates an aggregate publisher
+func NewAggregatePublisher(repo Repository, catalog Catalog, log *logger.Logger) *AggregatePublisher {
+	return &AggregatePublisher{repo: repo, catalog: catalog, log: log, now: time.Now}
+}
+
+// Publish pushes an aggregate to the catalog and marks it published
+func (p *AggregatePublisher) Publish(ctx context.Context, agg *Aggregate) error {
+	if err := p.catalog.UpdateRatingAggregate(ctx, agg); err != nil {
+		p.log.Warn(ctx, "Failed to push rating aggregate", map[string]interface{}{"error": err.Error(), "product_id": agg.ProductID})
+		return err
+	}
+	if err := p.repo.MarkPublished(ctx, agg, p.now()); err != nil {
+		p.log.Error(ctx, "Failed to mark rating aggregate published", map[string]interface{}{"error": err.Error(), "product_id": agg.ProductID})
+		return err
+	}
+	return nil
+}
+
+// PublishPending pushes aggregates that are not yet published, oldest first,
+// and returns how many were published. It stops at the first failure.
+func (p *AggregatePublisher) PublishPending(ctx context.Context) (int, error) {
+	aggregates, err := p.repo.ListUnpublishedAggregates(ctx, publishBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i, agg := range aggregates {
+		if err := p.Publish(ctx, agg); err != nil {
+			return i, err
+		}
+	}
+	return len(aggregates), nil
+}
+
+// Run publishes pending aggregates every interval until ctx is cancelled
+func (p *AggregatePublisher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := p.PublishPending(ctx); err != nil {
+				p.log.Error(ctx, "Failed to publish rating aggregates", map[string]interface{}{"error": err.Error(), "published": n})
+			}
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/google/uuid"
+)
+
+// Moderation statuses of reviews
+const (
+	StatusPending  = "PENDING"
+	StatusApproved = "APPROVED"
+	StatusRejected = "REJECTED"
+)
+
+// Review sort orders
+const (
+	SortNewest        = "NEWEST"
+	SortOldest        = "OLDEST"
+	SortHighestRating = "HIGHEST_RATING"
+	SortLowestRating  = "LOWEST_RATING"
+)
+
+var (
+	// ErrReviewNotFound is returned when a review does not exist
+	ErrReviewNotFound = errors.New("review not found")
+	// ErrDuplicateReview is returned when a customer has already reviewed a product
+	ErrDuplicateReview = errors.New("product already reviewed by this customer")
+)
+
+// productUserConstraint is the unique constraint on reviews(tenant_id,
+// product_id, user_id)
+const productUserConstraint = "reviews_tenant_product_user_key"
+
+// Review is a customer's review of a product they bought
+type Review struct {
+	ID             string
+	TenantID       string
+	ProductID      string
+	UserID         string
+	OrderID        string
+	Rating         int32
+	Title          string
+	Body           string
+	Status         string
+	ModerationNote string
+	ModeratedBy    string
+	ModeratedAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// Aggregate is the rating of a product's approved reviews in every tenant
+type Aggregate struct {
+	ProductID     string
+	AverageRating float64
+	ReviewCount   int32
+	// ComputedAt is when the aggregate was computed; the catalog ignores
+	// aggregates older than the one it has
+	ComputedAt time.Time
+}
+
+// ReviewFilter selects the reviews to list
+type ReviewFilter struct {
+	ProductID string
+	Status    string
+	SortBy    string
+}
+
+// Repository handles review data persistence. Reviews are scoped to the
+// tenant of the request context; a review of another tenant is not found.
+// Aggregates cover the reviews of every tenant, as the catalog they are
+// pushed to is shared by all tenants.
+type Repository interface {
+	// Create inserts a review, or returns ErrDuplicateReview
+	Create(ctx context.Context, review *Review) (*Review, error)
+	GetByID(ctx context.Context, id string) (*Review, error)
+	// Moderate sets a review's moderation status. When the change adds the
+	// review to or removes it from the product's approved reviews, the
+	// product's aggregate is recomputed, marked unpublished and returned;
+	// otherwise the returned aggregate is nil.
+	Moderate(ctx context.Context, id, status, note, actor string, at time.Time) (*Review, *Aggregate, error)
+	List(ctx context.Context, filter ReviewFilter, page, pageSize int32) ([]*Review, int32, error)
+	// GetAggregate returns a product's aggregate, which is zero for products
+	// without approved reviews
+	GetAggregate(ctx context.Context, productID string) (*Aggregate, error)
+	// ListUnpublishedAggregates returns up to limit aggregates not yet pushed
+	// to the catalog, oldest first
+	ListUnpublishedAggregates(ctx context.Context, limit int) ([]*Aggregate, error)
+	// MarkPublished records that an aggregate was pushed to the catalog,
+	// unless it has been recomputed since
+	MarkPublished(ctx context.Context, agg *Aggregate, at time.Time) error
+	// AnonymizeUser replaces userID on their reviews with pseudonym and
+	// removes the titles and bodies, returning the number of reviews changed
+	AnonymizeUser(ctx context.Context, userID, pseudonym string) (int32, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL review repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const reviewColumns = `id, tenant_id, product_id, user_id, order_id, rating, title, body, status, moderation_note,
+	moderated_by, moderated_at, created_at`
+
+// sortOrders maps a sort order to its ORDER BY clause
+var sortOrders = map[string]string{
+	SortNewest:        "created_at DESC, id",
+	SortOldest:        "created_at, id",
+	SortHighestRating: "rating DESC, created_at DESC, id",
+	SortLowestRating:  "rating, created_at DESC, id",
+}
+
+// Create inserts a review
+func (r *postgresRepository) Create(ctx context.Context, review *Review) (*Review, error) {
+	review.ID = uuid.New().String()
+	review.TenantID = tenant.FromContext(ctx)
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO reviews (id, tenant_id, product_id, user_id, order_id, rating, title, body, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, review.ID, review.TenantID, review.ProductID, review.UserID, review.OrderID, review.Rating, review.Title, review.Body, review.Status, review.CreatedAt)
+	if pgerr.IsUniqueViolation(err, productUserConstraint) {
+		return nil, ErrDuplicateReview
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to create review", map[string]interface{}{"error": err.Error(), "product_id": review.ProductID})
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	r.log.Info(ctx, "Review created", map[string]interface{}{"review_id": review.ID, "product_id": review.ProductID})
+	return review, nil
+}
+
+// GetByID retrieves a review
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Review, error) {
+	query := "SELECT " + reviewColumns + " FROM reviews WHERE id = $1 AND tenant_id = $2"
+
+	review, err := scanReview(r.db.QueryRowContext(ctx, query, id, tenant.FromContext(ctx)))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get review", map[string]interface{}{"error": err.Error(), "review_id": id})
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return review, nil
+}
+
+// Moderate sets a review's moderation status and recomputes its product's
+// aggregate in one transaction
+func (r *postgresRepository) Moderate(ctx context.Context, id, status, note, actor string, at time.Time) (*Review, *Aggregate, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous, productID string
+	err = tx.QueryRowContext(ctx, "SELECT status, product_id FROM reviews WHERE id = $1 AND tenant_id = $2 FOR UPDATE", id, tenant.FromContext(ctx)).Scan(&previous, &productID)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lock review: %w", err)
+	}
+
+	recompute := previous == StatusApproved || status == StatusApproved
+	if recompute {
+		// Moderations of the same product's reviews recompute its aggregate
+		// one at a time, so each sees the others' changes
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", productID); err != nil {
+			return nil, nil, fmt.Errorf("failed to lock product aggregate: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE reviews
+		SET status = $2, moderation_note = $3, moderated_by = $4, moderated_at = $5
+		WHERE id = $1
+		RETURNING ` + reviewColumns
+
+	review, err := scanReview(tx.QueryRowContext(ctx, query, id, status, note, actor, at))
+	if err != nil {
+		r.log.Error(ctx, "Failed to moderate review", map[string]interface{}{"error": err.Error(), "review_id": id})
+		return nil, nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	var agg *Aggregate
+	if recompute {
+		// computed_at only moves forward, so the catalog never takes a
+		// recomputed aggregate for a stale one
+		agg = &Aggregate{}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO rating_aggregates (product_id, average_rating, review_count, computed_at)
+			SELECT $1, COALESCE(ROUND(AVG(rating), 2), 0), COUNT(*), $2
+			FROM reviews
+			WHERE product_id = $1 AND status = 'APPROVED'
+			ON CONFLICT (product_id) DO UPDATE
+			SET average_rating = EXCLUDED.average_rating, review_count = EXCLUDED.review_count,
+				computed_at = GREATEST(EXCLUDED.computed_at, rating_aggregates.computed_at + INTERVAL '1 microsecond'),
+				published_at = NULL
+			RETURNING product_id, average_rating, review_count, computed_at
+		`, review.ProductID, at).Scan(&agg.ProductID, &agg.AverageRating, &agg.ReviewCount, &agg.ComputedAt)
+		if err != nil {
+			r.log.Error(ctx, "Failed to recompute rating aggregate", map[string]interface{}{"error": err.Error(), "product_id": review.ProductID})
+			return nil, nil, fmt.Errorf("failed to recompute rating aggregate: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Review moderated", map[string]interface{}{"review_id": id, "from": previous, "to": status, "actor": actor})
+	return review, agg, nil
+}
+
+// List retrieves reviews with pagination
+func (r *postgresRepository) List(ctx context.Context, filter ReviewFilter, page, pageSize int32) ([]*Review, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	orderBy, ok := sortOrders[filter.SortBy]
+	if !ok {
+		orderBy = sortOrders[SortNewest]
+	}
+
+	offset := (page - 1) * pageSize
+	tenantID := tenant.FromContext(ctx)
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM reviews WHERE tenant_id = $3 AND product_id = $1 AND status = $2", filter.ProductID, filter.Status, tenantID).Scan(&total)
+	if pgerr.IsMalformedID(err) {
+		return []*Review{}, 0, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to count reviews", map[string]interface{}{"error": err.Error(), "product_id": filter.ProductID})
+		return nil, 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews
+		WHERE tenant_id = $3 AND product_id = $1 AND status = $2
+		ORDER BY ` + orderBy + `
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.ProductID, filter.Status, tenantID, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list reviews", map[string]interface{}{"error": err.Error(), "product_id": filter.ProductID})
+		return nil, 0, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := make([]*Review, 0, pageSize)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan review", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating reviews", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating reviews: %w", err)
+	}
+
+	return reviews, total, nil
+}
+
+// GetAggregate retrieves a product's aggregate
+func (r *postgresRepository) GetAggregate(ctx context.Context, productID string) (*Aggregate, error) {
+	agg := &Aggregate{ProductID: productID}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT average_rating, review_count, computed_at FROM rating_aggregates WHERE product_id = $1
+	`, productID).Scan(&agg.AverageRating, &agg.ReviewCount, &agg.ComputedAt)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return agg, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get rating aggregate", map[string]interface{}{"error": err.Error(), "product_id": productID})
+		return nil, fmt.Errorf("failed to get rating aggregate: %w", err)
+	}
+	return agg, nil
+}
+
+// ListUnpublishedAggregates retrieves aggregates not yet pushed to the catalog
+func (r *postgresRepository) ListUnpublishedAggregates(ctx context.Context, limit int) ([]*Aggregate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT product_id, average_rating, review_count, computed_at
+		FROM rating_aggregates
+		WHERE published_at IS NULL
+		ORDER BY computed_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unpublished aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	var aggregates []*Aggregate
+	for rows.Next() {
+		agg := &Aggregate{}
+		if err := rows.Scan(&agg.ProductID, &agg.AverageRating, &agg.ReviewCount, &agg.ComputedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan aggregate: %w", err)
+		}
+		aggregates = append(aggregates, agg)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating aggregates: %w", err)
+	}
+	return aggregates, nil
+}
+
+// MarkPublished sets published_at on an aggregate that has not been
+// recomputed since it was read
+func (r *postgresRepository) MarkPublished(ctx context.Context, agg *Aggregate, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE rating_aggregates SET published_at = $3
+		WHERE product_id = $1 AND computed_at = $2
+	`, agg.ProductID, agg.ComputedAt, at)
+	if err != nil {
+		return fmt.Errorf("failed to mark aggregate published: %w", err)
+	}
+	return nil
+}
+
+// AnonymizeUser anonymizes a customer's reviews. Ratings and statuses are
+// kept, so the aggregates stay as they are.
+func (r *postgresRepository) AnonymizeUser(ctx context.Context, userID, pseudonym string) (int32, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE reviews SET user_id = $2, title = '', body = ''
+		WHERE user_id = $1 AND tenant_id = $3
+	`, userID, pseudonym, tenant.FromContext(ctx))
+	if err != nil {
+		r.log.Error(ctx, "Failed to anonymize reviews", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return 0, fmt.Errorf("failed to anonymize reviews: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize reviews: %w", err)
+	}
+
+	r.log.Info(ctx, "Reviews anonymized", map[string]interface{}{"user_id": userID, "reviews": rows})
+	return int32(rows), nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReview scans a review selected with reviewColumns
+func scanReview(row rowScanner) (*Review, error) {
+	r := &Review{}
+	var moderatedBy sql.NullString
+	var moderatedAt sql.NullTime
+
+	err := row.Scan(&r.ID, &r.TenantID, &r.ProductID, &r.UserID, &r.OrderID, &r.Rating, &r.Title, &r.Body, &r.Status, &r.ModerationNote,
+		&moderatedBy, &moderatedAt, &r.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	r.ModeratedBy = moderatedBy.String
+	if moderatedAt.Valid {
+		r.ModeratedAt = &moderatedAt.Time
+	}
+	return r, nil
+}
@@ -0,0 +1,169 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/tenant"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("review-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var reviewColumnNames = []string{
+	"id", "tenant_id", "product_id", "user_id", "order_id", "rating", "title", "body", "status", "moderation_note",
+	"moderated_by", "moderated_at", "created_at",
+}
+
+func TestCreate_Duplicate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO reviews`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: productUserConstraint})
+
+	_, err := repo.Create(context.Background(), &Review{ProductID: "product-1", UserID: "user-1", Rating: 4, Status: StatusPending})
+	if err != ErrDuplicateReview {
+		t.Errorf("Expected ErrDuplicateReview, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM reviews WHERE id = \$1 AND tenant_id = \$2`).
+		WithArgs("not-a-uuid", tenant.Default).
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	if _, err := repo.GetByID(context.Background(), "not-a-uuid"); err != ErrReviewNotFound {
+		t.Errorf("Expected ErrReviewNotFound, got %v", err)
+	}
+}
+
+func TestModerate_RecomputesAggregate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, product_id FROM reviews WHERE id = \$1 AND tenant_id = \$2 FOR UPDATE`).
+		WithArgs("review-1", tenant.Default).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "product_id"}).AddRow(StatusPending, "product-1"))
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\(\$1\)\)`).
+		WithArgs("product-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`UPDATE reviews\s+SET status = \$2`).
+		WithArgs("review-1", StatusApproved, "", "admin-1", now).
+		WillReturnRows(sqlmock.NewRows(reviewColumnNames).
+			AddRow("review-1", tenant.Default, "product-1", "user-1", "order-1", 4, "Great", "Works well", StatusApproved, "", "admin-1", now, now))
+	mock.ExpectQuery(`INSERT INTO rating_aggregates`).
+		WithArgs("product-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "average_rating", "review_count", "computed_at"}).
+			AddRow("product-1", 4.5, 2, now))
+	mock.ExpectCommit()
+
+	review, agg, err := repo.Moderate(context.Background(), "review-1", StatusApproved, "", "admin-1", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if review.Status != StatusApproved || review.ModeratedBy != "admin-1" {
+		t.Errorf("Unexpected review %+v", review)
+	}
+	if agg == nil || agg.AverageRating != 4.5 || agg.ReviewCount != 2 {
+		t.Errorf("Unexpected aggregate %+v", agg)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestModerate_RejectingPendingReview(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, product_id FROM reviews WHERE id = \$1 AND tenant_id = \$2 FOR UPDATE`).
+		WithArgs("review-1", tenant.Default).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "product_id"}).AddRow(StatusPending, "product-1"))
+	mock.ExpectQuery(`UPDATE reviews\s+SET status = \$2`).
+		WithArgs("review-1", StatusRejected, "spam", "admin-1", now).
+		WillReturnRows(sqlmock.NewRows(reviewColumnNames).
+			AddRow("review-1", tenant.Default, "product-1", "user-1", "order-1", 1, "", "", StatusRejected, "spam", "admin-1", now, now))
+	mock.ExpectCommit()
+
+	_, agg, err := repo.Moderate(context.Background(), "review-1", StatusRejected, "spam", "admin-1", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if agg != nil {
+		t.Errorf("Expected no aggregate, got %+v", agg)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reviews WHERE tenant_id = \$3 AND product_id = \$1 AND status = \$2`).
+		WithArgs("product-1", StatusApproved, "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`ORDER BY rating DESC, created_at DESC, id\s+LIMIT \$4 OFFSET \$5`).
+		WithArgs("product-1", StatusApproved, "acme", int32(20), int32(20)).
+		WillReturnRows(sqlmock.NewRows(reviewColumnNames).
+			AddRow("review-1", "acme", "product-1", "user-1", "order-1", 5, "", "", StatusApproved, "", nil, nil, now))
+
+	ctx := tenant.NewContext(context.Background(), "acme")
+	reviews, total, err := repo.List(ctx, ReviewFilter{ProductID: "product-1", Status: StatusApproved, SortBy: SortHighestRating}, 2, 20)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 1 || len(reviews) != 1 || reviews[0].ModeratedAt != nil {
+		t.Errorf("Unexpected reviews %v (total %d)", reviews, total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`UPDATE reviews SET user_id = \$2, title = '', body = ''\s+WHERE user_id = \$1 AND tenant_id = \$3`).
+		WithArgs("user-1", "erased-1", tenant.Default).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	records, err := repo.AnonymizeUser(context.Background(), "user-1", "erased-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if records != 2 {
+		t.Errorf("Expected 2 reviews, got %d", records)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/review/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Length limits of reviews, in characters
+const (
+	maxTitleLength = 150
+	maxBodyLength  = 5000
+	maxNoteLength  = 1000
+)
+
+// maxUserIDLength matches the user_id column of reviews
+const maxUserIDLength = 255
+
+// Bounds of a review's rating
+const (
+	minRating = 1
+	maxRating = 5
+)
+
+const (
+	// actorMetadataKey is the gRPC metadata key carrying the calling user's ID
+	actorMetadataKey = "x-user-id"
+	// systemActor is recorded when a change is made without a calling user
+	systemActor = "system"
+)
+
+// AdminMethods are the admin-scoped RPCs of the review service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.ReviewService_ModerateReview_FullMethodName,
+	pb.ReviewService_EraseUserData_FullMethodName,
+}
+
+// MethodRoles maps the RPCs needing a token to the roles allowed to call
+// them: EraseUserData is called by the privacy service, with a SERVICE token
+func MethodRoles() map[string][]string {
+	return map[string][]string{
+		pb.ReviewService_EraseUserData_FullMethodName: {auth.RoleService},
+	}
+}
+
+// Service implements the ReviewService gRPC interface
+type Service struct {
+	pb.UnimplementedReviewServiceServer
+	repo      Repository
+	orders    Orders
+	publisher *AggregatePublisher
+	log       *logger.Logger
+	now       func() time.Time
+}
+
+// NewService creates a new review service
+func NewService(repo Repository, orders Orders, publisher *AggregatePublisher, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		orders:    orders,
+		publisher: publisher,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// SubmitReview records a customer's review of a product they bought. The
+// review is PENDING until a moderator approves it.
+func (s *Service) SubmitReview(ctx context.Context, req *pb.SubmitReviewRequest) (*pb.SubmitReviewResponse, error) {
+	title, body := strings.TrimSpace(req.Title), strings.TrimSpace(req.Body)
+	if msg := reviewProblem(req.ProductId, req.UserId, req.Rating, title, body); msg != "" {
+		s.log.Warn(ctx, "Submit review failed: "+msg, map[string]interface{}{"product_id": req.ProductId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	orderID, err := s.orders.FindPurchase(ctx, req.UserId, req.ProductId)
+	if errors.Is(err, ErrNotPurchased) {
+		s.log.Warn(ctx, "Submit review failed: product not purchased", map[string]interface{}{"product_id": req.ProductId, "user_id": req.UserId})
+		return nil, status.Error(codes.FailedPrecondition, "only customers who bought the product can review it")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to verify purchase", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId, "user_id": req.UserId})
+		return nil, status.Error(codes.Unavailable, "failed to verify purchase")
+	}
+
+	review, err := s.repo.Create(ctx, &Review{
+		ProductID: req.ProductId,
+		UserID:    req.UserId,
+		OrderID:   orderID,
+		Rating:    req.Rating,
+		Title:     title,
+		Body:      body,
+		Status:    StatusPending,
+		CreatedAt: s.now(),
+	})
+	if errors.Is(err, ErrDuplicateReview) {
+		s.log.Warn(ctx, "Submit review failed: already reviewed", map[string]interface{}{"product_id": req.ProductId, "user_id": req.UserId})
+		return nil, status.Error(codes.AlreadyExists, "product already reviewed")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to create review", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to create review")
+	}
+
+	return &pb.SubmitReviewResponse{
+		Review: toProtoReview(review),
+	}, nil
+}
+
+// GetReview retrieves a review by ID
+func (s *Service) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	if req.ReviewId == "" {
+		s.log.Warn(ctx, "Get review failed: review ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "review_id is required")
+	}
+
+	review, err := s.repo.GetByID(ctx, req.ReviewId)
+	if err != nil {
+		return nil, s.reviewError(ctx, err, req.ReviewId)
+	}
+
+	return &pb.GetReviewResponse{
+		Review: toProtoReview(review),
+	}, nil
+}
+
+// ModerateReview approves or rejects a review. When the product's approved
+// reviews change, its new rating is pushed to the catalog; a push that fails
+// is retried by the aggregate publisher.
+func (s *Service) ModerateReview(ctx context.Context, req *pb.ModerateReviewRequest) (*pb.ModerateReviewResponse, error) {
+	if req.ReviewId == "" {
+		s.log.Warn(ctx, "Moderate review failed: review ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "review_id is required")
+	}
+	if req.Status != StatusApproved && req.Status != StatusRejected {
+		return nil, status.Error(codes.InvalidArgument, "status must be APPROVED or REJECTED")
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, status.Errorf(codes.InvalidArgument, "note cannot exceed %d characters", maxNoteLength)
+	}
+
+	review, agg, err := s.repo.Moderate(ctx, req.ReviewId, req.Status, note, actorFromContext(ctx), s.now())
+	if err != nil {
+		return nil, s.reviewError(ctx, err, req.ReviewId)
+	}
+
+	if agg != nil {
+		// The moderation is done; a failed push is left to the publisher
+		_ = s.publisher.Publish(ctx, agg)
+	}
+
+	return &pb.ModerateReviewResponse{
+		Review: toProtoReview(review),
+	}, nil
+}
+
+// ListReviews lists a product's reviews with its rating. Without a status it
+// lists approved reviews; with one it is a moderation queue.
+func (s *Service) ListReviews(ctx context.Context, req *pb.ListReviewsRequest) (*pb.ListReviewsResponse, error) {
+	if req.ProductId == "" {
+		s.log.Warn(ctx, "List reviews failed: product ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	reviewStatus := StatusApproved
+	if req.Status != "" {
+		if req.Status != StatusPending && req.Status != StatusApproved && req.Status != StatusRejected {
+			return nil, status.Error(codes.InvalidArgument, "status must be PENDING, APPROVED or REJECTED")
+		}
+		reviewStatus = req.Status
+	}
+
+	sortBy := req.SortBy
+	if sortBy == "" {
+		sortBy = SortNewest
+	}
+	if _, ok := sortOrders[sortBy]; !ok {
+		return nil, status.Error(codes.InvalidArgument, "sort_by must be NEWEST, OLDEST, HIGHEST_RATING or LOWEST_RATING")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	reviews, total, err := s.repo.List(ctx, ReviewFilter{ProductID: req.ProductId, Status: reviewStatus, SortBy: sortBy}, page, pageSize)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list reviews", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to list reviews")
+	}
+
+	agg, err := s.repo.GetAggregate(ctx, req.ProductId)
+	if err != nil {
+		s.log.Error(ctx, "Failed to get rating aggregate", map[string]interface{}{"error": err.Error(), "product_id": req.ProductId})
+		return nil, status.Error(codes.Internal, "failed to get rating")
+	}
+
+	protoReviews := make([]*pb.Review, len(reviews))
+	for i, r := range reviews {
+		protoReviews[i] = toProtoReview(r)
+	}
+
+	return &pb.ListReviewsResponse{
+		Reviews:       protoReviews,
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+		AverageRating: agg.AverageRating,
+		ReviewCount:   agg.ReviewCount,
+	}, nil
+}
+
+// EraseUserData anonymizes a customer's reviews for the privacy service when
+// their account is deleted. Erasing a customer again changes nothing.
+func (s *Service) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	switch {
+	case req.UserId == "":
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	case req.Pseudonym == "" || req.Pseudonym == req.UserId:
+		return nil, status.Error(codes.InvalidArgument, "pseudonym is required and must differ from user_id")
+	case len(req.Pseudonym) > maxUserIDLength:
+		return nil, status.Error(codes.InvalidArgument, "pseudonym is too long")
+	}
+
+	records, err := s.repo.AnonymizeUser(ctx, req.UserId, req.Pseudonym)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to erase user data")
+	}
+	return &pb.EraseUserDataResponse{
+		Records: records,
+	}, nil
+}
+
+// reviewProblem validates a review submission and returns a message
+// describing the first problem, or ""
+func reviewProblem(productID, userID string, rating int32, title, body string) string {
+	switch {
+	case productID == "":
+		return "product_id is required"
+	case userID == "":
+		return "user_id is required"
+	case len(userID) > maxUserIDLength:
+		return "user_id is too long"
+	case rating < minRating || rating > maxRating:
+		return fmt.Sprintf("rating must be between %d and %d", minRating, maxRating)
+	case utf8.RuneCountInString(title) > maxTitleLength:
+		return fmt.Sprintf("title cannot exceed %d characters", maxTitleLength)
+	case utf8.RuneCountInString(body) > maxBodyLength:
+		return fmt.Sprintf("body cannot exceed %d characters", maxBodyLength)
+	}
+	return ""
+}
+
+// reviewError maps a review lookup or moderation failure to a gRPC error
+func (s *Service) reviewError(ctx context.Context, err error, reviewID string) error {
+	if errors.Is(err, ErrReviewNotFound) {
+		s.log.Warn(ctx, "Review not found", map[string]interface{}{"review_id": reviewID})
+		return status.Error(codes.NotFound, "review not found")
+	}
+	s.log.Error(ctx, "Failed to get review", map[string]interface{}{"error": err.Error(), "review_id": reviewID})
+	return status.Error(codes.Internal, "failed to get review")
+}
+
+// actorFromContext returns the user ID forwarded by the gateway, or systemActor
+func actorFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return systemActor
+	}
+	for _, v := range md.Get(actorMetadataKey) {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return systemActor
+}
+
+// toProtoReview converts a review to protobuf
+func toProtoReview(r *Review) *pb.Review {
+	review := &pb.Review{
+		Id:             r.ID,
+		ProductId:      r.ProductID,
+		UserId:         r.UserID,
+		OrderId:        r.OrderID,
+		Rating:         r.Rating,
+		Title:          r.Title,
+		Body:           r.Body,
+		Status:         r.Status,
+		ModerationNote: r.ModerationNote,
+		ModeratedBy:    r.ModeratedBy,
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+	}
+	if r.ModeratedAt != nil {
+		review.ModeratedAt = timestamppb.New(*r.ModeratedAt)
+	}
+	return review
+}
@@ -0,0 +1,498 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/review/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// storedAggregate is an aggregate and whether it has been published
+type storedAggregate struct {
+	Aggregate
+	published bool
+}
+
+// memoryRepository keeps reviews and recomputes a product's aggregate when a
+// moderation approves a review or rejects an approved one
+type memoryRepository struct {
+	mu         sync.Mutex
+	reviews    map[string]Review
+	aggregates map[string]storedAggregate
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{reviews: map[string]Review{}, aggregates: map[string]storedAggregate{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, review *Review) (*Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.reviews {
+		if r.ProductID == review.ProductID && r.UserID == review.UserID {
+			return nil, ErrDuplicateReview
+		}
+	}
+	review.ID = uuid.New().String()
+	m.reviews[review.ID] = *review
+	return review, nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.reviews[id]
+	if !ok {
+		return nil, ErrReviewNotFound
+	}
+	return &r, nil
+}
+
+func (m *memoryRepository) Moderate(ctx context.Context, id, status, note, actor string, at time.Time) (*Review, *Aggregate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.reviews[id]
+	if !ok {
+		return nil, nil, ErrReviewNotFound
+	}
+	previous := r.Status
+	r.Status, r.ModerationNote, r.ModeratedBy, r.ModeratedAt = status, note, actor, &at
+	m.reviews[id] = r
+	if previous != StatusApproved && status != StatusApproved {
+		return &r, nil, nil
+	}
+
+	agg := Aggregate{ProductID: r.ProductID, ComputedAt: at}
+	var sum int32
+	for _, other := range m.reviews {
+		if other.ProductID == r.ProductID && other.Status == StatusApproved {
+			sum += other.Rating
+			agg.ReviewCount++
+		}
+	}
+	if agg.ReviewCount > 0 {
+		agg.AverageRating = math.Round(float64(sum)/float64(agg.ReviewCount)*100) / 100
+	}
+	if stored, ok := m.aggregates[r.ProductID]; ok && !agg.ComputedAt.After(stored.ComputedAt) {
+		agg.ComputedAt = stored.ComputedAt.Add(time.Microsecond)
+	}
+	m.aggregates[r.ProductID] = storedAggregate{Aggregate: agg}
+	return &r, &agg, nil
+}
+
+func (m *memoryRepository) List(ctx context.Context, filter ReviewFilter, page, pageSize int32) ([]*Review, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var reviews []*Review
+	for _, r := range m.reviews {
+		if r.ProductID == filter.ProductID && r.Status == filter.Status {
+			reviews = append(reviews, &r)
+		}
+	}
+	sort.SliceStable(reviews, func(i, j int) bool {
+		a, b := reviews[i], reviews[j]
+		switch filter.SortBy {
+		case SortOldest:
+			return a.CreatedAt.Before(b.CreatedAt)
+		case SortHighestRating:
+			return a.Rating > b.Rating || a.Rating == b.Rating && a.CreatedAt.After(b.CreatedAt)
+		case SortLowestRating:
+			return a.Rating < b.Rating || a.Rating == b.Rating && a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	total := int32(len(reviews))
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	return reviews[start:end], total, nil
+}
+
+func (m *memoryRepository) GetAggregate(ctx context.Context, productID string) (*Aggregate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored, ok := m.aggregates[productID]; ok {
+		agg := stored.Aggregate
+		return &agg, nil
+	}
+	return &Aggregate{ProductID: productID}, nil
+}
+
+func (m *memoryRepository) ListUnpublishedAggregates(ctx context.Context, limit int) ([]*Aggregate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var aggregates []*Aggregate
+	for _, stored := range m.aggregates {
+		if !stored.published {
+			agg := stored.Aggregate
+			aggregates = append(aggregates, &agg)
+		}
+	}
+	sort.Slice(aggregates, func(i, j int) bool { return aggregates[i].ComputedAt.Before(aggregates[j].ComputedAt) })
+	if len(aggregates) > limit {
+		aggregates = aggregates[:limit]
+	}
+	return aggregates, nil
+}
+
+func (m *memoryRepository) MarkPublished(ctx context.Context, agg *Aggregate, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored, ok := m.aggregates[agg.ProductID]; ok && stored.ComputedAt.Equal(agg.ComputedAt) {
+		stored.published = true
+		m.aggregates[agg.ProductID] = stored
+	}
+	return nil
+}
+
+func (m *memoryRepository) AnonymizeUser(ctx context.Context, userID, pseudonym string) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var records int32
+	for id, r := range m.reviews {
+		if r.UserID == userID {
+			r.UserID, r.Title, r.Body = pseudonym, "", ""
+			m.reviews[id] = r
+			records++
+		}
+	}
+	return records, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeOrders holds the products each customer bought, keyed by user ID, and
+// fails lookups with err
+type fakeOrders struct {
+	purchases map[string][]string
+	err       error
+}
+
+func (o *fakeOrders) FindPurchase(ctx context.Context, userID, productID string) (string, error) {
+	if o.err != nil {
+		return "", o.err
+	}
+	for _, p := range o.purchases[userID] {
+		if p == productID {
+			return "order-" + userID, nil
+		}
+	}
+	return "", ErrNotPurchased
+}
+
+// fakeCatalog records the aggregates pushed to it and fails pushes with err
+type fakeCatalog struct {
+	mu         sync.Mutex
+	aggregates []Aggregate
+	err        error
+}
+
+func (c *fakeCatalog) UpdateRatingAggregate(ctx context.Context, agg *Aggregate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.aggregates = append(c.aggregates, *agg)
+	return nil
+}
+
+func (c *fakeCatalog) Aggregates() []Aggregate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Aggregate(nil), c.aggregates...)
+}
+
+func setupService(t *testing.T) (*Service, *memoryRepository, *fakeCatalog) {
+	repo := newMemoryRepository()
+	catalog := &fakeCatalog{}
+	orders := &fakeOrders{purchases: map[string][]string{
+		"user-1": {"product-1"},
+		"user-2": {"product-1"},
+		"user-3": {"product-1"},
+	}}
+	log := logger.New("review-test")
+	return NewService(repo, orders, NewAggregatePublisher(repo, catalog, log), log), repo, catalog
+}
+
+// submit submits a review of product-1 by userID and fails the test on error
+func submit(t *testing.T, service *Service, userID string, rating int32) *pb.Review {
+	resp, err := service.SubmitReview(context.Background(), &pb.SubmitReviewRequest{
+		ProductId: "product-1", UserId: userID, Rating: rating, Title: " Great ", Body: "Works well",
+	})
+	if err != nil {
+		t.Fatalf("Failed to submit review: %v", err)
+	}
+	return resp.Review
+}
+
+func moderate(t *testing.T, service *Service, reviewID, reviewStatus string) *pb.Review {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "admin-1"))
+	resp, err := service.ModerateReview(ctx, &pb.ModerateReviewRequest{ReviewId: reviewID, Status: reviewStatus})
+	if err != nil {
+		t.Fatalf("Failed to moderate review: %v", err)
+	}
+	return resp.Review
+}
+
+func TestSubmitReview(t *testing.T) {
+	service, _, _ := setupService(t)
+
+	review := submit(t, service, "user-1", 4)
+	if review.Status != StatusPending || review.OrderId != "order-user-1" || review.Title != "Great" {
+		t.Errorf("Unexpected review %+v", review)
+	}
+
+	_, err := service.SubmitReview(context.Background(), &pb.SubmitReviewRequest{ProductId: "product-1", UserId: "user-1", Rating: 5})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a second review, got %v", err)
+	}
+}
+
+func TestSubmitReview_Failures(t *testing.T) {
+	service, repo, _ := setupService(t)
+	long := make([]byte, maxTitleLength+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	tests := []struct {
+		name     string
+		req      *pb.SubmitReviewRequest
+		expected codes.Code
+	}{
+		{"missing product", &pb.SubmitReviewRequest{UserId: "user-1", Rating: 4}, codes.InvalidArgument},
+		{"missing user", &pb.SubmitReviewRequest{ProductId: "product-1", Rating: 4}, codes.InvalidArgument},
+		{"rating too low", &pb.SubmitReviewRequest{ProductId: "product-1", UserId: "user-1", Rating: 0}, codes.InvalidArgument},
+		{"rating too high", &pb.SubmitReviewRequest{ProductId: "product-1", UserId: "user-1", Rating: 6}, codes.InvalidArgument},
+		{"title too long", &pb.SubmitReviewRequest{ProductId: "product-1", UserId: "user-1", Rating: 4, Title: string(long)}, codes.InvalidArgument},
+		{"not purchased", &pb.SubmitReviewRequest{ProductId: "product-2", UserId: "user-1", Rating: 4}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.SubmitReview(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	service.orders.(*fakeOrders).err = errors.New("order service down")
+	_, err := service.SubmitReview(context.Background(), &pb.SubmitReviewRequest{ProductId: "product-1", UserId: "user-1", Rating: 4})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable when purchases cannot be verified, got %v", err)
+	}
+	if len(repo.reviews) != 0 {
+		t.Errorf("Expected no review to be recorded, got %d", len(repo.reviews))
+	}
+}
+
+func TestModerateReview_PublishesAggregate(t *testing.T) {
+	service, _, catalog := setupService(t)
+	first := submit(t, service, "user-1", 5)
+	second := submit(t, service, "user-2", 2)
+	third := submit(t, service, "user-3", 4)
+
+	approved := moderate(t, service, first.Id, StatusApproved)
+	if approved.ModeratedBy != "admin-1" || approved.ModeratedAt == nil {
+		t.Errorf("Expected the moderator to be recorded, got %+v", approved)
+	}
+	moderate(t, service, second.Id, StatusApproved)
+	// Rejecting a pending review leaves the rating alone
+	moderate(t, service, third.Id, StatusRejected)
+	moderate(t, service, first.Id, StatusRejected)
+
+	aggregates := catalog.Aggregates()
+	if len(aggregates) != 3 {
+		t.Fatalf("Expected 3 aggregates pushed, got %v", aggregates)
+	}
+	expected := []struct {
+		average float64
+		count   int32
+	}{{5, 1}, {3.5, 2}, {2, 1}}
+	for i, e := range expected {
+		if aggregates[i].AverageRating != e.average || aggregates[i].ReviewCount != e.count {
+			t.Errorf("Aggregate %d: expected %v over %d reviews, got %+v", i+1, e.average, e.count, aggregates[i])
+		}
+		if i > 0 && !aggregates[i].ComputedAt.After(aggregates[i-1].ComputedAt) {
+			t.Errorf("Aggregate %d: expected a later computed_at than the one before", i+1)
+		}
+	}
+}
+
+func TestModerateReview_FailedPushIsRetried(t *testing.T) {
+	service, _, catalog := setupService(t)
+	review := submit(t, service, "user-1", 5)
+
+	catalog.err = errors.New("catalog down")
+	moderate(t, service, review.Id, StatusApproved)
+	if len(catalog.Aggregates()) != 0 {
+		t.Fatal("Expected no aggregate to reach the catalog")
+	}
+
+	catalog.err = nil
+	published, err := service.publisher.PublishPending(context.Background())
+	if err != nil || published != 1 {
+		t.Fatalf("Expected 1 aggregate published, got %d (%v)", published, err)
+	}
+	published, _ = service.publisher.PublishPending(context.Background())
+	if published != 0 {
+		t.Errorf("Expected nothing left to publish, got %d", published)
+	}
+	if aggregates := catalog.Aggregates(); len(aggregates) != 1 || aggregates[0].ReviewCount != 1 {
+		t.Errorf("Expected the aggregate to be pushed once, got %v", aggregates)
+	}
+}
+
+func TestModerateReview_Invalid(t *testing.T) {
+	service, _, _ := setupService(t)
+	review := submit(t, service, "user-1", 5)
+
+	tests := []struct {
+		name     string
+		req      *pb.ModerateReviewRequest
+		expected codes.Code
+	}{
+		{"missing review", &pb.ModerateReviewRequest{Status: StatusApproved}, codes.InvalidArgument},
+		{"pending status", &pb.ModerateReviewRequest{ReviewId: review.Id, Status: StatusPending}, codes.InvalidArgument},
+		{"unknown review", &pb.ModerateReviewRequest{ReviewId: "missing", Status: StatusApproved}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.ModerateReview(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestListReviews(t *testing.T) {
+	service, repo, _ := setupService(t)
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i, rating := range []int32{3, 5, 4} {
+		service.now = func() time.Time { return base.Add(time.Duration(i) * time.Hour) }
+		review := submit(t, service, []string{"user-1", "user-2", "user-3"}[i], rating)
+		if rating != 4 {
+			moderate(t, service, review.Id, StatusApproved)
+		}
+	}
+
+	resp, err := service.ListReviews(context.Background(), &pb.ListReviewsRequest{ProductId: "product-1", SortBy: SortHighestRating})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || len(resp.Reviews) != 2 || resp.Reviews[0].Rating != 5 || resp.Reviews[1].Rating != 3 {
+		t.Errorf("Expected the approved reviews best first, got %v", resp.Reviews)
+	}
+	if resp.AverageRating != 4 || resp.ReviewCount != 2 || resp.PageSize != 10 {
+		t.Errorf("Unexpected rating %v over %d reviews, page size %d", resp.AverageRating, resp.ReviewCount, resp.PageSize)
+	}
+
+	resp, err = service.ListReviews(context.Background(), &pb.ListReviewsRequest{ProductId: "product-1", Status: StatusPending})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Reviews) != 1 || resp.Reviews[0].Rating != 4 {
+		t.Errorf("Expected the pending review, got %v", resp.Reviews)
+	}
+
+	resp, _ = service.ListReviews(context.Background(), &pb.ListReviewsRequest{ProductId: "product-1", Page: 2, PageSize: 1})
+	if resp.Total != 2 || len(resp.Reviews) != 1 || resp.Reviews[0].UserId != "user-1" {
+		t.Errorf("Expected the older approved review on page 2, got %v", resp.Reviews)
+	}
+	if len(repo.reviews) != 3 {
+		t.Errorf("Expected 3 reviews, got %d", len(repo.reviews))
+	}
+}
+
+func TestListReviews_Invalid(t *testing.T) {
+	service, _, _ := setupService(t)
+
+	tests := []struct {
+		name string
+		req  *pb.ListReviewsRequest
+	}{
+		{"missing product", &pb.ListReviewsRequest{}},
+		{"unknown sort", &pb.ListReviewsRequest{ProductId: "product-1", SortBy: "HELPFUL"}},
+		{"unknown status", &pb.ListReviewsRequest{ProductId: "product-1", Status: "HIDDEN"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.ListReviews(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEraseUserData(t *testing.T) {
+	service, repo, _ := setupService(t)
+	approved := moderate(t, service, submit(t, service, "user-1", 5).Id, StatusApproved)
+	submit(t, service, "user-2", 3)
+
+	resp, err := service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{UserId: "user-1", Pseudonym: "erased-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Records != 1 {
+		t.Errorf("Expected 1 review anonymized, got %d", resp.Records)
+	}
+
+	got := repo.reviews[approved.Id]
+	if got.UserID != "erased-1" || got.Title != "" || got.Body != "" || got.Rating != 5 || got.Status != StatusApproved {
+		t.Errorf("Unexpected anonymized review %+v", got)
+	}
+
+	// Erasing again finds nothing left of the customer
+	resp, err = service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{UserId: "user-1", Pseudonym: "erased-1"})
+	if err != nil || resp.Records != 0 {
+		t.Errorf("Expected nothing erased again, got %v, %v", resp, err)
+	}
+
+	if _, err := service.EraseUserData(context.Background(), &pb.EraseUserDataRequest{UserId: "user-2", Pseudonym: "user-2"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a pseudonym equal to the user, got %v", err)
+	}
+}
+
+func TestMethodRoles(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", 15*time.Minute, time.Hour)
+	interceptor := auth.NewInterceptor(tokens, auth.InterceptorConfig{ExemptMethods: []string{"/"}, RequiredRoles: MethodRoles()})
+	withToken := func(role string) context.Context {
+		token, _ := tokens.GenerateAccessToken("caller-1", "", role)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected codes.Code
+	}{
+		{"no token", context.Background(), codes.Unauthenticated},
+		{"customer", withToken(auth.RoleUser), codes.PermissionDenied},
+		{"admin", withToken(auth.RoleAdmin), codes.PermissionDenied},
+		{"service", withToken(auth.RoleService), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor.Authenticate(tt.ctx, pb.ReviewService_EraseUserData_FullMethodName)
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
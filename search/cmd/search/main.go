@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/ipfilter"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/Ujjwaljain16/E-commerce-Backend/search"
+	"github.com/Ujjwaljain16/E-commerce-Backend/search/pb"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	log := logger.New("search-service")
+	log.Info(ctx, "Starting Search Service", nil)
+
+	// Get configuration from environment
+	port := getEnv("PORT", "50062")
+	metricsPort := getEnv("METRICS_PORT", "9101")
+	catalogAddr := getEnv("CATALOG_ADDR", "localhost:50052")
+	resyncInterval := getEnvDuration("SEARCH_RESYNC_INTERVAL", search.DefaultResyncInterval)
+
+	index, err := search.NewElasticsearchIndex(search.ElasticsearchConfig{
+		URL:      getEnv("ELASTICSEARCH_URL", "http://localhost:9200"),
+		Index:    getEnv("ELASTICSEARCH_INDEX", search.DefaultIndexName),
+		Username: os.Getenv("ELASTICSEARCH_USERNAME"),
+		Password: os.Getenv("ELASTICSEARCH_PASSWORD"),
+		Timeout:  getEnvDuration("ELASTICSEARCH_TIMEOUT", 0),
+	})
+	if err != nil {
+		log.Error(ctx, "Failed to configure Elasticsearch", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// The index is fed from the catalog's product export and change stream.
+	// Admin RPCs of the catalog need a SERVICE token: a catalog:read token of
+	// the indexer's service account from TOKEN_URL when CLIENT_ID is set, or
+	// one signed with JWT_SECRET.
+	serviceTokens := auth.ServiceTokensFromEnv("search-service")
+	if clientID := os.Getenv("CLIENT_ID"); clientID != "" {
+		serviceTokens = auth.NewClientCredentialsTokens(getEnv("TOKEN_URL", "http://localhost:9090/oauth2/token"), clientID, os.Getenv("CLIENT_SECRET"), "catalog:read")
+	}
+	catalogConn, err := grpc.NewClient(catalogAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(serviceTokens.UnaryClientInterceptor()), grpc.WithStreamInterceptor(serviceTokens.StreamClientInterceptor()))
+	if err != nil {
+		log.Error(ctx, "Failed to create catalog service client", map[string]interface{}{
+			"error": err.Error(),
+			"addr":  catalogAddr,
+		})
+		os.Exit(1)
+	}
+	defer catalogConn.Close()
+
+	// Keep the index in step with the catalog in the background
+	indexer := search.NewIndexer(catalogpb.NewCatalogServiceClient(catalogConn), index, resyncInterval, log)
+	indexerCtx, stopIndexer := context.WithCancel(ctx)
+	defer stopIndexer()
+	go indexer.Run(indexerCtx)
+
+	// Create service
+	service := search.NewService(index, indexer, log)
+
+	// IP filtering: admin network allowlist and the deny list shared through Redis
+	filterCtx, stopFilter := context.WithCancel(ctx)
+	defer stopFilter()
+	ipFilter, err := ipfilter.FromEnv(filterCtx, log, search.AdminMethods)
+	if err != nil {
+		log.Error(ctx, "Failed to set up IP filtering", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	// Create gRPC server with metrics and IP filter interceptors
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor("search-service"),
+			ipFilter.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterSearchServiceServer(grpcServer, service)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("search.SearchService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Enable reflection for grpcurl/grpcui
+	reflection.Register(grpcServer)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%s", metricsPort)
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Start gRPC server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Error(ctx, "Failed to listen", map[string]interface{}{
+			"error": err.Error(),
+			"port":  port,
+		})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "Search Service listening", map[string]interface{}{
+		"port":         port,
+		"metrics_port": metricsPort,
+	})
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		stopFilter()
+		stopIndexer()
+		grpcServer.GracefulStop()
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Error(ctx, "Failed to serve", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
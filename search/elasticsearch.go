@@ -0,0 +1,413 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultIndexName is the Elasticsearch index products are stored in
+	DefaultIndexName = "products"
+	// defaultTimeout is used for Elasticsearch requests when none is configured
+	defaultTimeout = 10 * time.Second
+	// maxResponseSize caps the Elasticsearch responses read
+	maxResponseSize = 16 << 20
+)
+
+// ElasticsearchConfig holds the settings of the Elasticsearch index
+type ElasticsearchConfig struct {
+	// URL is the base URL of the cluster, e.g. http://localhost:9200
+	URL string
+	// Index defaults to DefaultIndexName
+	Index    string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// ElasticsearchIndex stores products in an Elasticsearch index through its
+// REST API
+type ElasticsearchIndex struct {
+	cfg        ElasticsearchConfig
+	httpClient *http.Client
+}
+
+// NewElasticsearchIndex creates a new Elasticsearch index
+func NewElasticsearchIndex(cfg ElasticsearchConfig) (*ElasticsearchIndex, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("elasticsearch: URL is required")
+	}
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	if cfg.Index == "" {
+		cfg.Index = DefaultIndexName
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &ElasticsearchIndex{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// indexMappings maps the document fields. Names are full text with a keyword
+// copy, categories are exact keywords with a full text copy, and name_suggest
+// backs Suggest.
+const indexMappings = `{
+	"mappings": {
+		"properties": {
+			"product_id": {"type": "keyword"},
+			"name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+			"description": {"type": "text"},
+			"category": {"type": "keyword", "fields": {"text": {"type": "text"}}},
+			"sku": {"type": "keyword"},
+			"price": {"type": "double"},
+			"effective_price": {"type": "double"},
+			"on_sale": {"type": "boolean"},
+			"image": {"type": "keyword", "index": false},
+			"average_rating": {"type": "double"},
+			"review_count": {"type": "integer"},
+			"in_stock": {"type": "boolean"},
+			"created_at": {"type": "date"},
+			"synced_at": {"type": "date"},
+			"name_suggest": {"type": "completion"}
+		}
+	}
+}`
+
+// esDocument is a document with the inputs of its name suggestions
+type esDocument struct {
+	*Document
+	NameSuggest struct {
+		Input []string `json:"input"`
+	} `json:"name_suggest"`
+}
+
+type esError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+type esErrorResponse struct {
+	Error esError `json:"error"`
+}
+
+// Ensure creates the index with its mappings unless it exists
+func (x *ElasticsearchIndex) Ensure(ctx context.Context) error {
+	code, _, err := x.do(ctx, http.MethodHead, "/"+url.PathEscape(x.cfg.Index), "", nil)
+	if err != nil {
+		return err
+	}
+	if code == http.StatusOK {
+		return nil
+	}
+
+	code, body, err := x.do(ctx, http.MethodPut, "/"+url.PathEscape(x.cfg.Index), "application/json", []byte(indexMappings))
+	if err != nil {
+		return err
+	}
+	if code == http.StatusBadRequest && responseError(body).Type == "resource_already_exists_exception" {
+		// Another replica created it first
+		return nil
+	}
+	return checkStatus("create index", code, body)
+}
+
+// Put writes documents with the bulk API. Each write carries the catalog
+// version as an external version, so Elasticsearch ignores it when a newer
+// version is indexed; an equal version is rewritten to refresh synced_at.
+func (x *ElasticsearchIndex) Put(ctx context.Context, docs ...*Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, doc := range docs {
+		action := map[string]interface{}{
+			"index": map[string]interface{}{
+				"_index":       x.cfg.Index,
+				"_id":          doc.ProductID,
+				"version":      doc.Version,
+				"version_type": "external_gte",
+			},
+		}
+		source := esDocument{Document: doc}
+		source.NameSuggest.Input = suggestInputs(doc.Name)
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(source); err != nil {
+			return err
+		}
+	}
+
+	code, body, err := x.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := checkStatus("bulk index", code, body); err != nil {
+		return err
+	}
+
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string   `json:"_id"`
+			Status int      `json:"status"`
+			Error  *esError `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("elasticsearch: invalid bulk response: %w", err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			// A conflict means a newer version is already indexed
+			if result.Error != nil && result.Status != http.StatusConflict {
+				return fmt.Errorf("elasticsearch: failed to index %s: %s: %s", result.ID, result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+// Delete removes a product's document
+func (x *ElasticsearchIndex) Delete(ctx context.Context, productID string) error {
+	code, body, err := x.do(ctx, http.MethodDelete, "/"+url.PathEscape(x.cfg.Index)+"/_doc/"+url.PathEscape(productID), "", nil)
+	if err != nil {
+		return err
+	}
+	if code == http.StatusNotFound {
+		return nil
+	}
+	return checkStatus("delete", code, body)
+}
+
+// DeleteSyncedBefore deletes by a range query on synced_at
+func (x *ElasticsearchIndex) DeleteSyncedBefore(ctx context.Context, t time.Time) (int64, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{
+				"synced_at": map[string]interface{}{"lt": t.UTC().Format(time.RFC3339Nano)},
+			},
+		},
+	}
+	var resp struct {
+		Deleted int64 `json:"deleted"`
+	}
+	path := "/" + url.PathEscape(x.cfg.Index) + "/_delete_by_query?conflicts=proceed"
+	if err := x.post(ctx, path, "delete by query", query, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Deleted, nil
+}
+
+// Search runs a bool query: the text must match and every filter must hold
+func (x *ElasticsearchIndex) Search(ctx context.Context, query *Query) ([]*Hit, int32, error) {
+	var resp struct {
+		Hits struct {
+			Total struct {
+				Value int32 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Score  *float64 `json:"_score"`
+				Source Document `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	path := "/" + url.PathEscape(x.cfg.Index) + "/_search"
+	if err := x.post(ctx, path, "search", searchBody(query), &resp); err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]*Hit, len(resp.Hits.Hits))
+	for i, h := range resp.Hits.Hits {
+		hits[i] = &Hit{Document: h.Source}
+		if h.Score != nil {
+			hits[i].Score = *h.Score
+		}
+	}
+	return hits, resp.Hits.Total.Value, nil
+}
+
+// searchBody builds the search request of a query
+func searchBody(query *Query) map[string]interface{} {
+	var must interface{} = map[string]interface{}{"match_all": map[string]interface{}{}}
+	if query.Text != "" {
+		must = map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":     query.Text,
+				"fields":    []string{"name^3", "category.text^2", "description", "sku^4"},
+				"fuzziness": "AUTO",
+				"lenient":   true,
+			},
+		}
+	}
+
+	filters := []interface{}{}
+	if query.Category != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"category": query.Category}})
+	}
+	if query.MinPrice > 0 || query.MaxPrice > 0 {
+		price := map[string]interface{}{}
+		if query.MinPrice > 0 {
+			price["gte"] = query.MinPrice
+		}
+		if query.MaxPrice > 0 {
+			price["lte"] = query.MaxPrice
+		}
+		filters = append(filters, map[string]interface{}{"range": map[string]interface{}{"effective_price": price}})
+	}
+	if query.MinRating > 0 {
+		filters = append(filters, map[string]interface{}{"range": map[string]interface{}{"average_rating": map[string]interface{}{"gte": query.MinRating}}})
+	}
+	if query.InStockOnly {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"in_stock": true}})
+	}
+
+	return map[string]interface{}{
+		"from":             (query.Page - 1) * query.PageSize,
+		"size":             query.PageSize,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{"must": must, "filter": filters},
+		},
+		"sort": sortFields[query.SortBy],
+	}
+}
+
+// sortFields are the sort clauses of each sort order; the product ID breaks
+// ties so pages are stable
+var sortFields = map[string][]interface{}{
+	SortRelevance: {map[string]string{"_score": "desc"}, map[string]string{"product_id": "asc"}},
+	SortPriceAsc:  {map[string]string{"effective_price": "asc"}, map[string]string{"product_id": "asc"}},
+	SortPriceDesc: {map[string]string{"effective_price": "desc"}, map[string]string{"product_id": "asc"}},
+	SortRating: {
+		map[string]string{"average_rating": "desc"},
+		map[string]string{"review_count": "desc"},
+		map[string]string{"product_id": "asc"},
+	},
+	SortNewest: {map[string]string{"created_at": "desc"}, map[string]string{"product_id": "asc"}},
+}
+
+// Suggest queries the completion suggester
+func (x *ElasticsearchIndex) Suggest(ctx context.Context, prefix string, limit int32) ([]*Suggestion, error) {
+	body := map[string]interface{}{
+		"_source": []string{"product_id", "name"},
+		"suggest": map[string]interface{}{
+			"products": map[string]interface{}{
+				"prefix": prefix,
+				"completion": map[string]interface{}{
+					"field":           "name_suggest",
+					"size":            limit,
+					"skip_duplicates": true,
+				},
+			},
+		},
+	}
+	var resp struct {
+		Suggest struct {
+			Products []struct {
+				Options []struct {
+					Source Document `json:"_source"`
+				} `json:"options"`
+			} `json:"products"`
+		} `json:"suggest"`
+	}
+	path := "/" + url.PathEscape(x.cfg.Index) + "/_search"
+	if err := x.post(ctx, path, "suggest", body, &resp); err != nil {
+		return nil, err
+	}
+
+	suggestions := []*Suggestion{}
+	for _, entry := range resp.Suggest.Products {
+		for _, option := range entry.Options {
+			suggestions = append(suggestions, &Suggestion{ProductID: option.Source.ProductID, Name: option.Source.Name})
+		}
+	}
+	return suggestions, nil
+}
+
+// suggestInputs lists the name from each of its words, so a prefix of any
+// word suggests the product: "Blue Linen Shirt", "Linen Shirt" and "Shirt"
+func suggestInputs(name string) []string {
+	words := strings.Fields(name)
+	inputs := make([]string, len(words))
+	for i := range words {
+		inputs[i] = strings.Join(words[i:], " ")
+	}
+	return inputs
+}
+
+// post sends a JSON POST request and decodes a successful response into out
+func (x *ElasticsearchIndex) post(ctx context.Context, path, op string, body, out interface{}) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	code, respBody, err := x.do(ctx, http.MethodPost, path, "application/json", encoded)
+	if err != nil {
+		return err
+	}
+	if err := checkStatus(op, code, respBody); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("elasticsearch: invalid %s response: %w", op, err)
+	}
+	return nil
+}
+
+// do sends a request and returns the response status and body
+func (x *ElasticsearchIndex) do(ctx context.Context, method, path, contentType string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, x.cfg.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if x.cfg.Username != "" {
+		req.SetBasicAuth(x.cfg.Username, x.cfg.Password)
+	}
+
+	resp, err := x.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("elasticsearch: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, nil, fmt.Errorf("elasticsearch: failed to read response: %w", err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// checkStatus returns an error describing a failed response
+func checkStatus(op string, code int, body []byte) error {
+	if code >= 200 && code <= 299 {
+		return nil
+	}
+	e := responseError(body)
+	return fmt.Errorf("elasticsearch: %s failed with status %d: %s: %s", op, code, e.Type, e.Reason)
+}
+
+func responseError(body []byte) esError {
+	var decoded esErrorResponse
+	_ = json.Unmarshal(body, &decoded)
+	return decoded.Error
+}
@@ -0,0 +1,227 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestElasticsearch(t *testing.T, handler http.HandlerFunc) *ElasticsearchIndex {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	index, err := NewElasticsearchIndex(ElasticsearchConfig{URL: server.URL + "/", Username: "elastic", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return index
+}
+
+func TestElasticsearchEnsure(t *testing.T) {
+	var created bool
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "elastic" || pass != "secret" || r.URL.Path != "/products" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		case http.MethodPut:
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["mappings"] == nil {
+				t.Errorf("Expected mappings, got %v (%v)", body, err)
+			}
+			created = true
+			w.Write([]byte(`{"acknowledged": true}`))
+		}
+	})
+
+	if err := index.Ensure(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !created {
+		t.Error("Expected the index to be created")
+	}
+}
+
+func TestElasticsearchEnsure_CreatedConcurrently(t *testing.T) {
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"type": "resource_already_exists_exception", "reason": "index [products] already exists"}}`))
+	})
+
+	if err := index.Ensure(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestElasticsearchPut(t *testing.T) {
+	var lines []map[string]interface{}
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("Unexpected request %s with %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			json.Unmarshal(scanner.Bytes(), &line)
+			lines = append(lines, line)
+		}
+		// p2 has a newer version indexed already
+		w.Write([]byte(`{"errors": true, "items": [
+			{"index": {"_id": "p1", "status": 201}},
+			{"index": {"_id": "p2", "status": 409, "error": {"type": "version_conflict_engine_exception", "reason": "current version [5] is higher"}}}]}`))
+	})
+
+	err := index.Put(context.Background(),
+		&Document{ProductID: "p1", Name: "Blue Linen Shirt", Version: 3},
+		&Document{ProductID: "p2", Name: "Rug", Version: 4},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("Expected an action and a source per document, got %v", lines)
+	}
+	action := lines[0]["index"].(map[string]interface{})
+	if action["_id"] != "p1" || action["_index"] != "products" || action["version"] != 3.0 || action["version_type"] != "external_gte" {
+		t.Errorf("Unexpected action %v", action)
+	}
+	source := lines[1]
+	inputs := source["name_suggest"].(map[string]interface{})["input"].([]interface{})
+	if source["product_id"] != "p1" || len(inputs) != 3 || inputs[1] != "Linen Shirt" {
+		t.Errorf("Unexpected source %v", source)
+	}
+	if _, ok := source["Version"]; ok {
+		t.Error("Expected the version not to be stored in the source")
+	}
+}
+
+func TestElasticsearchPut_ItemError(t *testing.T) {
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors": true, "items": [
+			{"index": {"_id": "p1", "status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse"}}}]}`))
+	})
+
+	err := index.Put(context.Background(), &Document{ProductID: "p1", Version: 1})
+	if err == nil || !strings.Contains(err.Error(), "mapper_parsing_exception") {
+		t.Errorf("Expected the item error, got %v", err)
+	}
+}
+
+func TestElasticsearchDelete(t *testing.T) {
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/products/_doc/p1" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"result": "not_found"}`))
+	})
+
+	if err := index.Delete(context.Background(), "p1"); err != nil {
+		t.Errorf("Expected a missing document not to be an error, got %v", err)
+	}
+}
+
+func TestElasticsearchDeleteSyncedBefore(t *testing.T) {
+	before := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/products/_delete_by_query" || !strings.Contains(string(body), `"lt":"2026-06-01T12:00:00Z"`) {
+			t.Errorf("Unexpected request %s: %s", r.URL.Path, body)
+		}
+		w.Write([]byte(`{"deleted": 2}`))
+	})
+
+	removed, err := index.DeleteSyncedBefore(context.Background(), before)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 removed, got %d", removed)
+	}
+}
+
+func TestElasticsearchSearch(t *testing.T) {
+	var body map[string]interface{}
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/products/_search" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"hits": {"total": {"value": 21}, "hits": [
+			{"_id": "p1", "_score": 2.5, "_source": {"product_id": "p1", "name": "Blue Linen Shirt", "effective_price": 40, "in_stock": true}}]}}`))
+	})
+
+	hits, total, err := index.Search(context.Background(), &Query{
+		Text:      "linen",
+		Category:  "Apparel",
+		MaxPrice:  50,
+		MinRating: 4,
+		SortBy:    SortRelevance,
+		Page:      3,
+		PageSize:  10,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 21 || len(hits) != 1 || hits[0].Name != "Blue Linen Shirt" || hits[0].Score != 2.5 || !hits[0].InStock {
+		t.Errorf("Unexpected hits %v of %d", hits, total)
+	}
+
+	if body["from"] != 20.0 || body["size"] != 10.0 {
+		t.Errorf("Expected the third page, got from %v size %v", body["from"], body["size"])
+	}
+	boolQuery := body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	match := boolQuery["must"].(map[string]interface{})["multi_match"].(map[string]interface{})
+	if match["query"] != "linen" || match["fuzziness"] != "AUTO" {
+		t.Errorf("Unexpected match %v", match)
+	}
+	if filters := boolQuery["filter"].([]interface{}); len(filters) != 3 {
+		t.Errorf("Expected category, price and rating filters, got %v", filters)
+	}
+}
+
+func TestElasticsearchSuggest(t *testing.T) {
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		products := body["suggest"].(map[string]interface{})["products"].(map[string]interface{})
+		if products["prefix"] != "shi" || products["completion"].(map[string]interface{})["size"] != 5.0 {
+			t.Errorf("Unexpected suggest %v", products)
+		}
+		w.Write([]byte(`{"suggest": {"products": [{"options": [
+			{"_id": "p1", "_source": {"product_id": "p1", "name": "Blue Linen Shirt"}},
+			{"_id": "p2", "_source": {"product_id": "p2", "name": "Shirt Dress"}}]}]}}`))
+	})
+
+	suggestions, err := index.Suggest(context.Background(), "shi", 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(suggestions) != 2 || suggestions[1].ProductID != "p2" || suggestions[1].Name != "Shirt Dress" {
+		t.Errorf("Unexpected suggestions %v", suggestions)
+	}
+}
+
+func TestElasticsearchErrorStatus(t *testing.T) {
+	index := newTestElasticsearch(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": {"type": "cluster_block_exception", "reason": "blocked"}}`))
+	})
+
+	_, _, err := index.Search(context.Background(), &Query{SortBy: SortRelevance, Page: 1, PageSize: 10})
+	if err == nil || !strings.Contains(err.Error(), "503") || !strings.Contains(err.Error(), "cluster_block_exception") {
+		t.Errorf("Expected the status and error type, got %v", err)
+	}
+}
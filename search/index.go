@@ -0,0 +1,79 @@
+package search
+
+import (
+	"context"
+	"time"
+)
+
+// Sort orders of search results
+const (
+	SortRelevance = "RELEVANCE"
+	SortPriceAsc  = "PRICE_ASC"
+	SortPriceDesc = "PRICE_DESC"
+	SortRating    = "RATING"
+	SortNewest    = "NEWEST"
+)
+
+// Document is an active product as it is indexed
+type Document struct {
+	ProductID      string    `json:"product_id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Category       string    `json:"category"`
+	SKU            string    `json:"sku"`
+	Price          float64   `json:"price"`
+	EffectivePrice float64   `json:"effective_price"`
+	OnSale         bool      `json:"on_sale"`
+	Image          string    `json:"image"`
+	AverageRating  float64   `json:"average_rating"`
+	ReviewCount    int32     `json:"review_count"`
+	InStock        bool      `json:"in_stock"`
+	CreatedAt      time.Time `json:"created_at"`
+	// Version is the catalog version of the product; an older version never
+	// replaces a newer one
+	Version int64 `json:"-"`
+	// SyncedAt is when the document was last written, so a full resync can
+	// remove the documents it did not write
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// Query is a product search
+type Query struct {
+	Text        string
+	Category    string
+	MinPrice    float64
+	MaxPrice    float64
+	MinRating   float64
+	InStockOnly bool
+	SortBy      string
+	Page        int32
+	PageSize    int32
+}
+
+// Hit is a document matching a search
+type Hit struct {
+	Document
+	Score float64
+}
+
+// Suggestion is a product name completing a prefix
+type Suggestion struct {
+	ProductID string
+	Name      string
+}
+
+// Index stores the searchable products
+type Index interface {
+	// Ensure creates the index if it does not exist
+	Ensure(ctx context.Context) error
+	// Put writes documents, replacing those of the same products. A document
+	// older than the one indexed for its product is ignored.
+	Put(ctx context.Context, docs ...*Document) error
+	// Delete removes a product's document; removing a missing one is not an error
+	Delete(ctx context.Context, productID string) error
+	// DeleteSyncedBefore removes the documents last written before t and
+	// returns how many were removed
+	DeleteSyncedBefore(ctx context.Context, t time.Time) (int64, error)
+	Search(ctx context.Context, query *Query) ([]*Hit, int32, error)
+	Suggest(ctx context.Context, prefix string, limit int32) ([]*Suggestion, error)
+}
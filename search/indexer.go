@@ -0,0 +1,255 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// DefaultResyncInterval is how often the index is rebuilt from the whole catalog
+const DefaultResyncInterval = time.Hour
+
+// Catalog values the index is built from
+const (
+	catalogStatusActive  = "ACTIVE"
+	catalogTypeDigital   = "DIGITAL"
+	catalogChangeDeleted = "DELETED"
+)
+
+const (
+	// resyncBatchSize is how many documents a resync writes at once
+	resyncBatchSize = 500
+	// retryDelay is how long the indexer waits after the catalog or the index fails
+	retryDelay = 5 * time.Second
+)
+
+// Indexer keeps the index in step with the catalog. It consumes the catalog's
+// product change stream, and rebuilds the index from the whole catalog when
+// it starts, when the stream breaks, and every resync interval, which also
+// picks up sale prices that started or ended since a product changed.
+type Indexer struct {
+	catalog        catalogpb.CatalogServiceClient
+	index          Index
+	resyncInterval time.Duration
+	retryDelay     time.Duration
+	resync         chan struct{}
+	log            *logger.Logger
+	now            func() time.Time
+}
+
+// NewIndexer creates an indexer. A resyncInterval of 0 uses DefaultResyncInterval.
+func NewIndexer(catalog catalogpb.CatalogServiceClient, index Index, resyncInterval time.Duration, log *logger.Logger) *Indexer {
+	if resyncInterval <= 0 {
+		resyncInterval = DefaultResyncInterval
+	}
+	return &Indexer{
+		catalog:        catalog,
+		index:          index,
+		resyncInterval: resyncInterval,
+		retryDelay:     retryDelay,
+		resync:         make(chan struct{}, 1),
+		log:            log,
+		now:            time.Now,
+	}
+}
+
+// RequestResync asks the indexer to rebuild the index from the whole
+// catalog. It returns false when a rebuild is already pending.
+func (i *Indexer) RequestResync() bool {
+	select {
+	case i.resync <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run indexes product changes until ctx is cancelled, starting over after a
+// delay whenever the catalog or the index fails
+func (i *Indexer) Run(ctx context.Context) {
+	for {
+		err := i.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			continue
+		}
+
+		i.log.Warn(ctx, "Product indexing interrupted", map[string]interface{}{"error": err.Error(), "retry_in": i.retryDelay.String()})
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(i.retryDelay):
+		}
+	}
+}
+
+// follow subscribes to product changes, resyncs, and applies changes until
+// the stream breaks or the next resync is due. It returns nil when a resync
+// is due.
+func (i *Indexer) follow(ctx context.Context) error {
+	if err := i.index.Ensure(ctx); err != nil {
+		return err
+	}
+
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	defer stopWatching()
+	stream, err := i.catalog.WatchProducts(watchCtx, &catalogpb.WatchProductsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to watch products: %w", err)
+	}
+	// The catalog sends headers once the subscription is live; changes from
+	// then on are queued on the stream while the resync runs
+	if _, err := stream.Header(); err != nil {
+		return fmt.Errorf("failed to watch products: %w", err)
+	}
+
+	// A resync requested before now is covered by this one
+	select {
+	case <-i.resync:
+	default:
+	}
+	if err := i.Resync(ctx); err != nil {
+		return err
+	}
+
+	// Stop watching when the next resync is due, so it starts from a fresh
+	// subscription
+	go func() {
+		timer := time.NewTimer(i.resyncInterval)
+		defer timer.Stop()
+		select {
+		case <-watchCtx.Done():
+		case <-timer.C:
+		case <-i.resync:
+		}
+		stopWatching()
+	}()
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if watchCtx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				return errors.New("product change stream ended")
+			}
+			return fmt.Errorf("product change stream broke: %w", err)
+		}
+		if err := i.apply(ctx, event); err != nil {
+			return err
+		}
+	}
+}
+
+// apply indexes one product change
+func (i *Indexer) apply(ctx context.Context, event *catalogpb.ProductChangeEvent) error {
+	if event.Type != catalogChangeDeleted && event.Product == nil {
+		// The catalog could not load the product; a deletion follows if it
+		// was deleted, and the next resync catches any other change
+		i.log.Warn(ctx, "Product change without product", map[string]interface{}{"product_id": event.ProductId, "type": event.Type})
+		return nil
+	}
+
+	doc := toDocument(event.Product, i.now())
+	if doc == nil {
+		if err := i.index.Delete(ctx, event.ProductId); err != nil {
+			return fmt.Errorf("failed to remove product %s: %w", event.ProductId, err)
+		}
+		return nil
+	}
+	if err := i.index.Put(ctx, doc); err != nil {
+		return fmt.Errorf("failed to index product %s: %w", event.ProductId, err)
+	}
+	return nil
+}
+
+// Resync indexes every active product in the catalog, then removes the
+// documents it did not write: products deleted or made drafts while changes
+// were not being followed.
+func (i *Indexer) Resync(ctx context.Context) error {
+	// The index stores times to the millisecond; truncating keeps every
+	// document written from now on at or after started
+	started := i.now().Truncate(time.Millisecond)
+
+	streamCtx, stopStream := context.WithCancel(ctx)
+	defer stopStream()
+	stream, err := i.catalog.StreamProducts(streamCtx, &catalogpb.StreamProductsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to stream products: %w", err)
+	}
+
+	indexed := 0
+	batch := make([]*Document, 0, resyncBatchSize)
+	flush := func() error {
+		if err := i.index.Put(ctx, batch...); err != nil {
+			return fmt.Errorf("failed to index products: %w", err)
+		}
+		indexed += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		product, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stream products: %w", err)
+		}
+		if doc := toDocument(product, i.now()); doc != nil {
+			batch = append(batch, doc)
+		}
+		if len(batch) == resyncBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	removed, err := i.index.DeleteSyncedBefore(ctx, started)
+	if err != nil {
+		return fmt.Errorf("failed to remove stale products: %w", err)
+	}
+
+	i.log.Info(ctx, "Search index resynced", map[string]interface{}{"indexed": indexed, "removed": removed, "duration": i.now().Sub(started).String()})
+	return nil
+}
+
+// toDocument converts a catalog product to its document, or returns nil when
+// the product is not searchable
+func toDocument(p *catalogpb.Product, now time.Time) *Document {
+	if p == nil || p.Status != catalogStatusActive {
+		return nil
+	}
+	doc := &Document{
+		ProductID:      p.Id,
+		Name:           p.Name,
+		Description:    p.Description,
+		Category:       p.Category,
+		SKU:            p.Sku,
+		Price:          p.Price,
+		EffectivePrice: p.EffectivePrice,
+		OnSale:         p.OnSale,
+		AverageRating:  p.AverageRating,
+		ReviewCount:    p.ReviewCount,
+		InStock:        p.ProductType == catalogTypeDigital || p.Stock > 0,
+		CreatedAt:      p.CreatedAt.AsTime(),
+		Version:        p.Version,
+		SyncedAt:       now,
+	}
+	if len(p.Images) > 0 {
+		doc.Image = p.Images[0]
+	}
+	return doc
+}
@@ -0,0 +1,181 @@
+package search
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// productStream serves products from a StreamProducts call
+type productStream struct {
+	grpc.ClientStream
+	products []*catalogpb.Product
+}
+
+func (s *productStream) Recv() (*catalogpb.Product, error) {
+	if len(s.products) == 0 {
+		return nil, io.EOF
+	}
+	p := s.products[0]
+	s.products = s.products[1:]
+	return p, nil
+}
+
+// changeStream serves product changes from a WatchProducts call until its
+// context is cancelled
+type changeStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *catalogpb.ProductChangeEvent
+}
+
+func (s *changeStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *changeStream) Recv() (*catalogpb.ProductChangeEvent, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case e := <-s.events:
+		return e, nil
+	}
+}
+
+// fakeCatalogClient streams a fixed catalog and the changes sent on events
+type fakeCatalogClient struct {
+	catalogpb.CatalogServiceClient
+	products []*catalogpb.Product
+	events   chan *catalogpb.ProductChangeEvent
+	watching chan struct{}
+}
+
+func (c *fakeCatalogClient) StreamProducts(ctx context.Context, req *catalogpb.StreamProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[catalogpb.Product], error) {
+	return &productStream{products: append([]*catalogpb.Product{}, c.products...)}, nil
+}
+
+func (c *fakeCatalogClient) WatchProducts(ctx context.Context, req *catalogpb.WatchProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[catalogpb.ProductChangeEvent], error) {
+	c.watching <- struct{}{}
+	return &changeStream{ctx: ctx, events: c.events}, nil
+}
+
+func product(id, name string, version int64) *catalogpb.Product {
+	return &catalogpb.Product{
+		Id:             id,
+		Name:           name,
+		Status:         catalogStatusActive,
+		Price:          20,
+		EffectivePrice: 20,
+		Stock:          3,
+		Version:        version,
+		CreatedAt:      timestamppb.New(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)),
+	}
+}
+
+func TestToDocument(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	p := product("p1", "Lamp", 4)
+	p.Images = []string{"https://cdn.example.com/lamp.jpg", "https://cdn.example.com/lamp-2.jpg"}
+
+	doc := toDocument(p, now)
+	if doc.ProductID != "p1" || doc.Version != 4 || !doc.InStock || !doc.SyncedAt.Equal(now) || doc.Image != p.Images[0] {
+		t.Errorf("Unexpected document %+v", doc)
+	}
+
+	digital := product("p2", "E-book", 1)
+	digital.ProductType = catalogTypeDigital
+	digital.Stock = 0
+	if doc := toDocument(digital, now); !doc.InStock {
+		t.Error("Expected digital products to be in stock")
+	}
+
+	draft := product("p3", "Draft", 1)
+	draft.Status = "DRAFT"
+	if doc := toDocument(draft, now); doc != nil {
+		t.Errorf("Expected drafts not to be indexed, got %+v", doc)
+	}
+}
+
+func TestIndexer_ResyncRemovesStaleProducts(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	index := newMemoryIndex()
+	// Indexed before the resync: gone from the catalog since
+	index.Put(context.Background(), &Document{ProductID: "deleted", Version: 1, SyncedAt: now.Add(-time.Hour)})
+
+	draft := product("draft", "Draft", 2)
+	draft.Status = "DRAFT"
+	catalog := &fakeCatalogClient{products: []*catalogpb.Product{product("p1", "Lamp", 1), draft}}
+	indexer := NewIndexer(catalog, index, 0, logger.New("search-test"))
+	indexer.now = func() time.Time { return now }
+
+	if err := indexer.Resync(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := index.get("p1"); !ok {
+		t.Error("Expected p1 to be indexed")
+	}
+	for _, id := range []string{"deleted", "draft"} {
+		if _, ok := index.get(id); ok {
+			t.Errorf("Expected %s not to be indexed", id)
+		}
+	}
+}
+
+func TestIndexer_Run(t *testing.T) {
+	index := newMemoryIndex()
+	catalog := &fakeCatalogClient{
+		products: []*catalogpb.Product{product("p1", "Lamp", 1), product("p2", "Rug", 1)},
+		events:   make(chan *catalogpb.ProductChangeEvent),
+		watching: make(chan struct{}, 2),
+	}
+	indexer := NewIndexer(catalog, index, time.Hour, logger.New("search-test"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		indexer.Run(ctx)
+		close(done)
+	}()
+	<-catalog.watching
+
+	renamed := product("p1", "Desk Lamp", 2)
+	draft := product("p2", "Rug", 2)
+	draft.Status = "DRAFT"
+	// Sends return once the indexer received the change; the unbuffered
+	// events channel orders each change after the one before was applied
+	catalog.events <- &catalogpb.ProductChangeEvent{Type: "UPDATED", ProductId: "p1", Product: renamed}
+	catalog.events <- &catalogpb.ProductChangeEvent{Type: "UPDATED", ProductId: "p2", Product: draft}
+	catalog.events <- &catalogpb.ProductChangeEvent{Type: "CREATED", ProductId: "p3"}
+	catalog.events <- &catalogpb.ProductChangeEvent{Type: "CREATED", ProductId: "p4", Product: product("p4", "Vase", 1)}
+	catalog.events <- &catalogpb.ProductChangeEvent{Type: "DELETED", ProductId: "p4"}
+
+	// A requested resync starts over with a new subscription
+	if !indexer.RequestResync() {
+		t.Fatal("Expected a resync to start")
+	}
+	<-catalog.watching
+	cancel()
+	<-done
+
+	if doc, ok := index.get("p1"); !ok || doc.Name != "Desk Lamp" {
+		// The resync rewrote p1 from the fixed catalog, but version 1 does not
+		// replace the indexed version 2
+		t.Errorf("Expected the renamed lamp to stay indexed, got %+v", doc)
+	}
+	// The resync indexes p2 again from the fixed catalog, where it is active
+	if _, ok := index.get("p2"); !ok {
+		t.Error("Expected the resync to index p2 again")
+	}
+	for _, id := range []string{"p3", "p4"} {
+		if _, ok := index.get(id); ok {
+			t.Errorf("Expected %s not to be indexed", id)
+		}
+	}
+}
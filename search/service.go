@@ -0,0 +1,175 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/search/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxQueryLength caps search queries and suggestion prefixes
+	maxQueryLength = 200
+	// maxCategoryLength matches the catalog's category column
+	maxCategoryLength = 100
+	// maxResultWindow is how deep into the results Elasticsearch pages
+	maxResultWindow = 10000
+	// maxSuggestions caps the suggestions returned at once
+	maxSuggestions = 10
+)
+
+// AdminMethods are the admin-scoped RPCs of the search service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.SearchService_Reindex_FullMethodName,
+}
+
+// Service implements the SearchService gRPC interface
+type Service struct {
+	pb.UnimplementedSearchServiceServer
+	index   Index
+	indexer *Indexer
+	log     *logger.Logger
+}
+
+// NewService creates a new search service
+func NewService(index Index, indexer *Indexer, log *logger.Logger) *Service {
+	return &Service{
+		index:   index,
+		indexer: indexer,
+		log:     log,
+	}
+}
+
+// Search finds active products by text and filters
+func (s *Service) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	query := &Query{
+		Text:        strings.TrimSpace(req.Query),
+		Category:    req.Category,
+		MinPrice:    req.MinPrice,
+		MaxPrice:    req.MaxPrice,
+		MinRating:   req.MinRating,
+		InStockOnly: req.InStockOnly,
+		SortBy:      req.SortBy,
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+	}
+	if query.SortBy == "" {
+		query.SortBy = SortRelevance
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = 10
+	}
+	if query.PageSize > 100 {
+		query.PageSize = 100
+	}
+
+	if msg := queryProblem(query); msg != "" {
+		s.log.Warn(ctx, "Search failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	hits, total, err := s.index.Search(ctx, query)
+	if err != nil {
+		s.log.Error(ctx, "Failed to search products", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Unavailable, "search index unavailable")
+	}
+
+	resp := &pb.SearchResponse{
+		Hits:     make([]*pb.SearchHit, len(hits)),
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	for i, h := range hits {
+		resp.Hits[i] = toProtoHit(h)
+	}
+	return resp, nil
+}
+
+// queryProblem validates a search and returns a message describing the
+// first problem, or ""
+func queryProblem(q *Query) string {
+	switch {
+	case len(q.Text) > maxQueryLength:
+		return "query is too long"
+	case len(q.Category) > maxCategoryLength:
+		return "category is too long"
+	case q.MinPrice < 0 || q.MaxPrice < 0:
+		return "prices cannot be negative"
+	case q.MaxPrice > 0 && q.MinPrice > q.MaxPrice:
+		return "min_price cannot be above max_price"
+	case q.MinRating < 0 || q.MinRating > 5:
+		return "min_rating must be between 0 and 5"
+	case sortFields[q.SortBy] == nil:
+		return "sort_by must be RELEVANCE, PRICE_ASC, PRICE_DESC, RATING or NEWEST"
+	case int64(q.Page)*int64(q.PageSize) > maxResultWindow:
+		return "results beyond the first 10000 cannot be paged; narrow the search"
+	}
+	return ""
+}
+
+// Suggest completes a prefix to product names
+func (s *Service) Suggest(ctx context.Context, req *pb.SuggestRequest) (*pb.SuggestResponse, error) {
+	prefix := strings.TrimSpace(req.Prefix)
+	if prefix == "" {
+		s.log.Warn(ctx, "Suggest failed: prefix is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "prefix is required")
+	}
+	if len(prefix) > maxQueryLength {
+		s.log.Warn(ctx, "Suggest failed: prefix is too long", nil)
+		return nil, status.Error(codes.InvalidArgument, "prefix is too long")
+	}
+
+	limit := req.Limit
+	if limit < 1 {
+		limit = 5
+	}
+	if limit > maxSuggestions {
+		limit = maxSuggestions
+	}
+
+	suggestions, err := s.index.Suggest(ctx, prefix, limit)
+	if err != nil {
+		s.log.Error(ctx, "Failed to suggest products", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Unavailable, "search index unavailable")
+	}
+
+	resp := &pb.SuggestResponse{Suggestions: make([]*pb.Suggestion, len(suggestions))}
+	for i, sg := range suggestions {
+		resp.Suggestions[i] = &pb.Suggestion{ProductId: sg.ProductID, Name: sg.Name}
+	}
+	return resp, nil
+}
+
+// Reindex asks the indexer to rebuild the index from the whole catalog
+func (s *Service) Reindex(ctx context.Context, req *pb.ReindexRequest) (*pb.ReindexResponse, error) {
+	started := s.indexer.RequestResync()
+	s.log.Info(ctx, "Reindex requested", map[string]interface{}{"started": started})
+	return &pb.ReindexResponse{Started: started}, nil
+}
+
+// toProtoHit converts a hit to protobuf
+func toProtoHit(h *Hit) *pb.SearchHit {
+	return &pb.SearchHit{
+		ProductId:      h.ProductID,
+		Name:           h.Name,
+		Description:    h.Description,
+		Category:       h.Category,
+		Sku:            h.SKU,
+		Price:          h.Price,
+		EffectivePrice: h.EffectivePrice,
+		OnSale:         h.OnSale,
+		Image:          h.Image,
+		AverageRating:  h.AverageRating,
+		ReviewCount:    h.ReviewCount,
+		InStock:        h.InStock,
+		Score:          h.Score,
+	}
+}
@@ -0,0 +1,288 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/search/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryIndex is an in-memory Index with the same version rule as the
+// Elasticsearch index. Text matches are case-insensitive substrings.
+type memoryIndex struct {
+	mu      sync.Mutex
+	docs    map[string]Document
+	err     error
+	queries []*Query
+}
+
+func newMemoryIndex() *memoryIndex {
+	return &memoryIndex{docs: map[string]Document{}}
+}
+
+func (m *memoryIndex) Ensure(ctx context.Context) error {
+	return m.err
+}
+
+func (m *memoryIndex) Put(ctx context.Context, docs ...*Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	for _, doc := range docs {
+		if current, ok := m.docs[doc.ProductID]; ok && current.Version > doc.Version {
+			continue
+		}
+		m.docs[doc.ProductID] = *doc
+	}
+	return nil
+}
+
+func (m *memoryIndex) Delete(ctx context.Context, productID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	delete(m.docs, productID)
+	return nil
+}
+
+func (m *memoryIndex) DeleteSyncedBefore(ctx context.Context, t time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return 0, m.err
+	}
+	var removed int64
+	for id, doc := range m.docs {
+		if doc.SyncedAt.Before(t) {
+			delete(m.docs, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (m *memoryIndex) Search(ctx context.Context, query *Query) ([]*Hit, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queries = append(m.queries, query)
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+
+	text := strings.ToLower(query.Text)
+	var hits []*Hit
+	for _, doc := range m.docs {
+		haystack := strings.ToLower(strings.Join([]string{doc.Name, doc.Category, doc.Description, doc.SKU}, " "))
+		switch {
+		case text != "" && !strings.Contains(haystack, text),
+			query.Category != "" && doc.Category != query.Category,
+			query.MinPrice > 0 && doc.EffectivePrice < query.MinPrice,
+			query.MaxPrice > 0 && doc.EffectivePrice > query.MaxPrice,
+			doc.AverageRating < query.MinRating,
+			query.InStockOnly && !doc.InStock:
+			continue
+		}
+		hits = append(hits, &Hit{Document: doc, Score: 1})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		switch {
+		case query.SortBy == SortPriceAsc && a.EffectivePrice != b.EffectivePrice:
+			return a.EffectivePrice < b.EffectivePrice
+		case query.SortBy == SortPriceDesc && a.EffectivePrice != b.EffectivePrice:
+			return a.EffectivePrice > b.EffectivePrice
+		case query.SortBy == SortRating && a.AverageRating != b.AverageRating:
+			return a.AverageRating > b.AverageRating
+		}
+		return a.ProductID < b.ProductID
+	})
+
+	total := int32(len(hits))
+	start := int((query.Page - 1) * query.PageSize)
+	if start > len(hits) {
+		start = len(hits)
+	}
+	end := start + int(query.PageSize)
+	if end > len(hits) {
+		end = len(hits)
+	}
+	return hits[start:end], total, nil
+}
+
+func (m *memoryIndex) Suggest(ctx context.Context, prefix string, limit int32) ([]*Suggestion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	suggestions := []*Suggestion{}
+	for _, doc := range m.docs {
+		for _, input := range suggestInputs(doc.Name) {
+			if strings.HasPrefix(strings.ToLower(input), strings.ToLower(prefix)) {
+				suggestions = append(suggestions, &Suggestion{ProductID: doc.ProductID, Name: doc.Name})
+				break
+			}
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Name < suggestions[j].Name })
+	if int32(len(suggestions)) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+func (m *memoryIndex) get(productID string) (Document, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	doc, ok := m.docs[productID]
+	return doc, ok
+}
+
+func setupService(index Index) *Service {
+	log := logger.New("search-test")
+	return NewService(index, NewIndexer(nil, index, 0, log), log)
+}
+
+func seedIndex(t *testing.T, index *memoryIndex) {
+	t.Helper()
+	err := index.Put(context.Background(),
+		&Document{ProductID: "p1", Name: "Blue Linen Shirt", Category: "Apparel", SKU: "SHIRT-1", EffectivePrice: 40, AverageRating: 4.5, InStock: true},
+		&Document{ProductID: "p2", Name: "Red Linen Shirt", Category: "Apparel", SKU: "SHIRT-2", EffectivePrice: 25, AverageRating: 3.8, InStock: false},
+		&Document{ProductID: "p3", Name: "Linen Napkins", Category: "Home", SKU: "NAP-1", EffectivePrice: 12, AverageRating: 4.9, InStock: true},
+	)
+	if err != nil {
+		t.Fatalf("Failed to seed index: %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	index := newMemoryIndex()
+	seedIndex(t, index)
+	service := setupService(index)
+
+	resp, err := service.Search(context.Background(), &pb.SearchRequest{Query: "  linen ", SortBy: SortPriceAsc})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 3 || resp.Page != 1 || resp.PageSize != 10 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+	if len(resp.Hits) != 3 || resp.Hits[0].ProductId != "p3" || resp.Hits[2].ProductId != "p1" {
+		t.Errorf("Expected hits by price, got %v", resp.Hits)
+	}
+	if got := index.queries[0]; got.Text != "linen" {
+		t.Errorf("Expected the query to be trimmed, got %q", got.Text)
+	}
+
+	resp, err = service.Search(context.Background(), &pb.SearchRequest{Category: "Apparel", MinPrice: 20, InStockOnly: true, PageSize: 500})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Hits) != 1 || resp.Hits[0].ProductId != "p1" || resp.Hits[0].Sku != "SHIRT-1" {
+		t.Errorf("Expected only the blue shirt, got %v", resp.Hits)
+	}
+	if resp.PageSize != 100 || index.queries[1].SortBy != SortRelevance {
+		t.Errorf("Expected page size 100 sorted by relevance, got %d and %s", resp.PageSize, index.queries[1].SortBy)
+	}
+}
+
+func TestSearch_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.SearchRequest
+	}{
+		{"query too long", &pb.SearchRequest{Query: strings.Repeat("a", maxQueryLength+1)}},
+		{"category too long", &pb.SearchRequest{Category: strings.Repeat("a", maxCategoryLength+1)}},
+		{"negative price", &pb.SearchRequest{MinPrice: -1}},
+		{"inverted price range", &pb.SearchRequest{MinPrice: 50, MaxPrice: 10}},
+		{"rating out of range", &pb.SearchRequest{MinRating: 6}},
+		{"unknown sort", &pb.SearchRequest{SortBy: "NAME"}},
+		{"too deep", &pb.SearchRequest{Page: 101, PageSize: 100}},
+	}
+
+	index := newMemoryIndex()
+	service := setupService(index)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Search(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+	if len(index.queries) != 0 {
+		t.Errorf("Expected no searches, got %d", len(index.queries))
+	}
+}
+
+func TestSearch_IndexUnavailable(t *testing.T) {
+	index := newMemoryIndex()
+	index.err = errors.New("connection refused")
+	service := setupService(index)
+
+	_, err := service.Search(context.Background(), &pb.SearchRequest{Query: "shirt"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+	_, err = service.Suggest(context.Background(), &pb.SuggestRequest{Prefix: "sh"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	index := newMemoryIndex()
+	seedIndex(t, index)
+	service := setupService(index)
+
+	resp, err := service.Suggest(context.Background(), &pb.SuggestRequest{Prefix: "Shi", Limit: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Suggestions) != 1 || resp.Suggestions[0].Name != "Blue Linen Shirt" {
+		t.Errorf("Expected one shirt, got %v", resp.Suggestions)
+	}
+
+	resp, err = service.Suggest(context.Background(), &pb.SuggestRequest{Prefix: "linen"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Suggestions) != 3 {
+		t.Errorf("Expected every linen product, got %v", resp.Suggestions)
+	}
+
+	_, err = service.Suggest(context.Background(), &pb.SuggestRequest{Prefix: "  "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty prefix, got %v", err)
+	}
+}
+
+func TestReindex(t *testing.T) {
+	service := setupService(newMemoryIndex())
+
+	resp, err := service.Reindex(context.Background(), &pb.ReindexRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Started {
+		t.Error("Expected a rebuild to start")
+	}
+
+	// The first request has not been picked up yet
+	resp, _ = service.Reindex(context.Background(), &pb.ReindexRequest{})
+	if resp.Started {
+		t.Error("Expected the second request to join the pending rebuild")
+	}
+}
@@ -0,0 +1,178 @@
+package shipping
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Carrier names
+const (
+	CarrierFlatRate  = "flat_rate"
+	CarrierTableRate = "table_rate"
+	CarrierEasyPost  = "easypost"
+)
+
+// maxResponseSize caps the carrier API response body that is read, in bytes
+const maxResponseSize = 1 << 20
+
+// defaultTimeout is used for carrier API calls when none is configured
+const defaultTimeout = 30 * time.Second
+
+var (
+	// ErrNoRate is returned when a carrier cannot ship a parcel, e.g. it is
+	// too heavy or the destination is not served
+	ErrNoRate = errors.New("no rate for parcel")
+	// ErrUnknownService is returned when a carrier has no such service
+	ErrUnknownService = errors.New("unknown carrier service")
+	// ErrUnknownCarrier is returned when a carrier is not configured
+	ErrUnknownCarrier = errors.New("unknown carrier")
+)
+
+// Parcel is the package being shipped
+type Parcel struct {
+	WeightGrams int64
+	LengthCM    float64
+	WidthCM     float64
+	HeightCM    float64
+}
+
+// Address is a postal address
+type Address struct {
+	Name       string `json:"name"`
+	Street1    string `json:"street1"`
+	Street2    string `json:"street2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// Rate is a carrier's price to ship a parcel with one of its services
+type Rate struct {
+	Carrier       string
+	Service       string
+	Amount        float64
+	Currency      string
+	EstimatedDays int32
+}
+
+// Label is a purchased shipping label
+type Label struct {
+	TrackingNumber string
+	LabelURL       string
+	// Rate is what the label was bought at
+	Rate Rate
+}
+
+// Carrier quotes rates and buys labels. Implementations must be safe for
+// concurrent use.
+type Carrier interface {
+	// Name identifies the carrier in requests and stored shipments
+	Name() string
+	// Rates returns the carrier's rates for a parcel. It returns ErrNoRate
+	// when no service can ship it.
+	Rates(ctx context.Context, parcel Parcel, destination Address) ([]Rate, error)
+	// BuyLabel buys a label for a parcel with service. It returns
+	// ErrUnknownService when the carrier has no such service and ErrNoRate
+	// when the service cannot ship the parcel.
+	BuyLabel(ctx context.Context, service string, parcel Parcel, destination Address) (*Label, error)
+}
+
+// Registry holds the configured carriers
+type Registry struct {
+	carriers map[string]Carrier
+}
+
+// NewRegistry creates a registry of carriers, which must have distinct names
+func NewRegistry(carriers ...Carrier) (*Registry, error) {
+	r := &Registry{carriers: make(map[string]Carrier, len(carriers))}
+	for _, c := range carriers {
+		if _, ok := r.carriers[c.Name()]; ok {
+			return nil, fmt.Errorf("carrier %s is configured twice", c.Name())
+		}
+		r.carriers[c.Name()] = c
+	}
+	return r, nil
+}
+
+// Get returns the carrier named name
+func (r *Registry) Get(name string) (Carrier, error) {
+	c, ok := r.carriers[name]
+	if !ok {
+		return nil, ErrUnknownCarrier
+	}
+	return c, nil
+}
+
+// Names returns the names of the configured carriers, sorted
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.carriers))
+	for name := range r.carriers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortRates orders rates cheapest first, then fastest
+func sortRates(rates []Rate) {
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Amount != rates[j].Amount {
+			return rates[i].Amount < rates[j].Amount
+		}
+		return rates[i].EstimatedDays < rates[j].EstimatedDays
+	})
+}
+
+// newTrackingNumber returns a random tracking number with prefix, for
+// carriers that do not issue their own
+func newTrackingNumber(prefix string) string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return prefix + strings.ToUpper(hex.EncodeToString(b))
+}
+
+// labelURL returns where the label of a locally issued tracking number is
+// served, or "" when no label base URL is configured
+func labelURL(baseURL, trackingNumber string) string {
+	if baseURL == "" {
+		return ""
+	}
+	return strings.TrimRight(baseURL, "/") + "/" + trackingNumber + ".pdf"
+}
+
+// send performs a carrier API request and returns the status code and body
+func send(client *http.Client, req *http.Request) (int, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// billableWeight is the weight a carrier charges for: the larger of the
+// parcel's weight and its dimensional weight, which is its volume in cm³ over
+// divisor, in kilograms. A zero divisor or missing dimensions charge the
+// actual weight.
+func billableWeight(parcel Parcel, divisor float64) int64 {
+	if divisor <= 0 || parcel.LengthCM <= 0 || parcel.WidthCM <= 0 || parcel.HeightCM <= 0 {
+		return parcel.WeightGrams
+	}
+	dimensional := int64(math.Ceil(parcel.LengthCM * parcel.WidthCM * parcel.HeightCM / divisor * 1000))
+	return max(parcel.WeightGrams, dimensional)
+}
@@ -0,0 +1,65 @@
+package shipping
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	flat, _ := NewFlatRateCarrier(FlatRateConfig{Services: []FlatRateService{{Name: "standard", Amount: 5}}, Currency: "USD"})
+	table, _ := NewTableRateCarrier(TableRateConfig{Rows: []TableRow{{Service: "ground", Country: AnyCountry, MaxWeightGrams: 1000, Amount: 8}}, Currency: "USD"})
+
+	registry, err := NewRegistry(table, flat)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if names := registry.Names(); !reflect.DeepEqual(names, []string{CarrierFlatRate, CarrierTableRate}) {
+		t.Errorf("Unexpected names %v", names)
+	}
+	if _, err := registry.Get("ups"); !errors.Is(err, ErrUnknownCarrier) {
+		t.Errorf("Expected ErrUnknownCarrier, got %v", err)
+	}
+	if _, err := NewRegistry(flat, flat); err == nil {
+		t.Error("Expected an error for a carrier configured twice")
+	}
+}
+
+func TestBillableWeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		parcel   Parcel
+		divisor  float64
+		expected int64
+	}{
+		{"no dimensions", Parcel{WeightGrams: 800}, 5000, 800},
+		{"dense parcel", Parcel{WeightGrams: 3000, LengthCM: 20, WidthCM: 10, HeightCM: 10}, 5000, 3000},
+		{"bulky parcel", Parcel{WeightGrams: 500, LengthCM: 50, WidthCM: 40, HeightCM: 30}, 5000, 12000},
+		{"dimensional weight off", Parcel{WeightGrams: 500, LengthCM: 50, WidthCM: 40, HeightCM: 30}, -1, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := billableWeight(tt.parcel, tt.divisor); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSortRates(t *testing.T) {
+	rates := []Rate{
+		{Service: "express", Amount: 15, EstimatedDays: 1},
+		{Service: "economy", Amount: 5, EstimatedDays: 7},
+		{Service: "standard", Amount: 5, EstimatedDays: 3},
+	}
+	sortRates(rates)
+
+	var order []string
+	for _, r := range rates {
+		order = append(order, r.Service)
+	}
+	if !reflect.DeepEqual(order, []string{"standard", "economy", "express"}) {
+		t.Errorf("Unexpected order %v", order)
+	}
+}
@@ -0,0 +1,316 @@
+package shipping
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gramsPerOunce and cmPerInch convert parcels to the units EasyPost expects
+const (
+	gramsPerOunce = 28.349523125
+	cmPerInch     = 2.54
+)
+
+// EasyPostConfig holds the settings of the EasyPost carrier
+type EasyPostConfig struct {
+	APIKey string
+	// FromAddress is the address parcels ship from
+	FromAddress Address
+	// BaseURL defaults to https://api.easypost.com
+	BaseURL string
+	Timeout time.Duration
+	// WebhookSecret verifies tracker webhooks; empty disables them
+	WebhookSecret string
+}
+
+// EasyPostCarrier quotes and buys labels through an EasyPost-style
+// multi-carrier API. Its services are named "<carrier> <service>", e.g.
+// "USPS Priority".
+type EasyPostCarrier struct {
+	cfg        EasyPostConfig
+	httpClient *http.Client
+}
+
+// NewEasyPostCarrier creates a new EasyPost carrier
+func NewEasyPostCarrier(cfg EasyPostConfig) (*EasyPostCarrier, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("easypost: API key is required")
+	}
+	if cfg.FromAddress.Country == "" || cfg.FromAddress.PostalCode == "" {
+		return nil, errors.New("easypost: from address needs a country and postal code")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.easypost.com"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &EasyPostCarrier{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Name returns "easypost"
+func (c *EasyPostCarrier) Name() string {
+	return CarrierEasyPost
+}
+
+type easyPostAddress struct {
+	Name    string `json:"name,omitempty"`
+	Street1 string `json:"street1,omitempty"`
+	Street2 string `json:"street2,omitempty"`
+	City    string `json:"city,omitempty"`
+	State   string `json:"state,omitempty"`
+	Zip     string `json:"zip"`
+	Country string `json:"country"`
+}
+
+type easyPostParcel struct {
+	Weight float64 `json:"weight"`
+	Length float64 `json:"length,omitempty"`
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+}
+
+type easyPostRate struct {
+	ID           string `json:"id"`
+	Carrier      string `json:"carrier"`
+	Service      string `json:"service"`
+	Rate         string `json:"rate"`
+	Currency     string `json:"currency"`
+	DeliveryDays int32  `json:"delivery_days"`
+}
+
+type easyPostShipment struct {
+	ID           string         `json:"id"`
+	Rates        []easyPostRate `json:"rates"`
+	TrackingCode string         `json:"tracking_code"`
+	PostageLabel struct {
+		LabelURL string `json:"label_url"`
+	} `json:"postage_label"`
+	SelectedRate easyPostRate `json:"selected_rate"`
+}
+
+type easyPostErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Rates creates an EasyPost shipment, which quotes every carrier account
+// linked to the API key
+func (c *EasyPostCarrier) Rates(ctx context.Context, parcel Parcel, destination Address) ([]Rate, error) {
+	shipment, err := c.createShipment(ctx, parcel, destination)
+	if err != nil {
+		return nil, err
+	}
+	if len(shipment.Rates) == 0 {
+		return nil, ErrNoRate
+	}
+
+	rates := make([]Rate, 0, len(shipment.Rates))
+	for _, r := range shipment.Rates {
+		rate, err := toRate(r)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// BuyLabel creates an EasyPost shipment and buys its rate for service
+func (c *EasyPostCarrier) BuyLabel(ctx context.Context, service string, parcel Parcel, destination Address) (*Label, error) {
+	shipment, err := c.createShipment(ctx, parcel, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	var chosen *easyPostRate
+	for i, r := range shipment.Rates {
+		if r.Carrier+" "+r.Service == service {
+			chosen = &shipment.Rates[i]
+			break
+		}
+	}
+	if chosen == nil {
+		return nil, ErrUnknownService
+	}
+
+	var bought easyPostShipment
+	body := map[string]interface{}{"rate": map[string]string{"id": chosen.ID}}
+	if err := c.do(ctx, "/v2/shipments/"+url.PathEscape(shipment.ID)+"/buy", body, &bought); err != nil {
+		return nil, err
+	}
+	if bought.TrackingCode == "" || bought.PostageLabel.LabelURL == "" {
+		return nil, fmt.Errorf("easypost: shipment %s was bought without a label", shipment.ID)
+	}
+
+	rate, err := toRate(*chosen)
+	if err != nil {
+		return nil, err
+	}
+	return &Label{TrackingNumber: bought.TrackingCode, LabelURL: bought.PostageLabel.LabelURL, Rate: rate}, nil
+}
+
+// easyPostStatuses maps EasyPost tracker statuses to shipment statuses;
+// statuses not listed, e.g. unknown or cancelled, are ignored
+var easyPostStatuses = map[string]string{
+	"pre_transit":      StatusCreated,
+	"in_transit":       StatusInTransit,
+	"out_for_delivery": StatusOutForDelivery,
+	"delivered":        StatusDelivered,
+	"return_to_sender": StatusReturned,
+	"failure":          StatusException,
+	"error":            StatusException,
+}
+
+type easyPostEvent struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Result      struct {
+		TrackingCode    string `json:"tracking_code"`
+		Status          string `json:"status"`
+		TrackingDetails []struct {
+			Message          string    `json:"message"`
+			Datetime         time.Time `json:"datetime"`
+			TrackingLocation struct {
+				City    string `json:"city"`
+				State   string `json:"state"`
+				Country string `json:"country"`
+			} `json:"tracking_location"`
+		} `json:"tracking_details"`
+	} `json:"result"`
+}
+
+// ParseTrackingWebhook verifies a tracker webhook by its X-Hmac-Signature
+// header, "hmac-sha256-hex=" and the hex HMAC-SHA256 of the body with the
+// webhook secret, and parses its tracker.updated event. Other events have no
+// status and are ignored.
+func (c *EasyPostCarrier) ParseTrackingWebhook(ctx context.Context, header http.Header, body []byte) (*TrackingUpdate, error) {
+	if c.cfg.WebhookSecret == "" {
+		return nil, ErrWebhooksDisabled
+	}
+	signature, ok := strings.CutPrefix(header.Get("X-Hmac-Signature"), "hmac-sha256-hex=")
+	if !ok || !verifyHMAC(body, c.cfg.WebhookSecret, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event easyPostEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("easypost: invalid webhook event: %w", err)
+	}
+	if event.ID == "" {
+		return nil, errors.New("easypost: webhook event has no id")
+	}
+
+	update := &TrackingUpdate{EventID: event.ID, TrackingNumber: event.Result.TrackingCode}
+	if event.Description != "tracker.updated" || event.Result.TrackingCode == "" {
+		return update, nil
+	}
+	update.Status = easyPostStatuses[event.Result.Status]
+
+	// The latest tracking detail describes the event
+	if details := event.Result.TrackingDetails; len(details) > 0 {
+		latest := details[len(details)-1]
+		update.Description = latest.Message
+		update.OccurredAt = latest.Datetime
+		var parts []string
+		for _, part := range []string{latest.TrackingLocation.City, latest.TrackingLocation.State, latest.TrackingLocation.Country} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		update.Location = strings.Join(parts, ", ")
+	}
+	return update, nil
+}
+
+func (c *EasyPostCarrier) createShipment(ctx context.Context, parcel Parcel, destination Address) (*easyPostShipment, error) {
+	body := map[string]interface{}{
+		"shipment": map[string]interface{}{
+			"from_address": toEasyPostAddress(c.cfg.FromAddress),
+			"to_address":   toEasyPostAddress(destination),
+			"parcel": easyPostParcel{
+				Weight: float64(parcel.WeightGrams) / gramsPerOunce,
+				Length: parcel.LengthCM / cmPerInch,
+				Width:  parcel.WidthCM / cmPerInch,
+				Height: parcel.HeightCM / cmPerInch,
+			},
+		},
+	}
+
+	var shipment easyPostShipment
+	if err := c.do(ctx, "/v2/shipments", body, &shipment); err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// do sends a JSON POST request and decodes the response into out
+func (c *EasyPostCarrier) do(ctx context.Context, path string, body, out interface{}) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+path, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.cfg.APIKey, "")
+
+	code, respBody, err := send(c.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("easypost: request failed: %w", err)
+	}
+
+	if code < 200 || code > 299 {
+		var decoded easyPostErrorResponse
+		_ = json.Unmarshal(respBody, &decoded)
+		return fmt.Errorf("easypost: unexpected status %d: %s", code, decoded.Error.Message)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("easypost: invalid response: %w", err)
+	}
+	return nil
+}
+
+func toEasyPostAddress(a Address) easyPostAddress {
+	return easyPostAddress{
+		Name:    a.Name,
+		Street1: a.Street1,
+		Street2: a.Street2,
+		City:    a.City,
+		State:   a.State,
+		Zip:     a.PostalCode,
+		Country: a.Country,
+	}
+}
+
+func toRate(r easyPostRate) (Rate, error) {
+	amount, err := strconv.ParseFloat(r.Rate, 64)
+	if err != nil {
+		return Rate{}, fmt.Errorf("easypost: invalid rate %q for %s %s", r.Rate, r.Carrier, r.Service)
+	}
+	return Rate{
+		Carrier:       CarrierEasyPost,
+		Service:       r.Carrier + " " + r.Service,
+		Amount:        amount,
+		Currency:      r.Currency,
+		EstimatedDays: r.DeliveryDays,
+	}, nil
+}
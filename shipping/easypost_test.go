@@ -0,0 +1,153 @@
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const easyPostRates = `{"id": "shp_1", "rates": [
+	{"id": "rate_1", "carrier": "USPS", "service": "Priority", "rate": "7.58", "currency": "USD", "delivery_days": 2},
+	{"id": "rate_2", "carrier": "UPS", "service": "Ground", "rate": "9.10", "currency": "USD", "delivery_days": 5}]}`
+
+func newTestEasyPost(t *testing.T, handler http.HandlerFunc) *EasyPostCarrier {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	carrier, err := NewEasyPostCarrier(EasyPostConfig{
+		APIKey:      "EZ_test",
+		FromAddress: Address{Name: "Warehouse", Street1: "1 Dock St", City: "Austin", State: "TX", PostalCode: "73301", Country: "US"},
+		BaseURL:     server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return carrier
+}
+
+func TestEasyPostRates(t *testing.T) {
+	carrier := newTestEasyPost(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "EZ_test" || r.URL.Path != "/v2/shipments" {
+			t.Errorf("Unexpected request %s with user %q", r.URL.Path, user)
+		}
+		var body struct {
+			Shipment struct {
+				ToAddress easyPostAddress `json:"to_address"`
+				Parcel    easyPostParcel  `json:"parcel"`
+			} `json:"shipment"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Shipment.ToAddress.Zip != "10001" || math.Abs(body.Shipment.Parcel.Weight-35.27) > 0.01 || body.Shipment.Parcel.Length != 10 {
+			t.Errorf("Unexpected shipment %+v", body.Shipment)
+		}
+		w.Write([]byte(easyPostRates))
+	})
+
+	rates, err := carrier.Rates(context.Background(), Parcel{WeightGrams: 1000, LengthCM: 25.4, WidthCM: 12.7, HeightCM: 5.08}, Address{PostalCode: "10001", Country: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := Rate{Carrier: CarrierEasyPost, Service: "USPS Priority", Amount: 7.58, Currency: "USD", EstimatedDays: 2}
+	if len(rates) != 2 || rates[0] != expected {
+		t.Errorf("Unexpected rates %v", rates)
+	}
+}
+
+func TestEasyPostBuyLabel(t *testing.T) {
+	var bought string
+	carrier := newTestEasyPost(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/shipments":
+			w.Write([]byte(easyPostRates))
+		case "/v2/shipments/shp_1/buy":
+			var body struct {
+				Rate struct {
+					ID string `json:"id"`
+				} `json:"rate"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			bought = body.Rate.ID
+			w.Write([]byte(`{"id": "shp_1", "tracking_code": "1Z999", "postage_label": {"label_url": "https://easypost.example/label.png"}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	})
+
+	label, err := carrier.BuyLabel(context.Background(), "UPS Ground", Parcel{WeightGrams: 1000}, Address{PostalCode: "10001", Country: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bought != "rate_2" || label.TrackingNumber != "1Z999" || label.LabelURL != "https://easypost.example/label.png" || label.Rate.Amount != 9.10 {
+		t.Errorf("Unexpected label %+v bought with %s", label, bought)
+	}
+
+	if _, err := carrier.BuyLabel(context.Background(), "FedEx Overnight", Parcel{WeightGrams: 1000}, Address{PostalCode: "10001", Country: "US"}); !errors.Is(err, ErrUnknownService) {
+		t.Errorf("Expected ErrUnknownService, got %v", err)
+	}
+}
+
+func TestEasyPostError(t *testing.T) {
+	carrier := newTestEasyPost(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error": {"code": "ADDRESS.VERIFY.FAILURE", "message": "Address not found"}}`))
+	})
+
+	_, err := carrier.Rates(context.Background(), Parcel{WeightGrams: 1000}, Address{PostalCode: "00000", Country: "US"})
+	if err == nil || errors.Is(err, ErrNoRate) {
+		t.Errorf("Expected a carrier error, got %v", err)
+	}
+}
+
+func TestEasyPostParseTrackingWebhook(t *testing.T) {
+	carrier := newTestEasyPost(t, func(w http.ResponseWriter, r *http.Request) {})
+	carrier.cfg.WebhookSecret = "ez_whsec"
+
+	body := `{"id": "evt_1", "description": "tracker.updated", "result": {"tracking_code": "9400", "status": "out_for_delivery",
+		"tracking_details": [
+			{"message": "Arrived at facility", "status": "in_transit", "datetime": "2024-05-01T08:00:00Z"},
+			{"message": "Out for delivery", "status": "out_for_delivery", "datetime": "2024-05-02T07:30:00Z",
+				"tracking_location": {"city": "NEW YORK", "state": "NY", "country": "US"}}]}}`
+	header := http.Header{"X-Hmac-Signature": {"hmac-sha256-hex=" + sign(body, "ez_whsec")}}
+
+	update, err := carrier.ParseTrackingWebhook(context.Background(), header, []byte(body))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if update.EventID != "evt_1" || update.TrackingNumber != "9400" || update.Status != StatusOutForDelivery ||
+		update.Description != "Out for delivery" || update.Location != "NEW YORK, NY, US" || update.OccurredAt.Hour() != 7 {
+		t.Errorf("Unexpected update %+v", update)
+	}
+
+	header.Set("X-Hmac-Signature", "hmac-sha256-hex="+sign(body, "other"))
+	if _, err := carrier.ParseTrackingWebhook(context.Background(), header, []byte(body)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+
+	carrier.cfg.WebhookSecret = ""
+	if _, err := carrier.ParseTrackingWebhook(context.Background(), header, []byte(body)); !errors.Is(err, ErrWebhooksDisabled) {
+		t.Errorf("Expected ErrWebhooksDisabled, got %v", err)
+	}
+}
+
+func TestEasyPostParseTrackingWebhook_IgnoresOtherEvents(t *testing.T) {
+	carrier := newTestEasyPost(t, func(w http.ResponseWriter, r *http.Request) {})
+	carrier.cfg.WebhookSecret = "ez_whsec"
+
+	for _, body := range []string{
+		`{"id": "evt_2", "description": "batch.created", "result": {}}`,
+		`{"id": "evt_3", "description": "tracker.updated", "result": {"tracking_code": "9400", "status": "unknown"}}`,
+	} {
+		header := http.Header{"X-Hmac-Signature": {"hmac-sha256-hex=" + sign(body, "ez_whsec")}}
+		update, err := carrier.ParseTrackingWebhook(context.Background(), header, []byte(body))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if update.Status != "" {
+			t.Errorf("Expected %s to be ignored, got status %s", update.EventID, update.Status)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FlatRateService is a service shipped at one price whatever the parcel
+type FlatRateService struct {
+	Name          string
+	Amount        float64
+	EstimatedDays int32
+}
+
+// FlatRateConfig holds the settings of the flat-rate carrier
+type FlatRateConfig struct {
+	Services []FlatRateService
+	Currency string
+	// MaxWeightGrams is the heaviest parcel shipped; zero means no limit
+	MaxWeightGrams int64
+	// LabelBaseURL is where labels are served, by tracking number
+	LabelBaseURL string
+	// WebhookSecret signs tracking webhooks; empty disables them
+	WebhookSecret string
+}
+
+// FlatRateCarrier charges a fixed price per service, for shipping the business
+// fulfils itself. It issues its own tracking numbers.
+type FlatRateCarrier struct {
+	cfg FlatRateConfig
+}
+
+// NewFlatRateCarrier creates a new flat-rate carrier
+func NewFlatRateCarrier(cfg FlatRateConfig) (*FlatRateCarrier, error) {
+	if len(cfg.Services) == 0 {
+		return nil, errors.New("flat rate: at least one service is required")
+	}
+	if cfg.Currency == "" {
+		return nil, errors.New("flat rate: currency is required")
+	}
+	return &FlatRateCarrier{cfg: cfg}, nil
+}
+
+// Name returns "flat_rate"
+func (c *FlatRateCarrier) Name() string {
+	return CarrierFlatRate
+}
+
+// Rates returns every service's rate, unless the parcel is over the weight limit
+func (c *FlatRateCarrier) Rates(ctx context.Context, parcel Parcel, destination Address) ([]Rate, error) {
+	if c.cfg.MaxWeightGrams > 0 && parcel.WeightGrams > c.cfg.MaxWeightGrams {
+		return nil, ErrNoRate
+	}
+	rates := make([]Rate, len(c.cfg.Services))
+	for i, s := range c.cfg.Services {
+		rates[i] = c.rate(s)
+	}
+	return rates, nil
+}
+
+// BuyLabel issues a tracking number for the service
+func (c *FlatRateCarrier) BuyLabel(ctx context.Context, service string, parcel Parcel, destination Address) (*Label, error) {
+	for _, s := range c.cfg.Services {
+		if s.Name != service {
+			continue
+		}
+		if c.cfg.MaxWeightGrams > 0 && parcel.WeightGrams > c.cfg.MaxWeightGrams {
+			return nil, ErrNoRate
+		}
+		tracking := newTrackingNumber("FR")
+		return &Label{TrackingNumber: tracking, LabelURL: labelURL(c.cfg.LabelBaseURL, tracking), Rate: c.rate(s)}, nil
+	}
+	return nil, ErrUnknownService
+}
+
+func (c *FlatRateCarrier) rate(s FlatRateService) Rate {
+	return Rate{Carrier: CarrierFlatRate, Service: s.Name, Amount: s.Amount, Currency: c.cfg.Currency, EstimatedDays: s.EstimatedDays}
+}
+
+// ParseTrackingWebhook verifies and parses a signed tracking webhook
+func (c *FlatRateCarrier) ParseTrackingWebhook(ctx context.Context, header http.Header, body []byte) (*TrackingUpdate, error) {
+	return parseSignedTrackingWebhook(c.cfg.WebhookSecret, header, body)
+}
+
+// ParseFlatRates parses a comma-separated list of service=amount:days entries,
+// e.g. "standard=4.99:5,express=14.99:2". The days are optional.
+func ParseFlatRates(s string) ([]FlatRateService, error) {
+	var services []FlatRateService
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, price, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid flat rate %q: expected service=amount:days", entry)
+		}
+		amountText, daysText, hasDays := strings.Cut(price, ":")
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(amountText), 64)
+		if err != nil || amount < 0 {
+			return nil, fmt.Errorf("invalid flat rate %q: amount must be a non-negative number", entry)
+		}
+		var days int64
+		if hasDays {
+			if days, err = strconv.ParseInt(strings.TrimSpace(daysText), 10, 32); err != nil || days < 0 {
+				return nil, fmt.Errorf("invalid flat rate %q: days must be a non-negative integer", entry)
+			}
+		}
+		services = append(services, FlatRateService{Name: strings.TrimSpace(name), Amount: amount, EstimatedDays: int32(days)})
+	}
+	return services, nil
+}
@@ -0,0 +1,66 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFlatRates(t *testing.T) {
+	services, err := ParseFlatRates("standard=4.99:5, express=14.99:2,pickup=0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []FlatRateService{
+		{Name: "standard", Amount: 4.99, EstimatedDays: 5},
+		{Name: "express", Amount: 14.99, EstimatedDays: 2},
+		{Name: "pickup", Amount: 0},
+	}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("Expected %v, got %v", expected, services)
+	}
+
+	for _, invalid := range []string{"standard", "=4.99", "standard=cheap", "standard=-1", "standard=4.99:soon"} {
+		if _, err := ParseFlatRates(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestFlatRateCarrier(t *testing.T) {
+	carrier, err := NewFlatRateCarrier(FlatRateConfig{
+		Services:       []FlatRateService{{Name: "standard", Amount: 4.99, EstimatedDays: 5}},
+		Currency:       "USD",
+		MaxWeightGrams: 2000,
+		LabelBaseURL:   "https://labels.example.com/",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ctx := context.Background()
+
+	rates, err := carrier.Rates(ctx, Parcel{WeightGrams: 1500}, Address{Country: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rates) != 1 || rates[0] != (Rate{Carrier: CarrierFlatRate, Service: "standard", Amount: 4.99, Currency: "USD", EstimatedDays: 5}) {
+		t.Errorf("Unexpected rates %v", rates)
+	}
+
+	label, err := carrier.BuyLabel(ctx, "standard", Parcel{WeightGrams: 1500}, Address{Country: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(label.TrackingNumber, "FR") || label.LabelURL != "https://labels.example.com/"+label.TrackingNumber+".pdf" {
+		t.Errorf("Unexpected label %+v", label)
+	}
+
+	if _, err := carrier.Rates(ctx, Parcel{WeightGrams: 2500}, Address{Country: "US"}); !errors.Is(err, ErrNoRate) {
+		t.Errorf("Expected ErrNoRate for an overweight parcel, got %v", err)
+	}
+	if _, err := carrier.BuyLabel(ctx, "overnight", Parcel{WeightGrams: 1500}, Address{Country: "US"}); !errors.Is(err, ErrUnknownService) {
+		t.Errorf("Expected ErrUnknownService, got %v", err)
+	}
+}
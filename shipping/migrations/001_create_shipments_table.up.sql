@@ -0,0 +1,22 @@
+-- Order shipments with purchased labels. The destination is stored as the
+-- address JSON the label was bought for.
+CREATE TABLE IF NOT EXISTS shipments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    carrier VARCHAR(20) NOT NULL,
+    service VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'CREATED' CHECK (status IN ('CREATED')),
+    tracking_number VARCHAR(100) NOT NULL,
+    label_url TEXT NOT NULL DEFAULT '',
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL,
+    weight_grams BIGINT NOT NULL CHECK (weight_grams > 0),
+    length_cm DOUBLE PRECISION NOT NULL DEFAULT 0,
+    width_cm DOUBLE PRECISION NOT NULL DEFAULT 0,
+    height_cm DOUBLE PRECISION NOT NULL DEFAULT 0,
+    destination JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (carrier, tracking_number)
+);
+
+CREATE INDEX idx_shipments_order ON shipments(order_id, created_at DESC);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tracking_events_shipment;
+DROP TABLE IF EXISTS tracking_events;
+
+ALTER TABLE shipments DROP COLUMN IF EXISTS status_at;
+UPDATE shipments SET status = 'CREATED' WHERE status <> 'CREATED';
+ALTER TABLE shipments DROP CONSTRAINT IF EXISTS shipments_status_check;
+ALTER TABLE shipments ADD CONSTRAINT shipments_status_check CHECK (status IN ('CREATED'));
@@ -0,0 +1,23 @@
+-- Shipments move through the statuses their carrier's tracking events report.
+-- status_at is when the event behind the current status happened, so that
+-- events delivered out of order do not move a shipment back.
+ALTER TABLE shipments DROP CONSTRAINT IF EXISTS shipments_status_check;
+ALTER TABLE shipments ADD CONSTRAINT shipments_status_check
+    CHECK (status IN ('CREATED', 'IN_TRANSIT', 'OUT_FOR_DELIVERY', 'DELIVERED', 'RETURNED', 'EXCEPTION'));
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS status_at TIMESTAMP;
+
+-- Carrier tracking events received by webhook. A carrier event is recorded
+-- once, so redeliveries are acknowledged without being applied twice.
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id UUID PRIMARY KEY,
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    carrier_event_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (shipment_id, carrier_event_id)
+);
+
+CREATE INDEX idx_tracking_events_shipment ON tracking_events(shipment_id, occurred_at DESC);
@@ -0,0 +1,85 @@
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	notificationpb "github.com/Ujjwaljain16/E-commerce-Backend/notification/pb"
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventShipmentDelivered is the notification service event for a delivered shipment
+const eventShipmentDelivered = "shipment.delivered"
+
+// Notifier tells customers about their shipments
+type Notifier interface {
+	// ShipmentUpdated is called once when a shipment moves to a new status,
+	// with the tracking event that moved it
+	ShipmentUpdated(ctx context.Context, shipment *Shipment, event *TrackingEvent) error
+}
+
+type logNotifier struct {
+	log *logger.Logger
+}
+
+// NewLogNotifier creates a Notifier that only logs the notifications it would
+// send, for deployments without a notification service
+func NewLogNotifier(log *logger.Logger) Notifier {
+	return &logNotifier{log: log}
+}
+
+func (n *logNotifier) ShipmentUpdated(ctx context.Context, shipment *Shipment, event *TrackingEvent) error {
+	n.log.Info(ctx, "Shipment update notification", map[string]interface{}{
+		"shipment_id":     shipment.ID,
+		"order_id":        shipment.OrderID,
+		"status":          shipment.Status,
+		"tracking_number": shipment.TrackingNumber,
+		"description":     event.Description,
+	})
+	return nil
+}
+
+type grpcNotifier struct {
+	notifications notificationpb.NotificationServiceClient
+	orders        orderpb.OrderServiceClient
+}
+
+// NewGRPCNotifier creates a Notifier that publishes shipment.delivered events
+// to the notification service, addressed to the customer of the shipment's
+// order. Other status changes are not notified.
+func NewGRPCNotifier(notifications notificationpb.NotificationServiceClient, orders orderpb.OrderServiceClient) Notifier {
+	return &grpcNotifier{notifications: notifications, orders: orders}
+}
+
+func (n *grpcNotifier) ShipmentUpdated(ctx context.Context, shipment *Shipment, event *TrackingEvent) error {
+	if shipment.Status != StatusDelivered {
+		return nil
+	}
+
+	order, err := n.orders.GetOrder(ctx, &orderpb.GetOrderRequest{OrderId: shipment.OrderID})
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	// A shipment is delivered once, so its ID identifies the event
+	_, err = n.notifications.PublishEvent(ctx, &notificationpb.PublishEventRequest{
+		EventId: eventShipmentDelivered + ":" + shipment.ID,
+		Type:    eventShipmentDelivered,
+		UserId:  order.Order.UserId,
+		Data: map[string]string{
+			"order_id":        shipment.OrderID,
+			"carrier":         shipment.Carrier,
+			"tracking_number": shipment.TrackingNumber,
+			"location":        event.Location,
+			"delivered_at":    event.OccurredAt.UTC().Format(time.RFC3339),
+		},
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", eventShipmentDelivered, err)
+	}
+	return nil
+}
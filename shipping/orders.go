@@ -0,0 +1,53 @@
+package shipping
+
+import (
+	"context"
+
+	orderpb "github.com/Ujjwaljain16/E-commerce-Backend/order/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Order statuses set by the shipping service
+const (
+	orderStatusFulfilled = "FULFILLED"
+	orderStatusDelivered = "DELIVERED"
+)
+
+// Orders moves orders along as their shipments are tracked
+type Orders interface {
+	// MarkFulfilled moves an order to FULFILLED. An order that is not PAID
+	// is left as it is.
+	MarkFulfilled(ctx context.Context, orderID string) error
+	// MarkDelivered moves an order to DELIVERED. An order that is not
+	// FULFILLED is left as it is.
+	MarkDelivered(ctx context.Context, orderID string) error
+}
+
+type grpcOrders struct {
+	client orderpb.OrderServiceClient
+}
+
+// NewGRPCOrders creates Orders backed by the order service
+func NewGRPCOrders(client orderpb.OrderServiceClient) Orders {
+	return &grpcOrders{client: client}
+}
+
+func (o *grpcOrders) MarkFulfilled(ctx context.Context, orderID string) error {
+	return o.setStatus(ctx, orderID, orderStatusFulfilled)
+}
+
+func (o *grpcOrders) MarkDelivered(ctx context.Context, orderID string) error {
+	return o.setStatus(ctx, orderID, orderStatusDelivered)
+}
+
+// setStatus moves an order to status. The order service refuses
+// transitions the order state machine does not allow with FAILED_PRECONDITION,
+// which means the order has already moved on or cannot move this way.
+func (o *grpcOrders) setStatus(ctx context.Context, orderID, to string) error {
+	_, err := o.client.UpdateOrderStatus(ctx, &orderpb.UpdateOrderStatusRequest{OrderId: orderID, Status: to})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil
+	}
+	return err
+}
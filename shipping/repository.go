@@ -0,0 +1,274 @@
+package shipping
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Shipment statuses
+const (
+	StatusCreated        = "CREATED"
+	StatusInTransit      = "IN_TRANSIT"
+	StatusOutForDelivery = "OUT_FOR_DELIVERY"
+	StatusDelivered      = "DELIVERED"
+	StatusReturned       = "RETURNED"
+	StatusException      = "EXCEPTION"
+)
+
+var (
+	// ErrShipmentNotFound is returned when a shipment does not exist
+	ErrShipmentNotFound = errors.New("shipment not found")
+	// ErrDuplicateEvent is returned when a tracking event was already recorded
+	ErrDuplicateEvent = errors.New("tracking event already recorded")
+)
+
+// Shipment is an order's parcel with a purchased label
+type Shipment struct {
+	ID             string
+	OrderID        string
+	Carrier        string
+	Service        string
+	Status         string
+	TrackingNumber string
+	LabelURL       string
+	Amount         float64
+	Currency       string
+	Parcel         Parcel
+	Destination    Address
+	CreatedAt      time.Time
+	// StatusAt is when the tracking event behind Status happened; it is zero
+	// until the first tracking event
+	StatusAt time.Time
+}
+
+// TrackingEvent is a carrier's report of a shipment's progress
+type TrackingEvent struct {
+	ID         string
+	ShipmentID string
+	// CarrierEventID is the carrier's event ID; deliveries of the same event share it
+	CarrierEventID string
+	Status         string
+	Description    string
+	Location       string
+	OccurredAt     time.Time
+	ReceivedAt     time.Time
+}
+
+// Repository handles shipment data persistence
+type Repository interface {
+	Create(ctx context.Context, shipment *Shipment) (*Shipment, error)
+	GetByID(ctx context.Context, id string) (*Shipment, error)
+	GetByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (*Shipment, error)
+	// RecordTrackingEvent stores a tracking event and moves its shipment to
+	// the event's status, unless the shipment already has a status from a
+	// later event. It returns the shipment and its status before the event,
+	// or ErrDuplicateEvent when the event was already recorded.
+	RecordTrackingEvent(ctx context.Context, event *TrackingEvent) (*Shipment, string, error)
+	// DeleteTrackingEvent forgets a recorded tracking event so that a
+	// redelivery of it is applied again
+	DeleteTrackingEvent(ctx context.Context, shipmentID, carrierEventID string) error
+	// ListTrackingEvents returns a shipment's tracking events, newest first
+	ListTrackingEvents(ctx context.Context, shipmentID string) ([]*TrackingEvent, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL shipment repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const shipmentColumns = `id, order_id, carrier, service, status, tracking_number, label_url, amount, currency,
+	weight_grams, length_cm, width_cm, height_cm, destination, created_at, status_at`
+
+// Create inserts a shipment
+func (r *postgresRepository) Create(ctx context.Context, shipment *Shipment) (*Shipment, error) {
+	shipment.ID = uuid.New().String()
+
+	destination, err := json.Marshal(shipment.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode destination: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO shipments (id, order_id, carrier, service, status, tracking_number, label_url, amount, currency,
+			weight_grams, length_cm, width_cm, height_cm, destination, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`, shipment.ID, shipment.OrderID, shipment.Carrier, shipment.Service, shipment.Status, shipment.TrackingNumber,
+		shipment.LabelURL, shipment.Amount, shipment.Currency, shipment.Parcel.WeightGrams, shipment.Parcel.LengthCM,
+		shipment.Parcel.WidthCM, shipment.Parcel.HeightCM, destination, shipment.CreatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create shipment", map[string]interface{}{"error": err.Error(), "order_id": shipment.OrderID})
+		return nil, fmt.Errorf("failed to create shipment: %w", err)
+	}
+
+	r.log.Info(ctx, "Shipment created", map[string]interface{}{"shipment_id": shipment.ID, "order_id": shipment.OrderID, "carrier": shipment.Carrier})
+	return shipment, nil
+}
+
+// GetByID retrieves a shipment
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Shipment, error) {
+	query := "SELECT " + shipmentColumns + " FROM shipments WHERE id = $1"
+
+	shipment, err := scanShipment(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrShipmentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get shipment", map[string]interface{}{"error": err.Error(), "shipment_id": id})
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	return shipment, nil
+}
+
+// GetByTrackingNumber retrieves a carrier's shipment by tracking number
+func (r *postgresRepository) GetByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (*Shipment, error) {
+	query := "SELECT " + shipmentColumns + " FROM shipments WHERE carrier = $1 AND tracking_number = $2"
+
+	shipment, err := scanShipment(r.db.QueryRowContext(ctx, query, carrier, trackingNumber))
+	if err == sql.ErrNoRows {
+		return nil, ErrShipmentNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get shipment by tracking number", map[string]interface{}{"error": err.Error(), "carrier": carrier, "tracking_number": trackingNumber})
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	return shipment, nil
+}
+
+// RecordTrackingEvent stores a tracking event and applies its status to the
+// shipment in one transaction
+func (r *postgresRepository) RecordTrackingEvent(ctx context.Context, event *TrackingEvent) (*Shipment, string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM shipments WHERE id = $1 FOR UPDATE", event.ShipmentID).Scan(&previous)
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, "", ErrShipmentNotFound
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to lock shipment: %w", err)
+	}
+
+	event.ID = uuid.New().String()
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO tracking_events (id, shipment_id, carrier_event_id, status, description, location, occurred_at, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (shipment_id, carrier_event_id) DO NOTHING
+	`, event.ID, event.ShipmentID, event.CarrierEventID, event.Status, event.Description, event.Location, event.OccurredAt, event.ReceivedAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to record tracking event: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, "", fmt.Errorf("failed to record tracking event: %w", err)
+	} else if n == 0 {
+		return nil, "", ErrDuplicateEvent
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE shipments SET status = $2, status_at = $3
+		WHERE id = $1 AND (status_at IS NULL OR status_at <= $3)
+	`, event.ShipmentID, event.Status, event.OccurredAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update shipment status: %w", err)
+	}
+
+	shipment, err := scanShipment(tx.QueryRowContext(ctx, "SELECT "+shipmentColumns+" FROM shipments WHERE id = $1", event.ShipmentID))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Tracking event recorded", map[string]interface{}{
+		"shipment_id": shipment.ID,
+		"event_id":    event.CarrierEventID,
+		"from":        previous,
+		"to":          shipment.Status,
+	})
+	return shipment, previous, nil
+}
+
+// DeleteTrackingEvent removes a recorded tracking event
+func (r *postgresRepository) DeleteTrackingEvent(ctx context.Context, shipmentID, carrierEventID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM tracking_events WHERE shipment_id = $1 AND carrier_event_id = $2", shipmentID, carrierEventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tracking event: %w", err)
+	}
+	return nil
+}
+
+// ListTrackingEvents returns a shipment's tracking events, newest first
+func (r *postgresRepository) ListTrackingEvents(ctx context.Context, shipmentID string) ([]*TrackingEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, shipment_id, carrier_event_id, status, description, location, occurred_at, received_at
+		FROM tracking_events
+		WHERE shipment_id = $1
+		ORDER BY occurred_at DESC, received_at DESC
+	`, shipmentID)
+	if pgerr.IsMalformedID(err) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to list tracking events", map[string]interface{}{"error": err.Error(), "shipment_id": shipmentID})
+		return nil, fmt.Errorf("failed to list tracking events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*TrackingEvent
+	for rows.Next() {
+		e := &TrackingEvent{}
+		if err := rows.Scan(&e.ID, &e.ShipmentID, &e.CarrierEventID, &e.Status, &e.Description, &e.Location, &e.OccurredAt, &e.ReceivedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tracking event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tracking events: %w", err)
+	}
+	return events, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanShipment(row rowScanner) (*Shipment, error) {
+	s := &Shipment{}
+	var destination []byte
+	var statusAt sql.NullTime
+	err := row.Scan(&s.ID, &s.OrderID, &s.Carrier, &s.Service, &s.Status, &s.TrackingNumber, &s.LabelURL, &s.Amount, &s.Currency,
+		&s.Parcel.WeightGrams, &s.Parcel.LengthCM, &s.Parcel.WidthCM, &s.Parcel.HeightCM, &destination, &s.CreatedAt, &statusAt)
+	if err != nil {
+		return nil, err
+	}
+	s.StatusAt = statusAt.Time
+	if err := json.Unmarshal(destination, &s.Destination); err != nil {
+		return nil, fmt.Errorf("failed to decode destination: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,170 @@
+package shipping
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("shipping-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	shipment := &Shipment{
+		OrderID:        "order-1",
+		Carrier:        CarrierFlatRate,
+		Service:        "standard",
+		Status:         StatusCreated,
+		TrackingNumber: "FR1",
+		Amount:         4.99,
+		Currency:       "USD",
+		Parcel:         Parcel{WeightGrams: 500},
+		Destination:    Address{PostalCode: "10001", Country: "US"},
+		CreatedAt:      now,
+	}
+
+	mock.ExpectExec(`INSERT INTO shipments`).
+		WithArgs(sqlmock.AnyArg(), "order-1", CarrierFlatRate, "standard", StatusCreated, "FR1", "", 4.99, "USD",
+			int64(500), 0.0, 0.0, 0.0, []byte(`{"name":"","street1":"","city":"","postal_code":"10001","country":"US"}`), now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	created, err := repo.Create(context.Background(), shipment)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+var shipmentColumnNames = []string{
+	"id", "order_id", "carrier", "service", "status", "tracking_number", "label_url", "amount", "currency",
+	"weight_grams", "length_cm", "width_cm", "height_cm", "destination", "created_at", "status_at",
+}
+
+// shipmentRow returns ship-1 with status; statusAt may be nil
+func shipmentRow(status string, statusAt interface{}) *sqlmock.Rows {
+	return sqlmock.NewRows(shipmentColumnNames).
+		AddRow("ship-1", "order-1", CarrierEasyPost, "USPS Priority", status, "9400", "https://label", 7.58, "USD",
+			1000, 10.0, 10.0, 10.0, []byte(`{"name": "Ada", "postal_code": "10001", "country": "US"}`), time.Now(), statusAt)
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM shipments WHERE id = \$1`).WithArgs("ship-1").WillReturnRows(shipmentRow(StatusCreated, nil))
+
+	shipment, err := repo.GetByID(context.Background(), "ship-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if shipment.Destination.Name != "Ada" || shipment.Parcel.WeightGrams != 1000 || shipment.TrackingNumber != "9400" {
+		t.Errorf("Unexpected shipment %+v", shipment)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM shipments WHERE id = \$1`).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM shipments WHERE id = \$1`).WithArgs("not-a-uuid").WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(context.Background(), id); !errors.Is(err, ErrShipmentNotFound) {
+			t.Errorf("Expected ErrShipmentNotFound for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestRecordTrackingEvent(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	occurred := time.Now().Add(-time.Hour)
+	received := time.Now()
+	event := &TrackingEvent{ShipmentID: "ship-1", CarrierEventID: "evt_1", Status: StatusInTransit, Description: "Departed", OccurredAt: occurred, ReceivedAt: received}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM shipments WHERE id = \$1 FOR UPDATE`).WithArgs("ship-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(StatusCreated))
+	mock.ExpectExec(`INSERT INTO tracking_events .* ON CONFLICT \(shipment_id, carrier_event_id\) DO NOTHING`).
+		WithArgs(sqlmock.AnyArg(), "ship-1", "evt_1", StatusInTransit, "Departed", "", occurred, received).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE shipments SET status = \$2, status_at = \$3\s+WHERE id = \$1 AND \(status_at IS NULL OR status_at <= \$3\)`).
+		WithArgs("ship-1", StatusInTransit, occurred).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .* FROM shipments WHERE id = \$1`).WithArgs("ship-1").WillReturnRows(shipmentRow(StatusInTransit, occurred))
+	mock.ExpectCommit()
+
+	shipment, previous, err := repo.RecordTrackingEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if previous != StatusCreated || shipment.Status != StatusInTransit || !shipment.StatusAt.Equal(occurred) {
+		t.Errorf("Unexpected result %q, %+v", previous, shipment)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecordTrackingEvent_Duplicate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM shipments`).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(StatusInTransit))
+	mock.ExpectExec(`INSERT INTO tracking_events`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, _, err := repo.RecordTrackingEvent(context.Background(), &TrackingEvent{ShipmentID: "ship-1", CarrierEventID: "evt_1", Status: StatusInTransit})
+	if !errors.Is(err, ErrDuplicateEvent) {
+		t.Errorf("Expected ErrDuplicateEvent, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListTrackingEvents(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "shipment_id", "carrier_event_id", "status", "description", "location", "occurred_at", "received_at"}).
+		AddRow("te-2", "ship-1", "evt_2", StatusDelivered, "Delivered", "New York, NY", now, now).
+		AddRow("te-1", "ship-1", "evt_1", StatusInTransit, "Departed", "", now.Add(-time.Hour), now)
+	mock.ExpectQuery(`SELECT .* FROM tracking_events\s+WHERE shipment_id = \$1\s+ORDER BY occurred_at DESC`).WithArgs("ship-1").WillReturnRows(rows)
+
+	events, err := repo.ListTrackingEvents(context.Background(), "ship-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(events) != 2 || events[0].Status != StatusDelivered || events[1].CarrierEventID != "evt_1" {
+		t.Errorf("Unexpected events %+v", events)
+	}
+}
@@ -0,0 +1,160 @@
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/shipping/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CarrierSandbox is the name of the sandbox carrier
+const CarrierSandbox = "sandbox"
+
+// SandboxUnservedCountry is the destination country the sandbox carrier does
+// not ship to, so shipments without a rate can be exercised
+const SandboxUnservedCountry = "ZZ"
+
+// sandboxServices are the services of the sandbox carrier
+var sandboxServices = []FlatRateService{
+	{Name: "standard", Amount: 4.99, EstimatedDays: 5},
+	{Name: "express", Amount: 14.99, EstimatedDays: 2},
+}
+
+// maxFakeLabels caps the labels a sandbox keeps; the oldest are dropped first
+const maxFakeLabels = 1000
+
+// FakeLabel is a label the sandbox carrier issued
+type FakeLabel struct {
+	TrackingNumber string
+	Service        string
+	WeightGrams    int64
+	Destination    Address
+	Amount         float64
+	Currency       string
+	CreatedAt      time.Time
+}
+
+// SandboxCarrier is a carrier for sandbox mode. It quotes fixed standard and
+// express rates to every country except SandboxUnservedCountry, makes no
+// network calls and records the labels it issues so they can be inspected.
+// Its tracking numbers are sequential, SBX0000000001 first, so a flow run
+// again on a fresh service yields the same ones.
+type SandboxCarrier struct {
+	currency      string
+	webhookSecret string
+	now           func() time.Time
+	mu            sync.Mutex
+	issued        int
+	labels        []*FakeLabel
+}
+
+// NewSandboxCarrier creates a sandbox carrier quoting in currency. Tracking
+// webhooks signed with webhookSecret are accepted, as for the flat-rate
+// carrier; an empty secret disables them.
+func NewSandboxCarrier(currency, webhookSecret string) *SandboxCarrier {
+	return &SandboxCarrier{currency: currency, webhookSecret: webhookSecret, now: time.Now}
+}
+
+// Name returns "sandbox"
+func (c *SandboxCarrier) Name() string {
+	return CarrierSandbox
+}
+
+// Rates returns the sandbox rates, unless the destination is not served
+func (c *SandboxCarrier) Rates(ctx context.Context, parcel Parcel, destination Address) ([]Rate, error) {
+	if destination.Country == SandboxUnservedCountry {
+		return nil, ErrNoRate
+	}
+	rates := make([]Rate, len(sandboxServices))
+	for i, s := range sandboxServices {
+		rates[i] = c.rate(s)
+	}
+	return rates, nil
+}
+
+// BuyLabel issues the next tracking number and records the label
+func (c *SandboxCarrier) BuyLabel(ctx context.Context, service string, parcel Parcel, destination Address) (*Label, error) {
+	for _, s := range sandboxServices {
+		if s.Name != service {
+			continue
+		}
+		if destination.Country == SandboxUnservedCountry {
+			return nil, ErrNoRate
+		}
+		rate := c.rate(s)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.issued++
+		tracking := fmt.Sprintf("SBX%010d", c.issued)
+		if len(c.labels) >= maxFakeLabels {
+			c.labels = c.labels[1:]
+		}
+		c.labels = append(c.labels, &FakeLabel{
+			TrackingNumber: tracking,
+			Service:        service,
+			WeightGrams:    parcel.WeightGrams,
+			Destination:    destination,
+			Amount:         rate.Amount,
+			Currency:       rate.Currency,
+			CreatedAt:      c.now(),
+		})
+		return &Label{TrackingNumber: tracking, Rate: rate}, nil
+	}
+	return nil, ErrUnknownService
+}
+
+func (c *SandboxCarrier) rate(s FlatRateService) Rate {
+	return Rate{Carrier: CarrierSandbox, Service: s.Name, Amount: s.Amount, Currency: c.currency, EstimatedDays: s.EstimatedDays}
+}
+
+// ParseTrackingWebhook verifies and parses a signed tracking webhook
+func (c *SandboxCarrier) ParseTrackingWebhook(ctx context.Context, header http.Header, body []byte) (*TrackingUpdate, error) {
+	return parseSignedTrackingWebhook(c.webhookSecret, header, body)
+}
+
+// Labels returns the labels issued so far, oldest first, with tracking number
+// trackingNumber or, when it is empty, all of them
+func (c *SandboxCarrier) Labels(trackingNumber string) []*FakeLabel {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var labels []*FakeLabel
+	for _, l := range c.labels {
+		if trackingNumber == "" || l.TrackingNumber == trackingNumber {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+// ListFakeLabels lists the labels the sandbox carrier issued, oldest first, so
+// a flow can be checked without a carrier account. It is only served in
+// sandbox mode.
+func (s *Service) ListFakeLabels(ctx context.Context, req *pb.ListFakeLabelsRequest) (*pb.ListFakeLabelsResponse, error) {
+	carrier, err := s.carriers.Get(CarrierSandbox)
+	sandbox, ok := carrier.(*SandboxCarrier)
+	if err != nil || !ok {
+		s.log.Warn(ctx, "List fake labels failed: sandbox mode is off", nil)
+		return nil, status.Error(codes.FailedPrecondition, "fake labels are only recorded in sandbox mode")
+	}
+	labels := sandbox.Labels(req.TrackingNumber)
+
+	resp := &pb.ListFakeLabelsResponse{Labels: make([]*pb.FakeLabel, len(labels))}
+	for i, l := range labels {
+		resp.Labels[i] = &pb.FakeLabel{
+			TrackingNumber: l.TrackingNumber,
+			Service:        l.Service,
+			WeightGrams:    l.WeightGrams,
+			Destination:    toProtoAddress(l.Destination),
+			Amount:         l.Amount,
+			Currency:       l.Currency,
+			CreatedAt:      timestamppb.New(l.CreatedAt),
+		}
+	}
+	return resp, nil
+}
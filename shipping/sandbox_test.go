@@ -0,0 +1,67 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/shipping/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSandboxCarrier(t *testing.T) {
+	carrier := NewSandboxCarrier("USD", "")
+	rates, err := carrier.Rates(context.Background(), Parcel{WeightGrams: 50000}, Address{Country: "US"})
+	if err != nil || len(rates) != 2 || rates[0].Carrier != CarrierSandbox || rates[0].Currency != "USD" {
+		t.Fatalf("Expected the two sandbox rates, got %v, %v", rates, err)
+	}
+	if _, err := carrier.Rates(context.Background(), Parcel{WeightGrams: 500}, Address{Country: SandboxUnservedCountry}); !errors.Is(err, ErrNoRate) {
+		t.Errorf("Expected ErrNoRate for %s, got %v", SandboxUnservedCountry, err)
+	}
+	if _, err := carrier.BuyLabel(context.Background(), "overnight", Parcel{WeightGrams: 500}, Address{Country: "US"}); !errors.Is(err, ErrUnknownService) {
+		t.Errorf("Expected ErrUnknownService, got %v", err)
+	}
+
+	for _, expected := range []string{"SBX0000000001", "SBX0000000002"} {
+		label, err := carrier.BuyLabel(context.Background(), "express", Parcel{WeightGrams: 500}, Address{Country: "US"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if label.TrackingNumber != expected || label.Rate.Amount != 14.99 {
+			t.Errorf("Expected %s at 14.99, got %+v", expected, label)
+		}
+	}
+	if labels := carrier.Labels("SBX0000000002"); len(labels) != 1 || labels[0].Service != "express" {
+		t.Errorf("Expected the second label, got %v", labels)
+	}
+}
+
+func TestListFakeLabels(t *testing.T) {
+	service, _ := setupService(t)
+	if _, err := service.ListFakeLabels(context.Background(), &pb.ListFakeLabelsRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition outside sandbox mode, got %v", err)
+	}
+
+	service, _ = setupService(t, NewSandboxCarrier("USD", ""))
+	resp, err := service.CreateShipment(context.Background(), &pb.CreateShipmentRequest{
+		OrderId: "order-1", Carrier: CarrierSandbox, Service: "standard", Parcel: &pb.Parcel{WeightGrams: 800}, Destination: validDestination(),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Shipment.TrackingNumber != "SBX0000000001" {
+		t.Errorf("Expected tracking number SBX0000000001, got %s", resp.Shipment.TrackingNumber)
+	}
+
+	labels, err := service.ListFakeLabels(context.Background(), &pb.ListFakeLabelsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(labels.Labels) != 1 || labels.Labels[0].WeightGrams != 800 || labels.Labels[0].Destination.Country != "US" || labels.Labels[0].Amount != 4.99 {
+		t.Errorf("Expected the label of order-1, got %v", labels.Labels)
+	}
+	if other, _ := service.ListFakeLabels(context.Background(), &pb.ListFakeLabelsRequest{TrackingNumber: "SBX0000000002"}); len(other.Labels) != 0 {
+		t.Errorf("Expected no label SBX0000000002, got %v", other.Labels)
+	}
+}
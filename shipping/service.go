@@ -0,0 +1,352 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/shipping/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxParcelWeightGrams is the heaviest parcel accepted, well above what
+// parcel carriers take
+const maxParcelWeightGrams = 1000 * 1000
+
+// AdminMethods are the admin-scoped RPCs of the shipping service; they are
+// restricted to the admin network allowlist
+var AdminMethods = []string{
+	pb.ShippingService_CreateShipment_FullMethodName,
+}
+
+// Service implements the ShippingService gRPC interface
+type Service struct {
+	pb.UnimplementedShippingServiceServer
+	repo     Repository
+	carriers *Registry
+	orders   Orders
+	notifier Notifier
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+// NewService creates a new shipping service
+func NewService(repo Repository, carriers *Registry, orders Orders, notifier Notifier, log *logger.Logger) *Service {
+	return &Service{
+		repo:     repo,
+		carriers: carriers,
+		orders:   orders,
+		notifier: notifier,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// GetRates quotes the configured carriers, or only the requested one, in
+// parallel. Carriers that cannot ship the parcel or fail to answer are left
+// out; the call fails only when every carrier asked failed.
+func (s *Service) GetRates(ctx context.Context, req *pb.GetRatesRequest) (*pb.GetRatesResponse, error) {
+	parcel, destination := fromProtoParcel(req.Parcel), fromProtoAddress(req.Destination)
+	if msg := parcelProblem(parcel); msg != "" {
+		s.log.Warn(ctx, "Get rates failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	if msg := destinationProblem(destination, false); msg != "" {
+		s.log.Warn(ctx, "Get rates failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	names := s.carriers.Names()
+	if req.Carrier != "" {
+		if _, err := s.carriers.Get(req.Carrier); err != nil {
+			s.log.Warn(ctx, "Get rates failed: unknown carrier", map[string]interface{}{"carrier": req.Carrier})
+			return nil, status.Error(codes.InvalidArgument, "carrier must be one of "+strings.Join(names, ", "))
+		}
+		names = []string{req.Carrier}
+	}
+
+	results := make([][]Rate, len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		carrier, _ := s.carriers.Get(name)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = carrier.Rates(ctx, parcel, destination)
+		}()
+	}
+	wg.Wait()
+
+	var rates []Rate
+	failed := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			rates = append(rates, results[i]...)
+		case errors.Is(err, ErrNoRate):
+		default:
+			failed++
+			s.log.Warn(ctx, "Carrier rate quote failed", map[string]interface{}{"error": err.Error(), "carrier": names[i]})
+		}
+	}
+	if failed == len(names) {
+		s.log.Error(ctx, "Get rates failed: no carrier answered", map[string]interface{}{"carriers": names})
+		return nil, status.Error(codes.Unavailable, "shipping carriers unavailable")
+	}
+
+	sortRates(rates)
+	resp := &pb.GetRatesResponse{Rates: make([]*pb.Rate, len(rates))}
+	for i, rate := range rates {
+		resp.Rates[i] = toProtoRate(rate)
+	}
+	return resp, nil
+}
+
+// CreateShipment buys a label for an order's parcel and records the shipment
+func (s *Service) CreateShipment(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.CreateShipmentResponse, error) {
+	parcel, destination := fromProtoParcel(req.Parcel), fromProtoAddress(req.Destination)
+	if msg := shipmentProblem(req.OrderId, req.Service, parcel, destination); msg != "" {
+		s.log.Warn(ctx, "Create shipment failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	carrier, err := s.carriers.Get(req.Carrier)
+	if err != nil {
+		s.log.Warn(ctx, "Create shipment failed: unknown carrier", map[string]interface{}{"carrier": req.Carrier})
+		return nil, status.Error(codes.InvalidArgument, "carrier must be one of "+strings.Join(s.carriers.Names(), ", "))
+	}
+
+	label, err := carrier.BuyLabel(ctx, req.Service, parcel, destination)
+	switch {
+	case errors.Is(err, ErrUnknownService):
+		s.log.Warn(ctx, "Create shipment failed: unknown service", map[string]interface{}{"carrier": req.Carrier, "service": req.Service})
+		return nil, status.Error(codes.InvalidArgument, "carrier "+req.Carrier+" has no service "+req.Service)
+	case errors.Is(err, ErrNoRate):
+		s.log.Warn(ctx, "Create shipment failed: service cannot ship parcel", map[string]interface{}{"carrier": req.Carrier, "service": req.Service})
+		return nil, status.Error(codes.FailedPrecondition, "service "+req.Service+" cannot ship this parcel to this destination")
+	case err != nil:
+		s.log.Error(ctx, "Carrier label purchase failed", map[string]interface{}{"error": err.Error(), "carrier": req.Carrier, "order_id": req.OrderId})
+		return nil, status.Error(codes.Unavailable, "shipping carrier unavailable")
+	}
+
+	// The label is bought, so record it even if the caller gave up
+	shipment, err := s.repo.Create(context.WithoutCancel(ctx), &Shipment{
+		OrderID:        req.OrderId,
+		Carrier:        carrier.Name(),
+		Service:        req.Service,
+		Status:         StatusCreated,
+		TrackingNumber: label.TrackingNumber,
+		LabelURL:       label.LabelURL,
+		Amount:         label.Rate.Amount,
+		Currency:       label.Rate.Currency,
+		Parcel:         parcel,
+		Destination:    destination,
+		CreatedAt:      s.now(),
+	})
+	if err != nil {
+		s.log.Error(ctx, "Failed to record bought label", map[string]interface{}{
+			"error":           err.Error(),
+			"order_id":        req.OrderId,
+			"carrier":         carrier.Name(),
+			"tracking_number": label.TrackingNumber,
+		})
+		return nil, status.Error(codes.Internal, "failed to record shipment")
+	}
+
+	return &pb.CreateShipmentResponse{
+		Shipment: toProtoShipment(shipment),
+	}, nil
+}
+
+// GetShipment retrieves a shipment by ID
+func (s *Service) GetShipment(ctx context.Context, req *pb.GetShipmentRequest) (*pb.GetShipmentResponse, error) {
+	if req.ShipmentId == "" {
+		s.log.Warn(ctx, "Get shipment failed: shipment ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "shipment_id is required")
+	}
+
+	shipment, err := s.repo.GetByID(ctx, req.ShipmentId)
+	if errors.Is(err, ErrShipmentNotFound) {
+		s.log.Warn(ctx, "Shipment not found", map[string]interface{}{"shipment_id": req.ShipmentId})
+		return nil, status.Error(codes.NotFound, "shipment not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get shipment", map[string]interface{}{"error": err.Error(), "shipment_id": req.ShipmentId})
+		return nil, status.Error(codes.Internal, "failed to get shipment")
+	}
+
+	return &pb.GetShipmentResponse{
+		Shipment: toProtoShipment(shipment),
+	}, nil
+}
+
+// GetTracking retrieves a shipment with its tracking events, newest first
+func (s *Service) GetTracking(ctx context.Context, req *pb.GetTrackingRequest) (*pb.GetTrackingResponse, error) {
+	if req.ShipmentId == "" {
+		s.log.Warn(ctx, "Get tracking failed: shipment ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "shipment_id is required")
+	}
+
+	shipment, err := s.repo.GetByID(ctx, req.ShipmentId)
+	if errors.Is(err, ErrShipmentNotFound) {
+		s.log.Warn(ctx, "Shipment not found", map[string]interface{}{"shipment_id": req.ShipmentId})
+		return nil, status.Error(codes.NotFound, "shipment not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get shipment", map[string]interface{}{"error": err.Error(), "shipment_id": req.ShipmentId})
+		return nil, status.Error(codes.Internal, "failed to get shipment")
+	}
+
+	events, err := s.repo.ListTrackingEvents(ctx, shipment.ID)
+	if err != nil {
+		s.log.Error(ctx, "Failed to list tracking events", map[string]interface{}{"error": err.Error(), "shipment_id": shipment.ID})
+		return nil, status.Error(codes.Internal, "failed to get tracking events")
+	}
+
+	resp := &pb.GetTrackingResponse{
+		Shipment: toProtoShipment(shipment),
+		Events:   make([]*pb.TrackingEvent, len(events)),
+	}
+	for i, e := range events {
+		resp.Events[i] = toProtoTrackingEvent(e)
+	}
+	return resp, nil
+}
+
+// shipmentProblem validates a shipment request and returns a message
+// describing the first problem, or ""
+func shipmentProblem(orderID, service string, parcel Parcel, destination Address) string {
+	switch {
+	case orderID == "":
+		return "order_id is required"
+	case service == "":
+		return "service is required"
+	}
+	if msg := parcelProblem(parcel); msg != "" {
+		return msg
+	}
+	return destinationProblem(destination, true)
+}
+
+// parcelProblem validates a parcel and returns a message describing the
+// first problem, or ""
+func parcelProblem(p Parcel) string {
+	dimensions := 0
+	for _, d := range []float64{p.LengthCM, p.WidthCM, p.HeightCM} {
+		if d < 0 {
+			return "parcel dimensions must not be negative"
+		}
+		if d > 0 {
+			dimensions++
+		}
+	}
+	switch {
+	case p.WeightGrams <= 0:
+		return "parcel weight_grams must be positive"
+	case p.WeightGrams > maxParcelWeightGrams:
+		return "parcel is too heavy"
+	case dimensions != 0 && dimensions != 3:
+		return "parcel needs all of length_cm, width_cm and height_cm, or none"
+	}
+	return ""
+}
+
+// destinationProblem validates a destination and returns a message
+// describing the first problem, or "". Rates need only the country and postal
+// code; a label needs a full address.
+func destinationProblem(a Address, forLabel bool) string {
+	switch {
+	case len(a.Country) != 2:
+		return "destination country must be a two-letter ISO 3166 code"
+	case a.PostalCode == "":
+		return "destination postal_code is required"
+	case forLabel && (a.Name == "" || a.Street1 == "" || a.City == ""):
+		return "destination name, street1 and city are required"
+	}
+	return ""
+}
+
+func fromProtoParcel(p *pb.Parcel) Parcel {
+	if p == nil {
+		return Parcel{}
+	}
+	return Parcel{WeightGrams: p.WeightGrams, LengthCM: p.LengthCm, WidthCM: p.WidthCm, HeightCM: p.HeightCm}
+}
+
+func fromProtoAddress(a *pb.Address) Address {
+	if a == nil {
+		return Address{}
+	}
+	return Address{
+		Name:       a.Name,
+		Street1:    a.Street1,
+		Street2:    a.Street2,
+		City:       a.City,
+		State:      a.State,
+		PostalCode: a.PostalCode,
+		Country:    strings.ToUpper(a.Country),
+	}
+}
+
+// toProtoRate converts a rate to protobuf
+func toProtoRate(r Rate) *pb.Rate {
+	return &pb.Rate{
+		Carrier:       r.Carrier,
+		Service:       r.Service,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		EstimatedDays: r.EstimatedDays,
+	}
+}
+
+// toProtoShipment converts a shipment to protobuf
+func toProtoShipment(s *Shipment) *pb.Shipment {
+	return &pb.Shipment{
+		Id:             s.ID,
+		OrderId:        s.OrderID,
+		Carrier:        s.Carrier,
+		Service:        s.Service,
+		Status:         s.Status,
+		TrackingNumber: s.TrackingNumber,
+		LabelUrl:       s.LabelURL,
+		Amount:         s.Amount,
+		Currency:       s.Currency,
+		Parcel: &pb.Parcel{
+			WeightGrams: s.Parcel.WeightGrams,
+			LengthCm:    s.Parcel.LengthCM,
+			WidthCm:     s.Parcel.WidthCM,
+			HeightCm:    s.Parcel.HeightCM,
+		},
+		Destination: toProtoAddress(s.Destination),
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+	}
+}
+
+// toProtoAddress converts an address to protobuf
+func toProtoAddress(a Address) *pb.Address {
+	return &pb.Address{
+		Name:       a.Name,
+		Street1:    a.Street1,
+		Street2:    a.Street2,
+		City:       a.City,
+		State:      a.State,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+// toProtoTrackingEvent converts a tracking event to protobuf
+func toProtoTrackingEvent(e *TrackingEvent) *pb.TrackingEvent {
+	return &pb.TrackingEvent{
+		Status:      e.Status,
+		Description: e.Description,
+		Location:    e.Location,
+		OccurredAt:  timestamppb.New(e.OccurredAt),
+	}
+}
@@ -0,0 +1,327 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/shipping/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps shipments and their tracking events, refusing a
+// carrier event it already has and keeping the status of the latest event
+type memoryRepository struct {
+	mu        sync.Mutex
+	shipments map[string]Shipment
+	events    []TrackingEvent
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{shipments: map[string]Shipment{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, shipment *Shipment) (*Shipment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	shipment.ID = uuid.New().String()
+	m.shipments[shipment.ID] = *shipment
+	return shipment, nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Shipment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.shipments[id]
+	if !ok {
+		return nil, ErrShipmentNotFound
+	}
+	return &s, nil
+}
+
+func (m *memoryRepository) GetByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (*Shipment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.shipments {
+		if s.Carrier == carrier && s.TrackingNumber == trackingNumber {
+			return &s, nil
+		}
+	}
+	return nil, ErrShipmentNotFound
+}
+
+func (m *memoryRepository) RecordTrackingEvent(ctx context.Context, event *TrackingEvent) (*Shipment, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.shipments[event.ShipmentID]
+	if !ok {
+		return nil, "", ErrShipmentNotFound
+	}
+	for _, e := range m.events {
+		if e.ShipmentID == event.ShipmentID && e.CarrierEventID == event.CarrierEventID {
+			return nil, "", ErrDuplicateEvent
+		}
+	}
+	event.ID = uuid.New().String()
+	m.events = append(m.events, *event)
+
+	previous := s.Status
+	if s.StatusAt.IsZero() || !s.StatusAt.After(event.OccurredAt) {
+		s.Status, s.StatusAt = event.Status, event.OccurredAt
+		m.shipments[s.ID] = s
+	}
+	return &s, previous, nil
+}
+
+func (m *memoryRepository) DeleteTrackingEvent(ctx context.Context, shipmentID, carrierEventID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = slices.DeleteFunc(m.events, func(e TrackingEvent) bool {
+		return e.ShipmentID == shipmentID && e.CarrierEventID == carrierEventID
+	})
+	return nil
+}
+
+func (m *memoryRepository) ListTrackingEvents(ctx context.Context, shipmentID string) ([]*TrackingEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var events []*TrackingEvent
+	for _, e := range m.events {
+		if e.ShipmentID == shipmentID {
+			events = append(events, &e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].OccurredAt.After(events[j].OccurredAt) })
+	return events, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeOrders records the order updates made, as "fulfilled <id>" or
+// "delivered <id>", and fails them with err
+type fakeOrders struct {
+	mu    sync.Mutex
+	calls []string
+	err   error
+}
+
+func (o *fakeOrders) MarkFulfilled(ctx context.Context, orderID string) error {
+	return o.record("fulfilled " + orderID)
+}
+
+func (o *fakeOrders) MarkDelivered(ctx context.Context, orderID string) error {
+	return o.record("delivered " + orderID)
+}
+
+func (o *fakeOrders) record(call string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return o.err
+	}
+	o.calls = append(o.calls, call)
+	return nil
+}
+
+func (o *fakeOrders) Calls() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return slices.Clone(o.calls)
+}
+
+// fakeNotifier records the statuses customers were notified of
+type fakeNotifier struct {
+	mu       sync.Mutex
+	statuses []string
+}
+
+func (n *fakeNotifier) ShipmentUpdated(ctx context.Context, shipment *Shipment, event *TrackingEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.statuses = append(n.statuses, shipment.Status)
+	return nil
+}
+
+func (n *fakeNotifier) Statuses() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return slices.Clone(n.statuses)
+}
+
+// failingCarrier fails every call with err
+type failingCarrier struct {
+	name string
+	err  error
+}
+
+func (c *failingCarrier) Name() string { return c.name }
+
+func (c *failingCarrier) Rates(ctx context.Context, parcel Parcel, destination Address) ([]Rate, error) {
+	return nil, c.err
+}
+
+func (c *failingCarrier) BuyLabel(ctx context.Context, service string, parcel Parcel, destination Address) (*Label, error) {
+	return nil, c.err
+}
+
+func setupService(t *testing.T, extra ...Carrier) (*Service, *memoryRepository) {
+	flat, err := NewFlatRateCarrier(FlatRateConfig{
+		Services:       []FlatRateService{{Name: "standard", Amount: 4.99, EstimatedDays: 5}, {Name: "express", Amount: 14.99, EstimatedDays: 2}},
+		Currency:       "USD",
+		MaxWeightGrams: 2000,
+		WebhookSecret:  "whsec",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create carrier: %v", err)
+	}
+	table, err := NewTableRateCarrier(TableRateConfig{
+		Rows:     []TableRow{{Service: "ground", Country: AnyCountry, MaxWeightGrams: 10000, Amount: 7.5, EstimatedDays: 4}},
+		Currency: "USD",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create carrier: %v", err)
+	}
+
+	registry, err := NewRegistry(append([]Carrier{flat, table}, extra...)...)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	repo := newMemoryRepository()
+	return NewService(repo, registry, &fakeOrders{}, &fakeNotifier{}, logger.New("shipping-test")), repo
+}
+
+func validDestination() *pb.Address {
+	return &pb.Address{Name: "Ada Lovelace", Street1: "1 Main St", City: "New York", State: "NY", PostalCode: "10001", Country: "us"}
+}
+
+func TestGetRates(t *testing.T) {
+	service, _ := setupService(t)
+
+	resp, err := service.GetRates(context.Background(), &pb.GetRatesRequest{Parcel: &pb.Parcel{WeightGrams: 1500}, Destination: validDestination()})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var services []string
+	for _, r := range resp.Rates {
+		services = append(services, r.Carrier+"/"+r.Service)
+	}
+	expected := []string{"flat_rate/standard", "table_rate/ground", "flat_rate/express"}
+	if len(services) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, services)
+	}
+	for i := range expected {
+		if services[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, services)
+			break
+		}
+	}
+}
+
+func TestGetRates_SkipsCarriersThatCannotShip(t *testing.T) {
+	service, _ := setupService(t, &failingCarrier{name: CarrierEasyPost, err: errors.New("timeout")})
+
+	// Too heavy for flat rate, and easypost is down
+	resp, err := service.GetRates(context.Background(), &pb.GetRatesRequest{Parcel: &pb.Parcel{WeightGrams: 5000}, Destination: validDestination()})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Rates) != 1 || resp.Rates[0].Carrier != CarrierTableRate {
+		t.Errorf("Expected only the table rate, got %v", resp.Rates)
+	}
+
+	_, err = service.GetRates(context.Background(), &pb.GetRatesRequest{Parcel: &pb.Parcel{WeightGrams: 500}, Destination: validDestination(), Carrier: CarrierEasyPost})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable when the only carrier asked fails, got %v", err)
+	}
+}
+
+func TestGetRates_InvalidRequest(t *testing.T) {
+	service, _ := setupService(t)
+
+	tests := []struct {
+		name string
+		req  *pb.GetRatesRequest
+	}{
+		{"missing parcel", &pb.GetRatesRequest{Destination: validDestination()}},
+		{"partial dimensions", &pb.GetRatesRequest{Parcel: &pb.Parcel{WeightGrams: 500, LengthCm: 10}, Destination: validDestination()}},
+		{"missing country", &pb.GetRatesRequest{Parcel: &pb.Parcel{WeightGrams: 500}, Destination: &pb.Address{PostalCode: "10001"}}},
+		{"unknown carrier", &pb.GetRatesRequest{Parcel: &pb.Parcel{WeightGrams: 500}, Destination: validDestination(), Carrier: "ups"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.GetRates(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateShipment(t *testing.T) {
+	service, _ := setupService(t)
+
+	resp, err := service.CreateShipment(context.Background(), &pb.CreateShipmentRequest{
+		OrderId: "order-1", Carrier: CarrierFlatRate, Service: "express", Parcel: &pb.Parcel{WeightGrams: 800}, Destination: validDestination(),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	shipment := resp.Shipment
+	if shipment.Status != StatusCreated || shipment.TrackingNumber == "" || shipment.Amount != 14.99 || shipment.Destination.Country != "US" {
+		t.Errorf("Unexpected shipment %+v", shipment)
+	}
+
+	got, err := service.GetShipment(context.Background(), &pb.GetShipmentRequest{ShipmentId: shipment.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Shipment.TrackingNumber != shipment.TrackingNumber {
+		t.Errorf("Expected tracking number %s, got %s", shipment.TrackingNumber, got.Shipment.TrackingNumber)
+	}
+}
+
+func TestCreateShipment_Failures(t *testing.T) {
+	service, repo := setupService(t, &failingCarrier{name: CarrierEasyPost, err: errors.New("timeout")})
+	request := func(carrier, svc string, weight int64) *pb.CreateShipmentRequest {
+		return &pb.CreateShipmentRequest{OrderId: "order-1", Carrier: carrier, Service: svc, Parcel: &pb.Parcel{WeightGrams: weight}, Destination: validDestination()}
+	}
+
+	tests := []struct {
+		name     string
+		req      *pb.CreateShipmentRequest
+		expected codes.Code
+	}{
+		{"missing street", &pb.CreateShipmentRequest{OrderId: "order-1", Carrier: CarrierFlatRate, Service: "standard", Parcel: &pb.Parcel{WeightGrams: 500}, Destination: &pb.Address{PostalCode: "10001", Country: "US"}}, codes.InvalidArgument},
+		{"unknown carrier", request("ups", "ground", 500), codes.InvalidArgument},
+		{"unknown service", request(CarrierFlatRate, "overnight", 500), codes.InvalidArgument},
+		{"parcel too heavy", request(CarrierFlatRate, "standard", 3000), codes.FailedPrecondition},
+		{"carrier down", request(CarrierEasyPost, "USPS Priority", 500), codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.CreateShipment(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+	if len(repo.shipments) != 0 {
+		t.Errorf("Expected no shipment to be recorded, got %d", len(repo.shipments))
+	}
+}
+
+func TestGetShipment_NotFound(t *testing.T) {
+	service, _ := setupService(t)
+
+	if _, err := service.GetShipment(context.Background(), &pb.GetShipmentRequest{ShipmentId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
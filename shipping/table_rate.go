@@ -0,0 +1,165 @@
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AnyCountry matches destinations that have no rows of their own
+const AnyCountry = "*"
+
+// defaultDimensionalDivisor is the cm³ per kilogram most carriers use to turn
+// a parcel's volume into a dimensional weight
+const defaultDimensionalDivisor = 5000
+
+// TableRow prices a service for parcels up to a weight sent to a country
+type TableRow struct {
+	Service        string  `json:"service"`
+	Country        string  `json:"country"`
+	MaxWeightGrams int64   `json:"max_weight_grams"`
+	Amount         float64 `json:"amount"`
+	EstimatedDays  int32   `json:"estimated_days"`
+}
+
+// TableRateConfig holds the settings of the table-rate carrier
+type TableRateConfig struct {
+	Rows     []TableRow
+	Currency string
+	// DimensionalDivisor turns volume into weight; it defaults to 5000 cm³/kg
+	// and a negative value prices by actual weight only
+	DimensionalDivisor float64
+	// LabelBaseURL is where labels are served, by tracking number
+	LabelBaseURL string
+	// WebhookSecret signs tracking webhooks; empty disables them
+	WebhookSecret string
+}
+
+// TableRateCarrier prices parcels by billable weight and destination country
+// from a rate table, for shipping the business fulfils itself. It issues its
+// own tracking numbers.
+type TableRateCarrier struct {
+	cfg TableRateConfig
+}
+
+// NewTableRateCarrier creates a new table-rate carrier
+func NewTableRateCarrier(cfg TableRateConfig) (*TableRateCarrier, error) {
+	if len(cfg.Rows) == 0 {
+		return nil, errors.New("table rate: at least one row is required")
+	}
+	if cfg.Currency == "" {
+		return nil, errors.New("table rate: currency is required")
+	}
+	if cfg.DimensionalDivisor == 0 {
+		cfg.DimensionalDivisor = defaultDimensionalDivisor
+	}
+	return &TableRateCarrier{cfg: cfg}, nil
+}
+
+// Name returns "table_rate"
+func (c *TableRateCarrier) Name() string {
+	return CarrierTableRate
+}
+
+// Rates returns a rate for each service that ships the parcel's billable
+// weight to its destination. A service's rows for the destination country
+// take precedence over its AnyCountry rows; among them the lightest row that
+// fits the parcel applies.
+func (c *TableRateCarrier) Rates(ctx context.Context, parcel Parcel, destination Address) ([]Rate, error) {
+	weight := billableWeight(parcel, c.cfg.DimensionalDivisor)
+	country := strings.ToUpper(destination.Country)
+
+	var services []string
+	best := map[string]TableRow{}
+	hasCountryRows := map[string]bool{}
+	for _, row := range c.cfg.Rows {
+		if row.Country == country {
+			hasCountryRows[row.Service] = true
+		}
+	}
+
+	for _, row := range c.cfg.Rows {
+		if row.Country != country && (row.Country != AnyCountry || hasCountryRows[row.Service]) {
+			continue
+		}
+		if row.MaxWeightGrams < weight {
+			continue
+		}
+		current, ok := best[row.Service]
+		if !ok {
+			services = append(services, row.Service)
+		}
+		if !ok || row.MaxWeightGrams < current.MaxWeightGrams {
+			best[row.Service] = row
+		}
+	}
+
+	if len(services) == 0 {
+		return nil, ErrNoRate
+	}
+	rates := make([]Rate, len(services))
+	for i, service := range services {
+		row := best[service]
+		rates[i] = Rate{Carrier: CarrierTableRate, Service: service, Amount: row.Amount, Currency: c.cfg.Currency, EstimatedDays: row.EstimatedDays}
+	}
+	return rates, nil
+}
+
+// BuyLabel issues a tracking number for the service
+func (c *TableRateCarrier) BuyLabel(ctx context.Context, service string, parcel Parcel, destination Address) (*Label, error) {
+	if !c.hasService(service) {
+		return nil, ErrUnknownService
+	}
+	rates, err := c.Rates(ctx, parcel, destination)
+	if err != nil {
+		return nil, err
+	}
+	for _, rate := range rates {
+		if rate.Service == service {
+			tracking := newTrackingNumber("TR")
+			return &Label{TrackingNumber: tracking, LabelURL: labelURL(c.cfg.LabelBaseURL, tracking), Rate: rate}, nil
+		}
+	}
+	return nil, ErrNoRate
+}
+
+func (c *TableRateCarrier) hasService(service string) bool {
+	for _, row := range c.cfg.Rows {
+		if row.Service == service {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTrackingWebhook verifies and parses a signed tracking webhook
+func (c *TableRateCarrier) ParseTrackingWebhook(ctx context.Context, header http.Header, body []byte) (*TrackingUpdate, error) {
+	return parseSignedTrackingWebhook(c.cfg.WebhookSecret, header, body)
+}
+
+// ParseRateTable parses a JSON array of table rows, e.g.
+// [{"service": "standard", "country": "US", "max_weight_grams": 1000, "amount": 5.5, "estimated_days": 3}]
+func ParseRateTable(s string) ([]TableRow, error) {
+	var rows []TableRow
+	if err := json.Unmarshal([]byte(s), &rows); err != nil {
+		return nil, fmt.Errorf("invalid rate table: %w", err)
+	}
+	for i := range rows {
+		row := &rows[i]
+		row.Country = strings.ToUpper(strings.TrimSpace(row.Country))
+		switch {
+		case row.Service == "":
+			return nil, fmt.Errorf("invalid rate table row %d: service is required", i+1)
+		case row.Country == "":
+			return nil, fmt.Errorf("invalid rate table row %d: country is required; use %q for any country", i+1, AnyCountry)
+		case row.MaxWeightGrams <= 0:
+			return nil, fmt.Errorf("invalid rate table row %d: max_weight_grams must be positive", i+1)
+		case row.Amount < 0:
+			return nil, fmt.Errorf("invalid rate table row %d: amount must not be negative", i+1)
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,101 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRateTable(t *testing.T) {
+	rows, err := ParseRateTable(`[{"service": "standard", "country": "us", "max_weight_grams": 1000, "amount": 5.5, "estimated_days": 3}]`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []TableRow{{Service: "standard", Country: "US", MaxWeightGrams: 1000, Amount: 5.5, EstimatedDays: 3}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, rows)
+	}
+
+	for _, invalid := range []string{
+		`not json`,
+		`[{"country": "US", "max_weight_grams": 1000}]`,
+		`[{"service": "standard", "max_weight_grams": 1000}]`,
+		`[{"service": "standard", "country": "US"}]`,
+		`[{"service": "standard", "country": "US", "max_weight_grams": 1000, "amount": -1}]`,
+	} {
+		if _, err := ParseRateTable(invalid); err == nil {
+			t.Errorf("Expected an error for %s", invalid)
+		}
+	}
+}
+
+func newTestTableRate(t *testing.T) *TableRateCarrier {
+	carrier, err := NewTableRateCarrier(TableRateConfig{
+		Rows: []TableRow{
+			{Service: "standard", Country: "US", MaxWeightGrams: 5000, Amount: 9.5, EstimatedDays: 4},
+			{Service: "standard", Country: "US", MaxWeightGrams: 1000, Amount: 5.5, EstimatedDays: 3},
+			{Service: "standard", Country: AnyCountry, MaxWeightGrams: 2000, Amount: 20, EstimatedDays: 10},
+			{Service: "express", Country: AnyCountry, MaxWeightGrams: 2000, Amount: 35, EstimatedDays: 3},
+		},
+		Currency: "USD",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return carrier
+}
+
+func TestTableRateCarrier_Rates(t *testing.T) {
+	tests := []struct {
+		name     string
+		parcel   Parcel
+		country  string
+		expected map[string]float64
+	}{
+		{"lightest fitting row", Parcel{WeightGrams: 800}, "US", map[string]float64{"standard": 5.5, "express": 35}},
+		{"heavier bracket", Parcel{WeightGrams: 3000}, "US", map[string]float64{"standard": 9.5}},
+		{"any country", Parcel{WeightGrams: 800}, "DE", map[string]float64{"standard": 20, "express": 35}},
+		{"dimensional weight", Parcel{WeightGrams: 500, LengthCM: 30, WidthCM: 20, HeightCM: 20}, "US", map[string]float64{"standard": 9.5}},
+	}
+
+	carrier := newTestTableRate(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := carrier.Rates(context.Background(), tt.parcel, Address{Country: tt.country})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			got := map[string]float64{}
+			for _, r := range rates {
+				got[r.Service] = r.Amount
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTableRateCarrier_NoRate(t *testing.T) {
+	carrier := newTestTableRate(t)
+	ctx := context.Background()
+
+	if _, err := carrier.Rates(ctx, Parcel{WeightGrams: 6000}, Address{Country: "US"}); !errors.Is(err, ErrNoRate) {
+		t.Errorf("Expected ErrNoRate, got %v", err)
+	}
+	if _, err := carrier.BuyLabel(ctx, "express", Parcel{WeightGrams: 3000}, Address{Country: "US"}); !errors.Is(err, ErrNoRate) {
+		t.Errorf("Expected ErrNoRate, got %v", err)
+	}
+	if _, err := carrier.BuyLabel(ctx, "overnight", Parcel{WeightGrams: 800}, Address{Country: "US"}); !errors.Is(err, ErrUnknownService) {
+		t.Errorf("Expected ErrUnknownService, got %v", err)
+	}
+
+	label, err := carrier.BuyLabel(ctx, "standard", Parcel{WeightGrams: 800}, Address{Country: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if label.Rate.Amount != 5.5 || label.LabelURL != "" {
+		t.Errorf("Unexpected label %+v", label)
+	}
+}
@@ -0,0 +1,249 @@
+package shipping
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// maxWebhookSize caps the webhook body that is read, in bytes
+const maxWebhookSize = 1 << 20
+
+// trackingStatuses are the statuses tracking events can move a shipment to
+var trackingStatuses = []string{StatusCreated, StatusInTransit, StatusOutForDelivery, StatusDelivered, StatusReturned, StatusException}
+
+var (
+	// ErrWebhooksDisabled is returned when a carrier has no webhook secret configured
+	ErrWebhooksDisabled = errors.New("webhooks are not configured for this carrier")
+	// ErrInvalidSignature is returned when a webhook's signature does not verify
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// TrackingUpdate is a verified carrier tracking webhook event
+type TrackingUpdate struct {
+	// EventID is the carrier's event ID; deliveries of the same event share it
+	EventID        string
+	TrackingNumber string
+	// Status is the shipment status the event reports, or empty for events
+	// the service ignores
+	Status      string
+	Description string
+	Location    string
+	// OccurredAt is when the carrier saw the event; zero when unknown
+	OccurredAt time.Time
+}
+
+// TrackingWebhookVerifier is implemented by carriers that send tracking webhooks
+type TrackingWebhookVerifier interface {
+	// ParseTrackingWebhook verifies a webhook delivery's signature and parses
+	// its event. It returns ErrInvalidSignature when the signature does not
+	// verify and ErrWebhooksDisabled when no webhook secret is configured.
+	ParseTrackingWebhook(ctx context.Context, header http.Header, body []byte) (*TrackingUpdate, error)
+}
+
+// WebhookHandler serves carrier tracking webhooks at POST /webhooks/{carrier},
+// e.g. /webhooks/easypost
+func (s *Service) WebhookHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhooks/{carrier}", s.serveWebhook)
+	return mux
+}
+
+// serveWebhook verifies and applies one webhook delivery. Any response other
+// than 2xx makes the carrier deliver the event again later.
+func (s *Service) serveWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("carrier")
+
+	carrier, err := s.carriers.Get(name)
+	verifier, ok := carrier.(TrackingWebhookVerifier)
+	if err != nil || !ok {
+		s.log.Warn(ctx, "Webhook for unknown carrier", map[string]interface{}{"carrier": name})
+		http.Error(w, "unknown carrier", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		s.log.Warn(ctx, "Webhook body rejected", map[string]interface{}{"carrier": name, "error": err.Error()})
+		http.Error(w, "invalid body", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	update, err := verifier.ParseTrackingWebhook(ctx, r.Header, body)
+	switch {
+	case errors.Is(err, ErrWebhooksDisabled):
+		s.log.Warn(ctx, "Webhook for carrier without webhook secret", map[string]interface{}{"carrier": name})
+		http.Error(w, "webhooks not configured", http.StatusNotFound)
+		return
+	case errors.Is(err, ErrInvalidSignature):
+		s.log.Warn(ctx, "Webhook signature rejected", map[string]interface{}{"carrier": name, "error": err.Error()})
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	case err != nil:
+		s.log.Warn(ctx, "Webhook could not be parsed", map[string]interface{}{"carrier": name, "error": err.Error()})
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	err = s.HandleTrackingUpdate(ctx, name, update)
+	if errors.Is(err, ErrShipmentNotFound) {
+		http.Error(w, "unknown shipment", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "event not processed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleTrackingUpdate records a verified tracking event from carrier, moves
+// the shipment to the status it reports, notifies the customer of the new
+// status and brings the shipment's order in line. Each event is applied once:
+// deliveries of an event already recorded are acknowledged and skipped. An
+// event whose order update fails is forgotten so that the carrier's retry is
+// applied.
+func (s *Service) HandleTrackingUpdate(ctx context.Context, carrier string, update *TrackingUpdate) error {
+	fields := map[string]interface{}{"carrier": carrier, "event_id": update.EventID, "tracking_number": update.TrackingNumber}
+	if update.Status == "" {
+		s.log.Info(ctx, "Tracking event ignored", fields)
+		return nil
+	}
+
+	shipment, err := s.repo.GetByTrackingNumber(ctx, carrier, update.TrackingNumber)
+	if errors.Is(err, ErrShipmentNotFound) {
+		s.log.Warn(ctx, "Tracking event for unknown shipment", fields)
+		return err
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to get shipment for tracking event", map[string]interface{}{"error": err.Error(), "carrier": carrier, "tracking_number": update.TrackingNumber})
+		return err
+	}
+
+	now := s.now()
+	event := &TrackingEvent{
+		ShipmentID:     shipment.ID,
+		CarrierEventID: update.EventID,
+		Status:         update.Status,
+		Description:    update.Description,
+		Location:       update.Location,
+		OccurredAt:     update.OccurredAt,
+		ReceivedAt:     now,
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+
+	updated, previous, err := s.repo.RecordTrackingEvent(ctx, event)
+	if errors.Is(err, ErrDuplicateEvent) {
+		s.log.Info(ctx, "Duplicate tracking event skipped", fields)
+		return nil
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to record tracking event", map[string]interface{}{"error": err.Error(), "shipment_id": shipment.ID, "event_id": update.EventID})
+		return err
+	}
+
+	if updated.Status != previous {
+		s.log.Info(ctx, "Shipment status changed", map[string]interface{}{"shipment_id": updated.ID, "from": previous, "to": updated.Status})
+		// A missed notification is not worth the carrier redelivering the event
+		if err := s.notifier.ShipmentUpdated(ctx, updated, event); err != nil {
+			s.log.Error(ctx, "Failed to notify customer of shipment update", map[string]interface{}{"error": err.Error(), "shipment_id": updated.ID, "order_id": updated.OrderID})
+		}
+	}
+
+	if err := s.syncOrder(ctx, updated); err != nil {
+		if ferr := s.repo.DeleteTrackingEvent(context.WithoutCancel(ctx), shipment.ID, update.EventID); ferr != nil {
+			s.log.Error(ctx, "Failed to forget tracking event", map[string]interface{}{"error": ferr.Error(), "shipment_id": shipment.ID, "event_id": update.EventID})
+		}
+		return err
+	}
+	return nil
+}
+
+// syncOrder moves a shipment's order to match the shipment: a shipment on its
+// way fulfils the order, and a delivered shipment delivers it
+func (s *Service) syncOrder(ctx context.Context, shipment *Shipment) error {
+	var err error
+	switch shipment.Status {
+	case StatusInTransit, StatusOutForDelivery:
+		err = s.orders.MarkFulfilled(ctx, shipment.OrderID)
+	case StatusDelivered:
+		// Orders reach DELIVERED only through FULFILLED
+		if err = s.orders.MarkFulfilled(ctx, shipment.OrderID); err == nil {
+			err = s.orders.MarkDelivered(ctx, shipment.OrderID)
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to update order for shipment", map[string]interface{}{"error": err.Error(), "shipment_id": shipment.ID, "order_id": shipment.OrderID, "status": shipment.Status})
+	}
+	return err
+}
+
+// signedTrackingEvent is the tracking webhook body of the carriers the
+// business runs itself, signed with the shared webhook secret
+type signedTrackingEvent struct {
+	ID             string    `json:"id"`
+	TrackingNumber string    `json:"tracking_number"`
+	Status         string    `json:"status"`
+	Description    string    `json:"description"`
+	Location       string    `json:"location"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// parseSignedTrackingWebhook verifies the X-Signature header, the hex
+// HMAC-SHA256 of the body with secret, and parses the body. It is the
+// tracking webhook format of the flat-rate and table-rate carriers, posted by
+// the fulfilment team's tooling.
+func parseSignedTrackingWebhook(secret string, header http.Header, body []byte) (*TrackingUpdate, error) {
+	if secret == "" {
+		return nil, ErrWebhooksDisabled
+	}
+	if !verifyHMAC(body, secret, header.Get("X-Signature")) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event signedTrackingEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("invalid tracking event: %w", err)
+	}
+	switch {
+	case event.ID == "":
+		return nil, errors.New("tracking event has no id")
+	case event.TrackingNumber == "":
+		return nil, errors.New("tracking event has no tracking_number")
+	case !slices.Contains(trackingStatuses, event.Status):
+		return nil, fmt.Errorf("tracking event has unknown status %q", event.Status)
+	}
+
+	return &TrackingUpdate{
+		EventID:        event.ID,
+		TrackingNumber: event.TrackingNumber,
+		Status:         event.Status,
+		Description:    event.Description,
+		Location:       event.Location,
+		OccurredAt:     event.OccurredAt,
+	}, nil
+}
+
+// verifyHMAC reports whether hexSignature is the HMAC-SHA256 of payload with secret
+func verifyHMAC(payload []byte, secret, hexSignature string) bool {
+	signature, err := hex.DecodeString(hexSignature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), signature)
+}
@@ -0,0 +1,163 @@
+package shipping
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/shipping/pb"
+)
+
+// sign returns the hex HMAC-SHA256 of body with secret
+func sign(body, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver posts a tracking webhook signed with the test secret for carrier and
+// returns the response code
+func deliver(t *testing.T, service *Service, carrier, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/"+carrier, strings.NewReader(body))
+	req.Header.Set("X-Signature", sign(body, "whsec"))
+	rec := httptest.NewRecorder()
+	service.WebhookHandler().ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// createTrackedShipment buys a flat-rate label for order-1
+func createTrackedShipment(t *testing.T, service *Service) *pb.Shipment {
+	resp, err := service.CreateShipment(context.Background(), &pb.CreateShipmentRequest{
+		OrderId: "order-1", Carrier: CarrierFlatRate, Service: "standard", Parcel: &pb.Parcel{WeightGrams: 500}, Destination: validDestination(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create shipment: %v", err)
+	}
+	return resp.Shipment
+}
+
+func trackingEvent(id, tracking, status, occurredAt string) string {
+	return `{"id": "` + id + `", "tracking_number": "` + tracking + `", "status": "` + status +
+		`", "description": "` + status + ` scan", "occurred_at": "` + occurredAt + `"}`
+}
+
+func TestWebhook_DeliveredShipmentDeliversOrderOnce(t *testing.T) {
+	service, _ := setupService(t)
+	shipment := createTrackedShipment(t, service)
+
+	deliveries := []string{
+		trackingEvent("evt_1", shipment.TrackingNumber, StatusInTransit, "2024-05-01T10:00:00Z"),
+		trackingEvent("evt_2", shipment.TrackingNumber, StatusDelivered, "2024-05-02T10:00:00Z"),
+		trackingEvent("evt_2", shipment.TrackingNumber, StatusDelivered, "2024-05-02T10:00:00Z"),
+	}
+	for i, body := range deliveries {
+		if code := deliver(t, service, CarrierFlatRate, body); code != http.StatusOK {
+			t.Fatalf("Delivery %d: expected 200, got %d", i+1, code)
+		}
+	}
+
+	resp, err := service.GetTracking(context.Background(), &pb.GetTrackingRequest{ShipmentId: shipment.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Shipment.Status != StatusDelivered {
+		t.Errorf("Expected a delivered shipment, got %s", resp.Shipment.Status)
+	}
+	if len(resp.Events) != 2 || resp.Events[0].Status != StatusDelivered || resp.Events[1].Status != StatusInTransit {
+		t.Errorf("Expected both events newest first, got %v", resp.Events)
+	}
+
+	expected := []string{"fulfilled order-1", "fulfilled order-1", "delivered order-1"}
+	if calls := service.orders.(*fakeOrders).Calls(); !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected order updates %v, got %v", expected, calls)
+	}
+	if statuses := service.notifier.(*fakeNotifier).Statuses(); !reflect.DeepEqual(statuses, []string{StatusInTransit, StatusDelivered}) {
+		t.Errorf("Expected one notification per status, got %v", statuses)
+	}
+}
+
+func TestWebhook_LateEventDoesNotMoveShipmentBack(t *testing.T) {
+	service, repo := setupService(t)
+	shipment := createTrackedShipment(t, service)
+
+	deliver(t, service, CarrierFlatRate, trackingEvent("evt_2", shipment.TrackingNumber, StatusDelivered, "2024-05-02T10:00:00Z"))
+	deliver(t, service, CarrierFlatRate, trackingEvent("evt_1", shipment.TrackingNumber, StatusInTransit, "2024-05-01T10:00:00Z"))
+
+	stored, _ := repo.GetByID(context.Background(), shipment.Id)
+	if stored.Status != StatusDelivered {
+		t.Errorf("Expected the shipment to stay delivered, got %s", stored.Status)
+	}
+	if statuses := service.notifier.(*fakeNotifier).Statuses(); !reflect.DeepEqual(statuses, []string{StatusDelivered}) {
+		t.Errorf("Expected only the delivery to be notified, got %v", statuses)
+	}
+}
+
+func TestWebhook_OrderFailureIsRetried(t *testing.T) {
+	service, _ := setupService(t)
+	shipment := createTrackedShipment(t, service)
+	orders := service.orders.(*fakeOrders)
+	body := trackingEvent("evt_1", shipment.TrackingNumber, StatusInTransit, "2024-05-01T10:00:00Z")
+
+	orders.err = errors.New("order service down")
+	if code := deliver(t, service, CarrierFlatRate, body); code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", code)
+	}
+
+	orders.err = nil
+	if code := deliver(t, service, CarrierFlatRate, body); code != http.StatusOK {
+		t.Fatalf("Expected the retry to succeed, got %d", code)
+	}
+	if calls := orders.Calls(); !reflect.DeepEqual(calls, []string{"fulfilled order-1"}) {
+		t.Errorf("Expected the retry to fulfil the order, got %v", calls)
+	}
+	if statuses := service.notifier.(*fakeNotifier).Statuses(); len(statuses) != 1 {
+		t.Errorf("Expected the customer to be notified once, got %v", statuses)
+	}
+}
+
+func TestWebhook_Rejected(t *testing.T) {
+	service, _ := setupService(t)
+	shipment := createTrackedShipment(t, service)
+	valid := trackingEvent("evt_1", shipment.TrackingNumber, StatusInTransit, "2024-05-01T10:00:00Z")
+
+	tests := []struct {
+		name     string
+		carrier  string
+		body     string
+		header   string
+		expected int
+	}{
+		{"unknown carrier", "ups", valid, "", http.StatusNotFound},
+		{"carrier without webhook secret", CarrierTableRate, valid, "", http.StatusNotFound},
+		{"bad signature", CarrierFlatRate, valid, sign(valid, "other"), http.StatusUnauthorized},
+		{"unknown status", CarrierFlatRate, trackingEvent("evt_1", shipment.TrackingNumber, "LOST", "2024-05-01T10:00:00Z"), "", http.StatusBadRequest},
+		{"unknown tracking number", CarrierFlatRate, trackingEvent("evt_1", "FR-MISSING", StatusInTransit, "2024-05-01T10:00:00Z"), "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/"+tt.carrier, strings.NewReader(tt.body))
+			signature := tt.header
+			if signature == "" {
+				signature = sign(tt.body, "whsec")
+			}
+			req.Header.Set("X-Signature", signature)
+			rec := httptest.NewRecorder()
+			service.WebhookHandler().ServeHTTP(rec, req)
+			if rec.Code != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+
+	if calls := service.orders.(*fakeOrders).Calls(); len(calls) != 0 {
+		t.Errorf("Expected no order updates, got %v", calls)
+	}
+}
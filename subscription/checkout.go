@@ -0,0 +1,68 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkoutpb "github.com/Ujjwaljain16/E-commerce-Backend/checkout/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrPaymentDeclined is returned when checkout declines a subscription's
+	// payment; the order is retried later
+	ErrPaymentDeclined = errors.New("payment declined")
+	// ErrOrderRefused is returned when checkout refuses a subscription's order
+	// for a reason retrying will not fix soon, such as a product out of stock
+	// or withdrawn; the order is missed
+	ErrOrderRefused = errors.New("order refused")
+)
+
+// checkoutPaymentDeclined is the message checkout declines payments with
+const checkoutPaymentDeclined = "payment declined"
+
+// Checkout places subscriptions' orders
+type Checkout interface {
+	// PlaceOrder checks out a subscription's items and returns the paid
+	// order's ID. It returns ErrPaymentDeclined or ErrOrderRefused when the
+	// order cannot be placed; other errors are outages.
+	PlaceOrder(ctx context.Context, sub *Subscription) (string, error)
+}
+
+type grpcCheckout struct {
+	client checkoutpb.CheckoutServiceClient
+}
+
+// NewGRPCCheckout creates a Checkout backed by the checkout service
+func NewGRPCCheckout(client checkoutpb.CheckoutServiceClient) Checkout {
+	return &grpcCheckout{client: client}
+}
+
+func (c *grpcCheckout) PlaceOrder(ctx context.Context, sub *Subscription) (string, error) {
+	items := make([]*checkoutpb.CartItem, len(sub.Items))
+	for i, item := range sub.Items {
+		items[i] = &checkoutpb.CartItem{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+
+	resp, err := c.client.PlaceOrder(ctx, &checkoutpb.PlaceOrderRequest{
+		UserId:          sub.UserID,
+		Items:           items,
+		PaymentMethod:   sub.PaymentMethod,
+		PaymentProvider: sub.PaymentProvider,
+		Currency:        sub.Currency,
+		Email:           sub.Email,
+	})
+	if err != nil {
+		st := status.Convert(err)
+		switch {
+		case st.Code() == codes.FailedPrecondition && st.Message() == checkoutPaymentDeclined:
+			return "", ErrPaymentDeclined
+		case st.Code() == codes.FailedPrecondition, st.Code() == codes.InvalidArgument, st.Code() == codes.NotFound:
+			return "", fmt.Errorf("%w: %s", ErrOrderRefused, st.Message())
+		}
+		return "", err
+	}
+	return resp.OrderId, nil
+}
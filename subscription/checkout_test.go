@@ -0,0 +1,76 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	checkoutpb "github.com/Ujjwaljain16/E-commerce-Backend/checkout/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCheckoutClient records checkouts and answers with a fixed error
+type fakeCheckoutClient struct {
+	checkoutpb.CheckoutServiceClient
+	err  error
+	reqs []*checkoutpb.PlaceOrderRequest
+}
+
+func (c *fakeCheckoutClient) PlaceOrder(ctx context.Context, req *checkoutpb.PlaceOrderRequest, opts ...grpc.CallOption) (*checkoutpb.PlaceOrderResponse, error) {
+	c.reqs = append(c.reqs, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &checkoutpb.PlaceOrderResponse{OrderId: "order-1", Status: "PAID"}, nil
+}
+
+func TestGRPCCheckoutPlaceOrder(t *testing.T) {
+	client := &fakeCheckoutClient{}
+	sub := &Subscription{
+		UserID:        "user-1",
+		Items:         []Item{{ProductID: "coffee", Quantity: 2}},
+		PaymentMethod: "pm_card_visa",
+		Currency:      "EUR",
+		Email:         "customer@example.com",
+	}
+
+	orderID, err := NewGRPCCheckout(client).PlaceOrder(context.Background(), sub)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if orderID != "order-1" {
+		t.Errorf("Expected order-1, got %s", orderID)
+	}
+	req := client.reqs[0]
+	if req.UserId != "user-1" || req.PaymentMethod != "pm_card_visa" || req.Currency != "EUR" || req.Email != "customer@example.com" {
+		t.Errorf("Unexpected checkout request %+v", req)
+	}
+	if len(req.Items) != 1 || req.Items[0].ProductId != "coffee" || req.Items[0].Quantity != 2 {
+		t.Errorf("Expected the subscription's items, got %+v", req.Items)
+	}
+}
+
+func TestGRPCCheckoutPlaceOrder_Errors(t *testing.T) {
+	outage := status.Error(codes.Unavailable, "failed to reserve stock")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"payment declined", status.Error(codes.FailedPrecondition, "payment declined"), ErrPaymentDeclined},
+		{"out of stock", status.Error(codes.FailedPrecondition, "insufficient stock"), ErrOrderRefused},
+		{"product withdrawn", status.Error(codes.NotFound, "product not found"), ErrOrderRefused},
+		{"outage", outage, outage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGRPCCheckout(&fakeCheckoutClient{err: tt.err}).PlaceOrder(context.Background(), &Subscription{})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_subscriptions_due;
+DROP INDEX IF EXISTS idx_subscriptions_user;
+DROP TABLE IF EXISTS subscriptions;
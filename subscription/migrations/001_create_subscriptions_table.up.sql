@@ -0,0 +1,27 @@
+-- Recurring orders. An ACTIVE subscription's order is placed once
+-- attempt_at passes: at next_order_at, later while a declined payment is
+-- being retried, and pushed back briefly while the scheduler is placing it.
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'PAUSED', 'PAST_DUE', 'CANCELLED')),
+    items JSONB NOT NULL,
+    interval_days INTEGER NOT NULL CHECK (interval_days BETWEEN 1 AND 365),
+    payment_method VARCHAR(255) NOT NULL,
+    payment_provider VARCHAR(20) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    next_order_at TIMESTAMP NOT NULL,
+    attempt_at TIMESTAMP NOT NULL,
+    failed_payments INTEGER NOT NULL DEFAULT 0 CHECK (failed_payments >= 0),
+    last_order_id VARCHAR(255) NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cancelled_at TIMESTAMP
+);
+
+-- A customer's subscriptions, newest first
+CREATE INDEX idx_subscriptions_user ON subscriptions(user_id, created_at DESC);
+-- Subscriptions the scheduler has to place an order for
+CREATE INDEX idx_subscriptions_due ON subscriptions(attempt_at) WHERE status = 'ACTIVE';
@@ -0,0 +1,369 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Subscription statuses
+const (
+	StatusActive    = "ACTIVE"
+	StatusPaused    = "PAUSED"
+	StatusPastDue   = "PAST_DUE"
+	StatusCancelled = "CANCELLED"
+)
+
+var (
+	// ErrSubscriptionNotFound is returned when a subscription does not exist
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	// ErrStatusConflict is returned when a subscription changed since it was read
+	ErrStatusConflict = errors.New("subscription status changed")
+)
+
+// Item is a product and quantity ordered every interval
+type Item struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// Subscription is a customer's recurring order
+type Subscription struct {
+	ID              string
+	UserID          string
+	Status          string
+	Items           []Item
+	IntervalDays    int32
+	PaymentMethod   string
+	PaymentProvider string
+	Currency        string
+	Email           string
+	// NextOrderAt is when the next order is due
+	NextOrderAt time.Time
+	// AttemptAt is when the scheduler next tries to place the order: at
+	// NextOrderAt, later while a declined payment is retried, and pushed back
+	// while the scheduler is placing it
+	AttemptAt      time.Time
+	FailedPayments int32
+	LastOrderID    string
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CancelledAt    *time.Time
+}
+
+// Interval is the time between a subscription's orders
+func (s *Subscription) Interval() time.Duration {
+	return time.Duration(s.IntervalDays) * 24 * time.Hour
+}
+
+// Run is the outcome of the scheduler's attempt to place a subscription's
+// order
+type Run struct {
+	// Due is the NextOrderAt the attempt was for
+	Due            time.Time
+	NextOrderAt    time.Time
+	AttemptAt      time.Time
+	FailedPayments int32
+	// PastDue stops the subscription once payment retries ran out
+	PastDue   bool
+	OrderID   string
+	Error     string
+	UpdatedAt time.Time
+}
+
+// Repository handles subscription data persistence
+type Repository interface {
+	Create(ctx context.Context, sub *Subscription) (*Subscription, error)
+	GetByID(ctx context.Context, id string) (*Subscription, error)
+	// ListByUser lists a customer's subscriptions, newest first; an empty
+	// status matches every subscription
+	ListByUser(ctx context.Context, userID, status string, page, pageSize int32) ([]*Subscription, int32, error)
+	// Pause moves an ACTIVE subscription to PAUSED
+	Pause(ctx context.Context, id string, at time.Time) (*Subscription, error)
+	// Resume moves a PAUSED or PAST_DUE subscription to ACTIVE, replacing its
+	// payment method unless paymentMethod is empty. An order that fell due
+	// meanwhile is due at once.
+	Resume(ctx context.Context, id, paymentMethod string, at time.Time) (*Subscription, error)
+	// Skip moves the next order of an ACTIVE or PAUSED subscription on by one
+	// interval
+	Skip(ctx context.Context, id string, at time.Time) (*Subscription, error)
+	// Cancel moves a subscription that is not CANCELLED to CANCELLED
+	Cancel(ctx context.Context, id string, at time.Time) (*Subscription, error)
+	// ClaimDue returns up to limit ACTIVE subscriptions whose attempt is due
+	// at at, pushing their attempts back to leaseUntil so no other scheduler
+	// claims them meanwhile
+	ClaimDue(ctx context.Context, at, leaseUntil time.Time, limit int) ([]*Subscription, error)
+	// RecordRun records the outcome of placing a subscription's order. It
+	// returns ErrStatusConflict when the order due changed meanwhile, e.g.
+	// because the customer skipped it.
+	RecordRun(ctx context.Context, id string, run *Run) error
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL subscription repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const subscriptionColumns = `id, user_id, status, items, interval_days, payment_method, payment_provider, currency, email,
+	next_order_at, attempt_at, failed_payments, last_order_id, last_error, created_at, updated_at, cancelled_at`
+
+// Create inserts a subscription
+func (r *postgresRepository) Create(ctx context.Context, sub *Subscription) (*Subscription, error) {
+	items, err := json.Marshal(sub.Items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode items: %w", err)
+	}
+
+	sub.ID = uuid.New().String()
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO subscriptions (id, user_id, status, items, interval_days, payment_method, payment_provider, currency,
+			email, next_order_at, attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, sub.ID, sub.UserID, sub.Status, string(items), sub.IntervalDays, sub.PaymentMethod, sub.PaymentProvider, sub.Currency,
+		sub.Email, sub.NextOrderAt, sub.AttemptAt, sub.CreatedAt, sub.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create subscription", map[string]interface{}{"error": err.Error(), "user_id": sub.UserID})
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	r.log.Info(ctx, "Subscription created", map[string]interface{}{"subscription_id": sub.ID, "user_id": sub.UserID, "interval_days": sub.IntervalDays})
+	return sub, nil
+}
+
+// GetByID retrieves a subscription by ID
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Subscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM subscriptions WHERE id = $1"
+
+	sub, err := scanSubscription(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get subscription", map[string]interface{}{"error": err.Error(), "subscription_id": id})
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// ListByUser retrieves a customer's subscriptions with pagination
+func (r *postgresRepository) ListByUser(ctx context.Context, userID, status string, page, pageSize int32) ([]*Subscription, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM subscriptions WHERE user_id = $1 AND ($2 = '' OR status = $2)", userID, status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count subscriptions", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to count subscriptions: %w", err)
+	}
+
+	query := "SELECT " + subscriptionColumns + ` FROM subscriptions
+		WHERE user_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list subscriptions", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]*Subscription, 0, pageSize)
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan subscription", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating subscriptions", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating subscriptions: %w", err)
+	}
+
+	return subs, total, nil
+}
+
+// Pause stops an ACTIVE subscription
+func (r *postgresRepository) Pause(ctx context.Context, id string, at time.Time) (*Subscription, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions SET status = 'PAUSED', updated_at = $2
+		WHERE id = $1 AND status = 'ACTIVE'
+	`, id, at)
+	return r.afterUpdate(ctx, id, result, err, "pause")
+}
+
+// Resume restarts a PAUSED or PAST_DUE subscription. Declined payments are
+// forgotten, so a PAST_DUE subscription gets a fresh set of retries.
+func (r *postgresRepository) Resume(ctx context.Context, id, paymentMethod string, at time.Time) (*Subscription, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions SET status = 'ACTIVE',
+			payment_method = COALESCE(NULLIF($2, ''), payment_method),
+			next_order_at = GREATEST(next_order_at, $3),
+			attempt_at = GREATEST(next_order_at, $3),
+			failed_payments = 0, last_error = '', updated_at = $3
+		WHERE id = $1 AND status IN ('PAUSED', 'PAST_DUE')
+	`, id, paymentMethod, at)
+	return r.afterUpdate(ctx, id, result, err, "resume")
+}
+
+// Skip moves the next order of an ACTIVE or PAUSED subscription on by one
+// interval
+func (r *postgresRepository) Skip(ctx context.Context, id string, at time.Time) (*Subscription, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions SET
+			next_order_at = next_order_at + make_interval(days => interval_days),
+			attempt_at = next_order_at + make_interval(days => interval_days),
+			failed_payments = 0, last_error = '', updated_at = $2
+		WHERE id = $1 AND status IN ('ACTIVE', 'PAUSED')
+	`, id, at)
+	return r.afterUpdate(ctx, id, result, err, "skip")
+}
+
+// Cancel ends a subscription
+func (r *postgresRepository) Cancel(ctx context.Context, id string, at time.Time) (*Subscription, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions SET status = 'CANCELLED', cancelled_at = $2, updated_at = $2
+		WHERE id = $1 AND status <> 'CANCELLED'
+	`, id, at)
+	return r.afterUpdate(ctx, id, result, err, "cancel")
+}
+
+// afterUpdate checks the result of a change made only while the subscription
+// was in an allowed status, and reads the changed subscription
+func (r *postgresRepository) afterUpdate(ctx context.Context, id string, result sql.Result, err error, action string) (*Subscription, error) {
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update subscription", map[string]interface{}{"error": err.Error(), "subscription_id": id, "action": action})
+		return nil, fmt.Errorf("failed to update subscription: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update subscription: %w", err)
+	}
+	if rows == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	}
+
+	r.log.Info(ctx, "Subscription updated", map[string]interface{}{"subscription_id": id, "action": action})
+	return r.GetByID(ctx, id)
+}
+
+// ClaimDue leases due subscriptions in one statement. Rows locked by a
+// concurrent claim are skipped rather than waited for.
+func (r *postgresRepository) ClaimDue(ctx context.Context, at, leaseUntil time.Time, limit int) ([]*Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE subscriptions SET attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM subscriptions
+			WHERE status = 'ACTIVE' AND attempt_at <= $1
+			ORDER BY attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+subscriptionColumns, at, leaseUntil, limit)
+	if err != nil {
+		r.log.Error(ctx, "Failed to claim due subscriptions", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to claim due subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating subscriptions: %w", err)
+	}
+	return subs, nil
+}
+
+// RecordRun stores the outcome of an attempt, unless the order it was for is
+// no longer the one due. A subscription paused or cancelled during the
+// attempt keeps its status; only running out of payment retries changes it.
+func (r *postgresRepository) RecordRun(ctx context.Context, id string, run *Run) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions SET next_order_at = $3, attempt_at = $4, failed_payments = $5,
+			status = CASE WHEN $6 AND status = 'ACTIVE' THEN 'PAST_DUE' ELSE status END,
+			last_order_id = CASE WHEN $7 <> '' THEN $7 ELSE last_order_id END,
+			last_error = $8, updated_at = $9
+		WHERE id = $1 AND next_order_at = $2
+	`, id, run.Due, run.NextOrderAt, run.AttemptAt, run.FailedPayments, run.PastDue, run.OrderID, run.Error, run.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record subscription run", map[string]interface{}{"error": err.Error(), "subscription_id": id})
+		return fmt.Errorf("failed to record subscription run: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to record subscription run: %w", err)
+	}
+	if rows == 0 {
+		return ErrStatusConflict
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row rowScanner) (*Subscription, error) {
+	sub := &Subscription{}
+	var items []byte
+	var cancelledAt sql.NullTime
+	err := row.Scan(&sub.ID, &sub.UserID, &sub.Status, &items, &sub.IntervalDays, &sub.PaymentMethod, &sub.PaymentProvider,
+		&sub.Currency, &sub.Email, &sub.NextOrderAt, &sub.AttemptAt, &sub.FailedPayments, &sub.LastOrderID, &sub.LastError,
+		&sub.CreatedAt, &sub.UpdatedAt, &cancelledAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(items, &sub.Items); err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
+	if cancelledAt.Valid {
+		sub.CancelledAt = &cancelledAt.Time
+	}
+	return sub, nil
+}
@@ -0,0 +1,172 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("subscription-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var subscriptionColumnNames = []string{
+	"id", "user_id", "status", "items", "interval_days", "payment_method", "payment_provider", "currency", "email",
+	"next_order_at", "attempt_at", "failed_payments", "last_order_id", "last_error", "created_at", "updated_at", "cancelled_at",
+}
+
+// subscriptionRow adds a row for a subscription in a status
+func subscriptionRow(rows *sqlmock.Rows, id, status string, at time.Time) *sqlmock.Rows {
+	return rows.AddRow(id, "user-1", status, []byte(`[{"product_id":"coffee","quantity":2}]`), 14, "pm_card_visa", "", "USD",
+		"customer@example.com", at, at, 0, "", "", at, at, nil)
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	sub := &Subscription{
+		UserID:        "user-1",
+		Status:        StatusActive,
+		Items:         []Item{{ProductID: "coffee", Quantity: 2}},
+		IntervalDays:  14,
+		PaymentMethod: "pm_card_visa",
+		NextOrderAt:   now,
+		AttemptAt:     now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	mock.ExpectExec(`INSERT INTO subscriptions`).
+		WithArgs(sqlmock.AnyArg(), "user-1", StatusActive, `[{"product_id":"coffee","quantity":2}]`, int32(14), "pm_card_visa", "", "",
+			"", now, now, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	created, err := repo.Create(context.Background(), sub)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM subscriptions WHERE id = \$1`).
+		WithArgs("sub-1").
+		WillReturnRows(subscriptionRow(sqlmock.NewRows(subscriptionColumnNames), "sub-1", StatusActive, now))
+
+	sub, err := repo.GetByID(context.Background(), "sub-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sub.Items) != 1 || sub.Items[0].ProductID != "coffee" || sub.Items[0].Quantity != 2 {
+		t.Errorf("Expected the items decoded, got %+v", sub.Items)
+	}
+	if sub.CancelledAt != nil {
+		t.Errorf("Expected no cancellation time, got %v", sub.CancelledAt)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM subscriptions WHERE id = \$1`).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM subscriptions WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(context.Background(), id); err != ErrSubscriptionNotFound {
+			t.Errorf("Expected ErrSubscriptionNotFound for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestPause_Conflict(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE subscriptions SET status = 'PAUSED', updated_at = \$2\s+WHERE id = \$1 AND status = 'ACTIVE'`).
+		WithArgs("sub-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM subscriptions WHERE id = \$1`).
+		WillReturnRows(subscriptionRow(sqlmock.NewRows(subscriptionColumnNames), "sub-1", StatusCancelled, now))
+
+	if _, err := repo.Pause(context.Background(), "sub-1", now); err != ErrStatusConflict {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClaimDue(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	lease := now.Add(claimLease)
+	mock.ExpectQuery(`UPDATE subscriptions SET attempt_at = \$2\s+WHERE id IN \(\s+SELECT id FROM subscriptions\s+WHERE status = 'ACTIVE' AND attempt_at <= \$1.*FOR UPDATE SKIP LOCKED\s+\)\s+RETURNING`).
+		WithArgs(now, lease, 50).
+		WillReturnRows(subscriptionRow(sqlmock.NewRows(subscriptionColumnNames), "sub-1", StatusActive, lease))
+
+	subs, err := repo.ClaimDue(context.Background(), now, lease, 50)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(subs) != 1 || subs[0].ID != "sub-1" {
+		t.Errorf("Expected sub-1 claimed, got %+v", subs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecordRun(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	run := &Run{Due: now, NextOrderAt: now.Add(14 * 24 * time.Hour), AttemptAt: now.Add(14 * 24 * time.Hour), OrderID: "order-1", UpdatedAt: now}
+	mock.ExpectExec(`UPDATE subscriptions SET next_order_at = \$3.*WHERE id = \$1 AND next_order_at = \$2`).
+		WithArgs("sub-1", run.Due, run.NextOrderAt, run.AttemptAt, int32(0), false, "order-1", "", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE subscriptions SET next_order_at = \$3.*WHERE id = \$1 AND next_order_at = \$2`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.RecordRun(context.Background(), "sub-1", run); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The order due changed, e.g. the customer skipped it
+	if err := repo.RecordRun(context.Background(), "sub-1", run); err != ErrStatusConflict {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// claimBatchSize is how many due subscriptions are claimed at a time
+	claimBatchSize = 50
+	// claimLease is how long a claimed subscription is held by the scheduler
+	// placing its order. A scheduler that dies mid-order leaves it to be tried
+	// again once the lease is up, as it does after an outage.
+	claimLease = 10 * time.Minute
+)
+
+// RetryPolicy sets how declined payments are retried
+type RetryPolicy struct {
+	// Delay is the wait before the first retry; each retry after it waits
+	// twice as long as the one before
+	Delay time.Duration
+	// MaxAttempts is how many payments are tried for an order before the
+	// subscription becomes PAST_DUE
+	MaxAttempts int32
+}
+
+// DefaultRetryPolicy retries a declined payment after 1, 2 and 4 days
+var DefaultRetryPolicy = RetryPolicy{Delay: 24 * time.Hour, MaxAttempts: 4}
+
+// retryDelay is the wait after the failed'th declined payment
+func (p RetryPolicy) retryDelay(failed int32) time.Duration {
+	return p.Delay << (failed - 1)
+}
+
+// Scheduler places the orders of due subscriptions through checkout
+type Scheduler struct {
+	repo     Repository
+	checkout Checkout
+	retry    RetryPolicy
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+// NewScheduler creates a subscription order scheduler
+func NewScheduler(repo Repository, checkout Checkout, retry RetryPolicy, log *logger.Logger) *Scheduler {
+	return &Scheduler{
+		repo:     repo,
+		checkout: checkout,
+		retry:    retry,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// Run places due orders every interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PlaceDue(ctx); err != nil {
+				s.log.Error(ctx, "Failed to place subscription orders", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
+
+// PlaceDue places the order of every subscription due now, a batch at a time
+func (s *Scheduler) PlaceDue(ctx context.Context) error {
+	for {
+		now := s.now()
+		subs, err := s.repo.ClaimDue(ctx, now, now.Add(claimLease), claimBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, sub := range subs {
+			s.place(ctx, sub)
+		}
+		if len(subs) < claimBatchSize {
+			return nil
+		}
+	}
+}
+
+// place places a claimed subscription's order and records the outcome
+func (s *Scheduler) place(ctx context.Context, sub *Subscription) {
+	orderID, err := s.checkout.PlaceOrder(ctx, sub)
+	now := s.now()
+	run := &Run{Due: sub.NextOrderAt, UpdatedAt: now}
+	fields := map[string]interface{}{"subscription_id": sub.ID, "user_id": sub.UserID}
+
+	switch {
+	case err == nil:
+		run.NextOrderAt = nextOrderAt(sub, now)
+		run.AttemptAt = run.NextOrderAt
+		run.OrderID = orderID
+		fields["order_id"] = orderID
+		s.log.Info(ctx, "Subscription order placed", fields)
+	case errors.Is(err, ErrPaymentDeclined):
+		run.NextOrderAt = sub.NextOrderAt
+		run.FailedPayments = sub.FailedPayments + 1
+		run.Error = err.Error()
+		fields["failed_payments"] = run.FailedPayments
+		if run.FailedPayments >= s.retry.MaxAttempts {
+			run.AttemptAt = sub.AttemptAt
+			run.PastDue = true
+			s.log.Warn(ctx, "Subscription past due: payment retries exhausted", fields)
+		} else {
+			run.AttemptAt = now.Add(s.retry.retryDelay(run.FailedPayments))
+			fields["retry_at"] = run.AttemptAt
+			s.log.Info(ctx, "Subscription payment declined", fields)
+		}
+	case errors.Is(err, ErrOrderRefused):
+		run.NextOrderAt = nextOrderAt(sub, now)
+		run.AttemptAt = run.NextOrderAt
+		run.Error = err.Error()
+		fields["error"] = err.Error()
+		s.log.Warn(ctx, "Subscription order missed", fields)
+	default:
+		// An outage: the claim's lease runs out and the order is tried again
+		fields["error"] = err.Error()
+		s.log.Error(ctx, "Failed to place subscription order", fields)
+		return
+	}
+
+	err = s.repo.RecordRun(ctx, sub.ID, run)
+	if errors.Is(err, ErrStatusConflict) {
+		s.log.Warn(ctx, "Subscription changed while its order was placed", fields)
+		return
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		s.log.Error(ctx, "Failed to record subscription order", fields)
+	}
+}
+
+// nextOrderAt is the first order date after the one due that is still to
+// come, so a subscription that missed orders during an outage places one
+// order rather than catching up on each
+func nextOrderAt(sub *Subscription, now time.Time) time.Time {
+	next := sub.NextOrderAt.Add(sub.Interval())
+	for !next.After(now) {
+		next = next.Add(sub.Interval())
+	}
+	return next
+}
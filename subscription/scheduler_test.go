@@ -0,0 +1,188 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+// fakeCheckout places orders with a scripted outcome per call
+type fakeCheckout struct {
+	results []error
+	placed  []string
+}
+
+func (f *fakeCheckout) PlaceOrder(ctx context.Context, sub *Subscription) (string, error) {
+	var err error
+	if len(f.results) > 0 {
+		err, f.results = f.results[0], f.results[1:]
+	}
+	if err != nil {
+		return "", err
+	}
+	orderID := fmt.Sprintf("order-%d", len(f.placed)+1)
+	f.placed = append(f.placed, orderID)
+	return orderID, nil
+}
+
+type schedulerFixture struct {
+	scheduler *Scheduler
+	repo      *memoryRepository
+	checkout  *fakeCheckout
+	clock     time.Time
+	sub       *Subscription
+}
+
+func setupScheduler(t *testing.T, results ...error) *schedulerFixture {
+	f := &schedulerFixture{
+		repo:     newMemoryRepository(),
+		checkout: &fakeCheckout{results: results},
+		clock:    now,
+	}
+	f.scheduler = NewScheduler(f.repo, f.checkout, RetryPolicy{Delay: 24 * time.Hour, MaxAttempts: 3}, logger.New("subscription-test"))
+	f.scheduler.now = func() time.Time { return f.clock }
+
+	sub, err := f.repo.Create(context.Background(), &Subscription{
+		UserID:        "user-1",
+		Status:        StatusActive,
+		Items:         []Item{{ProductID: "coffee", Quantity: 2}},
+		IntervalDays:  14,
+		PaymentMethod: "pm_card_visa",
+		NextOrderAt:   now,
+		AttemptAt:     now,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create subscription: %v", err)
+	}
+	f.sub = sub
+	return f
+}
+
+// placeDueAt runs the scheduler at a time and returns the subscription after
+func (f *schedulerFixture) placeDueAt(t *testing.T, at time.Time) *Subscription {
+	f.clock = at
+	if err := f.scheduler.PlaceDue(context.Background()); err != nil {
+		t.Fatalf("Failed to place due orders: %v", err)
+	}
+	sub, _ := f.repo.GetByID(context.Background(), f.sub.ID)
+	return sub
+}
+
+func TestScheduler_PlacesDueOrder(t *testing.T) {
+	f := setupScheduler(t)
+
+	sub := f.placeDueAt(t, now)
+	if len(f.checkout.placed) != 1 || sub.LastOrderID != "order-1" {
+		t.Fatalf("Expected one order placed, got %v", f.checkout.placed)
+	}
+	next := now.Add(14 * 24 * time.Hour)
+	if !sub.NextOrderAt.Equal(next) || !sub.AttemptAt.Equal(next) {
+		t.Errorf("Expected the next order due in 14 days, got %v", sub.NextOrderAt)
+	}
+
+	// Nothing is due until then
+	f.placeDueAt(t, next.Add(-time.Minute))
+	if len(f.checkout.placed) != 1 {
+		t.Errorf("Expected no order before the next is due, got %v", f.checkout.placed)
+	}
+}
+
+func TestScheduler_DoesNotCatchUpMissedOrders(t *testing.T) {
+	f := setupScheduler(t)
+
+	// Three intervals late: one order is placed and the next is still to come
+	sub := f.placeDueAt(t, now.Add(30*24*time.Hour))
+	if len(f.checkout.placed) != 1 {
+		t.Fatalf("Expected one order placed, got %v", f.checkout.placed)
+	}
+	if !sub.NextOrderAt.Equal(now.Add(42 * 24 * time.Hour)) {
+		t.Errorf("Expected the next order due on the first date to come, got %v", sub.NextOrderAt)
+	}
+}
+
+func TestScheduler_RetriesDeclinedPayment(t *testing.T) {
+	f := setupScheduler(t, ErrPaymentDeclined, ErrPaymentDeclined)
+
+	sub := f.placeDueAt(t, now)
+	if sub.FailedPayments != 1 || !sub.AttemptAt.Equal(now.Add(24*time.Hour)) || !sub.NextOrderAt.Equal(now) {
+		t.Fatalf("Expected a retry in a day for the same order, got %+v", sub)
+	}
+	if sub.LastError != ErrPaymentDeclined.Error() {
+		t.Errorf("Expected the decline recorded, got %q", sub.LastError)
+	}
+
+	// Each retry waits twice as long as the one before
+	sub = f.placeDueAt(t, now.Add(24*time.Hour))
+	if sub.FailedPayments != 2 || !sub.AttemptAt.Equal(now.Add(72*time.Hour)) {
+		t.Fatalf("Expected a retry two days later, got %+v", sub)
+	}
+
+	sub = f.placeDueAt(t, now.Add(72*time.Hour))
+	if sub.FailedPayments != 0 || sub.LastOrderID != "order-1" || sub.LastError != "" {
+		t.Errorf("Expected the retry to place the order, got %+v", sub)
+	}
+	if !sub.NextOrderAt.Equal(now.Add(14 * 24 * time.Hour)) {
+		t.Errorf("Expected the next order due on the original cadence, got %v", sub.NextOrderAt)
+	}
+}
+
+func TestScheduler_PastDueAfterRetries(t *testing.T) {
+	f := setupScheduler(t, ErrPaymentDeclined, ErrPaymentDeclined, ErrPaymentDeclined)
+
+	f.placeDueAt(t, now)
+	f.placeDueAt(t, now.Add(24*time.Hour))
+	sub := f.placeDueAt(t, now.Add(72*time.Hour))
+	if sub.Status != StatusPastDue || sub.FailedPayments != 3 {
+		t.Fatalf("Expected the subscription past due after 3 declines, got %+v", sub)
+	}
+
+	f.placeDueAt(t, now.Add(30*24*time.Hour))
+	if len(f.checkout.placed) != 0 {
+		t.Errorf("Expected no orders for a past due subscription, got %v", f.checkout.placed)
+	}
+}
+
+func TestScheduler_MissesRefusedOrder(t *testing.T) {
+	f := setupScheduler(t, ErrOrderRefused)
+
+	sub := f.placeDueAt(t, now)
+	if sub.Status != StatusActive || sub.FailedPayments != 0 || sub.LastError == "" {
+		t.Fatalf("Expected the order missed with the reason recorded, got %+v", sub)
+	}
+	if !sub.NextOrderAt.Equal(now.Add(14 * 24 * time.Hour)) {
+		t.Errorf("Expected the next order due a cycle later, got %v", sub.NextOrderAt)
+	}
+}
+
+func TestScheduler_OutageRetriesAfterLease(t *testing.T) {
+	f := setupScheduler(t, errors.New("connection refused"))
+
+	sub := f.placeDueAt(t, now)
+	if !sub.AttemptAt.Equal(now.Add(claimLease)) || sub.FailedPayments != 0 {
+		t.Fatalf("Expected the order left to the end of the lease, got %+v", sub)
+	}
+
+	sub = f.placeDueAt(t, now.Add(claimLease))
+	if len(f.checkout.placed) != 1 || sub.LastOrderID != "order-1" {
+		t.Errorf("Expected the order placed once the lease ran out, got %v", f.checkout.placed)
+	}
+}
+
+func TestScheduler_SkipDuringRun(t *testing.T) {
+	f := setupScheduler(t)
+	ctx := context.Background()
+
+	// The customer skips the order while the scheduler is placing it
+	claimed, _ := f.repo.ClaimDue(ctx, now, now.Add(claimLease), claimBatchSize)
+	skipped, _ := f.repo.Skip(ctx, f.sub.ID, now)
+	f.scheduler.place(ctx, claimed[0])
+
+	sub, _ := f.repo.GetByID(ctx, f.sub.ID)
+	if !sub.NextOrderAt.Equal(skipped.NextOrderAt) || sub.LastOrderID != "" {
+		t.Errorf("Expected the skip kept, got %+v", sub)
+	}
+}
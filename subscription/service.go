@@ -0,0 +1,346 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/subscription/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the user_id, payment_method and email columns
+	maxIDLength = 255
+	// maxProviderLength matches the payment_provider column
+	maxProviderLength = 20
+	// maxItems matches the checkout service's limit on lines per cart
+	maxItems = 100
+	// maxIntervalDays is the longest time between orders
+	maxIntervalDays = 365
+)
+
+// validStatuses are the statuses a subscription list can be filtered by
+var validStatuses = map[string]bool{
+	StatusActive: true, StatusPaused: true, StatusPastDue: true, StatusCancelled: true,
+}
+
+// Service implements the SubscriptionService gRPC interface
+type Service struct {
+	pb.UnimplementedSubscriptionServiceServer
+	repo Repository
+	log  *logger.Logger
+	now  func() time.Time
+}
+
+// NewService creates a new subscription service
+func NewService(repo Repository, log *logger.Logger) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+// CreateSubscription starts a recurring order. The first order is placed at
+// start_at, or straight away when it is unset.
+func (s *Service) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.CreateSubscriptionResponse, error) {
+	now := s.now()
+	if msg := createProblem(req, now); msg != "" {
+		s.log.Warn(ctx, "Create subscription failed: "+msg, map[string]interface{}{"user_id": req.UserId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	first := now
+	if req.StartAt != nil {
+		first = req.StartAt.AsTime()
+	}
+	sub := &Subscription{
+		UserID:          req.UserId,
+		Status:          StatusActive,
+		Items:           make([]Item, len(req.Items)),
+		IntervalDays:    req.IntervalDays,
+		PaymentMethod:   req.PaymentMethod,
+		PaymentProvider: req.PaymentProvider,
+		Currency:        req.Currency,
+		Email:           req.Email,
+		NextOrderAt:     first,
+		AttemptAt:       first,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	for i, item := range req.Items {
+		sub.Items[i] = Item{ProductID: item.ProductId, Quantity: item.Quantity}
+	}
+
+	created, err := s.repo.Create(ctx, sub)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create subscription")
+	}
+
+	return &pb.CreateSubscriptionResponse{
+		Subscription: toProtoSubscription(created),
+	}, nil
+}
+
+// createProblem validates a subscription request and returns a message
+// describing the first problem, or ""
+func createProblem(req *pb.CreateSubscriptionRequest, now time.Time) string {
+	switch {
+	case req.UserId == "":
+		return "user_id is required"
+	case len(req.UserId) > maxIDLength:
+		return "user_id is too long"
+	case len(req.Items) == 0:
+		return "items are required"
+	case len(req.Items) > maxItems:
+		return fmt.Sprintf("a subscription cannot have more than %d items", maxItems)
+	case req.IntervalDays < 1 || req.IntervalDays > maxIntervalDays:
+		return fmt.Sprintf("interval_days must be between 1 and %d", maxIntervalDays)
+	case req.PaymentMethod == "":
+		return "payment_method is required"
+	case len(req.PaymentMethod) > maxIDLength:
+		return "payment_method is too long"
+	case len(req.PaymentProvider) > maxProviderLength:
+		return "payment_provider is too long"
+	case req.Currency != "" && !isCurrencyCode(req.Currency):
+		return "currency must be a 3-letter ISO 4217 code"
+	case len(req.Email) > maxIDLength:
+		return "email is too long"
+	case req.StartAt != nil && req.StartAt.AsTime().Before(now.Add(-time.Minute)):
+		return "start_at cannot be in the past"
+	}
+
+	seen := make(map[string]bool, len(req.Items))
+	for _, item := range req.Items {
+		switch {
+		case item.ProductId == "":
+			return "product_id is required"
+		case item.Quantity <= 0:
+			return "quantity must be positive"
+		case seen[item.ProductId]:
+			return "product " + item.ProductId + " appears more than once"
+		}
+		seen[item.ProductId] = true
+	}
+	return ""
+}
+
+// isCurrencyCode reports whether code is three upper-case letters
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// GetSubscription returns one of the customer's subscriptions
+func (s *Service) GetSubscription(ctx context.Context, req *pb.GetSubscriptionRequest) (*pb.GetSubscriptionResponse, error) {
+	sub, err := s.load(ctx, req.SubscriptionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetSubscriptionResponse{
+		Subscription: toProtoSubscription(sub),
+	}, nil
+}
+
+// ListSubscriptions lists a customer's subscriptions, newest first
+func (s *Service) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	if req.UserId == "" {
+		s.log.Warn(ctx, "List subscriptions failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Status != "" && !validStatuses[req.Status] {
+		s.log.Warn(ctx, "List subscriptions failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be ACTIVE, PAUSED, PAST_DUE or CANCELLED")
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	subs, total, err := s.repo.ListByUser(ctx, req.UserId, req.Status, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list subscriptions")
+	}
+
+	protoSubs := make([]*pb.Subscription, len(subs))
+	for i, sub := range subs {
+		protoSubs[i] = toProtoSubscription(sub)
+	}
+
+	return &pb.ListSubscriptionsResponse{
+		Subscriptions: protoSubs,
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+	}, nil
+}
+
+// PauseSubscription stops placing an ACTIVE subscription's orders until it
+// is resumed
+func (s *Service) PauseSubscription(ctx context.Context, req *pb.PauseSubscriptionRequest) (*pb.PauseSubscriptionResponse, error) {
+	sub, err := s.load(ctx, req.SubscriptionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status != StatusActive {
+		return nil, status.Error(codes.FailedPrecondition, "subscription is "+sub.Status+", not ACTIVE")
+	}
+
+	paused, err := s.repo.Pause(ctx, sub.ID, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, sub.ID)
+	}
+	return &pb.PauseSubscriptionResponse{
+		Subscription: toProtoSubscription(paused),
+	}, nil
+}
+
+// ResumeSubscription restarts a PAUSED or PAST_DUE subscription, optionally
+// with a new payment method
+func (s *Service) ResumeSubscription(ctx context.Context, req *pb.ResumeSubscriptionRequest) (*pb.ResumeSubscriptionResponse, error) {
+	if len(req.PaymentMethod) > maxIDLength {
+		s.log.Warn(ctx, "Resume subscription failed: payment method too long", map[string]interface{}{"subscription_id": req.SubscriptionId})
+		return nil, status.Error(codes.InvalidArgument, "payment_method is too long")
+	}
+
+	sub, err := s.load(ctx, req.SubscriptionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status != StatusPaused && sub.Status != StatusPastDue {
+		return nil, status.Error(codes.FailedPrecondition, "subscription is "+sub.Status+", not PAUSED or PAST_DUE")
+	}
+
+	resumed, err := s.repo.Resume(ctx, sub.ID, req.PaymentMethod, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, sub.ID)
+	}
+	return &pb.ResumeSubscriptionResponse{
+		Subscription: toProtoSubscription(resumed),
+	}, nil
+}
+
+// SkipNextOrder moves the next order of an ACTIVE or PAUSED subscription on by
+// one interval. A declined payment being retried is dropped with the order.
+func (s *Service) SkipNextOrder(ctx context.Context, req *pb.SkipNextOrderRequest) (*pb.SkipNextOrderResponse, error) {
+	sub, err := s.load(ctx, req.SubscriptionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status != StatusActive && sub.Status != StatusPaused {
+		return nil, status.Error(codes.FailedPrecondition, "subscription is "+sub.Status+", not ACTIVE or PAUSED")
+	}
+
+	skipped, err := s.repo.Skip(ctx, sub.ID, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, sub.ID)
+	}
+	return &pb.SkipNextOrderResponse{
+		Subscription: toProtoSubscription(skipped),
+	}, nil
+}
+
+// CancelSubscription ends a subscription for good
+func (s *Service) CancelSubscription(ctx context.Context, req *pb.CancelSubscriptionRequest) (*pb.CancelSubscriptionResponse, error) {
+	sub, err := s.load(ctx, req.SubscriptionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status == StatusCancelled {
+		return nil, status.Error(codes.FailedPrecondition, "subscription is already CANCELLED")
+	}
+
+	cancelled, err := s.repo.Cancel(ctx, sub.ID, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, sub.ID)
+	}
+	return &pb.CancelSubscriptionResponse{
+		Subscription: toProtoSubscription(cancelled),
+	}, nil
+}
+
+// load reads one of a customer's subscriptions. Another customer's
+// subscription is reported as not found.
+func (s *Service) load(ctx context.Context, subscriptionID, userID string) (*Subscription, error) {
+	if subscriptionID == "" {
+		s.log.Warn(ctx, "Subscription request failed: subscription ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "subscription_id is required")
+	}
+	if userID == "" {
+		s.log.Warn(ctx, "Subscription request failed: user ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	sub, err := s.repo.GetByID(ctx, subscriptionID)
+	if errors.Is(err, ErrSubscriptionNotFound) || (err == nil && sub.UserID != userID) {
+		s.log.Warn(ctx, "Subscription not found", map[string]interface{}{"subscription_id": subscriptionID, "user_id": userID})
+		return nil, status.Error(codes.NotFound, "subscription not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get subscription")
+	}
+	return sub, nil
+}
+
+// updateError maps an error changing a subscription to a gRPC status
+func (s *Service) updateError(ctx context.Context, err error, subscriptionID string) error {
+	switch {
+	case errors.Is(err, ErrSubscriptionNotFound):
+		return status.Error(codes.NotFound, "subscription not found")
+	case errors.Is(err, ErrStatusConflict):
+		s.log.Warn(ctx, "Subscription changed by another request", map[string]interface{}{"subscription_id": subscriptionID})
+		return status.Error(codes.Aborted, "subscription was changed by another request")
+	}
+	return status.Error(codes.Internal, "failed to update subscription")
+}
+
+// toProtoSubscription converts a subscription to protobuf
+func toProtoSubscription(s *Subscription) *pb.Subscription {
+	sub := &pb.Subscription{
+		Id:              s.ID,
+		UserId:          s.UserID,
+		Status:          s.Status,
+		Items:           make([]*pb.SubscriptionItem, len(s.Items)),
+		IntervalDays:    s.IntervalDays,
+		PaymentMethod:   s.PaymentMethod,
+		PaymentProvider: s.PaymentProvider,
+		Currency:        s.Currency,
+		Email:           s.Email,
+		NextOrderAt:     timestamppb.New(s.NextOrderAt),
+		FailedPayments:  s.FailedPayments,
+		LastOrderId:     s.LastOrderID,
+		LastError:       s.LastError,
+		CreatedAt:       timestamppb.New(s.CreatedAt),
+		UpdatedAt:       timestamppb.New(s.UpdatedAt),
+	}
+	for i, item := range s.Items {
+		sub.Items[i] = &pb.SubscriptionItem{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+	if s.Status == StatusActive && s.FailedPayments > 0 {
+		sub.RetryAt = timestamppb.New(s.AttemptAt)
+	}
+	if s.CancelledAt != nil {
+		sub.CancelledAt = timestamppb.New(*s.CancelledAt)
+	}
+	return sub
+}
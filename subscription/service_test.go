@@ -0,0 +1,397 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/subscription/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps subscriptions by ID, changing one only from the
+// statuses the caller names and recording a run only for the due date stored
+type memoryRepository struct {
+	mu   sync.Mutex
+	subs map[string]Subscription
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{subs: map[string]Subscription{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, sub *Subscription) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub.ID = uuid.New().String()
+	m.store(sub)
+	return m.get(sub.ID), nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subs[id]; !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	return m.get(id), nil
+}
+
+func (m *memoryRepository) ListByUser(ctx context.Context, userID, status string, page, pageSize int32) ([]*Subscription, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []*Subscription
+	for id, sub := range m.subs {
+		if sub.UserID == userID && (status == "" || sub.Status == status) {
+			matched = append(matched, m.get(id))
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+
+	total := int32(len(matched))
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	return matched[start:end], total, nil
+}
+
+func (m *memoryRepository) Pause(ctx context.Context, id string, at time.Time) (*Subscription, error) {
+	return m.update(id, []string{StatusActive}, func(s *Subscription) {
+		s.Status = StatusPaused
+		s.UpdatedAt = at
+	})
+}
+
+func (m *memoryRepository) Resume(ctx context.Context, id, paymentMethod string, at time.Time) (*Subscription, error) {
+	return m.update(id, []string{StatusPaused, StatusPastDue}, func(s *Subscription) {
+		s.Status = StatusActive
+		if paymentMethod != "" {
+			s.PaymentMethod = paymentMethod
+		}
+		if s.NextOrderAt.Before(at) {
+			s.NextOrderAt = at
+		}
+		s.AttemptAt = s.NextOrderAt
+		s.FailedPayments = 0
+		s.LastError = ""
+		s.UpdatedAt = at
+	})
+}
+
+func (m *memoryRepository) Skip(ctx context.Context, id string, at time.Time) (*Subscription, error) {
+	return m.update(id, []string{StatusActive, StatusPaused}, func(s *Subscription) {
+		s.NextOrderAt = s.NextOrderAt.Add(s.Interval())
+		s.AttemptAt = s.NextOrderAt
+		s.FailedPayments = 0
+		s.LastError = ""
+		s.UpdatedAt = at
+	})
+}
+
+func (m *memoryRepository) Cancel(ctx context.Context, id string, at time.Time) (*Subscription, error) {
+	return m.update(id, []string{StatusActive, StatusPaused, StatusPastDue}, func(s *Subscription) {
+		s.Status = StatusCancelled
+		s.CancelledAt = &at
+		s.UpdatedAt = at
+	})
+}
+
+func (m *memoryRepository) ClaimDue(ctx context.Context, at, leaseUntil time.Time, limit int) ([]*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []*Subscription
+	for id, sub := range m.subs {
+		if len(due) == limit {
+			break
+		}
+		if sub.Status == StatusActive && !sub.AttemptAt.After(at) {
+			sub.AttemptAt = leaseUntil
+			m.subs[id] = sub
+			due = append(due, m.get(id))
+		}
+	}
+	return due, nil
+}
+
+func (m *memoryRepository) RecordRun(ctx context.Context, id string, run *Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subs[id]
+	if !ok || !sub.NextOrderAt.Equal(run.Due) {
+		return ErrStatusConflict
+	}
+	sub.NextOrderAt = run.NextOrderAt
+	sub.AttemptAt = run.AttemptAt
+	sub.FailedPayments = run.FailedPayments
+	if run.PastDue && sub.Status == StatusActive {
+		sub.Status = StatusPastDue
+	}
+	if run.OrderID != "" {
+		sub.LastOrderID = run.OrderID
+	}
+	sub.LastError = run.Error
+	sub.UpdatedAt = run.UpdatedAt
+	m.subs[id] = sub
+	return nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+func (m *memoryRepository) update(id string, from []string, change func(*Subscription)) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subs[id]
+	if !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	for _, status := range from {
+		if sub.Status == status {
+			change(&sub)
+			m.subs[id] = sub
+			return m.get(id), nil
+		}
+	}
+	return nil, ErrStatusConflict
+}
+
+// store keeps a copy of a subscription, so callers cannot change it in place
+func (m *memoryRepository) store(s *Subscription) {
+	stored := *s
+	stored.Items = append([]Item(nil), s.Items...)
+	m.subs[s.ID] = stored
+}
+
+func (m *memoryRepository) get(id string) *Subscription {
+	s := m.subs[id]
+	s.Items = append([]Item(nil), s.Items...)
+	return &s
+}
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func setupService() (*Service, *memoryRepository) {
+	repo := newMemoryRepository()
+	service := NewService(repo, logger.New("subscription-test"))
+	service.now = func() time.Time { return now }
+	return service, repo
+}
+
+func validRequest() *pb.CreateSubscriptionRequest {
+	return &pb.CreateSubscriptionRequest{
+		UserId: "user-1",
+		Items: []*pb.SubscriptionItem{
+			{ProductId: "coffee", Quantity: 2},
+			{ProductId: "filters", Quantity: 1},
+		},
+		IntervalDays:  14,
+		PaymentMethod: "pm_card_visa",
+		Currency:      "USD",
+		Email:         "customer@example.com",
+	}
+}
+
+// create creates a subscription and fails the test on error
+func create(t *testing.T, service *Service, req *pb.CreateSubscriptionRequest) *pb.Subscription {
+	resp, err := service.CreateSubscription(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Failed to create subscription: %v", err)
+	}
+	return resp.Subscription
+}
+
+func TestCreateSubscription(t *testing.T) {
+	service, _ := setupService()
+
+	sub := create(t, service, validRequest())
+	if sub.Status != StatusActive || sub.UserId != "user-1" || sub.IntervalDays != 14 || len(sub.Items) != 2 {
+		t.Errorf("Unexpected subscription %+v", sub)
+	}
+	if !sub.NextOrderAt.AsTime().Equal(now) {
+		t.Errorf("Expected the first order due now, got %v", sub.NextOrderAt.AsTime())
+	}
+	if sub.RetryAt != nil {
+		t.Errorf("Expected no retry, got %v", sub.RetryAt.AsTime())
+	}
+
+	req := validRequest()
+	req.StartAt = timestamppb.New(now.Add(72 * time.Hour))
+	sub = create(t, service, req)
+	if !sub.NextOrderAt.AsTime().Equal(now.Add(72 * time.Hour)) {
+		t.Errorf("Expected the first order due at start_at, got %v", sub.NextOrderAt.AsTime())
+	}
+}
+
+func TestCreateSubscription_InvalidRequest(t *testing.T) {
+	service, _ := setupService()
+
+	tests := []struct {
+		name   string
+		modify func(*pb.CreateSubscriptionRequest)
+	}{
+		{"missing user", func(r *pb.CreateSubscriptionRequest) { r.UserId = "" }},
+		{"no items", func(r *pb.CreateSubscriptionRequest) { r.Items = nil }},
+		{"zero quantity", func(r *pb.CreateSubscriptionRequest) { r.Items[0].Quantity = 0 }},
+		{"duplicate product", func(r *pb.CreateSubscriptionRequest) { r.Items[1].ProductId = "coffee" }},
+		{"zero interval", func(r *pb.CreateSubscriptionRequest) { r.IntervalDays = 0 }},
+		{"interval over a year", func(r *pb.CreateSubscriptionRequest) { r.IntervalDays = 366 }},
+		{"missing payment method", func(r *pb.CreateSubscriptionRequest) { r.PaymentMethod = "" }},
+		{"invalid currency", func(r *pb.CreateSubscriptionRequest) { r.Currency = "usd" }},
+		{"start in the past", func(r *pb.CreateSubscriptionRequest) { r.StartAt = timestamppb.New(now.Add(-time.Hour)) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validRequest()
+			tt.modify(req)
+			_, err := service.CreateSubscription(context.Background(), req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetSubscription_OtherCustomer(t *testing.T) {
+	service, _ := setupService()
+	sub := create(t, service, validRequest())
+
+	resp, err := service.GetSubscription(context.Background(), &pb.GetSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+	if err != nil || resp.Subscription.Id != sub.Id {
+		t.Fatalf("Expected the subscription, got %v", err)
+	}
+
+	_, err = service.GetSubscription(context.Background(), &pb.GetSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-2"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another customer's subscription, got %v", err)
+	}
+}
+
+func TestListSubscriptions(t *testing.T) {
+	service, _ := setupService()
+	first := create(t, service, validRequest())
+	create(t, service, validRequest())
+	if _, err := service.PauseSubscription(context.Background(), &pb.PauseSubscriptionRequest{SubscriptionId: first.Id, UserId: "user-1"}); err != nil {
+		t.Fatalf("Failed to pause subscription: %v", err)
+	}
+
+	resp, err := service.ListSubscriptions(context.Background(), &pb.ListSubscriptionsRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 2 || resp.PageSize != 10 {
+		t.Errorf("Expected 2 subscriptions on a page of 10, got %d on %d", resp.Total, resp.PageSize)
+	}
+
+	resp, err = service.ListSubscriptions(context.Background(), &pb.ListSubscriptionsRequest{UserId: "user-1", Status: StatusPaused})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 1 || resp.Subscriptions[0].Id != first.Id {
+		t.Errorf("Expected only the paused subscription, got %+v", resp.Subscriptions)
+	}
+
+	_, err = service.ListSubscriptions(context.Background(), &pb.ListSubscriptionsRequest{UserId: "user-1", Status: "EXPIRED"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown status, got %v", err)
+	}
+}
+
+func TestPauseAndResumeSubscription(t *testing.T) {
+	service, _ := setupService()
+	sub := create(t, service, validRequest())
+	ctx := context.Background()
+
+	paused, err := service.PauseSubscription(ctx, &pb.PauseSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+	if err != nil || paused.Subscription.Status != StatusPaused {
+		t.Fatalf("Expected the subscription paused, got %v", err)
+	}
+	_, err = service.PauseSubscription(ctx, &pb.PauseSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition pausing a paused subscription, got %v", err)
+	}
+
+	// The order that fell due while paused is placed as soon as it resumes
+	service.now = func() time.Time { return now.Add(48 * time.Hour) }
+	resumed, err := service.ResumeSubscription(ctx, &pb.ResumeSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1", PaymentMethod: "pm_card_new"})
+	if err != nil {
+		t.Fatalf("Failed to resume subscription: %v", err)
+	}
+	if resumed.Subscription.Status != StatusActive || resumed.Subscription.PaymentMethod != "pm_card_new" {
+		t.Errorf("Expected the subscription active with the new payment method, got %+v", resumed.Subscription)
+	}
+	if !resumed.Subscription.NextOrderAt.AsTime().Equal(now.Add(48 * time.Hour)) {
+		t.Errorf("Expected the overdue order due on resume, got %v", resumed.Subscription.NextOrderAt.AsTime())
+	}
+
+	_, err = service.ResumeSubscription(ctx, &pb.ResumeSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition resuming an active subscription, got %v", err)
+	}
+}
+
+func TestSkipNextOrder(t *testing.T) {
+	service, _ := setupService()
+	sub := create(t, service, validRequest())
+
+	resp, err := service.SkipNextOrder(context.Background(), &pb.SkipNextOrderRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Failed to skip order: %v", err)
+	}
+	if !resp.Subscription.NextOrderAt.AsTime().Equal(now.Add(14 * 24 * time.Hour)) {
+		t.Errorf("Expected the next order one interval later, got %v", resp.Subscription.NextOrderAt.AsTime())
+	}
+}
+
+func TestCancelSubscription(t *testing.T) {
+	service, _ := setupService()
+	sub := create(t, service, validRequest())
+	ctx := context.Background()
+
+	resp, err := service.CancelSubscription(ctx, &pb.CancelSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Failed to cancel subscription: %v", err)
+	}
+	if resp.Subscription.Status != StatusCancelled || resp.Subscription.CancelledAt == nil {
+		t.Errorf("Expected the subscription cancelled, got %+v", resp.Subscription)
+	}
+
+	for name, call := range map[string]func() error{
+		"cancel": func() error {
+			_, err := service.CancelSubscription(ctx, &pb.CancelSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+			return err
+		},
+		"skip": func() error {
+			_, err := service.SkipNextOrder(ctx, &pb.SkipNextOrderRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+			return err
+		},
+		"resume": func() error {
+			_, err := service.ResumeSubscription(ctx, &pb.ResumeSubscriptionRequest{SubscriptionId: sub.Id, UserId: "user-1"})
+			return err
+		},
+	} {
+		if err := call(); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("Expected FailedPrecondition to %s a cancelled subscription, got %v", name, err)
+		}
+	}
+}
+
+func TestUpdateError_Conflict(t *testing.T) {
+	service, _ := setupService()
+
+	err := service.updateError(context.Background(), ErrStatusConflict, "sub-1")
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("Expected Aborted, got %v", err)
+	}
+	err = service.updateError(context.Background(), errors.New("connection reset"), "sub-1")
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	cartpb "github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/synthetic"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize logger
+	log := logger.New("synthetic-probe")
+	log.Info(ctx, "Starting synthetic probes", nil)
+
+	metricsPort := getEnv("METRICS_PORT", "9094")
+	interval := getEnvDuration("PROBE_INTERVAL", time.Minute)
+
+	// Journeys run only when their service address and test fixture are configured
+	var journeys []synthetic.Journey
+	if addr, email := os.Getenv("ACCOUNT_SERVICE_ADDR"), os.Getenv("PROBE_EMAIL"); addr != "" && email != "" {
+		conn := dial(ctx, log, addr)
+		defer conn.Close()
+		journeys = append(journeys, synthetic.LoginJourney(accountpb.NewAccountServiceClient(conn), email, os.Getenv("PROBE_PASSWORD")))
+	}
+	if addr, productID := os.Getenv("CATALOG_SERVICE_ADDR"), os.Getenv("PROBE_PRODUCT_ID"); addr != "" && productID != "" {
+		conn := dial(ctx, log, addr)
+		defer conn.Close()
+		journeys = append(journeys, synthetic.ProductFetchJourney(catalogpb.NewCatalogServiceClient(conn), productID))
+	}
+	if addr, userID, productID := os.Getenv("CART_SERVICE_ADDR"), os.Getenv("PROBE_USER_ID"), os.Getenv("PROBE_PRODUCT_ID"); addr != "" && userID != "" && productID != "" {
+		conn := dial(ctx, log, addr)
+		defer conn.Close()
+		journeys = append(journeys, synthetic.CartAddJourney(cartpb.NewCartServiceClient(conn), userID, productID))
+	}
+	if len(journeys) == 0 {
+		log.Error(ctx, "No journeys configured", nil)
+		os.Exit(1)
+	}
+
+	runner := synthetic.NewRunner(synthetic.Config{
+		Timeout:          getEnvDuration("PROBE_TIMEOUT", synthetic.DefaultConfig.Timeout),
+		FailureThreshold: getEnvInt("PROBE_FAILURE_THRESHOLD", synthetic.DefaultConfig.FailureThreshold),
+	}, log, journeys...)
+
+	// Start Prometheus metrics HTTP server
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		log.Info(ctx, "Metrics server listening", map[string]interface{}{
+			"port": metricsPort,
+		})
+		if err := http.ListenAndServe(fmt.Sprintf(":%s", metricsPort), nil); err != nil {
+			log.Error(ctx, "Metrics server failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Handle graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down gracefully", nil)
+		cancel()
+	}()
+
+	log.Info(ctx, "Synthetic probes running", map[string]interface{}{
+		"journeys": len(journeys),
+		"interval": interval.String(),
+	})
+	runner.Run(ctx, interval)
+}
+
+// dial creates a gRPC client connection to a service
+func dial(ctx context.Context, log *logger.Logger, addr string) *grpc.ClientConn {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Error(ctx, "Failed to create client", map[string]interface{}{
+			"error": err.Error(),
+			"addr":  addr,
+		})
+		os.Exit(1)
+	}
+	return conn
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,94 @@
+package synthetic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	cartpb "github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/metadata"
+)
+
+// Journey names
+const (
+	JourneyLogin        = "login"
+	JourneyProductFetch = "product_fetch"
+	JourneyCartAdd      = "cart_add"
+)
+
+// LoginJourney signs in with a dedicated test account and fetches its profile
+func LoginJourney(client accountpb.AccountServiceClient, email, password string) Journey {
+	return Journey{
+		Name: JourneyLogin,
+		Run: func(ctx context.Context) error {
+			resp, err := client.Login(ctx, &accountpb.LoginRequest{Email: email, Password: password})
+			if err != nil {
+				return fmt.Errorf("login: %w", err)
+			}
+			if resp.AccessToken == "" || resp.User == nil {
+				return errors.New("login: response is missing the token or user")
+			}
+
+			authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+resp.AccessToken)
+			profile, err := client.GetProfile(authCtx, &accountpb.GetProfileRequest{UserId: resp.User.Id})
+			if err != nil {
+				return fmt.Errorf("get profile: %w", err)
+			}
+			if profile.User.GetEmail() != email {
+				return fmt.Errorf("get profile: expected %s, got %s", email, profile.User.GetEmail())
+			}
+			return nil
+		},
+	}
+}
+
+// ProductFetchJourney fetches a dedicated test product and checks it can be sold
+func ProductFetchJourney(client catalogpb.CatalogServiceClient, productID string) Journey {
+	return Journey{
+		Name: JourneyProductFetch,
+		Run: func(ctx context.Context) error {
+			resp, err := client.GetProduct(ctx, &catalogpb.GetProductRequest{Id: productID})
+			if err != nil {
+				return fmt.Errorf("get product: %w", err)
+			}
+			if resp.Product.GetId() != productID {
+				return fmt.Errorf("get product: expected %s, got %q", productID, resp.Product.GetId())
+			}
+			if resp.Product.EffectivePrice <= 0 {
+				return errors.New("get product: product has no price")
+			}
+			return nil
+		},
+	}
+}
+
+// CartAddJourney adds a dedicated test product to a dedicated test customer's
+// cart, checks the cart holds it and clears the cart again, so the probe cart
+// is never abandoned
+func CartAddJourney(client cartpb.CartServiceClient, userID, productID string) Journey {
+	return Journey{
+		Name: JourneyCartAdd,
+		Run: func(ctx context.Context) error {
+			resp, err := client.AddItem(ctx, &cartpb.AddItemRequest{UserId: userID, ProductId: productID, Quantity: 1})
+			if err != nil {
+				return fmt.Errorf("add item: %w", err)
+			}
+			found := false
+			for _, item := range resp.Cart.GetItems() {
+				if item.ProductId == productID {
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("add item: cart is missing %s", productID)
+			}
+
+			if _, err := client.ClearCart(ctx, &cartpb.ClearCartRequest{UserId: userID}); err != nil {
+				return fmt.Errorf("clear cart: %w", err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package synthetic
+
+import (
+	"context"
+	"testing"
+
+	accountpb "github.com/Ujjwaljain16/E-commerce-Backend/account/pb"
+	cartpb "github.com/Ujjwaljain16/E-commerce-Backend/cart/pb"
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAccountClient struct {
+	accountpb.AccountServiceClient
+	email string
+}
+
+func (f *fakeAccountClient) Login(ctx context.Context, req *accountpb.LoginRequest, opts ...grpc.CallOption) (*accountpb.LoginResponse, error) {
+	if req.Password != "probe-password" {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	return &accountpb.LoginResponse{User: &accountpb.User{Id: "u1"}, AccessToken: "token"}, nil
+}
+
+func (f *fakeAccountClient) GetProfile(ctx context.Context, req *accountpb.GetProfileRequest, opts ...grpc.CallOption) (*accountpb.GetProfileResponse, error) {
+	return &accountpb.GetProfileResponse{User: &accountpb.User{Id: req.UserId, Email: f.email}}, nil
+}
+
+type fakeCatalogClient struct {
+	catalogpb.CatalogServiceClient
+	product *catalogpb.Product
+}
+
+func (f *fakeCatalogClient) GetProduct(ctx context.Context, req *catalogpb.GetProductRequest, opts ...grpc.CallOption) (*catalogpb.GetProductResponse, error) {
+	if f.product == nil {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	return &catalogpb.GetProductResponse{Product: f.product}, nil
+}
+
+type fakeCartClient struct {
+	cartpb.CartServiceClient
+	dropItems bool
+	items     map[string]int32
+}
+
+func (f *fakeCartClient) AddItem(ctx context.Context, req *cartpb.AddItemRequest, opts ...grpc.CallOption) (*cartpb.AddItemResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	f.items[req.ProductId] += req.Quantity
+	cart := &cartpb.Cart{UserId: req.UserId}
+	if !f.dropItems {
+		for id, qty := range f.items {
+			cart.Items = append(cart.Items, &cartpb.CartItem{ProductId: id, Quantity: qty})
+		}
+	}
+	return &cartpb.AddItemResponse{Cart: cart}, nil
+}
+
+func (f *fakeCartClient) ClearCart(ctx context.Context, req *cartpb.ClearCartRequest, opts ...grpc.CallOption) (*cartpb.ClearCartResponse, error) {
+	f.items = map[string]int32{}
+	return &cartpb.ClearCartResponse{Cart: &cartpb.Cart{UserId: req.UserId}}, nil
+}
+
+func TestLoginJourney(t *testing.T) {
+	client := &fakeAccountClient{email: "probe@example.com"}
+	ctx := context.Background()
+
+	if err := LoginJourney(client, "probe@example.com", "probe-password").Run(ctx); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := LoginJourney(client, "probe@example.com", "wrong").Run(ctx); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", err)
+	}
+	if err := LoginJourney(client, "other@example.com", "probe-password").Run(ctx); err == nil {
+		t.Error("expected error for mismatched profile")
+	}
+}
+
+func TestProductFetchJourney(t *testing.T) {
+	ctx := context.Background()
+
+	ok := &fakeCatalogClient{product: &catalogpb.Product{Id: "p1", Price: 10, EffectivePrice: 10}}
+	if err := ProductFetchJourney(ok, "p1").Run(ctx); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	missing := &fakeCatalogClient{}
+	if err := ProductFetchJourney(missing, "p1").Run(ctx); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	unpriced := &fakeCatalogClient{product: &catalogpb.Product{Id: "p1"}}
+	if err := ProductFetchJourney(unpriced, "p1").Run(ctx); err == nil {
+		t.Error("expected error for product without a price")
+	}
+}
+
+func TestCartAddJourney(t *testing.T) {
+	ctx := context.Background()
+
+	ok := &fakeCartClient{items: map[string]int32{}}
+	if err := CartAddJourney(ok, "u1", "p1").Run(ctx); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if len(ok.items) != 0 {
+		t.Errorf("expected the cart to be cleared, got %v", ok.items)
+	}
+
+	if err := CartAddJourney(ok, "u1", "").Run(ctx); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	dropped := &fakeCartClient{dropItems: true, items: map[string]int32{}}
+	if err := CartAddJourney(dropped, "u1", "p1").Run(ctx); err == nil {
+		t.Error("expected error for a cart without the product")
+	}
+}
@@ -0,0 +1,135 @@
+// Package synthetic runs key user journeys (login, product fetch) against the
+// live services on a schedule, using dedicated test accounts and SKUs, and
+// exports their success and latency as metrics.
+package synthetic
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+)
+
+// Journey is one synthetic user journey
+type Journey struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of one journey run
+type Result struct {
+	Journey  string
+	Duration time.Duration
+	Err      error
+}
+
+// Config configures a Runner
+type Config struct {
+	// Timeout bounds each journey run
+	Timeout time.Duration
+	// FailureThreshold is the number of consecutive failures of a journey
+	// that raise an alert
+	FailureThreshold int
+}
+
+// DefaultConfig is used for zero Config fields
+var DefaultConfig = Config{
+	Timeout:          10 * time.Second,
+	FailureThreshold: 3,
+}
+
+// Runner executes journeys and tracks their consecutive failures
+type Runner struct {
+	journeys []Journey
+	cfg      Config
+	log      *logger.Logger
+	failures map[string]int
+}
+
+// NewRunner creates a runner for the given journeys
+func NewRunner(cfg Config, log *logger.Logger, journeys ...Journey) *Runner {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultConfig.Timeout
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultConfig.FailureThreshold
+	}
+	return &Runner{
+		journeys: journeys,
+		cfg:      cfg,
+		log:      log,
+		failures: make(map[string]int, len(journeys)),
+	}
+}
+
+// RunOnce runs every journey once, in order, and records the results
+func (r *Runner) RunOnce(ctx context.Context) []Result {
+	results := make([]Result, 0, len(r.journeys))
+	for _, j := range r.journeys {
+		result := r.run(ctx, j)
+		r.record(ctx, result)
+		results = append(results, result)
+	}
+	return results
+}
+
+// Run runs the journeys immediately and then every interval until ctx is cancelled
+func (r *Runner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run executes a journey within the configured timeout
+func (r *Runner) run(ctx context.Context, j Journey) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := j.Run(ctx)
+	return Result{Journey: j.Name, Duration: time.Since(start), Err: err}
+}
+
+// record exports a result and alerts when a journey starts or stops failing
+func (r *Runner) record(ctx context.Context, result Result) {
+	metrics.ProbeDuration.WithLabelValues(result.Journey).Observe(result.Duration.Seconds())
+
+	if result.Err == nil {
+		metrics.ProbeRunsTotal.WithLabelValues(result.Journey, "success").Inc()
+		metrics.ProbeUp.WithLabelValues(result.Journey).Set(1)
+		if r.failures[result.Journey] >= r.cfg.FailureThreshold {
+			r.log.Info(ctx, "Synthetic probe recovered", map[string]interface{}{
+				"journey":  result.Journey,
+				"failures": r.failures[result.Journey],
+			})
+		}
+		r.failures[result.Journey] = 0
+		return
+	}
+
+	metrics.ProbeRunsTotal.WithLabelValues(result.Journey, "failure").Inc()
+	metrics.ProbeUp.WithLabelValues(result.Journey).Set(0)
+	r.failures[result.Journey]++
+
+	fields := map[string]interface{}{
+		"journey":     result.Journey,
+		"error":       result.Err.Error(),
+		"failures":    r.failures[result.Journey],
+		"duration_ms": result.Duration.Milliseconds(),
+	}
+	// Alert once when the threshold is reached; later failures are warnings
+	if r.failures[result.Journey] == r.cfg.FailureThreshold {
+		r.log.Error(ctx, "Synthetic probe failing", fields)
+		return
+	}
+	r.log.Warn(ctx, "Synthetic probe failed", fields)
+}
@@ -0,0 +1,71 @@
+package synthetic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRunOnce_RecordsResults(t *testing.T) {
+	fail := errors.New("connection refused")
+	runner := NewRunner(Config{}, logger.New("synthetic-test"),
+		Journey{Name: "test_ok", Run: func(ctx context.Context) error { return nil }},
+		Journey{Name: "test_down", Run: func(ctx context.Context) error { return fail }},
+	)
+
+	results := runner.RunOnce(context.Background())
+
+	if len(results) != 2 || results[0].Err != nil || !errors.Is(results[1].Err, fail) {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if up := testutil.ToFloat64(metrics.ProbeUp.WithLabelValues("test_ok")); up != 1 {
+		t.Errorf("expected test_ok to be up, got %v", up)
+	}
+	if up := testutil.ToFloat64(metrics.ProbeUp.WithLabelValues("test_down")); up != 0 {
+		t.Errorf("expected test_down to be down, got %v", up)
+	}
+	if n := testutil.ToFloat64(metrics.ProbeRunsTotal.WithLabelValues("test_down", "failure")); n != 1 {
+		t.Errorf("expected 1 failed run, got %v", n)
+	}
+}
+
+func TestRunOnce_ConsecutiveFailures(t *testing.T) {
+	var err error
+	runner := NewRunner(Config{FailureThreshold: 2}, logger.New("synthetic-test"),
+		Journey{Name: "test_flaky", Run: func(ctx context.Context) error { return err }},
+	)
+	ctx := context.Background()
+
+	err = errors.New("timeout")
+	runner.RunOnce(ctx)
+	runner.RunOnce(ctx)
+	if runner.failures["test_flaky"] != 2 {
+		t.Errorf("expected 2 consecutive failures, got %d", runner.failures["test_flaky"])
+	}
+
+	err = nil
+	runner.RunOnce(ctx)
+	if runner.failures["test_flaky"] != 0 {
+		t.Errorf("expected failures to reset after success, got %d", runner.failures["test_flaky"])
+	}
+}
+
+func TestRunOnce_Timeout(t *testing.T) {
+	runner := NewRunner(Config{Timeout: 10 * time.Millisecond}, logger.New("synthetic-test"),
+		Journey{Name: "test_slow", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	)
+
+	results := runner.RunOnce(context.Background())
+
+	if !errors.Is(results[0].Err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", results[0].Err)
+	}
+}
@@ -0,0 +1,42 @@
+package vendors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrProductNotFound is returned when the catalog has no such product
+var ErrProductNotFound = errors.New("product not found")
+
+// Catalog finds who sells a product
+type Catalog interface {
+	// ProductVendor returns the vendor selling a product, or "" when the
+	// operator sells it
+	ProductVendor(ctx context.Context, productID string) (string, error)
+}
+
+type grpcCatalog struct {
+	client catalogpb.CatalogServiceClient
+}
+
+// NewGRPCCatalog creates a Catalog backed by the catalog service
+func NewGRPCCatalog(client catalogpb.CatalogServiceClient) Catalog {
+	return &grpcCatalog{client: client}
+}
+
+// ProductVendor reads the product's vendor from the catalog
+func (c *grpcCatalog) ProductVendor(ctx context.Context, productID string) (string, error) {
+	resp, err := c.client.GetProduct(ctx, &catalogpb.GetProductRequest{Id: productID})
+	if status.Code(err) == codes.NotFound {
+		return "", ErrProductNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get product: %w", err)
+	}
+	return resp.Product.VendorId, nil
+}
@@ -0,0 +1,39 @@
+package vendors
+
+import (
+	"context"
+	"testing"
+
+	catalogpb "github.com/Ujjwaljain16/E-commerce-Backend/catalog/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCatalogClient serves GetProduct from the catalog client interface
+type fakeCatalogClient struct {
+	catalogpb.CatalogServiceClient
+	products map[string]string
+}
+
+func (c *fakeCatalogClient) GetProduct(ctx context.Context, req *catalogpb.GetProductRequest, opts ...grpc.CallOption) (*catalogpb.GetProductResponse, error) {
+	vendorID, ok := c.products[req.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	return &catalogpb.GetProductResponse{Product: &catalogpb.Product{Id: req.Id, VendorId: vendorID}}, nil
+}
+
+func TestGRPCCatalog_ProductVendor(t *testing.T) {
+	catalog := NewGRPCCatalog(&fakeCatalogClient{products: map[string]string{"mug": "vendor-1", "lamp": ""}})
+
+	if vendorID, err := catalog.ProductVendor(context.Background(), "mug"); err != nil || vendorID != "vendor-1" {
+		t.Errorf("Expected vendor-1, got %q, %v", vendorID, err)
+	}
+	if vendorID, err := catalog.ProductVendor(context.Background(), "lamp"); err != nil || vendorID != "" {
+		t.Errorf("Expected the operator's product, got %q, %v", vendorID, err)
+	}
+	if _, err := catalog.ProductVendor(context.Background(), "missing"); err != ErrProductNotFound {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_vendor_commissions_vendor;
+DROP TABLE IF EXISTS vendor_commissions;
+DROP INDEX IF EXISTS idx_vendors_status;
+DROP INDEX IF EXISTS idx_vendors_owner;
+DROP TABLE IF EXISTS vendors;
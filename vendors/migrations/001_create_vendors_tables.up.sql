@@ -0,0 +1,36 @@
+-- Marketplace vendors. A vendor sells once ACTIVE; its products are those
+-- whose vendor_id in the catalog is the vendor's id.
+CREATE TABLE IF NOT EXISTS vendors (
+    id UUID PRIMARY KEY,
+    owner_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACTIVE', 'REJECTED', 'SUSPENDED')),
+    status_reason TEXT NOT NULL DEFAULT '',
+    commission_rate DECIMAL(5, 4) NOT NULL CHECK (commission_rate BETWEEN 0 AND 1),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A user registers one vendor
+CREATE UNIQUE INDEX idx_vendors_owner ON vendors(owner_id);
+-- Vendors by status, newest first
+CREATE INDEX idx_vendors_status ON vendors(status, created_at DESC);
+
+-- The commission on each order line sold by a vendor, with the rate in force
+-- when the order was paid
+CREATE TABLE IF NOT EXISTS vendor_commissions (
+    order_id VARCHAR(255) NOT NULL,
+    product_id VARCHAR(255) NOT NULL,
+    vendor_id UUID NOT NULL REFERENCES vendors(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    gross DECIMAL(10, 2) NOT NULL CHECK (gross >= 0),
+    rate DECIMAL(5, 4) NOT NULL CHECK (rate BETWEEN 0 AND 1),
+    commission DECIMAL(10, 2) NOT NULL CHECK (commission >= 0),
+    payout DECIMAL(10, 2) NOT NULL CHECK (payout >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_id, product_id)
+);
+
+-- A vendor's commissions, newest first
+CREATE INDEX idx_vendor_commissions_vendor ON vendor_commissions(vendor_id, created_at DESC);
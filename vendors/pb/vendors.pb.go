@@ -0,0 +1,1601 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: vendors/vendors.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Vendor is a marketplace seller
+type Vendor struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId        string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // user who registered and manages the vendor
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Email          string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                                         // PENDING, ACTIVE, REJECTED or SUSPENDED
+	StatusReason   string                 `protobuf:"bytes,6,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`         // why the vendor was rejected or suspended
+	CommissionRate float64                `protobuf:"fixed64,7,opt,name=commission_rate,json=commissionRate,proto3" json:"commission_rate,omitempty"` // share of each sale kept by the marketplace, from 0 to 1
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Vendor) Reset() {
+	*x = Vendor{}
+	mi := &file_vendors_vendors_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vendor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vendor) ProtoMessage() {}
+
+func (x *Vendor) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vendor.ProtoReflect.Descriptor instead.
+func (*Vendor) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vendor) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vendor) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Vendor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Vendor) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Vendor) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Vendor) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Vendor) GetCommissionRate() float64 {
+	if x != nil {
+		return x.CommissionRate
+	}
+	return 0
+}
+
+func (x *Vendor) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Vendor) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Commission is what the marketplace keeps of one order line sold by a vendor
+type Commission struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VendorId      string                 `protobuf:"bytes,3,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Gross         float64                `protobuf:"fixed64,5,opt,name=gross,proto3" json:"gross,omitempty"`           // quantity times unit price
+	Rate          float64                `protobuf:"fixed64,6,opt,name=rate,proto3" json:"rate,omitempty"`             // the vendor's commission rate when the order was paid
+	Commission    float64                `protobuf:"fixed64,7,opt,name=commission,proto3" json:"commission,omitempty"` // kept by the marketplace
+	Payout        float64                `protobuf:"fixed64,8,opt,name=payout,proto3" json:"payout,omitempty"`         // owed to the vendor
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Commission) Reset() {
+	*x = Commission{}
+	mi := &file_vendors_vendors_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Commission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Commission) ProtoMessage() {}
+
+func (x *Commission) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Commission.ProtoReflect.Descriptor instead.
+func (*Commission) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Commission) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Commission) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Commission) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+func (x *Commission) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Commission) GetGross() float64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *Commission) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *Commission) GetCommission() float64 {
+	if x != nil {
+		return x.Commission
+	}
+	return 0
+}
+
+func (x *Commission) GetPayout() float64 {
+	if x != nil {
+		return x.Payout
+	}
+	return 0
+}
+
+func (x *Commission) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// RegisterVendor applies to sell on the marketplace. The vendor is PENDING
+// until approved. A user registers one vendor.
+type RegisterVendorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterVendorRequest) Reset() {
+	*x = RegisterVendorRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterVendorRequest) ProtoMessage() {}
+
+func (x *RegisterVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterVendorRequest.ProtoReflect.Descriptor instead.
+func (*RegisterVendorRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterVendorRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *RegisterVendorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterVendorRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RegisterVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterVendorResponse) Reset() {
+	*x = RegisterVendorResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterVendorResponse) ProtoMessage() {}
+
+func (x *RegisterVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterVendorResponse.ProtoReflect.Descriptor instead.
+func (*RegisterVendorResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+// GetVendor returns a vendor
+type GetVendorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VendorId      string                 `protobuf:"bytes,1,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVendorRequest) Reset() {
+	*x = GetVendorRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVendorRequest) ProtoMessage() {}
+
+func (x *GetVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVendorRequest.ProtoReflect.Descriptor instead.
+func (*GetVendorRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetVendorRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+type GetVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVendorResponse) Reset() {
+	*x = GetVendorResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVendorResponse) ProtoMessage() {}
+
+func (x *GetVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVendorResponse.ProtoReflect.Descriptor instead.
+func (*GetVendorResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+// ListVendors lists vendors, newest first
+type ListVendorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // optional filter
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVendorsRequest) Reset() {
+	*x = ListVendorsRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVendorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVendorsRequest) ProtoMessage() {}
+
+func (x *ListVendorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVendorsRequest.ProtoReflect.Descriptor instead.
+func (*ListVendorsRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListVendorsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListVendorsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListVendorsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListVendorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendors       []*Vendor              `protobuf:"bytes,1,rep,name=vendors,proto3" json:"vendors,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVendorsResponse) Reset() {
+	*x = ListVendorsResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVendorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVendorsResponse) ProtoMessage() {}
+
+func (x *ListVendorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVendorsResponse.ProtoReflect.Descriptor instead.
+func (*ListVendorsResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListVendorsResponse) GetVendors() []*Vendor {
+	if x != nil {
+		return x.Vendors
+	}
+	return nil
+}
+
+func (x *ListVendorsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListVendorsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListVendorsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ApproveVendor lets a PENDING or SUSPENDED vendor sell
+type ApproveVendorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VendorId      string                 `protobuf:"bytes,1,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveVendorRequest) Reset() {
+	*x = ApproveVendorRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveVendorRequest) ProtoMessage() {}
+
+func (x *ApproveVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveVendorRequest.ProtoReflect.Descriptor instead.
+func (*ApproveVendorRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ApproveVendorRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+type ApproveVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveVendorResponse) Reset() {
+	*x = ApproveVendorResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveVendorResponse) ProtoMessage() {}
+
+func (x *ApproveVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveVendorResponse.ProtoReflect.Descriptor instead.
+func (*ApproveVendorResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApproveVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+// RejectVendor turns down a PENDING vendor's application
+type RejectVendorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VendorId      string                 `protobuf:"bytes,1,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectVendorRequest) Reset() {
+	*x = RejectVendorRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectVendorRequest) ProtoMessage() {}
+
+func (x *RejectVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectVendorRequest.ProtoReflect.Descriptor instead.
+func (*RejectVendorRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RejectVendorRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+func (x *RejectVendorRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RejectVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectVendorResponse) Reset() {
+	*x = RejectVendorResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectVendorResponse) ProtoMessage() {}
+
+func (x *RejectVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectVendorResponse.ProtoReflect.Descriptor instead.
+func (*RejectVendorResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RejectVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+// SuspendVendor stops an ACTIVE vendor from changing its products
+type SuspendVendorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VendorId      string                 `protobuf:"bytes,1,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendVendorRequest) Reset() {
+	*x = SuspendVendorRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendVendorRequest) ProtoMessage() {}
+
+func (x *SuspendVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendVendorRequest.ProtoReflect.Descriptor instead.
+func (*SuspendVendorRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SuspendVendorRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+func (x *SuspendVendorRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SuspendVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendVendorResponse) Reset() {
+	*x = SuspendVendorResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendVendorResponse) ProtoMessage() {}
+
+func (x *SuspendVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendVendorResponse.ProtoReflect.Descriptor instead.
+func (*SuspendVendorResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SuspendVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+// SetCommissionRate changes the share of a vendor's future sales kept by the
+// marketplace; commissions already recorded keep their rate
+type SetCommissionRateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VendorId       string                 `protobuf:"bytes,1,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	CommissionRate float64                `protobuf:"fixed64,2,opt,name=commission_rate,json=commissionRate,proto3" json:"commission_rate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetCommissionRateRequest) Reset() {
+	*x = SetCommissionRateRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCommissionRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCommissionRateRequest) ProtoMessage() {}
+
+func (x *SetCommissionRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCommissionRateRequest.ProtoReflect.Descriptor instead.
+func (*SetCommissionRateRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetCommissionRateRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+func (x *SetCommissionRateRequest) GetCommissionRate() float64 {
+	if x != nil {
+		return x.CommissionRate
+	}
+	return 0
+}
+
+type SetCommissionRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCommissionRateResponse) Reset() {
+	*x = SetCommissionRateResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCommissionRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCommissionRateResponse) ProtoMessage() {}
+
+func (x *SetCommissionRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCommissionRateResponse.ProtoReflect.Descriptor instead.
+func (*SetCommissionRateResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetCommissionRateResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+// OrderLine is a line of a paid order
+type OrderLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderLine) Reset() {
+	*x = OrderLine{}
+	mi := &file_vendors_vendors_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderLine) ProtoMessage() {}
+
+func (x *OrderLine) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderLine.ProtoReflect.Descriptor instead.
+func (*OrderLine) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OrderLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderLine) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+// RecordOrderCommissions records the commission on each line of a paid order
+// sold by a vendor. Lines of products the operator sells earn none. Recording
+// an order again returns the commissions it recorded first.
+type RecordOrderCommissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Lines         []*OrderLine           `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordOrderCommissionsRequest) Reset() {
+	*x = RecordOrderCommissionsRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordOrderCommissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordOrderCommissionsRequest) ProtoMessage() {}
+
+func (x *RecordOrderCommissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordOrderCommissionsRequest.ProtoReflect.Descriptor instead.
+func (*RecordOrderCommissionsRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecordOrderCommissionsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RecordOrderCommissionsRequest) GetLines() []*OrderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type RecordOrderCommissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commissions   []*Commission          `protobuf:"bytes,1,rep,name=commissions,proto3" json:"commissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordOrderCommissionsResponse) Reset() {
+	*x = RecordOrderCommissionsResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordOrderCommissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordOrderCommissionsResponse) ProtoMessage() {}
+
+func (x *RecordOrderCommissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordOrderCommissionsResponse.ProtoReflect.Descriptor instead.
+func (*RecordOrderCommissionsResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RecordOrderCommissionsResponse) GetCommissions() []*Commission {
+	if x != nil {
+		return x.Commissions
+	}
+	return nil
+}
+
+// ListCommissions lists a vendor's commissions, newest first
+type ListCommissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VendorId      string                 `protobuf:"bytes,1,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommissionsRequest) Reset() {
+	*x = ListCommissionsRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommissionsRequest) ProtoMessage() {}
+
+func (x *ListCommissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommissionsRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListCommissionsRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+func (x *ListCommissionsRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ListCommissionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCommissionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListCommissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commissions   []*Commission          `protobuf:"bytes,1,rep,name=commissions,proto3" json:"commissions,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommissionsResponse) Reset() {
+	*x = ListCommissionsResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommissionsResponse) ProtoMessage() {}
+
+func (x *ListCommissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommissionsResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListCommissionsResponse) GetCommissions() []*Commission {
+	if x != nil {
+		return x.Commissions
+	}
+	return nil
+}
+
+func (x *ListCommissionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListCommissionsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCommissionsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// GetCommissionSummary adds up a vendor's commissions recorded in a period
+type GetCommissionSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VendorId      string                 `protobuf:"bytes,1,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"` // optional; from the first commission when unset
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`     // optional, exclusive; up to now when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommissionSummaryRequest) Reset() {
+	*x = GetCommissionSummaryRequest{}
+	mi := &file_vendors_vendors_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommissionSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommissionSummaryRequest) ProtoMessage() {}
+
+func (x *GetCommissionSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommissionSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetCommissionSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetCommissionSummaryRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+func (x *GetCommissionSummaryRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *GetCommissionSummaryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetCommissionSummaryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetCommissionSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        int32                  `protobuf:"varint,1,opt,name=orders,proto3" json:"orders,omitempty"`
+	Gross         float64                `protobuf:"fixed64,2,opt,name=gross,proto3" json:"gross,omitempty"`
+	Commission    float64                `protobuf:"fixed64,3,opt,name=commission,proto3" json:"commission,omitempty"`
+	Payout        float64                `protobuf:"fixed64,4,opt,name=payout,proto3" json:"payout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommissionSummaryResponse) Reset() {
+	*x = GetCommissionSummaryResponse{}
+	mi := &file_vendors_vendors_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommissionSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommissionSummaryResponse) ProtoMessage() {}
+
+func (x *GetCommissionSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vendors_vendors_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommissionSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetCommissionSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_vendors_vendors_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetCommissionSummaryResponse) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *GetCommissionSummaryResponse) GetGross() float64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *GetCommissionSummaryResponse) GetCommission() float64 {
+	if x != nil {
+		return x.Commission
+	}
+	return 0
+}
+
+func (x *GetCommissionSummaryResponse) GetPayout() float64 {
+	if x != nil {
+		return x.Payout
+	}
+	return 0
+}
+
+var File_vendors_vendors_proto protoreflect.FileDescriptor
+
+const file_vendors_vendors_proto_rawDesc = "" +
+	"\n" +
+	"\x15vendors/vendors.proto\x12\avendors\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb9\x02\n" +
+	"\x06Vendor\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12#\n" +
+	"\rstatus_reason\x18\x06 \x01(\tR\fstatusReason\x12'\n" +
+	"\x0fcommission_rate\x18\a \x01(\x01R\x0ecommissionRate\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9c\x02\n" +
+	"\n" +
+	"Commission\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tvendor_id\x18\x03 \x01(\tR\bvendorId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05gross\x18\x05 \x01(\x01R\x05gross\x12\x12\n" +
+	"\x04rate\x18\x06 \x01(\x01R\x04rate\x12\x1e\n" +
+	"\n" +
+	"commission\x18\a \x01(\x01R\n" +
+	"commission\x12\x16\n" +
+	"\x06payout\x18\b \x01(\x01R\x06payout\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\\\n" +
+	"\x15RegisterVendorRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"A\n" +
+	"\x16RegisterVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.vendors.VendorR\x06vendor\"/\n" +
+	"\x10GetVendorRequest\x12\x1b\n" +
+	"\tvendor_id\x18\x01 \x01(\tR\bvendorId\"<\n" +
+	"\x11GetVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.vendors.VendorR\x06vendor\"]\n" +
+	"\x12ListVendorsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x87\x01\n" +
+	"\x13ListVendorsResponse\x12)\n" +
+	"\avendors\x18\x01 \x03(\v2\x0f.vendors.VendorR\avendors\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"3\n" +
+	"\x14ApproveVendorRequest\x12\x1b\n" +
+	"\tvendor_id\x18\x01 \x01(\tR\bvendorId\"@\n" +
+	"\x15ApproveVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.vendors.VendorR\x06vendor\"J\n" +
+	"\x13RejectVendorRequest\x12\x1b\n" +
+	"\tvendor_id\x18\x01 \x01(\tR\bvendorId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"?\n" +
+	"\x14RejectVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.vendors.VendorR\x06vendor\"K\n" +
+	"\x14SuspendVendorRequest\x12\x1b\n" +
+	"\tvendor_id\x18\x01 \x01(\tR\bvendorId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"@\n" +
+	"\x15SuspendVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.vendors.VendorR\x06vendor\"`\n" +
+	"\x18SetCommissionRateRequest\x12\x1b\n" +
+	"\tvendor_id\x18\x01 \x01(\tR\bvendorId\x12'\n" +
+	"\x0fcommission_rate\x18\x02 \x01(\x01R\x0ecommissionRate\"D\n" +
+	"\x19SetCommissionRateResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.vendors.VendorR\x06vendor\"e\n" +
+	"\tOrderLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\"d\n" +
+	"\x1dRecordOrderCommissionsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12(\n" +
+	"\x05lines\x18\x02 \x03(\v2\x12.vendors.OrderLineR\x05lines\"W\n" +
+	"\x1eRecordOrderCommissionsResponse\x125\n" +
+	"\vcommissions\x18\x01 \x03(\v2\x13.vendors.CommissionR\vcommissions\"\x81\x01\n" +
+	"\x16ListCommissionsRequest\x12\x1b\n" +
+	"\tvendor_id\x18\x01 \x01(\tR\bvendorId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x97\x01\n" +
+	"\x17ListCommissionsResponse\x125\n" +
+	"\vcommissions\x18\x01 \x03(\v2\x13.vendors.CommissionR\vcommissions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xb1\x01\n" +
+	"\x1bGetCommissionSummaryRequest\x12\x1b\n" +
+	"\tvendor_id\x18\x01 \x01(\tR\bvendorId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x84\x01\n" +
+	"\x1cGetCommissionSummaryResponse\x12\x16\n" +
+	"\x06orders\x18\x01 \x01(\x05R\x06orders\x12\x14\n" +
+	"\x05gross\x18\x02 \x01(\x01R\x05gross\x12\x1e\n" +
+	"\n" +
+	"commission\x18\x03 \x01(\x01R\n" +
+	"commission\x12\x16\n" +
+	"\x06payout\x18\x04 \x01(\x01R\x06payout2\xdf\x06\n" +
+	"\rVendorService\x12Q\n" +
+	"\x0eRegisterVendor\x12\x1e.vendors.RegisterVendorRequest\x1a\x1f.vendors.RegisterVendorResponse\x12B\n" +
+	"\tGetVendor\x12\x19.vendors.GetVendorRequest\x1a\x1a.vendors.GetVendorResponse\x12H\n" +
+	"\vListVendors\x12\x1b.vendors.ListVendorsRequest\x1a\x1c.vendors.ListVendorsResponse\x12N\n" +
+	"\rApproveVendor\x12\x1d.vendors.ApproveVendorRequest\x1a\x1e.vendors.ApproveVendorResponse\x12K\n" +
+	"\fRejectVendor\x12\x1c.vendors.RejectVendorRequest\x1a\x1d.vendors.RejectVendorResponse\x12N\n" +
+	"\rSuspendVendor\x12\x1d.vendors.SuspendVendorRequest\x1a\x1e.vendors.SuspendVendorResponse\x12Z\n" +
+	"\x11SetCommissionRate\x12!.vendors.SetCommissionRateRequest\x1a\".vendors.SetCommissionRateResponse\x12i\n" +
+	"\x16RecordOrderCommissions\x12&.vendors.RecordOrderCommissionsRequest\x1a'.vendors.RecordOrderCommissionsResponse\x12T\n" +
+	"\x0fListCommissions\x12\x1f.vendors.ListCommissionsRequest\x1a .vendors.ListCommissionsResponse\x12c\n" +
+	"\x14GetCommissionSummary\x12$.vendors.GetCommissionSummaryRequest\x1a%.vendors.GetCommissionSummaryResponseB7Z5github.com/Ujjwaljain16/E-commerce-Backend/vendors/pbb\x06proto3"
+
+var (
+	file_vendors_vendors_proto_rawDescOnce sync.Once
+	file_vendors_vendors_proto_rawDescData []byte
+)
+
+func file_vendors_vendors_proto_rawDescGZIP() []byte {
+	file_vendors_vendors_proto_rawDescOnce.Do(func() {
+		file_vendors_vendors_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vendors_vendors_proto_rawDesc), len(file_vendors_vendors_proto_rawDesc)))
+	})
+	return file_vendors_vendors_proto_rawDescData
+}
+
+var file_vendors_vendors_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_vendors_vendors_proto_goTypes = []any{
+	(*Vendor)(nil),                         // 0: vendors.Vendor
+	(*Commission)(nil),                     // 1: vendors.Commission
+	(*RegisterVendorRequest)(nil),          // 2: vendors.RegisterVendorRequest
+	(*RegisterVendorResponse)(nil),         // 3: vendors.RegisterVendorResponse
+	(*GetVendorRequest)(nil),               // 4: vendors.GetVendorRequest
+	(*GetVendorResponse)(nil),              // 5: vendors.GetVendorResponse
+	(*ListVendorsRequest)(nil),             // 6: vendors.ListVendorsRequest
+	(*ListVendorsResponse)(nil),            // 7: vendors.ListVendorsResponse
+	(*ApproveVendorRequest)(nil),           // 8: vendors.ApproveVendorRequest
+	(*ApproveVendorResponse)(nil),          // 9: vendors.ApproveVendorResponse
+	(*RejectVendorRequest)(nil),            // 10: vendors.RejectVendorRequest
+	(*RejectVendorResponse)(nil),           // 11: vendors.RejectVendorResponse
+	(*SuspendVendorRequest)(nil),           // 12: vendors.SuspendVendorRequest
+	(*SuspendVendorResponse)(nil),          // 13: vendors.SuspendVendorResponse
+	(*SetCommissionRateRequest)(nil),       // 14: vendors.SetCommissionRateRequest
+	(*SetCommissionRateResponse)(nil),      // 15: vendors.SetCommissionRateResponse
+	(*OrderLine)(nil),                      // 16: vendors.OrderLine
+	(*RecordOrderCommissionsRequest)(nil),  // 17: vendors.RecordOrderCommissionsRequest
+	(*RecordOrderCommissionsResponse)(nil), // 18: vendors.RecordOrderCommissionsResponse
+	(*ListCommissionsRequest)(nil),         // 19: vendors.ListCommissionsRequest
+	(*ListCommissionsResponse)(nil),        // 20: vendors.ListCommissionsResponse
+	(*GetCommissionSummaryRequest)(nil),    // 21: vendors.GetCommissionSummaryRequest
+	(*GetCommissionSummaryResponse)(nil),   // 22: vendors.GetCommissionSummaryResponse
+	(*timestamppb.Timestamp)(nil),          // 23: google.protobuf.Timestamp
+}
+var file_vendors_vendors_proto_depIdxs = []int32{
+	23, // 0: vendors.Vendor.created_at:type_name -> google.protobuf.Timestamp
+	23, // 1: vendors.Vendor.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 2: vendors.Commission.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: vendors.RegisterVendorResponse.vendor:type_name -> vendors.Vendor
+	0,  // 4: vendors.GetVendorResponse.vendor:type_name -> vendors.Vendor
+	0,  // 5: vendors.ListVendorsResponse.vendors:type_name -> vendors.Vendor
+	0,  // 6: vendors.ApproveVendorResponse.vendor:type_name -> vendors.Vendor
+	0,  // 7: vendors.RejectVendorResponse.vendor:type_name -> vendors.Vendor
+	0,  // 8: vendors.SuspendVendorResponse.vendor:type_name -> vendors.Vendor
+	0,  // 9: vendors.SetCommissionRateResponse.vendor:type_name -> vendors.Vendor
+	16, // 10: vendors.RecordOrderCommissionsRequest.lines:type_name -> vendors.OrderLine
+	1,  // 11: vendors.RecordOrderCommissionsResponse.commissions:type_name -> vendors.Commission
+	1,  // 12: vendors.ListCommissionsResponse.commissions:type_name -> vendors.Commission
+	23, // 13: vendors.GetCommissionSummaryRequest.from:type_name -> google.protobuf.Timestamp
+	23, // 14: vendors.GetCommissionSummaryRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 15: vendors.VendorService.RegisterVendor:input_type -> vendors.RegisterVendorRequest
+	4,  // 16: vendors.VendorService.GetVendor:input_type -> vendors.GetVendorRequest
+	6,  // 17: vendors.VendorService.ListVendors:input_type -> vendors.ListVendorsRequest
+	8,  // 18: vendors.VendorService.ApproveVendor:input_type -> vendors.ApproveVendorRequest
+	10, // 19: vendors.VendorService.RejectVendor:input_type -> vendors.RejectVendorRequest
+	12, // 20: vendors.VendorService.SuspendVendor:input_type -> vendors.SuspendVendorRequest
+	14, // 21: vendors.VendorService.SetCommissionRate:input_type -> vendors.SetCommissionRateRequest
+	17, // 22: vendors.VendorService.RecordOrderCommissions:input_type -> vendors.RecordOrderCommissionsRequest
+	19, // 23: vendors.VendorService.ListCommissions:input_type -> vendors.ListCommissionsRequest
+	21, // 24: vendors.VendorService.GetCommissionSummary:input_type -> vendors.GetCommissionSummaryRequest
+	3,  // 25: vendors.VendorService.RegisterVendor:output_type -> vendors.RegisterVendorResponse
+	5,  // 26: vendors.VendorService.GetVendor:output_type -> vendors.GetVendorResponse
+	7,  // 27: vendors.VendorService.ListVendors:output_type -> vendors.ListVendorsResponse
+	9,  // 28: vendors.VendorService.ApproveVendor:output_type -> vendors.ApproveVendorResponse
+	11, // 29: vendors.VendorService.RejectVendor:output_type -> vendors.RejectVendorResponse
+	13, // 30: vendors.VendorService.SuspendVendor:output_type -> vendors.SuspendVendorResponse
+	15, // 31: vendors.VendorService.SetCommissionRate:output_type -> vendors.SetCommissionRateResponse
+	18, // 32: vendors.VendorService.RecordOrderCommissions:output_type -> vendors.RecordOrderCommissionsResponse
+	20, // 33: vendors.VendorService.ListCommissions:output_type -> vendors.ListCommissionsResponse
+	22, // 34: vendors.VendorService.GetCommissionSummary:output_type -> vendors.GetCommissionSummaryResponse
+	25, // [25:35] is the sub-list for method output_type
+	15, // [15:25] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_vendors_vendors_proto_init() }
+func file_vendors_vendors_proto_init() {
+	if File_vendors_vendors_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vendors_vendors_proto_rawDesc), len(file_vendors_vendors_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vendors_vendors_proto_goTypes,
+		DependencyIndexes: file_vendors_vendors_proto_depIdxs,
+		MessageInfos:      file_vendors_vendors_proto_msgTypes,
+	}.Build()
+	File_vendors_vendors_proto = out.File
+	file_vendors_vendors_proto_goTypes = nil
+	file_vendors_vendors_proto_depIdxs = nil
+}
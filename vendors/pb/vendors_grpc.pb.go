@@ -0,0 +1,469 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: vendors/vendors.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VendorService_RegisterVendor_FullMethodName         = "/vendors.VendorService/RegisterVendor"
+	VendorService_GetVendor_FullMethodName              = "/vendors.VendorService/GetVendor"
+	VendorService_ListVendors_FullMethodName            = "/vendors.VendorService/ListVendors"
+	VendorService_ApproveVendor_FullMethodName          = "/vendors.VendorService/ApproveVendor"
+	VendorService_RejectVendor_FullMethodName           = "/vendors.VendorService/RejectVendor"
+	VendorService_SuspendVendor_FullMethodName          = "/vendors.VendorService/SuspendVendor"
+	VendorService_SetCommissionRate_FullMethodName      = "/vendors.VendorService/SetCommissionRate"
+	VendorService_RecordOrderCommissions_FullMethodName = "/vendors.VendorService/RecordOrderCommissions"
+	VendorService_ListCommissions_FullMethodName        = "/vendors.VendorService/ListCommissions"
+	VendorService_GetCommissionSummary_FullMethodName   = "/vendors.VendorService/GetCommissionSummary"
+)
+
+// VendorServiceClient is the client API for VendorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// VendorService onboards marketplace vendors and records the commission on
+// their sales
+type VendorServiceClient interface {
+	RegisterVendor(ctx context.Context, in *RegisterVendorRequest, opts ...grpc.CallOption) (*RegisterVendorResponse, error)
+	GetVendor(ctx context.Context, in *GetVendorRequest, opts ...grpc.CallOption) (*GetVendorResponse, error)
+	ListVendors(ctx context.Context, in *ListVendorsRequest, opts ...grpc.CallOption) (*ListVendorsResponse, error)
+	ApproveVendor(ctx context.Context, in *ApproveVendorRequest, opts ...grpc.CallOption) (*ApproveVendorResponse, error)
+	RejectVendor(ctx context.Context, in *RejectVendorRequest, opts ...grpc.CallOption) (*RejectVendorResponse, error)
+	SuspendVendor(ctx context.Context, in *SuspendVendorRequest, opts ...grpc.CallOption) (*SuspendVendorResponse, error)
+	SetCommissionRate(ctx context.Context, in *SetCommissionRateRequest, opts ...grpc.CallOption) (*SetCommissionRateResponse, error)
+	RecordOrderCommissions(ctx context.Context, in *RecordOrderCommissionsRequest, opts ...grpc.CallOption) (*RecordOrderCommissionsResponse, error)
+	ListCommissions(ctx context.Context, in *ListCommissionsRequest, opts ...grpc.CallOption) (*ListCommissionsResponse, error)
+	GetCommissionSummary(ctx context.Context, in *GetCommissionSummaryRequest, opts ...grpc.CallOption) (*GetCommissionSummaryResponse, error)
+}
+
+type vendorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVendorServiceClient(cc grpc.ClientConnInterface) VendorServiceClient {
+	return &vendorServiceClient{cc}
+}
+
+func (c *vendorServiceClient) RegisterVendor(ctx context.Context, in *RegisterVendorRequest, opts ...grpc.CallOption) (*RegisterVendorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterVendorResponse)
+	err := c.cc.Invoke(ctx, VendorService_RegisterVendor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) GetVendor(ctx context.Context, in *GetVendorRequest, opts ...grpc.CallOption) (*GetVendorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVendorResponse)
+	err := c.cc.Invoke(ctx, VendorService_GetVendor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) ListVendors(ctx context.Context, in *ListVendorsRequest, opts ...grpc.CallOption) (*ListVendorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVendorsResponse)
+	err := c.cc.Invoke(ctx, VendorService_ListVendors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) ApproveVendor(ctx context.Context, in *ApproveVendorRequest, opts ...grpc.CallOption) (*ApproveVendorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveVendorResponse)
+	err := c.cc.Invoke(ctx, VendorService_ApproveVendor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) RejectVendor(ctx context.Context, in *RejectVendorRequest, opts ...grpc.CallOption) (*RejectVendorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectVendorResponse)
+	err := c.cc.Invoke(ctx, VendorService_RejectVendor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) SuspendVendor(ctx context.Context, in *SuspendVendorRequest, opts ...grpc.CallOption) (*SuspendVendorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuspendVendorResponse)
+	err := c.cc.Invoke(ctx, VendorService_SuspendVendor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) SetCommissionRate(ctx context.Context, in *SetCommissionRateRequest, opts ...grpc.CallOption) (*SetCommissionRateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCommissionRateResponse)
+	err := c.cc.Invoke(ctx, VendorService_SetCommissionRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) RecordOrderCommissions(ctx context.Context, in *RecordOrderCommissionsRequest, opts ...grpc.CallOption) (*RecordOrderCommissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordOrderCommissionsResponse)
+	err := c.cc.Invoke(ctx, VendorService_RecordOrderCommissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) ListCommissions(ctx context.Context, in *ListCommissionsRequest, opts ...grpc.CallOption) (*ListCommissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommissionsResponse)
+	err := c.cc.Invoke(ctx, VendorService_ListCommissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorServiceClient) GetCommissionSummary(ctx context.Context, in *GetCommissionSummaryRequest, opts ...grpc.CallOption) (*GetCommissionSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCommissionSummaryResponse)
+	err := c.cc.Invoke(ctx, VendorService_GetCommissionSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorServiceServer is the server API for VendorService service.
+// All implementations must embed UnimplementedVendorServiceServer
+// for forward compatibility.
+//
+// VendorService onboards marketplace vendors and records the commission on
+// their sales
+type VendorServiceServer interface {
+	RegisterVendor(context.Context, *RegisterVendorRequest) (*RegisterVendorResponse, error)
+	GetVendor(context.Context, *GetVendorRequest) (*GetVendorResponse, error)
+	ListVendors(context.Context, *ListVendorsRequest) (*ListVendorsResponse, error)
+	ApproveVendor(context.Context, *ApproveVendorRequest) (*ApproveVendorResponse, error)
+	RejectVendor(context.Context, *RejectVendorRequest) (*RejectVendorResponse, error)
+	SuspendVendor(context.Context, *SuspendVendorRequest) (*SuspendVendorResponse, error)
+	SetCommissionRate(context.Context, *SetCommissionRateRequest) (*SetCommissionRateResponse, error)
+	RecordOrderCommissions(context.Context, *RecordOrderCommissionsRequest) (*RecordOrderCommissionsResponse, error)
+	ListCommissions(context.Context, *ListCommissionsRequest) (*ListCommissionsResponse, error)
+	GetCommissionSummary(context.Context, *GetCommissionSummaryRequest) (*GetCommissionSummaryResponse, error)
+	mustEmbedUnimplementedVendorServiceServer()
+}
+
+// UnimplementedVendorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVendorServiceServer struct{}
+
+func (UnimplementedVendorServiceServer) RegisterVendor(context.Context, *RegisterVendorRequest) (*RegisterVendorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterVendor not implemented")
+}
+func (UnimplementedVendorServiceServer) GetVendor(context.Context, *GetVendorRequest) (*GetVendorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVendor not implemented")
+}
+func (UnimplementedVendorServiceServer) ListVendors(context.Context, *ListVendorsRequest) (*ListVendorsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVendors not implemented")
+}
+func (UnimplementedVendorServiceServer) ApproveVendor(context.Context, *ApproveVendorRequest) (*ApproveVendorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveVendor not implemented")
+}
+func (UnimplementedVendorServiceServer) RejectVendor(context.Context, *RejectVendorRequest) (*RejectVendorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectVendor not implemented")
+}
+func (UnimplementedVendorServiceServer) SuspendVendor(context.Context, *SuspendVendorRequest) (*SuspendVendorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuspendVendor not implemented")
+}
+func (UnimplementedVendorServiceServer) SetCommissionRate(context.Context, *SetCommissionRateRequest) (*SetCommissionRateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCommissionRate not implemented")
+}
+func (UnimplementedVendorServiceServer) RecordOrderCommissions(context.Context, *RecordOrderCommissionsRequest) (*RecordOrderCommissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordOrderCommissions not implemented")
+}
+func (UnimplementedVendorServiceServer) ListCommissions(context.Context, *ListCommissionsRequest) (*ListCommissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCommissions not implemented")
+}
+func (UnimplementedVendorServiceServer) GetCommissionSummary(context.Context, *GetCommissionSummaryRequest) (*GetCommissionSummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCommissionSummary not implemented")
+}
+func (UnimplementedVendorServiceServer) mustEmbedUnimplementedVendorServiceServer() {}
+func (UnimplementedVendorServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeVendorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VendorServiceServer will
+// result in compilation errors.
+type UnsafeVendorServiceServer interface {
+	mustEmbedUnimplementedVendorServiceServer()
+}
+
+func RegisterVendorServiceServer(s grpc.ServiceRegistrar, srv VendorServiceServer) {
+	// If the following call panics, it indicates UnimplementedVendorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VendorService_ServiceDesc, srv)
+}
+
+func _VendorService_RegisterVendor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterVendorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).RegisterVendor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_RegisterVendor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).RegisterVendor(ctx, req.(*RegisterVendorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_GetVendor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVendorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).GetVendor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_GetVendor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).GetVendor(ctx, req.(*GetVendorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_ListVendors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVendorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).ListVendors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_ListVendors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).ListVendors(ctx, req.(*ListVendorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_ApproveVendor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveVendorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).ApproveVendor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_ApproveVendor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).ApproveVendor(ctx, req.(*ApproveVendorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_RejectVendor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectVendorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).RejectVendor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_RejectVendor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).RejectVendor(ctx, req.(*RejectVendorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_SuspendVendor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendVendorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).SuspendVendor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_SuspendVendor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).SuspendVendor(ctx, req.(*SuspendVendorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_SetCommissionRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCommissionRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).SetCommissionRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_SetCommissionRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).SetCommissionRate(ctx, req.(*SetCommissionRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_RecordOrderCommissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordOrderCommissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).RecordOrderCommissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_RecordOrderCommissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).RecordOrderCommissions(ctx, req.(*RecordOrderCommissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_ListCommissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).ListCommissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_ListCommissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).ListCommissions(ctx, req.(*ListCommissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorService_GetCommissionSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommissionSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorServiceServer).GetCommissionSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorService_GetCommissionSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorServiceServer).GetCommissionSummary(ctx, req.(*GetCommissionSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VendorService_ServiceDesc is the grpc.ServiceDesc for VendorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VendorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vendors.VendorService",
+	HandlerType: (*VendorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterVendor",
+			Handler:    _VendorService_RegisterVendor_Handler,
+		},
+		{
+			MethodName: "GetVendor",
+			Handler:    _VendorService_GetVendor_Handler,
+		},
+		{
+			MethodName: "ListVendors",
+			Handler:    _VendorService_ListVendors_Handler,
+		},
+		{
+			MethodName: "ApproveVendor",
+			Handler:    _VendorService_ApproveVendor_Handler,
+		},
+		{
+			MethodName: "RejectVendor",
+			Handler:    _VendorService_RejectVendor_Handler,
+		},
+		{
+			MethodName: "SuspendVendor",
+			Handler:    _VendorService_SuspendVendor_Handler,
+		},
+		{
+			MethodName: "SetCommissionRate",
+			Handler:    _VendorService_SetCommissionRate_Handler,
+		},
+		{
+			MethodName: "RecordOrderCommissions",
+			Handler:    _VendorService_RecordOrderCommissions_Handler,
+		},
+		{
+			MethodName: "ListCommissions",
+			Handler:    _VendorService_ListCommissions_Handler,
+		},
+		{
+			MethodName: "GetCommissionSummary",
+			Handler:    _VendorService_GetCommissionSummary_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vendors/vendors.proto",
+}
@@ -0,0 +1,387 @@
+package vendors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+)
+
+// Vendor statuses
+const (
+	StatusPending   = "PENDING"
+	StatusActive    = "ACTIVE"
+	StatusRejected  = "REJECTED"
+	StatusSuspended = "SUSPENDED"
+)
+
+var (
+	// ErrVendorNotFound is returned when a vendor does not exist
+	ErrVendorNotFound = errors.New("vendor not found")
+	// ErrOwnerTaken is returned when a user registers a second vendor
+	ErrOwnerTaken = errors.New("user already registered a vendor")
+	// ErrStatusConflict is returned when a vendor is not in a status the
+	// change applies to
+	ErrStatusConflict = errors.New("vendor status changed")
+)
+
+// ownerConstraint is the unique index on the user who registered a vendor
+const ownerConstraint = "idx_vendors_owner"
+
+// Vendor is a marketplace seller
+type Vendor struct {
+	ID      string
+	OwnerID string
+	Name    string
+	Email   string
+	Status  string
+	// StatusReason is why the vendor was rejected or suspended
+	StatusReason string
+	// CommissionRate is the share of each sale kept by the marketplace
+	CommissionRate float64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Commission is what the marketplace keeps of one order line sold by a vendor
+type Commission struct {
+	OrderID   string
+	ProductID string
+	VendorID  string
+	Quantity  int32
+	Gross     float64
+	// Rate is the vendor's commission rate when the order was paid
+	Rate       float64
+	Commission float64
+	Payout     float64
+	CreatedAt  time.Time
+}
+
+// Summary adds up a vendor's commissions
+type Summary struct {
+	Orders     int32
+	Gross      float64
+	Commission float64
+	Payout     float64
+}
+
+// Repository handles vendor and commission data persistence
+type Repository interface {
+	// Create inserts a vendor, returning ErrOwnerTaken when its owner has one
+	Create(ctx context.Context, vendor *Vendor) (*Vendor, error)
+	GetByID(ctx context.Context, id string) (*Vendor, error)
+	// List lists vendors, newest first; an empty status matches every vendor
+	List(ctx context.Context, status string, page, pageSize int32) ([]*Vendor, int32, error)
+	// Approve moves a PENDING or SUSPENDED vendor to ACTIVE
+	Approve(ctx context.Context, id string, at time.Time) (*Vendor, error)
+	// Reject moves a PENDING vendor to REJECTED
+	Reject(ctx context.Context, id, reason string, at time.Time) (*Vendor, error)
+	// Suspend moves an ACTIVE vendor to SUSPENDED
+	Suspend(ctx context.Context, id, reason string, at time.Time) (*Vendor, error)
+	// SetCommissionRate changes the rate of a vendor that is not REJECTED
+	SetCommissionRate(ctx context.Context, id string, rate float64, at time.Time) (*Vendor, error)
+	// RecordCommissions stores an order's commissions unless the order has
+	// some already, and returns those the order has
+	RecordCommissions(ctx context.Context, orderID string, commissions []*Commission) ([]*Commission, error)
+	// Commissions lists a vendor's commissions, newest first
+	Commissions(ctx context.Context, vendorID string, page, pageSize int32) ([]*Commission, int32, error)
+	// Summarize adds up a vendor's commissions recorded from from until to
+	Summarize(ctx context.Context, vendorID string, from, to time.Time) (*Summary, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL vendor repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const vendorColumns = `id, owner_id, name, email, status, status_reason, commission_rate, created_at, updated_at`
+
+const commissionColumns = `order_id, product_id, vendor_id, quantity, gross, rate, commission, payout, created_at`
+
+// Create inserts a vendor
+func (r *postgresRepository) Create(ctx context.Context, vendor *Vendor) (*Vendor, error) {
+	vendor.ID = uuid.New().String()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO vendors (id, owner_id, name, email, status, commission_rate, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, vendor.ID, vendor.OwnerID, vendor.Name, vendor.Email, vendor.Status, vendor.CommissionRate, vendor.CreatedAt, vendor.UpdatedAt)
+	if pgerr.IsUniqueViolation(err, ownerConstraint) {
+		return nil, ErrOwnerTaken
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to create vendor", map[string]interface{}{"error": err.Error(), "owner_id": vendor.OwnerID})
+		return nil, fmt.Errorf("failed to create vendor: %w", err)
+	}
+
+	r.log.Info(ctx, "Vendor registered", map[string]interface{}{"vendor_id": vendor.ID, "owner_id": vendor.OwnerID})
+	return vendor, nil
+}
+
+// GetByID retrieves a vendor by ID
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*Vendor, error) {
+	query := "SELECT " + vendorColumns + " FROM vendors WHERE id = $1"
+
+	vendor, err := scanVendor(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrVendorNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get vendor", map[string]interface{}{"error": err.Error(), "vendor_id": id})
+		return nil, fmt.Errorf("failed to get vendor: %w", err)
+	}
+	return vendor, nil
+}
+
+// List retrieves vendors with pagination
+func (r *postgresRepository) List(ctx context.Context, status string, page, pageSize int32) ([]*Vendor, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM vendors WHERE ($1 = '' OR status = $1)", status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count vendors", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to count vendors: %w", err)
+	}
+
+	query := "SELECT " + vendorColumns + ` FROM vendors
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list vendors", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("failed to list vendors: %w", err)
+	}
+	defer rows.Close()
+
+	vendors := make([]*Vendor, 0, pageSize)
+	for rows.Next() {
+		vendor, err := scanVendor(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan vendor", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan vendor: %w", err)
+		}
+		vendors = append(vendors, vendor)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating vendors", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating vendors: %w", err)
+	}
+
+	return vendors, total, nil
+}
+
+// Approve lets a PENDING or SUSPENDED vendor sell
+func (r *postgresRepository) Approve(ctx context.Context, id string, at time.Time) (*Vendor, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE vendors SET status = 'ACTIVE', status_reason = '', updated_at = $2
+		WHERE id = $1 AND status IN ('PENDING', 'SUSPENDED')
+	`, id, at)
+	return r.afterUpdate(ctx, id, result, err, "approve")
+}
+
+// Reject turns down a PENDING vendor
+func (r *postgresRepository) Reject(ctx context.Context, id, reason string, at time.Time) (*Vendor, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE vendors SET status = 'REJECTED', status_reason = $2, updated_at = $3
+		WHERE id = $1 AND status = 'PENDING'
+	`, id, reason, at)
+	return r.afterUpdate(ctx, id, result, err, "reject")
+}
+
+// Suspend stops an ACTIVE vendor from selling
+func (r *postgresRepository) Suspend(ctx context.Context, id, reason string, at time.Time) (*Vendor, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE vendors SET status = 'SUSPENDED', status_reason = $2, updated_at = $3
+		WHERE id = $1 AND status = 'ACTIVE'
+	`, id, reason, at)
+	return r.afterUpdate(ctx, id, result, err, "suspend")
+}
+
+// SetCommissionRate changes a vendor's commission rate
+func (r *postgresRepository) SetCommissionRate(ctx context.Context, id string, rate float64, at time.Time) (*Vendor, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE vendors SET commission_rate = $2, updated_at = $3
+		WHERE id = $1 AND status <> 'REJECTED'
+	`, id, rate, at)
+	return r.afterUpdate(ctx, id, result, err, "set_commission_rate")
+}
+
+// afterUpdate reads back a vendor after a status-guarded update. When no row
+// changed it tells a missing vendor from one in another status.
+func (r *postgresRepository) afterUpdate(ctx context.Context, id string, result sql.Result, err error, action string) (*Vendor, error) {
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrVendorNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update vendor", map[string]interface{}{"error": err.Error(), "vendor_id": id, "action": action})
+		return nil, fmt.Errorf("failed to update vendor: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vendor: %w", err)
+	}
+	if rows == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrStatusConflict
+	}
+
+	r.log.Info(ctx, "Vendor updated", map[string]interface{}{"vendor_id": id, "action": action})
+	return r.GetByID(ctx, id)
+}
+
+// RecordCommissions inserts an order's commissions in one transaction. An
+// order reported again inserts nothing, so a retried report cannot count a
+// sale twice.
+func (r *postgresRepository) RecordCommissions(ctx context.Context, orderID string, commissions []*Commission) ([]*Commission, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, c := range commissions {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO vendor_commissions (order_id, product_id, vendor_id, quantity, gross, rate, commission, payout, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (order_id, product_id) DO NOTHING
+		`, orderID, c.ProductID, c.VendorID, c.Quantity, c.Gross, c.Rate, c.Commission, c.Payout, c.CreatedAt)
+		if err != nil {
+			r.log.Error(ctx, "Failed to record commission", map[string]interface{}{"error": err.Error(), "order_id": orderID, "product_id": c.ProductID})
+			return nil, fmt.Errorf("failed to record commission: %w", err)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT "+commissionColumns+" FROM vendor_commissions WHERE order_id = $1 ORDER BY product_id", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commissions: %w", err)
+	}
+	recorded, err := scanCommissions(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Order commissions recorded", map[string]interface{}{"order_id": orderID, "commissions": len(recorded)})
+	return recorded, nil
+}
+
+// Commissions retrieves a vendor's commissions with pagination
+func (r *postgresRepository) Commissions(ctx context.Context, vendorID string, page, pageSize int32) ([]*Commission, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM vendor_commissions WHERE vendor_id = $1", vendorID).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count commissions", map[string]interface{}{"error": err.Error(), "vendor_id": vendorID})
+		return nil, 0, fmt.Errorf("failed to count commissions: %w", err)
+	}
+
+	query := "SELECT " + commissionColumns + ` FROM vendor_commissions
+		WHERE vendor_id = $1
+		ORDER BY created_at DESC, order_id, product_id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, vendorID, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list commissions", map[string]interface{}{"error": err.Error(), "vendor_id": vendorID})
+		return nil, 0, fmt.Errorf("failed to list commissions: %w", err)
+	}
+	commissions, err := scanCommissions(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return commissions, total, nil
+}
+
+// Summarize adds up a vendor's commissions in one query
+func (r *postgresRepository) Summarize(ctx context.Context, vendorID string, from, to time.Time) (*Summary, error) {
+	s := &Summary{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT order_id), COALESCE(SUM(gross), 0), COALESCE(SUM(commission), 0), COALESCE(SUM(payout), 0)
+		FROM vendor_commissions
+		WHERE vendor_id = $1 AND created_at >= $2 AND created_at < $3
+	`, vendorID, from, to).Scan(&s.Orders, &s.Gross, &s.Commission, &s.Payout)
+	if err != nil {
+		r.log.Error(ctx, "Failed to summarize commissions", map[string]interface{}{"error": err.Error(), "vendor_id": vendorID})
+		return nil, fmt.Errorf("failed to summarize commissions: %w", err)
+	}
+	return s, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanVendor(row rowScanner) (*Vendor, error) {
+	v := &Vendor{}
+	err := row.Scan(&v.ID, &v.OwnerID, &v.Name, &v.Email, &v.Status, &v.StatusReason, &v.CommissionRate, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// scanCommissions reads and closes rows selected with commissionColumns
+func scanCommissions(rows *sql.Rows) ([]*Commission, error) {
+	defer rows.Close()
+
+	var commissions []*Commission
+	for rows.Next() {
+		c := &Commission{}
+		err := rows.Scan(&c.OrderID, &c.ProductID, &c.VendorID, &c.Quantity, &c.Gross, &c.Rate, &c.Commission, &c.Payout, &c.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan commission: %w", err)
+		}
+		commissions = append(commissions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating commissions: %w", err)
+	}
+	return commissions, nil
+}
@@ -0,0 +1,177 @@
+package vendors
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("vendors-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var vendorColumnNames = []string{
+	"id", "owner_id", "name", "email", "status", "status_reason", "commission_rate", "created_at", "updated_at",
+}
+
+var commissionColumnNames = []string{
+	"order_id", "product_id", "vendor_id", "quantity", "gross", "rate", "commission", "payout", "created_at",
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO vendors`).
+		WithArgs(sqlmock.AnyArg(), "user-1", "Shop", "shop@example.com", StatusPending, 0.15, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	vendor, err := repo.Create(context.Background(), &Vendor{
+		OwnerID: "user-1", Name: "Shop", Email: "shop@example.com", Status: StatusPending, CommissionRate: 0.15, CreatedAt: now, UpdatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if vendor.ID == "" {
+		t.Error("Expected an ID to be assigned")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreate_OwnerTaken(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO vendors`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: ownerConstraint})
+
+	_, err := repo.Create(context.Background(), &Vendor{OwnerID: "user-1"})
+	if err != ErrOwnerTaken {
+		t.Errorf("Expected ErrOwnerTaken, got %v", err)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM vendors WHERE id = \$1`).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT .* FROM vendors WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	for _, id := range []string{"missing", "not-a-uuid"} {
+		if _, err := repo.GetByID(context.Background(), id); err != ErrVendorNotFound {
+			t.Errorf("Expected ErrVendorNotFound for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestApprove(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE vendors SET status = 'ACTIVE'.* WHERE id = \$1 AND status IN \('PENDING', 'SUSPENDED'\)`).
+		WithArgs("vendor-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .* FROM vendors WHERE id = \$1`).
+		WithArgs("vendor-1").
+		WillReturnRows(sqlmock.NewRows(vendorColumnNames).
+			AddRow("vendor-1", "user-1", "Shop", "shop@example.com", StatusActive, "", 0.15, now, now))
+
+	vendor, err := repo.Approve(context.Background(), "vendor-1", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if vendor.Status != StatusActive {
+		t.Errorf("Expected ACTIVE, got %s", vendor.Status)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSuspend_StatusConflict(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE vendors SET status = 'SUSPENDED'`).
+		WithArgs("vendor-1", "fraud", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT .* FROM vendors WHERE id = \$1`).
+		WithArgs("vendor-1").
+		WillReturnRows(sqlmock.NewRows(vendorColumnNames).
+			AddRow("vendor-1", "user-1", "Shop", "shop@example.com", StatusPending, "", 0.15, now, now))
+
+	if _, err := repo.Suspend(context.Background(), "vendor-1", "fraud", now); err != ErrStatusConflict {
+		t.Errorf("Expected ErrStatusConflict, got %v", err)
+	}
+}
+
+func TestRecordCommissions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO vendor_commissions .* ON CONFLICT \(order_id, product_id\) DO NOTHING`).
+		WithArgs("order-1", "mug", "vendor-1", int32(3), 37.5, 0.15, 5.63, 31.87, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .* FROM vendor_commissions WHERE order_id = \$1`).
+		WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows(commissionColumnNames).
+			AddRow("order-1", "mug", "vendor-1", 3, 37.5, 0.15, 5.63, 31.87, now))
+	mock.ExpectCommit()
+
+	recorded, err := repo.RecordCommissions(context.Background(), "order-1", []*Commission{{
+		OrderID: "order-1", ProductID: "mug", VendorID: "vendor-1", Quantity: 3, Gross: 37.5, Rate: 0.15, Commission: 5.63, Payout: 31.87, CreatedAt: now,
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(recorded) != 1 || recorded[0].Payout != 31.87 {
+		t.Errorf("Unexpected commissions %v", recorded)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT order_id\).* FROM vendor_commissions`).
+		WithArgs("vendor-1", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"orders", "gross", "commission", "payout"}).AddRow(2, 40.0, 6.0, 34.0))
+
+	summary, err := repo.Summarize(context.Background(), "vendor-1", from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if summary.Orders != 2 || summary.Payout != 34 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+}
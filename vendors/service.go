@@ -0,0 +1,564 @@
+package vendors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/vendors/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxFieldLength matches the VARCHAR(255) columns of the vendors table
+	maxFieldLength = 255
+	// maxReasonLength caps a rejection or suspension reason
+	maxReasonLength = 1000
+	// maxOrderLines caps the lines of an order reported for commission
+	maxOrderLines = 100
+	// maxAmount fits the DECIMAL(10, 2) amount columns
+	maxAmount = 99999999.99
+	// DefaultCommissionRate is the share of each sale kept by the marketplace
+	// for newly registered vendors
+	DefaultCommissionRate = 0.15
+)
+
+// AdminMethods are the admin-scoped RPCs of the vendor service; they are
+// restricted to the admin network allowlist. The order service records
+// commissions from inside it.
+var AdminMethods = []string{
+	pb.VendorService_ListVendors_FullMethodName,
+	pb.VendorService_ApproveVendor_FullMethodName,
+	pb.VendorService_RejectVendor_FullMethodName,
+	pb.VendorService_SuspendVendor_FullMethodName,
+	pb.VendorService_SetCommissionRate_FullMethodName,
+	pb.VendorService_RecordOrderCommissions_FullMethodName,
+}
+
+// Service implements the VendorService gRPC interface
+type Service struct {
+	pb.UnimplementedVendorServiceServer
+	repo    Repository
+	catalog Catalog
+	// commissionRate is given to newly registered vendors
+	commissionRate float64
+	log            *logger.Logger
+	now            func() time.Time
+}
+
+// NewService creates a new vendor service
+func NewService(repo Repository, catalog Catalog, commissionRate float64, log *logger.Logger) *Service {
+	return &Service{
+		repo:           repo,
+		catalog:        catalog,
+		commissionRate: commissionRate,
+		log:            log,
+		now:            time.Now,
+	}
+}
+
+// RegisterVendor applies to sell on the marketplace
+func (s *Service) RegisterVendor(ctx context.Context, req *pb.RegisterVendorRequest) (*pb.RegisterVendorResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if msg := registerProblem(req, name); msg != "" {
+		s.log.Warn(ctx, "Register vendor failed: "+msg, map[string]interface{}{"owner_id": req.OwnerId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	now := s.now()
+	vendor, err := s.repo.Create(ctx, &Vendor{
+		OwnerID:        req.OwnerId,
+		Name:           name,
+		Email:          req.Email,
+		Status:         StatusPending,
+		CommissionRate: s.commissionRate,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if errors.Is(err, ErrOwnerTaken) {
+		s.log.Warn(ctx, "Register vendor failed: user already registered a vendor", map[string]interface{}{"owner_id": req.OwnerId})
+		return nil, status.Error(codes.AlreadyExists, "user already registered a vendor")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to register vendor")
+	}
+
+	return &pb.RegisterVendorResponse{
+		Vendor: toProtoVendor(vendor),
+	}, nil
+}
+
+// registerProblem validates a registration and returns a message describing
+// the first problem, or ""
+func registerProblem(req *pb.RegisterVendorRequest, name string) string {
+	switch {
+	case req.OwnerId == "":
+		return "owner_id is required"
+	case len(req.OwnerId) > maxFieldLength:
+		return "owner_id is too long"
+	case name == "":
+		return "name is required"
+	case utf8.RuneCountInString(name) > maxFieldLength:
+		return fmt.Sprintf("name cannot exceed %d characters", maxFieldLength)
+	case !validEmail(req.Email):
+		return "email must be a valid email address"
+	case len(req.Email) > maxFieldLength:
+		return "email is too long"
+	}
+	return ""
+}
+
+// GetVendor returns a vendor
+func (s *Service) GetVendor(ctx context.Context, req *pb.GetVendorRequest) (*pb.GetVendorResponse, error) {
+	vendor, err := s.load(ctx, req.VendorId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetVendorResponse{
+		Vendor: toProtoVendor(vendor),
+	}, nil
+}
+
+// ListVendors lists vendors, newest first
+func (s *Service) ListVendors(ctx context.Context, req *pb.ListVendorsRequest) (*pb.ListVendorsResponse, error) {
+	switch req.Status {
+	case "", StatusPending, StatusActive, StatusRejected, StatusSuspended:
+	default:
+		s.log.Warn(ctx, "List vendors failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be PENDING, ACTIVE, REJECTED or SUSPENDED")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	vendors, total, err := s.repo.List(ctx, req.Status, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list vendors")
+	}
+
+	protoVendors := make([]*pb.Vendor, len(vendors))
+	for i, v := range vendors {
+		protoVendors[i] = toProtoVendor(v)
+	}
+
+	return &pb.ListVendorsResponse{
+		Vendors:  protoVendors,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// ApproveVendor lets a PENDING or SUSPENDED vendor sell
+func (s *Service) ApproveVendor(ctx context.Context, req *pb.ApproveVendorRequest) (*pb.ApproveVendorResponse, error) {
+	vendor, err := s.load(ctx, req.VendorId)
+	if err != nil {
+		return nil, err
+	}
+	if vendor.Status != StatusPending && vendor.Status != StatusSuspended {
+		s.log.Warn(ctx, "Approve vendor failed: vendor is "+vendor.Status, map[string]interface{}{"vendor_id": vendor.ID})
+		return nil, status.Error(codes.FailedPrecondition, "only PENDING or SUSPENDED vendors can be approved")
+	}
+
+	vendor, err = s.repo.Approve(ctx, vendor.ID, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, req.VendorId)
+	}
+	return &pb.ApproveVendorResponse{
+		Vendor: toProtoVendor(vendor),
+	}, nil
+}
+
+// RejectVendor turns down a PENDING vendor's application
+func (s *Service) RejectVendor(ctx context.Context, req *pb.RejectVendorRequest) (*pb.RejectVendorResponse, error) {
+	reason, err := s.reason(ctx, "Reject vendor", req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	vendor, err := s.load(ctx, req.VendorId)
+	if err != nil {
+		return nil, err
+	}
+	if vendor.Status != StatusPending {
+		s.log.Warn(ctx, "Reject vendor failed: vendor is "+vendor.Status, map[string]interface{}{"vendor_id": vendor.ID})
+		return nil, status.Error(codes.FailedPrecondition, "only PENDING vendors can be rejected")
+	}
+
+	vendor, err = s.repo.Reject(ctx, vendor.ID, reason, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, req.VendorId)
+	}
+	return &pb.RejectVendorResponse{
+		Vendor: toProtoVendor(vendor),
+	}, nil
+}
+
+// SuspendVendor stops an ACTIVE vendor from changing its products
+func (s *Service) SuspendVendor(ctx context.Context, req *pb.SuspendVendorRequest) (*pb.SuspendVendorResponse, error) {
+	reason, err := s.reason(ctx, "Suspend vendor", req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	vendor, err := s.load(ctx, req.VendorId)
+	if err != nil {
+		return nil, err
+	}
+	if vendor.Status != StatusActive {
+		s.log.Warn(ctx, "Suspend vendor failed: vendor is "+vendor.Status, map[string]interface{}{"vendor_id": vendor.ID})
+		return nil, status.Error(codes.FailedPrecondition, "only ACTIVE vendors can be suspended")
+	}
+
+	vendor, err = s.repo.Suspend(ctx, vendor.ID, reason, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, req.VendorId)
+	}
+	return &pb.SuspendVendorResponse{
+		Vendor: toProtoVendor(vendor),
+	}, nil
+}
+
+// reason validates a rejection or suspension reason
+func (s *Service) reason(ctx context.Context, op, reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		s.log.Warn(ctx, op+" failed: reason is required", nil)
+		return "", status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if utf8.RuneCountInString(reason) > maxReasonLength {
+		s.log.Warn(ctx, op+" failed: reason too long", nil)
+		return "", status.Errorf(codes.InvalidArgument, "reason cannot exceed %d characters", maxReasonLength)
+	}
+	return reason, nil
+}
+
+// SetCommissionRate changes the share of a vendor's future sales kept by the
+// marketplace
+func (s *Service) SetCommissionRate(ctx context.Context, req *pb.SetCommissionRateRequest) (*pb.SetCommissionRateResponse, error) {
+	if !ValidCommissionRate(req.CommissionRate) {
+		s.log.Warn(ctx, "Set commission rate failed: invalid rate", map[string]interface{}{"vendor_id": req.VendorId, "commission_rate": req.CommissionRate})
+		return nil, status.Error(codes.InvalidArgument, "commission_rate must be between 0 and 1 with at most 4 decimal places")
+	}
+	vendor, err := s.load(ctx, req.VendorId)
+	if err != nil {
+		return nil, err
+	}
+	if vendor.Status == StatusRejected {
+		s.log.Warn(ctx, "Set commission rate failed: vendor is REJECTED", map[string]interface{}{"vendor_id": vendor.ID})
+		return nil, status.Error(codes.FailedPrecondition, "rejected vendors cannot be changed")
+	}
+
+	vendor, err = s.repo.SetCommissionRate(ctx, vendor.ID, req.CommissionRate, s.now())
+	if err != nil {
+		return nil, s.updateError(ctx, err, req.VendorId)
+	}
+	return &pb.SetCommissionRateResponse{
+		Vendor: toProtoVendor(vendor),
+	}, nil
+}
+
+// ValidCommissionRate reports whether rate is a share from 0 to 1 that fits
+// the DECIMAL(5, 4) rate columns
+func ValidCommissionRate(rate float64) bool {
+	if !(rate >= 0 && rate <= 1) {
+		return false
+	}
+	scaled := rate * 10000
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
+}
+
+// RecordOrderCommissions records the commission on each line of a paid order
+// sold by a vendor
+func (s *Service) RecordOrderCommissions(ctx context.Context, req *pb.RecordOrderCommissionsRequest) (*pb.RecordOrderCommissionsResponse, error) {
+	if msg := recordProblem(req); msg != "" {
+		s.log.Warn(ctx, "Record order commissions failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	commissions, err := s.commissions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(commissions) == 0 {
+		return &pb.RecordOrderCommissionsResponse{}, nil
+	}
+
+	recorded, err := s.repo.RecordCommissions(ctx, req.OrderId, commissions)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to record commissions")
+	}
+	return &pb.RecordOrderCommissionsResponse{
+		Commissions: toProtoCommissions(recorded),
+	}, nil
+}
+
+// recordProblem validates an order report and returns a message describing
+// the first problem, or ""
+func recordProblem(req *pb.RecordOrderCommissionsRequest) string {
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case len(req.OrderId) > maxFieldLength:
+		return "order_id is too long"
+	case len(req.Lines) == 0:
+		return "lines are required"
+	case len(req.Lines) > maxOrderLines:
+		return fmt.Sprintf("at most %d lines are allowed", maxOrderLines)
+	}
+	for _, line := range req.Lines {
+		switch {
+		case line.ProductId == "":
+			return "product_id is required for every line"
+		case len(line.ProductId) > maxFieldLength:
+			return "product_id is too long"
+		case line.Quantity <= 0:
+			return "quantity must be positive"
+		case !(line.UnitPrice >= 0 && line.UnitPrice <= maxAmount):
+			return "unit_price must be an amount between 0 and 99999999.99"
+		case float64(line.Quantity)*line.UnitPrice > maxAmount:
+			return "line total cannot exceed 99999999.99"
+		}
+	}
+	return ""
+}
+
+// commissions works out the commission on each vendor's lines at the
+// vendor's current rate. Lines of one product are added together; lines the
+// operator sells earn none.
+func (s *Service) commissions(ctx context.Context, req *pb.RecordOrderCommissionsRequest) ([]*Commission, error) {
+	now := s.now()
+	byProduct := make(map[string]*Commission, len(req.Lines))
+	var commissions []*Commission
+	for _, line := range req.Lines {
+		if c, ok := byProduct[line.ProductId]; ok {
+			c.Quantity += line.Quantity
+			c.Gross += float64(line.Quantity) * line.UnitPrice
+			continue
+		}
+		c := &Commission{
+			OrderID:   req.OrderId,
+			ProductID: line.ProductId,
+			Quantity:  line.Quantity,
+			Gross:     float64(line.Quantity) * line.UnitPrice,
+			CreatedAt: now,
+		}
+		byProduct[line.ProductId] = c
+		commissions = append(commissions, c)
+	}
+
+	vendors := make(map[string]*Vendor)
+	kept := commissions[:0]
+	for _, c := range commissions {
+		fields := map[string]interface{}{"order_id": req.OrderId, "product_id": c.ProductID}
+		vendorID, err := s.catalog.ProductVendor(ctx, c.ProductID)
+		if errors.Is(err, ErrProductNotFound) {
+			// Deleted since the order was placed; its seller is unknown
+			s.log.Warn(ctx, "No commission: product not found", fields)
+			continue
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+			s.log.Error(ctx, "Failed to look up product vendor", fields)
+			return nil, status.Error(codes.Unavailable, "failed to look up products")
+		}
+		if vendorID == "" {
+			continue
+		}
+
+		vendor, ok := vendors[vendorID]
+		if !ok {
+			vendor, err = s.repo.GetByID(ctx, vendorID)
+			if errors.Is(err, ErrVendorNotFound) {
+				fields["vendor_id"] = vendorID
+				s.log.Warn(ctx, "No commission: vendor not found", fields)
+				continue
+			}
+			if err != nil {
+				return nil, status.Error(codes.Internal, "failed to record commissions")
+			}
+			vendors[vendorID] = vendor
+		}
+
+		c.VendorID = vendor.ID
+		c.Gross = roundCents(c.Gross)
+		c.Rate = vendor.CommissionRate
+		c.Commission = roundCents(c.Gross * c.Rate)
+		c.Payout = roundCents(c.Gross - c.Commission)
+		kept = append(kept, c)
+	}
+	return kept, nil
+}
+
+// ListCommissions lists a vendor's commissions, newest first
+func (s *Service) ListCommissions(ctx context.Context, req *pb.ListCommissionsRequest) (*pb.ListCommissionsResponse, error) {
+	vendor, err := s.loadOwned(ctx, req.VendorId, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	commissions, total, err := s.repo.Commissions(ctx, vendor.ID, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list commissions")
+	}
+
+	return &pb.ListCommissionsResponse{
+		Commissions: toProtoCommissions(commissions),
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+	}, nil
+}
+
+// GetCommissionSummary adds up a vendor's commissions recorded in a period
+func (s *Service) GetCommissionSummary(ctx context.Context, req *pb.GetCommissionSummaryRequest) (*pb.GetCommissionSummaryResponse, error) {
+	vendor, err := s.loadOwned(ctx, req.VendorId, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Unix(0, 0).UTC()
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	to := s.now()
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	if !from.Before(to) {
+		s.log.Warn(ctx, "Get commission summary failed: empty period", map[string]interface{}{"vendor_id": vendor.ID})
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	summary, err := s.repo.Summarize(ctx, vendor.ID, from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get commission summary")
+	}
+
+	return &pb.GetCommissionSummaryResponse{
+		Orders:     summary.Orders,
+		Gross:      summary.Gross,
+		Commission: summary.Commission,
+		Payout:     summary.Payout,
+	}, nil
+}
+
+// load returns a vendor, or the gRPC error to answer with
+func (s *Service) load(ctx context.Context, vendorID string) (*Vendor, error) {
+	if vendorID == "" {
+		s.log.Warn(ctx, "Vendor request failed: vendor ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "vendor_id is required")
+	}
+
+	vendor, err := s.repo.GetByID(ctx, vendorID)
+	if errors.Is(err, ErrVendorNotFound) {
+		s.log.Warn(ctx, "Vendor not found", map[string]interface{}{"vendor_id": vendorID})
+		return nil, status.Error(codes.NotFound, "vendor not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get vendor")
+	}
+	return vendor, nil
+}
+
+// loadOwned is load for the user who registered the vendor; another user's
+// vendor is reported as not found
+func (s *Service) loadOwned(ctx context.Context, vendorID, ownerID string) (*Vendor, error) {
+	if ownerID == "" {
+		s.log.Warn(ctx, "Vendor request failed: owner ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+	vendor, err := s.load(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+	if vendor.OwnerID != ownerID {
+		s.log.Warn(ctx, "Vendor not found", map[string]interface{}{"vendor_id": vendorID, "owner_id": ownerID})
+		return nil, status.Error(codes.NotFound, "vendor not found")
+	}
+	return vendor, nil
+}
+
+// updateError maps an error changing a vendor to a gRPC status
+func (s *Service) updateError(ctx context.Context, err error, vendorID string) error {
+	switch {
+	case errors.Is(err, ErrVendorNotFound):
+		return status.Error(codes.NotFound, "vendor not found")
+	case errors.Is(err, ErrStatusConflict):
+		s.log.Warn(ctx, "Vendor changed by another request", map[string]interface{}{"vendor_id": vendorID})
+		return status.Error(codes.Aborted, "vendor was changed by another request")
+	}
+	return status.Error(codes.Internal, "failed to update vendor")
+}
+
+// validEmail reports whether email is a bare address, without a display name
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// toProtoVendor converts a vendor to protobuf
+func toProtoVendor(v *Vendor) *pb.Vendor {
+	return &pb.Vendor{
+		Id:             v.ID,
+		OwnerId:        v.OwnerID,
+		Name:           v.Name,
+		Email:          v.Email,
+		Status:         v.Status,
+		StatusReason:   v.StatusReason,
+		CommissionRate: v.CommissionRate,
+		CreatedAt:      timestamppb.New(v.CreatedAt),
+		UpdatedAt:      timestamppb.New(v.UpdatedAt),
+	}
+}
+
+// toProtoCommissions converts commissions to protobuf
+func toProtoCommissions(commissions []*Commission) []*pb.Commission {
+	protoCommissions := make([]*pb.Commission, len(commissions))
+	for i, c := range commissions {
+		protoCommissions[i] = &pb.Commission{
+			OrderId:    c.OrderID,
+			ProductId:  c.ProductID,
+			VendorId:   c.VendorID,
+			Quantity:   c.Quantity,
+			Gross:      c.Gross,
+			Rate:       c.Rate,
+			Commission: c.Commission,
+			Payout:     c.Payout,
+			CreatedAt:  timestamppb.New(c.CreatedAt),
+		}
+	}
+	return protoCommissions
+}
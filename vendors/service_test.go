@@ -0,0 +1,481 @@
+package vendors
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/vendors/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryRepository keeps vendors and their commissions, moving a vendor only
+// from the statuses each transition allows
+type memoryRepository struct {
+	mu          sync.Mutex
+	vendors     map[string]*Vendor
+	commissions []*Commission
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{vendors: map[string]*Vendor{}}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, vendor *Vendor) (*Vendor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range m.vendors {
+		if v.OwnerID == vendor.OwnerID {
+			return nil, ErrOwnerTaken
+		}
+	}
+	vendor.ID = uuid.New().String()
+	stored := *vendor
+	m.vendors[vendor.ID] = &stored
+	return vendor, nil
+}
+
+func (m *memoryRepository) GetByID(ctx context.Context, id string) (*Vendor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.vendors[id]
+	if !ok {
+		return nil, ErrVendorNotFound
+	}
+	copied := *v
+	return &copied, nil
+}
+
+func (m *memoryRepository) List(ctx context.Context, status string, page, pageSize int32) ([]*Vendor, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var vendors []*Vendor
+	for _, v := range m.vendors {
+		if status == "" || v.Status == status {
+			copied := *v
+			vendors = append(vendors, &copied)
+		}
+	}
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i].CreatedAt.After(vendors[j].CreatedAt) })
+	return vendors, int32(len(vendors)), nil
+}
+
+// transition changes a vendor in one of the from statuses
+func (m *memoryRepository) transition(id string, from []string, change func(v *Vendor)) (*Vendor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.vendors[id]
+	if !ok {
+		return nil, ErrVendorNotFound
+	}
+	for _, s := range from {
+		if v.Status == s {
+			change(v)
+			copied := *v
+			return &copied, nil
+		}
+	}
+	return nil, ErrStatusConflict
+}
+
+func (m *memoryRepository) Approve(ctx context.Context, id string, at time.Time) (*Vendor, error) {
+	return m.transition(id, []string{StatusPending, StatusSuspended}, func(v *Vendor) {
+		v.Status, v.StatusReason, v.UpdatedAt = StatusActive, "", at
+	})
+}
+
+func (m *memoryRepository) Reject(ctx context.Context, id, reason string, at time.Time) (*Vendor, error) {
+	return m.transition(id, []string{StatusPending}, func(v *Vendor) {
+		v.Status, v.StatusReason, v.UpdatedAt = StatusRejected, reason, at
+	})
+}
+
+func (m *memoryRepository) Suspend(ctx context.Context, id, reason string, at time.Time) (*Vendor, error) {
+	return m.transition(id, []string{StatusActive}, func(v *Vendor) {
+		v.Status, v.StatusReason, v.UpdatedAt = StatusSuspended, reason, at
+	})
+}
+
+func (m *memoryRepository) SetCommissionRate(ctx context.Context, id string, rate float64, at time.Time) (*Vendor, error) {
+	return m.transition(id, []string{StatusPending, StatusActive, StatusSuspended}, func(v *Vendor) {
+		v.CommissionRate, v.UpdatedAt = rate, at
+	})
+}
+
+func (m *memoryRepository) RecordCommissions(ctx context.Context, orderID string, commissions []*Commission) ([]*Commission, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range commissions {
+		exists := false
+		for _, e := range m.commissions {
+			if e.OrderID == orderID && e.ProductID == c.ProductID {
+				exists = true
+			}
+		}
+		if !exists {
+			stored := *c
+			m.commissions = append(m.commissions, &stored)
+		}
+	}
+	var recorded []*Commission
+	for _, e := range m.commissions {
+		if e.OrderID == orderID {
+			copied := *e
+			recorded = append(recorded, &copied)
+		}
+	}
+	sort.Slice(recorded, func(i, j int) bool { return recorded[i].ProductID < recorded[j].ProductID })
+	return recorded, nil
+}
+
+func (m *memoryRepository) Commissions(ctx context.Context, vendorID string, page, pageSize int32) ([]*Commission, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var commissions []*Commission
+	for _, c := range m.commissions {
+		if c.VendorID == vendorID {
+			copied := *c
+			commissions = append(commissions, &copied)
+		}
+	}
+	return commissions, int32(len(commissions)), nil
+}
+
+func (m *memoryRepository) Summarize(ctx context.Context, vendorID string, from, to time.Time) (*Summary, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := &Summary{}
+	orders := map[string]bool{}
+	for _, c := range m.commissions {
+		if c.VendorID != vendorID || c.CreatedAt.Before(from) || !c.CreatedAt.Before(to) {
+			continue
+		}
+		orders[c.OrderID] = true
+		s.Gross += c.Gross
+		s.Commission += c.Commission
+		s.Payout += c.Payout
+	}
+	s.Orders = int32(len(orders))
+	return s, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// fakeCatalog maps products to the vendors selling them
+type fakeCatalog struct {
+	vendors map[string]string
+	err     error
+}
+
+func (c *fakeCatalog) ProductVendor(ctx context.Context, productID string) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	vendorID, ok := c.vendors[productID]
+	if !ok {
+		return "", ErrProductNotFound
+	}
+	return vendorID, nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	catalog *fakeCatalog
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo:    newMemoryRepository(),
+		catalog: &fakeCatalog{vendors: map[string]string{}},
+		now:     time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(f.repo, f.catalog, DefaultCommissionRate, logger.New("vendors-test"))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+// register registers a vendor for ownerID
+func (f *serviceFixture) register(t *testing.T, ownerID string) *pb.Vendor {
+	t.Helper()
+	resp, err := f.service.RegisterVendor(context.Background(), &pb.RegisterVendorRequest{
+		OwnerId: ownerID,
+		Name:    "Shop of " + ownerID,
+		Email:   ownerID + "@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Vendor
+}
+
+// active registers and approves a vendor for ownerID
+func (f *serviceFixture) active(t *testing.T, ownerID string) *pb.Vendor {
+	t.Helper()
+	vendor := f.register(t, ownerID)
+	resp, err := f.service.ApproveVendor(context.Background(), &pb.ApproveVendorRequest{VendorId: vendor.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Vendor
+}
+
+func TestRegisterVendor(t *testing.T) {
+	f := setupService()
+
+	vendor := f.register(t, "user-1")
+	if vendor.Id == "" || vendor.Status != StatusPending || vendor.CommissionRate != DefaultCommissionRate {
+		t.Errorf("Unexpected vendor %v", vendor)
+	}
+
+	_, err := f.service.RegisterVendor(context.Background(), &pb.RegisterVendorRequest{OwnerId: "user-1", Name: "Again", Email: "again@example.com"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a second vendor, got %v", err)
+	}
+}
+
+func TestRegisterVendor_InvalidRequest(t *testing.T) {
+	f := setupService()
+
+	tests := []struct {
+		name string
+		req  *pb.RegisterVendorRequest
+	}{
+		{"missing owner", &pb.RegisterVendorRequest{Name: "Shop", Email: "shop@example.com"}},
+		{"blank name", &pb.RegisterVendorRequest{OwnerId: "user-1", Name: "  ", Email: "shop@example.com"}},
+		{"invalid email", &pb.RegisterVendorRequest{OwnerId: "user-1", Name: "Shop", Email: "Shop <shop@example.com>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.service.RegisterVendor(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVendorLifecycle(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+
+	vendor := f.active(t, "user-1")
+	if vendor.Status != StatusActive {
+		t.Fatalf("Expected ACTIVE, got %s", vendor.Status)
+	}
+	if _, err := f.service.RejectVendor(ctx, &pb.RejectVendorRequest{VendorId: vendor.Id, Reason: "late"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition rejecting an ACTIVE vendor, got %v", err)
+	}
+
+	if _, err := f.service.SuspendVendor(ctx, &pb.SuspendVendorRequest{VendorId: vendor.Id}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a reason, got %v", err)
+	}
+	suspended, err := f.service.SuspendVendor(ctx, &pb.SuspendVendorRequest{VendorId: vendor.Id, Reason: "counterfeit goods"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if suspended.Vendor.Status != StatusSuspended || suspended.Vendor.StatusReason != "counterfeit goods" {
+		t.Errorf("Unexpected vendor %v", suspended.Vendor)
+	}
+
+	reinstated, err := f.service.ApproveVendor(ctx, &pb.ApproveVendorRequest{VendorId: vendor.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reinstated.Vendor.Status != StatusActive || reinstated.Vendor.StatusReason != "" {
+		t.Errorf("Unexpected vendor %v", reinstated.Vendor)
+	}
+
+	pending := f.register(t, "user-2")
+	rejected, err := f.service.RejectVendor(ctx, &pb.RejectVendorRequest{VendorId: pending.Id, Reason: "incomplete"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rejected.Vendor.Status != StatusRejected {
+		t.Errorf("Expected REJECTED, got %s", rejected.Vendor.Status)
+	}
+	if _, err := f.service.ApproveVendor(ctx, &pb.ApproveVendorRequest{VendorId: pending.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition approving a REJECTED vendor, got %v", err)
+	}
+	if _, err := f.service.SetCommissionRate(ctx, &pb.SetCommissionRateRequest{VendorId: pending.Id, CommissionRate: 0.1}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition changing a REJECTED vendor, got %v", err)
+	}
+
+	if _, err := f.service.ApproveVendor(ctx, &pb.ApproveVendorRequest{VendorId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListVendors(t *testing.T) {
+	f := setupService()
+	f.active(t, "user-1")
+	f.register(t, "user-2")
+
+	resp, err := f.service.ListVendors(context.Background(), &pb.ListVendorsRequest{Status: StatusPending})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 1 || resp.Vendors[0].OwnerId != "user-2" || resp.Page != 1 || resp.PageSize != 10 {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	if _, err := f.service.ListVendors(context.Background(), &pb.ListVendorsRequest{Status: "CLOSED"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown status, got %v", err)
+	}
+}
+
+func TestValidCommissionRate(t *testing.T) {
+	for rate, expected := range map[float64]bool{
+		0: true, 0.15: true, 0.1234: true, 1: true,
+		-0.01: false, 1.01: false, 0.12345: false,
+	} {
+		if got := ValidCommissionRate(rate); got != expected {
+			t.Errorf("ValidCommissionRate(%v) = %v, expected %v", rate, got, expected)
+		}
+	}
+}
+
+func TestRecordOrderCommissions(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+	vendor := f.active(t, "user-1")
+	f.catalog.vendors = map[string]string{"mug": vendor.Id, "lamp": ""}
+
+	req := &pb.RecordOrderCommissionsRequest{OrderId: "order-1", Lines: []*pb.OrderLine{
+		{ProductId: "mug", Quantity: 2, UnitPrice: 12.5},
+		{ProductId: "lamp", Quantity: 1, UnitPrice: 40},
+		{ProductId: "mug", Quantity: 1, UnitPrice: 12.5},
+		{ProductId: "deleted", Quantity: 1, UnitPrice: 5},
+	}}
+	resp, err := f.service.RecordOrderCommissions(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Commissions) != 1 {
+		t.Fatalf("Expected one commission for the vendor's lines, got %v", resp.Commissions)
+	}
+	c := resp.Commissions[0]
+	if c.ProductId != "mug" || c.VendorId != vendor.Id || c.Quantity != 3 || c.Gross != 37.5 || c.Rate != 0.15 || c.Commission != 5.63 || c.Payout != 31.87 {
+		t.Errorf("Unexpected commission %v", c)
+	}
+
+	// The rate when the order was paid stays with it, and a report sent
+	// again records nothing more
+	if _, err := f.service.SetCommissionRate(ctx, &pb.SetCommissionRateRequest{VendorId: vendor.Id, CommissionRate: 0.2}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	again, err := f.service.RecordOrderCommissions(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(again.Commissions) != 1 || again.Commissions[0].Rate != 0.15 || len(f.repo.commissions) != 1 {
+		t.Errorf("Expected the first report kept, got %v", again.Commissions)
+	}
+}
+
+func TestRecordOrderCommissions_OperatorOrder(t *testing.T) {
+	f := setupService()
+	f.catalog.vendors = map[string]string{"lamp": ""}
+
+	resp, err := f.service.RecordOrderCommissions(context.Background(), &pb.RecordOrderCommissionsRequest{
+		OrderId: "order-1",
+		Lines:   []*pb.OrderLine{{ProductId: "lamp", Quantity: 1, UnitPrice: 40}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Commissions) != 0 || len(f.repo.commissions) != 0 {
+		t.Errorf("Expected no commissions, got %v", resp.Commissions)
+	}
+}
+
+func TestRecordOrderCommissions_Rejected(t *testing.T) {
+	f := setupService()
+	line := func(productID string, quantity int32, price float64) []*pb.OrderLine {
+		return []*pb.OrderLine{{ProductId: productID, Quantity: quantity, UnitPrice: price}}
+	}
+
+	tests := []struct {
+		name     string
+		req      *pb.RecordOrderCommissionsRequest
+		expected codes.Code
+	}{
+		{"missing order", &pb.RecordOrderCommissionsRequest{Lines: line("mug", 1, 1)}, codes.InvalidArgument},
+		{"no lines", &pb.RecordOrderCommissionsRequest{OrderId: "order-1"}, codes.InvalidArgument},
+		{"zero quantity", &pb.RecordOrderCommissionsRequest{OrderId: "order-1", Lines: line("mug", 0, 1)}, codes.InvalidArgument},
+		{"negative price", &pb.RecordOrderCommissionsRequest{OrderId: "order-1", Lines: line("mug", 1, -1)}, codes.InvalidArgument},
+		{"line too large", &pb.RecordOrderCommissionsRequest{OrderId: "order-1", Lines: line("mug", 1000, maxAmount)}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.service.RecordOrderCommissions(context.Background(), tt.req); status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	f.catalog.err = errors.New("unavailable")
+	_, err := f.service.RecordOrderCommissions(context.Background(), &pb.RecordOrderCommissionsRequest{OrderId: "order-1", Lines: line("mug", 1, 1)})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable when the catalog fails, got %v", err)
+	}
+}
+
+func TestCommissions_Owner(t *testing.T) {
+	f := setupService()
+	ctx := context.Background()
+	vendor := f.active(t, "user-1")
+	f.catalog.vendors = map[string]string{"mug": vendor.Id}
+
+	for _, orderID := range []string{"order-1", "order-2"} {
+		_, err := f.service.RecordOrderCommissions(ctx, &pb.RecordOrderCommissionsRequest{
+			OrderId: orderID,
+			Lines:   []*pb.OrderLine{{ProductId: "mug", Quantity: 1, UnitPrice: 20}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	list, err := f.service.ListCommissions(ctx, &pb.ListCommissionsRequest{VendorId: vendor.Id, OwnerId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list.Total != 2 {
+		t.Errorf("Expected 2 commissions, got %d", list.Total)
+	}
+
+	summary, err := f.service.GetCommissionSummary(ctx, &pb.GetCommissionSummaryRequest{
+		VendorId: vendor.Id,
+		OwnerId:  "user-1",
+		To:       timestamppb.New(f.now.Add(time.Second)),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if summary.Orders != 2 || summary.Gross != 40 || summary.Commission != 6 || summary.Payout != 34 {
+		t.Errorf("Unexpected summary %v", summary)
+	}
+
+	if _, err := f.service.ListCommissions(ctx, &pb.ListCommissionsRequest{VendorId: vendor.Id, OwnerId: "user-2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another user's vendor, got %v", err)
+	}
+	_, err = f.service.GetCommissionSummary(ctx, &pb.GetCommissionSummaryRequest{
+		VendorId: vendor.Id,
+		OwnerId:  "user-1",
+		From:     timestamppb.New(f.now),
+		To:       timestamppb.New(f.now),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty period, got %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_wallet_entries_user;
+DROP INDEX IF EXISTS idx_wallet_entries_debited;
+DROP INDEX IF EXISTS idx_wallet_entries_credited;
+DROP TABLE IF EXISTS wallet_entries;
+DROP TABLE IF EXISTS wallets;
@@ -0,0 +1,32 @@
+-- Store credit balances. Every change goes through a conditional update of
+-- the customer's row, so concurrent debits are serialized on it and the
+-- balance can never go below zero.
+CREATE TABLE IF NOT EXISTS wallets (
+    user_id VARCHAR(255) PRIMARY KEY,
+    balance DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Store credit ledger. Every change to a balance is an entry recording the
+-- balance it left.
+CREATE TABLE IF NOT EXISTS wallet_entries (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES wallets(user_id),
+    type VARCHAR(10) NOT NULL CHECK (type IN ('CREDIT', 'REFUND', 'DEBIT', 'RELEASE')),
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount <> 0),
+    balance DECIMAL(10, 2) NOT NULL CHECK (balance >= 0),
+    reference VARCHAR(255) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    released_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((type = 'DEBIT') = (amount < 0)),
+    CHECK (type = 'DEBIT' OR released_at IS NULL)
+);
+
+-- A reference credits or refunds once, and an order has at most one
+-- unreleased debit
+CREATE UNIQUE INDEX idx_wallet_entries_credited ON wallet_entries(type, reference) WHERE type IN ('CREDIT', 'REFUND');
+CREATE UNIQUE INDEX idx_wallet_entries_debited ON wallet_entries(reference) WHERE type = 'DEBIT' AND released_at IS NULL;
+-- A customer's ledger, newest first
+CREATE INDEX idx_wallet_entries_user ON wallet_entries(user_id, created_at DESC);
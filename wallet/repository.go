@@ -0,0 +1,375 @@
+package wallet
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Entry types
+const (
+	TypeCredit  = "CREDIT"
+	TypeRefund  = "REFUND"
+	TypeDebit   = "DEBIT"
+	TypeRelease = "RELEASE"
+)
+
+var (
+	// ErrDebitNotFound is returned when a debit does not exist
+	ErrDebitNotFound = errors.New("debit not found")
+	// ErrInsufficientFunds is returned when a customer's balance is less than
+	// a debit asks for
+	ErrInsufficientFunds = errors.New("insufficient store credit")
+	// ErrBalanceLimit is returned when a credit would take a balance past
+	// what the balance column holds
+	ErrBalanceLimit = errors.New("balance would exceed 99999999.99")
+	// ErrReferenceInUse is returned when a reference has already been used by
+	// another customer
+	ErrReferenceInUse = errors.New("reference already used for another customer")
+)
+
+// Unique indexes on credited references and an order's unreleased debit
+const (
+	creditedConstraint = "idx_wallet_entries_credited"
+	debitedConstraint  = "idx_wallet_entries_debited"
+)
+
+// Entry is a change to a customer's store credit
+type Entry struct {
+	ID     string
+	UserID string
+	Type   string
+	// Amount is positive for CREDIT, REFUND and RELEASE entries and negative
+	// for DEBIT entries
+	Amount float64
+	// Balance is the customer's balance after the entry
+	Balance float64
+	// Reference is the idempotency key of a CREDIT or REFUND entry, the order
+	// of a DEBIT entry and the debit of a RELEASE entry
+	Reference  string
+	Note       string
+	ReleasedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// Wallet is a customer's store credit balance
+type Wallet struct {
+	UserID  string
+	Balance float64
+	// UpdatedAt is nil for a customer who was never credited
+	UpdatedAt *time.Time
+}
+
+// Repository handles wallet data persistence
+type Repository interface {
+	// Credit adds a CREDIT or REFUND entry to the customer's balance. When
+	// the reference was credited already, that entry is returned with false.
+	Credit(ctx context.Context, entry *Entry) (*Entry, bool, error)
+	// Debit takes a DEBIT entry from the customer's balance, returning
+	// ErrInsufficientFunds when the balance falls short. When the order has
+	// an unreleased debit already, that is returned instead.
+	Debit(ctx context.Context, debit *Entry) (*Entry, error)
+	// Release gives a debit back to the customer's balance; a released debit
+	// is returned unchanged
+	Release(ctx context.Context, id string, at time.Time) (*Entry, error)
+	// GetWallet returns the customer's balance, which is 0 for a customer who
+	// was never credited
+	GetWallet(ctx context.Context, userID string) (*Wallet, error)
+	// ListEntries lists the customer's entries, newest first
+	ListEntries(ctx context.Context, userID string, page, pageSize int32) ([]*Entry, int32, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL wallet repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const entryColumns = `id, user_id, type, amount, balance, reference, note, released_at, created_at`
+
+// insertEntry is the statement recording an entry
+const insertEntry = `
+	INSERT INTO wallet_entries (id, user_id, type, amount, balance, reference, note, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+// Credit adds to the customer's balance, creating their wallet on the first
+// credit, and records the entry in one transaction
+func (r *postgresRepository) Credit(ctx context.Context, entry *Entry) (*Entry, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO wallets (user_id, balance, created_at, updated_at) VALUES ($1, $2, $3, $3)
+		ON CONFLICT (user_id) DO UPDATE SET balance = wallets.balance + EXCLUDED.balance, updated_at = EXCLUDED.updated_at
+		RETURNING balance
+	`, entry.UserID, entry.Amount, entry.CreatedAt).Scan(&entry.Balance)
+	if isNumericOverflow(err) {
+		return nil, false, ErrBalanceLimit
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to credit wallet", map[string]interface{}{"error": err.Error(), "user_id": entry.UserID})
+		return nil, false, fmt.Errorf("failed to credit wallet: %w", err)
+	}
+
+	entry.ID = uuid.New().String()
+	_, err = tx.ExecContext(ctx, insertEntry, entry.ID, entry.UserID, entry.Type, entry.Amount, entry.Balance,
+		entry.Reference, entry.Note, entry.CreatedAt)
+	if pgerr.IsUniqueViolation(err, creditedConstraint) {
+		// The rollback undoes the balance change of this attempt
+		tx.Rollback()
+		existing, err := r.credited(ctx, entry)
+		return existing, false, err
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to record credit", map[string]interface{}{"error": err.Error(), "reference": entry.Reference})
+		return nil, false, fmt.Errorf("failed to record credit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Wallet credited", map[string]interface{}{"entry_id": entry.ID, "user_id": entry.UserID, "type": entry.Type, "amount": entry.Amount})
+	return entry, true, nil
+}
+
+// credited returns the entry that credited the reference first
+func (r *postgresRepository) credited(ctx context.Context, entry *Entry) (*Entry, error) {
+	query := "SELECT " + entryColumns + " FROM wallet_entries WHERE type = $1 AND reference = $2"
+	existing, err := scanEntry(r.db.QueryRowContext(ctx, query, entry.Type, entry.Reference))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit: %w", err)
+	}
+	if existing.UserID != entry.UserID {
+		return nil, ErrReferenceInUse
+	}
+	return existing, nil
+}
+
+// Debit takes from the customer's balance and records the entry in one
+// transaction. The balance is only lowered while it covers the debit, and the
+// update locks the customer's row, so concurrent debits cannot overdraw it.
+func (r *postgresRepository) Debit(ctx context.Context, debit *Entry) (*Entry, error) {
+	// A retried checkout gets the debit it already made
+	existing, err := r.unreleasedDebit(ctx, debit)
+	if err != sql.ErrNoRows {
+		return existing, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE wallets SET balance = balance - $2, updated_at = $3
+		WHERE user_id = $1 AND balance >= $2
+		RETURNING balance
+	`, debit.UserID, -debit.Amount, debit.CreatedAt).Scan(&debit.Balance)
+	if err == sql.ErrNoRows {
+		return nil, ErrInsufficientFunds
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to debit wallet", map[string]interface{}{"error": err.Error(), "user_id": debit.UserID})
+		return nil, fmt.Errorf("failed to debit wallet: %w", err)
+	}
+
+	debit.ID = uuid.New().String()
+	debit.Type = TypeDebit
+	_, err = tx.ExecContext(ctx, insertEntry, debit.ID, debit.UserID, debit.Type, debit.Amount, debit.Balance,
+		debit.Reference, debit.Note, debit.CreatedAt)
+	if pgerr.IsUniqueViolation(err, debitedConstraint) {
+		tx.Rollback()
+		return r.unreleasedDebit(ctx, debit)
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to record debit", map[string]interface{}{"error": err.Error(), "order_id": debit.Reference})
+		return nil, fmt.Errorf("failed to record debit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Wallet debited", map[string]interface{}{"debit_id": debit.ID, "user_id": debit.UserID, "order_id": debit.Reference, "amount": -debit.Amount})
+	return debit, nil
+}
+
+// unreleasedDebit returns the order's unreleased debit, or sql.ErrNoRows
+func (r *postgresRepository) unreleasedDebit(ctx context.Context, debit *Entry) (*Entry, error) {
+	query := "SELECT " + entryColumns + " FROM wallet_entries WHERE reference = $1 AND type = 'DEBIT' AND released_at IS NULL"
+	existing, err := scanEntry(r.db.QueryRowContext(ctx, query, debit.Reference))
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debit: %w", err)
+	}
+	if existing.UserID != debit.UserID {
+		return nil, ErrReferenceInUse
+	}
+	return existing, nil
+}
+
+// Release gives back a debit and records a RELEASE entry in one transaction
+func (r *postgresRepository) Release(ctx context.Context, id string, at time.Time) (*Entry, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT " + entryColumns + " FROM wallet_entries WHERE id = $1 AND type = 'DEBIT' FOR UPDATE"
+	debit, err := scanEntry(tx.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrDebitNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock debit: %w", err)
+	}
+	if debit.ReleasedAt != nil {
+		return debit, nil
+	}
+
+	var balance float64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE wallets SET balance = balance + $2, updated_at = $3 WHERE user_id = $1 RETURNING balance
+	`, debit.UserID, -debit.Amount, at).Scan(&balance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to give back debit: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE wallet_entries SET released_at = $2 WHERE id = $1", id, at); err != nil {
+		r.log.Error(ctx, "Failed to release debit", map[string]interface{}{"error": err.Error(), "debit_id": id})
+		return nil, fmt.Errorf("failed to release debit: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, insertEntry, uuid.New().String(), debit.UserID, TypeRelease, -debit.Amount, balance,
+		debit.ID, "", at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record release: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	debit.ReleasedAt = &at
+	r.log.Info(ctx, "Debit released", map[string]interface{}{"debit_id": id, "user_id": debit.UserID})
+	return debit, nil
+}
+
+// GetWallet retrieves a customer's balance
+func (r *postgresRepository) GetWallet(ctx context.Context, userID string) (*Wallet, error) {
+	w := &Wallet{UserID: userID}
+	var updatedAt time.Time
+	err := r.db.QueryRowContext(ctx, "SELECT balance, updated_at FROM wallets WHERE user_id = $1", userID).
+		Scan(&w.Balance, &updatedAt)
+	if err == sql.ErrNoRows {
+		return w, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get wallet", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	w.UpdatedAt = &updatedAt
+	return w, nil
+}
+
+// ListEntries retrieves a customer's entries with pagination
+func (r *postgresRepository) ListEntries(ctx context.Context, userID string, page, pageSize int32) ([]*Entry, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	var total int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM wallet_entries WHERE user_id = $1", userID).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count wallet entries", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to count wallet entries: %w", err)
+	}
+
+	query := "SELECT " + entryColumns + ` FROM wallet_entries
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list wallet entries", map[string]interface{}{"error": err.Error(), "user_id": userID})
+		return nil, 0, fmt.Errorf("failed to list wallet entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*Entry, 0, pageSize)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			r.log.Error(ctx, "Failed to scan wallet entry", map[string]interface{}{"error": err.Error()})
+			return nil, 0, fmt.Errorf("failed to scan wallet entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		r.log.Error(ctx, "Error iterating wallet entries", map[string]interface{}{"error": err.Error()})
+		return nil, 0, fmt.Errorf("error iterating wallet entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// isNumericOverflow reports whether err is Postgres rejecting a value too
+// large for its DECIMAL column
+func isNumericOverflow(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "22003"
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry scans an entry selected with entryColumns
+func scanEntry(row rowScanner) (*Entry, error) {
+	e := &Entry{}
+	var releasedAt sql.NullTime
+
+	err := row.Scan(&e.ID, &e.UserID, &e.Type, &e.Amount, &e.Balance, &e.Reference, &e.Note,
+		&releasedAt, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if releasedAt.Valid {
+		e.ReleasedAt = &releasedAt.Time
+	}
+	return e, nil
+}
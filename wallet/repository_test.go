@@ -0,0 +1,240 @@
+package wallet
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("wallet-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var entryColumnNames = []string{"id", "user_id", "type", "amount", "balance", "reference", "note", "released_at", "created_at"}
+
+func TestCredit(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO wallets (.+) ON CONFLICT \(user_id\) DO UPDATE SET balance = wallets.balance \+ EXCLUDED.balance`).
+		WithArgs("user-1", 10.0, now).
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(15.0))
+	mock.ExpectExec(`INSERT INTO wallet_entries`).
+		WithArgs(sqlmock.AnyArg(), "user-1", TypeRefund, 10.0, 15.0, "return-1", "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	entry, credited, err := repo.Credit(context.Background(), &Entry{UserID: "user-1", Type: TypeRefund, Amount: 10, Reference: "return-1", CreatedAt: now})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !credited || entry.ID == "" || entry.Balance != 15 {
+		t.Errorf("Unexpected credit %+v", entry)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCredit_AlreadyCredited(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO wallets`).
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(20.0))
+	mock.ExpectExec(`INSERT INTO wallet_entries`).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: creditedConstraint})
+	mock.ExpectRollback()
+	mock.ExpectQuery(`SELECT (.+) FROM wallet_entries WHERE type = \$1 AND reference = \$2`).
+		WithArgs(TypeCredit, "goodwill-1").
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).AddRow("entry-1", "user-1", TypeCredit, 10.0, 10.0, "goodwill-1", "", nil, now))
+
+	entry, credited, err := repo.Credit(context.Background(), &Entry{UserID: "user-1", Type: TypeCredit, Amount: 10, Reference: "goodwill-1", CreatedAt: now})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if credited || entry.ID != "entry-1" {
+		t.Errorf("Expected the existing credit, got %+v", entry)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDebit(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM wallet_entries WHERE reference = \$1 AND type = 'DEBIT' AND released_at IS NULL`).
+		WithArgs("order-1").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE wallets SET balance = balance - \$2, updated_at = \$3\s+WHERE user_id = \$1 AND balance >= \$2\s+RETURNING balance`).
+		WithArgs("user-1", 12.5, now).
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(7.5))
+	mock.ExpectExec(`INSERT INTO wallet_entries`).
+		WithArgs(sqlmock.AnyArg(), "user-1", TypeDebit, -12.5, 7.5, "order-1", "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	debit, err := repo.Debit(context.Background(), &Entry{UserID: "user-1", Amount: -12.5, Reference: "order-1", CreatedAt: now})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if debit.ID == "" || debit.Type != TypeDebit || debit.Balance != 7.5 {
+		t.Errorf("Unexpected debit %+v", debit)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDebit_InsufficientFunds(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM wallet_entries`).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE wallets SET balance = balance - \$2`).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := repo.Debit(context.Background(), &Entry{UserID: "user-1", Amount: -50, Reference: "order-1", CreatedAt: time.Now()})
+	if err != ErrInsufficientFunds {
+		t.Errorf("Expected ErrInsufficientFunds, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDebit_ExistingDebit(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM wallet_entries WHERE reference = \$1 AND type = 'DEBIT'`).
+		WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).AddRow("debit-1", "user-2", TypeDebit, -5.0, 0.0, "order-1", "", nil, now))
+
+	_, err := repo.Debit(context.Background(), &Entry{UserID: "user-1", Amount: -5, Reference: "order-1", CreatedAt: now})
+	if err != ErrReferenceInUse {
+		t.Errorf("Expected ErrReferenceInUse, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRelease(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM wallet_entries WHERE id = \$1 AND type = 'DEBIT' FOR UPDATE`).
+		WithArgs("debit-1").
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).AddRow("debit-1", "user-1", TypeDebit, -12.5, 7.5, "order-1", "", nil, now))
+	mock.ExpectQuery(`UPDATE wallets SET balance = balance \+ \$2`).
+		WithArgs("user-1", 12.5, now).
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(20.0))
+	mock.ExpectExec(`UPDATE wallet_entries SET released_at = \$2 WHERE id = \$1`).
+		WithArgs("debit-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO wallet_entries`).
+		WithArgs(sqlmock.AnyArg(), "user-1", TypeRelease, 12.5, 20.0, "debit-1", "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	debit, err := repo.Release(context.Background(), "debit-1", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if debit.ReleasedAt == nil {
+		t.Errorf("Expected the debit released, got %+v", debit)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRelease_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM wallet_entries WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+	mock.ExpectRollback()
+
+	if _, err := repo.Release(context.Background(), "not-a-uuid", time.Now()); err != ErrDebitNotFound {
+		t.Errorf("Expected ErrDebitNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetWallet_NeverCredited(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT balance, updated_at FROM wallets WHERE user_id = \$1`).
+		WithArgs("user-1").
+		WillReturnError(sql.ErrNoRows)
+
+	w, err := repo.GetWallet(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if w.Balance != 0 || w.UpdatedAt != nil {
+		t.Errorf("Expected an empty wallet, got %+v", w)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListEntries(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM wallet_entries WHERE user_id = \$1`).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT (.+) FROM wallet_entries\s+WHERE user_id = \$1\s+ORDER BY created_at DESC, id\s+LIMIT \$2 OFFSET \$3`).
+		WithArgs("user-1", int32(10), int32(0)).
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).AddRow("entry-1", "user-1", TypeCredit, 10.0, 10.0, "goodwill-1", "", nil, now))
+
+	entries, total, err := repo.ListEntries(context.Background(), "user-1", 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 1 || len(entries) != 1 || entries[0].Reference != "goodwill-1" {
+		t.Errorf("Unexpected entries %v", entries)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,303 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/wallet/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the user_id and reference columns
+	maxIDLength = 255
+	// maxAmount fits the DECIMAL(10, 2) amount and balance columns
+	maxAmount = 99999999.99
+	// maxNoteLength bounds the note kept on a credit
+	maxNoteLength = 1000
+)
+
+// AdminMethods are the admin-scoped RPCs of the wallet service; they are
+// restricted to the admin network allowlist and to the roles of MethodRoles.
+// Support and the returns flow credit wallets, and checkout debits them, from
+// inside it.
+var AdminMethods = []string{
+	pb.WalletService_CreditWallet_FullMethodName,
+	pb.WalletService_RefundToWallet_FullMethodName,
+	pb.WalletService_DebitWallet_FullMethodName,
+	pb.WalletService_ReleaseDebit_FullMethodName,
+}
+
+// MethodRoles maps each of AdminMethods to the roles allowed to call it:
+// ADMIN tokens for support, and SERVICE tokens for checkout and returns
+func MethodRoles() map[string][]string {
+	roles := make(map[string][]string, len(AdminMethods))
+	for _, m := range AdminMethods {
+		roles[m] = []string{auth.RoleAdmin, auth.RoleService}
+	}
+	return roles
+}
+
+// Service implements the WalletService gRPC interface
+type Service struct {
+	pb.UnimplementedWalletServiceServer
+	repo Repository
+	log  *logger.Logger
+	now  func() time.Time
+}
+
+// NewService creates a new wallet service
+func NewService(repo Repository, log *logger.Logger) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+// CreditWallet adds store credit to a customer's wallet
+func (s *Service) CreditWallet(ctx context.Context, req *pb.CreditWalletRequest) (*pb.CreditWalletResponse, error) {
+	entry, credited, err := s.credit(ctx, "Credit wallet", TypeCredit, req.UserId, req.Amount, req.Reference, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreditWalletResponse{
+		Entry:    toProtoEntry(entry),
+		Credited: credited,
+	}, nil
+}
+
+// RefundToWallet refunds money to a customer's wallet
+func (s *Service) RefundToWallet(ctx context.Context, req *pb.RefundToWalletRequest) (*pb.RefundToWalletResponse, error) {
+	entry, refunded, err := s.credit(ctx, "Refund to wallet", TypeRefund, req.UserId, req.Amount, req.Reference, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RefundToWalletResponse{
+		Entry:    toProtoEntry(entry),
+		Refunded: refunded,
+	}, nil
+}
+
+// credit validates and records a CREDIT or REFUND entry; op names the RPC in
+// log messages
+func (s *Service) credit(ctx context.Context, op, entryType, userID string, amount float64, reference, note string) (*Entry, bool, error) {
+	amount = roundCents(amount)
+	if msg := creditProblem(userID, amount, reference, note); msg != "" {
+		s.log.Warn(ctx, op+" failed: "+msg, map[string]interface{}{"reference": reference})
+		return nil, false, status.Error(codes.InvalidArgument, msg)
+	}
+
+	entry, credited, err := s.repo.Credit(ctx, &Entry{
+		UserID:    userID,
+		Type:      entryType,
+		Amount:    amount,
+		Reference: reference,
+		Note:      note,
+		CreatedAt: s.now(),
+	})
+	switch {
+	case errors.Is(err, ErrBalanceLimit):
+		return nil, false, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrReferenceInUse):
+		return nil, false, status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		s.log.Error(ctx, "Failed to credit wallet", map[string]interface{}{"error": err.Error(), "reference": reference})
+		return nil, false, status.Error(codes.Internal, "failed to credit wallet")
+	}
+	return entry, credited, nil
+}
+
+// creditProblem validates a credit and returns a message describing the first
+// problem, or ""
+func creditProblem(userID string, amount float64, reference, note string) string {
+	if msg := userProblem(userID); msg != "" {
+		return msg
+	}
+	switch {
+	case !isAmount(amount):
+		return "amount must be an amount above 0 and at most 99999999.99"
+	case reference == "":
+		return "reference is required"
+	case len(reference) > maxIDLength:
+		return "reference is too long"
+	case len(note) > maxNoteLength:
+		return "note is too long"
+	}
+	return ""
+}
+
+// DebitWallet spends store credit on an order
+func (s *Service) DebitWallet(ctx context.Context, req *pb.DebitWalletRequest) (*pb.DebitWalletResponse, error) {
+	amount := roundCents(req.Amount)
+	if msg := debitProblem(req, amount); msg != "" {
+		s.log.Warn(ctx, "Debit wallet failed: "+msg, map[string]interface{}{"order_id": req.OrderId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	debit, err := s.repo.Debit(ctx, &Entry{
+		UserID:    req.UserId,
+		Amount:    -amount,
+		Reference: req.OrderId,
+		CreatedAt: s.now(),
+	})
+	switch {
+	case errors.Is(err, ErrInsufficientFunds):
+		s.log.Info(ctx, "Wallet not debited: insufficient store credit", map[string]interface{}{"user_id": req.UserId, "amount": amount})
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrReferenceInUse):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		s.log.Error(ctx, "Failed to debit wallet", map[string]interface{}{"error": err.Error(), "order_id": req.OrderId})
+		return nil, status.Error(codes.Internal, "failed to debit wallet")
+	}
+
+	return &pb.DebitWalletResponse{
+		Debit: toProtoEntry(debit),
+	}, nil
+}
+
+// debitProblem validates a debit and returns a message describing the first
+// problem, or ""
+func debitProblem(req *pb.DebitWalletRequest, amount float64) string {
+	if msg := userProblem(req.UserId); msg != "" {
+		return msg
+	}
+	switch {
+	case req.OrderId == "":
+		return "order_id is required"
+	case len(req.OrderId) > maxIDLength:
+		return "order_id is too long"
+	case !isAmount(amount):
+		return "amount must be an amount above 0 and at most 99999999.99"
+	}
+	return ""
+}
+
+// ReleaseDebit gives back the store credit of a debit whose checkout was
+// undone
+func (s *Service) ReleaseDebit(ctx context.Context, req *pb.ReleaseDebitRequest) (*pb.ReleaseDebitResponse, error) {
+	if req.DebitId == "" {
+		s.log.Warn(ctx, "Release debit failed: debit ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "debit_id is required")
+	}
+
+	debit, err := s.repo.Release(ctx, req.DebitId, s.now())
+	if errors.Is(err, ErrDebitNotFound) {
+		return nil, status.Error(codes.NotFound, "debit not found")
+	}
+	if err != nil {
+		s.log.Error(ctx, "Failed to release debit", map[string]interface{}{"error": err.Error(), "debit_id": req.DebitId})
+		return nil, status.Error(codes.Internal, "failed to release debit")
+	}
+
+	return &pb.ReleaseDebitResponse{
+		Debit: toProtoEntry(debit),
+	}, nil
+}
+
+// GetWallet returns a customer's store credit balance
+func (s *Service) GetWallet(ctx context.Context, req *pb.GetWalletRequest) (*pb.GetWalletResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "Get wallet failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	w, err := s.repo.GetWallet(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get wallet")
+	}
+
+	resp := &pb.GetWalletResponse{
+		UserId:  w.UserID,
+		Balance: w.Balance,
+	}
+	if w.UpdatedAt != nil {
+		resp.UpdatedAt = timestamppb.New(*w.UpdatedAt)
+	}
+	return resp, nil
+}
+
+// ListEntries lists a customer's ledger, newest first
+func (s *Service) ListEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	if msg := userProblem(req.UserId); msg != "" {
+		s.log.Warn(ctx, "List entries failed: "+msg, nil)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	entries, total, err := s.repo.ListEntries(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list entries")
+	}
+
+	protoEntries := make([]*pb.Entry, len(entries))
+	for i, e := range entries {
+		protoEntries[i] = toProtoEntry(e)
+	}
+
+	return &pb.ListEntriesResponse{
+		Entries:  protoEntries,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// userProblem validates a customer ID and returns a message describing the
+// problem, or ""
+func userProblem(userID string) string {
+	switch {
+	case userID == "":
+		return "user_id is required"
+	case len(userID) > maxIDLength:
+		return "user_id is too long"
+	}
+	return ""
+}
+
+// isAmount reports whether v is a positive amount that fits the amount
+// columns
+func isAmount(v float64) bool {
+	return v > 0 && v <= maxAmount
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// toProtoEntry converts an entry to protobuf
+func toProtoEntry(e *Entry) *pb.Entry {
+	entry := &pb.Entry{
+		Id:        e.ID,
+		UserId:    e.UserID,
+		Type:      e.Type,
+		Amount:    e.Amount,
+		Balance:   e.Balance,
+		Reference: e.Reference,
+		Note:      e.Note,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.ReleasedAt != nil {
+		entry.ReleasedAt = timestamppb.New(*e.ReleasedAt)
+	}
+	return entry
+}
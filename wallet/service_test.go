@@ -0,0 +1,457 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/auth"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/wallet/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps wallet balances and entries, refusing debits over the
+// balance and a second debit of one reference
+type memoryRepository struct {
+	mu       sync.Mutex
+	balances map[string]float64
+	entries  []*Entry
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{balances: map[string]float64{}}
+}
+
+func (m *memoryRepository) find(match func(e *Entry) bool) *Entry {
+	for _, e := range m.entries {
+		if match(e) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) Credit(ctx context.Context, entry *Entry) (*Entry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e := m.find(func(e *Entry) bool { return e.Type == entry.Type && e.Reference == entry.Reference }); e != nil {
+		if e.UserID != entry.UserID {
+			return nil, false, ErrReferenceInUse
+		}
+		existing := *e
+		return &existing, false, nil
+	}
+	balance := roundCents(m.balances[entry.UserID] + entry.Amount)
+	if balance > maxAmount {
+		return nil, false, ErrBalanceLimit
+	}
+	m.balances[entry.UserID] = balance
+	entry.ID = uuid.New().String()
+	entry.Balance = balance
+	stored := *entry
+	m.entries = append(m.entries, &stored)
+	return entry, true, nil
+}
+
+func (m *memoryRepository) Debit(ctx context.Context, debit *Entry) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e := m.find(func(e *Entry) bool {
+		return e.Type == TypeDebit && e.Reference == debit.Reference && e.ReleasedAt == nil
+	}); e != nil {
+		if e.UserID != debit.UserID {
+			return nil, ErrReferenceInUse
+		}
+		existing := *e
+		return &existing, nil
+	}
+	if m.balances[debit.UserID] < -debit.Amount {
+		return nil, ErrInsufficientFunds
+	}
+	m.balances[debit.UserID] = roundCents(m.balances[debit.UserID] + debit.Amount)
+	debit.ID = uuid.New().String()
+	debit.Type = TypeDebit
+	debit.Balance = m.balances[debit.UserID]
+	stored := *debit
+	m.entries = append(m.entries, &stored)
+	return debit, nil
+}
+
+func (m *memoryRepository) Release(ctx context.Context, id string, at time.Time) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	debit := m.find(func(e *Entry) bool { return e.ID == id && e.Type == TypeDebit })
+	if debit == nil {
+		return nil, ErrDebitNotFound
+	}
+	if debit.ReleasedAt == nil {
+		m.balances[debit.UserID] = roundCents(m.balances[debit.UserID] - debit.Amount)
+		debit.ReleasedAt = &at
+		m.entries = append(m.entries, &Entry{
+			ID:        uuid.New().String(),
+			UserID:    debit.UserID,
+			Type:      TypeRelease,
+			Amount:    -debit.Amount,
+			Balance:   m.balances[debit.UserID],
+			Reference: id,
+			CreatedAt: at,
+		})
+	}
+	released := *debit
+	return &released, nil
+}
+
+func (m *memoryRepository) GetWallet(ctx context.Context, userID string) (*Wallet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := &Wallet{UserID: userID, Balance: m.balances[userID]}
+	for _, e := range m.entries {
+		if e.UserID == userID {
+			w.UpdatedAt = &e.CreatedAt
+		}
+	}
+	return w, nil
+}
+
+func (m *memoryRepository) ListEntries(ctx context.Context, userID string, page, pageSize int32) ([]*Entry, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []*Entry
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		if e := *m.entries[i]; e.UserID == userID {
+			entries = append(entries, &e)
+		}
+	}
+
+	total := int32(len(entries))
+	start := int((page - 1) * pageSize)
+	if start > len(entries) {
+		start = len(entries)
+	}
+	end := start + int(pageSize)
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[start:end], total, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+type serviceFixture struct {
+	repo    *memoryRepository
+	service *Service
+	now     time.Time
+}
+
+func setupService() *serviceFixture {
+	f := &serviceFixture{
+		repo: newMemoryRepository(),
+		now:  time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(f.repo, logger.New("wallet-test"))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *serviceFixture) credit(t *testing.T, reference string, amount float64) *pb.CreditWalletResponse {
+	t.Helper()
+	resp, err := f.service.CreditWallet(context.Background(), &pb.CreditWalletRequest{
+		UserId:    "user-1",
+		Amount:    amount,
+		Reference: reference,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp
+}
+
+func (f *serviceFixture) balance(t *testing.T) float64 {
+	t.Helper()
+	resp, err := f.service.GetWallet(context.Background(), &pb.GetWalletRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp.Balance
+}
+
+func TestCreditWallet(t *testing.T) {
+	f := setupService()
+
+	resp := f.credit(t, "goodwill-1", 10.005)
+	if !resp.Credited || resp.Entry.Type != TypeCredit || resp.Entry.Amount != 10.01 || resp.Entry.Balance != 10.01 {
+		t.Errorf("Unexpected credit %v", resp)
+	}
+
+	// Crediting the reference again returns the first credit
+	again := f.credit(t, "goodwill-1", 10.005)
+	if again.Credited || again.Entry.Id != resp.Entry.Id {
+		t.Errorf("Expected the existing credit, got %v", again)
+	}
+	if balance := f.balance(t); balance != 10.01 {
+		t.Errorf("Expected a balance of 10.01, got %v", balance)
+	}
+}
+
+func TestCreditWallet_InvalidInput(t *testing.T) {
+	f := setupService()
+
+	tests := []struct {
+		name string
+		req  *pb.CreditWalletRequest
+	}{
+		{"missing user", &pb.CreditWalletRequest{Amount: 5, Reference: "r"}},
+		{"zero amount", &pb.CreditWalletRequest{UserId: "user-1", Amount: 0.004, Reference: "r"}},
+		{"negative amount", &pb.CreditWalletRequest{UserId: "user-1", Amount: -5, Reference: "r"}},
+		{"amount too large", &pb.CreditWalletRequest{UserId: "user-1", Amount: 100000000, Reference: "r"}},
+		{"missing reference", &pb.CreditWalletRequest{UserId: "user-1", Amount: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreditWallet(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreditWallet_ReferenceOfAnotherCustomer(t *testing.T) {
+	f := setupService()
+	f.credit(t, "goodwill-1", 5)
+
+	_, err := f.service.CreditWallet(context.Background(), &pb.CreditWalletRequest{UserId: "user-2", Amount: 5, Reference: "goodwill-1"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestCreditWallet_BalanceLimit(t *testing.T) {
+	f := setupService()
+	f.credit(t, "goodwill-1", maxAmount)
+
+	_, err := f.service.CreditWallet(context.Background(), &pb.CreditWalletRequest{UserId: "user-1", Amount: 1, Reference: "goodwill-2"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestRefundToWallet(t *testing.T) {
+	f := setupService()
+
+	resp, err := f.service.RefundToWallet(context.Background(), &pb.RefundToWalletRequest{
+		UserId:    "user-1",
+		Amount:    24.5,
+		Reference: "return-1",
+		Note:      "Returned order-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Refunded || resp.Entry.Type != TypeRefund || resp.Entry.Balance != 24.5 || resp.Entry.Note != "Returned order-1" {
+		t.Errorf("Unexpected refund %v", resp)
+	}
+
+	// A credit may use the same reference as a refund
+	if credit := f.credit(t, "return-1", 5); !credit.Credited {
+		t.Errorf("Expected a credit with a refund's reference, got %v", credit)
+	}
+	if balance := f.balance(t); balance != 29.5 {
+		t.Errorf("Expected a balance of 29.50, got %v", balance)
+	}
+}
+
+func TestDebitWallet(t *testing.T) {
+	f := setupService()
+	f.credit(t, "goodwill-1", 20)
+
+	resp, err := f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-1", OrderId: "order-1", Amount: 12.5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Debit.Type != TypeDebit || resp.Debit.Amount != -12.5 || resp.Debit.Balance != 7.5 || resp.Debit.Reference != "order-1" {
+		t.Errorf("Unexpected debit %v", resp.Debit)
+	}
+
+	// A retried checkout gets the first debit
+	again, err := f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-1", OrderId: "order-1", Amount: 12.5})
+	if err != nil || again.Debit.Id != resp.Debit.Id {
+		t.Errorf("Expected the existing debit, got %v (%v)", again, err)
+	}
+	if balance := f.balance(t); balance != 7.5 {
+		t.Errorf("Expected a balance of 7.50, got %v", balance)
+	}
+}
+
+func TestDebitWallet_InsufficientFunds(t *testing.T) {
+	f := setupService()
+	f.credit(t, "goodwill-1", 10)
+
+	_, err := f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-1", OrderId: "order-1", Amount: 10.01})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+
+	// A customer who was never credited has nothing to spend
+	_, err = f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-2", OrderId: "order-2", Amount: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+	if balance := f.balance(t); balance != 10 {
+		t.Errorf("Expected the balance untouched, got %v", balance)
+	}
+}
+
+func TestDebitWallet_Concurrent(t *testing.T) {
+	f := setupService()
+	f.credit(t, "goodwill-1", 50)
+
+	// Twenty checkouts of 5 race for a balance covering ten of them
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	debited, declined := 0, 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-1", OrderId: fmt.Sprintf("order-%d", i), Amount: 5})
+			mu.Lock()
+			defer mu.Unlock()
+			switch status.Code(err) {
+			case codes.OK:
+				debited++
+			case codes.FailedPrecondition:
+				declined++
+			default:
+				t.Errorf("Unexpected error %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if debited != 10 || declined != 10 {
+		t.Errorf("Expected 10 debits and 10 declines, got %d and %d", debited, declined)
+	}
+	if balance := f.balance(t); balance != 0 {
+		t.Errorf("Expected the balance spent exactly, got %v", balance)
+	}
+}
+
+func TestReleaseDebit(t *testing.T) {
+	f := setupService()
+	f.credit(t, "goodwill-1", 20)
+	debit, err := f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-1", OrderId: "order-1", Amount: 15})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := f.service.ReleaseDebit(context.Background(), &pb.ReleaseDebitRequest{DebitId: debit.Debit.Id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Debit.ReleasedAt == nil {
+		t.Errorf("Expected the debit released, got %v", resp.Debit)
+	}
+	if balance := f.balance(t); balance != 20 {
+		t.Errorf("Expected the credit given back, got %v", balance)
+	}
+
+	// Releasing again gives nothing back twice
+	if _, err := f.service.ReleaseDebit(context.Background(), &pb.ReleaseDebitRequest{DebitId: debit.Debit.Id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if balance := f.balance(t); balance != 20 {
+		t.Errorf("Expected a balance of 20, got %v", balance)
+	}
+
+	// The order can spend store credit again once its debit was released
+	again, err := f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-1", OrderId: "order-1", Amount: 15})
+	if err != nil || again.Debit.Id == debit.Debit.Id {
+		t.Errorf("Expected a new debit, got %v (%v)", again, err)
+	}
+}
+
+func TestReleaseDebit_NotFound(t *testing.T) {
+	f := setupService()
+
+	_, err := f.service.ReleaseDebit(context.Background(), &pb.ReleaseDebitRequest{DebitId: "debit-404"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	_, err = f.service.ReleaseDebit(context.Background(), &pb.ReleaseDebitRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetWalletRPC_NeverCredited(t *testing.T) {
+	f := setupService()
+
+	resp, err := f.service.GetWallet(context.Background(), &pb.GetWalletRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Balance != 0 || resp.UpdatedAt != nil {
+		t.Errorf("Expected an empty wallet, got %v", resp)
+	}
+}
+
+func TestListEntriesRPC(t *testing.T) {
+	f := setupService()
+	f.credit(t, "goodwill-1", 20)
+	debit, err := f.service.DebitWallet(context.Background(), &pb.DebitWalletRequest{UserId: "user-1", OrderId: "order-1", Amount: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := f.service.ReleaseDebit(context.Background(), &pb.ReleaseDebitRequest{DebitId: debit.Debit.Id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := f.service.ListEntries(context.Background(), &pb.ListEntriesRequest{UserId: "user-1", PageSize: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Total != 3 || len(resp.Entries) != 2 || resp.PageSize != 2 {
+		t.Fatalf("Unexpected page %v", resp)
+	}
+	if release := resp.Entries[0]; release.Type != TypeRelease || release.Amount != 5 || release.Balance != 20 || release.Reference != debit.Debit.Id {
+		t.Errorf("Expected the release first, got %v", release)
+	}
+}
+
+func TestMethodRoles(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", 15*time.Minute, time.Hour)
+	interceptor := auth.NewInterceptor(tokens, auth.InterceptorConfig{ExemptMethods: []string{"/"}, RequiredRoles: MethodRoles()})
+	withToken := func(role string) context.Context {
+		token, _ := tokens.GenerateAccessToken("caller-1", "", role)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+
+	for _, method := range AdminMethods {
+		tests := []struct {
+			name     string
+			ctx      context.Context
+			expected codes.Code
+		}{
+			{"no token", context.Background(), codes.Unauthenticated},
+			{"customer", withToken(auth.RoleUser), codes.PermissionDenied},
+			{"service", withToken(auth.RoleService), codes.OK},
+			{"admin", withToken(auth.RoleAdmin), codes.OK},
+		}
+		for _, tt := range tests {
+			_, err := interceptor.Authenticate(tt.ctx, method)
+			if status.Code(err) != tt.expected {
+				t.Errorf("%s by %s: expected %v, got %v", method, tt.name, tt.expected, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+)
+
+const (
+	// maxAttempts is how many times a delivery is tried before it is FAILED
+	maxAttempts = 10
+	// retryBackoff is the wait after the first failed attempt; it doubles
+	// with each further attempt, so the last is tried some 8.5 hours after
+	// the first
+	retryBackoff = time.Minute
+	// deliveryTimeout bounds one attempt
+	deliveryTimeout = 10 * time.Second
+	// claimLease is how long a claimed delivery is left to its deliverer
+	// before another may claim it; it outlasts a batch of attempts
+	claimLease = 2 * time.Minute
+	// deliveryBatchSize is how many due deliveries are claimed per pass
+	deliveryBatchSize = 10
+	// maxErrorBodySize caps the response body kept in a failed attempt's error
+	maxErrorBodySize = 256
+)
+
+// userAgent identifies deliveries to endpoints
+const userAgent = "E-commerce-Webhooks/1.0"
+
+// Deliverer POSTs deliveries to their endpoints and records each attempt.
+// Failed attempts are retried with exponential backoff until maxAttempts.
+type Deliverer struct {
+	repo   Repository
+	client *http.Client
+	log    *logger.Logger
+	now    func() time.Time
+	// wake starts a pass before the next tick
+	wake chan struct{}
+}
+
+// NewDeliverer creates a deliverer. Redirects are not followed: an endpoint
+// answers for itself or the attempt fails.
+func NewDeliverer(repo Repository, log *logger.Logger) *Deliverer {
+	return &Deliverer{
+		repo: repo,
+		client: &http.Client{
+			Timeout: deliveryTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		log:  log,
+		now:  time.Now,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Wake asks a running deliverer to send due deliveries now, without waiting
+// for its next tick
+func (d *Deliverer) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Deliver sends a delivery and records the attempt on del. The error is only
+// for failing to record the attempt; a failed send is recorded on del.
+func (d *Deliverer) Deliver(ctx context.Context, del *Delivery) error {
+	fields := map[string]interface{}{"delivery_id": del.ID, "endpoint_id": del.EndpointID, "event_id": del.EventID}
+
+	start := d.now()
+	code, err := d.send(ctx, del, start)
+	now := d.now()
+
+	attempt := &Attempt{DeliveryID: del.ID, StatusCode: int32(code), Duration: now.Sub(start), AttemptedAt: start}
+	del.Attempts++
+	del.LastStatusCode = int32(code)
+	switch {
+	case err == nil:
+		del.Status, del.LastError, del.DeliveredAt, del.NextAttemptAt = StatusSucceeded, "", &now, nil
+		d.log.Info(ctx, "Webhook delivered", fields)
+	case del.Attempts >= maxAttempts:
+		attempt.Error = err.Error()
+		del.Status, del.LastError, del.NextAttemptAt = StatusFailed, err.Error(), nil
+		fields["error"] = err.Error()
+		fields["attempts"] = del.Attempts
+		d.log.Error(ctx, "Webhook delivery failed", fields)
+	default:
+		attempt.Error = err.Error()
+		next := now.Add(retryBackoff << (del.Attempts - 1))
+		del.Status, del.LastError, del.NextAttemptAt = StatusPending, err.Error(), &next
+		fields["error"] = err.Error()
+		fields["attempts"] = del.Attempts
+		d.log.Warn(ctx, "Webhook delivery failed, will retry", fields)
+	}
+
+	// The attempt happened; record it even if the caller has gone away
+	return d.repo.RecordAttempt(context.WithoutCancel(ctx), del, attempt)
+}
+
+// send POSTs a delivery's payload, signed at sentAt, and returns the response
+// status code, 0 when there was no response. Any status other than 2xx is an
+// error.
+func (d *Deliverer) send(ctx context.Context, del *Delivery, sentAt time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, del.URL, bytes.NewReader(del.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Webhook-Id", del.EventID)
+	req.Header.Set("Webhook-Event", del.EventType)
+	req.Header.Set("Webhook-Delivery", del.ID)
+	req.Header.Set(SignatureHeader, Sign(del.Secret, sentAt, del.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// DeliverDue sends the deliveries that are due and returns how many were
+// attempted
+func (d *Deliverer) DeliverDue(ctx context.Context) (int, error) {
+	due, err := d.repo.ClaimDue(ctx, d.now(), claimLease, deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i, del := range due {
+		if err := d.Deliver(ctx, del); err != nil {
+			return i, err
+		}
+	}
+	return len(due), nil
+}
+
+// Run sends due deliveries every interval, and when woken, until ctx is
+// cancelled
+func (d *Deliverer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		n, err := d.DeliverDue(ctx)
+		if err != nil {
+			d.log.Error(ctx, "Failed to deliver due webhooks", map[string]interface{}{"error": err.Error(), "delivered": n})
+		} else if n == deliveryBatchSize {
+			// A full batch: more may be due
+			d.Wake()
+		}
+	}
+}
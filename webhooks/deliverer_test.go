@@ -0,0 +1,129 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/webhooks/pb"
+)
+
+// publishTo registers an endpoint at url and publishes an event to it,
+// returning the endpoint's secret and the delivery ID
+func publishTo(t *testing.T, service *Service, url string) (string, string) {
+	t.Helper()
+	ctx := context.Background()
+	endpoint, err := service.CreateEndpoint(ctx, &pb.CreateEndpointRequest{Url: url, EventTypes: []string{"order.paid"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	published, err := service.PublishEvent(ctx, &pb.PublishEventRequest{EventId: "order.paid:o1", Type: "order.paid", Data: map[string]string{"order_id": "o1"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return endpoint.Secret, published.Deliveries[0].Id
+}
+
+func TestDeliverer_DeliverDue_Signed(t *testing.T) {
+	r := newReceiver(t, http.StatusNoContent)
+	repo := newMemoryRepository()
+	service, deliverer := newTestService(repo, r.server.Client())
+	secret, deliveryID := publishTo(t, service, r.server.URL)
+
+	if n, err := deliverer.DeliverDue(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Expected 1 delivery attempted, got %d, %v", n, err)
+	}
+
+	if len(r.got) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(r.got))
+	}
+	req, body := r.got[0], r.bodies[0]
+	if req.Method != http.MethodPost || req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected request %s %s", req.Method, req.Header.Get("Content-Type"))
+	}
+	if req.Header.Get("Webhook-Id") != "order.paid:o1" || req.Header.Get("Webhook-Event") != "order.paid" || req.Header.Get("Webhook-Delivery") != deliveryID {
+		t.Errorf("Unexpected webhook headers %v", req.Header)
+	}
+	if string(body) != string(repo.events["order.paid:o1"].Payload) {
+		t.Errorf("Expected the event payload, got %s", body)
+	}
+	if err := Verify(req.Header.Get(SignatureHeader), body, secret, 5*time.Minute, fixedNow); err != nil {
+		t.Errorf("Expected the signature to verify, got %v", err)
+	}
+
+	d := repo.deliveries[deliveryID]
+	if d.Status != StatusSucceeded || d.Attempts != 1 || d.LastStatusCode != 204 || d.DeliveredAt == nil || d.NextAttemptAt != nil {
+		t.Errorf("Unexpected delivery %+v", d)
+	}
+	if n, _ := deliverer.DeliverDue(context.Background()); n != 0 {
+		t.Errorf("Expected nothing left to deliver, got %d", n)
+	}
+}
+
+func TestDeliverer_Deliver_Backoff(t *testing.T) {
+	r := newReceiver(t, http.StatusServiceUnavailable)
+	repo := newMemoryRepository()
+	service, deliverer := newTestService(repo, r.server.Client())
+	_, deliveryID := publishTo(t, service, r.server.URL)
+
+	d, _ := repo.GetDelivery(context.Background(), deliveryID)
+	d.URL, d.Secret, d.Payload = r.server.URL, "whsec_test", []byte(`{}`)
+	for attempt := int32(1); attempt < maxAttempts; attempt++ {
+		if err := deliverer.Deliver(context.Background(), d); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		wantNext := fixedNow.Add(retryBackoff << (attempt - 1))
+		if d.Status != StatusPending || d.Attempts != attempt || d.NextAttemptAt == nil || !d.NextAttemptAt.Equal(wantNext) {
+			t.Fatalf("Attempt %d: expected PENDING until %v, got %s until %v", attempt, wantNext, d.Status, d.NextAttemptAt)
+		}
+	}
+
+	if err := deliverer.Deliver(context.Background(), d); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if d.Status != StatusFailed || d.Attempts != maxAttempts || d.NextAttemptAt != nil || d.LastStatusCode != 503 {
+		t.Errorf("Expected FAILED after %d attempts, got %+v", maxAttempts, d)
+	}
+	if attempts, _ := repo.Attempts(context.Background(), deliveryID); len(attempts) != maxAttempts {
+		t.Errorf("Expected every attempt to be logged, got %d", len(attempts))
+	}
+}
+
+func TestDeliverer_Deliver_NoRedirects(t *testing.T) {
+	target := newReceiver(t, http.StatusOK)
+	redirect := newReceiver(t, http.StatusOK)
+	redirect.server.Config.Handler = http.RedirectHandler(target.server.URL, http.StatusTemporaryRedirect)
+
+	repo := newMemoryRepository()
+	service, deliverer := newTestService(repo, redirect.server.Client())
+	_, deliveryID := publishTo(t, service, redirect.server.URL)
+
+	if _, err := deliverer.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if d := repo.deliveries[deliveryID]; d.Status != StatusPending || d.LastStatusCode != http.StatusTemporaryRedirect {
+		t.Errorf("Expected a redirect to fail the attempt, got %+v", d)
+	}
+	if len(target.got) != 0 {
+		t.Error("Expected the redirect not to be followed")
+	}
+}
+
+func TestDeliverer_DeliverDue_InactiveEndpoint(t *testing.T) {
+	r := newReceiver(t, http.StatusOK)
+	repo := newMemoryRepository()
+	service, deliverer := newTestService(repo, r.server.Client())
+	_, deliveryID := publishTo(t, service, r.server.URL)
+
+	endpointID := repo.deliveries[deliveryID].EndpointID
+	repo.endpoints[endpointID].Active = false
+	if n, _ := deliverer.DeliverDue(context.Background()); n != 0 {
+		t.Errorf("Expected deliveries to an inactive endpoint to be held, got %d", n)
+	}
+
+	repo.endpoints[endpointID].Active = true
+	if n, _ := deliverer.DeliverDue(context.Background()); n != 1 {
+		t.Errorf("Expected the held delivery once the endpoint is active, got %d", n)
+	}
+}
@@ -0,0 +1,590 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/pgerr"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Delivery statuses
+const (
+	StatusPending   = "PENDING"
+	StatusSucceeded = "SUCCEEDED"
+	StatusFailed    = "FAILED"
+)
+
+var (
+	// ErrEndpointNotFound is returned when an endpoint does not exist
+	ErrEndpointNotFound = errors.New("endpoint not found")
+	// ErrDeliveryNotFound is returned when a delivery does not exist
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	// ErrDuplicateEvent is returned when an event has already been recorded
+	ErrDuplicateEvent = errors.New("event already recorded")
+	// ErrNotFailed is returned when retrying a delivery that has not failed
+	ErrNotFailed = errors.New("delivery has not failed")
+)
+
+// Endpoint is a URL an integrator receives events on
+type Endpoint struct {
+	ID          string
+	URL         string
+	Description string
+	EventTypes  []string
+	// Secret signs every delivery to the endpoint
+	Secret    string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Event is a domain event handed to the webhooks service
+type Event struct {
+	ID   string
+	Type string
+	// Payload is the JSON body sent to endpoints
+	Payload    []byte
+	OccurredAt time.Time
+	ReceivedAt time.Time
+}
+
+// Delivery is one event sent to one endpoint
+type Delivery struct {
+	ID             string
+	EndpointID     string
+	EventID        string
+	EventType      string
+	Status         string
+	Attempts       int32
+	LastStatusCode int32
+	LastError      string
+	// NextAttemptAt is when a PENDING delivery is due to be sent
+	NextAttemptAt *time.Time
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+
+	// URL, Secret and Payload are what is sent; they are loaded with the
+	// deliveries ClaimDue returns
+	URL     string
+	Secret  string
+	Payload []byte
+}
+
+// Attempt is one try at sending a delivery
+type Attempt struct {
+	DeliveryID  string
+	StatusCode  int32
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+// Repository handles webhook data persistence
+type Repository interface {
+	CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error)
+	GetEndpoint(ctx context.Context, id string) (*Endpoint, error)
+	// ListEndpoints lists every endpoint, oldest first
+	ListEndpoints(ctx context.Context) ([]*Endpoint, error)
+	// UpdateEndpoint stores an endpoint's URL, description, event types,
+	// active flag and update time
+	UpdateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error)
+	SetSecret(ctx context.Context, id, secret string, updatedAt time.Time) error
+	// DeleteEndpoint deletes an endpoint with its deliveries
+	DeleteEndpoint(ctx context.Context, id string) error
+	// SubscribedEndpoints lists the active endpoints subscribed to eventType
+	SubscribedEndpoints(ctx context.Context, eventType string) ([]*Endpoint, error)
+	// CreateForEvent records an event with its deliveries, or returns
+	// ErrDuplicateEvent when the event has been recorded before
+	CreateForEvent(ctx context.Context, event *Event, deliveries []*Delivery) error
+	ListByEvent(ctx context.Context, eventID string) ([]*Delivery, error)
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+	// ListDeliveries lists an endpoint's deliveries, newest first, optionally
+	// only those with status
+	ListDeliveries(ctx context.Context, endpointID, status string, page, pageSize int32) ([]*Delivery, int32, error)
+	// Attempts lists a delivery's attempts, oldest first
+	Attempts(ctx context.Context, deliveryID string) ([]*Attempt, error)
+	// ClaimDue returns up to limit PENDING deliveries to active endpoints due
+	// by now, with what is sent, and defers them to now plus lease, so that no
+	// other deliverer claims them while they are being sent
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Delivery, error)
+	// RecordAttempt logs an attempt and stores its outcome on the delivery:
+	// status, attempts, last status code and error, delivered and next
+	// attempt times
+	RecordAttempt(ctx context.Context, d *Delivery, attempt *Attempt) error
+	// Requeue makes a FAILED delivery PENDING again, due at now with no
+	// attempts, or returns ErrNotFailed
+	Requeue(ctx context.Context, id string, now time.Time) (*Delivery, error)
+	Close() error
+}
+
+type postgresRepository struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewPostgresRepository creates a new PostgreSQL webhook repository
+func NewPostgresRepository(db *sql.DB, log *logger.Logger) Repository {
+	return &postgresRepository{db: db, log: log}
+}
+
+const endpointColumns = `id, url, description, event_types, secret, active, created_at, updated_at`
+
+const deliveryColumns = `id, endpoint_id, event_id, event_type, status, attempts, last_status_code, last_error,
+	next_attempt_at, created_at, delivered_at`
+
+// CreateEndpoint inserts an endpoint
+func (r *postgresRepository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	endpoint.ID = uuid.New().String()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_endpoints (`+endpointColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, endpoint.ID, endpoint.URL, endpoint.Description, pq.Array(endpoint.EventTypes), endpoint.Secret,
+		endpoint.Active, endpoint.CreatedAt, endpoint.UpdatedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to create endpoint", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	r.log.Info(ctx, "Endpoint created", map[string]interface{}{"endpoint_id": endpoint.ID})
+	return endpoint, nil
+}
+
+// GetEndpoint retrieves an endpoint
+func (r *postgresRepository) GetEndpoint(ctx context.Context, id string) (*Endpoint, error) {
+	query := "SELECT " + endpointColumns + " FROM webhook_endpoints WHERE id = $1"
+
+	e, err := scanEndpoint(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrEndpointNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get endpoint", map[string]interface{}{"error": err.Error(), "endpoint_id": id})
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+	return e, nil
+}
+
+// ListEndpoints retrieves every endpoint
+func (r *postgresRepository) ListEndpoints(ctx context.Context) ([]*Endpoint, error) {
+	query := "SELECT " + endpointColumns + " FROM webhook_endpoints ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list endpoints", map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+	return scanEndpoints(rows)
+}
+
+// UpdateEndpoint updates an endpoint's settings
+func (r *postgresRepository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $2, description = $3, event_types = $4, active = $5, updated_at = $6
+		WHERE id = $1
+		RETURNING ` + endpointColumns
+
+	e, err := scanEndpoint(r.db.QueryRowContext(ctx, query, endpoint.ID, endpoint.URL, endpoint.Description,
+		pq.Array(endpoint.EventTypes), endpoint.Active, endpoint.UpdatedAt))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrEndpointNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to update endpoint", map[string]interface{}{"error": err.Error(), "endpoint_id": endpoint.ID})
+		return nil, fmt.Errorf("failed to update endpoint: %w", err)
+	}
+	return e, nil
+}
+
+// SetSecret replaces an endpoint's signing secret
+func (r *postgresRepository) SetSecret(ctx context.Context, id, secret string, updatedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_endpoints SET secret = $2, updated_at = $3 WHERE id = $1
+	`, id, secret, updatedAt)
+	if pgerr.IsMalformedID(err) {
+		return ErrEndpointNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to set endpoint secret", map[string]interface{}{"error": err.Error(), "endpoint_id": id})
+		return fmt.Errorf("failed to set endpoint secret: %w", err)
+	}
+	return requireRow(result, ErrEndpointNotFound)
+}
+
+// DeleteEndpoint deletes an endpoint; its deliveries and their attempts are
+// deleted by cascade
+func (r *postgresRepository) DeleteEndpoint(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM webhook_endpoints WHERE id = $1", id)
+	if pgerr.IsMalformedID(err) {
+		return ErrEndpointNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to delete endpoint", map[string]interface{}{"error": err.Error(), "endpoint_id": id})
+		return fmt.Errorf("failed to delete endpoint: %w", err)
+	}
+	return requireRow(result, ErrEndpointNotFound)
+}
+
+// SubscribedEndpoints retrieves the active endpoints subscribed to eventType
+func (r *postgresRepository) SubscribedEndpoints(ctx context.Context, eventType string) ([]*Endpoint, error) {
+	query := "SELECT " + endpointColumns + " FROM webhook_endpoints WHERE active AND $1 = ANY(event_types) ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, eventType)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list subscribed endpoints", map[string]interface{}{"error": err.Error(), "event_type": eventType})
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+	return scanEndpoints(rows)
+}
+
+// CreateForEvent inserts an event and its deliveries in one transaction
+func (r *postgresRepository) CreateForEvent(ctx context.Context, event *Event, deliveries []*Delivery) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO webhook_events (id, type, payload, occurred_at, received_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING
+	`, event.ID, event.Type, string(event.Payload), event.OccurredAt, event.ReceivedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record event", map[string]interface{}{"error": err.Error(), "event_id": event.ID})
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	} else if n == 0 {
+		return ErrDuplicateEvent
+	}
+
+	for _, d := range deliveries {
+		d.ID = uuid.New().String()
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (id, endpoint_id, event_id, event_type, status, attempts, next_attempt_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, d.ID, d.EndpointID, d.EventID, d.EventType, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt)
+		if err != nil {
+			r.log.Error(ctx, "Failed to create delivery", map[string]interface{}{"error": err.Error(), "event_id": event.ID, "endpoint_id": d.EndpointID})
+			return fmt.Errorf("failed to create delivery: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info(ctx, "Event recorded", map[string]interface{}{"event_id": event.ID, "type": event.Type, "deliveries": len(deliveries)})
+	return nil
+}
+
+// ListByEvent retrieves the deliveries of an event
+func (r *postgresRepository) ListByEvent(ctx context.Context, eventID string) ([]*Delivery, error) {
+	query := "SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE event_id = $1 ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list event deliveries", map[string]interface{}{"error": err.Error(), "event_id": eventID})
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	return scanDeliveries(rows)
+}
+
+// GetDelivery retrieves a delivery
+func (r *postgresRepository) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	query := "SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE id = $1"
+
+	d, err := scanDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows || pgerr.IsMalformedID(err) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to get delivery", map[string]interface{}{"error": err.Error(), "delivery_id": id})
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+	return d, nil
+}
+
+// ListDeliveries retrieves an endpoint's deliveries with pagination
+func (r *postgresRepository) ListDeliveries(ctx context.Context, endpointID, status string, page, pageSize int32) ([]*Delivery, int32, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	// An empty status matches every status
+	var total int32
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM webhook_deliveries WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+	`, endpointID, status).Scan(&total)
+	if err != nil {
+		r.log.Error(ctx, "Failed to count deliveries", map[string]interface{}{"error": err.Error(), "endpoint_id": endpointID})
+		return nil, 0, fmt.Errorf("failed to count deliveries: %w", err)
+	}
+
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, endpointID, status, pageSize, offset)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list deliveries", map[string]interface{}{"error": err.Error(), "endpoint_id": endpointID})
+		return nil, 0, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	deliveries, err := scanDeliveries(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// Attempts retrieves a delivery's attempts
+func (r *postgresRepository) Attempts(ctx context.Context, deliveryID string) ([]*Attempt, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT delivery_id, status_code, error, duration_ms, attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY id
+	`, deliveryID)
+	if err != nil {
+		r.log.Error(ctx, "Failed to list attempts", map[string]interface{}{"error": err.Error(), "delivery_id": deliveryID})
+		return nil, fmt.Errorf("failed to list attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*Attempt
+	for rows.Next() {
+		a := &Attempt{}
+		var durationMS int64
+		if err := rows.Scan(&a.DeliveryID, &a.StatusCode, &a.Error, &durationMS, &a.AttemptedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attempt: %w", err)
+		}
+		a.Duration = time.Duration(durationMS) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// ClaimDue defers due deliveries by lease and returns them with their
+// endpoint's URL and secret and their event's payload. Rows claimed by a
+// concurrent deliverer are skipped rather than waited for.
+func (r *postgresRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Delivery, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_deliveries SET next_attempt_at = $2
+			WHERE id IN (
+				SELECT d.id FROM webhook_deliveries d
+				JOIN webhook_endpoints e ON e.id = d.endpoint_id
+				WHERE d.status = 'PENDING' AND d.next_attempt_at <= $1 AND e.active
+				ORDER BY d.next_attempt_at
+				LIMIT $3
+				FOR UPDATE OF d SKIP LOCKED
+			)
+			RETURNING ` + deliveryColumns + `
+		)
+		SELECT c.id, c.endpoint_id, c.event_id, c.event_type, c.status, c.attempts, c.last_status_code, c.last_error,
+			c.next_attempt_at, c.created_at, c.delivered_at, e.url, e.secret, ev.payload
+		FROM claimed c
+		JOIN webhook_endpoints e ON e.id = c.endpoint_id
+		JOIN webhook_events ev ON ev.id = c.event_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*Delivery
+	for rows.Next() {
+		d := &Delivery{}
+		var nextAttemptAt, deliveredAt sql.NullTime
+		var payload string
+		err := rows.Scan(&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &d.LastStatusCode,
+			&d.LastError, &nextAttemptAt, &d.CreatedAt, &deliveredAt, &d.URL, &d.Secret, &payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		setDeliveryTimes(d, nextAttemptAt, deliveredAt)
+		d.Payload = []byte(payload)
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// RecordAttempt logs an attempt and stores the delivery's outcome in one
+// transaction
+func (r *postgresRepository) RecordAttempt(ctx context.Context, d *Delivery, attempt *Attempt) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_delivery_attempts (delivery_id, status_code, error, duration_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, d.ID, attempt.StatusCode, attempt.Error, attempt.Duration.Milliseconds(), attempt.AttemptedAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to log delivery attempt", map[string]interface{}{"error": err.Error(), "delivery_id": d.ID})
+		return fmt.Errorf("failed to log delivery attempt: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, last_status_code = $4, last_error = $5, delivered_at = $6, next_attempt_at = $7
+		WHERE id = $1
+	`, d.ID, d.Status, d.Attempts, d.LastStatusCode, d.LastError, d.DeliveredAt, d.NextAttemptAt)
+	if err != nil {
+		r.log.Error(ctx, "Failed to record delivery attempt", map[string]interface{}{"error": err.Error(), "delivery_id": d.ID})
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Requeue makes a FAILED delivery due again. The update only matches a
+// FAILED row, so a delivery retried twice at once is requeued once.
+func (r *postgresRepository) Requeue(ctx context.Context, id string, now time.Time) (*Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = $2
+		WHERE id = $1 AND status = 'FAILED'
+		RETURNING ` + deliveryColumns
+
+	d, err := scanDelivery(r.db.QueryRowContext(ctx, query, id, now))
+	if err == sql.ErrNoRows {
+		if _, err := r.GetDelivery(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrNotFailed
+	}
+	if pgerr.IsMalformedID(err) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "Failed to requeue delivery", map[string]interface{}{"error": err.Error(), "delivery_id": id})
+		return nil, fmt.Errorf("failed to requeue delivery: %w", err)
+	}
+	return d, nil
+}
+
+// Close closes the database connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+// requireRow returns notFound when result changed no row
+func requireRow(result sql.Result, notFound error) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEndpoint scans an endpoint selected with endpointColumns
+func scanEndpoint(row rowScanner) (*Endpoint, error) {
+	e := &Endpoint{}
+	err := row.Scan(&e.ID, &e.URL, &e.Description, pq.Array(&e.EventTypes), &e.Secret, &e.Active, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// scanEndpoints scans and closes rows of endpoints
+func scanEndpoints(rows *sql.Rows) ([]*Endpoint, error) {
+	defer rows.Close()
+
+	var endpoints []*Endpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan endpoint: %w", err)
+		}
+		endpoints = append(endpoints, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+// scanDelivery scans a delivery selected with deliveryColumns
+func scanDelivery(row rowScanner) (*Delivery, error) {
+	d := &Delivery{}
+	var nextAttemptAt, deliveredAt sql.NullTime
+
+	err := row.Scan(&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &d.LastStatusCode,
+		&d.LastError, &nextAttemptAt, &d.CreatedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	setDeliveryTimes(d, nextAttemptAt, deliveredAt)
+	return d, nil
+}
+
+// setDeliveryTimes sets a delivery's nullable times
+func setDeliveryTimes(d *Delivery, nextAttemptAt, deliveredAt sql.NullTime) {
+	if nextAttemptAt.Valid {
+		d.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+}
+
+// scanDeliveries scans and closes rows of deliveries
+func scanDeliveries(rows *sql.Rows) ([]*Delivery, error) {
+	defer rows.Close()
+
+	var deliveries []*Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deliveries: %w", err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,161 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, Repository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	log := logger.New("webhooks-test")
+	repo := NewPostgresRepository(db, log)
+
+	return db, mock, repo
+}
+
+var deliveryColumnNames = []string{
+	"id", "endpoint_id", "event_id", "event_type", "status", "attempts", "last_status_code", "last_error",
+	"next_attempt_at", "created_at", "delivered_at",
+}
+
+func TestCreateForEvent_Duplicate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO webhook_events`).
+		WithArgs("order.paid:o1", "order.paid", `{}`, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	event := &Event{ID: "order.paid:o1", Type: "order.paid", Payload: []byte(`{}`), OccurredAt: now, ReceivedAt: now}
+	err := repo.CreateForEvent(context.Background(), event, []*Delivery{{EndpointID: "e1", EventID: event.ID}})
+	if err != ErrDuplicateEvent {
+		t.Errorf("Expected ErrDuplicateEvent, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSubscribedEndpoints(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM webhook_endpoints WHERE active AND \$1 = ANY\(event_types\)`).
+		WithArgs("order.paid").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "description", "event_types", "secret", "active", "created_at", "updated_at"}).
+			AddRow("e1", "https://erp.example.com/hooks", "ERP", "{order.created,order.paid}", "whsec_a", true, now, now))
+
+	endpoints, err := repo.SubscribedEndpoints(context.Background(), "order.paid")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(endpoints) != 1 || len(endpoints[0].EventTypes) != 2 || endpoints[0].EventTypes[1] != "order.paid" {
+		t.Errorf("Unexpected endpoints %v", endpoints)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClaimDue(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`WITH claimed AS \(\s*UPDATE webhook_deliveries SET next_attempt_at = \$2`).
+		WithArgs(now, now.Add(claimLease), deliveryBatchSize).
+		WillReturnRows(sqlmock.NewRows(append(deliveryColumnNames, "url", "secret", "payload")).
+			AddRow("d1", "e1", "order.paid:o1", "order.paid", StatusPending, 2, 500, "unexpected status 500", now.Add(claimLease), now, nil,
+				"https://erp.example.com/hooks", "whsec_a", `{"id":"order.paid:o1"}`))
+
+	due, err := repo.ClaimDue(context.Background(), now, claimLease, deliveryBatchSize)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(due) != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", len(due))
+	}
+	d := due[0]
+	if d.Attempts != 2 || d.URL != "https://erp.example.com/hooks" || d.Secret != "whsec_a" || string(d.Payload) != `{"id":"order.paid:o1"}` || d.DeliveredAt != nil {
+		t.Errorf("Unexpected delivery %+v", d)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecordAttempt(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	d := &Delivery{ID: "d1", Status: StatusSucceeded, Attempts: 1, LastStatusCode: 200, DeliveredAt: &now}
+	attempt := &Attempt{DeliveryID: "d1", StatusCode: 200, Duration: 120 * time.Millisecond, AttemptedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO webhook_delivery_attempts`).
+		WithArgs("d1", int32(200), "", int64(120), now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE webhook_deliveries`).
+		WithArgs("d1", StatusSucceeded, int32(1), int32(200), "", &now, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.RecordAttempt(context.Background(), d, attempt); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRequeue_NotFailed(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE webhook_deliveries\s+SET status = 'PENDING'`).
+		WithArgs("d1", now).
+		WillReturnRows(sqlmock.NewRows(deliveryColumnNames))
+	mock.ExpectQuery(`SELECT (.+) FROM webhook_deliveries WHERE id = \$1`).
+		WithArgs("d1").
+		WillReturnRows(sqlmock.NewRows(deliveryColumnNames).
+			AddRow("d1", "e1", "order.paid:o1", "order.paid", StatusSucceeded, 1, 200, "", nil, now, now))
+
+	if _, err := repo.Requeue(context.Background(), "d1", now); err != ErrNotFailed {
+		t.Errorf("Expected ErrNotFailed, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetEndpoint_MalformedID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM webhook_endpoints WHERE id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pq.Error{Code: "22P02"})
+
+	if _, err := repo.GetEndpoint(context.Background(), "not-a-uuid"); err != ErrEndpointNotFound {
+		t.Errorf("Expected ErrEndpointNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
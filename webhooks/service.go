@@ -0,0 +1,511 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/webhooks/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIDLength matches the event ID column
+	maxIDLength = 255
+	// maxURLLength matches the url column
+	maxURLLength = 2048
+	// maxDescriptionLength matches the description column
+	maxDescriptionLength = 255
+	// maxDataEntries and maxDataSize bound an event's data
+	maxDataEntries = 50
+	maxDataSize    = 16 << 10
+)
+
+// EventTypes are the event types endpoints can subscribe to
+var EventTypes = []string{
+	"order.created",
+	"order.paid",
+	"order.cancelled",
+	"order.fulfilled",
+	"order.delivered",
+	"payment.captured",
+	"payment.refunded",
+	"shipment.shipped",
+	"shipment.delivered",
+	"return.requested",
+	"return.refunded",
+	"product.created",
+	"product.updated",
+	"product.deleted",
+}
+
+// AdminMethods are the admin-scoped RPCs of the webhooks service; they are
+// restricted to the admin network allowlist. Endpoints are managed from the
+// backoffice and events are published by the other services, so that is
+// every RPC.
+var AdminMethods = []string{
+	"/" + pb.WebhookService_ServiceDesc.ServiceName + "/",
+}
+
+// Service implements the WebhookService gRPC interface
+type Service struct {
+	pb.UnimplementedWebhookServiceServer
+	repo      Repository
+	deliverer *Deliverer
+	// allowHTTP accepts http:// endpoint URLs
+	allowHTTP bool
+	log       *logger.Logger
+	now       func() time.Time
+}
+
+// NewService creates a new webhooks service
+func NewService(repo Repository, deliverer *Deliverer, log *logger.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		deliverer: deliverer,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// WithHTTPEndpoints accepts http:// endpoint URLs as well as https://, for
+// development against local receivers
+func (s *Service) WithHTTPEndpoints() *Service {
+	s.allowHTTP = true
+	return s
+}
+
+// CreateEndpoint registers an endpoint with a new signing secret
+func (s *Service) CreateEndpoint(ctx context.Context, req *pb.CreateEndpointRequest) (*pb.CreateEndpointResponse, error) {
+	eventTypes := normalizeEventTypes(req.EventTypes)
+	if msg := s.endpointProblem(req.Url, req.Description, eventTypes); msg != "" {
+		s.log.Warn(ctx, "Create endpoint failed: "+msg, map[string]interface{}{"url": req.Url})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		s.log.Error(ctx, "Failed to generate secret", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to create endpoint")
+	}
+
+	now := s.now()
+	endpoint, err := s.repo.CreateEndpoint(ctx, &Endpoint{
+		URL:         req.Url,
+		Description: req.Description,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create endpoint")
+	}
+
+	return &pb.CreateEndpointResponse{
+		Endpoint: toProtoEndpoint(endpoint),
+		Secret:   secret,
+	}, nil
+}
+
+// GetEndpoint returns an endpoint
+func (s *Service) GetEndpoint(ctx context.Context, req *pb.GetEndpointRequest) (*pb.GetEndpointResponse, error) {
+	endpoint, err := s.loadEndpoint(ctx, req.EndpointId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetEndpointResponse{
+		Endpoint: toProtoEndpoint(endpoint),
+	}, nil
+}
+
+// ListEndpoints lists every endpoint, oldest first
+func (s *Service) ListEndpoints(ctx context.Context, req *pb.ListEndpointsRequest) (*pb.ListEndpointsResponse, error) {
+	endpoints, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list endpoints")
+	}
+
+	resp := &pb.ListEndpointsResponse{Endpoints: make([]*pb.Endpoint, len(endpoints))}
+	for i, e := range endpoints {
+		resp.Endpoints[i] = toProtoEndpoint(e)
+	}
+	return resp, nil
+}
+
+// UpdateEndpoint replaces an endpoint's settings. Deliveries already made
+// keep going to the endpoint's new URL; deactivating it holds them until it
+// is active again.
+func (s *Service) UpdateEndpoint(ctx context.Context, req *pb.UpdateEndpointRequest) (*pb.UpdateEndpointResponse, error) {
+	if req.EndpointId == "" {
+		s.log.Warn(ctx, "Update endpoint failed: endpoint ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "endpoint_id is required")
+	}
+	eventTypes := normalizeEventTypes(req.EventTypes)
+	if msg := s.endpointProblem(req.Url, req.Description, eventTypes); msg != "" {
+		s.log.Warn(ctx, "Update endpoint failed: "+msg, map[string]interface{}{"endpoint_id": req.EndpointId})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	endpoint, err := s.repo.UpdateEndpoint(ctx, &Endpoint{
+		ID:          req.EndpointId,
+		URL:         req.Url,
+		Description: req.Description,
+		EventTypes:  eventTypes,
+		Active:      req.Active,
+		UpdatedAt:   s.now(),
+	})
+	if errors.Is(err, ErrEndpointNotFound) {
+		s.log.Warn(ctx, "Endpoint not found", map[string]interface{}{"endpoint_id": req.EndpointId})
+		return nil, status.Error(codes.NotFound, "endpoint not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update endpoint")
+	}
+
+	if endpoint.Active {
+		// Deliveries held while the endpoint was inactive are due
+		s.deliverer.Wake()
+	}
+	return &pb.UpdateEndpointResponse{
+		Endpoint: toProtoEndpoint(endpoint),
+	}, nil
+}
+
+// DeleteEndpoint removes an endpoint with its deliveries
+func (s *Service) DeleteEndpoint(ctx context.Context, req *pb.DeleteEndpointRequest) (*pb.DeleteEndpointResponse, error) {
+	if req.EndpointId == "" {
+		s.log.Warn(ctx, "Delete endpoint failed: endpoint ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "endpoint_id is required")
+	}
+
+	err := s.repo.DeleteEndpoint(ctx, req.EndpointId)
+	if errors.Is(err, ErrEndpointNotFound) {
+		s.log.Warn(ctx, "Endpoint not found", map[string]interface{}{"endpoint_id": req.EndpointId})
+		return nil, status.Error(codes.NotFound, "endpoint not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete endpoint")
+	}
+
+	s.log.Info(ctx, "Endpoint deleted", map[string]interface{}{"endpoint_id": req.EndpointId})
+	return &pb.DeleteEndpointResponse{}, nil
+}
+
+// RotateSecret replaces an endpoint's signing secret
+func (s *Service) RotateSecret(ctx context.Context, req *pb.RotateSecretRequest) (*pb.RotateSecretResponse, error) {
+	if req.EndpointId == "" {
+		s.log.Warn(ctx, "Rotate secret failed: endpoint ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "endpoint_id is required")
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		s.log.Error(ctx, "Failed to generate secret", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Internal, "failed to rotate secret")
+	}
+
+	err = s.repo.SetSecret(ctx, req.EndpointId, secret, s.now())
+	if errors.Is(err, ErrEndpointNotFound) {
+		s.log.Warn(ctx, "Endpoint not found", map[string]interface{}{"endpoint_id": req.EndpointId})
+		return nil, status.Error(codes.NotFound, "endpoint not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to rotate secret")
+	}
+
+	s.log.Info(ctx, "Endpoint secret rotated", map[string]interface{}{"endpoint_id": req.EndpointId})
+	return &pb.RotateSecretResponse{Secret: secret}, nil
+}
+
+// eventPayload is the JSON body endpoints receive
+type eventPayload struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Data       map[string]string `json:"data"`
+}
+
+// PublishEvent records an event with a delivery to every active endpoint
+// subscribed to its type. The deliveries are due at once and sent by the
+// deliverer.
+func (s *Service) PublishEvent(ctx context.Context, req *pb.PublishEventRequest) (*pb.PublishEventResponse, error) {
+	if msg := eventProblem(req); msg != "" {
+		s.log.Warn(ctx, "Publish event failed: "+msg, map[string]interface{}{"event_id": req.EventId, "type": req.Type})
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	now := s.now()
+	payload := eventPayload{ID: req.EventId, Type: req.Type, OccurredAt: now.UTC(), Data: req.Data}
+	if req.OccurredAt != nil {
+		payload.OccurredAt = req.OccurredAt.AsTime()
+	}
+	if payload.Data == nil {
+		payload.Data = map[string]string{}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to encode event")
+	}
+	event := &Event{ID: req.EventId, Type: req.Type, Payload: body, OccurredAt: payload.OccurredAt, ReceivedAt: now}
+
+	endpoints, err := s.repo.SubscribedEndpoints(ctx, event.Type)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list endpoints")
+	}
+	deliveries := make([]*Delivery, len(endpoints))
+	for i, e := range endpoints {
+		deliveries[i] = &Delivery{
+			EndpointID:    e.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Status:        StatusPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		}
+	}
+
+	err = s.repo.CreateForEvent(ctx, event, deliveries)
+	if errors.Is(err, ErrDuplicateEvent) {
+		s.log.Info(ctx, "Duplicate event skipped", map[string]interface{}{"event_id": event.ID, "type": event.Type})
+		existing, err := s.repo.ListByEvent(ctx, event.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get deliveries")
+		}
+		return &pb.PublishEventResponse{Deliveries: toProtoDeliveries(existing), Duplicate: true}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to record event")
+	}
+
+	if len(deliveries) > 0 {
+		s.deliverer.Wake()
+	}
+	return &pb.PublishEventResponse{Deliveries: toProtoDeliveries(deliveries)}, nil
+}
+
+// eventProblem validates an event and returns a message describing the first
+// problem, or ""
+func eventProblem(req *pb.PublishEventRequest) string {
+	switch {
+	case req.EventId == "":
+		return "event_id is required"
+	case len(req.EventId) > maxIDLength:
+		return "event_id is too long"
+	case req.Type == "":
+		return "type is required"
+	case !slices.Contains(EventTypes, req.Type):
+		return "unknown event type " + req.Type
+	case len(req.Data) > maxDataEntries:
+		return "data has too many entries"
+	}
+	size := 0
+	for k, v := range req.Data {
+		size += len(k) + len(v)
+	}
+	if size > maxDataSize {
+		return "data is too large"
+	}
+	return ""
+}
+
+// ListDeliveries lists an endpoint's deliveries, newest first
+func (s *Service) ListDeliveries(ctx context.Context, req *pb.ListDeliveriesRequest) (*pb.ListDeliveriesResponse, error) {
+	endpoint, err := s.loadEndpoint(ctx, req.EndpointId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != "" && req.Status != StatusPending && req.Status != StatusSucceeded && req.Status != StatusFailed {
+		s.log.Warn(ctx, "List deliveries failed: invalid status", map[string]interface{}{"status": req.Status})
+		return nil, status.Error(codes.InvalidArgument, "status must be PENDING, SUCCEEDED or FAILED")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	deliveries, total, err := s.repo.ListDeliveries(ctx, endpoint.ID, req.Status, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list deliveries")
+	}
+
+	return &pb.ListDeliveriesResponse{
+		Deliveries: toProtoDeliveries(deliveries),
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// GetDelivery returns a delivery with its attempts
+func (s *Service) GetDelivery(ctx context.Context, req *pb.GetDeliveryRequest) (*pb.GetDeliveryResponse, error) {
+	if req.DeliveryId == "" {
+		s.log.Warn(ctx, "Get delivery failed: delivery ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "delivery_id is required")
+	}
+
+	delivery, err := s.repo.GetDelivery(ctx, req.DeliveryId)
+	if errors.Is(err, ErrDeliveryNotFound) {
+		s.log.Warn(ctx, "Delivery not found", map[string]interface{}{"delivery_id": req.DeliveryId})
+		return nil, status.Error(codes.NotFound, "delivery not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get delivery")
+	}
+
+	attempts, err := s.repo.Attempts(ctx, delivery.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get delivery attempts")
+	}
+
+	resp := &pb.GetDeliveryResponse{Delivery: toProtoDelivery(delivery)}
+	for _, a := range attempts {
+		resp.Attempts = append(resp.Attempts, &pb.Attempt{
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+			DurationMs:  a.Duration.Milliseconds(),
+			AttemptedAt: timestamppb.New(a.AttemptedAt),
+		})
+	}
+	return resp, nil
+}
+
+// RetryDelivery makes a FAILED delivery due again
+func (s *Service) RetryDelivery(ctx context.Context, req *pb.RetryDeliveryRequest) (*pb.RetryDeliveryResponse, error) {
+	if req.DeliveryId == "" {
+		s.log.Warn(ctx, "Retry delivery failed: delivery ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "delivery_id is required")
+	}
+
+	delivery, err := s.repo.Requeue(ctx, req.DeliveryId, s.now())
+	switch {
+	case errors.Is(err, ErrDeliveryNotFound):
+		s.log.Warn(ctx, "Delivery not found", map[string]interface{}{"delivery_id": req.DeliveryId})
+		return nil, status.Error(codes.NotFound, "delivery not found")
+	case errors.Is(err, ErrNotFailed):
+		s.log.Warn(ctx, "Retry delivery failed: delivery has not failed", map[string]interface{}{"delivery_id": req.DeliveryId})
+		return nil, status.Error(codes.FailedPrecondition, "only FAILED deliveries can be retried")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "failed to retry delivery")
+	}
+
+	s.log.Info(ctx, "Delivery requeued", map[string]interface{}{"delivery_id": delivery.ID})
+	s.deliverer.Wake()
+	return &pb.RetryDeliveryResponse{
+		Delivery: toProtoDelivery(delivery),
+	}, nil
+}
+
+// endpointProblem validates an endpoint's settings and returns a message
+// describing the first problem, or ""
+func (s *Service) endpointProblem(rawURL, description string, eventTypes []string) string {
+	if rawURL == "" {
+		return "url is required"
+	}
+	if len(rawURL) > maxURLLength {
+		return "url is too long"
+	}
+	u, err := url.Parse(rawURL)
+	switch {
+	case err != nil || u.Host == "":
+		return "url must be an absolute URL"
+	case u.Scheme != "https" && !(s.allowHTTP && u.Scheme == "http"):
+		return "url must use https"
+	case u.User != nil:
+		return "url cannot contain credentials"
+	case u.Fragment != "":
+		return "url cannot contain a fragment"
+	case utf8.RuneCountInString(description) > maxDescriptionLength:
+		return "description is too long"
+	case len(eventTypes) == 0:
+		return "at least one event type is required"
+	}
+	for _, t := range eventTypes {
+		if !slices.Contains(EventTypes, t) {
+			return "unknown event type " + t
+		}
+	}
+	return ""
+}
+
+// normalizeEventTypes sorts event types and drops duplicates
+func normalizeEventTypes(eventTypes []string) []string {
+	normalized := slices.Clone(eventTypes)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// loadEndpoint returns an endpoint, or the gRPC error to answer with
+func (s *Service) loadEndpoint(ctx context.Context, endpointID string) (*Endpoint, error) {
+	if endpointID == "" {
+		s.log.Warn(ctx, "Endpoint request failed: endpoint ID is required", nil)
+		return nil, status.Error(codes.InvalidArgument, "endpoint_id is required")
+	}
+
+	endpoint, err := s.repo.GetEndpoint(ctx, endpointID)
+	if errors.Is(err, ErrEndpointNotFound) {
+		s.log.Warn(ctx, "Endpoint not found", map[string]interface{}{"endpoint_id": endpointID})
+		return nil, status.Error(codes.NotFound, "endpoint not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get endpoint")
+	}
+	return endpoint, nil
+}
+
+func toProtoEndpoint(e *Endpoint) *pb.Endpoint {
+	return &pb.Endpoint{
+		Id:          e.ID,
+		Url:         e.URL,
+		Description: e.Description,
+		EventTypes:  e.EventTypes,
+		Active:      e.Active,
+		CreatedAt:   timestamppb.New(e.CreatedAt),
+		UpdatedAt:   timestamppb.New(e.UpdatedAt),
+	}
+}
+
+func toProtoDelivery(d *Delivery) *pb.Delivery {
+	delivery := &pb.Delivery{
+		Id:             d.ID,
+		EndpointId:     d.EndpointID,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+	if d.NextAttemptAt != nil {
+		delivery.NextAttemptAt = timestamppb.New(*d.NextAttemptAt)
+	}
+	if d.DeliveredAt != nil {
+		delivery.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+	}
+	return delivery
+}
+
+func toProtoDeliveries(deliveries []*Delivery) []*pb.Delivery {
+	result := make([]*pb.Delivery, len(deliveries))
+	for i, d := range deliveries {
+		result[i] = toProtoDelivery(d)
+	}
+	return result
+}
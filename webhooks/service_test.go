@@ -0,0 +1,540 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ujjwaljain16/E-commerce-Backend/pkg/logger"
+	"github.com/Ujjwaljain16/E-commerce-Backend/webhooks/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRepository keeps endpoints, events, deliveries and their attempts for
+// the service and deliverer tests
+type memoryRepository struct {
+	mu         sync.Mutex
+	endpoints  map[string]*Endpoint
+	events     map[string]*Event
+	deliveries map[string]*Delivery
+	attempts   map[string][]*Attempt
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		endpoints:  map[string]*Endpoint{},
+		events:     map[string]*Event{},
+		deliveries: map[string]*Delivery{},
+		attempts:   map[string][]*Attempt{},
+	}
+}
+
+func (m *memoryRepository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	endpoint.ID = uuid.New().String()
+	stored := *endpoint
+	m.endpoints[endpoint.ID] = &stored
+	return endpoint, nil
+}
+
+func (m *memoryRepository) GetEndpoint(ctx context.Context, id string) (*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.endpoints[id]
+	if !ok {
+		return nil, ErrEndpointNotFound
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (m *memoryRepository) ListEndpoints(ctx context.Context) ([]*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var endpoints []*Endpoint
+	for _, e := range m.endpoints {
+		copied := *e
+		endpoints = append(endpoints, &copied)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].CreatedAt.Before(endpoints[j].CreatedAt) })
+	return endpoints, nil
+}
+
+func (m *memoryRepository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.endpoints[endpoint.ID]
+	if !ok {
+		return nil, ErrEndpointNotFound
+	}
+	e.URL, e.Description, e.EventTypes, e.Active, e.UpdatedAt = endpoint.URL, endpoint.Description, endpoint.EventTypes, endpoint.Active, endpoint.UpdatedAt
+	copied := *e
+	return &copied, nil
+}
+
+func (m *memoryRepository) SetSecret(ctx context.Context, id, secret string, updatedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.endpoints[id]
+	if !ok {
+		return ErrEndpointNotFound
+	}
+	e.Secret, e.UpdatedAt = secret, updatedAt
+	return nil
+}
+
+func (m *memoryRepository) DeleteEndpoint(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.endpoints[id]; !ok {
+		return ErrEndpointNotFound
+	}
+	delete(m.endpoints, id)
+	for deliveryID, d := range m.deliveries {
+		if d.EndpointID == id {
+			delete(m.deliveries, deliveryID)
+			delete(m.attempts, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) SubscribedEndpoints(ctx context.Context, eventType string) ([]*Endpoint, error) {
+	endpoints, _ := m.ListEndpoints(ctx)
+	var subscribed []*Endpoint
+	for _, e := range endpoints {
+		if e.Active && slices.Contains(e.EventTypes, eventType) {
+			subscribed = append(subscribed, e)
+		}
+	}
+	return subscribed, nil
+}
+
+func (m *memoryRepository) CreateForEvent(ctx context.Context, event *Event, deliveries []*Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.events[event.ID]; ok {
+		return ErrDuplicateEvent
+	}
+	m.events[event.ID] = event
+	for _, d := range deliveries {
+		d.ID = uuid.New().String()
+		stored := *d
+		m.deliveries[d.ID] = &stored
+	}
+	return nil
+}
+
+func (m *memoryRepository) ListByEvent(ctx context.Context, eventID string) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*Delivery
+	for _, d := range m.deliveries {
+		if d.EventID == eventID {
+			copied := *d
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *memoryRepository) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.deliveries[id]
+	if !ok {
+		return nil, ErrDeliveryNotFound
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (m *memoryRepository) ListDeliveries(ctx context.Context, endpointID, status string, page, pageSize int32) ([]*Delivery, int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*Delivery
+	for _, d := range m.deliveries {
+		if d.EndpointID == endpointID && (status == "" || d.Status == status) {
+			copied := *d
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) })
+	total := int32(len(deliveries))
+	start := min(int((page-1)*pageSize), len(deliveries))
+	end := min(start+int(pageSize), len(deliveries))
+	return deliveries[start:end], total, nil
+}
+
+func (m *memoryRepository) Attempts(ctx context.Context, deliveryID string) ([]*Attempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.attempts[deliveryID]), nil
+}
+
+func (m *memoryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []*Delivery
+	for _, d := range m.deliveries {
+		e := m.endpoints[d.EndpointID]
+		if d.Status != StatusPending || d.NextAttemptAt == nil || d.NextAttemptAt.After(now) || !e.Active || len(due) == limit {
+			continue
+		}
+		next := now.Add(lease)
+		d.NextAttemptAt = &next
+		copied := *d
+		copied.URL, copied.Secret, copied.Payload = e.URL, e.Secret, m.events[d.EventID].Payload
+		due = append(due, &copied)
+	}
+	return due, nil
+}
+
+func (m *memoryRepository) RecordAttempt(ctx context.Context, d *Delivery, attempt *Attempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *d
+	stored.URL, stored.Secret, stored.Payload = "", "", nil
+	m.deliveries[d.ID] = &stored
+	m.attempts[d.ID] = append(m.attempts[d.ID], attempt)
+	return nil
+}
+
+func (m *memoryRepository) Requeue(ctx context.Context, id string, now time.Time) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.deliveries[id]
+	if !ok {
+		return nil, ErrDeliveryNotFound
+	}
+	if d.Status != StatusFailed {
+		return nil, ErrNotFailed
+	}
+	d.Status, d.Attempts, d.NextAttemptAt = StatusPending, 0, &now
+	copied := *d
+	return &copied, nil
+}
+
+func (m *memoryRepository) Close() error {
+	return nil
+}
+
+// receiver is an HTTPS endpoint that records the deliveries it gets and
+// answers with status
+type receiver struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	status int
+	got    []*http.Request
+	bodies [][]byte
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	t.Helper()
+	r := &receiver{status: status}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.got = append(r.got, req)
+		r.bodies = append(r.bodies, body)
+		code := r.status
+		r.mu.Unlock()
+		w.WriteHeader(code)
+		w.Write([]byte("receiver says hi"))
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// fixedNow is the time of the service and deliverer under test
+var fixedNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(repo *memoryRepository, client *http.Client) (*Service, *Deliverer) {
+	log := logger.New("webhooks-test")
+	deliverer := NewDeliverer(repo, log)
+	deliverer.now = func() time.Time { return fixedNow }
+	if client != nil {
+		client.CheckRedirect = deliverer.client.CheckRedirect
+		client.Timeout = deliverer.client.Timeout
+		deliverer.client = client
+	}
+	service := NewService(repo, deliverer, log)
+	service.now = func() time.Time { return fixedNow }
+	return service, deliverer
+}
+
+func TestService_CreateEndpoint(t *testing.T) {
+	repo := newMemoryRepository()
+	service, _ := newTestService(repo, nil)
+
+	resp, err := service.CreateEndpoint(context.Background(), &pb.CreateEndpointRequest{
+		Url:         "https://erp.example.com/hooks",
+		Description: "ERP",
+		EventTypes:  []string{"order.paid", "order.created", "order.paid"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(resp.Secret, secretPrefix) || len(resp.Secret) != len(secretPrefix)+64 {
+		t.Errorf("Unexpected secret %q", resp.Secret)
+	}
+	e := resp.Endpoint
+	if !e.Active || e.Url != "https://erp.example.com/hooks" || !slices.Equal(e.EventTypes, []string{"order.created", "order.paid"}) {
+		t.Errorf("Unexpected endpoint %v", e)
+	}
+	if stored, _ := repo.GetEndpoint(context.Background(), e.Id); stored.Secret != resp.Secret {
+		t.Error("Expected the secret to be stored")
+	}
+
+	other, err := service.CreateEndpoint(context.Background(), &pb.CreateEndpointRequest{Url: "https://crm.example.com/", EventTypes: []string{"order.paid"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if other.Secret == resp.Secret {
+		t.Error("Expected every endpoint to get its own secret")
+	}
+}
+
+func TestService_CreateEndpoint_Invalid(t *testing.T) {
+	service, _ := newTestService(newMemoryRepository(), nil)
+
+	tests := []struct {
+		name string
+		req  *pb.CreateEndpointRequest
+	}{
+		{"no url", &pb.CreateEndpointRequest{EventTypes: []string{"order.paid"}}},
+		{"relative url", &pb.CreateEndpointRequest{Url: "/hooks", EventTypes: []string{"order.paid"}}},
+		{"plain http", &pb.CreateEndpointRequest{Url: "http://erp.example.com/hooks", EventTypes: []string{"order.paid"}}},
+		{"credentials", &pb.CreateEndpointRequest{Url: "https://user:pw@erp.example.com/hooks", EventTypes: []string{"order.paid"}}},
+		{"url too long", &pb.CreateEndpointRequest{Url: "https://erp.example.com/" + strings.Repeat("a", maxURLLength), EventTypes: []string{"order.paid"}}},
+		{"no event types", &pb.CreateEndpointRequest{Url: "https://erp.example.com/hooks"}},
+		{"unknown event type", &pb.CreateEndpointRequest{Url: "https://erp.example.com/hooks", EventTypes: []string{"order.teleported"}}},
+		{"description too long", &pb.CreateEndpointRequest{Url: "https://erp.example.com/hooks", EventTypes: []string{"order.paid"}, Description: strings.Repeat("d", 256)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.CreateEndpoint(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+
+	// Development deployments may use local http receivers
+	service.WithHTTPEndpoints()
+	if _, err := service.CreateEndpoint(context.Background(), &pb.CreateEndpointRequest{Url: "http://localhost:9000/hooks", EventTypes: []string{"order.paid"}}); err != nil {
+		t.Errorf("Expected http to be accepted, got %v", err)
+	}
+}
+
+func TestService_EndpointLifecycle(t *testing.T) {
+	repo := newMemoryRepository()
+	service, _ := newTestService(repo, nil)
+	ctx := context.Background()
+
+	created, err := service.CreateEndpoint(ctx, &pb.CreateEndpointRequest{Url: "https://erp.example.com/hooks", EventTypes: []string{"order.paid"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	id := created.Endpoint.Id
+
+	updated, err := service.UpdateEndpoint(ctx, &pb.UpdateEndpointRequest{
+		EndpointId: id,
+		Url:        "https://erp.example.com/v2/hooks",
+		EventTypes: []string{"order.paid", "order.cancelled"},
+		Active:     false,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Endpoint.Active || updated.Endpoint.Url != "https://erp.example.com/v2/hooks" || len(updated.Endpoint.EventTypes) != 2 {
+		t.Errorf("Unexpected endpoint %v", updated.Endpoint)
+	}
+
+	rotated, err := service.RotateSecret(ctx, &pb.RotateSecretRequest{EndpointId: id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rotated.Secret == created.Secret {
+		t.Error("Expected a new secret")
+	}
+	if stored, _ := repo.GetEndpoint(ctx, id); stored.Secret != rotated.Secret {
+		t.Error("Expected the new secret to be stored")
+	}
+
+	list, err := service.ListEndpoints(ctx, &pb.ListEndpointsRequest{})
+	if err != nil || len(list.Endpoints) != 1 {
+		t.Fatalf("Expected 1 endpoint, got %v, %v", list, err)
+	}
+
+	if _, err := service.DeleteEndpoint(ctx, &pb.DeleteEndpointRequest{EndpointId: id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.GetEndpoint(ctx, &pb.GetEndpointRequest{EndpointId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := service.UpdateEndpoint(ctx, &pb.UpdateEndpointRequest{EndpointId: id, Url: "https://erp.example.com/", EventTypes: []string{"order.paid"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := service.RotateSecret(ctx, &pb.RotateSecretRequest{EndpointId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestService_PublishEvent(t *testing.T) {
+	repo := newMemoryRepository()
+	service, _ := newTestService(repo, nil)
+	ctx := context.Background()
+
+	paid, _ := service.CreateEndpoint(ctx, &pb.CreateEndpointRequest{Url: "https://erp.example.com/hooks", EventTypes: []string{"order.paid"}})
+	service.CreateEndpoint(ctx, &pb.CreateEndpointRequest{Url: "https://crm.example.com/hooks", EventTypes: []string{"order.cancelled"}})
+	inactive, _ := service.CreateEndpoint(ctx, &pb.CreateEndpointRequest{Url: "https://old.example.com/hooks", EventTypes: []string{"order.paid"}})
+	service.UpdateEndpoint(ctx, &pb.UpdateEndpointRequest{EndpointId: inactive.Endpoint.Id, Url: "https://old.example.com/hooks", EventTypes: []string{"order.paid"}})
+
+	req := &pb.PublishEventRequest{EventId: "order.paid:o1", Type: "order.paid", Data: map[string]string{"order_id": "o1", "total": "42.50"}}
+	resp, err := service.PublishEvent(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Duplicate || len(resp.Deliveries) != 1 {
+		t.Fatalf("Expected 1 delivery, got %v", resp)
+	}
+	d := resp.Deliveries[0]
+	if d.EndpointId != paid.Endpoint.Id || d.Status != StatusPending || !d.NextAttemptAt.AsTime().Equal(fixedNow) {
+		t.Errorf("Unexpected delivery %v", d)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(repo.events["order.paid:o1"].Payload, &payload); err != nil {
+		t.Fatalf("Expected a JSON payload, got %v", err)
+	}
+	if payload["id"] != "order.paid:o1" || payload["type"] != "order.paid" || payload["occurred_at"] != "2026-06-01T12:00:00Z" {
+		t.Errorf("Unexpected payload %v", payload)
+	}
+	if data, _ := payload["data"].(map[string]interface{}); data["total"] != "42.50" {
+		t.Errorf("Unexpected payload data %v", payload["data"])
+	}
+
+	again, err := service.PublishEvent(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !again.Duplicate || len(again.Deliveries) != 1 || again.Deliveries[0].Id != d.Id {
+		t.Errorf("Expected the first publication's deliveries, got %v", again)
+	}
+}
+
+func TestService_PublishEvent_Invalid(t *testing.T) {
+	service, _ := newTestService(newMemoryRepository(), nil)
+
+	bigData := map[string]string{}
+	for i := 0; i <= maxDataEntries; i++ {
+		bigData[fmt.Sprintf("k%d", i)] = "v"
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.PublishEventRequest
+	}{
+		{"no event id", &pb.PublishEventRequest{Type: "order.paid"}},
+		{"event id too long", &pb.PublishEventRequest{EventId: strings.Repeat("e", 256), Type: "order.paid"}},
+		{"no type", &pb.PublishEventRequest{EventId: "e1"}},
+		{"unknown type", &pb.PublishEventRequest{EventId: "e1", Type: "order.teleported"}},
+		{"too many data entries", &pb.PublishEventRequest{EventId: "e1", Type: "order.paid", Data: bigData}},
+		{"data too large", &pb.PublishEventRequest{EventId: "e1", Type: "order.paid", Data: map[string]string{"k": strings.Repeat("v", maxDataSize)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.PublishEvent(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestService_DeliveryLog(t *testing.T) {
+	r := newReceiver(t, http.StatusInternalServerError)
+	repo := newMemoryRepository()
+	service, deliverer := newTestService(repo, r.server.Client())
+	ctx := context.Background()
+
+	endpoint, _ := service.CreateEndpoint(ctx, &pb.CreateEndpointRequest{Url: r.server.URL, EventTypes: []string{"order.paid"}})
+	published, _ := service.PublishEvent(ctx, &pb.PublishEventRequest{EventId: "order.paid:o1", Type: "order.paid"})
+	deliveryID := published.Deliveries[0].Id
+
+	if n, err := deliverer.DeliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected 1 delivery attempted, got %d, %v", n, err)
+	}
+
+	got, err := service.GetDelivery(ctx, &pb.GetDeliveryRequest{DeliveryId: deliveryID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Delivery.Status != StatusPending || got.Delivery.Attempts != 1 || got.Delivery.LastStatusCode != 500 {
+		t.Errorf("Unexpected delivery %v", got.Delivery)
+	}
+	if len(got.Attempts) != 1 || got.Attempts[0].StatusCode != 500 || !strings.Contains(got.Attempts[0].Error, "receiver says hi") {
+		t.Errorf("Unexpected attempts %v", got.Attempts)
+	}
+
+	list, err := service.ListDeliveries(ctx, &pb.ListDeliveriesRequest{EndpointId: endpoint.Endpoint.Id, Status: StatusPending})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list.Total != 1 || list.Page != 1 || list.PageSize != 10 || len(list.Deliveries) != 1 {
+		t.Errorf("Unexpected list %v", list)
+	}
+
+	if _, err := service.ListDeliveries(ctx, &pb.ListDeliveriesRequest{EndpointId: endpoint.Endpoint.Id, Status: "LOST"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+	if _, err := service.ListDeliveries(ctx, &pb.ListDeliveriesRequest{EndpointId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := service.GetDelivery(ctx, &pb.GetDeliveryRequest{DeliveryId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestService_RetryDelivery(t *testing.T) {
+	repo := newMemoryRepository()
+	service, _ := newTestService(repo, nil)
+	ctx := context.Background()
+
+	service.CreateEndpoint(ctx, &pb.CreateEndpointRequest{Url: "https://erp.example.com/hooks", EventTypes: []string{"order.paid"}})
+	published, _ := service.PublishEvent(ctx, &pb.PublishEventRequest{EventId: "order.paid:o1", Type: "order.paid"})
+	id := published.Deliveries[0].Id
+
+	if _, err := service.RetryDelivery(ctx, &pb.RetryDeliveryRequest{DeliveryId: id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a pending delivery, got %v", err)
+	}
+
+	repo.deliveries[id].Status = StatusFailed
+	repo.deliveries[id].Attempts = maxAttempts
+	repo.deliveries[id].NextAttemptAt = nil
+
+	resp, err := service.RetryDelivery(ctx, &pb.RetryDeliveryRequest{DeliveryId: id})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Delivery.Status != StatusPending || resp.Delivery.Attempts != 0 || !resp.Delivery.NextAttemptAt.AsTime().Equal(fixedNow) {
+		t.Errorf("Unexpected delivery %v", resp.Delivery)
+	}
+
+	if _, err := service.RetryDelivery(ctx, &pb.RetryDeliveryRequest{DeliveryId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// Computed independently: HMAC-SHA256("secret", "1700000000.{}")
+	got := Sign("secret", time.Unix(1700000000, 0), []byte("{}"))
+	want := "t=1700000000,v1=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"order.paid:o1"}`)
+	header := Sign("whsec_a", now, body)
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		secret string
+		now    time.Time
+		valid  bool
+	}{
+		{"valid", header, body, "whsec_a", now, true},
+		{"valid within tolerance", header, body, "whsec_a", now.Add(4 * time.Minute), true},
+		{"second signature matches", "t=1700000000,v1=00ff," + header[len("t=1700000000,"):], body, "whsec_a", now, true},
+		{"wrong secret", header, body, "whsec_b", now, false},
+		{"tampered body", header, []byte(`{"id":"order.paid:o2"}`), "whsec_a", now, false},
+		{"too old", header, body, "whsec_a", now.Add(6 * time.Minute), false},
+		{"from the future", header, body, "whsec_a", now.Add(-6 * time.Minute), false},
+		{"malformed", "v1=abc", body, "whsec_a", now, false},
+		{"empty", "", body, "whsec_a", now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.header, tt.body, tt.secret, 5*time.Minute, tt.now)
+			if tt.valid && err != nil {
+				t.Errorf("Expected the signature to verify, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+}